@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// newRecomputeAnalyticsCmd rebuilds the analytics summary of a wedding from
+// its recorded events
+func newRecomputeAnalyticsCmd() *cobra.Command {
+	var weddingFlag string
+	cmd := &cobra.Command{
+		Use:   "recompute-analytics --wedding ID",
+		Short: "Recompute the analytics summary of a wedding",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			weddingID, err := models.ParseID(weddingFlag)
+			if err != nil {
+				return errors.New("--wedding must be a wedding ID")
+			}
+
+			ctx := cmd.Context()
+			container, closeDB, err := connect(ctx)
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+
+			if err := container.Services.Analytics.RefreshWeddingAnalytics(ctx, weddingID); err != nil {
+				return err
+			}
+			return printJSON(map[string]interface{}{"wedding_id": weddingID, "recomputed": true})
+		},
+	}
+	cmd.Flags().StringVar(&weddingFlag, "wedding", "", "ID of the wedding")
+	cmd.MarkFlagRequired("wedding")
+	return cmd
+}
+
+// newCleanupAnalyticsCmd deletes the analytics events recorded before a cutoff
+func newCleanupAnalyticsCmd() *cobra.Command {
+	var days int
+	cmd := &cobra.Command{
+		Use:   "cleanup-analytics [--days N]",
+		Short: "Delete analytics data older than a number of days",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if days < 1 {
+				return errors.New("--days must be at least 1")
+			}
+
+			ctx := cmd.Context()
+			container, closeDB, err := connect(ctx)
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+
+			olderThan := time.Now().AddDate(0, 0, -days)
+			if err := container.Services.Analytics.CleanupOldAnalytics(ctx, olderThan); err != nil {
+				return err
+			}
+			return printJSON(map[string]interface{}{"deleted_before": olderThan.UTC()})
+		},
+	}
+	cmd.Flags().IntVar(&days, "days", 365, "delete events older than this many days")
+	return cmd
+}
+
+// newApplyRetentionCmd deletes the analytics events and audit logs past their
+// retention period, as the API's retention scheduler does
+func newApplyRetentionCmd() *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "apply-retention [--dry-run]",
+		Short: "Delete analytics events and audit logs past their retention period",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			container, closeDB, err := connect(ctx)
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+
+			retention := container.Services.Retention
+			if !dryRun {
+				if err := retention.SyncIndexes(ctx); err != nil {
+					return err
+				}
+			}
+			report, err := retention.Run(ctx, dryRun)
+			if err != nil {
+				return err
+			}
+			return printJSON(report)
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only count the data that would be deleted")
+	return cmd
+}
@@ -0,0 +1,132 @@
+// Command admin runs operator tasks against an environment, through the same
+// services and repositories as the API, configured from the same environment:
+//
+//	go run ./cmd/admin <command> [flags]
+//
+// Run it with --help to list the commands, and with --help after a command to
+// see its flags. Results are printed to stdout as JSON.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/app"
+	"wedding-invitation-backend/internal/config"
+	"wedding-invitation-backend/pkg/database"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := newRootCmd().ExecuteContext(ctx)
+	stop()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// newRootCmd creates the tool's command with all of its subcommands
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "admin",
+		Short: "Run operator tasks against an environment",
+		// Failing tasks print their error, not the usage
+		SilenceUsage: true,
+	}
+	root.AddCommand(
+		newCreateAdminCmd(),
+		newRecomputeAnalyticsCmd(),
+		newCleanupAnalyticsCmd(),
+		newApplyRetentionCmd(),
+		newRegenerateThumbnailsCmd(),
+		newMigrateStorageCmd(),
+		newExportWeddingCmd(),
+		newImportWeddingCmd(),
+		newMigrateStatusCmd(),
+		newMigrateUpCmd(),
+		newMigrateDownCmd(),
+	)
+	return root
+}
+
+// connect builds the application against the configured database. The
+// returned function closes the connection.
+func connect(ctx context.Context) (*app.Container, func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logger, err := newLogger(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	db, err := database.NewMongoDB(&cfg.Database)
+	if err != nil {
+		return nil, nil, err
+	}
+	closeDB := func() {
+		db.Close(context.Background())
+		logger.Sync()
+	}
+
+	indexCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	err = db.EnsureIndexes(indexCtx)
+	cancel()
+	if err != nil {
+		closeDB()
+		return nil, nil, fmt.Errorf("failed to ensure indexes: %w", err)
+	}
+
+	migrateCtx, cancel := context.WithTimeout(ctx, database.MigrationTimeout)
+	err = db.Migrate(migrateCtx)
+	cancel()
+	if err != nil {
+		closeDB()
+		return nil, nil, err
+	}
+
+	if cfg.Database.Driver == database.DriverPostgres {
+		if err := database.MigratePostgres(&cfg.Database); err != nil {
+			closeDB()
+			return nil, nil, fmt.Errorf("failed to migrate PostgreSQL: %w", err)
+		}
+	}
+
+	container, err := app.NewContainer(cfg, logger, db.Database)
+	if err != nil {
+		closeDB()
+		return nil, nil, fmt.Errorf("failed to build application: %w", err)
+	}
+	return container, closeDB, nil
+}
+
+// newLogger logs warnings and errors only, so that they do not drown the
+// command's output
+func newLogger(cfg *config.Config) (*zap.Logger, error) {
+	zapConfig := zap.NewDevelopmentConfig()
+	if cfg.IsProduction() {
+		zapConfig = zap.NewProductionConfig()
+	}
+	zapConfig.Level = zap.NewAtomicLevelAt(zap.WarnLevel)
+	return zapConfig.Build()
+}
+
+// printJSON prints a command's result
+func printJSON(v interface{}) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/app"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+)
+
+// mediaPageSize is how many media records are read per query
+const mediaPageSize = 100
+
+// newRegenerateThumbnailsCmd renders the thumbnails of stored images again,
+// e.g. after the thumbnail sizes or formats were changed
+func newRegenerateThumbnailsCmd() *cobra.Command {
+	var mediaFlag, userFlag string
+	var all bool
+	cmd := &cobra.Command{
+		Use:   "regenerate-thumbnails --media ID[,ID...] | --user ID | --all",
+		Short: "Render the thumbnails of stored images again",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return regenerateThumbnails(cmd.Context(), mediaFlag, userFlag, all)
+		},
+	}
+	cmd.Flags().StringVar(&mediaFlag, "media", "", "comma-separated IDs of the media")
+	cmd.Flags().StringVar(&userFlag, "user", "", "ID of the user whose media to process")
+	cmd.Flags().BoolVar(&all, "all", false, "process all media")
+	return cmd
+}
+
+// regenerateThumbnails renders the thumbnails of the given media, the media of
+// a user or all media. Media that is not an image is skipped.
+func regenerateThumbnails(ctx context.Context, mediaFlag, userFlag string, all bool) error {
+	var mediaIDs []models.ID
+	if mediaFlag != "" {
+		for _, raw := range strings.Split(mediaFlag, ",") {
+			id, err := models.ParseID(strings.TrimSpace(raw))
+			if err != nil {
+				return fmt.Errorf("invalid media ID %q", raw)
+			}
+			mediaIDs = append(mediaIDs, id)
+		}
+	}
+	var filter repository.MediaFilter
+	if userFlag != "" {
+		userID, err := models.ParseID(userFlag)
+		if err != nil {
+			return errors.New("--user must be a user ID")
+		}
+		filter.CreatedBy = &userID
+	}
+	if len(mediaIDs) == 0 && filter.CreatedBy == nil && !all {
+		return errors.New("one of --media, --user or --all is required")
+	}
+
+	container, closeDB, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	result := struct {
+		Regenerated int         `json:"regenerated"`
+		Skipped     int         `json:"skipped"`
+		Failed      []models.ID `json:"failed,omitempty"`
+	}{}
+	process := func(media *models.Media) {
+		if !media.IsImage() {
+			result.Skipped++
+			return
+		}
+		if err := container.Services.Media.GenerateThumbnails(ctx, media.ID); err != nil {
+			container.Logger.Error("Failed to regenerate thumbnails", zap.String("media_id", media.ID.String()), zap.Error(err))
+			result.Failed = append(result.Failed, media.ID)
+			return
+		}
+		result.Regenerated++
+	}
+
+	if len(mediaIDs) > 0 {
+		for _, id := range mediaIDs {
+			media, err := container.Repositories.Media.GetByID(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get media %s: %w", id, err)
+			}
+			process(media)
+		}
+		return printJSON(result)
+	}
+
+	opts := repository.ListOptions{
+		Limit: mediaPageSize,
+		Sort:  []repository.SortField{{Field: "createdAt"}, {Field: "_id"}},
+	}
+	for {
+		page, _, err := container.Repositories.Media.List(ctx, filter, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list media: %w", err)
+		}
+		for _, media := range page {
+			process(media)
+		}
+		if len(page) < mediaPageSize || ctx.Err() != nil {
+			break
+		}
+		opts.Offset += mediaPageSize
+	}
+	return printJSON(result)
+}
+
+// newMigrateStorageCmd copies stored media from one storage backend to
+// another, both configured from the STORAGE_* and UPLOAD_* settings. Switch
+// STORAGE_PROVIDER to the new backend once the migration is done.
+func newMigrateStorageCmd() *cobra.Command {
+	var from, to string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "migrate-storage --from PROVIDER --to PROVIDER [--dry-run]",
+		Short: "Copy stored media from one storage backend to another",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if from == to {
+				return errors.New("--from and --to must be different providers")
+			}
+			return migrateStorage(cmd.Context(), from, to, dryRun)
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "local", "storage provider to copy from: local or s3")
+	cmd.Flags().StringVar(&to, "to", "s3", "storage provider to copy to: local or s3")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only count the files that would be copied")
+	return cmd
+}
+
+// migrateStorage copies the stored media from one storage provider to another
+func migrateStorage(ctx context.Context, from, to string, dryRun bool) error {
+	container, closeDB, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	source, err := app.NewStorageService(container.Config, from)
+	if err != nil {
+		return fmt.Errorf("failed to set up %s storage: %w", from, err)
+	}
+	reader, ok := source.(services.ObjectReader)
+	if !ok {
+		return fmt.Errorf("%s storage cannot read files back", from)
+	}
+	destination, err := app.NewStorageService(container.Config, to)
+	if err != nil {
+		return fmt.Errorf("failed to set up %s storage: %w", to, err)
+	}
+
+	result, err := container.Services.StorageMigration.Migrate(ctx, reader, destination, dryRun)
+	if err != nil {
+		return err
+	}
+	if err := printJSON(result); err != nil {
+		return err
+	}
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("%d media could not be migrated", len(result.Failed))
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"wedding-invitation-backend/internal/config"
+	"wedding-invitation-backend/pkg/database"
+	migrations "wedding-invitation-backend/pkg/database/migrations/mongodb"
+)
+
+// newMigrateStatusCmd lists the MongoDB migrations and when they were applied
+func newMigrateStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate-status",
+		Short: "List the MongoDB migrations and whether they are applied",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openMongoDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close(context.Background())
+
+			statuses, err := migrations.Statuses(cmd.Context(), db.Database)
+			if err != nil {
+				return err
+			}
+			return printJSON(map[string]interface{}{"latest": migrations.Latest(), "migrations": statuses})
+		},
+	}
+}
+
+// newMigrateUpCmd applies the pending MongoDB migrations, up to a version if given
+func newMigrateUpCmd() *cobra.Command {
+	var to int
+	cmd := &cobra.Command{
+		Use:   "migrate-up [--to VERSION]",
+		Short: "Apply the pending MongoDB migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if to < 0 || to > migrations.Latest() {
+				return fmt.Errorf("--to must be a version between 1 and %d", migrations.Latest())
+			}
+
+			db, err := openMongoDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close(context.Background())
+
+			applied, err := migrations.Up(cmd.Context(), db.Database, to)
+			if printErr := printJSON(map[string]interface{}{"applied": applied}); printErr != nil {
+				return printErr
+			}
+			return err
+		},
+	}
+	cmd.Flags().IntVar(&to, "to", 0, "apply the migrations up to this version (default all)")
+	return cmd
+}
+
+// newMigrateDownCmd reverts the latest applied MongoDB migrations
+func newMigrateDownCmd() *cobra.Command {
+	var steps int
+	cmd := &cobra.Command{
+		Use:   "migrate-down [--steps N]",
+		Short: "Revert the latest applied MongoDB migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if steps < 1 {
+				return errors.New("--steps must be at least 1")
+			}
+
+			db, err := openMongoDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close(context.Background())
+
+			reverted, err := migrations.Down(cmd.Context(), db.Database, steps)
+			if printErr := printJSON(map[string]interface{}{"reverted": reverted}); printErr != nil {
+				return printErr
+			}
+			return err
+		},
+	}
+	cmd.Flags().IntVar(&steps, "steps", 1, "number of migrations to revert")
+	return cmd
+}
+
+// openMongoDB connects to the configured MongoDB database as it is, without
+// ensuring indexes or applying migrations like connect
+func openMongoDB() (*database.MongoDB, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return database.NewMongoDB(&cfg.Database)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// newCreateAdminCmd creates an admin account. The password is read from the
+// environment to keep it out of shell history.
+func newCreateAdminCmd() *cobra.Command {
+	var email, firstName, lastName string
+	cmd := &cobra.Command{
+		Use:   "create-admin --email EMAIL [--first-name NAME] [--last-name NAME]",
+		Short: "Create an admin account, or promote an existing one",
+		Long: "Create an admin account, or promote an existing one.\n\n" +
+			"The password is read from $ADMIN_PASSWORD; an existing account is only\n" +
+			"promoted when it is the account's password.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			container, closeDB, err := connect(ctx)
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+
+			admin, created, err := container.Services.Bootstrap.CreateAdmin(ctx, email, os.Getenv("ADMIN_PASSWORD"), firstName, lastName)
+			if err != nil {
+				return err
+			}
+			return printJSON(map[string]interface{}{
+				"user_id": admin.ID,
+				"email":   admin.Email,
+				"created": created,
+			})
+		},
+	}
+	cmd.Flags().StringVar(&email, "email", "", "email of the admin account")
+	cmd.Flags().StringVar(&firstName, "first-name", "Admin", "first name of a new account")
+	cmd.Flags().StringVar(&lastName, "last-name", "User", "last name of a new account")
+	cmd.MarkFlagRequired("email")
+	return cmd
+}
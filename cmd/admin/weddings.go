@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// newExportWeddingCmd writes a wedding with its households, guests and RSVPs
+// as a JSON bundle that import-wedding reads
+func newExportWeddingCmd() *cobra.Command {
+	var weddingFlag, out string
+	cmd := &cobra.Command{
+		Use:   "export-wedding --wedding ID [--out FILE]",
+		Short: "Export a wedding with its guests and RSVPs as JSON",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			weddingID, err := models.ParseID(weddingFlag)
+			if err != nil {
+				return errors.New("--wedding must be a wedding ID")
+			}
+			return exportWedding(cmd.Context(), weddingID, out)
+		},
+	}
+	cmd.Flags().StringVar(&weddingFlag, "wedding", "", "ID of the wedding")
+	cmd.Flags().StringVar(&out, "out", "", "file to write the bundle to (defaults to stdout)")
+	cmd.MarkFlagRequired("wedding")
+	return cmd
+}
+
+// exportWedding writes the bundle of a wedding to out, or stdout when empty
+func exportWedding(ctx context.Context, weddingID models.ID, out string) error {
+	container, closeDB, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	bundle, err := container.Services.WeddingTransfers.Export(ctx, weddingID)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		return printJSON(bundle)
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+	// The bundle holds guests' contact details and the wedding's password hash
+	return os.WriteFile(out, data, 0o600)
+}
+
+// newImportWeddingCmd creates the wedding of an exported bundle for an owner,
+// who is given by email or ID
+func newImportWeddingCmd() *cobra.Command {
+	var file, ownerFlag, slug string
+	cmd := &cobra.Command{
+		Use:   "import-wedding --file FILE --owner EMAIL|ID [--slug SLUG]",
+		Short: "Import an exported wedding for an owner",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return importWedding(cmd.Context(), file, ownerFlag, slug)
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "bundle written by export-wedding")
+	cmd.Flags().StringVar(&ownerFlag, "owner", "", "email or ID of the new owner")
+	cmd.Flags().StringVar(&slug, "slug", "", "slug of the imported wedding (defaults to the exported one)")
+	cmd.MarkFlagRequired("file")
+	cmd.MarkFlagRequired("owner")
+	return cmd
+}
+
+// importWedding imports the bundle in file for the owner
+func importWedding(ctx context.Context, file, ownerFlag, slug string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var bundle services.WeddingBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return fmt.Errorf("invalid bundle: %w", err)
+	}
+
+	container, closeDB, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	ownerID, err := models.ParseID(ownerFlag)
+	if err != nil {
+		owner, err := container.Repositories.Users.GetByEmail(ctx, strings.ToLower(ownerFlag))
+		if err != nil {
+			return fmt.Errorf("failed to find owner %s: %w", ownerFlag, err)
+		}
+		ownerID = owner.ID
+	}
+
+	wedding, err := container.Services.WeddingTransfers.Import(ctx, &bundle, ownerID, slug)
+	if err != nil {
+		return err
+	}
+	return printJSON(map[string]interface{}{
+		"wedding_id": wedding.ID,
+		"slug":       wedding.Slug,
+		"owner_id":   wedding.UserID,
+		"guests":     len(bundle.Guests),
+		"rsvps":      len(bundle.RSVPs),
+	})
+}
@@ -0,0 +1,167 @@
+// Command api serves the wedding invitation REST API.
+//
+// @title Wedding Invitation Backend API
+// @version 1.0
+// @description REST API for managing wedding invitations, guests, RSVPs, and user authentication.
+// @host localhost:8080
+// @BasePath /api/v1
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/app"
+	"wedding-invitation-backend/internal/config"
+	"wedding-invitation-backend/internal/tracing"
+	"wedding-invitation-backend/pkg/database"
+)
+
+// shutdownTimeout bounds how long in-flight requests and background work get
+// to finish on shutdown
+const shutdownTimeout = 30 * time.Second
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		fail("failed to load config: %v", err)
+	}
+
+	logger, err := newLogger(cfg)
+	if err != nil {
+		fail("failed to create logger: %v", err)
+	}
+	defer logger.Sync()
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, cfg.Tracing.ServiceName, cfg.Server.Environment)
+	if err != nil {
+		logger.Fatal("Failed to set up tracing", zap.Error(err))
+	}
+	defer flushTraces(shutdownTracing, logger)
+
+	db, err := database.NewMongoDB(&cfg.Database)
+	if err != nil {
+		logger.Fatal("Failed to connect to MongoDB", zap.Error(err))
+	}
+	defer db.Close(context.Background())
+
+	indexCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	err = db.EnsureIndexes(indexCtx)
+	cancel()
+	if err != nil {
+		logger.Fatal("Failed to ensure indexes", zap.Error(err))
+	}
+
+	migrateCtx, cancel := context.WithTimeout(context.Background(), database.MigrationTimeout)
+	err = db.Migrate(migrateCtx)
+	cancel()
+	if err != nil {
+		logger.Fatal("Failed to migrate MongoDB", zap.Error(err))
+	}
+
+	if cfg.Database.Driver == database.DriverPostgres {
+		if err := database.MigratePostgres(&cfg.Database); err != nil {
+			logger.Fatal("Failed to migrate PostgreSQL", zap.Error(err))
+		}
+	}
+
+	container, err := app.NewContainer(cfg, logger, db.Database)
+	if err != nil {
+		logger.Fatal("Failed to build application", zap.Error(err))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// The workers outlive the signal so that they can drain their queues on shutdown
+	container.Start(context.Background())
+
+	go reloadOnHangup(ctx, container, logger)
+
+	server := &http.Server{
+		Addr:         ":" + cfg.Server.Port,
+		Handler:      container.Router(),
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Info("Starting API server", zap.String("addr", server.Addr), zap.String("environment", cfg.Server.Environment))
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+
+	select {
+	case err := <-serverErr:
+		if err != nil {
+			logger.Error("API server failed", zap.Error(err))
+		}
+	case <-ctx.Done():
+		logger.Info("Shutting down API server")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Failed to shut down API server gracefully", zap.Error(err))
+	}
+	logger.Info("Waiting for background work")
+	if err := container.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Failed to finish background work", zap.Error(err))
+	}
+}
+
+// reloadOnHangup reloads the configuration on SIGHUP and applies the settings
+// that can change while the server runs. An invalid configuration is ignored,
+// keeping the current settings.
+func reloadOnHangup(ctx context.Context, container *app.Container, logger *zap.Logger) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+			cfg, err := config.Load()
+			if err != nil {
+				logger.Error("Ignoring invalid configuration", zap.Error(err))
+				continue
+			}
+			container.Reload(cfg)
+		}
+	}
+}
+
+func newLogger(cfg *config.Config) (*zap.Logger, error) {
+	if cfg.IsProduction() {
+		return zap.NewProduction()
+	}
+	return zap.NewDevelopment()
+}
+
+// flushTraces exports the spans still buffered on shutdown
+func flushTraces(shutdown func(context.Context) error, logger *zap.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdown(ctx); err != nil {
+		logger.Error("Failed to flush traces", zap.Error(err))
+	}
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
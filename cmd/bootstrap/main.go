@@ -0,0 +1,108 @@
+// Command bootstrap provisions a new environment (admin user, default themes,
+// system config) without going through the HTTP API. It is safe to run repeatedly.
+//
+//	BOOTSTRAP_TOKEN=... BOOTSTRAP_ADMIN_PASSWORD=... go run ./cmd/bootstrap \
+//	    -admin-email admin@example.com -admin-first-name Site -admin-last-name Admin
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/config"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/repository/mongodb"
+	"wedding-invitation-backend/internal/repository/postgres"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+	"wedding-invitation-backend/pkg/database"
+)
+
+func main() {
+	var req services.BootstrapRequest
+	var registrationOpen bool
+
+	token := flag.String("token", os.Getenv("BOOTSTRAP_TOKEN"), "bootstrap token (defaults to $BOOTSTRAP_TOKEN)")
+	flag.StringVar(&req.AdminEmail, "admin-email", os.Getenv("BOOTSTRAP_ADMIN_EMAIL"), "initial admin email")
+	flag.StringVar(&req.AdminFirstName, "admin-first-name", "Admin", "initial admin first name")
+	flag.StringVar(&req.AdminLastName, "admin-last-name", "User", "initial admin last name")
+	flag.StringVar(&req.DefaultThemeID, "default-theme", "", "default theme ID (defaults to the built-in default)")
+	flag.StringVar(&req.SupportEmail, "support-email", "", "support contact email")
+	flag.BoolVar(&registrationOpen, "registration-open", true, "allow public sign-ups")
+	flag.Parse()
+
+	// Read the password from the environment to keep it out of shell history
+	req.AdminPassword = os.Getenv("BOOTSTRAP_ADMIN_PASSWORD")
+	req.RegistrationOpen = &registrationOpen
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		fail("invalid bootstrap input: %v", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fail("failed to load config: %v", err)
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		fail("failed to create logger: %v", err)
+	}
+	defer logger.Sync()
+
+	db, err := database.NewMongoDB(&cfg.Database)
+	if err != nil {
+		fail("%v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	defer db.Close(context.Background())
+
+	if err := db.EnsureIndexes(ctx); err != nil {
+		fail("failed to ensure indexes: %v", err)
+	}
+	if err := db.Migrate(ctx); err != nil {
+		fail("%v", err)
+	}
+
+	var users repository.UserRepository = mongodb.NewMongoUserRepository(db.Database)
+	if cfg.Database.Driver == database.DriverPostgres {
+		if err := database.MigratePostgres(&cfg.Database); err != nil {
+			fail("failed to migrate PostgreSQL: %v", err)
+		}
+		sqlDB, err := database.OpenPostgres(&cfg.Database)
+		if err != nil {
+			fail("%v", err)
+		}
+		defer sqlDB.Close()
+		users = postgres.NewUserRepository(sqlDB)
+	}
+
+	bootstrapService := services.NewBootstrapService(
+		users,
+		mongodb.NewSystemRepository(db.Database),
+		mongodb.NewThemeRepository(db.Database),
+		cfg.Auth.BootstrapToken,
+		logger,
+	)
+
+	result, err := bootstrapService.Bootstrap(ctx, *token, req)
+	if err != nil {
+		fail("bootstrap failed: %v", err)
+	}
+
+	out, _ := json.MarshalIndent(result, "", "  ")
+	fmt.Println(string(out))
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
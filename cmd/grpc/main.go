@@ -0,0 +1,130 @@
+// Command grpc serves the internal gRPC API (wedding, guest and RSVP services)
+// on GRPC_PORT for services deployed alongside the API. It shares the API's
+// database and token secrets; callers send an access token as
+// "authorization: Bearer <token>" metadata. Background jobs and schedulers keep
+// running in the API and worker processes.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/app"
+	"wedding-invitation-backend/internal/config"
+	"wedding-invitation-backend/internal/grpcapi"
+	"wedding-invitation-backend/internal/tracing"
+	"wedding-invitation-backend/pkg/database"
+)
+
+// shutdownTimeout is how long in-flight calls may take to finish on shutdown
+const shutdownTimeout = 30 * time.Second
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		fail("failed to load config: %v", err)
+	}
+
+	logger, err := newLogger(cfg)
+	if err != nil {
+		fail("failed to create logger: %v", err)
+	}
+	defer logger.Sync()
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, cfg.Tracing.ServiceName+"-grpc", cfg.Server.Environment)
+	if err != nil {
+		logger.Fatal("Failed to set up tracing", zap.Error(err))
+	}
+	defer flushTraces(shutdownTracing, logger)
+
+	db, err := database.NewMongoDB(&cfg.Database)
+	if err != nil {
+		logger.Fatal("Failed to connect to MongoDB", zap.Error(err))
+	}
+	defer db.Close(context.Background())
+
+	indexCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	err = db.EnsureIndexes(indexCtx)
+	cancel()
+	if err != nil {
+		logger.Fatal("Failed to ensure indexes", zap.Error(err))
+	}
+
+	migrateCtx, cancel := context.WithTimeout(context.Background(), database.MigrationTimeout)
+	err = db.Migrate(migrateCtx)
+	cancel()
+	if err != nil {
+		logger.Fatal("Failed to migrate MongoDB", zap.Error(err))
+	}
+
+	if cfg.Database.Driver == database.DriverPostgres {
+		if err := database.MigratePostgres(&cfg.Database); err != nil {
+			logger.Fatal("Failed to migrate PostgreSQL", zap.Error(err))
+		}
+	}
+
+	container, err := app.NewContainer(cfg, logger, db.Database)
+	if err != nil {
+		logger.Fatal("Failed to build application", zap.Error(err))
+	}
+
+	listener, err := net.Listen("tcp", ":"+cfg.Server.GRPCPort)
+	if err != nil {
+		logger.Fatal("Failed to listen", zap.String("port", cfg.Server.GRPCPort), zap.Error(err))
+	}
+
+	svc := container.Services
+	server := grpcapi.NewServer(svc.Weddings, svc.Guests, svc.RSVPs, container.Tokens, logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		logger.Info("Starting gRPC server", zap.String("port", cfg.Server.GRPCPort), zap.String("environment", cfg.Server.Environment))
+		if err := server.Serve(listener); err != nil {
+			logger.Fatal("Failed to serve gRPC", zap.Error(err))
+		}
+	}()
+
+	<-ctx.Done()
+	logger.Info("Shutting down gRPC server")
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		logger.Warn("gRPC calls did not finish in time, stopping")
+		server.Stop()
+	}
+}
+
+func newLogger(cfg *config.Config) (*zap.Logger, error) {
+	if cfg.IsProduction() {
+		return zap.NewProduction()
+	}
+	return zap.NewDevelopment()
+}
+
+// flushTraces exports the spans still buffered on shutdown
+func flushTraces(shutdown func(context.Context) error, logger *zap.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdown(ctx); err != nil {
+		logger.Error("Failed to flush traces", zap.Error(err))
+	}
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,121 @@
+// Command worker processes background jobs (thumbnail generation, analytics
+// reconciliation, email delivery) outside the API process. Run it alongside API
+// instances started with JOB_WORKERS_IN_API=false; any number of workers can
+// share the queue.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/app"
+	"wedding-invitation-backend/internal/config"
+	"wedding-invitation-backend/internal/tracing"
+	"wedding-invitation-backend/pkg/database"
+)
+
+// shutdownTimeout bounds how long the due jobs get to finish on shutdown
+const shutdownTimeout = 30 * time.Second
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		fail("failed to load config: %v", err)
+	}
+
+	logger, err := newLogger(cfg)
+	if err != nil {
+		fail("failed to create logger: %v", err)
+	}
+	defer logger.Sync()
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, cfg.Tracing.ServiceName+"-worker", cfg.Server.Environment)
+	if err != nil {
+		logger.Fatal("Failed to set up tracing", zap.Error(err))
+	}
+	defer flushTraces(shutdownTracing, logger)
+
+	db, err := database.NewMongoDB(&cfg.Database)
+	if err != nil {
+		logger.Fatal("Failed to connect to MongoDB", zap.Error(err))
+	}
+	defer db.Close(context.Background())
+
+	indexCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	err = db.EnsureIndexes(indexCtx)
+	cancel()
+	if err != nil {
+		logger.Fatal("Failed to ensure indexes", zap.Error(err))
+	}
+
+	migrateCtx, cancel := context.WithTimeout(context.Background(), database.MigrationTimeout)
+	err = db.Migrate(migrateCtx)
+	cancel()
+	if err != nil {
+		logger.Fatal("Failed to migrate MongoDB", zap.Error(err))
+	}
+
+	if cfg.Database.Driver == database.DriverPostgres {
+		if err := database.MigratePostgres(&cfg.Database); err != nil {
+			logger.Fatal("Failed to migrate PostgreSQL", zap.Error(err))
+		}
+	}
+
+	container, err := app.NewContainer(cfg, logger, db.Database)
+	if err != nil {
+		logger.Fatal("Failed to build application", zap.Error(err))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Only the job queue runs here; the API keeps running the schedulers. The
+	// workers outlive the signal so that they can drain the queue on shutdown.
+	jobs := container.Services.Jobs
+	logger.Info("Starting job worker", zap.Int("workers", cfg.Jobs.Workers), zap.String("environment", cfg.Server.Environment))
+	jobCtx, abort := context.WithCancel(context.Background())
+	defer abort()
+	jobs.Start(jobCtx)
+
+	<-ctx.Done()
+	logger.Info("Shutting down job worker")
+	stopped := make(chan struct{})
+	go func() {
+		jobs.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		logger.Warn("Jobs did not finish in time, aborting them")
+		abort()
+		<-stopped
+	}
+}
+
+func newLogger(cfg *config.Config) (*zap.Logger, error) {
+	if cfg.IsProduction() {
+		return zap.NewProduction()
+	}
+	return zap.NewDevelopment()
+}
+
+// flushTraces exports the spans still buffered on shutdown
+func flushTraces(shutdown func(context.Context) error, logger *zap.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdown(ctx); err != nil {
+		logger.Error("Failed to flush traces", zap.Error(err))
+	}
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
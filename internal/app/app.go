@@ -0,0 +1,62 @@
+// Package app assembles the API: it builds repositories, services and handlers from
+// configuration once, and lets each domain register its own routes and background
+// workers, so adding a subsystem does not mean editing the entry point.
+package app
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Routes are the router groups a registrar can attach handlers to
+type Routes struct {
+	// Engine is the root router, for routes outside the versioned API (health, static files)
+	Engine *gin.Engine
+	// Public is /api/v1 without authentication
+	Public *gin.RouterGroup
+	// Protected is /api/v1 for users with a valid access token
+	Protected *gin.RouterGroup
+	// Admin is /api/v1/admin for users with a valid access token and the admin role
+	Admin *gin.RouterGroup
+
+	rateLimits  map[string]gin.HandlerFunc
+	idempotency gin.HandlerFunc
+}
+
+// RateLimit returns the middleware limiting each client to the named policy,
+// e.g. services.RateLimitLogin. Unconfigured policies let every request through.
+func (r *Routes) RateLimit(policy string) gin.HandlerFunc {
+	if limit, ok := r.rateLimits[policy]; ok {
+		return limit
+	}
+	return func(c *gin.Context) { c.Next() }
+}
+
+// Idempotent returns the middleware replaying the recorded response when a
+// client retries a request with the same Idempotency-Key header
+func (r *Routes) Idempotent() gin.HandlerFunc {
+	if r.idempotency != nil {
+		return r.idempotency
+	}
+	return func(c *gin.Context) { c.Next() }
+}
+
+// RouteRegistrar plugs the routes of one domain into the API
+type RouteRegistrar interface {
+	RegisterRoutes(routes *Routes)
+}
+
+// RouteRegistrarFunc adapts a function to a RouteRegistrar
+type RouteRegistrarFunc func(routes *Routes)
+
+// RegisterRoutes calls f(routes)
+func (f RouteRegistrarFunc) RegisterRoutes(routes *Routes) {
+	f(routes)
+}
+
+// Worker is a background loop that runs for the lifetime of the server
+type Worker interface {
+	Start(ctx context.Context)
+	Stop()
+}
@@ -0,0 +1,1036 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/config"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/middleware"
+	"wedding-invitation-backend/internal/repository/mongodb"
+	"wedding-invitation-backend/internal/repository/postgres"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+	"wedding-invitation-backend/pkg/database"
+)
+
+const (
+	// tokenIssuer is the issuer claim of access and refresh tokens
+	tokenIssuer = "wedding-invitation-backend"
+	// requestLogCapacity is how many recent requests are kept for request tracing
+	requestLogCapacity = 1000
+	// metricsWebhookInterval is how often due metrics webhooks are delivered
+	metricsWebhookInterval = time.Minute
+	// tenantWebhookInterval is how often the lifecycle event outbox is drained
+	tenantWebhookInterval = 5 * time.Second
+	// analyticsReportInterval is how often due analytics digests are emailed
+	analyticsReportInterval = 15 * time.Minute
+	// reminderInterval is how often due RSVP reminder campaigns are dispatched
+	reminderInterval = time.Minute
+	// budgetReminderInterval is how often reminders of payments coming due are emailed
+	budgetReminderInterval = 15 * time.Minute
+	// analyticsReconcileInterval is how often changed analytics counters are
+	// reconciled with the raw events
+	analyticsReconcileInterval = time.Hour
+	// analyticsBenchmarkInterval is how often the platform analytics benchmarks are recomputed
+	analyticsBenchmarkInterval = 6 * time.Hour
+	// analyticsAnomalyInterval is how often the last hour's traffic and RSVPs are
+	// checked for anomalies
+	analyticsAnomalyInterval = 15 * time.Minute
+	// retentionInterval is how often data past its retention period is deleted
+	retentionInterval = 6 * time.Hour
+	// healthCheckTimeout bounds each dependency probe of the readiness check
+	healthCheckTimeout = 2 * time.Second
+	// uploadSessionPurgeInterval is how often expired resumable uploads are discarded
+	uploadSessionPurgeInterval = 15 * time.Minute
+	// accountErasureInterval is how often accounts whose deletion is due are erased
+	accountErasureInterval = time.Hour
+	// idempotencyKeyTTL is how long responses are replayed for retried requests
+	idempotencyKeyTTL = 24 * time.Hour
+)
+
+// Repositories holds the MongoDB repositories shared by all services
+type Repositories struct {
+	Users            repository.UserRepository
+	Weddings         repository.WeddingRepository
+	RSVPs            repository.RSVPRepository
+	RSVPSubmissions  repository.RSVPSubmissionRepository
+	Guests           repository.GuestRepository
+	GuestGroups      repository.GuestGroupRepository
+	PreviewLinks     repository.PreviewLinkRepository
+	WeddingRevisions repository.WeddingRevisionRepository
+	SlugRedirects    repository.SlugRedirectRepository
+	Media            repository.MediaRepository
+	Analytics        repository.AnalyticsRepository
+	AnalyticsReports repository.AnalyticsReportRepository
+	Anomalies        repository.AnalyticsAnomalyRepository
+	MetricsWebhooks  repository.MetricsWebhookRepository
+	TenantWebhooks   repository.TenantWebhookRepository
+	ExportJobs       repository.ExportJobRepository
+	Reminders        repository.ReminderRepository
+	GuestPhotos      repository.GuestPhotoRepository
+	UploadSessions   repository.UploadSessionRepository
+	Jobs             repository.JobRepository
+	System           repository.SystemRepository
+	Themes           repository.ThemeRepository
+	Registry         repository.RegistryRepository
+	Wishes           repository.WishRepository
+	SongRequests     repository.SongRequestRepository
+	Budget           repository.BudgetRepository
+	Vendors          repository.VendorRepository
+	Push             repository.PushSubscriptionRepository
+	WeddingWebhooks  repository.WeddingWebhookRepository
+	APIKeys          repository.APIKeyRepository
+	AuditLogs        repository.AuditLogRepository
+	Retention        repository.RetentionRepository
+	AbuseReports     repository.AbuseReportRepository
+	Sessions         repository.SessionRepository
+	Suppressions     repository.EmailSuppressionRepository
+	Deletions        repository.AccountDeletionRepository
+	Organizations    repository.OrganizationRepository
+}
+
+// Services holds the application services
+type Services struct {
+	Auth             services.AuthService
+	TwoFactor        *services.TwoFactorService
+	Sessions         *services.SessionService
+	Users            *services.UserService
+	Weddings         *services.WeddingService
+	PreviewLinks     *services.PreviewLinkService
+	RSVPs            *services.RSVPService
+	RSVPQueue        *services.RSVPQueueService // nil unless write-behind RSVPs are enabled
+	Guests           *services.GuestService
+	GuestGroups      *services.GuestGroupService
+	GuestMerges      *services.GuestMergeService
+	Invitations      *services.InvitationService
+	Collaborators    *services.CollaboratorService
+	GuestQRCodes     *services.GuestQRService
+	CheckIns         *services.CheckInService
+	Reminders        *services.ReminderService
+	Media            services.MediaService
+	MediaScans       *services.MediaScanService   // nil unless UPLOAD_SCAN_MODE is set
+	Files            services.ObjectOpener        // nil unless stored files are served by the API
+	MediaResize      *services.MediaResizeService // nil unless the storage backend can read uploads back
+	UploadSessions   *services.UploadSessionService
+	Gallery          *services.GuestGalleryService
+	Analytics        services.AnalyticsService
+	AnalyticsReports *services.AnalyticsReportService
+	Anomalies        *services.AnalyticsAnomalyService
+	AnalyticsExports *services.AnalyticsExportService
+	AccountExports   *services.AccountExportService
+	InvitationPDFs   *services.InvitationPDFService
+	OpenGraph        *services.OpenGraphService
+	Email            services.EmailService
+	Suppressions     *services.EmailSuppressionService
+	Deletions        *services.AccountDeletionService
+	ExportJobs       *services.ExportJobService
+	MetricsWebhooks  *services.MetricsWebhookService
+	TenantWebhooks   *services.TenantWebhookService
+	Bootstrap        *services.BootstrapService
+	StorageMigration *services.StorageMigrationService
+	WeddingTransfers *services.WeddingTransferService
+	Billing          *services.BillingService
+	Themes           *services.ThemeService
+	Registry         *services.RegistryService
+	Wishes           *services.WishService
+	Music            *services.MusicService
+	Budget           *services.BudgetService
+	Vendors          *services.VendorService
+	Push             *services.PushService
+	WeddingWebhooks  *services.WeddingWebhookService
+	APIKeys          *services.APIKeyService
+	AuditLogs        *services.AuditLogService
+	Retention        *services.RetentionService
+	Moderation       *services.WeddingModerationService
+	Jobs             *services.JobQueue
+	Health           *services.HealthService
+	Organizations    *services.OrganizationService
+	FeatureFlags     *services.FeatureFlagService
+}
+
+// Container owns every dependency of the API. Domains plug in through Register and
+// AddWorker; NewContainer registers the built-in ones.
+type Container struct {
+	Config       *config.Config
+	Logger       *zap.Logger
+	DB           *mongo.Database
+	Redis        *redis.Client // nil unless REDIS_URL is set
+	SQL          *sql.DB       // nil unless DATABASE_DRIVER is postgres
+	Repositories *Repositories
+	Services     *Services
+	// RequestLogs keeps recent request log entries for admin request tracing
+	RequestLogs *middleware.RequestLogBuffer
+	// Tokens issues access and refresh tokens and authenticates protected routes
+	Tokens *utils.JWTManager
+	// Tasks tracks work started by requests that runs in the background, so that
+	// Shutdown waits for it
+	Tasks *services.BackgroundTasks
+
+	registrars []RouteRegistrar
+	workers    []Worker
+	// abort cancels the context the workers run with
+	abort context.CancelFunc
+	// origins and rateLimitPolicies are the settings Reload applies to the
+	// running server
+	origins           *middleware.OriginSet
+	rateLimitPolicies *middleware.RateLimitPolicies
+}
+
+// NewContainer builds repositories, services and the built-in route registrars and
+// workers. It does not touch the database; workers only run after Start.
+func NewContainer(cfg *config.Config, logger *zap.Logger, db *mongo.Database) (*Container, error) {
+	c := &Container{
+		Config:      cfg,
+		Logger:      logger,
+		DB:          db,
+		RequestLogs: middleware.NewRequestLogBuffer(requestLogCapacity),
+		Tokens:      utils.NewJWTManager(cfg.Auth.JWTSecret, cfg.Auth.JWTRefreshSecret, cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL, tokenIssuer),
+		Tasks:       services.NewBackgroundTasks(logger),
+
+		origins:           middleware.NewOriginSet(cfg.Server.AllowedOrigins),
+		rateLimitPolicies: middleware.NewRateLimitPolicies(rateLimitPolicies(cfg.RateLimit)),
+	}
+
+	redisClient, err := newRedisClient(cfg.Redis)
+	if err != nil {
+		return nil, err
+	}
+	c.Redis = redisClient
+
+	sqlDB, err := newPostgresDB(cfg.Database)
+	if err != nil {
+		return nil, err
+	}
+	c.SQL = sqlDB
+
+	c.Repositories = newRepositories(db, sqlDB)
+
+	svc, err := c.newServices()
+	if err != nil {
+		return nil, err
+	}
+	c.Services = svc
+
+	c.registerDefaults()
+	return c, nil
+}
+
+// newRepositories creates the MongoDB repositories. With a PostgreSQL database,
+// users, weddings, guests and RSVPs are stored there instead.
+func newRepositories(db *mongo.Database, sqlDB *sql.DB) *Repositories {
+	repos := &Repositories{
+		Users:            mongodb.NewMongoUserRepository(db),
+		Weddings:         mongodb.NewMongoWeddingRepository(db),
+		RSVPs:            mongodb.NewMongoRSVPRepository(db),
+		RSVPSubmissions:  mongodb.NewRSVPSubmissionRepository(db),
+		Guests:           mongodb.NewGuestRepository(db),
+		GuestGroups:      mongodb.NewGuestGroupRepository(db),
+		PreviewLinks:     mongodb.NewPreviewLinkRepository(db),
+		WeddingRevisions: mongodb.NewWeddingRevisionRepository(db),
+		SlugRedirects:    mongodb.NewSlugRedirectRepository(db),
+		Media:            mongodb.NewMediaRepository(db),
+		Analytics:        mongodb.NewAnalyticsRepository(db),
+		AnalyticsReports: mongodb.NewAnalyticsReportRepository(db),
+		Anomalies:        mongodb.NewAnalyticsAnomalyRepository(db),
+		MetricsWebhooks:  mongodb.NewMetricsWebhookRepository(db),
+		TenantWebhooks:   mongodb.NewTenantWebhookRepository(db),
+		ExportJobs:       mongodb.NewExportJobRepository(db),
+		Reminders:        mongodb.NewReminderRepository(db),
+		GuestPhotos:      mongodb.NewGuestPhotoRepository(db),
+		UploadSessions:   mongodb.NewUploadSessionRepository(db),
+		Jobs:             mongodb.NewJobRepository(db),
+		System:           mongodb.NewSystemRepository(db),
+		Themes:           mongodb.NewThemeRepository(db),
+		Registry:         mongodb.NewRegistryRepository(db),
+		Wishes:           mongodb.NewWishRepository(db),
+		SongRequests:     mongodb.NewSongRequestRepository(db),
+		Budget:           mongodb.NewBudgetRepository(db),
+		Vendors:          mongodb.NewVendorRepository(db),
+		Push:             mongodb.NewPushSubscriptionRepository(db),
+		WeddingWebhooks:  mongodb.NewWeddingWebhookRepository(db),
+		APIKeys:          mongodb.NewAPIKeyRepository(db),
+		AuditLogs:        mongodb.NewAuditLogRepository(db),
+		Retention:        mongodb.NewRetentionRepository(db),
+		AbuseReports:     mongodb.NewAbuseReportRepository(db),
+		Sessions:         mongodb.NewSessionRepository(db),
+		Suppressions:     mongodb.NewEmailSuppressionRepository(db),
+		Deletions:        mongodb.NewAccountDeletionRepository(db),
+		Organizations:    mongodb.NewOrganizationRepository(db),
+	}
+	if sqlDB != nil {
+		repos.Users = postgres.NewUserRepository(sqlDB)
+		repos.Weddings = postgres.NewWeddingRepository(sqlDB)
+		repos.RSVPs = postgres.NewRSVPRepository(sqlDB)
+		repos.Guests = postgres.NewGuestRepository(sqlDB)
+		repos.Deletions = postgres.NewAccountDeletionRepository(sqlDB, repos.Deletions)
+	}
+	return repos
+}
+
+// newServices creates the services and wires their optional features
+func (c *Container) newServices() (*Services, error) {
+	cfg, repos, logger := c.Config, c.Repositories, c.Logger
+
+	mediaConfig, err := newMediaServiceConfig(cfg.Upload)
+	if err != nil {
+		return nil, err
+	}
+
+	storage, err := newStorageService(cfg, mediaConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var uploadSessionExpiry time.Duration
+	if cfg.Upload.ResumableExpiry != "" {
+		if uploadSessionExpiry, err = time.ParseDuration(cfg.Upload.ResumableExpiry); err != nil {
+			return nil, fmt.Errorf("invalid UPLOAD_RESUMABLE_EXPIRY: %w", err)
+		}
+	}
+
+	// Every email, sent directly or through the queue, skips suppressed addresses
+	emailSuppressions := services.NewEmailSuppressionService(repos.Suppressions, logger)
+	if cfg.Email.SendGridWebhookKey != "" {
+		key, err := services.ParseSendGridWebhookKey(cfg.Email.SendGridWebhookKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SENDGRID_WEBHOOK_PUBLIC_KEY: %w", err)
+		}
+		emailSuppressions.EnableSendGridWebhook(key)
+	}
+	email := services.NewSuppressedEmailService(newEmailService(cfg.Email, logger), emailSuppressions, logger)
+
+	geo, err := newGeoIPProvider(cfg.GeoIP, logger)
+	if err != nil {
+		return nil, err
+	}
+	oauthProviders, err := newOAuthProviders(cfg.OAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Thumbnails, analytics reconciliation and exports and fire-and-forget emails run as background jobs
+	jobs := services.NewJobQueue(repos.Jobs, services.JobQueueOptions{
+		Workers:      cfg.Jobs.Workers,
+		PollInterval: cfg.Jobs.PollInterval,
+		Lease:        cfg.Jobs.Lease,
+		MaxAttempts:  cfg.Jobs.MaxAttempts,
+		Backoff:      cfg.Jobs.Backoff,
+		MaxBackoff:   cfg.Jobs.MaxBackoff,
+	}, logger)
+	queuedEmail := services.NewQueuedEmailService(jobs)
+
+	tenantWebhooks := services.NewTenantWebhookService(repos.TenantWebhooks, repos.Users, logger)
+	// Wedding webhooks are delivered by background jobs, which retry failed endpoints
+	weddingWebhooks := services.NewWeddingWebhookService(repos.WeddingWebhooks, repos.Weddings, jobs, logger)
+	// New RSVPs are pushed to the browsers of the wedding's members by background jobs
+	webPush, err := newWebPushSender(cfg.WebPush, logger)
+	if err != nil {
+		return nil, err
+	}
+	push := services.NewPushService(repos.Push, repos.Users, repos.Weddings, jobs, webPush, logger)
+
+	// Changes to weddings, guests, RSVPs, media, themes and accounts are audited
+	auditLogs := services.NewAuditLogService(repos.AuditLogs, repos.Weddings, logger)
+	retention := services.NewRetentionService(repos.Retention, repos.Weddings, map[string]time.Duration{
+		models.RetentionPageViews:   cfg.Retention.PageViews,
+		models.RetentionRSVPEvents:  cfg.Retention.RSVPEvents,
+		models.RetentionConversions: cfg.Retention.Conversions,
+		models.RetentionAuditLogs:   cfg.Retention.AuditLogs,
+	}, cfg.Retention.TTLIndexes, logger)
+
+	// Logins waiting for their second factor are shared through Redis when
+	// there is more than one API instance
+	pendingLogins := services.NewMemoryPendingLoginStore()
+	if c.Redis != nil {
+		pendingLogins = services.NewRedisPendingLoginStore(c.Redis)
+	}
+	twoFactor := services.NewTwoFactorService(repos.Users, pendingLogins, cfg.Auth.TOTPIssuer, logger)
+	twoFactor.SetAuditLog(auditLogs)
+	sessions := services.NewSessionService(repos.Sessions, logger)
+	loginGuard := services.NewLoginGuard(repos.Users, queuedEmail, services.LoginGuardOptions{
+		MaxFailedAttempts: cfg.Auth.MaxFailedLogins,
+		LockDuration:      cfg.Auth.LockDuration,
+		MaxLockDuration:   cfg.Auth.MaxLockDuration,
+		SiteURL:           cfg.Email.SiteURL,
+	}, logger)
+	loginGuard.SetGeoIPProvider(geo)
+
+	weddings := services.NewWeddingService(repos.Weddings, repos.Users)
+	weddings.SetAuditLog(auditLogs)
+	weddings.SetEventPublisher(tenantWebhooks)
+	weddings.SetWebhookNotifier(weddingWebhooks)
+	weddings.SetThemeCatalog(repos.Themes)
+	weddings.EnableRevisions(repos.WeddingRevisions)
+	weddings.EnableSlugHistory(repos.SlugRedirects)
+	organizations := services.NewOrganizationService(repos.Organizations, repos.Users, repos.Weddings, logger)
+	organizations.SetEventPublisher(tenantWebhooks)
+	previewLinks := services.NewPreviewLinkService(repos.PreviewLinks, repos.Weddings, guestLinkSecret(cfg.Auth), cfg.Email.SiteURL)
+	previewLinks.SetAuditLog(auditLogs)
+
+	// Feature overrides are shared through Redis like the RSVP insights cache
+	featureOverrides := services.NewMemoryFeatureOverrideStore()
+	if c.Redis != nil {
+		featureOverrides = services.NewRedisFeatureOverrideStore(c.Redis)
+	}
+	features := services.NewFeatureFlagService(featureDefaults(cfg.Features), featureOverrides, repos.Weddings, logger)
+
+	rsvps := services.NewRSVPService(repos.RSVPs, repos.Weddings)
+	rsvps.EnableFraudScoring(services.NewRSVPFraudScorer(repos.RSVPs, repos.Guests, services.DefaultRSVPFraudConfig(), logger))
+	rsvps.EnableWebhooks(weddingWebhooks)
+	rsvps.EnablePushNotifications(push)
+	rsvps.EnableGuestLinking(repos.Guests, guestLinkSecret(cfg.Auth))
+	rsvps.EnableEditLinks(queuedEmail, guestLinkSecret(cfg.Auth), cfg.Email.SiteURL)
+	rsvps.EnableAuditLog(auditLogs)
+	rsvps.EnableFeatureFlags(features)
+	rsvpInsights := services.NewMemoryRSVPInsightsCache()
+	if c.Redis != nil {
+		rsvpInsights = services.NewRedisRSVPInsightsCache(c.Redis)
+	}
+	rsvps.EnableInsightsCache(rsvpInsights)
+
+	checkIns := services.NewCheckInService(repos.Guests, repos.Weddings, guestLinkSecret(cfg.Auth))
+	checkIns.SetWebhookNotifier(weddingWebhooks)
+	checkIns.SetGuestGroups(repos.GuestGroups)
+
+	guestQRCodes := services.NewGuestQRService(repos.Guests, repos.Weddings, guestLinkSecret(cfg.Auth), cfg.Email.SiteURL)
+	whatsapp := newWhatsAppService(cfg.WhatsApp, logger)
+
+	invitations := services.NewInvitationService(repos.Guests, repos.Weddings, email, services.InvitationOptions{
+		SiteURL: cfg.Email.SiteURL,
+		Workers: cfg.Email.InvitationWorkers,
+	}, logger)
+	// Logged WhatsApp messages would mark invitations sent that nobody received
+	if whatsAppConfigured(cfg.WhatsApp) {
+		invitations.EnableMessaging(models.InvitationChannelWhatsApp, whatsapp, services.MessageTemplate{
+			Name:     cfg.WhatsApp.InvitationTemplate,
+			Language: cfg.WhatsApp.TemplateLanguage,
+		})
+	}
+	invitations.EnableHouseholds(repos.GuestGroups)
+
+	// Reminders credit the RSVPs their guests submit afterwards
+	reminders := services.NewReminderService(repos.Reminders, repos.Guests, repos.Weddings, repos.RSVPs,
+		email, whatsapp, guestQRCodes,
+		strings.TrimRight(cfg.Server.PublicURL, "/")+"/api/v1", logger)
+	rsvps.EnableResponseTracking(reminders)
+
+	svc := &Services{
+		Auth: services.NewAuthService(repos.Users, c.Tokens, services.AuthDependencies{
+			TwoFactor:      twoFactor,
+			OAuthProviders: oauthProviders,
+			Sessions:       sessions,
+			LoginGuard:     loginGuard,
+			Email:          queuedEmail,
+			SiteURL:        cfg.Email.SiteURL,
+			Logger:         logger,
+		}),
+		TwoFactor:     twoFactor,
+		Sessions:      sessions,
+		Users:         services.NewUserService(repos.Users),
+		Weddings:      weddings,
+		PreviewLinks:  previewLinks,
+		RSVPs:         rsvps,
+		Guests:        services.NewGuestService(repos.Guests, repos.Weddings),
+		GuestGroups:   services.NewGuestGroupService(repos.GuestGroups, repos.Guests, repos.Weddings),
+		GuestMerges:   services.NewGuestMergeService(repos.Guests, repos.RSVPs, repos.GuestGroups, repos.Weddings),
+		Invitations:   invitations,
+		Collaborators: services.NewCollaboratorService(repos.Weddings, repos.Users, queuedEmail, cfg.Email.SiteURL, logger),
+		GuestQRCodes:  guestQRCodes,
+		CheckIns:      checkIns,
+		Reminders:     reminders,
+		Media: services.NewMediaServiceWithVideo(
+			repos.Media,
+			storage,
+			services.NewFileValidator(mediaConfig.AllowedTypes, mediaConfig.MaxFileSize),
+			services.NewImageProcessorWithVariants(mediaConfig.ThumbnailSizes, services.ThumbnailVariants(mediaConfig.EnableWebP, mediaConfig.EnableAVIF)),
+			newVideoTranscoder(cfg.Upload, logger),
+			jobs,
+			logger,
+			mediaConfig,
+		),
+		Analytics:        services.NewAnalyticsServiceWithGeoIP(repos.Analytics, repos.Weddings, geo, logger),
+		AnalyticsReports: services.NewAnalyticsReportService(repos.AnalyticsReports, repos.Analytics, repos.Weddings, repos.Users, queuedEmail, logger),
+		Anomalies:        services.NewAnalyticsAnomalyService(repos.Anomalies, repos.Analytics, repos.Weddings, repos.Users, queuedEmail, logger),
+		Email:            email,
+		Suppressions:     emailSuppressions,
+		ExportJobs:       services.NewExportJobService(repos.ExportJobs, repos.Weddings, logger),
+		MetricsWebhooks:  services.NewMetricsWebhookService(repos.MetricsWebhooks, repos.Weddings, repos.RSVPs, repos.Guests, repos.Analytics, logger),
+		TenantWebhooks:   tenantWebhooks,
+		Bootstrap:        services.NewBootstrapService(repos.Users, repos.System, repos.Themes, cfg.Auth.BootstrapToken, logger),
+		StorageMigration: services.NewStorageMigrationService(repos.Media, logger),
+		WeddingTransfers: services.NewWeddingTransferService(repos.Users, repos.Weddings, repos.GuestGroups, repos.Guests, repos.RSVPs, logger),
+		Themes:           services.NewThemeService(repos.Themes, repos.Weddings, logger),
+		Registry:         services.NewRegistryService(repos.Registry, repos.Weddings, logger),
+		Wishes:           services.NewWishService(repos.Wishes, repos.Weddings, logger),
+		Music:            services.NewMusicService(repos.SongRequests, repos.Weddings, logger),
+		Budget:           services.NewBudgetService(repos.Budget, repos.Weddings, repos.Users, queuedEmail, logger),
+		Vendors:          services.NewVendorService(repos.Vendors, repos.Weddings, repos.Media, logger),
+		Push:             push,
+		WeddingWebhooks:  weddingWebhooks,
+		APIKeys:          services.NewAPIKeyService(repos.APIKeys, repos.Weddings, logger),
+		AuditLogs:        auditLogs,
+		Retention:        retention,
+		Moderation:       services.NewWeddingModerationService(repos.Weddings, repos.Users, repos.AbuseReports, queuedEmail, logger),
+		Jobs:             jobs,
+		Organizations:    organizations,
+		FeatureFlags:     features,
+	}
+	svc.Wishes.SetFeatureFlags(features)
+	svc.Music.SetFeatureFlags(features)
+	svc.Users.SetAuditLog(auditLogs)
+	svc.Guests.SetAuditLog(auditLogs)
+	svc.GuestMerges.SetAuditLog(auditLogs)
+	if cfg.OAuth.GoogleClientID != "" && cfg.OAuth.GoogleClientSecret != "" {
+		// Connections to Google Contacts are shared through Redis like pending logins
+		googleTokens := services.NewMemoryGoogleContactsTokenStore()
+		if c.Redis != nil {
+			googleTokens = services.NewRedisGoogleContactsTokenStore(c.Redis)
+		}
+		svc.Guests.EnableGoogleContacts(services.NewGooglePeopleClient(cfg.OAuth.GoogleClientID, cfg.OAuth.GoogleClientSecret), googleTokens)
+	}
+	svc.Themes.SetAuditLog(auditLogs)
+	svc.Moderation.SetAuditLog(auditLogs)
+	if scanner := newMalwareScanner(cfg.Upload); scanner != nil {
+		// Set before the media service is shared, so that every upload path is scanned
+		svc.MediaScans = services.NewMediaScanService(scanner, repos.Media, repos.Users, storage, jobs, queuedEmail,
+			services.MediaScanOptions{Mode: cfg.Upload.ScanMode, BaseURL: mediaConfig.BaseURL}, logger)
+		svc.Media = services.NewScanningMediaService(svc.Media, svc.MediaScans)
+	}
+	svc.Billing = services.NewBillingService(repos.Users, repos.Weddings, repos.Guests, repos.Media,
+		cfg.Billing.StripeWebhookSecret, cfg.Billing.CheckoutURL, logger)
+	if cfg.Billing.Enabled {
+		// Set before the media service is shared, so that guest photos and
+		// resumable uploads count towards the owner's storage too
+		svc.Weddings.SetPlanLimiter(svc.Billing)
+		svc.Guests.SetPlanLimiter(svc.Billing)
+		svc.Media = services.NewPlanLimitedMediaService(svc.Media, svc.Billing)
+	}
+	svc.Media = services.NewAuditedMediaService(svc.Media, auditLogs)
+	if files, ok := storage.(services.ObjectOpener); ok && servesFiles(cfg.Storage) {
+		svc.Files = files
+	}
+	if reader, ok := storage.(services.ObjectReader); ok {
+		// Renditions are shared through Redis like the other caches, else kept on disk
+		resizeCache := services.NewDiskImageCache(cfg.Upload.ResizeCachePath)
+		if c.Redis != nil {
+			resizeCache = services.NewRedisImageCache(c.Redis, "media:resize:")
+		}
+		svc.MediaResize = services.NewMediaResizeService(repos.Media, reader, resizeCache, services.MediaResizeOptions{
+			MaxDimension: cfg.Upload.ResizeMaxDimension,
+			CacheTTL:     cfg.Upload.ResizeCacheTTL,
+		}, logger)
+	}
+	svc.UploadSessions = services.NewUploadSessionService(repos.UploadSessions, services.NewFileChunkStore(resumableUploadPath(cfg.Upload)),
+		svc.Media, mediaConfig, uploadSessionExpiry, logger)
+	svc.Gallery = services.NewGuestGalleryService(repos.GuestPhotos, repos.Weddings, svc.Media, logger)
+	svc.Gallery.SetWebhookNotifier(weddingWebhooks)
+	svc.Anomalies.SetWebhookNotifier(weddingWebhooks)
+	svc.Gallery.SetFeatureFlags(features)
+	svc.Deletions = services.NewAccountDeletionService(repos.Deletions, repos.Users, svc.Media, storage, sessions, email,
+		services.AccountDeletionOptions{SiteURL: cfg.Email.SiteURL, GracePeriod: cfg.Auth.AccountDeletionGracePeriod}, logger)
+	svc.Deletions.SetAuditLog(auditLogs)
+	svc.AnalyticsExports = services.NewAnalyticsExportService(repos.Analytics, repos.Weddings, repos.ExportJobs, svc.Analytics, storage, jobs, logger)
+	svc.AccountExports = services.NewAccountExportService(repos.Users, repos.Weddings, repos.Guests, repos.RSVPs, repos.Wishes, repos.Media,
+		repos.ExportJobs, storage, jobs, logger)
+	svc.InvitationPDFs = services.NewInvitationPDFService(repos.Weddings, repos.Guests, repos.ExportJobs, guestQRCodes, storage, jobs, logger)
+	// Link preview images are cached next to the media renditions
+	openGraphCache := services.NewDiskImageCache(filepath.Join(cfg.Upload.ResizeCachePath, "og"))
+	if c.Redis != nil {
+		openGraphCache = services.NewRedisImageCache(c.Redis, "og:image:")
+	}
+	svc.OpenGraph = services.NewOpenGraphService(openGraphCache, services.OpenGraphOptions{
+		SiteURL: cfg.Email.SiteURL,
+		APIURL:  strings.TrimRight(cfg.Server.PublicURL, "/") + "/api/v1",
+	}, logger)
+	services.RegisterJobHandlers(jobs, svc.Media, svc.Analytics, svc.AnalyticsExports, svc.AccountExports, svc.InvitationPDFs, email, weddingWebhooks, push, svc.MediaScans)
+	svc.Health = services.NewHealthService(c.healthChecks(storage), healthCheckTimeout, logger)
+
+	if cfg.RSVP.WriteBehindEnabled {
+		svc.RSVPQueue = services.NewRSVPQueueService(rsvps, repos.RSVPSubmissions, services.RSVPQueueOptions{
+			Partitions:   cfg.RSVP.QueueWorkers,
+			PollInterval: cfg.RSVP.QueuePollInterval,
+			Lease:        cfg.RSVP.QueueLease,
+			MaxAttempts:  cfg.RSVP.QueueMaxAttempts,
+			Backoff:      cfg.RSVP.QueueBackoff,
+			MaxBackoff:   cfg.RSVP.QueueMaxBackoff,
+		}, logger)
+	}
+
+	return svc, nil
+}
+
+// newRedisClient connects to Redis when REDIS_URL is set. The client dials lazily.
+func newRedisClient(cfg config.RedisConfig) (*redis.Client, error) {
+	if cfg.URL == "" {
+		return nil, nil
+	}
+	opts, err := redis.ParseURL(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REDIS_URL: %w", err)
+	}
+	if cfg.Password != "" {
+		opts.Password = cfg.Password
+	}
+	if cfg.DB != 0 {
+		opts.DB = cfg.DB
+	}
+	return redis.NewClient(opts), nil
+}
+
+// newPostgresDB opens the PostgreSQL database when DATABASE_DRIVER is postgres.
+// The pool dials lazily.
+func newPostgresDB(cfg config.DatabaseConfig) (*sql.DB, error) {
+	switch cfg.Driver {
+	case "", database.DriverMongoDB:
+		return nil, nil
+	case database.DriverPostgres:
+		return database.OpenPostgres(&cfg)
+	default:
+		return nil, fmt.Errorf("unknown DATABASE_DRIVER %q", cfg.Driver)
+	}
+}
+
+// healthChecks are the dependencies readiness requires: MongoDB, plus PostgreSQL,
+// Redis and remote storage when configured
+func (c *Container) healthChecks(storage services.StorageService) []services.HealthCheck {
+	checks := []services.HealthCheck{{
+		Name:  "mongodb",
+		Check: func(ctx context.Context) error { return c.DB.Client().Ping(ctx, readpref.Primary()) },
+	}}
+	if c.SQL != nil {
+		checks = append(checks, services.HealthCheck{Name: "postgres", Check: c.SQL.PingContext})
+	}
+	if c.Redis != nil {
+		checks = append(checks, services.HealthCheck{
+			Name:  "redis",
+			Check: func(ctx context.Context) error { return c.Redis.Ping(ctx).Err() },
+		})
+	}
+	if pinger, ok := storage.(services.StoragePinger); ok {
+		checks = append(checks, services.HealthCheck{Name: "storage", Check: pinger.Ping})
+	}
+	return checks
+}
+
+// newEmailService sends through SendGrid or an SMTP server when configured and only logs emails otherwise
+func newEmailService(cfg config.EmailConfig, logger *zap.Logger) services.EmailService {
+	switch {
+	case cfg.Provider == "sendgrid" && cfg.APIKey != "":
+		return services.NewSendGridEmailService(cfg.APIKey, cfg.From)
+	case cfg.Provider == "smtp" && cfg.SMTPHost != "":
+		return services.NewSMTPEmailService(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	}
+	logger.Warn("No email provider configured, emails will only be logged")
+	return services.NewLogEmailService(logger)
+}
+
+// newWhatsAppService sends through the WhatsApp Cloud API when configured and only logs messages otherwise
+func newWhatsAppService(cfg config.WhatsAppConfig, logger *zap.Logger) services.WhatsAppService {
+	if whatsAppConfigured(cfg) {
+		return services.NewCloudWhatsAppService(cfg.AccessToken, cfg.PhoneNumberID)
+	}
+	logger.Warn("No WhatsApp provider configured, WhatsApp reminders will only be logged")
+	return services.NewLogWhatsAppService(logger)
+}
+
+// newWebPushSender signs pushes with the configured VAPID key. Without one there is
+// no sender and Web Push notifications are unavailable.
+func newWebPushSender(cfg config.WebPushConfig, logger *zap.Logger) (services.WebPushSender, error) {
+	if cfg.VAPIDPrivateKey == "" {
+		logger.Warn("No VAPID key configured, Web Push notifications are disabled")
+		return nil, nil
+	}
+	sender, err := services.NewVAPIDSender(cfg.VAPIDPrivateKey, cfg.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure web push: %w", err)
+	}
+	return sender, nil
+}
+
+// rateLimits builds the middleware of each rate limit policy, counting requests
+// in Redis when it is configured so that every instance shares the limits. The
+// policies are looked up per request, so Reload can change them.
+func (c *Container) rateLimits() map[string]gin.HandlerFunc {
+	limiter := services.NewMemoryRateLimiter()
+	if c.Redis != nil {
+		limiter = services.NewRedisRateLimiter(c.Redis)
+	}
+	limits := make(map[string]gin.HandlerFunc)
+	add := func(name string, key middleware.RateLimitKeyFunc) {
+		limits[name] = middleware.ReloadableRateLimit(limiter, c.rateLimitPolicies, name, key, c.Logger)
+	}
+	add(services.RateLimitLogin, middleware.RateLimitByIP)
+	add(services.RateLimitPasswordReset, middleware.RateLimitByIP)
+	add(services.RateLimitAccountEmail, middleware.RateLimitByIP)
+	add(services.RateLimitPublicRSVP, middleware.RateLimitByIP)
+	add(services.RateLimitTracking, middleware.RateLimitByIP)
+	add(services.RateLimitUploads, middleware.RateLimitByUser)
+	return limits
+}
+
+// idempotency builds the middleware replaying responses for retried requests,
+// recording them in Redis when it is configured so that any instance can replay them
+func (c *Container) idempotency() gin.HandlerFunc {
+	store := services.NewMemoryIdempotencyStore()
+	if c.Redis != nil {
+		store = services.NewRedisIdempotencyStore(c.Redis)
+	}
+	return middleware.Idempotency(store, idempotencyKeyTTL, c.Logger)
+}
+
+// rateLimitPolicies maps the rate limit settings onto policies, all of them
+// disabled when rate limiting is off
+func rateLimitPolicies(cfg config.RateLimitConfig) []services.RateLimitPolicy {
+	policies := []services.RateLimitPolicy{
+		{Name: services.RateLimitLogin, Limit: cfg.LoginLimit, Window: cfg.LoginWindow},
+		{Name: services.RateLimitPasswordReset, Limit: cfg.PasswordResetLimit, Window: cfg.PasswordResetWindow},
+		{Name: services.RateLimitAccountEmail, Limit: cfg.AccountEmailLimit, Window: cfg.AccountEmailWindow},
+		{Name: services.RateLimitPublicRSVP, Limit: cfg.PublicRSVPLimit, Window: cfg.PublicRSVPWindow},
+		{Name: services.RateLimitTracking, Limit: cfg.TrackingLimit, Window: cfg.TrackingWindow},
+		{Name: services.RateLimitUploads, Limit: cfg.UploadsLimit, Window: cfg.UploadsWindow},
+	}
+	if !cfg.Enabled {
+		for i := range policies {
+			policies[i].Limit = 0
+		}
+	}
+	return policies
+}
+
+// Reload applies the settings of a reloaded configuration that can change
+// while the server runs: CORS origins, rate limits and feature defaults.
+// Changes to any other setting are logged and only take effect after a restart.
+func (c *Container) Reload(cfg *config.Config) {
+	c.origins.Store(cfg.Server.AllowedOrigins)
+	c.rateLimitPolicies.Replace(rateLimitPolicies(cfg.RateLimit))
+	c.Services.FeatureFlags.SetDefaults(featureDefaults(cfg.Features))
+	c.Logger.Info("Configuration reloaded",
+		zap.Strings("cors_origins", cfg.Server.AllowedOrigins),
+		zap.Bool("rate_limit_enabled", cfg.RateLimit.Enabled),
+	)
+	if changed := c.Config.RequiresRestart(cfg); len(changed) > 0 {
+		c.Logger.Warn("Configuration changes require a restart", zap.Strings("settings", changed))
+	}
+}
+
+// featureDefaults maps the feature settings onto the defaults of the feature flags
+func featureDefaults(cfg config.FeaturesConfig) map[string]bool {
+	return map[string]bool{
+		services.FeatureGuestPhotos:       cfg.GuestPhotos,
+		services.FeatureWishes:            cfg.Wishes,
+		services.FeatureAnalyticsTracking: cfg.AnalyticsTracking,
+		services.FeatureRSVPEditing:       cfg.RSVPEditing,
+		services.FeatureSongRequests:      cfg.SongRequests,
+	}
+}
+
+// whatsAppConfigured reports whether WhatsApp Cloud API credentials are set
+func whatsAppConfigured(cfg config.WhatsAppConfig) bool {
+	return cfg.AccessToken != "" && cfg.PhoneNumberID != ""
+}
+
+// newGeoIPProvider locates visitors with the local MaxMind database, falling
+// back to IPinfo, with whichever of them is configured
+func newGeoIPProvider(cfg config.GeoIPConfig, logger *zap.Logger) (services.GeoIPProvider, error) {
+	var providers []services.GeoIPProvider
+	if cfg.MaxMindDBPath != "" {
+		maxmind, err := services.NewMaxMindGeoIPProvider(cfg.MaxMindDBPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GEOIP_MAXMIND_DB_PATH: %w", err)
+		}
+		providers = append(providers, maxmind)
+	}
+	if cfg.IPinfoToken != "" {
+		providers = append(providers, services.NewIPinfoGeoIPProvider(cfg.IPinfoToken))
+	}
+
+	switch len(providers) {
+	case 0:
+		logger.Warn("No GeoIP provider configured, visitors will not be located")
+		return nil, nil
+	case 1:
+		return providers[0], nil
+	default:
+		return services.NewChainGeoIPProvider(providers...), nil
+	}
+}
+
+// newOAuthProviders returns the social login providers with credentials
+func newOAuthProviders(cfg config.OAuthConfig) ([]services.OAuthProvider, error) {
+	var providers []services.OAuthProvider
+	if cfg.GoogleClientID != "" && cfg.GoogleClientSecret != "" {
+		providers = append(providers, services.NewGoogleOAuthProvider(cfg.GoogleClientID, cfg.GoogleClientSecret))
+	}
+	if cfg.AppleClientID != "" && cfg.AppleTeamID != "" && cfg.AppleKeyID != "" && cfg.ApplePrivateKey != "" {
+		apple, err := services.NewAppleOAuthProvider(cfg.AppleClientID, cfg.AppleTeamID, cfg.AppleKeyID, cfg.ApplePrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid APPLE_PRIVATE_KEY: %w", err)
+		}
+		providers = append(providers, apple)
+	}
+	return providers, nil
+}
+
+// guestLinkSecret is the secret signing guest links, the JWT secret unless one is configured
+func guestLinkSecret(cfg config.AuthConfig) string {
+	if cfg.GuestLinkSecret != "" {
+		return cfg.GuestLinkSecret
+	}
+	return cfg.JWTSecret
+}
+
+// uploadSigningSecret is the secret signing links to private files, the JWT
+// secret unless one is configured
+func uploadSigningSecret(cfg *config.Config) string {
+	if cfg.Upload.SigningSecret != "" {
+		return cfg.Upload.SigningSecret
+	}
+	return cfg.Auth.JWTSecret
+}
+
+// servesFiles reports whether the API serves stored files under /uploads:
+// always for local storage, and for S3 when configured
+func servesFiles(cfg config.StorageConfig) bool {
+	return cfg.Provider == "" || cfg.Provider == "local" || cfg.ServeViaAPI
+}
+
+// newStorageService selects the media storage backend. S3 storage also becomes
+// the base URL of stored media, unless its files are served by the API.
+func newStorageService(cfg *config.Config, mediaConfig *services.MediaServiceConfig) (services.StorageService, error) {
+	switch cfg.Storage.Provider {
+	case "", "local":
+		storage := services.NewLocalStorageService(cfg.Upload.LocalPath, cfg.Upload.BaseURL)
+		storage.EnableSignedURLs(uploadSigningSecret(cfg))
+		return storage, nil
+	case "s3":
+		cdnURL := cfg.Storage.CDNURL
+		if cfg.Storage.ServeViaAPI {
+			cdnURL = cfg.Upload.BaseURL
+		}
+		storage, err := services.NewS3StorageService(&services.StorageConfig{
+			Provider:       cfg.Storage.Provider,
+			Bucket:         cfg.Storage.Bucket,
+			AccessKey:      cfg.Storage.AccessKey,
+			SecretKey:      cfg.Storage.SecretKey,
+			Region:         cfg.Storage.Region,
+			Endpoint:       cfg.Storage.Endpoint,
+			CDNURL:         cdnURL,
+			Environment:    cfg.Server.Environment,
+			UseSSL:         cfg.Storage.UseSSL,
+			ForcePathStyle: cfg.Storage.ForcePathStyle,
+		})
+		if err != nil {
+			return nil, err
+		}
+		mediaConfig.BaseURL = storage.BaseURL()
+		return storage, nil
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_PROVIDER %q", cfg.Storage.Provider)
+	}
+}
+
+// NewStorageService creates the storage backend of a provider with the
+// configured storage settings, for tools moving files from one backend to another
+func NewStorageService(cfg *config.Config, provider string) (services.StorageService, error) {
+	providerCfg := *cfg
+	providerCfg.Storage.Provider = provider
+	return newStorageService(&providerCfg, services.DefaultMediaServiceConfig())
+}
+
+// newMediaServiceConfig maps upload settings onto the media service defaults
+func newMediaServiceConfig(upload config.UploadConfig) (*services.MediaServiceConfig, error) {
+	mediaConfig := services.DefaultMediaServiceConfig()
+	if upload.MaxFileSize > 0 {
+		mediaConfig.MaxFileSize = upload.MaxFileSize
+	}
+	if upload.MaxTotalSize > 0 {
+		mediaConfig.MaxTotalSize = upload.MaxTotalSize
+	}
+	if upload.MaxFiles > 0 {
+		mediaConfig.MaxFiles = upload.MaxFiles
+	}
+	if len(upload.AllowedTypes) > 0 {
+		mediaConfig.AllowedTypes = upload.AllowedTypes
+	}
+	if upload.PresignExpiry != "" {
+		expiry, err := time.ParseDuration(upload.PresignExpiry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid UPLOAD_PRESIGN_EXPIRY: %w", err)
+		}
+		mediaConfig.PresignExpiry = expiry
+	}
+	if upload.BaseURL != "" {
+		mediaConfig.BaseURL = upload.BaseURL
+	}
+	if upload.ThumbnailSizes != "" {
+		sizes, err := config.ParseThumbnailSizes(upload.ThumbnailSizes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid UPLOAD_THUMBNAIL_SIZES: %w", err)
+		}
+		mediaConfig.ThumbnailSizes = make([]services.ThumbnailSize, len(sizes))
+		for i, size := range sizes {
+			mediaConfig.ThumbnailSizes[i] = services.ThumbnailSize{Name: size.Name, Width: size.Width, Height: size.Height}
+		}
+	}
+	mediaConfig.EnableWebP = upload.EnableWebP
+	mediaConfig.EnableAVIF = upload.EnableAVIF
+	if upload.MaxVideoSize > 0 {
+		mediaConfig.MaxVideoSize = upload.MaxVideoSize
+	}
+	if upload.MaxVideoDuration != "" {
+		duration, err := time.ParseDuration(upload.MaxVideoDuration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid UPLOAD_MAX_VIDEO_DURATION: %w", err)
+		}
+		mediaConfig.MaxVideoDuration = duration
+	}
+	return mediaConfig, nil
+}
+
+// resumableUploadPath is where chunks of resumable uploads are staged, the
+// system temporary directory unless configured
+func resumableUploadPath(upload config.UploadConfig) string {
+	if upload.ResumablePath != "" {
+		return upload.ResumablePath
+	}
+	return filepath.Join(os.TempDir(), "resumable-uploads")
+}
+
+// newVideoTranscoder returns the ffmpeg transcoder, or nil to turn video uploads
+// away when ffmpeg is not installed
+func newVideoTranscoder(upload config.UploadConfig, logger *zap.Logger) services.VideoTranscoder {
+	for _, tool := range []string{upload.FFmpegPath, upload.FFprobePath} {
+		if _, err := exec.LookPath(tool); err != nil {
+			logger.Warn("Video uploads are disabled: ffmpeg is not available", zap.String("path", tool), zap.Error(err))
+			return nil
+		}
+	}
+	return services.NewFFmpegTranscoder(upload.FFmpegPath, upload.FFprobePath)
+}
+
+// newMalwareScanner selects the scanner uploads are checked with, or nil when
+// scanning is off
+func newMalwareScanner(upload config.UploadConfig) services.MalwareScanner {
+	switch {
+	case upload.ScanMode != services.ScanModeAsync && upload.ScanMode != services.ScanModeBlocking:
+		return nil
+	case upload.ScanClamAVAddr != "":
+		return services.NewClamAVScanner(upload.ScanClamAVAddr, upload.ScanTimeout)
+	case upload.ScanAPIURL != "":
+		return services.NewHTTPScanner(upload.ScanAPIURL, upload.ScanAPIToken, upload.ScanTimeout)
+	default:
+		return nil
+	}
+}
+
+// Register adds route registrars; their routes are attached when Router is called
+func (c *Container) Register(registrars ...RouteRegistrar) {
+	c.registrars = append(c.registrars, registrars...)
+}
+
+// AddWorker adds background workers that run between Start and Stop
+func (c *Container) AddWorker(workers ...Worker) {
+	c.workers = append(c.workers, workers...)
+}
+
+// Router builds the HTTP router with the global middleware and every registered domain
+func (c *Container) Router() *gin.Engine {
+	if c.Config.IsProduction() {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.Use(otelgin.Middleware(c.Config.Tracing.ServiceName, otelgin.WithGinFilter(func(ctx *gin.Context) bool {
+		// Health checks would drown out the traces worth looking at
+		return !strings.HasPrefix(ctx.FullPath(), "/health")
+	})))
+	router.Use(middleware.RequestID())
+	router.Use(middleware.RequestLogger(c.Logger, c.RequestLogs))
+	middleware.ApplySecurityDefaults(router, c.Logger, c.Config.Server.Environment, c.origins)
+
+	v1 := router.Group("/api/v1")
+	auth := middleware.JWTAuth(c.Tokens, c.Services.Sessions)
+	// Third-party integrations may call the routes of the weddings their API key is scoped to
+	apiKeyAuth := middleware.APIKeyAuth(c.Services.APIKeys, auth, "/api/v1/weddings/:id")
+	routes := &Routes{
+		Engine:    router,
+		Public:    v1.Group(""),
+		Protected: v1.Group("", apiKeyAuth),
+		Admin:     v1.Group("/admin", auth, middleware.RequirePlatformAdmin()),
+
+		rateLimits:  c.rateLimits(),
+		idempotency: c.idempotency(),
+	}
+
+	for _, registrar := range c.registrars {
+		registrar.RegisterRoutes(routes)
+	}
+	return router
+}
+
+// Start runs the background workers until Shutdown; cancelling ctx aborts them
+func (c *Container) Start(ctx context.Context) {
+	ctx, c.abort = context.WithCancel(ctx)
+	for _, worker := range c.workers {
+		worker.Start(ctx)
+	}
+}
+
+// Shutdown waits for the background tasks, then stops the background workers in
+// reverse order, letting them finish their in-flight work and drain their queues.
+// Work still running when ctx is done is cancelled and Shutdown returns an error.
+// The Redis and PostgreSQL connections are closed last.
+func (c *Container) Shutdown(ctx context.Context) error {
+	err := c.Tasks.Shutdown(ctx)
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for i := len(c.workers) - 1; i >= 0; i-- {
+			c.workers[i].Stop()
+		}
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		if c.abort != nil {
+			c.abort()
+		}
+		<-stopped
+		err = errors.Join(err, fmt.Errorf("background workers did not finish in time: %w", ctx.Err()))
+	}
+
+	if c.Redis != nil {
+		if err := c.Redis.Close(); err != nil {
+			c.Logger.Warn("Failed to close Redis connection", zap.Error(err))
+		}
+	}
+	if c.SQL != nil {
+		if err := c.SQL.Close(); err != nil {
+			c.Logger.Warn("Failed to close PostgreSQL connection", zap.Error(err))
+		}
+	}
+	return err
+}
+
+// Stop shuts down without a deadline, waiting for all background work to finish
+func (c *Container) Stop() {
+	_ = c.Shutdown(context.Background())
+}
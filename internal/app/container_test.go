@@ -0,0 +1,464 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/config"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/repository/postgres"
+	"wedding-invitation-backend/internal/services"
+)
+
+// newTestContainer builds a container against a client that is never used; the
+// driver only dials on the first operation
+func newTestContainer(t *testing.T, cfg *config.Config) *Container {
+	gin.SetMode(gin.TestMode)
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://localhost:27017"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Disconnect(context.Background()) })
+
+	container, err := NewContainer(cfg, zap.NewNop(), client.Database("app_test"))
+	require.NoError(t, err)
+	// Access tokens are checked against their session on every request
+	container.Services.Sessions = services.NewSessionService(testSessionRepository{}, zap.NewNop())
+	return container
+}
+
+// testSessionRepository keeps sessions in memory for the access tokens tests issue
+type testSessionRepository map[models.ID]*models.Session
+
+func (r testSessionRepository) Create(ctx context.Context, session *models.Session) error {
+	r[session.ID] = session
+	return nil
+}
+
+func (r testSessionRepository) GetByID(ctx context.Context, id models.ID) (*models.Session, error) {
+	session, ok := r[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return session, nil
+}
+
+func (r testSessionRepository) ListActiveByUser(ctx context.Context, userID models.ID, now time.Time) ([]*models.Session, error) {
+	return nil, nil
+}
+
+func (r testSessionRepository) Rotate(ctx context.Context, session *models.Session, previousTokenID string) error {
+	return repository.ErrNotFound
+}
+
+func (r testSessionRepository) Delete(ctx context.Context, userID, id models.ID) error {
+	delete(r, id)
+	return nil
+}
+
+func (r testSessionRepository) DeleteByUser(ctx context.Context, userID models.ID, except *models.ID) (int64, error) {
+	return 0, nil
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
+		Server: config.ServerConfig{Environment: "test"},
+		Auth: config.AuthConfig{
+			JWTSecret:        "test-secret",
+			JWTRefreshSecret: "test-refresh-secret",
+			AccessTokenTTL:   15 * time.Minute,
+			RefreshTokenTTL:  time.Hour,
+		},
+		Upload: config.UploadConfig{PresignExpiry: "15m"},
+	}
+}
+
+func TestContainer_RegistersDomainRoutes(t *testing.T) {
+	router := newTestContainer(t, testConfig()).Router()
+
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+
+	for _, route := range []string{
+		"GET /health",
+		"GET /health/live",
+		"GET /health/ready",
+		"POST /api/v1/auth/login",
+		"POST /api/v1/auth/2fa/login",
+		"POST /api/v1/auth/oauth/:provider",
+		"POST /api/v1/auth/unlock",
+		"POST /api/v1/auth/resend-verification",
+		"POST /api/v1/auth/confirm-deletion",
+		"GET /api/v1/users/sessions",
+		"DELETE /api/v1/users/sessions",
+		"DELETE /api/v1/users/sessions/:id",
+		"DELETE /api/v1/users/me",
+		"DELETE /api/v1/users/me/deletion",
+		"POST /api/v1/users/me/export",
+		"GET /api/v1/users/me/export/:id",
+		"GET /api/v1/users/me/export/:id/download",
+		"POST /api/v1/auth/2fa/setup",
+		"POST /api/v1/auth/2fa/verify",
+		"POST /api/v1/weddings",
+		"GET /api/v1/public/weddings",
+		"GET /api/v1/public/weddings/slug/:slug",
+		"GET /api/v1/public/weddings/slug/:slug/calendar.ics",
+		"POST /api/v1/public/weddings/:id/rsvp",
+		"GET /api/v1/public/rsvp/:token",
+		"PUT /api/v1/public/rsvp/:token",
+		"GET /api/v1/weddings/:id/rsvps/review",
+		"GET /api/v1/weddings/:id/rsvps/meal-report",
+		"POST /api/v1/weddings/:id/rsvps/export",
+		"GET /api/v1/weddings/:id/exports",
+		"GET /api/v1/weddings/:id/guests",
+		"POST /api/v1/upload",
+		"PATCH /api/v1/upload/resumable/:id",
+		"GET /api/v1/billing",
+		"POST /api/v1/webhooks/stripe",
+		"GET /api/v1/themes",
+		"POST /api/v1/admin/themes",
+		"DELETE /api/v1/admin/themes/:id",
+		"POST /api/v1/public/weddings/:id/gallery",
+		"GET /api/v1/weddings/:id/gallery/pending",
+		"GET /api/v1/public/weddings/slug/:slug/registry",
+		"POST /api/v1/public/weddings/slug/:slug/registry/pledges",
+		"PUT /api/v1/weddings/:id/registry/pledges/:pledge_id",
+		"POST /api/v1/public/weddings/slug/:slug/wishes",
+		"POST /api/v1/weddings/:id/wishes/:wish_id/hide",
+		"POST /api/v1/public/weddings/slug/:slug/song-requests",
+		"GET /api/v1/weddings/:id/song-requests/export",
+		"POST /api/v1/weddings/:id/song-requests/:request_id/reject",
+		"GET /api/v1/weddings/:id/budget",
+		"PUT /api/v1/weddings/:id/budget/payments/:payment_id",
+		"GET /api/v1/weddings/:id/vendors",
+		"DELETE /api/v1/weddings/:id/vendors/:vendor_id",
+		"POST /api/v1/users/push/subscriptions",
+		"PUT /api/v1/users/notifications",
+		"POST /api/v1/weddings/:id/webhooks",
+		"GET /api/v1/weddings/:id/webhooks/:webhook_id/deliveries",
+		"POST /api/v1/users/api-keys",
+		"GET /api/v1/users/api-keys/:id/usage",
+		"GET /api/v1/weddings/:id/analytics",
+		"GET /api/v1/admin/requests/:request_id/trace",
+		"GET /api/v1/weddings/:id/audit-logs",
+		"GET /api/v1/admin/audit-logs",
+		"POST /api/v1/public/weddings/slug/:slug/report",
+		"GET /api/v1/admin/weddings",
+		"GET /api/v1/admin/weddings/:id",
+		"POST /api/v1/admin/weddings/:id/unpublish",
+		"POST /api/v1/admin/weddings/:id/flag",
+		"DELETE /api/v1/admin/weddings/:id/flag",
+		"POST /api/v1/admin/weddings/:id/takedown",
+		"POST /api/v1/admin/weddings/:id/restore",
+		"GET /api/v1/admin/abuse-reports",
+		"POST /api/v1/admin/abuse-reports/:id/close",
+		"POST /api/v1/webhooks/email/sendgrid",
+		"GET /api/v1/admin/email-suppressions",
+		"POST /api/v1/admin/email-suppressions",
+		"DELETE /api/v1/admin/email-suppressions/:email",
+		"POST /api/v1/tenant/webhooks/secret/rotate",
+		"POST /api/v1/system/bootstrap",
+		"GET /api/v1/weddings/:id/features",
+		"GET /api/v1/public/weddings/:id/features",
+		"PUT /api/v1/admin/features/:feature",
+		"DELETE /api/v1/admin/weddings/:id/features/:feature",
+	} {
+		assert.True(t, registered[route], "expected route %s", route)
+	}
+}
+
+func TestContainer_WriteBehindWorker(t *testing.T) {
+	cfg := testConfig()
+	assert.Nil(t, newTestContainer(t, cfg).Services.RSVPQueue)
+
+	cfg.RSVP.WriteBehindEnabled = true
+	container := newTestContainer(t, cfg)
+	assert.NotNil(t, container.Services.RSVPQueue)
+	assert.Contains(t, container.workers, Worker(container.Services.RSVPQueue))
+}
+
+func TestContainer_InvalidUploadConfig(t *testing.T) {
+	cfg := testConfig()
+	cfg.Upload.PresignExpiry = "soon"
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://localhost:27017"))
+	require.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	_, err = NewContainer(cfg, zap.NewNop(), client.Database("app_test"))
+	assert.Error(t, err)
+}
+
+func TestContainer_StorageProvider(t *testing.T) {
+	cfg := testConfig()
+	cfg.Upload.LocalPath = "./uploads"
+	cfg.Storage = config.StorageConfig{Provider: "s3", Bucket: "photos", Region: "us-east-1", UseSSL: true}
+
+	router := newTestContainer(t, cfg).Router()
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+	assert.True(t, registered["POST /api/v1/upload/multipart"])
+	assert.False(t, registered["GET /uploads/*filepath"], "S3 storage must not serve the local upload directory")
+
+	cfg.Storage.ServeViaAPI = true
+	container := newTestContainer(t, cfg)
+	assert.NotNil(t, container.Services.Files)
+	registered = make(map[string]bool)
+	for _, route := range container.Router().Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+	assert.True(t, registered["GET /uploads/*filepath"], "S3 files are served by the API when configured")
+
+	cfg.Storage.Provider = "ftp"
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://localhost:27017"))
+	require.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	_, err = NewContainer(cfg, zap.NewNop(), client.Database("app_test"))
+	assert.ErrorContains(t, err, "STORAGE_PROVIDER")
+}
+
+func TestContainer_HealthChecks(t *testing.T) {
+	names := func(container *Container) []string {
+		var names []string
+		for _, check := range container.healthChecks(nil) {
+			names = append(names, check.Name)
+		}
+		return names
+	}
+
+	cfg := testConfig()
+	container := newTestContainer(t, cfg)
+	assert.Nil(t, container.Redis)
+	assert.Equal(t, []string{"mongodb"}, names(container))
+
+	cfg.Redis.URL = "redis://localhost:6379/1"
+	container = newTestContainer(t, cfg)
+	require.NotNil(t, container.Redis)
+	assert.Equal(t, 1, container.Redis.Options().DB)
+	assert.Equal(t, []string{"mongodb", "redis"}, names(container))
+	container.Stop()
+
+	cfg.Redis.URL = "localhost:6379"
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://localhost:27017"))
+	require.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	_, err = NewContainer(cfg, zap.NewNop(), client.Database("app_test"))
+	assert.ErrorContains(t, err, "REDIS_URL")
+}
+
+func TestContainer_DatabaseDriver(t *testing.T) {
+	cfg := testConfig()
+	cfg.Database.Driver = "postgres"
+	cfg.Database.PostgresURL = "postgres://localhost:5432/app_test"
+	container := newTestContainer(t, cfg)
+	require.NotNil(t, container.SQL)
+	assert.IsType(t, &postgres.UserRepository{}, container.Repositories.Users)
+	assert.IsType(t, &postgres.AccountDeletionRepository{}, container.Repositories.Deletions)
+	checks := container.healthChecks(nil)
+	require.Len(t, checks, 2)
+	assert.Equal(t, "postgres", checks[1].Name)
+	container.Stop()
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://localhost:27017"))
+	require.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	cfg.Database.PostgresURL = ""
+	_, err = NewContainer(cfg, zap.NewNop(), client.Database("app_test"))
+	assert.ErrorContains(t, err, "POSTGRES_URL")
+
+	cfg.Database.Driver = "mysql"
+	_, err = NewContainer(cfg, zap.NewNop(), client.Database("app_test"))
+	assert.ErrorContains(t, err, "DATABASE_DRIVER")
+}
+
+func TestContainer_RegisterCustomDomain(t *testing.T) {
+	container := newTestContainer(t, testConfig())
+	container.Register(RouteRegistrarFunc(func(routes *Routes) {
+		routes.Public.GET("/ping", func(c *gin.Context) {
+			c.String(http.StatusOK, "pong")
+		})
+	}))
+
+	w := httptest.NewRecorder()
+	container.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/ping", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "pong", w.Body.String())
+	assert.NotEmpty(t, w.Header().Get("X-Request-ID"))
+}
+
+func TestGuestRoutes_AliasWeddingParam(t *testing.T) {
+	container := newTestContainer(t, testConfig())
+	router := container.Router()
+
+	// A valid wedding ID must reach the guest handler under :wedding_id, so the request
+	// fails on the missing body rather than on the ID
+	w := httptest.NewRecorder()
+	path := "/api/v1/weddings/" + models.NewID().String() + "/guests"
+	req := httptest.NewRequest(http.MethodPost, path, nil)
+	req.Header.Set("Authorization", "Bearer "+testAccessToken(t, container, "user"))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Invalid request data")
+}
+
+func TestContainer_ProtectedRoutesRequireToken(t *testing.T) {
+	container := newTestContainer(t, testConfig())
+	router := container.Router()
+
+	serve := func(path, token string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve("/api/v1/auth/me", ""))
+	assert.Equal(t, http.StatusUnauthorized, serve("/api/v1/auth/me", "not-a-token"))
+	assert.Equal(t, http.StatusUnauthorized, serve("/api/v1/admin/users", ""))
+	assert.Equal(t, http.StatusForbidden, serve("/api/v1/admin/users", testAccessToken(t, container, "user")))
+	// Public routes stay open
+	assert.Equal(t, http.StatusBadRequest, serve("/api/v1/auth/verify-email", ""))
+
+	// Protected routes also accept API keys, admin routes do not
+	serveAPIKey := func(path, key string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-API-Key", key)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	assert.Equal(t, http.StatusUnauthorized, serveAPIKey("/api/v1/weddings/"+models.NewID().String(), "not-a-key"))
+	assert.Equal(t, http.StatusUnauthorized, serveAPIKey("/api/v1/admin/users", "not-a-key"))
+}
+
+// testAccessToken issues an access token for a new user with the given role,
+// bound to a session of its own
+func testAccessToken(t *testing.T, container *Container, role string) string {
+	userID, sessionID := models.NewID(), models.NewID()
+	tokens, err := container.Tokens.GenerateSessionTokenPair(userID, "jane@example.com", []string{role}, "", sessionID.String())
+	require.NoError(t, err)
+	require.NoError(t, container.Services.Sessions.Start(context.Background(), sessionID, userID, tokens.RefreshTokenID, tokens.RefreshExpiresAt))
+	return tokens.AccessToken
+}
+
+func TestContainer_Reload(t *testing.T) {
+	container := newTestContainer(t, testConfig())
+	router := container.Router()
+
+	login := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Rate limiting is off and only the default local origins are allowed
+	w := login()
+	assert.Empty(t, w.Header().Get("X-RateLimit-Limit"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	cfg := testConfig()
+	cfg.Server.AllowedOrigins = []string{"https://app.example.com"}
+	cfg.RateLimit = config.RateLimitConfig{Enabled: true, LoginLimit: 1, LoginWindow: time.Minute}
+	container.Reload(cfg)
+
+	w = login()
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, http.StatusTooManyRequests, login().Code)
+}
+
+func TestContainer_AccountEmailRateLimit(t *testing.T) {
+	cfg := testConfig()
+	cfg.RateLimit = config.RateLimitConfig{Enabled: true, AccountEmailLimit: 1, AccountEmailWindow: time.Minute}
+	router := newTestContainer(t, cfg).Router()
+
+	post := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Resending verification emails and guessing unlock tokens count against one limit per IP
+	w := post("/api/v1/auth/resend-verification")
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Limit"))
+	assert.NotEqual(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, http.StatusTooManyRequests, post("/api/v1/auth/unlock").Code)
+}
+
+// blockingWorker records when it is stopped and, when stuck, only exits once
+// the context it runs with is cancelled
+type blockingWorker struct {
+	name    string
+	stuck   bool
+	stopped *[]string
+	ctx     context.Context
+}
+
+func (w *blockingWorker) Start(ctx context.Context) { w.ctx = ctx }
+
+func (w *blockingWorker) Stop() {
+	if w.stuck {
+		<-w.ctx.Done()
+	}
+	*w.stopped = append(*w.stopped, w.name)
+}
+
+func TestContainer_Shutdown(t *testing.T) {
+	newContainer := func() *Container {
+		return &Container{Logger: zap.NewNop(), Tasks: services.NewBackgroundTasks(zap.NewNop())}
+	}
+
+	t.Run("waits for tasks and stops workers in reverse order", func(t *testing.T) {
+		var stopped []string
+		container := newContainer()
+		container.AddWorker(&blockingWorker{name: "first", stopped: &stopped}, &blockingWorker{name: "second", stopped: &stopped})
+		container.Start(context.Background())
+
+		taskDone := false
+		container.Tasks.Go("email", func(ctx context.Context) {
+			time.Sleep(10 * time.Millisecond)
+			taskDone = true
+		})
+
+		require.NoError(t, container.Shutdown(context.Background()))
+		assert.True(t, taskDone)
+		assert.Equal(t, []string{"second", "first"}, stopped)
+	})
+
+	t.Run("aborts workers that do not finish in time", func(t *testing.T) {
+		var stopped []string
+		container := newContainer()
+		container.AddWorker(&blockingWorker{name: "stuck", stuck: true, stopped: &stopped})
+		container.Start(context.Background())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, container.Shutdown(ctx), context.DeadlineExceeded)
+		assert.Equal(t, []string{"stuck"}, stopped)
+	})
+}
@@ -0,0 +1,798 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/handlers"
+	"wedding-invitation-backend/internal/middleware"
+	"wedding-invitation-backend/internal/services"
+)
+
+// registerDefaults wires the built-in domains and their background workers
+func (c *Container) registerDefaults() {
+	svc := c.Services
+	auditLog := svc.AuditLogs
+
+	accountHandler := handlers.NewAccountHandler(svc.Auth)
+	accountHandler.EnableAuthCookies(handlers.AuthCookieConfig{
+		Secure:      c.Config.IsProduction(),
+		RefreshTTL:  c.Config.Auth.RefreshTokenTTL,
+		RefreshPath: "/api/v1/auth",
+	})
+
+	userHandler := handlers.NewUserHandler(svc.Users)
+	userHandler.EnablePIIReveal(auditLog)
+
+	rsvpHandler := handlers.NewRSVPHandler(svc.RSVPs)
+	rsvpHandler.EnablePIIReveal(auditLog)
+	rsvpHandler.EnableExportJobs(svc.ExportJobs)
+	if svc.RSVPQueue != nil {
+		rsvpHandler.EnableWriteBehind(svc.RSVPQueue)
+	}
+
+	publicHandler := handlers.NewPublicHandler(svc.Weddings, svc.RSVPs)
+	publicHandler.EnableEditLinks(svc.RSVPs)
+	publicHandler.EnablePreviews(svc.PreviewLinks)
+	publicHandler.EnableSlugRedirects(svc.Weddings)
+	publicHandler.EnableBranding(svc.Organizations)
+	publicHandler.EnableOpenGraph(svc.OpenGraph)
+
+	graphqlHandler := handlers.NewGraphQLHandler(svc.Weddings, svc.Guests, svc.RSVPs, svc.Analytics, svc.Media, svc.Auth, c.Logger)
+	graphqlHandler.EnablePIIReveal(auditLog)
+
+	organizationHandler := handlers.NewOrganizationHandler(svc.Organizations)
+	organizationHandler.EnablePIIReveal(auditLog)
+
+	guestHandler := handlers.NewGuestHandler(svc.Guests)
+	guestHandler.EnablePIIReveal(auditLog)
+	guestHandler.EnableExportJobs(svc.ExportJobs)
+
+	// Locally stored files are served by the API; S3 serves its own unless configured otherwise
+	var fileHandler *handlers.FileHandler
+	if svc.Files != nil {
+		fileHandler = handlers.NewFileHandler(svc.Files, handlers.FileConfig{
+			SigningSecret:   uploadSigningSecret(c.Config),
+			PrivatePrefixes: c.Config.Upload.PrivatePrefixes,
+			MaxAge:          c.Config.Upload.CacheMaxAge,
+		}, c.Logger)
+	}
+
+	var resizeHandler *handlers.MediaResizeHandler
+	if svc.MediaResize != nil {
+		resizeHandler = handlers.NewMediaResizeHandler(svc.MediaResize, c.Config.Upload.CacheMaxAge)
+	}
+
+	uploadHandler := handlers.NewUploadHandler(svc.Media, c.Logger)
+	uploadHandler.EnableResumableUploads(svc.UploadSessions)
+
+	analyticsHandler := handlers.NewAnalyticsHandler(svc.Analytics, svc.Weddings)
+	analyticsHandler.SetStreamOrigins(c.origins.Load)
+	analyticsHandler.SetFeatureFlags(svc.FeatureFlags)
+
+	c.Register(
+		&systemRoutes{health: handlers.NewHealthHandler(svc.Health), bootstrap: handlers.NewBootstrapHandler(svc.Bootstrap)},
+		&authRoutes{
+			accounts:  accountHandler,
+			twoFactor: handlers.NewTwoFactorHandler(svc.TwoFactor),
+			sessions:  handlers.NewSessionHandler(svc.Sessions),
+			users:     userHandler,
+			deletions: handlers.NewAccountDeletionHandler(svc.Deletions),
+			exports:   handlers.NewAccountExportHandler(svc.AccountExports),
+		},
+		&weddingRoutes{
+			weddings:      handlers.NewWeddingHandler(svc.Weddings),
+			public:        publicHandler,
+			collaborators: handlers.NewCollaboratorHandler(svc.Collaborators),
+			previews:      handlers.NewPreviewLinkHandler(svc.PreviewLinks),
+			revisions:     handlers.NewWeddingRevisionHandler(svc.Weddings),
+			pdfs:          handlers.NewInvitationPDFHandler(svc.InvitationPDFs),
+
+			requireVerifiedEmail: c.Config.Auth.RequireVerifiedEmail,
+			users:                svc.Auth,
+		},
+		&rsvpRoutes{rsvps: rsvpHandler, exports: handlers.NewExportJobHandler(svc.ExportJobs)},
+		&guestRoutes{
+			guests:      guestHandler,
+			invitations: handlers.NewInvitationHandler(svc.Invitations),
+			groups:      handlers.NewGuestGroupHandler(svc.GuestGroups),
+			contacts:    handlers.NewGuestContactsHandler(svc.Guests),
+			merges:      handlers.NewGuestMergeHandler(svc.GuestMerges),
+			qrcodes:     handlers.NewGuestQRHandler(svc.GuestQRCodes),
+			checkins:    handlers.NewCheckInHandler(svc.CheckIns),
+			reminders:   handlers.NewReminderHandler(svc.Reminders),
+			whatsapp:    handlers.NewWhatsAppWebhookHandler(svc.Invitations, c.Config.WhatsApp.VerifyToken, c.Config.WhatsApp.AppSecret),
+		},
+		&mediaRoutes{uploads: uploadHandler, files: fileHandler, resize: resizeHandler},
+		&galleryRoutes{gallery: handlers.NewGalleryHandler(svc.Gallery)},
+		&registryRoutes{registry: handlers.NewRegistryHandler(svc.Registry)},
+		&wishRoutes{wishes: handlers.NewWishHandler(svc.Wishes)},
+		&musicRoutes{music: handlers.NewMusicHandler(svc.Music)},
+		&budgetRoutes{budget: handlers.NewBudgetHandler(svc.Budget)},
+		&vendorRoutes{vendors: handlers.NewVendorHandler(svc.Vendors)},
+		&pushRoutes{push: handlers.NewPushHandler(svc.Push)},
+		&weddingWebhookRoutes{webhooks: handlers.NewWeddingWebhookHandler(svc.WeddingWebhooks)},
+		&apiKeyRoutes{keys: handlers.NewAPIKeyHandler(svc.APIKeys)},
+		&analyticsRoutes{
+			analytics: analyticsHandler,
+			reports:   handlers.NewAnalyticsReportHandler(svc.AnalyticsReports),
+			anomalies: handlers.NewAnalyticsAnomalyHandler(svc.Anomalies),
+			exports:   handlers.NewAnalyticsExportHandler(svc.AnalyticsExports),
+			traces:    handlers.NewRequestTraceHandler(svc.Analytics, c.RequestLogs),
+		},
+		&integrationRoutes{
+			metrics: handlers.NewMetricsWebhookHandler(svc.MetricsWebhooks),
+			tenant:  handlers.NewTenantWebhookHandler(svc.TenantWebhooks),
+		},
+		&billingRoutes{billing: handlers.NewBillingHandler(svc.Billing)},
+		&themeRoutes{themes: handlers.NewThemeHandler(svc.Themes)},
+		&auditLogRoutes{auditLogs: handlers.NewAuditLogHandler(svc.AuditLogs)},
+		&moderationRoutes{moderation: handlers.NewWeddingModerationHandler(svc.Moderation)},
+		&emailSuppressionRoutes{suppressions: handlers.NewEmailSuppressionHandler(svc.Suppressions)},
+		&organizationRoutes{organizations: organizationHandler},
+		&featureFlagRoutes{features: handlers.NewFeatureFlagHandler(svc.FeatureFlags)},
+		&retentionRoutes{retention: handlers.NewRetentionHandler(svc.Retention)},
+		&graphqlRoutes{graphql: graphqlHandler},
+	)
+
+	c.AddWorker(
+		services.NewMetricsWebhookScheduler(svc.MetricsWebhooks, metricsWebhookInterval, c.Logger),
+		services.NewTenantWebhookDispatcher(svc.TenantWebhooks, tenantWebhookInterval, c.Logger),
+		services.NewReportScheduler(svc.AnalyticsReports, analyticsReportInterval, c.Logger),
+		services.NewReminderScheduler(svc.Reminders, reminderInterval, c.Logger),
+		services.NewBudgetReminderScheduler(svc.Budget, budgetReminderInterval, c.Logger),
+		services.NewAccountErasureScheduler(svc.Deletions, accountErasureInterval, c.Logger),
+		services.NewUploadSessionJanitor(svc.UploadSessions, uploadSessionPurgeInterval, c.Logger),
+		services.NewAnalyticsReconcileScheduler(c.Repositories.Analytics, svc.Jobs, analyticsReconcileInterval, c.Logger),
+		services.NewAnalyticsBenchmarkScheduler(c.Repositories.Analytics, analyticsBenchmarkInterval, c.Logger),
+		services.NewAnalyticsAnomalyScheduler(svc.Anomalies, analyticsAnomalyInterval, c.Logger),
+		services.NewRetentionScheduler(svc.Retention, retentionInterval, c.Config.Retention.DryRun, c.Logger),
+		svc.Invitations,
+	)
+	if svc.RSVPQueue != nil {
+		c.AddWorker(svc.RSVPQueue)
+	}
+	// Otherwise cmd/worker processes the background jobs
+	if c.Config.Jobs.RunInAPI {
+		c.AddWorker(svc.Jobs)
+	}
+}
+
+// systemRoutes serves health checks and environment provisioning
+type systemRoutes struct {
+	health    *handlers.HealthHandler
+	bootstrap *handlers.BootstrapHandler
+}
+
+func (r *systemRoutes) RegisterRoutes(routes *Routes) {
+	routes.Engine.GET("/health/live", r.health.Live)
+	routes.Engine.GET("/health/ready", r.health.Ready)
+	// Kept for probes configured before the liveness/readiness split
+	routes.Engine.GET("/health", r.health.Ready)
+	routes.Public.POST("/system/bootstrap", r.bootstrap.Bootstrap)
+}
+
+// authRoutes serves accounts, sessions and user profiles
+type authRoutes struct {
+	accounts  *handlers.AccountHandler
+	twoFactor *handlers.TwoFactorHandler
+	sessions  *handlers.SessionHandler
+	users     *handlers.UserHandler
+	deletions *handlers.AccountDeletionHandler
+	exports   *handlers.AccountExportHandler
+}
+
+func (r *authRoutes) RegisterRoutes(routes *Routes) {
+	auth := routes.Public.Group("/auth")
+	auth.POST("/register", r.accounts.Register)
+	auth.POST("/login", routes.RateLimit(services.RateLimitLogin), r.accounts.Login)
+	auth.POST("/2fa/login", routes.RateLimit(services.RateLimitLogin), r.accounts.CompleteTwoFactorLogin)
+	auth.POST("/oauth/:provider", r.accounts.OAuthLogin)
+	auth.POST("/refresh", r.accounts.RefreshToken)
+	auth.POST("/forgot-password", routes.RateLimit(services.RateLimitPasswordReset), r.accounts.ForgotPassword)
+	auth.POST("/reset-password", routes.RateLimit(services.RateLimitPasswordReset), r.accounts.ResetPassword)
+	auth.GET("/verify-email", r.accounts.VerifyEmail)
+	auth.POST("/resend-verification", routes.RateLimit(services.RateLimitAccountEmail), r.accounts.ResendVerification)
+	auth.POST("/unlock", routes.RateLimit(services.RateLimitAccountEmail), r.accounts.UnlockAccount)
+	auth.POST("/confirm-deletion", r.deletions.ConfirmDeletion)
+
+	account := routes.Protected.Group("/auth")
+	account.GET("/me", r.accounts.Me)
+	account.POST("/logout", r.accounts.Logout)
+	account.POST("/change-password", r.accounts.ChangePassword)
+	account.POST("/2fa/setup", r.twoFactor.Setup)
+	account.POST("/2fa/verify", r.twoFactor.Verify)
+	account.POST("/2fa/disable", r.twoFactor.Disable)
+	account.POST("/2fa/backup-codes", r.twoFactor.RegenerateBackupCodes)
+
+	users := routes.Protected.Group("/users")
+	users.GET("/profile", r.users.GetProfile)
+	users.PUT("/profile", r.users.UpdateProfile)
+	users.GET("/weddings", r.users.GetUserWeddings)
+	users.POST("/weddings/:wedding_id", r.users.AddWeddingToUser)
+	users.DELETE("/weddings/:wedding_id", r.users.RemoveWeddingFromUser)
+	users.GET("/sessions", r.sessions.ListSessions)
+	users.DELETE("/sessions", r.sessions.RevokeOtherSessions)
+	users.DELETE("/sessions/:id", r.sessions.RevokeSession)
+	users.DELETE("/me", r.deletions.RequestDeletion)
+	users.DELETE("/me/deletion", r.deletions.CancelDeletion)
+	users.POST("/me/export", r.exports.ExportAccount)
+	users.GET("/me/export/:id", r.exports.GetAccountExport)
+	users.GET("/me/export/:id/download", r.exports.DownloadAccountExport)
+
+	admin := routes.Admin.Group("/users")
+	admin.GET("", r.users.GetUsersList)
+	admin.GET("/search", r.users.SearchUsers)
+	admin.GET("/stats", r.users.GetUserStats)
+	admin.PUT("/:id/status", r.users.UpdateUserStatus)
+	admin.PUT("/:id/role", r.users.UpdateUserRole)
+	admin.DELETE("/:id", r.users.DeleteUser)
+}
+
+// weddingRoutes serves wedding management and the public showcase
+type weddingRoutes struct {
+	weddings      *handlers.WeddingHandler
+	public        *handlers.PublicHandler
+	collaborators *handlers.CollaboratorHandler
+	previews      *handlers.PreviewLinkHandler
+	revisions     *handlers.WeddingRevisionHandler
+	pdfs          *handlers.InvitationPDFHandler
+	// requireVerifiedEmail keeps users with an unverified email from creating weddings
+	requireVerifiedEmail bool
+	users                middleware.UserLoader
+}
+
+func (r *weddingRoutes) RegisterRoutes(routes *Routes) {
+	public := routes.Public.Group("/public/weddings")
+	public.GET("", r.weddings.ListPublicWeddings)
+	public.GET("/slug/:slug", r.public.GetWeddingBySlug)
+	public.GET("/slug/:slug/calendar.ics", r.public.GetWeddingCalendar)
+	// Link previews for chat apps and social networks
+	public.GET("/slug/:slug/og-image.png", r.public.GetWeddingOGImage)
+	public.GET("/slug/:slug/share", r.public.GetWeddingSharePage)
+	// Drafts are shown to whoever holds one of their preview links
+	routes.Public.GET("/public/preview/:token", r.public.PreviewWedding)
+
+	weddings := routes.Protected.Group("/weddings")
+	create := []gin.HandlerFunc{middleware.RequirePermission(models.PermissionCreateWedding)}
+	if r.requireVerifiedEmail {
+		create = append(create, middleware.RequireVerifiedEmail(r.users))
+	}
+	weddings.POST("", append(create, r.weddings.CreateWedding)...)
+	weddings.GET("", r.weddings.GetUserWeddings)
+	weddings.GET("/slug/:slug", r.weddings.GetWeddingBySlug)
+	weddings.GET("/slug-availability", r.weddings.CheckSlugAvailability)
+	weddings.GET("/:id", r.weddings.GetWedding)
+	weddings.PUT("/:id", r.weddings.UpdateWedding)
+	weddings.DELETE("/:id", r.weddings.DeleteWedding)
+	weddings.POST("/:id/publish", r.weddings.PublishWedding)
+
+	collaborators := weddings.Group("/:id/collaborators")
+	collaborators.GET("", r.collaborators.ListCollaborators)
+	collaborators.POST("", r.collaborators.InviteCollaborator)
+	collaborators.POST("/accept", r.collaborators.AcceptInvite)
+	collaborators.DELETE("/:user_id", r.collaborators.RemoveCollaborator)
+
+	previews := routes.Protected.Group("/weddings/:id/preview-links", aliasParam("id", "wedding_id"))
+	previews.POST("", r.previews.CreatePreviewLink)
+	previews.GET("", r.previews.ListPreviewLinks)
+	previews.DELETE("/:link_id", r.previews.RevokePreviewLink)
+
+	revisions := routes.Protected.Group("/weddings/:id/revisions", aliasParam("id", "wedding_id"))
+	revisions.GET("", r.revisions.ListRevisions)
+	revisions.GET("/:rev", r.revisions.GetRevision)
+	revisions.POST("/:rev/restore", r.revisions.RestoreRevision)
+
+	pdfs := weddings.Group("/:id/export/pdf")
+	pdfs.GET("", r.pdfs.ExportInvitationPDF)
+	pdfs.GET("/:job_id", r.pdfs.GetInvitationPDF)
+	pdfs.GET("/:job_id/download", r.pdfs.DownloadInvitationPDF)
+}
+
+// rsvpRoutes serves public RSVP submission, RSVP management and export history
+type rsvpRoutes struct {
+	rsvps   *handlers.RSVPHandler
+	exports *handlers.ExportJobHandler
+}
+
+func (r *rsvpRoutes) RegisterRoutes(routes *Routes) {
+	public := routes.Public.Group("/public/weddings/:id/rsvp")
+	// Mobile clients retry submissions; an Idempotency-Key keeps that from duplicating RSVPs
+	public.POST("", routes.RateLimit(services.RateLimitPublicRSVP), routes.Idempotent(), r.rsvps.SubmitRSVP)
+	public.GET("/submissions/:submission_id", r.rsvps.GetSubmissionStatus)
+
+	// Guests change their RSVP through the edit link emailed after submission
+	edits := routes.Public.Group("/public/rsvp/:token")
+	edits.GET("", r.rsvps.GetRSVPForEdit)
+	edits.PUT("", r.rsvps.UpdateRSVPByEditLink)
+
+	weddings := routes.Protected.Group("/weddings/:id")
+	weddings.GET("/rsvps", r.rsvps.GetRSVPs)
+	weddings.GET("/rsvps/review", r.rsvps.GetRSVPReviewQueue)
+	weddings.GET("/rsvps/statistics", r.rsvps.GetRSVPStatistics)
+	weddings.GET("/rsvps/insights", r.rsvps.GetRSVPInsights)
+	weddings.GET("/rsvps/meal-report", r.rsvps.GetMealReport)
+	weddings.GET("/rsvps/export", r.rsvps.ExportRSVPs)
+	weddings.POST("/rsvps/export", r.rsvps.ExportRSVPs)
+	weddings.GET("/exports", r.exports.ListExportJobs)
+
+	rsvps := routes.Protected.Group("/rsvps")
+	rsvps.PUT("/:id", r.rsvps.UpdateRSVP)
+	rsvps.POST("/:id/review", r.rsvps.ReviewRSVP)
+	rsvps.DELETE("/:id", r.rsvps.DeleteRSVP)
+}
+
+// guestRoutes serves the guest list of a wedding
+type guestRoutes struct {
+	guests      *handlers.GuestHandler
+	invitations *handlers.InvitationHandler
+	groups      *handlers.GuestGroupHandler
+	contacts    *handlers.GuestContactsHandler
+	merges      *handlers.GuestMergeHandler
+	qrcodes     *handlers.GuestQRHandler
+	checkins    *handlers.CheckInHandler
+	reminders   *handlers.ReminderHandler
+	whatsapp    *handlers.WhatsAppWebhookHandler
+}
+
+func (r *guestRoutes) RegisterRoutes(routes *Routes) {
+	// Wedding routes name their parameter :id, and the router requires one name per
+	// path segment, so the guest handlers get it under the name they read
+	weddings := routes.Protected.Group("/weddings/:id/guests", aliasParam("id", "wedding_id"))
+	weddings.POST("", r.guests.CreateGuest)
+	weddings.GET("", r.guests.ListGuests)
+	weddings.GET("/search", r.guests.SearchGuests)
+	weddings.GET("/export", r.guests.ExportGuests)
+	weddings.POST("/export", r.guests.ExportGuests)
+	weddings.POST("/bulk", routes.Idempotent(), r.guests.BulkCreateGuests)
+	weddings.POST("/bulk-update", r.guests.BulkUpdateGuests)
+	weddings.POST("/bulk-delete", r.guests.BulkDeleteGuests)
+	weddings.GET("/duplicates", r.merges.FindDuplicates)
+	weddings.POST("/merge", r.merges.MergeGuests)
+	weddings.POST("/import", r.guests.ImportGuestsCSV)
+	weddings.POST("/import/vcard", r.contacts.ImportVCard)
+	weddings.POST("/import/google/connect", r.contacts.ConnectGoogleContacts)
+	weddings.DELETE("/import/google/connect", r.contacts.DisconnectGoogleContacts)
+	weddings.GET("/import/google/contacts", r.contacts.ListGoogleContacts)
+	weddings.POST("/import/google", r.contacts.ImportGoogleContacts)
+	weddings.POST("/send-invites", r.invitations.SendInvites)
+	weddings.POST("/:guest_id/send-invite", r.invitations.SendInvite)
+	weddings.GET("/qrcodes", r.qrcodes.ExportQRCodes)
+
+	groups := routes.Protected.Group("/weddings/:id/guest-groups", aliasParam("id", "wedding_id"))
+	groups.POST("", r.groups.CreateGroup)
+	groups.GET("", r.groups.ListGroups)
+	groups.GET("/:group_id", r.groups.GetGroup)
+	groups.PUT("/:group_id", r.groups.UpdateGroup)
+	groups.DELETE("/:group_id", r.groups.DeleteGroup)
+	groups.POST("/:group_id/send-invite", r.invitations.SendGroupInvite)
+
+	guests := routes.Protected.Group("/guests")
+	guests.GET("/:id", r.guests.GetGuest)
+	guests.GET("/:id/qrcode", r.qrcodes.GetGuestQRCode)
+	guests.PUT("/:id", r.guests.UpdateGuest)
+	guests.DELETE("/:id", r.guests.DeleteGuest)
+
+	checkins := routes.Protected.Group("/weddings/:id/checkin")
+	checkins.POST("", r.checkins.CheckInGuest)
+	checkins.GET("/stats", r.checkins.GetCheckInStats)
+
+	reminders := routes.Protected.Group("/weddings/:id/reminders")
+	reminders.POST("", r.reminders.CreateCampaign)
+	reminders.GET("", r.reminders.ListCampaigns)
+	reminders.GET("/:campaign_id", r.reminders.GetCampaign)
+	reminders.DELETE("/:campaign_id", r.reminders.CancelCampaign)
+
+	// Reminder emails load this image, which records that they were opened
+	routes.Public.GET("/public/reminders/:delivery_id/open", r.reminders.TrackOpen)
+
+	// The WhatsApp Cloud API reports invitation deliveries here
+	routes.Public.GET("/webhooks/whatsapp", r.whatsapp.VerifyWebhook)
+	routes.Public.POST("/webhooks/whatsapp", r.whatsapp.ReceiveWebhook)
+
+	// Scanning a guest's QR code opens the RSVP form, which resolves the signed link
+	routes.Public.GET("/public/weddings/:id/rsvp/guest", r.qrcodes.ResolveGuestLink)
+}
+
+// aliasParam exposes the path parameter from under the additional name to
+func aliasParam(from, to string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Params = append(c.Params, gin.Param{Key: to, Value: c.Param(from)})
+		c.Next()
+	}
+}
+
+// mediaRoutes serves uploads and the stored files
+type mediaRoutes struct {
+	uploads *handlers.UploadHandler
+	files   *handlers.FileHandler        // nil unless stored files are served by the API
+	resize  *handlers.MediaResizeHandler // nil unless the storage backend can read uploads back
+}
+
+func (r *mediaRoutes) RegisterRoutes(routes *Routes) {
+	if r.files != nil {
+		routes.Engine.GET("/uploads/*filepath", r.files.ServeFile)
+		routes.Engine.HEAD("/uploads/*filepath", r.files.ServeFile)
+	}
+	if r.resize != nil {
+		// Public like the files it renders, so that <img> tags can point at it
+		routes.Public.GET("/media/:id/resize", r.resize.ResizeMedia)
+	}
+
+	// Starting an upload counts against the uploads limit; its chunks and completion do not
+	limit := routes.RateLimit(services.RateLimitUploads)
+	upload := routes.Protected.Group("/upload")
+	upload.POST("", limit, r.uploads.HandleUpload)
+	upload.POST("/single", limit, r.uploads.HandleSingleUpload)
+	upload.POST("/presign", limit, r.uploads.HandlePresignURL)
+	upload.POST("/confirm", routes.Idempotent(), r.uploads.HandleConfirmUpload)
+	upload.POST("/multipart", limit, r.uploads.HandleInitiateMultipartUpload)
+	upload.POST("/multipart/complete", r.uploads.HandleCompleteMultipartUpload)
+	upload.POST("/multipart/abort", r.uploads.HandleAbortMultipartUpload)
+	upload.POST("/resumable", limit, r.uploads.HandleCreateUploadSession)
+	upload.HEAD("/resumable/:id", r.uploads.HandleGetUploadSession)
+	upload.GET("/resumable/:id", r.uploads.HandleGetUploadSession)
+	upload.PATCH("/resumable/:id", r.uploads.HandleUploadChunk)
+	upload.POST("/resumable/:id/complete", r.uploads.HandleCompleteUploadSession)
+	upload.DELETE("/resumable/:id", r.uploads.HandleCancelUploadSession)
+
+	media := routes.Protected.Group("/media")
+	media.GET("", r.uploads.HandleListMedia)
+	media.GET("/:id", r.uploads.HandleGetMedia)
+	media.DELETE("/:id", r.uploads.HandleDeleteMedia)
+}
+
+// galleryRoutes serves guest photo uploads, their moderation and the public gallery
+type galleryRoutes struct {
+	gallery *handlers.GalleryHandler
+}
+
+func (r *galleryRoutes) RegisterRoutes(routes *Routes) {
+	public := routes.Public.Group("/public/weddings/:id/gallery")
+	public.GET("", r.gallery.GetGallery)
+	public.POST("", routes.RateLimit(services.RateLimitUploads), r.gallery.UploadGuestPhoto)
+
+	gallery := routes.Protected.Group("/weddings/:id/gallery")
+	gallery.GET("/pending", r.gallery.GetPendingPhotos)
+	gallery.POST("/:photo_id/approve", r.gallery.ApprovePhoto)
+	gallery.POST("/:photo_id/reject", r.gallery.RejectPhoto)
+}
+
+// registryRoutes serves the gift registry and the gifts guests pledged
+type registryRoutes struct {
+	registry *handlers.RegistryHandler
+}
+
+func (r *registryRoutes) RegisterRoutes(routes *Routes) {
+	public := routes.Public.Group("/public/weddings/slug/:slug/registry")
+	public.GET("", r.registry.GetPublicRegistry)
+	public.POST("/pledges", r.registry.PledgeGift)
+
+	registry := routes.Protected.Group("/weddings/:id/registry")
+	registry.GET("", r.registry.ListItems)
+	registry.POST("", r.registry.CreateItem)
+	registry.PUT("/:item_id", r.registry.UpdateItem)
+	registry.DELETE("/:item_id", r.registry.DeleteItem)
+	registry.GET("/pledges", r.registry.ListPledges)
+	registry.PUT("/pledges/:pledge_id", r.registry.UpdatePledgeStatus)
+}
+
+// wishRoutes serves the guestbook wishes wall and its moderation
+type wishRoutes struct {
+	wishes *handlers.WishHandler
+}
+
+func (r *wishRoutes) RegisterRoutes(routes *Routes) {
+	public := routes.Public.Group("/public/weddings/slug/:slug/wishes")
+	public.GET("", r.wishes.GetWishes)
+	public.POST("", r.wishes.CreateWish)
+
+	wishes := routes.Protected.Group("/weddings/:id/wishes")
+	wishes.GET("", r.wishes.ListWishes)
+	wishes.POST("/:wish_id/approve", r.wishes.ApproveWish)
+	wishes.POST("/:wish_id/hide", r.wishes.HideWish)
+	wishes.DELETE("/:wish_id", r.wishes.DeleteWish)
+}
+
+// musicRoutes serves the song requests of guests, their moderation and the DJ's list
+type musicRoutes struct {
+	music *handlers.MusicHandler
+}
+
+func (r *musicRoutes) RegisterRoutes(routes *Routes) {
+	public := routes.Public.Group("/public/weddings/slug/:slug/song-requests")
+	public.GET("", r.music.GetSongRequests)
+	public.POST("", r.music.RequestSong)
+
+	songs := routes.Protected.Group("/weddings/:id/song-requests")
+	songs.GET("", r.music.ListSongRequests)
+	songs.GET("/export", r.music.ExportSongRequests)
+	songs.POST("/:request_id/approve", r.music.ApproveSongRequest)
+	songs.POST("/:request_id/reject", r.music.RejectSongRequest)
+	songs.DELETE("/:request_id", r.music.DeleteSongRequest)
+}
+
+// budgetRoutes serves a wedding's budget categories and vendor payments
+type budgetRoutes struct {
+	budget *handlers.BudgetHandler
+}
+
+func (r *budgetRoutes) RegisterRoutes(routes *Routes) {
+	budget := routes.Protected.Group("/weddings/:id/budget")
+	budget.GET("", r.budget.GetSummary)
+	budget.GET("/categories", r.budget.ListCategories)
+	budget.POST("/categories", r.budget.CreateCategory)
+	budget.PUT("/categories/:category_id", r.budget.UpdateCategory)
+	budget.DELETE("/categories/:category_id", r.budget.DeleteCategory)
+	budget.GET("/payments", r.budget.ListPayments)
+	budget.POST("/payments", r.budget.CreatePayment)
+	budget.PUT("/payments/:payment_id", r.budget.UpdatePayment)
+	budget.DELETE("/payments/:payment_id", r.budget.DeletePayment)
+}
+
+// vendorRoutes serves the vendor directory of a wedding
+type vendorRoutes struct {
+	vendors *handlers.VendorHandler
+}
+
+func (r *vendorRoutes) RegisterRoutes(routes *Routes) {
+	vendors := routes.Protected.Group("/weddings/:id/vendors")
+	vendors.GET("", r.vendors.ListVendors)
+	vendors.POST("", r.vendors.CreateVendor)
+	vendors.GET("/:vendor_id", r.vendors.GetVendor)
+	vendors.PUT("/:vendor_id", r.vendors.UpdateVendor)
+	vendors.DELETE("/:vendor_id", r.vendors.DeleteVendor)
+}
+
+// pushRoutes serves the Web Push subscriptions and notification preferences of the signed-in user
+type pushRoutes struct {
+	push *handlers.PushHandler
+}
+
+func (r *pushRoutes) RegisterRoutes(routes *Routes) {
+	push := routes.Protected.Group("/users/push")
+	push.GET("/public-key", r.push.GetVAPIDPublicKey)
+	push.GET("/subscriptions", r.push.ListSubscriptions)
+	push.POST("/subscriptions", r.push.Subscribe)
+	push.DELETE("/subscriptions/:id", r.push.Unsubscribe)
+
+	notifications := routes.Protected.Group("/users/notifications")
+	notifications.GET("", r.push.GetPreferences)
+	notifications.PUT("", r.push.UpdatePreferences)
+}
+
+// weddingWebhookRoutes serves a wedding's event webhooks and their delivery logs
+type weddingWebhookRoutes struct {
+	webhooks *handlers.WeddingWebhookHandler
+}
+
+func (r *weddingWebhookRoutes) RegisterRoutes(routes *Routes) {
+	webhooks := routes.Protected.Group("/weddings/:id/webhooks")
+	webhooks.GET("", r.webhooks.ListWebhooks)
+	webhooks.POST("", r.webhooks.CreateWebhook)
+	webhooks.PUT("/:webhook_id", r.webhooks.UpdateWebhook)
+	webhooks.DELETE("/:webhook_id", r.webhooks.DeleteWebhook)
+	webhooks.GET("/:webhook_id/deliveries", r.webhooks.ListDeliveries)
+}
+
+// apiKeyRoutes serves the API keys users create for third-party integrations
+type apiKeyRoutes struct {
+	keys *handlers.APIKeyHandler
+}
+
+func (r *apiKeyRoutes) RegisterRoutes(routes *Routes) {
+	keys := routes.Protected.Group("/users/api-keys")
+	keys.GET("", r.keys.ListKeys)
+	keys.POST("", r.keys.CreateKey)
+	keys.PUT("/:id", r.keys.UpdateKey)
+	keys.DELETE("/:id", r.keys.RevokeKey)
+	keys.GET("/:id/usage", r.keys.GetUsage)
+}
+
+// analyticsRoutes serves event tracking, wedding analytics, the live stream, exports, digest settings, anomalies and request tracing
+type analyticsRoutes struct {
+	analytics *handlers.AnalyticsHandler
+	reports   *handlers.AnalyticsReportHandler
+	anomalies *handlers.AnalyticsAnomalyHandler
+	exports   *handlers.AnalyticsExportHandler
+	traces    *handlers.RequestTraceHandler
+}
+
+func (r *analyticsRoutes) RegisterRoutes(routes *Routes) {
+	track := routes.Public.Group("/analytics/track", routes.RateLimit(services.RateLimitTracking))
+	track.POST("/page-view", r.analytics.TrackPageView)
+	track.POST("/page-duration", r.analytics.TrackPageDuration)
+	track.POST("/rsvp-submission", r.analytics.TrackRSVPSubmission)
+	track.POST("/rsvp-abandonment", r.analytics.TrackRSVPAbandonment)
+	track.POST("/conversion", r.analytics.TrackConversion)
+	track.POST("/batch", r.analytics.TrackBatch)
+
+	wedding := routes.Protected.Group("/weddings/:id/analytics")
+	wedding.GET("", r.analytics.GetWeddingAnalytics)
+	wedding.GET("/summary", r.analytics.GetAnalyticsSummary)
+	wedding.GET("/page-views", r.analytics.GetPageViews)
+	wedding.GET("/popular-pages", r.analytics.GetPopularPages)
+	wedding.GET("/traffic-sources", r.analytics.GetTrafficSources)
+	wedding.GET("/geo", r.analytics.GetGeoBreakdown)
+	wedding.GET("/daily", r.analytics.GetDailyMetrics)
+	wedding.GET("/funnel", r.analytics.GetRSVPFunnel)
+	wedding.POST("/refresh", r.analytics.RefreshAnalytics)
+	wedding.GET("/stream", r.analytics.StreamAnalytics)
+	wedding.GET("/export", r.exports.ExportAnalytics)
+	wedding.GET("/exports/:job_id/download", r.exports.DownloadAnalyticsExport)
+	wedding.GET("/reports", r.reports.GetReportSettings)
+	wedding.PUT("/reports", r.reports.UpdateReportSettings)
+	wedding.GET("/anomalies", r.anomalies.ListAnomalies)
+
+	routes.Protected.GET("/users/analytics/overview", r.analytics.GetUserOverview)
+
+	routes.Admin.GET("/analytics/system", r.analytics.GetSystemAnalytics)
+	routes.Admin.POST("/analytics/refresh", r.analytics.RefreshSystemAnalytics)
+	routes.Admin.GET("/requests/:request_id/trace", r.traces.GetRequestTrace)
+}
+
+// integrationRoutes serves outbound metrics and tenant lifecycle webhooks
+type integrationRoutes struct {
+	metrics *handlers.MetricsWebhookHandler
+	tenant  *handlers.TenantWebhookHandler
+}
+
+func (r *integrationRoutes) RegisterRoutes(routes *Routes) {
+	metrics := routes.Protected.Group("/integrations/metrics-webhooks")
+	metrics.POST("", r.metrics.CreateWebhook)
+	metrics.GET("", r.metrics.ListWebhooks)
+	metrics.PUT("/:id", r.metrics.UpdateWebhook)
+	metrics.DELETE("/:id", r.metrics.DeleteWebhook)
+	metrics.GET("/:id/deliveries", r.metrics.ListDeliveries)
+	metrics.POST("/:id/deliver", r.metrics.DeliverNow)
+
+	tenant := routes.Protected.Group("/tenant/webhooks", middleware.RequirePermission(models.PermissionManageOrganization))
+	tenant.POST("", r.tenant.CreateWebhook)
+	tenant.GET("", r.tenant.ListWebhooks)
+	tenant.POST("/secret/rotate", r.tenant.RotateSecret)
+	tenant.PUT("/:id", r.tenant.UpdateWebhook)
+	tenant.DELETE("/:id", r.tenant.DeleteWebhook)
+	tenant.GET("/:id/deliveries", r.tenant.ListDeliveries)
+	tenant.POST("/:id/replay", r.tenant.ReplayWebhook)
+}
+
+// billingRoutes serves plans and Stripe's subscription events
+type billingRoutes struct {
+	billing *handlers.BillingHandler
+}
+
+func (r *billingRoutes) RegisterRoutes(routes *Routes) {
+	routes.Protected.GET("/billing", r.billing.GetBilling)
+	routes.Public.POST("/webhooks/stripe", r.billing.ReceiveStripeWebhook)
+}
+
+// themeRoutes serves the theme catalog and its administration
+type themeRoutes struct {
+	themes *handlers.ThemeHandler
+}
+
+func (r *themeRoutes) RegisterRoutes(routes *Routes) {
+	routes.Public.GET("/themes", r.themes.ListThemes)
+
+	admin := routes.Admin.Group("/themes")
+	admin.GET("", r.themes.AdminListThemes)
+	admin.POST("", r.themes.AdminCreateTheme)
+	admin.GET("/:id", r.themes.AdminGetTheme)
+	admin.PUT("/:id", r.themes.AdminUpdateTheme)
+	admin.DELETE("/:id", r.themes.AdminDeleteTheme)
+}
+
+// auditLogRoutes serves the audit log to admins and wedding owners
+type auditLogRoutes struct {
+	auditLogs *handlers.AuditLogHandler
+}
+
+func (r *auditLogRoutes) RegisterRoutes(routes *Routes) {
+	routes.Protected.GET("/weddings/:id/audit-logs", r.auditLogs.ListWeddingAuditLogs)
+	routes.Admin.GET("/audit-logs", r.auditLogs.ListAuditLogs)
+}
+
+// moderationRoutes serves wedding moderation to admins and abuse reports to visitors
+type moderationRoutes struct {
+	moderation *handlers.WeddingModerationHandler
+}
+
+func (r *moderationRoutes) RegisterRoutes(routes *Routes) {
+	routes.Public.POST("/public/weddings/slug/:slug/report", r.moderation.ReportAbuse)
+
+	weddings := routes.Admin.Group("/weddings")
+	weddings.GET("", r.moderation.ListWeddings)
+	weddings.GET("/:id", r.moderation.GetWedding)
+	weddings.POST("/:id/unpublish", r.moderation.UnpublishWedding)
+	weddings.POST("/:id/flag", r.moderation.FlagWedding)
+	weddings.DELETE("/:id/flag", r.moderation.UnflagWedding)
+	weddings.POST("/:id/takedown", r.moderation.TakeDownWedding)
+	weddings.POST("/:id/restore", r.moderation.RestoreWedding)
+
+	reports := routes.Admin.Group("/abuse-reports")
+	reports.GET("", r.moderation.ListAbuseReports)
+	reports.POST("/:id/close", r.moderation.CloseAbuseReport)
+}
+
+// emailSuppressionRoutes serves the email suppression list to admins and
+// receives the email provider's bounce reports
+type emailSuppressionRoutes struct {
+	suppressions *handlers.EmailSuppressionHandler
+}
+
+func (r *emailSuppressionRoutes) RegisterRoutes(routes *Routes) {
+	routes.Public.POST("/webhooks/email/sendgrid", r.suppressions.ReceiveSendGridWebhook)
+
+	admin := routes.Admin.Group("/email-suppressions")
+	admin.GET("", r.suppressions.ListSuppressions)
+	admin.POST("", r.suppressions.SuppressEmail)
+	admin.DELETE("/:email", r.suppressions.RemoveSuppression)
+}
+
+// organizationRoutes serves white-label organizations to platform admins and
+// their own organization to organization admins
+type organizationRoutes struct {
+	organizations *handlers.OrganizationHandler
+}
+
+func (r *organizationRoutes) RegisterRoutes(routes *Routes) {
+	own := routes.Protected.Group("/organization", middleware.RequirePermission(models.PermissionManageOrganization))
+	own.GET("", r.organizations.GetOwnOrganization)
+	own.PUT("/branding", r.organizations.UpdateBranding)
+	own.GET("/users", r.organizations.ListMembers)
+	own.GET("/weddings", r.organizations.ListWeddings)
+
+	admin := routes.Admin.Group("/organizations")
+	admin.POST("", r.organizations.CreateOrganization)
+	admin.GET("", r.organizations.ListOrganizations)
+	admin.GET("/:id", r.organizations.GetOrganization)
+	admin.PUT("/:id", r.organizations.UpdateOrganization)
+	admin.DELETE("/:id", r.organizations.DeleteOrganization)
+	admin.POST("/:id/members", r.organizations.AddMember)
+}
+
+// featureFlagRoutes serves the features of weddings to their frontends and
+// feature overrides to admins
+type featureFlagRoutes struct {
+	features *handlers.FeatureFlagHandler
+}
+
+func (r *featureFlagRoutes) RegisterRoutes(routes *Routes) {
+	routes.Public.GET("/public/weddings/:id/features", r.features.GetPublicWeddingFeatures)
+	routes.Protected.GET("/weddings/:id/features", r.features.GetWeddingFeatures)
+
+	admin := routes.Admin.Group("/features")
+	admin.GET("", r.features.ListFlags)
+	admin.PUT("/:feature", r.features.SetFlag)
+	admin.DELETE("/:feature", r.features.ClearFlag)
+
+	weddings := routes.Admin.Group("/weddings/:id/features")
+	weddings.GET("", r.features.ListWeddingFlags)
+	weddings.PUT("/:feature", r.features.SetWeddingFlag)
+	weddings.DELETE("/:feature", r.features.ClearWeddingFlag)
+}
+
+// retentionRoutes lets admins review the retention policy and override it for weddings
+type retentionRoutes struct {
+	retention *handlers.RetentionHandler
+}
+
+func (r *retentionRoutes) RegisterRoutes(routes *Routes) {
+	admin := routes.Admin.Group("/retention")
+	admin.GET("", r.retention.GetPolicy)
+	admin.GET("/report", r.retention.GetReport)
+
+	weddings := routes.Admin.Group("/weddings/:id/retention")
+	weddings.GET("", r.retention.GetWeddingOverride)
+	weddings.PUT("", r.retention.SetWeddingOverride)
+	weddings.DELETE("", r.retention.ClearWeddingOverride)
+}
+
+// graphqlRoutes serves the dashboard's GraphQL API
+type graphqlRoutes struct {
+	graphql *handlers.GraphQLHandler
+}
+
+func (r *graphqlRoutes) RegisterRoutes(routes *Routes) {
+	routes.Protected.POST("/graphql", r.graphql.Query)
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -21,11 +22,15 @@ func TestLoad(t *testing.T) {
 			name: "default configuration",
 			validate: func(t *testing.T, cfg *Config) {
 				assert.Equal(t, "8080", cfg.Server.Port)
+				assert.Equal(t, "9090", cfg.Server.GRPCPort)
 				assert.Equal(t, "development", cfg.Server.Environment)
 				assert.Equal(t, []string{"*"}, cfg.Server.AllowedOrigins)
 				assert.Equal(t, "mongodb://localhost:27017", cfg.Database.URI)
 				assert.Equal(t, "wedding_invitations", cfg.Database.Database)
 				assert.Equal(t, 10, cfg.Database.Timeout)
+				assert.Equal(t, uint64(100), cfg.Database.MaxPoolSize)
+				assert.Equal(t, "primary", cfg.Database.ReadPreference)
+				assert.Equal(t, 30*time.Second, cfg.Database.OperationTimeout)
 				assert.Equal(t, 15*time.Minute, cfg.Auth.AccessTokenTTL)
 				assert.Equal(t, 12, cfg.Auth.BcryptCost)
 			},
@@ -34,25 +39,35 @@ func TestLoad(t *testing.T) {
 		{
 			name: "environment variables override",
 			envVars: map[string]string{
-				"PORT":            "9000",
-				"APP_ENV":         "production",
-				"MONGODB_URI":     "mongodb://prod:27017",
-				"JWT_SECRET":      "prod-secret",
-				"JWT_ACCESS_TTL":  "1h",
-				"BCRYPT_COST":     "14",
-				"ALLOWED_ORIGINS": "https://example.com,https://app.example.com",
+				"PORT":                  "9000",
+				"APP_ENV":               "production",
+				"MONGODB_URI":           "mongodb://prod:27017",
+				"MONGODB_MAX_POOL_SIZE": "50",
+				"JWT_SECRET":            "prod-secret-at-least-32-characters",
+				"JWT_REFRESH_SECRET":    "prod-refresh-secret-of-32-characters",
+				"JWT_ACCESS_TTL":        "1h",
+				"BCRYPT_COST":           "14",
+				"ALLOWED_ORIGINS":       "https://example.com,https://app.example.com",
 			},
 			validate: func(t *testing.T, cfg *Config) {
 				assert.Equal(t, "9000", cfg.Server.Port)
 				assert.Equal(t, "production", cfg.Server.Environment)
 				assert.Equal(t, []string{"https://example.com", "https://app.example.com"}, cfg.Server.AllowedOrigins)
 				assert.Equal(t, "mongodb://prod:27017", cfg.Database.URI)
-				assert.Equal(t, "prod-secret", cfg.Auth.JWTSecret)
+				assert.Equal(t, uint64(50), cfg.Database.MaxPoolSize)
+				assert.Equal(t, "prod-secret-at-least-32-characters", cfg.Auth.JWTSecret)
 				assert.Equal(t, 1*time.Hour, cfg.Auth.AccessTokenTTL)
 				assert.Equal(t, 14, cfg.Auth.BcryptCost)
 			},
 			wantErr: false,
 		},
+		{
+			name: "production without secrets",
+			envVars: map[string]string{
+				"APP_ENV": "production",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -103,3 +118,32 @@ func TestConfigStaging(t *testing.T) {
 	assert.False(t, cfg.IsDevelopment())
 	assert.False(t, cfg.IsProduction())
 }
+
+func TestLoadConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "api.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("PORT: \"7000\"\nALLOWED_ORIGINS:\n  - https://example.com\nUPLOAD_THUMBNAIL_SIZES: small:100x100\n"), 0o600))
+
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("UPLOAD_MAX_FILES", "3")
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "7000", cfg.Server.Port)
+	assert.Equal(t, []string{"https://example.com"}, cfg.Server.AllowedOrigins)
+	assert.Equal(t, "small:100x100", cfg.Upload.ThumbnailSizes)
+	assert.Equal(t, 3, cfg.Upload.MaxFiles)
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Setenv("CONFIG_FILE", filepath.Join(dir, "missing.yaml"))
+		_, err := Load()
+		assert.ErrorContains(t, err, "failed to read config file")
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("PORT: \"70000\"\n"), 0o600))
+		_, err := Load()
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []string{`PORT must be a port number between 1 and 65535, got "70000"`}, validationErr.Problems)
+	})
+}
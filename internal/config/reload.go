@@ -0,0 +1,29 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+)
+
+// RequiresRestart names the settings that differ in next but are only read at
+// startup. CORS origins, rate limits and feature defaults are applied to a
+// running server on reload; everything else needs a restart to take effect.
+func (c *Config) RequiresRestart(next *Config) []string {
+	current, updated := *c, *next
+	current.Server.AllowedOrigins, updated.Server.AllowedOrigins = nil, nil
+	current.RateLimit, updated.RateLimit = RateLimitConfig{}, RateLimitConfig{}
+	current.Features, updated.Features = FeaturesConfig{}, FeaturesConfig{}
+
+	var changed []string
+	a, b := reflect.ValueOf(current), reflect.ValueOf(updated)
+	for i := 0; i < a.NumField(); i++ {
+		section := a.Field(i)
+		for j := 0; j < section.NumField(); j++ {
+			if !reflect.DeepEqual(section.Field(j).Interface(), b.Field(i).Field(j).Interface()) {
+				changed = append(changed, section.Type().Field(j).Tag.Get("mapstructure"))
+			}
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
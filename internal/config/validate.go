@@ -0,0 +1,285 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// minAnalyticsRetention is the shortest period raw analytics events may be kept:
+// reconciliation rebuilds the counters of the last 90 days from them
+const minAnalyticsRetention = 90 * 24 * time.Hour
+
+// ValidationError lists every problem found in a configuration, so that all of
+// them can be fixed in one go
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// ThumbnailSpec is one of the sizes thumbnails of uploaded images are rendered in
+type ThumbnailSpec struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// ParseThumbnailSizes parses UPLOAD_THUMBNAIL_SIZES, a comma-separated list of
+// name:WIDTHxHEIGHT, e.g. "small:150x150,large:800x800"
+func ParseThumbnailSizes(value string) ([]ThumbnailSpec, error) {
+	var sizes []ThumbnailSpec
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, dimensions, ok := strings.Cut(entry, ":")
+		width, height, okSize := strings.Cut(dimensions, "x")
+		w, errW := strconv.Atoi(width)
+		h, errH := strconv.Atoi(height)
+		if !ok || !okSize || name == "" || errW != nil || errH != nil || w <= 0 || h <= 0 {
+			return nil, fmt.Errorf("%q is not name:WIDTHxHEIGHT", entry)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("size %q is listed twice", name)
+		}
+		seen[name] = true
+		sizes = append(sizes, ThumbnailSpec{Name: name, Width: w, Height: h})
+	}
+	return sizes, nil
+}
+
+// Validate checks the configuration for values the application cannot start
+// with, naming each setting by its environment variable
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.port("PORT", c.Server.Port)
+	v.port("GRPC_PORT", c.Server.GRPCPort)
+	v.oneOf("APP_ENV", c.Server.Environment, "development", "test", "staging", "production")
+	v.origins("ALLOWED_ORIGINS", c.Server.AllowedOrigins)
+	v.url("PUBLIC_API_URL", c.Server.PublicURL)
+
+	if !strings.HasPrefix(c.Database.URI, "mongodb://") && !strings.HasPrefix(c.Database.URI, "mongodb+srv://") {
+		v.fail("MONGODB_URI must start with mongodb:// or mongodb+srv://")
+	}
+	v.required("MONGODB_DATABASE", c.Database.Database)
+	v.positive("MONGODB_TIMEOUT_SECONDS", int64(c.Database.Timeout))
+	if c.Database.MaxPoolSize < 1 {
+		v.fail("MONGODB_MAX_POOL_SIZE must be at least 1")
+	}
+	if c.Database.MinPoolSize > c.Database.MaxPoolSize {
+		v.fail("MONGODB_MIN_POOL_SIZE must be at most MONGODB_MAX_POOL_SIZE")
+	}
+	if c.Database.MaxConnIdleTime < 0 {
+		v.fail("MONGODB_MAX_CONN_IDLE_TIME must not be negative")
+	}
+	v.positiveDuration("MONGODB_SERVER_SELECTION_TIMEOUT", c.Database.ServerSelectionTimeout)
+	v.oneOf("MONGODB_READ_PREFERENCE", c.Database.ReadPreference, "primary", "primaryPreferred", "secondary", "secondaryPreferred", "nearest")
+	v.positiveDuration("MONGODB_OPERATION_TIMEOUT", c.Database.OperationTimeout)
+	v.oneOf("DATABASE_DRIVER", c.Database.Driver, "mongodb", "postgres")
+	if c.Database.Driver == "postgres" {
+		v.required("POSTGRES_URL", c.Database.PostgresURL)
+	}
+
+	if c.IsProduction() {
+		v.secret("JWT_SECRET", c.Auth.JWTSecret)
+		v.secret("JWT_REFRESH_SECRET", c.Auth.JWTRefreshSecret)
+	}
+	v.positiveDuration("JWT_ACCESS_TTL", c.Auth.AccessTokenTTL)
+	if c.Auth.RefreshTokenTTL <= c.Auth.AccessTokenTTL {
+		v.fail("JWT_REFRESH_TTL must be longer than JWT_ACCESS_TTL")
+	}
+	if c.Auth.BcryptCost < 4 || c.Auth.BcryptCost > 31 {
+		v.fail("BCRYPT_COST must be between 4 and 31, got %d", c.Auth.BcryptCost)
+	}
+
+	v.oneOf("STORAGE_PROVIDER", c.Storage.Provider, "local", "s3")
+	switch c.Storage.Provider {
+	case "local":
+		v.required("UPLOAD_LOCAL_PATH", c.Upload.LocalPath)
+	case "s3":
+		v.required("S3_BUCKET_NAME", c.Storage.Bucket)
+	}
+	v.url("CDN_URL", c.Storage.CDNURL)
+
+	v.url("UPLOAD_BASE_URL", c.Upload.BaseURL)
+	v.positive("UPLOAD_MAX_FILE_SIZE", c.Upload.MaxFileSize)
+	if c.Upload.MaxTotalSize < c.Upload.MaxFileSize {
+		v.fail("UPLOAD_MAX_TOTAL_SIZE must be at least UPLOAD_MAX_FILE_SIZE")
+	}
+	v.positive("UPLOAD_MAX_FILES", int64(c.Upload.MaxFiles))
+	if len(c.Upload.AllowedTypes) == 0 {
+		v.fail("UPLOAD_ALLOWED_TYPES must list at least one MIME type")
+	}
+	for _, mimeType := range c.Upload.AllowedTypes {
+		if kind, subtype, ok := strings.Cut(mimeType, "/"); !ok || kind == "" || subtype == "" {
+			v.fail("UPLOAD_ALLOWED_TYPES: %q is not a MIME type like image/jpeg", mimeType)
+		}
+	}
+	if _, err := ParseThumbnailSizes(c.Upload.ThumbnailSizes); err != nil {
+		v.fail("UPLOAD_THUMBNAIL_SIZES: %v", err)
+	}
+	v.duration("UPLOAD_PRESIGN_EXPIRY", c.Upload.PresignExpiry)
+	v.duration("UPLOAD_MAX_VIDEO_DURATION", c.Upload.MaxVideoDuration)
+	v.duration("UPLOAD_RESUMABLE_EXPIRY", c.Upload.ResumableExpiry)
+	v.oneOf("UPLOAD_SCAN_MODE", c.Upload.ScanMode, "", "off", "async", "blocking")
+	if c.Upload.ScanMode == "async" || c.Upload.ScanMode == "blocking" {
+		if c.Upload.ScanClamAVAddr == "" && c.Upload.ScanAPIURL == "" {
+			v.fail("UPLOAD_SCAN_MODE %s needs UPLOAD_SCAN_CLAMAV_ADDR or UPLOAD_SCAN_API_URL", c.Upload.ScanMode)
+		}
+		v.positiveDuration("UPLOAD_SCAN_TIMEOUT", c.Upload.ScanTimeout)
+	}
+	v.url("UPLOAD_SCAN_API_URL", c.Upload.ScanAPIURL)
+	v.positive("UPLOAD_RESIZE_MAX_DIMENSION", int64(c.Upload.ResizeMaxDimension))
+	v.positiveDuration("UPLOAD_RESIZE_CACHE_TTL", c.Upload.ResizeCacheTTL)
+
+	v.oneOf("EMAIL_PROVIDER", c.Email.Provider, "", "sendgrid", "smtp")
+	switch c.Email.Provider {
+	case "sendgrid":
+		v.required("SENDGRID_API_KEY", c.Email.APIKey)
+	case "smtp":
+		v.required("SMTP_HOST", c.Email.SMTPHost)
+	}
+	v.url("PUBLIC_SITE_URL", c.Email.SiteURL)
+
+	v.oneOf("TRACING_EXPORTER", c.Tracing.Exporter, "", "otlp", "jaeger")
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		v.fail("TRACING_SAMPLE_RATIO must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+	}
+
+	v.positive("JOB_WORKERS", int64(c.Jobs.Workers))
+	if c.RSVP.WriteBehindEnabled {
+		v.positive("RSVP_QUEUE_WORKERS", int64(c.RSVP.QueueWorkers))
+	}
+
+	v.rateLimit("LOGIN", c.RateLimit.LoginLimit, c.RateLimit.LoginWindow)
+	v.rateLimit("PASSWORD_RESET", c.RateLimit.PasswordResetLimit, c.RateLimit.PasswordResetWindow)
+	v.rateLimit("ACCOUNT_EMAIL", c.RateLimit.AccountEmailLimit, c.RateLimit.AccountEmailWindow)
+	v.rateLimit("PUBLIC_RSVP", c.RateLimit.PublicRSVPLimit, c.RateLimit.PublicRSVPWindow)
+	v.rateLimit("TRACKING", c.RateLimit.TrackingLimit, c.RateLimit.TrackingWindow)
+	v.rateLimit("UPLOADS", c.RateLimit.UploadsLimit, c.RateLimit.UploadsWindow)
+
+	v.retention("RETENTION_PAGE_VIEWS", c.Retention.PageViews, minAnalyticsRetention)
+	v.retention("RETENTION_RSVP_EVENTS", c.Retention.RSVPEvents, minAnalyticsRetention)
+	v.retention("RETENTION_CONVERSIONS", c.Retention.Conversions, minAnalyticsRetention)
+	v.retention("RETENTION_AUDIT_LOGS", c.Retention.AuditLogs, 24*time.Hour)
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+// validator collects the problems of a configuration
+type validator struct {
+	problems []string
+}
+
+func (v *validator) fail(format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) required(name, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.fail("%s is required", name)
+	}
+}
+
+func (v *validator) positive(name string, value int64) {
+	if value <= 0 {
+		v.fail("%s must be greater than 0, got %d", name, value)
+	}
+}
+
+func (v *validator) positiveDuration(name string, value time.Duration) {
+	if value <= 0 {
+		v.fail("%s must be a positive duration like 15m, got %s", name, value)
+	}
+}
+
+func (v *validator) oneOf(name, value string, allowed ...string) {
+	for _, option := range allowed {
+		if value == option {
+			return
+		}
+	}
+	var named []string
+	for _, option := range allowed {
+		if option != "" {
+			named = append(named, option)
+		}
+	}
+	v.fail("%s must be one of %s, got %q", name, strings.Join(named, ", "), value)
+}
+
+func (v *validator) port(name, value string) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		v.fail("%s must be a port number between 1 and 65535, got %q", name, value)
+	}
+}
+
+// url checks that an optional value is an absolute http(s) URL
+func (v *validator) url(name, value string) {
+	if value == "" {
+		return
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.fail("%s must be an absolute http or https URL, got %q", name, value)
+	}
+}
+
+// duration checks a duration kept as a string until the service using it parses it
+func (v *validator) duration(name, value string) {
+	if value == "" {
+		return
+	}
+	if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+		v.fail("%s must be a positive duration like 15m, got %q", name, value)
+	}
+}
+
+// secret checks that a signing secret is long enough to resist guessing
+func (v *validator) secret(name, value string) {
+	if len(value) < 32 {
+		v.fail("%s must be at least 32 characters in production", name)
+	}
+}
+
+// origins checks CORS origins: "*", or a scheme and host without a path,
+// the host possibly starting with "*." to allow its subdomains
+func (v *validator) origins(name string, origins []string) {
+	for _, origin := range origins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(strings.Replace(origin, "*.", "wildcard.", 1))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+			v.fail("%s: %q is not an origin like https://example.com", name, origin)
+		}
+	}
+}
+
+func (v *validator) rateLimit(policy string, limit int, window time.Duration) {
+	if limit < 0 {
+		v.fail("RATE_LIMIT_%s_LIMIT must not be negative, got %d", policy, limit)
+	}
+	if limit > 0 && window <= 0 {
+		v.fail("RATE_LIMIT_%s_WINDOW must be a positive duration when RATE_LIMIT_%s_LIMIT is set", policy, policy)
+	}
+}
+
+// retention checks a retention period: 0 keeps the data forever
+func (v *validator) retention(name string, period, min time.Duration) {
+	if period != 0 && period < min {
+		v.fail("%s must be 0 (keep forever) or at least %s, got %s", name, min, period)
+	}
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadDefaults(t *testing.T) *Config {
+	t.Helper()
+	cfg, err := Load()
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		want   []string
+	}{
+		{
+			name:   "defaults",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name: "production requires secrets",
+			modify: func(cfg *Config) {
+				cfg.Server.Environment = "production"
+				cfg.Auth.JWTSecret = "short"
+			},
+			want: []string{
+				"JWT_SECRET must be at least 32 characters in production",
+				"JWT_REFRESH_SECRET must be at least 32 characters in production",
+			},
+		},
+		{
+			name: "collects every problem",
+			modify: func(cfg *Config) {
+				cfg.Server.Environment = "prod"
+				cfg.Server.AllowedOrigins = []string{"https://*.example.com", "example.com", "https://example.com/app"}
+				cfg.Database.URI = "localhost:27017"
+				cfg.Storage.Provider = "s3"
+				cfg.Upload.BaseURL = "/uploads"
+				cfg.Upload.AllowedTypes = []string{"image/jpeg", "png"}
+				cfg.Upload.ThumbnailSizes = "small:150"
+				cfg.Upload.PresignExpiry = "soon"
+				cfg.Email.Provider = "sendgrid"
+				cfg.RateLimit.LoginWindow = 0
+			},
+			want: []string{
+				`APP_ENV must be one of development, test, staging, production, got "prod"`,
+				`ALLOWED_ORIGINS: "example.com" is not an origin like https://example.com`,
+				`ALLOWED_ORIGINS: "https://example.com/app" is not an origin like https://example.com`,
+				"MONGODB_URI must start with mongodb:// or mongodb+srv://",
+				"S3_BUCKET_NAME is required",
+				`UPLOAD_BASE_URL must be an absolute http or https URL, got "/uploads"`,
+				`UPLOAD_ALLOWED_TYPES: "png" is not a MIME type like image/jpeg`,
+				`UPLOAD_THUMBNAIL_SIZES: "small:150" is not name:WIDTHxHEIGHT`,
+				`UPLOAD_PRESIGN_EXPIRY must be a positive duration like 15m, got "soon"`,
+				"SENDGRID_API_KEY is required",
+				"RATE_LIMIT_LOGIN_WINDOW must be a positive duration when RATE_LIMIT_LOGIN_LIMIT is set",
+			},
+		},
+		{
+			name: "mongodb client options",
+			modify: func(cfg *Config) {
+				cfg.Database.MinPoolSize = 200
+				cfg.Database.ReadPreference = "secondaryOnly"
+				cfg.Database.OperationTimeout = 0
+			},
+			want: []string{
+				"MONGODB_MIN_POOL_SIZE must be at most MONGODB_MAX_POOL_SIZE",
+				`MONGODB_READ_PREFERENCE must be one of primary, primaryPreferred, secondary, secondaryPreferred, nearest, got "secondaryOnly"`,
+				"MONGODB_OPERATION_TIMEOUT must be a positive duration like 15m, got 0s",
+			},
+		},
+		{
+			name: "token lifetimes",
+			modify: func(cfg *Config) {
+				cfg.Auth.RefreshTokenTTL = cfg.Auth.AccessTokenTTL
+			},
+			want: []string{"JWT_REFRESH_TTL must be longer than JWT_ACCESS_TTL"},
+		},
+		{
+			name: "retention periods",
+			modify: func(cfg *Config) {
+				cfg.Retention.PageViews = 0
+				cfg.Retention.RSVPEvents = 30 * 24 * time.Hour
+				cfg.Retention.AuditLogs = time.Hour
+			},
+			want: []string{
+				"RETENTION_RSVP_EVENTS must be 0 (keep forever) or at least 2160h0m0s, got 720h0m0s",
+				"RETENTION_AUDIT_LOGS must be 0 (keep forever) or at least 24h0m0s, got 1h0m0s",
+			},
+		},
+		{
+			name: "malware scanning needs a scanner",
+			modify: func(cfg *Config) {
+				cfg.Upload.ScanMode = "async"
+				cfg.Upload.ScanAPIURL = "scanner.internal"
+			},
+			want: []string{`UPLOAD_SCAN_API_URL must be an absolute http or https URL, got "scanner.internal"`},
+		},
+		{
+			name: "malware scan mode",
+			modify: func(cfg *Config) {
+				cfg.Upload.ScanMode = "sync"
+			},
+			want: []string{`UPLOAD_SCAN_MODE must be one of off, async, blocking, got "sync"`},
+		},
+		{
+			name: "malware scanner address",
+			modify: func(cfg *Config) {
+				cfg.Upload.ScanMode = "blocking"
+			},
+			want: []string{"UPLOAD_SCAN_MODE blocking needs UPLOAD_SCAN_CLAMAV_ADDR or UPLOAD_SCAN_API_URL"},
+		},
+		{
+			name: "image resize limits",
+			modify: func(cfg *Config) {
+				cfg.Upload.ResizeMaxDimension = 0
+				cfg.Upload.ResizeCacheTTL = 0
+			},
+			want: []string{
+				"UPLOAD_RESIZE_MAX_DIMENSION must be greater than 0, got 0",
+				"UPLOAD_RESIZE_CACHE_TTL must be a positive duration like 15m, got 0s",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadDefaults(t)
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.want, validationErr.Problems)
+			assert.Contains(t, err.Error(), "invalid configuration:\n  - ")
+		})
+	}
+}
+
+func TestParseThumbnailSizes(t *testing.T) {
+	sizes, err := ParseThumbnailSizes("small:150x150, large:800x600")
+	require.NoError(t, err)
+	assert.Equal(t, []ThumbnailSpec{
+		{Name: "small", Width: 150, Height: 150},
+		{Name: "large", Width: 800, Height: 600},
+	}, sizes)
+
+	for _, value := range []string{"small", "small:0x150", "small:axb", ":150x150", "small:150x150,small:200x200"} {
+		_, err := ParseThumbnailSizes(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestRequiresRestart(t *testing.T) {
+	current := loadDefaults(t)
+	next := loadDefaults(t)
+	next.Server.AllowedOrigins = []string{"https://example.com"}
+	next.RateLimit.LoginLimit = 1
+	next.RateLimit.LoginWindow = time.Second
+	assert.Empty(t, current.RequiresRestart(next))
+
+	next.Server.Port = "9000"
+	next.Upload.MaxFiles = 1
+	assert.Equal(t, []string{"PORT", "UPLOAD_MAX_FILES"}, current.RequiresRestart(next))
+}
@@ -0,0 +1,90 @@
+// Package errs defines the kinds of errors the domain reports, so that callers
+// tell a missing record from a forbidden or invalid request with errors.Is
+// instead of matching error messages. Services declare their own sentinels of
+// a kind, e.g. NotFound("wedding not found"), and wrap them with %w as usual.
+package errs
+
+import "errors"
+
+// Kinds of domain errors
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrForbidden  = errors.New("forbidden")
+	ErrValidation = errors.New("validation failed")
+	ErrConflict   = errors.New("conflict")
+)
+
+// Error is a domain error of one kind. errors.Is matches both the error itself
+// and its kind.
+type Error struct {
+	Kind    error
+	Message string
+}
+
+// Error returns the message
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is the kind of the error
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// NotFound returns an error of a record that does not exist, or that the caller
+// may not know exists
+func NotFound(message string) error {
+	return &Error{Kind: ErrNotFound, Message: message}
+}
+
+// Forbidden returns an error of an action the caller is not allowed to take
+func Forbidden(message string) error {
+	return &Error{Kind: ErrForbidden, Message: message}
+}
+
+// Conflict returns an error of a change that clashes with the stored state,
+// such as a duplicate
+func Conflict(message string) error {
+	return &Error{Kind: ErrConflict, Message: message}
+}
+
+// FieldError describes why the value of one field is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is an invalid request, with the fields at fault when known
+type ValidationError struct {
+	Message string
+	Fields  []FieldError
+}
+
+// Error returns the message; the field errors are details of it
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is ErrValidation
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Validation returns an error of an invalid request, naming the invalid fields
+func Validation(message string, fields ...FieldError) error {
+	return &ValidationError{Message: message, Fields: fields}
+}
+
+// InvalidField returns an error of a request with one invalid field
+func InvalidField(field, message string) error {
+	return &ValidationError{Message: message, Fields: []FieldError{{Field: field, Message: message}}}
+}
+
+// Fields returns the field errors of a validation error in err's chain
+func Fields(err error) []FieldError {
+	var validation *ValidationError
+	if errors.As(err, &validation) {
+		return validation.Fields
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKinds(t *testing.T) {
+	errWeddingNotFound := NotFound("wedding not found")
+	wrapped := fmt.Errorf("failed to publish wedding: %w", errWeddingNotFound)
+
+	assert.ErrorIs(t, wrapped, errWeddingNotFound)
+	assert.ErrorIs(t, wrapped, ErrNotFound)
+	assert.NotErrorIs(t, wrapped, ErrForbidden)
+	assert.NotErrorIs(t, NotFound("wedding not found"), errWeddingNotFound)
+	assert.Equal(t, "wedding not found", errWeddingNotFound.Error())
+
+	assert.ErrorIs(t, Forbidden("access denied"), ErrForbidden)
+	assert.ErrorIs(t, Conflict("email already exists"), ErrConflict)
+}
+
+func TestValidation(t *testing.T) {
+	err := fmt.Errorf("failed to update user: %w", InvalidField("role", "invalid user role"))
+
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Equal(t, "failed to update user: invalid user role", err.Error())
+	assert.Equal(t, []FieldError{{Field: "role", Message: "invalid user role"}}, Fields(err))
+	assert.Nil(t, Fields(errors.New("boom")))
+}
@@ -2,18 +2,19 @@ package models
 
 import (
 	"time"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // PageView represents a page view event for analytics
 type PageView struct {
-	ID           primitive.ObjectID          `bson:"_id,omitempty" json:"id"`
-	WeddingID    primitive.ObjectID          `bson:"wedding_id" json:"wedding_id"`
+	ID           ID          `bson:"_id,omitempty" json:"id"`
+	WeddingID    ID          `bson:"wedding_id" json:"wedding_id"`
 	SessionID    string                      `bson:"session_id" json:"session_id"`
 	IPAddress    string                      `bson:"ip_address" json:"-"`
 	UserAgent    string                      `bson:"user_agent" json:"-"`
 	Referrer     string                      `bson:"referrer,omitempty" json:"-"`
+	Source       string                      `bson:"source,omitempty" json:"source"`     // Normalized traffic source, e.g. "google", "facebook", "newsletter"
+	Medium       string                      `bson:"medium,omitempty" json:"medium"`     // e.g. "organic", "social", "referral", "email"
+	Campaign     string                      `bson:"campaign,omitempty" json:"campaign"` // utm_campaign
 	Page         string                      `bson:"page" json:"page"` // e.g., "invitation", "rsvp", "gallery"
 	Timestamp    time.Time                   `bson:"timestamp" json:"timestamp"`
 	Duration     int64                       `bson:"duration,omitempty" json:"duration"` // Time spent on page in seconds
@@ -22,14 +23,15 @@ type PageView struct {
 	OS           string                      `bson:"os,omitempty" json:"os"`
 	Country      string                      `bson:"country,omitempty" json:"country"`
 	City         string                      `bson:"city,omitempty" json:"city"`
+	IsBot        bool                        `bson:"is_bot,omitempty" json:"is_bot"` // Crawler or automated browser; left out of every count
 	Metadata     map[string]interface{}      `bson:"metadata,omitempty" json:"metadata,omitempty"`
 }
 
 // RSVPAnalytics represents analytics data for RSVP submissions
 type RSVPAnalytics struct {
-	ID              primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
-	WeddingID       primitive.ObjectID   `bson:"wedding_id" json:"wedding_id"`
-	RSVPID          primitive.ObjectID   `bson:"rsvp_id" json:"rsvp_id"`
+	ID              ID   `bson:"_id,omitempty" json:"id"`
+	WeddingID       ID   `bson:"wedding_id" json:"wedding_id"`
+	RSVPID          ID   `bson:"rsvp_id" json:"rsvp_id"`
 	SessionID       string               `bson:"session_id" json:"session_id"`
 	TimeToComplete  int64                `bson:"time_to_complete" json:"time_to_complete"` // Seconds from page view to submission
 	Source          string               `bson:"source" json:"source"`                    // web, direct_link, qr_code, manual
@@ -43,8 +45,8 @@ type RSVPAnalytics struct {
 
 // ConversionEvent represents conversion events
 type ConversionEvent struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	WeddingID  primitive.ObjectID `bson:"wedding_id" json:"wedding_id"`
+	ID         ID `bson:"_id,omitempty" json:"id"`
+	WeddingID  ID `bson:"wedding_id" json:"wedding_id"`
 	SessionID  string             `bson:"session_id" json:"session_id"`
 	Event      string             `bson:"event" json:"event"` // rsvp_started, rsvp_completed, share_clicked, etc.
 	Value      float64            `bson:"value,omitempty" json:"value"` // Optional value (e.g., for goal tracking)
@@ -55,7 +57,7 @@ type ConversionEvent struct {
 
 // WeddingAnalytics represents aggregated analytics for a wedding
 type WeddingAnalytics struct {
-	WeddingID           primitive.ObjectID          `bson:"_id" json:"wedding_id"`
+	WeddingID           ID          `bson:"_id" json:"wedding_id"`
 	PageViews           int64                       `bson:"page_views" json:"page_views"`
 	UniqueSessions      int64                       `bson:"unique_sessions" json:"unique_sessions"`
 	RSVPCount           int64                       `bson:"rsvp_count" json:"rsvp_count"`
@@ -64,11 +66,41 @@ type WeddingAnalytics struct {
 	PopularPages        map[string]int64            `bson:"popular_pages" json:"popular_pages"`
 	TrafficSources      map[string]int64            `bson:"traffic_sources" json:"traffic_sources"`
 	DeviceBreakdown     map[string]int64            `bson:"device_breakdown" json:"device_breakdown"`
+	CountryBreakdown    map[string]int64            `bson:"country_breakdown" json:"country_breakdown"` // Page views by ISO country code
 	ViewsByDate         map[string]int64            `bson:"views_by_date" json:"views_by_date"`
 	RSVPsByDate         map[string]int64            `bson:"rsvps_by_date" json:"rsvps_by_date"`
-	AverageTimeOnPage   float64                     `bson:"average_time_on_page" json:"average_time_on_page"`
-	BounceRate          float64                     `bson:"bounce_rate" json:"bounce_rate"`
+	AverageTimeOnPage   float64                     `bson:"average_time_on_page" json:"average_time_on_page"` // Seconds, over page views that reported a duration
+	BounceRate          float64                     `bson:"bounce_rate" json:"bounce_rate"`                   // Single-page sessions / sessions
+	BouncedSessions     int64                       `bson:"bounced_sessions" json:"bounced_sessions"`
+	BotPageViews        int64                       `bson:"bot_page_views" json:"bot_page_views"` // Page views of bots, not counted in PageViews
+	BotPercentage       float64                     `bson:"bot_percentage" json:"bot_percentage"` // Bot page views / all page views
+	TotalDuration       int64                       `bson:"total_duration" json:"-"` // Seconds
+	TimedPageViews      int64                       `bson:"timed_page_views" json:"-"`
 	LastUpdated         time.Time                   `bson:"last_updated" json:"last_updated"`
+	ReconciledAt        *time.Time                  `bson:"reconciled_at,omitempty" json:"reconciled_at,omitempty"` // Last reconciliation with the raw events
+	Wishes              int64                       `bson:"-" json:"wishes"`         // Approved guestbook wishes
+	PendingWishes       int64                       `bson:"-" json:"pending_wishes"` // Guestbook wishes awaiting approval
+}
+
+// AnalyticsDailyBucket holds a wedding's analytics counters for one UTC day.
+// Tracked events increment them, so dashboards read counters instead of
+// aggregating raw events, and they outlive the raw events' retention.
+type AnalyticsDailyBucket struct {
+	WeddingID   ID `bson:"wedding_id" json:"wedding_id"`
+	Date        string             `bson:"date" json:"date"` // YYYY-MM-DD
+	PageViews   int64              `bson:"page_views" json:"page_views"`
+	Sessions    int64              `bson:"sessions" json:"sessions"`         // Sessions active that day
+	NewSessions int64              `bson:"new_sessions" json:"new_sessions"` // Sessions first seen that day
+	RSVPs       int64              `bson:"rsvps" json:"rsvps"`
+	Bounces     int64              `bson:"bounces" json:"bounces"`         // Sessions first seen that day with a single page view
+	Duration    int64              `bson:"duration" json:"duration"`       // Seconds spent on the day's page views
+	TimedViews  int64              `bson:"timed_views" json:"timed_views"` // Page views that reported a duration
+	BotPageViews int64             `bson:"bot_page_views" json:"bot_page_views"` // Page views of bots, not counted in PageViews
+	Pages       map[string]int64   `bson:"pages,omitempty" json:"pages,omitempty"`
+	Devices     map[string]int64   `bson:"devices,omitempty" json:"devices,omitempty"`
+	Sources     map[string]int64   `bson:"sources,omitempty" json:"sources,omitempty"` // Sessions first seen that day, by traffic source
+	Countries   map[string]int64   `bson:"countries,omitempty" json:"countries,omitempty"` // Page views by ISO country code
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
 }
 
 // SystemAnalytics represents system-wide analytics
@@ -89,7 +121,7 @@ type SystemAnalytics struct {
 
 // AnalyticsFilter represents filters for analytics queries
 type AnalyticsFilter struct {
-	WeddingID    *primitive.ObjectID `json:"wedding_id,omitempty"`
+	WeddingID    *ID `json:"wedding_id,omitempty"`
 	StartDate    *time.Time          `json:"start_date,omitempty"`
 	EndDate      *time.Time          `json:"end_date,omitempty"`
 	Device       string              `json:"device,omitempty"`
@@ -128,6 +160,21 @@ type TrafficSourceStats struct {
 	Views    int64  `json:"views"`
 }
 
+// CountryStats represents statistics for the visitors of one country
+type CountryStats struct {
+	Country  string      `json:"country"` // ISO 3166-1 alpha-2 code
+	Visitors int64       `json:"visitors"`
+	Views    int64       `json:"views"`
+	Cities   []CityStats `json:"cities"`
+}
+
+// CityStats represents statistics for the visitors of one city
+type CityStats struct {
+	City     string `json:"city"`
+	Visitors int64  `json:"visitors"`
+	Views    int64  `json:"views"`
+}
+
 // DailyMetrics represents metrics for a specific day
 type DailyMetrics struct {
 	Date       string  `json:"date"`
@@ -135,4 +182,29 @@ type DailyMetrics struct {
 	Sessions   int64   `json:"sessions"`
 	RSVPs      int64   `json:"rsvps"`
 	Conversions float64 `json:"conversion_rate"`
-}
\ No newline at end of file
+	BounceRate  float64 `json:"bounce_rate"`
+	AvgTime     float64 `json:"avg_time_on_page"`
+}
+// AnalyticsLiveEventType identifies the kind of event pushed to live dashboards
+type AnalyticsLiveEventType string
+
+const (
+	AnalyticsLivePageView      AnalyticsLiveEventType = "page_view"
+	AnalyticsLiveRSVPSubmitted AnalyticsLiveEventType = "rsvp_submitted"
+	AnalyticsLiveRSVPAbandoned AnalyticsLiveEventType = "rsvp_abandoned"
+	AnalyticsLiveConversion    AnalyticsLiveEventType = "conversion"
+)
+
+// AnalyticsLiveEvent is a tracked event pushed to a wedding's analytics stream.
+// It carries no visitor IP or user agent.
+type AnalyticsLiveEvent struct {
+	Type      AnalyticsLiveEventType `json:"type"`
+	WeddingID ID     `json:"wedding_id"`
+	SessionID string                 `json:"session_id"`
+	Page      string                 `json:"page,omitempty"`
+	Device    string                 `json:"device,omitempty"`
+	Source    string                 `json:"source,omitempty"`
+	Event     string                 `json:"event,omitempty"`
+	Value     float64                `json:"value,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
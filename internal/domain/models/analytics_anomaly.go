@@ -0,0 +1,125 @@
+package models
+
+import "time"
+
+// AnomalyMetric is the hourly volume an anomaly was found in
+type AnomalyMetric string
+
+const (
+	AnomalyMetricPageViews AnomalyMetric = "page_views"
+	AnomalyMetricRSVPs     AnomalyMetric = "rsvps"
+)
+
+// AnomalyKind tells whether the volume rose above or fell below its baseline
+type AnomalyKind string
+
+const (
+	AnomalySpike AnomalyKind = "spike"
+	AnomalyDrop  AnomalyKind = "drop"
+)
+
+// Channels anomaly alerts are sent through besides the analytics dashboard
+const (
+	AnomalyChannelEmail   = "email"
+	AnomalyChannelWebhook = "webhook"
+)
+
+// Defaults of the anomaly alert thresholds
+const (
+	DefaultAnomalySpikeFactor = 3.0
+	DefaultAnomalyDropRatio   = 0.2
+	DefaultAnomalyMinVolume   = 20.0
+)
+
+// AnomalyAlertSettings are the thresholds of a wedding's alerts on unusual
+// hourly traffic and RSVP volume. The zero value alerts with the defaults
+// through every channel.
+type AnomalyAlertSettings struct {
+	// Disabled stops detecting anomalies of the wedding
+	Disabled bool `bson:"disabled" json:"disabled"`
+	// SpikeFactor is how many times its baseline an hour's volume has to reach to
+	// be a spike; 0 means 3
+	SpikeFactor float64 `bson:"spike_factor,omitempty" json:"spike_factor,omitempty" validate:"omitempty,min=1.5,max=100"`
+	// DropRatio is the share of its baseline an hour's volume has to fall to, or
+	// below, to be a drop; 0 means 0.2
+	DropRatio float64 `bson:"drop_ratio,omitempty" json:"drop_ratio,omitempty" validate:"omitempty,gt=0,lt=1"`
+	// MinVolume is how many events an hour needs for a spike, and its baseline
+	// for a drop, so that quiet weddings do not alert on noise; 0 means 20
+	MinVolume float64 `bson:"min_volume,omitempty" json:"min_volume,omitempty" validate:"omitempty,min=1,max=100000"`
+	// Channels are where alerts are sent besides the dashboard, email and
+	// webhook; empty sends them to both
+	Channels []string `bson:"channels,omitempty" json:"channels,omitempty" validate:"omitempty,max=2,dive,oneof=email webhook"`
+}
+
+// Spike returns the spike factor, or its default
+func (s AnomalyAlertSettings) Spike() float64 {
+	if s.SpikeFactor <= 0 {
+		return DefaultAnomalySpikeFactor
+	}
+	return s.SpikeFactor
+}
+
+// Drop returns the drop ratio, or its default
+func (s AnomalyAlertSettings) Drop() float64 {
+	if s.DropRatio <= 0 {
+		return DefaultAnomalyDropRatio
+	}
+	return s.DropRatio
+}
+
+// Volume returns the minimum volume, or its default
+func (s AnomalyAlertSettings) Volume() float64 {
+	if s.MinVolume <= 0 {
+		return DefaultAnomalyMinVolume
+	}
+	return s.MinVolume
+}
+
+// Sends reports whether alerts are sent through the channel
+func (s AnomalyAlertSettings) Sends(channel string) bool {
+	if len(s.Channels) == 0 {
+		return true
+	}
+	for _, c := range s.Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// Detect compares an hour's volume with its baseline, the average volume of
+// the same hour on previous days, and returns the kind of anomaly it is, if any
+func (s AnomalyAlertSettings) Detect(value, baseline float64) (AnomalyKind, bool) {
+	switch {
+	case value >= s.Volume() && value >= baseline*s.Spike():
+		return AnomalySpike, true
+	case baseline >= s.Volume() && value <= baseline*s.Drop():
+		return AnomalyDrop, true
+	}
+	return "", false
+}
+
+// HourlyActivity is how many page views and RSVPs a wedding got in one hour
+type HourlyActivity struct {
+	WeddingID ID
+	Hour      time.Time
+	PageViews int64
+	RSVPs     int64
+}
+
+// AnalyticsAnomaly is an hour in which a wedding's traffic or RSVP volume
+// strayed from its baseline, e.g. a spike after the invitation was shared or
+// a drop after a slug change broke the printed links
+type AnalyticsAnomaly struct {
+	ID        ID            `bson:"_id,omitempty" json:"id"`
+	WeddingID ID            `bson:"wedding_id" json:"wedding_id"`
+	Metric    AnomalyMetric `bson:"metric" json:"metric"`
+	Kind      AnomalyKind   `bson:"kind" json:"kind"`
+	// Hour is the start of the hour the volume was counted in
+	Hour  time.Time `bson:"hour" json:"hour"`
+	Value int64     `bson:"value" json:"value"`
+	// Baseline is the average volume of the same hour on the previous days
+	Baseline   float64   `bson:"baseline" json:"baseline"`
+	DetectedAt time.Time `bson:"detected_at" json:"detected_at"`
+}
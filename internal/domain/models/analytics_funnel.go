@@ -0,0 +1,58 @@
+package models
+
+// RSVPFormSteps are the steps of the RSVP form, in order. Abandonment events
+// name the step the guest left on.
+var RSVPFormSteps = []string{"personal_info", "attending_status", "guest_count", "dietary_restrictions", "confirmation"}
+
+// Steps of the RSVP funnel around the form steps
+const (
+	FunnelStepViewedInvitation = "viewed_invitation"
+	FunnelStepStartedRSVP      = "started_rsvp"
+	FunnelStepCompleted        = "completed"
+)
+
+// RSVPFunnelSteps lists every step of the RSVP funnel, in order
+func RSVPFunnelSteps() []string {
+	steps := make([]string, 0, len(RSVPFormSteps)+3)
+	steps = append(steps, FunnelStepViewedInvitation, FunnelStepStartedRSVP)
+	steps = append(steps, RSVPFormSteps...)
+	return append(steps, FunnelStepCompleted)
+}
+
+// RSVPFunnelSession is what one session did in the RSVP flow, as its tracked
+// events tell
+type RSVPFunnelSession struct {
+	SessionID        string
+	Device           string
+	ViewedInvitation bool
+	StartedRSVP      bool
+	AbandonedSteps   []string
+	Completed        bool
+}
+
+// RSVPFunnelStep is how many sessions reached a step of the RSVP funnel
+type RSVPFunnelStep struct {
+	Step     string `json:"step"`
+	Sessions int64  `json:"sessions"`
+	// DropOff is how many sessions of the previous step did not reach this one
+	DropOff int64 `json:"drop_off"`
+	// DropOffRate is DropOff as a percentage of the previous step
+	DropOffRate float64 `json:"drop_off_rate"`
+	// ConversionRate is Sessions as a percentage of the first step
+	ConversionRate float64 `json:"conversion_rate"`
+}
+
+// RSVPFunnelDevice is the RSVP funnel of the sessions on one kind of device
+type RSVPFunnelDevice struct {
+	Device string           `json:"device"`
+	Steps  []RSVPFunnelStep `json:"steps"`
+}
+
+// RSVPFunnel is the step by step drop-off of a wedding's RSVP flow over a range
+// of days, overall and by device
+type RSVPFunnel struct {
+	StartDate string             `json:"start_date"`
+	EndDate   string             `json:"end_date"`
+	Steps     []RSVPFunnelStep   `json:"steps"`
+	Devices   []RSVPFunnelDevice `json:"devices"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// Benchmark metrics compared between a user's weddings and the platform
+const (
+	BenchmarkConversionRate    = "conversion_rate"
+	BenchmarkBounceRate        = "bounce_rate"
+	BenchmarkAverageTimeOnPage = "average_time_on_page"
+	BenchmarkPageViews         = "page_views"
+)
+
+// BenchmarkStat summarizes one metric over the weddings of the platform
+type BenchmarkStat struct {
+	Average float64 `bson:"average" json:"average"`
+	Median  float64 `bson:"median" json:"median"`
+}
+
+// AnalyticsBenchmarks are anonymized platform-wide figures the analytics of
+// one wedding are compared with. Only weddings with enough page views to have
+// meaningful rates are included.
+type AnalyticsBenchmarks struct {
+	// Weddings is how many weddings the figures cover
+	Weddings   int64                    `bson:"weddings" json:"weddings"`
+	Metrics    map[string]BenchmarkStat `bson:"metrics" json:"metrics"`
+	ComputedAt time.Time                `bson:"computed_at" json:"computed_at"`
+}
+
+// WeddingAnalyticsOverview is one wedding's row of a user's analytics overview
+type WeddingAnalyticsOverview struct {
+	WeddingID         ID      `json:"wedding_id"`
+	Title             string  `json:"title"`
+	Slug              string  `json:"slug"`
+	Status            string  `json:"status"`
+	PageViews         int64   `json:"page_views"`
+	UniqueSessions    int64   `json:"unique_sessions"`
+	RSVPCount         int64   `json:"rsvp_count"`
+	ConversionRate    float64 `json:"conversion_rate"`
+	BounceRate        float64 `json:"bounce_rate"`
+	AverageTimeOnPage float64 `json:"average_time_on_page"`
+}
+
+// SourceStats is how many sessions came from a traffic source
+type SourceStats struct {
+	Source   string `json:"source"`
+	Sessions int64  `json:"sessions"`
+}
+
+// BenchmarkComparison sets a metric of the user's weddings against the platform
+type BenchmarkComparison struct {
+	Metric  string  `json:"metric"`
+	Value   float64 `json:"value"`
+	Average float64 `json:"platform_average"`
+	Median  float64 `json:"platform_median"`
+	// Difference is Value minus the platform average, as a percentage of the average
+	Difference float64 `json:"difference"`
+}
+
+// UserAnalyticsOverview aggregates the analytics of every wedding a user may
+// view and compares them with the platform benchmarks
+type UserAnalyticsOverview struct {
+	Weddings       []WeddingAnalyticsOverview `json:"weddings"`
+	PageViews      int64                      `json:"page_views"`
+	UniqueSessions int64                      `json:"unique_sessions"`
+	RSVPCount      int64                      `json:"rsvp_count"`
+	CompletedRSVPs int64                      `json:"completed_rsvps"`
+	// ConversionRate is RSVPs per hundred page views over all the weddings
+	ConversionRate    float64       `json:"conversion_rate"`
+	BounceRate        float64       `json:"bounce_rate"`
+	AverageTimeOnPage float64       `json:"average_time_on_page"`
+	TopSources        []SourceStats `json:"top_sources"`
+	// Benchmarks is empty until the platform figures cover enough weddings to
+	// stay anonymous
+	Benchmarks           []BenchmarkComparison `json:"benchmarks"`
+	BenchmarksComputedAt *time.Time            `json:"benchmarks_computed_at,omitempty"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+)
+
+// AnalyticsReportFrequency represents how often an analytics digest is emailed
+type AnalyticsReportFrequency string
+
+const (
+	AnalyticsReportWeekly  AnalyticsReportFrequency = "weekly"
+	AnalyticsReportMonthly AnalyticsReportFrequency = "monthly"
+)
+
+// analyticsReportHour is the UTC hour at which digests are sent
+const analyticsReportHour = 8
+
+// AnalyticsReportSettings is a wedding owner's opt-in to emailed analytics digests
+type AnalyticsReportSettings struct {
+	ID         ID                       `bson:"_id,omitempty" json:"id"`
+	WeddingID  ID                       `bson:"wedding_id" json:"wedding_id"`
+	UserID     ID                       `bson:"user_id" json:"user_id"`
+	Enabled    bool                     `bson:"enabled" json:"enabled"`
+	Frequency  AnalyticsReportFrequency `bson:"frequency" json:"frequency"`
+	LastSentAt *time.Time               `bson:"last_sent_at,omitempty" json:"last_sent_at,omitempty"`
+	LastError  string                   `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	NextSendAt time.Time                `bson:"next_send_at" json:"next_send_at"`
+	CreatedAt  time.Time                `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time                `bson:"updated_at" json:"updated_at"`
+}
+
+// IsValid checks whether the frequency is supported
+func (f AnalyticsReportFrequency) IsValid() bool {
+	return f == AnalyticsReportWeekly || f == AnalyticsReportMonthly
+}
+
+// NextRun returns the first send time strictly after the given time: Mondays for
+// weekly digests and the first of the month for monthly ones, at 08:00 UTC
+func (f AnalyticsReportFrequency) NextRun(after time.Time) time.Time {
+	after = after.UTC()
+	day := time.Date(after.Year(), after.Month(), after.Day(), analyticsReportHour, 0, 0, 0, time.UTC)
+
+	switch f {
+	case AnalyticsReportMonthly:
+		next := time.Date(after.Year(), after.Month(), 1, analyticsReportHour, 0, 0, 0, time.UTC)
+		if !next.After(after) {
+			next = next.AddDate(0, 1, 0)
+		}
+		return next
+	default:
+		daysUntilMonday := (int(time.Monday) - int(day.Weekday()) + 7) % 7
+		next := day.AddDate(0, 0, daysUntilMonday)
+		if !next.After(after) {
+			next = next.AddDate(0, 0, 7)
+		}
+		return next
+	}
+}
+
+// IsDue checks whether the digest should be sent at the given time
+func (s *AnalyticsReportSettings) IsDue(now time.Time) bool {
+	return s.Enabled && !now.Before(s.NextSendAt)
+}
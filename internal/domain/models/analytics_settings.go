@@ -0,0 +1,33 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// AnalyticsSettings controls how visits to a wedding are tracked. The zero
+// value records every event, as weddings saved before the settings existed do.
+type AnalyticsSettings struct {
+	// Disabled stops recording the wedding's events altogether
+	Disabled bool `bson:"disabled" json:"disabled"`
+	// SampleRate is the share of sessions whose page views are recorded, from
+	// 0 to 1; 0 records them all. Counts of a sampled wedding cover the
+	// recorded sessions only.
+	SampleRate float64 `bson:"sample_rate,omitempty" json:"sample_rate,omitempty" validate:"min=0,max=1"`
+	// RespectDoNotTrack skips the events of visitors whose browser sends Do Not
+	// Track or Global Privacy Control
+	RespectDoNotTrack bool `bson:"respect_do_not_track" json:"respect_do_not_track"`
+	// Alerts are the thresholds of the alerts on unusual traffic and RSVP volume
+	Alerts AnomalyAlertSettings `bson:"alerts" json:"alerts"`
+}
+
+// Samples reports whether the page views of a session of the wedding are
+// recorded. A session is always either in or out of the sample, so its views
+// and durations stay together.
+func (s AnalyticsSettings) Samples(weddingID ID, sessionID string) bool {
+	if s.SampleRate <= 0 || s.SampleRate >= 1 {
+		return true
+	}
+	sum := sha256.Sum256([]byte(weddingID.String() + "\x00" + sessionID))
+	return float64(binary.BigEndian.Uint32(sum[:4]))/(1<<32) < s.SampleRate
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+)
+
+// APIKeyAccess is what an API key may do with one wedding
+type APIKeyAccess string
+
+const (
+	APIKeyAccessRead      APIKeyAccess = "read"
+	APIKeyAccessReadWrite APIKeyAccess = "read_write"
+)
+
+// IsValid checks whether the access level is supported
+func (a APIKeyAccess) IsValid() bool {
+	return a == APIKeyAccessRead || a == APIKeyAccessReadWrite
+}
+
+// APIKeyScope grants an API key access to one wedding
+type APIKeyScope struct {
+	WeddingID ID           `bson:"wedding_id" json:"wedding_id"`
+	Access    APIKeyAccess `bson:"access" json:"access"`
+}
+
+// APIKey lets a third-party integration, such as a wedding-planner tool, call the
+// API on behalf of a user for the weddings it is scoped to. Only a hash of the
+// key is stored; the prefix identifies it in the dashboard.
+type APIKey struct {
+	ID        ID            `bson:"_id,omitempty" json:"id"`
+	UserID    ID            `bson:"user_id" json:"user_id"`
+	Name      string        `bson:"name" json:"name"`
+	Prefix    string        `bson:"prefix" json:"prefix"`
+	KeyHash   string        `bson:"key_hash" json:"-"`
+	Scopes    []APIKeyScope `bson:"scopes" json:"scopes"`
+	RateLimit int           `bson:"rate_limit" json:"rate_limit"` // Requests per minute
+
+	RequestCount int64      `bson:"request_count" json:"request_count"`
+	LastUsedAt   *time.Time `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	ExpiresAt    *time.Time `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+	RevokedAt    *time.Time `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// IsActive reports whether the key can still authenticate requests
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// Allows reports whether the key may read, or with write also change, the wedding
+func (k *APIKey) Allows(weddingID ID, write bool) bool {
+	for _, scope := range k.Scopes {
+		if scope.WeddingID == weddingID {
+			return !write || scope.Access == APIKeyAccessReadWrite
+		}
+	}
+	return false
+}
+
+// APIKeyUsage counts the requests made with an API key on one day (UTC)
+type APIKeyUsage struct {
+	KeyID       ID        `bson:"key_id" json:"key_id"`
+	Date        string    `bson:"date" json:"date"` // YYYY-MM-DD
+	Requests    int64     `bson:"requests" json:"requests"`
+	Errors      int64     `bson:"errors" json:"errors"`             // Responses with a 4xx or 5xx status
+	RateLimited int64     `bson:"rate_limited" json:"rate_limited"` // Requests turned away by the key's rate limit
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+}
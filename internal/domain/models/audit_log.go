@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+)
+
+// Kinds of records audit log entries are about
+const (
+	AuditTargetWedding = "wedding"
+	AuditTargetGuest   = "guest"
+	AuditTargetRSVP    = "rsvp"
+	AuditTargetMedia   = "media"
+	AuditTargetTheme   = "theme"
+	AuditTargetUser    = "user"
+)
+
+// Audited actions, named <target>.<verb>
+const (
+	AuditWeddingCreate        = "wedding.create"
+	AuditWeddingUpdate        = "wedding.update"
+	AuditWeddingPublish       = "wedding.publish"
+	AuditWeddingDelete        = "wedding.delete"
+	AuditWeddingModerate      = "wedding.moderate"
+	AuditWeddingPreviewLink   = "wedding.preview_link"
+	AuditWeddingPreviewRevoke = "wedding.preview_revoke"
+	AuditGuestCreate          = "guest.create"
+	AuditGuestImport          = "guest.import"
+	AuditGuestUpdate          = "guest.update"
+	AuditGuestDelete          = "guest.delete"
+	AuditGuestMerge           = "guest.merge"
+	AuditRSVPCreate           = "rsvp.create"
+	AuditRSVPUpdate           = "rsvp.update"
+	AuditRSVPReview           = "rsvp.review"
+	AuditRSVPDelete           = "rsvp.delete"
+	AuditMediaUpload          = "media.upload"
+	AuditMediaDelete          = "media.delete"
+	AuditThemeCreate          = "theme.create"
+	AuditThemeUpdate          = "theme.update"
+	AuditThemeDelete          = "theme.delete"
+	AuditUserStatus           = "user.status_change"
+	AuditUserRole             = "user.role_change"
+	AuditUserDelete           = "user.delete"
+	AuditUserErase            = "user.erase"
+	AuditUser2FAEnable        = "user.2fa_enable"
+	AuditUser2FADisable       = "user.2fa_disable"
+)
+
+// AuditLog records who changed what and from where. Entries without an actor
+// were made by guests through public links.
+type AuditLog struct {
+	ID         ID                     `bson:"_id,omitempty" json:"id"`
+	ActorID    *ID                    `bson:"actor_id,omitempty" json:"actor_id,omitempty"`
+	APIKeyID   *ID                    `bson:"api_key_id,omitempty" json:"api_key_id,omitempty"`
+	Action     string                 `bson:"action" json:"action"`
+	TargetType string                 `bson:"target_type" json:"target_type"`
+	TargetID   string                 `bson:"target_id" json:"target_id"`
+	WeddingID  *ID                    `bson:"wedding_id,omitempty" json:"wedding_id,omitempty"`
+	Changes    []AuditChange          `bson:"changes,omitempty" json:"changes,omitempty"`
+	Metadata   map[string]interface{} `bson:"metadata,omitempty" json:"metadata,omitempty"`
+	IPAddress  string                 `bson:"ip_address,omitempty" json:"ip_address,omitempty"`
+	UserAgent  string                 `bson:"user_agent,omitempty" json:"user_agent,omitempty"`
+	RequestID  string                 `bson:"request_id,omitempty" json:"request_id,omitempty"`
+	CreatedAt  time.Time              `bson:"created_at" json:"created_at"`
+}
+
+// AuditChange is the value of a field before and after an audited change.
+// Before is empty for created records and After for deleted ones.
+type AuditChange struct {
+	Field  string      `bson:"field" json:"field"`
+	Before interface{} `bson:"before,omitempty" json:"before,omitempty"`
+	After  interface{} `bson:"after,omitempty" json:"after,omitempty"`
+}
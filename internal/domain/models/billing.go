@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+)
+
+// PlanTier identifies a subscription plan
+type PlanTier string
+
+const (
+	PlanFree    PlanTier = "free"
+	PlanPremium PlanTier = "premium"
+)
+
+// PlanLimits bounds what the users of a plan may create
+type PlanLimits struct {
+	Weddings         int   `json:"weddings"`
+	GuestsPerWedding int   `json:"guests_per_wedding"`
+	StorageBytes     int64 `json:"storage_bytes"`
+	PremiumThemes    bool  `json:"premium_themes"`
+}
+
+var planLimits = map[PlanTier]PlanLimits{
+	PlanFree: {
+		Weddings:         1,
+		GuestsPerWedding: 150,
+		StorageBytes:     200 * 1024 * 1024, // 200MB
+	},
+	PlanPremium: {
+		Weddings:         5,
+		GuestsPerWedding: 2000,
+		StorageBytes:     10 * 1024 * 1024 * 1024, // 10GB
+		PremiumThemes:    true,
+	},
+}
+
+// Limits returns the limits of the plan, those of the free plan for unknown tiers
+func (t PlanTier) Limits() PlanLimits {
+	if limits, ok := planLimits[t]; ok {
+		return limits
+	}
+	return planLimits[PlanFree]
+}
+
+// SubscriptionStatus mirrors the status of a subscription at the payment provider
+type SubscriptionStatus string
+
+const (
+	SubscriptionActive   SubscriptionStatus = "active"
+	SubscriptionTrialing SubscriptionStatus = "trialing"
+	SubscriptionPastDue  SubscriptionStatus = "past_due"
+	SubscriptionCanceled SubscriptionStatus = "canceled"
+)
+
+// Subscription is the paid plan of a user as last reported by the payment provider
+type Subscription struct {
+	Plan             PlanTier           `bson:"plan" json:"plan"`
+	Status           SubscriptionStatus `bson:"status" json:"status"`
+	CustomerID       string             `bson:"customer_id,omitempty" json:"-"`
+	SubscriptionID   string             `bson:"subscription_id,omitempty" json:"-"`
+	CurrentPeriodEnd *time.Time         `bson:"current_period_end,omitempty" json:"current_period_end,omitempty"`
+	// UpdatedAt is when the provider emitted the event applied last, so that
+	// events delivered out of order cannot roll the subscription back
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// GrantsPlan checks whether the subscription currently grants its plan. Past
+// due subscriptions keep it while the provider retries the payment.
+func (s *Subscription) GrantsPlan() bool {
+	switch s.Status {
+	case SubscriptionActive, SubscriptionTrialing, SubscriptionPastDue:
+		return true
+	}
+	return false
+}
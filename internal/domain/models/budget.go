@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+)
+
+// BudgetSettings is the overall budget of a wedding. Budget amounts are in the
+// smallest unit of Currency, e.g. cents.
+type BudgetSettings struct {
+	Currency string `bson:"currency,omitempty" json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+	Total    int64  `bson:"total,omitempty" json:"total,omitempty" validate:"gte=0"`
+}
+
+// BudgetPaymentStatus tracks whether a vendor payment was made
+type BudgetPaymentStatus string
+
+const (
+	BudgetPaymentPending BudgetPaymentStatus = "pending"
+	BudgetPaymentPaid    BudgetPaymentStatus = "paid"
+)
+
+// BudgetCategory is a part of a wedding's budget, e.g. catering, with the amount planned for it
+type BudgetCategory struct {
+	ID        ID        `bson:"_id,omitempty" json:"id"`
+	WeddingID ID        `bson:"wedding_id" json:"wedding_id"`
+	Name      string    `bson:"name" json:"name"`
+	Planned   int64     `bson:"planned" json:"planned"`
+	Notes     string    `bson:"notes,omitempty" json:"notes,omitempty"`
+	Order     int       `bson:"order" json:"order"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// BudgetPayment is an amount owed or paid to a vendor. Payments with a due date
+// remind the wedding owner ahead of it until they are paid.
+type BudgetPayment struct {
+	ID          ID                  `bson:"_id,omitempty" json:"id"`
+	WeddingID   ID                  `bson:"wedding_id" json:"wedding_id"`
+	CategoryID  *ID                 `bson:"category_id,omitempty" json:"category_id,omitempty"`
+	Vendor      string              `bson:"vendor" json:"vendor"`
+	Description string              `bson:"description,omitempty" json:"description,omitempty"`
+	Amount      int64               `bson:"amount" json:"amount"`
+	Status      BudgetPaymentStatus `bson:"status" json:"status"`
+	DueDate     *time.Time          `bson:"due_date,omitempty" json:"due_date,omitempty"`
+	PaidAt      *time.Time          `bson:"paid_at,omitempty" json:"paid_at,omitempty"`
+	// ReminderDays is how many days before the due date the owner is reminded;
+	// RemindAt is when, empty without a due date
+	ReminderDays int        `bson:"reminder_days" json:"reminder_days"`
+	RemindAt     *time.Time `bson:"remind_at,omitempty" json:"remind_at,omitempty"`
+	RemindedAt   *time.Time `bson:"reminded_at,omitempty" json:"reminded_at,omitempty"`
+	CreatedAt    time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time  `bson:"updated_at" json:"updated_at"`
+}
+
+// IsOverdue reports whether the payment is unpaid past its due date
+func (p *BudgetPayment) IsOverdue(now time.Time) bool {
+	return p.Status != BudgetPaymentPaid && p.DueDate != nil && p.DueDate.Before(now)
+}
+
+// BudgetSummary compares what a wedding planned to spend with what it owes and paid
+type BudgetSummary struct {
+	WeddingID ID     `json:"wedding_id"`
+	Currency  string `json:"currency,omitempty"`
+	// Total is the overall budget; Remaining what is left of it after every payment
+	Total     int64 `json:"total"`
+	Remaining int64 `json:"remaining"`
+	// Planned adds up the categories; Actual the payments, paid or not
+	Planned     int64 `json:"planned"`
+	Actual      int64 `json:"actual"`
+	Paid        int64 `json:"paid"`
+	Outstanding int64 `json:"outstanding"`
+	Overdue     int64 `json:"overdue"`
+
+	Categories []BudgetCategorySummary `json:"categories"`
+	// Upcoming are the unpaid payments due soon, overdue ones first
+	Upcoming    []*BudgetPayment `json:"upcoming"`
+	GeneratedAt time.Time        `json:"generated_at"`
+}
+
+// BudgetCategorySummary compares the planned and actual spending of a category.
+// Payments without a category are summed up under a nil CategoryID.
+type BudgetCategorySummary struct {
+	CategoryID  *ID    `json:"category_id,omitempty"`
+	Name        string `json:"name"`
+	Planned     int64  `json:"planned"`
+	Actual      int64  `json:"actual"`
+	Paid        int64  `json:"paid"`
+	Outstanding int64  `json:"outstanding"`
+	// Variance is Planned less Actual, negative when over budget
+	Variance int64 `json:"variance"`
+	Payments int   `json:"payments"`
+}
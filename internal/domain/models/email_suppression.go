@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// EmailSuppressionReason is why an address no longer receives email
+type EmailSuppressionReason string
+
+const (
+	// EmailSuppressionBounce marks addresses whose mail bounced permanently
+	EmailSuppressionBounce EmailSuppressionReason = "bounce"
+	// EmailSuppressionSpamReport marks addresses whose owner reported our mail as spam
+	EmailSuppressionSpamReport EmailSuppressionReason = "spam_report"
+	// EmailSuppressionManual marks addresses an admin suppressed
+	EmailSuppressionManual EmailSuppressionReason = "manual"
+)
+
+// EmailSuppression is an address email is no longer sent to
+type EmailSuppression struct {
+	ID     ID                     `bson:"_id,omitempty" json:"id"`
+	Email  string                 `bson:"email" json:"email"` // Lower case
+	Reason EmailSuppressionReason `bson:"reason" json:"reason"`
+	// Detail is the provider's explanation, such as the bounce response
+	Detail    string    `bson:"detail,omitempty" json:"detail,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+// ExportJobStatus represents the lifecycle of a data export
+type ExportJobStatus string
+
+const (
+	ExportJobQueued    ExportJobStatus = "queued" // Waiting to be generated in the background
+	ExportJobRunning   ExportJobStatus = "running"
+	ExportJobCompleted ExportJobStatus = "completed"
+	ExportJobFailed    ExportJobStatus = "failed"
+)
+
+// ExportJob records a single export of wedding data. For encrypted exports only
+// the fingerprint of the owner's public key is kept, never the key material.
+type ExportJob struct {
+	ID             ID              `bson:"_id,omitempty" json:"id"`
+	WeddingID      ID              `bson:"wedding_id" json:"wedding_id"`
+	UserID         ID              `bson:"user_id" json:"user_id"`
+	Resource       string          `bson:"resource" json:"resource"` // rsvps, guests, analytics_page_views, ...
+	Format         string          `bson:"format" json:"format"`     // json, csv, xlsx
+	Encryption     string          `bson:"encryption,omitempty" json:"encryption,omitempty"`
+	KeyFingerprint string          `bson:"key_fingerprint,omitempty" json:"key_fingerprint,omitempty"`
+	Status         ExportJobStatus `bson:"status" json:"status"`
+	Records        int             `bson:"records" json:"records"`
+	Error          string          `bson:"error,omitempty" json:"error,omitempty"`
+	FileKey        string          `bson:"file_key,omitempty" json:"-"` // Storage key of an export generated in the background
+	CreatedAt      time.Time       `bson:"created_at" json:"created_at"`
+	CompletedAt    *time.Time      `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
+
+// IsEncrypted checks whether the export was encrypted to an owner-supplied key
+func (j *ExportJob) IsEncrypted() bool {
+	return j.Encryption != ""
+}
+
+// HasFile checks whether the export was generated in the background and can be downloaded
+func (j *ExportJob) HasFile() bool {
+	return j.Status == ExportJobCompleted && j.FileKey != ""
+}
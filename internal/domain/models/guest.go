@@ -1,36 +1,61 @@
 package models
 
 import (
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"time"
 )
 
 // Guest model for Phase 3
 type Guest struct {
-	ID               primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
-	WeddingID        primitive.ObjectID  `bson:"wedding_id" json:"wedding_id"`
-	FirstName        string              `bson:"first_name" json:"first_name" validate:"required,max=50"`
-	LastName         string              `bson:"last_name" json:"last_name" validate:"required,max=50"`
-	Email            string              `bson:"email,omitempty" json:"email,omitempty" validate:"omitempty,email,max=100"`
-	Phone            string              `bson:"phone,omitempty" json:"phone,omitempty"`
-	Address          *Address            `bson:"address,omitempty" json:"address,omitempty"`
-	Relationship     string              `bson:"relationship,omitempty" json:"relationship,omitempty"`
-	Side             string              `bson:"side,omitempty" validate:"oneof=bride groom both"`
-	InvitedVia       string              `bson:"invited_via" json:"invited_via" validate:"oneof=digital manual"`
-	InvitationStatus string              `bson:"invitation_status" json:"invitation_status" validate:"oneof=pending sent delivered failed"`
-	AllowPlusOne     bool                `bson:"allow_plus_one" json:"allow_plus_one"`
-	MaxPlusOnes      int                 `bson:"max_plus_ones" json:"max_plus_ones" validate:"min=0,max=5"`
-	RSVPStatus       string              `bson:"rsvp_status,omitempty" json:"rsvp_status,omitempty" validate:"omitempty,oneof=attending not-attending maybe pending"`
-	RSVPID           *primitive.ObjectID `bson:"rsvp_id,omitempty" json:"rsvp_id,omitempty"`
-	DietaryNotes     string              `bson:"dietary_notes,omitempty" json:"dietary_notes,omitempty"`
-	VIP              bool                `bson:"vip,omitempty" json:"vip,omitempty"`
-	Notes            string              `bson:"notes,omitempty" json:"notes,omitempty"`
-	ImportBatchID    string              `bson:"import_batch_id,omitempty" json:"import_batch_id,omitempty"`
-	CreatedAt        time.Time           `bson:"created_at" json:"created_at"`
-	UpdatedAt        time.Time           `bson:"updated_at" json:"updated_at"`
-	CreatedBy        primitive.ObjectID  `bson:"created_by" json:"created_by"`
+	ID                  ID            `bson:"_id,omitempty" json:"id"`
+	WeddingID           ID            `bson:"wedding_id" json:"wedding_id"`
+	FirstName           string        `bson:"first_name" json:"first_name" validate:"required,max=50"`
+	LastName            string        `bson:"last_name" json:"last_name" validate:"required,max=50"`
+	Email               string        `bson:"email,omitempty" json:"email,omitempty" validate:"omitempty,email,max=100"`
+	Phone               string        `bson:"phone,omitempty" json:"phone,omitempty"`
+	Address             *Address      `bson:"address,omitempty" json:"address,omitempty"`
+	Relationship        string        `bson:"relationship,omitempty" json:"relationship,omitempty"`
+	Side                string        `bson:"side,omitempty" validate:"oneof=bride groom both"`
+	InvitedVia          string        `bson:"invited_via" json:"invited_via" validate:"oneof=digital manual"`
+	InvitationStatus    string        `bson:"invitation_status" json:"invitation_status" validate:"oneof=pending sent delivered read failed"`
+	InvitationSentAt    *time.Time    `bson:"invitation_sent_at,omitempty" json:"invitation_sent_at,omitempty"`
+	InvitationError     string        `bson:"invitation_error,omitempty" json:"invitation_error,omitempty"`
+	InvitationChannel   string        `bson:"invitation_channel,omitempty" json:"invitation_channel,omitempty"`
+	InvitationMessageID string        `bson:"invitation_message_id,omitempty" json:"-"`
+	AllowPlusOne        bool          `bson:"allow_plus_one" json:"allow_plus_one"`
+	MaxPlusOnes         int           `bson:"max_plus_ones" json:"max_plus_ones" validate:"min=0,max=5"`
+	RSVPStatus          string        `bson:"rsvp_status,omitempty" json:"rsvp_status,omitempty" validate:"omitempty,oneof=attending not-attending maybe pending"`
+	RSVPID              *ID           `bson:"rsvp_id,omitempty" json:"rsvp_id,omitempty"`
+	Companions          []PlusOneInfo `bson:"companions,omitempty" json:"companions,omitempty"` // Named in the guest's RSVP
+	DietaryNotes        string        `bson:"dietary_notes,omitempty" json:"dietary_notes,omitempty"`
+	VIP                 bool          `bson:"vip,omitempty" json:"vip,omitempty"`
+	Notes               string        `bson:"notes,omitempty" json:"notes,omitempty"`
+	Tags                []string      `bson:"tags,omitempty" json:"tags,omitempty"` // Labels the owners group guests by
+	ImportBatchID       string        `bson:"import_batch_id,omitempty" json:"import_batch_id,omitempty"`
+	GroupID             *ID           `bson:"group_id,omitempty" json:"group_id,omitempty"` // Household the guest is invited with
+	CheckIn             *GuestCheckIn `bson:"check_in,omitempty" json:"check_in,omitempty"`
+	SearchKeys          []string      `bson:"search_keys,omitempty" json:"-"` // Kept by the repository, see GuestSearchKeys
+	PhoneKey            string        `bson:"phone_key,omitempty" json:"-"`   // Kept by the repository, see GuestPhoneKey
+	CreatedAt           time.Time     `bson:"created_at" json:"created_at"`
+	UpdatedAt           time.Time     `bson:"updated_at" json:"updated_at"`
+	CreatedBy           ID            `bson:"created_by" json:"created_by"`
 }
 
+// Invitation statuses of a guest
+const (
+	InvitationStatusPending   = "pending"
+	InvitationStatusSent      = "sent"
+	InvitationStatusDelivered = "delivered"
+	InvitationStatusRead      = "read"
+	InvitationStatusFailed    = "failed"
+)
+
+// Channels invitations are sent over. Messaging providers such as WhatsApp report the
+// delivery of an invitation under the guest's InvitationMessageID.
+const (
+	InvitationChannelEmail    = "email"
+	InvitationChannelWhatsApp = "whatsapp"
+)
+
 type Address struct {
 	Street  string `bson:"street,omitempty" json:"street,omitempty"`
 	City    string `bson:"city,omitempty" json:"city,omitempty"`
@@ -39,11 +64,149 @@ type Address struct {
 	Country string `bson:"country,omitempty" json:"country,omitempty"`
 }
 
+// GuestCheckIn records a guest's arrival on the wedding day
+type GuestCheckIn struct {
+	ArrivedAt       time.Time `bson:"arrived_at" json:"arrived_at"`
+	PlusOnesBrought int       `bson:"plus_ones_brought" json:"plus_ones_brought"`
+	Companions      []string  `bson:"companions,omitempty" json:"companions,omitempty"` // IDs of the named companions who came
+	Table           string    `bson:"table,omitempty" json:"table,omitempty"`
+	CheckedInBy     ID        `bson:"checked_in_by" json:"checked_in_by"`
+}
+
+// CheckInStats counts the arrivals at a wedding
+type CheckInStats struct {
+	TotalGuests     int64      `json:"total_guests"`
+	ExpectedGuests  int64      `json:"expected_guests"`  // Guests who RSVP'd attending
+	CheckedIn       int64      `json:"checked_in"`       // Guests checked in, whatever their RSVP
+	ExpectedArrived int64      `json:"expected_arrived"` // Expected guests checked in
+	PlusOnesBrought int64      `json:"plus_ones_brought"`
+	Attendance      int64      `json:"attendance"` // Checked-in guests and their plus-ones
+	LastCheckInAt   *time.Time `json:"last_check_in_at,omitempty"`
+}
+
+// GuestPatch is a change applied to many guests at once. Nil fields are left
+// unchanged; tags are added to and removed from those a guest already has.
+type GuestPatch struct {
+	Side             *string
+	Relationship     *string
+	InvitedVia       *string
+	InvitationStatus *string
+	InvitationSentAt *time.Time // Set along with InvitationStatus when invitations are marked sent
+	VIP              *bool
+	AllowPlusOne     *bool
+	AddTags          []string
+	RemoveTags       []string
+}
+
+// IsEmpty reports whether the patch changes nothing
+func (p GuestPatch) IsEmpty() bool {
+	return p.Side == nil && p.Relationship == nil && p.InvitedVia == nil && p.InvitationStatus == nil &&
+		p.VIP == nil && p.AllowPlusOne == nil && len(p.AddTags) == 0 && len(p.RemoveTags) == 0
+}
+
+// Apply makes the changes of the patch to the guest
+func (p GuestPatch) Apply(g *Guest) {
+	if p.Side != nil {
+		g.Side = *p.Side
+	}
+	if p.Relationship != nil {
+		g.Relationship = *p.Relationship
+	}
+	if p.InvitedVia != nil {
+		g.InvitedVia = *p.InvitedVia
+	}
+	if p.InvitationStatus != nil {
+		g.InvitationStatus = *p.InvitationStatus
+		g.InvitationError = ""
+	}
+	if p.InvitationSentAt != nil {
+		g.InvitationSentAt = p.InvitationSentAt
+	}
+	if p.VIP != nil {
+		g.VIP = *p.VIP
+	}
+	if p.AllowPlusOne != nil {
+		g.AllowPlusOne = *p.AllowPlusOne
+	}
+	if len(p.AddTags) > 0 || len(p.RemoveTags) > 0 {
+		g.Tags = p.tags(g.Tags)
+	}
+}
+
+// tags returns the current tags followed by the added ones the guest does not
+// have yet, without the removed ones; a tag both added and removed is removed
+func (p GuestPatch) tags(current []string) []string {
+	removed := make(map[string]bool, len(p.RemoveTags))
+	for _, tag := range p.RemoveTags {
+		removed[tag] = true
+	}
+
+	tags := make([]string, 0, len(current)+len(p.AddTags))
+	seen := make(map[string]bool, cap(tags))
+	for _, list := range [][]string{current, p.AddTags} {
+		for _, tag := range list {
+			if removed[tag] || seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// GuestBulkResult reports the outcome of a bulk guest operation. Guests that
+// could not be changed are listed in Failed with the reason.
+type GuestBulkResult struct {
+	Requested int                `json:"requested"`
+	Succeeded int                `json:"succeeded"`
+	Failed    []GuestBulkFailure `json:"failed"`
+}
+
+// GuestBulkFailure is a guest a bulk operation skipped
+type GuestBulkFailure struct {
+	GuestID string `json:"guest_id"`
+	Error   string `json:"error"`
+}
+
 type GuestImportResult struct {
 	SuccessCount int      `json:"success_count"`
 	ErrorCount   int      `json:"error_count"`
 	Errors       []string `json:"errors"`
 	BatchID      string   `json:"batch_id"`
+	// Duplicates are the contacts skipped because a guest has their email or phone
+	DuplicateCount int      `json:"duplicate_count,omitempty"`
+	Duplicates     []string `json:"duplicates,omitempty"`
 }
 
+// Reasons two guests are reported as likely duplicates
+const (
+	DuplicateReasonEmail       = "email"
+	DuplicateReasonPhone       = "phone"
+	DuplicateReasonName        = "name"
+	DuplicateReasonSimilarName = "similar_name"
+)
+
+// GuestDuplicate is a pair of guests that are likely the same person. Guest is the
+// older record, the one suggested to keep when merging.
+type GuestDuplicate struct {
+	Guest     *Guest   `json:"guest"`
+	Duplicate *Guest   `json:"duplicate"`
+	Reasons   []string `json:"reasons"`
+	// Score ranks the pairs; matching contact details weigh more than names
+	Score int `json:"score"`
+}
 
+// GuestContact is an address book contact, from a vCard file or Google Contacts,
+// that can be imported as a guest
+type GuestContact struct {
+	// ResourceName identifies a Google contact to import
+	ResourceName string `json:"resource_name,omitempty"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Email        string `json:"email,omitempty"`
+	Phone        string `json:"phone,omitempty"`
+	Notes        string `json:"notes,omitempty"`
+	// DuplicateOf is the guest that already has the contact's email or phone
+	DuplicateOf *ID `json:"duplicate_of,omitempty"`
+}
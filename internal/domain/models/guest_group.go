@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// GuestGroup is a household or family invited, seated and answering as one unit.
+// Members point to their group through Guest.GroupID, so a guest belongs to at
+// most one group.
+type GuestGroup struct {
+	ID        ID     `bson:"_id,omitempty" json:"id"`
+	WeddingID ID     `bson:"wedding_id" json:"wedding_id"`
+	Name      string `bson:"name" json:"name"`
+	// PrimaryGuestID is the member the household invitation is addressed to
+	PrimaryGuestID ID        `bson:"primary_guest_id" json:"primary_guest_id"`
+	Table          string    `bson:"table,omitempty" json:"table,omitempty"` // Where the household is seated
+	Notes          string    `bson:"notes,omitempty" json:"notes,omitempty"`
+	CreatedAt      time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time `bson:"updated_at" json:"updated_at"`
+	CreatedBy      ID        `bson:"created_by" json:"created_by"`
+}
+
+// GuestGroupDetail is a group with its members
+type GuestGroupDetail struct {
+	*GuestGroup
+	Members []*Guest `json:"members"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// GuestPhotoStatus represents the moderation state of a guest photo
+type GuestPhotoStatus string
+
+const (
+	GuestPhotoPending  GuestPhotoStatus = "pending"
+	GuestPhotoApproved GuestPhotoStatus = "approved"
+	GuestPhotoRejected GuestPhotoStatus = "rejected"
+)
+
+// GuestUploadSettings controls whether guests may add photos to a wedding's gallery
+type GuestUploadSettings struct {
+	Enabled bool `bson:"enabled" json:"enabled"`
+	// RequireApproval keeps guest photos out of the public gallery until the
+	// owner approves them
+	RequireApproval bool `bson:"require_approval" json:"require_approval"`
+}
+
+// GuestPhoto is a photo a guest uploaded to a wedding's gallery. The image
+// itself is a media file owned by the wedding owner.
+type GuestPhoto struct {
+	ID           ID                `bson:"_id,omitempty" json:"id"`
+	WeddingID    ID                `bson:"wedding_id" json:"wedding_id"`
+	MediaID      ID                `bson:"media_id" json:"media_id"`
+	URL          string            `bson:"url" json:"url"`
+	Thumbnails   map[string]string `bson:"thumbnails,omitempty" json:"thumbnails,omitempty"`
+	Width        int               `bson:"width,omitempty" json:"width,omitempty"`
+	Height       int               `bson:"height,omitempty" json:"height,omitempty"`
+	UploaderName string            `bson:"uploader_name,omitempty" json:"uploader_name,omitempty"`
+	Caption      string            `bson:"caption,omitempty" json:"caption,omitempty"`
+	UploaderIP   string            `bson:"uploader_ip,omitempty" json:"-"`
+	Status       GuestPhotoStatus  `bson:"status" json:"status"`
+	ModeratedBy  *ID               `bson:"moderated_by,omitempty" json:"moderated_by,omitempty"`
+	ModeratedAt  *time.Time        `bson:"moderated_at,omitempty" json:"moderated_at,omitempty"`
+	CreatedAt    time.Time         `bson:"created_at" json:"created_at"`
+}
+
+// IsPending checks whether the photo is waiting for the owner's decision
+func (p *GuestPhoto) IsPending() bool {
+	return p.Status == GuestPhotoPending
+}
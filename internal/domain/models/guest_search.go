@@ -0,0 +1,99 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Limits of the search keys kept for a guest
+const (
+	// maxNoteSearchKeys bounds the words of a guest's notes they are found by
+	maxNoteSearchKeys = 50
+	// minPhoneSearchDigits is the fewest trailing digits of a phone number a guest is found by
+	minPhoneSearchDigits = 4
+	// minPhoneKeyDigits is the fewest digits of a phone number a guest is matched by
+	minPhoneKeyDigits = 6
+	// phoneKeyDigits is how many trailing digits of phone numbers are compared
+	phoneKeyDigits = 10
+)
+
+// SearchTokens splits text into lowercase words stripped of diacritics, so that
+// "José" is found as "jose"
+func SearchTokens(text string) []string {
+	folder := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	if folded, _, err := transform.String(folder, text); err == nil {
+		text = folded
+	}
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// PhoneSearchDigits returns the digits of a phone number without leading zeros,
+// the part of a number a national or international prefix does not change
+func PhoneSearchDigits(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	return strings.TrimLeft(digits.String(), "0")
+}
+
+// GuestPhoneKey keeps the last digits of a phone number, so that numbers written
+// with spaces, dashes or brackets, or with and without the country code or trunk
+// prefix, match. Numbers too short to identify anyone have no key.
+func GuestPhoneKey(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	key := digits.String()
+	if len(key) < minPhoneKeyDigits {
+		return ""
+	}
+	if len(key) > phoneKeyDigits {
+		key = key[len(key)-phoneKeyDigits:]
+	}
+	return key
+}
+
+// GuestSearchKeys lists the keys a guest is found by: the words of their name,
+// email and notes, and every run of trailing digits of their phone number, so
+// that a number is found by any part of it, with or without country code.
+// Searches match keys by prefix.
+func GuestSearchKeys(g *Guest) []string {
+	seen := make(map[string]bool)
+	keys := []string{}
+	add := func(key string) {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	for _, text := range []string{g.FirstName, g.LastName, g.Email} {
+		for _, token := range SearchTokens(text) {
+			add(token)
+		}
+	}
+	notes := SearchTokens(g.Notes)
+	if len(notes) > maxNoteSearchKeys {
+		notes = notes[:maxNoteSearchKeys]
+	}
+	for _, token := range notes {
+		add(token)
+	}
+	digits := PhoneSearchDigits(g.Phone)
+	for i := 0; len(digits)-i >= minPhoneSearchDigits; i++ {
+		add(digits[i:])
+	}
+	return keys
+}
@@ -0,0 +1,30 @@
+package models
+
+// HealthStatus is the overall readiness of the API
+type HealthStatus string
+
+const (
+	HealthOK          HealthStatus = "ok"
+	HealthUnavailable HealthStatus = "unavailable"
+)
+
+// DependencyStatus is whether a single dependency answered its probe
+type DependencyStatus string
+
+const (
+	DependencyUp   DependencyStatus = "up"
+	DependencyDown DependencyStatus = "down"
+)
+
+// DependencyHealth is the outcome of probing one dependency
+type DependencyHealth struct {
+	Status    DependencyStatus `json:"status"`
+	LatencyMs int64            `json:"latency_ms"`
+	Error     string           `json:"error,omitempty"` // timed out or unreachable; details are only logged
+}
+
+// HealthReport is the readiness of the API and of each dependency it was probed with
+type HealthReport struct {
+	Status       HealthStatus                `json:"status"`
+	Dependencies map[string]DependencyHealth `json:"dependencies"`
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ErrInvalidID is returned when parsing a malformed ID
+var ErrInvalidID = errors.New("invalid id")
+
+// ID identifies a record. Above the repositories an ID is an opaque string; the
+// MongoDB repositories store it as an ObjectID and the PostgreSQL ones as text.
+//
+// New IDs keep the 24 character hex format records have always had: a
+// timestamp followed by random bytes and a counter, so IDs still sort by
+// creation time and existing links and references stay valid.
+type ID string
+
+// NilID is the zero ID, which identifies no record
+const NilID ID = ""
+
+// idLength is the length in bytes of a decoded ID
+const idLength = 12
+
+var (
+	idProcessUnique = randomBytes(5)
+	idCounter       = binary.BigEndian.Uint32(randomBytes(4))
+)
+
+// NewID generates a new unique ID
+func NewID() ID {
+	return NewIDFromTimestamp(time.Now())
+}
+
+// NewIDFromTimestamp generates a new unique ID that sorts by the timestamp
+func NewIDFromTimestamp(timestamp time.Time) ID {
+	var b [idLength]byte
+	binary.BigEndian.PutUint32(b[0:4], uint32(timestamp.Unix()))
+	copy(b[4:9], idProcessUnique)
+	counter := atomic.AddUint32(&idCounter, 1)
+	b[9], b[10], b[11] = byte(counter>>16), byte(counter>>8), byte(counter)
+	return ID(hex.EncodeToString(b[:]))
+}
+
+// ParseID parses an ID received from a client or another system
+func ParseID(s string) (ID, error) {
+	if len(s) != 2*idLength {
+		return NilID, ErrInvalidID
+	}
+	if _, err := hex.DecodeString(s); err != nil {
+		return NilID, ErrInvalidID
+	}
+	return ID(strings.ToLower(s)), nil
+}
+
+// IDFromBytes returns the ID encoded by Bytes
+func IDFromBytes(b []byte) (ID, error) {
+	if len(b) != idLength {
+		return NilID, ErrInvalidID
+	}
+	return ID(hex.EncodeToString(b)), nil
+}
+
+// Bytes returns the binary form of the ID, or nil when it is not well formed
+func (id ID) Bytes() []byte {
+	b, err := hex.DecodeString(string(id))
+	if err != nil || len(b) != idLength {
+		return nil
+	}
+	return b
+}
+
+// IsZero reports whether the ID is NilID
+func (id ID) IsZero() bool {
+	return id == NilID
+}
+
+func (id ID) String() string {
+	return string(id)
+}
+
+// UnmarshalJSON rejects malformed IDs in request bodies. Empty strings decode
+// as NilID and null leaves the ID unchanged, as they always have.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*id = NilID
+		return nil
+	}
+	parsed, err := ParseID(s)
+	if err != nil {
+		return fmt.Errorf("%w %q", ErrInvalidID, s)
+	}
+	*id = parsed
+	return nil
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Errorf("failed to initialize ID generation: %w", err))
+	}
+	return b
+}
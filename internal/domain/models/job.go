@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// JobStatus represents the lifecycle of a background job
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobCompleted JobStatus = "completed"
+	JobFailed    JobStatus = "failed"
+)
+
+// Job is a unit of background work persisted in the job queue so it survives restarts
+type Job struct {
+	ID   ID     `bson:"_id,omitempty" json:"id"`
+	Type string `bson:"type" json:"type"`
+	// Payload is the BSON-encoded input of the job's handler
+	Payload bson.Raw `bson:"payload,omitempty" json:"-"`
+	// UniqueKey coalesces jobs: at most one queued job exists per type and key
+	UniqueKey   string     `bson:"unique_key,omitempty" json:"unique_key,omitempty"`
+	Status      JobStatus  `bson:"status" json:"status"`
+	Attempts    int        `bson:"attempts" json:"attempts"`
+	MaxAttempts int        `bson:"max_attempts" json:"max_attempts"`
+	RunAt       time.Time  `bson:"run_at" json:"run_at"`
+	LockedUntil *time.Time `bson:"locked_until,omitempty" json:"-"`
+	Error       string     `bson:"error,omitempty" json:"error,omitempty"`
+	CreatedAt   time.Time  `bson:"created_at" json:"created_at"`
+	ProcessedAt *time.Time `bson:"processed_at,omitempty" json:"processed_at,omitempty"`
+	// TraceContext links the job to the trace of the request that queued it
+	TraceContext map[string]string `bson:"trace_context,omitempty" json:"-"`
+}
+
+// DecodePayload unmarshals the job's payload into v
+func (j *Job) DecodePayload(v interface{}) error {
+	return bson.Unmarshal(j.Payload, v)
+}
+
+// IsFinal checks whether the job has finished processing
+func (j *Job) IsFinal() bool {
+	return j.Status == JobCompleted || j.Status == JobFailed
+}
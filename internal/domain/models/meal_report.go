@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// MealReport tells the caterer what the attending guests of a wedding eat
+type MealReport struct {
+	WeddingID      ID  `json:"wedding_id"`
+	TotalAttendees int `json:"total_attendees"`
+	// Meals counts every meal option, including those nobody chose
+	Meals         []OptionCount  `json:"meals"`
+	NoMealChoice  int            `json:"no_meal_choice"`
+	AllergyCounts map[string]int `json:"allergy_counts"`
+	Attendees     []MealAttendee `json:"attendees"`
+	GeneratedAt   time.Time      `json:"generated_at"`
+}
+
+// MealAttendee is one attending guest of a meal report, either the guest who
+// answered the RSVP or one of their plus ones
+type MealAttendee struct {
+	RSVPID       ID       `json:"rsvp_id"`
+	Name         string   `json:"name"`
+	PlusOne      bool     `json:"plus_one"`
+	MealChoice   string   `json:"meal_choice,omitempty"`
+	Allergies    []string `json:"allergies,omitempty"`
+	DietaryNotes string   `json:"dietary_notes,omitempty"`
+}
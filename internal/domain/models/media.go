@@ -2,13 +2,24 @@ package models
 
 import (
 	"time"
+)
+
+// MediaStatus is the processing state of an upload
+type MediaStatus string
 
-	"go.mongodb.org/mongo-driver/bson/primitive"
+// Media processing states
+const (
+	MediaStatusPending    MediaStatus = "pending"
+	MediaStatusProcessing MediaStatus = "processing"
+	MediaStatusReady      MediaStatus = "ready"
+	MediaStatusFailed     MediaStatus = "failed"
+	// MediaStatusRejected is media whose file was found infected and quarantined
+	MediaStatusRejected MediaStatus = "rejected"
 )
 
 // Media represents a stored media file with metadata
 type Media struct {
-	ID          primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	ID          ID     `bson:"_id,omitempty" json:"id"`
 	Filename    string                 `bson:"filename" json:"filename"`
 	OriginalURL string                 `bson:"originalUrl" json:"originalUrl"`
 	Thumbnails  map[string]string      `bson:"thumbnails,omitempty" json:"thumbnails,omitempty"`
@@ -18,9 +29,17 @@ type Media struct {
 	Height      int                    `bson:"height,omitempty" json:"height,omitempty"`
 	Format      string                 `bson:"format,omitempty" json:"format,omitempty"`
 	EXIF        map[string]interface{} `bson:"exif,omitempty" json:"exif,omitempty"`
+	Duration    float64                `bson:"duration,omitempty" json:"duration,omitempty"` // Seconds, for videos
+	PlaybackURL string                 `bson:"playbackUrl,omitempty" json:"playbackUrl,omitempty"`
+	Status      MediaStatus            `bson:"status,omitempty" json:"status,omitempty"`
 	StorageKey  string                 `bson:"storageKey" json:"-"`
+	// ContentHash is the hex SHA-256 of the uploaded file. Uploads of the same
+	// file by the same user share the stored object of the first one.
+	ContentHash string                 `bson:"contentHash,omitempty" json:"-"`
+	Threat      string                 `bson:"threat,omitempty" json:"threat,omitempty"` // Set when the file was rejected by the malware scan
+	ScannedAt   *time.Time             `bson:"scannedAt,omitempty" json:"scannedAt,omitempty"`
 	CreatedAt   time.Time              `bson:"createdAt" json:"createdAt"`
-	CreatedBy   primitive.ObjectID     `bson:"createdBy" json:"createdBy"`
+	CreatedBy   ID     `bson:"createdBy" json:"createdBy"`
 	UpdatedAt   time.Time              `bson:"updatedAt,omitempty" json:"updatedAt,omitempty"`
 	DeletedAt   *time.Time             `bson:"deletedAt,omitempty" json:"deletedAt,omitempty"`
 }
@@ -30,6 +49,20 @@ func (m *Media) IsImage() bool {
 	return m.MimeType == "image/jpeg" || m.MimeType == "image/png" || m.MimeType == "image/webp"
 }
 
+// IsVideo checks if the media file is a video
+func (m *Media) IsVideo() bool {
+	return m.MimeType == "video/mp4" || m.MimeType == "video/webm"
+}
+
+// ProcessingStatus returns the processing state; media stored before states
+// were tracked are ready
+func (m *Media) ProcessingStatus() MediaStatus {
+	if m.Status == "" {
+		return MediaStatusReady
+	}
+	return m.Status
+}
+
 // HasThumbnails checks if the media has thumbnails generated
 func (m *Media) HasThumbnails() bool {
 	return len(m.Thumbnails) > 0
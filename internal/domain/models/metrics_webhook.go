@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+)
+
+// MetricsWebhook is a scheduled push of aggregated wedding metrics to an external CRM endpoint
+type MetricsWebhook struct {
+	ID              ID         `bson:"_id,omitempty" json:"id"`
+	UserID          ID         `bson:"user_id" json:"user_id"`
+	Name            string     `bson:"name" json:"name"`
+	URL             string     `bson:"url" json:"url"`
+	Secret          string     `bson:"secret" json:"-"` // Used for HMAC signing, never exposed
+	WeddingIDs      []ID       `bson:"wedding_ids" json:"wedding_ids"`
+	Schedule        string     `bson:"schedule" json:"schedule"` // daily
+	Active          bool       `bson:"active" json:"active"`
+	LastDeliveredAt *time.Time `bson:"last_delivered_at,omitempty" json:"last_delivered_at,omitempty"`
+	NextDeliveryAt  time.Time  `bson:"next_delivery_at" json:"next_delivery_at"`
+	CreatedAt       time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt       time.Time  `bson:"updated_at" json:"updated_at"`
+}
+
+// MetricsWebhookSchedule represents supported delivery schedules
+type MetricsWebhookSchedule string
+
+const (
+	MetricsWebhookScheduleDaily MetricsWebhookSchedule = "daily"
+)
+
+// Interval returns the delivery interval for the webhook schedule
+func (w *MetricsWebhook) Interval() time.Duration {
+	switch MetricsWebhookSchedule(w.Schedule) {
+	case MetricsWebhookScheduleDaily:
+		return 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// IsDue checks whether the webhook should be delivered at the given time
+func (w *MetricsWebhook) IsDue(now time.Time) bool {
+	return w.Active && !now.Before(w.NextDeliveryAt)
+}
+
+// WeddingMetrics is the payload posted to the CRM for a single wedding
+type WeddingMetrics struct {
+	WeddingID      ID        `json:"wedding_id"`
+	Slug           string    `json:"slug"`
+	Title          string    `json:"title"`
+	EventDate      time.Time `json:"event_date"`
+	TotalResponses int       `json:"total_responses"`
+	Attending      int       `json:"attending"`
+	NotAttending   int       `json:"not_attending"`
+	Maybe          int       `json:"maybe"`
+	TotalAttendees int       `json:"total_attendees"`
+	TotalGuests    int64     `json:"total_guests"`
+	PendingGuests  int64     `json:"pending_guests"`
+	PageViews      int64     `json:"page_views"`
+	GeneratedAt    time.Time `json:"generated_at"`
+}
+
+// MetricsWebhookPayload is the body of a metrics webhook delivery
+type MetricsWebhookPayload struct {
+	Event     string           `json:"event"`
+	WebhookID string           `json:"webhook_id"`
+	SentAt    time.Time        `json:"sent_at"`
+	Weddings  []WeddingMetrics `json:"weddings"`
+}
+
+// MetricsWebhookDelivery records a single delivery attempt
+type MetricsWebhookDelivery struct {
+	ID           ID        `bson:"_id,omitempty" json:"id"`
+	WebhookID    ID        `bson:"webhook_id" json:"webhook_id"`
+	URL          string    `bson:"url" json:"url"`
+	StatusCode   int       `bson:"status_code" json:"status_code"`
+	Success      bool      `bson:"success" json:"success"`
+	Error        string    `bson:"error,omitempty" json:"error,omitempty"`
+	WeddingCount int       `bson:"wedding_count" json:"wedding_count"`
+	DurationMs   int64     `bson:"duration_ms" json:"duration_ms"`
+	AttemptedAt  time.Time `bson:"attempted_at" json:"attempted_at"`
+}
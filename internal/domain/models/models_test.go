@@ -1,11 +1,13 @@
 package models
 
 import (
+	"encoding/json"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func TestUserStatus_Constants(t *testing.T) {
@@ -242,9 +244,9 @@ func TestWeddingStatus_Constants(t *testing.T) {
 }
 
 func TestGuestModel_Fields(t *testing.T) {
-	guestID := primitive.NewObjectID()
-	weddingID := primitive.NewObjectID()
-	createdBy := primitive.NewObjectID()
+	guestID := NewID()
+	weddingID := NewID()
+	createdBy := NewID()
 
 	guest := &Guest{
 		ID:        guestID,
@@ -501,3 +503,260 @@ func TestMediaModel_BeforeUpdate(t *testing.T) {
 	assert.True(t, media.UpdatedAt.After(beforeUpdate))
 	assert.True(t, media.UpdatedAt.After(originalUpdatedAt))
 }
+
+func TestAnalyticsReportFrequency_NextRun(t *testing.T) {
+	// Wednesday
+	after := time.Date(2026, 3, 11, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		frequency AnalyticsReportFrequency
+		after     time.Time
+		want      time.Time
+	}{
+		{"weekly - next Monday", AnalyticsReportWeekly, after, time.Date(2026, 3, 16, 8, 0, 0, 0, time.UTC)},
+		{"weekly - Monday before send hour", AnalyticsReportWeekly, time.Date(2026, 3, 16, 7, 0, 0, 0, time.UTC), time.Date(2026, 3, 16, 8, 0, 0, 0, time.UTC)},
+		{"weekly - Monday at send hour", AnalyticsReportWeekly, time.Date(2026, 3, 16, 8, 0, 0, 0, time.UTC), time.Date(2026, 3, 23, 8, 0, 0, 0, time.UTC)},
+		{"monthly - first of next month", AnalyticsReportMonthly, after, time.Date(2026, 4, 1, 8, 0, 0, 0, time.UTC)},
+		{"monthly - year rollover", AnalyticsReportMonthly, time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 8, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.frequency.NextRun(tt.after))
+		})
+	}
+
+	assert.True(t, AnalyticsReportWeekly.IsValid())
+	assert.False(t, AnalyticsReportFrequency("daily").IsValid())
+}
+
+func TestRoleHasPermission(t *testing.T) {
+	assert.True(t, IsValidRole(RolePlanner))
+	assert.False(t, IsValidRole("superuser"))
+
+	assert.True(t, RoleHasPermission(RoleUser, PermissionCreateWedding))
+	assert.True(t, RoleHasPermission(RolePlanner, PermissionCreateWedding))
+	assert.False(t, RoleHasPermission(RoleCollaborator, PermissionCreateWedding))
+	assert.False(t, RoleHasPermission(RoleOwner, PermissionManageUsers))
+	assert.True(t, RoleHasPermission(RoleAdmin, PermissionManageUsers))
+	assert.False(t, RoleHasPermission("superuser", PermissionCreateWedding))
+}
+
+func TestWedding_Can(t *testing.T) {
+	ownerID := NewID()
+	collaboratorID := NewID()
+	plannerID := NewID()
+	wedding := &Wedding{
+		UserID: ownerID,
+		Collaborators: []WeddingCollaborator{
+			{UserID: collaboratorID, Role: WeddingRoleCollaborator},
+			{UserID: plannerID, Role: WeddingRolePlanner},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		userID     ID
+		permission Permission
+		want       bool
+	}{
+		{"owner deletes", ownerID, PermissionDeleteWedding, true},
+		{"owner manages collaborators", ownerID, PermissionManageCollaborators, true},
+		{"collaborator edits", collaboratorID, PermissionEditWedding, true},
+		{"collaborator cannot delete", collaboratorID, PermissionDeleteWedding, false},
+		{"planner manages guests", plannerID, PermissionManageGuests, true},
+		{"planner manages RSVPs", plannerID, PermissionManageRSVPs, true},
+		{"planner cannot edit", plannerID, PermissionEditWedding, false},
+		{"stranger", NewID(), PermissionManageGuests, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, wedding.Can(tt.userID, tt.permission))
+		})
+	}
+}
+
+func TestRSVP_RecordChange(t *testing.T) {
+	rsvp := &RSVP{}
+	for i := 0; i < MaxRSVPChanges+5; i++ {
+		rsvp.RecordChange(RSVPChange{ChangedBy: RSVPChangedByGuest, Fields: []RSVPFieldChange{{Field: "attendance_count", To: strconv.Itoa(i)}}})
+	}
+
+	assert.Len(t, rsvp.Changes, MaxRSVPChanges)
+	assert.Equal(t, "5", rsvp.Changes[0].Fields[0].To)
+	assert.Equal(t, strconv.Itoa(MaxRSVPChanges+4), rsvp.Changes[MaxRSVPChanges-1].Fields[0].To)
+}
+
+func TestID_NewAndParse(t *testing.T) {
+	earlier := NewIDFromTimestamp(time.Now().Add(-time.Hour))
+	id := NewID()
+	assert.Len(t, id.String(), 24)
+	assert.NotEqual(t, id, NewID())
+	assert.Less(t, earlier.String(), id.String(), "IDs sort by creation time")
+
+	parsed, err := ParseID(strings.ToUpper(id.String()))
+	assert.NoError(t, err)
+	assert.Equal(t, id, parsed)
+
+	for _, invalid := range []string{"", "abc", "zzzzzzzzzzzzzzzzzzzzzzzz"} {
+		_, err := ParseID(invalid)
+		assert.ErrorIs(t, err, ErrInvalidID, invalid)
+	}
+
+	fromBytes, err := IDFromBytes(id.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, id, fromBytes)
+	assert.True(t, NilID.IsZero())
+}
+
+func TestID_UnmarshalJSON(t *testing.T) {
+	id := NewID()
+	var body struct {
+		ID    ID  `json:"id"`
+		Empty ID  `json:"empty"`
+		Ptr   *ID `json:"ptr"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"id":"`+id.String()+`","empty":"","ptr":null}`), &body))
+	assert.Equal(t, id, body.ID)
+	assert.True(t, body.Empty.IsZero())
+	assert.Nil(t, body.Ptr)
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"id":"not-an-id"}`), &body), ErrInvalidID)
+}
+
+func TestGuestPatch_Apply(t *testing.T) {
+	side := "bride"
+	status := InvitationStatusSent
+	guest := &Guest{Side: "groom", InvitationStatus: InvitationStatusFailed, InvitationError: "bounced", Tags: []string{"family", "table-1"}}
+
+	assert.True(t, GuestPatch{}.IsEmpty())
+	patch := GuestPatch{Side: &side, InvitationStatus: &status, AddTags: []string{"friends", "family", "table-2"}, RemoveTags: []string{"table-1", "table-2"}}
+	assert.False(t, patch.IsEmpty())
+	patch.Apply(guest)
+
+	assert.Equal(t, "bride", guest.Side)
+	assert.Equal(t, InvitationStatusSent, guest.InvitationStatus)
+	assert.Empty(t, guest.InvitationError)
+	assert.Equal(t, []string{"family", "friends"}, guest.Tags, "added tags are kept once and removed tags win")
+}
+
+func TestWedding_Localized(t *testing.T) {
+	wedding := &Wedding{
+		Language: "en",
+		Title:    "Anna & Ben",
+		Event:    EventDetails{Title: "Ceremony", VenueName: "Grand Hall", DressCode: "Formal"},
+		Sessions: []EventSession{
+			{ID: "akad", EventDetails: EventDetails{Title: "Akad"}},
+			{ID: "reception", EventDetails: EventDetails{Title: "Reception"}},
+		},
+		RSVP: RSVPSettings{CustomQuestions: []CustomQuestion{
+			{ID: "song", Question: "Which song gets you dancing?"},
+		}},
+		Labels: map[string]string{"rsvp": "RSVP", "gallery": "Gallery"},
+		Translations: map[string]WeddingTranslation{
+			"id": {
+				Title:     "Pernikahan Anna & Ben",
+				Event:     EventTranslation{Title: "Pemberkatan"},
+				Sessions:  map[string]EventTranslation{"reception": {Title: "Resepsi"}},
+				Questions: map[string]string{"song": "Lagu apa yang membuatmu berdansa?"},
+				Labels:    map[string]string{"rsvp": "Konfirmasi Kehadiran"},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"en", "id"}, wedding.Languages())
+	assert.Same(t, wedding, wedding.Localized("en"))
+	assert.Same(t, wedding, wedding.Localized("fr"))
+
+	localized := wedding.Localized("id")
+	assert.Equal(t, "id", localized.ContentLanguage())
+	assert.Equal(t, "Pernikahan Anna & Ben", localized.Title)
+	assert.Equal(t, "Pemberkatan", localized.Event.Title)
+	// Fields the translation leaves empty keep the wedding's own text
+	assert.Equal(t, "Grand Hall", localized.Event.VenueName)
+	assert.Equal(t, "Formal", localized.Event.DressCode)
+	assert.Equal(t, "Akad", localized.Sessions[0].Title)
+	assert.Equal(t, "Resepsi", localized.Sessions[1].Title)
+	assert.Equal(t, "Lagu apa yang membuatmu berdansa?", localized.RSVP.CustomQuestions[0].Question)
+	assert.Equal(t, map[string]string{"rsvp": "Konfirmasi Kehadiran", "gallery": "Gallery"}, localized.Labels)
+
+	// The wedding itself is left as it was
+	assert.Equal(t, "Reception", wedding.Sessions[1].Title)
+	assert.Equal(t, "Which song gets you dancing?", wedding.RSVP.CustomQuestions[0].Question)
+	assert.Equal(t, "RSVP", wedding.Labels["rsvp"])
+}
+
+func TestGuestSearchKeys(t *testing.T) {
+	assert.Equal(t, []string{"jose", "muller", "o", "brien"}, SearchTokens("José  Müller, O'Brien"))
+	assert.Equal(t, "6281234567", PhoneSearchDigits("+62 (812) 345-67"))
+	assert.Equal(t, "81234567", PhoneSearchDigits("0812-345-67"))
+
+	guest := &Guest{
+		FirstName: "José",
+		LastName:  "Ramírez",
+		Email:     "jose.r@example.com",
+		Phone:     "0812-3456",
+		Notes:     "Cousin of José",
+	}
+	assert.Equal(t, []string{
+		"jose", "ramirez", "r", "example", "com", "cousin", "of",
+		"8123456", "123456", "23456", "3456",
+	}, GuestSearchKeys(guest))
+
+	assert.Equal(t, []string{}, GuestSearchKeys(&Guest{Phone: "123"}))
+}
+
+func TestGuestPhoneKey(t *testing.T) {
+	assert.Equal(t, "8123456789", GuestPhoneKey("+62 812-3456-789"))
+	assert.Equal(t, "8123456789", GuestPhoneKey("(0812) 3456 789"))
+	assert.Equal(t, "123456", GuestPhoneKey("12-34-56"))
+	assert.Equal(t, "", GuestPhoneKey("12345"), "too short to identify anyone")
+	assert.Equal(t, "", GuestPhoneKey(""))
+}
+
+func TestAnalyticsSettings_Samples(t *testing.T) {
+	weddingID := NewID()
+
+	assert.True(t, AnalyticsSettings{}.Samples(weddingID, "s1"), "no rate records every session")
+	assert.True(t, AnalyticsSettings{SampleRate: 1}.Samples(weddingID, "s1"))
+
+	settings := AnalyticsSettings{SampleRate: 0.25}
+	sampled := 0
+	for i := 0; i < 4000; i++ {
+		sessionID := "session-" + strconv.Itoa(i)
+		if settings.Samples(weddingID, sessionID) {
+			sampled++
+			assert.True(t, settings.Samples(weddingID, sessionID), "a session stays in the sample")
+		}
+	}
+	assert.InDelta(t, 1000, sampled, 150)
+}
+
+func TestAnomalyAlertSettings_Detect(t *testing.T) {
+	var defaults AnomalyAlertSettings
+
+	kind, ok := defaults.Detect(60, 10)
+	assert.True(t, ok)
+	assert.Equal(t, AnomalySpike, kind)
+
+	kind, ok = defaults.Detect(5, 100)
+	assert.True(t, ok)
+	assert.Equal(t, AnomalyDrop, kind)
+
+	// Quiet weddings do not alert on noise either way
+	_, ok = defaults.Detect(8, 1)
+	assert.False(t, ok, "spike below the minimum volume")
+	_, ok = defaults.Detect(0, 10)
+	assert.False(t, ok, "drop from a baseline below the minimum volume")
+
+	_, ok = defaults.Detect(25, 10)
+	assert.False(t, ok)
+	kind, ok = AnomalyAlertSettings{SpikeFactor: 2}.Detect(25, 10)
+	assert.True(t, ok)
+	assert.Equal(t, AnomalySpike, kind)
+
+	assert.True(t, defaults.Sends(AnomalyChannelEmail))
+	assert.False(t, AnomalyAlertSettings{Channels: []string{AnomalyChannelWebhook}}.Sends(AnomalyChannelEmail))
+}
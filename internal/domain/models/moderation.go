@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+)
+
+// ModerationActionType is something an admin did to a wedding
+type ModerationActionType string
+
+const (
+	ModerationUnpublish ModerationActionType = "unpublish"
+	ModerationFlag      ModerationActionType = "flag"
+	ModerationUnflag    ModerationActionType = "unflag"
+	ModerationTakedown  ModerationActionType = "takedown"
+	ModerationRestore   ModerationActionType = "restore"
+)
+
+// WeddingModeration is the moderation state of a wedding. Owners can't change
+// it; a wedding that is taken down can't be published again until an admin
+// restores it.
+type WeddingModeration struct {
+	Flagged    bool       `bson:"flagged" json:"flagged"`
+	FlagReason string     `bson:"flag_reason,omitempty" json:"flag_reason,omitempty"`
+	FlaggedAt  *time.Time `bson:"flagged_at,omitempty" json:"flagged_at,omitempty"`
+
+	TakenDown      bool       `bson:"taken_down" json:"taken_down"`
+	TakedownReason string     `bson:"takedown_reason,omitempty" json:"takedown_reason,omitempty"`
+	TakenDownAt    *time.Time `bson:"taken_down_at,omitempty" json:"taken_down_at,omitempty"`
+
+	// History lists the moderation actions taken on the wedding, oldest first
+	History []ModerationAction `bson:"history,omitempty" json:"history,omitempty"`
+}
+
+// ModerationAction records an admin's moderation of a wedding. AdminID is
+// empty for weddings flagged automatically by abuse reports.
+type ModerationAction struct {
+	Action    ModerationActionType `bson:"action" json:"action"`
+	Reason    string               `bson:"reason,omitempty" json:"reason,omitempty"`
+	AdminID   *ID                  `bson:"admin_id,omitempty" json:"admin_id,omitempty"`
+	CreatedAt time.Time            `bson:"created_at" json:"created_at"`
+}
+
+// IsTakenDown reports whether an admin took the wedding down
+func (w *Wedding) IsTakenDown() bool {
+	return w.Moderation != nil && w.Moderation.TakenDown
+}
+
+// AbuseReportCategory is what a visitor reported a wedding for
+type AbuseReportCategory string
+
+const (
+	AbuseCategorySpam          AbuseReportCategory = "spam"
+	AbuseCategoryScam          AbuseReportCategory = "scam"
+	AbuseCategoryInappropriate AbuseReportCategory = "inappropriate"
+	AbuseCategoryCopyright     AbuseReportCategory = "copyright"
+	AbuseCategoryImpersonation AbuseReportCategory = "impersonation"
+	AbuseCategoryOther         AbuseReportCategory = "other"
+)
+
+// IsValid reports whether c is a known abuse report category
+func (c AbuseReportCategory) IsValid() bool {
+	switch c {
+	case AbuseCategorySpam, AbuseCategoryScam, AbuseCategoryInappropriate,
+		AbuseCategoryCopyright, AbuseCategoryImpersonation, AbuseCategoryOther:
+		return true
+	}
+	return false
+}
+
+// AbuseReportStatus is where an abuse report is in review
+type AbuseReportStatus string
+
+const (
+	AbuseReportOpen      AbuseReportStatus = "open"
+	AbuseReportResolved  AbuseReportStatus = "resolved"
+	AbuseReportDismissed AbuseReportStatus = "dismissed"
+)
+
+// AbuseReport is a visitor's report that a published wedding breaks the rules
+type AbuseReport struct {
+	ID            ID                  `bson:"_id,omitempty" json:"id"`
+	WeddingID     ID                  `bson:"wedding_id" json:"wedding_id"`
+	WeddingSlug   string              `bson:"wedding_slug" json:"wedding_slug"`
+	Category      AbuseReportCategory `bson:"category" json:"category"`
+	Details       string              `bson:"details,omitempty" json:"details,omitempty"`
+	ReporterEmail string              `bson:"reporter_email,omitempty" json:"reporter_email,omitempty"`
+	ReporterIP    string              `bson:"reporter_ip,omitempty" json:"reporter_ip,omitempty"`
+	Status        AbuseReportStatus   `bson:"status" json:"status"`
+	// ResolutionNote is the admin's note on how the report was handled
+	ResolutionNote string     `bson:"resolution_note,omitempty" json:"resolution_note,omitempty"`
+	ResolvedBy     *ID        `bson:"resolved_by,omitempty" json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+	CreatedAt      time.Time  `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+)
+
+// MusicProvider is the service a wedding's background music is embedded from
+type MusicProvider string
+
+const (
+	MusicSpotify MusicProvider = "spotify"
+	MusicYouTube MusicProvider = "youtube"
+)
+
+// MusicSettings configures the background music of a wedding's invitation and
+// the song requests its guests may send for the DJ
+type MusicSettings struct {
+	// URL is the Spotify or YouTube link of the song or playlist, as shared by the owner
+	URL string `bson:"url,omitempty" json:"url,omitempty" validate:"omitempty,max=500"`
+	// Provider and EmbedURL are derived from URL: the player the invitation embeds
+	Provider MusicProvider `bson:"provider,omitempty" json:"provider,omitempty"`
+	EmbedURL string        `bson:"embed_url,omitempty" json:"embed_url,omitempty"`
+	// Autoplay asks the invitation to start the music once the guest opens it
+	Autoplay     bool                `bson:"autoplay" json:"autoplay"`
+	SongRequests SongRequestSettings `bson:"song_requests" json:"song_requests"`
+}
+
+// SongRequestSettings controls whether guests may request songs for a wedding's DJ
+type SongRequestSettings struct {
+	Enabled bool `bson:"enabled" json:"enabled"`
+	// RequireApproval keeps requests off the public list and the DJ's until the owner approves them
+	RequireApproval bool `bson:"require_approval" json:"require_approval"`
+}
+
+// SongRequestStatus represents the moderation state of a song request
+type SongRequestStatus string
+
+const (
+	SongRequestPending  SongRequestStatus = "pending"
+	SongRequestApproved SongRequestStatus = "approved"
+	SongRequestRejected SongRequestStatus = "rejected"
+)
+
+// SongRequest is a song guests asked the DJ of a wedding to play. Guests
+// requesting the same song add to one request rather than each making their own.
+type SongRequest struct {
+	ID        ID     `bson:"_id,omitempty" json:"id"`
+	WeddingID ID     `bson:"wedding_id" json:"wedding_id"`
+	Title     string `bson:"title" json:"title"`
+	Artist    string `bson:"artist,omitempty" json:"artist,omitempty"`
+	// Key identifies the song however its title and artist were written
+	Key string `bson:"key" json:"-"`
+	// Requests counts the guests who requested the song
+	Requests int `bson:"requests" json:"requests"`
+	// RequestedBy names the first guests who requested the song and gave a name
+	RequestedBy []string `bson:"requested_by,omitempty" json:"requested_by,omitempty"`
+	// Filtered is set when profanity was masked out of the song or a name
+	Filtered    bool              `bson:"filtered,omitempty" json:"filtered,omitempty"`
+	Status      SongRequestStatus `bson:"status" json:"status"`
+	ModeratedBy *ID               `bson:"moderated_by,omitempty" json:"moderated_by,omitempty"`
+	ModeratedAt *time.Time        `bson:"moderated_at,omitempty" json:"moderated_at,omitempty"`
+	CreatedAt   time.Time         `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time         `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// Organization is a white-label tenant, such as a wedding agency running the
+// platform for its couples under its own brand. Its ID is the tenant ID its
+// users and weddings carry.
+type Organization struct {
+	ID        string               `bson:"_id" json:"id"`
+	Name      string               `bson:"name" json:"name"`
+	Branding  OrganizationBranding `bson:"branding" json:"branding"`
+	CreatedBy ID                   `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+// OrganizationBranding is how an organization's weddings present it to guests
+type OrganizationBranding struct {
+	DisplayName    string `bson:"display_name,omitempty" json:"display_name,omitempty" validate:"omitempty,max=100"`
+	LogoURL        string `bson:"logo_url,omitempty" json:"logo_url,omitempty" validate:"omitempty,url"`
+	FaviconURL     string `bson:"favicon_url,omitempty" json:"favicon_url,omitempty" validate:"omitempty,url"`
+	PrimaryColor   string `bson:"primary_color,omitempty" json:"primary_color,omitempty" validate:"omitempty,hexcolor"`
+	SecondaryColor string `bson:"secondary_color,omitempty" json:"secondary_color,omitempty" validate:"omitempty,hexcolor"`
+	WebsiteURL     string `bson:"website_url,omitempty" json:"website_url,omitempty" validate:"omitempty,url"`
+	SupportEmail   string `bson:"support_email,omitempty" json:"support_email,omitempty" validate:"omitempty,email"`
+	// HidePlatformBranding removes the platform's own credits from wedding pages
+	HidePlatformBranding bool `bson:"hide_platform_branding" json:"hide_platform_branding"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+// PreviewLink lets someone without an account, such as the couple's partner or
+// planner, view a wedding read-only before it is published. The link's token
+// is signed and carries the link ID and expiry, so only the link itself is
+// stored; revoking the link stops its token from working.
+type PreviewLink struct {
+	ID        ID     `bson:"_id,omitempty" json:"id"`
+	WeddingID ID     `bson:"wedding_id" json:"wedding_id"`
+	Label     string `bson:"label,omitempty" json:"label,omitempty"` // Who the link was shared with
+
+	ExpiresAt  time.Time  `bson:"expires_at" json:"expires_at"`
+	RevokedAt  *time.Time `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	UseCount   int64      `bson:"use_count" json:"use_count"`
+	LastUsedAt *time.Time `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+	CreatedBy ID        `bson:"created_by" json:"created_by"`
+}
+
+// IsActive reports whether the link still grants access to the wedding
+func (l *PreviewLink) IsActive(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt)
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+)
+
+// PushEvent is an event a user may get a push notification for
+type PushEvent string
+
+const (
+	// PushEventRSVPAttending is a guest accepting the invitation
+	PushEventRSVPAttending PushEvent = "rsvp.attending"
+	// PushEventRSVPDeclined is a guest declining the invitation
+	PushEventRSVPDeclined PushEvent = "rsvp.declined"
+	// PushEventRSVPMaybe is a guest answering maybe
+	PushEventRSVPMaybe PushEvent = "rsvp.maybe"
+	// PushEventRSVPReview is an RSVP held for review as suspicious
+	PushEventRSVPReview PushEvent = "rsvp.needs_review"
+)
+
+// PushEvents lists every event that sends push notifications
+var PushEvents = []PushEvent{
+	PushEventRSVPAttending,
+	PushEventRSVPDeclined,
+	PushEventRSVPMaybe,
+	PushEventRSVPReview,
+}
+
+// IsValidPushEvent checks whether the event sends push notifications
+func IsValidPushEvent(event string) bool {
+	for _, e := range PushEvents {
+		if string(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationPreferences controls which notifications a user receives
+type NotificationPreferences struct {
+	// PushMuted are the events the user gets no push notifications for; every
+	// other event pushes to all of the user's subscribed browsers
+	PushMuted []PushEvent `bson:"push_muted,omitempty" json:"push_muted"`
+}
+
+// Pushes reports whether the user gets push notifications for the event
+func (p NotificationPreferences) Pushes(event PushEvent) bool {
+	for _, muted := range p.PushMuted {
+		if muted == event {
+			return false
+		}
+	}
+	return true
+}
+
+// PushSubscription is a browser a user subscribed to Web Push notifications.
+// The keys encrypt the notifications so that only that browser can read them.
+type PushSubscription struct {
+	ID     ID `bson:"_id,omitempty" json:"id"`
+	UserID ID `bson:"user_id" json:"user_id"`
+	// Endpoint is the push service URL of the browser, unique per subscription
+	Endpoint   string     `bson:"endpoint" json:"endpoint"`
+	P256dh     string     `bson:"p256dh" json:"-"`
+	Auth       string     `bson:"auth" json:"-"`
+	UserAgent  string     `bson:"user_agent,omitempty" json:"user_agent,omitempty"`
+	CreatedAt  time.Time  `bson:"created_at" json:"created_at"`
+	LastPushAt *time.Time `bson:"last_push_at,omitempty" json:"last_push_at,omitempty"`
+}
+
+// PushNotification is the payload a subscribed browser's service worker receives
+type PushNotification struct {
+	Event     PushEvent         `bson:"event" json:"event"`
+	Title     string            `bson:"title" json:"title"`
+	Body      string            `bson:"body" json:"body"`
+	WeddingID ID                `bson:"wedding_id" json:"wedding_id"`
+	Data      map[string]string `bson:"data,omitempty" json:"data,omitempty"`
+	// Tag lets the browser replace an earlier notification about the same thing
+	Tag string `bson:"tag,omitempty" json:"tag,omitempty"`
+}
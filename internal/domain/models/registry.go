@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+)
+
+// RegistryItemType identifies how guests send a gift
+type RegistryItemType string
+
+const (
+	// RegistryBankAccount is a bank account guests transfer cash gifts to
+	RegistryBankAccount RegistryItemType = "bank_account"
+	// RegistryEWallet is an e-wallet guests send cash gifts to
+	RegistryEWallet RegistryItemType = "e_wallet"
+	// RegistryExternalLink is a gift registry hosted by a store
+	RegistryExternalLink RegistryItemType = "external_link"
+)
+
+// GiftPledgeStatus tracks a pledged gift until the couple has thanked the guest
+type GiftPledgeStatus string
+
+const (
+	GiftPledged  GiftPledgeStatus = "pledged"
+	GiftReceived GiftPledgeStatus = "received"
+	GiftThanked  GiftPledgeStatus = "thanked"
+)
+
+// RegistrySettings controls the gift registry of a wedding
+type RegistrySettings struct {
+	// AllowPledges lets guests record the gift they sent on the public page
+	AllowPledges bool `bson:"allow_pledges" json:"allow_pledges"`
+}
+
+// RegistryItem is a way for guests to send a gift, shown on the wedding's public page
+type RegistryItem struct {
+	ID        ID               `bson:"_id,omitempty" json:"id"`
+	WeddingID ID               `bson:"wedding_id" json:"wedding_id"`
+	Type      RegistryItemType `bson:"type" json:"type"`
+	Label     string           `bson:"label" json:"label"`
+	// Provider is the bank, e-wallet or store name
+	Provider      string    `bson:"provider,omitempty" json:"provider,omitempty"`
+	AccountName   string    `bson:"account_name,omitempty" json:"account_name,omitempty"`
+	AccountNumber string    `bson:"account_number,omitempty" json:"account_number,omitempty"`
+	URL           string    `bson:"url,omitempty" json:"url,omitempty"`
+	Note          string    `bson:"note,omitempty" json:"note,omitempty"`
+	Order         int       `bson:"order" json:"order"`
+	CreatedAt     time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// GiftPledge is a gift a guest reports having sent
+type GiftPledge struct {
+	ID             ID     `bson:"_id,omitempty" json:"id"`
+	WeddingID      ID     `bson:"wedding_id" json:"wedding_id"`
+	RegistryItemID *ID    `bson:"registry_item_id,omitempty" json:"registry_item_id,omitempty"`
+	GuestName      string `bson:"guest_name" json:"guest_name"`
+	GuestEmail     string `bson:"guest_email,omitempty" json:"guest_email,omitempty"`
+	// Amount is in the smallest unit of Currency; empty for gifts other than cash
+	Amount    int64            `bson:"amount,omitempty" json:"amount,omitempty"`
+	Currency  string           `bson:"currency,omitempty" json:"currency,omitempty"`
+	Message   string           `bson:"message,omitempty" json:"message,omitempty"`
+	Status    GiftPledgeStatus `bson:"status" json:"status"`
+	ThankedAt *time.Time       `bson:"thanked_at,omitempty" json:"thanked_at,omitempty"`
+	PledgerIP string           `bson:"pledger_ip,omitempty" json:"-"`
+	CreatedAt time.Time        `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time        `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+)
+
+// ReminderChannel is how a reminder reaches guests
+type ReminderChannel string
+
+const (
+	ReminderChannelEmail    ReminderChannel = "email"
+	ReminderChannelWhatsApp ReminderChannel = "whatsapp"
+)
+
+// IsValid checks whether the channel is supported
+func (c ReminderChannel) IsValid() bool {
+	return c == ReminderChannelEmail || c == ReminderChannelWhatsApp
+}
+
+// ReminderCampaignStatus is the lifecycle state of a reminder campaign
+type ReminderCampaignStatus string
+
+const (
+	ReminderCampaignScheduled ReminderCampaignStatus = "scheduled"
+	ReminderCampaignSending   ReminderCampaignStatus = "sending"
+	ReminderCampaignSent      ReminderCampaignStatus = "sent"
+	ReminderCampaignCancelled ReminderCampaignStatus = "cancelled"
+)
+
+// ReminderCampaign is a scheduled reminder to the guests of a wedding who have not RSVP'd yet
+type ReminderCampaign struct {
+	ID        ID                     `bson:"_id,omitempty" json:"id"`
+	WeddingID ID                     `bson:"wedding_id" json:"wedding_id"`
+	CreatedBy ID                     `bson:"created_by" json:"created_by"`
+	Channel   ReminderChannel        `bson:"channel" json:"channel"`
+	Subject   string                 `bson:"subject,omitempty" json:"subject,omitempty"` // Email only
+	Template  string                 `bson:"template" json:"template"`
+	SendAt    time.Time              `bson:"send_at" json:"send_at"`
+	Status    ReminderCampaignStatus `bson:"status" json:"status"`
+
+	// Outcome of the dispatch. Skipped guests had no address for the channel or
+	// already answered.
+	Recipients  int        `bson:"recipients" json:"recipients"`
+	Sent        int        `bson:"sent" json:"sent"`
+	Failed      int        `bson:"failed" json:"failed"`
+	Skipped     int        `bson:"skipped" json:"skipped"`
+	StartedAt   *time.Time `bson:"started_at,omitempty" json:"started_at,omitempty"`
+	CompletedAt *time.Time `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+	LockedUntil *time.Time `bson:"locked_until,omitempty" json:"-"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Reminder delivery statuses
+const (
+	ReminderDeliverySent   = "sent"
+	ReminderDeliveryFailed = "failed"
+)
+
+// ReminderDelivery is the reminder of a campaign sent to one guest. The guest's
+// email and phone are kept to attribute later RSVPs to the reminder.
+type ReminderDelivery struct {
+	ID          ID              `bson:"_id,omitempty" json:"id"`
+	CampaignID  ID              `bson:"campaign_id" json:"campaign_id"`
+	WeddingID   ID              `bson:"wedding_id" json:"wedding_id"`
+	GuestID     ID              `bson:"guest_id" json:"guest_id"`
+	Channel     ReminderChannel `bson:"channel" json:"channel"`
+	Email       string          `bson:"email,omitempty" json:"email,omitempty"`
+	Phone       string          `bson:"phone,omitempty" json:"phone,omitempty"`
+	Status      string          `bson:"status" json:"status"`
+	Error       string          `bson:"error,omitempty" json:"error,omitempty"`
+	SentAt      time.Time       `bson:"sent_at" json:"sent_at"`
+	OpenedAt    *time.Time      `bson:"opened_at,omitempty" json:"opened_at,omitempty"`
+	RespondedAt *time.Time      `bson:"responded_at,omitempty" json:"responded_at,omitempty"`
+}
+
+// ReminderDeliveryStats counts the deliveries of a campaign
+type ReminderDeliveryStats struct {
+	Sent      int64 `json:"sent"`
+	Failed    int64 `json:"failed"`
+	Opened    int64 `json:"opened"`
+	Responded int64 `json:"responded"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// RequestLogEntry is a structured record of a single API request
+type RequestLogEntry struct {
+	RequestID string        `json:"request_id"`
+	Method    string        `json:"method"`
+	Path      string        `json:"path"`
+	Status    int           `json:"status"`
+	Latency   time.Duration `json:"latency"`
+	ClientIP  string        `json:"client_ip"`
+	UserAgent string        `json:"user_agent,omitempty"`
+	UserID    string        `json:"user_id,omitempty"`
+	Errors    []string      `json:"errors,omitempty"`
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+// RequestTrace correlates analytics events and request logs sharing a request ID
+type RequestTrace struct {
+	RequestID   string             `json:"request_id"`
+	PageViews   []*PageView        `json:"page_views"`
+	Conversions []*ConversionEvent `json:"conversions"`
+	Logs        []RequestLogEntry  `json:"logs"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// Kinds of data the retention job deletes once they are older than their period
+const (
+	RetentionPageViews   = "page_views"
+	RetentionRSVPEvents  = "rsvp_events"
+	RetentionConversions = "conversions"
+	RetentionAuditLogs   = "audit_logs"
+)
+
+// RetentionDataTypes lists every kind of data under the retention policy
+var RetentionDataTypes = []string{RetentionPageViews, RetentionRSVPEvents, RetentionConversions, RetentionAuditLogs}
+
+// RetentionOverride shortens how long the data of one wedding is kept, e.g. at
+// the couple's request. Periods are in days by data type; a type left out
+// keeps the platform's period.
+type RetentionOverride struct {
+	WeddingID ID             `bson:"_id" json:"wedding_id"`
+	Days      map[string]int `bson:"days" json:"days"`
+	UpdatedAt time.Time      `bson:"updated_at" json:"updated_at"`
+}
+
+// Period returns how long the wedding keeps a kind of data under the override,
+// or 0 when the override leaves it to the platform
+func (o *RetentionOverride) Period(dataType string) time.Duration {
+	if o == nil || o.Days[dataType] <= 0 {
+		return 0
+	}
+	return time.Duration(o.Days[dataType]) * 24 * time.Hour
+}
+
+// RetentionPolicy is the platform's period for a kind of data
+type RetentionPolicy struct {
+	DataType string `json:"data_type"`
+	// Days is 0 for data kept forever
+	Days int `json:"days"`
+	// TTLIndex is set when MongoDB expires the data rather than the retention job
+	TTLIndex bool `json:"ttl_index"`
+}
+
+// RetentionResult is how much of a kind of data one retention run deleted, or
+// would have deleted in a dry run, for the platform or a single wedding
+type RetentionResult struct {
+	DataType  string    `json:"data_type"`
+	WeddingID *ID       `json:"wedding_id,omitempty"` // Set for a wedding's override
+	Before    time.Time `json:"before"`
+	Count     int64     `json:"count"`
+}
+
+// RetentionReport is the outcome of a retention run
+type RetentionReport struct {
+	DryRun  bool              `json:"dry_run"`
+	RanAt   time.Time         `json:"ran_at"`
+	Results []RetentionResult `json:"results"`
+	// Total is the sum of the results' counts
+	Total int64 `json:"total"`
+}
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"time"
+)
+
+// Account roles of a user
+const (
+	RoleUser         = "user" // Default role of existing accounts, equivalent to RoleOwner
+	RoleOwner        = "owner"
+	RoleCollaborator = "collaborator"
+	RolePlanner      = "planner"
+	RoleAdmin        = "admin"
+	// RoleOrgAdmin administers the organization of the user, see User.IsTenantAdmin.
+	// Unlike RoleAdmin it grants nothing outside the organization.
+	RoleOrgAdmin = "org_admin"
+)
+
+// Permission is an action a role may perform
+type Permission string
+
+const (
+	PermissionCreateWedding       Permission = "wedding:create"
+	PermissionEditWedding         Permission = "wedding:edit"
+	PermissionDeleteWedding       Permission = "wedding:delete"
+	PermissionManageGuests        Permission = "guests:manage"
+	PermissionManageRSVPs         Permission = "rsvps:manage"
+	PermissionViewAnalytics       Permission = "analytics:view"
+	PermissionManageCollaborators Permission = "collaborators:manage"
+	PermissionManageUsers         Permission = "users:manage"
+	PermissionViewSystem          Permission = "system:view"
+	PermissionManageOrganization  Permission = "organization:manage"
+)
+
+// rolePermissions are the account-wide permissions of each role. Admins may do
+// everything; other roles act on a wedding through their WeddingRole in it.
+var rolePermissions = map[string][]Permission{
+	RoleUser:         {PermissionCreateWedding},
+	RoleOwner:        {PermissionCreateWedding},
+	RolePlanner:      {PermissionCreateWedding},
+	RoleCollaborator: {},
+	RoleOrgAdmin:     {PermissionCreateWedding, PermissionManageOrganization},
+}
+
+// IsValidRole reports whether role is a known account role
+func IsValidRole(role string) bool {
+	if role == RoleAdmin {
+		return true
+	}
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// RoleHasPermission reports whether an account role grants the permission
+func RoleHasPermission(role string, permission Permission) bool {
+	if role == RoleAdmin {
+		return true
+	}
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// WeddingRole is the role of a user within one wedding
+type WeddingRole string
+
+const (
+	// WeddingRoleOwner is the user who created the wedding
+	WeddingRoleOwner WeddingRole = "owner"
+	// WeddingRoleCollaborator co-manages the wedding, e.g. the other half of the couple
+	WeddingRoleCollaborator WeddingRole = "collaborator"
+	// WeddingRolePlanner manages guests and RSVPs without editing the wedding itself
+	WeddingRolePlanner WeddingRole = "planner"
+)
+
+var weddingRolePermissions = map[WeddingRole][]Permission{
+	WeddingRoleOwner: {
+		PermissionEditWedding,
+		PermissionDeleteWedding,
+		PermissionManageGuests,
+		PermissionManageRSVPs,
+		PermissionViewAnalytics,
+		PermissionManageCollaborators,
+	},
+	WeddingRoleCollaborator: {
+		PermissionEditWedding,
+		PermissionManageGuests,
+		PermissionManageRSVPs,
+		PermissionViewAnalytics,
+	},
+	WeddingRolePlanner: {
+		PermissionManageGuests,
+		PermissionManageRSVPs,
+		PermissionViewAnalytics,
+	},
+}
+
+// IsValid reports whether r is a known wedding role
+func (r WeddingRole) IsValid() bool {
+	_, ok := weddingRolePermissions[r]
+	return ok
+}
+
+// Has reports whether the wedding role grants the permission
+func (r WeddingRole) Has(permission Permission) bool {
+	for _, p := range weddingRolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// WeddingCollaborator is a user other than the owner who may manage a wedding
+type WeddingCollaborator struct {
+	UserID  ID          `bson:"user_id" json:"user_id"`
+	Role    WeddingRole `bson:"role" json:"role"`
+	AddedAt time.Time   `bson:"added_at" json:"added_at"`
+}
+
+// CollaboratorInvite is a pending invitation for the owner of an email address to
+// collaborate on a wedding. Only a hash of the emailed token is stored.
+type CollaboratorInvite struct {
+	ID        ID          `bson:"_id" json:"id"`
+	Email     string      `bson:"email" json:"email"`
+	Role      WeddingRole `bson:"role" json:"role"`
+	TokenHash string      `bson:"token_hash" json:"-"`
+	InvitedBy ID          `bson:"invited_by" json:"invited_by"`
+	ExpiresAt time.Time   `bson:"expires_at" json:"expires_at"`
+	CreatedAt time.Time   `bson:"created_at" json:"created_at"`
+}
+
+// IsExpired reports whether the invitation can no longer be accepted
+func (i *CollaboratorInvite) IsExpired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
+
+// RoleOf returns the role of the user in the wedding
+func (w *Wedding) RoleOf(userID ID) (WeddingRole, bool) {
+	if w.UserID == userID {
+		return WeddingRoleOwner, true
+	}
+	for _, collaborator := range w.Collaborators {
+		if collaborator.UserID == userID {
+			return collaborator.Role, true
+		}
+	}
+	return "", false
+}
+
+// Can reports whether the user's role in the wedding grants the permission
+func (w *Wedding) Can(userID ID, permission Permission) bool {
+	role, ok := w.RoleOf(userID)
+	return ok && role.Has(permission)
+}
@@ -1,15 +1,24 @@
 package models
 
 import (
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// PlusOneInfo for guests bringing additional people
+// PlusOneInfo for guests bringing additional people. The ID identifies the
+// companion at check-in; Age lets caterers and venues plan for children.
 type PlusOneInfo struct {
-	FirstName string `bson:"first_name" json:"first_name"`
-	LastName  string `bson:"last_name" json:"last_name"`
-	Dietary   string `bson:"dietary,omitempty" json:"dietary,omitempty"`
+	ID         string   `bson:"id,omitempty" json:"id,omitempty"`
+	FirstName  string   `bson:"first_name" json:"first_name"`
+	LastName   string   `bson:"last_name" json:"last_name"`
+	Age        *int     `bson:"age,omitempty" json:"age,omitempty"`
+	Dietary    string   `bson:"dietary,omitempty" json:"dietary,omitempty"`
+	MealChoice string   `bson:"meal_choice,omitempty" json:"meal_choice,omitempty"`
+	Allergies  []string `bson:"allergies,omitempty" json:"allergies,omitempty"`
 }
 
 // CustomAnswer stores responses to custom questions
@@ -19,10 +28,43 @@ type CustomAnswer struct {
 	Answer     interface{} `bson:"answer" json:"answer"` // Can be string, []string, bool, etc.
 }
 
+// Text formats the answer for exports, joining multiple choices with "; "
+func (a CustomAnswer) Text() string {
+	switch value := a.Answer.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case []string:
+		return strings.Join(value, "; ")
+	case primitive.A:
+		return CustomAnswer{Answer: []interface{}(value)}.Text()
+	case []interface{}:
+		parts := make([]string, 0, len(value))
+		for _, part := range value {
+			parts = append(parts, CustomAnswer{Answer: part}.Text())
+		}
+		return strings.Join(parts, "; ")
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// RSVPSessionResponse is a guest's answer for one session of a multi-session wedding
+type RSVPSessionResponse struct {
+	SessionID string `bson:"session_id" json:"session_id" validate:"required"`
+	Status    string `bson:"status" json:"status" validate:"oneof=attending not-attending maybe"`
+}
+
 type RSVP struct {
-	ID        primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
-	WeddingID primitive.ObjectID  `bson:"wedding_id" json:"wedding_id"`
-	GuestID   *primitive.ObjectID `bson:"guest_id,omitempty" json:"guest_id,omitempty"` // Link to pre-registered guest
+	ID        ID  `bson:"_id,omitempty" json:"id"`
+	WeddingID ID  `bson:"wedding_id" json:"wedding_id"`
+	GuestID   *ID `bson:"guest_id,omitempty" json:"guest_id,omitempty"` // Link to pre-registered guest
+	// GroupID is the household of the linked guest; the RSVP then answers for every member
+	GroupID *ID                `bson:"group_id,omitempty" json:"group_id,omitempty"`
+	Members []RSVPMemberStatus `bson:"members,omitempty" json:"members,omitempty"`
 
 	// Guest Information (if not linked to pre-registered guest)
 	FirstName string `bson:"first_name" json:"first_name" validate:"required,max=50"`
@@ -33,6 +75,8 @@ type RSVP struct {
 	// RSVP Response
 	Status          string `bson:"status" json:"status" validate:"oneof=attending not-attending maybe"`
 	AttendanceCount int    `bson:"attendance_count" json:"attendance_count" validate:"min=1"`
+	// Sessions holds the answer for every session of a multi-session wedding
+	Sessions []RSVPSessionResponse `bson:"sessions,omitempty" json:"sessions,omitempty"`
 
 	// Plus Ones
 	PlusOnes     []PlusOneInfo `bson:"plus_ones,omitempty" json:"plus_ones,omitempty"`
@@ -42,6 +86,9 @@ type RSVP struct {
 	DietaryRestrictions string   `bson:"dietary_restrictions,omitempty" json:"dietary_restrictions,omitempty"`
 	DietarySelected     []string `bson:"dietary_selected,omitempty" json:"dietary_selected,omitempty"`
 	AdditionalNotes     string   `bson:"additional_notes,omitempty" json:"additional_notes,omitempty" validate:"omitempty,max=500"`
+	// MealChoice is one of the wedding's meal options; plus ones choose their own
+	MealChoice string   `bson:"meal_choice,omitempty" json:"meal_choice,omitempty"`
+	Allergies  []string `bson:"allergies,omitempty" json:"allergies,omitempty"`
 
 	// Custom Questions Answers
 	CustomAnswers []CustomAnswer `bson:"custom_answers,omitempty" json:"custom_answers,omitempty"`
@@ -59,6 +106,29 @@ type RSVP struct {
 	// Internal tracking
 	Source string `bson:"source" json:"source" validate:"oneof=web direct_link qr_code manual"`
 	Notes  string `bson:"notes,omitempty" json:"notes,omitempty"` // Admin notes
+
+	// Fraud review, set when the submission was flagged as suspicious
+	Review *RSVPReview `bson:"review,omitempty" json:"review,omitempty"`
+
+	// Changes made after submission, oldest first
+	Changes []RSVPChange `bson:"changes,omitempty" json:"changes,omitempty"`
+}
+
+// RSVPMemberStatus is the answer a household RSVP gives for one of the other members
+type RSVPMemberStatus struct {
+	GuestID ID     `bson:"guest_id" json:"guest_id" validate:"required"`
+	Status  string `bson:"status" json:"status" validate:"oneof=attending not-attending maybe"`
+}
+
+// MemberStatus returns the answer given for a household member, the RSVP's own
+// status when the member was not answered for separately
+func (r *RSVP) MemberStatus(guestID ID) string {
+	for _, member := range r.Members {
+		if member.GuestID == guestID {
+			return member.Status
+		}
+	}
+	return r.Status
 }
 
 // RSVPStatus represents possible response statuses
@@ -80,6 +150,46 @@ type RSVPStatistics struct {
 	PlusOnesCount   int            `json:"plus_ones_count"`
 	DietaryCounts   map[string]int `json:"dietary_counts"`
 	SubmissionTrend []DailyCount   `json:"submission_trend"`
+	// Sessions breaks the responses down per session of a multi-session wedding
+	Sessions []SessionStatistics `json:"sessions,omitempty"`
+	// CustomQuestions sums up the answers to the wedding's custom RSVP questions
+	CustomQuestions []CustomQuestionStatistics `json:"custom_questions,omitempty"`
+}
+
+// CustomQuestionStatistics sums up the answers to one custom RSVP question.
+// Choice questions count every option, number questions summarize the numbers
+// given and text questions only count responses.
+type CustomQuestionStatistics struct {
+	QuestionID string               `json:"question_id"`
+	Question   string               `json:"question"`
+	Type       string               `json:"type"`
+	Responses  int                  `json:"responses"`
+	Options    []OptionCount        `json:"options,omitempty"`
+	Number     *NumberAnswerSummary `json:"number,omitempty"`
+}
+
+// OptionCount is how many responses picked a choice question's option
+type OptionCount struct {
+	Option string `json:"option"`
+	Count  int    `json:"count"`
+}
+
+// NumberAnswerSummary summarizes the answers to a number question
+type NumberAnswerSummary struct {
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Sum     float64 `json:"sum"`
+	Average float64 `json:"average"`
+}
+
+// SessionStatistics breaks RSVP responses down for one event session
+type SessionStatistics struct {
+	SessionID    string `json:"session_id"`
+	Title        string `json:"title,omitempty"`
+	Attending    int    `json:"attending"`
+	NotAttending int    `json:"not_attending"`
+	Maybe        int    `json:"maybe"`
+	TotalGuests  int    `json:"total_guests"` // Attending guests, including plus ones
 }
 
 type DailyCount struct {
@@ -97,6 +207,19 @@ const (
 	RSVPSourceManual     RSVPSource = "manual"
 )
 
+// FullName returns the companion's first and last name
+func (p PlusOneInfo) FullName() string {
+	return strings.TrimSpace(p.FirstName + " " + p.LastName)
+}
+
+// Label names the companion for lists and exports, with their age when known
+func (p PlusOneInfo) Label() string {
+	if p.Age == nil {
+		return p.FullName()
+	}
+	return fmt.Sprintf("%s (%d)", p.FullName(), *p.Age)
+}
+
 // Helper methods for RSVP
 func (r *RSVP) GetFullName() string {
 	return r.FirstName + " " + r.LastName
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Who changed an RSVP after it was submitted
+const (
+	RSVPChangedByGuest        = "guest" // through the guest's edit link
+	RSVPChangedByOwner        = "owner"
+	RSVPChangedByResubmission = "resubmission" // by the guest submitting the RSVP form again
+)
+
+// MaxRSVPChanges is the number of changes kept in an RSVP's history; older ones are dropped
+const MaxRSVPChanges = 50
+
+// RSVPChange records one update of an RSVP and the fields it changed
+type RSVPChange struct {
+	ChangedAt time.Time         `bson:"changed_at" json:"changed_at"`
+	ChangedBy string            `bson:"changed_by" json:"changed_by"`
+	Fields    []RSVPFieldChange `bson:"fields" json:"fields"`
+}
+
+// RSVPFieldChange is the old and new value of a changed RSVP field, as shown to the owner
+type RSVPFieldChange struct {
+	Field string `bson:"field" json:"field"`
+	From  string `bson:"from" json:"from"`
+	To    string `bson:"to" json:"to"`
+}
+
+// RecordChange appends a change to the RSVP's history, keeping the latest MaxRSVPChanges
+func (r *RSVP) RecordChange(change RSVPChange) {
+	r.Changes = append(r.Changes, change)
+	if len(r.Changes) > MaxRSVPChanges {
+		r.Changes = r.Changes[len(r.Changes)-MaxRSVPChanges:]
+	}
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// Guest fields the attendance of a wedding's guest list can be broken down by
+const (
+	GuestBreakdownSide         = "side"
+	GuestBreakdownRelationship = "relationship"
+	GuestBreakdownVIP          = "vip"
+)
+
+// Keys of the VIP breakdown, and of guests a breakdown field is not set for
+const (
+	BreakdownVIP         = "vip"
+	BreakdownRegular     = "regular"
+	BreakdownUnspecified = "unspecified"
+)
+
+// RSVPInsights is the in-depth view of a wedding's RSVPs for its dashboard.
+// Like RSVPStatistics it leaves out RSVPs held for review.
+type RSVPInsights struct {
+	WeddingID    ID  `json:"wedding_id"`
+	Responses    int `json:"responses"`
+	Attending    int `json:"attending"`
+	NotAttending int `json:"not_attending"`
+	Maybe        int `json:"maybe"`
+
+	// InvitedGuests is the size of the guest list and RespondedGuests how many
+	// on it answered; ResponseRate is their ratio, 0 without a guest list
+	InvitedGuests   int     `json:"invited_guests"`
+	RespondedGuests int     `json:"responded_guests"`
+	ResponseRate    float64 `json:"response_rate"`
+
+	// Timeline counts the answers by the day they were submitted, oldest first
+	Timeline  []AttendanceDay     `json:"timeline"`
+	Headcount HeadcountProjection `json:"headcount"`
+
+	BySide         []AttendanceBreakdown `json:"by_side"`
+	ByRelationship []AttendanceBreakdown `json:"by_relationship"`
+	ByVIP          []AttendanceBreakdown `json:"by_vip"`
+
+	// Meals counts the meal choices of everyone attending, plus ones included
+	Meals        []OptionCount `json:"meals"`
+	NoMealChoice int           `json:"no_meal_choice"`
+
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// AttendanceDay counts the RSVPs submitted on one day by their current answer
+type AttendanceDay struct {
+	Date         string `json:"date"` // YYYY-MM-DD, UTC
+	Attending    int    `json:"attending"`
+	NotAttending int    `json:"not_attending"`
+	Maybe        int    `json:"maybe"`
+	// Headcount is the people the attending RSVPs bring, plus ones included,
+	// and MaybeHeadcount those of the maybe RSVPs
+	Headcount      int `json:"headcount"`
+	MaybeHeadcount int `json:"maybe_headcount"`
+	// TotalHeadcount is the headcount of this day and all days before it
+	TotalHeadcount int `json:"total_headcount"`
+}
+
+// HeadcountProjection estimates how many people will come
+type HeadcountProjection struct {
+	// Confirmed is everyone attending RSVPs bring, ConfirmedPlusOnes the plus
+	// ones among them
+	Confirmed         int `json:"confirmed"`
+	ConfirmedPlusOnes int `json:"confirmed_plus_ones"`
+	// Maybe is everyone maybe RSVPs would bring
+	Maybe int `json:"maybe"`
+	// PendingGuests are the guests on the list who have not answered yet
+	PendingGuests int `json:"pending_guests"`
+	// AcceptanceRate is the share of attending among the RSVPs that decided
+	AcceptanceRate float64 `json:"acceptance_rate"`
+	// AveragePartySize is the people an attending RSVP brings on average
+	AveragePartySize float64 `json:"average_party_size"`
+	// Projected adds to the confirmed headcount half of the maybes and the
+	// pending guests expected to accept, with parties of the average size
+	Projected int `json:"projected"`
+}
+
+// AttendanceBreakdown counts the answers of the guests sharing a value of a
+// guest field, e.g. the bride's side
+type AttendanceBreakdown struct {
+	Key          string `json:"key"`
+	Invited      int    `json:"invited"`
+	Attending    int    `json:"attending"`
+	NotAttending int    `json:"not_attending"`
+	Maybe        int    `json:"maybe"`
+	Pending      int    `json:"pending"`
+	// Headcount is the attending guests with the companions they named
+	Headcount int `json:"headcount"`
+}
+
+// Add counts guests with an RSVP status, and the headcount they bring when attending
+func (b *AttendanceBreakdown) Add(status string, guests, headcount int) {
+	b.Invited += guests
+	switch status {
+	case string(RSVPAttending):
+		b.Attending += guests
+		b.Headcount += headcount
+	case string(RSVPNotAttending):
+		b.NotAttending += guests
+	case string(RSVPMaybe):
+		b.Maybe += guests
+	default:
+		b.Pending += guests
+	}
+}
+
+// AttendanceBreakdowns collects the counts of guests by breakdown key, keeping
+// guests without a value under BreakdownUnspecified
+type AttendanceBreakdowns map[string]*AttendanceBreakdown
+
+// Add counts guests with a breakdown key and RSVP status
+func (b AttendanceBreakdowns) Add(key, status string, guests, headcount int) {
+	if key == "" {
+		key = BreakdownUnspecified
+	}
+	breakdown, ok := b[key]
+	if !ok {
+		breakdown = &AttendanceBreakdown{Key: key}
+		b[key] = breakdown
+	}
+	breakdown.Add(status, guests, headcount)
+}
+
+// List returns the breakdowns largest group first, then by key
+func (b AttendanceBreakdowns) List() []AttendanceBreakdown {
+	list := make([]AttendanceBreakdown, 0, len(b))
+	for _, breakdown := range b {
+		list = append(list, *breakdown)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Invited != list[j].Invited {
+			return list[i].Invited > list[j].Invited
+		}
+		return list[i].Key < list[j].Key
+	})
+	return list
+}
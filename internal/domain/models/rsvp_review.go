@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+// RSVPReviewStatus represents the owner review state of a flagged RSVP
+type RSVPReviewStatus string
+
+const (
+	RSVPReviewPending  RSVPReviewStatus = "pending"
+	RSVPReviewAccepted RSVPReviewStatus = "accepted"
+	RSVPReviewRejected RSVPReviewStatus = "rejected"
+)
+
+// Signals raised while screening a public RSVP submission
+const (
+	RSVPSignalIPVelocity        = "ip_velocity"
+	RSVPSignalGuestNameMismatch = "guest_name_mismatch"
+	RSVPSignalDisposableEmail   = "disposable_email"
+	// RSVPSignalWalkIn marks a submission matching no guest on the wedding's guest list
+	RSVPSignalWalkIn = "walk_in"
+)
+
+// RSVPReview records why an RSVP was flagged as suspicious and the owner's decision
+type RSVPReview struct {
+	Status     RSVPReviewStatus `bson:"status" json:"status"`
+	Score      int              `bson:"score" json:"score"`
+	Signals    []string         `bson:"signals" json:"signals"`
+	FlaggedAt  time.Time        `bson:"flagged_at" json:"flagged_at"`
+	ReviewedBy *ID              `bson:"reviewed_by,omitempty" json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time       `bson:"reviewed_at,omitempty" json:"reviewed_at,omitempty"`
+}
+
+// NeedsReview checks whether the RSVP is waiting for an owner decision
+func (r *RSVP) NeedsReview() bool {
+	return r.Review != nil && r.Review.Status == RSVPReviewPending
+}
+
+// IsCounted checks whether the RSVP counts towards wedding statistics.
+// Flagged RSVPs only count once the owner accepts them.
+func (r *RSVP) IsCounted() bool {
+	return r.Review == nil || r.Review.Status == RSVPReviewAccepted
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+// RSVPSubmissionStatus represents the lifecycle of a queued RSVP submission
+type RSVPSubmissionStatus string
+
+const (
+	RSVPSubmissionQueued     RSVPSubmissionStatus = "queued"
+	RSVPSubmissionProcessing RSVPSubmissionStatus = "processing"
+	RSVPSubmissionCompleted  RSVPSubmissionStatus = "completed"
+	RSVPSubmissionFailed     RSVPSubmissionStatus = "failed"
+)
+
+// RSVPSubmission is a validated RSVP waiting to be persisted by the write-behind worker
+type RSVPSubmission struct {
+	ID          ID                   `bson:"_id,omitempty" json:"id"`
+	WeddingID   ID                   `bson:"wedding_id" json:"wedding_id"`
+	GuestKey    string               `bson:"guest_key" json:"-"` // Submissions sharing a key are applied in order
+	Partition   int                  `bson:"partition" json:"-"`
+	Status      RSVPSubmissionStatus `bson:"status" json:"status"`
+	RSVP        RSVP                 `bson:"rsvp" json:"-"`
+	Proof       RSVPProof            `bson:"proof" json:"-"`
+	RSVPID      *ID                  `bson:"rsvp_id,omitempty" json:"rsvp_id,omitempty"`
+	Error       string               `bson:"error,omitempty" json:"error,omitempty"`
+	Attempts    int                  `bson:"attempts" json:"attempts"`
+	LockedUntil *time.Time           `bson:"locked_until,omitempty" json:"-"` // Lease while processing, earliest retry while queued
+	CreatedAt   time.Time            `bson:"created_at" json:"created_at"`
+	ProcessedAt *time.Time           `bson:"processed_at,omitempty" json:"processed_at,omitempty"`
+}
+
+// RSVPProof records what a submission proved about its sender. Only a proven
+// submission may change the RSVP its guest answered with before.
+type RSVPProof struct {
+	GuestToken bool `bson:"guest_token,omitempty"`  // Came through the guest's signed invitation link
+	EditRSVPID *ID  `bson:"edit_rsvp_id,omitempty"` // Carried the signed edit link of this RSVP
+}
+
+// IsFinal checks whether the submission has finished processing
+func (s *RSVPSubmission) IsFinal() bool {
+	return s.Status == RSVPSubmissionCompleted || s.Status == RSVPSubmissionFailed
+}
+
+// RSVPGuestKey identifies the guest of a prepared RSVP within its wedding for
+// per-guest ordering: the guest it is linked to, however it was matched, or else
+// the email or name it was submitted with
+func RSVPGuestKey(rsvp *RSVP) string {
+	if rsvp.GuestID != nil {
+		return rsvp.WeddingID.String() + ":guest:" + rsvp.GuestID.String()
+	}
+	identity := strings.ToLower(strings.TrimSpace(rsvp.Email))
+	if identity == "" {
+		identity = strings.ToLower(strings.TrimSpace(rsvp.FirstName) + " " + strings.TrimSpace(rsvp.LastName))
+	}
+	return rsvp.WeddingID.String() + ":" + identity
+}
+
+// RSVPPartition maps a guest key onto one of n worker partitions
+func RSVPPartition(guestKey string, n int) int {
+	if n <= 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	h.Write([]byte(guestKey))
+	return int(h.Sum32() % uint32(n))
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// Session is a device signed in to an account. Its refresh token is replaced
+// on every refresh, and ending the session stops it from being refreshed.
+type Session struct {
+	ID        ID     `bson:"_id,omitempty" json:"id"`
+	UserID    ID     `bson:"user_id" json:"-"`
+	Device    string `bson:"device" json:"device"` // desktop, mobile or tablet
+	Browser   string `bson:"browser" json:"browser"`
+	OS        string `bson:"os" json:"os"`
+	UserAgent string `bson:"user_agent,omitempty" json:"user_agent,omitempty"`
+	IP        string `bson:"ip,omitempty" json:"ip,omitempty"`
+	// RefreshTokenID is the ID of the only refresh token that may refresh the session
+	RefreshTokenID string    `bson:"refresh_token_id" json:"-"`
+	CreatedAt      time.Time `bson:"created_at" json:"created_at"`
+	LastSeenAt     time.Time `bson:"last_seen_at" json:"last_seen_at"`
+	ExpiresAt      time.Time `bson:"expires_at" json:"expires_at"`
+	// Current marks the session of the request listing the sessions
+	Current bool `bson:"-" json:"current"`
+}
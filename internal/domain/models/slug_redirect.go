@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+// SlugRedirect remembers a slug a wedding had before it was renamed, so that
+// links shared with the old slug keep leading to the wedding. The old slug
+// stays with the wedding and cannot be taken by another one.
+type SlugRedirect struct {
+	Slug      string    `bson:"_id" json:"slug"`
+	WeddingID ID        `bson:"wedding_id" json:"wedding_id"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// SystemConfigID is the identifier of the singleton system configuration document
+const SystemConfigID = "global"
+
+// SystemConfig holds environment-wide settings provisioned at bootstrap
+type SystemConfig struct {
+	ID                 string     `bson:"_id" json:"id"`
+	Bootstrapped       bool       `bson:"bootstrapped" json:"bootstrapped"`
+	BootstrappedAt     *time.Time `bson:"bootstrapped_at,omitempty" json:"bootstrapped_at,omitempty"`
+	BootstrapTokenHash string     `bson:"bootstrap_token_hash,omitempty" json:"-"` // SHA-256 of the consumed token
+	RegistrationOpen   bool       `bson:"registration_open" json:"registration_open"`
+	MaintenanceMode    bool       `bson:"maintenance_mode" json:"maintenance_mode"`
+	DefaultThemeID     string     `bson:"default_theme_id" json:"default_theme_id"`
+	SupportEmail       string     `bson:"support_email,omitempty" json:"support_email,omitempty"`
+	UpdatedAt          time.Time  `bson:"updated_at" json:"updated_at"`
+}
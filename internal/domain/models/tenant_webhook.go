@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+)
+
+// LifecycleEventType represents platform-level events delivered to tenant webhooks
+type LifecycleEventType string
+
+const (
+	LifecycleWeddingCreated   LifecycleEventType = "wedding.created"
+	LifecycleWeddingPublished LifecycleEventType = "wedding.published"
+	LifecycleWeddingDeleted   LifecycleEventType = "wedding.deleted"
+	LifecycleUserRegistered   LifecycleEventType = "user.registered" // A user joined the organization
+)
+
+// LifecycleEventTypes lists every event a tenant can subscribe to
+var LifecycleEventTypes = []LifecycleEventType{
+	LifecycleWeddingCreated,
+	LifecycleWeddingPublished,
+	LifecycleWeddingDeleted,
+	LifecycleUserRegistered,
+}
+
+// IsValidLifecycleEventType checks whether the event type is supported
+func IsValidLifecycleEventType(eventType string) bool {
+	for _, t := range LifecycleEventTypes {
+		if string(t) == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// LifecycleEvent is an outbox record of a platform event for a tenant
+type LifecycleEvent struct {
+	ID           ID                     `bson:"_id,omitempty" json:"id"`
+	TenantID     string                 `bson:"tenant_id" json:"tenant_id"`
+	Type         LifecycleEventType     `bson:"type" json:"type"`
+	SubjectID    ID                     `bson:"subject_id" json:"subject_id"` // Wedding or user the event is about
+	Data         map[string]interface{} `bson:"data,omitempty" json:"data,omitempty"`
+	OccurredAt   time.Time              `bson:"occurred_at" json:"occurred_at"`
+	Dispatched   bool                   `bson:"dispatched" json:"-"`
+	DispatchedAt *time.Time             `bson:"dispatched_at,omitempty" json:"-"`
+}
+
+// TenantWebhook is a tenant-scoped subscription to lifecycle events
+type TenantWebhook struct {
+	ID        ID        `bson:"_id,omitempty" json:"id"`
+	TenantID  string    `bson:"tenant_id" json:"tenant_id"`
+	URL       string    `bson:"url" json:"url"`
+	Events    []string  `bson:"events" json:"events"` // Empty subscribes to all events
+	Active    bool      `bson:"active" json:"active"`
+	CreatedBy ID        `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Subscribes checks whether the webhook wants the given event type
+func (w *TenantWebhook) Subscribes(eventType LifecycleEventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// TenantSigningKey is the secret a tenant uses to verify webhook signatures
+type TenantSigningKey struct {
+	TenantID  string    `bson:"_id" json:"tenant_id"`
+	Secret    string    `bson:"secret" json:"-"`
+	RotatedAt time.Time `bson:"rotated_at" json:"rotated_at"`
+}
+
+// TenantWebhookDelivery records a single delivery attempt of an event to a webhook
+type TenantWebhookDelivery struct {
+	ID          ID                 `bson:"_id,omitempty" json:"id"`
+	WebhookID   ID                 `bson:"webhook_id" json:"webhook_id"`
+	EventID     ID                 `bson:"event_id" json:"event_id"`
+	EventType   LifecycleEventType `bson:"event_type" json:"event_type"`
+	StatusCode  int                `bson:"status_code" json:"status_code"`
+	Success     bool               `bson:"success" json:"success"`
+	Error       string             `bson:"error,omitempty" json:"error,omitempty"`
+	Replay      bool               `bson:"replay" json:"replay"`
+	AttemptedAt time.Time          `bson:"attempted_at" json:"attempted_at"`
+}
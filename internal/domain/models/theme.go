@@ -0,0 +1,113 @@
+package models
+
+import (
+	"time"
+)
+
+// Sections of the wedding page a theme can lay out
+const (
+	ThemeSectionHero         = "hero"
+	ThemeSectionStory        = "story"
+	ThemeSectionEvent        = "event"
+	ThemeSectionGallery      = "gallery"
+	ThemeSectionGuestGallery = "guest_gallery"
+	ThemeSectionRSVP         = "rsvp"
+)
+
+// ThemeSections lists every section a theme may support
+var ThemeSections = []string{
+	ThemeSectionHero,
+	ThemeSectionStory,
+	ThemeSectionEvent,
+	ThemeSectionGallery,
+	ThemeSectionGuestGallery,
+	ThemeSectionRSVP,
+}
+
+// ThemeColorKeys lists the colors of a wedding's ThemeSettings a theme may let couples customize
+var ThemeColorKeys = []string{"primary_color", "secondary_color", "background_color"}
+
+// ThemeColorOption describes a color couples may customize in a theme
+type ThemeColorOption struct {
+	Key     string `bson:"key" json:"key" validate:"required"` // One of ThemeColorKeys
+	Label   string `bson:"label" json:"label" validate:"required,max=50"`
+	Default string `bson:"default" json:"default" validate:"required,hexcolor"`
+}
+
+// Theme is a selectable wedding page theme from the catalog
+type Theme struct {
+	ID             string             `bson:"_id" json:"id"`
+	Name           string             `bson:"name" json:"name"`
+	Description    string             `bson:"description,omitempty" json:"description,omitempty"`
+	PrimaryColor   string             `bson:"primary_color" json:"primary_color"`
+	SecondaryColor string             `bson:"secondary_color" json:"secondary_color"`
+	FontFamily     string             `bson:"font_family" json:"font_family"`
+	PreviewImages  []string           `bson:"preview_images,omitempty" json:"preview_images"`
+	Sections       []string           `bson:"sections,omitempty" json:"sections"`
+	ColorScheme    []ThemeColorOption `bson:"color_scheme,omitempty" json:"color_scheme"`
+	IsDefault      bool               `bson:"is_default" json:"is_default"`
+	Premium        bool               `bson:"premium" json:"premium"` // Only for premium plans
+	Active         bool               `bson:"active" json:"active"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// DefaultThemes returns the built-in theme catalog seeded on bootstrap
+func DefaultThemes() []Theme {
+	return []Theme{
+		{
+			ID:             "classic",
+			Name:           "Classic",
+			Description:    "Timeless serif typography on ivory",
+			PrimaryColor:   "#8B7355",
+			SecondaryColor: "#F5F0E6",
+			FontFamily:     "Playfair Display",
+			Sections:       ThemeSections,
+			ColorScheme:    defaultColorScheme("#8B7355", "#F5F0E6", "#FFFDF8"),
+			IsDefault:      true,
+			Active:         true,
+		},
+		{
+			ID:             "modern",
+			Name:           "Modern",
+			Description:    "Clean sans-serif layout with bold accents",
+			PrimaryColor:   "#222222",
+			SecondaryColor: "#FFFFFF",
+			FontFamily:     "Montserrat",
+			Sections:       ThemeSections,
+			ColorScheme:    defaultColorScheme("#222222", "#FFFFFF", "#FFFFFF"),
+			Active:         true,
+		},
+		{
+			ID:             "garden",
+			Name:           "Garden",
+			Description:    "Soft greens and botanical details",
+			PrimaryColor:   "#5B7F5B",
+			SecondaryColor: "#F2F7F0",
+			FontFamily:     "Cormorant Garamond",
+			Sections:       []string{ThemeSectionHero, ThemeSectionStory, ThemeSectionEvent, ThemeSectionGallery, ThemeSectionRSVP},
+			ColorScheme:    defaultColorScheme("#5B7F5B", "#F2F7F0", "#FAFCF9"),
+			Active:         true,
+		},
+		{
+			ID:             "royal",
+			Name:           "Royal",
+			Description:    "Deep navy with gold foil accents",
+			PrimaryColor:   "#1F2A44",
+			SecondaryColor: "#C9A227",
+			FontFamily:     "Cinzel",
+			Sections:       ThemeSections,
+			ColorScheme:    defaultColorScheme("#1F2A44", "#C9A227", "#0F1626"),
+			Premium:        true,
+			Active:         true,
+		},
+	}
+}
+
+func defaultColorScheme(primary, secondary, background string) []ThemeColorOption {
+	return []ThemeColorOption{
+		{Key: "primary_color", Label: "Primary", Default: primary},
+		{Key: "secondary_color", Label: "Secondary", Default: secondary},
+		{Key: "background_color", Label: "Background", Default: background},
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// UploadSession tracks a resumable upload sent through the API in chunks. The
+// received bytes are staged outside the database; Offset counts how many of
+// them have been stored.
+type UploadSession struct {
+	ID        ID        `bson:"_id,omitempty" json:"id"`
+	UserID    ID        `bson:"user_id" json:"user_id"`
+	Filename  string    `bson:"filename" json:"filename"`
+	Size      int64     `bson:"size" json:"size"`
+	Offset    int64     `bson:"offset" json:"offset"`
+	ExpiresAt time.Time `bson:"expires_at" json:"expires_at"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// IsComplete checks whether every byte of the file has been received
+func (s *UploadSession) IsComplete() bool {
+	return s.Offset >= s.Size
+}
@@ -1,32 +1,164 @@
 package models
 
 import (
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"time"
 )
 
 type User struct {
-	ID                     primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
-	Email                  string               `bson:"email" json:"email" validate:"required,email"`
-	PasswordHash           string               `bson:"password_hash" json:"-"` // Never expose in JSON
-	FirstName              string               `bson:"first_name" json:"first_name" validate:"required,min=2,max=50"`
-	LastName               string               `bson:"last_name" json:"last_name" validate:"required,min=2,max=50"`
-	Name                   string               `bson:"name" json:"name"` // Computed field for compatibility
-	Phone                  string               `bson:"phone,omitempty" json:"phone,omitempty" validate:"omitempty,e164"`
-	EmailVerified          bool                 `bson:"email_verified" json:"email_verified"`
-	EmailVerifiedAt        *time.Time           `bson:"email_verified_at,omitempty" json:"email_verified_at,omitempty"`
-	EmailVerificationToken string               `bson:"email_verification_token,omitempty" json:"-"`
-	PasswordResetToken     string               `bson:"password_reset_token,omitempty" json:"-"`
-	PasswordResetExpires   *time.Time           `bson:"password_reset_expires,omitempty" json:"-"`
-	ProfileImageURL        string               `bson:"profile_image_url,omitempty" json:"profile_image_url,omitempty" validate:"omitempty,url"`
-	WeddingIDs             []primitive.ObjectID `bson:"wedding_ids" json:"wedding_ids"` // References to weddings
-	CreatedAt              time.Time            `bson:"created_at" json:"created_at"`
-	UpdatedAt              time.Time            `bson:"updated_at" json:"updated_at"`
-	LastLoginAt            *time.Time           `bson:"last_login_at,omitempty" json:"last_login_at,omitempty"`
-	Status                 UserStatus           `bson:"status" json:"status" validate:"required,oneof=active inactive unverified suspended"`
-	Role                   string               `bson:"role" json:"role" validate:"required,oneof=user admin"`
-	PreferredLanguage      string               `bson:"preferred_language,omitempty" json:"preferred_language,omitempty"`
-	Timezone               string               `bson:"timezone,omitempty" json:"timezone,omitempty"`
+	ID                       ID                      `bson:"_id,omitempty" json:"id"`
+	Email                    string                  `bson:"email" json:"email" validate:"required,email"`
+	PasswordHash             string                  `bson:"password_hash" json:"-"` // Never expose in JSON
+	FirstName                string                  `bson:"first_name" json:"first_name" validate:"required,min=2,max=50"`
+	LastName                 string                  `bson:"last_name" json:"last_name" validate:"required,min=2,max=50"`
+	Name                     string                  `bson:"name" json:"name"` // Computed field for compatibility
+	Phone                    string                  `bson:"phone,omitempty" json:"phone,omitempty" validate:"omitempty,e164"`
+	EmailVerified            bool                    `bson:"email_verified" json:"email_verified"`
+	EmailVerifiedAt          *time.Time              `bson:"email_verified_at,omitempty" json:"email_verified_at,omitempty"`
+	EmailVerificationToken   string                  `bson:"email_verification_token,omitempty" json:"-"`
+	EmailVerificationExpires *time.Time              `bson:"email_verification_expires,omitempty" json:"-"`
+	EmailVerificationSentAt  *time.Time              `bson:"email_verification_sent_at,omitempty" json:"-"` // Throttles resending the link
+	PasswordResetToken       string                  `bson:"password_reset_token,omitempty" json:"-"`
+	PasswordResetExpires     *time.Time              `bson:"password_reset_expires,omitempty" json:"-"`
+	ProfileImageURL          string                  `bson:"profile_image_url,omitempty" json:"profile_image_url,omitempty" validate:"omitempty,url"`
+	WeddingIDs               []ID                    `bson:"wedding_ids" json:"wedding_ids"` // References to weddings
+	CreatedAt                time.Time               `bson:"created_at" json:"created_at"`
+	UpdatedAt                time.Time               `bson:"updated_at" json:"updated_at"`
+	LastLoginAt              *time.Time              `bson:"last_login_at,omitempty" json:"last_login_at,omitempty"`
+	Status                   UserStatus              `bson:"status" json:"status" validate:"required,oneof=active inactive unverified suspended"`
+	Role                     string                  `bson:"role" json:"role" validate:"required,oneof=user owner collaborator planner admin"`
+	TenantID                 string                  `bson:"tenant_id,omitempty" json:"tenant_id,omitempty"` // White-label tenant the user belongs to
+	PreferredLanguage        string                  `bson:"preferred_language,omitempty" json:"preferred_language,omitempty"`
+	Timezone                 string                  `bson:"timezone,omitempty" json:"timezone,omitempty"`
+	Subscription             *Subscription           `bson:"subscription,omitempty" json:"subscription,omitempty"`
+	TwoFactor                *TwoFactorAuth          `bson:"two_factor,omitempty" json:"two_factor,omitempty"`
+	Provider                 AuthProvider            `bson:"provider,omitempty" json:"provider,omitempty"` // How the account was created; empty for password accounts created before social login
+	OAuthAccounts            []OAuthAccount          `bson:"oauth_accounts,omitempty" json:"oauth_accounts,omitempty"`
+	LoginSecurity            *LoginSecurity          `bson:"login_security,omitempty" json:"-"`
+	Deletion                 *AccountDeletion        `bson:"deletion,omitempty" json:"deletion,omitempty"` // Pending request to delete the account
+	Notifications            NotificationPreferences `bson:"notifications" json:"notifications"`
+}
+
+// AuthProvider is a way of signing in
+type AuthProvider string
+
+const (
+	AuthProviderPassword AuthProvider = "password"
+	AuthProviderGoogle   AuthProvider = "google"
+	AuthProviderApple    AuthProvider = "apple"
+)
+
+// OAuthAccount is a social login account linked to a user
+type OAuthAccount struct {
+	Provider AuthProvider `bson:"provider" json:"provider"`
+	// Subject is the provider's stable ID of the account; emails may change
+	Subject  string    `bson:"subject" json:"-"`
+	Email    string    `bson:"email,omitempty" json:"email,omitempty"`
+	LinkedAt time.Time `bson:"linked_at" json:"linked_at"`
+}
+
+// OAuthAccountFor returns the user's linked account at the provider, if any
+func (u *User) OAuthAccountFor(provider AuthProvider) *OAuthAccount {
+	for i := range u.OAuthAccounts {
+		if u.OAuthAccounts[i].Provider == provider {
+			return &u.OAuthAccounts[i]
+		}
+	}
+	return nil
+}
+
+// IsTenantAdmin reports whether the user administers their organization
+func (u *User) IsTenantAdmin() bool {
+	return u.TenantID != "" && u.Role == RoleOrgAdmin
+}
+
+// IsPlatformAdmin reports whether the user administers the whole platform, which
+// members of an organization never do
+func (u *User) IsPlatformAdmin() bool {
+	return u.TenantID == "" && u.Role == RoleAdmin
+}
+
+// HasVerifiedEmail reports whether the user proved they own their email address.
+// Accounts verified before email_verified was recorded only have email_verified_at.
+func (u *User) HasVerifiedEmail() bool {
+	return u.EmailVerified || u.EmailVerifiedAt != nil
+}
+
+// TwoFactorAuth is a user's authenticator app (TOTP) second factor. Only
+// whether it is enabled is exposed.
+type TwoFactorAuth struct {
+	Enabled   bool       `bson:"enabled" json:"enabled"`
+	EnabledAt *time.Time `bson:"enabled_at,omitempty" json:"enabled_at,omitempty"`
+	Secret    string     `bson:"secret,omitempty" json:"-"`
+	// PendingSecret was handed out by setup and becomes Secret once a code from it is verified
+	PendingSecret string `bson:"pending_secret,omitempty" json:"-"`
+	// BackupCodes are SHA-256 hashes of the unused single-use backup codes
+	BackupCodes []string `bson:"backup_codes,omitempty" json:"-"`
+	// LastUsedStep is the time step of the last accepted code, so that codes can't be replayed
+	LastUsedStep int64 `bson:"last_used_step,omitempty" json:"-"`
+}
+
+// TwoFactorEnabled reports whether the user must enter a second factor to log in
+func (u *User) TwoFactorEnabled() bool {
+	return u.TwoFactor != nil && u.TwoFactor.Enabled
+}
+
+// LoginSecurity tracks a user's failed logins, lockouts and the devices and
+// countries the user logged in from
+type LoginSecurity struct {
+	// FailedAttempts counts wrong passwords since the last login or lockout
+	FailedAttempts int `bson:"failed_attempts,omitempty"`
+	// Lockouts counts lockouts since the last login; each one lasts longer
+	Lockouts    int        `bson:"lockouts,omitempty"`
+	LockedUntil *time.Time `bson:"locked_until,omitempty"`
+	// UnlockTokenHash is the SHA-256 hash of the token in the emailed unlock link
+	UnlockTokenHash string        `bson:"unlock_token_hash,omitempty"`
+	KnownDevices    []KnownDevice `bson:"known_devices,omitempty"`
+	// KnownCountries are the ISO codes of the countries the user logged in from
+	KnownCountries []string `bson:"known_countries,omitempty"`
+}
+
+// KnownDevice is a device the user logged in from, identified by a
+// fingerprint of its device type, browser and OS
+type KnownDevice struct {
+	Fingerprint string    `bson:"fingerprint"`
+	LastSeenAt  time.Time `bson:"last_seen_at"`
+}
+
+// LoginLocked reports whether logins to the account are locked at the given time
+func (u *User) LoginLocked(now time.Time) bool {
+	return u.LoginSecurity != nil && u.LoginSecurity.LockedUntil != nil && now.Before(*u.LoginSecurity.LockedUntil)
+}
+
+// Unlock lifts a lockout and resets the failed attempts
+func (s *LoginSecurity) Unlock() {
+	s.FailedAttempts = 0
+	s.LockedUntil = nil
+	s.UnlockTokenHash = ""
+}
+
+// AccountDeletion is a user's request to delete their account. It is confirmed
+// through an emailed link, after which the account's data is erased once
+// ScheduledFor has passed unless the user cancels first.
+type AccountDeletion struct {
+	RequestedAt time.Time `bson:"requested_at" json:"requested_at"`
+	// TokenHash is the SHA-256 hash of the token in the emailed confirmation link
+	TokenHash      string     `bson:"token_hash,omitempty" json:"-"`
+	TokenExpiresAt *time.Time `bson:"token_expires_at,omitempty" json:"-"`
+	ConfirmedAt    *time.Time `bson:"confirmed_at,omitempty" json:"confirmed_at,omitempty"`
+	ScheduledFor   *time.Time `bson:"scheduled_for,omitempty" json:"scheduled_for,omitempty"`
+}
+
+// Confirmed reports whether the deletion was confirmed and awaits erasure
+func (d *AccountDeletion) Confirmed() bool {
+	return d != nil && d.ConfirmedAt != nil
+}
+
+// Plan returns the plan the user is currently entitled to
+func (u *User) Plan() PlanTier {
+	if u.Subscription == nil || !u.Subscription.GrantsPlan() {
+		return PlanFree
+	}
+	return u.Subscription.Plan
 }
 
 // UserStatus represents possible user statuses
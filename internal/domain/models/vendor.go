@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+)
+
+// VendorContractStatus tracks where the contract with a vendor stands
+type VendorContractStatus string
+
+const (
+	VendorContractNone        VendorContractStatus = "none"
+	VendorContractNegotiating VendorContractStatus = "negotiating"
+	VendorContractSent        VendorContractStatus = "sent"
+	VendorContractSigned      VendorContractStatus = "signed"
+	VendorContractCancelled   VendorContractStatus = "cancelled"
+)
+
+// IsValid reports whether s is a known contract status
+func (s VendorContractStatus) IsValid() bool {
+	switch s {
+	case VendorContractNone, VendorContractNegotiating, VendorContractSent, VendorContractSigned, VendorContractCancelled:
+		return true
+	}
+	return false
+}
+
+// VendorSharing controls which collaborators of a wedding see a vendor
+type VendorSharing string
+
+const (
+	// VendorPrivate vendors are seen by the wedding owner only
+	VendorPrivate VendorSharing = "private"
+	// VendorSharedView vendors are seen by every collaborator and planner
+	VendorSharedView VendorSharing = "view"
+	// VendorSharedEdit vendors may also be updated by every collaborator and planner
+	VendorSharedEdit VendorSharing = "edit"
+)
+
+// Vendor is a business a wedding's owner works with or considers, e.g. a photographer
+type Vendor struct {
+	ID        ID     `bson:"_id,omitempty" json:"id"`
+	WeddingID ID     `bson:"wedding_id" json:"wedding_id"`
+	Name      string `bson:"name" json:"name"`
+	// Category is what the vendor provides, e.g. photographer, caterer or venue
+	Category    string `bson:"category" json:"category"`
+	ContactName string `bson:"contact_name,omitempty" json:"contact_name,omitempty"`
+	Email       string `bson:"email,omitempty" json:"email,omitempty"`
+	Phone       string `bson:"phone,omitempty" json:"phone,omitempty"`
+	Website     string `bson:"website,omitempty" json:"website,omitempty"`
+	Address     string `bson:"address,omitempty" json:"address,omitempty"`
+
+	ContractStatus VendorContractStatus `bson:"contract_status" json:"contract_status"`
+	Attachments    []VendorAttachment   `bson:"attachments,omitempty" json:"attachments"`
+	Notes          string               `bson:"notes,omitempty" json:"notes,omitempty"`
+	Sharing        VendorSharing        `bson:"sharing" json:"sharing"`
+
+	CreatedBy ID        `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// VendorAttachment is an uploaded file, e.g. a contract or quote, attached to a vendor
+type VendorAttachment struct {
+	MediaID  ID     `bson:"media_id" json:"media_id"`
+	Filename string `bson:"filename" json:"filename"`
+	URL      string `bson:"url" json:"url"`
+	MimeType string `bson:"mime_type" json:"mime_type"`
+	Size     int64  `bson:"size" json:"size"`
+}
+
+// IsVisibleTo reports whether a member of the wedding with the role sees the vendor
+func (v *Vendor) IsVisibleTo(role WeddingRole) bool {
+	return role == WeddingRoleOwner || v.Sharing == VendorSharedView || v.Sharing == VendorSharedEdit
+}
+
+// IsEditableBy reports whether a member of the wedding with the role may update the vendor
+func (v *Vendor) IsEditableBy(role WeddingRole) bool {
+	return role == WeddingRoleOwner || v.Sharing == VendorSharedEdit
+}
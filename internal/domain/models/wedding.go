@@ -1,7 +1,6 @@
 package models
 
 import (
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"time"
 )
 
@@ -15,6 +14,19 @@ type EventDetails struct {
 	VenueMapURL    string    `bson:"venue_map_url,omitempty" json:"venue_map_url,omitempty" validate:"omitempty,url"`
 	DressCode      string    `bson:"dress_code,omitempty" json:"dress_code,omitempty"`
 	AdditionalInfo string    `bson:"additional_info,omitempty" json:"additional_info,omitempty"`
+
+	// EndDate is when the event ends; calendars assume a few hours without it
+	EndDate *time.Time `bson:"end_date,omitempty" json:"end_date,omitempty"`
+	// Timezone is the IANA time zone of the venue, e.g. "Asia/Jakarta"
+	Timezone string `bson:"timezone,omitempty" json:"timezone,omitempty"`
+}
+
+// EventSession is one event of a multi-session wedding, e.g. the akad, the
+// reception or the after-party. Guests RSVP to each session separately.
+type EventSession struct {
+	// ID identifies the session in RSVPs, e.g. "akad" or "reception"
+	ID           string `bson:"id" json:"id,omitempty" validate:"required,slug"`
+	EventDetails `bson:",inline"`
 }
 
 // CoupleInfo represents bride and groom details
@@ -54,14 +66,48 @@ type ThemeSettings struct {
 	CustomSettings  map[string]interface{} `bson:"custom_settings,omitempty" json:"custom_settings,omitempty"`
 }
 
+// Custom RSVP question types
+const (
+	CustomQuestionText         = "text"
+	CustomQuestionSingleChoice = "single_choice"
+	CustomQuestionMultiChoice  = "multi_choice"
+	CustomQuestionNumber       = "number"
+)
+
 // CustomQuestion for RSVP forms
 type CustomQuestion struct {
 	ID       string   `bson:"id" json:"id"`
 	Question string   `bson:"question" json:"question" validate:"required,max=200"`
-	Type     string   `bson:"type" json:"type" validate:"oneof=text textarea select checkbox radio"`
+	Type     string   `bson:"type" json:"type" validate:"oneof=text single_choice multi_choice number textarea select checkbox radio"`
 	Required bool     `bson:"required" json:"required"`
 	Options  []string `bson:"options,omitempty" json:"options,omitempty"`
 	Order    int      `bson:"order" json:"order"`
+	// Min and Max bound the answers to number questions
+	Min *float64 `bson:"min,omitempty" json:"min,omitempty"`
+	Max *float64 `bson:"max,omitempty" json:"max,omitempty"`
+}
+
+// Kind returns the question type, with the older textarea, select, radio and
+// checkbox types mapped onto text, single choice and multi choice. It is empty
+// for unknown types.
+func (q CustomQuestion) Kind() string {
+	switch q.Type {
+	case CustomQuestionText, "textarea":
+		return CustomQuestionText
+	case CustomQuestionSingleChoice, "select", "radio":
+		return CustomQuestionSingleChoice
+	case CustomQuestionMultiChoice, "checkbox":
+		return CustomQuestionMultiChoice
+	case CustomQuestionNumber:
+		return CustomQuestionNumber
+	}
+	return ""
+}
+
+// IsChoice reports whether answers must be picked from the question's options
+func (q CustomQuestion) IsChoice() bool {
+	kind := q.Kind()
+	return kind == CustomQuestionSingleChoice || kind == CustomQuestionMultiChoice
 }
 
 // RSVPSettings configures RSVP form behavior
@@ -74,6 +120,7 @@ type RSVPSettings struct {
 	CollectPhone      bool             `bson:"collect_phone" json:"collect_phone"`
 	CollectDietary    bool             `bson:"collect_dietary" json:"collect_dietary"`
 	DietaryOptions    []string         `bson:"dietary_options,omitempty" json:"dietary_options,omitempty"`
+	MealOptions       []string         `bson:"meal_options,omitempty" json:"meal_options,omitempty"`
 	CustomQuestions   []CustomQuestion `bson:"custom_questions,omitempty" json:"custom_questions,omitempty"`
 	ConfirmationEmail bool             `bson:"confirmation_email" json:"confirmation_email"`
 	EmailTemplate     string           `bson:"email_template,omitempty" json:"email_template,omitempty"`
@@ -92,8 +139,17 @@ type GalleryImage struct {
 
 // Wedding is the main collection document
 type Wedding struct {
-	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	UserID primitive.ObjectID `bson:"user_id" json:"user_id"` // Reference to owner
+	ID     ID `bson:"_id,omitempty" json:"id"`
+	UserID ID `bson:"user_id" json:"user_id"` // Reference to owner
+
+	// TenantID is inherited from the owner when the wedding is created
+	TenantID string `bson:"tenant_id,omitempty" json:"tenant_id,omitempty"`
+
+	// Collaborators may manage the wedding according to their role in it. Not
+	// omitempty so that removing the last one is persisted.
+	Collaborators []WeddingCollaborator `bson:"collaborators" json:"collaborators,omitempty"`
+	// CollaboratorInvites are pending invitations to collaborate
+	CollaboratorInvites []CollaboratorInvite `bson:"collaborator_invites" json:"collaborator_invites,omitempty"`
 
 	// URL and Access
 	Slug         string `bson:"slug" json:"slug" validate:"required,min=3,max=50,slug"`
@@ -104,6 +160,9 @@ type Wedding struct {
 	Title  string       `bson:"title" json:"title" validate:"required,max=100"`
 	Couple CoupleInfo   `bson:"couple" json:"couple"`
 	Event  EventDetails `bson:"event" json:"event"`
+	// Sessions are the events of a wedding held in several parts; Event stays
+	// the main one. Not omitempty so that removing the last one is persisted.
+	Sessions []EventSession `bson:"sessions" json:"sessions,omitempty" validate:"omitempty,max=10,dive"`
 
 	// Media
 	CoverImageURL  string         `bson:"cover_image_url,omitempty" json:"cover_image_url,omitempty"`
@@ -114,14 +173,43 @@ type Wedding struct {
 	Theme ThemeSettings `bson:"theme" json:"theme"`
 	RSVP  RSVPSettings  `bson:"rsvp" json:"rsvp"`
 
+	// Guest photo gallery
+	GuestUploads GuestUploadSettings `bson:"guest_uploads" json:"guest_uploads"`
+
+	// Gift registry
+	Registry RegistrySettings `bson:"registry" json:"registry"`
+
+	// Guestbook wishes
+	Wishes WishSettings `bson:"wishes" json:"wishes"`
+
+	// Background music and song requests
+	Music MusicSettings `bson:"music" json:"music"`
+
+	// Budget tracking
+	Budget BudgetSettings `bson:"budget" json:"budget"`
+
+	// Visitor analytics
+	Analytics AnalyticsSettings `bson:"analytics" json:"analytics"`
+
 	// Social/Sharing
 	ShareMessage string `bson:"share_message,omitempty" json:"share_message,omitempty" validate:"omitempty,max=280"`
 
+	// Language is the BCP 47 tag of the language the content is written in,
+	// "en" when empty
+	Language string `bson:"language,omitempty" json:"language,omitempty"`
+	// Labels names the sections of the invitation, by label key, e.g. "rsvp"
+	Labels map[string]string `bson:"labels,omitempty" json:"labels,omitempty"`
+	// Translations holds the content in other languages, by language tag
+	Translations map[string]WeddingTranslation `bson:"translations,omitempty" json:"translations,omitempty" validate:"omitempty,max=10,dive"`
+
 	// Status
 	Status      string     `bson:"status" json:"status" validate:"oneof=draft published expired archived"`
 	PublishedAt *time.Time `bson:"published_at,omitempty" json:"published_at,omitempty"`
 	ExpiresAt   *time.Time `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
 
+	// Moderation is set by admins and preserved across owner updates
+	Moderation *WeddingModeration `bson:"moderation,omitempty" json:"moderation,omitempty"`
+
 	// Counts (denormalized for performance)
 	RSVPCount      int `bson:"rsvp_count" json:"rsvp_count"`
 	GuestCount     int `bson:"guest_count" json:"guest_count"`
@@ -158,6 +246,9 @@ func (w *Wedding) IsRSVPOpen() bool {
 }
 
 func (w *Wedding) IsAccessible() bool {
+	if w.IsTakenDown() {
+		return false
+	}
 	if w.IsPublic {
 		return w.Status == string(WeddingStatusPublished)
 	}
@@ -166,6 +257,25 @@ func (w *Wedding) IsAccessible() bool {
 	return w.Status == string(WeddingStatusPublished)
 }
 
+// Session returns the event session with the given ID
+func (w *Wedding) Session(id string) (*EventSession, bool) {
+	for i := range w.Sessions {
+		if w.Sessions[i].ID == id {
+			return &w.Sessions[i], true
+		}
+	}
+	return nil, false
+}
+
+// EventSessions returns the events guests attend: the sessions of a
+// multi-session wedding, or its main event
+func (w *Wedding) EventSessions() []EventSession {
+	if len(w.Sessions) > 0 {
+		return w.Sessions
+	}
+	return []EventSession{{EventDetails: w.Event}}
+}
+
 func (w *Wedding) IsExpired() bool {
 	if w.ExpiresAt == nil {
 		return false
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// WeddingRevision keeps the content of a wedding as it was before one of its
+// updates, so that the update can be rolled back. Revisions are numbered from 1
+// per wedding, oldest first.
+type WeddingRevision struct {
+	ID        ID  `bson:"_id,omitempty" json:"id"`
+	WeddingID ID  `bson:"wedding_id" json:"wedding_id"`
+	Number    int `bson:"number" json:"number"`
+	// ChangedFields are the JSON paths, such as couple.story, the update changed
+	ChangedFields []string `bson:"changed_fields" json:"changed_fields"`
+	// RestoredFrom is the revision the update restored, if it was a rollback
+	RestoredFrom *int `bson:"restored_from,omitempty" json:"restored_from,omitempty"`
+	// Snapshot is the wedding before the update; listings leave it out
+	Snapshot  *Wedding  `bson:"snapshot,omitempty" json:"snapshot,omitempty"`
+	CreatedBy ID        `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
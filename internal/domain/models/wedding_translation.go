@@ -0,0 +1,114 @@
+package models
+
+import (
+	"sort"
+)
+
+// DefaultWeddingLanguage is the language of a wedding's own content when its
+// owner did not set one
+const DefaultWeddingLanguage = "en"
+
+// EventTranslation is the text of an event in another language
+type EventTranslation struct {
+	Title          string `bson:"title,omitempty" json:"title,omitempty" validate:"omitempty,max=100"`
+	VenueName      string `bson:"venue_name,omitempty" json:"venue_name,omitempty" validate:"omitempty,max=200"`
+	VenueAddress   string `bson:"venue_address,omitempty" json:"venue_address,omitempty" validate:"omitempty,max=500"`
+	DressCode      string `bson:"dress_code,omitempty" json:"dress_code,omitempty"`
+	AdditionalInfo string `bson:"additional_info,omitempty" json:"additional_info,omitempty"`
+}
+
+// WeddingTranslation is the text of a wedding in another language than its
+// own. Fields left empty show the wedding's own text.
+type WeddingTranslation struct {
+	Title        string           `bson:"title,omitempty" json:"title,omitempty" validate:"omitempty,max=100"`
+	Story        string           `bson:"story,omitempty" json:"story,omitempty" validate:"omitempty,max=2000"`
+	ShareMessage string           `bson:"share_message,omitempty" json:"share_message,omitempty" validate:"omitempty,max=280"`
+	Event        EventTranslation `bson:"event,omitempty" json:"event,omitempty"`
+	// Sessions translates the sessions of a multi-session wedding, by session ID
+	Sessions map[string]EventTranslation `bson:"sessions,omitempty" json:"sessions,omitempty" validate:"omitempty,dive"`
+	// Questions translates the custom RSVP questions, by question ID. Their
+	// options stay as they are, since answers are checked against them.
+	Questions map[string]string `bson:"questions,omitempty" json:"questions,omitempty"`
+	// Labels translates the wedding's section labels, by label key
+	Labels map[string]string `bson:"labels,omitempty" json:"labels,omitempty"`
+}
+
+// ContentLanguage returns the language of the wedding's own content
+func (w *Wedding) ContentLanguage() string {
+	if w.Language == "" {
+		return DefaultWeddingLanguage
+	}
+	return w.Language
+}
+
+// Languages lists the languages the wedding can be shown in, its own first
+func (w *Wedding) Languages() []string {
+	languages := []string{w.ContentLanguage()}
+	for lang := range w.Translations {
+		if lang != languages[0] {
+			languages = append(languages, lang)
+		}
+	}
+	// Keep the order stable after the wedding's own language
+	sort.Strings(languages[1:])
+	return languages
+}
+
+// Localized returns the wedding with its text in the language. The wedding is
+// returned as is for its own language or one it has no translation for;
+// otherwise a copy has the translated text, and its own text wherever the
+// translation leaves a field empty.
+func (w *Wedding) Localized(lang string) *Wedding {
+	translation, ok := w.Translations[lang]
+	if !ok || lang == w.ContentLanguage() {
+		return w
+	}
+
+	localized := *w
+	localized.Language = lang
+	localized.Title = translated(w.Title, translation.Title)
+	localized.ShareMessage = translated(w.ShareMessage, translation.ShareMessage)
+	localized.Couple.Story = translated(w.Couple.Story, translation.Story)
+	localized.Event = translation.Event.apply(w.Event)
+
+	localized.Sessions = make([]EventSession, len(w.Sessions))
+	for i, session := range w.Sessions {
+		localized.Sessions[i] = session
+		localized.Sessions[i].EventDetails = translation.Sessions[session.ID].apply(session.EventDetails)
+	}
+
+	localized.RSVP.CustomQuestions = make([]CustomQuestion, len(w.RSVP.CustomQuestions))
+	for i, question := range w.RSVP.CustomQuestions {
+		question.Question = translated(question.Question, translation.Questions[question.ID])
+		localized.RSVP.CustomQuestions[i] = question
+	}
+
+	localized.Labels = make(map[string]string, len(w.Labels)+len(translation.Labels))
+	for key, label := range w.Labels {
+		localized.Labels[key] = label
+	}
+	for key, label := range translation.Labels {
+		if label != "" {
+			localized.Labels[key] = label
+		}
+	}
+	return &localized
+}
+
+// apply returns the event with its text translated
+func (t EventTranslation) apply(event EventDetails) EventDetails {
+	event.Title = translated(event.Title, t.Title)
+	event.VenueName = translated(event.VenueName, t.VenueName)
+	event.VenueAddress = translated(event.VenueAddress, t.VenueAddress)
+	event.DressCode = translated(event.DressCode, t.DressCode)
+	event.AdditionalInfo = translated(event.AdditionalInfo, t.AdditionalInfo)
+	return event
+}
+
+// translated returns the translation of a text, or the text itself without one
+func translated(text, translation string) string {
+	if translation == "" {
+		return text
+	}
+	return translation
+}
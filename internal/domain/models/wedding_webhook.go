@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+)
+
+// WeddingEventType represents events of a wedding delivered to its owner's webhooks
+type WeddingEventType string
+
+const (
+	WeddingEventRSVPCreated      WeddingEventType = "rsvp.created"
+	WeddingEventGuestCheckedIn   WeddingEventType = "guest.checked_in"
+	WeddingEventWeddingPublished WeddingEventType = "wedding.published"
+	WeddingEventMediaUploaded    WeddingEventType = "media.uploaded"
+	WeddingEventAnalyticsAnomaly WeddingEventType = "analytics.anomaly"
+)
+
+// WeddingEventTypes lists every event a wedding webhook can subscribe to
+var WeddingEventTypes = []WeddingEventType{
+	WeddingEventRSVPCreated,
+	WeddingEventGuestCheckedIn,
+	WeddingEventWeddingPublished,
+	WeddingEventMediaUploaded,
+	WeddingEventAnalyticsAnomaly,
+}
+
+// IsValidWeddingEventType checks whether the event type is supported
+func IsValidWeddingEventType(eventType string) bool {
+	for _, t := range WeddingEventTypes {
+		if string(t) == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WeddingWebhook is a wedding owner's subscription to the events of a wedding
+type WeddingWebhook struct {
+	ID        ID        `bson:"_id,omitempty" json:"id"`
+	WeddingID ID        `bson:"wedding_id" json:"wedding_id"`
+	URL       string    `bson:"url" json:"url"`
+	Secret    string    `bson:"secret" json:"-"`      // Used for HMAC signing, never exposed
+	Events    []string  `bson:"events" json:"events"` // Empty subscribes to all events
+	Active    bool      `bson:"active" json:"active"`
+	CreatedBy ID        `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Subscribes checks whether the webhook wants the given event type
+func (w *WeddingWebhook) Subscribes(eventType WeddingEventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// WeddingEvent is the body posted to a wedding webhook. Its ID stays the same
+// across retries, so receivers can drop duplicates.
+type WeddingEvent struct {
+	ID         ID                     `bson:"id" json:"id"`
+	Type       WeddingEventType       `bson:"type" json:"type"`
+	WeddingID  ID                     `bson:"wedding_id" json:"wedding_id"`
+	Data       map[string]interface{} `bson:"data,omitempty" json:"data,omitempty"`
+	OccurredAt time.Time              `bson:"occurred_at" json:"occurred_at"`
+}
+
+// WeddingWebhookDelivery records a single delivery attempt of an event to a wedding webhook
+type WeddingWebhookDelivery struct {
+	ID          ID               `bson:"_id,omitempty" json:"id"`
+	WebhookID   ID               `bson:"webhook_id" json:"webhook_id"`
+	EventID     ID               `bson:"event_id" json:"event_id"`
+	EventType   WeddingEventType `bson:"event_type" json:"event_type"`
+	Attempt     int              `bson:"attempt" json:"attempt"`
+	StatusCode  int              `bson:"status_code" json:"status_code"`
+	Success     bool             `bson:"success" json:"success"`
+	Error       string           `bson:"error,omitempty" json:"error,omitempty"`
+	DurationMs  int64            `bson:"duration_ms" json:"duration_ms"`
+	AttemptedAt time.Time        `bson:"attempted_at" json:"attempted_at"`
+}
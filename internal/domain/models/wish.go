@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// WishStatus represents the moderation state of a guestbook wish
+type WishStatus string
+
+const (
+	WishPending  WishStatus = "pending"
+	WishApproved WishStatus = "approved"
+	WishHidden   WishStatus = "hidden"
+)
+
+// WishSettings controls whether guests may leave wishes on a wedding's guestbook
+type WishSettings struct {
+	Enabled bool `bson:"enabled" json:"enabled"`
+	// RequireApproval keeps wishes off the public wall until the owner approves them
+	RequireApproval bool `bson:"require_approval" json:"require_approval"`
+}
+
+// Wish is a congratulatory message a guest left on a wedding's guestbook
+type Wish struct {
+	ID        ID     `bson:"_id,omitempty" json:"id"`
+	WeddingID ID     `bson:"wedding_id" json:"wedding_id"`
+	GuestName string `bson:"guest_name" json:"guest_name"`
+	Message   string `bson:"message" json:"message"`
+	// Filtered is set when profanity was masked out of the name or message
+	Filtered    bool       `bson:"filtered,omitempty" json:"filtered,omitempty"`
+	Status      WishStatus `bson:"status" json:"status"`
+	GuestIP     string     `bson:"guest_ip,omitempty" json:"-"`
+	ModeratedBy *ID        `bson:"moderated_by,omitempty" json:"moderated_by,omitempty"`
+	ModeratedAt *time.Time `bson:"moderated_at,omitempty" json:"moderated_at,omitempty"`
+	CreatedAt   time.Time  `bson:"created_at" json:"created_at"`
+}
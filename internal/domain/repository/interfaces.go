@@ -1,121 +1,639 @@
+// Package repository defines the storage operations the services depend on.
+// Records are identified by models.ID, which is opaque to callers; each
+// implementation converts it to the identifiers of its database.
 package repository
 
 import (
 	"context"
-	"errors"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"time"
+	"wedding-invitation-backend/internal/domain/errs"
 	"wedding-invitation-backend/internal/domain/models"
 )
 
 var (
-	ErrNotFound = errors.New("document not found")
+	ErrNotFound = errs.NotFound("document not found")
 )
 
 // UserRepository defines database operations for users
 type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
-	GetByID(ctx context.Context, id primitive.ObjectID) (*models.User, error)
+	GetByID(ctx context.Context, id models.ID) (*models.User, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	GetByVerificationToken(ctx context.Context, token string) (*models.User, error)
 	GetByResetToken(ctx context.Context, token string) (*models.User, error)
 	Update(ctx context.Context, user *models.User) error
-	Delete(ctx context.Context, id primitive.ObjectID) error
+	Delete(ctx context.Context, id models.ID) error
 	List(ctx context.Context, page, pageSize int, filters UserFilters) ([]*models.User, int64, error)
-	AddWeddingID(ctx context.Context, userID, weddingID primitive.ObjectID) error
-	RemoveWeddingID(ctx context.Context, userID, weddingID primitive.ObjectID) error
-	UpdateLastLogin(ctx context.Context, userID primitive.ObjectID) error
-	SetEmailVerified(ctx context.Context, userID primitive.ObjectID) error
+	AddWeddingID(ctx context.Context, userID, weddingID models.ID) error
+	RemoveWeddingID(ctx context.Context, userID, weddingID models.ID) error
+	UpdateLastLogin(ctx context.Context, userID models.ID) error
+	SetEmailVerified(ctx context.Context, userID models.ID) error
+	GetByBillingCustomerID(ctx context.Context, customerID string) (*models.User, error)
+	// GetByOAuthAccount retrieves the user a social login account is linked to
+	GetByOAuthAccount(ctx context.Context, provider models.AuthProvider, subject string) (*models.User, error)
+	// UpdateSubscription stores the user's subscription unless a newer one (by UpdatedAt) is
+	// stored already, returning ErrNotFound in that case so stale provider events are dropped
+	UpdateSubscription(ctx context.Context, userID models.ID, subscription *models.Subscription) error
+	// RecordFailedLogin counts a wrong password against the user and returns the updated user
+	RecordFailedLogin(ctx context.Context, userID models.ID) (*models.User, error)
+	// LockLogin locks logins until the given time, resetting the failed attempts
+	// and counting the lockout
+	LockLogin(ctx context.Context, userID models.ID, until time.Time, unlockTokenHash string) error
+	// UnlockLogin lifts a lockout and resets the failed attempts
+	UnlockLogin(ctx context.Context, userID models.ID) error
+	// GetByUnlockToken retrieves the locked user an unlock link was sent to
+	GetByUnlockToken(ctx context.Context, tokenHash string) (*models.User, error)
 }
 
 // WeddingRepository defines database operations for weddings
 type WeddingRepository interface {
 	Create(ctx context.Context, wedding *models.Wedding) error
-	GetByID(ctx context.Context, id primitive.ObjectID) (*models.Wedding, error)
+	GetByID(ctx context.Context, id models.ID) (*models.Wedding, error)
+	// GetByIDs retrieves the weddings with the given IDs, in no particular order, leaving out those that do not exist
+	GetByIDs(ctx context.Context, ids []models.ID) ([]*models.Wedding, error)
 	GetBySlug(ctx context.Context, slug string) (*models.Wedding, error)
-	GetByUserID(ctx context.Context, userID primitive.ObjectID, page, pageSize int, filters WeddingFilters) ([]*models.Wedding, int64, error)
+	GetByUserID(ctx context.Context, userID models.ID, page, pageSize int, filters WeddingFilters) ([]*models.Wedding, int64, error)
 	Update(ctx context.Context, wedding *models.Wedding) error
-	Delete(ctx context.Context, id primitive.ObjectID) error
+	Delete(ctx context.Context, id models.ID) error
 	ExistsBySlug(ctx context.Context, slug string) (bool, error)
 	ListPublic(ctx context.Context, page, pageSize int, filters PublicWeddingFilters) ([]*models.Wedding, int64, error)
-	IncrementViewCount(ctx context.Context, id primitive.ObjectID) error
-	UpdateRSVPCount(ctx context.Context, weddingID primitive.ObjectID) error
+	IncrementViewCount(ctx context.Context, id models.ID) error
+	UpdateRSVPCount(ctx context.Context, weddingID models.ID) error
+	// CountByOwner counts the weddings the user owns, leaving out those they collaborate on
+	CountByOwner(ctx context.Context, userID models.ID) (int64, error)
+	CountByTheme(ctx context.Context, themeID string) (int64, error)
+	// List lists all weddings matching the filters, newest first, for admins
+	List(ctx context.Context, page, pageSize int, filters AdminWeddingFilters) ([]*models.Wedding, int64, error)
 }
 
 // RSVPRepository defines database operations for RSVPs
 type RSVPRepository interface {
 	Create(ctx context.Context, rsvp *models.RSVP) error
-	GetByID(ctx context.Context, id primitive.ObjectID) (*models.RSVP, error)
-	GetByEmail(ctx context.Context, weddingID primitive.ObjectID, email string) (*models.RSVP, error)
-	ListByWedding(ctx context.Context, weddingID primitive.ObjectID, page, pageSize int, filters RSVPFilters) ([]*models.RSVP, int64, error)
+	GetByID(ctx context.Context, id models.ID) (*models.RSVP, error)
+	GetByEmail(ctx context.Context, weddingID models.ID, email string) (*models.RSVP, error)
+	ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int, filters RSVPFilters) ([]*models.RSVP, int64, error)
 	Update(ctx context.Context, rsvp *models.RSVP) error
-	Delete(ctx context.Context, id primitive.ObjectID) error
-	GetStatistics(ctx context.Context, weddingID primitive.ObjectID) (*models.RSVPStatistics, error)
-	MarkConfirmationSent(ctx context.Context, id primitive.ObjectID) error
-	GetSubmissionTrend(ctx context.Context, weddingID primitive.ObjectID, days int) ([]models.DailyCount, error)
+	Delete(ctx context.Context, id models.ID) error
+	GetStatistics(ctx context.Context, weddingID models.ID) (*models.RSVPStatistics, error)
+	MarkConfirmationSent(ctx context.Context, id models.ID) error
+	GetSubmissionTrend(ctx context.Context, weddingID models.ID, days int) ([]models.DailyCount, error)
+	// StreamByWedding calls fn for each RSVP of the wedding, newest first, without loading them all into memory.
+	// Iteration stops at the first error returned by fn or when ctx is cancelled.
+	StreamByWedding(ctx context.Context, weddingID models.ID, fn func(*models.RSVP) error) error
+	// CountByIPSince counts the wedding's RSVPs submitted from an IP address since a point in time
+	CountByIPSince(ctx context.Context, weddingID models.ID, ipAddress string, since time.Time) (int64, error)
+	// GetAttendanceTimeline counts the wedding's RSVPs that count towards its statistics by the
+	// UTC day they were submitted and their answer, oldest day first
+	GetAttendanceTimeline(ctx context.Context, weddingID models.ID) ([]models.AttendanceDay, error)
+	// GetMealCounts counts the meal choices of everyone the wedding's counted attending RSVPs
+	// bring, plus ones included, by meal; those without a choice are counted under ""
+	GetMealCounts(ctx context.Context, weddingID models.ID) (map[string]int, error)
 }
 
 // GuestRepository defines database operations for guests (for Phase 3)
 type GuestRepository interface {
 	Create(ctx context.Context, guest *models.Guest) error
 	CreateMany(ctx context.Context, guests []*models.Guest) error
-	GetByID(ctx context.Context, id primitive.ObjectID) (*models.Guest, error)
-	GetByEmail(ctx context.Context, weddingID primitive.ObjectID, email string) (*models.Guest, error)
-	ListByWedding(ctx context.Context, weddingID primitive.ObjectID, page, pageSize int, filters GuestFilters) ([]*models.Guest, int64, error)
+	GetByID(ctx context.Context, id models.ID) (*models.Guest, error)
+	GetByEmail(ctx context.Context, weddingID models.ID, email string) (*models.Guest, error)
+	// GetByPhone retrieves the first guest of the wedding whose phone number has the key,
+	// see models.GuestPhoneKey
+	GetByPhone(ctx context.Context, weddingID models.ID, phoneKey string) (*models.Guest, error)
+	ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int, filters GuestFilters) ([]*models.Guest, int64, error)
 	Update(ctx context.Context, guest *models.Guest) error
-	Delete(ctx context.Context, id primitive.ObjectID) error
+	Delete(ctx context.Context, id models.ID) error
 	ImportBatch(ctx context.Context, guests []*models.Guest, batchID string) error
-	GetByImportBatch(ctx context.Context, weddingID primitive.ObjectID, batchID string) ([]*models.Guest, error)
+	GetByImportBatch(ctx context.Context, weddingID models.ID, batchID string) ([]*models.Guest, error)
+	// Search returns the guests of the wedding with a search key starting with each of prefixes, see
+	// models.GuestSearchKeys. Guests saved before search keys were kept are returned too, for callers to check.
+	Search(ctx context.Context, weddingID models.ID, prefixes []string) ([]*models.Guest, error)
+	// StreamByWedding calls fn for each guest of the wedding matching filters, oldest first, without loading them all into memory.
+	// Iteration stops at the first error returned by fn or when ctx is cancelled.
+	StreamByWedding(ctx context.Context, weddingID models.ID, filters GuestFilters, fn func(*models.Guest) error) error
+	// UpdateInvitationStatus records the outcome of sending the guest's invitation. A non-nil
+	// sentAt is stored as the send time; reason is the delivery error, empty on success.
+	UpdateInvitationStatus(ctx context.Context, id models.ID, status string, sentAt *time.Time, reason string) error
+	// MarkInvitationSent records a sent invitation with its channel and the provider's message ID,
+	// empty when the provider reports no delivery.
+	MarkInvitationSent(ctx context.Context, id models.ID, channel, messageID string, sentAt time.Time) error
+	// UpdateInvitationStatusByMessage moves the invitation sent as messageID to status. Only
+	// invitations in one of the from statuses match, so late or repeated delivery reports cannot
+	// move an invitation backwards; ErrNotFound is returned when none matched.
+	UpdateInvitationStatusByMessage(ctx context.Context, messageID, status string, from []string, reason string) error
+	// CheckIn records the guest's arrival. It only matches guests who are not checked in yet
+	// and returns ErrNotFound otherwise, so concurrent check-ins of one guest cannot both succeed.
+	CheckIn(ctx context.Context, id models.ID, checkIn models.GuestCheckIn) error
+	// CheckInStats counts the guests of the wedding and their arrivals
+	CheckInStats(ctx context.Context, weddingID models.ID) (*models.CheckInStats, error)
+	// UpdateMany applies patch to the guests of the wedding with the given IDs in one write
+	// and returns how many matched
+	UpdateMany(ctx context.Context, weddingID models.ID, ids []models.ID, patch models.GuestPatch) (int64, error)
+	// DeleteMany deletes the guests of the wedding with the given IDs in one write and
+	// returns how many were deleted
+	DeleteMany(ctx context.Context, weddingID models.ID, ids []models.ID) (int64, error)
+	// SetGroup moves the guests of the wedding with the given IDs into the group, or out
+	// of any group when groupID is nil, and returns how many matched
+	SetGroup(ctx context.Context, weddingID models.ID, ids []models.ID, groupID *models.ID) (int64, error)
+	// AttendanceBreakdown counts the answers of the wedding's guests grouped by a guest field,
+	// one of the models.GuestBreakdown fields, largest group first
+	AttendanceBreakdown(ctx context.Context, weddingID models.ID, field string) ([]models.AttendanceBreakdown, error)
+}
+
+// GuestGroupRepository defines database operations for the households guests are grouped into
+type GuestGroupRepository interface {
+	Create(ctx context.Context, group *models.GuestGroup) error
+	GetByID(ctx context.Context, id models.ID) (*models.GuestGroup, error)
+	// ListByWedding lists the groups of a wedding by name
+	ListByWedding(ctx context.Context, weddingID models.ID) ([]*models.GuestGroup, error)
+	Update(ctx context.Context, group *models.GuestGroup) error
+	Delete(ctx context.Context, id models.ID) error
+}
+
+// SlugRedirectRepository defines database operations for the old slugs of renamed weddings
+type SlugRedirectRepository interface {
+	// Save points the slug at its wedding, replacing an earlier redirect of the slug
+	Save(ctx context.Context, redirect *models.SlugRedirect) error
+	GetBySlug(ctx context.Context, slug string) (*models.SlugRedirect, error)
+	Delete(ctx context.Context, slug string) error
+	DeleteByWedding(ctx context.Context, weddingID models.ID) error
+}
+
+// WeddingRevisionRepository defines database operations for the revision history of weddings
+type WeddingRevisionRepository interface {
+	// Create numbers the revision after the latest one of its wedding and stores it
+	Create(ctx context.Context, revision *models.WeddingRevision) error
+	GetByNumber(ctx context.Context, weddingID models.ID, number int) (*models.WeddingRevision, error)
+	// ListByWedding lists the revisions of a wedding without their snapshots, newest first
+	ListByWedding(ctx context.Context, weddingID models.ID, limit int) ([]*models.WeddingRevision, error)
+	// DeleteBefore removes the revisions of a wedding numbered below number
+	DeleteBefore(ctx context.Context, weddingID models.ID, number int) error
+}
+
+// PreviewLinkRepository defines database operations for the links sharing draft weddings
+type PreviewLinkRepository interface {
+	Create(ctx context.Context, link *models.PreviewLink) error
+	GetByID(ctx context.Context, id models.ID) (*models.PreviewLink, error)
+	// ListByWedding lists the preview links of a wedding, newest first
+	ListByWedding(ctx context.Context, weddingID models.ID) ([]*models.PreviewLink, error)
+	Update(ctx context.Context, link *models.PreviewLink) error
+	// RecordUse counts a visit through the link at the given time
+	RecordUse(ctx context.Context, id models.ID, at time.Time) error
 }
 
 // MediaRepository defines database operations for media files (for Phase 2)
 type MediaRepository interface {
 	Create(ctx context.Context, media *models.Media) error
-	GetByID(ctx context.Context, id primitive.ObjectID) (*models.Media, error)
+	GetByID(ctx context.Context, id models.ID) (*models.Media, error)
 	GetByStorageKey(ctx context.Context, key string) (*models.Media, error)
 	List(ctx context.Context, filter MediaFilter, opts ListOptions) ([]*models.Media, int64, error)
 	Update(ctx context.Context, media *models.Media) error
-	Delete(ctx context.Context, id primitive.ObjectID) error
-	SoftDelete(ctx context.Context, id primitive.ObjectID) error
+	Delete(ctx context.Context, id models.ID) error
+	SoftDelete(ctx context.Context, id models.ID) error
 	GetOrphaned(ctx context.Context, before time.Time) ([]*models.Media, error)
-	GetByCreatedBy(ctx context.Context, userID primitive.ObjectID, opts ListOptions) ([]*models.Media, int64, error)
+	GetByCreatedBy(ctx context.Context, userID models.ID, opts ListOptions) ([]*models.Media, int64, error)
+	// TotalSizeByCreatedBy sums the size of the media the user stores, leaving out deleted media
+	TotalSizeByCreatedBy(ctx context.Context, userID models.ID) (int64, error)
+	// ListAllByCreatedBy lists every media record of the user, deleted media included
+	ListAllByCreatedBy(ctx context.Context, userID models.ID) ([]*models.Media, error)
+	// GetByContentHash finds the latest media of the user with the content hash
+	// whose stored object can be reused, neither deleted, failed nor rejected
+	GetByContentHash(ctx context.Context, userID models.ID, hash string) (*models.Media, error)
+	// CountByStorageKey counts the media records, deleted ones included, that
+	// reference a stored object
+	CountByStorageKey(ctx context.Context, key string) (int64, error)
 }
 
 // AnalyticsRepository defines database operations for analytics (for Phase 4)
 type AnalyticsRepository interface {
 	// Page Views
 	TrackPageView(ctx context.Context, pageView *models.PageView) error
-	GetPageViews(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error)
+	// TrackPageViews records the page views of a batch in one write
+	TrackPageViews(ctx context.Context, pageViews []*models.PageView) error
+	TrackPageDuration(ctx context.Context, weddingID models.ID, sessionID, page string, duration int64) error
+	GetPageViews(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error)
 
 	// RSVP Analytics
 	TrackRSVPEvent(ctx context.Context, event *models.RSVPAnalytics) error
-	GetRSVPAnalytics(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error)
+	GetRSVPAnalytics(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error)
 
 	// Conversion Events
 	TrackConversion(ctx context.Context, event *models.ConversionEvent) error
-	GetConversions(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error)
+	TrackConversions(ctx context.Context, events []*models.ConversionEvent) error
+	GetConversions(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error)
+
+	// Request Tracing
+	GetPageViewsByRequestID(ctx context.Context, requestID string) ([]*models.PageView, error)
+	GetConversionsByRequestID(ctx context.Context, requestID string) ([]*models.ConversionEvent, error)
 
 	// Aggregated Analytics
-	GetWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) (*models.WeddingAnalytics, error)
-	UpdateWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) error
-	RefreshWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) error
+	GetWeddingAnalytics(ctx context.Context, weddingID models.ID) (*models.WeddingAnalytics, error)
+	UpdateWeddingAnalytics(ctx context.Context, weddingID models.ID) error
+	RefreshWeddingAnalytics(ctx context.Context, weddingID models.ID) error
+	ListWeddingsToReconcile(ctx context.Context, after models.ID, limit int) ([]models.ID, error)
+	// ListWeddingAnalytics returns the counters of the weddings that tracked events
+	ListWeddingAnalytics(ctx context.Context, weddingIDs []models.ID) ([]*models.WeddingAnalytics, error)
 
 	// System Analytics
 	GetSystemAnalytics(ctx context.Context) (*models.SystemAnalytics, error)
 	UpdateSystemAnalytics(ctx context.Context) error
 	RefreshSystemAnalytics(ctx context.Context) error
+	// GetAnalyticsBenchmarks returns ErrNotFound until the benchmarks are first computed
+	GetAnalyticsBenchmarks(ctx context.Context) (*models.AnalyticsBenchmarks, error)
+	UpdateAnalyticsBenchmarks(ctx context.Context, minPageViews int64) (*models.AnalyticsBenchmarks, error)
 
 	// Reports
-	GetAnalyticsSummary(ctx context.Context, weddingID primitive.ObjectID, period string) (*models.AnalyticsSummary, error)
-	GetPopularPages(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.PageStats, error)
-	GetTrafficSources(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.TrafficSourceStats, error)
-	GetDailyMetrics(ctx context.Context, weddingID primitive.ObjectID, startDate, endDate time.Time) ([]models.DailyMetrics, error)
+	GetAnalyticsSummary(ctx context.Context, weddingID models.ID, period string) (*models.AnalyticsSummary, error)
+	GetPopularPages(ctx context.Context, weddingID models.ID, limit int) ([]models.PageStats, error)
+	GetTrafficSources(ctx context.Context, weddingID models.ID, limit int) ([]models.TrafficSourceStats, error)
+	GetGeoBreakdown(ctx context.Context, weddingID models.ID, limit int) ([]models.CountryStats, error)
+	GetDailyMetrics(ctx context.Context, weddingID models.ID, startDate, endDate time.Time) ([]models.DailyMetrics, error)
+	// CountHourlyActivity counts the page views and RSVPs of every wedding in
+	// each of the hours starting at the given times; hours without events are left out
+	CountHourlyActivity(ctx context.Context, hours []time.Time) ([]models.HourlyActivity, error)
+	// GetRSVPFunnelSessions returns what each session tracked in [from, to) did in the RSVP flow
+	GetRSVPFunnelSessions(ctx context.Context, weddingID models.ID, from, to time.Time) ([]models.RSVPFunnelSession, error)
+
+	// Raw event exports; fn is called for each event tracked in [from, to), oldest first
+	StreamPageViews(ctx context.Context, weddingID models.ID, from, to time.Time, fn func(*models.PageView) error) error
+	StreamRSVPEvents(ctx context.Context, weddingID models.ID, from, to time.Time, fn func(*models.RSVPAnalytics) error) error
+	StreamConversions(ctx context.Context, weddingID models.ID, from, to time.Time, fn func(*models.ConversionEvent) error) error
 
 	// Cleanup
 	CleanupOldAnalytics(ctx context.Context, olderThan time.Time) error
 }
 
+// SystemRepository defines database operations for system configuration
+type SystemRepository interface {
+	GetConfig(ctx context.Context) (*models.SystemConfig, error)
+	SaveConfig(ctx context.Context, config *models.SystemConfig) error
+}
+
+// ThemeRepository defines database operations for the theme catalog
+type ThemeRepository interface {
+	Create(ctx context.Context, theme *models.Theme) error
+	GetByID(ctx context.Context, id string) (*models.Theme, error)
+	// List returns the catalog with the default theme first, then by name
+	List(ctx context.Context, activeOnly bool) ([]*models.Theme, error)
+	Update(ctx context.Context, theme *models.Theme) error
+	Delete(ctx context.Context, id string) error
+	// Upsert creates a theme or refreshes an existing one, keeping its creation time
+	Upsert(ctx context.Context, theme *models.Theme) error
+}
+
+// RSVPSubmissionRepository defines the durable queue backing write-behind RSVP submissions
+type RSVPSubmissionRepository interface {
+	Enqueue(ctx context.Context, submission *models.RSVPSubmission) error
+	GetByID(ctx context.Context, id models.ID) (*models.RSVPSubmission, error)
+	// ClaimNext leases the oldest pending submission in a partition, returning ErrNotFound when idle
+	ClaimNext(ctx context.Context, partition int, now time.Time, lease time.Duration) (*models.RSVPSubmission, error)
+	MarkCompleted(ctx context.Context, id, rsvpID models.ID) error
+	// MarkFailed records a permanent failure, dropping the submitted RSVP
+	MarkFailed(ctx context.Context, id models.ID, reason string) error
+	// Release returns a submission to the queue to be claimed again from retryAt
+	Release(ctx context.Context, id models.ID, retryAt time.Time, reason string) error
+}
+
+// JobRepository defines the durable queue of background jobs
+type JobRepository interface {
+	// Enqueue inserts a queued job. A job with a unique key is dropped while another
+	// queued job of the same type has that key.
+	Enqueue(ctx context.Context, job *models.Job) error
+	GetByID(ctx context.Context, id models.ID) (*models.Job, error)
+	// ClaimNext leases the job of one of the given types that is due first, returning ErrNotFound when idle
+	ClaimNext(ctx context.Context, types []string, now time.Time, lease time.Duration) (*models.Job, error)
+	MarkCompleted(ctx context.Context, id models.ID) error
+	MarkFailed(ctx context.Context, id models.ID, reason string) error
+	// Retry returns a job to the queue to run again at runAt
+	Retry(ctx context.Context, id models.ID, runAt time.Time, reason string) error
+}
+
+// ExportJobRepository defines database operations for the export job history
+type ExportJobRepository interface {
+	Create(ctx context.Context, job *models.ExportJob) error
+	GetByID(ctx context.Context, id models.ID) (*models.ExportJob, error)
+	// SetFile records where the file of an export generated in the background is stored
+	SetFile(ctx context.Context, id models.ID, fileKey string) error
+	// Finish records the final status and record count of a running job
+	Finish(ctx context.Context, id models.ID, status models.ExportJobStatus, records int, reason string) error
+	ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int) ([]*models.ExportJob, int64, error)
+}
+
+// MetricsWebhookRepository defines database operations for scheduled CRM metrics webhooks
+type MetricsWebhookRepository interface {
+	Create(ctx context.Context, webhook *models.MetricsWebhook) error
+	GetByID(ctx context.Context, id models.ID) (*models.MetricsWebhook, error)
+	ListByUser(ctx context.Context, userID models.ID) ([]*models.MetricsWebhook, error)
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*models.MetricsWebhook, error)
+	Update(ctx context.Context, webhook *models.MetricsWebhook) error
+	Delete(ctx context.Context, id models.ID) error
+	MarkDelivered(ctx context.Context, id models.ID, deliveredAt, nextDeliveryAt time.Time) error
+	CreateDelivery(ctx context.Context, delivery *models.MetricsWebhookDelivery) error
+	ListDeliveries(ctx context.Context, webhookID models.ID, page, pageSize int) ([]*models.MetricsWebhookDelivery, int64, error)
+}
+
+// TenantWebhookRepository defines database operations for tenant lifecycle webhooks and their outbox
+type TenantWebhookRepository interface {
+	// Subscriptions
+	CreateWebhook(ctx context.Context, webhook *models.TenantWebhook) error
+	GetWebhook(ctx context.Context, id models.ID) (*models.TenantWebhook, error)
+	ListWebhooks(ctx context.Context, tenantID string) ([]*models.TenantWebhook, error)
+	UpdateWebhook(ctx context.Context, webhook *models.TenantWebhook) error
+	DeleteWebhook(ctx context.Context, id models.ID) error
+
+	// Signing keys
+	GetSigningKey(ctx context.Context, tenantID string) (*models.TenantSigningKey, error)
+	SaveSigningKey(ctx context.Context, key *models.TenantSigningKey) error
+
+	// Outbox
+	AppendEvent(ctx context.Context, event *models.LifecycleEvent) error
+	ListPendingEvents(ctx context.Context, limit int) ([]*models.LifecycleEvent, error)
+	MarkEventDispatched(ctx context.Context, id models.ID, dispatchedAt time.Time) error
+	ListEventsSince(ctx context.Context, tenantID string, since time.Time, limit int) ([]*models.LifecycleEvent, error)
+
+	// Deliveries
+	CreateDelivery(ctx context.Context, delivery *models.TenantWebhookDelivery) error
+	ListDeliveries(ctx context.Context, webhookID models.ID, page, pageSize int) ([]*models.TenantWebhookDelivery, int64, error)
+}
+
+// OrganizationRepository defines database operations for white-label organizations
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *models.Organization) error
+	GetByID(ctx context.Context, id string) (*models.Organization, error)
+	List(ctx context.Context, page, pageSize int) ([]*models.Organization, int64, error)
+	Update(ctx context.Context, org *models.Organization) error
+	Delete(ctx context.Context, id string) error
+}
+
+// AnalyticsReportRepository defines database operations for emailed analytics digest settings
+type AnalyticsReportRepository interface {
+	GetByWedding(ctx context.Context, weddingID models.ID) (*models.AnalyticsReportSettings, error)
+	// Upsert creates or replaces the settings of the wedding
+	Upsert(ctx context.Context, settings *models.AnalyticsReportSettings) error
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*models.AnalyticsReportSettings, error)
+	// MarkSent records a send attempt, with reason empty on success, and schedules the next one
+	MarkSent(ctx context.Context, id models.ID, sentAt, nextSendAt time.Time, reason string) error
+}
+
+// AnalyticsAnomalyRepository defines database operations for the anomalies found in wedding analytics
+type AnalyticsAnomalyRepository interface {
+	Create(ctx context.Context, anomaly *models.AnalyticsAnomaly) error
+	// GetLatest returns the wedding's latest anomaly of a metric and kind, or ErrNotFound
+	GetLatest(ctx context.Context, weddingID models.ID, metric models.AnomalyMetric, kind models.AnomalyKind) (*models.AnalyticsAnomaly, error)
+	// ListByWedding lists the anomalies of a wedding, latest hour first
+	ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int) ([]*models.AnalyticsAnomaly, int64, error)
+}
+
+// ReminderRepository defines database operations for RSVP reminder campaigns and their deliveries
+type ReminderRepository interface {
+	CreateCampaign(ctx context.Context, campaign *models.ReminderCampaign) error
+	GetCampaign(ctx context.Context, id models.ID) (*models.ReminderCampaign, error)
+	ListCampaigns(ctx context.Context, weddingID models.ID) ([]*models.ReminderCampaign, error)
+	// CancelCampaign cancels a campaign that has not started, returning ErrNotFound otherwise
+	CancelCampaign(ctx context.Context, id models.ID) error
+	// ClaimDueCampaign leases the earliest due campaign, returning ErrNotFound when none is due.
+	// Campaigns whose lease expired during dispatch (e.g. the worker crashed) are reclaimed.
+	ClaimDueCampaign(ctx context.Context, now time.Time, lease time.Duration) (*models.ReminderCampaign, error)
+	// FinishCampaign stores the dispatch counts of a campaign and marks it sent
+	FinishCampaign(ctx context.Context, campaign *models.ReminderCampaign) error
+
+	// Deliveries
+	CreateDelivery(ctx context.Context, delivery *models.ReminderDelivery) error
+	// HasDelivery reports whether the campaign already reached the guest
+	HasDelivery(ctx context.Context, campaignID, guestID models.ID) (bool, error)
+	MarkDeliveryOpened(ctx context.Context, id models.ID, openedAt time.Time) error
+	// MarkResponded attributes an RSVP received at respondedAt to the reminders sent before it
+	// to the guest with the email or phone, returning how many were attributed
+	MarkResponded(ctx context.Context, weddingID models.ID, email, phone string, respondedAt time.Time) (int64, error)
+	DeliveryStats(ctx context.Context, campaignID models.ID) (*models.ReminderDeliveryStats, error)
+}
+
+// GuestPhotoRepository defines database operations for photos guests add to wedding galleries
+type GuestPhotoRepository interface {
+	Create(ctx context.Context, photo *models.GuestPhoto) error
+	GetByID(ctx context.Context, id models.ID) (*models.GuestPhoto, error)
+	// Moderate records the owner's decision on a pending photo, returning ErrNotFound
+	// when the photo is not pending
+	Moderate(ctx context.Context, id models.ID, status models.GuestPhotoStatus, moderatedBy models.ID, moderatedAt time.Time) error
+	// SetThumbnails records the thumbnails rendered after the photo was uploaded
+	SetThumbnails(ctx context.Context, id models.ID, thumbnails map[string]string) error
+	// ListByWedding lists the photos of a wedding in a status, oldest first
+	ListByWedding(ctx context.Context, weddingID models.ID, status models.GuestPhotoStatus, page, pageSize int) ([]*models.GuestPhoto, int64, error)
+}
+
+// RegistryRepository defines database operations for wedding gift registries and the gifts guests pledge
+type RegistryRepository interface {
+	CreateItem(ctx context.Context, item *models.RegistryItem) error
+	GetItem(ctx context.Context, id models.ID) (*models.RegistryItem, error)
+	// ListItems lists the items of a wedding's registry in display order
+	ListItems(ctx context.Context, weddingID models.ID) ([]*models.RegistryItem, error)
+	UpdateItem(ctx context.Context, item *models.RegistryItem) error
+	DeleteItem(ctx context.Context, id models.ID) error
+
+	CreatePledge(ctx context.Context, pledge *models.GiftPledge) error
+	GetPledge(ctx context.Context, id models.ID) (*models.GiftPledge, error)
+	// ListPledges lists a wedding's pledges, newest first; an empty status lists all of them
+	ListPledges(ctx context.Context, weddingID models.ID, status models.GiftPledgeStatus, page, pageSize int) ([]*models.GiftPledge, int64, error)
+	UpdatePledgeStatus(ctx context.Context, id models.ID, status models.GiftPledgeStatus, thankedAt *time.Time) error
+}
+
+// VendorRepository defines database operations for the vendors of weddings
+type VendorRepository interface {
+	Create(ctx context.Context, vendor *models.Vendor) error
+	GetByID(ctx context.Context, id models.ID) (*models.Vendor, error)
+	// ListByWedding lists the vendors of a wedding by name
+	ListByWedding(ctx context.Context, weddingID models.ID, filters VendorFilters) ([]*models.Vendor, error)
+	CountByWedding(ctx context.Context, weddingID models.ID) (int64, error)
+	Update(ctx context.Context, vendor *models.Vendor) error
+	Delete(ctx context.Context, id models.ID) error
+}
+
+// PushSubscriptionRepository defines database operations for Web Push subscriptions
+type PushSubscriptionRepository interface {
+	// Save stores a subscription by its endpoint, replacing the keys and owner of
+	// an earlier subscription of the same browser
+	Save(ctx context.Context, subscription *models.PushSubscription) error
+	GetByID(ctx context.Context, id models.ID) (*models.PushSubscription, error)
+	ListByUser(ctx context.Context, userID models.ID) ([]*models.PushSubscription, error)
+	MarkPushed(ctx context.Context, id models.ID, pushedAt time.Time) error
+	Delete(ctx context.Context, id models.ID) error
+}
+
+// BudgetRepository defines database operations for wedding budgets and their vendor payments
+type BudgetRepository interface {
+	CreateCategory(ctx context.Context, category *models.BudgetCategory) error
+	GetCategory(ctx context.Context, id models.ID) (*models.BudgetCategory, error)
+	// ListCategories lists the budget categories of a wedding in display order
+	ListCategories(ctx context.Context, weddingID models.ID) ([]*models.BudgetCategory, error)
+	UpdateCategory(ctx context.Context, category *models.BudgetCategory) error
+	DeleteCategory(ctx context.Context, id models.ID) error
+
+	CreatePayment(ctx context.Context, payment *models.BudgetPayment) error
+	GetPayment(ctx context.Context, id models.ID) (*models.BudgetPayment, error)
+	// ListPayments lists the payments of a wedding matching filters, by due date with undated ones last
+	ListPayments(ctx context.Context, weddingID models.ID, filters BudgetPaymentFilters) ([]*models.BudgetPayment, error)
+	UpdatePayment(ctx context.Context, payment *models.BudgetPayment) error
+	DeletePayment(ctx context.Context, id models.ID) error
+	// ClaimDueReminder marks the unpaid payment whose reminder has been due the longest as
+	// reminded and returns it, or ErrNotFound when no reminder is due. Each reminder is
+	// claimed once, so concurrent schedulers cannot both send it.
+	ClaimDueReminder(ctx context.Context, now time.Time) (*models.BudgetPayment, error)
+	// RescheduleReminder makes a claimed reminder due again at remindAt, e.g. after it failed
+	RescheduleReminder(ctx context.Context, id models.ID, remindAt time.Time) error
+}
+
+// WishRepository defines database operations for the wishes guests leave on wedding guestbooks
+type WishRepository interface {
+	Create(ctx context.Context, wish *models.Wish) error
+	GetByID(ctx context.Context, id models.ID) (*models.Wish, error)
+	// SetStatus records the owner's decision on a wish
+	SetStatus(ctx context.Context, id models.ID, status models.WishStatus, moderatedBy models.ID, moderatedAt time.Time) error
+	Delete(ctx context.Context, id models.ID) error
+	// ListByWedding lists the wishes of a wedding, newest first; an empty status lists them all
+	ListByWedding(ctx context.Context, weddingID models.ID, status models.WishStatus, page, pageSize int) ([]*models.Wish, int64, error)
+}
+
+// SongRequestRepository defines database operations for the songs guests request for a wedding's DJ
+type SongRequestRepository interface {
+	// Request stores a song request or, when the wedding already has a request
+	// with the same key, counts it on that one. requester is added to the names
+	// of the request unless empty or maxRequesters are listed already. It returns
+	// the stored request.
+	Request(ctx context.Context, request *models.SongRequest, requester string, maxRequesters int) (*models.SongRequest, error)
+	GetByID(ctx context.Context, id models.ID) (*models.SongRequest, error)
+	// SetStatus records the owner's decision on a request
+	SetStatus(ctx context.Context, id models.ID, status models.SongRequestStatus, moderatedBy models.ID, moderatedAt time.Time) error
+	Delete(ctx context.Context, id models.ID) error
+	// ListByWedding lists the song requests of a wedding, most requested first;
+	// an empty status lists them all
+	ListByWedding(ctx context.Context, weddingID models.ID, status models.SongRequestStatus, page, pageSize int) ([]*models.SongRequest, int64, error)
+}
+
+// WeddingWebhookRepository defines database operations for wedding webhooks and their delivery log
+type WeddingWebhookRepository interface {
+	Create(ctx context.Context, webhook *models.WeddingWebhook) error
+	GetByID(ctx context.Context, id models.ID) (*models.WeddingWebhook, error)
+	ListByWedding(ctx context.Context, weddingID models.ID) ([]*models.WeddingWebhook, error)
+	Update(ctx context.Context, webhook *models.WeddingWebhook) error
+	// Delete removes a webhook and its delivery log
+	Delete(ctx context.Context, id models.ID) error
+	CreateDelivery(ctx context.Context, delivery *models.WeddingWebhookDelivery) error
+	// ListDeliveries lists the delivery attempts of a webhook, newest first
+	ListDeliveries(ctx context.Context, webhookID models.ID, page, pageSize int) ([]*models.WeddingWebhookDelivery, int64, error)
+}
+
+// APIKeyRepository defines database operations for API keys and their daily usage
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	GetByID(ctx context.Context, id models.ID) (*models.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	ListByUser(ctx context.Context, userID models.ID) ([]*models.APIKey, error)
+	Update(ctx context.Context, key *models.APIKey) error
+	// RecordUsage counts a request on the key and in its usage bucket for the day of at
+	RecordUsage(ctx context.Context, keyID models.ID, at time.Time, failed, rateLimited bool) error
+	// ListUsage lists the daily usage of a key from the given date (YYYY-MM-DD), oldest first
+	ListUsage(ctx context.Context, keyID models.ID, from string) ([]*models.APIKeyUsage, error)
+}
+
+// AuditLogRepository defines database operations for the audit log
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry *models.AuditLog) error
+	// List lists the entries matching the filters, newest first
+	List(ctx context.Context, filters AuditLogFilters, page, pageSize int) ([]*models.AuditLog, int64, error)
+}
+
+// RetentionRepository deletes data past its retention period and stores the
+// retention overrides of weddings. Data types are the models.Retention* constants.
+type RetentionRepository interface {
+	// CountExpired counts the data of a type created before the cutoff, of one
+	// wedding or, with a nil weddingID, of all of them
+	CountExpired(ctx context.Context, dataType string, weddingID *models.ID, before time.Time) (int64, error)
+	// DeleteExpired deletes what CountExpired counts and returns how much it deleted
+	DeleteExpired(ctx context.Context, dataType string, weddingID *models.ID, before time.Time) (int64, error)
+	// SyncTTLIndex makes the creation time index of a data type expire the data
+	// after the period, or stop expiring it when the period is 0
+	SyncTTLIndex(ctx context.Context, dataType string, period time.Duration) error
+
+	GetOverride(ctx context.Context, weddingID models.ID) (*models.RetentionOverride, error)
+	ListOverrides(ctx context.Context) ([]*models.RetentionOverride, error)
+	SetOverride(ctx context.Context, override *models.RetentionOverride) error
+	DeleteOverride(ctx context.Context, weddingID models.ID) error
+}
+
+// AbuseReportRepository defines database operations for abuse reports
+type AbuseReportRepository interface {
+	Create(ctx context.Context, report *models.AbuseReport) error
+	GetByID(ctx context.Context, id models.ID) (*models.AbuseReport, error)
+	// List lists the reports matching the filters, newest first
+	List(ctx context.Context, filters AbuseReportFilters, page, pageSize int) ([]*models.AbuseReport, int64, error)
+	Update(ctx context.Context, report *models.AbuseReport) error
+	CountOpenByWedding(ctx context.Context, weddingID models.ID) (int64, error)
+}
+
+// SessionRepository defines database operations for signed-in devices
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.Session) error
+	GetByID(ctx context.Context, id models.ID) (*models.Session, error)
+	// ListActiveByUser lists the user's unexpired sessions, most recently seen first
+	ListActiveByUser(ctx context.Context, userID models.ID, now time.Time) ([]*models.Session, error)
+	// Rotate replaces the session's refresh token and records where it was seen,
+	// returning ErrNotFound unless previousTokenID is the session's current token
+	Rotate(ctx context.Context, session *models.Session, previousTokenID string) error
+	// Delete ends one of the user's sessions, returning ErrNotFound for sessions of other users
+	Delete(ctx context.Context, userID, id models.ID) error
+	// DeleteByUser ends all of the user's sessions except the given one, if any
+	DeleteByUser(ctx context.Context, userID models.ID, except *models.ID) (int64, error)
+}
+
+// EmailSuppressionRepository defines database operations for the addresses email is no longer sent to
+type EmailSuppressionRepository interface {
+	// Upsert suppresses the address, replacing the reason of an earlier suppression
+	Upsert(ctx context.Context, suppression *models.EmailSuppression) error
+	// Exists reports whether the address is suppressed
+	Exists(ctx context.Context, email string) (bool, error)
+	// List lists the suppressions, newest first, optionally only addresses containing search
+	List(ctx context.Context, search string, page, pageSize int) ([]*models.EmailSuppression, int64, error)
+	// Delete lifts the suppression of the address, returning ErrNotFound when there is none
+	Delete(ctx context.Context, email string) error
+}
+
+// AccountDeletionRepository defines database operations for account deletion
+// requests and for erasing the data of deleted accounts
+type AccountDeletionRepository interface {
+	// SetDeletion stores the user's deletion request, replacing an earlier one
+	SetDeletion(ctx context.Context, userID models.ID, deletion *models.AccountDeletion) error
+	// ClearDeletion withdraws the user's deletion request, returning ErrNotFound when there is none
+	ClearDeletion(ctx context.Context, userID models.ID) error
+	// GetByDeletionToken retrieves the user whose deletion request has the token hash
+	GetByDeletionToken(ctx context.Context, tokenHash string) (*models.User, error)
+	// ListDue lists up to limit users whose confirmed deletion was scheduled before the time
+	ListDue(ctx context.Context, before time.Time, limit int) ([]*models.User, error)
+	// OwnedWeddingIDs lists the weddings the user owns
+	OwnedWeddingIDs(ctx context.Context, userID models.ID) ([]models.ID, error)
+	// DeleteWeddingData deletes a wedding with its guests, RSVPs, photos, wishes, registry,
+	// reminders, webhooks, exports and analytics, returning the storage keys of its export files
+	DeleteWeddingData(ctx context.Context, weddingID models.ID) ([]string, error)
+	// AnonymizeContact removes the name, email and phone of the guests, RSVPs and gift
+	// pledges with the email address, and the analytics identifiers of those RSVPs'
+	// visits, returning how many records were anonymized
+	AnonymizeContact(ctx context.Context, email string) (int64, error)
+	// RemoveCollaborator removes the user from the collaborators of every wedding
+	RemoveCollaborator(ctx context.Context, userID models.ID) error
+	// DeleteAccount deletes the user with their sessions, API keys, metrics webhooks, upload
+	// sessions and exports, returning the storage keys of their export files
+	DeleteAccount(ctx context.Context, userID models.ID) ([]string, error)
+}
+
+// UploadSessionRepository defines database operations for resumable upload sessions
+type UploadSessionRepository interface {
+	Create(ctx context.Context, session *models.UploadSession) error
+	GetByID(ctx context.Context, id models.ID) (*models.UploadSession, error)
+	// Advance moves the offset of a session from one value to another and extends
+	// its expiry, returning ErrNotFound when the offset is no longer from
+	Advance(ctx context.Context, id models.ID, from, to int64, expiresAt time.Time) error
+	Delete(ctx context.Context, id models.ID) error
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*models.UploadSession, error)
+}
+
 // Filter types for repository queries
 
 type UserFilters struct {
@@ -133,9 +651,42 @@ type WeddingFilters struct {
 	EventDate     *time.Time `json:"event_date"`
 }
 
+// AdminWeddingFilters narrows the admin listing of all weddings
+type AdminWeddingFilters struct {
+	Status    string     `json:"status"`
+	Search    string     `json:"search"` // Title, slug or couple names
+	OwnerID   *models.ID `json:"owner_id"`
+	Flagged   *bool      `json:"flagged"`
+	TakenDown *bool      `json:"taken_down"`
+}
+
+// AbuseReportFilters narrows the admin listing of abuse reports
+type AbuseReportFilters struct {
+	Status    models.AbuseReportStatus `json:"status"`
+	WeddingID *models.ID               `json:"wedding_id"`
+}
+
+// Sort orders for the public wedding showcase
+const (
+	PublicWeddingSortDate       = "date"        // Closest event date first (default)
+	PublicWeddingSortRecent     = "recent"      // Most recently published first
+	PublicWeddingSortMostViewed = "most_viewed" // Highest view count first
+	PublicWeddingSortRelevance  = "relevance"   // Best text match first; requires Search
+)
+
+// Event date ranges for the public wedding showcase
+const (
+	PublicWeddingsUpcoming = "upcoming"
+	PublicWeddingsPast     = "past"
+)
+
 type PublicWeddingFilters struct {
-	Search    string     `json:"search"`
-	EventDate *time.Time `json:"event_date"`
+	Search    string     `json:"search"`     // Full-text search over couple names and titles
+	EventDate *time.Time `json:"event_date"` // Events on or after this date
+	DateFrom  *time.Time `json:"date_from"`
+	DateTo    *time.Time `json:"date_to"`
+	When      string     `json:"when"` // upcoming or past, relative to now
+	Sort      string     `json:"sort"`
 }
 
 type RSVPFilters struct {
@@ -144,9 +695,25 @@ type RSVPFilters struct {
 	SubmittedAfter  *time.Time `json:"submitted_after"`
 	SubmittedBefore *time.Time `json:"submitted_before"`
 	Source          string     `json:"source"`
+	ReviewStatus    string     `json:"review_status"`
+}
+
+// BudgetPaymentFilters for querying budget payments
+type BudgetPaymentFilters struct {
+	CategoryID *models.ID
+	Status     models.BudgetPaymentStatus
+}
+
+// VendorFilters for querying vendors
+type VendorFilters struct {
+	Category       string
+	ContractStatus models.VendorContractStatus
+	// SharedOnly leaves out the vendors private to the wedding owner
+	SharedOnly bool
 }
 
 type GuestFilters struct {
+	// RSVPStatus "pending" also matches guests whose status was never set
 	RSVPStatus       string `json:"rsvp_status"`
 	Side             string `json:"side"`
 	Relationship     string `json:"relationship"`
@@ -155,6 +722,22 @@ type GuestFilters struct {
 	InvitationStatus string `json:"invitation_status"`
 	InvitedVia       string `json:"invited_via"`
 	AllowPlusOne     *bool  `json:"allow_plus_one"`
+	Tag              string `json:"tag"`
+	ImportBatchID    string `json:"import_batch_id"`
+	// GroupID restricts the guests to the members of a household when set
+	GroupID *models.ID `json:"group_id"`
+	// IDs restricts the guests to those with the given IDs when not empty
+	IDs []models.ID `json:"-"`
+}
+
+type AuditLogFilters struct {
+	WeddingID  *models.ID `json:"wedding_id"`
+	ActorID    *models.ID `json:"actor_id"`
+	Action     string     `json:"action"`
+	TargetType string     `json:"target_type"`
+	TargetID   string     `json:"target_id"`
+	From       *time.Time `json:"from"`
+	To         *time.Time `json:"to"`
 }
 
 type GuestStatistics struct {
@@ -169,17 +752,23 @@ type GuestStatistics struct {
 }
 
 type MediaFilter struct {
-	MimeType      string              `json:"mimeType"`
-	CreatedBy     *primitive.ObjectID `json:"createdBy"`
-	CreatedAfter  *time.Time          `json:"createdAfter"`
-	CreatedBefore *time.Time          `json:"createdBefore"`
-	HasThumbnails bool                `json:"hasThumbnails"`
+	MimeType      string     `json:"mimeType"`
+	CreatedBy     *models.ID `json:"createdBy"`
+	CreatedAfter  *time.Time `json:"createdAfter"`
+	CreatedBefore *time.Time `json:"createdBefore"`
+	HasThumbnails bool       `json:"hasThumbnails"`
 }
 
 type ListOptions struct {
-	Limit  int64  `json:"limit"`
-	Offset int64  `json:"offset"`
-	Sort   bson.D `json:"sort"`
+	Limit  int64       `json:"limit"`
+	Offset int64       `json:"offset"`
+	Sort   []SortField `json:"sort"`
+}
+
+// SortField orders listed records by a field, independent of the database
+type SortField struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc"`
 }
 
 type DateRange struct {
@@ -0,0 +1,22 @@
+package repository
+
+import "context"
+
+type tenantKey struct{}
+
+// WithTenant scopes the repository calls made with the returned context to one
+// organization: users and weddings of other tenants are neither found nor
+// changed, as if they did not exist. Requests of organization members run in
+// their tenant's scope; background jobs and platform admins are unscoped.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	if tenantID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant the context is scoped to, if any
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantKey{}).(string)
+	return tenantID, ok
+}
@@ -0,0 +1,517 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Request is a GraphQL request as POSTed by clients
+type Request struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is the result of a request. Data is nil when the request could not
+// be executed at all.
+type Response struct {
+	Data   interface{} `json:"data"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error is an error of a request, or of the field at Path
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+// Location is where in the query an error occurred
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// resultMap is a JSON object that keeps its keys in the order they were selected
+type resultMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newResultMap(size int) *resultMap {
+	return &resultMap{keys: make([]string, 0, size), values: make(map[string]interface{}, size)}
+}
+
+func (m *resultMap) set(key string, value interface{}) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// MarshalJSON encodes the map with its keys in order
+func (m *resultMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// execution is the state of one request
+type execution struct {
+	schema    *Schema
+	fragments map[string]*Fragment
+	variables map[string]interface{}
+
+	mu     sync.Mutex
+	errors []*Error
+}
+
+// Execute parses and runs the request's query. Errors of single fields are
+// reported alongside the data, with the field resolved to null.
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return requestError(err)
+	}
+
+	operation, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return requestError(err)
+	}
+	if operation.Type != "query" {
+		return requestError(fmt.Errorf("%s operations are not supported", operation.Type))
+	}
+
+	maxDepth := s.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = DefaultMaxDepth
+	}
+	depth, err := selectionDepth(operation.SelectionSet, doc.Fragments, map[string]bool{})
+	if err != nil {
+		return requestError(err)
+	}
+	if depth > maxDepth {
+		return requestError(fmt.Errorf("query depth %d exceeds the maximum of %d", depth, maxDepth))
+	}
+
+	variables, err := coerceVariables(operation, req.Variables)
+	if err != nil {
+		return requestError(err)
+	}
+
+	e := &execution{schema: s, fragments: doc.Fragments, variables: variables}
+	maxAliases := s.MaxAliases
+	if maxAliases == 0 {
+		maxAliases = DefaultMaxAliases
+	}
+	maxComplexity := s.MaxComplexity
+	if maxComplexity == 0 {
+		maxComplexity = DefaultMaxComplexity
+	}
+	aliases := 0
+	complexity := e.complexity(operation.SelectionSet, maxComplexity, &aliases)
+	if aliases > maxAliases {
+		return requestError(fmt.Errorf("query selects %d aliases, more than the maximum of %d", aliases, maxAliases))
+	}
+	if complexity > maxComplexity {
+		return requestError(fmt.Errorf("query complexity exceeds the maximum of %d", maxComplexity))
+	}
+
+	data := e.executeObject(ctx, s.Query, nil, operation.SelectionSet, nil)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+func requestError(err error) *Response {
+	response := &Response{Errors: []*Error{{Message: err.Error()}}}
+	if syntaxErr, ok := err.(*SyntaxError); ok {
+		response.Errors[0].Locations = []Location{{Line: syntaxErr.Line, Column: syntaxErr.Column}}
+	}
+	return response
+}
+
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, fmt.Errorf("operationName is required when the document has several operations")
+		}
+		return doc.Operations[0], nil
+	}
+	for _, operation := range doc.Operations {
+		if operation.Name == name {
+			return operation, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+// selectionDepth returns how many levels of fields the selections nest,
+// rejecting fragments that spread themselves
+func selectionDepth(selections []Selection, fragments map[string]*Fragment, visiting map[string]bool) (int, error) {
+	depth := 0
+	for _, selection := range selections {
+		var d int
+		var err error
+		switch sel := selection.(type) {
+		case *Field:
+			if d, err = selectionDepth(sel.SelectionSet, fragments, visiting); err == nil {
+				d++
+			}
+		case *InlineFragment:
+			d, err = selectionDepth(sel.SelectionSet, fragments, visiting)
+		case *FragmentSpread:
+			fragment, ok := fragments[sel.Name]
+			if !ok {
+				return 0, fmt.Errorf("unknown fragment %q", sel.Name)
+			}
+			if visiting[sel.Name] {
+				return 0, fmt.Errorf("fragment %q spreads itself", sel.Name)
+			}
+			visiting[sel.Name] = true
+			d, err = selectionDepth(fragment.SelectionSet, fragments, visiting)
+			delete(visiting, sel.Name)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if d > depth {
+			depth = d
+		}
+	}
+	return depth, nil
+}
+
+// complexity returns the complexity of the selections and adds the aliases
+// they select to aliases, walking fragments wherever they are spread. Once
+// the complexity exceeds limit it returns limit+1 without walking further.
+func (e *execution) complexity(selections []Selection, limit int, aliases *int) int {
+	total := 0
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *Field:
+			if sel.Alias != "" {
+				*aliases++
+			}
+			child := e.complexity(sel.SelectionSet, limit, aliases)
+			if e.schema.Complexity != nil {
+				total += e.schema.Complexity(sel, e.arguments(sel), child)
+			} else {
+				total += child + 1
+			}
+		case *InlineFragment:
+			total += e.complexity(sel.SelectionSet, limit, aliases)
+		case *FragmentSpread:
+			total += e.complexity(e.fragments[sel.Name].SelectionSet, limit, aliases)
+		}
+		if total > limit {
+			return limit + 1
+		}
+	}
+	return total
+}
+
+func coerceVariables(operation *Operation, given map[string]interface{}) (map[string]interface{}, error) {
+	variables := make(map[string]interface{}, len(operation.Variables))
+	for _, definition := range operation.Variables {
+		value, ok := given[definition.Name]
+		if !ok && definition.Default != nil {
+			value, ok = valueOf(definition.Default, nil), true
+		}
+		if (!ok || value == nil) && definition.Type[len(definition.Type)-1] == '!' {
+			return nil, fmt.Errorf("variable $%s of type %s is required", definition.Name, definition.Type)
+		}
+		variables[definition.Name] = value
+	}
+	return variables, nil
+}
+
+// valueOf returns the Go value of an argument value
+func valueOf(value Value, variables map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case Variable:
+		return variables[v.Name]
+	case Literal:
+		return v.Value
+	case EnumValue:
+		return v.Name
+	case ListValue:
+		items := make([]interface{}, len(v.Items))
+		for i, item := range v.Items {
+			items[i] = valueOf(item, variables)
+		}
+		return items
+	case ObjectValue:
+		fields := make(map[string]interface{}, len(v.Fields))
+		for name, field := range v.Fields {
+			fields[name] = valueOf(field, variables)
+		}
+		return fields
+	}
+	return nil
+}
+
+// arguments returns the values of the field's arguments
+func (e *execution) arguments(field *Field) Args {
+	args := make(Args, len(field.Arguments))
+	for name, value := range field.Arguments {
+		args[name] = valueOf(value, e.variables)
+	}
+	return args
+}
+
+func (e *execution) addError(err *Error) {
+	e.mu.Lock()
+	e.errors = append(e.errors, err)
+	e.mu.Unlock()
+}
+
+func (e *execution) fieldError(ctx context.Context, field *Field, path []interface{}, err error) {
+	message := err.Error()
+	if _, internal := err.(*Error); !internal && e.schema.PresentError != nil {
+		message = e.schema.PresentError(ctx, err)
+	}
+	e.addError(&Error{
+		Message:   message,
+		Locations: []Location{{Line: field.Line, Column: field.Column}},
+		Path:      append([]interface{}(nil), path...),
+	})
+}
+
+// collectFields groups the selected fields by response key, in the order
+// they were selected, expanding fragments that apply to the type
+func (e *execution) collectFields(typeName string, selections []Selection, keys *[]string, fields map[string][]*Field) {
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *Field:
+			if !e.included(sel.Directives) {
+				continue
+			}
+			key := sel.ResponseKey()
+			if _, seen := fields[key]; !seen {
+				*keys = append(*keys, key)
+			}
+			fields[key] = append(fields[key], sel)
+		case *InlineFragment:
+			if !e.included(sel.Directives) || (sel.TypeCondition != "" && sel.TypeCondition != typeName) {
+				continue
+			}
+			e.collectFields(typeName, sel.SelectionSet, keys, fields)
+		case *FragmentSpread:
+			fragment := e.fragments[sel.Name]
+			if !e.included(sel.Directives) || fragment.TypeCondition != typeName {
+				continue
+			}
+			e.collectFields(typeName, fragment.SelectionSet, keys, fields)
+		}
+	}
+}
+
+// included applies the @skip and @include directives
+func (e *execution) included(directives []*Directive) bool {
+	for _, directive := range directives {
+		condition, _ := valueOf(directive.Arguments["if"], e.variables).(bool)
+		switch directive.Name {
+		case "skip":
+			if condition {
+				return false
+			}
+		case "include":
+			if !condition {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// executeObject resolves the selected fields of the source value. Fields with
+// resolvers run concurrently, so that the loaders they call can batch.
+func (e *execution) executeObject(ctx context.Context, object *Object, source interface{}, selections []Selection, path []interface{}) *resultMap {
+	typeName := typeNameOf(object, source)
+	var keys []string
+	fields := make(map[string][]*Field)
+	e.collectFields(typeName, selections, &keys, fields)
+
+	values := make([]interface{}, len(keys))
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		field := mergeFields(fields[key])
+		fieldPath := append(append([]interface{}(nil), path...), key)
+
+		if field.Name == "__typename" {
+			values[i] = typeName
+			continue
+		}
+		var resolve ResolveFunc
+		if object != nil {
+			resolve = object.Fields[field.Name]
+		}
+		if resolve == nil {
+			values[i] = e.resolveValue(ctx, typeName, source, field, fieldPath)
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, field *Field, resolve ResolveFunc) {
+			defer wg.Done()
+			defer e.recoverField(ctx, field, fieldPath)
+			value, err := resolve(ctx, ResolveParams{Source: source, Args: e.arguments(field), Field: field})
+			if err != nil {
+				e.fieldError(ctx, field, fieldPath, err)
+				return
+			}
+			values[i] = e.complete(ctx, value, field, fieldPath)
+		}(i, field, resolve)
+	}
+	wg.Wait()
+
+	result := newResultMap(len(keys))
+	for i, key := range keys {
+		result.set(key, values[i])
+	}
+	return result
+}
+
+// mergeFields merges the selections of fields selected more than once under
+// the same key into the first of them
+func mergeFields(fields []*Field) *Field {
+	if len(fields) == 1 {
+		return fields[0]
+	}
+	merged := *fields[0]
+	merged.SelectionSet = nil
+	for _, field := range fields {
+		merged.SelectionSet = append(merged.SelectionSet, field.SelectionSet...)
+	}
+	return &merged
+}
+
+func (e *execution) recoverField(ctx context.Context, field *Field, path []interface{}) {
+	if r := recover(); r != nil {
+		e.fieldError(ctx, field, path, fmt.Errorf("panic resolving %s: %v", field.Name, r))
+	}
+}
+
+// resolveValue resolves a field from the JSON encoding of the source value
+func (e *execution) resolveValue(ctx context.Context, typeName string, source interface{}, field *Field, path []interface{}) interface{} {
+	v := reflect.ValueOf(source)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	var value interface{}
+	found := false
+	switch v.Kind() {
+	case reflect.Struct:
+		value, found = fieldValue(v, field.Name)
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			entry := v.MapIndex(reflect.ValueOf(field.Name).Convert(v.Type().Key()))
+			found = true
+			if entry.IsValid() {
+				value = entry.Interface()
+			}
+		}
+	}
+	if !found {
+		e.fieldError(ctx, field, path, &Error{Message: fmt.Sprintf("cannot query field %q on type %q", field.Name, typeName)})
+		return nil
+	}
+	return e.complete(ctx, value, field, path)
+}
+
+// complete turns a resolved value into its response: the selected fields of
+// objects, the completed items of lists, and leaves as they are
+func (e *execution) complete(ctx context.Context, value interface{}, field *Field, path []interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || ((v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.IsNil()) {
+		return nil
+	}
+
+	object := e.schema.objectFor(v.Type())
+	switch {
+	case object == nil && isLeaf(v.Type()):
+		if len(field.SelectionSet) > 0 {
+			e.fieldError(ctx, field, path, &Error{Message: fmt.Sprintf("field %q of type %q has no fields to select", field.Name, v.Type().Name())})
+			return nil
+		}
+		return value
+	case object == nil && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array):
+		return e.completeList(ctx, v, field, path)
+	case object == nil && v.Kind() == reflect.Map && len(field.SelectionSet) == 0:
+		return value
+	}
+
+	if len(field.SelectionSet) == 0 {
+		e.fieldError(ctx, field, path, &Error{Message: fmt.Sprintf("field %q of type %q must select its fields", field.Name, typeNameOf(object, value))})
+		return nil
+	}
+	return e.executeObject(ctx, object, value, field.SelectionSet, path)
+}
+
+// completeList completes the items of a list, concurrently when they are
+// objects
+func (e *execution) completeList(ctx context.Context, v reflect.Value, field *Field, path []interface{}) interface{} {
+	items := make([]interface{}, v.Len())
+	if len(field.SelectionSet) == 0 {
+		for i := range items {
+			items[i] = e.complete(ctx, v.Index(i).Interface(), field, append(append([]interface{}(nil), path...), i))
+		}
+		return items
+	}
+
+	var wg sync.WaitGroup
+	for i := range items {
+		itemPath := append(append([]interface{}(nil), path...), i)
+		item := v.Index(i).Interface()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer e.recoverField(ctx, field, itemPath)
+			items[i] = e.complete(ctx, item, field, itemPath)
+		}(i)
+	}
+	wg.Wait()
+	return items
+}
+
+// typeNameOf returns the name of the object, or of the Go type of the value
+func typeNameOf(object *Object, value interface{}) string {
+	if object != nil {
+		return object.Name
+	}
+	if value == nil {
+		return ""
+	}
+	return indirectType(reflect.TypeOf(value)).Name()
+}
@@ -0,0 +1,229 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testTimestamps struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testAuthor struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type testPost struct {
+	testTimestamps
+	ID       string            `json:"id"`
+	Title    string            `json:"title"`
+	AuthorID string            `json:"author_id"`
+	Tags     []string          `json:"tags"`
+	Meta     map[string]string `json:"meta,omitempty"`
+	Secret   string            `json:"-"`
+}
+
+func newTestSchema(authorLoads *int) *Schema {
+	posts := []*testPost{
+		{ID: "p1", Title: "First", AuthorID: "a1", Tags: []string{"go"}, Secret: "hidden",
+			testTimestamps: testTimestamps{CreatedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}},
+		{ID: "p2", Title: "Second", AuthorID: "a2", Meta: map[string]string{"lang": "en"}},
+		{ID: "p3", Title: "Third", AuthorID: "a1"},
+	}
+	authors := NewLoader(func(ctx context.Context, ids []string) []Result[*testAuthor] {
+		*authorLoads++
+		results := make([]Result[*testAuthor], len(ids))
+		for i, id := range ids {
+			results[i].Value = &testAuthor{ID: id, Name: "Author " + id}
+		}
+		return results
+	}, DefaultLoaderConfig())
+
+	schema := NewSchema(&Object{Name: "Query", Fields: map[string]ResolveFunc{
+		"posts": func(ctx context.Context, p ResolveParams) (interface{}, error) {
+			first, err := p.Args.Int("first", len(posts))
+			if err != nil {
+				return nil, err
+			}
+			if first > len(posts) {
+				first = len(posts)
+			}
+			return posts[:first], nil
+		},
+		"post": func(ctx context.Context, p ResolveParams) (interface{}, error) {
+			id, err := p.Args.String("id")
+			if err != nil {
+				return nil, err
+			}
+			for _, post := range posts {
+				if post.ID == id {
+					return post, nil
+				}
+			}
+			return nil, errors.New("post not found")
+		},
+	}})
+	schema.Bind(testPost{}, &Object{Name: "Post", Fields: map[string]ResolveFunc{
+		"author": func(ctx context.Context, p ResolveParams) (interface{}, error) {
+			return authors.Load(ctx, p.Source.(*testPost).AuthorID)
+		},
+		"explode": func(ctx context.Context, p ResolveParams) (interface{}, error) {
+			panic("boom")
+		},
+	}})
+	return schema
+}
+
+func executeJSON(t *testing.T, schema *Schema, req Request) string {
+	t.Helper()
+	data, err := json.Marshal(schema.Execute(context.Background(), req))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestExecute(t *testing.T) {
+	loads := 0
+	schema := newTestSchema(&loads)
+
+	body := executeJSON(t, schema, Request{Query: `{
+		posts {
+			__typename
+			title
+			heading: title
+			created_at
+			tags
+			meta { lang }
+			author { name }
+		}
+	}`})
+	assert.JSONEq(t, `{"data":{"posts":[
+		{"__typename":"Post","title":"First","heading":"First","created_at":"2026-01-02T00:00:00Z","tags":["go"],"meta":null,"author":{"name":"Author a1"}},
+		{"__typename":"Post","title":"Second","heading":"Second","created_at":"0001-01-01T00:00:00Z","tags":null,"meta":{"lang":"en"},"author":{"name":"Author a2"}},
+		{"__typename":"Post","title":"Third","heading":"Third","created_at":"0001-01-01T00:00:00Z","tags":null,"meta":null,"author":{"name":"Author a1"}}
+	]}}`, body)
+	assert.Equal(t, 1, loads, "the authors of all posts are loaded in one batch")
+}
+
+func TestExecute_KeepsSelectionOrder(t *testing.T) {
+	loads := 0
+	body := executeJSON(t, newTestSchema(&loads), Request{Query: `{ post(id: "p1") { title id author_id } }`})
+	assert.Equal(t, `{"data":{"post":{"title":"First","id":"p1","author_id":"a1"}}}`, body)
+}
+
+func TestExecute_VariablesFragmentsAndDirectives(t *testing.T) {
+	loads := 0
+	schema := newTestSchema(&loads)
+
+	body := executeJSON(t, schema, Request{
+		Query: `
+			query Posts($first: Int = 1, $withAuthor: Boolean!) {
+				posts(first: $first) { ...post author @include(if: $withAuthor) { id } id @skip(if: true) }
+			}
+			fragment post on Post { title ... on Author { name } }
+		`,
+		Variables: map[string]interface{}{"first": float64(2), "withAuthor": false},
+	})
+	assert.JSONEq(t, `{"data":{"posts":[{"title":"First"},{"title":"Second"}]}}`, body)
+
+	body = executeJSON(t, schema, Request{Query: `query Posts($withAuthor: Boolean!) { posts { id } }`})
+	assert.JSONEq(t, `{"data":null,"errors":[{"message":"variable $withAuthor of type Boolean! is required"}]}`, body)
+}
+
+func TestExecute_FieldErrors(t *testing.T) {
+	loads := 0
+	schema := newTestSchema(&loads)
+	schema.PresentError = func(ctx context.Context, err error) string {
+		return fmt.Sprintf("presented: %v", err)
+	}
+
+	response := schema.Execute(context.Background(), Request{Query: `{
+		missing: post(id: "p9") { title }
+		post(id: "p1") { title Secret explode title { length } }
+		posts(first: "all") { id }
+	}`})
+	data, err := json.Marshal(response.Data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"missing":null,"post":{"title":null,"Secret":null,"explode":null},"posts":null}`, string(data))
+
+	messages := make(map[string]string)
+	for _, fieldErr := range response.Errors {
+		messages[fmt.Sprint(fieldErr.Path)] = fieldErr.Message
+	}
+	assert.Equal(t, map[string]string{
+		"[missing]":      "presented: post not found",
+		"[post title]":   `field "title" of type "string" has no fields to select`,
+		"[post Secret]":  `cannot query field "Secret" on type "Post"`,
+		"[post explode]": "presented: panic resolving explode: boom",
+		"[posts]":        `argument "first" must be an integer`,
+	}, messages)
+}
+
+func TestExecute_RequestErrors(t *testing.T) {
+	loads := 0
+	schema := newTestSchema(&loads)
+	schema.MaxDepth = 2
+	schema.MaxAliases = 2
+	schema.MaxComplexity = 10
+
+	tests := []struct {
+		name    string
+		req     Request
+		message string
+	}{
+		{"syntax", Request{Query: `{ posts { id }`}, "syntax error at 1:15: unexpected end of document"},
+		{"mutation", Request{Query: `mutation { deletePost(id: "p1") { id } }`}, "mutation operations are not supported"},
+		{"ambiguous operation", Request{Query: `query A { posts { id } } query B { posts { id } }`}, "operationName is required when the document has several operations"},
+		{"unknown operation", Request{Query: `query A { posts { id } }`, OperationName: "B"}, `unknown operation "B"`},
+		{"too deep", Request{Query: `{ posts { author { name } } }`}, "query depth 3 exceeds the maximum of 2"},
+		{"fragment cycle", Request{Query: `{ posts { ...a } } fragment a on Post { ...a }`}, `fragment "a" spreads itself`},
+		{"unknown fragment", Request{Query: `{ posts { ...a } }`}, `unknown fragment "a"`},
+		{"too many aliases", Request{Query: `{ a: post(id: "p1") { id } b: post(id: "p2") { id } c: post(id: "p3") { id } }`}, "query selects 3 aliases, more than the maximum of 2"},
+		{"aliases in fragments", Request{Query: `{ posts { ...a ...a } } fragment a on Post { x: id y: title }`}, "query selects 4 aliases, more than the maximum of 2"},
+		{"too complex", Request{Query: `{ posts { ...a ...a } } fragment a on Post { id title author_id created_at tags }`}, "query complexity exceeds the maximum of 10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := schema.Execute(context.Background(), tt.req)
+			assert.Nil(t, response.Data)
+			require.Len(t, response.Errors, 1)
+			assert.Equal(t, tt.message, response.Errors[0].Message)
+		})
+	}
+}
+
+func TestExecute_Complexity(t *testing.T) {
+	loads := 0
+	schema := newTestSchema(&loads)
+	schema.MaxComplexity = 7
+	schema.Complexity = func(field *Field, args Args, childComplexity int) int {
+		if field.Name != "posts" {
+			return childComplexity + 1
+		}
+		first, err := args.Int("first", 3)
+		if err != nil {
+			return childComplexity + 1
+		}
+		return first*childComplexity + 1
+	}
+
+	response := schema.Execute(context.Background(), Request{Query: `{ posts(first: 3) { id title } }`})
+	assert.Empty(t, response.Errors)
+	assert.NotNil(t, response.Data)
+
+	response = schema.Execute(context.Background(), Request{
+		Query:     `query($first: Int) { posts(first: $first) { id title } }`,
+		Variables: map[string]interface{}{"first": float64(4)},
+	})
+	assert.Nil(t, response.Data)
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, "query complexity exceeds the maximum of 7", response.Errors[0].Message)
+	assert.Zero(t, loads)
+}
@@ -0,0 +1,159 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Result is the value loaded for one key, or why it could not be
+type Result[V any] struct {
+	Value V
+	Err   error
+}
+
+// BatchFunc loads the values of the keys, returning one result per key in
+// the same order
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) []Result[V]
+
+// LoaderConfig holds the batching configuration of a loader
+type LoaderConfig struct {
+	// Wait is how long a batch collects keys before it is fetched
+	Wait time.Duration
+	// MaxBatch is the most keys fetched at once; a full batch is fetched
+	// without waiting
+	MaxBatch int
+}
+
+// DefaultLoaderConfig returns the default batching configuration
+func DefaultLoaderConfig() LoaderConfig {
+	return LoaderConfig{Wait: 2 * time.Millisecond, MaxBatch: 100}
+}
+
+// Loader batches the loads of the fields resolved for one request into
+// calls of its batch function and caches their results. Loaders live as long
+// as the request: the cache is never invalidated.
+type Loader[K comparable, V any] struct {
+	fetch  BatchFunc[K, V]
+	config LoaderConfig
+
+	mu    sync.Mutex
+	cache map[K]*loaded[V]
+	batch *batch[K, V]
+}
+
+type loaded[V any] struct {
+	done chan struct{}
+	Result[V]
+}
+
+type batch[K comparable, V any] struct {
+	ctx     context.Context
+	keys    []K
+	results []*loaded[V]
+}
+
+// NewLoader creates a loader fetching with the batch function
+func NewLoader[K comparable, V any](fetch BatchFunc[K, V], config LoaderConfig) *Loader[K, V] {
+	if config.MaxBatch <= 0 {
+		config.MaxBatch = DefaultLoaderConfig().MaxBatch
+	}
+	return &Loader[K, V]{fetch: fetch, config: config, cache: make(map[K]*loaded[V])}
+}
+
+// Load returns the value of the key, fetching it with the other keys loaded
+// within the wait
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	result, cached := l.cache[key]
+	if !cached {
+		result = &loaded[V]{done: make(chan struct{})}
+		l.cache[key] = result
+		l.enqueue(ctx, key, result)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-result.done:
+		return result.Value, result.Err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// enqueue adds the key to the pending batch, starting one if there is none;
+// the caller holds the lock
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K, result *loaded[V]) {
+	if l.batch == nil {
+		b := &batch[K, V]{ctx: ctx}
+		l.batch = b
+		time.AfterFunc(l.config.Wait, func() {
+			l.mu.Lock()
+			if l.batch != b {
+				l.mu.Unlock()
+				return
+			}
+			l.batch = nil
+			l.mu.Unlock()
+			l.dispatch(b)
+		})
+	}
+
+	b := l.batch
+	b.keys = append(b.keys, key)
+	b.results = append(b.results, result)
+	if len(b.keys) >= l.config.MaxBatch {
+		l.batch = nil
+		go l.dispatch(b)
+	}
+}
+
+func (l *Loader[K, V]) dispatch(b *batch[K, V]) {
+	results, err := l.fetchBatch(b)
+	if err == nil && len(results) != len(b.keys) {
+		err = fmt.Errorf("loader returned %d results for %d keys", len(results), len(b.keys))
+	}
+	for i, result := range b.results {
+		if err != nil {
+			result.Err = err
+		} else {
+			result.Result = results[i]
+		}
+		close(result.done)
+	}
+}
+
+// fetchBatch fetches the batch, recovering from panics in the batch function
+// since it runs outside the request's goroutine
+func (l *Loader[K, V]) fetchBatch(b *batch[K, V]) (results []Result[V], err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic loading batch: %v", r)
+		}
+	}()
+	return l.fetch(b.ctx, b.keys), nil
+}
+
+// FetchEach is a batch function for services without batch lookups: it
+// fetches each key separately, at most limit at a time, sharing the loader's
+// cache so that a request fetches each key once
+func FetchEach[K comparable, V any](limit int, fetch func(ctx context.Context, key K) (V, error)) BatchFunc[K, V] {
+	return func(ctx context.Context, keys []K) []Result[V] {
+		results := make([]Result[V], len(keys))
+		sem := make(chan struct{}, limit)
+		var wg sync.WaitGroup
+		for i, key := range keys {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int, key K) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				results[i].Value, results[i].Err = fetch(ctx, key)
+			}(i, key)
+		}
+		wg.Wait()
+		return results
+	}
+}
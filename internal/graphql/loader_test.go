@@ -0,0 +1,112 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader_BatchesAndCaches(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]int
+	loader := NewLoader(func(ctx context.Context, keys []int) []Result[int] {
+		mu.Lock()
+		batches = append(batches, keys)
+		mu.Unlock()
+		results := make([]Result[int], len(keys))
+		for i, key := range keys {
+			if key < 0 {
+				results[i].Err = errors.New("negative key")
+				continue
+			}
+			results[i].Value = key * 10
+		}
+		return results
+	}, LoaderConfig{Wait: 10 * time.Millisecond, MaxBatch: 3})
+
+	ctx := context.Background()
+	keys := []int{1, 2, 1, 3, 4, -1}
+	values := make([]int, len(keys))
+	errs := make([]error, len(keys))
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func(i, key int) {
+			defer wg.Done()
+			values[i], errs[i] = loader.Load(ctx, key)
+		}(i, key)
+	}
+	wg.Wait()
+
+	assert.Equal(t, []int{10, 20, 10, 30, 40, 0}, values)
+	assert.EqualError(t, errs[5], "negative key")
+	require.Len(t, batches, 2, "five distinct keys in batches of at most three")
+	assert.Len(t, batches[0], 3)
+	assert.Len(t, batches[1], 2)
+
+	value, err := loader.Load(ctx, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 40, value)
+	assert.Len(t, batches, 2, "loaded keys are cached")
+}
+
+func TestLoader_BatchFuncFailures(t *testing.T) {
+	ctx := context.Background()
+
+	short := NewLoader(func(ctx context.Context, keys []string) []Result[string] {
+		return nil
+	}, DefaultLoaderConfig())
+	_, err := short.Load(ctx, "a")
+	assert.EqualError(t, err, "loader returned 0 results for 1 keys")
+
+	panicking := NewLoader(func(ctx context.Context, keys []string) []Result[string] {
+		panic("boom")
+	}, DefaultLoaderConfig())
+	_, err = panicking.Load(ctx, "a")
+	assert.EqualError(t, err, "panic loading batch: boom")
+}
+
+func TestLoader_ContextCancelled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	loader := NewLoader(func(ctx context.Context, keys []string) []Result[string] {
+		<-release
+		return make([]Result[string], len(keys))
+	}, DefaultLoaderConfig())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := loader.Load(ctx, "a")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFetchEach(t *testing.T) {
+	var running, peak int32
+	fetch := FetchEach(2, func(ctx context.Context, key int) (int, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		if key == 0 {
+			return 0, errors.New("zero")
+		}
+		return key * key, nil
+	})
+
+	results := fetch(context.Background(), []int{1, 2, 3, 0, 5})
+	require.Len(t, results, 5)
+	assert.Equal(t, 9, results[2].Value)
+	assert.EqualError(t, results[3].Err, "zero")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&peak), "at most two keys are fetched at once")
+}
@@ -0,0 +1,745 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Document is a parsed GraphQL request document
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation is a query, mutation or subscription of a document
+type Operation struct {
+	Type         string
+	Name         string
+	Variables    []*VariableDefinition
+	SelectionSet []Selection
+}
+
+// VariableDefinition declares an operation variable and its default value
+type VariableDefinition struct {
+	Name    string
+	Type    string
+	Default Value
+}
+
+// Fragment is a named fragment definition
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	SelectionSet  []Selection
+}
+
+// Selection is a *Field, *FragmentSpread or *InlineFragment
+type Selection interface {
+	selection()
+}
+
+// Field selects a field, under its alias if it has one
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    map[string]Value
+	Directives   []*Directive
+	SelectionSet []Selection
+	Line         int
+	Column       int
+}
+
+// ResponseKey is the key the field's value is returned under
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// FragmentSpread includes a named fragment
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+}
+
+// InlineFragment includes selections, optionally only for one type
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+}
+
+func (*Field) selection()          {}
+func (*FragmentSpread) selection() {}
+func (*InlineFragment) selection() {}
+
+// Directive is a directive such as @include(if: $flag)
+type Directive struct {
+	Name      string
+	Arguments map[string]Value
+}
+
+// Value is a literal or variable argument value
+type Value interface {
+	value()
+}
+
+// Variable refers to an operation variable
+type Variable struct{ Name string }
+
+// Literal is a scalar literal: an int64, float64, string, bool or nil
+type Literal struct{ Value interface{} }
+
+// EnumValue is an enum literal, resolved to its name
+type EnumValue struct{ Name string }
+
+// ListValue is a list literal
+type ListValue struct{ Items []Value }
+
+// ObjectValue is an input object literal
+type ObjectValue struct{ Fields map[string]Value }
+
+func (Variable) value()    {}
+func (Literal) value()     {}
+func (EnumValue) value()   {}
+func (ListValue) value()   {}
+func (ObjectValue) value() {}
+
+// SyntaxError reports where a document could not be parsed
+type SyntaxError struct {
+	Message string
+	Line    int
+	Column  int
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	line   int
+	column int
+}
+
+type lexer struct {
+	src    string
+	pos    int
+	line   int
+	column int
+}
+
+func (l *lexer) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Message: fmt.Sprintf(format, args...), Line: l.line, Column: l.column}
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.pos < len(l.src); i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.column = 1
+		} else {
+			l.column++
+		}
+		l.pos++
+	}
+}
+
+// skipIgnored skips whitespace, commas and comments
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\uFEFF"):
+			l.pos += len("\uFEFF")
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	tok := token{line: l.line, column: l.column}
+	if l.pos >= len(l.src) {
+		tok.kind = tokenEOF
+		return tok, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		tok.kind, tok.text = tokenPunct, "..."
+		l.advance(3)
+	case strings.ContainsRune("!$():=@[]{}|&", rune(c)):
+		tok.kind, tok.text = tokenPunct, string(c)
+		l.advance(1)
+	case c == '_' || isLetter(c):
+		start := l.pos
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		tok.kind, tok.text = tokenName, l.src[start:l.pos]
+	case c == '-' || isDigit(c):
+		return l.number(tok)
+	case c == '"':
+		text, err := l.string()
+		if err != nil {
+			return tok, err
+		}
+		tok.kind, tok.text = tokenString, text
+	default:
+		r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+		return tok, l.errorf("unexpected character %q", r)
+	}
+	return tok, nil
+}
+
+func (l *lexer) number(tok token) (token, error) {
+	start := l.pos
+	if l.src[l.pos] == '-' {
+		l.advance(1)
+	}
+	digits := func() int {
+		n := 0
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.advance(1)
+			n++
+		}
+		return n
+	}
+	if digits() == 0 {
+		return tok, l.errorf("invalid number")
+	}
+	tok.kind = tokenInt
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		l.advance(1)
+		if digits() == 0 {
+			return tok, l.errorf("invalid number")
+		}
+		tok.kind = tokenFloat
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		l.advance(1)
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.advance(1)
+		}
+		if digits() == 0 {
+			return tok, l.errorf("invalid number")
+		}
+		tok.kind = tokenFloat
+	}
+	tok.text = l.src[start:l.pos]
+	return tok, nil
+}
+
+func (l *lexer) string() (string, error) {
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		l.advance(3)
+		end := strings.Index(l.src[l.pos:], `"""`)
+		if end < 0 {
+			return "", l.errorf("unterminated string")
+		}
+		text := l.src[l.pos : l.pos+end]
+		l.advance(end + 3)
+		return strings.TrimSpace(text), nil
+	}
+
+	l.advance(1)
+	var b strings.Builder
+	for {
+		if l.pos >= len(l.src) || l.src[l.pos] == '\n' {
+			return "", l.errorf("unterminated string")
+		}
+		c := l.src[l.pos]
+		if c == '"' {
+			l.advance(1)
+			return b.String(), nil
+		}
+		if c != '\\' {
+			r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			b.WriteRune(r)
+			l.advance(size)
+			continue
+		}
+		if l.pos+1 >= len(l.src) {
+			return "", l.errorf("unterminated string")
+		}
+		switch esc := l.src[l.pos+1]; esc {
+		case '"', '\\', '/':
+			b.WriteByte(esc)
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			if l.pos+6 > len(l.src) {
+				return "", l.errorf("invalid unicode escape")
+			}
+			code, err := strconv.ParseUint(l.src[l.pos+2:l.pos+6], 16, 32)
+			if err != nil {
+				return "", l.errorf("invalid unicode escape")
+			}
+			b.WriteRune(rune(code))
+			l.advance(4)
+		default:
+			return "", l.errorf("invalid escape \\%c", esc)
+		}
+		l.advance(2)
+	}
+}
+
+func isLetter(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+// Parse parses a GraphQL request document
+func Parse(source string) (*Document, error) {
+	p := &parser{lex: &lexer{src: source, line: 1, column: 1}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{Fragments: make(map[string]*Fragment)}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek(tokenPunct, "{"):
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", SelectionSet: selections})
+		case p.peek(tokenName, "fragment"):
+			fragment, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := doc.Fragments[fragment.Name]; exists {
+				return nil, p.errorf("fragment %q is defined more than once", fragment.Name)
+			}
+			doc.Fragments[fragment.Name] = fragment
+		case p.peek(tokenName, "query"), p.peek(tokenName, "mutation"), p.peek(tokenName, "subscription"):
+			operation, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, operation)
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.Operations) == 0 {
+		return nil, &SyntaxError{Message: "document has no operations", Line: 1, Column: 1}
+	}
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Message: fmt.Sprintf(format, args...), Line: p.tok.line, Column: p.tok.column}
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return p.errorf("unexpected end of document")
+	}
+	return p.errorf("unexpected %q", p.tok.text)
+}
+
+func (p *parser) peek(kind tokenKind, text string) bool {
+	return p.tok.kind == kind && p.tok.text == text
+}
+
+// skip consumes the punctuator if it is next, reporting whether it was
+func (p *parser) skip(text string) (bool, error) {
+	if !p.peek(tokenPunct, text) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expect(text string) error {
+	if !p.peek(tokenPunct, text) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.tok.text
+	return name, p.advance()
+}
+
+func (p *parser) operation() (*Operation, error) {
+	operation := &Operation{Type: p.tok.text}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenName {
+		operation.Name = p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if ok, err := p.skip("("); err != nil {
+		return nil, err
+	} else if ok {
+		for !p.peek(tokenPunct, ")") {
+			definition, err := p.variableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			operation.Variables = append(operation.Variables, definition)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	operation.SelectionSet = selections
+	return operation, nil
+}
+
+func (p *parser) variableDefinition() (*VariableDefinition, error) {
+	if err := p.expect("$"); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	typ, err := p.typeReference()
+	if err != nil {
+		return nil, err
+	}
+	definition := &VariableDefinition{Name: name, Type: typ}
+	if ok, err := p.skip("="); err != nil {
+		return nil, err
+	} else if ok {
+		if definition.Default, err = p.value(true); err != nil {
+			return nil, err
+		}
+	}
+	return definition, nil
+}
+
+func (p *parser) typeReference() (string, error) {
+	var typ string
+	if ok, err := p.skip("["); err != nil {
+		return "", err
+	} else if ok {
+		inner, err := p.typeReference()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect("]"); err != nil {
+			return "", err
+		}
+		typ = "[" + inner + "]"
+	} else if typ, err = p.name(); err != nil {
+		return "", err
+	}
+	if ok, err := p.skip("!"); err != nil {
+		return "", err
+	} else if ok {
+		typ += "!"
+	}
+	return typ, nil
+}
+
+func (p *parser) fragment() (*Fragment, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, p.errorf("fragment cannot be named \"on\"")
+	}
+	if !p.peek(tokenName, "on") {
+		return nil, p.unexpected()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	typeCondition, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &Fragment{Name: name, TypeCondition: typeCondition, SelectionSet: selections}, nil
+}
+
+func (p *parser) selectionSet() ([]Selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var selections []Selection
+	for !p.peek(tokenPunct, "}") {
+		selection, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+	if len(selections) == 0 {
+		return nil, p.errorf("selection set is empty")
+	}
+	return selections, p.advance()
+}
+
+func (p *parser) selection() (Selection, error) {
+	if ok, err := p.skip("..."); err != nil {
+		return nil, err
+	} else if ok {
+		return p.fragmentSelection()
+	}
+
+	field := &Field{Line: p.tok.line, Column: p.tok.column}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		field.Alias = name
+		if name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	field.Name = name
+
+	if field.Arguments, err = p.arguments(); err != nil {
+		return nil, err
+	}
+	if field.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if p.peek(tokenPunct, "{") {
+		if field.SelectionSet, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+func (p *parser) fragmentSelection() (Selection, error) {
+	if p.tok.kind == tokenName && p.tok.text != "on" {
+		spread := &FragmentSpread{Name: p.tok.text}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		directives, err := p.directives()
+		if err != nil {
+			return nil, err
+		}
+		spread.Directives = directives
+		return spread, nil
+	}
+
+	inline := &InlineFragment{}
+	if p.peek(tokenName, "on") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		typeCondition, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		inline.TypeCondition = typeCondition
+	}
+	directives, err := p.directives()
+	if err != nil {
+		return nil, err
+	}
+	inline.Directives = directives
+	if inline.SelectionSet, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return inline, nil
+}
+
+func (p *parser) arguments() (map[string]Value, error) {
+	if ok, err := p.skip("("); err != nil || !ok {
+		return nil, err
+	}
+	arguments := make(map[string]Value)
+	for !p.peek(tokenPunct, ")") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if _, exists := arguments[name]; exists {
+			return nil, p.errorf("argument %q is given more than once", name)
+		}
+		if arguments[name], err = p.value(false); err != nil {
+			return nil, err
+		}
+	}
+	return arguments, p.advance()
+}
+
+func (p *parser) directives() ([]*Directive, error) {
+	var directives []*Directive
+	for p.peek(tokenPunct, "@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		arguments, err := p.arguments()
+		if err != nil {
+			return nil, err
+		}
+		directives = append(directives, &Directive{Name: name, Arguments: arguments})
+	}
+	return directives, nil
+}
+
+// value parses a value; constant values, such as variable defaults, cannot
+// refer to variables
+func (p *parser) value(constant bool) (Value, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokenPunct:
+		switch tok.text {
+		case "$":
+			if constant {
+				return nil, p.errorf("variables are not allowed here")
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			return Variable{Name: name}, nil
+		case "[":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			list := ListValue{}
+			for !p.peek(tokenPunct, "]") {
+				item, err := p.value(constant)
+				if err != nil {
+					return nil, err
+				}
+				list.Items = append(list.Items, item)
+			}
+			return list, p.advance()
+		case "{":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			object := ObjectValue{Fields: make(map[string]Value)}
+			for !p.peek(tokenPunct, "}") {
+				name, err := p.name()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+				if object.Fields[name], err = p.value(constant); err != nil {
+					return nil, err
+				}
+			}
+			return object, p.advance()
+		}
+	case tokenInt:
+		n, err := strconv.ParseInt(tok.text, 10, 64)
+		if err != nil {
+			return nil, p.errorf("integer %s is out of range", tok.text)
+		}
+		return Literal{Value: n}, p.advance()
+	case tokenFloat:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, p.errorf("invalid float %s", tok.text)
+		}
+		return Literal{Value: f}, p.advance()
+	case tokenString:
+		return Literal{Value: tok.text}, p.advance()
+	case tokenName:
+		var value Value
+		switch tok.text {
+		case "true":
+			value = Literal{Value: true}
+		case "false":
+			value = Literal{Value: false}
+		case "null":
+			value = Literal{Value: nil}
+		default:
+			value = EnumValue{Name: tok.text}
+		}
+		return value, p.advance()
+	}
+	return nil, p.unexpected()
+}
@@ -0,0 +1,86 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := Parse(`
+		# The dashboard's wedding page
+		query Wedding($id: ID!, $page: Int = 2) {
+			wedding(id: $id) {
+				title
+				host: userId
+				guests(page: $page, status: ATTENDING, search: "Ann \"Z\"") @include(if: true) {
+					...guestFields
+				}
+				... on Wedding { slug }
+			}
+		}
+
+		fragment guestFields on GuestConnection { total items { firstName } }
+	`)
+	require.NoError(t, err)
+	require.Len(t, doc.Operations, 1)
+
+	operation := doc.Operations[0]
+	assert.Equal(t, "query", operation.Type)
+	assert.Equal(t, "Wedding", operation.Name)
+	require.Len(t, operation.Variables, 2)
+	assert.Equal(t, "ID!", operation.Variables[0].Type)
+	assert.Equal(t, Literal{Value: int64(2)}, operation.Variables[1].Default)
+
+	wedding := operation.SelectionSet[0].(*Field)
+	assert.Equal(t, Variable{Name: "id"}, wedding.Arguments["id"])
+	require.Len(t, wedding.SelectionSet, 4)
+
+	host := wedding.SelectionSet[1].(*Field)
+	assert.Equal(t, "host", host.ResponseKey())
+	assert.Equal(t, "userId", host.Name)
+
+	guests := wedding.SelectionSet[2].(*Field)
+	assert.Equal(t, EnumValue{Name: "ATTENDING"}, guests.Arguments["status"])
+	assert.Equal(t, Literal{Value: `Ann "Z"`}, guests.Arguments["search"])
+	require.Len(t, guests.Directives, 1)
+	assert.Equal(t, "include", guests.Directives[0].Name)
+	assert.Equal(t, &FragmentSpread{Name: "guestFields"}, guests.SelectionSet[0])
+
+	inline := wedding.SelectionSet[3].(*InlineFragment)
+	assert.Equal(t, "Wedding", inline.TypeCondition)
+
+	require.Contains(t, doc.Fragments, "guestFields")
+	assert.Equal(t, "GuestConnection", doc.Fragments["guestFields"].TypeCondition)
+}
+
+func TestParse_Shorthand(t *testing.T) {
+	doc, err := Parse(`{ me { email } }`)
+	require.NoError(t, err)
+	assert.Equal(t, "query", doc.Operations[0].Type)
+	assert.Equal(t, "me", doc.Operations[0].SelectionSet[0].(*Field).Name)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"empty document", ``},
+		{"unclosed selection", `{ me { email }`},
+		{"empty selection", `{ me { } }`},
+		{"unterminated string", `{ wedding(id: "abc) { title } }`},
+		{"unexpected character", `{ me % }`},
+		{"variable in default", `query ($a: Int = $b) { me { email } }`},
+		{"duplicate argument", `{ wedding(id: "a", id: "b") { title } }`},
+		{"duplicate fragment", `{ me { ...f } } fragment f on User { email } fragment f on User { email }`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.query)
+			var syntaxErr *SyntaxError
+			assert.ErrorAs(t, err, &syntaxErr)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+// Package graphql executes GraphQL queries against Go values. Types are the
+// Go types the resolvers return: their fields are the fields of their JSON
+// encoding, and an Object bound to a type adds fields resolved by functions,
+// such as the guests of a wedding. Only queries are supported; everything
+// the dashboard changes goes through the REST API.
+package graphql
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultMaxDepth is the deepest selection a query may make
+	DefaultMaxDepth = 10
+	// DefaultMaxAliases is how many fields a query may select under an alias
+	DefaultMaxAliases = 20
+	// DefaultMaxComplexity is the highest complexity a query may have
+	DefaultMaxComplexity = 1000
+)
+
+// ResolveFunc resolves a field of the source value
+type ResolveFunc func(ctx context.Context, p ResolveParams) (interface{}, error)
+
+// ResolveParams are the source value and arguments of a resolved field
+type ResolveParams struct {
+	Source interface{}
+	Args   Args
+	Field  *Field
+}
+
+// Object names a Go type and adds fields resolved by functions to the fields
+// of its JSON encoding
+type Object struct {
+	Name   string
+	Fields map[string]ResolveFunc
+}
+
+// Schema is the query root and the objects bound to Go types
+type Schema struct {
+	Query *Object
+	// MaxDepth limits how deep queries may select; DefaultMaxDepth when zero
+	MaxDepth int
+	// MaxAliases limits how many fields queries may select under an alias,
+	// counting fragments once for every spread; DefaultMaxAliases when zero
+	MaxAliases int
+	// MaxComplexity limits the complexity of queries, the sum of the
+	// complexities of their fields; DefaultMaxComplexity when zero
+	MaxComplexity int
+	// Complexity returns the complexity of a field from its arguments and
+	// the complexity of its selections, for example multiplying it by the
+	// number of items a list returns. One more than childComplexity when nil.
+	Complexity func(field *Field, args Args, childComplexity int) int
+	// PresentError turns a resolver error into the message the client sees;
+	// the error's own message when nil
+	PresentError func(ctx context.Context, err error) string
+
+	objects map[reflect.Type]*Object
+}
+
+// NewSchema creates a schema with the query root
+func NewSchema(query *Object) *Schema {
+	return &Schema{Query: query, objects: make(map[reflect.Type]*Object)}
+}
+
+// Bind binds the object to the type of sample, so that values of the type,
+// or pointers to it, resolve the object's fields
+func (s *Schema) Bind(sample interface{}, object *Object) {
+	s.objects[indirectType(reflect.TypeOf(sample))] = object
+}
+
+func (s *Schema) objectFor(t reflect.Type) *Object {
+	return s.objects[indirectType(t)]
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// Args are the arguments of a field, with variables substituted. Numbers are
+// int64 or float64, enums are their names, lists are []interface{} and input
+// objects are map[string]interface{}.
+type Args map[string]interface{}
+
+// Int returns the integer argument, or fallback when it is not given
+func (a Args) Int(name string, fallback int) (int, error) {
+	switch v := a[name].(type) {
+	case nil:
+		return fallback, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), nil
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return int(n), nil
+		}
+	}
+	return 0, &Error{Message: fmt.Sprintf("argument %q must be an integer", name)}
+}
+
+// String returns the string argument, or "" when it is not given
+func (a Args) String(name string) (string, error) {
+	switch v := a[name].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	}
+	return "", &Error{Message: fmt.Sprintf("argument %q must be a string", name)}
+}
+
+// Bool returns the boolean argument, or fallback when it is not given
+func (a Args) Bool(name string, fallback bool) (bool, error) {
+	switch v := a[name].(type) {
+	case nil:
+		return fallback, nil
+	case bool:
+		return v, nil
+	}
+	return false, &Error{Message: fmt.Sprintf("argument %q must be a boolean", name)}
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// isLeaf reports whether values of the type are returned whole, as their JSON
+// encoding, rather than selected from
+func isLeaf(t reflect.Type) bool {
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) ||
+		reflect.PtrTo(t).Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Interface:
+		return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+	}
+	return true
+}
+
+// jsonField is the index of a struct field with its JSON name
+type jsonField struct {
+	index []int
+}
+
+var fieldCache sync.Map // reflect.Type -> map[string]jsonField
+
+// jsonFields returns the fields of the struct type by their JSON names, with
+// the fields of embedded structs promoted as encoding/json does
+func jsonFields(t reflect.Type) map[string]jsonField {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.(map[string]jsonField)
+	}
+
+	fields := make(map[string]jsonField)
+	var walk func(t reflect.Type, index []int, depth int)
+	depths := make(map[string]int)
+	walk = func(t reflect.Type, index []int, depth int) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := field.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, _, _ := strings.Cut(tag, ",")
+			fieldIndex := append(append([]int(nil), index...), i)
+
+			if field.Anonymous && name == "" {
+				embedded := indirectType(field.Type)
+				if embedded.Kind() == reflect.Struct {
+					walk(embedded, fieldIndex, depth+1)
+					continue
+				}
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			// Shallower fields hide deeper ones of the same name
+			if existing, ok := depths[name]; ok && existing <= depth {
+				continue
+			}
+			depths[name] = depth
+			fields[name] = jsonField{index: fieldIndex}
+		}
+	}
+	walk(t, nil, 0)
+
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+// fieldValue returns the value of the struct field with the JSON name, and
+// whether the struct has one; fields of nil embedded pointers are nil
+func fieldValue(v reflect.Value, name string) (interface{}, bool) {
+	field, ok := jsonFields(v.Type())[name]
+	if !ok {
+		return nil, false
+	}
+	for _, i := range field.index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil, true
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v.Interface(), true
+}
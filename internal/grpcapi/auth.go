@@ -0,0 +1,83 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// healthServicePrefix is the method prefix of the health service, which
+// load balancers call without a token
+const healthServicePrefix = "/grpc.health.v1.Health/"
+
+// authUnary authenticates calls with the access token in their "authorization"
+// metadata and carries the caller in the context, as JWTAuth does for REST requests
+func authUnary(tokens *utils.JWTManager) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, tokens)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authenticate(ctx context.Context, tokens *utils.JWTManager) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	authorization := md.Get("authorization")
+	if len(authorization) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "no token provided")
+	}
+	token, found := strings.CutPrefix(authorization[0], "Bearer ")
+	if !found || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+
+	claims, err := tokens.ValidateToken(token, utils.AccessToken)
+	if err != nil {
+		if errors.Is(err, utils.ErrExpiredToken) {
+			return nil, status.Error(codes.Unauthenticated, "token has expired")
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	ctx = utils.WithActor(ctx, utils.RequestActor{UserID: claims.UserID})
+	// Members of an organization only see its users and weddings
+	if claims.TenantID != "" {
+		ctx = repository.WithTenant(ctx, claims.TenantID)
+	}
+	client := utils.RequestClient{}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		client.IP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(client.IP); err == nil {
+			client.IP = host
+		}
+	}
+	if userAgent := md.Get("user-agent"); len(userAgent) > 0 {
+		client.UserAgent = userAgent[0]
+	}
+	return utils.WithClient(ctx, client), nil
+}
+
+// callerID returns the user the call was authenticated as
+func callerID(ctx context.Context) (models.ID, error) {
+	userID, err := models.ParseID(utils.ActorFromContext(ctx).UserID)
+	if err != nil {
+		return models.NilID, status.Error(codes.Unauthenticated, "invalid user in token")
+	}
+	return userID, nil
+}
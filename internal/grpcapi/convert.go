@@ -0,0 +1,132 @@
+package grpcapi
+
+import (
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"wedding-invitation-backend/internal/domain/models"
+	weddingv1 "wedding-invitation-backend/pkg/pb/wedding/v1"
+)
+
+func weddingToProto(w *models.Wedding) *weddingv1.Wedding {
+	return &weddingv1.Wedding{
+		Id:           w.ID.String(),
+		UserId:       w.UserID.String(),
+		Slug:         w.Slug,
+		Title:        w.Title,
+		Status:       w.Status,
+		IsPublic:     w.IsPublic,
+		Partner1Name: fullName(w.Couple.Partner1.FullName, w.Couple.Partner1.FirstName, w.Couple.Partner1.LastName),
+		Partner2Name: fullName(w.Couple.Partner2.FullName, w.Couple.Partner2.FirstName, w.Couple.Partner2.LastName),
+		Event: &weddingv1.Event{
+			Title:        w.Event.Title,
+			Date:         timestamp(w.Event.Date),
+			Time:         w.Event.Time,
+			VenueName:    w.Event.VenueName,
+			VenueAddress: w.Event.VenueAddress,
+			Timezone:     w.Event.Timezone,
+		},
+		Language:       w.Language,
+		RsvpCount:      int32(w.RSVPCount),
+		GuestCount:     int32(w.GuestCount),
+		TotalAttending: int32(w.TotalAttending),
+		ViewCount:      w.ViewCount,
+		PublishedAt:    optionalTimestamp(w.PublishedAt),
+		CreatedAt:      timestamp(w.CreatedAt),
+		UpdatedAt:      timestamp(w.UpdatedAt),
+	}
+}
+
+func guestToProto(g *models.Guest) *weddingv1.Guest {
+	return &weddingv1.Guest{
+		Id:               g.ID.String(),
+		WeddingId:        g.WeddingID.String(),
+		FirstName:        g.FirstName,
+		LastName:         g.LastName,
+		Email:            g.Email,
+		Phone:            g.Phone,
+		Relationship:     g.Relationship,
+		Side:             g.Side,
+		InvitedVia:       g.InvitedVia,
+		InvitationStatus: g.InvitationStatus,
+		AllowPlusOne:     g.AllowPlusOne,
+		MaxPlusOnes:      int32(g.MaxPlusOnes),
+		RsvpStatus:       g.RSVPStatus,
+		Vip:              g.VIP,
+		Notes:            g.Notes,
+		Tags:             g.Tags,
+		GroupId:          optionalID(g.GroupID),
+		CreatedAt:        timestamp(g.CreatedAt),
+		UpdatedAt:        timestamp(g.UpdatedAt),
+	}
+}
+
+func rsvpToProto(r *models.RSVP) *weddingv1.RSVP {
+	return &weddingv1.RSVP{
+		Id:                  r.ID.String(),
+		WeddingId:           r.WeddingID.String(),
+		GuestId:             optionalID(r.GuestID),
+		FirstName:           r.FirstName,
+		LastName:            r.LastName,
+		Email:               r.Email,
+		Phone:               r.Phone,
+		Status:              r.Status,
+		AttendanceCount:     int32(r.AttendanceCount),
+		PlusOneCount:        int32(r.PlusOneCount),
+		DietaryRestrictions: r.DietaryRestrictions,
+		MealChoice:          r.MealChoice,
+		AdditionalNotes:     r.AdditionalNotes,
+		Source:              r.Source,
+		SubmittedAt:         timestamp(r.SubmittedAt),
+		UpdatedAt:           optionalTimestamp(r.UpdatedAt),
+	}
+}
+
+func statisticsToProto(s *models.RSVPStatistics) *weddingv1.RSVPStatistics {
+	stats := &weddingv1.RSVPStatistics{
+		TotalResponses: int32(s.TotalResponses),
+		Attending:      int32(s.Attending),
+		NotAttending:   int32(s.NotAttending),
+		Maybe:          int32(s.Maybe),
+		TotalGuests:    int32(s.TotalGuests),
+		PlusOnesCount:  int32(s.PlusOnesCount),
+		DietaryCounts:  make(map[string]int32, len(s.DietaryCounts)),
+	}
+	for option, count := range s.DietaryCounts {
+		stats.DietaryCounts[option] = int32(count)
+	}
+	return stats
+}
+
+// fullName returns the full name of a partner, made of their first and last
+// name when none was given
+func fullName(full, first, last string) string {
+	if full != "" {
+		return full
+	}
+	return strings.TrimSpace(first + " " + last)
+}
+
+// timestamp converts t, leaving zero times unset
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamp(*t)
+}
+
+func optionalID(id *models.ID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
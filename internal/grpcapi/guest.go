@@ -0,0 +1,98 @@
+package grpcapi
+
+import (
+	"context"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	weddingv1 "wedding-invitation-backend/pkg/pb/wedding/v1"
+)
+
+type guestServer struct {
+	weddingv1.UnimplementedGuestServiceServer
+	guests Guests
+}
+
+func (s *guestServer) GetGuest(ctx context.Context, req *weddingv1.GetGuestRequest) (*weddingv1.Guest, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	guestID, err := parseID("guest_id", req.GetGuestId())
+	if err != nil {
+		return nil, err
+	}
+
+	guest, err := s.guests.GetGuestByID(ctx, guestID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return guestToProto(guest), nil
+}
+
+func (s *guestServer) ListGuests(ctx context.Context, req *weddingv1.ListGuestsRequest) (*weddingv1.ListGuestsResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	weddingID, err := parseID("wedding_id", req.GetWeddingId())
+	if err != nil {
+		return nil, err
+	}
+	page, pageSize := pageParams(req.GetPage(), req.GetPageSize())
+	filters := repository.GuestFilters{
+		Search:     req.GetSearch(),
+		Side:       req.GetSide(),
+		RSVPStatus: req.GetRsvpStatus(),
+		Tag:        req.GetTag(),
+	}
+
+	guests, total, err := s.guests.ListGuests(ctx, weddingID, userID, page, pageSize, filters)
+	if err != nil {
+		return nil, err
+	}
+	resp := &weddingv1.ListGuestsResponse{
+		Guests:   make([]*weddingv1.Guest, 0, len(guests)),
+		Total:    total,
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}
+	for _, guest := range guests {
+		resp.Guests = append(resp.Guests, guestToProto(guest))
+	}
+	return resp, nil
+}
+
+// CreateGuest creates the guest with the defaults of the REST API: invited
+// digitally, invitation and RSVP pending
+func (s *guestServer) CreateGuest(ctx context.Context, req *weddingv1.CreateGuestRequest) (*weddingv1.Guest, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	weddingID, err := parseID("wedding_id", req.GetWeddingId())
+	if err != nil {
+		return nil, err
+	}
+
+	guest := &models.Guest{
+		FirstName:        req.GetFirstName(),
+		LastName:         req.GetLastName(),
+		Email:            req.GetEmail(),
+		Phone:            req.GetPhone(),
+		Relationship:     req.GetRelationship(),
+		Side:             req.GetSide(),
+		InvitedVia:       "digital",
+		InvitationStatus: models.InvitationStatusPending,
+		AllowPlusOne:     req.GetAllowPlusOne(),
+		MaxPlusOnes:      int(req.GetMaxPlusOnes()),
+		RSVPStatus:       "pending",
+		VIP:              req.GetVip(),
+		Notes:            req.GetNotes(),
+		Tags:             req.GetTags(),
+	}
+	if err := s.guests.CreateGuest(ctx, weddingID, userID, guest); err != nil {
+		return nil, err
+	}
+	return guestToProto(guest), nil
+}
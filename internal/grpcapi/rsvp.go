@@ -0,0 +1,58 @@
+package grpcapi
+
+import (
+	"context"
+
+	"wedding-invitation-backend/internal/domain/repository"
+	weddingv1 "wedding-invitation-backend/pkg/pb/wedding/v1"
+)
+
+type rsvpServer struct {
+	weddingv1.UnimplementedRSVPServiceServer
+	rsvps RSVPs
+}
+
+func (s *rsvpServer) ListRSVPs(ctx context.Context, req *weddingv1.ListRSVPsRequest) (*weddingv1.ListRSVPsResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	weddingID, err := parseID("wedding_id", req.GetWeddingId())
+	if err != nil {
+		return nil, err
+	}
+	page, pageSize := pageParams(req.GetPage(), req.GetPageSize())
+	filters := repository.RSVPFilters{Status: req.GetStatus(), Search: req.GetSearch()}
+
+	rsvps, total, err := s.rsvps.ListRSVPs(ctx, weddingID, userID, page, pageSize, filters)
+	if err != nil {
+		return nil, err
+	}
+	resp := &weddingv1.ListRSVPsResponse{
+		Rsvps:    make([]*weddingv1.RSVP, 0, len(rsvps)),
+		Total:    total,
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}
+	for _, rsvp := range rsvps {
+		resp.Rsvps = append(resp.Rsvps, rsvpToProto(rsvp))
+	}
+	return resp, nil
+}
+
+func (s *rsvpServer) GetRSVPStatistics(ctx context.Context, req *weddingv1.GetRSVPStatisticsRequest) (*weddingv1.RSVPStatistics, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	weddingID, err := parseID("wedding_id", req.GetWeddingId())
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.rsvps.GetRSVPStatistics(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return statisticsToProto(stats), nil
+}
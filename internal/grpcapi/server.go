@@ -0,0 +1,158 @@
+// Package grpcapi serves the internal gRPC API for services embedded alongside
+// this backend. It calls the same services as the REST API and authenticates
+// calls with the same access tokens, sent as "authorization: Bearer <token>"
+// metadata.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+	weddingv1 "wedding-invitation-backend/pkg/pb/wedding/v1"
+)
+
+// Weddings reads the weddings of the caller
+type Weddings interface {
+	GetWeddingsByIDs(ctx context.Context, ids []models.ID, requestingUserID models.ID) (map[models.ID]*models.Wedding, error)
+	GetUserWeddings(ctx context.Context, userID models.ID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error)
+}
+
+// Guests manages the guest lists of weddings
+type Guests interface {
+	GetGuestByID(ctx context.Context, guestID, userID models.ID) (*models.Guest, error)
+	ListGuests(ctx context.Context, weddingID, userID models.ID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error)
+	CreateGuest(ctx context.Context, weddingID, userID models.ID, guest *models.Guest) error
+}
+
+// RSVPs reads the RSVPs of weddings and their statistics
+type RSVPs interface {
+	ListRSVPs(ctx context.Context, weddingID models.ID, userID models.ID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error)
+	GetRSVPStatistics(ctx context.Context, weddingID models.ID, userID models.ID) (*models.RSVPStatistics, error)
+}
+
+// internalErrorMessage is the message of errors whose details are only logged
+const internalErrorMessage = "an unexpected error occurred"
+
+// NewServer creates a gRPC server serving the wedding, guest and RSVP services
+// and the standard health service, which needs no token
+func NewServer(weddings Weddings, guests Guests, rsvps RSVPs, tokens *utils.JWTManager, logger *zap.Logger, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(recoverUnary(logger), errorUnary(logger), authUnary(tokens)),
+	}, opts...)
+	server := grpc.NewServer(opts...)
+
+	weddingv1.RegisterWeddingServiceServer(server, &weddingServer{weddings: weddings})
+	weddingv1.RegisterGuestServiceServer(server, &guestServer{guests: guests})
+	weddingv1.RegisterRSVPServiceServer(server, &rsvpServer{rsvps: rsvps})
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	return server
+}
+
+// recoverUnary turns a panicking call into an internal error
+func recoverUnary(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				logger.Error("panic recovered",
+					zap.Any("error", recovered),
+					zap.String("method", info.FullMethod),
+					zap.String("stack", string(debug.Stack())),
+				)
+				err = status.Error(codes.Internal, internalErrorMessage)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// errorUnary answers domain errors with the status code of their kind and their
+// message, like the REST API's error middleware; any other error is logged and
+// answered as an internal error
+func errorUnary(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if _, ok := status.FromError(err); ok {
+			return resp, err
+		}
+
+		code := codeOf(err)
+		if code == codes.Internal {
+			logger.Error("gRPC call failed", zap.String("method", info.FullMethod), zap.Error(err))
+			return nil, status.Error(codes.Internal, internalErrorMessage)
+		}
+		return nil, status.Error(code, domainMessage(err))
+	}
+}
+
+// codeOf returns the status code of the kind of a domain error
+func codeOf(err error) codes.Code {
+	switch {
+	case errors.Is(err, errs.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, errs.ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, errs.ErrValidation):
+		return codes.InvalidArgument
+	case errors.Is(err, errs.ErrConflict):
+		return codes.AlreadyExists
+	case errors.Is(err, services.ErrPlanLimitExceeded):
+		return codes.FailedPrecondition
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+// domainMessage returns the message of the domain error in err's chain, without
+// the context it was wrapped in
+func domainMessage(err error) string {
+	var domainErr *errs.Error
+	var validationErr *errs.ValidationError
+	switch {
+	case errors.As(err, &domainErr):
+		return domainErr.Message
+	case errors.As(err, &validationErr):
+		return validationErr.Message
+	default:
+		return err.Error()
+	}
+}
+
+// parseID parses the ID in a field of a request
+func parseID(field, value string) (models.ID, error) {
+	id, err := models.ParseID(value)
+	if err != nil {
+		return models.NilID, errs.InvalidField(field, field+" is not a valid ID")
+	}
+	return id, nil
+}
+
+// pageParams returns the page and page size of a list request with the
+// defaults and limit of utils.ParsePaginationParams
+func pageParams(page, pageSize int32) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	} else if pageSize > 100 {
+		pageSize = 100
+	}
+	return int(page), int(pageSize)
+}
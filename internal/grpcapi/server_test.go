@@ -0,0 +1,235 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+	weddingv1 "wedding-invitation-backend/pkg/pb/wedding/v1"
+)
+
+// fakeServices answers the gRPC services from in-memory records, remembering
+// the last call they received
+type fakeServices struct {
+	weddings     map[models.ID]*models.Wedding
+	guest        *models.Guest
+	createErr    error
+	stats        *models.RSVPStatistics
+	statsErr     error
+	lastTenant   string
+	lastPage     [2]int
+	guestFilters repository.GuestFilters
+	created      *models.Guest
+}
+
+func (f *fakeServices) GetWeddingsByIDs(ctx context.Context, ids []models.ID, requestingUserID models.ID) (map[models.ID]*models.Wedding, error) {
+	f.lastTenant, _ = repository.TenantFromContext(ctx)
+	found := make(map[models.ID]*models.Wedding)
+	for _, id := range ids {
+		if wedding, ok := f.weddings[id]; ok && wedding.UserID == requestingUserID {
+			found[id] = wedding
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeServices) GetUserWeddings(ctx context.Context, userID models.ID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error) {
+	f.lastPage = [2]int{page, pageSize}
+	var weddings []*models.Wedding
+	for _, wedding := range f.weddings {
+		if wedding.UserID == userID {
+			weddings = append(weddings, wedding)
+		}
+	}
+	return weddings, int64(len(weddings)), nil
+}
+
+func (f *fakeServices) GetGuestByID(ctx context.Context, guestID, userID models.ID) (*models.Guest, error) {
+	if f.guest == nil || f.guest.ID != guestID {
+		return nil, repository.ErrNotFound
+	}
+	return f.guest, nil
+}
+
+func (f *fakeServices) ListGuests(ctx context.Context, weddingID, userID models.ID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error) {
+	f.lastPage = [2]int{page, pageSize}
+	f.guestFilters = filters
+	return []*models.Guest{f.guest}, 1, nil
+}
+
+func (f *fakeServices) CreateGuest(ctx context.Context, weddingID, userID models.ID, guest *models.Guest) error {
+	if f.createErr != nil {
+		return f.createErr
+	}
+	guest.ID = models.NewID()
+	guest.WeddingID = weddingID
+	f.created = guest
+	return nil
+}
+
+func (f *fakeServices) ListRSVPs(ctx context.Context, weddingID models.ID, userID models.ID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error) {
+	return []*models.RSVP{}, 0, nil
+}
+
+func (f *fakeServices) GetRSVPStatistics(ctx context.Context, weddingID models.ID, userID models.ID) (*models.RSVPStatistics, error) {
+	return f.stats, f.statsErr
+}
+
+func newTestClient(t *testing.T, fake *fakeServices, tokens *utils.JWTManager) *grpc.ClientConn {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(fake, fake, fake, tokens, zap.NewNop())
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func withToken(t *testing.T, tokens *utils.JWTManager, userID models.ID, tenantID string) context.Context {
+	t.Helper()
+	pair, err := tokens.GenerateSessionTokenPair(userID, "owner@example.com", nil, tenantID, "")
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+pair.AccessToken)
+}
+
+func newTestTokens() *utils.JWTManager {
+	return utils.NewJWTManager("access-secret", "refresh-secret", time.Minute, time.Hour, "test")
+}
+
+func TestServer_Authentication(t *testing.T) {
+	tokens := newTestTokens()
+	conn := newTestClient(t, &fakeServices{}, tokens)
+	client := weddingv1.NewWeddingServiceClient(conn)
+
+	_, err := client.ListWeddings(context.Background(), &weddingv1.ListWeddingsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer not-a-token")
+	_, err = client.ListWeddings(ctx, &weddingv1.ListWeddingsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	otherTokens := utils.NewJWTManager("other-secret", "refresh-secret", time.Minute, time.Hour, "test")
+	_, err = client.ListWeddings(withToken(t, otherTokens, models.NewID(), ""), &weddingv1.ListWeddingsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Load balancers check the health service without a token
+	health, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, health.Status)
+}
+
+func TestWeddingServer(t *testing.T) {
+	tokens := newTestTokens()
+	ownerID := models.NewID()
+	published := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	wedding := &models.Wedding{ID: models.NewID(), UserID: ownerID, Title: "Ours", Status: "published", PublishedAt: &published}
+	wedding.Couple.Partner1.FirstName = "Ann"
+	wedding.Couple.Partner1.LastName = "Lee"
+	fake := &fakeServices{weddings: map[models.ID]*models.Wedding{wedding.ID: wedding}}
+	client := weddingv1.NewWeddingServiceClient(newTestClient(t, fake, tokens))
+	ctx := withToken(t, tokens, ownerID, "org-1")
+
+	got, err := client.GetWedding(ctx, &weddingv1.GetWeddingRequest{WeddingId: wedding.ID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, "Ours", got.Title)
+	assert.Equal(t, "Ann Lee", got.Partner1Name)
+	assert.Equal(t, published, got.PublishedAt.AsTime())
+	assert.Nil(t, got.CreatedAt, "zero times are left unset")
+	assert.Equal(t, "org-1", fake.lastTenant, "the token's tenant scopes the call")
+
+	_, err = client.GetWedding(ctx, &weddingv1.GetWeddingRequest{WeddingId: models.NewID().String()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "wedding not found", status.Convert(err).Message())
+
+	_, err = client.GetWedding(ctx, &weddingv1.GetWeddingRequest{WeddingId: "nope"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	list, err := client.ListWeddings(ctx, &weddingv1.ListWeddingsRequest{PageSize: 500})
+	require.NoError(t, err)
+	assert.Len(t, list.Weddings, 1)
+	assert.Equal(t, int32(100), list.PageSize)
+	assert.Equal(t, [2]int{1, 100}, fake.lastPage)
+}
+
+func TestGuestServer(t *testing.T) {
+	tokens := newTestTokens()
+	userID := models.NewID()
+	guest := &models.Guest{ID: models.NewID(), WeddingID: models.NewID(), FirstName: "Ann", Tags: []string{"family"}}
+	fake := &fakeServices{guest: guest}
+	client := weddingv1.NewGuestServiceClient(newTestClient(t, fake, tokens))
+	ctx := withToken(t, tokens, userID, "")
+
+	got, err := client.GetGuest(ctx, &weddingv1.GetGuestRequest{GuestId: guest.ID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, "Ann", got.FirstName)
+	assert.Equal(t, []string{"family"}, got.Tags)
+
+	_, err = client.GetGuest(ctx, &weddingv1.GetGuestRequest{GuestId: models.NewID().String()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	list, err := client.ListGuests(ctx, &weddingv1.ListGuestsRequest{WeddingId: guest.WeddingID.String(), Page: 2, Side: "bride", Tag: "family"})
+	require.NoError(t, err)
+	assert.Len(t, list.Guests, 1)
+	assert.Equal(t, [2]int{2, 20}, fake.lastPage)
+	assert.Equal(t, repository.GuestFilters{Side: "bride", Tag: "family"}, fake.guestFilters)
+
+	created, err := client.CreateGuest(ctx, &weddingv1.CreateGuestRequest{WeddingId: guest.WeddingID.String(), FirstName: "Bo", LastName: "Kim"})
+	require.NoError(t, err)
+	assert.Equal(t, fake.created.ID.String(), created.Id)
+	assert.Equal(t, "pending", created.RsvpStatus)
+	assert.Equal(t, "digital", created.InvitedVia)
+
+	fake.createErr = services.ErrDuplicateGuestEmail
+	_, err = client.CreateGuest(ctx, &weddingv1.CreateGuestRequest{WeddingId: guest.WeddingID.String(), FirstName: "Bo", LastName: "Kim"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	fake.createErr = errors.New("connection refused")
+	_, err = client.CreateGuest(ctx, &weddingv1.CreateGuestRequest{WeddingId: guest.WeddingID.String(), FirstName: "Bo", LastName: "Kim"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, internalErrorMessage, status.Convert(err).Message(), "internal errors are not shown")
+}
+
+func TestRSVPServer(t *testing.T) {
+	tokens := newTestTokens()
+	fake := &fakeServices{stats: &models.RSVPStatistics{Attending: 3, DietaryCounts: map[string]int{"vegan": 2}}}
+	client := weddingv1.NewRSVPServiceClient(newTestClient(t, fake, tokens))
+	ctx := withToken(t, tokens, models.NewID(), "")
+
+	stats, err := client.GetRSVPStatistics(ctx, &weddingv1.GetRSVPStatisticsRequest{WeddingId: models.NewID().String()})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), stats.Attending)
+	assert.Equal(t, map[string]int32{"vegan": 2}, stats.DietaryCounts)
+
+	fake.statsErr = services.ErrWeddingAccessDenied
+	_, err = client.GetRSVPStatistics(ctx, &weddingv1.GetRSVPStatisticsRequest{WeddingId: models.NewID().String()})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	list, err := client.ListRSVPs(ctx, &weddingv1.ListRSVPsRequest{WeddingId: models.NewID().String()})
+	require.NoError(t, err)
+	assert.Empty(t, list.Rsvps)
+}
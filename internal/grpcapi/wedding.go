@@ -0,0 +1,62 @@
+package grpcapi
+
+import (
+	"context"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+	weddingv1 "wedding-invitation-backend/pkg/pb/wedding/v1"
+)
+
+type weddingServer struct {
+	weddingv1.UnimplementedWeddingServiceServer
+	weddings Weddings
+}
+
+// GetWedding looks the wedding up like the dashboard does, so that reads by
+// other services are not counted as views
+func (s *weddingServer) GetWedding(ctx context.Context, req *weddingv1.GetWeddingRequest) (*weddingv1.Wedding, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	weddingID, err := parseID("wedding_id", req.GetWeddingId())
+	if err != nil {
+		return nil, err
+	}
+
+	weddings, err := s.weddings.GetWeddingsByIDs(ctx, []models.ID{weddingID}, userID)
+	if err != nil {
+		return nil, err
+	}
+	wedding, found := weddings[weddingID]
+	if !found {
+		return nil, services.ErrWeddingNotFound
+	}
+	return weddingToProto(wedding), nil
+}
+
+func (s *weddingServer) ListWeddings(ctx context.Context, req *weddingv1.ListWeddingsRequest) (*weddingv1.ListWeddingsResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page, pageSize := pageParams(req.GetPage(), req.GetPageSize())
+	filters := repository.WeddingFilters{Status: req.GetStatus(), Search: req.GetSearch()}
+
+	weddings, total, err := s.weddings.GetUserWeddings(ctx, userID, page, pageSize, filters)
+	if err != nil {
+		return nil, err
+	}
+	resp := &weddingv1.ListWeddingsResponse{
+		Weddings: make([]*weddingv1.Wedding, 0, len(weddings)),
+		Total:    total,
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}
+	for _, wedding := range weddings {
+		resp.Weddings = append(resp.Weddings, weddingToProto(wedding))
+	}
+	return resp, nil
+}
@@ -0,0 +1,515 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// AccountHandler exposes registration, login and password management backed by the
+// JWT auth service
+type AccountHandler struct {
+	authService services.AuthService
+	cookies     *AuthCookieConfig
+}
+
+// AuthCookieConfig configures the cookies issued tokens are returned in
+type AuthCookieConfig struct {
+	Secure      bool          // Only send the cookies over HTTPS
+	RefreshTTL  time.Duration // Lifetime of the refresh token cookie
+	RefreshPath string        // Path the refresh token cookie is sent to, e.g. /api/v1/auth
+}
+
+// NewAccountHandler creates a new account handler
+func NewAccountHandler(authService services.AuthService) *AccountHandler {
+	return &AccountHandler{
+		authService: authService,
+	}
+}
+
+// EnableAuthCookies also returns issued tokens as HttpOnly cookies, so browser clients
+// are authenticated by the access_token cookie and refresh with the refresh_token cookie
+func (h *AccountHandler) EnableAuthCookies(cfg AuthCookieConfig) {
+	h.cookies = &cfg
+}
+
+// RefreshTokenRequest carries the refresh token to exchange for a new token pair.
+// It may be omitted when the refresh token is sent as a cookie.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// Register godoc
+// @Summary Register a new account
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param user body services.RegisterRequest true "Registration data"
+// @Success 201 {object} services.AuthResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/register [post]
+func (h *AccountHandler) Register(c *gin.Context) {
+	var req services.RegisterRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	resp, err := h.authService.Register(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrEmailAlreadyExists):
+			utils.ErrorResponse(c, http.StatusConflict, "Email already registered")
+		case errors.Is(err, services.ErrInvalidPassword):
+			utils.ErrorResponse(c, http.StatusBadRequest, "Password does not meet requirements")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to register account")
+		}
+		return
+	}
+
+	h.setTokenCookies(c, resp)
+	utils.Response(c, http.StatusCreated, resp)
+}
+
+// Login godoc
+// @Summary Log in
+// @Description Returns tokens, or for accounts with two-factor authentication two_factor_required and a two_factor_token to complete the login with at /auth/2fa/login
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body services.LoginRequest true "Login credentials"
+// @Success 200 {object} services.AuthResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/login [post]
+func (h *AccountHandler) Login(c *gin.Context) {
+	var req services.LoginRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	resp, err := h.authService.Login(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUserNotFound), errors.Is(err, services.ErrInvalidCredentials):
+			// Do not reveal whether the email is registered
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid email or password")
+		case errors.Is(err, services.ErrAccountDisabled):
+			utils.ErrorResponse(c, http.StatusForbidden, "Account is disabled")
+		case errors.Is(err, services.ErrAccountNotVerified):
+			utils.ErrorResponse(c, http.StatusForbidden, "Account is not verified")
+		case errors.Is(err, services.ErrAccountLocked):
+			utils.ErrorResponse(c, http.StatusLocked, "Account is temporarily locked after too many failed logins. Check your email for an unlock link.")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to log in")
+		}
+		return
+	}
+
+	h.setTokenCookies(c, resp)
+	utils.Response(c, http.StatusOK, resp)
+}
+
+// CompleteTwoFactorLogin godoc
+// @Summary Complete a two-factor login
+// @Description Exchange the two_factor_token returned by login and a code from the authenticator app or a backup code for tokens. Five wrong codes end the login.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body services.TwoFactorLoginRequest true "Second factor"
+// @Success 200 {object} services.AuthResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/2fa/login [post]
+func (h *AccountHandler) CompleteTwoFactorLogin(c *gin.Context) {
+	var req services.TwoFactorLoginRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	resp, err := h.authService.CompleteTwoFactorLogin(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidTwoFactorCode):
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid two-factor code")
+		case errors.Is(err, services.ErrTwoFactorLoginExpired):
+			utils.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+		case errors.Is(err, services.ErrAccountDisabled):
+			utils.ErrorResponse(c, http.StatusForbidden, "Account is disabled")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to log in")
+		}
+		return
+	}
+
+	h.setTokenCookies(c, resp)
+	utils.Response(c, http.StatusOK, resp)
+}
+
+// OAuthLogin godoc
+// @Summary Sign in with Google or Apple
+// @Description Exchange the authorization code from the provider's consent screen for tokens. First-time users get an account; an existing account with the same email is linked when the provider verified the email. Accounts with two-factor authentication get a two_factor_token as with login.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param provider path string true "google or apple"
+// @Param request body services.OAuthLoginRequest true "Authorization code"
+// @Success 200 {object} services.AuthResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/oauth/{provider} [post]
+func (h *AccountHandler) OAuthLogin(c *gin.Context) {
+	var req services.OAuthLoginRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	resp, err := h.authService.OAuthLogin(c.Request.Context(), models.AuthProvider(c.Param("provider")), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOAuthProviderNotSupported):
+			utils.ErrorResponse(c, http.StatusNotFound, "Sign-in provider is not supported")
+		case errors.Is(err, services.ErrOAuthExchangeFailed):
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Sign-in with the provider failed")
+		case errors.Is(err, services.ErrOAuthEmailNotVerified):
+			utils.ErrorResponse(c, http.StatusForbidden, "The provider has not verified your email")
+		case errors.Is(err, services.ErrEmailAlreadyExists):
+			utils.ErrorResponse(c, http.StatusConflict, "Email is linked to another account at the provider")
+		case errors.Is(err, services.ErrAccountDisabled):
+			utils.ErrorResponse(c, http.StatusForbidden, "Account is disabled")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to sign in")
+		}
+		return
+	}
+
+	h.setTokenCookies(c, resp)
+	utils.Response(c, http.StatusOK, resp)
+}
+
+// RefreshToken godoc
+// @Summary Refresh tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} services.AuthResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/auth/refresh [post]
+func (h *AccountHandler) RefreshToken(c *gin.Context) {
+	var req RefreshTokenRequest
+	if c.Request.ContentLength != 0 && !bindAndValidate(c, &req) {
+		return
+	}
+	if req.RefreshToken == "" {
+		req.RefreshToken, _ = c.Cookie(refreshTokenCookie)
+	}
+	if req.RefreshToken == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Refresh token is required")
+		return
+	}
+
+	resp, err := h.authService.RefreshToken(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrAccountDisabled):
+			utils.ErrorResponse(c, http.StatusForbidden, "Account is disabled")
+		default:
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid refresh token")
+		}
+		return
+	}
+
+	h.setTokenCookies(c, resp)
+	utils.Response(c, http.StatusOK, resp)
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description End the current session and clear the auth cookies. The access token stays valid until it expires.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v1/auth/logout [post]
+func (h *AccountHandler) Logout(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	if err := h.authService.Logout(c.Request.Context(), userID.String(), c.GetString("deviceID")); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to log out")
+		return
+	}
+
+	h.clearTokenCookies(c)
+	utils.SuccessResponse(c, "Logged out")
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Always succeeds so the response does not reveal whether the email is registered
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body services.PasswordResetRequest true "Account email"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/auth/forgot-password [post]
+func (h *AccountHandler) ForgotPassword(c *gin.Context) {
+	var req services.PasswordResetRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	// The reset token is delivered out of band and never returned to the caller
+	_, _ = h.authService.ForgotPassword(c.Request.Context(), req.Email)
+
+	utils.SuccessResponse(c, "If the email is registered, a password reset link has been sent")
+}
+
+// ResetPassword godoc
+// @Summary Reset a password
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body services.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/reset-password [post]
+func (h *AccountHandler) ResetPassword(c *gin.Context) {
+	var req services.ResetPasswordRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	if err := h.authService.ResetPassword(c.Request.Context(), req); err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidPassword):
+			utils.ErrorResponse(c, http.StatusBadRequest, "Password does not meet requirements")
+		case errors.Is(err, services.ErrUserNotFound):
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid or expired reset token")
+		default:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Failed to reset password: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, "Password has been reset")
+}
+
+// UnlockAccount godoc
+// @Summary Unlock an account
+// @Description Lift the lockout after too many failed logins with the token from the emailed unlock link
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body services.UnlockAccountRequest true "Unlock token"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/unlock [post]
+func (h *AccountHandler) UnlockAccount(c *gin.Context) {
+	var req services.UnlockAccountRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	if err := h.authService.UnlockAccount(c.Request.Context(), req.Token); err != nil {
+		if errors.Is(err, services.ErrInvalidUnlockToken) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid or used unlock link")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to unlock account")
+		return
+	}
+
+	utils.SuccessResponse(c, "Account unlocked")
+}
+
+// VerifyEmail godoc
+// @Summary Verify an email address
+// @Tags auth
+// @Produce json
+// @Param token query string true "Verification token"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/auth/verify-email [get]
+func (h *AccountHandler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Verification token is required")
+		return
+	}
+
+	if err := h.authService.VerifyEmail(c.Request.Context(), token); err != nil {
+		if errors.Is(err, services.ErrVerificationTokenExpired) {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Verification link has expired, request a new one")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid verification token")
+		return
+	}
+
+	utils.SuccessResponse(c, "Email verified")
+}
+
+// ResendVerification godoc
+// @Summary Resend the email verification link
+// @Description Emails an unverified account a new verification link, invalidating the previous one. Always succeeds so the response does not reveal whether the email is registered or verified; accounts get at most one link a minute.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body services.ResendVerificationRequest true "Account email"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /api/v1/auth/resend-verification [post]
+func (h *AccountHandler) ResendVerification(c *gin.Context) {
+	var req services.ResendVerificationRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	// Too frequent requests are ignored like unknown addresses, which would be revealed otherwise
+	_ = h.authService.ResendVerification(c.Request.Context(), req.Email)
+
+	utils.SuccessResponse(c, "If the email is registered and not yet verified, a new verification link has been sent")
+}
+
+// ChangePassword godoc
+// @Summary Change password
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body services.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/change-password [post]
+func (h *AccountHandler) ChangePassword(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req services.ChangePasswordRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	if err := h.authService.ChangePassword(c.Request.Context(), userID, req); err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidCredentials):
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Current password is incorrect")
+		case errors.Is(err, services.ErrInvalidPassword):
+			utils.ErrorResponse(c, http.StatusBadRequest, "Password does not meet requirements")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to change password")
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, "Password changed")
+}
+
+// Me godoc
+// @Summary Get the authenticated account
+// @Tags auth
+// @Produce json
+// @Success 200 {object} models.User
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/auth/me [get]
+func (h *AccountHandler) Me(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	user, err := h.authService.GetProfile(c.Request.Context(), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "User not found")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, user)
+}
+
+const (
+	accessTokenCookie  = "access_token"
+	refreshTokenCookie = "refresh_token"
+)
+
+// setTokenCookies returns the issued tokens as cookies when auth cookies are enabled
+func (h *AccountHandler) setTokenCookies(c *gin.Context, resp *services.AuthResponse) {
+	// Logins waiting for a second factor have no tokens yet
+	if h.cookies == nil || resp.AccessToken == "" {
+		return
+	}
+
+	accessMaxAge := int(time.Until(resp.ExpiresAt).Seconds())
+	h.writeCookie(c, accessTokenCookie, resp.AccessToken, "/", accessMaxAge)
+	h.writeCookie(c, refreshTokenCookie, resp.RefreshToken, h.cookies.RefreshPath, int(h.cookies.RefreshTTL.Seconds()))
+}
+
+// clearTokenCookies removes the auth cookies when auth cookies are enabled
+func (h *AccountHandler) clearTokenCookies(c *gin.Context) {
+	if h.cookies == nil {
+		return
+	}
+
+	h.writeCookie(c, accessTokenCookie, "", "/", -1)
+	h.writeCookie(c, refreshTokenCookie, "", h.cookies.RefreshPath, -1)
+}
+
+func (h *AccountHandler) writeCookie(c *gin.Context, name, value, path string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   h.cookies.Secure,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// bindAndValidate binds the JSON body into req and validates it, answering 400 on failure
+func bindAndValidate(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return false
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
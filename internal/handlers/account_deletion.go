@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// AccountDeletionManager deletes accounts after an emailed confirmation and a grace period
+type AccountDeletionManager interface {
+	RequestDeletion(ctx context.Context, userID models.ID) (*models.AccountDeletion, error)
+	ConfirmDeletion(ctx context.Context, token string) (*models.AccountDeletion, error)
+	CancelDeletion(ctx context.Context, userID models.ID) error
+}
+
+// ConfirmAccountDeletionRequest confirms a deletion with the token of the emailed link
+type ConfirmAccountDeletionRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// AccountDeletionHandler lets users delete their own account
+type AccountDeletionHandler struct {
+	deletions AccountDeletionManager
+}
+
+// NewAccountDeletionHandler creates a new account deletion handler
+func NewAccountDeletionHandler(deletions AccountDeletionManager) *AccountDeletionHandler {
+	return &AccountDeletionHandler{deletions: deletions}
+}
+
+// RequestDeletion godoc
+// @Summary Delete my account
+// @Description Emails a link to confirm deleting the current account. Once confirmed, the account and its weddings, guests, RSVPs, photos and analytics are erased after the grace period; until then the deletion can be cancelled.
+// @Tags users
+// @Produce json
+// @Success 202 {object} models.AccountDeletion
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/me [delete]
+func (h *AccountDeletionHandler) RequestDeletion(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	deletion, err := h.deletions.RequestDeletion(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to request account deletion")
+		return
+	}
+
+	utils.Response(c, http.StatusAccepted, deletion)
+}
+
+// ConfirmDeletion godoc
+// @Summary Confirm deleting an account
+// @Description Confirms the deletion the emailed link was sent for and signs the account out everywhere. The account is erased once the returned scheduled_for has passed.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ConfirmAccountDeletionRequest true "Token from the emailed link"
+// @Success 200 {object} models.AccountDeletion
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/confirm-deletion [post]
+func (h *AccountDeletionHandler) ConfirmDeletion(c *gin.Context) {
+	var req ConfirmAccountDeletionRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	deletion, err := h.deletions.ConfirmDeletion(c.Request.Context(), req.Token)
+	if err != nil {
+		h.handleError(c, err, "Failed to confirm account deletion")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, deletion)
+}
+
+// CancelDeletion godoc
+// @Summary Cancel deleting my account
+// @Description Withdraws a pending or confirmed deletion of the current account before it is erased
+// @Tags users
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/me/deletion [delete]
+func (h *AccountDeletionHandler) CancelDeletion(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	if err := h.deletions.CancelDeletion(c.Request.Context(), userID); err != nil {
+		h.handleError(c, err, "Failed to cancel account deletion")
+		return
+	}
+
+	utils.SuccessResponse(c, "Account deletion cancelled")
+}
+
+func (h *AccountDeletionHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "User not found")
+	case errors.Is(err, services.ErrAccountDeletionNotRequested):
+		utils.ErrorResponse(c, http.StatusNotFound, "No account deletion was requested")
+	case errors.Is(err, services.ErrAccountDeletionScheduled):
+		utils.ErrorResponse(c, http.StatusConflict, "Account deletion is already scheduled; cancel it first")
+	case errors.Is(err, services.ErrInvalidDeletionToken):
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid or expired account deletion link")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
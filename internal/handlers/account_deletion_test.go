@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockAccountDeletionManager keeps one deletion per user and accepts the token "valid"
+type MockAccountDeletionManager struct {
+	deletions map[models.ID]*models.AccountDeletion
+	requester models.ID
+}
+
+func (m *MockAccountDeletionManager) RequestDeletion(ctx context.Context, userID models.ID) (*models.AccountDeletion, error) {
+	if m.deletions[userID].Confirmed() {
+		return nil, services.ErrAccountDeletionScheduled
+	}
+	deletion := &models.AccountDeletion{RequestedAt: time.Now()}
+	m.deletions[userID] = deletion
+	m.requester = userID
+	return deletion, nil
+}
+
+func (m *MockAccountDeletionManager) ConfirmDeletion(ctx context.Context, token string) (*models.AccountDeletion, error) {
+	deletion := m.deletions[m.requester]
+	if token != "valid" || deletion == nil {
+		return nil, services.ErrInvalidDeletionToken
+	}
+	now := time.Now()
+	scheduledFor := now.Add(14 * 24 * time.Hour)
+	deletion.ConfirmedAt = &now
+	deletion.ScheduledFor = &scheduledFor
+	return deletion, nil
+}
+
+func (m *MockAccountDeletionManager) CancelDeletion(ctx context.Context, userID models.ID) error {
+	if m.deletions[userID] == nil {
+		return services.ErrAccountDeletionNotRequested
+	}
+	delete(m.deletions, userID)
+	return nil
+}
+
+func setupAccountDeletionRouter(manager AccountDeletionManager, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewAccountDeletionHandler(manager)
+	router.POST("/auth/confirm-deletion", handler.ConfirmDeletion)
+	users := router.Group("/users", func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	users.DELETE("/me", handler.RequestDeletion)
+	users.DELETE("/me/deletion", handler.CancelDeletion)
+	return router
+}
+
+func sendAccountDeletionRequest(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAccountDeletionHandler(t *testing.T) {
+	userID := models.NewID()
+	manager := &MockAccountDeletionManager{deletions: make(map[models.ID]*models.AccountDeletion)}
+	router := setupAccountDeletionRouter(manager, userID)
+
+	w := sendAccountDeletionRequest(router, http.MethodDelete, "/users/me/deletion", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = sendAccountDeletionRequest(router, http.MethodDelete, "/users/me", "")
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Contains(t, manager.deletions, userID)
+
+	w = sendAccountDeletionRequest(router, http.MethodPost, "/auth/confirm-deletion", `{}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = sendAccountDeletionRequest(router, http.MethodPost, "/auth/confirm-deletion", `{"token":"forged"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = sendAccountDeletionRequest(router, http.MethodPost, "/auth/confirm-deletion", `{"token":"valid"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data models.AccountDeletion `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Data.ScheduledFor)
+
+	w = sendAccountDeletionRequest(router, http.MethodDelete, "/users/me", "")
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = sendAccountDeletionRequest(router, http.MethodDelete, "/users/me/deletion", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, manager.deletions, userID)
+}
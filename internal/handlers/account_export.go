@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// AccountExportHandler lets users download all of their data
+type AccountExportHandler struct {
+	exports services.AccountExporter
+}
+
+// NewAccountExportHandler creates a new account export handler
+func NewAccountExportHandler(exports services.AccountExporter) *AccountExportHandler {
+	return &AccountExportHandler{exports: exports}
+}
+
+// ExportAccount godoc
+// @Summary Export my data
+// @Description Queues a zip archive of the current user's profile, weddings with their guests, RSVPs and wishes, and uploaded media with the files, as JSON and CSV. Poll the returned export until it is completed, then download it.
+// @Tags users
+// @Produce json
+// @Success 202 {object} models.ExportJob
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/me/export [post]
+func (h *AccountExportHandler) ExportAccount(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	job, err := h.exports.QueueExport(c.Request.Context(), userID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	utils.Response(c, http.StatusAccepted, job)
+}
+
+// GetAccountExport godoc
+// @Summary Get an export of my data
+// @Description Get the status of an export of the current user's data
+// @Tags users
+// @Produce json
+// @Param id path string true "Export job ID"
+// @Success 200 {object} models.ExportJob
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/me/export/{id} [get]
+func (h *AccountExportHandler) GetAccountExport(c *gin.Context) {
+	userID, jobID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.exports.GetExport(c.Request.Context(), userID, jobID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, job)
+}
+
+// DownloadAccountExport godoc
+// @Summary Download an export of my data
+// @Description Redirect to a short-lived signed link to a completed export of the current user's data
+// @Tags users
+// @Param id path string true "Export job ID"
+// @Success 302
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/me/export/{id}/download [get]
+func (h *AccountExportHandler) DownloadAccountExport(c *gin.Context) {
+	userID, jobID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	url, err := h.exports.DownloadURL(c.Request.Context(), userID, jobID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.Redirect(http.StatusFound, url)
+}
+
+func (h *AccountExportHandler) parseRequest(c *gin.Context) (models.ID, models.ID, bool) {
+	jobID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid export job ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+	return userID, jobID, true
+}
+
+func (h *AccountExportHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "User not found")
+	case errors.Is(err, services.ErrExportJobNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Export not found")
+	case errors.Is(err, services.ErrExportNotReady):
+		utils.ErrorResponse(c, http.StatusConflict, "Export is not ready for download")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to export account data")
+	}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockAccountExporter keeps exports in memory; they complete when marked so
+type MockAccountExporter struct {
+	jobs map[models.ID]*models.ExportJob
+}
+
+func (m *MockAccountExporter) QueueExport(ctx context.Context, userID models.ID) (*models.ExportJob, error) {
+	job := &models.ExportJob{ID: models.NewID(), UserID: userID, Resource: services.AccountExportResource, Status: models.ExportJobQueued}
+	m.jobs[job.ID] = job
+	return job, nil
+}
+
+func (m *MockAccountExporter) GetExport(ctx context.Context, userID, jobID models.ID) (*models.ExportJob, error) {
+	job, ok := m.jobs[jobID]
+	if !ok || job.UserID != userID {
+		return nil, services.ErrExportJobNotFound
+	}
+	return job, nil
+}
+
+func (m *MockAccountExporter) DownloadURL(ctx context.Context, userID, jobID models.ID) (string, error) {
+	job, err := m.GetExport(ctx, userID, jobID)
+	if err != nil {
+		return "", err
+	}
+	if !job.HasFile() {
+		return "", services.ErrExportNotReady
+	}
+	return "https://cdn.example.com/" + job.FileKey, nil
+}
+
+func setupAccountExportRouter(exporter services.AccountExporter, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewAccountExportHandler(exporter)
+	users := router.Group("/users", func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	users.POST("/me/export", handler.ExportAccount)
+	users.GET("/me/export/:id", handler.GetAccountExport)
+	users.GET("/me/export/:id/download", handler.DownloadAccountExport)
+	return router
+}
+
+func sendAccountExportRequest(router *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAccountExportHandler(t *testing.T) {
+	userID := models.NewID()
+	exporter := &MockAccountExporter{jobs: make(map[models.ID]*models.ExportJob)}
+	router := setupAccountExportRouter(exporter, userID)
+
+	w := sendAccountExportRequest(router, http.MethodPost, "/users/me/export")
+	require.Equal(t, http.StatusAccepted, w.Code)
+	var resp struct {
+		Data models.ExportJob `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, models.ExportJobQueued, resp.Data.Status)
+	path := "/users/me/export/" + resp.Data.ID.String()
+
+	w = sendAccountExportRequest(router, http.MethodGet, path+"/download")
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	job := exporter.jobs[resp.Data.ID]
+	job.Status = models.ExportJobCompleted
+	job.FileKey = "exports/account/takeout.zip"
+
+	w = sendAccountExportRequest(router, http.MethodGet, path)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, models.ExportJobCompleted, resp.Data.Status)
+	assert.NotContains(t, w.Body.String(), "takeout.zip", "the storage key is never exposed")
+
+	w = sendAccountExportRequest(router, http.MethodGet, path+"/download")
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://cdn.example.com/exports/account/takeout.zip", w.Header().Get("Location"))
+
+	w = sendAccountExportRequest(router, http.MethodGet, "/users/me/export/"+models.NewID().String())
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = sendAccountExportRequest(router, http.MethodGet, "/users/me/export/not-an-id")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,428 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockAuthService for testing the account handler
+type MockAuthService struct {
+	mock.Mock
+}
+
+func (m *MockAuthService) Register(ctx context.Context, req services.RegisterRequest) (*services.AuthResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.AuthResponse), args.Error(1)
+}
+
+func (m *MockAuthService) Login(ctx context.Context, req services.LoginRequest) (*services.AuthResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.AuthResponse), args.Error(1)
+}
+
+func (m *MockAuthService) CompleteTwoFactorLogin(ctx context.Context, req services.TwoFactorLoginRequest) (*services.AuthResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.AuthResponse), args.Error(1)
+}
+
+func (m *MockAuthService) OAuthLogin(ctx context.Context, provider models.AuthProvider, req services.OAuthLoginRequest) (*services.AuthResponse, error) {
+	args := m.Called(ctx, provider, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.AuthResponse), args.Error(1)
+}
+
+func (m *MockAuthService) RefreshToken(ctx context.Context, refreshToken string) (*services.AuthResponse, error) {
+	args := m.Called(ctx, refreshToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.AuthResponse), args.Error(1)
+}
+
+func (m *MockAuthService) Logout(ctx context.Context, userID string, sessionID string) error {
+	return m.Called(ctx, userID, sessionID).Error(0)
+}
+
+func (m *MockAuthService) ChangePassword(ctx context.Context, userID models.ID, req services.ChangePasswordRequest) error {
+	return m.Called(ctx, userID, req).Error(0)
+}
+
+func (m *MockAuthService) ForgotPassword(ctx context.Context, email string) (*services.PasswordResetResponse, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.PasswordResetResponse), args.Error(1)
+}
+
+func (m *MockAuthService) ResetPassword(ctx context.Context, req services.ResetPasswordRequest) error {
+	return m.Called(ctx, req).Error(0)
+}
+
+func (m *MockAuthService) VerifyEmail(ctx context.Context, token string) error {
+	return m.Called(ctx, token).Error(0)
+}
+
+func (m *MockAuthService) ResendVerification(ctx context.Context, email string) error {
+	return m.Called(ctx, email).Error(0)
+}
+
+func (m *MockAuthService) UnlockAccount(ctx context.Context, token string) error {
+	return m.Called(ctx, token).Error(0)
+}
+
+func (m *MockAuthService) GetProfile(ctx context.Context, userID models.ID) (*models.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func setupAccountRouter(authService services.AuthService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewAccountHandler(authService)
+
+	router.POST("/auth/register", handler.Register)
+	router.POST("/auth/login", handler.Login)
+	router.POST("/auth/2fa/login", handler.CompleteTwoFactorLogin)
+	router.POST("/auth/oauth/:provider", handler.OAuthLogin)
+	router.POST("/auth/forgot-password", handler.ForgotPassword)
+	router.POST("/auth/unlock", handler.UnlockAccount)
+	router.GET("/auth/verify-email", handler.VerifyEmail)
+	router.POST("/auth/resend-verification", handler.ResendVerification)
+	return router
+}
+
+func postJSON(router *gin.Engine, path string, body interface{}) *httptest.ResponseRecorder {
+	data, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAccountHandler_Register(t *testing.T) {
+	registerReq := services.RegisterRequest{
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Email:     "jane@example.com",
+		Password:  "Str0ng!Passw0rd",
+	}
+
+	t.Run("created", func(t *testing.T) {
+		authService := new(MockAuthService)
+		authService.On("Register", mock.Anything, registerReq).Return(&services.AuthResponse{
+			User:        &models.User{Email: registerReq.Email},
+			AccessToken: "access",
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}, nil)
+
+		w := postJSON(setupAccountRouter(authService), "/auth/register", registerReq)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"access_token":"access"`)
+		authService.AssertExpectations(t)
+	})
+
+	t.Run("email taken", func(t *testing.T) {
+		authService := new(MockAuthService)
+		authService.On("Register", mock.Anything, registerReq).Return(nil, services.ErrEmailAlreadyExists)
+
+		w := postJSON(setupAccountRouter(authService), "/auth/register", registerReq)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		authService := new(MockAuthService)
+
+		w := postJSON(setupAccountRouter(authService), "/auth/register", map[string]string{"email": "not-an-email"})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		authService.AssertNotCalled(t, "Register", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccountHandler_Login(t *testing.T) {
+	loginReq := services.LoginRequest{Email: "jane@example.com", Password: "secret"}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unknown email", services.ErrUserNotFound, http.StatusUnauthorized},
+		{"wrong password", services.ErrInvalidCredentials, http.StatusUnauthorized},
+		{"disabled", services.ErrAccountDisabled, http.StatusForbidden},
+		{"not verified", services.ErrAccountNotVerified, http.StatusForbidden},
+		{"locked", services.ErrAccountLocked, http.StatusLocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authService := new(MockAuthService)
+			authService.On("Login", mock.Anything, loginReq).Return(nil, tt.err)
+
+			w := postJSON(setupAccountRouter(authService), "/auth/login", loginReq)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestAccountHandler_AuthCookies(t *testing.T) {
+	loginReq := services.LoginRequest{Email: "jane@example.com", Password: "secret"}
+	resp := &services.AuthResponse{
+		User:         &models.User{Email: loginReq.Email},
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ExpiresAt:    time.Now().Add(15 * time.Minute),
+	}
+
+	setup := func(authService services.AuthService) *gin.Engine {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		handler := NewAccountHandler(authService)
+		handler.EnableAuthCookies(AuthCookieConfig{Secure: true, RefreshTTL: time.Hour, RefreshPath: "/auth"})
+		router.POST("/auth/login", handler.Login)
+		router.POST("/auth/refresh", handler.RefreshToken)
+		return router
+	}
+
+	cookiesByName := func(w *httptest.ResponseRecorder) map[string]*http.Cookie {
+		cookies := make(map[string]*http.Cookie)
+		for _, cookie := range w.Result().Cookies() {
+			cookies[cookie.Name] = cookie
+		}
+		return cookies
+	}
+
+	t.Run("login sets the token cookies", func(t *testing.T) {
+		authService := new(MockAuthService)
+		authService.On("Login", mock.Anything, loginReq).Return(resp, nil)
+
+		w := postJSON(setup(authService), "/auth/login", loginReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		cookies := cookiesByName(w)
+		if assert.Contains(t, cookies, "access_token") {
+			assert.Equal(t, "access", cookies["access_token"].Value)
+			assert.True(t, cookies["access_token"].HttpOnly)
+			assert.True(t, cookies["access_token"].Secure)
+		}
+		if assert.Contains(t, cookies, "refresh_token") {
+			assert.Equal(t, "/auth", cookies["refresh_token"].Path)
+			assert.Equal(t, 3600, cookies["refresh_token"].MaxAge)
+		}
+	})
+
+	t.Run("refresh reads the refresh token cookie", func(t *testing.T) {
+		authService := new(MockAuthService)
+		authService.On("RefreshToken", mock.Anything, "refresh").Return(resp, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "refresh"})
+		w := httptest.NewRecorder()
+		setup(authService).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, cookiesByName(w), "access_token")
+		authService.AssertExpectations(t)
+	})
+
+	t.Run("refresh without a token", func(t *testing.T) {
+		authService := new(MockAuthService)
+
+		w := httptest.NewRecorder()
+		setup(authService).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/auth/refresh", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		authService.AssertNotCalled(t, "RefreshToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("logins waiting for a second factor set no cookies", func(t *testing.T) {
+		authService := new(MockAuthService)
+		authService.On("Login", mock.Anything, loginReq).Return(&services.AuthResponse{
+			TwoFactorRequired: true,
+			TwoFactorToken:    "pending",
+			ExpiresAt:         time.Now().Add(5 * time.Minute),
+		}, nil)
+
+		w := postJSON(setup(authService), "/auth/login", loginReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Result().Cookies())
+		assert.Contains(t, w.Body.String(), `"two_factor_required":true`)
+		assert.NotContains(t, w.Body.String(), "access_token")
+	})
+
+	t.Run("no cookies unless enabled", func(t *testing.T) {
+		authService := new(MockAuthService)
+		authService.On("Login", mock.Anything, loginReq).Return(resp, nil)
+
+		w := postJSON(setupAccountRouter(authService), "/auth/login", loginReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Result().Cookies())
+	})
+}
+
+func TestAccountHandler_CompleteTwoFactorLogin(t *testing.T) {
+	req := services.TwoFactorLoginRequest{TwoFactorToken: "pending", Code: "123456"}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"wrong code", services.ErrInvalidTwoFactorCode, http.StatusUnauthorized},
+		{"expired", services.ErrTwoFactorLoginExpired, http.StatusUnauthorized},
+		{"disabled", services.ErrAccountDisabled, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authService := new(MockAuthService)
+			if tt.err != nil {
+				authService.On("CompleteTwoFactorLogin", mock.Anything, req).Return(nil, tt.err)
+			} else {
+				authService.On("CompleteTwoFactorLogin", mock.Anything, req).Return(&services.AuthResponse{AccessToken: "access"}, nil)
+			}
+
+			w := postJSON(setupAccountRouter(authService), "/auth/2fa/login", req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+
+	t.Run("code is required", func(t *testing.T) {
+		w := postJSON(setupAccountRouter(new(MockAuthService)), "/auth/2fa/login", map[string]string{"two_factor_token": "pending"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAccountHandler_OAuthLogin(t *testing.T) {
+	req := services.OAuthLoginRequest{Code: "code", RedirectURI: "https://app.example.com/callback"}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"unknown provider", services.ErrOAuthProviderNotSupported, http.StatusNotFound},
+		{"rejected code", fmt.Errorf("%w: invalid_grant", services.ErrOAuthExchangeFailed), http.StatusUnauthorized},
+		{"unverified email", services.ErrOAuthEmailNotVerified, http.StatusForbidden},
+		{"email linked elsewhere", services.ErrEmailAlreadyExists, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authService := new(MockAuthService)
+			if tt.err != nil {
+				authService.On("OAuthLogin", mock.Anything, models.AuthProviderGoogle, req).Return(nil, tt.err)
+			} else {
+				authService.On("OAuthLogin", mock.Anything, models.AuthProviderGoogle, req).Return(&services.AuthResponse{AccessToken: "access"}, nil)
+			}
+
+			w := postJSON(setupAccountRouter(authService), "/auth/oauth/google", req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+
+	t.Run("redirect URI is required", func(t *testing.T) {
+		w := postJSON(setupAccountRouter(new(MockAuthService)), "/auth/oauth/google", map[string]string{"code": "code"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAccountHandler_ForgotPassword_DoesNotLeakToken(t *testing.T) {
+	authService := new(MockAuthService)
+	authService.On("ForgotPassword", mock.Anything, "jane@example.com").Return(&services.PasswordResetResponse{
+		Token:     "reset-token",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	authService.On("ForgotPassword", mock.Anything, "nobody@example.com").Return(nil, services.ErrUserNotFound)
+
+	router := setupAccountRouter(authService)
+
+	known := postJSON(router, "/auth/forgot-password", map[string]string{"email": "jane@example.com"})
+	unknown := postJSON(router, "/auth/forgot-password", map[string]string{"email": "nobody@example.com"})
+
+	assert.Equal(t, http.StatusOK, known.Code)
+	assert.NotContains(t, known.Body.String(), "reset-token")
+	assert.Equal(t, known.Body.String(), unknown.Body.String())
+}
+
+func TestAccountHandler_UnlockAccount(t *testing.T) {
+	authService := new(MockAuthService)
+	authService.On("UnlockAccount", mock.Anything, "good-token").Return(nil)
+	authService.On("UnlockAccount", mock.Anything, "used-token").Return(services.ErrInvalidUnlockToken)
+	router := setupAccountRouter(authService)
+
+	assert.Equal(t, http.StatusOK, postJSON(router, "/auth/unlock", map[string]string{"token": "good-token"}).Code)
+	assert.Equal(t, http.StatusBadRequest, postJSON(router, "/auth/unlock", map[string]string{"token": "used-token"}).Code)
+	assert.Equal(t, http.StatusBadRequest, postJSON(router, "/auth/unlock", map[string]string{}).Code)
+	authService.AssertExpectations(t)
+}
+
+func TestAccountHandler_VerifyEmail(t *testing.T) {
+	authService := new(MockAuthService)
+	authService.On("VerifyEmail", mock.Anything, "good-token").Return(nil)
+	authService.On("VerifyEmail", mock.Anything, "old-token").Return(services.ErrVerificationTokenExpired)
+	router := setupAccountRouter(authService)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	assert.Equal(t, http.StatusOK, get("/auth/verify-email?token=good-token").Code)
+	w := get("/auth/verify-email?token=old-token")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "expired")
+	assert.Equal(t, http.StatusBadRequest, get("/auth/verify-email").Code)
+	authService.AssertExpectations(t)
+}
+
+func TestAccountHandler_ResendVerification(t *testing.T) {
+	authService := new(MockAuthService)
+	authService.On("ResendVerification", mock.Anything, "ana@example.com").Return(nil)
+	authService.On("ResendVerification", mock.Anything, "busy@example.com").Return(services.ErrVerificationResendTooSoon)
+	router := setupAccountRouter(authService)
+
+	// The response never reveals whether a link was sent
+	assert.Equal(t, http.StatusOK, postJSON(router, "/auth/resend-verification", map[string]string{"email": "ana@example.com"}).Code)
+	assert.Equal(t, http.StatusOK, postJSON(router, "/auth/resend-verification", map[string]string{"email": "busy@example.com"}).Code)
+	assert.Equal(t, http.StatusBadRequest, postJSON(router, "/auth/resend-verification", map[string]string{"email": "not an email"}).Code)
+	authService.AssertExpectations(t)
+}
@@ -3,12 +3,13 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"wedding-invitation-backend/internal/domain/errs"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/services"
 )
@@ -17,6 +18,8 @@ import (
 type AnalyticsHandler struct {
 	analyticsService services.AnalyticsService
 	weddingService   *services.WeddingService
+	streamOrigins    func() []string
+	features         services.FeatureChecker
 }
 
 // NewAnalyticsHandler creates a new analytics handler
@@ -27,6 +30,32 @@ func NewAnalyticsHandler(analyticsService services.AnalyticsService, weddingServ
 	}
 }
 
+// SetFeatureFlags stops recording the events of weddings the analytics_tracking feature is off for
+func (h *AnalyticsHandler) SetFeatureFlags(features services.FeatureChecker) {
+	h.features = features
+}
+
+// trackingDisabled answers the events of weddings the analytics_tracking
+// feature is off for as accepted without recording them, so that the wedding
+// site keeps working
+func (h *AnalyticsHandler) trackingDisabled(c *gin.Context, weddingID models.ID) bool {
+	if h.features == nil || h.features.Enabled(c.Request.Context(), weddingID, services.FeatureAnalyticsTracking) {
+		return false
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "Analytics tracking is disabled"})
+	return true
+}
+
+// trackingSkipped answers events the wedding's analytics settings leave
+// unrecorded as accepted, like those of weddings with tracking disabled
+func trackingSkipped(c *gin.Context, err error) bool {
+	if !errors.Is(err, services.ErrTrackingSkipped) {
+		return false
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "Not recorded under the wedding's analytics settings"})
+	return true
+}
+
 // TrackPageViewRequest represents a page view tracking request
 type TrackPageViewRequest struct {
 	WeddingID string `json:"wedding_id" binding:"required"`
@@ -34,6 +63,14 @@ type TrackPageViewRequest struct {
 	Page      string `json:"page" binding:"required"`
 }
 
+// TrackPageDurationRequest represents a page duration heartbeat
+type TrackPageDurationRequest struct {
+	WeddingID string `json:"wedding_id" binding:"required"`
+	SessionID string `json:"session_id" binding:"required"`
+	Page      string `json:"page" binding:"required"`
+	Duration  int64  `json:"duration" binding:"required"` // Seconds spent on the page so far
+}
+
 // TrackConversionRequest represents a conversion tracking request
 type TrackConversionRequest struct {
 	WeddingID  string                 `json:"wedding_id" binding:"required"`
@@ -43,6 +80,42 @@ type TrackConversionRequest struct {
 	Properties map[string]interface{} `json:"properties"`
 }
 
+// TrackBatchEventRequest is one event of a tracking batch. Type is page_view,
+// page_duration or conversion and tells which of the other fields apply.
+type TrackBatchEventRequest struct {
+	Type       string                 `json:"type"`
+	WeddingID  string                 `json:"wedding_id"`
+	SessionID  string                 `json:"session_id"`
+	Page       string                 `json:"page"`
+	Duration   int64                  `json:"duration"` // Seconds spent on the page so far
+	Event      string                 `json:"event"`
+	Value      float64                `json:"value"`
+	Properties map[string]interface{} `json:"properties"`
+	Timestamp  time.Time              `json:"timestamp"` // When the event happened, within the last 24 hours; defaults to now
+}
+
+// TrackBatchRequest represents a batch of buffered tracking events
+type TrackBatchRequest struct {
+	Events []TrackBatchEventRequest `json:"events" binding:"required"`
+}
+
+// TrackBatchFailure is an event of a batch that was not recorded. Retryable
+// failures may succeed when the event is sent again.
+type TrackBatchFailure struct {
+	Index     int    `json:"index"`
+	Error     string `json:"error"`
+	Retryable bool   `json:"retryable"`
+}
+
+// TrackBatchResponse reports the outcome of a tracking batch. Skipped events
+// are accepted without being recorded because tracking is disabled.
+type TrackBatchResponse struct {
+	Received int                 `json:"received"`
+	Recorded int                 `json:"recorded"`
+	Skipped  int                 `json:"skipped"`
+	Failed   []TrackBatchFailure `json:"failed"`
+}
+
 // TrackRSVPSubmissionRequest represents an RSVP submission tracking request
 type TrackRSVPSubmissionRequest struct {
 	WeddingID      string `json:"wedding_id" binding:"required"`
@@ -80,6 +153,7 @@ type AnalyticsFilterRequest struct {
 // @Produce json
 // @Param request body TrackPageViewRequest true "Page view data"
 // @Success 201 {object} gin.H
+// @Success 202 {object} gin.H "Not recorded, tracking is disabled"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /analytics/track/page-view [post]
@@ -91,11 +165,14 @@ func (h *AnalyticsHandler) TrackPageView(c *gin.Context) {
 	}
 
 	// Validate wedding ID
-	weddingID, err := primitive.ObjectIDFromHex(req.WeddingID)
+	weddingID, err := models.ParseID(req.WeddingID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
 		return
 	}
+	if h.trackingDisabled(c, weddingID) {
+		return
+	}
 
 	// Validate page
 	if !h.analyticsService.IsValidPage(req.Page) {
@@ -105,22 +182,72 @@ func (h *AnalyticsHandler) TrackPageView(c *gin.Context) {
 
 	// Track page view
 	err = h.analyticsService.TrackPageView(c.Request.Context(), weddingID, req.SessionID, req.Page, c.Request)
+	if trackingSkipped(c, err) {
+		return
+	}
 	if err != nil {
-		if err.Error() == "wedding not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
-			return
-		}
-		if err.Error() == "cannot track analytics for unpublished wedding" {
-			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Wedding is not published"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to track page view"})
+		_ = c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{"message": "Page view tracked successfully"})
 }
 
+// TrackPageDuration records the time spent on a viewed page
+// @Summary Track page duration
+// @Description Report the seconds spent so far on the session's latest view of a page; send it periodically and when the page is left (public endpoint)
+// @Tags Analytics
+// @Accept json
+// @Produce json
+// @Param request body TrackPageDurationRequest true "Page duration data"
+// @Success 200 {object} gin.H
+// @Success 202 {object} gin.H "Not recorded, tracking is disabled"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /analytics/track/page-duration [post]
+func (h *AnalyticsHandler) TrackPageDuration(c *gin.Context) {
+	var req TrackPageDurationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+
+	// Validate wedding ID
+	weddingID, err := models.ParseID(req.WeddingID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
+		return
+	}
+	if h.trackingDisabled(c, weddingID) {
+		return
+	}
+
+	// Validate page
+	if !h.analyticsService.IsValidPage(req.Page) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid page name"})
+		return
+	}
+
+	// Track page duration
+	err = h.analyticsService.TrackPageDuration(c.Request.Context(), weddingID, req.SessionID, req.Page, req.Duration, c.Request)
+	if trackingSkipped(c, err) {
+		return
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidPageDuration):
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, services.ErrPageViewNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Page view not found"})
+		default:
+			_ = c.Error(err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Page duration tracked successfully"})
+}
+
 // TrackRSVPSubmission tracks an RSVP submission event
 // @Summary Track RSVP submission
 // @Description Track an RSVP submission for analytics
@@ -129,6 +256,7 @@ func (h *AnalyticsHandler) TrackPageView(c *gin.Context) {
 // @Produce json
 // @Param request body TrackRSVPSubmissionRequest true "RSVP submission data"
 // @Success 201 {object} gin.H
+// @Success 202 {object} gin.H "Not recorded, tracking is disabled"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /analytics/track/rsvp-submission [post]
@@ -140,14 +268,17 @@ func (h *AnalyticsHandler) TrackRSVPSubmission(c *gin.Context) {
 	}
 
 	// Validate wedding ID
-	weddingID, err := primitive.ObjectIDFromHex(req.WeddingID)
+	weddingID, err := models.ParseID(req.WeddingID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
 		return
 	}
+	if h.trackingDisabled(c, weddingID) {
+		return
+	}
 
 	// Validate RSVP ID
-	rsvpID, err := primitive.ObjectIDFromHex(req.RSVPID)
+	rsvpID, err := models.ParseID(req.RSVPID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid RSVP ID"})
 		return
@@ -169,12 +300,11 @@ func (h *AnalyticsHandler) TrackRSVPSubmission(c *gin.Context) {
 
 	// Track RSVP submission
 	err = h.analyticsService.TrackRSVPSubmission(c.Request.Context(), weddingID, rsvpID, req.SessionID, req.Source, req.TimeToComplete, c.Request)
+	if trackingSkipped(c, err) {
+		return
+	}
 	if err != nil {
-		if err.Error() == "wedding not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to track RSVP submission"})
+		_ = c.Error(err)
 		return
 	}
 
@@ -189,6 +319,7 @@ func (h *AnalyticsHandler) TrackRSVPSubmission(c *gin.Context) {
 // @Produce json
 // @Param request body TrackRSVPAbandonmentRequest true "RSVP abandonment data"
 // @Success 201 {object} gin.H
+// @Success 202 {object} gin.H "Not recorded, tracking is disabled"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /analytics/track/rsvp-abandonment [post]
@@ -200,16 +331,18 @@ func (h *AnalyticsHandler) TrackRSVPAbandonment(c *gin.Context) {
 	}
 
 	// Validate wedding ID
-	weddingID, err := primitive.ObjectIDFromHex(req.WeddingID)
+	weddingID, err := models.ParseID(req.WeddingID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
 		return
 	}
+	if h.trackingDisabled(c, weddingID) {
+		return
+	}
 
 	// Validate abandoned step
-	validSteps := []string{"personal_info", "attending_status", "guest_count", "dietary_restrictions", "confirmation"}
 	isValidStep := false
-	for _, step := range validSteps {
+	for _, step := range models.RSVPFormSteps {
 		if req.AbandonedStep == step {
 			isValidStep = true
 			break
@@ -222,12 +355,11 @@ func (h *AnalyticsHandler) TrackRSVPAbandonment(c *gin.Context) {
 
 	// Track RSVP abandonment
 	err = h.analyticsService.TrackRSVPAbandonment(c.Request.Context(), weddingID, req.SessionID, req.AbandonedStep, req.FormErrors, c.Request)
+	if trackingSkipped(c, err) {
+		return
+	}
 	if err != nil {
-		if err.Error() == "wedding not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to track RSVP abandonment"})
+		_ = c.Error(err)
 		return
 	}
 
@@ -242,6 +374,7 @@ func (h *AnalyticsHandler) TrackRSVPAbandonment(c *gin.Context) {
 // @Produce json
 // @Param request body TrackConversionRequest true "Conversion data"
 // @Success 201 {object} gin.H
+// @Success 202 {object} gin.H "Not recorded, tracking is disabled"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /analytics/track/conversion [post]
@@ -253,11 +386,14 @@ func (h *AnalyticsHandler) TrackConversion(c *gin.Context) {
 	}
 
 	// Validate wedding ID
-	weddingID, err := primitive.ObjectIDFromHex(req.WeddingID)
+	weddingID, err := models.ParseID(req.WeddingID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
 		return
 	}
+	if h.trackingDisabled(c, weddingID) {
+		return
+	}
 
 	// Validate event
 	if !h.analyticsService.IsValidEvent(req.Event) {
@@ -272,18 +408,99 @@ func (h *AnalyticsHandler) TrackConversion(c *gin.Context) {
 
 	// Track conversion
 	err = h.analyticsService.TrackConversion(c.Request.Context(), weddingID, req.SessionID, req.Event, req.Value, req.Properties)
+	if trackingSkipped(c, err) {
+		return
+	}
 	if err != nil {
-		if err.Error() == "wedding not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to track conversion"})
+		_ = c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{"message": "Conversion tracked successfully"})
 }
 
+// TrackBatch tracks several buffered events at once
+// @Summary Track a batch of events
+// @Description Record up to 100 page views, page durations and conversions in one request (public endpoint). Events are validated one by one; the response lists the events that failed by index, and whether sending them again may succeed.
+// @Tags Analytics
+// @Accept json
+// @Produce json
+// @Param request body TrackBatchRequest true "Events"
+// @Success 200 {object} TrackBatchResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /analytics/track/batch [post]
+func (h *AnalyticsHandler) TrackBatch(c *gin.Context) {
+	var req TrackBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		return
+	}
+	if len(req.Events) == 0 || len(req.Events) > services.MaxTrackBatchSize {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "A batch must hold between 1 and " + strconv.Itoa(services.MaxTrackBatchSize) + " events"})
+		return
+	}
+
+	response := TrackBatchResponse{Received: len(req.Events), Failed: []TrackBatchFailure{}}
+	events := make([]services.TrackBatchEvent, 0, len(req.Events))
+	indexes := make([]int, 0, len(req.Events))
+	disabled := make(map[models.ID]bool)
+	for i, event := range req.Events {
+		weddingID, err := models.ParseID(event.WeddingID)
+		if err != nil {
+			response.Failed = append(response.Failed, TrackBatchFailure{Index: i, Error: "Invalid wedding ID"})
+			continue
+		}
+		off, checked := disabled[weddingID]
+		if !checked {
+			off = h.features != nil && !h.features.Enabled(c.Request.Context(), weddingID, services.FeatureAnalyticsTracking)
+			disabled[weddingID] = off
+		}
+		if off {
+			response.Skipped++
+			continue
+		}
+
+		if event.Properties != nil {
+			event.Properties = h.analyticsService.SanitizeCustomData(event.Properties)
+		}
+		events = append(events, services.TrackBatchEvent{
+			Type:       event.Type,
+			WeddingID:  weddingID,
+			SessionID:  event.SessionID,
+			Page:       event.Page,
+			Duration:   event.Duration,
+			Event:      event.Event,
+			Value:      event.Value,
+			Properties: event.Properties,
+			Timestamp:  event.Timestamp,
+		})
+		indexes = append(indexes, i)
+	}
+
+	if len(events) > 0 {
+		for j, err := range h.analyticsService.TrackBatch(c.Request.Context(), events, c.Request) {
+			if err == nil {
+				response.Recorded++
+				continue
+			}
+			if errors.Is(err, services.ErrTrackingSkipped) {
+				response.Skipped++
+				continue
+			}
+			failure := TrackBatchFailure{Index: indexes[j], Error: err.Error()}
+			// Internal errors are not shown; the client only learns to retry
+			if !errors.Is(err, errs.ErrValidation) && !errors.Is(err, errs.ErrNotFound) && !errors.Is(err, services.ErrInvalidPageDuration) {
+				failure.Error = "Failed to record event"
+				failure.Retryable = true
+			}
+			response.Failed = append(response.Failed, failure)
+		}
+	}
+	sort.Slice(response.Failed, func(i, j int) bool { return response.Failed[i].Index < response.Failed[j].Index })
+
+	c.JSON(http.StatusOK, response)
+}
+
 // GetWeddingAnalytics retrieves wedding analytics
 // @Summary Get wedding analytics
 // @Description Retrieve analytics for a specific wedding
@@ -296,7 +513,7 @@ func (h *AnalyticsHandler) TrackConversion(c *gin.Context) {
 // @Router /weddings/{id}/analytics [get]
 func (h *AnalyticsHandler) GetWeddingAnalytics(c *gin.Context) {
 	weddingIDStr := c.Param("id")
-	weddingID, err := primitive.ObjectIDFromHex(weddingIDStr)
+	weddingID, err := models.ParseID(weddingIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
 		return
@@ -309,24 +526,20 @@ func (h *AnalyticsHandler) GetWeddingAnalytics(c *gin.Context) {
 		return
 	}
 
-	userID, err := primitive.ObjectIDFromHex(userIDStr.(string))
+	userID, err := models.ParseID(userIDStr.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
 		return
 	}
 
-	// Verify wedding ownership
+	// Verify the user may view the wedding's analytics
 	wedding, err := h.weddingService.GetWeddingByID(c.Request.Context(), weddingID, userID)
 	if err != nil {
-		if err.Error() == "wedding not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve wedding"})
+		_ = c.Error(err)
 		return
 	}
 
-	if wedding.UserID != userID {
+	if !wedding.Can(userID, models.PermissionViewAnalytics) {
 		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
 		return
 	}
@@ -354,7 +567,7 @@ func (h *AnalyticsHandler) GetWeddingAnalytics(c *gin.Context) {
 // @Router /weddings/{id}/analytics/summary [get]
 func (h *AnalyticsHandler) GetAnalyticsSummary(c *gin.Context) {
 	weddingIDStr := c.Param("id")
-	weddingID, err := primitive.ObjectIDFromHex(weddingIDStr)
+	weddingID, err := models.ParseID(weddingIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
 		return
@@ -367,24 +580,20 @@ func (h *AnalyticsHandler) GetAnalyticsSummary(c *gin.Context) {
 		return
 	}
 
-	userID, err := primitive.ObjectIDFromHex(userIDStr.(string))
+	userID, err := models.ParseID(userIDStr.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
 		return
 	}
 
-	// Verify wedding ownership
+	// Verify the user may view the wedding's analytics
 	wedding, err := h.weddingService.GetWeddingByID(c.Request.Context(), weddingID, userID)
 	if err != nil {
-		if err.Error() == "wedding not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve wedding"})
+		_ = c.Error(err)
 		return
 	}
 
-	if wedding.UserID != userID {
+	if !wedding.Can(userID, models.PermissionViewAnalytics) {
 		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
 		return
 	}
@@ -424,7 +633,7 @@ func (h *AnalyticsHandler) GetAnalyticsSummary(c *gin.Context) {
 // @Router /weddings/{id}/analytics/page-views [get]
 func (h *AnalyticsHandler) GetPageViews(c *gin.Context) {
 	weddingIDStr := c.Param("id")
-	weddingID, err := primitive.ObjectIDFromHex(weddingIDStr)
+	weddingID, err := models.ParseID(weddingIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
 		return
@@ -437,24 +646,20 @@ func (h *AnalyticsHandler) GetPageViews(c *gin.Context) {
 		return
 	}
 
-	userID, err := primitive.ObjectIDFromHex(userIDStr.(string))
+	userID, err := models.ParseID(userIDStr.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
 		return
 	}
 
-	// Verify wedding ownership
+	// Verify the user may view the wedding's analytics
 	wedding, err := h.weddingService.GetWeddingByID(c.Request.Context(), weddingID, userID)
 	if err != nil {
-		if err.Error() == "wedding not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve wedding"})
+		_ = c.Error(err)
 		return
 	}
 
-	if wedding.UserID != userID {
+	if !wedding.Can(userID, models.PermissionViewAnalytics) {
 		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
 		return
 	}
@@ -534,7 +739,7 @@ func (h *AnalyticsHandler) GetPageViews(c *gin.Context) {
 // @Router /weddings/{id}/analytics/popular-pages [get]
 func (h *AnalyticsHandler) GetPopularPages(c *gin.Context) {
 	weddingIDStr := c.Param("id")
-	weddingID, err := primitive.ObjectIDFromHex(weddingIDStr)
+	weddingID, err := models.ParseID(weddingIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
 		return
@@ -547,24 +752,20 @@ func (h *AnalyticsHandler) GetPopularPages(c *gin.Context) {
 		return
 	}
 
-	userID, err := primitive.ObjectIDFromHex(userIDStr.(string))
+	userID, err := models.ParseID(userIDStr.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
 		return
 	}
 
-	// Verify wedding ownership
+	// Verify the user may view the wedding's analytics
 	wedding, err := h.weddingService.GetWeddingByID(c.Request.Context(), weddingID, userID)
 	if err != nil {
-		if err.Error() == "wedding not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve wedding"})
+		_ = c.Error(err)
 		return
 	}
 
-	if wedding.UserID != userID {
+	if !wedding.Can(userID, models.PermissionViewAnalytics) {
 		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
 		return
 	}
@@ -590,6 +791,323 @@ func (h *AnalyticsHandler) GetPopularPages(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": pages})
 }
 
+// GetTrafficSources retrieves the traffic sources of a wedding
+// @Summary Get traffic sources
+// @Description Retrieve where a wedding's visitors came from, by UTM source or referrer
+// @Tags Analytics
+// @Param id path string true "Wedding ID"
+// @Param limit query int false "Limit" default(10)
+// @Success 200 {object} gin.H{data=[]models.TrafficSourceStats}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /weddings/{id}/analytics/traffic-sources [get]
+func (h *AnalyticsHandler) GetTrafficSources(c *gin.Context) {
+	weddingIDStr := c.Param("id")
+	weddingID, err := models.ParseID(weddingIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
+		return
+	}
+
+	// Get user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	userID, err := models.ParseID(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
+		return
+	}
+
+	// Verify the user may view the wedding's analytics
+	wedding, err := h.weddingService.GetWeddingByID(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if !wedding.Can(userID, models.PermissionViewAnalytics) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
+		return
+	}
+
+	// Get limit from query
+	limit := 10
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit < 1 || parsedLimit > 100 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid limit (must be 1-100)"})
+			return
+		}
+		limit = parsedLimit
+	}
+
+	// Get traffic sources
+	sources, err := h.analyticsService.GetTrafficSources(c.Request.Context(), weddingID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve traffic sources"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": sources})
+}
+
+// GetGeoBreakdown retrieves the countries and cities of a wedding's visitors
+// @Summary Get geographic breakdown
+// @Description Retrieve the countries of a wedding's visitors, each with its cities. Views without a resolved location are left out
+// @Tags Analytics
+// @Param id path string true "Wedding ID"
+// @Param limit query int false "Limit" default(10)
+// @Success 200 {object} gin.H{data=[]models.CountryStats}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /weddings/{id}/analytics/geo [get]
+func (h *AnalyticsHandler) GetGeoBreakdown(c *gin.Context) {
+	weddingIDStr := c.Param("id")
+	weddingID, err := models.ParseID(weddingIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
+		return
+	}
+
+	// Get user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	userID, err := models.ParseID(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
+		return
+	}
+
+	// Verify the user may view the wedding's analytics
+	wedding, err := h.weddingService.GetWeddingByID(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if !wedding.Can(userID, models.PermissionViewAnalytics) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
+		return
+	}
+
+	// Get limit from query
+	limit := 10
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit < 1 || parsedLimit > 100 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid limit (must be 1-100)"})
+			return
+		}
+		limit = parsedLimit
+	}
+
+	// Get countries
+	countries, err := h.analyticsService.GetGeoBreakdown(c.Request.Context(), weddingID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve geographic breakdown"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": countries})
+}
+
+// GetDailyMetrics retrieves a wedding's daily metrics for trend charts
+// @Summary Get daily metrics
+// @Description Retrieve page views, sessions and RSVPs per day; days without activity are zero
+// @Tags Analytics
+// @Param id path string true "Wedding ID"
+// @Param start_date query string false "First day (YYYY-MM-DD), defaults to 29 days before end_date"
+// @Param end_date query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} gin.H{data=[]models.DailyMetrics}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /weddings/{id}/analytics/daily [get]
+func (h *AnalyticsHandler) GetDailyMetrics(c *gin.Context) {
+	weddingIDStr := c.Param("id")
+	weddingID, err := models.ParseID(weddingIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
+		return
+	}
+
+	// Get user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	userID, err := models.ParseID(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
+		return
+	}
+
+	// Verify the user may view the wedding's analytics
+	wedding, err := h.weddingService.GetWeddingByID(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if !wedding.Can(userID, models.PermissionViewAnalytics) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
+		return
+	}
+
+	// Parse the date range
+	endDate := time.Now().UTC()
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		endDate, err = time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid end date format"})
+			return
+		}
+	}
+
+	startDate := endDate.AddDate(0, 0, -29)
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		startDate, err = time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid start date format"})
+			return
+		}
+	}
+
+	// Get daily metrics
+	metrics, err := h.analyticsService.GetDailyMetrics(c.Request.Context(), weddingID, startDate, endDate)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve daily metrics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": metrics})
+}
+
+// GetRSVPFunnel retrieves the drop-off of a wedding's RSVP flow
+// @Summary Get RSVP funnel
+// @Description Retrieve how many sessions reached each step of the RSVP flow (viewed invitation, started RSVP, each form step, completed) and how many dropped off, overall and by device
+// @Tags Analytics
+// @Param id path string true "Wedding ID"
+// @Param start_date query string false "First day (YYYY-MM-DD), defaults to 29 days before end_date"
+// @Param end_date query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} gin.H{data=models.RSVPFunnel}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /weddings/{id}/analytics/funnel [get]
+func (h *AnalyticsHandler) GetRSVPFunnel(c *gin.Context) {
+	weddingIDStr := c.Param("id")
+	weddingID, err := models.ParseID(weddingIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
+		return
+	}
+
+	// Get user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	userID, err := models.ParseID(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
+		return
+	}
+
+	// Verify the user may view the wedding's analytics
+	wedding, err := h.weddingService.GetWeddingByID(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if !wedding.Can(userID, models.PermissionViewAnalytics) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
+		return
+	}
+
+	// Parse the date range
+	endDate := time.Now().UTC()
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		endDate, err = time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid end date format"})
+			return
+		}
+	}
+
+	startDate := endDate.AddDate(0, 0, -29)
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		startDate, err = time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid start date format"})
+			return
+		}
+	}
+
+	// Get the funnel
+	funnel, err := h.analyticsService.GetRSVPFunnel(c.Request.Context(), weddingID, startDate, endDate)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve RSVP funnel"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": funnel})
+}
+
+// GetUserOverview retrieves the analytics of all the user's weddings
+// @Summary Get user analytics overview
+// @Description Aggregate views, RSVP rates and top traffic sources across every wedding whose analytics the user may view, compared with anonymized platform benchmarks
+// @Tags Analytics
+// @Success 200 {object} gin.H{data=models.UserAnalyticsOverview}
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/analytics/overview [get]
+func (h *AnalyticsHandler) GetUserOverview(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	userID, err := models.ParseID(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
+		return
+	}
+
+	overview, err := h.analyticsService.GetUserOverview(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve analytics overview"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": overview})
+}
+
 // GetSystemAnalytics retrieves system-wide analytics
 // @Summary Get system analytics
 // @Description Retrieve system-wide analytics (admin only)
@@ -628,7 +1146,7 @@ func (h *AnalyticsHandler) GetSystemAnalytics(c *gin.Context) {
 // @Router /weddings/{id}/analytics/refresh [post]
 func (h *AnalyticsHandler) RefreshAnalytics(c *gin.Context) {
 	weddingIDStr := c.Param("id")
-	weddingID, err := primitive.ObjectIDFromHex(weddingIDStr)
+	weddingID, err := models.ParseID(weddingIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
 		return
@@ -641,24 +1159,20 @@ func (h *AnalyticsHandler) RefreshAnalytics(c *gin.Context) {
 		return
 	}
 
-	userID, err := primitive.ObjectIDFromHex(userIDStr.(string))
+	userID, err := models.ParseID(userIDStr.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
 		return
 	}
 
-	// Verify wedding ownership
+	// Verify the user may view the wedding's analytics
 	wedding, err := h.weddingService.GetWeddingByID(c.Request.Context(), weddingID, userID)
 	if err != nil {
-		if err.Error() == "wedding not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve wedding"})
+		_ = c.Error(err)
 		return
 	}
 
-	if wedding.UserID != userID {
+	if !wedding.Can(userID, models.PermissionViewAnalytics) {
 		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
 		return
 	}
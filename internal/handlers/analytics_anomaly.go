@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// AnalyticsAnomalyLister lists the traffic and RSVP anomalies found for a wedding
+type AnalyticsAnomalyLister interface {
+	ListAnomalies(ctx context.Context, weddingID, userID models.ID, page, pageSize int) ([]*models.AnalyticsAnomaly, int64, error)
+}
+
+// AnalyticsAnomalyHandler serves the anomaly alerts of a wedding's analytics dashboard
+type AnalyticsAnomalyHandler struct {
+	anomalies AnalyticsAnomalyLister
+}
+
+// NewAnalyticsAnomalyHandler creates a new analytics anomaly handler
+func NewAnalyticsAnomalyHandler(anomalies AnalyticsAnomalyLister) *AnalyticsAnomalyHandler {
+	return &AnalyticsAnomalyHandler{anomalies: anomalies}
+}
+
+// ListAnomalies godoc
+// @Summary List analytics anomalies
+// @Description List the hours in which the wedding's page views or RSVPs spiked or dropped against the same hour on previous days, latest first. Thresholds and channels are set in the wedding's analytics alert settings.
+// @Tags Analytics
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} PaginationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/analytics/anomalies [get]
+func (h *AnalyticsAnomalyHandler) ListAnomalies(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+	anomalies, total, err := h.anomalies.ListAnomalies(c.Request.Context(), weddingID, userID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err, "Failed to list anomalies")
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, anomalies, int64(len(anomalies)), total, page, pageSize)
+}
+
+func (h *AnalyticsAnomalyHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to view analytics for this wedding")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockAnalyticsAnomalyLister returns the anomalies of the one wedding its owner may view
+type MockAnalyticsAnomalyLister struct {
+	weddingID models.ID
+	ownerID   models.ID
+	anomalies []*models.AnalyticsAnomaly
+}
+
+func (m *MockAnalyticsAnomalyLister) ListAnomalies(ctx context.Context, weddingID, userID models.ID, page, pageSize int) ([]*models.AnalyticsAnomaly, int64, error) {
+	if weddingID != m.weddingID {
+		return nil, 0, services.ErrWeddingNotFound
+	}
+	if userID != m.ownerID {
+		return nil, 0, services.ErrUnauthorized
+	}
+	return m.anomalies, int64(len(m.anomalies)), nil
+}
+
+func setupAnalyticsAnomalyRouter(lister AnalyticsAnomalyLister, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	handler := NewAnalyticsAnomalyHandler(lister)
+	router.GET("/weddings/:id/analytics/anomalies", handler.ListAnomalies)
+	return router
+}
+
+func sendAnalyticsAnomalyRequest(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAnalyticsAnomalyHandler_ListAnomalies(t *testing.T) {
+	lister := &MockAnalyticsAnomalyLister{weddingID: models.NewID(), ownerID: models.NewID()}
+	lister.anomalies = []*models.AnalyticsAnomaly{{
+		ID:        models.NewID(),
+		WeddingID: lister.weddingID,
+		Metric:    models.AnomalyMetricPageViews,
+		Kind:      models.AnomalyDrop,
+		Value:     2,
+		Baseline:  80,
+	}}
+	path := "/weddings/" + lister.weddingID.String() + "/analytics/anomalies"
+
+	w := sendAnalyticsAnomalyRequest(setupAnalyticsAnomalyRouter(lister, lister.ownerID), path+"?page=1&size=10")
+	require.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data  []models.AnalyticsAnomaly `json:"data"`
+		Total int64                     `json:"total"`
+		Size  int                       `json:"size"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, models.AnomalyDrop, response.Data[0].Kind)
+	assert.Equal(t, int64(1), response.Total)
+	assert.Equal(t, 10, response.Size)
+
+	w = sendAnalyticsAnomalyRequest(setupAnalyticsAnomalyRouter(lister, models.NewID()), path)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = sendAnalyticsAnomalyRequest(setupAnalyticsAnomalyRouter(lister, lister.ownerID), "/weddings/"+models.NewID().String()+"/analytics/anomalies")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = sendAnalyticsAnomalyRequest(setupAnalyticsAnomalyRouter(lister, lister.ownerID), "/weddings/nope/analytics/anomalies")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
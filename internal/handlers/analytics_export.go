@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// AnalyticsExportHandler serves raw analytics exports to wedding owners
+type AnalyticsExportHandler struct {
+	exports services.AnalyticsExporter
+}
+
+// NewAnalyticsExportHandler creates a new analytics export handler
+func NewAnalyticsExportHandler(exports services.AnalyticsExporter) *AnalyticsExportHandler {
+	return &AnalyticsExportHandler{exports: exports}
+}
+
+// ExportAnalytics godoc
+// @Summary Export analytics
+// @Description Download a wedding's raw page views, RSVP events or conversions, or its daily metrics (summary), for a date range (owner only). Raw events spanning more than 31 days, or any export with async=true, are generated in the background: the response is 202 with the export job, whose file is then served by the download endpoint.
+// @Tags Analytics
+// @Produce json
+// @Produce text/csv
+// @Param id path string true "Wedding ID"
+// @Param type query string true "page_views, rsvp, conversions or summary"
+// @Param format query string false "csv or json" default(json)
+// @Param start_date query string false "First day (YYYY-MM-DD), defaults to 29 days before end_date"
+// @Param end_date query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Param async query bool false "Generate in the background regardless of the range"
+// @Success 200 {file} file
+// @Success 202 {object} utils.APIResponse{data=models.ExportJob}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/analytics/export [get]
+func (h *AnalyticsExportHandler) ExportAnalytics(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	req := services.AnalyticsExportRequest{
+		Type:   c.Query("type"),
+		Format: c.DefaultQuery("format", services.AnalyticsExportJSON),
+		To:     time.Now().UTC(),
+	}
+	if endDate := c.Query("end_date"); endDate != "" {
+		if req.To, err = time.Parse("2006-01-02", endDate); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid end date format")
+			return
+		}
+	}
+	req.From = req.To.AddDate(0, 0, -29)
+	if startDate := c.Query("start_date"); startDate != "" {
+		if req.From, err = time.Parse("2006-01-02", startDate); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid start date format")
+			return
+		}
+	}
+	async, _ := strconv.ParseBool(c.Query("async"))
+
+	if async || req.IsLarge() {
+		job, err := h.exports.QueueExport(c.Request.Context(), weddingID, userID, req)
+		if err != nil {
+			h.writeError(c, err)
+			return
+		}
+		c.JSON(http.StatusAccepted, utils.APIResponse{
+			Success: true,
+			Message: "Export queued",
+			Data:    job,
+		})
+		return
+	}
+
+	job, err := h.exports.StartExport(c.Request.Context(), weddingID, userID, req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.Header("X-Export-Job-ID", job.ID.String())
+
+	layout, _ := services.AnalyticsExportLayoutOf(req.Type)
+	records, started, err := streamExport(c,
+		csvExport{filename: layout.Filename, header: layout.Header, row: layout.Row},
+		exportOptions{format: req.Format},
+		func(emit func(record interface{}) error) error {
+			return h.exports.StreamExport(c.Request.Context(), weddingID, req, emit)
+		})
+	h.exports.FinishExport(context.WithoutCancel(c.Request.Context()), job, records, err)
+	if err != nil && !started {
+		h.writeError(c, err)
+	}
+}
+
+// DownloadAnalyticsExport godoc
+// @Summary Download a background analytics export
+// @Description Redirect to a short-lived link to an analytics export generated in the background (owner only)
+// @Tags Analytics
+// @Param id path string true "Wedding ID"
+// @Param job_id path string true "Export job ID"
+// @Success 302
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/analytics/exports/{job_id}/download [get]
+func (h *AnalyticsExportHandler) DownloadAnalyticsExport(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	jobID, err := models.ParseID(c.Param("job_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid export job ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	url, err := h.exports.DownloadURL(c.Request.Context(), weddingID, userID, jobID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.Redirect(http.StatusFound, url)
+}
+
+func (h *AnalyticsExportHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidAnalyticsExport), errors.Is(err, services.ErrInvalidDateRange):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrExportJobNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Export not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Only the wedding owner can export analytics")
+	case errors.Is(err, services.ErrExportNotReady):
+		utils.ErrorResponse(c, http.StatusConflict, "Export is not ready for download")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to export analytics")
+	}
+}
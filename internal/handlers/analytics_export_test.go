@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockAnalyticsExporter streams fixed records and records how exports were started
+type MockAnalyticsExporter struct {
+	err      error
+	records  []interface{}
+	lastReq  services.AnalyticsExportRequest
+	queued   bool
+	finished bool
+}
+
+func (m *MockAnalyticsExporter) StartExport(ctx context.Context, weddingID, userID models.ID, req services.AnalyticsExportRequest) (*models.ExportJob, error) {
+	m.lastReq = req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.ExportJob{ID: models.NewID(), WeddingID: weddingID, Status: models.ExportJobRunning}, nil
+}
+
+func (m *MockAnalyticsExporter) StreamExport(ctx context.Context, weddingID models.ID, req services.AnalyticsExportRequest, emit func(record interface{}) error) error {
+	for _, record := range m.records {
+		if err := emit(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockAnalyticsExporter) FinishExport(ctx context.Context, job *models.ExportJob, records int, exportErr error) {
+	m.finished = true
+}
+
+func (m *MockAnalyticsExporter) QueueExport(ctx context.Context, weddingID, userID models.ID, req services.AnalyticsExportRequest) (*models.ExportJob, error) {
+	m.lastReq = req
+	m.queued = true
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.ExportJob{ID: models.NewID(), WeddingID: weddingID, Status: models.ExportJobQueued}, nil
+}
+
+func (m *MockAnalyticsExporter) DownloadURL(ctx context.Context, weddingID, userID, jobID models.ID) (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	return "https://cdn.example.com/signed", nil
+}
+
+func setupAnalyticsExportRouter(exporter services.AnalyticsExporter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", models.NewID().String())
+		c.Next()
+	})
+	handler := NewAnalyticsExportHandler(exporter)
+	router.GET("/weddings/:id/analytics/export", handler.ExportAnalytics)
+	router.GET("/weddings/:id/analytics/exports/:job_id/download", handler.DownloadAnalyticsExport)
+	return router
+}
+
+func TestAnalyticsExportHandler_ExportAnalytics(t *testing.T) {
+	path := "/weddings/" + models.NewID().String() + "/analytics/export"
+
+	t.Run("Streams a short range", func(t *testing.T) {
+		exporter := &MockAnalyticsExporter{records: []interface{}{
+			&models.RSVPAnalytics{ID: models.NewID(), SessionID: "s1", Source: "qr_code"},
+		}}
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path+"?type=rsvp&format=csv&start_date=2024-05-01&end_date=2024-05-31", nil)
+		setupAnalyticsExportRouter(exporter).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "analytics_rsvp")
+		assert.NotEmpty(t, w.Header().Get("X-Export-Job-ID"))
+		assert.Contains(t, w.Body.String(), "qr_code")
+		assert.False(t, exporter.queued)
+		assert.True(t, exporter.finished)
+	})
+
+	t.Run("Queues a long range", func(t *testing.T) {
+		exporter := &MockAnalyticsExporter{}
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path+"?type=page_views&start_date=2024-01-01&end_date=2024-03-31", nil)
+		setupAnalyticsExportRouter(exporter).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.True(t, exporter.queued)
+		assert.Equal(t, services.AnalyticsExportJSON, exporter.lastReq.Format)
+	})
+
+	t.Run("Queues on request", func(t *testing.T) {
+		exporter := &MockAnalyticsExporter{}
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path+"?type=summary&async=true", nil)
+		setupAnalyticsExportRouter(exporter).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.True(t, exporter.queued)
+	})
+
+	tests := []struct {
+		name       string
+		query      string
+		err        error
+		wantStatus int
+	}{
+		{"Invalid start date", "?type=rsvp&start_date=May", nil, http.StatusBadRequest},
+		{"Invalid type", "?type=guests", services.ErrInvalidAnalyticsExport, http.StatusBadRequest},
+		{"Not the owner", "?type=rsvp", services.ErrUnauthorized, http.StatusForbidden},
+		{"Wedding not found", "?type=rsvp", services.ErrWeddingNotFound, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, path+tt.query, nil)
+			setupAnalyticsExportRouter(&MockAnalyticsExporter{err: tt.err}).ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestAnalyticsExportHandler_DownloadAnalyticsExport(t *testing.T) {
+	path := "/weddings/" + models.NewID().String() + "/analytics/exports/" + models.NewID().String() + "/download"
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"Redirects to the file", nil, http.StatusFound},
+		{"Not ready", services.ErrExportNotReady, http.StatusConflict},
+		{"Unknown export", services.ErrExportJobNotFound, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			setupAnalyticsExportRouter(&MockAnalyticsExporter{err: tt.err}).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.err == nil {
+				assert.Equal(t, "https://cdn.example.com/signed", w.Header().Get("Location"))
+			}
+		})
+	}
+}
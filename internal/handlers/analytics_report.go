@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// AnalyticsReportSettingsService manages a wedding's emailed analytics digests
+type AnalyticsReportSettingsService interface {
+	GetSettings(ctx context.Context, weddingID, userID models.ID) (*models.AnalyticsReportSettings, error)
+	UpdateSettings(ctx context.Context, weddingID, userID models.ID, req services.UpdateReportSettingsRequest) (*models.AnalyticsReportSettings, error)
+}
+
+// AnalyticsReportHandler serves the analytics digest settings of a wedding
+type AnalyticsReportHandler struct {
+	reportService AnalyticsReportSettingsService
+}
+
+// NewAnalyticsReportHandler creates a new analytics report handler
+func NewAnalyticsReportHandler(reportService AnalyticsReportSettingsService) *AnalyticsReportHandler {
+	return &AnalyticsReportHandler{reportService: reportService}
+}
+
+// GetReportSettings godoc
+// @Summary Get analytics report settings
+// @Description Get whether weekly or monthly analytics digests are emailed to the wedding owner
+// @Tags Analytics
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {object} models.AnalyticsReportSettings
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/analytics/reports [get]
+func (h *AnalyticsReportHandler) GetReportSettings(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.reportService.GetSettings(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get report settings")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, settings)
+}
+
+// UpdateReportSettings godoc
+// @Summary Update analytics report settings
+// @Description Opt the wedding in or out of emailed analytics digests, or change their frequency
+// @Tags Analytics
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param settings body services.UpdateReportSettingsRequest true "Report settings"
+// @Success 200 {object} models.AnalyticsReportSettings
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/analytics/reports [put]
+func (h *AnalyticsReportHandler) UpdateReportSettings(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var req services.UpdateReportSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	settings, err := h.reportService.UpdateSettings(c.Request.Context(), weddingID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update report settings")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, settings)
+}
+
+func (h *AnalyticsReportHandler) parseRequest(c *gin.Context) (models.ID, models.ID, bool) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+	return weddingID, userID, true
+}
+
+func (h *AnalyticsReportHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidReportFrequency):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to manage reports for this wedding")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
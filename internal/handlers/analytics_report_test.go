@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockAnalyticsReportService returns fixed settings or a fixed error
+type MockAnalyticsReportService struct {
+	settings *models.AnalyticsReportSettings
+	err      error
+	lastReq  services.UpdateReportSettingsRequest
+}
+
+func (m *MockAnalyticsReportService) GetSettings(ctx context.Context, weddingID, userID models.ID) (*models.AnalyticsReportSettings, error) {
+	return m.settings, m.err
+}
+
+func (m *MockAnalyticsReportService) UpdateSettings(ctx context.Context, weddingID, userID models.ID, req services.UpdateReportSettingsRequest) (*models.AnalyticsReportSettings, error) {
+	m.lastReq = req
+	return m.settings, m.err
+}
+
+func setupAnalyticsReportRouter(service AnalyticsReportSettingsService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", models.NewID().String())
+		c.Next()
+	})
+	handler := NewAnalyticsReportHandler(service)
+	router.GET("/weddings/:id/analytics/reports", handler.GetReportSettings)
+	router.PUT("/weddings/:id/analytics/reports", handler.UpdateReportSettings)
+	return router
+}
+
+func TestAnalyticsReportHandler_UpdateReportSettings(t *testing.T) {
+	weddingID := models.NewID()
+	path := "/weddings/" + weddingID.String() + "/analytics/reports"
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"invalid frequency", services.ErrInvalidReportFrequency, http.StatusBadRequest},
+		{"not owner", services.ErrUnauthorized, http.StatusForbidden},
+		{"wedding not found", services.ErrWeddingNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &MockAnalyticsReportService{
+				settings: &models.AnalyticsReportSettings{WeddingID: weddingID, Enabled: true, Frequency: models.AnalyticsReportMonthly},
+				err:      tt.err,
+			}
+			router := setupAnalyticsReportRouter(service)
+
+			req := httptest.NewRequest(http.MethodPut, path, bytes.NewBufferString(`{"enabled":true,"frequency":"monthly"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if assert.NotNil(t, service.lastReq.Frequency) {
+				assert.Equal(t, "monthly", *service.lastReq.Frequency)
+			}
+		})
+	}
+}
+
+func TestAnalyticsReportHandler_GetReportSettings_InvalidWeddingID(t *testing.T) {
+	router := setupAnalyticsReportRouter(&MockAnalyticsReportService{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/weddings/not-an-id/analytics/reports", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+const (
+	// analyticsStreamWriteWait bounds how long a single frame may take to send
+	analyticsStreamWriteWait = 10 * time.Second
+	// analyticsStreamPongWait is how long the dashboard may stay silent before
+	// the stream is considered dead
+	analyticsStreamPongWait = 60 * time.Second
+	// analyticsStreamPingPeriod must be shorter than analyticsStreamPongWait
+	analyticsStreamPingPeriod = analyticsStreamPongWait * 9 / 10
+)
+
+// SetStreamOrigins restricts which browser origins may open the live analytics
+// stream. Requests without an Origin header and same-host requests are always
+// accepted; "*" accepts any origin. The origins are asked for on every request,
+// so they can change while the server runs.
+func (h *AnalyticsHandler) SetStreamOrigins(origins func() []string) {
+	h.streamOrigins = origins
+}
+
+// StreamAnalytics pushes live analytics events over a WebSocket
+// @Summary Stream live analytics
+// @Description Upgrade to a WebSocket that receives page views, RSVP submissions, RSVP abandonments and conversions of the wedding as they are tracked. Each message is a JSON models.AnalyticsLiveEvent.
+// @Tags Analytics
+// @Param id path string true "Wedding ID"
+// @Success 101 {object} models.AnalyticsLiveEvent
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /weddings/{id}/analytics/stream [get]
+func (h *AnalyticsHandler) StreamAnalytics(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
+		return
+	}
+
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	userID, err := models.ParseID(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
+		return
+	}
+
+	// Verify the user may view analytics before upgrading, while errors can still be JSON
+	wedding, err := h.weddingService.GetWeddingByID(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if !wedding.Can(userID, models.PermissionViewAnalytics) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
+		return
+	}
+
+	upgrader := websocket.Upgrader{CheckOrigin: h.checkStreamOrigin}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already written the error response
+		return
+	}
+	defer conn.Close()
+
+	events, cancel := h.analyticsService.SubscribeLive(weddingID)
+	defer cancel()
+
+	// The dashboard never sends data; reading only processes pongs and
+	// notices when the client goes away
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		conn.SetReadLimit(512)
+		conn.SetReadDeadline(time.Now().Add(analyticsStreamPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(analyticsStreamPongWait))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(analyticsStreamPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(analyticsStreamWriteWait))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(analyticsStreamWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+func (h *AnalyticsHandler) checkStreamOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+
+	if h.streamOrigins == nil {
+		return false
+	}
+	for _, allowed := range h.streamOrigins() {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
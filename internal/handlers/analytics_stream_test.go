@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	mocks "wedding-invitation-backend/test/mocks/repository"
+)
+
+func setupAnalyticsStreamServer(t *testing.T, wedding *models.Wedding, userID models.ID) (*httptest.Server, *MockAnalyticsService) {
+	ctrl := gomock.NewController(t)
+	weddingRepo := mocks.NewMockWeddingRepository(ctrl)
+	weddingRepo.EXPECT().GetByID(gomock.Any(), wedding.ID).Return(wedding, nil).AnyTimes()
+	weddingRepo.EXPECT().IncrementViewCount(gomock.Any(), wedding.ID).Return(nil).AnyTimes()
+
+	analyticsService := NewMockAnalyticsService()
+	handler := NewAnalyticsHandler(analyticsService, services.NewWeddingService(weddingRepo, nil))
+	handler.SetStreamOrigins(func() []string { return []string{"https://dashboard.example.com"} })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	router.GET("/weddings/:id/analytics/stream", handler.StreamAnalytics)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server, analyticsService
+}
+
+func streamURL(server *httptest.Server, weddingID models.ID) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http") + "/weddings/" + weddingID.String() + "/analytics/stream"
+}
+
+func TestAnalyticsHandler_StreamAnalytics(t *testing.T) {
+	ownerID := models.NewID()
+	wedding := &models.Wedding{ID: models.NewID(), UserID: ownerID, Status: string(models.WeddingStatusPublished)}
+
+	t.Run("Pushes tracked events to the owner", func(t *testing.T) {
+		server, analyticsService := setupAnalyticsStreamServer(t, wedding, ownerID)
+
+		header := http.Header{"Origin": []string{"https://dashboard.example.com"}}
+		conn, resp, err := websocket.DefaultDialer.Dial(streamURL(server, wedding.ID), header)
+		require.NoError(t, err)
+		defer conn.Close()
+		assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+		require.Eventually(t, func() bool {
+			return analyticsService.broker.SubscriberCount(wedding.ID) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		analyticsService.broker.Publish(&models.AnalyticsLiveEvent{
+			Type:      models.AnalyticsLivePageView,
+			WeddingID: wedding.ID,
+			SessionID: "session-1",
+			Page:      "gallery",
+		})
+
+		var event models.AnalyticsLiveEvent
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		require.NoError(t, conn.ReadJSON(&event))
+		assert.Equal(t, models.AnalyticsLivePageView, event.Type)
+		assert.Equal(t, "gallery", event.Page)
+
+		conn.Close()
+		assert.Eventually(t, func() bool {
+			return analyticsService.broker.SubscriberCount(wedding.ID) == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Rejects non-owners before upgrading", func(t *testing.T) {
+		server, _ := setupAnalyticsStreamServer(t, wedding, models.NewID())
+
+		_, resp, err := websocket.DefaultDialer.Dial(streamURL(server, wedding.ID), nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("Rejects unknown origins", func(t *testing.T) {
+		server, _ := setupAnalyticsStreamServer(t, wedding, ownerID)
+
+		header := http.Header{"Origin": []string{"https://evil.example.com"}}
+		_, resp, err := websocket.DefaultDialer.Dial(streamURL(server, wedding.ID), header)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("Invalid wedding ID", func(t *testing.T) {
+		server, _ := setupAnalyticsStreamServer(t, wedding, ownerID)
+
+		url := "ws" + strings.TrimPrefix(server.URL, "http") + "/weddings/not-an-id/analytics/stream"
+		_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
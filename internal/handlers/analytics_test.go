@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -12,13 +13,14 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
 )
 
 // MockAnalyticsService for testing
 type MockAnalyticsService struct {
 	trackPageViewError           error
+	trackPageDurationError       error
 	trackRSVPSubmissionError     error
 	trackRSVPAbandonmentError    error
 	trackConversionError         error
@@ -28,30 +30,50 @@ type MockAnalyticsService struct {
 	getPopularPagesError         error
 	refreshWeddingAnalyticsError error
 	getSystemAnalyticsError      error
+	getUserOverviewError         error
 	refreshSystemAnalyticsError  error
+	getRequestTraceError         error
+	trackBatchErrors             []error
+	trackedBatch                 []services.TrackBatchEvent
+	broker                       *services.AnalyticsBroker
 }
 
 func NewMockAnalyticsService() *MockAnalyticsService {
-	return &MockAnalyticsService{}
+	return &MockAnalyticsService{broker: services.NewAnalyticsBroker()}
 }
 
-func (m *MockAnalyticsService) TrackPageView(ctx context.Context, weddingID primitive.ObjectID, sessionID, page string, req *http.Request) error {
+func (m *MockAnalyticsService) TrackPageView(ctx context.Context, weddingID models.ID, sessionID, page string, req *http.Request) error {
 	return m.trackPageViewError
 }
 
-func (m *MockAnalyticsService) TrackRSVPSubmission(ctx context.Context, weddingID, rsvpID primitive.ObjectID, sessionID, source string, timeToComplete int64, req *http.Request) error {
+func (m *MockAnalyticsService) TrackPageDuration(ctx context.Context, weddingID models.ID, sessionID, page string, duration int64, req *http.Request) error {
+	return m.trackPageDurationError
+}
+
+func (m *MockAnalyticsService) TrackRSVPSubmission(ctx context.Context, weddingID, rsvpID models.ID, sessionID, source string, timeToComplete int64, req *http.Request) error {
 	return m.trackRSVPSubmissionError
 }
 
-func (m *MockAnalyticsService) TrackRSVPAbandonment(ctx context.Context, weddingID primitive.ObjectID, sessionID, abandonedStep string, formErrors []string, req *http.Request) error {
+func (m *MockAnalyticsService) TrackRSVPAbandonment(ctx context.Context, weddingID models.ID, sessionID, abandonedStep string, formErrors []string, req *http.Request) error {
 	return m.trackRSVPAbandonmentError
 }
 
-func (m *MockAnalyticsService) TrackConversion(ctx context.Context, weddingID primitive.ObjectID, sessionID, event string, value float64, properties map[string]interface{}) error {
+func (m *MockAnalyticsService) TrackConversion(ctx context.Context, weddingID models.ID, sessionID, event string, value float64, properties map[string]interface{}) error {
 	return m.trackConversionError
 }
 
-func (m *MockAnalyticsService) GetWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) (*models.WeddingAnalytics, error) {
+func (m *MockAnalyticsService) TrackBatch(ctx context.Context, events []services.TrackBatchEvent, req *http.Request) []error {
+	m.trackedBatch = append(m.trackedBatch, events...)
+	results := make([]error, len(events))
+	copy(results, m.trackBatchErrors)
+	return results
+}
+
+func (m *MockAnalyticsService) GetRSVPFunnel(ctx context.Context, weddingID models.ID, startDate, endDate time.Time) (*models.RSVPFunnel, error) {
+	return &models.RSVPFunnel{}, nil
+}
+
+func (m *MockAnalyticsService) GetWeddingAnalytics(ctx context.Context, weddingID models.ID) (*models.WeddingAnalytics, error) {
 	if m.getWeddingAnalyticsError != nil {
 		return nil, m.getWeddingAnalyticsError
 	}
@@ -66,7 +88,7 @@ func (m *MockAnalyticsService) GetWeddingAnalytics(ctx context.Context, weddingI
 	}, nil
 }
 
-func (m *MockAnalyticsService) GetAnalyticsSummary(ctx context.Context, weddingID primitive.ObjectID, period string) (*models.AnalyticsSummary, error) {
+func (m *MockAnalyticsService) GetAnalyticsSummary(ctx context.Context, weddingID models.ID, period string) (*models.AnalyticsSummary, error) {
 	if m.getAnalyticsSummaryError != nil {
 		return nil, m.getAnalyticsSummaryError
 	}
@@ -83,13 +105,13 @@ func (m *MockAnalyticsService) GetAnalyticsSummary(ctx context.Context, weddingI
 	}, nil
 }
 
-func (m *MockAnalyticsService) GetPageViews(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error) {
+func (m *MockAnalyticsService) GetPageViews(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error) {
 	if m.getPageViewsError != nil {
 		return nil, 0, m.getPageViewsError
 	}
 	return []*models.PageView{
 		{
-			ID:        primitive.NewObjectID(),
+			ID:        models.NewID(),
 			WeddingID: weddingID,
 			SessionID: "session123",
 			Page:      "/wedding/john-doe",
@@ -98,7 +120,7 @@ func (m *MockAnalyticsService) GetPageViews(ctx context.Context, weddingID primi
 	}, 1, nil
 }
 
-func (m *MockAnalyticsService) GetPopularPages(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.PageStats, error) {
+func (m *MockAnalyticsService) GetPopularPages(ctx context.Context, weddingID models.ID, limit int) ([]models.PageStats, error) {
 	if m.getPopularPagesError != nil {
 		return nil, m.getPopularPagesError
 	}
@@ -112,7 +134,7 @@ func (m *MockAnalyticsService) GetPopularPages(ctx context.Context, weddingID pr
 	}, nil
 }
 
-func (m *MockAnalyticsService) RefreshWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) error {
+func (m *MockAnalyticsService) RefreshWeddingAnalytics(ctx context.Context, weddingID models.ID) error {
 	return m.refreshWeddingAnalyticsError
 }
 
@@ -136,24 +158,58 @@ func (m *MockAnalyticsService) GetSystemAnalytics(ctx context.Context) (*models.
 	}, nil
 }
 
+func (m *MockAnalyticsService) GetUserOverview(ctx context.Context, userID models.ID) (*models.UserAnalyticsOverview, error) {
+	if m.getUserOverviewError != nil {
+		return nil, m.getUserOverviewError
+	}
+	return &models.UserAnalyticsOverview{
+		PageViews:      300,
+		RSVPCount:      15,
+		ConversionRate: 5,
+		TopSources:     []models.SourceStats{{Source: "whatsapp", Sessions: 120}},
+		Benchmarks: []models.BenchmarkComparison{
+			{Metric: models.BenchmarkConversionRate, Value: 5, Average: 4, Median: 3.5, Difference: 25},
+		},
+	}, nil
+}
+
 func (m *MockAnalyticsService) RefreshSystemAnalytics(ctx context.Context) error {
 	return m.refreshSystemAnalyticsError
 }
 
 // Remaining interface methods with minimal implementations for testing
-func (m *MockAnalyticsService) GetRSVPAnalytics(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error) {
+func (m *MockAnalyticsService) GetRSVPAnalytics(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error) {
 	return []*models.RSVPAnalytics{}, 0, nil
 }
 
-func (m *MockAnalyticsService) GetConversions(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error) {
+func (m *MockAnalyticsService) GetConversions(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error) {
 	return []*models.ConversionEvent{}, 0, nil
 }
 
-func (m *MockAnalyticsService) GetTrafficSources(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.TrafficSourceStats, error) {
+func (m *MockAnalyticsService) GetRequestTrace(ctx context.Context, requestID string) (*models.RequestTrace, error) {
+	if m.getRequestTraceError != nil {
+		return nil, m.getRequestTraceError
+	}
+	trace := &models.RequestTrace{RequestID: requestID, Logs: []models.RequestLogEntry{}}
+	if requestID == "req-with-events" {
+		trace.PageViews = []*models.PageView{{Page: "invitation", Metadata: map[string]interface{}{"request_id": requestID}}}
+	}
+	return trace, nil
+}
+
+func (m *MockAnalyticsService) SubscribeLive(weddingID models.ID) (<-chan *models.AnalyticsLiveEvent, func()) {
+	return m.broker.Subscribe(weddingID)
+}
+
+func (m *MockAnalyticsService) GetTrafficSources(ctx context.Context, weddingID models.ID, limit int) ([]models.TrafficSourceStats, error) {
 	return []models.TrafficSourceStats{}, nil
 }
 
-func (m *MockAnalyticsService) GetDailyMetrics(ctx context.Context, weddingID primitive.ObjectID, startDate, endDate time.Time) ([]models.DailyMetrics, error) {
+func (m *MockAnalyticsService) GetGeoBreakdown(ctx context.Context, weddingID models.ID, limit int) ([]models.CountryStats, error) {
+	return []models.CountryStats{}, nil
+}
+
+func (m *MockAnalyticsService) GetDailyMetrics(ctx context.Context, weddingID models.ID, startDate, endDate time.Time) ([]models.DailyMetrics, error) {
 	return []models.DailyMetrics{}, nil
 }
 
@@ -189,9 +245,9 @@ func TestAnalyticsHandler_TrackPageView(t *testing.T) {
 
 	router.POST("/analytics/track/page-view", handler.TrackPageView)
 
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 	req := TrackPageViewRequest{
-		WeddingID: weddingID.Hex(),
+		WeddingID: weddingID.String(),
 		SessionID: "session123",
 		Page:      "/wedding/test",
 	}
@@ -211,6 +267,61 @@ func TestAnalyticsHandler_TrackPageView(t *testing.T) {
 	assert.Equal(t, "Page view tracked successfully", response["message"])
 }
 
+func TestAnalyticsHandler_TrackPageView_SkippedBySettings(t *testing.T) {
+	mockAnalyticsService := NewMockAnalyticsService()
+	mockAnalyticsService.trackPageViewError = services.ErrTrackingSkipped
+	handler := NewAnalyticsHandler(mockAnalyticsService, nil)
+	router := setupAnalyticsTestRouter()
+	router.POST("/analytics/track/page-view", handler.TrackPageView)
+
+	reqBody, _ := json.Marshal(TrackPageViewRequest{WeddingID: models.NewID().String(), SessionID: "session123", Page: "invitation"})
+	w := httptest.NewRecorder()
+	reqHTTP, _ := http.NewRequest("POST", "/analytics/track/page-view", bytes.NewBuffer(reqBody))
+	reqHTTP.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, reqHTTP)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+}
+
+func TestAnalyticsHandler_TrackPageDuration(t *testing.T) {
+	post := func(handler *AnalyticsHandler, req TrackPageDurationRequest) *httptest.ResponseRecorder {
+		router := setupAnalyticsTestRouter()
+		router.POST("/analytics/track/page-duration", handler.TrackPageDuration)
+
+		reqBody, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		reqHTTP, _ := http.NewRequest("POST", "/analytics/track/page-duration", bytes.NewBuffer(reqBody))
+		reqHTTP.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, reqHTTP)
+		return w
+	}
+	req := TrackPageDurationRequest{
+		WeddingID: models.NewID().String(),
+		SessionID: "session123",
+		Page:      "/wedding/test",
+		Duration:  42,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		w := post(NewAnalyticsHandler(NewMockAnalyticsService(), nil), req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Unknown page view", func(t *testing.T) {
+		mockAnalyticsService := NewMockAnalyticsService()
+		mockAnalyticsService.trackPageDurationError = services.ErrPageViewNotFound
+		w := post(NewAnalyticsHandler(mockAnalyticsService, nil), req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid duration", func(t *testing.T) {
+		mockAnalyticsService := NewMockAnalyticsService()
+		mockAnalyticsService.trackPageDurationError = services.ErrInvalidPageDuration
+		w := post(NewAnalyticsHandler(mockAnalyticsService, nil), req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
 func TestAnalyticsHandler_TrackRSVPSubmission(t *testing.T) {
 	mockAnalyticsService := NewMockAnalyticsService()
 	handler := NewAnalyticsHandler(mockAnalyticsService, nil)
@@ -218,11 +329,11 @@ func TestAnalyticsHandler_TrackRSVPSubmission(t *testing.T) {
 
 	router.POST("/analytics/track/rsvp-submission", handler.TrackRSVPSubmission)
 
-	weddingID := primitive.NewObjectID()
-	rsvpID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	rsvpID := models.NewID()
 	req := TrackRSVPSubmissionRequest{
-		WeddingID: weddingID.Hex(),
-		RSVPID:    rsvpID.Hex(),
+		WeddingID: weddingID.String(),
+		RSVPID:    rsvpID.String(),
 		SessionID: "session123",
 		Source:    "web",
 	}
@@ -249,9 +360,9 @@ func TestAnalyticsHandler_TrackRSVPAbandonment(t *testing.T) {
 
 	router.POST("/analytics/track/rsvp-abandonment", handler.TrackRSVPAbandonment)
 
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 	req := TrackRSVPAbandonmentRequest{
-		WeddingID:     weddingID.Hex(),
+		WeddingID:     weddingID.String(),
 		SessionID:     "session123",
 		AbandonedStep: "personal_info",
 		FormErrors:    []string{"Invalid email"},
@@ -279,9 +390,9 @@ func TestAnalyticsHandler_TrackConversion(t *testing.T) {
 
 	router.POST("/analytics/track/conversion", handler.TrackConversion)
 
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 	req := TrackConversionRequest{
-		WeddingID:  weddingID.Hex(),
+		WeddingID:  weddingID.String(),
 		SessionID:  "session123",
 		Event:      "rsvp_completion",
 		Value:      1.0,
@@ -303,6 +414,78 @@ func TestAnalyticsHandler_TrackConversion(t *testing.T) {
 	assert.Equal(t, "Conversion tracked successfully", response["message"])
 }
 
+// staticFeatures turns features on and off for every wedding
+type staticFeatures map[string]bool
+
+func (f staticFeatures) Enabled(ctx context.Context, weddingID models.ID, feature string) bool {
+	return f[feature]
+}
+
+func TestAnalyticsHandler_TrackBatch(t *testing.T) {
+	weddingID := models.NewID()
+	post := func(handler *AnalyticsHandler, body interface{}) *httptest.ResponseRecorder {
+		router := setupAnalyticsTestRouter()
+		router.POST("/analytics/track/batch", handler.TrackBatch)
+		reqBody, _ := json.Marshal(body)
+		w := httptest.NewRecorder()
+		reqHTTP, _ := http.NewRequest("POST", "/analytics/track/batch", bytes.NewBuffer(reqBody))
+		reqHTTP.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, reqHTTP)
+		return w
+	}
+
+	t.Run("Reports partial failures", func(t *testing.T) {
+		mockAnalyticsService := NewMockAnalyticsService()
+		mockAnalyticsService.trackBatchErrors = []error{nil, services.ErrInvalidTrackPage, errors.New("write failed")}
+		handler := NewAnalyticsHandler(mockAnalyticsService, nil)
+
+		w := post(handler, TrackBatchRequest{Events: []TrackBatchEventRequest{
+			{Type: "page_view", WeddingID: weddingID.String(), SessionID: "s1", Page: "invitation"},
+			{Type: "page_view", WeddingID: "not-an-id", SessionID: "s1", Page: "invitation"},
+			{Type: "page_view", WeddingID: weddingID.String(), SessionID: "s1", Page: "admin"},
+			{Type: "conversion", WeddingID: weddingID.String(), SessionID: "s1", Event: "rsvp_completed"},
+		}})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response TrackBatchResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 4, response.Received)
+		assert.Equal(t, 1, response.Recorded)
+		assert.Equal(t, []TrackBatchFailure{
+			{Index: 1, Error: "Invalid wedding ID"},
+			{Index: 2, Error: "invalid page name"},
+			{Index: 3, Error: "Failed to record event", Retryable: true},
+		}, response.Failed)
+		assert.Len(t, mockAnalyticsService.trackedBatch, 3)
+	})
+
+	t.Run("Skips weddings with tracking disabled", func(t *testing.T) {
+		mockAnalyticsService := NewMockAnalyticsService()
+		handler := NewAnalyticsHandler(mockAnalyticsService, nil)
+		handler.SetFeatureFlags(staticFeatures{services.FeatureAnalyticsTracking: false})
+
+		w := post(handler, TrackBatchRequest{Events: []TrackBatchEventRequest{
+			{Type: "page_view", WeddingID: weddingID.String(), SessionID: "s1", Page: "invitation"},
+		}})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response TrackBatchResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 1, response.Skipped)
+		assert.Empty(t, mockAnalyticsService.trackedBatch)
+	})
+
+	t.Run("Rejects empty and oversized batches", func(t *testing.T) {
+		handler := NewAnalyticsHandler(NewMockAnalyticsService(), nil)
+
+		w := post(handler, TrackBatchRequest{Events: []TrackBatchEventRequest{}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = post(handler, TrackBatchRequest{Events: make([]TrackBatchEventRequest, services.MaxTrackBatchSize+1)})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
 func TestAnalyticsHandler_GetWeddingAnalytics(t *testing.T) {
 	// Test skipped due to wedding service dependency
 	// In a real implementation, this would require a proper wedding service mock
@@ -339,6 +522,46 @@ func TestAnalyticsHandler_GetSystemAnalytics(t *testing.T) {
 	t.Skip("Analytics retrieval tests require wedding service setup")
 }
 
+func TestAnalyticsHandler_GetUserOverview(t *testing.T) {
+	get := func(service *MockAnalyticsService, userID string) *httptest.ResponseRecorder {
+		router := setupAnalyticsTestRouter()
+		router.GET("/users/analytics/overview", func(c *gin.Context) {
+			if userID != "" {
+				c.Set("user_id", userID)
+			}
+		}, NewAnalyticsHandler(service, nil).GetUserOverview)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/users/analytics/overview", nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Returns the overview", func(t *testing.T) {
+		w := get(NewMockAnalyticsService(), models.NewID().String())
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data models.UserAnalyticsOverview `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(300), response.Data.PageViews)
+		require.Len(t, response.Data.Benchmarks, 1)
+		assert.Equal(t, 4.0, response.Data.Benchmarks[0].Average)
+	})
+
+	t.Run("Requires authentication", func(t *testing.T) {
+		w := get(NewMockAnalyticsService(), "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Service failure", func(t *testing.T) {
+		service := NewMockAnalyticsService()
+		service.getUserOverviewError = errors.New("database down")
+		w := get(service, models.NewID().String())
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
 func TestAnalyticsHandler_RefreshSystemAnalytics(t *testing.T) {
 	// Test skipped due to wedding service dependency
 	// In a real implementation, this would require a proper wedding service mock
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// APIKeyManager manages the API keys a user creates for third-party integrations
+type APIKeyManager interface {
+	CreateKey(ctx context.Context, userID models.ID, req services.CreateAPIKeyRequest) (*models.APIKey, string, error)
+	ListKeys(ctx context.Context, userID models.ID) ([]*models.APIKey, error)
+	UpdateKey(ctx context.Context, userID, id models.ID, req services.UpdateAPIKeyRequest) (*models.APIKey, error)
+	RevokeKey(ctx context.Context, userID, id models.ID) error
+	GetUsage(ctx context.Context, userID, id models.ID, days int) (*services.APIKeyUsageReport, error)
+}
+
+// APIKeyHandler serves API key management and usage
+type APIKeyHandler struct {
+	keys APIKeyManager
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(keys APIKeyManager) *APIKeyHandler {
+	return &APIKeyHandler{keys: keys}
+}
+
+// CreateAPIKeyResponse includes the key itself, which is only returned once
+type CreateAPIKeyResponse struct {
+	*models.APIKey
+	Key string `json:"key"`
+}
+
+// CreateKey godoc
+// @Summary Create an API key
+// @Description Create a key for a third-party integration, scoped read-only or read-write to the given weddings. Send it in the X-API-Key header.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param key body services.CreateAPIKeyRequest true "API key data"
+// @Success 201 {object} CreateAPIKeyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/api-keys [post]
+func (h *APIKeyHandler) CreateKey(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req services.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	key, value, err := h.keys.CreateKey(c.Request.Context(), userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create API key")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, CreateAPIKeyResponse{
+		APIKey: key,
+		Key:    value,
+	})
+}
+
+// ListKeys godoc
+// @Summary List API keys
+// @Description List the current user's API keys with their request counts and last use
+// @Tags api-keys
+// @Produce json
+// @Success 200 {array} models.APIKey
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/api-keys [get]
+func (h *APIKeyHandler) ListKeys(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	keys, err := h.keys.ListKeys(c.Request.Context(), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to list API keys")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, keys)
+}
+
+// UpdateKey godoc
+// @Summary Update an API key
+// @Description Rename an API key or change its wedding scopes or rate limit
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param id path string true "API key ID"
+// @Param key body services.UpdateAPIKeyRequest true "API key changes"
+// @Success 200 {object} models.APIKey
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/api-keys/{id} [put]
+func (h *APIKeyHandler) UpdateKey(c *gin.Context) {
+	keyID, userID, ok := h.parseKeyRequest(c)
+	if !ok {
+		return
+	}
+
+	var req services.UpdateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	key, err := h.keys.UpdateKey(c.Request.Context(), userID, keyID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update API key")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, key)
+}
+
+// RevokeKey godoc
+// @Summary Revoke an API key
+// @Description Stop an API key from authenticating; its usage history is kept
+// @Tags api-keys
+// @Param id path string true "API key ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeKey(c *gin.Context) {
+	keyID, userID, ok := h.parseKeyRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.keys.RevokeKey(c.Request.Context(), userID, keyID); err != nil {
+		h.handleError(c, err, "Failed to revoke API key")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetUsage godoc
+// @Summary Get API key usage
+// @Description Daily requests, errors and rate-limited requests of an API key
+// @Tags api-keys
+// @Produce json
+// @Param id path string true "API key ID"
+// @Param days query int false "Number of days, up to 90" default(30)
+// @Success 200 {object} services.APIKeyUsageReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/api-keys/{id}/usage [get]
+func (h *APIKeyHandler) GetUsage(c *gin.Context) {
+	keyID, userID, ok := h.parseKeyRequest(c)
+	if !ok {
+		return
+	}
+
+	days := 30
+	if value := c.Query("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 90 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "days must be between 1 and 90")
+			return
+		}
+		days = parsed
+	}
+
+	report, err := h.keys.GetUsage(c.Request.Context(), userID, keyID, days)
+	if err != nil {
+		h.handleError(c, err, "Failed to get API key usage")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, report)
+}
+
+func (h *APIKeyHandler) parseKeyRequest(c *gin.Context) (models.ID, models.ID, bool) {
+	keyID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid API key ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+
+	return keyID, userID, true
+}
+
+func (h *APIKeyHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrAPIKeyNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "API key not found")
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to grant access to this wedding")
+	case errors.Is(err, services.ErrTooManyAPIKeys):
+		utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrInvalidAPIKeyScope), errors.Is(err, services.ErrInvalidAPIKeyExpiry),
+		errors.Is(err, services.ErrInvalidAPIKey):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
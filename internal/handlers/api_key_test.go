@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockAPIKeyManager is a mock implementation of APIKeyManager
+type MockAPIKeyManager struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyManager) CreateKey(ctx context.Context, userID models.ID, req services.CreateAPIKeyRequest) (*models.APIKey, string, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*models.APIKey), args.String(1), args.Error(2)
+}
+
+func (m *MockAPIKeyManager) ListKeys(ctx context.Context, userID models.ID) ([]*models.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyManager) UpdateKey(ctx context.Context, userID, id models.ID, req services.UpdateAPIKeyRequest) (*models.APIKey, error) {
+	args := m.Called(ctx, userID, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyManager) RevokeKey(ctx context.Context, userID, id models.ID) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyManager) GetUsage(ctx context.Context, userID, id models.ID, days int) (*services.APIKeyUsageReport, error) {
+	args := m.Called(ctx, userID, id, days)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.APIKeyUsageReport), args.Error(1)
+}
+
+func setupAPIKeyTestRouter(handler *APIKeyHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	keys := router.Group("/api/v1/users/api-keys")
+	keys.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	keys.GET("", handler.ListKeys)
+	keys.POST("", handler.CreateKey)
+	keys.PUT("/:id", handler.UpdateKey)
+	keys.DELETE("/:id", handler.RevokeKey)
+	keys.GET("/:id/usage", handler.GetUsage)
+
+	return router
+}
+
+func TestAPIKeyHandler_CreateKey(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	scopes := `"scopes":[{"wedding_id":"` + weddingID.String() + `","access":"read"}]`
+
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		expected int
+	}{
+		{"created", `{"name":"Planner tool",` + scopes + `}`, nil, http.StatusCreated},
+		{"missing scopes", `{"name":"Planner tool"}`, nil, http.StatusBadRequest},
+		{"invalid access", `{"name":"Planner tool","scopes":[{"wedding_id":"` + weddingID.String() + `","access":"admin"}]}`, nil, http.StatusBadRequest},
+		{"rate limit too high", `{"name":"Planner tool",` + scopes + `,"rate_limit":5000}`, nil, http.StatusBadRequest},
+		{"wedding of another user", `{"name":"Planner tool",` + scopes + `}`, services.ErrUnauthorized, http.StatusForbidden},
+		{"too many keys", `{"name":"Planner tool",` + scopes + `}`, services.ErrTooManyAPIKeys, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := new(MockAPIKeyManager)
+			if tt.err != nil {
+				keys.On("CreateKey", mock.Anything, userID, mock.Anything).Return(nil, "", tt.err)
+			} else {
+				keys.On("CreateKey", mock.Anything, userID, mock.Anything).
+					Return(&models.APIKey{ID: models.NewID(), Prefix: "wik_abcdefgh", KeyHash: "stored-hash"}, "wik_abcdefgh-secret", nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/api-keys", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupAPIKeyTestRouter(NewAPIKeyHandler(keys), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			if tt.expected == http.StatusCreated {
+				var response struct {
+					Data map[string]interface{} `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "wik_abcdefgh-secret", response.Data["key"])
+				assert.Equal(t, "wik_abcdefgh", response.Data["prefix"])
+				assert.NotContains(t, w.Body.String(), "stored-hash")
+			}
+		})
+	}
+}
+
+func TestAPIKeyHandler_RevokeKey(t *testing.T) {
+	userID := models.NewID()
+	keyID := models.NewID()
+
+	t.Run("revoked", func(t *testing.T) {
+		keys := new(MockAPIKeyManager)
+		keys.On("RevokeKey", mock.Anything, userID, keyID).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/api-keys/"+keyID.String(), nil)
+		w := httptest.NewRecorder()
+		setupAPIKeyTestRouter(NewAPIKeyHandler(keys), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		keys := new(MockAPIKeyManager)
+		keys.On("RevokeKey", mock.Anything, userID, keyID).Return(services.ErrAPIKeyNotFound)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/api-keys/"+keyID.String(), nil)
+		w := httptest.NewRecorder()
+		setupAPIKeyTestRouter(NewAPIKeyHandler(keys), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestAPIKeyHandler_GetUsage(t *testing.T) {
+	userID := models.NewID()
+	keyID := models.NewID()
+
+	keys := new(MockAPIKeyManager)
+	keys.On("GetUsage", mock.Anything, userID, keyID, 7).
+		Return(&services.APIKeyUsageReport{KeyID: keyID, TotalRequests: 12}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/api-keys/"+keyID.String()+"/usage?days=7", nil)
+	w := httptest.NewRecorder()
+	router := setupAPIKeyTestRouter(NewAPIKeyHandler(keys), userID)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total_requests":12`)
+	keys.AssertExpectations(t)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/users/api-keys/"+keyID.String()+"/usage?days=365", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
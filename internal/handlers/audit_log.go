@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// AuditLogReader lists the audit log for admins and wedding owners
+type AuditLogReader interface {
+	ListAuditLogs(ctx context.Context, filters repository.AuditLogFilters, page, pageSize int) ([]*models.AuditLog, int64, error)
+	ListWeddingAuditLogs(ctx context.Context, weddingID, userID models.ID, filters repository.AuditLogFilters, page, pageSize int) ([]*models.AuditLog, int64, error)
+}
+
+// AuditLogHandler serves the audit log
+type AuditLogHandler struct {
+	auditLogs AuditLogReader
+}
+
+// NewAuditLogHandler creates a new audit log handler
+func NewAuditLogHandler(auditLogs AuditLogReader) *AuditLogHandler {
+	return &AuditLogHandler{auditLogs: auditLogs}
+}
+
+// ListAuditLogs godoc
+// @Summary List the audit log
+// @Description List who created, changed and deleted weddings, guests, RSVPs, media, themes and accounts, newest first (admin only)
+// @Tags admin
+// @Produce json
+// @Param wedding_id query string false "Wedding ID"
+// @Param actor_id query string false "ID of the user who made the change"
+// @Param action query string false "Action, e.g. wedding.update"
+// @Param target_type query string false "Target type: wedding, guest, rsvp, media, theme or user"
+// @Param target_id query string false "Target ID"
+// @Param from query string false "Earliest time (RFC3339)"
+// @Param to query string false "Latest time (RFC3339)"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} PaginationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/audit-logs [get]
+func (h *AuditLogHandler) ListAuditLogs(c *gin.Context) {
+	filters, err := parseAuditLogFilters(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if weddingID := c.Query("wedding_id"); weddingID != "" {
+		id, err := models.ParseID(weddingID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding_id")
+			return
+		}
+		filters.WeddingID = &id
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+	entries, total, err := h.auditLogs.ListAuditLogs(c.Request.Context(), filters, page, pageSize)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get audit logs")
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, entries, int64(len(entries)), total, page, pageSize)
+}
+
+// ListWeddingAuditLogs godoc
+// @Summary List a wedding's audit log
+// @Description List who changed the wedding, its guests and its RSVPs, newest first. Changes without an actor were made by guests (wedding owner only).
+// @Tags weddings
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param actor_id query string false "ID of the user who made the change"
+// @Param action query string false "Action, e.g. guest.update"
+// @Param target_type query string false "Target type: wedding, guest or rsvp"
+// @Param target_id query string false "Target ID"
+// @Param from query string false "Earliest time (RFC3339)"
+// @Param to query string false "Latest time (RFC3339)"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} PaginationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/audit-logs [get]
+func (h *AuditLogHandler) ListWeddingAuditLogs(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	filters, err := parseAuditLogFilters(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+	entries, total, err := h.auditLogs.ListWeddingAuditLogs(c.Request.Context(), weddingID, userID, filters, page, pageSize)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWeddingNotFound):
+			utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+		case errors.Is(err, services.ErrUnauthorized):
+			utils.ErrorResponse(c, http.StatusForbidden, "Only the wedding's owner may view its audit log")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get audit logs")
+		}
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, entries, int64(len(entries)), total, page, pageSize)
+}
+
+// parseAuditLogFilters reads the audit log filters shared by both listings
+func parseAuditLogFilters(c *gin.Context) (repository.AuditLogFilters, error) {
+	filters := repository.AuditLogFilters{
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+	}
+	if actorID := c.Query("actor_id"); actorID != "" {
+		id, err := models.ParseID(actorID)
+		if err != nil {
+			return filters, errors.New("invalid actor_id")
+		}
+		filters.ActorID = &id
+	}
+	if from := c.Query("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return filters, errors.New("from must be an RFC3339 time")
+		}
+		filters.From = &t
+	}
+	if to := c.Query("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return filters, errors.New("to must be an RFC3339 time")
+		}
+		filters.To = &t
+	}
+	return filters, nil
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockAuditLogReader is a mock implementation of AuditLogReader
+type MockAuditLogReader struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogReader) ListAuditLogs(ctx context.Context, filters repository.AuditLogFilters, page, pageSize int) ([]*models.AuditLog, int64, error) {
+	args := m.Called(ctx, filters, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*models.AuditLog), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAuditLogReader) ListWeddingAuditLogs(ctx context.Context, weddingID, userID models.ID, filters repository.AuditLogFilters, page, pageSize int) ([]*models.AuditLog, int64, error) {
+	args := m.Called(ctx, weddingID, userID, filters, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*models.AuditLog), args.Get(1).(int64), args.Error(2)
+}
+
+func setupAuditLogTestRouter(handler *AuditLogHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	router.GET("/api/v1/admin/audit-logs", handler.ListAuditLogs)
+	router.GET("/api/v1/weddings/:id/audit-logs", handler.ListWeddingAuditLogs)
+	return router
+}
+
+func TestAuditLogHandler_ListAuditLogs(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+
+	t.Run("Success - filters", func(t *testing.T) {
+		reader := &MockAuditLogReader{}
+		router := setupAuditLogTestRouter(NewAuditLogHandler(reader), userID)
+
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		reader.On("ListAuditLogs", mock.Anything, repository.AuditLogFilters{
+			WeddingID:  &weddingID,
+			Action:     models.AuditGuestUpdate,
+			TargetType: models.AuditTargetGuest,
+			From:       &from,
+		}, 2, 10).Return([]*models.AuditLog{{Action: models.AuditGuestUpdate}}, int64(11), nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/audit-logs?wedding_id="+weddingID.String()+
+			"&action=guest.update&target_type=guest&from=2026-01-01T00:00:00Z&page=2&size=10", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"guest.update"`)
+		reader.AssertExpectations(t)
+	})
+
+	t.Run("Error - invalid filters", func(t *testing.T) {
+		router := setupAuditLogTestRouter(NewAuditLogHandler(&MockAuditLogReader{}), userID)
+		for _, query := range []string{"wedding_id=nope", "actor_id=nope", "from=yesterday", "to=2026-01-01"} {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/audit-logs?"+query, nil))
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+}
+
+func TestAuditLogHandler_ListWeddingAuditLogs(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"Success", nil, http.StatusOK},
+		{"Error - not the owner", services.ErrUnauthorized, http.StatusForbidden},
+		{"Error - wedding not found", services.ErrWeddingNotFound, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &MockAuditLogReader{}
+			router := setupAuditLogTestRouter(NewAuditLogHandler(reader), userID)
+			if tt.err != nil {
+				reader.On("ListWeddingAuditLogs", mock.Anything, weddingID, userID, repository.AuditLogFilters{}, 1, 20).Return(nil, int64(0), tt.err)
+			} else {
+				reader.On("ListWeddingAuditLogs", mock.Anything, weddingID, userID, repository.AuditLogFilters{}, 1, 20).Return([]*models.AuditLog{}, int64(0), nil)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/weddings/"+weddingID.String()+"/audit-logs", nil))
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
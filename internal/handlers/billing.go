@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// maxStripeWebhookBody bounds the webhook events read from Stripe
+const maxStripeWebhookBody = 1 << 20
+
+// BillingManager reports users' plans and applies Stripe's subscription events
+type BillingManager interface {
+	GetOverview(ctx context.Context, userID models.ID) (*services.BillingOverview, error)
+	HandleStripeWebhook(ctx context.Context, body []byte, signature string) error
+}
+
+// BillingHandler serves plans and the Stripe webhook
+type BillingHandler struct {
+	billing BillingManager
+}
+
+// NewBillingHandler creates a new billing handler
+func NewBillingHandler(billing BillingManager) *BillingHandler {
+	return &BillingHandler{billing: billing}
+}
+
+// GetBilling godoc
+// @Summary Get the current plan
+// @Description Get the user's plan, its limits, their usage and, on the free plan, the checkout link to upgrade
+// @Tags billing
+// @Produce json
+// @Success 200 {object} services.BillingOverview
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /billing [get]
+func (h *BillingHandler) GetBilling(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	overview, err := h.billing.GetOverview(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			utils.ErrorResponse(c, http.StatusNotFound, "User not found")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get billing")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, overview)
+}
+
+// ReceiveStripeWebhook godoc
+// @Summary Receive Stripe subscription events
+// @Description Webhook events of Stripe, signed with the endpoint's signing secret. Completed checkouts and subscription changes update the user's plan.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Success 200 {object} utils.APIResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /webhooks/stripe [post]
+func (h *BillingHandler) ReceiveStripeWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxStripeWebhookBody))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	// A failure answers 500 so that Stripe delivers the event again
+	if err := h.billing.HandleStripeWebhook(c.Request.Context(), body, c.GetHeader(services.StripeSignatureHeader)); err != nil {
+		switch {
+		case errors.Is(err, services.ErrBillingNotConfigured):
+			utils.ErrorResponse(c, http.StatusServiceUnavailable, err.Error())
+		case errors.Is(err, services.ErrInvalidBillingWebhook):
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid webhook event")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to process webhook event")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.APIResponse{
+		Success: true,
+		Message: "Webhook received",
+	})
+}
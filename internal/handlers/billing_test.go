@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockBillingManager is a mock implementation of BillingManager
+type MockBillingManager struct {
+	mock.Mock
+}
+
+func (m *MockBillingManager) GetOverview(ctx context.Context, userID models.ID) (*services.BillingOverview, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.BillingOverview), args.Error(1)
+}
+
+func (m *MockBillingManager) HandleStripeWebhook(ctx context.Context, body []byte, signature string) error {
+	args := m.Called(ctx, body, signature)
+	return args.Error(0)
+}
+
+func setupBillingTestRouter(handler *BillingHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	protected := router.Group("/api/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	protected.GET("/billing", handler.GetBilling)
+	router.POST("/api/v1/webhooks/stripe", handler.ReceiveStripeWebhook)
+
+	return router
+}
+
+func TestBillingHandler_GetBilling(t *testing.T) {
+	userID := models.NewID()
+
+	t.Run("success", func(t *testing.T) {
+		billing := new(MockBillingManager)
+		billing.On("GetOverview", mock.Anything, userID).Return(&services.BillingOverview{
+			Plan:   models.PlanFree,
+			Limits: models.PlanFree.Limits(),
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/billing", nil)
+		w := httptest.NewRecorder()
+		setupBillingTestRouter(NewBillingHandler(billing), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"plan":"free"`)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		billing := new(MockBillingManager)
+		billing.On("GetOverview", mock.Anything, userID).Return(nil, services.ErrUserNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/billing", nil)
+		w := httptest.NewRecorder()
+		setupBillingTestRouter(NewBillingHandler(billing), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestBillingHandler_ReceiveStripeWebhook(t *testing.T) {
+	body := `{"id":"evt_1","type":"customer.subscription.updated"}`
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"accepted", nil, http.StatusOK},
+		{"invalid signature", services.ErrInvalidBillingWebhook, http.StatusBadRequest},
+		{"not configured", services.ErrBillingNotConfigured, http.StatusServiceUnavailable},
+		{"processing failure", assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			billing := new(MockBillingManager)
+			billing.On("HandleStripeWebhook", mock.Anything, []byte(body), "t=1,v1=abc").Return(tt.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/stripe", strings.NewReader(body))
+			req.Header.Set(services.StripeSignatureHeader, "t=1,v1=abc")
+			w := httptest.NewRecorder()
+			setupBillingTestRouter(NewBillingHandler(billing), models.NewID()).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			billing.AssertExpectations(t)
+		})
+	}
+}
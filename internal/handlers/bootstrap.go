@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// BootstrapTokenHeader carries the one-time bootstrap token
+const BootstrapTokenHeader = "X-Bootstrap-Token"
+
+// BootstrapHandler handles environment provisioning requests
+type BootstrapHandler struct {
+	bootstrapService *services.BootstrapService
+}
+
+// NewBootstrapHandler creates a new bootstrap handler
+func NewBootstrapHandler(bootstrapService *services.BootstrapService) *BootstrapHandler {
+	return &BootstrapHandler{
+		bootstrapService: bootstrapService,
+	}
+}
+
+// Bootstrap godoc
+// @Summary Bootstrap a new environment
+// @Description Idempotently create the initial admin user, default themes, and system config. Guarded by the one-time bootstrap token.
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param X-Bootstrap-Token header string true "Bootstrap token"
+// @Param bootstrap body services.BootstrapRequest true "Bootstrap data"
+// @Success 200 {object} services.BootstrapResult "Already bootstrapped"
+// @Success 201 {object} services.BootstrapResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/system/bootstrap [post]
+func (h *BootstrapHandler) Bootstrap(c *gin.Context) {
+	var req services.BootstrapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.bootstrapService.Bootstrap(c.Request.Context(), c.GetHeader(BootstrapTokenHeader), req)
+	if err != nil {
+		switch err {
+		case services.ErrBootstrapDisabled:
+			// Hide the endpoint entirely when no token is configured
+			utils.ErrorResponse(c, http.StatusNotFound, "Not found")
+		case services.ErrInvalidBootstrapToken:
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid bootstrap token")
+		case services.ErrInvalidPassword:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Admin password does not meet requirements")
+		case services.ErrAdminAccountExists:
+			utils.ErrorResponse(c, http.StatusConflict, "An account with the admin email already exists")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to bootstrap environment")
+		}
+		return
+	}
+
+	if result.AlreadyBootstrapped {
+		utils.Response(c, http.StatusOK, result)
+		return
+	}
+	utils.Response(c, http.StatusCreated, result)
+}
@@ -0,0 +1,424 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// BudgetManager manages a wedding's budget categories and vendor payments
+type BudgetManager interface {
+	GetSummary(ctx context.Context, weddingID, userID models.ID) (*models.BudgetSummary, error)
+	ListCategories(ctx context.Context, weddingID, userID models.ID) ([]*models.BudgetCategory, error)
+	CreateCategory(ctx context.Context, weddingID, userID models.ID, req services.BudgetCategoryRequest) (*models.BudgetCategory, error)
+	UpdateCategory(ctx context.Context, weddingID, categoryID, userID models.ID, req services.BudgetCategoryRequest) (*models.BudgetCategory, error)
+	DeleteCategory(ctx context.Context, weddingID, categoryID, userID models.ID) error
+	ListPayments(ctx context.Context, weddingID, userID models.ID, filters repository.BudgetPaymentFilters) ([]*models.BudgetPayment, error)
+	CreatePayment(ctx context.Context, weddingID, userID models.ID, req services.BudgetPaymentRequest) (*models.BudgetPayment, error)
+	UpdatePayment(ctx context.Context, weddingID, paymentID, userID models.ID, req services.BudgetPaymentRequest) (*models.BudgetPayment, error)
+	DeletePayment(ctx context.Context, weddingID, paymentID, userID models.ID) error
+}
+
+// BudgetHandler serves a wedding's budget
+type BudgetHandler struct {
+	budget BudgetManager
+}
+
+// NewBudgetHandler creates a new wedding budget handler
+func NewBudgetHandler(budget BudgetManager) *BudgetHandler {
+	return &BudgetHandler{budget: budget}
+}
+
+// GetSummary godoc
+// @Summary Get a wedding's budget
+// @Description Compare the planned spending of each category with its payments, and list the unpaid payments due within 30 days (wedding editors only)
+// @Tags budget
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {object} models.BudgetSummary
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/budget [get]
+func (h *BudgetHandler) GetSummary(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.budget.GetSummary(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get budget")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, summary)
+}
+
+// ListCategories godoc
+// @Summary List budget categories
+// @Description Get the categories of a wedding's budget in display order (wedding editors only)
+// @Tags budget
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {array} models.BudgetCategory
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/budget/categories [get]
+func (h *BudgetHandler) ListCategories(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	categories, err := h.budget.ListCategories(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get budget categories")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, categories)
+}
+
+// CreateCategory godoc
+// @Summary Add a budget category
+// @Description Add a category, e.g. catering, with the amount planned for it (wedding editors only)
+// @Tags budget
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param category body services.BudgetCategoryRequest true "Budget category"
+// @Success 201 {object} models.BudgetCategory
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/budget/categories [post]
+func (h *BudgetHandler) CreateCategory(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindBudgetCategory(c)
+	if !ok {
+		return
+	}
+
+	category, err := h.budget.CreateCategory(c.Request.Context(), weddingID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create budget category")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, category)
+}
+
+// UpdateCategory godoc
+// @Summary Update a budget category
+// @Description Replace the details of a budget category (wedding editors only)
+// @Tags budget
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param category_id path string true "Budget category ID"
+// @Param category body services.BudgetCategoryRequest true "Budget category"
+// @Success 200 {object} models.BudgetCategory
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/budget/categories/{category_id} [put]
+func (h *BudgetHandler) UpdateCategory(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	categoryID, err := models.ParseID(c.Param("category_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid budget category ID")
+		return
+	}
+
+	req, ok := bindBudgetCategory(c)
+	if !ok {
+		return
+	}
+
+	category, err := h.budget.UpdateCategory(c.Request.Context(), weddingID, categoryID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update budget category")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, category)
+}
+
+// DeleteCategory godoc
+// @Summary Delete a budget category
+// @Description Remove a category without payments from a wedding's budget (wedding editors only)
+// @Tags budget
+// @Param id path string true "Wedding ID"
+// @Param category_id path string true "Budget category ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/budget/categories/{category_id} [delete]
+func (h *BudgetHandler) DeleteCategory(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	categoryID, err := models.ParseID(c.Param("category_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid budget category ID")
+		return
+	}
+
+	if err := h.budget.DeleteCategory(c.Request.Context(), weddingID, categoryID, userID); err != nil {
+		h.handleError(c, err, "Failed to delete budget category")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListPayments godoc
+// @Summary List budget payments
+// @Description Get the vendor payments of a wedding by due date, undated ones last (wedding editors only)
+// @Tags budget
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param category_id query string false "Filter by category"
+// @Param status query string false "Filter by status" Enums(pending, paid)
+// @Success 200 {array} models.BudgetPayment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/budget/payments [get]
+func (h *BudgetHandler) ListPayments(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	filters := repository.BudgetPaymentFilters{Status: models.BudgetPaymentStatus(c.Query("status"))}
+	if categoryID := c.Query("category_id"); categoryID != "" {
+		id, err := models.ParseID(categoryID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid budget category ID")
+			return
+		}
+		filters.CategoryID = &id
+	}
+
+	payments, err := h.budget.ListPayments(c.Request.Context(), weddingID, userID, filters)
+	if err != nil {
+		h.handleError(c, err, "Failed to get budget payments")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, payments)
+}
+
+// CreatePayment godoc
+// @Summary Add a budget payment
+// @Description Record an amount owed or paid to a vendor. Unpaid payments with a due date remind the wedding owner ahead of it. (wedding editors only)
+// @Tags budget
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param payment body services.BudgetPaymentRequest true "Budget payment"
+// @Success 201 {object} models.BudgetPayment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/budget/payments [post]
+func (h *BudgetHandler) CreatePayment(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindBudgetPayment(c)
+	if !ok {
+		return
+	}
+
+	payment, err := h.budget.CreatePayment(c.Request.Context(), weddingID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create budget payment")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, payment)
+}
+
+// UpdatePayment godoc
+// @Summary Update a budget payment
+// @Description Replace the details of a payment, e.g. to mark it paid (wedding editors only)
+// @Tags budget
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param payment_id path string true "Budget payment ID"
+// @Param payment body services.BudgetPaymentRequest true "Budget payment"
+// @Success 200 {object} models.BudgetPayment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/budget/payments/{payment_id} [put]
+func (h *BudgetHandler) UpdatePayment(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	paymentID, err := models.ParseID(c.Param("payment_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid budget payment ID")
+		return
+	}
+
+	req, ok := bindBudgetPayment(c)
+	if !ok {
+		return
+	}
+
+	payment, err := h.budget.UpdatePayment(c.Request.Context(), weddingID, paymentID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update budget payment")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, payment)
+}
+
+// DeletePayment godoc
+// @Summary Delete a budget payment
+// @Description Remove a payment from a wedding's budget (wedding editors only)
+// @Tags budget
+// @Param id path string true "Wedding ID"
+// @Param payment_id path string true "Budget payment ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/budget/payments/{payment_id} [delete]
+func (h *BudgetHandler) DeletePayment(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	paymentID, err := models.ParseID(c.Param("payment_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid budget payment ID")
+		return
+	}
+
+	if err := h.budget.DeletePayment(c.Request.Context(), weddingID, paymentID, userID); err != nil {
+		h.handleError(c, err, "Failed to delete budget payment")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseWeddingRequest reads the wedding and the authenticated user of a budget request
+func (h *BudgetHandler) parseWeddingRequest(c *gin.Context) (models.ID, models.ID, bool) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+
+	return weddingID, userID, true
+}
+
+// bindBudgetCategory reads and validates a budget category from the request body
+func bindBudgetCategory(c *gin.Context) (services.BudgetCategoryRequest, bool) {
+	var req services.BudgetCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return req, false
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return req, false
+	}
+
+	return req, true
+}
+
+// bindBudgetPayment reads and validates a budget payment from the request body
+func bindBudgetPayment(c *gin.Context) (services.BudgetPaymentRequest, bool) {
+	var req services.BudgetPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return req, false
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return req, false
+	}
+
+	return req, true
+}
+
+func (h *BudgetHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrBudgetCategoryNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Budget category not found")
+	case errors.Is(err, services.ErrBudgetPaymentNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Budget payment not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to manage this wedding's budget")
+	case errors.Is(err, services.ErrInvalidBudgetPayment):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrBudgetFull), errors.Is(err, services.ErrBudgetCategoryInUse):
+		utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
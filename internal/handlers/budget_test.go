@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockBudgetManager is a mock implementation of BudgetManager
+type MockBudgetManager struct {
+	mock.Mock
+}
+
+func (m *MockBudgetManager) GetSummary(ctx context.Context, weddingID, userID models.ID) (*models.BudgetSummary, error) {
+	args := m.Called(ctx, weddingID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BudgetSummary), args.Error(1)
+}
+
+func (m *MockBudgetManager) ListCategories(ctx context.Context, weddingID, userID models.ID) ([]*models.BudgetCategory, error) {
+	args := m.Called(ctx, weddingID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.BudgetCategory), args.Error(1)
+}
+
+func (m *MockBudgetManager) CreateCategory(ctx context.Context, weddingID, userID models.ID, req services.BudgetCategoryRequest) (*models.BudgetCategory, error) {
+	args := m.Called(ctx, weddingID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BudgetCategory), args.Error(1)
+}
+
+func (m *MockBudgetManager) UpdateCategory(ctx context.Context, weddingID, categoryID, userID models.ID, req services.BudgetCategoryRequest) (*models.BudgetCategory, error) {
+	args := m.Called(ctx, weddingID, categoryID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BudgetCategory), args.Error(1)
+}
+
+func (m *MockBudgetManager) DeleteCategory(ctx context.Context, weddingID, categoryID, userID models.ID) error {
+	args := m.Called(ctx, weddingID, categoryID, userID)
+	return args.Error(0)
+}
+
+func (m *MockBudgetManager) ListPayments(ctx context.Context, weddingID, userID models.ID, filters repository.BudgetPaymentFilters) ([]*models.BudgetPayment, error) {
+	args := m.Called(ctx, weddingID, userID, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.BudgetPayment), args.Error(1)
+}
+
+func (m *MockBudgetManager) CreatePayment(ctx context.Context, weddingID, userID models.ID, req services.BudgetPaymentRequest) (*models.BudgetPayment, error) {
+	args := m.Called(ctx, weddingID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BudgetPayment), args.Error(1)
+}
+
+func (m *MockBudgetManager) UpdatePayment(ctx context.Context, weddingID, paymentID, userID models.ID, req services.BudgetPaymentRequest) (*models.BudgetPayment, error) {
+	args := m.Called(ctx, weddingID, paymentID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BudgetPayment), args.Error(1)
+}
+
+func (m *MockBudgetManager) DeletePayment(ctx context.Context, weddingID, paymentID, userID models.ID) error {
+	args := m.Called(ctx, weddingID, paymentID, userID)
+	return args.Error(0)
+}
+
+func setupBudgetTestRouter(handler *BudgetHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	budget := router.Group("/api/v1/weddings/:id/budget")
+	budget.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	budget.GET("", handler.GetSummary)
+	budget.DELETE("/categories/:category_id", handler.DeleteCategory)
+	budget.GET("/payments", handler.ListPayments)
+	budget.POST("/payments", handler.CreatePayment)
+
+	return router
+}
+
+func TestBudgetHandler_CreatePayment(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	path := "/api/v1/weddings/" + weddingID.String() + "/budget/payments"
+
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		called   bool
+		expected int
+	}{
+		{"created", `{"vendor":"Caterer","amount":250000}`, nil, true, http.StatusCreated},
+		{"unknown category", `{"vendor":"Caterer","amount":250000}`, services.ErrBudgetCategoryNotFound, true, http.StatusNotFound},
+		{"budget full", `{"vendor":"Caterer","amount":250000}`, services.ErrBudgetFull, true, http.StatusConflict},
+		{"missing vendor", `{"amount":250000}`, nil, false, http.StatusBadRequest},
+		{"negative amount", `{"vendor":"Caterer","amount":-1}`, nil, false, http.StatusBadRequest},
+		{"reminder too early", `{"vendor":"Caterer","reminder_days":90}`, nil, false, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := new(MockBudgetManager)
+			if tt.called {
+				req := services.BudgetPaymentRequest{Vendor: "Caterer", Amount: 250000}
+				if tt.err != nil {
+					budget.On("CreatePayment", mock.Anything, weddingID, userID, req).Return(nil, tt.err)
+				} else {
+					budget.On("CreatePayment", mock.Anything, weddingID, userID, req).Return(&models.BudgetPayment{ID: models.NewID()}, nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupBudgetTestRouter(NewBudgetHandler(budget), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			budget.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBudgetHandler_ListPayments(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	categoryID := models.NewID()
+	path := "/api/v1/weddings/" + weddingID.String() + "/budget/payments"
+
+	t.Run("filtered", func(t *testing.T) {
+		budget := new(MockBudgetManager)
+		filters := repository.BudgetPaymentFilters{CategoryID: &categoryID, Status: models.BudgetPaymentPending}
+		budget.On("ListPayments", mock.Anything, weddingID, userID, filters).Return([]*models.BudgetPayment{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, path+"?status=pending&category_id="+categoryID.String(), nil)
+		w := httptest.NewRecorder()
+		setupBudgetTestRouter(NewBudgetHandler(budget), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		budget.AssertExpectations(t)
+	})
+
+	t.Run("invalid category", func(t *testing.T) {
+		budget := new(MockBudgetManager)
+
+		req := httptest.NewRequest(http.MethodGet, path+"?category_id=nope", nil)
+		w := httptest.NewRecorder()
+		setupBudgetTestRouter(NewBudgetHandler(budget), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		budget.AssertNotCalled(t, "ListPayments", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestBudgetHandler_DeleteCategory(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	categoryID := models.NewID()
+	path := "/api/v1/weddings/" + weddingID.String() + "/budget/categories/" + categoryID.String()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"deleted", nil, http.StatusNoContent},
+		{"has payments", services.ErrBudgetCategoryInUse, http.StatusConflict},
+		{"not an editor", services.ErrUnauthorized, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := new(MockBudgetManager)
+			budget.On("DeleteCategory", mock.Anything, weddingID, categoryID, userID).Return(tt.err)
+
+			req := httptest.NewRequest(http.MethodDelete, path, nil)
+			w := httptest.NewRecorder()
+			setupBudgetTestRouter(NewBudgetHandler(budget), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// CheckInManager checks guests in on the wedding day
+type CheckInManager interface {
+	CheckIn(ctx context.Context, weddingID, userID models.ID, req services.CheckInRequest) (*models.Guest, error)
+	Stats(ctx context.Context, weddingID, userID models.ID) (*models.CheckInStats, error)
+}
+
+// CheckInHandler serves the wedding day check-in at the door
+type CheckInHandler struct {
+	checkins CheckInManager
+}
+
+// NewCheckInHandler creates a new check-in handler
+func NewCheckInHandler(checkins CheckInManager) *CheckInHandler {
+	return &CheckInHandler{checkins: checkins}
+}
+
+// CheckInGuestRequest checks in a guest by the token of their QR code or by guest ID
+type CheckInGuestRequest struct {
+	// Token is the t parameter of the link in the guest's QR code
+	Token    string `json:"token"`
+	GuestID  string `json:"guest_id"`
+	PlusOnes int    `json:"plus_ones" binding:"min=0,max=5"`
+	// Companions are the IDs of the guest's named companions who came; they replace plus_ones
+	Companions []string `json:"companions" binding:"max=5"`
+	Table      string   `json:"table" binding:"max=50"`
+}
+
+// CheckInGuest godoc
+// @Summary Check in a guest
+// @Description Record a guest's arrival with the plus-ones they brought and their table. Named companions from the guest's RSVP are checked in by ID. The guest is identified by the token of their QR code or by ID; a guest is only checked in once.
+// @Tags Guests
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body CheckInGuestRequest true "Check-in"
+// @Success 201 {object} models.Guest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} utils.APIResponse "Already checked in, with the guest"
+// @Router /weddings/{id}/checkin [post]
+func (h *CheckInHandler) CheckInGuest(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var req CheckInGuestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	checkIn := services.CheckInRequest{Token: req.Token, PlusOnes: req.PlusOnes, Companions: req.Companions, Table: req.Table}
+	if req.GuestID != "" {
+		guestID, err := models.ParseID(req.GuestID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid guest ID")
+			return
+		}
+		checkIn.GuestID = guestID
+	}
+
+	guest, err := h.checkins.CheckIn(c.Request.Context(), weddingID, userID, checkIn)
+	if err != nil {
+		// Door staff see when the guest arrived instead of only a refusal
+		if errors.Is(err, services.ErrAlreadyCheckedIn) && guest != nil {
+			c.JSON(http.StatusConflict, utils.APIResponse{
+				Success: false,
+				Message: "Guest is already checked in",
+				Data:    guest,
+			})
+			return
+		}
+		h.handleError(c, err, "Failed to check in guest")
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.APIResponse{
+		Success: true,
+		Message: "Guest checked in",
+		Data:    guest,
+	})
+}
+
+// GetCheckInStats godoc
+// @Summary Get check-in statistics
+// @Description Live attendance counts of the wedding: guests expected, checked in and the plus-ones they brought
+// @Tags Guests
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {object} models.CheckInStats
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /weddings/{id}/checkin/stats [get]
+func (h *CheckInHandler) GetCheckInStats(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.checkins.Stats(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get check-in statistics")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.APIResponse{
+		Success: true,
+		Message: "Check-in statistics retrieved successfully",
+		Data:    stats,
+	})
+}
+
+// parseRequest reads the wedding ID and the authenticated user, writing the error response on failure
+func (h *CheckInHandler) parseRequest(c *gin.Context) (weddingID, userID models.ID, ok bool) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return weddingID, userID, false
+	}
+
+	userID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return weddingID, userID, false
+	}
+
+	return weddingID, userID, true
+}
+
+func (h *CheckInHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrCheckInGuestRequired):
+		utils.ErrorResponse(c, http.StatusBadRequest, "A guest token or guest ID is required")
+	case errors.Is(err, services.ErrTooManyPlusOnes), errors.Is(err, services.ErrUnknownCompanion):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrGuestNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Guest not found")
+	case errors.Is(err, services.ErrInvalidGuestLink):
+		utils.ErrorResponse(c, http.StatusNotFound, "QR code does not belong to a guest of this wedding")
+	case errors.Is(err, services.ErrAlreadyCheckedIn):
+		utils.ErrorResponse(c, http.StatusConflict, "Guest is already checked in")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to check in guests of this wedding")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockCheckInManager records the last check-in and returns a fixed error
+type MockCheckInManager struct {
+	err     error
+	guest   *models.Guest
+	lastReq services.CheckInRequest
+}
+
+func (m *MockCheckInManager) CheckIn(ctx context.Context, weddingID, userID models.ID, req services.CheckInRequest) (*models.Guest, error) {
+	m.lastReq = req
+	if m.err != nil {
+		return m.guest, m.err
+	}
+	return &models.Guest{
+		ID:        models.NewID(),
+		WeddingID: weddingID,
+		CheckIn:   &models.GuestCheckIn{ArrivedAt: time.Now(), PlusOnesBrought: req.PlusOnes, Table: req.Table},
+	}, nil
+}
+
+func (m *MockCheckInManager) Stats(ctx context.Context, weddingID, userID models.ID) (*models.CheckInStats, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.CheckInStats{TotalGuests: 10, CheckedIn: 4, PlusOnesBrought: 2, Attendance: 6}, nil
+}
+
+func setupCheckInRouter(manager CheckInManager) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", models.NewID().String())
+		c.Next()
+	})
+	handler := NewCheckInHandler(manager)
+	router.POST("/weddings/:id/checkin", handler.CheckInGuest)
+	router.GET("/weddings/:id/checkin/stats", handler.GetCheckInStats)
+	return router
+}
+
+func postCheckIn(router *gin.Engine, body string) *httptest.ResponseRecorder {
+	path := "/weddings/" + models.NewID().String() + "/checkin"
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCheckInHandler_CheckInGuest(t *testing.T) {
+	t.Run("By token", func(t *testing.T) {
+		manager := &MockCheckInManager{}
+		router := setupCheckInRouter(manager)
+
+		w := postCheckIn(router, `{"token":"abc","plus_ones":1,"table":"7"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, services.CheckInRequest{Token: "abc", PlusOnes: 1, Table: "7"}, manager.lastReq)
+		assert.Contains(t, w.Body.String(), `"table":"7"`)
+	})
+
+	t.Run("By guest ID", func(t *testing.T) {
+		manager := &MockCheckInManager{}
+		router := setupCheckInRouter(manager)
+		guestID := models.NewID()
+
+		w := postCheckIn(router, `{"guest_id":"`+guestID.String()+`"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, guestID, manager.lastReq.GuestID)
+	})
+
+	t.Run("Already checked in returns the guest", func(t *testing.T) {
+		guest := &models.Guest{FirstName: "Carol", CheckIn: &models.GuestCheckIn{Table: "3"}}
+		router := setupCheckInRouter(&MockCheckInManager{err: services.ErrAlreadyCheckedIn, guest: guest})
+
+		w := postCheckIn(router, `{"token":"abc"}`)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), `"table":"3"`)
+	})
+
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid guest ID", `{"guest_id":"nope"}`, nil, http.StatusBadRequest},
+		{"negative plus-ones", `{"token":"abc","plus_ones":-1}`, nil, http.StatusBadRequest},
+		{"no guest", `{}`, services.ErrCheckInGuestRequired, http.StatusBadRequest},
+		{"too many plus-ones", `{"token":"abc","plus_ones":3}`, services.ErrTooManyPlusOnes, http.StatusBadRequest},
+		{"unknown QR code", `{"token":"abc"}`, services.ErrInvalidGuestLink, http.StatusNotFound},
+		{"checked in meanwhile", `{"token":"abc"}`, services.ErrAlreadyCheckedIn, http.StatusConflict},
+		{"not authorized", `{"token":"abc"}`, services.ErrUnauthorized, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupCheckInRouter(&MockCheckInManager{err: tt.err})
+
+			w := postCheckIn(router, tt.body)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestCheckInHandler_GetCheckInStats(t *testing.T) {
+	path := "/weddings/" + models.NewID().String() + "/checkin/stats"
+
+	t.Run("Success", func(t *testing.T) {
+		router := setupCheckInRouter(&MockCheckInManager{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"attendance":6`)
+	})
+
+	t.Run("Wedding not found", func(t *testing.T) {
+		router := setupCheckInRouter(&MockCheckInManager{err: services.ErrWeddingNotFound})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// CollaboratorManager shares weddings with collaborators
+type CollaboratorManager interface {
+	ListCollaborators(ctx context.Context, weddingID, userID models.ID) (*services.Collaborators, error)
+	InviteCollaborator(ctx context.Context, weddingID, userID models.ID, email string, role models.WeddingRole) (*models.CollaboratorInvite, error)
+	AcceptInvite(ctx context.Context, weddingID, userID models.ID, token string) (*models.Wedding, error)
+	RemoveCollaborator(ctx context.Context, weddingID, userID, collaboratorID models.ID) error
+}
+
+// CollaboratorHandler manages the collaborators of a wedding
+type CollaboratorHandler struct {
+	collaborators CollaboratorManager
+}
+
+// NewCollaboratorHandler creates a new collaborator handler
+func NewCollaboratorHandler(collaborators CollaboratorManager) *CollaboratorHandler {
+	return &CollaboratorHandler{collaborators: collaborators}
+}
+
+// InviteCollaboratorRequest invites an email address to collaborate on a wedding
+type InviteCollaboratorRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	// Role is collaborator (edits the wedding, guests and RSVPs) or planner (guests and RSVPs only)
+	Role models.WeddingRole `json:"role" binding:"required,oneof=collaborator planner"`
+}
+
+// AcceptCollaboratorInviteRequest accepts an emailed collaborator invitation
+type AcceptCollaboratorInviteRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ListCollaborators godoc
+// @Summary List wedding collaborators
+// @Description List the collaborators and pending collaborator invitations of a wedding
+// @Tags Weddings
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {object} services.Collaborators
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /weddings/{id}/collaborators [get]
+func (h *CollaboratorHandler) ListCollaborators(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	collaborators, err := h.collaborators.ListCollaborators(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list collaborators")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.APIResponse{
+		Success: true,
+		Message: "Collaborators retrieved successfully",
+		Data:    collaborators,
+	})
+}
+
+// InviteCollaborator godoc
+// @Summary Invite a wedding collaborator
+// @Description Email an invitation to collaborate on the wedding. Only the owner may invite; inviting an address again replaces its pending invitation.
+// @Tags Weddings
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body InviteCollaboratorRequest true "Invitation"
+// @Success 201 {object} models.CollaboratorInvite
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /weddings/{id}/collaborators [post]
+func (h *CollaboratorHandler) InviteCollaborator(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var req InviteCollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	invite, err := h.collaborators.InviteCollaborator(c.Request.Context(), weddingID, userID, req.Email, req.Role)
+	if err != nil {
+		h.handleError(c, err, "Failed to invite collaborator")
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.APIResponse{
+		Success: true,
+		Message: "Collaborator invited",
+		Data:    invite,
+	})
+}
+
+// AcceptInvite godoc
+// @Summary Accept a collaborator invitation
+// @Description Join the wedding as a collaborator with the emailed invitation token. The signed-in user's email must be the invited address.
+// @Tags Weddings
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body AcceptCollaboratorInviteRequest true "Invitation token"
+// @Success 200 {object} models.Wedding
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Router /weddings/{id}/collaborators/accept [post]
+func (h *CollaboratorHandler) AcceptInvite(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var req AcceptCollaboratorInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	wedding, err := h.collaborators.AcceptInvite(c.Request.Context(), weddingID, userID, req.Token)
+	if err != nil {
+		h.handleError(c, err, "Failed to accept invitation")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.APIResponse{
+		Success: true,
+		Message: "Invitation accepted",
+		Data:    wedding,
+	})
+}
+
+// RemoveCollaborator godoc
+// @Summary Remove a wedding collaborator
+// @Description Remove a collaborator from the wedding. The owner may remove anyone; collaborators may remove themselves.
+// @Tags Weddings
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param user_id path string true "Collaborator user ID"
+// @Success 200 {object} utils.APIResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /weddings/{id}/collaborators/{user_id} [delete]
+func (h *CollaboratorHandler) RemoveCollaborator(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	collaboratorID, err := models.ParseID(c.Param("user_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid collaborator ID")
+		return
+	}
+
+	if err := h.collaborators.RemoveCollaborator(c.Request.Context(), weddingID, userID, collaboratorID); err != nil {
+		h.handleError(c, err, "Failed to remove collaborator")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.APIResponse{
+		Success: true,
+		Message: "Collaborator removed",
+	})
+}
+
+// parseRequest reads the wedding ID and the authenticated user, writing the error response on failure
+func (h *CollaboratorHandler) parseRequest(c *gin.Context) (weddingID, userID models.ID, ok bool) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return weddingID, userID, false
+	}
+
+	userID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return weddingID, userID, false
+	}
+
+	return weddingID, userID, true
+}
+
+func (h *CollaboratorHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to manage collaborators of this wedding")
+	case errors.Is(err, services.ErrInvalidCollaboratorRole):
+		utils.ErrorResponse(c, http.StatusBadRequest, "Role must be collaborator or planner")
+	case errors.Is(err, services.ErrAlreadyCollaborator):
+		utils.ErrorResponse(c, http.StatusConflict, "User already collaborates on this wedding")
+	case errors.Is(err, services.ErrCollaboratorNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Collaborator not found")
+	case errors.Is(err, services.ErrCollaboratorInviteNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Invitation not found")
+	case errors.Is(err, services.ErrCollaboratorInviteExpired):
+		utils.ErrorResponse(c, http.StatusGone, "Invitation has expired")
+	case errors.Is(err, services.ErrCollaboratorInviteWrongEmail):
+		utils.ErrorResponse(c, http.StatusForbidden, "Invitation was sent to another email address")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
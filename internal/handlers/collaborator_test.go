@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockCollaboratorManager records the last request and returns a fixed error
+type MockCollaboratorManager struct {
+	err       error
+	lastEmail string
+	lastRole  models.WeddingRole
+	lastToken string
+}
+
+func (m *MockCollaboratorManager) ListCollaborators(ctx context.Context, weddingID, userID models.ID) (*services.Collaborators, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &services.Collaborators{OwnerID: userID}, nil
+}
+
+func (m *MockCollaboratorManager) InviteCollaborator(ctx context.Context, weddingID, userID models.ID, email string, role models.WeddingRole) (*models.CollaboratorInvite, error) {
+	m.lastEmail, m.lastRole = email, role
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.CollaboratorInvite{ID: models.NewID(), Email: email, Role: role, TokenHash: "secret-hash"}, nil
+}
+
+func (m *MockCollaboratorManager) AcceptInvite(ctx context.Context, weddingID, userID models.ID, token string) (*models.Wedding, error) {
+	m.lastToken = token
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.Wedding{ID: weddingID}, nil
+}
+
+func (m *MockCollaboratorManager) RemoveCollaborator(ctx context.Context, weddingID, userID, collaboratorID models.ID) error {
+	return m.err
+}
+
+func setupCollaboratorRouter(manager CollaboratorManager) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", models.NewID().String())
+		c.Next()
+	})
+	handler := NewCollaboratorHandler(manager)
+	router.GET("/weddings/:id/collaborators", handler.ListCollaborators)
+	router.POST("/weddings/:id/collaborators", handler.InviteCollaborator)
+	router.POST("/weddings/:id/collaborators/accept", handler.AcceptInvite)
+	router.DELETE("/weddings/:id/collaborators/:user_id", handler.RemoveCollaborator)
+	return router
+}
+
+func postCollaboratorJSON(router *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCollaboratorHandler_InviteCollaborator(t *testing.T) {
+	path := "/weddings/" + models.NewID().String() + "/collaborators"
+
+	t.Run("Invites the address with the role", func(t *testing.T) {
+		manager := &MockCollaboratorManager{}
+		router := setupCollaboratorRouter(manager)
+
+		w := postCollaboratorJSON(router, path, `{"email":"planner@example.com","role":"planner"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "planner@example.com", manager.lastEmail)
+		assert.Equal(t, models.WeddingRolePlanner, manager.lastRole)
+		assert.NotContains(t, w.Body.String(), "secret-hash")
+	})
+
+	t.Run("Rejects the owner role", func(t *testing.T) {
+		router := setupCollaboratorRouter(&MockCollaboratorManager{})
+
+		w := postCollaboratorJSON(router, path, `{"email":"planner@example.com","role":"owner"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"not the owner", services.ErrUnauthorized, http.StatusForbidden},
+		{"already a collaborator", services.ErrAlreadyCollaborator, http.StatusConflict},
+		{"wedding not found", services.ErrWeddingNotFound, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupCollaboratorRouter(&MockCollaboratorManager{err: tt.err})
+
+			w := postCollaboratorJSON(router, path, `{"email":"planner@example.com","role":"planner"}`)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestCollaboratorHandler_AcceptInvite(t *testing.T) {
+	path := "/weddings/" + models.NewID().String() + "/collaborators/accept"
+
+	t.Run("Passes the token", func(t *testing.T) {
+		manager := &MockCollaboratorManager{}
+		router := setupCollaboratorRouter(manager)
+
+		w := postCollaboratorJSON(router, path, `{"token":"abc123"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "abc123", manager.lastToken)
+	})
+
+	t.Run("Missing token", func(t *testing.T) {
+		router := setupCollaboratorRouter(&MockCollaboratorManager{})
+
+		w := postCollaboratorJSON(router, path, `{}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unknown token", services.ErrCollaboratorInviteNotFound, http.StatusNotFound},
+		{"expired", services.ErrCollaboratorInviteExpired, http.StatusGone},
+		{"another email", services.ErrCollaboratorInviteWrongEmail, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupCollaboratorRouter(&MockCollaboratorManager{err: tt.err})
+
+			w := postCollaboratorJSON(router, path, `{"token":"abc123"}`)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestCollaboratorHandler_RemoveCollaborator(t *testing.T) {
+	base := "/weddings/" + models.NewID().String() + "/collaborators/"
+
+	t.Run("Success", func(t *testing.T) {
+		router := setupCollaboratorRouter(&MockCollaboratorManager{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, base+models.NewID().String(), nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Invalid collaborator ID", func(t *testing.T) {
+		router := setupCollaboratorRouter(&MockCollaboratorManager{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, base+"nope", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Not a collaborator", func(t *testing.T) {
+		router := setupCollaboratorRouter(&MockCollaboratorManager{err: services.ErrCollaboratorNotFound})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, base+models.NewID().String(), nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// maxEmailWebhookBody bounds the event batches read from the email provider
+const maxEmailWebhookBody = 5 << 20
+
+// EmailSuppressionManager keeps the list of addresses email is no longer sent to
+type EmailSuppressionManager interface {
+	Suppress(ctx context.Context, email string, reason models.EmailSuppressionReason, detail string) error
+	List(ctx context.Context, search string, page, pageSize int) ([]*models.EmailSuppression, int64, error)
+	Remove(ctx context.Context, email string) error
+	VerifySendGridWebhook(timestamp string, body []byte, signature string) error
+	RecordSendGridEvents(ctx context.Context, events []services.SendGridEvent) error
+}
+
+// SuppressEmailRequest stops email to an address
+type SuppressEmailRequest struct {
+	Email  string `json:"email" validate:"required,email"`
+	Detail string `json:"detail" validate:"max=500"`
+}
+
+// EmailSuppressionHandler serves the email suppression list to admins and
+// receives the email provider's bounce and spam reports
+type EmailSuppressionHandler struct {
+	suppressions EmailSuppressionManager
+}
+
+// NewEmailSuppressionHandler creates a new email suppression handler
+func NewEmailSuppressionHandler(suppressions EmailSuppressionManager) *EmailSuppressionHandler {
+	return &EmailSuppressionHandler{suppressions: suppressions}
+}
+
+// ListSuppressions godoc
+// @Summary List suppressed email addresses
+// @Description List the addresses no email is sent to because mail bounced, was reported as spam or an admin suppressed them, newest first (admin only)
+// @Tags admin
+// @Produce json
+// @Param search query string false "Only addresses containing this text"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} PaginationResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/email-suppressions [get]
+func (h *EmailSuppressionHandler) ListSuppressions(c *gin.Context) {
+	page, pageSize := utils.ParsePaginationParams(c)
+	suppressions, total, err := h.suppressions.List(c.Request.Context(), c.Query("search"), page, pageSize)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to list email suppressions")
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, suppressions, int64(len(suppressions)), total, page, pageSize)
+}
+
+// SuppressEmail godoc
+// @Summary Suppress an email address
+// @Description Stop sending email to an address (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body SuppressEmailRequest true "Address and optional note"
+// @Success 200 {object} utils.APIResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/email-suppressions [post]
+func (h *EmailSuppressionHandler) SuppressEmail(c *gin.Context) {
+	var req SuppressEmailRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	if err := h.suppressions.Suppress(c.Request.Context(), req.Email, models.EmailSuppressionManual, req.Detail); err != nil {
+		h.handleError(c, err, "Failed to suppress email address")
+		return
+	}
+
+	utils.SuccessResponse(c, "Email address suppressed")
+}
+
+// RemoveSuppression godoc
+// @Summary Lift an email suppression
+// @Description Send email to a suppressed address again, for example once its mailbox was fixed (admin only)
+// @Tags admin
+// @Produce json
+// @Param email path string true "Email address"
+// @Success 200 {object} utils.APIResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/email-suppressions/{email} [delete]
+func (h *EmailSuppressionHandler) RemoveSuppression(c *gin.Context) {
+	if err := h.suppressions.Remove(c.Request.Context(), c.Param("email")); err != nil {
+		h.handleError(c, err, "Failed to remove email suppression")
+		return
+	}
+
+	utils.SuccessResponse(c, "Email suppression removed")
+}
+
+// ReceiveSendGridWebhook godoc
+// @Summary Receive SendGrid email events
+// @Description Signed event webhook of SendGrid. Permanent bounces and spam reports suppress the address; other events are ignored.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Success 200 {object} utils.APIResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /webhooks/email/sendgrid [post]
+func (h *EmailSuppressionHandler) ReceiveSendGridWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxEmailWebhookBody))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	err = h.suppressions.VerifySendGridWebhook(c.GetHeader(services.SendGridTimestampHeader), body, c.GetHeader(services.SendGridSignatureHeader))
+	switch {
+	case errors.Is(err, services.ErrEmailWebhookNotConfigured):
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "SendGrid webhooks are not configured")
+		return
+	case err != nil:
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid signature")
+		return
+	}
+
+	events, err := services.ParseSendGridEvents(body)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid webhook events")
+		return
+	}
+
+	// A failure answers 500 so SendGrid delivers the events again
+	if err := h.suppressions.RecordSendGridEvents(c.Request.Context(), events); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to process email events")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.APIResponse{
+		Success: true,
+		Message: "Webhook received",
+	})
+}
+
+func (h *EmailSuppressionHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrEmailSuppressionNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Email suppression not found")
+	case errors.Is(err, services.ErrInvalidEmailAddress):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
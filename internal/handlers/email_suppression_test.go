@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockEmailSuppressionManager keeps suppressions in a map and verifies a fixed signature
+type MockEmailSuppressionManager struct {
+	suppressions map[string]models.EmailSuppressionReason
+	webhookErr   error
+	recordErr    error
+	events       []services.SendGridEvent
+}
+
+func newMockEmailSuppressionManager() *MockEmailSuppressionManager {
+	return &MockEmailSuppressionManager{suppressions: make(map[string]models.EmailSuppressionReason)}
+}
+
+func (m *MockEmailSuppressionManager) Suppress(ctx context.Context, email string, reason models.EmailSuppressionReason, detail string) error {
+	m.suppressions[email] = reason
+	return nil
+}
+
+func (m *MockEmailSuppressionManager) List(ctx context.Context, search string, page, pageSize int) ([]*models.EmailSuppression, int64, error) {
+	var list []*models.EmailSuppression
+	for email, reason := range m.suppressions {
+		list = append(list, &models.EmailSuppression{Email: email, Reason: reason})
+	}
+	return list, int64(len(list)), nil
+}
+
+func (m *MockEmailSuppressionManager) Remove(ctx context.Context, email string) error {
+	if _, ok := m.suppressions[email]; !ok {
+		return services.ErrEmailSuppressionNotFound
+	}
+	delete(m.suppressions, email)
+	return nil
+}
+
+func (m *MockEmailSuppressionManager) VerifySendGridWebhook(timestamp string, body []byte, signature string) error {
+	if m.webhookErr != nil {
+		return m.webhookErr
+	}
+	if signature != "valid" {
+		return services.ErrInvalidEmailWebhookSignature
+	}
+	return nil
+}
+
+func (m *MockEmailSuppressionManager) RecordSendGridEvents(ctx context.Context, events []services.SendGridEvent) error {
+	m.events = append(m.events, events...)
+	return m.recordErr
+}
+
+func setupEmailSuppressionRouter(manager EmailSuppressionManager) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewEmailSuppressionHandler(manager)
+	router.POST("/webhooks/email/sendgrid", handler.ReceiveSendGridWebhook)
+	router.GET("/admin/email-suppressions", handler.ListSuppressions)
+	router.POST("/admin/email-suppressions", handler.SuppressEmail)
+	router.DELETE("/admin/email-suppressions/:email", handler.RemoveSuppression)
+	return router
+}
+
+func sendEmailSuppressionRequest(router *gin.Engine, method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestEmailSuppressionHandler_Admin(t *testing.T) {
+	manager := newMockEmailSuppressionManager()
+	router := setupEmailSuppressionRouter(manager)
+
+	w := sendEmailSuppressionRequest(router, http.MethodPost, "/admin/email-suppressions", `{"email":"ana@example.com"}`, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, models.EmailSuppressionManual, manager.suppressions["ana@example.com"])
+
+	w = sendEmailSuppressionRequest(router, http.MethodPost, "/admin/email-suppressions", `{"email":"not an address"}`, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = sendEmailSuppressionRequest(router, http.MethodGet, "/admin/email-suppressions", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data []models.EmailSuppression `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, "ana@example.com", resp.Data[0].Email)
+
+	w = sendEmailSuppressionRequest(router, http.MethodDelete, "/admin/email-suppressions/ana@example.com", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = sendEmailSuppressionRequest(router, http.MethodDelete, "/admin/email-suppressions/ana@example.com", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestEmailSuppressionHandler_ReceiveSendGridWebhook(t *testing.T) {
+	const events = `[{"email":"ana@example.com","event":"bounce","type":"bounce"}]`
+	signed := map[string]string{services.SendGridSignatureHeader: "valid", services.SendGridTimestampHeader: "1780000000"}
+
+	t.Run("records events", func(t *testing.T) {
+		manager := newMockEmailSuppressionManager()
+		w := sendEmailSuppressionRequest(setupEmailSuppressionRouter(manager), http.MethodPost, "/webhooks/email/sendgrid", events, signed)
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, manager.events, 1)
+		assert.Equal(t, "ana@example.com", manager.events[0].Email)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		manager := newMockEmailSuppressionManager()
+		w := sendEmailSuppressionRequest(setupEmailSuppressionRouter(manager), http.MethodPost, "/webhooks/email/sendgrid", events,
+			map[string]string{services.SendGridSignatureHeader: "forged"})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Empty(t, manager.events)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		manager := newMockEmailSuppressionManager()
+		manager.webhookErr = services.ErrEmailWebhookNotConfigured
+		w := sendEmailSuppressionRequest(setupEmailSuppressionRouter(manager), http.MethodPost, "/webhooks/email/sendgrid", events, signed)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		manager := newMockEmailSuppressionManager()
+		w := sendEmailSuppressionRequest(setupEmailSuppressionRouter(manager), http.MethodPost, "/webhooks/email/sendgrid", `{`, signed)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("processing failure is retried", func(t *testing.T) {
+		manager := newMockEmailSuppressionManager()
+		manager.recordErr = errors.New("database unavailable")
+		w := sendEmailSuppressionRequest(setupEmailSuppressionRouter(manager), http.MethodPost, "/webhooks/email/sendgrid", events, signed)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
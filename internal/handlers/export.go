@@ -0,0 +1,348 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// Export output formats, selected via ?format= or the request body; JSON is the default
+const (
+	exportFormatJSON = "json"
+	exportFormatCSV  = "csv"
+	exportFormatXLSX = "xlsx"
+)
+
+// ExportRequest is the optional JSON body of POST export requests. Armored OpenPGP
+// keys do not fit in a query string, so encrypted exports are usually POSTed.
+type ExportRequest struct {
+	Format string `json:"format,omitempty" example:"csv"`
+	// PublicKey is an age recipient (age1...) or an ASCII-armored OpenPGP public key
+	PublicKey string `json:"public_key,omitempty"`
+}
+
+// exportOptions are the resolved output settings of an export request
+type exportOptions struct {
+	format    string
+	recipient utils.ExportRecipient
+}
+
+// parseExportOptions reads the format and public key of an export from the query
+// string or, for POST requests, the JSON body. Invalid input is answered with 400.
+func parseExportOptions(c *gin.Context) (exportOptions, bool) {
+	req := ExportRequest{
+		Format:    c.Query("format"),
+		PublicKey: c.Query("public_key"),
+	}
+	if c.Request.Method == http.MethodPost && c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+			return exportOptions{}, false
+		}
+	}
+
+	opts := exportOptions{format: exportFormatJSON}
+	if req.Format == exportFormatCSV || req.Format == exportFormatXLSX {
+		opts.format = req.Format
+	}
+
+	if req.PublicKey != "" {
+		recipient, err := utils.ParseExportRecipient(req.PublicKey)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return exportOptions{}, false
+		}
+		opts.recipient = recipient
+	}
+	return opts, true
+}
+
+// csvExport describes how records of an export are laid out as CSV or XLSX rows
+type csvExport struct {
+	filename string
+	header   []string
+	row      func(record interface{}) []string
+}
+
+// streamExport writes the records produced by produce as JSON, CSV or XLSX without holding
+// them in memory, encrypting the output when opts carries a recipient. The response
+// only starts with the first record, so errors raised before then (wedding not found,
+// not authorized) are returned to the caller with started == false and can still be
+// reported as regular error responses. Errors after the response has started truncate
+// the output and are attached to the gin context. records counts what was written.
+func streamExport(c *gin.Context, layout csvExport, opts exportOptions, produce func(emit func(record interface{}) error) error) (records int, started bool, err error) {
+	ctx := c.Request.Context()
+
+	var writer utils.RecordWriter
+	var encrypted io.WriteCloser
+	start := func() error {
+		started = true
+		filename := layout.filename + "." + opts.format
+		contentType := "application/json; charset=utf-8"
+		switch opts.format {
+		case exportFormatCSV:
+			contentType = "text/csv; charset=utf-8"
+		case exportFormatXLSX:
+			contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		}
+
+		var out io.Writer = c.Writer
+		if opts.recipient != nil {
+			filename += opts.recipient.FileExtension()
+			contentType = "application/octet-stream"
+			c.Header("X-Export-Key-Fingerprint", opts.recipient.Fingerprint())
+		}
+		c.Header("Content-Type", contentType)
+		if opts.format != exportFormatJSON || opts.recipient != nil {
+			c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+		}
+		c.Status(http.StatusOK)
+
+		if opts.recipient != nil {
+			enc, err := opts.recipient.Encrypt(c.Writer)
+			if err != nil {
+				return fmt.Errorf("failed to start export encryption: %w", err)
+			}
+			encrypted = enc
+			out = enc
+		}
+
+		switch opts.format {
+		case exportFormatCSV:
+			writer = utils.NewCSVRecordWriter(out, layout.header, layout.row)
+		case exportFormatXLSX:
+			writer = utils.NewXLSXRecordWriter(out, layout.filename, layout.header, layout.row)
+		default:
+			writer = utils.NewJSONRecordWriter(out)
+		}
+		return nil
+	}
+
+	fail := func(err error) (int, bool, error) {
+		_ = c.Error(err)
+		return records, true, err
+	}
+
+	err = produce(func(record interface{}) error {
+		// Stop pulling from the database as soon as the client goes away
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := writer.WriteRecord(record); err != nil {
+			return err
+		}
+		records++
+		return nil
+	})
+	if err != nil {
+		if !started {
+			return 0, false, err
+		}
+		return fail(err)
+	}
+
+	if !started {
+		if err := start(); err != nil {
+			return fail(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return fail(err)
+	}
+	if encrypted != nil {
+		// Sealing the final chunk happens on close, so the output is only complete after this
+		if err := encrypted.Close(); err != nil {
+			return fail(err)
+		}
+		c.Writer.Flush()
+	}
+	return records, true, nil
+}
+
+// rsvpCSVExport lays out RSVPs as CSV rows, with a column for the answers to
+// each of the wedding's custom questions
+func rsvpCSVExport(questions []models.CustomQuestion) csvExport {
+	header := []string{
+		"id", "first_name", "last_name", "email", "phone", "status", "attendance_count",
+		"plus_one_count", "companions", "dietary_restrictions", "additional_notes", "source", "submitted_at",
+	}
+	for _, question := range questions {
+		header = append(header, question.Question)
+	}
+
+	return csvExport{
+		filename: "rsvps",
+		header:   header,
+		row: func(record interface{}) []string {
+			rsvp := record.(*models.RSVP)
+			row := []string{
+				rsvp.ID.String(),
+				rsvp.FirstName,
+				rsvp.LastName,
+				rsvp.Email,
+				rsvp.Phone,
+				rsvp.Status,
+				strconv.Itoa(rsvp.AttendanceCount),
+				strconv.Itoa(rsvp.PlusOneCount),
+				companionLabels(rsvp.PlusOnes),
+				rsvp.DietaryRestrictions,
+				rsvp.AdditionalNotes,
+				rsvp.Source,
+				rsvp.SubmittedAt.Format(time.RFC3339),
+			}
+			for _, question := range questions {
+				answer := ""
+				for _, custom := range rsvp.CustomAnswers {
+					if custom.QuestionID == question.ID {
+						answer = custom.Text()
+						break
+					}
+				}
+				row = append(row, answer)
+			}
+			return row
+		},
+	}
+}
+
+// mealCSVExport lays out the attendees of a meal report as CSV rows
+var mealCSVExport = csvExport{
+	filename: "meal-report",
+	header:   []string{"rsvp_id", "name", "plus_one", "meal_choice", "allergies", "dietary_notes"},
+	row: func(record interface{}) []string {
+		attendee := record.(*models.MealAttendee)
+		return []string{
+			attendee.RSVPID.String(),
+			attendee.Name,
+			strconv.FormatBool(attendee.PlusOne),
+			attendee.MealChoice,
+			strings.Join(attendee.Allergies, "; "),
+			attendee.DietaryNotes,
+		}
+	},
+}
+
+// guestCSVExport lays out guest responses as CSV rows
+var guestCSVExport = csvExport{
+	filename: "guests",
+	header: []string{
+		"id", "first_name", "last_name", "email", "phone", "relationship", "side", "invitation_status",
+		"rsvp_status", "allow_plus_one", "max_plus_ones", "companions", "vip", "dietary_notes", "notes",
+	},
+	row: func(record interface{}) []string {
+		guest := record.(*GuestResponse)
+		return []string{
+			guest.ID.String(),
+			guest.FirstName,
+			guest.LastName,
+			guest.Email,
+			guest.Phone,
+			guest.Relationship,
+			guest.Side,
+			guest.InvitationStatus,
+			guest.RSVPStatus,
+			strconv.FormatBool(guest.AllowPlusOne),
+			strconv.Itoa(guest.MaxPlusOnes),
+			companionLabels(guest.Companions),
+			strconv.FormatBool(guest.VIP),
+			guest.DietaryNotes,
+			guest.Notes,
+		}
+	},
+}
+
+// songRequestCSVExport lays out the DJ's list of requested songs as CSV rows
+var songRequestCSVExport = csvExport{
+	filename: "song-requests",
+	header:   []string{"title", "artist", "requests", "requested_by", "requested_at"},
+	row: func(record interface{}) []string {
+		request := record.(*models.SongRequest)
+		return []string{
+			request.Title,
+			request.Artist,
+			strconv.Itoa(request.Requests),
+			strings.Join(request.RequestedBy, "; "),
+			request.CreatedAt.UTC().Format(time.RFC3339),
+		}
+	},
+}
+
+// companionLabels lists named companions, with their ages, in a single cell
+func companionLabels(companions []models.PlusOneInfo) string {
+	labels := make([]string, len(companions))
+	for i, companion := range companions {
+		labels[i] = companion.Label()
+	}
+	return strings.Join(labels, "; ")
+}
+
+// ExportJobHandler serves the export history of a wedding
+type ExportJobHandler struct {
+	exportJobs services.ExportJobRecorder
+}
+
+// NewExportJobHandler creates a new export job handler
+func NewExportJobHandler(exportJobs services.ExportJobRecorder) *ExportJobHandler {
+	return &ExportJobHandler{exportJobs: exportJobs}
+}
+
+// ListExportJobs godoc
+// @Summary List export jobs
+// @Description Get the paginated export history of a wedding, newest first, including the key fingerprint of encrypted exports (owner only)
+// @Tags exports
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PaginatedResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/exports [get]
+func (h *ExportJobHandler) ListExportJobs(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+
+	jobs, total, err := h.exportJobs.ListExportJobs(c.Request.Context(), weddingID, userID, page, pageSize)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWeddingNotFound):
+			utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+		case errors.Is(err, services.ErrUnauthorized):
+			utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to view exports for this wedding")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to list export jobs")
+		}
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, jobs, int64(len(jobs)), total, page, pageSize)
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// FeatureFlags resolves the features of weddings and lets admins override them
+type FeatureFlags interface {
+	GetFeatures(ctx context.Context, weddingID, userID models.ID) (map[string]bool, error)
+	GetPublicFeatures(ctx context.Context, weddingID models.ID) (map[string]bool, error)
+	ListFlags(ctx context.Context, weddingID models.ID) ([]services.FeatureFlag, error)
+	SetOverride(ctx context.Context, weddingID models.ID, feature string, enabled bool) error
+	ClearOverride(ctx context.Context, weddingID models.ID, feature string) error
+}
+
+// SetFeatureOverrideRequest turns a feature on or off
+type SetFeatureOverrideRequest struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
+
+// FeatureFlagHandler serves the features of weddings to their frontends and
+// feature overrides to admins
+type FeatureFlagHandler struct {
+	features FeatureFlags
+}
+
+// NewFeatureFlagHandler creates a new feature flag handler
+func NewFeatureFlagHandler(features FeatureFlags) *FeatureFlagHandler {
+	return &FeatureFlagHandler{features: features}
+}
+
+// GetWeddingFeatures godoc
+// @Summary Get a wedding's features
+// @Description Get which features are on for a wedding, by name, e.g. guest_photos, wishes, analytics_tracking and rsvp_editing (wedding members only)
+// @Tags weddings
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {object} utils.APIResponse{data=map[string]bool}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/features [get]
+func (h *FeatureFlagHandler) GetWeddingFeatures(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	features, err := h.features.GetFeatures(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get features")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, features)
+}
+
+// GetPublicWeddingFeatures godoc
+// @Summary Get a published wedding's features
+// @Description Get which features are on for a published wedding, by name, for its public site
+// @Tags public
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {object} utils.APIResponse{data=map[string]bool}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/public/weddings/{id}/features [get]
+func (h *FeatureFlagHandler) GetPublicWeddingFeatures(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	features, err := h.features.GetPublicFeatures(c.Request.Context(), weddingID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get features")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, features)
+}
+
+// ListFlags godoc
+// @Summary List feature flags
+// @Description List every feature with its default in this environment and the override for all weddings (admin only)
+// @Tags admin
+// @Produce json
+// @Success 200 {object} utils.APIResponse{data=[]services.FeatureFlag}
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/features [get]
+func (h *FeatureFlagHandler) ListFlags(c *gin.Context) {
+	h.listFlags(c, models.NilID)
+}
+
+// SetFlag godoc
+// @Summary Override a feature flag
+// @Description Turn a feature on or off for all weddings, overriding the environment's default (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param feature path string true "Feature name"
+// @Param request body SetFeatureOverrideRequest true "Whether the feature is on"
+// @Success 200 {object} utils.APIResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/features/{feature} [put]
+func (h *FeatureFlagHandler) SetFlag(c *gin.Context) {
+	h.setOverride(c, models.NilID)
+}
+
+// ClearFlag godoc
+// @Summary Remove a feature flag override
+// @Description Return a feature to the environment's default for all weddings (admin only)
+// @Tags admin
+// @Produce json
+// @Param feature path string true "Feature name"
+// @Success 200 {object} utils.APIResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/features/{feature} [delete]
+func (h *FeatureFlagHandler) ClearFlag(c *gin.Context) {
+	h.clearOverride(c, models.NilID)
+}
+
+// ListWeddingFlags godoc
+// @Summary List a wedding's feature flags
+// @Description List every feature with the state the wedding inherits and its own override (admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {object} utils.APIResponse{data=[]services.FeatureFlag}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/weddings/{id}/features [get]
+func (h *FeatureFlagHandler) ListWeddingFlags(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+	h.listFlags(c, weddingID)
+}
+
+// SetWeddingFlag godoc
+// @Summary Override a wedding's feature flag
+// @Description Turn a feature on or off for one wedding (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param feature path string true "Feature name"
+// @Param request body SetFeatureOverrideRequest true "Whether the feature is on"
+// @Success 200 {object} utils.APIResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/weddings/{id}/features/{feature} [put]
+func (h *FeatureFlagHandler) SetWeddingFlag(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+	h.setOverride(c, weddingID)
+}
+
+// ClearWeddingFlag godoc
+// @Summary Remove a wedding's feature flag override
+// @Description Return a feature of one wedding to the state it inherits (admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param feature path string true "Feature name"
+// @Success 200 {object} utils.APIResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/weddings/{id}/features/{feature} [delete]
+func (h *FeatureFlagHandler) ClearWeddingFlag(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+	h.clearOverride(c, weddingID)
+}
+
+func (h *FeatureFlagHandler) listFlags(c *gin.Context, weddingID models.ID) {
+	flags, err := h.features.ListFlags(c.Request.Context(), weddingID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list feature flags")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, flags)
+}
+
+func (h *FeatureFlagHandler) setOverride(c *gin.Context, weddingID models.ID) {
+	var req SetFeatureOverrideRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	if err := h.features.SetOverride(c.Request.Context(), weddingID, c.Param("feature"), *req.Enabled); err != nil {
+		h.handleError(c, err, "Failed to override feature flag")
+		return
+	}
+
+	utils.SuccessResponse(c, "Feature flag overridden")
+}
+
+func (h *FeatureFlagHandler) clearOverride(c *gin.Context, weddingID models.ID) {
+	if err := h.features.ClearOverride(c.Request.Context(), weddingID, c.Param("feature")); err != nil {
+		h.handleError(c, err, "Failed to remove feature flag override")
+		return
+	}
+
+	utils.SuccessResponse(c, "Feature flag override removed")
+}
+
+func (h *FeatureFlagHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrUnknownFeature):
+		utils.ErrorResponse(c, http.StatusNotFound, "Unknown feature")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to access this wedding")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// fileContentPolicy keeps stored files, such as uploaded SVGs, from running
+// scripts when opened directly
+const fileContentPolicy = "default-src 'none'; img-src 'self'; media-src 'self'; style-src 'unsafe-inline'; sandbox"
+
+// FileConfig controls who may download stored files and how long they are cached
+type FileConfig struct {
+	// SigningSecret verifies the expiring links of private files
+	SigningSecret string
+	// PrivatePrefixes are the key prefixes of files only served with a signed link
+	PrivatePrefixes []string
+	// MaxAge is how long browsers and CDNs cache public files
+	MaxAge time.Duration
+}
+
+// FileHandler serves stored files from the storage backend, with ETags,
+// conditional and range requests, so that videos can be streamed and seeked
+type FileHandler struct {
+	files  services.ObjectOpener
+	config FileConfig
+	logger *zap.Logger
+}
+
+// NewFileHandler creates a new stored file handler
+func NewFileHandler(files services.ObjectOpener, config FileConfig, logger *zap.Logger) *FileHandler {
+	return &FileHandler{files: files, config: config, logger: logger}
+}
+
+// ServeFile godoc
+// @Summary Download a stored file
+// @Description Serve an uploaded file or thumbnail. Range, If-None-Match and If-Modified-Since requests are supported. Private files, such as exports, need the expires and signature parameters of a signed link.
+// @Tags Media
+// @Produce octet-stream
+// @Param filepath path string true "Storage key of the file"
+// @Param expires query int false "Expiry of a signed link, in Unix seconds"
+// @Param signature query string false "Signature of a signed link"
+// @Success 200 {file} binary
+// @Success 206 {file} binary
+// @Success 304
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /uploads/{filepath} [get]
+func (h *FileHandler) ServeFile(c *gin.Context) {
+	key := strings.TrimPrefix(path.Clean("/"+c.Param("filepath")), "/")
+	if key == "" {
+		utils.ErrorResponse(c, http.StatusNotFound, "File not found")
+		return
+	}
+
+	// Any signed link is checked, so that a link to a public file also expires
+	signature := c.Query(utils.FileURLSignatureParam)
+	signed := signature != ""
+	if signed || h.isPrivate(key) {
+		expires := c.Query(utils.FileURLExpiresParam)
+		if err := utils.VerifyFileSignature(h.config.SigningSecret, key, expires, signature); err != nil {
+			if errors.Is(err, utils.ErrExpiredFileSignature) {
+				utils.ErrorResponse(c, http.StatusForbidden, "File link has expired")
+				return
+			}
+			utils.ErrorResponse(c, http.StatusForbidden, "Invalid file link")
+			return
+		}
+	}
+
+	object, err := h.files.Open(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			utils.ErrorResponse(c, http.StatusNotFound, "File not found")
+			return
+		}
+		h.logger.Error("Failed to open stored file", zap.String("key", key), zap.Error(err))
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to read file")
+		return
+	}
+	defer object.Content.Close()
+
+	header := c.Writer.Header()
+	if object.ContentType != "" {
+		header.Set("Content-Type", object.ContentType)
+	}
+	if object.ETag != "" {
+		header.Set("ETag", object.ETag)
+	}
+	header.Set("Cache-Control", h.cacheControl(signed, c.Query(utils.FileURLExpiresParam)))
+	header.Set("Content-Security-Policy", fileContentPolicy)
+	header.Set("X-Content-Type-Options", "nosniff")
+
+	// ServeContent answers range and conditional requests from the ETag and
+	// modification time, and seeks the content instead of reading all of it
+	http.ServeContent(c.Writer, c.Request, path.Base(key), object.ModTime, object.Content)
+}
+
+func (h *FileHandler) isPrivate(key string) bool {
+	for _, prefix := range h.config.PrivatePrefixes {
+		if prefix != "" && strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheControl lets shared caches keep public files for the configured max age;
+// signed links are only cached privately, until they expire
+func (h *FileHandler) cacheControl(signed bool, expires string) string {
+	if signed {
+		unix, _ := strconv.ParseInt(expires, 10, 64)
+		return fmt.Sprintf("private, max-age=%d", max(unix-time.Now().Unix(), 0))
+	}
+	if h.config.MaxAge <= 0 {
+		return "no-cache"
+	}
+	return fmt.Sprintf("public, max-age=%d", int64(h.config.MaxAge/time.Second))
+}
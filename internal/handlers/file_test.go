@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+func setupFileTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	storage := services.NewLocalStorageService(t.TempDir(), "http://localhost:8080/uploads")
+	ctx := context.Background()
+	_, err := storage.Upload(ctx, "uploads/2024/06/a/original.mp4", []byte("0123456789"), "video/mp4", nil)
+	require.NoError(t, err)
+	_, err = storage.Upload(ctx, "exports/account/u/job.zip", []byte("zip"), "application/zip", nil)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewFileHandler(storage, FileConfig{
+		SigningSecret:   "secret",
+		PrivatePrefixes: []string{"exports/"},
+		MaxAge:          time.Hour,
+	}, zap.NewNop())
+	router.GET("/uploads/*filepath", handler.ServeFile)
+	router.HEAD("/uploads/*filepath", handler.ServeFile)
+	return router
+}
+
+func serveFile(router *gin.Engine, method, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestFileHandler_ServeFile(t *testing.T) {
+	router := setupFileTestRouter(t)
+
+	w := serveFile(router, http.MethodGet, "/uploads/uploads/2024/06/a/original.mp4", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0123456789", w.Body.String())
+	assert.Equal(t, "video/mp4", w.Header().Get("Content-Type"))
+	assert.Equal(t, "public, max-age=3600", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "bytes", w.Header().Get("Accept-Ranges"))
+	assert.Contains(t, w.Header().Get("Content-Security-Policy"), "sandbox")
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	t.Run("range", func(t *testing.T) {
+		w := serveFile(router, http.MethodGet, "/uploads/uploads/2024/06/a/original.mp4", http.Header{"Range": {"bytes=2-5"}})
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Equal(t, "2345", w.Body.String())
+		assert.Equal(t, "bytes 2-5/10", w.Header().Get("Content-Range"))
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		w := serveFile(router, http.MethodGet, "/uploads/uploads/2024/06/a/original.mp4", http.Header{"If-None-Match": {etag}})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("head", func(t *testing.T) {
+		w := serveFile(router, http.MethodHead, "/uploads/uploads/2024/06/a/original.mp4", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "10", w.Header().Get("Content-Length"))
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serveFile(router, http.MethodGet, "/uploads/uploads/missing.jpg", nil).Code)
+		assert.Equal(t, http.StatusNotFound, serveFile(router, http.MethodGet, "/uploads/", nil).Code)
+	})
+}
+
+func TestFileHandler_SignedLinks(t *testing.T) {
+	router := setupFileTestRouter(t)
+	key := "exports/account/u/job.zip"
+
+	w := serveFile(router, http.MethodGet, "/uploads/"+key, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code, "private files need a signed link")
+
+	signed := utils.SignFileURL("secret", "/uploads", key, time.Now().Add(time.Hour))
+	w = serveFile(router, http.MethodGet, signed, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "zip", w.Body.String())
+	assert.True(t, strings.HasPrefix(w.Header().Get("Cache-Control"), "private, max-age="))
+
+	expired := utils.SignFileURL("secret", "/uploads", key, time.Now().Add(-time.Minute))
+	w = serveFile(router, http.MethodGet, expired, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "expired")
+
+	forged := utils.SignFileURL("other", "/uploads", key, time.Now().Add(time.Hour))
+	assert.Equal(t, http.StatusForbidden, serveFile(router, http.MethodGet, forged, nil).Code)
+
+	// A signed link to a public file is checked too
+	public := utils.SignFileURL("secret", "/uploads", "uploads/2024/06/a/original.mp4", time.Now().Add(-time.Minute))
+	assert.Equal(t, http.StatusForbidden, serveFile(router, http.MethodGet, public, nil).Code)
+}
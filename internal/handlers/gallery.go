@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// GuestGallery stores guest photos, lets wedding editors moderate them and lists the approved ones
+type GuestGallery interface {
+	UploadPhoto(ctx context.Context, weddingID models.ID, file io.Reader, header *multipart.FileHeader, upload services.GuestPhotoUpload) (*models.GuestPhoto, error)
+	ListGallery(ctx context.Context, weddingID models.ID, page, pageSize int) ([]*models.GuestPhoto, int64, error)
+	ListPending(ctx context.Context, weddingID, userID models.ID, page, pageSize int) ([]*models.GuestPhoto, int64, error)
+	ModeratePhoto(ctx context.Context, weddingID, photoID, userID models.ID, approve bool) (*models.GuestPhoto, error)
+}
+
+// GalleryHandler serves guest photo uploads, their moderation and the public gallery
+type GalleryHandler struct {
+	gallery GuestGallery
+}
+
+// NewGalleryHandler creates a new gallery handler
+func NewGalleryHandler(gallery GuestGallery) *GalleryHandler {
+	return &GalleryHandler{gallery: gallery}
+}
+
+// UploadGuestPhoto godoc
+// @Summary Add a photo to a wedding gallery
+// @Description Upload a guest photo to a published wedding that accepts them. The photo is shown once the owner approves it, unless the wedding skips approval.
+// @Tags gallery
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param file formData file true "JPEG, PNG or WebP photo"
+// @Param name formData string false "Uploader name"
+// @Param caption formData string false "Caption"
+// @Success 201 {object} models.GuestPhoto
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/public/weddings/{id}/gallery [post]
+func (h *GalleryHandler) UploadGuestPhoto(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "No file provided")
+		return
+	}
+	defer file.Close()
+
+	photo, err := h.gallery.UploadPhoto(c.Request.Context(), weddingID, file, header, services.GuestPhotoUpload{
+		UploaderName: c.PostForm("name"),
+		Caption:      c.PostForm("caption"),
+		IP:           c.ClientIP(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWeddingNotFound):
+			utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+		case errors.Is(err, services.ErrGuestUploadsDisabled):
+			utils.ErrorResponse(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrInvalidGuestPhotoDetails):
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrGuestPhotoNotImage):
+			utils.ErrorResponse(c, http.StatusUnsupportedMediaType, err.Error())
+		case errors.Is(err, services.ErrPlanLimitExceeded):
+			// The owner's storage is full; guests cannot upgrade it
+			utils.ErrorResponse(c, http.StatusForbidden, "This gallery is not accepting more photos")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to upload photo")
+		}
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, photo)
+}
+
+// GetGallery godoc
+// @Summary Get a wedding gallery
+// @Description Get the approved guest photos of a published wedding, oldest first
+// @Tags gallery
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PaginatedResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/public/weddings/{id}/gallery [get]
+func (h *GalleryHandler) GetGallery(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+
+	photos, total, err := h.gallery.ListGallery(c.Request.Context(), weddingID, page, pageSize)
+	if err != nil {
+		if errors.Is(err, services.ErrWeddingNotFound) {
+			utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get gallery")
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, photos, int64(len(photos)), total, page, pageSize)
+}
+
+// GetPendingPhotos godoc
+// @Summary Get the gallery moderation queue
+// @Description Get the guest photos awaiting approval, oldest first (wedding editors only)
+// @Tags gallery
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PaginatedResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/gallery/pending [get]
+func (h *GalleryHandler) GetPendingPhotos(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+
+	photos, total, err := h.gallery.ListPending(c.Request.Context(), weddingID, userID, page, pageSize)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWeddingNotFound):
+			utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+		case errors.Is(err, services.ErrUnauthorized):
+			utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to moderate this wedding's gallery")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get pending photos")
+		}
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, photos, int64(len(photos)), total, page, pageSize)
+}
+
+// ApprovePhoto godoc
+// @Summary Approve a guest photo
+// @Description Show a pending guest photo in the public gallery (wedding editors only)
+// @Tags gallery
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param photo_id path string true "Photo ID"
+// @Success 200 {object} models.GuestPhoto
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/gallery/{photo_id}/approve [post]
+func (h *GalleryHandler) ApprovePhoto(c *gin.Context) {
+	h.moderatePhoto(c, true)
+}
+
+// RejectPhoto godoc
+// @Summary Reject a guest photo
+// @Description Keep a pending guest photo out of the gallery and delete it (wedding editors only)
+// @Tags gallery
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param photo_id path string true "Photo ID"
+// @Success 200 {object} models.GuestPhoto
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/gallery/{photo_id}/reject [post]
+func (h *GalleryHandler) RejectPhoto(c *gin.Context) {
+	h.moderatePhoto(c, false)
+}
+
+func (h *GalleryHandler) moderatePhoto(c *gin.Context, approve bool) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	photoID, err := models.ParseID(c.Param("photo_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid photo ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	photo, err := h.gallery.ModeratePhoto(c.Request.Context(), weddingID, photoID, userID, approve)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWeddingNotFound):
+			utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+		case errors.Is(err, services.ErrGuestPhotoNotFound):
+			utils.ErrorResponse(c, http.StatusNotFound, "Photo not found")
+		case errors.Is(err, services.ErrUnauthorized):
+			utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to moderate this wedding's gallery")
+		case errors.Is(err, services.ErrGuestPhotoNotPending):
+			utils.ErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to moderate photo")
+		}
+		return
+	}
+
+	utils.Response(c, http.StatusOK, photo)
+}
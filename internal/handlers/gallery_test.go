@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockGuestGallery records the last upload and moderation and returns a fixed error
+type MockGuestGallery struct {
+	err        error
+	lastUpload services.GuestPhotoUpload
+	lastFile   string
+	approved   *bool
+}
+
+func (m *MockGuestGallery) UploadPhoto(ctx context.Context, weddingID models.ID, file io.Reader, header *multipart.FileHeader, upload services.GuestPhotoUpload) (*models.GuestPhoto, error) {
+	m.lastUpload = upload
+	m.lastFile = header.Filename
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.GuestPhoto{ID: models.NewID(), WeddingID: weddingID, Status: models.GuestPhotoPending}, nil
+}
+
+func (m *MockGuestGallery) ListGallery(ctx context.Context, weddingID models.ID, page, pageSize int) ([]*models.GuestPhoto, int64, error) {
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+	return []*models.GuestPhoto{{ID: models.NewID(), WeddingID: weddingID, Status: models.GuestPhotoApproved}}, 1, nil
+}
+
+func (m *MockGuestGallery) ListPending(ctx context.Context, weddingID, userID models.ID, page, pageSize int) ([]*models.GuestPhoto, int64, error) {
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+	return []*models.GuestPhoto{}, 0, nil
+}
+
+func (m *MockGuestGallery) ModeratePhoto(ctx context.Context, weddingID, photoID, userID models.ID, approve bool) (*models.GuestPhoto, error) {
+	m.approved = &approve
+	if m.err != nil {
+		return nil, m.err
+	}
+	status := models.GuestPhotoRejected
+	if approve {
+		status = models.GuestPhotoApproved
+	}
+	return &models.GuestPhoto{ID: photoID, WeddingID: weddingID, Status: status}, nil
+}
+
+func setupGalleryRouter(gallery GuestGallery) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", models.NewID().String())
+		c.Next()
+	})
+	handler := NewGalleryHandler(gallery)
+	router.POST("/public/weddings/:id/gallery", handler.UploadGuestPhoto)
+	router.GET("/public/weddings/:id/gallery", handler.GetGallery)
+	router.GET("/weddings/:id/gallery/pending", handler.GetPendingPhotos)
+	router.POST("/weddings/:id/gallery/:photo_id/approve", handler.ApprovePhoto)
+	router.POST("/weddings/:id/gallery/:photo_id/reject", handler.RejectPhoto)
+	return router
+}
+
+func TestGalleryHandler_UploadGuestPhoto(t *testing.T) {
+	upload := func(router *gin.Engine) *httptest.ResponseRecorder {
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", "dance.jpg")
+		require.NoError(t, err)
+		part.Write([]byte{0xFF, 0xD8, 0xFF})
+		writer.WriteField("name", "Aunt May")
+		writer.WriteField("caption", "First dance")
+		require.NoError(t, writer.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/public/weddings/"+models.NewID().String()+"/gallery", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		gallery := &MockGuestGallery{}
+		w := upload(setupGalleryRouter(gallery))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "dance.jpg", gallery.lastFile)
+		assert.Equal(t, "Aunt May", gallery.lastUpload.UploaderName)
+		assert.Equal(t, "First dance", gallery.lastUpload.Caption)
+		assert.Contains(t, w.Body.String(), `"status":"pending"`)
+	})
+
+	t.Run("Error - uploads disabled", func(t *testing.T) {
+		w := upload(setupGalleryRouter(&MockGuestGallery{err: services.ErrGuestUploadsDisabled}))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Error - not an image", func(t *testing.T) {
+		w := upload(setupGalleryRouter(&MockGuestGallery{err: services.ErrGuestPhotoNotImage}))
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("Error - no file", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		setupGalleryRouter(&MockGuestGallery{}).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/public/weddings/"+models.NewID().String()+"/gallery", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGalleryHandler_GetGallery(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		setupGalleryRouter(&MockGuestGallery{}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/public/weddings/"+models.NewID().String()+"/gallery?page=1", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"approved"`)
+	})
+
+	t.Run("Error - wedding not found", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		setupGalleryRouter(&MockGuestGallery{err: services.ErrWeddingNotFound}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/public/weddings/"+models.NewID().String()+"/gallery", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestGalleryHandler_Moderation(t *testing.T) {
+	photoPath := func(action string) string {
+		return "/weddings/" + models.NewID().String() + "/gallery/" + models.NewID().String() + "/" + action
+	}
+
+	t.Run("Approve", func(t *testing.T) {
+		gallery := &MockGuestGallery{}
+		w := httptest.NewRecorder()
+		setupGalleryRouter(gallery).ServeHTTP(w, httptest.NewRequest(http.MethodPost, photoPath("approve"), nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.NotNil(t, gallery.approved)
+		assert.True(t, *gallery.approved)
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		gallery := &MockGuestGallery{}
+		w := httptest.NewRecorder()
+		setupGalleryRouter(gallery).ServeHTTP(w, httptest.NewRequest(http.MethodPost, photoPath("reject"), nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.NotNil(t, gallery.approved)
+		assert.False(t, *gallery.approved)
+	})
+
+	t.Run("Error - already moderated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		setupGalleryRouter(&MockGuestGallery{err: services.ErrGuestPhotoNotPending}).ServeHTTP(w, httptest.NewRequest(http.MethodPost, photoPath("approve"), nil))
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Error - not an editor", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		setupGalleryRouter(&MockGuestGallery{err: services.ErrUnauthorized}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/weddings/"+models.NewID().String()+"/gallery/pending", nil))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Error - invalid photo ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		setupGalleryRouter(&MockGuestGallery{}).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/weddings/"+models.NewID().String()+"/gallery/nope/approve", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
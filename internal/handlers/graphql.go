@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/graphql"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// DashboardWeddings reads the weddings shown on the dashboard
+type DashboardWeddings interface {
+	GetWeddingsByIDs(ctx context.Context, ids []models.ID, requestingUserID models.ID) (map[models.ID]*models.Wedding, error)
+	GetUserWeddings(ctx context.Context, userID models.ID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error)
+}
+
+// DashboardGuests reads the guest lists of weddings
+type DashboardGuests interface {
+	ListGuests(ctx context.Context, weddingID, userID models.ID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error)
+}
+
+// DashboardRSVPs reads the RSVPs of weddings and their statistics
+type DashboardRSVPs interface {
+	ListRSVPs(ctx context.Context, weddingID models.ID, userID models.ID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error)
+	GetRSVPStatistics(ctx context.Context, weddingID models.ID, userID models.ID) (*models.RSVPStatistics, error)
+}
+
+// DashboardAnalytics reads the analytics summaries of weddings
+type DashboardAnalytics interface {
+	GetAnalyticsSummary(ctx context.Context, weddingID models.ID, period string) (*models.AnalyticsSummary, error)
+	ValidatePeriod(period string) bool
+}
+
+// DashboardMedia reads the media users uploaded
+type DashboardMedia interface {
+	GetUserMedia(ctx context.Context, userID models.ID, page, pageSize int, filters repository.MediaFilter) ([]*models.Media, int64, error)
+}
+
+// DashboardProfiles reads the profile of the current user
+type DashboardProfiles interface {
+	GetProfile(ctx context.Context, userID models.ID) (*models.User, error)
+}
+
+// GraphQLHandler serves the read-only GraphQL API of the dashboard. It reads
+// through the same services as the REST API, batching the lookups of the
+// fields a query selects for many weddings at once.
+type GraphQLHandler struct {
+	weddings  DashboardWeddings
+	guests    DashboardGuests
+	rsvps     DashboardRSVPs
+	analytics DashboardAnalytics
+	media     DashboardMedia
+	profiles  DashboardProfiles
+	pii       *PIIAccess
+	schema    *graphql.Schema
+	logger    *zap.Logger
+}
+
+// NewGraphQLHandler creates a new GraphQL handler
+func NewGraphQLHandler(
+	weddings DashboardWeddings,
+	guests DashboardGuests,
+	rsvps DashboardRSVPs,
+	analytics DashboardAnalytics,
+	media DashboardMedia,
+	profiles DashboardProfiles,
+	logger *zap.Logger,
+) *GraphQLHandler {
+	h := &GraphQLHandler{
+		weddings:  weddings,
+		guests:    guests,
+		rsvps:     rsvps,
+		analytics: analytics,
+		media:     media,
+		profiles:  profiles,
+		logger:    logger,
+	}
+	h.schema = h.newSchema()
+	h.schema.PresentError = h.presentError
+	return h
+}
+
+// EnablePIIReveal allows users to request unmasked guest and RSVP contact
+// details of their weddings, recording each reveal with auditLog
+func (h *GraphQLHandler) EnablePIIReveal(auditLog AuditLogger) {
+	h.pii = NewPIIAccess(auditLog)
+}
+
+// Query godoc
+// @Summary Query the dashboard with GraphQL
+// @Description Run a GraphQL query over the current user's weddings, guests, RSVPs, analytics summaries and media. Only queries are supported. Errors of single fields are returned alongside the data with the field set to null.
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Param request body graphql.Request true "GraphQL request"
+// @Param reveal_pii query bool false "Return unmasked guest and RSVP emails and phone numbers (audit-logged)"
+// @Success 200 {object} graphql.Response
+// @Failure 400 {object} graphql.Response
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v1/graphql [post]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req graphql.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Guests and RSVPs are only listed for the user's own weddings
+	reveal, ok := h.pii.authorize(c, true)
+	if !ok {
+		return
+	}
+
+	loaders := h.newLoaders(userID, reveal)
+	response := h.schema.Execute(withDashboardLoaders(c.Request.Context(), loaders), req)
+	if reveal {
+		h.pii.record(c, "graphql", int(loaders.revealed.Load()))
+	}
+
+	status := http.StatusOK
+	if response.Data == nil {
+		status = http.StatusBadRequest
+	}
+	c.JSON(status, response)
+}
+
+// presentError shows the messages of domain errors as the REST API does and
+// hides those of internal errors
+func (h *GraphQLHandler) presentError(ctx context.Context, err error) string {
+	var domainErr *errs.Error
+	var validationErr *errs.ValidationError
+	message := ""
+	switch {
+	case errors.As(err, &domainErr):
+		message = domainErr.Message
+	case errors.As(err, &validationErr):
+		message = validationErr.Message
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "Request cancelled"
+	default:
+		h.logger.Error("GraphQL field failed", zap.Error(err))
+		return "An unexpected error occurred"
+	}
+	if message == "" {
+		return message
+	}
+	return strings.ToUpper(message[:1]) + message[1:]
+}
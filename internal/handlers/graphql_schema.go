@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"context"
+	"sync/atomic"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/graphql"
+	"wedding-invitation-backend/internal/services"
+)
+
+const (
+	// dashboardFetchLimit is how many lookups of one kind a query runs at once
+	// against services without batch lookups
+	dashboardFetchLimit = 8
+	// dashboardMaxComplexity admits the guests and RSVPs of a default page of
+	// weddings, but not full pages of both, see dashboardComplexity
+	dashboardMaxComplexity = 5000
+)
+
+// dashboardPage is a page of a list, shaped like the REST API's paginated responses
+type dashboardPage struct {
+	Items interface{} `json:"items"`
+	Count int64       `json:"count"`
+	Page  int         `json:"page"`
+	Size  int         `json:"size"`
+	Total int64       `json:"total"`
+}
+
+// guestPageKey holds the guest filters the schema offers; GuestFilters
+// itself is not comparable
+type guestPageKey struct {
+	weddingID                     models.ID
+	page, size                    int
+	search, side, rsvpStatus, tag string
+}
+
+type rsvpPageKey struct {
+	weddingID  models.ID
+	page, size int
+	filters    repository.RSVPFilters
+}
+
+type analyticsKey struct {
+	weddingID models.ID
+	period    string
+}
+
+// dashboardLoaders batch and cache the lookups of one request by the current
+// user. Guest and RSVP contact details are masked unless reveal is set, in
+// which case revealed counts the records returned unmasked.
+type dashboardLoaders struct {
+	userID    models.ID
+	reveal    bool
+	revealed  *atomic.Int64
+	weddings  *graphql.Loader[models.ID, *models.Wedding]
+	guests    *graphql.Loader[guestPageKey, *dashboardPage]
+	rsvps     *graphql.Loader[rsvpPageKey, *dashboardPage]
+	stats     *graphql.Loader[models.ID, *models.RSVPStatistics]
+	analytics *graphql.Loader[analyticsKey, *models.AnalyticsSummary]
+}
+
+type dashboardLoadersKey struct{}
+
+func withDashboardLoaders(ctx context.Context, loaders *dashboardLoaders) context.Context {
+	return context.WithValue(ctx, dashboardLoadersKey{}, loaders)
+}
+
+func dashboardLoadersFrom(ctx context.Context) *dashboardLoaders {
+	return ctx.Value(dashboardLoadersKey{}).(*dashboardLoaders)
+}
+
+func (h *GraphQLHandler) newLoaders(userID models.ID, reveal bool) *dashboardLoaders {
+	config := graphql.DefaultLoaderConfig()
+	revealed := new(atomic.Int64)
+	return &dashboardLoaders{
+		userID:   userID,
+		reveal:   reveal,
+		revealed: revealed,
+		weddings: graphql.NewLoader(func(ctx context.Context, ids []models.ID) []graphql.Result[*models.Wedding] {
+			results := make([]graphql.Result[*models.Wedding], len(ids))
+			weddings, err := h.weddings.GetWeddingsByIDs(ctx, ids, userID)
+			for i, id := range ids {
+				switch wedding, found := weddings[id]; {
+				case err != nil:
+					results[i].Err = err
+				case !found:
+					results[i].Err = services.ErrWeddingNotFound
+				default:
+					results[i].Value = wedding
+				}
+			}
+			return results
+		}, config),
+		guests: graphql.NewLoader(graphql.FetchEach(dashboardFetchLimit, func(ctx context.Context, key guestPageKey) (*dashboardPage, error) {
+			filters := repository.GuestFilters{Search: key.search, Side: key.side, RSVPStatus: key.rsvpStatus, Tag: key.tag}
+			guests, total, err := h.guests.ListGuests(ctx, key.weddingID, userID, key.page, key.size, filters)
+			if err != nil {
+				return nil, err
+			}
+			if reveal {
+				revealed.Add(int64(len(guests)))
+			} else {
+				guests = maskGuests(guests)
+			}
+			return &dashboardPage{Items: guests, Count: int64(len(guests)), Page: key.page, Size: key.size, Total: total}, nil
+		}), config),
+		rsvps: graphql.NewLoader(graphql.FetchEach(dashboardFetchLimit, func(ctx context.Context, key rsvpPageKey) (*dashboardPage, error) {
+			rsvps, total, err := h.rsvps.ListRSVPs(ctx, key.weddingID, userID, key.page, key.size, key.filters)
+			if err != nil {
+				return nil, err
+			}
+			if reveal {
+				revealed.Add(int64(len(rsvps)))
+			} else {
+				rsvps = maskRSVPs(rsvps)
+			}
+			return &dashboardPage{Items: rsvps, Count: int64(len(rsvps)), Page: key.page, Size: key.size, Total: total}, nil
+		}), config),
+		stats: graphql.NewLoader(graphql.FetchEach(dashboardFetchLimit, func(ctx context.Context, weddingID models.ID) (*models.RSVPStatistics, error) {
+			return h.rsvps.GetRSVPStatistics(ctx, weddingID, userID)
+		}), config),
+		analytics: graphql.NewLoader(graphql.FetchEach(dashboardFetchLimit, func(ctx context.Context, key analyticsKey) (*models.AnalyticsSummary, error) {
+			return h.analytics.GetAnalyticsSummary(ctx, key.weddingID, key.period)
+		}), config),
+	}
+}
+
+// newSchema builds the dashboard schema. Weddings, guests, RSVPs, media and
+// users have the fields of their REST representations; weddings add their
+// guests, RSVPs, RSVP statistics and analytics summary.
+func (h *GraphQLHandler) newSchema() *graphql.Schema {
+	schema := graphql.NewSchema(&graphql.Object{Name: "Query", Fields: map[string]graphql.ResolveFunc{
+		"me":       h.resolveMe,
+		"wedding":  h.resolveWedding,
+		"weddings": h.resolveWeddings,
+		"media":    h.resolveMedia,
+	}})
+	schema.Bind(models.Wedding{}, &graphql.Object{Name: "Wedding", Fields: map[string]graphql.ResolveFunc{
+		"guests":          h.resolveGuests,
+		"rsvps":           h.resolveRSVPs,
+		"rsvp_statistics": h.resolveRSVPStatistics,
+		"analytics":       h.resolveAnalytics,
+	}})
+	schema.Complexity = dashboardComplexity
+	schema.MaxComplexity = dashboardMaxComplexity
+	return schema
+}
+
+// dashboardPageFields are the fields returning a page of a list
+var dashboardPageFields = map[string]bool{"weddings": true, "media": true, "guests": true, "rsvps": true}
+
+// dashboardComplexity counts what a page selects once for every item the page
+// may hold, so that guests of every wedding on a page of weddings add up
+func dashboardComplexity(field *graphql.Field, args graphql.Args, childComplexity int) int {
+	if !dashboardPageFields[field.Name] {
+		return childComplexity + 1
+	}
+	_, size, err := pageArgs(args)
+	if err != nil {
+		// The resolver reports the invalid argument
+		return childComplexity + 1
+	}
+	return size*childComplexity + 1
+}
+
+func (h *GraphQLHandler) resolveMe(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	return h.profiles.GetProfile(ctx, dashboardLoadersFrom(ctx).userID)
+}
+
+func (h *GraphQLHandler) resolveWedding(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	raw, err := p.Args.String("id")
+	if err != nil {
+		return nil, err
+	}
+	id, err := models.ParseID(raw)
+	if err != nil {
+		return nil, errs.InvalidField("id", "invalid wedding ID")
+	}
+	return dashboardLoadersFrom(ctx).weddings.Load(ctx, id)
+}
+
+func (h *GraphQLHandler) resolveWeddings(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	page, size, err := pageArgs(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	var filters repository.WeddingFilters
+	if filters.Status, err = p.Args.String("status"); err != nil {
+		return nil, err
+	}
+	if filters.Search, err = p.Args.String("search"); err != nil {
+		return nil, err
+	}
+
+	weddings, total, err := h.weddings.GetUserWeddings(ctx, dashboardLoadersFrom(ctx).userID, page, size, filters)
+	if err != nil {
+		return nil, err
+	}
+	return &dashboardPage{Items: weddings, Count: int64(len(weddings)), Page: page, Size: size, Total: total}, nil
+}
+
+func (h *GraphQLHandler) resolveMedia(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	page, size, err := pageArgs(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	var filters repository.MediaFilter
+	if filters.MimeType, err = p.Args.String("mimeType"); err != nil {
+		return nil, err
+	}
+
+	media, total, err := h.media.GetUserMedia(ctx, dashboardLoadersFrom(ctx).userID, page, size, filters)
+	if err != nil {
+		return nil, err
+	}
+	return &dashboardPage{Items: media, Count: int64(len(media)), Page: page, Size: size, Total: total}, nil
+}
+
+func (h *GraphQLHandler) resolveGuests(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	key := guestPageKey{weddingID: p.Source.(*models.Wedding).ID}
+	var err error
+	if key.page, key.size, err = pageArgs(p.Args); err != nil {
+		return nil, err
+	}
+	for name, filter := range map[string]*string{
+		"search":      &key.search,
+		"side":        &key.side,
+		"rsvp_status": &key.rsvpStatus,
+		"tag":         &key.tag,
+	} {
+		if *filter, err = p.Args.String(name); err != nil {
+			return nil, err
+		}
+	}
+	return dashboardLoadersFrom(ctx).guests.Load(ctx, key)
+}
+
+func (h *GraphQLHandler) resolveRSVPs(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	key := rsvpPageKey{weddingID: p.Source.(*models.Wedding).ID}
+	var err error
+	if key.page, key.size, err = pageArgs(p.Args); err != nil {
+		return nil, err
+	}
+	if key.filters.Status, err = p.Args.String("status"); err != nil {
+		return nil, err
+	}
+	if key.filters.Search, err = p.Args.String("search"); err != nil {
+		return nil, err
+	}
+	return dashboardLoadersFrom(ctx).rsvps.Load(ctx, key)
+}
+
+func (h *GraphQLHandler) resolveRSVPStatistics(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	return dashboardLoadersFrom(ctx).stats.Load(ctx, p.Source.(*models.Wedding).ID)
+}
+
+func (h *GraphQLHandler) resolveAnalytics(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+	wedding := p.Source.(*models.Wedding)
+	loaders := dashboardLoadersFrom(ctx)
+	if !wedding.Can(loaders.userID, models.PermissionViewAnalytics) {
+		return nil, services.ErrWeddingAccessDenied
+	}
+
+	period, err := p.Args.String("period")
+	if err != nil {
+		return nil, err
+	}
+	if period == "" {
+		period = "daily"
+	}
+	if !h.analytics.ValidatePeriod(period) {
+		return nil, errs.InvalidField("period", "invalid period")
+	}
+	return loaders.analytics.Load(ctx, analyticsKey{weddingID: wedding.ID, period: period})
+}
+
+// pageArgs returns the page and size arguments with the defaults and limit
+// of utils.ParsePaginationParams
+func pageArgs(args graphql.Args) (int, int, error) {
+	page, err := args.Int("page", 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	size, err := args.Int("size", 20)
+	if err != nil {
+		return 0, 0, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 20
+	} else if size > 100 {
+		size = 100
+	}
+	return page, size, nil
+}
@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/graphql"
+)
+
+// MockDashboard serves the GraphQL handler from in-memory weddings, counting
+// the lookups it receives
+type MockDashboard struct {
+	mu             sync.Mutex
+	weddings       map[models.ID]*models.Wedding
+	batchLookups   [][]models.ID
+	statsLookups   int
+	summaryLookups int
+}
+
+func (m *MockDashboard) GetWeddingsByIDs(ctx context.Context, ids []models.ID, requestingUserID models.ID) (map[models.ID]*models.Wedding, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batchLookups = append(m.batchLookups, ids)
+	found := make(map[models.ID]*models.Wedding)
+	for _, id := range ids {
+		if wedding, ok := m.weddings[id]; ok {
+			found[id] = wedding
+		}
+	}
+	return found, nil
+}
+
+func (m *MockDashboard) GetUserWeddings(ctx context.Context, userID models.ID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error) {
+	var weddings []*models.Wedding
+	for _, wedding := range m.weddings {
+		if wedding.UserID == userID {
+			weddings = append(weddings, wedding)
+		}
+	}
+	return weddings, int64(len(weddings)), nil
+}
+
+func (m *MockDashboard) ListGuests(ctx context.Context, weddingID, userID models.ID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error) {
+	guests := []*models.Guest{{ID: models.NewID(), WeddingID: weddingID, FirstName: "Ann", Email: "ann.lee@gmail.com", Phone: "+6281234561234"}}
+	return guests, 1, nil
+}
+
+func (m *MockDashboard) ListRSVPs(ctx context.Context, weddingID models.ID, userID models.ID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error) {
+	return []*models.RSVP{{ID: models.NewID(), WeddingID: weddingID, FirstName: "Ben", Email: "ben.ong@gmail.com", Phone: "+6281299995678"}}, 1, nil
+}
+
+func (m *MockDashboard) GetRSVPStatistics(ctx context.Context, weddingID models.ID, userID models.ID) (*models.RSVPStatistics, error) {
+	m.mu.Lock()
+	m.statsLookups++
+	m.mu.Unlock()
+	return &models.RSVPStatistics{TotalResponses: 3, Attending: 2}, nil
+}
+
+func (m *MockDashboard) GetAnalyticsSummary(ctx context.Context, weddingID models.ID, period string) (*models.AnalyticsSummary, error) {
+	m.mu.Lock()
+	m.summaryLookups++
+	m.mu.Unlock()
+	if period == "monthly" {
+		return nil, errors.New("connection refused")
+	}
+	return &models.AnalyticsSummary{Period: period, TotalPageViews: 42}, nil
+}
+
+func (m *MockDashboard) ValidatePeriod(period string) bool {
+	return period == "daily" || period == "weekly" || period == "monthly"
+}
+
+func (m *MockDashboard) GetUserMedia(ctx context.Context, userID models.ID, page, pageSize int, filters repository.MediaFilter) ([]*models.Media, int64, error) {
+	return []*models.Media{{ID: models.NewID(), MimeType: "image/jpeg"}}, 1, nil
+}
+
+func (m *MockDashboard) GetProfile(ctx context.Context, userID models.ID) (*models.User, error) {
+	return &models.User{ID: userID, Email: "owner@example.com", PasswordHash: "secret"}, nil
+}
+
+func setupGraphQLTestRouter(dashboard *MockDashboard, auditLog ...AuditLogger) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-User-ID"); userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	})
+
+	handler := NewGraphQLHandler(dashboard, dashboard, dashboard, dashboard, dashboard, dashboard, zap.NewNop())
+	if len(auditLog) > 0 {
+		handler.EnablePIIReveal(auditLog[0])
+	}
+	router.POST("/graphql", handler.Query)
+	return router
+}
+
+func sendGraphQLQuery(t *testing.T, router *gin.Engine, userID models.ID, req graphql.Request) (int, map[string]interface{}) {
+	t.Helper()
+	return sendGraphQLRequest(t, router, "/graphql", userID, req)
+}
+
+func sendGraphQLRequest(t *testing.T, router *gin.Engine, path string, userID models.ID, req graphql.Request) (int, map[string]interface{}) {
+	t.Helper()
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+	httpReq := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+	httpReq.Header.Set("Content-Type", "application/json")
+	if userID != models.NilID {
+		httpReq.Header.Set("X-User-ID", userID.String())
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), w.Body.String())
+	return w.Code, body
+}
+
+func TestGraphQLHandler_Query(t *testing.T) {
+	ownerID := models.NewID()
+	first := &models.Wedding{ID: models.NewID(), UserID: ownerID, Title: "First", Status: string(models.WeddingStatusPublished)}
+	second := &models.Wedding{ID: models.NewID(), UserID: ownerID, Title: "Second"}
+	dashboard := &MockDashboard{weddings: map[models.ID]*models.Wedding{first.ID: first, second.ID: second}}
+	router := setupGraphQLTestRouter(dashboard)
+
+	code, body := sendGraphQLQuery(t, router, ownerID, graphql.Request{
+		Query: `query Dashboard($a: ID!, $b: ID!) {
+			me { email password_hash }
+			a: wedding(id: $a) { title rsvp_statistics { attending } analytics(period: weekly) { total_page_views } }
+			b: wedding(id: $b) { title rsvp_statistics { attending } guests(side: "bride") { total items { first_name } } }
+		}`,
+		Variables: map[string]interface{}{"a": first.ID.String(), "b": second.ID.String()},
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"email": "owner@example.com", "password_hash": nil}, data["me"], "hidden fields cannot be selected")
+	assert.Equal(t, map[string]interface{}{
+		"title":           "First",
+		"rsvp_statistics": map[string]interface{}{"attending": float64(2)},
+		"analytics":       map[string]interface{}{"total_page_views": float64(42)},
+	}, data["a"])
+	assert.Equal(t, "Second", data["b"].(map[string]interface{})["title"])
+	assert.Equal(t, map[string]interface{}{
+		"total": float64(1),
+		"items": []interface{}{map[string]interface{}{"first_name": "Ann"}},
+	}, data["b"].(map[string]interface{})["guests"])
+
+	require.Len(t, body["errors"], 1)
+	assert.Equal(t, []interface{}{"me", "password_hash"}, body["errors"].([]interface{})[0].(map[string]interface{})["path"])
+
+	assert.Len(t, dashboard.batchLookups, 1, "both weddings are loaded in one lookup")
+	assert.ElementsMatch(t, []models.ID{first.ID, second.ID}, dashboard.batchLookups[0])
+	assert.Equal(t, 2, dashboard.statsLookups)
+}
+
+func TestGraphQLHandler_WeddingsAndMedia(t *testing.T) {
+	ownerID := models.NewID()
+	wedding := &models.Wedding{ID: models.NewID(), UserID: ownerID, Title: "Ours"}
+	dashboard := &MockDashboard{weddings: map[models.ID]*models.Wedding{wedding.ID: wedding}}
+	router := setupGraphQLTestRouter(dashboard)
+
+	code, body := sendGraphQLQuery(t, router, ownerID, graphql.Request{Query: `{
+		weddings(size: 500) { total size items { __typename id title } }
+		media { items { mimeType } }
+	}`})
+	require.Equal(t, http.StatusOK, code)
+	assert.Nil(t, body["errors"])
+	assert.Equal(t, map[string]interface{}{
+		"total": float64(1),
+		"size":  float64(100),
+		"items": []interface{}{map[string]interface{}{"__typename": "Wedding", "id": wedding.ID.String(), "title": "Ours"}},
+	}, body["data"].(map[string]interface{})["weddings"])
+	assert.Equal(t, map[string]interface{}{
+		"items": []interface{}{map[string]interface{}{"mimeType": "image/jpeg"}},
+	}, body["data"].(map[string]interface{})["media"])
+}
+
+func TestGraphQLHandler_FieldErrors(t *testing.T) {
+	ownerID := models.NewID()
+	published := &models.Wedding{ID: models.NewID(), UserID: models.NewID(), Status: string(models.WeddingStatusPublished), Title: "Theirs"}
+	own := &models.Wedding{ID: models.NewID(), UserID: ownerID, Title: "Ours"}
+	dashboard := &MockDashboard{weddings: map[models.ID]*models.Wedding{published.ID: published, own.ID: own}}
+	router := setupGraphQLTestRouter(dashboard)
+
+	code, body := sendGraphQLQuery(t, router, ownerID, graphql.Request{
+		Query: `query ($theirs: ID!, $ours: ID!, $missing: ID!) {
+			theirs: wedding(id: $theirs) { title analytics { period } }
+			ours: wedding(id: $ours) { bad: analytics(period: "hourly") { period } down: analytics(period: "monthly") { period } }
+			missing: wedding(id: $missing) { title }
+			invalid: wedding(id: "nope") { title }
+		}`,
+		Variables: map[string]interface{}{"theirs": published.ID.String(), "ours": own.ID.String(), "missing": models.NewID().String()},
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	messages := make(map[string]interface{})
+	for _, raw := range body["errors"].([]interface{}) {
+		fieldErr := raw.(map[string]interface{})
+		path, _ := json.Marshal(fieldErr["path"])
+		messages[string(path)] = fieldErr["message"]
+	}
+	assert.Equal(t, map[string]interface{}{
+		`["theirs","analytics"]`: "Access denied",
+		`["ours","bad"]`:         "Invalid period",
+		`["ours","down"]`:        "An unexpected error occurred",
+		`["missing"]`:            "Wedding not found",
+		`["invalid"]`:            "Invalid wedding ID",
+	}, messages)
+	assert.Equal(t, "Theirs", body["data"].(map[string]interface{})["theirs"].(map[string]interface{})["title"])
+}
+
+func TestGraphQLHandler_RequestErrors(t *testing.T) {
+	router := setupGraphQLTestRouter(&MockDashboard{weddings: map[models.ID]*models.Wedding{}})
+
+	code, body := sendGraphQLQuery(t, router, models.NewID(), graphql.Request{Query: `{ weddings { items { title }`})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Nil(t, body["data"])
+	assert.NotEmpty(t, body["errors"])
+
+	code, body = sendGraphQLQuery(t, router, models.NewID(), graphql.Request{Query: `mutation { deleteWedding(id: "x") { id } }`})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "mutation operations are not supported", body["errors"].([]interface{})[0].(map[string]interface{})["message"])
+
+	code, _ = sendGraphQLQuery(t, router, models.NilID, graphql.Request{Query: `{ me { email } }`})
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestGraphQLHandler_ContactDetails(t *testing.T) {
+	ownerID := models.NewID()
+	wedding := &models.Wedding{ID: models.NewID(), UserID: ownerID, Title: "First"}
+	dashboard := &MockDashboard{weddings: map[models.ID]*models.Wedding{wedding.ID: wedding}}
+	req := graphql.Request{
+		Query:     `query($id: ID!) { wedding(id: $id) { guests { items { email phone } } rsvps { items { email phone } } } }`,
+		Variables: map[string]interface{}{"id": wedding.ID.String()},
+	}
+	contacts := func(body map[string]interface{}, list string) map[string]interface{} {
+		page := body["data"].(map[string]interface{})["wedding"].(map[string]interface{})[list].(map[string]interface{})
+		return page["items"].([]interface{})[0].(map[string]interface{})
+	}
+
+	t.Run("Masked by default", func(t *testing.T) {
+		code, body := sendGraphQLQuery(t, setupGraphQLTestRouter(dashboard), ownerID, req)
+		require.Equal(t, http.StatusOK, code, body)
+		assert.Equal(t, map[string]interface{}{"email": "a***@gmail.com", "phone": "+62***1234"}, contacts(body, "guests"))
+		assert.Equal(t, map[string]interface{}{"email": "b***@gmail.com", "phone": "+62***5678"}, contacts(body, "rsvps"))
+	})
+
+	t.Run("Reveal is audit logged", func(t *testing.T) {
+		auditLog := &MockAuditLogger{}
+		auditLog.On("Log", mock.Anything, ownerID.String(), "pii.reveal", mock.MatchedBy(func(metadata map[string]interface{}) bool {
+			return metadata["resource"] == "graphql" && metadata["records"] == 2
+		})).Once()
+
+		code, body := sendGraphQLRequest(t, setupGraphQLTestRouter(dashboard, auditLog), "/graphql?reveal_pii=true", ownerID, req)
+		require.Equal(t, http.StatusOK, code, body)
+		assert.Equal(t, "ann.lee@gmail.com", contacts(body, "guests")["email"])
+		assert.Equal(t, "+6281299995678", contacts(body, "rsvps")["phone"])
+		auditLog.AssertExpectations(t)
+	})
+
+	t.Run("Reveal refused without audit logging", func(t *testing.T) {
+		code, _ := sendGraphQLRequest(t, setupGraphQLTestRouter(dashboard), "/graphql?reveal_pii=true", ownerID, req)
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+}
+
+func TestGraphQLHandler_Complexity(t *testing.T) {
+	ownerID := models.NewID()
+	router := setupGraphQLTestRouter(&MockDashboard{weddings: map[models.ID]*models.Wedding{}})
+
+	code, body := sendGraphQLQuery(t, router, ownerID, graphql.Request{
+		Query: `{ weddings { items { title guests { total items { first_name } } rsvps { total items { first_name } } } } }`,
+	})
+	assert.Equal(t, http.StatusOK, code, body)
+
+	// Every guest of every wedding on a full page counts
+	code, body = sendGraphQLQuery(t, router, ownerID, graphql.Request{
+		Query: `{ weddings(size: 100) { items { title guests(size: 100) { total items { first_name } } } } }`,
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "query complexity exceeds the maximum of 5000", body["errors"].([]interface{})[0].(map[string]interface{})["message"])
+}
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -16,6 +18,8 @@ import (
 // GuestHandler handles guest-related HTTP requests
 type GuestHandler struct {
 	guestService services.GuestServiceInterface
+	pii          *PIIAccess
+	exportJobs   services.ExportJobRecorder
 }
 
 // NewGuestHandler creates a new guest handler
@@ -25,6 +29,18 @@ func NewGuestHandler(guestService services.GuestServiceInterface) *GuestHandler
 	}
 }
 
+// EnablePIIReveal allows owners to request unmasked guest contact details,
+// recording each reveal with auditLog
+func (h *GuestHandler) EnablePIIReveal(auditLog AuditLogger) {
+	h.pii = NewPIIAccess(auditLog)
+}
+
+// EnableExportJobs records every guest export, including the fingerprint of the
+// key encrypted exports were sealed to, in the wedding's export history
+func (h *GuestHandler) EnableExportJobs(recorder services.ExportJobRecorder) {
+	h.exportJobs = recorder
+}
+
 // CreateGuestRequest represents a request to create a guest
 type CreateGuestRequest struct {
 	FirstName        string          `json:"first_name" validate:"required"`
@@ -35,13 +51,14 @@ type CreateGuestRequest struct {
 	Relationship     string          `json:"relationship,omitempty"`
 	Side             string          `json:"side,omitempty" validate:"oneof=bride groom both"`
 	InvitedVia       string          `json:"invited_via,omitempty" validate:"oneof=digital manual"`
-	InvitationStatus string          `json:"invitation_status,omitempty" validate:"oneof=pending sent delivered failed"`
+	InvitationStatus string          `json:"invitation_status,omitempty" validate:"oneof=pending sent delivered read failed"`
 	AllowPlusOne     bool            `json:"allow_plus_one,omitempty"`
 	MaxPlusOnes      int             `json:"max_plus_ones,omitempty" validate:"min=0,max=5"`
 	RSVPStatus       string          `json:"rsvp_status,omitempty" validate:"omitempty,oneof=attending not-attending maybe pending"`
 	DietaryNotes     string          `json:"dietary_notes,omitempty"`
 	VIP              bool            `json:"vip,omitempty"`
 	Notes            string          `json:"notes,omitempty"`
+	Tags             []string        `json:"tags,omitempty" validate:"omitempty,max=20,dive,required,max=30"`
 }
 
 // BulkCreateGuestsRequest represents a request to create multiple guests
@@ -59,39 +76,75 @@ type UpdateGuestRequest struct {
 	Relationship     *string         `json:"relationship,omitempty"`
 	Side             *string         `json:"side,omitempty" validate:"omitempty,oneof=bride groom both"`
 	InvitedVia       *string         `json:"invited_via,omitempty" validate:"omitempty,oneof=digital manual"`
-	InvitationStatus *string         `json:"invitation_status,omitempty" validate:"omitempty,oneof=pending sent delivered failed"`
+	InvitationStatus *string         `json:"invitation_status,omitempty" validate:"omitempty,oneof=pending sent delivered read failed"`
 	AllowPlusOne     *bool           `json:"allow_plus_one,omitempty"`
 	MaxPlusOnes      *int            `json:"max_plus_ones,omitempty" validate:"omitempty,min=0,max=5"`
 	RSVPStatus       *string         `json:"rsvp_status,omitempty" validate:"omitempty,oneof=attending not-attending maybe pending"`
 	DietaryNotes     *string         `json:"dietary_notes,omitempty"`
 	VIP              *bool           `json:"vip,omitempty"`
 	Notes            *string         `json:"notes,omitempty"`
+	Tags             []string        `json:"tags,omitempty" validate:"omitempty,max=20,dive,required,max=30"`
+}
+
+// BulkGuestSelection selects the guests of a bulk operation, either by ID or as
+// every guest of an import batch. With atomic, a guest that cannot be changed
+// leaves all of them unchanged.
+type BulkGuestSelection struct {
+	GuestIDs      []string `json:"guest_ids,omitempty" validate:"omitempty,max=500"`
+	ImportBatchID string   `json:"import_batch_id,omitempty"`
+	Atomic        bool     `json:"atomic,omitempty"`
+}
+
+// GuestPatchRequest lists the changes of a bulk update; omitted fields are left unchanged
+type GuestPatchRequest struct {
+	Side             *string  `json:"side,omitempty" validate:"omitempty,oneof=bride groom both"`
+	Relationship     *string  `json:"relationship,omitempty"`
+	InvitedVia       *string  `json:"invited_via,omitempty" validate:"omitempty,oneof=digital manual"`
+	InvitationStatus *string  `json:"invitation_status,omitempty" validate:"omitempty,oneof=pending sent delivered failed"`
+	VIP              *bool    `json:"vip,omitempty"`
+	AllowPlusOne     *bool    `json:"allow_plus_one,omitempty"`
+	AddTags          []string `json:"add_tags,omitempty" validate:"omitempty,max=20,dive,required,max=30"`
+	RemoveTags       []string `json:"remove_tags,omitempty" validate:"omitempty,max=20,dive,required,max=30"`
+}
+
+// BulkUpdateGuestsRequest represents a request to change many guests at once
+type BulkUpdateGuestsRequest struct {
+	BulkGuestSelection
+	Patch GuestPatchRequest `json:"patch"`
+}
+
+// BulkDeleteGuestsRequest represents a request to delete many guests at once
+type BulkDeleteGuestsRequest struct {
+	BulkGuestSelection
 }
 
 // GuestResponse represents a guest response
 type GuestResponse struct {
-	ID               primitive.ObjectID  `json:"id"`
-	WeddingID        primitive.ObjectID  `json:"wedding_id"`
-	FirstName        string              `json:"first_name"`
-	LastName         string              `json:"last_name"`
-	Email            string              `json:"email,omitempty"`
-	Phone            string              `json:"phone,omitempty"`
-	Address          *models.Address     `json:"address,omitempty"`
-	Relationship     string              `json:"relationship,omitempty"`
-	Side             string              `json:"side,omitempty"`
-	InvitedVia       string              `json:"invited_via"`
-	InvitationStatus string              `json:"invitation_status"`
-	AllowPlusOne     bool                `json:"allow_plus_one"`
-	MaxPlusOnes      int                 `json:"max_plus_ones"`
-	RSVPStatus       string              `json:"rsvp_status,omitempty"`
-	RSVPID           *primitive.ObjectID `json:"rsvp_id,omitempty"`
-	DietaryNotes     string              `json:"dietary_notes,omitempty"`
-	VIP              bool                `json:"vip"`
-	Notes            string              `json:"notes,omitempty"`
-	ImportBatchID    string              `json:"import_batch_id,omitempty"`
-	CreatedBy        primitive.ObjectID  `json:"created_by"`
-	CreatedAt        primitive.DateTime  `json:"created_at"`
-	UpdatedAt        primitive.DateTime  `json:"updated_at"`
+	ID               models.ID            `json:"id"`
+	WeddingID        models.ID            `json:"wedding_id"`
+	FirstName        string               `json:"first_name"`
+	LastName         string               `json:"last_name"`
+	Email            string               `json:"email,omitempty"`
+	Phone            string               `json:"phone,omitempty"`
+	Address          *models.Address      `json:"address,omitempty"`
+	Relationship     string               `json:"relationship,omitempty"`
+	Side             string               `json:"side,omitempty"`
+	InvitedVia       string               `json:"invited_via"`
+	InvitationStatus string               `json:"invitation_status"`
+	AllowPlusOne     bool                 `json:"allow_plus_one"`
+	MaxPlusOnes      int                  `json:"max_plus_ones"`
+	RSVPStatus       string               `json:"rsvp_status,omitempty"`
+	RSVPID           *models.ID           `json:"rsvp_id,omitempty"`
+	Companions       []models.PlusOneInfo `json:"companions,omitempty"`
+	DietaryNotes     string               `json:"dietary_notes,omitempty"`
+	VIP              bool                 `json:"vip"`
+	Notes            string               `json:"notes,omitempty"`
+	Tags             []string             `json:"tags,omitempty"`
+	ImportBatchID    string               `json:"import_batch_id,omitempty"`
+	CheckIn          *models.GuestCheckIn `json:"check_in,omitempty"`
+	CreatedBy        models.ID            `json:"created_by"`
+	CreatedAt        primitive.DateTime   `json:"created_at"`
+	UpdatedAt        primitive.DateTime   `json:"updated_at"`
 }
 
 // GuestListResponse represents a list of guests with pagination
@@ -112,7 +165,7 @@ type ImportResult struct {
 
 // CreateGuest creates a new guest
 func (h *GuestHandler) CreateGuest(c *gin.Context) {
-	weddingID, err := primitive.ObjectIDFromHex(c.Param("wedding_id"))
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
 		return
@@ -161,6 +214,7 @@ func (h *GuestHandler) CreateGuest(c *gin.Context) {
 		DietaryNotes:     req.DietaryNotes,
 		VIP:              req.VIP,
 		Notes:            req.Notes,
+		Tags:             cleanTags(req.Tags),
 	}
 
 	// Set default status
@@ -179,6 +233,10 @@ func (h *GuestHandler) CreateGuest(c *gin.Context) {
 			utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
 			return
 		}
+		if errors.Is(err, services.ErrPlanLimitExceeded) {
+			utils.ErrorResponse(c, http.StatusPaymentRequired, err.Error())
+			return
+		}
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create guest")
 		return
 	}
@@ -192,7 +250,7 @@ func (h *GuestHandler) CreateGuest(c *gin.Context) {
 
 // GetGuest retrieves a single guest by ID
 func (h *GuestHandler) GetGuest(c *gin.Context) {
-	guestID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	guestID, err := models.ParseID(c.Param("id"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid guest ID")
 		return
@@ -217,9 +275,46 @@ func (h *GuestHandler) GetGuest(c *gin.Context) {
 	utils.Response(c, http.StatusOK, h.convertToGuestResponse(guest))
 }
 
+// parseGuestFilters reads the guest list filters shared by ListGuests and ExportGuests
+// from the query string. Invalid input is answered with 400.
+func parseGuestFilters(c *gin.Context) (repository.GuestFilters, bool) {
+	filters := repository.GuestFilters{
+		Search:           c.Query("search"),
+		Side:             c.Query("side"),
+		Relationship:     c.Query("relationship"),
+		RSVPStatus:       c.Query("rsvp_status"),
+		InvitationStatus: c.Query("invitation_status"),
+		InvitedVia:       c.Query("invited_via"),
+		Tag:              c.Query("tag"),
+		ImportBatchID:    c.Query("import_batch_id"),
+	}
+
+	flags := []struct {
+		name   string
+		target **bool
+	}{
+		{"vip", &filters.VIP},
+		{"allow_plus_one", &filters.AllowPlusOne},
+	}
+	for _, flag := range flags {
+		raw := c.Query(flag.name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid "+flag.name+" filter")
+			return filters, false
+		}
+		*flag.target = &value
+	}
+
+	return filters, true
+}
+
 // ListGuests retrieves guests for a wedding
 func (h *GuestHandler) ListGuests(c *gin.Context) {
-	weddingID, err := primitive.ObjectIDFromHex(c.Param("wedding_id"))
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
 		return
@@ -234,12 +329,9 @@ func (h *GuestHandler) ListGuests(c *gin.Context) {
 	// Parse pagination
 	page, size := utils.ParsePaginationParams(c)
 
-	// Parse filters
-	filters := repository.GuestFilters{
-		Search:           c.Query("search"),
-		Side:             c.Query("side"),
-		RSVPStatus:       c.Query("rsvp_status"),
-		InvitationStatus: c.Query("invitation_status"),
+	filters, ok := parseGuestFilters(c)
+	if !ok {
+		return
 	}
 
 	guests, total, err := h.guestService.ListGuests(c.Request.Context(), weddingID, userID, page, size, filters)
@@ -252,18 +344,150 @@ func (h *GuestHandler) ListGuests(c *gin.Context) {
 		return
 	}
 
-	// Convert to response format
+	reveal, ok := h.pii.Reveal(c, "guests", true, len(guests))
+	if !ok {
+		return
+	}
+
+	// Convert to response format, masking contact details unless revealed
 	guestResponses := make([]GuestResponse, len(guests))
 	for i, guest := range guests {
 		guestResponses[i] = *h.convertToGuestResponse(guest)
+		if !reveal {
+			guestResponses[i].Email = utils.MaskEmail(guest.Email)
+			guestResponses[i].Phone = utils.MaskPhone(guest.Phone)
+		}
 	}
 
 	utils.PaginatedResponse(c, http.StatusOK, guestResponses, int64(len(guestResponses)), total, page, size)
 }
 
+// SearchGuests finds guests of a wedding by name, email, phone or notes (?q=),
+// best matches first, up to ?limit= guests
+func (h *GuestHandler) SearchGuests(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Search query is required")
+		return
+	}
+	limit := services.DefaultGuestSearchLimit
+	if raw := c.Query("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > services.MaxGuestSearchLimit {
+			utils.ErrorResponse(c, http.StatusBadRequest, "limit must be between 1 and "+strconv.Itoa(services.MaxGuestSearchLimit))
+			return
+		}
+	}
+
+	guests, err := h.guestService.SearchGuests(c.Request.Context(), weddingID, userID, query, limit)
+	switch {
+	case err == nil:
+	case errors.Is(err, repository.ErrNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+		return
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to search guests of this wedding")
+		return
+	case errors.Is(err, services.ErrInvalidGuestSearch):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to search guests")
+		return
+	}
+
+	reveal, ok := h.pii.Reveal(c, "guests", true, len(guests))
+	if !ok {
+		return
+	}
+
+	guestResponses := make([]GuestResponse, len(guests))
+	for i, guest := range guests {
+		guestResponses[i] = *h.convertToGuestResponse(guest)
+		if !reveal {
+			guestResponses[i].Email = utils.MaskEmail(guest.Email)
+			guestResponses[i].Phone = utils.MaskPhone(guest.Phone)
+		}
+	}
+
+	utils.Response(c, http.StatusOK, guestResponses)
+}
+
+// ExportGuests streams the guests of a wedding as JSON, CSV (?format=csv) or XLSX
+// (?format=xlsx), filtered like ListGuests. A public key (?public_key= or the POST
+// body) encrypts the export to the owner's key.
+func (h *GuestHandler) ExportGuests(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	filters, ok := parseGuestFilters(c)
+	if !ok {
+		return
+	}
+
+	opts, ok := parseExportOptions(c)
+	if !ok {
+		return
+	}
+
+	var job *models.ExportJob
+	if h.exportJobs != nil {
+		job, err = h.exportJobs.StartExport(c.Request.Context(), weddingID, userID, guestCSVExport.filename, opts.format, opts.recipient)
+		if err != nil {
+			h.writeExportError(c, err)
+			return
+		}
+		c.Header("X-Export-Job-ID", job.ID.String())
+	}
+
+	records, started, err := streamExport(c, guestCSVExport, opts, func(emit func(record interface{}) error) error {
+		return h.guestService.StreamGuests(c.Request.Context(), weddingID, userID, filters, func(guest *models.Guest) error {
+			return emit(h.convertToGuestResponse(guest))
+		})
+	})
+	if job != nil {
+		h.exportJobs.FinishExport(context.WithoutCancel(c.Request.Context()), job, records, err)
+	}
+	if err != nil && !started {
+		h.writeExportError(c, err)
+	}
+}
+
+func (h *GuestHandler) writeExportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound), errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to export guests for this wedding")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to export guests")
+	}
+}
+
 // UpdateGuest updates an existing guest
 func (h *GuestHandler) UpdateGuest(c *gin.Context) {
-	guestID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	guestID, err := models.ParseID(c.Param("id"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid guest ID")
 		return
@@ -352,6 +576,9 @@ func (h *GuestHandler) UpdateGuest(c *gin.Context) {
 	if req.Notes != nil {
 		guest.Notes = *req.Notes
 	}
+	if req.Tags != nil {
+		guest.Tags = cleanTags(req.Tags)
+	}
 
 	if err := h.guestService.UpdateGuest(c.Request.Context(), guestID, userID, guest); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update guest")
@@ -363,7 +590,7 @@ func (h *GuestHandler) UpdateGuest(c *gin.Context) {
 
 // DeleteGuest deletes a guest
 func (h *GuestHandler) DeleteGuest(c *gin.Context) {
-	guestID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	guestID, err := models.ParseID(c.Param("id"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid guest ID")
 		return
@@ -389,7 +616,7 @@ func (h *GuestHandler) DeleteGuest(c *gin.Context) {
 
 // BulkCreateGuests creates multiple guests at once
 func (h *GuestHandler) BulkCreateGuests(c *gin.Context) {
-	weddingID, err := primitive.ObjectIDFromHex(c.Param("wedding_id"))
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
 		return
@@ -436,6 +663,7 @@ func (h *GuestHandler) BulkCreateGuests(c *gin.Context) {
 			DietaryNotes:     guestReq.DietaryNotes,
 			VIP:              guestReq.VIP,
 			Notes:            guestReq.Notes,
+			Tags:             cleanTags(guestReq.Tags),
 		}
 
 		// Set defaults
@@ -456,6 +684,10 @@ func (h *GuestHandler) BulkCreateGuests(c *gin.Context) {
 			utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
 			return
 		}
+		if errors.Is(err, services.ErrPlanLimitExceeded) {
+			utils.ErrorResponse(c, http.StatusPaymentRequired, err.Error())
+			return
+		}
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create guests")
 		return
 	}
@@ -478,7 +710,7 @@ func (h *GuestHandler) BulkCreateGuests(c *gin.Context) {
 
 // ImportGuestsCSV imports guests from a CSV file
 func (h *GuestHandler) ImportGuestsCSV(c *gin.Context) {
-	weddingID, err := primitive.ObjectIDFromHex(c.Param("wedding_id"))
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
 		return
@@ -499,6 +731,10 @@ func (h *GuestHandler) ImportGuestsCSV(c *gin.Context) {
 
 	result, err := h.guestService.ImportGuestsFromCSV(c.Request.Context(), weddingID, userID, file)
 	if err != nil {
+		if errors.Is(err, services.ErrPlanLimitExceeded) {
+			utils.ErrorResponse(c, http.StatusPaymentRequired, err.Error())
+			return
+		}
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to import guests: "+err.Error())
 		return
 	}
@@ -506,8 +742,165 @@ func (h *GuestHandler) ImportGuestsCSV(c *gin.Context) {
 	utils.Response(c, http.StatusOK, result)
 }
 
+// BulkUpdateGuests applies one change, such as the side, the invitation status or
+// tags, to the selected guests of a wedding
+func (h *GuestHandler) BulkUpdateGuests(c *gin.Context) {
+	weddingID, userID, ok := h.bulkTarget(c)
+	if !ok {
+		return
+	}
+
+	var req BulkUpdateGuestsRequest
+	if !bindBulkRequest(c, &req) {
+		return
+	}
+	selection, ok := parseGuestSelection(c, req.BulkGuestSelection)
+	if !ok {
+		return
+	}
+
+	patch := models.GuestPatch{
+		Side:             req.Patch.Side,
+		Relationship:     req.Patch.Relationship,
+		InvitedVia:       req.Patch.InvitedVia,
+		InvitationStatus: req.Patch.InvitationStatus,
+		VIP:              req.Patch.VIP,
+		AllowPlusOne:     req.Patch.AllowPlusOne,
+		AddTags:          cleanTags(req.Patch.AddTags),
+		RemoveTags:       cleanTags(req.Patch.RemoveTags),
+	}
+	if patch.IsEmpty() {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Patch must change at least one field")
+		return
+	}
+
+	result, err := h.guestService.BulkUpdateGuests(c.Request.Context(), weddingID, userID, selection, patch, req.Atomic)
+	h.writeBulkResult(c, result, err, req.Atomic, "update")
+}
+
+// BulkDeleteGuests deletes the selected guests of a wedding
+func (h *GuestHandler) BulkDeleteGuests(c *gin.Context) {
+	weddingID, userID, ok := h.bulkTarget(c)
+	if !ok {
+		return
+	}
+
+	var req BulkDeleteGuestsRequest
+	if !bindBulkRequest(c, &req) {
+		return
+	}
+	selection, ok := parseGuestSelection(c, req.BulkGuestSelection)
+	if !ok {
+		return
+	}
+
+	result, err := h.guestService.BulkDeleteGuests(c.Request.Context(), weddingID, userID, selection, req.Atomic)
+	h.writeBulkResult(c, result, err, req.Atomic, "delete")
+}
+
+// bulkTarget reads the wedding and user of a bulk operation
+func (h *GuestHandler) bulkTarget(c *gin.Context) (models.ID, models.ID, bool) {
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+	return weddingID, userID, true
+}
+
+// bindBulkRequest binds and validates the body of a bulk operation
+func bindBulkRequest(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return false
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		if validationErrors, ok := err.(*utils.ValidationError); ok {
+			errors := make(map[string]string)
+			for i, errorMsg := range validationErrors.Errors {
+				errors["field_"+strconv.Itoa(i+1)] = errorMsg
+			}
+			utils.ValidationErrorResponse(c, errors)
+			return false
+		}
+		utils.ErrorResponse(c, http.StatusBadRequest, "Validation failed")
+		return false
+	}
+	return true
+}
+
+// parseGuestSelection reads the guests a bulk operation selects, by ID or by import batch
+func parseGuestSelection(c *gin.Context, req BulkGuestSelection) (services.GuestSelection, bool) {
+	if (len(req.GuestIDs) == 0) == (req.ImportBatchID == "") {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Provide either guest_ids or import_batch_id")
+		return services.GuestSelection{}, false
+	}
+
+	selection := services.GuestSelection{ImportBatchID: req.ImportBatchID}
+	for _, raw := range req.GuestIDs {
+		id, err := models.ParseID(raw)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid guest ID: "+raw)
+			return services.GuestSelection{}, false
+		}
+		selection.IDs = append(selection.IDs, id)
+	}
+	return selection, true
+}
+
+// writeBulkResult answers a bulk operation. An atomic operation that changed
+// nothing because some guests failed is answered with 422 and the failures.
+func (h *GuestHandler) writeBulkResult(c *gin.Context, result *models.GuestBulkResult, err error, atomic bool, action string) {
+	switch {
+	case err == nil:
+	case errors.Is(err, repository.ErrNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+		return
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to manage guests of this wedding")
+		return
+	case errors.Is(err, services.ErrEmptyGuestSelection):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to "+action+" guests")
+		return
+	}
+
+	if atomic && len(result.Failed) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, utils.APIResponse{
+			Success: false,
+			Error:   "No guests were changed because some of them failed",
+			Data:    result,
+		})
+		return
+	}
+	utils.Response(c, http.StatusOK, result)
+}
+
 // Helper methods
 
+// cleanTags trims the tags, dropping those left empty
+func cleanTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	cleaned := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			cleaned = append(cleaned, tag)
+		}
+	}
+	return cleaned
+}
+
 func (h *GuestHandler) convertToGuestResponse(guest *models.Guest) *GuestResponse {
 	return &GuestResponse{
 		ID:               guest.ID,
@@ -525,10 +918,13 @@ func (h *GuestHandler) convertToGuestResponse(guest *models.Guest) *GuestRespons
 		MaxPlusOnes:      guest.MaxPlusOnes,
 		RSVPStatus:       guest.RSVPStatus,
 		RSVPID:           guest.RSVPID,
+		Companions:       guest.Companions,
 		DietaryNotes:     guest.DietaryNotes,
 		VIP:              guest.VIP,
 		Notes:            guest.Notes,
+		Tags:             guest.Tags,
 		ImportBatchID:    guest.ImportBatchID,
+		CheckIn:          guest.CheckIn,
 		CreatedBy:        guest.CreatedBy,
 		CreatedAt:        primitive.NewDateTimeFromTime(guest.CreatedAt),
 		UpdatedAt:        primitive.NewDateTimeFromTime(guest.UpdatedAt),
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// GuestContactImporter imports guests from address books: vCard files and
+// the Google account the owner connects
+type GuestContactImporter interface {
+	ImportGuestsFromVCard(ctx context.Context, weddingID, userID models.ID, vcf io.Reader) (*models.GuestImportResult, error)
+	ConnectGoogleContacts(ctx context.Context, weddingID, userID models.ID, code, redirectURI string) ([]*models.GuestContact, error)
+	ListGoogleContacts(ctx context.Context, weddingID, userID models.ID) ([]*models.GuestContact, error)
+	ImportGoogleContacts(ctx context.Context, weddingID, userID models.ID, resourceNames []string) (*models.GuestImportResult, error)
+	DisconnectGoogleContacts(ctx context.Context, userID models.ID) error
+}
+
+// GuestContactsHandler serves guest imports from vCard files and Google Contacts
+type GuestContactsHandler struct {
+	contacts GuestContactImporter
+}
+
+// NewGuestContactsHandler creates a new guest contacts handler
+func NewGuestContactsHandler(contacts GuestContactImporter) *GuestContactsHandler {
+	return &GuestContactsHandler{contacts: contacts}
+}
+
+// ConnectGoogleContactsRequest carries the authorization code of Google's
+// consent screen for the contacts.readonly scope
+type ConnectGoogleContactsRequest struct {
+	Code        string `json:"code" validate:"required"`
+	RedirectURI string `json:"redirect_uri" validate:"required,url"`
+}
+
+// ImportGoogleContactsRequest selects the Google contacts to import
+type ImportGoogleContactsRequest struct {
+	ResourceNames []string `json:"resource_names" validate:"required,min=1,max=1000,dive,required"`
+}
+
+// ImportVCard godoc
+// @Summary Import guests from a vCard file
+// @Description Import the contacts of a .vcf file as guests of one import batch. Contacts with the email or phone of an existing guest are skipped as duplicates.
+// @Tags Guests
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param file formData file true "vCard file"
+// @Success 200 {object} models.GuestImportResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/guests/import/vcard [post]
+func (h *GuestContactsHandler) ImportVCard(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "No file provided")
+		return
+	}
+	defer file.Close()
+
+	result, err := h.contacts.ImportGuestsFromVCard(c.Request.Context(), weddingID, userID, file)
+	if err != nil {
+		h.handleError(c, err, "Failed to import guests")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, result)
+}
+
+// ConnectGoogleContacts godoc
+// @Summary Connect Google Contacts
+// @Description Redeem an authorization code for the contacts.readonly scope and list the account's contacts, each with the guest it duplicates by email or phone. The connection lasts until Google's access token expires.
+// @Tags Guests
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body ConnectGoogleContactsRequest true "Authorization code"
+// @Success 200 {array} models.GuestContact
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /weddings/{id}/guests/import/google/connect [post]
+func (h *GuestContactsHandler) ConnectGoogleContacts(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	var req ConnectGoogleContactsRequest
+	if !bindGuestContactsRequest(c, &req) {
+		return
+	}
+
+	contacts, err := h.contacts.ConnectGoogleContacts(c.Request.Context(), weddingID, userID, req.Code, req.RedirectURI)
+	if err != nil {
+		h.handleError(c, err, "Failed to connect Google Contacts")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, nonNilContacts(contacts))
+}
+
+// ListGoogleContacts godoc
+// @Summary List Google contacts
+// @Description List the contacts of the connected Google account, each with the guest it duplicates by email or phone
+// @Tags Guests
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {array} models.GuestContact
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /weddings/{id}/guests/import/google/contacts [get]
+func (h *GuestContactsHandler) ListGoogleContacts(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	contacts, err := h.contacts.ListGoogleContacts(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list Google contacts")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, nonNilContacts(contacts))
+}
+
+// ImportGoogleContacts godoc
+// @Summary Import Google contacts
+// @Description Import the selected contacts of the connected Google account as guests of one import batch, skipping duplicates of existing guests
+// @Tags Guests
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body ImportGoogleContactsRequest true "Contacts to import"
+// @Success 200 {object} models.GuestImportResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /weddings/{id}/guests/import/google [post]
+func (h *GuestContactsHandler) ImportGoogleContacts(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	var req ImportGoogleContactsRequest
+	if !bindGuestContactsRequest(c, &req) {
+		return
+	}
+
+	result, err := h.contacts.ImportGoogleContacts(c.Request.Context(), weddingID, userID, req.ResourceNames)
+	if err != nil {
+		h.handleError(c, err, "Failed to import Google contacts")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, result)
+}
+
+// DisconnectGoogleContacts godoc
+// @Summary Disconnect Google Contacts
+// @Description Forget the access token of the connected Google account
+// @Tags Guests
+// @Param id path string true "Wedding ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /weddings/{id}/guests/import/google/connect [delete]
+func (h *GuestContactsHandler) DisconnectGoogleContacts(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	if err := h.contacts.DisconnectGoogleContacts(c.Request.Context(), userID); err != nil {
+		h.handleError(c, err, "Failed to disconnect Google Contacts")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *GuestContactsHandler) parseWeddingRequest(c *gin.Context) (models.ID, models.ID, bool) {
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+
+	return weddingID, userID, true
+}
+
+func bindGuestContactsRequest(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return false
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	return true
+}
+
+func nonNilContacts(contacts []*models.GuestContact) []*models.GuestContact {
+	if contacts == nil {
+		return []*models.GuestContact{}
+	}
+	return contacts
+}
+
+func (h *GuestContactsHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to manage guests of this wedding")
+	case errors.Is(err, services.ErrPlanLimitExceeded):
+		utils.ErrorResponse(c, http.StatusPaymentRequired, err.Error())
+	case errors.Is(err, services.ErrInvalidVCard):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrOAuthExchangeFailed):
+		utils.ErrorResponse(c, http.StatusBadRequest, "Google rejected the authorization")
+	case errors.Is(err, services.ErrGoogleContactsNotConnected):
+		utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrGoogleContactsUnavailable):
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockGuestContactImporter is a mock implementation of GuestContactImporter
+type MockGuestContactImporter struct {
+	mock.Mock
+}
+
+func (m *MockGuestContactImporter) ImportGuestsFromVCard(ctx context.Context, weddingID, userID models.ID, vcf io.Reader) (*models.GuestImportResult, error) {
+	data, _ := io.ReadAll(vcf)
+	args := m.Called(ctx, weddingID, userID, string(data))
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GuestImportResult), args.Error(1)
+}
+
+func (m *MockGuestContactImporter) ConnectGoogleContacts(ctx context.Context, weddingID, userID models.ID, code, redirectURI string) ([]*models.GuestContact, error) {
+	args := m.Called(ctx, weddingID, userID, code, redirectURI)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.GuestContact), args.Error(1)
+}
+
+func (m *MockGuestContactImporter) ListGoogleContacts(ctx context.Context, weddingID, userID models.ID) ([]*models.GuestContact, error) {
+	args := m.Called(ctx, weddingID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.GuestContact), args.Error(1)
+}
+
+func (m *MockGuestContactImporter) ImportGoogleContacts(ctx context.Context, weddingID, userID models.ID, resourceNames []string) (*models.GuestImportResult, error) {
+	args := m.Called(ctx, weddingID, userID, resourceNames)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GuestImportResult), args.Error(1)
+}
+
+func (m *MockGuestContactImporter) DisconnectGoogleContacts(ctx context.Context, userID models.ID) error {
+	return m.Called(ctx, userID).Error(0)
+}
+
+func setupGuestContactsTestRouter(handler *GuestContactsHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	imports := router.Group("/api/v1/weddings/:wedding_id/guests/import")
+	imports.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	imports.POST("/vcard", handler.ImportVCard)
+	imports.POST("/google/connect", handler.ConnectGoogleContacts)
+	imports.DELETE("/google/connect", handler.DisconnectGoogleContacts)
+	imports.GET("/google/contacts", handler.ListGoogleContacts)
+	imports.POST("/google", handler.ImportGoogleContacts)
+
+	return router
+}
+
+func TestGuestContactsHandler_ImportVCard(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	path := "/api/v1/weddings/" + weddingID.String() + "/guests/import/vcard"
+	vcf := "BEGIN:VCARD\r\nFN:John Doe\r\nEND:VCARD\r\n"
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"imported", nil, http.StatusOK},
+		{"not a vCard", services.ErrInvalidVCard, http.StatusBadRequest},
+		{"over the plan's guest limit", services.ErrPlanLimitExceeded, http.StatusPaymentRequired},
+		{"not a manager", services.ErrUnauthorized, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contacts := new(MockGuestContactImporter)
+			if tt.err != nil {
+				contacts.On("ImportGuestsFromVCard", mock.Anything, weddingID, userID, vcf).Return(nil, tt.err)
+			} else {
+				contacts.On("ImportGuestsFromVCard", mock.Anything, weddingID, userID, vcf).
+					Return(&models.GuestImportResult{SuccessCount: 1, Errors: []string{}}, nil)
+			}
+
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			part, _ := writer.CreateFormFile("file", "contacts.vcf")
+			part.Write([]byte(vcf))
+			writer.Close()
+
+			req := httptest.NewRequest(http.MethodPost, path, body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			w := httptest.NewRecorder()
+			setupGuestContactsTestRouter(NewGuestContactsHandler(contacts), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			contacts.AssertExpectations(t)
+		})
+	}
+
+	t.Run("no file", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		w := httptest.NewRecorder()
+		setupGuestContactsTestRouter(NewGuestContactsHandler(new(MockGuestContactImporter)), userID).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGuestContactsHandler_GoogleContacts(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	base := "/api/v1/weddings/" + weddingID.String() + "/guests/import/google"
+	duplicateOf := models.NewID()
+	listed := []*models.GuestContact{{ResourceName: "people/c1", FirstName: "Ann", LastName: "Lee", DuplicateOf: &duplicateOf}}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		setup    func(*MockGuestContactImporter)
+		expected int
+	}{
+		{
+			name: "connect", method: http.MethodPost, path: base + "/connect",
+			body: `{"code":"abc","redirect_uri":"https://app.example.com/callback"}`,
+			setup: func(m *MockGuestContactImporter) {
+				m.On("ConnectGoogleContacts", mock.Anything, weddingID, userID, "abc", "https://app.example.com/callback").Return(listed, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name: "connect without a code", method: http.MethodPost, path: base + "/connect",
+			body: `{"redirect_uri":"https://app.example.com/callback"}`, setup: func(*MockGuestContactImporter) {},
+			expected: http.StatusBadRequest,
+		},
+		{
+			name: "connect with a rejected code", method: http.MethodPost, path: base + "/connect",
+			body: `{"code":"abc","redirect_uri":"https://app.example.com/callback"}`,
+			setup: func(m *MockGuestContactImporter) {
+				m.On("ConnectGoogleContacts", mock.Anything, weddingID, userID, "abc", "https://app.example.com/callback").Return(nil, services.ErrOAuthExchangeFailed)
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			name: "list without a connection", method: http.MethodGet, path: base + "/contacts",
+			setup: func(m *MockGuestContactImporter) {
+				m.On("ListGoogleContacts", mock.Anything, weddingID, userID).Return(nil, services.ErrGoogleContactsNotConnected)
+			},
+			expected: http.StatusConflict,
+		},
+		{
+			name: "list when Google is not configured", method: http.MethodGet, path: base + "/contacts",
+			setup: func(m *MockGuestContactImporter) {
+				m.On("ListGoogleContacts", mock.Anything, weddingID, userID).Return(nil, services.ErrGoogleContactsUnavailable)
+			},
+			expected: http.StatusServiceUnavailable,
+		},
+		{
+			name: "import", method: http.MethodPost, path: base,
+			body: `{"resource_names":["people/c1","people/c2"]}`,
+			setup: func(m *MockGuestContactImporter) {
+				m.On("ImportGoogleContacts", mock.Anything, weddingID, userID, []string{"people/c1", "people/c2"}).
+					Return(&models.GuestImportResult{SuccessCount: 1, DuplicateCount: 1}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name: "import nothing", method: http.MethodPost, path: base,
+			body: `{"resource_names":[]}`, setup: func(*MockGuestContactImporter) {},
+			expected: http.StatusBadRequest,
+		},
+		{
+			name: "disconnect", method: http.MethodDelete, path: base + "/connect",
+			setup: func(m *MockGuestContactImporter) {
+				m.On("DisconnectGoogleContacts", mock.Anything, userID).Return(nil)
+			},
+			expected: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contacts := new(MockGuestContactImporter)
+			tt.setup(contacts)
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupGuestContactsTestRouter(NewGuestContactsHandler(contacts), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			contacts.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// GuestGroupManager manages the households guests of a wedding are grouped into
+type GuestGroupManager interface {
+	CreateGroup(ctx context.Context, weddingID, userID models.ID, req services.GuestGroupRequest) (*models.GuestGroupDetail, error)
+	ListGroups(ctx context.Context, weddingID, userID models.ID) ([]*models.GuestGroupDetail, error)
+	GetGroup(ctx context.Context, weddingID, groupID, userID models.ID) (*models.GuestGroupDetail, error)
+	UpdateGroup(ctx context.Context, weddingID, groupID, userID models.ID, req services.GuestGroupRequest) (*models.GuestGroupDetail, error)
+	DeleteGroup(ctx context.Context, weddingID, groupID, userID models.ID) error
+}
+
+// GuestGroupHandler serves the households of a wedding's guest list
+type GuestGroupHandler struct {
+	groups GuestGroupManager
+}
+
+// NewGuestGroupHandler creates a new guest group handler
+func NewGuestGroupHandler(groups GuestGroupManager) *GuestGroupHandler {
+	return &GuestGroupHandler{groups: groups}
+}
+
+// CreateGroup godoc
+// @Summary Create a guest group
+// @Description Group guests of a wedding into a household that gets one invitation and answers one RSVP for all its members. A guest belongs to at most one group.
+// @Tags Guests
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param group body services.GuestGroupRequest true "Guest group"
+// @Success 201 {object} models.GuestGroupDetail
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/guest-groups [post]
+func (h *GuestGroupHandler) CreateGroup(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindGuestGroup(c)
+	if !ok {
+		return
+	}
+
+	group, err := h.groups.CreateGroup(c.Request.Context(), weddingID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create guest group")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, group)
+}
+
+// ListGroups godoc
+// @Summary List guest groups
+// @Description Get the households of a wedding with their members
+// @Tags Guests
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {array} models.GuestGroupDetail
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/guest-groups [get]
+func (h *GuestGroupHandler) ListGroups(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	groups, err := h.groups.ListGroups(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get guest groups")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, groups)
+}
+
+// GetGroup godoc
+// @Summary Get a guest group
+// @Description Get a household with its members
+// @Tags Guests
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param group_id path string true "Guest group ID"
+// @Success 200 {object} models.GuestGroupDetail
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/guest-groups/{group_id} [get]
+func (h *GuestGroupHandler) GetGroup(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+	groupID, ok := parseGuestGroupID(c)
+	if !ok {
+		return
+	}
+
+	group, err := h.groups.GetGroup(c.Request.Context(), weddingID, groupID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get guest group")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, group)
+}
+
+// UpdateGroup godoc
+// @Summary Update a guest group
+// @Description Replace the details and members of a household; guests left out are removed from it
+// @Tags Guests
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param group_id path string true "Guest group ID"
+// @Param group body services.GuestGroupRequest true "Guest group"
+// @Success 200 {object} models.GuestGroupDetail
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/guest-groups/{group_id} [put]
+func (h *GuestGroupHandler) UpdateGroup(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+	groupID, ok := parseGuestGroupID(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindGuestGroup(c)
+	if !ok {
+		return
+	}
+
+	group, err := h.groups.UpdateGroup(c.Request.Context(), weddingID, groupID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update guest group")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, group)
+}
+
+// DeleteGroup godoc
+// @Summary Delete a guest group
+// @Description Remove a household; its members stay on the guest list
+// @Tags Guests
+// @Param id path string true "Wedding ID"
+// @Param group_id path string true "Guest group ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/guest-groups/{group_id} [delete]
+func (h *GuestGroupHandler) DeleteGroup(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+	groupID, ok := parseGuestGroupID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.groups.DeleteGroup(c.Request.Context(), weddingID, groupID, userID); err != nil {
+		h.handleError(c, err, "Failed to delete guest group")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *GuestGroupHandler) parseWeddingRequest(c *gin.Context) (models.ID, models.ID, bool) {
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+
+	return weddingID, userID, true
+}
+
+func parseGuestGroupID(c *gin.Context) (models.ID, bool) {
+	groupID, err := models.ParseID(c.Param("group_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid guest group ID")
+		return models.NilID, false
+	}
+	return groupID, true
+}
+
+// bindGuestGroup reads and validates a guest group from the request body
+func bindGuestGroup(c *gin.Context) (services.GuestGroupRequest, bool) {
+	var req services.GuestGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return req, false
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return req, false
+	}
+
+	return req, true
+}
+
+func (h *GuestGroupHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrGuestGroupNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Guest group not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to manage guests of this wedding")
+	case errors.Is(err, services.ErrInvalidGuestGroup):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrGuestAlreadyGrouped):
+		utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockGuestGroupManager is a mock implementation of GuestGroupManager
+type MockGuestGroupManager struct {
+	mock.Mock
+}
+
+func (m *MockGuestGroupManager) CreateGroup(ctx context.Context, weddingID, userID models.ID, req services.GuestGroupRequest) (*models.GuestGroupDetail, error) {
+	args := m.Called(ctx, weddingID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GuestGroupDetail), args.Error(1)
+}
+
+func (m *MockGuestGroupManager) ListGroups(ctx context.Context, weddingID, userID models.ID) ([]*models.GuestGroupDetail, error) {
+	args := m.Called(ctx, weddingID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.GuestGroupDetail), args.Error(1)
+}
+
+func (m *MockGuestGroupManager) GetGroup(ctx context.Context, weddingID, groupID, userID models.ID) (*models.GuestGroupDetail, error) {
+	args := m.Called(ctx, weddingID, groupID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GuestGroupDetail), args.Error(1)
+}
+
+func (m *MockGuestGroupManager) UpdateGroup(ctx context.Context, weddingID, groupID, userID models.ID, req services.GuestGroupRequest) (*models.GuestGroupDetail, error) {
+	args := m.Called(ctx, weddingID, groupID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GuestGroupDetail), args.Error(1)
+}
+
+func (m *MockGuestGroupManager) DeleteGroup(ctx context.Context, weddingID, groupID, userID models.ID) error {
+	args := m.Called(ctx, weddingID, groupID, userID)
+	return args.Error(0)
+}
+
+func setupGuestGroupTestRouter(handler *GuestGroupHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	groups := router.Group("/api/v1/weddings/:wedding_id/guest-groups")
+	groups.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	groups.POST("", handler.CreateGroup)
+	groups.GET("", handler.ListGroups)
+	groups.GET("/:group_id", handler.GetGroup)
+	groups.PUT("/:group_id", handler.UpdateGroup)
+	groups.DELETE("/:group_id", handler.DeleteGroup)
+
+	return router
+}
+
+func TestGuestGroupHandler_CreateGroup(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	guestID := models.NewID()
+	path := "/api/v1/weddings/" + weddingID.String() + "/guest-groups"
+	body := `{"name":"The Does","guest_ids":["` + guestID.String() + `"],"table":"7"}`
+	valid := services.GuestGroupRequest{Name: "The Does", GuestIDs: []models.ID{guestID}, Table: "7"}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"created", nil, http.StatusCreated},
+		{"not a manager", services.ErrUnauthorized, http.StatusForbidden},
+		{"unknown guest", services.ErrInvalidGuestGroup, http.StatusBadRequest},
+		{"guest in another group", services.ErrGuestAlreadyGrouped, http.StatusConflict},
+		{"wedding not found", services.ErrWeddingNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := new(MockGuestGroupManager)
+			if tt.err != nil {
+				groups.On("CreateGroup", mock.Anything, weddingID, userID, valid).Return(nil, tt.err)
+			} else {
+				group := &models.GuestGroup{ID: models.NewID(), WeddingID: weddingID, Name: "The Does", PrimaryGuestID: guestID}
+				groups.On("CreateGroup", mock.Anything, weddingID, userID, valid).
+					Return(&models.GuestGroupDetail{GuestGroup: group, Members: []*models.Guest{{ID: guestID}}}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupGuestGroupTestRouter(NewGuestGroupHandler(groups), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			groups.AssertExpectations(t)
+		})
+	}
+
+	invalid := map[string]string{
+		"no guests":        `{"name":"The Does","guest_ids":[]}`,
+		"no name":          `{"guest_ids":["` + guestID.String() + `"]}`,
+		"invalid guest ID": `{"name":"The Does","guest_ids":["bad"]}`,
+	}
+	for name, body := range invalid {
+		t.Run(name, func(t *testing.T) {
+			groups := new(MockGuestGroupManager)
+			req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupGuestGroupTestRouter(NewGuestGroupHandler(groups), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			groups.AssertNotCalled(t, "CreateGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGuestGroupHandler_DeleteGroup(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	groupID := models.NewID()
+	path := "/api/v1/weddings/" + weddingID.String() + "/guest-groups/" + groupID.String()
+
+	t.Run("deleted", func(t *testing.T) {
+		groups := new(MockGuestGroupManager)
+		groups.On("DeleteGroup", mock.Anything, weddingID, groupID, userID).Return(nil)
+
+		w := httptest.NewRecorder()
+		setupGuestGroupTestRouter(NewGuestGroupHandler(groups), userID).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, path, nil))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		groups.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		groups := new(MockGuestGroupManager)
+		groups.On("DeleteGroup", mock.Anything, weddingID, groupID, userID).Return(services.ErrGuestGroupNotFound)
+
+		w := httptest.NewRecorder()
+		setupGuestGroupTestRouter(NewGuestGroupHandler(groups), userID).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, path, nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("invalid group ID", func(t *testing.T) {
+		groups := new(MockGuestGroupManager)
+
+		w := httptest.NewRecorder()
+		setupGuestGroupTestRouter(NewGuestGroupHandler(groups), userID).
+			ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v1/weddings/"+weddingID.String()+"/guest-groups/bad", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		groups.AssertNotCalled(t, "DeleteGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
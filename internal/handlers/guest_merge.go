@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// GuestDeduplicator finds guests entered more than once and merges them
+type GuestDeduplicator interface {
+	FindDuplicates(ctx context.Context, weddingID, userID models.ID) ([]*models.GuestDuplicate, error)
+	MergeGuests(ctx context.Context, weddingID, userID models.ID, req services.GuestMergeRequest) (*models.Guest, error)
+}
+
+// GuestMergeHandler serves duplicate detection and merging of a wedding's guests
+type GuestMergeHandler struct {
+	merges GuestDeduplicator
+}
+
+// NewGuestMergeHandler creates a new guest merge handler
+func NewGuestMergeHandler(merges GuestDeduplicator) *GuestMergeHandler {
+	return &GuestMergeHandler{merges: merges}
+}
+
+// FindDuplicates godoc
+// @Summary Find duplicate guests
+// @Description List pairs of guests that are likely the same person: they share an email or phone number, or their names are the same or differ by a typo. The older guest of each pair comes first; best matches are listed first.
+// @Tags Guests
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {array} models.GuestDuplicate
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/guests/duplicates [get]
+func (h *GuestMergeHandler) FindDuplicates(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	duplicates, err := h.merges.FindDuplicates(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to find duplicate guests")
+		return
+	}
+	if duplicates == nil {
+		duplicates = []*models.GuestDuplicate{}
+	}
+
+	utils.Response(c, http.StatusOK, duplicates)
+}
+
+// MergeGuests godoc
+// @Summary Merge two guests
+// @Description Merge the duplicate into the guest and delete it. Missing details of the guest are taken from the duplicate, notes and tags are combined, and its RSVPs, household and invitation history move to the guest.
+// @Tags Guests
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body services.GuestMergeRequest true "Guests to merge"
+// @Success 200 {object} models.Guest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/guests/merge [post]
+func (h *GuestMergeHandler) MergeGuests(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	var req services.GuestMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	guest, err := h.merges.MergeGuests(c.Request.Context(), weddingID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to merge guests")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, guest)
+}
+
+func (h *GuestMergeHandler) parseWeddingRequest(c *gin.Context) (models.ID, models.ID, bool) {
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+
+	return weddingID, userID, true
+}
+
+func (h *GuestMergeHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrGuestNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Guest not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to manage guests of this wedding")
+	case errors.Is(err, services.ErrInvalidGuestMerge):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockGuestDeduplicator is a mock implementation of GuestDeduplicator
+type MockGuestDeduplicator struct {
+	mock.Mock
+}
+
+func (m *MockGuestDeduplicator) FindDuplicates(ctx context.Context, weddingID, userID models.ID) ([]*models.GuestDuplicate, error) {
+	args := m.Called(ctx, weddingID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.GuestDuplicate), args.Error(1)
+}
+
+func (m *MockGuestDeduplicator) MergeGuests(ctx context.Context, weddingID, userID models.ID, req services.GuestMergeRequest) (*models.Guest, error) {
+	args := m.Called(ctx, weddingID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Guest), args.Error(1)
+}
+
+func setupGuestMergeTestRouter(handler *GuestMergeHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	guests := router.Group("/api/v1/weddings/:wedding_id/guests")
+	guests.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	guests.GET("/duplicates", handler.FindDuplicates)
+	guests.POST("/merge", handler.MergeGuests)
+
+	return router
+}
+
+func TestGuestMergeHandler_FindDuplicates(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	path := "/api/v1/weddings/" + weddingID.String() + "/guests/duplicates"
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"found", nil, http.StatusOK},
+		{"not a manager", services.ErrUnauthorized, http.StatusForbidden},
+		{"wedding not found", services.ErrWeddingNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merges := new(MockGuestDeduplicator)
+			if tt.err != nil {
+				merges.On("FindDuplicates", mock.Anything, weddingID, userID).Return(nil, tt.err)
+			} else {
+				merges.On("FindDuplicates", mock.Anything, weddingID, userID).Return([]*models.GuestDuplicate{{
+					Guest: &models.Guest{ID: models.NewID()}, Duplicate: &models.Guest{ID: models.NewID()},
+					Reasons: []string{models.DuplicateReasonEmail}, Score: 3,
+				}}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			w := httptest.NewRecorder()
+			setupGuestMergeTestRouter(NewGuestMergeHandler(merges), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			merges.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGuestMergeHandler_MergeGuests(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	path := "/api/v1/weddings/" + weddingID.String() + "/guests/merge"
+	keepID, duplicateID := models.NewID(), models.NewID()
+	body := `{"guest_id":"` + keepID.String() + `","duplicate_id":"` + duplicateID.String() + `"}`
+	valid := services.GuestMergeRequest{GuestID: keepID, DuplicateID: duplicateID}
+
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		expected int
+	}{
+		{"merged", body, nil, http.StatusOK},
+		{"missing duplicate", `{"guest_id":"` + keepID.String() + `"}`, nil, http.StatusBadRequest},
+		{"guests of different groups", body, services.ErrInvalidGuestMerge, http.StatusBadRequest},
+		{"guest not found", body, services.ErrGuestNotFound, http.StatusNotFound},
+		{"not a manager", body, services.ErrUnauthorized, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merges := new(MockGuestDeduplicator)
+			if tt.body == body {
+				if tt.err != nil {
+					merges.On("MergeGuests", mock.Anything, weddingID, userID, valid).Return(nil, tt.err)
+				} else {
+					merges.On("MergeGuests", mock.Anything, weddingID, userID, valid).Return(&models.Guest{ID: keepID}, nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupGuestMergeTestRouter(NewGuestMergeHandler(merges), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			merges.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// GuestQRManager renders guest QR codes and resolves the signed links they encode
+type GuestQRManager interface {
+	GuestQRCode(ctx context.Context, guestID, userID models.ID, opts services.QROptions) (*services.QRCode, error)
+	StreamQRCodes(ctx context.Context, weddingID, userID models.ID, filters repository.GuestFilters, opts services.QROptions, fn func(*services.QRCode) error) error
+	ResolveGuestToken(ctx context.Context, weddingID models.ID, token string) (*services.GuestRSVPPrefill, error)
+}
+
+// GuestQRHandler serves guest QR codes and the public side of their links
+type GuestQRHandler struct {
+	qrcodes GuestQRManager
+}
+
+// NewGuestQRHandler creates a new guest QR code handler
+func NewGuestQRHandler(qrcodes GuestQRManager) *GuestQRHandler {
+	return &GuestQRHandler{qrcodes: qrcodes}
+}
+
+// GetGuestQRCode godoc
+// @Summary Get a guest's QR code
+// @Description Render a QR code of the guest's signed RSVP link, for printed invitations and check-in
+// @Tags Guests
+// @Produce png
+// @Produce image/svg+xml
+// @Param id path string true "Guest ID"
+// @Param format query string false "png (default) or svg"
+// @Param size query int false "Width and height in pixels, 64 to 2048 (default 256)"
+// @Success 200 {file} binary
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /guests/{id}/qrcode [get]
+func (h *GuestQRHandler) GetGuestQRCode(c *gin.Context) {
+	guestID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid guest ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	opts, ok := parseQROptions(c)
+	if !ok {
+		return
+	}
+
+	code, err := h.qrcodes.GuestQRCode(c.Request.Context(), guestID, userID, opts)
+	if err != nil {
+		h.handleError(c, err, "Failed to generate QR code")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, code.Filename))
+	c.Data(http.StatusOK, code.ContentType, code.Data)
+}
+
+// ExportQRCodes godoc
+// @Summary Download guest QR codes
+// @Description Stream a zip archive with the QR code of every guest of the wedding, filtered like the guest list
+// @Tags Guests
+// @Produce application/zip
+// @Param id path string true "Wedding ID"
+// @Param format query string false "png (default) or svg"
+// @Param size query int false "Width and height in pixels, 64 to 2048 (default 256)"
+// @Success 200 {file} binary
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /weddings/{id}/guests/qrcodes [get]
+func (h *GuestQRHandler) ExportQRCodes(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	filters, ok := parseGuestFilters(c)
+	if !ok {
+		return
+	}
+
+	opts, ok := parseQROptions(c)
+	if !ok {
+		return
+	}
+
+	// The archive is streamed, so the response starts with the first code. Errors
+	// before that (unknown wedding, not authorized) are still regular error responses.
+	var archive *zip.Writer
+	start := func() {
+		c.Header("Content-Type", "application/zip")
+		c.Header("Content-Disposition", `attachment; filename="guest-qrcodes.zip"`)
+		c.Status(http.StatusOK)
+		archive = zip.NewWriter(c.Writer)
+	}
+
+	err = h.qrcodes.StreamQRCodes(c.Request.Context(), weddingID, userID, filters, opts, func(code *services.QRCode) error {
+		if err := c.Request.Context().Err(); err != nil {
+			return err
+		}
+		if archive == nil {
+			start()
+		}
+		// Images are already compressed, so they are stored as is
+		w, err := archive.CreateHeader(&zip.FileHeader{Name: code.Filename, Method: zip.Store})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(code.Data)
+		return err
+	})
+	if err != nil {
+		if archive == nil {
+			h.handleError(c, err, "Failed to generate QR codes")
+			return
+		}
+		// Headers are sent; the truncated archive tells the client it failed
+		_ = c.Error(err)
+		return
+	}
+
+	if archive == nil {
+		start()
+	}
+	if err := archive.Close(); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// ResolveGuestLink godoc
+// @Summary Resolve a guest link
+// @Description Return the guest a signed RSVP link (the t parameter of QR code links) belongs to, to pre-fill the RSVP form
+// @Tags RSVP
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param token query string true "Signed guest token"
+// @Success 200 {object} services.GuestRSVPPrefill
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /public/weddings/{id}/rsvp/guest [get]
+func (h *GuestQRHandler) ResolveGuestLink(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	token := c.Query("token")
+	if token == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Token is required")
+		return
+	}
+
+	prefill, err := h.qrcodes.ResolveGuestToken(c.Request.Context(), weddingID, token)
+	if err != nil {
+		h.handleError(c, err, "Failed to resolve guest link")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.APIResponse{
+		Success: true,
+		Message: "Guest resolved",
+		Data:    prefill,
+	})
+}
+
+// parseQROptions reads ?format= and ?size=, answering invalid input with 400
+func parseQROptions(c *gin.Context) (services.QROptions, bool) {
+	opts := services.QROptions{Format: c.Query("format")}
+	if raw := c.Query("size"); raw != "" {
+		size, err := strconv.Atoi(raw)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid size")
+			return opts, false
+		}
+		opts.Size = size
+	}
+	return opts, true
+}
+
+func (h *GuestQRHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidQROptions):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrGuestNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Guest not found")
+	case errors.Is(err, services.ErrInvalidGuestLink):
+		utils.ErrorResponse(c, http.StatusNotFound, "Invalid guest link")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to manage guests of this wedding")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockGuestQRManager returns fixed codes and records the last request
+type MockGuestQRManager struct {
+	codes       []*services.QRCode
+	err         error
+	lastOpts    services.QROptions
+	lastFilters repository.GuestFilters
+	lastToken   string
+}
+
+func (m *MockGuestQRManager) GuestQRCode(ctx context.Context, guestID, userID models.ID, opts services.QROptions) (*services.QRCode, error) {
+	m.lastOpts = opts
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.codes[0], nil
+}
+
+func (m *MockGuestQRManager) StreamQRCodes(ctx context.Context, weddingID, userID models.ID, filters repository.GuestFilters, opts services.QROptions, fn func(*services.QRCode) error) error {
+	m.lastOpts, m.lastFilters = opts, filters
+	if m.err != nil {
+		return m.err
+	}
+	for _, code := range m.codes {
+		if err := fn(code); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockGuestQRManager) ResolveGuestToken(ctx context.Context, weddingID models.ID, token string) (*services.GuestRSVPPrefill, error) {
+	m.lastToken = token
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &services.GuestRSVPPrefill{WeddingID: weddingID, FirstName: "Carol"}, nil
+}
+
+func newTestQRCode(name string) *services.QRCode {
+	return &services.QRCode{
+		Guest:       &models.Guest{ID: models.NewID()},
+		Data:        []byte("image " + name),
+		ContentType: "image/png",
+		Filename:    name + ".png",
+	}
+}
+
+func setupGuestQRRouter(manager GuestQRManager) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewGuestQRHandler(manager)
+	router.GET("/public/weddings/:id/rsvp/guest", handler.ResolveGuestLink)
+
+	protected := router.Group("", func(c *gin.Context) {
+		c.Set("user_id", models.NewID().String())
+		c.Next()
+	})
+	protected.GET("/guests/:id/qrcode", handler.GetGuestQRCode)
+	protected.GET("/weddings/:wedding_id/guests/qrcodes", handler.ExportQRCodes)
+	return router
+}
+
+func TestGuestQRHandler_GetGuestQRCode(t *testing.T) {
+	path := "/guests/" + models.NewID().String() + "/qrcode"
+
+	t.Run("Serves the image", func(t *testing.T) {
+		manager := &MockGuestQRManager{codes: []*services.QRCode{newTestQRCode("carol")}}
+		router := setupGuestQRRouter(manager)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+"?format=svg&size=512", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "carol.png")
+		assert.Equal(t, "image carol", w.Body.String())
+		assert.Equal(t, services.QROptions{Format: "svg", Size: 512}, manager.lastOpts)
+	})
+
+	t.Run("Invalid size", func(t *testing.T) {
+		router := setupGuestQRRouter(&MockGuestQRManager{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+"?size=big", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid options", services.ErrInvalidQROptions, http.StatusBadRequest},
+		{"guest not found", services.ErrGuestNotFound, http.StatusNotFound},
+		{"not authorized", services.ErrUnauthorized, http.StatusForbidden},
+		{"internal error", errors.New("boom"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupGuestQRRouter(&MockGuestQRManager{err: tt.err})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestGuestQRHandler_ExportQRCodes(t *testing.T) {
+	path := "/weddings/" + models.NewID().String() + "/guests/qrcodes"
+
+	t.Run("Zips every code", func(t *testing.T) {
+		manager := &MockGuestQRManager{codes: []*services.QRCode{newTestQRCode("carol"), newTestQRCode("dan")}}
+		router := setupGuestQRRouter(manager)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+"?rsvp_status=attending", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+		assert.Equal(t, "attending", manager.lastFilters.RSVPStatus)
+
+		archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		require.NoError(t, err)
+		require.Len(t, archive.File, 2)
+		assert.Equal(t, "carol.png", archive.File[0].Name)
+		rc, err := archive.File[1].Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		assert.Equal(t, "image dan", string(content))
+	})
+
+	t.Run("Empty wedding", func(t *testing.T) {
+		router := setupGuestQRRouter(&MockGuestQRManager{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		require.NoError(t, err)
+		assert.Empty(t, archive.File)
+	})
+
+	t.Run("Not authorized", func(t *testing.T) {
+		router := setupGuestQRRouter(&MockGuestQRManager{err: services.ErrUnauthorized})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Invalid filter", func(t *testing.T) {
+		router := setupGuestQRRouter(&MockGuestQRManager{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+"?vip=maybe", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGuestQRHandler_ResolveGuestLink(t *testing.T) {
+	path := "/public/weddings/" + models.NewID().String() + "/rsvp/guest"
+
+	t.Run("Returns the prefill", func(t *testing.T) {
+		manager := &MockGuestQRManager{}
+		router := setupGuestQRRouter(manager)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+"?token=abc", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "abc", manager.lastToken)
+		assert.Contains(t, w.Body.String(), `"first_name":"Carol"`)
+	})
+
+	t.Run("Missing token", func(t *testing.T) {
+		router := setupGuestQRRouter(&MockGuestQRManager{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		router := setupGuestQRRouter(&MockGuestQRManager{err: services.ErrInvalidGuestLink})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+"?token=abc", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
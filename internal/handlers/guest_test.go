@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -8,13 +9,14 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
 	"wedding-invitation-backend/internal/services"
@@ -22,7 +24,7 @@ import (
 
 // MockGuestService for testing
 type MockGuestService struct {
-	guests          map[primitive.ObjectID]*models.Guest
+	guests          map[models.ID]*models.Guest
 	createError     error
 	getError        error
 	updateError     error
@@ -30,20 +32,24 @@ type MockGuestService struct {
 	listError       error
 	bulkCreateError error
 	importError     error
+	bulkSelection   services.GuestSelection
+	bulkPatch       models.GuestPatch
+	bulkResult      *models.GuestBulkResult
+	bulkError       error
 }
 
 func NewMockGuestService() *MockGuestService {
 	return &MockGuestService{
-		guests: make(map[primitive.ObjectID]*models.Guest),
+		guests: make(map[models.ID]*models.Guest),
 	}
 }
 
-func (m *MockGuestService) CreateGuest(ctx context.Context, weddingID, userID primitive.ObjectID, guest *models.Guest) error {
+func (m *MockGuestService) CreateGuest(ctx context.Context, weddingID, userID models.ID, guest *models.Guest) error {
 	if m.createError != nil {
 		return m.createError
 	}
 
-	id := primitive.NewObjectID()
+	id := models.NewID()
 	guest.ID = id
 	guest.WeddingID = weddingID
 	guest.CreatedBy = userID
@@ -53,7 +59,7 @@ func (m *MockGuestService) CreateGuest(ctx context.Context, weddingID, userID pr
 	return nil
 }
 
-func (m *MockGuestService) GetGuestByID(ctx context.Context, guestID, userID primitive.ObjectID) (*models.Guest, error) {
+func (m *MockGuestService) GetGuestByID(ctx context.Context, guestID, userID models.ID) (*models.Guest, error) {
 	if m.getError != nil {
 		return nil, m.getError
 	}
@@ -71,7 +77,7 @@ func (m *MockGuestService) GetGuestByID(ctx context.Context, guestID, userID pri
 	return guest, nil
 }
 
-func (m *MockGuestService) ListGuests(ctx context.Context, weddingID, userID primitive.ObjectID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error) {
+func (m *MockGuestService) ListGuests(ctx context.Context, weddingID, userID models.ID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error) {
 	if m.listError != nil {
 		return nil, 0, m.listError
 	}
@@ -89,7 +95,42 @@ func (m *MockGuestService) ListGuests(ctx context.Context, weddingID, userID pri
 	return guests, int64(len(guests)), nil
 }
 
-func (m *MockGuestService) UpdateGuest(ctx context.Context, guestID, userID primitive.ObjectID, guest *models.Guest) error {
+func (m *MockGuestService) SearchGuests(ctx context.Context, weddingID, userID models.ID, query string, limit int) ([]*models.Guest, error) {
+	if m.listError != nil {
+		return nil, m.listError
+	}
+
+	var guests []*models.Guest
+	for _, guest := range m.guests {
+		if guest.WeddingID == weddingID && guest.CreatedBy == userID && strings.Contains(strings.ToLower(guest.FirstName+" "+guest.LastName), strings.ToLower(query)) {
+			guests = append(guests, guest)
+		}
+	}
+	if len(guests) > limit {
+		guests = guests[:limit]
+	}
+	return guests, nil
+}
+
+func (m *MockGuestService) StreamGuests(ctx context.Context, weddingID, userID models.ID, filters repository.GuestFilters, fn func(*models.Guest) error) error {
+	if m.listError != nil {
+		return m.listError
+	}
+
+	for _, guest := range m.guests {
+		if filters.RSVPStatus != "" && guest.RSVPStatus != filters.RSVPStatus {
+			continue
+		}
+		if guest.WeddingID == weddingID && guest.CreatedBy == userID {
+			if err := fn(guest); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MockGuestService) UpdateGuest(ctx context.Context, guestID, userID models.ID, guest *models.Guest) error {
 	if m.updateError != nil {
 		return m.updateError
 	}
@@ -112,7 +153,7 @@ func (m *MockGuestService) UpdateGuest(ctx context.Context, guestID, userID prim
 	return nil
 }
 
-func (m *MockGuestService) DeleteGuest(ctx context.Context, guestID, userID primitive.ObjectID) error {
+func (m *MockGuestService) DeleteGuest(ctx context.Context, guestID, userID models.ID) error {
 	if m.deleteError != nil {
 		return m.deleteError
 	}
@@ -130,13 +171,13 @@ func (m *MockGuestService) DeleteGuest(ctx context.Context, guestID, userID prim
 	return nil
 }
 
-func (m *MockGuestService) CreateManyGuests(ctx context.Context, weddingID, userID primitive.ObjectID, guests []*models.Guest) error {
+func (m *MockGuestService) CreateManyGuests(ctx context.Context, weddingID, userID models.ID, guests []*models.Guest) error {
 	if m.bulkCreateError != nil {
 		return m.bulkCreateError
 	}
 
 	for _, guest := range guests {
-		id := primitive.NewObjectID()
+		id := models.NewID()
 		guest.ID = id
 		guest.WeddingID = weddingID
 		guest.CreatedBy = userID
@@ -148,7 +189,7 @@ func (m *MockGuestService) CreateManyGuests(ctx context.Context, weddingID, user
 	return nil
 }
 
-func (m *MockGuestService) ImportGuestsFromCSV(ctx context.Context, weddingID, userID primitive.ObjectID, csvData io.Reader) (*models.GuestImportResult, error) {
+func (m *MockGuestService) ImportGuestsFromCSV(ctx context.Context, weddingID, userID models.ID, csvData io.Reader) (*models.GuestImportResult, error) {
 	if m.importError != nil {
 		return nil, m.importError
 	}
@@ -161,7 +202,7 @@ func (m *MockGuestService) ImportGuestsFromCSV(ctx context.Context, weddingID, u
 	}, nil
 }
 
-func (m *MockGuestService) GetImportBatch(ctx context.Context, weddingID, userID primitive.ObjectID, batchID string) ([]*models.Guest, error) {
+func (m *MockGuestService) GetImportBatch(ctx context.Context, weddingID, userID models.ID, batchID string) ([]*models.Guest, error) {
 	var guests []*models.Guest
 	for _, guest := range m.guests {
 		if guest.ImportBatchID == batchID && guest.CreatedBy == userID {
@@ -171,6 +212,26 @@ func (m *MockGuestService) GetImportBatch(ctx context.Context, weddingID, userID
 	return guests, nil
 }
 
+func (m *MockGuestService) BulkUpdateGuests(ctx context.Context, weddingID, userID models.ID, selection services.GuestSelection, patch models.GuestPatch, atomic bool) (*models.GuestBulkResult, error) {
+	m.bulkSelection = selection
+	m.bulkPatch = patch
+	return m.bulkResult, m.bulkError
+}
+
+func (m *MockGuestService) BulkDeleteGuests(ctx context.Context, weddingID, userID models.ID, selection services.GuestSelection, atomic bool) (*models.GuestBulkResult, error) {
+	m.bulkSelection = selection
+	return m.bulkResult, m.bulkError
+}
+
+// MockAuditLogger is a mock implementation of AuditLogger
+type MockAuditLogger struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogger) Log(ctx context.Context, userID, action string, metadata map[string]interface{}) {
+	m.Called(ctx, userID, action, metadata)
+}
+
 func setupGuestTestRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	return gin.New()
@@ -181,12 +242,12 @@ func TestGuestHandler_CreateGuest(t *testing.T) {
 	handler := NewGuestHandler(mockService)
 	router := setupGuestTestRouter()
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Mock user context
 	router.Use(func(c *gin.Context) {
-		c.Set("user_id", userID.Hex())
+		c.Set("user_id", userID.String())
 		c.Next()
 	})
 
@@ -209,7 +270,7 @@ func TestGuestHandler_CreateGuest(t *testing.T) {
 
 	reqBody, _ := json.Marshal(req)
 	w := httptest.NewRecorder()
-	reqHTTP, _ := http.NewRequest("POST", fmt.Sprintf("/weddings/%s/guests", weddingID.Hex()), bytes.NewBuffer(reqBody))
+	reqHTTP, _ := http.NewRequest("POST", fmt.Sprintf("/weddings/%s/guests", weddingID.String()), bytes.NewBuffer(reqBody))
 	reqHTTP.Header.Set("Content-Type", "application/json")
 
 	router.ServeHTTP(w, reqHTTP)
@@ -229,12 +290,12 @@ func TestGuestHandler_CreateGuest_ValidationError(t *testing.T) {
 	handler := NewGuestHandler(mockService)
 	router := setupGuestTestRouter()
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Mock user context
 	router.Use(func(c *gin.Context) {
-		c.Set("user_id", userID.Hex())
+		c.Set("user_id", userID.String())
 		c.Next()
 	})
 
@@ -247,7 +308,7 @@ func TestGuestHandler_CreateGuest_ValidationError(t *testing.T) {
 
 	reqBody, _ := json.Marshal(req)
 	w := httptest.NewRecorder()
-	reqHTTP, _ := http.NewRequest("POST", fmt.Sprintf("/weddings/%s/guests", weddingID.Hex()), bytes.NewBuffer(reqBody))
+	reqHTTP, _ := http.NewRequest("POST", fmt.Sprintf("/weddings/%s/guests", weddingID.String()), bytes.NewBuffer(reqBody))
 	reqHTTP.Header.Set("Content-Type", "application/json")
 
 	router.ServeHTTP(w, reqHTTP)
@@ -266,8 +327,8 @@ func TestGuestHandler_GetGuest(t *testing.T) {
 	handler := NewGuestHandler(mockService)
 	router := setupGuestTestRouter()
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Create test guest
 	guest := &models.Guest{
@@ -280,14 +341,14 @@ func TestGuestHandler_GetGuest(t *testing.T) {
 
 	// Mock user context
 	router.Use(func(c *gin.Context) {
-		c.Set("user_id", userID.Hex())
+		c.Set("user_id", userID.String())
 		c.Next()
 	})
 
 	router.GET("/guests/:id", handler.GetGuest)
 
 	w := httptest.NewRecorder()
-	reqHTTP, _ := http.NewRequest("GET", fmt.Sprintf("/guests/%s", guest.ID.Hex()), nil)
+	reqHTTP, _ := http.NewRequest("GET", fmt.Sprintf("/guests/%s", guest.ID.String()), nil)
 
 	router.ServeHTTP(w, reqHTTP)
 
@@ -308,18 +369,18 @@ func TestGuestHandler_GetGuest_NotFound(t *testing.T) {
 	handler := NewGuestHandler(mockService)
 	router := setupGuestTestRouter()
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 
 	// Mock user context
 	router.Use(func(c *gin.Context) {
-		c.Set("user_id", userID.Hex())
+		c.Set("user_id", userID.String())
 		c.Next()
 	})
 
 	router.GET("/guests/:id", handler.GetGuest)
 
 	w := httptest.NewRecorder()
-	reqHTTP, _ := http.NewRequest("GET", "/guests/"+primitive.NewObjectID().Hex(), nil)
+	reqHTTP, _ := http.NewRequest("GET", "/guests/"+models.NewID().String(), nil)
 
 	router.ServeHTTP(w, reqHTTP)
 
@@ -337,8 +398,8 @@ func TestGuestHandler_ListGuests(t *testing.T) {
 	handler := NewGuestHandler(mockService)
 	router := setupGuestTestRouter()
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Create test guests
 	guests := []*models.Guest{
@@ -352,14 +413,14 @@ func TestGuestHandler_ListGuests(t *testing.T) {
 
 	// Mock user context
 	router.Use(func(c *gin.Context) {
-		c.Set("user_id", userID.Hex())
+		c.Set("user_id", userID.String())
 		c.Next()
 	})
 
 	router.GET("/weddings/:wedding_id/guests", handler.ListGuests)
 
 	w := httptest.NewRecorder()
-	reqHTTP, _ := http.NewRequest("GET", fmt.Sprintf("/weddings/%s/guests?page=1&size=10", weddingID.Hex()), nil)
+	reqHTTP, _ := http.NewRequest("GET", fmt.Sprintf("/weddings/%s/guests?page=1&size=10", weddingID.String()), nil)
 
 	router.ServeHTTP(w, reqHTTP)
 
@@ -375,13 +436,232 @@ func TestGuestHandler_ListGuests(t *testing.T) {
 	assert.Equal(t, float64(10), response["size"])
 }
 
+func TestGuestHandler_SearchGuests(t *testing.T) {
+	mockService := NewMockGuestService()
+	handler := NewGuestHandler(mockService)
+	router := setupGuestTestRouter()
+
+	weddingID := models.NewID()
+	userID := models.NewID()
+	mockService.CreateGuest(context.Background(), weddingID, userID, &models.Guest{FirstName: "John", LastName: "Doe", Email: "john@example.com"})
+	mockService.CreateGuest(context.Background(), weddingID, userID, &models.Guest{FirstName: "Jane", LastName: "Smith"})
+
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	router.GET("/weddings/:wedding_id/guests/search", handler.SearchGuests)
+
+	search := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/weddings/%s/guests/search?%s", weddingID.String(), query), nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := search("q=john")
+	require.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data []GuestResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, "John", response.Data[0].FirstName)
+	assert.NotEqual(t, "john@example.com", response.Data[0].Email)
+
+	assert.Equal(t, http.StatusBadRequest, search("q=").Code)
+	assert.Equal(t, http.StatusBadRequest, search("q=john&limit=500").Code)
+
+	mockService.listError = services.ErrInvalidGuestSearch
+	assert.Equal(t, http.StatusBadRequest, search("q=%3F").Code)
+	mockService.listError = services.ErrUnauthorized
+	assert.Equal(t, http.StatusForbidden, search("q=john").Code)
+}
+
+func TestGuestHandler_ListGuests_MasksPII(t *testing.T) {
+	weddingID := models.NewID()
+	userID := models.NewID()
+
+	setup := func(auditLog AuditLogger) *gin.Engine {
+		mockService := NewMockGuestService()
+		handler := NewGuestHandler(mockService)
+		if auditLog != nil {
+			handler.EnablePIIReveal(auditLog)
+		}
+		mockService.CreateGuest(context.Background(), weddingID, userID, &models.Guest{
+			FirstName: "John",
+			LastName:  "Doe",
+			Email:     "john.doe@gmail.com",
+			Phone:     "+6281234561234",
+			WeddingID: weddingID,
+			CreatedBy: userID,
+		})
+
+		router := setupGuestTestRouter()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", userID.String())
+			c.Next()
+		})
+		router.GET("/weddings/:wedding_id/guests", handler.ListGuests)
+		return router
+	}
+
+	listGuest := func(t *testing.T, router *gin.Engine, query string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/weddings/%s/guests%s", weddingID.String(), query), nil)
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		if w.Code != http.StatusOK {
+			return w.Code, nil
+		}
+		return w.Code, response["data"].([]interface{})[0].(map[string]interface{})
+	}
+
+	t.Run("Masked by default", func(t *testing.T) {
+		code, guest := listGuest(t, setup(nil), "")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "j***@gmail.com", guest["email"])
+		assert.Equal(t, "+62***1234", guest["phone"])
+	})
+
+	t.Run("Reveal is audit logged", func(t *testing.T) {
+		auditLog := &MockAuditLogger{}
+		auditLog.On("Log", mock.Anything, userID.String(), "pii.reveal", mock.Anything).Once()
+
+		code, guest := listGuest(t, setup(auditLog), "?reveal_pii=true")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "john.doe@gmail.com", guest["email"])
+		assert.Equal(t, "+6281234561234", guest["phone"])
+		auditLog.AssertExpectations(t)
+	})
+
+	t.Run("Reveal refused without audit logging", func(t *testing.T) {
+		code, _ := listGuest(t, setup(nil), "?reveal_pii=true")
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+}
+
+func TestGuestHandler_ExportGuests(t *testing.T) {
+	weddingID := models.NewID()
+	userID := models.NewID()
+
+	mockService := NewMockGuestService()
+	handler := NewGuestHandler(mockService)
+	for i := 0; i < 3; i++ {
+		guest := &models.Guest{
+			FirstName: fmt.Sprintf("Guest%d", i),
+			LastName:  "Doe",
+			Email:     fmt.Sprintf("guest%d@example.com", i),
+		}
+		if i == 0 {
+			guest.RSVPStatus = "attending"
+			guest.DietaryNotes = "Vegan & gluten-free"
+		}
+		mockService.CreateGuest(context.Background(), weddingID, userID, guest)
+	}
+
+	router := setupGuestTestRouter()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	router.GET("/weddings/:wedding_id/guests/export", handler.ExportGuests)
+
+	t.Run("JSON", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/weddings/%s/guests/export", weddingID.String()), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data []GuestResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Data, 3)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/weddings/%s/guests/export?format=csv", weddingID.String()), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "guests.csv")
+
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		assert.Len(t, lines, 4)
+		assert.True(t, strings.HasPrefix(lines[0], "id,first_name,last_name,email"))
+	})
+
+	t.Run("CSV honors the list filters", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/weddings/%s/guests/export?format=csv&rsvp_status=attending", weddingID.String()), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[1], "Guest0")
+		assert.Contains(t, lines[1], "Vegan & gluten-free")
+	})
+
+	t.Run("XLSX", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/weddings/%s/guests/export?format=xlsx", weddingID.String()), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "guests.xlsx")
+
+		archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		require.NoError(t, err)
+		var sheet string
+		for _, file := range archive.File {
+			if file.Name == "xl/worksheets/sheet1.xml" {
+				rc, err := file.Open()
+				require.NoError(t, err)
+				content, err := io.ReadAll(rc)
+				rc.Close()
+				require.NoError(t, err)
+				sheet = string(content)
+			}
+		}
+		assert.Equal(t, 4, strings.Count(sheet, "<row "))
+		assert.Contains(t, sheet, "dietary_notes")
+		assert.Contains(t, sheet, "Vegan &amp; gluten-free")
+	})
+
+	t.Run("Invalid filter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/weddings/%s/guests/export?vip=maybe", weddingID.String()), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Error before streaming starts", func(t *testing.T) {
+		mockService.listError = repository.ErrNotFound
+		defer func() { mockService.listError = nil }()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/weddings/%s/guests/export", weddingID.String()), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
 func TestGuestHandler_UpdateGuest(t *testing.T) {
 	mockService := NewMockGuestService()
 	handler := NewGuestHandler(mockService)
 	router := setupGuestTestRouter()
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Create test guest
 	guest := &models.Guest{
@@ -394,7 +674,7 @@ func TestGuestHandler_UpdateGuest(t *testing.T) {
 
 	// Mock user context
 	router.Use(func(c *gin.Context) {
-		c.Set("user_id", userID.Hex())
+		c.Set("user_id", userID.String())
 		c.Next()
 	})
 
@@ -410,7 +690,7 @@ func TestGuestHandler_UpdateGuest(t *testing.T) {
 
 	reqBody, _ := json.Marshal(req)
 	w := httptest.NewRecorder()
-	reqHTTP, _ := http.NewRequest("PUT", fmt.Sprintf("/guests/%s", guest.ID.Hex()), bytes.NewBuffer(reqBody))
+	reqHTTP, _ := http.NewRequest("PUT", fmt.Sprintf("/guests/%s", guest.ID.String()), bytes.NewBuffer(reqBody))
 	reqHTTP.Header.Set("Content-Type", "application/json")
 
 	router.ServeHTTP(w, reqHTTP)
@@ -429,8 +709,8 @@ func TestGuestHandler_DeleteGuest(t *testing.T) {
 	handler := NewGuestHandler(mockService)
 	router := setupGuestTestRouter()
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Create test guest
 	guest := &models.Guest{
@@ -443,14 +723,14 @@ func TestGuestHandler_DeleteGuest(t *testing.T) {
 
 	// Mock user context
 	router.Use(func(c *gin.Context) {
-		c.Set("user_id", userID.Hex())
+		c.Set("user_id", userID.String())
 		c.Next()
 	})
 
 	router.DELETE("/guests/:id", handler.DeleteGuest)
 
 	w := httptest.NewRecorder()
-	reqHTTP, _ := http.NewRequest("DELETE", fmt.Sprintf("/guests/%s", guest.ID.Hex()), nil)
+	reqHTTP, _ := http.NewRequest("DELETE", fmt.Sprintf("/guests/%s", guest.ID.String()), nil)
 
 	router.ServeHTTP(w, reqHTTP)
 
@@ -467,12 +747,12 @@ func TestGuestHandler_BulkCreateGuests(t *testing.T) {
 	handler := NewGuestHandler(mockService)
 	router := setupGuestTestRouter()
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Mock user context
 	router.Use(func(c *gin.Context) {
-		c.Set("user_id", userID.Hex())
+		c.Set("user_id", userID.String())
 		c.Next()
 	})
 
@@ -488,7 +768,7 @@ func TestGuestHandler_BulkCreateGuests(t *testing.T) {
 
 	reqBody, _ := json.Marshal(req)
 	w := httptest.NewRecorder()
-	reqHTTP, _ := http.NewRequest("POST", fmt.Sprintf("/weddings/%s/guests/bulk", weddingID.Hex()), bytes.NewBuffer(reqBody))
+	reqHTTP, _ := http.NewRequest("POST", fmt.Sprintf("/weddings/%s/guests/bulk", weddingID.String()), bytes.NewBuffer(reqBody))
 	reqHTTP.Header.Set("Content-Type", "application/json")
 
 	router.ServeHTTP(w, reqHTTP)
@@ -504,3 +784,97 @@ func TestGuestHandler_BulkCreateGuests(t *testing.T) {
 	data := response["data"].(map[string]interface{})
 	assert.Equal(t, float64(2), data["total"])
 }
+
+func TestGuestHandler_BulkUpdateGuests(t *testing.T) {
+	weddingID := models.NewID()
+	userID := models.NewID()
+	guestID := models.NewID()
+
+	setup := func(mockService *MockGuestService) *gin.Engine {
+		handler := NewGuestHandler(mockService)
+		router := setupGuestTestRouter()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", userID.String())
+			c.Next()
+		})
+		router.POST("/weddings/:wedding_id/guests/bulk-update", handler.BulkUpdateGuests)
+		router.POST("/weddings/:wedding_id/guests/bulk-delete", handler.BulkDeleteGuests)
+		return router
+	}
+	post := func(router *gin.Engine, action, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/weddings/%s/guests/%s", weddingID.String(), action), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("applies the patch to the selected guests", func(t *testing.T) {
+		mockService := NewMockGuestService()
+		mockService.bulkResult = &models.GuestBulkResult{Requested: 1, Succeeded: 1, Failed: []models.GuestBulkFailure{}}
+		router := setup(mockService)
+
+		w := post(router, "bulk-update", fmt.Sprintf(`{"guest_ids":["%s"],"patch":{"side":"bride","add_tags":[" family "]}}`, guestID.String()))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []models.ID{guestID}, mockService.bulkSelection.IDs)
+		require.NotNil(t, mockService.bulkPatch.Side)
+		assert.Equal(t, "bride", *mockService.bulkPatch.Side)
+		assert.Equal(t, []string{"family"}, mockService.bulkPatch.AddTags)
+	})
+
+	t.Run("selects an import batch", func(t *testing.T) {
+		mockService := NewMockGuestService()
+		mockService.bulkResult = &models.GuestBulkResult{Requested: 3, Succeeded: 3, Failed: []models.GuestBulkFailure{}}
+		router := setup(mockService)
+
+		w := post(router, "bulk-delete", `{"import_batch_id":"batch_1"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "batch_1", mockService.bulkSelection.ImportBatchID)
+		assert.Empty(t, mockService.bulkSelection.IDs)
+	})
+
+	t.Run("rejects invalid selections and empty patches", func(t *testing.T) {
+		router := setup(NewMockGuestService())
+
+		for _, body := range []string{
+			`{"patch":{"vip":true}}`,
+			fmt.Sprintf(`{"guest_ids":["%s"],"import_batch_id":"batch_1","patch":{"vip":true}}`, guestID.String()),
+			`{"guest_ids":["not-an-id"],"patch":{"vip":true}}`,
+			fmt.Sprintf(`{"guest_ids":["%s"],"patch":{}}`, guestID.String()),
+			fmt.Sprintf(`{"guest_ids":["%s"],"patch":{"side":"nobody"}}`, guestID.String()),
+		} {
+			w := post(router, "bulk-update", body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+	})
+
+	t.Run("answers 422 when an atomic operation fails", func(t *testing.T) {
+		mockService := NewMockGuestService()
+		mockService.bulkResult = &models.GuestBulkResult{
+			Requested: 1,
+			Failed:    []models.GuestBulkFailure{{GuestID: guestID.String(), Error: "guest not found"}},
+		}
+		router := setup(mockService)
+
+		w := post(router, "bulk-delete", fmt.Sprintf(`{"guest_ids":["%s"],"atomic":true}`, guestID.String()))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		var response struct {
+			Data models.GuestBulkResult `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Data.Failed, 1)
+	})
+
+	t.Run("forbids users who cannot manage the guests", func(t *testing.T) {
+		mockService := NewMockGuestService()
+		mockService.bulkError = services.ErrUnauthorized
+		router := setup(mockService)
+
+		w := post(router, "bulk-delete", fmt.Sprintf(`{"guest_ids":["%s"]}`, guestID.String()))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// HealthChecker probes the dependencies the API needs to serve requests
+type HealthChecker interface {
+	Check(ctx context.Context) *models.HealthReport
+}
+
+// HealthHandler serves liveness and readiness probes
+type HealthHandler struct {
+	health HealthChecker
+}
+
+// NewHealthHandler creates a new health handler
+func NewHealthHandler(health HealthChecker) *HealthHandler {
+	return &HealthHandler{health: health}
+}
+
+// Live godoc
+// @Summary Liveness probe
+// @Description Report that the process is up and serving HTTP, without probing dependencies
+// @Tags System
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /health/live [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": models.HealthOK})
+}
+
+// Ready godoc
+// @Summary Readiness probe
+// @Description Probe MongoDB and, when configured, Redis and S3 storage. Responds 503 while any of them is down.
+// @Tags System
+// @Produce json
+// @Success 200 {object} models.HealthReport
+// @Failure 503 {object} models.HealthReport
+// @Router /health/ready [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.health.Check(c.Request.Context())
+	status := http.StatusOK
+	if report.Status != models.HealthOK {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
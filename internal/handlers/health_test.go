@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// MockHealthChecker returns a fixed report and counts the probes
+type MockHealthChecker struct {
+	report *models.HealthReport
+	checks int
+}
+
+func (m *MockHealthChecker) Check(ctx context.Context) *models.HealthReport {
+	m.checks++
+	return m.report
+}
+
+func setupHealthRouter(health HealthChecker) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewHealthHandler(health)
+	router.GET("/health/live", handler.Live)
+	router.GET("/health/ready", handler.Ready)
+	return router
+}
+
+func TestHealthHandler_Live(t *testing.T) {
+	health := &MockHealthChecker{report: &models.HealthReport{Status: models.HealthUnavailable}}
+	w := httptest.NewRecorder()
+	setupHealthRouter(health).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	// Liveness never depends on other services, or their outages would restart the API
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Zero(t, health.checks)
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	tests := []struct {
+		name       string
+		report     *models.HealthReport
+		wantStatus int
+	}{
+		{
+			name: "Ready",
+			report: &models.HealthReport{Status: models.HealthOK, Dependencies: map[string]models.DependencyHealth{
+				"mongodb": {Status: models.DependencyUp},
+			}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Dependency down",
+			report: &models.HealthReport{Status: models.HealthUnavailable, Dependencies: map[string]models.DependencyHealth{
+				"mongodb": {Status: models.DependencyUp},
+				"redis":   {Status: models.DependencyDown, Error: "timed out"},
+			}},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			setupHealthRouter(&MockHealthChecker{report: tt.report}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			var report models.HealthReport
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+			assert.Equal(t, *tt.report, report)
+		})
+	}
+}
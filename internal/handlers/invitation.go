@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// InvitationSender queues guest invitations
+type InvitationSender interface {
+	SendInvite(ctx context.Context, weddingID, guestID, userID models.ID, channel string) (*models.Guest, error)
+	SendInvites(ctx context.Context, weddingID, userID models.ID, guestIDs []models.ID, channel string) (*services.InvitationBatchResult, error)
+	SendGroupInvite(ctx context.Context, weddingID, groupID, userID models.ID, channel string) (*models.Guest, error)
+}
+
+// InvitationHandler sends guests their invitations by email or WhatsApp
+type InvitationHandler struct {
+	invitations InvitationSender
+}
+
+// NewInvitationHandler creates a new invitation handler
+func NewInvitationHandler(invitations InvitationSender) *InvitationHandler {
+	return &InvitationHandler{invitations: invitations}
+}
+
+// SendInviteRequest selects how a guest's invitation is sent
+type SendInviteRequest struct {
+	// Channel is email (the default) or whatsapp
+	Channel string `json:"channel,omitempty" binding:"omitempty,oneof=email whatsapp"`
+}
+
+// SendInvitesRequest selects the guests of a bulk invitation send
+type SendInvitesRequest struct {
+	// GuestIDs limits the send to these guests; empty invites every guest whose
+	// invitation is pending or failed
+	GuestIDs []string `json:"guest_ids,omitempty"`
+	// Channel is email (the default) or whatsapp
+	Channel string `json:"channel,omitempty" binding:"omitempty,oneof=email whatsapp"`
+}
+
+// SendInvite godoc
+// @Summary Send a guest's invitation
+// @Description Queue the invitation of one guest by email or WhatsApp, with their personalized RSVP link. The guest's invitation_status becomes sent or failed once delivery is attempted; WhatsApp later reports it delivered or read.
+// @Tags Guests
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param guest_id path string true "Guest ID"
+// @Param request body SendInviteRequest false "Channel"
+// @Success 202 {object} utils.APIResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /weddings/{id}/guests/{guest_id}/send-invite [post]
+func (h *InvitationHandler) SendInvite(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	guestID, err := models.ParseID(c.Param("guest_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid guest ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req SendInviteRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+			return
+		}
+	}
+
+	guest, err := h.invitations.SendInvite(c.Request.Context(), weddingID, guestID, userID, req.Channel)
+	if err != nil {
+		h.handleError(c, err, "Failed to send invitation")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, utils.APIResponse{
+		Success: true,
+		Message: "Invitation queued",
+		Data:    gin.H{"guest_id": guest.ID},
+	})
+}
+
+// SendGroupInvite godoc
+// @Summary Send a household its invitation
+// @Description Queue one invitation for every member of a guest group, addressed to the group's primary guest (or another member who can be reached over the channel). Every member's invitation_status becomes sent or failed once delivery is attempted.
+// @Tags Guests
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param group_id path string true "Guest group ID"
+// @Param request body SendInviteRequest false "Channel"
+// @Success 202 {object} utils.APIResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /weddings/{id}/guest-groups/{group_id}/send-invite [post]
+func (h *InvitationHandler) SendGroupInvite(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	groupID, err := models.ParseID(c.Param("group_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid guest group ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req SendInviteRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+			return
+		}
+	}
+
+	guest, err := h.invitations.SendGroupInvite(c.Request.Context(), weddingID, groupID, userID, req.Channel)
+	if err != nil {
+		h.handleError(c, err, "Failed to send invitation")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, utils.APIResponse{
+		Success: true,
+		Message: "Invitation queued",
+		Data:    gin.H{"group_id": groupID, "guest_id": guest.ID},
+	})
+}
+
+// SendInvites godoc
+// @Summary Send guest invitations in bulk
+// @Description Queue invitations by email or WhatsApp for the given guests, or for every guest whose invitation is pending or failed. Guests without an email address (or phone number for WhatsApp) or already queued are skipped.
+// @Tags Guests
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body SendInvitesRequest false "Guests to invite"
+// @Success 202 {object} services.InvitationBatchResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /weddings/{id}/guests/send-invites [post]
+func (h *InvitationHandler) SendInvites(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req SendInvitesRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+			return
+		}
+	}
+
+	guestIDs := make([]models.ID, 0, len(req.GuestIDs))
+	for _, id := range req.GuestIDs {
+		guestID, err := models.ParseID(id)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid guest ID: "+id)
+			return
+		}
+		guestIDs = append(guestIDs, guestID)
+	}
+
+	result, err := h.invitations.SendInvites(c.Request.Context(), weddingID, userID, guestIDs, req.Channel)
+	if err != nil {
+		h.handleError(c, err, "Failed to send invitations")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, utils.APIResponse{
+		Success: true,
+		Message: "Invitations queued",
+		Data:    result,
+	})
+}
+
+func (h *InvitationHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrGuestHasNoEmail):
+		utils.ErrorResponse(c, http.StatusBadRequest, "Guest has no email address")
+	case errors.Is(err, services.ErrGuestHasNoPhone):
+		utils.ErrorResponse(c, http.StatusBadRequest, "Guest has no phone number")
+	case errors.Is(err, services.ErrInvitationChannel):
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invitations cannot be sent over this channel")
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrGuestNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Guest not found")
+	case errors.Is(err, services.ErrGuestGroupNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Guest group not found")
+	case errors.Is(err, services.ErrInvalidGuestGroup):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to invite guests of this wedding")
+	case errors.Is(err, services.ErrInvitationQueueFull):
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "Too many invitations are being sent, try again later")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// InvitationPDFHandler serves printable PDF invitations
+type InvitationPDFHandler struct {
+	exports services.InvitationPDFExporter
+}
+
+// NewInvitationPDFHandler creates a new invitation PDF handler
+func NewInvitationPDFHandler(exports services.InvitationPDFExporter) *InvitationPDFHandler {
+	return &InvitationPDFHandler{exports: exports}
+}
+
+// ExportInvitationPDF godoc
+// @Summary Export the invitation as a PDF
+// @Description Queues a printable PDF of the invitation in the wedding's theme: the couple, the event details and QR codes of the venue's directions and the RSVP form. Without parameters it is a single generic invitation; guest_id renders one guest's personalized invitation, and personalized=true one page per guest matching the guest list filters. Poll the returned export until it is completed, then download it.
+// @Tags weddings
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param guest_id query string false "Render the personalized invitation of this guest"
+// @Param personalized query bool false "Render a personalized invitation per guest matching the guest list filters"
+// @Param rsvp_status query string false "Filter personalized invitations by RSVP status"
+// @Param side query string false "Filter personalized invitations by side"
+// @Param tag query string false "Filter personalized invitations by tag"
+// @Success 202 {object} models.ExportJob
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/export/pdf [get]
+func (h *InvitationPDFHandler) ExportInvitationPDF(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var req services.InvitationPDFRequest
+	if raw := c.Query("guest_id"); raw != "" {
+		guestID, err := models.ParseID(raw)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid guest ID")
+			return
+		}
+		req.GuestID = &guestID
+	}
+	if raw := c.Query("personalized"); raw != "" {
+		personalized, err := strconv.ParseBool(raw)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid personalized flag")
+			return
+		}
+		req.Personalized = personalized
+	}
+	if req.Personalized {
+		filters, ok := parseGuestFilters(c)
+		if !ok {
+			return
+		}
+		req.Filters = filters
+	}
+
+	job, err := h.exports.QueueExport(c.Request.Context(), weddingID, userID, req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	utils.Response(c, http.StatusAccepted, job)
+}
+
+// GetInvitationPDF godoc
+// @Summary Get an invitation PDF export
+// @Description Get the status of an invitation PDF export
+// @Tags weddings
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param job_id path string true "Export job ID"
+// @Success 200 {object} models.ExportJob
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/export/pdf/{job_id} [get]
+func (h *InvitationPDFHandler) GetInvitationPDF(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+	jobID, ok := h.parseJobID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.exports.GetExport(c.Request.Context(), weddingID, userID, jobID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, job)
+}
+
+// DownloadInvitationPDF godoc
+// @Summary Download an invitation PDF
+// @Description Redirect to a short-lived signed link to a completed invitation PDF
+// @Tags weddings
+// @Param id path string true "Wedding ID"
+// @Param job_id path string true "Export job ID"
+// @Success 302
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/export/pdf/{job_id}/download [get]
+func (h *InvitationPDFHandler) DownloadInvitationPDF(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+	jobID, ok := h.parseJobID(c)
+	if !ok {
+		return
+	}
+
+	url, err := h.exports.DownloadURL(c.Request.Context(), weddingID, userID, jobID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.Redirect(http.StatusFound, url)
+}
+
+func (h *InvitationPDFHandler) parseRequest(c *gin.Context) (models.ID, models.ID, bool) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+	return weddingID, userID, true
+}
+
+func (h *InvitationPDFHandler) parseJobID(c *gin.Context) (models.ID, bool) {
+	jobID, err := models.ParseID(c.Param("job_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid export job ID")
+		return models.NilID, false
+	}
+	return jobID, true
+}
+
+func (h *InvitationPDFHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrGuestNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Guest not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to export invitations for this wedding")
+	case errors.Is(err, services.ErrExportJobNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Export not found")
+	case errors.Is(err, services.ErrExportNotReady):
+		utils.ErrorResponse(c, http.StatusConflict, "Export is not ready for download")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to export invitation PDF")
+	}
+}
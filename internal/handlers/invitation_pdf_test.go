@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockInvitationPDFExporter keeps exports of one wedding in memory, recording the requests
+type MockInvitationPDFExporter struct {
+	weddingID models.ID
+	jobs      map[models.ID]*models.ExportJob
+	requests  []services.InvitationPDFRequest
+}
+
+func (m *MockInvitationPDFExporter) QueueExport(ctx context.Context, weddingID, userID models.ID, req services.InvitationPDFRequest) (*models.ExportJob, error) {
+	if weddingID != m.weddingID {
+		return nil, services.ErrWeddingNotFound
+	}
+	m.requests = append(m.requests, req)
+	job := &models.ExportJob{ID: models.NewID(), WeddingID: weddingID, UserID: userID, Resource: services.InvitationPDFResource, Status: models.ExportJobQueued}
+	m.jobs[job.ID] = job
+	return job, nil
+}
+
+func (m *MockInvitationPDFExporter) GetExport(ctx context.Context, weddingID, userID, jobID models.ID) (*models.ExportJob, error) {
+	job, ok := m.jobs[jobID]
+	if !ok || job.WeddingID != weddingID {
+		return nil, services.ErrExportJobNotFound
+	}
+	return job, nil
+}
+
+func (m *MockInvitationPDFExporter) DownloadURL(ctx context.Context, weddingID, userID, jobID models.ID) (string, error) {
+	job, err := m.GetExport(ctx, weddingID, userID, jobID)
+	if err != nil {
+		return "", err
+	}
+	if !job.HasFile() {
+		return "", services.ErrExportNotReady
+	}
+	return "https://cdn.example.com/" + job.FileKey, nil
+}
+
+func setupInvitationPDFRouter(exporter services.InvitationPDFExporter, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewInvitationPDFHandler(exporter)
+	pdfs := router.Group("/weddings/:id/export/pdf", func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	pdfs.GET("", handler.ExportInvitationPDF)
+	pdfs.GET("/:job_id", handler.GetInvitationPDF)
+	pdfs.GET("/:job_id/download", handler.DownloadInvitationPDF)
+	return router
+}
+
+func TestInvitationPDFHandler(t *testing.T) {
+	exporter := &MockInvitationPDFExporter{weddingID: models.NewID(), jobs: make(map[models.ID]*models.ExportJob)}
+	router := setupInvitationPDFRouter(exporter, models.NewID())
+	base := "/weddings/" + exporter.weddingID.String() + "/export/pdf"
+
+	w := sendAccountExportRequest(router, http.MethodGet, base)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	var resp struct {
+		Data models.ExportJob `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, models.ExportJobQueued, resp.Data.Status)
+	jobPath := base + "/" + resp.Data.ID.String()
+
+	w = sendAccountExportRequest(router, http.MethodGet, jobPath)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = sendAccountExportRequest(router, http.MethodGet, jobPath+"/download")
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	job := exporter.jobs[resp.Data.ID]
+	job.Status = models.ExportJobCompleted
+	job.FileKey = "exports/invitations/x.pdf"
+	w = sendAccountExportRequest(router, http.MethodGet, jobPath+"/download")
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://cdn.example.com/exports/invitations/x.pdf", w.Header().Get("Location"))
+
+	guestID := models.NewID()
+	w = sendAccountExportRequest(router, http.MethodGet, base+"?guest_id="+guestID.String())
+	require.Equal(t, http.StatusAccepted, w.Code)
+	w = sendAccountExportRequest(router, http.MethodGet, base+"?personalized=true&rsvp_status=pending&vip=true")
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Len(t, exporter.requests, 3)
+	assert.Equal(t, services.InvitationPDFRequest{}, exporter.requests[0])
+	assert.Equal(t, &guestID, exporter.requests[1].GuestID)
+	assert.True(t, exporter.requests[2].Personalized)
+	assert.Equal(t, "pending", exporter.requests[2].Filters.RSVPStatus)
+	require.NotNil(t, exporter.requests[2].Filters.VIP)
+	assert.True(t, *exporter.requests[2].Filters.VIP)
+
+	for _, path := range []string{base + "?guest_id=nope", base + "?personalized=maybe", base + "?personalized=1&vip=sure", base + "/nope"} {
+		w = sendAccountExportRequest(router, http.MethodGet, path)
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+
+	w = sendAccountExportRequest(router, http.MethodGet, base+"/"+models.NewID().String())
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = sendAccountExportRequest(router, http.MethodGet, "/weddings/"+models.NewID().String()+"/export/pdf")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
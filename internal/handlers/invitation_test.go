@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockInvitationSender records the requested guests and returns a fixed error
+type MockInvitationSender struct {
+	err          error
+	lastGuestIDs []models.ID
+	lastChannel  string
+}
+
+func (m *MockInvitationSender) SendInvite(ctx context.Context, weddingID, guestID, userID models.ID, channel string) (*models.Guest, error) {
+	m.lastChannel = channel
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.Guest{ID: guestID, WeddingID: weddingID}, nil
+}
+
+func (m *MockInvitationSender) SendInvites(ctx context.Context, weddingID, userID models.ID, guestIDs []models.ID, channel string) (*services.InvitationBatchResult, error) {
+	m.lastGuestIDs = guestIDs
+	m.lastChannel = channel
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &services.InvitationBatchResult{Queued: len(guestIDs)}, nil
+}
+
+func (m *MockInvitationSender) SendGroupInvite(ctx context.Context, weddingID, groupID, userID models.ID, channel string) (*models.Guest, error) {
+	m.lastChannel = channel
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.Guest{ID: models.NewID(), WeddingID: weddingID, GroupID: &groupID}, nil
+}
+
+func setupInvitationRouter(sender InvitationSender) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", models.NewID().String())
+		c.Next()
+	})
+	handler := NewInvitationHandler(sender)
+	router.POST("/weddings/:wedding_id/guests/send-invites", handler.SendInvites)
+	router.POST("/weddings/:wedding_id/guests/:guest_id/send-invite", handler.SendInvite)
+	router.POST("/weddings/:wedding_id/guest-groups/:group_id/send-invite", handler.SendGroupInvite)
+	return router
+}
+
+func TestInvitationHandler_SendGroupInvite(t *testing.T) {
+	path := "/weddings/" + models.NewID().String() + "/guest-groups/" + models.NewID().String() + "/send-invite"
+
+	tests := []struct {
+		name       string
+		path       string
+		err        error
+		wantStatus int
+	}{
+		{"success", path, nil, http.StatusAccepted},
+		{"nobody reachable", path, services.ErrGuestHasNoEmail, http.StatusBadRequest},
+		{"empty group", path, services.ErrInvalidGuestGroup, http.StatusBadRequest},
+		{"group not found", path, services.ErrGuestGroupNotFound, http.StatusNotFound},
+		{"not owner", path, services.ErrUnauthorized, http.StatusForbidden},
+		{"invalid group ID", "/weddings/" + models.NewID().String() + "/guest-groups/bad/send-invite", nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupInvitationRouter(&MockInvitationSender{err: tt.err})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestInvitationHandler_SendInvite(t *testing.T) {
+	weddingID := models.NewID()
+	path := "/weddings/" + weddingID.String() + "/guests/" + models.NewID().String() + "/send-invite"
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusAccepted},
+		{"no email", services.ErrGuestHasNoEmail, http.StatusBadRequest},
+		{"no phone", services.ErrGuestHasNoPhone, http.StatusBadRequest},
+		{"channel not available", services.ErrInvitationChannel, http.StatusBadRequest},
+		{"not owner", services.ErrUnauthorized, http.StatusForbidden},
+		{"guest not found", services.ErrGuestNotFound, http.StatusNotFound},
+		{"wedding not found", services.ErrWeddingNotFound, http.StatusNotFound},
+		{"queue full", services.ErrInvitationQueueFull, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupInvitationRouter(&MockInvitationSender{err: tt.err})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+
+	t.Run("Over WhatsApp", func(t *testing.T) {
+		sender := &MockInvitationSender{}
+		router := setupInvitationRouter(sender)
+
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(`{"channel":"whatsapp"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Equal(t, "whatsapp", sender.lastChannel)
+	})
+
+	t.Run("Unknown channel", func(t *testing.T) {
+		router := setupInvitationRouter(&MockInvitationSender{})
+
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(`{"channel":"pigeon"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Invalid guest ID", func(t *testing.T) {
+		router := setupInvitationRouter(&MockInvitationSender{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/weddings/"+weddingID.String()+"/guests/not-an-id/send-invite", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestInvitationHandler_SendInvites(t *testing.T) {
+	path := "/weddings/" + models.NewID().String() + "/guests/send-invites"
+
+	t.Run("Without a body invites the default selection", func(t *testing.T) {
+		sender := &MockInvitationSender{}
+		router := setupInvitationRouter(sender)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Empty(t, sender.lastGuestIDs)
+	})
+
+	t.Run("Passes the selected guests", func(t *testing.T) {
+		sender := &MockInvitationSender{}
+		router := setupInvitationRouter(sender)
+		guestID := models.NewID()
+
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(`{"guest_ids":["`+guestID.String()+`"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Equal(t, []models.ID{guestID}, sender.lastGuestIDs)
+		assert.Contains(t, w.Body.String(), `"queued":1`)
+	})
+
+	t.Run("Invalid guest ID", func(t *testing.T) {
+		router := setupInvitationRouter(&MockInvitationSender{})
+
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(`{"guest_ids":["nope"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
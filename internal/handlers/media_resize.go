@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// MediaResizer renders uploaded images at a requested size
+type MediaResizer interface {
+	Resize(ctx context.Context, mediaID models.ID, req services.ResizeRequest) (*services.ResizedImage, error)
+}
+
+// MediaResizeHandler serves uploaded images resized on the fly
+type MediaResizeHandler struct {
+	resizer MediaResizer
+	// maxAge is how long browsers and CDNs cache resized images
+	maxAge time.Duration
+}
+
+// NewMediaResizeHandler creates a new media resize handler
+func NewMediaResizeHandler(resizer MediaResizer, maxAge time.Duration) *MediaResizeHandler {
+	return &MediaResizeHandler{resizer: resizer, maxAge: maxAge}
+}
+
+// ResizeMedia godoc
+// @Summary Resize an uploaded image
+// @Description Serve an uploaded image resized to the requested width and height, rendered once and cached. Either dimension may be left out to scale to the other. Images are never scaled up past their original size. If-None-Match requests are answered with 304.
+// @Tags Media
+// @Produce image/jpeg,image/png,image/webp
+// @Param id path string true "Media ID"
+// @Param w query int false "Width in pixels"
+// @Param h query int false "Height in pixels"
+// @Param fit query string false "contain fits the image within the box, cover fills it cropping the overflow, fill stretches the image to it" Enums(contain, cover, fill) default(contain)
+// @Success 200 {file} binary
+// @Success 304
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /media/{id}/resize [get]
+func (h *MediaResizeHandler) ResizeMedia(c *gin.Context) {
+	mediaID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid media ID")
+		return
+	}
+
+	req := services.ResizeRequest{Fit: c.Query("fit")}
+	if req.Width, err = queryDimension(c, "w"); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid width")
+		return
+	}
+	if req.Height, err = queryDimension(c, "h"); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid height")
+		return
+	}
+
+	resized, err := h.resizer.Resize(c.Request.Context(), mediaID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidResize):
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrMediaNotFound):
+			utils.ErrorResponse(c, http.StatusNotFound, "Media not found")
+		case errors.Is(err, services.ErrMediaNotResizable):
+			utils.ErrorResponse(c, http.StatusUnprocessableEntity, "Media cannot be resized")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to resize media")
+		}
+		return
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", resized.ContentType)
+	header.Set("ETag", resized.ETag)
+	header.Set("Cache-Control", h.cacheControl())
+	header.Set("Content-Security-Policy", fileContentPolicy)
+	header.Set("X-Content-Type-Options", "nosniff")
+
+	// ServeContent answers If-None-Match from the ETag
+	http.ServeContent(c.Writer, c.Request, "", time.Time{}, bytes.NewReader(resized.Data))
+}
+
+// queryDimension parses a width or height query parameter, zero when absent
+func queryDimension(c *gin.Context, name string) (int, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// cacheControl lets shared caches keep resized images as long as the public
+// files they are rendered from
+func (h *MediaResizeHandler) cacheControl() string {
+	if h.maxAge <= 0 {
+		return "no-cache"
+	}
+	return fmt.Sprintf("public, max-age=%d", int64(h.maxAge/time.Second))
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockMediaResizer resizes the one image it knows, recording the requests
+type MockMediaResizer struct {
+	mediaID  models.ID
+	requests []services.ResizeRequest
+}
+
+func (m *MockMediaResizer) Resize(ctx context.Context, mediaID models.ID, req services.ResizeRequest) (*services.ResizedImage, error) {
+	if mediaID != m.mediaID {
+		return nil, services.ErrMediaNotFound
+	}
+	if req.Width > 2048 {
+		return nil, services.ErrInvalidResize
+	}
+	m.requests = append(m.requests, req)
+	return &services.ResizedImage{Data: []byte("resized"), ContentType: "image/jpeg", ETag: `"abc"`}, nil
+}
+
+func sendMediaResizeRequest(handler *MediaResizeHandler, path string, header http.Header) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/media/:id/resize", handler.ResizeMedia)
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMediaResizeHandler_ResizeMedia(t *testing.T) {
+	resizer := &MockMediaResizer{mediaID: models.NewID()}
+	handler := NewMediaResizeHandler(resizer, time.Hour)
+	path := "/media/" + resizer.mediaID.String() + "/resize"
+
+	w := sendMediaResizeRequest(handler, path+"?w=300&h=200&fit=cover", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "resized", w.Body.String())
+	assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+	assert.Equal(t, `"abc"`, w.Header().Get("ETag"))
+	assert.Equal(t, "public, max-age=3600", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	require.Len(t, resizer.requests, 1)
+	assert.Equal(t, services.ResizeRequest{Width: 300, Height: 200, Fit: "cover"}, resizer.requests[0])
+
+	w = sendMediaResizeRequest(handler, path+"?w=300", http.Header{"If-None-Match": {`"abc"`}})
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = sendMediaResizeRequest(handler, path+"?w=wide", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = sendMediaResizeRequest(handler, path+"?w=4096", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = sendMediaResizeRequest(handler, "/media/"+models.NewID().String()+"/resize?w=300", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = sendMediaResizeRequest(handler, "/media/not-an-id/resize?w=300", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
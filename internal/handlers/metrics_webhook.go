@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// MetricsWebhookHandler handles CRM metrics webhook HTTP requests
+type MetricsWebhookHandler struct {
+	webhookService *services.MetricsWebhookService
+}
+
+// NewMetricsWebhookHandler creates a new metrics webhook handler
+func NewMetricsWebhookHandler(webhookService *services.MetricsWebhookService) *MetricsWebhookHandler {
+	return &MetricsWebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateMetricsWebhookResponse includes the signing secret, which is only returned once
+type CreateMetricsWebhookResponse struct {
+	*models.MetricsWebhook
+	Secret string `json:"secret"`
+}
+
+// CreateWebhook godoc
+// @Summary Create a metrics webhook
+// @Description Subscribe a CRM endpoint to daily signed RSVP and guest metrics (owner only)
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param webhook body services.CreateMetricsWebhookRequest true "Webhook data"
+// @Success 201 {object} CreateMetricsWebhookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/integrations/metrics-webhooks [post]
+func (h *MetricsWebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req services.CreateMetricsWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, secret, err := h.webhookService.CreateWebhook(c.Request.Context(), userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create metrics webhook")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, CreateMetricsWebhookResponse{
+		MetricsWebhook: webhook,
+		Secret:         secret,
+	})
+}
+
+// ListWebhooks godoc
+// @Summary List metrics webhooks
+// @Description List the CRM metrics webhooks owned by the current user
+// @Tags integrations
+// @Produce json
+// @Success 200 {array} models.MetricsWebhook
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/integrations/metrics-webhooks [get]
+func (h *MetricsWebhookHandler) ListWebhooks(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	webhooks, err := h.webhookService.ListWebhooks(c.Request.Context(), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to list metrics webhooks")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, webhooks)
+}
+
+// UpdateWebhook godoc
+// @Summary Update a metrics webhook
+// @Description Change the endpoint, weddings, or active state of a metrics webhook
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param webhook body services.UpdateMetricsWebhookRequest true "Webhook changes"
+// @Success 200 {object} models.MetricsWebhook
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/integrations/metrics-webhooks/{id} [put]
+func (h *MetricsWebhookHandler) UpdateWebhook(c *gin.Context) {
+	webhookID, userID, ok := h.parseWebhookRequest(c)
+	if !ok {
+		return
+	}
+
+	var req services.UpdateMetricsWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, err := h.webhookService.UpdateWebhook(c.Request.Context(), webhookID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update metrics webhook")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a metrics webhook
+// @Description Delete a metrics webhook and its delivery log
+// @Tags integrations
+// @Param id path string true "Webhook ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/integrations/metrics-webhooks/{id} [delete]
+func (h *MetricsWebhookHandler) DeleteWebhook(c *gin.Context) {
+	webhookID, userID, ok := h.parseWebhookRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), webhookID, userID); err != nil {
+		h.handleError(c, err, "Failed to delete metrics webhook")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary List metrics webhook deliveries
+// @Description Get the paginated delivery log for a metrics webhook, newest first
+// @Tags integrations
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PaginatedResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/integrations/metrics-webhooks/{id}/deliveries [get]
+func (h *MetricsWebhookHandler) ListDeliveries(c *gin.Context) {
+	webhookID, userID, ok := h.parseWebhookRequest(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+
+	deliveries, total, err := h.webhookService.ListDeliveries(c.Request.Context(), webhookID, userID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err, "Failed to list metrics webhook deliveries")
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, deliveries, int64(len(deliveries)), total, page, pageSize)
+}
+
+// DeliverNow godoc
+// @Summary Trigger a metrics webhook delivery
+// @Description Send the current metrics to the webhook endpoint immediately
+// @Tags integrations
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} models.MetricsWebhookDelivery
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/integrations/metrics-webhooks/{id}/deliver [post]
+func (h *MetricsWebhookHandler) DeliverNow(c *gin.Context) {
+	webhookID, userID, ok := h.parseWebhookRequest(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookService.DeliverNow(c.Request.Context(), webhookID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to deliver metrics webhook")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, delivery)
+}
+
+func (h *MetricsWebhookHandler) parseWebhookRequest(c *gin.Context) (models.ID, models.ID, bool) {
+	webhookID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid webhook ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+
+	return webhookID, userID, true
+}
+
+func (h *MetricsWebhookHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrMetricsWebhookNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Metrics webhook not found")
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to manage this webhook")
+	case errors.Is(err, services.ErrInvalidWebhookURL), errors.Is(err, services.ErrWebhookURLNotPublic), errors.Is(err, services.ErrNoWebhookWeddings):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// SongRequests collects the songs guests request, lets wedding editors moderate them and lists them for the DJ
+type SongRequests interface {
+	RequestSong(ctx context.Context, slug string, req services.SongRequestInput) (*models.SongRequest, error)
+	ListPublicSongRequests(ctx context.Context, slug string, page, pageSize int) ([]*models.SongRequest, int64, error)
+	ListSongRequests(ctx context.Context, weddingID, userID models.ID, status models.SongRequestStatus, page, pageSize int) ([]*models.SongRequest, int64, error)
+	ModerateSongRequest(ctx context.Context, weddingID, requestID, userID models.ID, approve bool) (*models.SongRequest, error)
+	DeleteSongRequest(ctx context.Context, weddingID, requestID, userID models.ID) error
+	StreamSongRequests(ctx context.Context, weddingID, userID models.ID, fn func(*models.SongRequest) error) error
+}
+
+// MusicHandler serves the song requests of guests, their moderation and the DJ's list
+type MusicHandler struct {
+	songs SongRequests
+}
+
+// NewMusicHandler creates a new music handler
+func NewMusicHandler(songs SongRequests) *MusicHandler {
+	return &MusicHandler{songs: songs}
+}
+
+// RequestSong godoc
+// @Summary Request a song
+// @Description Ask the DJ of a published wedding that takes song requests to play a song. A song requested before, however its title and artist were written, is counted on the earlier request. Profanity is masked, and a new request is listed once the owner approves it, unless the wedding skips approval.
+// @Tags music
+// @Accept json
+// @Produce json
+// @Param slug path string true "Wedding slug"
+// @Param request body services.SongRequestInput true "Song"
+// @Success 201 {object} models.SongRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/public/weddings/slug/{slug}/song-requests [post]
+func (h *MusicHandler) RequestSong(c *gin.Context) {
+	var req services.SongRequestInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request, err := h.songs.RequestSong(c.Request.Context(), c.Param("slug"), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to save song request")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, request)
+}
+
+// GetSongRequests godoc
+// @Summary Get a wedding's requested songs
+// @Description Get the approved song requests of a published wedding, most requested first
+// @Tags music
+// @Produce json
+// @Param slug path string true "Wedding slug"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PaginatedResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/public/weddings/slug/{slug}/song-requests [get]
+func (h *MusicHandler) GetSongRequests(c *gin.Context) {
+	page, pageSize := utils.ParsePaginationParams(c)
+
+	requests, total, err := h.songs.ListPublicSongRequests(c.Request.Context(), c.Param("slug"), page, pageSize)
+	if err != nil {
+		h.handleError(c, err, "Failed to get song requests")
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, requests, int64(len(requests)), total, page, pageSize)
+}
+
+// ListSongRequests godoc
+// @Summary List a wedding's song requests for moderation
+// @Description Get the song requests of a wedding, most requested first (wedding editors only)
+// @Tags music
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param status query string false "Filter by status" Enums(pending, approved, rejected)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PaginatedResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/song-requests [get]
+func (h *MusicHandler) ListSongRequests(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+	status := models.SongRequestStatus(c.Query("status"))
+
+	requests, total, err := h.songs.ListSongRequests(c.Request.Context(), weddingID, userID, status, page, pageSize)
+	if err != nil {
+		h.handleError(c, err, "Failed to get song requests")
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, requests, int64(len(requests)), total, page, pageSize)
+}
+
+// ExportSongRequests godoc
+// @Summary Export the DJ's song list
+// @Description Download the approved song requests of a wedding, most requested first, with how many guests requested each and who (wedding editors only)
+// @Tags music
+// @Produce json,text/csv,application/octet-stream
+// @Param id path string true "Wedding ID"
+// @Param format query string false "Output format" Enums(json, csv, xlsx)
+// @Param public_key query string false "age recipient to encrypt the list to"
+// @Success 200 {array} models.SongRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/song-requests/export [get]
+func (h *MusicHandler) ExportSongRequests(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	opts, ok := parseExportOptions(c)
+	if !ok {
+		return
+	}
+
+	_, started, err := streamExport(c, songRequestCSVExport, opts, func(emit func(record interface{}) error) error {
+		return h.songs.StreamSongRequests(c.Request.Context(), weddingID, userID, func(request *models.SongRequest) error {
+			return emit(request)
+		})
+	})
+	if err != nil && !started {
+		h.handleError(c, err, "Failed to export song requests")
+	}
+}
+
+// ApproveSongRequest godoc
+// @Summary Approve a song request
+// @Description Put a pending or rejected song on the DJ's list (wedding editors only)
+// @Tags music
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request_id path string true "Song request ID"
+// @Success 200 {object} models.SongRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/song-requests/{request_id}/approve [post]
+func (h *MusicHandler) ApproveSongRequest(c *gin.Context) {
+	h.moderateSongRequest(c, true)
+}
+
+// RejectSongRequest godoc
+// @Summary Reject a song request
+// @Description Keep a song off the DJ's list without deleting it, so that requesting it again does not bring it back (wedding editors only)
+// @Tags music
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request_id path string true "Song request ID"
+// @Success 200 {object} models.SongRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/song-requests/{request_id}/reject [post]
+func (h *MusicHandler) RejectSongRequest(c *gin.Context) {
+	h.moderateSongRequest(c, false)
+}
+
+func (h *MusicHandler) moderateSongRequest(c *gin.Context, approve bool) {
+	weddingID, requestID, userID, ok := h.parseSongRequest(c)
+	if !ok {
+		return
+	}
+
+	request, err := h.songs.ModerateSongRequest(c.Request.Context(), weddingID, requestID, userID, approve)
+	if err != nil {
+		h.handleError(c, err, "Failed to moderate song request")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, request)
+}
+
+// DeleteSongRequest godoc
+// @Summary Delete a song request
+// @Description Remove a song request from a wedding (wedding editors only)
+// @Tags music
+// @Param id path string true "Wedding ID"
+// @Param request_id path string true "Song request ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/song-requests/{request_id} [delete]
+func (h *MusicHandler) DeleteSongRequest(c *gin.Context) {
+	weddingID, requestID, userID, ok := h.parseSongRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.songs.DeleteSongRequest(c.Request.Context(), weddingID, requestID, userID); err != nil {
+		h.handleError(c, err, "Failed to delete song request")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseSongRequest reads the wedding, the song request and the authenticated user of a moderation request
+func (h *MusicHandler) parseSongRequest(c *gin.Context) (weddingID, requestID, userID models.ID, ok bool) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	requestID, err = models.ParseID(c.Param("request_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid song request ID")
+		return
+	}
+
+	userID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	return weddingID, requestID, userID, true
+}
+
+func (h *MusicHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrSongRequestNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Song request not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to manage this wedding's song requests")
+	case errors.Is(err, services.ErrSongRequestsDisabled):
+		utils.ErrorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrInvalidSongRequest), errors.Is(err, services.ErrInvalidSongRequestStatus):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
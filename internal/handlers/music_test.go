@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockSongRequests is a mock implementation of SongRequests
+type MockSongRequests struct {
+	mock.Mock
+}
+
+func (m *MockSongRequests) RequestSong(ctx context.Context, slug string, req services.SongRequestInput) (*models.SongRequest, error) {
+	args := m.Called(ctx, slug, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SongRequest), args.Error(1)
+}
+
+func (m *MockSongRequests) ListPublicSongRequests(ctx context.Context, slug string, page, pageSize int) ([]*models.SongRequest, int64, error) {
+	args := m.Called(ctx, slug, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*models.SongRequest), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSongRequests) ListSongRequests(ctx context.Context, weddingID, userID models.ID, status models.SongRequestStatus, page, pageSize int) ([]*models.SongRequest, int64, error) {
+	args := m.Called(ctx, weddingID, userID, status, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*models.SongRequest), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSongRequests) ModerateSongRequest(ctx context.Context, weddingID, requestID, userID models.ID, approve bool) (*models.SongRequest, error) {
+	args := m.Called(ctx, weddingID, requestID, userID, approve)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SongRequest), args.Error(1)
+}
+
+func (m *MockSongRequests) DeleteSongRequest(ctx context.Context, weddingID, requestID, userID models.ID) error {
+	args := m.Called(ctx, weddingID, requestID, userID)
+	return args.Error(0)
+}
+
+func (m *MockSongRequests) StreamSongRequests(ctx context.Context, weddingID, userID models.ID, fn func(*models.SongRequest) error) error {
+	args := m.Called(ctx, weddingID, userID, fn)
+	if requests, ok := args.Get(0).([]*models.SongRequest); ok {
+		for _, request := range requests {
+			if err := fn(request); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func setupMusicTestRouter(handler *MusicHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	public := router.Group("/api/v1/public/weddings/slug/:slug/song-requests")
+	public.GET("", handler.GetSongRequests)
+	public.POST("", handler.RequestSong)
+
+	protected := router.Group("/api/v1/weddings/:id/song-requests")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	protected.GET("", handler.ListSongRequests)
+	protected.GET("/export", handler.ExportSongRequests)
+	protected.POST("/:request_id/approve", handler.ApproveSongRequest)
+	protected.POST("/:request_id/reject", handler.RejectSongRequest)
+	protected.DELETE("/:request_id", handler.DeleteSongRequest)
+
+	return router
+}
+
+func TestMusicHandler_RequestSong(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		expected int
+	}{
+		{"requested", `{"title":"Dancing Queen","artist":"ABBA","guest_name":"Alice"}`, nil, http.StatusCreated},
+		{"song requests disabled", `{"title":"Dancing Queen"}`, services.ErrSongRequestsDisabled, http.StatusForbidden},
+		{"unknown wedding", `{"title":"Dancing Queen"}`, services.ErrWeddingNotFound, http.StatusNotFound},
+		{"title without words", `{"title":"?!"}`, services.ErrInvalidSongRequest, http.StatusBadRequest},
+		{"missing title", `{"artist":"ABBA"}`, nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			songs := new(MockSongRequests)
+			if tt.err != nil {
+				songs.On("RequestSong", mock.Anything, "jane-john", mock.Anything).Return(nil, tt.err)
+			} else {
+				songs.On("RequestSong", mock.Anything, "jane-john", mock.Anything).Return(&models.SongRequest{Title: "Dancing Queen", Requests: 1}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/public/weddings/slug/jane-john/song-requests", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupMusicTestRouter(NewMusicHandler(songs), models.NilID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
+
+func TestMusicHandler_ModerateSongRequest(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	requestID := models.NewID()
+	base := "/api/v1/weddings/" + weddingID.String() + "/song-requests/" + requestID.String()
+
+	tests := []struct {
+		name     string
+		path     string
+		approve  bool
+		err      error
+		expected int
+	}{
+		{"approved", base + "/approve", true, nil, http.StatusOK},
+		{"rejected", base + "/reject", false, nil, http.StatusOK},
+		{"not an editor", base + "/reject", false, services.ErrUnauthorized, http.StatusForbidden},
+		{"unknown song request", base + "/approve", true, services.ErrSongRequestNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			songs := new(MockSongRequests)
+			if tt.err != nil {
+				songs.On("ModerateSongRequest", mock.Anything, weddingID, requestID, userID, tt.approve).Return(nil, tt.err)
+			} else {
+				songs.On("ModerateSongRequest", mock.Anything, weddingID, requestID, userID, tt.approve).Return(&models.SongRequest{ID: requestID}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			w := httptest.NewRecorder()
+			setupMusicTestRouter(NewMusicHandler(songs), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			songs.AssertExpectations(t)
+		})
+	}
+
+	t.Run("invalid song request ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/weddings/"+weddingID.String()+"/song-requests/nope/approve", nil)
+		w := httptest.NewRecorder()
+		setupMusicTestRouter(NewMusicHandler(new(MockSongRequests)), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		songs := new(MockSongRequests)
+		songs.On("DeleteSongRequest", mock.Anything, weddingID, requestID, userID).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, base, nil)
+		w := httptest.NewRecorder()
+		setupMusicTestRouter(NewMusicHandler(songs), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
+
+func TestMusicHandler_ExportSongRequests(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	path := "/api/v1/weddings/" + weddingID.String() + "/song-requests/export"
+
+	t.Run("CSV", func(t *testing.T) {
+		songs := new(MockSongRequests)
+		songs.On("StreamSongRequests", mock.Anything, weddingID, userID, mock.Anything).Return([]*models.SongRequest{
+			{Title: "Dancing Queen", Artist: "ABBA", Requests: 3, RequestedBy: []string{"Alice", "Bob"}},
+			{Title: "September", Artist: "Earth, Wind & Fire", Requests: 1},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, path+"?format=csv", nil)
+		w := httptest.NewRecorder()
+		setupMusicTestRouter(NewMusicHandler(songs), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "song-requests.csv")
+
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "title,artist,requests,requested_by,requested_at", lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "Dancing Queen,ABBA,3,Alice; Bob,"))
+		assert.True(t, strings.HasPrefix(lines[2], `September,"Earth, Wind & Fire",1,,`))
+	})
+
+	t.Run("not an editor", func(t *testing.T) {
+		songs := new(MockSongRequests)
+		songs.On("StreamSongRequests", mock.Anything, weddingID, userID, mock.Anything).Return(nil, services.ErrUnauthorized)
+
+		req := httptest.NewRequest(http.MethodGet, path+"?format=csv", nil)
+		w := httptest.NewRecorder()
+		setupMusicTestRouter(NewMusicHandler(songs), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
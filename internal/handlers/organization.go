@@ -0,0 +1,372 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// OrganizationManager manages white-label organizations for platform admins
+// and organization admins
+type OrganizationManager interface {
+	CreateOrganization(ctx context.Context, actorID models.ID, req services.CreateOrganizationRequest) (*models.Organization, error)
+	ListOrganizations(ctx context.Context, actorID models.ID, page, pageSize int) ([]*models.Organization, int64, error)
+	GetOrganization(ctx context.Context, actorID models.ID, id string) (*models.Organization, error)
+	UpdateOrganization(ctx context.Context, actorID models.ID, id string, req services.UpdateOrganizationRequest) (*models.Organization, error)
+	DeleteOrganization(ctx context.Context, actorID models.ID, id string) error
+	AddMember(ctx context.Context, actorID models.ID, id string, req services.AddOrganizationMemberRequest) (*models.User, error)
+	GetOwnOrganization(ctx context.Context, userID models.ID) (*models.Organization, error)
+	UpdateBranding(ctx context.Context, userID models.ID, branding models.OrganizationBranding) (*models.Organization, error)
+	ListMembers(ctx context.Context, userID models.ID, page, pageSize int, filters repository.UserFilters) ([]*models.User, int64, error)
+	ListWeddings(ctx context.Context, userID models.ID, page, pageSize int, filters repository.AdminWeddingFilters) ([]*models.Wedding, int64, error)
+}
+
+// OrganizationHandler handles organization HTTP requests
+type OrganizationHandler struct {
+	organizations OrganizationManager
+	pii           *PIIAccess
+}
+
+// NewOrganizationHandler creates a new organization handler
+func NewOrganizationHandler(organizations OrganizationManager) *OrganizationHandler {
+	return &OrganizationHandler{organizations: organizations}
+}
+
+// EnablePIIReveal allows organization admins to request unmasked contact
+// details of their members, recording each reveal with auditLog
+func (h *OrganizationHandler) EnablePIIReveal(auditLog AuditLogger) {
+	h.pii = NewPIIAccess(auditLog)
+}
+
+// CreateOrganization godoc
+// @Summary Create an organization
+// @Description Create a white-label organization. Its ID becomes the tenant ID of its users and weddings (platform admin only).
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param organization body services.CreateOrganizationRequest true "Organization data"
+// @Success 201 {object} models.Organization
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/organizations [post]
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	userID, ok := organizationUser(c)
+	if !ok {
+		return
+	}
+
+	var req services.CreateOrganizationRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	org, err := h.organizations.CreateOrganization(c.Request.Context(), userID, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, org)
+}
+
+// ListOrganizations godoc
+// @Summary List organizations
+// @Description List every white-label organization by name (platform admin only)
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} PaginationResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/organizations [get]
+func (h *OrganizationHandler) ListOrganizations(c *gin.Context) {
+	userID, ok := organizationUser(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+	orgs, total, err := h.organizations.ListOrganizations(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, orgs, int64(len(orgs)), total, page, pageSize)
+}
+
+// GetOrganization godoc
+// @Summary Get an organization
+// @Description Get a white-label organization (platform admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} models.Organization
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/organizations/{id} [get]
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	userID, ok := organizationUser(c)
+	if !ok {
+		return
+	}
+
+	org, err := h.organizations.GetOrganization(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, org)
+}
+
+// UpdateOrganization godoc
+// @Summary Update an organization
+// @Description Change the name or branding of a white-label organization (platform admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param organization body services.UpdateOrganizationRequest true "Organization changes"
+// @Success 200 {object} models.Organization
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/organizations/{id} [put]
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	userID, ok := organizationUser(c)
+	if !ok {
+		return
+	}
+
+	var req services.UpdateOrganizationRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	org, err := h.organizations.UpdateOrganization(c.Request.Context(), userID, c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, org)
+}
+
+// DeleteOrganization godoc
+// @Summary Delete an organization
+// @Description Delete a white-label organization that no longer has members (platform admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} utils.APIResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/organizations/{id} [delete]
+func (h *OrganizationHandler) DeleteOrganization(c *gin.Context) {
+	userID, ok := organizationUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.organizations.DeleteOrganization(c.Request.Context(), userID, c.Param("id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Organization deleted")
+}
+
+// AddMember godoc
+// @Summary Add a user to an organization
+// @Description Move a user and the weddings they own into a white-label organization, optionally as its admin (platform admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param member body services.AddOrganizationMemberRequest true "Member data"
+// @Success 200 {object} models.User
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/organizations/{id}/members [post]
+func (h *OrganizationHandler) AddMember(c *gin.Context) {
+	userID, ok := organizationUser(c)
+	if !ok {
+		return
+	}
+
+	var req services.AddOrganizationMemberRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	user, err := h.organizations.AddMember(c.Request.Context(), userID, c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, user)
+}
+
+// GetOwnOrganization godoc
+// @Summary Get my organization
+// @Description Get the organization of the current organization admin
+// @Tags organization
+// @Produce json
+// @Success 200 {object} models.Organization
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/organization [get]
+func (h *OrganizationHandler) GetOwnOrganization(c *gin.Context) {
+	userID, ok := organizationUser(c)
+	if !ok {
+		return
+	}
+
+	org, err := h.organizations.GetOwnOrganization(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, org)
+}
+
+// UpdateBranding godoc
+// @Summary Update my organization's branding
+// @Description Replace the branding shown on the public pages of the organization's weddings (organization admin only)
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param branding body models.OrganizationBranding true "Branding"
+// @Success 200 {object} models.Organization
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/organization/branding [put]
+func (h *OrganizationHandler) UpdateBranding(c *gin.Context) {
+	userID, ok := organizationUser(c)
+	if !ok {
+		return
+	}
+
+	var branding models.OrganizationBranding
+	if !bindAndValidate(c, &branding) {
+		return
+	}
+
+	org, err := h.organizations.UpdateBranding(c.Request.Context(), userID, branding)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, org)
+}
+
+// ListMembers godoc
+// @Summary List my organization's users
+// @Description List the users of the current admin's organization (organization admin only)
+// @Tags organization
+// @Produce json
+// @Param status query string false "Account status"
+// @Param search query string false "Name or email"
+// @Param reveal_pii query bool false "Return unmasked emails and phone numbers (audit-logged)"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} PaginationResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/organization/users [get]
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	userID, ok := organizationUser(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+	filters := repository.UserFilters{Status: c.Query("status"), Search: c.Query("search")}
+	users, total, err := h.organizations.ListMembers(c.Request.Context(), userID, page, pageSize, filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// The service only lists the members of the admin's own organization
+	reveal, ok := h.pii.Reveal(c, "users", true, len(users))
+	if !ok {
+		return
+	}
+	if !reveal {
+		users = maskUsers(users)
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, users, int64(len(users)), total, page, pageSize)
+}
+
+// ListWeddings godoc
+// @Summary List my organization's weddings
+// @Description List the weddings of the current admin's organization, newest first (organization admin only)
+// @Tags organization
+// @Produce json
+// @Param status query string false "Wedding status"
+// @Param search query string false "Title, slug or couple names"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} PaginationResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/organization/weddings [get]
+func (h *OrganizationHandler) ListWeddings(c *gin.Context) {
+	userID, ok := organizationUser(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+	filters := repository.AdminWeddingFilters{Status: c.Query("status"), Search: c.Query("search")}
+	weddings, total, err := h.organizations.ListWeddings(c.Request.Context(), userID, page, pageSize, filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, weddings, int64(len(weddings)), total, page, pageSize)
+}
+
+func organizationUser(c *gin.Context) (models.ID, bool) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, false
+	}
+	return userID, true
+}
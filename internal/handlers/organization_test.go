@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/middleware"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockOrganizationManager keeps organizations in a map; admins manage all of
+// them and the organization admin manages "acme"
+type MockOrganizationManager struct {
+	admin    models.ID
+	orgAdmin models.ID
+	orgs     map[string]*models.Organization
+}
+
+func (m *MockOrganizationManager) platform(actorID models.ID) error {
+	if actorID != m.admin {
+		return services.ErrPlatformAdminRequired
+	}
+	return nil
+}
+
+func (m *MockOrganizationManager) own(userID models.ID) (*models.Organization, error) {
+	if userID != m.orgAdmin {
+		return nil, services.ErrNotTenantAdmin
+	}
+	return m.orgs["acme"], nil
+}
+
+func (m *MockOrganizationManager) CreateOrganization(ctx context.Context, actorID models.ID, req services.CreateOrganizationRequest) (*models.Organization, error) {
+	if err := m.platform(actorID); err != nil {
+		return nil, err
+	}
+	if _, exists := m.orgs[req.ID]; exists {
+		return nil, services.ErrOrganizationExists
+	}
+	org := &models.Organization{ID: req.ID, Name: req.Name, Branding: req.Branding}
+	m.orgs[org.ID] = org
+	return org, nil
+}
+
+func (m *MockOrganizationManager) ListOrganizations(ctx context.Context, actorID models.ID, page, pageSize int) ([]*models.Organization, int64, error) {
+	if err := m.platform(actorID); err != nil {
+		return nil, 0, err
+	}
+	var orgs []*models.Organization
+	for _, org := range m.orgs {
+		orgs = append(orgs, org)
+	}
+	return orgs, int64(len(orgs)), nil
+}
+
+func (m *MockOrganizationManager) GetOrganization(ctx context.Context, actorID models.ID, id string) (*models.Organization, error) {
+	if err := m.platform(actorID); err != nil {
+		return nil, err
+	}
+	org, exists := m.orgs[id]
+	if !exists {
+		return nil, services.ErrOrganizationNotFound
+	}
+	return org, nil
+}
+
+func (m *MockOrganizationManager) UpdateOrganization(ctx context.Context, actorID models.ID, id string, req services.UpdateOrganizationRequest) (*models.Organization, error) {
+	org, err := m.GetOrganization(ctx, actorID, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		org.Name = *req.Name
+	}
+	return org, nil
+}
+
+func (m *MockOrganizationManager) DeleteOrganization(ctx context.Context, actorID models.ID, id string) error {
+	if _, err := m.GetOrganization(ctx, actorID, id); err != nil {
+		return err
+	}
+	delete(m.orgs, id)
+	return nil
+}
+
+func (m *MockOrganizationManager) AddMember(ctx context.Context, actorID models.ID, id string, req services.AddOrganizationMemberRequest) (*models.User, error) {
+	if _, err := m.GetOrganization(ctx, actorID, id); err != nil {
+		return nil, err
+	}
+	return &models.User{ID: models.ID(req.UserID), TenantID: id}, nil
+}
+
+func (m *MockOrganizationManager) GetOwnOrganization(ctx context.Context, userID models.ID) (*models.Organization, error) {
+	return m.own(userID)
+}
+
+func (m *MockOrganizationManager) UpdateBranding(ctx context.Context, userID models.ID, branding models.OrganizationBranding) (*models.Organization, error) {
+	org, err := m.own(userID)
+	if err != nil {
+		return nil, err
+	}
+	org.Branding = branding
+	return org, nil
+}
+
+func (m *MockOrganizationManager) ListMembers(ctx context.Context, userID models.ID, page, pageSize int, filters repository.UserFilters) ([]*models.User, int64, error) {
+	if _, err := m.own(userID); err != nil {
+		return nil, 0, err
+	}
+	return []*models.User{{ID: m.orgAdmin, TenantID: "acme", Email: "jane.doe@gmail.com", Phone: "+6281234561234"}}, 1, nil
+}
+
+func (m *MockOrganizationManager) ListWeddings(ctx context.Context, userID models.ID, page, pageSize int, filters repository.AdminWeddingFilters) ([]*models.Wedding, int64, error) {
+	if _, err := m.own(userID); err != nil {
+		return nil, 0, err
+	}
+	return []*models.Wedding{}, 0, nil
+}
+
+func setupOrganizationTestRouter(manager *MockOrganizationManager, auditLog ...AuditLogger) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.NewErrorHandler(zap.NewNop(), middleware.DefaultErrorConfig()).Middleware())
+	router.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-User-ID"); userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	})
+
+	handler := NewOrganizationHandler(manager)
+	if len(auditLog) > 0 {
+		handler.EnablePIIReveal(auditLog[0])
+	}
+	router.POST("/admin/organizations", handler.CreateOrganization)
+	router.GET("/admin/organizations", handler.ListOrganizations)
+	router.GET("/admin/organizations/:id", handler.GetOrganization)
+	router.DELETE("/admin/organizations/:id", handler.DeleteOrganization)
+	router.GET("/organization", handler.GetOwnOrganization)
+	router.PUT("/organization/branding", handler.UpdateBranding)
+	router.GET("/organization/users", handler.ListMembers)
+	return router
+}
+
+func sendOrganizationRequest(router *gin.Engine, method, path string, userID models.ID, body interface{}) *httptest.ResponseRecorder {
+	var reader *bytes.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if userID != models.NilID {
+		req.Header.Set("X-User-ID", userID.String())
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestOrganizationHandler_Admin(t *testing.T) {
+	manager := &MockOrganizationManager{admin: models.NewID(), orgAdmin: models.NewID(), orgs: map[string]*models.Organization{}}
+	router := setupOrganizationTestRouter(manager)
+
+	w := sendOrganizationRequest(router, http.MethodPost, "/admin/organizations", manager.admin, services.CreateOrganizationRequest{ID: "acme", Name: "Acme Weddings"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Contains(t, manager.orgs, "acme")
+
+	w = sendOrganizationRequest(router, http.MethodPost, "/admin/organizations", manager.admin, services.CreateOrganizationRequest{ID: "acme", Name: "Again"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = sendOrganizationRequest(router, http.MethodPost, "/admin/organizations", manager.admin, services.CreateOrganizationRequest{
+		ID: "bad-colors", Name: "Bad", Branding: models.OrganizationBranding{PrimaryColor: "blue"},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = sendOrganizationRequest(router, http.MethodGet, "/admin/organizations", manager.orgAdmin, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code, "organization admins cannot see other organizations")
+
+	w = sendOrganizationRequest(router, http.MethodGet, "/admin/organizations/missing", manager.admin, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = sendOrganizationRequest(router, http.MethodGet, "/admin/organizations", models.NilID, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestOrganizationHandler_OwnOrganization(t *testing.T) {
+	manager := &MockOrganizationManager{
+		admin:    models.NewID(),
+		orgAdmin: models.NewID(),
+		orgs:     map[string]*models.Organization{"acme": {ID: "acme", Name: "Acme Weddings"}},
+	}
+	router := setupOrganizationTestRouter(manager)
+
+	w := sendOrganizationRequest(router, http.MethodPut, "/organization/branding", manager.orgAdmin, models.OrganizationBranding{
+		DisplayName: "Acme", PrimaryColor: "#aa0033", LogoURL: "https://acme.example/logo.png",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "#aa0033", manager.orgs["acme"].Branding.PrimaryColor)
+
+	w = sendOrganizationRequest(router, http.MethodPut, "/organization/branding", manager.orgAdmin, models.OrganizationBranding{LogoURL: "not a url"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = sendOrganizationRequest(router, http.MethodGet, "/organization/users", manager.orgAdmin, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"tenant_id":"acme"`)
+	assert.Contains(t, w.Body.String(), `"email":"j***@gmail.com"`)
+	assert.NotContains(t, w.Body.String(), "jane.doe@gmail.com")
+
+	w = sendOrganizationRequest(router, http.MethodGet, "/organization/users?reveal_pii=true", manager.orgAdmin, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code, "reveals must be audit-logged")
+
+	w = sendOrganizationRequest(router, http.MethodGet, "/organization", models.NewID(), nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestOrganizationHandler_ListMembersRevealPII(t *testing.T) {
+	manager := &MockOrganizationManager{orgAdmin: models.NewID(), orgs: map[string]*models.Organization{"acme": {ID: "acme"}}}
+	auditLog := &MockAuditLogger{}
+	auditLog.On("Log", mock.Anything, manager.orgAdmin.String(), "pii.reveal", mock.Anything).Once()
+	router := setupOrganizationTestRouter(manager, auditLog)
+
+	w := sendOrganizationRequest(router, http.MethodGet, "/organization/users?reveal_pii=true", manager.orgAdmin, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"email":"jane.doe@gmail.com"`)
+	assert.Contains(t, w.Body.String(), `"phone":"+6281234561234"`)
+	auditLog.AssertExpectations(t)
+}
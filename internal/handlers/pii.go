@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// RevealPIIParam is the query parameter requesting unmasked emails and phone numbers in list responses
+const RevealPIIParam = "reveal_pii"
+
+// AuditLogger defines the audit logging interface
+type AuditLogger interface {
+	Log(ctx context.Context, userID, action string, metadata map[string]interface{})
+}
+
+// PIIAccess decides whether list endpoints return contact details in full.
+// Emails and phone numbers are masked unless the caller explicitly asks to
+// reveal them, is permitted to, and the reveal can be audit-logged.
+type PIIAccess struct {
+	auditLog AuditLogger
+}
+
+// NewPIIAccess creates a PII access policy. With a nil audit logger every
+// reveal request is refused, since reveals must leave an audit trail.
+func NewPIIAccess(auditLog AuditLogger) *PIIAccess {
+	return &PIIAccess{auditLog: auditLog}
+}
+
+// Reveal reports whether the response should include unmasked PII. ownerScoped
+// marks endpoints whose data the service has already restricted to the
+// caller's own weddings; otherwise only admins may reveal. When a reveal is
+// requested but not allowed, a 403 is written and ok is false.
+func (p *PIIAccess) Reveal(c *gin.Context, resource string, ownerScoped bool, count int) (reveal bool, ok bool) {
+	reveal, ok = p.authorize(c, ownerScoped)
+	if reveal {
+		p.record(c, resource, count)
+	}
+	return reveal, ok
+}
+
+// authorize is Reveal without the audit log entry, for responses that only
+// know how many records they reveal once built; they call record afterwards.
+func (p *PIIAccess) authorize(c *gin.Context, ownerScoped bool) (reveal bool, ok bool) {
+	if c.Query(RevealPIIParam) != "true" {
+		return false, true
+	}
+
+	if !c.GetBool("is_admin") && !ownerScoped {
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to reveal personal data")
+		return false, false
+	}
+	if p == nil || p.auditLog == nil {
+		utils.ErrorResponse(c, http.StatusForbidden, "Revealing personal data is not enabled")
+		return false, false
+	}
+	return true, true
+}
+
+// record audit-logs a reveal that authorize allowed
+func (p *PIIAccess) record(c *gin.Context, resource string, count int) {
+	admin := c.GetBool("is_admin")
+	p.auditLog.Log(c.Request.Context(), c.GetString("user_id"), "pii.reveal", map[string]interface{}{
+		"resource":   resource,
+		"path":       c.Request.URL.Path,
+		"records":    count,
+		"admin":      admin,
+		"request_id": c.GetString(utils.RequestIDKey),
+		"ip_address": c.ClientIP(),
+	})
+}
+
+// maskRSVPs returns copies of the RSVPs with email and phone masked
+func maskRSVPs(rsvps []*models.RSVP) []*models.RSVP {
+	masked := make([]*models.RSVP, len(rsvps))
+	for i, rsvp := range rsvps {
+		copied := *rsvp
+		copied.Email = utils.MaskEmail(rsvp.Email)
+		copied.Phone = utils.MaskPhone(rsvp.Phone)
+		masked[i] = &copied
+	}
+	return masked
+}
+
+// maskGuests returns copies of the guests with email and phone masked
+func maskGuests(guests []*models.Guest) []*models.Guest {
+	masked := make([]*models.Guest, len(guests))
+	for i, guest := range guests {
+		copied := *guest
+		copied.Email = utils.MaskEmail(guest.Email)
+		copied.Phone = utils.MaskPhone(guest.Phone)
+		masked[i] = &copied
+	}
+	return masked
+}
+
+// maskUsers returns copies of the users with email and phone masked
+func maskUsers(users []*models.User) []*models.User {
+	masked := make([]*models.User, len(users))
+	for i, user := range users {
+		copied := *user
+		copied.Email = utils.MaskEmail(user.Email)
+		copied.Phone = utils.MaskPhone(user.Phone)
+		masked[i] = &copied
+	}
+	return masked
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// PreviewLinkManager issues and revokes the links sharing a draft wedding
+type PreviewLinkManager interface {
+	CreateLink(ctx context.Context, weddingID, userID models.ID, req services.CreatePreviewLinkRequest) (*services.PreviewLinkDetail, error)
+	ListLinks(ctx context.Context, weddingID, userID models.ID) ([]*services.PreviewLinkDetail, error)
+	RevokeLink(ctx context.Context, weddingID, userID, linkID models.ID) error
+}
+
+// PreviewLinkHandler serves the preview links of a wedding
+type PreviewLinkHandler struct {
+	links PreviewLinkManager
+}
+
+// NewPreviewLinkHandler creates a new preview link handler
+func NewPreviewLinkHandler(links PreviewLinkManager) *PreviewLinkHandler {
+	return &PreviewLinkHandler{links: links}
+}
+
+// CreatePreviewLink godoc
+// @Summary Create a preview link
+// @Description Issue an expiring link that lets anyone holding it view the wedding read-only, even before it is published. The link works for 7 days unless expires_in_hours is given.
+// @Tags Weddings
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body services.CreatePreviewLinkRequest true "Preview link"
+// @Success 201 {object} services.PreviewLinkDetail
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/preview-links [post]
+func (h *PreviewLinkHandler) CreatePreviewLink(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	var req services.CreatePreviewLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	link, err := h.links.CreateLink(c.Request.Context(), weddingID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create preview link")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, link)
+}
+
+// ListPreviewLinks godoc
+// @Summary List preview links
+// @Description List the preview links of the wedding, newest first, with how often each was used. Expired and revoked links are included without their URL.
+// @Tags Weddings
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {array} services.PreviewLinkDetail
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/preview-links [get]
+func (h *PreviewLinkHandler) ListPreviewLinks(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	links, err := h.links.ListLinks(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list preview links")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, links)
+}
+
+// RevokePreviewLink godoc
+// @Summary Revoke a preview link
+// @Description Stop a preview link from working. Its usage stays listed.
+// @Tags Weddings
+// @Param id path string true "Wedding ID"
+// @Param link_id path string true "Preview link ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/preview-links/{link_id} [delete]
+func (h *PreviewLinkHandler) RevokePreviewLink(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+	linkID, err := models.ParseID(c.Param("link_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid preview link ID")
+		return
+	}
+
+	if err := h.links.RevokeLink(c.Request.Context(), weddingID, userID, linkID); err != nil {
+		h.handleError(c, err, "Failed to revoke preview link")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *PreviewLinkHandler) parseWeddingRequest(c *gin.Context) (models.ID, models.ID, bool) {
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+
+	return weddingID, userID, true
+}
+
+func (h *PreviewLinkHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrPreviewLinkNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Preview link not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to share this wedding")
+	case errors.Is(err, services.ErrTooManyPreviewLinks):
+		utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
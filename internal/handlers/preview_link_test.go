@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockPreviewLinkManager is a mock implementation of PreviewLinkManager
+type MockPreviewLinkManager struct {
+	mock.Mock
+}
+
+func (m *MockPreviewLinkManager) CreateLink(ctx context.Context, weddingID, userID models.ID, req services.CreatePreviewLinkRequest) (*services.PreviewLinkDetail, error) {
+	args := m.Called(ctx, weddingID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.PreviewLinkDetail), args.Error(1)
+}
+
+func (m *MockPreviewLinkManager) ListLinks(ctx context.Context, weddingID, userID models.ID) ([]*services.PreviewLinkDetail, error) {
+	args := m.Called(ctx, weddingID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*services.PreviewLinkDetail), args.Error(1)
+}
+
+func (m *MockPreviewLinkManager) RevokeLink(ctx context.Context, weddingID, userID, linkID models.ID) error {
+	return m.Called(ctx, weddingID, userID, linkID).Error(0)
+}
+
+// MockWeddingPreviewer is a mock implementation of services.WeddingPreviewer
+type MockWeddingPreviewer struct {
+	mock.Mock
+}
+
+func (m *MockWeddingPreviewer) OpenPreview(ctx context.Context, token string) (*models.Wedding, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Wedding), args.Error(1)
+}
+
+func setupPreviewLinkTestRouter(handler *PreviewLinkHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	links := router.Group("/api/v1/weddings/:wedding_id/preview-links")
+	links.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	links.POST("", handler.CreatePreviewLink)
+	links.GET("", handler.ListPreviewLinks)
+	links.DELETE("/:link_id", handler.RevokePreviewLink)
+
+	return router
+}
+
+func TestPreviewLinkHandler_CreatePreviewLink(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	path := "/api/v1/weddings/" + weddingID.String() + "/preview-links"
+	body := `{"label":"Planner","expires_in_hours":48}`
+	valid := services.CreatePreviewLinkRequest{Label: "Planner", ExpiresInHours: 48}
+
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		expected int
+	}{
+		{"created", body, nil, http.StatusCreated},
+		{"expiry over 30 days", `{"expires_in_hours":721}`, nil, http.StatusBadRequest},
+		{"too many links", body, services.ErrTooManyPreviewLinks, http.StatusConflict},
+		{"not an editor", body, services.ErrUnauthorized, http.StatusForbidden},
+		{"wedding not found", body, services.ErrWeddingNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := new(MockPreviewLinkManager)
+			if tt.body == body {
+				if tt.err != nil {
+					links.On("CreateLink", mock.Anything, weddingID, userID, valid).Return(nil, tt.err)
+				} else {
+					links.On("CreateLink", mock.Anything, weddingID, userID, valid).Return(&services.PreviewLinkDetail{
+						PreviewLink: &models.PreviewLink{ID: models.NewID(), WeddingID: weddingID, Label: "Planner"},
+						Token:       "token",
+						URL:         "https://app.example.com/preview?token=token",
+					}, nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupPreviewLinkTestRouter(NewPreviewLinkHandler(links), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			links.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPreviewLinkHandler_ListAndRevoke(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	linkID := models.NewID()
+	path := "/api/v1/weddings/" + weddingID.String() + "/preview-links"
+
+	t.Run("list", func(t *testing.T) {
+		links := new(MockPreviewLinkManager)
+		links.On("ListLinks", mock.Anything, weddingID, userID).Return([]*services.PreviewLinkDetail{
+			{PreviewLink: &models.PreviewLink{ID: linkID, WeddingID: weddingID, UseCount: 3}},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		setupPreviewLinkTestRouter(NewPreviewLinkHandler(links), userID).ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"use_count":3`)
+		links.AssertExpectations(t)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		links := new(MockPreviewLinkManager)
+		links.On("RevokeLink", mock.Anything, weddingID, userID, linkID).Return(nil)
+
+		w := httptest.NewRecorder()
+		setupPreviewLinkTestRouter(NewPreviewLinkHandler(links), userID).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, path+"/"+linkID.String(), nil))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		links.AssertExpectations(t)
+	})
+
+	t.Run("revoke an unknown link", func(t *testing.T) {
+		links := new(MockPreviewLinkManager)
+		links.On("RevokeLink", mock.Anything, weddingID, userID, linkID).Return(services.ErrPreviewLinkNotFound)
+
+		w := httptest.NewRecorder()
+		setupPreviewLinkTestRouter(NewPreviewLinkHandler(links), userID).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, path+"/"+linkID.String(), nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		links.AssertExpectations(t)
+	})
+}
+
+func TestPublicHandler_PreviewWedding(t *testing.T) {
+	draft := &models.Wedding{
+		ID:     models.NewID(),
+		Slug:   "draft-wedding",
+		Status: string(models.WeddingStatusDraft),
+		Event:  models.EventDetails{Date: time.Now().AddDate(0, 3, 0)},
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"draft shown", nil, http.StatusOK},
+		{"revoked link", services.ErrExpiredPreviewLink, http.StatusGone},
+		{"forged token", services.ErrInvalidPreviewLink, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previews := new(MockWeddingPreviewer)
+			if tt.err != nil {
+				previews.On("OpenPreview", mock.Anything, "token").Return(nil, tt.err)
+			} else {
+				previews.On("OpenPreview", mock.Anything, "token").Return(draft, nil)
+			}
+
+			handler := NewPublicHandler(new(MockWeddingServiceForPublic), new(MockRSVPServiceForPublic))
+			handler.EnablePreviews(previews)
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/api/v1/public/preview/:token", handler.PreviewWedding)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/public/preview/token", nil))
+
+			assert.Equal(t, tt.expected, w.Code)
+			if tt.err == nil {
+				var response PublicWeddingResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "draft-wedding", response.Slug)
+				assert.True(t, response.Preview)
+				assert.Equal(t, "private, no-store", w.Header().Get("Cache-Control"))
+			}
+			previews.AssertExpectations(t)
+		})
+	}
+}
@@ -1,21 +1,29 @@
 package handlers
 
 import (
+	"bytes"
 	"errors"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"wedding-invitation-backend/internal/domain/errs"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
 )
 
 // PublicHandler handles public wedding operations
 type PublicHandler struct {
 	weddingService services.PublicWeddingService
 	rsvpService    services.PublicRSVPService
+	editLinks      services.RSVPEditLinker
+	previews       services.WeddingPreviewer
+	slugs          services.SlugResolver
+	branding       services.BrandingProvider
+	openGraph      services.OpenGraphRenderer
 }
 
 // NewPublicHandler creates a new public handler
@@ -26,59 +34,157 @@ func NewPublicHandler(weddingService services.PublicWeddingService, rsvpService
 	}
 }
 
+// EnableEditLinks answers submitted RSVPs with the link their guest can change them with
+func (h *PublicHandler) EnableEditLinks(links services.RSVPEditLinker) {
+	h.editLinks = links
+}
+
+// EnablePreviews serves weddings that are not published yet through their preview links
+func (h *PublicHandler) EnablePreviews(previews services.WeddingPreviewer) {
+	h.previews = previews
+}
+
+// EnableSlugRedirects sends visitors of a renamed wedding's old slug to its current one
+func (h *PublicHandler) EnableSlugRedirects(slugs services.SlugResolver) {
+	h.slugs = slugs
+}
+
+// EnableBranding shows the branding of their organization on the pages of white-label weddings
+func (h *PublicHandler) EnableBranding(branding services.BrandingProvider) {
+	h.branding = branding
+}
+
+// EnableOpenGraph adds link previews to public weddings
+func (h *PublicHandler) EnableOpenGraph(openGraph services.OpenGraphRenderer) {
+	h.openGraph = openGraph
+}
+
+// redirectRenamedSlug answers with a permanent redirect when the slug is the
+// old slug of a renamed wedding, reporting whether it did
+func (h *PublicHandler) redirectRenamedSlug(c *gin.Context, slug string) bool {
+	if h.slugs == nil {
+		return false
+	}
+
+	current, err := h.slugs.ResolveSlug(c.Request.Context(), slug)
+	if err != nil {
+		return false
+	}
+
+	location := strings.Replace(c.FullPath(), ":slug", current, 1)
+	if c.Request.URL.RawQuery != "" {
+		location += "?" + c.Request.URL.RawQuery
+	}
+	c.Redirect(http.StatusMovedPermanently, location)
+	return true
+}
+
 // PublicWeddingResponse represents the public wedding view response
 type PublicWeddingResponse struct {
-	Slug            string                  `json:"slug"`
-	Theme           string                  `json:"theme"`
-	GroomName       string                  `json:"groom_name"`
-	BrideName       string                  `json:"bride_name"`
-	GroomRole       string                  `json:"groom_role"`
-	BrideRole       string                  `json:"bride_role"`
-	GroomBio        string                  `json:"groom_bio"`
-	BrideBio        string                  `json:"bride_bio"`
-	GroomPhotoURL   string                  `json:"groom_photo_url"`
-	BridePhotoURL   string                  `json:"bride_photo_url"`
-	LoveStory       string                  `json:"love_story"`
-	WeddingDate     time.Time               `json:"wedding_date"`
-	VenueName       string                  `json:"venue_name"`
-	VenueAddress    string                  `json:"venue_address"`
-	VenueMapURL     string                  `json:"venue_map_url"`
-	ContactEmail    string                  `json:"contact_email"`
-	SiteTitle       string                  `json:"site_title"`
-	MetaDescription string                  `json:"meta_description"`
-	Events          []models.EventDetails   `json:"events"`
-	GalleryImages   []string                `json:"gallery_images"`
-	AllowPlusOne    bool                    `json:"allow_plus_one"`
-	CollectDietary  bool                    `json:"collect_dietary"`
-	CustomQuestions []models.CustomQuestion `json:"custom_questions"`
-	RSVPDeadline    time.Time               `json:"rsvp_deadline"`
-	RSVPStatus      string                  `json:"rsvp_status"`
+	Slug            string                   `json:"slug"`
+	Theme           string                   `json:"theme"`
+	GroomName       string                   `json:"groom_name"`
+	BrideName       string                   `json:"bride_name"`
+	GroomRole       string                   `json:"groom_role"`
+	BrideRole       string                   `json:"bride_role"`
+	GroomBio        string                   `json:"groom_bio"`
+	BrideBio        string                   `json:"bride_bio"`
+	GroomPhotoURL   string                   `json:"groom_photo_url"`
+	BridePhotoURL   string                   `json:"bride_photo_url"`
+	LoveStory       string                   `json:"love_story"`
+	WeddingDate     time.Time                `json:"wedding_date"`
+	VenueName       string                   `json:"venue_name"`
+	VenueAddress    string                   `json:"venue_address"`
+	VenueMapURL     string                   `json:"venue_map_url"`
+	ContactEmail    string                   `json:"contact_email"`
+	SiteTitle       string                   `json:"site_title"`
+	MetaDescription string                   `json:"meta_description"`
+	Events          []models.EventSession    `json:"events"`
+	CalendarLinks   []services.CalendarLinks `json:"calendar_links"`
+	GalleryImages   []string                 `json:"gallery_images"`
+	AllowPlusOne    bool                     `json:"allow_plus_one"`
+	CollectDietary  bool                     `json:"collect_dietary"`
+	CustomQuestions []models.CustomQuestion  `json:"custom_questions"`
+	MealOptions     []string                 `json:"meal_options"`
+	RSVPDeadline    time.Time                `json:"rsvp_deadline"`
+	RSVPStatus      string                   `json:"rsvp_status"`
+	// Preview is set when the wedding is viewed through a preview link
+	Preview bool `json:"preview,omitempty"`
+	// Language is the language of the content; Languages lists all the
+	// languages the wedding can be shown in, for its language switcher
+	Language  string            `json:"language"`
+	Languages []string          `json:"languages"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	// Branding is the branding of the organization the wedding belongs to
+	Branding *models.OrganizationBranding `json:"branding,omitempty"`
+	// OpenGraph is the link preview of the wedding, for the page's meta tags
+	OpenGraph *services.OpenGraphMeta `json:"open_graph,omitempty"`
+	// Music is the background music of the wedding and whether guests may request songs
+	Music *PublicMusic `json:"music,omitempty"`
+}
+
+// PublicMusic is the background music player of a public wedding
+type PublicMusic struct {
+	Provider     models.MusicProvider `json:"provider,omitempty"`
+	EmbedURL     string               `json:"embed_url,omitempty"`
+	Autoplay     bool                 `json:"autoplay"`
+	SongRequests bool                 `json:"song_requests"`
 }
 
 // PublicRSVPRequest represents the public RSVP submission request
 type PublicRSVPRequest struct {
-	Name                string            `json:"name" binding:"required,min=1,max=100"`
-	Email               string            `json:"email" binding:"email"`
-	Phone               string            `json:"phone"`
-	Attending           bool              `json:"attending" binding:"required"`
-	NumberOfGuests      int               `json:"number_of_guests" binding:"required,min=1,max=10"`
-	PlusOneName         string            `json:"plus_one_name"`
-	DietaryRestrictions string            `json:"dietary_restrictions" binding:"max=500"`
-	Message             string            `json:"message" binding:"max=1000"`
-	CustomAnswers       map[string]string `json:"custom_answers"`
+	Name                string                 `json:"name" binding:"required,min=1,max=100"`
+	Email               string                 `json:"email" binding:"email"`
+	Phone               string                 `json:"phone"`
+	Attending           bool                   `json:"attending" binding:"required"`
+	NumberOfGuests      int                    `json:"number_of_guests" binding:"required,min=1,max=10"`
+	PlusOneName         string                 `json:"plus_one_name"`
+	DietaryRestrictions string                 `json:"dietary_restrictions" binding:"max=500"`
+	Message             string                 `json:"message" binding:"max=1000"`
+	CustomAnswers       map[string]interface{} `json:"custom_answers"`
+	// MealChoice and Allergies are the guest's; the plus one chooses separately
+	MealChoice        string   `json:"meal_choice,omitempty"`
+	Allergies         []string `json:"allergies,omitempty"`
+	PlusOneMealChoice string   `json:"plus_one_meal_choice,omitempty"`
+	PlusOneAllergies  []string `json:"plus_one_allergies,omitempty"`
+	// Sessions tells, by session ID, which sessions of a multi-session
+	// wedding the guest attends; without it Attending applies to all of them
+	Sessions map[string]bool `json:"sessions,omitempty"`
+	// Companions names everyone the guest brings along; PlusOneName is the
+	// single-companion shorthand kept for older invitations
+	Companions []PublicCompanion `json:"companions,omitempty"`
+	// GuestToken is the token of the guest's personal invitation link
+	GuestToken string `json:"guest_token,omitempty"`
+	// EditToken is the token of the edit link of the RSVP the guest changes;
+	// without it or GuestToken an earlier RSVP is not changed
+	EditToken string `json:"edit_token,omitempty"`
+	// Members tells, by guest ID, which other members of the guest's household
+	// attend; those left out answer like the guest
+	Members map[string]bool `json:"members,omitempty"`
+}
+
+// PublicCompanion is a companion named in a public RSVP
+type PublicCompanion struct {
+	Name       string   `json:"name"`
+	Age        *int     `json:"age,omitempty"`
+	MealChoice string   `json:"meal_choice,omitempty"`
+	Allergies  []string `json:"allergies,omitempty"`
 }
 
 // PublicRSVPResponse represents the public RSVP submission response
 type PublicRSVPResponse struct {
-	ID               primitive.ObjectID `json:"id"`
-	WeddingID        primitive.ObjectID `json:"wedding_id"`
-	Name             string             `json:"name"`
-	Email            string             `json:"email"`
-	Attending        bool               `json:"attending"`
-	NumberOfGuests   int                `json:"number_of_guests"`
-	PlusOneName      string             `json:"plus_one_name"`
-	SubmittedAt      time.Time          `json:"submitted_at"`
-	ConfirmationSent bool               `json:"confirmation_sent"`
+	ID               models.ID       `json:"id"`
+	WeddingID        models.ID       `json:"wedding_id"`
+	Name             string          `json:"name"`
+	Email            string          `json:"email"`
+	Attending        bool            `json:"attending"`
+	NumberOfGuests   int             `json:"number_of_guests"`
+	PlusOneName      string          `json:"plus_one_name"`
+	Companions       []string        `json:"companions,omitempty"`
+	SubmittedAt      time.Time       `json:"submitted_at"`
+	ConfirmationSent bool            `json:"confirmation_sent"`
+	Sessions         map[string]bool `json:"sessions,omitempty"`
+	EditLink         string          `json:"edit_link,omitempty"`
 }
 
 // GetWeddingBySlug retrieves a public wedding by slug
@@ -87,83 +193,238 @@ type PublicRSVPResponse struct {
 // @Tags Public
 // @Param slug path string true "Wedding URL slug"
 // @Success 200 {object} utils.Response{data=PublicWeddingResponse}
+// @Success 301 "Old slug of a renamed wedding; Location has the current one"
 // @Failure 404 {object} utils.ErrorResponse
 // @Failure 403 {object} utils.ErrorResponse
 // @Router /public/weddings/{slug} [get]
 func (h *PublicHandler) GetWeddingBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	if slug == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Slug is required"})
+		publicError(c, http.StatusBadRequest, "Slug is required")
 		return
 	}
 
 	// Get wedding by slug (public access - no user ID)
 	wedding, err := h.weddingService.GetWeddingBySlugForPublic(c.Request.Context(), slug)
 	if err != nil {
-		if err.Error() == "wedding not found" || err.Error() == "wedding not published" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found or not yet published"})
+		if errors.Is(err, services.ErrWeddingNotFound) && h.redirectRenamedSlug(c, slug) {
+			return
+		}
+		if errors.Is(err, errs.ErrNotFound) {
+			publicError(c, http.StatusNotFound, "Wedding not found or not yet published")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve wedding"})
+		publicError(c, http.StatusInternalServerError, "Failed to retrieve wedding")
 		return
 	}
 
 	// Check if wedding is password protected
 	if wedding.PasswordHash != "" {
-		c.JSON(http.StatusForbidden, ErrorResponse{Error: "This wedding is password protected"})
+		publicError(c, http.StatusForbidden, "This wedding is password protected")
 		return
 	}
 
-	// Convert to public response
-	response := h.convertToPublicResponse(wedding)
+	// Convert to public response, in the language the guest asked for
+	response := h.localizedResponse(c, wedding)
+	if h.openGraph != nil {
+		response.OpenGraph = h.openGraph.Meta(wedding.Localized(response.Language))
+	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// PreviewWedding shows a wedding through a preview link, published or not
+// @Summary Preview wedding (public)
+// @Description View a wedding, including an unpublished draft, through a preview link its owner shared (no authentication required). Each visit is counted on the link.
+// @Tags Public
+// @Param token path string true "Preview token"
+// @Success 200 {object} utils.Response{data=PublicWeddingResponse}
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 410 {object} utils.ErrorResponse
+// @Router /public/preview/{token} [get]
+func (h *PublicHandler) PreviewWedding(c *gin.Context) {
+	if h.previews == nil {
+		publicError(c, http.StatusNotFound, "Preview link not found")
+		return
+	}
+
+	wedding, err := h.previews.OpenPreview(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidPreviewLink), errors.Is(err, services.ErrWeddingTakenDown):
+			publicError(c, http.StatusNotFound, "Preview link not found")
+		case errors.Is(err, services.ErrExpiredPreviewLink):
+			publicError(c, http.StatusGone, "This preview link has expired or was revoked")
+		default:
+			publicError(c, http.StatusInternalServerError, "Failed to retrieve wedding")
+		}
+		return
+	}
+
+	// Previews are private by design, so neither robots nor shared caches keep them
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("X-Robots-Tag", "noindex")
+	response := h.localizedResponse(c, wedding)
+	response.Preview = true
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetWeddingCalendar downloads the events of a public wedding as a calendar file
+// @Summary Download wedding calendar (public)
+// @Description Download the events of a public wedding as an iCalendar file, one event per session (no authentication required)
+// @Tags Public
+// @Produce text/calendar
+// @Param slug path string true "Wedding URL slug"
+// @Success 200 {file} file
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Router /public/weddings/slug/{slug}/calendar.ics [get]
+func (h *PublicHandler) GetWeddingCalendar(c *gin.Context) {
+	wedding, ok := h.publicWedding(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+wedding.Slug+`.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", services.WeddingCalendar(localizeWedding(c, wedding), time.Now()))
+}
+
+// GetWeddingOGImage serves the link preview image of a public wedding
+// @Summary Get wedding link preview image (public)
+// @Description The image shown when the wedding's link is shared: the couple's names, the date and the venue in the wedding's theme colors. Rendered again once any of them change (no authentication required).
+// @Tags Public
+// @Produce image/png
+// @Param slug path string true "Wedding URL slug"
+// @Success 200 {file} file
+// @Success 304
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Router /public/weddings/slug/{slug}/og-image.png [get]
+func (h *PublicHandler) GetWeddingOGImage(c *gin.Context) {
+	if h.openGraph == nil {
+		publicError(c, http.StatusNotFound, "Wedding not found or not yet published")
+		return
+	}
+	wedding, ok := h.publicWedding(c)
+	if !ok {
+		return
+	}
+
+	img, err := h.openGraph.Image(c.Request.Context(), localizeWedding(c, wedding))
+	if err != nil {
+		publicError(c, http.StatusInternalServerError, "Failed to render link preview")
+		return
+	}
+
+	c.Header("Content-Type", "image/png")
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.Header("ETag", img.ETag)
+	http.ServeContent(c.Writer, c.Request, "", time.Time{}, bytes.NewReader(img.Data))
+}
+
+// GetWeddingSharePage serves the link preview meta tags of a public wedding
+// @Summary Get wedding share page (public)
+// @Description A page with the Open Graph and Twitter meta tags of the wedding, for the crawlers of chat apps and social networks that do not run the frontend. Browsers are sent on to the invitation (no authentication required).
+// @Tags Public
+// @Produce html
+// @Param slug path string true "Wedding URL slug"
+// @Success 200 {string} string
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Router /public/weddings/slug/{slug}/share [get]
+func (h *PublicHandler) GetWeddingSharePage(c *gin.Context) {
+	if h.openGraph == nil {
+		publicError(c, http.StatusNotFound, "Wedding not found or not yet published")
+		return
+	}
+	wedding, ok := h.publicWedding(c)
+	if !ok {
+		return
+	}
+
+	page, err := services.RenderSharePage(h.openGraph.Meta(localizeWedding(c, wedding)))
+	if err != nil {
+		publicError(c, http.StatusInternalServerError, "Failed to render link preview")
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+}
+
+// publicWedding finds the published wedding of the slug in the path for
+// pages derived from it, answering when there is none to show
+func (h *PublicHandler) publicWedding(c *gin.Context) (*models.Wedding, bool) {
+	slug := c.Param("slug")
+
+	wedding, err := h.weddingService.GetWeddingBySlugForPublic(c.Request.Context(), slug)
+	if err != nil {
+		if errors.Is(err, services.ErrWeddingNotFound) && h.redirectRenamedSlug(c, slug) {
+			return nil, false
+		}
+		if errors.Is(err, errs.ErrNotFound) {
+			publicError(c, http.StatusNotFound, "Wedding not found or not yet published")
+			return nil, false
+		}
+		publicError(c, http.StatusInternalServerError, "Failed to retrieve wedding")
+		return nil, false
+	}
+
+	if wedding.PasswordHash != "" {
+		publicError(c, http.StatusForbidden, "This wedding is password protected")
+		return nil, false
+	}
+	return wedding, true
+}
+
 // SubmitRSVP submits an RSVP for a public wedding
 // @Summary Submit RSVP for public wedding
 // @Description Submit an RSVP for a public wedding (no authentication required)
 // @Tags Public
 // @Param slug path string true "Wedding URL slug"
 // @Param request body PublicRSVPRequest true "RSVP data"
+// @Success 200 {object} utils.Response{data=PublicRSVPResponse} "The guest's earlier RSVP, updated"
 // @Success 201 {object} utils.Response{data=PublicRSVPResponse}
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 404 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
 // @Failure 409 {object} utils.ErrorResponse
+// @Failure 410 {object} utils.ErrorResponse
 // @Router /public/weddings/{slug}/rsvp [post]
 func (h *PublicHandler) SubmitRSVP(c *gin.Context) {
 	slug := c.Param("slug")
 	if slug == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Slug is required"})
+		publicError(c, http.StatusBadRequest, "Slug is required")
 		return
 	}
 
 	var req PublicRSVPRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request data: " + err.Error()})
+		publicError(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
 		return
 	}
 
 	// Validate RSVP request
 	if err := h.validatePublicRSVPRequest(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		publicError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Get wedding by slug to verify it exists and is published
 	wedding, err := h.weddingService.GetWeddingBySlugForPublic(c.Request.Context(), slug)
 	if err != nil {
-		if err.Error() == "wedding not found" || err.Error() == "wedding not published" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found or not yet published"})
+		if errors.Is(err, errs.ErrNotFound) {
+			publicError(c, http.StatusNotFound, "Wedding not found or not yet published")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to retrieve wedding"})
+		publicError(c, http.StatusInternalServerError, "Failed to retrieve wedding")
 		return
 	}
 
 	// Check if wedding is password protected
 	if wedding.PasswordHash != "" {
-		c.JSON(http.StatusForbidden, ErrorResponse{Error: "This wedding is password protected"})
+		publicError(c, http.StatusForbidden, "This wedding is password protected")
 		return
 	}
 
@@ -181,31 +442,73 @@ func (h *PublicHandler) SubmitRSVP(c *gin.Context) {
 		status = "attending"
 	}
 
-	// Convert custom answers from map[string]string to []models.CustomAnswer
-	customAnswers := make([]models.CustomAnswer, 0)
-	for question, answer := range req.CustomAnswers {
+	// Convert custom answers to []models.CustomAnswer; the RSVP service checks
+	// them against the wedding's questions
+	customAnswers := make([]models.CustomAnswer, 0, len(req.CustomAnswers))
+	for questionID, answer := range req.CustomAnswers {
 		customAnswers = append(customAnswers, models.CustomAnswer{
-			QuestionID: question,
-			Question:   question, // Using question ID as question for now
+			QuestionID: questionID,
 			Answer:     answer,
 		})
 	}
 
-	// Handle plus one
-	var plusOnes []models.PlusOneInfo
-	if req.PlusOneName != "" && req.NumberOfGuests > 1 {
-		plusOneNameParts := strings.SplitN(req.PlusOneName, " ", 2)
-		plusOneFirstName := plusOneNameParts[0]
-		plusOneLastName := ""
-		if len(plusOneNameParts) > 1 {
-			plusOneLastName = plusOneNameParts[1]
+	// Convert session attendance to per-session answers
+	var sessions []models.RSVPSessionResponse
+	for sessionID, attends := range req.Sessions {
+		sessionStatus := string(models.RSVPNotAttending)
+		if attends {
+			sessionStatus = string(models.RSVPAttending)
+		}
+		sessions = append(sessions, models.RSVPSessionResponse{SessionID: sessionID, Status: sessionStatus})
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].SessionID < sessions[j].SessionID })
+
+	// Convert household attendance to per-member answers
+	var members []models.RSVPMemberStatus
+	for guestID, attends := range req.Members {
+		memberID, err := models.ParseID(guestID)
+		if err != nil {
+			publicError(c, http.StatusBadRequest, services.ErrInvalidHouseholdMember.Error())
+			return
+		}
+		memberStatus := string(models.RSVPNotAttending)
+		if attends {
+			memberStatus = string(models.RSVPAttending)
 		}
-		plusOnes = []models.PlusOneInfo{{
-			FirstName: plusOneFirstName,
-			LastName:  plusOneLastName,
-			Dietary:   req.DietaryRestrictions,
+		members = append(members, models.RSVPMemberStatus{GuestID: memberID, Status: memberStatus})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].GuestID < members[j].GuestID })
+
+	// Handle companions, or the single plus one of older invitations
+	companions := req.Companions
+	if len(companions) == 0 && req.PlusOneName != "" && req.NumberOfGuests > 1 {
+		companions = []PublicCompanion{{
+			Name:       req.PlusOneName,
+			MealChoice: req.PlusOneMealChoice,
+			Allergies:  req.PlusOneAllergies,
 		}}
 	}
+	var plusOnes []models.PlusOneInfo
+	for _, companion := range companions {
+		nameParts := strings.SplitN(strings.TrimSpace(companion.Name), " ", 2)
+		plusOne := models.PlusOneInfo{
+			FirstName:  nameParts[0],
+			Age:        companion.Age,
+			Dietary:    req.DietaryRestrictions,
+			MealChoice: companion.MealChoice,
+			Allergies:  companion.Allergies,
+		}
+		if len(nameParts) > 1 {
+			plusOne.LastName = nameParts[1]
+		}
+		plusOnes = append(plusOnes, plusOne)
+	}
+
+	// NumberOfGuests counts the whole party, companions included
+	attendanceCount := req.NumberOfGuests - len(plusOnes)
+	if attendanceCount < 1 {
+		attendanceCount = 1
+	}
 
 	// Create RSVP submission request
 	submitReq := services.SubmitRSVPRequest{
@@ -214,11 +517,17 @@ func (h *PublicHandler) SubmitRSVP(c *gin.Context) {
 		Email:               req.Email,
 		Phone:               req.Phone,
 		Status:              status,
-		AttendanceCount:     req.NumberOfGuests,
+		AttendanceCount:     attendanceCount,
+		Sessions:            sessions,
 		PlusOnes:            plusOnes,
 		DietaryRestrictions: req.DietaryRestrictions,
 		AdditionalNotes:     req.Message,
+		MealChoice:          req.MealChoice,
+		Allergies:           req.Allergies,
 		CustomAnswers:       customAnswers,
+		GuestToken:          req.GuestToken,
+		EditToken:           req.EditToken,
+		Members:             members,
 		Source:              string(models.RSVPSourceWeb),
 		IPAddress:           c.ClientIP(),
 		UserAgent:           c.GetHeader("User-Agent"),
@@ -227,26 +536,53 @@ func (h *PublicHandler) SubmitRSVP(c *gin.Context) {
 	// Submit RSVP
 	rsvp, err := h.rsvpService.SubmitRSVP(c.Request.Context(), wedding.ID, submitReq)
 	if err != nil {
-		if err.Error() == "RSVP period is not open" || err.Error() == "RSVP deadline has passed" {
-			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "RSVP period is not open"})
+		if errors.Is(err, services.ErrRSVPClosed) {
+			publicError(c, http.StatusBadRequest, "RSVP period is not open")
+			return
+		}
+		if errors.Is(err, services.ErrDuplicateRSVP) {
+			publicError(c, http.StatusConflict, "An RSVP for this guest already exists")
 			return
 		}
-		if err.Error() == "email already exists" {
-			c.JSON(http.StatusConflict, ErrorResponse{Error: "An RSVP with this email already exists"})
+		if errors.Is(err, services.ErrExpiredRSVPEditLink) {
+			publicError(c, http.StatusGone, "RSVP edit link has expired")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to submit RSVP"})
+		if errors.Is(err, services.ErrRSVPEditingDisabled) {
+			publicError(c, http.StatusForbidden, err.Error())
+			return
+		}
+		if errors.Is(err, services.ErrInvalidRSVPSessions) || errors.Is(err, services.ErrInvalidRSVPAnswers) ||
+			errors.Is(err, services.ErrInvalidMealChoice) || errors.Is(err, services.ErrInvalidAllergies) ||
+			errors.Is(err, services.ErrInvalidCompanion) || errors.Is(err, services.ErrInvalidGuestLink) ||
+			errors.Is(err, services.ErrInvalidRSVPEditLink) ||
+			errors.Is(err, services.ErrTooManyPlusOnes) || errors.Is(err, services.ErrInvalidHouseholdMember) {
+			publicError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		publicError(c, http.StatusInternalServerError, "Failed to submit RSVP")
 		return
 	}
 
-	// Get plus one name for response
+	// Get companion names for response
 	plusOneName := ""
-	if len(rsvp.PlusOnes) > 0 {
-		plusOneName = rsvp.PlusOnes[0].FirstName + " " + rsvp.PlusOnes[0].LastName
+	var companionNames []string
+	for _, plusOne := range rsvp.PlusOnes {
+		companionNames = append(companionNames, plusOne.FullName())
+	}
+	if len(companionNames) > 0 {
+		plusOneName = companionNames[0]
 	}
 
 	// Convert attending status
 	attending := rsvp.Status == "attending"
+	var sessionAttendance map[string]bool
+	if len(rsvp.Sessions) > 0 {
+		sessionAttendance = make(map[string]bool, len(rsvp.Sessions))
+		for _, response := range rsvp.Sessions {
+			sessionAttendance[response.SessionID] = response.Status == string(models.RSVPAttending)
+		}
+	}
 
 	// Convert to response
 	response := &PublicRSVPResponse{
@@ -255,13 +591,23 @@ func (h *PublicHandler) SubmitRSVP(c *gin.Context) {
 		Name:             rsvp.GetFullName(),
 		Email:            rsvp.Email,
 		Attending:        attending,
-		NumberOfGuests:   rsvp.AttendanceCount,
+		NumberOfGuests:   rsvp.GetTotalGuests(),
 		PlusOneName:      plusOneName,
+		Companions:       companionNames,
 		SubmittedAt:      rsvp.SubmittedAt,
 		ConfirmationSent: rsvp.ConfirmationSent,
+		Sessions:         sessionAttendance,
+	}
+	if h.editLinks != nil {
+		response.EditLink = h.editLinks.EditLink(c.Request.Context(), rsvp)
 	}
 
-	c.JSON(http.StatusCreated, response)
+	code := http.StatusCreated
+	if rsvp.UpdatedAt != nil {
+		// The guest answered before and updated their RSVP
+		code = http.StatusOK
+	}
+	c.JSON(code, response)
 }
 
 // convertToPublicResponse converts a wedding model to public response
@@ -278,15 +624,18 @@ func (h *PublicHandler) convertToPublicResponse(wedding *models.Wedding) *Public
 		rsvpDeadline = *wedding.RSVP.Deadline
 	}
 
+	// Default texts follow the wedding's language where there is a translation
+	lang := utils.NegotiateLanguage(wedding.ContentLanguage(), "", publicMessageLanguages)
+
 	return &PublicWeddingResponse{
 		Slug:            wedding.Slug,
 		Theme:           wedding.Theme.ThemeID,
 		GroomName:       wedding.Couple.Partner1.FullName,
 		BrideName:       wedding.Couple.Partner2.FullName,
-		GroomRole:       "Partner 1", // Default roles
-		BrideRole:       "Partner 2",
-		GroomBio:        wedding.Couple.Partner1.FirstName + " is one half of the happy couple.",
-		BrideBio:        wedding.Couple.Partner2.FirstName + " is the other half of the happy couple.",
+		GroomRole:       publicText(lang, "Partner 1"), // Default roles
+		BrideRole:       publicText(lang, "Partner 2"),
+		GroomBio:        publicText(lang, "%s is one half of the happy couple.", wedding.Couple.Partner1.FirstName),
+		BrideBio:        publicText(lang, "%s is the other half of the happy couple.", wedding.Couple.Partner2.FirstName),
 		GroomPhotoURL:   wedding.Couple.Partner1.PhotoURL,
 		BridePhotoURL:   wedding.Couple.Partner2.PhotoURL,
 		LoveStory:       wedding.Couple.Story,
@@ -297,13 +646,32 @@ func (h *PublicHandler) convertToPublicResponse(wedding *models.Wedding) *Public
 		ContactEmail:    "", // No contact email field in wedding model
 		SiteTitle:       wedding.Title,
 		MetaDescription: wedding.ShareMessage,
-		Events:          []models.EventDetails{wedding.Event},
+		Events:          wedding.EventSessions(),
+		CalendarLinks:   services.AddToCalendarLinks(wedding),
 		GalleryImages:   galleryImages,
 		AllowPlusOne:    wedding.RSVP.AllowPlusOne,
 		CollectDietary:  wedding.RSVP.CollectDietary,
 		CustomQuestions: wedding.RSVP.CustomQuestions,
+		MealOptions:     wedding.RSVP.MealOptions,
 		RSVPDeadline:    rsvpDeadline,
 		RSVPStatus:      h.getRSVPStatus(wedding),
+		Language:        wedding.ContentLanguage(),
+		Languages:       wedding.Languages(),
+		Labels:          wedding.Labels,
+		Music:           publicMusic(wedding.Music),
+	}
+}
+
+// publicMusic returns the music of a wedding to show its guests, nil without any
+func publicMusic(music models.MusicSettings) *PublicMusic {
+	if music.EmbedURL == "" && !music.SongRequests.Enabled {
+		return nil
+	}
+	return &PublicMusic{
+		Provider:     music.Provider,
+		EmbedURL:     music.EmbedURL,
+		Autoplay:     music.Autoplay && music.EmbedURL != "",
+		SongRequests: music.SongRequests.Enabled,
 	}
 }
 
@@ -358,10 +726,13 @@ func (h *PublicHandler) validatePublicRSVPRequest(req *PublicRSVPRequest) error
 		return errors.New("number of guests must be between 1 and 10")
 	}
 
-	// Validate plus one name if more than 1 guest
-	if req.NumberOfGuests > 1 && req.PlusOneName == "" {
+	// Validate companions are named if more than 1 guest
+	if req.NumberOfGuests > 1 && req.PlusOneName == "" && len(req.Companions) == 0 {
 		return errors.New("plus one name is required when bringing more than 1 guest")
 	}
+	if len(req.Companions) >= req.NumberOfGuests {
+		return errors.New("number of guests must include the guest and all companions")
+	}
 
 	// Validate dietary restrictions length
 	if len(req.DietaryRestrictions) > 500 {
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// publicMessageLanguages are the languages public endpoints answer in,
+// English first as the fallback
+var publicMessageLanguages = []string{"en", "id"}
+
+// publicMessages translates the messages of public endpoints, by language and
+// English message. Messages without a translation are sent in English.
+var publicMessages = map[string]map[string]string{
+	"id": {
+		"Slug is required":                                           "Slug wajib diisi",
+		"Wedding not found or not yet published":                     "Undangan tidak ditemukan atau belum diterbitkan",
+		"Failed to retrieve wedding":                                 "Gagal memuat undangan",
+		"Failed to render link preview":                              "Gagal membuat pratinjau tautan",
+		"This wedding is password protected":                         "Undangan ini dilindungi kata sandi",
+		"Preview link not found":                                     "Tautan pratinjau tidak ditemukan",
+		"This preview link has expired or was revoked":               "Tautan pratinjau ini sudah kedaluwarsa atau dicabut",
+		"RSVP period is not open":                                    "Masa konfirmasi kehadiran belum atau sudah tidak dibuka",
+		"An RSVP with this email already exists":                     "Konfirmasi kehadiran dengan email ini sudah ada",
+		"An RSVP for this guest already exists":                      "Konfirmasi kehadiran untuk tamu ini sudah ada",
+		"Failed to submit RSVP":                                      "Gagal mengirim konfirmasi kehadiran",
+		"name is required":                                           "Nama wajib diisi",
+		"name must be 100 characters or less":                        "Nama paling banyak 100 karakter",
+		"invalid email format":                                       "Format email tidak valid",
+		"invalid phone format":                                       "Format nomor telepon tidak valid",
+		"Partner 1":                                                  "Mempelai 1",
+		"Partner 2":                                                  "Mempelai 2",
+		"%s is one half of the happy couple.":                        "%s adalah salah satu mempelai yang berbahagia.",
+		"%s is the other half of the happy couple.":                  "%s adalah pasangan mempelai yang berbahagia.",
+		"number of guests must be between 1 and 10":                  "Jumlah tamu harus antara 1 dan 10",
+		"plus one name is required when bringing more than 1 guest":  "Nama pendamping wajib diisi jika membawa lebih dari 1 tamu",
+		"number of guests must include the guest and all companions": "Jumlah tamu harus mencakup tamu dan semua pendampingnya",
+		"dietary restrictions must be 500 characters or less":        "Pantangan makanan paling banyak 500 karakter",
+		"message must be 1000 characters or less":                    "Pesan paling banyak 1000 karakter",
+	},
+}
+
+// publicText returns a public message in the language, formatted with the
+// arguments when there are any
+func publicText(lang, message string, args ...interface{}) string {
+	if translation, ok := publicMessages[lang][message]; ok {
+		message = translation
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// messageLanguage negotiates the language of the messages sent to the client:
+// its lang query parameter, then its Accept-Language header
+func messageLanguage(c *gin.Context) string {
+	return utils.NegotiateLanguage(c.Query("lang"), c.GetHeader("Accept-Language"), publicMessageLanguages)
+}
+
+// publicError answers with an error message in the client's language
+func publicError(c *gin.Context, status int, message string) {
+	lang := messageLanguage(c)
+	c.Header("Content-Language", lang)
+	c.Header("Vary", "Accept-Language")
+	c.JSON(status, ErrorResponse{Error: publicText(lang, message)})
+}
+
+// localizeWedding returns the wedding in the language the client asked for
+// among the wedding's, falling back to the wedding's own language
+func localizeWedding(c *gin.Context, wedding *models.Wedding) *models.Wedding {
+	lang := utils.NegotiateLanguage(c.Query("lang"), c.GetHeader("Accept-Language"), wedding.Languages())
+	c.Header("Content-Language", lang)
+	c.Header("Vary", "Accept-Language")
+	return wedding.Localized(lang)
+}
+
+// localizedResponse converts the wedding to its public view in the client's language
+func (h *PublicHandler) localizedResponse(c *gin.Context, wedding *models.Wedding) *PublicWeddingResponse {
+	response := h.convertToPublicResponse(localizeWedding(c, wedding))
+	// A translated copy is in one language; the switcher offers all of them
+	response.Languages = wedding.Languages()
+	response.Branding = h.organizationBranding(c, wedding)
+	return response
+}
+
+// organizationBranding returns the branding of the wedding's organization. A
+// failure to load it only costs the page its branding.
+func (h *PublicHandler) organizationBranding(c *gin.Context, wedding *models.Wedding) *models.OrganizationBranding {
+	if h.branding == nil || wedding.TenantID == "" {
+		return nil
+	}
+	branding, err := h.branding.Branding(c.Request.Context(), wedding.TenantID)
+	if err != nil {
+		return nil
+	}
+	return branding
+}
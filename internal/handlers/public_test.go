@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,7 +12,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/services"
@@ -37,7 +37,7 @@ type MockRSVPServiceForPublic struct {
 	mock.Mock
 }
 
-func (m *MockRSVPServiceForPublic) SubmitRSVP(ctx context.Context, weddingID primitive.ObjectID, req services.SubmitRSVPRequest) (*models.RSVP, error) {
+func (m *MockRSVPServiceForPublic) SubmitRSVP(ctx context.Context, weddingID models.ID, req services.SubmitRSVPRequest) (*models.RSVP, error) {
 	args := m.Called(ctx, weddingID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -53,6 +53,9 @@ func setupPublicTestRouter(publicHandler *PublicHandler) *gin.Engine {
 	public := v1.Group("/public")
 	{
 		public.GET("/weddings/:slug", publicHandler.GetWeddingBySlug)
+		public.GET("/weddings/:slug/calendar.ics", publicHandler.GetWeddingCalendar)
+		public.GET("/weddings/:slug/og-image.png", publicHandler.GetWeddingOGImage)
+		public.GET("/weddings/:slug/share", publicHandler.GetWeddingSharePage)
 		public.POST("/weddings/:slug/rsvp", publicHandler.SubmitRSVP)
 	}
 
@@ -68,7 +71,7 @@ func TestPublicHandler_GetWeddingBySlug_Success(t *testing.T) {
 	router := setupPublicTestRouter(publicHandler)
 
 	wedding := &models.Wedding{
-		ID:     primitive.NewObjectID(),
+		ID:     models.NewID(),
 		Slug:   "john-jane-wedding",
 		Status: string(models.WeddingStatusPublished),
 		Couple: models.CoupleInfo{
@@ -130,6 +133,41 @@ func TestPublicHandler_GetWeddingBySlug_Success(t *testing.T) {
 	mockWeddingService.AssertExpectations(t)
 }
 
+// fakeBranding returns the branding of the tenants it holds
+type fakeBranding map[string]*models.OrganizationBranding
+
+func (f fakeBranding) Branding(ctx context.Context, tenantID string) (*models.OrganizationBranding, error) {
+	return f[tenantID], nil
+}
+
+func TestPublicHandler_GetWeddingBySlug_Branding(t *testing.T) {
+	mockWeddingService := new(MockWeddingServiceForPublic)
+	publicHandler := NewPublicHandler(mockWeddingService, new(MockRSVPServiceForPublic))
+	publicHandler.EnableBranding(fakeBranding{"acme": {DisplayName: "Acme Weddings", PrimaryColor: "#112233"}})
+	router := setupPublicTestRouter(publicHandler)
+
+	branded := &models.Wedding{ID: models.NewID(), Slug: "branded", TenantID: "acme", Status: string(models.WeddingStatusPublished)}
+	plain := &models.Wedding{ID: models.NewID(), Slug: "plain", Status: string(models.WeddingStatusPublished)}
+	mockWeddingService.On("GetWeddingBySlugForPublic", mock.Anything, "branded").Return(branded, nil)
+	mockWeddingService.On("GetWeddingBySlugForPublic", mock.Anything, "plain").Return(plain, nil)
+
+	get := func(slug string) PublicWeddingResponse {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/public/weddings/"+slug, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var response PublicWeddingResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	response := get("branded")
+	require.NotNil(t, response.Branding)
+	assert.Equal(t, "Acme Weddings", response.Branding.DisplayName)
+	assert.Equal(t, "#112233", response.Branding.PrimaryColor)
+
+	assert.Nil(t, get("plain").Branding)
+}
+
 func TestPublicHandler_GetWeddingBySlug_NotFound(t *testing.T) {
 	// Arrange
 	mockWeddingService := new(MockWeddingServiceForPublic)
@@ -138,7 +176,7 @@ func TestPublicHandler_GetWeddingBySlug_NotFound(t *testing.T) {
 
 	router := setupPublicTestRouter(publicHandler)
 
-	mockWeddingService.On("GetWeddingBySlugForPublic", mock.Anything, "nonexistent").Return(nil, errors.New("wedding not found"))
+	mockWeddingService.On("GetWeddingBySlugForPublic", mock.Anything, "nonexistent").Return(nil, services.ErrWeddingNotFound)
 
 	// Act
 	req, _ := http.NewRequest("GET", "/api/v1/public/weddings/nonexistent", nil)
@@ -164,7 +202,7 @@ func TestPublicHandler_SubmitRSVP_Success(t *testing.T) {
 
 	router := setupPublicTestRouter(publicHandler)
 
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 	wedding := &models.Wedding{
 		ID:     weddingID,
 		Slug:   "john-jane-wedding",
@@ -177,8 +215,8 @@ func TestPublicHandler_SubmitRSVP_Success(t *testing.T) {
 	}
 
 	mockWeddingService.On("GetWeddingBySlugForPublic", mock.Anything, "john-jane-wedding").Return(wedding, nil)
-	mockRSVPService.On("SubmitRSVP", mock.Anything, mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("services.SubmitRSVPRequest")).Return(&models.RSVP{
-		ID:              primitive.NewObjectID(),
+	mockRSVPService.On("SubmitRSVP", mock.Anything, mock.AnythingOfType("models.ID"), mock.AnythingOfType("services.SubmitRSVPRequest")).Return(&models.RSVP{
+		ID:              models.NewID(),
 		WeddingID:       wedding.ID,
 		FirstName:       "Alice",
 		LastName:        "Smith",
@@ -222,6 +260,42 @@ func TestPublicHandler_SubmitRSVP_Success(t *testing.T) {
 	mockRSVPService.AssertExpectations(t)
 }
 
+func TestPublicHandler_SubmitRSVP_Resubmitted(t *testing.T) {
+	mockWeddingService := new(MockWeddingServiceForPublic)
+	mockRSVPService := new(MockRSVPServiceForPublic)
+	router := setupPublicTestRouter(NewPublicHandler(mockWeddingService, mockRSVPService))
+
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		Slug:   "john-jane-wedding",
+		Status: string(models.WeddingStatusPublished),
+		RSVP: models.RSVPSettings{
+			Deadline: func() *time.Time { t := time.Now().AddDate(0, 3, 0); return &t }(),
+		},
+	}
+	mockWeddingService.On("GetWeddingBySlugForPublic", mock.Anything, "john-jane-wedding").Return(wedding, nil)
+	updatedAt := time.Now()
+	mockRSVPService.On("SubmitRSVP", mock.Anything, wedding.ID, mock.Anything).Return(&models.RSVP{
+		ID:              models.NewID(),
+		WeddingID:       wedding.ID,
+		FirstName:       "Alice",
+		LastName:        "Smith",
+		Status:          "attending",
+		AttendanceCount: 1,
+		SubmittedAt:     updatedAt.Add(-time.Hour),
+		UpdatedAt:       &updatedAt,
+	}, nil)
+
+	body, _ := json.Marshal(PublicRSVPRequest{Name: "Alice Smith", Email: "alice@example.com", Attending: true, NumberOfGuests: 1})
+	req, _ := http.NewRequest("POST", "/api/v1/public/weddings/john-jane-wedding/rsvp", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// The guest's earlier RSVP was updated
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
 func TestPublicHandler_SubmitRSVP_InvalidJSON(t *testing.T) {
 	// Arrange
 	mockWeddingService := new(MockWeddingServiceForPublic)
@@ -307,3 +381,315 @@ func TestPublicHandler_convertToPublicResponse(t *testing.T) {
 	assert.True(t, response.AllowPlusOne)
 	assert.True(t, response.CollectDietary)
 }
+
+func TestPublicHandler_SubmitRSVP_Sessions(t *testing.T) {
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		Slug:   "john-jane-wedding",
+		Status: string(models.WeddingStatusPublished),
+		Sessions: []models.EventSession{
+			{ID: "akad", EventDetails: models.EventDetails{Title: "Akad Nikah"}},
+			{ID: "reception", EventDetails: models.EventDetails{Title: "Reception"}},
+		},
+	}
+	matchSessions := mock.MatchedBy(func(req services.SubmitRSVPRequest) bool {
+		return assert.ObjectsAreEqual([]models.RSVPSessionResponse{
+			{SessionID: "akad", Status: "not-attending"},
+			{SessionID: "reception", Status: "attending"},
+		}, req.Sessions)
+	})
+	body := []byte(`{"name":"Alice Smith","email":"alice@example.com","attending":true,"number_of_guests":1,"sessions":{"akad":false,"reception":true}}`)
+
+	t.Run("answered per session", func(t *testing.T) {
+		mockWeddingService := new(MockWeddingServiceForPublic)
+		mockRSVPService := new(MockRSVPServiceForPublic)
+		mockWeddingService.On("GetWeddingBySlugForPublic", mock.Anything, "john-jane-wedding").Return(wedding, nil)
+		mockRSVPService.On("SubmitRSVP", mock.Anything, wedding.ID, matchSessions).Return(&models.RSVP{
+			ID:        models.NewID(),
+			WeddingID: wedding.ID,
+			FirstName: "Alice",
+			LastName:  "Smith",
+			Status:    "attending",
+			Sessions: []models.RSVPSessionResponse{
+				{SessionID: "akad", Status: "not-attending"},
+				{SessionID: "reception", Status: "attending"},
+			},
+		}, nil)
+
+		req, _ := http.NewRequest("POST", "/api/v1/public/weddings/john-jane-wedding/rsvp", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setupPublicTestRouter(NewPublicHandler(mockWeddingService, mockRSVPService)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response PublicRSVPResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, map[string]bool{"akad": false, "reception": true}, response.Sessions)
+		mockRSVPService.AssertExpectations(t)
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		mockWeddingService := new(MockWeddingServiceForPublic)
+		mockRSVPService := new(MockRSVPServiceForPublic)
+		mockWeddingService.On("GetWeddingBySlugForPublic", mock.Anything, "john-jane-wedding").Return(wedding, nil)
+		mockRSVPService.On("SubmitRSVP", mock.Anything, wedding.ID, mock.Anything).Return(nil, services.ErrInvalidRSVPSessions)
+
+		req, _ := http.NewRequest("POST", "/api/v1/public/weddings/john-jane-wedding/rsvp", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setupPublicTestRouter(NewPublicHandler(mockWeddingService, mockRSVPService)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPublicHandler_convertToPublicResponse_Sessions(t *testing.T) {
+	publicHandler := NewPublicHandler(new(MockWeddingServiceForPublic), new(MockRSVPServiceForPublic))
+	event := models.EventDetails{Title: "Wedding", VenueName: "Garden Pavilion"}
+
+	// Single-event weddings list their event as the only session
+	response := publicHandler.convertToPublicResponse(&models.Wedding{Event: event})
+	assert.Equal(t, []models.EventSession{{EventDetails: event}}, response.Events)
+
+	sessions := []models.EventSession{
+		{ID: "akad", EventDetails: models.EventDetails{Title: "Akad Nikah"}},
+		{ID: "reception", EventDetails: event},
+	}
+	response = publicHandler.convertToPublicResponse(&models.Wedding{Event: event, Sessions: sessions})
+	assert.Equal(t, sessions, response.Events)
+	require.Len(t, response.CalendarLinks, 2)
+	assert.Equal(t, "akad", response.CalendarLinks[0].SessionID)
+	assert.Contains(t, response.CalendarLinks[0].Google, "https://calendar.google.com/")
+}
+
+func TestPublicHandler_GetWeddingCalendar(t *testing.T) {
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		Slug:   "john-jane-wedding",
+		Title:  "John & Jane's Wedding",
+		Status: string(models.WeddingStatusPublished),
+		Event: models.EventDetails{
+			Title:     "Wedding",
+			Date:      time.Date(2024, 6, 15, 14, 0, 0, 0, time.UTC),
+			VenueName: "Garden Pavilion",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		wedding  *models.Wedding
+		err      error
+		expected int
+	}{
+		{"published", wedding, nil, http.StatusOK},
+		{"not published", nil, services.ErrWeddingNotPublished, http.StatusNotFound},
+		{"password protected", &models.Wedding{Slug: "secret", PasswordHash: "hash"}, nil, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWeddingService := new(MockWeddingServiceForPublic)
+			mockWeddingService.On("GetWeddingBySlugForPublic", mock.Anything, "john-jane-wedding").Return(tt.wedding, tt.err)
+
+			req, _ := http.NewRequest("GET", "/api/v1/public/weddings/john-jane-wedding/calendar.ics", nil)
+			w := httptest.NewRecorder()
+			setupPublicTestRouter(NewPublicHandler(mockWeddingService, new(MockRSVPServiceForPublic))).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			if tt.expected == http.StatusOK {
+				assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+				assert.Equal(t, `attachment; filename="john-jane-wedding.ics"`, w.Header().Get("Content-Disposition"))
+				assert.Contains(t, w.Body.String(), "DTSTART:20240615T140000Z\r\n")
+			}
+		})
+	}
+}
+
+func TestPublicHandler_OpenGraph(t *testing.T) {
+	wedding := &models.Wedding{
+		ID:           models.NewID(),
+		Slug:         "john-jane-wedding",
+		Title:        "John & Jane's Wedding",
+		ShareMessage: "Join us <3",
+		Status:       string(models.WeddingStatusPublished),
+	}
+	wedding.Couple.Partner1.FirstName = "John"
+	wedding.Couple.Partner2.FirstName = "Jane"
+	mockWeddingService := new(MockWeddingServiceForPublic)
+	mockWeddingService.On("GetWeddingBySlugForPublic", mock.Anything, "john-jane-wedding").Return(wedding, nil)
+	mockWeddingService.On("GetWeddingBySlugForPublic", mock.Anything, "secret").Return(&models.Wedding{Slug: "secret", PasswordHash: "hash"}, nil)
+
+	publicHandler := NewPublicHandler(mockWeddingService, new(MockRSVPServiceForPublic))
+	publicHandler.EnableOpenGraph(services.NewOpenGraphService(services.NewDiskImageCache(t.TempDir()), services.OpenGraphOptions{
+		SiteURL: "https://invite.example.com",
+		APIURL:  "https://api.example.com/api/v1",
+	}, zap.NewNop()))
+	router := setupPublicTestRouter(publicHandler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/public/weddings/john-jane-wedding", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var response PublicWeddingResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.OpenGraph)
+	assert.Equal(t, "https://invite.example.com/w/john-jane-wedding", response.OpenGraph.URL)
+	assert.Contains(t, response.OpenGraph.Image, "https://api.example.com/api/v1/public/weddings/slug/john-jane-wedding/og-image.png?v=")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/public/weddings/john-jane-wedding/og-image.png", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.Equal(t, "public, max-age=86400", w.Header().Get("Cache-Control"))
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.True(t, bytes.HasPrefix(w.Body.Bytes(), []byte("\x89PNG")))
+
+	req := httptest.NewRequest("GET", "/api/v1/public/weddings/john-jane-wedding/og-image.png", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/public/weddings/john-jane-wedding/share", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `<meta property="og:image" content="`+response.OpenGraph.Image)
+	assert.Contains(t, w.Body.String(), `<meta property="og:description" content="Join us &lt;3">`)
+
+	for _, path := range []string{"/api/v1/public/weddings/secret/og-image.png", "/api/v1/public/weddings/secret/share"} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, http.StatusForbidden, w.Code, path)
+	}
+}
+
+func TestPublicHandler_SubmitRSVP_Companions(t *testing.T) {
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		Slug:   "john-jane-wedding",
+		Status: string(models.WeddingStatusPublished),
+	}
+	matchCompanions := mock.MatchedBy(func(req services.SubmitRSVPRequest) bool {
+		return req.AttendanceCount == 1 && req.GuestToken == "guest-token" && len(req.PlusOnes) == 2 &&
+			req.PlusOnes[0].FirstName == "Bob" && req.PlusOnes[0].LastName == "Smith" &&
+			req.PlusOnes[1].Age != nil && *req.PlusOnes[1].Age == 6
+	})
+	body := []byte(`{"name":"Alice Smith","email":"alice@example.com","attending":true,"number_of_guests":3,"guest_token":"guest-token",` +
+		`"companions":[{"name":"Bob Smith"},{"name":"Tim","age":6}]}`)
+
+	t.Run("named companions", func(t *testing.T) {
+		mockWeddingService := new(MockWeddingServiceForPublic)
+		mockRSVPService := new(MockRSVPServiceForPublic)
+		mockWeddingService.On("GetWeddingBySlugForPublic", mock.Anything, "john-jane-wedding").Return(wedding, nil)
+		mockRSVPService.On("SubmitRSVP", mock.Anything, wedding.ID, matchCompanions).Return(&models.RSVP{
+			ID:              models.NewID(),
+			WeddingID:       wedding.ID,
+			FirstName:       "Alice",
+			LastName:        "Smith",
+			Status:          "attending",
+			AttendanceCount: 1,
+			PlusOneCount:    2,
+			PlusOnes:        []models.PlusOneInfo{{FirstName: "Bob", LastName: "Smith"}, {FirstName: "Tim"}},
+		}, nil)
+
+		req, _ := http.NewRequest("POST", "/api/v1/public/weddings/john-jane-wedding/rsvp", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setupPublicTestRouter(NewPublicHandler(mockWeddingService, mockRSVPService)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response PublicRSVPResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 3, response.NumberOfGuests)
+		assert.Equal(t, []string{"Bob Smith", "Tim"}, response.Companions)
+		mockRSVPService.AssertExpectations(t)
+	})
+
+	t.Run("more companions than the guest may bring", func(t *testing.T) {
+		mockWeddingService := new(MockWeddingServiceForPublic)
+		mockRSVPService := new(MockRSVPServiceForPublic)
+		mockWeddingService.On("GetWeddingBySlugForPublic", mock.Anything, "john-jane-wedding").Return(wedding, nil)
+		mockRSVPService.On("SubmitRSVP", mock.Anything, wedding.ID, mock.Anything).Return(nil, services.ErrTooManyPlusOnes)
+
+		req, _ := http.NewRequest("POST", "/api/v1/public/weddings/john-jane-wedding/rsvp", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setupPublicTestRouter(NewPublicHandler(mockWeddingService, mockRSVPService)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPublicHandler_GetWeddingBySlug_Language(t *testing.T) {
+	wedding := &models.Wedding{
+		ID:       models.NewID(),
+		Slug:     "anna-ben",
+		Status:   string(models.WeddingStatusPublished),
+		Title:    "Anna & Ben",
+		Language: "en",
+		Event:    models.EventDetails{VenueName: "Grand Hall"},
+		Translations: map[string]models.WeddingTranslation{
+			"id": {Title: "Pernikahan Anna & Ben", Event: models.EventTranslation{VenueName: "Aula Utama"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		accept   string
+		language string
+		title    string
+		venue    string
+	}{
+		{name: "own language", language: "en", title: "Anna & Ben", venue: "Grand Hall"},
+		{name: "lang parameter", query: "?lang=id", accept: "en", language: "id", title: "Pernikahan Anna & Ben", venue: "Aula Utama"},
+		{name: "accept language", accept: "id-ID,id;q=0.9", language: "id", title: "Pernikahan Anna & Ben", venue: "Aula Utama"},
+		{name: "no translation", query: "?lang=fr", language: "en", title: "Anna & Ben", venue: "Grand Hall"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weddings := new(MockWeddingServiceForPublic)
+			weddings.On("GetWeddingBySlugForPublic", mock.Anything, "anna-ben").Return(wedding, nil)
+			router := setupPublicTestRouter(NewPublicHandler(weddings, new(MockRSVPServiceForPublic)))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/public/weddings/anna-ben"+tt.query, nil)
+			req.Header.Set("Accept-Language", tt.accept)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.language, w.Header().Get("Content-Language"))
+
+			var response PublicWeddingResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.language, response.Language)
+			assert.Equal(t, []string{"en", "id"}, response.Languages)
+			assert.Equal(t, tt.title, response.SiteTitle)
+			assert.Equal(t, tt.venue, response.VenueName)
+		})
+	}
+}
+
+func TestPublicHandler_ErrorLanguage(t *testing.T) {
+	weddings := new(MockWeddingServiceForPublic)
+	weddings.On("GetWeddingBySlugForPublic", mock.Anything, "missing").Return(nil, services.ErrWeddingNotFound)
+	router := setupPublicTestRouter(NewPublicHandler(weddings, new(MockRSVPServiceForPublic)))
+
+	for accept, expected := range map[string]string{
+		"id-ID,id;q=0.9,en;q=0.8": "Undangan tidak ditemukan atau belum diterbitkan",
+		"en-GB":                   "Wedding not found or not yet published",
+		"ja":                      "Wedding not found or not yet published",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/public/weddings/missing", nil)
+		req.Header.Set("Accept-Language", accept)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, expected, response.Error, accept)
+	}
+}
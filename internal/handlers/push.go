@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// PushManager manages the Web Push subscriptions and notification preferences of a user
+type PushManager interface {
+	VAPIDPublicKey() (string, error)
+	Subscribe(ctx context.Context, userID models.ID, req services.PushSubscriptionRequest, userAgent string) (*models.PushSubscription, error)
+	ListSubscriptions(ctx context.Context, userID models.ID) ([]*models.PushSubscription, error)
+	Unsubscribe(ctx context.Context, userID, subscriptionID models.ID) error
+	GetPreferences(ctx context.Context, userID models.ID) (*models.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, userID models.ID, req services.NotificationPreferencesRequest) (*models.NotificationPreferences, error)
+}
+
+// VAPIDPublicKeyResponse is the application server key browsers subscribe with
+type VAPIDPublicKeyResponse struct {
+	PublicKey string `json:"public_key"`
+}
+
+// PushHandler serves the Web Push subscriptions and notification preferences of the authenticated account
+type PushHandler struct {
+	push PushManager
+}
+
+// NewPushHandler creates a new Web Push handler
+func NewPushHandler(push PushManager) *PushHandler {
+	return &PushHandler{push: push}
+}
+
+// GetVAPIDPublicKey godoc
+// @Summary Get the Web Push key
+// @Description Get the VAPID application server key to pass to pushManager.subscribe in the browser
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} VAPIDPublicKeyResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/users/push/public-key [get]
+func (h *PushHandler) GetVAPIDPublicKey(c *gin.Context) {
+	key, err := h.push.VAPIDPublicKey()
+	if err != nil {
+		h.handleError(c, err, "Failed to get Web Push key")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, VAPIDPublicKeyResponse{PublicKey: key})
+}
+
+// Subscribe godoc
+// @Summary Subscribe to push notifications
+// @Description Subscribe the browser to push notifications with the JSON of its PushSubscription. Subscribing the same browser again replaces its keys.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param subscription body services.PushSubscriptionRequest true "Browser push subscription"
+// @Success 201 {object} models.PushSubscription
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/users/push/subscriptions [post]
+func (h *PushHandler) Subscribe(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req services.PushSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	subscription, err := h.push.Subscribe(c.Request.Context(), userID, req, c.Request.UserAgent())
+	if err != nil {
+		h.handleError(c, err, "Failed to subscribe to push notifications")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, subscription)
+}
+
+// ListSubscriptions godoc
+// @Summary List push subscriptions
+// @Description List the browsers subscribed to push notifications for the current account, newest first
+// @Tags notifications
+// @Produce json
+// @Success 200 {array} models.PushSubscription
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/push/subscriptions [get]
+func (h *PushHandler) ListSubscriptions(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	subscriptions, err := h.push.ListSubscriptions(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list push subscriptions")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, subscriptions)
+}
+
+// Unsubscribe godoc
+// @Summary Unsubscribe from push notifications
+// @Description Stop pushing notifications to a browser
+// @Tags notifications
+// @Param id path string true "Subscription ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/push/subscriptions/{id} [delete]
+func (h *PushHandler) Unsubscribe(c *gin.Context) {
+	subscriptionID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	if err := h.push.Unsubscribe(c.Request.Context(), userID, subscriptionID); err != nil {
+		h.handleError(c, err, "Failed to unsubscribe from push notifications")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetPreferences godoc
+// @Summary Get notification preferences
+// @Description Get the events the current account gets no push notifications for
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} models.NotificationPreferences
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/notifications [get]
+func (h *PushHandler) GetPreferences(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	preferences, err := h.push.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get notification preferences")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, preferences)
+}
+
+// UpdatePreferences godoc
+// @Summary Update notification preferences
+// @Description Replace the events the current account gets no push notifications for: rsvp.attending, rsvp.declined, rsvp.maybe and rsvp.needs_review
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param preferences body services.NotificationPreferencesRequest true "Muted events"
+// @Success 200 {object} models.NotificationPreferences
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/notifications [put]
+func (h *PushHandler) UpdatePreferences(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req services.NotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	preferences, err := h.push.UpdatePreferences(c.Request.Context(), userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update notification preferences")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, preferences)
+}
+
+func (h *PushHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWebPushUnavailable):
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "Push notifications are not available")
+	case errors.Is(err, services.ErrPushSubscriptionNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Push subscription not found")
+	case errors.Is(err, services.ErrUserNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "User not found")
+	case errors.Is(err, services.ErrInvalidPushSubscription), errors.Is(err, services.ErrInvalidPushEvent):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockPushManager is a mock implementation of PushManager
+type MockPushManager struct {
+	mock.Mock
+}
+
+func (m *MockPushManager) VAPIDPublicKey() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPushManager) Subscribe(ctx context.Context, userID models.ID, req services.PushSubscriptionRequest, userAgent string) (*models.PushSubscription, error) {
+	args := m.Called(ctx, userID, req, userAgent)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PushSubscription), args.Error(1)
+}
+
+func (m *MockPushManager) ListSubscriptions(ctx context.Context, userID models.ID) ([]*models.PushSubscription, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PushSubscription), args.Error(1)
+}
+
+func (m *MockPushManager) Unsubscribe(ctx context.Context, userID, subscriptionID models.ID) error {
+	args := m.Called(ctx, userID, subscriptionID)
+	return args.Error(0)
+}
+
+func (m *MockPushManager) GetPreferences(ctx context.Context, userID models.ID) (*models.NotificationPreferences, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NotificationPreferences), args.Error(1)
+}
+
+func (m *MockPushManager) UpdatePreferences(ctx context.Context, userID models.ID, req services.NotificationPreferencesRequest) (*models.NotificationPreferences, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NotificationPreferences), args.Error(1)
+}
+
+func setupPushTestRouter(handler *PushHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	users := router.Group("/api/v1/users")
+	users.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	users.GET("/push/public-key", handler.GetVAPIDPublicKey)
+	users.GET("/push/subscriptions", handler.ListSubscriptions)
+	users.POST("/push/subscriptions", handler.Subscribe)
+	users.DELETE("/push/subscriptions/:id", handler.Unsubscribe)
+	users.GET("/notifications", handler.GetPreferences)
+	users.PUT("/notifications", handler.UpdatePreferences)
+
+	return router
+}
+
+func TestPushHandler_Subscribe(t *testing.T) {
+	userID := models.NewID()
+	valid := services.PushSubscriptionRequest{
+		Endpoint: "https://push.example.com/abc",
+		Keys:     services.PushSubscriptionKeys{P256dh: "BNcR", Auth: "tBHI"},
+	}
+	body := `{"endpoint":"https://push.example.com/abc","expirationTime":null,"keys":{"p256dh":"BNcR","auth":"tBHI"}}`
+
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		called   bool
+		expected int
+	}{
+		{"subscribed", body, nil, true, http.StatusCreated},
+		{"invalid keys", body, services.ErrInvalidPushSubscription, true, http.StatusBadRequest},
+		{"not configured", body, services.ErrWebPushUnavailable, true, http.StatusServiceUnavailable},
+		{"missing keys", `{"endpoint":"https://push.example.com/abc"}`, nil, false, http.StatusBadRequest},
+		{"invalid endpoint", `{"endpoint":"nope","keys":{"p256dh":"BNcR","auth":"tBHI"}}`, nil, false, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			push := new(MockPushManager)
+			if tt.called {
+				if tt.err != nil {
+					push.On("Subscribe", mock.Anything, userID, valid, "Firefox").Return(nil, tt.err)
+				} else {
+					push.On("Subscribe", mock.Anything, userID, valid, "Firefox").Return(&models.PushSubscription{ID: models.NewID()}, nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/push/subscriptions", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", "Firefox")
+			w := httptest.NewRecorder()
+			setupPushTestRouter(NewPushHandler(push), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			push.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPushHandler_Preferences(t *testing.T) {
+	userID := models.NewID()
+
+	t.Run("unknown event", func(t *testing.T) {
+		push := new(MockPushManager)
+		req := services.NotificationPreferencesRequest{PushMuted: []string{"wish.created"}}
+		push.On("UpdatePreferences", mock.Anything, userID, req).Return(nil, services.ErrInvalidPushEvent)
+
+		r := httptest.NewRequest(http.MethodPut, "/api/v1/users/notifications", bytes.NewBufferString(`{"push_muted":["wish.created"]}`))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setupPushTestRouter(NewPushHandler(push), userID).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unsubscribing another user's browser", func(t *testing.T) {
+		push := new(MockPushManager)
+		subscriptionID := models.NewID()
+		push.On("Unsubscribe", mock.Anything, userID, subscriptionID).Return(services.ErrPushSubscriptionNotFound)
+
+		r := httptest.NewRequest(http.MethodDelete, "/api/v1/users/push/subscriptions/"+subscriptionID.String(), nil)
+		w := httptest.NewRecorder()
+		setupPushTestRouter(NewPushHandler(push), userID).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,366 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// RegistryManager manages a wedding's gift registry and the gifts guests pledged
+type RegistryManager interface {
+	ListItems(ctx context.Context, weddingID, userID models.ID) ([]*models.RegistryItem, error)
+	CreateItem(ctx context.Context, weddingID, userID models.ID, req services.RegistryItemRequest) (*models.RegistryItem, error)
+	UpdateItem(ctx context.Context, weddingID, itemID, userID models.ID, req services.RegistryItemRequest) (*models.RegistryItem, error)
+	DeleteItem(ctx context.Context, weddingID, itemID, userID models.ID) error
+	ListPledges(ctx context.Context, weddingID, userID models.ID, status models.GiftPledgeStatus, page, pageSize int) ([]*models.GiftPledge, int64, error)
+	UpdatePledgeStatus(ctx context.Context, weddingID, pledgeID, userID models.ID, status models.GiftPledgeStatus) (*models.GiftPledge, error)
+	GetPublicRegistry(ctx context.Context, slug string) (*services.PublicRegistry, error)
+	PledgeGift(ctx context.Context, slug string, req services.GiftPledgeRequest) (*models.GiftPledge, error)
+}
+
+// RegistryHandler serves the gift registry and the gifts guests pledged
+type RegistryHandler struct {
+	registry RegistryManager
+}
+
+// NewRegistryHandler creates a new gift registry handler
+func NewRegistryHandler(registry RegistryManager) *RegistryHandler {
+	return &RegistryHandler{registry: registry}
+}
+
+// ListItems godoc
+// @Summary List registry items
+// @Description Get the bank accounts, e-wallets and registry links of a wedding (wedding editors only)
+// @Tags registry
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {array} models.RegistryItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/registry [get]
+func (h *RegistryHandler) ListItems(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.registry.ListItems(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get registry")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, items)
+}
+
+// CreateItem godoc
+// @Summary Add a registry item
+// @Description Add a bank account, e-wallet or external registry link to a wedding (wedding editors only)
+// @Tags registry
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param item body services.RegistryItemRequest true "Registry item"
+// @Success 201 {object} models.RegistryItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/registry [post]
+func (h *RegistryHandler) CreateItem(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindRegistryItem(c)
+	if !ok {
+		return
+	}
+
+	item, err := h.registry.CreateItem(c.Request.Context(), weddingID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create registry item")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, item)
+}
+
+// UpdateItem godoc
+// @Summary Update a registry item
+// @Description Replace the details of a registry item (wedding editors only)
+// @Tags registry
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param item_id path string true "Registry item ID"
+// @Param item body services.RegistryItemRequest true "Registry item"
+// @Success 200 {object} models.RegistryItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/registry/{item_id} [put]
+func (h *RegistryHandler) UpdateItem(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	itemID, err := models.ParseID(c.Param("item_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid registry item ID")
+		return
+	}
+
+	req, ok := bindRegistryItem(c)
+	if !ok {
+		return
+	}
+
+	item, err := h.registry.UpdateItem(c.Request.Context(), weddingID, itemID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update registry item")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, item)
+}
+
+// DeleteItem godoc
+// @Summary Delete a registry item
+// @Description Remove an item from a wedding's registry. Pledges made through it are kept. (wedding editors only)
+// @Tags registry
+// @Param id path string true "Wedding ID"
+// @Param item_id path string true "Registry item ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/registry/{item_id} [delete]
+func (h *RegistryHandler) DeleteItem(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	itemID, err := models.ParseID(c.Param("item_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid registry item ID")
+		return
+	}
+
+	if err := h.registry.DeleteItem(c.Request.Context(), weddingID, itemID, userID); err != nil {
+		h.handleError(c, err, "Failed to delete registry item")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListPledges godoc
+// @Summary List gift pledges
+// @Description Get the gifts guests reported having sent, newest first (wedding editors only)
+// @Tags registry
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param status query string false "Filter by status" Enums(pledged, received, thanked)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PaginatedResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/registry/pledges [get]
+func (h *RegistryHandler) ListPledges(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+	status := models.GiftPledgeStatus(c.Query("status"))
+
+	pledges, total, err := h.registry.ListPledges(c.Request.Context(), weddingID, userID, status, page, pageSize)
+	if err != nil {
+		h.handleError(c, err, "Failed to get gift pledges")
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, pledges, int64(len(pledges)), total, page, pageSize)
+}
+
+// UpdatePledgeStatus godoc
+// @Summary Update a gift pledge
+// @Description Mark a pledged gift as received or the guest as thanked (wedding editors only)
+// @Tags registry
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param pledge_id path string true "Gift pledge ID"
+// @Param status body services.GiftPledgeStatusRequest true "New status"
+// @Success 200 {object} models.GiftPledge
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/registry/pledges/{pledge_id} [put]
+func (h *RegistryHandler) UpdatePledgeStatus(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	pledgeID, err := models.ParseID(c.Param("pledge_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid gift pledge ID")
+		return
+	}
+
+	var req services.GiftPledgeStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	pledge, err := h.registry.UpdatePledgeStatus(c.Request.Context(), weddingID, pledgeID, userID, req.Status)
+	if err != nil {
+		h.handleError(c, err, "Failed to update gift pledge")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, pledge)
+}
+
+// GetPublicRegistry godoc
+// @Summary Get a wedding's gift registry
+// @Description Get where guests can send gifts to a published wedding, and whether they can record the gift they sent
+// @Tags registry
+// @Produce json
+// @Param slug path string true "Wedding slug"
+// @Success 200 {object} services.PublicRegistry
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/public/weddings/slug/{slug}/registry [get]
+func (h *RegistryHandler) GetPublicRegistry(c *gin.Context) {
+	registry, err := h.registry.GetPublicRegistry(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		h.handleError(c, err, "Failed to get registry")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, registry)
+}
+
+// PledgeGift godoc
+// @Summary Record a gift
+// @Description Let a guest record the gift they sent to a published wedding that accepts pledges
+// @Tags registry
+// @Accept json
+// @Produce json
+// @Param slug path string true "Wedding slug"
+// @Param pledge body services.GiftPledgeRequest true "Gift pledge"
+// @Success 201 {object} models.GiftPledge
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/public/weddings/slug/{slug}/registry/pledges [post]
+func (h *RegistryHandler) PledgeGift(c *gin.Context) {
+	var req services.GiftPledgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.IP = c.ClientIP()
+
+	pledge, err := h.registry.PledgeGift(c.Request.Context(), c.Param("slug"), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to record gift")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, pledge)
+}
+
+// parseWeddingRequest reads the wedding and the authenticated user of a registry request
+func (h *RegistryHandler) parseWeddingRequest(c *gin.Context) (models.ID, models.ID, bool) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+
+	return weddingID, userID, true
+}
+
+// bindRegistryItem reads and validates a registry item from the request body
+func bindRegistryItem(c *gin.Context) (services.RegistryItemRequest, bool) {
+	var req services.RegistryItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return req, false
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return req, false
+	}
+
+	return req, true
+}
+
+func (h *RegistryHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrRegistryItemNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Registry item not found")
+	case errors.Is(err, services.ErrGiftPledgeNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Gift pledge not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to manage this wedding's registry")
+	case errors.Is(err, services.ErrGiftPledgesDisabled):
+		utils.ErrorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrInvalidRegistryItem), errors.Is(err, services.ErrInvalidGiftPledge):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrRegistryFull):
+		utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
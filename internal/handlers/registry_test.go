@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockRegistryManager is a mock implementation of RegistryManager
+type MockRegistryManager struct {
+	mock.Mock
+}
+
+func (m *MockRegistryManager) ListItems(ctx context.Context, weddingID, userID models.ID) ([]*models.RegistryItem, error) {
+	args := m.Called(ctx, weddingID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.RegistryItem), args.Error(1)
+}
+
+func (m *MockRegistryManager) CreateItem(ctx context.Context, weddingID, userID models.ID, req services.RegistryItemRequest) (*models.RegistryItem, error) {
+	args := m.Called(ctx, weddingID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RegistryItem), args.Error(1)
+}
+
+func (m *MockRegistryManager) UpdateItem(ctx context.Context, weddingID, itemID, userID models.ID, req services.RegistryItemRequest) (*models.RegistryItem, error) {
+	args := m.Called(ctx, weddingID, itemID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RegistryItem), args.Error(1)
+}
+
+func (m *MockRegistryManager) DeleteItem(ctx context.Context, weddingID, itemID, userID models.ID) error {
+	args := m.Called(ctx, weddingID, itemID, userID)
+	return args.Error(0)
+}
+
+func (m *MockRegistryManager) ListPledges(ctx context.Context, weddingID, userID models.ID, status models.GiftPledgeStatus, page, pageSize int) ([]*models.GiftPledge, int64, error) {
+	args := m.Called(ctx, weddingID, userID, status, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*models.GiftPledge), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRegistryManager) UpdatePledgeStatus(ctx context.Context, weddingID, pledgeID, userID models.ID, status models.GiftPledgeStatus) (*models.GiftPledge, error) {
+	args := m.Called(ctx, weddingID, pledgeID, userID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GiftPledge), args.Error(1)
+}
+
+func (m *MockRegistryManager) GetPublicRegistry(ctx context.Context, slug string) (*services.PublicRegistry, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.PublicRegistry), args.Error(1)
+}
+
+func (m *MockRegistryManager) PledgeGift(ctx context.Context, slug string, req services.GiftPledgeRequest) (*models.GiftPledge, error) {
+	args := m.Called(ctx, slug, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GiftPledge), args.Error(1)
+}
+
+func setupRegistryTestRouter(handler *RegistryHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	public := router.Group("/api/v1/public/weddings/slug/:slug/registry")
+	public.GET("", handler.GetPublicRegistry)
+	public.POST("/pledges", handler.PledgeGift)
+
+	protected := router.Group("/api/v1/weddings/:id/registry")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	protected.GET("", handler.ListItems)
+	protected.POST("", handler.CreateItem)
+	protected.PUT("/:item_id", handler.UpdateItem)
+	protected.DELETE("/:item_id", handler.DeleteItem)
+	protected.GET("/pledges", handler.ListPledges)
+	protected.PUT("/pledges/:pledge_id", handler.UpdatePledgeStatus)
+
+	return router
+}
+
+func TestRegistryHandler_CreateItem(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	valid := services.RegistryItemRequest{
+		Type:          models.RegistryBankAccount,
+		Label:         "Wedding fund",
+		Provider:      "BCA",
+		AccountName:   "Jane Doe",
+		AccountNumber: "1234567890",
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"created", nil, http.StatusCreated},
+		{"not an editor", services.ErrUnauthorized, http.StatusForbidden},
+		{"missing details", services.ErrInvalidRegistryItem, http.StatusBadRequest},
+		{"registry full", services.ErrRegistryFull, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := new(MockRegistryManager)
+			if tt.err != nil {
+				registry.On("CreateItem", mock.Anything, weddingID, userID, valid).Return(nil, tt.err)
+			} else {
+				registry.On("CreateItem", mock.Anything, weddingID, userID, valid).Return(&models.RegistryItem{ID: models.NewID()}, nil)
+			}
+
+			body, err := json.Marshal(valid)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/weddings/"+weddingID.String()+"/registry", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupRegistryTestRouter(NewRegistryHandler(registry), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			registry.AssertExpectations(t)
+		})
+	}
+
+	t.Run("unknown type", func(t *testing.T) {
+		registry := new(MockRegistryManager)
+		body := []byte(`{"type":"cheque","label":"Cheque"}`)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/weddings/"+weddingID.String()+"/registry", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setupRegistryTestRouter(NewRegistryHandler(registry), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		registry.AssertNotCalled(t, "CreateItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestRegistryHandler_UpdatePledgeStatus(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	pledgeID := models.NewID()
+	path := "/api/v1/weddings/" + weddingID.String() + "/registry/pledges/" + pledgeID.String()
+
+	t.Run("thanked", func(t *testing.T) {
+		registry := new(MockRegistryManager)
+		registry.On("UpdatePledgeStatus", mock.Anything, weddingID, pledgeID, userID, models.GiftThanked).
+			Return(&models.GiftPledge{ID: pledgeID, Status: models.GiftThanked}, nil)
+
+		req := httptest.NewRequest(http.MethodPut, path, bytes.NewBufferString(`{"status":"thanked"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setupRegistryTestRouter(NewRegistryHandler(registry), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"thanked"`)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		registry := new(MockRegistryManager)
+
+		req := httptest.NewRequest(http.MethodPut, path, bytes.NewBufferString(`{"status":"lost"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setupRegistryTestRouter(NewRegistryHandler(registry), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		registry.AssertNotCalled(t, "UpdatePledgeStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestRegistryHandler_PledgeGift(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"recorded", nil, http.StatusCreated},
+		{"pledges disabled", services.ErrGiftPledgesDisabled, http.StatusForbidden},
+		{"unknown wedding", services.ErrWeddingNotFound, http.StatusNotFound},
+		{"item of another wedding", services.ErrRegistryItemNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := new(MockRegistryManager)
+			matchIP := mock.MatchedBy(func(req services.GiftPledgeRequest) bool {
+				return req.GuestName == "Alice" && req.IP != ""
+			})
+			if tt.err != nil {
+				registry.On("PledgeGift", mock.Anything, "jane-john", matchIP).Return(nil, tt.err)
+			} else {
+				registry.On("PledgeGift", mock.Anything, "jane-john", matchIP).Return(&models.GiftPledge{GuestName: "Alice", PledgerIP: "192.0.2.1"}, nil)
+			}
+
+			body := []byte(`{"guest_name":"Alice","amount":50000000,"currency":"IDR"}`)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/public/weddings/slug/jane-john/registry/pledges", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupRegistryTestRouter(NewRegistryHandler(registry), models.NilID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			assert.NotContains(t, w.Body.String(), "192.0.2.1")
+			registry.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// ReminderManager schedules RSVP reminder campaigns and reports on them
+type ReminderManager interface {
+	CreateCampaign(ctx context.Context, weddingID, userID models.ID, req services.CreateReminderCampaignRequest) (*models.ReminderCampaign, error)
+	ListCampaigns(ctx context.Context, weddingID, userID models.ID) ([]*services.ReminderCampaignReport, error)
+	GetCampaignReport(ctx context.Context, weddingID, campaignID, userID models.ID) (*services.ReminderCampaignReport, error)
+	CancelCampaign(ctx context.Context, weddingID, campaignID, userID models.ID) error
+	TrackOpen(ctx context.Context, deliveryID models.ID) error
+}
+
+// ReminderHandler serves the RSVP reminder campaigns of a wedding
+type ReminderHandler struct {
+	reminders ReminderManager
+}
+
+// NewReminderHandler creates a new reminder handler
+func NewReminderHandler(reminders ReminderManager) *ReminderHandler {
+	return &ReminderHandler{reminders: reminders}
+}
+
+// trackingPixel is a transparent 1x1 GIF
+var trackingPixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// CreateReminderCampaignRequest schedules a reminder to the guests who have not RSVP'd yet
+type CreateReminderCampaignRequest struct {
+	Channel models.ReminderChannel `json:"channel" binding:"required"`
+	Subject string                 `json:"subject" binding:"max=200"`
+	// Template placeholders: {first_name}, {last_name}, {wedding_title}, {rsvp_link}, {rsvp_deadline}
+	Template string     `json:"template" binding:"required"`
+	SendAt   *time.Time `json:"send_at"`
+}
+
+// CreateCampaign godoc
+// @Summary Schedule an RSVP reminder
+// @Description Schedule a reminder by email or WhatsApp to every guest whose RSVP is still pending. Without send_at the reminder goes out right away.
+// @Tags Guests
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body CreateReminderCampaignRequest true "Reminder campaign"
+// @Success 201 {object} models.ReminderCampaign
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /weddings/{id}/reminders [post]
+func (h *ReminderHandler) CreateCampaign(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var req CreateReminderCampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	campaign, err := h.reminders.CreateCampaign(c.Request.Context(), weddingID, userID, services.CreateReminderCampaignRequest{
+		Channel:  req.Channel,
+		Subject:  req.Subject,
+		Template: req.Template,
+		SendAt:   req.SendAt,
+	})
+	if err != nil {
+		h.handleError(c, err, "Failed to schedule reminder")
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.APIResponse{
+		Success: true,
+		Message: "Reminder scheduled",
+		Data:    campaign,
+	})
+}
+
+// ListCampaigns godoc
+// @Summary List RSVP reminders
+// @Description List the reminder campaigns of a wedding with the opens and responses attributed to each
+// @Tags Guests
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {array} services.ReminderCampaignReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /weddings/{id}/reminders [get]
+func (h *ReminderHandler) ListCampaigns(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	reports, err := h.reminders.ListCampaigns(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list reminders")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.APIResponse{
+		Success: true,
+		Message: "Reminders retrieved successfully",
+		Data:    reports,
+	})
+}
+
+// GetCampaign godoc
+// @Summary Get an RSVP reminder report
+// @Description Get a reminder campaign with how many reminders were sent, opened and answered with an RSVP
+// @Tags Guests
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param campaign_id path string true "Campaign ID"
+// @Success 200 {object} services.ReminderCampaignReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /weddings/{id}/reminders/{campaign_id} [get]
+func (h *ReminderHandler) GetCampaign(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+	campaignID, ok := h.parseCampaignID(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reminders.GetCampaignReport(c.Request.Context(), weddingID, campaignID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get reminder")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.APIResponse{
+		Success: true,
+		Message: "Reminder retrieved successfully",
+		Data:    report,
+	})
+}
+
+// CancelCampaign godoc
+// @Summary Cancel an RSVP reminder
+// @Description Cancel a reminder campaign that has not been sent yet
+// @Tags Guests
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param campaign_id path string true "Campaign ID"
+// @Success 200 {object} utils.APIResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /weddings/{id}/reminders/{campaign_id} [delete]
+func (h *ReminderHandler) CancelCampaign(c *gin.Context) {
+	weddingID, userID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+	campaignID, ok := h.parseCampaignID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.reminders.CancelCampaign(c.Request.Context(), weddingID, campaignID, userID); err != nil {
+		h.handleError(c, err, "Failed to cancel reminder")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.APIResponse{
+		Success: true,
+		Message: "Reminder cancelled",
+	})
+}
+
+// TrackOpen godoc
+// @Summary Track a reminder email open
+// @Description Tracking image embedded in reminder emails. Always answers with a transparent GIF so mail clients never show a broken image.
+// @Tags Public
+// @Produce image/gif
+// @Param delivery_id path string true "Reminder delivery ID"
+// @Success 200 {file} binary
+// @Router /public/reminders/{delivery_id}/open [get]
+func (h *ReminderHandler) TrackOpen(c *gin.Context) {
+	if deliveryID, err := models.ParseID(c.Param("delivery_id")); err == nil {
+		// A failed open is not worth an error to the mail client
+		_ = h.reminders.TrackOpen(c.Request.Context(), deliveryID)
+	}
+
+	c.Header("Cache-Control", "no-store, no-cache, must-revalidate")
+	c.Data(http.StatusOK, "image/gif", trackingPixel)
+}
+
+// parseRequest reads the wedding ID and the authenticated user, writing the error response on failure
+func (h *ReminderHandler) parseRequest(c *gin.Context) (weddingID, userID models.ID, ok bool) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return weddingID, userID, false
+	}
+
+	userID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return weddingID, userID, false
+	}
+
+	return weddingID, userID, true
+}
+
+func (h *ReminderHandler) parseCampaignID(c *gin.Context) (models.ID, bool) {
+	campaignID, err := models.ParseID(c.Param("campaign_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid campaign ID")
+		return campaignID, false
+	}
+	return campaignID, true
+}
+
+func (h *ReminderHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidReminderChannel),
+		errors.Is(err, services.ErrInvalidReminderTemplate),
+		errors.Is(err, services.ErrReminderInPast):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrReminderCampaignNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Reminder not found")
+	case errors.Is(err, services.ErrReminderCampaignStarted):
+		utils.ErrorResponse(c, http.StatusConflict, "Reminder has already been sent")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to manage reminders of this wedding")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
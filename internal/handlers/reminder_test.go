@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockReminderManager records the last request and returns a fixed error
+type MockReminderManager struct {
+	err     error
+	lastReq services.CreateReminderCampaignRequest
+	opened  []models.ID
+}
+
+func (m *MockReminderManager) CreateCampaign(ctx context.Context, weddingID, userID models.ID, req services.CreateReminderCampaignRequest) (*models.ReminderCampaign, error) {
+	m.lastReq = req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.ReminderCampaign{ID: models.NewID(), WeddingID: weddingID, Channel: req.Channel, Status: models.ReminderCampaignScheduled}, nil
+}
+
+func (m *MockReminderManager) ListCampaigns(ctx context.Context, weddingID, userID models.ID) ([]*services.ReminderCampaignReport, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*services.ReminderCampaignReport{{
+		ReminderCampaign: &models.ReminderCampaign{ID: models.NewID(), WeddingID: weddingID},
+		Stats:            models.ReminderDeliveryStats{Sent: 4, Responded: 1},
+		ResponseRate:     25,
+	}}, nil
+}
+
+func (m *MockReminderManager) GetCampaignReport(ctx context.Context, weddingID, campaignID, userID models.ID) (*services.ReminderCampaignReport, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &services.ReminderCampaignReport{ReminderCampaign: &models.ReminderCampaign{ID: campaignID, WeddingID: weddingID}}, nil
+}
+
+func (m *MockReminderManager) CancelCampaign(ctx context.Context, weddingID, campaignID, userID models.ID) error {
+	return m.err
+}
+
+func (m *MockReminderManager) TrackOpen(ctx context.Context, deliveryID models.ID) error {
+	m.opened = append(m.opened, deliveryID)
+	return m.err
+}
+
+func setupReminderRouter(manager ReminderManager) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", models.NewID().String())
+		c.Next()
+	})
+	handler := NewReminderHandler(manager)
+	router.POST("/weddings/:id/reminders", handler.CreateCampaign)
+	router.GET("/weddings/:id/reminders", handler.ListCampaigns)
+	router.GET("/weddings/:id/reminders/:campaign_id", handler.GetCampaign)
+	router.DELETE("/weddings/:id/reminders/:campaign_id", handler.CancelCampaign)
+	router.GET("/public/reminders/:delivery_id/open", handler.TrackOpen)
+	return router
+}
+
+func remindersPath() string {
+	return "/weddings/" + models.NewID().String() + "/reminders"
+}
+
+func TestReminderHandler_CreateCampaign(t *testing.T) {
+	post := func(router *gin.Engine, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, remindersPath(), bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		manager := &MockReminderManager{}
+		router := setupReminderRouter(manager)
+
+		w := post(router, `{"channel":"whatsapp","template":"Hi {first_name}","send_at":"2030-01-02T10:00:00Z"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, models.ReminderChannelWhatsApp, manager.lastReq.Channel)
+		assert.Equal(t, "Hi {first_name}", manager.lastReq.Template)
+		require.NotNil(t, manager.lastReq.SendAt)
+		assert.Equal(t, 2030, manager.lastReq.SendAt.Year())
+	})
+
+	t.Run("Error - missing template", func(t *testing.T) {
+		router := setupReminderRouter(&MockReminderManager{})
+
+		w := post(router, `{"channel":"email"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Error - send date in the past", func(t *testing.T) {
+		router := setupReminderRouter(&MockReminderManager{err: services.ErrReminderInPast})
+
+		w := post(router, `{"channel":"email","template":"Hi"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Error - not a manager", func(t *testing.T) {
+		router := setupReminderRouter(&MockReminderManager{err: services.ErrUnauthorized})
+
+		w := post(router, `{"channel":"email","template":"Hi"}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestReminderHandler_Reports(t *testing.T) {
+	t.Run("List", func(t *testing.T) {
+		router := setupReminderRouter(&MockReminderManager{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, remindersPath(), nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"response_rate":25`)
+	})
+
+	t.Run("Error - invalid campaign ID", func(t *testing.T) {
+		router := setupReminderRouter(&MockReminderManager{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, remindersPath()+"/nope", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Error - campaign not found", func(t *testing.T) {
+		router := setupReminderRouter(&MockReminderManager{err: services.ErrReminderCampaignNotFound})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, remindersPath()+"/"+models.NewID().String(), nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestReminderHandler_CancelCampaign(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		router := setupReminderRouter(&MockReminderManager{})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, remindersPath()+"/"+models.NewID().String(), nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Error - already sent", func(t *testing.T) {
+		router := setupReminderRouter(&MockReminderManager{err: services.ErrReminderCampaignStarted})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, remindersPath()+"/"+models.NewID().String(), nil))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestReminderHandler_TrackOpen(t *testing.T) {
+	manager := &MockReminderManager{}
+	router := setupReminderRouter(manager)
+	deliveryID := models.NewID()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/public/reminders/"+deliveryID.String()+"/open", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/gif", w.Header().Get("Content-Type"))
+	assert.Equal(t, trackingPixel, w.Body.Bytes())
+	assert.Equal(t, []models.ID{deliveryID}, manager.opened)
+
+	// Unknown or malformed links still get an image
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/public/reminders/nope/open", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, manager.opened, 1)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// RequestLogLookup finds request logs recorded by the logging middleware
+type RequestLogLookup interface {
+	FindByRequestID(requestID string) []models.RequestLogEntry
+}
+
+// RequestTraceHandler exposes request ID lookups for debugging tracking discrepancies
+type RequestTraceHandler struct {
+	analyticsService services.AnalyticsService
+	logs             RequestLogLookup
+}
+
+// NewRequestTraceHandler creates a new request trace handler. logs may be nil
+// when request logs are not buffered.
+func NewRequestTraceHandler(analyticsService services.AnalyticsService, logs RequestLogLookup) *RequestTraceHandler {
+	return &RequestTraceHandler{
+		analyticsService: analyticsService,
+		logs:             logs,
+	}
+}
+
+// GetRequestTrace finds analytics events and logs for a request ID
+// @Summary Trace a request
+// @Description Find all analytics events and buffered request logs for a request ID (admin only)
+// @Tags Analytics
+// @Param request_id path string true "Request ID"
+// @Success 200 {object} gin.H{data=models.RequestTrace}
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/requests/{request_id}/trace [get]
+func (h *RequestTraceHandler) GetRequestTrace(c *gin.Context) {
+	isAdmin, exists := c.Get("is_admin")
+	if !exists || !isAdmin.(bool) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Admin access required"})
+		return
+	}
+
+	requestID := c.Param("request_id")
+	if requestID == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Request ID is required"})
+		return
+	}
+
+	trace, err := h.analyticsService.GetRequestTrace(c.Request.Context(), requestID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to trace request"})
+		return
+	}
+
+	if h.logs != nil {
+		if logs := h.logs.FindByRequestID(requestID); logs != nil {
+			trace.Logs = logs
+		}
+	}
+
+	if len(trace.PageViews) == 0 && len(trace.Conversions) == 0 && len(trace.Logs) == 0 {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "No events or logs found for request ID"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": trace})
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+type stubRequestLogLookup map[string][]models.RequestLogEntry
+
+func (s stubRequestLogLookup) FindByRequestID(requestID string) []models.RequestLogEntry {
+	return s[requestID]
+}
+
+func setupRequestTraceRouter(isAdmin bool, logs RequestLogLookup) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewRequestTraceHandler(NewMockAnalyticsService(), logs)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("is_admin", isAdmin)
+		c.Next()
+	})
+	router.GET("/admin/requests/:request_id/trace", handler.GetRequestTrace)
+	return router
+}
+
+func TestRequestTraceHandler_GetRequestTrace(t *testing.T) {
+	logs := stubRequestLogLookup{
+		"req-with-events": {{RequestID: "req-with-events", Method: "POST", Path: "/api/v1/analytics/track/page-view", Status: 201}},
+		"req-logs-only":   {{RequestID: "req-logs-only", Method: "GET", Path: "/health", Status: 200}},
+	}
+
+	t.Run("Success - events and logs", func(t *testing.T) {
+		router := setupRequestTraceRouter(true, logs)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/admin/requests/req-with-events/trace", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data models.RequestTrace `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Data.PageViews, 1)
+		assert.Len(t, response.Data.Logs, 1)
+	})
+
+	t.Run("Success - logs only", func(t *testing.T) {
+		router := setupRequestTraceRouter(true, logs)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/admin/requests/req-logs-only/trace", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		router := setupRequestTraceRouter(true, nil)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/admin/requests/unknown/trace", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Forbidden for non-admin", func(t *testing.T) {
+		router := setupRequestTraceRouter(false, logs)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/admin/requests/req-with-events/trace", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// RetentionManager reports the retention policy and manages its per-wedding overrides
+type RetentionManager interface {
+	Policies() []models.RetentionPolicy
+	ListOverrides(ctx context.Context) ([]*models.RetentionOverride, error)
+	GetOverride(ctx context.Context, weddingID models.ID) (*models.RetentionOverride, error)
+	SetOverride(ctx context.Context, weddingID models.ID, days map[string]int) (*models.RetentionOverride, error)
+	ClearOverride(ctx context.Context, weddingID models.ID) error
+	Run(ctx context.Context, dryRun bool) (*models.RetentionReport, error)
+}
+
+// RetentionPolicyResponse is the platform's retention policy with the weddings overriding it
+type RetentionPolicyResponse struct {
+	Policies  []models.RetentionPolicy    `json:"policies"`
+	Overrides []*models.RetentionOverride `json:"overrides"`
+}
+
+// SetRetentionOverrideRequest sets how many days the data of a wedding is kept,
+// by data type: page_views, rsvp_events, conversions or audit_logs
+type SetRetentionOverrideRequest struct {
+	Days map[string]int `json:"days" validate:"required"`
+}
+
+// RetentionHandler lets admins review the retention policy and shorten it for single weddings
+type RetentionHandler struct {
+	retention RetentionManager
+}
+
+// NewRetentionHandler creates a new retention handler
+func NewRetentionHandler(retention RetentionManager) *RetentionHandler {
+	return &RetentionHandler{retention: retention}
+}
+
+// GetPolicy godoc
+// @Summary Get the retention policy
+// @Description Get how many days each kind of data is kept, 0 meaning forever, and the weddings keeping theirs for less (admin only)
+// @Tags admin
+// @Produce json
+// @Success 200 {object} utils.APIResponse{data=RetentionPolicyResponse}
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/retention [get]
+func (h *RetentionHandler) GetPolicy(c *gin.Context) {
+	overrides, err := h.retention.ListOverrides(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err, "Failed to list retention overrides")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, RetentionPolicyResponse{
+		Policies:  h.retention.Policies(),
+		Overrides: overrides,
+	})
+}
+
+// GetReport godoc
+// @Summary Preview a retention run
+// @Description Count the data a retention run would delete now, without deleting anything (admin only)
+// @Tags admin
+// @Produce json
+// @Success 200 {object} utils.APIResponse{data=models.RetentionReport}
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/retention/report [get]
+func (h *RetentionHandler) GetReport(c *gin.Context) {
+	report, err := h.retention.Run(c.Request.Context(), true)
+	if err != nil {
+		h.handleError(c, err, "Failed to preview retention run")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, report)
+}
+
+// GetWeddingOverride godoc
+// @Summary Get a wedding's retention override
+// @Description Get the shorter retention periods of one wedding (admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {object} utils.APIResponse{data=models.RetentionOverride}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/weddings/{id}/retention [get]
+func (h *RetentionHandler) GetWeddingOverride(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	override, err := h.retention.GetOverride(c.Request.Context(), weddingID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get retention override")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, override)
+}
+
+// SetWeddingOverride godoc
+// @Summary Override a wedding's retention periods
+// @Description Keep the data of one wedding for fewer days than the platform does, e.g. at the couple's request; replaces any previous override (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body SetRetentionOverrideRequest true "Days to keep by data type"
+// @Success 200 {object} utils.APIResponse{data=models.RetentionOverride}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/weddings/{id}/retention [put]
+func (h *RetentionHandler) SetWeddingOverride(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	var req SetRetentionOverrideRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	override, err := h.retention.SetOverride(c.Request.Context(), weddingID, req.Days)
+	if err != nil {
+		h.handleError(c, err, "Failed to override retention periods")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, override)
+}
+
+// ClearWeddingOverride godoc
+// @Summary Remove a wedding's retention override
+// @Description Return a wedding to the platform's retention periods (admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {object} utils.APIResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/weddings/{id}/retention [delete]
+func (h *RetentionHandler) ClearWeddingOverride(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	if err := h.retention.ClearOverride(c.Request.Context(), weddingID); err != nil {
+		h.handleError(c, err, "Failed to remove retention override")
+		return
+	}
+
+	utils.SuccessResponse(c, "Retention override removed")
+}
+
+func (h *RetentionHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrRetentionOverrideNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Retention override not found")
+	case errors.Is(err, services.ErrUnknownRetentionDataType),
+		errors.Is(err, services.ErrInvalidRetentionDays),
+		errors.Is(err, services.ErrRetentionOverrideTooLong):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockRetentionManager keeps overrides in a map, accepting periods under 90 days
+type MockRetentionManager struct {
+	overrides map[models.ID]*models.RetentionOverride
+	dryRuns   int
+}
+
+func newMockRetentionManager() *MockRetentionManager {
+	return &MockRetentionManager{overrides: make(map[models.ID]*models.RetentionOverride)}
+}
+
+func (m *MockRetentionManager) Policies() []models.RetentionPolicy {
+	return []models.RetentionPolicy{{DataType: models.RetentionPageViews, Days: 90, TTLIndex: true}}
+}
+
+func (m *MockRetentionManager) ListOverrides(ctx context.Context) ([]*models.RetentionOverride, error) {
+	overrides := []*models.RetentionOverride{}
+	for _, override := range m.overrides {
+		overrides = append(overrides, override)
+	}
+	return overrides, nil
+}
+
+func (m *MockRetentionManager) GetOverride(ctx context.Context, weddingID models.ID) (*models.RetentionOverride, error) {
+	override, ok := m.overrides[weddingID]
+	if !ok {
+		return nil, services.ErrRetentionOverrideNotFound
+	}
+	return override, nil
+}
+
+func (m *MockRetentionManager) SetOverride(ctx context.Context, weddingID models.ID, days map[string]int) (*models.RetentionOverride, error) {
+	for _, d := range days {
+		if d >= 90 {
+			return nil, services.ErrRetentionOverrideTooLong
+		}
+	}
+	override := &models.RetentionOverride{WeddingID: weddingID, Days: days}
+	m.overrides[weddingID] = override
+	return override, nil
+}
+
+func (m *MockRetentionManager) ClearOverride(ctx context.Context, weddingID models.ID) error {
+	if _, ok := m.overrides[weddingID]; !ok {
+		return services.ErrRetentionOverrideNotFound
+	}
+	delete(m.overrides, weddingID)
+	return nil
+}
+
+func (m *MockRetentionManager) Run(ctx context.Context, dryRun bool) (*models.RetentionReport, error) {
+	if dryRun {
+		m.dryRuns++
+	}
+	return &models.RetentionReport{DryRun: dryRun, Results: []models.RetentionResult{}, Total: 4}, nil
+}
+
+func setupRetentionRouter(manager RetentionManager) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewRetentionHandler(manager)
+	router.GET("/admin/retention", handler.GetPolicy)
+	router.GET("/admin/retention/report", handler.GetReport)
+	router.GET("/admin/weddings/:id/retention", handler.GetWeddingOverride)
+	router.PUT("/admin/weddings/:id/retention", handler.SetWeddingOverride)
+	router.DELETE("/admin/weddings/:id/retention", handler.ClearWeddingOverride)
+	return router
+}
+
+func sendRetentionRequest(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRetentionHandler(t *testing.T) {
+	manager := newMockRetentionManager()
+	router := setupRetentionRouter(manager)
+	weddingID := models.NewID()
+	path := "/admin/weddings/" + weddingID.String() + "/retention"
+
+	w := sendRetentionRequest(router, http.MethodPut, path, `{"days":{"page_views":30}}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 30, manager.overrides[weddingID].Days[models.RetentionPageViews])
+
+	w = sendRetentionRequest(router, http.MethodPut, path, `{"days":{"page_views":120}}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = sendRetentionRequest(router, http.MethodPut, path, `{}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = sendRetentionRequest(router, http.MethodPut, "/admin/weddings/nope/retention", `{"days":{"page_views":30}}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = sendRetentionRequest(router, http.MethodGet, "/admin/retention", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var policy struct {
+		Data RetentionPolicyResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &policy))
+	assert.Len(t, policy.Data.Policies, 1)
+	require.Len(t, policy.Data.Overrides, 1)
+	assert.Equal(t, weddingID, policy.Data.Overrides[0].WeddingID)
+
+	w = sendRetentionRequest(router, http.MethodGet, "/admin/retention/report", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var report struct {
+		Data models.RetentionReport `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.True(t, report.Data.DryRun)
+	assert.Equal(t, int64(4), report.Data.Total)
+	assert.Equal(t, 1, manager.dryRuns)
+
+	w = sendRetentionRequest(router, http.MethodGet, path, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = sendRetentionRequest(router, http.MethodDelete, path, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = sendRetentionRequest(router, http.MethodDelete, path, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = sendRetentionRequest(router, http.MethodGet, path, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -1,19 +1,24 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
 	"wedding-invitation-backend/internal/services"
 	"wedding-invitation-backend/internal/utils"
 )
 
 type RSVPHandler struct {
-	rsvpService services.RSVPServiceInterface
+	rsvpService     services.RSVPServiceInterface
+	submissionQueue services.RSVPSubmissionQueue
+	pii             *PIIAccess
+	exportJobs      services.ExportJobRecorder
 }
 
 func NewRSVPHandler(rsvpService services.RSVPServiceInterface) *RSVPHandler {
@@ -22,22 +27,46 @@ func NewRSVPHandler(rsvpService services.RSVPServiceInterface) *RSVPHandler {
 	}
 }
 
+// EnablePIIReveal allows owners to request unmasked RSVP contact details,
+// recording each reveal with auditLog
+func (h *RSVPHandler) EnablePIIReveal(auditLog AuditLogger) {
+	h.pii = NewPIIAccess(auditLog)
+}
+
+// EnableExportJobs records every RSVP export, including the fingerprint of the
+// key encrypted exports were sealed to, in the wedding's export history
+func (h *RSVPHandler) EnableExportJobs(recorder services.ExportJobRecorder) {
+	h.exportJobs = recorder
+}
+
+// EnableWriteBehind routes public RSVP submissions through the durable queue.
+// Submissions are validated synchronously and answered with 202 Accepted.
+func (h *RSVPHandler) EnableWriteBehind(queue services.RSVPSubmissionQueue) {
+	h.submissionQueue = queue
+}
+
 // SubmitRSVP godoc
 // @Summary Submit a new RSVP
-// @Description Submit a new RSVP for a wedding (public endpoint)
+// @Description Submit an RSVP for a wedding (public endpoint). Guests are matched to the guest list by link token, email or phone; a guest who answered before updates their RSVP through their link token or the RSVP's edit token, answered with 200, and gets 409 without either.
 // @Tags rsvp
 // @Accept json
 // @Produce json
 // @Param id path string true "Wedding ID"
 // @Param rsvp body services.SubmitRSVPRequest true "RSVP data"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
+// @Success 200 {object} models.RSVP "The guest's earlier RSVP, updated"
 // @Success 201 {object} models.RSVP
+// @Success 202 {object} models.RSVPSubmission "Queued when write-behind mode is enabled"
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
 // @Failure 422 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/public/weddings/{id}/rsvp [post]
 func (h *RSVPHandler) SubmitRSVP(c *gin.Context) {
-	weddingID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	weddingID, err := models.ParseID(c.Param("id"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
 		return
@@ -59,32 +88,111 @@ func (h *RSVPHandler) SubmitRSVP(c *gin.Context) {
 	req.IPAddress = c.ClientIP()
 	req.UserAgent = c.GetHeader("User-Agent")
 
+	if h.submissionQueue != nil {
+		submission, err := h.submissionQueue.Enqueue(c.Request.Context(), weddingID, req)
+		if err != nil {
+			h.handleSubmitError(c, err)
+			return
+		}
+
+		c.Header("Location", "/api/v1/public/weddings/"+weddingID.String()+"/rsvp/submissions/"+submission.ID.String())
+		utils.Response(c, http.StatusAccepted, submission)
+		return
+	}
+
 	// Submit RSVP
 	rsvp, err := h.rsvpService.SubmitRSVP(c.Request.Context(), weddingID, req)
 	if err != nil {
-		switch err {
-		case services.ErrWeddingNotFound:
-			utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
-			return
-		case services.ErrRSVPClosed:
-			utils.ErrorResponse(c, http.StatusUnprocessableEntity, "RSVP is not open for this wedding")
-			return
-		case services.ErrInvalidRSVPStatus:
-			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid RSVP status")
-			return
-		case services.ErrDuplicateRSVP:
-			utils.ErrorResponse(c, http.StatusConflict, "RSVP already submitted for this email")
-			return
-		case services.ErrTooManyPlusOnes:
-			utils.ErrorResponse(c, http.StatusBadRequest, "Too many plus ones")
-			return
-		default:
-			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to submit RSVP")
+		h.handleSubmitError(c, err)
+		return
+	}
+
+	// Fraud review details are for the wedding owner only; flagged guests see a normal confirmation
+	response := *rsvp
+	response.Review = nil
+	status := http.StatusCreated
+	if rsvp.UpdatedAt != nil {
+		// The guest answered before and updated their RSVP
+		status = http.StatusOK
+	}
+	utils.Response(c, status, &response)
+}
+
+// GetSubmissionStatus godoc
+// @Summary Get queued RSVP submission status
+// @Description Check whether a queued RSVP submission has been saved (public endpoint)
+// @Tags rsvp
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param submission_id path string true "Submission ID"
+// @Success 200 {object} models.RSVPSubmission
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/public/weddings/{id}/rsvp/submissions/{submission_id} [get]
+func (h *RSVPHandler) GetSubmissionStatus(c *gin.Context) {
+	if h.submissionQueue == nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "RSVP submission not found")
+		return
+	}
+
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	submissionID, err := models.ParseID(c.Param("submission_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid submission ID")
+		return
+	}
+
+	submission, err := h.submissionQueue.GetSubmission(c.Request.Context(), weddingID, submissionID)
+	if err != nil {
+		if err == services.ErrRSVPSubmissionNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "RSVP submission not found")
 			return
 		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get RSVP submission")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, submission)
+}
+
+func (h *RSVPHandler) handleSubmitError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrInvalidRSVPAnswers) || errors.Is(err, services.ErrInvalidMealChoice) ||
+		errors.Is(err, services.ErrInvalidCompanion) || errors.Is(err, services.ErrInvalidGuestLink) ||
+		errors.Is(err, services.ErrInvalidHouseholdMember) || errors.Is(err, services.ErrInvalidRSVPEditLink) {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrExpiredRSVPEditLink) {
+		utils.ErrorResponse(c, http.StatusGone, "RSVP edit link has expired")
+		return
+	}
+	if errors.Is(err, services.ErrRSVPEditingDisabled) {
+		utils.ErrorResponse(c, http.StatusForbidden, err.Error())
+		return
 	}
 
-	utils.Response(c, http.StatusCreated, rsvp)
+	switch err {
+	case services.ErrWeddingNotFound:
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case services.ErrRSVPClosed:
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, "RSVP is not open for this wedding")
+	case services.ErrInvalidRSVPStatus:
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid RSVP status")
+	case services.ErrDuplicateRSVP:
+		utils.ErrorResponse(c, http.StatusConflict, "RSVP already submitted for this email")
+	case services.ErrTooManyPlusOnes:
+		utils.ErrorResponse(c, http.StatusBadRequest, "Too many plus ones")
+	case services.ErrInvalidRSVPSessions, services.ErrInvalidAllergies:
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to submit RSVP")
+	}
 }
 
 // GetRSVPs godoc
@@ -98,6 +206,8 @@ func (h *RSVPHandler) SubmitRSVP(c *gin.Context) {
 // @Param status query string false "Filter by status"
 // @Param search query string false "Search by name or email"
 // @Param source query string false "Filter by source"
+// @Param review_status query string false "Filter by fraud review status" Enums(pending, accepted, rejected)
+// @Param reveal_pii query bool false "Return unmasked emails and phone numbers (audit-logged)"
 // @Success 200 {object} PaginatedResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
@@ -105,7 +215,36 @@ func (h *RSVPHandler) SubmitRSVP(c *gin.Context) {
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/weddings/{id}/rsvps [get]
 func (h *RSVPHandler) GetRSVPs(c *gin.Context) {
-	weddingID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	h.listRSVPs(c, repository.RSVPFilters{
+		Status:       c.Query("status"),
+		Search:       c.Query("search"),
+		Source:       c.Query("source"),
+		ReviewStatus: c.Query("review_status"),
+	})
+}
+
+// GetRSVPReviewQueue godoc
+// @Summary Get the RSVP review queue
+// @Description Get paginated RSVPs flagged as suspicious and awaiting the owner's decision (owner only)
+// @Tags rsvp
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Param reveal_pii query bool false "Return unmasked emails and phone numbers (audit-logged)"
+// @Success 200 {object} PaginatedResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/rsvps/review [get]
+func (h *RSVPHandler) GetRSVPReviewQueue(c *gin.Context) {
+	h.listRSVPs(c, repository.RSVPFilters{ReviewStatus: string(models.RSVPReviewPending)})
+}
+
+// listRSVPs writes a page of the wedding's RSVPs matching filters
+func (h *RSVPHandler) listRSVPs(c *gin.Context, filters repository.RSVPFilters) {
+	weddingID, err := models.ParseID(c.Param("id"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
 		return
@@ -129,13 +268,6 @@ func (h *RSVPHandler) GetRSVPs(c *gin.Context) {
 		pageSize = 20
 	}
 
-	// Build filters
-	filters := repository.RSVPFilters{
-		Status: c.Query("status"),
-		Search: c.Query("search"),
-		Source: c.Query("source"),
-	}
-
 	rsvps, total, err := h.rsvpService.ListRSVPs(c.Request.Context(), weddingID, userID, page, pageSize, filters)
 	if err != nil {
 		switch err {
@@ -151,6 +283,14 @@ func (h *RSVPHandler) GetRSVPs(c *gin.Context) {
 		}
 	}
 
+	reveal, ok := h.pii.Reveal(c, "rsvps", true, len(rsvps))
+	if !ok {
+		return
+	}
+	if !reveal {
+		rsvps = maskRSVPs(rsvps)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"data":        rsvps,
 		"page":        page,
@@ -173,7 +313,7 @@ func (h *RSVPHandler) GetRSVPs(c *gin.Context) {
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/weddings/{id}/rsvps/statistics [get]
 func (h *RSVPHandler) GetRSVPStatistics(c *gin.Context) {
-	weddingID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	weddingID, err := models.ParseID(c.Param("id"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
 		return
@@ -186,7 +326,7 @@ func (h *RSVPHandler) GetRSVPStatistics(c *gin.Context) {
 		return
 	}
 
-	userID, err := primitive.ObjectIDFromHex(userIDStr.(string))
+	userID, err := models.ParseID(userIDStr.(string))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid user ID")
 		return
@@ -210,6 +350,109 @@ func (h *RSVPHandler) GetRSVPStatistics(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": stats})
 }
 
+// GetRSVPInsights godoc
+// @Summary Get in-depth RSVP statistics for a wedding
+// @Description Attendance and declines by day, response rate of the guest list, projected headcount including plus ones, answers by side, relationship and VIP status, and meal counts (owner only)
+// @Tags rsvp
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {object} models.RSVPInsights
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/rsvps/insights [get]
+func (h *RSVPHandler) GetRSVPInsights(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	insights, err := h.rsvpService.GetRSVPInsights(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		switch err {
+		case services.ErrWeddingNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+		case services.ErrUnauthorized:
+			utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to view statistics for this wedding")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get RSVP insights")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": insights})
+}
+
+// GetMealReport godoc
+// @Summary Get the caterer meal report
+// @Description Meal choice and allergy counts of everyone attending, plus ones included, with the list of attendees. With format=csv or xlsx only the attendee list is exported.
+// @Tags rsvp
+// @Produce json,text/csv,application/octet-stream
+// @Param id path string true "Wedding ID"
+// @Param format query string false "Output format of the attendee list" Enums(json, csv, xlsx)
+// @Param public_key query string false "age recipient to encrypt the attendee list to"
+// @Success 200 {object} models.MealReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/rsvps/meal-report [get]
+func (h *RSVPHandler) GetMealReport(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	opts, ok := parseExportOptions(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.rsvpService.GetMealReport(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		switch err {
+		case services.ErrWeddingNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+		case services.ErrUnauthorized:
+			utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to view the meal report for this wedding")
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get meal report")
+		}
+		return
+	}
+
+	if opts.format == exportFormatJSON && opts.recipient == nil {
+		utils.Response(c, http.StatusOK, report)
+		return
+	}
+
+	_, _, _ = streamExport(c, mealCSVExport, opts, func(emit func(record interface{}) error) error {
+		for i := range report.Attendees {
+			if err := emit(&report.Attendees[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // UpdateRSVP godoc
 // @Summary Update an RSVP
 // @Description Update an existing RSVP (owner only, within 24 hours of submission)
@@ -226,7 +469,7 @@ func (h *RSVPHandler) GetRSVPStatistics(c *gin.Context) {
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/rsvps/{id} [put]
 func (h *RSVPHandler) UpdateRSVP(c *gin.Context) {
-	rsvpID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	rsvpID, err := models.ParseID(c.Param("id"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid RSVP ID")
 		return
@@ -246,6 +489,11 @@ func (h *RSVPHandler) UpdateRSVP(c *gin.Context) {
 
 	rsvp, err := h.rsvpService.UpdateRSVP(c.Request.Context(), rsvpID, req)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidRSVPAnswers) || errors.Is(err, services.ErrInvalidMealChoice) ||
+			errors.Is(err, services.ErrInvalidCompanion) {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		switch err {
 		case services.ErrRSVPNotFound:
 			utils.ErrorResponse(c, http.StatusNotFound, "RSVP not found")
@@ -259,6 +507,9 @@ func (h *RSVPHandler) UpdateRSVP(c *gin.Context) {
 		case services.ErrTooManyPlusOnes:
 			utils.ErrorResponse(c, http.StatusBadRequest, "Too many plus ones")
 			return
+		case services.ErrInvalidRSVPSessions, services.ErrInvalidAllergies:
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
 		default:
 			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update RSVP")
 			return
@@ -268,6 +519,66 @@ func (h *RSVPHandler) UpdateRSVP(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": rsvp})
 }
 
+// ReviewRSVPRequest is the owner's decision on a flagged RSVP
+type ReviewRSVPRequest struct {
+	Decision string `json:"decision" binding:"required" example:"accept"`
+}
+
+// ReviewRSVP godoc
+// @Summary Review a flagged RSVP
+// @Description Accept or reject an RSVP held for fraud review; accepted RSVPs count towards statistics (wedding owner only)
+// @Tags rsvp
+// @Accept json
+// @Produce json
+// @Param id path string true "RSVP ID"
+// @Param review body ReviewRSVPRequest true "Review decision (accept or reject)"
+// @Success 200 {object} models.RSVP
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/rsvps/{id}/review [post]
+func (h *RSVPHandler) ReviewRSVP(c *gin.Context) {
+	rsvpID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid RSVP ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req ReviewRSVPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	rsvp, err := h.rsvpService.ReviewRSVP(c.Request.Context(), rsvpID, userID, req.Decision)
+	if err != nil {
+		switch err {
+		case services.ErrInvalidReview:
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case services.ErrRSVPNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "RSVP not found")
+		case services.ErrUnauthorized:
+			utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to review this RSVP")
+		case services.ErrRSVPNotInReview:
+			utils.ErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to review RSVP")
+		}
+		return
+	}
+
+	utils.Response(c, http.StatusOK, rsvp)
+}
+
 // DeleteRSVP godoc
 // @Summary Delete an RSVP
 // @Description Delete an RSVP (wedding owner only)
@@ -281,7 +592,7 @@ func (h *RSVPHandler) UpdateRSVP(c *gin.Context) {
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/rsvps/{id} [delete]
 func (h *RSVPHandler) DeleteRSVP(c *gin.Context) {
-	rsvpID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	rsvpID, err := models.ParseID(c.Param("id"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid RSVP ID")
 		return
@@ -294,7 +605,7 @@ func (h *RSVPHandler) DeleteRSVP(c *gin.Context) {
 		return
 	}
 
-	userID, err := primitive.ObjectIDFromHex(userIDStr.(string))
+	userID, err := models.ParseID(userIDStr.(string))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid user ID")
 		return
@@ -320,18 +631,25 @@ func (h *RSVPHandler) DeleteRSVP(c *gin.Context) {
 
 // ExportRSVPs godoc
 // @Summary Export RSVPs
-// @Description Export all RSVPs for a wedding (owner only, for CSV download)
+// @Description Stream all RSVPs for a wedding as JSON or CSV (owner only). When a public key
+// @Description (age recipient or armored OpenPGP key) is supplied the export is encrypted to it
+// @Description and only the key fingerprint is recorded on the export job.
 // @Tags rsvp
-// @Produce json
+// @Accept json
+// @Produce json,text/csv,application/octet-stream
 // @Param id path string true "Wedding ID"
+// @Param format query string false "Output format" Enums(json, csv) default(json)
+// @Param public_key query string false "age recipient to encrypt the export to"
+// @Param export body ExportRequest false "Export options (POST only)"
 // @Success 200 {array} models.RSVP
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/weddings/{id}/rsvps/export [get]
+// @Router /api/v1/weddings/{id}/rsvps/export [post]
 func (h *RSVPHandler) ExportRSVPs(c *gin.Context) {
-	weddingID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	weddingID, err := models.ParseID(c.Param("id"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
 		return
@@ -344,26 +662,55 @@ func (h *RSVPHandler) ExportRSVPs(c *gin.Context) {
 		return
 	}
 
-	userID, err := primitive.ObjectIDFromHex(userIDStr.(string))
+	userID, err := models.ParseID(userIDStr.(string))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid user ID")
 		return
 	}
 
-	rsvps, err := h.rsvpService.ExportRSVPs(c.Request.Context(), weddingID, userID)
+	opts, ok := parseExportOptions(c)
+	if !ok {
+		return
+	}
+
+	// The custom questions add a CSV column each; fetching them also checks access
+	questions, err := h.rsvpService.GetRSVPQuestions(c.Request.Context(), weddingID, userID)
 	if err != nil {
-		switch err {
-		case services.ErrWeddingNotFound:
-			utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
-			return
-		case services.ErrUnauthorized:
-			utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to export RSVPs for this wedding")
-			return
-		default:
-			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to export RSVPs")
+		h.writeExportError(c, err)
+		return
+	}
+	layout := rsvpCSVExport(questions)
+
+	var job *models.ExportJob
+	if h.exportJobs != nil {
+		job, err = h.exportJobs.StartExport(c.Request.Context(), weddingID, userID, layout.filename, opts.format, opts.recipient)
+		if err != nil {
+			h.writeExportError(c, err)
 			return
 		}
+		c.Header("X-Export-Job-ID", job.ID.String())
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": rsvps})
+	records, started, err := streamExport(c, layout, opts, func(emit func(record interface{}) error) error {
+		return h.rsvpService.StreamRSVPs(c.Request.Context(), weddingID, userID, func(rsvp *models.RSVP) error {
+			return emit(rsvp)
+		})
+	})
+	if job != nil {
+		h.exportJobs.FinishExport(context.WithoutCancel(c.Request.Context()), job, records, err)
+	}
+	if err != nil && !started {
+		h.writeExportError(c, err)
+	}
+}
+
+func (h *RSVPHandler) writeExportError(c *gin.Context, err error) {
+	switch err {
+	case services.ErrWeddingNotFound:
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case services.ErrUnauthorized:
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to export RSVPs for this wedding")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to export RSVPs")
+	}
 }
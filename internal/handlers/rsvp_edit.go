@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// RSVPEditResponse is an RSVP as its guest sees it through their edit link
+type RSVPEditResponse struct {
+	ID                  models.ID                    `json:"id"`
+	WeddingID           models.ID                    `json:"wedding_id"`
+	FirstName           string                       `json:"first_name"`
+	LastName            string                       `json:"last_name"`
+	Status              string                       `json:"status"`
+	AttendanceCount     int                          `json:"attendance_count"`
+	Sessions            []models.RSVPSessionResponse `json:"sessions,omitempty"`
+	Companions          []models.PlusOneInfo         `json:"companions,omitempty"`
+	DietaryRestrictions string                       `json:"dietary_restrictions,omitempty"`
+	AdditionalNotes     string                       `json:"additional_notes,omitempty"`
+	MealChoice          string                       `json:"meal_choice,omitempty"`
+	Allergies           []string                     `json:"allergies,omitempty"`
+	CustomAnswers       []models.CustomAnswer        `json:"custom_answers,omitempty"`
+	SubmittedAt         time.Time                    `json:"submitted_at"`
+	UpdatedAt           *time.Time                   `json:"updated_at,omitempty"`
+}
+
+// RSVPEditRequest changes an RSVP through its guest's edit link; omitted fields
+// are left as they are. Companions keep their ID so check-in can find them.
+type RSVPEditRequest struct {
+	Status              *string                       `json:"status" binding:"omitempty,oneof=attending not-attending maybe"`
+	AttendanceCount     *int                          `json:"attendance_count" binding:"omitempty,min=1,max=10"`
+	Sessions            *[]models.RSVPSessionResponse `json:"sessions"`
+	Companions          *[]models.PlusOneInfo         `json:"companions" binding:"omitempty,max=5"`
+	DietaryRestrictions *string                       `json:"dietary_restrictions" binding:"omitempty,max=500"`
+	AdditionalNotes     *string                       `json:"additional_notes" binding:"omitempty,max=500"`
+	MealChoice          *string                       `json:"meal_choice"`
+	Allergies           *[]string                     `json:"allergies"`
+	CustomAnswers       map[string]interface{}        `json:"custom_answers"`
+}
+
+// GetRSVPForEdit godoc
+// @Summary Get an RSVP by its edit link
+// @Description Returns the RSVP a guest's edit link belongs to, so the guest can review and change it (public endpoint)
+// @Tags rsvp
+// @Produce json
+// @Param token path string true "Edit token from the link emailed after submission"
+// @Success 200 {object} RSVPEditResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/public/rsvp/{token} [get]
+func (h *RSVPHandler) GetRSVPForEdit(c *gin.Context) {
+	rsvp, err := h.rsvpService.GetRSVPByEditToken(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		h.handleEditError(c, err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, newRSVPEditResponse(rsvp))
+}
+
+// UpdateRSVPByEditLink godoc
+// @Summary Change an RSVP by its edit link
+// @Description Lets a guest change their attendance, meals and companions while the wedding's RSVP period is open. Every change is recorded in the RSVP's history for the owner (public endpoint).
+// @Tags rsvp
+// @Accept json
+// @Produce json
+// @Param token path string true "Edit token from the link emailed after submission"
+// @Param rsvp body RSVPEditRequest true "Changed fields"
+// @Success 200 {object} RSVPEditResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/public/rsvp/{token} [put]
+func (h *RSVPHandler) UpdateRSVPByEditLink(c *gin.Context) {
+	var req RSVPEditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	update := services.UpdateRSVPRequest{
+		Status:              req.Status,
+		AttendanceCount:     req.AttendanceCount,
+		Sessions:            req.Sessions,
+		PlusOnes:            req.Companions,
+		DietaryRestrictions: req.DietaryRestrictions,
+		AdditionalNotes:     req.AdditionalNotes,
+		MealChoice:          req.MealChoice,
+		Allergies:           req.Allergies,
+	}
+	if req.CustomAnswers != nil {
+		customAnswers := make([]models.CustomAnswer, 0, len(req.CustomAnswers))
+		for questionID, answer := range req.CustomAnswers {
+			customAnswers = append(customAnswers, models.CustomAnswer{QuestionID: questionID, Answer: answer})
+		}
+		sort.Slice(customAnswers, func(i, j int) bool { return customAnswers[i].QuestionID < customAnswers[j].QuestionID })
+		update.CustomAnswers = &customAnswers
+	}
+
+	rsvp, err := h.rsvpService.UpdateRSVPByEditToken(c.Request.Context(), c.Param("token"), update)
+	if err != nil {
+		h.handleEditError(c, err)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, newRSVPEditResponse(rsvp))
+}
+
+func (h *RSVPHandler) handleEditError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidRSVPEditLink):
+		utils.ErrorResponse(c, http.StatusNotFound, "RSVP edit link is invalid")
+	case errors.Is(err, services.ErrExpiredRSVPEditLink):
+		utils.ErrorResponse(c, http.StatusGone, "RSVP edit link has expired")
+	case errors.Is(err, services.ErrRSVPEditingDisabled):
+		utils.ErrorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrRSVPClosed):
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, "RSVP is not open for this wedding")
+	case errors.Is(err, services.ErrTooManyPlusOnes):
+		utils.ErrorResponse(c, http.StatusBadRequest, "Too many plus ones")
+	case errors.Is(err, services.ErrInvalidRSVPStatus):
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid RSVP status")
+	case errors.Is(err, services.ErrInvalidRSVPAnswers), errors.Is(err, services.ErrInvalidMealChoice),
+		errors.Is(err, services.ErrInvalidAllergies), errors.Is(err, services.ErrInvalidRSVPSessions),
+		errors.Is(err, services.ErrInvalidCompanion):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update RSVP")
+	}
+}
+
+func newRSVPEditResponse(rsvp *models.RSVP) *RSVPEditResponse {
+	return &RSVPEditResponse{
+		ID:                  rsvp.ID,
+		WeddingID:           rsvp.WeddingID,
+		FirstName:           rsvp.FirstName,
+		LastName:            rsvp.LastName,
+		Status:              rsvp.Status,
+		AttendanceCount:     rsvp.AttendanceCount,
+		Sessions:            rsvp.Sessions,
+		Companions:          rsvp.PlusOnes,
+		DietaryRestrictions: rsvp.DietaryRestrictions,
+		AdditionalNotes:     rsvp.AdditionalNotes,
+		MealChoice:          rsvp.MealChoice,
+		Allergies:           rsvp.Allergies,
+		CustomAnswers:       rsvp.CustomAnswers,
+		SubmittedAt:         rsvp.SubmittedAt,
+		UpdatedAt:           rsvp.UpdatedAt,
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"filippo.io/age"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,28 +20,32 @@ import (
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
 	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
 )
 
 // MockRSVPService for handler testing
 type MockRSVPService struct {
-	rsvps     map[primitive.ObjectID]*models.RSVP
-	createErr error
-	getErr    error
+	rsvps      map[models.ID]*models.RSVP
+	questions  []models.CustomQuestion
+	mealReport *models.MealReport
+	editTokens map[string]models.ID
+	createErr  error
+	getErr     error
 }
 
 func NewMockRSVPService() *MockRSVPService {
 	return &MockRSVPService{
-		rsvps: make(map[primitive.ObjectID]*models.RSVP),
+		rsvps: make(map[models.ID]*models.RSVP),
 	}
 }
 
-func (m *MockRSVPService) SubmitRSVP(ctx context.Context, weddingID primitive.ObjectID, req services.SubmitRSVPRequest) (*models.RSVP, error) {
+func (m *MockRSVPService) SubmitRSVP(ctx context.Context, weddingID models.ID, req services.SubmitRSVPRequest) (*models.RSVP, error) {
 	if m.createErr != nil {
 		return nil, m.createErr
 	}
 
 	rsvp := &models.RSVP{
-		ID:              primitive.NewObjectID(),
+		ID:              models.NewID(),
 		WeddingID:       weddingID,
 		FirstName:       req.FirstName,
 		LastName:        req.LastName,
@@ -53,7 +60,7 @@ func (m *MockRSVPService) SubmitRSVP(ctx context.Context, weddingID primitive.Ob
 	return rsvp, nil
 }
 
-func (m *MockRSVPService) GetRSVPByID(ctx context.Context, id primitive.ObjectID) (*models.RSVP, error) {
+func (m *MockRSVPService) GetRSVPByID(ctx context.Context, id models.ID) (*models.RSVP, error) {
 	if m.getErr != nil {
 		return nil, m.getErr
 	}
@@ -63,7 +70,7 @@ func (m *MockRSVPService) GetRSVPByID(ctx context.Context, id primitive.ObjectID
 	return nil, services.ErrRSVPNotFound
 }
 
-func (m *MockRSVPService) UpdateRSVP(ctx context.Context, id primitive.ObjectID, req services.UpdateRSVPRequest) (*models.RSVP, error) {
+func (m *MockRSVPService) UpdateRSVP(ctx context.Context, id models.ID, req services.UpdateRSVPRequest) (*models.RSVP, error) {
 	rsvp, exists := m.rsvps[id]
 	if !exists {
 		return nil, services.ErrRSVPNotFound
@@ -80,7 +87,7 @@ func (m *MockRSVPService) UpdateRSVP(ctx context.Context, id primitive.ObjectID,
 	return rsvp, nil
 }
 
-func (m *MockRSVPService) DeleteRSVP(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
+func (m *MockRSVPService) DeleteRSVP(ctx context.Context, id models.ID, userID models.ID) error {
 	if _, exists := m.rsvps[id]; !exists {
 		return services.ErrRSVPNotFound
 	}
@@ -88,7 +95,7 @@ func (m *MockRSVPService) DeleteRSVP(ctx context.Context, id primitive.ObjectID,
 	return nil
 }
 
-func (m *MockRSVPService) ListRSVPs(ctx context.Context, weddingID primitive.ObjectID, userID primitive.ObjectID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error) {
+func (m *MockRSVPService) ListRSVPs(ctx context.Context, weddingID models.ID, userID models.ID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error) {
 	var results []*models.RSVP
 	for _, rsvp := range m.rsvps {
 		if rsvp.WeddingID == weddingID {
@@ -98,14 +105,19 @@ func (m *MockRSVPService) ListRSVPs(ctx context.Context, weddingID primitive.Obj
 	return results, int64(len(results)), nil
 }
 
-func (m *MockRSVPService) GetRSVPStatistics(ctx context.Context, weddingID primitive.ObjectID, userID primitive.ObjectID) (*models.RSVPStatistics, error) {
+func (m *MockRSVPService) GetRSVPStatistics(ctx context.Context, weddingID models.ID, userID models.ID) (*models.RSVPStatistics, error) {
 	stats := &models.RSVPStatistics{
 		TotalResponses: len(m.rsvps),
 	}
 	return stats, nil
 }
 
-func (m *MockRSVPService) ExportRSVPs(ctx context.Context, weddingID primitive.ObjectID, userID primitive.ObjectID) ([]*models.RSVP, error) {
+func (m *MockRSVPService) GetRSVPInsights(ctx context.Context, weddingID models.ID, userID models.ID) (*models.RSVPInsights, error) {
+	insights := &models.RSVPInsights{WeddingID: weddingID, Responses: len(m.rsvps)}
+	return insights, nil
+}
+
+func (m *MockRSVPService) ExportRSVPs(ctx context.Context, weddingID models.ID, userID models.ID) ([]*models.RSVP, error) {
 	var results []*models.RSVP
 	for _, rsvp := range m.rsvps {
 		if rsvp.WeddingID == weddingID {
@@ -115,6 +127,69 @@ func (m *MockRSVPService) ExportRSVPs(ctx context.Context, weddingID primitive.O
 	return results, nil
 }
 
+func (m *MockRSVPService) ReviewRSVP(ctx context.Context, id models.ID, userID models.ID, decision string) (*models.RSVP, error) {
+	rsvp, exists := m.rsvps[id]
+	if !exists {
+		return nil, services.ErrRSVPNotFound
+	}
+	if !rsvp.NeedsReview() {
+		return nil, services.ErrRSVPNotInReview
+	}
+	switch decision {
+	case services.RSVPReviewAccept:
+		rsvp.Review.Status = models.RSVPReviewAccepted
+	case services.RSVPReviewReject:
+		rsvp.Review.Status = models.RSVPReviewRejected
+	default:
+		return nil, services.ErrInvalidReview
+	}
+	return rsvp, nil
+}
+
+func (m *MockRSVPService) StreamRSVPs(ctx context.Context, weddingID models.ID, userID models.ID, fn func(*models.RSVP) error) error {
+	for _, rsvp := range m.rsvps {
+		if rsvp.WeddingID == weddingID {
+			if err := fn(rsvp); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MockRSVPService) GetRSVPQuestions(ctx context.Context, weddingID models.ID, userID models.ID) ([]models.CustomQuestion, error) {
+	return m.questions, nil
+}
+
+func (m *MockRSVPService) GetMealReport(ctx context.Context, weddingID models.ID, userID models.ID) (*models.MealReport, error) {
+	if m.mealReport == nil {
+		return nil, services.ErrWeddingNotFound
+	}
+	return m.mealReport, nil
+}
+
+func (m *MockRSVPService) GetRSVPByEditToken(ctx context.Context, token string) (*models.RSVP, error) {
+	rsvpID, ok := m.editTokens[token]
+	if !ok {
+		return nil, services.ErrInvalidRSVPEditLink
+	}
+	return m.GetRSVPByID(ctx, rsvpID)
+}
+
+func (m *MockRSVPService) UpdateRSVPByEditToken(ctx context.Context, token string, req services.UpdateRSVPRequest) (*models.RSVP, error) {
+	rsvp, err := m.GetRSVPByEditToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if req.MealChoice != nil {
+		rsvp.MealChoice = *req.MealChoice
+	}
+	if req.PlusOnes != nil {
+		rsvp.PlusOnes = *req.PlusOnes
+	}
+	return m.UpdateRSVP(ctx, rsvp.ID, req)
+}
+
 func setupRSVPRouter() (*gin.Engine, *MockRSVPService) {
 	gin.SetMode(gin.TestMode)
 	mockService := NewMockRSVPService()
@@ -124,7 +199,7 @@ func setupRSVPRouter() (*gin.Engine, *MockRSVPService) {
 
 	// Mock auth middleware
 	router.Use(func(c *gin.Context) {
-		c.Set("user_id", primitive.NewObjectID().Hex())
+		c.Set("user_id", models.NewID().String())
 		c.Next()
 	})
 
@@ -135,13 +210,20 @@ func setupRSVPRouter() (*gin.Engine, *MockRSVPService) {
 		public := v1.Group("/public")
 		{
 			public.POST("/weddings/:id/rsvp", handler.SubmitRSVP)
+			public.GET("/rsvp/:token", handler.GetRSVPForEdit)
+			public.PUT("/rsvp/:token", handler.UpdateRSVPByEditLink)
 		}
 
 		// Protected routes
 		v1.GET("/weddings/:id/rsvps", handler.GetRSVPs)
 		v1.GET("/weddings/:id/rsvps/statistics", handler.GetRSVPStatistics)
+		v1.GET("/weddings/:id/rsvps/insights", handler.GetRSVPInsights)
+		v1.GET("/weddings/:id/rsvps/meal-report", handler.GetMealReport)
 		v1.GET("/weddings/:id/rsvps/export", handler.ExportRSVPs)
+		v1.POST("/weddings/:id/rsvps/export", handler.ExportRSVPs)
+		v1.GET("/weddings/:id/rsvps/review", handler.GetRSVPReviewQueue)
 		v1.PUT("/rsvps/:id", handler.UpdateRSVP)
+		v1.POST("/rsvps/:id/review", handler.ReviewRSVP)
 		v1.DELETE("/rsvps/:id", handler.DeleteRSVP)
 	}
 
@@ -151,7 +233,7 @@ func setupRSVPRouter() (*gin.Engine, *MockRSVPService) {
 func TestRSVPHandler_SubmitRSVP(t *testing.T) {
 	router, _ := setupRSVPRouter()
 
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 	reqBody := services.SubmitRSVPRequest{
 		FirstName:       "John",
 		LastName:        "Doe",
@@ -165,7 +247,7 @@ func TestRSVPHandler_SubmitRSVP(t *testing.T) {
 	}
 
 	body, _ := json.Marshal(reqBody)
-	req, _ := http.NewRequest("POST", "/api/v1/public/weddings/"+weddingID.Hex()+"/rsvp", bytes.NewBuffer(body))
+	req, _ := http.NewRequest("POST", "/api/v1/public/weddings/"+weddingID.String()+"/rsvp", bytes.NewBuffer(body))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "test-agent")
 
@@ -214,10 +296,10 @@ func TestRSVPHandler_SubmitRSVP_InvalidID(t *testing.T) {
 func TestRSVPHandler_SubmitRSVP_InvalidBody(t *testing.T) {
 	router, _ := setupRSVPRouter()
 
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 	reqBody := `{"invalid": "json"}`
 
-	req, _ := http.NewRequest("POST", "/api/v1/public/weddings/"+weddingID.Hex()+"/rsvp", bytes.NewBufferString(reqBody))
+	req, _ := http.NewRequest("POST", "/api/v1/public/weddings/"+weddingID.String()+"/rsvp", bytes.NewBufferString(reqBody))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -241,7 +323,7 @@ func TestRSVPHandler_SubmitRSVP_ServiceError(t *testing.T) {
 	// Set service to return error
 	mockService.createErr = services.ErrRSVPClosed
 
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 	reqBody := services.SubmitRSVPRequest{
 		FirstName:       "John",
 		LastName:        "Doe",
@@ -251,7 +333,7 @@ func TestRSVPHandler_SubmitRSVP_ServiceError(t *testing.T) {
 	}
 
 	body, _ := json.Marshal(reqBody)
-	req, _ := http.NewRequest("POST", "/api/v1/public/weddings/"+weddingID.Hex()+"/rsvp", bytes.NewBuffer(body))
+	req, _ := http.NewRequest("POST", "/api/v1/public/weddings/"+weddingID.String()+"/rsvp", bytes.NewBuffer(body))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -271,11 +353,11 @@ func TestRSVPHandler_SubmitRSVP_ServiceError(t *testing.T) {
 func TestRSVPHandler_GetRSVPs(t *testing.T) {
 	router, mockService := setupRSVPRouter()
 
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 
 	// Create test RSVP
 	rsvp := &models.RSVP{
-		ID:              primitive.NewObjectID(),
+		ID:              models.NewID(),
 		WeddingID:       weddingID,
 		FirstName:       "John",
 		LastName:        "Doe",
@@ -284,7 +366,7 @@ func TestRSVPHandler_GetRSVPs(t *testing.T) {
 	}
 	mockService.rsvps[rsvp.ID] = rsvp
 
-	req, _ := http.NewRequest("GET", "/api/v1/weddings/"+weddingID.Hex()+"/rsvps", nil)
+	req, _ := http.NewRequest("GET", "/api/v1/weddings/"+weddingID.String()+"/rsvps", nil)
 
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
@@ -317,9 +399,9 @@ func TestRSVPHandler_GetRSVPs_InvalidID(t *testing.T) {
 func TestRSVPHandler_GetRSVPStatistics(t *testing.T) {
 	router, _ := setupRSVPRouter()
 
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 
-	req, _ := http.NewRequest("GET", "/api/v1/weddings/"+weddingID.Hex()+"/rsvps/statistics", nil)
+	req, _ := http.NewRequest("GET", "/api/v1/weddings/"+weddingID.String()+"/rsvps/statistics", nil)
 
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
@@ -335,15 +417,34 @@ func TestRSVPHandler_GetRSVPStatistics(t *testing.T) {
 	assert.NotNil(t, data)
 }
 
+func TestRSVPHandler_GetRSVPInsights(t *testing.T) {
+	router, _ := setupRSVPRouter()
+
+	weddingID := models.NewID()
+
+	req, _ := http.NewRequest("GET", "/api/v1/weddings/"+weddingID.String()+"/rsvps/insights", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data models.RSVPInsights `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, weddingID, response.Data.WeddingID)
+}
+
 func TestRSVPHandler_UpdateRSVP(t *testing.T) {
 	router, mockService := setupRSVPRouter()
 
-	rsvpID := primitive.NewObjectID()
+	rsvpID := models.NewID()
 
 	// Create test RSVP
 	rsvp := &models.RSVP{
 		ID:              rsvpID,
-		WeddingID:       primitive.NewObjectID(),
+		WeddingID:       models.NewID(),
 		FirstName:       "John",
 		LastName:        "Doe",
 		Status:          "attending",
@@ -359,7 +460,7 @@ func TestRSVPHandler_UpdateRSVP(t *testing.T) {
 	}
 
 	body, _ := json.Marshal(reqBody)
-	req, _ := http.NewRequest("PUT", "/api/v1/rsvps/"+rsvpID.Hex(), bytes.NewBuffer(body))
+	req, _ := http.NewRequest("PUT", "/api/v1/rsvps/"+rsvpID.String(), bytes.NewBuffer(body))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -379,19 +480,19 @@ func TestRSVPHandler_UpdateRSVP(t *testing.T) {
 func TestRSVPHandler_DeleteRSVP(t *testing.T) {
 	router, mockService := setupRSVPRouter()
 
-	rsvpID := primitive.NewObjectID()
+	rsvpID := models.NewID()
 
 	// Create test RSVP
 	rsvp := &models.RSVP{
 		ID:        rsvpID,
-		WeddingID: primitive.NewObjectID(),
+		WeddingID: models.NewID(),
 		FirstName: "John",
 		LastName:  "Doe",
 		Status:    "attending",
 	}
 	mockService.rsvps[rsvpID] = rsvp
 
-	req, _ := http.NewRequest("DELETE", "/api/v1/rsvps/"+rsvpID.Hex(), nil)
+	req, _ := http.NewRequest("DELETE", "/api/v1/rsvps/"+rsvpID.String(), nil)
 
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
@@ -410,11 +511,11 @@ func TestRSVPHandler_DeleteRSVP(t *testing.T) {
 func TestRSVPHandler_ExportRSVPs(t *testing.T) {
 	router, mockService := setupRSVPRouter()
 
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 
 	// Create test RSVP
 	rsvp := &models.RSVP{
-		ID:        primitive.NewObjectID(),
+		ID:        models.NewID(),
 		WeddingID: weddingID,
 		FirstName: "John",
 		LastName:  "Doe",
@@ -422,7 +523,7 @@ func TestRSVPHandler_ExportRSVPs(t *testing.T) {
 	}
 	mockService.rsvps[rsvp.ID] = rsvp
 
-	req, _ := http.NewRequest("GET", "/api/v1/weddings/"+weddingID.Hex()+"/rsvps/export", nil)
+	req, _ := http.NewRequest("GET", "/api/v1/weddings/"+weddingID.String()+"/rsvps/export", nil)
 
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
@@ -441,7 +542,330 @@ func TestRSVPHandler_ExportRSVPs(t *testing.T) {
 	assert.Len(t, dataArray, 1)
 }
 
+func TestRSVPHandler_ExportRSVPs_CustomQuestions(t *testing.T) {
+	router, mockService := setupRSVPRouter()
+
+	weddingID := models.NewID()
+	mockService.questions = []models.CustomQuestion{
+		{ID: "meal", Question: "Meal", Type: models.CustomQuestionSingleChoice, Options: []string{"Fish", "Chicken"}},
+		{ID: "days", Question: "Days attending", Type: models.CustomQuestionMultiChoice, Options: []string{"Friday", "Saturday"}},
+		{ID: "kids", Question: "Children", Type: models.CustomQuestionNumber},
+	}
+	rsvp := &models.RSVP{
+		ID:        models.NewID(),
+		WeddingID: weddingID,
+		FirstName: "John",
+		LastName:  "Doe",
+		Status:    "attending",
+		CustomAnswers: []models.CustomAnswer{
+			{QuestionID: "days", Answer: primitive.A{"Friday", "Saturday"}},
+			{QuestionID: "kids", Answer: 2.0},
+		},
+	}
+	mockService.rsvps[rsvp.ID] = rsvp
+
+	req, _ := http.NewRequest("GET", "/api/v1/weddings/"+weddingID.String()+"/rsvps/export?format=csv", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasSuffix(lines[0], ",submitted_at,Meal,Days attending,Children"))
+	assert.True(t, strings.HasSuffix(lines[1], ",,Friday; Saturday,2"))
+}
+
+func TestRSVPHandler_GetMealReport(t *testing.T) {
+	router, mockService := setupRSVPRouter()
+	weddingID := models.NewID()
+	rsvpID := models.NewID()
+
+	req, _ := http.NewRequest("GET", "/api/v1/weddings/"+weddingID.String()+"/rsvps/meal-report", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mockService.mealReport = &models.MealReport{
+		WeddingID:      weddingID,
+		TotalAttendees: 2,
+		Meals:          []models.OptionCount{{Option: "Fish", Count: 1}, {Option: "Beef", Count: 0}},
+		NoMealChoice:   1,
+		AllergyCounts:  map[string]int{"peanuts": 1},
+		Attendees: []models.MealAttendee{
+			{RSVPID: rsvpID, Name: "Jane Doe", PlusOne: true, Allergies: []string{"peanuts", "shellfish"}},
+			{RSVPID: rsvpID, Name: "John Doe", MealChoice: "Fish"},
+		},
+	}
+
+	req, _ = http.NewRequest("GET", "/api/v1/weddings/"+weddingID.String()+"/rsvps/meal-report", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"no_meal_choice":1`)
+	assert.Contains(t, w.Body.String(), `{"option":"Fish","count":1}`)
+
+	req, _ = http.NewRequest("GET", "/api/v1/weddings/"+weddingID.String()+"/rsvps/meal-report?format=csv", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "meal-report.csv")
+	assert.Equal(t, strings.Join([]string{
+		"rsvp_id,name,plus_one,meal_choice,allergies,dietary_notes",
+		rsvpID.String() + ",Jane Doe,true,,peanuts; shellfish,",
+		rsvpID.String() + ",John Doe,false,Fish,,",
+	}, "\n"), strings.TrimSpace(w.Body.String()))
+}
+
+func TestRSVPHandler_ReviewRSVP(t *testing.T) {
+	router, mockService := setupRSVPRouter()
+
+	flagged := &models.RSVP{
+		ID:        models.NewID(),
+		WeddingID: models.NewID(),
+		FirstName: "Mallory",
+		Review:    &models.RSVPReview{Status: models.RSVPReviewPending, Score: 80},
+	}
+	mockService.rsvps[flagged.ID] = flagged
+
+	review := func(decision string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(ReviewRSVPRequest{Decision: decision})
+		req, _ := http.NewRequest("POST", "/api/v1/rsvps/"+flagged.ID.String()+"/review", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusBadRequest, review("later").Code)
+
+	w := review(services.RSVPReviewReject)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, models.RSVPReviewRejected, flagged.Review.Status)
+
+	assert.Equal(t, http.StatusConflict, review(services.RSVPReviewAccept).Code)
+}
+
+// MockExportJobRecorder records export jobs in memory
+type MockExportJobRecorder struct {
+	jobs []*models.ExportJob
+}
+
+func (m *MockExportJobRecorder) StartExport(ctx context.Context, weddingID, userID models.ID, resource, format string, recipient utils.ExportRecipient) (*models.ExportJob, error) {
+	job := &models.ExportJob{ID: models.NewID(), WeddingID: weddingID, UserID: userID, Resource: resource, Format: format, Status: models.ExportJobRunning}
+	if recipient != nil {
+		job.Encryption = recipient.Type()
+		job.KeyFingerprint = recipient.Fingerprint()
+	}
+	m.jobs = append(m.jobs, job)
+	return job, nil
+}
+
+func (m *MockExportJobRecorder) FinishExport(ctx context.Context, job *models.ExportJob, records int, exportErr error) {
+	job.Status = models.ExportJobCompleted
+	if exportErr != nil {
+		job.Status = models.ExportJobFailed
+	}
+	job.Records = records
+}
+
+func (m *MockExportJobRecorder) ListExportJobs(ctx context.Context, weddingID, userID models.ID, page, pageSize int) ([]*models.ExportJob, int64, error) {
+	return m.jobs, int64(len(m.jobs)), nil
+}
+
+func TestRSVPHandler_ExportRSVPs_Encrypted(t *testing.T) {
+	router, mockService := setupRSVPRouter()
+
+	weddingID := models.NewID()
+	rsvp := &models.RSVP{ID: models.NewID(), WeddingID: weddingID, FirstName: "John", LastName: "Doe", Status: "attending"}
+	mockService.rsvps[rsvp.ID] = rsvp
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	body, _ := json.Marshal(ExportRequest{Format: "csv", PublicKey: identity.Recipient().String()})
+	req, _ := http.NewRequest("POST", "/api/v1/weddings/"+weddingID.String()+"/rsvps/export", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "rsvps.csv.age")
+	assert.NotEmpty(t, w.Header().Get("X-Export-Key-Fingerprint"))
+	assert.NotContains(t, w.Body.String(), "John")
+
+	r, err := age.Decrypt(w.Body, identity)
+	require.NoError(t, err)
+	plaintext, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Contains(t, string(plaintext), "first_name")
+	assert.Contains(t, string(plaintext), "John")
+}
+
+func TestRSVPHandler_ExportRSVPs_RecordsJob(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := NewMockRSVPService()
+	recorder := &MockExportJobRecorder{}
+	handler := NewRSVPHandler(mockService)
+	handler.EnableExportJobs(recorder)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", models.NewID().String())
+		c.Next()
+	})
+	router.GET("/weddings/:id/rsvps/export", handler.ExportRSVPs)
+
+	weddingID := models.NewID()
+	for i := 0; i < 3; i++ {
+		rsvp := &models.RSVP{ID: models.NewID(), WeddingID: weddingID, FirstName: "Guest", Status: "attending"}
+		mockService.rsvps[rsvp.ID] = rsvp
+	}
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest("GET", "/weddings/"+weddingID.String()+"/rsvps/export?public_key="+identity.Recipient().String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, recorder.jobs, 1)
+	job := recorder.jobs[0]
+	assert.Equal(t, job.ID.String(), w.Header().Get("X-Export-Job-ID"))
+	assert.Equal(t, models.ExportJobCompleted, job.Status)
+	assert.Equal(t, 3, job.Records)
+	assert.Equal(t, utils.ExportKeyAge, job.Encryption)
+	assert.Equal(t, w.Header().Get("X-Export-Key-Fingerprint"), job.KeyFingerprint)
+}
+
+func TestRSVPHandler_ExportRSVPs_InvalidPublicKey(t *testing.T) {
+	router, _ := setupRSVPRouter()
+
+	body, _ := json.Marshal(ExportRequest{PublicKey: "not-a-key"})
+	req, _ := http.NewRequest("POST", "/api/v1/weddings/"+models.NewID().String()+"/rsvps/export", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 // Helper functions
 func intPtr(i int) *int {
 	return &i
 }
+
+// MockRSVPSubmissionQueue for write-behind handler testing
+type MockRSVPSubmissionQueue struct {
+	submissions map[models.ID]*models.RSVPSubmission
+}
+
+func (m *MockRSVPSubmissionQueue) Enqueue(ctx context.Context, weddingID models.ID, req services.SubmitRSVPRequest) (*models.RSVPSubmission, error) {
+	submission := &models.RSVPSubmission{
+		ID:        models.NewID(),
+		WeddingID: weddingID,
+		Status:    models.RSVPSubmissionQueued,
+		CreatedAt: time.Now(),
+	}
+	m.submissions[submission.ID] = submission
+	return submission, nil
+}
+
+func (m *MockRSVPSubmissionQueue) GetSubmission(ctx context.Context, weddingID, id models.ID) (*models.RSVPSubmission, error) {
+	submission, exists := m.submissions[id]
+	if !exists || submission.WeddingID != weddingID {
+		return nil, services.ErrRSVPSubmissionNotFound
+	}
+	return submission, nil
+}
+
+func TestRSVPHandler_SubmitRSVP_WriteBehind(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := NewMockRSVPService()
+	queue := &MockRSVPSubmissionQueue{submissions: make(map[models.ID]*models.RSVPSubmission)}
+	handler := NewRSVPHandler(mockService)
+	handler.EnableWriteBehind(queue)
+
+	router := gin.New()
+	router.POST("/api/v1/public/weddings/:id/rsvp", handler.SubmitRSVP)
+	router.GET("/api/v1/public/weddings/:id/rsvp/submissions/:submission_id", handler.GetSubmissionStatus)
+
+	weddingID := models.NewID()
+	body, _ := json.Marshal(services.SubmitRSVPRequest{
+		FirstName:       "John",
+		LastName:        "Doe",
+		Status:          "attending",
+		AttendanceCount: 1,
+		Source:          "web",
+	})
+	req, _ := http.NewRequest("POST", "/api/v1/public/weddings/"+weddingID.String()+"/rsvp", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Empty(t, mockService.rsvps)
+	require.Len(t, queue.submissions, 1)
+
+	location := w.Header().Get("Location")
+	assert.NotEmpty(t, location)
+
+	req, _ = http.NewRequest("GET", location, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest("GET", "/api/v1/public/weddings/"+weddingID.String()+"/rsvp/submissions/"+models.NewID().String(), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRSVPHandler_EditLink(t *testing.T) {
+	router, mockService := setupRSVPRouter()
+	rsvp := &models.RSVP{
+		ID:              models.NewID(),
+		WeddingID:       models.NewID(),
+		FirstName:       "John",
+		LastName:        "Doe",
+		Email:           "john@example.com",
+		Status:          "attending",
+		AttendanceCount: 1,
+		Notes:           "Owner's note",
+	}
+	mockService.rsvps[rsvp.ID] = rsvp
+	mockService.editTokens = map[string]models.ID{"edit-token": rsvp.ID}
+
+	req, _ := http.NewRequest("GET", "/api/v1/public/rsvp/other-token", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req, _ = http.NewRequest("GET", "/api/v1/public/rsvp/edit-token", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"first_name":"John"`)
+	assert.NotContains(t, w.Body.String(), "Owner's note")
+
+	body := `{"status":"maybe","meal_choice":"Fish","companions":[{"id":"c1","first_name":"Jane"}]}`
+	req, _ = http.NewRequest("PUT", "/api/v1/public/rsvp/edit-token", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "maybe", rsvp.Status)
+	assert.Equal(t, "Fish", rsvp.MealChoice)
+	require.Len(t, rsvp.PlusOnes, 1)
+	assert.Equal(t, "c1", rsvp.PlusOnes[0].ID)
+
+	req, _ = http.NewRequest("PUT", "/api/v1/public/rsvp/edit-token", strings.NewReader(`{"attendance_count":11}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// SessionManager lists and ends the sessions signed in to an account
+type SessionManager interface {
+	ListSessions(ctx context.Context, userID, currentID models.ID) ([]*models.Session, error)
+	RevokeSession(ctx context.Context, userID, id models.ID) error
+	RevokeOtherSessions(ctx context.Context, userID, currentID models.ID) (int64, error)
+}
+
+// RevokedSessionsResponse reports how many sessions were ended
+type RevokedSessionsResponse struct {
+	Revoked int64 `json:"revoked"`
+}
+
+// SessionHandler serves the signed-in devices of the authenticated account
+type SessionHandler struct {
+	sessions SessionManager
+}
+
+// NewSessionHandler creates a new session handler
+func NewSessionHandler(sessions SessionManager) *SessionHandler {
+	return &SessionHandler{sessions: sessions}
+}
+
+// ListSessions godoc
+// @Summary List sessions
+// @Description List the devices signed in to the current account, most recently seen first. The session of the request is marked current.
+// @Tags users
+// @Produce json
+// @Success 200 {array} models.Session
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/sessions [get]
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	sessions, err := h.sessions.ListSessions(c.Request.Context(), userID, currentSessionID(c))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to list sessions")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, sessions)
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Sign a device out. Its access token stays valid until it expires, but it can no longer be refreshed.
+// @Tags users
+// @Param id path string true "Session ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/sessions/{id} [delete]
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	sessionID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid session ID")
+		return
+	}
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	if err := h.sessions.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		if errors.Is(err, services.ErrSessionNotFound) {
+			utils.ErrorResponse(c, http.StatusNotFound, "Session not found")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to revoke session")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeOtherSessions godoc
+// @Summary Revoke all other sessions
+// @Description Sign out every device but the one making the request
+// @Tags users
+// @Produce json
+// @Success 200 {object} RevokedSessionsResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/users/sessions [delete]
+func (h *SessionHandler) RevokeOtherSessions(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	revoked, err := h.sessions.RevokeOtherSessions(c.Request.Context(), userID, currentSessionID(c))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to revoke sessions")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, RevokedSessionsResponse{Revoked: revoked})
+}
+
+// currentSessionID returns the session the request's access token was issued
+// to, or a nil ID for tokens without one and API keys
+func currentSessionID(c *gin.Context) models.ID {
+	id, err := models.ParseID(c.GetString("deviceID"))
+	if err != nil {
+		return models.NilID
+	}
+	return id
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockSessionManager is a mock implementation of SessionManager
+type MockSessionManager struct {
+	mock.Mock
+}
+
+func (m *MockSessionManager) ListSessions(ctx context.Context, userID, currentID models.ID) ([]*models.Session, error) {
+	args := m.Called(ctx, userID, currentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Session), args.Error(1)
+}
+
+func (m *MockSessionManager) RevokeSession(ctx context.Context, userID, id models.ID) error {
+	return m.Called(ctx, userID, id).Error(0)
+}
+
+func (m *MockSessionManager) RevokeOtherSessions(ctx context.Context, userID, currentID models.ID) (int64, error) {
+	args := m.Called(ctx, userID, currentID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func setupSessionTestRouter(handler *SessionHandler, userID, sessionID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	users := router.Group("/api/v1/users", func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Set("deviceID", sessionID.String())
+		c.Next()
+	})
+	users.GET("/sessions", handler.ListSessions)
+	users.DELETE("/sessions", handler.RevokeOtherSessions)
+	users.DELETE("/sessions/:id", handler.RevokeSession)
+	return router
+}
+
+func sendSessionRequest(router *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, "/api/v1/users/sessions"+path, nil))
+	return w
+}
+
+func TestSessionHandler_ListSessions(t *testing.T) {
+	userID, sessionID := models.NewID(), models.NewID()
+	manager := &MockSessionManager{}
+	manager.On("ListSessions", mock.Anything, userID, sessionID).Return([]*models.Session{
+		{ID: sessionID, Browser: "safari", Current: true},
+	}, nil)
+
+	w := sendSessionRequest(setupSessionTestRouter(NewSessionHandler(manager), userID, sessionID), http.MethodGet, "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"current":true`)
+	assert.NotContains(t, w.Body.String(), "refresh_token_id")
+	manager.AssertExpectations(t)
+}
+
+func TestSessionHandler_RevokeSession(t *testing.T) {
+	userID, sessionID, otherID := models.NewID(), models.NewID(), models.NewID()
+
+	t.Run("Success", func(t *testing.T) {
+		manager := &MockSessionManager{}
+		manager.On("RevokeSession", mock.Anything, userID, otherID).Return(nil)
+
+		w := sendSessionRequest(setupSessionTestRouter(NewSessionHandler(manager), userID, sessionID), http.MethodDelete, "/"+otherID.String())
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		manager.AssertExpectations(t)
+	})
+
+	t.Run("Error - not found", func(t *testing.T) {
+		manager := &MockSessionManager{}
+		manager.On("RevokeSession", mock.Anything, userID, otherID).Return(services.ErrSessionNotFound)
+
+		w := sendSessionRequest(setupSessionTestRouter(NewSessionHandler(manager), userID, sessionID), http.MethodDelete, "/"+otherID.String())
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Error - invalid ID", func(t *testing.T) {
+		manager := &MockSessionManager{}
+
+		w := sendSessionRequest(setupSessionTestRouter(NewSessionHandler(manager), userID, sessionID), http.MethodDelete, "/not-an-id")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		manager.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSessionHandler_RevokeOtherSessions(t *testing.T) {
+	userID, sessionID := models.NewID(), models.NewID()
+	manager := &MockSessionManager{}
+	manager.On("RevokeOtherSessions", mock.Anything, userID, sessionID).Return(int64(3), nil)
+
+	w := sendSessionRequest(setupSessionTestRouter(NewSessionHandler(manager), userID, sessionID), http.MethodDelete, "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"revoked":3`)
+	manager.AssertExpectations(t)
+}
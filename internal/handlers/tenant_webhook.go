@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// TenantWebhookHandler handles tenant lifecycle webhook HTTP requests
+type TenantWebhookHandler struct {
+	webhookService *services.TenantWebhookService
+}
+
+// NewTenantWebhookHandler creates a new tenant webhook handler
+func NewTenantWebhookHandler(webhookService *services.TenantWebhookService) *TenantWebhookHandler {
+	return &TenantWebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateTenantWebhookResponse includes the tenant signing secret when it was generated by this request
+type CreateTenantWebhookResponse struct {
+	*models.TenantWebhook
+	Secret string `json:"secret,omitempty"`
+}
+
+// RotateTenantSecretResponse contains the new tenant signing secret
+type RotateTenantSecretResponse struct {
+	Secret string `json:"secret"`
+}
+
+// ReplayTenantWebhookRequest selects which outbox events to replay
+type ReplayTenantWebhookRequest struct {
+	Since time.Time `json:"since" validate:"required"`
+}
+
+// CreateWebhook godoc
+// @Summary Create a tenant webhook
+// @Description Subscribe an endpoint to platform lifecycle events for the current tenant (tenant admin only)
+// @Tags tenant
+// @Accept json
+// @Produce json
+// @Param webhook body services.CreateTenantWebhookRequest true "Webhook data"
+// @Success 201 {object} CreateTenantWebhookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tenant/webhooks [post]
+func (h *TenantWebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req services.CreateTenantWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, secret, err := h.webhookService.CreateWebhook(c.Request.Context(), userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create tenant webhook")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, CreateTenantWebhookResponse{
+		TenantWebhook: webhook,
+		Secret:        secret,
+	})
+}
+
+// ListWebhooks godoc
+// @Summary List tenant webhooks
+// @Description List the lifecycle webhooks of the current tenant
+// @Tags tenant
+// @Produce json
+// @Success 200 {array} models.TenantWebhook
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tenant/webhooks [get]
+func (h *TenantWebhookHandler) ListWebhooks(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	webhooks, err := h.webhookService.ListWebhooks(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list tenant webhooks")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, webhooks)
+}
+
+// UpdateWebhook godoc
+// @Summary Update a tenant webhook
+// @Description Change the endpoint, event filter, or active state of a tenant webhook
+// @Tags tenant
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param webhook body services.UpdateTenantWebhookRequest true "Webhook changes"
+// @Success 200 {object} models.TenantWebhook
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tenant/webhooks/{id} [put]
+func (h *TenantWebhookHandler) UpdateWebhook(c *gin.Context) {
+	webhookID, userID, ok := h.parseWebhookRequest(c)
+	if !ok {
+		return
+	}
+
+	var req services.UpdateTenantWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, err := h.webhookService.UpdateWebhook(c.Request.Context(), webhookID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update tenant webhook")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a tenant webhook
+// @Description Delete a tenant webhook and its delivery log
+// @Tags tenant
+// @Param id path string true "Webhook ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tenant/webhooks/{id} [delete]
+func (h *TenantWebhookHandler) DeleteWebhook(c *gin.Context) {
+	webhookID, userID, ok := h.parseWebhookRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), webhookID, userID); err != nil {
+		h.handleError(c, err, "Failed to delete tenant webhook")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary List tenant webhook deliveries
+// @Description Get the paginated delivery log for a tenant webhook, newest first
+// @Tags tenant
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PaginatedResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tenant/webhooks/{id}/deliveries [get]
+func (h *TenantWebhookHandler) ListDeliveries(c *gin.Context) {
+	webhookID, userID, ok := h.parseWebhookRequest(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+
+	deliveries, total, err := h.webhookService.ListDeliveries(c.Request.Context(), webhookID, userID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err, "Failed to list tenant webhook deliveries")
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, deliveries, int64(len(deliveries)), total, page, pageSize)
+}
+
+// ReplayWebhook godoc
+// @Summary Replay lifecycle events
+// @Description Redeliver the tenant's outbox events since a point in time to a webhook
+// @Tags tenant
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param replay body ReplayTenantWebhookRequest true "Replay window"
+// @Success 200 {object} services.TenantWebhookReplayResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tenant/webhooks/{id}/replay [post]
+func (h *TenantWebhookHandler) ReplayWebhook(c *gin.Context) {
+	webhookID, userID, ok := h.parseWebhookRequest(c)
+	if !ok {
+		return
+	}
+
+	var req ReplayTenantWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.webhookService.Replay(c.Request.Context(), webhookID, userID, req.Since)
+	if err != nil {
+		h.handleError(c, err, "Failed to replay lifecycle events")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, result)
+}
+
+// RotateSecret godoc
+// @Summary Rotate the tenant signing secret
+// @Description Replace the secret used to sign all of the tenant's webhook deliveries
+// @Tags tenant
+// @Produce json
+// @Success 200 {object} RotateTenantSecretResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tenant/webhooks/secret/rotate [post]
+func (h *TenantWebhookHandler) RotateSecret(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	secret, err := h.webhookService.RotateSecret(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to rotate signing secret")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, RotateTenantSecretResponse{Secret: secret})
+}
+
+func (h *TenantWebhookHandler) parseWebhookRequest(c *gin.Context) (models.ID, models.ID, bool) {
+	webhookID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid webhook ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+
+	return webhookID, userID, true
+}
+
+func (h *TenantWebhookHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrTenantWebhookNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Tenant webhook not found")
+	case errors.Is(err, services.ErrNotTenantAdmin), errors.Is(err, services.ErrUserNotFound):
+		utils.ErrorResponse(c, http.StatusForbidden, "Tenant admin access required")
+	case errors.Is(err, services.ErrInvalidWebhookURL), errors.Is(err, services.ErrWebhookURLNotPublic), errors.Is(err, services.ErrInvalidLifecycleEvent):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// ThemeManager lists the theme catalog and lets admins maintain it
+type ThemeManager interface {
+	ListAvailableThemes(ctx context.Context) ([]*models.Theme, error)
+	ListThemes(ctx context.Context) ([]*models.Theme, error)
+	GetTheme(ctx context.Context, id string) (*models.Theme, error)
+	CreateTheme(ctx context.Context, req services.CreateThemeRequest) (*models.Theme, error)
+	UpdateTheme(ctx context.Context, id string, req services.UpdateThemeRequest) (*models.Theme, error)
+	DeleteTheme(ctx context.Context, id string) error
+}
+
+// ThemeHandler serves the theme catalog
+type ThemeHandler struct {
+	themes ThemeManager
+}
+
+// NewThemeHandler creates a new theme handler
+func NewThemeHandler(themes ThemeManager) *ThemeHandler {
+	return &ThemeHandler{themes: themes}
+}
+
+// ListThemes godoc
+// @Summary List available themes
+// @Description List the active themes couples may choose, with their preview images, sections, customizable colors and whether they need a premium plan
+// @Tags themes
+// @Produce json
+// @Success 200 {array} models.Theme
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/themes [get]
+func (h *ThemeHandler) ListThemes(c *gin.Context) {
+	themes, err := h.themes.ListAvailableThemes(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to list themes")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, themes)
+}
+
+// AdminListThemes godoc
+// @Summary List the theme catalog
+// @Description List every theme, inactive ones included (admin only)
+// @Tags admin
+// @Produce json
+// @Success 200 {array} models.Theme
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/themes [get]
+func (h *ThemeHandler) AdminListThemes(c *gin.Context) {
+	themes, err := h.themes.ListThemes(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to list themes")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, themes)
+}
+
+// AdminGetTheme godoc
+// @Summary Get a theme
+// @Description Get a theme of the catalog (admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "Theme ID"
+// @Success 200 {object} models.Theme
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/themes/{id} [get]
+func (h *ThemeHandler) AdminGetTheme(c *gin.Context) {
+	theme, err := h.themes.GetTheme(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err, "Failed to get theme")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, theme)
+}
+
+// AdminCreateTheme godoc
+// @Summary Create a theme
+// @Description Add a theme to the catalog (admin only). A new default theme replaces the previous one.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param theme body services.CreateThemeRequest true "Theme data"
+// @Success 201 {object} models.Theme
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/themes [post]
+func (h *ThemeHandler) AdminCreateTheme(c *gin.Context) {
+	var req services.CreateThemeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	theme, err := h.themes.CreateTheme(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create theme")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, theme)
+}
+
+// AdminUpdateTheme godoc
+// @Summary Update a theme
+// @Description Change a theme of the catalog (admin only). Deactivated themes stay on the weddings using them.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Theme ID"
+// @Param theme body services.UpdateThemeRequest true "Theme changes"
+// @Success 200 {object} models.Theme
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/themes/{id} [put]
+func (h *ThemeHandler) AdminUpdateTheme(c *gin.Context) {
+	var req services.UpdateThemeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	theme, err := h.themes.UpdateTheme(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update theme")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, theme)
+}
+
+// AdminDeleteTheme godoc
+// @Summary Delete a theme
+// @Description Remove a theme no wedding uses from the catalog (admin only)
+// @Tags admin
+// @Param id path string true "Theme ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/themes/{id} [delete]
+func (h *ThemeHandler) AdminDeleteTheme(c *gin.Context) {
+	if err := h.themes.DeleteTheme(c.Request.Context(), c.Param("id")); err != nil {
+		h.handleError(c, err, "Failed to delete theme")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ThemeHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrThemeNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Theme not found")
+	case errors.Is(err, services.ErrInvalidTheme):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrThemeExists), errors.Is(err, services.ErrThemeInUse):
+		utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
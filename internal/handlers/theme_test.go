@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockThemeManager is a mock implementation of ThemeManager
+type MockThemeManager struct {
+	mock.Mock
+}
+
+func (m *MockThemeManager) ListAvailableThemes(ctx context.Context) ([]*models.Theme, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Theme), args.Error(1)
+}
+
+func (m *MockThemeManager) ListThemes(ctx context.Context) ([]*models.Theme, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Theme), args.Error(1)
+}
+
+func (m *MockThemeManager) GetTheme(ctx context.Context, id string) (*models.Theme, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Theme), args.Error(1)
+}
+
+func (m *MockThemeManager) CreateTheme(ctx context.Context, req services.CreateThemeRequest) (*models.Theme, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Theme), args.Error(1)
+}
+
+func (m *MockThemeManager) UpdateTheme(ctx context.Context, id string, req services.UpdateThemeRequest) (*models.Theme, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Theme), args.Error(1)
+}
+
+func (m *MockThemeManager) DeleteTheme(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func setupThemeTestRouter(handler *ThemeHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.GET("/api/v1/themes", handler.ListThemes)
+	admin := router.Group("/api/v1/admin/themes")
+	admin.GET("", handler.AdminListThemes)
+	admin.POST("", handler.AdminCreateTheme)
+	admin.GET("/:id", handler.AdminGetTheme)
+	admin.PUT("/:id", handler.AdminUpdateTheme)
+	admin.DELETE("/:id", handler.AdminDeleteTheme)
+
+	return router
+}
+
+func TestThemeHandler_ListThemes(t *testing.T) {
+	themes := new(MockThemeManager)
+	themes.On("ListAvailableThemes", mock.Anything).Return([]*models.Theme{
+		{ID: "classic", Name: "Classic", Active: true, Sections: models.ThemeSections},
+		{ID: "royal", Name: "Royal", Active: true, Premium: true},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/themes", nil)
+	w := httptest.NewRecorder()
+	setupThemeTestRouter(NewThemeHandler(themes)).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":"royal"`)
+	assert.Contains(t, w.Body.String(), `"premium":true`)
+}
+
+func TestThemeHandler_AdminCreateTheme(t *testing.T) {
+	valid := services.CreateThemeRequest{
+		ID:             "sunset",
+		Name:           "Sunset",
+		PrimaryColor:   "#E07A5F",
+		SecondaryColor: "#F4F1DE",
+		FontFamily:     "Lora",
+	}
+
+	tests := []struct {
+		name     string
+		req      services.CreateThemeRequest
+		err      error
+		expected int
+	}{
+		{"created", valid, nil, http.StatusCreated},
+		{"exists", valid, services.ErrThemeExists, http.StatusConflict},
+		{"invalid", valid, fmt.Errorf("%w: unknown section", services.ErrInvalidTheme), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			themes := new(MockThemeManager)
+			if tt.err != nil {
+				themes.On("CreateTheme", mock.Anything, tt.req).Return(nil, tt.err)
+			} else {
+				themes.On("CreateTheme", mock.Anything, tt.req).Return(&models.Theme{ID: tt.req.ID, Name: tt.req.Name}, nil)
+			}
+
+			body, err := json.Marshal(tt.req)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/themes", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupThemeTestRouter(NewThemeHandler(themes)).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			themes.AssertExpectations(t)
+		})
+	}
+
+	t.Run("invalid color", func(t *testing.T) {
+		themes := new(MockThemeManager)
+		req := valid
+		req.PrimaryColor = "orange"
+
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+		httpReq := httptest.NewRequest(http.MethodPost, "/api/v1/admin/themes", bytes.NewBuffer(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setupThemeTestRouter(NewThemeHandler(themes)).ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		themes.AssertNotCalled(t, "CreateTheme", mock.Anything, mock.Anything)
+	})
+}
+
+func TestThemeHandler_AdminDeleteTheme(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"deleted", nil, http.StatusNoContent},
+		{"in use", services.ErrThemeInUse, http.StatusConflict},
+		{"not found", services.ErrThemeNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			themes := new(MockThemeManager)
+			themes.On("DeleteTheme", mock.Anything, "garden").Return(tt.err)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/themes/garden", nil)
+			w := httptest.NewRecorder()
+			setupThemeTestRouter(NewThemeHandler(themes)).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// TwoFactorManager manages two-factor authentication of an account
+type TwoFactorManager interface {
+	Setup(ctx context.Context, userID models.ID) (*services.TwoFactorSetup, error)
+	Enable(ctx context.Context, userID models.ID, code string) (*services.TwoFactorBackupCodes, error)
+	Disable(ctx context.Context, userID models.ID, password, code string) error
+	RegenerateBackupCodes(ctx context.Context, userID models.ID, code string) (*services.TwoFactorBackupCodes, error)
+}
+
+// TwoFactorCodeRequest carries a code from the authenticator app
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required,max=20"`
+}
+
+// DisableTwoFactorRequest confirms turning two-factor authentication off
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required,max=20"`
+}
+
+// TwoFactorHandler serves two-factor authentication settings of the authenticated account
+type TwoFactorHandler struct {
+	twoFactor TwoFactorManager
+}
+
+// NewTwoFactorHandler creates a new two-factor handler
+func NewTwoFactorHandler(twoFactor TwoFactorManager) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactor: twoFactor}
+}
+
+// Setup godoc
+// @Summary Start two-factor setup
+// @Description Generate a TOTP secret with its provisioning URI and QR code. Two-factor authentication is enabled once a code is verified.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} services.TwoFactorSetup
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/auth/2fa/setup [post]
+func (h *TwoFactorHandler) Setup(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	setup, err := h.twoFactor.Setup(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to set up two-factor authentication")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, setup)
+}
+
+// Verify godoc
+// @Summary Enable two-factor authentication
+// @Description Confirm the secret from setup with a code from the authenticator app. The backup codes are only shown once.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body TwoFactorCodeRequest true "Code from the authenticator app"
+// @Success 200 {object} services.TwoFactorBackupCodes
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/auth/2fa/verify [post]
+func (h *TwoFactorHandler) Verify(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req TwoFactorCodeRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	codes, err := h.twoFactor.Enable(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		h.handleError(c, err, "Failed to enable two-factor authentication")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, codes)
+}
+
+// Disable godoc
+// @Summary Disable two-factor authentication
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body DisableTwoFactorRequest true "Password and a code from the app or a backup code"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/auth/2fa/disable [post]
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req DisableTwoFactorRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	if err := h.twoFactor.Disable(c.Request.Context(), userID, req.Password, req.Code); err != nil {
+		h.handleError(c, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	utils.SuccessResponse(c, "Two-factor authentication disabled")
+}
+
+// RegenerateBackupCodes godoc
+// @Summary Regenerate backup codes
+// @Description Replace all backup codes. Requires a code from the authenticator app.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body TwoFactorCodeRequest true "Code from the authenticator app"
+// @Success 200 {object} services.TwoFactorBackupCodes
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/auth/2fa/backup-codes [post]
+func (h *TwoFactorHandler) RegenerateBackupCodes(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req TwoFactorCodeRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	codes, err := h.twoFactor.RegenerateBackupCodes(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		h.handleError(c, err, "Failed to regenerate backup codes")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, codes)
+}
+
+func (h *TwoFactorHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, services.ErrTwoFactorNotEnabled),
+		errors.Is(err, services.ErrTwoFactorNotSetUp):
+		utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrInvalidTwoFactorCode):
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid two-factor code")
+	case errors.Is(err, services.ErrInvalidCredentials):
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Password is incorrect")
+	case errors.Is(err, services.ErrUserNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "User not found")
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockTwoFactorManager is a mock implementation of TwoFactorManager
+type MockTwoFactorManager struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorManager) Setup(ctx context.Context, userID models.ID) (*services.TwoFactorSetup, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.TwoFactorSetup), args.Error(1)
+}
+
+func (m *MockTwoFactorManager) Enable(ctx context.Context, userID models.ID, code string) (*services.TwoFactorBackupCodes, error) {
+	args := m.Called(ctx, userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.TwoFactorBackupCodes), args.Error(1)
+}
+
+func (m *MockTwoFactorManager) Disable(ctx context.Context, userID models.ID, password, code string) error {
+	return m.Called(ctx, userID, password, code).Error(0)
+}
+
+func (m *MockTwoFactorManager) RegenerateBackupCodes(ctx context.Context, userID models.ID, code string) (*services.TwoFactorBackupCodes, error) {
+	args := m.Called(ctx, userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.TwoFactorBackupCodes), args.Error(1)
+}
+
+func setupTwoFactorTestRouter(handler *TwoFactorHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	account := router.Group("/api/v1/auth/2fa", func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	account.POST("/setup", handler.Setup)
+	account.POST("/verify", handler.Verify)
+	account.POST("/disable", handler.Disable)
+	account.POST("/backup-codes", handler.RegenerateBackupCodes)
+	return router
+}
+
+func postTwoFactor(router *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/2fa"+path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestTwoFactorHandler_Setup(t *testing.T) {
+	userID := models.NewID()
+
+	t.Run("Success", func(t *testing.T) {
+		manager := &MockTwoFactorManager{}
+		manager.On("Setup", mock.Anything, userID).Return(&services.TwoFactorSetup{
+			Secret:          "JBSWY3DPEHPK3PXP",
+			ProvisioningURI: "otpauth://totp/x",
+		}, nil)
+
+		w := postTwoFactor(setupTwoFactorTestRouter(NewTwoFactorHandler(manager), userID), "/setup", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "otpauth://totp/x")
+		manager.AssertExpectations(t)
+	})
+
+	t.Run("Error - already enabled", func(t *testing.T) {
+		manager := &MockTwoFactorManager{}
+		manager.On("Setup", mock.Anything, userID).Return(nil, services.ErrTwoFactorAlreadyEnabled)
+
+		w := postTwoFactor(setupTwoFactorTestRouter(NewTwoFactorHandler(manager), userID), "/setup", "")
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestTwoFactorHandler_Verify(t *testing.T) {
+	userID := models.NewID()
+
+	tests := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+	}{
+		{"Success", `{"code":"123456"}`, nil, http.StatusOK},
+		{"Error - wrong code", `{"code":"123456"}`, services.ErrInvalidTwoFactorCode, http.StatusBadRequest},
+		{"Error - not set up", `{"code":"123456"}`, services.ErrTwoFactorNotSetUp, http.StatusConflict},
+		{"Error - missing code", `{}`, nil, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &MockTwoFactorManager{}
+			if tt.err != nil {
+				manager.On("Enable", mock.Anything, userID, "123456").Return(nil, tt.err)
+			} else {
+				manager.On("Enable", mock.Anything, userID, "123456").Return(&services.TwoFactorBackupCodes{BackupCodes: []string{"abcde-12345"}}, nil)
+			}
+
+			w := postTwoFactor(setupTwoFactorTestRouter(NewTwoFactorHandler(manager), userID), "/verify", tt.body)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusOK {
+				assert.Contains(t, w.Body.String(), "abcde-12345")
+			}
+		})
+	}
+}
+
+func TestTwoFactorHandler_Disable(t *testing.T) {
+	userID := models.NewID()
+
+	t.Run("Success", func(t *testing.T) {
+		manager := &MockTwoFactorManager{}
+		manager.On("Disable", mock.Anything, userID, "secret", "123456").Return(nil)
+
+		w := postTwoFactor(setupTwoFactorTestRouter(NewTwoFactorHandler(manager), userID), "/disable",
+			`{"password":"secret","code":"123456"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		manager.AssertExpectations(t)
+	})
+
+	t.Run("Error - wrong password", func(t *testing.T) {
+		manager := &MockTwoFactorManager{}
+		manager.On("Disable", mock.Anything, userID, "wrong", "123456").Return(services.ErrInvalidCredentials)
+
+		w := postTwoFactor(setupTwoFactorTestRouter(NewTwoFactorHandler(manager), userID), "/disable",
+			`{"password":"wrong","code":"123456"}`)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"wedding-invitation-backend/internal/domain/models"
 )
 
 // ErrorResponse represents an error response
@@ -26,5 +26,5 @@ type PaginationResponse struct {
 
 // IDResponse represents an ID response
 type IDResponse struct {
-	ID primitive.ObjectID `json:"id"`
+	ID models.ID `json:"id"`
 }
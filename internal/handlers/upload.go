@@ -1,22 +1,25 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"mime/multipart"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
 	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
 )
 
 // UploadHandler handles file upload requests
 type UploadHandler struct {
 	mediaService services.MediaService
+	sessions     UploadSessionManager // nil unless resumable uploads are enabled
 	logger       *zap.Logger
 }
 
@@ -40,7 +43,11 @@ type FileMetadata struct {
 	MimeType string `json:"mimeType"`
 }
 
-// UploadResponse represents the response for a successful upload
+// UploadResponse represents the response for a successful upload. Thumbnails
+// maps size names to URLs, with WebP and AVIF renditions of a size keyed
+// "<size>_webp" and "<size>_avif"; videos get a "poster" frame. Status is
+// pending or processing until background work such as video transcoding is
+// done, then ready (or failed).
 type UploadResponse struct {
 	ID          string                 `json:"id"`
 	Filename    string                 `json:"filename"`
@@ -52,6 +59,10 @@ type UploadResponse struct {
 	Height      int                    `json:"height,omitempty"`
 	Format      string                 `json:"format,omitempty"`
 	EXIF        map[string]interface{} `json:"exif,omitempty"`
+	Duration    float64                `json:"duration,omitempty"`
+	PlaybackURL string                 `json:"playbackUrl,omitempty"`
+	Status      string                 `json:"status"`
+	Threat      string                 `json:"threat,omitempty"`
 	CreatedAt   string                 `json:"createdAt"`
 }
 
@@ -62,15 +73,54 @@ type PresignedURLRequest struct {
 	Size        int64  `json:"size" binding:"required"`
 }
 
-// PresignedUploadResponse represents a pre-signed upload URL response
+// PresignedUploadResponse represents a pre-signed upload URL response. With
+// method POST the fields and then the file are sent as a multipart form; with
+// PUT the file is the raw request body.
 type PresignedUploadResponse struct {
 	UploadURL string            `json:"uploadUrl"`
+	Method    string            `json:"method"`
 	Fields    map[string]string `json:"fields,omitempty"`
 	Key       string            `json:"key"`
 	MediaID   string            `json:"mediaId"`
 	ExpiresAt string            `json:"expiresAt"`
 }
 
+// MultipartUploadRequest represents a request to start a multipart upload
+type MultipartUploadRequest struct {
+	Filename    string `json:"filename" binding:"required"`
+	ContentType string `json:"contentType" binding:"required"`
+	Size        int64  `json:"size" binding:"required,min=1"`
+}
+
+// MultipartUploadResponse lists the pre-signed URLs of every part of a multipart upload
+type MultipartUploadResponse struct {
+	UploadID  string                   `json:"uploadId"`
+	Key       string                   `json:"key"`
+	MediaID   string                   `json:"mediaId"`
+	PartSize  int64                    `json:"partSize"`
+	Parts     []services.PresignedPart `json:"parts"`
+	ExpiresAt string                   `json:"expiresAt"`
+}
+
+// CompleteMultipartUploadRequest represents a request to finish a multipart upload
+type CompleteMultipartUploadRequest struct {
+	Key      string                   `json:"key" binding:"required"`
+	UploadID string                   `json:"uploadId" binding:"required"`
+	Parts    []services.CompletedPart `json:"parts" binding:"required,min=1,dive"`
+}
+
+// AbortMultipartUploadRequest represents a request to discard a multipart upload
+type AbortMultipartUploadRequest struct {
+	Key      string `json:"key" binding:"required"`
+	UploadID string `json:"uploadId" binding:"required"`
+}
+
+// CompleteMultipartUploadResponse represents a finished multipart upload
+type CompleteMultipartUploadResponse struct {
+	Key string `json:"key"`
+	URL string `json:"url"`
+}
+
 // MediaListResponse represents a paginated list of media files
 type MediaListResponse struct {
 	Media      []*UploadResponse `json:"media"`
@@ -90,6 +140,7 @@ type MediaListResponse struct {
 // @Param files formData file true "Files to upload"
 // @Success 200 {array} UploadResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
 // @Failure 413 {object} ErrorResponse
 // @Failure 415 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -128,6 +179,10 @@ func (h *UploadHandler) HandleUpload(c *gin.Context) {
 	// Upload files
 	mediaFiles, err := h.mediaService.UploadFiles(ctx, filesMap, *userID)
 	if err != nil {
+		if errors.Is(err, services.ErrPlanLimitExceeded) {
+			respondWithError(c, http.StatusPaymentRequired, err.Error())
+			return
+		}
 		h.logger.Error("Failed to upload files", zap.Error(err))
 		respondWithError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -144,7 +199,7 @@ func (h *UploadHandler) HandleUpload(c *gin.Context) {
 
 // HandleSingleUpload handles single file upload for simplicity
 // @Summary Upload single file
-// @Description Upload a single file with validation and processing
+// @Description Upload a single image or video with validation and processing. Videos (mp4, webm) are transcoded in the background and stay pending until their playback rendition is ready.
 // @Tags upload
 // @Accept multipart/form-data
 // @Produce json
@@ -152,6 +207,7 @@ func (h *UploadHandler) HandleUpload(c *gin.Context) {
 // @Param file formData file true "File to upload"
 // @Success 200 {object} UploadResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
 // @Failure 413 {object} ErrorResponse
 // @Failure 415 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -176,8 +232,7 @@ func (h *UploadHandler) HandleSingleUpload(c *gin.Context) {
 	// Upload file
 	media, err := h.mediaService.UploadFile(ctx, file, header, *userID)
 	if err != nil {
-		h.logger.Error("Failed to upload file", zap.Error(err))
-		respondWithError(c, http.StatusInternalServerError, err.Error())
+		h.respondWithUploadError(c, err)
 		return
 	}
 
@@ -195,6 +250,7 @@ func (h *UploadHandler) HandleSingleUpload(c *gin.Context) {
 // @Param request body PresignedURLRequest true "Presigned URL request"
 // @Success 200 {object} PresignedUploadResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/upload/presign [post]
 func (h *UploadHandler) HandlePresignURL(c *gin.Context) {
@@ -232,6 +288,10 @@ func (h *UploadHandler) HandlePresignURL(c *gin.Context) {
 	presignedInfo, err := h.mediaService.GeneratePresignedUploadURL(
 		ctx, req.Filename, req.ContentType, req.Size, *userID)
 	if err != nil {
+		if errors.Is(err, services.ErrPlanLimitExceeded) {
+			respondWithError(c, http.StatusPaymentRequired, err.Error())
+			return
+		}
 		h.logger.Error("Failed to generate presigned upload URL", zap.Error(err))
 		respondWithError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -245,15 +305,164 @@ func (h *UploadHandler) HandlePresignURL(c *gin.Context) {
 
 	response := PresignedUploadResponse{
 		UploadURL: presignedInfo.URL,
+		Method:    presignedInfo.Method,
 		Fields:    presignedInfo.Fields,
 		Key:       presignedInfo.Key,
 		MediaID:   mediaID,
-		ExpiresAt: "", // Calculate from expiry time
+		ExpiresAt: presignedInfo.ExpiresAt.Format(time.RFC3339),
 	}
 
 	respondWithJSON(c, http.StatusOK, response)
 }
 
+// HandleInitiateMultipartUpload starts a direct multipart upload of a large file
+// @Summary Start multipart upload
+// @Description Start a multipart upload straight to storage. PUT each part to its URL, keep the ETag response header of each, then complete the upload.
+// @Tags upload
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body MultipartUploadRequest true "Multipart upload request"
+// @Success 200 {object} MultipartUploadResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 501 {object} ErrorResponse
+// @Router /api/v1/upload/multipart [post]
+func (h *UploadHandler) HandleInitiateMultipartUpload(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := h.getUserIDFromContext(c)
+	if userID == nil {
+		respondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req MultipartUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		respondWithError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	info, err := h.mediaService.InitiateMultipartUpload(ctx, req.Filename, req.ContentType, req.Size, *userID)
+	if err != nil {
+		h.respondWithMultipartError(c, err, "Failed to start multipart upload")
+		return
+	}
+
+	respondWithJSON(c, http.StatusOK, MultipartUploadResponse{
+		UploadID:  info.UploadID,
+		Key:       info.Key,
+		MediaID:   info.MediaID,
+		PartSize:  info.PartSize,
+		Parts:     info.Parts,
+		ExpiresAt: info.ExpiresAt.Format(time.RFC3339),
+	})
+}
+
+// HandleCompleteMultipartUpload assembles the uploaded parts of a multipart upload
+// @Summary Complete multipart upload
+// @Description Assemble the uploaded parts into the final file
+// @Tags upload
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body CompleteMultipartUploadRequest true "Uploaded parts"
+// @Success 200 {object} CompleteMultipartUploadResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 501 {object} ErrorResponse
+// @Router /api/v1/upload/multipart/complete [post]
+func (h *UploadHandler) HandleCompleteMultipartUpload(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := h.getUserIDFromContext(c)
+	if userID == nil {
+		respondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req CompleteMultipartUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		respondWithError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	url, err := h.mediaService.CompleteMultipartUpload(ctx, req.Key, req.UploadID, req.Parts, *userID)
+	if err != nil {
+		h.respondWithMultipartError(c, err, "Failed to complete multipart upload")
+		return
+	}
+
+	respondWithJSON(c, http.StatusOK, CompleteMultipartUploadResponse{Key: req.Key, URL: url})
+}
+
+// HandleAbortMultipartUpload discards an unfinished multipart upload
+// @Summary Abort multipart upload
+// @Description Discard a multipart upload and the parts uploaded so far
+// @Tags upload
+// @Accept json
+// @Param Authorization header string true "Bearer token"
+// @Param request body AbortMultipartUploadRequest true "Upload to abort"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 501 {object} ErrorResponse
+// @Router /api/v1/upload/multipart/abort [post]
+func (h *UploadHandler) HandleAbortMultipartUpload(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := h.getUserIDFromContext(c)
+	if userID == nil {
+		respondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req AbortMultipartUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		respondWithError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.mediaService.AbortMultipartUpload(ctx, req.Key, req.UploadID, *userID); err != nil {
+		h.respondWithMultipartError(c, err, "Failed to abort multipart upload")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondWithUploadError maps errors of the upload pipeline to responses
+func (h *UploadHandler) respondWithUploadError(c *gin.Context, err error) {
+	h.logger.Error("Failed to upload file", zap.Error(err))
+	switch {
+	case errors.Is(err, services.ErrPlanLimitExceeded):
+		respondWithError(c, http.StatusPaymentRequired, err.Error())
+	case errors.Is(err, services.ErrVideoUnsupported):
+		respondWithError(c, http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, services.ErrVideoTooLong):
+		respondWithError(c, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, services.ErrMalwareDetected):
+		respondWithError(c, http.StatusUnprocessableEntity, err.Error())
+	default:
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// respondWithMultipartError maps multipart upload errors to responses
+func (h *UploadHandler) respondWithMultipartError(c *gin.Context, err error, message string) {
+	if errors.Is(err, services.ErrMultipartUploadUnsupported) {
+		respondWithError(c, http.StatusNotImplemented, err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrPlanLimitExceeded) {
+		respondWithError(c, http.StatusPaymentRequired, err.Error())
+		return
+	}
+	h.logger.Error(message, zap.Error(err))
+	respondWithError(c, http.StatusInternalServerError, err.Error())
+}
+
 // HandleConfirmUpload confirms a file uploaded via pre-signed URL
 // @Summary Confirm pre-signed upload
 // @Description Confirm a file that was uploaded using a pre-signed URL
@@ -262,6 +471,7 @@ func (h *UploadHandler) HandlePresignURL(c *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "Bearer token"
 // @Param request body ConfirmUploadRequest true "Confirm upload request"
+// @Param Idempotency-Key header string false "Key that makes retries of the request safe"
 // @Success 200 {object} UploadResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -288,7 +498,7 @@ func (h *UploadHandler) HandleConfirmUpload(c *gin.Context) {
 	}
 
 	// Validate MediaID format
-	if _, err := primitive.ObjectIDFromHex(req.MediaID); err != nil {
+	if _, err := models.ParseID(req.MediaID); err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid media ID format")
 		return
 	}
@@ -315,7 +525,7 @@ func (h *UploadHandler) HandleConfirmUpload(c *gin.Context) {
 
 // HandleGetMedia retrieves a media file by ID
 // @Summary Get media file
-// @Description Retrieve media file metadata by ID
+// @Description Retrieve media file metadata by ID, including its processing status (pending, processing, ready or failed)
 // @Tags upload
 // @Produce json
 // @Param Authorization header string true "Bearer token"
@@ -334,7 +544,7 @@ func (h *UploadHandler) HandleGetMedia(c *gin.Context) {
 	}
 
 	mediaIDStr := c.Param("id")
-	mediaID, err := primitive.ObjectIDFromHex(mediaIDStr)
+	mediaID, err := models.ParseID(mediaIDStr)
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid media ID format")
 		return
@@ -438,7 +648,7 @@ func (h *UploadHandler) HandleDeleteMedia(c *gin.Context) {
 	}
 
 	mediaIDStr := c.Param("id")
-	mediaID, err := primitive.ObjectIDFromHex(mediaIDStr)
+	mediaID, err := models.ParseID(mediaIDStr)
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid media ID format")
 		return
@@ -462,32 +672,19 @@ type ConfirmUploadRequest struct {
 	Key     string `json:"key" binding:"required"`
 }
 
-// getUserIDFromContext extracts user ID from JWT token context
-func (h *UploadHandler) getUserIDFromContext(c *gin.Context) *primitive.ObjectID {
-	// This should extract user ID from JWT token set by auth middleware
-	// For now, we'll use a placeholder implementation
-	userIDStr, exists := c.Get("userID")
-	if !exists {
-		return nil
-	}
-
-	userIDStrStr, ok := userIDStr.(string)
-	if !ok {
-		return nil
-	}
-
-	userID, err := primitive.ObjectIDFromHex(userIDStrStr)
+// getUserIDFromContext returns the user authenticated by the auth middleware, or nil
+func (h *UploadHandler) getUserIDFromContext(c *gin.Context) *models.ID {
+	userID, err := utils.GetUserIDFromContext(c)
 	if err != nil {
 		return nil
 	}
-
 	return &userID
 }
 
 // convertMediaToResponse converts a media model to upload response
 func (h *UploadHandler) convertMediaToResponse(media *models.Media) *UploadResponse {
 	return &UploadResponse{
-		ID:          media.ID.Hex(),
+		ID:          media.ID.String(),
 		Filename:    media.Filename,
 		OriginalURL: media.OriginalURL,
 		Thumbnails:  media.Thumbnails,
@@ -497,6 +694,10 @@ func (h *UploadHandler) convertMediaToResponse(media *models.Media) *UploadRespo
 		Height:      media.Height,
 		Format:      media.Format,
 		EXIF:        media.EXIF,
+		Duration:    media.Duration,
+		PlaybackURL: media.PlaybackURL,
+		Status:      string(media.ProcessingStatus()),
+		Threat:      media.Threat,
 		CreatedAt:   media.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
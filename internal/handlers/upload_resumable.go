@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// Headers of the resumable upload protocol, borrowed from tus
+const (
+	headerUploadOffset = "Upload-Offset"
+	headerUploadLength = "Upload-Length"
+	// chunkContentType is the content type of PATCH request bodies
+	chunkContentType = "application/offset+octet-stream"
+)
+
+// UploadSessionManager takes uploads in chunks that can resume after a dropped connection
+type UploadSessionManager interface {
+	CreateSession(ctx context.Context, filename string, size int64, userID models.ID) (*models.UploadSession, error)
+	GetSession(ctx context.Context, id, userID models.ID) (*models.UploadSession, error)
+	AppendChunk(ctx context.Context, id, userID models.ID, offset int64, data io.Reader) (*models.UploadSession, error)
+	CompleteSession(ctx context.Context, id, userID models.ID) (*models.Media, error)
+	CancelSession(ctx context.Context, id, userID models.ID) error
+}
+
+// EnableResumableUploads serves chunked uploads through the upload session manager
+func (h *UploadHandler) EnableResumableUploads(sessions UploadSessionManager) {
+	h.sessions = sessions
+}
+
+// CreateUploadSessionRequest represents a request to start a resumable upload
+type CreateUploadSessionRequest struct {
+	Filename string `json:"filename" binding:"required"`
+	Size     int64  `json:"size" binding:"required,min=1"`
+}
+
+// UploadSessionResponse describes a resumable upload. Offset is the number of
+// bytes received, from which the next chunk continues.
+type UploadSessionResponse struct {
+	ID        string `json:"id"`
+	Filename  string `json:"filename"`
+	Size      int64  `json:"size"`
+	Offset    int64  `json:"offset"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+// HandleCreateUploadSession starts a resumable upload
+// @Summary Start a resumable upload
+// @Description Start an upload sent in chunks that can resume after a dropped connection. The file type and size are checked up front.
+// @Tags upload
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body CreateUploadSessionRequest true "File to upload"
+// @Success 201 {object} UploadSessionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 501 {object} ErrorResponse
+// @Router /api/v1/upload/resumable [post]
+func (h *UploadHandler) HandleCreateUploadSession(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == nil {
+		respondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	if !h.resumableUploadsEnabled(c) {
+		return
+	}
+
+	var req CreateUploadSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	session, err := h.sessions.CreateSession(c.Request.Context(), req.Filename, req.Size, *userID)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.Header("Location", c.Request.URL.Path+"/"+session.ID.String())
+	setUploadSessionHeaders(c, session)
+	respondWithJSON(c, http.StatusCreated, convertUploadSessionToResponse(session))
+}
+
+// HandleGetUploadSession reports how much of a resumable upload was received
+// @Summary Get resumable upload progress
+// @Description Get the offset to resume a resumable upload from, in the Upload-Offset header and the body
+// @Tags upload
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Upload session ID"
+// @Success 200 {object} UploadSessionResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/upload/resumable/{id} [head]
+func (h *UploadHandler) HandleGetUploadSession(c *gin.Context) {
+	userID, sessionID, ok := h.parseUploadSessionRequest(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.sessions.GetSession(c.Request.Context(), sessionID, *userID)
+	if err != nil {
+		h.respondWithUploadSessionError(c, err)
+		return
+	}
+
+	setUploadSessionHeaders(c, session)
+	c.Header("Cache-Control", "no-store")
+	respondWithJSON(c, http.StatusOK, convertUploadSessionToResponse(session))
+}
+
+// HandleUploadChunk stores the next chunk of a resumable upload
+// @Summary Upload a chunk
+// @Description Append the request body to a resumable upload. Upload-Offset must be the number of bytes received so far; the response carries the new one. Bytes received before a dropped connection are kept.
+// @Tags upload
+// @Accept application/offset+octet-stream
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Upload session ID"
+// @Param Upload-Offset header int true "Offset of the chunk"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Router /api/v1/upload/resumable/{id} [patch]
+func (h *UploadHandler) HandleUploadChunk(c *gin.Context) {
+	userID, sessionID, ok := h.parseUploadSessionRequest(c)
+	if !ok {
+		return
+	}
+
+	if c.ContentType() != chunkContentType {
+		respondWithError(c, http.StatusUnsupportedMediaType, "Chunks must be sent as "+chunkContentType)
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader(headerUploadOffset), 10, 64)
+	if err != nil || offset < 0 {
+		respondWithError(c, http.StatusBadRequest, "Invalid Upload-Offset header")
+		return
+	}
+
+	session, err := h.sessions.AppendChunk(c.Request.Context(), sessionID, *userID, offset, c.Request.Body)
+	if session != nil {
+		setUploadSessionHeaders(c, session)
+	}
+	if err != nil {
+		h.respondWithUploadSessionError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// HandleCompleteUploadSession processes a fully received resumable upload
+// @Summary Complete a resumable upload
+// @Description Validate, process and store a resumable upload once every byte was received
+// @Tags upload
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Upload session ID"
+// @Success 200 {object} UploadResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/upload/resumable/{id}/complete [post]
+func (h *UploadHandler) HandleCompleteUploadSession(c *gin.Context) {
+	userID, sessionID, ok := h.parseUploadSessionRequest(c)
+	if !ok {
+		return
+	}
+
+	media, err := h.sessions.CompleteSession(c.Request.Context(), sessionID, *userID)
+	if err != nil {
+		if errors.Is(err, services.ErrUploadSessionNotFound) || errors.Is(err, services.ErrUploadIncomplete) {
+			h.respondWithUploadSessionError(c, err)
+			return
+		}
+		h.respondWithUploadError(c, err)
+		return
+	}
+
+	respondWithJSON(c, http.StatusOK, h.convertMediaToResponse(media))
+}
+
+// HandleCancelUploadSession discards a resumable upload
+// @Summary Cancel a resumable upload
+// @Description Discard a resumable upload and the bytes it received
+// @Tags upload
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Upload session ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/upload/resumable/{id} [delete]
+func (h *UploadHandler) HandleCancelUploadSession(c *gin.Context) {
+	userID, sessionID, ok := h.parseUploadSessionRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.sessions.CancelSession(c.Request.Context(), sessionID, *userID); err != nil {
+		h.respondWithUploadSessionError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// resumableUploadsEnabled answers 501 when resumable uploads are not served
+func (h *UploadHandler) resumableUploadsEnabled(c *gin.Context) bool {
+	if h.sessions == nil {
+		respondWithError(c, http.StatusNotImplemented, "Resumable uploads are not enabled")
+		return false
+	}
+	return true
+}
+
+// parseUploadSessionRequest reads the user and session ID of requests on an upload session
+func (h *UploadHandler) parseUploadSessionRequest(c *gin.Context) (*models.ID, models.ID, bool) {
+	userID := h.getUserIDFromContext(c)
+	if userID == nil {
+		respondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return nil, models.NilID, false
+	}
+	if !h.resumableUploadsEnabled(c) {
+		return nil, models.NilID, false
+	}
+
+	sessionID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid upload session ID")
+		return nil, models.NilID, false
+	}
+	return userID, sessionID, true
+}
+
+// respondWithUploadSessionError maps upload session errors to responses
+func (h *UploadHandler) respondWithUploadSessionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrUploadSessionNotFound):
+		respondWithError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrUploadOffsetMismatch), errors.Is(err, services.ErrUploadIncomplete):
+		respondWithError(c, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrUploadChunkTooLarge):
+		respondWithError(c, http.StatusRequestEntityTooLarge, err.Error())
+	default:
+		h.logger.Error("Resumable upload failed", zap.Error(err))
+		respondWithError(c, http.StatusInternalServerError, "Failed to store upload")
+	}
+}
+
+func setUploadSessionHeaders(c *gin.Context, session *models.UploadSession) {
+	c.Header(headerUploadOffset, strconv.FormatInt(session.Offset, 10))
+	c.Header(headerUploadLength, strconv.FormatInt(session.Size, 10))
+}
+
+func convertUploadSessionToResponse(session *models.UploadSession) *UploadSessionResponse {
+	return &UploadSessionResponse{
+		ID:        session.ID.String(),
+		Filename:  session.Filename,
+		Size:      session.Size,
+		Offset:    session.Offset,
+		ExpiresAt: session.ExpiresAt.Format(time.RFC3339),
+	}
+}
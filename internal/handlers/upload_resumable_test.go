@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockUploadSessionManager is a mock implementation of UploadSessionManager
+type MockUploadSessionManager struct {
+	mock.Mock
+}
+
+func (m *MockUploadSessionManager) CreateSession(ctx context.Context, filename string, size int64, userID models.ID) (*models.UploadSession, error) {
+	args := m.Called(ctx, filename, size, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UploadSession), args.Error(1)
+}
+
+func (m *MockUploadSessionManager) GetSession(ctx context.Context, id, userID models.ID) (*models.UploadSession, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UploadSession), args.Error(1)
+}
+
+func (m *MockUploadSessionManager) AppendChunk(ctx context.Context, id, userID models.ID, offset int64, data io.Reader) (*models.UploadSession, error) {
+	args := m.Called(ctx, id, userID, offset, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UploadSession), args.Error(1)
+}
+
+func (m *MockUploadSessionManager) CompleteSession(ctx context.Context, id, userID models.ID) (*models.Media, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Media), args.Error(1)
+}
+
+func (m *MockUploadSessionManager) CancelSession(ctx context.Context, id, userID models.ID) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func TestUploadHandler_ResumableUpload(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	userID := models.NewID()
+
+	setup := func() (*MockUploadSessionManager, *UploadHandler) {
+		sessions := new(MockUploadSessionManager)
+		handler := NewUploadHandler(new(MockMediaService), logger)
+		handler.EnableResumableUploads(sessions)
+		return sessions, handler
+	}
+
+	newSession := func(offset int64) *models.UploadSession {
+		return &models.UploadSession{
+			ID:        models.NewID(),
+			UserID:    userID,
+			Filename:  "dance.mp4",
+			Size:      100,
+			Offset:    offset,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	patch := func(handler *UploadHandler, id models.ID, offset, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/upload/resumable/"+id.String(), strings.NewReader(body))
+		req.Header.Set("Content-Type", chunkContentType)
+		req.Header.Set(headerUploadOffset, offset)
+		w := httptest.NewRecorder()
+		setupUploadTestRouter(handler, userID).ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("create", func(t *testing.T) {
+		sessions, handler := setup()
+		session := newSession(0)
+		sessions.On("CreateSession", mock.Anything, "dance.mp4", int64(100), userID).Return(session, nil)
+
+		reqBody, err := json.Marshal(CreateUploadSessionRequest{Filename: "dance.mp4", Size: 100})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/upload/resumable", bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setupUploadTestRouter(handler, userID).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "/api/v1/upload/resumable/"+session.ID.String(), w.Header().Get("Location"))
+		assert.Equal(t, "0", w.Header().Get(headerUploadOffset))
+
+		var response UploadSessionResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, session.ID.String(), response.ID)
+		assert.Equal(t, int64(100), response.Size)
+	})
+
+	t.Run("chunk", func(t *testing.T) {
+		sessions, handler := setup()
+		session := newSession(40)
+		sessions.On("AppendChunk", mock.Anything, session.ID, userID, int64(0), mock.Anything).Return(session, nil)
+
+		w := patch(handler, session.ID, "0", strings.Repeat("a", 40))
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "40", w.Header().Get(headerUploadOffset))
+	})
+
+	t.Run("chunk at the wrong offset", func(t *testing.T) {
+		sessions, handler := setup()
+		session := newSession(40)
+		sessions.On("AppendChunk", mock.Anything, session.ID, userID, int64(10), mock.Anything).Return(session, services.ErrUploadOffsetMismatch)
+
+		w := patch(handler, session.ID, "10", "aaaa")
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "40", w.Header().Get(headerUploadOffset))
+	})
+
+	t.Run("chunk without offset", func(t *testing.T) {
+		sessions, handler := setup()
+
+		w := patch(handler, models.NewID(), "", "aaaa")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		sessions.AssertNotCalled(t, "AppendChunk")
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		sessions, handler := setup()
+		session := newSession(100)
+		media := createTestMedia()
+		sessions.On("CompleteSession", mock.Anything, session.ID, userID).Return(media, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/upload/resumable/"+session.ID.String()+"/complete", nil)
+		w := httptest.NewRecorder()
+		setupUploadTestRouter(handler, userID).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response UploadResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, media.ID.String(), response.ID)
+	})
+
+	t.Run("complete before every byte arrived", func(t *testing.T) {
+		sessions, handler := setup()
+		id := models.NewID()
+		sessions.On("CompleteSession", mock.Anything, id, userID).Return(nil, services.ErrUploadIncomplete)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/upload/resumable/"+id.String()+"/complete", nil)
+		w := httptest.NewRecorder()
+		setupUploadTestRouter(handler, userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		sessions, handler := setup()
+		id := models.NewID()
+		sessions.On("GetSession", mock.Anything, id, userID).Return(nil, services.ErrUploadSessionNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/upload/resumable/"+id.String(), nil)
+		w := httptest.NewRecorder()
+		setupUploadTestRouter(handler, userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		handler := NewUploadHandler(new(MockMediaService), logger)
+
+		reqBody, err := json.Marshal(CreateUploadSessionRequest{Filename: "dance.mp4", Size: 100})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/upload/resumable", bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setupUploadTestRouter(handler, userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotImplemented, w.Code)
+	})
+}
@@ -15,8 +15,6 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap/zaptest"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
@@ -28,7 +26,7 @@ type MockMediaService struct {
 	mock.Mock
 }
 
-func (m *MockMediaService) UploadFile(ctx context.Context, file io.Reader, header *multipart.FileHeader, userID primitive.ObjectID) (*models.Media, error) {
+func (m *MockMediaService) UploadFile(ctx context.Context, file io.Reader, header *multipart.FileHeader, userID models.ID) (*models.Media, error) {
 	args := m.Called(ctx, file, header, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -36,12 +34,12 @@ func (m *MockMediaService) UploadFile(ctx context.Context, file io.Reader, heade
 	return args.Get(0).(*models.Media), args.Error(1)
 }
 
-func (m *MockMediaService) UploadFiles(ctx context.Context, files map[string][]*multipart.FileHeader, userID primitive.ObjectID) ([]*models.Media, error) {
+func (m *MockMediaService) UploadFiles(ctx context.Context, files map[string][]*multipart.FileHeader, userID models.ID) ([]*models.Media, error) {
 	args := m.Called(ctx, mock.AnythingOfType("map[string][]*multipart.FileHeader"), userID)
 	return args.Get(0).([]*models.Media), args.Error(1)
 }
 
-func (m *MockMediaService) GetMedia(ctx context.Context, mediaID primitive.ObjectID) (*models.Media, error) {
+func (m *MockMediaService) GetMedia(ctx context.Context, mediaID models.ID) (*models.Media, error) {
 	args := m.Called(ctx, mediaID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -49,33 +47,66 @@ func (m *MockMediaService) GetMedia(ctx context.Context, mediaID primitive.Objec
 	return args.Get(0).(*models.Media), args.Error(1)
 }
 
-func (m *MockMediaService) GetUserMedia(ctx context.Context, userID primitive.ObjectID, page, pageSize int, filters repository.MediaFilter) ([]*models.Media, int64, error) {
+func (m *MockMediaService) GetUserMedia(ctx context.Context, userID models.ID, page, pageSize int, filters repository.MediaFilter) ([]*models.Media, int64, error) {
 	args := m.Called(ctx, userID, page, pageSize, filters)
 	return args.Get(0).([]*models.Media), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockMediaService) DeleteMedia(ctx context.Context, mediaID, userID primitive.ObjectID) error {
+func (m *MockMediaService) DeleteMedia(ctx context.Context, mediaID, userID models.ID) error {
 	args := m.Called(ctx, mediaID, userID)
 	return args.Error(0)
 }
 
-func (m *MockMediaService) GeneratePresignedUploadURL(ctx context.Context, filename, contentType string, size int64, userID primitive.ObjectID) (*services.PresignedUploadInfo, error) {
+func (m *MockMediaService) GeneratePresignedUploadURL(ctx context.Context, filename, contentType string, size int64, userID models.ID) (*services.PresignedUploadInfo, error) {
 	args := m.Called(ctx, filename, contentType, size, userID)
 	return args.Get(0).(*services.PresignedUploadInfo), args.Error(1)
 }
 
-func (m *MockMediaService) ProcessUploadedFile(ctx context.Context, presignedInfo *services.PresignedUploadInfo, userID primitive.ObjectID) (*models.Media, error) {
+func (m *MockMediaService) ProcessUploadedFile(ctx context.Context, presignedInfo *services.PresignedUploadInfo, userID models.ID) (*models.Media, error) {
 	args := m.Called(ctx, presignedInfo, userID)
 	return args.Get(0).(*models.Media), args.Error(1)
 }
 
-func setupUploadTestRouter(handler *UploadHandler, userID primitive.ObjectID) *gin.Engine {
+func (m *MockMediaService) InitiateMultipartUpload(ctx context.Context, filename, contentType string, size int64, userID models.ID) (*services.MultipartUploadInfo, error) {
+	args := m.Called(ctx, filename, contentType, size, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.MultipartUploadInfo), args.Error(1)
+}
+
+func (m *MockMediaService) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []services.CompletedPart, userID models.ID) (string, error) {
+	args := m.Called(ctx, key, uploadID, parts, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMediaService) AbortMultipartUpload(ctx context.Context, key, uploadID string, userID models.ID) error {
+	args := m.Called(ctx, key, uploadID, userID)
+	return args.Error(0)
+}
+
+func (m *MockMediaService) GenerateThumbnails(ctx context.Context, mediaID models.ID) error {
+	args := m.Called(ctx, mediaID)
+	return args.Error(0)
+}
+
+func (m *MockMediaService) TranscodeVideo(ctx context.Context, mediaID models.ID, lastAttempt bool) error {
+	args := m.Called(ctx, mediaID, lastAttempt)
+	return args.Error(0)
+}
+
+func (m *MockMediaService) PurgeUserMedia(ctx context.Context, userID models.ID) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func setupUploadTestRouter(handler *UploadHandler, userID models.ID) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
 	// Mock auth middleware to set user ID
 	router.Use(func(c *gin.Context) {
-		c.Set("userID", userID.Hex())
+		c.Set("user_id", userID.String())
 		c.Next()
 	})
 
@@ -85,6 +116,14 @@ func setupUploadTestRouter(handler *UploadHandler, userID primitive.ObjectID) *g
 		v1.POST("/upload/single", handler.HandleSingleUpload)
 		v1.POST("/upload/presign", handler.HandlePresignURL)
 		v1.POST("/upload/confirm", handler.HandleConfirmUpload)
+		v1.POST("/upload/multipart", handler.HandleInitiateMultipartUpload)
+		v1.POST("/upload/multipart/complete", handler.HandleCompleteMultipartUpload)
+		v1.POST("/upload/multipart/abort", handler.HandleAbortMultipartUpload)
+		v1.POST("/upload/resumable", handler.HandleCreateUploadSession)
+		v1.GET("/upload/resumable/:id", handler.HandleGetUploadSession)
+		v1.PATCH("/upload/resumable/:id", handler.HandleUploadChunk)
+		v1.POST("/upload/resumable/:id/complete", handler.HandleCompleteUploadSession)
+		v1.DELETE("/upload/resumable/:id", handler.HandleCancelUploadSession)
 		v1.GET("/media/:id", handler.HandleGetMedia)
 		v1.GET("/media", handler.HandleListMedia)
 		v1.DELETE("/media/:id", handler.HandleDeleteMedia)
@@ -94,9 +133,9 @@ func setupUploadTestRouter(handler *UploadHandler, userID primitive.ObjectID) *g
 }
 
 func createTestMedia() *models.Media {
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	return &models.Media{
-		ID:          primitive.NewObjectID(),
+		ID:          models.NewID(),
 		Filename:    "test-photo.jpg",
 		OriginalURL: "http://example.com/test-photo.jpg",
 		Thumbnails: map[string]string{
@@ -133,7 +172,7 @@ func TestUploadHandler_HandleSingleUpload(t *testing.T) {
 	mockService := new(MockMediaService)
 	handler := NewUploadHandler(mockService, logger)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	router := setupUploadTestRouter(handler, userID)
 
 	testMedia := createTestMedia()
@@ -167,6 +206,26 @@ func TestUploadHandler_HandleSingleUpload(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			expectError:    true,
 		},
+		{
+			name:        "video uploads unsupported",
+			filename:    "clip.mp4",
+			fileContent: []byte("test video content"),
+			setupMocks: func() {
+				mockService.On("UploadFile", mock.Anything, mock.Anything, mock.Anything, userID).Return(nil, services.ErrVideoUnsupported)
+			},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectError:    true,
+		},
+		{
+			name:        "video too long",
+			filename:    "clip.mp4",
+			fileContent: []byte("test video content"),
+			setupMocks: func() {
+				mockService.On("UploadFile", mock.Anything, mock.Anything, mock.Anything, userID).Return(nil, fmt.Errorf("%w: 5m0s exceeds the maximum of 3m0s", services.ErrVideoTooLong))
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectError:    true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -193,7 +252,7 @@ func TestUploadHandler_HandleSingleUpload(t *testing.T) {
 				var response UploadResponse
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				require.NoError(t, err)
-				assert.Equal(t, testMedia.ID.Hex(), response.ID)
+				assert.Equal(t, testMedia.ID.String(), response.ID)
 				assert.Equal(t, testMedia.Filename, response.Filename)
 			}
 
@@ -207,7 +266,7 @@ func TestUploadHandler_HandlePresignURL(t *testing.T) {
 	mockService := new(MockMediaService)
 	handler := NewUploadHandler(mockService, logger)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	router := setupUploadTestRouter(handler, userID)
 
 	presignedInfo := &services.PresignedUploadInfo{
@@ -303,7 +362,7 @@ func TestUploadHandler_HandleGetMedia(t *testing.T) {
 	mockService := new(MockMediaService)
 	handler := NewUploadHandler(mockService, logger)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	router := setupUploadTestRouter(handler, userID)
 	testMedia := createTestMedia()
 	testMedia.CreatedBy = userID
@@ -317,7 +376,7 @@ func TestUploadHandler_HandleGetMedia(t *testing.T) {
 	}{
 		{
 			name:    "successful retrieval",
-			mediaID: testMedia.ID.Hex(),
+			mediaID: testMedia.ID.String(),
 			setupMocks: func() {
 				mockService.On("GetMedia", mock.Anything, testMedia.ID).Return(testMedia, nil)
 			},
@@ -333,7 +392,7 @@ func TestUploadHandler_HandleGetMedia(t *testing.T) {
 		},
 		{
 			name:    "media not found",
-			mediaID: testMedia.ID.Hex(),
+			mediaID: testMedia.ID.String(),
 			setupMocks: func() {
 				mockService.On("GetMedia", mock.Anything, testMedia.ID).Return(nil, fmt.Errorf("media not found"))
 			},
@@ -362,8 +421,9 @@ func TestUploadHandler_HandleGetMedia(t *testing.T) {
 				var response UploadResponse
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				require.NoError(t, err)
-				assert.Equal(t, testMedia.ID.Hex(), response.ID)
+				assert.Equal(t, testMedia.ID.String(), response.ID)
 				assert.Equal(t, testMedia.Filename, response.Filename)
+				assert.Equal(t, "ready", response.Status)
 			}
 
 			if tt.name == "successful retrieval" {
@@ -371,6 +431,31 @@ func TestUploadHandler_HandleGetMedia(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("video being transcoded", func(t *testing.T) {
+		video := &models.Media{
+			ID:          models.NewID(),
+			Filename:    "first-dance.mp4",
+			OriginalURL: "http://example.com/uploads/first-dance.mp4",
+			MimeType:    "video/mp4",
+			Duration:    42.5,
+			Status:      models.MediaStatusProcessing,
+			CreatedBy:   userID,
+		}
+		mockService.ExpectedCalls = nil
+		mockService.On("GetMedia", mock.Anything, video.ID).Return(video, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/media/"+video.ID.String(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response UploadResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "processing", response.Status)
+		assert.Equal(t, 42.5, response.Duration)
+		assert.Empty(t, response.PlaybackURL)
+	})
 }
 
 func TestUploadHandler_HandleListMedia(t *testing.T) {
@@ -378,14 +463,14 @@ func TestUploadHandler_HandleListMedia(t *testing.T) {
 	mockService := new(MockMediaService)
 	handler := NewUploadHandler(mockService, logger)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	router := setupUploadTestRouter(handler, userID)
 
 	testMedia1 := createTestMedia()
 	testMedia1.CreatedBy = userID
 	testMedia2 := createTestMedia()
 	testMedia2.CreatedBy = userID
-	testMedia2.ID = primitive.NewObjectID()
+	testMedia2.ID = models.NewID()
 	testMedia2.Filename = "test2.jpg"
 
 	tests := []struct {
@@ -444,7 +529,7 @@ func TestUploadHandler_HandleDeleteMedia(t *testing.T) {
 	mockService := new(MockMediaService)
 	handler := NewUploadHandler(mockService, logger)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	router := setupUploadTestRouter(handler, userID)
 
 	testMedia := createTestMedia()
@@ -459,7 +544,7 @@ func TestUploadHandler_HandleDeleteMedia(t *testing.T) {
 	}{
 		{
 			name:    "successful deletion",
-			mediaID: testMedia.ID.Hex(),
+			mediaID: testMedia.ID.String(),
 			setupMocks: func() {
 				mockService.On("DeleteMedia", mock.Anything, testMedia.ID, userID).Return(nil)
 			},
@@ -475,7 +560,7 @@ func TestUploadHandler_HandleDeleteMedia(t *testing.T) {
 		},
 		{
 			name:    "deletion failure",
-			mediaID: testMedia.ID.Hex(),
+			mediaID: testMedia.ID.String(),
 			setupMocks: func() {
 				mockService.On("DeleteMedia", mock.Anything, testMedia.ID, userID).Return(fmt.Errorf("deletion failed"))
 			},
@@ -513,3 +598,87 @@ func TestUploadHandler_HandleDeleteMedia(t *testing.T) {
 		})
 	}
 }
+
+func TestUploadHandler_MultipartUpload(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	userID := models.NewID()
+
+	post := func(router *gin.Engine, path string, body interface{}) *httptest.ResponseRecorder {
+		reqBody, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("initiate returns part URLs", func(t *testing.T) {
+		mockService := new(MockMediaService)
+		router := setupUploadTestRouter(NewUploadHandler(mockService, logger), userID)
+
+		info := &services.MultipartUploadInfo{
+			UploadID: "upload-1",
+			Key:      "uploads/2026/10/15/abc/original.jpg",
+			MediaID:  "abc",
+			PartSize: 8 * 1024 * 1024,
+			Parts: []services.PresignedPart{
+				{PartNumber: 1, URL: "https://storage.example.com/part1"},
+				{PartNumber: 2, URL: "https://storage.example.com/part2"},
+			},
+		}
+		mockService.On("InitiateMultipartUpload", mock.Anything, "big.jpg", "image/jpeg", int64(12*1024*1024), userID).Return(info, nil)
+
+		w := post(router, "/api/v1/upload/multipart", MultipartUploadRequest{Filename: "big.jpg", ContentType: "image/jpeg", Size: 12 * 1024 * 1024})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response MultipartUploadResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "upload-1", response.UploadID)
+		assert.Len(t, response.Parts, 2)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("initiate on storage without multipart support", func(t *testing.T) {
+		mockService := new(MockMediaService)
+		router := setupUploadTestRouter(NewUploadHandler(mockService, logger), userID)
+		mockService.On("InitiateMultipartUpload", mock.Anything, "big.jpg", "image/jpeg", int64(1024), userID).Return(nil, services.ErrMultipartUploadUnsupported)
+
+		w := post(router, "/api/v1/upload/multipart", MultipartUploadRequest{Filename: "big.jpg", ContentType: "image/jpeg", Size: 1024})
+		assert.Equal(t, http.StatusNotImplemented, w.Code)
+	})
+
+	t.Run("complete passes the uploaded parts", func(t *testing.T) {
+		mockService := new(MockMediaService)
+		router := setupUploadTestRouter(NewUploadHandler(mockService, logger), userID)
+
+		parts := []services.CompletedPart{{PartNumber: 1, ETag: "etag-1"}, {PartNumber: 2, ETag: "etag-2"}}
+		mockService.On("CompleteMultipartUpload", mock.Anything, "uploads/key.jpg", "upload-1", parts, userID).Return("https://cdn.example.com/uploads/key.jpg", nil)
+
+		w := post(router, "/api/v1/upload/multipart/complete", CompleteMultipartUploadRequest{Key: "uploads/key.jpg", UploadID: "upload-1", Parts: parts})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response CompleteMultipartUploadResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "https://cdn.example.com/uploads/key.jpg", response.URL)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("complete requires parts", func(t *testing.T) {
+		mockService := new(MockMediaService)
+		router := setupUploadTestRouter(NewUploadHandler(mockService, logger), userID)
+
+		w := post(router, "/api/v1/upload/multipart/complete", CompleteMultipartUploadRequest{Key: "uploads/key.jpg", UploadID: "upload-1"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		mockService := new(MockMediaService)
+		router := setupUploadTestRouter(NewUploadHandler(mockService, logger), userID)
+		mockService.On("AbortMultipartUpload", mock.Anything, "uploads/key.jpg", "upload-1", userID).Return(nil)
+
+		w := post(router, "/api/v1/upload/multipart/abort", AbortMultipartUploadRequest{Key: "uploads/key.jpg", UploadID: "upload-1"})
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
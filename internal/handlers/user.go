@@ -3,18 +3,17 @@ package handlers
 import (
 	"net/http"
 	"strconv"
-	"strings"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
 	"wedding-invitation-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // UserHandler handles user-related HTTP requests
 type UserHandler struct {
 	userService *services.UserService
+	pii         *PIIAccess
 }
 
 // NewUserHandler creates a new user handler
@@ -24,6 +23,12 @@ func NewUserHandler(userService *services.UserService) *UserHandler {
 	}
 }
 
+// EnablePIIReveal allows admins to request unmasked user contact details,
+// recording each reveal with auditLog
+func (h *UserHandler) EnablePIIReveal(auditLog AuditLogger) {
+	h.pii = NewPIIAccess(auditLog)
+}
+
 // GetProfile handles GET /api/v1/users/profile
 func (h *UserHandler) GetProfile(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
@@ -33,7 +38,7 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	objectID, err := primitive.ObjectIDFromHex(userID.(string))
+	objectID, err := models.ParseID(userID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
@@ -42,11 +47,7 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 	// Get user profile
 	user, err := h.userService.GetUserProfile(c.Request.Context(), objectID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user profile"})
+		_ = c.Error(err)
 		return
 	}
 
@@ -62,7 +63,7 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	objectID, err := primitive.ObjectIDFromHex(userID.(string))
+	objectID, err := models.ParseID(userID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
@@ -78,11 +79,7 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	// Update user profile
 	user, err := h.userService.UpdateUserProfile(c.Request.Context(), objectID, &profile)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user profile"})
+		_ = c.Error(err)
 		return
 	}
 
@@ -120,6 +117,16 @@ func (h *UserHandler) GetUsersList(c *gin.Context) {
 		return
 	}
 
+	reveal, ok := h.pii.Reveal(c, "users", false, len(response.Users))
+	if !ok {
+		return
+	}
+	if !reveal {
+		masked := *response
+		masked.Users = maskUsers(response.Users)
+		response = &masked
+	}
+
 	c.JSON(http.StatusOK, response)
 }
 
@@ -144,6 +151,14 @@ func (h *UserHandler) SearchUsers(c *gin.Context) {
 		return
 	}
 
+	reveal, ok := h.pii.Reveal(c, "users", false, len(users))
+	if !ok {
+		return
+	}
+	if !reveal {
+		users = maskUsers(users)
+	}
+
 	c.JSON(http.StatusOK, gin.H{"users": users})
 }
 
@@ -151,7 +166,7 @@ func (h *UserHandler) SearchUsers(c *gin.Context) {
 func (h *UserHandler) UpdateUserStatus(c *gin.Context) {
 	// Get user ID from URL params
 	userIDStr := c.Param("id")
-	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	userID, err := models.ParseID(userIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
@@ -171,26 +186,46 @@ func (h *UserHandler) UpdateUserStatus(c *gin.Context) {
 
 	// Update user status
 	if err := h.userService.UpdateUserStatus(c.Request.Context(), userID, status); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
-			return
-		}
-		if strings.Contains(err.Error(), "invalid user status") {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user status"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user status"})
+		_ = c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "User status updated successfully"})
 }
 
+// UpdateUserRole handles PUT /api/v1/admin/users/:id/role (admin only)
+func (h *UserHandler) UpdateUserRole(c *gin.Context) {
+	// Get user ID from URL params
+	userIDStr := c.Param("id")
+	userID, err := models.ParseID(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	// Parse request body
+	var requestBody struct {
+		Role string `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	// Update user role
+	if err := h.userService.UpdateUserRole(c.Request.Context(), userID, requestBody.Role); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User role updated successfully"})
+}
+
 // DeleteUser handles DELETE /api/v1/admin/users/:id (admin only)
 func (h *UserHandler) DeleteUser(c *gin.Context) {
 	// Get user ID from URL params
 	userIDStr := c.Param("id")
-	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	userID, err := models.ParseID(userIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
@@ -198,11 +233,7 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 
 	// Delete user
 	if err := h.userService.DeleteUser(c.Request.Context(), userID); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete user"})
+		_ = c.Error(err)
 		return
 	}
 
@@ -230,7 +261,7 @@ func (h *UserHandler) GetUserWeddings(c *gin.Context) {
 		return
 	}
 
-	objectID, err := primitive.ObjectIDFromHex(userID.(string))
+	objectID, err := models.ParseID(userID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
@@ -239,11 +270,7 @@ func (h *UserHandler) GetUserWeddings(c *gin.Context) {
 	// Get user weddings
 	weddingIDs, err := h.userService.GetUserWeddings(c.Request.Context(), objectID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user weddings"})
+		_ = c.Error(err)
 		return
 	}
 
@@ -259,7 +286,7 @@ func (h *UserHandler) AddWeddingToUser(c *gin.Context) {
 		return
 	}
 
-	userObjectID, err := primitive.ObjectIDFromHex(userID.(string))
+	userObjectID, err := models.ParseID(userID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
@@ -267,7 +294,7 @@ func (h *UserHandler) AddWeddingToUser(c *gin.Context) {
 
 	// Get wedding ID from URL params
 	weddingIDStr := c.Param("wedding_id")
-	weddingID, err := primitive.ObjectIDFromHex(weddingIDStr)
+	weddingID, err := models.ParseID(weddingIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wedding ID"})
 		return
@@ -275,11 +302,7 @@ func (h *UserHandler) AddWeddingToUser(c *gin.Context) {
 
 	// Add wedding to user
 	if err := h.userService.AddWeddingToUser(c.Request.Context(), userObjectID, weddingID); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add wedding to user"})
+		_ = c.Error(err)
 		return
 	}
 
@@ -295,7 +318,7 @@ func (h *UserHandler) RemoveWeddingFromUser(c *gin.Context) {
 		return
 	}
 
-	userObjectID, err := primitive.ObjectIDFromHex(userID.(string))
+	userObjectID, err := models.ParseID(userID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
@@ -303,7 +326,7 @@ func (h *UserHandler) RemoveWeddingFromUser(c *gin.Context) {
 
 	// Get wedding ID from URL params
 	weddingIDStr := c.Param("wedding_id")
-	weddingID, err := primitive.ObjectIDFromHex(weddingIDStr)
+	weddingID, err := models.ParseID(weddingIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wedding ID"})
 		return
@@ -311,11 +334,7 @@ func (h *UserHandler) RemoveWeddingFromUser(c *gin.Context) {
 
 	// Remove wedding from user
 	if err := h.userService.RemoveWeddingFromUser(c.Request.Context(), userObjectID, weddingID); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove wedding from user"})
+		_ = c.Error(err)
 		return
 	}
 
@@ -14,7 +14,6 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
@@ -23,16 +22,16 @@ import (
 
 // UserServiceInterface defines the interface for UserService for testing
 type UserServiceInterface interface {
-	GetUserProfile(ctx context.Context, userID primitive.ObjectID) (*models.User, error)
-	UpdateUserProfile(ctx context.Context, userID primitive.ObjectID, profile *services.UserProfile) (*models.User, error)
+	GetUserProfile(ctx context.Context, userID models.ID) (*models.User, error)
+	UpdateUserProfile(ctx context.Context, userID models.ID, profile *services.UserProfile) (*models.User, error)
 	GetUsersList(ctx context.Context, page, pageSize int, filters repository.UserFilters) (*services.UserListResponse, error)
 	SearchUsers(ctx context.Context, query string, limit int) ([]*models.User, error)
-	UpdateUserStatus(ctx context.Context, userID primitive.ObjectID, status models.UserStatus) error
-	DeleteUser(ctx context.Context, userID primitive.ObjectID) error
+	UpdateUserStatus(ctx context.Context, userID models.ID, status models.UserStatus) error
+	DeleteUser(ctx context.Context, userID models.ID) error
 	GetUserStats(ctx context.Context) (map[string]int64, error)
-	GetUserWeddings(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error)
-	AddWeddingToUser(ctx context.Context, userID, weddingID primitive.ObjectID) error
-	RemoveWeddingFromUser(ctx context.Context, userID, weddingID primitive.ObjectID) error
+	GetUserWeddings(ctx context.Context, userID models.ID) ([]models.ID, error)
+	AddWeddingToUser(ctx context.Context, userID, weddingID models.ID) error
+	RemoveWeddingFromUser(ctx context.Context, userID, weddingID models.ID) error
 }
 
 // MockUserService is a mock implementation of UserServiceInterface
@@ -40,7 +39,7 @@ type MockUserService struct {
 	mock.Mock
 }
 
-func (m *MockUserService) GetUserProfile(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+func (m *MockUserService) GetUserProfile(ctx context.Context, userID models.ID) (*models.User, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -48,7 +47,7 @@ func (m *MockUserService) GetUserProfile(ctx context.Context, userID primitive.O
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserService) UpdateUserProfile(ctx context.Context, userID primitive.ObjectID, profile *services.UserProfile) (*models.User, error) {
+func (m *MockUserService) UpdateUserProfile(ctx context.Context, userID models.ID, profile *services.UserProfile) (*models.User, error) {
 	args := m.Called(ctx, userID, profile)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -72,12 +71,12 @@ func (m *MockUserService) SearchUsers(ctx context.Context, query string, limit i
 	return args.Get(0).([]*models.User), args.Error(1)
 }
 
-func (m *MockUserService) UpdateUserStatus(ctx context.Context, userID primitive.ObjectID, status models.UserStatus) error {
+func (m *MockUserService) UpdateUserStatus(ctx context.Context, userID models.ID, status models.UserStatus) error {
 	args := m.Called(ctx, userID, status)
 	return args.Error(0)
 }
 
-func (m *MockUserService) DeleteUser(ctx context.Context, userID primitive.ObjectID) error {
+func (m *MockUserService) DeleteUser(ctx context.Context, userID models.ID) error {
 	args := m.Called(ctx, userID)
 	return args.Error(0)
 }
@@ -90,20 +89,20 @@ func (m *MockUserService) GetUserStats(ctx context.Context) (map[string]int64, e
 	return args.Get(0).(map[string]int64), args.Error(1)
 }
 
-func (m *MockUserService) GetUserWeddings(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+func (m *MockUserService) GetUserWeddings(ctx context.Context, userID models.ID) ([]models.ID, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]primitive.ObjectID), args.Error(1)
+	return args.Get(0).([]models.ID), args.Error(1)
 }
 
-func (m *MockUserService) AddWeddingToUser(ctx context.Context, userID, weddingID primitive.ObjectID) error {
+func (m *MockUserService) AddWeddingToUser(ctx context.Context, userID, weddingID models.ID) error {
 	args := m.Called(ctx, userID, weddingID)
 	return args.Error(0)
 }
 
-func (m *MockUserService) RemoveWeddingFromUser(ctx context.Context, userID, weddingID primitive.ObjectID) error {
+func (m *MockUserService) RemoveWeddingFromUser(ctx context.Context, userID, weddingID models.ID) error {
 	args := m.Called(ctx, userID, weddingID)
 	return args.Error(0)
 }
@@ -128,7 +127,7 @@ func (h *TestUserHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	objectID, err := primitive.ObjectIDFromHex(userID.(string))
+	objectID, err := models.ParseID(userID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
@@ -156,7 +155,7 @@ func (h *TestUserHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	objectID, err := primitive.ObjectIDFromHex(userID.(string))
+	objectID, err := models.ParseID(userID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
@@ -242,7 +241,7 @@ func (h *TestUserHandler) SearchUsers(c *gin.Context) {
 func (h *TestUserHandler) UpdateUserStatus(c *gin.Context) {
 	// Get user ID from URL params
 	userIDStr := c.Param("id")
-	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	userID, err := models.ParseID(userIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
@@ -280,7 +279,7 @@ func (h *TestUserHandler) UpdateUserStatus(c *gin.Context) {
 func (h *TestUserHandler) DeleteUser(c *gin.Context) {
 	// Get user ID from URL params
 	userIDStr := c.Param("id")
-	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	userID, err := models.ParseID(userIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
@@ -318,7 +317,7 @@ func (h *TestUserHandler) GetUserWeddings(c *gin.Context) {
 		return
 	}
 
-	objectID, err := primitive.ObjectIDFromHex(userID.(string))
+	objectID, err := models.ParseID(userID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
@@ -346,7 +345,7 @@ func (h *TestUserHandler) AddWeddingToUser(c *gin.Context) {
 		return
 	}
 
-	userObjectID, err := primitive.ObjectIDFromHex(userID.(string))
+	userObjectID, err := models.ParseID(userID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
@@ -354,7 +353,7 @@ func (h *TestUserHandler) AddWeddingToUser(c *gin.Context) {
 
 	// Get wedding ID from URL params
 	weddingIDStr := c.Param("wedding_id")
-	weddingID, err := primitive.ObjectIDFromHex(weddingIDStr)
+	weddingID, err := models.ParseID(weddingIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wedding ID"})
 		return
@@ -381,7 +380,7 @@ func (h *TestUserHandler) RemoveWeddingFromUser(c *gin.Context) {
 		return
 	}
 
-	userObjectID, err := primitive.ObjectIDFromHex(userID.(string))
+	userObjectID, err := models.ParseID(userID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
@@ -389,7 +388,7 @@ func (h *TestUserHandler) RemoveWeddingFromUser(c *gin.Context) {
 
 	// Get wedding ID from URL params
 	weddingIDStr := c.Param("wedding_id")
-	weddingID, err := primitive.ObjectIDFromHex(weddingIDStr)
+	weddingID, err := models.ParseID(weddingIDStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wedding ID"})
 		return
@@ -412,7 +411,7 @@ func TestUserHandler_GetProfile(t *testing.T) {
 	mockUserService := new(MockUserService)
 	userHandler := NewTestUserHandler(mockUserService)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	mockUser := &models.User{
 		ID:        userID,
 		FirstName: "John",
@@ -437,7 +436,7 @@ func TestUserHandler_GetProfile(t *testing.T) {
 		req.Header.Set("Content-Type", "application/json")
 		c, _ := gin.CreateTestContext(httptest.NewRecorder())
 		c.Request = req
-		c.Set("user_id", userID.Hex())
+		c.Set("user_id", userID.String())
 
 		userHandler.UpdateProfile(c)
 
@@ -450,7 +449,7 @@ func TestUserHandler_GetProfile(t *testing.T) {
 		req.Header.Set("Content-Type", "application/json")
 		c, _ := gin.CreateTestContext(httptest.NewRecorder())
 		c.Request = req
-		c.Set("user_id", userID.Hex())
+		c.Set("user_id", userID.String())
 
 		userHandler.UpdateProfile(c)
 
@@ -465,7 +464,7 @@ func TestUserHandler_GetUsersList(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		expectedResponse := &services.UserListResponse{
 			Users: []*models.User{
-				{ID: primitive.NewObjectID(), FirstName: "John", Email: "john@example.com"},
+				{ID: models.NewID(), FirstName: "John", Email: "john@example.com"},
 			},
 			Total:      1,
 			Page:       1,
@@ -509,7 +508,7 @@ func TestUserHandler_SearchUsers(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		expectedUsers := []*models.User{
-			{ID: primitive.NewObjectID(), FirstName: "John", Email: "john@example.com"},
+			{ID: models.NewID(), FirstName: "John", Email: "john@example.com"},
 		}
 		mockUserService.On("SearchUsers", mock.Anything, "john", 20).Return(expectedUsers, nil)
 
@@ -538,18 +537,18 @@ func TestUserHandler_UpdateUserStatus(t *testing.T) {
 	mockUserService := new(MockUserService)
 	userHandler := NewTestUserHandler(mockUserService)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 
 	t.Run("success", func(t *testing.T) {
 		mockUserService.On("UpdateUserStatus", mock.Anything, userID, models.UserStatusActive).Return(nil)
 
 		requestBody := map[string]string{"status": "active"}
 		body, _ := json.Marshal(requestBody)
-		req, _ := http.NewRequest("PUT", "/api/v1/admin/users/"+userID.Hex()+"/status", bytes.NewBuffer(body))
+		req, _ := http.NewRequest("PUT", "/api/v1/admin/users/"+userID.String()+"/status", bytes.NewBuffer(body))
 		req.Header.Set("Content-Type", "application/json")
 		c, _ := gin.CreateTestContext(httptest.NewRecorder())
 		c.Request = req
-		c.Params = gin.Params{{Key: "id", Value: userID.Hex()}}
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}}
 
 		userHandler.UpdateUserStatus(c)
 
@@ -576,15 +575,15 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 	mockUserService := new(MockUserService)
 	userHandler := NewTestUserHandler(mockUserService)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 
 	t.Run("success", func(t *testing.T) {
 		mockUserService.On("DeleteUser", mock.Anything, userID).Return(nil)
 
-		req, _ := http.NewRequest("DELETE", "/api/v1/admin/users/"+userID.Hex(), nil)
+		req, _ := http.NewRequest("DELETE", "/api/v1/admin/users/"+userID.String(), nil)
 		c, _ := gin.CreateTestContext(httptest.NewRecorder())
 		c.Request = req
-		c.Params = gin.Params{{Key: "id", Value: userID.Hex()}}
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}}
 
 		userHandler.DeleteUser(c)
 
@@ -599,11 +598,11 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 
 		mockUserService.On("DeleteUser", mock.Anything, userID).Return(errors.New("user not found"))
 
-		req, _ := http.NewRequest("DELETE", "/api/v1/admin/users/"+userID.Hex(), nil)
+		req, _ := http.NewRequest("DELETE", "/api/v1/admin/users/"+userID.String(), nil)
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
-		c.Params = gin.Params{{Key: "id", Value: userID.Hex()}}
+		c.Params = gin.Params{{Key: "id", Value: userID.String()}}
 
 		userHandler.DeleteUser(c)
 
@@ -640,8 +639,8 @@ func TestUserHandler_GetUserWeddings(t *testing.T) {
 	mockUserService := new(MockUserService)
 	userHandler := NewTestUserHandler(mockUserService)
 
-	userID := primitive.NewObjectID()
-	weddingIDs := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}
+	userID := models.NewID()
+	weddingIDs := []models.ID{models.NewID(), models.NewID()}
 
 	t.Run("success", func(t *testing.T) {
 		mockUserService.On("GetUserWeddings", mock.Anything, userID).Return(weddingIDs, nil)
@@ -649,7 +648,7 @@ func TestUserHandler_GetUserWeddings(t *testing.T) {
 		req, _ := http.NewRequest("GET", "/api/v1/users/weddings", nil)
 		c, _ := gin.CreateTestContext(httptest.NewRecorder())
 		c.Request = req
-		c.Set("user_id", userID.Hex())
+		c.Set("user_id", userID.String())
 
 		userHandler.GetUserWeddings(c)
 
@@ -672,17 +671,17 @@ func TestUserHandler_AddWeddingToUser(t *testing.T) {
 	mockUserService := new(MockUserService)
 	userHandler := NewTestUserHandler(mockUserService)
 
-	userID := primitive.NewObjectID()
-	weddingID := primitive.NewObjectID()
+	userID := models.NewID()
+	weddingID := models.NewID()
 
 	t.Run("success", func(t *testing.T) {
 		mockUserService.On("AddWeddingToUser", mock.Anything, userID, weddingID).Return(nil)
 
-		req, _ := http.NewRequest("POST", "/api/v1/users/weddings/"+weddingID.Hex(), nil)
+		req, _ := http.NewRequest("POST", "/api/v1/users/weddings/"+weddingID.String(), nil)
 		c, _ := gin.CreateTestContext(httptest.NewRecorder())
 		c.Request = req
-		c.Set("user_id", userID.Hex())
-		c.Params = gin.Params{{Key: "wedding_id", Value: weddingID.Hex()}}
+		c.Set("user_id", userID.String())
+		c.Params = gin.Params{{Key: "wedding_id", Value: weddingID.String()}}
 
 		userHandler.AddWeddingToUser(c)
 
@@ -694,7 +693,7 @@ func TestUserHandler_AddWeddingToUser(t *testing.T) {
 		req, _ := http.NewRequest("POST", "/api/v1/users/weddings/invalid-id", nil)
 		c, _ := gin.CreateTestContext(httptest.NewRecorder())
 		c.Request = req
-		c.Set("user_id", userID.Hex())
+		c.Set("user_id", userID.String())
 		c.Params = gin.Params{{Key: "wedding_id", Value: "invalid-id"}}
 
 		userHandler.AddWeddingToUser(c)
@@ -707,17 +706,17 @@ func TestUserHandler_RemoveWeddingFromUser(t *testing.T) {
 	mockUserService := new(MockUserService)
 	userHandler := NewTestUserHandler(mockUserService)
 
-	userID := primitive.NewObjectID()
-	weddingID := primitive.NewObjectID()
+	userID := models.NewID()
+	weddingID := models.NewID()
 
 	t.Run("success", func(t *testing.T) {
 		mockUserService.On("RemoveWeddingFromUser", mock.Anything, userID, weddingID).Return(nil)
 
-		req, _ := http.NewRequest("DELETE", "/api/v1/users/weddings/"+weddingID.Hex(), nil)
+		req, _ := http.NewRequest("DELETE", "/api/v1/users/weddings/"+weddingID.String(), nil)
 		c, _ := gin.CreateTestContext(httptest.NewRecorder())
 		c.Request = req
-		c.Set("user_id", userID.Hex())
-		c.Params = gin.Params{{Key: "wedding_id", Value: weddingID.Hex()}}
+		c.Set("user_id", userID.String())
+		c.Params = gin.Params{{Key: "wedding_id", Value: weddingID.String()}}
 
 		userHandler.RemoveWeddingFromUser(c)
 
@@ -732,12 +731,12 @@ func TestUserHandler_RemoveWeddingFromUser(t *testing.T) {
 
 		mockUserService.On("RemoveWeddingFromUser", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("user not found"))
 
-		req, _ := http.NewRequest("DELETE", "/api/v1/users/weddings/"+weddingID.Hex(), nil)
+		req, _ := http.NewRequest("DELETE", "/api/v1/users/weddings/"+weddingID.String(), nil)
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = req
-		c.Set("user_id", userID.Hex())
-		c.Params = gin.Params{{Key: "wedding_id", Value: weddingID.Hex()}}
+		c.Set("user_id", userID.String())
+		c.Params = gin.Params{{Key: "wedding_id", Value: weddingID.String()}}
 
 		userHandler.RemoveWeddingFromUser(c)
 
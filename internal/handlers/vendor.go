@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// VendorManager manages the vendors of a wedding
+type VendorManager interface {
+	ListVendors(ctx context.Context, weddingID, userID models.ID, filters repository.VendorFilters) ([]*models.Vendor, error)
+	GetVendor(ctx context.Context, weddingID, vendorID, userID models.ID) (*models.Vendor, error)
+	CreateVendor(ctx context.Context, weddingID, userID models.ID, req services.VendorRequest) (*models.Vendor, error)
+	UpdateVendor(ctx context.Context, weddingID, vendorID, userID models.ID, req services.VendorRequest) (*models.Vendor, error)
+	DeleteVendor(ctx context.Context, weddingID, vendorID, userID models.ID) error
+}
+
+// VendorHandler serves the vendor directory of a wedding
+type VendorHandler struct {
+	vendors VendorManager
+}
+
+// NewVendorHandler creates a new wedding vendor handler
+func NewVendorHandler(vendors VendorManager) *VendorHandler {
+	return &VendorHandler{vendors: vendors}
+}
+
+// ListVendors godoc
+// @Summary List vendors
+// @Description Get the vendors of a wedding by name. Collaborators see the vendors the owner shared with them.
+// @Tags vendors
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param category query string false "Filter by category, e.g. photographer"
+// @Param contract_status query string false "Filter by contract status" Enums(none, negotiating, sent, signed, cancelled)
+// @Success 200 {array} models.Vendor
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/vendors [get]
+func (h *VendorHandler) ListVendors(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	filters := repository.VendorFilters{
+		Category:       c.Query("category"),
+		ContractStatus: models.VendorContractStatus(c.Query("contract_status")),
+	}
+
+	vendors, err := h.vendors.ListVendors(c.Request.Context(), weddingID, userID, filters)
+	if err != nil {
+		h.handleError(c, err, "Failed to get vendors")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, vendors)
+}
+
+// GetVendor godoc
+// @Summary Get a vendor
+// @Description Get the contact details, contract status, attachments and notes of a vendor
+// @Tags vendors
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param vendor_id path string true "Vendor ID"
+// @Success 200 {object} models.Vendor
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/vendors/{vendor_id} [get]
+func (h *VendorHandler) GetVendor(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	vendorID, ok := parseVendorID(c)
+	if !ok {
+		return
+	}
+
+	vendor, err := h.vendors.GetVendor(c.Request.Context(), weddingID, vendorID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get vendor")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, vendor)
+}
+
+// CreateVendor godoc
+// @Summary Add a vendor
+// @Description Add a vendor, private to the owner unless shared with collaborators (wedding owner only)
+// @Tags vendors
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param vendor body services.VendorRequest true "Vendor"
+// @Success 201 {object} models.Vendor
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/vendors [post]
+func (h *VendorHandler) CreateVendor(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindVendor(c)
+	if !ok {
+		return
+	}
+
+	vendor, err := h.vendors.CreateVendor(c.Request.Context(), weddingID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create vendor")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, vendor)
+}
+
+// UpdateVendor godoc
+// @Summary Update a vendor
+// @Description Replace the details of a vendor. Collaborators may update vendors shared with them for editing, but not how they are shared.
+// @Tags vendors
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param vendor_id path string true "Vendor ID"
+// @Param vendor body services.VendorRequest true "Vendor"
+// @Success 200 {object} models.Vendor
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/vendors/{vendor_id} [put]
+func (h *VendorHandler) UpdateVendor(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	vendorID, ok := parseVendorID(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindVendor(c)
+	if !ok {
+		return
+	}
+
+	vendor, err := h.vendors.UpdateVendor(c.Request.Context(), weddingID, vendorID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update vendor")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, vendor)
+}
+
+// DeleteVendor godoc
+// @Summary Delete a vendor
+// @Description Remove a vendor from a wedding. Attached files are kept. (wedding owner only)
+// @Tags vendors
+// @Param id path string true "Wedding ID"
+// @Param vendor_id path string true "Vendor ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/vendors/{vendor_id} [delete]
+func (h *VendorHandler) DeleteVendor(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	vendorID, ok := parseVendorID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.vendors.DeleteVendor(c.Request.Context(), weddingID, vendorID, userID); err != nil {
+		h.handleError(c, err, "Failed to delete vendor")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseWeddingRequest reads the wedding and the authenticated user of a vendor request
+func (h *VendorHandler) parseWeddingRequest(c *gin.Context) (models.ID, models.ID, bool) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+
+	return weddingID, userID, true
+}
+
+// parseVendorID reads the vendor of a request from its path
+func parseVendorID(c *gin.Context) (models.ID, bool) {
+	vendorID, err := models.ParseID(c.Param("vendor_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid vendor ID")
+		return models.NilID, false
+	}
+	return vendorID, true
+}
+
+// bindVendor reads and validates a vendor from the request body
+func bindVendor(c *gin.Context) (services.VendorRequest, bool) {
+	var req services.VendorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return req, false
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return req, false
+	}
+
+	return req, true
+}
+
+func (h *VendorHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrVendorNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Vendor not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to manage this wedding's vendors")
+	case errors.Is(err, services.ErrInvalidVendor):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrVendorsFull):
+		utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
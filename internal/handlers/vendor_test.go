@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockVendorManager is a mock implementation of VendorManager
+type MockVendorManager struct {
+	mock.Mock
+}
+
+func (m *MockVendorManager) ListVendors(ctx context.Context, weddingID, userID models.ID, filters repository.VendorFilters) ([]*models.Vendor, error) {
+	args := m.Called(ctx, weddingID, userID, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Vendor), args.Error(1)
+}
+
+func (m *MockVendorManager) GetVendor(ctx context.Context, weddingID, vendorID, userID models.ID) (*models.Vendor, error) {
+	args := m.Called(ctx, weddingID, vendorID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Vendor), args.Error(1)
+}
+
+func (m *MockVendorManager) CreateVendor(ctx context.Context, weddingID, userID models.ID, req services.VendorRequest) (*models.Vendor, error) {
+	args := m.Called(ctx, weddingID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Vendor), args.Error(1)
+}
+
+func (m *MockVendorManager) UpdateVendor(ctx context.Context, weddingID, vendorID, userID models.ID, req services.VendorRequest) (*models.Vendor, error) {
+	args := m.Called(ctx, weddingID, vendorID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Vendor), args.Error(1)
+}
+
+func (m *MockVendorManager) DeleteVendor(ctx context.Context, weddingID, vendorID, userID models.ID) error {
+	args := m.Called(ctx, weddingID, vendorID, userID)
+	return args.Error(0)
+}
+
+func setupVendorTestRouter(handler *VendorHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	vendors := router.Group("/api/v1/weddings/:id/vendors")
+	vendors.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	vendors.GET("", handler.ListVendors)
+	vendors.POST("", handler.CreateVendor)
+	vendors.GET("/:vendor_id", handler.GetVendor)
+	vendors.PUT("/:vendor_id", handler.UpdateVendor)
+	vendors.DELETE("/:vendor_id", handler.DeleteVendor)
+
+	return router
+}
+
+func TestVendorHandler_CreateVendor(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	path := "/api/v1/weddings/" + weddingID.String() + "/vendors"
+	valid := services.VendorRequest{Name: "Lens & Light", Category: "photographer", Sharing: models.VendorSharedView}
+
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		called   bool
+		expected int
+	}{
+		{"created", `{"name":"Lens & Light","category":"photographer","sharing":"view"}`, nil, true, http.StatusCreated},
+		{"not the owner", `{"name":"Lens & Light","category":"photographer","sharing":"view"}`, services.ErrUnauthorized, true, http.StatusForbidden},
+		{"unknown attachment", `{"name":"Lens & Light","category":"photographer","sharing":"view"}`, services.ErrInvalidVendor, true, http.StatusBadRequest},
+		{"too many vendors", `{"name":"Lens & Light","category":"photographer","sharing":"view"}`, services.ErrVendorsFull, true, http.StatusConflict},
+		{"missing category", `{"name":"Lens & Light"}`, nil, false, http.StatusBadRequest},
+		{"invalid email", `{"name":"Lens & Light","category":"photographer","email":"nope"}`, nil, false, http.StatusBadRequest},
+		{"unknown sharing", `{"name":"Lens & Light","category":"photographer","sharing":"public"}`, nil, false, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vendors := new(MockVendorManager)
+			if tt.called {
+				if tt.err != nil {
+					vendors.On("CreateVendor", mock.Anything, weddingID, userID, valid).Return(nil, tt.err)
+				} else {
+					vendors.On("CreateVendor", mock.Anything, weddingID, userID, valid).Return(&models.Vendor{ID: models.NewID()}, nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupVendorTestRouter(NewVendorHandler(vendors), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			vendors.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVendorHandler_GetVendor(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	vendorID := models.NewID()
+
+	t.Run("private to the owner", func(t *testing.T) {
+		vendors := new(MockVendorManager)
+		vendors.On("GetVendor", mock.Anything, weddingID, vendorID, userID).Return(nil, services.ErrVendorNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/weddings/"+weddingID.String()+"/vendors/"+vendorID.String(), nil)
+		w := httptest.NewRecorder()
+		setupVendorTestRouter(NewVendorHandler(vendors), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("invalid vendor ID", func(t *testing.T) {
+		vendors := new(MockVendorManager)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/weddings/"+weddingID.String()+"/vendors/nope", nil)
+		w := httptest.NewRecorder()
+		setupVendorTestRouter(NewVendorHandler(vendors), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		vendors.AssertNotCalled(t, "GetVendor", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -1,12 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
@@ -34,6 +34,8 @@ func NewWeddingHandler(weddingService services.WeddingServiceInterface) *Wedding
 // @Success 201 {object} models.Wedding
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/weddings [post]
 func (h *WeddingHandler) CreateWedding(c *gin.Context) {
@@ -50,13 +52,33 @@ func (h *WeddingHandler) CreateWedding(c *gin.Context) {
 	}
 
 	userID := c.GetString("userID")
-	userOID, err := primitive.ObjectIDFromHex(userID)
+	userOID, err := models.ParseID(userID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
 		return
 	}
 
 	if err := h.weddingService.CreateWedding(c.Request.Context(), &wedding, userOID); err != nil {
+		if errors.Is(err, services.ErrWeddingTakenDown) {
+			c.JSON(http.StatusForbidden, ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrPlanLimitExceeded) {
+			c.JSON(http.StatusPaymentRequired, ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrSlugTaken) {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrThemeNotFound) || errors.Is(err, services.ErrDuplicateEventSession) ||
+			errors.Is(err, services.ErrInvalidEventTimezone) || errors.Is(err, services.ErrInvalidEventEnd) ||
+			errors.Is(err, services.ErrReservedSlug) || errors.Is(err, services.ErrInappropriateSlug) ||
+			errors.Is(err, services.ErrInvalidWeddingLanguage) || errors.Is(err, services.ErrOwnLanguageTranslation) ||
+			errors.Is(err, services.ErrInvalidMusicURL) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -76,35 +98,27 @@ func (h *WeddingHandler) CreateWedding(c *gin.Context) {
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/weddings/{id} [get]
 func (h *WeddingHandler) GetWedding(c *gin.Context) {
-	weddingID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	weddingID, err := models.ParseID(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
 		return
 	}
 
 	userID := c.GetString("userID")
-	var userOID primitive.ObjectID
+	var userOID models.ID
 	if userID != "" {
-		userOID, err = primitive.ObjectIDFromHex(userID)
+		userOID, err = models.ParseID(userID)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
 			return
 		}
 	} else {
-		userOID = primitive.NilObjectID
+		userOID = models.NilID
 	}
 
 	wedding, err := h.weddingService.GetWeddingByID(c.Request.Context(), weddingID, userOID)
 	if err != nil {
-		if err.Error() == "wedding not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
-			return
-		}
-		if err.Error() == "access denied" {
-			c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -130,10 +144,10 @@ func (h *WeddingHandler) GetWeddingBySlug(c *gin.Context) {
 	}
 
 	userID := c.GetString("userID")
-	var userOID primitive.ObjectID
+	var userOID models.ID
 	if userID != "" {
 		var err error
-		userOID, err = primitive.ObjectIDFromHex(userID)
+		userOID, err = models.ParseID(userID)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
 			return
@@ -142,15 +156,7 @@ func (h *WeddingHandler) GetWeddingBySlug(c *gin.Context) {
 
 	wedding, err := h.weddingService.GetWeddingBySlug(c.Request.Context(), slug, userOID)
 	if err != nil {
-		if err.Error() == "wedding not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
-			return
-		}
-		if err.Error() == "access denied" {
-			c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -172,7 +178,7 @@ func (h *WeddingHandler) GetWeddingBySlug(c *gin.Context) {
 // @Router /api/v1/weddings [get]
 func (h *WeddingHandler) GetUserWeddings(c *gin.Context) {
 	userID := c.GetString("userID")
-	userOID, err := primitive.ObjectIDFromHex(userID)
+	userOID, err := models.ParseID(userID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
 		return
@@ -232,12 +238,14 @@ func (h *WeddingHandler) GetUserWeddings(c *gin.Context) {
 // @Success 200 {object} models.Wedding
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/weddings/{id} [put]
 func (h *WeddingHandler) UpdateWedding(c *gin.Context) {
-	weddingID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	weddingID, err := models.ParseID(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
 		return
@@ -259,28 +267,88 @@ func (h *WeddingHandler) UpdateWedding(c *gin.Context) {
 	}
 
 	userID := c.GetString("userID")
-	userOID, err := primitive.ObjectIDFromHex(userID)
+	userOID, err := models.ParseID(userID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
 		return
 	}
 
 	if err := h.weddingService.UpdateWedding(c.Request.Context(), &wedding, userOID); err != nil {
-		if err.Error() == "wedding not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
+		if errors.Is(err, services.ErrPlanLimitExceeded) {
+			c.JSON(http.StatusPaymentRequired, ErrorResponse{Error: err.Error()})
 			return
 		}
-		if err.Error() == "access denied" {
-			c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
+		if errors.Is(err, services.ErrSlugTaken) {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		if errors.Is(err, services.ErrThemeNotFound) || errors.Is(err, services.ErrDuplicateEventSession) ||
+			errors.Is(err, services.ErrInvalidEventTimezone) || errors.Is(err, services.ErrInvalidEventEnd) ||
+			errors.Is(err, services.ErrReservedSlug) || errors.Is(err, services.ErrInappropriateSlug) ||
+			errors.Is(err, services.ErrInvalidWeddingLanguage) || errors.Is(err, services.ErrOwnLanguageTranslation) ||
+			errors.Is(err, services.ErrInvalidMusicURL) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+		_ = c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, wedding)
 }
 
+// CheckSlugAvailability godoc
+// @Summary Check whether a slug is available
+// @Description Tell whether a wedding could use a slug and, if not, why and which similar slug is free. With a wedding ID, the wedding's own current and old slugs are available.
+// @Tags weddings
+// @Produce json
+// @Param slug query string true "Slug to check"
+// @Param wedding_id query string false "Wedding that would use the slug"
+// @Success 200 {object} services.SlugAvailability
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/slug-availability [get]
+func (h *WeddingHandler) CheckSlugAvailability(c *gin.Context) {
+	slug := c.Query("slug")
+	if slug == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Slug is required"})
+		return
+	}
+
+	var weddingID models.ID
+	if raw := c.Query("wedding_id"); raw != "" {
+		id, err := models.ParseID(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
+			return
+		}
+		weddingID = id
+	}
+
+	userOID, err := models.ParseID(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
+		return
+	}
+
+	availability, err := h.weddingService.CheckSlugAvailability(c.Request.Context(), slug, weddingID, userOID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWeddingNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
+		case errors.Is(err, services.ErrUnauthorized):
+			c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
 // DeleteWedding godoc
 // @Summary Delete a wedding
 // @Description Delete a wedding (only owner can delete)
@@ -295,29 +363,21 @@ func (h *WeddingHandler) UpdateWedding(c *gin.Context) {
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/weddings/{id} [delete]
 func (h *WeddingHandler) DeleteWedding(c *gin.Context) {
-	weddingID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	weddingID, err := models.ParseID(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
 		return
 	}
 
 	userID := c.GetString("userID")
-	userOID, err := primitive.ObjectIDFromHex(userID)
+	userOID, err := models.ParseID(userID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
 		return
 	}
 
 	if err := h.weddingService.DeleteWedding(c.Request.Context(), weddingID, userOID); err != nil {
-		if err.Error() == "wedding not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
-			return
-		}
-		if err.Error() == "access denied" {
-			c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -338,14 +398,14 @@ func (h *WeddingHandler) DeleteWedding(c *gin.Context) {
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/weddings/{id}/publish [post]
 func (h *WeddingHandler) PublishWedding(c *gin.Context) {
-	weddingID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	weddingID, err := models.ParseID(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid wedding ID"})
 		return
 	}
 
 	userID := c.GetString("userID")
-	userOID, err := primitive.ObjectIDFromHex(userID)
+	userOID, err := models.ParseID(userID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid user ID"})
 		return
@@ -354,25 +414,21 @@ func (h *WeddingHandler) PublishWedding(c *gin.Context) {
 	// Get the wedding first to validate
 	wedding, err := h.weddingService.GetWeddingByID(c.Request.Context(), weddingID, userOID)
 	if err != nil {
-		if err.Error() == "wedding not found" {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Wedding not found"})
-			return
-		}
-		if err.Error() == "access denied" {
-			c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
-	// Check ownership
-	if wedding.UserID != userOID {
+	// Check the user may edit the wedding
+	if !wedding.Can(userOID, models.PermissionEditWedding) {
 		c.JSON(http.StatusForbidden, ErrorResponse{Error: "Access denied"})
 		return
 	}
 
 	if err := h.weddingService.PublishWedding(c.Request.Context(), weddingID, userOID); err != nil {
+		if errors.Is(err, services.ErrWeddingTakenDown) {
+			c.JSON(http.StatusForbidden, ErrorResponse{Error: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -394,8 +450,12 @@ func (h *WeddingHandler) PublishWedding(c *gin.Context) {
 // @Produce json
 // @Param page query int false "Page number (default: 1)"
 // @Param page_size query int false "Page size (default: 20)"
-// @Param search query string false "Search term"
+// @Param search query string false "Full-text search over couple names and titles"
 // @Param event_date query string false "Filter by event date (RFC3339 format)"
+// @Param date_from query string false "Only weddings on or after this date (RFC3339 or YYYY-MM-DD)"
+// @Param date_to query string false "Only weddings on or before this date (RFC3339 or YYYY-MM-DD)"
+// @Param when query string false "Restrict to upcoming or past weddings" Enums(upcoming, past)
+// @Param sort query string false "Sort order (default: date)" Enums(date, recent, most_viewed, relevance)
 // @Success 200 {object} PaginatedWeddingsResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -408,6 +468,8 @@ func (h *WeddingHandler) ListPublicWeddings(c *gin.Context) {
 	// Build filters
 	filters := repository.PublicWeddingFilters{
 		Search: c.Query("search"),
+		When:   c.Query("when"),
+		Sort:   c.Query("sort"),
 	}
 
 	// Parse event date filter
@@ -417,8 +479,30 @@ func (h *WeddingHandler) ListPublicWeddings(c *gin.Context) {
 		}
 	}
 
+	// Parse date range filters; a plain date_to covers the whole day
+	if dateFrom := c.Query("date_from"); dateFrom != "" {
+		t, err := parseShowcaseDate(dateFrom, false)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid date_from, expected RFC3339 or YYYY-MM-DD"})
+			return
+		}
+		filters.DateFrom = &t
+	}
+	if dateTo := c.Query("date_to"); dateTo != "" {
+		t, err := parseShowcaseDate(dateTo, true)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid date_to, expected RFC3339 or YYYY-MM-DD"})
+			return
+		}
+		filters.DateTo = &t
+	}
+
 	weddings, total, err := h.weddingService.ListPublicWeddings(c.Request.Context(), page, pageSize, filters)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidPublicWeddingFilter) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -431,6 +515,22 @@ func (h *WeddingHandler) ListPublicWeddings(c *gin.Context) {
 	})
 }
 
+// parseShowcaseDate accepts full RFC3339 timestamps or plain calendar dates.
+// With endOfDay set, a plain date resolves to the last instant of that day.
+func parseShowcaseDate(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
+
 // Response types
 
 type PaginatedWeddingsResponse struct {
@@ -0,0 +1,389 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// WeddingModerator moderates weddings and handles abuse reports
+type WeddingModerator interface {
+	ListWeddings(ctx context.Context, page, pageSize int, filters repository.AdminWeddingFilters) ([]*models.Wedding, int64, error)
+	GetWedding(ctx context.Context, weddingID models.ID) (*services.AdminWeddingDetail, error)
+	Unpublish(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error)
+	Flag(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error)
+	Unflag(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error)
+	TakeDown(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error)
+	Restore(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error)
+	ReportAbuse(ctx context.Context, slug string, req services.AbuseReportRequest) (*models.AbuseReport, error)
+	ListAbuseReports(ctx context.Context, filters repository.AbuseReportFilters, page, pageSize int) ([]*models.AbuseReport, int64, error)
+	CloseAbuseReport(ctx context.Context, reportID, adminID models.ID, status models.AbuseReportStatus, note string) (*models.AbuseReport, error)
+}
+
+// ModerationRequest gives the reason for a moderation action
+type ModerationRequest struct {
+	Reason string `json:"reason" validate:"max=1000"`
+}
+
+// CloseAbuseReportRequest resolves or dismisses an abuse report
+type CloseAbuseReportRequest struct {
+	Status models.AbuseReportStatus `json:"status" validate:"required"`
+	Note   string                   `json:"note" validate:"max=1000"`
+}
+
+// WeddingModerationHandler serves wedding moderation to admins and abuse report intake to visitors
+type WeddingModerationHandler struct {
+	moderation WeddingModerator
+}
+
+// NewWeddingModerationHandler creates a new wedding moderation handler
+func NewWeddingModerationHandler(moderation WeddingModerator) *WeddingModerationHandler {
+	return &WeddingModerationHandler{moderation: moderation}
+}
+
+// ListWeddings godoc
+// @Summary List all weddings
+// @Description List and search every wedding, newest first (admin only)
+// @Tags admin
+// @Produce json
+// @Param status query string false "Status: draft, published, expired or archived"
+// @Param search query string false "Search title, slug and couple names"
+// @Param owner_id query string false "Owner's user ID"
+// @Param flagged query bool false "Only flagged (true) or unflagged (false) weddings"
+// @Param taken_down query bool false "Only taken down (true) or live (false) weddings"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} PaginationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/weddings [get]
+func (h *WeddingModerationHandler) ListWeddings(c *gin.Context) {
+	filters := repository.AdminWeddingFilters{
+		Status: c.Query("status"),
+		Search: c.Query("search"),
+	}
+	if ownerID := c.Query("owner_id"); ownerID != "" {
+		id, err := models.ParseID(ownerID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid owner_id")
+			return
+		}
+		filters.OwnerID = &id
+	}
+	var err error
+	if filters.Flagged, err = parseOptionalBool(c, "flagged"); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "flagged must be true or false")
+		return
+	}
+	if filters.TakenDown, err = parseOptionalBool(c, "taken_down"); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "taken_down must be true or false")
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+	weddings, total, err := h.moderation.ListWeddings(c.Request.Context(), page, pageSize, filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to list weddings")
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, weddings, int64(len(weddings)), total, page, pageSize)
+}
+
+// GetWedding godoc
+// @Summary Get a wedding for review
+// @Description Get any wedding with its owner's account and the number of open abuse reports (admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {object} services.AdminWeddingDetail
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/weddings/{id} [get]
+func (h *WeddingModerationHandler) GetWedding(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	detail, err := h.moderation.GetWedding(c.Request.Context(), weddingID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get wedding")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, detail)
+}
+
+// UnpublishWedding godoc
+// @Summary Force-unpublish a wedding
+// @Description Return a wedding to draft with the reason recorded. The owner may publish it again. (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body ModerationRequest true "Reason (required)"
+// @Success 200 {object} models.Wedding
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/weddings/{id}/unpublish [post]
+func (h *WeddingModerationHandler) UnpublishWedding(c *gin.Context) {
+	h.moderate(c, h.moderation.Unpublish, "Failed to unpublish wedding")
+}
+
+// FlagWedding godoc
+// @Summary Flag a wedding for review
+// @Description Mark a wedding for review without changing what visitors see (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body ModerationRequest false "Reason"
+// @Success 200 {object} models.Wedding
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/weddings/{id}/flag [post]
+func (h *WeddingModerationHandler) FlagWedding(c *gin.Context) {
+	h.moderate(c, h.moderation.Flag, "Failed to flag wedding")
+}
+
+// UnflagWedding godoc
+// @Summary Clear a wedding's review flag
+// @Description Clear the review flag of a wedding (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body ModerationRequest false "Reason"
+// @Success 200 {object} models.Wedding
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/weddings/{id}/flag [delete]
+func (h *WeddingModerationHandler) UnflagWedding(c *gin.Context) {
+	h.moderate(c, h.moderation.Unflag, "Failed to unflag wedding")
+}
+
+// TakeDownWedding godoc
+// @Summary Take down a wedding
+// @Description Unpublish a wedding and keep its owner from publishing it again until it is restored. The reason is recorded and emailed to the owner. (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body ModerationRequest true "Reason (required)"
+// @Success 200 {object} models.Wedding
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/weddings/{id}/takedown [post]
+func (h *WeddingModerationHandler) TakeDownWedding(c *gin.Context) {
+	h.moderate(c, h.moderation.TakeDown, "Failed to take down wedding")
+}
+
+// RestoreWedding godoc
+// @Summary Lift a takedown
+// @Description Let the owner publish a taken down wedding again. The wedding stays in draft. (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param request body ModerationRequest false "Reason"
+// @Success 200 {object} models.Wedding
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/weddings/{id}/restore [post]
+func (h *WeddingModerationHandler) RestoreWedding(c *gin.Context) {
+	h.moderate(c, h.moderation.Restore, "Failed to restore wedding")
+}
+
+// ReportAbuse godoc
+// @Summary Report a wedding
+// @Description Report a published wedding for spam, scams, inappropriate content, copyright, impersonation or another reason. The wedding is flagged for review by an admin.
+// @Tags public
+// @Accept json
+// @Produce json
+// @Param slug path string true "Wedding slug"
+// @Param report body services.AbuseReportRequest true "Report"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/public/weddings/slug/{slug}/report [post]
+func (h *WeddingModerationHandler) ReportAbuse(c *gin.Context) {
+	var req services.AbuseReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.IP = c.ClientIP()
+
+	report, err := h.moderation.ReportAbuse(c.Request.Context(), c.Param("slug"), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to submit report")
+		return
+	}
+
+	// Reporters only learn that the report was received
+	utils.Response(c, http.StatusCreated, gin.H{"id": report.ID, "status": report.Status})
+}
+
+// ListAbuseReports godoc
+// @Summary List abuse reports
+// @Description List the abuse reports visitors filed, newest first (admin only)
+// @Tags admin
+// @Produce json
+// @Param status query string false "Status: open, resolved or dismissed"
+// @Param wedding_id query string false "Wedding ID"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} PaginationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/abuse-reports [get]
+func (h *WeddingModerationHandler) ListAbuseReports(c *gin.Context) {
+	filters := repository.AbuseReportFilters{Status: models.AbuseReportStatus(c.Query("status"))}
+	if weddingID := c.Query("wedding_id"); weddingID != "" {
+		id, err := models.ParseID(weddingID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding_id")
+			return
+		}
+		filters.WeddingID = &id
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+	reports, total, err := h.moderation.ListAbuseReports(c.Request.Context(), filters, page, pageSize)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to list abuse reports")
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, reports, int64(len(reports)), total, page, pageSize)
+}
+
+// CloseAbuseReport godoc
+// @Summary Close an abuse report
+// @Description Resolve or dismiss an abuse report with a note (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Report ID"
+// @Param request body CloseAbuseReportRequest true "Resolution"
+// @Success 200 {object} models.AbuseReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/admin/abuse-reports/{id}/close [post]
+func (h *WeddingModerationHandler) CloseAbuseReport(c *gin.Context) {
+	reportID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid report ID")
+		return
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	var req CloseAbuseReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.moderation.CloseAbuseReport(c.Request.Context(), reportID, adminID, req.Status, req.Note)
+	if err != nil {
+		h.handleError(c, err, "Failed to close abuse report")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, report)
+}
+
+// moderate applies a moderation action to the wedding in the path
+func (h *WeddingModerationHandler) moderate(c *gin.Context, action func(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error), fallback string) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	adminID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	// The body is optional for actions that don't require a reason
+	var req ModerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	wedding, err := action(c.Request.Context(), weddingID, adminID, req.Reason)
+	if err != nil {
+		h.handleError(c, err, fallback)
+		return
+	}
+
+	utils.Response(c, http.StatusOK, wedding)
+}
+
+func (h *WeddingModerationHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrAbuseReportNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Abuse report not found")
+	case errors.Is(err, services.ErrWeddingNotTakenDown):
+		utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrModerationReasonRequired), errors.Is(err, services.ErrInvalidAbuseReport),
+		errors.Is(err, services.ErrInvalidAbuseReportStatus):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
+
+// parseOptionalBool reads a true/false query parameter, nil when absent
+func parseOptionalBool(c *gin.Context, name string) (*bool, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
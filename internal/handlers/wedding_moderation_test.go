@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockWeddingModerator is a mock implementation of WeddingModerator
+type MockWeddingModerator struct {
+	mock.Mock
+}
+
+func (m *MockWeddingModerator) ListWeddings(ctx context.Context, page, pageSize int, filters repository.AdminWeddingFilters) ([]*models.Wedding, int64, error) {
+	args := m.Called(ctx, page, pageSize, filters)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*models.Wedding), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWeddingModerator) GetWedding(ctx context.Context, weddingID models.ID) (*services.AdminWeddingDetail, error) {
+	args := m.Called(ctx, weddingID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.AdminWeddingDetail), args.Error(1)
+}
+
+func (m *MockWeddingModerator) moderated(args mock.Arguments) (*models.Wedding, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Wedding), args.Error(1)
+}
+
+func (m *MockWeddingModerator) Unpublish(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error) {
+	return m.moderated(m.Called(ctx, weddingID, adminID, reason))
+}
+
+func (m *MockWeddingModerator) Flag(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error) {
+	return m.moderated(m.Called(ctx, weddingID, adminID, reason))
+}
+
+func (m *MockWeddingModerator) Unflag(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error) {
+	return m.moderated(m.Called(ctx, weddingID, adminID, reason))
+}
+
+func (m *MockWeddingModerator) TakeDown(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error) {
+	return m.moderated(m.Called(ctx, weddingID, adminID, reason))
+}
+
+func (m *MockWeddingModerator) Restore(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error) {
+	return m.moderated(m.Called(ctx, weddingID, adminID, reason))
+}
+
+func (m *MockWeddingModerator) ReportAbuse(ctx context.Context, slug string, req services.AbuseReportRequest) (*models.AbuseReport, error) {
+	args := m.Called(ctx, slug, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AbuseReport), args.Error(1)
+}
+
+func (m *MockWeddingModerator) ListAbuseReports(ctx context.Context, filters repository.AbuseReportFilters, page, pageSize int) ([]*models.AbuseReport, int64, error) {
+	args := m.Called(ctx, filters, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*models.AbuseReport), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWeddingModerator) CloseAbuseReport(ctx context.Context, reportID, adminID models.ID, status models.AbuseReportStatus, note string) (*models.AbuseReport, error) {
+	args := m.Called(ctx, reportID, adminID, status, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AbuseReport), args.Error(1)
+}
+
+func setupWeddingModerationTestRouter(handler *WeddingModerationHandler, adminID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/public/weddings/slug/:slug/report", handler.ReportAbuse)
+
+	admin := router.Group("/api/v1/admin", func(c *gin.Context) {
+		c.Set("user_id", adminID.String())
+		c.Next()
+	})
+	admin.GET("/weddings", handler.ListWeddings)
+	admin.GET("/weddings/:id", handler.GetWedding)
+	admin.POST("/weddings/:id/flag", handler.FlagWedding)
+	admin.POST("/weddings/:id/takedown", handler.TakeDownWedding)
+	admin.POST("/weddings/:id/restore", handler.RestoreWedding)
+	admin.POST("/abuse-reports/:id/close", handler.CloseAbuseReport)
+	return router
+}
+
+func TestWeddingModerationHandler_ListWeddings(t *testing.T) {
+	adminID := models.NewID()
+	ownerID := models.NewID()
+
+	t.Run("Success - filters", func(t *testing.T) {
+		moderator := &MockWeddingModerator{}
+		router := setupWeddingModerationTestRouter(NewWeddingModerationHandler(moderator), adminID)
+
+		flagged := true
+		moderator.On("ListWeddings", mock.Anything, 1, 20, repository.AdminWeddingFilters{
+			Status:  "published",
+			Search:  "dana",
+			OwnerID: &ownerID,
+			Flagged: &flagged,
+		}).Return([]*models.Wedding{{Slug: "dana-and-sam"}}, int64(1), nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+			"/api/v1/admin/weddings?status=published&search=dana&flagged=true&owner_id="+ownerID.String(), nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "dana-and-sam")
+		moderator.AssertExpectations(t)
+	})
+
+	t.Run("Error - invalid filters", func(t *testing.T) {
+		router := setupWeddingModerationTestRouter(NewWeddingModerationHandler(&MockWeddingModerator{}), adminID)
+		for _, query := range []string{"owner_id=nope", "flagged=maybe", "taken_down=2"} {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/weddings?"+query, nil))
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+}
+
+func TestWeddingModerationHandler_Moderate(t *testing.T) {
+	adminID := models.NewID()
+	weddingID := models.NewID()
+
+	tests := []struct {
+		name   string
+		path   string
+		method string
+		body   string
+		reason string
+		err    error
+		status int
+	}{
+		{"Success - takedown", "/takedown", "TakeDown", `{"reason":"Scam"}`, "Scam", nil, http.StatusOK},
+		{"Success - flag without a body", "/flag", "Flag", "", "", nil, http.StatusOK},
+		{"Error - takedown without a reason", "/takedown", "TakeDown", "", "", services.ErrModerationReasonRequired, http.StatusBadRequest},
+		{"Error - wedding not found", "/flag", "Flag", `{}`, "", services.ErrWeddingNotFound, http.StatusNotFound},
+		{"Error - not taken down", "/restore", "Restore", "", "", services.ErrWeddingNotTakenDown, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moderator := &MockWeddingModerator{}
+			router := setupWeddingModerationTestRouter(NewWeddingModerationHandler(moderator), adminID)
+			if tt.err != nil {
+				moderator.On(tt.method, mock.Anything, weddingID, adminID, tt.reason).Return(nil, tt.err)
+			} else {
+				moderator.On(tt.method, mock.Anything, weddingID, adminID, tt.reason).Return(&models.Wedding{ID: weddingID}, nil)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/weddings/"+weddingID.String()+tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			moderator.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWeddingModerationHandler_ReportAbuse(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		moderator := &MockWeddingModerator{}
+		router := setupWeddingModerationTestRouter(NewWeddingModerationHandler(moderator), models.NewID())
+		moderator.On("ReportAbuse", mock.Anything, "dana-and-sam", services.AbuseReportRequest{
+			Category: models.AbuseCategorySpam,
+			Details:  "Ads everywhere",
+			IP:       "192.0.2.1",
+		}).Return(&models.AbuseReport{ID: models.NewID(), Status: models.AbuseReportOpen, ReporterIP: "192.0.2.1"}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/public/weddings/slug/dana-and-sam/report",
+			strings.NewReader(`{"category":"spam","details":"Ads everywhere"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"open"`)
+		// Reporters don't see what is stored about them
+		assert.NotContains(t, w.Body.String(), "192.0.2.1")
+	})
+
+	t.Run("Error - invalid", func(t *testing.T) {
+		moderator := &MockWeddingModerator{}
+		router := setupWeddingModerationTestRouter(NewWeddingModerationHandler(moderator), models.NewID())
+		moderator.On("ReportAbuse", mock.Anything, "dana-and-sam", mock.Anything).Return(nil, services.ErrInvalidAbuseReport)
+
+		for _, body := range []string{`{}`, `{"category":"spam","reporter_email":"nope"}`, `{"category":"rude"}`} {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/public/weddings/slug/dana-and-sam/report", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+	})
+}
+
+func TestWeddingModerationHandler_CloseAbuseReport(t *testing.T) {
+	adminID := models.NewID()
+	reportID := models.NewID()
+
+	moderator := &MockWeddingModerator{}
+	router := setupWeddingModerationTestRouter(NewWeddingModerationHandler(moderator), adminID)
+	moderator.On("CloseAbuseReport", mock.Anything, reportID, adminID, models.AbuseReportDismissed, "Not abuse").
+		Return(&models.AbuseReport{ID: reportID, Status: models.AbuseReportDismissed}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/abuse-reports/"+reportID.String()+"/close",
+		strings.NewReader(`{"status":"dismissed","note":"Not abuse"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"dismissed"`)
+	moderator.AssertExpectations(t)
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// WeddingReviser lists the revisions of a wedding and rolls it back to them
+type WeddingReviser interface {
+	ListRevisions(ctx context.Context, weddingID, userID models.ID) ([]*models.WeddingRevision, error)
+	GetRevision(ctx context.Context, weddingID, userID models.ID, number int) (*models.WeddingRevision, error)
+	RestoreRevision(ctx context.Context, weddingID, userID models.ID, number int) (*models.Wedding, error)
+}
+
+// WeddingRevisionHandler serves the revision history of weddings
+type WeddingRevisionHandler struct {
+	revisions WeddingReviser
+}
+
+// NewWeddingRevisionHandler creates a new wedding revision handler
+func NewWeddingRevisionHandler(revisions WeddingReviser) *WeddingRevisionHandler {
+	return &WeddingRevisionHandler{revisions: revisions}
+}
+
+// ListRevisions godoc
+// @Summary List wedding revisions
+// @Description List the revisions of the wedding, newest first. Each update of the wedding adds one, holding the content from before the update and the fields it changed; the latest 50 are kept.
+// @Tags Weddings
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {array} models.WeddingRevision
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/revisions [get]
+func (h *WeddingRevisionHandler) ListRevisions(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := h.revisions.ListRevisions(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list wedding revisions")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, revisions)
+}
+
+// GetRevision godoc
+// @Summary Get a wedding revision
+// @Description Get a revision of the wedding with the content the wedding had before the revision's update
+// @Tags Weddings
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param rev path int true "Revision number"
+// @Success 200 {object} models.WeddingRevision
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/revisions/{rev} [get]
+func (h *WeddingRevisionHandler) GetRevision(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+	number, ok := h.parseRevision(c)
+	if !ok {
+		return
+	}
+
+	revision, err := h.revisions.GetRevision(c.Request.Context(), weddingID, userID, number)
+	if err != nil {
+		h.handleError(c, err, "Failed to get wedding revision")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, revision)
+}
+
+// RestoreRevision godoc
+// @Summary Restore a wedding revision
+// @Description Roll the content of the wedding back to what it was before the revision's update. The status, slug and visibility of the wedding stay as they are, and the rollback adds a revision of its own, so it can be undone.
+// @Tags Weddings
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param rev path int true "Revision number"
+// @Success 200 {object} models.Wedding
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /weddings/{id}/revisions/{rev}/restore [post]
+func (h *WeddingRevisionHandler) RestoreRevision(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+	number, ok := h.parseRevision(c)
+	if !ok {
+		return
+	}
+
+	wedding, err := h.revisions.RestoreRevision(c.Request.Context(), weddingID, userID, number)
+	if err != nil {
+		h.handleError(c, err, "Failed to restore wedding revision")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, wedding)
+}
+
+func (h *WeddingRevisionHandler) parseWeddingRequest(c *gin.Context) (models.ID, models.ID, bool) {
+	weddingID, err := models.ParseID(c.Param("wedding_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return models.NilID, models.NilID, false
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return models.NilID, models.NilID, false
+	}
+
+	return weddingID, userID, true
+}
+
+func (h *WeddingRevisionHandler) parseRevision(c *gin.Context) (int, bool) {
+	number, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || number < 1 {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid revision number")
+		return 0, false
+	}
+	return number, true
+}
+
+func (h *WeddingRevisionHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrRevisionNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Revision not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to edit this wedding")
+	case errors.Is(err, services.ErrNothingToRestore):
+		utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrPlanLimitExceeded):
+		utils.ErrorResponse(c, http.StatusPaymentRequired, err.Error())
+	case errors.Is(err, services.ErrThemeNotFound), errors.Is(err, services.ErrDuplicateEventSession),
+		errors.Is(err, services.ErrInvalidEventTimezone), errors.Is(err, services.ErrInvalidEventEnd):
+		// The revision no longer passes validation, e.g. its theme was withdrawn
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockWeddingReviser is a mock implementation of WeddingReviser
+type MockWeddingReviser struct {
+	mock.Mock
+}
+
+func (m *MockWeddingReviser) ListRevisions(ctx context.Context, weddingID, userID models.ID) ([]*models.WeddingRevision, error) {
+	args := m.Called(ctx, weddingID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WeddingRevision), args.Error(1)
+}
+
+func (m *MockWeddingReviser) GetRevision(ctx context.Context, weddingID, userID models.ID, number int) (*models.WeddingRevision, error) {
+	args := m.Called(ctx, weddingID, userID, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WeddingRevision), args.Error(1)
+}
+
+func (m *MockWeddingReviser) RestoreRevision(ctx context.Context, weddingID, userID models.ID, number int) (*models.Wedding, error) {
+	args := m.Called(ctx, weddingID, userID, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Wedding), args.Error(1)
+}
+
+func setupWeddingRevisionTestRouter(handler *WeddingRevisionHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	revisions := router.Group("/api/v1/weddings/:wedding_id/revisions")
+	revisions.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	revisions.GET("", handler.ListRevisions)
+	revisions.GET("/:rev", handler.GetRevision)
+	revisions.POST("/:rev/restore", handler.RestoreRevision)
+
+	return router
+}
+
+func TestWeddingRevisionHandler(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	base := "/api/v1/weddings/" + weddingID.String() + "/revisions"
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		setup    func(*MockWeddingReviser)
+		expected int
+	}{
+		{
+			name: "list", method: http.MethodGet, path: base,
+			setup: func(m *MockWeddingReviser) {
+				m.On("ListRevisions", mock.Anything, weddingID, userID).Return([]*models.WeddingRevision{
+					{Number: 2, WeddingID: weddingID, ChangedFields: []string{"title"}},
+				}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name: "list as a guest manager", method: http.MethodGet, path: base,
+			setup: func(m *MockWeddingReviser) {
+				m.On("ListRevisions", mock.Anything, weddingID, userID).Return(nil, services.ErrUnauthorized)
+			},
+			expected: http.StatusForbidden,
+		},
+		{
+			name: "get an unknown revision", method: http.MethodGet, path: base + "/9",
+			setup: func(m *MockWeddingReviser) {
+				m.On("GetRevision", mock.Anything, weddingID, userID, 9).Return(nil, services.ErrRevisionNotFound)
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			name: "get with an invalid number", method: http.MethodGet, path: base + "/latest",
+			setup: func(*MockWeddingReviser) {}, expected: http.StatusBadRequest,
+		},
+		{
+			name: "restore", method: http.MethodPost, path: base + "/1/restore",
+			setup: func(m *MockWeddingReviser) {
+				m.On("RestoreRevision", mock.Anything, weddingID, userID, 1).Return(&models.Wedding{ID: weddingID}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name: "restore the current content", method: http.MethodPost, path: base + "/1/restore",
+			setup: func(m *MockWeddingReviser) {
+				m.On("RestoreRevision", mock.Anything, weddingID, userID, 1).Return(nil, services.ErrNothingToRestore)
+			},
+			expected: http.StatusConflict,
+		},
+		{
+			name: "restore a withdrawn theme", method: http.MethodPost, path: base + "/1/restore",
+			setup: func(m *MockWeddingReviser) {
+				m.On("RestoreRevision", mock.Anything, weddingID, userID, 1).Return(nil, services.ErrThemeNotFound)
+			},
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revisions := new(MockWeddingReviser)
+			tt.setup(revisions)
+
+			w := httptest.NewRecorder()
+			setupWeddingRevisionTestRouter(NewWeddingRevisionHandler(revisions), userID).ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expected, w.Code)
+			revisions.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockSlugResolver is a mock implementation of services.SlugResolver
+type MockSlugResolver struct {
+	mock.Mock
+}
+
+func (m *MockSlugResolver) ResolveSlug(ctx context.Context, slug string) (string, error) {
+	args := m.Called(ctx, slug)
+	return args.String(0), args.Error(1)
+}
+
+func TestPublicHandler_GetWeddingBySlug_RenamedSlug(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		resolved string
+		resolve  error
+		expected int
+		location string
+	}{
+		{
+			name: "old slug", path: "/api/v1/public/weddings/old-slug?lang=id",
+			resolved: "new-slug", expected: http.StatusMovedPermanently,
+			location: "/api/v1/public/weddings/new-slug?lang=id",
+		},
+		{
+			name: "old slug of the calendar", path: "/api/v1/public/weddings/old-slug/calendar.ics",
+			resolved: "new-slug", expected: http.StatusMovedPermanently,
+			location: "/api/v1/public/weddings/new-slug/calendar.ics",
+		},
+		{
+			name: "unknown slug", path: "/api/v1/public/weddings/old-slug",
+			resolve: services.ErrWeddingNotFound, expected: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weddings := new(MockWeddingServiceForPublic)
+			weddings.On("GetWeddingBySlugForPublic", mock.Anything, "old-slug").Return(nil, services.ErrWeddingNotFound)
+			slugs := new(MockSlugResolver)
+			slugs.On("ResolveSlug", mock.Anything, "old-slug").Return(tt.resolved, tt.resolve)
+
+			publicHandler := NewPublicHandler(weddings, new(MockRSVPServiceForPublic))
+			publicHandler.EnableSlugRedirects(slugs)
+
+			w := httptest.NewRecorder()
+			setupPublicTestRouter(publicHandler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expected, w.Code)
+			assert.Equal(t, tt.location, w.Header().Get("Location"))
+			slugs.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPublicHandler_GetWeddingBySlug_UnpublishedNotRedirected(t *testing.T) {
+	weddings := new(MockWeddingServiceForPublic)
+	weddings.On("GetWeddingBySlugForPublic", mock.Anything, "draft").Return(nil, services.ErrWeddingNotPublished)
+	slugs := new(MockSlugResolver)
+
+	publicHandler := NewPublicHandler(weddings, new(MockRSVPServiceForPublic))
+	publicHandler.EnableSlugRedirects(slugs)
+
+	w := httptest.NewRecorder()
+	setupPublicTestRouter(publicHandler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/public/weddings/draft", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	slugs.AssertNotCalled(t, "ResolveSlug", mock.Anything, mock.Anything)
+}
+
+func TestWeddingHandler_CheckSlugAvailability(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+
+	tests := []struct {
+		name     string
+		query    string
+		setup    func(*MockWeddingService)
+		expected int
+	}{
+		{
+			name: "taken slug", query: "?slug=anna-ben",
+			setup: func(m *MockWeddingService) {
+				m.On("CheckSlugAvailability", mock.Anything, "anna-ben", models.NilID, userID).Return(&services.SlugAvailability{
+					Slug: "anna-ben", Reason: services.SlugUnavailableTaken, Suggestion: "anna-ben-1",
+				}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name: "for a wedding", query: "?slug=anna-ben&wedding_id=" + weddingID.String(),
+			setup: func(m *MockWeddingService) {
+				m.On("CheckSlugAvailability", mock.Anything, "anna-ben", weddingID, userID).Return(&services.SlugAvailability{
+					Slug: "anna-ben", Available: true,
+				}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name: "for another user's wedding", query: "?slug=anna-ben&wedding_id=" + weddingID.String(),
+			setup: func(m *MockWeddingService) {
+				m.On("CheckSlugAvailability", mock.Anything, "anna-ben", weddingID, userID).Return(nil, services.ErrUnauthorized)
+			},
+			expected: http.StatusForbidden,
+		},
+		{
+			name: "missing slug", query: "", setup: func(*MockWeddingService) {}, expected: http.StatusBadRequest,
+		},
+		{
+			name: "invalid wedding ID", query: "?slug=anna-ben&wedding_id=nope", setup: func(*MockWeddingService) {}, expected: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockWeddingService)
+			tt.setup(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/weddings/slug-availability"+tt.query, nil)
+			c.Set("userID", userID.String())
+
+			NewWeddingHandler(mockService).CheckSlugAvailability(c)
+
+			assert.Equal(t, tt.expected, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWeddingHandler_UpdateWedding_SlugErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "taken", err: services.ErrSlugTaken, expected: http.StatusConflict},
+		{name: "reserved", err: services.ErrReservedSlug, expected: http.StatusBadRequest},
+		{name: "inappropriate", err: services.ErrInappropriateSlug, expected: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := models.NewID()
+			wedding := createTestWedding()
+			body, err := json.Marshal(wedding)
+			require.NoError(t, err)
+
+			mockService := new(MockWeddingService)
+			mockService.On("UpdateWedding", mock.Anything, mock.AnythingOfType("*models.Wedding"), userID).Return(tt.err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/api/v1/weddings/"+wedding.ID.String(), bytes.NewReader(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("userID", userID.String())
+			c.Params = gin.Params{{Key: "id", Value: wedding.ID.String()}}
+
+			NewWeddingHandler(mockService).UpdateWedding(c)
+
+			assert.Equal(t, tt.expected, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
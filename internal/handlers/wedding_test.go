@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -14,10 +14,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
 
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/middleware"
+	"wedding-invitation-backend/internal/services"
 )
 
 // MockWeddingService is a mock implementation of WeddingService
@@ -25,12 +27,12 @@ type MockWeddingService struct {
 	mock.Mock
 }
 
-func (m *MockWeddingService) CreateWedding(ctx context.Context, wedding *models.Wedding, userID primitive.ObjectID) error {
+func (m *MockWeddingService) CreateWedding(ctx context.Context, wedding *models.Wedding, userID models.ID) error {
 	args := m.Called(ctx, wedding, userID)
 	return args.Error(0)
 }
 
-func (m *MockWeddingService) GetWeddingByID(ctx context.Context, id primitive.ObjectID, requestingUserID primitive.ObjectID) (*models.Wedding, error) {
+func (m *MockWeddingService) GetWeddingByID(ctx context.Context, id models.ID, requestingUserID models.ID) (*models.Wedding, error) {
 	args := m.Called(ctx, id, requestingUserID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -38,7 +40,7 @@ func (m *MockWeddingService) GetWeddingByID(ctx context.Context, id primitive.Ob
 	return args.Get(0).(*models.Wedding), args.Error(1)
 }
 
-func (m *MockWeddingService) GetWeddingBySlug(ctx context.Context, slug string, requestingUserID primitive.ObjectID) (*models.Wedding, error) {
+func (m *MockWeddingService) GetWeddingBySlug(ctx context.Context, slug string, requestingUserID models.ID) (*models.Wedding, error) {
 	args := m.Called(ctx, slug, requestingUserID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -46,22 +48,22 @@ func (m *MockWeddingService) GetWeddingBySlug(ctx context.Context, slug string,
 	return args.Get(0).(*models.Wedding), args.Error(1)
 }
 
-func (m *MockWeddingService) GetUserWeddings(ctx context.Context, userID primitive.ObjectID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error) {
+func (m *MockWeddingService) GetUserWeddings(ctx context.Context, userID models.ID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error) {
 	args := m.Called(ctx, userID, page, pageSize, filters)
 	return args.Get(0).([]*models.Wedding), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockWeddingService) UpdateWedding(ctx context.Context, wedding *models.Wedding, requestingUserID primitive.ObjectID) error {
+func (m *MockWeddingService) UpdateWedding(ctx context.Context, wedding *models.Wedding, requestingUserID models.ID) error {
 	args := m.Called(ctx, wedding, requestingUserID)
 	return args.Error(0)
 }
 
-func (m *MockWeddingService) DeleteWedding(ctx context.Context, weddingID primitive.ObjectID, requestingUserID primitive.ObjectID) error {
+func (m *MockWeddingService) DeleteWedding(ctx context.Context, weddingID models.ID, requestingUserID models.ID) error {
 	args := m.Called(ctx, weddingID, requestingUserID)
 	return args.Error(0)
 }
 
-func (m *MockWeddingService) PublishWedding(ctx context.Context, weddingID primitive.ObjectID, requestingUserID primitive.ObjectID) error {
+func (m *MockWeddingService) PublishWedding(ctx context.Context, weddingID models.ID, requestingUserID models.ID) error {
 	args := m.Called(ctx, weddingID, requestingUserID)
 	return args.Error(0)
 }
@@ -71,10 +73,18 @@ func (m *MockWeddingService) ListPublicWeddings(ctx context.Context, page, pageS
 	return args.Get(0).([]*models.Wedding), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockWeddingService) CheckSlugAvailability(ctx context.Context, slug string, weddingID, userID models.ID) (*services.SlugAvailability, error) {
+	args := m.Called(ctx, slug, weddingID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.SlugAvailability), args.Error(1)
+}
+
 func createTestWedding() *models.Wedding {
 	return &models.Wedding{
-		ID:     primitive.NewObjectID(),
-		UserID: primitive.NewObjectID(),
+		ID:     models.NewID(),
+		UserID: models.NewID(),
 		Title:  "Test Wedding",
 		Slug:   "test-wedding",
 		Couple: models.CoupleInfo{
@@ -141,9 +151,9 @@ func setupTestRouter(mockService *MockWeddingService) *gin.Engine {
 func TestWeddingHandler_CreateWedding(t *testing.T) {
 	mockService := new(MockWeddingService)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	wedding := createTestWedding()
-	wedding.ID = primitive.NilObjectID // Will be set by service
+	wedding.ID = models.NilID // Will be set by service
 
 	weddingJSON, _ := json.Marshal(wedding)
 	req, _ := http.NewRequest("POST", "/api/v1/weddings", bytes.NewBuffer(weddingJSON))
@@ -157,7 +167,7 @@ func TestWeddingHandler_CreateWedding(t *testing.T) {
 	// Create a gin context with user ID
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
-	c.Set("userID", userID.Hex())
+	c.Set("userID", userID.String())
 
 	// Call the handler directly
 	handler := NewWeddingHandler(mockService)
@@ -196,11 +206,11 @@ func TestWeddingHandler_GetWedding(t *testing.T) {
 	_ = setupTestRouter(mockService)
 
 	wedding := createTestWedding()
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 
 	mockService.On("GetWeddingByID", mock.Anything, wedding.ID, userID).Return(wedding, nil)
 
-	req, _ := http.NewRequest("GET", "/api/v1/weddings/"+wedding.ID.Hex(), nil)
+	req, _ := http.NewRequest("GET", "/api/v1/weddings/"+wedding.ID.String(), nil)
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -208,8 +218,8 @@ func TestWeddingHandler_GetWedding(t *testing.T) {
 	// Create a gin context with user ID
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
-	c.Set("userID", userID.Hex())
-	c.Params = gin.Params{{Key: "id", Value: wedding.ID.Hex()}}
+	c.Set("userID", userID.String())
+	c.Params = gin.Params{{Key: "id", Value: wedding.ID.String()}}
 
 	// Call the handler directly
 	handler := NewWeddingHandler(mockService)
@@ -246,24 +256,23 @@ func TestWeddingHandler_GetWedding_NotFound(t *testing.T) {
 	mockService := new(MockWeddingService)
 	_ = setupTestRouter(mockService)
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
-	mockService.On("GetWeddingByID", mock.Anything, weddingID, userID).Return(nil, errors.New("wedding not found"))
+	mockService.On("GetWeddingByID", mock.Anything, weddingID, userID).Return(nil, services.ErrWeddingNotFound)
 
-	req, _ := http.NewRequest("GET", "/api/v1/weddings/"+weddingID.Hex(), nil)
+	req, _ := http.NewRequest("GET", "/api/v1/weddings/"+weddingID.String(), nil)
 
 	w := httptest.NewRecorder()
 
-	// Create a gin context with user ID
-	c, _ := gin.CreateTestContext(w)
-	c.Request = req
-	c.Set("userID", userID.Hex())
-	c.Params = gin.Params{{Key: "id", Value: weddingID.Hex()}}
-
-	// Call the handler directly
-	handler := NewWeddingHandler(mockService)
-	handler.GetWedding(c)
+	// Service errors are answered by the error handling middleware
+	router := gin.New()
+	router.Use(middleware.NewErrorHandler(zap.NewNop(), middleware.DefaultErrorConfig()).Middleware())
+	router.GET("/api/v1/weddings/:id", func(c *gin.Context) {
+		c.Set("userID", userID.String())
+		NewWeddingHandler(mockService).GetWedding(c)
+	})
+	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusNotFound, w.Code)
 
@@ -280,7 +289,7 @@ func TestWeddingHandler_GetWeddingBySlug(t *testing.T) {
 	_ = setupTestRouter(mockService)
 
 	wedding := createTestWedding()
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 
 	mockService.On("GetWeddingBySlug", mock.Anything, wedding.Slug, userID).Return(wedding, nil)
 
@@ -291,7 +300,7 @@ func TestWeddingHandler_GetWeddingBySlug(t *testing.T) {
 	// Create a gin context with user ID
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
-	c.Set("userID", userID.Hex())
+	c.Set("userID", userID.String())
 	c.Params = gin.Params{{Key: "slug", Value: wedding.Slug}}
 
 	// Call the handler directly
@@ -312,7 +321,7 @@ func TestWeddingHandler_GetUserWeddings(t *testing.T) {
 	mockService := new(MockWeddingService)
 	_ = setupTestRouter(mockService)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	weddings := []*models.Wedding{createTestWedding()}
 	filters := repository.WeddingFilters{}
 
@@ -325,7 +334,7 @@ func TestWeddingHandler_GetUserWeddings(t *testing.T) {
 	// Create a gin context with user ID
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
-	c.Set("userID", userID.Hex())
+	c.Set("userID", userID.String())
 	c.Request = req
 
 	// Call the handler directly
@@ -349,11 +358,11 @@ func TestWeddingHandler_UpdateWedding(t *testing.T) {
 	mockService := new(MockWeddingService)
 	_ = setupTestRouter(mockService)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	wedding := createTestWedding()
 
 	weddingJSON, _ := json.Marshal(wedding)
-	req, _ := http.NewRequest("PUT", "/api/v1/weddings/"+wedding.ID.Hex(), bytes.NewBuffer(weddingJSON))
+	req, _ := http.NewRequest("PUT", "/api/v1/weddings/"+wedding.ID.String(), bytes.NewBuffer(weddingJSON))
 	req.Header.Set("Content-Type", "application/json")
 
 	mockService.On("UpdateWedding", mock.Anything, mock.AnythingOfType("*models.Wedding"), userID).Return(nil)
@@ -363,8 +372,8 @@ func TestWeddingHandler_UpdateWedding(t *testing.T) {
 	// Create a gin context with user ID
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
-	c.Set("userID", userID.Hex())
-	c.Params = gin.Params{{Key: "id", Value: wedding.ID.Hex()}}
+	c.Set("userID", userID.String())
+	c.Params = gin.Params{{Key: "id", Value: wedding.ID.String()}}
 
 	// Call the handler directly
 	handler := NewWeddingHandler(mockService)
@@ -384,20 +393,20 @@ func TestWeddingHandler_DeleteWedding(t *testing.T) {
 	mockService := new(MockWeddingService)
 	_ = setupTestRouter(mockService)
 
-	userID := primitive.NewObjectID()
-	weddingID := primitive.NewObjectID()
+	userID := models.NewID()
+	weddingID := models.NewID()
 
 	mockService.On("DeleteWedding", mock.Anything, weddingID, userID).Return(nil)
 
-	req, _ := http.NewRequest("DELETE", "/api/v1/weddings/"+weddingID.Hex(), nil)
+	req, _ := http.NewRequest("DELETE", "/api/v1/weddings/"+weddingID.String(), nil)
 
 	w := httptest.NewRecorder()
 
 	// Create a gin context with user ID
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
-	c.Set("userID", userID.Hex())
-	c.Params = gin.Params{{Key: "id", Value: weddingID.Hex()}}
+	c.Set("userID", userID.String())
+	c.Params = gin.Params{{Key: "id", Value: weddingID.String()}}
 
 	// Call the handler directly
 	handler := NewWeddingHandler(mockService)
@@ -417,7 +426,7 @@ func TestWeddingHandler_PublishWedding(t *testing.T) {
 	mockService := new(MockWeddingService)
 	_ = setupTestRouter(mockService)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	wedding := createTestWedding()
 	wedding.UserID = userID // Ensure the wedding belongs to the test user
 
@@ -428,15 +437,15 @@ func TestWeddingHandler_PublishWedding(t *testing.T) {
 	// Third call to get updated wedding
 	mockService.On("GetWeddingByID", mock.Anything, wedding.ID, userID).Return(wedding, nil)
 
-	req, _ := http.NewRequest("POST", "/api/v1/weddings/"+wedding.ID.Hex()+"/publish", nil)
+	req, _ := http.NewRequest("POST", "/api/v1/weddings/"+wedding.ID.String()+"/publish", nil)
 
 	w := httptest.NewRecorder()
 
 	// Create a gin context with user ID
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
-	c.Set("userID", userID.Hex())
-	c.Params = gin.Params{{Key: "id", Value: wedding.ID.Hex()}}
+	c.Set("userID", userID.String())
+	c.Params = gin.Params{{Key: "id", Value: wedding.ID.String()}}
 
 	// Call the handler directly
 	handler := NewWeddingHandler(mockService)
@@ -479,30 +488,74 @@ func TestWeddingHandler_ListPublicWeddings(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestWeddingHandler_ListPublicWeddings_SearchFilters(t *testing.T) {
+	mockService := new(MockWeddingService)
+	router := setupTestRouter(mockService)
+
+	dateFrom := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	dateTo := time.Date(2025, 6, 30, 23, 59, 59, 999999999, time.UTC)
+	filters := repository.PublicWeddingFilters{
+		Search:   "john",
+		When:     repository.PublicWeddingsUpcoming,
+		Sort:     repository.PublicWeddingSortMostViewed,
+		DateFrom: &dateFrom,
+		DateTo:   &dateTo,
+	}
+
+	mockService.On("ListPublicWeddings", mock.Anything, 1, 20, filters).Return([]*models.Wedding{}, int64(0), nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/public/weddings?search=john&when=upcoming&sort=most_viewed&date_from=2025-06-01&date_to=2025-06-30", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestWeddingHandler_ListPublicWeddings_InvalidFilters(t *testing.T) {
+	mockService := new(MockWeddingService)
+	router := setupTestRouter(mockService)
+
+	mockService.On("ListPublicWeddings", mock.Anything, 1, 20, repository.PublicWeddingFilters{Sort: "alphabetical"}).
+		Return([]*models.Wedding(nil), int64(0), fmt.Errorf("%w: unknown sort", services.ErrInvalidPublicWeddingFilter))
+
+	req, _ := http.NewRequest("GET", "/api/v1/public/weddings?sort=alphabetical", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req, _ = http.NewRequest("GET", "/api/v1/public/weddings?date_from=june", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockService.AssertExpectations(t)
+}
+
 func TestWeddingHandler_UpdateWedding_AccessDenied(t *testing.T) {
 	mockService := new(MockWeddingService)
 	_ = setupTestRouter(mockService)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	wedding := createTestWedding()
 
 	weddingJSON, _ := json.Marshal(wedding)
-	req, _ := http.NewRequest("PUT", "/api/v1/weddings/"+wedding.ID.Hex(), bytes.NewBuffer(weddingJSON))
+	req, _ := http.NewRequest("PUT", "/api/v1/weddings/"+wedding.ID.String(), bytes.NewBuffer(weddingJSON))
 	req.Header.Set("Content-Type", "application/json")
 
-	mockService.On("UpdateWedding", mock.Anything, mock.AnythingOfType("*models.Wedding"), userID).Return(errors.New("access denied"))
+	mockService.On("UpdateWedding", mock.Anything, mock.AnythingOfType("*models.Wedding"), userID).Return(services.ErrWeddingAccessDenied)
 
 	w := httptest.NewRecorder()
 
-	// Create a gin context with user ID
-	c, _ := gin.CreateTestContext(w)
-	c.Request = req
-	c.Set("userID", userID.Hex())
-	c.Params = gin.Params{{Key: "id", Value: wedding.ID.Hex()}}
-
-	// Call the handler directly
-	handler := NewWeddingHandler(mockService)
-	handler.UpdateWedding(c)
+	// Service errors are answered by the error handling middleware
+	router := gin.New()
+	router.Use(middleware.NewErrorHandler(zap.NewNop(), middleware.DefaultErrorConfig()).Middleware())
+	router.PUT("/api/v1/weddings/:id", func(c *gin.Context) {
+		c.Set("userID", userID.String())
+		NewWeddingHandler(mockService).UpdateWedding(c)
+	})
+	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusForbidden, w.Code)
 
@@ -518,22 +571,22 @@ func TestWeddingHandler_PublishWedding_OwnerCheck(t *testing.T) {
 	mockService := new(MockWeddingService)
 	_ = setupTestRouter(mockService)
 
-	userID := primitive.NewObjectID()
-	otherUserID := primitive.NewObjectID()
+	userID := models.NewID()
+	otherUserID := models.NewID()
 	wedding := createTestWedding()
 	wedding.UserID = otherUserID // Different from requesting user
 
 	mockService.On("GetWeddingByID", mock.Anything, wedding.ID, userID).Return(wedding, nil)
 
-	req, _ := http.NewRequest("POST", "/api/v1/weddings/"+wedding.ID.Hex()+"/publish", nil)
+	req, _ := http.NewRequest("POST", "/api/v1/weddings/"+wedding.ID.String()+"/publish", nil)
 
 	w := httptest.NewRecorder()
 
 	// Create a gin context with user ID
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
-	c.Set("userID", userID.Hex())
-	c.Params = gin.Params{{Key: "id", Value: wedding.ID.Hex()}}
+	c.Set("userID", userID.String())
+	c.Params = gin.Params{{Key: "id", Value: wedding.ID.String()}}
 
 	// Call the handler directly
 	handler := NewWeddingHandler(mockService)
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// WeddingWebhookManager manages the webhooks a wedding owner subscribes to the wedding's events
+type WeddingWebhookManager interface {
+	CreateWebhook(ctx context.Context, weddingID, userID models.ID, req services.CreateWeddingWebhookRequest) (*models.WeddingWebhook, string, error)
+	ListWebhooks(ctx context.Context, weddingID, userID models.ID) ([]*models.WeddingWebhook, error)
+	UpdateWebhook(ctx context.Context, weddingID, id, userID models.ID, req services.UpdateWeddingWebhookRequest) (*models.WeddingWebhook, error)
+	DeleteWebhook(ctx context.Context, weddingID, id, userID models.ID) error
+	ListDeliveries(ctx context.Context, weddingID, id, userID models.ID, page, pageSize int) ([]*models.WeddingWebhookDelivery, int64, error)
+}
+
+// WeddingWebhookHandler serves a wedding's webhooks and their delivery logs
+type WeddingWebhookHandler struct {
+	webhooks WeddingWebhookManager
+}
+
+// NewWeddingWebhookHandler creates a new wedding webhook handler
+func NewWeddingWebhookHandler(webhooks WeddingWebhookManager) *WeddingWebhookHandler {
+	return &WeddingWebhookHandler{webhooks: webhooks}
+}
+
+// CreateWeddingWebhookResponse includes the signing secret, which is only returned once
+type CreateWeddingWebhookResponse struct {
+	*models.WeddingWebhook
+	Secret string `json:"secret"`
+}
+
+// CreateWebhook godoc
+// @Summary Create a wedding webhook
+// @Description Subscribe an endpoint to signed events of a wedding: rsvp.created, guest.checked_in, wedding.published and media.uploaded (owner only). Without events, every event is delivered.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param webhook body services.CreateWeddingWebhookRequest true "Webhook data"
+// @Success 201 {object} CreateWeddingWebhookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/webhooks [post]
+func (h *WeddingWebhookHandler) CreateWebhook(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	var req services.CreateWeddingWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, secret, err := h.webhooks.CreateWebhook(c.Request.Context(), weddingID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create wedding webhook")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, CreateWeddingWebhookResponse{
+		WeddingWebhook: webhook,
+		Secret:         secret,
+	})
+}
+
+// ListWebhooks godoc
+// @Summary List wedding webhooks
+// @Description List the webhooks of a wedding (owner only)
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Success 200 {array} models.WeddingWebhook
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/webhooks [get]
+func (h *WeddingWebhookHandler) ListWebhooks(c *gin.Context) {
+	weddingID, userID, ok := h.parseWeddingRequest(c)
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.webhooks.ListWebhooks(c.Request.Context(), weddingID, userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list wedding webhooks")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, webhooks)
+}
+
+// UpdateWebhook godoc
+// @Summary Update a wedding webhook
+// @Description Change the endpoint, events, or active state of a wedding webhook (owner only)
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param webhook_id path string true "Webhook ID"
+// @Param webhook body services.UpdateWeddingWebhookRequest true "Webhook changes"
+// @Success 200 {object} models.WeddingWebhook
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/webhooks/{webhook_id} [put]
+func (h *WeddingWebhookHandler) UpdateWebhook(c *gin.Context) {
+	weddingID, webhookID, userID, ok := h.parseWebhookRequest(c)
+	if !ok {
+		return
+	}
+
+	var req services.UpdateWeddingWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, err := h.webhooks.UpdateWebhook(c.Request.Context(), weddingID, webhookID, userID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update wedding webhook")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a wedding webhook
+// @Description Remove a wedding webhook and its delivery log (owner only)
+// @Tags webhooks
+// @Param id path string true "Wedding ID"
+// @Param webhook_id path string true "Webhook ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/webhooks/{webhook_id} [delete]
+func (h *WeddingWebhookHandler) DeleteWebhook(c *gin.Context) {
+	weddingID, webhookID, userID, ok := h.parseWebhookRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhooks.DeleteWebhook(c.Request.Context(), weddingID, webhookID, userID); err != nil {
+		h.handleError(c, err, "Failed to delete wedding webhook")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary List wedding webhook deliveries
+// @Description List the delivery attempts of a wedding webhook, newest first, including failed attempts that are retried (owner only)
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param webhook_id path string true "Webhook ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PaginatedResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/webhooks/{webhook_id}/deliveries [get]
+func (h *WeddingWebhookHandler) ListDeliveries(c *gin.Context) {
+	weddingID, webhookID, userID, ok := h.parseWebhookRequest(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+
+	deliveries, total, err := h.webhooks.ListDeliveries(c.Request.Context(), weddingID, webhookID, userID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err, "Failed to list wedding webhook deliveries")
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, deliveries, int64(len(deliveries)), total, page, pageSize)
+}
+
+// parseWeddingRequest reads the wedding and the authenticated user of a request
+func (h *WeddingWebhookHandler) parseWeddingRequest(c *gin.Context) (weddingID, userID models.ID, ok bool) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	return weddingID, userID, true
+}
+
+// parseWebhookRequest reads the wedding, the webhook and the authenticated user of a request
+func (h *WeddingWebhookHandler) parseWebhookRequest(c *gin.Context) (weddingID, webhookID, userID models.ID, ok bool) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	webhookID, err = models.ParseID(c.Param("webhook_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid webhook ID")
+		return
+	}
+
+	userID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	return weddingID, webhookID, userID, true
+}
+
+func (h *WeddingWebhookHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrWeddingWebhookNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding webhook not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Only the wedding owner can manage its webhooks")
+	case errors.Is(err, services.ErrTooManyWeddingWebhooks):
+		utils.ErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrInvalidWebhookURL), errors.Is(err, services.ErrWebhookURLNotPublic), errors.Is(err, services.ErrInvalidWeddingEvent):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockWeddingWebhookManager is a mock implementation of WeddingWebhookManager
+type MockWeddingWebhookManager struct {
+	mock.Mock
+}
+
+func (m *MockWeddingWebhookManager) CreateWebhook(ctx context.Context, weddingID, userID models.ID, req services.CreateWeddingWebhookRequest) (*models.WeddingWebhook, string, error) {
+	args := m.Called(ctx, weddingID, userID, req)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*models.WeddingWebhook), args.String(1), args.Error(2)
+}
+
+func (m *MockWeddingWebhookManager) ListWebhooks(ctx context.Context, weddingID, userID models.ID) ([]*models.WeddingWebhook, error) {
+	args := m.Called(ctx, weddingID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WeddingWebhook), args.Error(1)
+}
+
+func (m *MockWeddingWebhookManager) UpdateWebhook(ctx context.Context, weddingID, id, userID models.ID, req services.UpdateWeddingWebhookRequest) (*models.WeddingWebhook, error) {
+	args := m.Called(ctx, weddingID, id, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WeddingWebhook), args.Error(1)
+}
+
+func (m *MockWeddingWebhookManager) DeleteWebhook(ctx context.Context, weddingID, id, userID models.ID) error {
+	args := m.Called(ctx, weddingID, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockWeddingWebhookManager) ListDeliveries(ctx context.Context, weddingID, id, userID models.ID, page, pageSize int) ([]*models.WeddingWebhookDelivery, int64, error) {
+	args := m.Called(ctx, weddingID, id, userID, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*models.WeddingWebhookDelivery), args.Get(1).(int64), args.Error(2)
+}
+
+func setupWeddingWebhookTestRouter(handler *WeddingWebhookHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	protected := router.Group("/api/v1/weddings/:id/webhooks")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	protected.GET("", handler.ListWebhooks)
+	protected.POST("", handler.CreateWebhook)
+	protected.PUT("/:webhook_id", handler.UpdateWebhook)
+	protected.DELETE("/:webhook_id", handler.DeleteWebhook)
+	protected.GET("/:webhook_id/deliveries", handler.ListDeliveries)
+
+	return router
+}
+
+func TestWeddingWebhookHandler_CreateWebhook(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	path := "/api/v1/weddings/" + weddingID.String() + "/webhooks"
+
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		expected int
+	}{
+		{"created", `{"url":"https://example.com/hook","events":["rsvp.created"]}`, nil, http.StatusCreated},
+		{"missing url", `{"events":["rsvp.created"]}`, nil, http.StatusBadRequest},
+		{"unknown event", `{"url":"https://example.com/hook","events":["party.started"]}`, services.ErrInvalidWeddingEvent, http.StatusBadRequest},
+		{"not the owner", `{"url":"https://example.com/hook"}`, services.ErrUnauthorized, http.StatusForbidden},
+		{"too many webhooks", `{"url":"https://example.com/hook"}`, services.ErrTooManyWeddingWebhooks, http.StatusConflict},
+		{"unknown wedding", `{"url":"https://example.com/hook"}`, services.ErrWeddingNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhooks := new(MockWeddingWebhookManager)
+			if tt.err != nil {
+				webhooks.On("CreateWebhook", mock.Anything, weddingID, userID, mock.Anything).Return(nil, "", tt.err)
+			} else {
+				webhooks.On("CreateWebhook", mock.Anything, weddingID, userID, mock.Anything).
+					Return(&models.WeddingWebhook{ID: models.NewID(), WeddingID: weddingID, Secret: "signing-secret"}, "signing-secret", nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupWeddingWebhookTestRouter(NewWeddingWebhookHandler(webhooks), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			if tt.expected == http.StatusCreated {
+				// The secret is returned once, at the top level of the response
+				var response struct {
+					Data map[string]interface{} `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "signing-secret", response.Data["secret"])
+			}
+		})
+	}
+}
+
+func TestWeddingWebhookHandler_ListWebhooks_HidesSecret(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+
+	webhooks := new(MockWeddingWebhookManager)
+	webhooks.On("ListWebhooks", mock.Anything, weddingID, userID).
+		Return([]*models.WeddingWebhook{{ID: models.NewID(), URL: "https://example.com/hook", Secret: "signing-secret"}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/weddings/"+weddingID.String()+"/webhooks", nil)
+	w := httptest.NewRecorder()
+	setupWeddingWebhookTestRouter(NewWeddingWebhookHandler(webhooks), userID).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "https://example.com/hook")
+	assert.NotContains(t, w.Body.String(), "signing-secret")
+}
+
+func TestWeddingWebhookHandler_ManageWebhook(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	webhookID := models.NewID()
+	base := "/api/v1/weddings/" + weddingID.String() + "/webhooks/"
+
+	t.Run("paused", func(t *testing.T) {
+		webhooks := new(MockWeddingWebhookManager)
+		paused := mock.MatchedBy(func(req services.UpdateWeddingWebhookRequest) bool {
+			return req.Active != nil && !*req.Active
+		})
+		webhooks.On("UpdateWebhook", mock.Anything, weddingID, webhookID, userID, paused).
+			Return(&models.WeddingWebhook{ID: webhookID}, nil)
+
+		req := httptest.NewRequest(http.MethodPut, base+webhookID.String(), bytes.NewBufferString(`{"active":false}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setupWeddingWebhookTestRouter(NewWeddingWebhookHandler(webhooks), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		webhooks.AssertExpectations(t)
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		webhooks := new(MockWeddingWebhookManager)
+		webhooks.On("DeleteWebhook", mock.Anything, weddingID, webhookID, userID).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, base+webhookID.String(), nil)
+		w := httptest.NewRecorder()
+		setupWeddingWebhookTestRouter(NewWeddingWebhookHandler(webhooks), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("unknown webhook", func(t *testing.T) {
+		webhooks := new(MockWeddingWebhookManager)
+		webhooks.On("DeleteWebhook", mock.Anything, weddingID, webhookID, userID).Return(services.ErrWeddingWebhookNotFound)
+
+		req := httptest.NewRequest(http.MethodDelete, base+webhookID.String(), nil)
+		w := httptest.NewRecorder()
+		setupWeddingWebhookTestRouter(NewWeddingWebhookHandler(webhooks), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("invalid webhook ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, base+"not-an-id", nil)
+		w := httptest.NewRecorder()
+		setupWeddingWebhookTestRouter(NewWeddingWebhookHandler(new(MockWeddingWebhookManager)), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestWeddingWebhookHandler_ListDeliveries(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	webhookID := models.NewID()
+
+	webhooks := new(MockWeddingWebhookManager)
+	webhooks.On("ListDeliveries", mock.Anything, weddingID, webhookID, userID, 2, 10).
+		Return([]*models.WeddingWebhookDelivery{{WebhookID: webhookID, EventType: models.WeddingEventRSVPCreated, StatusCode: 500, Attempt: 1}}, int64(11), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/weddings/"+weddingID.String()+"/webhooks/"+webhookID.String()+"/deliveries?page=2&size=10", nil)
+	w := httptest.NewRecorder()
+	setupWeddingWebhookTestRouter(NewWeddingWebhookHandler(webhooks), userID).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"event_type":"rsvp.created"`)
+	webhooks.AssertExpectations(t)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// maxWhatsAppWebhookBody bounds the webhook notifications read from the Cloud API
+const maxWhatsAppWebhookBody = 1 << 20
+
+// MessageStatusReceiver applies messaging providers' delivery reports
+type MessageStatusReceiver interface {
+	HandleMessageStatuses(ctx context.Context, statuses []services.MessageStatus) error
+}
+
+// WhatsAppWebhookHandler receives the WhatsApp Cloud API's delivery reports
+type WhatsAppWebhookHandler struct {
+	receiver    MessageStatusReceiver
+	verifyToken string
+	appSecret   string
+}
+
+// NewWhatsAppWebhookHandler creates a handler for the webhook subscribed with verifyToken
+// whose notifications are signed with the Meta app secret
+func NewWhatsAppWebhookHandler(receiver MessageStatusReceiver, verifyToken, appSecret string) *WhatsAppWebhookHandler {
+	return &WhatsAppWebhookHandler{receiver: receiver, verifyToken: verifyToken, appSecret: appSecret}
+}
+
+// VerifyWebhook godoc
+// @Summary Verify the WhatsApp webhook
+// @Description Subscription handshake of the WhatsApp Cloud API: echoes hub.challenge when hub.verify_token matches
+// @Tags Webhooks
+// @Produce plain
+// @Param hub.mode query string true "subscribe"
+// @Param hub.verify_token query string true "Configured verify token"
+// @Param hub.challenge query string true "Challenge to echo"
+// @Success 200 {string} string
+// @Failure 403 {object} ErrorResponse
+// @Router /webhooks/whatsapp [get]
+func (h *WhatsAppWebhookHandler) VerifyWebhook(c *gin.Context) {
+	if h.verifyToken == "" || c.Query("hub.mode") != "subscribe" || c.Query("hub.verify_token") != h.verifyToken {
+		utils.ErrorResponse(c, http.StatusForbidden, "Invalid verify token")
+		return
+	}
+	c.String(http.StatusOK, c.Query("hub.challenge"))
+}
+
+// ReceiveWebhook godoc
+// @Summary Receive WhatsApp delivery reports
+// @Description Webhook notifications of the WhatsApp Cloud API, signed with the Meta app secret. Delivery reports of invitations move the guest's invitation_status to delivered, read or failed.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Success 200 {object} utils.APIResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /webhooks/whatsapp [post]
+func (h *WhatsAppWebhookHandler) ReceiveWebhook(c *gin.Context) {
+	if h.appSecret == "" {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "WhatsApp webhooks are not configured")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWhatsAppWebhookBody))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+	if !services.VerifyWhatsAppSignature(h.appSecret, body, c.GetHeader(services.WhatsAppSignatureHeader)) {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid signature")
+		return
+	}
+
+	statuses, err := services.ParseWhatsAppStatuses(body)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid webhook notification")
+		return
+	}
+
+	// A failure answers 500 so the Cloud API delivers the notification again
+	if err := h.receiver.HandleMessageStatuses(c.Request.Context(), statuses); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to process delivery reports")
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.APIResponse{
+		Success: true,
+		Message: "Webhook received",
+	})
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockMessageStatusReceiver records delivery reports and returns a fixed error
+type MockMessageStatusReceiver struct {
+	err      error
+	statuses []services.MessageStatus
+}
+
+func (m *MockMessageStatusReceiver) HandleMessageStatuses(ctx context.Context, statuses []services.MessageStatus) error {
+	m.statuses = append(m.statuses, statuses...)
+	return m.err
+}
+
+func setupWhatsAppWebhookRouter(receiver MessageStatusReceiver, appSecret string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewWhatsAppWebhookHandler(receiver, "verify-me", appSecret)
+	router.GET("/webhooks/whatsapp", handler.VerifyWebhook)
+	router.POST("/webhooks/whatsapp", handler.ReceiveWebhook)
+	return router
+}
+
+func postWhatsAppWebhook(router *gin.Engine, body, secret string) *httptest.ResponseRecorder {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/whatsapp", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(services.WhatsAppSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+const whatsAppStatusWebhook = `{"entry":[{"changes":[{"value":{"statuses":[{"id":"wamid.1","status":"read","timestamp":"1780000000"}]}}]}]}`
+
+func TestWhatsAppWebhookHandler_VerifyWebhook(t *testing.T) {
+	router := setupWhatsAppWebhookRouter(&MockMessageStatusReceiver{}, "app-secret")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/webhooks/whatsapp?hub.mode=subscribe&hub.verify_token=verify-me&hub.challenge=1158201444", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1158201444", w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/webhooks/whatsapp?hub.mode=subscribe&hub.verify_token=wrong&hub.challenge=1", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestWhatsAppWebhookHandler_ReceiveWebhook(t *testing.T) {
+	t.Run("Applies delivery reports", func(t *testing.T) {
+		receiver := &MockMessageStatusReceiver{}
+		router := setupWhatsAppWebhookRouter(receiver, "app-secret")
+
+		w := postWhatsAppWebhook(router, whatsAppStatusWebhook, "app-secret")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, receiver.statuses, 1)
+		assert.Equal(t, "wamid.1", receiver.statuses[0].MessageID)
+		assert.Equal(t, services.MessageStatusRead, receiver.statuses[0].Status)
+	})
+
+	t.Run("Error - invalid signature", func(t *testing.T) {
+		receiver := &MockMessageStatusReceiver{}
+		router := setupWhatsAppWebhookRouter(receiver, "app-secret")
+
+		w := postWhatsAppWebhook(router, whatsAppStatusWebhook, "forged")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Empty(t, receiver.statuses)
+	})
+
+	t.Run("Error - not configured", func(t *testing.T) {
+		router := setupWhatsAppWebhookRouter(&MockMessageStatusReceiver{}, "")
+
+		w := postWhatsAppWebhook(router, whatsAppStatusWebhook, "")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("Error - processing fails so the provider retries", func(t *testing.T) {
+		router := setupWhatsAppWebhookRouter(&MockMessageStatusReceiver{err: errors.New("db down")}, "app-secret")
+
+		w := postWhatsAppWebhook(router, whatsAppStatusWebhook, "app-secret")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
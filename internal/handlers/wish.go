@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// Guestbook stores the wishes guests leave, lets wedding editors moderate them and lists the approved ones
+type Guestbook interface {
+	CreateWish(ctx context.Context, slug string, req services.WishRequest) (*models.Wish, error)
+	ListPublicWishes(ctx context.Context, slug string, page, pageSize int) ([]*models.Wish, int64, error)
+	ListWishes(ctx context.Context, weddingID, userID models.ID, status models.WishStatus, page, pageSize int) ([]*models.Wish, int64, error)
+	ModerateWish(ctx context.Context, weddingID, wishID, userID models.ID, approve bool) (*models.Wish, error)
+	DeleteWish(ctx context.Context, weddingID, wishID, userID models.ID) error
+}
+
+// WishHandler serves the guestbook wishes wall and its moderation
+type WishHandler struct {
+	wishes Guestbook
+}
+
+// NewWishHandler creates a new guestbook wish handler
+func NewWishHandler(wishes Guestbook) *WishHandler {
+	return &WishHandler{wishes: wishes}
+}
+
+// CreateWish godoc
+// @Summary Leave a wish
+// @Description Leave a congratulatory message on a published wedding that accepts wishes. Profanity is masked, and the wish is shown once the owner approves it, unless the wedding skips approval.
+// @Tags wishes
+// @Accept json
+// @Produce json
+// @Param slug path string true "Wedding slug"
+// @Param wish body services.WishRequest true "Wish"
+// @Success 201 {object} models.Wish
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/public/weddings/slug/{slug}/wishes [post]
+func (h *WishHandler) CreateWish(c *gin.Context) {
+	var req services.WishRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request data: "+err.Error())
+		return
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.IP = c.ClientIP()
+
+	wish, err := h.wishes.CreateWish(c.Request.Context(), c.Param("slug"), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to save wish")
+		return
+	}
+
+	utils.Response(c, http.StatusCreated, wish)
+}
+
+// GetWishes godoc
+// @Summary Get a wedding's wishes
+// @Description Get the approved wishes of a published wedding, newest first
+// @Tags wishes
+// @Produce json
+// @Param slug path string true "Wedding slug"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PaginatedResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/public/weddings/slug/{slug}/wishes [get]
+func (h *WishHandler) GetWishes(c *gin.Context) {
+	page, pageSize := utils.ParsePaginationParams(c)
+
+	wishes, total, err := h.wishes.ListPublicWishes(c.Request.Context(), c.Param("slug"), page, pageSize)
+	if err != nil {
+		h.handleError(c, err, "Failed to get wishes")
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, wishes, int64(len(wishes)), total, page, pageSize)
+}
+
+// ListWishes godoc
+// @Summary List a wedding's wishes for moderation
+// @Description Get the wishes of a wedding, newest first (wedding editors only)
+// @Tags wishes
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param status query string false "Filter by status" Enums(pending, approved, hidden)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} PaginatedResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/wishes [get]
+func (h *WishHandler) ListWishes(c *gin.Context) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	page, pageSize := utils.ParsePaginationParams(c)
+	status := models.WishStatus(c.Query("status"))
+
+	wishes, total, err := h.wishes.ListWishes(c.Request.Context(), weddingID, userID, status, page, pageSize)
+	if err != nil {
+		h.handleError(c, err, "Failed to get wishes")
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, wishes, int64(len(wishes)), total, page, pageSize)
+}
+
+// ApproveWish godoc
+// @Summary Approve a wish
+// @Description Show a pending or hidden wish on the public wall (wedding editors only)
+// @Tags wishes
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param wish_id path string true "Wish ID"
+// @Success 200 {object} models.Wish
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/wishes/{wish_id}/approve [post]
+func (h *WishHandler) ApproveWish(c *gin.Context) {
+	h.moderateWish(c, true)
+}
+
+// HideWish godoc
+// @Summary Hide a wish
+// @Description Keep a wish off the public wall without deleting it (wedding editors only)
+// @Tags wishes
+// @Produce json
+// @Param id path string true "Wedding ID"
+// @Param wish_id path string true "Wish ID"
+// @Success 200 {object} models.Wish
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/wishes/{wish_id}/hide [post]
+func (h *WishHandler) HideWish(c *gin.Context) {
+	h.moderateWish(c, false)
+}
+
+func (h *WishHandler) moderateWish(c *gin.Context, approve bool) {
+	weddingID, wishID, userID, ok := h.parseWishRequest(c)
+	if !ok {
+		return
+	}
+
+	wish, err := h.wishes.ModerateWish(c.Request.Context(), weddingID, wishID, userID, approve)
+	if err != nil {
+		h.handleError(c, err, "Failed to moderate wish")
+		return
+	}
+
+	utils.Response(c, http.StatusOK, wish)
+}
+
+// DeleteWish godoc
+// @Summary Delete a wish
+// @Description Remove a wish from a wedding's guestbook (wedding editors only)
+// @Tags wishes
+// @Param id path string true "Wedding ID"
+// @Param wish_id path string true "Wish ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/weddings/{id}/wishes/{wish_id} [delete]
+func (h *WishHandler) DeleteWish(c *gin.Context) {
+	weddingID, wishID, userID, ok := h.parseWishRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.wishes.DeleteWish(c.Request.Context(), weddingID, wishID, userID); err != nil {
+		h.handleError(c, err, "Failed to delete wish")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseWishRequest reads the wedding, the wish and the authenticated user of a moderation request
+func (h *WishHandler) parseWishRequest(c *gin.Context) (weddingID, wishID, userID models.ID, ok bool) {
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wedding ID")
+		return
+	}
+
+	wishID, err = models.ParseID(c.Param("wish_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid wish ID")
+		return
+	}
+
+	userID, err = utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	return weddingID, wishID, userID, true
+}
+
+func (h *WishHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrWeddingNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wedding not found")
+	case errors.Is(err, services.ErrWishNotFound):
+		utils.ErrorResponse(c, http.StatusNotFound, "Wish not found")
+	case errors.Is(err, services.ErrUnauthorized):
+		utils.ErrorResponse(c, http.StatusForbidden, "Not authorized to moderate this wedding's wishes")
+	case errors.Is(err, services.ErrWishesDisabled):
+		utils.ErrorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrInvalidWish), errors.Is(err, services.ErrInvalidWishStatus):
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		utils.ErrorResponse(c, http.StatusInternalServerError, fallback)
+	}
+}
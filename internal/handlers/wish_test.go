@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// MockGuestbook is a mock implementation of Guestbook
+type MockGuestbook struct {
+	mock.Mock
+}
+
+func (m *MockGuestbook) CreateWish(ctx context.Context, slug string, req services.WishRequest) (*models.Wish, error) {
+	args := m.Called(ctx, slug, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Wish), args.Error(1)
+}
+
+func (m *MockGuestbook) ListPublicWishes(ctx context.Context, slug string, page, pageSize int) ([]*models.Wish, int64, error) {
+	args := m.Called(ctx, slug, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*models.Wish), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockGuestbook) ListWishes(ctx context.Context, weddingID, userID models.ID, status models.WishStatus, page, pageSize int) ([]*models.Wish, int64, error) {
+	args := m.Called(ctx, weddingID, userID, status, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*models.Wish), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockGuestbook) ModerateWish(ctx context.Context, weddingID, wishID, userID models.ID, approve bool) (*models.Wish, error) {
+	args := m.Called(ctx, weddingID, wishID, userID, approve)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Wish), args.Error(1)
+}
+
+func (m *MockGuestbook) DeleteWish(ctx context.Context, weddingID, wishID, userID models.ID) error {
+	args := m.Called(ctx, weddingID, wishID, userID)
+	return args.Error(0)
+}
+
+func setupWishTestRouter(handler *WishHandler, userID models.ID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	public := router.Group("/api/v1/public/weddings/slug/:slug/wishes")
+	public.GET("", handler.GetWishes)
+	public.POST("", handler.CreateWish)
+
+	protected := router.Group("/api/v1/weddings/:id/wishes")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_id", userID.String())
+		c.Next()
+	})
+	protected.GET("", handler.ListWishes)
+	protected.POST("/:wish_id/approve", handler.ApproveWish)
+	protected.POST("/:wish_id/hide", handler.HideWish)
+	protected.DELETE("/:wish_id", handler.DeleteWish)
+
+	return router
+}
+
+func TestWishHandler_CreateWish(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		expected int
+	}{
+		{"created", `{"guest_name":"Alice","message":"Congratulations!"}`, nil, http.StatusCreated},
+		{"wishes disabled", `{"guest_name":"Alice","message":"Congratulations!"}`, services.ErrWishesDisabled, http.StatusForbidden},
+		{"unknown wedding", `{"guest_name":"Alice","message":"Congratulations!"}`, services.ErrWeddingNotFound, http.StatusNotFound},
+		{"missing message", `{"guest_name":"Alice"}`, nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wishes := new(MockGuestbook)
+			withIP := mock.MatchedBy(func(req services.WishRequest) bool { return req.IP != "" })
+			if tt.err != nil {
+				wishes.On("CreateWish", mock.Anything, "jane-john", withIP).Return(nil, tt.err)
+			} else {
+				wishes.On("CreateWish", mock.Anything, "jane-john", withIP).Return(&models.Wish{GuestName: "Alice", GuestIP: "192.0.2.1"}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/public/weddings/slug/jane-john/wishes", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			setupWishTestRouter(NewWishHandler(wishes), models.NilID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			assert.NotContains(t, w.Body.String(), "192.0.2.1")
+		})
+	}
+}
+
+func TestWishHandler_ModerateWish(t *testing.T) {
+	userID := models.NewID()
+	weddingID := models.NewID()
+	wishID := models.NewID()
+	base := "/api/v1/weddings/" + weddingID.String() + "/wishes/" + wishID.String()
+
+	tests := []struct {
+		name     string
+		path     string
+		approve  bool
+		err      error
+		expected int
+	}{
+		{"approved", base + "/approve", true, nil, http.StatusOK},
+		{"hidden", base + "/hide", false, nil, http.StatusOK},
+		{"not an editor", base + "/hide", false, services.ErrUnauthorized, http.StatusForbidden},
+		{"unknown wish", base + "/approve", true, services.ErrWishNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wishes := new(MockGuestbook)
+			if tt.err != nil {
+				wishes.On("ModerateWish", mock.Anything, weddingID, wishID, userID, tt.approve).Return(nil, tt.err)
+			} else {
+				wishes.On("ModerateWish", mock.Anything, weddingID, wishID, userID, tt.approve).Return(&models.Wish{ID: wishID}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			w := httptest.NewRecorder()
+			setupWishTestRouter(NewWishHandler(wishes), userID).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			wishes.AssertExpectations(t)
+		})
+	}
+
+	t.Run("deleted", func(t *testing.T) {
+		wishes := new(MockGuestbook)
+		wishes.On("DeleteWish", mock.Anything, weddingID, wishID, userID).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, base, nil)
+		w := httptest.NewRecorder()
+		setupWishTestRouter(NewWishHandler(wishes), userID).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
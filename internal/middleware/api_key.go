@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// APIKeyHeader is the header third-party integrations send their API key in
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator resolves API keys and tracks the requests made with them
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, value string) (*models.APIKey, error)
+	RecordAPIKeyUsage(ctx context.Context, key *models.APIKey, status int, rateLimited bool)
+}
+
+// APIKeyAuth authenticates requests carrying an X-API-Key header as the key's
+// owner, and hands every other request to fallback, e.g. JWTAuth. A key may only
+// call routes under weddingPath, such as "/api/v1/weddings/:id", for the weddings
+// it is scoped to, and only read them unless its scope allows writes. Each key is
+// limited to its own requests per minute, counted by this API instance.
+func APIKeyAuth(keys APIKeyAuthenticator, fallback gin.HandlerFunc, weddingPath string) gin.HandlerFunc {
+	limiter := newAPIKeyLimiter()
+
+	return func(c *gin.Context) {
+		value := c.GetHeader(APIKeyHeader)
+		if value == "" {
+			fallback(c)
+			return
+		}
+
+		key, err := keys.AuthenticateAPIKey(c.Request.Context(), value)
+		if err != nil {
+			message := "Failed to authenticate API key"
+			status := http.StatusInternalServerError
+			if errors.Is(err, services.ErrInvalidAPIKey) {
+				message = "Invalid API key"
+				status = http.StatusUnauthorized
+			}
+			c.JSON(status, gin.H{"error": message})
+			c.Abort()
+			return
+		}
+
+		if !limiter.allow(key) {
+			keys.RecordAPIKeyUsage(c.Request.Context(), key, http.StatusTooManyRequests, true)
+			c.Header("Retry-After", strconv.Itoa(int(limiter.retryAfter(key).Seconds())+1))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "API key rate limit exceeded"})
+			c.Abort()
+			return
+		}
+
+		if !apiKeyMayAccess(c, key, weddingPath) {
+			keys.RecordAPIKeyUsage(c.Request.Context(), key, http.StatusForbidden, false)
+			c.JSON(http.StatusForbidden, gin.H{"error": "API key is not allowed to access this resource"})
+			c.Abort()
+			return
+		}
+
+		// API keys act with the permissions of a regular account, never an admin's
+		setAuthContext(c, key.UserID.String(), models.RoleUser, "", "", "")
+		c.Set("apiKeyID", key.ID.String())
+		c.Request = c.Request.WithContext(utils.WithActor(c.Request.Context(), utils.RequestActor{UserID: key.UserID.String(), APIKeyID: key.ID.String()}))
+
+		c.Next()
+
+		keys.RecordAPIKeyUsage(c.Request.Context(), key, c.Writer.Status(), false)
+	}
+}
+
+// apiKeyMayAccess checks that the route belongs to a wedding the key is scoped
+// to, with write access for anything but reads
+func apiKeyMayAccess(c *gin.Context, key *models.APIKey, weddingPath string) bool {
+	route := c.FullPath()
+	if route != weddingPath && !strings.HasPrefix(route, weddingPath+"/") {
+		return false
+	}
+
+	weddingID, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		return false
+	}
+
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return key.Allows(weddingID, false)
+	default:
+		return key.Allows(weddingID, true)
+	}
+}
+
+// apiKeyLimiter holds a token bucket per API key, refilled at the key's rate
+// limit per minute with a minute's worth of burst
+type apiKeyLimiter struct {
+	mu       sync.Mutex
+	limiters map[models.ID]*apiKeyBucket
+}
+
+type apiKeyBucket struct {
+	limit   int
+	limiter *rate.Limiter
+}
+
+func newAPIKeyLimiter() *apiKeyLimiter {
+	return &apiKeyLimiter{limiters: make(map[models.ID]*apiKeyBucket)}
+}
+
+func (l *apiKeyLimiter) bucket(key *models.APIKey) *rate.Limiter {
+	limit := key.RateLimit
+	if limit <= 0 {
+		limit = services.DefaultAPIKeyRateLimit
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// A changed rate limit takes effect with a fresh bucket
+	bucket, exists := l.limiters[key.ID]
+	if !exists || bucket.limit != limit {
+		bucket = &apiKeyBucket{
+			limit:   limit,
+			limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(limit)), limit),
+		}
+		l.limiters[key.ID] = bucket
+	}
+	return bucket.limiter
+}
+
+func (l *apiKeyLimiter) allow(key *models.APIKey) bool {
+	return l.bucket(key).Allow()
+}
+
+// retryAfter is how long until the key's next request is allowed
+func (l *apiKeyLimiter) retryAfter(key *models.APIKey) time.Duration {
+	reservation := l.bucket(key).Reserve()
+	defer reservation.Cancel()
+	return reservation.Delay()
+}
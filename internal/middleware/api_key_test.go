@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/services"
+)
+
+// fakeAPIKeys authenticates a fixed set of keys and records their usage
+type fakeAPIKeys struct {
+	mu    sync.Mutex
+	keys  map[string]*models.APIKey
+	usage []apiKeyUse
+}
+
+type apiKeyUse struct {
+	status      int
+	rateLimited bool
+}
+
+func (f *fakeAPIKeys) AuthenticateAPIKey(ctx context.Context, value string) (*models.APIKey, error) {
+	key, ok := f.keys[value]
+	if !ok {
+		return nil, services.ErrInvalidAPIKey
+	}
+	return key, nil
+}
+
+func (f *fakeAPIKeys) RecordAPIKeyUsage(ctx context.Context, key *models.APIKey, status int, rateLimited bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.usage = append(f.usage, apiKeyUse{status: status, rateLimited: rateLimited})
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := models.NewID()
+	readWedding := models.NewID()
+	writeWedding := models.NewID()
+	key := &models.APIKey{
+		ID:        models.NewID(),
+		UserID:    userID,
+		RateLimit: 100,
+		Scopes: []models.APIKeyScope{
+			{WeddingID: readWedding, Access: models.APIKeyAccessRead},
+			{WeddingID: writeWedding, Access: models.APIKeyAccessReadWrite},
+		},
+	}
+
+	newRouter := func(keys *fakeAPIKeys) *gin.Engine {
+		fallback := func(c *gin.Context) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "No token provided"})
+			c.Abort()
+		}
+		handler := func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"user_id": c.GetString("user_id"), "role": c.GetString("userRole")})
+		}
+
+		router := gin.New()
+		protected := router.Group("/api/v1", APIKeyAuth(keys, fallback, "/api/v1/weddings/:id"))
+		protected.GET("/weddings/:id", handler)
+		protected.PUT("/weddings/:id", handler)
+		protected.GET("/weddings/:id/guests", handler)
+		protected.GET("/users/profile", handler)
+		return router
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		apiKey   string
+		expected int
+	}{
+		{"read scoped wedding", http.MethodGet, "/api/v1/weddings/" + readWedding.String(), "wik_valid", http.StatusOK},
+		{"read nested route", http.MethodGet, "/api/v1/weddings/" + readWedding.String() + "/guests", "wik_valid", http.StatusOK},
+		{"write read-only wedding", http.MethodPut, "/api/v1/weddings/" + readWedding.String(), "wik_valid", http.StatusForbidden},
+		{"write read-write wedding", http.MethodPut, "/api/v1/weddings/" + writeWedding.String(), "wik_valid", http.StatusOK},
+		{"other wedding", http.MethodGet, "/api/v1/weddings/" + models.NewID().String(), "wik_valid", http.StatusForbidden},
+		{"route outside weddings", http.MethodGet, "/api/v1/users/profile", "wik_valid", http.StatusForbidden},
+		{"unknown key", http.MethodGet, "/api/v1/weddings/" + readWedding.String(), "wik_unknown", http.StatusUnauthorized},
+		{"no key falls back", http.MethodGet, "/api/v1/weddings/" + readWedding.String(), "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := &fakeAPIKeys{keys: map[string]*models.APIKey{"wik_valid": key}}
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+			newRouter(keys).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			if tt.expected == http.StatusOK {
+				assert.Contains(t, w.Body.String(), userID.String())
+				assert.Contains(t, w.Body.String(), `"role":"user"`)
+			}
+			if tt.apiKey == "wik_valid" {
+				// Every request made with a valid key is counted, refused or not
+				require.Len(t, keys.usage, 1)
+				assert.Equal(t, tt.expected, keys.usage[0].status)
+			} else {
+				assert.Empty(t, keys.usage)
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuth_RateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	weddingID := models.NewID()
+	key := &models.APIKey{
+		ID:        models.NewID(),
+		UserID:    models.NewID(),
+		RateLimit: 2,
+		Scopes:    []models.APIKeyScope{{WeddingID: weddingID, Access: models.APIKeyAccessRead}},
+	}
+	other := &models.APIKey{
+		ID:        models.NewID(),
+		UserID:    key.UserID,
+		RateLimit: 2,
+		Scopes:    key.Scopes,
+	}
+	keys := &fakeAPIKeys{keys: map[string]*models.APIKey{"wik_first": key, "wik_second": other}}
+
+	router := gin.New()
+	router.GET("/api/v1/weddings/:id", APIKeyAuth(keys, func(c *gin.Context) { c.AbortWithStatus(http.StatusUnauthorized) }, "/api/v1/weddings/:id"),
+		func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := func(apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/weddings/"+weddingID.String(), nil)
+		req.Header.Set(APIKeyHeader, apiKey)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, request("wik_first").Code)
+	assert.Equal(t, http.StatusOK, request("wik_first").Code)
+
+	limited := request("wik_first")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.NotEmpty(t, limited.Header().Get("Retry-After"))
+	assert.True(t, keys.usage[len(keys.usage)-1].rateLimited)
+
+	// Each key has its own limit
+	assert.Equal(t, http.StatusOK, request("wik_second").Code)
+
+	// A raised limit applies straight away
+	key.RateLimit = 5
+	assert.Equal(t, http.StatusOK, request("wik_first").Code)
+}
@@ -1,19 +1,29 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 
-	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
 )
 
-// AuthMiddleware creates a JWT authentication middleware
-func AuthMiddleware(tokenService *services.TokenService, blacklistChecker BlacklistChecker) gin.HandlerFunc {
+// SessionChecker reports whether a signed-in session is still active
+type SessionChecker interface {
+	IsActive(ctx context.Context, userID, id models.ID) (bool, error)
+}
+
+// JWTAuth authenticates requests with an access token issued by jwtManager, read from
+// the Authorization header or the access_token cookie. The token's permissions carry
+// the user's role. With sessions, tokens of sessions that ended are turned away
+// before they expire; sessions may be nil when they are not tracked.
+func JWTAuth(jwtManager *utils.JWTManager, sessions SessionChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get token from Authorization header or cookie
 		tokenString := extractToken(c)
 		if tokenString == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "No token provided"})
@@ -21,46 +31,84 @@ func AuthMiddleware(tokenService *services.TokenService, blacklistChecker Blackl
 			return
 		}
 
-		// Parse and validate token
-		token, err := tokenService.ParseToken(tokenString)
+		claims, err := jwtManager.ValidateToken(tokenString, utils.AccessToken)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
-			return
-		}
-
-		// Extract claims
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			message := "Invalid token"
+			if errors.Is(err, utils.ErrExpiredToken) {
+				message = "Token has expired"
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{"error": message})
 			c.Abort()
 			return
 		}
-
-		// Check if token is blacklisted
-		jti, exists := claims["jti"].(string)
-		if exists {
-			if isBlacklisted, err := blacklistChecker.IsBlacklisted(c, jti); err != nil || isBlacklisted {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+		if sessions != nil {
+			active, err := sessionActive(c.Request.Context(), sessions, claims)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check session"})
+				c.Abort()
+				return
+			}
+			if !active {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has ended"})
 				c.Abort()
 				return
 			}
 		}
 
-		// Set user information in context
-		userID, _ := claims["sub"].(string)
-		role, _ := claims["role"].(string)
-		deviceID, _ := claims["device_id"].(string)
-
-		c.Set("userID", userID)
-		c.Set("userRole", role)
-		c.Set("deviceID", deviceID)
-		c.Set("tokenJTI", jti)
+		// The device is the signed-in session the token was issued to
+		setAuthContext(c, claims.UserID, roleFromPermissions(claims.Permissions), claims.TenantID, claims.SessionID, claims.ID)
+		c.Set("userEmail", claims.Email)
+		// Members of an organization only see its users and weddings
+		if claims.TenantID != "" {
+			c.Request = c.Request.WithContext(repository.WithTenant(c.Request.Context(), claims.TenantID))
+		}
 
 		c.Next()
 	}
 }
 
+// sessionActive reports whether the session the token was issued to is still
+// active. Tokens issued to no session cannot be revoked and are not active.
+func sessionActive(ctx context.Context, sessions SessionChecker, claims *utils.Claims) (bool, error) {
+	userID, err := models.ParseID(claims.UserID)
+	if err != nil {
+		return false, nil
+	}
+	sessionID, err := models.ParseID(claims.SessionID)
+	if err != nil {
+		return false, nil
+	}
+	return sessions.IsActive(ctx, userID, sessionID)
+}
+
+// roleFromPermissions returns the account role carried by the token's permissions,
+// or "user" when none is known. Admin wins over any other role.
+func roleFromPermissions(permissions []string) string {
+	role := models.RoleUser
+	for _, permission := range permissions {
+		if permission == models.RoleAdmin {
+			return models.RoleAdmin
+		}
+		if role == models.RoleUser && models.IsValidRole(permission) {
+			role = permission
+		}
+	}
+	return role
+}
+
+// setAuthContext stores the authenticated identity. Handlers read the user ID as
+// "user_id" through utils.GetUserIDFromContext; the helpers below read "userID".
+// Only admins of no organization are platform admins ("is_admin").
+func setAuthContext(c *gin.Context, userID, role, tenantID, deviceID, jti string) {
+	c.Set("user_id", userID)
+	c.Set("userID", userID)
+	c.Set("userRole", role)
+	c.Set("deviceID", deviceID)
+	c.Set("tokenJTI", jti)
+	c.Set("is_admin", role == models.RoleAdmin && tenantID == "")
+	c.Request = c.Request.WithContext(utils.WithActor(c.Request.Context(), utils.RequestActor{UserID: userID}))
+}
+
 // RequireRole creates a middleware that requires a specific role
 func RequireRole(requiredRole string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -81,54 +129,78 @@ func RequireRole(requiredRole string) gin.HandlerFunc {
 	}
 }
 
-// RequireAdmin creates a middleware that requires admin role
-func RequireAdmin() gin.HandlerFunc {
-	return RequireRole("admin")
-}
-
-// OptionalAuth creates an optional authentication middleware
-func OptionalAuth(tokenService *services.TokenService, blacklistChecker BlacklistChecker) gin.HandlerFunc {
+// RequirePermission creates a middleware that requires the user's account role to
+// grant permission. Per-wedding permissions are checked by the services instead.
+func RequirePermission(permission models.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		tokenString := extractToken(c)
-		if tokenString == "" {
-			c.Next()
+		userRole, exists := c.Get("userRole")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User role not found"})
+			c.Abort()
+			return
+		}
+
+		role, _ := userRole.(string)
+		if !models.RoleHasPermission(role, permission) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			c.Abort()
 			return
 		}
 
-		token, err := tokenService.ParseToken(tokenString)
+		c.Next()
+	}
+}
+
+// UserLoader loads the authenticated user's account
+type UserLoader interface {
+	GetProfile(ctx context.Context, userID models.ID) (*models.User, error)
+}
+
+// RequireVerifiedEmail creates a middleware that only lets users who verified
+// their email address through, answering 403 to the others
+func RequireVerifiedEmail(users UserLoader) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := utils.GetUserIDFromContext(c)
 		if err != nil {
-			c.Next()
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			c.Abort()
 			return
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.Next()
+		user, err := users.GetProfile(c.Request.Context(), userID)
+		if err != nil || user == nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load account"})
+			c.Abort()
+			return
+		}
+		if !user.HasVerifiedEmail() {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Verify your email address first"})
+			c.Abort()
 			return
 		}
 
-		// Check if token is blacklisted
-		jti, exists := claims["jti"].(string)
-		if exists {
-			if isBlacklisted, err := blacklistChecker.IsBlacklisted(c, jti); err == nil && !isBlacklisted {
-				userID, _ := claims["sub"].(string)
-				role, _ := claims["role"].(string)
-				deviceID, _ := claims["device_id"].(string)
-
-				c.Set("userID", userID)
-				c.Set("userRole", role)
-				c.Set("deviceID", deviceID)
-				c.Set("tokenJTI", jti)
-			}
+		c.Next()
+	}
+}
+
+// RequirePlatformAdmin creates a middleware that only lets platform admins through.
+// Admins of an organization are kept out, as the routes it guards are not scoped
+// to a tenant.
+func RequirePlatformAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !IsPlatformAdmin(c) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Platform admin access required"})
+			c.Abort()
+			return
 		}
 
 		c.Next()
 	}
 }
 
-// BlacklistChecker defines the interface for checking token blacklist status
-type BlacklistChecker interface {
-	IsBlacklisted(c *gin.Context, jti string) (bool, error)
+// RequireAdmin creates a middleware that requires admin role
+func RequireAdmin() gin.HandlerFunc {
+	return RequireRole("admin")
 }
 
 // extractToken extracts JWT token from Authorization header or cookie
@@ -184,6 +256,11 @@ func IsAuthenticated(c *gin.Context) bool {
 	return exists
 }
 
+// IsPlatformAdmin checks if the user is an admin of no organization
+func IsPlatformAdmin(c *gin.Context) bool {
+	return c.GetBool("is_admin")
+}
+
 // IsAdmin checks if the user has admin role
 func IsAdmin(c *gin.Context) bool {
 	role, exists := GetUserRole(c)
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -8,220 +10,361 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
-	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
 )
 
-// MockBlacklistChecker is a mock implementation of BlacklistChecker
-type MockBlacklistChecker struct {
-	mock.Mock
-}
-
-func (m *MockBlacklistChecker) IsBlacklisted(c *gin.Context, jti string) (bool, error) {
-	args := m.Called(c, jti)
-	return args.Bool(0), args.Error(1)
-}
-
-func TestAuthMiddleware(t *testing.T) {
+func TestRequireRole(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	// Create token service
-	tokenService := createTestTokenService(t)
-
-	// Create mock blacklist checker
-	mockBlacklistChecker := new(MockBlacklistChecker)
-
-	// Create middleware
-	middleware := AuthMiddleware(tokenService, mockBlacklistChecker)
-
-	// Test case: valid token
-	t.Run("valid token", func(t *testing.T) {
-		// Generate token
-		tokenPair, err := tokenService.GenerateTokenPair("user-123", "device-123", "user")
-		require.NoError(t, err)
-
-		// Setup mock
-		mockBlacklistChecker.On("IsBlacklisted", mock.Anything, tokenPair.AccessJTI).Return(false, nil)
+	// Test case: correct role
+	t.Run("correct role", func(t *testing.T) {
+		middleware := RequireRole("user")
 
-		// Create request with Authorization header
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest("GET", "/test", nil)
-		c.Request.Header.Set("Authorization", "Bearer "+tokenPair.AccessToken)
+		c.Set("userRole", "user")
 
-		// Call middleware
 		middleware(c)
 
-		// Assertions
 		assert.Equal(t, http.StatusOK, w.Code)
-		userID, exists := c.Get("userID")
-		assert.True(t, exists)
-		assert.Equal(t, "user-123", userID)
-		mockBlacklistChecker.AssertExpectations(t)
 	})
 
-	// Test case: no token
-	t.Run("no token", func(t *testing.T) {
+	// Test case: wrong role
+	t.Run("wrong role", func(t *testing.T) {
+		middleware := RequireRole("admin")
+
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest("GET", "/test", nil)
+		c.Set("userRole", "user")
 
 		middleware(c)
 
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
 
-	// Test case: blacklisted token
-	t.Run("blacklisted token", func(t *testing.T) {
-		// Generate token
-		tokenPair, err := tokenService.GenerateTokenPair("user-123", "device-123", "user")
-		require.NoError(t, err)
-
-		// Setup mock
-		mockBlacklistChecker.On("IsBlacklisted", mock.Anything, tokenPair.AccessJTI).Return(true, nil)
+	// Test case: no role
+	t.Run("no role", func(t *testing.T) {
+		middleware := RequireRole("admin")
 
-		// Create request with Authorization header
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest("GET", "/test", nil)
-		c.Request.Header.Set("Authorization", "Bearer "+tokenPair.AccessToken)
 
-		// Call middleware
 		middleware(c)
 
-		// Assertions
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
-		mockBlacklistChecker.AssertExpectations(t)
 	})
 }
 
-func TestRequireRole(t *testing.T) {
+func TestRequirePermission(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	// Test case: correct role
-	t.Run("correct role", func(t *testing.T) {
-		middleware := RequireRole("user")
+	tests := []struct {
+		name       string
+		role       string
+		permission models.Permission
+		wantStatus int
+	}{
+		{"planner creates weddings", models.RolePlanner, models.PermissionCreateWedding, http.StatusOK},
+		{"collaborator cannot create weddings", models.RoleCollaborator, models.PermissionCreateWedding, http.StatusForbidden},
+		{"owner cannot manage users", models.RoleOwner, models.PermissionManageUsers, http.StatusForbidden},
+		{"admin manages users", models.RoleAdmin, models.PermissionManageUsers, http.StatusOK},
+		{"organization admin manages the organization", models.RoleOrgAdmin, models.PermissionManageOrganization, http.StatusOK},
+		{"organization admin cannot manage users", models.RoleOrgAdmin, models.PermissionManageUsers, http.StatusForbidden},
+		{"no role", "", models.PermissionCreateWedding, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/test", nil)
+			if tt.role != "" {
+				c.Set("userRole", tt.role)
+			}
+
+			RequirePermission(tt.permission)(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestRequirePlatformAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
 
+	tests := []struct {
+		name       string
+		isAdmin    interface{}
+		wantStatus int
+	}{
+		{"platform admin", true, http.StatusOK},
+		{"organization admin", false, http.StatusForbidden},
+		{"not authenticated", nil, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/test", nil)
+			if tt.isAdmin != nil {
+				c.Set("is_admin", tt.isAdmin)
+			}
+
+			RequirePlatformAdmin()(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+// fakeUserLoader returns the users it holds
+type fakeUserLoader map[models.ID]*models.User
+
+func (f fakeUserLoader) GetProfile(ctx context.Context, userID models.ID) (*models.User, error) {
+	user, ok := f[userID]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+func TestRequireVerifiedEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	verifiedAt := time.Now()
+	verified := &models.User{ID: models.NewID(), EmailVerified: true}
+	legacy := &models.User{ID: models.NewID(), EmailVerifiedAt: &verifiedAt}
+	unverified := &models.User{ID: models.NewID()}
+	users := fakeUserLoader{verified.ID: verified, legacy.ID: legacy, unverified.ID: unverified}
+
+	tests := []struct {
+		name       string
+		userID     string
+		wantStatus int
+	}{
+		{"verified", verified.ID.String(), http.StatusOK},
+		{"verified before email_verified was recorded", legacy.ID.String(), http.StatusOK},
+		{"unverified", unverified.ID.String(), http.StatusForbidden},
+		{"unknown user", models.NewID().String(), http.StatusInternalServerError},
+		{"not authenticated", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/weddings", nil)
+			if tt.userID != "" {
+				c.Set("user_id", tt.userID)
+			}
+
+			RequireVerifiedEmail(users)(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantStatus != http.StatusOK, c.IsAborted())
+		})
+	}
+}
+
+func TestJWTAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	jwtManager := utils.NewJWTManager("test-secret", "test-refresh-secret", 15*time.Minute, time.Hour, "test-issuer")
+	userID := models.NewID()
+
+	serve := func(t *testing.T, setup func(r *http.Request)) (*httptest.ResponseRecorder, *gin.Context) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest("GET", "/test", nil)
-		c.Set("userRole", "user")
+		setup(c.Request)
+		JWTAuth(jwtManager, nil)(c)
+		return w, c
+	}
 
-		middleware(c)
+	t.Run("bearer token", func(t *testing.T) {
+		tokens, err := jwtManager.GenerateTokenPair(userID, "jane@example.com", []string{"user"})
+		require.NoError(t, err)
+
+		w, c := serve(t, func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+tokens.AccessToken) })
 
 		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, c.IsAborted())
+		assert.Equal(t, userID.String(), c.GetString("user_id"))
+		assert.Equal(t, userID.String(), c.GetString("userID"))
+		assert.Equal(t, "user", c.GetString("userRole"))
+		assert.Equal(t, "jane@example.com", c.GetString("userEmail"))
+		assert.NotEmpty(t, c.GetString("tokenJTI"))
+		_, scoped := repository.TenantFromContext(c.Request.Context())
+		assert.False(t, scoped)
 	})
 
-	// Test case: wrong role
-	t.Run("wrong role", func(t *testing.T) {
-		middleware := RequireRole("admin")
+	t.Run("organization member", func(t *testing.T) {
+		tokens, err := jwtManager.GenerateSessionTokenPair(userID, "jane@example.com", []string{"user"}, "acme", "")
+		require.NoError(t, err)
 
-		w := httptest.NewRecorder()
-		c, _ := gin.CreateTestContext(w)
-		c.Request = httptest.NewRequest("GET", "/test", nil)
-		c.Set("userRole", "user")
+		_, c := serve(t, func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+tokens.AccessToken) })
 
-		middleware(c)
+		tenant, ok := repository.TenantFromContext(c.Request.Context())
+		assert.True(t, ok)
+		assert.Equal(t, "acme", tenant)
+	})
 
-		assert.Equal(t, http.StatusForbidden, w.Code)
+	t.Run("cookie token with admin role", func(t *testing.T) {
+		tokens, err := jwtManager.GenerateTokenPair(userID, "admin@example.com", []string{"admin"})
+		require.NoError(t, err)
+
+		w, c := serve(t, func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "access_token", Value: tokens.AccessToken}) })
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "admin", c.GetString("userRole"))
+		assert.True(t, c.GetBool("is_admin"))
 	})
 
-	// Test case: no role
-	t.Run("no role", func(t *testing.T) {
-		middleware := RequireRole("admin")
+	t.Run("admin role in an organization", func(t *testing.T) {
+		tokens, err := jwtManager.GenerateSessionTokenPair(userID, "admin@example.com", []string{"admin"}, "acme", "")
+		require.NoError(t, err)
 
-		w := httptest.NewRecorder()
-		c, _ := gin.CreateTestContext(w)
-		c.Request = httptest.NewRequest("GET", "/test", nil)
+		w, c := serve(t, func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+tokens.AccessToken) })
 
-		middleware(c)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, c.GetBool("is_admin"), "only admins of no organization are platform admins")
+	})
+
+	t.Run("planner role", func(t *testing.T) {
+		tokens, err := jwtManager.GenerateTokenPair(userID, "planner@example.com", []string{"planner"})
+		require.NoError(t, err)
+
+		w, c := serve(t, func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+tokens.AccessToken) })
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "planner", c.GetString("userRole"))
+		assert.False(t, c.GetBool("is_admin"))
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		w, c := serve(t, func(r *http.Request) {})
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.True(t, c.IsAborted())
 	})
-}
 
-func TestRequireAdmin(t *testing.T) {
-	gin.SetMode(gin.TestMode)
+	t.Run("refresh token is rejected", func(t *testing.T) {
+		tokens, err := jwtManager.GenerateTokenPair(userID, "jane@example.com", []string{"user"})
+		require.NoError(t, err)
 
-	middleware := RequireAdmin()
+		w, _ := serve(t, func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+tokens.RefreshToken) })
 
-	// Test case: admin role
-	t.Run("admin role", func(t *testing.T) {
-		w := httptest.NewRecorder()
-		c, _ := gin.CreateTestContext(w)
-		c.Request = httptest.NewRequest("GET", "/test", nil)
-		c.Set("userRole", "admin")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
 
-		middleware(c)
+	t.Run("expired token", func(t *testing.T) {
+		expired := utils.NewJWTManager("test-secret", "test-refresh-secret", -time.Minute, time.Hour, "test-issuer")
+		tokens, err := expired.GenerateTokenPair(userID, "jane@example.com", []string{"user"})
+		require.NoError(t, err)
 
-		assert.Equal(t, http.StatusOK, w.Code)
+		w, _ := serve(t, func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+tokens.AccessToken) })
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "expired")
 	})
 
-	// Test case: user role
-	t.Run("user role", func(t *testing.T) {
-		w := httptest.NewRecorder()
-		c, _ := gin.CreateTestContext(w)
-		c.Request = httptest.NewRequest("GET", "/test", nil)
-		c.Set("userRole", "user")
+	t.Run("token signed with another secret", func(t *testing.T) {
+		other := utils.NewJWTManager("other-secret", "other-refresh-secret", 15*time.Minute, time.Hour, "test-issuer")
+		tokens, err := other.GenerateTokenPair(userID, "jane@example.com", []string{"user"})
+		require.NoError(t, err)
 
-		middleware(c)
+		w, _ := serve(t, func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+tokens.AccessToken) })
 
-		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
 
-func TestOptionalAuth(t *testing.T) {
-	gin.SetMode(gin.TestMode)
+// fakeSessionChecker holds the active sessions by ID, failing when err is set
+type fakeSessionChecker struct {
+	active map[models.ID]models.ID
+	err    error
+}
 
-	// Create token service
-	tokenService := createTestTokenService(t)
+func (f fakeSessionChecker) IsActive(ctx context.Context, userID, id models.ID) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	owner, ok := f.active[id]
+	return ok && owner == userID, nil
+}
 
-	// Create mock blacklist checker
-	mockBlacklistChecker := new(MockBlacklistChecker)
+func TestJWTAuth_Sessions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
 
-	// Create middleware
-	middleware := OptionalAuth(tokenService, mockBlacklistChecker)
+	jwtManager := utils.NewJWTManager("test-secret", "test-refresh-secret", 15*time.Minute, time.Hour, "test-issuer")
+	userID := models.NewID()
+	active, ended := models.NewID(), models.NewID()
+	sessions := fakeSessionChecker{active: map[models.ID]models.ID{active: userID}}
 
-	// Test case: valid token
-	t.Run("valid token", func(t *testing.T) {
-		// Generate token
-		tokenPair, err := tokenService.GenerateTokenPair("user-123", "device-123", "user")
+	serve := func(t *testing.T, checker SessionChecker, sessionID string) *httptest.ResponseRecorder {
+		tokens, err := jwtManager.GenerateSessionTokenPair(userID, "jane@example.com", []string{"user"}, "", sessionID)
 		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/test", nil)
+		c.Request.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+		JWTAuth(jwtManager, checker)(c)
+		return w
+	}
+
+	tests := []struct {
+		name       string
+		checker    SessionChecker
+		sessionID  string
+		wantStatus int
+	}{
+		{"active session", sessions, active.String(), http.StatusOK},
+		{"session ended", sessions, ended.String(), http.StatusUnauthorized},
+		{"token bound to no session", sessions, "", http.StatusUnauthorized},
+		{"session check fails", fakeSessionChecker{err: errors.New("database down")}, active.String(), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(t, tt.checker, tt.sessionID)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
 
-		// Setup mock
-		mockBlacklistChecker.On("IsBlacklisted", mock.Anything, tokenPair.AccessJTI).Return(false, nil)
+func TestRequireAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
 
-		// Create request with Authorization header
+	middleware := RequireAdmin()
+
+	// Test case: admin role
+	t.Run("admin role", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest("GET", "/test", nil)
-		c.Request.Header.Set("Authorization", "Bearer "+tokenPair.AccessToken)
+		c.Set("userRole", "admin")
 
-		// Call middleware
 		middleware(c)
 
-		// Assertions
 		assert.Equal(t, http.StatusOK, w.Code)
-		userID, exists := c.Get("userID")
-		assert.True(t, exists)
-		assert.Equal(t, "user-123", userID)
-		mockBlacklistChecker.AssertExpectations(t)
 	})
 
-	// Test case: no token
-	t.Run("no token", func(t *testing.T) {
+	// Test case: user role
+	t.Run("user role", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest("GET", "/test", nil)
+		c.Set("userRole", "user")
 
 		middleware(c)
 
-		assert.Equal(t, http.StatusOK, w.Code)
-		userID, exists := c.Get("userID")
-		assert.False(t, exists)
-		assert.Empty(t, userID)
+		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
 }
 
@@ -344,22 +487,3 @@ func TestIsAdmin(t *testing.T) {
 		assert.False(t, IsAdmin(c))
 	})
 }
-
-// Helper function to create a test token service
-func createTestTokenService(t *testing.T) *services.TokenService {
-	privateKeyPEM, publicKeyPEM, err := services.GenerateRSAKeyPair(2048)
-	require.NoError(t, err)
-
-	config := services.TokenConfig{
-		PrivateKeyPEM: privateKeyPEM,
-		PublicKeyPEM:  publicKeyPEM,
-		Issuer:        "test-issuer",
-		Audience:      "test-audience",
-		AccessExpiry:  15 * time.Minute,
-		RefreshExpiry: 7 * 24 * time.Hour,
-	}
-
-	tokenService, err := services.NewTokenService(config)
-	require.NoError(t, err)
-	return tokenService
-}
@@ -1,12 +1,16 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"runtime/debug"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/utils"
 )
 
 // ErrorConfig holds configuration for error handling
@@ -119,6 +123,12 @@ func (eh *ErrorHandler) handleRequestErrors(c *gin.Context) {
 		)
 	}
 
+	// A handler that already answered, e.g. a streamed export that failed
+	// midway, recorded the error for the log only
+	if c.Writer.Written() {
+		return
+	}
+
 	// Check for custom error handlers
 	if handler, exists := eh.config.CustomHandlers[string(rune(err.Type))]; exists {
 		handler(c, err.Err)
@@ -129,70 +139,60 @@ func (eh *ErrorHandler) handleRequestErrors(c *gin.Context) {
 	eh.handleCommonError(c, err.Err)
 }
 
-// handleCommonError handles common error types with appropriate HTTP status codes
+// handleCommonError answers domain errors with the status of their kind and
+// their message; any other error is an internal error whose message is not shown
 func (eh *ErrorHandler) handleCommonError(c *gin.Context, err error) {
-	errorMessage := err.Error()
-
-	// Determine HTTP status code and error code based on error message
-	statusCode, errorCode, message := eh.categorizeError(errorMessage)
-
-	c.JSON(statusCode, gin.H{
-		"success": false,
-		"error": gin.H{
-			"code":    errorCode,
-			"message": message,
-		},
-	})
-}
-
-// categorizeError categorizes errors and returns appropriate status codes
-func (eh *ErrorHandler) categorizeError(errorMessage string) (int, string, string) {
-	errorMessage = strings.ToLower(errorMessage)
+	statusCode, errorCode := categorizeError(err)
 
-	// Validation errors
-	if strings.Contains(errorMessage, "validation") ||
-		strings.Contains(errorMessage, "invalid") ||
-		strings.Contains(errorMessage, "required") ||
-		strings.Contains(errorMessage, "format") {
-		return http.StatusBadRequest, "VALIDATION_ERROR", "Invalid input data"
+	response := utils.APIErrorResponse{
+		Success: false,
+		Error:   "An unexpected error occurred",
+		Code:    errorCode,
 	}
-
-	// Authentication errors
-	if strings.Contains(errorMessage, "unauthorized") ||
-		strings.Contains(errorMessage, "authentication") ||
-		strings.Contains(errorMessage, "token") ||
-		strings.Contains(errorMessage, "login") {
-		return http.StatusUnauthorized, "AUTHENTICATION_ERROR", "Authentication required"
+	if statusCode != http.StatusInternalServerError {
+		response.Error = domainMessage(err)
 	}
-
-	// Authorization errors
-	if strings.Contains(errorMessage, "forbidden") ||
-		strings.Contains(errorMessage, "permission") ||
-		strings.Contains(errorMessage, "access") {
-		return http.StatusForbidden, "AUTHORIZATION_ERROR", "Insufficient permissions"
+	if fields := errs.Fields(err); len(fields) > 0 {
+		response.Details = make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			response.Details[field.Field] = field.Message
+		}
 	}
 
-	// Not found errors
-	if strings.Contains(errorMessage, "not found") ||
-		strings.Contains(errorMessage, "exists") == false && strings.Contains(errorMessage, "exist") {
-		return http.StatusNotFound, "NOT_FOUND", "Resource not found"
-	}
+	c.JSON(statusCode, response)
+}
 
-	// Conflict errors
-	if strings.Contains(errorMessage, "conflict") ||
-		strings.Contains(errorMessage, "duplicate") ||
-		strings.Contains(errorMessage, "already") {
-		return http.StatusConflict, "CONFLICT", "Resource conflict"
+// categorizeError returns the HTTP status and error code of the kind of a domain error
+func categorizeError(err error) (int, string) {
+	switch {
+	case errors.Is(err, errs.ErrNotFound):
+		return http.StatusNotFound, "not_found"
+	case errors.Is(err, errs.ErrForbidden):
+		return http.StatusForbidden, "forbidden"
+	case errors.Is(err, errs.ErrValidation):
+		return http.StatusBadRequest, "validation_error"
+	case errors.Is(err, errs.ErrConflict):
+		return http.StatusConflict, "conflict"
+	default:
+		return http.StatusInternalServerError, "internal_error"
 	}
+}
 
-	// Rate limiting errors
-	if strings.Contains(errorMessage, "rate limit") ||
-		strings.Contains(errorMessage, "too many") {
-		return http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED", "Too many requests"
+// domainMessage returns the message of the domain error in err's chain, without
+// the context it was wrapped in, capitalized like the rest of the API's messages
+func domainMessage(err error) string {
+	message := err.Error()
+	var domainErr *errs.Error
+	var validationErr *errs.ValidationError
+	if errors.As(err, &domainErr) {
+		message = domainErr.Message
+	} else if errors.As(err, &validationErr) {
+		message = validationErr.Message
 	}
-
-	// Default internal server error
-	return http.StatusInternalServerError, "INTERNAL_ERROR", "An unexpected error occurred"
+	if message == "" {
+		return message
+	}
+	return strings.ToUpper(message[:1]) + message[1:]
 }
 
 // RegisterCustomHandler registers a custom error handler for a specific error type
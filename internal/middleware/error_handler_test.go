@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/utils"
+)
+
+func TestErrorHandler_DomainErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	errWeddingNotFound := errs.NotFound("wedding not found")
+
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    string
+		message string
+		details map[string]interface{}
+	}{
+		{"not found", fmt.Errorf("failed to publish wedding: %w", errWeddingNotFound), http.StatusNotFound, "not_found", "Wedding not found", nil},
+		{"forbidden", errs.Forbidden("access denied"), http.StatusForbidden, "forbidden", "Access denied", nil},
+		{"conflict", errs.Conflict("slug already exists"), http.StatusConflict, "conflict", "Slug already exists", nil},
+		{"validation", errs.InvalidField("role", "invalid user role"), http.StatusBadRequest, "validation_error", "Invalid user role",
+			map[string]interface{}{"role": "invalid user role"}},
+		{"internal", errors.New("connection refused"), http.StatusInternalServerError, "internal_error", "An unexpected error occurred", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(NewErrorHandler(zap.NewNop(), DefaultErrorConfig()).Middleware())
+			router.GET("/", func(c *gin.Context) {
+				_ = c.Error(tt.err)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.status, w.Code)
+			var response utils.APIErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.False(t, response.Success)
+			assert.Equal(t, tt.code, response.Code)
+			assert.Equal(t, tt.message, response.Error)
+			assert.Equal(t, tt.details, response.Details)
+		})
+	}
+}
+
+func TestErrorHandler_AnsweredRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(NewErrorHandler(zap.NewNop(), DefaultErrorConfig()).Middleware())
+	router.GET("/export", func(c *gin.Context) {
+		c.String(http.StatusOK, "partial")
+		_ = c.Error(errors.New("stream broke"))
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/export", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "partial", w.Body.String())
+}
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client's key for a request it may retry
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks a response replayed for a retried request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// maxIdempotencyKeyLength bounds keys, which are usually UUIDs
+	maxIdempotencyKeyLength = 255
+	// idempotencyLease is how long a key stays reserved for a request that
+	// neither completes nor fails, e.g. because the instance died
+	idempotencyLease = time.Minute
+)
+
+// idempotencyRecorder copies the response body as the handler writes it
+type idempotencyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency lets clients retry a request safely by sending an Idempotency-Key
+// header: the response of the first request with a key is recorded for ttl and
+// replayed for retries, marked with Idempotent-Replayed. Keys are scoped to the
+// path and the authenticated user. Reusing a key for a different request gets
+// 422, and retrying while the first request is still processed gets 409.
+// Server errors and throttled requests are not recorded, so they can be retried. Requests
+// without the header, and all requests when the store fails, are processed as
+// usual.
+func Idempotency(store services.IdempotencyStore, ttl time.Duration, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Failed to read request body")
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := c.Request.Method + " " + c.Request.URL.Path
+		if userID, err := utils.GetUserIDFromContext(c); err == nil {
+			scope += " " + userID.String()
+		}
+		key = hashIdempotency([]byte(scope), []byte(key))
+		requestHash := hashIdempotency(body)
+
+		ctx := c.Request.Context()
+		recorded, err := store.Reserve(ctx, key, requestHash, idempotencyLease)
+		if err != nil {
+			logger.Warn("Idempotency store unavailable, processing request", zap.Error(err))
+			c.Next()
+			return
+		}
+		if recorded != nil {
+			switch {
+			case recorded.RequestHash != requestHash:
+				utils.ErrorResponse(c, http.StatusUnprocessableEntity, "Idempotency-Key was used for a different request")
+			case !recorded.Completed():
+				utils.ErrorResponse(c, http.StatusConflict, "A request with this Idempotency-Key is still being processed")
+			default:
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(recorded.Status, recorded.ContentType, recorded.Body)
+			}
+			c.Abort()
+			return
+		}
+
+		recorder := &idempotencyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+		c.Writer = recorder.ResponseWriter
+
+		// Record the response even if the client went away meanwhile
+		ctx = context.WithoutCancel(ctx)
+		if status := recorder.Status(); status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+			if err := store.Release(ctx, key); err != nil {
+				logger.Warn("Failed to release idempotency key", zap.Error(err))
+			}
+			return
+		}
+		response := &services.IdempotentResponse{
+			RequestHash: requestHash,
+			Status:      recorder.Status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}
+		if err := store.Save(ctx, key, response, ttl); err != nil {
+			logger.Warn("Failed to save idempotent response", zap.Error(err))
+		}
+	}
+}
+
+// hashIdempotency fingerprints the parts of an idempotent request
+func hashIdempotency(parts ...[]byte) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/services"
+)
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(status *int) (*gin.Engine, *int) {
+		calls := 0
+		router := gin.New()
+		router.POST("/rsvp", Idempotency(services.NewMemoryIdempotencyStore(), time.Hour, zap.NewNop()), func(c *gin.Context) {
+			calls++
+			c.JSON(*status, gin.H{"call": calls})
+		})
+		return router, &calls
+	}
+	submit := func(router *gin.Engine, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/rsvp", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("replays the response of a retried request", func(t *testing.T) {
+		status := http.StatusCreated
+		router, calls := newRouter(&status)
+
+		first := submit(router, "key-1", `{"name":"Ann"}`)
+		assert.Equal(t, http.StatusCreated, first.Code)
+
+		retry := submit(router, "key-1", `{"name":"Ann"}`)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, "application/json; charset=utf-8", retry.Header().Get("Content-Type"))
+		assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, 1, *calls)
+
+		assert.Equal(t, http.StatusCreated, submit(router, "key-2", `{"name":"Ann"}`).Code)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("rejects a key reused for a different request", func(t *testing.T) {
+		status := http.StatusCreated
+		router, calls := newRouter(&status)
+
+		submit(router, "key-1", `{"name":"Ann"}`)
+		w := submit(router, "key-1", `{"name":"Bob"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("lets server errors be retried", func(t *testing.T) {
+		status := http.StatusInternalServerError
+		router, calls := newRouter(&status)
+
+		assert.Equal(t, http.StatusInternalServerError, submit(router, "key-1", `{}`).Code)
+		status = http.StatusCreated
+		w := submit(router, "key-1", `{}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("rejects retries while the first request is processed", func(t *testing.T) {
+		store := services.NewMemoryIdempotencyStore()
+		started, release := make(chan struct{}), make(chan struct{})
+		router := gin.New()
+		router.POST("/rsvp", Idempotency(store, time.Hour, zap.NewNop()), func(c *gin.Context) {
+			close(started)
+			<-release
+			c.Status(http.StatusCreated)
+		})
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			submit(router, "key-1", `{}`)
+		}()
+		<-started
+		assert.Equal(t, http.StatusConflict, submit(router, "key-1", `{}`).Code)
+		close(release)
+		<-done
+	})
+
+	t.Run("processes requests without a key as usual", func(t *testing.T) {
+		status := http.StatusCreated
+		router, calls := newRouter(&status)
+
+		submit(router, "", `{}`)
+		submit(router, "", `{}`)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("rejects overlong keys", func(t *testing.T) {
+		status := http.StatusCreated
+		router, _ := newRouter(&status)
+		assert.Equal(t, http.StatusBadRequest, submit(router, strings.Repeat("k", 256), `{}`).Code)
+	})
+}
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/services"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// RateLimitKeyFunc identifies the client a request is counted for
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// RateLimitByIP counts requests per client IP
+func RateLimitByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// RateLimitByUser counts requests per authenticated user, and per client IP
+// for anonymous requests
+func RateLimitByUser(c *gin.Context) string {
+	if userID, err := utils.GetUserIDFromContext(c); err == nil {
+		return "user:" + userID.String()
+	}
+	return RateLimitByIP(c)
+}
+
+// throttledRequests counts the requests rejected by a rate limit policy. It is
+// exported by the globally installed OpenTelemetry meter provider.
+var throttledRequests, _ = otel.Meter("wedding-invitation-backend").Int64Counter(
+	"http.server.rate_limited_requests",
+	metric.WithDescription("Requests rejected by a rate limit policy"),
+	metric.WithUnit("{request}"),
+)
+
+// RateLimit limits the requests of each client, as identified by key, to the
+// policy. Allowed requests carry X-RateLimit-Limit and X-RateLimit-Remaining
+// headers; throttled requests get 429 with Retry-After. When the limiter fails,
+// e.g. because Redis is down, requests are let through.
+func RateLimit(limiter services.RateLimiter, policy services.RateLimitPolicy, key RateLimitKeyFunc, logger *zap.Logger) gin.HandlerFunc {
+	if !policy.Enabled() {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		limitRequest(c, limiter, policy, key, logger)
+	}
+}
+
+// RateLimitPolicies holds rate limit policies by name that can be replaced at
+// runtime, e.g. when the configuration is reloaded
+type RateLimitPolicies struct {
+	mu       sync.RWMutex
+	policies map[string]services.RateLimitPolicy
+}
+
+// NewRateLimitPolicies returns a set holding the given policies
+func NewRateLimitPolicies(policies []services.RateLimitPolicy) *RateLimitPolicies {
+	set := &RateLimitPolicies{}
+	set.Replace(policies)
+	return set
+}
+
+// Replace swaps all policies for the given ones
+func (p *RateLimitPolicies) Replace(policies []services.RateLimitPolicy) {
+	byName := make(map[string]services.RateLimitPolicy, len(policies))
+	for _, policy := range policies {
+		byName[policy.Name] = policy
+	}
+	p.mu.Lock()
+	p.policies = byName
+	p.mu.Unlock()
+}
+
+// Get returns the policy of the given name, which is disabled when unknown
+func (p *RateLimitPolicies) Get(name string) services.RateLimitPolicy {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if policy, ok := p.policies[name]; ok {
+		return policy
+	}
+	return services.RateLimitPolicy{Name: name}
+}
+
+// ReloadableRateLimit works like RateLimit, but looks the policy up on every
+// request so that replacing it takes effect immediately
+func ReloadableRateLimit(limiter services.RateLimiter, policies *RateLimitPolicies, name string, key RateLimitKeyFunc, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy := policies.Get(name)
+		if !policy.Enabled() {
+			c.Next()
+			return
+		}
+		limitRequest(c, limiter, policy, key, logger)
+	}
+}
+
+// limitRequest counts the request against the policy and rejects it once the
+// client is over the limit
+func limitRequest(c *gin.Context, limiter services.RateLimiter, policy services.RateLimitPolicy, key RateLimitKeyFunc, logger *zap.Logger) {
+	result, err := limiter.Allow(c.Request.Context(), policy, key(c))
+	if err != nil {
+		logger.Warn("Rate limiter unavailable, allowing request",
+			zap.String("policy", policy.Name),
+			zap.Error(err),
+		)
+		c.Next()
+		return
+	}
+
+	c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	if result.Allowed {
+		c.Next()
+		return
+	}
+
+	throttledRequests.Add(c.Request.Context(), 1, metric.WithAttributes(attribute.String("policy", policy.Name)))
+	logger.Info("Request rate limited",
+		zap.String("policy", policy.Name),
+		zap.String("path", c.FullPath()),
+		zap.String("client_ip", c.ClientIP()),
+	)
+	c.Header("Retry-After", strconv.Itoa(int((result.RetryAfter+time.Second-1)/time.Second)))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"success": false,
+		"error": gin.H{
+			"code":    "RATE_LIMIT_EXCEEDED",
+			"message": "Too many requests. Please try again later.",
+		},
+	})
+	c.Abort()
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/services"
+)
+
+// failingRateLimiter is a rate limiter whose store is unreachable
+type failingRateLimiter struct{}
+
+func (failingRateLimiter) Allow(ctx context.Context, policy services.RateLimitPolicy, key string) (services.RateLimitResult, error) {
+	return services.RateLimitResult{}, errors.New("connection refused")
+}
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	policy := services.RateLimitPolicy{Name: services.RateLimitLogin, Limit: 2, Window: time.Minute}
+
+	newRouter := func(limiter services.RateLimiter) *gin.Engine {
+		router := gin.New()
+		router.POST("/login", RateLimit(limiter, policy, RateLimitByIP, zap.NewNop()), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		return router
+	}
+	login := func(router *gin.Engine, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = ip + ":1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("throttles clients over the limit", func(t *testing.T) {
+		router := newRouter(services.NewMemoryRateLimiter())
+
+		w := login(router, "203.0.113.1")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "1", w.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal(t, http.StatusOK, login(router, "203.0.113.1").Code)
+
+		w = login(router, "203.0.113.1")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "60", w.Header().Get("Retry-After"))
+		assert.Contains(t, w.Body.String(), "RATE_LIMIT_EXCEEDED")
+
+		assert.Equal(t, http.StatusOK, login(router, "203.0.113.2").Code)
+	})
+
+	t.Run("lets requests through when the limiter fails", func(t *testing.T) {
+		router := newRouter(failingRateLimiter{})
+		for range 3 {
+			assert.Equal(t, http.StatusOK, login(router, "203.0.113.1").Code)
+		}
+	})
+}
+
+func TestRateLimitByUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/upload", nil)
+	c.Request.RemoteAddr = "203.0.113.1:1234"
+	assert.Equal(t, "ip:203.0.113.1", RateLimitByUser(c))
+
+	c.Set("user_id", "6650f1c2a4b5c6d7e8f90123")
+	assert.Equal(t, "user:6650f1c2a4b5c6d7e8f90123", RateLimitByUser(c))
+}
+
+func TestReloadableRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	policies := NewRateLimitPolicies([]services.RateLimitPolicy{{Name: services.RateLimitLogin, Limit: 1, Window: time.Minute}})
+	router := gin.New()
+	router.POST("/login", ReloadableRateLimit(services.NewMemoryRateLimiter(), policies, services.RateLimitLogin, RateLimitByIP, zap.NewNop()), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	login := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = "203.0.113.1:1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, login())
+	assert.Equal(t, http.StatusTooManyRequests, login())
+
+	policies.Replace([]services.RateLimitPolicy{{Name: services.RateLimitLogin, Limit: 0, Window: time.Minute}})
+	assert.Equal(t, http.StatusOK, login())
+
+	policies.Replace(nil)
+	assert.Equal(t, http.StatusOK, login())
+}
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/tracing"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// validRequestID limits client-supplied request IDs to safe, bounded values
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9\-_.]{1,128}$`)
+
+// RequestID assigns every request an ID, reusing a well-formed X-Request-ID header when present.
+// The ID is stored in the gin context, the request context, and echoed in the response.
+// The request context also carries the client's IP and user agent for audit logs.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(utils.RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = utils.NewRequestID()
+		}
+
+		c.Set(utils.RequestIDKey, requestID)
+		ctx := utils.WithRequestID(c.Request.Context(), requestID)
+		ctx = utils.WithClient(ctx, utils.RequestClient{IP: c.ClientIP(), UserAgent: c.Request.UserAgent()})
+		c.Request = c.Request.WithContext(ctx)
+		c.Header(utils.RequestIDHeader, requestID)
+
+		c.Next()
+	}
+}
+
+// RequestLogBuffer keeps the most recent request logs in memory for debugging lookups
+type RequestLogBuffer struct {
+	mu       sync.RWMutex
+	entries  []models.RequestLogEntry
+	next     int
+	full     bool
+	capacity int
+}
+
+// NewRequestLogBuffer creates a ring buffer holding up to capacity entries
+func NewRequestLogBuffer(capacity int) *RequestLogBuffer {
+	if capacity <= 0 {
+		capacity = 10000
+	}
+	return &RequestLogBuffer{
+		entries:  make([]models.RequestLogEntry, capacity),
+		capacity: capacity,
+	}
+}
+
+// Add records an entry, evicting the oldest one when full
+func (b *RequestLogBuffer) Add(entry models.RequestLogEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.entries[b.next] = entry
+	b.next = (b.next + 1) % b.capacity
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// FindByRequestID returns buffered entries for a request ID, oldest first
+func (b *RequestLogBuffer) FindByRequestID(requestID string) []models.RequestLogEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	start, count := 0, b.next
+	if b.full {
+		start, count = b.next, b.capacity
+	}
+
+	var matches []models.RequestLogEntry
+	for i := 0; i < count; i++ {
+		entry := b.entries[(start+i)%b.capacity]
+		if entry.RequestID == requestID {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
+
+// RequestLogger logs every request with its request ID and, when buffer is non-nil,
+// keeps the entry for lookup. It must run after RequestID.
+func RequestLogger(logger *zap.Logger, buffer *RequestLogBuffer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+
+		c.Next()
+
+		entry := models.RequestLogEntry{
+			RequestID: c.GetString(utils.RequestIDKey),
+			Method:    c.Request.Method,
+			Path:      path,
+			Status:    c.Writer.Status(),
+			Latency:   time.Since(start),
+			ClientIP:  c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			UserID:    c.GetString("user_id"),
+			Timestamp: start,
+		}
+		for _, err := range c.Errors {
+			entry.Errors = append(entry.Errors, err.Error())
+		}
+
+		fields := []zap.Field{
+			zap.String("request_id", entry.RequestID),
+			zap.String("method", entry.Method),
+			zap.String("path", entry.Path),
+			zap.Int("status", entry.Status),
+			zap.Duration("latency", entry.Latency),
+			zap.String("client_ip", entry.ClientIP),
+		}
+		if entry.UserID != "" {
+			fields = append(fields, zap.String("user_id", entry.UserID))
+		}
+		if traceID := tracing.TraceID(c.Request.Context()); traceID != "" {
+			fields = append(fields, zap.String("trace_id", traceID))
+		}
+
+		switch {
+		case entry.Status >= 500:
+			logger.Error("Request completed", append(fields, zap.Strings("errors", entry.Errors))...)
+		case entry.Status >= 400:
+			logger.Warn("Request completed", fields...)
+		default:
+			logger.Info("Request completed", fields...)
+		}
+
+		if buffer != nil {
+			buffer.Add(entry)
+		}
+	}
+}
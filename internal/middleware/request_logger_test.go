@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/utils"
+)
+
+func TestRequestIDAndLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	buffer := NewRequestLogBuffer(10)
+	router := gin.New()
+	router.Use(RequestID(), RequestLogger(zaptest.NewLogger(t), buffer))
+
+	var fromContext string
+	var client utils.RequestClient
+	router.GET("/test", func(c *gin.Context) {
+		fromContext = utils.RequestIDFromContext(c.Request.Context())
+		client = utils.ClientFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	t.Run("Reuses client request ID", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.Header.Set(utils.RequestIDHeader, "client-req-123")
+		req.Header.Set("User-Agent", "test-agent")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "client-req-123", w.Header().Get(utils.RequestIDHeader))
+		assert.Equal(t, "client-req-123", fromContext)
+		assert.Equal(t, utils.RequestClient{IP: "192.0.2.1", UserAgent: "test-agent"}, client)
+
+		entries := buffer.FindByRequestID("client-req-123")
+		require.Len(t, entries, 1)
+		assert.Equal(t, "/test", entries[0].Path)
+		assert.Equal(t, http.StatusOK, entries[0].Status)
+	})
+
+	t.Run("Generates ID for missing or malformed header", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.Header.Set(utils.RequestIDHeader, "bad id with spaces")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		requestID := w.Header().Get(utils.RequestIDHeader)
+		assert.NotEmpty(t, requestID)
+		assert.NotEqual(t, "bad id with spaces", requestID)
+		assert.Equal(t, requestID, fromContext)
+	})
+}
+
+func TestRequestLogBuffer_Evicts(t *testing.T) {
+	buffer := NewRequestLogBuffer(2)
+	buffer.Add(models.RequestLogEntry{RequestID: "a"})
+	buffer.Add(models.RequestLogEntry{RequestID: "b"})
+	buffer.Add(models.RequestLogEntry{RequestID: "c"})
+
+	assert.Empty(t, buffer.FindByRequestID("a"))
+	assert.Len(t, buffer.FindByRequestID("b"), 1)
+	assert.Len(t, buffer.FindByRequestID("c"), 1)
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"strings"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
 )
@@ -158,6 +159,43 @@ type CORSSecurityConfig struct {
 	MaxAge            int
 	// Strict origin checking
 	StrictOriginChecking bool
+	// Origins take precedence over AllowedOrigins unless empty, and can be
+	// changed while the server runs
+	Origins *OriginSet
+}
+
+// allowedOrigins returns the origins currently allowed
+func (config CORSSecurityConfig) allowedOrigins() []string {
+	if config.Origins != nil {
+		if origins := config.Origins.Load(); len(origins) > 0 {
+			return origins
+		}
+	}
+	return config.AllowedOrigins
+}
+
+// OriginSet holds allowed CORS origins that can be replaced at runtime, e.g.
+// when the configuration is reloaded
+type OriginSet struct {
+	origins atomic.Pointer[[]string]
+}
+
+// NewOriginSet returns a set allowing the given origins
+func NewOriginSet(origins []string) *OriginSet {
+	set := &OriginSet{}
+	set.Store(origins)
+	return set
+}
+
+// Store replaces the allowed origins
+func (s *OriginSet) Store(origins []string) {
+	origins = append([]string(nil), origins...)
+	s.origins.Store(&origins)
+}
+
+// Load returns the allowed origins
+func (s *OriginSet) Load() []string {
+	return *s.origins.Load()
 }
 
 // DefaultCORSSecurityConfig returns default CORS configuration
@@ -194,7 +232,7 @@ func CORSSecurityMiddleware(config CORSSecurityConfig) gin.HandlerFunc {
 		// Handle preflight requests
 		if c.Request.Method == "OPTIONS" {
 			// Check if origin is allowed
-			if !isOriginAllowed(origin, config.AllowedOrigins, config.StrictOriginChecking) {
+			if !isOriginAllowed(origin, config.allowedOrigins(), config.StrictOriginChecking) {
 				c.AbortWithStatus(http.StatusForbidden)
 				return
 			}
@@ -213,7 +251,7 @@ func CORSSecurityMiddleware(config CORSSecurityConfig) gin.HandlerFunc {
 		}
 		
 		// Handle actual requests
-		if isOriginAllowed(origin, config.AllowedOrigins, config.StrictOriginChecking) {
+		if isOriginAllowed(origin, config.allowedOrigins(), config.StrictOriginChecking) {
 			c.Header("Access-Control-Allow-Origin", origin)
 			
 			if len(config.ExposedHeaders) > 0 {
@@ -230,20 +230,21 @@ func (smb *SecurityMiddlewareBuilder) Build() *SecurityMiddleware {
 	return NewSecurityMiddleware(smb.logger, smb.config)
 }
 
-// ApplySecurityDefaults applies sensible security defaults to a router
-func ApplySecurityDefaults(router *gin.Engine, logger *zap.Logger, environment string, allowedOrigins []string) {
+// ApplySecurityDefaults applies sensible security defaults to a router. CORS
+// follows changes to the origins; in development the default local origins are
+// kept while none are configured.
+func ApplySecurityDefaults(router *gin.Engine, logger *zap.Logger, environment string, origins *OriginSet) {
 	var config SecurityConfig
 	
 	if environment == "production" {
-		config = ProductionSecurityConfig(allowedOrigins)
+		config = ProductionSecurityConfig(nil)
+		config.CORS.Origins = origins
 	} else {
 		config = DefaultSecurityConfig()
 		config.Environment = environment
 		config.SecurityHeaders = DevelopmentSecurityHeadersConfig()
 		config.ErrorHandling = DevelopmentErrorConfig()
-		if len(allowedOrigins) > 0 {
-			config.CORS.AllowedOrigins = allowedOrigins
-		}
+		config.CORS.Origins = origins
 	}
 	
 	securityMiddleware := NewSecurityMiddleware(logger, config)
@@ -252,6 +253,6 @@ func ApplySecurityDefaults(router *gin.Engine, logger *zap.Logger, environment s
 	logger.Info("Security middleware applied",
 		zap.String("environment", environment),
 		zap.Bool("rate_limiting_enabled", config.RateLimiting.Enabled),
-		zap.Strings("cors_origins", config.CORS.AllowedOrigins),
+		zap.Strings("cors_origins", config.CORS.allowedOrigins()),
 	)
 }
\ No newline at end of file
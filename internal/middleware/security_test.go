@@ -246,3 +246,31 @@ func TestSecurityMiddlewareBuilder(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestCORSSecurityReloadsOrigins(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	origins := NewOriginSet([]string{"https://old.example.com"})
+	config := ProductionCORSSecurityConfig(nil)
+	config.Origins = origins
+	router := gin.New()
+	router.Use(CORSSecurityMiddleware(config))
+	router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	allowed := func(origin string) string {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.Header.Set("Origin", origin)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	assert.Equal(t, "https://old.example.com", allowed("https://old.example.com"))
+	assert.Empty(t, allowed("https://new.example.com"))
+
+	origins.Store([]string{"https://new.example.com"})
+	assert.Empty(t, allowed("https://old.example.com"))
+	assert.Equal(t, "https://new.example.com", allowed("https://new.example.com"))
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+
+	"wedding-invitation-backend/internal/domain/models"
 )
 
 // ValidationMiddleware provides comprehensive input validation
@@ -157,17 +159,10 @@ func (vm *ValidationMiddleware) validateSlug(fl validator.FieldLevel) bool {
 	return true
 }
 
+// validateObjectID checks that the field holds a well-formed record ID
 func (vm *ValidationMiddleware) validateObjectID(fl validator.FieldLevel) bool {
-	id := fl.Field().String()
-
-	// MongoDB ObjectID is 24 hex characters
-	if len(id) != 24 {
-		return false
-	}
-
-	// Check if all characters are valid hex
-	matched, _ := regexp.MatchString("^[a-fA-F0-9]{24}$", id)
-	return matched
+	_, err := models.ParseID(fl.Field().String())
+	return err == nil
 }
 
 func (vm *ValidationMiddleware) validatePhone(fl validator.FieldLevel) bool {
@@ -0,0 +1,111 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure abuseReportRepository implements the domain repository interface
+var _ repository.AbuseReportRepository = (*abuseReportRepository)(nil)
+
+type abuseReportRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAbuseReportRepository creates a new MongoDB abuse report repository
+func NewAbuseReportRepository(db *mongo.Database) repository.AbuseReportRepository {
+	return &abuseReportRepository{
+		collection: db.Collection("abuse_reports"),
+	}
+}
+
+// Create stores a new abuse report
+func (r *abuseReportRepository) Create(ctx context.Context, report *models.AbuseReport) error {
+	if report.ID.IsZero() {
+		report.ID = models.NewID()
+	}
+	if report.CreatedAt.IsZero() {
+		report.CreatedAt = time.Now()
+	}
+
+	if _, err := r.collection.InsertOne(ctx, report); err != nil {
+		return fmt.Errorf("failed to create abuse report: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an abuse report by ID
+func (r *abuseReportRepository) GetByID(ctx context.Context, id models.ID) (*models.AbuseReport, error) {
+	var report models.AbuseReport
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&report); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get abuse report: %w", err)
+	}
+	return &report, nil
+}
+
+// List lists the abuse reports matching the filters, newest first
+func (r *abuseReportRepository) List(ctx context.Context, filters repository.AbuseReportFilters, page, pageSize int) ([]*models.AbuseReport, int64, error) {
+	filter := bson.M{}
+	if filters.Status != "" {
+		filter["status"] = filters.Status
+	}
+	if filters.WeddingID != nil {
+		filter["wedding_id"] = *filters.WeddingID
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count abuse reports: %w", err)
+	}
+
+	skip := (page - 1) * pageSize
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list abuse reports: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	reports := []*models.AbuseReport{}
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode abuse reports: %w", err)
+	}
+	return reports, total, nil
+}
+
+// Update saves the review of an abuse report
+func (r *abuseReportRepository) Update(ctx context.Context, report *models.AbuseReport) error {
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": report.ID}, report)
+	if err != nil {
+		return fmt.Errorf("failed to update abuse report: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// CountOpenByWedding counts the wedding's abuse reports awaiting review
+func (r *abuseReportRepository) CountOpenByWedding(ctx context.Context, weddingID models.ID) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"wedding_id": weddingID, "status": models.AbuseReportOpen})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count abuse reports: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,369 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure accountDeletionRepository implements the domain repository interface
+var _ repository.AccountDeletionRepository = (*accountDeletionRepository)(nil)
+
+// anonymizedName replaces the first name of anonymized guests and RSVPs
+const anonymizedName = "Anonymous"
+
+// weddingDataCollections hold records that belong to a single wedding through wedding_id
+var weddingDataCollections = []string{
+	"guests",
+	"guest_groups",
+	"preview_links",
+	"wedding_revisions",
+	"slug_redirects",
+	"rsvps",
+	"rsvp_submissions",
+	"guest_photos",
+	"wishes",
+	"song_requests",
+	"registry_items",
+	"gift_pledges",
+	"budget_categories",
+	"budget_payments",
+	"vendors",
+	"reminder_campaigns",
+	"reminder_deliveries",
+	"page_views",
+	"rsvp_analytics",
+	"conversion_events",
+	"analytics_daily",
+	"analytics_report_settings",
+	"analytics_anomalies",
+	"abuse_reports",
+}
+
+type accountDeletionRepository struct {
+	db    *mongo.Database
+	users *mongo.Collection
+}
+
+// NewAccountDeletionRepository creates a new MongoDB account deletion repository
+func NewAccountDeletionRepository(db *mongo.Database) repository.AccountDeletionRepository {
+	return &accountDeletionRepository{
+		db:    db,
+		users: db.Collection("users"),
+	}
+}
+
+// SetDeletion stores the user's deletion request, replacing an earlier one
+func (r *accountDeletionRepository) SetDeletion(ctx context.Context, userID models.ID, deletion *models.AccountDeletion) error {
+	result, err := r.users.UpdateOne(ctx,
+		bson.M{"_id": userID},
+		bson.M{"$set": bson.M{"deletion": deletion, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store account deletion: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// ClearDeletion withdraws the user's deletion request
+func (r *accountDeletionRepository) ClearDeletion(ctx context.Context, userID models.ID) error {
+	result, err := r.users.UpdateOne(ctx,
+		bson.M{"_id": userID, "deletion": bson.M{"$exists": true}},
+		bson.M{
+			"$unset": bson.M{"deletion": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to cancel account deletion: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// GetByDeletionToken retrieves the user whose deletion request has the token hash
+func (r *accountDeletionRepository) GetByDeletionToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	var user models.User
+	err := r.users.FindOne(ctx, bson.M{"deletion.token_hash": tokenHash}).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to find account deletion: %w", err)
+	}
+	return &user, nil
+}
+
+// ListDue lists up to limit users whose confirmed deletion was scheduled before the time
+func (r *accountDeletionRepository) ListDue(ctx context.Context, before time.Time, limit int) ([]*models.User, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "deletion.scheduled_for", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.users.Find(ctx, bson.M{"deletion.scheduled_for": bson.M{"$lte": before}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due account deletions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var users []*models.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, fmt.Errorf("failed to decode due account deletions: %w", err)
+	}
+	return users, nil
+}
+
+// OwnedWeddingIDs lists the weddings the user owns
+func (r *accountDeletionRepository) OwnedWeddingIDs(ctx context.Context, userID models.ID) ([]models.ID, error) {
+	ids, err := r.distinctIDs(ctx, "weddings", bson.M{"user_id": userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owned weddings: %w", err)
+	}
+	return ids, nil
+}
+
+// DeleteWeddingData deletes a wedding and everything stored for it
+func (r *accountDeletionRepository) DeleteWeddingData(ctx context.Context, weddingID models.ID) ([]string, error) {
+	byWedding := bson.M{"wedding_id": weddingID}
+
+	// Export files are returned before their jobs are deleted
+	keys, err := r.exportFileKeys(ctx, byWedding)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.db.Collection("export_jobs").DeleteMany(ctx, byWedding); err != nil {
+		return nil, fmt.Errorf("failed to delete export jobs: %w", err)
+	}
+
+	webhookIDs, err := r.distinctIDs(ctx, "wedding_webhooks", byWedding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wedding webhooks: %w", err)
+	}
+	if len(webhookIDs) > 0 {
+		if _, err := r.db.Collection("wedding_webhook_deliveries").DeleteMany(ctx, bson.M{"webhook_id": bson.M{"$in": webhookIDs}}); err != nil {
+			return nil, fmt.Errorf("failed to delete wedding webhook deliveries: %w", err)
+		}
+	}
+	if _, err := r.db.Collection("wedding_webhooks").DeleteMany(ctx, byWedding); err != nil {
+		return nil, fmt.Errorf("failed to delete wedding webhooks: %w", err)
+	}
+
+	for _, name := range weddingDataCollections {
+		if _, err := r.db.Collection(name).DeleteMany(ctx, byWedding); err != nil {
+			return nil, fmt.Errorf("failed to delete %s: %w", name, err)
+		}
+	}
+	if _, err := r.db.Collection("wedding_analytics").DeleteOne(ctx, bson.M{"_id": weddingID}); err != nil {
+		return nil, fmt.Errorf("failed to delete wedding analytics: %w", err)
+	}
+
+	// The wedding goes last so that an interrupted erasure is found again
+	if _, err := r.db.Collection("weddings").DeleteOne(ctx, bson.M{"_id": weddingID}); err != nil {
+		return nil, fmt.Errorf("failed to delete wedding: %w", err)
+	}
+	return keys, nil
+}
+
+// AnonymizeContact removes the contact details stored under the email address
+func (r *accountDeletionRepository) AnonymizeContact(ctx context.Context, email string) (int64, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return 0, nil
+	}
+	matchEmail := func(field string) bson.M {
+		return bson.M{field: bson.M{"$regex": "^" + regexp.QuoteMeta(email) + "$", "$options": "i"}}
+	}
+
+	// The sessions of the RSVPs tie page views and conversions to the person
+	rsvpIDs, err := r.distinctIDs(ctx, "rsvps", matchEmail("email"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to find rsvps: %w", err)
+	}
+	if err := r.AnonymizeRSVPSessions(ctx, rsvpIDs); err != nil {
+		return 0, err
+	}
+
+	var anonymized int64
+	result, err := r.db.Collection("rsvps").UpdateMany(ctx, matchEmail("email"), bson.M{
+		"$set":   bson.M{"first_name": anonymizedName, "last_name": ""},
+		"$unset": bson.M{"email": "", "phone": "", "ip_address": "", "user_agent": ""},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymize rsvps: %w", err)
+	}
+	anonymized += result.ModifiedCount
+
+	result, err = r.db.Collection("guests").UpdateMany(ctx, matchEmail("email"), bson.M{
+		"$set":   bson.M{"first_name": anonymizedName, "last_name": ""},
+		"$unset": bson.M{"email": "", "phone": ""},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymize guests: %w", err)
+	}
+	anonymized += result.ModifiedCount
+
+	result, err = r.db.Collection("gift_pledges").UpdateMany(ctx, matchEmail("guest_email"), bson.M{
+		"$set":   bson.M{"guest_name": anonymizedName},
+		"$unset": bson.M{"guest_email": "", "pledger_ip": ""},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymize gift pledges: %w", err)
+	}
+	anonymized += result.ModifiedCount
+
+	if _, err := r.db.Collection("reminder_deliveries").UpdateMany(ctx, matchEmail("email"),
+		bson.M{"$unset": bson.M{"email": "", "phone": ""}}); err != nil {
+		return 0, fmt.Errorf("failed to anonymize reminder deliveries: %w", err)
+	}
+
+	return anonymized, nil
+}
+
+// AnonymizeRSVPSessions removes the identifiers of the visits, conversions and RSVP
+// analytics of the sessions in which the RSVPs were submitted
+func (r *accountDeletionRepository) AnonymizeRSVPSessions(ctx context.Context, rsvpIDs []models.ID) error {
+	if len(rsvpIDs) == 0 {
+		return nil
+	}
+	sessions, err := r.db.Collection("rsvp_analytics").Distinct(ctx, "session_id", bson.M{"rsvp_id": bson.M{"$in": rsvpIDs}})
+	if err != nil {
+		return fmt.Errorf("failed to find rsvp sessions: %w", err)
+	}
+	sessions = nonEmpty(sessions)
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	bySession := bson.M{"session_id": bson.M{"$in": sessions}}
+	if _, err := r.db.Collection("page_views").UpdateMany(ctx, bySession,
+		bson.M{"$set": bson.M{"session_id": "", "ip_address": "", "user_agent": ""}}); err != nil {
+		return fmt.Errorf("failed to anonymize page views: %w", err)
+	}
+	for _, name := range []string{"conversion_events", "rsvp_analytics"} {
+		if _, err := r.db.Collection(name).UpdateMany(ctx, bySession,
+			bson.M{"$set": bson.M{"session_id": ""}}); err != nil {
+			return fmt.Errorf("failed to anonymize %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// RemoveCollaborator removes the user from the collaborators of every wedding
+func (r *accountDeletionRepository) RemoveCollaborator(ctx context.Context, userID models.ID) error {
+	_, err := r.db.Collection("weddings").UpdateMany(ctx,
+		bson.M{"collaborators.user_id": userID},
+		bson.M{
+			"$pull": bson.M{"collaborators": bson.M{"user_id": userID}},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove collaborator: %w", err)
+	}
+	return nil
+}
+
+// DeleteAccount deletes the user with their sessions, API keys, metrics webhooks, upload
+// sessions, push subscriptions and exports, returning the storage keys of their export files
+func (r *accountDeletionRepository) DeleteAccount(ctx context.Context, userID models.ID) ([]string, error) {
+	byUser := bson.M{"user_id": userID}
+
+	keys, err := r.exportFileKeys(ctx, byUser)
+	if err != nil {
+		return nil, err
+	}
+
+	keyIDs, err := r.distinctIDs(ctx, "api_keys", byUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	if len(keyIDs) > 0 {
+		if _, err := r.db.Collection("api_key_usage").DeleteMany(ctx, bson.M{"key_id": bson.M{"$in": keyIDs}}); err != nil {
+			return nil, fmt.Errorf("failed to delete api key usage: %w", err)
+		}
+	}
+
+	webhookIDs, err := r.distinctIDs(ctx, "metrics_webhooks", byUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list metrics webhooks: %w", err)
+	}
+	if len(webhookIDs) > 0 {
+		if _, err := r.db.Collection("metrics_webhook_deliveries").DeleteMany(ctx, bson.M{"webhook_id": bson.M{"$in": webhookIDs}}); err != nil {
+			return nil, fmt.Errorf("failed to delete metrics webhook deliveries: %w", err)
+		}
+	}
+
+	for _, name := range []string{"sessions", "api_keys", "metrics_webhooks", "upload_sessions", "push_subscriptions", "analytics_report_settings", "export_jobs"} {
+		if _, err := r.db.Collection(name).DeleteMany(ctx, byUser); err != nil {
+			return nil, fmt.Errorf("failed to delete %s: %w", name, err)
+		}
+	}
+
+	if _, err := r.users.DeleteOne(ctx, bson.M{"_id": userID}); err != nil {
+		return nil, fmt.Errorf("failed to delete user: %w", err)
+	}
+	return keys, nil
+}
+
+// exportFileKeys returns the storage keys of the export files of the jobs matching the filter
+func (r *accountDeletionRepository) exportFileKeys(ctx context.Context, filter bson.M) ([]string, error) {
+	query := bson.M{"file_key": bson.M{"$nin": bson.A{nil, ""}}}
+	for field, value := range filter {
+		query[field] = value
+	}
+	cursor, err := r.db.Collection("export_jobs").Find(ctx, query, options.Find().SetProjection(bson.M{"file_key": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find export files: %w", err)
+	}
+	var exports []models.ExportJob
+	if err := cursor.All(ctx, &exports); err != nil {
+		return nil, fmt.Errorf("failed to decode export files: %w", err)
+	}
+	keys := make([]string, 0, len(exports))
+	for _, export := range exports {
+		keys = append(keys, export.FileKey)
+	}
+	return keys, nil
+}
+
+// distinctIDs returns the IDs of the documents in the collection matching the filter
+func (r *accountDeletionRepository) distinctIDs(ctx context.Context, collection string, filter bson.M) ([]models.ID, error) {
+	values, err := r.db.Collection(collection).Distinct(ctx, "_id", filter)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]models.ID, 0, len(values))
+	for _, value := range values {
+		if oid, ok := value.(primitive.ObjectID); ok {
+			ids = append(ids, models.ID(oid.Hex()))
+		}
+	}
+	return ids, nil
+}
+
+// nonEmpty drops the empty strings left by anonymized or anonymous records
+func nonEmpty(values []interface{}) []interface{} {
+	kept := values[:0]
+	for _, value := range values {
+		if s, ok := value.(string); ok && s != "" {
+			kept = append(kept, value)
+		}
+	}
+	return kept
+}
@@ -3,10 +3,10 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -23,6 +23,7 @@ type analyticsRepository struct {
 	rsvpEvents       *mongo.Collection
 	conversions      *mongo.Collection
 	weddingAnalytics *mongo.Collection
+	dailyAnalytics   *mongo.Collection
 	systemAnalytics  *mongo.Collection
 }
 
@@ -34,35 +35,135 @@ func NewAnalyticsRepository(db *mongo.Database) repository.AnalyticsRepository {
 		rsvpEvents:       db.Collection("rsvp_analytics"),
 		conversions:      db.Collection("conversion_events"),
 		weddingAnalytics: db.Collection("wedding_analytics"),
+		dailyAnalytics:   db.Collection("analytics_daily"),
 		systemAnalytics:  db.Collection("system_analytics"),
 	}
 }
 
-// TrackPageView records a page view event
+// TrackPageView records a page view event and increments the wedding's counters
 func (r *analyticsRepository) TrackPageView(ctx context.Context, pageView *models.PageView) error {
 	if pageView.ID.IsZero() {
-		pageView.ID = primitive.NewObjectID()
+		pageView.ID = models.NewID()
 	}
 	if pageView.Timestamp.IsZero() {
 		pageView.Timestamp = time.Now()
 	}
 
-	_, err := r.pageViews.InsertOne(ctx, pageView)
+	history, err := r.sessionHistory(ctx, pageView)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pageViews.InsertOne(ctx, pageView)
 	if err != nil {
 		return fmt.Errorf("failed to track page view: %w", err)
 	}
 
-	// Update wedding analytics asynchronously
-	go func() {
-		r.UpdateWeddingAnalytics(context.Background(), pageView.WeddingID)
-	}()
+	return r.countPageView(ctx, pageView, history)
+}
+
+// TrackPageViews records page views in one write and increments the counters
+// of their weddings once per wedding and day. Views of a session are counted
+// in the order of their timestamps.
+func (r *analyticsRepository) TrackPageViews(ctx context.Context, pageViews []*models.PageView) error {
+	if len(pageViews) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for _, pageView := range pageViews {
+		if pageView.ID.IsZero() {
+			pageView.ID = models.NewID()
+		}
+		if pageView.Timestamp.IsZero() {
+			pageView.Timestamp = now
+		}
+	}
+	ordered := make([]*models.PageView, len(pageViews))
+	copy(ordered, pageViews)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Timestamp.Before(ordered[j].Timestamp) })
+
+	// A session's history is what was stored before the batch, followed by its
+	// earlier views in the batch
+	type sessionKey struct {
+		weddingID models.ID
+		sessionID string
+	}
+	histories := make(map[sessionKey][]time.Time)
+	buckets := make(map[models.ID]map[string]bson.M)
+	totals := make(map[models.ID]bson.M)
+	for _, pageView := range ordered {
+		key := sessionKey{pageView.WeddingID, pageView.SessionID}
+		history, seen := histories[key]
+		if !seen {
+			var err error
+			if history, err = r.sessionHistory(ctx, pageView); err != nil {
+				return err
+			}
+		}
+		if pageView.SessionID != "" && !pageView.IsBot {
+			histories[key] = append([]time.Time{pageView.Timestamp}, history...)
+		}
+		if len(history) > 2 {
+			history = history[:2]
+		}
+
+		viewBuckets, viewTotals := pageViewCounters(pageView, history)
+		if buckets[pageView.WeddingID] == nil {
+			buckets[pageView.WeddingID] = make(map[string]bson.M)
+			totals[pageView.WeddingID] = bson.M{}
+		}
+		for date, inc := range viewBuckets {
+			if buckets[pageView.WeddingID][date] == nil {
+				buckets[pageView.WeddingID][date] = bson.M{}
+			}
+			addCounters(buckets[pageView.WeddingID][date], inc)
+		}
+		addCounters(totals[pageView.WeddingID], viewTotals)
+	}
+
+	documents := make([]interface{}, len(ordered))
+	for i, pageView := range ordered {
+		documents[i] = pageView
+	}
+	if _, err := r.pageViews.InsertMany(ctx, documents); err != nil {
+		return fmt.Errorf("failed to track page views: %w", err)
+	}
 
+	for weddingID, weddingBuckets := range buckets {
+		if err := r.incrementCounters(ctx, weddingID, weddingBuckets, totals[weddingID]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// TrackPageDuration records how long the session's latest view of a page has
+// lasted. Durations only grow, so repeated heartbeats are safe.
+func (r *analyticsRepository) TrackPageDuration(ctx context.Context, weddingID models.ID, sessionID, page string, duration int64) error {
+	var previous models.PageView
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
+		SetReturnDocument(options.Before)
+	err := r.pageViews.FindOneAndUpdate(ctx,
+		bson.M{"wedding_id": weddingID, "session_id": sessionID, "page": page},
+		bson.M{"$max": bson.M{"duration": duration}},
+		opts).Decode(&previous)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return repository.ErrNotFound
+		}
+		return fmt.Errorf("failed to track page duration: %w", err)
+	}
+
+	pageView := previous
+	pageView.Duration = duration
+	return r.countPageDuration(ctx, &pageView, previous.Duration)
+}
+
 // GetPageViews retrieves page views with filtering
-func (r *analyticsRepository) GetPageViews(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error) {
-	query := bson.M{"wedding_id": weddingID}
+func (r *analyticsRepository) GetPageViews(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error) {
+	query := humanPageViews(bson.M{"wedding_id": weddingID})
 
 	// Apply filters
 	if filter != nil {
@@ -115,10 +216,10 @@ func (r *analyticsRepository) GetPageViews(ctx context.Context, weddingID primit
 	return pageViews, total, nil
 }
 
-// TrackRSVPEvent records an RSVP analytics event
+// TrackRSVPEvent records an RSVP analytics event and increments the wedding's counters
 func (r *analyticsRepository) TrackRSVPEvent(ctx context.Context, event *models.RSVPAnalytics) error {
 	if event.ID.IsZero() {
-		event.ID = primitive.NewObjectID()
+		event.ID = models.NewID()
 	}
 	if event.Timestamp.IsZero() {
 		event.Timestamp = time.Now()
@@ -129,16 +230,11 @@ func (r *analyticsRepository) TrackRSVPEvent(ctx context.Context, event *models.
 		return fmt.Errorf("failed to track RSVP event: %w", err)
 	}
 
-	// Update wedding analytics asynchronously
-	go func() {
-		r.UpdateWeddingAnalytics(context.Background(), event.WeddingID)
-	}()
-
-	return nil
+	return r.countRSVPEvent(ctx, event)
 }
 
 // GetRSVPAnalytics retrieves RSVP analytics with filtering
-func (r *analyticsRepository) GetRSVPAnalytics(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error) {
+func (r *analyticsRepository) GetRSVPAnalytics(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error) {
 	query := bson.M{"wedding_id": weddingID}
 
 	// Apply filters
@@ -195,7 +291,7 @@ func (r *analyticsRepository) GetRSVPAnalytics(ctx context.Context, weddingID pr
 // TrackConversion records a conversion event
 func (r *analyticsRepository) TrackConversion(ctx context.Context, event *models.ConversionEvent) error {
 	if event.ID.IsZero() {
-		event.ID = primitive.NewObjectID()
+		event.ID = models.NewID()
 	}
 	if event.Timestamp.IsZero() {
 		event.Timestamp = time.Now()
@@ -206,16 +302,67 @@ func (r *analyticsRepository) TrackConversion(ctx context.Context, event *models
 		return fmt.Errorf("failed to track conversion: %w", err)
 	}
 
-	// Update wedding analytics asynchronously
-	go func() {
-		r.UpdateWeddingAnalytics(context.Background(), event.WeddingID)
-	}()
+	return nil
+}
 
+// TrackConversions records conversion events in one write
+func (r *analyticsRepository) TrackConversions(ctx context.Context, events []*models.ConversionEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	documents := make([]interface{}, len(events))
+	for i, event := range events {
+		if event.ID.IsZero() {
+			event.ID = models.NewID()
+		}
+		if event.Timestamp.IsZero() {
+			event.Timestamp = now
+		}
+		documents[i] = event
+	}
+
+	if _, err := r.conversions.InsertMany(ctx, documents); err != nil {
+		return fmt.Errorf("failed to track conversions: %w", err)
+	}
 	return nil
 }
 
+// GetPageViewsByRequestID retrieves page views ingested by the given API request
+func (r *analyticsRepository) GetPageViewsByRequestID(ctx context.Context, requestID string) ([]*models.PageView, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}}).SetLimit(100)
+	cursor, err := r.pageViews.Find(ctx, bson.M{"metadata.request_id": requestID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find page views by request ID: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var pageViews []*models.PageView
+	if err := cursor.All(ctx, &pageViews); err != nil {
+		return nil, fmt.Errorf("failed to decode page views: %w", err)
+	}
+	return pageViews, nil
+}
+
+// GetConversionsByRequestID retrieves conversion events ingested by the given API request
+func (r *analyticsRepository) GetConversionsByRequestID(ctx context.Context, requestID string) ([]*models.ConversionEvent, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}}).SetLimit(100)
+	cursor, err := r.conversions.Find(ctx, bson.M{"properties.request_id": requestID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find conversions by request ID: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var conversions []*models.ConversionEvent
+	if err := cursor.All(ctx, &conversions); err != nil {
+		return nil, fmt.Errorf("failed to decode conversions: %w", err)
+	}
+	return conversions, nil
+}
+
 // GetConversions retrieves conversion events with filtering
-func (r *analyticsRepository) GetConversions(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error) {
+func (r *analyticsRepository) GetConversions(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error) {
 	query := bson.M{"wedding_id": weddingID}
 
 	// Apply filters
@@ -266,146 +413,122 @@ func (r *analyticsRepository) GetConversions(ctx context.Context, weddingID prim
 	return conversions, total, nil
 }
 
-// GetWeddingAnalytics retrieves aggregated analytics for a wedding
-func (r *analyticsRepository) GetWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) (*models.WeddingAnalytics, error) {
-	var analytics models.WeddingAnalytics
+// GetWeddingAnalytics retrieves a wedding's analytics from its counters
+func (r *analyticsRepository) GetWeddingAnalytics(ctx context.Context, weddingID models.ID) (*models.WeddingAnalytics, error) {
+	analytics := models.WeddingAnalytics{WeddingID: weddingID}
 	err := r.weddingAnalytics.FindOne(ctx, bson.M{"_id": weddingID}).Decode(&analytics)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			// Return empty analytics if not found
-			return &models.WeddingAnalytics{
-				WeddingID:       weddingID,
-				PageViews:       0,
-				UniqueSessions:  0,
-				RSVPCount:       0,
-				CompletedRSVPs:  0,
-				ConversionRate:  0,
-				PopularPages:    make(map[string]int64),
-				TrafficSources:  make(map[string]int64),
-				DeviceBreakdown: make(map[string]int64),
-				ViewsByDate:     make(map[string]int64),
-				RSVPsByDate:     make(map[string]int64),
-				LastUpdated:     time.Now(),
-			}, nil
+		if err != mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("failed to get wedding analytics: %w", err)
 		}
-		return nil, fmt.Errorf("failed to get wedding analytics: %w", err)
-	}
-
-	return &analytics, nil
-}
-
-// UpdateWeddingAnalytics recalculates and updates wedding analytics
-func (r *analyticsRepository) UpdateWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) error {
-	// Get basic metrics
-	pageViews, err := r.pageViews.CountDocuments(ctx, bson.M{"wedding_id": weddingID})
-	if err != nil {
-		return fmt.Errorf("failed to count page views: %w", err)
+		// Return empty analytics if not found
+		analytics.LastUpdated = time.Now()
 	}
 
-	// Get unique sessions
-	pipeline := []bson.M{
-		{"$match": bson.M{"wedding_id": weddingID}},
-		{"$group": bson.M{"_id": "$session_id"}},
-		{"$count": "unique_sessions"},
+	if analytics.PopularPages == nil {
+		analytics.PopularPages = make(map[string]int64)
 	}
-	cursor, err := r.pageViews.Aggregate(ctx, pipeline)
-	if err != nil {
-		return fmt.Errorf("failed to aggregate sessions: %w", err)
+	if analytics.TrafficSources == nil {
+		analytics.TrafficSources = make(map[string]int64)
 	}
-	defer cursor.Close(ctx)
-
-	var uniqueSessions int64 = 0
-	if cursor.Next(ctx) {
-		var result struct {
-			UniqueSessions int64 `bson:"unique_sessions"`
-		}
-		if err := cursor.Decode(&result); err == nil {
-			uniqueSessions = result.UniqueSessions
-		}
+	if analytics.DeviceBreakdown == nil {
+		analytics.DeviceBreakdown = make(map[string]int64)
 	}
-
-	// Get RSVP count
-	rsvpCount, err := r.rsvpEvents.CountDocuments(ctx, bson.M{"wedding_id": weddingID})
-	if err != nil {
-		return fmt.Errorf("failed to count RSVP analytics: %w", err)
+	if analytics.CountryBreakdown == nil {
+		analytics.CountryBreakdown = make(map[string]int64)
 	}
+	setAnalyticsRates(&analytics)
 
-	// Calculate popular pages
-	popularPagesPipeline := []bson.M{
-		{"$match": bson.M{"wedding_id": weddingID}},
-		{"$group": bson.M{"_id": "$page", "count": bson.M{"$sum": 1}}},
-		{"$sort": bson.M{"count": -1}},
-		{"$limit": 10},
-	}
-	popularPagesCursor, err := r.pageViews.Aggregate(ctx, popularPagesPipeline)
+	buckets, err := r.getDailyBuckets(ctx, weddingID, "", "")
 	if err != nil {
-		return fmt.Errorf("failed to aggregate popular pages: %w", err)
+		return nil, err
 	}
-	defer popularPagesCursor.Close(ctx)
-
-	popularPages := make(map[string]int64)
-	for popularPagesCursor.Next(ctx) {
-		var result struct {
-			Page  string `bson:"_id"`
-			Count int64  `bson:"count"`
+	analytics.ViewsByDate = make(map[string]int64, len(buckets))
+	analytics.RSVPsByDate = make(map[string]int64, len(buckets))
+	for _, bucket := range buckets {
+		if bucket.PageViews > 0 {
+			analytics.ViewsByDate[bucket.Date] = bucket.PageViews
 		}
-		if err := popularPagesCursor.Decode(&result); err == nil {
-			popularPages[result.Page] = result.Count
+		if bucket.RSVPs > 0 {
+			analytics.RSVPsByDate[bucket.Date] = bucket.RSVPs
 		}
 	}
 
-	// Calculate device breakdown
-	devicePipeline := []bson.M{
-		{"$match": bson.M{"wedding_id": weddingID, "device": bson.M{"$ne": ""}}},
-		{"$group": bson.M{"_id": "$device", "count": bson.M{"$sum": 1}}},
+	// Wishes are counted live, so moderation shows up right away
+	wishes := r.db.Collection("wishes")
+	analytics.Wishes, err = wishes.CountDocuments(ctx, bson.M{"wedding_id": weddingID, "status": models.WishApproved})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count wishes: %w", err)
 	}
-	deviceCursor, err := r.pageViews.Aggregate(ctx, devicePipeline)
+	analytics.PendingWishes, err = wishes.CountDocuments(ctx, bson.M{"wedding_id": weddingID, "status": models.WishPending})
 	if err != nil {
-		return fmt.Errorf("failed to aggregate device breakdown: %w", err)
+		return nil, fmt.Errorf("failed to count pending wishes: %w", err)
 	}
-	defer deviceCursor.Close(ctx)
 
-	deviceBreakdown := make(map[string]int64)
-	for deviceCursor.Next(ctx) {
-		var result struct {
-			Device string `bson:"_id"`
-			Count  int64  `bson:"count"`
-		}
-		if err := deviceCursor.Decode(&result); err == nil {
-			deviceBreakdown[result.Device] = result.Count
-		}
+	return &analytics, nil
+}
+
+// UpdateWeddingAnalytics reconciles a wedding's counters with its raw events.
+// Tracking increments the counters as events arrive; this rebuilds the daily
+// buckets still covered by the raw events' retention, keeps older buckets as
+// they are, and resets the totals to the sum of all buckets. Events tracked
+// while it runs can be missed until the next reconciliation.
+func (r *analyticsRepository) UpdateWeddingAnalytics(ctx context.Context, weddingID models.ID) error {
+	// The oldest retained day may already be partly expired, so start after it.
+	// A wedding whose override keeps raw events for less keeps the counters of
+	// the days before as they are.
+	retention := analyticsRawRetention
+	if period, err := rawEventRetention(ctx, r.db, weddingID); err != nil {
+		return err
+	} else if period > 0 && period < retention {
+		retention = period
+	}
+	since := time.Now().UTC().Add(-retention).Truncate(24 * time.Hour).Add(24 * time.Hour)
+	if err := r.rebuildDailyBuckets(ctx, weddingID, since); err != nil {
+		return err
 	}
 
-	// Calculate conversion rate
-	var conversionRate float64 = 0
-	if pageViews > 0 {
-		conversionRate = float64(rsvpCount) / float64(pageViews) * 100
+	buckets, err := r.getDailyBuckets(ctx, weddingID, "", "")
+	if err != nil {
+		return err
 	}
 
-	// Create update
 	analytics := &models.WeddingAnalytics{
-		WeddingID:         weddingID,
-		PageViews:         pageViews,
-		UniqueSessions:    uniqueSessions,
-		RSVPCount:         rsvpCount,
-		CompletedRSVPs:    rsvpCount, // For now, all RSVPs are considered completed
-		ConversionRate:    conversionRate,
-		PopularPages:      popularPages,
-		TrafficSources:    make(map[string]int64), // TODO: implement traffic source tracking
-		DeviceBreakdown:   deviceBreakdown,
-		ViewsByDate:       make(map[string]int64), // TODO: implement date-based tracking
-		RSVPsByDate:       make(map[string]int64), // TODO: implement date-based RSVP tracking
-		AverageTimeOnPage: 0,                      // TODO: implement average time on page
-		BounceRate:        0,                      // TODO: implement bounce rate calculation
-		LastUpdated:       time.Now(),
+		WeddingID:        weddingID,
+		PopularPages:     make(map[string]int64),
+		TrafficSources:   make(map[string]int64),
+		DeviceBreakdown:  make(map[string]int64),
+		CountryBreakdown: make(map[string]int64),
 	}
+	for _, bucket := range buckets {
+		analytics.PageViews += bucket.PageViews
+		analytics.UniqueSessions += bucket.NewSessions
+		analytics.RSVPCount += bucket.RSVPs
+		analytics.BouncedSessions += bucket.Bounces
+		analytics.TotalDuration += bucket.Duration
+		analytics.TimedPageViews += bucket.TimedViews
+		analytics.BotPageViews += bucket.BotPageViews
+		for page, count := range bucket.Pages {
+			analytics.PopularPages[page] += count
+		}
+		for device, count := range bucket.Devices {
+			analytics.DeviceBreakdown[device] += count
+		}
+		for source, count := range bucket.Sources {
+			analytics.TrafficSources[source] += count
+		}
+		for country, count := range bucket.Countries {
+			analytics.CountryBreakdown[country] += count
+		}
+	}
+	analytics.CompletedRSVPs = analytics.RSVPCount // For now, all RSVPs are considered completed
+	setAnalyticsRates(analytics)
 
-	// Upsert analytics
-	filter := bson.M{"_id": weddingID}
-	update := bson.M{"$set": analytics}
-	options := options.Update().SetUpsert(true)
+	now := time.Now()
+	analytics.LastUpdated = now
+	analytics.ReconciledAt = &now
 
-	_, err = r.weddingAnalytics.UpdateOne(ctx, filter, update, options)
+	_, err = r.weddingAnalytics.ReplaceOne(ctx, bson.M{"_id": weddingID}, analytics, options.Replace().SetUpsert(true))
 	if err != nil {
 		return fmt.Errorf("failed to update wedding analytics: %w", err)
 	}
@@ -506,7 +629,7 @@ func (r *analyticsRepository) UpdateSystemAnalytics(ctx context.Context) error {
 	}
 
 	// Get total page views
-	totalPageViews, err := r.pageViews.CountDocuments(ctx, bson.M{})
+	totalPageViews, err := r.pageViews.CountDocuments(ctx, humanPageViews(bson.M{}))
 	if err != nil {
 		return fmt.Errorf("failed to count page views: %w", err)
 	}
@@ -540,7 +663,7 @@ func (r *analyticsRepository) UpdateSystemAnalytics(ctx context.Context) error {
 }
 
 // GetAnalyticsSummary generates a summary report for a wedding
-func (r *analyticsRepository) GetAnalyticsSummary(ctx context.Context, weddingID primitive.ObjectID, period string) (*models.AnalyticsSummary, error) {
+func (r *analyticsRepository) GetAnalyticsSummary(ctx context.Context, weddingID models.ID, period string) (*models.AnalyticsSummary, error) {
 	// Calculate date range based on period
 	var startDate time.Time
 	now := time.Now()
@@ -628,19 +751,21 @@ func (r *analyticsRepository) GetAnalyticsSummary(ctx context.Context, weddingID
 }
 
 // GetPopularPages returns the most popular pages for a wedding
-func (r *analyticsRepository) GetPopularPages(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.PageStats, error) {
+func (r *analyticsRepository) GetPopularPages(ctx context.Context, weddingID models.ID, limit int) ([]models.PageStats, error) {
 	pipeline := []bson.M{
-		{"$match": bson.M{"wedding_id": weddingID}},
+		{"$match": humanPageViews(bson.M{"wedding_id": weddingID})},
 		{"$group": bson.M{
 			"_id":          "$page",
 			"views":        bson.M{"$sum": 1},
 			"unique_views": bson.M{"$addToSet": "$session_id"},
+			// Only views that reported a duration; $avg skips nulls
+			"avg_time": bson.M{"$avg": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$duration", 0}}, "$duration", nil}}},
 		}},
 		{"$project": bson.M{
 			"page":         "$_id",
 			"views":        1,
 			"unique_views": bson.M{"$size": "$unique_views"},
-			"avg_time":     bson.M{"$avg": "$duration"},
+			"avg_time":     1,
 		}},
 		{"$sort": bson.M{"views": -1}},
 		{"$limit": int64(limit)},
@@ -675,15 +800,13 @@ func (r *analyticsRepository) GetPopularPages(ctx context.Context, weddingID pri
 	return pages, nil
 }
 
-// GetTrafficSources returns traffic sources for a wedding
-func (r *analyticsRepository) GetTrafficSources(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.TrafficSourceStats, error) {
+// GetTrafficSources returns the traffic sources of a wedding's page views, by visitors
+func (r *analyticsRepository) GetTrafficSources(ctx context.Context, weddingID models.ID, limit int) ([]models.TrafficSourceStats, error) {
 	pipeline := []bson.M{
-		{"$match": bson.M{
-			"wedding_id": weddingID,
-			"referrer":   bson.M{"$ne": ""},
-		}},
+		{"$match": humanPageViews(bson.M{"wedding_id": weddingID})},
 		{"$group": bson.M{
-			"_id":      "$referrer",
+			// Views tracked before attribution only carry a referrer
+			"_id":      bson.M{"$ifNull": bson.A{"$source", "unknown"}},
 			"visitors": bson.M{"$addToSet": "$session_id"},
 			"views":    bson.M{"$sum": 1},
 		}},
@@ -692,7 +815,7 @@ func (r *analyticsRepository) GetTrafficSources(ctx context.Context, weddingID p
 			"visitors": bson.M{"$size": "$visitors"},
 			"views":    1,
 		}},
-		{"$sort": bson.M{"visitors": -1}},
+		{"$sort": bson.D{{Key: "visitors", Value: -1}, {Key: "views", Value: -1}}},
 		{"$limit": int64(limit)},
 	}
 
@@ -723,129 +846,129 @@ func (r *analyticsRepository) GetTrafficSources(ctx context.Context, weddingID p
 	return sources, nil
 }
 
-// GetDailyMetrics returns daily metrics for a date range
-func (r *analyticsRepository) GetDailyMetrics(ctx context.Context, weddingID primitive.ObjectID, startDate, endDate time.Time) ([]models.DailyMetrics, error) {
-	// Get daily page views
-	pageViewsPipeline := []bson.M{
-		{"$match": bson.M{
-			"wedding_id": weddingID,
-			"timestamp":  bson.M{"$gte": startDate, "$lte": endDate},
-		}},
+// GetGeoBreakdown returns the countries of a wedding's page views by visitors,
+// each with its cities. Views without a known country are left out.
+func (r *analyticsRepository) GetGeoBreakdown(ctx context.Context, weddingID models.ID, limit int) ([]models.CountryStats, error) {
+	match := humanPageViews(bson.M{"wedding_id": weddingID, "country": bson.M{"$nin": bson.A{"", nil}}})
+
+	pipeline := []bson.M{
+		{"$match": match},
 		{"$group": bson.M{
-			"_id": bson.M{
-				"year":  bson.M{"$year": "$timestamp"},
-				"month": bson.M{"$month": "$timestamp"},
-				"day":   bson.M{"$dayOfMonth": "$timestamp"},
-			},
-			"page_views": bson.M{"$sum": 1},
-			"sessions":   bson.M{"$addToSet": "$session_id"},
-		}},
-		{"$addFields": bson.M{
-			"date_str": bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": bson.M{"$dateFromParts": bson.M{"year": "$_id.year", "month": "$_id.month", "day": "$_id.day"}}}},
+			"_id":      "$country",
+			"visitors": bson.M{"$addToSet": "$session_id"},
+			"views":    bson.M{"$sum": 1},
 		}},
 		{"$project": bson.M{
-			"date":       "$date_str",
-			"page_views": 1,
-			"sessions":   bson.M{"$size": "$sessions"},
+			"country":  "$_id",
+			"visitors": bson.M{"$size": "$visitors"},
+			"views":    1,
 		}},
-		{"$sort": bson.M{"date": 1}},
+		{"$sort": bson.D{{Key: "visitors", Value: -1}, {Key: "views", Value: -1}, {Key: "_id", Value: 1}}},
+		{"$limit": int64(limit)},
 	}
 
-	pageViewsCursor, err := r.pageViews.Aggregate(ctx, pageViewsPipeline)
+	cursor, err := r.pageViews.Aggregate(ctx, pipeline)
 	if err != nil {
-		return nil, fmt.Errorf("failed to aggregate daily page views: %w", err)
+		return nil, fmt.Errorf("failed to aggregate countries: %w", err)
+	}
+	var countryResults []struct {
+		Country  string `bson:"country"`
+		Visitors int64  `bson:"visitors"`
+		Views    int64  `bson:"views"`
+	}
+	if err := cursor.All(ctx, &countryResults); err != nil {
+		return nil, fmt.Errorf("failed to decode countries: %w", err)
+	}
+	if len(countryResults) == 0 {
+		return []models.CountryStats{}, nil
 	}
-	defer pageViewsCursor.Close(ctx)
-
-	// Create map for page views by date
-	pageViewsByDate := make(map[string]models.DailyMetrics)
-	for pageViewsCursor.Next(ctx) {
-		var result struct {
-			Date      string `bson:"date"`
-			PageViews int64  `bson:"page_views"`
-			Sessions  int64  `bson:"sessions"`
-		}
-		if err := pageViewsCursor.Decode(&result); err != nil {
-			continue
-		}
 
-		pageViewsByDate[result.Date] = models.DailyMetrics{
-			Date:      result.Date,
-			PageViews: result.PageViews,
-			Sessions:  result.Sessions,
+	countries := make([]models.CountryStats, len(countryResults))
+	index := make(map[string]int, len(countryResults))
+	codes := make(bson.A, len(countryResults))
+	for i, result := range countryResults {
+		countries[i] = models.CountryStats{
+			Country:  result.Country,
+			Visitors: result.Visitors,
+			Views:    result.Views,
+			Cities:   []models.CityStats{},
 		}
+		index[result.Country] = i
+		codes[i] = result.Country
 	}
 
-	// Get daily RSVPs
-	rsvpPipeline := []bson.M{
-		{"$match": bson.M{
-			"wedding_id": weddingID,
-			"timestamp":  bson.M{"$gte": startDate, "$lte": endDate},
-		}},
+	// Cities of the listed countries
+	cursor, err = r.pageViews.Aggregate(ctx, []bson.M{
+		{"$match": humanPageViews(bson.M{"wedding_id": weddingID, "country": bson.M{"$in": codes}, "city": bson.M{"$nin": bson.A{"", nil}}})},
 		{"$group": bson.M{
-			"_id": bson.M{
-				"year":  bson.M{"$year": "$timestamp"},
-				"month": bson.M{"$month": "$timestamp"},
-				"day":   bson.M{"$dayOfMonth": "$timestamp"},
-			},
-			"rsvps": bson.M{"$sum": 1},
-		}},
-		{"$addFields": bson.M{
-			"date_str": bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": bson.M{"$dateFromParts": bson.M{"year": "$_id.year", "month": "$_id.month", "day": "$_id.day"}}}},
+			"_id":      bson.M{"country": "$country", "city": "$city"},
+			"visitors": bson.M{"$addToSet": "$session_id"},
+			"views":    bson.M{"$sum": 1},
 		}},
 		{"$project": bson.M{
-			"date":  "$date_str",
-			"rsvps": 1,
+			"visitors": bson.M{"$size": "$visitors"},
+			"views":    1,
 		}},
+		{"$sort": bson.D{{Key: "visitors", Value: -1}, {Key: "views", Value: -1}, {Key: "_id.city", Value: 1}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate cities: %w", err)
 	}
+	var cityResults []struct {
+		ID struct {
+			Country string `bson:"country"`
+			City    string `bson:"city"`
+		} `bson:"_id"`
+		Visitors int64 `bson:"visitors"`
+		Views    int64 `bson:"views"`
+	}
+	if err := cursor.All(ctx, &cityResults); err != nil {
+		return nil, fmt.Errorf("failed to decode cities: %w", err)
+	}
+	for _, result := range cityResults {
+		country := &countries[index[result.ID.Country]]
+		country.Cities = append(country.Cities, models.CityStats{
+			City:     result.ID.City,
+			Visitors: result.Visitors,
+			Views:    result.Views,
+		})
+	}
+
+	return countries, nil
+}
 
-	rsvpCursor, err := r.rsvpEvents.Aggregate(ctx, rsvpPipeline)
+// GetDailyMetrics returns daily metrics for a date range from the daily buckets
+func (r *analyticsRepository) GetDailyMetrics(ctx context.Context, weddingID models.ID, startDate, endDate time.Time) ([]models.DailyMetrics, error) {
+	buckets, err := r.getDailyBuckets(ctx, weddingID, analyticsDate(startDate), analyticsDate(endDate))
 	if err != nil {
-		return nil, fmt.Errorf("failed to aggregate daily RSVPs: %w", err)
+		return nil, err
 	}
-	defer rsvpCursor.Close(ctx)
 
-	// Combine data and calculate conversion rates
-	var metrics []models.DailyMetrics
-	for rsvpCursor.Next(ctx) {
-		var result struct {
-			Date  string `bson:"date"`
-			RSVPS int64  `bson:"rsvps"`
+	metrics := make([]models.DailyMetrics, 0, len(buckets))
+	for _, bucket := range buckets {
+		metric := models.DailyMetrics{
+			Date:      bucket.Date,
+			PageViews: bucket.PageViews,
+			Sessions:  bucket.Sessions,
+			RSVPs:     bucket.RSVPs,
 		}
-		if err := rsvpCursor.Decode(&result); err != nil {
-			continue
+		if bucket.PageViews > 0 {
+			metric.Conversions = float64(bucket.RSVPs) / float64(bucket.PageViews) * 100
 		}
-
-		dailyMetric := pageViewsByDate[result.Date]
-		dailyMetric.Date = result.Date
-		dailyMetric.RSVPs = result.RSVPS
-
-		if dailyMetric.PageViews > 0 {
-			dailyMetric.Conversions = float64(result.RSVPS) / float64(dailyMetric.PageViews) * 100
+		if bucket.NewSessions > 0 {
+			metric.BounceRate = float64(bucket.Bounces) / float64(bucket.NewSessions) * 100
 		}
-
-		metrics = append(metrics, dailyMetric)
-		delete(pageViewsByDate, result.Date)
-	}
-
-	// Add remaining dates without RSVPs
-	for _, dailyMetric := range pageViewsByDate {
-		metrics = append(metrics, dailyMetric)
-	}
-
-	// Sort by date
-	for i := 0; i < len(metrics)-1; i++ {
-		for j := i + 1; j < len(metrics); j++ {
-			if metrics[i].Date > metrics[j].Date {
-				metrics[i], metrics[j] = metrics[j], metrics[i]
-			}
+		if bucket.TimedViews > 0 {
+			metric.AvgTime = float64(bucket.Duration) / float64(bucket.TimedViews)
 		}
+		metrics = append(metrics, metric)
 	}
 
 	return metrics, nil
 }
 
-// CleanupOldAnalytics removes analytics data older than the specified date
+// CleanupOldAnalytics removes raw analytics events older than the specified
+// date; the daily counters are kept
 func (r *analyticsRepository) CleanupOldAnalytics(ctx context.Context, olderThan time.Time) error {
 	// Cleanup old page views
 	_, err := r.pageViews.DeleteMany(ctx, bson.M{"timestamp": bson.M{"$lt": olderThan}})
@@ -869,7 +992,7 @@ func (r *analyticsRepository) CleanupOldAnalytics(ctx context.Context, olderThan
 }
 
 // RefreshWeddingAnalytics forces a refresh of wedding-specific analytics
-func (r *analyticsRepository) RefreshWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) error {
+func (r *analyticsRepository) RefreshWeddingAnalytics(ctx context.Context, weddingID models.ID) error {
 	// This would typically trigger a comprehensive recalculation
 	// For now, we'll delegate to the UpdateWeddingAnalytics method
 	return r.UpdateWeddingAnalytics(ctx, weddingID)
@@ -0,0 +1,154 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// analyticsHourFormat is how events are grouped by the UTC hour they were tracked in
+const (
+	analyticsHourFormat = "%Y-%m-%dT%H"
+	analyticsHourLayout = "2006-01-02T15"
+)
+
+// CountHourlyActivity counts the page views and RSVP events of every wedding
+// in each of the hours starting at the given times
+func (r *analyticsRepository) CountHourlyActivity(ctx context.Context, hours []time.Time) ([]models.HourlyActivity, error) {
+	if len(hours) == 0 {
+		return nil, nil
+	}
+	ranges := make(bson.A, len(hours))
+	for i, hour := range hours {
+		ranges[i] = bson.M{"timestamp": bson.M{"$gte": hour, "$lt": hour.Add(time.Hour)}}
+	}
+	// RSVP events are never flagged as bots, so the page view filter fits both
+	pipeline := []bson.M{
+		{"$match": humanPageViews(bson.M{"$or": ranges})},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"wedding_id": "$wedding_id",
+				"hour":       bson.M{"$dateToString": bson.M{"format": analyticsHourFormat, "date": "$timestamp"}},
+			},
+			"count": bson.M{"$sum": 1},
+		}},
+	}
+
+	type hourKey struct {
+		WeddingID models.ID `bson:"wedding_id"`
+		Hour      string    `bson:"hour"`
+	}
+	activity := make(map[hourKey]*models.HourlyActivity)
+	count := func(collection *mongo.Collection, add func(*models.HourlyActivity, int64)) error {
+		var counts []struct {
+			Key   hourKey `bson:"_id"`
+			Count int64   `bson:"count"`
+		}
+		if err := aggregateAll(ctx, collection, pipeline, &counts); err != nil {
+			return fmt.Errorf("failed to count hourly %s: %w", collection.Name(), err)
+		}
+		for _, c := range counts {
+			entry := activity[c.Key]
+			if entry == nil {
+				hour, err := time.Parse(analyticsHourLayout, c.Key.Hour)
+				if err != nil {
+					return fmt.Errorf("invalid analytics hour %q: %w", c.Key.Hour, err)
+				}
+				entry = &models.HourlyActivity{WeddingID: c.Key.WeddingID, Hour: hour}
+				activity[c.Key] = entry
+			}
+			add(entry, c.Count)
+		}
+		return nil
+	}
+
+	if err := count(r.pageViews, func(a *models.HourlyActivity, n int64) { a.PageViews = n }); err != nil {
+		return nil, err
+	}
+	if err := count(r.rsvpEvents, func(a *models.HourlyActivity, n int64) { a.RSVPs = n }); err != nil {
+		return nil, err
+	}
+
+	result := make([]models.HourlyActivity, 0, len(activity))
+	for _, entry := range activity {
+		result = append(result, *entry)
+	}
+	return result, nil
+}
+
+// Ensure analyticsAnomalyRepository implements the domain repository interface
+var _ repository.AnalyticsAnomalyRepository = (*analyticsAnomalyRepository)(nil)
+
+type analyticsAnomalyRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAnalyticsAnomalyRepository creates a new MongoDB analytics anomaly repository
+func NewAnalyticsAnomalyRepository(db *mongo.Database) repository.AnalyticsAnomalyRepository {
+	return &analyticsAnomalyRepository{
+		collection: db.Collection("analytics_anomalies"),
+	}
+}
+
+// Create records an anomaly
+func (r *analyticsAnomalyRepository) Create(ctx context.Context, anomaly *models.AnalyticsAnomaly) error {
+	if anomaly.ID.IsZero() {
+		anomaly.ID = models.NewID()
+	}
+	if anomaly.DetectedAt.IsZero() {
+		anomaly.DetectedAt = time.Now()
+	}
+	if _, err := r.collection.InsertOne(ctx, anomaly); err != nil {
+		return fmt.Errorf("failed to create analytics anomaly: %w", err)
+	}
+	return nil
+}
+
+// GetLatest returns the wedding's anomaly of a metric and kind with the latest hour
+func (r *analyticsAnomalyRepository) GetLatest(ctx context.Context, weddingID models.ID, metric models.AnomalyMetric, kind models.AnomalyKind) (*models.AnalyticsAnomaly, error) {
+	var anomaly models.AnalyticsAnomaly
+	opts := options.FindOne().SetSort(bson.D{{Key: "hour", Value: -1}})
+	err := r.collection.FindOne(ctx, bson.M{"wedding_id": weddingID, "metric": metric, "kind": kind}, opts).Decode(&anomaly)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get analytics anomaly: %w", err)
+	}
+	return &anomaly, nil
+}
+
+// ListByWedding lists the anomalies of a wedding, latest hour first
+func (r *analyticsAnomalyRepository) ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int) ([]*models.AnalyticsAnomaly, int64, error) {
+	filter := bson.M{"wedding_id": weddingID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count analytics anomalies: %w", err)
+	}
+
+	skip := (page - 1) * pageSize
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "hour", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list analytics anomalies: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	anomalies := []*models.AnalyticsAnomaly{}
+	if err := cursor.All(ctx, &anomalies); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode analytics anomalies: %w", err)
+	}
+	return anomalies, total, nil
+}
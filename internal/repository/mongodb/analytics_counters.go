@@ -0,0 +1,471 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+const (
+	// analyticsDateFormat is the format of the daily bucket dates
+	analyticsDateFormat = "2006-01-02"
+
+	// analyticsRawRetention is the shortest period raw analytics events are kept
+	// for unless a wedding's retention override shortens it; older days only
+	// survive in the daily buckets
+	analyticsRawRetention = 90 * 24 * time.Hour
+)
+
+// analyticsDate returns the UTC day a timestamp is counted in
+func analyticsDate(t time.Time) string {
+	return t.UTC().Format(analyticsDateFormat)
+}
+
+// humanPageViews narrows a page view filter to the views not flagged as bots,
+// which are stored but left out of every count
+func humanPageViews(filter bson.M) bson.M {
+	human := bson.M{"is_bot": bson.M{"$ne": true}}
+	for key, value := range filter {
+		human[key] = value
+	}
+	return human
+}
+
+// counterKey turns a page, device or source name into a document key usable in $inc
+func counterKey(name string) string {
+	if name == "" {
+		return "unknown"
+	}
+	key := strings.ReplaceAll(name, ".", "_")
+	if strings.HasPrefix(key, "$") {
+		key = "_" + key[1:]
+	}
+	return key
+}
+
+// sessionHistory returns when the page view's session was last seen, newest
+// first and at most twice; empty for a session seen for the first time and
+// for bots, whose views do not count towards sessions
+func (r *analyticsRepository) sessionHistory(ctx context.Context, pageView *models.PageView) ([]time.Time, error) {
+	if pageView.SessionID == "" || pageView.IsBot {
+		return nil, nil
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
+		SetProjection(bson.M{"timestamp": 1}).
+		SetLimit(2)
+	cursor, err := r.pageViews.Find(ctx, humanPageViews(bson.M{"wedding_id": pageView.WeddingID, "session_id": pageView.SessionID}), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session page views: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var views []models.PageView
+	if err := cursor.All(ctx, &views); err != nil {
+		return nil, fmt.Errorf("failed to decode session page views: %w", err)
+	}
+
+	history := make([]time.Time, len(views))
+	for i, view := range views {
+		history[i] = view.Timestamp
+	}
+	return history, nil
+}
+
+// countPageView increments the counters of a tracked page view. history is
+// when its session was seen before this view (see sessionHistory).
+//
+// A session counts as a bounce from its first view; its second view takes the
+// bounce back from the day the session started.
+func (r *analyticsRepository) countPageView(ctx context.Context, pageView *models.PageView, history []time.Time) error {
+	buckets, totals := pageViewCounters(pageView, history)
+	return r.incrementCounters(ctx, pageView.WeddingID, buckets, totals)
+}
+
+// pageViewCounters returns the increments of the daily buckets (by date) and of
+// the wedding totals for a page view, as counted by countPageView. A bot's view
+// only counts as one.
+func pageViewCounters(pageView *models.PageView, history []time.Time) (map[string]bson.M, bson.M) {
+	date := analyticsDate(pageView.Timestamp)
+	if pageView.IsBot {
+		return map[string]bson.M{date: {"bot_page_views": 1}}, bson.M{"bot_page_views": 1}
+	}
+	page := counterKey(pageView.Page)
+
+	bucket := bson.M{"page_views": 1, "pages." + page: 1}
+	totals := bson.M{"page_views": 1, "popular_pages." + page: 1}
+	buckets := map[string]bson.M{date: bucket}
+	if pageView.Device != "" {
+		device := counterKey(pageView.Device)
+		bucket["devices."+device] = 1
+		totals["device_breakdown."+device] = 1
+	}
+	if pageView.Country != "" {
+		country := counterKey(pageView.Country)
+		bucket["countries."+country] = 1
+		totals["country_breakdown."+country] = 1
+	}
+	if pageView.SessionID != "" {
+		switch {
+		case len(history) == 0:
+			// Sessions are attributed to the source of their first view
+			source := counterKey(pageView.Source)
+			bucket["sessions"] = 1
+			bucket["new_sessions"] = 1
+			bucket["bounces"] = 1
+			bucket["sources."+source] = 1
+			totals["unique_sessions"] = 1
+			totals["bounced_sessions"] = 1
+			totals["traffic_sources."+source] = 1
+		case analyticsDate(history[0]) != date:
+			bucket["sessions"] = 1
+		}
+
+		if len(history) == 1 {
+			startDate := analyticsDate(history[0])
+			if buckets[startDate] == nil {
+				buckets[startDate] = bson.M{}
+			}
+			buckets[startDate]["bounces"] = -1
+			totals["bounced_sessions"] = -1
+		}
+	}
+
+	return buckets, totals
+}
+
+// countRSVPEvent increments the counters of a tracked RSVP submission
+func (r *analyticsRepository) countRSVPEvent(ctx context.Context, event *models.RSVPAnalytics) error {
+	return r.incrementCounters(ctx, event.WeddingID,
+		map[string]bson.M{analyticsDate(event.Timestamp): {"rsvps": 1}},
+		bson.M{"rsvp_count": 1, "completed_rsvps": 1}) // For now, all RSVPs are considered completed
+}
+
+// countPageDuration adds the time newly reported for a page view. previous is
+// the duration it reported before.
+func (r *analyticsRepository) countPageDuration(ctx context.Context, pageView *models.PageView, previous int64) error {
+	added := pageView.Duration - previous
+	if added <= 0 || pageView.IsBot {
+		return nil
+	}
+
+	inc := bson.M{"duration": added}
+	totals := bson.M{"total_duration": added}
+	if previous == 0 {
+		inc["timed_views"] = 1
+		totals["timed_page_views"] = 1
+	}
+	return r.incrementCounters(ctx, pageView.WeddingID, map[string]bson.M{analyticsDate(pageView.Timestamp): inc}, totals)
+}
+
+// incrementCounters applies one event to the daily buckets (by date) and to the wedding totals
+func (r *analyticsRepository) incrementCounters(ctx context.Context, weddingID models.ID, buckets map[string]bson.M, totals bson.M) error {
+	now := time.Now()
+	upsert := options.Update().SetUpsert(true)
+
+	for date, inc := range buckets {
+		_, err := r.dailyAnalytics.UpdateOne(ctx,
+			bson.M{"wedding_id": weddingID, "date": date},
+			bson.M{"$inc": inc, "$set": bson.M{"updated_at": now}},
+			upsert)
+		if err != nil {
+			return fmt.Errorf("failed to update daily analytics: %w", err)
+		}
+	}
+
+	_, err := r.weddingAnalytics.UpdateOne(ctx,
+		bson.M{"_id": weddingID},
+		bson.M{"$inc": totals, "$set": bson.M{"last_updated": now}},
+		upsert)
+	if err != nil {
+		return fmt.Errorf("failed to update wedding analytics: %w", err)
+	}
+
+	return nil
+}
+
+// addCounters adds the increments of src to dst
+func addCounters(dst, src bson.M) {
+	for key, value := range src {
+		current, _ := dst[key].(int)
+		dst[key] = current + value.(int)
+	}
+}
+
+// setAnalyticsRates derives the rates of a wedding's analytics from its counters
+func setAnalyticsRates(analytics *models.WeddingAnalytics) {
+	analytics.ConversionRate = 0
+	if analytics.PageViews > 0 {
+		analytics.ConversionRate = float64(analytics.RSVPCount) / float64(analytics.PageViews) * 100
+	}
+	analytics.BounceRate = 0
+	if analytics.UniqueSessions > 0 {
+		analytics.BounceRate = float64(analytics.BouncedSessions) / float64(analytics.UniqueSessions) * 100
+	}
+	analytics.AverageTimeOnPage = 0
+	if analytics.TimedPageViews > 0 {
+		analytics.AverageTimeOnPage = float64(analytics.TotalDuration) / float64(analytics.TimedPageViews)
+	}
+	analytics.BotPercentage = 0
+	if all := analytics.PageViews + analytics.BotPageViews; all > 0 {
+		analytics.BotPercentage = float64(analytics.BotPageViews) / float64(all) * 100
+	}
+}
+
+// getDailyBuckets returns a wedding's daily buckets between two dates (inclusive), oldest first
+func (r *analyticsRepository) getDailyBuckets(ctx context.Context, weddingID models.ID, from, to string) ([]*models.AnalyticsDailyBucket, error) {
+	query := bson.M{"wedding_id": weddingID}
+	dates := bson.M{}
+	if from != "" {
+		dates["$gte"] = from
+	}
+	if to != "" {
+		dates["$lte"] = to
+	}
+	if len(dates) > 0 {
+		query["date"] = dates
+	}
+
+	cursor, err := r.dailyAnalytics.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "date", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find daily analytics: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var buckets []*models.AnalyticsDailyBucket
+	if err := cursor.All(ctx, &buckets); err != nil {
+		return nil, fmt.Errorf("failed to decode daily analytics: %w", err)
+	}
+	return buckets, nil
+}
+
+// rebuildDailyBuckets recomputes the daily buckets of the days since the given
+// one from the raw events
+func (r *analyticsRepository) rebuildDailyBuckets(ctx context.Context, weddingID models.ID, since time.Time) error {
+	buckets := make(map[string]*models.AnalyticsDailyBucket)
+	bucketFor := func(date string) *models.AnalyticsDailyBucket {
+		bucket, ok := buckets[date]
+		if !ok {
+			bucket = &models.AnalyticsDailyBucket{
+				WeddingID: weddingID,
+				Date:      date,
+				Pages:     make(map[string]int64),
+				Devices:   make(map[string]int64),
+				Sources:   make(map[string]int64),
+				Countries: make(map[string]int64),
+			}
+			buckets[date] = bucket
+		}
+		return bucket
+	}
+	dateOf := bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$timestamp"}}
+	match := bson.M{"wedding_id": weddingID, "timestamp": bson.M{"$gte": since}}
+
+	// Page views per page, device and country
+	cursor, err := r.pageViews.Aggregate(ctx, []bson.M{
+		{"$match": humanPageViews(match)},
+		{"$group": bson.M{
+			"_id":      bson.M{"date": dateOf, "page": "$page", "device": "$device", "country": "$country"},
+			"count":    bson.M{"$sum": 1},
+			"duration": bson.M{"$sum": "$duration"},
+			"timed":    bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$duration", 0}}, 1, 0}}},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to aggregate daily page views: %w", err)
+	}
+	var views []struct {
+		ID struct {
+			Date    string `bson:"date"`
+			Page    string `bson:"page"`
+			Device  string `bson:"device"`
+			Country string `bson:"country"`
+		} `bson:"_id"`
+		Count    int64 `bson:"count"`
+		Duration int64 `bson:"duration"`
+		Timed    int64 `bson:"timed"`
+	}
+	if err := cursor.All(ctx, &views); err != nil {
+		return fmt.Errorf("failed to decode daily page views: %w", err)
+	}
+	for _, view := range views {
+		bucket := bucketFor(view.ID.Date)
+		bucket.PageViews += view.Count
+		bucket.Duration += view.Duration
+		bucket.TimedViews += view.Timed
+		bucket.Pages[counterKey(view.ID.Page)] += view.Count
+		if view.ID.Device != "" {
+			bucket.Devices[counterKey(view.ID.Device)] += view.Count
+		}
+		if view.ID.Country != "" {
+			bucket.Countries[counterKey(view.ID.Country)] += view.Count
+		}
+	}
+
+	// Sessions active per day
+	cursor, err = r.pageViews.Aggregate(ctx, []bson.M{
+		{"$match": humanPageViews(bson.M{"wedding_id": weddingID, "timestamp": bson.M{"$gte": since}, "session_id": bson.M{"$ne": ""}})},
+		{"$group": bson.M{"_id": bson.M{"date": dateOf, "session": "$session_id"}}},
+		{"$group": bson.M{"_id": "$_id.date", "count": bson.M{"$sum": 1}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to aggregate daily sessions: %w", err)
+	}
+	var sessions []struct {
+		Date  string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return fmt.Errorf("failed to decode daily sessions: %w", err)
+	}
+	for _, day := range sessions {
+		bucketFor(day.Date).Sessions = day.Count
+	}
+
+	// Sessions, single-page sessions and session sources by the day the
+	// sessions were first seen
+	cursor, err = r.pageViews.Aggregate(ctx, []bson.M{
+		{"$match": humanPageViews(bson.M{"wedding_id": weddingID, "session_id": bson.M{"$ne": ""}})},
+		{"$sort": bson.D{{Key: "timestamp", Value: 1}}},
+		{"$group": bson.M{
+			"_id":    "$session_id",
+			"first":  bson.M{"$first": "$timestamp"},
+			"source": bson.M{"$first": "$source"},
+			"views":  bson.M{"$sum": 1},
+		}},
+		{"$match": bson.M{"first": bson.M{"$gte": since}}},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"date":   bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$first"}},
+				"source": "$source",
+			},
+			"count":   bson.M{"$sum": 1},
+			"bounces": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$views", 1}}, 1, 0}}},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to aggregate new sessions: %w", err)
+	}
+	var newSessions []struct {
+		ID struct {
+			Date   string `bson:"date"`
+			Source string `bson:"source"`
+		} `bson:"_id"`
+		Count   int64 `bson:"count"`
+		Bounces int64 `bson:"bounces"`
+	}
+	if err := cursor.All(ctx, &newSessions); err != nil {
+		return fmt.Errorf("failed to decode new sessions: %w", err)
+	}
+	for _, group := range newSessions {
+		bucket := bucketFor(group.ID.Date)
+		bucket.NewSessions += group.Count
+		bucket.Bounces += group.Bounces
+		bucket.Sources[counterKey(group.ID.Source)] += group.Count
+	}
+
+	// Page views of bots per day
+	cursor, err = r.pageViews.Aggregate(ctx, []bson.M{
+		{"$match": bson.M{"wedding_id": weddingID, "timestamp": bson.M{"$gte": since}, "is_bot": true}},
+		{"$group": bson.M{"_id": dateOf, "count": bson.M{"$sum": 1}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to aggregate daily bot page views: %w", err)
+	}
+	var bots []struct {
+		Date  string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &bots); err != nil {
+		return fmt.Errorf("failed to decode daily bot page views: %w", err)
+	}
+	for _, day := range bots {
+		bucketFor(day.Date).BotPageViews = day.Count
+	}
+
+	// RSVPs per day
+	cursor, err = r.rsvpEvents.Aggregate(ctx, []bson.M{
+		{"$match": match},
+		{"$group": bson.M{"_id": dateOf, "count": bson.M{"$sum": 1}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to aggregate daily RSVPs: %w", err)
+	}
+	var rsvps []struct {
+		Date  string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rsvps); err != nil {
+		return fmt.Errorf("failed to decode daily RSVPs: %w", err)
+	}
+	for _, day := range rsvps {
+		bucketFor(day.Date).RSVPs = day.Count
+	}
+
+	now := time.Now()
+	dates := make([]string, 0, len(buckets))
+	for date, bucket := range buckets {
+		bucket.UpdatedAt = now
+		_, err := r.dailyAnalytics.ReplaceOne(ctx,
+			bson.M{"wedding_id": weddingID, "date": date},
+			bucket,
+			options.Replace().SetUpsert(true))
+		if err != nil {
+			return fmt.Errorf("failed to save daily analytics: %w", err)
+		}
+		dates = append(dates, date)
+	}
+
+	// Days without any retained event have nothing left to count
+	_, err = r.dailyAnalytics.DeleteMany(ctx, bson.M{
+		"wedding_id": weddingID,
+		"date":       bson.M{"$gte": analyticsDate(since), "$nin": dates},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete stale daily analytics: %w", err)
+	}
+
+	return nil
+}
+
+// ListWeddingsToReconcile returns weddings whose counters changed since their
+// last reconciliation, in ID order starting after the given ID
+func (r *analyticsRepository) ListWeddingsToReconcile(ctx context.Context, after models.ID, limit int) ([]models.ID, error) {
+	query := bson.M{"$or": []bson.M{
+		{"reconciled_at": bson.M{"$exists": false}},
+		{"$expr": bson.M{"$gt": bson.A{"$last_updated", "$reconciled_at"}}},
+	}}
+	if !after.IsZero() {
+		query["_id"] = bson.M{"$gt": after}
+	}
+	opts := options.Find().SetProjection(bson.M{"_id": 1}).SetSort(bson.D{{Key: "_id", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.weddingAnalytics.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find weddings to reconcile: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		ID models.ID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode weddings to reconcile: %w", err)
+	}
+
+	ids := make([]models.ID, len(results))
+	for i, result := range results {
+		ids[i] = result.ID
+	}
+	return ids, nil
+}
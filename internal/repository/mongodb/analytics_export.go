@@ -0,0 +1,70 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// StreamPageViews calls fn for each page view of the wedding tracked in [from, to), oldest first
+func (r *analyticsRepository) StreamPageViews(ctx context.Context, weddingID models.ID, from, to time.Time, fn func(*models.PageView) error) error {
+	return streamEvents(ctx, r.pageViews, weddingID, from, to, func(cursor *mongo.Cursor) error {
+		var pageView models.PageView
+		if err := cursor.Decode(&pageView); err != nil {
+			return fmt.Errorf("failed to decode page view: %w", err)
+		}
+		return fn(&pageView)
+	})
+}
+
+// StreamRSVPEvents calls fn for each RSVP event of the wedding tracked in [from, to), oldest first
+func (r *analyticsRepository) StreamRSVPEvents(ctx context.Context, weddingID models.ID, from, to time.Time, fn func(*models.RSVPAnalytics) error) error {
+	return streamEvents(ctx, r.rsvpEvents, weddingID, from, to, func(cursor *mongo.Cursor) error {
+		var event models.RSVPAnalytics
+		if err := cursor.Decode(&event); err != nil {
+			return fmt.Errorf("failed to decode RSVP event: %w", err)
+		}
+		return fn(&event)
+	})
+}
+
+// StreamConversions calls fn for each conversion event of the wedding tracked in [from, to), oldest first
+func (r *analyticsRepository) StreamConversions(ctx context.Context, weddingID models.ID, from, to time.Time, fn func(*models.ConversionEvent) error) error {
+	return streamEvents(ctx, r.conversions, weddingID, from, to, func(cursor *mongo.Cursor) error {
+		var event models.ConversionEvent
+		if err := cursor.Decode(&event); err != nil {
+			return fmt.Errorf("failed to decode conversion event: %w", err)
+		}
+		return fn(&event)
+	})
+}
+
+// streamEvents walks a wedding's raw events of one collection in timestamp
+// order without loading them all into memory
+func streamEvents(ctx context.Context, collection *mongo.Collection, weddingID models.ID, from, to time.Time, decode func(*mongo.Cursor) error) error {
+	opts := options.Find().
+		SetBatchSize(streamBatchSize).
+		SetSort(bson.D{{Key: "timestamp", Value: 1}})
+
+	cursor, err := collection.Find(ctx, bson.M{
+		"wedding_id": weddingID,
+		"timestamp":  bson.M{"$gte": from, "$lt": to},
+	}, opts)
+	if err != nil {
+		return fmt.Errorf("failed to find %s: %w", collection.Name(), err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		if err := decode(cursor); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,122 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// GetRSVPFunnelSessions returns what each session of a wedding tracked in
+// [from, to) did in the RSVP flow, from its page views, RSVP events and the
+// rsvp_started and rsvp_completed conversions
+func (r *analyticsRepository) GetRSVPFunnelSessions(ctx context.Context, weddingID models.ID, from, to time.Time) ([]models.RSVPFunnelSession, error) {
+	match := bson.M{
+		"wedding_id": weddingID,
+		"session_id": bson.M{"$nin": bson.A{"", nil}},
+		"timestamp":  bson.M{"$gte": from, "$lt": to},
+	}
+	sessions := make(map[string]*models.RSVPFunnelSession)
+	session := func(id string) *models.RSVPFunnelSession {
+		if sessions[id] == nil {
+			sessions[id] = &models.RSVPFunnelSession{SessionID: id}
+		}
+		return sessions[id]
+	}
+
+	// Sessions are attributed to the device of their first view
+	var views []struct {
+		SessionID string `bson:"_id"`
+		Device    string `bson:"device"`
+	}
+	err := aggregateAll(ctx, r.pageViews, []bson.M{
+		{"$match": humanPageViews(match)},
+		{"$sort": bson.M{"timestamp": 1}},
+		{"$group": bson.M{"_id": "$session_id", "device": bson.M{"$first": "$device"}}},
+	}, &views)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate funnel page views: %w", err)
+	}
+	for _, view := range views {
+		s := session(view.SessionID)
+		s.ViewedInvitation = true
+		s.Device = view.Device
+	}
+
+	var rsvpEvents []struct {
+		SessionID string   `bson:"_id"`
+		Device    string   `bson:"device"`
+		Abandoned []string `bson:"abandoned"`
+		Submitted bool     `bson:"submitted"`
+	}
+	err = aggregateAll(ctx, r.rsvpEvents, []bson.M{
+		{"$match": match},
+		{"$sort": bson.M{"timestamp": 1}},
+		{"$group": bson.M{
+			"_id":       "$session_id",
+			"device":    bson.M{"$first": "$device"},
+			"abandoned": bson.M{"$addToSet": "$abandoned_step"},
+			"submitted": bson.M{"$max": bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$abandoned_step", ""}}, ""}}},
+		}},
+	}, &rsvpEvents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate funnel RSVP events: %w", err)
+	}
+	for _, event := range rsvpEvents {
+		s := session(event.SessionID)
+		if s.Device == "" {
+			s.Device = event.Device
+		}
+		for _, step := range event.Abandoned {
+			if step != "" {
+				s.AbandonedSteps = append(s.AbandonedSteps, step)
+			}
+		}
+		s.Completed = s.Completed || event.Submitted
+	}
+
+	var conversions []struct {
+		SessionID string `bson:"_id"`
+		Started   bool   `bson:"started"`
+		Completed bool   `bson:"completed"`
+	}
+	conversionMatch := bson.M{"event": bson.M{"$in": bson.A{"rsvp_started", "rsvp_completed"}}}
+	for key, value := range match {
+		conversionMatch[key] = value
+	}
+	err = aggregateAll(ctx, r.conversions, []bson.M{
+		{"$match": conversionMatch},
+		{"$group": bson.M{
+			"_id":       "$session_id",
+			"started":   bson.M{"$max": bson.M{"$eq": bson.A{"$event", "rsvp_started"}}},
+			"completed": bson.M{"$max": bson.M{"$eq": bson.A{"$event", "rsvp_completed"}}},
+		}},
+	}, &conversions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate funnel conversions: %w", err)
+	}
+	for _, conversion := range conversions {
+		s := session(conversion.SessionID)
+		s.StartedRSVP = s.StartedRSVP || conversion.Started
+		s.Completed = s.Completed || conversion.Completed
+	}
+
+	result := make([]models.RSVPFunnelSession, 0, len(sessions))
+	for _, s := range sessions {
+		result = append(result, *s)
+	}
+	return result, nil
+}
+
+// aggregateAll runs a pipeline on a collection and decodes every result into results
+func aggregateAll(ctx context.Context, collection *mongo.Collection, pipeline []bson.M, results interface{}) error {
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	return cursor.All(ctx, results)
+}
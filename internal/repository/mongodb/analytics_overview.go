@@ -0,0 +1,122 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// analyticsBenchmarksID is the _id of the platform benchmarks document
+const analyticsBenchmarksID = "platform"
+
+// ListWeddingAnalytics returns the aggregated counters of the weddings, leaving
+// out those that never tracked an event. Unlike GetWeddingAnalytics it reads
+// no daily buckets nor wishes.
+func (r *analyticsRepository) ListWeddingAnalytics(ctx context.Context, weddingIDs []models.ID) ([]*models.WeddingAnalytics, error) {
+	if len(weddingIDs) == 0 {
+		return nil, nil
+	}
+	cursor, err := r.weddingAnalytics.Find(ctx, bson.M{"_id": bson.M{"$in": weddingIDs}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wedding analytics: %w", err)
+	}
+	var results []*models.WeddingAnalytics
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode wedding analytics: %w", err)
+	}
+	for _, analytics := range results {
+		setAnalyticsRates(analytics)
+	}
+	return results, nil
+}
+
+// GetAnalyticsBenchmarks returns the last computed platform benchmarks
+func (r *analyticsRepository) GetAnalyticsBenchmarks(ctx context.Context) (*models.AnalyticsBenchmarks, error) {
+	var benchmarks models.AnalyticsBenchmarks
+	err := r.db.Collection("analytics_benchmarks").FindOne(ctx, bson.M{"_id": analyticsBenchmarksID}).Decode(&benchmarks)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get analytics benchmarks: %w", err)
+	}
+	return &benchmarks, nil
+}
+
+// UpdateAnalyticsBenchmarks recomputes the platform benchmarks from the
+// counters of every wedding with at least minPageViews page views
+func (r *analyticsRepository) UpdateAnalyticsBenchmarks(ctx context.Context, minPageViews int64) (*models.AnalyticsBenchmarks, error) {
+	opts := options.Find().SetProjection(bson.M{
+		"page_views":       1,
+		"unique_sessions":  1,
+		"rsvp_count":       1,
+		"bounced_sessions": 1,
+		"total_duration":   1,
+		"timed_page_views": 1,
+	})
+	cursor, err := r.weddingAnalytics.Find(ctx, bson.M{"page_views": bson.M{"$gte": minPageViews}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wedding analytics: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	values := make(map[string][]float64)
+	for cursor.Next(ctx) {
+		var analytics models.WeddingAnalytics
+		if err := cursor.Decode(&analytics); err != nil {
+			return nil, fmt.Errorf("failed to decode wedding analytics: %w", err)
+		}
+		setAnalyticsRates(&analytics)
+		values[models.BenchmarkPageViews] = append(values[models.BenchmarkPageViews], float64(analytics.PageViews))
+		values[models.BenchmarkConversionRate] = append(values[models.BenchmarkConversionRate], analytics.ConversionRate)
+		if analytics.UniqueSessions > 0 {
+			values[models.BenchmarkBounceRate] = append(values[models.BenchmarkBounceRate], analytics.BounceRate)
+		}
+		if analytics.TimedPageViews > 0 {
+			values[models.BenchmarkAverageTimeOnPage] = append(values[models.BenchmarkAverageTimeOnPage], analytics.AverageTimeOnPage)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list wedding analytics: %w", err)
+	}
+
+	benchmarks := &models.AnalyticsBenchmarks{
+		Weddings:   int64(len(values[models.BenchmarkPageViews])),
+		Metrics:    make(map[string]models.BenchmarkStat, len(values)),
+		ComputedAt: time.Now(),
+	}
+	for metric, metricValues := range values {
+		benchmarks.Metrics[metric] = benchmarkStat(metricValues)
+	}
+
+	_, err = r.db.Collection("analytics_benchmarks").ReplaceOne(ctx,
+		bson.M{"_id": analyticsBenchmarksID}, benchmarks, options.Replace().SetUpsert(true))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save analytics benchmarks: %w", err)
+	}
+	return benchmarks, nil
+}
+
+// benchmarkStat returns the average and median of values, which it sorts
+func benchmarkStat(values []float64) models.BenchmarkStat {
+	sort.Float64s(values)
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	stat := models.BenchmarkStat{Average: sum / float64(len(values))}
+	if mid := len(values) / 2; len(values)%2 == 1 {
+		stat.Median = values[mid]
+	} else {
+		stat.Median = (values[mid-1] + values[mid]) / 2
+	}
+	return stat
+}
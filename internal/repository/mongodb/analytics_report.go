@@ -0,0 +1,99 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure analyticsReportRepository implements the domain repository interface
+var _ repository.AnalyticsReportRepository = (*analyticsReportRepository)(nil)
+
+type analyticsReportRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAnalyticsReportRepository creates a new MongoDB analytics report settings repository
+func NewAnalyticsReportRepository(db *mongo.Database) repository.AnalyticsReportRepository {
+	return &analyticsReportRepository{
+		collection: db.Collection("analytics_report_settings"),
+	}
+}
+
+// GetByWedding retrieves the digest settings of a wedding
+func (r *analyticsReportRepository) GetByWedding(ctx context.Context, weddingID models.ID) (*models.AnalyticsReportSettings, error) {
+	var settings models.AnalyticsReportSettings
+	err := r.collection.FindOne(ctx, bson.M{"wedding_id": weddingID}).Decode(&settings)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get analytics report settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// Upsert creates or replaces the digest settings of a wedding
+func (r *analyticsReportRepository) Upsert(ctx context.Context, settings *models.AnalyticsReportSettings) error {
+	if settings.ID.IsZero() {
+		settings.ID = models.NewID()
+	}
+	now := time.Now()
+	if settings.CreatedAt.IsZero() {
+		settings.CreatedAt = now
+	}
+	settings.UpdatedAt = now
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := r.collection.ReplaceOne(ctx, bson.M{"wedding_id": settings.WeddingID}, settings, opts); err != nil {
+		return fmt.Errorf("failed to save analytics report settings: %w", err)
+	}
+	return nil
+}
+
+// ListDue retrieves enabled settings whose next send time has passed
+func (r *analyticsReportRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.AnalyticsReportSettings, error) {
+	filter := bson.M{
+		"enabled":      true,
+		"next_send_at": bson.M{"$lte": now},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "next_send_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due analytics reports: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var settings []*models.AnalyticsReportSettings
+	if err := cursor.All(ctx, &settings); err != nil {
+		return nil, fmt.Errorf("failed to decode analytics report settings: %w", err)
+	}
+	return settings, nil
+}
+
+// MarkSent records a send attempt and schedules the next one
+func (r *analyticsReportRepository) MarkSent(ctx context.Context, id models.ID, sentAt, nextSendAt time.Time, reason string) error {
+	set := bson.M{
+		"next_send_at": nextSendAt,
+		"last_error":   reason,
+		"updated_at":   time.Now(),
+	}
+	if reason == "" {
+		set["last_sent_at"] = sentAt
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set}); err != nil {
+		return fmt.Errorf("failed to mark analytics report sent: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure apiKeyRepository implements the domain repository interface
+var _ repository.APIKeyRepository = (*apiKeyRepository)(nil)
+
+type apiKeyRepository struct {
+	keys  *mongo.Collection
+	usage *mongo.Collection
+}
+
+// NewAPIKeyRepository creates a new MongoDB API key repository
+func NewAPIKeyRepository(db *mongo.Database) repository.APIKeyRepository {
+	return &apiKeyRepository{
+		keys:  db.Collection("api_keys"),
+		usage: db.Collection("api_key_usage"),
+	}
+}
+
+// Create inserts a new API key
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	if key.ID.IsZero() {
+		key.ID = models.NewID()
+	}
+	now := time.Now()
+	key.CreatedAt = now
+	key.UpdatedAt = now
+
+	if _, err := r.keys.InsertOne(ctx, key); err != nil {
+		return fmt.Errorf("failed to insert API key: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an API key by ID
+func (r *apiKeyRepository) GetByID(ctx context.Context, id models.ID) (*models.APIKey, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+// GetByHash retrieves an API key by the hash of its value
+func (r *apiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	return r.findOne(ctx, bson.M{"key_hash": keyHash})
+}
+
+func (r *apiKeyRepository) findOne(ctx context.Context, filter bson.M) (*models.APIKey, error) {
+	var key models.APIKey
+	err := r.keys.FindOne(ctx, filter).Decode(&key)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return &key, nil
+}
+
+// ListByUser retrieves all API keys of a user, newest first
+func (r *apiKeyRepository) ListByUser(ctx context.Context, userID models.ID) ([]*models.APIKey, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.keys.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var keys []*models.APIKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, fmt.Errorf("failed to decode API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Update updates the settings of an API key, leaving its usage counters alone
+func (r *apiKeyRepository) Update(ctx context.Context, key *models.APIKey) error {
+	key.UpdatedAt = time.Now()
+
+	result, err := r.keys.UpdateOne(ctx, bson.M{"_id": key.ID}, bson.M{"$set": bson.M{
+		"name":       key.Name,
+		"scopes":     key.Scopes,
+		"rate_limit": key.RateLimit,
+		"expires_at": key.ExpiresAt,
+		"revoked_at": key.RevokedAt,
+		"updated_at": key.UpdatedAt,
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to update API key: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// RecordUsage counts a request on the key and in its daily usage bucket
+func (r *apiKeyRepository) RecordUsage(ctx context.Context, keyID models.ID, at time.Time, failed, rateLimited bool) error {
+	inc := bson.M{"requests": 1}
+	if failed {
+		inc["errors"] = 1
+	}
+	if rateLimited {
+		inc["rate_limited"] = 1
+	}
+
+	_, err := r.usage.UpdateOne(ctx,
+		bson.M{"key_id": keyID, "date": at.UTC().Format("2006-01-02")},
+		bson.M{"$inc": inc, "$set": bson.M{"updated_at": at}},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to update API key usage: %w", err)
+	}
+
+	_, err = r.keys.UpdateOne(ctx, bson.M{"_id": keyID}, bson.M{
+		"$inc": bson.M{"request_count": 1},
+		"$set": bson.M{"last_used_at": at},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update API key: %w", err)
+	}
+	return nil
+}
+
+// ListUsage retrieves the daily usage of a key from the given date, oldest first
+func (r *apiKeyRepository) ListUsage(ctx context.Context, keyID models.ID, from string) ([]*models.APIKeyUsage, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+	cursor, err := r.usage.Find(ctx, bson.M{"key_id": keyID, "date": bson.M{"$gte": from}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API key usage: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var usage []*models.APIKeyUsage
+	if err := cursor.All(ctx, &usage); err != nil {
+		return nil, fmt.Errorf("failed to decode API key usage: %w", err)
+	}
+	return usage, nil
+}
@@ -0,0 +1,96 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure auditLogRepository implements the domain repository interface
+var _ repository.AuditLogRepository = (*auditLogRepository)(nil)
+
+type auditLogRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAuditLogRepository creates a new MongoDB audit log repository
+func NewAuditLogRepository(db *mongo.Database) repository.AuditLogRepository {
+	return &auditLogRepository{
+		collection: db.Collection("audit_logs"),
+	}
+}
+
+// Create stores a new audit log entry
+func (r *auditLogRepository) Create(ctx context.Context, entry *models.AuditLog) error {
+	if entry.ID.IsZero() {
+		entry.ID = models.NewID()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	if _, err := r.collection.InsertOne(ctx, entry); err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+	return nil
+}
+
+// List lists the audit log entries matching the filters, newest first
+func (r *auditLogRepository) List(ctx context.Context, filters repository.AuditLogFilters, page, pageSize int) ([]*models.AuditLog, int64, error) {
+	filter := bson.M{}
+	if filters.WeddingID != nil {
+		filter["wedding_id"] = *filters.WeddingID
+	}
+	if filters.ActorID != nil {
+		filter["actor_id"] = *filters.ActorID
+	}
+	if filters.Action != "" {
+		filter["action"] = filters.Action
+	}
+	if filters.TargetType != "" {
+		filter["target_type"] = filters.TargetType
+	}
+	if filters.TargetID != "" {
+		filter["target_id"] = filters.TargetID
+	}
+	if filters.From != nil || filters.To != nil {
+		createdAt := bson.M{}
+		if filters.From != nil {
+			createdAt["$gte"] = *filters.From
+		}
+		if filters.To != nil {
+			createdAt["$lte"] = *filters.To
+		}
+		filter["created_at"] = createdAt
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	skip := (page - 1) * pageSize
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := []*models.AuditLog{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode audit logs: %w", err)
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,221 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure budgetRepository implements the domain repository interface
+var _ repository.BudgetRepository = (*budgetRepository)(nil)
+
+type budgetRepository struct {
+	categories *mongo.Collection
+	payments   *mongo.Collection
+}
+
+// NewBudgetRepository creates a new MongoDB wedding budget repository
+func NewBudgetRepository(db *mongo.Database) repository.BudgetRepository {
+	return &budgetRepository{
+		categories: db.Collection("budget_categories"),
+		payments:   db.Collection("budget_payments"),
+	}
+}
+
+// CreateCategory adds a category to a wedding's budget
+func (r *budgetRepository) CreateCategory(ctx context.Context, category *models.BudgetCategory) error {
+	if category.ID.IsZero() {
+		category.ID = models.NewID()
+	}
+	now := time.Now()
+	category.CreatedAt = now
+	category.UpdatedAt = now
+
+	if _, err := r.categories.InsertOne(ctx, category); err != nil {
+		return fmt.Errorf("failed to create budget category: %w", err)
+	}
+	return nil
+}
+
+// GetCategory retrieves a budget category by ID
+func (r *budgetRepository) GetCategory(ctx context.Context, id models.ID) (*models.BudgetCategory, error) {
+	var category models.BudgetCategory
+	if err := r.categories.FindOne(ctx, bson.M{"_id": id}).Decode(&category); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get budget category: %w", err)
+	}
+	return &category, nil
+}
+
+// ListCategories retrieves the budget categories of a wedding in display order
+func (r *budgetRepository) ListCategories(ctx context.Context, weddingID models.ID) ([]*models.BudgetCategory, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}, {Key: "created_at", Value: 1}})
+	cursor, err := r.categories.Find(ctx, bson.M{"wedding_id": weddingID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budget categories: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	categories := []*models.BudgetCategory{}
+	if err := cursor.All(ctx, &categories); err != nil {
+		return nil, fmt.Errorf("failed to decode budget categories: %w", err)
+	}
+	return categories, nil
+}
+
+// UpdateCategory replaces a budget category
+func (r *budgetRepository) UpdateCategory(ctx context.Context, category *models.BudgetCategory) error {
+	category.UpdatedAt = time.Now()
+
+	result, err := r.categories.ReplaceOne(ctx, bson.M{"_id": category.ID}, category)
+	if err != nil {
+		return fmt.Errorf("failed to update budget category: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteCategory removes a budget category
+func (r *budgetRepository) DeleteCategory(ctx context.Context, id models.ID) error {
+	result, err := r.categories.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete budget category: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// CreatePayment records a payment to a vendor
+func (r *budgetRepository) CreatePayment(ctx context.Context, payment *models.BudgetPayment) error {
+	if payment.ID.IsZero() {
+		payment.ID = models.NewID()
+	}
+	now := time.Now()
+	payment.CreatedAt = now
+	payment.UpdatedAt = now
+
+	if _, err := r.payments.InsertOne(ctx, payment); err != nil {
+		return fmt.Errorf("failed to create budget payment: %w", err)
+	}
+	return nil
+}
+
+// GetPayment retrieves a budget payment by ID
+func (r *budgetRepository) GetPayment(ctx context.Context, id models.ID) (*models.BudgetPayment, error) {
+	var payment models.BudgetPayment
+	if err := r.payments.FindOne(ctx, bson.M{"_id": id}).Decode(&payment); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get budget payment: %w", err)
+	}
+	return &payment, nil
+}
+
+// ListPayments retrieves the payments of a wedding by due date, undated ones last
+func (r *budgetRepository) ListPayments(ctx context.Context, weddingID models.ID, filters repository.BudgetPaymentFilters) ([]*models.BudgetPayment, error) {
+	filter := bson.M{"wedding_id": weddingID}
+	if filters.CategoryID != nil {
+		filter["category_id"] = *filters.CategoryID
+	}
+	if filters.Status != "" {
+		filter["status"] = filters.Status
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}, {Key: "created_at", Value: 1}})
+	cursor, err := r.payments.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budget payments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var payments []*models.BudgetPayment
+	if err := cursor.All(ctx, &payments); err != nil {
+		return nil, fmt.Errorf("failed to decode budget payments: %w", err)
+	}
+
+	// MongoDB sorts missing due dates first
+	dated := make([]*models.BudgetPayment, 0, len(payments))
+	var undated []*models.BudgetPayment
+	for _, payment := range payments {
+		if payment.DueDate == nil {
+			undated = append(undated, payment)
+		} else {
+			dated = append(dated, payment)
+		}
+	}
+	return append(dated, undated...), nil
+}
+
+// UpdatePayment replaces a budget payment
+func (r *budgetRepository) UpdatePayment(ctx context.Context, payment *models.BudgetPayment) error {
+	payment.UpdatedAt = time.Now()
+
+	result, err := r.payments.ReplaceOne(ctx, bson.M{"_id": payment.ID}, payment)
+	if err != nil {
+		return fmt.Errorf("failed to update budget payment: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// DeletePayment removes a budget payment
+func (r *budgetRepository) DeletePayment(ctx context.Context, id models.ID) error {
+	result, err := r.payments.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete budget payment: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// ClaimDueReminder marks the payment reminder that has been due the longest as sent
+func (r *budgetRepository) ClaimDueReminder(ctx context.Context, now time.Time) (*models.BudgetPayment, error) {
+	filter := bson.M{
+		"status":      models.BudgetPaymentPending,
+		"remind_at":   bson.M{"$lte": now},
+		"reminded_at": bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{"reminded_at": now}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "remind_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var payment models.BudgetPayment
+	if err := r.payments.FindOneAndUpdate(ctx, filter, update, opts).Decode(&payment); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to claim payment reminder: %w", err)
+	}
+	return &payment, nil
+}
+
+// RescheduleReminder makes a claimed payment reminder due again
+func (r *budgetRepository) RescheduleReminder(ctx context.Context, id models.ID, remindAt time.Time) error {
+	update := bson.M{
+		"$set":   bson.M{"remind_at": remindAt},
+		"$unset": bson.M{"reminded_at": ""},
+	}
+	if _, err := r.payments.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		return fmt.Errorf("failed to reschedule payment reminder: %w", err)
+	}
+	return nil
+}
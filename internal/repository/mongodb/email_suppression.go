@@ -0,0 +1,111 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure emailSuppressionRepository implements the domain repository interface
+var _ repository.EmailSuppressionRepository = (*emailSuppressionRepository)(nil)
+
+type emailSuppressionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewEmailSuppressionRepository creates a new MongoDB email suppression repository
+func NewEmailSuppressionRepository(db *mongo.Database) repository.EmailSuppressionRepository {
+	return &emailSuppressionRepository{
+		collection: db.Collection("email_suppressions"),
+	}
+}
+
+// Upsert suppresses the address, replacing the reason of an earlier suppression
+func (r *emailSuppressionRepository) Upsert(ctx context.Context, suppression *models.EmailSuppression) error {
+	suppression.Email = strings.ToLower(strings.TrimSpace(suppression.Email))
+	if suppression.CreatedAt.IsZero() {
+		suppression.CreatedAt = time.Now()
+	}
+
+	var stored models.EmailSuppression
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"email": suppression.Email},
+		bson.M{
+			"$set": bson.M{
+				"reason":     suppression.Reason,
+				"detail":     suppression.Detail,
+				"created_at": suppression.CreatedAt,
+			},
+			"$setOnInsert": bson.M{"_id": models.NewID()},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&stored)
+	if err != nil {
+		return fmt.Errorf("failed to suppress email: %w", err)
+	}
+	suppression.ID = stored.ID
+	return nil
+}
+
+// Exists reports whether the address is suppressed
+func (r *emailSuppressionRepository) Exists(ctx context.Context, email string) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx,
+		bson.M{"email": strings.ToLower(strings.TrimSpace(email))},
+		options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to check email suppression: %w", err)
+	}
+	return count > 0, nil
+}
+
+// List lists the suppressions, newest first
+func (r *emailSuppressionRepository) List(ctx context.Context, search string, page, pageSize int) ([]*models.EmailSuppression, int64, error) {
+	filter := bson.M{}
+	if search != "" {
+		filter["email"] = bson.M{"$regex": regexp.QuoteMeta(strings.ToLower(search))}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count email suppressions: %w", err)
+	}
+
+	skip := (page - 1) * pageSize
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list email suppressions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	suppressions := []*models.EmailSuppression{}
+	if err := cursor.All(ctx, &suppressions); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode email suppressions: %w", err)
+	}
+	return suppressions, total, nil
+}
+
+// Delete lifts the suppression of the address
+func (r *emailSuppressionRepository) Delete(ctx context.Context, email string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"email": strings.ToLower(strings.TrimSpace(email))})
+	if err != nil {
+		return fmt.Errorf("failed to delete email suppression: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
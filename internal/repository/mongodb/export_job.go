@@ -0,0 +1,117 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure exportJobRepository implements the domain repository interface
+var _ repository.ExportJobRepository = (*exportJobRepository)(nil)
+
+type exportJobRepository struct {
+	collection *mongo.Collection
+}
+
+// NewExportJobRepository creates a new MongoDB-backed export job history
+func NewExportJobRepository(db *mongo.Database) repository.ExportJobRepository {
+	return &exportJobRepository{
+		collection: db.Collection("export_jobs"),
+	}
+}
+
+// Create inserts a new export job, running unless queued for the background
+func (r *exportJobRepository) Create(ctx context.Context, job *models.ExportJob) error {
+	if job.ID.IsZero() {
+		job.ID = models.NewID()
+	}
+	if job.Status != models.ExportJobQueued {
+		job.Status = models.ExportJobRunning
+	}
+	job.CreatedAt = time.Now()
+
+	if _, err := r.collection.InsertOne(ctx, job); err != nil {
+		return fmt.Errorf("failed to create export job: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an export job
+func (r *exportJobRepository) GetByID(ctx context.Context, id models.ID) (*models.ExportJob, error) {
+	var job models.ExportJob
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&job); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get export job: %w", err)
+	}
+	return &job, nil
+}
+
+// SetFile records the storage key of an export generated in the background
+func (r *exportJobRepository) SetFile(ctx context.Context, id models.ID, fileKey string) error {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"file_key": fileKey}})
+	if err != nil {
+		return fmt.Errorf("failed to set export job file: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Finish records the final status and record count of an export job
+func (r *exportJobRepository) Finish(ctx context.Context, id models.ID, status models.ExportJobStatus, records int, reason string) error {
+	fields := bson.M{
+		"status":       status,
+		"records":      records,
+		"completed_at": time.Now(),
+	}
+	if reason != "" {
+		fields["error"] = reason
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": fields})
+	if err != nil {
+		return fmt.Errorf("failed to finish export job: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// ListByWedding retrieves the export history of a wedding, newest first
+func (r *exportJobRepository) ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int) ([]*models.ExportJob, int64, error) {
+	filter := bson.M{"wedding_id": weddingID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count export jobs: %w", err)
+	}
+
+	skip := (page - 1) * pageSize
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list export jobs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []*models.ExportJob
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode export jobs: %w", err)
+	}
+	return jobs, total, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -30,12 +31,14 @@ func NewGuestRepository(db *mongo.Database) repository.GuestRepository {
 func (r *GuestRepository) Create(ctx context.Context, guest *models.Guest) error {
 	// Generate ID if not set
 	if guest.ID.IsZero() {
-		guest.ID = primitive.NewObjectID()
+		guest.ID = models.NewID()
 	}
 
 	now := time.Now()
 	guest.CreatedAt = now
 	guest.UpdatedAt = now
+	guest.SearchKeys = models.GuestSearchKeys(guest)
+	guest.PhoneKey = models.GuestPhoneKey(guest.Phone)
 
 	_, err := r.collection.InsertOne(ctx, guest)
 	if err != nil {
@@ -46,12 +49,12 @@ func (r *GuestRepository) Create(ctx context.Context, guest *models.Guest) error
 }
 
 // GetByID retrieves a guest by ID
-func (r *GuestRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Guest, error) {
+func (r *GuestRepository) GetByID(ctx context.Context, id models.ID) (*models.Guest, error) {
 	var guest models.Guest
 	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&guest)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("guest not found")
+			return nil, fmt.Errorf("guest not found: %w", repository.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get guest: %w", err)
 	}
@@ -59,7 +62,7 @@ func (r *GuestRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*
 }
 
 // GetByEmail retrieves a guest by email within a wedding
-func (r *GuestRepository) GetByEmail(ctx context.Context, weddingID primitive.ObjectID, email string) (*models.Guest, error) {
+func (r *GuestRepository) GetByEmail(ctx context.Context, weddingID models.ID, email string) (*models.Guest, error) {
 	var guest models.Guest
 	err := r.collection.FindOne(ctx, bson.M{
 		"wedding_id": weddingID,
@@ -74,6 +77,23 @@ func (r *GuestRepository) GetByEmail(ctx context.Context, weddingID primitive.Ob
 	return &guest, nil
 }
 
+// GetByPhone retrieves the oldest guest of the wedding whose phone number has the
+// key, using the wedding_id and phone_key index
+func (r *GuestRepository) GetByPhone(ctx context.Context, weddingID models.ID, phoneKey string) (*models.Guest, error) {
+	var guest models.Guest
+	err := r.collection.FindOne(ctx, bson.M{
+		"wedding_id": weddingID,
+		"phone_key":  phoneKey,
+	}, options.FindOne().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})).Decode(&guest)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get guest by phone: %w", err)
+	}
+	return &guest, nil
+}
+
 // CreateMany creates multiple guests in a single operation
 func (r *GuestRepository) CreateMany(ctx context.Context, guests []*models.Guest) error {
 	if len(guests) == 0 {
@@ -86,10 +106,12 @@ func (r *GuestRepository) CreateMany(ctx context.Context, guests []*models.Guest
 	for _, guest := range guests {
 		// Generate ID if not set
 		if guest.ID.IsZero() {
-			guest.ID = primitive.NewObjectID()
+			guest.ID = models.NewID()
 		}
 		guest.CreatedAt = now
 		guest.UpdatedAt = now
+		guest.SearchKeys = models.GuestSearchKeys(guest)
+		guest.PhoneKey = models.GuestPhoneKey(guest.Phone)
 		docs = append(docs, guest)
 	}
 
@@ -102,7 +124,7 @@ func (r *GuestRepository) CreateMany(ctx context.Context, guests []*models.Guest
 }
 
 // ListByWedding retrieves guests by wedding ID with pagination
-func (r *GuestRepository) ListByWedding(ctx context.Context, weddingID primitive.ObjectID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error) {
+func (r *GuestRepository) ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error) {
 	// Calculate offset from page
 	offset := 0
 	if page > 0 {
@@ -150,8 +172,13 @@ func (r *GuestRepository) ListByWedding(ctx context.Context, weddingID primitive
 // Update updates an existing guest
 func (r *GuestRepository) Update(ctx context.Context, guest *models.Guest) error {
 	guest.UpdatedAt = time.Now()
+	guest.SearchKeys = models.GuestSearchKeys(guest)
+	guest.PhoneKey = models.GuestPhoneKey(guest.Phone)
 
 	update := bson.M{"$set": guest}
+	if guest.PhoneKey == "" {
+		update["$unset"] = bson.M{"phone_key": ""}
+	}
 	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": guest.ID}, update)
 	if err != nil {
 		return fmt.Errorf("failed to update guest: %w", err)
@@ -165,7 +192,7 @@ func (r *GuestRepository) Update(ctx context.Context, guest *models.Guest) error
 }
 
 // Delete deletes a guest
-func (r *GuestRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+func (r *GuestRepository) Delete(ctx context.Context, id models.ID) error {
 	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
 	if err != nil {
 		return fmt.Errorf("failed to delete guest: %w", err)
@@ -191,6 +218,8 @@ func (r *GuestRepository) ImportBatch(ctx context.Context, guests []*models.Gues
 		guest.CreatedAt = now
 		guest.UpdatedAt = now
 		guest.ImportBatchID = batchID
+		guest.SearchKeys = models.GuestSearchKeys(guest)
+		guest.PhoneKey = models.GuestPhoneKey(guest.Phone)
 		docs = append(docs, guest)
 	}
 
@@ -203,7 +232,7 @@ func (r *GuestRepository) ImportBatch(ctx context.Context, guests []*models.Gues
 }
 
 // GetByImportBatch retrieves guests by wedding ID and batch ID
-func (r *GuestRepository) GetByImportBatch(ctx context.Context, weddingID primitive.ObjectID, batchID string) ([]*models.Guest, error) {
+func (r *GuestRepository) GetByImportBatch(ctx context.Context, weddingID models.ID, batchID string) ([]*models.Guest, error) {
 	filter := bson.M{
 		"wedding_id":      weddingID,
 		"import_batch_id": batchID,
@@ -227,6 +256,340 @@ func (r *GuestRepository) GetByImportBatch(ctx context.Context, weddingID primit
 	return guests, nil
 }
 
+// Search returns the guests of the wedding with a search key starting with each
+// prefix, using the wedding_id and search_keys index. Guests without keys, saved
+// before they were kept, are returned too.
+func (r *GuestRepository) Search(ctx context.Context, weddingID models.ID, prefixes []string) ([]*models.Guest, error) {
+	matches := make([]interface{}, len(prefixes))
+	for i, prefix := range prefixes {
+		matches[i] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(prefix)}
+	}
+	filter := bson.M{
+		"wedding_id": weddingID,
+		"$or": []bson.M{
+			{"search_keys": bson.M{"$all": matches}},
+			{"search_keys": bson.M{"$exists": false}},
+		},
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search guests: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var guests []*models.Guest
+	if err := cursor.All(ctx, &guests); err != nil {
+		return nil, fmt.Errorf("failed to decode guests: %w", err)
+	}
+	return guests, nil
+}
+
+// StreamByWedding calls fn for each guest of the wedding matching filters, oldest first
+func (r *GuestRepository) StreamByWedding(ctx context.Context, weddingID models.ID, filters repository.GuestFilters, fn func(*models.Guest) error) error {
+	opts := options.Find().
+		SetBatchSize(streamBatchSize).
+		SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	filter := r.buildFilters(bson.M{"wedding_id": weddingID}, filters)
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return fmt.Errorf("failed to stream guests: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var guest models.Guest
+		if err := cursor.Decode(&guest); err != nil {
+			return fmt.Errorf("failed to decode guest: %w", err)
+		}
+		if err := fn(&guest); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("failed to stream guests: %w", err)
+	}
+	return nil
+}
+
+// UpdateInvitationStatus records the outcome of sending a guest's invitation
+func (r *GuestRepository) UpdateInvitationStatus(ctx context.Context, id models.ID, status string, sentAt *time.Time, reason string) error {
+	set := bson.M{
+		"invitation_status": status,
+		"invitation_error":  reason,
+		"updated_at":        time.Now(),
+	}
+	if sentAt != nil {
+		set["invitation_sent_at"] = *sentAt
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set})
+	if err != nil {
+		return fmt.Errorf("failed to update invitation status: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// MarkInvitationSent records a sent invitation and the provider message its delivery is reported under
+func (r *GuestRepository) MarkInvitationSent(ctx context.Context, id models.ID, channel, messageID string, sentAt time.Time) error {
+	update := bson.M{
+		"$set": bson.M{
+			"invitation_status":  models.InvitationStatusSent,
+			"invitation_error":   "",
+			"invitation_sent_at": sentAt,
+			"invitation_channel": channel,
+			"updated_at":         time.Now(),
+		},
+	}
+	if messageID != "" {
+		update["$set"].(bson.M)["invitation_message_id"] = messageID
+	} else {
+		update["$unset"] = bson.M{"invitation_message_id": ""}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to mark invitation sent: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateInvitationStatusByMessage applies a provider delivery report to the invitation sent as messageID
+func (r *GuestRepository) UpdateInvitationStatusByMessage(ctx context.Context, messageID, status string, from []string, reason string) error {
+	filter := bson.M{
+		"invitation_message_id": messageID,
+		"invitation_status":     bson.M{"$in": from},
+	}
+	update := bson.M{"$set": bson.M{
+		"invitation_status": status,
+		"invitation_error":  reason,
+		"updated_at":        time.Now(),
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update invitation status: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// CheckIn records a guest's arrival unless the guest is already checked in
+func (r *GuestRepository) CheckIn(ctx context.Context, id models.ID, checkIn models.GuestCheckIn) error {
+	filter := bson.M{"_id": id, "check_in": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"check_in": checkIn, "updated_at": time.Now()}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to check in guest: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// CheckInStats counts the guests of a wedding and their arrivals in one pass
+func (r *GuestRepository) CheckInStats(ctx context.Context, weddingID models.ID) (*models.CheckInStats, error) {
+	attending := bson.M{"$eq": bson.A{"$rsvp_status", "attending"}}
+	checkedIn := bson.M{"$gt": bson.A{"$check_in", nil}}
+	count := func(cond interface{}) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{cond, 1, 0}}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"wedding_id": weddingID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":               nil,
+			"total_guests":      bson.M{"$sum": 1},
+			"expected_guests":   count(attending),
+			"checked_in":        count(checkedIn),
+			"expected_arrived":  count(bson.M{"$and": bson.A{attending, checkedIn}}),
+			"plus_ones_brought": bson.M{"$sum": "$check_in.plus_ones_brought"},
+			"last_check_in_at":  bson.M{"$max": "$check_in.arrived_at"},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate check-in stats: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		TotalGuests     int64      `bson:"total_guests"`
+		ExpectedGuests  int64      `bson:"expected_guests"`
+		CheckedIn       int64      `bson:"checked_in"`
+		ExpectedArrived int64      `bson:"expected_arrived"`
+		PlusOnesBrought int64      `bson:"plus_ones_brought"`
+		LastCheckInAt   *time.Time `bson:"last_check_in_at"`
+	}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode check-in stats: %w", err)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read check-in stats: %w", err)
+	}
+
+	return &models.CheckInStats{
+		TotalGuests:     result.TotalGuests,
+		ExpectedGuests:  result.ExpectedGuests,
+		CheckedIn:       result.CheckedIn,
+		ExpectedArrived: result.ExpectedArrived,
+		PlusOnesBrought: result.PlusOnesBrought,
+		Attendance:      result.CheckedIn + result.PlusOnesBrought,
+		LastCheckInAt:   result.LastCheckInAt,
+	}, nil
+}
+
+// AttendanceBreakdown counts the answers of a wedding's guests grouped by a guest field
+func (r *GuestRepository) AttendanceBreakdown(ctx context.Context, weddingID models.ID, field string) ([]models.AttendanceBreakdown, error) {
+	var key interface{}
+	switch field {
+	case models.GuestBreakdownSide, models.GuestBreakdownRelationship:
+		key = bson.M{"$ifNull": bson.A{"$" + field, ""}}
+	case models.GuestBreakdownVIP:
+		key = bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$vip", true}}, models.BreakdownVIP, models.BreakdownRegular}}
+	default:
+		return nil, fmt.Errorf("unknown guest breakdown field: %s", field)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"wedding_id": weddingID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"key":    key,
+				"status": bson.M{"$ifNull": bson.A{"$rsvp_status", "pending"}},
+			},
+			"count": bson.M{"$sum": 1},
+			// The guest and the companions they named in their RSVP
+			"headcount": bson.M{"$sum": bson.M{"$add": bson.A{1, bson.M{"$size": bson.M{"$ifNull": bson.A{"$companions", bson.A{}}}}}}},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate attendance breakdown: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	breakdowns := models.AttendanceBreakdowns{}
+	for cursor.Next(ctx) {
+		var result struct {
+			ID struct {
+				Key    string `bson:"key"`
+				Status string `bson:"status"`
+			} `bson:"_id"`
+			Count     int `bson:"count"`
+			Headcount int `bson:"headcount"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode attendance breakdown: %w", err)
+		}
+		breakdowns.Add(result.ID.Key, result.ID.Status, result.Count, result.Headcount)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read attendance breakdown: %w", err)
+	}
+	return breakdowns.List(), nil
+}
+
+// UpdateMany applies a patch to the guests of a wedding with an update pipeline,
+// so that tags can be added and removed in the same write
+func (r *GuestRepository) UpdateMany(ctx context.Context, weddingID models.ID, ids []models.ID, patch models.GuestPatch) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// Values are wrapped in $literal so that strings starting with $ are not read as field paths
+	set := bson.M{"updated_at": time.Now()}
+	fields := []struct {
+		name  string
+		value interface{}
+		isSet bool
+	}{
+		{"side", patch.Side, patch.Side != nil},
+		{"relationship", patch.Relationship, patch.Relationship != nil},
+		{"invited_via", patch.InvitedVia, patch.InvitedVia != nil},
+		{"invitation_status", patch.InvitationStatus, patch.InvitationStatus != nil},
+		{"invitation_sent_at", patch.InvitationSentAt, patch.InvitationSentAt != nil},
+		{"vip", patch.VIP, patch.VIP != nil},
+		{"allow_plus_one", patch.AllowPlusOne, patch.AllowPlusOne != nil},
+	}
+	for _, field := range fields {
+		if field.isSet {
+			set[field.name] = bson.M{"$literal": field.value}
+		}
+	}
+	if patch.InvitationStatus != nil {
+		set["invitation_error"] = ""
+	}
+	if len(patch.AddTags) > 0 || len(patch.RemoveTags) > 0 {
+		tags := bson.M{"$ifNull": bson.A{"$tags", bson.A{}}}
+		set["tags"] = bson.M{"$setDifference": bson.A{
+			bson.M{"$setUnion": bson.A{tags, bson.M{"$literal": nonNil(patch.AddTags)}}},
+			bson.M{"$literal": nonNil(patch.RemoveTags)},
+		}}
+	}
+
+	filter := bson.M{"wedding_id": weddingID, "_id": bson.M{"$in": ids}}
+	result, err := r.collection.UpdateMany(ctx, filter, mongo.Pipeline{{{Key: "$set", Value: set}}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to update guests: %w", err)
+	}
+	return result.MatchedCount, nil
+}
+
+// DeleteMany deletes the guests of a wedding with the given IDs
+func (r *GuestRepository) DeleteMany(ctx context.Context, weddingID models.ID, ids []models.ID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"wedding_id": weddingID, "_id": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete guests: %w", err)
+	}
+	return result.DeletedCount, nil
+}
+
+// SetGroup moves the guests of a wedding into a group, or out of any group when groupID is nil
+func (r *GuestRepository) SetGroup(ctx context.Context, weddingID models.ID, ids []models.ID, groupID *models.ID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	update := bson.M{"$unset": bson.M{"group_id": ""}, "$set": bson.M{"updated_at": time.Now()}}
+	if groupID != nil {
+		update = bson.M{"$set": bson.M{"group_id": *groupID, "updated_at": time.Now()}}
+	}
+
+	result, err := r.collection.UpdateMany(ctx, bson.M{"wedding_id": weddingID, "_id": bson.M{"$in": ids}}, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to set guest group: %w", err)
+	}
+	return result.MatchedCount, nil
+}
+
+// nonNil returns an empty list for nil, which would be stored as null
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
 // buildFilters constructs the MongoDB filter based on the provided filters
 func (r *GuestRepository) buildFilters(baseFilter bson.M, filters repository.GuestFilters) bson.M {
 	if filters.Search != "" {
@@ -243,7 +606,9 @@ func (r *GuestRepository) buildFilters(baseFilter bson.M, filters repository.Gue
 		baseFilter["side"] = filters.Side
 	}
 
-	if filters.RSVPStatus != "" {
+	if filters.RSVPStatus == "pending" {
+		baseFilter["rsvp_status"] = bson.M{"$in": bson.A{"pending", "", nil}}
+	} else if filters.RSVPStatus != "" {
 		baseFilter["rsvp_status"] = filters.RSVPStatus
 	}
 
@@ -255,6 +620,34 @@ func (r *GuestRepository) buildFilters(baseFilter bson.M, filters repository.Gue
 		baseFilter["vip"] = *filters.VIP
 	}
 
+	if filters.InvitationStatus != "" {
+		baseFilter["invitation_status"] = filters.InvitationStatus
+	}
+
+	if filters.InvitedVia != "" {
+		baseFilter["invited_via"] = filters.InvitedVia
+	}
+
+	if filters.AllowPlusOne != nil {
+		baseFilter["allow_plus_one"] = *filters.AllowPlusOne
+	}
+
+	if filters.Tag != "" {
+		baseFilter["tags"] = filters.Tag
+	}
+
+	if filters.ImportBatchID != "" {
+		baseFilter["import_batch_id"] = filters.ImportBatchID
+	}
+
+	if filters.GroupID != nil {
+		baseFilter["group_id"] = *filters.GroupID
+	}
+
+	if len(filters.IDs) > 0 {
+		baseFilter["_id"] = bson.M{"$in": filters.IDs}
+	}
+
 	return baseFilter
 }
 
@@ -281,6 +674,18 @@ func (r *GuestRepository) EnsureIndexes(ctx context.Context) error {
 			Keys:    bson.M{"wedding_id": 1, "invitation_status": 1},
 			Options: options.Index().SetName("wedding_invitation_status_index"),
 		},
+		{
+			Keys:    bson.D{{Key: "wedding_id", Value: 1}, {Key: "tags", Value: 1}},
+			Options: options.Index().SetName("wedding_tags_index"),
+		},
+		{
+			Keys:    bson.D{{Key: "wedding_id", Value: 1}, {Key: "search_keys", Value: 1}},
+			Options: options.Index().SetName("wedding_search_keys_index"),
+		},
+		{
+			Keys:    bson.M{"group_id": 1},
+			Options: options.Index().SetName("group_id_index").SetSparse(true),
+		},
 		{
 			Keys:    bson.M{"import_batch_id": 1},
 			Options: options.Index().SetName("import_batch_id_index"),
@@ -0,0 +1,95 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure guestGroupRepository implements the domain repository interface
+var _ repository.GuestGroupRepository = (*guestGroupRepository)(nil)
+
+type guestGroupRepository struct {
+	collection *mongo.Collection
+}
+
+// NewGuestGroupRepository creates a new MongoDB guest group repository
+func NewGuestGroupRepository(db *mongo.Database) repository.GuestGroupRepository {
+	return &guestGroupRepository{collection: db.Collection("guest_groups")}
+}
+
+// Create adds a group to a wedding
+func (r *guestGroupRepository) Create(ctx context.Context, group *models.GuestGroup) error {
+	if group.ID.IsZero() {
+		group.ID = models.NewID()
+	}
+	now := time.Now()
+	group.CreatedAt = now
+	group.UpdatedAt = now
+
+	if _, err := r.collection.InsertOne(ctx, group); err != nil {
+		return fmt.Errorf("failed to create guest group: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a guest group by ID
+func (r *guestGroupRepository) GetByID(ctx context.Context, id models.ID) (*models.GuestGroup, error) {
+	var group models.GuestGroup
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&group); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get guest group: %w", err)
+	}
+	return &group, nil
+}
+
+// ListByWedding retrieves the groups of a wedding by name
+func (r *guestGroupRepository) ListByWedding(ctx context.Context, weddingID models.ID) ([]*models.GuestGroup, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"wedding_id": weddingID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list guest groups: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	groups := []*models.GuestGroup{}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, fmt.Errorf("failed to decode guest groups: %w", err)
+	}
+	return groups, nil
+}
+
+// Update replaces a guest group
+func (r *guestGroupRepository) Update(ctx context.Context, group *models.GuestGroup) error {
+	group.UpdatedAt = time.Now()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": group.ID}, bson.M{"$set": group})
+	if err != nil {
+		return fmt.Errorf("failed to update guest group: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes a guest group. Its members must be ungrouped separately.
+func (r *guestGroupRepository) Delete(ctx context.Context, id models.ID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete guest group: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
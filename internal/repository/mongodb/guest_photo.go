@@ -0,0 +1,114 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure guestPhotoRepository implements the domain repository interface
+var _ repository.GuestPhotoRepository = (*guestPhotoRepository)(nil)
+
+type guestPhotoRepository struct {
+	collection *mongo.Collection
+}
+
+// NewGuestPhotoRepository creates a new MongoDB guest photo repository
+func NewGuestPhotoRepository(db *mongo.Database) repository.GuestPhotoRepository {
+	return &guestPhotoRepository{
+		collection: db.Collection("guest_photos"),
+	}
+}
+
+// Create stores a new guest photo
+func (r *guestPhotoRepository) Create(ctx context.Context, photo *models.GuestPhoto) error {
+	if photo.ID.IsZero() {
+		photo.ID = models.NewID()
+	}
+	photo.CreatedAt = time.Now()
+
+	if _, err := r.collection.InsertOne(ctx, photo); err != nil {
+		return fmt.Errorf("failed to create guest photo: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a guest photo by ID
+func (r *guestPhotoRepository) GetByID(ctx context.Context, id models.ID) (*models.GuestPhoto, error) {
+	var photo models.GuestPhoto
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&photo); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get guest photo: %w", err)
+	}
+	return &photo, nil
+}
+
+// Moderate approves or rejects a photo that is still pending
+func (r *guestPhotoRepository) Moderate(ctx context.Context, id models.ID, status models.GuestPhotoStatus, moderatedBy models.ID, moderatedAt time.Time) error {
+	filter := bson.M{"_id": id, "status": models.GuestPhotoPending}
+	update := bson.M{"$set": bson.M{
+		"status":       status,
+		"moderated_by": moderatedBy,
+		"moderated_at": moderatedAt,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to moderate guest photo: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// SetThumbnails stores the thumbnail URLs of a photo
+func (r *guestPhotoRepository) SetThumbnails(ctx context.Context, id models.ID, thumbnails map[string]string) error {
+	update := bson.M{"$set": bson.M{"thumbnails": thumbnails}}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update guest photo thumbnails: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// ListByWedding retrieves the photos of a wedding in a status, in upload order
+func (r *guestPhotoRepository) ListByWedding(ctx context.Context, weddingID models.ID, status models.GuestPhotoStatus, page, pageSize int) ([]*models.GuestPhoto, int64, error) {
+	filter := bson.M{"wedding_id": weddingID, "status": status}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count guest photos: %w", err)
+	}
+
+	skip := (page - 1) * pageSize
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list guest photos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	photos := []*models.GuestPhoto{}
+	if err := cursor.All(ctx, &photos); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode guest photos: %w", err)
+	}
+	return photos, total, nil
+}
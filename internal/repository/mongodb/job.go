@@ -0,0 +1,154 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure jobRepository implements the domain repository interface
+var _ repository.JobRepository = (*jobRepository)(nil)
+
+type jobRepository struct {
+	collection *mongo.Collection
+}
+
+// NewJobRepository creates a new MongoDB-backed background job queue
+func NewJobRepository(db *mongo.Database) repository.JobRepository {
+	return &jobRepository{
+		collection: db.Collection("jobs"),
+	}
+}
+
+// Enqueue inserts a new queued job. A job with a unique key is only inserted when no
+// queued job of the same type has that key, so bursts of identical work run once.
+func (r *jobRepository) Enqueue(ctx context.Context, job *models.Job) error {
+	if job.ID.IsZero() {
+		job.ID = models.NewID()
+	}
+	job.Status = models.JobQueued
+	job.CreatedAt = time.Now()
+	if job.RunAt.IsZero() {
+		job.RunAt = job.CreatedAt
+	}
+
+	if job.UniqueKey == "" {
+		if _, err := r.collection.InsertOne(ctx, job); err != nil {
+			return fmt.Errorf("failed to enqueue job: %w", err)
+		}
+		return nil
+	}
+
+	filter := bson.M{
+		"type":       job.Type,
+		"unique_key": job.UniqueKey,
+		"status":     models.JobQueued,
+	}
+	_, err := r.collection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": job}, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to enqueue job: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a job by ID
+func (r *jobRepository) GetByID(ctx context.Context, id models.ID) (*models.Job, error) {
+	var job models.Job
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	return &job, nil
+}
+
+// ClaimNext leases the due job that has waited longest. Jobs whose lease expired
+// (e.g. the worker crashed) are reclaimed.
+func (r *jobRepository) ClaimNext(ctx context.Context, types []string, now time.Time, lease time.Duration) (*models.Job, error) {
+	filter := bson.M{
+		"type": bson.M{"$in": types},
+		"$or": bson.A{
+			bson.M{
+				"status": models.JobQueued,
+				"run_at": bson.M{"$lte": now},
+			},
+			bson.M{
+				"status":       models.JobRunning,
+				"locked_until": bson.M{"$lt": now},
+			},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       models.JobRunning,
+			"locked_until": now.Add(lease),
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job models.Job
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+	return &job, nil
+}
+
+// MarkCompleted records that a job finished
+func (r *jobRepository) MarkCompleted(ctx context.Context, id models.ID) error {
+	return r.finish(ctx, id, bson.M{"status": models.JobCompleted})
+}
+
+// MarkFailed records a permanent failure for a job
+func (r *jobRepository) MarkFailed(ctx context.Context, id models.ID, reason string) error {
+	return r.finish(ctx, id, bson.M{
+		"status": models.JobFailed,
+		"error":  reason,
+	})
+}
+
+// Retry returns a job to the queue so it runs again at runAt
+func (r *jobRepository) Retry(ctx context.Context, id models.ID, runAt time.Time, reason string) error {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set":   bson.M{"status": models.JobQueued, "run_at": runAt, "error": reason},
+		"$unset": bson.M{"locked_until": ""},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to retry job: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *jobRepository) finish(ctx context.Context, id models.ID, fields bson.M) error {
+	fields["processed_at"] = time.Now()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set":   fields,
+		"$unset": bson.M{"locked_until": ""},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"wedding-invitation-backend/internal/domain/models"
@@ -28,30 +27,25 @@ func NewMediaRepository(db *mongo.Database) repository.MediaRepository {
 func (r *mediaRepository) Create(ctx context.Context, media *models.Media) error {
 	// Generate ID if not set
 	if media.ID.IsZero() {
-		media.ID = primitive.NewObjectID()
+		media.ID = models.NewID()
 	}
 
 	media.BeforeCreate()
 
-	result, err := r.collection.InsertOne(ctx, media)
-	if err != nil {
+	if _, err := r.collection.InsertOne(ctx, media); err != nil {
 		return fmt.Errorf("failed to insert media: %w", err)
 	}
 
-	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
-		media.ID = oid
-	}
-
 	return nil
 }
 
 // GetByID retrieves a media record by ID
-func (r *mediaRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Media, error) {
+func (r *mediaRepository) GetByID(ctx context.Context, id models.ID) (*models.Media, error) {
 	var media models.Media
 	err := r.collection.FindOne(ctx, bson.M{"_id": id, "deletedAt": bson.M{"$exists": false}}).Decode(&media)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, fmt.Errorf("media not found")
+			return nil, fmt.Errorf("media not found: %w", repository.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get media: %w", err)
 	}
@@ -105,14 +99,22 @@ func (r *mediaRepository) List(ctx context.Context, filter repository.MediaFilte
 	}
 
 	// Set default sort
-	if len(opts.Sort) == 0 {
-		opts.Sort = bson.D{{Key: "createdAt", Value: -1}}
+	sort := bson.D{{Key: "createdAt", Value: -1}}
+	if len(opts.Sort) > 0 {
+		sort = bson.D{}
+		for _, field := range opts.Sort {
+			direction := 1
+			if field.Desc {
+				direction = -1
+			}
+			sort = append(sort, bson.E{Key: field.Field, Value: direction})
+		}
 	}
 
 	findOpts := options.Find().
 		SetLimit(opts.Limit).
 		SetSkip(opts.Offset).
-		SetSort(opts.Sort)
+		SetSort(sort)
 
 	cursor, err := r.collection.Find(ctx, query, findOpts)
 	if err != nil {
@@ -149,7 +151,7 @@ func (r *mediaRepository) Update(ctx context.Context, media *models.Media) error
 }
 
 // Delete permanently deletes a media record
-func (r *mediaRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+func (r *mediaRepository) Delete(ctx context.Context, id models.ID) error {
 	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
 	if err != nil {
 		return fmt.Errorf("failed to delete media: %w", err)
@@ -163,7 +165,7 @@ func (r *mediaRepository) Delete(ctx context.Context, id primitive.ObjectID) err
 }
 
 // SoftDelete marks a media record as deleted
-func (r *mediaRepository) SoftDelete(ctx context.Context, id primitive.ObjectID) error {
+func (r *mediaRepository) SoftDelete(ctx context.Context, id models.ID) error {
 	now := time.Now()
 	result, err := r.collection.UpdateOne(
 		ctx,
@@ -202,9 +204,79 @@ func (r *mediaRepository) GetOrphaned(ctx context.Context, before time.Time) ([]
 }
 
 // GetByCreatedBy retrieves media created by a specific user
-func (r *mediaRepository) GetByCreatedBy(ctx context.Context, userID primitive.ObjectID, opts repository.ListOptions) ([]*models.Media, int64, error) {
+func (r *mediaRepository) GetByCreatedBy(ctx context.Context, userID models.ID, opts repository.ListOptions) ([]*models.Media, int64, error) {
 	filter := repository.MediaFilter{
 		CreatedBy: &userID,
 	}
 	return r.List(ctx, filter, opts)
 }
+
+// TotalSizeByCreatedBy sums the size of the media a user stores
+func (r *mediaRepository) TotalSizeByCreatedBy(ctx context.Context, userID models.ID) (int64, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{"createdBy": userID, "deletedAt": bson.M{"$exists": false}}},
+		{"$group": bson.M{"_id": nil, "total": bson.M{"$sum": "$size"}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum media size: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		Total int64 `bson:"total"`
+	}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			return 0, fmt.Errorf("failed to decode media size: %w", err)
+		}
+	}
+	return result.Total, cursor.Err()
+}
+
+// ListAllByCreatedBy lists every media record of the user, deleted media included
+func (r *mediaRepository) ListAllByCreatedBy(ctx context.Context, userID models.ID) ([]*models.Media, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"createdBy": userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user media: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var media []*models.Media
+	if err := cursor.All(ctx, &media); err != nil {
+		return nil, fmt.Errorf("failed to decode user media: %w", err)
+	}
+
+	return media, nil
+}
+
+// GetByContentHash finds the latest media of a user with the content hash that
+// still has its stored object
+func (r *mediaRepository) GetByContentHash(ctx context.Context, userID models.ID, hash string) (*models.Media, error) {
+	filter := bson.M{
+		"createdBy":   userID,
+		"contentHash": hash,
+		"deletedAt":   bson.M{"$exists": false},
+		"status":      bson.M{"$nin": []models.MediaStatus{models.MediaStatusFailed, models.MediaStatusRejected}},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	var media models.Media
+	if err := r.collection.FindOne(ctx, filter, opts).Decode(&media); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to find media by content: %w", err)
+	}
+	return &media, nil
+}
+
+// CountByStorageKey counts the media records referencing a stored object
+func (r *mediaRepository) CountByStorageKey(ctx context.Context, key string) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"storageKey": key})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count media references: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,185 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure metricsWebhookRepository implements the domain repository interface
+var _ repository.MetricsWebhookRepository = (*metricsWebhookRepository)(nil)
+
+type metricsWebhookRepository struct {
+	webhooks   *mongo.Collection
+	deliveries *mongo.Collection
+}
+
+// NewMetricsWebhookRepository creates a new MongoDB metrics webhook repository
+func NewMetricsWebhookRepository(db *mongo.Database) repository.MetricsWebhookRepository {
+	return &metricsWebhookRepository{
+		webhooks:   db.Collection("metrics_webhooks"),
+		deliveries: db.Collection("metrics_webhook_deliveries"),
+	}
+}
+
+// Create inserts a new metrics webhook
+func (r *metricsWebhookRepository) Create(ctx context.Context, webhook *models.MetricsWebhook) error {
+	if webhook.ID.IsZero() {
+		webhook.ID = models.NewID()
+	}
+	now := time.Now()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	if _, err := r.webhooks.InsertOne(ctx, webhook); err != nil {
+		return fmt.Errorf("failed to insert metrics webhook: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a metrics webhook by ID
+func (r *metricsWebhookRepository) GetByID(ctx context.Context, id models.ID) (*models.MetricsWebhook, error) {
+	var webhook models.MetricsWebhook
+	err := r.webhooks.FindOne(ctx, bson.M{"_id": id}).Decode(&webhook)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get metrics webhook: %w", err)
+	}
+	return &webhook, nil
+}
+
+// ListByUser retrieves all metrics webhooks owned by a user
+func (r *metricsWebhookRepository) ListByUser(ctx context.Context, userID models.ID) ([]*models.MetricsWebhook, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.webhooks.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list metrics webhooks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var webhooks []*models.MetricsWebhook
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, fmt.Errorf("failed to decode metrics webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// ListDue retrieves active webhooks whose next delivery time has passed
+func (r *metricsWebhookRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.MetricsWebhook, error) {
+	filter := bson.M{
+		"active":           true,
+		"next_delivery_at": bson.M{"$lte": now},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "next_delivery_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.webhooks.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due metrics webhooks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var webhooks []*models.MetricsWebhook
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, fmt.Errorf("failed to decode metrics webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// Update updates an existing metrics webhook
+func (r *metricsWebhookRepository) Update(ctx context.Context, webhook *models.MetricsWebhook) error {
+	webhook.UpdatedAt = time.Now()
+
+	result, err := r.webhooks.UpdateOne(ctx, bson.M{"_id": webhook.ID}, bson.M{"$set": webhook})
+	if err != nil {
+		return fmt.Errorf("failed to update metrics webhook: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes a metrics webhook and its delivery log
+func (r *metricsWebhookRepository) Delete(ctx context.Context, id models.ID) error {
+	result, err := r.webhooks.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete metrics webhook: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+
+	if _, err := r.deliveries.DeleteMany(ctx, bson.M{"webhook_id": id}); err != nil {
+		return fmt.Errorf("failed to delete metrics webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// MarkDelivered records the last delivery time and schedules the next one
+func (r *metricsWebhookRepository) MarkDelivered(ctx context.Context, id models.ID, deliveredAt, nextDeliveryAt time.Time) error {
+	_, err := r.webhooks.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{
+			"last_delivered_at": deliveredAt,
+			"next_delivery_at":  nextDeliveryAt,
+			"updated_at":        time.Now(),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark metrics webhook delivered: %w", err)
+	}
+	return nil
+}
+
+// CreateDelivery records a delivery attempt
+func (r *metricsWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.MetricsWebhookDelivery) error {
+	if delivery.ID.IsZero() {
+		delivery.ID = models.NewID()
+	}
+	if delivery.AttemptedAt.IsZero() {
+		delivery.AttemptedAt = time.Now()
+	}
+
+	if _, err := r.deliveries.InsertOne(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to insert metrics webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries retrieves the delivery log for a webhook, newest first
+func (r *metricsWebhookRepository) ListDeliveries(ctx context.Context, webhookID models.ID, page, pageSize int) ([]*models.MetricsWebhookDelivery, int64, error) {
+	filter := bson.M{"webhook_id": webhookID}
+
+	total, err := r.deliveries.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count metrics webhook deliveries: %w", err)
+	}
+
+	skip := (page - 1) * pageSize
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "attempted_at", Value: -1}})
+
+	cursor, err := r.deliveries.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list metrics webhook deliveries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []*models.MetricsWebhookDelivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode metrics webhook deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
@@ -0,0 +1,115 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure organizationRepository implements the domain repository interface
+var _ repository.OrganizationRepository = (*organizationRepository)(nil)
+
+type organizationRepository struct {
+	organizations *mongo.Collection
+}
+
+// NewOrganizationRepository creates a new MongoDB organization repository
+func NewOrganizationRepository(db *mongo.Database) repository.OrganizationRepository {
+	return &organizationRepository{organizations: db.Collection("organizations")}
+}
+
+// Create inserts a new organization
+func (r *organizationRepository) Create(ctx context.Context, org *models.Organization) error {
+	now := time.Now()
+	org.CreatedAt = now
+	org.UpdatedAt = now
+
+	if _, err := r.organizations.InsertOne(ctx, org); err != nil {
+		return fmt.Errorf("failed to insert organization: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an organization by its tenant ID
+func (r *organizationRepository) GetByID(ctx context.Context, id string) (*models.Organization, error) {
+	var org models.Organization
+	if err := r.organizations.FindOne(ctx, bson.M{"_id": id}).Decode(&org); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return &org, nil
+}
+
+// List retrieves organizations by name with pagination
+func (r *organizationRepository) List(ctx context.Context, page, pageSize int) ([]*models.Organization, int64, error) {
+	total, err := r.organizations.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count organizations: %w", err)
+	}
+
+	skip := (page - 1) * pageSize
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.organizations.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var orgs []*models.Organization
+	if err := cursor.All(ctx, &orgs); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode organizations: %w", err)
+	}
+	return orgs, total, nil
+}
+
+// Update updates the name and branding of an organization
+func (r *organizationRepository) Update(ctx context.Context, org *models.Organization) error {
+	org.UpdatedAt = time.Now()
+
+	result, err := r.organizations.UpdateOne(ctx, bson.M{"_id": org.ID}, bson.M{"$set": bson.M{
+		"name":       org.Name,
+		"branding":   org.Branding,
+		"updated_at": org.UpdatedAt,
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes an organization
+func (r *organizationRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.organizations.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// tenantScoped adds the tenant the context is scoped to, if any, to a filter of
+// tenant-owned records
+func tenantScoped(ctx context.Context, filter bson.M) bson.M {
+	if tenantID, ok := repository.TenantFromContext(ctx); ok {
+		filter["tenant_id"] = tenantID
+	}
+	return filter
+}
@@ -0,0 +1,98 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure previewLinkRepository implements the domain repository interface
+var _ repository.PreviewLinkRepository = (*previewLinkRepository)(nil)
+
+type previewLinkRepository struct {
+	collection *mongo.Collection
+}
+
+// NewPreviewLinkRepository creates a new MongoDB preview link repository
+func NewPreviewLinkRepository(db *mongo.Database) repository.PreviewLinkRepository {
+	return &previewLinkRepository{collection: db.Collection("preview_links")}
+}
+
+// Create adds a preview link to a wedding
+func (r *previewLinkRepository) Create(ctx context.Context, link *models.PreviewLink) error {
+	if link.ID.IsZero() {
+		link.ID = models.NewID()
+	}
+	now := time.Now()
+	link.CreatedAt = now
+	link.UpdatedAt = now
+
+	if _, err := r.collection.InsertOne(ctx, link); err != nil {
+		return fmt.Errorf("failed to create preview link: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a preview link by ID
+func (r *previewLinkRepository) GetByID(ctx context.Context, id models.ID) (*models.PreviewLink, error) {
+	var link models.PreviewLink
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&link); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get preview link: %w", err)
+	}
+	return &link, nil
+}
+
+// ListByWedding retrieves the preview links of a wedding, newest first
+func (r *previewLinkRepository) ListByWedding(ctx context.Context, weddingID models.ID) ([]*models.PreviewLink, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"wedding_id": weddingID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list preview links: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	links := []*models.PreviewLink{}
+	if err := cursor.All(ctx, &links); err != nil {
+		return nil, fmt.Errorf("failed to decode preview links: %w", err)
+	}
+	return links, nil
+}
+
+// Update replaces a preview link
+func (r *previewLinkRepository) Update(ctx context.Context, link *models.PreviewLink) error {
+	link.UpdatedAt = time.Now()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": link.ID}, bson.M{"$set": link})
+	if err != nil {
+		return fmt.Errorf("failed to update preview link: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// RecordUse counts a visit through the link without overwriting concurrent ones
+func (r *previewLinkRepository) RecordUse(ctx context.Context, id models.ID, at time.Time) error {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$inc": bson.M{"use_count": 1},
+		"$set": bson.M{"last_used_at": at},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record preview link use: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
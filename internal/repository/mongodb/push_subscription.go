@@ -0,0 +1,103 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure pushSubscriptionRepository implements the domain repository interface
+var _ repository.PushSubscriptionRepository = (*pushSubscriptionRepository)(nil)
+
+type pushSubscriptionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewPushSubscriptionRepository creates a new MongoDB Web Push subscription repository
+func NewPushSubscriptionRepository(db *mongo.Database) repository.PushSubscriptionRepository {
+	return &pushSubscriptionRepository{collection: db.Collection("push_subscriptions")}
+}
+
+// Save upserts a subscription by its endpoint. A browser subscribing again, possibly
+// after another user signed in, takes over the earlier subscription with fresh keys.
+func (r *pushSubscriptionRepository) Save(ctx context.Context, subscription *models.PushSubscription) error {
+	if subscription.CreatedAt.IsZero() {
+		subscription.CreatedAt = time.Now()
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"user_id":    subscription.UserID,
+			"p256dh":     subscription.P256dh,
+			"auth":       subscription.Auth,
+			"user_agent": subscription.UserAgent,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        models.NewID(),
+			"created_at": subscription.CreatedAt,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved models.PushSubscription
+	if err := r.collection.FindOneAndUpdate(ctx, bson.M{"endpoint": subscription.Endpoint}, update, opts).Decode(&saved); err != nil {
+		return fmt.Errorf("failed to save push subscription: %w", err)
+	}
+	*subscription = saved
+	return nil
+}
+
+// GetByID retrieves a push subscription by ID
+func (r *pushSubscriptionRepository) GetByID(ctx context.Context, id models.ID) (*models.PushSubscription, error) {
+	var subscription models.PushSubscription
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&subscription); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get push subscription: %w", err)
+	}
+	return &subscription, nil
+}
+
+// ListByUser retrieves the push subscriptions of a user, newest first
+func (r *pushSubscriptionRepository) ListByUser(ctx context.Context, userID models.ID) ([]*models.PushSubscription, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list push subscriptions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	subscriptions := []*models.PushSubscription{}
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return nil, fmt.Errorf("failed to decode push subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// MarkPushed records when a notification was last pushed to a subscription
+func (r *pushSubscriptionRepository) MarkPushed(ctx context.Context, id models.ID, pushedAt time.Time) error {
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_push_at": pushedAt}}); err != nil {
+		return fmt.Errorf("failed to mark push subscription pushed: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a push subscription
+func (r *pushSubscriptionRepository) Delete(ctx context.Context, id models.ID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure registryRepository implements the domain repository interface
+var _ repository.RegistryRepository = (*registryRepository)(nil)
+
+type registryRepository struct {
+	items   *mongo.Collection
+	pledges *mongo.Collection
+}
+
+// NewRegistryRepository creates a new MongoDB gift registry repository
+func NewRegistryRepository(db *mongo.Database) repository.RegistryRepository {
+	return &registryRepository{
+		items:   db.Collection("registry_items"),
+		pledges: db.Collection("gift_pledges"),
+	}
+}
+
+// CreateItem adds an item to a wedding's registry
+func (r *registryRepository) CreateItem(ctx context.Context, item *models.RegistryItem) error {
+	if item.ID.IsZero() {
+		item.ID = models.NewID()
+	}
+	now := time.Now()
+	item.CreatedAt = now
+	item.UpdatedAt = now
+
+	if _, err := r.items.InsertOne(ctx, item); err != nil {
+		return fmt.Errorf("failed to create registry item: %w", err)
+	}
+	return nil
+}
+
+// GetItem retrieves a registry item by ID
+func (r *registryRepository) GetItem(ctx context.Context, id models.ID) (*models.RegistryItem, error) {
+	var item models.RegistryItem
+	if err := r.items.FindOne(ctx, bson.M{"_id": id}).Decode(&item); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get registry item: %w", err)
+	}
+	return &item, nil
+}
+
+// ListItems retrieves the items of a wedding's registry in display order
+func (r *registryRepository) ListItems(ctx context.Context, weddingID models.ID) ([]*models.RegistryItem, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}, {Key: "created_at", Value: 1}})
+	cursor, err := r.items.Find(ctx, bson.M{"wedding_id": weddingID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registry items: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	items := []*models.RegistryItem{}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode registry items: %w", err)
+	}
+	return items, nil
+}
+
+// UpdateItem replaces a registry item
+func (r *registryRepository) UpdateItem(ctx context.Context, item *models.RegistryItem) error {
+	item.UpdatedAt = time.Now()
+
+	result, err := r.items.UpdateOne(ctx, bson.M{"_id": item.ID}, bson.M{"$set": item})
+	if err != nil {
+		return fmt.Errorf("failed to update registry item: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteItem removes a registry item. Pledges made through it keep its ID.
+func (r *registryRepository) DeleteItem(ctx context.Context, id models.ID) error {
+	result, err := r.items.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete registry item: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// CreatePledge records a gift a guest pledged
+func (r *registryRepository) CreatePledge(ctx context.Context, pledge *models.GiftPledge) error {
+	if pledge.ID.IsZero() {
+		pledge.ID = models.NewID()
+	}
+	now := time.Now()
+	pledge.CreatedAt = now
+	pledge.UpdatedAt = now
+
+	if _, err := r.pledges.InsertOne(ctx, pledge); err != nil {
+		return fmt.Errorf("failed to create gift pledge: %w", err)
+	}
+	return nil
+}
+
+// GetPledge retrieves a gift pledge by ID
+func (r *registryRepository) GetPledge(ctx context.Context, id models.ID) (*models.GiftPledge, error) {
+	var pledge models.GiftPledge
+	if err := r.pledges.FindOne(ctx, bson.M{"_id": id}).Decode(&pledge); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get gift pledge: %w", err)
+	}
+	return &pledge, nil
+}
+
+// ListPledges retrieves the pledges of a wedding, newest first
+func (r *registryRepository) ListPledges(ctx context.Context, weddingID models.ID, status models.GiftPledgeStatus, page, pageSize int) ([]*models.GiftPledge, int64, error) {
+	filter := bson.M{"wedding_id": weddingID}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	total, err := r.pledges.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count gift pledges: %w", err)
+	}
+
+	skip := (page - 1) * pageSize
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.pledges.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list gift pledges: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	pledges := []*models.GiftPledge{}
+	if err := cursor.All(ctx, &pledges); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode gift pledges: %w", err)
+	}
+	return pledges, total, nil
+}
+
+// UpdatePledgeStatus records the couple's progress on a pledged gift
+func (r *registryRepository) UpdatePledgeStatus(ctx context.Context, id models.ID, status models.GiftPledgeStatus, thankedAt *time.Time) error {
+	set := bson.M{"status": status, "updated_at": time.Now()}
+	update := bson.M{"$set": set}
+	if thankedAt != nil {
+		set["thanked_at"] = *thankedAt
+	} else {
+		update["$unset"] = bson.M{"thanked_at": ""}
+	}
+
+	result, err := r.pledges.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update gift pledge: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
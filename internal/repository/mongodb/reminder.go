@@ -0,0 +1,254 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure reminderRepository implements the domain repository interface
+var _ repository.ReminderRepository = (*reminderRepository)(nil)
+
+type reminderRepository struct {
+	campaigns  *mongo.Collection
+	deliveries *mongo.Collection
+}
+
+// NewReminderRepository creates a new MongoDB reminder campaign repository
+func NewReminderRepository(db *mongo.Database) repository.ReminderRepository {
+	return &reminderRepository{
+		campaigns:  db.Collection("reminder_campaigns"),
+		deliveries: db.Collection("reminder_deliveries"),
+	}
+}
+
+// CreateCampaign stores a new reminder campaign
+func (r *reminderRepository) CreateCampaign(ctx context.Context, campaign *models.ReminderCampaign) error {
+	if campaign.ID.IsZero() {
+		campaign.ID = models.NewID()
+	}
+	now := time.Now()
+	campaign.CreatedAt = now
+	campaign.UpdatedAt = now
+
+	if _, err := r.campaigns.InsertOne(ctx, campaign); err != nil {
+		return fmt.Errorf("failed to create reminder campaign: %w", err)
+	}
+	return nil
+}
+
+// GetCampaign retrieves a reminder campaign by ID
+func (r *reminderRepository) GetCampaign(ctx context.Context, id models.ID) (*models.ReminderCampaign, error) {
+	var campaign models.ReminderCampaign
+	if err := r.campaigns.FindOne(ctx, bson.M{"_id": id}).Decode(&campaign); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get reminder campaign: %w", err)
+	}
+	return &campaign, nil
+}
+
+// ListCampaigns retrieves the reminder campaigns of a wedding, newest send date first
+func (r *reminderRepository) ListCampaigns(ctx context.Context, weddingID models.ID) ([]*models.ReminderCampaign, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "send_at", Value: -1}})
+	cursor, err := r.campaigns.Find(ctx, bson.M{"wedding_id": weddingID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminder campaigns: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	campaigns := []*models.ReminderCampaign{}
+	if err := cursor.All(ctx, &campaigns); err != nil {
+		return nil, fmt.Errorf("failed to decode reminder campaigns: %w", err)
+	}
+	return campaigns, nil
+}
+
+// CancelCampaign cancels a campaign that is still scheduled
+func (r *reminderRepository) CancelCampaign(ctx context.Context, id models.ID) error {
+	filter := bson.M{"_id": id, "status": models.ReminderCampaignScheduled}
+	update := bson.M{"$set": bson.M{"status": models.ReminderCampaignCancelled, "updated_at": time.Now()}}
+
+	result, err := r.campaigns.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to cancel reminder campaign: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// ClaimDueCampaign leases the campaign that has been due the longest
+func (r *reminderRepository) ClaimDueCampaign(ctx context.Context, now time.Time, lease time.Duration) (*models.ReminderCampaign, error) {
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{
+				"status":  models.ReminderCampaignScheduled,
+				"send_at": bson.M{"$lte": now},
+			},
+			bson.M{
+				"status":       models.ReminderCampaignSending,
+				"locked_until": bson.M{"$lt": now},
+			},
+		},
+	}
+	update := bson.M{"$set": bson.M{
+		"status":       models.ReminderCampaignSending,
+		"locked_until": now.Add(lease),
+		"updated_at":   now,
+	}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "send_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var campaign models.ReminderCampaign
+	if err := r.campaigns.FindOneAndUpdate(ctx, filter, update, opts).Decode(&campaign); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to claim reminder campaign: %w", err)
+	}
+
+	// The first claim starts the campaign; reclaims keep the original start
+	if campaign.StartedAt == nil {
+		if _, err := r.campaigns.UpdateOne(ctx, bson.M{"_id": campaign.ID}, bson.M{"$set": bson.M{"started_at": now}}); err != nil {
+			return nil, fmt.Errorf("failed to start reminder campaign: %w", err)
+		}
+		campaign.StartedAt = &now
+	}
+	return &campaign, nil
+}
+
+// FinishCampaign stores the outcome of a dispatched campaign and releases its lease
+func (r *reminderRepository) FinishCampaign(ctx context.Context, campaign *models.ReminderCampaign) error {
+	update := bson.M{
+		"$set": bson.M{
+			"status":       models.ReminderCampaignSent,
+			"recipients":   campaign.Recipients,
+			"sent":         campaign.Sent,
+			"failed":       campaign.Failed,
+			"skipped":      campaign.Skipped,
+			"completed_at": campaign.CompletedAt,
+			"updated_at":   time.Now(),
+		},
+		"$unset": bson.M{"locked_until": ""},
+	}
+
+	result, err := r.campaigns.UpdateOne(ctx, bson.M{"_id": campaign.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to finish reminder campaign: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// CreateDelivery records the reminder sent to a guest
+func (r *reminderRepository) CreateDelivery(ctx context.Context, delivery *models.ReminderDelivery) error {
+	if delivery.ID.IsZero() {
+		delivery.ID = models.NewID()
+	}
+	delivery.Email = strings.ToLower(delivery.Email)
+
+	if _, err := r.deliveries.InsertOne(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to create reminder delivery: %w", err)
+	}
+	return nil
+}
+
+// HasDelivery reports whether the campaign already reached the guest
+func (r *reminderRepository) HasDelivery(ctx context.Context, campaignID, guestID models.ID) (bool, error) {
+	count, err := r.deliveries.CountDocuments(ctx, bson.M{"campaign_id": campaignID, "guest_id": guestID}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to check reminder delivery: %w", err)
+	}
+	return count > 0, nil
+}
+
+// MarkDeliveryOpened records the first time a reminder email was opened
+func (r *reminderRepository) MarkDeliveryOpened(ctx context.Context, id models.ID, openedAt time.Time) error {
+	filter := bson.M{"_id": id, "opened_at": bson.M{"$exists": false}}
+	if _, err := r.deliveries.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"opened_at": openedAt}}); err != nil {
+		return fmt.Errorf("failed to mark reminder opened: %w", err)
+	}
+	return nil
+}
+
+// MarkResponded attributes an RSVP to the reminders the guest received before it
+func (r *reminderRepository) MarkResponded(ctx context.Context, weddingID models.ID, email, phone string, respondedAt time.Time) (int64, error) {
+	var contacts bson.A
+	if email != "" {
+		contacts = append(contacts, bson.M{"email": strings.ToLower(email)})
+	}
+	if phone != "" {
+		contacts = append(contacts, bson.M{"phone": phone})
+	}
+	if len(contacts) == 0 {
+		return 0, nil
+	}
+
+	filter := bson.M{
+		"wedding_id":   weddingID,
+		"status":       models.ReminderDeliverySent,
+		"sent_at":      bson.M{"$lte": respondedAt},
+		"responded_at": bson.M{"$exists": false},
+		"$or":          contacts,
+	}
+	result, err := r.deliveries.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"responded_at": respondedAt}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to attribute RSVP to reminders: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
+
+// DeliveryStats counts the sent, failed, opened and answered reminders of a campaign
+func (r *reminderRepository) DeliveryStats(ctx context.Context, campaignID models.ID) (*models.ReminderDeliveryStats, error) {
+	count := func(cond interface{}) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{cond, 1, 0}}}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"campaign_id": campaignID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       nil,
+			"sent":      count(bson.M{"$eq": bson.A{"$status", models.ReminderDeliverySent}}),
+			"failed":    count(bson.M{"$eq": bson.A{"$status", models.ReminderDeliveryFailed}}),
+			"opened":    count(bson.M{"$gt": bson.A{"$opened_at", nil}}),
+			"responded": count(bson.M{"$gt": bson.A{"$responded_at", nil}}),
+		}}},
+	}
+
+	cursor, err := r.deliveries.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate reminder deliveries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var stats models.ReminderDeliveryStats
+	if cursor.Next(ctx) {
+		var result struct {
+			Sent      int64 `bson:"sent"`
+			Failed    int64 `bson:"failed"`
+			Opened    int64 `bson:"opened"`
+			Responded int64 `bson:"responded"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode reminder delivery stats: %w", err)
+		}
+		stats = models.ReminderDeliveryStats(result)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read reminder delivery stats: %w", err)
+	}
+	return &stats, nil
+}
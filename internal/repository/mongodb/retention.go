@@ -0,0 +1,203 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure retentionRepository implements the domain repository interface
+var _ repository.RetentionRepository = (*retentionRepository)(nil)
+
+// retentionTarget is where a kind of data under the retention policy is stored
+type retentionTarget struct {
+	collection string
+	field      string // Creation time
+}
+
+var retentionTargets = map[string]retentionTarget{
+	models.RetentionPageViews:   {collection: "page_views", field: "timestamp"},
+	models.RetentionRSVPEvents:  {collection: "rsvp_analytics", field: "timestamp"},
+	models.RetentionConversions: {collection: "conversion_events", field: "timestamp"},
+	models.RetentionAuditLogs:   {collection: "audit_logs", field: "created_at"},
+}
+
+type retentionRepository struct {
+	db        *mongo.Database
+	overrides *mongo.Collection
+}
+
+// NewRetentionRepository creates a new MongoDB retention repository
+func NewRetentionRepository(db *mongo.Database) repository.RetentionRepository {
+	return &retentionRepository{
+		db:        db,
+		overrides: db.Collection("retention_overrides"),
+	}
+}
+
+// CountExpired counts the data of a type created before the cutoff
+func (r *retentionRepository) CountExpired(ctx context.Context, dataType string, weddingID *models.ID, before time.Time) (int64, error) {
+	collection, filter, err := r.expired(dataType, weddingID, before)
+	if err != nil {
+		return 0, err
+	}
+	count, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count expired %s: %w", dataType, err)
+	}
+	return count, nil
+}
+
+// DeleteExpired deletes the data of a type created before the cutoff
+func (r *retentionRepository) DeleteExpired(ctx context.Context, dataType string, weddingID *models.ID, before time.Time) (int64, error) {
+	collection, filter, err := r.expired(dataType, weddingID, before)
+	if err != nil {
+		return 0, err
+	}
+	result, err := collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired %s: %w", dataType, err)
+	}
+	return result.DeletedCount, nil
+}
+
+func (r *retentionRepository) expired(dataType string, weddingID *models.ID, before time.Time) (*mongo.Collection, bson.M, error) {
+	target, ok := retentionTargets[dataType]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown retention data type %q", dataType)
+	}
+	filter := bson.M{target.field: bson.M{"$lt": before}}
+	if weddingID != nil {
+		filter["wedding_id"] = *weddingID
+	}
+	return r.db.Collection(target.collection), filter, nil
+}
+
+// SyncTTLIndex makes the ascending index on the creation time of a data type
+// a TTL index expiring the data after the period, or a plain one when the
+// period is 0. A TTL index whose period changed is modified in place; turning
+// a plain index into a TTL one or back rebuilds it.
+func (r *retentionRepository) SyncTTLIndex(ctx context.Context, dataType string, period time.Duration) error {
+	target, ok := retentionTargets[dataType]
+	if !ok {
+		return fmt.Errorf("unknown retention data type %q", dataType)
+	}
+	indexes := r.db.Collection(target.collection).Indexes()
+	name := target.field + "_1"
+
+	specs, err := indexes.ListSpecifications(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list %s indexes: %w", target.collection, err)
+	}
+	var existing *mongo.IndexSpecification
+	for _, spec := range specs {
+		if spec.Name == name {
+			existing = spec
+		}
+	}
+
+	seconds := int32(period / time.Second)
+	switch {
+	case existing != nil && period > 0 && existing.ExpireAfterSeconds != nil:
+		if *existing.ExpireAfterSeconds == seconds {
+			return nil
+		}
+		err := r.db.RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: target.collection},
+			{Key: "index", Value: bson.D{{Key: "name", Value: name}, {Key: "expireAfterSeconds", Value: seconds}}},
+		}).Err()
+		if err != nil {
+			return fmt.Errorf("failed to change %s TTL index: %w", target.collection, err)
+		}
+		return nil
+	case existing != nil && period == 0 && existing.ExpireAfterSeconds == nil:
+		return nil
+	case existing != nil:
+		if _, err := indexes.DropOne(ctx, name); err != nil {
+			return fmt.Errorf("failed to drop %s index %s: %w", target.collection, name, err)
+		}
+	}
+
+	opts := options.Index()
+	if period > 0 {
+		opts.SetExpireAfterSeconds(seconds)
+	}
+	if _, err := indexes.CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: target.field, Value: 1}}, Options: opts}); err != nil {
+		return fmt.Errorf("failed to create %s index %s: %w", target.collection, name, err)
+	}
+	return nil
+}
+
+// GetOverride returns the retention override of a wedding
+func (r *retentionRepository) GetOverride(ctx context.Context, weddingID models.ID) (*models.RetentionOverride, error) {
+	var override models.RetentionOverride
+	if err := r.overrides.FindOne(ctx, bson.M{"_id": weddingID}).Decode(&override); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get retention override: %w", err)
+	}
+	return &override, nil
+}
+
+// ListOverrides lists the retention overrides of every wedding
+func (r *retentionRepository) ListOverrides(ctx context.Context) ([]*models.RetentionOverride, error) {
+	cursor, err := r.overrides.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list retention overrides: %w", err)
+	}
+	overrides := []*models.RetentionOverride{}
+	if err := cursor.All(ctx, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to decode retention overrides: %w", err)
+	}
+	return overrides, nil
+}
+
+// SetOverride creates or replaces the retention override of a wedding
+func (r *retentionRepository) SetOverride(ctx context.Context, override *models.RetentionOverride) error {
+	override.UpdatedAt = time.Now()
+	_, err := r.overrides.ReplaceOne(ctx, bson.M{"_id": override.WeddingID}, override, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save retention override: %w", err)
+	}
+	return nil
+}
+
+// DeleteOverride removes the retention override of a wedding
+func (r *retentionRepository) DeleteOverride(ctx context.Context, weddingID models.ID) error {
+	result, err := r.overrides.DeleteOne(ctx, bson.M{"_id": weddingID})
+	if err != nil {
+		return fmt.Errorf("failed to delete retention override: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// rawEventRetention returns the shortest period a retention override keeps the
+// raw analytics events of a wedding for, or 0 when it has none
+func rawEventRetention(ctx context.Context, db *mongo.Database, weddingID models.ID) (time.Duration, error) {
+	var override models.RetentionOverride
+	err := db.Collection("retention_overrides").FindOne(ctx, bson.M{"_id": weddingID}).Decode(&override)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get retention override: %w", err)
+	}
+	var shortest time.Duration
+	for _, dataType := range []string{models.RetentionPageViews, models.RetentionRSVPEvents, models.RetentionConversions} {
+		if period := override.Period(dataType); period > 0 && (shortest == 0 || period < shortest) {
+			shortest = period
+		}
+	}
+	return shortest, nil
+}
@@ -2,10 +2,10 @@ package mongodb
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"wedding-invitation-backend/internal/domain/models"
@@ -27,7 +27,7 @@ func (r *mongoRSVPRepository) Create(ctx context.Context, rsvp *models.RSVP) err
 	return err
 }
 
-func (r *mongoRSVPRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.RSVP, error) {
+func (r *mongoRSVPRepository) GetByID(ctx context.Context, id models.ID) (*models.RSVP, error) {
 	var rsvp models.RSVP
 	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&rsvp)
 	if err != nil {
@@ -39,7 +39,7 @@ func (r *mongoRSVPRepository) GetByID(ctx context.Context, id primitive.ObjectID
 	return &rsvp, nil
 }
 
-func (r *mongoRSVPRepository) GetByEmail(ctx context.Context, weddingID primitive.ObjectID, email string) (*models.RSVP, error) {
+func (r *mongoRSVPRepository) GetByEmail(ctx context.Context, weddingID models.ID, email string) (*models.RSVP, error) {
 	var rsvp models.RSVP
 	err := r.collection.FindOne(ctx, bson.M{
 		"wedding_id": weddingID,
@@ -54,7 +54,7 @@ func (r *mongoRSVPRepository) GetByEmail(ctx context.Context, weddingID primitiv
 	return &rsvp, nil
 }
 
-func (r *mongoRSVPRepository) ListByWedding(ctx context.Context, weddingID primitive.ObjectID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error) {
+func (r *mongoRSVPRepository) ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error) {
 	filter := bson.M{"wedding_id": weddingID}
 
 	// Apply filters
@@ -64,6 +64,9 @@ func (r *mongoRSVPRepository) ListByWedding(ctx context.Context, weddingID primi
 	if filters.Source != "" {
 		filter["source"] = filters.Source
 	}
+	if filters.ReviewStatus != "" {
+		filter["review.status"] = filters.ReviewStatus
+	}
 	if filters.Search != "" {
 		filter["$or"] = []bson.M{
 			{"first_name": bson.M{"$regex": filters.Search, "$options": "i"}},
@@ -118,14 +121,14 @@ func (r *mongoRSVPRepository) Update(ctx context.Context, rsvp *models.RSVP) err
 	return err
 }
 
-func (r *mongoRSVPRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+func (r *mongoRSVPRepository) Delete(ctx context.Context, id models.ID) error {
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
 	return err
 }
 
-func (r *mongoRSVPRepository) GetStatistics(ctx context.Context, weddingID primitive.ObjectID) (*models.RSVPStatistics, error) {
-	// Match stage
-	matchStage := bson.D{{"$match", bson.D{{"wedding_id", weddingID}}}}
+func (r *mongoRSVPRepository) GetStatistics(ctx context.Context, weddingID models.ID) (*models.RSVPStatistics, error) {
+	// Match stage; flagged RSVPs only count once accepted by the owner
+	matchStage := bson.D{{"$match", countedRSVPsOf(weddingID)}}
 
 	// Group by status to get counts
 	groupStage := bson.D{
@@ -199,13 +202,62 @@ func (r *mongoRSVPRepository) GetStatistics(ctx context.Context, weddingID primi
 		}
 	}
 
+	// Get per-session counts of multi-session weddings
+	sessionPipeline := mongo.Pipeline{
+		matchStage,
+		bson.D{{"$unwind", "$sessions"}},
+		bson.D{
+			{"$group", bson.D{
+				{"_id", bson.D{{"session", "$sessions.session_id"}, {"status", "$sessions.status"}}},
+				{"count", bson.D{{"$sum", 1}}},
+				{"guests", bson.D{{"$sum", bson.D{{"$add", bson.A{"$attendance_count", "$plus_one_count"}}}}}},
+			}},
+		},
+		bson.D{{"$sort", bson.D{{"_id.session", 1}}}},
+	}
+
+	sessionCursor, err := r.collection.Aggregate(ctx, sessionPipeline)
+	if err == nil {
+		defer sessionCursor.Close(ctx)
+		index := make(map[string]int)
+		for sessionCursor.Next(ctx) {
+			var result struct {
+				ID struct {
+					Session string `bson:"session"`
+					Status  string `bson:"status"`
+				} `bson:"_id"`
+				Count  int `bson:"count"`
+				Guests int `bson:"guests"`
+			}
+			if err := sessionCursor.Decode(&result); err != nil {
+				continue
+			}
+
+			i, ok := index[result.ID.Session]
+			if !ok {
+				i = len(stats.Sessions)
+				index[result.ID.Session] = i
+				stats.Sessions = append(stats.Sessions, models.SessionStatistics{SessionID: result.ID.Session})
+			}
+			switch result.ID.Status {
+			case "attending":
+				stats.Sessions[i].Attending = result.Count
+				stats.Sessions[i].TotalGuests = result.Guests
+			case "not-attending":
+				stats.Sessions[i].NotAttending = result.Count
+			case "maybe":
+				stats.Sessions[i].Maybe = result.Count
+			}
+		}
+	}
+
 	// Get submission trend for last 30 days
 	stats.SubmissionTrend, _ = r.GetSubmissionTrend(ctx, weddingID, 30)
 
 	return stats, nil
 }
 
-func (r *mongoRSVPRepository) MarkConfirmationSent(ctx context.Context, id primitive.ObjectID) error {
+func (r *mongoRSVPRepository) MarkConfirmationSent(ctx context.Context, id models.ID) error {
 	now := time.Now()
 	_, err := r.collection.UpdateOne(
 		ctx,
@@ -220,7 +272,7 @@ func (r *mongoRSVPRepository) MarkConfirmationSent(ctx context.Context, id primi
 	return err
 }
 
-func (r *mongoRSVPRepository) GetSubmissionTrend(ctx context.Context, weddingID primitive.ObjectID, days int) ([]models.DailyCount, error) {
+func (r *mongoRSVPRepository) GetSubmissionTrend(ctx context.Context, weddingID models.ID, days int) ([]models.DailyCount, error) {
 	startDate := time.Now().AddDate(0, 0, -days).Truncate(24 * time.Hour)
 
 	matchStage := bson.D{
@@ -275,3 +327,143 @@ func (r *mongoRSVPRepository) GetSubmissionTrend(ctx context.Context, weddingID
 
 	return trend, nil
 }
+
+// streamBatchSize bounds how many documents the driver buffers per round trip while streaming
+const streamBatchSize = 500
+
+func (r *mongoRSVPRepository) StreamByWedding(ctx context.Context, weddingID models.ID, fn func(*models.RSVP) error) error {
+	opts := options.Find().
+		SetBatchSize(streamBatchSize).
+		SetSort(bson.D{{Key: "submitted_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"wedding_id": weddingID}, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var rsvp models.RSVP
+		if err := cursor.Decode(&rsvp); err != nil {
+			return err
+		}
+		if err := fn(&rsvp); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (r *mongoRSVPRepository) CountByIPSince(ctx context.Context, weddingID models.ID, ipAddress string, since time.Time) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{
+		"wedding_id":   weddingID,
+		"ip_address":   ipAddress,
+		"submitted_at": bson.M{"$gte": since},
+	})
+}
+
+// countedRSVPsOf matches the RSVPs of a wedding that count towards its statistics;
+// flagged RSVPs only count once accepted by the owner
+func countedRSVPsOf(weddingID models.ID) bson.M {
+	return bson.M{
+		"wedding_id":    weddingID,
+		"review.status": bson.M{"$nin": bson.A{models.RSVPReviewPending, models.RSVPReviewRejected}},
+	}
+}
+
+// GetAttendanceTimeline counts the counted RSVPs of a wedding by submission day and answer
+func (r *mongoRSVPRepository) GetAttendanceTimeline(ctx context.Context, weddingID models.ID) ([]models.AttendanceDay, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: countedRSVPsOf(weddingID)}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"date":   bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$submitted_at"}},
+				"status": "$status",
+			},
+			"count":     bson.M{"$sum": 1},
+			"headcount": bson.M{"$sum": "$attendance_count"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.date", Value: 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate attendance timeline: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	timeline := []models.AttendanceDay{}
+	for cursor.Next(ctx) {
+		var result struct {
+			ID struct {
+				Date   string `bson:"date"`
+				Status string `bson:"status"`
+			} `bson:"_id"`
+			Count     int `bson:"count"`
+			Headcount int `bson:"headcount"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode attendance timeline: %w", err)
+		}
+
+		if len(timeline) == 0 || timeline[len(timeline)-1].Date != result.ID.Date {
+			timeline = append(timeline, models.AttendanceDay{Date: result.ID.Date})
+		}
+		day := &timeline[len(timeline)-1]
+		switch result.ID.Status {
+		case string(models.RSVPAttending):
+			day.Attending += result.Count
+			day.Headcount += result.Headcount
+		case string(models.RSVPNotAttending):
+			day.NotAttending += result.Count
+		case string(models.RSVPMaybe):
+			day.Maybe += result.Count
+			day.MaybeHeadcount += result.Headcount
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read attendance timeline: %w", err)
+	}
+	return timeline, nil
+}
+
+// GetMealCounts counts the meal choices of everyone the counted attending RSVPs of a wedding bring
+func (r *mongoRSVPRepository) GetMealCounts(ctx context.Context, weddingID models.ID) (map[string]int, error) {
+	match := countedRSVPsOf(weddingID)
+	match["status"] = string(models.RSVPAttending)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		// One meal for the guest and one for each plus one, "" when not chosen
+		{{Key: "$project", Value: bson.M{"meals": bson.M{"$concatArrays": bson.A{
+			bson.A{bson.M{"$ifNull": bson.A{"$meal_choice", ""}}},
+			bson.M{"$map": bson.M{
+				"input": bson.M{"$ifNull": bson.A{"$plus_ones", bson.A{}}},
+				"as":    "plus_one",
+				"in":    bson.M{"$ifNull": bson.A{"$$plus_one.meal_choice", ""}},
+			}},
+		}}}}},
+		{{Key: "$unwind", Value: "$meals"}},
+		{{Key: "$group", Value: bson.M{"_id": "$meals", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate meal counts: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Meal  string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to read meal counts: %w", err)
+	}
+
+	counts := make(map[string]int, len(results))
+	for _, result := range results {
+		counts[result.Meal] = result.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,183 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure rsvpSubmissionRepository implements the domain repository interface
+var _ repository.RSVPSubmissionRepository = (*rsvpSubmissionRepository)(nil)
+
+type rsvpSubmissionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewRSVPSubmissionRepository creates a new MongoDB-backed RSVP submission queue
+func NewRSVPSubmissionRepository(db *mongo.Database) repository.RSVPSubmissionRepository {
+	return &rsvpSubmissionRepository{
+		collection: db.Collection("rsvp_submissions"),
+	}
+}
+
+// Enqueue inserts a new queued submission
+func (r *rsvpSubmissionRepository) Enqueue(ctx context.Context, submission *models.RSVPSubmission) error {
+	if submission.ID.IsZero() {
+		submission.ID = models.NewID()
+	}
+	submission.Status = models.RSVPSubmissionQueued
+	submission.CreatedAt = time.Now()
+
+	if _, err := r.collection.InsertOne(ctx, submission); err != nil {
+		return fmt.Errorf("failed to enqueue RSVP submission: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a submission by ID
+func (r *rsvpSubmissionRepository) GetByID(ctx context.Context, id models.ID) (*models.RSVPSubmission, error) {
+	var submission models.RSVPSubmission
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&submission)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get RSVP submission: %w", err)
+	}
+	return &submission, nil
+}
+
+// ClaimNext leases the oldest submission in the partition that is queued and due
+// for another attempt, or whose lease expired (e.g. the worker crashed), skipping the submissions of guests
+// with an earlier submission still queued or leased. A guest's submissions are
+// thus applied one at a time and in order, whichever worker or replica claims them.
+func (r *rsvpSubmissionRepository) ClaimNext(ctx context.Context, partition int, now time.Time, lease time.Duration) (*models.RSVPSubmission, error) {
+	claimable := bson.A{
+		// Released submissions wait out their backoff until locked_until
+		bson.M{
+			"status":       models.RSVPSubmissionQueued,
+			"locked_until": bson.M{"$not": bson.M{"$gt": now}},
+		},
+		bson.M{
+			"status":       models.RSVPSubmissionProcessing,
+			"locked_until": bson.M{"$lt": now},
+		},
+	}
+	cursor, err := r.collection.Find(ctx, bson.M{"partition": partition, "$or": claimable}, options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetProjection(bson.M{"guest_key": 1, "created_at": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find RSVP submissions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":       models.RSVPSubmissionProcessing,
+			"locked_until": now.Add(lease),
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	for cursor.Next(ctx) {
+		var candidate models.RSVPSubmission
+		if err := cursor.Decode(&candidate); err != nil {
+			return nil, fmt.Errorf("failed to decode RSVP submission: %w", err)
+		}
+
+		waiting, err := r.hasEarlierUnfinished(ctx, &candidate)
+		if err != nil {
+			return nil, err
+		}
+		if waiting {
+			continue
+		}
+
+		var submission models.RSVPSubmission
+		err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": candidate.ID, "$or": claimable}, update,
+			options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&submission)
+		if err == mongo.ErrNoDocuments {
+			// Claimed by another worker in the meantime
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim RSVP submission: %w", err)
+		}
+		return &submission, nil
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read RSVP submissions: %w", err)
+	}
+	return nil, repository.ErrNotFound
+}
+
+// hasEarlierUnfinished reports whether a submission of the same guest queued
+// before it is still queued or being processed
+func (r *rsvpSubmissionRepository) hasEarlierUnfinished(ctx context.Context, submission *models.RSVPSubmission) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{
+		"guest_key": submission.GuestKey,
+		"status":    bson.M{"$in": bson.A{models.RSVPSubmissionQueued, models.RSVPSubmissionProcessing}},
+		"$or": bson.A{
+			bson.M{"created_at": bson.M{"$lt": submission.CreatedAt}},
+			bson.M{"created_at": submission.CreatedAt, "_id": bson.M{"$lt": submission.ID}},
+		},
+	}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to check earlier RSVP submissions: %w", err)
+	}
+	return count > 0, nil
+}
+
+// MarkCompleted records the persisted RSVP for a submission
+func (r *rsvpSubmissionRepository) MarkCompleted(ctx context.Context, id, rsvpID models.ID) error {
+	// The payload now lives in the rsvps collection, so drop the copy
+	return r.finish(ctx, id, bson.M{
+		"status":  models.RSVPSubmissionCompleted,
+		"rsvp_id": rsvpID,
+	}, bson.M{"locked_until": "", "rsvp": ""})
+}
+
+// MarkFailed records a permanent failure for a submission
+func (r *rsvpSubmissionRepository) MarkFailed(ctx context.Context, id models.ID, reason string) error {
+	// The RSVP is never saved, so don't keep the guest's details around
+	return r.finish(ctx, id, bson.M{
+		"status": models.RSVPSubmissionFailed,
+		"error":  reason,
+	}, bson.M{"locked_until": "", "rsvp": ""})
+}
+
+// Release returns a submission to the queue so it is retried from retryAt
+func (r *rsvpSubmissionRepository) Release(ctx context.Context, id models.ID, retryAt time.Time, reason string) error {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"status": models.RSVPSubmissionQueued, "locked_until": retryAt, "error": reason},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release RSVP submission: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *rsvpSubmissionRepository) finish(ctx context.Context, id models.ID, fields, unset bson.M) error {
+	fields["processed_at"] = time.Now()
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set":   fields,
+		"$unset": unset,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update RSVP submission: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure sessionRepository implements the domain repository interface
+var _ repository.SessionRepository = (*sessionRepository)(nil)
+
+type sessionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSessionRepository creates a new MongoDB session repository
+func NewSessionRepository(db *mongo.Database) repository.SessionRepository {
+	return &sessionRepository{
+		collection: db.Collection("sessions"),
+	}
+}
+
+// Create stores a new session
+func (r *sessionRepository) Create(ctx context.Context, session *models.Session) error {
+	if session.ID.IsZero() {
+		session.ID = models.NewID()
+	}
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = time.Now()
+	}
+
+	if _, err := r.collection.InsertOne(ctx, session); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a session by ID
+func (r *sessionRepository) GetByID(ctx context.Context, id models.ID) (*models.Session, error) {
+	var session models.Session
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&session); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return &session, nil
+}
+
+// ListActiveByUser lists the user's unexpired sessions, most recently seen first
+func (r *sessionRepository) ListActiveByUser(ctx context.Context, userID models.ID, now time.Time) ([]*models.Session, error) {
+	cursor, err := r.collection.Find(ctx,
+		bson.M{"user_id": userID, "expires_at": bson.M{"$gt": now}},
+		options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	sessions := []*models.Session{}
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, fmt.Errorf("failed to decode sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// Rotate replaces the session's refresh token if previousTokenID is still its current one
+func (r *sessionRepository) Rotate(ctx context.Context, session *models.Session, previousTokenID string) error {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": session.ID, "refresh_token_id": previousTokenID},
+		bson.M{"$set": bson.M{
+			"refresh_token_id": session.RefreshTokenID,
+			"ip":               session.IP,
+			"user_agent":       session.UserAgent,
+			"device":           session.Device,
+			"browser":          session.Browser,
+			"os":               session.OS,
+			"last_seen_at":     session.LastSeenAt,
+			"expires_at":       session.ExpiresAt,
+		}})
+	if err != nil {
+		return fmt.Errorf("failed to rotate session: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Delete ends one of the user's sessions
+func (r *sessionRepository) Delete(ctx context.Context, userID, id models.ID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteByUser ends all of the user's sessions except the given one
+func (r *sessionRepository) DeleteByUser(ctx context.Context, userID models.ID, except *models.ID) (int64, error) {
+	filter := bson.M{"user_id": userID}
+	if except != nil {
+		filter["_id"] = bson.M{"$ne": *except}
+	}
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sessions: %w", err)
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,65 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure slugRedirectRepository implements the domain repository interface
+var _ repository.SlugRedirectRepository = (*slugRedirectRepository)(nil)
+
+type slugRedirectRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSlugRedirectRepository creates a new MongoDB slug redirect repository
+func NewSlugRedirectRepository(db *mongo.Database) repository.SlugRedirectRepository {
+	return &slugRedirectRepository{collection: db.Collection("slug_redirects")}
+}
+
+// Save points the slug at its wedding, replacing an earlier redirect of the slug
+func (r *slugRedirectRepository) Save(ctx context.Context, redirect *models.SlugRedirect) error {
+	redirect.CreatedAt = time.Now()
+
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": redirect.Slug}, redirect, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save slug redirect: %w", err)
+	}
+	return nil
+}
+
+// GetBySlug retrieves the redirect of an old slug
+func (r *slugRedirectRepository) GetBySlug(ctx context.Context, slug string) (*models.SlugRedirect, error) {
+	var redirect models.SlugRedirect
+	if err := r.collection.FindOne(ctx, bson.M{"_id": slug}).Decode(&redirect); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get slug redirect: %w", err)
+	}
+	return &redirect, nil
+}
+
+// Delete removes the redirect of a slug, if any
+func (r *slugRedirectRepository) Delete(ctx context.Context, slug string) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": slug}); err != nil {
+		return fmt.Errorf("failed to delete slug redirect: %w", err)
+	}
+	return nil
+}
+
+// DeleteByWedding removes the redirects of a wedding, releasing its old slugs
+func (r *slugRedirectRepository) DeleteByWedding(ctx context.Context, weddingID models.ID) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"wedding_id": weddingID}); err != nil {
+		return fmt.Errorf("failed to delete slug redirects: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure songRequestRepository implements the domain repository interface
+var _ repository.SongRequestRepository = (*songRequestRepository)(nil)
+
+type songRequestRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSongRequestRepository creates a new MongoDB song request repository
+func NewSongRequestRepository(db *mongo.Database) repository.SongRequestRepository {
+	return &songRequestRepository{
+		collection: db.Collection("song_requests"),
+	}
+}
+
+// Request stores a song request or counts it on the wedding's request with the
+// same key. The unique index on wedding_id and key makes concurrent requests of
+// a new song collapse too: the one losing the race is retried as a duplicate.
+func (r *songRequestRepository) Request(ctx context.Context, request *models.SongRequest, requester string, maxRequesters int) (*models.SongRequest, error) {
+	now := time.Now()
+	filter := bson.M{"wedding_id": request.WeddingID, "key": request.Key}
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"_id":        models.NewID(),
+			"title":      request.Title,
+			"artist":     request.Artist,
+			"filtered":   request.Filtered,
+			"status":     request.Status,
+			"created_at": now,
+		},
+		"$inc": bson.M{"requests": 1},
+		"$set": bson.M{"updated_at": now},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var stored models.SongRequest
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&stored)
+	if mongo.IsDuplicateKeyError(err) {
+		err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&stored)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save song request: %w", err)
+	}
+
+	if requester != "" && len(stored.RequestedBy) < maxRequesters {
+		// Only add the name while the list is short, whoever got there first
+		result, err := r.collection.UpdateOne(ctx,
+			bson.M{"_id": stored.ID, fmt.Sprintf("requested_by.%d", maxRequesters-1): bson.M{"$exists": false}},
+			bson.M{"$addToSet": bson.M{"requested_by": requester}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to save song requester: %w", err)
+		}
+		if result.ModifiedCount > 0 {
+			stored.RequestedBy = append(stored.RequestedBy, requester)
+		}
+	}
+	return &stored, nil
+}
+
+// GetByID retrieves a song request by ID
+func (r *songRequestRepository) GetByID(ctx context.Context, id models.ID) (*models.SongRequest, error) {
+	var request models.SongRequest
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&request); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get song request: %w", err)
+	}
+	return &request, nil
+}
+
+// SetStatus approves or rejects a song request
+func (r *songRequestRepository) SetStatus(ctx context.Context, id models.ID, status models.SongRequestStatus, moderatedBy models.ID, moderatedAt time.Time) error {
+	update := bson.M{"$set": bson.M{
+		"status":       status,
+		"moderated_by": moderatedBy,
+		"moderated_at": moderatedAt,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to moderate song request: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes a song request
+func (r *songRequestRepository) Delete(ctx context.Context, id models.ID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete song request: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// ListByWedding lists the song requests of a wedding, most requested first and
+// then the first requested
+func (r *songRequestRepository) ListByWedding(ctx context.Context, weddingID models.ID, status models.SongRequestStatus, page, pageSize int) ([]*models.SongRequest, int64, error) {
+	filter := bson.M{"wedding_id": weddingID}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count song requests: %w", err)
+	}
+
+	skip := (page - 1) * pageSize
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "requests", Value: -1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list song requests: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	requests := []*models.SongRequest{}
+	if err := cursor.All(ctx, &requests); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode song requests: %w", err)
+	}
+	return requests, total, nil
+}
@@ -0,0 +1,53 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure systemRepository implements the domain repository interface
+var _ repository.SystemRepository = (*systemRepository)(nil)
+
+type systemRepository struct {
+	config *mongo.Collection
+}
+
+// NewSystemRepository creates a new MongoDB system repository
+func NewSystemRepository(db *mongo.Database) repository.SystemRepository {
+	return &systemRepository{
+		config: db.Collection("system_config"),
+	}
+}
+
+// GetConfig retrieves the singleton system configuration
+func (r *systemRepository) GetConfig(ctx context.Context) (*models.SystemConfig, error) {
+	var config models.SystemConfig
+	err := r.config.FindOne(ctx, bson.M{"_id": models.SystemConfigID}).Decode(&config)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get system config: %w", err)
+	}
+	return &config, nil
+}
+
+// SaveConfig creates or replaces the singleton system configuration
+func (r *systemRepository) SaveConfig(ctx context.Context, config *models.SystemConfig) error {
+	config.ID = models.SystemConfigID
+	config.UpdatedAt = time.Now()
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := r.config.ReplaceOne(ctx, bson.M{"_id": config.ID}, config, opts); err != nil {
+		return fmt.Errorf("failed to save system config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure tenantWebhookRepository implements the domain repository interface
+var _ repository.TenantWebhookRepository = (*tenantWebhookRepository)(nil)
+
+type tenantWebhookRepository struct {
+	webhooks    *mongo.Collection
+	signingKeys *mongo.Collection
+	outbox      *mongo.Collection
+	deliveries  *mongo.Collection
+}
+
+// NewTenantWebhookRepository creates a new MongoDB tenant webhook repository
+func NewTenantWebhookRepository(db *mongo.Database) repository.TenantWebhookRepository {
+	return &tenantWebhookRepository{
+		webhooks:    db.Collection("tenant_webhooks"),
+		signingKeys: db.Collection("tenant_signing_keys"),
+		outbox:      db.Collection("lifecycle_outbox"),
+		deliveries:  db.Collection("tenant_webhook_deliveries"),
+	}
+}
+
+// CreateWebhook inserts a new tenant webhook
+func (r *tenantWebhookRepository) CreateWebhook(ctx context.Context, webhook *models.TenantWebhook) error {
+	if webhook.ID.IsZero() {
+		webhook.ID = models.NewID()
+	}
+	now := time.Now()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	if _, err := r.webhooks.InsertOne(ctx, webhook); err != nil {
+		return fmt.Errorf("failed to insert tenant webhook: %w", err)
+	}
+	return nil
+}
+
+// GetWebhook retrieves a tenant webhook by ID
+func (r *tenantWebhookRepository) GetWebhook(ctx context.Context, id models.ID) (*models.TenantWebhook, error) {
+	var webhook models.TenantWebhook
+	err := r.webhooks.FindOne(ctx, bson.M{"_id": id}).Decode(&webhook)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get tenant webhook: %w", err)
+	}
+	return &webhook, nil
+}
+
+// ListWebhooks retrieves all webhooks for a tenant
+func (r *tenantWebhookRepository) ListWebhooks(ctx context.Context, tenantID string) ([]*models.TenantWebhook, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.webhooks.Find(ctx, bson.M{"tenant_id": tenantID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenant webhooks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var webhooks []*models.TenantWebhook
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, fmt.Errorf("failed to decode tenant webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// UpdateWebhook updates an existing tenant webhook
+func (r *tenantWebhookRepository) UpdateWebhook(ctx context.Context, webhook *models.TenantWebhook) error {
+	webhook.UpdatedAt = time.Now()
+
+	result, err := r.webhooks.UpdateOne(ctx, bson.M{"_id": webhook.ID}, bson.M{"$set": webhook})
+	if err != nil {
+		return fmt.Errorf("failed to update tenant webhook: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteWebhook removes a tenant webhook and its delivery log
+func (r *tenantWebhookRepository) DeleteWebhook(ctx context.Context, id models.ID) error {
+	result, err := r.webhooks.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete tenant webhook: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+
+	if _, err := r.deliveries.DeleteMany(ctx, bson.M{"webhook_id": id}); err != nil {
+		return fmt.Errorf("failed to delete tenant webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// GetSigningKey retrieves the signing key for a tenant
+func (r *tenantWebhookRepository) GetSigningKey(ctx context.Context, tenantID string) (*models.TenantSigningKey, error) {
+	var key models.TenantSigningKey
+	err := r.signingKeys.FindOne(ctx, bson.M{"_id": tenantID}).Decode(&key)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get tenant signing key: %w", err)
+	}
+	return &key, nil
+}
+
+// SaveSigningKey creates or replaces the signing key for a tenant
+func (r *tenantWebhookRepository) SaveSigningKey(ctx context.Context, key *models.TenantSigningKey) error {
+	opts := options.Replace().SetUpsert(true)
+	if _, err := r.signingKeys.ReplaceOne(ctx, bson.M{"_id": key.TenantID}, key, opts); err != nil {
+		return fmt.Errorf("failed to save tenant signing key: %w", err)
+	}
+	return nil
+}
+
+// AppendEvent writes a lifecycle event to the outbox
+func (r *tenantWebhookRepository) AppendEvent(ctx context.Context, event *models.LifecycleEvent) error {
+	if event.ID.IsZero() {
+		event.ID = models.NewID()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	if _, err := r.outbox.InsertOne(ctx, event); err != nil {
+		return fmt.Errorf("failed to append lifecycle event: %w", err)
+	}
+	return nil
+}
+
+// ListPendingEvents retrieves undispatched outbox events, oldest first
+func (r *tenantWebhookRepository) ListPendingEvents(ctx context.Context, limit int) ([]*models.LifecycleEvent, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.outbox.Find(ctx, bson.M{"dispatched": false}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending lifecycle events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var events []*models.LifecycleEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("failed to decode lifecycle events: %w", err)
+	}
+	return events, nil
+}
+
+// MarkEventDispatched flags an outbox event as dispatched
+func (r *tenantWebhookRepository) MarkEventDispatched(ctx context.Context, id models.ID, dispatchedAt time.Time) error {
+	_, err := r.outbox.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"dispatched": true, "dispatched_at": dispatchedAt},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark lifecycle event dispatched: %w", err)
+	}
+	return nil
+}
+
+// ListEventsSince retrieves a tenant's outbox events at or after since, oldest first
+func (r *tenantWebhookRepository) ListEventsSince(ctx context.Context, tenantID string, since time.Time, limit int) ([]*models.LifecycleEvent, error) {
+	filter := bson.M{
+		"tenant_id":   tenantID,
+		"occurred_at": bson.M{"$gte": since},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.outbox.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lifecycle events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var events []*models.LifecycleEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("failed to decode lifecycle events: %w", err)
+	}
+	return events, nil
+}
+
+// CreateDelivery records a delivery attempt
+func (r *tenantWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.TenantWebhookDelivery) error {
+	if delivery.ID.IsZero() {
+		delivery.ID = models.NewID()
+	}
+	if delivery.AttemptedAt.IsZero() {
+		delivery.AttemptedAt = time.Now()
+	}
+
+	if _, err := r.deliveries.InsertOne(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to insert tenant webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries retrieves the delivery log for a webhook, newest first
+func (r *tenantWebhookRepository) ListDeliveries(ctx context.Context, webhookID models.ID, page, pageSize int) ([]*models.TenantWebhookDelivery, int64, error) {
+	filter := bson.M{"webhook_id": webhookID}
+
+	total, err := r.deliveries.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count tenant webhook deliveries: %w", err)
+	}
+
+	skip := (page - 1) * pageSize
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "attempted_at", Value: -1}})
+
+	cursor, err := r.deliveries.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list tenant webhook deliveries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []*models.TenantWebhookDelivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode tenant webhook deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
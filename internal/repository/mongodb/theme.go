@@ -0,0 +1,136 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure themeRepository implements the domain repository interface
+var _ repository.ThemeRepository = (*themeRepository)(nil)
+
+type themeRepository struct {
+	themes *mongo.Collection
+}
+
+// NewThemeRepository creates a new MongoDB theme repository
+func NewThemeRepository(db *mongo.Database) repository.ThemeRepository {
+	return &themeRepository{
+		themes: db.Collection("themes"),
+	}
+}
+
+// Create inserts a new theme
+func (r *themeRepository) Create(ctx context.Context, theme *models.Theme) error {
+	now := time.Now()
+	theme.CreatedAt = now
+	theme.UpdatedAt = now
+
+	if _, err := r.themes.InsertOne(ctx, theme); err != nil {
+		return fmt.Errorf("failed to insert theme: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a theme by ID
+func (r *themeRepository) GetByID(ctx context.Context, id string) (*models.Theme, error) {
+	var theme models.Theme
+	err := r.themes.FindOne(ctx, bson.M{"_id": id}).Decode(&theme)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get theme: %w", err)
+	}
+	return &theme, nil
+}
+
+// List retrieves the theme catalog
+func (r *themeRepository) List(ctx context.Context, activeOnly bool) ([]*models.Theme, error) {
+	filter := bson.M{}
+	if activeOnly {
+		filter["active"] = true
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "is_default", Value: -1}, {Key: "name", Value: 1}})
+	cursor, err := r.themes.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list themes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var themes []*models.Theme
+	if err := cursor.All(ctx, &themes); err != nil {
+		return nil, fmt.Errorf("failed to decode themes: %w", err)
+	}
+	return themes, nil
+}
+
+// Update replaces a theme, keeping its creation time
+func (r *themeRepository) Update(ctx context.Context, theme *models.Theme) error {
+	theme.UpdatedAt = time.Now()
+
+	update := bson.M{"$set": themeFields(theme)}
+	result, err := r.themes.UpdateOne(ctx, bson.M{"_id": theme.ID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update theme: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes a theme from the catalog
+func (r *themeRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.themes.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete theme: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Upsert creates a theme or refreshes an existing one, keeping its creation time
+func (r *themeRepository) Upsert(ctx context.Context, theme *models.Theme) error {
+	now := time.Now()
+	theme.UpdatedAt = now
+
+	update := bson.M{
+		"$set":         themeFields(theme),
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	if _, err := r.themes.UpdateOne(ctx, bson.M{"_id": theme.ID}, update, opts); err != nil {
+		return fmt.Errorf("failed to upsert theme: %w", err)
+	}
+	return nil
+}
+
+// themeFields are the fields of a theme that updates may change
+func themeFields(theme *models.Theme) bson.M {
+	return bson.M{
+		"name":            theme.Name,
+		"description":     theme.Description,
+		"primary_color":   theme.PrimaryColor,
+		"secondary_color": theme.SecondaryColor,
+		"font_family":     theme.FontFamily,
+		"preview_images":  theme.PreviewImages,
+		"sections":        theme.Sections,
+		"color_scheme":    theme.ColorScheme,
+		"is_default":      theme.IsDefault,
+		"premium":         theme.Premium,
+		"active":          theme.Active,
+		"updated_at":      theme.UpdatedAt,
+	}
+}
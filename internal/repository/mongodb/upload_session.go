@@ -0,0 +1,105 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure uploadSessionRepository implements the domain repository interface
+var _ repository.UploadSessionRepository = (*uploadSessionRepository)(nil)
+
+type uploadSessionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewUploadSessionRepository creates a new MongoDB resumable upload session repository
+func NewUploadSessionRepository(db *mongo.Database) repository.UploadSessionRepository {
+	return &uploadSessionRepository{
+		collection: db.Collection("upload_sessions"),
+	}
+}
+
+// Create stores a new upload session
+func (r *uploadSessionRepository) Create(ctx context.Context, session *models.UploadSession) error {
+	if session.ID.IsZero() {
+		session.ID = models.NewID()
+	}
+	now := time.Now()
+	session.CreatedAt = now
+	session.UpdatedAt = now
+
+	if _, err := r.collection.InsertOne(ctx, session); err != nil {
+		return fmt.Errorf("failed to create upload session: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an upload session by ID
+func (r *uploadSessionRepository) GetByID(ctx context.Context, id models.ID) (*models.UploadSession, error) {
+	var session models.UploadSession
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&session); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get upload session: %w", err)
+	}
+	return &session, nil
+}
+
+// Advance records received bytes, unless another request moved the offset first
+func (r *uploadSessionRepository) Advance(ctx context.Context, id models.ID, from, to int64, expiresAt time.Time) error {
+	filter := bson.M{"_id": id, "offset": from}
+	update := bson.M{"$set": bson.M{
+		"offset":     to,
+		"expires_at": expiresAt,
+		"updated_at": time.Now(),
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to advance upload session: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes an upload session
+func (r *uploadSessionRepository) Delete(ctx context.Context, id models.ID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete upload session: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// ListExpired retrieves sessions that expired before now, oldest first
+func (r *uploadSessionRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*models.UploadSession, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "expires_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, bson.M{"expires_at": bson.M{"$lt": now}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired upload sessions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	sessions := []*models.UploadSession{}
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, fmt.Errorf("failed to decode upload sessions: %w", err)
+	}
+	return sessions, nil
+}
@@ -30,9 +30,9 @@ func (r *MongoUserRepository) Create(ctx context.Context, user *models.User) err
 }
 
 // GetByID retrieves a user by ID
-func (r *MongoUserRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+func (r *MongoUserRepository) GetByID(ctx context.Context, id models.ID) (*models.User, error) {
 	var user models.User
-	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&user)
+	err := r.collection.FindOne(ctx, tenantScoped(ctx, bson.M{"_id": id})).Decode(&user)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
@@ -46,7 +46,7 @@ func (r *MongoUserRepository) GetByID(ctx context.Context, id primitive.ObjectID
 // GetByEmail retrieves a user by email
 func (r *MongoUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	err := r.collection.FindOne(ctx, bson.M{"email": email}).Decode(&user)
+	err := r.collection.FindOne(ctx, tenantScoped(ctx, bson.M{"email": email})).Decode(&user)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
@@ -89,22 +89,22 @@ func (r *MongoUserRepository) GetByResetToken(ctx context.Context, token string)
 func (r *MongoUserRepository) Update(ctx context.Context, user *models.User) error {
 	_, err := r.collection.UpdateOne(
 		ctx,
-		bson.M{"_id": user.ID},
+		tenantScoped(ctx, bson.M{"_id": user.ID}),
 		bson.M{"$set": user},
 	)
 	return err
 }
 
 // Delete removes a user from the database
-func (r *MongoUserRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
-	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+func (r *MongoUserRepository) Delete(ctx context.Context, id models.ID) error {
+	_, err := r.collection.DeleteOne(ctx, tenantScoped(ctx, bson.M{"_id": id}))
 	return err
 }
 
 // List retrieves a paginated list of users with optional filters
 func (r *MongoUserRepository) List(ctx context.Context, page, pageSize int, filters repository.UserFilters) ([]*models.User, int64, error) {
 	// Build filter
-	filter := bson.M{}
+	filter := tenantScoped(ctx, bson.M{})
 
 	if filters.Status != "" {
 		filter["status"] = filters.Status
@@ -176,7 +176,7 @@ func (r *MongoUserRepository) List(ctx context.Context, page, pageSize int, filt
 }
 
 // AddWeddingID adds a wedding ID to the user's wedding_ids array
-func (r *MongoUserRepository) AddWeddingID(ctx context.Context, userID, weddingID primitive.ObjectID) error {
+func (r *MongoUserRepository) AddWeddingID(ctx context.Context, userID, weddingID models.ID) error {
 	_, err := r.collection.UpdateOne(
 		ctx,
 		bson.M{"_id": userID},
@@ -186,7 +186,7 @@ func (r *MongoUserRepository) AddWeddingID(ctx context.Context, userID, weddingI
 }
 
 // RemoveWeddingID removes a wedding ID from the user's wedding_ids array
-func (r *MongoUserRepository) RemoveWeddingID(ctx context.Context, userID, weddingID primitive.ObjectID) error {
+func (r *MongoUserRepository) RemoveWeddingID(ctx context.Context, userID, weddingID models.ID) error {
 	_, err := r.collection.UpdateOne(
 		ctx,
 		bson.M{"_id": userID},
@@ -196,7 +196,7 @@ func (r *MongoUserRepository) RemoveWeddingID(ctx context.Context, userID, weddi
 }
 
 // UpdateLastLogin updates the user's last login time
-func (r *MongoUserRepository) UpdateLastLogin(ctx context.Context, userID primitive.ObjectID) error {
+func (r *MongoUserRepository) UpdateLastLogin(ctx context.Context, userID models.ID) error {
 	now := primitive.NewDateTimeFromTime(time.Now())
 	_, err := r.collection.UpdateOne(
 		ctx,
@@ -207,7 +207,7 @@ func (r *MongoUserRepository) UpdateLastLogin(ctx context.Context, userID primit
 }
 
 // SetEmailVerified marks a user's email as verified
-func (r *MongoUserRepository) SetEmailVerified(ctx context.Context, userID primitive.ObjectID) error {
+func (r *MongoUserRepository) SetEmailVerified(ctx context.Context, userID models.ID) error {
 	now := primitive.NewDateTimeFromTime(time.Now())
 	_, err := r.collection.UpdateOne(
 		ctx,
@@ -223,6 +223,125 @@ func (r *MongoUserRepository) SetEmailVerified(ctx context.Context, userID primi
 	return err
 }
 
+// GetByBillingCustomerID retrieves a user by the payment provider's customer ID
+func (r *MongoUserRepository) GetByBillingCustomerID(ctx context.Context, customerID string) (*models.User, error) {
+	var user models.User
+	err := r.collection.FindOne(ctx, bson.M{"subscription.customer_id": customerID}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// GetByOAuthAccount retrieves the user a social login account is linked to
+func (r *MongoUserRepository) GetByOAuthAccount(ctx context.Context, provider models.AuthProvider, subject string) (*models.User, error) {
+	var user models.User
+	err := r.collection.FindOne(ctx, bson.M{
+		"oauth_accounts": bson.M{"$elemMatch": bson.M{"provider": provider, "subject": subject}},
+	}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// UpdateSubscription stores a user's subscription unless a newer one is stored already
+func (r *MongoUserRepository) UpdateSubscription(ctx context.Context, userID models.ID, subscription *models.Subscription) error {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id": userID,
+			"$or": []bson.M{
+				{"subscription.updated_at": bson.M{"$exists": false}},
+				{"subscription.updated_at": bson.M{"$lt": subscription.UpdatedAt}},
+			},
+		},
+		bson.M{"$set": bson.M{
+			"subscription": subscription,
+			"updated_at":   time.Now(),
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// RecordFailedLogin counts a wrong password against the user and returns the updated user
+func (r *MongoUserRepository) RecordFailedLogin(ctx context.Context, userID models.ID) (*models.User, error) {
+	var user models.User
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": userID},
+		bson.M{"$inc": bson.M{"login_security.failed_attempts": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// LockLogin locks logins until the given time and counts the lockout
+func (r *MongoUserRepository) LockLogin(ctx context.Context, userID models.ID, until time.Time, unlockTokenHash string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": userID},
+		bson.M{
+			"$set": bson.M{
+				"login_security.locked_until":      until,
+				"login_security.unlock_token_hash": unlockTokenHash,
+				"login_security.failed_attempts":   0,
+			},
+			"$inc": bson.M{"login_security.lockouts": 1},
+		},
+	)
+	return err
+}
+
+// UnlockLogin lifts a lockout and resets the failed attempts
+func (r *MongoUserRepository) UnlockLogin(ctx context.Context, userID models.ID) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": userID},
+		bson.M{"$unset": bson.M{
+			"login_security.locked_until":      "",
+			"login_security.unlock_token_hash": "",
+			"login_security.failed_attempts":   "",
+		}},
+	)
+	return err
+}
+
+// GetByUnlockToken retrieves the locked user an unlock link was sent to
+func (r *MongoUserRepository) GetByUnlockToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	var user models.User
+	err := r.collection.FindOne(ctx, bson.M{"login_security.unlock_token_hash": tokenHash}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
 // UpdatePassword updates a user's password
 func (r *MongoUserRepository) UpdatePassword(ctx context.Context, userID, passwordHash string) error {
 	_, err := r.collection.UpdateOne(
@@ -0,0 +1,115 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure vendorRepository implements the domain repository interface
+var _ repository.VendorRepository = (*vendorRepository)(nil)
+
+type vendorRepository struct {
+	collection *mongo.Collection
+}
+
+// NewVendorRepository creates a new MongoDB vendor repository
+func NewVendorRepository(db *mongo.Database) repository.VendorRepository {
+	return &vendorRepository{collection: db.Collection("vendors")}
+}
+
+// Create adds a vendor to a wedding
+func (r *vendorRepository) Create(ctx context.Context, vendor *models.Vendor) error {
+	if vendor.ID.IsZero() {
+		vendor.ID = models.NewID()
+	}
+	now := time.Now()
+	vendor.CreatedAt = now
+	vendor.UpdatedAt = now
+
+	if _, err := r.collection.InsertOne(ctx, vendor); err != nil {
+		return fmt.Errorf("failed to create vendor: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a vendor by ID
+func (r *vendorRepository) GetByID(ctx context.Context, id models.ID) (*models.Vendor, error) {
+	var vendor models.Vendor
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&vendor); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get vendor: %w", err)
+	}
+	return &vendor, nil
+}
+
+// ListByWedding retrieves the vendors of a wedding by name
+func (r *vendorRepository) ListByWedding(ctx context.Context, weddingID models.ID, filters repository.VendorFilters) ([]*models.Vendor, error) {
+	filter := bson.M{"wedding_id": weddingID}
+	if filters.Category != "" {
+		filter["category"] = filters.Category
+	}
+	if filters.ContractStatus != "" {
+		filter["contract_status"] = filters.ContractStatus
+	}
+	if filters.SharedOnly {
+		filter["sharing"] = bson.M{"$in": []models.VendorSharing{models.VendorSharedView, models.VendorSharedEdit}}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vendors: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	vendors := []*models.Vendor{}
+	if err := cursor.All(ctx, &vendors); err != nil {
+		return nil, fmt.Errorf("failed to decode vendors: %w", err)
+	}
+	return vendors, nil
+}
+
+// CountByWedding counts the vendors of a wedding
+func (r *vendorRepository) CountByWedding(ctx context.Context, weddingID models.ID) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"wedding_id": weddingID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count vendors: %w", err)
+	}
+	return count, nil
+}
+
+// Update replaces a vendor
+func (r *vendorRepository) Update(ctx context.Context, vendor *models.Vendor) error {
+	vendor.UpdatedAt = time.Now()
+
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": vendor.ID}, vendor)
+	if err != nil {
+		return fmt.Errorf("failed to update vendor: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes a vendor
+func (r *vendorRepository) Delete(ctx context.Context, id models.ID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete vendor: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -3,7 +3,6 @@ package mongodb
 import (
 	"context"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"time"
@@ -32,9 +31,9 @@ func (r *MongoWeddingRepository) Create(ctx context.Context, wedding *models.Wed
 }
 
 // GetByID retrieves a wedding by ID
-func (r *MongoWeddingRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Wedding, error) {
+func (r *MongoWeddingRepository) GetByID(ctx context.Context, id models.ID) (*models.Wedding, error) {
 	var wedding models.Wedding
-	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&wedding)
+	err := r.collection.FindOne(ctx, tenantScoped(ctx, bson.M{"_id": id})).Decode(&wedding)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
@@ -45,10 +44,28 @@ func (r *MongoWeddingRepository) GetByID(ctx context.Context, id primitive.Objec
 	return &wedding, nil
 }
 
+// GetByIDs retrieves the weddings with the given IDs
+func (r *MongoWeddingRepository) GetByIDs(ctx context.Context, ids []models.ID) ([]*models.Wedding, error) {
+	if len(ids) == 0 {
+		return []*models.Wedding{}, nil
+	}
+	cursor, err := r.collection.Find(ctx, tenantScoped(ctx, bson.M{"_id": bson.M{"$in": ids}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	weddings := []*models.Wedding{}
+	if err := cursor.All(ctx, &weddings); err != nil {
+		return nil, err
+	}
+	return weddings, nil
+}
+
 // GetBySlug retrieves a wedding by slug
 func (r *MongoWeddingRepository) GetBySlug(ctx context.Context, slug string) (*models.Wedding, error) {
 	var wedding models.Wedding
-	err := r.collection.FindOne(ctx, bson.M{"slug": slug}).Decode(&wedding)
+	err := r.collection.FindOne(ctx, tenantScoped(ctx, bson.M{"slug": slug})).Decode(&wedding)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
@@ -60,23 +77,26 @@ func (r *MongoWeddingRepository) GetBySlug(ctx context.Context, slug string) (*m
 }
 
 // GetByUserID retrieves weddings by user ID with pagination
-func (r *MongoWeddingRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error) {
-	// Build filter
-	filter := bson.M{"user_id": userID}
+func (r *MongoWeddingRepository) GetByUserID(ctx context.Context, userID models.ID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error) {
+	// Build filter; the user's weddings include those they collaborate on
+	filter := tenantScoped(ctx, bson.M{"$or": []bson.M{
+		{"user_id": userID},
+		{"collaborators.user_id": userID},
+	}})
 
 	if filters.Status != "" {
 		filter["status"] = filters.Status
 	}
 
 	if filters.Search != "" {
-		filter["$or"] = []bson.M{
+		filter["$and"] = []bson.M{{"$or": []bson.M{
 			{"title": bson.M{"$regex": filters.Search, "$options": "i"}},
 			{"slug": bson.M{"$regex": filters.Search, "$options": "i"}},
 			{"couple.partner1.first_name": bson.M{"$regex": filters.Search, "$options": "i"}},
 			{"couple.partner1.last_name": bson.M{"$regex": filters.Search, "$options": "i"}},
 			{"couple.partner2.first_name": bson.M{"$regex": filters.Search, "$options": "i"}},
 			{"couple.partner2.last_name": bson.M{"$regex": filters.Search, "$options": "i"}},
-		}
+		}}}
 	}
 
 	if filters.CreatedAfter != nil {
@@ -145,15 +165,15 @@ func (r *MongoWeddingRepository) Update(ctx context.Context, wedding *models.Wed
 	wedding.UpdatedAt = time.Now()
 	_, err := r.collection.UpdateOne(
 		ctx,
-		bson.M{"_id": wedding.ID},
+		tenantScoped(ctx, bson.M{"_id": wedding.ID}),
 		bson.M{"$set": wedding},
 	)
 	return err
 }
 
 // Delete removes a wedding from the database
-func (r *MongoWeddingRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
-	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+func (r *MongoWeddingRepository) Delete(ctx context.Context, id models.ID) error {
+	_, err := r.collection.DeleteOne(ctx, tenantScoped(ctx, bson.M{"_id": id}))
 	return err
 }
 
@@ -166,14 +186,25 @@ func (r *MongoWeddingRepository) ExistsBySlug(ctx context.Context, slug string)
 	return count > 0, nil
 }
 
-// ListPublic retrieves public weddings with pagination
-func (r *MongoWeddingRepository) ListPublic(ctx context.Context, page, pageSize int, filters repository.PublicWeddingFilters) ([]*models.Wedding, int64, error) {
-	// Build filter for public weddings
-	filter := bson.M{
-		"is_public": true,
-		"status":    string(models.WeddingStatusPublished),
-	}
+// CountByOwner counts the weddings a user owns
+func (r *MongoWeddingRepository) CountByOwner(ctx context.Context, userID models.ID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"user_id": userID})
+}
+
+// CountByTheme counts the weddings using a theme
+func (r *MongoWeddingRepository) CountByTheme(ctx context.Context, themeID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"theme.theme_id": themeID})
+}
 
+// List retrieves all weddings matching the admin filters with pagination
+func (r *MongoWeddingRepository) List(ctx context.Context, page, pageSize int, filters repository.AdminWeddingFilters) ([]*models.Wedding, int64, error) {
+	filter := tenantScoped(ctx, bson.M{})
+	if filters.Status != "" {
+		filter["status"] = filters.Status
+	}
+	if filters.OwnerID != nil {
+		filter["user_id"] = *filters.OwnerID
+	}
 	if filters.Search != "" {
 		filter["$or"] = []bson.M{
 			{"title": bson.M{"$regex": filters.Search, "$options": "i"}},
@@ -184,9 +215,82 @@ func (r *MongoWeddingRepository) ListPublic(ctx context.Context, page, pageSize
 			{"couple.partner2.last_name": bson.M{"$regex": filters.Search, "$options": "i"}},
 		}
 	}
+	// Weddings never moderated have no moderation field
+	if filters.Flagged != nil {
+		if *filters.Flagged {
+			filter["moderation.flagged"] = true
+		} else {
+			filter["moderation.flagged"] = bson.M{"$ne": true}
+		}
+	}
+	if filters.TakenDown != nil {
+		if *filters.TakenDown {
+			filter["moderation.taken_down"] = true
+		} else {
+			filter["moderation.taken_down"] = bson.M{"$ne": true}
+		}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	skip := (page - 1) * pageSize
+	if skip < 0 {
+		skip = 0
+	}
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	weddings := []*models.Wedding{}
+	if err := cursor.All(ctx, &weddings); err != nil {
+		return nil, 0, err
+	}
+	return weddings, total, nil
+}
+
+// ListPublic retrieves public weddings with pagination
+func (r *MongoWeddingRepository) ListPublic(ctx context.Context, page, pageSize int, filters repository.PublicWeddingFilters) ([]*models.Wedding, int64, error) {
+	// Build filter for public weddings
+	filter := bson.M{
+		"is_public": true,
+		"status":    string(models.WeddingStatusPublished),
+	}
 
+	if filters.Search != "" {
+		filter["$text"] = bson.M{"$search": filters.Search}
+	}
+
+	dateFilter := bson.M{}
 	if filters.EventDate != nil {
-		filter["event.date"] = bson.M{"$gte": filters.EventDate}
+		dateFilter["$gte"] = *filters.EventDate
+	}
+	if filters.DateFrom != nil {
+		dateFilter["$gte"] = *filters.DateFrom
+	}
+	if filters.DateTo != nil {
+		dateFilter["$lte"] = *filters.DateTo
+	}
+	switch filters.When {
+	case repository.PublicWeddingsUpcoming:
+		now := time.Now()
+		if from, ok := dateFilter["$gte"].(time.Time); !ok || from.Before(now) {
+			dateFilter["$gte"] = now
+		}
+	case repository.PublicWeddingsPast:
+		dateFilter["$lt"] = time.Now()
+	}
+	if len(dateFilter) > 0 {
+		filter["event.date"] = dateFilter
 	}
 
 	// Count total documents
@@ -209,7 +313,7 @@ func (r *MongoWeddingRepository) ListPublic(ctx context.Context, page, pageSize
 		&options.FindOptions{
 			Skip:  &skip64,
 			Limit: &limit64,
-			Sort:  bson.D{{Key: "event.date", Value: 1}},
+			Sort:  publicWeddingSort(filters),
 		},
 	)
 	if err != nil {
@@ -234,7 +338,7 @@ func (r *MongoWeddingRepository) ListPublic(ctx context.Context, page, pageSize
 }
 
 // IncrementViewCount increments the view count for a wedding
-func (r *MongoWeddingRepository) IncrementViewCount(ctx context.Context, id primitive.ObjectID) error {
+func (r *MongoWeddingRepository) IncrementViewCount(ctx context.Context, id models.ID) error {
 	now := time.Now()
 	_, err := r.collection.UpdateOne(
 		ctx,
@@ -247,15 +351,74 @@ func (r *MongoWeddingRepository) IncrementViewCount(ctx context.Context, id prim
 	return err
 }
 
-// UpdateRSVPCount updates the RSVP statistics for a wedding
-func (r *MongoWeddingRepository) UpdateRSVPCount(ctx context.Context, weddingID primitive.ObjectID) error {
-	// This would typically involve an aggregation pipeline to count RSVPs
-	// For now, we'll update the basic RSVP count
-	// In a real implementation, you'd want to aggregate RSVP data and update all counts
-	_, err := r.collection.UpdateOne(
+// UpdateRSVPCount recounts the RSVPs of a wedding. TotalAttending counts every
+// attending guest together with their named companions. RSVPs held for review
+// are left out until accepted, as in the RSVP statistics.
+func (r *MongoWeddingRepository) UpdateRSVPCount(ctx context.Context, weddingID models.ID) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "wedding_id", Value: weddingID},
+			{Key: "review.status", Value: bson.D{{Key: "$nin", Value: bson.A{models.RSVPReviewPending, models.RSVPReviewRejected}}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "rsvps", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "attending", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$cond", Value: bson.A{
+				bson.D{{Key: "$eq", Value: bson.A{"$status", string(models.RSVPAttending)}}},
+				bson.D{{Key: "$add", Value: bson.A{"$attendance_count", "$plus_one_count"}}},
+				0,
+			}}}}}},
+		}}},
+	}
+
+	cursor, err := r.collection.Database().Collection("rsvps").Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var counts struct {
+		RSVPs     int `bson:"rsvps"`
+		Attending int `bson:"attending"`
+	}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&counts); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
 		ctx,
 		bson.M{"_id": weddingID},
-		bson.M{"$set": bson.M{"updated_at": time.Now()}},
+		bson.M{"$set": bson.M{
+			"rsvp_count":      counts.RSVPs,
+			"total_attending": counts.Attending,
+			"updated_at":      time.Now(),
+		}},
 	)
 	return err
 }
+
+// publicWeddingSort maps a showcase sort option to a sort document, with _id as a
+// tie-breaker so pagination is stable
+func publicWeddingSort(filters repository.PublicWeddingFilters) bson.D {
+	switch filters.Sort {
+	case repository.PublicWeddingSortRecent:
+		return bson.D{{Key: "published_at", Value: -1}, {Key: "_id", Value: -1}}
+	case repository.PublicWeddingSortMostViewed:
+		return bson.D{{Key: "view_count", Value: -1}, {Key: "_id", Value: -1}}
+	case repository.PublicWeddingSortRelevance:
+		if filters.Search != "" {
+			return bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: -1}}
+		}
+	}
+
+	// Date order: soonest first, or most recent first when browsing past weddings
+	if filters.When == repository.PublicWeddingsPast {
+		return bson.D{{Key: "event.date", Value: -1}, {Key: "_id", Value: -1}}
+	}
+	return bson.D{{Key: "event.date", Value: 1}, {Key: "_id", Value: 1}}
+}
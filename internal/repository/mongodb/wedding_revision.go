@@ -0,0 +1,109 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure weddingRevisionRepository implements the domain repository interface
+var _ repository.WeddingRevisionRepository = (*weddingRevisionRepository)(nil)
+
+// revisionNumberAttempts bounds the retries of concurrent updates racing for a revision number
+const revisionNumberAttempts = 3
+
+type weddingRevisionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewWeddingRevisionRepository creates a new MongoDB wedding revision repository
+func NewWeddingRevisionRepository(db *mongo.Database) repository.WeddingRevisionRepository {
+	return &weddingRevisionRepository{collection: db.Collection("wedding_revisions")}
+}
+
+// Create numbers the revision after the latest one of its wedding and stores
+// it. The unique (wedding_id, number) index turns races into retries.
+func (r *weddingRevisionRepository) Create(ctx context.Context, revision *models.WeddingRevision) error {
+	if revision.ID.IsZero() {
+		revision.ID = models.NewID()
+	}
+	revision.CreatedAt = time.Now()
+
+	for attempt := 1; ; attempt++ {
+		latest, err := r.latestNumber(ctx, revision.WeddingID)
+		if err != nil {
+			return err
+		}
+		revision.Number = latest + 1
+
+		_, err = r.collection.InsertOne(ctx, revision)
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) || attempt == revisionNumberAttempts {
+			return fmt.Errorf("failed to create wedding revision: %w", err)
+		}
+	}
+}
+
+// GetByNumber retrieves a revision of a wedding with its snapshot
+func (r *weddingRevisionRepository) GetByNumber(ctx context.Context, weddingID models.ID, number int) (*models.WeddingRevision, error) {
+	var revision models.WeddingRevision
+	err := r.collection.FindOne(ctx, bson.M{"wedding_id": weddingID, "number": number}).Decode(&revision)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding revision: %w", err)
+	}
+	return &revision, nil
+}
+
+// ListByWedding retrieves the revisions of a wedding without their snapshots, newest first
+func (r *weddingRevisionRepository) ListByWedding(ctx context.Context, weddingID models.ID, limit int) ([]*models.WeddingRevision, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "number", Value: -1}}).
+		SetProjection(bson.M{"snapshot": 0}).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, bson.M{"wedding_id": weddingID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wedding revisions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	revisions := []*models.WeddingRevision{}
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, fmt.Errorf("failed to decode wedding revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// DeleteBefore removes the revisions of a wedding numbered below number
+func (r *weddingRevisionRepository) DeleteBefore(ctx context.Context, weddingID models.ID, number int) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"wedding_id": weddingID, "number": bson.M{"$lt": number}})
+	if err != nil {
+		return fmt.Errorf("failed to delete wedding revisions: %w", err)
+	}
+	return nil
+}
+
+func (r *weddingRevisionRepository) latestNumber(ctx context.Context, weddingID models.ID) (int, error) {
+	var latest struct {
+		Number int `bson:"number"`
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "number", Value: -1}}).SetProjection(bson.M{"number": 1})
+	if err := r.collection.FindOne(ctx, bson.M{"wedding_id": weddingID}, opts).Decode(&latest); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get latest wedding revision: %w", err)
+	}
+	return latest.Number, nil
+}
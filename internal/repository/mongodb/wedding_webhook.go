@@ -0,0 +1,147 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure weddingWebhookRepository implements the domain repository interface
+var _ repository.WeddingWebhookRepository = (*weddingWebhookRepository)(nil)
+
+type weddingWebhookRepository struct {
+	webhooks   *mongo.Collection
+	deliveries *mongo.Collection
+}
+
+// NewWeddingWebhookRepository creates a new MongoDB wedding webhook repository
+func NewWeddingWebhookRepository(db *mongo.Database) repository.WeddingWebhookRepository {
+	return &weddingWebhookRepository{
+		webhooks:   db.Collection("wedding_webhooks"),
+		deliveries: db.Collection("wedding_webhook_deliveries"),
+	}
+}
+
+// Create inserts a new wedding webhook
+func (r *weddingWebhookRepository) Create(ctx context.Context, webhook *models.WeddingWebhook) error {
+	if webhook.ID.IsZero() {
+		webhook.ID = models.NewID()
+	}
+	now := time.Now()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	if _, err := r.webhooks.InsertOne(ctx, webhook); err != nil {
+		return fmt.Errorf("failed to insert wedding webhook: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a wedding webhook by ID
+func (r *weddingWebhookRepository) GetByID(ctx context.Context, id models.ID) (*models.WeddingWebhook, error) {
+	var webhook models.WeddingWebhook
+	err := r.webhooks.FindOne(ctx, bson.M{"_id": id}).Decode(&webhook)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding webhook: %w", err)
+	}
+	return &webhook, nil
+}
+
+// ListByWedding retrieves all webhooks of a wedding, newest first
+func (r *weddingWebhookRepository) ListByWedding(ctx context.Context, weddingID models.ID) ([]*models.WeddingWebhook, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.webhooks.Find(ctx, bson.M{"wedding_id": weddingID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wedding webhooks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var webhooks []*models.WeddingWebhook
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, fmt.Errorf("failed to decode wedding webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// Update updates an existing wedding webhook
+func (r *weddingWebhookRepository) Update(ctx context.Context, webhook *models.WeddingWebhook) error {
+	webhook.UpdatedAt = time.Now()
+
+	result, err := r.webhooks.UpdateOne(ctx, bson.M{"_id": webhook.ID}, bson.M{"$set": webhook})
+	if err != nil {
+		return fmt.Errorf("failed to update wedding webhook: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes a wedding webhook and its delivery log
+func (r *weddingWebhookRepository) Delete(ctx context.Context, id models.ID) error {
+	result, err := r.webhooks.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete wedding webhook: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+
+	if _, err := r.deliveries.DeleteMany(ctx, bson.M{"webhook_id": id}); err != nil {
+		return fmt.Errorf("failed to delete wedding webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// CreateDelivery records a delivery attempt
+func (r *weddingWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WeddingWebhookDelivery) error {
+	if delivery.ID.IsZero() {
+		delivery.ID = models.NewID()
+	}
+	if delivery.AttemptedAt.IsZero() {
+		delivery.AttemptedAt = time.Now()
+	}
+
+	if _, err := r.deliveries.InsertOne(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to insert wedding webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries retrieves the delivery log for a webhook, newest first
+func (r *weddingWebhookRepository) ListDeliveries(ctx context.Context, webhookID models.ID, page, pageSize int) ([]*models.WeddingWebhookDelivery, int64, error) {
+	filter := bson.M{"webhook_id": webhookID}
+
+	total, err := r.deliveries.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count wedding webhook deliveries: %w", err)
+	}
+
+	skip := (page - 1) * pageSize
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "attempted_at", Value: -1}})
+
+	cursor, err := r.deliveries.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list wedding webhook deliveries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []*models.WeddingWebhookDelivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode wedding webhook deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
@@ -0,0 +1,114 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure wishRepository implements the domain repository interface
+var _ repository.WishRepository = (*wishRepository)(nil)
+
+type wishRepository struct {
+	collection *mongo.Collection
+}
+
+// NewWishRepository creates a new MongoDB guestbook wish repository
+func NewWishRepository(db *mongo.Database) repository.WishRepository {
+	return &wishRepository{
+		collection: db.Collection("wishes"),
+	}
+}
+
+// Create stores a new wish
+func (r *wishRepository) Create(ctx context.Context, wish *models.Wish) error {
+	if wish.ID.IsZero() {
+		wish.ID = models.NewID()
+	}
+	wish.CreatedAt = time.Now()
+
+	if _, err := r.collection.InsertOne(ctx, wish); err != nil {
+		return fmt.Errorf("failed to create wish: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a wish by ID
+func (r *wishRepository) GetByID(ctx context.Context, id models.ID) (*models.Wish, error) {
+	var wish models.Wish
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&wish); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get wish: %w", err)
+	}
+	return &wish, nil
+}
+
+// SetStatus approves or hides a wish
+func (r *wishRepository) SetStatus(ctx context.Context, id models.ID, status models.WishStatus, moderatedBy models.ID, moderatedAt time.Time) error {
+	update := bson.M{"$set": bson.M{
+		"status":       status,
+		"moderated_by": moderatedBy,
+		"moderated_at": moderatedAt,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return fmt.Errorf("failed to moderate wish: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes a wish
+func (r *wishRepository) Delete(ctx context.Context, id models.ID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete wish: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// ListByWedding lists the wishes of a wedding, newest first
+func (r *wishRepository) ListByWedding(ctx context.Context, weddingID models.ID, status models.WishStatus, page, pageSize int) ([]*models.Wish, int64, error) {
+	filter := bson.M{"wedding_id": weddingID}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count wishes: %w", err)
+	}
+
+	skip := (page - 1) * pageSize
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list wishes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	wishes := []*models.Wish{}
+	if err := cursor.All(ctx, &wishes); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode wishes: %w", err)
+	}
+	return wishes, total, nil
+}
@@ -0,0 +1,236 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure AccountDeletionRepository implements the domain repository interface
+var _ repository.AccountDeletionRepository = (*AccountDeletionRepository)(nil)
+
+// anonymizedName replaces the first name of anonymized guests and RSVPs
+const anonymizedName = "Anonymous"
+
+// rsvpSessionAnonymizer removes the analytics identifiers of the sessions in
+// which RSVPs were submitted; analytics stay in MongoDB
+type rsvpSessionAnonymizer interface {
+	AnonymizeRSVPSessions(ctx context.Context, rsvpIDs []models.ID) error
+}
+
+// AccountDeletionRepository implements repository.AccountDeletionRepository
+// when users, weddings, guests and RSVPs are stored in PostgreSQL. Everything
+// else a wedding or account has is erased by the MongoDB repository it wraps.
+type AccountDeletionRepository struct {
+	db       *sql.DB
+	users    *table[models.User]
+	weddings *table[models.Wedding]
+	guests   *table[models.Guest]
+	rsvps    *table[models.RSVP]
+	mongo    repository.AccountDeletionRepository
+}
+
+// NewAccountDeletionRepository creates a new PostgreSQL account deletion
+// repository that erases the records kept in MongoDB through mongo
+func NewAccountDeletionRepository(db *sql.DB, mongo repository.AccountDeletionRepository) repository.AccountDeletionRepository {
+	return &AccountDeletionRepository{
+		db:       db,
+		users:    newUserTable(db),
+		weddings: newWeddingTable(db),
+		guests:   newGuestTable(db),
+		rsvps:    newRSVPTable(db),
+		mongo:    mongo,
+	}
+}
+
+// SetDeletion stores the user's deletion request, replacing an earlier one
+func (r *AccountDeletionRepository) SetDeletion(ctx context.Context, userID models.ID, deletion *models.AccountDeletion) error {
+	_, err := r.users.modify(ctx, byID(userID), func(user *models.User) error {
+		user.Deletion = deletion
+		user.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("failed to store account deletion: %w", err)
+	}
+	return err
+}
+
+// ClearDeletion withdraws the user's deletion request
+func (r *AccountDeletionRepository) ClearDeletion(ctx context.Context, userID models.ID) error {
+	_, err := r.users.modify(ctx, byID(userID), func(user *models.User) error {
+		if user.Deletion == nil {
+			return repository.ErrNotFound
+		}
+		user.Deletion = nil
+		user.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("failed to cancel account deletion: %w", err)
+	}
+	return err
+}
+
+// GetByDeletionToken retrieves the user whose deletion request has the token hash
+func (r *AccountDeletionRepository) GetByDeletionToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	user, err := r.users.get(ctx, r.db, "", newWhere("deletion_token_hash = ?", tokenHash))
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to find account deletion: %w", err)
+	}
+	return user, err
+}
+
+// ListDue lists up to limit users whose confirmed deletion was scheduled before the time
+func (r *AccountDeletionRepository) ListDue(ctx context.Context, before time.Time, limit int) ([]*models.User, error) {
+	users, err := r.users.find(ctx, newWhere("deletion_scheduled_for <= ?", before), "deletion_scheduled_for", limit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due account deletions: %w", err)
+	}
+	return users, nil
+}
+
+// OwnedWeddingIDs lists the weddings the user owns
+func (r *AccountDeletionRepository) OwnedWeddingIDs(ctx context.Context, userID models.ID) ([]models.ID, error) {
+	ids, err := r.ids(ctx, "SELECT id FROM weddings WHERE user_id = $1", userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owned weddings: %w", err)
+	}
+	return ids, nil
+}
+
+// DeleteWeddingData deletes a wedding and everything stored for it
+func (r *AccountDeletionRepository) DeleteWeddingData(ctx context.Context, weddingID models.ID) ([]string, error) {
+	keys, err := r.mongo.DeleteWeddingData(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The wedding goes last so that an interrupted erasure is found again
+	err = withTx(ctx, r.db, func(tx *sql.Tx) error {
+		byWedding := newWhere("wedding_id = ?", weddingID.String())
+		if _, err := r.guests.delete(ctx, tx, byWedding); err != nil {
+			return fmt.Errorf("failed to delete guests: %w", err)
+		}
+		if _, err := r.rsvps.delete(ctx, tx, byWedding); err != nil {
+			return fmt.Errorf("failed to delete rsvps: %w", err)
+		}
+		if _, err := r.weddings.delete(ctx, tx, byID(weddingID)); err != nil {
+			return fmt.Errorf("failed to delete wedding: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// AnonymizeContact removes the contact details stored under the email address
+func (r *AccountDeletionRepository) AnonymizeContact(ctx context.Context, email string) (int64, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return 0, nil
+	}
+	byEmail := newWhere("lower(email) = lower(?)", email)
+
+	// The sessions of the RSVPs tie page views and conversions to the person
+	if sessions, ok := r.mongo.(rsvpSessionAnonymizer); ok {
+		rsvpIDs, err := r.ids(ctx, "SELECT id FROM rsvps"+byEmail.sql(), byEmail.args...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find rsvps: %w", err)
+		}
+		if err := sessions.AnonymizeRSVPSessions(ctx, rsvpIDs); err != nil {
+			return 0, err
+		}
+	}
+
+	anonymized, err := r.mongo.AnonymizeContact(ctx, email)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := r.rsvps.modifyAll(ctx, byEmail, func(rsvp *models.RSVP) error {
+		rsvp.FirstName, rsvp.LastName = anonymizedName, ""
+		rsvp.Email, rsvp.Phone, rsvp.IPAddress, rsvp.UserAgent = "", "", "", ""
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymize rsvps: %w", err)
+	}
+	anonymized += count
+
+	count, err = r.guests.modifyAll(ctx, byEmail, func(guest *models.Guest) error {
+		guest.FirstName, guest.LastName = anonymizedName, ""
+		guest.Email, guest.Phone = "", ""
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymize guests: %w", err)
+	}
+	anonymized += count
+
+	return anonymized, nil
+}
+
+// RemoveCollaborator removes the user from the collaborators of every wedding
+func (r *AccountDeletionRepository) RemoveCollaborator(ctx context.Context, userID models.ID) error {
+	w := newWhere("collaborator_ids @> ARRAY[?]::text[]", userID.String())
+	_, err := r.weddings.modifyAll(ctx, w, func(wedding *models.Wedding) error {
+		kept := wedding.Collaborators[:0]
+		for _, collaborator := range wedding.Collaborators {
+			if collaborator.UserID != userID {
+				kept = append(kept, collaborator)
+			}
+		}
+		wedding.Collaborators = kept
+		wedding.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove collaborator: %w", err)
+	}
+	return nil
+}
+
+// DeleteAccount deletes the user with their sessions, API keys, metrics webhooks, upload
+// sessions and exports, returning the storage keys of their export files
+func (r *AccountDeletionRepository) DeleteAccount(ctx context.Context, userID models.ID) ([]string, error) {
+	keys, err := r.mongo.DeleteAccount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.users.delete(ctx, r.db, byID(userID)); err != nil {
+		return nil, fmt.Errorf("failed to delete user: %w", err)
+	}
+	return keys, nil
+}
+
+// ids runs a query selecting record IDs
+func (r *AccountDeletionRepository) ids(ctx context.Context, query string, args ...interface{}) ([]models.ID, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []models.ID
+	for rows.Next() {
+		var hex string
+		if err := rows.Scan(&hex); err != nil {
+			return nil, err
+		}
+		id, err := models.ParseID(hex)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
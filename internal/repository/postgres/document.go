@@ -0,0 +1,455 @@
+// Package postgres implements the user, wedding, guest and RSVP repositories on
+// PostgreSQL. Each table keeps the whole model as a JSONB document, encoded as
+// MongoDB canonical extended JSON so that every field round-trips exactly as it
+// does in MongoDB, next to the columns that queries filter and sort on.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// querier runs statements on the database or within a transaction
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// columns are the values of the queried columns of a record by column name
+type columns map[string]interface{}
+
+// table stores records of type T as documents with the columns derived from them
+type table[T any] struct {
+	db      *sql.DB
+	name    string
+	id      func(*T) models.ID
+	columns func(*T) columns
+}
+
+// insert stores a new record
+func (t *table[T]) insert(ctx context.Context, q querier, doc *T) error {
+	names, values, err := t.row(doc)
+	if err != nil {
+		return err
+	}
+	placeholders := make([]string, len(names))
+	for i := range names {
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", t.name, strings.Join(names, ", "), strings.Join(placeholders, ", "))
+	_, err = q.ExecContext(ctx, query, values...)
+	return err
+}
+
+// insertMany stores new records in one transaction
+func (t *table[T]) insertMany(ctx context.Context, docs []*T) error {
+	return withTx(ctx, t.db, func(tx *sql.Tx) error {
+		for _, doc := range docs {
+			if err := t.insert(ctx, tx, doc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// save replaces a stored record, reporting whether it exists
+func (t *table[T]) save(ctx context.Context, q querier, doc *T) (bool, error) {
+	names, values, err := t.row(doc)
+	if err != nil {
+		return false, err
+	}
+	// names[0] is the ID, which is matched rather than set
+	assignments := make([]string, 0, len(names)-1)
+	for i, name := range names[1:] {
+		assignments = append(assignments, name+" = $"+strconv.Itoa(i+2))
+	}
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE id = $1", t.name, strings.Join(assignments, ", "))
+	result, err := q.ExecContext(ctx, query, values...)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// merge stores the top-level fields of doc over the stored record, as a MongoDB
+// $set of the whole model does: fields doc omits when empty keep their value.
+// merge reports whether the record exists.
+func (t *table[T]) merge(ctx context.Context, doc *T) (bool, error) {
+	_, err := t.modify(ctx, byID(t.id(doc)), func(stored *T) error {
+		merged, err := overlay(stored, doc)
+		if err != nil {
+			return err
+		}
+		*stored = *merged
+		return nil
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// modify changes the first record matching the conditions within a transaction
+// that locks it, returning the changed record or repository.ErrNotFound. An
+// error from fn aborts the change.
+func (t *table[T]) modify(ctx context.Context, w *where, fn func(*T) error) (*T, error) {
+	var doc *T
+	err := withTx(ctx, t.db, func(tx *sql.Tx) error {
+		var err error
+		doc, err = t.get(ctx, tx, "FOR UPDATE", w)
+		if err != nil {
+			return err
+		}
+		if err := fn(doc); err != nil {
+			return err
+		}
+		_, err = t.save(ctx, tx, doc)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// modifyAll changes every record matching the conditions within a transaction
+// that locks them, returning how many there were. An error from fn aborts the change.
+func (t *table[T]) modifyAll(ctx context.Context, w *where, fn func(*T) error) (int64, error) {
+	var changed int64
+	err := withTx(ctx, t.db, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT document FROM %s%s FOR UPDATE", t.name, w.sql()), w.args...)
+		if err != nil {
+			return err
+		}
+		// The rows are read before updating them on the same connection
+		var docs []*T
+		for rows.Next() {
+			var data []byte
+			if err := rows.Scan(&data); err != nil {
+				rows.Close()
+				return err
+			}
+			var doc T
+			if err := decodeDocument(data, &doc); err != nil {
+				rows.Close()
+				return err
+			}
+			docs = append(docs, &doc)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, doc := range docs {
+			if err := fn(doc); err != nil {
+				return err
+			}
+			if _, err := t.save(ctx, tx, doc); err != nil {
+				return err
+			}
+		}
+		changed = int64(len(docs))
+		return nil
+	})
+	return changed, err
+}
+
+// get returns the first record matching the conditions or repository.ErrNotFound.
+// lock is appended to the query, e.g. FOR UPDATE.
+func (t *table[T]) get(ctx context.Context, q querier, lock string, w *where) (*T, error) {
+	query := fmt.Sprintf("SELECT document FROM %s%s LIMIT 1 %s", t.name, w.sql(), lock)
+	var data []byte
+	if err := q.QueryRowContext(ctx, query, w.args...).Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	var doc T
+	if err := decodeDocument(data, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// find lists the records matching the conditions in order. A limit of 0 lists them all.
+func (t *table[T]) find(ctx context.Context, w *where, order string, limit, offset int) ([]*T, error) {
+	var docs []*T
+	err := t.each(ctx, w, order, limit, offset, func(doc *T) error {
+		docs = append(docs, doc)
+		return nil
+	})
+	return docs, err
+}
+
+// each calls fn for each record matching the conditions in order, stopping at
+// the first error. A limit of 0 reads them all.
+func (t *table[T]) each(ctx context.Context, w *where, order string, limit, offset int, fn func(*T) error) error {
+	query := fmt.Sprintf("SELECT document FROM %s%s", t.name, w.sql())
+	if order != "" {
+		query += " ORDER BY " + order
+	}
+	if limit > 0 {
+		query += " LIMIT " + strconv.Itoa(limit)
+	}
+	if offset > 0 {
+		query += " OFFSET " + strconv.Itoa(offset)
+	}
+
+	rows, err := t.db.QueryContext(ctx, query, w.args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return err
+		}
+		var doc T
+		if err := decodeDocument(data, &doc); err != nil {
+			return err
+		}
+		if err := fn(&doc); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// count counts the records matching the conditions
+func (t *table[T]) count(ctx context.Context, w *where) (int64, error) {
+	var total int64
+	err := t.db.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %s%s", t.name, w.sql()), w.args...).Scan(&total)
+	return total, err
+}
+
+// delete deletes the records matching the conditions, returning how many there were
+func (t *table[T]) delete(ctx context.Context, q querier, w *where) (int64, error) {
+	result, err := q.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s%s", t.name, w.sql()), w.args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// row returns the column names of a record, starting with the ID and ending
+// with the document, and their values
+func (t *table[T]) row(doc *T) ([]string, []interface{}, error) {
+	data, err := encodeDocument(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	derived := t.columns(doc)
+	names := make([]string, 0, len(derived)+2)
+	for name := range derived {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([]interface{}, 0, len(names)+2)
+	values = append(values, t.id(doc).String())
+	for _, name := range names {
+		values = append(values, derived[name])
+	}
+	values = append(values, string(data))
+
+	names = append(append([]string{"id"}, names...), "document")
+	return names, values, nil
+}
+
+// withTx runs fn in a transaction, committing it unless fn fails
+func withTx(ctx context.Context, db *sql.DB, fn func(*sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// where holds conditions that are joined with AND. Their ? placeholders are
+// numbered in the order the conditions are added.
+type where struct {
+	conditions []string
+	args       []interface{}
+}
+
+func newWhere(condition string, args ...interface{}) *where {
+	return (&where{}).add(condition, args...)
+}
+
+// byID matches the record with the ID
+func byID(id models.ID) *where {
+	return newWhere("id = ?", id.String())
+}
+
+// tenantScoped adds the tenant the context is scoped to, if any, to the
+// conditions on tenant-owned records
+func tenantScoped(ctx context.Context, w *where) *where {
+	if tenantID, ok := repository.TenantFromContext(ctx); ok {
+		w.add("tenant_id = ?", tenantID)
+	}
+	return w
+}
+
+// add adds a condition with a value for each of its ? placeholders
+func (w *where) add(condition string, args ...interface{}) *where {
+	var b strings.Builder
+	for _, r := range condition {
+		if r == '?' {
+			w.args = append(w.args, args[0])
+			args = args[1:]
+			b.WriteString("$" + strconv.Itoa(len(w.args)))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	w.conditions = append(w.conditions, b.String())
+	return w
+}
+
+// search adds a condition that any of the columns matches the case-insensitive
+// regular expression, like a MongoDB $regex with the i option
+func (w *where) search(pattern string, names ...string) *where {
+	matches := make([]string, len(names))
+	args := make([]interface{}, len(names))
+	for i, name := range names {
+		matches[i] = name + " ~* ?"
+		args[i] = pattern
+	}
+	return w.add("("+strings.Join(matches, " OR ")+")", args...)
+}
+
+func (w *where) sql() string {
+	if len(w.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w.conditions, " AND ")
+}
+
+// encodeDocument encodes a model as canonical extended JSON, which keeps the
+// BSON types, and the fields hidden from the API, of every field
+func encodeDocument(doc interface{}) ([]byte, error) {
+	data, err := bson.MarshalExtJSON(doc, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+	return data, nil
+}
+
+func decodeDocument(data []byte, doc interface{}) error {
+	if err := bson.UnmarshalExtJSON(data, true, doc); err != nil {
+		return fmt.Errorf("failed to decode document: %w", err)
+	}
+	return nil
+}
+
+// overlay returns stored with the top-level fields of doc set on it
+func overlay[T any](stored, doc *T) (*T, error) {
+	base, err := toDocument(stored)
+	if err != nil {
+		return nil, err
+	}
+	set, err := toDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range set {
+		replaced := false
+		for i := range base {
+			if base[i].Key == field.Key {
+				base[i].Value = field.Value
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			base = append(base, field)
+		}
+	}
+
+	data, err := bson.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	var merged T
+	if err := bson.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
+
+func toDocument(v interface{}) (bson.D, error) {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var d bson.D
+	if err := bson.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// nullString stores empty strings, which MongoDB documents omit, as NULL
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func nullTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
+
+// nonNilStrings stores nil lists, which MongoDB documents omit, as empty arrays
+func nonNilStrings(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+func hexIDs(ids []models.ID) []string {
+	hexes := make([]string, len(ids))
+	for i, id := range ids {
+		hexes[i] = id.String()
+	}
+	return hexes
+}
+
+// skip returns how many records come before a page
+func skip(page, pageSize int) int {
+	if page < 1 {
+		return 0
+	}
+	return (page - 1) * pageSize
+}
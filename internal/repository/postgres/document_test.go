@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+func TestWhere_NumbersPlaceholders(t *testing.T) {
+	w := newWhere("wedding_id = ?", "w1").
+		add("status = ?", "attending").
+		search("ann", "first_name", "last_name")
+
+	assert.Equal(t, " WHERE wedding_id = $1 AND status = $2 AND (first_name ~* $3 OR last_name ~* $4)", w.sql())
+	assert.Equal(t, []interface{}{"w1", "attending", "ann", "ann"}, w.args)
+	assert.Empty(t, (&where{}).sql())
+}
+
+func TestTenantScoped(t *testing.T) {
+	id := models.NewID()
+	assert.Equal(t, " WHERE id = $1", tenantScoped(context.Background(), byID(id)).sql())
+
+	ctx := repository.WithTenant(context.Background(), "acme")
+	w := tenantScoped(ctx, byID(id))
+	assert.Equal(t, " WHERE id = $1 AND tenant_id = $2", w.sql())
+	assert.Equal(t, []interface{}{id.String(), "acme"}, w.args)
+}
+
+func TestDocument_RoundTripKeepsHiddenFields(t *testing.T) {
+	verifiedAt := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	user := &models.User{
+		ID:              models.NewID(),
+		Email:           "jane@example.com",
+		PasswordHash:    "hash",
+		EmailVerifiedAt: &verifiedAt,
+		WeddingIDs:      []models.ID{models.NewID()},
+	}
+
+	data, err := encodeDocument(user)
+	require.NoError(t, err)
+
+	var decoded models.User
+	require.NoError(t, decodeDocument(data, &decoded))
+	assert.Equal(t, user.ID, decoded.ID)
+	assert.Equal(t, "hash", decoded.PasswordHash)
+	assert.True(t, verifiedAt.Equal(*decoded.EmailVerifiedAt))
+	assert.Equal(t, user.WeddingIDs, decoded.WeddingIDs)
+}
+
+func TestOverlay_KeepsOmittedFields(t *testing.T) {
+	stored := &models.User{
+		ID:           models.NewID(),
+		Email:        "jane@example.com",
+		FirstName:    "Jane",
+		PasswordHash: "hash",
+		Phone:        "+15550100",
+	}
+	update := &models.User{ID: stored.ID, Email: stored.Email, FirstName: "Janet"}
+
+	merged, err := overlay(stored, update)
+	require.NoError(t, err)
+	assert.Equal(t, "Janet", merged.FirstName)
+	assert.Equal(t, "+15550100", merged.Phone, "fields omitted when empty keep their stored value")
+	assert.Empty(t, merged.PasswordHash, "fields stored when empty are replaced, as with MongoDB")
+}
+
+func TestUserColumns(t *testing.T) {
+	scheduledFor := time.Now()
+	user := &models.User{
+		Email:         "jane@example.com",
+		OAuthAccounts: []models.OAuthAccount{{Provider: "google", Subject: "123"}},
+		Deletion:      &models.AccountDeletion{ScheduledFor: &scheduledFor},
+	}
+
+	cols := userColumns(user)
+	assert.Equal(t, []string{"google:123"}, cols["oauth_accounts"])
+	assert.Nil(t, cols["deletion_token_hash"], "empty strings are stored as NULL")
+	assert.Equal(t, scheduledFor, cols["deletion_scheduled_for"])
+	assert.Nil(t, cols["billing_customer_id"])
+}
+
+func TestPublicWeddingOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters repository.PublicWeddingFilters
+		want    string
+	}{
+		{"date", repository.PublicWeddingFilters{}, "event_date ASC NULLS FIRST, id ASC"},
+		{"past", repository.PublicWeddingFilters{When: repository.PublicWeddingsPast}, "event_date DESC NULLS LAST, id DESC"},
+		{"recent", repository.PublicWeddingFilters{Sort: repository.PublicWeddingSortRecent}, "published_at DESC NULLS LAST, id DESC"},
+		{"most viewed", repository.PublicWeddingFilters{Sort: repository.PublicWeddingSortMostViewed}, "view_count DESC, id DESC"},
+		{"relevance without search", repository.PublicWeddingFilters{Sort: repository.PublicWeddingSortRelevance}, "event_date ASC NULLS FIRST, id ASC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, publicWeddingOrder(tt.filters))
+		})
+	}
+
+	relevance := publicWeddingOrder(repository.PublicWeddingFilters{Sort: repository.PublicWeddingSortRelevance, Search: "anna"})
+	assert.Contains(t, relevance, "ts_rank(search, ")
+	assert.Contains(t, relevance, "$3")
+}
+
+func TestSkip(t *testing.T) {
+	assert.Equal(t, 0, skip(0, 20))
+	assert.Equal(t, 0, skip(1, 20))
+	assert.Equal(t, 40, skip(3, 20))
+}
@@ -0,0 +1,465 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure GuestRepository implements the domain repository interface
+var _ repository.GuestRepository = (*GuestRepository)(nil)
+
+// GuestRepository implements repository.GuestRepository for PostgreSQL
+type GuestRepository struct {
+	guests *table[models.Guest]
+}
+
+// NewGuestRepository creates a new PostgreSQL guest repository
+func NewGuestRepository(db *sql.DB) repository.GuestRepository {
+	return &GuestRepository{guests: newGuestTable(db)}
+}
+
+func newGuestTable(db *sql.DB) *table[models.Guest] {
+	return &table[models.Guest]{
+		db:      db,
+		name:    "guests",
+		id:      func(g *models.Guest) models.ID { return g.ID },
+		columns: guestColumns,
+	}
+}
+
+// guestColumns derives the queried columns of a guest
+func guestColumns(g *models.Guest) columns {
+	cols := columns{
+		"wedding_id":            g.WeddingID.String(),
+		"first_name":            g.FirstName,
+		"last_name":             g.LastName,
+		"email":                 nullString(g.Email),
+		"relationship":          g.Relationship,
+		"side":                  g.Side,
+		"rsvp_status":           g.RSVPStatus,
+		"vip":                   g.VIP,
+		"invitation_status":     g.InvitationStatus,
+		"invited_via":           g.InvitedVia,
+		"invitation_message_id": nullString(g.InvitationMessageID),
+		"allow_plus_one":        g.AllowPlusOne,
+		"import_batch_id":       nullString(g.ImportBatchID),
+		"checked_in_at":         nil,
+		"plus_ones_brought":     0,
+		"tags":                  nonNilStrings(g.Tags),
+		"search_keys":           models.GuestSearchKeys(g),
+		"phone_key":             nullString(models.GuestPhoneKey(g.Phone)),
+		"group_id":              nil,
+		"created_at":            g.CreatedAt,
+	}
+	if g.GroupID != nil {
+		cols["group_id"] = g.GroupID.String()
+	}
+	if g.CheckIn != nil {
+		cols["checked_in_at"] = g.CheckIn.ArrivedAt
+		cols["plus_ones_brought"] = g.CheckIn.PlusOnesBrought
+	}
+	return cols
+}
+
+// Create creates a new guest
+func (r *GuestRepository) Create(ctx context.Context, guest *models.Guest) error {
+	// Generate ID if not set
+	if guest.ID.IsZero() {
+		guest.ID = models.NewID()
+	}
+
+	now := time.Now()
+	guest.CreatedAt = now
+	guest.UpdatedAt = now
+
+	if err := r.guests.insert(ctx, r.guests.db, guest); err != nil {
+		return fmt.Errorf("failed to create guest: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a guest by ID
+func (r *GuestRepository) GetByID(ctx context.Context, id models.ID) (*models.Guest, error) {
+	guest, err := r.guests.get(ctx, r.guests.db, "", byID(id))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("guest not found: %w", repository.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get guest: %w", err)
+	}
+	return guest, nil
+}
+
+// GetByEmail retrieves a guest by email within a wedding
+func (r *GuestRepository) GetByEmail(ctx context.Context, weddingID models.ID, email string) (*models.Guest, error) {
+	guest, err := r.guests.get(ctx, r.guests.db, "", newWhere("wedding_id = ?", weddingID.String()).add("email = ?", email))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get guest by email: %w", err)
+	}
+	return guest, nil
+}
+
+// GetByPhone retrieves the oldest guest of the wedding whose phone number has the key
+func (r *GuestRepository) GetByPhone(ctx context.Context, weddingID models.ID, phoneKey string) (*models.Guest, error) {
+	guests, err := r.guests.find(ctx, newWhere("wedding_id = ?", weddingID.String()).add("phone_key = ?", phoneKey), "created_at, id", 1, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guest by phone: %w", err)
+	}
+	if len(guests) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return guests[0], nil
+}
+
+// CreateMany creates multiple guests in a single transaction
+func (r *GuestRepository) CreateMany(ctx context.Context, guests []*models.Guest) error {
+	if len(guests) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for _, guest := range guests {
+		// Generate ID if not set
+		if guest.ID.IsZero() {
+			guest.ID = models.NewID()
+		}
+		guest.CreatedAt = now
+		guest.UpdatedAt = now
+	}
+
+	if err := r.guests.insertMany(ctx, guests); err != nil {
+		return fmt.Errorf("failed to create many guests: %w", err)
+	}
+	return nil
+}
+
+// ListByWedding retrieves guests by wedding ID with pagination
+func (r *GuestRepository) ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error) {
+	w := guestFilters(newWhere("wedding_id = ?", weddingID.String()), filters)
+
+	total, err := r.guests.count(ctx, w)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count guests: %w", err)
+	}
+
+	guests, err := r.guests.find(ctx, w, "last_name, first_name", pageSize, skip(page, pageSize))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get guests: %w", err)
+	}
+	return guests, total, nil
+}
+
+// Update updates an existing guest
+func (r *GuestRepository) Update(ctx context.Context, guest *models.Guest) error {
+	guest.UpdatedAt = time.Now()
+
+	found, err := r.guests.merge(ctx, guest)
+	if err != nil {
+		return fmt.Errorf("failed to update guest: %w", err)
+	}
+	if !found {
+		return errors.New("guest not found")
+	}
+	return nil
+}
+
+// Delete deletes a guest
+func (r *GuestRepository) Delete(ctx context.Context, id models.ID) error {
+	deleted, err := r.guests.delete(ctx, r.guests.db, byID(id))
+	if err != nil {
+		return fmt.Errorf("failed to delete guest: %w", err)
+	}
+	if deleted == 0 {
+		return errors.New("guest not found")
+	}
+	return nil
+}
+
+// ImportBatch imports multiple guests with a batch ID
+func (r *GuestRepository) ImportBatch(ctx context.Context, guests []*models.Guest, batchID string) error {
+	if len(guests) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for _, guest := range guests {
+		if guest.ID.IsZero() {
+			guest.ID = models.NewID()
+		}
+		guest.CreatedAt = now
+		guest.UpdatedAt = now
+		guest.ImportBatchID = batchID
+	}
+
+	if err := r.guests.insertMany(ctx, guests); err != nil {
+		return fmt.Errorf("failed to import guest batch: %w", err)
+	}
+	return nil
+}
+
+// GetByImportBatch retrieves guests by wedding ID and batch ID
+func (r *GuestRepository) GetByImportBatch(ctx context.Context, weddingID models.ID, batchID string) ([]*models.Guest, error) {
+	w := newWhere("wedding_id = ?", weddingID.String()).add("import_batch_id = ?", batchID)
+	guests, err := r.guests.find(ctx, w, "", 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guests by batch: %w", err)
+	}
+	return guests, nil
+}
+
+// Search returns the guests of the wedding with a search key starting with each
+// prefix. Guests without keys, saved before they were kept, are returned too.
+func (r *GuestRepository) Search(ctx context.Context, weddingID models.ID, prefixes []string) ([]*models.Guest, error) {
+	matches := make([]string, len(prefixes))
+	args := make([]interface{}, len(prefixes))
+	for i, prefix := range prefixes {
+		matches[i] = "EXISTS (SELECT 1 FROM unnest(search_keys) AS key WHERE starts_with(key, ?))"
+		args[i] = prefix
+	}
+	w := newWhere("wedding_id = ?", weddingID.String()).
+		add("(search_keys IS NULL OR ("+strings.Join(matches, " AND ")+"))", args...)
+
+	guests, err := r.guests.find(ctx, w, "", 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search guests: %w", err)
+	}
+	return guests, nil
+}
+
+// StreamByWedding calls fn for each guest of the wedding matching filters, oldest first
+func (r *GuestRepository) StreamByWedding(ctx context.Context, weddingID models.ID, filters repository.GuestFilters, fn func(*models.Guest) error) error {
+	w := guestFilters(newWhere("wedding_id = ?", weddingID.String()), filters)
+
+	var fnErr error
+	err := r.guests.each(ctx, w, "created_at", 0, 0, func(guest *models.Guest) error {
+		fnErr = fn(guest)
+		return fnErr
+	})
+	if err != nil && err != fnErr {
+		return fmt.Errorf("failed to stream guests: %w", err)
+	}
+	return err
+}
+
+// UpdateInvitationStatus records the outcome of sending a guest's invitation
+func (r *GuestRepository) UpdateInvitationStatus(ctx context.Context, id models.ID, status string, sentAt *time.Time, reason string) error {
+	return r.modify(ctx, byID(id), "failed to update invitation status", func(guest *models.Guest) error {
+		guest.InvitationStatus = status
+		guest.InvitationError = reason
+		if sentAt != nil {
+			guest.InvitationSentAt = sentAt
+		}
+		return nil
+	})
+}
+
+// MarkInvitationSent records a sent invitation and the provider message its delivery is reported under
+func (r *GuestRepository) MarkInvitationSent(ctx context.Context, id models.ID, channel, messageID string, sentAt time.Time) error {
+	return r.modify(ctx, byID(id), "failed to mark invitation sent", func(guest *models.Guest) error {
+		guest.InvitationStatus = models.InvitationStatusSent
+		guest.InvitationError = ""
+		guest.InvitationSentAt = &sentAt
+		guest.InvitationChannel = channel
+		guest.InvitationMessageID = messageID
+		return nil
+	})
+}
+
+// UpdateInvitationStatusByMessage applies a provider delivery report to the invitation sent as messageID
+func (r *GuestRepository) UpdateInvitationStatusByMessage(ctx context.Context, messageID, status string, from []string, reason string) error {
+	w := newWhere("invitation_message_id = ?", messageID).add("invitation_status = ANY(?)", from)
+	return r.modify(ctx, w, "failed to update invitation status", func(guest *models.Guest) error {
+		guest.InvitationStatus = status
+		guest.InvitationError = reason
+		return nil
+	})
+}
+
+// CheckIn records a guest's arrival unless the guest is already checked in
+func (r *GuestRepository) CheckIn(ctx context.Context, id models.ID, checkIn models.GuestCheckIn) error {
+	w := byID(id).add("checked_in_at IS NULL")
+	return r.modify(ctx, w, "failed to check in guest", func(guest *models.Guest) error {
+		guest.CheckIn = &checkIn
+		return nil
+	})
+}
+
+// CheckInStats counts the guests of a wedding and their arrivals in one pass
+func (r *GuestRepository) CheckInStats(ctx context.Context, weddingID models.ID) (*models.CheckInStats, error) {
+	var stats models.CheckInStats
+	var lastCheckInAt sql.NullTime
+	err := r.guests.db.QueryRowContext(ctx, `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE rsvp_status = 'attending'),
+		       COUNT(checked_in_at),
+		       COUNT(checked_in_at) FILTER (WHERE rsvp_status = 'attending'),
+		       COALESCE(SUM(plus_ones_brought), 0),
+		       MAX(checked_in_at)
+		FROM guests
+		WHERE wedding_id = $1`,
+		weddingID.String(),
+	).Scan(&stats.TotalGuests, &stats.ExpectedGuests, &stats.CheckedIn, &stats.ExpectedArrived, &stats.PlusOnesBrought, &lastCheckInAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate check-in stats: %w", err)
+	}
+
+	stats.Attendance = stats.CheckedIn + stats.PlusOnesBrought
+	if lastCheckInAt.Valid {
+		stats.LastCheckInAt = &lastCheckInAt.Time
+	}
+	return &stats, nil
+}
+
+// AttendanceBreakdown counts the answers of a wedding's guests grouped by a guest field
+func (r *GuestRepository) AttendanceBreakdown(ctx context.Context, weddingID models.ID, field string) ([]models.AttendanceBreakdown, error) {
+	var key func(*models.Guest) string
+	switch field {
+	case models.GuestBreakdownSide:
+		key = func(g *models.Guest) string { return g.Side }
+	case models.GuestBreakdownRelationship:
+		key = func(g *models.Guest) string { return g.Relationship }
+	case models.GuestBreakdownVIP:
+		key = func(g *models.Guest) string {
+			if g.VIP {
+				return models.BreakdownVIP
+			}
+			return models.BreakdownRegular
+		}
+	default:
+		return nil, fmt.Errorf("unknown guest breakdown field: %s", field)
+	}
+
+	breakdowns := models.AttendanceBreakdowns{}
+	err := r.guests.each(ctx, newWhere("wedding_id = ?", weddingID.String()), "", 0, 0, func(guest *models.Guest) error {
+		breakdowns.Add(key(guest), guest.RSVPStatus, 1, 1+len(guest.Companions))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count attendance breakdown: %w", err)
+	}
+	return breakdowns.List(), nil
+}
+
+// UpdateMany applies a patch to the guests of a wedding in one transaction
+func (r *GuestRepository) UpdateMany(ctx context.Context, weddingID models.ID, ids []models.ID, patch models.GuestPatch) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	w := newWhere("wedding_id = ?", weddingID.String()).add("id = ANY(?)", hexIDs(ids))
+	now := time.Now()
+	changed, err := r.guests.modifyAll(ctx, w, func(guest *models.Guest) error {
+		patch.Apply(guest)
+		guest.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to update guests: %w", err)
+	}
+	return changed, nil
+}
+
+// DeleteMany deletes the guests of a wedding with the given IDs
+func (r *GuestRepository) DeleteMany(ctx context.Context, weddingID models.ID, ids []models.ID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	w := newWhere("wedding_id = ?", weddingID.String()).add("id = ANY(?)", hexIDs(ids))
+	deleted, err := r.guests.delete(ctx, r.guests.db, w)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete guests: %w", err)
+	}
+	return deleted, nil
+}
+
+// SetGroup moves the guests of a wedding into a group, or out of any group when groupID is nil
+func (r *GuestRepository) SetGroup(ctx context.Context, weddingID models.ID, ids []models.ID, groupID *models.ID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	w := newWhere("wedding_id = ?", weddingID.String()).add("id = ANY(?)", hexIDs(ids))
+	now := time.Now()
+	changed, err := r.guests.modifyAll(ctx, w, func(guest *models.Guest) error {
+		guest.GroupID = groupID
+		guest.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to set guest group: %w", err)
+	}
+	return changed, nil
+}
+
+// modify changes the guest matching the conditions, returning
+// repository.ErrNotFound when there is none
+func (r *GuestRepository) modify(ctx context.Context, w *where, failure string, fn func(*models.Guest) error) error {
+	_, err := r.guests.modify(ctx, w, func(guest *models.Guest) error {
+		if err := fn(guest); err != nil {
+			return err
+		}
+		guest.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return repository.ErrNotFound
+		}
+		return fmt.Errorf("%s: %w", failure, err)
+	}
+	return nil
+}
+
+// guestFilters adds the conditions of the provided filters
+func guestFilters(w *where, filters repository.GuestFilters) *where {
+	if filters.Search != "" {
+		w.search(filters.Search, "first_name", "last_name", "email", "relationship")
+	}
+	if filters.Side != "" {
+		w.add("side = ?", filters.Side)
+	}
+	if filters.RSVPStatus == "pending" {
+		w.add("rsvp_status IN ('pending', '')")
+	} else if filters.RSVPStatus != "" {
+		w.add("rsvp_status = ?", filters.RSVPStatus)
+	}
+	if filters.Relationship != "" {
+		w.add("relationship = ?", filters.Relationship)
+	}
+	if filters.VIP != nil {
+		w.add("vip = ?", *filters.VIP)
+	}
+	if filters.InvitationStatus != "" {
+		w.add("invitation_status = ?", filters.InvitationStatus)
+	}
+	if filters.InvitedVia != "" {
+		w.add("invited_via = ?", filters.InvitedVia)
+	}
+	if filters.AllowPlusOne != nil {
+		w.add("allow_plus_one = ?", *filters.AllowPlusOne)
+	}
+	if filters.Tag != "" {
+		w.add("tags @> ARRAY[?]::text[]", filters.Tag)
+	}
+	if filters.ImportBatchID != "" {
+		w.add("import_batch_id = ?", filters.ImportBatchID)
+	}
+	if filters.GroupID != nil {
+		w.add("group_id = ?", filters.GroupID.String())
+	}
+	if len(filters.IDs) > 0 {
+		w.add("id = ANY(?)", hexIDs(filters.IDs))
+	}
+	return w
+}
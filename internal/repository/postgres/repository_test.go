@@ -0,0 +1,272 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/config"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/pkg/database"
+)
+
+// setupTestDB migrates the database at POSTGRES_TEST_URL and empties its tables
+func setupTestDB(t *testing.T) *sql.DB {
+	url := os.Getenv("POSTGRES_TEST_URL")
+	if testing.Short() || url == "" {
+		t.Skip("Skipping integration test: POSTGRES_TEST_URL is not set")
+	}
+
+	cfg := &config.DatabaseConfig{Driver: database.DriverPostgres, PostgresURL: url, Timeout: 10}
+	require.NoError(t, database.MigratePostgres(cfg))
+
+	db, err := database.OpenPostgres(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec("TRUNCATE users, weddings, guests, rsvps")
+	require.NoError(t, err)
+	return db
+}
+
+func TestUserRepository_Postgres(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	user := &models.User{
+		Email:         "jane@example.com",
+		PasswordHash:  "hash",
+		FirstName:     "Jane",
+		Status:        models.UserStatusActive,
+		OAuthAccounts: []models.OAuthAccount{{Provider: "google", Subject: "123"}},
+		CreatedAt:     time.Now(),
+	}
+	require.NoError(t, repo.Create(ctx, user))
+	assert.Error(t, repo.Create(ctx, &models.User{Email: user.Email}), "emails are unique")
+
+	found, err := repo.GetByEmail(ctx, "jane@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, user.ID, found.ID)
+	assert.Equal(t, "hash", found.PasswordHash)
+
+	linked, err := repo.GetByOAuthAccount(ctx, "google", "123")
+	require.NoError(t, err)
+	require.NotNil(t, linked)
+	assert.Equal(t, user.ID, linked.ID)
+
+	missing, err := repo.GetByID(ctx, models.NewID())
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	weddingID := models.NewID()
+	require.NoError(t, repo.AddWeddingID(ctx, user.ID, weddingID))
+	require.NoError(t, repo.AddWeddingID(ctx, user.ID, weddingID))
+	found, err = repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ID{weddingID}, found.WeddingIDs)
+
+	// Events delivered out of order do not roll the subscription back
+	newer := &models.Subscription{Plan: "premium", UpdatedAt: time.Now()}
+	require.NoError(t, repo.UpdateSubscription(ctx, user.ID, newer))
+	older := &models.Subscription{Plan: "free", UpdatedAt: newer.UpdatedAt.Add(-time.Minute)}
+	assert.ErrorIs(t, repo.UpdateSubscription(ctx, user.ID, older), repository.ErrNotFound)
+
+	users, total, err := repo.List(ctx, 1, 10, repository.UserFilters{Search: "jan"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, users, 1)
+}
+
+func TestWeddingRepository_ListPublicPostgres(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewWeddingRepository(db)
+	ctx := context.Background()
+
+	newWedding := func(slug, title, partner string, views int64) *models.Wedding {
+		wedding := &models.Wedding{
+			UserID:    models.NewID(),
+			Slug:      slug,
+			Title:     title,
+			Status:    string(models.WeddingStatusPublished),
+			IsPublic:  true,
+			ViewCount: views,
+		}
+		wedding.Couple.Partner1.FirstName = partner
+		require.NoError(t, repo.Create(ctx, wedding))
+		return wedding
+	}
+	anna := newWedding("anna-and-ben", "Our garden wedding", "Anna", 5)
+	newWedding("clara-and-dan", "A beach wedding", "Clara", 50)
+	private := newWedding("eve-and-finn", "Anna's friends", "Eve", 0)
+	private.IsPublic = false
+	require.NoError(t, repo.Update(ctx, private))
+
+	weddings, total, err := repo.ListPublic(ctx, 1, 10, repository.PublicWeddingFilters{Search: "anna"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, weddings, 1)
+	assert.Equal(t, anna.ID, weddings[0].ID)
+
+	weddings, _, err = repo.ListPublic(ctx, 1, 10, repository.PublicWeddingFilters{Sort: repository.PublicWeddingSortMostViewed})
+	require.NoError(t, err)
+	require.Len(t, weddings, 2)
+	assert.Equal(t, "clara-and-dan", weddings[0].Slug)
+
+	exists, err := repo.ExistsBySlug(ctx, "anna-and-ben")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestGuestRepository_CheckInPostgres(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewGuestRepository(db)
+	ctx := context.Background()
+
+	weddingID := models.NewID()
+	guests := []*models.Guest{
+		{WeddingID: weddingID, FirstName: "Ann", LastName: "Lee", RSVPStatus: "attending"},
+		{WeddingID: weddingID, FirstName: "Bo", LastName: "Kim", RSVPStatus: "pending"},
+	}
+	require.NoError(t, repo.CreateMany(ctx, guests))
+
+	arrival := models.GuestCheckIn{ArrivedAt: time.Now(), PlusOnesBrought: 1}
+	require.NoError(t, repo.CheckIn(ctx, guests[0].ID, arrival))
+	assert.ErrorIs(t, repo.CheckIn(ctx, guests[0].ID, arrival), repository.ErrNotFound, "a guest checks in once")
+
+	stats, err := repo.CheckInStats(ctx, weddingID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stats.TotalGuests)
+	assert.Equal(t, int64(1), stats.ExpectedGuests)
+	assert.Equal(t, int64(1), stats.CheckedIn)
+	assert.Equal(t, int64(2), stats.Attendance)
+	assert.NotNil(t, stats.LastCheckInAt)
+
+	listed, total, err := repo.ListByWedding(ctx, weddingID, 1, 10, repository.GuestFilters{RSVPStatus: "attending"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, listed, 1)
+	assert.NotNil(t, listed[0].CheckIn)
+}
+
+func TestGuestRepository_BulkPostgres(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewGuestRepository(db)
+	ctx := context.Background()
+
+	weddingID := models.NewID()
+	guests := []*models.Guest{
+		{WeddingID: weddingID, FirstName: "Ann", LastName: "Lee", Tags: []string{"family"}},
+		{WeddingID: weddingID, FirstName: "Bo", LastName: "Kim"},
+		{WeddingID: models.NewID(), FirstName: "Cy", LastName: "Park"},
+	}
+	require.NoError(t, repo.CreateMany(ctx, guests))
+	ids := []models.ID{guests[0].ID, guests[1].ID, guests[2].ID}
+
+	side := "bride"
+	updated, err := repo.UpdateMany(ctx, weddingID, ids, models.GuestPatch{Side: &side, AddTags: []string{"friends"}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated, "guests of other weddings are not matched")
+
+	tagged, total, err := repo.ListByWedding(ctx, weddingID, 1, 10, repository.GuestFilters{Tag: "friends", Side: "bride"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, tagged, 2)
+	assert.Equal(t, []string{"friends"}, tagged[0].Tags, "guests are listed by last name")
+	assert.Equal(t, []string{"family", "friends"}, tagged[1].Tags)
+
+	deleted, err := repo.DeleteMany(ctx, weddingID, ids)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	_, err = repo.GetByID(ctx, guests[2].ID)
+	assert.NoError(t, err)
+}
+
+func TestRSVPRepository_StatisticsPostgres(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRSVPRepository(db)
+	ctx := context.Background()
+
+	weddingID := models.NewID()
+	for _, rsvp := range []*models.RSVP{
+		{WeddingID: weddingID, FirstName: "Ann", Status: "attending", AttendanceCount: 2, PlusOneCount: 1, SubmittedAt: time.Now()},
+		{WeddingID: weddingID, FirstName: "Bo", Status: "not-attending", SubmittedAt: time.Now()},
+		{WeddingID: weddingID, FirstName: "Cy", Status: "attending", AttendanceCount: 1, SubmittedAt: time.Now(),
+			Review: &models.RSVPReview{Status: models.RSVPReviewPending}},
+	} {
+		require.NoError(t, repo.Create(ctx, rsvp))
+	}
+
+	stats, err := repo.GetStatistics(ctx, weddingID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.TotalResponses, "RSVPs held for review are not counted")
+	assert.Equal(t, 1, stats.Attending)
+	assert.Equal(t, 1, stats.NotAttending)
+	assert.Equal(t, 2, stats.TotalGuests)
+	assert.Len(t, stats.SubmissionTrend, 30)
+}
+
+// mongoDeletions stands in for the MongoDB half of account deletion
+type mongoDeletions struct {
+	repository.AccountDeletionRepository
+	anonymizedSessions []models.ID
+}
+
+func (m *mongoDeletions) AnonymizeRSVPSessions(ctx context.Context, rsvpIDs []models.ID) error {
+	m.anonymizedSessions = rsvpIDs
+	return nil
+}
+
+func (m *mongoDeletions) AnonymizeContact(ctx context.Context, email string) (int64, error) {
+	return 0, nil
+}
+
+func (m *mongoDeletions) DeleteWeddingData(ctx context.Context, weddingID models.ID) ([]string, error) {
+	return []string{"weddings/" + weddingID.String() + "/photo.jpg"}, nil
+}
+
+func TestAccountDeletionRepository_Postgres(t *testing.T) {
+	db := setupTestDB(t)
+	mongo := &mongoDeletions{}
+	repo := NewAccountDeletionRepository(db, mongo)
+	weddings := NewWeddingRepository(db)
+	rsvps := NewRSVPRepository(db)
+	ctx := context.Background()
+
+	ownerID := models.NewID()
+	wedding := &models.Wedding{UserID: ownerID, Slug: "anna-and-ben", Title: "Anna & Ben"}
+	require.NoError(t, weddings.Create(ctx, wedding))
+	rsvp := &models.RSVP{WeddingID: wedding.ID, FirstName: "Jane", Email: "Jane@Example.com", IPAddress: "10.0.0.1", SubmittedAt: time.Now()}
+	require.NoError(t, rsvps.Create(ctx, rsvp))
+
+	owned, err := repo.OwnedWeddingIDs(ctx, ownerID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ID{wedding.ID}, owned)
+
+	anonymized, err := repo.AnonymizeContact(ctx, "jane@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), anonymized)
+	assert.Equal(t, []models.ID{rsvp.ID}, mongo.anonymizedSessions)
+
+	stored, err := rsvps.GetByID(ctx, rsvp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Anonymous", stored.FirstName)
+	assert.Empty(t, stored.Email)
+	assert.Empty(t, stored.IPAddress)
+
+	keys, err := repo.DeleteWeddingData(ctx, wedding.ID)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+	_, err = rsvps.GetByID(ctx, rsvp.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	owned, err = repo.OwnedWeddingIDs(ctx, ownerID)
+	require.NoError(t, err)
+	assert.Empty(t, owned)
+}
@@ -0,0 +1,284 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure RSVPRepository implements the domain repository interface
+var _ repository.RSVPRepository = (*RSVPRepository)(nil)
+
+// countedRSVPs leaves out RSVPs held for review until the owner accepts them
+const countedRSVPs = "(review_status IS NULL OR review_status NOT IN ('" +
+	string(models.RSVPReviewPending) + "', '" + string(models.RSVPReviewRejected) + "'))"
+
+// RSVPRepository implements repository.RSVPRepository for PostgreSQL
+type RSVPRepository struct {
+	rsvps *table[models.RSVP]
+}
+
+// NewRSVPRepository creates a new PostgreSQL RSVP repository
+func NewRSVPRepository(db *sql.DB) repository.RSVPRepository {
+	return &RSVPRepository{rsvps: newRSVPTable(db)}
+}
+
+func newRSVPTable(db *sql.DB) *table[models.RSVP] {
+	return &table[models.RSVP]{
+		db:      db,
+		name:    "rsvps",
+		id:      func(r *models.RSVP) models.ID { return r.ID },
+		columns: rsvpColumns,
+	}
+}
+
+// rsvpColumns derives the queried columns of an RSVP
+func rsvpColumns(r *models.RSVP) columns {
+	var reviewStatus interface{}
+	if r.Review != nil {
+		reviewStatus = string(r.Review.Status)
+	}
+	return columns{
+		"wedding_id":       r.WeddingID.String(),
+		"first_name":       r.FirstName,
+		"last_name":        r.LastName,
+		"email":            nullString(r.Email),
+		"status":           r.Status,
+		"source":           r.Source,
+		"review_status":    reviewStatus,
+		"attendance_count": r.AttendanceCount,
+		"plus_one_count":   r.PlusOneCount,
+		"ip_address":       nullString(r.IPAddress),
+		"submitted_at":     r.SubmittedAt,
+	}
+}
+
+func (r *RSVPRepository) Create(ctx context.Context, rsvp *models.RSVP) error {
+	if rsvp.ID.IsZero() {
+		rsvp.ID = models.NewID()
+	}
+	return r.rsvps.insert(ctx, r.rsvps.db, rsvp)
+}
+
+func (r *RSVPRepository) GetByID(ctx context.Context, id models.ID) (*models.RSVP, error) {
+	return r.rsvps.get(ctx, r.rsvps.db, "", byID(id))
+}
+
+func (r *RSVPRepository) GetByEmail(ctx context.Context, weddingID models.ID, email string) (*models.RSVP, error) {
+	return r.rsvps.get(ctx, r.rsvps.db, "", newWhere("wedding_id = ?", weddingID.String()).add("email = ?", email))
+}
+
+func (r *RSVPRepository) ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error) {
+	w := newWhere("wedding_id = ?", weddingID.String())
+	if filters.Status != "" {
+		w.add("status = ?", filters.Status)
+	}
+	if filters.Source != "" {
+		w.add("source = ?", filters.Source)
+	}
+	if filters.ReviewStatus != "" {
+		w.add("review_status = ?", filters.ReviewStatus)
+	}
+	if filters.Search != "" {
+		w.search(filters.Search, "first_name", "last_name", "email")
+	}
+	if filters.SubmittedAfter != nil {
+		w.add("submitted_at >= ?", *filters.SubmittedAfter)
+	}
+	if filters.SubmittedBefore != nil {
+		w.add("submitted_at <= ?", *filters.SubmittedBefore)
+	}
+
+	total, err := r.rsvps.count(ctx, w)
+	if err != nil {
+		return nil, 0, err
+	}
+	rsvps, err := r.rsvps.find(ctx, w, "submitted_at DESC", pageSize, skip(page, pageSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	return rsvps, total, nil
+}
+
+func (r *RSVPRepository) Update(ctx context.Context, rsvp *models.RSVP) error {
+	_, err := r.rsvps.merge(ctx, rsvp)
+	return err
+}
+
+func (r *RSVPRepository) Delete(ctx context.Context, id models.ID) error {
+	_, err := r.rsvps.delete(ctx, r.rsvps.db, byID(id))
+	return err
+}
+
+// GetStatistics tallies the counted RSVPs of a wedding in one pass
+func (r *RSVPRepository) GetStatistics(ctx context.Context, weddingID models.ID) (*models.RSVPStatistics, error) {
+	stats := &models.RSVPStatistics{
+		DietaryCounts:   make(map[string]int),
+		SubmissionTrend: []models.DailyCount{},
+	}
+	sessions := make(map[string]*models.SessionStatistics)
+
+	w := newWhere("wedding_id = ?", weddingID.String()).add(countedRSVPs)
+	err := r.rsvps.each(ctx, w, "", 0, 0, func(rsvp *models.RSVP) error {
+		switch rsvp.Status {
+		case "attending":
+			stats.Attending++
+		case "not-attending":
+			stats.NotAttending++
+		case "maybe":
+			stats.Maybe++
+		}
+		stats.TotalGuests += rsvp.AttendanceCount
+		stats.PlusOnesCount += rsvp.PlusOneCount
+		stats.TotalResponses++
+
+		for _, dietary := range rsvp.DietarySelected {
+			if dietary != "" {
+				stats.DietaryCounts[dietary]++
+			}
+		}
+
+		for _, response := range rsvp.Sessions {
+			session, ok := sessions[response.SessionID]
+			if !ok {
+				session = &models.SessionStatistics{SessionID: response.SessionID}
+				sessions[response.SessionID] = session
+			}
+			switch response.Status {
+			case "attending":
+				session.Attending++
+				session.TotalGuests += rsvp.AttendanceCount + rsvp.PlusOneCount
+			case "not-attending":
+				session.NotAttending++
+			case "maybe":
+				session.Maybe++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		stats.Sessions = append(stats.Sessions, *session)
+	}
+	sort.Slice(stats.Sessions, func(i, j int) bool {
+		return stats.Sessions[i].SessionID < stats.Sessions[j].SessionID
+	})
+
+	// Get submission trend for last 30 days
+	stats.SubmissionTrend, _ = r.GetSubmissionTrend(ctx, weddingID, 30)
+
+	return stats, nil
+}
+
+func (r *RSVPRepository) MarkConfirmationSent(ctx context.Context, id models.ID) error {
+	now := time.Now()
+	_, err := r.rsvps.modify(ctx, byID(id), func(rsvp *models.RSVP) error {
+		rsvp.ConfirmationSent = true
+		rsvp.ConfirmationSentAt = &now
+		return nil
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (r *RSVPRepository) GetSubmissionTrend(ctx context.Context, weddingID models.ID, days int) ([]models.DailyCount, error) {
+	startDate := time.Now().AddDate(0, 0, -days).Truncate(24 * time.Hour)
+
+	rows, err := r.rsvps.db.QueryContext(ctx, `
+		SELECT to_char(submitted_at AT TIME ZONE 'UTC', 'YYYY-MM-DD'), COUNT(*)
+		FROM rsvps
+		WHERE wedding_id = $1 AND submitted_at >= $2
+		GROUP BY 1`,
+		weddingID.String(), startDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var date string
+		var count int
+		if err := rows.Scan(&date, &count); err != nil {
+			return nil, err
+		}
+		counts[date] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Fill in missing days with zero count
+	trend := make([]models.DailyCount, days)
+	now := time.Now().Truncate(24 * time.Hour)
+	for i := 0; i < days; i++ {
+		date := now.AddDate(0, 0, -days+1+i).Format("2006-01-02")
+		trend[i] = models.DailyCount{Date: date, Count: counts[date]}
+	}
+
+	return trend, nil
+}
+
+func (r *RSVPRepository) StreamByWedding(ctx context.Context, weddingID models.ID, fn func(*models.RSVP) error) error {
+	return r.rsvps.each(ctx, newWhere("wedding_id = ?", weddingID.String()), "submitted_at DESC", 0, 0, fn)
+}
+
+func (r *RSVPRepository) CountByIPSince(ctx context.Context, weddingID models.ID, ipAddress string, since time.Time) (int64, error) {
+	return r.rsvps.count(ctx, newWhere("wedding_id = ?", weddingID.String()).
+		add("ip_address = ?", ipAddress).
+		add("submitted_at >= ?", since))
+}
+
+func (r *RSVPRepository) GetAttendanceTimeline(ctx context.Context, weddingID models.ID) ([]models.AttendanceDay, error) {
+	timeline := []models.AttendanceDay{}
+	w := newWhere("wedding_id = ?", weddingID.String()).add(countedRSVPs)
+	err := r.rsvps.each(ctx, w, "submitted_at", 0, 0, func(rsvp *models.RSVP) error {
+		date := rsvp.SubmittedAt.UTC().Format("2006-01-02")
+		if len(timeline) == 0 || timeline[len(timeline)-1].Date != date {
+			timeline = append(timeline, models.AttendanceDay{Date: date})
+		}
+		day := &timeline[len(timeline)-1]
+		switch rsvp.Status {
+		case string(models.RSVPAttending):
+			day.Attending++
+			day.Headcount += rsvp.AttendanceCount
+		case string(models.RSVPNotAttending):
+			day.NotAttending++
+		case string(models.RSVPMaybe):
+			day.Maybe++
+			day.MaybeHeadcount += rsvp.AttendanceCount
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return timeline, nil
+}
+
+func (r *RSVPRepository) GetMealCounts(ctx context.Context, weddingID models.ID) (map[string]int, error) {
+	counts := make(map[string]int)
+	w := newWhere("wedding_id = ?", weddingID.String()).add(countedRSVPs).add("status = ?", string(models.RSVPAttending))
+	err := r.rsvps.each(ctx, w, "", 0, 0, func(rsvp *models.RSVP) error {
+		counts[rsvp.MealChoice]++
+		for _, plusOne := range rsvp.PlusOnes {
+			counts[plusOne.MealChoice]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
@@ -0,0 +1,274 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure UserRepository implements the domain repository interface
+var _ repository.UserRepository = (*UserRepository)(nil)
+
+// UserRepository implements repository.UserRepository for PostgreSQL
+type UserRepository struct {
+	users *table[models.User]
+}
+
+// NewUserRepository creates a new PostgreSQL user repository
+func NewUserRepository(db *sql.DB) repository.UserRepository {
+	return &UserRepository{users: newUserTable(db)}
+}
+
+func newUserTable(db *sql.DB) *table[models.User] {
+	return &table[models.User]{
+		db:      db,
+		name:    "users",
+		id:      func(u *models.User) models.ID { return u.ID },
+		columns: userColumns,
+	}
+}
+
+// userColumns derives the queried columns of a user
+func userColumns(u *models.User) columns {
+	cols := columns{
+		"email":                    u.Email,
+		"first_name":               u.FirstName,
+		"last_name":                u.LastName,
+		"status":                   string(u.Status),
+		"email_verification_token": nullString(u.EmailVerificationToken),
+		"password_reset_token":     nullString(u.PasswordResetToken),
+		"billing_customer_id":      nil,
+		"unlock_token_hash":        nil,
+		"deletion_token_hash":      nil,
+		"deletion_scheduled_for":   nil,
+		"tenant_id":                u.TenantID,
+		"created_at":               u.CreatedAt,
+	}
+	if u.Subscription != nil {
+		cols["billing_customer_id"] = nullString(u.Subscription.CustomerID)
+	}
+	if u.LoginSecurity != nil {
+		cols["unlock_token_hash"] = nullString(u.LoginSecurity.UnlockTokenHash)
+	}
+	if u.Deletion != nil {
+		cols["deletion_token_hash"] = nullString(u.Deletion.TokenHash)
+		cols["deletion_scheduled_for"] = nullTime(u.Deletion.ScheduledFor)
+	}
+
+	accounts := make([]string, len(u.OAuthAccounts))
+	for i, account := range u.OAuthAccounts {
+		accounts[i] = oauthAccountKey(account.Provider, account.Subject)
+	}
+	cols["oauth_accounts"] = accounts
+	return cols
+}
+
+// oauthAccountKey identifies a social login account in the oauth_accounts column
+func oauthAccountKey(provider models.AuthProvider, subject string) string {
+	return string(provider) + ":" + subject
+}
+
+// Create inserts a new user into the database
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	if user.ID.IsZero() {
+		user.ID = models.NewID()
+	}
+	return r.users.insert(ctx, r.users.db, user)
+}
+
+// GetByID retrieves a user by ID
+func (r *UserRepository) GetByID(ctx context.Context, id models.ID) (*models.User, error) {
+	return r.getBy(ctx, tenantScoped(ctx, byID(id)))
+}
+
+// GetByEmail retrieves a user by email
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	return r.getBy(ctx, tenantScoped(ctx, newWhere("email = ?", email)))
+}
+
+// GetByVerificationToken retrieves a user by verification token
+func (r *UserRepository) GetByVerificationToken(ctx context.Context, token string) (*models.User, error) {
+	return r.getBy(ctx, newWhere("email_verification_token = ?", token))
+}
+
+// GetByResetToken retrieves a user by password reset token
+func (r *UserRepository) GetByResetToken(ctx context.Context, token string) (*models.User, error) {
+	return r.getBy(ctx, newWhere("password_reset_token = ?", token))
+}
+
+// Update updates a user in the database
+func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
+	_, err := r.users.merge(ctx, user)
+	return err
+}
+
+// Delete removes a user from the database
+func (r *UserRepository) Delete(ctx context.Context, id models.ID) error {
+	_, err := r.users.delete(ctx, r.users.db, tenantScoped(ctx, byID(id)))
+	return err
+}
+
+// List retrieves a paginated list of users with optional filters
+func (r *UserRepository) List(ctx context.Context, page, pageSize int, filters repository.UserFilters) ([]*models.User, int64, error) {
+	w := tenantScoped(ctx, &where{})
+	if filters.Status != "" {
+		w.add("status = ?", filters.Status)
+	}
+	if filters.Search != "" {
+		w.search(filters.Search, "first_name", "last_name", "email")
+	}
+	if filters.CreatedAfter != nil {
+		w.add("created_at >= ?", *filters.CreatedAfter)
+	}
+	if filters.CreatedBefore != nil {
+		w.add("created_at <= ?", *filters.CreatedBefore)
+	}
+
+	total, err := r.users.count(ctx, w)
+	if err != nil {
+		return nil, 0, err
+	}
+	users, err := r.users.find(ctx, w, "created_at DESC", pageSize, skip(page, pageSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// AddWeddingID adds a wedding ID to the user's wedding IDs
+func (r *UserRepository) AddWeddingID(ctx context.Context, userID, weddingID models.ID) error {
+	return r.modify(ctx, byID(userID), func(user *models.User) {
+		for _, id := range user.WeddingIDs {
+			if id == weddingID {
+				return
+			}
+		}
+		user.WeddingIDs = append(user.WeddingIDs, weddingID)
+	})
+}
+
+// RemoveWeddingID removes a wedding ID from the user's wedding IDs
+func (r *UserRepository) RemoveWeddingID(ctx context.Context, userID, weddingID models.ID) error {
+	return r.modify(ctx, byID(userID), func(user *models.User) {
+		kept := user.WeddingIDs[:0]
+		for _, id := range user.WeddingIDs {
+			if id != weddingID {
+				kept = append(kept, id)
+			}
+		}
+		user.WeddingIDs = kept
+	})
+}
+
+// UpdateLastLogin updates the user's last login time
+func (r *UserRepository) UpdateLastLogin(ctx context.Context, userID models.ID) error {
+	now := time.Now()
+	return r.modify(ctx, byID(userID), func(user *models.User) {
+		user.LastLoginAt = &now
+	})
+}
+
+// SetEmailVerified marks a user's email as verified
+func (r *UserRepository) SetEmailVerified(ctx context.Context, userID models.ID) error {
+	now := time.Now()
+	return r.modify(ctx, byID(userID), func(user *models.User) {
+		user.EmailVerified = true
+		user.EmailVerifiedAt = &now
+		user.EmailVerificationToken = ""
+	})
+}
+
+// GetByBillingCustomerID retrieves a user by the payment provider's customer ID
+func (r *UserRepository) GetByBillingCustomerID(ctx context.Context, customerID string) (*models.User, error) {
+	return r.getBy(ctx, newWhere("billing_customer_id = ?", customerID))
+}
+
+// GetByOAuthAccount retrieves the user a social login account is linked to
+func (r *UserRepository) GetByOAuthAccount(ctx context.Context, provider models.AuthProvider, subject string) (*models.User, error) {
+	return r.getBy(ctx, newWhere("oauth_accounts @> ARRAY[?]::text[]", oauthAccountKey(provider, subject)))
+}
+
+// UpdateSubscription stores a user's subscription unless a newer one is stored already
+func (r *UserRepository) UpdateSubscription(ctx context.Context, userID models.ID, subscription *models.Subscription) error {
+	// Stored times have millisecond precision
+	updatedAt := subscription.UpdatedAt.Truncate(time.Millisecond)
+	_, err := r.users.modify(ctx, byID(userID), func(user *models.User) error {
+		if user.Subscription != nil && !user.Subscription.UpdatedAt.Before(updatedAt) {
+			return repository.ErrNotFound
+		}
+		user.Subscription = subscription
+		user.UpdatedAt = time.Now()
+		return nil
+	})
+	return err
+}
+
+// RecordFailedLogin counts a wrong password against the user and returns the updated user
+func (r *UserRepository) RecordFailedLogin(ctx context.Context, userID models.ID) (*models.User, error) {
+	user, err := r.users.modify(ctx, byID(userID), func(user *models.User) error {
+		if user.LoginSecurity == nil {
+			user.LoginSecurity = &models.LoginSecurity{}
+		}
+		user.LoginSecurity.FailedAttempts++
+		return nil
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	return user, err
+}
+
+// LockLogin locks logins until the given time and counts the lockout
+func (r *UserRepository) LockLogin(ctx context.Context, userID models.ID, until time.Time, unlockTokenHash string) error {
+	return r.modify(ctx, byID(userID), func(user *models.User) {
+		if user.LoginSecurity == nil {
+			user.LoginSecurity = &models.LoginSecurity{}
+		}
+		user.LoginSecurity.LockedUntil = &until
+		user.LoginSecurity.UnlockTokenHash = unlockTokenHash
+		user.LoginSecurity.FailedAttempts = 0
+		user.LoginSecurity.Lockouts++
+	})
+}
+
+// UnlockLogin lifts a lockout and resets the failed attempts
+func (r *UserRepository) UnlockLogin(ctx context.Context, userID models.ID) error {
+	return r.modify(ctx, byID(userID), func(user *models.User) {
+		if user.LoginSecurity == nil {
+			return
+		}
+		user.LoginSecurity.LockedUntil = nil
+		user.LoginSecurity.UnlockTokenHash = ""
+		user.LoginSecurity.FailedAttempts = 0
+	})
+}
+
+// GetByUnlockToken retrieves the locked user an unlock link was sent to
+func (r *UserRepository) GetByUnlockToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	return r.getBy(ctx, newWhere("unlock_token_hash = ?", tokenHash))
+}
+
+// getBy retrieves the user matching the conditions, or nil when there is none
+func (r *UserRepository) getBy(ctx context.Context, w *where) (*models.User, error) {
+	user, err := r.users.get(ctx, r.users.db, "", w)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	return user, err
+}
+
+// modify changes the user matching the conditions; like a MongoDB update, a missing user is not an error
+func (r *UserRepository) modify(ctx context.Context, w *where, fn func(*models.User)) error {
+	_, err := r.users.modify(ctx, w, func(user *models.User) error {
+		fn(user)
+		return nil
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,314 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Ensure WeddingRepository implements the domain repository interface
+var _ repository.WeddingRepository = (*WeddingRepository)(nil)
+
+// showcaseQuery matches any word of the search parameter, like a MongoDB $text search
+const showcaseQuery = "replace(plainto_tsquery('english', %s)::text, '&', '|')::tsquery"
+
+// weddingSearchColumns are searched by the owner and admin listings
+var weddingSearchColumns = []string{
+	"title", "slug", "partner1_first_name", "partner1_last_name", "partner2_first_name", "partner2_last_name",
+}
+
+// WeddingRepository implements repository.WeddingRepository for PostgreSQL
+type WeddingRepository struct {
+	weddings *table[models.Wedding]
+}
+
+// NewWeddingRepository creates a new PostgreSQL wedding repository
+func NewWeddingRepository(db *sql.DB) repository.WeddingRepository {
+	return &WeddingRepository{weddings: newWeddingTable(db)}
+}
+
+func newWeddingTable(db *sql.DB) *table[models.Wedding] {
+	return &table[models.Wedding]{
+		db:      db,
+		name:    "weddings",
+		id:      func(w *models.Wedding) models.ID { return w.ID },
+		columns: weddingColumns,
+	}
+}
+
+// weddingColumns derives the queried columns of a wedding
+func weddingColumns(w *models.Wedding) columns {
+	collaborators := make([]string, len(w.Collaborators))
+	for i, collaborator := range w.Collaborators {
+		collaborators[i] = collaborator.UserID.String()
+	}
+	var eventDate interface{}
+	if !w.Event.Date.IsZero() {
+		eventDate = w.Event.Date
+	}
+	return columns{
+		"user_id":             w.UserID.String(),
+		"collaborator_ids":    collaborators,
+		"slug":                w.Slug,
+		"status":              w.Status,
+		"is_public":           w.IsPublic,
+		"title":               w.Title,
+		"partner1_first_name": w.Couple.Partner1.FirstName,
+		"partner1_last_name":  w.Couple.Partner1.LastName,
+		"partner2_first_name": w.Couple.Partner2.FirstName,
+		"partner2_last_name":  w.Couple.Partner2.LastName,
+		"theme_id":            w.Theme.ThemeID,
+		"event_date":          eventDate,
+		"published_at":        nullTime(w.PublishedAt),
+		"view_count":          w.ViewCount,
+		"flagged":             w.Moderation != nil && w.Moderation.Flagged,
+		"taken_down":          w.Moderation != nil && w.Moderation.TakenDown,
+		"tenant_id":           w.TenantID,
+		"created_at":          w.CreatedAt,
+	}
+}
+
+// Create inserts a new wedding into the database
+func (r *WeddingRepository) Create(ctx context.Context, wedding *models.Wedding) error {
+	if wedding.ID.IsZero() {
+		wedding.ID = models.NewID()
+	}
+	wedding.CreatedAt = time.Now()
+	wedding.UpdatedAt = time.Now()
+	return r.weddings.insert(ctx, r.weddings.db, wedding)
+}
+
+// GetByID retrieves a wedding by ID
+func (r *WeddingRepository) GetByID(ctx context.Context, id models.ID) (*models.Wedding, error) {
+	return r.getBy(ctx, tenantScoped(ctx, byID(id)))
+}
+
+// GetByIDs retrieves the weddings with the given IDs
+func (r *WeddingRepository) GetByIDs(ctx context.Context, ids []models.ID) ([]*models.Wedding, error) {
+	if len(ids) == 0 {
+		return []*models.Wedding{}, nil
+	}
+	return r.weddings.find(ctx, tenantScoped(ctx, newWhere("id = ANY(?)", hexIDs(ids))), "", 0, 0)
+}
+
+// GetBySlug retrieves a wedding by slug
+func (r *WeddingRepository) GetBySlug(ctx context.Context, slug string) (*models.Wedding, error) {
+	return r.getBy(ctx, tenantScoped(ctx, newWhere("slug = ?", slug)))
+}
+
+// GetByUserID retrieves the weddings a user owns or collaborates on with pagination
+func (r *WeddingRepository) GetByUserID(ctx context.Context, userID models.ID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error) {
+	w := tenantScoped(ctx, newWhere("(user_id = ? OR collaborator_ids @> ARRAY[?]::text[])", userID.String(), userID.String()))
+	if filters.Status != "" {
+		w.add("status = ?", filters.Status)
+	}
+	if filters.Search != "" {
+		w.search(filters.Search, weddingSearchColumns...)
+	}
+	if filters.CreatedAfter != nil {
+		w.add("created_at >= ?", *filters.CreatedAfter)
+	}
+	if filters.CreatedBefore != nil {
+		w.add("created_at <= ?", *filters.CreatedBefore)
+	}
+	if filters.EventDate != nil {
+		w.add("event_date >= ?", *filters.EventDate)
+	}
+
+	return r.page(ctx, w, "created_at DESC", page, pageSize)
+}
+
+// Update updates a wedding in the database
+func (r *WeddingRepository) Update(ctx context.Context, wedding *models.Wedding) error {
+	wedding.UpdatedAt = time.Now()
+	_, err := r.weddings.merge(ctx, wedding)
+	return err
+}
+
+// Delete removes a wedding from the database
+func (r *WeddingRepository) Delete(ctx context.Context, id models.ID) error {
+	_, err := r.weddings.delete(ctx, r.weddings.db, tenantScoped(ctx, byID(id)))
+	return err
+}
+
+// ExistsBySlug checks if a wedding with the given slug exists
+func (r *WeddingRepository) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
+	count, err := r.weddings.count(ctx, newWhere("slug = ?", slug))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CountByOwner counts the weddings a user owns
+func (r *WeddingRepository) CountByOwner(ctx context.Context, userID models.ID) (int64, error) {
+	return r.weddings.count(ctx, newWhere("user_id = ?", userID.String()))
+}
+
+// CountByTheme counts the weddings using a theme
+func (r *WeddingRepository) CountByTheme(ctx context.Context, themeID string) (int64, error) {
+	return r.weddings.count(ctx, newWhere("theme_id = ?", themeID))
+}
+
+// List retrieves all weddings matching the admin filters with pagination
+func (r *WeddingRepository) List(ctx context.Context, page, pageSize int, filters repository.AdminWeddingFilters) ([]*models.Wedding, int64, error) {
+	w := tenantScoped(ctx, &where{})
+	if filters.Status != "" {
+		w.add("status = ?", filters.Status)
+	}
+	if filters.OwnerID != nil {
+		w.add("user_id = ?", filters.OwnerID.String())
+	}
+	if filters.Search != "" {
+		w.search(filters.Search, weddingSearchColumns...)
+	}
+	if filters.Flagged != nil {
+		w.add("flagged = ?", *filters.Flagged)
+	}
+	if filters.TakenDown != nil {
+		w.add("taken_down = ?", *filters.TakenDown)
+	}
+
+	weddings, total, err := r.page(ctx, w, "created_at DESC, id DESC", page, pageSize)
+	if weddings == nil && err == nil {
+		weddings = []*models.Wedding{}
+	}
+	return weddings, total, err
+}
+
+// ListPublic retrieves public weddings with pagination
+func (r *WeddingRepository) ListPublic(ctx context.Context, page, pageSize int, filters repository.PublicWeddingFilters) ([]*models.Wedding, int64, error) {
+	w := newWhere("is_public = ?", true).add("status = ?", string(models.WeddingStatusPublished))
+	if filters.Search != "" {
+		w.add("search @@ "+fmt.Sprintf(showcaseQuery, "?"), filters.Search)
+	}
+
+	var from *time.Time
+	if filters.EventDate != nil {
+		from = filters.EventDate
+	}
+	if filters.DateFrom != nil {
+		from = filters.DateFrom
+	}
+	now := time.Now()
+	switch filters.When {
+	case repository.PublicWeddingsUpcoming:
+		if from == nil || from.Before(now) {
+			from = &now
+		}
+	case repository.PublicWeddingsPast:
+		w.add("event_date < ?", now)
+	}
+	if from != nil {
+		w.add("event_date >= ?", *from)
+	}
+	if filters.DateTo != nil {
+		w.add("event_date <= ?", *filters.DateTo)
+	}
+
+	total, err := r.weddings.count(ctx, w)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	weddings, err := r.weddings.find(ctx, w, publicWeddingOrder(filters), pageSize, skip(page, pageSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	return weddings, total, nil
+}
+
+// IncrementViewCount increments the view count for a wedding
+func (r *WeddingRepository) IncrementViewCount(ctx context.Context, id models.ID) error {
+	now := time.Now()
+	return r.modify(ctx, byID(id), func(wedding *models.Wedding) {
+		wedding.ViewCount++
+		wedding.LastViewedAt = &now
+	})
+}
+
+// UpdateRSVPCount recounts the RSVPs of a wedding. TotalAttending counts every
+// attending guest together with their named companions. RSVPs held for review
+// are left out until accepted, as in the RSVP statistics.
+func (r *WeddingRepository) UpdateRSVPCount(ctx context.Context, weddingID models.ID) error {
+	var counts struct {
+		RSVPs     int
+		Attending int
+	}
+	err := r.weddings.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(CASE WHEN status = $2 THEN attendance_count + plus_one_count ELSE 0 END), 0)
+		FROM rsvps
+		WHERE wedding_id = $1 AND `+countedRSVPs,
+		weddingID.String(), string(models.RSVPAttending),
+	).Scan(&counts.RSVPs, &counts.Attending)
+	if err != nil {
+		return err
+	}
+
+	return r.modify(ctx, byID(weddingID), func(wedding *models.Wedding) {
+		wedding.RSVPCount = counts.RSVPs
+		wedding.TotalAttending = counts.Attending
+		wedding.UpdatedAt = time.Now()
+	})
+}
+
+// page counts the weddings matching the conditions and lists one page of them
+func (r *WeddingRepository) page(ctx context.Context, w *where, order string, page, pageSize int) ([]*models.Wedding, int64, error) {
+	total, err := r.weddings.count(ctx, w)
+	if err != nil {
+		return nil, 0, err
+	}
+	weddings, err := r.weddings.find(ctx, w, order, pageSize, skip(page, pageSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	return weddings, total, nil
+}
+
+// getBy retrieves the wedding matching the conditions, or nil when there is none
+func (r *WeddingRepository) getBy(ctx context.Context, w *where) (*models.Wedding, error) {
+	wedding, err := r.weddings.get(ctx, r.weddings.db, "", w)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	return wedding, err
+}
+
+// modify changes the wedding matching the conditions; like a MongoDB update, a missing wedding is not an error
+func (r *WeddingRepository) modify(ctx context.Context, w *where, fn func(*models.Wedding)) error {
+	_, err := r.weddings.modify(ctx, w, func(wedding *models.Wedding) error {
+		fn(wedding)
+		return nil
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// publicWeddingOrder maps a showcase sort option to an ORDER BY clause, with the
+// ID as a tie-breaker so pagination is stable
+func publicWeddingOrder(filters repository.PublicWeddingFilters) string {
+	switch filters.Sort {
+	case repository.PublicWeddingSortRelevance:
+		if filters.Search != "" {
+			// The search is the third parameter, after is_public and status
+			return "ts_rank(search, " + fmt.Sprintf(showcaseQuery, "$3") + ") DESC, id DESC"
+		}
+	case repository.PublicWeddingSortRecent:
+		return "published_at DESC NULLS LAST, id DESC"
+	case repository.PublicWeddingSortMostViewed:
+		return "view_count DESC, id DESC"
+	}
+
+	// Date order: soonest first, or most recent first when browsing past weddings
+	if filters.When == repository.PublicWeddingsPast {
+		return "event_date DESC NULLS LAST, id DESC"
+	}
+	return "event_date ASC NULLS FIRST, id ASC"
+}
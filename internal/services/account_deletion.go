@@ -0,0 +1,347 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"net/url"
+	"sync"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// Account deletion errors
+var (
+	ErrAccountDeletionNotRequested = errors.New("account deletion was not requested")
+	ErrAccountDeletionScheduled    = errors.New("account deletion is already scheduled")
+	ErrInvalidDeletionToken        = errors.New("invalid or expired account deletion link")
+)
+
+const (
+	// accountDeletionLinkTTL is how long the emailed confirmation link is valid
+	accountDeletionLinkTTL = 24 * time.Hour
+	// DefaultAccountDeletionGracePeriod is how long a confirmed deletion can be cancelled
+	DefaultAccountDeletionGracePeriod = 14 * 24 * time.Hour
+	// accountErasureBatch caps the accounts erased per run
+	accountErasureBatch = 20
+)
+
+// AccountDeletionOptions configures account deletion
+type AccountDeletionOptions struct {
+	// SiteURL is where the confirmation link points to
+	SiteURL string
+	// GracePeriod is how long a confirmed deletion can be cancelled before the
+	// account's data is erased
+	GracePeriod time.Duration
+}
+
+// AccountDeletionService lets users delete their account. A request emails a
+// confirmation link; once confirmed, the account is signed out everywhere and
+// erased after the grace period unless the user cancels first. Erasure deletes
+// the weddings the user owns with everything in them and the files the user
+// uploaded, and anonymizes what other weddings store under the user's email.
+type AccountDeletionService struct {
+	repo     repository.AccountDeletionRepository
+	userRepo repository.UserRepository
+	media    MediaService
+	storage  StorageService
+	sessions *SessionService
+	email    EmailService
+	audit    AuditRecorder
+	opts     AccountDeletionOptions
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+// NewAccountDeletionService creates a new account deletion service
+func NewAccountDeletionService(
+	repo repository.AccountDeletionRepository,
+	userRepo repository.UserRepository,
+	media MediaService,
+	storage StorageService,
+	sessions *SessionService,
+	email EmailService,
+	opts AccountDeletionOptions,
+	logger *zap.Logger,
+) *AccountDeletionService {
+	if opts.GracePeriod <= 0 {
+		opts.GracePeriod = DefaultAccountDeletionGracePeriod
+	}
+	return &AccountDeletionService{
+		repo:     repo,
+		userRepo: userRepo,
+		media:    media,
+		storage:  storage,
+		sessions: sessions,
+		email:    email,
+		opts:     opts,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// SetAuditLog records erased accounts in the audit log
+func (s *AccountDeletionService) SetAuditLog(audit AuditRecorder) {
+	s.audit = audit
+}
+
+// RequestDeletion starts deleting the user's account by emailing them a
+// confirmation link. Asking again sends a new link.
+func (s *AccountDeletionService) RequestDeletion(ctx context.Context, userID models.ID) (*models.AccountDeletion, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	if user.Deletion.Confirmed() {
+		return nil, ErrAccountDeletionScheduled
+	}
+
+	token, err := utils.GenerateResetToken()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	expiresAt := now.Add(accountDeletionLinkTTL)
+	deletion := &models.AccountDeletion{
+		RequestedAt:    now,
+		TokenHash:      hashDeletionToken(token),
+		TokenExpiresAt: &expiresAt,
+	}
+	if err := s.repo.SetDeletion(ctx, user.ID, deletion); err != nil {
+		return nil, err
+	}
+
+	// Unlike other account emails the link is the only way to go on, so a
+	// failure to send it fails the request
+	msg, err := DefaultEmailTemplates().Render(EmailTemplateAccountDeletion, struct {
+		FirstName       string
+		Link            string
+		ExpiresAt       time.Time
+		GracePeriodDays int
+	}{user.FirstName, s.confirmLink(token), expiresAt, int(s.opts.GracePeriod.Hours() / 24)})
+	if err != nil {
+		return nil, err
+	}
+	msg.To = user.Email
+	if err := s.email.Send(ctx, msg); err != nil {
+		return nil, fmt.Errorf("failed to send account deletion email: %w", err)
+	}
+	return deletion, nil
+}
+
+// ConfirmDeletion confirms the deletion the emailed token was sent for and
+// schedules the erasure after the grace period. The account is signed out of
+// every session.
+func (s *AccountDeletionService) ConfirmDeletion(ctx context.Context, token string) (*models.AccountDeletion, error) {
+	if token == "" {
+		return nil, ErrInvalidDeletionToken
+	}
+	user, err := s.repo.GetByDeletionToken(ctx, hashDeletionToken(token))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrInvalidDeletionToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	deletion := user.Deletion
+	if deletion == nil || deletion.TokenExpiresAt == nil || now.After(*deletion.TokenExpiresAt) {
+		return nil, ErrInvalidDeletionToken
+	}
+
+	scheduledFor := now.Add(s.opts.GracePeriod)
+	deletion.TokenHash = ""
+	deletion.TokenExpiresAt = nil
+	deletion.ConfirmedAt = &now
+	deletion.ScheduledFor = &scheduledFor
+	if err := s.repo.SetDeletion(ctx, user.ID, deletion); err != nil {
+		return nil, err
+	}
+
+	if s.sessions != nil {
+		if _, err := s.sessions.RevokeAllSessions(ctx, user.ID); err != nil {
+			s.logger.Warn("Failed to revoke sessions of deleted account",
+				zap.String("user_id", user.ID.String()),
+				zap.Error(err))
+		}
+	}
+
+	s.logger.Info("Account deletion confirmed",
+		zap.String("user_id", user.ID.String()),
+		zap.Time("scheduled_for", scheduledFor))
+	return deletion, nil
+}
+
+// CancelDeletion withdraws the user's deletion request, confirmed or not
+func (s *AccountDeletionService) CancelDeletion(ctx context.Context, userID models.ID) error {
+	err := s.repo.ClearDeletion(ctx, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrAccountDeletionNotRequested
+	}
+	return err
+}
+
+// EraseDue erases the accounts whose grace period ended by now, returning how
+// many were erased. Accounts that fail are logged and retried on the next run.
+func (s *AccountDeletionService) EraseDue(ctx context.Context, now time.Time) (int, error) {
+	users, err := s.repo.ListDue(ctx, now, accountErasureBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	erased := 0
+	for _, user := range users {
+		if err := s.EraseAccount(ctx, user); err != nil {
+			s.logger.Error("Account erasure failed",
+				zap.String("user_id", user.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		erased++
+	}
+	return erased, nil
+}
+
+// EraseAccount permanently erases the account and its data. Each step can be
+// repeated, so an interrupted erasure is finished by the next run; the user
+// record goes last.
+func (s *AccountDeletionService) EraseAccount(ctx context.Context, user *models.User) error {
+	weddingIDs, err := s.repo.OwnedWeddingIDs(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	for _, weddingID := range weddingIDs {
+		keys, err := s.repo.DeleteWeddingData(ctx, weddingID)
+		if err != nil {
+			return err
+		}
+		s.deleteExportFiles(ctx, user.ID, keys)
+	}
+
+	files, err := s.media.PurgeUserMedia(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to purge media: %w", err)
+	}
+
+	anonymized, err := s.repo.AnonymizeContact(ctx, user.Email)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.RemoveCollaborator(ctx, user.ID); err != nil {
+		return err
+	}
+	// Account exports include the user's data too
+	keys, err := s.repo.DeleteAccount(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	s.deleteExportFiles(ctx, user.ID, keys)
+
+	// The entry outlives the account, so it holds no personal data
+	if s.audit != nil {
+		s.audit.Record(ctx, &models.AuditLog{
+			ActorID:    &user.ID,
+			Action:     models.AuditUserErase,
+			TargetType: models.AuditTargetUser,
+			TargetID:   user.ID.String(),
+			Metadata: map[string]interface{}{
+				"weddings":   len(weddingIDs),
+				"files":      files,
+				"anonymized": anonymized,
+			},
+		})
+	}
+
+	s.logger.Info("Account erased",
+		zap.String("user_id", user.ID.String()),
+		zap.Int("weddings", len(weddingIDs)),
+		zap.Int("files", files))
+	return nil
+}
+
+// deleteExportFiles removes export files from storage; failures are only logged
+// as their records are gone already
+func (s *AccountDeletionService) deleteExportFiles(ctx context.Context, userID models.ID, keys []string) {
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			s.logger.Warn("Failed to delete export file of erased account",
+				zap.String("user_id", userID.String()),
+				zap.String("key", key),
+				zap.Error(err))
+		}
+	}
+}
+
+func (s *AccountDeletionService) confirmLink(token string) string {
+	query := url.Values{}
+	query.Set("token", token)
+	return fmt.Sprintf("%s/account/delete/confirm?%s", s.opts.SiteURL, query.Encode())
+}
+
+func hashDeletionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// AccountErasureScheduler periodically erases the accounts whose deletion is due
+type AccountErasureScheduler struct {
+	service  *AccountDeletionService
+	interval time.Duration
+	logger   *zap.Logger
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewAccountErasureScheduler creates a scheduler that checks for due deletions every interval
+func NewAccountErasureScheduler(service *AccountDeletionService, interval time.Duration, logger *zap.Logger) *AccountErasureScheduler {
+	return &AccountErasureScheduler{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start runs the scheduler loop in the background
+func (sch *AccountErasureScheduler) Start(ctx context.Context) {
+	sch.wg.Add(1)
+	go func() {
+		defer sch.wg.Done()
+		ticker := time.NewTicker(sch.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sch.stop:
+				return
+			case now := <-ticker.C:
+				erased, err := sch.service.EraseDue(ctx, now)
+				if err != nil {
+					sch.logger.Error("Account erasure run failed", zap.Error(err))
+					continue
+				}
+				if erased > 0 {
+					sch.logger.Info("Deleted accounts erased", zap.Int("count", erased))
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the scheduler loop to exit and waits for it
+func (sch *AccountErasureScheduler) Stop() {
+	close(sch.stop)
+	sch.wg.Wait()
+}
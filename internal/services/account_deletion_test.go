@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// memoryAccountDeletionRepository keeps deletion requests in memory and records erasure steps
+type memoryAccountDeletionRepository struct {
+	users       map[models.ID]*models.User
+	owned       map[models.ID][]models.ID
+	exportKeys  map[models.ID][]string
+	userKeys    map[models.ID][]string
+	erased      []models.ID
+	anonymized  []string
+	uncollabs   []models.ID
+	deleted     []models.ID
+	deleteError error
+}
+
+func newMemoryAccountDeletionRepository(users ...*models.User) *memoryAccountDeletionRepository {
+	repo := &memoryAccountDeletionRepository{
+		users:      make(map[models.ID]*models.User),
+		owned:      make(map[models.ID][]models.ID),
+		exportKeys: make(map[models.ID][]string),
+		userKeys:   make(map[models.ID][]string),
+	}
+	for _, user := range users {
+		repo.users[user.ID] = user
+	}
+	return repo
+}
+
+func (r *memoryAccountDeletionRepository) SetDeletion(ctx context.Context, userID models.ID, deletion *models.AccountDeletion) error {
+	user, ok := r.users[userID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	stored := *deletion
+	user.Deletion = &stored
+	return nil
+}
+
+func (r *memoryAccountDeletionRepository) ClearDeletion(ctx context.Context, userID models.ID) error {
+	user, ok := r.users[userID]
+	if !ok || user.Deletion == nil {
+		return repository.ErrNotFound
+	}
+	user.Deletion = nil
+	return nil
+}
+
+func (r *memoryAccountDeletionRepository) GetByDeletionToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.Deletion != nil && user.Deletion.TokenHash != "" && user.Deletion.TokenHash == tokenHash {
+			copied := *user
+			deletion := *user.Deletion
+			copied.Deletion = &deletion
+			return &copied, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *memoryAccountDeletionRepository) ListDue(ctx context.Context, before time.Time, limit int) ([]*models.User, error) {
+	var due []*models.User
+	for _, user := range r.users {
+		if user.Deletion != nil && user.Deletion.ScheduledFor != nil && !user.Deletion.ScheduledFor.After(before) {
+			due = append(due, user)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryAccountDeletionRepository) OwnedWeddingIDs(ctx context.Context, userID models.ID) ([]models.ID, error) {
+	return r.owned[userID], nil
+}
+
+func (r *memoryAccountDeletionRepository) DeleteWeddingData(ctx context.Context, weddingID models.ID) ([]string, error) {
+	r.erased = append(r.erased, weddingID)
+	return r.exportKeys[weddingID], nil
+}
+
+func (r *memoryAccountDeletionRepository) AnonymizeContact(ctx context.Context, email string) (int64, error) {
+	r.anonymized = append(r.anonymized, email)
+	return 2, nil
+}
+
+func (r *memoryAccountDeletionRepository) RemoveCollaborator(ctx context.Context, userID models.ID) error {
+	r.uncollabs = append(r.uncollabs, userID)
+	return nil
+}
+
+func (r *memoryAccountDeletionRepository) DeleteAccount(ctx context.Context, userID models.ID) ([]string, error) {
+	if r.deleteError != nil {
+		return nil, r.deleteError
+	}
+	r.deleted = append(r.deleted, userID)
+	delete(r.users, userID)
+	return r.userKeys[userID], nil
+}
+
+// purgingMediaService counts the users whose media were purged; other media operations are unused
+type purgingMediaService struct {
+	MediaService
+	purged []models.ID
+	err    error
+}
+
+func (m *purgingMediaService) PurgeUserMedia(ctx context.Context, userID models.ID) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	m.purged = append(m.purged, userID)
+	return 3, nil
+}
+
+type accountDeletionFixture struct {
+	service  *AccountDeletionService
+	repo     *memoryAccountDeletionRepository
+	users    *MockUserRepository
+	media    *purgingMediaService
+	storage  *MockStorageService
+	sessions *memorySessionRepository
+	email    *MockEmailService
+	audit    *MockAuditLogRepository
+	user     *models.User
+	now      time.Time
+}
+
+func setupAccountDeletionService(t *testing.T) *accountDeletionFixture {
+	user := &models.User{ID: models.NewID(), Email: "Ana@Example.com", FirstName: "Ana"}
+	f := &accountDeletionFixture{
+		repo:     newMemoryAccountDeletionRepository(user),
+		users:    new(MockUserRepository),
+		media:    &purgingMediaService{},
+		storage:  new(MockStorageService),
+		sessions: newMemorySessionRepository(),
+		email:    &MockEmailService{},
+		audit:    &MockAuditLogRepository{},
+		user:     user,
+		now:      time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.users.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+
+	f.service = NewAccountDeletionService(f.repo, f.users, f.media, f.storage, NewSessionService(f.sessions, zap.NewNop()), f.email,
+		AccountDeletionOptions{SiteURL: "https://example.com", GracePeriod: 7 * 24 * time.Hour}, zaptest.NewLogger(t))
+	f.service.SetAuditLog(NewAuditLogService(f.audit, &MockWeddingRepository{}, zap.NewNop()))
+	f.service.now = func() time.Time { return f.now }
+	return f
+}
+
+// emailedDeletionToken returns the token of the last confirmation link emailed
+func emailedDeletionToken(t *testing.T, email *MockEmailService) string {
+	t.Helper()
+	require.NotEmpty(t, email.sent)
+	msg := email.sent[len(email.sent)-1]
+	start := strings.Index(msg.Text, "https://example.com/account/delete/confirm?")
+	require.GreaterOrEqual(t, start, 0, msg.Text)
+	link, err := url.Parse(strings.Fields(msg.Text[start:])[0])
+	require.NoError(t, err)
+	return link.Query().Get("token")
+}
+
+func TestAccountDeletionService_RequestAndConfirm(t *testing.T) {
+	ctx := context.Background()
+	f := setupAccountDeletionService(t)
+	require.NoError(t, f.sessions.Create(ctx, &models.Session{ID: models.NewID(), UserID: f.user.ID, ExpiresAt: f.now.Add(time.Hour)}))
+
+	deletion, err := f.service.RequestDeletion(ctx, f.user.ID)
+	require.NoError(t, err)
+	assert.False(t, deletion.Confirmed())
+	require.Len(t, f.email.sent, 1)
+	assert.Equal(t, "Ana@Example.com", f.email.sent[0].To)
+	assert.Equal(t, "Confirm deleting your account", f.email.sent[0].Subject)
+	assert.Contains(t, f.email.sent[0].Text, "after 7 days")
+
+	token := emailedDeletionToken(t, f.email)
+	assert.NotEqual(t, token, f.user.Deletion.TokenHash, "only the hash is stored")
+
+	_, err = f.service.ConfirmDeletion(ctx, "wrong")
+	assert.ErrorIs(t, err, ErrInvalidDeletionToken)
+
+	confirmed, err := f.service.ConfirmDeletion(ctx, token)
+	require.NoError(t, err)
+	require.True(t, confirmed.Confirmed())
+	assert.Equal(t, f.now.Add(7*24*time.Hour), *confirmed.ScheduledFor)
+	assert.Empty(t, f.user.Deletion.TokenHash)
+	assert.Empty(t, f.sessions.sessions, "the account is signed out everywhere")
+
+	// The link works once, and a scheduled deletion is not requested again
+	_, err = f.service.ConfirmDeletion(ctx, token)
+	assert.ErrorIs(t, err, ErrInvalidDeletionToken)
+	_, err = f.service.RequestDeletion(ctx, f.user.ID)
+	assert.ErrorIs(t, err, ErrAccountDeletionScheduled)
+}
+
+func TestAccountDeletionService_ExpiredLink(t *testing.T) {
+	ctx := context.Background()
+	f := setupAccountDeletionService(t)
+
+	_, err := f.service.RequestDeletion(ctx, f.user.ID)
+	require.NoError(t, err)
+	token := emailedDeletionToken(t, f.email)
+
+	f.now = f.now.Add(25 * time.Hour)
+	_, err = f.service.ConfirmDeletion(ctx, token)
+	assert.ErrorIs(t, err, ErrInvalidDeletionToken)
+	assert.False(t, f.user.Deletion.Confirmed())
+}
+
+func TestAccountDeletionService_EmailFailure(t *testing.T) {
+	f := setupAccountDeletionService(t)
+	f.email.err = errors.New("smtp unavailable")
+
+	_, err := f.service.RequestDeletion(context.Background(), f.user.ID)
+	assert.Error(t, err)
+}
+
+func TestAccountDeletionService_Cancel(t *testing.T) {
+	ctx := context.Background()
+	f := setupAccountDeletionService(t)
+
+	assert.ErrorIs(t, f.service.CancelDeletion(ctx, f.user.ID), ErrAccountDeletionNotRequested)
+
+	_, err := f.service.RequestDeletion(ctx, f.user.ID)
+	require.NoError(t, err)
+	_, err = f.service.ConfirmDeletion(ctx, emailedDeletionToken(t, f.email))
+	require.NoError(t, err)
+
+	require.NoError(t, f.service.CancelDeletion(ctx, f.user.ID))
+	assert.Nil(t, f.user.Deletion)
+
+	erased, err := f.service.EraseDue(ctx, f.now.Add(30*24*time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, erased)
+	assert.Empty(t, f.repo.deleted)
+}
+
+func TestAccountDeletionService_EraseDue(t *testing.T) {
+	ctx := context.Background()
+	f := setupAccountDeletionService(t)
+	weddingID := models.NewID()
+	f.repo.owned[f.user.ID] = []models.ID{weddingID}
+	f.repo.exportKeys[weddingID] = []string{"exports/rsvps.csv"}
+	f.storage.On("Delete", ctx, "exports/rsvps.csv").Return(nil)
+	f.repo.userKeys[f.user.ID] = []string{"exports/account/takeout.zip"}
+	f.storage.On("Delete", ctx, "exports/account/takeout.zip").Return(nil)
+
+	_, err := f.service.RequestDeletion(ctx, f.user.ID)
+	require.NoError(t, err)
+	_, err = f.service.ConfirmDeletion(ctx, emailedDeletionToken(t, f.email))
+	require.NoError(t, err)
+
+	// Nothing is erased during the grace period
+	erased, err := f.service.EraseDue(ctx, f.now.Add(6*24*time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, erased)
+
+	// A failed erasure is retried on the next run
+	f.repo.deleteError = errors.New("connection reset")
+	erased, err = f.service.EraseDue(ctx, f.now.Add(8*24*time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, erased)
+	assert.Empty(t, f.audit.entries)
+
+	f.repo.deleteError = nil
+	erased, err = f.service.EraseDue(ctx, f.now.Add(8*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, erased)
+
+	assert.Contains(t, f.repo.erased, weddingID)
+	assert.Contains(t, f.media.purged, f.user.ID)
+	assert.Contains(t, f.repo.anonymized, "Ana@Example.com")
+	assert.Contains(t, f.repo.uncollabs, f.user.ID)
+	assert.Equal(t, []models.ID{f.user.ID}, f.repo.deleted)
+	f.storage.AssertCalled(t, "Delete", ctx, "exports/rsvps.csv")
+	f.storage.AssertCalled(t, "Delete", ctx, "exports/account/takeout.zip")
+
+	require.Len(t, f.audit.entries, 1)
+	entry := f.audit.entries[0]
+	assert.Equal(t, models.AuditUserErase, entry.Action)
+	assert.Equal(t, f.user.ID.String(), entry.TargetID)
+	assert.Equal(t, 1, entry.Metadata["weddings"])
+	assert.Equal(t, 3, entry.Metadata["files"])
+	assert.NotContains(t, entry.Metadata, "email")
+}
+
+func TestAccountDeletionService_EraseStopsWhenMediaPurgeFails(t *testing.T) {
+	ctx := context.Background()
+	f := setupAccountDeletionService(t)
+	f.media.err = errors.New("storage unavailable")
+
+	err := f.service.EraseAccount(ctx, f.user)
+	assert.Error(t, err)
+	assert.Empty(t, f.repo.deleted, "the account stays until its files are gone")
+}
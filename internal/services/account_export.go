@@ -0,0 +1,518 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+const (
+	// AccountExportResource is the resource account exports are recorded as
+	AccountExportResource = "account"
+	// AccountExportFormat is the format of account exports: a zip archive of
+	// JSON and CSV files plus the uploaded media files
+	AccountExportFormat = "zip"
+	// accountExportLinkExpiry is how long a download link of an account export works
+	accountExportLinkExpiry = 15 * time.Minute
+	// accountExportPageSize is how many weddings or wishes are read per query
+	accountExportPageSize = 100
+)
+
+// accountExportLayout describes how the records of one file of an account
+// export are laid out as CSV rows
+type accountExportLayout struct {
+	header []string
+	row    func(record interface{}) []string
+}
+
+var accountWeddingLayout = accountExportLayout{
+	header: []string{"id", "title", "slug", "status", "published_at", "created_at"},
+	row: func(record interface{}) []string {
+		wedding := record.(*models.Wedding)
+		publishedAt := ""
+		if wedding.PublishedAt != nil {
+			publishedAt = wedding.PublishedAt.UTC().Format(time.RFC3339)
+		}
+		return []string{
+			wedding.ID.String(),
+			wedding.Title,
+			wedding.Slug,
+			wedding.Status,
+			publishedAt,
+			wedding.CreatedAt.UTC().Format(time.RFC3339),
+		}
+	},
+}
+
+var accountGuestLayout = accountExportLayout{
+	header: []string{
+		"id", "first_name", "last_name", "email", "phone", "relationship", "side", "invitation_status",
+		"rsvp_status", "allow_plus_one", "max_plus_ones", "vip", "dietary_notes", "notes",
+	},
+	row: func(record interface{}) []string {
+		guest := record.(*models.Guest)
+		return []string{
+			guest.ID.String(),
+			guest.FirstName,
+			guest.LastName,
+			guest.Email,
+			guest.Phone,
+			guest.Relationship,
+			guest.Side,
+			guest.InvitationStatus,
+			guest.RSVPStatus,
+			strconv.FormatBool(guest.AllowPlusOne),
+			strconv.Itoa(guest.MaxPlusOnes),
+			strconv.FormatBool(guest.VIP),
+			guest.DietaryNotes,
+			guest.Notes,
+		}
+	},
+}
+
+var accountRSVPLayout = accountExportLayout{
+	header: []string{
+		"id", "first_name", "last_name", "email", "phone", "status", "attendance_count", "plus_one_count",
+		"dietary_restrictions", "meal_choice", "additional_notes", "source", "submitted_at",
+	},
+	row: func(record interface{}) []string {
+		rsvp := record.(*models.RSVP)
+		return []string{
+			rsvp.ID.String(),
+			rsvp.FirstName,
+			rsvp.LastName,
+			rsvp.Email,
+			rsvp.Phone,
+			rsvp.Status,
+			strconv.Itoa(rsvp.AttendanceCount),
+			strconv.Itoa(rsvp.PlusOneCount),
+			rsvp.DietaryRestrictions,
+			rsvp.MealChoice,
+			rsvp.AdditionalNotes,
+			rsvp.Source,
+			rsvp.SubmittedAt.UTC().Format(time.RFC3339),
+		}
+	},
+}
+
+var accountWishLayout = accountExportLayout{
+	header: []string{"id", "guest_name", "message", "status", "created_at"},
+	row: func(record interface{}) []string {
+		wish := record.(*models.Wish)
+		return []string{
+			wish.ID.String(),
+			wish.GuestName,
+			wish.Message,
+			string(wish.Status),
+			wish.CreatedAt.UTC().Format(time.RFC3339),
+		}
+	},
+}
+
+var accountMediaLayout = accountExportLayout{
+	header: []string{"id", "filename", "mime_type", "size", "width", "height", "created_at", "file"},
+	row: func(record interface{}) []string {
+		media := record.(*accountExportMedia)
+		return []string{
+			media.ID.String(),
+			media.Filename,
+			media.MimeType,
+			strconv.FormatInt(media.Size, 10),
+			strconv.Itoa(media.Width),
+			strconv.Itoa(media.Height),
+			media.CreatedAt.UTC().Format(time.RFC3339),
+			media.File,
+		}
+	},
+}
+
+// accountExportMedia is a media record with the path of its file in the archive,
+// empty when the file could not be included
+type accountExportMedia struct {
+	*models.Media
+	File string `json:"file,omitempty"`
+}
+
+// AccountExportService exports everything a user owns — their profile, weddings
+// with guests, RSVPs and wishes, and uploaded media with the files — as a zip
+// archive generated in the background into storage
+type AccountExportService struct {
+	userRepo      repository.UserRepository
+	weddingRepo   repository.WeddingRepository
+	guestRepo     repository.GuestRepository
+	rsvpRepo      repository.RSVPRepository
+	wishRepo      repository.WishRepository
+	mediaRepo     repository.MediaRepository
+	exportJobRepo repository.ExportJobRepository
+	storage       StorageService
+	jobs          JobEnqueuer
+	logger        *zap.Logger
+}
+
+// NewAccountExportService creates a new account export service
+func NewAccountExportService(
+	userRepo repository.UserRepository,
+	weddingRepo repository.WeddingRepository,
+	guestRepo repository.GuestRepository,
+	rsvpRepo repository.RSVPRepository,
+	wishRepo repository.WishRepository,
+	mediaRepo repository.MediaRepository,
+	exportJobRepo repository.ExportJobRepository,
+	storage StorageService,
+	jobs JobEnqueuer,
+	logger *zap.Logger,
+) *AccountExportService {
+	return &AccountExportService{
+		userRepo:      userRepo,
+		weddingRepo:   weddingRepo,
+		guestRepo:     guestRepo,
+		rsvpRepo:      rsvpRepo,
+		wishRepo:      wishRepo,
+		mediaRepo:     mediaRepo,
+		exportJobRepo: exportJobRepo,
+		storage:       storage,
+		jobs:          jobs,
+		logger:        logger,
+	}
+}
+
+// QueueExport queues an export of the user's data; its status is polled with
+// GetExport and the archive downloaded with DownloadURL once completed
+func (s *AccountExportService) QueueExport(ctx context.Context, userID models.ID) (*models.ExportJob, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	job := &models.ExportJob{
+		UserID:   userID,
+		Resource: AccountExportResource,
+		Format:   AccountExportFormat,
+		Status:   models.ExportJobQueued,
+	}
+	if err := s.exportJobRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to record export job: %w", err)
+	}
+
+	err = s.jobs.Enqueue(ctx, JobTypeExportAccount, AccountExportJob{
+		ExportJobID: job.ID,
+		UserID:      userID,
+	})
+	if err != nil {
+		finishExportJob(ctx, s.exportJobRepo, s.logger, job, 0, err)
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetExport returns one of the user's account exports
+func (s *AccountExportService) GetExport(ctx context.Context, userID, jobID models.ID) (*models.ExportJob, error) {
+	job, err := s.exportJobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrExportJobNotFound
+		}
+		return nil, err
+	}
+	// Other exports of the user are served with their wedding
+	if job.UserID != userID || job.Resource != AccountExportResource {
+		return nil, ErrExportJobNotFound
+	}
+	return job, nil
+}
+
+// DownloadURL returns a short-lived link to a completed account export
+func (s *AccountExportService) DownloadURL(ctx context.Context, userID, jobID models.ID) (string, error) {
+	job, err := s.GetExport(ctx, userID, jobID)
+	if err != nil {
+		return "", err
+	}
+	if !job.HasFile() {
+		return "", ErrExportNotReady
+	}
+
+	return s.storage.GetPresignedURL(ctx, job.FileKey, accountExportLinkExpiry)
+}
+
+// GenerateExport writes a queued account export into storage. On errors the
+// export stays queued for the job's retry unless this was its last attempt.
+func (s *AccountExportService) GenerateExport(ctx context.Context, payload AccountExportJob, lastAttempt bool) error {
+	job, err := s.exportJobRepo.GetByID(ctx, payload.ExportJobID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return PermanentJobError(ErrExportJobNotFound)
+		}
+		return err
+	}
+	if job.Status != models.ExportJobQueued {
+		// Generated by an earlier attempt
+		return nil
+	}
+
+	records, err := s.generateArchive(ctx, job)
+	if err != nil {
+		var permanent *permanentJobError
+		if lastAttempt || errors.As(err, &permanent) {
+			finishExportJob(ctx, s.exportJobRepo, s.logger, job, records, err)
+		}
+		return err
+	}
+
+	finishExportJob(ctx, s.exportJobRepo, s.logger, job, records, nil)
+	return nil
+}
+
+// generateArchive writes the archive to a temporary file, uploads it and
+// records its storage key. It returns how many records were exported.
+func (s *AccountExportService) generateArchive(ctx context.Context, job *models.ExportJob) (int, error) {
+	user, err := s.userRepo.GetByID(ctx, job.UserID)
+	if err != nil {
+		return 0, err
+	}
+	if user == nil {
+		return 0, PermanentJobError(ErrUserNotFound)
+	}
+
+	file, err := os.CreateTemp("", "account-export-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+	records, err := s.writeArchive(ctx, archive, user)
+	if err != nil {
+		return records, err
+	}
+	if err := archive.Close(); err != nil {
+		return records, fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return records, fmt.Errorf("failed to size export file: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return records, fmt.Errorf("failed to rewind export file: %w", err)
+	}
+
+	// The random part keeps download links unguessable where storage is public
+	token, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return records, err
+	}
+	key := fmt.Sprintf("exports/account/%s/%s-%s.zip", user.ID.String(), job.ID.String(), token)
+	if _, err := s.storage.UploadStream(ctx, key, file, "application/zip", size, nil); err != nil {
+		return records, fmt.Errorf("failed to store export: %w", err)
+	}
+
+	if err := s.exportJobRepo.SetFile(ctx, job.ID, key); err != nil {
+		return records, err
+	}
+	return records, nil
+}
+
+// writeArchive adds the user's profile, weddings and media to the archive
+func (s *AccountExportService) writeArchive(ctx context.Context, archive *zip.Writer, user *models.User) (int, error) {
+	profile, err := archive.Create("profile.json")
+	if err != nil {
+		return 0, err
+	}
+	encoder := json.NewEncoder(profile)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(user); err != nil {
+		return 0, fmt.Errorf("failed to export profile: %w", err)
+	}
+	records := 1
+
+	weddings, err := s.ownedWeddings(ctx, user.ID)
+	if err != nil {
+		return records, err
+	}
+	written, err := writeArchiveRecords(archive, "weddings", accountWeddingLayout, func(emit func(interface{}) error) error {
+		for _, wedding := range weddings {
+			if err := emit(wedding); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	records += written
+	if err != nil {
+		return records, fmt.Errorf("failed to export weddings: %w", err)
+	}
+
+	for _, wedding := range weddings {
+		written, err := s.writeWedding(ctx, archive, wedding.ID)
+		records += written
+		if err != nil {
+			return records, fmt.Errorf("failed to export wedding %s: %w", wedding.ID.String(), err)
+		}
+	}
+
+	written, err = s.writeMedia(ctx, archive, user.ID)
+	records += written
+	if err != nil {
+		return records, fmt.Errorf("failed to export media: %w", err)
+	}
+	return records, nil
+}
+
+// ownedWeddings lists the weddings the user owns; those they only collaborate
+// on belong to someone else's export
+func (s *AccountExportService) ownedWeddings(ctx context.Context, userID models.ID) ([]*models.Wedding, error) {
+	var owned []*models.Wedding
+	for page := 1; ; page++ {
+		weddings, total, err := s.weddingRepo.GetByUserID(ctx, userID, page, accountExportPageSize, repository.WeddingFilters{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list weddings: %w", err)
+		}
+		for _, wedding := range weddings {
+			if wedding.UserID == userID {
+				owned = append(owned, wedding)
+			}
+		}
+		if len(weddings) == 0 || int64(page*accountExportPageSize) >= total {
+			return owned, nil
+		}
+	}
+}
+
+// writeWedding adds the guests, RSVPs and wishes of a wedding to the archive
+func (s *AccountExportService) writeWedding(ctx context.Context, archive *zip.Writer, weddingID models.ID) (int, error) {
+	dir := path.Join("weddings", weddingID.String())
+
+	guests, err := writeArchiveRecords(archive, path.Join(dir, "guests"), accountGuestLayout, func(emit func(interface{}) error) error {
+		return s.guestRepo.StreamByWedding(ctx, weddingID, repository.GuestFilters{}, func(guest *models.Guest) error {
+			return emit(guest)
+		})
+	})
+	if err != nil {
+		return guests, err
+	}
+
+	rsvps, err := writeArchiveRecords(archive, path.Join(dir, "rsvps"), accountRSVPLayout, func(emit func(interface{}) error) error {
+		return s.rsvpRepo.StreamByWedding(ctx, weddingID, func(rsvp *models.RSVP) error {
+			return emit(rsvp)
+		})
+	})
+	if err != nil {
+		return guests + rsvps, err
+	}
+
+	wishes, err := writeArchiveRecords(archive, path.Join(dir, "wishes"), accountWishLayout, func(emit func(interface{}) error) error {
+		for page := 1; ; page++ {
+			wishes, total, err := s.wishRepo.ListByWedding(ctx, weddingID, "", page, accountExportPageSize)
+			if err != nil {
+				return err
+			}
+			for _, wish := range wishes {
+				if err := emit(wish); err != nil {
+					return err
+				}
+			}
+			if len(wishes) == 0 || int64(page*accountExportPageSize) >= total {
+				return nil
+			}
+		}
+	})
+	return guests + rsvps + wishes, err
+}
+
+// writeMedia adds the user's uploaded files and their metadata to the archive.
+// Files are only included where storage can read them back; deleted media are
+// left out.
+func (s *AccountExportService) writeMedia(ctx context.Context, archive *zip.Writer, userID models.ID) (int, error) {
+	all, err := s.mediaRepo.ListAllByCreatedBy(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	reader, canRead := s.storage.(ObjectReader)
+	var media []*accountExportMedia
+	for _, m := range all {
+		if m.DeletedAt != nil {
+			continue
+		}
+		exported := &accountExportMedia{Media: m}
+		media = append(media, exported)
+		if !canRead || m.StorageKey == "" {
+			continue
+		}
+
+		data, err := reader.Download(ctx, m.StorageKey)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read media %s: %w", m.ID.String(), err)
+		}
+		exported.File = path.Join("media", m.ID.String()+"-"+path.Base(strings.ReplaceAll(m.Filename, "\\", "/")))
+		out, err := archive.Create(exported.File)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := out.Write(data); err != nil {
+			return 0, err
+		}
+	}
+
+	return writeArchiveRecords(archive, "media", accountMediaLayout, func(emit func(interface{}) error) error {
+		for _, m := range media {
+			if err := emit(m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// writeArchiveRecords adds the records produced by produce to the archive as
+// name.json and name.csv. produce is called once per file, as a zip archive is
+// written one file at a time; the returned count is of the JSON file.
+func writeArchiveRecords(archive *zip.Writer, name string, layout accountExportLayout, produce func(emit func(interface{}) error) error) (int, error) {
+	records := 0
+	for _, format := range []string{AnalyticsExportJSON, AnalyticsExportCSV} {
+		out, err := archive.Create(name + "." + format)
+		if err != nil {
+			return records, err
+		}
+		writer := utils.NewJSONRecordWriter(out)
+		if format == AnalyticsExportCSV {
+			writer = utils.NewCSVRecordWriter(out, layout.header, layout.row)
+		}
+
+		written := 0
+		err = produce(func(record interface{}) error {
+			if err := writer.WriteRecord(record); err != nil {
+				return err
+			}
+			written++
+			return nil
+		})
+		if err != nil {
+			return records, err
+		}
+		if err := writer.Close(); err != nil {
+			return records, err
+		}
+		if format == AnalyticsExportJSON {
+			records = written
+		}
+	}
+	return records, nil
+}
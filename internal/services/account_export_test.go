@@ -0,0 +1,201 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// readableStorage is a mock storage that can read files back
+type readableStorage struct {
+	*MockStorageService
+	files map[string][]byte
+}
+
+func (s *readableStorage) Download(ctx context.Context, key string) ([]byte, error) {
+	data, ok := s.files[key]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return data, nil
+}
+
+type accountExportFixture struct {
+	service  *AccountExportService
+	queue    *JobQueue
+	jobs     *MockExportJobRepository
+	storage  *readableStorage
+	user     *models.User
+	wedding  *models.Wedding
+	uploaded []byte
+	key      string
+}
+
+func setupAccountExportService(t *testing.T) *accountExportFixture {
+	logger := zaptest.NewLogger(t)
+	f := &accountExportFixture{
+		jobs:    NewMockExportJobRepository(),
+		storage: &readableStorage{MockStorageService: &MockStorageService{}, files: map[string][]byte{"uploads/photo.jpg": []byte("jpeg bytes")}},
+		user:    &models.User{ID: models.NewID(), Email: "ana@example.com", FirstName: "Ana"},
+	}
+	f.wedding = &models.Wedding{ID: models.NewID(), UserID: f.user.ID, Title: "Ana & Ben", Slug: "ana-ben", Status: "published"}
+	collaborated := &models.Wedding{ID: models.NewID(), UserID: models.NewID(), Title: "Someone else's"}
+
+	users := &MockUserRepository{}
+	users.On("GetByID", mock.Anything, f.user.ID).Return(f.user, nil)
+	users.On("GetByID", mock.Anything, mock.Anything).Return(nil, nil)
+	weddings := &MockWeddingRepository{}
+	weddings.On("GetByUserID", mock.Anything, f.user.ID, 1, accountExportPageSize, repository.WeddingFilters{}).
+		Return([]*models.Wedding{f.wedding, collaborated}, int64(2), nil)
+
+	guests := NewMockGuestRepository()
+	guest := &models.Guest{ID: models.NewID(), WeddingID: f.wedding.ID, FirstName: "Cara", LastName: "Diaz", Email: "cara@example.com"}
+	guests.guests[guest.ID] = guest
+	rsvps := NewMockRSVPRepository()
+	rsvp := &models.RSVP{ID: models.NewID(), WeddingID: f.wedding.ID, FirstName: "Cara", LastName: "Diaz", Status: "attending", AttendanceCount: 2}
+	rsvps.rsvps[rsvp.ID] = rsvp
+	otherRSVP := &models.RSVP{ID: models.NewID(), WeddingID: collaborated.ID, FirstName: "Eve"}
+	rsvps.rsvps[otherRSVP.ID] = otherRSVP
+	wishes := NewMockWishRepository()
+	wish := &models.Wish{ID: models.NewID(), WeddingID: f.wedding.ID, GuestName: "Cara", Message: "Congratulations!", Status: models.WishApproved}
+	wishes.wishes[wish.ID] = wish
+
+	deletedAt := time.Now()
+	media := &MockMediaRepository{}
+	media.On("ListAllByCreatedBy", mock.Anything, f.user.ID).Return([]*models.Media{
+		{ID: models.NewID(), Filename: "photo.jpg", MimeType: "image/jpeg", Size: 10, StorageKey: "uploads/photo.jpg", CreatedBy: f.user.ID},
+		{ID: models.NewID(), Filename: "removed.jpg", MimeType: "image/jpeg", StorageKey: "uploads/removed.jpg", CreatedBy: f.user.ID, DeletedAt: &deletedAt},
+	}, nil)
+
+	f.storage.On("UploadStream", mock.Anything, mock.Anything, mock.Anything, "application/zip", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			f.key = args.String(1)
+			data, err := io.ReadAll(args.Get(2).(io.Reader))
+			require.NoError(t, err)
+			f.uploaded = data
+			assert.Equal(t, int64(len(data)), args.Get(4))
+		}).
+		Return("https://cdn.example.com/export.zip", nil)
+
+	f.queue = NewJobQueue(NewMockJobRepository(), JobQueueOptions{}, logger)
+	f.service = NewAccountExportService(users, weddings, guests, rsvps, wishes, media, f.jobs, f.storage, f.queue, logger)
+	RegisterJobHandlers(f.queue, nil, nil, nil, f.service, nil, nil, nil, nil, nil)
+	return f
+}
+
+// readArchive returns the files of a zip archive by name
+func readArchive(t *testing.T, data []byte) map[string]string {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	files := make(map[string]string)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[file.Name] = string(content)
+	}
+	return files
+}
+
+func TestAccountExportService_QueueExport(t *testing.T) {
+	ctx := context.Background()
+	f := setupAccountExportService(t)
+
+	job, err := f.service.QueueExport(ctx, f.user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExportJobQueued, job.Status)
+	assert.Equal(t, AccountExportResource, job.Resource)
+
+	_, err = f.service.DownloadURL(ctx, f.user.ID, job.ID)
+	assert.ErrorIs(t, err, ErrExportNotReady)
+
+	claimed, err := f.queue.ProcessNext(ctx)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	stored, err := f.service.GetExport(ctx, f.user.ID, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExportJobCompleted, stored.Status)
+	assert.Equal(t, 6, stored.Records, "profile, wedding, guest, RSVP, wish and photo")
+	assert.True(t, strings.HasPrefix(f.key, "exports/account/"+f.user.ID.String()+"/"+job.ID.String()+"-"))
+
+	files := readArchive(t, f.uploaded)
+	dir := "weddings/" + f.wedding.ID.String() + "/"
+	for _, name := range []string{
+		"profile.json", "weddings.json", "weddings.csv", "media.json", "media.csv",
+		dir + "guests.json", dir + "guests.csv", dir + "rsvps.json", dir + "rsvps.csv", dir + "wishes.json", dir + "wishes.csv",
+	} {
+		assert.Contains(t, files, name)
+	}
+
+	var profile models.User
+	require.NoError(t, json.Unmarshal([]byte(files["profile.json"]), &profile))
+	assert.Equal(t, "ana@example.com", profile.Email)
+	assert.NotContains(t, files["weddings.csv"], "Someone else's", "only owned weddings are exported")
+	assert.NotContains(t, files[dir+"rsvps.json"], "Eve")
+	assert.Contains(t, files[dir+"guests.csv"], "cara@example.com")
+	assert.Contains(t, files[dir+"wishes.csv"], "Congratulations!")
+
+	var media struct {
+		Data []struct {
+			Filename string `json:"filename"`
+			File     string `json:"file"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(files["media.json"]), &media))
+	require.Len(t, media.Data, 1, "deleted media are left out")
+	assert.Equal(t, "jpeg bytes", files[media.Data[0].File])
+
+	f.storage.On("GetPresignedURL", mock.Anything, f.key, accountExportLinkExpiry).Return("https://cdn.example.com/signed", nil)
+	url, err := f.service.DownloadURL(ctx, f.user.ID, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/signed", url)
+
+	_, err = f.service.DownloadURL(ctx, models.NewID(), job.ID)
+	assert.ErrorIs(t, err, ErrExportJobNotFound)
+}
+
+func TestAccountExportService_GetExportOnlyServesAccountExports(t *testing.T) {
+	ctx := context.Background()
+	f := setupAccountExportService(t)
+	analytics := &models.ExportJob{WeddingID: f.wedding.ID, UserID: f.user.ID, Resource: "analytics_rsvp", Status: models.ExportJobQueued}
+	require.NoError(t, f.jobs.Create(ctx, analytics))
+
+	_, err := f.service.GetExport(ctx, f.user.ID, analytics.ID)
+	assert.ErrorIs(t, err, ErrExportJobNotFound)
+	_, err = f.service.GetExport(ctx, f.user.ID, models.NewID())
+	assert.ErrorIs(t, err, ErrExportJobNotFound)
+}
+
+func TestAccountExportService_FailsOnLastAttempt(t *testing.T) {
+	ctx := context.Background()
+	f := setupAccountExportService(t)
+	delete(f.storage.files, "uploads/photo.jpg")
+
+	job, err := f.service.QueueExport(ctx, f.user.ID)
+	require.NoError(t, err)
+	payload := AccountExportJob{ExportJobID: job.ID, UserID: f.user.ID}
+
+	// Earlier attempts leave the export queued for the retry
+	assert.Error(t, f.service.GenerateExport(ctx, payload, false))
+	assert.Equal(t, models.ExportJobQueued, f.jobs.jobs[job.ID].Status)
+
+	assert.Error(t, f.service.GenerateExport(ctx, payload, true))
+	assert.Equal(t, models.ExportJobFailed, f.jobs.jobs[job.ID].Status)
+	f.storage.AssertNotCalled(t, "UploadStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -2,47 +2,88 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
 	"net"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
 
-	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.uber.org/zap"
-
+	"wedding-invitation-backend/internal/domain/errs"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/tracing"
+	"wedding-invitation-backend/internal/utils"
+)
+
+const (
+	// maxDailyMetricsDays bounds the range of one daily metrics request
+	maxDailyMetricsDays = 366
+	// maxPageDuration caps the time counted for one page view, so tabs left open
+	// do not skew the average time on page
+	maxPageDuration = 30 * 60
+)
+
+// attributionPattern matches characters not allowed in attribution values
+var attributionPattern = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+var (
+	ErrInvalidDateRange    = errors.New("date range must end after it starts and span at most 366 days")
+	ErrInvalidPageDuration = errors.New("page duration must be positive")
+	ErrPageViewNotFound    = errs.NotFound("page view not found")
+	// ErrTrackingUnpublished is returned for events of a wedding that is not published
+	ErrTrackingUnpublished = errs.Validation("cannot track analytics for unpublished wedding")
+	// ErrTrackingSkipped is returned for events the wedding's analytics settings
+	// leave unrecorded: tracking is off, the session is outside the sample, or
+	// the visitor asked not to be tracked
+	ErrTrackingSkipped = errors.New("event not recorded under the wedding's analytics settings")
 )
 
 // AnalyticsService represents the analytics service interface
 type AnalyticsService interface {
 	// Page View Tracking
-	TrackPageView(ctx context.Context, weddingID primitive.ObjectID, sessionID, page string, req *http.Request) error
-	GetPageViews(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error)
+	TrackPageView(ctx context.Context, weddingID models.ID, sessionID, page string, req *http.Request) error
+	TrackPageDuration(ctx context.Context, weddingID models.ID, sessionID, page string, duration int64, req *http.Request) error
+	GetPageViews(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error)
 
 	// RSVP Analytics
-	TrackRSVPSubmission(ctx context.Context, weddingID, rsvpID primitive.ObjectID, sessionID, source string, timeToComplete int64, req *http.Request) error
-	TrackRSVPAbandonment(ctx context.Context, weddingID primitive.ObjectID, sessionID, abandonedStep string, formErrors []string, req *http.Request) error
-	GetRSVPAnalytics(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error)
+	TrackRSVPSubmission(ctx context.Context, weddingID, rsvpID models.ID, sessionID, source string, timeToComplete int64, req *http.Request) error
+	TrackRSVPAbandonment(ctx context.Context, weddingID models.ID, sessionID, abandonedStep string, formErrors []string, req *http.Request) error
+	GetRSVPAnalytics(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error)
 
 	// Conversion Tracking
-	TrackConversion(ctx context.Context, weddingID primitive.ObjectID, sessionID, event string, value float64, properties map[string]interface{}) error
-	GetConversions(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error)
+	TrackConversion(ctx context.Context, weddingID models.ID, sessionID, event string, value float64, properties map[string]interface{}) error
+
+	// Batch Tracking
+	TrackBatch(ctx context.Context, events []TrackBatchEvent, req *http.Request) []error
+	GetConversions(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error)
+
+	// Live Stream
+	SubscribeLive(weddingID models.ID) (<-chan *models.AnalyticsLiveEvent, func())
+
+	// Request Tracing
+	GetRequestTrace(ctx context.Context, requestID string) (*models.RequestTrace, error)
 
 	// Analytics Data
-	GetWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) (*models.WeddingAnalytics, error)
+	GetWeddingAnalytics(ctx context.Context, weddingID models.ID) (*models.WeddingAnalytics, error)
 	GetSystemAnalytics(ctx context.Context) (*models.SystemAnalytics, error)
-	GetAnalyticsSummary(ctx context.Context, weddingID primitive.ObjectID, period string) (*models.AnalyticsSummary, error)
+	// GetUserOverview aggregates the user's weddings and compares them with the platform
+	GetUserOverview(ctx context.Context, userID models.ID) (*models.UserAnalyticsOverview, error)
+	GetAnalyticsSummary(ctx context.Context, weddingID models.ID, period string) (*models.AnalyticsSummary, error)
 
 	// Reports
-	GetPopularPages(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.PageStats, error)
-	GetTrafficSources(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.TrafficSourceStats, error)
-	GetDailyMetrics(ctx context.Context, weddingID primitive.ObjectID, startDate, endDate time.Time) ([]models.DailyMetrics, error)
+	GetPopularPages(ctx context.Context, weddingID models.ID, limit int) ([]models.PageStats, error)
+	GetTrafficSources(ctx context.Context, weddingID models.ID, limit int) ([]models.TrafficSourceStats, error)
+	GetGeoBreakdown(ctx context.Context, weddingID models.ID, limit int) ([]models.CountryStats, error)
+	GetDailyMetrics(ctx context.Context, weddingID models.ID, startDate, endDate time.Time) ([]models.DailyMetrics, error)
+	GetRSVPFunnel(ctx context.Context, weddingID models.ID, startDate, endDate time.Time) (*models.RSVPFunnel, error)
 
 	// Management
-	RefreshWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) error
+	RefreshWeddingAnalytics(ctx context.Context, weddingID models.ID) error
 	RefreshSystemAnalytics(ctx context.Context) error
 	CleanupOldAnalytics(ctx context.Context, olderThan time.Time) error
 
@@ -56,30 +97,70 @@ type AnalyticsService interface {
 type analyticsService struct {
 	analyticsRepo repository.AnalyticsRepository
 	weddingRepo   repository.WeddingRepository
+	broker        *AnalyticsBroker
+	geo           GeoIPProvider
 	logger        *zap.Logger
 }
 
-// NewAnalyticsService creates a new analytics service
+// NewAnalyticsService creates a new analytics service without geolocation
 func NewAnalyticsService(analyticsRepo repository.AnalyticsRepository, weddingRepo repository.WeddingRepository, logger *zap.Logger) AnalyticsService {
+	return NewAnalyticsServiceWithGeoIP(analyticsRepo, weddingRepo, nil, logger)
+}
+
+// NewAnalyticsServiceWithGeoIP creates an analytics service that resolves the
+// country and city of page views with the given provider. A nil provider
+// leaves them empty.
+func NewAnalyticsServiceWithGeoIP(analyticsRepo repository.AnalyticsRepository, weddingRepo repository.WeddingRepository, geo GeoIPProvider, logger *zap.Logger) AnalyticsService {
 	return &analyticsService{
 		analyticsRepo: analyticsRepo,
 		weddingRepo:   weddingRepo,
+		broker:        NewAnalyticsBroker(),
+		geo:           geo,
 		logger:        logger,
 	}
 }
 
 // TrackPageView tracks a page view event
-func (s *analyticsService) TrackPageView(ctx context.Context, weddingID primitive.ObjectID, sessionID, page string, req *http.Request) error {
+func (s *analyticsService) TrackPageView(ctx context.Context, weddingID models.ID, sessionID, page string, req *http.Request) error {
 	// Validate that wedding exists and is published
 	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
 	if err != nil {
-		return fmt.Errorf("wedding not found: %w", err)
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWeddingNotFound
+		}
+		return fmt.Errorf("failed to get wedding: %w", err)
 	}
 
 	if wedding.Status != string(models.WeddingStatusPublished) {
-		return fmt.Errorf("cannot track analytics for unpublished wedding")
+		return ErrTrackingUnpublished
+	}
+	if skipTracking(wedding, sessionID, req, true) {
+		return ErrTrackingSkipped
 	}
 
+	pageView := s.newPageView(ctx, weddingID, sessionID, page, req)
+	err = s.analyticsRepo.TrackPageView(ctx, pageView)
+	if err != nil {
+		s.logger.Error("Failed to track page view",
+			zap.Error(err),
+			zap.String("wedding_id", weddingID.String()),
+			zap.String("page", page))
+		return fmt.Errorf("failed to track page view: %w", err)
+	}
+
+	s.publishPageView(pageView)
+
+	s.logger.Debug("Tracked page view",
+		zap.String("wedding_id", weddingID.String()),
+		zap.String("session_id", sessionID),
+		zap.String("page", page))
+
+	return nil
+}
+
+// newPageView describes a view of a page by the client of the request, which
+// may be nil
+func (s *analyticsService) newPageView(ctx context.Context, weddingID models.ID, sessionID, page string, req *http.Request) *models.PageView {
 	// Extract user agent and IP address
 	userAgent := ""
 	if req != nil {
@@ -103,11 +184,18 @@ func (s *analyticsService) TrackPageView(ctx context.Context, weddingID primitiv
 		}
 	}
 
-	// Geolocation (placeholder - would integrate with IP geolocation service in production)
+	// Geolocation
 	country, city := "", ""
 	if ipAddress != "" {
-		country, city = s.getGeoLocation(ipAddress)
+		country, city = s.getGeoLocation(ctx, ipAddress)
+	}
+
+	// Attribute the visit to UTM parameters, falling back to the referrer
+	var query url.Values
+	if req != nil {
+		query = req.URL.Query()
 	}
+	source, medium, campaign := s.attributeTraffic(referrer, query)
 
 	pageView := &models.PageView{
 		WeddingID: weddingID,
@@ -115,6 +203,9 @@ func (s *analyticsService) TrackPageView(ctx context.Context, weddingID primitiv
 		IPAddress: ipAddress,
 		UserAgent: userAgent,
 		Referrer:  referrer,
+		Source:    source,
+		Medium:    medium,
+		Campaign:  campaign,
 		Page:      page,
 		Timestamp: time.Now(),
 		Device:    device,
@@ -122,37 +213,94 @@ func (s *analyticsService) TrackPageView(ctx context.Context, weddingID primitiv
 		OS:        os,
 		Country:   country,
 		City:      city,
+		IsBot:     isBot(req, userAgent, ipAddress),
 		Metadata:  make(map[string]interface{}),
 	}
 
-	err = s.analyticsRepo.TrackPageView(ctx, pageView)
+	// Correlate the event with the API request that ingested it
+	if requestID := requestIDFrom(ctx, req); requestID != "" {
+		pageView.Metadata["request_id"] = requestID
+	}
+
+	return pageView
+}
+
+// publishPageView streams a tracked page view to the wedding's live
+// subscribers, unless it is a bot's
+func (s *analyticsService) publishPageView(pageView *models.PageView) {
+	if pageView.IsBot {
+		return
+	}
+	s.broker.Publish(&models.AnalyticsLiveEvent{
+		Type:      models.AnalyticsLivePageView,
+		WeddingID: pageView.WeddingID,
+		SessionID: pageView.SessionID,
+		Page:      pageView.Page,
+		Device:    pageView.Device,
+		Source:    pageView.Source,
+		Timestamp: pageView.Timestamp,
+	})
+}
+
+// TrackPageDuration records the time spent so far on the session's latest view
+// of a page, in seconds. Clients send it as a heartbeat while the page is open
+// and when it is left; only the longest duration reported counts.
+func (s *analyticsService) TrackPageDuration(ctx context.Context, weddingID models.ID, sessionID, page string, duration int64, req *http.Request) error {
+	if duration <= 0 {
+		return ErrInvalidPageDuration
+	}
+	if duration > maxPageDuration {
+		duration = maxPageDuration
+	}
+
+	// Validate that wedding exists and is published
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
 	if err != nil {
-		s.logger.Error("Failed to track page view",
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWeddingNotFound
+		}
+		return fmt.Errorf("failed to get wedding: %w", err)
+	}
+
+	if wedding.Status != string(models.WeddingStatusPublished) {
+		return ErrTrackingUnpublished
+	}
+	if skipTracking(wedding, sessionID, req, true) {
+		return ErrTrackingSkipped
+	}
+
+	err = s.analyticsRepo.TrackPageDuration(ctx, weddingID, sessionID, page, duration)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrPageViewNotFound
+		}
+		s.logger.Error("Failed to track page duration",
 			zap.Error(err),
-			zap.String("wedding_id", weddingID.Hex()),
+			zap.String("wedding_id", weddingID.String()),
 			zap.String("page", page))
-		return fmt.Errorf("failed to track page view: %w", err)
+		return fmt.Errorf("failed to track page duration: %w", err)
 	}
 
-	s.logger.Debug("Tracked page view",
-		zap.String("wedding_id", weddingID.Hex()),
-		zap.String("session_id", sessionID),
-		zap.String("page", page))
-
 	return nil
 }
 
 // GetPageViews retrieves page views with filtering
-func (s *analyticsService) GetPageViews(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error) {
+func (s *analyticsService) GetPageViews(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error) {
 	return s.analyticsRepo.GetPageViews(ctx, weddingID, filter)
 }
 
 // TrackRSVPSubmission tracks an RSVP submission event
-func (s *analyticsService) TrackRSVPSubmission(ctx context.Context, weddingID, rsvpID primitive.ObjectID, sessionID, source string, timeToComplete int64, req *http.Request) error {
+func (s *analyticsService) TrackRSVPSubmission(ctx context.Context, weddingID, rsvpID models.ID, sessionID, source string, timeToComplete int64, req *http.Request) error {
 	// Validate that wedding exists
-	_, err := s.weddingRepo.GetByID(ctx, weddingID)
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
 	if err != nil {
-		return fmt.Errorf("wedding not found: %w", err)
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWeddingNotFound
+		}
+		return fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if skipTracking(wedding, sessionID, req, false) {
+		return ErrTrackingSkipped
 	}
 
 	// Extract user agent and device info
@@ -189,11 +337,20 @@ func (s *analyticsService) TrackRSVPSubmission(ctx context.Context, weddingID, r
 	if err != nil {
 		s.logger.Error("Failed to track RSVP submission",
 			zap.Error(err),
-			zap.String("wedding_id", weddingID.Hex()),
-			zap.String("rsvp_id", rsvpID.Hex()))
+			zap.String("wedding_id", weddingID.String()),
+			zap.String("rsvp_id", rsvpID.String()))
 		return fmt.Errorf("failed to track RSVP submission: %w", err)
 	}
 
+	s.broker.Publish(&models.AnalyticsLiveEvent{
+		Type:      models.AnalyticsLiveRSVPSubmitted,
+		WeddingID: weddingID,
+		SessionID: sessionID,
+		Device:    device,
+		Source:    source,
+		Timestamp: event.Timestamp,
+	})
+
 	// Track conversion
 	err = s.TrackConversion(ctx, weddingID, sessionID, "rsvp_completed", 1, map[string]interface{}{
 		"source":           source,
@@ -204,19 +361,25 @@ func (s *analyticsService) TrackRSVPSubmission(ctx context.Context, weddingID, r
 	}
 
 	s.logger.Debug("Tracked RSVP submission",
-		zap.String("wedding_id", weddingID.Hex()),
-		zap.String("rsvp_id", rsvpID.Hex()),
+		zap.String("wedding_id", weddingID.String()),
+		zap.String("rsvp_id", rsvpID.String()),
 		zap.String("source", source))
 
 	return nil
 }
 
 // TrackRSVPAbandonment tracks an RSVP abandonment event
-func (s *analyticsService) TrackRSVPAbandonment(ctx context.Context, weddingID primitive.ObjectID, sessionID, abandonedStep string, formErrors []string, req *http.Request) error {
+func (s *analyticsService) TrackRSVPAbandonment(ctx context.Context, weddingID models.ID, sessionID, abandonedStep string, formErrors []string, req *http.Request) error {
 	// Validate that wedding exists
-	_, err := s.weddingRepo.GetByID(ctx, weddingID)
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
 	if err != nil {
-		return fmt.Errorf("wedding not found: %w", err)
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWeddingNotFound
+		}
+		return fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if skipTracking(wedding, sessionID, req, false) {
+		return ErrTrackingSkipped
 	}
 
 	// Extract device info
@@ -248,11 +411,20 @@ func (s *analyticsService) TrackRSVPAbandonment(ctx context.Context, weddingID p
 	if err != nil {
 		s.logger.Error("Failed to track RSVP abandonment",
 			zap.Error(err),
-			zap.String("wedding_id", weddingID.Hex()),
+			zap.String("wedding_id", weddingID.String()),
 			zap.String("abandoned_step", abandonedStep))
 		return fmt.Errorf("failed to track RSVP abandonment: %w", err)
 	}
 
+	s.broker.Publish(&models.AnalyticsLiveEvent{
+		Type:      models.AnalyticsLiveRSVPAbandoned,
+		WeddingID: weddingID,
+		SessionID: sessionID,
+		Device:    device,
+		Event:     abandonedStep,
+		Timestamp: event.Timestamp,
+	})
+
 	// Track conversion funnel
 	err = s.TrackConversion(ctx, weddingID, sessionID, "rsvp_abandoned", 0, map[string]interface{}{
 		"step":        abandonedStep,
@@ -263,23 +435,36 @@ func (s *analyticsService) TrackRSVPAbandonment(ctx context.Context, weddingID p
 	}
 
 	s.logger.Debug("Tracked RSVP abandonment",
-		zap.String("wedding_id", weddingID.Hex()),
+		zap.String("wedding_id", weddingID.String()),
 		zap.String("abandoned_step", abandonedStep))
 
 	return nil
 }
 
 // GetRSVPAnalytics retrieves RSVP analytics with filtering
-func (s *analyticsService) GetRSVPAnalytics(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error) {
+func (s *analyticsService) GetRSVPAnalytics(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error) {
 	return s.analyticsRepo.GetRSVPAnalytics(ctx, weddingID, filter)
 }
 
 // TrackConversion tracks a conversion event
-func (s *analyticsService) TrackConversion(ctx context.Context, weddingID primitive.ObjectID, sessionID, event string, value float64, properties map[string]interface{}) error {
+func (s *analyticsService) TrackConversion(ctx context.Context, weddingID models.ID, sessionID, event string, value float64, properties map[string]interface{}) error {
 	// Validate that wedding exists
-	_, err := s.weddingRepo.GetByID(ctx, weddingID)
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
 	if err != nil {
-		return fmt.Errorf("wedding not found: %w", err)
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWeddingNotFound
+		}
+		return fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if skipTracking(wedding, sessionID, nil, false) {
+		return ErrTrackingSkipped
+	}
+
+	if requestID := utils.RequestIDFromContext(ctx); requestID != "" {
+		if properties == nil {
+			properties = make(map[string]interface{})
+		}
+		properties["request_id"] = requestID
 	}
 
 	conversionEvent := &models.ConversionEvent{
@@ -295,21 +480,64 @@ func (s *analyticsService) TrackConversion(ctx context.Context, weddingID primit
 	if err != nil {
 		s.logger.Error("Failed to track conversion",
 			zap.Error(err),
-			zap.String("wedding_id", weddingID.Hex()),
+			zap.String("wedding_id", weddingID.String()),
 			zap.String("event", event))
 		return fmt.Errorf("failed to track conversion: %w", err)
 	}
 
+	s.publishConversion(conversionEvent)
+
 	return nil
 }
 
+// publishConversion streams a tracked conversion to the wedding's live subscribers
+func (s *analyticsService) publishConversion(event *models.ConversionEvent) {
+	s.broker.Publish(&models.AnalyticsLiveEvent{
+		Type:      models.AnalyticsLiveConversion,
+		WeddingID: event.WeddingID,
+		SessionID: event.SessionID,
+		Event:     event.Event,
+		Value:     event.Value,
+		Timestamp: event.Timestamp,
+	})
+}
+
+// SubscribeLive streams the wedding's events as they are tracked until the
+// returned cancel function is called
+func (s *analyticsService) SubscribeLive(weddingID models.ID) (<-chan *models.AnalyticsLiveEvent, func()) {
+	return s.broker.Subscribe(weddingID)
+}
+
 // GetConversions retrieves conversion events with filtering
-func (s *analyticsService) GetConversions(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error) {
+func (s *analyticsService) GetConversions(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error) {
 	return s.analyticsRepo.GetConversions(ctx, weddingID, filter)
 }
 
+// GetRequestTrace finds analytics events ingested by the given API request
+func (s *analyticsService) GetRequestTrace(ctx context.Context, requestID string) (*models.RequestTrace, error) {
+	pageViews, err := s.analyticsRepo.GetPageViewsByRequestID(ctx, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page views: %w", err)
+	}
+
+	conversions, err := s.analyticsRepo.GetConversionsByRequestID(ctx, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversions: %w", err)
+	}
+
+	return &models.RequestTrace{
+		RequestID:   requestID,
+		PageViews:   pageViews,
+		Conversions: conversions,
+		Logs:        []models.RequestLogEntry{},
+	}, nil
+}
+
 // GetWeddingAnalytics retrieves aggregated analytics for a wedding
-func (s *analyticsService) GetWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) (*models.WeddingAnalytics, error) {
+func (s *analyticsService) GetWeddingAnalytics(ctx context.Context, weddingID models.ID) (_ *models.WeddingAnalytics, err error) {
+	ctx, span := tracing.Start(ctx, "analytics.wedding", attribute.String("wedding.id", weddingID.String()))
+	defer func() { tracing.End(span, err) }()
+
 	// Verify wedding ownership would be handled at the handler level
 	return s.analyticsRepo.GetWeddingAnalytics(ctx, weddingID)
 }
@@ -320,46 +548,91 @@ func (s *analyticsService) GetSystemAnalytics(ctx context.Context) (*models.Syst
 }
 
 // GetAnalyticsSummary generates a summary report for a wedding
-func (s *analyticsService) GetAnalyticsSummary(ctx context.Context, weddingID primitive.ObjectID, period string) (*models.AnalyticsSummary, error) {
+func (s *analyticsService) GetAnalyticsSummary(ctx context.Context, weddingID models.ID, period string) (_ *models.AnalyticsSummary, err error) {
+	ctx, span := tracing.Start(ctx, "analytics.summary",
+		attribute.String("wedding.id", weddingID.String()),
+		attribute.String("analytics.period", period))
+	defer func() { tracing.End(span, err) }()
+
 	// Verify wedding ownership would be handled at the handler level
 	return s.analyticsRepo.GetAnalyticsSummary(ctx, weddingID, period)
 }
 
 // GetPopularPages returns the most popular pages for a wedding
-func (s *analyticsService) GetPopularPages(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.PageStats, error) {
+func (s *analyticsService) GetPopularPages(ctx context.Context, weddingID models.ID, limit int) ([]models.PageStats, error) {
 	return s.analyticsRepo.GetPopularPages(ctx, weddingID, limit)
 }
 
 // GetTrafficSources returns traffic sources for a wedding
-func (s *analyticsService) GetTrafficSources(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.TrafficSourceStats, error) {
+func (s *analyticsService) GetTrafficSources(ctx context.Context, weddingID models.ID, limit int) ([]models.TrafficSourceStats, error) {
 	return s.analyticsRepo.GetTrafficSources(ctx, weddingID, limit)
 }
 
-// GetDailyMetrics returns daily metrics for a date range
-func (s *analyticsService) GetDailyMetrics(ctx context.Context, weddingID primitive.ObjectID, startDate, endDate time.Time) ([]models.DailyMetrics, error) {
-	return s.analyticsRepo.GetDailyMetrics(ctx, weddingID, startDate, endDate)
+// GetGeoBreakdown returns the countries and cities of a wedding's visitors
+func (s *analyticsService) GetGeoBreakdown(ctx context.Context, weddingID models.ID, limit int) ([]models.CountryStats, error) {
+	return s.analyticsRepo.GetGeoBreakdown(ctx, weddingID, limit)
 }
 
-// RefreshWeddingAnalytics forces a refresh of wedding analytics
-func (s *analyticsService) RefreshWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) error {
-	err := s.analyticsRepo.UpdateWeddingAnalytics(ctx, weddingID)
+// GetDailyMetrics returns one entry per UTC day of the range (inclusive), with
+// zeros for days without activity so charts get a continuous series
+func (s *analyticsService) GetDailyMetrics(ctx context.Context, weddingID models.ID, startDate, endDate time.Time) (_ []models.DailyMetrics, err error) {
+	start := startDate.UTC().Truncate(24 * time.Hour)
+	end := endDate.UTC().Truncate(24 * time.Hour)
+	ctx, span := tracing.Start(ctx, "analytics.daily",
+		attribute.String("wedding.id", weddingID.String()),
+		attribute.String("analytics.start", start.Format("2006-01-02")),
+		attribute.String("analytics.end", end.Format("2006-01-02")))
+	defer func() { tracing.End(span, err) }()
+	if end.Before(start) || end.Sub(start) >= maxDailyMetricsDays*24*time.Hour {
+		return nil, ErrInvalidDateRange
+	}
+
+	metrics, err := s.analyticsRepo.GetDailyMetrics(ctx, weddingID, start, end)
 	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string]models.DailyMetrics, len(metrics))
+	for _, metric := range metrics {
+		byDate[metric.Date] = metric
+	}
+
+	series := make([]models.DailyMetrics, 0, int(end.Sub(start).Hours()/24)+1)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		metric, ok := byDate[date]
+		if !ok {
+			metric = models.DailyMetrics{Date: date}
+		}
+		series = append(series, metric)
+	}
+	return series, nil
+}
+
+// RefreshWeddingAnalytics forces a refresh of wedding analytics
+func (s *analyticsService) RefreshWeddingAnalytics(ctx context.Context, weddingID models.ID) (err error) {
+	ctx, span := tracing.Start(ctx, "analytics.refresh_wedding", attribute.String("wedding.id", weddingID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.analyticsRepo.UpdateWeddingAnalytics(ctx, weddingID); err != nil {
 		s.logger.Error("Failed to refresh wedding analytics",
 			zap.Error(err),
-			zap.String("wedding_id", weddingID.Hex()))
+			zap.String("wedding_id", weddingID.String()))
 		return fmt.Errorf("failed to refresh wedding analytics: %w", err)
 	}
 
 	s.logger.Info("Wedding analytics refreshed",
-		zap.String("wedding_id", weddingID.Hex()))
+		zap.String("wedding_id", weddingID.String()))
 
 	return nil
 }
 
 // RefreshSystemAnalytics forces a refresh of system analytics
-func (s *analyticsService) RefreshSystemAnalytics(ctx context.Context) error {
-	err := s.analyticsRepo.UpdateSystemAnalytics(ctx)
-	if err != nil {
+func (s *analyticsService) RefreshSystemAnalytics(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "analytics.refresh_system")
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.analyticsRepo.UpdateSystemAnalytics(ctx); err != nil {
 		s.logger.Error("Failed to refresh system analytics", zap.Error(err))
 		return fmt.Errorf("failed to refresh system analytics: %w", err)
 	}
@@ -416,8 +689,47 @@ func (s *analyticsService) getClientIP(req *http.Request) string {
 	return ip
 }
 
+// skipTracking reports whether the wedding's analytics settings leave an event
+// of the session unrecorded. sampled applies the page view sample; req, which
+// may be nil, tells whether the visitor asked not to be tracked.
+func skipTracking(wedding *models.Wedding, sessionID string, req *http.Request, sampled bool) bool {
+	settings := wedding.Analytics
+	if settings.Disabled {
+		return true
+	}
+	if settings.RespectDoNotTrack && doNotTrack(req) {
+		return true
+	}
+	return sampled && !settings.Samples(wedding.ID, sessionID)
+}
+
+// doNotTrack reports whether the client of a request sends Do Not Track or
+// Global Privacy Control
+func doNotTrack(req *http.Request) bool {
+	if req == nil {
+		return false
+	}
+	return req.Header.Get("DNT") == "1" || req.Header.Get("Sec-GPC") == "1"
+}
+
+// requestIDFrom reads the request ID from ctx, falling back to the HTTP request's context
+func requestIDFrom(ctx context.Context, req *http.Request) string {
+	if requestID := utils.RequestIDFromContext(ctx); requestID != "" {
+		return requestID
+	}
+	if req != nil {
+		return utils.RequestIDFromContext(req.Context())
+	}
+	return ""
+}
+
 // parseUserAgent extracts device, browser, and OS from user agent string
 func (s *analyticsService) parseUserAgent(userAgent string) (device, browser, os string) {
+	return parseUserAgent(userAgent)
+}
+
+// parseUserAgent extracts device, browser, and OS from user agent string
+func parseUserAgent(userAgent string) (device, browser, os string) {
 	if userAgent == "" {
 		return "unknown", "unknown", "unknown"
 	}
@@ -468,27 +780,32 @@ func (s *analyticsService) parseUserAgent(userAgent string) (device, browser, os
 	return device, browser, os
 }
 
-// getGeoLocation extracts geolocation from IP address (placeholder implementation)
-func (s *analyticsService) getGeoLocation(ipAddress string) (country, city string) {
-	// This is a placeholder implementation
-	// In production, you would integrate with a geolocation service like:
-	// - MaxMind GeoIP2
-	// - IPinfo
-	// - IP-API
-	// - Abstract API
+// getGeoLocation resolves the country and city of an IP address. Lookups are
+// best effort: failures leave the location empty rather than failing tracking.
+func (s *analyticsService) getGeoLocation(ctx context.Context, ipAddress string) (country, city string) {
+	if s.geo == nil {
+		return "", ""
+	}
 
-	if ipAddress == "" || ipAddress == "127.0.0.1" || ipAddress == "::1" {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() {
 		return "", ""
 	}
 
-	// For demonstration, return empty values
-	// In production, you would make an API call to get real geolocation data
-	return "", ""
+	location, err := s.geo.Lookup(ctx, ip)
+	if err != nil {
+		s.logger.Debug("GeoIP lookup failed", zap.String("ip", ipAddress), zap.Error(err))
+		return "", ""
+	}
+	if location == nil {
+		return "", ""
+	}
+	return location.Country, location.City
 }
 
 // GenerateSessionID generates a unique session ID
 func (s *analyticsService) GenerateSessionID() string {
-	return primitive.NewObjectID().Hex()
+	return models.NewID().String()
 }
 
 // IsValidPage validates that a page name is allowed
@@ -583,6 +900,38 @@ func (s *analyticsService) ExtractSourceFromReferrer(referrer string) string {
 	}
 }
 
+// attributeTraffic returns the normalized source, medium and campaign of a
+// visit. UTM parameters win; otherwise the referrer is classified.
+func (s *analyticsService) attributeTraffic(referrer string, query url.Values) (source, medium, campaign string) {
+	if utmSource := normalizeAttribution(query.Get("utm_source")); utmSource != "" {
+		return utmSource, normalizeAttribution(query.Get("utm_medium")), normalizeAttribution(query.Get("utm_campaign"))
+	}
+
+	source = s.ExtractSourceFromReferrer(referrer)
+	switch source {
+	case "direct":
+		medium = "none"
+	case "google":
+		medium = "organic"
+	case "referral":
+		medium = "referral"
+	default:
+		medium = "social"
+	}
+	return source, medium, ""
+}
+
+// normalizeAttribution lowercases an attribution value and keeps it short and
+// safe to use as a counter key
+func normalizeAttribution(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	value = strings.Trim(attributionPattern.ReplaceAllString(value, "_"), "_")
+	if len(value) > 50 {
+		value = value[:50]
+	}
+	return value
+}
+
 // ValidatePeriod validates analytics period
 func (s *analyticsService) ValidatePeriod(period string) bool {
 	validPeriods := []string{"daily", "weekly", "monthly", "yearly"}
@@ -0,0 +1,339 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+const (
+	// anomalyBaselineDays is how many previous days the same hour is averaged
+	// over for the baseline, which keeps the daily rhythm of traffic out of it
+	anomalyBaselineDays = 7
+	// anomalyCooldown is how long after an alert the same metric and kind of a
+	// wedding does not alert again
+	anomalyCooldown = 6 * time.Hour
+)
+
+// AnalyticsAnomalyService compares each wedding's page views and RSVPs of the
+// last hour with the same hour of the previous days, and alerts the wedding's
+// members when they spike or drop, e.g. when a slug change breaks the links
+// guests were sent. Alerts are listed on the analytics dashboard and sent by
+// email and to the wedding's webhooks.
+type AnalyticsAnomalyService struct {
+	anomalyRepo   repository.AnalyticsAnomalyRepository
+	analyticsRepo repository.AnalyticsRepository
+	weddingRepo   repository.WeddingRepository
+	userRepo      repository.UserRepository
+	email         EmailService
+	webhooks      WeddingEventNotifier
+	logger        *zap.Logger
+}
+
+// NewAnalyticsAnomalyService creates a new analytics anomaly service
+func NewAnalyticsAnomalyService(
+	anomalyRepo repository.AnalyticsAnomalyRepository,
+	analyticsRepo repository.AnalyticsRepository,
+	weddingRepo repository.WeddingRepository,
+	userRepo repository.UserRepository,
+	email EmailService,
+	logger *zap.Logger,
+) *AnalyticsAnomalyService {
+	return &AnalyticsAnomalyService{
+		anomalyRepo:   anomalyRepo,
+		analyticsRepo: analyticsRepo,
+		weddingRepo:   weddingRepo,
+		userRepo:      userRepo,
+		email:         email,
+		logger:        logger,
+	}
+}
+
+// SetWebhookNotifier sends an analytics.anomaly event to the wedding's own webhooks
+func (s *AnalyticsAnomalyService) SetWebhookNotifier(notifier WeddingEventNotifier) {
+	s.webhooks = notifier
+}
+
+// ListAnomalies lists the anomalies of a wedding the user may view the analytics of, latest first
+func (s *AnalyticsAnomalyService) ListAnomalies(ctx context.Context, weddingID, userID models.ID, page, pageSize int) ([]*models.AnalyticsAnomaly, int64, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, 0, ErrWeddingNotFound
+		}
+		return nil, 0, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if !wedding.Can(userID, models.PermissionViewAnalytics) {
+		return nil, 0, ErrUnauthorized
+	}
+	return s.anomalyRepo.ListByWedding(ctx, weddingID, page, pageSize)
+}
+
+// Detect looks for anomalies in the last complete hour before now, records and
+// sends the new ones and returns them
+func (s *AnalyticsAnomalyService) Detect(ctx context.Context, now time.Time) ([]*models.AnalyticsAnomaly, error) {
+	hour := now.UTC().Truncate(time.Hour).Add(-time.Hour)
+	hours := make([]time.Time, anomalyBaselineDays+1)
+	for i := range hours {
+		hours[i] = hour.AddDate(0, 0, -i)
+	}
+
+	activity, err := s.analyticsRepo.CountHourlyActivity(ctx, hours)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count hourly activity: %w", err)
+	}
+
+	// Current and baseline volumes by wedding; weddings without events in the
+	// last hour are kept, as their traffic may have dropped to nothing
+	type volumes struct {
+		pageViews, rsvps                 int64
+		baselinePageViews, baselineRSVPs float64
+	}
+	byWedding := make(map[models.ID]*volumes)
+	var weddingIDs []models.ID
+	for _, a := range activity {
+		v := byWedding[a.WeddingID]
+		if v == nil {
+			v = &volumes{}
+			byWedding[a.WeddingID] = v
+			weddingIDs = append(weddingIDs, a.WeddingID)
+		}
+		if a.Hour.Equal(hour) {
+			v.pageViews, v.rsvps = a.PageViews, a.RSVPs
+		} else {
+			v.baselinePageViews += float64(a.PageViews) / anomalyBaselineDays
+			v.baselineRSVPs += float64(a.RSVPs) / anomalyBaselineDays
+		}
+	}
+	if len(weddingIDs) == 0 {
+		return nil, nil
+	}
+
+	weddings, err := s.weddingRepo.GetByIDs(ctx, weddingIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weddings: %w", err)
+	}
+
+	var found []*models.AnalyticsAnomaly
+	for _, wedding := range weddings {
+		if ctx.Err() != nil {
+			return found, ctx.Err()
+		}
+		settings := wedding.Analytics.Alerts
+		// Traffic of weddings taken offline is expected to drop
+		if settings.Disabled || wedding.Analytics.Disabled || !wedding.IsAccessible() {
+			continue
+		}
+
+		v := byWedding[wedding.ID]
+		for _, m := range []struct {
+			metric   models.AnomalyMetric
+			value    int64
+			baseline float64
+		}{
+			{models.AnomalyMetricPageViews, v.pageViews, v.baselinePageViews},
+			{models.AnomalyMetricRSVPs, v.rsvps, v.baselineRSVPs},
+		} {
+			kind, ok := settings.Detect(float64(m.value), m.baseline)
+			if !ok {
+				continue
+			}
+			anomaly := &models.AnalyticsAnomaly{
+				WeddingID: wedding.ID,
+				Metric:    m.metric,
+				Kind:      kind,
+				Hour:      hour,
+				Value:     m.value,
+				Baseline:  m.baseline,
+			}
+			recorded, err := s.record(ctx, anomaly)
+			if err != nil {
+				s.logger.Error("Failed to record analytics anomaly", zap.Error(err), zap.String("wedding_id", wedding.ID.String()))
+				continue
+			}
+			if recorded {
+				s.notify(ctx, wedding, anomaly)
+				found = append(found, anomaly)
+			}
+		}
+	}
+	return found, nil
+}
+
+// record stores an anomaly unless the wedding alerted on the same metric and
+// kind within the cooldown, and reports whether it did
+func (s *AnalyticsAnomalyService) record(ctx context.Context, anomaly *models.AnalyticsAnomaly) (bool, error) {
+	latest, err := s.anomalyRepo.GetLatest(ctx, anomaly.WeddingID, anomaly.Metric, anomaly.Kind)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return false, err
+	}
+	if latest != nil && anomaly.Hour.Sub(latest.Hour) < anomalyCooldown {
+		return false, nil
+	}
+	if err := s.anomalyRepo.Create(ctx, anomaly); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// notify sends an anomaly through the channels the wedding alerts on.
+// Failures are logged, not returned.
+func (s *AnalyticsAnomalyService) notify(ctx context.Context, wedding *models.Wedding, anomaly *models.AnalyticsAnomaly) {
+	settings := wedding.Analytics.Alerts
+
+	if s.webhooks != nil && settings.Sends(models.AnomalyChannelWebhook) {
+		s.webhooks.NotifyWeddingEvent(ctx, wedding.ID, models.WeddingEventAnalyticsAnomaly, map[string]interface{}{
+			"anomaly_id": anomaly.ID.String(),
+			"metric":     string(anomaly.Metric),
+			"kind":       string(anomaly.Kind),
+			"hour":       anomaly.Hour,
+			"value":      anomaly.Value,
+			"baseline":   anomaly.Baseline,
+		})
+	}
+
+	if s.email != nil && settings.Sends(models.AnomalyChannelEmail) {
+		owner, err := s.userRepo.GetByID(ctx, wedding.UserID)
+		if err != nil || owner == nil {
+			s.logger.Error("Failed to get wedding owner for anomaly alert", zap.Error(err), zap.String("wedding_id", wedding.ID.String()))
+			return
+		}
+		msg, err := renderAnomalyAlert(wedding, anomaly)
+		if err != nil {
+			s.logger.Error("Failed to render anomaly alert", zap.Error(err))
+			return
+		}
+		msg.To = owner.Email
+		if err := s.email.Send(ctx, msg); err != nil {
+			s.logger.Warn("Failed to send anomaly alert", zap.Error(err), zap.String("wedding_id", wedding.ID.String()))
+		}
+	}
+}
+
+// anomalyAlertView is the data rendered into an anomaly alert
+type anomalyAlertView struct {
+	Title    string
+	Headline string
+	Hour     string
+	Value    int64
+	Baseline string
+	Hint     string
+}
+
+var anomalyAlertHTML = template.Must(template.New("anomaly_alert").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #333;">
+  <h2>{{.Headline}}</h2>
+  <p>Between {{.Hour}} (UTC) {{.Title}} had <strong>{{.Value}}</strong>, against <strong>{{.Baseline}}</strong> at this hour on an average day last week.</p>
+  <p>{{.Hint}}</p>
+  <p style="font-size: 12px; color: #888;">You receive this email because anomaly alerts are on for this wedding. You can change their thresholds or turn them off in your wedding's analytics settings.</p>
+</body>
+</html>`))
+
+// renderAnomalyAlert renders the HTML and plain text alert of an anomaly
+func renderAnomalyAlert(wedding *models.Wedding, anomaly *models.AnalyticsAnomaly) (*EmailMessage, error) {
+	what := "page views"
+	if anomaly.Metric == models.AnomalyMetricRSVPs {
+		what = "RSVPs"
+	}
+
+	view := anomalyAlertView{
+		Title:    wedding.Title,
+		Hour:     anomaly.Hour.Format("Jan 2 15:04") + " and " + anomaly.Hour.Add(time.Hour).Format("15:04"),
+		Value:    anomaly.Value,
+		Baseline: fmt.Sprintf("%.1f", anomaly.Baseline),
+	}
+	if anomaly.Kind == models.AnomalySpike {
+		view.Headline = fmt.Sprintf("Unusually many %s for %s", what, wedding.Title)
+		view.Hint = "If you just shared the invitation, this is expected. Otherwise check the traffic sources on your analytics dashboard."
+	} else {
+		view.Headline = fmt.Sprintf("Unusually few %s for %s", what, wedding.Title)
+		view.Hint = "Check that the invitation still opens from the links your guests were sent, especially after changing its address."
+	}
+	var html bytes.Buffer
+	if err := anomalyAlertHTML.Execute(&html, view); err != nil {
+		return nil, fmt.Errorf("failed to render anomaly alert: %w", err)
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", view.Headline)
+	fmt.Fprintf(&text, "Between %s (UTC) %s had %d %s, against %s at this hour on an average day last week.\n\n%s\n",
+		view.Hour, view.Title, view.Value, what, view.Baseline, view.Hint)
+
+	return &EmailMessage{
+		Subject: view.Headline,
+		HTML:    html.String(),
+		Text:    text.String(),
+	}, nil
+}
+
+// AnalyticsAnomalyScheduler looks for anomalies in the last complete hour every interval
+type AnalyticsAnomalyScheduler struct {
+	service  *AnalyticsAnomalyService
+	interval time.Duration
+	logger   *zap.Logger
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewAnalyticsAnomalyScheduler creates a scheduler that looks for anomalies every interval.
+// Hours already checked are checked again harmlessly, as the cooldown keeps
+// them from alerting twice.
+func NewAnalyticsAnomalyScheduler(service *AnalyticsAnomalyService, interval time.Duration, logger *zap.Logger) *AnalyticsAnomalyScheduler {
+	return &AnalyticsAnomalyScheduler{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start runs the scheduler loop in the background, looking for anomalies
+// right away as well
+func (sch *AnalyticsAnomalyScheduler) Start(ctx context.Context) {
+	sch.wg.Add(1)
+	go func() {
+		defer sch.wg.Done()
+		ticker := time.NewTicker(sch.interval)
+		defer ticker.Stop()
+
+		sch.run(ctx, time.Now())
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sch.stop:
+				return
+			case now := <-ticker.C:
+				sch.run(ctx, now)
+			}
+		}
+	}()
+}
+
+func (sch *AnalyticsAnomalyScheduler) run(ctx context.Context, now time.Time) {
+	found, err := sch.service.Detect(ctx, now)
+	if err != nil {
+		sch.logger.Error("Analytics anomaly run failed", zap.Error(err))
+		return
+	}
+	if len(found) > 0 {
+		sch.logger.Info("Analytics anomalies found", zap.Int("count", len(found)))
+	}
+}
+
+// Stop signals the scheduler loop to exit and waits for it
+func (sch *AnalyticsAnomalyScheduler) Stop() {
+	close(sch.stop)
+	sch.wg.Wait()
+}
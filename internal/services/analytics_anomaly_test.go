@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// memoryAnalyticsAnomalyRepository keeps anomalies in memory
+type memoryAnalyticsAnomalyRepository struct {
+	anomalies []*models.AnalyticsAnomaly
+}
+
+func (r *memoryAnalyticsAnomalyRepository) Create(ctx context.Context, anomaly *models.AnalyticsAnomaly) error {
+	anomaly.ID = models.NewID()
+	r.anomalies = append(r.anomalies, anomaly)
+	return nil
+}
+
+func (r *memoryAnalyticsAnomalyRepository) GetLatest(ctx context.Context, weddingID models.ID, metric models.AnomalyMetric, kind models.AnomalyKind) (*models.AnalyticsAnomaly, error) {
+	var latest *models.AnalyticsAnomaly
+	for _, a := range r.anomalies {
+		if a.WeddingID == weddingID && a.Metric == metric && a.Kind == kind && (latest == nil || a.Hour.After(latest.Hour)) {
+			latest = a
+		}
+	}
+	if latest == nil {
+		return nil, repository.ErrNotFound
+	}
+	return latest, nil
+}
+
+func (r *memoryAnalyticsAnomalyRepository) ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int) ([]*models.AnalyticsAnomaly, int64, error) {
+	anomalies := []*models.AnalyticsAnomaly{}
+	for _, a := range r.anomalies {
+		if a.WeddingID == weddingID {
+			anomalies = append(anomalies, a)
+		}
+	}
+	return anomalies, int64(len(anomalies)), nil
+}
+
+// hourlyActivity returns the activity of a wedding in an hour and the same hour
+// on each of the previous days, the first value being the hour's own
+func hourlyActivity(weddingID models.ID, hour time.Time, pageViews, rsvps []int64) []models.HourlyActivity {
+	activity := make([]models.HourlyActivity, len(pageViews))
+	for i := range pageViews {
+		activity[i] = models.HourlyActivity{WeddingID: weddingID, Hour: hour.AddDate(0, 0, -i), PageViews: pageViews[i], RSVPs: rsvps[i]}
+	}
+	return activity
+}
+
+func TestAnalyticsAnomalyService_Detect(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 6, 10, 12, 20, 0, 0, time.UTC)
+	hour := time.Date(2026, 6, 10, 11, 0, 0, 0, time.UTC)
+
+	owner := &models.User{ID: models.NewID(), Email: "owner@example.com"}
+	published := string(models.WeddingStatusPublished)
+	// Shared today, alerting through webhooks only
+	shared := &models.Wedding{ID: models.NewID(), UserID: owner.ID, Title: "Ana & Ben", Status: published}
+	shared.Analytics.Alerts.Channels = []string{models.AnomalyChannelWebhook}
+	// Traffic gone after a slug change, alerting through every channel
+	broken := &models.Wedding{ID: models.NewID(), UserID: owner.ID, Title: "Cy & Di", Status: published}
+	// Alerts turned off
+	muted := &models.Wedding{ID: models.NewID(), UserID: owner.ID, Title: "Ed & Fi", Status: published}
+	muted.Analytics.Alerts.Disabled = true
+	// Unpublished on purpose
+	draft := &models.Wedding{ID: models.NewID(), UserID: owner.ID, Title: "Gus & Hal", Status: "draft"}
+
+	var activity []models.HourlyActivity
+	activity = append(activity, hourlyActivity(shared.ID, hour, []int64{60, 10, 10, 10, 10, 10, 10, 10}, []int64{3, 1, 1, 1, 1, 1, 1, 1})...)
+	// Without a single page view in the current hour
+	activity = append(activity, hourlyActivity(broken.ID, hour.AddDate(0, 0, -1), []int64{100, 100, 100, 100, 100, 100, 100}, []int64{5, 5, 5, 5, 5, 5, 5})...)
+	activity = append(activity, hourlyActivity(muted.ID, hour, []int64{500, 10, 10, 10, 10, 10, 10, 10}, []int64{0, 0, 0, 0, 0, 0, 0, 0})...)
+	activity = append(activity, hourlyActivity(draft.ID, hour.AddDate(0, 0, -1), []int64{100, 100, 100, 100, 100, 100, 100}, []int64{0, 0, 0, 0, 0, 0, 0})...)
+
+	anomalyRepo := &memoryAnalyticsAnomalyRepository{}
+	analyticsRepo := &MockAnalyticsRepository{}
+	weddingRepo := &MockWeddingRepository{}
+	userRepo := &MockUserRepository{}
+	email := &MockEmailService{}
+	notifier := &recordingEventNotifier{}
+
+	analyticsRepo.On("CountHourlyActivity", mock.Anything, mock.MatchedBy(func(hours []time.Time) bool {
+		return len(hours) == anomalyBaselineDays+1 && hours[0].Equal(hour) && hours[anomalyBaselineDays].Equal(hour.AddDate(0, 0, -anomalyBaselineDays))
+	})).Return(activity, nil)
+	weddingRepo.On("GetByIDs", mock.Anything, mock.Anything).Return([]*models.Wedding{shared, broken, muted, draft}, nil)
+	userRepo.On("GetByID", mock.Anything, owner.ID).Return(owner, nil)
+
+	service := NewAnalyticsAnomalyService(anomalyRepo, analyticsRepo, weddingRepo, userRepo, email, zaptest.NewLogger(t))
+	service.SetWebhookNotifier(notifier)
+
+	found, err := service.Detect(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+
+	byWedding := make(map[models.ID]*models.AnalyticsAnomaly)
+	for _, a := range found {
+		byWedding[a.WeddingID] = a
+	}
+	spike := byWedding[shared.ID]
+	require.NotNil(t, spike)
+	assert.Equal(t, models.AnomalyMetricPageViews, spike.Metric)
+	assert.Equal(t, models.AnomalySpike, spike.Kind)
+	assert.Equal(t, hour, spike.Hour)
+	assert.Equal(t, int64(60), spike.Value)
+	assert.InDelta(t, 10, spike.Baseline, 0.001)
+
+	drop := byWedding[broken.ID]
+	require.NotNil(t, drop)
+	assert.Equal(t, models.AnomalyMetricPageViews, drop.Metric)
+	assert.Equal(t, models.AnomalyDrop, drop.Kind)
+	assert.Equal(t, int64(0), drop.Value)
+	assert.InDelta(t, 100, drop.Baseline, 0.001)
+
+	// Both went to webhooks, only the drop was emailed
+	require.Len(t, notifier.events, 2)
+	for _, event := range notifier.events {
+		assert.Equal(t, models.WeddingEventAnalyticsAnomaly, event.Type)
+	}
+	require.Len(t, email.sent, 1)
+	assert.Equal(t, owner.Email, email.sent[0].To)
+	assert.Contains(t, email.sent[0].Subject, "Unusually few page views")
+	assert.Contains(t, email.sent[0].Text, "Cy & Di")
+
+	// Running again within the cooldown alerts nothing new
+	found, err = service.Detect(ctx, now.Add(15*time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, found)
+	assert.Len(t, anomalyRepo.anomalies, 2)
+	assert.Len(t, notifier.events, 2)
+}
+
+func TestAnalyticsAnomalyService_ListAnomalies(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+	wedding := &models.Wedding{ID: models.NewID(), UserID: ownerID}
+	missingID := models.NewID()
+
+	anomalyRepo := &memoryAnalyticsAnomalyRepository{}
+	require.NoError(t, anomalyRepo.Create(ctx, &models.AnalyticsAnomaly{WeddingID: wedding.ID, Metric: models.AnomalyMetricRSVPs, Kind: models.AnomalySpike}))
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+	weddingRepo.On("GetByID", mock.Anything, missingID).Return(nil, repository.ErrNotFound)
+
+	service := NewAnalyticsAnomalyService(anomalyRepo, &MockAnalyticsRepository{}, weddingRepo, &MockUserRepository{}, nil, zaptest.NewLogger(t))
+
+	anomalies, total, err := service.ListAnomalies(ctx, wedding.ID, ownerID, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, anomalies, 1)
+
+	_, _, err = service.ListAnomalies(ctx, wedding.ID, models.NewID(), 1, 20)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	_, _, err = service.ListAnomalies(ctx, missingID, ownerID, 1, 20)
+	assert.ErrorIs(t, err, ErrWeddingNotFound)
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// Types of the events of a tracking batch
+const (
+	TrackBatchPageView     = "page_view"
+	TrackBatchPageDuration = "page_duration"
+	TrackBatchConversion   = "conversion"
+)
+
+const (
+	// MaxTrackBatchSize bounds the events of one tracking batch
+	MaxTrackBatchSize = 100
+	// maxTrackBatchEventAge is how long a client may buffer an event before
+	// sending it
+	maxTrackBatchEventAge = 24 * time.Hour
+)
+
+var (
+	ErrInvalidTrackBatchType = errs.InvalidField("type", "type must be page_view, page_duration or conversion")
+	ErrInvalidTrackPage      = errs.InvalidField("page", "invalid page name")
+	ErrInvalidTrackEvent     = errs.InvalidField("event", "invalid event")
+	ErrMissingTrackSession   = errs.InvalidField("session_id", "session_id is required")
+	ErrStaleTrackEvent       = errs.InvalidField("timestamp", "event is more than 24 hours old")
+)
+
+// TrackBatchEvent is one event of a tracking batch. Type tells which of the
+// other fields apply: Page for page views, Page and Duration for page
+// durations, and Event, Value and Properties for conversions. Timestamp is when
+// the client saw the event; it defaults to when the batch is received.
+type TrackBatchEvent struct {
+	Type       string
+	WeddingID  models.ID
+	SessionID  string
+	Page       string
+	Duration   int64
+	Event      string
+	Value      float64
+	Properties map[string]interface{}
+	Timestamp  time.Time
+}
+
+// TrackBatch records the events a client buffered and sent at once. Page views
+// and conversions are each stored with one write; durations are applied after
+// the page views, so a batch may hold a view and its duration. It returns the
+// outcome of each event by index, nil for a recorded one: an invalid event
+// fails alone, while a failed write fails the events it held.
+func (s *analyticsService) TrackBatch(ctx context.Context, events []TrackBatchEvent, req *http.Request) []error {
+	results := make([]error, len(events))
+	now := time.Now()
+
+	// Weddings are looked up once per batch
+	weddings := make(map[models.ID]*models.Wedding)
+	weddingErrs := make(map[models.ID]error)
+	getWedding := func(id models.ID) (*models.Wedding, error) {
+		if wedding, ok := weddings[id]; ok {
+			return wedding, nil
+		}
+		if err, ok := weddingErrs[id]; ok {
+			return nil, err
+		}
+		wedding, err := s.weddingRepo.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				err = ErrWeddingNotFound
+			} else {
+				err = fmt.Errorf("failed to get wedding: %w", err)
+			}
+			weddingErrs[id] = err
+			return nil, err
+		}
+		weddings[id] = wedding
+		return wedding, nil
+	}
+
+	// The client is the same for every page view of the batch
+	var client *models.PageView
+	requestID := utils.RequestIDFromContext(ctx)
+
+	var pageViews []*models.PageView
+	var pageViewIndexes []int
+	var conversions []*models.ConversionEvent
+	var conversionIndexes []int
+	var durations []int
+	for i := range events {
+		event := &events[i]
+		if err := s.validateBatchEvent(event, now); err != nil {
+			results[i] = err
+			continue
+		}
+		wedding, err := getWedding(event.WeddingID)
+		if err != nil {
+			results[i] = err
+			continue
+		}
+		if skipTracking(wedding, event.SessionID, req, event.Type != TrackBatchConversion) {
+			results[i] = ErrTrackingSkipped
+			continue
+		}
+
+		switch event.Type {
+		case TrackBatchPageView:
+			if wedding.Status != string(models.WeddingStatusPublished) {
+				results[i] = ErrTrackingUnpublished
+				continue
+			}
+			if client == nil {
+				client = s.newPageView(ctx, "", "", "", req)
+			}
+			pageView := *client
+			pageView.WeddingID = event.WeddingID
+			pageView.SessionID = event.SessionID
+			pageView.Page = event.Page
+			pageView.Timestamp = event.Timestamp
+			pageView.Metadata = make(map[string]interface{}, len(client.Metadata))
+			for key, value := range client.Metadata {
+				pageView.Metadata[key] = value
+			}
+			pageViews = append(pageViews, &pageView)
+			pageViewIndexes = append(pageViewIndexes, i)
+		case TrackBatchPageDuration:
+			if wedding.Status != string(models.WeddingStatusPublished) {
+				results[i] = ErrTrackingUnpublished
+				continue
+			}
+			durations = append(durations, i)
+		case TrackBatchConversion:
+			properties := event.Properties
+			if requestID != "" {
+				if properties == nil {
+					properties = make(map[string]interface{})
+				}
+				properties["request_id"] = requestID
+			}
+			conversions = append(conversions, &models.ConversionEvent{
+				WeddingID:  event.WeddingID,
+				SessionID:  event.SessionID,
+				Event:      event.Event,
+				Value:      event.Value,
+				Timestamp:  event.Timestamp,
+				Properties: properties,
+			})
+			conversionIndexes = append(conversionIndexes, i)
+		}
+	}
+
+	if len(pageViews) > 0 {
+		if err := s.analyticsRepo.TrackPageViews(ctx, pageViews); err != nil {
+			s.logger.Error("Failed to track page views", zap.Error(err), zap.Int("count", len(pageViews)))
+			err = fmt.Errorf("failed to track page views: %w", err)
+			for _, i := range pageViewIndexes {
+				results[i] = err
+			}
+		} else {
+			for _, pageView := range pageViews {
+				s.publishPageView(pageView)
+			}
+		}
+	}
+
+	if len(conversions) > 0 {
+		if err := s.analyticsRepo.TrackConversions(ctx, conversions); err != nil {
+			s.logger.Error("Failed to track conversions", zap.Error(err), zap.Int("count", len(conversions)))
+			err = fmt.Errorf("failed to track conversions: %w", err)
+			for _, i := range conversionIndexes {
+				results[i] = err
+			}
+		} else {
+			for _, conversion := range conversions {
+				s.publishConversion(conversion)
+			}
+		}
+	}
+
+	for _, i := range durations {
+		event := events[i]
+		duration := event.Duration
+		if duration > maxPageDuration {
+			duration = maxPageDuration
+		}
+		if err := s.analyticsRepo.TrackPageDuration(ctx, event.WeddingID, event.SessionID, event.Page, duration); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				results[i] = ErrPageViewNotFound
+				continue
+			}
+			s.logger.Error("Failed to track page duration",
+				zap.Error(err),
+				zap.String("wedding_id", event.WeddingID.String()),
+				zap.String("page", event.Page))
+			results[i] = fmt.Errorf("failed to track page duration: %w", err)
+		}
+	}
+
+	return results
+}
+
+// validateBatchEvent checks the fields of an event's type and settles its
+// timestamp: a missing or future one becomes now
+func (s *analyticsService) validateBatchEvent(event *TrackBatchEvent, now time.Time) error {
+	if event.SessionID == "" {
+		return ErrMissingTrackSession
+	}
+
+	switch event.Type {
+	case TrackBatchPageView:
+		if !s.IsValidPage(event.Page) {
+			return ErrInvalidTrackPage
+		}
+	case TrackBatchPageDuration:
+		if !s.IsValidPage(event.Page) {
+			return ErrInvalidTrackPage
+		}
+		if event.Duration <= 0 {
+			return ErrInvalidPageDuration
+		}
+	case TrackBatchConversion:
+		if !s.IsValidEvent(event.Event) {
+			return ErrInvalidTrackEvent
+		}
+	default:
+		return ErrInvalidTrackBatchType
+	}
+
+	switch {
+	case event.Timestamp.IsZero() || event.Timestamp.After(now):
+		event.Timestamp = now
+	case now.Sub(event.Timestamp) > maxTrackBatchEventAge:
+		return ErrStaleTrackEvent
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+func TestAnalyticsService_TrackBatch(t *testing.T) {
+	ctx := context.Background()
+	published := &models.Wedding{ID: models.NewID(), Status: string(models.WeddingStatusPublished)}
+	draft := &models.Wedding{ID: models.NewID(), Status: string(models.WeddingStatusDraft)}
+	missing := models.NewID()
+
+	newService := func(t *testing.T) (AnalyticsService, *MockAnalyticsRepository, *MockWeddingRepository) {
+		analyticsRepo := &MockAnalyticsRepository{}
+		weddingRepo := &MockWeddingRepository{}
+		weddingRepo.On("GetByID", ctx, published.ID).Return(published, nil)
+		weddingRepo.On("GetByID", ctx, draft.ID).Return(draft, nil)
+		weddingRepo.On("GetByID", ctx, missing).Return(nil, repository.ErrNotFound)
+		return NewAnalyticsService(analyticsRepo, weddingRepo, zaptest.NewLogger(t)), analyticsRepo, weddingRepo
+	}
+
+	req := httptest.NewRequest("POST", "/analytics/track/batch", nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) Mobile")
+
+	t.Run("Records each kind of event", func(t *testing.T) {
+		service, analyticsRepo, weddingRepo := newService(t)
+		seen := time.Now().Add(-time.Hour)
+
+		var pageViews []*models.PageView
+		analyticsRepo.On("TrackPageViews", ctx, mock.AnythingOfType("[]*models.PageView")).
+			Run(func(args mock.Arguments) { pageViews = args.Get(1).([]*models.PageView) }).
+			Return(nil)
+		analyticsRepo.On("TrackConversions", ctx, mock.AnythingOfType("[]*models.ConversionEvent")).Return(nil)
+		analyticsRepo.On("TrackPageDuration", ctx, published.ID, "s1", "invitation", int64(maxPageDuration)).Return(nil)
+
+		results := service.TrackBatch(ctx, []TrackBatchEvent{
+			{Type: TrackBatchPageView, WeddingID: published.ID, SessionID: "s1", Page: "invitation", Timestamp: seen},
+			{Type: TrackBatchPageView, WeddingID: published.ID, SessionID: "s1", Page: "gallery"},
+			{Type: TrackBatchPageDuration, WeddingID: published.ID, SessionID: "s1", Page: "invitation", Duration: 6 * 60 * 60},
+			{Type: TrackBatchConversion, WeddingID: published.ID, SessionID: "s1", Event: "rsvp_completed", Value: 1},
+		}, req)
+
+		assert.Equal(t, []error{nil, nil, nil, nil}, results)
+		require.Len(t, pageViews, 2)
+		assert.True(t, pageViews[0].Timestamp.Equal(seen))
+		assert.Equal(t, "mobile", pageViews[0].Device)
+		assert.Equal(t, "gallery", pageViews[1].Page)
+		analyticsRepo.AssertExpectations(t)
+		// The wedding is looked up once for the whole batch
+		weddingRepo.AssertNumberOfCalls(t, "GetByID", 1)
+	})
+
+	t.Run("Fails invalid events alone", func(t *testing.T) {
+		service, analyticsRepo, _ := newService(t)
+		analyticsRepo.On("TrackPageViews", ctx, mock.AnythingOfType("[]*models.PageView")).Return(nil)
+
+		results := service.TrackBatch(ctx, []TrackBatchEvent{
+			{Type: TrackBatchPageView, WeddingID: published.ID, SessionID: "s1", Page: "invitation"},
+			{Type: "click", WeddingID: published.ID, SessionID: "s1"},
+			{Type: TrackBatchPageView, WeddingID: published.ID, SessionID: "s1", Page: "admin"},
+			{Type: TrackBatchPageView, WeddingID: published.ID, Page: "invitation"},
+			{Type: TrackBatchConversion, WeddingID: published.ID, SessionID: "s1", Event: "unknown"},
+			{Type: TrackBatchPageDuration, WeddingID: published.ID, SessionID: "s1", Page: "invitation"},
+			{Type: TrackBatchPageView, WeddingID: published.ID, SessionID: "s1", Page: "invitation", Timestamp: time.Now().Add(-48 * time.Hour)},
+			{Type: TrackBatchPageView, WeddingID: draft.ID, SessionID: "s1", Page: "invitation"},
+			{Type: TrackBatchConversion, WeddingID: missing, SessionID: "s1", Event: "rsvp_completed"},
+		}, req)
+
+		require.Len(t, results, 9)
+		assert.NoError(t, results[0])
+		assert.ErrorIs(t, results[1], ErrInvalidTrackBatchType)
+		assert.ErrorIs(t, results[2], ErrInvalidTrackPage)
+		assert.ErrorIs(t, results[3], ErrMissingTrackSession)
+		assert.ErrorIs(t, results[4], ErrInvalidTrackEvent)
+		assert.ErrorIs(t, results[5], ErrInvalidPageDuration)
+		assert.ErrorIs(t, results[6], ErrStaleTrackEvent)
+		assert.ErrorIs(t, results[7], ErrTrackingUnpublished)
+		assert.ErrorIs(t, results[8], ErrWeddingNotFound)
+		analyticsRepo.AssertNumberOfCalls(t, "TrackPageViews", 1)
+	})
+
+	t.Run("Fails the events of a failed write", func(t *testing.T) {
+		service, analyticsRepo, _ := newService(t)
+		analyticsRepo.On("TrackPageViews", ctx, mock.AnythingOfType("[]*models.PageView")).Return(errors.New("connection reset"))
+		analyticsRepo.On("TrackConversions", ctx, mock.AnythingOfType("[]*models.ConversionEvent")).Return(nil)
+		analyticsRepo.On("TrackPageDuration", ctx, published.ID, "s1", "gallery", int64(30)).Return(repository.ErrNotFound)
+
+		results := service.TrackBatch(ctx, []TrackBatchEvent{
+			{Type: TrackBatchPageView, WeddingID: published.ID, SessionID: "s1", Page: "invitation"},
+			{Type: TrackBatchConversion, WeddingID: published.ID, SessionID: "s1", Event: "rsvp_completed"},
+			{Type: TrackBatchPageView, WeddingID: published.ID, SessionID: "s2", Page: "home"},
+			{Type: TrackBatchPageDuration, WeddingID: published.ID, SessionID: "s1", Page: "gallery", Duration: 30},
+		}, req)
+
+		assert.Error(t, results[0])
+		assert.NoError(t, results[1])
+		assert.Error(t, results[2])
+		assert.ErrorIs(t, results[3], ErrPageViewNotFound)
+	})
+
+	t.Run("Moves future timestamps to now", func(t *testing.T) {
+		service, analyticsRepo, _ := newService(t)
+		var conversions []*models.ConversionEvent
+		analyticsRepo.On("TrackConversions", ctx, mock.AnythingOfType("[]*models.ConversionEvent")).
+			Run(func(args mock.Arguments) { conversions = args.Get(1).([]*models.ConversionEvent) }).
+			Return(nil)
+
+		results := service.TrackBatch(ctx, []TrackBatchEvent{
+			{Type: TrackBatchConversion, WeddingID: published.ID, SessionID: "s1", Event: "rsvp_completed", Timestamp: time.Now().Add(time.Hour)},
+		}, req)
+
+		require.NoError(t, results[0])
+		require.Len(t, conversions, 1)
+		assert.False(t, conversions[0].Timestamp.After(time.Now()))
+	})
+}
@@ -0,0 +1,442 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// Analytics export types
+const (
+	AnalyticsExportPageViews   = "page_views"
+	AnalyticsExportRSVP        = "rsvp"
+	AnalyticsExportConversions = "conversions"
+	AnalyticsExportSummary     = "summary" // Daily metrics
+)
+
+// Analytics export formats
+const (
+	AnalyticsExportJSON = "json"
+	AnalyticsExportCSV  = "csv"
+)
+
+const (
+	// AnalyticsExportSyncDays is the longest range of raw events exported while
+	// the client waits; longer ranges are generated in the background
+	AnalyticsExportSyncDays = 31
+	// analyticsExportLinkExpiry is how long a download link of a background export works
+	analyticsExportLinkExpiry = 15 * time.Minute
+)
+
+var (
+	ErrInvalidAnalyticsExport = errors.New("export type must be page_views, rsvp, conversions or summary and format csv or json")
+	ErrExportJobNotFound      = errs.NotFound("export job not found")
+	ErrExportNotReady         = errors.New("export is not ready for download")
+)
+
+// AnalyticsExportRequest selects what an analytics export contains: the events
+// of one type tracked on the UTC days From to To (inclusive)
+type AnalyticsExportRequest struct {
+	Type   string    `bson:"type"`
+	Format string    `bson:"format"`
+	From   time.Time `bson:"from"`
+	To     time.Time `bson:"to"`
+}
+
+// Validate checks the type, format and date range of the export
+func (r AnalyticsExportRequest) Validate() error {
+	if _, ok := analyticsExportLayouts[r.Type]; !ok {
+		return ErrInvalidAnalyticsExport
+	}
+	if r.Format != AnalyticsExportJSON && r.Format != AnalyticsExportCSV {
+		return ErrInvalidAnalyticsExport
+	}
+	from, to := r.days()
+	if to.Before(from) || to.Sub(from) >= maxDailyMetricsDays*24*time.Hour {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
+
+// IsLarge reports whether the export spans too many days of raw events to be
+// generated while the client waits
+func (r AnalyticsExportRequest) IsLarge() bool {
+	if r.Type == AnalyticsExportSummary {
+		return false
+	}
+	from, to := r.days()
+	return to.Sub(from) >= AnalyticsExportSyncDays*24*time.Hour
+}
+
+// Resource is the resource the export is recorded as in the export history
+func (r AnalyticsExportRequest) Resource() string {
+	return analyticsExportLayouts[r.Type].Filename
+}
+
+// days returns the first and last day of the export
+func (r AnalyticsExportRequest) days() (time.Time, time.Time) {
+	return r.From.UTC().Truncate(24 * time.Hour), r.To.UTC().Truncate(24 * time.Hour)
+}
+
+// AnalyticsExportLayout describes how the records of an analytics export are
+// named and laid out as CSV rows
+type AnalyticsExportLayout struct {
+	Filename string
+	Header   []string
+	Row      func(record interface{}) []string
+}
+
+// AnalyticsExportLayoutOf returns the layout of an export type
+func AnalyticsExportLayoutOf(exportType string) (AnalyticsExportLayout, bool) {
+	layout, ok := analyticsExportLayouts[exportType]
+	return layout, ok
+}
+
+// analyticsExportLayouts leave out visitors' IP addresses and user agents
+var analyticsExportLayouts = map[string]AnalyticsExportLayout{
+	AnalyticsExportPageViews: {
+		Filename: "analytics_page_views",
+		Header: []string{
+			"id", "session_id", "page", "timestamp", "duration", "device", "browser", "os",
+			"country", "city", "source", "medium", "campaign", "is_bot",
+		},
+		Row: func(record interface{}) []string {
+			pageView := record.(*models.PageView)
+			return []string{
+				pageView.ID.String(),
+				pageView.SessionID,
+				pageView.Page,
+				pageView.Timestamp.UTC().Format(time.RFC3339),
+				strconv.FormatInt(pageView.Duration, 10),
+				pageView.Device,
+				pageView.Browser,
+				pageView.OS,
+				pageView.Country,
+				pageView.City,
+				pageView.Source,
+				pageView.Medium,
+				pageView.Campaign,
+				strconv.FormatBool(pageView.IsBot),
+			}
+		},
+	},
+	AnalyticsExportRSVP: {
+		Filename: "analytics_rsvp",
+		Header: []string{
+			"id", "rsvp_id", "session_id", "timestamp", "time_to_complete", "source", "device",
+			"browser", "referrer", "abandoned_step", "form_errors",
+		},
+		Row: func(record interface{}) []string {
+			event := record.(*models.RSVPAnalytics)
+			rsvpID := ""
+			if !event.RSVPID.IsZero() {
+				rsvpID = event.RSVPID.String()
+			}
+			return []string{
+				event.ID.String(),
+				rsvpID,
+				event.SessionID,
+				event.Timestamp.UTC().Format(time.RFC3339),
+				strconv.FormatInt(event.TimeToComplete, 10),
+				event.Source,
+				event.Device,
+				event.Browser,
+				event.Referrer,
+				event.AbandonedStep,
+				strings.Join(event.FormErrors, "; "),
+			}
+		},
+	},
+	AnalyticsExportConversions: {
+		Filename: "analytics_conversions",
+		Header:   []string{"id", "session_id", "event", "value", "currency", "timestamp"},
+		Row: func(record interface{}) []string {
+			event := record.(*models.ConversionEvent)
+			return []string{
+				event.ID.String(),
+				event.SessionID,
+				event.Event,
+				strconv.FormatFloat(event.Value, 'f', -1, 64),
+				event.Currency,
+				event.Timestamp.UTC().Format(time.RFC3339),
+			}
+		},
+	},
+	AnalyticsExportSummary: {
+		Filename: "analytics_summary",
+		Header:   []string{"date", "page_views", "sessions", "rsvps", "conversion_rate", "bounce_rate", "avg_time_on_page"},
+		Row: func(record interface{}) []string {
+			metric := record.(*models.DailyMetrics)
+			return []string{
+				metric.Date,
+				strconv.FormatInt(metric.PageViews, 10),
+				strconv.FormatInt(metric.Sessions, 10),
+				strconv.FormatInt(metric.RSVPs, 10),
+				strconv.FormatFloat(metric.Conversions, 'f', 2, 64),
+				strconv.FormatFloat(metric.BounceRate, 'f', 2, 64),
+				strconv.FormatFloat(metric.AvgTime, 'f', 2, 64),
+			}
+		},
+	},
+}
+
+// AnalyticsExportService exports a wedding's raw analytics events and daily
+// metrics to its owner, streaming short ranges and generating long ones in the
+// background into storage
+type AnalyticsExportService struct {
+	analyticsRepo repository.AnalyticsRepository
+	weddingRepo   repository.WeddingRepository
+	exportJobRepo repository.ExportJobRepository
+	analytics     AnalyticsService
+	storage       StorageService
+	jobs          JobEnqueuer
+	logger        *zap.Logger
+}
+
+// NewAnalyticsExportService creates a new analytics export service
+func NewAnalyticsExportService(
+	analyticsRepo repository.AnalyticsRepository,
+	weddingRepo repository.WeddingRepository,
+	exportJobRepo repository.ExportJobRepository,
+	analytics AnalyticsService,
+	storage StorageService,
+	jobs JobEnqueuer,
+	logger *zap.Logger,
+) *AnalyticsExportService {
+	return &AnalyticsExportService{
+		analyticsRepo: analyticsRepo,
+		weddingRepo:   weddingRepo,
+		exportJobRepo: exportJobRepo,
+		analytics:     analytics,
+		storage:       storage,
+		jobs:          jobs,
+		logger:        logger,
+	}
+}
+
+// StartExport verifies the user owns the wedding and records a running export
+// of it, to be streamed with StreamExport
+func (s *AnalyticsExportService) StartExport(ctx context.Context, weddingID, userID models.ID, req AnalyticsExportRequest) (*models.ExportJob, error) {
+	return s.createJob(ctx, weddingID, userID, req, models.ExportJobRunning)
+}
+
+// QueueExport verifies the user owns the wedding and queues the export for
+// generation in the background; its download link is served once completed
+func (s *AnalyticsExportService) QueueExport(ctx context.Context, weddingID, userID models.ID, req AnalyticsExportRequest) (*models.ExportJob, error) {
+	job, err := s.createJob(ctx, weddingID, userID, req, models.ExportJobQueued)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.jobs.Enqueue(ctx, JobTypeExportAnalytics, AnalyticsExportJob{
+		ExportJobID: job.ID,
+		WeddingID:   weddingID,
+		Request:     req,
+	})
+	if err != nil {
+		finishExportJob(ctx, s.exportJobRepo, s.logger, job, 0, err)
+		return nil, err
+	}
+	return job, nil
+}
+
+// FinishExport records the outcome of a streamed export
+func (s *AnalyticsExportService) FinishExport(ctx context.Context, job *models.ExportJob, records int, exportErr error) {
+	finishExportJob(ctx, s.exportJobRepo, s.logger, job, records, exportErr)
+}
+
+// StreamExport calls emit for each record of the export, oldest first
+func (s *AnalyticsExportService) StreamExport(ctx context.Context, weddingID models.ID, req AnalyticsExportRequest, emit func(record interface{}) error) error {
+	from, to := req.days()
+	// Raw events are selected by timestamp up to the end of the last day
+	end := to.AddDate(0, 0, 1)
+
+	switch req.Type {
+	case AnalyticsExportPageViews:
+		return s.analyticsRepo.StreamPageViews(ctx, weddingID, from, end, func(pageView *models.PageView) error {
+			return emit(pageView)
+		})
+	case AnalyticsExportRSVP:
+		return s.analyticsRepo.StreamRSVPEvents(ctx, weddingID, from, end, func(event *models.RSVPAnalytics) error {
+			return emit(event)
+		})
+	case AnalyticsExportConversions:
+		return s.analyticsRepo.StreamConversions(ctx, weddingID, from, end, func(event *models.ConversionEvent) error {
+			return emit(event)
+		})
+	case AnalyticsExportSummary:
+		metrics, err := s.analytics.GetDailyMetrics(ctx, weddingID, from, to)
+		if err != nil {
+			return err
+		}
+		for i := range metrics {
+			if err := emit(&metrics[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return ErrInvalidAnalyticsExport
+	}
+}
+
+// GenerateExport writes a queued export into storage. On errors the export
+// stays queued for the job's retry unless this was its last attempt.
+func (s *AnalyticsExportService) GenerateExport(ctx context.Context, payload AnalyticsExportJob, lastAttempt bool) error {
+	job, err := s.exportJobRepo.GetByID(ctx, payload.ExportJobID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return PermanentJobError(ErrExportJobNotFound)
+		}
+		return err
+	}
+	if job.Status != models.ExportJobQueued {
+		// Generated by an earlier attempt
+		return nil
+	}
+
+	records, err := s.generateFile(ctx, job, payload)
+	if err != nil {
+		var permanent *permanentJobError
+		if lastAttempt || errors.As(err, &permanent) {
+			finishExportJob(ctx, s.exportJobRepo, s.logger, job, records, err)
+		}
+		return err
+	}
+
+	finishExportJob(ctx, s.exportJobRepo, s.logger, job, records, nil)
+	return nil
+}
+
+// generateFile writes the export to a temporary file, uploads it and records
+// its storage key
+func (s *AnalyticsExportService) generateFile(ctx context.Context, job *models.ExportJob, payload AnalyticsExportJob) (int, error) {
+	req := payload.Request
+	layout, ok := analyticsExportLayouts[req.Type]
+	if !ok {
+		return 0, PermanentJobError(ErrInvalidAnalyticsExport)
+	}
+
+	file, err := os.CreateTemp("", "analytics-export-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	contentType := "application/json; charset=utf-8"
+	writer := utils.NewJSONRecordWriter(file)
+	if req.Format == AnalyticsExportCSV {
+		contentType = "text/csv; charset=utf-8"
+		writer = utils.NewCSVRecordWriter(file, layout.Header, layout.Row)
+	}
+
+	records := 0
+	err = s.StreamExport(ctx, payload.WeddingID, req, func(record interface{}) error {
+		if err := writer.WriteRecord(record); err != nil {
+			return err
+		}
+		records++
+		return nil
+	})
+	if err != nil {
+		return records, fmt.Errorf("failed to export analytics: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return records, fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return records, fmt.Errorf("failed to size export file: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return records, fmt.Errorf("failed to rewind export file: %w", err)
+	}
+
+	// The random part keeps download links unguessable where storage is public
+	token, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return records, err
+	}
+	key := fmt.Sprintf("exports/analytics/%s/%s-%s.%s", payload.WeddingID.String(), job.ID.String(), token, req.Format)
+	if _, err := s.storage.UploadStream(ctx, key, file, contentType, size, nil); err != nil {
+		return records, fmt.Errorf("failed to store export: %w", err)
+	}
+
+	if err := s.exportJobRepo.SetFile(ctx, job.ID, key); err != nil {
+		return records, err
+	}
+	return records, nil
+}
+
+// DownloadURL returns a short-lived link to an export generated in the background
+func (s *AnalyticsExportService) DownloadURL(ctx context.Context, weddingID, userID, jobID models.ID) (string, error) {
+	if err := s.verifyOwnership(ctx, weddingID, userID); err != nil {
+		return "", err
+	}
+
+	job, err := s.exportJobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", ErrExportJobNotFound
+		}
+		return "", err
+	}
+	if job.WeddingID != weddingID {
+		return "", ErrExportJobNotFound
+	}
+	if !job.HasFile() {
+		return "", ErrExportNotReady
+	}
+
+	return s.storage.GetPresignedURL(ctx, job.FileKey, analyticsExportLinkExpiry)
+}
+
+func (s *AnalyticsExportService) createJob(ctx context.Context, weddingID, userID models.ID, req AnalyticsExportRequest, status models.ExportJobStatus) (*models.ExportJob, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.verifyOwnership(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	job := &models.ExportJob{
+		WeddingID: weddingID,
+		UserID:    userID,
+		Resource:  req.Resource(),
+		Format:    req.Format,
+		Status:    status,
+	}
+	if err := s.exportJobRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to record export job: %w", err)
+	}
+	return job, nil
+}
+
+// verifyOwnership checks the user owns the wedding; raw analytics are not
+// shared with collaborators
+func (s *AnalyticsExportService) verifyOwnership(ctx context.Context, weddingID, userID models.ID) error {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWeddingNotFound
+		}
+		return fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding.UserID != userID {
+		return ErrUnauthorized
+	}
+	return nil
+}
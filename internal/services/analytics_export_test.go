@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func TestAnalyticsExportRequest(t *testing.T) {
+	day := func(date string) time.Time {
+		parsed, err := time.Parse("2006-01-02", date)
+		require.NoError(t, err)
+		return parsed
+	}
+
+	t.Run("Validate", func(t *testing.T) {
+		valid := AnalyticsExportRequest{Type: AnalyticsExportPageViews, Format: AnalyticsExportCSV, From: day("2024-05-01"), To: day("2024-05-31")}
+		assert.NoError(t, valid.Validate())
+
+		invalidType := valid
+		invalidType.Type = "guests"
+		assert.ErrorIs(t, invalidType.Validate(), ErrInvalidAnalyticsExport)
+
+		invalidFormat := valid
+		invalidFormat.Format = "xlsx"
+		assert.ErrorIs(t, invalidFormat.Validate(), ErrInvalidAnalyticsExport)
+
+		reversed := valid
+		reversed.From, reversed.To = valid.To, valid.From
+		assert.ErrorIs(t, reversed.Validate(), ErrInvalidDateRange)
+	})
+
+	t.Run("IsLarge", func(t *testing.T) {
+		month := AnalyticsExportRequest{Type: AnalyticsExportRSVP, From: day("2024-05-01"), To: day("2024-05-31")}
+		assert.False(t, month.IsLarge())
+
+		longer := month
+		longer.To = day("2024-06-01")
+		assert.True(t, longer.IsLarge())
+
+		// Daily metrics stay small whatever the range
+		summary := longer
+		summary.Type = AnalyticsExportSummary
+		assert.False(t, summary.IsLarge())
+	})
+}
+
+func setupAnalyticsExportService(t *testing.T) (*AnalyticsExportService, *MockAnalyticsRepository, *MockExportJobRepository, *MockStorageService, *JobQueue, *models.Wedding) {
+	logger := zaptest.NewLogger(t)
+	wedding := &models.Wedding{ID: models.NewID(), UserID: models.NewID()}
+	wedding.Collaborators = []models.WeddingCollaborator{{UserID: models.NewID(), Role: models.WeddingRoleCollaborator}}
+
+	analyticsRepo := &MockAnalyticsRepository{}
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+	exportJobs := NewMockExportJobRepository()
+	storage := &MockStorageService{}
+	queue := NewJobQueue(NewMockJobRepository(), JobQueueOptions{}, logger)
+
+	analytics := NewAnalyticsService(analyticsRepo, weddingRepo, logger)
+	service := NewAnalyticsExportService(analyticsRepo, weddingRepo, exportJobs, analytics, storage, queue, logger)
+	RegisterJobHandlers(queue, nil, analytics, service, nil, nil, nil, nil, nil, nil)
+	return service, analyticsRepo, exportJobs, storage, queue, wedding
+}
+
+func TestAnalyticsExportService_StreamExport(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Only the owner can export", func(t *testing.T) {
+		service, _, _, _, _, wedding := setupAnalyticsExportService(t)
+		req := AnalyticsExportRequest{Type: AnalyticsExportPageViews, Format: AnalyticsExportJSON, From: from, To: to}
+
+		_, err := service.StartExport(ctx, wedding.ID, wedding.Collaborators[0].UserID, req)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+
+		job, err := service.StartExport(ctx, wedding.ID, wedding.UserID, req)
+		require.NoError(t, err)
+		assert.Equal(t, models.ExportJobRunning, job.Status)
+		assert.Equal(t, "analytics_page_views", job.Resource)
+	})
+
+	t.Run("Raw events of the whole last day", func(t *testing.T) {
+		service, analyticsRepo, _, _, _, wedding := setupAnalyticsExportService(t)
+		pageView := &models.PageView{ID: models.NewID(), SessionID: "s1", Page: "rsvp", Timestamp: to.Add(23 * time.Hour), Country: "ID"}
+		analyticsRepo.On("StreamPageViews", mock.Anything, wedding.ID, from, to.AddDate(0, 0, 1), mock.Anything).Return([]*models.PageView{pageView}, nil)
+
+		var records []interface{}
+		err := service.StreamExport(ctx, wedding.ID, AnalyticsExportRequest{Type: AnalyticsExportPageViews, Format: AnalyticsExportCSV, From: from, To: to}, func(record interface{}) error {
+			records = append(records, record)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+
+		layout, ok := AnalyticsExportLayoutOf(AnalyticsExportPageViews)
+		require.True(t, ok)
+		row := layout.Row(records[0])
+		assert.Len(t, row, len(layout.Header))
+		assert.Equal(t, "rsvp", row[2])
+		assert.Equal(t, "2024-05-03T23:00:00Z", row[3])
+		assert.Equal(t, "ID", row[8])
+	})
+
+	t.Run("Summary of every day", func(t *testing.T) {
+		service, analyticsRepo, _, _, _, wedding := setupAnalyticsExportService(t)
+		analyticsRepo.On("GetDailyMetrics", mock.Anything, wedding.ID, from, to).Return([]models.DailyMetrics{{Date: "2024-05-02", PageViews: 4}}, nil)
+
+		var dates []string
+		err := service.StreamExport(ctx, wedding.ID, AnalyticsExportRequest{Type: AnalyticsExportSummary, Format: AnalyticsExportJSON, From: from, To: to}, func(record interface{}) error {
+			dates = append(dates, record.(*models.DailyMetrics).Date)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2024-05-01", "2024-05-02", "2024-05-03"}, dates)
+	})
+}
+
+func TestAnalyticsExportService_QueueExport(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	req := AnalyticsExportRequest{Type: AnalyticsExportConversions, Format: AnalyticsExportCSV, From: from, To: to}
+
+	t.Run("Generated in the background and downloaded by link", func(t *testing.T) {
+		service, analyticsRepo, exportJobs, storage, queue, wedding := setupAnalyticsExportService(t)
+		analyticsRepo.On("StreamConversions", mock.Anything, wedding.ID, from, to.AddDate(0, 0, 1), mock.Anything).Return([]*models.ConversionEvent{
+			{ID: models.NewID(), SessionID: "s1", Event: "rsvp_completed", Timestamp: from},
+			{ID: models.NewID(), SessionID: "s2", Event: "share_clicked", Timestamp: to},
+		}, nil)
+
+		var key, uploaded string
+		storage.On("UploadStream", mock.Anything, mock.Anything, mock.Anything, "text/csv; charset=utf-8", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				key = args.String(1)
+				data, err := io.ReadAll(args.Get(2).(io.Reader))
+				require.NoError(t, err)
+				uploaded = string(data)
+				assert.Equal(t, int64(len(data)), args.Get(4))
+			}).
+			Return("https://cdn.example.com/export.csv", nil)
+
+		job, err := service.QueueExport(ctx, wedding.ID, wedding.UserID, req)
+		require.NoError(t, err)
+		assert.Equal(t, models.ExportJobQueued, job.Status)
+
+		_, err = service.DownloadURL(ctx, wedding.ID, wedding.UserID, job.ID)
+		assert.ErrorIs(t, err, ErrExportNotReady)
+
+		claimed, err := queue.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+
+		stored := exportJobs.jobs[job.ID]
+		assert.Equal(t, models.ExportJobCompleted, stored.Status)
+		assert.Equal(t, 2, stored.Records)
+		assert.Equal(t, key, stored.FileKey)
+		assert.True(t, strings.HasPrefix(key, "exports/analytics/"+wedding.ID.String()+"/"+job.ID.String()+"-"))
+		assert.True(t, strings.HasSuffix(key, ".csv"))
+
+		lines := strings.Split(strings.TrimSpace(uploaded), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "id,session_id,event,value,currency,timestamp", lines[0])
+		assert.Contains(t, lines[2], "share_clicked")
+
+		storage.On("GetPresignedURL", mock.Anything, key, analyticsExportLinkExpiry).Return("https://cdn.example.com/signed", nil)
+		url, err := service.DownloadURL(ctx, wedding.ID, wedding.UserID, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "https://cdn.example.com/signed", url)
+
+		_, err = service.DownloadURL(ctx, wedding.ID, wedding.Collaborators[0].UserID, job.ID)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Failed on the last attempt", func(t *testing.T) {
+		service, analyticsRepo, exportJobs, _, _, wedding := setupAnalyticsExportService(t)
+		analyticsRepo.On("StreamConversions", mock.Anything, wedding.ID, mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+		job, err := service.QueueExport(ctx, wedding.ID, wedding.UserID, req)
+		require.NoError(t, err)
+		payload := AnalyticsExportJob{ExportJobID: job.ID, WeddingID: wedding.ID, Request: req}
+
+		// Earlier attempts leave the export queued for the retry
+		assert.Error(t, service.GenerateExport(ctx, payload, false))
+		assert.Equal(t, models.ExportJobQueued, exportJobs.jobs[job.ID].Status)
+
+		assert.Error(t, service.GenerateExport(ctx, payload, true))
+		assert.Equal(t, models.ExportJobFailed, exportJobs.jobs[job.ID].Status)
+	})
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/tracing"
+)
+
+// GetRSVPFunnel reports how far the sessions of a wedding's visitors got in the
+// RSVP flow between two days, overall and by device. A session counts toward
+// every step up to the furthest one its events show it reached: the abandoned
+// form step or completion. Raw events are kept for 90 days, so older days are
+// empty.
+func (s *analyticsService) GetRSVPFunnel(ctx context.Context, weddingID models.ID, startDate, endDate time.Time) (_ *models.RSVPFunnel, err error) {
+	start := startDate.UTC().Truncate(24 * time.Hour)
+	end := endDate.UTC().Truncate(24 * time.Hour)
+	ctx, span := tracing.Start(ctx, "analytics.funnel",
+		attribute.String("wedding.id", weddingID.String()),
+		attribute.String("analytics.start", start.Format("2006-01-02")),
+		attribute.String("analytics.end", end.Format("2006-01-02")))
+	defer func() { tracing.End(span, err) }()
+	if end.Before(start) || end.Sub(start) >= maxDailyMetricsDays*24*time.Hour {
+		return nil, ErrInvalidDateRange
+	}
+
+	sessions, err := s.analyticsRepo.GetRSVPFunnelSessions(ctx, weddingID, start, end.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	steps := models.RSVPFunnelSteps()
+	total := make([]int64, len(steps))
+	byDevice := make(map[string][]int64)
+	for _, session := range sessions {
+		reached := funnelReach(session)
+		if reached < 0 {
+			continue
+		}
+		device := session.Device
+		if device == "" {
+			device = "unknown"
+		}
+		if byDevice[device] == nil {
+			byDevice[device] = make([]int64, len(steps))
+		}
+		for i := 0; i <= reached; i++ {
+			total[i]++
+			byDevice[device][i]++
+		}
+	}
+
+	funnel := &models.RSVPFunnel{
+		StartDate: start.Format("2006-01-02"),
+		EndDate:   end.Format("2006-01-02"),
+		Steps:     funnelSteps(steps, total),
+		Devices:   make([]models.RSVPFunnelDevice, 0, len(byDevice)),
+	}
+	for device, counts := range byDevice {
+		funnel.Devices = append(funnel.Devices, models.RSVPFunnelDevice{Device: device, Steps: funnelSteps(steps, counts)})
+	}
+	sort.Slice(funnel.Devices, func(i, j int) bool {
+		a, b := funnel.Devices[i], funnel.Devices[j]
+		if a.Steps[0].Sessions != b.Steps[0].Sessions {
+			return a.Steps[0].Sessions > b.Steps[0].Sessions
+		}
+		return a.Device < b.Device
+	})
+	return funnel, nil
+}
+
+// funnelReach returns the index in models.RSVPFunnelSteps of the furthest step a
+// session reached, or -1 for a session that never viewed the invitation nor
+// took part in the RSVP flow
+func funnelReach(session models.RSVPFunnelSession) int {
+	formStart := 2
+	if session.Completed {
+		return formStart + len(models.RSVPFormSteps)
+	}
+	reached := -1
+	if session.ViewedInvitation {
+		reached = 0
+	}
+	if session.StartedRSVP {
+		reached = 1
+	}
+	for _, abandoned := range session.AbandonedSteps {
+		for i, step := range models.RSVPFormSteps {
+			if step == abandoned && formStart+i > reached {
+				reached = formStart + i
+			}
+		}
+	}
+	return reached
+}
+
+// funnelSteps derives the drop-off between steps from how many sessions
+// reached each
+func funnelSteps(steps []string, counts []int64) []models.RSVPFunnelStep {
+	result := make([]models.RSVPFunnelStep, len(steps))
+	for i, step := range steps {
+		result[i] = models.RSVPFunnelStep{Step: step, Sessions: counts[i]}
+		if counts[0] > 0 {
+			result[i].ConversionRate = float64(counts[i]) / float64(counts[0]) * 100
+		}
+		if i > 0 {
+			result[i].DropOff = counts[i-1] - counts[i]
+			if counts[i-1] > 0 {
+				result[i].DropOffRate = float64(result[i].DropOff) / float64(counts[i-1]) * 100
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func TestAnalyticsService_GetRSVPFunnel(t *testing.T) {
+	ctx := context.Background()
+	weddingID := models.NewID()
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Counts sessions up to the furthest step they reached", func(t *testing.T) {
+		analyticsRepo := &MockAnalyticsRepository{}
+		service := NewAnalyticsService(analyticsRepo, &MockWeddingRepository{}, zaptest.NewLogger(t))
+		analyticsRepo.On("GetRSVPFunnelSessions", mock.Anything, weddingID, start, end.AddDate(0, 0, 1)).Return([]models.RSVPFunnelSession{
+			{SessionID: "viewer", Device: "mobile", ViewedInvitation: true},
+			{SessionID: "starter", Device: "mobile", ViewedInvitation: true, StartedRSVP: true},
+			{SessionID: "quitter", Device: "desktop", ViewedInvitation: true, StartedRSVP: true, AbandonedSteps: []string{"personal_info", "guest_count"}},
+			{SessionID: "guest", Device: "mobile", ViewedInvitation: true, StartedRSVP: true, Completed: true},
+			// Came straight to the form from a link, without a tracked view
+			{SessionID: "direct", Device: "", AbandonedSteps: []string{"attending_status"}},
+			// Did nothing the funnel knows of
+			{SessionID: "other"},
+		}, nil)
+
+		funnel, err := service.GetRSVPFunnel(ctx, weddingID, start, end)
+		require.NoError(t, err)
+
+		assert.Equal(t, "2024-05-01", funnel.StartDate)
+		assert.Equal(t, "2024-05-31", funnel.EndDate)
+
+		sessions := make(map[string]int64)
+		for _, step := range funnel.Steps {
+			sessions[step.Step] = step.Sessions
+		}
+		assert.Equal(t, map[string]int64{
+			"viewed_invitation":    5,
+			"started_rsvp":         4,
+			"personal_info":        3,
+			"attending_status":     3,
+			"guest_count":          2,
+			"dietary_restrictions": 1,
+			"confirmation":         1,
+			"completed":            1,
+		}, sessions)
+
+		started := funnel.Steps[1]
+		assert.Equal(t, int64(1), started.DropOff)
+		assert.InDelta(t, 20, started.DropOffRate, 0.001)
+		assert.InDelta(t, 80, started.ConversionRate, 0.001)
+		assert.InDelta(t, 20, funnel.Steps[len(funnel.Steps)-1].ConversionRate, 0.001)
+
+		require.Len(t, funnel.Devices, 3)
+		assert.Equal(t, "mobile", funnel.Devices[0].Device)
+		assert.Equal(t, int64(3), funnel.Devices[0].Steps[0].Sessions)
+		assert.Equal(t, int64(1), funnel.Devices[0].Steps[len(funnel.Steps)-1].Sessions)
+		assert.Equal(t, "desktop", funnel.Devices[1].Device)
+		assert.Equal(t, "unknown", funnel.Devices[2].Device)
+	})
+
+	t.Run("Rejects an invalid range", func(t *testing.T) {
+		service := NewAnalyticsService(&MockAnalyticsRepository{}, &MockWeddingRepository{}, zaptest.NewLogger(t))
+
+		_, err := service.GetRSVPFunnel(ctx, weddingID, end, start)
+		assert.ErrorIs(t, err, ErrInvalidDateRange)
+	})
+}
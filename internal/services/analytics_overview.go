@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/tracing"
+)
+
+const (
+	// minBenchmarkPageViews is how many page views a wedding needs to count
+	// toward the platform benchmarks; rates of fewer views are noise
+	minBenchmarkPageViews = 50
+	// minBenchmarkWeddings is how many weddings the benchmarks must cover before
+	// they are shown, so they never reveal the figures of a few weddings
+	minBenchmarkWeddings = 10
+	// userOverviewPageSize is how many weddings are listed per page for an overview
+	userOverviewPageSize = 100
+	// userOverviewTopSources is how many traffic sources an overview lists
+	userOverviewTopSources = 5
+)
+
+// GetUserOverview aggregates the analytics of every wedding the user may view
+// the analytics of and compares them with the platform benchmarks
+func (s *analyticsService) GetUserOverview(ctx context.Context, userID models.ID) (_ *models.UserAnalyticsOverview, err error) {
+	ctx, span := tracing.Start(ctx, "analytics.user_overview", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	var weddings []*models.Wedding
+	for page := 1; ; page++ {
+		batch, total, err := s.weddingRepo.GetByUserID(ctx, userID, page, userOverviewPageSize, repository.WeddingFilters{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list weddings: %w", err)
+		}
+		for _, wedding := range batch {
+			if wedding.Can(userID, models.PermissionViewAnalytics) {
+				weddings = append(weddings, wedding)
+			}
+		}
+		if len(batch) == 0 || int64(page*userOverviewPageSize) >= total {
+			break
+		}
+	}
+
+	overview := &models.UserAnalyticsOverview{
+		Weddings:   make([]models.WeddingAnalyticsOverview, 0, len(weddings)),
+		TopSources: []models.SourceStats{},
+		Benchmarks: []models.BenchmarkComparison{},
+	}
+	if len(weddings) == 0 {
+		return overview, nil
+	}
+
+	weddingIDs := make([]models.ID, len(weddings))
+	for i, wedding := range weddings {
+		weddingIDs[i] = wedding.ID
+	}
+	counters, err := s.analyticsRepo.ListWeddingAnalytics(ctx, weddingIDs)
+	if err != nil {
+		return nil, err
+	}
+	byWedding := make(map[models.ID]*models.WeddingAnalytics, len(counters))
+	for _, analytics := range counters {
+		byWedding[analytics.WeddingID] = analytics
+	}
+
+	var bounced, duration, timedViews int64
+	sources := make(map[string]int64)
+	for _, wedding := range weddings {
+		row := models.WeddingAnalyticsOverview{
+			WeddingID: wedding.ID,
+			Title:     wedding.Title,
+			Slug:      wedding.Slug,
+			Status:    wedding.Status,
+		}
+		if analytics := byWedding[wedding.ID]; analytics != nil {
+			row.PageViews = analytics.PageViews
+			row.UniqueSessions = analytics.UniqueSessions
+			row.RSVPCount = analytics.RSVPCount
+			row.ConversionRate = analytics.ConversionRate
+			row.BounceRate = analytics.BounceRate
+			row.AverageTimeOnPage = analytics.AverageTimeOnPage
+
+			overview.PageViews += analytics.PageViews
+			overview.UniqueSessions += analytics.UniqueSessions
+			overview.RSVPCount += analytics.RSVPCount
+			overview.CompletedRSVPs += analytics.CompletedRSVPs
+			bounced += analytics.BouncedSessions
+			duration += analytics.TotalDuration
+			timedViews += analytics.TimedPageViews
+			for source, count := range analytics.TrafficSources {
+				sources[source] += count
+			}
+		}
+		overview.Weddings = append(overview.Weddings, row)
+	}
+	sort.SliceStable(overview.Weddings, func(i, j int) bool {
+		return overview.Weddings[i].PageViews > overview.Weddings[j].PageViews
+	})
+
+	if overview.PageViews > 0 {
+		overview.ConversionRate = float64(overview.RSVPCount) / float64(overview.PageViews) * 100
+	}
+	if overview.UniqueSessions > 0 {
+		overview.BounceRate = float64(bounced) / float64(overview.UniqueSessions) * 100
+	}
+	if timedViews > 0 {
+		overview.AverageTimeOnPage = float64(duration) / float64(timedViews)
+	}
+
+	for source, sessions := range sources {
+		overview.TopSources = append(overview.TopSources, models.SourceStats{Source: source, Sessions: sessions})
+	}
+	sort.Slice(overview.TopSources, func(i, j int) bool {
+		a, b := overview.TopSources[i], overview.TopSources[j]
+		if a.Sessions != b.Sessions {
+			return a.Sessions > b.Sessions
+		}
+		return a.Source < b.Source
+	})
+	if len(overview.TopSources) > userOverviewTopSources {
+		overview.TopSources = overview.TopSources[:userOverviewTopSources]
+	}
+
+	// The overview is still useful without benchmarks, so failing to read
+	// them is not an error
+	benchmarks, err := s.analyticsRepo.GetAnalyticsBenchmarks(ctx)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			s.logger.Warn("Failed to get analytics benchmarks", zap.Error(err))
+		}
+		return overview, nil
+	}
+	if benchmarks.Weddings < minBenchmarkWeddings {
+		return overview, nil
+	}
+	values := map[string]float64{
+		models.BenchmarkConversionRate:    overview.ConversionRate,
+		models.BenchmarkBounceRate:        overview.BounceRate,
+		models.BenchmarkAverageTimeOnPage: overview.AverageTimeOnPage,
+		models.BenchmarkPageViews:         float64(overview.PageViews) / float64(len(weddings)),
+	}
+	for _, metric := range []string{models.BenchmarkConversionRate, models.BenchmarkBounceRate, models.BenchmarkAverageTimeOnPage, models.BenchmarkPageViews} {
+		stat, ok := benchmarks.Metrics[metric]
+		if !ok {
+			continue
+		}
+		comparison := models.BenchmarkComparison{
+			Metric:  metric,
+			Value:   values[metric],
+			Average: stat.Average,
+			Median:  stat.Median,
+		}
+		if stat.Average > 0 {
+			comparison.Difference = (comparison.Value - stat.Average) / stat.Average * 100
+		}
+		overview.Benchmarks = append(overview.Benchmarks, comparison)
+	}
+	overview.BenchmarksComputedAt = &benchmarks.ComputedAt
+	return overview, nil
+}
+
+// AnalyticsBenchmarkScheduler periodically recomputes the platform benchmarks
+// user overviews compare their weddings with
+type AnalyticsBenchmarkScheduler struct {
+	analyticsRepo repository.AnalyticsRepository
+	interval      time.Duration
+	logger        *zap.Logger
+	stop          chan struct{}
+	wg            sync.WaitGroup
+}
+
+// NewAnalyticsBenchmarkScheduler creates a scheduler that recomputes the benchmarks every interval
+func NewAnalyticsBenchmarkScheduler(analyticsRepo repository.AnalyticsRepository, interval time.Duration, logger *zap.Logger) *AnalyticsBenchmarkScheduler {
+	return &AnalyticsBenchmarkScheduler{
+		analyticsRepo: analyticsRepo,
+		interval:      interval,
+		logger:        logger,
+		stop:          make(chan struct{}),
+	}
+}
+
+// Compute recomputes and stores the platform benchmarks
+func (sch *AnalyticsBenchmarkScheduler) Compute(ctx context.Context) (*models.AnalyticsBenchmarks, error) {
+	benchmarks, err := sch.analyticsRepo.UpdateAnalyticsBenchmarks(ctx, minBenchmarkPageViews)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update analytics benchmarks: %w", err)
+	}
+	return benchmarks, nil
+}
+
+// Start runs the scheduler loop in the background. The benchmarks are
+// computed right away as well, so a fresh deployment does not wait a whole
+// interval for them.
+func (sch *AnalyticsBenchmarkScheduler) Start(ctx context.Context) {
+	sch.wg.Add(1)
+	go func() {
+		defer sch.wg.Done()
+		ticker := time.NewTicker(sch.interval)
+		defer ticker.Stop()
+
+		sch.run(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sch.stop:
+				return
+			case <-ticker.C:
+				sch.run(ctx)
+			}
+		}
+	}()
+}
+
+func (sch *AnalyticsBenchmarkScheduler) run(ctx context.Context) {
+	benchmarks, err := sch.Compute(ctx)
+	if err != nil {
+		sch.logger.Error("Analytics benchmark run failed", zap.Error(err))
+		return
+	}
+	sch.logger.Info("Analytics benchmarks computed", zap.Int64("weddings", benchmarks.Weddings))
+}
+
+// Stop signals the scheduler loop to exit and waits for it
+func (sch *AnalyticsBenchmarkScheduler) Stop() {
+	close(sch.stop)
+	sch.wg.Wait()
+}
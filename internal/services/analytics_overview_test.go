@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+func TestAnalyticsService_GetUserOverview(t *testing.T) {
+	ctx := context.Background()
+	userID := models.NewID()
+	popular := &models.Wedding{ID: models.NewID(), UserID: userID, Title: "Popular", Status: string(models.WeddingStatusPublished)}
+	quiet := &models.Wedding{ID: models.NewID(), UserID: userID, Title: "Quiet", Status: string(models.WeddingStatusPublished)}
+	shared := &models.Wedding{ID: models.NewID(), UserID: models.NewID(), Title: "Shared", Status: string(models.WeddingStatusDraft),
+		Collaborators: []models.WeddingCollaborator{{UserID: userID, Role: models.WeddingRolePlanner}}}
+	weddingIDs := []models.ID{quiet.ID, popular.ID, shared.ID}
+
+	setup := func(t *testing.T, benchmarks *models.AnalyticsBenchmarks, benchmarksErr error) AnalyticsService {
+		weddingRepo := &MockWeddingRepository{}
+		weddingRepo.On("GetByUserID", mock.Anything, userID, 1, userOverviewPageSize, repository.WeddingFilters{}).
+			Return([]*models.Wedding{quiet, popular, shared}, int64(3), nil)
+		analyticsRepo := &MockAnalyticsRepository{}
+		analyticsRepo.On("ListWeddingAnalytics", mock.Anything, weddingIDs).Return([]*models.WeddingAnalytics{
+			{WeddingID: popular.ID, PageViews: 300, UniqueSessions: 100, RSVPCount: 12, CompletedRSVPs: 10, BouncedSessions: 40,
+				TotalDuration: 900, TimedPageViews: 30, ConversionRate: 4,
+				TrafficSources: map[string]int64{"whatsapp": 60, "direct": 30, "instagram": 10}},
+			{WeddingID: quiet.ID, PageViews: 100, UniqueSessions: 50, RSVPCount: 8, CompletedRSVPs: 8, BouncedSessions: 20,
+				TotalDuration: 300, TimedPageViews: 10, ConversionRate: 8,
+				TrafficSources: map[string]int64{"direct": 40, "email": 5, "facebook": 3, "twitter": 2}},
+		}, nil)
+		analyticsRepo.On("GetAnalyticsBenchmarks", mock.Anything).Return(benchmarks, benchmarksErr)
+		return NewAnalyticsService(analyticsRepo, weddingRepo, zaptest.NewLogger(t))
+	}
+
+	t.Run("Aggregates the user's weddings and compares them with the platform", func(t *testing.T) {
+		computedAt := time.Now().Add(-time.Hour)
+		service := setup(t, &models.AnalyticsBenchmarks{
+			Weddings: 40,
+			Metrics: map[string]models.BenchmarkStat{
+				models.BenchmarkConversionRate: {Average: 4, Median: 3},
+				models.BenchmarkBounceRate:     {Average: 50, Median: 45},
+			},
+			ComputedAt: computedAt,
+		}, nil)
+
+		overview, err := service.GetUserOverview(ctx, userID)
+		require.NoError(t, err)
+
+		require.Len(t, overview.Weddings, 3)
+		assert.Equal(t, "Popular", overview.Weddings[0].Title)
+		assert.Equal(t, "Quiet", overview.Weddings[1].Title)
+		assert.Equal(t, "Shared", overview.Weddings[2].Title)
+		assert.Equal(t, int64(0), overview.Weddings[2].PageViews)
+
+		assert.Equal(t, int64(400), overview.PageViews)
+		assert.Equal(t, int64(150), overview.UniqueSessions)
+		assert.Equal(t, int64(20), overview.RSVPCount)
+		assert.Equal(t, int64(18), overview.CompletedRSVPs)
+		assert.InDelta(t, 5, overview.ConversionRate, 0.001)
+		assert.InDelta(t, 40, overview.BounceRate, 0.001)
+		assert.InDelta(t, 30, overview.AverageTimeOnPage, 0.001)
+
+		assert.Equal(t, []models.SourceStats{
+			{Source: "direct", Sessions: 70},
+			{Source: "whatsapp", Sessions: 60},
+			{Source: "instagram", Sessions: 10},
+			{Source: "email", Sessions: 5},
+			{Source: "facebook", Sessions: 3},
+		}, overview.TopSources)
+
+		require.Len(t, overview.Benchmarks, 2)
+		assert.Equal(t, models.BenchmarkConversionRate, overview.Benchmarks[0].Metric)
+		assert.InDelta(t, 25, overview.Benchmarks[0].Difference, 0.001)
+		assert.Equal(t, models.BenchmarkBounceRate, overview.Benchmarks[1].Metric)
+		assert.InDelta(t, -20, overview.Benchmarks[1].Difference, 0.001)
+		require.NotNil(t, overview.BenchmarksComputedAt)
+		assert.Equal(t, computedAt, *overview.BenchmarksComputedAt)
+	})
+
+	t.Run("Hides benchmarks covering too few weddings", func(t *testing.T) {
+		service := setup(t, &models.AnalyticsBenchmarks{
+			Weddings: minBenchmarkWeddings - 1,
+			Metrics:  map[string]models.BenchmarkStat{models.BenchmarkConversionRate: {Average: 4, Median: 3}},
+		}, nil)
+
+		overview, err := service.GetUserOverview(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, overview.Benchmarks)
+		assert.Nil(t, overview.BenchmarksComputedAt)
+	})
+
+	t.Run("Still returns the overview without benchmarks", func(t *testing.T) {
+		for _, benchmarksErr := range []error{repository.ErrNotFound, errors.New("connection reset")} {
+			service := setup(t, nil, benchmarksErr)
+
+			overview, err := service.GetUserOverview(ctx, userID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(400), overview.PageViews)
+			assert.Empty(t, overview.Benchmarks)
+		}
+	})
+
+	t.Run("User without weddings", func(t *testing.T) {
+		weddingRepo := &MockWeddingRepository{}
+		weddingRepo.On("GetByUserID", mock.Anything, userID, 1, userOverviewPageSize, repository.WeddingFilters{}).
+			Return([]*models.Wedding{}, int64(0), nil)
+		service := NewAnalyticsService(&MockAnalyticsRepository{}, weddingRepo, zaptest.NewLogger(t))
+
+		overview, err := service.GetUserOverview(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, overview.Weddings)
+		assert.Equal(t, int64(0), overview.PageViews)
+	})
+}
+
+func TestAnalyticsBenchmarkScheduler_Compute(t *testing.T) {
+	analyticsRepo := &MockAnalyticsRepository{}
+	analyticsRepo.On("UpdateAnalyticsBenchmarks", mock.Anything, int64(minBenchmarkPageViews)).
+		Return(&models.AnalyticsBenchmarks{Weddings: 12}, nil)
+	scheduler := NewAnalyticsBenchmarkScheduler(analyticsRepo, time.Hour, zaptest.NewLogger(t))
+
+	benchmarks, err := scheduler.Compute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), benchmarks.Weddings)
+	analyticsRepo.AssertExpectations(t)
+}
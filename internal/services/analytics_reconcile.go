@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// analyticsReconcileBatch is how many weddings are listed per page when queueing reconciliations
+const analyticsReconcileBatch = 500
+
+// AnalyticsReconcileScheduler periodically queues a reconciliation of the
+// analytics counters of every wedding that tracked events since its last one.
+// Tracking keeps the counters current; reconciliation repairs the drift left by
+// failed or concurrent counter updates.
+type AnalyticsReconcileScheduler struct {
+	analyticsRepo repository.AnalyticsRepository
+	jobs          JobEnqueuer
+	interval      time.Duration
+	logger        *zap.Logger
+	stop          chan struct{}
+	wg            sync.WaitGroup
+}
+
+// NewAnalyticsReconcileScheduler creates a scheduler that queues reconciliations every interval
+func NewAnalyticsReconcileScheduler(analyticsRepo repository.AnalyticsRepository, jobs JobEnqueuer, interval time.Duration, logger *zap.Logger) *AnalyticsReconcileScheduler {
+	return &AnalyticsReconcileScheduler{
+		analyticsRepo: analyticsRepo,
+		jobs:          jobs,
+		interval:      interval,
+		logger:        logger,
+		stop:          make(chan struct{}),
+	}
+}
+
+// ScheduleDue queues a reconciliation job for each wedding whose counters
+// changed since it was last reconciled. Weddings never reconciled, including
+// those tracked before the daily counters existed, are always due, so the
+// first run after an upgrade backfills their daily buckets. It returns the
+// number of weddings queued.
+func (sch *AnalyticsReconcileScheduler) ScheduleDue(ctx context.Context) (int, error) {
+	scheduled := 0
+	after := models.NilID
+	for {
+		weddingIDs, err := sch.analyticsRepo.ListWeddingsToReconcile(ctx, after, analyticsReconcileBatch)
+		if err != nil {
+			return scheduled, fmt.Errorf("failed to list weddings to reconcile: %w", err)
+		}
+
+		for _, weddingID := range weddingIDs {
+			if err := sch.jobs.EnqueueUnique(ctx, JobTypeReconcileAnalytics, weddingID.String(), AnalyticsReconcileJob{WeddingID: weddingID}); err != nil {
+				return scheduled, err
+			}
+			scheduled++
+		}
+
+		if len(weddingIDs) < analyticsReconcileBatch {
+			return scheduled, nil
+		}
+		after = weddingIDs[len(weddingIDs)-1]
+	}
+}
+
+// Start runs the scheduler loop in the background
+func (sch *AnalyticsReconcileScheduler) Start(ctx context.Context) {
+	sch.wg.Add(1)
+	go func() {
+		defer sch.wg.Done()
+		ticker := time.NewTicker(sch.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sch.stop:
+				return
+			case <-ticker.C:
+				scheduled, err := sch.ScheduleDue(ctx)
+				if err != nil {
+					sch.logger.Error("Analytics reconciliation run failed", zap.Error(err))
+					continue
+				}
+				if scheduled > 0 {
+					sch.logger.Info("Analytics reconciliations queued", zap.Int("count", scheduled))
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the scheduler loop to exit and waits for it
+func (sch *AnalyticsReconcileScheduler) Stop() {
+	close(sch.stop)
+	sch.wg.Wait()
+}
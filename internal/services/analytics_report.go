@@ -0,0 +1,340 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"html/template"
+	"strings"
+	"sync"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+const (
+	// analyticsReportBatchSize bounds how many digests are sent per scheduler run
+	analyticsReportBatchSize = 50
+	// analyticsReportRetryInterval is how soon a failed digest is retried
+	analyticsReportRetryInterval = time.Hour
+	// analyticsReportTopEntries is how many pages and sources a digest lists
+	analyticsReportTopEntries = 5
+)
+
+var ErrInvalidReportFrequency = errors.New("report frequency must be weekly or monthly")
+
+// AnalyticsReportService emails wedding owners a periodic digest of their wedding analytics
+type AnalyticsReportService struct {
+	reportRepo    repository.AnalyticsReportRepository
+	analyticsRepo repository.AnalyticsRepository
+	weddingRepo   repository.WeddingRepository
+	userRepo      repository.UserRepository
+	email         EmailService
+	logger        *zap.Logger
+}
+
+// NewAnalyticsReportService creates a new analytics report service
+func NewAnalyticsReportService(
+	reportRepo repository.AnalyticsReportRepository,
+	analyticsRepo repository.AnalyticsRepository,
+	weddingRepo repository.WeddingRepository,
+	userRepo repository.UserRepository,
+	email EmailService,
+	logger *zap.Logger,
+) *AnalyticsReportService {
+	return &AnalyticsReportService{
+		reportRepo:    reportRepo,
+		analyticsRepo: analyticsRepo,
+		weddingRepo:   weddingRepo,
+		userRepo:      userRepo,
+		email:         email,
+		logger:        logger,
+	}
+}
+
+// UpdateReportSettingsRequest represents changes to a wedding's digest settings
+type UpdateReportSettingsRequest struct {
+	Enabled   *bool   `json:"enabled,omitempty"`
+	Frequency *string `json:"frequency,omitempty" example:"weekly"`
+}
+
+// GetSettings returns the digest settings of a wedding owned by the user; weddings
+// that never opted in get disabled weekly defaults
+func (s *AnalyticsReportService) GetSettings(ctx context.Context, weddingID, userID models.ID) (*models.AnalyticsReportSettings, error) {
+	wedding, err := s.getOwnedWedding(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.loadSettings(ctx, wedding)
+}
+
+// UpdateSettings opts a wedding in or out of digests or changes their frequency
+func (s *AnalyticsReportService) UpdateSettings(ctx context.Context, weddingID, userID models.ID, req UpdateReportSettingsRequest) (*models.AnalyticsReportSettings, error) {
+	wedding, err := s.getOwnedWedding(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.loadSettings(ctx, wedding)
+	if err != nil {
+		return nil, err
+	}
+
+	reschedule := false
+	if req.Frequency != nil {
+		frequency := models.AnalyticsReportFrequency(*req.Frequency)
+		if !frequency.IsValid() {
+			return nil, ErrInvalidReportFrequency
+		}
+		reschedule = frequency != settings.Frequency
+		settings.Frequency = frequency
+	}
+	if req.Enabled != nil {
+		reschedule = reschedule || (*req.Enabled && !settings.Enabled)
+		settings.Enabled = *req.Enabled
+	}
+	if reschedule || settings.NextSendAt.IsZero() {
+		settings.NextSendAt = settings.Frequency.NextRun(time.Now())
+	}
+	settings.UserID = userID
+
+	if err := s.reportRepo.Upsert(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save report settings: %w", err)
+	}
+	return settings, nil
+}
+
+// SendDue emails every digest whose schedule has elapsed and returns how many were sent
+func (s *AnalyticsReportService) SendDue(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.reportRepo.ListDue(ctx, now, analyticsReportBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due analytics reports: %w", err)
+	}
+
+	sent := 0
+	for _, settings := range due {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+		if s.send(ctx, settings, now) {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// send builds and emails one digest, then records the attempt and schedules the next one
+func (s *AnalyticsReportService) send(ctx context.Context, settings *models.AnalyticsReportSettings, now time.Time) bool {
+	err := s.deliver(ctx, settings)
+	if errors.Is(err, repository.ErrNotFound) {
+		// The wedding is gone; stop sending rather than retrying forever
+		settings.Enabled = false
+		if err := s.reportRepo.Upsert(ctx, settings); err != nil {
+			s.logger.Error("Failed to disable analytics report", zap.Error(err), zap.String("wedding_id", settings.WeddingID.String()))
+		}
+		return false
+	}
+
+	next := settings.Frequency.NextRun(now)
+	reason := ""
+	if err != nil {
+		s.logger.Warn("Failed to send analytics report", zap.Error(err), zap.String("wedding_id", settings.WeddingID.String()))
+		next = now.Add(analyticsReportRetryInterval)
+		reason = err.Error()
+	}
+	if err := s.reportRepo.MarkSent(ctx, settings.ID, now, next, reason); err != nil {
+		s.logger.Error("Failed to reschedule analytics report", zap.Error(err), zap.String("wedding_id", settings.WeddingID.String()))
+	}
+	return err == nil
+}
+
+func (s *AnalyticsReportService) deliver(ctx context.Context, settings *models.AnalyticsReportSettings) error {
+	wedding, err := s.weddingRepo.GetByID(ctx, settings.WeddingID)
+	if err != nil {
+		return err
+	}
+
+	// Digests always go to the current owner, even if the wedding changed hands
+	owner, err := s.userRepo.GetByID(ctx, wedding.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get wedding owner: %w", err)
+	}
+
+	summary, err := s.analyticsRepo.GetAnalyticsSummary(ctx, wedding.ID, string(settings.Frequency))
+	if err != nil {
+		return fmt.Errorf("failed to get analytics summary: %w", err)
+	}
+
+	msg, err := renderAnalyticsReport(wedding, settings.Frequency, summary)
+	if err != nil {
+		return err
+	}
+	msg.To = owner.Email
+
+	return s.email.Send(ctx, msg)
+}
+
+func (s *AnalyticsReportService) loadSettings(ctx context.Context, wedding *models.Wedding) (*models.AnalyticsReportSettings, error) {
+	settings, err := s.reportRepo.GetByWedding(ctx, wedding.ID)
+	if err == nil {
+		return settings, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get report settings: %w", err)
+	}
+	return &models.AnalyticsReportSettings{
+		WeddingID: wedding.ID,
+		UserID:    wedding.UserID,
+		Frequency: models.AnalyticsReportWeekly,
+	}, nil
+}
+
+func (s *AnalyticsReportService) getOwnedWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	return wedding, nil
+}
+
+// analyticsReportView is the data rendered into a digest
+type analyticsReportView struct {
+	Title          string
+	Period         string
+	PageViews      int64
+	Sessions       int64
+	RSVPs          int64
+	ConversionRate string
+	TopSources     []models.TrafficSourceStats
+	TopPages       []models.PageStats
+}
+
+var analyticsReportHTML = template.Must(template.New("analytics_report").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #333;">
+  <h2>{{.Period}} report for {{.Title}}</h2>
+  <table cellpadding="6">
+    <tr><td>Page views</td><td><strong>{{.PageViews}}</strong></td></tr>
+    <tr><td>Visitors</td><td><strong>{{.Sessions}}</strong></td></tr>
+    <tr><td>RSVPs</td><td><strong>{{.RSVPs}}</strong></td></tr>
+    <tr><td>RSVP conversion</td><td><strong>{{.ConversionRate}}</strong></td></tr>
+  </table>
+  {{if .TopSources}}
+  <h3>Top sources</h3>
+  <ul>{{range .TopSources}}<li>{{.Source}}: {{.Views}} views from {{.Visitors}} visitors</li>{{end}}</ul>
+  {{end}}
+  {{if .TopPages}}
+  <h3>Top pages</h3>
+  <ul>{{range .TopPages}}<li>{{.Page}}: {{.Views}} views</li>{{end}}</ul>
+  {{end}}
+  <p style="font-size: 12px; color: #888;">You receive this email because analytics reports are enabled for this wedding. You can turn them off in your wedding's analytics settings.</p>
+</body>
+</html>`))
+
+// renderAnalyticsReport renders the HTML and plain text digest of a wedding's analytics summary
+func renderAnalyticsReport(wedding *models.Wedding, frequency models.AnalyticsReportFrequency, summary *models.AnalyticsSummary) (*EmailMessage, error) {
+	period := "Weekly"
+	if frequency == models.AnalyticsReportMonthly {
+		period = "Monthly"
+	}
+
+	view := analyticsReportView{
+		Title:          wedding.Title,
+		Period:         period,
+		PageViews:      summary.TotalPageViews,
+		Sessions:       summary.TotalSessions,
+		RSVPs:          summary.TotalRSVPs,
+		ConversionRate: fmt.Sprintf("%.1f%%", summary.ConversionRate),
+		TopSources:     summary.TopSources,
+		TopPages:       summary.TopPages,
+	}
+	if len(view.TopSources) > analyticsReportTopEntries {
+		view.TopSources = view.TopSources[:analyticsReportTopEntries]
+	}
+	if len(view.TopPages) > analyticsReportTopEntries {
+		view.TopPages = view.TopPages[:analyticsReportTopEntries]
+	}
+
+	var html bytes.Buffer
+	if err := analyticsReportHTML.Execute(&html, view); err != nil {
+		return nil, fmt.Errorf("failed to render analytics report: %w", err)
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s report for %s\n\n", view.Period, view.Title)
+	fmt.Fprintf(&text, "Page views: %d\nVisitors: %d\nRSVPs: %d\nRSVP conversion: %s\n", view.PageViews, view.Sessions, view.RSVPs, view.ConversionRate)
+	if len(view.TopSources) > 0 {
+		text.WriteString("\nTop sources:\n")
+		for _, source := range view.TopSources {
+			fmt.Fprintf(&text, "- %s: %d views from %d visitors\n", source.Source, source.Views, source.Visitors)
+		}
+	}
+
+	return &EmailMessage{
+		Subject: fmt.Sprintf("%s analytics report: %s", period, wedding.Title),
+		HTML:    html.String(),
+		Text:    text.String(),
+	}, nil
+}
+
+// ReportScheduler periodically emails due analytics digests
+type ReportScheduler struct {
+	service  *AnalyticsReportService
+	interval time.Duration
+	logger   *zap.Logger
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewReportScheduler creates a scheduler that checks for due digests every interval
+func NewReportScheduler(service *AnalyticsReportService, interval time.Duration, logger *zap.Logger) *ReportScheduler {
+	return &ReportScheduler{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start runs the scheduler loop in the background
+func (sch *ReportScheduler) Start(ctx context.Context) {
+	sch.wg.Add(1)
+	go func() {
+		defer sch.wg.Done()
+		ticker := time.NewTicker(sch.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sch.stop:
+				return
+			case now := <-ticker.C:
+				sent, err := sch.service.SendDue(ctx, now)
+				if err != nil {
+					sch.logger.Error("Analytics report run failed", zap.Error(err))
+					continue
+				}
+				if sent > 0 {
+					sch.logger.Info("Analytics reports sent", zap.Int("count", sent))
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the scheduler loop to exit and waits for it
+func (sch *ReportScheduler) Stop() {
+	close(sch.stop)
+	sch.wg.Wait()
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockAnalyticsReportRepository is an in-memory analytics report settings repository
+type MockAnalyticsReportRepository struct {
+	settings map[models.ID]*models.AnalyticsReportSettings // keyed by wedding ID
+}
+
+func NewMockAnalyticsReportRepository() *MockAnalyticsReportRepository {
+	return &MockAnalyticsReportRepository{
+		settings: make(map[models.ID]*models.AnalyticsReportSettings),
+	}
+}
+
+func (m *MockAnalyticsReportRepository) GetByWedding(ctx context.Context, weddingID models.ID) (*models.AnalyticsReportSettings, error) {
+	settings, exists := m.settings[weddingID]
+	if !exists {
+		return nil, repository.ErrNotFound
+	}
+	return settings, nil
+}
+
+func (m *MockAnalyticsReportRepository) Upsert(ctx context.Context, settings *models.AnalyticsReportSettings) error {
+	if settings.ID.IsZero() {
+		settings.ID = models.NewID()
+	}
+	m.settings[settings.WeddingID] = settings
+	return nil
+}
+
+func (m *MockAnalyticsReportRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.AnalyticsReportSettings, error) {
+	var due []*models.AnalyticsReportSettings
+	for _, settings := range m.settings {
+		if settings.IsDue(now) {
+			due = append(due, settings)
+		}
+	}
+	return due, nil
+}
+
+func (m *MockAnalyticsReportRepository) MarkSent(ctx context.Context, id models.ID, sentAt, nextSendAt time.Time, reason string) error {
+	for _, settings := range m.settings {
+		if settings.ID == id {
+			settings.NextSendAt = nextSendAt
+			settings.LastError = reason
+			if reason == "" {
+				settings.LastSentAt = &sentAt
+			}
+		}
+	}
+	return nil
+}
+
+// MockEmailService records sent emails
+type MockEmailService struct {
+	sent []*EmailMessage
+	err  error
+}
+
+func (m *MockEmailService) Send(ctx context.Context, msg *EmailMessage) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func setupAnalyticsReportService(t *testing.T) (*AnalyticsReportService, *MockAnalyticsReportRepository, *MockEmailService, *models.Wedding) {
+	reportRepo := NewMockAnalyticsReportRepository()
+	analyticsRepo := &MockAnalyticsRepository{}
+	weddingRepo := &MockWeddingRepository{}
+	userRepo := &MockUserRepository{}
+	email := &MockEmailService{}
+
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		UserID: models.NewID(),
+		Title:  "Alice & Bob",
+	}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+	weddingRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+	userRepo.On("GetByID", mock.Anything, wedding.UserID).Return(&models.User{ID: wedding.UserID, Email: "alice@example.com"}, nil)
+	analyticsRepo.On("GetAnalyticsSummary", mock.Anything, wedding.ID, mock.Anything).Return(&models.AnalyticsSummary{
+		TotalPageViews: 120,
+		TotalSessions:  80,
+		TotalRSVPs:     12,
+		ConversionRate: 10,
+		TopSources:     []models.TrafficSourceStats{{Source: "instagram", Visitors: 50, Views: 70}},
+	}, nil)
+
+	service := NewAnalyticsReportService(reportRepo, analyticsRepo, weddingRepo, userRepo, email, zaptest.NewLogger(t))
+	return service, reportRepo, email, wedding
+}
+
+func TestAnalyticsReportService_Settings(t *testing.T) {
+	service, reportRepo, _, wedding := setupAnalyticsReportService(t)
+	ctx := context.Background()
+
+	t.Run("Defaults to disabled weekly", func(t *testing.T) {
+		settings, err := service.GetSettings(ctx, wedding.ID, wedding.UserID)
+		require.NoError(t, err)
+		assert.False(t, settings.Enabled)
+		assert.Equal(t, models.AnalyticsReportWeekly, settings.Frequency)
+		assert.Empty(t, reportRepo.settings)
+	})
+
+	t.Run("Opt in schedules the next digest", func(t *testing.T) {
+		enabled := true
+		frequency := "monthly"
+		settings, err := service.UpdateSettings(ctx, wedding.ID, wedding.UserID, UpdateReportSettingsRequest{Enabled: &enabled, Frequency: &frequency})
+		require.NoError(t, err)
+
+		assert.True(t, settings.Enabled)
+		assert.Equal(t, models.AnalyticsReportMonthly, settings.Frequency)
+		assert.Equal(t, 1, settings.NextSendAt.Day())
+		assert.True(t, settings.NextSendAt.After(time.Now()))
+		assert.Same(t, settings, reportRepo.settings[wedding.ID])
+	})
+
+	t.Run("Opt out", func(t *testing.T) {
+		disabled := false
+		settings, err := service.UpdateSettings(ctx, wedding.ID, wedding.UserID, UpdateReportSettingsRequest{Enabled: &disabled})
+		require.NoError(t, err)
+		assert.False(t, settings.Enabled)
+	})
+
+	t.Run("Error - invalid frequency", func(t *testing.T) {
+		frequency := "daily"
+		_, err := service.UpdateSettings(ctx, wedding.ID, wedding.UserID, UpdateReportSettingsRequest{Frequency: &frequency})
+		assert.ErrorIs(t, err, ErrInvalidReportFrequency)
+	})
+
+	t.Run("Error - not the owner", func(t *testing.T) {
+		_, err := service.GetSettings(ctx, wedding.ID, models.NewID())
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Error - wedding not found", func(t *testing.T) {
+		_, err := service.GetSettings(ctx, models.NewID(), wedding.UserID)
+		assert.ErrorIs(t, err, ErrWeddingNotFound)
+	})
+}
+
+func TestAnalyticsReportService_SendDue(t *testing.T) {
+	now := time.Date(2026, 3, 16, 8, 5, 0, 0, time.UTC)
+
+	t.Run("Emails the owner and schedules the next digest", func(t *testing.T) {
+		service, reportRepo, email, wedding := setupAnalyticsReportService(t)
+		settings := &models.AnalyticsReportSettings{
+			ID:         models.NewID(),
+			WeddingID:  wedding.ID,
+			UserID:     wedding.UserID,
+			Enabled:    true,
+			Frequency:  models.AnalyticsReportWeekly,
+			NextSendAt: now.Add(-5 * time.Minute),
+		}
+		reportRepo.settings[wedding.ID] = settings
+
+		sent, err := service.SendDue(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+
+		require.Len(t, email.sent, 1)
+		assert.Equal(t, "alice@example.com", email.sent[0].To)
+		assert.Contains(t, email.sent[0].Subject, "Weekly")
+		assert.Contains(t, email.sent[0].HTML, "Alice &amp; Bob")
+		assert.Contains(t, email.sent[0].HTML, "instagram")
+		assert.Contains(t, email.sent[0].Text, "RSVP conversion: 10.0%")
+
+		assert.Equal(t, time.Date(2026, 3, 23, 8, 0, 0, 0, time.UTC), settings.NextSendAt)
+		assert.Equal(t, now, *settings.LastSentAt)
+	})
+
+	t.Run("Retries failed sends", func(t *testing.T) {
+		service, reportRepo, email, wedding := setupAnalyticsReportService(t)
+		email.err = errors.New("provider down")
+		settings := &models.AnalyticsReportSettings{
+			ID:         models.NewID(),
+			WeddingID:  wedding.ID,
+			Enabled:    true,
+			Frequency:  models.AnalyticsReportWeekly,
+			NextSendAt: now,
+		}
+		reportRepo.settings[wedding.ID] = settings
+
+		sent, err := service.SendDue(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, 0, sent)
+		assert.Equal(t, now.Add(analyticsReportRetryInterval), settings.NextSendAt)
+		assert.Contains(t, settings.LastError, "provider down")
+		assert.Nil(t, settings.LastSentAt)
+	})
+
+	t.Run("Disables digests of deleted weddings", func(t *testing.T) {
+		service, reportRepo, email, _ := setupAnalyticsReportService(t)
+		deletedID := models.NewID()
+		settings := &models.AnalyticsReportSettings{
+			ID:         models.NewID(),
+			WeddingID:  deletedID,
+			Enabled:    true,
+			Frequency:  models.AnalyticsReportWeekly,
+			NextSendAt: now,
+		}
+		reportRepo.settings[deletedID] = settings
+
+		sent, err := service.SendDue(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, 0, sent)
+		assert.False(t, settings.Enabled)
+		assert.Empty(t, email.sent)
+	})
+}
+
+func TestSendGridEmailService_Send(t *testing.T) {
+	var received sendGridRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	service := NewSendGridEmailService("test-key", "reports@example.com")
+	service.endpoint = server.URL
+
+	err := service.Send(context.Background(), &EmailMessage{To: "alice@example.com", Subject: "Hi", HTML: "<p>Hi</p>", Text: "Hi"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "reports@example.com", received.From.Email)
+	assert.Equal(t, "alice@example.com", received.Personalizations[0].To[0].Email)
+	require.Len(t, received.Content, 2)
+	assert.Equal(t, "text/plain", received.Content[0].Type)
+
+	service.apiKey = "wrong-key"
+	assert.Error(t, service.Send(context.Background(), &EmailMessage{To: "alice@example.com", Subject: "Hi", Text: "Hi"}))
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"sync"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// analyticsSubscriberBuffer is how many events a live subscriber may fall behind
+// before further events are dropped for it
+const analyticsSubscriberBuffer = 64
+
+// AnalyticsBroker fans tracked analytics events out to live subscribers of a
+// wedding. Subscriptions are held in memory, so each API instance only sees
+// events it tracked itself.
+type AnalyticsBroker struct {
+	mu          sync.RWMutex
+	subscribers map[models.ID]map[chan *models.AnalyticsLiveEvent]struct{}
+}
+
+// NewAnalyticsBroker creates an empty analytics broker
+func NewAnalyticsBroker() *AnalyticsBroker {
+	return &AnalyticsBroker{
+		subscribers: make(map[models.ID]map[chan *models.AnalyticsLiveEvent]struct{}),
+	}
+}
+
+// Subscribe registers a subscriber for a wedding's events. The returned cancel
+// function unregisters it and closes the channel; it is safe to call twice.
+func (b *AnalyticsBroker) Subscribe(weddingID models.ID) (<-chan *models.AnalyticsLiveEvent, func()) {
+	ch := make(chan *models.AnalyticsLiveEvent, analyticsSubscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[weddingID] == nil {
+		b.subscribers[weddingID] = make(map[chan *models.AnalyticsLiveEvent]struct{})
+	}
+	b.subscribers[weddingID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[weddingID], ch)
+			if len(b.subscribers[weddingID]) == 0 {
+				delete(b.subscribers, weddingID)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
+
+// Publish delivers an event to every subscriber of its wedding without
+// blocking; subscribers whose buffer is full miss the event
+func (b *AnalyticsBroker) Publish(event *models.AnalyticsLiveEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers[event.WeddingID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// SubscriberCount returns the number of live subscribers of a wedding
+func (b *AnalyticsBroker) SubscriberCount(weddingID models.ID) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers[weddingID])
+}
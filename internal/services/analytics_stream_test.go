@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func TestAnalyticsBroker(t *testing.T) {
+	weddingID := models.NewID()
+
+	t.Run("Delivers only to subscribers of the wedding", func(t *testing.T) {
+		broker := NewAnalyticsBroker()
+		events, cancel := broker.Subscribe(weddingID)
+		defer cancel()
+		other, cancelOther := broker.Subscribe(models.NewID())
+		defer cancelOther()
+
+		broker.Publish(&models.AnalyticsLiveEvent{Type: models.AnalyticsLivePageView, WeddingID: weddingID})
+
+		require.Len(t, events, 1)
+		assert.Equal(t, models.AnalyticsLivePageView, (<-events).Type)
+		assert.Empty(t, other)
+	})
+
+	t.Run("Cancel unsubscribes and closes the channel", func(t *testing.T) {
+		broker := NewAnalyticsBroker()
+		events, cancel := broker.Subscribe(weddingID)
+		assert.Equal(t, 1, broker.SubscriberCount(weddingID))
+
+		cancel()
+		cancel()
+
+		assert.Equal(t, 0, broker.SubscriberCount(weddingID))
+		_, ok := <-events
+		assert.False(t, ok)
+		broker.Publish(&models.AnalyticsLiveEvent{WeddingID: weddingID})
+	})
+
+	t.Run("Drops events for slow subscribers", func(t *testing.T) {
+		broker := NewAnalyticsBroker()
+		events, cancel := broker.Subscribe(weddingID)
+		defer cancel()
+
+		for i := 0; i < analyticsSubscriberBuffer+10; i++ {
+			broker.Publish(&models.AnalyticsLiveEvent{WeddingID: weddingID})
+		}
+		assert.Len(t, events, analyticsSubscriberBuffer)
+	})
+}
+
+func TestAnalyticsService_SubscribeLive(t *testing.T) {
+	ctx := context.Background()
+	weddingID := models.NewID()
+
+	analyticsRepo := &MockAnalyticsRepository{}
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", ctx, weddingID).Return(&models.Wedding{
+		ID:     weddingID,
+		Status: string(models.WeddingStatusPublished),
+	}, nil)
+	analyticsRepo.On("TrackPageView", ctx, mock.AnythingOfType("*models.PageView")).Return(nil)
+	analyticsRepo.On("TrackRSVPEvent", ctx, mock.AnythingOfType("*models.RSVPAnalytics")).Return(nil)
+	analyticsRepo.On("TrackConversion", ctx, mock.AnythingOfType("*models.ConversionEvent")).Return(nil)
+
+	service := NewAnalyticsService(analyticsRepo, weddingRepo, zaptest.NewLogger(t))
+	events, cancel := service.SubscribeLive(weddingID)
+	defer cancel()
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 14_0 like Mac OS X)")
+	req.Header.Set("Accept-Language", "en-US")
+	req.Header.Set("Referer", "https://instagram.com/p/abc")
+
+	require.NoError(t, service.TrackPageView(ctx, weddingID, "session-1", "invitation", req))
+	require.NoError(t, service.TrackRSVPSubmission(ctx, weddingID, models.NewID(), "session-1", "qr_code", 45, req))
+
+	require.Len(t, events, 3)
+
+	pageView := <-events
+	assert.Equal(t, models.AnalyticsLivePageView, pageView.Type)
+	assert.Equal(t, "invitation", pageView.Page)
+	assert.Equal(t, "mobile", pageView.Device)
+	assert.Equal(t, "instagram", pageView.Source)
+
+	submitted := <-events
+	assert.Equal(t, models.AnalyticsLiveRSVPSubmitted, submitted.Type)
+	assert.Equal(t, "qr_code", submitted.Source)
+
+	conversion := <-events
+	assert.Equal(t, models.AnalyticsLiveConversion, conversion.Type)
+	assert.Equal(t, "rsvp_completed", conversion.Event)
+	assert.Equal(t, float64(1), conversion.Value)
+}
@@ -4,16 +4,18 @@ import (
 	"context"
 	"errors"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap/zaptest"
 
 	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
 )
 
 func TestAnalyticsService_TrackPageView(t *testing.T) {
@@ -24,7 +26,7 @@ func TestAnalyticsService_TrackPageView(t *testing.T) {
 	service := NewAnalyticsService(analyticsRepo, weddingRepo, logger)
 
 	ctx := context.Background()
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 	sessionID := "test-session-123"
 	page := "invitation"
 
@@ -52,6 +54,82 @@ func TestAnalyticsService_TrackPageView(t *testing.T) {
 		weddingRepo.AssertExpectations(t)
 	})
 
+	t.Run("Success - request ID attached to metadata", func(t *testing.T) {
+		analyticsRepo := &MockAnalyticsRepository{}
+		weddingRepo := &MockWeddingRepository{}
+		service := NewAnalyticsService(analyticsRepo, weddingRepo, zaptest.NewLogger(t))
+
+		wedding := &models.Wedding{
+			ID:     weddingID,
+			Status: string(models.WeddingStatusPublished),
+		}
+		tracedCtx := utils.WithRequestID(ctx, "req-abc")
+		weddingRepo.On("GetByID", tracedCtx, weddingID).Return(wedding, nil)
+		analyticsRepo.On("TrackPageView", tracedCtx, mock.MatchedBy(func(pv *models.PageView) bool {
+			return pv.Metadata["request_id"] == "req-abc"
+		})).Return(nil)
+
+		err := service.TrackPageView(tracedCtx, weddingID, sessionID, page, req)
+		require.NoError(t, err)
+
+		analyticsRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - traffic attributed", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			target   string
+			referrer string
+			source   string
+			medium   string
+			campaign string
+		}{
+			{"UTM parameters win", "/track?utm_source=Newsletter&utm_medium=Email&utm_campaign=Save%20the%20Date", "https://google.com", "newsletter", "email", "save_the_date"},
+			{"Search referrer", "/track", "https://google.com/search?q=wedding", "google", "organic", ""},
+			{"Social referrer", "/track", "https://instagram.com/p/abc", "instagram", "social", ""},
+			{"No referrer", "/track", "", "direct", "none", ""},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				analyticsRepo := &MockAnalyticsRepository{}
+				weddingRepo := &MockWeddingRepository{}
+				service := NewAnalyticsService(analyticsRepo, weddingRepo, zaptest.NewLogger(t))
+
+				weddingRepo.On("GetByID", ctx, weddingID).Return(&models.Wedding{ID: weddingID, Status: string(models.WeddingStatusPublished)}, nil)
+				analyticsRepo.On("TrackPageView", ctx, mock.MatchedBy(func(pv *models.PageView) bool {
+					return pv.Source == tc.source && pv.Medium == tc.medium && pv.Campaign == tc.campaign
+				})).Return(nil)
+
+				req := httptest.NewRequest("POST", tc.target, nil)
+				req.Header.Set("Referer", tc.referrer)
+				err := service.TrackPageView(ctx, weddingID, sessionID, page, req)
+				require.NoError(t, err)
+
+				analyticsRepo.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("Success - visitor located", func(t *testing.T) {
+		analyticsRepo := &MockAnalyticsRepository{}
+		weddingRepo := &MockWeddingRepository{}
+		geo := &fakeGeoIPProvider{locations: map[string]*GeoLocation{"203.0.113.7": {Country: "ID", City: "Jakarta"}}}
+		service := NewAnalyticsServiceWithGeoIP(analyticsRepo, weddingRepo, geo, zaptest.NewLogger(t))
+
+		weddingRepo.On("GetByID", ctx, weddingID).Return(&models.Wedding{ID: weddingID, Status: string(models.WeddingStatusPublished)}, nil)
+		analyticsRepo.On("TrackPageView", ctx, mock.MatchedBy(func(pv *models.PageView) bool {
+			return pv.Country == "ID" && pv.City == "Jakarta"
+		})).Return(nil)
+
+		req := httptest.NewRequest("POST", "/track", nil)
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		err := service.TrackPageView(ctx, weddingID, sessionID, page, req)
+		require.NoError(t, err)
+
+		analyticsRepo.AssertExpectations(t)
+	})
+
 	t.Run("Error - wedding not found", func(t *testing.T) {
 		// Create fresh mocks for this test
 		analyticsRepo := &MockAnalyticsRepository{}
@@ -115,6 +193,101 @@ func TestAnalyticsService_TrackPageView(t *testing.T) {
 	})
 }
 
+func TestAnalyticsService_TrackPageDuration(t *testing.T) {
+	ctx := context.Background()
+	weddingID := models.NewID()
+	published := &models.Wedding{ID: weddingID, Status: string(models.WeddingStatusPublished)}
+
+	newService := func(t *testing.T) (AnalyticsService, *MockAnalyticsRepository) {
+		analyticsRepo := &MockAnalyticsRepository{}
+		weddingRepo := &MockWeddingRepository{}
+		weddingRepo.On("GetByID", ctx, weddingID).Return(published, nil)
+		return NewAnalyticsService(analyticsRepo, weddingRepo, zaptest.NewLogger(t)), analyticsRepo
+	}
+
+	t.Run("Caps long durations", func(t *testing.T) {
+		service, analyticsRepo := newService(t)
+		analyticsRepo.On("TrackPageDuration", ctx, weddingID, "session123", "invitation", int64(maxPageDuration)).Return(nil)
+
+		err := service.TrackPageDuration(ctx, weddingID, "session123", "invitation", 6*60*60, nil)
+		require.NoError(t, err)
+		analyticsRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects non-positive durations", func(t *testing.T) {
+		service, _ := newService(t)
+
+		err := service.TrackPageDuration(ctx, weddingID, "session123", "invitation", 0, nil)
+		assert.ErrorIs(t, err, ErrInvalidPageDuration)
+	})
+
+	t.Run("Reports pages the session never viewed", func(t *testing.T) {
+		service, analyticsRepo := newService(t)
+		analyticsRepo.On("TrackPageDuration", ctx, weddingID, "session123", "gallery", int64(30)).Return(repository.ErrNotFound)
+
+		err := service.TrackPageDuration(ctx, weddingID, "session123", "gallery", 30, nil)
+		assert.ErrorIs(t, err, ErrPageViewNotFound)
+	})
+}
+
+func TestAnalyticsService_TrackingSettings(t *testing.T) {
+	ctx := context.Background()
+
+	newService := func(t *testing.T, settings models.AnalyticsSettings) (AnalyticsService, *MockAnalyticsRepository, models.ID) {
+		wedding := &models.Wedding{ID: models.NewID(), Status: string(models.WeddingStatusPublished), Analytics: settings}
+		analyticsRepo := &MockAnalyticsRepository{}
+		weddingRepo := &MockWeddingRepository{}
+		weddingRepo.On("GetByID", ctx, wedding.ID).Return(wedding, nil)
+		return NewAnalyticsService(analyticsRepo, weddingRepo, zaptest.NewLogger(t)), analyticsRepo, wedding.ID
+	}
+
+	t.Run("Disabled tracking records nothing", func(t *testing.T) {
+		service, analyticsRepo, weddingID := newService(t, models.AnalyticsSettings{Disabled: true})
+
+		err := service.TrackPageView(ctx, weddingID, "s1", "invitation", httptest.NewRequest("GET", "/", nil))
+		assert.ErrorIs(t, err, ErrTrackingSkipped)
+		err = service.TrackConversion(ctx, weddingID, "s1", "rsvp_completed", 1, nil)
+		assert.ErrorIs(t, err, ErrTrackingSkipped)
+		analyticsRepo.AssertNotCalled(t, "TrackPageView", mock.Anything, mock.Anything)
+		analyticsRepo.AssertNotCalled(t, "TrackConversion", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Do Not Track is respected when asked to", func(t *testing.T) {
+		service, analyticsRepo, weddingID := newService(t, models.AnalyticsSettings{RespectDoNotTrack: true})
+		analyticsRepo.On("TrackPageView", ctx, mock.AnythingOfType("*models.PageView")).Return(nil)
+
+		for _, header := range []string{"DNT", "Sec-GPC"} {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set(header, "1")
+			err := service.TrackPageView(ctx, weddingID, "s1", "invitation", req)
+			assert.ErrorIs(t, err, ErrTrackingSkipped, header)
+		}
+
+		err := service.TrackPageView(ctx, weddingID, "s1", "invitation", httptest.NewRequest("GET", "/", nil))
+		require.NoError(t, err)
+		analyticsRepo.AssertNumberOfCalls(t, "TrackPageView", 1)
+	})
+
+	t.Run("Page views are sampled by session", func(t *testing.T) {
+		settings := models.AnalyticsSettings{SampleRate: 0.5}
+		service, analyticsRepo, weddingID := newService(t, settings)
+		analyticsRepo.On("TrackPageView", ctx, mock.AnythingOfType("*models.PageView")).Return(nil)
+
+		recorded := 0
+		for i := 0; i < 20; i++ {
+			sessionID := "session-" + strconv.Itoa(i)
+			err := service.TrackPageView(ctx, weddingID, sessionID, "invitation", nil)
+			if settings.Samples(weddingID, sessionID) {
+				require.NoError(t, err)
+				recorded++
+			} else {
+				assert.ErrorIs(t, err, ErrTrackingSkipped)
+			}
+		}
+		analyticsRepo.AssertNumberOfCalls(t, "TrackPageView", recorded)
+	})
+}
+
 func TestAnalyticsService_TrackRSVPSubmission(t *testing.T) {
 	analyticsRepo := &MockAnalyticsRepository{}
 	weddingRepo := &MockWeddingRepository{}
@@ -123,8 +296,8 @@ func TestAnalyticsService_TrackRSVPSubmission(t *testing.T) {
 	service := NewAnalyticsService(analyticsRepo, weddingRepo, logger)
 
 	ctx := context.Background()
-	weddingID := primitive.NewObjectID()
-	rsvpID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	rsvpID := models.NewID()
 	sessionID := "test-session-123"
 	source := "web"
 	timeToComplete := int64(120)
@@ -180,7 +353,7 @@ func TestAnalyticsService_TrackRSVPAbandonment(t *testing.T) {
 	service := NewAnalyticsService(analyticsRepo, weddingRepo, logger)
 
 	ctx := context.Background()
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 	sessionID := "test-session-123"
 	abandonedStep := "personal_info"
 	formErrors := []string{"Invalid email format", "Name is required"}
@@ -234,7 +407,7 @@ func TestAnalyticsService_TrackConversion(t *testing.T) {
 	service := NewAnalyticsService(analyticsRepo, weddingRepo, logger)
 
 	ctx := context.Background()
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 	sessionID := "test-session-123"
 	event := "share_clicked"
 	value := 1.0
@@ -286,7 +459,7 @@ func TestAnalyticsService_GetWeddingAnalytics(t *testing.T) {
 	service := NewAnalyticsService(analyticsRepo, weddingRepo, logger)
 
 	ctx := context.Background()
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 
 	expectedAnalytics := &models.WeddingAnalytics{
 		WeddingID:      weddingID,
@@ -298,7 +471,7 @@ func TestAnalyticsService_GetWeddingAnalytics(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		analyticsRepo.On("GetWeddingAnalytics", ctx, weddingID).Return(expectedAnalytics, nil)
+		analyticsRepo.On("GetWeddingAnalytics", mock.Anything, weddingID).Return(expectedAnalytics, nil)
 
 		result, err := service.GetWeddingAnalytics(ctx, weddingID)
 		require.NoError(t, err)
@@ -314,7 +487,7 @@ func TestAnalyticsService_GetWeddingAnalytics(t *testing.T) {
 		logger := zaptest.NewLogger(t)
 		service := NewAnalyticsService(analyticsRepo, weddingRepo, logger)
 
-		analyticsRepo.On("GetWeddingAnalytics", ctx, weddingID).Return(nil, assert.AnError)
+		analyticsRepo.On("GetWeddingAnalytics", mock.Anything, weddingID).Return(nil, assert.AnError)
 
 		result, err := service.GetWeddingAnalytics(ctx, weddingID)
 		require.Error(t, err)
@@ -382,10 +555,10 @@ func TestAnalyticsService_RefreshWeddingAnalytics(t *testing.T) {
 	service := NewAnalyticsService(analyticsRepo, weddingRepo, logger)
 
 	ctx := context.Background()
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 
 	t.Run("Success", func(t *testing.T) {
-		analyticsRepo.On("UpdateWeddingAnalytics", ctx, weddingID).Return(nil)
+		analyticsRepo.On("UpdateWeddingAnalytics", mock.Anything, weddingID).Return(nil)
 
 		err := service.RefreshWeddingAnalytics(ctx, weddingID)
 		require.NoError(t, err)
@@ -400,7 +573,7 @@ func TestAnalyticsService_RefreshWeddingAnalytics(t *testing.T) {
 		logger := zaptest.NewLogger(t)
 		service := NewAnalyticsService(analyticsRepo, weddingRepo, logger)
 
-		analyticsRepo.On("UpdateWeddingAnalytics", ctx, weddingID).Return(assert.AnError)
+		analyticsRepo.On("UpdateWeddingAnalytics", mock.Anything, weddingID).Return(assert.AnError)
 
 		err := service.RefreshWeddingAnalytics(ctx, weddingID)
 		require.Error(t, err)
@@ -419,7 +592,7 @@ func TestAnalyticsService_RefreshSystemAnalytics(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
-		analyticsRepo.On("UpdateSystemAnalytics", ctx).Return(nil)
+		analyticsRepo.On("UpdateSystemAnalytics", mock.Anything).Return(nil)
 
 		err := service.RefreshSystemAnalytics(ctx)
 		require.NoError(t, err)
@@ -434,7 +607,7 @@ func TestAnalyticsService_RefreshSystemAnalytics(t *testing.T) {
 		logger := zaptest.NewLogger(t)
 		service := NewAnalyticsService(analyticsRepo, weddingRepo, logger)
 
-		analyticsRepo.On("UpdateSystemAnalytics", ctx).Return(assert.AnError)
+		analyticsRepo.On("UpdateSystemAnalytics", mock.Anything).Return(assert.AnError)
 
 		err := service.RefreshSystemAnalytics(ctx)
 		require.Error(t, err)
@@ -486,7 +659,7 @@ func TestAnalyticsService_GetAnalyticsSummary(t *testing.T) {
 	service := NewAnalyticsService(analyticsRepo, weddingRepo, logger)
 
 	ctx := context.Background()
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 	period := "weekly"
 
 	expectedSummary := &models.AnalyticsSummary{
@@ -502,7 +675,7 @@ func TestAnalyticsService_GetAnalyticsSummary(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		analyticsRepo.On("GetAnalyticsSummary", ctx, weddingID, period).Return(expectedSummary, nil)
+		analyticsRepo.On("GetAnalyticsSummary", mock.Anything, weddingID, period).Return(expectedSummary, nil)
 
 		result, err := service.GetAnalyticsSummary(ctx, weddingID, period)
 		require.NoError(t, err)
@@ -518,7 +691,7 @@ func TestAnalyticsService_GetAnalyticsSummary(t *testing.T) {
 		logger := zaptest.NewLogger(t)
 		service := NewAnalyticsService(analyticsRepo, weddingRepo, logger)
 
-		analyticsRepo.On("GetAnalyticsSummary", ctx, weddingID, period).Return(nil, assert.AnError)
+		analyticsRepo.On("GetAnalyticsSummary", mock.Anything, weddingID, period).Return(nil, assert.AnError)
 
 		result, err := service.GetAnalyticsSummary(ctx, weddingID, period)
 		require.Error(t, err)
@@ -528,6 +701,40 @@ func TestAnalyticsService_GetAnalyticsSummary(t *testing.T) {
 	})
 }
 
+func TestAnalyticsService_GetDailyMetrics(t *testing.T) {
+	ctx := context.Background()
+	weddingID := models.NewID()
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 6, 4, 15, 30, 0, 0, time.UTC)
+
+	t.Run("Fills days without activity", func(t *testing.T) {
+		analyticsRepo := &MockAnalyticsRepository{}
+		service := NewAnalyticsService(analyticsRepo, &MockWeddingRepository{}, zaptest.NewLogger(t))
+
+		analyticsRepo.On("GetDailyMetrics", mock.Anything, weddingID, start, end.Truncate(24*time.Hour)).Return([]models.DailyMetrics{
+			{Date: "2024-06-02", PageViews: 10, Sessions: 4, RSVPs: 1, Conversions: 10},
+		}, nil)
+
+		metrics, err := service.GetDailyMetrics(ctx, weddingID, start, end)
+		require.NoError(t, err)
+		require.Len(t, metrics, 4)
+		assert.Equal(t, models.DailyMetrics{Date: "2024-06-01"}, metrics[0])
+		assert.Equal(t, int64(10), metrics[1].PageViews)
+		assert.Equal(t, "2024-06-04", metrics[3].Date)
+		analyticsRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects invalid ranges", func(t *testing.T) {
+		service := NewAnalyticsService(&MockAnalyticsRepository{}, &MockWeddingRepository{}, zaptest.NewLogger(t))
+
+		_, err := service.GetDailyMetrics(ctx, weddingID, end, start)
+		assert.ErrorIs(t, err, ErrInvalidDateRange)
+
+		_, err = service.GetDailyMetrics(ctx, weddingID, start.AddDate(-2, 0, 0), end)
+		assert.ErrorIs(t, err, ErrInvalidDateRange)
+	})
+}
+
 func TestAnalyticsService_HelperMethods(t *testing.T) {
 	service := &analyticsService{}
 
@@ -692,16 +899,35 @@ func TestAnalyticsService_HelperMethods(t *testing.T) {
 	})
 
 	t.Run("GetGeoLocation", func(t *testing.T) {
-		// This is a placeholder implementation, so it should return empty values
-		country, city := service.getGeoLocation("192.168.1.1")
-		assert.Equal(t, "", country)
-		assert.Equal(t, "", city)
+		ctx := context.Background()
 
-		country, city = service.getGeoLocation("")
+		// Without a provider nothing is located
+		country, city := service.getGeoLocation(ctx, "203.0.113.7")
 		assert.Equal(t, "", country)
 		assert.Equal(t, "", city)
 
-		country, city = service.getGeoLocation("127.0.0.1")
+		geo := &fakeGeoIPProvider{locations: map[string]*GeoLocation{
+			"203.0.113.7": {Country: "ID", City: "Bandung"},
+			"192.168.1.1": {Country: "US"},
+			"127.0.0.1":   {Country: "US"},
+		}}
+		located := &analyticsService{geo: geo, logger: zaptest.NewLogger(t)}
+
+		country, city = located.getGeoLocation(ctx, "203.0.113.7")
+		assert.Equal(t, "ID", country)
+		assert.Equal(t, "Bandung", city)
+
+		// Private, loopback and invalid addresses are never looked up
+		for _, ip := range []string{"192.168.1.1", "127.0.0.1", "", "not-an-ip"} {
+			country, city = located.getGeoLocation(ctx, ip)
+			assert.Equal(t, "", country)
+			assert.Equal(t, "", city)
+		}
+		assert.Equal(t, []string{"203.0.113.7"}, geo.lookups)
+
+		// Failed lookups leave the location empty
+		geo.err = errors.New("database unavailable")
+		country, city = located.getGeoLocation(ctx, "198.51.100.1")
 		assert.Equal(t, "", country)
 		assert.Equal(t, "", city)
 	})
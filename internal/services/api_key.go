@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+var (
+	ErrAPIKeyNotFound      = errs.NotFound("API key not found")
+	ErrInvalidAPIKey       = errors.New("invalid or revoked API key")
+	ErrInvalidAPIKeyScope  = errors.New("each scope needs a wedding ID and an access of read or read_write, once per wedding")
+	ErrTooManyAPIKeys      = errors.New("a user can have at most 20 active API keys")
+	ErrInvalidAPIKeyExpiry = errors.New("API key expiry must be in the future")
+)
+
+const (
+	// apiKeyPrefix starts every API key, so leaked keys are easy to recognize
+	apiKeyPrefix = "wik_"
+	// apiKeyDisplayLength is how much of a key is kept to identify it in the dashboard
+	apiKeyDisplayLength = len(apiKeyPrefix) + 8
+
+	maxAPIKeysPerUser = 20
+	// DefaultAPIKeyRateLimit is the requests per minute of a key created without a limit
+	DefaultAPIKeyRateLimit = 60
+	// maxAPIKeyUsageDays bounds the usage history returned for a key
+	maxAPIKeyUsageDays = 90
+)
+
+// APIKeyService manages the API keys users create for third-party integrations,
+// authenticates requests made with them and tracks their usage
+type APIKeyService struct {
+	repo        repository.APIKeyRepository
+	weddingRepo repository.WeddingRepository
+	logger      *zap.Logger
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(repo repository.APIKeyRepository, weddingRepo repository.WeddingRepository, logger *zap.Logger) *APIKeyService {
+	return &APIKeyService{
+		repo:        repo,
+		weddingRepo: weddingRepo,
+		logger:      logger,
+	}
+}
+
+// APIKeyScopeRequest grants a key read or read-write access to one wedding
+type APIKeyScopeRequest struct {
+	WeddingID string `json:"wedding_id" validate:"required"`
+	Access    string `json:"access" validate:"required,oneof=read read_write"`
+}
+
+// CreateAPIKeyRequest represents a new API key
+type CreateAPIKeyRequest struct {
+	Name      string               `json:"name" validate:"required,max=100"`
+	Scopes    []APIKeyScopeRequest `json:"scopes" validate:"required,min=1,dive"`
+	RateLimit int                  `json:"rate_limit,omitempty" validate:"omitempty,min=1,max=1200"`
+	ExpiresAt *time.Time           `json:"expires_at,omitempty"`
+}
+
+// UpdateAPIKeyRequest represents changes to an API key
+type UpdateAPIKeyRequest struct {
+	Name      *string               `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Scopes    *[]APIKeyScopeRequest `json:"scopes,omitempty" validate:"omitempty,min=1,dive"`
+	RateLimit *int                  `json:"rate_limit,omitempty" validate:"omitempty,min=1,max=1200"`
+}
+
+// APIKeyUsageReport is the daily usage of an API key, one entry per day
+type APIKeyUsageReport struct {
+	KeyID         models.ID            `json:"key_id"`
+	From          string               `json:"from"`
+	To            string               `json:"to"`
+	TotalRequests int64                `json:"total_requests"`
+	TotalErrors   int64                `json:"total_errors"`
+	Days          []models.APIKeyUsage `json:"days"`
+}
+
+// CreateKey creates an API key for the user and returns its value, which is not shown again
+func (s *APIKeyService) CreateKey(ctx context.Context, userID models.ID, req CreateAPIKeyRequest) (*models.APIKey, string, error) {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, "", ErrInvalidAPIKeyExpiry
+	}
+
+	scopes, err := s.resolveScopes(ctx, userID, req.Scopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	existing, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list API keys: %w", err)
+	}
+	active := 0
+	for _, key := range existing {
+		if key.IsActive(time.Now()) {
+			active++
+		}
+	}
+	if active >= maxAPIKeysPerUser {
+		return nil, "", ErrTooManyAPIKeys
+	}
+
+	secret, err := utils.GenerateSecureToken(40)
+	if err != nil {
+		return nil, "", err
+	}
+	value := apiKeyPrefix + secret
+
+	rateLimit := req.RateLimit
+	if rateLimit == 0 {
+		rateLimit = DefaultAPIKeyRateLimit
+	}
+
+	key := &models.APIKey{
+		ID:        models.NewID(),
+		UserID:    userID,
+		Name:      strings.TrimSpace(req.Name),
+		Prefix:    value[:apiKeyDisplayLength],
+		KeyHash:   hashAPIKey(value),
+		Scopes:    scopes,
+		RateLimit: rateLimit,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := s.repo.Create(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("failed to create API key: %w", err)
+	}
+	return key, value, nil
+}
+
+// ListKeys lists the API keys of the user, revoked ones included, with their usage totals
+func (s *APIKeyService) ListKeys(ctx context.Context, userID models.ID) ([]*models.APIKey, error) {
+	keys, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// UpdateKey renames an API key or changes its scopes or rate limit
+func (s *APIKeyService) UpdateKey(ctx context.Context, userID, id models.ID, req UpdateAPIKeyRequest) (*models.APIKey, error) {
+	key, err := s.getKey(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if req.Name != nil {
+		key.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Scopes != nil {
+		if key.Scopes, err = s.resolveScopes(ctx, userID, *req.Scopes); err != nil {
+			return nil, err
+		}
+	}
+	if req.RateLimit != nil {
+		key.RateLimit = *req.RateLimit
+	}
+
+	if err := s.repo.Update(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to update API key: %w", err)
+	}
+	return key, nil
+}
+
+// RevokeKey stops an API key from authenticating; its usage history is kept
+func (s *APIKeyService) RevokeKey(ctx context.Context, userID, id models.ID) error {
+	key, err := s.getKey(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if key.RevokedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	key.RevokedAt = &now
+	if err := s.repo.Update(ctx, key); err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	return nil
+}
+
+// GetUsage returns the daily usage of an API key over the last days, including days without requests
+func (s *APIKeyService) GetUsage(ctx context.Context, userID, id models.ID, days int) (*APIKeyUsageReport, error) {
+	if _, err := s.getKey(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	if days <= 0 || days > maxAPIKeyUsageDays {
+		days = 30
+	}
+
+	today := time.Now().UTC()
+	from := today.AddDate(0, 0, -(days - 1)).Format("2006-01-02")
+	usage, err := s.repo.ListUsage(ctx, id, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API key usage: %w", err)
+	}
+
+	byDate := make(map[string]*models.APIKeyUsage, len(usage))
+	for _, day := range usage {
+		byDate[day.Date] = day
+	}
+
+	report := &APIKeyUsageReport{
+		KeyID: id,
+		From:  from,
+		To:    today.Format("2006-01-02"),
+		Days:  make([]models.APIKeyUsage, 0, days),
+	}
+	for i := days - 1; i >= 0; i-- {
+		date := today.AddDate(0, 0, -i).Format("2006-01-02")
+		day := models.APIKeyUsage{KeyID: id, Date: date}
+		if recorded, ok := byDate[date]; ok {
+			day = *recorded
+		}
+		report.TotalRequests += day.Requests
+		report.TotalErrors += day.Errors
+		report.Days = append(report.Days, day)
+	}
+	return report, nil
+}
+
+// AuthenticateAPIKey returns the active API key with the given value
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, value string) (*models.APIKey, error) {
+	if !strings.HasPrefix(value, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.GetByHash(ctx, hashAPIKey(value))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	if !key.IsActive(time.Now()) {
+		return nil, ErrInvalidAPIKey
+	}
+	return key, nil
+}
+
+// RecordAPIKeyUsage counts a request made with the key. Failures are logged, not
+// returned, so tracking never fails the request.
+func (s *APIKeyService) RecordAPIKeyUsage(ctx context.Context, key *models.APIKey, status int, rateLimited bool) {
+	if err := s.repo.RecordUsage(ctx, key.ID, time.Now(), status >= 400, rateLimited); err != nil {
+		s.logger.Error("Failed to record API key usage",
+			zap.Error(err),
+			zap.String("key_id", key.ID.String()))
+	}
+}
+
+// getKey returns an API key of the user
+func (s *APIKeyService) getKey(ctx context.Context, userID, id models.ID) (*models.APIKey, error) {
+	key, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	// Another user's key is reported as missing rather than forbidden
+	if key.UserID != userID {
+		return nil, ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+// resolveScopes checks that the user has a role in every wedding of the scopes.
+// The key acts as the user, so requests made with it are still limited by that role.
+func (s *APIKeyService) resolveScopes(ctx context.Context, userID models.ID, requested []APIKeyScopeRequest) ([]models.APIKeyScope, error) {
+	if len(requested) == 0 {
+		return nil, ErrInvalidAPIKeyScope
+	}
+
+	scopes := make([]models.APIKeyScope, 0, len(requested))
+	seen := make(map[models.ID]bool, len(requested))
+	for _, scope := range requested {
+		weddingID, err := models.ParseID(scope.WeddingID)
+		access := models.APIKeyAccess(scope.Access)
+		if err != nil || !access.IsValid() || seen[weddingID] {
+			return nil, ErrInvalidAPIKeyScope
+		}
+		seen[weddingID] = true
+
+		wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get wedding: %w", err)
+		}
+		// The wedding repository reports a missing wedding as nil
+		if wedding == nil {
+			return nil, ErrWeddingNotFound
+		}
+		if _, ok := wedding.RoleOf(userID); !ok {
+			return nil, ErrUnauthorized
+		}
+
+		scopes = append(scopes, models.APIKeyScope{WeddingID: weddingID, Access: access})
+	}
+	return scopes, nil
+}
+
+func hashAPIKey(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
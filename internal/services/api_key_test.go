@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockAPIKeyRepository is an in-memory API key repository
+type MockAPIKeyRepository struct {
+	keys  map[models.ID]*models.APIKey
+	usage map[models.ID]map[string]*models.APIKeyUsage
+}
+
+func NewMockAPIKeyRepository() *MockAPIKeyRepository {
+	return &MockAPIKeyRepository{
+		keys:  make(map[models.ID]*models.APIKey),
+		usage: make(map[models.ID]map[string]*models.APIKeyUsage),
+	}
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	m.keys[key.ID] = key
+	return nil
+}
+
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, id models.ID) (*models.APIKey, error) {
+	key, exists := m.keys[id]
+	if !exists {
+		return nil, repository.ErrNotFound
+	}
+	return key, nil
+}
+
+func (m *MockAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	for _, key := range m.keys {
+		if key.KeyHash == keyHash {
+			return key, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *MockAPIKeyRepository) ListByUser(ctx context.Context, userID models.ID) ([]*models.APIKey, error) {
+	var keys []*models.APIKey
+	for _, key := range m.keys {
+		if key.UserID == userID {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (m *MockAPIKeyRepository) Update(ctx context.Context, key *models.APIKey) error {
+	if _, exists := m.keys[key.ID]; !exists {
+		return repository.ErrNotFound
+	}
+	m.keys[key.ID] = key
+	return nil
+}
+
+func (m *MockAPIKeyRepository) RecordUsage(ctx context.Context, keyID models.ID, at time.Time, failed, rateLimited bool) error {
+	date := at.UTC().Format("2006-01-02")
+	if m.usage[keyID] == nil {
+		m.usage[keyID] = make(map[string]*models.APIKeyUsage)
+	}
+	day, exists := m.usage[keyID][date]
+	if !exists {
+		day = &models.APIKeyUsage{KeyID: keyID, Date: date}
+		m.usage[keyID][date] = day
+	}
+	day.Requests++
+	if failed {
+		day.Errors++
+	}
+	if rateLimited {
+		day.RateLimited++
+	}
+
+	if key, exists := m.keys[keyID]; exists {
+		key.RequestCount++
+		key.LastUsedAt = &at
+	}
+	return nil
+}
+
+func (m *MockAPIKeyRepository) ListUsage(ctx context.Context, keyID models.ID, from string) ([]*models.APIKeyUsage, error) {
+	var usage []*models.APIKeyUsage
+	for date, day := range m.usage[keyID] {
+		if date >= from {
+			usage = append(usage, day)
+		}
+	}
+	return usage, nil
+}
+
+func setupAPIKeyService(t *testing.T) (*APIKeyService, *MockAPIKeyRepository, *models.Wedding) {
+	keyRepo := NewMockAPIKeyRepository()
+	weddingRepo := &MockWeddingRepository{}
+
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		UserID: models.NewID(),
+		Title:  "Alice & Bob",
+		Collaborators: []models.WeddingCollaborator{
+			{UserID: models.NewID(), Role: models.WeddingRolePlanner},
+		},
+	}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+	weddingRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, nil)
+
+	return NewAPIKeyService(keyRepo, weddingRepo, zaptest.NewLogger(t)), keyRepo, wedding
+}
+
+func TestAPIKeyService_CreateKey(t *testing.T) {
+	service, keyRepo, wedding := setupAPIKeyService(t)
+	ctx := context.Background()
+	scopes := []APIKeyScopeRequest{{WeddingID: wedding.ID.String(), Access: "read"}}
+
+	t.Run("Success - returns the key once", func(t *testing.T) {
+		key, value, err := service.CreateKey(ctx, wedding.UserID, CreateAPIKeyRequest{Name: " Planner tool ", Scopes: scopes})
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(value, "wik_"))
+		assert.True(t, strings.HasPrefix(value, key.Prefix))
+		assert.NotContains(t, key.KeyHash, value)
+		assert.Equal(t, "Planner tool", key.Name)
+		assert.Equal(t, DefaultAPIKeyRateLimit, key.RateLimit)
+		assert.Equal(t, []models.APIKeyScope{{WeddingID: wedding.ID, Access: models.APIKeyAccessRead}}, key.Scopes)
+		assert.Contains(t, keyRepo.keys, key.ID)
+
+		authenticated, err := service.AuthenticateAPIKey(ctx, value)
+		require.NoError(t, err)
+		assert.Equal(t, key.ID, authenticated.ID)
+	})
+
+	t.Run("Success - collaborator", func(t *testing.T) {
+		_, _, err := service.CreateKey(ctx, wedding.Collaborators[0].UserID, CreateAPIKeyRequest{Name: "Planner tool", Scopes: scopes})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error - wedding the user cannot access", func(t *testing.T) {
+		_, _, err := service.CreateKey(ctx, models.NewID(), CreateAPIKeyRequest{Name: "Planner tool", Scopes: scopes})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Error - unknown wedding", func(t *testing.T) {
+		_, _, err := service.CreateKey(ctx, wedding.UserID, CreateAPIKeyRequest{
+			Name:   "Planner tool",
+			Scopes: []APIKeyScopeRequest{{WeddingID: models.NewID().String(), Access: "read"}},
+		})
+		assert.ErrorIs(t, err, ErrWeddingNotFound)
+	})
+
+	t.Run("Error - duplicate wedding scope", func(t *testing.T) {
+		_, _, err := service.CreateKey(ctx, wedding.UserID, CreateAPIKeyRequest{
+			Name:   "Planner tool",
+			Scopes: append(scopes, APIKeyScopeRequest{WeddingID: wedding.ID.String(), Access: "read_write"}),
+		})
+		assert.ErrorIs(t, err, ErrInvalidAPIKeyScope)
+	})
+
+	t.Run("Error - expiry in the past", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+		_, _, err := service.CreateKey(ctx, wedding.UserID, CreateAPIKeyRequest{Name: "Planner tool", Scopes: scopes, ExpiresAt: &past})
+		assert.ErrorIs(t, err, ErrInvalidAPIKeyExpiry)
+	})
+
+	t.Run("Error - too many keys", func(t *testing.T) {
+		for i := 0; i < maxAPIKeysPerUser; i++ {
+			id := models.NewID()
+			keyRepo.keys[id] = &models.APIKey{ID: id, UserID: wedding.UserID}
+		}
+		_, _, err := service.CreateKey(ctx, wedding.UserID, CreateAPIKeyRequest{Name: "Planner tool", Scopes: scopes})
+		assert.ErrorIs(t, err, ErrTooManyAPIKeys)
+	})
+}
+
+func TestAPIKeyService_AuthenticateAPIKey(t *testing.T) {
+	service, _, wedding := setupAPIKeyService(t)
+	ctx := context.Background()
+
+	key, value, err := service.CreateKey(ctx, wedding.UserID, CreateAPIKeyRequest{
+		Name:   "Planner tool",
+		Scopes: []APIKeyScopeRequest{{WeddingID: wedding.ID.String(), Access: "read_write"}},
+	})
+	require.NoError(t, err)
+
+	_, err = service.AuthenticateAPIKey(ctx, "wik_not-a-real-key")
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+	_, err = service.AuthenticateAPIKey(ctx, strings.TrimPrefix(value, "wik_"))
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	expired := time.Now().Add(-time.Minute)
+	key.ExpiresAt = &expired
+	_, err = service.AuthenticateAPIKey(ctx, value)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	key.ExpiresAt = nil
+	require.NoError(t, service.RevokeKey(ctx, wedding.UserID, key.ID))
+	_, err = service.AuthenticateAPIKey(ctx, value)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+}
+
+func TestAPIKeyService_UpdateKey(t *testing.T) {
+	service, keyRepo, wedding := setupAPIKeyService(t)
+	ctx := context.Background()
+
+	key := &models.APIKey{ID: models.NewID(), UserID: wedding.UserID, Name: "Old", RateLimit: 60}
+	keyRepo.keys[key.ID] = key
+
+	name := "Planner tool"
+	limit := 300
+	scopes := []APIKeyScopeRequest{{WeddingID: wedding.ID.String(), Access: "read_write"}}
+	updated, err := service.UpdateKey(ctx, wedding.UserID, key.ID, UpdateAPIKeyRequest{Name: &name, RateLimit: &limit, Scopes: &scopes})
+	require.NoError(t, err)
+	assert.Equal(t, "Planner tool", updated.Name)
+	assert.Equal(t, 300, updated.RateLimit)
+	assert.True(t, updated.Allows(wedding.ID, true))
+
+	// Another user's key is not found
+	_, err = service.UpdateKey(ctx, models.NewID(), key.ID, UpdateAPIKeyRequest{Name: &name})
+	assert.ErrorIs(t, err, ErrAPIKeyNotFound)
+
+	require.NoError(t, service.RevokeKey(ctx, wedding.UserID, key.ID))
+	_, err = service.UpdateKey(ctx, wedding.UserID, key.ID, UpdateAPIKeyRequest{Name: &name})
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+}
+
+func TestAPIKeyService_GetUsage(t *testing.T) {
+	service, keyRepo, wedding := setupAPIKeyService(t)
+	ctx := context.Background()
+
+	key := &models.APIKey{ID: models.NewID(), UserID: wedding.UserID}
+	keyRepo.keys[key.ID] = key
+
+	service.RecordAPIKeyUsage(ctx, key, 200, false)
+	service.RecordAPIKeyUsage(ctx, key, 404, false)
+	service.RecordAPIKeyUsage(ctx, key, 429, true)
+	require.NoError(t, keyRepo.RecordUsage(ctx, key.ID, time.Now().AddDate(0, 0, -2), false, false))
+	require.NoError(t, keyRepo.RecordUsage(ctx, key.ID, time.Now().AddDate(0, 0, -30), false, false))
+
+	report, err := service.GetUsage(ctx, wedding.UserID, key.ID, 7)
+	require.NoError(t, err)
+
+	require.Len(t, report.Days, 7)
+	assert.Equal(t, report.From, report.Days[0].Date)
+	assert.Equal(t, time.Now().UTC().Format("2006-01-02"), report.To)
+
+	today := report.Days[6]
+	assert.Equal(t, int64(3), today.Requests)
+	assert.Equal(t, int64(2), today.Errors)
+	assert.Equal(t, int64(1), today.RateLimited)
+	assert.Equal(t, int64(1), report.Days[4].Requests)
+	assert.Equal(t, int64(0), report.Days[5].Requests)
+	assert.Equal(t, int64(4), report.TotalRequests)
+	assert.Equal(t, int64(2), report.TotalErrors)
+
+	assert.Equal(t, int64(5), key.RequestCount)
+	assert.NotNil(t, key.LastUsedAt)
+
+	_, err = service.GetUsage(ctx, models.NewID(), key.ID, 7)
+	assert.ErrorIs(t, err, ErrAPIKeyNotFound)
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"reflect"
+	"sort"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// AuditRecorder records audited changes made by users, API keys and guests
+type AuditRecorder interface {
+	Record(ctx context.Context, entry *models.AuditLog)
+}
+
+// AuditLogService persists the audit log and lists it for admins and wedding
+// owners. The actor, client IP, user agent and request ID of an entry are taken
+// from the request context.
+type AuditLogService struct {
+	repo        repository.AuditLogRepository
+	weddingRepo repository.WeddingRepository
+	logger      *zap.Logger
+}
+
+// NewAuditLogService creates a new audit log service
+func NewAuditLogService(repo repository.AuditLogRepository, weddingRepo repository.WeddingRepository, logger *zap.Logger) *AuditLogService {
+	return &AuditLogService{
+		repo:        repo,
+		weddingRepo: weddingRepo,
+		logger:      logger,
+	}
+}
+
+// Record stores an audit log entry. Failures are logged rather than returned so
+// that auditing never fails the change it records.
+func (s *AuditLogService) Record(ctx context.Context, entry *models.AuditLog) {
+	actor := utils.ActorFromContext(ctx)
+	if entry.ActorID == nil {
+		if id, err := models.ParseID(actor.UserID); err == nil {
+			entry.ActorID = &id
+		}
+	}
+	if entry.APIKeyID == nil {
+		if id, err := models.ParseID(actor.APIKeyID); err == nil {
+			entry.APIKeyID = &id
+		}
+	}
+	client := utils.ClientFromContext(ctx)
+	entry.IPAddress = client.IP
+	entry.UserAgent = client.UserAgent
+	entry.RequestID = utils.RequestIDFromContext(ctx)
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	if err := s.repo.Create(ctx, entry); err != nil {
+		s.logger.Error("Failed to record audit log",
+			zap.String("action", entry.Action),
+			zap.String("target_id", entry.TargetID),
+			zap.Error(err),
+		)
+	}
+}
+
+// Log records an account action such as a login or a PII reveal by the user,
+// so the service can serve as the handlers' audit logger
+func (s *AuditLogService) Log(ctx context.Context, userID, action string, metadata map[string]interface{}) {
+	entry := &models.AuditLog{
+		Action:     action,
+		TargetType: models.AuditTargetUser,
+		TargetID:   userID,
+		Metadata:   metadata,
+	}
+	if id, err := models.ParseID(userID); err == nil {
+		entry.ActorID = &id
+	}
+	s.Record(ctx, entry)
+}
+
+// ListAuditLogs lists the entries matching the filters across all weddings, newest first
+func (s *AuditLogService) ListAuditLogs(ctx context.Context, filters repository.AuditLogFilters, page, pageSize int) ([]*models.AuditLog, int64, error) {
+	return s.repo.List(ctx, filters, page, pageSize)
+}
+
+// ListWeddingAuditLogs lists the entries of a wedding for its owner, newest first
+func (s *AuditLogService) ListWeddingAuditLogs(ctx context.Context, weddingID, userID models.ID, filters repository.AuditLogFilters, page, pageSize int) ([]*models.AuditLog, int64, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, 0, ErrWeddingNotFound
+		}
+		return nil, 0, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, 0, ErrWeddingNotFound
+	}
+	// Only the owner oversees what collaborators and planners changed
+	if !wedding.Can(userID, models.PermissionManageCollaborators) {
+		return nil, 0, ErrUnauthorized
+	}
+
+	filters.WeddingID = &weddingID
+	return s.repo.List(ctx, filters, page, pageSize)
+}
+
+// auditUnchangedFields are bookkeeping fields left out of audit diffs, along
+// with the change history RSVPs keep of themselves
+var auditUnchangedFields = map[string]bool{
+	"id": true, "created_at": true, "updated_at": true, "createdAt": true, "updatedAt": true,
+	"changes": true,
+}
+
+// auditFields snapshots the JSON form of a record for auditChanges, so fields
+// hidden from the API never reach the audit log. A nil record has no fields.
+func auditFields(record interface{}) map[string]interface{} {
+	if value := reflect.ValueOf(record); !value.IsValid() || (value.Kind() == reflect.Ptr && value.IsNil()) {
+		return nil
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// auditChanges lists the fields that differ between two snapshots taken by
+// auditFields. before is nil for created records and after for deleted ones.
+func auditChanges(before, after map[string]interface{}) []models.AuditChange {
+	names := make([]string, 0, len(before)+len(after))
+	for name := range before {
+		names = append(names, name)
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []models.AuditChange
+	for _, name := range names {
+		if auditUnchangedFields[name] {
+			continue
+		}
+		from, to := before[name], after[name]
+		if reflect.DeepEqual(from, to) {
+			continue
+		}
+		changes = append(changes, models.AuditChange{Field: name, Before: from, After: to})
+	}
+	return changes
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// MockAuditLogRepository is an in-memory audit log repository
+type MockAuditLogRepository struct {
+	entries []*models.AuditLog
+}
+
+func (m *MockAuditLogRepository) Create(ctx context.Context, entry *models.AuditLog) error {
+	if entry.ID.IsZero() {
+		entry.ID = models.NewID()
+	}
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *MockAuditLogRepository) List(ctx context.Context, filters repository.AuditLogFilters, page, pageSize int) ([]*models.AuditLog, int64, error) {
+	var entries []*models.AuditLog
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		entry := m.entries[i]
+		if filters.WeddingID != nil && (entry.WeddingID == nil || *entry.WeddingID != *filters.WeddingID) {
+			continue
+		}
+		if filters.Action != "" && entry.Action != filters.Action {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, int64(len(entries)), nil
+}
+
+func TestAuditLogService_Record(t *testing.T) {
+	repo := &MockAuditLogRepository{}
+	service := NewAuditLogService(repo, &MockWeddingRepository{}, zaptest.NewLogger(t))
+
+	userID := models.NewID()
+	keyID := models.NewID()
+	ctx := utils.WithRequestID(context.Background(), "req-1")
+	ctx = utils.WithClient(ctx, utils.RequestClient{IP: "203.0.113.7", UserAgent: "curl/8.0"})
+	ctx = utils.WithActor(ctx, utils.RequestActor{UserID: userID.String(), APIKeyID: keyID.String()})
+
+	service.Record(ctx, &models.AuditLog{Action: models.AuditGuestDelete, TargetType: models.AuditTargetGuest, TargetID: "g1"})
+	require.Len(t, repo.entries, 1)
+	entry := repo.entries[0]
+	assert.Equal(t, &userID, entry.ActorID)
+	assert.Equal(t, &keyID, entry.APIKeyID)
+	assert.Equal(t, "203.0.113.7", entry.IPAddress)
+	assert.Equal(t, "curl/8.0", entry.UserAgent)
+	assert.Equal(t, "req-1", entry.RequestID)
+	assert.False(t, entry.CreatedAt.IsZero())
+
+	// Guests acting through public links have no actor
+	service.Record(context.Background(), &models.AuditLog{Action: models.AuditRSVPCreate})
+	assert.Nil(t, repo.entries[1].ActorID)
+
+	// Account actions logged for the handlers are made by the user
+	service.Log(context.Background(), userID.String(), "login", map[string]interface{}{"ip": "203.0.113.7"})
+	assert.Equal(t, &userID, repo.entries[2].ActorID)
+	assert.Equal(t, models.AuditTargetUser, repo.entries[2].TargetType)
+}
+
+func TestAuditChanges(t *testing.T) {
+	type record struct {
+		ID        string    `json:"id"`
+		Name      string    `json:"name"`
+		Tags      []string  `json:"tags,omitempty"`
+		Secret    string    `json:"-"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+	before := &record{ID: "1", Name: "Old", Tags: []string{"a"}, Secret: "x", UpdatedAt: time.Now()}
+	after := &record{ID: "1", Name: "New", Secret: "y", UpdatedAt: time.Now().Add(time.Hour)}
+
+	assert.Equal(t, []models.AuditChange{
+		{Field: "name", Before: "Old", After: "New"},
+		{Field: "tags", Before: []interface{}{"a"}},
+	}, auditChanges(auditFields(before), auditFields(after)))
+
+	// Created records list their fields as after values
+	assert.Equal(t, []models.AuditChange{{Field: "name", After: "New"}}, auditChanges(nil, auditFields(after)))
+	assert.Empty(t, auditChanges(auditFields(after), auditFields(after)))
+	assert.Nil(t, auditFields((*record)(nil)))
+}
+
+func TestAuditLogService_ListWeddingAuditLogs(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+	collaboratorID := models.NewID()
+	weddingID := models.NewID()
+	wedding := &models.Wedding{
+		ID:            weddingID,
+		UserID:        ownerID,
+		Collaborators: []models.WeddingCollaborator{{UserID: collaboratorID, Role: models.WeddingRoleCollaborator}},
+	}
+	missingID := models.NewID()
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(wedding, nil)
+	weddingRepo.On("GetByID", mock.Anything, missingID).Return(nil, repository.ErrNotFound)
+
+	repo := &MockAuditLogRepository{}
+	service := NewAuditLogService(repo, weddingRepo, zaptest.NewLogger(t))
+	otherWedding := models.NewID()
+	service.Record(ctx, &models.AuditLog{Action: models.AuditWeddingUpdate, WeddingID: &weddingID})
+	service.Record(ctx, &models.AuditLog{Action: models.AuditGuestCreate, WeddingID: &weddingID})
+	service.Record(ctx, &models.AuditLog{Action: models.AuditWeddingUpdate, WeddingID: &otherWedding})
+
+	t.Run("Success - owner sees the wedding's entries", func(t *testing.T) {
+		entries, total, err := service.ListWeddingAuditLogs(ctx, weddingID, ownerID, repository.AuditLogFilters{WeddingID: &otherWedding}, 1, 20)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, total)
+		assert.Equal(t, models.AuditGuestCreate, entries[0].Action)
+
+		entries, _, err = service.ListWeddingAuditLogs(ctx, weddingID, ownerID, repository.AuditLogFilters{Action: models.AuditWeddingUpdate}, 1, 20)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("Error - collaborator", func(t *testing.T) {
+		_, _, err := service.ListWeddingAuditLogs(ctx, weddingID, collaboratorID, repository.AuditLogFilters{}, 1, 20)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Error - wedding not found", func(t *testing.T) {
+		_, _, err := service.ListWeddingAuditLogs(ctx, missingID, ownerID, repository.AuditLogFilters{}, 1, 20)
+		assert.ErrorIs(t, err, ErrWeddingNotFound)
+	})
+}
+
+func TestThemeService_AuditLog(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockAuditLogRepository{}
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("CountByTheme", mock.Anything, "sunset").Return(int64(0), nil)
+	service := NewThemeService(NewMockThemeRepository(), weddingRepo, zaptest.NewLogger(t))
+	service.SetAuditLog(NewAuditLogService(repo, &MockWeddingRepository{}, zaptest.NewLogger(t)))
+
+	_, err := service.CreateTheme(ctx, validCreateThemeRequest())
+	require.NoError(t, err)
+	name := "Sunrise"
+	_, err = service.UpdateTheme(ctx, "sunset", UpdateThemeRequest{Name: &name})
+	require.NoError(t, err)
+	require.NoError(t, service.DeleteTheme(ctx, "sunset"))
+
+	require.Len(t, repo.entries, 3)
+	assert.Equal(t, models.AuditThemeCreate, repo.entries[0].Action)
+	assert.Equal(t, "sunset", repo.entries[0].TargetID)
+
+	update := repo.entries[1]
+	assert.Equal(t, models.AuditThemeUpdate, update.Action)
+	assert.Equal(t, []models.AuditChange{{Field: "name", Before: validCreateThemeRequest().Name, After: "Sunrise"}}, update.Changes)
+
+	assert.Equal(t, models.AuditThemeDelete, repo.entries[2].Action)
+	assert.NotEmpty(t, repo.entries[2].Changes)
+}
@@ -3,40 +3,82 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"net/url"
+	"strings"
 	"time"
 
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"wedding-invitation-backend/internal/domain/errs"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
 	"wedding-invitation-backend/internal/utils"
 )
 
 var (
-	ErrUserNotFound        = errors.New("user not found")
+	ErrUserNotFound        = errs.NotFound("user not found")
 	ErrInvalidCredentials  = errors.New("invalid email or password")
-	ErrEmailAlreadyExists  = errors.New("email already exists")
+	ErrEmailAlreadyExists  = errs.Conflict("email already exists")
 	ErrInvalidPassword     = errors.New("password does not meet requirements")
 	ErrAccountNotVerified  = errors.New("account not verified")
 	ErrAccountDisabled     = errors.New("account is disabled")
 	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrTwoFactorUnavailable is returned when logging in a user with two-factor
+	// authentication to an auth service without it
+	ErrTwoFactorUnavailable = errors.New("two-factor authentication is not available")
+	// ErrOAuthProviderNotSupported is returned for social login providers that are unknown or not configured
+	ErrOAuthProviderNotSupported = errors.New("sign-in provider is not supported")
+	// ErrOAuthEmailNotVerified is returned when a provider does not vouch for the
+	// account's email, which is needed to create or link an account
+	ErrOAuthEmailNotVerified = errors.New("the provider has not verified the account's email")
+	// ErrVerificationTokenExpired is returned for verification links older than emailVerificationTTL
+	ErrVerificationTokenExpired = errors.New("verification token has expired")
+	// ErrVerificationResendTooSoon is returned when a verification link was sent
+	// less than verificationResendCooldown ago
+	ErrVerificationResendTooSoon = errors.New("verification email was sent too recently")
+)
+
+const (
+	// emailVerificationTTL is how long an email verification link stays valid
+	emailVerificationTTL = 24 * time.Hour
+	// verificationResendCooldown is the least time between two verification emails to an account
+	verificationResendCooldown = time.Minute
 )
 
 type AuthService interface {
 	Register(ctx context.Context, req RegisterRequest) (*AuthResponse, error)
+	// Login returns tokens, or a second-step token for users with two-factor authentication
 	Login(ctx context.Context, req LoginRequest) (*AuthResponse, error)
+	CompleteTwoFactorLogin(ctx context.Context, req TwoFactorLoginRequest) (*AuthResponse, error)
+	// OAuthLogin signs in with a social login provider, creating the account or
+	// linking it to the account with the same email when needed
+	OAuthLogin(ctx context.Context, provider models.AuthProvider, req OAuthLoginRequest) (*AuthResponse, error)
 	RefreshToken(ctx context.Context, refreshToken string) (*AuthResponse, error)
-	Logout(ctx context.Context, userID string, tokenID string) error
-	ChangePassword(ctx context.Context, userID primitive.ObjectID, req ChangePasswordRequest) error
+	// Logout ends the session the request was made with, if known
+	Logout(ctx context.Context, userID string, sessionID string) error
+	ChangePassword(ctx context.Context, userID models.ID, req ChangePasswordRequest) error
 	ForgotPassword(ctx context.Context, email string) (*PasswordResetResponse, error)
 	ResetPassword(ctx context.Context, req ResetPasswordRequest) error
 	VerifyEmail(ctx context.Context, token string) error
-	GetProfile(ctx context.Context, userID primitive.ObjectID) (*models.User, error)
+	// ResendVerification emails an unverified account a new verification link,
+	// invalidating the previous one. Unknown and verified accounts are ignored.
+	ResendVerification(ctx context.Context, email string) error
+	// UnlockAccount lifts a lockout with the token from the emailed unlock link
+	UnlockAccount(ctx context.Context, token string) error
+	GetProfile(ctx context.Context, userID models.ID) (*models.User, error)
 }
 
 type authService struct {
 	userRepo      repository.UserRepository
 	jwtManager    *utils.JWTManager
 	passValidator *utils.PasswordValidator
+	twoFactor     *TwoFactorService
+	oauth         map[models.AuthProvider]OAuthProvider
+	sessions      *SessionService
+	guard         *LoginGuard
+	email         EmailService
+	siteURL       string
+	logger        *zap.Logger
 }
 
 type RegisterRequest struct {
@@ -51,11 +93,32 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// AuthResponse carries the issued tokens. For users with two-factor
+// authentication, Login instead only returns TwoFactorToken, which
+// CompleteTwoFactorLogin exchanges for tokens together with a code.
 type AuthResponse struct {
-	User         *models.User `json:"user"`
-	AccessToken  string       `json:"access_token"`
-	RefreshToken string       `json:"refresh_token"`
-	ExpiresAt    time.Time    `json:"expires_at"`
+	User              *models.User `json:"user,omitempty"`
+	AccessToken       string       `json:"access_token,omitempty"`
+	RefreshToken      string       `json:"refresh_token,omitempty"`
+	ExpiresAt         time.Time    `json:"expires_at"`
+	TwoFactorRequired bool         `json:"two_factor_required,omitempty"`
+	TwoFactorToken    string       `json:"two_factor_token,omitempty"`
+}
+
+// TwoFactorLoginRequest completes a login with a code from the authenticator app or a backup code
+type TwoFactorLoginRequest struct {
+	TwoFactorToken string `json:"two_factor_token" validate:"required"`
+	Code           string `json:"code" validate:"required,max=20"`
+}
+
+// OAuthLoginRequest carries the authorization code from a provider's consent
+// screen. Apple only shares the user's name with the app on the first sign-in,
+// so clients pass it along.
+type OAuthLoginRequest struct {
+	Code        string `json:"code" validate:"required,max=2048"`
+	RedirectURI string `json:"redirect_uri" validate:"required,url"`
+	FirstName   string `json:"first_name,omitempty" validate:"omitempty,max=50"`
+	LastName    string `json:"last_name,omitempty" validate:"omitempty,max=50"`
 }
 
 type ChangePasswordRequest struct {
@@ -63,6 +126,11 @@ type ChangePasswordRequest struct {
 	NewPassword     string `json:"new_password" validate:"required,min=8,max=72"`
 }
 
+// ResendVerificationRequest asks for a new email verification link
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
 type PasswordResetRequest struct {
 	Email string `json:"email" validate:"required,email"`
 }
@@ -77,11 +145,51 @@ type ResetPasswordRequest struct {
 	Password string `json:"password" validate:"required,min=8,max=72"`
 }
 
-func NewAuthService(userRepo repository.UserRepository, jwtManager *utils.JWTManager) AuthService {
+// UnlockAccountRequest carries the token from the emailed unlock link
+type UnlockAccountRequest struct {
+	Token string `json:"token" validate:"required,max=128"`
+}
+
+// AuthDependencies are the optional collaborators of the auth service; each
+// one left out turns its feature off
+type AuthDependencies struct {
+	// TwoFactor asks users who enabled two-factor authentication for a second factor
+	TwoFactor *TwoFactorService
+	// OAuthProviders sign users in with their social login accounts
+	OAuthProviders []OAuthProvider
+	// Sessions tracks every login as a session that can be revoked
+	Sessions *SessionService
+	// LoginGuard locks accounts after too many wrong passwords and tells their
+	// owners about logins from new devices
+	LoginGuard *LoginGuard
+	// Email sends email verification and password reset links pointing to the
+	// frontend at SiteURL
+	Email   EmailService
+	SiteURL string
+	Logger  *zap.Logger
+}
+
+// NewAuthService creates a new auth service
+func NewAuthService(userRepo repository.UserRepository, jwtManager *utils.JWTManager, deps AuthDependencies) AuthService {
+	oauth := make(map[models.AuthProvider]OAuthProvider, len(deps.OAuthProviders))
+	for _, provider := range deps.OAuthProviders {
+		oauth[provider.Name()] = provider
+	}
+	logger := deps.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
 	return &authService{
 		userRepo:      userRepo,
 		jwtManager:    jwtManager,
 		passValidator: utils.NewPasswordValidator(),
+		twoFactor:     deps.TwoFactor,
+		oauth:         oauth,
+		sessions:      deps.Sessions,
+		guard:         deps.LoginGuard,
+		email:         deps.Email,
+		siteURL:       strings.TrimRight(deps.SiteURL, "/"),
+		logger:        logger,
 	}
 }
 
@@ -105,50 +213,55 @@ func (s *authService) Register(ctx context.Context, req RegisterRequest) (*AuthR
 
 	// Create user
 	user := &models.User{
-		ID:           primitive.NewObjectID(),
+		ID:           models.NewID(),
 		FirstName:    req.FirstName,
 		LastName:     req.LastName,
 		Email:        req.Email,
 		PasswordHash: hashedPassword,
 		Status:       models.UserStatusUnverified,
 		Role:         "user",
+		Provider:     models.AuthProviderPassword,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
+	if s.email != nil {
+		if err := s.rotateVerificationToken(user); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
-
-	// Generate tokens
-	tokenPair, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email, []string{user.Role})
-	if err != nil {
-		return nil, err
+	if user.EmailVerificationToken != "" {
+		s.sendVerificationEmail(ctx, user)
 	}
 
-	// Update user login info
-	now := time.Now()
-	user.LastLoginAt = &now
-	user.UpdatedAt = now
-	s.userRepo.Update(ctx, user)
-
-	return &AuthResponse{
-		User:         user,
-		AccessToken:  tokenPair.AccessToken,
-		RefreshToken: tokenPair.RefreshToken,
-		ExpiresAt:    tokenPair.ExpiresAt,
-	}, nil
+	return s.issueTokens(ctx, user)
 }
 
 func (s *authService) Login(ctx context.Context, req LoginRequest) (*AuthResponse, error) {
 	// Get user by email
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
-	if err != nil {
+	if err != nil || user == nil {
 		return nil, ErrUserNotFound
 	}
 
+	// Locked accounts are refused before the password is checked, so that
+	// guesses made during a lockout reveal nothing
+	if s.guard != nil {
+		if err := s.guard.CheckLocked(user); err != nil {
+			return nil, err
+		}
+	}
+
 	// Check password
 	if !utils.CheckPassword(user.PasswordHash, req.Password) {
+		if s.guard != nil {
+			if err := s.guard.RecordFailure(ctx, user); errors.Is(err, ErrAccountLocked) {
+				return nil, err
+			}
+		}
 		return nil, ErrInvalidCredentials
 	}
 
@@ -161,12 +274,172 @@ func (s *authService) Login(ctx context.Context, req LoginRequest) (*AuthRespons
 		return nil, ErrAccountNotVerified
 	}
 
-	// Generate tokens
-	tokenPair, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email, []string{user.Role})
+	// The password only starts the login when a second factor is required
+	return s.startSession(ctx, user)
+}
+
+// startSession issues tokens to a user who signed in, or asks for the second
+// factor first when the user enabled two-factor authentication
+func (s *authService) startSession(ctx context.Context, user *models.User) (*AuthResponse, error) {
+	if user.TwoFactorEnabled() {
+		if s.twoFactor == nil {
+			return nil, ErrTwoFactorUnavailable
+		}
+		token, expiresAt, err := s.twoFactor.StartLogin(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return &AuthResponse{
+			ExpiresAt:         expiresAt,
+			TwoFactorRequired: true,
+			TwoFactorToken:    token,
+		}, nil
+	}
+
+	return s.issueTokens(ctx, user)
+}
+
+func (s *authService) OAuthLogin(ctx context.Context, providerName models.AuthProvider, req OAuthLoginRequest) (*AuthResponse, error) {
+	provider, ok := s.oauth[providerName]
+	if !ok {
+		return nil, ErrOAuthProviderNotSupported
+	}
+	identity, err := provider.Exchange(ctx, req.Code, req.RedirectURI)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByOAuthAccount(ctx, identity.Provider, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		// Accounts are created and linked by email, which only a verified
+		// email may claim, or anyone could take over an account
+		if identity.Email == "" || !identity.EmailVerified {
+			return nil, ErrOAuthEmailNotVerified
+		}
+		if user, err = s.userRepo.GetByEmail(ctx, identity.Email); err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return s.registerOAuthUser(ctx, identity, req)
+		}
+		if err := s.linkOAuthAccount(ctx, user, identity); err != nil {
+			return nil, err
+		}
+	}
+
+	if user.Status == models.UserStatusSuspended {
+		return nil, ErrAccountDisabled
+	}
+	return s.startSession(ctx, user)
+}
+
+// registerOAuthUser creates the account of a first-time social login. It has
+// no password until the user resets one.
+func (s *authService) registerOAuthUser(ctx context.Context, identity *OAuthIdentity, req OAuthLoginRequest) (*AuthResponse, error) {
+	firstName, lastName := identity.FirstName, identity.LastName
+	if firstName == "" {
+		firstName = req.FirstName
+	}
+	if lastName == "" {
+		lastName = req.LastName
+	}
+
+	now := time.Now()
+	user := &models.User{
+		ID:              models.NewID(),
+		FirstName:       firstName,
+		LastName:        lastName,
+		Email:           identity.Email,
+		EmailVerified:   true,
+		EmailVerifiedAt: &now,
+		ProfileImageURL: identity.PictureURL,
+		Status:          models.UserStatusActive,
+		Role:            "user",
+		Provider:        identity.Provider,
+		OAuthAccounts: []models.OAuthAccount{{
+			Provider: identity.Provider,
+			Subject:  identity.Subject,
+			Email:    identity.Email,
+			LinkedAt: now,
+		}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	return s.issueTokens(ctx, user)
+}
+
+// linkOAuthAccount links a social login account to the user with its email.
+// The provider verified the email, so an unverified account becomes verified.
+func (s *authService) linkOAuthAccount(ctx context.Context, user *models.User, identity *OAuthIdentity) error {
+	// The email moved to another account at the provider; the linked one stays
+	if user.OAuthAccountFor(identity.Provider) != nil {
+		return ErrEmailAlreadyExists
+	}
+
+	now := time.Now()
+	user.OAuthAccounts = append(user.OAuthAccounts, models.OAuthAccount{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+		LinkedAt: now,
+	})
+	if !user.EmailVerified {
+		user.EmailVerified = true
+		user.EmailVerifiedAt = &now
+		user.EmailVerificationToken = ""
+		user.EmailVerificationExpires = nil
+	}
+	if user.Status == models.UserStatusUnverified {
+		user.Status = models.UserStatusActive
+	}
+	user.UpdatedAt = now
+	return s.userRepo.Update(ctx, user)
+}
+
+func (s *authService) CompleteTwoFactorLogin(ctx context.Context, req TwoFactorLoginRequest) (*AuthResponse, error) {
+	if s.twoFactor == nil {
+		return nil, ErrTwoFactorUnavailable
+	}
+	user, err := s.twoFactor.CompleteLogin(ctx, req.TwoFactorToken, req.Code)
 	if err != nil {
 		return nil, err
 	}
 
+	// The account may have been suspended while the login was pending
+	if user.Status == models.UserStatusSuspended {
+		return nil, ErrAccountDisabled
+	}
+
+	return s.issueTokens(ctx, user)
+}
+
+// issueTokens issues a token pair to a user who is logged in and records the login
+func (s *authService) issueTokens(ctx context.Context, user *models.User) (*AuthResponse, error) {
+	var sessionID models.ID
+	if s.sessions != nil {
+		sessionID = models.NewID()
+	}
+	tokenPair, err := s.generateTokens(user, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if s.sessions != nil {
+		if err := s.sessions.Start(ctx, sessionID, user.ID, tokenPair.RefreshTokenID, tokenPair.RefreshExpiresAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.guard != nil {
+		s.guard.RecordLogin(ctx, user)
+	}
+
 	// Update user login info
 	now := time.Now()
 	user.LastLoginAt = &now
@@ -181,22 +454,29 @@ func (s *authService) Login(ctx context.Context, req LoginRequest) (*AuthRespons
 	}, nil
 }
 
+// generateTokens generates a token pair for the user, bound to the session if one is given
+func (s *authService) generateTokens(user *models.User, sessionID models.ID) (*utils.TokenPair, error) {
+	sid := ""
+	if !sessionID.IsZero() {
+		sid = sessionID.String()
+	}
+	return s.jwtManager.GenerateSessionTokenPair(user.ID, user.Email, []string{user.Role}, user.TenantID, sid)
+}
+
 func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*AuthResponse, error) {
 	// Validate refresh token
-	tokenPair, err := s.jwtManager.RefreshAccessToken(refreshToken)
+	claims, err := s.jwtManager.ValidateToken(refreshToken, utils.RefreshToken)
 	if err != nil {
 		return nil, ErrInvalidRefreshToken
 	}
-
-	// Extract user info from new access token
-	userID, err := s.jwtManager.ExtractUserIDFromToken(tokenPair.AccessToken)
+	userID, err := models.ParseID(claims.UserID)
 	if err != nil {
 		return nil, ErrInvalidRefreshToken
 	}
 
 	// Get user
 	user, err := s.userRepo.GetByID(ctx, userID)
-	if err != nil {
+	if err != nil || user == nil {
 		return nil, ErrUserNotFound
 	}
 
@@ -205,9 +485,37 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*A
 		return nil, ErrAccountDisabled
 	}
 
-	// Note: Refresh token management would require additional fields in User model
-	// For now, we rely on JWT validation
-	s.userRepo.Update(ctx, user)
+	if s.sessions == nil {
+		tokenPair, err := s.generateTokens(user, models.NilID)
+		if err != nil {
+			return nil, err
+		}
+		return &AuthResponse{
+			User:         user,
+			AccessToken:  tokenPair.AccessToken,
+			RefreshToken: tokenPair.RefreshToken,
+			ExpiresAt:    tokenPair.ExpiresAt,
+		}, nil
+	}
+
+	// Refresh tokens issued before sessions were tracked could never be revoked
+	if claims.SessionID == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+	sessionID, err := models.ParseID(claims.SessionID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	tokenPair, err := s.generateTokens(user, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sessions.Rotate(ctx, sessionID, user.ID, claims.ID, tokenPair.RefreshTokenID, tokenPair.RefreshExpiresAt); err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
 
 	return &AuthResponse{
 		User:         user,
@@ -217,18 +525,27 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*A
 	}, nil
 }
 
-func (s *authService) Logout(ctx context.Context, userID string, tokenID string) error {
-	_, err := primitive.ObjectIDFromHex(userID)
+func (s *authService) Logout(ctx context.Context, userID string, sessionID string) error {
+	uid, err := models.ParseID(userID)
 	if err != nil {
 		return err
 	}
 
-	// Note: Token blacklisting would require additional fields in User model
-	// For now, we rely on token expiration
+	// Ending the session stops its tokens from being refreshed or used
+	if s.sessions == nil || sessionID == "" {
+		return nil
+	}
+	sid, err := models.ParseID(sessionID)
+	if err != nil {
+		return nil
+	}
+	if err := s.sessions.RevokeSession(ctx, uid, sid); err != nil && !errors.Is(err, ErrSessionNotFound) {
+		return err
+	}
 	return nil
 }
 
-func (s *authService) ChangePassword(ctx context.Context, userID primitive.ObjectID, req ChangePasswordRequest) error {
+func (s *authService) ChangePassword(ctx context.Context, userID models.ID, req ChangePasswordRequest) error {
 	// Get user
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -261,7 +578,7 @@ func (s *authService) ChangePassword(ctx context.Context, userID primitive.Objec
 func (s *authService) ForgotPassword(ctx context.Context, email string) (*PasswordResetResponse, error) {
 	// Get user
 	user, err := s.userRepo.GetByEmail(ctx, email)
-	if err != nil {
+	if err != nil || user == nil {
 		return nil, ErrUserNotFound
 	}
 
@@ -280,6 +597,13 @@ func (s *authService) ForgotPassword(ctx context.Context, email string) (*Passwo
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		return nil, err
 	}
+	if s.email != nil {
+		s.sendAccountEmail(ctx, user, EmailTemplatePasswordReset, struct {
+			FirstName string
+			Link      string
+			ExpiresAt time.Time
+		}{user.FirstName, s.accountLink("/reset-password", resetToken), expiresAt})
+	}
 
 	return &PasswordResetResponse{
 		Token:     resetToken,
@@ -290,7 +614,7 @@ func (s *authService) ForgotPassword(ctx context.Context, email string) (*Passwo
 func (s *authService) ResetPassword(ctx context.Context, req ResetPasswordRequest) error {
 	// Get user by reset token
 	user, err := s.userRepo.GetByResetToken(ctx, req.Token)
-	if err != nil {
+	if err != nil || user == nil {
 		return ErrUserNotFound
 	}
 
@@ -315,27 +639,127 @@ func (s *authService) ResetPassword(ctx context.Context, req ResetPasswordReques
 	user.PasswordResetToken = ""
 	user.PasswordResetExpires = nil
 	user.UpdatedAt = time.Now()
+	// Resetting the password proves the email is the owner's
+	if user.LoginSecurity != nil {
+		user.LoginSecurity.Unlock()
+	}
 
-	return s.userRepo.Update(ctx, user)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	// Whoever knew the old password is signed out everywhere
+	if s.sessions != nil {
+		if _, err := s.sessions.RevokeAllSessions(ctx, user.ID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *authService) VerifyEmail(ctx context.Context, token string) error {
 	// Get user by verification token
 	user, err := s.userRepo.GetByVerificationToken(ctx, token)
-	if err != nil {
+	if err != nil || user == nil {
 		return ErrUserNotFound
 	}
 
-	// Update user status
+	// Tokens issued before links expired have no expiry
 	now := time.Now()
-	user.Status = models.UserStatusActive
+	if user.EmailVerificationExpires != nil && now.After(*user.EmailVerificationExpires) {
+		return ErrVerificationTokenExpired
+	}
+
+	// Update user status
+	if user.Status == models.UserStatusUnverified {
+		user.Status = models.UserStatusActive
+	}
+	user.EmailVerified = true
 	user.EmailVerificationToken = ""
+	user.EmailVerificationExpires = nil
 	user.EmailVerifiedAt = &now
 	user.UpdatedAt = now
 
 	return s.userRepo.Update(ctx, user)
 }
 
-func (s *authService) GetProfile(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+func (s *authService) ResendVerification(ctx context.Context, email string) error {
+	if s.email == nil {
+		return nil
+	}
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil || user.HasVerifiedEmail() {
+		return nil
+	}
+	if user.EmailVerificationSentAt != nil && time.Since(*user.EmailVerificationSentAt) < verificationResendCooldown {
+		return ErrVerificationResendTooSoon
+	}
+
+	if err := s.rotateVerificationToken(user); err != nil {
+		return err
+	}
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	s.sendVerificationEmail(ctx, user)
+	return nil
+}
+
+// rotateVerificationToken gives the user a new verification token, replacing
+// any earlier one. The caller stores the user.
+func (s *authService) rotateVerificationToken(user *models.User) error {
+	token, err := utils.GenerateVerificationToken()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	expires := now.Add(emailVerificationTTL)
+	user.EmailVerificationToken = token
+	user.EmailVerificationExpires = &expires
+	user.EmailVerificationSentAt = &now
+	return nil
+}
+
+func (s *authService) sendVerificationEmail(ctx context.Context, user *models.User) {
+	s.sendAccountEmail(ctx, user, EmailTemplateVerification, struct {
+		FirstName string
+		Link      string
+	}{user.FirstName, s.accountLink("/verify-email", user.EmailVerificationToken)})
+}
+
+func (s *authService) UnlockAccount(ctx context.Context, token string) error {
+	if s.guard == nil {
+		return ErrInvalidUnlockToken
+	}
+	return s.guard.Unlock(ctx, token)
+}
+
+func (s *authService) GetProfile(ctx context.Context, userID models.ID) (*models.User, error) {
 	return s.userRepo.GetByID(ctx, userID)
 }
+
+// accountLink returns the frontend link at path carrying the token
+func (s *authService) accountLink(path, token string) string {
+	query := url.Values{}
+	query.Set("token", token)
+	return fmt.Sprintf("%s%s?%s", s.siteURL, path, query.Encode())
+}
+
+// sendAccountEmail emails the user the named template. A failure is only
+// logged; the user can ask for the link again.
+func (s *authService) sendAccountEmail(ctx context.Context, user *models.User, template string, data interface{}) {
+	msg, err := DefaultEmailTemplates().Render(template, data)
+	if err == nil {
+		msg.To = user.Email
+		err = s.email.Send(ctx, msg)
+	}
+	if err != nil {
+		s.logger.Error("Failed to send account email",
+			zap.String("user_id", user.ID.String()),
+			zap.String("template", template),
+			zap.Error(err))
+	}
+}
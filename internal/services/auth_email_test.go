@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/utils"
+)
+
+func newEmailTestAuthService(userRepo *MockUserRepository, email EmailService) AuthService {
+	jwtManager := utils.NewJWTManager("access-secret", "refresh-secret", time.Minute, time.Hour, "test")
+	return NewAuthService(userRepo, jwtManager, AuthDependencies{Email: email, SiteURL: "https://example.com/", Logger: zap.NewNop()})
+}
+
+func TestAuthService_AccountEmails(t *testing.T) {
+	ctx := context.Background()
+	userRepo := &MockUserRepository{}
+	var created *models.User
+	userRepo.On("GetByEmail", mock.Anything, "ana@example.com").Return(nil, nil).Once()
+	userRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.User")).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*models.User)
+	}).Return(nil)
+	userRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.User")).Return(nil)
+	userRepo.On("GetByEmail", mock.Anything, "nobody@example.com").Return(nil, nil)
+
+	email := &MockEmailService{}
+	service := newEmailTestAuthService(userRepo, email)
+
+	_, err := service.Register(ctx, RegisterRequest{
+		FirstName: "Ana",
+		LastName:  "Putri",
+		Email:     "ana@example.com",
+		Password:  "Str0ng!Passw0rd",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	require.NotEmpty(t, created.EmailVerificationToken)
+	require.Len(t, email.sent, 1)
+	assert.Equal(t, "ana@example.com", email.sent[0].To)
+	assert.Equal(t, "Verify your email address", email.sent[0].Subject)
+	assert.Contains(t, email.sent[0].Text, "https://example.com/verify-email?token="+created.EmailVerificationToken)
+
+	userRepo.On("GetByEmail", mock.Anything, "ana@example.com").Return(created, nil)
+	reset, err := service.ForgotPassword(ctx, "ana@example.com")
+	require.NoError(t, err)
+	require.Len(t, email.sent, 2)
+	assert.Equal(t, "Reset your password", email.sent[1].Subject)
+	assert.Contains(t, email.sent[1].Text, "https://example.com/reset-password?token="+reset.Token)
+
+	// Unknown addresses get no email
+	_, err = service.ForgotPassword(ctx, "nobody@example.com")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.Len(t, email.sent, 2)
+}
+
+func TestAuthService_ResendVerification(t *testing.T) {
+	ctx := context.Background()
+	sentAt := time.Now().Add(-time.Hour)
+	expires := sentAt.Add(emailVerificationTTL)
+	user := &models.User{
+		ID:                       models.NewID(),
+		FirstName:                "Ana",
+		Email:                    "ana@example.com",
+		Status:                   models.UserStatusUnverified,
+		EmailVerificationToken:   "old-token",
+		EmailVerificationExpires: &expires,
+		EmailVerificationSentAt:  &sentAt,
+	}
+	verifiedAt := time.Now()
+	verified := &models.User{ID: models.NewID(), Email: "budi@example.com", EmailVerifiedAt: &verifiedAt}
+	userRepo := &MockUserRepository{}
+	userRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+	userRepo.On("GetByEmail", mock.Anything, verified.Email).Return(verified, nil)
+	userRepo.On("GetByEmail", mock.Anything, "nobody@example.com").Return(nil, nil)
+	userRepo.On("Update", mock.Anything, user).Return(nil)
+	email := &MockEmailService{}
+	service := newEmailTestAuthService(userRepo, email)
+
+	require.NoError(t, service.ResendVerification(ctx, user.Email))
+	assert.NotEqual(t, "old-token", user.EmailVerificationToken)
+	assert.WithinDuration(t, time.Now().Add(emailVerificationTTL), *user.EmailVerificationExpires, time.Minute)
+	require.Len(t, email.sent, 1)
+	assert.Contains(t, email.sent[0].Text, "token="+user.EmailVerificationToken)
+
+	// Another link right away is refused
+	assert.ErrorIs(t, service.ResendVerification(ctx, user.Email), ErrVerificationResendTooSoon)
+	assert.Len(t, email.sent, 1)
+
+	// Verified and unknown accounts get nothing
+	require.NoError(t, service.ResendVerification(ctx, verified.Email))
+	require.NoError(t, service.ResendVerification(ctx, "nobody@example.com"))
+	assert.Len(t, email.sent, 1)
+}
+
+func TestAuthService_VerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	expired := time.Now().Add(-time.Minute)
+	valid := time.Now().Add(time.Hour)
+	expiredUser := &models.User{ID: models.NewID(), Status: models.UserStatusUnverified, EmailVerificationToken: "expired", EmailVerificationExpires: &expired}
+	user := &models.User{ID: models.NewID(), Status: models.UserStatusUnverified, EmailVerificationToken: "valid", EmailVerificationExpires: &valid}
+	userRepo := &MockUserRepository{}
+	userRepo.On("GetByVerificationToken", mock.Anything, "expired").Return(expiredUser, nil)
+	userRepo.On("GetByVerificationToken", mock.Anything, "valid").Return(user, nil)
+	userRepo.On("GetByVerificationToken", mock.Anything, "unknown").Return(nil, nil)
+	userRepo.On("Update", mock.Anything, user).Return(nil)
+	service := newEmailTestAuthService(userRepo, &MockEmailService{})
+
+	assert.ErrorIs(t, service.VerifyEmail(ctx, "expired"), ErrVerificationTokenExpired)
+	assert.False(t, expiredUser.HasVerifiedEmail())
+	assert.ErrorIs(t, service.VerifyEmail(ctx, "unknown"), ErrUserNotFound)
+
+	require.NoError(t, service.VerifyEmail(ctx, "valid"))
+	assert.True(t, user.EmailVerified)
+	assert.Equal(t, models.UserStatusActive, user.Status)
+	assert.Empty(t, user.EmailVerificationToken)
+	assert.Nil(t, user.EmailVerificationExpires)
+}
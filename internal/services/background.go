@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// BackgroundTasks runs work that outlives the request starting it, such as
+// sending an email, and lets shutdown wait for it instead of dropping it. Tasks
+// run with a context of their own, which is only cancelled when shutdown runs
+// out of time.
+type BackgroundTasks struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	closed  bool
+	running sync.WaitGroup
+}
+
+// NewBackgroundTasks creates a tracker of background tasks
+func NewBackgroundTasks(logger *zap.Logger) *BackgroundTasks {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &BackgroundTasks{ctx: ctx, cancel: cancel, logger: logger}
+}
+
+// Go runs task in the background. Once shutdown has begun the task runs before
+// Go returns, so late work is still done. Without a tracker, i.e. on a nil
+// *BackgroundTasks, the task runs untracked.
+func (t *BackgroundTasks) Go(name string, task func(ctx context.Context)) {
+	if t == nil {
+		go task(context.Background())
+		return
+	}
+
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		t.run(name, task)
+		return
+	}
+	t.running.Add(1)
+	t.mu.Unlock()
+
+	go func() {
+		defer t.running.Done()
+		t.run(name, task)
+	}()
+}
+
+func (t *BackgroundTasks) run(name string, task func(ctx context.Context)) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.logger.Error("Background task panicked", zap.String("task", name), zap.Any("panic", r))
+		}
+	}()
+	task(t.ctx)
+}
+
+// Shutdown waits for the running tasks until ctx is done, when it cancels the
+// ones left and returns an error
+func (t *BackgroundTasks) Shutdown(ctx context.Context) error {
+	t.mu.Lock()
+	t.closed = true
+	t.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		t.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		t.cancel()
+		return fmt.Errorf("background tasks did not finish in time: %w", ctx.Err())
+	}
+}
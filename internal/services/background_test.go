@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestBackgroundTasks(t *testing.T) {
+	t.Run("Shutdown waits for running tasks", func(t *testing.T) {
+		tasks := NewBackgroundTasks(zaptest.NewLogger(t))
+		var done atomic.Bool
+		tasks.Go("slow", func(ctx context.Context) {
+			time.Sleep(20 * time.Millisecond)
+			done.Store(true)
+		})
+
+		assert.NoError(t, tasks.Shutdown(context.Background()))
+		assert.True(t, done.Load())
+	})
+
+	t.Run("Shutdown cancels tasks when out of time", func(t *testing.T) {
+		tasks := NewBackgroundTasks(zaptest.NewLogger(t))
+		cancelled := make(chan struct{})
+		tasks.Go("stuck", func(ctx context.Context) {
+			<-ctx.Done()
+			close(cancelled)
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, tasks.Shutdown(ctx), context.DeadlineExceeded)
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Fatal("task was not cancelled")
+		}
+	})
+
+	t.Run("Tasks started during shutdown run before Go returns", func(t *testing.T) {
+		tasks := NewBackgroundTasks(zaptest.NewLogger(t))
+		assert.NoError(t, tasks.Shutdown(context.Background()))
+
+		ran := false
+		tasks.Go("late", func(ctx context.Context) { ran = true })
+		assert.True(t, ran)
+	})
+
+	t.Run("A panicking task does not take the process down", func(t *testing.T) {
+		tasks := NewBackgroundTasks(zaptest.NewLogger(t))
+		tasks.Go("panics", func(ctx context.Context) { panic("boom") })
+		assert.NoError(t, tasks.Shutdown(context.Background()))
+	})
+
+	t.Run("Runs untracked without a tracker", func(t *testing.T) {
+		var tasks *BackgroundTasks
+		ran := make(chan struct{})
+		tasks.Go("untracked", func(ctx context.Context) { close(ran) })
+		select {
+		case <-ran:
+		case <-time.After(time.Second):
+			t.Fatal("task did not run")
+		}
+	})
+}
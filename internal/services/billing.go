@@ -0,0 +1,379 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// StripeSignatureHeader carries the signature of Stripe webhook requests
+const StripeSignatureHeader = "Stripe-Signature"
+
+// stripeSignatureTolerance bounds the age of webhook requests, against replays
+const stripeSignatureTolerance = 5 * time.Minute
+
+var (
+	// ErrPlanLimitExceeded is returned when an action needs a higher plan
+	ErrPlanLimitExceeded     = errors.New("plan limit exceeded")
+	ErrInvalidBillingWebhook = errors.New("invalid billing webhook")
+	ErrBillingNotConfigured  = errors.New("billing webhooks are not configured")
+)
+
+// PlanLimiter enforces the limits of the plan a wedding's owner is subscribed to
+type PlanLimiter interface {
+	// CheckWeddingLimit checks the user may create another wedding
+	CheckWeddingLimit(ctx context.Context, userID models.ID) error
+	// CheckGuestLimit checks adding guests keeps the wedding within its owner's plan
+	CheckGuestLimit(ctx context.Context, wedding *models.Wedding, adding int) error
+	// CheckStorageLimit checks storing adding more bytes keeps the user within their plan
+	CheckStorageLimit(ctx context.Context, userID models.ID, adding int64) error
+	// CheckTheme checks the user's plan includes the theme
+	CheckTheme(ctx context.Context, userID models.ID, theme *models.Theme) error
+}
+
+// PlanUsage is how much of their plan a user uses
+type PlanUsage struct {
+	Weddings     int64 `json:"weddings"`
+	StorageBytes int64 `json:"storage_bytes"`
+}
+
+// BillingOverview describes a user's plan, its limits and their usage
+type BillingOverview struct {
+	Plan         models.PlanTier      `json:"plan"`
+	Limits       models.PlanLimits    `json:"limits"`
+	Usage        PlanUsage            `json:"usage"`
+	Subscription *models.Subscription `json:"subscription,omitempty"`
+	// CheckoutURL is where free users upgrade to premium
+	CheckoutURL string `json:"checkout_url,omitempty"`
+}
+
+// BillingService tracks the plans users subscribe to through Stripe and
+// enforces their limits. Users subscribe on a Stripe Payment Link; Stripe's
+// webhook then reports the subscription and its changes.
+type BillingService struct {
+	userRepo    repository.UserRepository
+	weddingRepo repository.WeddingRepository
+	guestRepo   repository.GuestRepository
+	mediaRepo   repository.MediaRepository
+	// webhookSecret verifies Stripe webhooks; checkoutURL is the Payment Link
+	webhookSecret string
+	checkoutURL   string
+	logger        *zap.Logger
+}
+
+var _ PlanLimiter = (*BillingService)(nil)
+
+// NewBillingService creates a new billing service
+func NewBillingService(
+	userRepo repository.UserRepository,
+	weddingRepo repository.WeddingRepository,
+	guestRepo repository.GuestRepository,
+	mediaRepo repository.MediaRepository,
+	webhookSecret, checkoutURL string,
+	logger *zap.Logger,
+) *BillingService {
+	return &BillingService{
+		userRepo:      userRepo,
+		weddingRepo:   weddingRepo,
+		guestRepo:     guestRepo,
+		mediaRepo:     mediaRepo,
+		webhookSecret: webhookSecret,
+		checkoutURL:   checkoutURL,
+		logger:        logger,
+	}
+}
+
+// GetOverview returns the user's plan, its limits and their usage
+func (s *BillingService) GetOverview(ctx context.Context, userID models.ID) (*BillingOverview, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	weddings, err := s.weddingRepo.CountByOwner(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count weddings: %w", err)
+	}
+	storage, err := s.mediaRepo.TotalSizeByCreatedBy(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum storage: %w", err)
+	}
+
+	plan := user.Plan()
+	overview := &BillingOverview{
+		Plan:         plan,
+		Limits:       plan.Limits(),
+		Usage:        PlanUsage{Weddings: weddings, StorageBytes: storage},
+		Subscription: user.Subscription,
+	}
+	if plan == models.PlanFree && s.checkoutURL != "" {
+		overview.CheckoutURL = s.checkoutLink(user)
+	}
+	return overview, nil
+}
+
+// checkoutLink points the Payment Link at the user, so that the completed
+// checkout can be matched to them
+func (s *BillingService) checkoutLink(user *models.User) string {
+	query := url.Values{}
+	query.Set("client_reference_id", user.ID.String())
+	query.Set("prefilled_email", user.Email)
+
+	separator := "?"
+	if strings.Contains(s.checkoutURL, "?") {
+		separator = "&"
+	}
+	return s.checkoutURL + separator + query.Encode()
+}
+
+// CheckWeddingLimit checks the user may create another wedding
+func (s *BillingService) CheckWeddingLimit(ctx context.Context, userID models.ID) error {
+	limits, err := s.limitsOf(ctx, userID)
+	if err != nil {
+		return err
+	}
+	count, err := s.weddingRepo.CountByOwner(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to count weddings: %w", err)
+	}
+	if count >= int64(limits.Weddings) {
+		return fmt.Errorf("%w: your plan allows %d weddings", ErrPlanLimitExceeded, limits.Weddings)
+	}
+	return nil
+}
+
+// CheckGuestLimit checks adding guests keeps the wedding within its owner's plan
+func (s *BillingService) CheckGuestLimit(ctx context.Context, wedding *models.Wedding, adding int) error {
+	limits, err := s.limitsOf(ctx, wedding.UserID)
+	if err != nil {
+		return err
+	}
+	_, count, err := s.guestRepo.ListByWedding(ctx, wedding.ID, 1, 1, repository.GuestFilters{})
+	if err != nil {
+		return fmt.Errorf("failed to count guests: %w", err)
+	}
+	if count+int64(adding) > int64(limits.GuestsPerWedding) {
+		return fmt.Errorf("%w: your plan allows %d guests per wedding", ErrPlanLimitExceeded, limits.GuestsPerWedding)
+	}
+	return nil
+}
+
+// CheckStorageLimit checks storing adding more bytes keeps the user within their plan
+func (s *BillingService) CheckStorageLimit(ctx context.Context, userID models.ID, adding int64) error {
+	limits, err := s.limitsOf(ctx, userID)
+	if err != nil {
+		return err
+	}
+	used, err := s.mediaRepo.TotalSizeByCreatedBy(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to sum storage: %w", err)
+	}
+	if used+adding > limits.StorageBytes {
+		return fmt.Errorf("%w: your plan allows %d MB of uploads", ErrPlanLimitExceeded, limits.StorageBytes/(1024*1024))
+	}
+	return nil
+}
+
+// CheckTheme checks the user's plan includes the theme
+func (s *BillingService) CheckTheme(ctx context.Context, userID models.ID, theme *models.Theme) error {
+	if !theme.Premium {
+		return nil
+	}
+
+	limits, err := s.limitsOf(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !limits.PremiumThemes {
+		return fmt.Errorf("%w: the %s theme needs a premium plan", ErrPlanLimitExceeded, theme.Name)
+	}
+	return nil
+}
+
+func (s *BillingService) limitsOf(ctx context.Context, userID models.ID) (models.PlanLimits, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return models.PlanLimits{}, err
+	}
+	return user.Plan().Limits(), nil
+}
+
+func (s *BillingService) getUser(ctx context.Context, userID models.ID) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// VerifyStripeSignature checks the t=<timestamp>,v1=<hex HMAC> signature Stripe
+// computes over "<timestamp>.<body>" with the endpoint's signing secret
+func VerifyStripeSignature(secret string, body []byte, header string, now time.Time) bool {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > stripeSignatureTolerance || age < -stripeSignatureTolerance {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(expected), []byte(signature)) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripeEvent is the part of a Stripe webhook event the billing service reads
+type stripeEvent struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object json.RawMessage `json:"object"`
+	} `json:"data"`
+}
+
+// stripeCheckoutSession is a completed Payment Link checkout
+type stripeCheckoutSession struct {
+	ClientReferenceID string `json:"client_reference_id"`
+	Customer          string `json:"customer"`
+	Subscription      string `json:"subscription"`
+}
+
+// stripeSubscription is a subscription as reported by subscription events
+type stripeSubscription struct {
+	ID               string `json:"id"`
+	Customer         string `json:"customer"`
+	Status           string `json:"status"`
+	CurrentPeriodEnd int64  `json:"current_period_end"`
+}
+
+// HandleStripeWebhook verifies a Stripe webhook request and applies the
+// subscription change it reports. Events of other types are ignored.
+func (s *BillingService) HandleStripeWebhook(ctx context.Context, body []byte, signature string) error {
+	if s.webhookSecret == "" {
+		return ErrBillingNotConfigured
+	}
+	if !VerifyStripeSignature(s.webhookSecret, body, signature, time.Now()) {
+		return fmt.Errorf("%w: bad signature", ErrInvalidBillingWebhook)
+	}
+
+	var event stripeEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBillingWebhook, err)
+	}
+	at := time.Unix(event.Created, 0)
+
+	switch event.Type {
+	case "checkout.session.completed":
+		var session stripeCheckoutSession
+		if err := json.Unmarshal(event.Data.Object, &session); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidBillingWebhook, err)
+		}
+		return s.applyCheckout(ctx, session, at)
+	case "customer.subscription.created", "customer.subscription.updated", "customer.subscription.deleted":
+		var subscription stripeSubscription
+		if err := json.Unmarshal(event.Data.Object, &subscription); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidBillingWebhook, err)
+		}
+		return s.applySubscription(ctx, subscription, at)
+	}
+	return nil
+}
+
+// applyCheckout subscribes the user who completed a checkout to premium
+func (s *BillingService) applyCheckout(ctx context.Context, session stripeCheckoutSession, at time.Time) error {
+	userID, err := models.ParseID(session.ClientReferenceID)
+	if err != nil || session.Subscription == "" {
+		s.logger.Warn("Ignoring checkout not made through the billing page",
+			zap.String("client_reference_id", session.ClientReferenceID))
+		return nil
+	}
+
+	return s.saveSubscription(ctx, userID, &models.Subscription{
+		Plan:           models.PlanPremium,
+		Status:         models.SubscriptionActive,
+		CustomerID:     session.Customer,
+		SubscriptionID: session.Subscription,
+		UpdatedAt:      at,
+	})
+}
+
+// applySubscription records a subscription change of a known customer.
+// Subscriptions are created before their checkout completes, so those of
+// customers not linked to a user yet are left to the checkout event.
+func (s *BillingService) applySubscription(ctx context.Context, subscription stripeSubscription, at time.Time) error {
+	user, err := s.userRepo.GetByBillingCustomerID(ctx, subscription.Customer)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		s.logger.Info("Ignoring subscription of an unknown customer", zap.String("customer_id", subscription.Customer))
+		return nil
+	}
+
+	updated := &models.Subscription{
+		Plan:           models.PlanPremium,
+		Status:         models.SubscriptionStatus(subscription.Status),
+		CustomerID:     subscription.Customer,
+		SubscriptionID: subscription.ID,
+		UpdatedAt:      at,
+	}
+	if subscription.CurrentPeriodEnd > 0 {
+		periodEnd := time.Unix(subscription.CurrentPeriodEnd, 0)
+		updated.CurrentPeriodEnd = &periodEnd
+	}
+	return s.saveSubscription(ctx, user.ID, updated)
+}
+
+func (s *BillingService) saveSubscription(ctx context.Context, userID models.ID, subscription *models.Subscription) error {
+	if err := s.userRepo.UpdateSubscription(ctx, userID, subscription); err != nil {
+		// A newer event was applied first
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+
+	s.logger.Info("Subscription updated",
+		zap.String("user_id", userID.String()),
+		zap.String("plan", string(subscription.Plan)),
+		zap.String("status", string(subscription.Status)))
+	return nil
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+const testStripeSecret = "whsec_test"
+
+func signStripeEvent(secret string, body []byte, at time.Time) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type billingFixture struct {
+	users    *MockUserRepository
+	weddings *MockWeddingRepository
+	guests   *MockGuestRepository
+	media    *MockMediaRepository
+	service  *BillingService
+}
+
+func newBillingFixture(t *testing.T) *billingFixture {
+	f := &billingFixture{
+		users:    &MockUserRepository{},
+		weddings: &MockWeddingRepository{},
+		guests:   NewMockGuestRepository(),
+		media:    &MockMediaRepository{},
+	}
+	f.service = NewBillingService(f.users, f.weddings, f.guests, f.media,
+		testStripeSecret, "https://buy.stripe.com/test", zaptest.NewLogger(t))
+	return f
+}
+
+func premiumUser() *models.User {
+	return &models.User{
+		ID:    models.NewID(),
+		Email: "couple@example.com",
+		Subscription: &models.Subscription{
+			Plan:   models.PlanPremium,
+			Status: models.SubscriptionActive,
+		},
+	}
+}
+
+func TestVerifyStripeSignature(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+	now := time.Now()
+
+	assert.True(t, VerifyStripeSignature(testStripeSecret, body, signStripeEvent(testStripeSecret, body, now), now))
+	assert.False(t, VerifyStripeSignature(testStripeSecret, body, signStripeEvent("whsec_other", body, now), now))
+	assert.False(t, VerifyStripeSignature(testStripeSecret, []byte(`{"id":"evt_2"}`), signStripeEvent(testStripeSecret, body, now), now))
+	assert.False(t, VerifyStripeSignature(testStripeSecret, body, signStripeEvent(testStripeSecret, body, now.Add(-10*time.Minute)), now))
+	assert.False(t, VerifyStripeSignature(testStripeSecret, body, "", now))
+}
+
+func TestUser_Plan(t *testing.T) {
+	user := &models.User{}
+	assert.Equal(t, models.PlanFree, user.Plan())
+
+	user = premiumUser()
+	assert.Equal(t, models.PlanPremium, user.Plan())
+
+	user.Subscription.Status = models.SubscriptionCanceled
+	assert.Equal(t, models.PlanFree, user.Plan())
+}
+
+func TestBillingService_HandleStripeWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("checkout subscribes the user to premium", func(t *testing.T) {
+		f := newBillingFixture(t)
+		userID := models.NewID()
+		body := []byte(fmt.Sprintf(`{"id":"evt_1","type":"checkout.session.completed","created":%d,"data":{"object":{"client_reference_id":%q,"customer":"cus_1","subscription":"sub_1"}}}`,
+			time.Now().Unix(), userID.String()))
+
+		f.users.On("UpdateSubscription", ctx, userID, mock.MatchedBy(func(s *models.Subscription) bool {
+			return s.Plan == models.PlanPremium && s.Status == models.SubscriptionActive && s.CustomerID == "cus_1" && s.SubscriptionID == "sub_1"
+		})).Return(nil).Once()
+
+		require.NoError(t, f.service.HandleStripeWebhook(ctx, body, signStripeEvent(testStripeSecret, body, time.Now())))
+		f.users.AssertExpectations(t)
+	})
+
+	t.Run("deleted subscription is recorded as canceled", func(t *testing.T) {
+		f := newBillingFixture(t)
+		user := premiumUser()
+		body := []byte(fmt.Sprintf(`{"id":"evt_2","type":"customer.subscription.deleted","created":%d,"data":{"object":{"id":"sub_1","customer":"cus_1","status":"canceled"}}}`,
+			time.Now().Unix()))
+
+		f.users.On("GetByBillingCustomerID", ctx, "cus_1").Return(user, nil).Once()
+		f.users.On("UpdateSubscription", ctx, user.ID, mock.MatchedBy(func(s *models.Subscription) bool {
+			return s.Status == models.SubscriptionCanceled && !s.GrantsPlan()
+		})).Return(nil).Once()
+
+		require.NoError(t, f.service.HandleStripeWebhook(ctx, body, signStripeEvent(testStripeSecret, body, time.Now())))
+		f.users.AssertExpectations(t)
+	})
+
+	t.Run("stale events and unknown customers are ignored", func(t *testing.T) {
+		f := newBillingFixture(t)
+		user := premiumUser()
+		body := []byte(fmt.Sprintf(`{"id":"evt_3","type":"customer.subscription.updated","created":%d,"data":{"object":{"id":"sub_1","customer":"cus_1","status":"active"}}}`,
+			time.Now().Unix()))
+		signature := signStripeEvent(testStripeSecret, body, time.Now())
+
+		f.users.On("GetByBillingCustomerID", ctx, "cus_1").Return(user, nil).Once()
+		f.users.On("UpdateSubscription", ctx, user.ID, mock.Anything).Return(repository.ErrNotFound).Once()
+		require.NoError(t, f.service.HandleStripeWebhook(ctx, body, signature))
+
+		f.users.On("GetByBillingCustomerID", ctx, "cus_1").Return(nil, nil).Once()
+		require.NoError(t, f.service.HandleStripeWebhook(ctx, body, signature))
+		f.users.AssertExpectations(t)
+	})
+
+	t.Run("bad signature", func(t *testing.T) {
+		f := newBillingFixture(t)
+		body := []byte(`{"id":"evt_4","type":"checkout.session.completed"}`)
+
+		err := f.service.HandleStripeWebhook(ctx, body, signStripeEvent("whsec_other", body, time.Now()))
+		assert.ErrorIs(t, err, ErrInvalidBillingWebhook)
+		f.users.AssertNotCalled(t, "UpdateSubscription", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		service := NewBillingService(nil, nil, nil, nil, "", "", zaptest.NewLogger(t))
+		assert.ErrorIs(t, service.HandleStripeWebhook(ctx, []byte(`{}`), ""), ErrBillingNotConfigured)
+	})
+}
+
+func TestBillingService_Limits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("weddings", func(t *testing.T) {
+		f := newBillingFixture(t)
+		free := &models.User{ID: models.NewID()}
+		premium := premiumUser()
+		f.users.On("GetByID", ctx, free.ID).Return(free, nil)
+		f.users.On("GetByID", ctx, premium.ID).Return(premium, nil)
+		f.weddings.On("CountByOwner", ctx, free.ID).Return(int64(1), nil)
+		f.weddings.On("CountByOwner", ctx, premium.ID).Return(int64(1), nil)
+
+		assert.ErrorIs(t, f.service.CheckWeddingLimit(ctx, free.ID), ErrPlanLimitExceeded)
+		assert.NoError(t, f.service.CheckWeddingLimit(ctx, premium.ID))
+	})
+
+	t.Run("guests", func(t *testing.T) {
+		f := newBillingFixture(t)
+		owner := &models.User{ID: models.NewID()}
+		wedding := &models.Wedding{ID: models.NewID(), UserID: owner.ID}
+		f.users.On("GetByID", ctx, owner.ID).Return(owner, nil)
+		for i := 0; i < 149; i++ {
+			require.NoError(t, f.guests.Create(ctx, &models.Guest{WeddingID: wedding.ID}))
+		}
+
+		assert.NoError(t, f.service.CheckGuestLimit(ctx, wedding, 1))
+		assert.ErrorIs(t, f.service.CheckGuestLimit(ctx, wedding, 2), ErrPlanLimitExceeded)
+	})
+
+	t.Run("storage", func(t *testing.T) {
+		f := newBillingFixture(t)
+		owner := &models.User{ID: models.NewID()}
+		f.users.On("GetByID", ctx, owner.ID).Return(owner, nil)
+		f.media.On("TotalSizeByCreatedBy", ctx, owner.ID).Return(int64(199*1024*1024), nil)
+
+		assert.NoError(t, f.service.CheckStorageLimit(ctx, owner.ID, 1024*1024))
+		assert.ErrorIs(t, f.service.CheckStorageLimit(ctx, owner.ID, 2*1024*1024), ErrPlanLimitExceeded)
+	})
+
+	t.Run("premium themes", func(t *testing.T) {
+		f := newBillingFixture(t)
+		free := &models.User{ID: models.NewID()}
+		premium := premiumUser()
+		f.users.On("GetByID", ctx, free.ID).Return(free, nil)
+		f.users.On("GetByID", ctx, premium.ID).Return(premium, nil)
+		classic := &models.Theme{ID: "classic", Name: "Classic"}
+		royal := &models.Theme{ID: "royal", Name: "Royal", Premium: true}
+
+		assert.NoError(t, f.service.CheckTheme(ctx, free.ID, classic))
+		assert.ErrorIs(t, f.service.CheckTheme(ctx, free.ID, royal), ErrPlanLimitExceeded)
+		assert.NoError(t, f.service.CheckTheme(ctx, premium.ID, royal))
+	})
+}
+
+func TestBillingService_GetOverview(t *testing.T) {
+	ctx := context.Background()
+	f := newBillingFixture(t)
+	user := &models.User{ID: models.NewID(), Email: "couple@example.com"}
+	f.users.On("GetByID", ctx, user.ID).Return(user, nil)
+	f.weddings.On("CountByOwner", ctx, user.ID).Return(int64(1), nil)
+	f.media.On("TotalSizeByCreatedBy", ctx, user.ID).Return(int64(4096), nil)
+
+	overview, err := f.service.GetOverview(ctx, user.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, models.PlanFree, overview.Plan)
+	assert.Equal(t, int64(1), overview.Usage.Weddings)
+	assert.Equal(t, int64(4096), overview.Usage.StorageBytes)
+	assert.Contains(t, overview.CheckoutURL, "client_reference_id="+user.ID.String())
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+var (
+	ErrBootstrapDisabled     = errors.New("bootstrap is disabled")
+	ErrInvalidBootstrapToken = errors.New("invalid bootstrap token")
+	ErrAdminAccountExists    = errors.New("an account with the admin email exists and the admin password is not its password")
+)
+
+// BootstrapRequest describes the initial state of a new environment
+type BootstrapRequest struct {
+	AdminEmail       string `json:"admin_email" validate:"required,email"`
+	AdminPassword    string `json:"admin_password" validate:"required,min=8"`
+	AdminFirstName   string `json:"admin_first_name" validate:"required,min=2,max=50"`
+	AdminLastName    string `json:"admin_last_name" validate:"required,min=2,max=50"`
+	RegistrationOpen *bool  `json:"registration_open,omitempty"`
+	DefaultThemeID   string `json:"default_theme_id,omitempty"`
+	SupportEmail     string `json:"support_email,omitempty" validate:"omitempty,email"`
+}
+
+// BootstrapResult reports what a bootstrap run did
+type BootstrapResult struct {
+	AlreadyBootstrapped bool                 `json:"already_bootstrapped"`
+	AdminUserID         models.ID            `json:"admin_user_id,omitempty"`
+	AdminCreated        bool                 `json:"admin_created"`
+	ThemesSeeded        int                  `json:"themes_seeded"`
+	Config              *models.SystemConfig `json:"config,omitempty"`
+}
+
+// BootstrapService provisions a fresh environment: the first admin user, the default
+// theme catalog, and the system configuration. Every step is idempotent so a failed
+// run can simply be retried; once it completes, the bootstrap token is consumed.
+type BootstrapService struct {
+	userRepo      repository.UserRepository
+	systemRepo    repository.SystemRepository
+	themeRepo     repository.ThemeRepository
+	token         string
+	passValidator *utils.PasswordValidator
+	logger        *zap.Logger
+}
+
+// NewBootstrapService creates a new bootstrap service. An empty token disables bootstrapping.
+func NewBootstrapService(userRepo repository.UserRepository, systemRepo repository.SystemRepository, themeRepo repository.ThemeRepository, token string, logger *zap.Logger) *BootstrapService {
+	return &BootstrapService{
+		userRepo:      userRepo,
+		systemRepo:    systemRepo,
+		themeRepo:     themeRepo,
+		token:         token,
+		passValidator: utils.NewPasswordValidator(),
+		logger:        logger,
+	}
+}
+
+// Bootstrap provisions the environment if it has not been bootstrapped yet
+func (s *BootstrapService) Bootstrap(ctx context.Context, token string, req BootstrapRequest) (*BootstrapResult, error) {
+	if s.token == "" {
+		return nil, ErrBootstrapDisabled
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		return nil, ErrInvalidBootstrapToken
+	}
+
+	config, err := s.systemRepo.GetConfig(ctx)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get system config: %w", err)
+	}
+
+	// Re-running a completed bootstrap is a no-op so pipelines can apply it repeatedly
+	if config != nil && config.Bootstrapped {
+		return &BootstrapResult{AlreadyBootstrapped: true, Config: config}, nil
+	}
+
+	result := &BootstrapResult{}
+
+	admin, created, err := s.ensureAdmin(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result.AdminUserID = admin.ID
+	result.AdminCreated = created
+
+	defaultThemeID := ""
+	for _, theme := range models.DefaultThemes() {
+		theme := theme
+		if err := s.themeRepo.Upsert(ctx, &theme); err != nil {
+			return nil, fmt.Errorf("failed to seed theme %s: %w", theme.ID, err)
+		}
+		if theme.IsDefault {
+			defaultThemeID = theme.ID
+		}
+		result.ThemesSeeded++
+	}
+
+	if config == nil {
+		config = &models.SystemConfig{RegistrationOpen: true}
+	}
+	if req.RegistrationOpen != nil {
+		config.RegistrationOpen = *req.RegistrationOpen
+	}
+	config.DefaultThemeID = defaultThemeID
+	if req.DefaultThemeID != "" {
+		config.DefaultThemeID = req.DefaultThemeID
+	}
+	if req.SupportEmail != "" {
+		config.SupportEmail = req.SupportEmail
+	}
+
+	now := time.Now()
+	tokenHash := sha256.Sum256([]byte(token))
+	config.Bootstrapped = true
+	config.BootstrappedAt = &now
+	config.BootstrapTokenHash = hex.EncodeToString(tokenHash[:])
+
+	if err := s.systemRepo.SaveConfig(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save system config: %w", err)
+	}
+	result.Config = config
+
+	s.logger.Info("Environment bootstrapped",
+		zap.String("admin_user_id", admin.ID.String()),
+		zap.Bool("admin_created", created),
+		zap.Int("themes_seeded", result.ThemesSeeded))
+
+	return result, nil
+}
+
+// ensureAdmin creates the admin user or promotes an existing account with the same
+// email. Anyone may have registered that email first, so an existing account is only
+// promoted when the admin password is its password.
+func (s *BootstrapService) ensureAdmin(ctx context.Context, req BootstrapRequest) (*models.User, bool, error) {
+	email := strings.ToLower(strings.TrimSpace(req.AdminEmail))
+
+	existing, err := s.userRepo.GetByEmail(ctx, email)
+	if err == nil && existing != nil {
+		if existing.PasswordHash == "" || !utils.CheckPassword(existing.PasswordHash, req.AdminPassword) {
+			return nil, false, ErrAdminAccountExists
+		}
+		if existing.Role != "admin" || existing.Status != models.UserStatusActive {
+			existing.Role = "admin"
+			existing.Status = models.UserStatusActive
+			existing.UpdatedAt = time.Now()
+			if err := s.userRepo.Update(ctx, existing); err != nil {
+				return nil, false, fmt.Errorf("failed to promote admin user: %w", err)
+			}
+		}
+		return existing, false, nil
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, false, fmt.Errorf("failed to look up admin user: %w", err)
+	}
+
+	if err := s.passValidator.Validate(req.AdminPassword); err != nil {
+		return nil, false, ErrInvalidPassword
+	}
+
+	hashedPassword, err := utils.HashPassword(req.AdminPassword)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	admin := &models.User{
+		ID:              models.NewID(),
+		Email:           email,
+		PasswordHash:    hashedPassword,
+		FirstName:       req.AdminFirstName,
+		LastName:        req.AdminLastName,
+		Name:            req.AdminFirstName + " " + req.AdminLastName,
+		EmailVerified:   true,
+		EmailVerifiedAt: &now,
+		WeddingIDs:      []models.ID{},
+		Status:          models.UserStatusActive,
+		Role:            "admin",
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if err := s.userRepo.Create(ctx, admin); err != nil {
+		return nil, false, fmt.Errorf("failed to create admin user: %w", err)
+	}
+
+	return admin, true, nil
+}
+
+// CreateAdmin creates an active admin account, or promotes the existing account
+// with the email and password to one. Unlike Bootstrap it needs no token and can run at any
+// time, so it is only offered to operators through the admin CLI. It reports
+// whether the account was created.
+func (s *BootstrapService) CreateAdmin(ctx context.Context, email, password, firstName, lastName string) (*models.User, bool, error) {
+	req := BootstrapRequest{
+		AdminEmail:     email,
+		AdminPassword:  password,
+		AdminFirstName: firstName,
+		AdminLastName:  lastName,
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, false, err
+	}
+
+	admin, created, err := s.ensureAdmin(ctx, req)
+	if err != nil {
+		return nil, false, err
+	}
+	s.logger.Info("Admin account provisioned",
+		zap.String("admin_user_id", admin.ID.String()),
+		zap.Bool("admin_created", created))
+	return admin, created, nil
+}
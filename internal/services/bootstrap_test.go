@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// MockSystemRepository is an in-memory system repository
+type MockSystemRepository struct {
+	config *models.SystemConfig
+}
+
+func NewMockSystemRepository() *MockSystemRepository {
+	return &MockSystemRepository{}
+}
+
+func (m *MockSystemRepository) GetConfig(ctx context.Context) (*models.SystemConfig, error) {
+	if m.config == nil {
+		return nil, repository.ErrNotFound
+	}
+	return m.config, nil
+}
+
+func (m *MockSystemRepository) SaveConfig(ctx context.Context, config *models.SystemConfig) error {
+	config.ID = models.SystemConfigID
+	m.config = config
+	return nil
+}
+
+func validBootstrapRequest() BootstrapRequest {
+	return BootstrapRequest{
+		AdminEmail:     "Admin@Example.com",
+		AdminPassword:  "Str0ng!Passw0rd",
+		AdminFirstName: "Site",
+		AdminLastName:  "Admin",
+	}
+}
+
+func hashedTestPassword(t *testing.T, password string) string {
+	hash, err := utils.HashPasswordWithCost(password, 4)
+	require.NoError(t, err)
+	return hash
+}
+
+func TestBootstrapService_Bootstrap(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - provisions and is idempotent", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		systemRepo := NewMockSystemRepository()
+		themeRepo := NewMockThemeRepository()
+		service := NewBootstrapService(userRepo, systemRepo, themeRepo, "bootstrap-token", zaptest.NewLogger(t))
+
+		userRepo.On("GetByEmail", ctx, "admin@example.com").Return(nil, repository.ErrNotFound).Once()
+		userRepo.On("Create", ctx, mock.AnythingOfType("*models.User")).Return(nil).Once()
+
+		result, err := service.Bootstrap(ctx, "bootstrap-token", validBootstrapRequest())
+		require.NoError(t, err)
+
+		assert.False(t, result.AlreadyBootstrapped)
+		assert.True(t, result.AdminCreated)
+		assert.Equal(t, len(models.DefaultThemes()), result.ThemesSeeded)
+		assert.Len(t, themeRepo.themes, len(models.DefaultThemes()))
+		require.NotNil(t, systemRepo.config)
+		assert.True(t, systemRepo.config.Bootstrapped)
+		assert.True(t, systemRepo.config.RegistrationOpen)
+		assert.Equal(t, "classic", systemRepo.config.DefaultThemeID)
+		assert.NotEmpty(t, systemRepo.config.BootstrapTokenHash)
+
+		created := userRepo.Calls[1].Arguments.Get(1).(*models.User)
+		assert.Equal(t, "admin", created.Role)
+		assert.Equal(t, models.UserStatusActive, created.Status)
+		assert.True(t, created.EmailVerified)
+
+		// A second run does nothing
+		again, err := service.Bootstrap(ctx, "bootstrap-token", validBootstrapRequest())
+		require.NoError(t, err)
+		assert.True(t, again.AlreadyBootstrapped)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - promotes existing user", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		systemRepo := NewMockSystemRepository()
+		themeRepo := NewMockThemeRepository()
+		service := NewBootstrapService(userRepo, systemRepo, themeRepo, "bootstrap-token", zaptest.NewLogger(t))
+
+		existing := &models.User{Email: "admin@example.com", PasswordHash: hashedTestPassword(t, "Str0ng!Passw0rd"), Role: "user", Status: models.UserStatusUnverified}
+		userRepo.On("GetByEmail", ctx, "admin@example.com").Return(existing, nil)
+		userRepo.On("Update", ctx, existing).Return(nil)
+
+		result, err := service.Bootstrap(ctx, "bootstrap-token", validBootstrapRequest())
+		require.NoError(t, err)
+		assert.False(t, result.AdminCreated)
+		assert.Equal(t, "admin", existing.Role)
+		assert.Equal(t, models.UserStatusActive, existing.Status)
+	})
+
+	t.Run("Error - existing user with another password", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		systemRepo := NewMockSystemRepository()
+		service := NewBootstrapService(userRepo, systemRepo, NewMockThemeRepository(), "bootstrap-token", zaptest.NewLogger(t))
+
+		existing := &models.User{Email: "admin@example.com", PasswordHash: hashedTestPassword(t, "Squatt3r!Passw0rd"), Role: "user", Status: models.UserStatusActive}
+		userRepo.On("GetByEmail", ctx, "admin@example.com").Return(existing, nil)
+
+		_, err := service.Bootstrap(ctx, "bootstrap-token", validBootstrapRequest())
+		assert.ErrorIs(t, err, ErrAdminAccountExists)
+		assert.Equal(t, "user", existing.Role)
+		assert.Nil(t, systemRepo.config, "the bootstrap can be retried with another email")
+		userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - wrong token", func(t *testing.T) {
+		service := NewBootstrapService(&MockUserRepository{}, NewMockSystemRepository(), NewMockThemeRepository(), "bootstrap-token", zaptest.NewLogger(t))
+
+		_, err := service.Bootstrap(ctx, "nope", validBootstrapRequest())
+		assert.ErrorIs(t, err, ErrInvalidBootstrapToken)
+	})
+
+	t.Run("Error - disabled without token", func(t *testing.T) {
+		service := NewBootstrapService(&MockUserRepository{}, NewMockSystemRepository(), NewMockThemeRepository(), "", zaptest.NewLogger(t))
+
+		_, err := service.Bootstrap(ctx, "", validBootstrapRequest())
+		assert.ErrorIs(t, err, ErrBootstrapDisabled)
+	})
+}
+
+func TestBootstrapService_CreateAdmin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - works without a bootstrap token", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		service := NewBootstrapService(userRepo, NewMockSystemRepository(), NewMockThemeRepository(), "", zaptest.NewLogger(t))
+
+		userRepo.On("GetByEmail", ctx, "ops@example.com").Return(nil, repository.ErrNotFound).Once()
+		userRepo.On("Create", ctx, mock.AnythingOfType("*models.User")).Return(nil).Once()
+
+		admin, created, err := service.CreateAdmin(ctx, "Ops@Example.com", "Str0ng!Passw0rd", "Site", "Ops")
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, "admin", admin.Role)
+		assert.Equal(t, "ops@example.com", admin.Email)
+	})
+
+	t.Run("Success - promotes an existing account", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		service := NewBootstrapService(userRepo, NewMockSystemRepository(), NewMockThemeRepository(), "", zaptest.NewLogger(t))
+
+		existing := &models.User{ID: models.NewID(), Email: "ops@example.com", PasswordHash: hashedTestPassword(t, "Str0ng!Passw0rd"), Role: "user", Status: models.UserStatusActive}
+		userRepo.On("GetByEmail", ctx, "ops@example.com").Return(existing, nil).Once()
+		userRepo.On("Update", ctx, existing).Return(nil).Once()
+
+		admin, created, err := service.CreateAdmin(ctx, "ops@example.com", "Str0ng!Passw0rd", "Site", "Ops")
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, "admin", admin.Role)
+	})
+
+	t.Run("Error - existing account without a password", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		service := NewBootstrapService(userRepo, NewMockSystemRepository(), NewMockThemeRepository(), "", zaptest.NewLogger(t))
+
+		// Signed up through social login
+		existing := &models.User{ID: models.NewID(), Email: "ops@example.com", Role: "user", Status: models.UserStatusActive}
+		userRepo.On("GetByEmail", ctx, "ops@example.com").Return(existing, nil).Once()
+
+		_, _, err := service.CreateAdmin(ctx, "ops@example.com", "Str0ng!Passw0rd", "Site", "Ops")
+		assert.ErrorIs(t, err, ErrAdminAccountExists)
+		assert.Equal(t, "user", existing.Role)
+	})
+
+	t.Run("Error - invalid input", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		service := NewBootstrapService(userRepo, NewMockSystemRepository(), NewMockThemeRepository(), "", zaptest.NewLogger(t))
+
+		_, _, err := service.CreateAdmin(ctx, "not-an-email", "Str0ng!Passw0rd", "Site", "Ops")
+		assert.Error(t, err)
+
+		userRepo.On("GetByEmail", ctx, "ops@example.com").Return(nil, repository.ErrNotFound).Once()
+		_, _, err = service.CreateAdmin(ctx, "ops@example.com", "weakpassword", "Site", "Ops")
+		assert.ErrorIs(t, err, ErrInvalidPassword)
+		userRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// botUserAgentMarkers are parts of the user agents of crawlers, link
+// previewers, monitors and HTTP libraries. Bare "bot" is left out as it is
+// part of phone names such as CUBOT; crawlers name themselves "...bot/x.y".
+var botUserAgentMarkers = []string{
+	"bot/", "bot;", "bot)", "bot-", "-bot", "_bot",
+	"crawler", "spider", "slurp", "crawl",
+	"facebookexternalhit", "facebookcatalog", "whatsapp", "preview", "embedly",
+	"pingdom", "statuscake", "uptime", "monitor",
+	"curl/", "wget/", "httpie/", "python-requests", "python-urllib", "aiohttp", "scrapy",
+	"go-http-client", "java/", "okhttp", "apache-httpclient", "axios/", "node-fetch",
+	"libwww-perl", "postmanruntime", "insomnia",
+}
+
+// headlessUserAgentMarkers are parts of the user agents of automated browsers
+var headlessUserAgentMarkers = []string{
+	"headlesschrome", "phantomjs", "puppeteer", "playwright", "selenium", "webdriver",
+	"chrome-lighthouse", "pagespeed",
+}
+
+// botIPRanges are networks crawlers fetch from, some of them with a browser
+// user agent: Googlebot, Bingbot, Meta's crawlers, Baidu and Semrush
+var botIPRanges = mustParseCIDRs(
+	"66.249.64.0/19",
+	"157.55.39.0/24", "207.46.13.0/24", "40.77.167.0/24", "13.66.139.0/24",
+	"31.13.24.0/21", "66.220.144.0/20", "69.63.176.0/20", "69.171.224.0/19", "173.252.64.0/18",
+	"180.76.15.0/24", "220.181.108.0/24",
+	"85.208.96.0/24", "185.191.171.0/24",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+// isBot reports whether the client of a tracking request looks automated: by
+// its user agent, the network it comes from, or signals of a headless browser.
+// Events without a request, such as those tracked by the server itself, are
+// never bots.
+func isBot(req *http.Request, userAgent, ipAddress string) bool {
+	if req == nil {
+		return false
+	}
+	userAgent = strings.ToLower(strings.TrimSpace(userAgent))
+	if userAgent == "" {
+		return true
+	}
+	for _, marker := range botUserAgentMarkers {
+		if strings.Contains(userAgent, marker) {
+			return true
+		}
+	}
+	for _, marker := range headlessUserAgentMarkers {
+		if strings.Contains(userAgent, marker) {
+			return true
+		}
+	}
+
+	if ip := net.ParseIP(ipAddress); ip != nil {
+		for _, network := range botIPRanges {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+	}
+
+	// Headless Chrome may hide in its user agent but not in its client hints,
+	// and browsers always send Accept-Language where automation often does not
+	if strings.Contains(strings.ToLower(req.Header.Get("Sec-CH-UA")), "headless") {
+		return true
+	}
+	return strings.HasPrefix(userAgent, "mozilla/") && req.Header.Get("Accept-Language") == ""
+}
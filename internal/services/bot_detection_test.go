@@ -0,0 +1,44 @@
+package services
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBot(t *testing.T) {
+	const chrome = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+
+	tests := []struct {
+		name      string
+		userAgent string
+		ip        string
+		headers   map[string]string
+		want      bool
+	}{
+		{name: "browser", userAgent: chrome, ip: "203.0.113.7", want: false},
+		{name: "phone named like a bot", userAgent: "Mozilla/5.0 (Linux; Android 9; CUBOT P30) AppleWebKit/537.36 Chrome/120.0 Mobile Safari/537.36", ip: "203.0.113.7", want: false},
+		{name: "no user agent", userAgent: "", ip: "203.0.113.7", want: true},
+		{name: "crawler", userAgent: "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", ip: "203.0.113.7", want: true},
+		{name: "link preview", userAgent: "facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)", ip: "203.0.113.7", want: true},
+		{name: "http library", userAgent: "python-requests/2.31.0", ip: "203.0.113.7", want: true},
+		{name: "headless user agent", userAgent: "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 HeadlessChrome/120.0.0.0 Safari/537.36", ip: "203.0.113.7", want: true},
+		{name: "headless client hints", userAgent: chrome, ip: "203.0.113.7", headers: map[string]string{"Sec-CH-UA": `"HeadlessChrome";v="120"`}, want: true},
+		{name: "browser without languages", userAgent: chrome, ip: "203.0.113.7", headers: map[string]string{"Accept-Language": ""}, want: true},
+		{name: "crawler network", userAgent: chrome, ip: "66.249.66.1", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/analytics/track/page-view", nil)
+			req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			assert.Equal(t, tt.want, isBot(req, tt.userAgent, tt.ip))
+		})
+	}
+
+	assert.False(t, isBot(nil, "", ""), "server-side events are never bots")
+}
@@ -0,0 +1,645 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/text/currency"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+const (
+	// maxBudgetCategories bounds the categories of a wedding's budget
+	maxBudgetCategories = 50
+	// maxBudgetPayments bounds the payments of a wedding's budget
+	maxBudgetPayments = 500
+	// defaultPaymentReminderDays is how many days before its due date a payment is reminded of
+	defaultPaymentReminderDays = 7
+	// maxPaymentReminderDays bounds how early a payment can be reminded of
+	maxPaymentReminderDays = 60
+	// budgetUpcomingWindow is how far ahead the budget summary lists payments due
+	budgetUpcomingWindow = 30 * 24 * time.Hour
+	// paymentReminderBatchSize bounds how many reminders are sent per scheduler run
+	paymentReminderBatchSize = 50
+	// paymentReminderRetryInterval is how soon a failed reminder is retried
+	paymentReminderRetryInterval = time.Hour
+)
+
+var (
+	ErrBudgetCategoryNotFound = errs.NotFound("budget category not found")
+	ErrBudgetCategoryInUse    = errors.New("budget category still has payments")
+	ErrBudgetPaymentNotFound  = errs.NotFound("budget payment not found")
+	ErrInvalidBudgetPayment   = errors.New("invalid budget payment")
+	ErrBudgetFull             = errors.New("a budget holds at most 50 categories and 500 payments")
+)
+
+// BudgetCategoryRequest describes a budget category
+type BudgetCategoryRequest struct {
+	Name    string `json:"name" validate:"required,max=100"`
+	Planned int64  `json:"planned" validate:"gte=0"`
+	Notes   string `json:"notes,omitempty" validate:"omitempty,max=500"`
+	Order   int    `json:"order"`
+}
+
+// BudgetPaymentRequest describes a payment to a vendor. Amounts are in the
+// smallest unit of the wedding's budget currency.
+type BudgetPaymentRequest struct {
+	CategoryID  string     `json:"category_id,omitempty"`
+	Vendor      string     `json:"vendor" validate:"required,max=100"`
+	Description string     `json:"description,omitempty" validate:"omitempty,max=500"`
+	Amount      int64      `json:"amount" validate:"gte=0"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	Paid        bool       `json:"paid"`
+	// PaidAt defaults to now for paid payments
+	PaidAt *time.Time `json:"paid_at,omitempty"`
+	// ReminderDays defaults to 7; 0 reminds on the due date
+	ReminderDays *int `json:"reminder_days,omitempty" validate:"omitempty,gte=0,lte=60"`
+}
+
+// BudgetService lets wedding editors plan their spending by category, track what
+// they owe and paid vendors, and reminds wedding owners of payments coming due
+type BudgetService struct {
+	budgetRepo  repository.BudgetRepository
+	weddingRepo repository.WeddingRepository
+	userRepo    repository.UserRepository
+	email       EmailService
+	logger      *zap.Logger
+}
+
+// NewBudgetService creates a new wedding budget service
+func NewBudgetService(
+	budgetRepo repository.BudgetRepository,
+	weddingRepo repository.WeddingRepository,
+	userRepo repository.UserRepository,
+	email EmailService,
+	logger *zap.Logger,
+) *BudgetService {
+	return &BudgetService{
+		budgetRepo:  budgetRepo,
+		weddingRepo: weddingRepo,
+		userRepo:    userRepo,
+		email:       email,
+		logger:      logger,
+	}
+}
+
+// GetSummary compares a wedding's planned spending with its payments by category
+// and lists the unpaid payments due within 30 days
+func (s *BudgetService) GetSummary(ctx context.Context, weddingID, userID models.ID) (*models.BudgetSummary, error) {
+	wedding, err := s.getEditableWedding(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.budgetRepo.ListCategories(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := s.budgetRepo.ListPayments(ctx, weddingID, repository.BudgetPaymentFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	summary := &models.BudgetSummary{
+		WeddingID:   weddingID,
+		Currency:    wedding.Budget.Currency,
+		Total:       wedding.Budget.Total,
+		Categories:  make([]models.BudgetCategorySummary, 0, len(categories)+1),
+		Upcoming:    []*models.BudgetPayment{},
+		GeneratedAt: now,
+	}
+
+	index := make(map[models.ID]int, len(categories))
+	for _, category := range categories {
+		index[category.ID] = len(summary.Categories)
+		id := category.ID
+		summary.Categories = append(summary.Categories, models.BudgetCategorySummary{
+			CategoryID: &id,
+			Name:       category.Name,
+			Planned:    category.Planned,
+		})
+		summary.Planned += category.Planned
+	}
+
+	uncategorized := models.BudgetCategorySummary{Name: "Uncategorized"}
+	var overdue []*models.BudgetPayment
+	for _, payment := range payments {
+		category := &uncategorized
+		if payment.CategoryID != nil {
+			if i, ok := index[*payment.CategoryID]; ok {
+				category = &summary.Categories[i]
+			}
+		}
+		category.Payments++
+		category.Actual += payment.Amount
+		summary.Actual += payment.Amount
+		if payment.Status == models.BudgetPaymentPaid {
+			category.Paid += payment.Amount
+			summary.Paid += payment.Amount
+		}
+
+		switch {
+		case payment.IsOverdue(now):
+			summary.Overdue += payment.Amount
+			overdue = append(overdue, payment)
+		case payment.Status != models.BudgetPaymentPaid && payment.DueDate != nil && payment.DueDate.Before(now.Add(budgetUpcomingWindow)):
+			summary.Upcoming = append(summary.Upcoming, payment)
+		}
+	}
+	// Payments are listed by due date, so overdue ones come first already sorted
+	summary.Upcoming = append(overdue, summary.Upcoming...)
+	if uncategorized.Payments > 0 {
+		summary.Categories = append(summary.Categories, uncategorized)
+	}
+
+	for i := range summary.Categories {
+		category := &summary.Categories[i]
+		category.Outstanding = category.Actual - category.Paid
+		category.Variance = category.Planned - category.Actual
+	}
+	summary.Outstanding = summary.Actual - summary.Paid
+	summary.Remaining = summary.Total - summary.Actual
+
+	return summary, nil
+}
+
+// ListCategories lists the categories of a wedding's budget
+func (s *BudgetService) ListCategories(ctx context.Context, weddingID, userID models.ID) ([]*models.BudgetCategory, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+	return s.budgetRepo.ListCategories(ctx, weddingID)
+}
+
+// CreateCategory adds a category to a wedding's budget
+func (s *BudgetService) CreateCategory(ctx context.Context, weddingID, userID models.ID, req BudgetCategoryRequest) (*models.BudgetCategory, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	categories, err := s.budgetRepo.ListCategories(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) >= maxBudgetCategories {
+		return nil, ErrBudgetFull
+	}
+
+	category := &models.BudgetCategory{WeddingID: weddingID}
+	applyBudgetCategory(category, req)
+	if err := s.budgetRepo.CreateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// UpdateCategory replaces the details of a budget category
+func (s *BudgetService) UpdateCategory(ctx context.Context, weddingID, categoryID, userID models.ID, req BudgetCategoryRequest) (*models.BudgetCategory, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+	category, err := s.getCategory(ctx, weddingID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	applyBudgetCategory(category, req)
+	if err := s.budgetRepo.UpdateCategory(ctx, category); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrBudgetCategoryNotFound
+		}
+		return nil, err
+	}
+	return category, nil
+}
+
+// DeleteCategory removes a budget category without payments
+func (s *BudgetService) DeleteCategory(ctx context.Context, weddingID, categoryID, userID models.ID) error {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return err
+	}
+	if _, err := s.getCategory(ctx, weddingID, categoryID); err != nil {
+		return err
+	}
+
+	payments, err := s.budgetRepo.ListPayments(ctx, weddingID, repository.BudgetPaymentFilters{CategoryID: &categoryID})
+	if err != nil {
+		return err
+	}
+	if len(payments) > 0 {
+		return ErrBudgetCategoryInUse
+	}
+
+	if err := s.budgetRepo.DeleteCategory(ctx, categoryID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrBudgetCategoryNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ListPayments lists the payments of a wedding's budget by due date
+func (s *BudgetService) ListPayments(ctx context.Context, weddingID, userID models.ID, filters repository.BudgetPaymentFilters) ([]*models.BudgetPayment, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+	if filters.Status != "" && filters.Status != models.BudgetPaymentPending && filters.Status != models.BudgetPaymentPaid {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidBudgetPayment, filters.Status)
+	}
+	return s.budgetRepo.ListPayments(ctx, weddingID, filters)
+}
+
+// CreatePayment records a payment to a vendor
+func (s *BudgetService) CreatePayment(ctx context.Context, weddingID, userID models.ID, req BudgetPaymentRequest) (*models.BudgetPayment, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	payments, err := s.budgetRepo.ListPayments(ctx, weddingID, repository.BudgetPaymentFilters{})
+	if err != nil {
+		return nil, err
+	}
+	if len(payments) >= maxBudgetPayments {
+		return nil, ErrBudgetFull
+	}
+
+	payment := &models.BudgetPayment{WeddingID: weddingID}
+	if err := s.applyBudgetPayment(ctx, payment, req, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.budgetRepo.CreatePayment(ctx, payment); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// UpdatePayment replaces the details of a payment, e.g. to record that it was paid
+func (s *BudgetService) UpdatePayment(ctx context.Context, weddingID, paymentID, userID models.ID, req BudgetPaymentRequest) (*models.BudgetPayment, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+	payment, err := s.getPayment(ctx, weddingID, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyBudgetPayment(ctx, payment, req, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.budgetRepo.UpdatePayment(ctx, payment); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrBudgetPaymentNotFound
+		}
+		return nil, err
+	}
+	return payment, nil
+}
+
+// DeletePayment removes a payment from a wedding's budget
+func (s *BudgetService) DeletePayment(ctx context.Context, weddingID, paymentID, userID models.ID) error {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return err
+	}
+	if _, err := s.getPayment(ctx, weddingID, paymentID); err != nil {
+		return err
+	}
+	if err := s.budgetRepo.DeletePayment(ctx, paymentID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrBudgetPaymentNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// SendDueReminders emails wedding owners about the payments whose reminder is due
+// and returns how many reminders were sent
+func (s *BudgetService) SendDueReminders(ctx context.Context, now time.Time) (int, error) {
+	sent := 0
+	for sent < paymentReminderBatchSize {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+
+		payment, err := s.budgetRepo.ClaimDueReminder(ctx, now)
+		if errors.Is(err, repository.ErrNotFound) {
+			return sent, nil
+		}
+		if err != nil {
+			return sent, err
+		}
+
+		err = s.remind(ctx, payment)
+		if errors.Is(err, repository.ErrNotFound) {
+			// The wedding or its owner is gone; nobody is left to remind
+			continue
+		}
+		if err != nil {
+			s.logger.Warn("Failed to send payment reminder", zap.Error(err), zap.String("payment_id", payment.ID.String()))
+			if err := s.budgetRepo.RescheduleReminder(ctx, payment.ID, now.Add(paymentReminderRetryInterval)); err != nil {
+				s.logger.Error("Failed to reschedule payment reminder", zap.Error(err), zap.String("payment_id", payment.ID.String()))
+			}
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// remind emails the owner of the payment's wedding that it is coming due
+func (s *BudgetService) remind(ctx context.Context, payment *models.BudgetPayment) error {
+	wedding, err := s.weddingRepo.GetByID(ctx, payment.WeddingID)
+	if err != nil {
+		return err
+	}
+	if wedding == nil {
+		return repository.ErrNotFound
+	}
+
+	// Reminders always go to the current owner, even if the wedding changed hands
+	owner, err := s.userRepo.GetByID(ctx, wedding.UserID)
+	if err != nil {
+		return err
+	}
+	if owner == nil {
+		return repository.ErrNotFound
+	}
+
+	msg, err := renderPaymentReminder(wedding, payment)
+	if err != nil {
+		return err
+	}
+	msg.To = owner.Email
+	return s.email.Send(ctx, msg)
+}
+
+// applyBudgetPayment copies the details of a request onto a payment and schedules its reminder
+func (s *BudgetService) applyBudgetPayment(ctx context.Context, payment *models.BudgetPayment, req BudgetPaymentRequest, now time.Time) error {
+	payment.CategoryID = nil
+	if req.CategoryID != "" {
+		categoryID, err := models.ParseID(req.CategoryID)
+		if err != nil {
+			return ErrBudgetCategoryNotFound
+		}
+		category, err := s.getCategory(ctx, payment.WeddingID, categoryID)
+		if err != nil {
+			return err
+		}
+		payment.CategoryID = &category.ID
+	}
+
+	vendor := strings.TrimSpace(req.Vendor)
+	if vendor == "" {
+		return fmt.Errorf("%w: vendor is required", ErrInvalidBudgetPayment)
+	}
+	reminderDays := defaultPaymentReminderDays
+	if req.ReminderDays != nil {
+		reminderDays = *req.ReminderDays
+	}
+	if req.Amount < 0 || reminderDays < 0 || reminderDays > maxPaymentReminderDays {
+		return fmt.Errorf("%w: amount must not be negative and reminders are at most %d days ahead", ErrInvalidBudgetPayment, maxPaymentReminderDays)
+	}
+
+	payment.Vendor = vendor
+	payment.Description = strings.TrimSpace(req.Description)
+	payment.Amount = req.Amount
+
+	payment.Status = models.BudgetPaymentPending
+	payment.PaidAt = nil
+	if req.Paid {
+		payment.Status = models.BudgetPaymentPaid
+		paidAt := now
+		if req.PaidAt != nil {
+			paidAt = req.PaidAt.UTC()
+		}
+		payment.PaidAt = &paidAt
+	}
+
+	// A new due date or reminder schedule is reminded of again
+	var remindAt *time.Time
+	if req.DueDate != nil {
+		at := req.DueDate.UTC().AddDate(0, 0, -reminderDays)
+		remindAt = &at
+	}
+	if !sameTime(payment.RemindAt, remindAt) {
+		payment.RemindedAt = nil
+	}
+	payment.DueDate = nil
+	if req.DueDate != nil {
+		dueDate := req.DueDate.UTC()
+		payment.DueDate = &dueDate
+	}
+	payment.ReminderDays = reminderDays
+	payment.RemindAt = remindAt
+	return nil
+}
+
+// getCategory returns a category of a wedding's budget
+func (s *BudgetService) getCategory(ctx context.Context, weddingID, categoryID models.ID) (*models.BudgetCategory, error) {
+	category, err := s.budgetRepo.GetCategory(ctx, categoryID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrBudgetCategoryNotFound
+		}
+		return nil, err
+	}
+	if category.WeddingID != weddingID {
+		return nil, ErrBudgetCategoryNotFound
+	}
+	return category, nil
+}
+
+// getPayment returns a payment of a wedding's budget
+func (s *BudgetService) getPayment(ctx context.Context, weddingID, paymentID models.ID) (*models.BudgetPayment, error) {
+	payment, err := s.budgetRepo.GetPayment(ctx, paymentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrBudgetPaymentNotFound
+		}
+		return nil, err
+	}
+	if payment.WeddingID != weddingID {
+		return nil, ErrBudgetPaymentNotFound
+	}
+	return payment, nil
+}
+
+func (s *BudgetService) getEditableWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	if !wedding.Can(userID, models.PermissionEditWedding) {
+		return nil, ErrUnauthorized
+	}
+	return wedding, nil
+}
+
+// applyBudgetCategory copies the details of a request onto a category
+func applyBudgetCategory(category *models.BudgetCategory, req BudgetCategoryRequest) {
+	category.Name = strings.TrimSpace(req.Name)
+	category.Planned = req.Planned
+	category.Notes = strings.TrimSpace(req.Notes)
+	category.Order = req.Order
+}
+
+// sameTime reports whether two optional times are both empty or equal
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// formatBudgetAmount formats an amount in the smallest unit of a currency, e.g.
+// "1,250.00 USD" for 125000 cents. Amounts without a known currency are plain numbers.
+func formatBudgetAmount(amount int64, code string) string {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return groupDigits(strconv.FormatInt(amount, 10))
+	}
+
+	scale, _ := currency.Standard.Rounding(unit)
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	divisor := int64(math.Pow10(scale))
+	formatted := groupDigits(strconv.FormatInt(amount/divisor, 10))
+	if scale > 0 {
+		formatted += fmt.Sprintf(".%0*d", scale, amount%divisor)
+	}
+	return sign + formatted + " " + unit.String()
+}
+
+// groupDigits separates the thousands of a run of digits with commas
+func groupDigits(digits string) string {
+	for i := len(digits) - 3; i > 0 && digits[i-1] != '-'; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return digits
+}
+
+var paymentReminderHTML = template.Must(template.New("payment_reminder").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #333;">
+  <h2>Payment due {{.DueDate}}</h2>
+  <p>A payment for {{.Title}} is coming due:</p>
+  <table cellpadding="6">
+    <tr><td>Vendor</td><td><strong>{{.Vendor}}</strong></td></tr>
+    {{if .Description}}<tr><td>For</td><td>{{.Description}}</td></tr>{{end}}
+    <tr><td>Amount</td><td><strong>{{.Amount}}</strong></td></tr>
+  </table>
+  <p style="font-size: 12px; color: #888;">You receive this email because the payment has a due date in your wedding's budget. Mark it paid to stop reminders.</p>
+</body>
+</html>`))
+
+// renderPaymentReminder renders the HTML and plain text reminder of a payment coming due
+func renderPaymentReminder(wedding *models.Wedding, payment *models.BudgetPayment) (*EmailMessage, error) {
+	view := struct {
+		Title       string
+		Vendor      string
+		Description string
+		Amount      string
+		DueDate     string
+	}{
+		Title:       wedding.Title,
+		Vendor:      payment.Vendor,
+		Description: payment.Description,
+		Amount:      formatBudgetAmount(payment.Amount, wedding.Budget.Currency),
+	}
+	if payment.DueDate != nil {
+		view.DueDate = payment.DueDate.Format("January 2, 2006")
+	}
+
+	var html bytes.Buffer
+	if err := paymentReminderHTML.Execute(&html, view); err != nil {
+		return nil, fmt.Errorf("failed to render payment reminder: %w", err)
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "A payment for %s is due %s.\n\nVendor: %s\n", view.Title, view.DueDate, view.Vendor)
+	if view.Description != "" {
+		fmt.Fprintf(&text, "For: %s\n", view.Description)
+	}
+	fmt.Fprintf(&text, "Amount: %s\n", view.Amount)
+
+	return &EmailMessage{
+		Subject: fmt.Sprintf("Payment due %s: %s", view.DueDate, view.Vendor),
+		HTML:    html.String(),
+		Text:    text.String(),
+	}, nil
+}
+
+// BudgetReminderScheduler periodically emails the reminders of payments coming due
+type BudgetReminderScheduler struct {
+	service  *BudgetService
+	interval time.Duration
+	logger   *zap.Logger
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewBudgetReminderScheduler creates a scheduler that checks for due payment reminders every interval
+func NewBudgetReminderScheduler(service *BudgetService, interval time.Duration, logger *zap.Logger) *BudgetReminderScheduler {
+	return &BudgetReminderScheduler{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start runs the scheduler loop in the background
+func (sch *BudgetReminderScheduler) Start(ctx context.Context) {
+	sch.wg.Add(1)
+	go func() {
+		defer sch.wg.Done()
+		ticker := time.NewTicker(sch.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sch.stop:
+				return
+			case now := <-ticker.C:
+				sent, err := sch.service.SendDueReminders(ctx, now)
+				if err != nil {
+					sch.logger.Error("Payment reminder run failed", zap.Error(err))
+					continue
+				}
+				if sent > 0 {
+					sch.logger.Info("Payment reminders sent", zap.Int("count", sent))
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the scheduler loop to exit and waits for it
+func (sch *BudgetReminderScheduler) Stop() {
+	close(sch.stop)
+	sch.wg.Wait()
+}
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockBudgetRepository is an in-memory wedding budget repository
+type MockBudgetRepository struct {
+	categories map[models.ID]*models.BudgetCategory
+	payments   map[models.ID]*models.BudgetPayment
+}
+
+func NewMockBudgetRepository() *MockBudgetRepository {
+	return &MockBudgetRepository{
+		categories: make(map[models.ID]*models.BudgetCategory),
+		payments:   make(map[models.ID]*models.BudgetPayment),
+	}
+}
+
+func (m *MockBudgetRepository) CreateCategory(ctx context.Context, category *models.BudgetCategory) error {
+	category.ID = models.NewID()
+	copied := *category
+	m.categories[category.ID] = &copied
+	return nil
+}
+
+func (m *MockBudgetRepository) GetCategory(ctx context.Context, id models.ID) (*models.BudgetCategory, error) {
+	category, ok := m.categories[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	copied := *category
+	return &copied, nil
+}
+
+func (m *MockBudgetRepository) ListCategories(ctx context.Context, weddingID models.ID) ([]*models.BudgetCategory, error) {
+	categories := []*models.BudgetCategory{}
+	for _, category := range m.categories {
+		if category.WeddingID == weddingID {
+			copied := *category
+			categories = append(categories, &copied)
+		}
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Order < categories[j].Order })
+	return categories, nil
+}
+
+func (m *MockBudgetRepository) UpdateCategory(ctx context.Context, category *models.BudgetCategory) error {
+	if _, ok := m.categories[category.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	copied := *category
+	m.categories[category.ID] = &copied
+	return nil
+}
+
+func (m *MockBudgetRepository) DeleteCategory(ctx context.Context, id models.ID) error {
+	if _, ok := m.categories[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.categories, id)
+	return nil
+}
+
+func (m *MockBudgetRepository) CreatePayment(ctx context.Context, payment *models.BudgetPayment) error {
+	payment.ID = models.NewID()
+	copied := *payment
+	m.payments[payment.ID] = &copied
+	return nil
+}
+
+func (m *MockBudgetRepository) GetPayment(ctx context.Context, id models.ID) (*models.BudgetPayment, error) {
+	payment, ok := m.payments[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	copied := *payment
+	return &copied, nil
+}
+
+func (m *MockBudgetRepository) ListPayments(ctx context.Context, weddingID models.ID, filters repository.BudgetPaymentFilters) ([]*models.BudgetPayment, error) {
+	payments := []*models.BudgetPayment{}
+	for _, payment := range m.payments {
+		if payment.WeddingID != weddingID ||
+			(filters.CategoryID != nil && (payment.CategoryID == nil || *payment.CategoryID != *filters.CategoryID)) ||
+			(filters.Status != "" && payment.Status != filters.Status) {
+			continue
+		}
+		copied := *payment
+		payments = append(payments, &copied)
+	}
+	sort.Slice(payments, func(i, j int) bool {
+		a, b := payments[i].DueDate, payments[j].DueDate
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return a.Before(*b)
+	})
+	return payments, nil
+}
+
+func (m *MockBudgetRepository) UpdatePayment(ctx context.Context, payment *models.BudgetPayment) error {
+	if _, ok := m.payments[payment.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	copied := *payment
+	m.payments[payment.ID] = &copied
+	return nil
+}
+
+func (m *MockBudgetRepository) DeletePayment(ctx context.Context, id models.ID) error {
+	if _, ok := m.payments[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.payments, id)
+	return nil
+}
+
+func (m *MockBudgetRepository) ClaimDueReminder(ctx context.Context, now time.Time) (*models.BudgetPayment, error) {
+	for _, payment := range m.payments {
+		if payment.Status == models.BudgetPaymentPending && payment.RemindAt != nil && !payment.RemindAt.After(now) && payment.RemindedAt == nil {
+			remindedAt := now
+			payment.RemindedAt = &remindedAt
+			copied := *payment
+			return &copied, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *MockBudgetRepository) RescheduleReminder(ctx context.Context, id models.ID, remindAt time.Time) error {
+	payment, ok := m.payments[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	payment.RemindAt = &remindAt
+	payment.RemindedAt = nil
+	return nil
+}
+
+func newTestBudgetService(budgetRepo *MockBudgetRepository, wedding *models.Wedding, owner *models.User, email *MockEmailService) *BudgetService {
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+	weddingRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+	userRepo := &MockUserRepository{}
+	userRepo.On("GetByID", mock.Anything, owner.ID).Return(owner, nil)
+	return NewBudgetService(budgetRepo, weddingRepo, userRepo, email, zap.NewNop())
+}
+
+func TestBudgetService_GetSummary(t *testing.T) {
+	owner := &models.User{ID: models.NewID(), Email: "owner@example.com"}
+	wedding := &models.Wedding{ID: models.NewID(), UserID: owner.ID, Budget: models.BudgetSettings{Currency: "USD", Total: 2000000}}
+	budgetRepo := NewMockBudgetRepository()
+	service := newTestBudgetService(budgetRepo, wedding, owner, &MockEmailService{})
+	ctx := context.Background()
+
+	catering, err := service.CreateCategory(ctx, wedding.ID, owner.ID, BudgetCategoryRequest{Name: " Catering ", Planned: 800000})
+	require.NoError(t, err)
+	assert.Equal(t, "Catering", catering.Name)
+	venue, err := service.CreateCategory(ctx, wedding.ID, owner.ID, BudgetCategoryRequest{Name: "Venue", Planned: 500000, Order: 1})
+	require.NoError(t, err)
+
+	now := time.Now()
+	lastWeek := now.AddDate(0, 0, -7)
+	nextWeek := now.AddDate(0, 0, 7)
+	nextYear := now.AddDate(1, 0, 0)
+	for _, req := range []BudgetPaymentRequest{
+		{CategoryID: catering.ID.String(), Vendor: "Caterer", Amount: 300000, Paid: true},
+		{CategoryID: catering.ID.String(), Vendor: "Caterer", Amount: 600000, DueDate: &nextWeek},
+		{CategoryID: venue.ID.String(), Vendor: "Hall", Amount: 200000, DueDate: &lastWeek},
+		{CategoryID: venue.ID.String(), Vendor: "Hall", Amount: 250000, DueDate: &nextYear},
+		{Vendor: "Florist", Amount: 50000},
+	} {
+		_, err := service.CreatePayment(ctx, wedding.ID, owner.ID, req)
+		require.NoError(t, err)
+	}
+
+	summary, err := service.GetSummary(ctx, wedding.ID, owner.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, "USD", summary.Currency)
+	assert.Equal(t, int64(1300000), summary.Planned)
+	assert.Equal(t, int64(1400000), summary.Actual)
+	assert.Equal(t, int64(300000), summary.Paid)
+	assert.Equal(t, int64(1100000), summary.Outstanding)
+	assert.Equal(t, int64(200000), summary.Overdue)
+	assert.Equal(t, int64(600000), summary.Remaining)
+
+	require.Len(t, summary.Categories, 3)
+	assert.Equal(t, models.BudgetCategorySummary{
+		CategoryID: &catering.ID, Name: "Catering", Planned: 800000, Actual: 900000, Paid: 300000,
+		Outstanding: 600000, Variance: -100000, Payments: 2,
+	}, summary.Categories[0])
+	assert.Equal(t, int64(50000), summary.Categories[1].Variance)
+	assert.Nil(t, summary.Categories[2].CategoryID)
+	assert.Equal(t, int64(50000), summary.Categories[2].Actual)
+
+	// Overdue first, then due within 30 days
+	require.Len(t, summary.Upcoming, 2)
+	assert.Equal(t, int64(200000), summary.Upcoming[0].Amount)
+	assert.Equal(t, int64(600000), summary.Upcoming[1].Amount)
+
+	t.Run("Error - category with payments", func(t *testing.T) {
+		err := service.DeleteCategory(ctx, wedding.ID, venue.ID, owner.ID)
+		assert.ErrorIs(t, err, ErrBudgetCategoryInUse)
+	})
+
+	t.Run("Error - category of another wedding", func(t *testing.T) {
+		budgetRepo.categories[models.NewID()] = &models.BudgetCategory{WeddingID: models.NewID(), Name: "Band"}
+		for id, category := range budgetRepo.categories {
+			if category.WeddingID == wedding.ID {
+				continue
+			}
+			_, err := service.CreatePayment(ctx, wedding.ID, owner.ID, BudgetPaymentRequest{CategoryID: id.String(), Vendor: "Band"})
+			assert.ErrorIs(t, err, ErrBudgetCategoryNotFound)
+			_, err = service.UpdateCategory(ctx, wedding.ID, id, owner.ID, BudgetCategoryRequest{Name: "Band"})
+			assert.ErrorIs(t, err, ErrBudgetCategoryNotFound)
+		}
+	})
+
+	t.Run("Error - not an editor", func(t *testing.T) {
+		_, err := service.GetSummary(ctx, wedding.ID, models.NewID())
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+}
+
+func TestBudgetService_SendDueReminders(t *testing.T) {
+	owner := &models.User{ID: models.NewID(), Email: "owner@example.com"}
+	wedding := &models.Wedding{ID: models.NewID(), UserID: owner.ID, Title: "Jane & John", Budget: models.BudgetSettings{Currency: "USD"}}
+	budgetRepo := NewMockBudgetRepository()
+	email := &MockEmailService{}
+	service := newTestBudgetService(budgetRepo, wedding, owner, email)
+	ctx := context.Background()
+
+	now := time.Now()
+	dueSoon := now.AddDate(0, 0, 3)
+	dueLater := now.AddDate(0, 1, 0)
+	soon, err := service.CreatePayment(ctx, wedding.ID, owner.ID, BudgetPaymentRequest{Vendor: "Photographer", Amount: 125050, DueDate: &dueSoon})
+	require.NoError(t, err)
+	_, err = service.CreatePayment(ctx, wedding.ID, owner.ID, BudgetPaymentRequest{Vendor: "Band", Amount: 90000, DueDate: &dueLater})
+	require.NoError(t, err)
+	_, err = service.CreatePayment(ctx, wedding.ID, owner.ID, BudgetPaymentRequest{Vendor: "Cake", Amount: 40000, DueDate: &dueSoon, Paid: true})
+	require.NoError(t, err)
+
+	t.Run("Success - reminds once of payments coming due", func(t *testing.T) {
+		sent, err := service.SendDueReminders(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		require.Len(t, email.sent, 1)
+		assert.Equal(t, "owner@example.com", email.sent[0].To)
+		assert.Contains(t, email.sent[0].Subject, "Photographer")
+		assert.Contains(t, email.sent[0].Text, "1,250.50 USD")
+
+		sent, err = service.SendDueReminders(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 0, sent)
+	})
+
+	t.Run("Success - a new due date reminds again", func(t *testing.T) {
+		days := 0
+		_, err := service.UpdatePayment(ctx, wedding.ID, soon.ID, owner.ID, BudgetPaymentRequest{Vendor: "Photographer", Amount: 125050, DueDate: &dueSoon, ReminderDays: &days})
+		require.NoError(t, err)
+
+		sent, err := service.SendDueReminders(ctx, dueSoon)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+	})
+
+	t.Run("Error - failed reminders are retried", func(t *testing.T) {
+		email.err = errors.New("smtp down")
+		later := dueLater.AddDate(0, 0, -7)
+		sent, err := service.SendDueReminders(ctx, later)
+		require.NoError(t, err)
+		assert.Equal(t, 0, sent)
+
+		email.err = nil
+		sent, err = service.SendDueReminders(ctx, later.Add(paymentReminderRetryInterval))
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+	})
+}
+
+func TestFormatBudgetAmount(t *testing.T) {
+	assert.Equal(t, "1,250.50 USD", formatBudgetAmount(125050, "USD"))
+	assert.Equal(t, "150,000 JPY", formatBudgetAmount(150000, "JPY"))
+	assert.Equal(t, "-0.05 EUR", formatBudgetAmount(-5, "EUR"))
+	assert.Equal(t, "1,234,567", formatBudgetAmount(1234567, ""))
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+const (
+	// defaultEventDuration is how long calendars block for an event without an end date
+	defaultEventDuration = 3 * time.Hour
+	// calendarLineLimit is the longest iCalendar content line in octets, per RFC 5545
+	calendarLineLimit = 75
+
+	calendarProductID = "-//Wedding Invitation//Wedding Calendar//EN"
+	calendarUIDDomain = "wedding-invitation"
+
+	googleCalendarURL  = "https://calendar.google.com/calendar/render"
+	outlookCalendarURL = "https://outlook.live.com/calendar/0/action/compose"
+)
+
+// CalendarLinks are the "Add to Calendar" links of one event of a wedding
+type CalendarLinks struct {
+	// SessionID is the session the links add, empty for a single-event wedding
+	SessionID string `json:"session_id,omitempty"`
+	Google    string `json:"google"`
+	Outlook   string `json:"outlook"`
+}
+
+// calendarEvent is one event of a wedding, resolved for a calendar
+type calendarEvent struct {
+	uid         string
+	summary     string
+	location    string
+	description string
+	start       time.Time
+	end         time.Time
+	// zone is the IANA time zone the times are written in; UTC when empty
+	zone string
+}
+
+// WeddingCalendar renders the events of a wedding as an iCalendar (RFC 5545)
+// file, one VEVENT per session. Events with a time zone are written in the
+// venue's local time, with the VTIMEZONE definitions they need.
+func WeddingCalendar(wedding *models.Wedding, now time.Time) []byte {
+	events := weddingCalendarEvents(wedding)
+
+	var b calendarBuilder
+	b.line("BEGIN:VCALENDAR")
+	b.line("VERSION:2.0")
+	b.line("PRODID:" + calendarProductID)
+	b.line("CALSCALE:GREGORIAN")
+	b.line("METHOD:PUBLISH")
+	b.line("X-WR-CALNAME:" + escapeCalendarText(wedding.Title))
+
+	for _, zone := range calendarZones(events) {
+		b.timezone(zone)
+	}
+
+	stamp := formatCalendarUTC(now)
+	for _, event := range events {
+		b.line("BEGIN:VEVENT")
+		b.line("UID:" + event.uid)
+		b.line("DTSTAMP:" + stamp)
+		b.line(calendarTimeProperty("DTSTART", event.start, event.zone))
+		b.line(calendarTimeProperty("DTEND", event.end, event.zone))
+		b.line("SUMMARY:" + escapeCalendarText(event.summary))
+		if event.location != "" {
+			b.line("LOCATION:" + escapeCalendarText(event.location))
+		}
+		if event.description != "" {
+			b.line("DESCRIPTION:" + escapeCalendarText(event.description))
+		}
+		b.line("STATUS:CONFIRMED")
+		b.line("TRANSP:OPAQUE")
+		b.line("END:VEVENT")
+	}
+
+	b.line("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// AddToCalendarLinks builds Google Calendar and Outlook links adding each
+// event of a wedding to a guest's calendar
+func AddToCalendarLinks(wedding *models.Wedding) []CalendarLinks {
+	events := weddingCalendarEvents(wedding)
+	sessions := wedding.EventSessions()
+
+	links := make([]CalendarLinks, 0, len(events))
+	for i, event := range events {
+		google := url.Values{}
+		google.Set("action", "TEMPLATE")
+		google.Set("text", event.summary)
+		google.Set("dates", formatCalendarUTC(event.start)+"/"+formatCalendarUTC(event.end))
+		google.Set("details", event.description)
+		google.Set("location", event.location)
+		if event.zone != "" {
+			google.Set("ctz", event.zone)
+		}
+
+		outlook := url.Values{}
+		outlook.Set("rru", "addevent")
+		outlook.Set("subject", event.summary)
+		outlook.Set("startdt", event.start.UTC().Format(time.RFC3339))
+		outlook.Set("enddt", event.end.UTC().Format(time.RFC3339))
+		outlook.Set("body", event.description)
+		outlook.Set("location", event.location)
+
+		links = append(links, CalendarLinks{
+			SessionID: sessions[i].ID,
+			Google:    googleCalendarURL + "?" + google.Encode(),
+			Outlook:   outlookCalendarURL + "?" + outlook.Encode(),
+		})
+	}
+	return links
+}
+
+// weddingCalendarEvents resolves the sessions of a wedding, in order, for a
+// calendar. Sessions without a time zone fall back to the main event's.
+func weddingCalendarEvents(wedding *models.Wedding) []calendarEvent {
+	sessions := wedding.EventSessions()
+	events := make([]calendarEvent, 0, len(sessions))
+	for _, session := range sessions {
+		end := session.Date.Add(defaultEventDuration)
+		if session.EndDate != nil && session.EndDate.After(session.Date) {
+			end = *session.EndDate
+		}
+
+		zone := session.Timezone
+		if zone == "" {
+			zone = wedding.Event.Timezone
+		}
+		if !isCalendarZone(zone) {
+			zone = ""
+		}
+
+		uid := wedding.ID.String()
+		if session.ID != "" {
+			uid += "-" + session.ID
+		}
+
+		summary := wedding.Title
+		if session.Title != "" && session.Title != wedding.Title {
+			summary = session.Title + " - " + wedding.Title
+		}
+
+		events = append(events, calendarEvent{
+			uid:         uid + "@" + calendarUIDDomain,
+			summary:     strings.TrimSpace(summary),
+			location:    joinNonEmpty(", ", session.VenueName, session.VenueAddress),
+			description: joinNonEmpty("\n", session.AdditionalInfo, prefixNonEmpty("Dress code: ", session.DressCode), session.VenueMapURL),
+			start:       session.Date,
+			end:         end,
+			zone:        zone,
+		})
+	}
+	return events
+}
+
+// calendarZone is a time zone the events of a calendar are written in
+type calendarZone struct {
+	id     string
+	name   string
+	offset int
+}
+
+// calendarZones lists the time zones the events are written in, in order of
+// first use. A zone whose events fall on different UTC offsets, e.g. on both
+// sides of a daylight saving change, cannot be described by a single offset,
+// so its events are written in UTC instead.
+func calendarZones(events []calendarEvent) []calendarZone {
+	var zones []calendarZone
+	index := make(map[string]int)
+	mixed := make(map[string]bool)
+	for _, event := range events {
+		if event.zone == "" {
+			continue
+		}
+		loc, _ := time.LoadLocation(event.zone)
+		name, offset := event.start.In(loc).Zone()
+		if _, endOffset := event.end.In(loc).Zone(); endOffset != offset {
+			mixed[event.zone] = true
+		}
+		if i, ok := index[event.zone]; !ok {
+			index[event.zone] = len(zones)
+			zones = append(zones, calendarZone{id: event.zone, name: name, offset: offset})
+		} else if zones[i].offset != offset {
+			mixed[event.zone] = true
+		}
+	}
+
+	var kept []calendarZone
+	for _, zone := range zones {
+		if !mixed[zone.id] {
+			kept = append(kept, zone)
+		}
+	}
+	for i := range events {
+		if mixed[events[i].zone] {
+			events[i].zone = ""
+		}
+	}
+	return kept
+}
+
+// isCalendarZone tells whether times can be written in a zone's local time;
+// UTC and the server's own zone are written in UTC
+func isCalendarZone(zone string) bool {
+	if zone == "" || zone == "UTC" || zone == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(zone)
+	return err == nil
+}
+
+// calendarTimeProperty writes a date-time property in the zone's local time,
+// or in UTC without a zone
+func calendarTimeProperty(name string, t time.Time, zone string) string {
+	if zone == "" {
+		return name + ":" + formatCalendarUTC(t)
+	}
+	loc, _ := time.LoadLocation(zone)
+	return name + ";TZID=" + zone + ":" + t.In(loc).Format("20060102T150405")
+}
+
+func formatCalendarUTC(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeCalendarText escapes a TEXT value per RFC 5545 section 3.3.11
+func escapeCalendarText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, sep)
+}
+
+func prefixNonEmpty(prefix, value string) string {
+	if strings.TrimSpace(value) == "" {
+		return ""
+	}
+	return prefix + value
+}
+
+// calendarBuilder writes iCalendar content lines, ending them with CRLF and
+// folding them at 75 octets without splitting UTF-8 characters
+type calendarBuilder struct {
+	strings.Builder
+}
+
+func (b *calendarBuilder) line(content string) {
+	limit := calendarLineLimit
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		b.WriteString(content[:cut])
+		b.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = calendarLineLimit - 1
+	}
+	b.WriteString(content)
+	b.WriteString("\r\n")
+}
+
+// timezone writes a VTIMEZONE with the zone's offset at the time of its events
+func (b *calendarBuilder) timezone(zone calendarZone) {
+	b.line("BEGIN:VTIMEZONE")
+	b.line("TZID:" + zone.id)
+	b.line("BEGIN:STANDARD")
+	b.line("DTSTART:19700101T000000")
+	b.line("TZOFFSETFROM:" + formatCalendarOffset(zone.offset))
+	b.line("TZOFFSETTO:" + formatCalendarOffset(zone.offset))
+	b.line("TZNAME:" + escapeCalendarText(zone.name))
+	b.line("END:STANDARD")
+	b.line("END:VTIMEZONE")
+}
+
+// formatCalendarOffset formats a UTC offset in seconds as ±hhmm
+func formatCalendarOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+}
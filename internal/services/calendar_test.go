@@ -0,0 +1,153 @@
+package services
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func calendarTestWedding() *models.Wedding {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	receptionEnd := time.Date(2024, 6, 15, 21, 0, 0, 0, jakarta)
+	return &models.Wedding{
+		ID:    models.NewID(),
+		Title: "John & Jane's Wedding",
+		Event: models.EventDetails{
+			Title:    "Wedding",
+			Date:     time.Date(2024, 6, 15, 8, 0, 0, 0, jakarta),
+			Timezone: "Asia/Jakarta",
+		},
+		Sessions: []models.EventSession{
+			{ID: "akad", EventDetails: models.EventDetails{
+				Title:        "Akad Nikah",
+				Date:         time.Date(2024, 6, 15, 8, 0, 0, 0, jakarta),
+				VenueName:    "Masjid Agung",
+				VenueAddress: "Jl. Merdeka 1, Jakarta",
+				DressCode:    "White",
+			}},
+			{ID: "reception", EventDetails: models.EventDetails{
+				Title:     "Reception",
+				Date:      time.Date(2024, 6, 15, 18, 0, 0, 0, jakarta),
+				EndDate:   &receptionEnd,
+				VenueName: "Beautiful Garden",
+			}},
+		},
+	}
+}
+
+// unfoldCalendar joins folded iCalendar lines back together
+func unfoldCalendar(ics string) []string {
+	return strings.Split(strings.ReplaceAll(ics, "\r\n ", ""), "\r\n")
+}
+
+func TestWeddingCalendar(t *testing.T) {
+	wedding := calendarTestWedding()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	ics := string(WeddingCalendar(wedding, now))
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, "line %q is not folded", line)
+		assert.NotContains(t, line, "\n")
+	}
+
+	lines := unfoldCalendar(ics)
+	assert.Equal(t, "BEGIN:VCALENDAR", lines[0])
+	assert.Contains(t, lines, "VERSION:2.0")
+	assert.Contains(t, lines, "X-WR-CALNAME:John & Jane's Wedding")
+
+	// One time zone definition for both sessions
+	assert.Equal(t, 1, strings.Count(ics, "BEGIN:VTIMEZONE"))
+	assert.Contains(t, lines, "TZID:Asia/Jakarta")
+	assert.Contains(t, lines, "TZOFFSETTO:+0700")
+
+	assert.Equal(t, 2, strings.Count(ics, "BEGIN:VEVENT"))
+	assert.Contains(t, lines, "UID:"+wedding.ID.String()+"-akad@wedding-invitation")
+	assert.Contains(t, lines, "DTSTAMP:20240501T120000Z")
+	assert.Contains(t, lines, "SUMMARY:Akad Nikah - John & Jane's Wedding")
+	assert.Contains(t, lines, `LOCATION:Masjid Agung\, Jl. Merdeka 1\, Jakarta`)
+	assert.Contains(t, lines, "DESCRIPTION:Dress code: White")
+
+	// Local venue times, with the default duration without an end date
+	assert.Contains(t, lines, "DTSTART;TZID=Asia/Jakarta:20240615T080000")
+	assert.Contains(t, lines, "DTEND;TZID=Asia/Jakarta:20240615T110000")
+	assert.Contains(t, lines, "DTSTART;TZID=Asia/Jakarta:20240615T180000")
+	assert.Contains(t, lines, "DTEND;TZID=Asia/Jakarta:20240615T210000")
+	assert.Equal(t, "END:VCALENDAR", lines[len(lines)-2])
+}
+
+func TestWeddingCalendar_UTC(t *testing.T) {
+	wedding := &models.Wedding{
+		ID:    models.NewID(),
+		Title: "Garden Wedding",
+		Event: models.EventDetails{
+			Title: "Garden Wedding",
+			Date:  time.Date(2024, 6, 15, 14, 0, 0, 0, time.UTC),
+		},
+	}
+
+	lines := unfoldCalendar(string(WeddingCalendar(wedding, time.Now())))
+	assert.NotContains(t, lines, "BEGIN:VTIMEZONE")
+	assert.Contains(t, lines, "UID:"+wedding.ID.String()+"@wedding-invitation")
+	assert.Contains(t, lines, "SUMMARY:Garden Wedding")
+	assert.Contains(t, lines, "DTSTART:20240615T140000Z")
+	assert.Contains(t, lines, "DTEND:20240615T170000Z")
+}
+
+func TestWeddingCalendar_DaylightSavingChange(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	wedding := &models.Wedding{
+		ID:    models.NewID(),
+		Title: "Wedding",
+		Event: models.EventDetails{Timezone: "America/New_York"},
+		Sessions: []models.EventSession{
+			{ID: "rehearsal", EventDetails: models.EventDetails{Title: "Rehearsal", Date: time.Date(2024, 3, 9, 18, 0, 0, 0, newYork)}},
+			{ID: "ceremony", EventDetails: models.EventDetails{Title: "Ceremony", Date: time.Date(2024, 3, 10, 15, 0, 0, 0, newYork)}},
+		},
+	}
+
+	// The sessions fall on both sides of the change, so they are written in UTC
+	lines := unfoldCalendar(string(WeddingCalendar(wedding, time.Now())))
+	assert.NotContains(t, lines, "BEGIN:VTIMEZONE")
+	assert.Contains(t, lines, "DTSTART:20240309T230000Z")
+	assert.Contains(t, lines, "DTSTART:20240310T190000Z")
+}
+
+func TestCalendarBuilder_FoldsWithoutSplittingCharacters(t *testing.T) {
+	var b calendarBuilder
+	b.line("DESCRIPTION:" + strings.Repeat("é", 60))
+
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+		assert.True(t, strings.ToValidUTF8(line, "?") == line, "line %q splits a character", line)
+	}
+	assert.Equal(t, []string{"DESCRIPTION:" + strings.Repeat("é", 60), ""}, unfoldCalendar(b.String()))
+}
+
+func TestAddToCalendarLinks(t *testing.T) {
+	links := AddToCalendarLinks(calendarTestWedding())
+	require.Len(t, links, 2)
+	assert.Equal(t, "akad", links[0].SessionID)
+	assert.Equal(t, "reception", links[1].SessionID)
+
+	google, err := url.Parse(links[1].Google)
+	require.NoError(t, err)
+	assert.Equal(t, "calendar.google.com", google.Host)
+	assert.Equal(t, "TEMPLATE", google.Query().Get("action"))
+	assert.Equal(t, "Reception - John & Jane's Wedding", google.Query().Get("text"))
+	assert.Equal(t, "20240615T110000Z/20240615T140000Z", google.Query().Get("dates"))
+	assert.Equal(t, "Asia/Jakarta", google.Query().Get("ctz"))
+
+	outlook, err := url.Parse(links[1].Outlook)
+	require.NoError(t, err)
+	assert.Equal(t, "outlook.live.com", outlook.Host)
+	assert.Equal(t, "2024-06-15T11:00:00Z", outlook.Query().Get("startdt"))
+	assert.Equal(t, "2024-06-15T14:00:00Z", outlook.Query().Get("enddt"))
+	assert.Equal(t, "Beautiful Garden", outlook.Query().Get("location"))
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+var (
+	ErrCheckInGuestRequired = errors.New("a guest token or guest ID is required")
+	ErrAlreadyCheckedIn     = errors.New("guest is already checked in")
+	ErrUnknownCompanion     = errors.New("companion is not named in the guest's RSVP")
+)
+
+// CheckInRequest identifies an arriving guest by the token of their QR code or by ID.
+// Companions lists the IDs of the named companions who came; when given, they
+// are the plus ones brought and PlusOnes is ignored.
+type CheckInRequest struct {
+	Token      string
+	GuestID    models.ID
+	PlusOnes   int
+	Companions []string
+	Table      string
+}
+
+// CheckInService checks guests in at the door on the wedding day. Staff scan the
+// guest's QR code, or look the guest up, and record who came with them and where
+// they sit.
+type CheckInService struct {
+	guestRepo   repository.GuestRepository
+	weddingRepo repository.WeddingRepository
+	groupRepo   repository.GuestGroupRepository
+	secret      string
+	webhooks    WeddingEventNotifier
+}
+
+// NewCheckInService creates a new check-in service. secret verifies the guest
+// tokens of QR codes and must be the one GuestQRService signs them with.
+func NewCheckInService(guestRepo repository.GuestRepository, weddingRepo repository.WeddingRepository, secret string) *CheckInService {
+	return &CheckInService{
+		guestRepo:   guestRepo,
+		weddingRepo: weddingRepo,
+		secret:      secret,
+	}
+}
+
+// SetWebhookNotifier sends a guest.checked_in event to the wedding's webhooks for every arrival
+func (s *CheckInService) SetWebhookNotifier(notifier WeddingEventNotifier) {
+	s.webhooks = notifier
+}
+
+// SetGuestGroups seats grouped guests checked in without a table at their household's table
+func (s *CheckInService) SetGuestGroups(groups repository.GuestGroupRepository) {
+	s.groupRepo = groups
+}
+
+// CheckIn records a guest's arrival at the wedding and returns the checked-in guest
+func (s *CheckInService) CheckIn(ctx context.Context, weddingID, userID models.ID, req CheckInRequest) (*models.Guest, error) {
+	if err := s.verifyAccess(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	guest, err := s.findGuest(ctx, weddingID, req)
+	if err != nil {
+		return nil, err
+	}
+	if guest.CheckIn != nil {
+		return guest, ErrAlreadyCheckedIn
+	}
+
+	companions, err := arrivingCompanions(guest, req.Companions)
+	if err != nil {
+		return nil, err
+	}
+	plusOnes := req.PlusOnes
+	if len(req.Companions) > 0 {
+		plusOnes = len(companions)
+	}
+
+	maxPlusOnes := 0
+	if guest.AllowPlusOne {
+		maxPlusOnes = guest.MaxPlusOnes
+	}
+	// A household's RSVP names its companions on the member who answered, within
+	// the household's allowance
+	if len(guest.Companions) > maxPlusOnes {
+		maxPlusOnes = len(guest.Companions)
+	}
+
+	table, err := s.seat(ctx, guest, req.Table)
+	if err != nil {
+		return nil, err
+	}
+	if plusOnes < 0 || plusOnes > maxPlusOnes {
+		return nil, fmt.Errorf("%w: at most %d", ErrTooManyPlusOnes, maxPlusOnes)
+	}
+
+	// Companions sit at the guest's table
+	checkIn := models.GuestCheckIn{
+		ArrivedAt:       time.Now(),
+		PlusOnesBrought: plusOnes,
+		Table:           table,
+		CheckedInBy:     userID,
+	}
+	var companionNames []string
+	for _, companion := range companions {
+		checkIn.Companions = append(checkIn.Companions, companion.ID)
+		companionNames = append(companionNames, companion.FullName())
+	}
+	if err := s.guestRepo.CheckIn(ctx, guest.ID, checkIn); err != nil {
+		// The guest was found above, so another device checked them in meanwhile
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrAlreadyCheckedIn
+		}
+		return nil, fmt.Errorf("failed to check in guest: %w", err)
+	}
+
+	guest.CheckIn = &checkIn
+	if s.webhooks != nil {
+		s.webhooks.NotifyWeddingEvent(ctx, weddingID, models.WeddingEventGuestCheckedIn, map[string]interface{}{
+			"guest_id":          guest.ID.String(),
+			"name":              strings.TrimSpace(guest.FirstName + " " + guest.LastName),
+			"plus_ones_brought": checkIn.PlusOnesBrought,
+			"companions":        companionNames,
+			"table":             checkIn.Table,
+			"arrived_at":        checkIn.ArrivedAt,
+		})
+	}
+	return guest, nil
+}
+
+// arrivingCompanions looks up the named companions of a guest by ID
+func arrivingCompanions(guest *models.Guest, ids []string) ([]models.PlusOneInfo, error) {
+	var companions []models.PlusOneInfo
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: %s is listed twice", ErrUnknownCompanion, id)
+		}
+		seen[id] = true
+
+		found := false
+		for _, companion := range guest.Companions {
+			if companion.ID == id {
+				companions = append(companions, companion)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCompanion, id)
+		}
+	}
+	return companions, nil
+}
+
+// Stats returns the live attendance counts of a wedding
+func (s *CheckInService) Stats(ctx context.Context, weddingID, userID models.ID) (*models.CheckInStats, error) {
+	if err := s.verifyAccess(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+	return s.guestRepo.CheckInStats(ctx, weddingID)
+}
+
+// findGuest resolves the guest of a check-in request, which must belong to the wedding
+func (s *CheckInService) findGuest(ctx context.Context, weddingID models.ID, req CheckInRequest) (*models.Guest, error) {
+	guestID := req.GuestID
+	notFound := ErrGuestNotFound
+	switch {
+	case req.Token != "":
+		id, err := utils.VerifyGuestToken(s.secret, req.Token)
+		if err != nil {
+			return nil, ErrInvalidGuestLink
+		}
+		guestID, notFound = id, ErrInvalidGuestLink
+	case guestID.IsZero():
+		return nil, ErrCheckInGuestRequired
+	}
+
+	guest, err := s.guestRepo.GetByID(ctx, guestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, notFound
+		}
+		return nil, err
+	}
+	if guest.WeddingID != weddingID {
+		return nil, notFound
+	}
+	return guest, nil
+}
+
+func (s *CheckInService) verifyAccess(ctx context.Context, weddingID, userID models.ID) error {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		return fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return ErrWeddingNotFound
+	}
+	if !wedding.Can(userID, models.PermissionManageGuests) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// seat returns the table a guest is checked in at: the requested one, or their
+// household's when none was given
+func (s *CheckInService) seat(ctx context.Context, guest *models.Guest, table string) (string, error) {
+	table = strings.TrimSpace(table)
+	if table != "" || guest.GroupID == nil || s.groupRepo == nil {
+		return table, nil
+	}
+
+	group, err := s.groupRepo.GetByID(ctx, *guest.GroupID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get guest group: %w", err)
+	}
+	return group.Table, nil
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/utils"
+)
+
+func setupCheckInService(t *testing.T) (*CheckInService, *MockGuestRepository, *models.Wedding, *models.Guest) {
+	guestRepo := NewMockGuestRepository()
+	weddingRepo := &MockWeddingRepository{}
+
+	wedding := &models.Wedding{ID: models.NewID(), UserID: models.NewID()}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+
+	guest := &models.Guest{
+		WeddingID:    wedding.ID,
+		FirstName:    "Carol",
+		LastName:     "Lee",
+		AllowPlusOne: true,
+		MaxPlusOnes:  2,
+		RSVPStatus:   "attending",
+	}
+	require.NoError(t, guestRepo.Create(context.Background(), guest))
+
+	return NewCheckInService(guestRepo, weddingRepo, "link-secret"), guestRepo, wedding, guest
+}
+
+func TestCheckInService_CheckIn(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("By QR token", func(t *testing.T) {
+		service, _, wedding, guest := setupCheckInService(t)
+		plannerID := models.NewID()
+		wedding.Collaborators = []models.WeddingCollaborator{{UserID: plannerID, Role: models.WeddingRolePlanner}}
+
+		checkedIn, err := service.CheckIn(ctx, wedding.ID, plannerID, CheckInRequest{
+			Token:    utils.SignGuestToken("link-secret", guest.ID),
+			PlusOnes: 2,
+			Table:    " 7 ",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, checkedIn.CheckIn)
+		assert.Equal(t, 2, checkedIn.CheckIn.PlusOnesBrought)
+		assert.Equal(t, "7", checkedIn.CheckIn.Table)
+		assert.Equal(t, plannerID, checkedIn.CheckIn.CheckedInBy)
+		assert.WithinDuration(t, time.Now(), checkedIn.CheckIn.ArrivedAt, time.Minute)
+	})
+
+	t.Run("Error - checked in twice", func(t *testing.T) {
+		service, _, wedding, guest := setupCheckInService(t)
+
+		_, err := service.CheckIn(ctx, wedding.ID, wedding.UserID, CheckInRequest{GuestID: guest.ID})
+		require.NoError(t, err)
+
+		existing, err := service.CheckIn(ctx, wedding.ID, wedding.UserID, CheckInRequest{GuestID: guest.ID, PlusOnes: 1})
+		assert.ErrorIs(t, err, ErrAlreadyCheckedIn)
+		require.NotNil(t, existing)
+		assert.Equal(t, 0, existing.CheckIn.PlusOnesBrought)
+	})
+
+	t.Run("Named companions", func(t *testing.T) {
+		service, _, wedding, guest := setupCheckInService(t)
+		guest.Companions = []models.PlusOneInfo{{ID: "c1", FirstName: "Dan"}, {ID: "c2", FirstName: "Amy"}}
+
+		_, err := service.CheckIn(ctx, wedding.ID, wedding.UserID, CheckInRequest{GuestID: guest.ID, Companions: []string{"c2", "c2"}})
+		assert.ErrorIs(t, err, ErrUnknownCompanion)
+		_, err = service.CheckIn(ctx, wedding.ID, wedding.UserID, CheckInRequest{GuestID: guest.ID, Companions: []string{"c3"}})
+		assert.ErrorIs(t, err, ErrUnknownCompanion)
+
+		checkedIn, err := service.CheckIn(ctx, wedding.ID, wedding.UserID, CheckInRequest{GuestID: guest.ID, PlusOnes: 2, Companions: []string{"c2"}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, checkedIn.CheckIn.PlusOnesBrought)
+		assert.Equal(t, []string{"c2"}, checkedIn.CheckIn.Companions)
+	})
+
+	t.Run("Household - seated at the group's table", func(t *testing.T) {
+		service, _, wedding, guest := setupCheckInService(t)
+		groups := NewMockGuestGroupRepository()
+		service.SetGuestGroups(groups)
+		group := &models.GuestGroup{WeddingID: wedding.ID, Name: "Lees", Table: "12"}
+		require.NoError(t, groups.Create(ctx, group))
+		guest.GroupID = &group.ID
+		// The household's RSVP named three companions on Carol
+		guest.Companions = []models.PlusOneInfo{{ID: "a", FirstName: "Ann"}, {ID: "b", FirstName: "Ben"}, {ID: "c", FirstName: "Cy"}}
+
+		checkedIn, err := service.CheckIn(ctx, wedding.ID, wedding.UserID, CheckInRequest{GuestID: guest.ID, Companions: []string{"a", "b", "c"}})
+		require.NoError(t, err)
+		assert.Equal(t, "12", checkedIn.CheckIn.Table)
+		assert.Equal(t, 3, checkedIn.CheckIn.PlusOnesBrought)
+	})
+
+	t.Run("Error - too many plus-ones", func(t *testing.T) {
+		service, _, wedding, guest := setupCheckInService(t)
+
+		_, err := service.CheckIn(ctx, wedding.ID, wedding.UserID, CheckInRequest{GuestID: guest.ID, PlusOnes: 3})
+		assert.ErrorIs(t, err, ErrTooManyPlusOnes)
+
+		guest.AllowPlusOne = false
+		_, err = service.CheckIn(ctx, wedding.ID, wedding.UserID, CheckInRequest{GuestID: guest.ID, PlusOnes: 1})
+		assert.ErrorIs(t, err, ErrTooManyPlusOnes)
+		assert.Nil(t, guest.CheckIn)
+	})
+
+	t.Run("Error - token of another wedding's guest", func(t *testing.T) {
+		service, guestRepo, wedding, _ := setupCheckInService(t)
+		other := &models.Guest{WeddingID: models.NewID(), FirstName: "Eve", LastName: "Other"}
+		require.NoError(t, guestRepo.Create(ctx, other))
+
+		_, err := service.CheckIn(ctx, wedding.ID, wedding.UserID, CheckInRequest{Token: utils.SignGuestToken("link-secret", other.ID)})
+		assert.ErrorIs(t, err, ErrInvalidGuestLink)
+
+		_, err = service.CheckIn(ctx, wedding.ID, wedding.UserID, CheckInRequest{GuestID: other.ID})
+		assert.ErrorIs(t, err, ErrGuestNotFound)
+	})
+
+	t.Run("Error - forged token", func(t *testing.T) {
+		service, _, wedding, guest := setupCheckInService(t)
+
+		_, err := service.CheckIn(ctx, wedding.ID, wedding.UserID, CheckInRequest{Token: utils.SignGuestToken("guess", guest.ID)})
+		assert.ErrorIs(t, err, ErrInvalidGuestLink)
+	})
+
+	t.Run("Error - no guest", func(t *testing.T) {
+		service, _, wedding, _ := setupCheckInService(t)
+
+		_, err := service.CheckIn(ctx, wedding.ID, wedding.UserID, CheckInRequest{})
+		assert.ErrorIs(t, err, ErrCheckInGuestRequired)
+	})
+
+	t.Run("Error - not a member", func(t *testing.T) {
+		service, _, wedding, guest := setupCheckInService(t)
+
+		_, err := service.CheckIn(ctx, wedding.ID, models.NewID(), CheckInRequest{GuestID: guest.ID})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+}
+
+func TestCheckInService_Stats(t *testing.T) {
+	ctx := context.Background()
+	service, guestRepo, wedding, guest := setupCheckInService(t)
+	walkIn := &models.Guest{WeddingID: wedding.ID, FirstName: "Dan", LastName: "Lee"}
+	require.NoError(t, guestRepo.Create(ctx, walkIn))
+
+	_, err := service.CheckIn(ctx, wedding.ID, wedding.UserID, CheckInRequest{GuestID: guest.ID, PlusOnes: 1})
+	require.NoError(t, err)
+	_, err = service.CheckIn(ctx, wedding.ID, wedding.UserID, CheckInRequest{GuestID: walkIn.ID})
+	require.NoError(t, err)
+
+	stats, err := service.Stats(ctx, wedding.ID, wedding.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stats.TotalGuests)
+	assert.Equal(t, int64(1), stats.ExpectedGuests)
+	assert.Equal(t, int64(2), stats.CheckedIn)
+	assert.Equal(t, int64(1), stats.ExpectedArrived)
+	assert.Equal(t, int64(3), stats.Attendance)
+
+	_, err = service.Stats(ctx, wedding.ID, models.NewID())
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"html/template"
+	"net/url"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+const (
+	// collaboratorInviteTTL is how long an emailed collaborator invitation stays valid
+	collaboratorInviteTTL = 7 * 24 * time.Hour
+	// collaboratorInviteTokenLength is the length of the emailed invitation token
+	collaboratorInviteTokenLength = 32
+)
+
+var (
+	ErrInvalidCollaboratorRole      = errors.New("invalid collaborator role")
+	ErrAlreadyCollaborator          = errs.Conflict("user already collaborates on this wedding")
+	ErrCollaboratorNotFound         = errs.NotFound("collaborator not found")
+	ErrCollaboratorInviteNotFound   = errs.NotFound("collaborator invitation not found")
+	ErrCollaboratorInviteExpired    = errors.New("collaborator invitation has expired")
+	ErrCollaboratorInviteWrongEmail = errors.New("collaborator invitation was sent to another email address")
+)
+
+// Collaborators lists the collaborators and pending invitations of a wedding
+type Collaborators struct {
+	OwnerID       models.ID                    `json:"owner_id"`
+	Collaborators []models.WeddingCollaborator `json:"collaborators"`
+	Invites       []models.CollaboratorInvite  `json:"invites"`
+}
+
+// CollaboratorService lets wedding owners share a wedding with other users. The
+// owner invites an email address with a role; the recipient accepts with the
+// emailed token while signed in with that address and becomes a collaborator.
+type CollaboratorService struct {
+	weddingRepo repository.WeddingRepository
+	userRepo    repository.UserRepository
+	email       EmailService
+	siteURL     string
+	logger      *zap.Logger
+}
+
+// NewCollaboratorService creates a new collaborator service. siteURL is the
+// frontend the accept links point to.
+func NewCollaboratorService(weddingRepo repository.WeddingRepository, userRepo repository.UserRepository, email EmailService, siteURL string, logger *zap.Logger) *CollaboratorService {
+	if siteURL == "" {
+		siteURL = DefaultInvitationOptions().SiteURL
+	}
+	return &CollaboratorService{
+		weddingRepo: weddingRepo,
+		userRepo:    userRepo,
+		email:       email,
+		siteURL:     strings.TrimRight(siteURL, "/"),
+		logger:      logger,
+	}
+}
+
+// ListCollaborators returns the collaborators of a wedding to any of its members
+func (s *CollaboratorService) ListCollaborators(ctx context.Context, weddingID, userID models.ID) (*Collaborators, error) {
+	wedding, err := s.getWedding(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := wedding.RoleOf(userID); !ok {
+		return nil, ErrUnauthorized
+	}
+
+	result := &Collaborators{
+		OwnerID:       wedding.UserID,
+		Collaborators: wedding.Collaborators,
+		Invites:       wedding.CollaboratorInvites,
+	}
+	if result.Collaborators == nil {
+		result.Collaborators = []models.WeddingCollaborator{}
+	}
+	if result.Invites == nil {
+		result.Invites = []models.CollaboratorInvite{}
+	}
+	return result, nil
+}
+
+// InviteCollaborator emails an invitation to collaborate on the wedding with the
+// given role. Inviting the same address again replaces its pending invitation.
+func (s *CollaboratorService) InviteCollaborator(ctx context.Context, weddingID, userID models.ID, email string, role models.WeddingRole) (*models.CollaboratorInvite, error) {
+	if role == models.WeddingRoleOwner || !role.IsValid() {
+		return nil, ErrInvalidCollaboratorRole
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	wedding, err := s.getWedding(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+	if !wedding.Can(userID, models.PermissionManageCollaborators) {
+		return nil, ErrUnauthorized
+	}
+
+	// Existing members can't be invited again
+	if user, err := s.userRepo.GetByEmail(ctx, email); err == nil && user != nil {
+		if _, ok := wedding.RoleOf(user.ID); ok {
+			return nil, ErrAlreadyCollaborator
+		}
+	}
+
+	token, err := utils.GenerateSecureToken(collaboratorInviteTokenLength)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invite := models.CollaboratorInvite{
+		ID:        models.NewID(),
+		Email:     email,
+		Role:      role,
+		TokenHash: hashInviteToken(token),
+		InvitedBy: userID,
+		ExpiresAt: now.Add(collaboratorInviteTTL),
+		CreatedAt: now,
+	}
+
+	invites := make([]models.CollaboratorInvite, 0, len(wedding.CollaboratorInvites)+1)
+	for _, existing := range wedding.CollaboratorInvites {
+		if existing.Email != email {
+			invites = append(invites, existing)
+		}
+	}
+	wedding.CollaboratorInvites = append(invites, invite)
+
+	if err := s.weddingRepo.Update(ctx, wedding); err != nil {
+		return nil, fmt.Errorf("failed to save collaborator invitation: %w", err)
+	}
+
+	if err := s.email.Send(ctx, s.inviteEmail(wedding, &invite, token)); err != nil {
+		s.logger.Warn("Failed to send collaborator invitation",
+			zap.String("wedding_id", wedding.ID.String()),
+			zap.String("invite_id", invite.ID.String()),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to send collaborator invitation: %w", err)
+	}
+
+	return &invite, nil
+}
+
+// AcceptInvite makes the user a collaborator of the wedding with the role of the
+// invitation the token belongs to. The user's email must be the invited one.
+func (s *CollaboratorService) AcceptInvite(ctx context.Context, weddingID, userID models.ID, token string) (*models.Wedding, error) {
+	wedding, err := s.getWedding(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := hashInviteToken(token)
+	index := -1
+	for i, invite := range wedding.CollaboratorInvites {
+		if invite.TokenHash == hash {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, ErrCollaboratorInviteNotFound
+	}
+	invite := wedding.CollaboratorInvites[index]
+	if invite.IsExpired() {
+		return nil, ErrCollaboratorInviteExpired
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	if !strings.EqualFold(user.Email, invite.Email) {
+		return nil, ErrCollaboratorInviteWrongEmail
+	}
+	if _, ok := wedding.RoleOf(userID); ok {
+		return nil, ErrAlreadyCollaborator
+	}
+
+	wedding.CollaboratorInvites = append(wedding.CollaboratorInvites[:index], wedding.CollaboratorInvites[index+1:]...)
+	wedding.Collaborators = append(wedding.Collaborators, models.WeddingCollaborator{
+		UserID:  userID,
+		Role:    invite.Role,
+		AddedAt: time.Now(),
+	})
+
+	if err := s.weddingRepo.Update(ctx, wedding); err != nil {
+		return nil, fmt.Errorf("failed to add collaborator: %w", err)
+	}
+
+	if err := s.userRepo.AddWeddingID(ctx, userID, wedding.ID); err != nil {
+		s.logger.Warn("Failed to link wedding to collaborator",
+			zap.String("wedding_id", wedding.ID.String()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+	}
+
+	return wedding, nil
+}
+
+// RemoveCollaborator removes a collaborator from the wedding. Owners may remove
+// anyone; collaborators may only remove themselves.
+func (s *CollaboratorService) RemoveCollaborator(ctx context.Context, weddingID, userID, collaboratorID models.ID) error {
+	wedding, err := s.getWedding(ctx, weddingID)
+	if err != nil {
+		return err
+	}
+	if userID != collaboratorID && !wedding.Can(userID, models.PermissionManageCollaborators) {
+		return ErrUnauthorized
+	}
+
+	collaborators := make([]models.WeddingCollaborator, 0, len(wedding.Collaborators))
+	for _, collaborator := range wedding.Collaborators {
+		if collaborator.UserID != collaboratorID {
+			collaborators = append(collaborators, collaborator)
+		}
+	}
+	if len(collaborators) == len(wedding.Collaborators) {
+		return ErrCollaboratorNotFound
+	}
+	wedding.Collaborators = collaborators
+
+	if err := s.weddingRepo.Update(ctx, wedding); err != nil {
+		return fmt.Errorf("failed to remove collaborator: %w", err)
+	}
+
+	if err := s.userRepo.RemoveWeddingID(ctx, collaboratorID, wedding.ID); err != nil {
+		s.logger.Warn("Failed to unlink wedding from collaborator",
+			zap.String("wedding_id", wedding.ID.String()),
+			zap.String("user_id", collaboratorID.String()),
+			zap.Error(err))
+	}
+
+	return nil
+}
+
+// AcceptLink returns the frontend link that accepts an invitation
+func (s *CollaboratorService) AcceptLink(weddingID models.ID, token string) string {
+	query := url.Values{}
+	query.Set("wedding", weddingID.String())
+	query.Set("token", token)
+	return fmt.Sprintf("%s/collaborations/accept?%s", s.siteURL, query.Encode())
+}
+
+func (s *CollaboratorService) inviteEmail(wedding *models.Wedding, invite *models.CollaboratorInvite, token string) *EmailMessage {
+	link := s.AcceptLink(wedding.ID, token)
+	expires := invite.ExpiresAt.Format("January 2, 2006")
+	return &EmailMessage{
+		To:      invite.Email,
+		Subject: fmt.Sprintf("You're invited to help plan %s", wedding.Title),
+		HTML: fmt.Sprintf(`<p>You have been invited to collaborate on <strong>%s</strong> as %s.</p>
+<p><a href="%s">Accept the invitation</a></p>
+<p style="font-size: 12px; color: #888;">Sign in with this email address to accept. The invitation expires on %s.</p>`,
+			template.HTMLEscapeString(wedding.Title), invite.Role, link, expires),
+		Text: fmt.Sprintf("You have been invited to collaborate on %s as %s.\n\nAccept the invitation: %s\n\nSign in with this email address to accept. The invitation expires on %s.\n",
+			wedding.Title, invite.Role, link, expires),
+	}
+}
+
+func (s *CollaboratorService) getWedding(ctx context.Context, weddingID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	return wedding, nil
+}
+
+func hashInviteToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+var acceptLinkPattern = regexp.MustCompile(`https://invites\.example\.com/collaborations/accept\?\S+`)
+
+func setupCollaboratorService(t *testing.T) (*CollaboratorService, *MockWeddingRepository, *MockUserRepository, *MockEmailService, *models.Wedding) {
+	weddingRepo := &MockWeddingRepository{}
+	userRepo := &MockUserRepository{}
+	email := &MockEmailService{}
+
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		UserID: models.NewID(),
+		Title:  "Alice & Bob",
+	}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+	weddingRepo.On("Update", mock.Anything, wedding).Return(nil)
+
+	service := NewCollaboratorService(weddingRepo, userRepo, email, "https://invites.example.com/", zaptest.NewLogger(t))
+	return service, weddingRepo, userRepo, email, wedding
+}
+
+// inviteToken extracts the invitation token from the accept link of an email
+func inviteToken(t *testing.T, msg *EmailMessage) string {
+	link := acceptLinkPattern.FindString(msg.Text)
+	require.NotEmpty(t, link)
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	return parsed.Query().Get("token")
+}
+
+func TestCollaboratorService_InviteCollaborator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Emails an invitation and stores its hash", func(t *testing.T) {
+		service, _, userRepo, email, wedding := setupCollaboratorService(t)
+		userRepo.On("GetByEmail", mock.Anything, "planner@example.com").Return(nil, errors.New("user not found"))
+
+		invite, err := service.InviteCollaborator(ctx, wedding.ID, wedding.UserID, " Planner@Example.com ", models.WeddingRolePlanner)
+		require.NoError(t, err)
+		assert.Equal(t, "planner@example.com", invite.Email)
+		assert.Equal(t, models.WeddingRolePlanner, invite.Role)
+		assert.WithinDuration(t, time.Now().Add(collaboratorInviteTTL), invite.ExpiresAt, time.Minute)
+
+		require.Len(t, email.sent, 1)
+		assert.Equal(t, "planner@example.com", email.sent[0].To)
+		assert.Contains(t, email.sent[0].Subject, "Alice & Bob")
+		assert.Contains(t, email.sent[0].HTML, "Alice &amp; Bob")
+		token := inviteToken(t, email.sent[0])
+		assert.Contains(t, email.sent[0].Text, "wedding="+wedding.ID.String())
+
+		require.Len(t, wedding.CollaboratorInvites, 1)
+		assert.Equal(t, hashInviteToken(token), wedding.CollaboratorInvites[0].TokenHash)
+		assert.NotEqual(t, token, wedding.CollaboratorInvites[0].TokenHash)
+	})
+
+	t.Run("Inviting again replaces the pending invitation", func(t *testing.T) {
+		service, _, userRepo, _, wedding := setupCollaboratorService(t)
+		userRepo.On("GetByEmail", mock.Anything, "planner@example.com").Return(nil, errors.New("user not found"))
+
+		first, err := service.InviteCollaborator(ctx, wedding.ID, wedding.UserID, "planner@example.com", models.WeddingRolePlanner)
+		require.NoError(t, err)
+		second, err := service.InviteCollaborator(ctx, wedding.ID, wedding.UserID, "planner@example.com", models.WeddingRoleCollaborator)
+		require.NoError(t, err)
+
+		require.Len(t, wedding.CollaboratorInvites, 1)
+		assert.NotEqual(t, first.ID, second.ID)
+		assert.Equal(t, models.WeddingRoleCollaborator, wedding.CollaboratorInvites[0].Role)
+	})
+
+	t.Run("Error - existing collaborator", func(t *testing.T) {
+		service, _, userRepo, _, wedding := setupCollaboratorService(t)
+		member := &models.User{ID: models.NewID(), Email: "partner@example.com"}
+		wedding.Collaborators = []models.WeddingCollaborator{{UserID: member.ID, Role: models.WeddingRoleCollaborator}}
+		userRepo.On("GetByEmail", mock.Anything, "partner@example.com").Return(member, nil)
+
+		_, err := service.InviteCollaborator(ctx, wedding.ID, wedding.UserID, "partner@example.com", models.WeddingRolePlanner)
+		assert.ErrorIs(t, err, ErrAlreadyCollaborator)
+	})
+
+	t.Run("Error - collaborators cannot invite", func(t *testing.T) {
+		service, _, _, _, wedding := setupCollaboratorService(t)
+		collaboratorID := models.NewID()
+		wedding.Collaborators = []models.WeddingCollaborator{{UserID: collaboratorID, Role: models.WeddingRoleCollaborator}}
+
+		_, err := service.InviteCollaborator(ctx, wedding.ID, collaboratorID, "planner@example.com", models.WeddingRolePlanner)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Error - owner role", func(t *testing.T) {
+		service, _, _, _, wedding := setupCollaboratorService(t)
+
+		_, err := service.InviteCollaborator(ctx, wedding.ID, wedding.UserID, "planner@example.com", models.WeddingRoleOwner)
+		assert.ErrorIs(t, err, ErrInvalidCollaboratorRole)
+	})
+}
+
+func TestCollaboratorService_AcceptInvite(t *testing.T) {
+	ctx := context.Background()
+
+	invite := func(t *testing.T, service *CollaboratorService, userRepo *MockUserRepository, email *MockEmailService, wedding *models.Wedding) string {
+		userRepo.On("GetByEmail", mock.Anything, "planner@example.com").Return(nil, errors.New("user not found"))
+		_, err := service.InviteCollaborator(ctx, wedding.ID, wedding.UserID, "planner@example.com", models.WeddingRolePlanner)
+		require.NoError(t, err)
+		return inviteToken(t, email.sent[len(email.sent)-1])
+	}
+
+	t.Run("Adds the user as a collaborator", func(t *testing.T) {
+		service, _, userRepo, email, wedding := setupCollaboratorService(t)
+		token := invite(t, service, userRepo, email, wedding)
+
+		planner := &models.User{ID: models.NewID(), Email: "Planner@example.com"}
+		userRepo.On("GetByID", mock.Anything, planner.ID).Return(planner, nil)
+		userRepo.On("AddWeddingID", mock.Anything, planner.ID, wedding.ID).Return(nil)
+
+		_, err := service.AcceptInvite(ctx, wedding.ID, planner.ID, token)
+		require.NoError(t, err)
+
+		assert.Empty(t, wedding.CollaboratorInvites)
+		role, ok := wedding.RoleOf(planner.ID)
+		assert.True(t, ok)
+		assert.Equal(t, models.WeddingRolePlanner, role)
+		assert.True(t, wedding.Can(planner.ID, models.PermissionManageGuests))
+		userRepo.AssertCalled(t, "AddWeddingID", mock.Anything, planner.ID, wedding.ID)
+
+		// The token can only be used once
+		_, err = service.AcceptInvite(ctx, wedding.ID, planner.ID, token)
+		assert.ErrorIs(t, err, ErrCollaboratorInviteNotFound)
+	})
+
+	t.Run("Error - signed in with another email", func(t *testing.T) {
+		service, _, userRepo, email, wedding := setupCollaboratorService(t)
+		token := invite(t, service, userRepo, email, wedding)
+
+		other := &models.User{ID: models.NewID(), Email: "someone@example.com"}
+		userRepo.On("GetByID", mock.Anything, other.ID).Return(other, nil)
+
+		_, err := service.AcceptInvite(ctx, wedding.ID, other.ID, token)
+		assert.ErrorIs(t, err, ErrCollaboratorInviteWrongEmail)
+		assert.Len(t, wedding.CollaboratorInvites, 1)
+	})
+
+	t.Run("Error - expired", func(t *testing.T) {
+		service, _, userRepo, email, wedding := setupCollaboratorService(t)
+		token := invite(t, service, userRepo, email, wedding)
+		wedding.CollaboratorInvites[0].ExpiresAt = time.Now().Add(-time.Minute)
+
+		_, err := service.AcceptInvite(ctx, wedding.ID, models.NewID(), token)
+		assert.ErrorIs(t, err, ErrCollaboratorInviteExpired)
+	})
+
+	t.Run("Error - unknown token", func(t *testing.T) {
+		service, _, _, _, wedding := setupCollaboratorService(t)
+
+		_, err := service.AcceptInvite(ctx, wedding.ID, models.NewID(), "not-a-token")
+		assert.ErrorIs(t, err, ErrCollaboratorInviteNotFound)
+	})
+}
+
+func TestCollaboratorService_RemoveCollaborator(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*CollaboratorService, *MockUserRepository, *models.Wedding, models.ID, models.ID) {
+		service, _, userRepo, _, wedding := setupCollaboratorService(t)
+		partnerID, plannerID := models.NewID(), models.NewID()
+		wedding.Collaborators = []models.WeddingCollaborator{
+			{UserID: partnerID, Role: models.WeddingRoleCollaborator},
+			{UserID: plannerID, Role: models.WeddingRolePlanner},
+		}
+		userRepo.On("RemoveWeddingID", mock.Anything, mock.Anything, wedding.ID).Return(nil)
+		return service, userRepo, wedding, partnerID, plannerID
+	}
+
+	t.Run("Owner removes a collaborator", func(t *testing.T) {
+		service, _, wedding, partnerID, plannerID := setup(t)
+
+		require.NoError(t, service.RemoveCollaborator(ctx, wedding.ID, wedding.UserID, plannerID))
+		require.Len(t, wedding.Collaborators, 1)
+		assert.Equal(t, partnerID, wedding.Collaborators[0].UserID)
+	})
+
+	t.Run("Collaborator leaves the wedding", func(t *testing.T) {
+		service, _, wedding, _, plannerID := setup(t)
+
+		require.NoError(t, service.RemoveCollaborator(ctx, wedding.ID, plannerID, plannerID))
+		_, ok := wedding.RoleOf(plannerID)
+		assert.False(t, ok)
+	})
+
+	t.Run("Error - collaborator removes another", func(t *testing.T) {
+		service, _, wedding, partnerID, plannerID := setup(t)
+
+		err := service.RemoveCollaborator(ctx, wedding.ID, partnerID, plannerID)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Error - not a collaborator", func(t *testing.T) {
+		service, _, wedding, _, _ := setup(t)
+
+		err := service.RemoveCollaborator(ctx, wedding.ID, wedding.UserID, models.NewID())
+		assert.ErrorIs(t, err, ErrCollaboratorNotFound)
+	})
+}
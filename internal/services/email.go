@@ -0,0 +1,125 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// sendGridEndpoint is the SendGrid v3 mail send API
+const sendGridEndpoint = "https://api.sendgrid.com/v3/mail/send"
+
+// EmailMessage is a single outgoing email
+type EmailMessage struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// EmailService sends transactional email
+type EmailService interface {
+	Send(ctx context.Context, msg *EmailMessage) error
+}
+
+// SendGridEmailService sends email through the SendGrid v3 API
+type SendGridEmailService struct {
+	apiKey     string
+	from       string
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewSendGridEmailService creates an email service sending from the given address
+func NewSendGridEmailService(apiKey, from string) *SendGridEmailService {
+	return &SendGridEmailService{
+		apiKey:     apiKey,
+		from:       from,
+		endpoint:   sendGridEndpoint,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+// Send posts the message to SendGrid, which answers 202 once it is queued
+func (s *SendGridEmailService) Send(ctx context.Context, msg *EmailMessage) error {
+	req := sendGridRequest{
+		Personalizations: []sendGridPersonalization{{To: []sendGridAddress{{Email: msg.To}}}},
+		From:             sendGridAddress{Email: s.from},
+		Subject:          msg.Subject,
+	}
+	// SendGrid requires text/plain to precede text/html
+	if msg.Text != "" {
+		req.Content = append(req.Content, sendGridContent{Type: "text/plain", Value: msg.Text})
+	}
+	if msg.HTML != "" {
+		req.Content = append(req.Content, sendGridContent{Type: "text/html", Value: msg.HTML})
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode email: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build email request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+s.apiKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("email provider returned status %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+// LogEmailService writes emails to the log instead of sending them, for development
+type LogEmailService struct {
+	logger *zap.Logger
+}
+
+// NewLogEmailService creates an email service that only logs messages
+func NewLogEmailService(logger *zap.Logger) *LogEmailService {
+	return &LogEmailService{logger: logger}
+}
+
+// Send logs the recipient and subject of the message
+func (s *LogEmailService) Send(ctx context.Context, msg *EmailMessage) error {
+	s.logger.Info("Email not sent, no email provider configured",
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject))
+	return nil
+}
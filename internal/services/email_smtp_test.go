@@ -0,0 +1,164 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts one plain SMTP conversation and records the envelope and message
+type fakeSMTPServer struct {
+	listener net.Listener
+	from     string
+	to       []string
+	data     string
+	done     chan struct{}
+}
+
+func newFakeSMTPServer(t *testing.T, rejectRecipient bool) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeSMTPServer{listener: listener, done: make(chan struct{})}
+	go server.serve(rejectRecipient)
+	return server
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve(rejectRecipient bool) {
+	defer close(s.done)
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			s.from = line[len("MAIL FROM:"):]
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			if rejectRecipient {
+				reply("550 No such user")
+				continue
+			}
+			s.to = append(s.to, line[len("RCPT TO:"):])
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 Go ahead")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			s.data = data.String()
+			reply("250 Queued")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPEmailService_Send(t *testing.T) {
+	server := newFakeSMTPServer(t, false)
+	service := NewSMTPEmailService("127.0.0.1", server.port(), "", "", "Weddings <noreply@example.com>")
+
+	err := service.Send(context.Background(), &EmailMessage{
+		To:      "ana@example.com",
+		Subject: "Undangan pernikahan",
+		HTML:    "<p>Hi Ana</p>",
+		Text:    "Hi Ana",
+	})
+	require.NoError(t, err)
+	<-server.done
+
+	assert.Equal(t, "<noreply@example.com>", server.from)
+	assert.Equal(t, []string{"<ana@example.com>"}, server.to)
+
+	msg, err := mail.ReadMessage(strings.NewReader(server.data))
+	require.NoError(t, err)
+	assert.Equal(t, "Undangan pernikahan", msg.Header.Get("Subject"))
+	assert.Equal(t, "<ana@example.com>", msg.Header.Get("To"))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	var types, bodies []string
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		types = append(types, part.Header.Get("Content-Type"))
+		bodies = append(bodies, strings.TrimSpace(string(body)))
+	}
+	assert.Equal(t, []string{"text/plain; charset=utf-8", "text/html; charset=utf-8"}, types)
+	assert.Equal(t, []string{"Hi Ana", "<p>Hi Ana</p>"}, bodies)
+}
+
+func TestSMTPEmailService_RejectedRecipient(t *testing.T) {
+	server := newFakeSMTPServer(t, true)
+	service := NewSMTPEmailService("127.0.0.1", server.port(), "", "", "noreply@example.com")
+
+	err := service.Send(context.Background(), &EmailMessage{To: "nobody@example.com", Subject: "Hi", Text: "Hi"})
+	assert.ErrorContains(t, err, "rejected recipient")
+}
+
+func TestSMTPEmailService_InvalidRecipient(t *testing.T) {
+	service := NewSMTPEmailService("127.0.0.1", 1, "", "", "noreply@example.com")
+
+	err := service.Send(context.Background(), &EmailMessage{To: "not an address", Subject: "Hi", Text: "Hi"})
+	assert.ErrorContains(t, err, "invalid recipient")
+}
+
+func TestBuildMIMEMessage_StripsHeaderLineBreaks(t *testing.T) {
+	from := &mail.Address{Address: "noreply@example.com"}
+	to := &mail.Address{Address: "ana@example.com"}
+
+	body, err := buildMIMEMessage(from, to, &EmailMessage{Subject: "Hi\r\nBcc: eve@example.com", Text: "Hi"})
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(body)))
+	require.NoError(t, err)
+	assert.Empty(t, msg.Header.Get("Bcc"))
+}
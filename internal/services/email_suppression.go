@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+var (
+	// ErrEmailSuppressed is returned for email to an address on the suppression list
+	ErrEmailSuppressed = errors.New("email address is suppressed")
+	// ErrEmailSuppressionNotFound is returned when lifting a suppression that does not exist
+	ErrEmailSuppressionNotFound = errs.NotFound("email suppression not found")
+	// ErrInvalidEmailAddress is returned for addresses that cannot be parsed
+	ErrInvalidEmailAddress = errors.New("invalid email address")
+	// ErrEmailWebhookNotConfigured is returned for event webhooks without a verification key
+	ErrEmailWebhookNotConfigured = errors.New("email event webhook is not configured")
+	// ErrInvalidEmailWebhookSignature is returned for event webhooks failing verification
+	ErrInvalidEmailWebhookSignature = errors.New("invalid email event webhook signature")
+)
+
+// EmailSuppressionService keeps the list of addresses email is no longer sent
+// to, because mail to them bounced, their owner reported it as spam or an
+// admin suppressed them
+type EmailSuppressionService struct {
+	repo       repository.EmailSuppressionRepository
+	webhookKey *ecdsa.PublicKey
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewEmailSuppressionService creates a new email suppression service
+func NewEmailSuppressionService(repo repository.EmailSuppressionRepository, logger *zap.Logger) *EmailSuppressionService {
+	return &EmailSuppressionService{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// EnableSendGridWebhook accepts SendGrid event webhooks signed with the key's private half
+func (s *EmailSuppressionService) EnableSendGridWebhook(key *ecdsa.PublicKey) {
+	s.webhookKey = key
+}
+
+// normalizeEmailAddress returns the bare lower case address, accepting "Name <address>" forms
+func normalizeEmailAddress(email string) (string, error) {
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return "", ErrInvalidEmailAddress
+	}
+	return strings.ToLower(addr.Address), nil
+}
+
+// Suppress stops email to the address
+func (s *EmailSuppressionService) Suppress(ctx context.Context, email string, reason models.EmailSuppressionReason, detail string) error {
+	address, err := normalizeEmailAddress(email)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Upsert(ctx, &models.EmailSuppression{
+		Email:     address,
+		Reason:    reason,
+		Detail:    detail,
+		CreatedAt: s.now(),
+	}); err != nil {
+		return fmt.Errorf("failed to suppress email address: %w", err)
+	}
+	s.logger.Info("Email address suppressed",
+		zap.String("email", address),
+		zap.String("reason", string(reason)))
+	return nil
+}
+
+// IsSuppressed reports whether email to the address is suppressed
+func (s *EmailSuppressionService) IsSuppressed(ctx context.Context, email string) (bool, error) {
+	address, err := normalizeEmailAddress(email)
+	if err != nil {
+		// Invalid addresses are left to the provider to reject
+		return false, nil
+	}
+	return s.repo.Exists(ctx, address)
+}
+
+// List lists the suppressed addresses, optionally only those containing search
+func (s *EmailSuppressionService) List(ctx context.Context, search string, page, pageSize int) ([]*models.EmailSuppression, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	return s.repo.List(ctx, strings.ToLower(strings.TrimSpace(search)), page, pageSize)
+}
+
+// Remove lifts the suppression of the address
+func (s *EmailSuppressionService) Remove(ctx context.Context, email string) error {
+	address, err := normalizeEmailAddress(email)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, address); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrEmailSuppressionNotFound
+		}
+		return fmt.Errorf("failed to remove email suppression: %w", err)
+	}
+	return nil
+}
+
+// VerifySendGridWebhook checks the signature of a SendGrid event webhook body
+func (s *EmailSuppressionService) VerifySendGridWebhook(timestamp string, body []byte, signature string) error {
+	if s.webhookKey == nil {
+		return ErrEmailWebhookNotConfigured
+	}
+	if !VerifySendGridSignature(s.webhookKey, timestamp, body, signature) {
+		return ErrInvalidEmailWebhookSignature
+	}
+	return nil
+}
+
+// RecordSendGridEvents suppresses the addresses of permanent bounces and spam
+// reports among SendGrid event webhook events. Other events are ignored.
+func (s *EmailSuppressionService) RecordSendGridEvents(ctx context.Context, events []SendGridEvent) error {
+	for _, event := range events {
+		reason, ok := event.suppressionReason()
+		if !ok {
+			continue
+		}
+		if err := s.Suppress(ctx, event.Email, reason, event.Reason); err != nil {
+			if errors.Is(err, ErrInvalidEmailAddress) {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// SuppressedEmailService checks the suppression list before handing email to
+// the provider, refusing email to suppressed addresses with ErrEmailSuppressed
+type SuppressedEmailService struct {
+	next         EmailService
+	suppressions *EmailSuppressionService
+	logger       *zap.Logger
+}
+
+// NewSuppressedEmailService wraps an email service with the suppression list
+func NewSuppressedEmailService(next EmailService, suppressions *EmailSuppressionService, logger *zap.Logger) *SuppressedEmailService {
+	return &SuppressedEmailService{
+		next:         next,
+		suppressions: suppressions,
+		logger:       logger,
+	}
+}
+
+// Send sends the message unless its recipient is suppressed
+func (s *SuppressedEmailService) Send(ctx context.Context, msg *EmailMessage) error {
+	suppressed, err := s.suppressions.IsSuppressed(ctx, msg.To)
+	if err != nil {
+		// Better to send to a bounced address than to drop mail while the database is unavailable
+		s.logger.Warn("Failed to check email suppression list", zap.Error(err))
+	} else if suppressed {
+		s.logger.Info("Email not sent to suppressed address",
+			zap.String("to", msg.To),
+			zap.String("subject", msg.Subject))
+		return ErrEmailSuppressed
+	}
+	return s.next.Send(ctx, msg)
+}
+
+// SendGrid signed event webhook headers
+const (
+	SendGridSignatureHeader = "X-Twilio-Email-Event-Webhook-Signature"
+	SendGridTimestampHeader = "X-Twilio-Email-Event-Webhook-Timestamp"
+)
+
+// ParseSendGridWebhookKey parses the base64 verification key SendGrid shows
+// for the signed event webhook
+func ParseSendGridWebhookKey(encoded string) (*ecdsa.PublicKey, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key: %w", err)
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key: %w", err)
+	}
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an ECDSA key")
+	}
+	return ecKey, nil
+}
+
+// VerifySendGridSignature checks the base64 ECDSA signature SendGrid computes
+// over the timestamp header followed by the webhook body
+func VerifySendGridSignature(key *ecdsa.PublicKey, timestamp string, body []byte, signature string) bool {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || timestamp == "" {
+		return false
+	}
+	hash := sha256.New()
+	hash.Write([]byte(timestamp))
+	hash.Write(body)
+	return ecdsa.VerifyASN1(key, hash.Sum(nil), sig)
+}
+
+// SendGridEvent is an event of the SendGrid event webhook
+type SendGridEvent struct {
+	Email string `json:"email"`
+	Event string `json:"event"`
+	// Type tells permanent bounces ("bounce") from temporary blocks ("blocked")
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// suppressionReason returns why the event suppresses its address, if it does
+func (e SendGridEvent) suppressionReason() (models.EmailSuppressionReason, bool) {
+	switch e.Event {
+	case "bounce":
+		if e.Type == "blocked" {
+			return "", false
+		}
+		return models.EmailSuppressionBounce, true
+	case "spamreport":
+		return models.EmailSuppressionSpamReport, true
+	}
+	return "", false
+}
+
+// ParseSendGridEvents decodes an event webhook body, which is a JSON array of events
+func ParseSendGridEvents(body []byte) ([]SendGridEvent, error) {
+	var events []SendGridEvent
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, fmt.Errorf("invalid SendGrid events: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// memoryEmailSuppressionRepository keeps suppressions in memory
+type memoryEmailSuppressionRepository struct {
+	suppressions map[string]*models.EmailSuppression
+	err          error
+}
+
+func newMemoryEmailSuppressionRepository() *memoryEmailSuppressionRepository {
+	return &memoryEmailSuppressionRepository{suppressions: make(map[string]*models.EmailSuppression)}
+}
+
+func (r *memoryEmailSuppressionRepository) Upsert(ctx context.Context, suppression *models.EmailSuppression) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.suppressions[suppression.Email] = suppression
+	return nil
+}
+
+func (r *memoryEmailSuppressionRepository) Exists(ctx context.Context, email string) (bool, error) {
+	if r.err != nil {
+		return false, r.err
+	}
+	_, ok := r.suppressions[email]
+	return ok, nil
+}
+
+func (r *memoryEmailSuppressionRepository) List(ctx context.Context, search string, page, pageSize int) ([]*models.EmailSuppression, int64, error) {
+	var list []*models.EmailSuppression
+	for email, suppression := range r.suppressions {
+		if strings.Contains(email, search) {
+			list = append(list, suppression)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Email < list[j].Email })
+	return list, int64(len(list)), nil
+}
+
+func (r *memoryEmailSuppressionRepository) Delete(ctx context.Context, email string) error {
+	if _, ok := r.suppressions[email]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.suppressions, email)
+	return nil
+}
+
+func TestEmailSuppressionService_Suppress(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryEmailSuppressionRepository()
+	service := NewEmailSuppressionService(repo, zap.NewNop())
+
+	require.NoError(t, service.Suppress(ctx, "Ana <Ana@Example.com>", models.EmailSuppressionManual, "asked to stop"))
+	require.Contains(t, repo.suppressions, "ana@example.com")
+	assert.Equal(t, models.EmailSuppressionManual, repo.suppressions["ana@example.com"].Reason)
+
+	suppressed, err := service.IsSuppressed(ctx, "ANA@example.com")
+	require.NoError(t, err)
+	assert.True(t, suppressed)
+
+	suppressed, err = service.IsSuppressed(ctx, "budi@example.com")
+	require.NoError(t, err)
+	assert.False(t, suppressed)
+
+	assert.ErrorIs(t, service.Suppress(ctx, "not an address", models.EmailSuppressionManual, ""), ErrInvalidEmailAddress)
+
+	require.NoError(t, service.Remove(ctx, "ana@example.com"))
+	assert.ErrorIs(t, service.Remove(ctx, "ana@example.com"), ErrEmailSuppressionNotFound)
+}
+
+func TestEmailSuppressionService_RecordSendGridEvents(t *testing.T) {
+	repo := newMemoryEmailSuppressionRepository()
+	service := NewEmailSuppressionService(repo, zap.NewNop())
+
+	events, err := ParseSendGridEvents([]byte(`[
+		{"email":"bounced@example.com","event":"bounce","type":"bounce","reason":"550 5.1.1 User unknown"},
+		{"email":"blocked@example.com","event":"bounce","type":"blocked","reason":"421 Try again later"},
+		{"email":"spam@example.com","event":"spamreport"},
+		{"email":"delivered@example.com","event":"delivered"},
+		{"email":"","event":"bounce","type":"bounce"}
+	]`))
+	require.NoError(t, err)
+	require.NoError(t, service.RecordSendGridEvents(context.Background(), events))
+
+	require.Len(t, repo.suppressions, 2)
+	assert.Equal(t, models.EmailSuppressionBounce, repo.suppressions["bounced@example.com"].Reason)
+	assert.Equal(t, "550 5.1.1 User unknown", repo.suppressions["bounced@example.com"].Detail)
+	assert.Equal(t, models.EmailSuppressionSpamReport, repo.suppressions["spam@example.com"].Reason)
+
+	_, err = ParseSendGridEvents([]byte(`{"email":"x"}`))
+	assert.Error(t, err)
+}
+
+func TestSuppressedEmailService_Send(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryEmailSuppressionRepository()
+	suppressions := NewEmailSuppressionService(repo, zap.NewNop())
+	require.NoError(t, suppressions.Suppress(ctx, "bounced@example.com", models.EmailSuppressionBounce, ""))
+	provider := &MockEmailService{}
+	service := NewSuppressedEmailService(provider, suppressions, zap.NewNop())
+
+	err := service.Send(ctx, &EmailMessage{To: "Bounced@example.com", Subject: "Hi"})
+	assert.ErrorIs(t, err, ErrEmailSuppressed)
+	assert.Empty(t, provider.sent)
+
+	require.NoError(t, service.Send(ctx, &EmailMessage{To: "ana@example.com", Subject: "Hi"}))
+	assert.Len(t, provider.sent, 1)
+
+	// Mail still goes out while the suppression list is unavailable
+	repo.err = errors.New("connection refused")
+	require.NoError(t, service.Send(ctx, &EmailMessage{To: "bounced@example.com", Subject: "Hi"}))
+	assert.Len(t, provider.sent, 2)
+}
+
+func TestVerifySendGridSignature(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	key, err := ParseSendGridWebhookKey(base64.StdEncoding.EncodeToString(der))
+	require.NoError(t, err)
+
+	body := []byte(`[{"email":"ana@example.com","event":"bounce"}]`)
+	timestamp := "1780000000"
+	hash := sha256.Sum256(append([]byte(timestamp), body...))
+	sig, err := ecdsa.SignASN1(rand.Reader, privateKey, hash[:])
+	require.NoError(t, err)
+	signature := base64.StdEncoding.EncodeToString(sig)
+
+	assert.True(t, VerifySendGridSignature(key, timestamp, body, signature))
+	assert.False(t, VerifySendGridSignature(key, "1780000001", body, signature))
+	assert.False(t, VerifySendGridSignature(key, timestamp, []byte(`[]`), signature))
+	assert.False(t, VerifySendGridSignature(key, timestamp, body, "not base64"))
+
+	service := NewEmailSuppressionService(newMemoryEmailSuppressionRepository(), zap.NewNop())
+	assert.ErrorIs(t, service.VerifySendGridWebhook(timestamp, body, signature), ErrEmailWebhookNotConfigured)
+	service.EnableSendGridWebhook(key)
+	assert.NoError(t, service.VerifySendGridWebhook(timestamp, body, signature))
+	assert.ErrorIs(t, service.VerifySendGridWebhook(timestamp, body, ""), ErrInvalidEmailWebhookSignature)
+
+	_, err = ParseSendGridWebhookKey("not a key")
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Email templates. Each has an HTML and a plain text part; the text part
+// also defines the subject.
+const (
+	EmailTemplateVerification     = "verification"
+	EmailTemplatePasswordReset    = "password_reset"
+	EmailTemplateRSVPConfirmation = "rsvp_confirmation"
+	EmailTemplateInvitation       = "invitation"
+	EmailTemplateAccountDeletion  = "account_deletion"
+)
+
+//go:embed templates/email/*.html templates/email/*.txt
+var emailTemplateFiles embed.FS
+
+// EmailTemplates renders the transactional emails from the templates in
+// templates/email: NAME.html is the HTML part and NAME.txt the plain text part,
+// which defines the subject as {{define "subject"}}...{{end}}
+type EmailTemplates struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// defaultEmailTemplates are parsed once; the embedded templates are known to be valid
+var defaultEmailTemplates = mustParseEmailTemplates()
+
+// DefaultEmailTemplates returns the built-in email templates
+func DefaultEmailTemplates() *EmailTemplates {
+	return defaultEmailTemplates
+}
+
+func mustParseEmailTemplates() *EmailTemplates {
+	templates, err := parseEmailTemplates()
+	if err != nil {
+		panic(err)
+	}
+	return templates
+}
+
+func parseEmailTemplates() (*EmailTemplates, error) {
+	html, err := htmltemplate.ParseFS(emailTemplateFiles, "templates/email/*.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML email templates: %w", err)
+	}
+	entries, err := emailTemplateFiles.ReadDir("templates/email")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email templates: %w", err)
+	}
+
+	// The text templates all define "subject", so each gets its own set
+	text := texttemplate.New("")
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".txt") {
+			continue
+		}
+		tmpl, err := texttemplate.ParseFS(emailTemplateFiles, "templates/email/"+entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse text email template %s: %w", entry.Name(), err)
+		}
+		if _, err := text.AddParseTree(entry.Name(), tmpl.Tree); err != nil {
+			return nil, err
+		}
+		if _, err := text.AddParseTree(strings.TrimSuffix(entry.Name(), ".txt")+".subject", tmpl.Lookup("subject").Tree); err != nil {
+			return nil, err
+		}
+	}
+	return &EmailTemplates{html: html, text: text}, nil
+}
+
+// Render renders the named email for the given data. The recipient is left to the caller.
+func (t *EmailTemplates) Render(name string, data interface{}) (*EmailMessage, error) {
+	var subject, text, html bytes.Buffer
+	if err := t.text.ExecuteTemplate(&subject, name+".subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s email subject: %w", name, err)
+	}
+	if err := t.text.ExecuteTemplate(&text, name+".txt", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s email: %w", name, err)
+	}
+	if err := t.html.ExecuteTemplate(&html, name+".html", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s email: %w", name, err)
+	}
+	return &EmailMessage{
+		Subject: strings.TrimSpace(subject.String()),
+		HTML:    html.String(),
+		Text:    text.String(),
+	}, nil
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailTemplates_Render(t *testing.T) {
+	templates := DefaultEmailTemplates()
+
+	msg, err := templates.Render(EmailTemplateVerification, struct {
+		FirstName string
+		Link      string
+	}{"<Ana>", "https://example.com/verify-email?token=abc"})
+	require.NoError(t, err)
+	assert.Equal(t, "Verify your email address", msg.Subject)
+	assert.Contains(t, msg.Text, "Hi <Ana>,")
+	assert.Contains(t, msg.Text, "https://example.com/verify-email?token=abc")
+	assert.Contains(t, msg.HTML, "&lt;Ana&gt;")
+	assert.NotContains(t, msg.HTML, "<Ana>")
+	assert.Empty(t, msg.To)
+
+	msg, err = templates.Render(EmailTemplatePasswordReset, struct {
+		FirstName string
+		Link      string
+		ExpiresAt time.Time
+	}{"Ana", "https://example.com/reset-password?token=abc", time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	assert.Equal(t, "Reset your password", msg.Subject)
+	assert.Contains(t, msg.Text, "June 1, 2026 12:00 UTC")
+
+	for _, name := range []string{EmailTemplateRSVPConfirmation, EmailTemplateInvitation, EmailTemplateAccountDeletion} {
+		assert.NotNil(t, templates.html.Lookup(name+".html"), name)
+		assert.NotNil(t, templates.text.Lookup(name+".txt"), name)
+		assert.NotNil(t, templates.text.Lookup(name+".subject"), name)
+	}
+
+	_, err = templates.Render("missing", nil)
+	assert.Error(t, err)
+}
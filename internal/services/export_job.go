@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// ExportJobService keeps the history of wedding data exports, including the
+// fingerprint of the key each encrypted export was sealed to
+type ExportJobService struct {
+	jobRepo     repository.ExportJobRepository
+	weddingRepo repository.WeddingRepository
+	logger      *zap.Logger
+}
+
+// NewExportJobService creates a new export job service
+func NewExportJobService(jobRepo repository.ExportJobRepository, weddingRepo repository.WeddingRepository, logger *zap.Logger) *ExportJobService {
+	return &ExportJobService{
+		jobRepo:     jobRepo,
+		weddingRepo: weddingRepo,
+		logger:      logger,
+	}
+}
+
+// StartExport verifies the user may manage the wedding's guests and RSVPs and records
+// a running export job. recipient is nil for unencrypted exports.
+func (s *ExportJobService) StartExport(ctx context.Context, weddingID, userID models.ID, resource, format string, recipient utils.ExportRecipient) (*models.ExportJob, error) {
+	if err := s.verifyOwnership(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	job := &models.ExportJob{
+		WeddingID: weddingID,
+		UserID:    userID,
+		Resource:  resource,
+		Format:    format,
+	}
+	if recipient != nil {
+		job.Encryption = recipient.Type()
+		job.KeyFingerprint = recipient.Fingerprint()
+	}
+
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to record export job: %w", err)
+	}
+	return job, nil
+}
+
+// FinishExport records the outcome of an export. Failures to record are logged
+// rather than returned, since the export itself has already been sent.
+func (s *ExportJobService) FinishExport(ctx context.Context, job *models.ExportJob, records int, exportErr error) {
+	finishExportJob(ctx, s.jobRepo, s.logger, job, records, exportErr)
+}
+
+// finishExportJob records the final status and record count of an export job
+func finishExportJob(ctx context.Context, jobRepo repository.ExportJobRepository, logger *zap.Logger, job *models.ExportJob, records int, exportErr error) {
+	job.Status = models.ExportJobCompleted
+	job.Records = records
+
+	reason := ""
+	if exportErr != nil {
+		job.Status = models.ExportJobFailed
+		reason = exportErr.Error()
+		job.Error = reason
+	}
+
+	if err := jobRepo.Finish(ctx, job.ID, job.Status, records, reason); err != nil {
+		logger.Error("Failed to finish export job",
+			zap.String("job_id", job.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// ListExportJobs returns the export history of a wedding for its owner
+func (s *ExportJobService) ListExportJobs(ctx context.Context, weddingID, userID models.ID, page, pageSize int) ([]*models.ExportJob, int64, error) {
+	if err := s.verifyOwnership(ctx, weddingID, userID); err != nil {
+		return nil, 0, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	return s.jobRepo.ListByWedding(ctx, weddingID, page, pageSize)
+}
+
+func (s *ExportJobService) verifyOwnership(ctx context.Context, weddingID, userID models.ID) error {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWeddingNotFound
+		}
+		return fmt.Errorf("failed to get wedding: %w", err)
+	}
+
+	// Owners, collaborators and planners all manage guests and RSVPs
+	if !wedding.Can(userID, models.PermissionManageGuests) {
+		return ErrUnauthorized
+	}
+	return nil
+}
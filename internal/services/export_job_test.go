@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// MockExportJobRepository is an in-memory export job repository
+type MockExportJobRepository struct {
+	jobs map[models.ID]*models.ExportJob
+}
+
+func NewMockExportJobRepository() *MockExportJobRepository {
+	return &MockExportJobRepository{jobs: make(map[models.ID]*models.ExportJob)}
+}
+
+func (m *MockExportJobRepository) Create(ctx context.Context, job *models.ExportJob) error {
+	if job.ID.IsZero() {
+		job.ID = models.NewID()
+	}
+	if job.Status != models.ExportJobQueued {
+		job.Status = models.ExportJobRunning
+	}
+	stored := *job
+	m.jobs[job.ID] = &stored
+	return nil
+}
+
+func (m *MockExportJobRepository) GetByID(ctx context.Context, id models.ID) (*models.ExportJob, error) {
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	stored := *job
+	return &stored, nil
+}
+
+func (m *MockExportJobRepository) SetFile(ctx context.Context, id models.ID, fileKey string) error {
+	job, ok := m.jobs[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	job.FileKey = fileKey
+	return nil
+}
+
+func (m *MockExportJobRepository) Finish(ctx context.Context, id models.ID, status models.ExportJobStatus, records int, reason string) error {
+	job, ok := m.jobs[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	job.Status = status
+	job.Records = records
+	job.Error = reason
+	return nil
+}
+
+func (m *MockExportJobRepository) ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int) ([]*models.ExportJob, int64, error) {
+	var jobs []*models.ExportJob
+	for _, job := range m.jobs {
+		if job.WeddingID == weddingID {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, int64(len(jobs)), nil
+}
+
+func setupExportJobService(t *testing.T) (*ExportJobService, *MockExportJobRepository, *models.Wedding) {
+	repo := NewMockExportJobRepository()
+	weddingRepo := new(MockWeddingRepository)
+
+	wedding := createTestWedding()
+	wedding.ID = models.NewID()
+	wedding.UserID = models.NewID()
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+	weddingRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+
+	return NewExportJobService(repo, weddingRepo, zaptest.NewLogger(t)), repo, wedding
+}
+
+func TestExportJobService_StartExport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - records key fingerprint", func(t *testing.T) {
+		service, repo, wedding := setupExportJobService(t)
+
+		identity, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		recipient, err := utils.ParseExportRecipient(identity.Recipient().String())
+		require.NoError(t, err)
+
+		job, err := service.StartExport(ctx, wedding.ID, wedding.UserID, "rsvps", "csv", recipient)
+		require.NoError(t, err)
+		assert.True(t, job.IsEncrypted())
+
+		stored := repo.jobs[job.ID]
+		assert.Equal(t, models.ExportJobRunning, stored.Status)
+		assert.Equal(t, utils.ExportKeyAge, stored.Encryption)
+		assert.Equal(t, recipient.Fingerprint(), stored.KeyFingerprint)
+
+		service.FinishExport(ctx, job, 42, nil)
+		assert.Equal(t, models.ExportJobCompleted, repo.jobs[job.ID].Status)
+		assert.Equal(t, 42, repo.jobs[job.ID].Records)
+	})
+
+	t.Run("Success - unencrypted export has no fingerprint", func(t *testing.T) {
+		service, repo, wedding := setupExportJobService(t)
+
+		job, err := service.StartExport(ctx, wedding.ID, wedding.UserID, "guests", "json", nil)
+		require.NoError(t, err)
+		assert.False(t, job.IsEncrypted())
+		assert.Empty(t, repo.jobs[job.ID].KeyFingerprint)
+
+		service.FinishExport(ctx, job, 3, errors.New("client went away"))
+		assert.Equal(t, models.ExportJobFailed, repo.jobs[job.ID].Status)
+		assert.Equal(t, "client went away", repo.jobs[job.ID].Error)
+	})
+
+	t.Run("Error - not the owner", func(t *testing.T) {
+		service, repo, wedding := setupExportJobService(t)
+
+		_, err := service.StartExport(ctx, wedding.ID, models.NewID(), "rsvps", "json", nil)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		assert.Empty(t, repo.jobs)
+	})
+
+	t.Run("Error - wedding not found", func(t *testing.T) {
+		service, _, wedding := setupExportJobService(t)
+
+		_, err := service.StartExport(ctx, models.NewID(), wedding.UserID, "rsvps", "json", nil)
+		assert.ErrorIs(t, err, ErrWeddingNotFound)
+	})
+}
@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Features that can be switched on and off per environment and per wedding
+const (
+	FeatureGuestPhotos       = "guest_photos"
+	FeatureWishes            = "wishes"
+	FeatureAnalyticsTracking = "analytics_tracking"
+	FeatureRSVPEditing       = "rsvp_editing"
+	FeatureSongRequests      = "song_requests"
+)
+
+var ErrUnknownFeature = errs.NotFound("unknown feature")
+
+// FeatureChecker reports whether a feature is on for a wedding
+type FeatureChecker interface {
+	Enabled(ctx context.Context, weddingID models.ID, feature string) bool
+}
+
+// featureEnabled reports whether a feature is on, all of them being on without a checker
+func featureEnabled(ctx context.Context, features FeatureChecker, weddingID models.ID, feature string) bool {
+	return features == nil || features.Enabled(ctx, weddingID, feature)
+}
+
+// FeatureOverrideStore keeps the overrides of feature defaults of a scope, all
+// weddings or one of them
+type FeatureOverrideStore interface {
+	Get(ctx context.Context, scope string) (map[string]bool, error)
+	Set(ctx context.Context, scope, feature string, enabled bool) error
+	Delete(ctx context.Context, scope, feature string) error
+}
+
+// featureScope is the scope of overrides of one wedding, or of all weddings for NilID
+func featureScope(weddingID models.ID) string {
+	if weddingID == models.NilID {
+		return "global"
+	}
+	return "wedding:" + weddingID.String()
+}
+
+// redisFeatureOverrideStore keeps overrides in Redis, so that they apply to every API instance
+type redisFeatureOverrideStore struct {
+	client *redis.Client
+}
+
+// NewRedisFeatureOverrideStore keeps feature overrides in Redis hashes
+func NewRedisFeatureOverrideStore(client *redis.Client) FeatureOverrideStore {
+	return &redisFeatureOverrideStore{client: client}
+}
+
+func (s *redisFeatureOverrideStore) key(scope string) string {
+	return "features:" + scope
+}
+
+func (s *redisFeatureOverrideStore) Get(ctx context.Context, scope string) (map[string]bool, error) {
+	values, err := s.client.HGetAll(ctx, s.key(scope)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature overrides: %w", err)
+	}
+	overrides := make(map[string]bool, len(values))
+	for feature, value := range values {
+		overrides[feature] = value == "1"
+	}
+	return overrides, nil
+}
+
+func (s *redisFeatureOverrideStore) Set(ctx context.Context, scope, feature string, enabled bool) error {
+	value := "0"
+	if enabled {
+		value = "1"
+	}
+	if err := s.client.HSet(ctx, s.key(scope), feature, value).Err(); err != nil {
+		return fmt.Errorf("failed to set feature override: %w", err)
+	}
+	return nil
+}
+
+func (s *redisFeatureOverrideStore) Delete(ctx context.Context, scope, feature string) error {
+	if err := s.client.HDel(ctx, s.key(scope), feature).Err(); err != nil {
+		return fmt.Errorf("failed to delete feature override: %w", err)
+	}
+	return nil
+}
+
+// memoryFeatureOverrideStore keeps overrides in process memory, for a single API
+// instance without Redis. They are lost on restart.
+type memoryFeatureOverrideStore struct {
+	mu        sync.RWMutex
+	overrides map[string]map[string]bool
+}
+
+// NewMemoryFeatureOverrideStore keeps feature overrides in process memory
+func NewMemoryFeatureOverrideStore() FeatureOverrideStore {
+	return &memoryFeatureOverrideStore{overrides: make(map[string]map[string]bool)}
+}
+
+func (s *memoryFeatureOverrideStore) Get(ctx context.Context, scope string) (map[string]bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	overrides := make(map[string]bool, len(s.overrides[scope]))
+	for feature, enabled := range s.overrides[scope] {
+		overrides[feature] = enabled
+	}
+	return overrides, nil
+}
+
+func (s *memoryFeatureOverrideStore) Set(ctx context.Context, scope, feature string, enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.overrides[scope] == nil {
+		s.overrides[scope] = make(map[string]bool)
+	}
+	s.overrides[scope][feature] = enabled
+	return nil
+}
+
+func (s *memoryFeatureOverrideStore) Delete(ctx context.Context, scope, feature string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.overrides[scope], feature)
+	return nil
+}
+
+// FeatureFlag is the state of a feature in a scope, as shown to admins
+type FeatureFlag struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// Default is the state before the overrides of the scope are applied
+	Default bool `json:"default"`
+	// Override is set when the scope overrides the default
+	Override *bool `json:"override,omitempty"`
+}
+
+// FeatureFlagService decides which features are on for a wedding. Each feature
+// starts from the environment's default, which admins can override for all
+// weddings and then for single weddings. A feature that is on may still be
+// turned off by the wedding's own settings, e.g. its guestbook.
+type FeatureFlagService struct {
+	mu          sync.RWMutex
+	defaults    map[string]bool
+	overrides   FeatureOverrideStore
+	weddingRepo repository.WeddingRepository
+	logger      *zap.Logger
+}
+
+// NewFeatureFlagService creates a feature flag service with the defaults of
+// every known feature
+func NewFeatureFlagService(defaults map[string]bool, overrides FeatureOverrideStore, weddingRepo repository.WeddingRepository, logger *zap.Logger) *FeatureFlagService {
+	s := &FeatureFlagService{
+		overrides:   overrides,
+		weddingRepo: weddingRepo,
+		logger:      logger,
+	}
+	s.SetDefaults(defaults)
+	return s
+}
+
+// SetDefaults replaces the defaults of the features, e.g. when the
+// configuration is reloaded
+func (s *FeatureFlagService) SetDefaults(defaults map[string]bool) {
+	copied := make(map[string]bool, len(defaults))
+	for feature, enabled := range defaults {
+		copied[feature] = enabled
+	}
+	s.mu.Lock()
+	s.defaults = copied
+	s.mu.Unlock()
+}
+
+// Enabled reports whether a feature is on for a wedding. Unknown features are off.
+func (s *FeatureFlagService) Enabled(ctx context.Context, weddingID models.ID, feature string) bool {
+	return s.resolve(ctx, weddingID)[feature]
+}
+
+// GetFeatures returns the features of a wedding to one of its members
+func (s *FeatureFlagService) GetFeatures(ctx context.Context, weddingID, userID models.ID) (map[string]bool, error) {
+	wedding, err := s.getWedding(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := wedding.RoleOf(userID); !ok {
+		return nil, ErrUnauthorized
+	}
+	return s.resolve(ctx, weddingID), nil
+}
+
+// GetPublicFeatures returns the features of a published wedding to its guests
+func (s *FeatureFlagService) GetPublicFeatures(ctx context.Context, weddingID models.ID) (map[string]bool, error) {
+	wedding, err := s.getWedding(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+	if !wedding.IsAccessible() {
+		return nil, ErrWeddingNotFound
+	}
+	return s.resolve(ctx, weddingID), nil
+}
+
+// ListFlags lists every feature with the overrides of a wedding, or of all
+// weddings for NilID
+func (s *FeatureFlagService) ListFlags(ctx context.Context, weddingID models.ID) ([]FeatureFlag, error) {
+	base := s.defaultFlags()
+	if weddingID != models.NilID {
+		if _, err := s.getWedding(ctx, weddingID); err != nil {
+			return nil, err
+		}
+		global, err := s.overrides.Get(ctx, featureScope(models.NilID))
+		if err != nil {
+			return nil, err
+		}
+		applyOverrides(base, global)
+	}
+	overrides, err := s.overrides.Get(ctx, featureScope(weddingID))
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make([]FeatureFlag, 0, len(base))
+	for name, enabled := range base {
+		flag := FeatureFlag{Name: name, Enabled: enabled, Default: enabled}
+		if override, ok := overrides[name]; ok {
+			flag.Enabled = override
+			flag.Override = &override
+		}
+		flags = append(flags, flag)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags, nil
+}
+
+// SetOverride turns a feature on or off for a wedding, or for all weddings for NilID
+func (s *FeatureFlagService) SetOverride(ctx context.Context, weddingID models.ID, feature string, enabled bool) error {
+	if err := s.checkOverride(ctx, weddingID, feature); err != nil {
+		return err
+	}
+	if err := s.overrides.Set(ctx, featureScope(weddingID), feature, enabled); err != nil {
+		return err
+	}
+	s.logger.Info("Feature flag overridden",
+		zap.String("feature", feature),
+		zap.String("scope", featureScope(weddingID)),
+		zap.Bool("enabled", enabled),
+	)
+	return nil
+}
+
+// ClearOverride returns a feature of a wedding, or of all weddings for NilID,
+// to the state it inherits
+func (s *FeatureFlagService) ClearOverride(ctx context.Context, weddingID models.ID, feature string) error {
+	if err := s.checkOverride(ctx, weddingID, feature); err != nil {
+		return err
+	}
+	return s.overrides.Delete(ctx, featureScope(weddingID), feature)
+}
+
+func (s *FeatureFlagService) checkOverride(ctx context.Context, weddingID models.ID, feature string) error {
+	if _, ok := s.defaultFlags()[feature]; !ok {
+		return ErrUnknownFeature
+	}
+	if weddingID != models.NilID {
+		if _, err := s.getWedding(ctx, weddingID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve applies the global and then the wedding's overrides to the defaults.
+// When the overrides cannot be read, e.g. because Redis is down, the features
+// fall back to what could be resolved.
+func (s *FeatureFlagService) resolve(ctx context.Context, weddingID models.ID) map[string]bool {
+	features := s.defaultFlags()
+	for _, scope := range []string{featureScope(models.NilID), featureScope(weddingID)} {
+		overrides, err := s.overrides.Get(ctx, scope)
+		if err != nil {
+			s.logger.Warn("Feature overrides unavailable, using defaults", zap.String("scope", scope), zap.Error(err))
+			break
+		}
+		applyOverrides(features, overrides)
+	}
+	return features
+}
+
+func (s *FeatureFlagService) defaultFlags() map[string]bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	features := make(map[string]bool, len(s.defaults))
+	for feature, enabled := range s.defaults {
+		features[feature] = enabled
+	}
+	return features
+}
+
+// applyOverrides overrides known features; overrides of features that are
+// no longer known are ignored
+func applyOverrides(features, overrides map[string]bool) {
+	for feature, enabled := range overrides {
+		if _, ok := features[feature]; ok {
+			features[feature] = enabled
+		}
+	}
+}
+
+func (s *FeatureFlagService) getWedding(ctx context.Context, weddingID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	// The wedding repository reports a missing wedding as nil
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	return wedding, nil
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// staticFeatures turns features on and off for every wedding
+type staticFeatures map[string]bool
+
+func (f staticFeatures) Enabled(ctx context.Context, weddingID models.ID, feature string) bool {
+	return f[feature]
+}
+
+func TestFeatureFlagService(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+	wedding := &models.Wedding{ID: models.NewID(), UserID: ownerID, Status: string(models.WeddingStatusPublished), IsPublic: true}
+	other := &models.Wedding{ID: models.NewID(), UserID: ownerID}
+
+	setup := func() *FeatureFlagService {
+		weddings := new(MockWeddingRepository)
+		weddings.On("GetByID", ctx, wedding.ID).Return(wedding, nil)
+		weddings.On("GetByID", ctx, other.ID).Return(other, nil)
+		return NewFeatureFlagService(map[string]bool{
+			FeatureWishes:      true,
+			FeatureGuestPhotos: false,
+		}, NewMemoryFeatureOverrideStore(), weddings, zaptest.NewLogger(t))
+	}
+
+	t.Run("applies global and then wedding overrides", func(t *testing.T) {
+		service := setup()
+		assert.True(t, service.Enabled(ctx, wedding.ID, FeatureWishes))
+		assert.False(t, service.Enabled(ctx, wedding.ID, FeatureGuestPhotos))
+		assert.False(t, service.Enabled(ctx, wedding.ID, "unknown"))
+
+		require.NoError(t, service.SetOverride(ctx, models.NilID, FeatureGuestPhotos, true))
+		require.NoError(t, service.SetOverride(ctx, wedding.ID, FeatureWishes, false))
+		assert.True(t, service.Enabled(ctx, wedding.ID, FeatureGuestPhotos))
+		assert.False(t, service.Enabled(ctx, wedding.ID, FeatureWishes))
+		assert.True(t, service.Enabled(ctx, other.ID, FeatureWishes))
+
+		require.NoError(t, service.ClearOverride(ctx, wedding.ID, FeatureWishes))
+		assert.True(t, service.Enabled(ctx, wedding.ID, FeatureWishes))
+	})
+
+	t.Run("lists flags with their overrides", func(t *testing.T) {
+		service := setup()
+		require.NoError(t, service.SetOverride(ctx, models.NilID, FeatureGuestPhotos, true))
+		require.NoError(t, service.SetOverride(ctx, wedding.ID, FeatureWishes, false))
+
+		global, err := service.ListFlags(ctx, models.NilID)
+		require.NoError(t, err)
+		on := true
+		assert.Equal(t, []FeatureFlag{
+			{Name: FeatureGuestPhotos, Enabled: true, Default: false, Override: &on},
+			{Name: FeatureWishes, Enabled: true, Default: true},
+		}, global)
+
+		flags, err := service.ListFlags(ctx, wedding.ID)
+		require.NoError(t, err)
+		off := false
+		assert.Equal(t, []FeatureFlag{
+			{Name: FeatureGuestPhotos, Enabled: true, Default: true},
+			{Name: FeatureWishes, Enabled: false, Default: true, Override: &off},
+		}, flags)
+	})
+
+	t.Run("rejects unknown features", func(t *testing.T) {
+		service := setup()
+		assert.ErrorIs(t, service.SetOverride(ctx, models.NilID, "unknown", true), ErrUnknownFeature)
+	})
+
+	t.Run("serves members and the public site", func(t *testing.T) {
+		service := setup()
+
+		features, err := service.GetFeatures(ctx, wedding.ID, ownerID)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{FeatureWishes: true, FeatureGuestPhotos: false}, features)
+
+		_, err = service.GetFeatures(ctx, wedding.ID, models.NewID())
+		assert.ErrorIs(t, err, ErrUnauthorized)
+
+		_, err = service.GetPublicFeatures(ctx, wedding.ID)
+		assert.NoError(t, err)
+		_, err = service.GetPublicFeatures(ctx, other.ID)
+		assert.ErrorIs(t, err, ErrWeddingNotFound)
+	})
+
+	t.Run("reloads defaults", func(t *testing.T) {
+		service := setup()
+		service.SetDefaults(map[string]bool{FeatureWishes: false, FeatureGuestPhotos: false})
+		assert.False(t, service.Enabled(ctx, wedding.ID, FeatureWishes))
+	})
+}
@@ -38,6 +38,7 @@ func NewFileValidator(allowedTypes []string, maxSize int64) FileValidator {
 			"image/jpeg": {0xFF, 0xD8, 0xFF},
 			"image/png":  {0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A},
 			"image/webp": {0x52, 0x49, 0x46, 0x46},
+			"video/webm": {0x1A, 0x45, 0xDF, 0xA3},
 		},
 	}
 }
@@ -99,6 +100,10 @@ func (v *fileValidator) extensionToMimeType(ext string) string {
 		return "image/png"
 	case "webp":
 		return "image/webp"
+	case "mp4":
+		return "video/mp4"
+	case "webm":
+		return "video/webm"
 	default:
 		return ""
 	}
@@ -116,6 +121,11 @@ func (v *fileValidator) isAllowedType(mimeType string) bool {
 
 // validateMagicNumber validates file signature
 func (v *fileValidator) validateMagicNumber(data []byte, expectedMime string) bool {
+	// MP4 files open with a box size followed by the "ftyp" box type
+	if expectedMime == "video/mp4" {
+		return len(data) >= 8 && bytes.Equal(data[4:8], []byte("ftyp"))
+	}
+
 	magic, ok := v.magicNumbers[expectedMime]
 	if !ok {
 		return false
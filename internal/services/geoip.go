@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// ipinfoEndpoint is the IPinfo lookup API
+const ipinfoEndpoint = "https://ipinfo.io"
+
+// GeoLocation is where an IP address is located
+type GeoLocation struct {
+	Country string // ISO 3166-1 alpha-2 code, e.g. "ID"
+	City    string // English name; empty when unknown
+}
+
+// GeoIPProvider resolves IP addresses to locations. Lookup returns nil without
+// an error when the address is unknown to the provider.
+type GeoIPProvider interface {
+	Lookup(ctx context.Context, ip net.IP) (*GeoLocation, error)
+}
+
+// MaxMindGeoIPProvider looks addresses up in a local MaxMind GeoLite2 or GeoIP2
+// database (City or Country edition)
+type MaxMindGeoIPProvider struct {
+	reader *maxminddb.Reader
+}
+
+// NewMaxMindGeoIPProvider opens the .mmdb database at path
+func NewMaxMindGeoIPProvider(path string) (*MaxMindGeoIPProvider, error) {
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open MaxMind database: %w", err)
+	}
+	return &MaxMindGeoIPProvider{reader: reader}, nil
+}
+
+type maxMindRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+}
+
+// Lookup resolves an address from the local database
+func (p *MaxMindGeoIPProvider) Lookup(ctx context.Context, ip net.IP) (*GeoLocation, error) {
+	var record maxMindRecord
+	if err := p.reader.Lookup(ip, &record); err != nil {
+		return nil, fmt.Errorf("failed to look up %s: %w", ip, err)
+	}
+	if record.Country.ISOCode == "" {
+		return nil, nil
+	}
+	return &GeoLocation{Country: record.Country.ISOCode, City: record.City.Names["en"]}, nil
+}
+
+// Close releases the database
+func (p *MaxMindGeoIPProvider) Close() error {
+	return p.reader.Close()
+}
+
+// IPinfoGeoIPProvider looks addresses up with the IPinfo API
+type IPinfoGeoIPProvider struct {
+	token      string
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewIPinfoGeoIPProvider creates a provider authenticating with the given API token
+func NewIPinfoGeoIPProvider(token string) *IPinfoGeoIPProvider {
+	return &IPinfoGeoIPProvider{
+		token:    token,
+		endpoint: ipinfoEndpoint,
+		// Lookups run while tracking page views, so keep them short
+		httpClient: &http.Client{Timeout: 2 * time.Second},
+	}
+}
+
+type ipinfoResponse struct {
+	Country string `json:"country"`
+	City    string `json:"city"`
+	Bogon   bool   `json:"bogon"`
+}
+
+// Lookup resolves an address through the API
+func (p *IPinfoGeoIPProvider) Lookup(ctx context.Context, ip net.IP) (*GeoLocation, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"/"+ip.String()+"/json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build IPinfo request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call IPinfo: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("IPinfo returned status %d", resp.StatusCode)
+	}
+
+	var body ipinfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode IPinfo response: %w", err)
+	}
+	if body.Bogon || body.Country == "" {
+		return nil, nil
+	}
+	return &GeoLocation{Country: body.Country, City: body.City}, nil
+}
+
+// ChainGeoIPProvider asks its providers in order until one knows the address,
+// e.g. a local database first and an API as fallback
+type ChainGeoIPProvider struct {
+	providers []GeoIPProvider
+}
+
+// NewChainGeoIPProvider creates a provider falling back through the given ones
+func NewChainGeoIPProvider(providers ...GeoIPProvider) *ChainGeoIPProvider {
+	return &ChainGeoIPProvider{providers: providers}
+}
+
+// Lookup returns the first location found. Errors only surface when no
+// provider found the address.
+func (p *ChainGeoIPProvider) Lookup(ctx context.Context, ip net.IP) (*GeoLocation, error) {
+	var lastErr error
+	for _, provider := range p.providers {
+		location, err := provider.Lookup(ctx, ip)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if location != nil {
+			return location, nil
+		}
+	}
+	return nil, lastErr
+}
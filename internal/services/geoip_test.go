@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGeoIPProvider locates the addresses it was given
+type fakeGeoIPProvider struct {
+	locations map[string]*GeoLocation
+	err       error
+	lookups   []string
+}
+
+func (f *fakeGeoIPProvider) Lookup(ctx context.Context, ip net.IP) (*GeoLocation, error) {
+	f.lookups = append(f.lookups, ip.String())
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.locations[ip.String()], nil
+}
+
+func TestIPinfoGeoIPProvider_Lookup(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/203.0.113.7/json":
+			w.Write([]byte(`{"ip":"203.0.113.7","city":"Surabaya","country":"ID"}`))
+		case "/10.0.0.1/json":
+			w.Write([]byte(`{"ip":"10.0.0.1","bogon":true}`))
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewIPinfoGeoIPProvider("secret")
+	provider.endpoint = server.URL
+
+	t.Run("Located", func(t *testing.T) {
+		location, err := provider.Lookup(ctx, net.ParseIP("203.0.113.7"))
+		require.NoError(t, err)
+		assert.Equal(t, &GeoLocation{Country: "ID", City: "Surabaya"}, location)
+	})
+
+	t.Run("Reserved address", func(t *testing.T) {
+		location, err := provider.Lookup(ctx, net.ParseIP("10.0.0.1"))
+		require.NoError(t, err)
+		assert.Nil(t, location)
+	})
+
+	t.Run("Error status", func(t *testing.T) {
+		_, err := provider.Lookup(ctx, net.ParseIP("198.51.100.1"))
+		assert.Error(t, err)
+	})
+}
+
+func TestChainGeoIPProvider_Lookup(t *testing.T) {
+	ctx := context.Background()
+	ip := net.ParseIP("203.0.113.7")
+
+	t.Run("Falls back to the next provider", func(t *testing.T) {
+		local := &fakeGeoIPProvider{}
+		remote := &fakeGeoIPProvider{locations: map[string]*GeoLocation{"203.0.113.7": {Country: "SG"}}}
+
+		location, err := NewChainGeoIPProvider(local, remote).Lookup(ctx, ip)
+		require.NoError(t, err)
+		assert.Equal(t, "SG", location.Country)
+		assert.Len(t, local.lookups, 1)
+	})
+
+	t.Run("Stops at the first location", func(t *testing.T) {
+		local := &fakeGeoIPProvider{locations: map[string]*GeoLocation{"203.0.113.7": {Country: "ID"}}}
+		remote := &fakeGeoIPProvider{}
+
+		location, err := NewChainGeoIPProvider(local, remote).Lookup(ctx, ip)
+		require.NoError(t, err)
+		assert.Equal(t, "ID", location.Country)
+		assert.Empty(t, remote.lookups)
+	})
+
+	t.Run("Errors only when nothing located", func(t *testing.T) {
+		failing := &fakeGeoIPProvider{err: errors.New("quota exceeded")}
+		remote := &fakeGeoIPProvider{locations: map[string]*GeoLocation{"203.0.113.7": {Country: "ID"}}}
+
+		location, err := NewChainGeoIPProvider(failing, remote).Lookup(ctx, ip)
+		require.NoError(t, err)
+		assert.Equal(t, "ID", location.Country)
+
+		_, err = NewChainGeoIPProvider(failing, &fakeGeoIPProvider{}).Lookup(ctx, ip)
+		assert.EqualError(t, err, "quota exceeded")
+	})
+}
@@ -0,0 +1,333 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+const (
+	googlePeopleConnectionsEndpoint = "https://people.googleapis.com/v1/people/me/connections"
+	// googleContactsScope is the scope the consent screen must request
+	googleContactsScope = "https://www.googleapis.com/auth/contacts.readonly"
+	// googleContactsPageSize is the most connections the People API returns per page
+	googleContactsPageSize = 1000
+	// maxGoogleContacts bounds the contacts read from one account
+	maxGoogleContacts = 5000
+	// defaultGoogleContactsTTL is how long a connection is kept when Google does not say
+	defaultGoogleContactsTTL = time.Hour
+)
+
+// GoogleContactsToken is an access token to a Google account's contacts
+type GoogleContactsToken struct {
+	AccessToken string
+	ExpiresIn   time.Duration
+}
+
+// GoogleContactsClient reads the contacts of a Google account through the People API
+type GoogleContactsClient interface {
+	// Exchange redeems an authorization code granted the contacts.readonly scope;
+	// redirectURI must be the one the code was requested with
+	Exchange(ctx context.Context, code, redirectURI string) (*GoogleContactsToken, error)
+	// ListContacts returns the named contacts of the account, and
+	// ErrGoogleContactsNotConnected when the token is no longer accepted
+	ListContacts(ctx context.Context, accessToken string) ([]*models.GuestContact, error)
+}
+
+// GooglePeopleClient reads Google contacts with the app's OAuth client
+type GooglePeopleClient struct {
+	clientID            string
+	clientSecret        string
+	tokenEndpoint       string
+	connectionsEndpoint string
+	httpClient          *http.Client
+}
+
+// NewGooglePeopleClient creates a People API client for the OAuth client
+func NewGooglePeopleClient(clientID, clientSecret string) *GooglePeopleClient {
+	return &GooglePeopleClient{
+		clientID:            clientID,
+		clientSecret:        clientSecret,
+		tokenEndpoint:       googleTokenEndpoint,
+		connectionsEndpoint: googlePeopleConnectionsEndpoint,
+		httpClient:          oauthHTTPClient,
+	}
+}
+
+// Exchange redeems the code and checks the user granted access to their contacts
+func (c *GooglePeopleClient) Exchange(ctx context.Context, code, redirectURI string) (*GoogleContactsToken, error) {
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+		Scope       string `json:"scope"`
+	}
+	if err := postOAuthForm(ctx, c.httpClient, c.tokenEndpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {c.clientID},
+		"client_secret": {c.clientSecret},
+	}, &token); err != nil {
+		return nil, err
+	}
+	// Users may untick the contacts permission on the consent screen
+	if token.Scope != "" && !containsScope(token.Scope, googleContactsScope) {
+		return nil, fmt.Errorf("%w: access to contacts was not granted", ErrOAuthExchangeFailed)
+	}
+
+	ttl := time.Duration(token.ExpiresIn) * time.Second
+	if ttl <= 0 {
+		ttl = defaultGoogleContactsTTL
+	}
+	return &GoogleContactsToken{AccessToken: token.AccessToken, ExpiresIn: ttl}, nil
+}
+
+func containsScope(scopes, scope string) bool {
+	for _, s := range strings.Fields(scopes) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type googlePerson struct {
+	ResourceName string `json:"resourceName"`
+	Names        []struct {
+		googleFieldMetadata
+		DisplayName string `json:"displayName"`
+		GivenName   string `json:"givenName"`
+		FamilyName  string `json:"familyName"`
+	} `json:"names"`
+	EmailAddresses []struct {
+		googleFieldMetadata
+		Value string `json:"value"`
+	} `json:"emailAddresses"`
+	PhoneNumbers []struct {
+		googleFieldMetadata
+		Value         string `json:"value"`
+		CanonicalForm string `json:"canonicalForm"`
+	} `json:"phoneNumbers"`
+}
+
+type googleFieldMetadata struct {
+	Metadata struct {
+		Primary bool `json:"primary"`
+	} `json:"metadata"`
+}
+
+// primaryIndex returns the index of the primary value of a field, or 0
+func primaryIndex(n int, primary func(int) bool) int {
+	for i := 0; i < n; i++ {
+		if primary(i) {
+			return i
+		}
+	}
+	return 0
+}
+
+// ListContacts pages through the account's connections. Contacts without a
+// name cannot be guests and are left out.
+func (c *GooglePeopleClient) ListContacts(ctx context.Context, accessToken string) ([]*models.GuestContact, error) {
+	var contacts []*models.GuestContact
+	pageToken := ""
+	for {
+		query := url.Values{
+			"personFields": {"names,emailAddresses,phoneNumbers"},
+			"pageSize":     {fmt.Sprint(googleContactsPageSize)},
+			"sortOrder":    {"FIRST_NAME_ASCENDING"},
+		}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		var page struct {
+			Connections   []googlePerson `json:"connections"`
+			NextPageToken string         `json:"nextPageToken"`
+		}
+		if err := c.get(ctx, c.connectionsEndpoint+"?"+query.Encode(), accessToken, &page); err != nil {
+			return nil, err
+		}
+
+		for _, person := range page.Connections {
+			if contact := googlePersonContact(person); contact != nil {
+				contacts = append(contacts, contact)
+			}
+		}
+		if page.NextPageToken == "" || len(contacts) >= maxGoogleContacts {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+
+	if len(contacts) > maxGoogleContacts {
+		contacts = contacts[:maxGoogleContacts]
+	}
+	return contacts, nil
+}
+
+func (c *GooglePeopleClient) get(ctx context.Context, endpoint, accessToken string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build People API request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call the People API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return ErrGoogleContactsNotConnected
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("People API returned status %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 16<<20)).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode People API response: %w", err)
+	}
+	return nil
+}
+
+func googlePersonContact(person googlePerson) *models.GuestContact {
+	if len(person.Names) == 0 {
+		return nil
+	}
+	contact := &models.GuestContact{ResourceName: person.ResourceName}
+
+	name := person.Names[primaryIndex(len(person.Names), func(i int) bool { return person.Names[i].Metadata.Primary })]
+	contact.FirstName, contact.LastName = strings.TrimSpace(name.GivenName), strings.TrimSpace(name.FamilyName)
+	if contact.FirstName == "" && contact.LastName == "" {
+		contact.FirstName, contact.LastName = splitFullName(name.DisplayName)
+	}
+	if contact.FirstName == "" && contact.LastName == "" {
+		return nil
+	}
+
+	if n := len(person.EmailAddresses); n > 0 {
+		contact.Email = person.EmailAddresses[primaryIndex(n, func(i int) bool { return person.EmailAddresses[i].Metadata.Primary })].Value
+	}
+	if n := len(person.PhoneNumbers); n > 0 {
+		phone := person.PhoneNumbers[primaryIndex(n, func(i int) bool { return person.PhoneNumbers[i].Metadata.Primary })]
+		contact.Phone = phone.CanonicalForm
+		if contact.Phone == "" {
+			contact.Phone = phone.Value
+		}
+	}
+	return contact
+}
+
+// GoogleContactsTokenStore keeps the access token of each user's Google
+// Contacts connection until it expires
+type GoogleContactsTokenStore interface {
+	Save(ctx context.Context, userID models.ID, accessToken string, ttl time.Duration) error
+	// Get returns ErrGoogleContactsNotConnected without a live connection
+	Get(ctx context.Context, userID models.ID) (string, error)
+	Delete(ctx context.Context, userID models.ID) error
+}
+
+// redisGoogleContactsTokenStore keeps connections in Redis, so that any API
+// instance can serve the import
+type redisGoogleContactsTokenStore struct {
+	client *redis.Client
+}
+
+// NewRedisGoogleContactsTokenStore keeps Google Contacts connections in Redis
+func NewRedisGoogleContactsTokenStore(client *redis.Client) GoogleContactsTokenStore {
+	return &redisGoogleContactsTokenStore{client: client}
+}
+
+func (s *redisGoogleContactsTokenStore) key(userID models.ID) string {
+	return "contacts:google:" + userID.String()
+}
+
+func (s *redisGoogleContactsTokenStore) Save(ctx context.Context, userID models.ID, accessToken string, ttl time.Duration) error {
+	if err := s.client.Set(ctx, s.key(userID), accessToken, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to store Google Contacts connection: %w", err)
+	}
+	return nil
+}
+
+func (s *redisGoogleContactsTokenStore) Get(ctx context.Context, userID models.ID) (string, error) {
+	token, err := s.client.Get(ctx, s.key(userID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrGoogleContactsNotConnected
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get Google Contacts connection: %w", err)
+	}
+	return token, nil
+}
+
+func (s *redisGoogleContactsTokenStore) Delete(ctx context.Context, userID models.ID) error {
+	if err := s.client.Del(ctx, s.key(userID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete Google Contacts connection: %w", err)
+	}
+	return nil
+}
+
+// memoryGoogleContactsTokenStore keeps connections in process memory, for a
+// single API instance without Redis
+type memoryGoogleContactsTokenStore struct {
+	mu     sync.Mutex
+	tokens map[models.ID]memoryGoogleContactsToken
+	now    func() time.Time
+}
+
+type memoryGoogleContactsToken struct {
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewMemoryGoogleContactsTokenStore keeps Google Contacts connections in process memory
+func NewMemoryGoogleContactsTokenStore() GoogleContactsTokenStore {
+	return &memoryGoogleContactsTokenStore{tokens: make(map[models.ID]memoryGoogleContactsToken), now: time.Now}
+}
+
+func (s *memoryGoogleContactsTokenStore) Save(ctx context.Context, userID models.ID, accessToken string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	// Expired connections are dropped as new ones are made
+	for id, token := range s.tokens {
+		if !now.Before(token.expiresAt) {
+			delete(s.tokens, id)
+		}
+	}
+	s.tokens[userID] = memoryGoogleContactsToken{accessToken: accessToken, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (s *memoryGoogleContactsTokenStore) Get(ctx context.Context, userID models.ID) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.tokens[userID]
+	if !ok || !s.now().Before(token.expiresAt) {
+		delete(s.tokens, userID)
+		return "", ErrGoogleContactsNotConnected
+	}
+	return token.accessToken, nil
+}
+
+func (s *memoryGoogleContactsTokenStore) Delete(ctx context.Context, userID models.ID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, userID)
+	return nil
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func TestGooglePeopleClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			require.NoError(t, r.ParseForm())
+			scope := googleContactsScope
+			if r.PostForm.Get("code") == "no-contacts" {
+				scope = "openid email"
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "people-access", "expires_in": 1800, "scope": scope})
+		case "/connections":
+			if r.Header.Get("Authorization") != "Bearer people-access" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			assert.Equal(t, "names,emailAddresses,phoneNumbers", r.URL.Query().Get("personFields"))
+			if r.URL.Query().Get("pageToken") == "" {
+				w.Write([]byte(`{"connections":[
+					{"resourceName":"people/c1","names":[{"givenName":"Old","familyName":"Name"},{"metadata":{"primary":true},"givenName":"Dana","familyName":"Lee"}],
+					 "emailAddresses":[{"value":"dana@work.example.com"},{"metadata":{"primary":true},"value":"dana@example.com"}],
+					 "phoneNumbers":[{"value":"(555) 010-2030","canonicalForm":"+15550102030"}]},
+					{"resourceName":"people/c2","emailAddresses":[{"value":"noname@example.com"}]}
+				],"nextPageToken":"page-2"}`))
+				return
+			}
+			assert.Equal(t, "page-2", r.URL.Query().Get("pageToken"))
+			w.Write([]byte(`{"connections":[{"resourceName":"people/c3","names":[{"displayName":"Eli Park"}]}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewGooglePeopleClient("client", "secret")
+	client.tokenEndpoint = server.URL + "/token"
+	client.connectionsEndpoint = server.URL + "/connections"
+	ctx := context.Background()
+
+	token, err := client.Exchange(ctx, "good-code", "https://app.example.com/callback")
+	require.NoError(t, err)
+	assert.Equal(t, &GoogleContactsToken{AccessToken: "people-access", ExpiresIn: 30 * time.Minute}, token)
+
+	_, err = client.Exchange(ctx, "no-contacts", "https://app.example.com/callback")
+	assert.ErrorIs(t, err, ErrOAuthExchangeFailed)
+
+	contacts, err := client.ListContacts(ctx, token.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.GuestContact{
+		{ResourceName: "people/c1", FirstName: "Dana", LastName: "Lee", Email: "dana@example.com", Phone: "+15550102030"},
+		{ResourceName: "people/c3", FirstName: "Eli", LastName: "Park"},
+	}, contacts)
+
+	_, err = client.ListContacts(ctx, "revoked")
+	assert.ErrorIs(t, err, ErrGoogleContactsNotConnected)
+}
+
+func TestMemoryGoogleContactsTokenStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemoryGoogleContactsTokenStore().(*memoryGoogleContactsTokenStore)
+	store.now = func() time.Time { return now }
+	userID := models.NewID()
+
+	require.NoError(t, store.Save(ctx, userID, "token", time.Minute))
+	token, err := store.Get(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+
+	now = now.Add(time.Minute)
+	_, err = store.Get(ctx, userID)
+	assert.ErrorIs(t, err, ErrGoogleContactsNotConnected)
+}
@@ -8,27 +8,54 @@ import (
 	"io"
 	"strings"
 	"time"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"wedding-invitation-backend/internal/domain/errs"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
 )
 
 // GuestServiceInterface defines the contract for guest service operations
 type GuestServiceInterface interface {
-	CreateGuest(ctx context.Context, weddingID, userID primitive.ObjectID, guest *models.Guest) error
-	GetGuestByID(ctx context.Context, guestID, userID primitive.ObjectID) (*models.Guest, error)
-	ListGuests(ctx context.Context, weddingID, userID primitive.ObjectID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error)
-	UpdateGuest(ctx context.Context, guestID, userID primitive.ObjectID, guest *models.Guest) error
-	DeleteGuest(ctx context.Context, guestID, userID primitive.ObjectID) error
-	CreateManyGuests(ctx context.Context, weddingID, userID primitive.ObjectID, guests []*models.Guest) error
-	ImportGuestsFromCSV(ctx context.Context, weddingID, userID primitive.ObjectID, csvData io.Reader) (*models.GuestImportResult, error)
+	CreateGuest(ctx context.Context, weddingID, userID models.ID, guest *models.Guest) error
+	GetGuestByID(ctx context.Context, guestID, userID models.ID) (*models.Guest, error)
+	ListGuests(ctx context.Context, weddingID, userID models.ID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error)
+	SearchGuests(ctx context.Context, weddingID, userID models.ID, query string, limit int) ([]*models.Guest, error)
+	UpdateGuest(ctx context.Context, guestID, userID models.ID, guest *models.Guest) error
+	DeleteGuest(ctx context.Context, guestID, userID models.ID) error
+	CreateManyGuests(ctx context.Context, weddingID, userID models.ID, guests []*models.Guest) error
+	ImportGuestsFromCSV(ctx context.Context, weddingID, userID models.ID, csvData io.Reader) (*models.GuestImportResult, error)
+	StreamGuests(ctx context.Context, weddingID, userID models.ID, filters repository.GuestFilters, fn func(*models.Guest) error) error
+	BulkUpdateGuests(ctx context.Context, weddingID, userID models.ID, selection GuestSelection, patch models.GuestPatch, atomic bool) (*models.GuestBulkResult, error)
+	BulkDeleteGuests(ctx context.Context, weddingID, userID models.ID, selection GuestSelection, atomic bool) (*models.GuestBulkResult, error)
+}
+
+// Limits of guest tags
+const (
+	maxGuestTags      = 20
+	maxGuestTagLength = 30
+)
+
+// ErrEmptyGuestSelection is returned for bulk operations that select no guests
+var ErrEmptyGuestSelection = errors.New("select guests by ID or by import batch")
+
+// ErrDuplicateGuestEmail is returned when another guest of the wedding has the email
+var ErrDuplicateGuestEmail = errs.Conflict("guest with this email already exists for this wedding")
+
+// GuestSelection picks the guests of a wedding a bulk operation applies to:
+// those with the given IDs, or every guest of an import batch
+type GuestSelection struct {
+	IDs           []models.ID
+	ImportBatchID string
 }
 
 // GuestService handles guest-related business logic
 type GuestService struct {
 	guestRepo   repository.GuestRepository
 	weddingRepo repository.WeddingRepository
+	limits      PlanLimiter
+	audit       AuditRecorder
+
+	googleContacts GoogleContactsClient
+	googleTokens   GoogleContactsTokenStore
 }
 
 // NewGuestService creates a new guest service
@@ -39,16 +66,26 @@ func NewGuestService(guestRepo repository.GuestRepository, weddingRepo repositor
 	}
 }
 
+// SetPlanLimiter enforces the guest limit of the wedding owner's plan
+func (s *GuestService) SetPlanLimiter(limits PlanLimiter) {
+	s.limits = limits
+}
+
+// SetAuditLog records the creation, changes, imports and deletion of guests in the audit log
+func (s *GuestService) SetAuditLog(audit AuditRecorder) {
+	s.audit = audit
+}
+
 // CreateGuest creates a new guest
-func (s *GuestService) CreateGuest(ctx context.Context, weddingID, userID primitive.ObjectID, guest *models.Guest) error {
-	// Verify wedding exists and user owns it
+func (s *GuestService) CreateGuest(ctx context.Context, weddingID, userID models.ID, guest *models.Guest) error {
+	// Verify wedding exists and user may manage its guests
 	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
 	if err != nil {
 		return fmt.Errorf("wedding not found: %w", err)
 	}
 
-	if wedding.UserID != userID {
-		return errors.New("unauthorized: you don't own this wedding")
+	if !wedding.Can(userID, models.PermissionManageGuests) {
+		return fmt.Errorf("%w: you can't manage guests of this wedding", ErrUnauthorized)
 	}
 
 	// Set wedding ID
@@ -57,22 +94,30 @@ func (s *GuestService) CreateGuest(ctx context.Context, weddingID, userID primit
 
 	// Validate guest data
 	if err := s.validateGuest(guest); err != nil {
-		return fmt.Errorf("invalid guest data: %w", err)
+		return fmt.Errorf("invalid guest data: %w", errs.Validation(err.Error()))
 	}
 
 	// Check for duplicate email within the same wedding
 	if guest.Email != "" {
 		existingGuest, err := s.guestRepo.GetByEmail(ctx, weddingID, guest.Email)
 		if err == nil && existingGuest != nil {
-			return errors.New("guest with this email already exists for this wedding")
+			return ErrDuplicateGuestEmail
 		}
 	}
 
-	return s.guestRepo.Create(ctx, guest)
+	if err := s.checkGuestLimit(ctx, wedding, 1); err != nil {
+		return err
+	}
+
+	if err := s.guestRepo.Create(ctx, guest); err != nil {
+		return err
+	}
+	s.recordAudit(ctx, models.AuditGuestCreate, weddingID, guest.ID.String(), auditChanges(nil, auditFields(guest)), nil)
+	return nil
 }
 
 // GetGuestByID retrieves a guest by ID
-func (s *GuestService) GetGuestByID(ctx context.Context, guestID, userID primitive.ObjectID) (*models.Guest, error) {
+func (s *GuestService) GetGuestByID(ctx context.Context, guestID, userID models.ID) (*models.Guest, error) {
 	guest, err := s.guestRepo.GetByID(ctx, guestID)
 	if err != nil {
 		return nil, err
@@ -87,7 +132,7 @@ func (s *GuestService) GetGuestByID(ctx context.Context, guestID, userID primiti
 }
 
 // ListGuests retrieves guests for a wedding with pagination and filtering
-func (s *GuestService) ListGuests(ctx context.Context, weddingID, userID primitive.ObjectID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error) {
+func (s *GuestService) ListGuests(ctx context.Context, weddingID, userID models.ID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error) {
 	// Verify user owns the wedding
 	if err := s.verifyWeddingOwnership(ctx, weddingID, userID); err != nil {
 		return nil, 0, err
@@ -96,8 +141,18 @@ func (s *GuestService) ListGuests(ctx context.Context, weddingID, userID primiti
 	return s.guestRepo.ListByWedding(ctx, weddingID, page, pageSize, filters)
 }
 
+// StreamGuests calls fn for every guest of the wedding matching filters without loading them all into memory
+func (s *GuestService) StreamGuests(ctx context.Context, weddingID, userID models.ID, filters repository.GuestFilters, fn func(*models.Guest) error) error {
+	// Verify user owns the wedding
+	if err := s.verifyWeddingOwnership(ctx, weddingID, userID); err != nil {
+		return err
+	}
+
+	return s.guestRepo.StreamByWedding(ctx, weddingID, filters, fn)
+}
+
 // UpdateGuest updates an existing guest
-func (s *GuestService) UpdateGuest(ctx context.Context, guestID, userID primitive.ObjectID, guest *models.Guest) error {
+func (s *GuestService) UpdateGuest(ctx context.Context, guestID, userID models.ID, guest *models.Guest) error {
 	// Get existing guest
 	existingGuest, err := s.guestRepo.GetByID(ctx, guestID)
 	if err != nil {
@@ -114,25 +169,30 @@ func (s *GuestService) UpdateGuest(ctx context.Context, guestID, userID primitiv
 	guest.WeddingID = existingGuest.WeddingID
 	guest.CreatedAt = existingGuest.CreatedAt
 	guest.CreatedBy = existingGuest.CreatedBy
+	guest.CheckIn = existingGuest.CheckIn
 
 	// Validate guest data
 	if err := s.validateGuest(guest); err != nil {
-		return fmt.Errorf("invalid guest data: %w", err)
+		return fmt.Errorf("invalid guest data: %w", errs.Validation(err.Error()))
 	}
 
 	// Check for duplicate email (if email changed)
 	if guest.Email != "" && guest.Email != existingGuest.Email {
 		existingEmailGuest, err := s.guestRepo.GetByEmail(ctx, existingGuest.WeddingID, guest.Email)
 		if err == nil && existingEmailGuest != nil && existingEmailGuest.ID != guestID {
-			return errors.New("guest with this email already exists for this wedding")
+			return ErrDuplicateGuestEmail
 		}
 	}
 
-	return s.guestRepo.Update(ctx, guest)
+	if err := s.guestRepo.Update(ctx, guest); err != nil {
+		return err
+	}
+	s.recordAudit(ctx, models.AuditGuestUpdate, guest.WeddingID, guestID.String(), auditChanges(auditFields(existingGuest), auditFields(guest)), nil)
+	return nil
 }
 
 // DeleteGuest deletes a guest
-func (s *GuestService) DeleteGuest(ctx context.Context, guestID, userID primitive.ObjectID) error {
+func (s *GuestService) DeleteGuest(ctx context.Context, guestID, userID models.ID) error {
 	// Get existing guest
 	guest, err := s.guestRepo.GetByID(ctx, guestID)
 	if err != nil {
@@ -144,18 +204,23 @@ func (s *GuestService) DeleteGuest(ctx context.Context, guestID, userID primitiv
 		return err
 	}
 
-	return s.guestRepo.Delete(ctx, guestID)
+	if err := s.guestRepo.Delete(ctx, guestID); err != nil {
+		return err
+	}
+	s.recordAudit(ctx, models.AuditGuestDelete, guest.WeddingID, guestID.String(), auditChanges(auditFields(guest), nil), nil)
+	return nil
 }
 
 // ImportGuestsFromCSV imports guests from a CSV file
-func (s *GuestService) ImportGuestsFromCSV(ctx context.Context, weddingID, userID primitive.ObjectID, csvData io.Reader) (*models.GuestImportResult, error) {
+func (s *GuestService) ImportGuestsFromCSV(ctx context.Context, weddingID, userID models.ID, csvData io.Reader) (*models.GuestImportResult, error) {
 	// Verify user owns the wedding
-	if err := s.verifyWeddingOwnership(ctx, weddingID, userID); err != nil {
+	wedding, err := s.getManagedWedding(ctx, weddingID, userID)
+	if err != nil {
 		return nil, err
 	}
 
 	// Generate batch ID
-	batchID := fmt.Sprintf("%s_%d", userID.Hex(), time.Now().Unix())
+	batchID := newImportBatchID(userID)
 
 	// Parse CSV
 	records, err := csv.NewReader(csvData).ReadAll()
@@ -194,9 +259,16 @@ func (s *GuestService) ImportGuestsFromCSV(ctx context.Context, weddingID, userI
 
 	// Import valid guests
 	if len(guests) > 0 {
+		if err := s.checkGuestLimit(ctx, wedding, len(guests)); err != nil {
+			return nil, err
+		}
 		if err := s.guestRepo.ImportBatch(ctx, guests, batchID); err != nil {
 			return nil, fmt.Errorf("failed to import guests: %w", err)
 		}
+		s.recordAudit(ctx, models.AuditGuestImport, weddingID, batchID, nil, map[string]interface{}{
+			"source": "csv",
+			"count":  len(guests),
+		})
 	}
 
 	result := &models.GuestImportResult{
@@ -210,7 +282,7 @@ func (s *GuestService) ImportGuestsFromCSV(ctx context.Context, weddingID, userI
 }
 
 // GetImportBatch retrieves guests from a specific import batch
-func (s *GuestService) GetImportBatch(ctx context.Context, weddingID, userID primitive.ObjectID, batchID string) ([]*models.Guest, error) {
+func (s *GuestService) GetImportBatch(ctx context.Context, weddingID, userID models.ID, batchID string) ([]*models.Guest, error) {
 	// Verify user owns the wedding
 	if err := s.verifyWeddingOwnership(ctx, weddingID, userID); err != nil {
 		return nil, err
@@ -220,9 +292,10 @@ func (s *GuestService) GetImportBatch(ctx context.Context, weddingID, userID pri
 }
 
 // CreateManyGuests creates multiple guests at once
-func (s *GuestService) CreateManyGuests(ctx context.Context, weddingID, userID primitive.ObjectID, guests []*models.Guest) error {
+func (s *GuestService) CreateManyGuests(ctx context.Context, weddingID, userID models.ID, guests []*models.Guest) error {
 	// Verify user owns the wedding
-	if err := s.verifyWeddingOwnership(ctx, weddingID, userID); err != nil {
+	wedding, err := s.getManagedWedding(ctx, weddingID, userID)
+	if err != nil {
 		return err
 	}
 
@@ -233,25 +306,181 @@ func (s *GuestService) CreateManyGuests(ctx context.Context, weddingID, userID p
 
 		// Validate guest
 		if err := s.validateGuest(guest); err != nil {
-			return fmt.Errorf("invalid guest data: %w", err)
+			return fmt.Errorf("invalid guest data: %w", errs.Validation(err.Error()))
 		}
 	}
 
-	return s.guestRepo.CreateMany(ctx, guests)
+	if err := s.checkGuestLimit(ctx, wedding, len(guests)); err != nil {
+		return err
+	}
+
+	if err := s.guestRepo.CreateMany(ctx, guests); err != nil {
+		return err
+	}
+	s.recordAudit(ctx, models.AuditGuestImport, weddingID, "", nil, map[string]interface{}{
+		"source": "bulk",
+		"count":  len(guests),
+	})
+	return nil
 }
 
-// verifyWeddingOwnership verifies that the user owns the wedding
-func (s *GuestService) verifyWeddingOwnership(ctx context.Context, weddingID, userID primitive.ObjectID) error {
+// BulkUpdateGuests applies patch to the selected guests of a wedding in one write.
+// Selected guests that do not exist or would become invalid are reported as failed
+// and left unchanged; with atomic, any failure leaves every guest unchanged.
+func (s *GuestService) BulkUpdateGuests(ctx context.Context, weddingID, userID models.ID, selection GuestSelection, patch models.GuestPatch, atomic bool) (*models.GuestBulkResult, error) {
+	if patch.InvitationStatus != nil && *patch.InvitationStatus == models.InvitationStatusSent && patch.InvitationSentAt == nil {
+		now := time.Now()
+		patch.InvitationSentAt = &now
+	}
+
+	return s.bulkApply(ctx, weddingID, userID, selection, atomic, bulkOperation{
+		check: func(guest *models.Guest) error {
+			patched := *guest
+			patch.Apply(&patched)
+			return s.validateGuest(&patched)
+		},
+		apply: func(ids []models.ID) (int64, error) {
+			return s.guestRepo.UpdateMany(ctx, weddingID, ids, patch)
+		},
+		audit: func(guest *models.Guest) {
+			patched := *guest
+			patch.Apply(&patched)
+			s.recordAudit(ctx, models.AuditGuestUpdate, weddingID, guest.ID.String(), auditChanges(auditFields(guest), auditFields(&patched)), map[string]interface{}{"source": "bulk"})
+		},
+	})
+}
+
+// BulkDeleteGuests deletes the selected guests of a wedding in one write. Selected
+// guests that do not exist are reported as failed; with atomic, they leave every
+// guest in place.
+func (s *GuestService) BulkDeleteGuests(ctx context.Context, weddingID, userID models.ID, selection GuestSelection, atomic bool) (*models.GuestBulkResult, error) {
+	return s.bulkApply(ctx, weddingID, userID, selection, atomic, bulkOperation{
+		apply: func(ids []models.ID) (int64, error) {
+			return s.guestRepo.DeleteMany(ctx, weddingID, ids)
+		},
+		audit: func(guest *models.Guest) {
+			s.recordAudit(ctx, models.AuditGuestDelete, weddingID, guest.ID.String(), auditChanges(auditFields(guest), nil), map[string]interface{}{"source": "bulk"})
+		},
+	})
+}
+
+// bulkOperation is what a bulk operation does to the selected guests
+type bulkOperation struct {
+	check func(*models.Guest) error        // Rejects a guest the operation cannot apply to; optional
+	apply func([]models.ID) (int64, error) // Applies the operation to the guests in one write
+	audit func(*models.Guest)              // Records the change of a guest, given as it was before
+}
+
+// bulkApply runs a bulk operation on the selected guests of a wedding, reporting
+// the guests that could not be selected or were rejected by the operation
+func (s *GuestService) bulkApply(ctx context.Context, weddingID, userID models.ID, selection GuestSelection, atomic bool, op bulkOperation) (*models.GuestBulkResult, error) {
+	if len(selection.IDs) == 0 && selection.ImportBatchID == "" {
+		return nil, ErrEmptyGuestSelection
+	}
+	if err := s.verifyWeddingOwnership(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	filters := repository.GuestFilters{IDs: selection.IDs, ImportBatchID: selection.ImportBatchID}
+	guests, _, err := s.guestRepo.ListByWedding(ctx, weddingID, 0, 0, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select guests: %w", err)
+	}
+
+	result := &models.GuestBulkResult{Failed: []models.GuestBulkFailure{}}
+	found := make(map[models.ID]bool, len(guests))
+	for _, guest := range guests {
+		found[guest.ID] = true
+	}
+	// Guests selected by ID are reported in the order they were given, once each
+	requested := make(map[models.ID]bool, len(selection.IDs))
+	for _, id := range selection.IDs {
+		if requested[id] {
+			continue
+		}
+		requested[id] = true
+		if !found[id] {
+			result.Failed = append(result.Failed, models.GuestBulkFailure{GuestID: id.String(), Error: "guest not found"})
+		}
+	}
+	result.Requested = len(requested)
+	if len(selection.IDs) == 0 {
+		result.Requested = len(guests)
+	}
+
+	var selected []*models.Guest
+	for _, guest := range guests {
+		if op.check != nil {
+			if err := op.check(guest); err != nil {
+				result.Failed = append(result.Failed, models.GuestBulkFailure{GuestID: guest.ID.String(), Error: err.Error()})
+				continue
+			}
+		}
+		selected = append(selected, guest)
+	}
+
+	if len(selected) == 0 || (atomic && len(result.Failed) > 0) {
+		return result, nil
+	}
+
+	ids := make([]models.ID, len(selected))
+	for i, guest := range selected {
+		ids[i] = guest.ID
+	}
+	changed, err := op.apply(ids)
+	if err != nil {
+		return nil, err
+	}
+	result.Succeeded = int(changed)
+
+	for _, guest := range selected {
+		op.audit(guest)
+	}
+	return result, nil
+}
+
+// recordAudit records a change of a wedding's guests when the audit log is enabled
+func (s *GuestService) recordAudit(ctx context.Context, action string, weddingID models.ID, guestID string, changes []models.AuditChange, metadata map[string]interface{}) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, &models.AuditLog{
+		Action:     action,
+		TargetType: models.AuditTargetGuest,
+		TargetID:   guestID,
+		WeddingID:  &weddingID,
+		Changes:    changes,
+		Metadata:   metadata,
+	})
+}
+
+// verifyWeddingOwnership verifies that the user may manage the wedding's guests, as its
+// owner or as a collaborator
+func (s *GuestService) verifyWeddingOwnership(ctx context.Context, weddingID, userID models.ID) error {
+	_, err := s.getManagedWedding(ctx, weddingID, userID)
+	return err
+}
+
+// getManagedWedding returns a wedding the user may manage the guests of
+func (s *GuestService) getManagedWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
 	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
 	if err != nil {
-		return fmt.Errorf("wedding not found: %w", err)
+		return nil, fmt.Errorf("wedding not found: %w", err)
 	}
 
-	if wedding.UserID != userID {
-		return errors.New("unauthorized: you don't own this wedding")
+	if !wedding.Can(userID, models.PermissionManageGuests) {
+		return nil, fmt.Errorf("%w: you can't manage guests of this wedding", ErrUnauthorized)
 	}
 
-	return nil
+	return wedding, nil
+}
+
+// checkGuestLimit checks adding guests keeps the wedding within its owner's plan
+func (s *GuestService) checkGuestLimit(ctx context.Context, wedding *models.Wedding, adding int) error {
+	if s.limits == nil {
+		return nil
+	}
+	return s.limits.CheckGuestLimit(ctx, wedding, adding)
 }
 
 // validateGuest validates guest data
@@ -328,11 +557,21 @@ func (s *GuestService) validateGuest(guest *models.Guest) error {
 		return errors.New("max plus ones must be between 0 and 5")
 	}
 
+	// Validate tags
+	if len(guest.Tags) > maxGuestTags {
+		return fmt.Errorf("a guest can have at most %d tags", maxGuestTags)
+	}
+	for _, tag := range guest.Tags {
+		if strings.TrimSpace(tag) == "" || len(tag) > maxGuestTagLength {
+			return fmt.Errorf("tags must be 1 to %d characters", maxGuestTagLength)
+		}
+	}
+
 	return nil
 }
 
 // parseGuestFromCSV parses a guest from a CSV row
-func (s *GuestService) parseGuestFromCSV(row []string, headers []string, weddingID, userID primitive.ObjectID, batchID string) (*models.Guest, error) {
+func (s *GuestService) parseGuestFromCSV(row []string, headers []string, weddingID, userID models.ID, batchID string) (*models.Guest, error) {
 	guest := &models.Guest{
 		WeddingID:     weddingID,
 		CreatedBy:     userID,
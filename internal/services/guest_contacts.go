@@ -0,0 +1,518 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/quotedprintable"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Limits of contact imports
+const (
+	maxVCardImportSize = 5 << 20
+	maxVCardContacts   = 5000
+)
+
+var (
+	// ErrInvalidVCard is returned for files that hold no vCard
+	ErrInvalidVCard = errors.New("invalid vCard file")
+	// ErrGoogleContactsUnavailable is returned when no Google OAuth client is configured
+	ErrGoogleContactsUnavailable = errors.New("Google Contacts import is not available")
+	// ErrGoogleContactsNotConnected is returned when the user has no live Google Contacts connection
+	ErrGoogleContactsNotConnected = errors.New("Google Contacts is not connected or the connection expired")
+)
+
+// EnableGoogleContacts lets owners connect their Google account and import
+// selected contacts as guests
+func (s *GuestService) EnableGoogleContacts(client GoogleContactsClient, tokens GoogleContactsTokenStore) {
+	s.googleContacts = client
+	s.googleTokens = tokens
+}
+
+// ImportGuestsFromVCard imports the contacts of a vCard (.vcf) file as guests.
+// Contacts with the email or phone of an existing guest, or of an earlier
+// contact in the file, are skipped as duplicates.
+func (s *GuestService) ImportGuestsFromVCard(ctx context.Context, weddingID, userID models.ID, vcf io.Reader) (*models.GuestImportResult, error) {
+	wedding, err := s.getManagedWedding(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, err := ParseVCards(io.LimitReader(vcf, maxVCardImportSize))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.importContacts(ctx, wedding, userID, contacts, "vcard")
+}
+
+// ConnectGoogleContacts redeems an authorization code for the contacts.readonly
+// scope, keeps the connection for later imports and returns the account's contacts
+func (s *GuestService) ConnectGoogleContacts(ctx context.Context, weddingID, userID models.ID, code, redirectURI string) ([]*models.GuestContact, error) {
+	if s.googleContacts == nil {
+		return nil, ErrGoogleContactsUnavailable
+	}
+	if _, err := s.getManagedWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	token, err := s.googleContacts.Exchange(ctx, code, redirectURI)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.googleTokens.Save(ctx, userID, token.AccessToken, token.ExpiresIn); err != nil {
+		return nil, err
+	}
+
+	return s.listGoogleContacts(ctx, weddingID, userID, token.AccessToken)
+}
+
+// ListGoogleContacts returns the contacts of the user's connected Google account,
+// with the guests they duplicate
+func (s *GuestService) ListGoogleContacts(ctx context.Context, weddingID, userID models.ID) ([]*models.GuestContact, error) {
+	if s.googleContacts == nil {
+		return nil, ErrGoogleContactsUnavailable
+	}
+	if _, err := s.getManagedWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := s.googleTokens.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.listGoogleContacts(ctx, weddingID, userID, accessToken)
+}
+
+// ImportGoogleContacts imports the selected contacts of the user's connected
+// Google account as guests, skipping those that duplicate a guest
+func (s *GuestService) ImportGoogleContacts(ctx context.Context, weddingID, userID models.ID, resourceNames []string) (*models.GuestImportResult, error) {
+	if s.googleContacts == nil {
+		return nil, ErrGoogleContactsUnavailable
+	}
+	wedding, err := s.getManagedWedding(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := s.googleTokens.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	contacts, err := s.fetchGoogleContacts(ctx, userID, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*models.GuestContact, len(contacts))
+	for _, contact := range contacts {
+		byName[contact.ResourceName] = contact
+	}
+	selected := make([]*models.GuestContact, 0, len(resourceNames))
+	var missing []string
+	seen := make(map[string]bool, len(resourceNames))
+	for _, name := range resourceNames {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if contact, ok := byName[name]; ok {
+			selected = append(selected, contact)
+		} else {
+			missing = append(missing, fmt.Sprintf("Contact %s: not found in Google Contacts", name))
+		}
+	}
+
+	result, err := s.importContacts(ctx, wedding, userID, selected, "google_contacts")
+	if err != nil {
+		return nil, err
+	}
+	result.Errors = append(result.Errors, missing...)
+	result.ErrorCount += len(missing)
+	return result, nil
+}
+
+// DisconnectGoogleContacts forgets the user's Google Contacts connection
+func (s *GuestService) DisconnectGoogleContacts(ctx context.Context, userID models.ID) error {
+	if s.googleContacts == nil {
+		return ErrGoogleContactsUnavailable
+	}
+	return s.googleTokens.Delete(ctx, userID)
+}
+
+func (s *GuestService) listGoogleContacts(ctx context.Context, weddingID, userID models.ID, accessToken string) ([]*models.GuestContact, error) {
+	contacts, err := s.fetchGoogleContacts(ctx, userID, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := s.contactIndex(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+	for _, contact := range contacts {
+		if id, ok := index.match(contact); ok {
+			contact.DuplicateOf = &id
+		}
+	}
+	return contacts, nil
+}
+
+// fetchGoogleContacts reads the contacts with the access token, dropping the
+// connection when Google no longer accepts it
+func (s *GuestService) fetchGoogleContacts(ctx context.Context, userID models.ID, accessToken string) ([]*models.GuestContact, error) {
+	contacts, err := s.googleContacts.ListContacts(ctx, accessToken)
+	if errors.Is(err, ErrGoogleContactsNotConnected) {
+		_ = s.googleTokens.Delete(ctx, userID)
+	}
+	return contacts, err
+}
+
+// importContacts adds contacts as guests of one import batch. Contacts that
+// duplicate a guest or an earlier contact, or that are not valid guests, are
+// reported rather than imported.
+func (s *GuestService) importContacts(ctx context.Context, wedding *models.Wedding, userID models.ID, contacts []*models.GuestContact, source string) (*models.GuestImportResult, error) {
+	index, err := s.contactIndex(ctx, wedding.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	batchID := newImportBatchID(userID)
+	result := &models.GuestImportResult{BatchID: batchID, Errors: []string{}}
+	var guests []*models.Guest
+	for i, contact := range contacts {
+		label := fmt.Sprintf("Contact %d", i+1)
+		if name := strings.TrimSpace(contact.FirstName + " " + contact.LastName); name != "" {
+			label += " (" + name + ")"
+		}
+		if _, ok := index.match(contact); ok {
+			result.Duplicates = append(result.Duplicates, label+": a guest with this email or phone already exists")
+			continue
+		}
+
+		guest := &models.Guest{
+			WeddingID:        wedding.ID,
+			CreatedBy:        userID,
+			FirstName:        contact.FirstName,
+			LastName:         contact.LastName,
+			Email:            contact.Email,
+			Phone:            contact.Phone,
+			Notes:            contact.Notes,
+			InvitedVia:       "manual",
+			InvitationStatus: models.InvitationStatusPending,
+			ImportBatchID:    batchID,
+		}
+		if err := s.validateGuest(guest); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", label, err))
+			continue
+		}
+
+		guests = append(guests, guest)
+		// Later contacts with the same email or phone are duplicates of this one
+		index.add(contact, models.NilID)
+	}
+
+	if len(guests) > 0 {
+		if err := s.checkGuestLimit(ctx, wedding, len(guests)); err != nil {
+			return nil, err
+		}
+		if err := s.guestRepo.ImportBatch(ctx, guests, batchID); err != nil {
+			return nil, fmt.Errorf("failed to import guests: %w", err)
+		}
+		s.recordAudit(ctx, models.AuditGuestImport, wedding.ID, batchID, nil, map[string]interface{}{
+			"source": source,
+			"count":  len(guests),
+		})
+	}
+
+	result.SuccessCount = len(guests)
+	result.ErrorCount = len(result.Errors)
+	result.DuplicateCount = len(result.Duplicates)
+	return result, nil
+}
+
+// guestContactIndex finds the guests of a wedding by normalized email and phone
+type guestContactIndex struct {
+	emails map[string]models.ID
+	phones map[string]models.ID
+}
+
+// contactIndex indexes the emails and phones of the wedding's guests
+func (s *GuestService) contactIndex(ctx context.Context, weddingID models.ID) (*guestContactIndex, error) {
+	index := &guestContactIndex{emails: make(map[string]models.ID), phones: make(map[string]models.ID)}
+	err := s.guestRepo.StreamByWedding(ctx, weddingID, repository.GuestFilters{}, func(guest *models.Guest) error {
+		index.add(&models.GuestContact{Email: guest.Email, Phone: guest.Phone}, guest.ID)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list guests: %w", err)
+	}
+	return index, nil
+}
+
+func (i *guestContactIndex) add(contact *models.GuestContact, guestID models.ID) {
+	if email := normalizeContactEmail(contact.Email); email != "" {
+		if _, ok := i.emails[email]; !ok {
+			i.emails[email] = guestID
+		}
+	}
+	if phone := models.GuestPhoneKey(contact.Phone); phone != "" {
+		if _, ok := i.phones[phone]; !ok {
+			i.phones[phone] = guestID
+		}
+	}
+}
+
+// match returns the guest with the contact's email or phone
+func (i *guestContactIndex) match(contact *models.GuestContact) (models.ID, bool) {
+	if email := normalizeContactEmail(contact.Email); email != "" {
+		if id, ok := i.emails[email]; ok {
+			return id, true
+		}
+	}
+	if phone := models.GuestPhoneKey(contact.Phone); phone != "" {
+		if id, ok := i.phones[phone]; ok {
+			return id, true
+		}
+	}
+	return models.NilID, false
+}
+
+func normalizeContactEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// newImportBatchID returns the ID shared by the guests of one import
+func newImportBatchID(userID models.ID) string {
+	return fmt.Sprintf("%s_%d", userID.String(), time.Now().Unix())
+}
+
+// splitFullName splits a formatted name into a first name and a last name,
+// taking the last word as the last name
+func splitFullName(name string) (string, string) {
+	words := strings.Fields(name)
+	switch len(words) {
+	case 0:
+		return "", ""
+	case 1:
+		return words[0], ""
+	default:
+		return strings.Join(words[:len(words)-1], " "), words[len(words)-1]
+	}
+}
+
+// vCardProperty is one content line of a vCard
+type vCardProperty struct {
+	name   string
+	params map[string][]string
+	value  string
+}
+
+// ParseVCards reads the contacts of a vCard 2.1, 3.0 or 4.0 file. The first
+// and last name come from N, or from FN when N is empty; the preferred, or
+// else the first, EMAIL and TEL are kept.
+func ParseVCards(r io.Reader) ([]*models.GuestContact, error) {
+	lines, err := unfoldVCardLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var contacts []*models.GuestContact
+	var card []vCardProperty
+	inCard := false
+	for _, line := range lines {
+		prop, ok := parseVCardLine(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VCARD"):
+			inCard, card = true, nil
+		case prop.name == "END" && strings.EqualFold(prop.value, "VCARD"):
+			if inCard {
+				contacts = append(contacts, vCardContact(card))
+				if len(contacts) > maxVCardContacts {
+					return nil, fmt.Errorf("%w: a file can hold at most %d contacts", ErrInvalidVCard, maxVCardContacts)
+				}
+			}
+			inCard = false
+		case inCard:
+			card = append(card, prop)
+		}
+	}
+
+	if len(contacts) == 0 {
+		return nil, fmt.Errorf("%w: no contacts found", ErrInvalidVCard)
+	}
+	return contacts, nil
+}
+
+// unfoldVCardLines joins folded content lines, which continue on lines starting
+// with a space or tab, and vCard 2.1 quoted-printable soft line breaks
+func unfoldVCardLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxVCardImportSize)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 {
+			last := lines[len(lines)-1]
+			if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+				lines[len(lines)-1] = last + line[1:]
+				continue
+			}
+			if strings.HasSuffix(last, "=") && strings.Contains(strings.ToUpper(vCardLineHead(last)), "QUOTED-PRINTABLE") {
+				lines[len(lines)-1] = last + "\r\n" + line
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidVCard, err)
+	}
+	return lines, nil
+}
+
+// vCardLineHead returns the name and parameters of a content line
+func vCardLineHead(line string) string {
+	if i := strings.Index(line, ":"); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+func parseVCardLine(line string) (vCardProperty, bool) {
+	colon := strings.Index(line, ":")
+	if colon <= 0 {
+		return vCardProperty{}, false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	name := strings.ToUpper(parts[0])
+	// Apple and Google prefix grouped properties, as in item1.EMAIL
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		name = name[dot+1:]
+	}
+
+	prop := vCardProperty{name: name, params: make(map[string][]string), value: line[colon+1:]}
+	for _, param := range parts[1:] {
+		key, value, found := strings.Cut(param, "=")
+		if !found {
+			// vCard 2.1 allows bare types, as in TEL;CELL;PREF
+			key, value = "TYPE", param
+		}
+		key = strings.ToUpper(key)
+		for _, v := range strings.Split(strings.Trim(value, `"`), ",") {
+			prop.params[key] = append(prop.params[key], strings.ToUpper(v))
+		}
+	}
+
+	if hasVCardParam(prop, "ENCODING", "QUOTED-PRINTABLE") || hasVCardParam(prop, "TYPE", "QUOTED-PRINTABLE") {
+		decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(prop.value)))
+		if err == nil {
+			prop.value = string(decoded)
+		}
+	}
+	return prop, true
+}
+
+func hasVCardParam(prop vCardProperty, key, value string) bool {
+	for _, v := range prop.params[key] {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// preferred reports whether a property is marked as the preferred one of its kind
+func (p vCardProperty) preferred() bool {
+	return hasVCardParam(p, "TYPE", "PREF") || len(p.params["PREF"]) > 0
+}
+
+func vCardContact(card []vCardProperty) *models.GuestContact {
+	contact := &models.GuestContact{}
+	var formatted string
+	var emailPref, phonePref bool
+	for _, prop := range card {
+		switch prop.name {
+		case "N":
+			fields := splitVCardValue(prop.value)
+			if len(fields) > 0 {
+				contact.LastName = strings.TrimSpace(fields[0])
+			}
+			if len(fields) > 1 {
+				contact.FirstName = strings.TrimSpace(fields[1])
+			}
+		case "FN":
+			formatted = strings.TrimSpace(unescapeVCardValue(prop.value))
+		case "EMAIL":
+			if contact.Email == "" || (!emailPref && prop.preferred()) {
+				contact.Email = strings.TrimSpace(unescapeVCardValue(prop.value))
+				emailPref = prop.preferred()
+			}
+		case "TEL":
+			if contact.Phone == "" || (!phonePref && prop.preferred()) {
+				contact.Phone = strings.TrimSpace(strings.TrimPrefix(unescapeVCardValue(prop.value), "tel:"))
+				phonePref = prop.preferred()
+			}
+		case "NOTE":
+			contact.Notes = strings.TrimSpace(unescapeVCardValue(prop.value))
+		}
+	}
+
+	if contact.FirstName == "" && contact.LastName == "" {
+		contact.FirstName, contact.LastName = splitFullName(formatted)
+	}
+	return contact
+}
+
+// splitVCardValue splits a structured value at its unescaped semicolons
+func splitVCardValue(value string) []string {
+	var fields []string
+	var field strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			field.WriteByte(value[i])
+			field.WriteByte(value[i+1])
+			i++
+		case value[i] == ';':
+			fields = append(fields, unescapeVCardValue(field.String()))
+			field.Reset()
+		default:
+			field.WriteByte(value[i])
+		}
+	}
+	return append(fields, unescapeVCardValue(field.String()))
+}
+
+func unescapeVCardValue(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var out strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			out.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n', 'N':
+			out.WriteByte('\n')
+		default:
+			out.WriteByte(value[i])
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+const testVCards = "BEGIN:VCARD\r\n" +
+	"VERSION:3.0\r\n" +
+	"N:Doe;John;;;\r\n" +
+	"FN:John Doe\r\n" +
+	"EMAIL;TYPE=HOME:john@home.example.com\r\n" +
+	"EMAIL;TYPE=INTERNET,PREF:john@example.com\r\n" +
+	"TEL;TYPE=CELL:+1 (555) 010-2030\r\n" +
+	"NOTE:Met at college\\, class of 2010\\nBest man\r\n" +
+	"END:VCARD\r\n" +
+	"BEGIN:VCARD\r\n" +
+	"VERSION:4.0\r\n" +
+	"FN:Mary Ann\r\n" +
+	"  Smith\r\n" +
+	"item1.TEL;VALUE=uri:tel:+44-20-7946-0000\r\n" +
+	"END:VCARD\r\n" +
+	"BEGIN:VCARD\r\n" +
+	"VERSION:2.1\r\n" +
+	"N;ENCODING=QUOTED-PRINTABLE;CHARSET=UTF-8:M=C3=BCller;J=C3=BCrgen\r\n" +
+	"TEL;CELL;PREF:5550001111\r\n" +
+	"END:VCARD\r\n"
+
+func TestParseVCards(t *testing.T) {
+	contacts, err := ParseVCards(strings.NewReader(testVCards))
+	require.NoError(t, err)
+	require.Len(t, contacts, 3)
+
+	assert.Equal(t, &models.GuestContact{
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john@example.com",
+		Phone:     "+1 (555) 010-2030",
+		Notes:     "Met at college, class of 2010\nBest man",
+	}, contacts[0])
+	assert.Equal(t, &models.GuestContact{FirstName: "Mary Ann", LastName: "Smith", Phone: "+44-20-7946-0000"}, contacts[1])
+	assert.Equal(t, &models.GuestContact{FirstName: "Jürgen", LastName: "Müller", Phone: "5550001111"}, contacts[2])
+
+	_, err = ParseVCards(strings.NewReader("first_name,last_name\nJohn,Doe\n"))
+	assert.ErrorIs(t, err, ErrInvalidVCard)
+}
+
+func newContactImportService(t *testing.T) (*GuestService, *MockGuestRepository, models.ID, models.ID) {
+	guestRepo := NewMockGuestRepository()
+	weddingRepo := &MockWeddingRepository{}
+	service := NewGuestService(guestRepo, weddingRepo)
+
+	weddingID := models.NewID()
+	userID := models.NewID()
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(&models.Wedding{ID: weddingID, UserID: userID}, nil)
+	return service, guestRepo, weddingID, userID
+}
+
+func TestGuestService_ImportGuestsFromVCard(t *testing.T) {
+	ctx := context.Background()
+	service, guestRepo, weddingID, userID := newContactImportService(t)
+	existing := &models.Guest{WeddingID: weddingID, FirstName: "Johnny", LastName: "Doe", Email: "JOHN@example.com"}
+	require.NoError(t, guestRepo.Create(ctx, existing))
+
+	vcf := testVCards +
+		// A second card for Mary with her number written differently
+		"BEGIN:VCARD\r\nFN:Mary Smith\r\nTEL:+44 20 7946 0000\r\nEND:VCARD\r\n" +
+		"BEGIN:VCARD\r\nFN:Cher\r\nEND:VCARD\r\n"
+
+	result, err := service.ImportGuestsFromVCard(ctx, weddingID, userID, strings.NewReader(vcf))
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.SuccessCount)
+	assert.Equal(t, 2, result.DuplicateCount)
+	assert.Equal(t, []string{
+		"Contact 1 (John Doe): a guest with this email or phone already exists",
+		"Contact 4 (Mary Smith): a guest with this email or phone already exists",
+	}, result.Duplicates)
+	assert.Equal(t, 1, result.ErrorCount)
+	assert.Equal(t, []string{"Contact 5 (Cher): last name is required"}, result.Errors)
+
+	imported := guestRepo.batchGuests[result.BatchID]
+	require.Len(t, imported, 2)
+	assert.Equal(t, "Mary Ann", imported[0].FirstName)
+	assert.Equal(t, userID, imported[0].CreatedBy)
+	assert.Equal(t, models.InvitationStatusPending, imported[0].InvitationStatus)
+	assert.Equal(t, "Jürgen", imported[1].FirstName)
+
+	t.Run("Error - not a vCard file", func(t *testing.T) {
+		_, err := service.ImportGuestsFromVCard(ctx, weddingID, userID, strings.NewReader("hello"))
+		assert.ErrorIs(t, err, ErrInvalidVCard)
+	})
+
+	t.Run("Error - not a manager of the wedding", func(t *testing.T) {
+		_, err := service.ImportGuestsFromVCard(ctx, weddingID, models.NewID(), strings.NewReader(testVCards))
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+}
+
+// fakeGoogleContacts serves a fixed address book to one access token
+type fakeGoogleContacts struct {
+	contacts []*models.GuestContact
+	revoked  bool
+}
+
+func (f *fakeGoogleContacts) Exchange(ctx context.Context, code, redirectURI string) (*GoogleContactsToken, error) {
+	if code != "good-code" {
+		return nil, ErrOAuthExchangeFailed
+	}
+	return &GoogleContactsToken{AccessToken: "contacts-token", ExpiresIn: time.Hour}, nil
+}
+
+func (f *fakeGoogleContacts) ListContacts(ctx context.Context, accessToken string) ([]*models.GuestContact, error) {
+	if f.revoked || accessToken != "contacts-token" {
+		return nil, ErrGoogleContactsNotConnected
+	}
+	contacts := make([]*models.GuestContact, len(f.contacts))
+	for i, contact := range f.contacts {
+		copied := *contact
+		contacts[i] = &copied
+	}
+	return contacts, nil
+}
+
+func TestGuestService_GoogleContacts(t *testing.T) {
+	ctx := context.Background()
+	service, guestRepo, weddingID, userID := newContactImportService(t)
+	existing := &models.Guest{WeddingID: weddingID, FirstName: "Ann", LastName: "Lee", Phone: "555-010-9999"}
+	require.NoError(t, guestRepo.Create(ctx, existing))
+
+	google := &fakeGoogleContacts{contacts: []*models.GuestContact{
+		{ResourceName: "people/c1", FirstName: "Ann", LastName: "Lee", Phone: "+15550109999"},
+		{ResourceName: "people/c2", FirstName: "Ben", LastName: "Ray", Email: "ben@example.com"},
+		{ResourceName: "people/c3", FirstName: "Cleo", LastName: "Park"},
+	}}
+
+	_, err := service.ListGoogleContacts(ctx, weddingID, userID)
+	assert.ErrorIs(t, err, ErrGoogleContactsUnavailable)
+
+	service.EnableGoogleContacts(google, NewMemoryGoogleContactsTokenStore())
+
+	_, err = service.ListGoogleContacts(ctx, weddingID, userID)
+	assert.ErrorIs(t, err, ErrGoogleContactsNotConnected)
+	_, err = service.ConnectGoogleContacts(ctx, weddingID, userID, "bad-code", "https://app.example.com/callback")
+	assert.ErrorIs(t, err, ErrOAuthExchangeFailed)
+
+	contacts, err := service.ConnectGoogleContacts(ctx, weddingID, userID, "good-code", "https://app.example.com/callback")
+	require.NoError(t, err)
+	require.Len(t, contacts, 3)
+	require.NotNil(t, contacts[0].DuplicateOf)
+	assert.Equal(t, existing.ID, *contacts[0].DuplicateOf)
+	assert.Nil(t, contacts[1].DuplicateOf)
+
+	result, err := service.ImportGoogleContacts(ctx, weddingID, userID, []string{"people/c1", "people/c2", "people/c2", "people/c9"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.SuccessCount)
+	assert.Equal(t, 1, result.DuplicateCount)
+	assert.Equal(t, []string{"Contact people/c9: not found in Google Contacts"}, result.Errors)
+	require.Len(t, guestRepo.batchGuests[result.BatchID], 1)
+	assert.Equal(t, "ben@example.com", guestRepo.batchGuests[result.BatchID][0].Email)
+
+	// Ben is a guest now
+	contacts, err = service.ListGoogleContacts(ctx, weddingID, userID)
+	require.NoError(t, err)
+	require.NotNil(t, contacts[1].DuplicateOf)
+
+	// A token Google revoked ends the connection
+	google.revoked = true
+	_, err = service.ListGoogleContacts(ctx, weddingID, userID)
+	assert.ErrorIs(t, err, ErrGoogleContactsNotConnected)
+	google.revoked = false
+	_, err = service.ListGoogleContacts(ctx, weddingID, userID)
+	assert.ErrorIs(t, err, ErrGoogleContactsNotConnected)
+
+	_, err = service.ConnectGoogleContacts(ctx, weddingID, userID, "good-code", "https://app.example.com/callback")
+	require.NoError(t, err)
+	require.NoError(t, service.DisconnectGoogleContacts(ctx, userID))
+	_, err = service.ImportGoogleContacts(ctx, weddingID, userID, []string{"people/c3"})
+	assert.ErrorIs(t, err, ErrGoogleContactsNotConnected)
+}
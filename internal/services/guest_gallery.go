@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+const (
+	// maxGuestPhotoNameLength bounds the uploader name shown with a guest photo
+	maxGuestPhotoNameLength = 100
+	// maxGuestPhotoCaptionLength bounds the caption of a guest photo
+	maxGuestPhotoCaptionLength = 500
+)
+
+var (
+	ErrGuestUploadsDisabled     = errors.New("guest photo uploads are disabled for this wedding")
+	ErrGuestPhotoNotImage       = errors.New("only JPEG, PNG and WebP photos can be added to the gallery")
+	ErrInvalidGuestPhotoDetails = errors.New("uploader name must be at most 100 and caption at most 500 characters")
+	ErrGuestPhotoNotFound       = errs.NotFound("guest photo not found")
+	ErrGuestPhotoNotPending     = errors.New("guest photo has already been moderated")
+)
+
+// galleryImageExtensions are the extensions of the images guests may upload
+var galleryImageExtensions = map[string]bool{"jpg": true, "jpeg": true, "png": true, "webp": true}
+
+// GuestPhotoUpload describes a photo a guest adds to a wedding's gallery
+type GuestPhotoUpload struct {
+	UploaderName string
+	Caption      string
+	// IP is the uploader's address, kept for abuse reports
+	IP string
+}
+
+// GuestGalleryService lets the guests of a published wedding add photos to its
+// gallery and the wedding's editors moderate them. Uploads are stored as media
+// of the wedding owner; only approved photos are shown publicly.
+type GuestGalleryService struct {
+	photoRepo   repository.GuestPhotoRepository
+	weddingRepo repository.WeddingRepository
+	media       MediaService
+	webhooks    WeddingEventNotifier
+	features    FeatureChecker
+	logger      *zap.Logger
+}
+
+// NewGuestGalleryService creates a new guest gallery service
+func NewGuestGalleryService(photoRepo repository.GuestPhotoRepository, weddingRepo repository.WeddingRepository, media MediaService, logger *zap.Logger) *GuestGalleryService {
+	return &GuestGalleryService{
+		photoRepo:   photoRepo,
+		weddingRepo: weddingRepo,
+		media:       media,
+		logger:      logger,
+	}
+}
+
+// SetWebhookNotifier sends a media.uploaded event to the wedding's webhooks for every guest photo
+func (s *GuestGalleryService) SetWebhookNotifier(notifier WeddingEventNotifier) {
+	s.webhooks = notifier
+}
+
+// SetFeatureFlags turns guest uploads away from weddings the guest_photos
+// feature is off for, whatever the wedding's own setting
+func (s *GuestGalleryService) SetFeatureFlags(features FeatureChecker) {
+	s.features = features
+}
+
+// UploadPhoto stores a guest's photo. It waits for approval when the wedding
+// requires it and is shown in the gallery right away otherwise.
+func (s *GuestGalleryService) UploadPhoto(ctx context.Context, weddingID models.ID, file io.Reader, header *multipart.FileHeader, upload GuestPhotoUpload) (*models.GuestPhoto, error) {
+	wedding, err := s.getPublishedWedding(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+	if !wedding.GuestUploads.Enabled || !featureEnabled(ctx, s.features, wedding.ID, FeatureGuestPhotos) {
+		return nil, ErrGuestUploadsDisabled
+	}
+
+	name := strings.TrimSpace(upload.UploaderName)
+	caption := strings.TrimSpace(upload.Caption)
+	if utf8.RuneCountInString(name) > maxGuestPhotoNameLength || utf8.RuneCountInString(caption) > maxGuestPhotoCaptionLength {
+		return nil, ErrInvalidGuestPhotoDetails
+	}
+
+	// The media service accepts videos too; its validator checks the content matches
+	if ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(header.Filename), ".")); !galleryImageExtensions[ext] {
+		return nil, ErrGuestPhotoNotImage
+	}
+
+	media, err := s.media.UploadFile(ctx, file, header, wedding.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := models.GuestPhotoApproved
+	if wedding.GuestUploads.RequireApproval {
+		status = models.GuestPhotoPending
+	}
+	photo := &models.GuestPhoto{
+		WeddingID:    wedding.ID,
+		MediaID:      media.ID,
+		URL:          media.OriginalURL,
+		Thumbnails:   media.Thumbnails,
+		Width:        media.Width,
+		Height:       media.Height,
+		UploaderName: name,
+		Caption:      caption,
+		UploaderIP:   upload.IP,
+		Status:       status,
+	}
+	if err := s.photoRepo.Create(ctx, photo); err != nil {
+		return nil, fmt.Errorf("failed to save guest photo: %w", err)
+	}
+	if s.webhooks != nil {
+		s.webhooks.NotifyWeddingEvent(ctx, wedding.ID, models.WeddingEventMediaUploaded, map[string]interface{}{
+			"photo_id":      photo.ID.String(),
+			"media_id":      photo.MediaID.String(),
+			"url":           photo.URL,
+			"uploader_name": photo.UploaderName,
+			"status":        photo.Status,
+		})
+	}
+	return photo, nil
+}
+
+// ListGallery lists the approved photos of a published wedding, oldest first
+func (s *GuestGalleryService) ListGallery(ctx context.Context, weddingID models.ID, page, pageSize int) ([]*models.GuestPhoto, int64, error) {
+	if _, err := s.getPublishedWedding(ctx, weddingID); err != nil {
+		return nil, 0, err
+	}
+	return s.listPhotos(ctx, weddingID, models.GuestPhotoApproved, page, pageSize)
+}
+
+// ListPending lists the photos awaiting moderation, oldest first
+func (s *GuestGalleryService) ListPending(ctx context.Context, weddingID, userID models.ID, page, pageSize int) ([]*models.GuestPhoto, int64, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, 0, err
+	}
+	return s.listPhotos(ctx, weddingID, models.GuestPhotoPending, page, pageSize)
+}
+
+// listPhotos lists photos in a status. Thumbnails rendered in the background
+// after the upload are copied onto the photos the first time they are listed.
+func (s *GuestGalleryService) listPhotos(ctx context.Context, weddingID models.ID, status models.GuestPhotoStatus, page, pageSize int) ([]*models.GuestPhoto, int64, error) {
+	photos, total, err := s.photoRepo.ListByWedding(ctx, weddingID, status, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, photo := range photos {
+		if len(photo.Thumbnails) > 0 {
+			continue
+		}
+		media, err := s.media.GetMedia(ctx, photo.MediaID)
+		if err != nil || !media.HasThumbnails() {
+			continue
+		}
+		photo.Thumbnails = media.Thumbnails
+		if err := s.photoRepo.SetThumbnails(ctx, photo.ID, media.Thumbnails); err != nil {
+			s.logger.Warn("Failed to record guest photo thumbnails", zap.String("photo_id", photo.ID.String()), zap.Error(err))
+		}
+	}
+	return photos, total, nil
+}
+
+// ModeratePhoto approves a pending photo into the gallery or rejects it. The
+// media of rejected photos is deleted.
+func (s *GuestGalleryService) ModeratePhoto(ctx context.Context, weddingID, photoID, userID models.ID, approve bool) (*models.GuestPhoto, error) {
+	wedding, err := s.getEditableWedding(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	photo, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrGuestPhotoNotFound
+		}
+		return nil, fmt.Errorf("failed to get guest photo: %w", err)
+	}
+	if photo.WeddingID != wedding.ID {
+		return nil, ErrGuestPhotoNotFound
+	}
+	if !photo.IsPending() {
+		return nil, ErrGuestPhotoNotPending
+	}
+
+	status := models.GuestPhotoRejected
+	if approve {
+		status = models.GuestPhotoApproved
+	}
+	now := time.Now()
+	if err := s.photoRepo.Moderate(ctx, photo.ID, status, userID, now); err != nil {
+		// Another editor decided first
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrGuestPhotoNotPending
+		}
+		return nil, fmt.Errorf("failed to moderate guest photo: %w", err)
+	}
+	photo.Status = status
+	photo.ModeratedBy = &userID
+	photo.ModeratedAt = &now
+
+	if !approve {
+		if err := s.media.DeleteMedia(ctx, photo.MediaID, wedding.UserID); err != nil {
+			s.logger.Error("Failed to delete rejected guest photo",
+				zap.String("photo_id", photo.ID.String()),
+				zap.String("media_id", photo.MediaID.String()),
+				zap.Error(err))
+		}
+	}
+	return photo, nil
+}
+
+// getPublishedWedding returns a wedding guests can see, hiding unpublished ones
+func (s *GuestGalleryService) getPublishedWedding(ctx context.Context, weddingID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if !wedding.IsAccessible() {
+		return nil, ErrWeddingNotFound
+	}
+	return wedding, nil
+}
+
+// getEditableWedding returns a wedding the user may moderate the gallery of
+func (s *GuestGalleryService) getEditableWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if !wedding.Can(userID, models.PermissionEditWedding) {
+		return nil, ErrUnauthorized
+	}
+	return wedding, nil
+}
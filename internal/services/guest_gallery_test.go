@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockGuestPhotoRepository is an in-memory guest photo repository
+type MockGuestPhotoRepository struct {
+	photos map[models.ID]*models.GuestPhoto
+}
+
+func NewMockGuestPhotoRepository() *MockGuestPhotoRepository {
+	return &MockGuestPhotoRepository{photos: make(map[models.ID]*models.GuestPhoto)}
+}
+
+func (m *MockGuestPhotoRepository) Create(ctx context.Context, photo *models.GuestPhoto) error {
+	if photo.ID.IsZero() {
+		photo.ID = models.NewID()
+	}
+	photo.CreatedAt = time.Now()
+	stored := *photo
+	m.photos[photo.ID] = &stored
+	return nil
+}
+
+func (m *MockGuestPhotoRepository) GetByID(ctx context.Context, id models.ID) (*models.GuestPhoto, error) {
+	photo, ok := m.photos[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	stored := *photo
+	return &stored, nil
+}
+
+func (m *MockGuestPhotoRepository) Moderate(ctx context.Context, id models.ID, status models.GuestPhotoStatus, moderatedBy models.ID, moderatedAt time.Time) error {
+	photo, ok := m.photos[id]
+	if !ok || photo.Status != models.GuestPhotoPending {
+		return repository.ErrNotFound
+	}
+	photo.Status = status
+	photo.ModeratedBy = &moderatedBy
+	photo.ModeratedAt = &moderatedAt
+	return nil
+}
+
+func (m *MockGuestPhotoRepository) SetThumbnails(ctx context.Context, id models.ID, thumbnails map[string]string) error {
+	photo, ok := m.photos[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	photo.Thumbnails = thumbnails
+	return nil
+}
+
+func (m *MockGuestPhotoRepository) ListByWedding(ctx context.Context, weddingID models.ID, status models.GuestPhotoStatus, page, pageSize int) ([]*models.GuestPhoto, int64, error) {
+	photos := []*models.GuestPhoto{}
+	for _, photo := range m.photos {
+		if photo.WeddingID == weddingID && photo.Status == status {
+			stored := *photo
+			photos = append(photos, &stored)
+		}
+	}
+	sort.Slice(photos, func(i, j int) bool { return photos[i].CreatedAt.Before(photos[j].CreatedAt) })
+	return photos, int64(len(photos)), nil
+}
+
+// galleryMediaService stores uploads in memory; other media operations are unused
+type galleryMediaService struct {
+	MediaService
+	media   map[models.ID]*models.Media
+	deleted []models.ID
+}
+
+func (m *galleryMediaService) UploadFile(ctx context.Context, file io.Reader, header *multipart.FileHeader, userID models.ID) (*models.Media, error) {
+	media := &models.Media{
+		ID:          models.NewID(),
+		Filename:    header.Filename,
+		OriginalURL: "https://cdn.example.com/" + header.Filename,
+		CreatedBy:   userID,
+	}
+	m.media[media.ID] = media
+	return media, nil
+}
+
+func (m *galleryMediaService) GetMedia(ctx context.Context, mediaID models.ID) (*models.Media, error) {
+	media, ok := m.media[mediaID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return media, nil
+}
+
+func (m *galleryMediaService) DeleteMedia(ctx context.Context, mediaID, userID models.ID) error {
+	m.deleted = append(m.deleted, mediaID)
+	return nil
+}
+
+func setupGuestGalleryService(t *testing.T) (*GuestGalleryService, *MockGuestPhotoRepository, *galleryMediaService, *models.Wedding) {
+	repo := NewMockGuestPhotoRepository()
+	media := &galleryMediaService{media: make(map[models.ID]*models.Media)}
+	weddingRepo := new(MockWeddingRepository)
+
+	wedding := createTestWedding()
+	wedding.ID = models.NewID()
+	wedding.UserID = models.NewID()
+	wedding.Status = string(models.WeddingStatusPublished)
+	wedding.GuestUploads = models.GuestUploadSettings{Enabled: true, RequireApproval: true}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+	weddingRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+
+	return NewGuestGalleryService(repo, weddingRepo, media, zaptest.NewLogger(t)), repo, media, wedding
+}
+
+func TestGuestGalleryService_UploadPhoto(t *testing.T) {
+	ctx := context.Background()
+	header := &multipart.FileHeader{Filename: "dance.jpg"}
+
+	t.Run("Success - held for approval", func(t *testing.T) {
+		service, repo, _, wedding := setupGuestGalleryService(t)
+
+		photo, err := service.UploadPhoto(ctx, wedding.ID, strings.NewReader("jpeg"), header, GuestPhotoUpload{
+			UploaderName: " Aunt May ",
+			Caption:      "First dance",
+			IP:           "203.0.113.7",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.GuestPhotoPending, photo.Status)
+		assert.Equal(t, "Aunt May", photo.UploaderName)
+		assert.Equal(t, "203.0.113.7", repo.photos[photo.ID].UploaderIP)
+
+		gallery, total, err := service.ListGallery(ctx, wedding.ID, 1, 20)
+		require.NoError(t, err)
+		assert.Zero(t, total)
+		assert.Empty(t, gallery)
+	})
+
+	t.Run("Success - shown right away without approval", func(t *testing.T) {
+		service, _, media, wedding := setupGuestGalleryService(t)
+		wedding.GuestUploads.RequireApproval = false
+
+		photo, err := service.UploadPhoto(ctx, wedding.ID, strings.NewReader("jpeg"), header, GuestPhotoUpload{})
+		require.NoError(t, err)
+		assert.Equal(t, models.GuestPhotoApproved, photo.Status)
+		assert.Equal(t, wedding.UserID, media.media[photo.MediaID].CreatedBy)
+
+		// Thumbnails rendered in the background show up once ready
+		media.media[photo.MediaID].AddThumbnail("small", "https://cdn.example.com/small.jpg")
+		gallery, total, err := service.ListGallery(ctx, wedding.ID, 1, 20)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, "https://cdn.example.com/small.jpg", gallery[0].Thumbnails["small"])
+	})
+
+	t.Run("Error - uploads disabled", func(t *testing.T) {
+		service, _, _, wedding := setupGuestGalleryService(t)
+		wedding.GuestUploads.Enabled = false
+
+		_, err := service.UploadPhoto(ctx, wedding.ID, strings.NewReader("jpeg"), header, GuestPhotoUpload{})
+		assert.ErrorIs(t, err, ErrGuestUploadsDisabled)
+	})
+
+	t.Run("Error - wedding not published", func(t *testing.T) {
+		service, _, _, wedding := setupGuestGalleryService(t)
+		wedding.Status = string(models.WeddingStatusDraft)
+
+		_, err := service.UploadPhoto(ctx, wedding.ID, strings.NewReader("jpeg"), header, GuestPhotoUpload{})
+		assert.ErrorIs(t, err, ErrWeddingNotFound)
+	})
+
+	t.Run("Error - videos", func(t *testing.T) {
+		service, _, _, wedding := setupGuestGalleryService(t)
+
+		_, err := service.UploadPhoto(ctx, wedding.ID, strings.NewReader("mp4"), &multipart.FileHeader{Filename: "toast.mp4"}, GuestPhotoUpload{})
+		assert.ErrorIs(t, err, ErrGuestPhotoNotImage)
+	})
+
+	t.Run("Error - caption too long", func(t *testing.T) {
+		service, _, _, wedding := setupGuestGalleryService(t)
+
+		_, err := service.UploadPhoto(ctx, wedding.ID, strings.NewReader("jpeg"), header, GuestPhotoUpload{
+			Caption: strings.Repeat("a", maxGuestPhotoCaptionLength+1),
+		})
+		assert.ErrorIs(t, err, ErrInvalidGuestPhotoDetails)
+	})
+}
+
+func TestGuestGalleryService_ModeratePhoto(t *testing.T) {
+	ctx := context.Background()
+	header := &multipart.FileHeader{Filename: "dance.jpg"}
+
+	t.Run("Success - approve", func(t *testing.T) {
+		service, _, _, wedding := setupGuestGalleryService(t)
+		uploaded, err := service.UploadPhoto(ctx, wedding.ID, strings.NewReader("jpeg"), header, GuestPhotoUpload{})
+		require.NoError(t, err)
+
+		pending, total, err := service.ListPending(ctx, wedding.ID, wedding.UserID, 1, 20)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, uploaded.ID, pending[0].ID)
+
+		photo, err := service.ModeratePhoto(ctx, wedding.ID, uploaded.ID, wedding.UserID, true)
+		require.NoError(t, err)
+		assert.Equal(t, models.GuestPhotoApproved, photo.Status)
+		assert.Equal(t, wedding.UserID, *photo.ModeratedBy)
+
+		gallery, _, err := service.ListGallery(ctx, wedding.ID, 1, 20)
+		require.NoError(t, err)
+		require.Len(t, gallery, 1)
+		assert.Equal(t, uploaded.ID, gallery[0].ID)
+	})
+
+	t.Run("Success - reject deletes the media", func(t *testing.T) {
+		service, _, media, wedding := setupGuestGalleryService(t)
+		uploaded, err := service.UploadPhoto(ctx, wedding.ID, strings.NewReader("jpeg"), header, GuestPhotoUpload{})
+		require.NoError(t, err)
+
+		photo, err := service.ModeratePhoto(ctx, wedding.ID, uploaded.ID, wedding.UserID, false)
+		require.NoError(t, err)
+		assert.Equal(t, models.GuestPhotoRejected, photo.Status)
+		assert.Equal(t, []models.ID{uploaded.MediaID}, media.deleted)
+
+		_, err = service.ModeratePhoto(ctx, wedding.ID, uploaded.ID, wedding.UserID, true)
+		assert.ErrorIs(t, err, ErrGuestPhotoNotPending)
+	})
+
+	t.Run("Error - not an editor", func(t *testing.T) {
+		service, _, _, wedding := setupGuestGalleryService(t)
+		uploaded, err := service.UploadPhoto(ctx, wedding.ID, strings.NewReader("jpeg"), header, GuestPhotoUpload{})
+		require.NoError(t, err)
+
+		_, err = service.ModeratePhoto(ctx, wedding.ID, uploaded.ID, models.NewID(), true)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+
+		_, _, err = service.ListPending(ctx, wedding.ID, models.NewID(), 1, 20)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Error - photo of another wedding", func(t *testing.T) {
+		service, repo, _, wedding := setupGuestGalleryService(t)
+		other := &models.GuestPhoto{WeddingID: models.NewID(), Status: models.GuestPhotoPending}
+		require.NoError(t, repo.Create(ctx, other))
+
+		_, err := service.ModeratePhoto(ctx, wedding.ID, other.ID, wedding.UserID, true)
+		assert.ErrorIs(t, err, ErrGuestPhotoNotFound)
+	})
+}
@@ -0,0 +1,311 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// maxGuestGroupMembers bounds the guests of a household
+const maxGuestGroupMembers = 20
+
+var (
+	ErrGuestGroupNotFound  = errs.NotFound("guest group not found")
+	ErrInvalidGuestGroup   = errors.New("invalid guest group")
+	ErrGuestAlreadyGrouped = errors.New("guest already belongs to another group")
+)
+
+// GuestGroupRequest describes a household and the guests in it
+type GuestGroupRequest struct {
+	Name     string      `json:"name" validate:"required,max=100"`
+	GuestIDs []models.ID `json:"guest_ids" validate:"required,min=1,max=20"`
+	// PrimaryGuestID receives the household invitation; the first guest when empty
+	PrimaryGuestID *models.ID `json:"primary_guest_id,omitempty"`
+	Table          string     `json:"table,omitempty" validate:"omitempty,max=50"`
+	Notes          string     `json:"notes,omitempty" validate:"omitempty,max=500"`
+}
+
+// GuestGroupService groups related guests into households that are invited,
+// seated and answer their RSVP as one unit
+type GuestGroupService struct {
+	groupRepo   repository.GuestGroupRepository
+	guestRepo   repository.GuestRepository
+	weddingRepo repository.WeddingRepository
+}
+
+// NewGuestGroupService creates a new guest group service
+func NewGuestGroupService(groupRepo repository.GuestGroupRepository, guestRepo repository.GuestRepository, weddingRepo repository.WeddingRepository) *GuestGroupService {
+	return &GuestGroupService{
+		groupRepo:   groupRepo,
+		guestRepo:   guestRepo,
+		weddingRepo: weddingRepo,
+	}
+}
+
+// CreateGroup groups guests of a wedding into a household. Guests can only be
+// in one group at a time.
+func (s *GuestGroupService) CreateGroup(ctx context.Context, weddingID, userID models.ID, req GuestGroupRequest) (*models.GuestGroupDetail, error) {
+	if _, err := s.getManagedWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	group := &models.GuestGroup{WeddingID: weddingID, CreatedBy: userID}
+	members, err := s.prepareGroup(ctx, group, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.groupRepo.Create(ctx, group); err != nil {
+		return nil, fmt.Errorf("failed to create guest group: %w", err)
+	}
+	if _, err := s.guestRepo.SetGroup(ctx, weddingID, guestIDs(members), &group.ID); err != nil {
+		return nil, fmt.Errorf("failed to add guests to group: %w", err)
+	}
+	setGroup(members, &group.ID)
+
+	return &models.GuestGroupDetail{GuestGroup: group, Members: members}, nil
+}
+
+// ListGroups lists the groups of a wedding with their members
+func (s *GuestGroupService) ListGroups(ctx context.Context, weddingID, userID models.ID) ([]*models.GuestGroupDetail, error) {
+	if _, err := s.getManagedWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	groups, err := s.groupRepo.ListByWedding(ctx, weddingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list guest groups: %w", err)
+	}
+
+	// One pass over the guest list rather than a query per group
+	members := make(map[models.ID][]*models.Guest, len(groups))
+	err = s.guestRepo.StreamByWedding(ctx, weddingID, repository.GuestFilters{}, func(guest *models.Guest) error {
+		if guest.GroupID != nil {
+			members[*guest.GroupID] = append(members[*guest.GroupID], guest)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list guests: %w", err)
+	}
+
+	details := make([]*models.GuestGroupDetail, 0, len(groups))
+	for _, group := range groups {
+		details = append(details, &models.GuestGroupDetail{GuestGroup: group, Members: nonNilGuests(members[group.ID])})
+	}
+	return details, nil
+}
+
+// GetGroup retrieves a group with its members
+func (s *GuestGroupService) GetGroup(ctx context.Context, weddingID, groupID, userID models.ID) (*models.GuestGroupDetail, error) {
+	group, err := s.getGroup(ctx, weddingID, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.members(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	return &models.GuestGroupDetail{GuestGroup: group, Members: members}, nil
+}
+
+// UpdateGroup replaces the details and members of a group. Guests left out of
+// the request are removed from the group.
+func (s *GuestGroupService) UpdateGroup(ctx context.Context, weddingID, groupID, userID models.ID, req GuestGroupRequest) (*models.GuestGroupDetail, error) {
+	group, err := s.getGroup(ctx, weddingID, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.members(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.prepareGroup(ctx, group, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.groupRepo.Update(ctx, group); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrGuestGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to update guest group: %w", err)
+	}
+
+	kept := guestIDs(members)
+	var removed []models.ID
+	for _, guest := range current {
+		if !hasID(kept, guest.ID) {
+			removed = append(removed, guest.ID)
+		}
+	}
+	if _, err := s.guestRepo.SetGroup(ctx, weddingID, removed, nil); err != nil {
+		return nil, fmt.Errorf("failed to remove guests from group: %w", err)
+	}
+	if _, err := s.guestRepo.SetGroup(ctx, weddingID, kept, &group.ID); err != nil {
+		return nil, fmt.Errorf("failed to add guests to group: %w", err)
+	}
+	setGroup(members, &group.ID)
+
+	return &models.GuestGroupDetail{GuestGroup: group, Members: members}, nil
+}
+
+// DeleteGroup removes a group. Its members stay on the guest list, ungrouped.
+func (s *GuestGroupService) DeleteGroup(ctx context.Context, weddingID, groupID, userID models.ID) error {
+	group, err := s.getGroup(ctx, weddingID, groupID, userID)
+	if err != nil {
+		return err
+	}
+
+	members, err := s.members(ctx, group)
+	if err != nil {
+		return err
+	}
+	if _, err := s.guestRepo.SetGroup(ctx, weddingID, guestIDs(members), nil); err != nil {
+		return fmt.Errorf("failed to remove guests from group: %w", err)
+	}
+
+	if err := s.groupRepo.Delete(ctx, group.ID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrGuestGroupNotFound
+		}
+		return fmt.Errorf("failed to delete guest group: %w", err)
+	}
+	return nil
+}
+
+// prepareGroup checks the requested members are guests of the group's wedding
+// without another group, and applies the request to the group
+func (s *GuestGroupService) prepareGroup(ctx context.Context, group *models.GuestGroup, req GuestGroupRequest) ([]*models.Guest, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidGuestGroup)
+	}
+
+	ids := make([]models.ID, 0, len(req.GuestIDs))
+	for _, id := range req.GuestIDs {
+		if !hasID(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 || len(ids) > maxGuestGroupMembers {
+		return nil, fmt.Errorf("%w: a group holds between 1 and %d guests", ErrInvalidGuestGroup, maxGuestGroupMembers)
+	}
+
+	primaryID := ids[0]
+	if req.PrimaryGuestID != nil {
+		primaryID = *req.PrimaryGuestID
+	}
+	if !hasID(ids, primaryID) {
+		return nil, fmt.Errorf("%w: the primary guest must be a member", ErrInvalidGuestGroup)
+	}
+
+	guests, _, err := s.guestRepo.ListByWedding(ctx, group.WeddingID, 0, 0, repository.GuestFilters{IDs: ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guests: %w", err)
+	}
+	byID := make(map[models.ID]*models.Guest, len(guests))
+	for _, guest := range guests {
+		byID[guest.ID] = guest
+	}
+
+	members := make([]*models.Guest, 0, len(ids))
+	for _, id := range ids {
+		guest, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: guest %s is not on the guest list", ErrInvalidGuestGroup, id.String())
+		}
+		if guest.GroupID != nil && *guest.GroupID != group.ID {
+			return nil, fmt.Errorf("%w: %s %s", ErrGuestAlreadyGrouped, guest.FirstName, guest.LastName)
+		}
+		members = append(members, guest)
+	}
+
+	group.Name = name
+	group.PrimaryGuestID = primaryID
+	group.Table = strings.TrimSpace(req.Table)
+	group.Notes = req.Notes
+	return members, nil
+}
+
+// members returns the guests of a group
+func (s *GuestGroupService) members(ctx context.Context, group *models.GuestGroup) ([]*models.Guest, error) {
+	guests, _, err := s.guestRepo.ListByWedding(ctx, group.WeddingID, 0, 0, repository.GuestFilters{GroupID: &group.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group members: %w", err)
+	}
+	return nonNilGuests(guests), nil
+}
+
+func (s *GuestGroupService) getGroup(ctx context.Context, weddingID, groupID, userID models.ID) (*models.GuestGroup, error) {
+	if _, err := s.getManagedWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrGuestGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to get guest group: %w", err)
+	}
+	if group.WeddingID != weddingID {
+		return nil, ErrGuestGroupNotFound
+	}
+	return group, nil
+}
+
+func (s *GuestGroupService) getManagedWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	if !wedding.Can(userID, models.PermissionManageGuests) {
+		return nil, ErrUnauthorized
+	}
+	return wedding, nil
+}
+
+func guestIDs(guests []*models.Guest) []models.ID {
+	ids := make([]models.ID, len(guests))
+	for i, guest := range guests {
+		ids[i] = guest.ID
+	}
+	return ids
+}
+
+func setGroup(guests []*models.Guest, groupID *models.ID) {
+	for _, guest := range guests {
+		guest.GroupID = groupID
+	}
+}
+
+func hasID(ids []models.ID, id models.ID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// nonNilGuests returns an empty list for nil, so groups without members encode as []
+func nonNilGuests(guests []*models.Guest) []*models.Guest {
+	if guests == nil {
+		return []*models.Guest{}
+	}
+	return guests
+}
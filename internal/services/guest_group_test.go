@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockGuestGroupRepository is an in-memory guest group repository
+type MockGuestGroupRepository struct {
+	groups map[models.ID]*models.GuestGroup
+}
+
+func NewMockGuestGroupRepository() *MockGuestGroupRepository {
+	return &MockGuestGroupRepository{groups: make(map[models.ID]*models.GuestGroup)}
+}
+
+func (m *MockGuestGroupRepository) Create(ctx context.Context, group *models.GuestGroup) error {
+	group.ID = models.NewID()
+	copied := *group
+	m.groups[group.ID] = &copied
+	return nil
+}
+
+func (m *MockGuestGroupRepository) GetByID(ctx context.Context, id models.ID) (*models.GuestGroup, error) {
+	group, ok := m.groups[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	copied := *group
+	return &copied, nil
+}
+
+func (m *MockGuestGroupRepository) ListByWedding(ctx context.Context, weddingID models.ID) ([]*models.GuestGroup, error) {
+	groups := []*models.GuestGroup{}
+	for _, group := range m.groups {
+		if group.WeddingID == weddingID {
+			copied := *group
+			groups = append(groups, &copied)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, nil
+}
+
+func (m *MockGuestGroupRepository) Update(ctx context.Context, group *models.GuestGroup) error {
+	if _, ok := m.groups[group.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	copied := *group
+	m.groups[group.ID] = &copied
+	return nil
+}
+
+func (m *MockGuestGroupRepository) Delete(ctx context.Context, id models.ID) error {
+	if _, ok := m.groups[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.groups, id)
+	return nil
+}
+
+// guestGroupFixture is a wedding with three guests and a service managing their groups
+type guestGroupFixture struct {
+	ownerID  models.ID
+	wedding  *models.Wedding
+	weddings *MockWeddingRepository
+	guests   *MockGuestRepository
+	groups   *MockGuestGroupRepository
+	service  *GuestGroupService
+	john     *models.Guest
+	mary     *models.Guest
+	tom      *models.Guest
+}
+
+func newGuestGroupFixture(t *testing.T) *guestGroupFixture {
+	f := &guestGroupFixture{
+		ownerID: models.NewID(),
+		guests:  NewMockGuestRepository(),
+		groups:  NewMockGuestGroupRepository(),
+	}
+	f.wedding = &models.Wedding{ID: models.NewID(), UserID: f.ownerID}
+	f.weddings = new(MockWeddingRepository)
+	f.weddings.On("GetByID", context.Background(), f.wedding.ID).Return(f.wedding, nil)
+	f.service = NewGuestGroupService(f.groups, f.guests, f.weddings)
+
+	f.john = &models.Guest{WeddingID: f.wedding.ID, FirstName: "John", LastName: "Doe"}
+	f.mary = &models.Guest{WeddingID: f.wedding.ID, FirstName: "Mary", LastName: "Doe"}
+	f.tom = &models.Guest{WeddingID: f.wedding.ID, FirstName: "Tom", LastName: "Smith"}
+	for _, guest := range []*models.Guest{f.john, f.mary, f.tom} {
+		require.NoError(t, f.guests.Create(context.Background(), guest))
+	}
+	return f
+}
+
+func TestGuestGroupService_CreateGroup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - members join the group and the first one is primary", func(t *testing.T) {
+		f := newGuestGroupFixture(t)
+
+		group, err := f.service.CreateGroup(ctx, f.wedding.ID, f.ownerID, GuestGroupRequest{
+			Name:     " The Does ",
+			GuestIDs: []models.ID{f.john.ID, f.mary.ID, f.john.ID},
+			Table:    "7",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "The Does", group.Name)
+		assert.Equal(t, f.john.ID, group.PrimaryGuestID)
+		assert.Equal(t, f.ownerID, group.CreatedBy)
+		assert.Len(t, group.Members, 2)
+		assert.Contains(t, f.groups.groups, group.ID)
+
+		require.NotNil(t, f.john.GroupID)
+		assert.Equal(t, group.ID, *f.john.GroupID)
+		require.NotNil(t, f.mary.GroupID)
+		assert.Equal(t, group.ID, *f.mary.GroupID)
+		assert.Nil(t, f.tom.GroupID)
+	})
+
+	t.Run("Error - guest already in another group", func(t *testing.T) {
+		f := newGuestGroupFixture(t)
+		_, err := f.service.CreateGroup(ctx, f.wedding.ID, f.ownerID, GuestGroupRequest{Name: "Does", GuestIDs: []models.ID{f.john.ID}})
+		require.NoError(t, err)
+
+		_, err = f.service.CreateGroup(ctx, f.wedding.ID, f.ownerID, GuestGroupRequest{Name: "Friends", GuestIDs: []models.ID{f.tom.ID, f.john.ID}})
+		assert.ErrorIs(t, err, ErrGuestAlreadyGrouped)
+		assert.Nil(t, f.tom.GroupID)
+		assert.Len(t, f.groups.groups, 1)
+	})
+
+	t.Run("Error - invalid groups", func(t *testing.T) {
+		f := newGuestGroupFixture(t)
+		outsider := models.NewID()
+
+		requests := map[string]GuestGroupRequest{
+			"blank name":             {Name: " ", GuestIDs: []models.ID{f.john.ID}},
+			"unknown guest":          {Name: "Does", GuestIDs: []models.ID{f.john.ID, models.NewID()}},
+			"primary is not member":  {Name: "Does", GuestIDs: []models.ID{f.john.ID}, PrimaryGuestID: &f.mary.ID},
+			"no guests":              {Name: "Does"},
+			"guest of other wedding": {Name: "Does", GuestIDs: []models.ID{outsider}},
+		}
+		for name, req := range requests {
+			_, err := f.service.CreateGroup(ctx, f.wedding.ID, f.ownerID, req)
+			assert.ErrorIs(t, err, ErrInvalidGuestGroup, name)
+		}
+		assert.Empty(t, f.groups.groups)
+	})
+
+	t.Run("Error - cannot manage guests", func(t *testing.T) {
+		f := newGuestGroupFixture(t)
+		_, err := f.service.CreateGroup(ctx, f.wedding.ID, models.NewID(), GuestGroupRequest{Name: "Does", GuestIDs: []models.ID{f.john.ID}})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+}
+
+func TestGuestGroupService_UpdateGroup(t *testing.T) {
+	ctx := context.Background()
+	f := newGuestGroupFixture(t)
+	group, err := f.service.CreateGroup(ctx, f.wedding.ID, f.ownerID, GuestGroupRequest{Name: "Does", GuestIDs: []models.ID{f.john.ID, f.mary.ID}})
+	require.NoError(t, err)
+
+	updated, err := f.service.UpdateGroup(ctx, f.wedding.ID, group.ID, f.ownerID, GuestGroupRequest{
+		Name:           "Doe & Smith",
+		GuestIDs:       []models.ID{f.mary.ID, f.tom.ID},
+		PrimaryGuestID: &f.tom.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Doe & Smith", updated.Name)
+	assert.Equal(t, f.tom.ID, updated.PrimaryGuestID)
+	assert.Equal(t, "Doe & Smith", f.groups.groups[group.ID].Name)
+
+	// John was left out of the household
+	assert.Nil(t, f.john.GroupID)
+	require.NotNil(t, f.tom.GroupID)
+	assert.Equal(t, group.ID, *f.tom.GroupID)
+
+	detail, err := f.service.GetGroup(ctx, f.wedding.ID, group.ID, f.ownerID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []models.ID{f.mary.ID, f.tom.ID}, guestIDs(detail.Members))
+
+	// Groups are only found through their own wedding
+	other := &models.Wedding{ID: models.NewID(), UserID: f.ownerID}
+	f.weddings.On("GetByID", ctx, other.ID).Return(other, nil)
+	_, err = f.service.GetGroup(ctx, other.ID, group.ID, f.ownerID)
+	assert.ErrorIs(t, err, ErrGuestGroupNotFound)
+	_, err = f.service.UpdateGroup(ctx, f.wedding.ID, models.NewID(), f.ownerID, GuestGroupRequest{Name: "Does", GuestIDs: []models.ID{f.john.ID}})
+	assert.ErrorIs(t, err, ErrGuestGroupNotFound)
+}
+
+func TestGuestGroupService_ListAndDeleteGroups(t *testing.T) {
+	ctx := context.Background()
+	f := newGuestGroupFixture(t)
+	does, err := f.service.CreateGroup(ctx, f.wedding.ID, f.ownerID, GuestGroupRequest{Name: "Does", GuestIDs: []models.ID{f.john.ID, f.mary.ID}})
+	require.NoError(t, err)
+	smiths, err := f.service.CreateGroup(ctx, f.wedding.ID, f.ownerID, GuestGroupRequest{Name: "Smiths", GuestIDs: []models.ID{f.tom.ID}})
+	require.NoError(t, err)
+
+	groups, err := f.service.ListGroups(ctx, f.wedding.ID, f.ownerID)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, does.ID, groups[0].ID)
+	assert.ElementsMatch(t, []models.ID{f.john.ID, f.mary.ID}, guestIDs(groups[0].Members))
+	assert.Equal(t, []models.ID{f.tom.ID}, guestIDs(groups[1].Members))
+
+	require.NoError(t, f.service.DeleteGroup(ctx, f.wedding.ID, does.ID, f.ownerID))
+	assert.NotContains(t, f.groups.groups, does.ID)
+	assert.Nil(t, f.john.GroupID)
+	assert.Nil(t, f.mary.GroupID)
+	assert.NotNil(t, f.tom.GroupID)
+	assert.Contains(t, f.guests.guests, f.john.ID)
+
+	assert.ErrorIs(t, f.service.DeleteGroup(ctx, f.wedding.ID, does.ID, f.ownerID), ErrGuestGroupNotFound)
+	assert.ErrorIs(t, f.service.DeleteGroup(ctx, f.wedding.ID, smiths.ID, models.NewID()), ErrUnauthorized)
+}
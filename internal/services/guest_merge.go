@@ -0,0 +1,483 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Limits of duplicate detection
+const (
+	// maxDuplicateScanGuests bounds the guests compared pairwise by name
+	maxDuplicateScanGuests = 5000
+	// maxGuestDuplicates bounds the pairs reported at once
+	maxGuestDuplicates = 500
+)
+
+// Weights of the reasons two guests are reported as duplicates
+var duplicateReasonScores = map[string]int{
+	models.DuplicateReasonEmail:       3,
+	models.DuplicateReasonPhone:       3,
+	models.DuplicateReasonName:        2,
+	models.DuplicateReasonSimilarName: 1,
+}
+
+// invitationStatusRank orders invitation statuses by how far the invitation got
+var invitationStatusRank = map[string]int{
+	models.InvitationStatusPending:   0,
+	models.InvitationStatusFailed:    1,
+	models.InvitationStatusSent:      2,
+	models.InvitationStatusDelivered: 3,
+	models.InvitationStatusRead:      4,
+}
+
+// ErrInvalidGuestMerge is returned for guests that cannot be merged
+var ErrInvalidGuestMerge = errors.New("invalid guest merge")
+
+// GuestMergeRequest names the guest to keep and the duplicate merged into it
+type GuestMergeRequest struct {
+	GuestID     models.ID `json:"guest_id" validate:"required"`
+	DuplicateID models.ID `json:"duplicate_id" validate:"required"`
+}
+
+// GuestMergeService finds guests entered more than once, typically by
+// overlapping imports, and merges them into one record
+type GuestMergeService struct {
+	guestRepo   repository.GuestRepository
+	rsvpRepo    repository.RSVPRepository
+	groupRepo   repository.GuestGroupRepository
+	weddingRepo repository.WeddingRepository
+	audit       AuditRecorder
+}
+
+// NewGuestMergeService creates a new guest merge service
+func NewGuestMergeService(guestRepo repository.GuestRepository, rsvpRepo repository.RSVPRepository, groupRepo repository.GuestGroupRepository, weddingRepo repository.WeddingRepository) *GuestMergeService {
+	return &GuestMergeService{
+		guestRepo:   guestRepo,
+		rsvpRepo:    rsvpRepo,
+		groupRepo:   groupRepo,
+		weddingRepo: weddingRepo,
+	}
+}
+
+// SetAuditLog records merges in the audit log
+func (s *GuestMergeService) SetAuditLog(audit AuditRecorder) {
+	s.audit = audit
+}
+
+// FindDuplicates lists pairs of guests of a wedding that share an email or
+// phone number, or whose names are the same or nearly the same, best
+// matches first
+func (s *GuestMergeService) FindDuplicates(ctx context.Context, weddingID, userID models.ID) ([]*models.GuestDuplicate, error) {
+	if _, err := s.getManagedWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	var guests []*models.Guest
+	err := s.guestRepo.StreamByWedding(ctx, weddingID, repository.GuestFilters{}, func(guest *models.Guest) error {
+		guests = append(guests, guest)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list guests: %w", err)
+	}
+
+	return findGuestDuplicates(guests), nil
+}
+
+// guestPair identifies two guests by their index, lower first
+type guestPair struct{ a, b int }
+
+func findGuestDuplicates(guests []*models.Guest) []*models.GuestDuplicate {
+	reasons := make(map[guestPair][]string)
+	addReason := func(a, b int, reason string) {
+		if a > b {
+			a, b = b, a
+		}
+		pair := guestPair{a, b}
+		for _, r := range reasons[pair] {
+			if r == reason {
+				return
+			}
+		}
+		reasons[pair] = append(reasons[pair], reason)
+	}
+
+	// Equal keys are found through an index; only names are compared pairwise
+	byEmail := make(map[string][]int)
+	byPhone := make(map[string][]int)
+	names := make([]guestName, len(guests))
+	for i, guest := range guests {
+		if email := normalizeContactEmail(guest.Email); email != "" {
+			for _, j := range byEmail[email] {
+				addReason(j, i, models.DuplicateReasonEmail)
+			}
+			byEmail[email] = append(byEmail[email], i)
+		}
+		if phone := models.GuestPhoneKey(guest.Phone); phone != "" {
+			for _, j := range byPhone[phone] {
+				addReason(j, i, models.DuplicateReasonPhone)
+			}
+			byPhone[phone] = append(byPhone[phone], i)
+		}
+		names[i] = newGuestName(guest.FirstName + " " + guest.LastName)
+	}
+
+	if len(guests) <= maxDuplicateScanGuests {
+		for i := range guests {
+			for j := i + 1; j < len(guests); j++ {
+				if reason := compareGuestNames(names[i], names[j]); reason != "" {
+					addReason(i, j, reason)
+				}
+			}
+		}
+	}
+
+	duplicates := make([]*models.GuestDuplicate, 0, len(reasons))
+	for pair, pairReasons := range reasons {
+		guest, duplicate := guests[pair.a], guests[pair.b]
+		if duplicate.CreatedAt.Before(guest.CreatedAt) {
+			guest, duplicate = duplicate, guest
+		}
+		score := 0
+		for _, reason := range pairReasons {
+			score += duplicateReasonScores[reason]
+		}
+		sort.Strings(pairReasons)
+		duplicates = append(duplicates, &models.GuestDuplicate{Guest: guest, Duplicate: duplicate, Reasons: pairReasons, Score: score})
+	}
+
+	sort.Slice(duplicates, func(i, j int) bool {
+		if duplicates[i].Score != duplicates[j].Score {
+			return duplicates[i].Score > duplicates[j].Score
+		}
+		if !duplicates[i].Guest.CreatedAt.Equal(duplicates[j].Guest.CreatedAt) {
+			return duplicates[i].Guest.CreatedAt.Before(duplicates[j].Guest.CreatedAt)
+		}
+		return duplicates[i].Duplicate.CreatedAt.Before(duplicates[j].Duplicate.CreatedAt)
+	})
+	if len(duplicates) > maxGuestDuplicates {
+		duplicates = duplicates[:maxGuestDuplicates]
+	}
+	return duplicates
+}
+
+// guestName is a full name lowercased to its letters and digits, with single
+// spaces between words, and the same words in alphabetical order
+type guestName struct {
+	normalized string
+	sorted     string
+}
+
+func newGuestName(name string) guestName {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	normalized := strings.Join(words, " ")
+	sort.Strings(words)
+	return guestName{normalized: normalized, sorted: strings.Join(words, " ")}
+}
+
+// compareGuestNames tells whether two names are the same, also with their
+// words in another order, or differ by a typo or two
+func compareGuestNames(x, y guestName) string {
+	a, b := x.normalized, y.normalized
+	if a == "" || b == "" {
+		return ""
+	}
+	if a == b || x.sorted == y.sorted {
+		return models.DuplicateReasonName
+	}
+
+	// Short names differ by a letter from many unrelated names
+	allowed := 1
+	if len(a) >= 12 && len(b) >= 12 {
+		allowed = 2
+	}
+	if len(a) < 6 || len(b) < 6 || absInt(len(a)-len(b)) > allowed {
+		return ""
+	}
+	if levenshtein(a, b) <= allowed {
+		return models.DuplicateReasonSimilarName
+	}
+	return ""
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// levenshtein counts the single-letter edits that turn a into b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// MergeGuests merges the duplicate into the guest and deletes it. The guest
+// keeps its own details and takes the duplicate's where it has none. Notes and
+// tags are combined and the invitation that got furthest is kept. The
+// duplicate's RSVP replaces a missing or pending one of the guest, and RSVPs
+// and households that pointed to the duplicate point to the guest.
+func (s *GuestMergeService) MergeGuests(ctx context.Context, weddingID, userID models.ID, req GuestMergeRequest) (*models.Guest, error) {
+	if _, err := s.getManagedWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+	if req.GuestID == req.DuplicateID {
+		return nil, fmt.Errorf("%w: a guest cannot be merged into itself", ErrInvalidGuestMerge)
+	}
+
+	guest, err := s.getGuest(ctx, weddingID, req.GuestID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := s.getGuest(ctx, weddingID, req.DuplicateID)
+	if err != nil {
+		return nil, err
+	}
+	if guest.GroupID != nil && duplicate.GroupID != nil && *guest.GroupID != *duplicate.GroupID {
+		return nil, fmt.Errorf("%w: the guests belong to different groups", ErrInvalidGuestMerge)
+	}
+
+	before := auditFields(guest)
+	mergeGuestRecords(guest, duplicate)
+
+	if err := s.relinkRSVPs(ctx, weddingID, duplicate.ID, guest.ID); err != nil {
+		return nil, err
+	}
+	if err := s.relinkGroup(ctx, duplicate, guest.ID); err != nil {
+		return nil, err
+	}
+	if err := s.guestRepo.Update(ctx, guest); err != nil {
+		return nil, fmt.Errorf("failed to update guest: %w", err)
+	}
+	if err := s.guestRepo.Delete(ctx, duplicate.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete duplicate guest: %w", err)
+	}
+
+	s.recordAudit(ctx, weddingID, guest.ID, auditChanges(before, auditFields(guest)), duplicate)
+	return guest, nil
+}
+
+// mergeGuestRecords folds the duplicate's details into the guest
+func mergeGuestRecords(guest, duplicate *models.Guest) {
+	fill := func(field *string, value string) {
+		if strings.TrimSpace(*field) == "" {
+			*field = value
+		}
+	}
+	fill(&guest.Email, duplicate.Email)
+	fill(&guest.Phone, duplicate.Phone)
+	fill(&guest.Relationship, duplicate.Relationship)
+	fill(&guest.Side, duplicate.Side)
+	if guest.Address == nil {
+		guest.Address = duplicate.Address
+	}
+	if guest.GroupID == nil {
+		guest.GroupID = duplicate.GroupID
+	}
+	if guest.CheckIn == nil {
+		guest.CheckIn = duplicate.CheckIn
+	}
+
+	guest.Notes = joinGuestText(guest.Notes, duplicate.Notes)
+	guest.DietaryNotes = joinGuestText(guest.DietaryNotes, duplicate.DietaryNotes)
+	guest.Tags = mergeTags(guest.Tags, duplicate.Tags)
+	guest.VIP = guest.VIP || duplicate.VIP
+	guest.AllowPlusOne = guest.AllowPlusOne || duplicate.AllowPlusOne
+	guest.MaxPlusOnes = max(guest.MaxPlusOnes, duplicate.MaxPlusOnes)
+
+	// The invitation that got furthest is the one the guest received
+	if invitationAhead(duplicate, guest) {
+		guest.InvitationStatus = duplicate.InvitationStatus
+		guest.InvitationSentAt = duplicate.InvitationSentAt
+		guest.InvitationError = duplicate.InvitationError
+		guest.InvitationChannel = duplicate.InvitationChannel
+		guest.InvitationMessageID = duplicate.InvitationMessageID
+	}
+
+	// An answer of the duplicate beats no answer of the guest
+	if duplicate.RSVPID != nil && (guest.RSVPID == nil || guest.RSVPStatus == "" || guest.RSVPStatus == "pending") {
+		guest.RSVPID = duplicate.RSVPID
+		guest.RSVPStatus = duplicate.RSVPStatus
+		guest.Companions = duplicate.Companions
+	}
+}
+
+func invitationAhead(a, b *models.Guest) bool {
+	rankA, rankB := invitationStatusRank[a.InvitationStatus], invitationStatusRank[b.InvitationStatus]
+	if rankA != rankB {
+		return rankA > rankB
+	}
+	return a.InvitationSentAt != nil && (b.InvitationSentAt == nil || a.InvitationSentAt.After(*b.InvitationSentAt))
+}
+
+func joinGuestText(a, b string) string {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	switch {
+	case b == "" || strings.Contains(a, b):
+		return a
+	case a == "" || strings.Contains(b, a):
+		return b
+	default:
+		return a + "\n" + b
+	}
+}
+
+func mergeTags(a, b []string) []string {
+	tags := append([]string(nil), a...)
+	for _, tag := range b {
+		if !containsString(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) > maxGuestTags {
+		tags = tags[:maxGuestTags]
+	}
+	return tags
+}
+
+// relinkRSVPs points the RSVPs of the duplicate, and the household answers
+// given for it, to the guest
+func (s *GuestMergeService) relinkRSVPs(ctx context.Context, weddingID, duplicateID, guestID models.ID) error {
+	var linked []*models.RSVP
+	err := s.rsvpRepo.StreamByWedding(ctx, weddingID, func(rsvp *models.RSVP) error {
+		if rsvp.GuestID != nil && *rsvp.GuestID == duplicateID {
+			linked = append(linked, rsvp)
+			return nil
+		}
+		for _, member := range rsvp.Members {
+			if member.GuestID == duplicateID {
+				linked = append(linked, rsvp)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list RSVPs: %w", err)
+	}
+
+	for _, rsvp := range linked {
+		if rsvp.GuestID != nil && *rsvp.GuestID == duplicateID {
+			id := guestID
+			rsvp.GuestID = &id
+		}
+		members := rsvp.Members[:0]
+		answered := false
+		for _, member := range rsvp.Members {
+			if member.GuestID == guestID {
+				answered = true
+			}
+		}
+		for _, member := range rsvp.Members {
+			if member.GuestID == duplicateID {
+				if answered {
+					continue
+				}
+				member.GuestID, answered = guestID, true
+			}
+			members = append(members, member)
+		}
+		rsvp.Members = members
+		if err := s.rsvpRepo.Update(ctx, rsvp); err != nil {
+			return fmt.Errorf("failed to update RSVP: %w", err)
+		}
+	}
+	return nil
+}
+
+// relinkGroup addresses the duplicate's household to the guest when the
+// duplicate was its primary member
+func (s *GuestMergeService) relinkGroup(ctx context.Context, duplicate *models.Guest, guestID models.ID) error {
+	if duplicate.GroupID == nil {
+		return nil
+	}
+	group, err := s.groupRepo.GetByID(ctx, *duplicate.GroupID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get guest group: %w", err)
+	}
+	if group.PrimaryGuestID != duplicate.ID {
+		return nil
+	}
+	group.PrimaryGuestID = guestID
+	if err := s.groupRepo.Update(ctx, group); err != nil {
+		return fmt.Errorf("failed to update guest group: %w", err)
+	}
+	return nil
+}
+
+func (s *GuestMergeService) getGuest(ctx context.Context, weddingID, guestID models.ID) (*models.Guest, error) {
+	guest, err := s.guestRepo.GetByID(ctx, guestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrGuestNotFound
+		}
+		return nil, fmt.Errorf("failed to get guest: %w", err)
+	}
+	if guest == nil || guest.WeddingID != weddingID {
+		return nil, ErrGuestNotFound
+	}
+	return guest, nil
+}
+
+func (s *GuestMergeService) getManagedWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	if !wedding.Can(userID, models.PermissionManageGuests) {
+		return nil, ErrUnauthorized
+	}
+	return wedding, nil
+}
+
+func (s *GuestMergeService) recordAudit(ctx context.Context, weddingID, guestID models.ID, changes []models.AuditChange, duplicate *models.Guest) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, &models.AuditLog{
+		Action:     models.AuditGuestMerge,
+		TargetType: models.AuditTargetGuest,
+		TargetID:   guestID.String(),
+		WeddingID:  &weddingID,
+		Changes:    changes,
+		Metadata: map[string]interface{}{
+			"duplicate_id": duplicate.ID.String(),
+			"duplicate":    auditFields(duplicate),
+		},
+	})
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func TestFindGuestDuplicates(t *testing.T) {
+	now := time.Now()
+	guest := func(minutes int, first, last, email, phone string) *models.Guest {
+		return &models.Guest{
+			ID: models.NewID(), FirstName: first, LastName: last, Email: email, Phone: phone,
+			CreatedAt: now.Add(time.Duration(minutes) * time.Minute),
+		}
+	}
+	john := guest(0, "John", "Doe", "john@example.com", "")
+	johnImported := guest(5, "Jon", "Doe", "JOHN@example.com ", "")
+	mary := guest(1, "Mary", "Smith", "", "+1 555 010 2030")
+	marySmith := guest(6, "Smith", "Mary", "", "(555) 010-2030")
+	jonathan := guest(2, "Jonathan", "Miller", "", "")
+	jonathon := guest(7, "Jonathon", "Miller", "", "")
+	ann := guest(3, "Ann", "Lee", "", "")
+	ben := guest(4, "Ben", "Lee", "", "")
+
+	duplicates := findGuestDuplicates([]*models.Guest{johnImported, marySmith, jonathon, john, mary, jonathan, ann, ben})
+	require.Len(t, duplicates, 3)
+
+	assert.Same(t, mary, duplicates[0].Guest)
+	assert.Same(t, marySmith, duplicates[0].Duplicate)
+	assert.Equal(t, []string{models.DuplicateReasonName, models.DuplicateReasonPhone}, duplicates[0].Reasons)
+	assert.Equal(t, 5, duplicates[0].Score)
+
+	assert.Same(t, john, duplicates[1].Guest)
+	assert.Same(t, johnImported, duplicates[1].Duplicate)
+	assert.Equal(t, []string{models.DuplicateReasonEmail, models.DuplicateReasonSimilarName}, duplicates[1].Reasons)
+	assert.Equal(t, 4, duplicates[1].Score)
+
+	assert.Same(t, jonathan, duplicates[2].Guest)
+	assert.Equal(t, []string{models.DuplicateReasonSimilarName}, duplicates[2].Reasons)
+	assert.Equal(t, 1, duplicates[2].Score)
+}
+
+func TestGuestMergeService_MergeGuests(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+	wedding := &models.Wedding{ID: models.NewID(), UserID: ownerID}
+	sentAt := time.Now().Add(-time.Hour)
+
+	setup := func(t *testing.T) (*GuestMergeService, *MockGuestRepository, *MockRSVPRepository, *MockGuestGroupRepository, *models.Guest, *models.Guest) {
+		guests := NewMockGuestRepository()
+		rsvps := NewMockRSVPRepository()
+		groups := NewMockGuestGroupRepository()
+		weddings := new(MockWeddingRepository)
+		weddings.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+
+		keep := &models.Guest{
+			WeddingID: wedding.ID, FirstName: "John", LastName: "Doe", Email: "john@example.com",
+			Notes: "Groom's cousin", Tags: []string{"family"}, InvitationStatus: models.InvitationStatusPending,
+		}
+		duplicate := &models.Guest{
+			WeddingID: wedding.ID, FirstName: "Jon", LastName: "Doe", Phone: "555-010-2030",
+			Notes: "Imported from phone", Tags: []string{"family", "college"}, VIP: true, MaxPlusOnes: 2,
+			InvitationStatus: models.InvitationStatusDelivered, InvitationSentAt: &sentAt,
+			InvitationChannel: models.InvitationChannelWhatsApp, InvitationMessageID: "wamid.1",
+		}
+		require.NoError(t, guests.Create(ctx, keep))
+		require.NoError(t, guests.Create(ctx, duplicate))
+		return NewGuestMergeService(guests, rsvps, groups, weddings), guests, rsvps, groups, keep, duplicate
+	}
+
+	t.Run("Success - details, invitation, RSVP and household move to the guest", func(t *testing.T) {
+		service, guests, rsvps, groups, keep, duplicate := setup(t)
+
+		rsvpID := models.NewID()
+		duplicateID := duplicate.ID
+		require.NoError(t, rsvps.Create(ctx, &models.RSVP{ID: rsvpID, WeddingID: wedding.ID, GuestID: &duplicateID, Status: "attending"}))
+		duplicate.RSVPID = &rsvpID
+		duplicate.RSVPStatus = "attending"
+
+		group := &models.GuestGroup{WeddingID: wedding.ID, Name: "Does", PrimaryGuestID: duplicate.ID}
+		require.NoError(t, groups.Create(ctx, group))
+		duplicate.GroupID = &group.ID
+		household := &models.RSVP{ID: models.NewID(), WeddingID: wedding.ID, Members: []models.RSVPMemberStatus{{GuestID: duplicate.ID, Status: "maybe"}}}
+		require.NoError(t, rsvps.Create(ctx, household))
+
+		merged, err := service.MergeGuests(ctx, wedding.ID, ownerID, GuestMergeRequest{GuestID: keep.ID, DuplicateID: duplicate.ID})
+		require.NoError(t, err)
+
+		assert.Equal(t, keep.ID, merged.ID)
+		assert.Equal(t, "John", merged.FirstName)
+		assert.Equal(t, "john@example.com", merged.Email)
+		assert.Equal(t, "555-010-2030", merged.Phone)
+		assert.Equal(t, "Groom's cousin\nImported from phone", merged.Notes)
+		assert.Equal(t, []string{"family", "college"}, merged.Tags)
+		assert.True(t, merged.VIP)
+		assert.Equal(t, 2, merged.MaxPlusOnes)
+		assert.Equal(t, models.InvitationStatusDelivered, merged.InvitationStatus)
+		assert.Equal(t, "wamid.1", merged.InvitationMessageID)
+		require.NotNil(t, merged.RSVPID)
+		assert.Equal(t, rsvpID, *merged.RSVPID)
+		assert.Equal(t, "attending", merged.RSVPStatus)
+		require.NotNil(t, merged.GroupID)
+		assert.Equal(t, group.ID, *merged.GroupID)
+
+		assert.Equal(t, keep.ID, *rsvps.rsvps[rsvpID].GuestID)
+		assert.Equal(t, keep.ID, rsvps.rsvps[household.ID].Members[0].GuestID)
+		assert.Equal(t, keep.ID, groups.groups[group.ID].PrimaryGuestID)
+		assert.NotContains(t, guests.guests, duplicate.ID)
+	})
+
+	t.Run("Error - guests of different groups", func(t *testing.T) {
+		service, guests, _, _, keep, duplicate := setup(t)
+		first, second := models.NewID(), models.NewID()
+		keep.GroupID, duplicate.GroupID = &first, &second
+
+		_, err := service.MergeGuests(ctx, wedding.ID, ownerID, GuestMergeRequest{GuestID: keep.ID, DuplicateID: duplicate.ID})
+		assert.ErrorIs(t, err, ErrInvalidGuestMerge)
+		assert.Contains(t, guests.guests, duplicate.ID)
+	})
+
+	t.Run("Error - invalid merges", func(t *testing.T) {
+		service, _, _, _, keep, duplicate := setup(t)
+
+		_, err := service.MergeGuests(ctx, wedding.ID, ownerID, GuestMergeRequest{GuestID: keep.ID, DuplicateID: keep.ID})
+		assert.ErrorIs(t, err, ErrInvalidGuestMerge)
+		_, err = service.MergeGuests(ctx, wedding.ID, ownerID, GuestMergeRequest{GuestID: keep.ID, DuplicateID: models.NewID()})
+		assert.ErrorIs(t, err, ErrGuestNotFound)
+		_, err = service.MergeGuests(ctx, wedding.ID, models.NewID(), GuestMergeRequest{GuestID: keep.ID, DuplicateID: duplicate.ID})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// QR code image formats
+const (
+	QRFormatPNG = "png"
+	QRFormatSVG = "svg"
+)
+
+const (
+	// DefaultQRSize is the width and height in pixels of a QR code when none is requested
+	DefaultQRSize = 256
+	minQRSize     = 64
+	maxQRSize     = 2048
+)
+
+var (
+	ErrInvalidQROptions = errors.New("invalid QR code options")
+	ErrInvalidGuestLink = errors.New("invalid or expired guest link")
+)
+
+// QROptions selects how QR codes are rendered
+type QROptions struct {
+	Format string
+	Size   int
+}
+
+// QRCode is a rendered guest QR code
+type QRCode struct {
+	Guest       *models.Guest
+	Data        []byte
+	ContentType string
+	Filename    string
+}
+
+// GuestRSVPPrefill is what a signed guest link reveals to the public RSVP form
+type GuestRSVPPrefill struct {
+	WeddingID    models.ID `json:"wedding_id"`
+	GuestID      models.ID `json:"guest_id"`
+	FirstName    string    `json:"first_name"`
+	LastName     string    `json:"last_name"`
+	Email        string    `json:"email,omitempty"`
+	Phone        string    `json:"phone,omitempty"`
+	AllowPlusOne bool      `json:"allow_plus_one"`
+	MaxPlusOnes  int       `json:"max_plus_ones"`
+	RSVPStatus   string    `json:"rsvp_status,omitempty"`
+	DietaryNotes string    `json:"dietary_notes,omitempty"`
+	// Household lists the other members of a grouped guest, whom the RSVP answers for
+	Household []GuestRSVPPrefillMember `json:"household,omitempty"`
+}
+
+// GuestRSVPPrefillMember is a household member shown on the RSVP form
+type GuestRSVPPrefillMember struct {
+	GuestID      models.ID `json:"guest_id"`
+	FirstName    string    `json:"first_name"`
+	LastName     string    `json:"last_name"`
+	AllowPlusOne bool      `json:"allow_plus_one"`
+	MaxPlusOnes  int       `json:"max_plus_ones"`
+	RSVPStatus   string    `json:"rsvp_status,omitempty"`
+}
+
+// GuestQRService renders QR codes of personalized RSVP links. Each link carries a
+// token signed with the server secret, so scanning it identifies the guest
+// without exposing guessable IDs and the public RSVP form can be pre-filled.
+type GuestQRService struct {
+	guestRepo   repository.GuestRepository
+	weddingRepo repository.WeddingRepository
+	secret      string
+	siteURL     string
+}
+
+// NewGuestQRService creates a new guest QR code service. secret signs the guest
+// tokens and siteURL is the frontend the links point to.
+func NewGuestQRService(guestRepo repository.GuestRepository, weddingRepo repository.WeddingRepository, secret, siteURL string) *GuestQRService {
+	if siteURL == "" {
+		siteURL = DefaultInvitationOptions().SiteURL
+	}
+	return &GuestQRService{
+		guestRepo:   guestRepo,
+		weddingRepo: weddingRepo,
+		secret:      secret,
+		siteURL:     strings.TrimRight(siteURL, "/"),
+	}
+}
+
+// WeddingLink returns the link to the wedding's public invitation page
+func (s *GuestQRService) WeddingLink(wedding *models.Wedding) string {
+	return fmt.Sprintf("%s/w/%s", s.siteURL, url.PathEscape(wedding.Slug))
+}
+
+// RSVPLink returns the guest's signed link to the wedding's RSVP form
+func (s *GuestQRService) RSVPLink(wedding *models.Wedding, guest *models.Guest) string {
+	query := url.Values{}
+	query.Set("t", utils.SignGuestToken(s.secret, guest.ID))
+	return fmt.Sprintf("%s?%s#rsvp", s.WeddingLink(wedding), query.Encode())
+}
+
+// GuestQRCode renders the QR code of a guest's RSVP link
+func (s *GuestQRService) GuestQRCode(ctx context.Context, guestID, userID models.ID, opts QROptions) (*QRCode, error) {
+	opts, err := normalizeQROptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	guest, err := s.guestRepo.GetByID(ctx, guestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrGuestNotFound
+		}
+		return nil, err
+	}
+
+	wedding, err := s.getManagedWedding(ctx, guest.WeddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.render(wedding, guest, opts)
+}
+
+// StreamQRCodes renders the QR code of every guest of a wedding matching filters and
+// passes them to fn one at a time
+func (s *GuestQRService) StreamQRCodes(ctx context.Context, weddingID, userID models.ID, filters repository.GuestFilters, opts QROptions, fn func(*QRCode) error) error {
+	opts, err := normalizeQROptions(opts)
+	if err != nil {
+		return err
+	}
+
+	wedding, err := s.getManagedWedding(ctx, weddingID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.guestRepo.StreamByWedding(ctx, weddingID, filters, func(guest *models.Guest) error {
+		code, err := s.render(wedding, guest, opts)
+		if err != nil {
+			return err
+		}
+		return fn(code)
+	})
+}
+
+// ResolveGuestToken returns the RSVP form data of the guest a signed link points to.
+// Tokens of guests from another wedding are rejected like invalid ones.
+func (s *GuestQRService) ResolveGuestToken(ctx context.Context, weddingID models.ID, token string) (*GuestRSVPPrefill, error) {
+	guestID, err := utils.VerifyGuestToken(s.secret, token)
+	if err != nil {
+		return nil, ErrInvalidGuestLink
+	}
+
+	guest, err := s.guestRepo.GetByID(ctx, guestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidGuestLink
+		}
+		return nil, err
+	}
+	if guest.WeddingID != weddingID {
+		return nil, ErrInvalidGuestLink
+	}
+
+	prefill := &GuestRSVPPrefill{
+		WeddingID:    guest.WeddingID,
+		GuestID:      guest.ID,
+		FirstName:    guest.FirstName,
+		LastName:     guest.LastName,
+		Email:        guest.Email,
+		Phone:        guest.Phone,
+		AllowPlusOne: guest.AllowPlusOne,
+		MaxPlusOnes:  guest.MaxPlusOnes,
+		RSVPStatus:   guest.RSVPStatus,
+		DietaryNotes: guest.DietaryNotes,
+	}
+
+	if guest.GroupID != nil {
+		members, _, err := s.guestRepo.ListByWedding(ctx, weddingID, 0, 0, repository.GuestFilters{GroupID: guest.GroupID})
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			if member.ID == guest.ID {
+				continue
+			}
+			prefill.Household = append(prefill.Household, GuestRSVPPrefillMember{
+				GuestID:      member.ID,
+				FirstName:    member.FirstName,
+				LastName:     member.LastName,
+				AllowPlusOne: member.AllowPlusOne,
+				MaxPlusOnes:  member.MaxPlusOnes,
+				RSVPStatus:   member.RSVPStatus,
+			})
+		}
+	}
+	return prefill, nil
+}
+
+func (s *GuestQRService) getManagedWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	if !wedding.Can(userID, models.PermissionManageGuests) {
+		return nil, ErrUnauthorized
+	}
+	return wedding, nil
+}
+
+func (s *GuestQRService) render(wedding *models.Wedding, guest *models.Guest, opts QROptions) (*QRCode, error) {
+	link := s.RSVPLink(wedding, guest)
+	code := &QRCode{
+		Guest:    guest,
+		Filename: qrFilename(guest, opts.Format),
+	}
+
+	switch opts.Format {
+	case QRFormatSVG:
+		qr, err := qrcode.New(link, qrcode.Medium)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode QR code: %w", err)
+		}
+		code.Data = renderQRSVG(qr.Bitmap(), opts.Size)
+		code.ContentType = "image/svg+xml"
+	default:
+		data, err := qrcode.Encode(link, qrcode.Medium, opts.Size)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode QR code: %w", err)
+		}
+		code.Data = data
+		code.ContentType = "image/png"
+	}
+	return code, nil
+}
+
+func normalizeQROptions(opts QROptions) (QROptions, error) {
+	if opts.Format == "" {
+		opts.Format = QRFormatPNG
+	}
+	if opts.Format != QRFormatPNG && opts.Format != QRFormatSVG {
+		return opts, fmt.Errorf("%w: format must be png or svg", ErrInvalidQROptions)
+	}
+	if opts.Size == 0 {
+		opts.Size = DefaultQRSize
+	}
+	if opts.Size < minQRSize || opts.Size > maxQRSize {
+		return opts, fmt.Errorf("%w: size must be between %d and %d", ErrInvalidQROptions, minQRSize, maxQRSize)
+	}
+	return opts, nil
+}
+
+// qrFilename names a guest's QR code after the guest, with the ID keeping namesakes apart
+func qrFilename(guest *models.Guest, format string) string {
+	name := utils.SanitizeSlug(guest.FirstName + " " + guest.LastName)
+	if name == "" {
+		return guest.ID.String() + "." + format
+	}
+	return name + "-" + guest.ID.String() + "." + format
+}
+
+// renderQRSVG draws a QR bitmap, quiet zone included, as one path of module runs
+func renderQRSVG(bitmap [][]bool, size int) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		size, size, len(bitmap), len(bitmap))
+	buf.WriteString(`<rect width="100%" height="100%" fill="#fff"/><path fill="#000" d="`)
+	for y, row := range bitmap {
+		for x := 0; x < len(row); {
+			if !row[x] {
+				x++
+				continue
+			}
+			run := 1
+			for x+run < len(row) && row[x+run] {
+				run++
+			}
+			fmt.Fprintf(&buf, "M%d %dh%dv1h-%dz", x, y, run, run)
+			x += run
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+	return buf.Bytes()
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+func setupGuestQRService(t *testing.T) (*GuestQRService, *MockGuestRepository, *models.Wedding, *models.Guest) {
+	guestRepo := NewMockGuestRepository()
+	weddingRepo := &MockWeddingRepository{}
+
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		UserID: models.NewID(),
+		Slug:   "alice-and-bob",
+	}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+
+	guest := &models.Guest{
+		WeddingID:    wedding.ID,
+		FirstName:    "Carol",
+		LastName:     "O'Neil",
+		Email:        "carol@example.com",
+		AllowPlusOne: true,
+		MaxPlusOnes:  1,
+		RSVPStatus:   "pending",
+	}
+	require.NoError(t, guestRepo.Create(context.Background(), guest))
+
+	service := NewGuestQRService(guestRepo, weddingRepo, "link-secret", "https://invites.example.com/")
+	return service, guestRepo, wedding, guest
+}
+
+func TestGuestQRService_RSVPLink(t *testing.T) {
+	service, _, wedding, guest := setupGuestQRService(t)
+
+	link, err := url.Parse(service.RSVPLink(wedding, guest))
+	require.NoError(t, err)
+	assert.Equal(t, "https://invites.example.com/w/alice-and-bob", link.Scheme+"://"+link.Host+link.Path)
+	assert.Equal(t, "rsvp", link.Fragment)
+
+	prefill, err := service.ResolveGuestToken(context.Background(), wedding.ID, link.Query().Get("t"))
+	require.NoError(t, err)
+	assert.Equal(t, guest.ID, prefill.GuestID)
+	assert.Equal(t, "Carol", prefill.FirstName)
+	assert.Equal(t, "carol@example.com", prefill.Email)
+	assert.True(t, prefill.AllowPlusOne)
+	assert.Equal(t, 1, prefill.MaxPlusOnes)
+}
+
+func TestGuestQRService_ResolveGuestToken(t *testing.T) {
+	ctx := context.Background()
+	service, guestRepo, wedding, guest := setupGuestQRService(t)
+	token := func(guest *models.Guest) string {
+		link, err := url.Parse(service.RSVPLink(wedding, guest))
+		require.NoError(t, err)
+		return link.Query().Get("t")
+	}
+
+	t.Run("Error - tampered token", func(t *testing.T) {
+		_, err := service.ResolveGuestToken(ctx, wedding.ID, token(guest)+"x")
+		assert.ErrorIs(t, err, ErrInvalidGuestLink)
+	})
+
+	t.Run("Error - guest of another wedding", func(t *testing.T) {
+		_, err := service.ResolveGuestToken(ctx, models.NewID(), token(guest))
+		assert.ErrorIs(t, err, ErrInvalidGuestLink)
+	})
+
+	t.Run("Error - deleted guest", func(t *testing.T) {
+		deleted := &models.Guest{ID: models.NewID(), WeddingID: wedding.ID}
+		_, err := service.ResolveGuestToken(ctx, wedding.ID, token(deleted))
+		assert.ErrorIs(t, err, ErrInvalidGuestLink)
+	})
+
+	t.Run("Error - signed with another secret", func(t *testing.T) {
+		other := NewGuestQRService(guestRepo, nil, "other-secret", "")
+		_, err := other.ResolveGuestToken(ctx, wedding.ID, token(guest))
+		assert.ErrorIs(t, err, ErrInvalidGuestLink)
+	})
+}
+
+func TestGuestQRService_GuestQRCode(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("PNG", func(t *testing.T) {
+		service, _, wedding, guest := setupGuestQRService(t)
+
+		code, err := service.GuestQRCode(ctx, guest.ID, wedding.UserID, QROptions{Size: 128})
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", code.ContentType)
+		assert.Equal(t, "carol-o-neil-"+guest.ID.String()+".png", code.Filename)
+
+		img, err := png.Decode(bytes.NewReader(code.Data))
+		require.NoError(t, err)
+		assert.Equal(t, 128, img.Bounds().Dx())
+	})
+
+	t.Run("SVG", func(t *testing.T) {
+		service, _, wedding, guest := setupGuestQRService(t)
+
+		code, err := service.GuestQRCode(ctx, guest.ID, wedding.UserID, QROptions{Format: QRFormatSVG})
+		require.NoError(t, err)
+		assert.Equal(t, "image/svg+xml", code.ContentType)
+		svg := string(code.Data)
+		assert.True(t, strings.HasPrefix(svg, "<svg "))
+		assert.Contains(t, svg, `width="256"`)
+		assert.Contains(t, svg, "h1v1h-1z")
+	})
+
+	t.Run("Planner can generate codes", func(t *testing.T) {
+		service, _, wedding, guest := setupGuestQRService(t)
+		plannerID := models.NewID()
+		wedding.Collaborators = []models.WeddingCollaborator{{UserID: plannerID, Role: models.WeddingRolePlanner}}
+
+		_, err := service.GuestQRCode(ctx, guest.ID, plannerID, QROptions{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error - not a member", func(t *testing.T) {
+		service, _, _, guest := setupGuestQRService(t)
+
+		_, err := service.GuestQRCode(ctx, guest.ID, models.NewID(), QROptions{})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Error - unknown guest", func(t *testing.T) {
+		service, _, wedding, _ := setupGuestQRService(t)
+
+		_, err := service.GuestQRCode(ctx, models.NewID(), wedding.UserID, QROptions{})
+		assert.ErrorIs(t, err, ErrGuestNotFound)
+	})
+
+	t.Run("Error - invalid options", func(t *testing.T) {
+		service, _, wedding, guest := setupGuestQRService(t)
+
+		_, err := service.GuestQRCode(ctx, guest.ID, wedding.UserID, QROptions{Format: "gif"})
+		assert.ErrorIs(t, err, ErrInvalidQROptions)
+		_, err = service.GuestQRCode(ctx, guest.ID, wedding.UserID, QROptions{Size: 10000})
+		assert.ErrorIs(t, err, ErrInvalidQROptions)
+	})
+}
+
+func TestGuestQRService_StreamQRCodes(t *testing.T) {
+	ctx := context.Background()
+	service, guestRepo, wedding, guest := setupGuestQRService(t)
+	attending := &models.Guest{WeddingID: wedding.ID, FirstName: "Dan", LastName: "Lee", RSVPStatus: "attending"}
+	require.NoError(t, guestRepo.Create(ctx, attending))
+	require.NoError(t, guestRepo.Create(ctx, &models.Guest{WeddingID: models.NewID(), FirstName: "Eve", LastName: "Other"}))
+
+	var all []models.ID
+	err := service.StreamQRCodes(ctx, wedding.ID, wedding.UserID, repository.GuestFilters{}, QROptions{}, func(code *QRCode) error {
+		all = append(all, code.Guest.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []models.ID{guest.ID, attending.ID}, all)
+
+	var filtered []models.ID
+	err = service.StreamQRCodes(ctx, wedding.ID, wedding.UserID, repository.GuestFilters{RSVPStatus: "attending"}, QROptions{}, func(code *QRCode) error {
+		filtered = append(filtered, code.Guest.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []models.ID{attending.ID}, filtered)
+
+	err = service.StreamQRCodes(ctx, wedding.ID, models.NewID(), repository.GuestFilters{}, QROptions{}, func(*QRCode) error { return nil })
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
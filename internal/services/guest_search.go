@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// Limits of guest search
+const (
+	// DefaultGuestSearchLimit is how many guests a search returns unless asked otherwise
+	DefaultGuestSearchLimit = 20
+	// MaxGuestSearchLimit bounds the guests a search returns
+	MaxGuestSearchLimit = 100
+	// maxGuestSearchTerms bounds the words of a query
+	maxGuestSearchTerms = 10
+)
+
+// ErrInvalidGuestSearch is returned for a query without a letter or digit to search for
+var ErrInvalidGuestSearch = errs.InvalidField("q", "search for at least one letter or digit")
+
+// Weights of the fields a term of a query matches, for a whole word and for the
+// start of one
+var guestSearchWeights = []struct {
+	field         string
+	whole, prefix int
+}{
+	{"name", 10, 6},
+	{"email", 5, 3},
+	{"phone", 4, 4},
+	{"notes", 2, 1},
+}
+
+// SearchGuests finds the guests of a wedding by name, email, phone number or
+// notes, best matches first. Each word of the query has to match the start of a
+// word of the guest, regardless of case and diacritics, so partial queries work
+// for typeahead; phone numbers match by any run of their digits.
+func (s *GuestService) SearchGuests(ctx context.Context, weddingID, userID models.ID, query string, limit int) ([]*models.Guest, error) {
+	if err := s.verifyWeddingOwnership(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	terms := guestSearchTerms(query)
+	if len(terms) == 0 {
+		return nil, ErrInvalidGuestSearch
+	}
+	if limit <= 0 {
+		limit = DefaultGuestSearchLimit
+	} else if limit > MaxGuestSearchLimit {
+		limit = MaxGuestSearchLimit
+	}
+
+	candidates, err := s.guestRepo.Search(ctx, weddingID, terms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search guests: %w", err)
+	}
+
+	scores := make(map[models.ID]int, len(candidates))
+	matches := make([]*models.Guest, 0, len(candidates))
+	for _, guest := range candidates {
+		if score := scoreGuestSearch(guest, terms); score > 0 {
+			scores[guest.ID] = score
+			matches = append(matches, guest)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if scores[a.ID] != scores[b.ID] {
+			return scores[a.ID] > scores[b.ID]
+		}
+		if !strings.EqualFold(a.LastName, b.LastName) {
+			return strings.ToLower(a.LastName) < strings.ToLower(b.LastName)
+		}
+		if !strings.EqualFold(a.FirstName, b.FirstName) {
+			return strings.ToLower(a.FirstName) < strings.ToLower(b.FirstName)
+		}
+		return a.ID < b.ID
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// guestSearchTerms splits a query into the distinct words searched for. Leading
+// zeros of numbers are dropped, as a trunk prefix a phone number may be stored
+// without.
+func guestSearchTerms(query string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, term := range models.SearchTokens(query) {
+		if isDigits(term) {
+			term = strings.TrimLeft(term, "0")
+		}
+		if term == "" || seen[term] {
+			continue
+		}
+		seen[term] = true
+		terms = append(terms, term)
+		if len(terms) == maxGuestSearchTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// scoreGuestSearch ranks how well a guest matches the terms of a query. Each
+// term adds the weight of the best field it matches; a guest missing a term
+// scores 0.
+func scoreGuestSearch(guest *models.Guest, terms []string) int {
+	fields := map[string][]string{
+		"name":  models.SearchTokens(guest.FirstName + " " + guest.LastName),
+		"email": models.SearchTokens(guest.Email),
+		"notes": models.SearchTokens(guest.Notes),
+	}
+	phone := models.PhoneSearchDigits(guest.Phone)
+
+	total := 0
+	for _, term := range terms {
+		best := 0
+		for _, weight := range guestSearchWeights {
+			score := 0
+			if weight.field == "phone" {
+				if isDigits(term) && strings.Contains(phone, term) {
+					score = weight.whole
+				}
+			} else {
+				for _, token := range fields[weight.field] {
+					if token == term {
+						score = weight.whole
+						break
+					}
+					if strings.HasPrefix(token, term) {
+						score = weight.prefix
+					}
+				}
+			}
+			if score > best {
+				best = score
+			}
+		}
+		if best == 0 {
+			return 0
+		}
+		total += best
+	}
+	return total
+}
+
+// isDigits reports whether s consists of ASCII digits only
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func TestGuestService_SearchGuests(t *testing.T) {
+	weddingID := models.NewID()
+	userID := models.NewID()
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(&models.Wedding{ID: weddingID, UserID: userID}, nil)
+
+	guestRepo := NewMockGuestRepository()
+	guests := map[string]*models.Guest{
+		"jose":   {FirstName: "José", LastName: "Ramírez", Email: "jr@example.com", Phone: "+62 812-3456-7890"},
+		"jordan": {FirstName: "Jordan", LastName: "Lee", Email: "jordan@example.com"},
+		"anna":   {FirstName: "Anna", LastName: "Smith", Email: "anna@example.com", Notes: "Jo's plus one"},
+		"mary":   {FirstName: "Mary", LastName: "Jones", Email: "mary@example.com"},
+	}
+	for _, guest := range guests {
+		guest.WeddingID = weddingID
+		require.NoError(t, guestRepo.Create(context.Background(), guest))
+	}
+	// Guests of other weddings are not found
+	require.NoError(t, guestRepo.Create(context.Background(), &models.Guest{WeddingID: models.NewID(), FirstName: "Jo"}))
+
+	service := NewGuestService(guestRepo, weddingRepo)
+	search := func(t *testing.T, query string, limit int) []*models.Guest {
+		found, err := service.SearchGuests(context.Background(), weddingID, userID, query, limit)
+		require.NoError(t, err)
+		return found
+	}
+
+	t.Run("Success - prefix ranked by field", func(t *testing.T) {
+		found := search(t, "jo", 0)
+		// Names first, ordered by last name, then notes
+		assert.Equal(t, []*models.Guest{guests["mary"], guests["jordan"], guests["jose"], guests["anna"]}, found)
+	})
+
+	t.Run("Success - whole word ranks above prefix", func(t *testing.T) {
+		found := search(t, "jordan", 0)
+		assert.Equal(t, []*models.Guest{guests["jordan"]}, found)
+	})
+
+	t.Run("Success - diacritics insensitive", func(t *testing.T) {
+		assert.Equal(t, []*models.Guest{guests["jose"]}, search(t, "jose ramirez", 0))
+		assert.Equal(t, []*models.Guest{guests["jose"]}, search(t, "JOSÉ", 0))
+	})
+
+	t.Run("Success - every term must match", func(t *testing.T) {
+		assert.Equal(t, []*models.Guest{guests["jordan"]}, search(t, "jo lee", 0))
+		assert.Empty(t, search(t, "mary smith", 0))
+	})
+
+	t.Run("Success - phone with or without country code", func(t *testing.T) {
+		assert.Equal(t, []*models.Guest{guests["jose"]}, search(t, "0812 3456", 0))
+		assert.Equal(t, []*models.Guest{guests["jose"]}, search(t, "7890", 0))
+	})
+
+	t.Run("Success - limited", func(t *testing.T) {
+		assert.Len(t, search(t, "jo", 2), 2)
+	})
+
+	t.Run("Invalid query", func(t *testing.T) {
+		_, err := service.SearchGuests(context.Background(), weddingID, userID, "  ?! ", 0)
+		assert.ErrorIs(t, err, ErrInvalidGuestSearch)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		_, err := service.SearchGuests(context.Background(), weddingID, models.NewID(), "jo", 0)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+}
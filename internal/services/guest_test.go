@@ -2,19 +2,21 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"github.com/stretchr/testify/require"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
 )
 
 // MockGuestRepository for testing
 type MockGuestRepository struct {
-	guests      map[primitive.ObjectID]*models.Guest
+	guests      map[models.ID]*models.Guest
 	batchGuests map[string][]*models.Guest
 	createError error
 	getError    error
@@ -24,7 +26,7 @@ type MockGuestRepository struct {
 
 func NewMockGuestRepository() *MockGuestRepository {
 	return &MockGuestRepository{
-		guests:      make(map[primitive.ObjectID]*models.Guest),
+		guests:      make(map[models.ID]*models.Guest),
 		batchGuests: make(map[string][]*models.Guest),
 	}
 }
@@ -34,7 +36,7 @@ func (m *MockGuestRepository) Create(ctx context.Context, guest *models.Guest) e
 		return m.createError
 	}
 
-	id := primitive.NewObjectID()
+	id := models.NewID()
 	guest.ID = id
 	guest.CreatedAt = time.Now()
 	guest.UpdatedAt = time.Now()
@@ -44,7 +46,7 @@ func (m *MockGuestRepository) Create(ctx context.Context, guest *models.Guest) e
 
 func (m *MockGuestRepository) CreateMany(ctx context.Context, guests []*models.Guest) error {
 	for _, guest := range guests {
-		id := primitive.NewObjectID()
+		id := models.NewID()
 		guest.ID = id
 		guest.CreatedAt = time.Now()
 		guest.UpdatedAt = time.Now()
@@ -53,7 +55,7 @@ func (m *MockGuestRepository) CreateMany(ctx context.Context, guests []*models.G
 	return nil
 }
 
-func (m *MockGuestRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Guest, error) {
+func (m *MockGuestRepository) GetByID(ctx context.Context, id models.ID) (*models.Guest, error) {
 	if m.getError != nil {
 		return nil, m.getError
 	}
@@ -65,7 +67,7 @@ func (m *MockGuestRepository) GetByID(ctx context.Context, id primitive.ObjectID
 	return guest, nil
 }
 
-func (m *MockGuestRepository) GetByEmail(ctx context.Context, weddingID primitive.ObjectID, email string) (*models.Guest, error) {
+func (m *MockGuestRepository) GetByEmail(ctx context.Context, weddingID models.ID, email string) (*models.Guest, error) {
 	for _, guest := range m.guests {
 		if guest.WeddingID == weddingID && guest.Email == email {
 			return guest, nil
@@ -74,11 +76,41 @@ func (m *MockGuestRepository) GetByEmail(ctx context.Context, weddingID primitiv
 	return nil, repository.ErrNotFound
 }
 
-func (m *MockGuestRepository) ListByWedding(ctx context.Context, weddingID primitive.ObjectID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error) {
+func (m *MockGuestRepository) GetByPhone(ctx context.Context, weddingID models.ID, phoneKey string) (*models.Guest, error) {
+	for _, guest := range m.guests {
+		if guest.WeddingID == weddingID && models.GuestPhoneKey(guest.Phone) == phoneKey {
+			return guest, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+// guestHasRSVPStatus mirrors the repositories' RSVP status filter, where
+// "pending" also matches guests without a status
+func guestHasRSVPStatus(guest *models.Guest, status string) bool {
+	if status == "pending" && guest.RSVPStatus == "" {
+		return true
+	}
+	return status == "" || guest.RSVPStatus == status
+}
+
+func (m *MockGuestRepository) ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error) {
 	var guests []*models.Guest
 
 	for _, guest := range m.guests {
 		if guest.WeddingID == weddingID {
+			if !guestHasRSVPStatus(guest, filters.RSVPStatus) {
+				continue
+			}
+			if filters.ImportBatchID != "" && guest.ImportBatchID != filters.ImportBatchID {
+				continue
+			}
+			if len(filters.IDs) > 0 && !containsID(filters.IDs, guest.ID) {
+				continue
+			}
+			if filters.GroupID != nil && (guest.GroupID == nil || *guest.GroupID != *filters.GroupID) {
+				continue
+			}
 			// Apply filters
 			if filters.Search != "" {
 				search := filters.Search
@@ -108,7 +140,7 @@ func (m *MockGuestRepository) Update(ctx context.Context, guest *models.Guest) e
 	return nil
 }
 
-func (m *MockGuestRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockGuestRepository) Delete(ctx context.Context, id models.ID) error {
 	if m.deleteError != nil {
 		return m.deleteError
 	}
@@ -124,7 +156,7 @@ func (m *MockGuestRepository) Delete(ctx context.Context, id primitive.ObjectID)
 func (m *MockGuestRepository) ImportBatch(ctx context.Context, guests []*models.Guest, batchID string) error {
 	for _, guest := range guests {
 		guest.ImportBatchID = batchID
-		id := primitive.NewObjectID()
+		id := models.NewID()
 		guest.ID = id
 		guest.CreatedAt = time.Now()
 		guest.UpdatedAt = time.Now()
@@ -135,7 +167,7 @@ func (m *MockGuestRepository) ImportBatch(ctx context.Context, guests []*models.
 	return nil
 }
 
-func (m *MockGuestRepository) GetByImportBatch(ctx context.Context, weddingID primitive.ObjectID, batchID string) ([]*models.Guest, error) {
+func (m *MockGuestRepository) GetByImportBatch(ctx context.Context, weddingID models.ID, batchID string) ([]*models.Guest, error) {
 	guests, exists := m.batchGuests[batchID]
 	if !exists {
 		return []*models.Guest{}, nil
@@ -151,14 +183,221 @@ func (m *MockGuestRepository) GetByImportBatch(ctx context.Context, weddingID pr
 	return result, nil
 }
 
+func (m *MockGuestRepository) Search(ctx context.Context, weddingID models.ID, prefixes []string) ([]*models.Guest, error) {
+	var guests []*models.Guest
+	for _, guest := range m.guests {
+		if guest.WeddingID != weddingID {
+			continue
+		}
+		keys := models.GuestSearchKeys(guest)
+		matched := true
+		for _, prefix := range prefixes {
+			found := false
+			for _, key := range keys {
+				if strings.HasPrefix(key, prefix) {
+					found = true
+					break
+				}
+			}
+			matched = matched && found
+		}
+		if matched {
+			guests = append(guests, guest)
+		}
+	}
+	return guests, nil
+}
+
+func (m *MockGuestRepository) StreamByWedding(ctx context.Context, weddingID models.ID, filters repository.GuestFilters, fn func(*models.Guest) error) error {
+	for _, guest := range m.guests {
+		if guest.WeddingID != weddingID {
+			continue
+		}
+		if !guestHasRSVPStatus(guest, filters.RSVPStatus) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(guest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockGuestRepository) UpdateInvitationStatus(ctx context.Context, id models.ID, status string, sentAt *time.Time, reason string) error {
+	if m.updateError != nil {
+		return m.updateError
+	}
+
+	guest, exists := m.guests[id]
+	if !exists {
+		return repository.ErrNotFound
+	}
+
+	guest.InvitationStatus = status
+	guest.InvitationError = reason
+	if sentAt != nil {
+		guest.InvitationSentAt = sentAt
+	}
+	return nil
+}
+
+func (m *MockGuestRepository) MarkInvitationSent(ctx context.Context, id models.ID, channel, messageID string, sentAt time.Time) error {
+	if m.updateError != nil {
+		return m.updateError
+	}
+
+	guest, exists := m.guests[id]
+	if !exists {
+		return repository.ErrNotFound
+	}
+
+	guest.InvitationStatus = models.InvitationStatusSent
+	guest.InvitationError = ""
+	guest.InvitationSentAt = &sentAt
+	guest.InvitationChannel = channel
+	guest.InvitationMessageID = messageID
+	return nil
+}
+
+func (m *MockGuestRepository) UpdateInvitationStatusByMessage(ctx context.Context, messageID, status string, from []string, reason string) error {
+	for _, guest := range m.guests {
+		if guest.InvitationMessageID != messageID {
+			continue
+		}
+		for _, current := range from {
+			if guest.InvitationStatus == current {
+				guest.InvitationStatus = status
+				guest.InvitationError = reason
+				return nil
+			}
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func (m *MockGuestRepository) CheckIn(ctx context.Context, id models.ID, checkIn models.GuestCheckIn) error {
+	if m.updateError != nil {
+		return m.updateError
+	}
+
+	guest, exists := m.guests[id]
+	if !exists || guest.CheckIn != nil {
+		return repository.ErrNotFound
+	}
+
+	guest.CheckIn = &checkIn
+	return nil
+}
+
+func (m *MockGuestRepository) CheckInStats(ctx context.Context, weddingID models.ID) (*models.CheckInStats, error) {
+	stats := &models.CheckInStats{}
+	for _, guest := range m.guests {
+		if guest.WeddingID != weddingID {
+			continue
+		}
+		stats.TotalGuests++
+		if guest.RSVPStatus == "attending" {
+			stats.ExpectedGuests++
+		}
+		if guest.CheckIn == nil {
+			continue
+		}
+		stats.CheckedIn++
+		if guest.RSVPStatus == "attending" {
+			stats.ExpectedArrived++
+		}
+		stats.PlusOnesBrought += int64(guest.CheckIn.PlusOnesBrought)
+	}
+	stats.Attendance = stats.CheckedIn + stats.PlusOnesBrought
+	return stats, nil
+}
+
+func (m *MockGuestRepository) UpdateMany(ctx context.Context, weddingID models.ID, ids []models.ID, patch models.GuestPatch) (int64, error) {
+	if m.updateError != nil {
+		return 0, m.updateError
+	}
+	var matched int64
+	for _, id := range ids {
+		if guest, ok := m.guests[id]; ok && guest.WeddingID == weddingID {
+			patch.Apply(guest)
+			matched++
+		}
+	}
+	return matched, nil
+}
+
+func (m *MockGuestRepository) DeleteMany(ctx context.Context, weddingID models.ID, ids []models.ID) (int64, error) {
+	if m.deleteError != nil {
+		return 0, m.deleteError
+	}
+	var deleted int64
+	for _, id := range ids {
+		if guest, ok := m.guests[id]; ok && guest.WeddingID == weddingID {
+			delete(m.guests, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (m *MockGuestRepository) SetGroup(ctx context.Context, weddingID models.ID, ids []models.ID, groupID *models.ID) (int64, error) {
+	if m.updateError != nil {
+		return 0, m.updateError
+	}
+	var matched int64
+	for _, id := range ids {
+		if guest, ok := m.guests[id]; ok && guest.WeddingID == weddingID {
+			guest.GroupID = groupID
+			matched++
+		}
+	}
+	return matched, nil
+}
+
+func (m *MockGuestRepository) AttendanceBreakdown(ctx context.Context, weddingID models.ID, field string) ([]models.AttendanceBreakdown, error) {
+	if m.getError != nil {
+		return nil, m.getError
+	}
+	breakdowns := models.AttendanceBreakdowns{}
+	for _, guest := range m.guests {
+		if guest.WeddingID != weddingID {
+			continue
+		}
+		key := guest.Side
+		switch field {
+		case models.GuestBreakdownRelationship:
+			key = guest.Relationship
+		case models.GuestBreakdownVIP:
+			key = models.BreakdownRegular
+			if guest.VIP {
+				key = models.BreakdownVIP
+			}
+		}
+		breakdowns.Add(key, guest.RSVPStatus, 1, 1+len(guest.Companions))
+	}
+	return breakdowns.List(), nil
+}
+
+func containsID(ids []models.ID, id models.ID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
 func TestGuestService_CreateGuest(t *testing.T) {
 	guestRepo := NewMockGuestRepository()
 	weddingRepo := &MockWeddingRepository{}
 	service := NewGuestService(guestRepo, weddingRepo)
 
 	// Create test wedding
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 	wedding := &models.Wedding{
 		ID:     weddingID,
 		UserID: userID,
@@ -189,9 +428,9 @@ func TestGuestService_CreateGuest_Unauthorized(t *testing.T) {
 	service := NewGuestService(guestRepo, weddingRepo)
 
 	// Create test wedding
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
-	otherUserID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
+	otherUserID := models.NewID()
 	wedding := &models.Wedding{
 		ID:     weddingID,
 		UserID: otherUserID, // Different user
@@ -214,14 +453,44 @@ func TestGuestService_CreateGuest_Unauthorized(t *testing.T) {
 	weddingRepo.AssertExpectations(t)
 }
 
+func TestGuestService_CreateGuest_Planner(t *testing.T) {
+	guestRepo := NewMockGuestRepository()
+	weddingRepo := &MockWeddingRepository{}
+	service := NewGuestService(guestRepo, weddingRepo)
+
+	// A planner manages guests of a wedding they don't own
+	weddingID := models.NewID()
+	plannerID := models.NewID()
+	wedding := &models.Wedding{
+		ID:     weddingID,
+		UserID: models.NewID(),
+		Collaborators: []models.WeddingCollaborator{
+			{UserID: plannerID, Role: models.WeddingRolePlanner},
+		},
+	}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(wedding, nil)
+
+	guest := &models.Guest{
+		FirstName: "John",
+		LastName:  "Doe",
+		Side:      "groom",
+	}
+
+	err := service.CreateGuest(context.Background(), weddingID, plannerID, guest)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, guest.ID)
+
+	weddingRepo.AssertExpectations(t)
+}
+
 func TestGuestService_CreateGuest_ValidationError(t *testing.T) {
 	guestRepo := NewMockGuestRepository()
 	weddingRepo := &MockWeddingRepository{}
 	service := NewGuestService(guestRepo, weddingRepo)
 
 	// Create test wedding
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 	wedding := &models.Wedding{
 		ID:     weddingID,
 		UserID: userID,
@@ -250,8 +519,8 @@ func TestGuestService_CreateGuest_DuplicateEmail(t *testing.T) {
 	service := NewGuestService(guestRepo, weddingRepo)
 
 	// Create test wedding
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 	wedding := &models.Wedding{
 		ID:     weddingID,
 		UserID: userID,
@@ -286,8 +555,8 @@ func TestGuestService_GetGuestByID(t *testing.T) {
 	service := NewGuestService(guestRepo, weddingRepo)
 
 	// Create test wedding
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 	wedding := &models.Wedding{
 		ID:     weddingID,
 		UserID: userID,
@@ -317,8 +586,8 @@ func TestGuestService_UpdateGuest(t *testing.T) {
 	service := NewGuestService(guestRepo, weddingRepo)
 
 	// Create test wedding
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 	wedding := &models.Wedding{
 		ID:     weddingID,
 		UserID: userID,
@@ -348,8 +617,8 @@ func TestGuestService_DeleteGuest(t *testing.T) {
 	service := NewGuestService(guestRepo, weddingRepo)
 
 	// Create test wedding
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 	wedding := &models.Wedding{
 		ID:     weddingID,
 		UserID: userID,
@@ -382,8 +651,8 @@ func TestGuestService_CreateManyGuests(t *testing.T) {
 	service := NewGuestService(guestRepo, weddingRepo)
 
 	// Create test wedding
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 	wedding := &models.Wedding{
 		ID:     weddingID,
 		UserID: userID,
@@ -415,3 +684,101 @@ func TestGuestService_CreateManyGuests(t *testing.T) {
 
 	weddingRepo.AssertExpectations(t)
 }
+
+func TestGuestService_BulkUpdateGuests(t *testing.T) {
+	weddingID := models.NewID()
+	userID := models.NewID()
+	setup := func() (*GuestService, *MockGuestRepository, []*models.Guest) {
+		guestRepo := NewMockGuestRepository()
+		weddingRepo := &MockWeddingRepository{}
+		weddingRepo.On("GetByID", mock.Anything, weddingID).Return(&models.Wedding{ID: weddingID, UserID: userID}, nil)
+
+		tagged := make([]string, maxGuestTags)
+		for i := range tagged {
+			tagged[i] = fmt.Sprintf("tag-%d", i)
+		}
+		guests := []*models.Guest{
+			{WeddingID: weddingID, FirstName: "Ana", LastName: "Lee", Tags: []string{"family"}},
+			{WeddingID: weddingID, FirstName: "Ben", LastName: "Lee", Tags: tagged},
+		}
+		for _, guest := range guests {
+			guestRepo.Create(context.Background(), guest)
+		}
+		return NewGuestService(guestRepo, weddingRepo), guestRepo, guests
+	}
+	side := "bride"
+	sent := models.InvitationStatusSent
+	patch := models.GuestPatch{Side: &side, InvitationStatus: &sent, AddTags: []string{"friends"}}
+
+	t.Run("applies the patch and reports the guests it could not change", func(t *testing.T) {
+		service, guestRepo, guests := setup()
+		missing := models.NewID()
+
+		result, err := service.BulkUpdateGuests(context.Background(), weddingID, userID,
+			GuestSelection{IDs: []models.ID{guests[0].ID, guests[1].ID, missing, guests[0].ID}}, patch, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, result.Requested)
+		assert.Equal(t, 1, result.Succeeded)
+		require.Len(t, result.Failed, 2)
+		assert.Equal(t, missing.String(), result.Failed[0].GuestID)
+		assert.Equal(t, guests[1].ID.String(), result.Failed[1].GuestID)
+
+		updated := guestRepo.guests[guests[0].ID]
+		assert.Equal(t, "bride", updated.Side)
+		assert.Equal(t, []string{"family", "friends"}, updated.Tags)
+		assert.Equal(t, models.InvitationStatusSent, updated.InvitationStatus)
+		assert.NotNil(t, updated.InvitationSentAt)
+		assert.Empty(t, guestRepo.guests[guests[1].ID].Side)
+	})
+
+	t.Run("changes nothing when an atomic update fails", func(t *testing.T) {
+		service, guestRepo, guests := setup()
+
+		result, err := service.BulkUpdateGuests(context.Background(), weddingID, userID,
+			GuestSelection{IDs: []models.ID{guests[0].ID, guests[1].ID}}, patch, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, result.Succeeded)
+		assert.Len(t, result.Failed, 1)
+		assert.Empty(t, guestRepo.guests[guests[0].ID].Side)
+	})
+
+	t.Run("requires a selection", func(t *testing.T) {
+		service, _, _ := setup()
+
+		_, err := service.BulkUpdateGuests(context.Background(), weddingID, userID, GuestSelection{}, patch, false)
+		assert.ErrorIs(t, err, ErrEmptyGuestSelection)
+	})
+
+	t.Run("rejects users who cannot manage the guests", func(t *testing.T) {
+		service, _, guests := setup()
+
+		_, err := service.BulkUpdateGuests(context.Background(), weddingID, models.NewID(), GuestSelection{IDs: []models.ID{guests[0].ID}}, patch, false)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+}
+
+func TestGuestService_BulkDeleteGuests(t *testing.T) {
+	guestRepo := NewMockGuestRepository()
+	weddingRepo := &MockWeddingRepository{}
+	service := NewGuestService(guestRepo, weddingRepo)
+
+	weddingID := models.NewID()
+	userID := models.NewID()
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(&models.Wedding{ID: weddingID, UserID: userID}, nil)
+
+	imported := &models.Guest{WeddingID: weddingID, FirstName: "Ana", LastName: "Lee", ImportBatchID: "batch_1"}
+	kept := &models.Guest{WeddingID: weddingID, FirstName: "Ben", LastName: "Lee"}
+	guestRepo.Create(context.Background(), imported)
+	guestRepo.Create(context.Background(), kept)
+
+	result, err := service.BulkDeleteGuests(context.Background(), weddingID, userID, GuestSelection{ImportBatchID: "batch_1"}, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Requested)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Empty(t, result.Failed)
+	assert.NotContains(t, guestRepo.guests, imported.ID)
+	assert.Contains(t, guestRepo.guests, kept.ID)
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// HealthCheck probes one dependency the API needs to serve requests
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// HealthService reports whether the API's dependencies are reachable
+type HealthService struct {
+	checks  []HealthCheck
+	timeout time.Duration
+	logger  *zap.Logger
+}
+
+// NewHealthService creates a health service probing the given dependencies,
+// each bounded by timeout
+func NewHealthService(checks []HealthCheck, timeout time.Duration, logger *zap.Logger) *HealthService {
+	return &HealthService{
+		checks:  checks,
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+// Check probes every dependency concurrently. The API is unavailable when any
+// of them is down.
+func (s *HealthService) Check(ctx context.Context) *models.HealthReport {
+	report := &models.HealthReport{
+		Status:       models.HealthOK,
+		Dependencies: make(map[string]models.DependencyHealth, len(s.checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range s.checks {
+		wg.Add(1)
+		go func(check HealthCheck) {
+			defer wg.Done()
+			health := s.probe(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Dependencies[check.Name] = health
+			if health.Status == models.DependencyDown {
+				report.Status = models.HealthUnavailable
+			}
+		}(check)
+	}
+	wg.Wait()
+
+	return report
+}
+
+func (s *HealthService) probe(ctx context.Context, check HealthCheck) models.DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Check(ctx)
+	health := models.DependencyHealth{
+		Status:    models.DependencyUp,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err == nil {
+		return health
+	}
+
+	health.Status = models.DependencyDown
+	health.Error = "unreachable"
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		health.Error = "timed out"
+	}
+	s.logger.Warn("Health check failed", zap.String("dependency", check.Name), zap.Error(err))
+	return health
+}
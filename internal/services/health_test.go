@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func TestHealthService_Check(t *testing.T) {
+	up := HealthCheck{Name: "mongodb", Check: func(ctx context.Context) error { return nil }}
+	down := HealthCheck{Name: "redis", Check: func(ctx context.Context) error { return errors.New("connection refused") }}
+	hanging := HealthCheck{Name: "storage", Check: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+
+	t.Run("Ready when every dependency is up", func(t *testing.T) {
+		report := NewHealthService([]HealthCheck{up}, time.Second, zaptest.NewLogger(t)).Check(context.Background())
+
+		assert.Equal(t, models.HealthOK, report.Status)
+		assert.Equal(t, models.DependencyUp, report.Dependencies["mongodb"].Status)
+		assert.Empty(t, report.Dependencies["mongodb"].Error)
+	})
+
+	t.Run("Unavailable when a dependency is down or hangs", func(t *testing.T) {
+		start := time.Now()
+		report := NewHealthService([]HealthCheck{up, down, hanging}, 50*time.Millisecond, zaptest.NewLogger(t)).Check(context.Background())
+
+		assert.Less(t, time.Since(start), time.Second, "probes must be bounded by the timeout")
+		assert.Equal(t, models.HealthUnavailable, report.Status)
+		assert.Len(t, report.Dependencies, 3)
+		assert.Equal(t, models.DependencyUp, report.Dependencies["mongodb"].Status)
+		assert.Equal(t, models.DependencyHealth{Status: models.DependencyDown, LatencyMs: report.Dependencies["redis"].LatencyMs, Error: "unreachable"}, report.Dependencies["redis"])
+		assert.Equal(t, "timed out", report.Dependencies["storage"].Error)
+	})
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// IdempotentResponse is the response recorded for an idempotency key, replayed
+// when a client retries the request
+type IdempotentResponse struct {
+	// RequestHash fingerprints the request, so a key reused for a different
+	// request is told apart from a retry
+	RequestHash string `json:"request_hash"`
+	// Status is 0 while the first request is still being processed
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// Completed reports whether the response has been recorded
+func (r *IdempotentResponse) Completed() bool {
+	return r.Status != 0
+}
+
+// IdempotencyStore records the responses of requests by idempotency key
+type IdempotencyStore interface {
+	// Reserve claims an unused key for a request for lease, returning nil.
+	// A key in use returns what is recorded for it.
+	Reserve(ctx context.Context, key, requestHash string, lease time.Duration) (*IdempotentResponse, error)
+	// Save records the response of a reserved key for ttl
+	Save(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error
+	// Release frees a reserved key, so the request can be retried
+	Release(ctx context.Context, key string) error
+}
+
+// redisIdempotencyStore keeps responses in Redis, so that a retry reaching any
+// API instance is answered
+type redisIdempotencyStore struct {
+	client *redis.Client
+}
+
+// NewRedisIdempotencyStore records idempotent responses in Redis
+func NewRedisIdempotencyStore(client *redis.Client) IdempotencyStore {
+	return &redisIdempotencyStore{client: client}
+}
+
+func (s *redisIdempotencyStore) key(key string) string {
+	return "idempotency:" + key
+}
+
+func (s *redisIdempotencyStore) Reserve(ctx context.Context, key, requestHash string, lease time.Duration) (*IdempotentResponse, error) {
+	reservation, err := json.Marshal(&IdempotentResponse{RequestHash: requestHash})
+	if err != nil {
+		return nil, err
+	}
+	reserved, err := s.client.SetNX(ctx, s.key(key), reservation, lease).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	value, err := s.client.Get(ctx, s.key(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		// Released or expired in between; answered as in progress, the next
+		// retry reserves it
+		return &IdempotentResponse{RequestHash: requestHash}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotent response: %w", err)
+	}
+	var recorded IdempotentResponse
+	if err := json.Unmarshal(value, &recorded); err != nil {
+		return nil, fmt.Errorf("failed to decode idempotent response: %w", err)
+	}
+	return &recorded, nil
+}
+
+func (s *redisIdempotencyStore) Save(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error {
+	value, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	if err := s.client.Set(ctx, s.key(key), value, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save idempotent response: %w", err)
+	}
+	return nil
+}
+
+func (s *redisIdempotencyStore) Release(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.key(key)).Err(); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// memoryIdempotencyStore keeps responses in process memory, for a single API
+// instance without Redis
+type memoryIdempotencyStore struct {
+	mu        sync.Mutex
+	responses map[string]memoryIdempotentResponse
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type memoryIdempotentResponse struct {
+	response  IdempotentResponse
+	expiresAt time.Time
+}
+
+// NewMemoryIdempotencyStore records idempotent responses in process memory
+func NewMemoryIdempotencyStore() IdempotencyStore {
+	return &memoryIdempotencyStore{responses: make(map[string]memoryIdempotentResponse), now: time.Now}
+}
+
+func (s *memoryIdempotencyStore) Reserve(ctx context.Context, key, requestHash string, lease time.Duration) (*IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= memoryRateLimitSweep {
+		for k, recorded := range s.responses {
+			if !now.Before(recorded.expiresAt) {
+				delete(s.responses, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	if recorded, ok := s.responses[key]; ok && now.Before(recorded.expiresAt) {
+		response := recorded.response
+		return &response, nil
+	}
+	s.responses[key] = memoryIdempotentResponse{
+		response:  IdempotentResponse{RequestHash: requestHash},
+		expiresAt: now.Add(lease),
+	}
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Save(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[key] = memoryIdempotentResponse{response: *response, expiresAt: s.now().Add(ttl)}
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.responses, key)
+	return nil
+}
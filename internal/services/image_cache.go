@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ImageCache keeps rendered images by their cache key
+type ImageCache interface {
+	// Get returns a cached image, nil when there is none
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, data []byte, ttl time.Duration) error
+}
+
+// redisImageCache keeps rendered images in Redis, shared by all API instances
+type redisImageCache struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisImageCache keeps rendered images in Redis under keys starting with prefix
+func NewRedisImageCache(client *redis.Client, prefix string) ImageCache {
+	return &redisImageCache{client: client, prefix: prefix}
+}
+
+func (c *redisImageCache) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached image: %w", err)
+	}
+	return data, nil
+}
+
+func (c *redisImageCache) Set(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	if err := c.client.Set(ctx, c.prefix+key, data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to cache image: %w", err)
+	}
+	return nil
+}
+
+// diskImageCache keeps rendered images as files under a directory. The
+// modification time of a file is set to its expiry.
+type diskImageCache struct {
+	dir string
+	now func() time.Time
+}
+
+// NewDiskImageCache keeps rendered images under dir, which is created as needed
+func NewDiskImageCache(dir string) ImageCache {
+	return &diskImageCache{dir: dir, now: time.Now}
+}
+
+// path spreads the files over subdirectories by the start of their key
+func (c *diskImageCache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key)
+}
+
+func (c *diskImageCache) Get(ctx context.Context, key string) ([]byte, error) {
+	path := c.path(key)
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat cached image: %w", err)
+	}
+	if !c.now().Before(info.ModTime()) {
+		// Expired files are removed as they are asked for again
+		os.Remove(path)
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached image: %w", err)
+	}
+	return data, nil
+}
+
+func (c *diskImageCache) Set(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create image cache directory: %w", err)
+	}
+
+	// Written aside and renamed, so that a concurrent Get never reads half a file
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to cache image: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to cache image: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to cache image: %w", err)
+	}
+	expiresAt := c.now().Add(ttl)
+	if err := os.Chtimes(tmp.Name(), expiresAt, expiresAt); err != nil {
+		return fmt.Errorf("failed to cache image: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to cache image: %w", err)
+	}
+	return nil
+}
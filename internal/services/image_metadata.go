@@ -0,0 +1,142 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// errMalformedImage is returned when an image container cannot be walked
+var errMalformedImage = errors.New("malformed image container")
+
+// stripMetadata removes embedded metadata (EXIF with its GPS tags, XMP, IPTC
+// and text chunks) from an encoded image without touching its pixels. Formats
+// without metadata support are returned unchanged.
+func stripMetadata(data []byte, format string) ([]byte, error) {
+	switch format {
+	case "jpeg", "jpg":
+		return stripJPEGMetadata(data)
+	case "png":
+		return stripPNGMetadata(data)
+	case "webp":
+		return stripWebPMetadata(data)
+	default:
+		return data, nil
+	}
+}
+
+// JPEG markers carrying metadata: APP1 holds EXIF and XMP, APP13 holds IPTC
+const (
+	jpegMarkerAPP1  = 0xE1
+	jpegMarkerAPP13 = 0xED
+	jpegMarkerSOS   = 0xDA
+)
+
+func stripJPEGMetadata(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, errMalformedImage
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:2]...)
+	for i := 2; ; {
+		if i+2 > len(data) || data[i] != 0xFF {
+			return nil, errMalformedImage
+		}
+		marker := data[i+1]
+		switch marker {
+		case 0xFF:
+			// Fill byte before a marker
+			i++
+			continue
+		case jpegMarkerSOS:
+			// Entropy-coded data follows; nothing after it is metadata
+			return append(out, data[i:]...), nil
+		}
+
+		if i+4 > len(data) {
+			return nil, errMalformedImage
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if end < i+4 || end > len(data) {
+			return nil, errMalformedImage
+		}
+		if marker != jpegMarkerAPP1 && marker != jpegMarkerAPP13 {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngMetadataChunks are the PNG chunks that carry EXIF or free text
+var pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "iTXt": true, "zTXt": true}
+
+func stripPNGMetadata(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, errMalformedImage
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	for i := len(pngSignature); i < len(data); {
+		if i+8 > len(data) {
+			return nil, errMalformedImage
+		}
+		// Length, type, data and CRC
+		end := i + 12 + int(binary.BigEndian.Uint32(data[i:]))
+		if end < i+12 || end > len(data) {
+			return nil, errMalformedImage
+		}
+		chunkType := string(data[i+4 : i+8])
+		if !pngMetadataChunks[chunkType] {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+		if chunkType == "IEND" {
+			break
+		}
+	}
+	return out, nil
+}
+
+// VP8X flags announcing EXIF and XMP chunks
+const (
+	webpFlagEXIF = 0x08
+	webpFlagXMP  = 0x04
+)
+
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, errMalformedImage
+	}
+
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+	for i := 12; i < len(data); {
+		if i+8 > len(data) {
+			return nil, errMalformedImage
+		}
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		// Chunks are padded to an even size
+		end := i + 8 + size + size%2
+		if end < i+8 || end > len(data) {
+			return nil, errMalformedImage
+		}
+		switch string(data[i : i+4]) {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			start := len(out)
+			out = append(out, data[i:end]...)
+			if size > 0 {
+				out[start+8] &^= webpFlagEXIF | webpFlagXMP
+			}
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
@@ -8,14 +8,20 @@ import (
 	"image/jpeg"
 	"image/png"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/chai2010/webp"
 	"github.com/disintegration/imaging"
+	"github.com/gen2brain/avif"
+	"github.com/rwcarlsen/goexif/exif"
 )
 
 // ImageProcessor processes images and generates thumbnails
 type ImageProcessor interface {
 	Process(ctx context.Context, reader io.Reader, mimeType string) (*ProcessedImage, error)
+	// Analyze is Process without the thumbnails, which GenerateThumbnail renders later
+	Analyze(ctx context.Context, reader io.Reader, mimeType string) (*ProcessedImage, error)
 	GenerateThumbnail(data []byte, width, height int, format string) ([]byte, error)
 	ExtractEXIF(data []byte) (map[string]interface{}, error)
 	ConvertToWebP(data []byte, quality float32) ([]byte, error)
@@ -43,32 +49,86 @@ type ThumbnailSize struct {
 	Height int
 }
 
+// Thumbnail variant formats, rendered alongside each thumbnail size
+const (
+	VariantWebP = "webp"
+	VariantAVIF = "avif"
+)
+
+// ThumbnailVariants lists the formats thumbnails are rendered in besides the original's
+func ThumbnailVariants(enableWebP, enableAVIF bool) []string {
+	var formats []string
+	if enableWebP {
+		formats = append(formats, VariantWebP)
+	}
+	if enableAVIF {
+		formats = append(formats, VariantAVIF)
+	}
+	return formats
+}
+
+// ThumbnailVariantName names a thumbnail size rendered in a variant format, e.g. "small_webp"
+func ThumbnailVariantName(size, format string) string {
+	return size + "_" + format
+}
+
+// thumbnailVariantFormat returns the format of a variant thumbnail name, or ""
+// for a thumbnail in the original's format
+func thumbnailVariantFormat(name string) string {
+	for _, format := range []string{VariantWebP, VariantAVIF} {
+		if strings.HasSuffix(name, "_"+format) {
+			return format
+		}
+	}
+	return ""
+}
+
 type imageProcessor struct {
 	thumbnailSizes []ThumbnailSize
-	enableWebP     bool
+	variants       []string
 }
 
 // NewImageProcessor creates a new image processor
 func NewImageProcessor(sizes []ThumbnailSize, enableWebP bool) ImageProcessor {
+	return NewImageProcessorWithVariants(sizes, ThumbnailVariants(enableWebP, false))
+}
+
+// NewImageProcessorWithVariants creates an image processor that also renders
+// every thumbnail size in the given variant formats (see ThumbnailVariants)
+func NewImageProcessorWithVariants(sizes []ThumbnailSize, variants []string) ImageProcessor {
 	return &imageProcessor{
 		thumbnailSizes: sizes,
-		enableWebP:     enableWebP,
+		variants:       variants,
 	}
 }
 
 // Process processes an image and generates thumbnails
 func (p *imageProcessor) Process(ctx context.Context, reader io.Reader, mimeType string) (*ProcessedImage, error) {
+	return p.process(reader, true)
+}
+
+// Analyze extracts metadata and cleans up the original without generating thumbnails
+func (p *imageProcessor) Analyze(ctx context.Context, reader io.Reader, mimeType string) (*ProcessedImage, error) {
+	return p.process(reader, false)
+}
+
+// process stores originals upright and without embedded metadata, so uploads
+// never publish where a photo was taken
+func (p *imageProcessor) process(reader io.Reader, withThumbnails bool) (*ProcessedImage, error) {
 	// Read all data
 	data, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read image data: %w", err)
 	}
 
-	// Decode image to get dimensions
-	img, format, err := image.Decode(bytes.NewReader(data))
+	_, format, err := image.DecodeConfig(bytes.NewReader(data))
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode image: %w", err)
 	}
+	img, err := decodeUpright(data)
+	if err != nil {
+		return nil, err
+	}
 
 	bounds := img.Bounds()
 	metadata := &ImageMetadata{
@@ -78,104 +138,142 @@ func (p *imageProcessor) Process(ctx context.Context, reader io.Reader, mimeType
 	}
 
 	// Extract EXIF data
-	exifData, err := p.ExtractEXIF(data)
-	if err == nil {
-		metadata.EXIF = exifData
+	orientation := 1
+	if x, err := exif.Decode(bytes.NewReader(data)); err == nil {
+		metadata.EXIF = exifFields(x)
+		orientation = exifOrientation(x)
 	}
 
-	// Generate thumbnails
-	thumbnails := make(map[string][]byte)
-	for _, size := range p.thumbnailSizes {
-		thumb, err := p.GenerateThumbnail(data, size.Width, size.Height, format)
-		if err != nil {
-			continue // Log error but continue with other sizes
-		}
-		thumbnails[size.Name] = thumb
+	original, err := p.sanitize(data, img, format, orientation)
+	if err != nil {
+		return nil, err
 	}
 
-	// Optionally convert to WebP
-	if p.enableWebP && format != "webp" {
-		webpData, err := p.ConvertToWebP(data, 85.0)
-		if err == nil {
-			// Use WebP as primary format if conversion succeeds and is smaller
-			if len(webpData) < len(data) {
-				data = webpData
-				metadata.Format = "webp"
+	// Generate thumbnails
+	thumbnails := make(map[string][]byte)
+	if withThumbnails {
+		for _, size := range p.thumbnailSizes {
+			thumb := imaging.Thumbnail(img, size.Width, size.Height, imaging.Lanczos)
+			if encoded, err := encodeImage(thumb, format); err == nil {
+				thumbnails[size.Name] = encoded
+			}
+			for _, variant := range p.variants {
+				if variant == format {
+					continue
+				}
+				if encoded, err := encodeImage(thumb, variant); err == nil {
+					thumbnails[ThumbnailVariantName(size.Name, variant)] = encoded
+				}
 			}
 		}
 	}
 
 	return &ProcessedImage{
-		OriginalData: data,
+		OriginalData: original,
 		Thumbnails:   thumbnails,
 		Metadata:     metadata,
 	}, nil
 }
 
+// sanitize returns the original without embedded metadata. Photos stored
+// rotated are re-encoded upright, as dropping their orientation tag would
+// otherwise turn them sideways.
+func (p *imageProcessor) sanitize(data []byte, img image.Image, format string, orientation int) ([]byte, error) {
+	if orientation <= 1 {
+		if stripped, err := stripMetadata(data, format); err == nil {
+			return stripped, nil
+		}
+	}
+	encoded, err := encodeImage(img, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encode image: %w", err)
+	}
+	return encoded, nil
+}
+
 // GenerateThumbnail generates a thumbnail with specified dimensions
 func (p *imageProcessor) GenerateThumbnail(data []byte, width, height int, format string) ([]byte, error) {
-	// Decode image
-	img, _, err := image.Decode(bytes.NewReader(data))
+	img, err := decodeUpright(data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode image: %w", err)
+		return nil, err
 	}
 
 	// Resize using Lanczos resampling (high quality)
 	thumb := imaging.Thumbnail(img, width, height, imaging.Lanczos)
 
-	// Encode based on format
+	encoded, err := encodeImage(thumb, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return encoded, nil
+}
+
+// decodeUpright decodes an image, applying its EXIF orientation
+func decodeUpright(data []byte) (image.Image, error) {
+	img, err := imaging.Decode(bytes.NewReader(data), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return img, nil
+}
+
+// encodeImage encodes an image in the given format, falling back to JPEG
+func encodeImage(img image.Image, format string) ([]byte, error) {
 	buf := new(bytes.Buffer)
+	var err error
 	switch format {
-	case "jpeg", "jpg":
-		err = jpeg.Encode(buf, thumb, &jpeg.Options{Quality: 85})
 	case "png":
-		err = png.Encode(buf, thumb)
-	case "webp":
-		err = webp.Encode(buf, thumb, &webp.Options{Quality: 85})
+		err = png.Encode(buf, img)
+	case VariantWebP:
+		err = webp.Encode(buf, img, &webp.Options{Quality: 85})
+	case VariantAVIF:
+		err = avif.Encode(buf, img, avif.Options{Quality: avif.DefaultQuality, Speed: avif.DefaultSpeed})
 	default:
-		err = jpeg.Encode(buf, thumb, &jpeg.Options{Quality: 85})
+		err = jpeg.Encode(buf, img, &jpeg.Options{Quality: 85})
 	}
-
 	if err != nil {
-		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+		return nil, err
 	}
-
 	return buf.Bytes(), nil
 }
 
-// ExtractEXIF extracts EXIF data from an image
+// ExtractEXIF extracts descriptive EXIF fields from an image. Location tags
+// are deliberately left out.
 func (p *imageProcessor) ExtractEXIF(data []byte) (map[string]interface{}, error) {
-	// Basic EXIF extraction stub implementation
-	// In a real implementation, you would use a library like github.com/rwcarreira/goexif
-	// or github.com/dsoprea/go-exif to extract EXIF data
+	x, err := exif.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read EXIF: %w", err)
+	}
+	return exifFields(x), nil
+}
+
+// exifFields picks the camera and capture time fields of EXIF data
+func exifFields(x *exif.Exif) map[string]interface{} {
 	result := make(map[string]interface{})
+	for key, field := range map[string]exif.FieldName{"make": exif.Make, "model": exif.Model} {
+		if tag, err := x.Get(field); err == nil {
+			if value, err := tag.StringVal(); err == nil {
+				result[key] = strings.TrimSpace(value)
+			}
+		}
+	}
+	if taken, err := x.DateTime(); err == nil {
+		result["datetime"] = taken.Format(time.RFC3339)
+	}
+	return result
+}
 
-	// Add some basic metadata that would typically be extracted from EXIF
-	// This is a placeholder implementation
-	result["make"] = "Unknown"
-	result["model"] = "Unknown"
-	result["datetime"] = ""
-	result["orientation"] = 1
-	result["flash"] = false
-
-	// In a production environment, implement actual EXIF parsing:
-	// import "github.com/rwcarreira/goexif/exif"
-	// exifData, err := exif.Decode(bytes.NewReader(data))
-	// if err == nil {
-	//     if dateTime, err := exifData.DateTime(); err == nil {
-	//         result["datetime"] = dateTime.String()
-	//     }
-	//     if lat, long, err := exifData.LatLong(); err == nil {
-	//         result["latitude"] = lat
-	//         result["longitude"] = long
-	//     }
-	//     if make, err := exifData.Get(exif.Make); err == nil {
-	//         result["make"] = make.String()
-	//     }
-	//     // ... more EXIF fields
-	// }
-
-	return result, nil
+// exifOrientation returns the EXIF orientation tag, 1 (upright) when absent
+func exifOrientation(x *exif.Exif) int {
+	tag, err := x.Get(exif.Orientation)
+	if err != nil {
+		return 1
+	}
+	orientation, err := tag.Int(0)
+	if err != nil {
+		return 1
+	}
+	return orientation
 }
 
 // ConvertToWebP converts an image to WebP format
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/chai2010/webp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPhoto returns a landscape image, red on its left half
+func testPhoto(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.RGBA{B: 255, A: 255}
+			if x < width/2 {
+				c = color.RGBA{R: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+// exifSegment builds a JPEG APP1 segment holding a camera make, an
+// orientation and a GPS latitude
+func exifSegment(orientation uint16) []byte {
+	le := binary.LittleEndian
+	tiff := []byte("II*\x00\x08\x00\x00\x00")
+
+	// IFD0: Make, Orientation and the GPS IFD pointer
+	const ifd0Entries = 3
+	makeOffset := 8 + 2 + ifd0Entries*12 + 4
+	gpsOffset := makeOffset + 6
+	ifd0 := le.AppendUint16(nil, ifd0Entries)
+	ifd0 = appendIFDEntry(ifd0, 0x010F, 2, 6, uint32(makeOffset))
+	ifd0 = appendIFDEntry(ifd0, 0x0112, 3, 1, uint32(orientation))
+	ifd0 = appendIFDEntry(ifd0, 0x8825, 4, 1, uint32(gpsOffset))
+	ifd0 = le.AppendUint32(ifd0, 0)
+	tiff = append(tiff, ifd0...)
+	tiff = append(tiff, "Canon\x00"...)
+
+	// GPS IFD: GPSLatitudeRef "N"
+	gps := le.AppendUint16(nil, 1)
+	gps = appendIFDEntry(gps, 0x0001, 2, 2, uint32('N'))
+	gps = le.AppendUint32(gps, 0)
+	tiff = append(tiff, gps...)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+func appendIFDEntry(ifd []byte, tag, kind uint16, count, value uint32) []byte {
+	ifd = binary.LittleEndian.AppendUint16(ifd, tag)
+	ifd = binary.LittleEndian.AppendUint16(ifd, kind)
+	ifd = binary.LittleEndian.AppendUint32(ifd, count)
+	return binary.LittleEndian.AppendUint32(ifd, value)
+}
+
+// jpegWithEXIF encodes a JPEG with an EXIF segment right after its SOI marker
+func jpegWithEXIF(t *testing.T, img image.Image, orientation uint16) []byte {
+	buf := new(bytes.Buffer)
+	require.NoError(t, jpeg.Encode(buf, img, &jpeg.Options{Quality: 95}))
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), exifSegment(orientation)...), data[2:]...)
+}
+
+func TestImageProcessor_Process(t *testing.T) {
+	ctx := context.Background()
+	sizes := []ThumbnailSize{{Name: "small", Width: 2, Height: 2}}
+
+	t.Run("strips location and keeps upright originals untouched", func(t *testing.T) {
+		data := jpegWithEXIF(t, testPhoto(8, 4), 1)
+		processor := NewImageProcessor(sizes, false)
+
+		processed, err := processor.Process(ctx, bytes.NewReader(data), "image/jpeg")
+		require.NoError(t, err)
+
+		assert.Equal(t, "Canon", processed.Metadata.EXIF["make"])
+		assert.NotContains(t, processed.Metadata.EXIF, "latitude")
+		assert.NotContains(t, string(processed.OriginalData), "Exif")
+		// Only the EXIF segment is dropped; the compressed image is kept as is
+		assert.Equal(t, len(data)-len(exifSegment(1)), len(processed.OriginalData))
+		assert.Equal(t, []string{"small"}, mapKeys(processed.Thumbnails))
+	})
+
+	t.Run("rotates originals stored sideways", func(t *testing.T) {
+		// Orientation 6: the camera was turned clockwise, so the photo is portrait
+		data := jpegWithEXIF(t, testPhoto(8, 4), 6)
+		processor := NewImageProcessor(sizes, false)
+
+		processed, err := processor.Process(ctx, bytes.NewReader(data), "image/jpeg")
+		require.NoError(t, err)
+		assert.Equal(t, 4, processed.Metadata.Width)
+		assert.Equal(t, 8, processed.Metadata.Height)
+
+		original, format, err := image.Decode(bytes.NewReader(processed.OriginalData))
+		require.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, image.Rect(0, 0, 4, 8), original.Bounds())
+		assert.NotContains(t, string(processed.OriginalData), "Exif")
+
+		// The red left half ends up on top
+		r, _, b, _ := original.At(2, 1).RGBA()
+		assert.Greater(t, r, b)
+	})
+
+	t.Run("renders thumbnail variants", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		require.NoError(t, png.Encode(buf, testPhoto(8, 4)))
+		processor := NewImageProcessorWithVariants(sizes, ThumbnailVariants(true, true))
+
+		processed, err := processor.Process(ctx, bytes.NewReader(buf.Bytes()), "image/png")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"small", "small_webp", "small_avif"}, mapKeys(processed.Thumbnails))
+
+		for name, format := range map[string]string{"small": "png", "small_webp": "webp", "small_avif": "avif"} {
+			config, decoded, err := image.DecodeConfig(bytes.NewReader(processed.Thumbnails[name]))
+			require.NoError(t, err, name)
+			assert.Equal(t, format, decoded, name)
+			assert.Equal(t, 2, config.Width, name)
+		}
+	})
+
+	t.Run("skips the variant in the original's format", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		require.NoError(t, webp.Encode(buf, testPhoto(8, 4), &webp.Options{Quality: 90}))
+		processor := NewImageProcessor(sizes, true)
+
+		processed, err := processor.Process(ctx, bytes.NewReader(buf.Bytes()), "image/webp")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"small"}, mapKeys(processed.Thumbnails))
+	})
+
+	t.Run("Analyze leaves thumbnails out", func(t *testing.T) {
+		data := jpegWithEXIF(t, testPhoto(8, 4), 1)
+		processor := NewImageProcessor(sizes, true)
+
+		processed, err := processor.Analyze(ctx, bytes.NewReader(data), "image/jpeg")
+		require.NoError(t, err)
+		assert.Empty(t, processed.Thumbnails)
+		assert.NotContains(t, string(processed.OriginalData), "Exif")
+	})
+}
+
+func TestImageProcessor_GenerateThumbnail(t *testing.T) {
+	processor := NewImageProcessor(nil, false)
+	data := jpegWithEXIF(t, testPhoto(8, 4), 6)
+
+	thumb, err := processor.GenerateThumbnail(data, 2, 4, "webp")
+	require.NoError(t, err)
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(thumb))
+	require.NoError(t, err)
+	assert.Equal(t, "webp", format)
+	assert.Equal(t, 2, config.Width)
+	assert.Equal(t, 4, config.Height)
+}
+
+func TestStripMetadata(t *testing.T) {
+	t.Run("PNG text and EXIF chunks", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		require.NoError(t, png.Encode(buf, testPhoto(4, 4)))
+		data := buf.Bytes()
+		// Insert after the IHDR chunk, which ends 33 bytes in
+		withText := append(append(append([]byte{}, data[:33]...), pngChunk("tEXt", "Location\x00Bali")...), data[33:]...)
+
+		stripped, err := stripMetadata(withText, "png")
+		require.NoError(t, err)
+		assert.Equal(t, data, stripped)
+	})
+
+	t.Run("WebP EXIF chunk and flag", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		require.NoError(t, webp.Encode(buf, testPhoto(4, 4), &webp.Options{Quality: 90}))
+		bitstream := buf.Bytes()[12:]
+
+		vp8x := make([]byte, 10)
+		vp8x[0] = webpFlagEXIF
+		vp8x[4], vp8x[7] = 3, 3 // width and height minus one
+		body := append([]byte("WEBP"), riffChunk("VP8X", vp8x)...)
+		body = append(body, bitstream...)
+		body = append(body, riffChunk("EXIF", exifSegment(1)[10:])...)
+		data := append(binary.LittleEndian.AppendUint32([]byte("RIFF"), uint32(len(body))), body...)
+
+		stripped, err := stripMetadata(data, "webp")
+		require.NoError(t, err)
+		assert.NotContains(t, string(stripped), "EXIF")
+		assert.Zero(t, stripped[20]&webpFlagEXIF)
+		assert.Equal(t, uint32(len(stripped)-8), binary.LittleEndian.Uint32(stripped[4:]))
+
+		decoded, err := webp.Decode(bytes.NewReader(stripped))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 4, 4), decoded.Bounds())
+	})
+
+	t.Run("malformed JPEG", func(t *testing.T) {
+		_, err := stripMetadata([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF}, "jpeg")
+		assert.ErrorIs(t, err, errMalformedImage)
+	})
+}
+
+func pngChunk(chunkType, data string) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, chunkType+data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE([]byte(chunkType+data)))
+}
+
+func riffChunk(fourCC string, payload []byte) []byte {
+	chunk := binary.LittleEndian.AppendUint32([]byte(fourCC), uint32(len(payload)))
+	chunk = append(chunk, payload...)
+	if len(payload)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+func mapKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
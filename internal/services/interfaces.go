@@ -5,6 +5,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
 )
 
 // PublicWeddingService defines methods needed for public wedding operations
@@ -14,30 +15,100 @@ type PublicWeddingService interface {
 
 // PublicRSVPService defines methods needed for public RSVP operations
 type PublicRSVPService interface {
-	SubmitRSVP(ctx context.Context, weddingID primitive.ObjectID, req SubmitRSVPRequest) (*models.RSVP, error)
+	SubmitRSVP(ctx context.Context, weddingID models.ID, req SubmitRSVPRequest) (*models.RSVP, error)
 }
 
 // RSVPServiceInterface defines the full interface for RSVP service
 type RSVPServiceInterface interface {
-	SubmitRSVP(ctx context.Context, weddingID primitive.ObjectID, req SubmitRSVPRequest) (*models.RSVP, error)
-	GetRSVPByID(ctx context.Context, id primitive.ObjectID) (*models.RSVP, error)
-	UpdateRSVP(ctx context.Context, id primitive.ObjectID, req UpdateRSVPRequest) (*models.RSVP, error)
-	DeleteRSVP(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error
-	ListRSVPs(ctx context.Context, weddingID primitive.ObjectID, userID primitive.ObjectID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error)
-	GetRSVPStatistics(ctx context.Context, weddingID primitive.ObjectID, userID primitive.ObjectID) (*models.RSVPStatistics, error)
-	ExportRSVPs(ctx context.Context, weddingID primitive.ObjectID, userID primitive.ObjectID) ([]*models.RSVP, error)
+	SubmitRSVP(ctx context.Context, weddingID models.ID, req SubmitRSVPRequest) (*models.RSVP, error)
+	GetRSVPByID(ctx context.Context, id models.ID) (*models.RSVP, error)
+	UpdateRSVP(ctx context.Context, id models.ID, req UpdateRSVPRequest) (*models.RSVP, error)
+	DeleteRSVP(ctx context.Context, id models.ID, userID models.ID) error
+	ListRSVPs(ctx context.Context, weddingID models.ID, userID models.ID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error)
+	GetRSVPStatistics(ctx context.Context, weddingID models.ID, userID models.ID) (*models.RSVPStatistics, error)
+	GetRSVPInsights(ctx context.Context, weddingID models.ID, userID models.ID) (*models.RSVPInsights, error)
+	ExportRSVPs(ctx context.Context, weddingID models.ID, userID models.ID) ([]*models.RSVP, error)
+	StreamRSVPs(ctx context.Context, weddingID models.ID, userID models.ID, fn func(*models.RSVP) error) error
+	GetRSVPQuestions(ctx context.Context, weddingID models.ID, userID models.ID) ([]models.CustomQuestion, error)
+	GetMealReport(ctx context.Context, weddingID models.ID, userID models.ID) (*models.MealReport, error)
+	ReviewRSVP(ctx context.Context, id models.ID, userID models.ID, decision string) (*models.RSVP, error)
+	GetRSVPByEditToken(ctx context.Context, token string) (*models.RSVP, error)
+	UpdateRSVPByEditToken(ctx context.Context, token string, req UpdateRSVPRequest) (*models.RSVP, error)
+}
+
+// RSVPEditLinker issues the links guests change their own RSVP with
+type RSVPEditLinker interface {
+	EditLink(ctx context.Context, rsvp *models.RSVP) string
+}
+
+// WeddingPreviewer opens the weddings preview links share before they are published
+type WeddingPreviewer interface {
+	OpenPreview(ctx context.Context, token string) (*models.Wedding, error)
+}
+
+// SlugResolver finds where the old slug of a renamed wedding leads
+type SlugResolver interface {
+	ResolveSlug(ctx context.Context, slug string) (string, error)
+}
+
+// BrandingProvider finds the branding of the organization a wedding belongs to
+type BrandingProvider interface {
+	Branding(ctx context.Context, tenantID string) (*models.OrganizationBranding, error)
+}
+
+// OpenGraphRenderer renders the link previews of public weddings
+type OpenGraphRenderer interface {
+	Meta(wedding *models.Wedding) *OpenGraphMeta
+	Image(ctx context.Context, wedding *models.Wedding) (*OpenGraphImage, error)
+}
+
+// RSVPSubmissionQueue defines the write-behind path for public RSVP submissions
+type RSVPSubmissionQueue interface {
+	Enqueue(ctx context.Context, weddingID models.ID, req SubmitRSVPRequest) (*models.RSVPSubmission, error)
+	GetSubmission(ctx context.Context, weddingID, id models.ID) (*models.RSVPSubmission, error)
+}
+
+// AnalyticsExporter exports a wedding's analytics to its owner
+type AnalyticsExporter interface {
+	StartExport(ctx context.Context, weddingID, userID models.ID, req AnalyticsExportRequest) (*models.ExportJob, error)
+	StreamExport(ctx context.Context, weddingID models.ID, req AnalyticsExportRequest, emit func(record interface{}) error) error
+	FinishExport(ctx context.Context, job *models.ExportJob, records int, exportErr error)
+	QueueExport(ctx context.Context, weddingID, userID models.ID, req AnalyticsExportRequest) (*models.ExportJob, error)
+	DownloadURL(ctx context.Context, weddingID, userID, jobID models.ID) (string, error)
+}
+
+// AccountExporter exports all of a user's data as an archive generated in the background
+type AccountExporter interface {
+	QueueExport(ctx context.Context, userID models.ID) (*models.ExportJob, error)
+	GetExport(ctx context.Context, userID, jobID models.ID) (*models.ExportJob, error)
+	DownloadURL(ctx context.Context, userID, jobID models.ID) (string, error)
+}
+
+// InvitationPDFExporter renders a wedding's printable invitations as a PDF generated in the background
+type InvitationPDFExporter interface {
+	QueueExport(ctx context.Context, weddingID, userID models.ID, req InvitationPDFRequest) (*models.ExportJob, error)
+	GetExport(ctx context.Context, weddingID, userID, jobID models.ID) (*models.ExportJob, error)
+	DownloadURL(ctx context.Context, weddingID, userID, jobID models.ID) (string, error)
+}
+
+// ExportJobRecorder records the export job history of a wedding
+type ExportJobRecorder interface {
+	StartExport(ctx context.Context, weddingID, userID models.ID, resource, format string, recipient utils.ExportRecipient) (*models.ExportJob, error)
+	FinishExport(ctx context.Context, job *models.ExportJob, records int, exportErr error)
+	ListExportJobs(ctx context.Context, weddingID, userID models.ID, page, pageSize int) ([]*models.ExportJob, int64, error)
 }
 
 // WeddingServiceInterface defines the full interface for Wedding service
 type WeddingServiceInterface interface {
-	CreateWedding(ctx context.Context, wedding *models.Wedding, userID primitive.ObjectID) error
-	GetWeddingByID(ctx context.Context, id primitive.ObjectID, requestingUserID primitive.ObjectID) (*models.Wedding, error)
-	GetWeddingBySlug(ctx context.Context, slug string, requestingUserID primitive.ObjectID) (*models.Wedding, error)
-	GetUserWeddings(ctx context.Context, userID primitive.ObjectID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error)
-	UpdateWedding(ctx context.Context, wedding *models.Wedding, requestingUserID primitive.ObjectID) error
-	DeleteWedding(ctx context.Context, weddingID primitive.ObjectID, requestingUserID primitive.ObjectID) error
-	PublishWedding(ctx context.Context, weddingID primitive.ObjectID, requestingUserID primitive.ObjectID) error
+	CreateWedding(ctx context.Context, wedding *models.Wedding, userID models.ID) error
+	GetWeddingByID(ctx context.Context, id models.ID, requestingUserID models.ID) (*models.Wedding, error)
+	GetWeddingBySlug(ctx context.Context, slug string, requestingUserID models.ID) (*models.Wedding, error)
+	GetUserWeddings(ctx context.Context, userID models.ID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error)
+	UpdateWedding(ctx context.Context, wedding *models.Wedding, requestingUserID models.ID) error
+	DeleteWedding(ctx context.Context, weddingID models.ID, requestingUserID models.ID) error
+	PublishWedding(ctx context.Context, weddingID models.ID, requestingUserID models.ID) error
 	ListPublicWeddings(ctx context.Context, page, pageSize int, filters repository.PublicWeddingFilters) ([]*models.Wedding, int64, error)
+	CheckSlugAvailability(ctx context.Context, slug string, weddingID, userID models.ID) (*SlugAvailability, error)
 }
 
 // WeddingFilters represents filters for wedding listings
@@ -0,0 +1,615 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+const (
+	// invitationSendTimeout bounds a single invitation email delivery
+	invitationSendTimeout = 30 * time.Second
+)
+
+var (
+	ErrGuestHasNoEmail     = errors.New("guest has no email address")
+	ErrGuestHasNoPhone     = errors.New("guest has no phone number")
+	ErrInvitationQueueFull = errors.New("invitation queue is full")
+	ErrInvitationChannel   = errors.New("invitation channel is not available")
+)
+
+// InvitationOptions configures the invitation worker pool
+type InvitationOptions struct {
+	SiteURL   string // Public wedding site the RSVP links point to
+	Workers   int    // Number of concurrent senders
+	QueueSize int    // Invitations waiting for a sender before new ones are rejected
+}
+
+// DefaultInvitationOptions returns sensible defaults for the invitation worker pool
+func DefaultInvitationOptions() InvitationOptions {
+	return InvitationOptions{
+		SiteURL:   "http://localhost:3000",
+		Workers:   4,
+		QueueSize: 1000,
+	}
+}
+
+// InvitationBatchResult reports how many guests a bulk send queued
+type InvitationBatchResult struct {
+	Queued  int `json:"queued"`
+	Skipped int `json:"skipped"`
+}
+
+// invitationJob is one invitation waiting for a sender. A household invitation
+// is addressed to guest on behalf of every member of group.
+type invitationJob struct {
+	wedding *models.Wedding
+	guest   *models.Guest
+	channel string
+	group   *models.GuestGroup
+	members []*models.Guest
+}
+
+// key identifies the invitation while it is queued, so a household is only queued once
+func (j invitationJob) key() models.ID {
+	if j.group != nil {
+		return j.group.ID
+	}
+	return j.guest.ID
+}
+
+// invitees returns the guests the invitation covers
+func (j invitationJob) invitees() []*models.Guest {
+	if j.group != nil {
+		return j.members
+	}
+	return []*models.Guest{j.guest}
+}
+
+// invitationMessenger sends invitations over a messaging channel with an approved template
+type invitationMessenger struct {
+	service  MessagingService
+	template MessageTemplate
+}
+
+// InvitationService sends guests their invitation with a personalized RSVP link, by
+// email or over an enabled messaging channel such as WhatsApp. Sends are queued in
+// memory and delivered by a worker pool; each outcome moves the guest's invitation
+// status to sent or failed, and messaging providers later report it delivered or
+// read. Invitations still queued when the service stops are sent before it
+// exits; those left when shutdown is aborted stay pending and can be sent again.
+type InvitationService struct {
+	guestRepo   repository.GuestRepository
+	weddingRepo repository.WeddingRepository
+	groupRepo   repository.GuestGroupRepository
+	email       EmailService
+	messengers  map[string]invitationMessenger
+	opts        InvitationOptions
+	logger      *zap.Logger
+	jobs        chan invitationJob
+	stop        chan struct{}
+	wg          sync.WaitGroup
+
+	mu       sync.Mutex
+	inFlight map[models.ID]struct{} // guests or households queued or being sent
+}
+
+// NewInvitationService creates a new invitation service
+func NewInvitationService(guestRepo repository.GuestRepository, weddingRepo repository.WeddingRepository, email EmailService, opts InvitationOptions, logger *zap.Logger) *InvitationService {
+	defaults := DefaultInvitationOptions()
+	if opts.SiteURL == "" {
+		opts.SiteURL = defaults.SiteURL
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = defaults.Workers
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaults.QueueSize
+	}
+	opts.SiteURL = strings.TrimSuffix(opts.SiteURL, "/")
+
+	return &InvitationService{
+		guestRepo:   guestRepo,
+		weddingRepo: weddingRepo,
+		email:       email,
+		messengers:  make(map[string]invitationMessenger),
+		opts:        opts,
+		logger:      logger,
+		jobs:        make(chan invitationJob, opts.QueueSize),
+		stop:        make(chan struct{}),
+		inFlight:    make(map[models.ID]struct{}),
+	}
+}
+
+// EnableMessaging sends invitations over channel with the message template, whose
+// body parameters are the guest's name, the couple, the date and the RSVP link.
+// It must be called before the service starts.
+func (s *InvitationService) EnableMessaging(channel string, messaging MessagingService, template MessageTemplate) {
+	s.messengers[channel] = invitationMessenger{service: messaging, template: template}
+}
+
+// EnableHouseholds sends grouped guests one invitation for their whole household,
+// addressed to the group's primary guest and marking every member invited.
+// It must be called before the service starts.
+func (s *InvitationService) EnableHouseholds(groups repository.GuestGroupRepository) {
+	s.groupRepo = groups
+}
+
+// SendInvite queues the invitation of a single guest over channel, email when empty,
+// regardless of whether it was sent before. Grouped guests are sent their household's
+// invitation.
+func (s *InvitationService) SendInvite(ctx context.Context, weddingID, guestID, userID models.ID, channel string) (*models.Guest, error) {
+	channel, err := s.resolveChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	wedding, err := s.getOwnedWedding(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	guest, err := s.guestRepo.GetByID(ctx, guestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrGuestNotFound
+		}
+		return nil, fmt.Errorf("failed to get guest: %w", err)
+	}
+	if guest.WeddingID != weddingID {
+		return nil, ErrGuestNotFound
+	}
+
+	job, err := s.newJob(ctx, wedding, guest, channel)
+	if err != nil {
+		return nil, err
+	}
+	if job.guest == nil {
+		return nil, noContactError(channel)
+	}
+
+	if _, err := s.enqueue(job); err != nil {
+		return nil, err
+	}
+	return guest, nil
+}
+
+// SendGroupInvite queues the invitation of a household over channel, email when
+// empty, and returns the member it is addressed to
+func (s *InvitationService) SendGroupInvite(ctx context.Context, weddingID, groupID, userID models.ID, channel string) (*models.Guest, error) {
+	channel, err := s.resolveChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	wedding, err := s.getOwnedWedding(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if s.groupRepo == nil {
+		return nil, ErrGuestGroupNotFound
+	}
+
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrGuestGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to get guest group: %w", err)
+	}
+	if group.WeddingID != weddingID {
+		return nil, ErrGuestGroupNotFound
+	}
+
+	members, _, err := s.guestRepo.ListByWedding(ctx, weddingID, 0, 0, repository.GuestFilters{GroupID: &group.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group members: %w", err)
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("%w: the group has no members", ErrInvalidGuestGroup)
+	}
+
+	job, err := s.newJob(ctx, wedding, members[0], channel)
+	if err != nil {
+		return nil, err
+	}
+	if job.guest == nil {
+		return nil, noContactError(channel)
+	}
+
+	if _, err := s.enqueue(job); err != nil {
+		return nil, err
+	}
+	return job.guest, nil
+}
+
+// SendInvites queues invitations over channel, email when empty, for several guests
+// of a wedding. Without guest IDs, every guest whose invitation is pending or failed
+// is invited. Guests without an address for the channel, of another wedding or
+// already queued are skipped. A household is sent one invitation, counted once,
+// however many of its members are listed.
+func (s *InvitationService) SendInvites(ctx context.Context, weddingID, userID models.ID, guestIDs []models.ID, channel string) (*InvitationBatchResult, error) {
+	channel, err := s.resolveChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	wedding, err := s.getOwnedWedding(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &InvitationBatchResult{}
+	households := make(map[models.ID]bool)
+	queue := func(guest *models.Guest) error {
+		if guest.WeddingID != weddingID {
+			result.Skipped++
+			return nil
+		}
+		if guest.GroupID != nil && s.groupRepo != nil {
+			if households[*guest.GroupID] {
+				return nil
+			}
+			households[*guest.GroupID] = true
+		}
+
+		job, err := s.newJob(ctx, wedding, guest, channel)
+		if err != nil {
+			return err
+		}
+		if job.guest == nil {
+			result.Skipped++
+			return nil
+		}
+		queued, err := s.enqueue(job)
+		if err != nil {
+			return err
+		}
+		if queued {
+			result.Queued++
+		} else {
+			result.Skipped++
+		}
+		return nil
+	}
+
+	if len(guestIDs) == 0 {
+		err = s.guestRepo.StreamByWedding(ctx, weddingID, repository.GuestFilters{}, func(guest *models.Guest) error {
+			if guest.InvitationStatus != models.InvitationStatusPending && guest.InvitationStatus != models.InvitationStatusFailed {
+				return nil
+			}
+			return queue(guest)
+		})
+		if err != nil {
+			if errors.Is(err, ErrInvitationQueueFull) {
+				return result, err
+			}
+			return nil, fmt.Errorf("failed to list guests: %w", err)
+		}
+		return result, nil
+	}
+
+	for _, guestID := range guestIDs {
+		guest, err := s.guestRepo.GetByID(ctx, guestID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				result.Skipped++
+				continue
+			}
+			return nil, fmt.Errorf("failed to get guest: %w", err)
+		}
+		if err := queue(guest); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// resolveChannel defaults the channel to email and checks that it is enabled
+func (s *InvitationService) resolveChannel(channel string) (string, error) {
+	if channel == "" || channel == models.InvitationChannelEmail {
+		return models.InvitationChannelEmail, nil
+	}
+	if _, enabled := s.messengers[channel]; !enabled {
+		return "", ErrInvitationChannel
+	}
+	return channel, nil
+}
+
+// noContactError is the error for guests who cannot be reached over the channel
+func noContactError(channel string) error {
+	if channel == models.InvitationChannelEmail {
+		return ErrGuestHasNoEmail
+	}
+	return ErrGuestHasNoPhone
+}
+
+// hasInvitationContact reports whether the guest can be reached over the channel
+func hasInvitationContact(guest *models.Guest, channel string) bool {
+	if channel == models.InvitationChannelEmail {
+		return guest.Email != ""
+	}
+	return guest.Phone != ""
+}
+
+// newJob builds the invitation of a guest, their household's when they are grouped.
+// The job has no guest when nobody it covers can be reached over the channel.
+func (s *InvitationService) newJob(ctx context.Context, wedding *models.Wedding, guest *models.Guest, channel string) (invitationJob, error) {
+	job := invitationJob{wedding: wedding, channel: channel}
+	if hasInvitationContact(guest, channel) {
+		job.guest = guest
+	}
+	if guest.GroupID == nil || s.groupRepo == nil {
+		return job, nil
+	}
+
+	group, err := s.groupRepo.GetByID(ctx, *guest.GroupID)
+	if err != nil {
+		// The group was deleted while its members were being ungrouped
+		if errors.Is(err, repository.ErrNotFound) {
+			return job, nil
+		}
+		return job, fmt.Errorf("failed to get guest group: %w", err)
+	}
+	members, _, err := s.guestRepo.ListByWedding(ctx, wedding.ID, 0, 0, repository.GuestFilters{GroupID: &group.ID})
+	if err != nil {
+		return job, fmt.Errorf("failed to get group members: %w", err)
+	}
+	job.group = group
+	job.members = members
+
+	// Addressed to the primary guest, or whoever of the household can be reached
+	candidates := make([]*models.Guest, 0, len(members)+1)
+	for _, member := range members {
+		if member.ID == group.PrimaryGuestID {
+			candidates = append(candidates, member)
+		}
+	}
+	candidates = append(candidates, guest)
+	candidates = append(candidates, members...)
+	job.guest = nil
+	for _, candidate := range candidates {
+		if hasInvitationContact(candidate, channel) {
+			job.guest = candidate
+			break
+		}
+	}
+	return job, nil
+}
+
+// enqueue hands an invitation to the worker pool. It reports false when the
+// invitation is already queued.
+func (s *InvitationService) enqueue(job invitationJob) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, queued := s.inFlight[job.key()]; queued {
+		return false, nil
+	}
+
+	select {
+	case s.jobs <- job:
+		s.inFlight[job.key()] = struct{}{}
+		return true, nil
+	default:
+		return false, ErrInvitationQueueFull
+	}
+}
+
+// Start runs the sender workers in the background
+func (s *InvitationService) Start(ctx context.Context) {
+	for i := 0; i < s.opts.Workers; i++ {
+		s.wg.Add(1)
+		go s.run(ctx)
+	}
+}
+
+// Stop signals the workers to send the queued invitations and exit, and waits for them
+func (s *InvitationService) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+func (s *InvitationService) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stop:
+			s.drain(ctx)
+			return
+		case job := <-s.jobs:
+			s.deliver(ctx, job)
+		}
+	}
+}
+
+// drain sends the invitations still queued, until the queue is empty or ctx is cancelled
+func (s *InvitationService) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		select {
+		case job := <-s.jobs:
+			s.deliver(ctx, job)
+		default:
+			return
+		}
+	}
+}
+
+// deliver sends one invitation and records the outcome on the guests it covers.
+// Delivery reports only follow the guest it was addressed to.
+func (s *InvitationService) deliver(ctx context.Context, job invitationJob) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.inFlight, job.key())
+		s.mu.Unlock()
+	}()
+
+	messageID, sendErr := s.send(ctx, job)
+
+	status := models.InvitationStatusSent
+	if sendErr != nil {
+		status = models.InvitationStatusFailed
+		s.logger.Warn("Failed to send invitation",
+			zap.String("guest_id", job.guest.ID.String()),
+			zap.String("channel", job.channel),
+			zap.Error(sendErr))
+	}
+
+	sentAt := time.Now()
+	for _, guest := range job.invitees() {
+		var err error
+		switch {
+		case sendErr != nil:
+			err = s.guestRepo.UpdateInvitationStatus(ctx, guest.ID, status, nil, sendErr.Error())
+		case guest.ID == job.guest.ID:
+			err = s.guestRepo.MarkInvitationSent(ctx, guest.ID, job.channel, messageID, sentAt)
+		default:
+			err = s.guestRepo.MarkInvitationSent(ctx, guest.ID, job.channel, "", sentAt)
+		}
+
+		if err != nil {
+			s.logger.Error("Failed to record invitation status",
+				zap.String("guest_id", guest.ID.String()),
+				zap.String("status", status),
+				zap.Error(err))
+		}
+	}
+}
+
+// send delivers one invitation and returns the ID its delivery is reported under, if any
+func (s *InvitationService) send(ctx context.Context, job invitationJob) (string, error) {
+	sendCtx, cancel := context.WithTimeout(ctx, invitationSendTimeout)
+	defer cancel()
+
+	rsvpLink := s.RSVPLink(job.wedding, job.guest)
+	view := newInvitationView(job.wedding, job.guest, rsvpLink)
+	if job.group != nil {
+		view.GuestName = job.group.Name
+	}
+	if messenger, ok := s.messengers[job.channel]; ok {
+		date := view.Date
+		if date == "" {
+			// Template parameters cannot be empty
+			date = "date to be announced"
+		}
+		return messenger.service.SendTemplate(sendCtx, &TemplateMessage{
+			To:       job.guest.Phone,
+			Template: messenger.template,
+			Params:   []string{view.GuestName, view.Couple, date, rsvpLink},
+		})
+	}
+
+	msg, err := renderInvitation(view)
+	if err != nil {
+		return "", err
+	}
+	msg.To = job.guest.Email
+	return "", s.email.Send(sendCtx, msg)
+}
+
+// invitationStatusTransitions maps a provider's delivery report to the invitation
+// status it moves to and the statuses it may move from. Reports can arrive out of
+// order, so an invitation never moves back, e.g. from read to delivered.
+var invitationStatusTransitions = map[string]struct {
+	status string
+	from   []string
+}{
+	MessageStatusDelivered: {models.InvitationStatusDelivered, []string{models.InvitationStatusSent}},
+	MessageStatusRead:      {models.InvitationStatusRead, []string{models.InvitationStatusSent, models.InvitationStatusDelivered}},
+	MessageStatusFailed:    {models.InvitationStatusFailed, []string{models.InvitationStatusSent, models.InvitationStatusDelivered}},
+}
+
+// HandleMessageStatuses applies messaging providers' delivery reports to the
+// invitations they concern. Reports of other messages, such as reminders, are ignored.
+func (s *InvitationService) HandleMessageStatuses(ctx context.Context, statuses []MessageStatus) error {
+	for _, report := range statuses {
+		transition, ok := invitationStatusTransitions[report.Status]
+		if !ok || report.MessageID == "" {
+			continue
+		}
+
+		reason := ""
+		if report.Status == MessageStatusFailed {
+			reason = report.Reason
+			if reason == "" {
+				reason = "message could not be delivered"
+			}
+		}
+
+		err := s.guestRepo.UpdateInvitationStatusByMessage(ctx, report.MessageID, transition.status, transition.from, reason)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("failed to update invitation status: %w", err)
+		}
+	}
+	return nil
+}
+
+// RSVPLink returns the guest's personalized link to the wedding's RSVP form
+func (s *InvitationService) RSVPLink(wedding *models.Wedding, guest *models.Guest) string {
+	query := url.Values{}
+	query.Set("guest", guest.ID.String())
+	return fmt.Sprintf("%s/w/%s?%s#rsvp", s.opts.SiteURL, url.PathEscape(wedding.Slug), query.Encode())
+}
+
+func (s *InvitationService) getOwnedWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+
+	if !wedding.Can(userID, models.PermissionManageGuests) {
+		return nil, ErrUnauthorized
+	}
+	return wedding, nil
+}
+
+// invitationView is the data rendered into an invitation email
+type invitationView struct {
+	GuestName string
+	Title     string
+	Couple    string
+	Date      string
+	Venue     string
+	RSVPLink  string
+	Deadline  string
+}
+
+// newInvitationView collects the details of a guest's invitation
+func newInvitationView(wedding *models.Wedding, guest *models.Guest, rsvpLink string) invitationView {
+	view := invitationView{
+		GuestName: strings.TrimSpace(guest.FirstName + " " + guest.LastName),
+		Title:     wedding.Title,
+		Couple:    wedding.Title,
+		RSVPLink:  rsvpLink,
+	}
+	if wedding.Couple.Partner1.FirstName != "" && wedding.Couple.Partner2.FirstName != "" {
+		view.Couple = wedding.Couple.Partner1.FirstName + " & " + wedding.Couple.Partner2.FirstName
+	}
+	if !wedding.Event.Date.IsZero() {
+		view.Date = wedding.Event.Date.Format("Monday, January 2, 2006")
+	}
+	view.Venue = wedding.Event.VenueName
+	if wedding.RSVP.Deadline != nil {
+		view.Deadline = wedding.RSVP.Deadline.Format("January 2, 2006")
+	}
+	return view
+}
+
+// renderInvitation renders the HTML and plain text invitation of a guest
+func renderInvitation(view invitationView) (*EmailMessage, error) {
+	return DefaultEmailTemplates().Render(EmailTemplateInvitation, view)
+}
@@ -0,0 +1,463 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image/color"
+	"net/url"
+	"strings"
+	"time"
+
+	qrcode "github.com/skip2/go-qrcode"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+const (
+	// InvitationPDFResource is the resource invitation PDFs are recorded as
+	InvitationPDFResource = "invitation_pdf"
+	// InvitationPDFFormat is the format of invitation exports
+	InvitationPDFFormat = "pdf"
+	// invitationPDFLinkExpiry is how long a download link of an invitation PDF works
+	invitationPDFLinkExpiry = 15 * time.Minute
+	// maxInvitationPDFGuests caps the pages of one personalized export
+	maxInvitationPDFGuests = 1000
+)
+
+var (
+	ErrNoInvitationPDFGuests      = errors.New("no guests match the filters of the personalized invitations")
+	ErrTooManyInvitationPDFGuests = fmt.Errorf("personalized invitation PDFs are limited to %d guests; filter the guest list", maxInvitationPDFGuests)
+)
+
+// InvitationPDFRequest selects the invitations a PDF export contains: one
+// page for a single guest, one per guest matching Filters when Personalized,
+// or else a single page addressed to nobody in particular
+type InvitationPDFRequest struct {
+	GuestID      *models.ID              `bson:"guest_id,omitempty"`
+	Personalized bool                    `bson:"personalized"`
+	Filters      repository.GuestFilters `bson:"filters"`
+}
+
+// themeStyle is the look of the invitations and link previews of a wedding, taken from its theme
+type themeStyle struct {
+	background color.Color
+	primary    color.Color
+	secondary  color.Color
+	font       string
+	boldFont   string
+}
+
+// InvitationPDFService renders a wedding's invitation — the couple, the event
+// details and QR codes of the venue's directions and the RSVP form — as a
+// printable PDF in the wedding's theme, generated in the background into storage
+type InvitationPDFService struct {
+	weddingRepo   repository.WeddingRepository
+	guestRepo     repository.GuestRepository
+	exportJobRepo repository.ExportJobRepository
+	links         *GuestQRService
+	storage       StorageService
+	jobs          JobEnqueuer
+	logger        *zap.Logger
+}
+
+// NewInvitationPDFService creates a new invitation PDF service. links signs
+// the personalized RSVP links printed on guests' invitations.
+func NewInvitationPDFService(
+	weddingRepo repository.WeddingRepository,
+	guestRepo repository.GuestRepository,
+	exportJobRepo repository.ExportJobRepository,
+	links *GuestQRService,
+	storage StorageService,
+	jobs JobEnqueuer,
+	logger *zap.Logger,
+) *InvitationPDFService {
+	return &InvitationPDFService{
+		weddingRepo:   weddingRepo,
+		guestRepo:     guestRepo,
+		exportJobRepo: exportJobRepo,
+		links:         links,
+		storage:       storage,
+		jobs:          jobs,
+		logger:        logger,
+	}
+}
+
+// QueueExport verifies the user manages the wedding's guests and queues the
+// PDF for generation in the background; its status is polled with GetExport
+// and the file downloaded with DownloadURL once completed
+func (s *InvitationPDFService) QueueExport(ctx context.Context, weddingID, userID models.ID, req InvitationPDFRequest) (*models.ExportJob, error) {
+	if _, err := s.getManagedWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+	if req.GuestID != nil {
+		guest, err := s.guestRepo.GetByID(ctx, *req.GuestID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, ErrGuestNotFound
+			}
+			return nil, err
+		}
+		if guest.WeddingID != weddingID {
+			return nil, ErrGuestNotFound
+		}
+	}
+
+	job := &models.ExportJob{
+		WeddingID: weddingID,
+		UserID:    userID,
+		Resource:  InvitationPDFResource,
+		Format:    InvitationPDFFormat,
+		Status:    models.ExportJobQueued,
+	}
+	if err := s.exportJobRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to record export job: %w", err)
+	}
+
+	err := s.jobs.Enqueue(ctx, JobTypeExportInvitationPDF, InvitationPDFJob{
+		ExportJobID: job.ID,
+		WeddingID:   weddingID,
+		Request:     req,
+	})
+	if err != nil {
+		finishExportJob(ctx, s.exportJobRepo, s.logger, job, 0, err)
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetExport returns one of the wedding's invitation PDF exports
+func (s *InvitationPDFService) GetExport(ctx context.Context, weddingID, userID, jobID models.ID) (*models.ExportJob, error) {
+	if _, err := s.getManagedWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	job, err := s.exportJobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrExportJobNotFound
+		}
+		return nil, err
+	}
+	if job.WeddingID != weddingID || job.Resource != InvitationPDFResource {
+		return nil, ErrExportJobNotFound
+	}
+	return job, nil
+}
+
+// DownloadURL returns a short-lived link to a completed invitation PDF
+func (s *InvitationPDFService) DownloadURL(ctx context.Context, weddingID, userID, jobID models.ID) (string, error) {
+	job, err := s.GetExport(ctx, weddingID, userID, jobID)
+	if err != nil {
+		return "", err
+	}
+	if !job.HasFile() {
+		return "", ErrExportNotReady
+	}
+
+	return s.storage.GetPresignedURL(ctx, job.FileKey, invitationPDFLinkExpiry)
+}
+
+// GenerateExport renders a queued invitation PDF into storage. On errors the
+// export stays queued for the job's retry unless this was its last attempt.
+func (s *InvitationPDFService) GenerateExport(ctx context.Context, payload InvitationPDFJob, lastAttempt bool) error {
+	job, err := s.exportJobRepo.GetByID(ctx, payload.ExportJobID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return PermanentJobError(ErrExportJobNotFound)
+		}
+		return err
+	}
+	if job.Status != models.ExportJobQueued {
+		// Generated by an earlier attempt
+		return nil
+	}
+
+	pages, err := s.generateFile(ctx, job, payload)
+	if err != nil {
+		var permanent *permanentJobError
+		if lastAttempt || errors.As(err, &permanent) {
+			finishExportJob(ctx, s.exportJobRepo, s.logger, job, pages, err)
+		}
+		return err
+	}
+
+	finishExportJob(ctx, s.exportJobRepo, s.logger, job, pages, nil)
+	return nil
+}
+
+// generateFile renders the invitations, uploads the PDF and records its
+// storage key. It returns how many pages were rendered.
+func (s *InvitationPDFService) generateFile(ctx context.Context, job *models.ExportJob, payload InvitationPDFJob) (int, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, payload.WeddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return 0, PermanentJobError(ErrWeddingNotFound)
+		}
+		return 0, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return 0, PermanentJobError(ErrWeddingNotFound)
+	}
+
+	guests, err := s.invitedGuests(ctx, wedding.ID, payload.Request)
+	if err != nil {
+		return 0, err
+	}
+
+	doc := utils.NewPDFDocument(utils.PDFA5Width, utils.PDFA5Height)
+	style := themeStyleOf(wedding.Theme)
+	for _, guest := range guests {
+		if err := s.renderInvitation(doc, style, wedding, guest); err != nil {
+			return 0, err
+		}
+	}
+	pages := len(guests)
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		return 0, fmt.Errorf("failed to write invitation PDF: %w", err)
+	}
+
+	// The random part keeps download links unguessable where storage is public
+	token, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return 0, err
+	}
+	key := fmt.Sprintf("exports/invitations/%s/%s-%s.pdf", wedding.ID.String(), job.ID.String(), token)
+	if _, err := s.storage.Upload(ctx, key, buf.Bytes(), "application/pdf", nil); err != nil {
+		return 0, fmt.Errorf("failed to store export: %w", err)
+	}
+
+	if err := s.exportJobRepo.SetFile(ctx, job.ID, key); err != nil {
+		return 0, err
+	}
+	return pages, nil
+}
+
+// invitedGuests returns the guests whose personalized invitations are
+// rendered, or a nil guest for a single generic invitation
+func (s *InvitationPDFService) invitedGuests(ctx context.Context, weddingID models.ID, req InvitationPDFRequest) ([]*models.Guest, error) {
+	if req.GuestID != nil {
+		guest, err := s.guestRepo.GetByID(ctx, *req.GuestID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, PermanentJobError(ErrGuestNotFound)
+			}
+			return nil, err
+		}
+		if guest.WeddingID != weddingID {
+			return nil, PermanentJobError(ErrGuestNotFound)
+		}
+		return []*models.Guest{guest}, nil
+	}
+	if !req.Personalized {
+		return []*models.Guest{nil}, nil
+	}
+
+	var guests []*models.Guest
+	err := s.guestRepo.StreamByWedding(ctx, weddingID, req.Filters, func(guest *models.Guest) error {
+		if len(guests) == maxInvitationPDFGuests {
+			return PermanentJobError(ErrTooManyInvitationPDFGuests)
+		}
+		guests = append(guests, guest)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(guests) == 0 {
+		return nil, PermanentJobError(ErrNoInvitationPDFGuests)
+	}
+	return guests, nil
+}
+
+// renderInvitation adds the invitation page of a guest, or a generic one
+// without a guest
+func (s *InvitationPDFService) renderInvitation(doc *utils.PDFDocument, style themeStyle, wedding *models.Wedding, guest *models.Guest) error {
+	const (
+		margin   = 40.0
+		qrSize   = 96.0
+		qrBottom = utils.PDFA5Height - 64
+	)
+	width := utils.PDFA5Width
+	page := doc.AddPage()
+	page.FillRect(0, 0, width, utils.PDFA5Height, style.background)
+	page.StrokeRect(18, 18, width-36, utils.PDFA5Height-36, 1.5, style.primary)
+	page.StrokeRect(23, 23, width-46, utils.PDFA5Height-46, 0.5, style.primary)
+
+	y := 70.0
+	if guest != nil {
+		page.CenteredText(y, style.font, 12, style.secondary, "Dear "+strings.TrimSpace(guest.FirstName+" "+guest.LastName)+",")
+		y += 22
+	}
+	page.CenteredText(y, style.font, 11, style.secondary, "Together with their families")
+	y += 40
+
+	page.CenteredText(y, style.boldFont, 24, style.primary, partnerName(wedding.Couple.Partner1.FullName, wedding.Couple.Partner1.FirstName, wedding.Couple.Partner1.LastName))
+	y += 26
+	page.CenteredText(y, style.font, 16, style.secondary, "&")
+	y += 28
+	page.CenteredText(y, style.boldFont, 24, style.primary, partnerName(wedding.Couple.Partner2.FullName, wedding.Couple.Partner2.FirstName, wedding.Couple.Partner2.LastName))
+	y += 30
+	page.CenteredText(y, style.font, 11, style.secondary, "request the pleasure of your company at")
+	y += 30
+
+	sessions := wedding.EventSessions()
+	for _, session := range sessions {
+		event := session.EventDetails
+		if event.Title != "" {
+			page.CenteredText(y, style.boldFont, 13, style.primary, event.Title)
+			y += 18
+		}
+		if !event.Date.IsZero() {
+			page.CenteredText(y, style.font, 11, style.secondary, formatInvitationDate(event))
+			y += 15
+		}
+		if event.VenueName != "" {
+			page.CenteredText(y, style.boldFont, 11, style.secondary, event.VenueName)
+			y += 15
+		}
+		for _, line := range utils.PDFWrapText(style.font, 10, width-2*margin-20, event.VenueAddress) {
+			page.CenteredText(y, style.font, 10, style.secondary, line)
+			y += 13
+		}
+		if event.DressCode != "" {
+			page.CenteredText(y, style.font, 10, style.secondary, "Dress code: "+event.DressCode)
+			y += 13
+		}
+		y += 12
+	}
+
+	rsvpLink := s.links.WeddingLink(wedding) + "#rsvp"
+	if guest != nil {
+		rsvpLink = s.links.RSVPLink(wedding, guest)
+	}
+	codes := []struct {
+		label string
+		link  string
+	}{
+		{"Directions", directionsLink(sessions[0].EventDetails)},
+		{"RSVP", rsvpLink},
+	}
+	qrTop := qrBottom - qrSize - 14
+	gap := (width - 2*qrSize) / 3
+	for i, code := range codes {
+		if code.link == "" {
+			continue
+		}
+		x := gap + float64(i)*(qrSize+gap)
+		if err := drawQRCode(page, code.link, x, qrTop, qrSize); err != nil {
+			return err
+		}
+		page.Text(x+(qrSize-utils.PDFTextWidth(style.font, 10, code.label))/2, qrBottom, style.font, 10, style.secondary, code.label)
+	}
+	return nil
+}
+
+func (s *InvitationPDFService) getManagedWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	if !wedding.Can(userID, models.PermissionManageGuests) {
+		return nil, ErrUnauthorized
+	}
+	return wedding, nil
+}
+
+// invitationStyle picks the colors and fonts of the invitation from the
+// theme; serif fonts unless the theme uses a sans-serif one
+func themeStyleOf(theme models.ThemeSettings) themeStyle {
+	style := themeStyle{
+		background: color.White,
+		primary:    color.RGBA{R: 0x5c, G: 0x3d, B: 0x2e, A: 0xff},
+		secondary:  color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff},
+		font:       utils.PDFTimes,
+		boldFont:   utils.PDFTimesBold,
+	}
+	if c, ok := utils.ParseHexColor(theme.BackgroundColor); ok {
+		style.background = c
+	}
+	if c, ok := utils.ParseHexColor(theme.PrimaryColor); ok {
+		style.primary = c
+	}
+	if c, ok := utils.ParseHexColor(theme.SecondaryColor); ok {
+		style.secondary = c
+	}
+	if strings.Contains(strings.ToLower(theme.FontFamily), "sans") {
+		style.font = utils.PDFHelvetica
+		style.boldFont = utils.PDFHelveticaBold
+	}
+	return style
+}
+
+func partnerName(fullName, firstName, lastName string) string {
+	if fullName != "" {
+		return fullName
+	}
+	return strings.TrimSpace(firstName + " " + lastName)
+}
+
+// formatInvitationDate writes the event's date in the venue's time zone,
+// followed by its time when given
+func formatInvitationDate(event models.EventDetails) string {
+	date := event.Date
+	if loc, err := time.LoadLocation(event.Timezone); err == nil && event.Timezone != "" {
+		date = date.In(loc)
+	}
+	text := date.Format("Monday, January 2, 2006")
+	if event.Time != "" {
+		text += " at " + event.Time
+	}
+	return text
+}
+
+// directionsLink returns the venue's map link, or a map search for its address
+func directionsLink(event models.EventDetails) string {
+	if event.VenueMapURL != "" {
+		return event.VenueMapURL
+	}
+	query := strings.TrimSpace(event.VenueName + " " + event.VenueAddress)
+	if query == "" {
+		return ""
+	}
+	return "https://www.google.com/maps/search/?api=1&query=" + url.QueryEscape(query)
+}
+
+// drawQRCode draws the QR code of a link as rectangles, which stay sharp in print
+func drawQRCode(page *utils.PDFPage, link string, x, y, size float64) error {
+	qr, err := qrcode.New(link, qrcode.Medium)
+	if err != nil {
+		return fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	bitmap := qr.Bitmap()
+	module := size / float64(len(bitmap))
+
+	page.FillRect(x, y, size, size, color.White)
+	for row, modules := range bitmap {
+		for col := 0; col < len(modules); {
+			if !modules[col] {
+				col++
+				continue
+			}
+			run := 1
+			for col+run < len(modules) && modules[col+run] {
+				run++
+			}
+			page.FillRect(x+float64(col)*module, y+float64(row)*module, float64(run)*module, module, color.Black)
+			col += run
+		}
+	}
+	return nil
+}
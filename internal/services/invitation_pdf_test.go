@@ -0,0 +1,190 @@
+package services
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"io"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+type invitationPDFFixture struct {
+	service  *InvitationPDFService
+	queue    *JobQueue
+	jobs     *MockExportJobRepository
+	storage  *MockStorageService
+	guests   *MockGuestRepository
+	wedding  *models.Wedding
+	uploaded []byte
+	key      string
+}
+
+func setupInvitationPDFService(t *testing.T) *invitationPDFFixture {
+	logger := zaptest.NewLogger(t)
+	f := &invitationPDFFixture{
+		jobs:    NewMockExportJobRepository(),
+		storage: &MockStorageService{},
+		guests:  NewMockGuestRepository(),
+	}
+	f.wedding = &models.Wedding{ID: models.NewID(), UserID: models.NewID(), Title: "Ana & Budi", Slug: "ana-budi"}
+	f.wedding.Couple.Partner1.FullName = "Ana Putri"
+	f.wedding.Couple.Partner2.FullName = "Budi Santoso"
+	f.wedding.Event = models.EventDetails{
+		Title:        "Wedding Reception",
+		Date:         time.Date(2026, 6, 20, 11, 0, 0, 0, time.UTC),
+		Time:         "18:00",
+		VenueName:    "Gedung Serbaguna",
+		VenueAddress: "Jalan Merdeka No. 17, Jakarta",
+		Timezone:     "Asia/Jakarta",
+	}
+	f.wedding.Theme.PrimaryColor = "#8a5a44"
+
+	weddings := &MockWeddingRepository{}
+	weddings.On("GetByID", mock.Anything, f.wedding.ID).Return(f.wedding, nil)
+	f.storage.On("Upload", mock.Anything, mock.Anything, mock.Anything, "application/pdf", mock.Anything).
+		Run(func(args mock.Arguments) {
+			f.key = args.String(1)
+			f.uploaded = args.Get(2).([]byte)
+		}).
+		Return("https://cdn.example.com/invitation.pdf", nil)
+
+	links := NewGuestQRService(f.guests, weddings, "guest-link-secret", "https://invite.example.com")
+	f.queue = NewJobQueue(NewMockJobRepository(), JobQueueOptions{}, logger)
+	f.service = NewInvitationPDFService(weddings, f.guests, f.jobs, links, f.storage, f.queue, logger)
+	RegisterJobHandlers(f.queue, nil, nil, nil, nil, f.service, nil, nil, nil, nil)
+	return f
+}
+
+func (f *invitationPDFFixture) addGuest(firstName, lastName, rsvpStatus string) *models.Guest {
+	guest := &models.Guest{ID: models.NewID(), WeddingID: f.wedding.ID, FirstName: firstName, LastName: lastName, RSVPStatus: rsvpStatus}
+	f.guests.guests[guest.ID] = guest
+	return guest
+}
+
+// pdfContents returns the decompressed content streams of a PDF, one per page
+func pdfContents(t *testing.T, data []byte) []string {
+	t.Helper()
+	var contents []string
+	for _, match := range regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindAllSubmatch(data, -1) {
+		reader, err := zlib.NewReader(bytes.NewReader(match[1]))
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		contents = append(contents, string(content))
+	}
+	return contents
+}
+
+func TestInvitationPDFService_QueueExport(t *testing.T) {
+	ctx := context.Background()
+	f := setupInvitationPDFService(t)
+
+	job, err := f.service.QueueExport(ctx, f.wedding.ID, f.wedding.UserID, InvitationPDFRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, models.ExportJobQueued, job.Status)
+	assert.Equal(t, InvitationPDFResource, job.Resource)
+
+	_, err = f.service.DownloadURL(ctx, f.wedding.ID, f.wedding.UserID, job.ID)
+	assert.ErrorIs(t, err, ErrExportNotReady)
+
+	claimed, err := f.queue.ProcessNext(ctx)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	stored, err := f.service.GetExport(ctx, f.wedding.ID, f.wedding.UserID, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExportJobCompleted, stored.Status)
+	assert.Equal(t, 1, stored.Records)
+	assert.Regexp(t, `^exports/invitations/`+f.wedding.ID.String()+"/"+job.ID.String()+`-[\w-]+\.pdf$`, f.key)
+
+	assert.True(t, bytes.HasPrefix(f.uploaded, []byte("%PDF-")))
+	pages := pdfContents(t, f.uploaded)
+	require.Len(t, pages, 1)
+	assert.Contains(t, pages[0], "(Ana Putri)")
+	assert.Contains(t, pages[0], "(Budi Santoso)")
+	assert.Contains(t, pages[0], "(Saturday, June 20, 2026 at 18:00)", "dated in the venue's time zone")
+	assert.Contains(t, pages[0], "(Gedung Serbaguna)")
+	assert.Contains(t, pages[0], "0.54 0.35 0.27 RG", "bordered in the theme's primary color")
+	assert.NotContains(t, pages[0], "(Dear ")
+
+	f.storage.On("GetPresignedURL", mock.Anything, f.key, invitationPDFLinkExpiry).Return("https://cdn.example.com/signed", nil)
+	url, err := f.service.DownloadURL(ctx, f.wedding.ID, f.wedding.UserID, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/signed", url)
+
+	_, err = f.service.GetExport(ctx, f.wedding.ID, models.NewID(), job.ID)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestInvitationPDFService_Personalized(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("one guest", func(t *testing.T) {
+		f := setupInvitationPDFService(t)
+		guest := f.addGuest("Cara", "Diaz", "pending")
+		f.addGuest("Dewi", "Lestari", "pending")
+
+		job, err := f.service.QueueExport(ctx, f.wedding.ID, f.wedding.UserID, InvitationPDFRequest{GuestID: &guest.ID})
+		require.NoError(t, err)
+		_, err = f.queue.ProcessNext(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, f.jobs.jobs[job.ID].Records)
+		pages := pdfContents(t, f.uploaded)
+		require.Len(t, pages, 1)
+		assert.Contains(t, pages[0], "(Dear Cara Diaz,)")
+	})
+
+	t.Run("a page per guest matching the filters", func(t *testing.T) {
+		f := setupInvitationPDFService(t)
+		f.addGuest("Cara", "Diaz", "pending")
+		f.addGuest("Dewi", "Lestari", "pending")
+		f.addGuest("Eko", "Prasetyo", "declined")
+
+		req := InvitationPDFRequest{Personalized: true}
+		req.Filters.RSVPStatus = "pending"
+		job, err := f.service.QueueExport(ctx, f.wedding.ID, f.wedding.UserID, req)
+		require.NoError(t, err)
+		_, err = f.queue.ProcessNext(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, models.ExportJobCompleted, f.jobs.jobs[job.ID].Status)
+		assert.Equal(t, 2, f.jobs.jobs[job.ID].Records)
+		pages := pdfContents(t, f.uploaded)
+		require.Len(t, pages, 2)
+		all := pages[0] + pages[1]
+		assert.Contains(t, all, "(Dear Cara Diaz,)")
+		assert.Contains(t, all, "(Dear Dewi Lestari,)")
+		assert.NotContains(t, all, "Eko")
+	})
+
+	t.Run("no matching guests fails the export", func(t *testing.T) {
+		f := setupInvitationPDFService(t)
+		req := InvitationPDFRequest{Personalized: true}
+		job, err := f.service.QueueExport(ctx, f.wedding.ID, f.wedding.UserID, req)
+		require.NoError(t, err)
+
+		err = f.service.GenerateExport(ctx, InvitationPDFJob{ExportJobID: job.ID, WeddingID: f.wedding.ID, Request: req}, false)
+		assert.ErrorIs(t, err, ErrNoInvitationPDFGuests)
+		assert.Equal(t, models.ExportJobFailed, f.jobs.jobs[job.ID].Status)
+		f.storage.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("guests of other weddings are refused", func(t *testing.T) {
+		f := setupInvitationPDFService(t)
+		other := &models.Guest{ID: models.NewID(), WeddingID: models.NewID(), FirstName: "Eve"}
+		f.guests.guests[other.ID] = other
+
+		_, err := f.service.QueueExport(ctx, f.wedding.ID, f.wedding.UserID, InvitationPDFRequest{GuestID: &other.ID})
+		assert.ErrorIs(t, err, ErrGuestNotFound)
+	})
+}
@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+func setupInvitationService(t *testing.T, queueSize int) (*InvitationService, *MockGuestRepository, *MockEmailService, *models.Wedding) {
+	guestRepo := NewMockGuestRepository()
+	weddingRepo := &MockWeddingRepository{}
+	email := &MockEmailService{}
+
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		UserID: models.NewID(),
+		Slug:   "alice-and-bob",
+		Title:  "Alice & Bob",
+	}
+	wedding.Event.Date = time.Date(2026, 6, 20, 0, 0, 0, 0, time.UTC)
+	wedding.Event.VenueName = "Rose Garden"
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+	weddingRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+
+	service := NewInvitationService(guestRepo, weddingRepo, email, InvitationOptions{
+		SiteURL:   "https://invites.example.com/",
+		QueueSize: queueSize,
+	}, zaptest.NewLogger(t))
+	return service, guestRepo, email, wedding
+}
+
+func addInvitationGuest(repo *MockGuestRepository, weddingID models.ID, email, status string) *models.Guest {
+	guest := &models.Guest{
+		WeddingID:        weddingID,
+		FirstName:        "Carol",
+		LastName:         "Smith",
+		Email:            email,
+		InvitationStatus: status,
+	}
+	repo.Create(context.Background(), guest)
+	return guest
+}
+
+// drainInvitations delivers every queued invitation on the calling goroutine
+func drainInvitations(service *InvitationService) {
+	for {
+		select {
+		case job := <-service.jobs:
+			service.deliver(context.Background(), job)
+		default:
+			return
+		}
+	}
+}
+
+func TestInvitationService_SendInvite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Sends the invitation and marks the guest sent", func(t *testing.T) {
+		service, guestRepo, email, wedding := setupInvitationService(t, 0)
+		guest := addInvitationGuest(guestRepo, wedding.ID, "carol@example.com", models.InvitationStatusPending)
+
+		_, err := service.SendInvite(ctx, wedding.ID, guest.ID, wedding.UserID, "")
+		require.NoError(t, err)
+		drainInvitations(service)
+
+		require.Len(t, email.sent, 1)
+		assert.Equal(t, "carol@example.com", email.sent[0].To)
+		assert.Contains(t, email.sent[0].Subject, "Alice & Bob")
+		link := "https://invites.example.com/w/alice-and-bob?guest=" + guest.ID.String() + "#rsvp"
+		assert.Contains(t, email.sent[0].Text, link)
+		assert.Contains(t, email.sent[0].Text, "Saturday, June 20, 2026")
+		assert.Contains(t, email.sent[0].HTML, "Dear Carol Smith")
+		assert.Contains(t, email.sent[0].HTML, "Rose Garden")
+
+		assert.Equal(t, models.InvitationStatusSent, guest.InvitationStatus)
+		assert.NotNil(t, guest.InvitationSentAt)
+		assert.Empty(t, guest.InvitationError)
+	})
+
+	t.Run("Marks the guest failed when delivery fails", func(t *testing.T) {
+		service, guestRepo, email, wedding := setupInvitationService(t, 0)
+		email.err = errors.New("mailbox unavailable")
+		guest := addInvitationGuest(guestRepo, wedding.ID, "carol@example.com", models.InvitationStatusPending)
+
+		_, err := service.SendInvite(ctx, wedding.ID, guest.ID, wedding.UserID, "")
+		require.NoError(t, err)
+		drainInvitations(service)
+
+		assert.Equal(t, models.InvitationStatusFailed, guest.InvitationStatus)
+		assert.Nil(t, guest.InvitationSentAt)
+		assert.Contains(t, guest.InvitationError, "mailbox unavailable")
+	})
+
+	t.Run("Error - guest has no email", func(t *testing.T) {
+		service, guestRepo, _, wedding := setupInvitationService(t, 0)
+		guest := addInvitationGuest(guestRepo, wedding.ID, "", models.InvitationStatusPending)
+
+		_, err := service.SendInvite(ctx, wedding.ID, guest.ID, wedding.UserID, "")
+		assert.ErrorIs(t, err, ErrGuestHasNoEmail)
+	})
+
+	t.Run("Error - guest of another wedding", func(t *testing.T) {
+		service, guestRepo, _, wedding := setupInvitationService(t, 0)
+		guest := addInvitationGuest(guestRepo, models.NewID(), "carol@example.com", models.InvitationStatusPending)
+
+		_, err := service.SendInvite(ctx, wedding.ID, guest.ID, wedding.UserID, "")
+		assert.ErrorIs(t, err, ErrGuestNotFound)
+	})
+
+	t.Run("Error - not the owner", func(t *testing.T) {
+		service, guestRepo, _, wedding := setupInvitationService(t, 0)
+		guest := addInvitationGuest(guestRepo, wedding.ID, "carol@example.com", models.InvitationStatusPending)
+
+		_, err := service.SendInvite(ctx, wedding.ID, guest.ID, models.NewID(), "")
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Error - wedding not found", func(t *testing.T) {
+		service, _, _, wedding := setupInvitationService(t, 0)
+
+		_, err := service.SendInvite(ctx, models.NewID(), models.NewID(), wedding.UserID, "")
+		assert.ErrorIs(t, err, ErrWeddingNotFound)
+	})
+}
+
+func TestInvitationService_SendInvites(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Invites pending and failed guests with an email", func(t *testing.T) {
+		service, guestRepo, email, wedding := setupInvitationService(t, 0)
+		pending := addInvitationGuest(guestRepo, wedding.ID, "pending@example.com", models.InvitationStatusPending)
+		failed := addInvitationGuest(guestRepo, wedding.ID, "failed@example.com", models.InvitationStatusFailed)
+		addInvitationGuest(guestRepo, wedding.ID, "sent@example.com", models.InvitationStatusSent)
+		addInvitationGuest(guestRepo, wedding.ID, "", models.InvitationStatusPending)
+		addInvitationGuest(guestRepo, models.NewID(), "other@example.com", models.InvitationStatusPending)
+
+		result, err := service.SendInvites(ctx, wedding.ID, wedding.UserID, nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Queued)
+		assert.Equal(t, 1, result.Skipped)
+
+		drainInvitations(service)
+		assert.Len(t, email.sent, 2)
+		assert.Equal(t, models.InvitationStatusSent, pending.InvitationStatus)
+		assert.Equal(t, models.InvitationStatusSent, failed.InvitationStatus)
+	})
+
+	t.Run("Invites only the selected guests and skips queued ones", func(t *testing.T) {
+		service, guestRepo, _, wedding := setupInvitationService(t, 0)
+		sent := addInvitationGuest(guestRepo, wedding.ID, "sent@example.com", models.InvitationStatusSent)
+		addInvitationGuest(guestRepo, wedding.ID, "pending@example.com", models.InvitationStatusPending)
+
+		ids := []models.ID{sent.ID, sent.ID, models.NewID()}
+		result, err := service.SendInvites(ctx, wedding.ID, wedding.UserID, ids, "")
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Queued)
+		assert.Equal(t, 2, result.Skipped)
+	})
+
+	t.Run("Error - queue full", func(t *testing.T) {
+		service, guestRepo, _, wedding := setupInvitationService(t, 1)
+		addInvitationGuest(guestRepo, wedding.ID, "one@example.com", models.InvitationStatusPending)
+		addInvitationGuest(guestRepo, wedding.ID, "two@example.com", models.InvitationStatusPending)
+
+		result, err := service.SendInvites(ctx, wedding.ID, wedding.UserID, nil, "")
+		assert.ErrorIs(t, err, ErrInvitationQueueFull)
+		require.NotNil(t, result)
+		assert.Equal(t, 1, result.Queued)
+	})
+}
+
+func TestInvitationService_Households(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*InvitationService, *MockGuestRepository, *MockEmailService, *models.Wedding, *models.GuestGroup, []*models.Guest) {
+		service, guestRepo, email, wedding := setupInvitationService(t, 0)
+		groups := NewMockGuestGroupRepository()
+		service.EnableHouseholds(groups)
+
+		// The primary guest has no email, so the invitation goes to the next member
+		child := addInvitationGuest(guestRepo, wedding.ID, "", models.InvitationStatusPending)
+		parent := addInvitationGuest(guestRepo, wedding.ID, "parent@example.com", models.InvitationStatusPending)
+		primary := addInvitationGuest(guestRepo, wedding.ID, "", models.InvitationStatusPending)
+		group := &models.GuestGroup{WeddingID: wedding.ID, Name: "The Smith Family", PrimaryGuestID: primary.ID}
+		require.NoError(t, groups.Create(ctx, group))
+		members := []*models.Guest{primary, parent, child}
+		for _, member := range members {
+			member.GroupID = &group.ID
+		}
+		return service, guestRepo, email, wedding, group, members
+	}
+
+	t.Run("One invitation marks every member sent", func(t *testing.T) {
+		service, _, email, wedding, group, members := setup(t)
+
+		guest, err := service.SendGroupInvite(ctx, wedding.ID, group.ID, wedding.UserID, "")
+		require.NoError(t, err)
+		assert.Equal(t, members[1].ID, guest.ID)
+		drainInvitations(service)
+
+		require.Len(t, email.sent, 1)
+		assert.Equal(t, "parent@example.com", email.sent[0].To)
+		assert.Contains(t, email.sent[0].HTML, "Dear The Smith Family")
+		for _, member := range members {
+			assert.Equal(t, models.InvitationStatusSent, member.InvitationStatus)
+		}
+	})
+
+	t.Run("Bulk sends collapse members into one invitation", func(t *testing.T) {
+		service, guestRepo, email, wedding, _, members := setup(t)
+		single := addInvitationGuest(guestRepo, wedding.ID, "single@example.com", models.InvitationStatusPending)
+
+		result, err := service.SendInvites(ctx, wedding.ID, wedding.UserID, []models.ID{members[0].ID, members[2].ID, single.ID}, "")
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Queued)
+		assert.Equal(t, 0, result.Skipped)
+
+		// A member sent alone gets the household invitation, which is already queued
+		_, err = service.SendInvite(ctx, wedding.ID, members[1].ID, wedding.UserID, "")
+		require.NoError(t, err)
+		drainInvitations(service)
+		assert.Len(t, email.sent, 2)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		service, _, _, wedding, group, members := setup(t)
+
+		_, err := service.SendGroupInvite(ctx, wedding.ID, models.NewID(), wedding.UserID, "")
+		assert.ErrorIs(t, err, ErrGuestGroupNotFound)
+
+		_, err = service.SendGroupInvite(ctx, wedding.ID, group.ID, models.NewID(), "")
+		assert.ErrorIs(t, err, ErrUnauthorized)
+
+		members[1].Email = ""
+		_, err = service.SendGroupInvite(ctx, wedding.ID, group.ID, wedding.UserID, "")
+		assert.ErrorIs(t, err, ErrGuestHasNoEmail)
+	})
+}
+
+func TestInvitationService_WhatsApp(t *testing.T) {
+	ctx := context.Background()
+	template := MessageTemplate{Name: "wedding_invitation", Language: "en"}
+
+	t.Run("Sends the template and follows its delivery", func(t *testing.T) {
+		service, guestRepo, email, wedding := setupInvitationService(t, 0)
+		whatsapp := &MockWhatsAppService{}
+		service.EnableMessaging(models.InvitationChannelWhatsApp, whatsapp, template)
+		guest := addInvitationGuest(guestRepo, wedding.ID, "carol@example.com", models.InvitationStatusPending)
+		guest.Phone = "+62 812 3456"
+
+		_, err := service.SendInvite(ctx, wedding.ID, guest.ID, wedding.UserID, models.InvitationChannelWhatsApp)
+		require.NoError(t, err)
+		drainInvitations(service)
+
+		assert.Empty(t, email.sent)
+		require.Len(t, whatsapp.templates, 1)
+		msg := whatsapp.templates[0]
+		assert.Equal(t, guest.Phone, msg.To)
+		assert.Equal(t, template, msg.Template)
+		assert.Equal(t, []string{"Carol Smith", "Alice & Bob", "Saturday, June 20, 2026", service.RSVPLink(wedding, guest)}, msg.Params)
+
+		assert.Equal(t, models.InvitationStatusSent, guest.InvitationStatus)
+		assert.Equal(t, models.InvitationChannelWhatsApp, guest.InvitationChannel)
+		assert.Equal(t, "wamid.1", guest.InvitationMessageID)
+
+		// Reports arrive out of order; a late delivered report does not undo read
+		require.NoError(t, service.HandleMessageStatuses(ctx, []MessageStatus{
+			{MessageID: "wamid.1", Status: MessageStatusRead},
+			{MessageID: "wamid.1", Status: MessageStatusDelivered},
+			{MessageID: "wamid.unknown", Status: MessageStatusDelivered},
+		}))
+		assert.Equal(t, models.InvitationStatusRead, guest.InvitationStatus)
+	})
+
+	t.Run("Failed delivery records the reason", func(t *testing.T) {
+		service, guestRepo, _, wedding := setupInvitationService(t, 0)
+		service.EnableMessaging(models.InvitationChannelWhatsApp, &MockWhatsAppService{}, template)
+		guest := addInvitationGuest(guestRepo, wedding.ID, "", models.InvitationStatusPending)
+		guest.Phone = "+62 812 3456"
+
+		result, err := service.SendInvites(ctx, wedding.ID, wedding.UserID, nil, models.InvitationChannelWhatsApp)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Queued)
+		drainInvitations(service)
+
+		require.NoError(t, service.HandleMessageStatuses(ctx, []MessageStatus{
+			{MessageID: guest.InvitationMessageID, Status: MessageStatusFailed, Reason: "Message undeliverable"},
+		}))
+		assert.Equal(t, models.InvitationStatusFailed, guest.InvitationStatus)
+		assert.Equal(t, "Message undeliverable", guest.InvitationError)
+	})
+
+	t.Run("Error - guest without phone", func(t *testing.T) {
+		service, guestRepo, _, wedding := setupInvitationService(t, 0)
+		service.EnableMessaging(models.InvitationChannelWhatsApp, &MockWhatsAppService{}, template)
+		guest := addInvitationGuest(guestRepo, wedding.ID, "carol@example.com", models.InvitationStatusPending)
+
+		_, err := service.SendInvite(ctx, wedding.ID, guest.ID, wedding.UserID, models.InvitationChannelWhatsApp)
+		assert.ErrorIs(t, err, ErrGuestHasNoPhone)
+	})
+
+	t.Run("Error - channel not enabled", func(t *testing.T) {
+		service, guestRepo, _, wedding := setupInvitationService(t, 0)
+		guest := addInvitationGuest(guestRepo, wedding.ID, "carol@example.com", models.InvitationStatusPending)
+
+		_, err := service.SendInvite(ctx, wedding.ID, guest.ID, wedding.UserID, models.InvitationChannelWhatsApp)
+		assert.ErrorIs(t, err, ErrInvitationChannel)
+	})
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// Built-in background job types
+const (
+	JobTypeGenerateThumbnails    = "media.generate_thumbnails"
+	JobTypeTranscodeVideo        = "media.transcode_video"
+	JobTypeScanMedia             = "media.scan"
+	JobTypeReconcileAnalytics    = "analytics.reconcile"
+	JobTypeSendEmail             = "email.send"
+	JobTypeExportAnalytics       = "analytics.export"
+	JobTypeExportAccount         = "account.export"
+	JobTypeExportInvitationPDF   = "invitation.export_pdf"
+	JobTypeDeliverWeddingWebhook = "webhook.deliver"
+	JobTypeSendPush              = "push.send"
+)
+
+// ThumbnailJob generates the thumbnails of an uploaded image
+type ThumbnailJob struct {
+	MediaID models.ID `bson:"media_id"`
+}
+
+// VideoJob transcodes an uploaded video
+type VideoJob struct {
+	MediaID models.ID `bson:"media_id"`
+}
+
+// MediaScanJob scans an uploaded file for malware
+type MediaScanJob struct {
+	MediaID models.ID `bson:"media_id"`
+}
+
+// AnalyticsReconcileJob reconciles a wedding's analytics counters with its raw events
+type AnalyticsReconcileJob struct {
+	WeddingID models.ID `bson:"wedding_id"`
+}
+
+// AnalyticsExportJob generates an analytics export into storage
+type AnalyticsExportJob struct {
+	ExportJobID models.ID              `bson:"export_job_id"`
+	WeddingID   models.ID              `bson:"wedding_id"`
+	Request     AnalyticsExportRequest `bson:"request"`
+}
+
+// AccountExportJob generates an export of a user's data into storage
+type AccountExportJob struct {
+	ExportJobID models.ID `bson:"export_job_id"`
+	UserID      models.ID `bson:"user_id"`
+}
+
+// InvitationPDFJob renders a wedding's invitation PDF into storage
+type InvitationPDFJob struct {
+	ExportJobID models.ID            `bson:"export_job_id"`
+	WeddingID   models.ID            `bson:"wedding_id"`
+	Request     InvitationPDFRequest `bson:"request"`
+}
+
+// EmailJob delivers one email
+type EmailJob struct {
+	To      string `bson:"to"`
+	Subject string `bson:"subject"`
+	HTML    string `bson:"html"`
+	Text    string `bson:"text,omitempty"`
+}
+
+// WeddingWebhookJob delivers one event to one wedding webhook
+type WeddingWebhookJob struct {
+	WebhookID models.ID           `bson:"webhook_id"`
+	Event     models.WeddingEvent `bson:"event"`
+}
+
+// RegisterJobHandlers registers the handlers of the built-in job types
+func RegisterJobHandlers(queue *JobQueue, media MediaService, analytics AnalyticsService, analyticsExports *AnalyticsExportService, accountExports *AccountExportService, invitationPDFs *InvitationPDFService, email EmailService, webhooks *WeddingWebhookService, push *PushService, scans *MediaScanService) {
+	queue.Handle(JobTypeGenerateThumbnails, func(ctx context.Context, job *models.Job) error {
+		var payload ThumbnailJob
+		if err := job.DecodePayload(&payload); err != nil {
+			return PermanentJobError(fmt.Errorf("invalid thumbnail job: %w", err))
+		}
+		return media.GenerateThumbnails(ctx, payload.MediaID)
+	})
+
+	queue.Handle(JobTypeTranscodeVideo, func(ctx context.Context, job *models.Job) error {
+		var payload VideoJob
+		if err := job.DecodePayload(&payload); err != nil {
+			return PermanentJobError(fmt.Errorf("invalid video job: %w", err))
+		}
+		lastAttempt := job.MaxAttempts > 0 && job.Attempts >= job.MaxAttempts
+		return media.TranscodeVideo(ctx, payload.MediaID, lastAttempt)
+	})
+
+	queue.Handle(JobTypeScanMedia, func(ctx context.Context, job *models.Job) error {
+		var payload MediaScanJob
+		if err := job.DecodePayload(&payload); err != nil {
+			return PermanentJobError(fmt.Errorf("invalid media scan job: %w", err))
+		}
+		return scans.ScanMedia(ctx, payload.MediaID)
+	})
+
+	queue.Handle(JobTypeReconcileAnalytics, func(ctx context.Context, job *models.Job) error {
+		var payload AnalyticsReconcileJob
+		if err := job.DecodePayload(&payload); err != nil {
+			return PermanentJobError(fmt.Errorf("invalid analytics job: %w", err))
+		}
+		return analytics.RefreshWeddingAnalytics(ctx, payload.WeddingID)
+	})
+
+	queue.Handle(JobTypeExportAnalytics, func(ctx context.Context, job *models.Job) error {
+		var payload AnalyticsExportJob
+		if err := job.DecodePayload(&payload); err != nil {
+			return PermanentJobError(fmt.Errorf("invalid analytics export job: %w", err))
+		}
+		lastAttempt := job.MaxAttempts > 0 && job.Attempts >= job.MaxAttempts
+		return analyticsExports.GenerateExport(ctx, payload, lastAttempt)
+	})
+
+	queue.Handle(JobTypeExportAccount, func(ctx context.Context, job *models.Job) error {
+		var payload AccountExportJob
+		if err := job.DecodePayload(&payload); err != nil {
+			return PermanentJobError(fmt.Errorf("invalid account export job: %w", err))
+		}
+		lastAttempt := job.MaxAttempts > 0 && job.Attempts >= job.MaxAttempts
+		return accountExports.GenerateExport(ctx, payload, lastAttempt)
+	})
+
+	queue.Handle(JobTypeExportInvitationPDF, func(ctx context.Context, job *models.Job) error {
+		var payload InvitationPDFJob
+		if err := job.DecodePayload(&payload); err != nil {
+			return PermanentJobError(fmt.Errorf("invalid invitation PDF job: %w", err))
+		}
+		lastAttempt := job.MaxAttempts > 0 && job.Attempts >= job.MaxAttempts
+		return invitationPDFs.GenerateExport(ctx, payload, lastAttempt)
+	})
+
+	queue.Handle(JobTypeSendEmail, func(ctx context.Context, job *models.Job) error {
+		var payload EmailJob
+		if err := job.DecodePayload(&payload); err != nil {
+			return PermanentJobError(fmt.Errorf("invalid email job: %w", err))
+		}
+		err := email.Send(ctx, &EmailMessage{
+			To:      payload.To,
+			Subject: payload.Subject,
+			HTML:    payload.HTML,
+			Text:    payload.Text,
+		})
+		if errors.Is(err, ErrEmailSuppressed) {
+			return PermanentJobError(err)
+		}
+		return err
+	})
+
+	queue.Handle(JobTypeDeliverWeddingWebhook, func(ctx context.Context, job *models.Job) error {
+		var payload WeddingWebhookJob
+		if err := job.DecodePayload(&payload); err != nil {
+			return PermanentJobError(fmt.Errorf("invalid wedding webhook job: %w", err))
+		}
+		return webhooks.Deliver(ctx, payload, job.Attempts)
+	})
+
+	queue.Handle(JobTypeSendPush, func(ctx context.Context, job *models.Job) error {
+		var payload PushJob
+		if err := job.DecodePayload(&payload); err != nil {
+			return PermanentJobError(fmt.Errorf("invalid push job: %w", err))
+		}
+		return push.Deliver(ctx, payload)
+	})
+}
+
+// QueuedEmailService is an EmailService that hands emails to the job queue, which
+// delivers them in the background and retries provider failures
+type QueuedEmailService struct {
+	jobs JobEnqueuer
+}
+
+// NewQueuedEmailService creates an email service that sends through background jobs
+func NewQueuedEmailService(jobs JobEnqueuer) *QueuedEmailService {
+	return &QueuedEmailService{jobs: jobs}
+}
+
+// Send queues the email for delivery
+func (s *QueuedEmailService) Send(ctx context.Context, msg *EmailMessage) error {
+	return s.jobs.Enqueue(ctx, JobTypeSendEmail, EmailJob{
+		To:      msg.To,
+		Subject: msg.Subject,
+		HTML:    msg.HTML,
+		Text:    msg.Text,
+	})
+}
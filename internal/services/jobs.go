@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/tracing"
+)
+
+// JobHandler runs one background job. Returning an error retries the job with
+// backoff unless the error is permanent (see PermanentJobError).
+type JobHandler func(ctx context.Context, job *models.Job) error
+
+// JobEnqueuer queues background jobs
+type JobEnqueuer interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}) error
+	// EnqueueUnique queues a job unless one of the same type and key is still waiting to run
+	EnqueueUnique(ctx context.Context, jobType, key string, payload interface{}) error
+}
+
+// permanentJobError marks a job failure that retrying cannot fix
+type permanentJobError struct {
+	err error
+}
+
+func (e *permanentJobError) Error() string { return e.err.Error() }
+func (e *permanentJobError) Unwrap() error { return e.err }
+
+// PermanentJobError wraps err so the job fails without being retried
+func PermanentJobError(err error) error {
+	return &permanentJobError{err: err}
+}
+
+// JobQueueOptions configures the background job workers
+type JobQueueOptions struct {
+	Workers      int           // Number of jobs processed concurrently
+	PollInterval time.Duration // Idle wait between empty polls
+	Lease        time.Duration // How long a claimed job is hidden from other workers
+	MaxAttempts  int           // Attempts before a failing job is marked failed
+	Backoff      time.Duration // Delay before the first retry; doubles with every attempt
+	MaxBackoff   time.Duration // Upper bound of the retry delay
+}
+
+// DefaultJobQueueOptions returns sensible defaults for the job workers
+func DefaultJobQueueOptions() JobQueueOptions {
+	return JobQueueOptions{
+		Workers:      4,
+		PollInterval: time.Second,
+		Lease:        5 * time.Minute,
+		MaxAttempts:  5,
+		Backoff:      30 * time.Second,
+		MaxBackoff:   time.Hour,
+	}
+}
+
+// JobQueue runs background work such as thumbnail generation, analytics
+// reconciliation and email delivery from a durable queue, so work accepted by
+// the API survives crashes and restarts. Failing jobs are retried with
+// exponential backoff. The workers run in the API process or in cmd/worker.
+type JobQueue struct {
+	jobRepo  repository.JobRepository
+	opts     JobQueueOptions
+	logger   *zap.Logger
+	handlers map[string]JobHandler
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewJobQueue creates a new background job queue
+func NewJobQueue(jobRepo repository.JobRepository, opts JobQueueOptions, logger *zap.Logger) *JobQueue {
+	defaults := DefaultJobQueueOptions()
+	if opts.Workers <= 0 {
+		opts.Workers = defaults.Workers
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaults.PollInterval
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = defaults.Lease
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaults.MaxAttempts
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = defaults.Backoff
+	}
+	if opts.MaxBackoff < opts.Backoff {
+		opts.MaxBackoff = defaults.MaxBackoff
+	}
+
+	return &JobQueue{
+		jobRepo:  jobRepo,
+		opts:     opts,
+		logger:   logger,
+		handlers: make(map[string]JobHandler),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Handle registers the handler of a job type. Workers only claim job types
+// that have a handler, so handlers must be registered before Start.
+func (q *JobQueue) Handle(jobType string, handler JobHandler) {
+	q.handlers[jobType] = handler
+}
+
+// Enqueue queues a job whose payload is BSON-encoded for its handler
+func (q *JobQueue) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	return q.enqueue(ctx, jobType, "", payload)
+}
+
+// EnqueueUnique queues a job unless one of the same type and key is still waiting
+// to run; the waiting job covers the new work
+func (q *JobQueue) EnqueueUnique(ctx context.Context, jobType, key string, payload interface{}) error {
+	return q.enqueue(ctx, jobType, key, payload)
+}
+
+func (q *JobQueue) enqueue(ctx context.Context, jobType, key string, payload interface{}) error {
+	raw, err := bson.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s job: %w", jobType, err)
+	}
+
+	job := &models.Job{
+		Type:         jobType,
+		Payload:      raw,
+		UniqueKey:    key,
+		MaxAttempts:  q.opts.MaxAttempts,
+		TraceContext: tracing.Inject(ctx),
+	}
+	if err := q.jobRepo.Enqueue(ctx, job); err != nil {
+		return fmt.Errorf("failed to enqueue %s job: %w", jobType, err)
+	}
+	return nil
+}
+
+// ProcessNext runs the job that is due first. It reports whether a job was claimed.
+func (q *JobQueue) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := q.jobRepo.ClaimNext(ctx, q.types(), time.Now(), q.opts.Lease)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	q.process(ctx, job)
+	return true, nil
+}
+
+func (q *JobQueue) process(ctx context.Context, job *models.Job) {
+	handler, ok := q.handlers[job.Type]
+	if !ok {
+		q.fail(ctx, job, fmt.Sprintf("no handler for job type %s", job.Type))
+		return
+	}
+
+	jobCtx, cancel := context.WithTimeout(tracing.Extract(ctx, job.TraceContext), q.opts.Lease)
+	jobCtx, span := tracing.Start(jobCtx, "job "+job.Type,
+		attribute.String("job.id", job.ID.String()),
+		attribute.Int("job.attempt", job.Attempts))
+	err := handler(jobCtx, job)
+	tracing.End(span, err)
+	cancel()
+	if err == nil {
+		if err := q.jobRepo.MarkCompleted(ctx, job.ID); err != nil {
+			q.logger.Error("Failed to mark job completed", zap.Error(err), zap.String("job_id", job.ID.String()))
+		}
+		return
+	}
+
+	var permanent *permanentJobError
+	maxAttempts := job.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = q.opts.MaxAttempts
+	}
+	if errors.As(err, &permanent) || job.Attempts >= maxAttempts {
+		q.fail(ctx, job, err.Error())
+		return
+	}
+
+	delay := q.backoff(job.Attempts)
+	q.logger.Warn("Retrying job",
+		zap.Error(err),
+		zap.String("job_id", job.ID.String()),
+		zap.String("type", job.Type),
+		zap.Int("attempts", job.Attempts),
+		zap.Duration("delay", delay))
+	if err := q.jobRepo.Retry(ctx, job.ID, time.Now().Add(delay), err.Error()); err != nil {
+		q.logger.Error("Failed to reschedule job", zap.Error(err), zap.String("job_id", job.ID.String()))
+	}
+}
+
+func (q *JobQueue) fail(ctx context.Context, job *models.Job, reason string) {
+	q.logger.Error("Job failed",
+		zap.String("job_id", job.ID.String()),
+		zap.String("type", job.Type),
+		zap.Int("attempts", job.Attempts),
+		zap.String("reason", reason))
+	if err := q.jobRepo.MarkFailed(ctx, job.ID, reason); err != nil {
+		q.logger.Error("Failed to mark job failed", zap.Error(err), zap.String("job_id", job.ID.String()))
+	}
+}
+
+// backoff is the delay before retrying a job that failed its attempts-th attempt
+func (q *JobQueue) backoff(attempts int) time.Duration {
+	delay := q.opts.Backoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= q.opts.MaxBackoff {
+			return q.opts.MaxBackoff
+		}
+	}
+	return delay
+}
+
+func (q *JobQueue) types() []string {
+	types := make([]string, 0, len(q.handlers))
+	for jobType := range q.handlers {
+		types = append(types, jobType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Start launches the workers
+func (q *JobQueue) Start(ctx context.Context) {
+	for i := 0; i < q.opts.Workers; i++ {
+		q.wg.Add(1)
+		go q.run(ctx)
+	}
+}
+
+// Stop signals the workers to finish the jobs that are due and exit, and waits
+// for them
+func (q *JobQueue) Stop() {
+	close(q.stop)
+	q.wg.Wait()
+}
+
+func (q *JobQueue) run(ctx context.Context) {
+	defer q.wg.Done()
+
+	// Once stopped, the worker keeps going until nothing is due, draining
+	// the queue; cancelling ctx aborts it
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		claimed, err := q.ProcessNext(ctx)
+		if err != nil {
+			q.logger.Error("Job worker error", zap.Error(err))
+		}
+		if claimed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.stop:
+			return
+		case <-time.After(q.opts.PollInterval):
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/tracing"
+)
+
+// MockJobRepository is an in-memory background job queue
+type MockJobRepository struct {
+	jobs     map[models.ID]*models.Job
+	sequence int
+}
+
+func NewMockJobRepository() *MockJobRepository {
+	return &MockJobRepository{
+		jobs: make(map[models.ID]*models.Job),
+	}
+}
+
+func (m *MockJobRepository) Enqueue(ctx context.Context, job *models.Job) error {
+	if job.UniqueKey != "" {
+		for _, existing := range m.jobs {
+			if existing.Type == job.Type && existing.UniqueKey == job.UniqueKey && existing.Status == models.JobQueued {
+				return nil
+			}
+		}
+	}
+
+	// Strictly increasing timestamps keep ordering deterministic
+	m.sequence++
+	job.ID = models.NewID()
+	job.Status = models.JobQueued
+	job.CreatedAt = time.Unix(int64(m.sequence), 0)
+	job.RunAt = job.CreatedAt
+	m.jobs[job.ID] = job
+	return nil
+}
+
+func (m *MockJobRepository) GetByID(ctx context.Context, id models.ID) (*models.Job, error) {
+	job, exists := m.jobs[id]
+	if !exists {
+		return nil, repository.ErrNotFound
+	}
+	return job, nil
+}
+
+func (m *MockJobRepository) ClaimNext(ctx context.Context, types []string, now time.Time, lease time.Duration) (*models.Job, error) {
+	var due []*models.Job
+	for _, job := range m.jobs {
+		if job.Status == models.JobQueued && !job.RunAt.After(now) && contains(types, job.Type) {
+			due = append(due, job)
+		}
+	}
+	if len(due) == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	sort.Slice(due, func(i, j int) bool { return due[i].RunAt.Before(due[j].RunAt) })
+	next := due[0]
+	lockedUntil := now.Add(lease)
+	next.Status = models.JobRunning
+	next.LockedUntil = &lockedUntil
+	next.Attempts++
+	return next, nil
+}
+
+func (m *MockJobRepository) MarkCompleted(ctx context.Context, id models.ID) error {
+	m.jobs[id].Status = models.JobCompleted
+	return nil
+}
+
+func (m *MockJobRepository) MarkFailed(ctx context.Context, id models.ID, reason string) error {
+	m.jobs[id].Status = models.JobFailed
+	m.jobs[id].Error = reason
+	return nil
+}
+
+func (m *MockJobRepository) Retry(ctx context.Context, id models.ID, runAt time.Time, reason string) error {
+	job := m.jobs[id]
+	job.Status = models.JobQueued
+	job.RunAt = runAt
+	job.Error = reason
+	job.LockedUntil = nil
+	return nil
+}
+
+// byType returns the jobs of one type
+func (m *MockJobRepository) byType(jobType string) []*models.Job {
+	var jobs []*models.Job
+	for _, job := range m.jobs {
+		if job.Type == jobType {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+type testJob struct {
+	Name string `bson:"name"`
+}
+
+func TestJobQueue_ProcessNext(t *testing.T) {
+	ctx := context.Background()
+
+	newQueue := func(t *testing.T, handler JobHandler) (*JobQueue, *MockJobRepository) {
+		repo := NewMockJobRepository()
+		queue := NewJobQueue(repo, JobQueueOptions{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}, zaptest.NewLogger(t))
+		queue.Handle("test", handler)
+		return queue, repo
+	}
+
+	t.Run("Runs the handler with the payload", func(t *testing.T) {
+		var got testJob
+		queue, repo := newQueue(t, func(ctx context.Context, job *models.Job) error {
+			return job.DecodePayload(&got)
+		})
+		require.NoError(t, queue.Enqueue(ctx, "test", testJob{Name: "hello"}))
+
+		claimed, err := queue.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+		assert.Equal(t, "hello", got.Name)
+		assert.Equal(t, models.JobCompleted, repo.byType("test")[0].Status)
+
+		claimed, err = queue.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.False(t, claimed)
+	})
+
+	t.Run("Retries a failing job with backoff", func(t *testing.T) {
+		queue, repo := newQueue(t, func(ctx context.Context, job *models.Job) error {
+			return errors.New("provider down")
+		})
+		require.NoError(t, queue.Enqueue(ctx, "test", testJob{}))
+
+		before := time.Now()
+		_, err := queue.ProcessNext(ctx)
+		require.NoError(t, err)
+
+		job := repo.byType("test")[0]
+		assert.Equal(t, models.JobQueued, job.Status)
+		assert.Equal(t, "provider down", job.Error)
+		assert.WithinDuration(t, before.Add(time.Minute), job.RunAt, 5*time.Second)
+
+		// Not due until the backoff elapsed
+		claimed, err := queue.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.False(t, claimed)
+	})
+
+	t.Run("Fails once attempts are exhausted", func(t *testing.T) {
+		queue, repo := newQueue(t, func(ctx context.Context, job *models.Job) error {
+			return errors.New("provider down")
+		})
+		require.NoError(t, queue.Enqueue(ctx, "test", testJob{}))
+
+		job := repo.byType("test")[0]
+		for i := 0; i < 3; i++ {
+			job.RunAt = time.Time{}
+			_, err := queue.ProcessNext(ctx)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, models.JobFailed, job.Status)
+		assert.Equal(t, 3, job.Attempts)
+	})
+
+	t.Run("Fails permanent errors without retrying", func(t *testing.T) {
+		queue, repo := newQueue(t, func(ctx context.Context, job *models.Job) error {
+			return PermanentJobError(errors.New("media deleted"))
+		})
+		require.NoError(t, queue.Enqueue(ctx, "test", testJob{}))
+
+		_, err := queue.ProcessNext(ctx)
+		require.NoError(t, err)
+
+		job := repo.byType("test")[0]
+		assert.Equal(t, models.JobFailed, job.Status)
+		assert.Equal(t, 1, job.Attempts)
+		assert.Equal(t, "media deleted", job.Error)
+	})
+
+	t.Run("Leaves job types without a handler to other workers", func(t *testing.T) {
+		queue, repo := newQueue(t, func(ctx context.Context, job *models.Job) error { return nil })
+		require.NoError(t, queue.Enqueue(ctx, "other", testJob{}))
+
+		claimed, err := queue.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.False(t, claimed)
+		assert.Equal(t, models.JobQueued, repo.byType("other")[0].Status)
+	})
+}
+
+func TestJobQueue_StopDrainsDueJobs(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockJobRepository()
+	queue := NewJobQueue(repo, JobQueueOptions{Workers: 1, PollInterval: time.Hour}, zaptest.NewLogger(t))
+	queue.Handle("test", func(ctx context.Context, job *models.Job) error { return nil })
+	for i := 0; i < 3; i++ {
+		require.NoError(t, queue.Enqueue(ctx, "test", testJob{}))
+	}
+
+	queue.Start(ctx)
+	queue.Stop()
+
+	for _, job := range repo.byType("test") {
+		assert.Equal(t, models.JobCompleted, job.Status)
+	}
+}
+
+func TestJobQueue_EnqueueUnique(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockJobRepository()
+	queue := NewJobQueue(repo, JobQueueOptions{}, zaptest.NewLogger(t))
+	queue.Handle("test", func(ctx context.Context, job *models.Job) error { return nil })
+
+	require.NoError(t, queue.EnqueueUnique(ctx, "test", "a", testJob{}))
+	require.NoError(t, queue.EnqueueUnique(ctx, "test", "a", testJob{}))
+	require.NoError(t, queue.EnqueueUnique(ctx, "test", "b", testJob{}))
+	assert.Len(t, repo.byType("test"), 2)
+
+	// Once the waiting job ran, new work needs a new job
+	for claimed := true; claimed; {
+		var err error
+		claimed, err = queue.ProcessNext(ctx)
+		require.NoError(t, err)
+	}
+	require.NoError(t, queue.EnqueueUnique(ctx, "test", "a", testJob{}))
+	assert.Len(t, repo.byType("test"), 3)
+}
+
+func TestJobQueue_TraceContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+
+	queue := NewJobQueue(NewMockJobRepository(), JobQueueOptions{MaxAttempts: 3}, zaptest.NewLogger(t))
+	var handlerTraceID string
+	queue.Handle("test", func(ctx context.Context, job *models.Job) error {
+		handlerTraceID = tracing.TraceID(ctx)
+		return nil
+	})
+
+	// The job runs later, in another context, but within the trace that queued it
+	requestCtx, span := tracing.Start(context.Background(), "request")
+	require.NoError(t, queue.Enqueue(requestCtx, "test", testJob{}))
+	span.End()
+
+	claimed, err := queue.ProcessNext(context.Background())
+	require.NoError(t, err)
+	require.True(t, claimed)
+	assert.Equal(t, tracing.TraceID(requestCtx), handlerTraceID)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "job test", spans[1].Name())
+	assert.Equal(t, span.SpanContext().SpanID(), spans[1].Parent().SpanID())
+}
+
+func TestJobQueue_Backoff(t *testing.T) {
+	queue := NewJobQueue(NewMockJobRepository(), JobQueueOptions{Backoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}, zaptest.NewLogger(t))
+
+	assert.Equal(t, 30*time.Second, queue.backoff(1))
+	assert.Equal(t, time.Minute, queue.backoff(2))
+	assert.Equal(t, 4*time.Minute, queue.backoff(4))
+	assert.Equal(t, 5*time.Minute, queue.backoff(5))
+	assert.Equal(t, 5*time.Minute, queue.backoff(30))
+}
+
+func TestRegisterJobHandlers(t *testing.T) {
+	ctx := context.Background()
+	weddingID := models.NewID()
+
+	repo := NewMockJobRepository()
+	queue := NewJobQueue(repo, JobQueueOptions{}, zaptest.NewLogger(t))
+
+	analyticsRepo := &MockAnalyticsRepository{}
+	analytics := NewAnalyticsService(analyticsRepo, &MockWeddingRepository{}, zaptest.NewLogger(t))
+	email := &MockEmailService{}
+	RegisterJobHandlers(queue, nil, analytics, nil, nil, nil, email, nil, nil, nil)
+
+	t.Run("Queued emails are delivered by the worker", func(t *testing.T) {
+		require.NoError(t, NewQueuedEmailService(queue).Send(ctx, &EmailMessage{To: "guest@example.com", Subject: "Hello", HTML: "<p>Hi</p>"}))
+		assert.Empty(t, email.sent)
+
+		claimed, err := queue.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+		require.Len(t, email.sent, 1)
+		assert.Equal(t, "guest@example.com", email.sent[0].To)
+		assert.Equal(t, "<p>Hi</p>", email.sent[0].HTML)
+	})
+
+	t.Run("Reconciles the analytics counters of changed weddings", func(t *testing.T) {
+		scheduler := NewAnalyticsReconcileScheduler(analyticsRepo, queue, time.Hour, zaptest.NewLogger(t))
+		analyticsRepo.On("ListWeddingsToReconcile", mock.Anything, models.NilID, analyticsReconcileBatch).Return([]models.ID{weddingID}, nil)
+		analyticsRepo.On("UpdateWeddingAnalytics", mock.Anything, weddingID).Return(nil).Once()
+
+		// A reconciliation still waiting to run covers the next schedule
+		for i := 0; i < 2; i++ {
+			scheduled, err := scheduler.ScheduleDue(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 1, scheduled)
+		}
+		require.Len(t, repo.byType(JobTypeReconcileAnalytics), 1)
+
+		claimed, err := queue.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+		assert.Equal(t, models.JobCompleted, repo.byType(JobTypeReconcileAnalytics)[0].Status)
+		analyticsRepo.AssertExpectations(t)
+	})
+}
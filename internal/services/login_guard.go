@@ -0,0 +1,333 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// maxKnownDevices caps the devices remembered per user; the least recently
+// seen is forgotten first
+const maxKnownDevices = 20
+
+var (
+	// ErrAccountLocked is returned for logins to an account locked after too many wrong passwords
+	ErrAccountLocked = errors.New("account is temporarily locked")
+	// ErrInvalidUnlockToken is returned for unlock links that were used, replaced or never sent
+	ErrInvalidUnlockToken = errors.New("invalid unlock token")
+)
+
+// LoginGuardOptions configures account lockout
+type LoginGuardOptions struct {
+	// MaxFailedAttempts is the number of wrong passwords in a row that locks the account
+	MaxFailedAttempts int
+	// LockDuration is how long the first lockout lasts; each further lockout
+	// before a successful login lasts twice as long, up to MaxLockDuration
+	LockDuration    time.Duration
+	MaxLockDuration time.Duration
+	// SiteURL is the frontend the unlock links point to
+	SiteURL string
+}
+
+// DefaultLoginGuardOptions returns the default lockout policy
+func DefaultLoginGuardOptions() LoginGuardOptions {
+	return LoginGuardOptions{
+		MaxFailedAttempts: 5,
+		LockDuration:      15 * time.Minute,
+		MaxLockDuration:   24 * time.Hour,
+		SiteURL:           DefaultInvitationOptions().SiteURL,
+	}
+}
+
+// LoginGuard protects accounts beyond the per-IP rate limits. Too many wrong
+// passwords in a row lock the account for a while and email the owner a link
+// that lifts the lock, and logins from a device or country the account was not
+// used from before are reported to the owner by email.
+type LoginGuard struct {
+	userRepo repository.UserRepository
+	email    EmailService
+	geoIP    GeoIPProvider
+	opts     LoginGuardOptions
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+// NewLoginGuard creates a new login guard
+func NewLoginGuard(userRepo repository.UserRepository, email EmailService, opts LoginGuardOptions, logger *zap.Logger) *LoginGuard {
+	defaults := DefaultLoginGuardOptions()
+	if opts.MaxFailedAttempts <= 0 {
+		opts.MaxFailedAttempts = defaults.MaxFailedAttempts
+	}
+	if opts.LockDuration <= 0 {
+		opts.LockDuration = defaults.LockDuration
+	}
+	if opts.MaxLockDuration <= 0 {
+		opts.MaxLockDuration = defaults.MaxLockDuration
+	}
+	if opts.MaxLockDuration < opts.LockDuration {
+		opts.MaxLockDuration = opts.LockDuration
+	}
+	if opts.SiteURL == "" {
+		opts.SiteURL = defaults.SiteURL
+	}
+	opts.SiteURL = strings.TrimRight(opts.SiteURL, "/")
+
+	return &LoginGuard{
+		userRepo: userRepo,
+		email:    email,
+		opts:     opts,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// SetGeoIPProvider locates logins so that logins from new countries are
+// reported. Without one, only new devices are.
+func (g *LoginGuard) SetGeoIPProvider(geoIP GeoIPProvider) {
+	g.geoIP = geoIP
+}
+
+// CheckLocked returns ErrAccountLocked while the user's logins are locked
+func (g *LoginGuard) CheckLocked(user *models.User) error {
+	if user.LoginLocked(g.now()) {
+		return ErrAccountLocked
+	}
+	return nil
+}
+
+// RecordFailure counts a wrong password. Once too many were entered in a row
+// the account locks, the owner is emailed an unlock link and ErrAccountLocked
+// is returned.
+func (g *LoginGuard) RecordFailure(ctx context.Context, user *models.User) error {
+	updated, err := g.userRepo.RecordFailedLogin(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record failed login: %w", err)
+	}
+	if updated == nil || updated.LoginSecurity == nil || updated.LoginSecurity.FailedAttempts < g.opts.MaxFailedAttempts {
+		return nil
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+	until := g.now().Add(g.lockDuration(updated.LoginSecurity.Lockouts))
+	if err := g.userRepo.LockLogin(ctx, user.ID, until, hashUnlockToken(token)); err != nil {
+		return fmt.Errorf("failed to lock account: %w", err)
+	}
+	g.logger.Warn("Account locked after failed logins",
+		zap.String("user_id", user.ID.String()),
+		zap.Int("lockouts", updated.LoginSecurity.Lockouts+1),
+		zap.Time("locked_until", until))
+
+	if err := g.email.Send(ctx, g.lockedEmail(updated, token, until)); err != nil {
+		// The lock expires on its own
+		g.logger.Error("Failed to send account locked email",
+			zap.String("user_id", user.ID.String()),
+			zap.Error(err))
+	}
+	return ErrAccountLocked
+}
+
+// lockDuration returns how long the lockout after the given number of earlier ones lasts
+func (g *LoginGuard) lockDuration(lockouts int) time.Duration {
+	duration := g.opts.LockDuration
+	for i := 0; i < lockouts && duration < g.opts.MaxLockDuration; i++ {
+		duration *= 2
+	}
+	if duration > g.opts.MaxLockDuration {
+		duration = g.opts.MaxLockDuration
+	}
+	return duration
+}
+
+// Unlock lifts the lockout the unlock link was sent for
+func (g *LoginGuard) Unlock(ctx context.Context, token string) error {
+	if token == "" {
+		return ErrInvalidUnlockToken
+	}
+	user, err := g.userRepo.GetByUnlockToken(ctx, hashUnlockToken(token))
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return ErrInvalidUnlockToken
+	}
+	return g.userRepo.UnlockLogin(ctx, user.ID)
+}
+
+// RecordLogin resets the failed logins of a user who logged in and remembers
+// the device and country the login came from, emailing the owner when either
+// is new to the account. The caller stores the user.
+func (g *LoginGuard) RecordLogin(ctx context.Context, user *models.User) {
+	client := utils.ClientFromContext(ctx)
+	security := user.LoginSecurity
+	if security == nil {
+		security = &models.LoginSecurity{}
+		user.LoginSecurity = security
+	}
+	security.Unlock()
+	security.Lockouts = 0
+
+	// Logins without a client, such as in tests, have nothing to compare
+	if client.UserAgent == "" && client.IP == "" {
+		return
+	}
+	// The first login has nothing to compare against either
+	firstLogin := len(security.KnownDevices) == 0
+
+	device, browser, os := parseUserAgent(client.UserAgent)
+	newDevice := g.rememberDevice(security, deviceFingerprint(device, browser, os))
+
+	location := g.locate(ctx, client.IP)
+	newCountry := false
+	if location != nil && !containsString(security.KnownCountries, location.Country) {
+		newCountry = len(security.KnownCountries) > 0
+		security.KnownCountries = append(security.KnownCountries, location.Country)
+	}
+
+	if firstLogin || (!newDevice && !newCountry) {
+		return
+	}
+	msg := g.newLoginEmail(user, newLogin{
+		Device:   device,
+		Browser:  browser,
+		OS:       os,
+		IP:       client.IP,
+		Location: location,
+		At:       g.now(),
+	})
+	if err := g.email.Send(ctx, msg); err != nil {
+		g.logger.Error("Failed to send new login email",
+			zap.String("user_id", user.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// rememberDevice records that the device was seen and reports whether it is new
+func (g *LoginGuard) rememberDevice(security *models.LoginSecurity, fingerprint string) bool {
+	now := g.now()
+	for i := range security.KnownDevices {
+		if security.KnownDevices[i].Fingerprint == fingerprint {
+			security.KnownDevices[i].LastSeenAt = now
+			return false
+		}
+	}
+
+	security.KnownDevices = append(security.KnownDevices, models.KnownDevice{Fingerprint: fingerprint, LastSeenAt: now})
+	if len(security.KnownDevices) > maxKnownDevices {
+		sort.Slice(security.KnownDevices, func(i, j int) bool {
+			return security.KnownDevices[i].LastSeenAt.After(security.KnownDevices[j].LastSeenAt)
+		})
+		security.KnownDevices = security.KnownDevices[:maxKnownDevices]
+	}
+	return true
+}
+
+// locate returns where the IP is, or nil when unknown
+func (g *LoginGuard) locate(ctx context.Context, ip string) *GeoLocation {
+	if g.geoIP == nil {
+		return nil
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.IsLoopback() || parsed.IsPrivate() {
+		return nil
+	}
+	location, err := g.geoIP.Lookup(ctx, parsed)
+	if err != nil {
+		g.logger.Debug("Failed to locate login", zap.Error(err))
+		return nil
+	}
+	return location
+}
+
+// deviceFingerprint identifies a device by its type, browser and OS, which
+// survive browser updates unlike the full user agent
+func deviceFingerprint(device, browser, os string) string {
+	sum := sha256.Sum256([]byte(device + "|" + browser + "|" + os))
+	return hex.EncodeToString(sum[:])
+}
+
+func hashUnlockToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// UnlockLink returns the frontend link that unlocks an account
+func (g *LoginGuard) UnlockLink(token string) string {
+	query := url.Values{}
+	query.Set("token", token)
+	return fmt.Sprintf("%s/account/unlock?%s", g.opts.SiteURL, query.Encode())
+}
+
+func (g *LoginGuard) lockedEmail(user *models.User, token string, until time.Time) *EmailMessage {
+	link := g.UnlockLink(token)
+	expires := until.UTC().Format("January 2, 2006 15:04 MST")
+	return &EmailMessage{
+		To:      user.Email,
+		Subject: "Your account was locked after failed logins",
+		HTML: fmt.Sprintf(`<p>Hi %s,</p>
+<p>Someone entered the wrong password for your account %d times in a row, so logins are locked until %s.</p>
+<p>If that was you, <a href="%s">unlock your account</a> now. If it was not, consider resetting your password.</p>`,
+			template.HTMLEscapeString(user.FirstName), g.opts.MaxFailedAttempts, expires, link),
+		Text: fmt.Sprintf("Hi %s,\n\nSomeone entered the wrong password for your account %d times in a row, so logins are locked until %s.\n\nIf that was you, unlock your account now: %s\n\nIf it was not, consider resetting your password.\n",
+			user.FirstName, g.opts.MaxFailedAttempts, expires, link),
+	}
+}
+
+// newLogin describes a login from a new device or country
+type newLogin struct {
+	Device, Browser, OS string
+	IP                  string
+	Location            *GeoLocation
+	At                  time.Time
+}
+
+func (l newLogin) where() string {
+	if l.Location == nil {
+		return l.IP
+	}
+	if l.Location.City != "" {
+		return fmt.Sprintf("%s, %s (%s)", l.Location.City, l.Location.Country, l.IP)
+	}
+	return fmt.Sprintf("%s (%s)", l.Location.Country, l.IP)
+}
+
+func (g *LoginGuard) newLoginEmail(user *models.User, login newLogin) *EmailMessage {
+	device := fmt.Sprintf("%s on %s (%s)", login.Browser, login.OS, login.Device)
+	at := login.At.UTC().Format("January 2, 2006 15:04 MST")
+	return &EmailMessage{
+		To:      user.Email,
+		Subject: "New login to your account",
+		HTML: fmt.Sprintf(`<p>Hi %s,</p>
+<p>Your account was just logged in to from a new device or location:</p>
+<ul><li>Device: %s</li><li>Location: %s</li><li>Time: %s</li></ul>
+<p>If this was not you, reset your password and sign out your other sessions.</p>`,
+			template.HTMLEscapeString(user.FirstName), template.HTMLEscapeString(device), template.HTMLEscapeString(login.where()), at),
+		Text: fmt.Sprintf("Hi %s,\n\nYour account was just logged in to from a new device or location:\n\nDevice: %s\nLocation: %s\nTime: %s\n\nIf this was not you, reset your password and sign out your other sessions.\n",
+			user.FirstName, device, login.where(), at),
+	}
+}
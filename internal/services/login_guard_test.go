@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/utils"
+)
+
+var testLoginGuardNow = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestLoginGuard(userRepo *MockUserRepository, email *MockEmailService) *LoginGuard {
+	guard := NewLoginGuard(userRepo, email, LoginGuardOptions{SiteURL: "https://app.example.com/"}, zap.NewNop())
+	guard.now = func() time.Time { return testLoginGuardNow }
+	return guard
+}
+
+func TestAuthService_Login_Lockout(t *testing.T) {
+	hash, err := utils.HashPasswordWithCost("correct-horse", 4)
+	require.NoError(t, err)
+	newUser := func() *models.User {
+		return &models.User{
+			ID:           models.NewID(),
+			Email:        "dana@example.com",
+			FirstName:    "Dana",
+			PasswordHash: hash,
+			Role:         "user",
+			Status:       models.UserStatusActive,
+		}
+	}
+	wrong := LoginRequest{Email: "dana@example.com", Password: "wrong"}
+
+	t.Run("Success - wrong passwords below the limit are counted", func(t *testing.T) {
+		user := newUser()
+		userRepo := &MockUserRepository{}
+		email := &MockEmailService{}
+		userRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+		userRepo.On("RecordFailedLogin", mock.Anything, user.ID).Return(&models.User{
+			ID: user.ID, LoginSecurity: &models.LoginSecurity{FailedAttempts: 4},
+		}, nil)
+		auth := NewAuthService(userRepo, nil, AuthDependencies{LoginGuard: newTestLoginGuard(userRepo, email)})
+
+		_, err := auth.Login(context.Background(), wrong)
+
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+		userRepo.AssertNotCalled(t, "LockLogin", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		assert.Empty(t, email.sent)
+	})
+
+	t.Run("Success - the last wrong password locks the account, longer after each lockout", func(t *testing.T) {
+		user := newUser()
+		userRepo := &MockUserRepository{}
+		email := &MockEmailService{}
+		userRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+		userRepo.On("RecordFailedLogin", mock.Anything, user.ID).Return(&models.User{
+			ID: user.ID, Email: user.Email, FirstName: "Dana",
+			LoginSecurity: &models.LoginSecurity{FailedAttempts: 5, Lockouts: 2},
+		}, nil)
+		var tokenHash string
+		userRepo.On("LockLogin", mock.Anything, user.ID, testLoginGuardNow.Add(time.Hour), mock.Anything).
+			Run(func(args mock.Arguments) { tokenHash = args.String(3) }).
+			Return(nil)
+		auth := NewAuthService(userRepo, nil, AuthDependencies{LoginGuard: newTestLoginGuard(userRepo, email)})
+
+		_, err := auth.Login(context.Background(), wrong)
+
+		assert.ErrorIs(t, err, ErrAccountLocked)
+		userRepo.AssertExpectations(t)
+		require.Len(t, email.sent, 1)
+		assert.Equal(t, user.Email, email.sent[0].To)
+		link := regexp.MustCompile(`https://app\.example\.com/account/unlock\?token=\S+`).FindString(email.sent[0].Text)
+		require.NotEmpty(t, link)
+		parsed, err := url.Parse(link)
+		require.NoError(t, err)
+		assert.Equal(t, tokenHash, hashUnlockToken(parsed.Query().Get("token")), "only the token's hash is stored")
+	})
+
+	t.Run("Error - locked accounts are refused even with the right password", func(t *testing.T) {
+		user := newUser()
+		until := testLoginGuardNow.Add(time.Minute)
+		user.LoginSecurity = &models.LoginSecurity{LockedUntil: &until}
+		userRepo := &MockUserRepository{}
+		userRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+		auth := NewAuthService(userRepo, nil, AuthDependencies{LoginGuard: newTestLoginGuard(userRepo, &MockEmailService{})})
+
+		_, err := auth.Login(context.Background(), LoginRequest{Email: user.Email, Password: "correct-horse"})
+
+		assert.ErrorIs(t, err, ErrAccountLocked)
+		userRepo.AssertNotCalled(t, "RecordFailedLogin", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLoginGuard_LockDuration(t *testing.T) {
+	guard := newTestLoginGuard(&MockUserRepository{}, &MockEmailService{})
+
+	assert.Equal(t, 15*time.Minute, guard.lockDuration(0))
+	assert.Equal(t, 30*time.Minute, guard.lockDuration(1))
+	assert.Equal(t, 24*time.Hour, guard.lockDuration(10))
+}
+
+func TestLoginGuard_Unlock(t *testing.T) {
+	ctx := context.Background()
+	userID := models.NewID()
+	userRepo := &MockUserRepository{}
+	userRepo.On("GetByUnlockToken", mock.Anything, hashUnlockToken("good-token")).Return(&models.User{ID: userID}, nil)
+	userRepo.On("GetByUnlockToken", mock.Anything, hashUnlockToken("used-token")).Return(nil, nil)
+	userRepo.On("UnlockLogin", mock.Anything, userID).Return(nil)
+	guard := newTestLoginGuard(userRepo, &MockEmailService{})
+
+	assert.NoError(t, guard.Unlock(ctx, "good-token"))
+	assert.ErrorIs(t, guard.Unlock(ctx, "used-token"), ErrInvalidUnlockToken)
+	assert.ErrorIs(t, guard.Unlock(ctx, ""), ErrInvalidUnlockToken)
+	userRepo.AssertExpectations(t)
+}
+
+func TestLoginGuard_RecordLogin(t *testing.T) {
+	const (
+		iphone = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148 Safari/604.1"
+		laptop = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0 Safari/537.36"
+	)
+	geo := &fakeGeoIPProvider{locations: map[string]*GeoLocation{
+		"203.0.113.7":  {Country: "ID", City: "Jakarta"},
+		"198.51.100.9": {Country: "NL", City: "Amsterdam"},
+	}}
+	email := &MockEmailService{}
+	guard := newTestLoginGuard(&MockUserRepository{}, email)
+	guard.SetGeoIPProvider(geo)
+	until := testLoginGuardNow.Add(-time.Minute)
+	user := &models.User{
+		ID:            models.NewID(),
+		Email:         "dana@example.com",
+		LoginSecurity: &models.LoginSecurity{FailedAttempts: 3, Lockouts: 1, LockedUntil: &until, UnlockTokenHash: "x"},
+	}
+	login := func(ip, userAgent string) {
+		guard.RecordLogin(utils.WithClient(context.Background(), utils.RequestClient{IP: ip, UserAgent: userAgent}), user)
+	}
+
+	login("203.0.113.7", iphone)
+	assert.Empty(t, email.sent, "the first login has nothing to compare against")
+	assert.Equal(t, models.LoginSecurity{
+		KnownDevices:   []models.KnownDevice{{Fingerprint: deviceFingerprint("mobile", "safari", "ios"), LastSeenAt: testLoginGuardNow}},
+		KnownCountries: []string{"ID"},
+	}, *user.LoginSecurity, "failed logins and lockouts are reset")
+
+	login("203.0.113.7", iphone)
+	assert.Empty(t, email.sent)
+
+	login("203.0.113.7", laptop)
+	require.Len(t, email.sent, 1, "new device")
+	assert.Contains(t, email.sent[0].Text, "chrome on windows (desktop)")
+	assert.Contains(t, email.sent[0].Text, "Jakarta, ID (203.0.113.7)")
+
+	login("198.51.100.9", iphone)
+	require.Len(t, email.sent, 2, "new country")
+	assert.Equal(t, "New login to your account", email.sent[1].Subject)
+	assert.Contains(t, email.sent[1].Text, "Amsterdam, NL")
+
+	login("198.51.100.9", laptop)
+	assert.Len(t, email.sent, 2)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ScanResult is the verdict of a malware scan
+type ScanResult struct {
+	Infected bool
+	// Threat names what was found, e.g. "Win.Test.EICAR_HDB-1"
+	Threat string
+}
+
+// MalwareScanner checks a file for malware
+type MalwareScanner interface {
+	Scan(ctx context.Context, file io.Reader) (*ScanResult, error)
+}
+
+// clamAVChunkSize is the size of the chunks files are streamed to clamd in
+const clamAVChunkSize = 64 * 1024
+
+// ClamAVScanner scans files with a clamd daemon through its INSTREAM command
+type ClamAVScanner struct {
+	address string
+	timeout time.Duration
+}
+
+// NewClamAVScanner creates a scanner for the clamd daemon at address, a
+// host:port or the path of a unix socket
+func NewClamAVScanner(address string, timeout time.Duration) *ClamAVScanner {
+	return &ClamAVScanner{address: address, timeout: timeout}
+}
+
+// Scan streams the file to clamd and reads its verdict
+func (s *ClamAVScanner) Scan(ctx context.Context, file io.Reader) (*ScanResult, error) {
+	network := "tcp"
+	if strings.HasPrefix(s.address, "/") {
+		network = "unix"
+	}
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, network, s.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else if s.timeout > 0 {
+		conn.SetDeadline(time.Now().Add(s.timeout))
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return nil, fmt.Errorf("failed to start clamd scan: %w", err)
+	}
+	// Each chunk is prefixed with its length; a zero length ends the stream
+	chunk := make([]byte, clamAVChunkSize)
+	size := make([]byte, 4)
+	for {
+		n, err := file.Read(chunk)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, werr := conn.Write(size); werr != nil {
+				return nil, fmt.Errorf("failed to stream file to clamd: %w", werr)
+			}
+			if _, werr := conn.Write(chunk[:n]); werr != nil {
+				return nil, fmt.Errorf("failed to stream file to clamd: %w", werr)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return nil, fmt.Errorf("failed to end clamd stream: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return parseClamAVReply(reply)
+}
+
+// parseClamAVReply reads replies like "stream: OK" and
+// "stream: Win.Test.EICAR_HDB-1 FOUND"
+func parseClamAVReply(reply string) (*ScanResult, error) {
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	_, verdict, _ := strings.Cut(reply, ": ")
+	switch {
+	case verdict == "OK":
+		return &ScanResult{}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return &ScanResult{Infected: true, Threat: strings.TrimSuffix(verdict, " FOUND")}, nil
+	default:
+		return nil, fmt.Errorf("clamd could not scan the file: %q", reply)
+	}
+}
+
+// HTTPScanner scans files with an external scanning API. The file is POSTed
+// as the request body and the API answers {"infected": bool, "threat": "..."}.
+type HTTPScanner struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewHTTPScanner creates a scanner for the API at url, authenticated with a
+// bearer token when one is given
+func NewHTTPScanner(url, token string, timeout time.Duration) *HTTPScanner {
+	return &HTTPScanner{url: url, token: token, client: &http.Client{Timeout: timeout}}
+}
+
+// Scan uploads the file to the API and reads its verdict
+func (s *HTTPScanner) Scan(ctx context.Context, file io.Reader) (*ScanResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, file)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach scanning API: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scanning API response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("scanning API returned %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	var verdict struct {
+		Infected *bool  `json:"infected"`
+		Threat   string `json:"threat"`
+	}
+	if err := json.Unmarshal(body, &verdict); err != nil {
+		return nil, fmt.Errorf("invalid scanning API response: %w", err)
+	}
+	if verdict.Infected == nil {
+		return nil, errors.New("scanning API response has no verdict")
+	}
+	return &ScanResult{Infected: *verdict.Infected, Threat: verdict.Threat}, nil
+}
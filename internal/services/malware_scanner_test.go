@@ -0,0 +1,110 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveClamd answers INSTREAM scans like clamd, finding files that contain "EICAR"
+func serveClamd(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				command := make([]byte, len("zINSTREAM\x00"))
+				if _, err := io.ReadFull(conn, command); err != nil || string(command) != "zINSTREAM\x00" {
+					conn.Write([]byte("UNKNOWN COMMAND\x00"))
+					return
+				}
+				var data bytes.Buffer
+				size := make([]byte, 4)
+				for {
+					if _, err := io.ReadFull(conn, size); err != nil {
+						return
+					}
+					n := binary.BigEndian.Uint32(size)
+					if n == 0 {
+						break
+					}
+					if _, err := io.CopyN(&data, conn, int64(n)); err != nil {
+						return
+					}
+				}
+				if bytes.Contains(data.Bytes(), []byte("EICAR")) {
+					conn.Write([]byte("stream: Win.Test.EICAR_HDB-1 FOUND\x00"))
+					return
+				}
+				conn.Write([]byte("stream: OK\x00"))
+			}(conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestClamAVScanner_Scan(t *testing.T) {
+	ctx := context.Background()
+	scanner := NewClamAVScanner(serveClamd(t), 5*time.Second)
+
+	result, err := scanner.Scan(ctx, strings.NewReader("clean photo"))
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	// Larger than a chunk, with the signature in the second one
+	infected := append(bytes.Repeat([]byte("a"), clamAVChunkSize), []byte("X5O!P%@AP EICAR")...)
+	result, err = scanner.Scan(ctx, bytes.NewReader(infected))
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+	assert.Equal(t, "Win.Test.EICAR_HDB-1", result.Threat)
+
+	_, err = parseClamAVReply("INSTREAM size limit exceeded. ERROR")
+	assert.Error(t, err)
+}
+
+func TestHTTPScanner_Scan(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		if bytes.Contains(data, []byte("EICAR")) {
+			w.Write([]byte(`{"infected": true, "threat": "EICAR-Test-File"}`))
+			return
+		}
+		w.Write([]byte(`{"infected": false}`))
+	}))
+	defer server.Close()
+
+	scanner := NewHTTPScanner(server.URL, "token", 5*time.Second)
+	result, err := scanner.Scan(ctx, strings.NewReader("clean photo"))
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	result, err = scanner.Scan(ctx, strings.NewReader("X5O!P%@AP EICAR"))
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+	assert.Equal(t, "EICAR-Test-File", result.Threat)
+
+	_, err = NewHTTPScanner(server.URL, "wrong", 5*time.Second).Scan(ctx, strings.NewReader("clean photo"))
+	assert.Error(t, err)
+}
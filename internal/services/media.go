@@ -2,28 +2,70 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"go.uber.org/zap"
 	"io"
 	"mime/multipart"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.uber.org/zap"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
 )
 
 // MediaService handles media operations
 type MediaService interface {
-	UploadFile(ctx context.Context, file io.Reader, header *multipart.FileHeader, userID primitive.ObjectID) (*models.Media, error)
-	UploadFiles(ctx context.Context, files map[string][]*multipart.FileHeader, userID primitive.ObjectID) ([]*models.Media, error)
-	GetMedia(ctx context.Context, mediaID primitive.ObjectID) (*models.Media, error)
-	GetUserMedia(ctx context.Context, userID primitive.ObjectID, page, pageSize int, filters repository.MediaFilter) ([]*models.Media, int64, error)
-	DeleteMedia(ctx context.Context, mediaID, userID primitive.ObjectID) error
-	GeneratePresignedUploadURL(ctx context.Context, filename, contentType string, size int64, userID primitive.ObjectID) (*PresignedUploadInfo, error)
-	ProcessUploadedFile(ctx context.Context, presignedInfo *PresignedUploadInfo, userID primitive.ObjectID) (*models.Media, error)
+	UploadFile(ctx context.Context, file io.Reader, header *multipart.FileHeader, userID models.ID) (*models.Media, error)
+	UploadFiles(ctx context.Context, files map[string][]*multipart.FileHeader, userID models.ID) ([]*models.Media, error)
+	GetMedia(ctx context.Context, mediaID models.ID) (*models.Media, error)
+	GetUserMedia(ctx context.Context, userID models.ID, page, pageSize int, filters repository.MediaFilter) ([]*models.Media, int64, error)
+	DeleteMedia(ctx context.Context, mediaID, userID models.ID) error
+	GeneratePresignedUploadURL(ctx context.Context, filename, contentType string, size int64, userID models.ID) (*PresignedUploadInfo, error)
+	ProcessUploadedFile(ctx context.Context, presignedInfo *PresignedUploadInfo, userID models.ID) (*models.Media, error)
+	InitiateMultipartUpload(ctx context.Context, filename, contentType string, size int64, userID models.ID) (*MultipartUploadInfo, error)
+	CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []CompletedPart, userID models.ID) (string, error)
+	AbortMultipartUpload(ctx context.Context, key, uploadID string, userID models.ID) error
+	// GenerateThumbnails renders and stores the thumbnails of an uploaded image
+	GenerateThumbnails(ctx context.Context, mediaID models.ID) error
+	// TranscodeVideo renders and stores the web rendition and poster frame of an
+	// uploaded video. The video is marked failed when lastAttempt is set.
+	TranscodeVideo(ctx context.Context, mediaID models.ID, lastAttempt bool) error
+	// PurgeUserMedia permanently deletes every file the user uploaded, deleted
+	// media included, from storage along with its records
+	PurgeUserMedia(ctx context.Context, userID models.ID) (int, error)
+}
+
+// ErrMultipartUploadUnsupported is returned when the storage backend cannot
+// take direct multipart uploads
+var ErrMultipartUploadUnsupported = errors.New("multipart uploads are not supported by the storage backend")
+
+// Video upload errors
+var (
+	ErrVideoUnsupported = errors.New("video uploads are not supported by this server")
+	ErrVideoTooLong     = errors.New("video is too long")
+)
+
+// maxMultipartParts is the S3 limit on parts in one upload
+const maxMultipartParts = 10000
+
+// MultipartUploadInfo describes a direct multipart upload: the client PUTs
+// each part to its URL and completes the upload with the returned ETags
+type MultipartUploadInfo struct {
+	UploadID  string
+	Key       string
+	MediaID   string
+	PartSize  int64
+	Parts     []PresignedPart
+	ExpiresAt time.Time
+}
+
+// PresignedPart is the upload URL of one part of a multipart upload
+type PresignedPart struct {
+	PartNumber int    `json:"partNumber"`
+	URL        string `json:"url"`
 }
 
 type mediaService struct {
@@ -31,6 +73,8 @@ type mediaService struct {
 	storageService StorageService
 	validator      FileValidator
 	imageProcessor ImageProcessor
+	transcoder     VideoTranscoder
+	jobs           JobEnqueuer
 	logger         *zap.Logger
 	config         *MediaServiceConfig
 }
@@ -43,8 +87,16 @@ type MediaServiceConfig struct {
 	AllowedTypes   []string        `json:"allowedTypes"`
 	ThumbnailSizes []ThumbnailSize `json:"thumbnailSizes"`
 	EnableWebP     bool            `json:"enableWebP"`
+	EnableAVIF     bool            `json:"enableAvif"`
 	PresignExpiry  time.Duration   `json:"presignExpiry"`
 	BaseURL        string          `json:"baseUrl"`
+	// MultipartPartSize is the part size of direct multipart uploads; S3
+	// requires at least 5MB for every part but the last
+	MultipartPartSize int64 `json:"multipartPartSize"`
+	// MaxVideoSize and MaxVideoDuration limit video uploads, which do not count
+	// towards MaxTotalSize
+	MaxVideoSize     int64         `json:"maxVideoSize"`
+	MaxVideoDuration time.Duration `json:"maxVideoDuration"`
 }
 
 // DefaultMediaServiceConfig returns default configuration
@@ -53,15 +105,18 @@ func DefaultMediaServiceConfig() *MediaServiceConfig {
 		MaxFileSize:  5 * 1024 * 1024,  // 5MB
 		MaxTotalSize: 20 * 1024 * 1024, // 20MB
 		MaxFiles:     10,
-		AllowedTypes: []string{"image/jpeg", "image/png", "image/webp"},
+		AllowedTypes: []string{"image/jpeg", "image/png", "image/webp", "video/mp4", "video/webm"},
 		ThumbnailSizes: []ThumbnailSize{
 			{Name: "small", Width: 150, Height: 150},
 			{Name: "medium", Width: 400, Height: 400},
 			{Name: "large", Width: 800, Height: 800},
 		},
-		EnableWebP:    true,
-		PresignExpiry: 15 * time.Minute,
-		BaseURL:       "http://localhost:8080/uploads",
+		EnableWebP:        true,
+		PresignExpiry:     15 * time.Minute,
+		BaseURL:           "http://localhost:8080/uploads",
+		MultipartPartSize: 8 * 1024 * 1024,   // 8MB
+		MaxVideoSize:      100 * 1024 * 1024, // 100MB
+		MaxVideoDuration:  3 * time.Minute,
 	}
 }
 
@@ -73,6 +128,37 @@ func NewMediaService(
 	imageProcessor ImageProcessor,
 	logger *zap.Logger,
 	config *MediaServiceConfig,
+) MediaService {
+	return NewMediaServiceWithJobs(mediaRepo, storageService, validator, imageProcessor, nil, logger, config)
+}
+
+// NewMediaServiceWithJobs creates a media service that generates thumbnails in
+// background jobs when the storage backend can read uploads back
+func NewMediaServiceWithJobs(
+	mediaRepo repository.MediaRepository,
+	storageService StorageService,
+	validator FileValidator,
+	imageProcessor ImageProcessor,
+	jobs JobEnqueuer,
+	logger *zap.Logger,
+	config *MediaServiceConfig,
+) MediaService {
+	return NewMediaServiceWithVideo(mediaRepo, storageService, validator, imageProcessor, nil, jobs, logger, config)
+}
+
+// NewMediaServiceWithVideo creates a media service that also takes video
+// uploads, transcoding them in background jobs. Videos are rejected with
+// ErrVideoUnsupported without a transcoder, a job queue or storage that can
+// read uploads back.
+func NewMediaServiceWithVideo(
+	mediaRepo repository.MediaRepository,
+	storageService StorageService,
+	validator FileValidator,
+	imageProcessor ImageProcessor,
+	transcoder VideoTranscoder,
+	jobs JobEnqueuer,
+	logger *zap.Logger,
+	config *MediaServiceConfig,
 ) MediaService {
 	if config == nil {
 		config = DefaultMediaServiceConfig()
@@ -83,24 +169,26 @@ func NewMediaService(
 		storageService: storageService,
 		validator:      validator,
 		imageProcessor: imageProcessor,
+		transcoder:     transcoder,
+		jobs:           jobs,
 		logger:         logger,
 		config:         config,
 	}
 }
 
 // UploadFile handles single file upload
-func (s *mediaService) UploadFile(ctx context.Context, file io.Reader, header *multipart.FileHeader, userID primitive.ObjectID) (*models.Media, error) {
-	// Validate file size
-	if header.Size > s.config.MaxFileSize {
-		return nil, fmt.Errorf("file size %d exceeds maximum allowed size %d", header.Size, s.config.MaxFileSize)
-	}
-
+func (s *mediaService) UploadFile(ctx context.Context, file io.Reader, header *multipart.FileHeader, userID models.ID) (*models.Media, error) {
 	// Validate file type and content
 	validationResult, err := s.validator.Validate(ctx, file, header)
 	if err != nil {
 		return nil, fmt.Errorf("file validation failed: %w", err)
 	}
 
+	// Validate file size
+	if maxSize := s.config.maxFileSize(validationResult.MimeType); header.Size > maxSize {
+		return nil, fmt.Errorf("file size %d exceeds maximum allowed size %d", header.Size, maxSize)
+	}
+
 	// Reset file pointer for processing
 	if seeker, ok := file.(io.Seeker); ok {
 		_, err = seeker.Seek(0, io.SeekStart)
@@ -109,14 +197,33 @@ func (s *mediaService) UploadFile(ctx context.Context, file io.Reader, header *m
 		}
 	}
 
-	// Process image (generate thumbnails, extract metadata)
-	processed, err := s.imageProcessor.Process(ctx, file, validationResult.MimeType)
+	// Uploads of a file the user stored before reuse its stored object
+	contentHash, file, err := hashUpload(file)
+	if err != nil {
+		return nil, err
+	}
+	if existing := s.findDuplicate(ctx, userID, contentHash); existing != nil {
+		return s.uploadDuplicate(ctx, existing, header, userID)
+	}
+
+	if isVideoMimeType(validationResult.MimeType) {
+		return s.uploadVideo(ctx, file, header, validationResult, contentHash, userID)
+	}
+
+	// Process image (generate thumbnails, extract metadata). Thumbnails are left
+	// to a background job when one can read the upload back.
+	asyncThumbnails := s.asyncThumbnails()
+	process := s.imageProcessor.Process
+	if asyncThumbnails {
+		process = s.imageProcessor.Analyze
+	}
+	processed, err := process(ctx, file, validationResult.MimeType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to process image: %w", err)
 	}
 
 	// Generate storage key
-	mediaID := primitive.NewObjectID()
+	mediaID := models.NewID()
 	storageKey := s.generateStorageKey(mediaID, validationResult.Extension)
 
 	// Upload original file
@@ -129,9 +236,12 @@ func (s *mediaService) UploadFile(ctx context.Context, file io.Reader, header *m
 	// Upload thumbnails
 	thumbnails := make(map[string]string)
 	for name, thumbData := range processed.Thumbnails {
-		thumbKey := s.generateThumbnailKey(mediaID, name, validationResult.Extension)
-		thumbURL, err := s.storageService.Upload(ctx, thumbKey, thumbData,
-			validationResult.MimeType, nil)
+		ext, mimeType := validationResult.Extension, validationResult.MimeType
+		if variant := thumbnailVariantFormat(name); variant != "" {
+			ext, mimeType = variant, extensionToMimeType(variant)
+		}
+		thumbKey := s.generateThumbnailKey(mediaID, name, ext)
+		thumbURL, err := s.storageService.Upload(ctx, thumbKey, thumbData, mimeType, nil)
 		if err != nil {
 			s.logger.Warn("Failed to upload thumbnail",
 				zap.String("thumbnail", name),
@@ -154,7 +264,12 @@ func (s *mediaService) UploadFile(ctx context.Context, file io.Reader, header *m
 		Format:      processed.Metadata.Format,
 		EXIF:        processed.Metadata.EXIF,
 		StorageKey:  storageKey,
+		ContentHash: contentHash,
 		CreatedBy:   userID,
+		Status:      models.MediaStatusReady,
+	}
+	if asyncThumbnails {
+		media.Status = models.MediaStatusPending
 	}
 
 	if err := s.mediaRepo.Create(ctx, media); err != nil {
@@ -163,11 +278,87 @@ func (s *mediaService) UploadFile(ctx context.Context, file io.Reader, header *m
 		return nil, fmt.Errorf("failed to create media record: %w", err)
 	}
 
+	if asyncThumbnails {
+		// The upload stands without thumbnails, so a failure here is not fatal
+		if err := s.jobs.Enqueue(ctx, JobTypeGenerateThumbnails, ThumbnailJob{MediaID: mediaID}); err != nil {
+			s.logger.Warn("Failed to queue thumbnail generation",
+				zap.String("media_id", mediaID.String()),
+				zap.Error(err))
+		}
+	}
+
 	return media, nil
 }
 
+// GenerateThumbnails renders the configured thumbnail sizes of a stored image,
+// and their WebP/AVIF variants, and records them on its media record.
+// Thumbnails are stored next to the original, so running it again overwrites them.
+func (s *mediaService) GenerateThumbnails(ctx context.Context, mediaID models.ID) error {
+	reader, ok := s.storageService.(ObjectReader)
+	if !ok {
+		return PermanentJobError(errors.New("storage backend cannot read uploads back"))
+	}
+
+	media, err := s.mediaRepo.GetByID(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+	if media.ProcessingStatus() == models.MediaStatusRejected {
+		// Quarantined by the malware scan
+		return nil
+	}
+
+	data, err := reader.Download(ctx, media.StorageKey)
+	if err != nil {
+		return err
+	}
+
+	thumbnails := make(map[string]string)
+	for _, size := range s.config.ThumbnailSizes {
+		renditions := map[string]string{size.Name: media.Format}
+		for _, variant := range ThumbnailVariants(s.config.EnableWebP, s.config.EnableAVIF) {
+			if variant != media.Format {
+				renditions[ThumbnailVariantName(size.Name, variant)] = variant
+			}
+		}
+
+		for name, format := range renditions {
+			thumbData, err := s.imageProcessor.GenerateThumbnail(data, size.Width, size.Height, format)
+			if err != nil {
+				return PermanentJobError(fmt.Errorf("failed to generate %s thumbnail: %w", name, err))
+			}
+
+			key, mimeType := thumbnailKeyFor(media.StorageKey, name, ""), media.MimeType
+			if format != media.Format {
+				key, mimeType = thumbnailKeyFor(media.StorageKey, name, format), extensionToMimeType(format)
+			}
+			thumbURL, err := s.storageService.Upload(ctx, key, thumbData, mimeType, nil)
+			if err != nil {
+				return fmt.Errorf("failed to upload %s thumbnail: %w", name, err)
+			}
+			thumbnails[name] = thumbURL
+		}
+	}
+
+	media.Thumbnails = thumbnails
+	media.Status = models.MediaStatusReady
+	if err := s.mediaRepo.Update(ctx, media); err != nil {
+		return fmt.Errorf("failed to record thumbnails: %w", err)
+	}
+	return nil
+}
+
+// asyncThumbnails reports whether thumbnails are generated by background jobs
+func (s *mediaService) asyncThumbnails() bool {
+	if s.jobs == nil {
+		return false
+	}
+	_, ok := s.storageService.(ObjectReader)
+	return ok
+}
+
 // UploadFiles handles multiple file uploads
-func (s *mediaService) UploadFiles(ctx context.Context, files map[string][]*multipart.FileHeader, userID primitive.ObjectID) ([]*models.Media, error) {
+func (s *mediaService) UploadFiles(ctx context.Context, files map[string][]*multipart.FileHeader, userID models.ID) ([]*models.Media, error) {
 	var allFiles []*multipart.FileHeader
 
 	// Flatten files map
@@ -186,7 +377,10 @@ func (s *mediaService) UploadFiles(ctx context.Context, files map[string][]*mult
 	// Check total size
 	var totalSize int64
 	for _, fileHeader := range allFiles {
-		totalSize += fileHeader.Size
+		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(fileHeader.Filename), "."))
+		if !isVideoMimeType(extensionToMimeType(ext)) {
+			totalSize += fileHeader.Size
+		}
 	}
 
 	if totalSize > s.config.MaxTotalSize {
@@ -225,12 +419,12 @@ func (s *mediaService) UploadFiles(ctx context.Context, files map[string][]*mult
 }
 
 // GetMedia retrieves a media file by ID
-func (s *mediaService) GetMedia(ctx context.Context, mediaID primitive.ObjectID) (*models.Media, error) {
+func (s *mediaService) GetMedia(ctx context.Context, mediaID models.ID) (*models.Media, error) {
 	return s.mediaRepo.GetByID(ctx, mediaID)
 }
 
 // GetUserMedia retrieves media files uploaded by a user
-func (s *mediaService) GetUserMedia(ctx context.Context, userID primitive.ObjectID, page, pageSize int, filters repository.MediaFilter) ([]*models.Media, int64, error) {
+func (s *mediaService) GetUserMedia(ctx context.Context, userID models.ID, page, pageSize int, filters repository.MediaFilter) ([]*models.Media, int64, error) {
 	// Set created by filter if not already set
 	filters.CreatedBy = &userID
 
@@ -240,14 +434,14 @@ func (s *mediaService) GetUserMedia(ctx context.Context, userID primitive.Object
 	opts := repository.ListOptions{
 		Limit:  limit,
 		Offset: offset,
-		Sort:   primitive.D{{Key: "createdAt", Value: -1}},
+		Sort:   []repository.SortField{{Field: "createdAt", Desc: true}},
 	}
 
 	return s.mediaRepo.List(ctx, filters, opts)
 }
 
 // DeleteMedia deletes a media file (soft delete)
-func (s *mediaService) DeleteMedia(ctx context.Context, mediaID, userID primitive.ObjectID) error {
+func (s *mediaService) DeleteMedia(ctx context.Context, mediaID, userID models.ID) error {
 	// Get media to verify ownership
 	media, err := s.mediaRepo.GetByID(ctx, mediaID)
 	if err != nil {
@@ -263,39 +457,61 @@ func (s *mediaService) DeleteMedia(ctx context.Context, mediaID, userID primitiv
 	return s.mediaRepo.SoftDelete(ctx, mediaID)
 }
 
-// GeneratePresignedUploadURL generates a pre-signed URL for direct upload
-func (s *mediaService) GeneratePresignedUploadURL(ctx context.Context, filename, contentType string, size int64, userID primitive.ObjectID) (*PresignedUploadInfo, error) {
-	// Validate file size
-	if size > s.config.MaxFileSize {
-		return nil, fmt.Errorf("file size %d exceeds maximum allowed size %d", size, s.config.MaxFileSize)
-	}
-
-	// Extract file extension
-	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
-	if ext == "" {
-		return nil, fmt.Errorf("file must have an extension")
+// PurgeUserMedia permanently deletes the user's files and media records. A
+// record is only removed once its files are, so a failed purge can be retried.
+func (s *mediaService) PurgeUserMedia(ctx context.Context, userID models.ID) (int, error) {
+	media, err := s.mediaRepo.ListAllByCreatedBy(ctx, userID)
+	if err != nil {
+		return 0, err
 	}
 
-	// Map extension to MIME type
-	mimeType := s.extensionToMimeType(ext)
-	if mimeType == "" {
-		return nil, fmt.Errorf("unsupported file extension: %s", ext)
-	}
+	purged := 0
+	for _, m := range media {
+		// Files shared with other media are deleted along with the last of them
+		shared, err := sharesStoredObject(ctx, s.mediaRepo, m)
+		if err != nil {
+			return purged, err
+		}
+		if shared {
+			if err := s.mediaRepo.Delete(ctx, m.ID); err != nil {
+				return purged, err
+			}
+			purged++
+			continue
+		}
 
-	// Check if MIME type is allowed
-	allowed := false
-	for _, allowedType := range s.config.AllowedTypes {
-		if allowedType == mimeType {
-			allowed = true
-			break
+		keys := []string{m.StorageKey}
+		for _, url := range m.Thumbnails {
+			keys = append(keys, strings.TrimPrefix(url, s.config.BaseURL+"/"))
+		}
+		if m.PlaybackURL != "" {
+			keys = append(keys, strings.TrimPrefix(m.PlaybackURL, s.config.BaseURL+"/"))
+		}
+		for _, key := range keys {
+			if key == "" {
+				continue
+			}
+			if err := s.storageService.Delete(ctx, key); err != nil {
+				return purged, fmt.Errorf("failed to delete file %s: %w", key, err)
+			}
 		}
+		if err := s.mediaRepo.Delete(ctx, m.ID); err != nil {
+			return purged, err
+		}
+		purged++
 	}
-	if !allowed {
-		return nil, fmt.Errorf("file type not allowed: %s", mimeType)
+	return purged, nil
+}
+
+// GeneratePresignedUploadURL generates a pre-signed URL for direct upload
+func (s *mediaService) GeneratePresignedUploadURL(ctx context.Context, filename, contentType string, size int64, userID models.ID) (*PresignedUploadInfo, error) {
+	ext, err := s.config.validateDirectUpload(filename, size)
+	if err != nil {
+		return nil, err
 	}
 
 	// Generate unique storage key
-	mediaID := primitive.NewObjectID()
+	mediaID := models.NewID()
 	storageKey := s.generateStorageKey(mediaID, ext)
 
 	// Generate presigned upload URL
@@ -307,13 +523,13 @@ func (s *mediaService) GeneratePresignedUploadURL(ctx context.Context, filename,
 
 	// Store media ID in presigned info for later processing
 	presignedInfo.Key = storageKey
-	presignedInfo.Fields["mediaId"] = mediaID.Hex()
+	presignedInfo.Fields["mediaId"] = mediaID.String()
 
 	return presignedInfo, nil
 }
 
 // ProcessUploadedFile processes a file uploaded via pre-signed URL
-func (s *mediaService) ProcessUploadedFile(ctx context.Context, presignedInfo *PresignedUploadInfo, userID primitive.ObjectID) (*models.Media, error) {
+func (s *mediaService) ProcessUploadedFile(ctx context.Context, presignedInfo *PresignedUploadInfo, userID models.ID) (*models.Media, error) {
 	// In a real implementation, this would:
 	// 1. Verify the file exists in storage
 	// 2. Download the file for processing
@@ -321,8 +537,17 @@ func (s *mediaService) ProcessUploadedFile(ctx context.Context, presignedInfo *P
 	// 4. Upload thumbnails
 	// 5. Create media record in database
 
+	// Verify the client actually uploaded the file
+	exists, err := s.storageService.Exists(ctx, presignedInfo.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check uploaded file: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("uploaded file not found: %s", presignedInfo.Key)
+	}
+
 	// For now, return a placeholder
-	mediaID := primitive.NewObjectID()
+	mediaID := models.NewID()
 
 	return &models.Media{
 		ID:          mediaID,
@@ -338,16 +563,144 @@ func (s *mediaService) ProcessUploadedFile(ctx context.Context, presignedInfo *P
 	}, nil
 }
 
+// InitiateMultipartUpload starts a direct upload of a large file in parts and
+// presigns the upload URL of every part
+func (s *mediaService) InitiateMultipartUpload(ctx context.Context, filename, contentType string, size int64, userID models.ID) (*MultipartUploadInfo, error) {
+	storage, ok := s.storageService.(MultipartStorage)
+	if !ok {
+		return nil, ErrMultipartUploadUnsupported
+	}
+
+	ext, err := s.config.validateDirectUpload(filename, size)
+	if err != nil {
+		return nil, err
+	}
+
+	partSize := s.config.MultipartPartSize
+	partCount := int((size + partSize - 1) / partSize)
+	if partCount > maxMultipartParts {
+		return nil, fmt.Errorf("file size %d needs more than %d parts", size, maxMultipartParts)
+	}
+
+	mediaID := models.NewID()
+	key := s.generateStorageKey(mediaID, ext)
+
+	uploadID, err := storage.CreateMultipartUpload(ctx, key, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start multipart upload: %w", err)
+	}
+
+	info := &MultipartUploadInfo{
+		UploadID:  uploadID,
+		Key:       key,
+		MediaID:   mediaID.String(),
+		PartSize:  partSize,
+		Parts:     make([]PresignedPart, 0, partCount),
+		ExpiresAt: time.Now().Add(s.config.PresignExpiry),
+	}
+	for partNumber := 1; partNumber <= partCount; partNumber++ {
+		url, err := storage.PresignUploadPart(ctx, key, uploadID, partNumber, s.config.PresignExpiry)
+		if err != nil {
+			if abortErr := storage.AbortMultipartUpload(ctx, key, uploadID); abortErr != nil {
+				s.logger.Warn("Failed to abort multipart upload", zap.Error(abortErr), zap.String("key", key))
+			}
+			return nil, fmt.Errorf("failed to presign upload part %d: %w", partNumber, err)
+		}
+		info.Parts = append(info.Parts, PresignedPart{PartNumber: partNumber, URL: url})
+	}
+
+	s.logger.Info("Started multipart upload",
+		zap.String("key", key),
+		zap.Int("parts", partCount),
+		zap.String("user_id", userID.String()))
+
+	return info, nil
+}
+
+// CompleteMultipartUpload assembles the uploaded parts and returns the file URL
+func (s *mediaService) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []CompletedPart, userID models.ID) (string, error) {
+	storage, ok := s.storageService.(MultipartStorage)
+	if !ok {
+		return "", ErrMultipartUploadUnsupported
+	}
+	if len(parts) == 0 {
+		return "", fmt.Errorf("at least one part is required")
+	}
+
+	// S3 requires the parts in ascending order
+	sorted := make([]CompletedPart, len(parts))
+	copy(sorted, parts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].PartNumber < sorted[j].PartNumber })
+
+	if err := storage.CompleteMultipartUpload(ctx, key, uploadID, sorted); err != nil {
+		return "", fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+
+	s.logger.Info("Completed multipart upload",
+		zap.String("key", key),
+		zap.Int("parts", len(sorted)),
+		zap.String("user_id", userID.String()))
+
+	return fmt.Sprintf("%s/%s", s.config.BaseURL, key), nil
+}
+
+// AbortMultipartUpload discards an unfinished multipart upload
+func (s *mediaService) AbortMultipartUpload(ctx context.Context, key, uploadID string, userID models.ID) error {
+	storage, ok := s.storageService.(MultipartStorage)
+	if !ok {
+		return ErrMultipartUploadUnsupported
+	}
+	return storage.AbortMultipartUpload(ctx, key, uploadID)
+}
+
 // Helper functions
 
-func (s *mediaService) generateStorageKey(mediaID primitive.ObjectID, ext string) string {
+// validateDirectUpload checks a file the client will upload straight to
+// storage and returns its extension
+func (c *MediaServiceConfig) validateDirectUpload(filename string, size int64) (string, error) {
+	// Extract file extension
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+	if ext == "" {
+		return "", fmt.Errorf("file must have an extension")
+	}
+
+	// Map extension to MIME type
+	mimeType := extensionToMimeType(ext)
+	if mimeType == "" {
+		return "", fmt.Errorf("unsupported file extension: %s", ext)
+	}
+
+	// Validate file size
+	if maxSize := c.maxFileSize(mimeType); size > maxSize {
+		return "", fmt.Errorf("file size %d exceeds maximum allowed size %d", size, maxSize)
+	}
+
+	// Check if MIME type is allowed
+	for _, allowedType := range c.AllowedTypes {
+		if allowedType == mimeType {
+			return ext, nil
+		}
+	}
+	return "", fmt.Errorf("file type not allowed: %s", mimeType)
+}
+
+func (s *mediaService) generateStorageKey(mediaID models.ID, ext string) string {
 	date := time.Now().Format("2006/01/02")
-	return fmt.Sprintf("uploads/%s/%s/original.%s", date, mediaID.Hex(), ext)
+	return fmt.Sprintf("uploads/%s/%s/original.%s", date, mediaID.String(), ext)
 }
 
-func (s *mediaService) generateThumbnailKey(mediaID primitive.ObjectID, name, ext string) string {
+func (s *mediaService) generateThumbnailKey(mediaID models.ID, name, ext string) string {
 	date := time.Now().Format("2006/01/02")
-	return fmt.Sprintf("uploads/%s/%s/%s.%s", date, mediaID.Hex(), name, ext)
+	return fmt.Sprintf("uploads/%s/%s/%s.%s", date, mediaID.String(), name, ext)
+}
+
+// thumbnailKeyFor places a thumbnail next to the original it was rendered from,
+// with the original's extension unless ext is given
+func thumbnailKeyFor(storageKey, name, ext string) string {
+	if ext == "" {
+		return fmt.Sprintf("%s/%s%s", path.Dir(storageKey), name, path.Ext(storageKey))
+	}
+	return fmt.Sprintf("%s/%s.%s", path.Dir(storageKey), name, ext)
 }
 
 func (s *mediaService) buildMetadata(metadata *ImageMetadata) map[string]string {
@@ -378,7 +731,7 @@ func (s *mediaService) cleanupFailedUpload(ctx context.Context, originalKey stri
 	}
 }
 
-func (s *mediaService) extensionToMimeType(ext string) string {
+func extensionToMimeType(ext string) string {
 	switch ext {
 	case "jpg", "jpeg":
 		return "image/jpeg"
@@ -386,6 +739,12 @@ func (s *mediaService) extensionToMimeType(ext string) string {
 		return "image/png"
 	case "webp":
 		return "image/webp"
+	case "avif":
+		return "image/avif"
+	case "mp4":
+		return "video/mp4"
+	case "webm":
+		return "video/webm"
 	default:
 		return ""
 	}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// auditedMediaService records the uploads and deletions of a media service in the audit log
+type auditedMediaService struct {
+	MediaService
+	audit AuditRecorder
+}
+
+// NewAuditedMediaService records the uploads and deletions of a media service in the audit log
+func NewAuditedMediaService(media MediaService, audit AuditRecorder) MediaService {
+	return &auditedMediaService{MediaService: media, audit: audit}
+}
+
+func (s *auditedMediaService) UploadFile(ctx context.Context, file io.Reader, header *multipart.FileHeader, userID models.ID) (*models.Media, error) {
+	media, err := s.MediaService.UploadFile(ctx, file, header, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, models.AuditMediaUpload, media, auditChanges(nil, auditFields(media)))
+	return media, nil
+}
+
+func (s *auditedMediaService) UploadFiles(ctx context.Context, files map[string][]*multipart.FileHeader, userID models.ID) ([]*models.Media, error) {
+	uploaded, err := s.MediaService.UploadFiles(ctx, files, userID)
+	for _, media := range uploaded {
+		s.record(ctx, models.AuditMediaUpload, media, auditChanges(nil, auditFields(media)))
+	}
+	return uploaded, err
+}
+
+func (s *auditedMediaService) ProcessUploadedFile(ctx context.Context, presignedInfo *PresignedUploadInfo, userID models.ID) (*models.Media, error) {
+	media, err := s.MediaService.ProcessUploadedFile(ctx, presignedInfo, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, models.AuditMediaUpload, media, auditChanges(nil, auditFields(media)))
+	return media, nil
+}
+
+func (s *auditedMediaService) DeleteMedia(ctx context.Context, mediaID, userID models.ID) error {
+	// Looked up first, since the deleted record is no longer found
+	media, _ := s.MediaService.GetMedia(ctx, mediaID)
+	if err := s.MediaService.DeleteMedia(ctx, mediaID, userID); err != nil {
+		return err
+	}
+	if media == nil {
+		media = &models.Media{ID: mediaID}
+	}
+	s.record(ctx, models.AuditMediaDelete, media, auditChanges(auditFields(media), nil))
+	return nil
+}
+
+func (s *auditedMediaService) record(ctx context.Context, action string, media *models.Media, changes []models.AuditChange) {
+	if media == nil {
+		return
+	}
+	s.audit.Record(ctx, &models.AuditLog{
+		Action:     action,
+		TargetType: models.AuditTargetMedia,
+		TargetID:   media.ID.String(),
+		Changes:    changes,
+	})
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// hashUpload computes the content hash of an upload and returns a reader of
+// the upload from its start. Seekable uploads are rewound, others buffered.
+func hashUpload(file io.Reader) (string, io.Reader, error) {
+	hash := sha256.New()
+	if seeker, ok := file.(io.ReadSeeker); ok {
+		if _, err := io.Copy(hash, seeker); err != nil {
+			return "", nil, fmt.Errorf("failed to hash file: %w", err)
+		}
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return "", nil, fmt.Errorf("failed to reset file pointer: %w", err)
+		}
+		return hex.EncodeToString(hash.Sum(nil)), seeker, nil
+	}
+
+	data, err := io.ReadAll(io.TeeReader(file, hash))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to hash file: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), bytes.NewReader(data), nil
+}
+
+// findDuplicate returns earlier media of the user with the same content whose
+// stored object can be reused, or nil. Lookup failures only cost the saving.
+func (s *mediaService) findDuplicate(ctx context.Context, userID models.ID, contentHash string) *models.Media {
+	existing, err := s.mediaRepo.GetByContentHash(ctx, userID, contentHash)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			s.logger.Warn("Failed to look up duplicate upload", zap.String("user_id", userID.String()), zap.Error(err))
+		}
+		return nil
+	}
+	return existing
+}
+
+// uploadDuplicate records an upload of a file the user stored before. The new
+// media shares the stored object, thumbnails and renditions of the earlier
+// one; it is processed again only if the earlier one still is.
+func (s *mediaService) uploadDuplicate(ctx context.Context, existing *models.Media, header *multipart.FileHeader, userID models.ID) (*models.Media, error) {
+	media := &models.Media{
+		ID:          models.NewID(),
+		Filename:    header.Filename,
+		OriginalURL: existing.OriginalURL,
+		Size:        existing.Size,
+		MimeType:    existing.MimeType,
+		Width:       existing.Width,
+		Height:      existing.Height,
+		Format:      existing.Format,
+		EXIF:        existing.EXIF,
+		Duration:    existing.Duration,
+		PlaybackURL: existing.PlaybackURL,
+		Status:      existing.ProcessingStatus(),
+		StorageKey:  existing.StorageKey,
+		ContentHash: existing.ContentHash,
+		ScannedAt:   existing.ScannedAt,
+		CreatedBy:   userID,
+	}
+	if len(existing.Thumbnails) > 0 {
+		media.Thumbnails = make(map[string]string, len(existing.Thumbnails))
+		for name, url := range existing.Thumbnails {
+			media.Thumbnails[name] = url
+		}
+	}
+
+	if err := s.mediaRepo.Create(ctx, media); err != nil {
+		// The stored object belongs to the earlier media, so nothing is cleaned up
+		return nil, fmt.Errorf("failed to create media record: %w", err)
+	}
+
+	if media.Status == models.MediaStatusPending || media.Status == models.MediaStatusProcessing {
+		// Rendered to the same keys as for the earlier media
+		jobType, payload := JobTypeGenerateThumbnails, interface{}(ThumbnailJob{MediaID: media.ID})
+		if media.IsVideo() {
+			jobType, payload = JobTypeTranscodeVideo, VideoJob{MediaID: media.ID}
+		}
+		if err := s.jobs.Enqueue(ctx, jobType, payload); err != nil {
+			s.logger.Warn("Failed to queue processing of duplicate upload",
+				zap.String("media_id", media.ID.String()),
+				zap.Error(err))
+		}
+	}
+
+	s.logger.Debug("Reused stored file for duplicate upload",
+		zap.String("media_id", media.ID.String()),
+		zap.String("storage_key", media.StorageKey))
+	return media, nil
+}
+
+// sharesStoredObject reports whether media other than m still reference the
+// stored object of m, so that its files must be kept
+func sharesStoredObject(ctx context.Context, repo repository.MediaRepository, m *models.Media) (bool, error) {
+	if m.StorageKey == "" {
+		return false, nil
+	}
+	refs, err := repo.CountByStorageKey(ctx, m.StorageKey)
+	if err != nil {
+		return false, err
+	}
+	return refs > 1, nil
+}
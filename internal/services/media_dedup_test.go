@@ -0,0 +1,131 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+func TestHashUpload(t *testing.T) {
+	sum := sha256.Sum256([]byte("photo"))
+	want := hex.EncodeToString(sum[:])
+
+	// Seekable uploads are rewound, others buffered
+	for _, file := range []io.Reader{bytes.NewReader([]byte("photo")), strings.NewReader("photo"), io.MultiReader(strings.NewReader("pho"), strings.NewReader("to"))} {
+		hash, rest, err := hashUpload(file)
+		require.NoError(t, err)
+		assert.Equal(t, want, hash)
+		data, err := io.ReadAll(rest)
+		require.NoError(t, err)
+		assert.Equal(t, "photo", string(data))
+	}
+}
+
+func TestMediaService_DuplicateUpload(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	validator := NewFileValidator([]string{"image/png"}, 5*1024*1024)
+	pngContent := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	sum := sha256.Sum256(pngContent)
+	contentHash := hex.EncodeToString(sum[:])
+	userID := models.NewID()
+	header := &multipart.FileHeader{Filename: "again.png", Size: int64(len(pngContent))}
+
+	t.Run("first upload records its content hash", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		storage := new(MockStorageService)
+		processor := new(MockImageProcessor)
+		service := NewMediaService(repo, storage, validator, processor, logger, DefaultMediaServiceConfig())
+
+		processor.On("Process", mock.Anything, mock.Anything, "image/png").Return(&ProcessedImage{
+			OriginalData: pngContent,
+			Metadata:     &ImageMetadata{Width: 10, Height: 10, Format: "png"},
+		}, nil)
+		storage.On("Upload", ctx, mock.AnythingOfType("string"), pngContent, "image/png", mock.Anything).
+			Return("http://example.com/uploads/original.png", nil)
+		repo.On("GetByContentHash", ctx, userID, contentHash).Return(nil, repository.ErrNotFound)
+		repo.On("Create", ctx, mock.AnythingOfType("*models.Media")).Return(nil)
+
+		media, err := service.UploadFile(ctx, bytes.NewReader(pngContent), header, userID)
+		require.NoError(t, err)
+		assert.Equal(t, contentHash, media.ContentHash)
+	})
+
+	t.Run("duplicate reuses the stored file and thumbnails", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		storage := new(MockStorageService)
+		processor := new(MockImageProcessor)
+		service := NewMediaService(repo, storage, validator, processor, logger, DefaultMediaServiceConfig())
+
+		existing := &models.Media{
+			ID:          models.NewID(),
+			Filename:    "first.png",
+			OriginalURL: "http://example.com/uploads/2026/01/02/abc/original.png",
+			Thumbnails:  map[string]string{"small": "http://example.com/uploads/2026/01/02/abc/small.png"},
+			Size:        int64(len(pngContent)),
+			MimeType:    "image/png",
+			Width:       10,
+			Height:      10,
+			Format:      "png",
+			Status:      models.MediaStatusReady,
+			StorageKey:  "uploads/2026/01/02/abc/original.png",
+			ContentHash: contentHash,
+			CreatedBy:   userID,
+		}
+		repo.On("GetByContentHash", ctx, userID, contentHash).Return(existing, nil)
+		repo.On("Create", ctx, mock.AnythingOfType("*models.Media")).Return(nil)
+
+		media, err := service.UploadFile(ctx, bytes.NewReader(pngContent), header, userID)
+		require.NoError(t, err)
+		assert.NotEqual(t, existing.ID, media.ID)
+		assert.Equal(t, "again.png", media.Filename)
+		assert.Equal(t, existing.StorageKey, media.StorageKey)
+		assert.Equal(t, existing.OriginalURL, media.OriginalURL)
+		assert.Equal(t, existing.Thumbnails, media.Thumbnails)
+		assert.Equal(t, models.MediaStatusReady, media.Status)
+
+		storage.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		processor.AssertNotCalled(t, "Process", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("duplicate of media still processing gets its own thumbnail job", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		jobs := NewMockJobRepository()
+		service := NewMediaServiceWithJobs(repo, new(MockReadableStorageService), validator, new(MockImageProcessor),
+			NewJobQueue(jobs, JobQueueOptions{}, logger), logger, DefaultMediaServiceConfig())
+
+		existing := &models.Media{
+			ID:          models.NewID(),
+			MimeType:    "image/png",
+			Status:      models.MediaStatusPending,
+			StorageKey:  "uploads/2026/01/02/abc/original.png",
+			ContentHash: contentHash,
+			CreatedBy:   userID,
+		}
+		repo.On("GetByContentHash", ctx, userID, contentHash).Return(existing, nil)
+		repo.On("Create", ctx, mock.AnythingOfType("*models.Media")).Return(nil)
+
+		media, err := service.UploadFile(ctx, bytes.NewReader(pngContent), header, userID)
+		require.NoError(t, err)
+		assert.Equal(t, models.MediaStatusPending, media.Status)
+
+		queued := jobs.byType(JobTypeGenerateThumbnails)
+		require.Len(t, queued, 1)
+		var payload ThumbnailJob
+		require.NoError(t, queued[0].DecodePayload(&payload))
+		assert.Equal(t, media.ID, payload.MediaID)
+	})
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// planLimitedMediaService turns away uploads that would take a user past the
+// storage limit of their plan
+type planLimitedMediaService struct {
+	MediaService
+	limits PlanLimiter
+}
+
+// NewPlanLimitedMediaService enforces the storage limit of the uploader's plan
+// on the uploads of a media service
+func NewPlanLimitedMediaService(media MediaService, limits PlanLimiter) MediaService {
+	return &planLimitedMediaService{MediaService: media, limits: limits}
+}
+
+func (s *planLimitedMediaService) UploadFile(ctx context.Context, file io.Reader, header *multipart.FileHeader, userID models.ID) (*models.Media, error) {
+	if err := s.limits.CheckStorageLimit(ctx, userID, header.Size); err != nil {
+		return nil, err
+	}
+	return s.MediaService.UploadFile(ctx, file, header, userID)
+}
+
+func (s *planLimitedMediaService) UploadFiles(ctx context.Context, files map[string][]*multipart.FileHeader, userID models.ID) ([]*models.Media, error) {
+	var total int64
+	for _, headers := range files {
+		for _, header := range headers {
+			total += header.Size
+		}
+	}
+	if err := s.limits.CheckStorageLimit(ctx, userID, total); err != nil {
+		return nil, err
+	}
+	return s.MediaService.UploadFiles(ctx, files, userID)
+}
+
+func (s *planLimitedMediaService) GeneratePresignedUploadURL(ctx context.Context, filename, contentType string, size int64, userID models.ID) (*PresignedUploadInfo, error) {
+	if err := s.limits.CheckStorageLimit(ctx, userID, size); err != nil {
+		return nil, err
+	}
+	return s.MediaService.GeneratePresignedUploadURL(ctx, filename, contentType, size, userID)
+}
+
+func (s *planLimitedMediaService) InitiateMultipartUpload(ctx context.Context, filename, contentType string, size int64, userID models.ID) (*MultipartUploadInfo, error) {
+	if err := s.limits.CheckStorageLimit(ctx, userID, size); err != nil {
+		return nil, err
+	}
+	return s.MediaService.InitiateMultipartUpload(ctx, filename, contentType, size, userID)
+}
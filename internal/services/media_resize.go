@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Fits of resized images
+const (
+	// ResizeFitContain scales the image to fit within the box, keeping its
+	// aspect ratio
+	ResizeFitContain = "contain"
+	// ResizeFitCover scales the image to fill the box, keeping its aspect
+	// ratio and cropping the overflow around the center
+	ResizeFitCover = "cover"
+	// ResizeFitFill stretches the image to the box
+	ResizeFitFill = "fill"
+)
+
+// Resize errors
+var (
+	ErrInvalidResize     = errors.New("invalid resize parameters")
+	ErrMediaNotResizable = errors.New("media cannot be resized")
+	ErrMediaNotFound     = errs.NotFound("media not found")
+)
+
+// DefaultResizeMaxDimension is the largest width or height resized images are
+// rendered at unless configured
+const DefaultResizeMaxDimension = 2048
+
+// MediaResizeOptions configures the rendering of resized images
+type MediaResizeOptions struct {
+	// MaxDimension is the largest width or height that can be asked for
+	MaxDimension int
+	// CacheTTL is how long rendered images are cached
+	CacheTTL time.Duration
+	// MaxConcurrent is how many images are rendered at once, the number of
+	// CPUs unless set
+	MaxConcurrent int
+}
+
+// ResizeRequest is the size and fit of a resized image. Either dimension may
+// be zero to scale to the other, keeping the aspect ratio.
+type ResizeRequest struct {
+	Width  int
+	Height int
+	Fit    string
+}
+
+// ResizedImage is a rendered resized image
+type ResizedImage struct {
+	Data        []byte
+	ContentType string
+	// ETag identifies the rendition, which never changes for the same media,
+	// size and fit
+	ETag string
+}
+
+// MediaResizeService renders uploaded images at the size a frontend asks for,
+// caching the renditions
+type MediaResizeService struct {
+	mediaRepo repository.MediaRepository
+	storage   ObjectReader
+	cache     ImageCache
+	opts      MediaResizeOptions
+	renders   chan struct{}
+	logger    *zap.Logger
+}
+
+// NewMediaResizeService creates a media resize service
+func NewMediaResizeService(mediaRepo repository.MediaRepository, storage ObjectReader, cache ImageCache, opts MediaResizeOptions, logger *zap.Logger) *MediaResizeService {
+	if opts.MaxDimension <= 0 {
+		opts.MaxDimension = DefaultResizeMaxDimension
+	}
+	if opts.MaxConcurrent <= 0 {
+		opts.MaxConcurrent = runtime.NumCPU()
+	}
+	return &MediaResizeService{
+		mediaRepo: mediaRepo,
+		storage:   storage,
+		cache:     cache,
+		opts:      opts,
+		renders:   make(chan struct{}, opts.MaxConcurrent),
+		logger:    logger,
+	}
+}
+
+// Resize returns an uploaded image resized as requested, rendering it unless
+// it is cached. Images are never scaled up past their original size.
+func (s *MediaResizeService) Resize(ctx context.Context, mediaID models.ID, req ResizeRequest) (*ResizedImage, error) {
+	req, err := s.validate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := s.mediaRepo.GetByID(ctx, mediaID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, ErrMediaNotFound
+		}
+		return nil, fmt.Errorf("failed to get media: %w", err)
+	}
+	if media.IsDeleted() || media.ProcessingStatus() == models.MediaStatusRejected || media.StorageKey == "" {
+		return nil, ErrMediaNotFound
+	}
+	if !resizable(media.MimeType) {
+		return nil, ErrMediaNotResizable
+	}
+
+	format := resizeFormat(media.MimeType)
+	key := resizeCacheKey(media.StorageKey, req, format)
+	resized := &ResizedImage{ContentType: "image/" + format, ETag: `"` + key[:32] + `"`}
+
+	data, err := s.cache.Get(ctx, key)
+	if err != nil {
+		s.logger.Warn("Failed to read cached resized image", zap.String("media_id", mediaID.String()), zap.Error(err))
+	}
+	if data != nil {
+		resized.Data = data
+		return resized, nil
+	}
+
+	data, err = s.render(ctx, media, req, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Set(ctx, key, data, s.opts.CacheTTL); err != nil {
+		s.logger.Warn("Failed to cache resized image", zap.String("media_id", mediaID.String()), zap.Error(err))
+	}
+	resized.Data = data
+	return resized, nil
+}
+
+// validate checks a resize request against the limits and fills in the
+// default fit
+func (s *MediaResizeService) validate(req ResizeRequest) (ResizeRequest, error) {
+	if req.Width < 0 || req.Height < 0 {
+		return req, fmt.Errorf("%w: width and height must not be negative", ErrInvalidResize)
+	}
+	if req.Width == 0 && req.Height == 0 {
+		return req, fmt.Errorf("%w: width or height is required", ErrInvalidResize)
+	}
+	if req.Width > s.opts.MaxDimension || req.Height > s.opts.MaxDimension {
+		return req, fmt.Errorf("%w: width and height must be at most %d", ErrInvalidResize, s.opts.MaxDimension)
+	}
+	switch req.Fit {
+	case "":
+		req.Fit = ResizeFitContain
+	case ResizeFitContain:
+	case ResizeFitCover, ResizeFitFill:
+		if req.Width == 0 || req.Height == 0 {
+			return req, fmt.Errorf("%w: fit %s needs both width and height", ErrInvalidResize, req.Fit)
+		}
+	default:
+		return req, fmt.Errorf("%w: fit must be one of %s, %s or %s", ErrInvalidResize, ResizeFitContain, ResizeFitCover, ResizeFitFill)
+	}
+	return req, nil
+}
+
+// render downloads and resizes the original image. Renders are limited to
+// MaxConcurrent at once, as decoding large originals is costly.
+func (s *MediaResizeService) render(ctx context.Context, media *models.Media, req ResizeRequest, format string) ([]byte, error) {
+	select {
+	case s.renders <- struct{}{}:
+		defer func() { <-s.renders }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	original, err := s.storage.Download(ctx, media.StorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download media: %w", err)
+	}
+	img, err := decodeUpright(original)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMediaNotResizable, err)
+	}
+
+	data, err := encodeImage(resizeImage(img, req), format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode resized image: %w", err)
+	}
+	return data, nil
+}
+
+// resizeImage resizes an image to the box and fit of a request, without
+// scaling it up
+func resizeImage(img image.Image, req ResizeRequest) image.Image {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	width, height := req.Width, req.Height
+
+	if width == 0 || height == 0 {
+		if width > srcW || height > srcH {
+			return img
+		}
+		return imaging.Resize(img, width, height, imaging.Lanczos)
+	}
+
+	if req.Fit == ResizeFitContain {
+		return imaging.Fit(img, width, height, imaging.Lanczos)
+	}
+	// A box larger than the original is scaled down to it, keeping the shape
+	// of the box
+	if width > srcW || height > srcH {
+		scale := min(float64(srcW)/float64(width), float64(srcH)/float64(height))
+		width = max(int(float64(width)*scale), 1)
+		height = max(int(float64(height)*scale), 1)
+	}
+	if req.Fit == ResizeFitCover {
+		return imaging.Fill(img, width, height, imaging.Center, imaging.Lanczos)
+	}
+	return imaging.Resize(img, width, height, imaging.Lanczos)
+}
+
+// resizable reports whether images of the MIME type can be decoded for resizing
+func resizable(mimeType string) bool {
+	switch mimeType {
+	case "image/jpeg", "image/jpg", "image/png", "image/gif", "image/webp":
+		return true
+	}
+	return false
+}
+
+// resizeFormat is the format resized images of the MIME type are encoded in:
+// PNG and WebP are kept for their transparency, the others become JPEG
+func resizeFormat(mimeType string) string {
+	switch mimeType {
+	case "image/png":
+		return "png"
+	case "image/webp":
+		return VariantWebP
+	}
+	return "jpeg"
+}
+
+// resizeCacheKey identifies the rendition of a stored image
+func resizeCacheKey(storageKey string, req ResizeRequest, format string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		storageKey, fmt.Sprint(req.Width), fmt.Sprint(req.Height), req.Fit, format,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// testPNG encodes a plain image of the given size
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func decodedSize(t *testing.T, data []byte) (int, int) {
+	t.Helper()
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	return cfg.Width, cfg.Height
+}
+
+func TestMediaResizeService_Resize(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	media := &models.Media{
+		ID:         models.NewID(),
+		MimeType:   "image/png",
+		Status:     models.MediaStatusReady,
+		StorageKey: "uploads/2026/01/02/abc/original.png",
+	}
+	original := testPNG(t, 400, 200)
+
+	newService := func() (*MediaResizeService, *MockMediaRepository, *MockReadableStorageService) {
+		repo := new(MockMediaRepository)
+		storage := new(MockReadableStorageService)
+		repo.On("GetByID", ctx, media.ID).Return(media, nil)
+		storage.On("Download", ctx, media.StorageKey).Return(original, nil)
+		service := NewMediaResizeService(repo, storage, NewDiskImageCache(t.TempDir()),
+			MediaResizeOptions{MaxDimension: 1000, CacheTTL: time.Hour}, logger)
+		return service, repo, storage
+	}
+
+	t.Run("fits", func(t *testing.T) {
+		service, _, _ := newService()
+		tests := []struct {
+			req           ResizeRequest
+			width, height int
+		}{
+			{ResizeRequest{Width: 100, Height: 100}, 100, 50},
+			{ResizeRequest{Width: 100, Height: 100, Fit: ResizeFitCover}, 100, 100},
+			{ResizeRequest{Width: 100, Height: 100, Fit: ResizeFitFill}, 100, 100},
+			{ResizeRequest{Width: 200}, 200, 100},
+			{ResizeRequest{Height: 50}, 100, 50},
+			// Never scaled up past the original
+			{ResizeRequest{Width: 800}, 400, 200},
+			{ResizeRequest{Width: 800, Height: 800, Fit: ResizeFitCover}, 200, 200},
+		}
+		for _, tt := range tests {
+			resized, err := service.Resize(ctx, media.ID, tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, "image/png", resized.ContentType)
+			width, height := decodedSize(t, resized.Data)
+			assert.Equal(t, tt.width, width, "%+v", tt.req)
+			assert.Equal(t, tt.height, height, "%+v", tt.req)
+		}
+	})
+
+	t.Run("renditions are cached", func(t *testing.T) {
+		service, _, storage := newService()
+		first, err := service.Resize(ctx, media.ID, ResizeRequest{Width: 100})
+		require.NoError(t, err)
+		second, err := service.Resize(ctx, media.ID, ResizeRequest{Width: 100, Fit: ResizeFitContain})
+		require.NoError(t, err)
+		assert.Equal(t, first.ETag, second.ETag)
+		assert.Equal(t, first.Data, second.Data)
+		storage.AssertNumberOfCalls(t, "Download", 1)
+
+		other, err := service.Resize(ctx, media.ID, ResizeRequest{Width: 120})
+		require.NoError(t, err)
+		assert.NotEqual(t, first.ETag, other.ETag)
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		service, _, storage := newService()
+		for _, req := range []ResizeRequest{
+			{},
+			{Width: -1, Height: 100},
+			{Width: 1001},
+			{Width: 100, Fit: ResizeFitCover},
+			{Width: 100, Height: 100, Fit: "stretch"},
+		} {
+			_, err := service.Resize(ctx, media.ID, req)
+			assert.ErrorIs(t, err, ErrInvalidResize, "%+v", req)
+		}
+		storage.AssertNotCalled(t, "Download", ctx, media.StorageKey)
+	})
+
+	t.Run("only stored images are resized", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		service := NewMediaResizeService(repo, new(MockReadableStorageService), NewDiskImageCache(t.TempDir()), MediaResizeOptions{}, logger)
+
+		video := &models.Media{ID: models.NewID(), MimeType: "video/mp4", StorageKey: "uploads/a/original.mp4"}
+		rejected := &models.Media{ID: models.NewID(), MimeType: "image/png", Status: models.MediaStatusRejected, StorageKey: "quarantine/uploads/a/original.png"}
+		missing := models.NewID()
+		repo.On("GetByID", ctx, video.ID).Return(video, nil)
+		repo.On("GetByID", ctx, rejected.ID).Return(rejected, nil)
+		repo.On("GetByID", ctx, missing).Return(nil, repository.ErrNotFound)
+
+		_, err := service.Resize(ctx, video.ID, ResizeRequest{Width: 100})
+		assert.ErrorIs(t, err, ErrMediaNotResizable)
+		_, err = service.Resize(ctx, rejected.ID, ResizeRequest{Width: 100})
+		assert.ErrorIs(t, err, ErrMediaNotFound)
+		_, err = service.Resize(ctx, missing, ResizeRequest{Width: 100})
+		assert.ErrorIs(t, err, ErrMediaNotFound)
+	})
+}
+
+func TestDiskImageCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	cache := &diskImageCache{dir: t.TempDir(), now: func() time.Time { return now }}
+	key := resizeCacheKey("uploads/a/original.png", ResizeRequest{Width: 100, Fit: ResizeFitContain}, "png")
+
+	data, err := cache.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	require.NoError(t, cache.Set(ctx, key, []byte("resized"), time.Hour))
+	data, err = cache.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("resized"), data)
+
+	now = now.Add(time.Hour)
+	data, err = cache.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Nil(t, data, "expired renditions are not served")
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Malware scan modes
+const (
+	// ScanModeAsync stores uploads right away and scans them in background
+	// jobs, quarantining the infected ones
+	ScanModeAsync = "async"
+	// ScanModeBlocking scans uploads before they are stored and refuses the
+	// infected ones
+	ScanModeBlocking = "blocking"
+)
+
+// quarantinePrefix is where infected files are moved to. It is one of the
+// private prefixes, so quarantined files are never served.
+const quarantinePrefix = "quarantine/"
+
+// ErrMalwareDetected is returned when an upload is found infected
+var ErrMalwareDetected = errors.New("file was rejected by the malware scan")
+
+// MediaScanOptions configures the malware scanning of uploads
+type MediaScanOptions struct {
+	// Mode is ScanModeAsync or ScanModeBlocking
+	Mode string
+	// BaseURL is the base URL of stored media, to find the storage keys of
+	// thumbnails and renditions from their URLs
+	BaseURL string
+}
+
+// MediaScanService scans uploaded media for malware. Infected files found
+// after they were stored are moved to quarantine, their media is marked
+// rejected and the uploader is emailed.
+type MediaScanService struct {
+	scanner   MalwareScanner
+	mediaRepo repository.MediaRepository
+	userRepo  repository.UserRepository
+	storage   StorageService
+	jobs      JobEnqueuer
+	email     EmailService
+	opts      MediaScanOptions
+	logger    *zap.Logger
+	now       func() time.Time
+}
+
+// NewMediaScanService creates a media scan service. Scans fall back to
+// blocking without a job queue or storage that can read uploads back.
+func NewMediaScanService(
+	scanner MalwareScanner,
+	mediaRepo repository.MediaRepository,
+	userRepo repository.UserRepository,
+	storage StorageService,
+	jobs JobEnqueuer,
+	email EmailService,
+	opts MediaScanOptions,
+	logger *zap.Logger,
+) *MediaScanService {
+	if opts.Mode == ScanModeAsync {
+		if _, ok := storage.(ObjectReader); !ok || jobs == nil {
+			logger.Warn("Uploads are scanned before they are stored: background scans need a job queue and storage that can read uploads back")
+			opts.Mode = ScanModeBlocking
+		}
+	}
+	if opts.Mode != ScanModeAsync {
+		opts.Mode = ScanModeBlocking
+	}
+	return &MediaScanService{
+		scanner:   scanner,
+		mediaRepo: mediaRepo,
+		userRepo:  userRepo,
+		storage:   storage,
+		jobs:      jobs,
+		email:     email,
+		opts:      opts,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// Mode returns how uploads are scanned
+func (s *MediaScanService) Mode() string {
+	return s.opts.Mode
+}
+
+// CheckFile scans a file about to be stored and returns ErrMalwareDetected
+// when it is infected
+func (s *MediaScanService) CheckFile(ctx context.Context, file io.Reader, filename string) error {
+	result, err := s.scanner.Scan(ctx, file)
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", filename, err)
+	}
+	if result.Infected {
+		s.logger.Warn("Refused infected upload", zap.String("filename", filename), zap.String("threat", result.Threat))
+		return fmt.Errorf("%w: %s", ErrMalwareDetected, filename)
+	}
+	return nil
+}
+
+// QueueScan queues the background scan of stored media
+func (s *MediaScanService) QueueScan(ctx context.Context, mediaID models.ID) {
+	if err := s.jobs.Enqueue(ctx, JobTypeScanMedia, MediaScanJob{MediaID: mediaID}); err != nil {
+		s.logger.Error("Failed to queue malware scan",
+			zap.String("media_id", mediaID.String()),
+			zap.Error(err))
+	}
+}
+
+// ScanMedia scans stored media. Infected media is quarantined and rejected,
+// and its uploader emailed; clean media is marked scanned.
+func (s *MediaScanService) ScanMedia(ctx context.Context, mediaID models.ID) error {
+	reader, ok := s.storage.(ObjectReader)
+	if !ok {
+		return PermanentJobError(errors.New("storage backend cannot read uploads back"))
+	}
+
+	media, err := s.mediaRepo.GetByID(ctx, mediaID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return PermanentJobError(err)
+		}
+		return err
+	}
+	if media.IsDeleted() || media.ProcessingStatus() == models.MediaStatusRejected {
+		return nil
+	}
+
+	data, err := reader.Download(ctx, media.StorageKey)
+	if err != nil {
+		return err
+	}
+	result, err := s.scanner.Scan(ctx, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to scan media: %w", err)
+	}
+
+	now := s.now()
+	media.ScannedAt = &now
+	if !result.Infected {
+		if err := s.mediaRepo.Update(ctx, media); err != nil {
+			return fmt.Errorf("failed to record malware scan: %w", err)
+		}
+		return nil
+	}
+
+	if err := s.quarantine(ctx, media, data, result.Threat); err != nil {
+		return err
+	}
+	s.logger.Warn("Quarantined infected upload",
+		zap.String("media_id", mediaID.String()),
+		zap.String("threat", result.Threat))
+	s.notifyOwner(ctx, media)
+	return nil
+}
+
+// quarantine moves the file of infected media to the quarantine, deletes its
+// thumbnails and renditions and marks it rejected
+func (s *MediaScanService) quarantine(ctx context.Context, media *models.Media, data []byte, threat string) error {
+	original := media.StorageKey
+	quarantined := quarantinePrefix + original
+	if _, err := s.storage.Upload(ctx, quarantined, data, media.MimeType, nil); err != nil {
+		return fmt.Errorf("failed to quarantine media: %w", err)
+	}
+
+	keys := []string{original}
+	for _, url := range media.Thumbnails {
+		keys = append(keys, strings.TrimPrefix(url, s.opts.BaseURL+"/"))
+	}
+	if media.PlaybackURL != "" {
+		keys = append(keys, strings.TrimPrefix(media.PlaybackURL, s.opts.BaseURL+"/"))
+	}
+
+	// Rejected before the files are deleted, so that the media is never
+	// served while they are half gone
+	media.Status = models.MediaStatusRejected
+	media.Threat = threat
+	if media.Threat == "" {
+		media.Threat = "unknown"
+	}
+	media.StorageKey = quarantined
+	media.OriginalURL = ""
+	media.Thumbnails = nil
+	media.PlaybackURL = ""
+	if err := s.mediaRepo.Update(ctx, media); err != nil {
+		return fmt.Errorf("failed to reject media: %w", err)
+	}
+
+	// Duplicate uploads sharing the file are rejected by their own scans, the
+	// last of which deletes it
+	refs, err := s.mediaRepo.CountByStorageKey(ctx, original)
+	if err != nil {
+		s.logger.Error("Failed to count media sharing infected file", zap.String("media_id", media.ID.String()), zap.Error(err))
+		return nil
+	}
+	if refs > 0 {
+		return nil
+	}
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := s.storage.Delete(ctx, key); err != nil {
+			s.logger.Error("Failed to delete infected file",
+				zap.String("media_id", media.ID.String()),
+				zap.String("key", key),
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// notifyOwner emails the uploader of rejected media
+func (s *MediaScanService) notifyOwner(ctx context.Context, media *models.Media) {
+	if s.email == nil {
+		return
+	}
+	owner, err := s.userRepo.GetByID(ctx, media.CreatedBy)
+	if err != nil {
+		s.logger.Warn("Failed to find uploader of rejected media", zap.String("media_id", media.ID.String()), zap.Error(err))
+		return
+	}
+
+	msg, err := renderMediaRejected(media)
+	if err != nil {
+		s.logger.Error("Failed to render rejected media email", zap.Error(err))
+		return
+	}
+	msg.To = owner.Email
+	if err := s.email.Send(ctx, msg); err != nil {
+		s.logger.Warn("Failed to send rejected media email", zap.String("media_id", media.ID.String()), zap.Error(err))
+	}
+}
+
+var mediaRejectedHTML = template.Must(template.New("media_rejected").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #333;">
+  <h2>We removed a file you uploaded</h2>
+  <p>Our malware scan found <strong>{{.Threat}}</strong> in <strong>{{.Filename}}</strong>, so it was taken down and is no longer shown on your invitation.</p>
+  <p>If the file came from someone else, scan your device before uploading it again. If you believe this is a mistake, reply to this email.</p>
+</body>
+</html>`))
+
+// renderMediaRejected renders the HTML and plain text email about rejected media
+func renderMediaRejected(media *models.Media) (*EmailMessage, error) {
+	var html bytes.Buffer
+	if err := mediaRejectedHTML.Execute(&html, media); err != nil {
+		return nil, fmt.Errorf("failed to render rejected media email: %w", err)
+	}
+	text := fmt.Sprintf("We removed a file you uploaded\n\nOur malware scan found %s in %s, so it was taken down and is no longer shown on your invitation.\n\n"+
+		"If the file came from someone else, scan your device before uploading it again. If you believe this is a mistake, reply to this email.\n",
+		media.Threat, media.Filename)
+	return &EmailMessage{
+		Subject: "We removed a file you uploaded",
+		HTML:    html.String(),
+		Text:    text,
+	}, nil
+}
+
+// scanningMediaService runs the uploads of a media service through the malware scan
+type scanningMediaService struct {
+	MediaService
+	scans *MediaScanService
+}
+
+// NewScanningMediaService scans the uploads of a media service for malware,
+// before they are stored or in background jobs after, depending on the mode
+// of the scan service
+func NewScanningMediaService(media MediaService, scans *MediaScanService) MediaService {
+	return &scanningMediaService{MediaService: media, scans: scans}
+}
+
+func (s *scanningMediaService) UploadFile(ctx context.Context, file io.Reader, header *multipart.FileHeader, userID models.ID) (*models.Media, error) {
+	if s.scans.Mode() == ScanModeBlocking {
+		// Buffered, as the file is read once by the scanner and once by the upload
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		if err := s.scans.CheckFile(ctx, bytes.NewReader(data), header.Filename); err != nil {
+			return nil, err
+		}
+		return s.MediaService.UploadFile(ctx, bytes.NewReader(data), header, userID)
+	}
+
+	media, err := s.MediaService.UploadFile(ctx, file, header, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.scans.QueueScan(ctx, media.ID)
+	return media, nil
+}
+
+func (s *scanningMediaService) UploadFiles(ctx context.Context, files map[string][]*multipart.FileHeader, userID models.ID) ([]*models.Media, error) {
+	if s.scans.Mode() == ScanModeBlocking {
+		// One infected file refuses the whole request
+		for _, headers := range files {
+			for _, header := range headers {
+				if err := s.checkHeader(ctx, header); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return s.MediaService.UploadFiles(ctx, files, userID)
+	}
+
+	uploaded, err := s.MediaService.UploadFiles(ctx, files, userID)
+	for _, media := range uploaded {
+		s.scans.QueueScan(ctx, media.ID)
+	}
+	return uploaded, err
+}
+
+func (s *scanningMediaService) checkHeader(ctx context.Context, header *multipart.FileHeader) error {
+	file, err := header.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", header.Filename, err)
+	}
+	defer file.Close()
+	return s.scans.CheckFile(ctx, file, header.Filename)
+}
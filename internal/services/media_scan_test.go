@@ -0,0 +1,200 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// stubMalwareScanner reports files containing its signature as infected
+type stubMalwareScanner struct {
+	signature string
+	scanned   int
+}
+
+func (s *stubMalwareScanner) Scan(ctx context.Context, file io.Reader) (*ScanResult, error) {
+	s.scanned++
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Contains(data, []byte(s.signature)) {
+		return &ScanResult{Infected: true, Threat: "Eicar-Test-Signature"}, nil
+	}
+	return &ScanResult{}, nil
+}
+
+// recordingMediaService records the files uploaded through it
+type recordingMediaService struct {
+	MediaService
+	uploaded [][]byte
+}
+
+func (m *recordingMediaService) UploadFile(ctx context.Context, file io.Reader, header *multipart.FileHeader, userID models.ID) (*models.Media, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	m.uploaded = append(m.uploaded, data)
+	return &models.Media{ID: models.NewID(), Filename: header.Filename, CreatedBy: userID}, nil
+}
+
+func TestScanningMediaService_Blocking(t *testing.T) {
+	ctx := context.Background()
+	scanner := &stubMalwareScanner{signature: "EICAR"}
+	scans := NewMediaScanService(scanner, new(MockMediaRepository), &MockUserRepository{}, new(MockStorageService), nil, nil,
+		MediaScanOptions{Mode: ScanModeAsync}, zaptest.NewLogger(t))
+	// Without a job queue scans cannot run in the background
+	require.Equal(t, ScanModeBlocking, scans.Mode())
+
+	inner := &recordingMediaService{}
+	service := NewScanningMediaService(inner, scans)
+
+	_, err := service.UploadFile(ctx, bytes.NewReader([]byte("X5O!P%@AP EICAR")), &multipart.FileHeader{Filename: "bad.png"}, models.NewID())
+	assert.ErrorIs(t, err, ErrMalwareDetected)
+	assert.Empty(t, inner.uploaded, "infected files are never stored")
+
+	media, err := service.UploadFile(ctx, bytes.NewReader([]byte("clean photo")), &multipart.FileHeader{Filename: "good.png"}, models.NewID())
+	require.NoError(t, err)
+	assert.Equal(t, "good.png", media.Filename)
+	require.Len(t, inner.uploaded, 1)
+	assert.Equal(t, []byte("clean photo"), inner.uploaded[0])
+	assert.Equal(t, 2, scanner.scanned)
+}
+
+func TestScanningMediaService_AsyncQueuesScan(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	jobs := NewMockJobRepository()
+	scanner := &stubMalwareScanner{signature: "EICAR"}
+	scans := NewMediaScanService(scanner, new(MockMediaRepository), &MockUserRepository{}, new(MockReadableStorageService),
+		NewJobQueue(jobs, JobQueueOptions{}, logger), nil, MediaScanOptions{Mode: ScanModeAsync}, logger)
+	require.Equal(t, ScanModeAsync, scans.Mode())
+
+	inner := &recordingMediaService{}
+	media, err := NewScanningMediaService(inner, scans).UploadFile(ctx, bytes.NewReader([]byte("X5O!P%@AP EICAR")), &multipart.FileHeader{Filename: "bad.png"}, models.NewID())
+	require.NoError(t, err)
+	assert.Len(t, inner.uploaded, 1)
+	assert.Zero(t, scanner.scanned, "the file is scanned by the job")
+
+	queued := jobs.byType(JobTypeScanMedia)
+	require.Len(t, queued, 1)
+	var payload MediaScanJob
+	require.NoError(t, queued[0].DecodePayload(&payload))
+	assert.Equal(t, media.ID, payload.MediaID)
+}
+
+func TestMediaScanService_ScanMedia(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	owner := &models.User{ID: models.NewID(), Email: "owner@example.com"}
+	opts := MediaScanOptions{Mode: ScanModeAsync, BaseURL: "http://example.com"}
+
+	t.Run("infected media is quarantined and rejected", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		storage := new(MockReadableStorageService)
+		users := &MockUserRepository{}
+		email := &MockEmailService{}
+		scans := NewMediaScanService(&stubMalwareScanner{signature: "EICAR"}, repo, users, storage,
+			NewJobQueue(NewMockJobRepository(), JobQueueOptions{}, logger), email, opts, logger)
+
+		media := &models.Media{
+			ID:          models.NewID(),
+			Filename:    "bad.png",
+			MimeType:    "image/png",
+			StorageKey:  "uploads/2026/01/02/abc/original.png",
+			OriginalURL: "http://example.com/uploads/2026/01/02/abc/original.png",
+			Thumbnails:  map[string]string{"small": "http://example.com/uploads/2026/01/02/abc/small.png"},
+			Status:      models.MediaStatusReady,
+			CreatedBy:   owner.ID,
+		}
+		infected := []byte("X5O!P%@AP EICAR")
+		repo.On("GetByID", ctx, media.ID).Return(media, nil)
+		repo.On("Update", ctx, media).Return(nil)
+		storage.On("Download", ctx, "uploads/2026/01/02/abc/original.png").Return(infected, nil)
+		storage.On("Upload", ctx, "quarantine/uploads/2026/01/02/abc/original.png", infected, "image/png", mock.Anything).Return("", nil)
+		repo.On("CountByStorageKey", ctx, "uploads/2026/01/02/abc/original.png").Return(int64(0), nil)
+		storage.On("Delete", ctx, "uploads/2026/01/02/abc/original.png").Return(nil)
+		storage.On("Delete", ctx, "uploads/2026/01/02/abc/small.png").Return(nil)
+		users.On("GetByID", mock.Anything, owner.ID).Return(owner, nil)
+
+		require.NoError(t, scans.ScanMedia(ctx, media.ID))
+		assert.Equal(t, models.MediaStatusRejected, media.Status)
+		assert.Equal(t, "Eicar-Test-Signature", media.Threat)
+		assert.Equal(t, "quarantine/uploads/2026/01/02/abc/original.png", media.StorageKey)
+		assert.Empty(t, media.OriginalURL)
+		assert.Empty(t, media.Thumbnails)
+		assert.NotNil(t, media.ScannedAt)
+		storage.AssertExpectations(t)
+
+		require.Len(t, email.sent, 1)
+		assert.Equal(t, owner.Email, email.sent[0].To)
+		assert.Contains(t, email.sent[0].Text, "bad.png")
+		assert.Contains(t, email.sent[0].Text, "Eicar-Test-Signature")
+
+		// Rejected media is not scanned again
+		require.NoError(t, scans.ScanMedia(ctx, media.ID))
+		storage.AssertNumberOfCalls(t, "Download", 1)
+	})
+
+	t.Run("files shared with a duplicate upload are kept for its own scan", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		storage := new(MockReadableStorageService)
+		users := &MockUserRepository{}
+		scans := NewMediaScanService(&stubMalwareScanner{signature: "EICAR"}, repo, users, storage,
+			NewJobQueue(NewMockJobRepository(), JobQueueOptions{}, logger), &MockEmailService{}, opts, logger)
+
+		media := &models.Media{ID: models.NewID(), MimeType: "image/png", StorageKey: "uploads/a/original.png", CreatedBy: owner.ID}
+		infected := []byte("X5O!P%@AP EICAR")
+		repo.On("GetByID", ctx, media.ID).Return(media, nil)
+		repo.On("Update", ctx, media).Return(nil)
+		repo.On("CountByStorageKey", ctx, "uploads/a/original.png").Return(int64(1), nil)
+		storage.On("Download", ctx, "uploads/a/original.png").Return(infected, nil)
+		storage.On("Upload", ctx, "quarantine/uploads/a/original.png", infected, "image/png", mock.Anything).Return("", nil)
+		users.On("GetByID", mock.Anything, owner.ID).Return(owner, nil)
+
+		require.NoError(t, scans.ScanMedia(ctx, media.ID))
+		assert.Equal(t, models.MediaStatusRejected, media.Status)
+		storage.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("clean media is marked scanned", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		storage := new(MockReadableStorageService)
+		email := &MockEmailService{}
+		scans := NewMediaScanService(&stubMalwareScanner{signature: "EICAR"}, repo, &MockUserRepository{}, storage,
+			NewJobQueue(NewMockJobRepository(), JobQueueOptions{}, logger), email, opts, logger)
+
+		media := &models.Media{ID: models.NewID(), StorageKey: "uploads/a/original.png", Status: models.MediaStatusReady}
+		repo.On("GetByID", ctx, media.ID).Return(media, nil)
+		repo.On("Update", ctx, media).Return(nil)
+		storage.On("Download", ctx, "uploads/a/original.png").Return([]byte("clean photo"), nil)
+
+		require.NoError(t, scans.ScanMedia(ctx, media.ID))
+		assert.Equal(t, models.MediaStatusReady, media.Status)
+		assert.NotNil(t, media.ScannedAt)
+		assert.Empty(t, email.sent)
+		storage.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("thumbnails are not rendered for rejected media", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		storage := new(MockReadableStorageService)
+		service := NewMediaServiceWithJobs(repo, storage, nil, new(MockImageProcessor), NewJobQueue(NewMockJobRepository(), JobQueueOptions{}, logger), logger, nil)
+
+		media := &models.Media{ID: models.NewID(), StorageKey: "quarantine/uploads/a/original.png", Status: models.MediaStatusRejected}
+		repo.On("GetByID", ctx, media.ID).Return(media, nil)
+
+		require.NoError(t, service.GenerateThumbnails(ctx, media.ID))
+		storage.AssertNotCalled(t, "Download", mock.Anything, mock.Anything)
+	})
+}
@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
@@ -62,7 +64,7 @@ func (m *MockMediaRepository) Create(ctx context.Context, media *models.Media) e
 	return args.Error(0)
 }
 
-func (m *MockMediaRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Media, error) {
+func (m *MockMediaRepository) GetByID(ctx context.Context, id models.ID) (*models.Media, error) {
 	args := m.Called(ctx, id)
 	result := args.Get(0)
 	if result == nil {
@@ -90,12 +92,12 @@ func (m *MockMediaRepository) Update(ctx context.Context, media *models.Media) e
 	return args.Error(0)
 }
 
-func (m *MockMediaRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockMediaRepository) Delete(ctx context.Context, id models.ID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockMediaRepository) SoftDelete(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockMediaRepository) SoftDelete(ctx context.Context, id models.ID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
@@ -105,11 +107,35 @@ func (m *MockMediaRepository) GetOrphaned(ctx context.Context, before time.Time)
 	return args.Get(0).([]*models.Media), args.Error(1)
 }
 
-func (m *MockMediaRepository) GetByCreatedBy(ctx context.Context, userID primitive.ObjectID, opts repository.ListOptions) ([]*models.Media, int64, error) {
+func (m *MockMediaRepository) GetByCreatedBy(ctx context.Context, userID models.ID, opts repository.ListOptions) ([]*models.Media, int64, error) {
 	args := m.Called(ctx, userID, opts)
 	return args.Get(0).([]*models.Media), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockMediaRepository) TotalSizeByCreatedBy(ctx context.Context, userID models.ID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMediaRepository) ListAllByCreatedBy(ctx context.Context, userID models.ID) ([]*models.Media, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*models.Media), args.Error(1)
+}
+
+func (m *MockMediaRepository) GetByContentHash(ctx context.Context, userID models.ID, hash string) (*models.Media, error) {
+	args := m.Called(ctx, userID, hash)
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*models.Media), args.Error(1)
+}
+
+func (m *MockMediaRepository) CountByStorageKey(ctx context.Context, key string) (int64, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // MockImageProcessor for testing
 type MockImageProcessor struct {
 	mock.Mock
@@ -123,6 +149,14 @@ func (m *MockImageProcessor) Process(ctx context.Context, reader io.Reader, mime
 	return args.Get(0).(*ProcessedImage), args.Error(1)
 }
 
+func (m *MockImageProcessor) Analyze(ctx context.Context, reader io.Reader, mimeType string) (*ProcessedImage, error) {
+	args := m.Called(ctx, reader, mimeType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ProcessedImage), args.Error(1)
+}
+
 func (m *MockImageProcessor) GenerateThumbnail(data []byte, width, height int, format string) ([]byte, error) {
 	args := m.Called(data, width, height, format)
 	return args.Get(0).([]byte), args.Error(1)
@@ -142,7 +176,7 @@ func (m *MockImageProcessor) ConvertToWebP(data []byte, quality float32) ([]byte
 }
 
 func TestFileValidator_Validate(t *testing.T) {
-	validator := NewFileValidator([]string{"image/jpeg", "image/png", "image/webp"}, 5*1024*1024)
+	validator := NewFileValidator([]string{"image/jpeg", "image/png", "image/webp", "video/mp4", "video/webm"}, 5*1024*1024)
 
 	tests := []struct {
 		name           string
@@ -163,6 +197,24 @@ func TestFileValidator_Validate(t *testing.T) {
 			fileContent:    []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}, // PNG magic number
 			expectedResult: &ValidationResult{MimeType: "image/png", Extension: "png", IsValid: true},
 		},
+		{
+			name:           "valid MP4 file",
+			filename:       "clip.mp4",
+			fileContent:    []byte("\x00\x00\x00\x20ftypisom"),
+			expectedResult: &ValidationResult{MimeType: "video/mp4", Extension: "mp4", IsValid: true},
+		},
+		{
+			name:           "valid WebM file",
+			filename:       "clip.webm",
+			fileContent:    []byte{0x1A, 0x45, 0xDF, 0xA3, 0x9F},
+			expectedResult: &ValidationResult{MimeType: "video/webm", Extension: "webm", IsValid: true},
+		},
+		{
+			name:          "MP4 without an ftyp box",
+			filename:      "clip.mp4",
+			fileContent:   []byte("\x00\x00\x00\x20moov"),
+			expectedError: "file content does not match extension: invalid magic number",
+		},
 		{
 			name:          "no extension",
 			filename:      "test",
@@ -221,7 +273,7 @@ func TestMediaService_UploadFile(t *testing.T) {
 	config := DefaultMediaServiceConfig()
 	service := NewMediaService(mockRepo, mockStorage, validator, mockImageProcessor, logger, config)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 
 	// Valid minimal JPEG file content (1x1 pixel)
 	jpegContent := []byte{
@@ -258,7 +310,7 @@ func TestMediaService_UploadFile(t *testing.T) {
 				mockImageProcessor.On("Process", mock.Anything, mock.AnythingOfType("*bytes.Reader"), "image/jpeg").
 					Return(&ProcessedImage{
 						OriginalData: jpegContent,
-						Thumbnails:   make(map[string][]byte),
+						Thumbnails:   map[string][]byte{"small_webp": []byte("webp thumb")},
 						Metadata: &ImageMetadata{
 							Width:  100,
 							Height: 100,
@@ -268,6 +320,10 @@ func TestMediaService_UploadFile(t *testing.T) {
 				mockStorage.On("Upload", mock.Anything, mock.AnythingOfType("string"),
 					mock.AnythingOfType("[]uint8"), "image/jpeg", mock.AnythingOfType("map[string]string")).
 					Return("http://example.com/uploads/test.jpg", nil)
+				mockStorage.On("Upload", mock.Anything, mock.MatchedBy(func(key string) bool { return strings.HasSuffix(key, "/small_webp.webp") }),
+					[]byte("webp thumb"), "image/webp", mock.Anything).
+					Return("http://example.com/uploads/small_webp.webp", nil)
+				mockRepo.On("GetByContentHash", mock.Anything, mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
 				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Media")).Return(nil)
 			},
 			expectError: false,
@@ -327,7 +383,7 @@ func TestMediaService_GetUserMedia(t *testing.T) {
 	config := DefaultMediaServiceConfig()
 	service := NewMediaService(mockRepo, mockStorage, validator, imageProcessor, logger, config)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 
 	tests := []struct {
 		name          string
@@ -346,8 +402,8 @@ func TestMediaService_GetUserMedia(t *testing.T) {
 			filters:  repository.MediaFilter{},
 			setupMocks: func() {
 				mediaList := []*models.Media{
-					{ID: primitive.NewObjectID(), Filename: "test1.jpg"},
-					{ID: primitive.NewObjectID(), Filename: "test2.jpg"},
+					{ID: models.NewID(), Filename: "test1.jpg"},
+					{ID: models.NewID(), Filename: "test2.jpg"},
 				}
 				mockRepo.On("List", ctx, mock.MatchedBy(func(filter repository.MediaFilter) bool {
 					return filter.CreatedBy != nil && *filter.CreatedBy == userID
@@ -393,14 +449,14 @@ func TestMediaService_DeleteMedia(t *testing.T) {
 	config := DefaultMediaServiceConfig()
 	service := NewMediaService(mockRepo, mockStorage, validator, imageProcessor, logger, config)
 
-	userID := primitive.NewObjectID()
-	mediaID := primitive.NewObjectID()
-	otherUserID := primitive.NewObjectID()
+	userID := models.NewID()
+	mediaID := models.NewID()
+	otherUserID := models.NewID()
 
 	tests := []struct {
 		name        string
-		mediaID     primitive.ObjectID
-		userID      primitive.ObjectID
+		mediaID     models.ID
+		userID      models.ID
 		setupMocks  func()
 		expectError bool
 		errorMsg    string
@@ -459,3 +515,421 @@ func TestMediaService_DeleteMedia(t *testing.T) {
 		})
 	}
 }
+
+// MockMultipartStorageService is a mock storage that supports multipart uploads
+type MockMultipartStorageService struct {
+	MockStorageService
+}
+
+func (m *MockMultipartStorageService) CreateMultipartUpload(ctx context.Context, key string, contentType string) (string, error) {
+	args := m.Called(ctx, key, contentType)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMultipartStorageService) PresignUploadPart(ctx context.Context, key, uploadID string, partNumber int, expiry time.Duration) (string, error) {
+	args := m.Called(ctx, key, uploadID, partNumber, expiry)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMultipartStorageService) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []CompletedPart) error {
+	args := m.Called(ctx, key, uploadID, parts)
+	return args.Error(0)
+}
+
+func (m *MockMultipartStorageService) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	args := m.Called(ctx, key, uploadID)
+	return args.Error(0)
+}
+
+func TestMediaService_MultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	userID := models.NewID()
+	validator := NewFileValidator([]string{"image/jpeg"}, 50*1024*1024)
+	imageProcessor := NewImageProcessor([]ThumbnailSize{}, false)
+
+	config := DefaultMediaServiceConfig()
+	config.MaxFileSize = 50 * 1024 * 1024
+	config.AllowedTypes = []string{"image/jpeg"}
+
+	t.Run("initiate presigns every part", func(t *testing.T) {
+		storage := new(MockMultipartStorageService)
+		service := NewMediaService(new(MockMediaRepository), storage, validator, imageProcessor, logger, config)
+
+		storage.On("CreateMultipartUpload", ctx, mock.AnythingOfType("string"), "image/jpeg").Return("upload-1", nil)
+		storage.On("PresignUploadPart", ctx, mock.AnythingOfType("string"), "upload-1", mock.AnythingOfType("int"), config.PresignExpiry).Return("https://storage.example.com/part", nil)
+
+		info, err := service.InitiateMultipartUpload(ctx, "album.jpg", "image/jpeg", 20*1024*1024, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "upload-1", info.UploadID)
+		assert.Contains(t, info.Key, info.MediaID)
+		assert.Len(t, info.Parts, 3) // 8MB + 8MB + 4MB
+		assert.Equal(t, 3, info.Parts[2].PartNumber)
+		storage.AssertNumberOfCalls(t, "PresignUploadPart", 3)
+	})
+
+	t.Run("initiate aborts when presigning fails", func(t *testing.T) {
+		storage := new(MockMultipartStorageService)
+		service := NewMediaService(new(MockMediaRepository), storage, validator, imageProcessor, logger, config)
+
+		storage.On("CreateMultipartUpload", ctx, mock.AnythingOfType("string"), "image/jpeg").Return("upload-1", nil)
+		storage.On("PresignUploadPart", ctx, mock.AnythingOfType("string"), "upload-1", 1, config.PresignExpiry).Return("", fmt.Errorf("signing failed"))
+		storage.On("AbortMultipartUpload", ctx, mock.AnythingOfType("string"), "upload-1").Return(nil)
+
+		_, err := service.InitiateMultipartUpload(ctx, "album.jpg", "image/jpeg", 1024, userID)
+		assert.Error(t, err)
+		storage.AssertCalled(t, "AbortMultipartUpload", ctx, mock.AnythingOfType("string"), "upload-1")
+	})
+
+	t.Run("initiate validates the file", func(t *testing.T) {
+		storage := new(MockMultipartStorageService)
+		service := NewMediaService(new(MockMediaRepository), storage, validator, imageProcessor, logger, config)
+
+		_, err := service.InitiateMultipartUpload(ctx, "album.png", "image/png", 1024, userID)
+		assert.ErrorContains(t, err, "file type not allowed")
+
+		_, err = service.InitiateMultipartUpload(ctx, "album.jpg", "image/jpeg", 100*1024*1024, userID)
+		assert.ErrorContains(t, err, "exceeds maximum allowed size")
+	})
+
+	t.Run("complete sorts parts", func(t *testing.T) {
+		storage := new(MockMultipartStorageService)
+		service := NewMediaService(new(MockMediaRepository), storage, validator, imageProcessor, logger, config)
+
+		sorted := []CompletedPart{{PartNumber: 1, ETag: "a"}, {PartNumber: 2, ETag: "b"}}
+		storage.On("CompleteMultipartUpload", ctx, "uploads/key.jpg", "upload-1", sorted).Return(nil)
+
+		url, err := service.CompleteMultipartUpload(ctx, "uploads/key.jpg", "upload-1", []CompletedPart{sorted[1], sorted[0]}, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, config.BaseURL+"/uploads/key.jpg", url)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("storage without multipart support", func(t *testing.T) {
+		service := NewMediaService(new(MockMediaRepository), new(MockStorageService), validator, imageProcessor, logger, config)
+
+		_, err := service.InitiateMultipartUpload(ctx, "album.jpg", "image/jpeg", 1024, userID)
+		assert.ErrorIs(t, err, ErrMultipartUploadUnsupported)
+		assert.ErrorIs(t, service.AbortMultipartUpload(ctx, "uploads/key.jpg", "upload-1", userID), ErrMultipartUploadUnsupported)
+	})
+}
+
+// MockReadableStorageService is a mock storage that can read stored files back
+type MockReadableStorageService struct {
+	MockStorageService
+}
+
+func (m *MockReadableStorageService) Download(ctx context.Context, key string) ([]byte, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func TestMediaService_AsyncThumbnails(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	validator := NewFileValidator([]string{"image/png"}, 5*1024*1024)
+	pngContent := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	config := DefaultMediaServiceConfig()
+	config.ThumbnailSizes = []ThumbnailSize{{Name: "small", Width: 150, Height: 150}}
+
+	t.Run("upload leaves thumbnails to a job", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		storage := new(MockReadableStorageService)
+		processor := new(MockImageProcessor)
+		jobs := NewMockJobRepository()
+		service := NewMediaServiceWithJobs(repo, storage, validator, processor, NewJobQueue(jobs, JobQueueOptions{}, logger), logger, config)
+
+		processor.On("Analyze", mock.Anything, mock.Anything, "image/png").Return(&ProcessedImage{
+			OriginalData: pngContent,
+			Metadata:     &ImageMetadata{Width: 10, Height: 10, Format: "png"},
+		}, nil)
+		storage.On("Upload", mock.Anything, mock.AnythingOfType("string"), pngContent, "image/png", mock.Anything).
+			Return("http://example.com/uploads/original.png", nil)
+		repo.On("GetByContentHash", mock.Anything, mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Media")).Return(nil)
+
+		media, err := service.UploadFile(ctx, bytes.NewReader(pngContent), &multipart.FileHeader{Filename: "photo.png", Size: int64(len(pngContent))}, models.NewID())
+		require.NoError(t, err)
+		assert.Empty(t, media.Thumbnails)
+		processor.AssertNotCalled(t, "Process", mock.Anything, mock.Anything, mock.Anything)
+
+		queued := jobs.byType(JobTypeGenerateThumbnails)
+		require.Len(t, queued, 1)
+		var payload ThumbnailJob
+		require.NoError(t, queued[0].DecodePayload(&payload))
+		assert.Equal(t, media.ID, payload.MediaID)
+	})
+
+	t.Run("job renders thumbnails and their variants next to the original", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		storage := new(MockReadableStorageService)
+		processor := new(MockImageProcessor)
+		variantConfig := *config
+		variantConfig.EnableAVIF = true
+		service := NewMediaServiceWithJobs(repo, storage, validator, processor, NewJobQueue(NewMockJobRepository(), JobQueueOptions{}, logger), logger, &variantConfig)
+
+		media := &models.Media{
+			ID:         models.NewID(),
+			MimeType:   "image/png",
+			Format:     "png",
+			StorageKey: "uploads/2026/01/02/abc/original.png",
+		}
+		repo.On("GetByID", ctx, media.ID).Return(media, nil)
+		storage.On("Download", ctx, media.StorageKey).Return(pngContent, nil)
+		processor.On("GenerateThumbnail", pngContent, 150, 150, "png").Return([]byte("thumb"), nil)
+		storage.On("Upload", ctx, "uploads/2026/01/02/abc/small.png", []byte("thumb"), "image/png", mock.Anything).
+			Return("http://example.com/uploads/2026/01/02/abc/small.png", nil)
+		processor.On("GenerateThumbnail", pngContent, 150, 150, "webp").Return([]byte("webp thumb"), nil)
+		storage.On("Upload", ctx, "uploads/2026/01/02/abc/small_webp.webp", []byte("webp thumb"), "image/webp", mock.Anything).
+			Return("http://example.com/uploads/2026/01/02/abc/small_webp.webp", nil)
+		processor.On("GenerateThumbnail", pngContent, 150, 150, "avif").Return([]byte("avif thumb"), nil)
+		storage.On("Upload", ctx, "uploads/2026/01/02/abc/small_avif.avif", []byte("avif thumb"), "image/avif", mock.Anything).
+			Return("http://example.com/uploads/2026/01/02/abc/small_avif.avif", nil)
+		repo.On("Update", ctx, media).Return(nil)
+
+		require.NoError(t, service.GenerateThumbnails(ctx, media.ID))
+		assert.Equal(t, map[string]string{
+			"small":      "http://example.com/uploads/2026/01/02/abc/small.png",
+			"small_webp": "http://example.com/uploads/2026/01/02/abc/small_webp.webp",
+			"small_avif": "http://example.com/uploads/2026/01/02/abc/small_avif.avif",
+		}, media.Thumbnails)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("job fails for good on storage that cannot read uploads back", func(t *testing.T) {
+		storage := new(MockStorageService)
+		service := NewMediaServiceWithJobs(new(MockMediaRepository), storage, validator, new(MockImageProcessor), NewJobQueue(NewMockJobRepository(), JobQueueOptions{}, logger), logger, config)
+
+		err := service.GenerateThumbnails(ctx, models.NewID())
+		var permanent *permanentJobError
+		assert.ErrorAs(t, err, &permanent)
+	})
+}
+
+// MockVideoTranscoder is a mock VideoTranscoder that writes placeholder renditions
+type MockVideoTranscoder struct {
+	mock.Mock
+}
+
+func (m *MockVideoTranscoder) Probe(ctx context.Context, path string) (*VideoInfo, error) {
+	args := m.Called(ctx, path)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*VideoInfo), args.Error(1)
+}
+
+func (m *MockVideoTranscoder) Transcode(ctx context.Context, inputPath, outputPath string) error {
+	args := m.Called(ctx, inputPath, outputPath)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	return os.WriteFile(outputPath, []byte("rendition"), 0o600)
+}
+
+func (m *MockVideoTranscoder) Poster(ctx context.Context, inputPath, outputPath string, at time.Duration) error {
+	args := m.Called(ctx, inputPath, outputPath, at)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	return os.WriteFile(outputPath, []byte("poster"), 0o600)
+}
+
+func TestMediaService_Video(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+	config := DefaultMediaServiceConfig()
+	validator := NewFileValidator(config.AllowedTypes, config.MaxFileSize)
+	mp4Content := []byte("\x00\x00\x00\x20ftypisom\x00\x00\x02\x00isomiso2avc1mp41")
+	header := &multipart.FileHeader{Filename: "first-dance.mp4", Size: 20 * 1024 * 1024}
+
+	t.Run("upload is stored and queued for transcoding", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		storage := new(MockReadableStorageService)
+		transcoder := new(MockVideoTranscoder)
+		jobs := NewMockJobRepository()
+		service := NewMediaServiceWithVideo(repo, storage, validator, new(MockImageProcessor), transcoder, NewJobQueue(jobs, JobQueueOptions{}, logger), logger, config)
+
+		transcoder.On("Probe", ctx, mock.AnythingOfType("string")).Return(&VideoInfo{Duration: 42 * time.Second, Width: 1920, Height: 1080}, nil)
+		storage.On("UploadStream", ctx, mock.MatchedBy(func(key string) bool { return strings.HasSuffix(key, "/original.mp4") }),
+			mock.Anything, "video/mp4", int64(len(mp4Content)), mock.Anything).
+			Return("http://example.com/uploads/original.mp4", nil)
+		repo.On("GetByContentHash", ctx, mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+		repo.On("Create", ctx, mock.AnythingOfType("*models.Media")).Return(nil)
+
+		media, err := service.UploadFile(ctx, bytes.NewReader(mp4Content), header, models.NewID())
+		require.NoError(t, err)
+		assert.Equal(t, models.MediaStatusPending, media.Status)
+		assert.Equal(t, 42.0, media.Duration)
+		assert.Equal(t, 1920, media.Width)
+		assert.True(t, media.IsVideo())
+
+		queued := jobs.byType(JobTypeTranscodeVideo)
+		require.Len(t, queued, 1)
+		var payload VideoJob
+		require.NoError(t, queued[0].DecodePayload(&payload))
+		assert.Equal(t, media.ID, payload.MediaID)
+	})
+
+	t.Run("videos over the duration limit are rejected", func(t *testing.T) {
+		storage := new(MockReadableStorageService)
+		transcoder := new(MockVideoTranscoder)
+		repo := new(MockMediaRepository)
+		service := NewMediaServiceWithVideo(repo, storage, validator, new(MockImageProcessor), transcoder, NewJobQueue(NewMockJobRepository(), JobQueueOptions{}, logger), logger, config)
+
+		repo.On("GetByContentHash", ctx, mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+		transcoder.On("Probe", ctx, mock.AnythingOfType("string")).Return(&VideoInfo{Duration: 5 * time.Minute}, nil)
+
+		_, err := service.UploadFile(ctx, bytes.NewReader(mp4Content), header, models.NewID())
+		assert.ErrorIs(t, err, ErrVideoTooLong)
+		storage.AssertNotCalled(t, "UploadStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("videos over the size limit are rejected", func(t *testing.T) {
+		service := NewMediaServiceWithVideo(new(MockMediaRepository), new(MockReadableStorageService), validator, new(MockImageProcessor), new(MockVideoTranscoder), NewJobQueue(NewMockJobRepository(), JobQueueOptions{}, logger), logger, config)
+
+		_, err := service.UploadFile(ctx, bytes.NewReader(mp4Content), &multipart.FileHeader{Filename: "long.mp4", Size: 200 * 1024 * 1024}, models.NewID())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeds maximum allowed size 104857600")
+	})
+
+	t.Run("videos are turned away without a transcoder", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		service := NewMediaServiceWithJobs(repo, new(MockReadableStorageService), validator, new(MockImageProcessor), NewJobQueue(NewMockJobRepository(), JobQueueOptions{}, logger), logger, config)
+
+		repo.On("GetByContentHash", ctx, mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+		_, err := service.UploadFile(ctx, bytes.NewReader(mp4Content), header, models.NewID())
+		assert.ErrorIs(t, err, ErrVideoUnsupported)
+	})
+
+	newVideo := func() *models.Media {
+		return &models.Media{
+			ID:         models.NewID(),
+			MimeType:   "video/mp4",
+			Duration:   42,
+			StorageKey: "uploads/2026/01/02/abc/original.mp4",
+			Status:     models.MediaStatusPending,
+		}
+	}
+
+	t.Run("job renders the rendition and poster next to the original", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		storage := new(MockReadableStorageService)
+		transcoder := new(MockVideoTranscoder)
+		service := NewMediaServiceWithVideo(repo, storage, validator, new(MockImageProcessor), transcoder, NewJobQueue(NewMockJobRepository(), JobQueueOptions{}, logger), logger, config)
+
+		media := newVideo()
+		var statuses []models.MediaStatus
+		repo.On("GetByID", ctx, media.ID).Return(media, nil)
+		repo.On("Update", ctx, media).Run(func(args mock.Arguments) {
+			statuses = append(statuses, args.Get(1).(*models.Media).Status)
+		}).Return(nil)
+		storage.On("Download", ctx, media.StorageKey).Return(mp4Content, nil)
+		transcoder.On("Transcode", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+		transcoder.On("Poster", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("string"), time.Second).Return(nil)
+		storage.On("UploadStream", ctx, "uploads/2026/01/02/abc/web.mp4", mock.Anything, "video/mp4", int64(len("rendition")), mock.Anything).
+			Return("http://example.com/uploads/2026/01/02/abc/web.mp4", nil)
+		storage.On("UploadStream", ctx, "uploads/2026/01/02/abc/poster.jpg", mock.Anything, "image/jpeg", int64(len("poster")), mock.Anything).
+			Return("http://example.com/uploads/2026/01/02/abc/poster.jpg", nil)
+
+		require.NoError(t, service.TranscodeVideo(ctx, media.ID, false))
+		assert.Equal(t, []models.MediaStatus{models.MediaStatusProcessing, models.MediaStatusReady}, statuses)
+		assert.Equal(t, "http://example.com/uploads/2026/01/02/abc/web.mp4", media.PlaybackURL)
+		assert.Equal(t, map[string]string{"poster": "http://example.com/uploads/2026/01/02/abc/poster.jpg"}, media.Thumbnails)
+	})
+
+	t.Run("job failing on the last attempt marks the video failed", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		storage := new(MockReadableStorageService)
+		transcoder := new(MockVideoTranscoder)
+		service := NewMediaServiceWithVideo(repo, storage, validator, new(MockImageProcessor), transcoder, NewJobQueue(NewMockJobRepository(), JobQueueOptions{}, logger), logger, config)
+
+		media := newVideo()
+		repo.On("GetByID", ctx, media.ID).Return(media, nil)
+		repo.On("Update", ctx, media).Return(nil)
+		storage.On("Download", ctx, media.StorageKey).Return(mp4Content, nil)
+		transcoder.On("Transcode", ctx, mock.Anything, mock.Anything).Return(assert.AnError)
+
+		// Earlier attempts leave the video pending for the retry
+		assert.ErrorIs(t, service.TranscodeVideo(ctx, media.ID, false), assert.AnError)
+		assert.Equal(t, models.MediaStatusPending, media.Status)
+
+		assert.ErrorIs(t, service.TranscodeVideo(ctx, media.ID, true), assert.AnError)
+		assert.Equal(t, models.MediaStatusFailed, media.Status)
+	})
+}
+
+func TestMediaService_PurgeUserMedia(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockMediaRepository)
+	mockStorage := new(MockStorageService)
+	config := DefaultMediaServiceConfig()
+	config.BaseURL = "http://example.com"
+	service := NewMediaService(mockRepo, mockStorage, NewFileValidator([]string{"image/jpeg"}, 5*1024*1024),
+		NewImageProcessor([]ThumbnailSize{}, false), zaptest.NewLogger(t), config)
+
+	userID := models.NewID()
+	deletedAt := time.Now()
+	photo := &models.Media{
+		ID:         models.NewID(),
+		StorageKey: "uploads/2026/01/02/a/original.jpg",
+		Thumbnails: map[string]string{"small": "http://example.com/uploads/2026/01/02/a/small.jpg"},
+		DeletedAt:  &deletedAt,
+	}
+	video := &models.Media{
+		ID:          models.NewID(),
+		StorageKey:  "uploads/2026/01/02/b/original.mp4",
+		PlaybackURL: "http://example.com/uploads/2026/01/02/b/web.mp4",
+	}
+	// A second upload of the photo, sharing its files
+	duplicate := &models.Media{
+		ID:         models.NewID(),
+		StorageKey: photo.StorageKey,
+		Thumbnails: photo.Thumbnails,
+	}
+	mockRepo.On("ListAllByCreatedBy", ctx, userID).Return([]*models.Media{duplicate, photo, video}, nil)
+	mockRepo.On("CountByStorageKey", ctx, photo.StorageKey).Return(int64(2), nil).Once()
+	mockRepo.On("CountByStorageKey", ctx, photo.StorageKey).Return(int64(1), nil).Once()
+	mockRepo.On("CountByStorageKey", ctx, video.StorageKey).Return(int64(1), nil)
+	mockStorage.On("Delete", ctx, mock.AnythingOfType("string")).Return(nil)
+	mockRepo.On("Delete", ctx, mock.AnythingOfType("models.ID")).Return(nil)
+
+	purged, err := service.PurgeUserMedia(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, purged)
+	mockStorage.AssertNumberOfCalls(t, "Delete", 4)
+	for _, key := range []string{
+		"uploads/2026/01/02/a/original.jpg",
+		"uploads/2026/01/02/a/small.jpg",
+		"uploads/2026/01/02/b/original.mp4",
+		"uploads/2026/01/02/b/web.mp4",
+	} {
+		mockStorage.AssertCalled(t, "Delete", ctx, key)
+	}
+	mockRepo.AssertCalled(t, "Delete", ctx, duplicate.ID)
+	mockRepo.AssertCalled(t, "Delete", ctx, photo.ID)
+	mockRepo.AssertCalled(t, "Delete", ctx, video.ID)
+}
+
+func TestMediaService_PurgeUserMediaKeepsRecordWhenStorageFails(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockMediaRepository)
+	mockStorage := new(MockStorageService)
+	service := NewMediaService(mockRepo, mockStorage, NewFileValidator([]string{"image/jpeg"}, 5*1024*1024),
+		NewImageProcessor([]ThumbnailSize{}, false), zaptest.NewLogger(t), DefaultMediaServiceConfig())
+
+	userID := models.NewID()
+	media := &models.Media{ID: models.NewID(), StorageKey: "uploads/a/original.jpg"}
+	mockRepo.On("ListAllByCreatedBy", ctx, userID).Return([]*models.Media{media}, nil)
+	mockRepo.On("CountByStorageKey", ctx, "uploads/a/original.jpg").Return(int64(1), nil)
+	mockStorage.On("Delete", ctx, "uploads/a/original.jpg").Return(fmt.Errorf("access denied"))
+
+	purged, err := service.PurgeUserMedia(ctx, userID)
+	assert.Error(t, err)
+	assert.Zero(t, purged)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
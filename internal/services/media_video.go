@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"mime/multipart"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// posterOffset is where poster frames are taken, past fade-ins of most clips
+const posterOffset = time.Second
+
+// isVideoMimeType reports whether uploads of the MIME type are videos
+func isVideoMimeType(mimeType string) bool {
+	return mimeType == "video/mp4" || mimeType == "video/webm"
+}
+
+// maxFileSize returns the upload size limit of a MIME type
+func (c *MediaServiceConfig) maxFileSize(mimeType string) int64 {
+	if isVideoMimeType(mimeType) {
+		return c.MaxVideoSize
+	}
+	return c.MaxFileSize
+}
+
+// videoSupported reports whether uploaded videos can be transcoded
+func (s *mediaService) videoSupported() bool {
+	return s.transcoder != nil && s.asyncThumbnails()
+}
+
+// uploadVideo stores an uploaded video as is and queues its transcoding; the
+// video is pending until the job has rendered its playback rendition
+func (s *mediaService) uploadVideo(ctx context.Context, file io.Reader, header *multipart.FileHeader, validationResult *ValidationResult, contentHash string, userID models.ID) (*models.Media, error) {
+	if !s.videoSupported() {
+		return nil, ErrVideoUnsupported
+	}
+
+	// The video is probed from a local copy, which is then uploaded
+	tmp, err := os.CreateTemp("", "upload-*."+validationResult.Extension)
+	if err != nil {
+		return nil, fmt.Errorf("failed to buffer video: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to buffer video: %w", err)
+	}
+
+	info, err := s.transcoder.Probe(ctx, tmp.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read video: %w", err)
+	}
+	if s.config.MaxVideoDuration > 0 && info.Duration > s.config.MaxVideoDuration {
+		return nil, fmt.Errorf("%w: %s exceeds the maximum of %s", ErrVideoTooLong, info.Duration.Round(time.Second), s.config.MaxVideoDuration)
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to buffer video: %w", err)
+	}
+
+	mediaID := models.NewID()
+	storageKey := s.generateStorageKey(mediaID, validationResult.Extension)
+	originalURL, err := s.storageService.UploadStream(ctx, storageKey, tmp, validationResult.MimeType, size, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload original file: %w", err)
+	}
+
+	media := &models.Media{
+		ID:          mediaID,
+		Filename:    header.Filename,
+		OriginalURL: originalURL,
+		Size:        size,
+		MimeType:    validationResult.MimeType,
+		Width:       info.Width,
+		Height:      info.Height,
+		Format:      validationResult.Extension,
+		Duration:    info.Duration.Seconds(),
+		StorageKey:  storageKey,
+		ContentHash: contentHash,
+		CreatedBy:   userID,
+		Status:      models.MediaStatusPending,
+	}
+	if err := s.mediaRepo.Create(ctx, media); err != nil {
+		s.cleanupFailedUpload(ctx, storageKey, nil)
+		return nil, fmt.Errorf("failed to create media record: %w", err)
+	}
+
+	if err := s.jobs.Enqueue(ctx, JobTypeTranscodeVideo, VideoJob{MediaID: mediaID}); err != nil {
+		// Without the job the video would stay pending forever
+		s.logger.Error("Failed to queue video transcoding",
+			zap.String("media_id", mediaID.String()),
+			zap.Error(err))
+		media.Status = models.MediaStatusFailed
+		if err := s.mediaRepo.Update(ctx, media); err != nil {
+			s.logger.Error("Failed to mark video failed", zap.String("media_id", mediaID.String()), zap.Error(err))
+		}
+	}
+
+	return media, nil
+}
+
+// TranscodeVideo renders the playback rendition and poster frame of a stored
+// video next to the original, and marks the video ready. A video that cannot
+// be transcoded is marked failed on the last attempt.
+func (s *mediaService) TranscodeVideo(ctx context.Context, mediaID models.ID, lastAttempt bool) error {
+	reader, ok := s.storageService.(ObjectReader)
+	if !ok || s.transcoder == nil {
+		return PermanentJobError(ErrVideoUnsupported)
+	}
+
+	media, err := s.mediaRepo.GetByID(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+	if media.ProcessingStatus() == models.MediaStatusRejected {
+		// Quarantined by the malware scan
+		return nil
+	}
+
+	media.Status = models.MediaStatusProcessing
+	if err := s.mediaRepo.Update(ctx, media); err != nil {
+		return fmt.Errorf("failed to record video processing: %w", err)
+	}
+
+	if err := s.transcode(ctx, reader, media); err != nil {
+		var permanent *permanentJobError
+		if lastAttempt || errors.As(err, &permanent) {
+			media.Status = models.MediaStatusFailed
+		} else {
+			media.Status = models.MediaStatusPending
+		}
+		if updateErr := s.mediaRepo.Update(ctx, media); updateErr != nil {
+			s.logger.Error("Failed to record video status", zap.String("media_id", mediaID.String()), zap.Error(updateErr))
+		}
+		return err
+	}
+
+	media.Status = models.MediaStatusReady
+	if err := s.mediaRepo.Update(ctx, media); err != nil {
+		return fmt.Errorf("failed to record video rendition: %w", err)
+	}
+	return nil
+}
+
+func (s *mediaService) transcode(ctx context.Context, reader ObjectReader, media *models.Media) error {
+	data, err := reader.Download(ctx, media.StorageKey)
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "transcode-*")
+	if err != nil {
+		return fmt.Errorf("failed to create work directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "original"+path.Ext(media.StorageKey))
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		return fmt.Errorf("failed to buffer video: %w", err)
+	}
+
+	rendition := filepath.Join(dir, "web.mp4")
+	if err := s.transcoder.Transcode(ctx, input, rendition); err != nil {
+		return fmt.Errorf("failed to transcode video: %w", err)
+	}
+
+	// Clips shorter than the offset get their middle frame
+	at := posterOffset
+	if duration := time.Duration(media.Duration * float64(time.Second)); duration < 2*at {
+		at = duration / 2
+	}
+	poster := filepath.Join(dir, "poster.jpg")
+	if err := s.transcoder.Poster(ctx, input, poster, at); err != nil {
+		return fmt.Errorf("failed to extract poster frame: %w", err)
+	}
+
+	playbackURL, err := s.uploadFile(ctx, rendition, thumbnailKeyFor(media.StorageKey, "web", "mp4"), "video/mp4")
+	if err != nil {
+		return fmt.Errorf("failed to upload video rendition: %w", err)
+	}
+	posterURL, err := s.uploadFile(ctx, poster, thumbnailKeyFor(media.StorageKey, "poster", "jpg"), "image/jpeg")
+	if err != nil {
+		return fmt.Errorf("failed to upload poster frame: %w", err)
+	}
+
+	media.PlaybackURL = playbackURL
+	media.AddThumbnail("poster", posterURL)
+	return nil
+}
+
+// uploadFile streams a local file to storage
+func (s *mediaService) uploadFile(ctx context.Context, name, key, contentType string) (string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+	return s.storageService.UploadStream(ctx, key, file, contentType, stat.Size(), nil)
+}
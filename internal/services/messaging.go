@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"time"
+)
+
+// Delivery statuses reported by messaging providers
+const (
+	MessageStatusSent      = "sent"
+	MessageStatusDelivered = "delivered"
+	MessageStatusRead      = "read"
+	MessageStatusFailed    = "failed"
+)
+
+// MessageTemplate names a message template approved by the messaging provider
+type MessageTemplate struct {
+	Name     string
+	Language string // e.g. en or id
+}
+
+// TemplateMessage is a message template filled in for one recipient
+type TemplateMessage struct {
+	To       string // Recipient phone number in international format
+	Template MessageTemplate
+	Params   []string // Body parameters, in the order the template numbers them
+}
+
+// MessageStatus is a messaging provider's delivery report of a sent message
+type MessageStatus struct {
+	MessageID string
+	Status    string
+	Reason    string // Why the message failed
+	Timestamp time.Time
+}
+
+// MessagingService sends template messages over a chat channel such as WhatsApp.
+// Business-initiated chats must start with a template the provider approved.
+type MessagingService interface {
+	// SendTemplate sends the message and returns the ID the provider reports its delivery under
+	SendTemplate(ctx context.Context, msg *TemplateMessage) (string, error)
+}
@@ -0,0 +1,422 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+var (
+	ErrMetricsWebhookNotFound = errs.NotFound("metrics webhook not found")
+	ErrInvalidWebhookURL      = errors.New("webhook url must be an absolute http or https url")
+	ErrWebhookURLNotPublic    = errors.New("webhook url must resolve to a public address")
+	ErrNoWebhookWeddings      = errors.New("at least one wedding is required")
+)
+
+const (
+	metricsWebhookEvent         = "metrics.daily"
+	metricsWebhookRetryInterval = time.Hour
+	metricsWebhookBatchSize     = 50
+)
+
+// MetricsWebhookService pushes aggregated wedding metrics to planner CRM endpoints
+type MetricsWebhookService struct {
+	webhookRepo   repository.MetricsWebhookRepository
+	weddingRepo   repository.WeddingRepository
+	rsvpRepo      repository.RSVPRepository
+	guestRepo     repository.GuestRepository
+	analyticsRepo repository.AnalyticsRepository
+	httpClient    *http.Client
+	logger        *zap.Logger
+}
+
+// NewMetricsWebhookService creates a new metrics webhook service
+func NewMetricsWebhookService(
+	webhookRepo repository.MetricsWebhookRepository,
+	weddingRepo repository.WeddingRepository,
+	rsvpRepo repository.RSVPRepository,
+	guestRepo repository.GuestRepository,
+	analyticsRepo repository.AnalyticsRepository,
+	logger *zap.Logger,
+) *MetricsWebhookService {
+	return &MetricsWebhookService{
+		webhookRepo:   webhookRepo,
+		weddingRepo:   weddingRepo,
+		rsvpRepo:      rsvpRepo,
+		guestRepo:     guestRepo,
+		analyticsRepo: analyticsRepo,
+		httpClient:    newWebhookClient(),
+		logger:        logger,
+	}
+}
+
+// CreateMetricsWebhookRequest represents a new metrics webhook subscription
+type CreateMetricsWebhookRequest struct {
+	Name       string   `json:"name" validate:"required,max=100"`
+	URL        string   `json:"url" validate:"required,url"`
+	Secret     string   `json:"secret,omitempty" validate:"omitempty,min=16,max=128"`
+	WeddingIDs []string `json:"wedding_ids" validate:"required,min=1"`
+}
+
+// UpdateMetricsWebhookRequest represents changes to a metrics webhook
+type UpdateMetricsWebhookRequest struct {
+	Name       *string   `json:"name,omitempty" validate:"omitempty,max=100"`
+	URL        *string   `json:"url,omitempty" validate:"omitempty,url"`
+	WeddingIDs *[]string `json:"wedding_ids,omitempty" validate:"omitempty,min=1"`
+	Active     *bool     `json:"active,omitempty"`
+}
+
+// CreateWebhook registers a new metrics webhook and returns the signing secret
+func (s *MetricsWebhookService) CreateWebhook(ctx context.Context, userID models.ID, req CreateMetricsWebhookRequest) (*models.MetricsWebhook, string, error) {
+	if err := validateWebhookURL(ctx, req.URL); err != nil {
+		return nil, "", err
+	}
+
+	weddingIDs, err := s.resolveOwnedWeddings(ctx, userID, req.WeddingIDs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		secret, err = utils.GenerateSecureToken(32)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	webhook := &models.MetricsWebhook{
+		ID:             models.NewID(),
+		UserID:         userID,
+		Name:           req.Name,
+		URL:            req.URL,
+		Secret:         secret,
+		WeddingIDs:     weddingIDs,
+		Schedule:       string(models.MetricsWebhookScheduleDaily),
+		Active:         true,
+		NextDeliveryAt: time.Now().Add(24 * time.Hour),
+	}
+
+	if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, "", fmt.Errorf("failed to create metrics webhook: %w", err)
+	}
+
+	return webhook, secret, nil
+}
+
+// ListWebhooks lists metrics webhooks owned by the user
+func (s *MetricsWebhookService) ListWebhooks(ctx context.Context, userID models.ID) ([]*models.MetricsWebhook, error) {
+	webhooks, err := s.webhookRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list metrics webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// UpdateWebhook applies changes to a metrics webhook owned by the user
+func (s *MetricsWebhookService) UpdateWebhook(ctx context.Context, id, userID models.ID, req UpdateMetricsWebhookRequest) (*models.MetricsWebhook, error) {
+	webhook, err := s.getOwnedWebhook(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		webhook.Name = *req.Name
+	}
+	if req.URL != nil {
+		if err := validateWebhookURL(ctx, *req.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = *req.URL
+	}
+	if req.WeddingIDs != nil {
+		weddingIDs, err := s.resolveOwnedWeddings(ctx, userID, *req.WeddingIDs)
+		if err != nil {
+			return nil, err
+		}
+		webhook.WeddingIDs = weddingIDs
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to update metrics webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook removes a metrics webhook owned by the user
+func (s *MetricsWebhookService) DeleteWebhook(ctx context.Context, id, userID models.ID) error {
+	if _, err := s.getOwnedWebhook(ctx, id, userID); err != nil {
+		return err
+	}
+
+	if err := s.webhookRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete metrics webhook: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries returns the delivery log for a metrics webhook owned by the user
+func (s *MetricsWebhookService) ListDeliveries(ctx context.Context, id, userID models.ID, page, pageSize int) ([]*models.MetricsWebhookDelivery, int64, error) {
+	if _, err := s.getOwnedWebhook(ctx, id, userID); err != nil {
+		return nil, 0, err
+	}
+
+	deliveries, total, err := s.webhookRepo.ListDeliveries(ctx, id, page, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list metrics webhook deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
+
+// DeliverNow sends the metrics payload immediately, e.g. to test an endpoint
+func (s *MetricsWebhookService) DeliverNow(ctx context.Context, id, userID models.ID) (*models.MetricsWebhookDelivery, error) {
+	webhook, err := s.getOwnedWebhook(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.deliver(ctx, webhook), nil
+}
+
+// DeliverDue sends metrics for every webhook whose schedule has elapsed
+func (s *MetricsWebhookService) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	webhooks, err := s.webhookRepo.ListDue(ctx, now, metricsWebhookBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due metrics webhooks: %w", err)
+	}
+
+	delivered := 0
+	for _, webhook := range webhooks {
+		if ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+		if s.deliver(ctx, webhook).Success {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// BuildWeddingMetrics aggregates RSVP, guest, and traffic numbers for a wedding
+func (s *MetricsWebhookService) BuildWeddingMetrics(ctx context.Context, wedding *models.Wedding) (*models.WeddingMetrics, error) {
+	metrics := &models.WeddingMetrics{
+		WeddingID:   wedding.ID,
+		Slug:        wedding.Slug,
+		Title:       wedding.Title,
+		EventDate:   wedding.Event.Date,
+		PageViews:   wedding.ViewCount,
+		GeneratedAt: time.Now(),
+	}
+
+	stats, err := s.rsvpRepo.GetStatistics(ctx, wedding.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get RSVP statistics: %w", err)
+	}
+	metrics.TotalResponses = stats.TotalResponses
+	metrics.Attending = stats.Attending
+	metrics.NotAttending = stats.NotAttending
+	metrics.Maybe = stats.Maybe
+	metrics.TotalAttendees = stats.TotalGuests
+
+	_, totalGuests, err := s.guestRepo.ListByWedding(ctx, wedding.ID, 1, 1, repository.GuestFilters{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count guests: %w", err)
+	}
+	metrics.TotalGuests = totalGuests
+
+	_, pendingGuests, err := s.guestRepo.ListByWedding(ctx, wedding.ID, 1, 1, repository.GuestFilters{RSVPStatus: "pending"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pending guests: %w", err)
+	}
+	metrics.PendingGuests = pendingGuests
+
+	// Prefer tracked page views; fall back to the denormalized view counter
+	if analytics, err := s.analyticsRepo.GetWeddingAnalytics(ctx, wedding.ID); err == nil && analytics != nil {
+		metrics.PageViews = analytics.PageViews
+	}
+
+	return metrics, nil
+}
+
+// deliver builds, signs, and posts the payload, then records the attempt
+func (s *MetricsWebhookService) deliver(ctx context.Context, webhook *models.MetricsWebhook) *models.MetricsWebhookDelivery {
+	start := time.Now()
+	delivery := &models.MetricsWebhookDelivery{
+		ID:          models.NewID(),
+		WebhookID:   webhook.ID,
+		URL:         webhook.URL,
+		AttemptedAt: start,
+	}
+
+	payload := models.MetricsWebhookPayload{
+		Event:     metricsWebhookEvent,
+		WebhookID: webhook.ID.String(),
+		SentAt:    start,
+		Weddings:  make([]models.WeddingMetrics, 0, len(webhook.WeddingIDs)),
+	}
+
+	for _, weddingID := range webhook.WeddingIDs {
+		wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+		if err != nil || wedding.UserID != webhook.UserID {
+			// Weddings deleted or transferred since subscription are skipped
+			continue
+		}
+		metrics, err := s.BuildWeddingMetrics(ctx, wedding)
+		if err != nil {
+			s.logger.Warn("Failed to build wedding metrics",
+				zap.Error(err),
+				zap.String("webhook_id", webhook.ID.String()),
+				zap.String("wedding_id", weddingID.String()))
+			continue
+		}
+		payload.Weddings = append(payload.Weddings, *metrics)
+	}
+	delivery.WeddingCount = len(payload.Weddings)
+
+	statusCode, err := s.post(ctx, webhook, payload)
+	delivery.StatusCode = statusCode
+	delivery.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		delivery.Error = err.Error()
+	} else {
+		delivery.Success = true
+	}
+
+	if err := s.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+		s.logger.Error("Failed to record metrics webhook delivery",
+			zap.Error(err),
+			zap.String("webhook_id", webhook.ID.String()))
+	}
+
+	next := start.Add(webhook.Interval())
+	if !delivery.Success {
+		next = start.Add(metricsWebhookRetryInterval)
+	}
+	if err := s.webhookRepo.MarkDelivered(ctx, webhook.ID, start, next); err != nil {
+		s.logger.Error("Failed to reschedule metrics webhook",
+			zap.Error(err),
+			zap.String("webhook_id", webhook.ID.String()))
+	}
+
+	return delivery
+}
+
+// post sends the signed payload and returns the response status code
+func (s *MetricsWebhookService) post(ctx context.Context, webhook *models.MetricsWebhook, payload models.MetricsWebhookPayload) (int, error) {
+	return postSignedJSON(ctx, s.httpClient, webhook.URL, webhook.Secret, metricsWebhookEvent, payload)
+}
+
+func (s *MetricsWebhookService) getOwnedWebhook(ctx context.Context, id, userID models.ID) (*models.MetricsWebhook, error) {
+	webhook, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrMetricsWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get metrics webhook: %w", err)
+	}
+	if webhook.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	return webhook, nil
+}
+
+func (s *MetricsWebhookService) resolveOwnedWeddings(ctx context.Context, userID models.ID, ids []string) ([]models.ID, error) {
+	if len(ids) == 0 {
+		return nil, ErrNoWebhookWeddings
+	}
+
+	weddingIDs := make([]models.ID, 0, len(ids))
+	for _, rawID := range ids {
+		weddingID, err := models.ParseID(rawID)
+		if err != nil {
+			return nil, ErrWeddingNotFound
+		}
+		wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, ErrWeddingNotFound
+			}
+			return nil, fmt.Errorf("failed to get wedding: %w", err)
+		}
+		if wedding.UserID != userID {
+			return nil, ErrUnauthorized
+		}
+		weddingIDs = append(weddingIDs, weddingID)
+	}
+	return weddingIDs, nil
+}
+
+// validateWebhookURL checks that a webhook URL is an http or https URL of a host
+// resolving only to public addresses
+func validateWebhookURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return ErrInvalidWebhookURL
+	}
+	return checkWebhookHost(ctx, parsed.Hostname())
+}
+
+// MetricsWebhookScheduler periodically delivers due metrics webhooks
+type MetricsWebhookScheduler struct {
+	service  *MetricsWebhookService
+	interval time.Duration
+	logger   *zap.Logger
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewMetricsWebhookScheduler creates a scheduler that checks for due webhooks every interval
+func NewMetricsWebhookScheduler(service *MetricsWebhookService, interval time.Duration, logger *zap.Logger) *MetricsWebhookScheduler {
+	return &MetricsWebhookScheduler{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start runs the scheduler loop in the background
+func (sch *MetricsWebhookScheduler) Start(ctx context.Context) {
+	sch.wg.Add(1)
+	go func() {
+		defer sch.wg.Done()
+		ticker := time.NewTicker(sch.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sch.stop:
+				return
+			case now := <-ticker.C:
+				delivered, err := sch.service.DeliverDue(ctx, now)
+				if err != nil {
+					sch.logger.Error("Metrics webhook run failed", zap.Error(err))
+					continue
+				}
+				if delivered > 0 {
+					sch.logger.Info("Metrics webhooks delivered", zap.Int("count", delivered))
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the scheduler loop to exit and waits for it
+func (sch *MetricsWebhookScheduler) Stop() {
+	close(sch.stop)
+	sch.wg.Wait()
+}
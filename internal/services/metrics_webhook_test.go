@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// MockMetricsWebhookRepository is an in-memory metrics webhook repository
+type MockMetricsWebhookRepository struct {
+	webhooks   map[models.ID]*models.MetricsWebhook
+	deliveries []*models.MetricsWebhookDelivery
+}
+
+func NewMockMetricsWebhookRepository() *MockMetricsWebhookRepository {
+	return &MockMetricsWebhookRepository{
+		webhooks: make(map[models.ID]*models.MetricsWebhook),
+	}
+}
+
+func (m *MockMetricsWebhookRepository) Create(ctx context.Context, webhook *models.MetricsWebhook) error {
+	m.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (m *MockMetricsWebhookRepository) GetByID(ctx context.Context, id models.ID) (*models.MetricsWebhook, error) {
+	webhook, exists := m.webhooks[id]
+	if !exists {
+		return nil, repository.ErrNotFound
+	}
+	return webhook, nil
+}
+
+func (m *MockMetricsWebhookRepository) ListByUser(ctx context.Context, userID models.ID) ([]*models.MetricsWebhook, error) {
+	var webhooks []*models.MetricsWebhook
+	for _, webhook := range m.webhooks {
+		if webhook.UserID == userID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (m *MockMetricsWebhookRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.MetricsWebhook, error) {
+	var webhooks []*models.MetricsWebhook
+	for _, webhook := range m.webhooks {
+		if webhook.IsDue(now) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (m *MockMetricsWebhookRepository) Update(ctx context.Context, webhook *models.MetricsWebhook) error {
+	if _, exists := m.webhooks[webhook.ID]; !exists {
+		return repository.ErrNotFound
+	}
+	m.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (m *MockMetricsWebhookRepository) Delete(ctx context.Context, id models.ID) error {
+	if _, exists := m.webhooks[id]; !exists {
+		return repository.ErrNotFound
+	}
+	delete(m.webhooks, id)
+	return nil
+}
+
+func (m *MockMetricsWebhookRepository) MarkDelivered(ctx context.Context, id models.ID, deliveredAt, nextDeliveryAt time.Time) error {
+	if webhook, exists := m.webhooks[id]; exists {
+		webhook.LastDeliveredAt = &deliveredAt
+		webhook.NextDeliveryAt = nextDeliveryAt
+	}
+	return nil
+}
+
+func (m *MockMetricsWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.MetricsWebhookDelivery) error {
+	m.deliveries = append(m.deliveries, delivery)
+	return nil
+}
+
+func (m *MockMetricsWebhookRepository) ListDeliveries(ctx context.Context, webhookID models.ID, page, pageSize int) ([]*models.MetricsWebhookDelivery, int64, error) {
+	var deliveries []*models.MetricsWebhookDelivery
+	for _, delivery := range m.deliveries {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, int64(len(deliveries)), nil
+}
+
+func setupMetricsWebhookService(t *testing.T) (*MetricsWebhookService, *MockMetricsWebhookRepository, *MockWeddingRepository, *models.Wedding) {
+	webhookRepo := NewMockMetricsWebhookRepository()
+	weddingRepo := &MockWeddingRepository{}
+	rsvpRepo := NewMockRSVPRepository()
+	stubWebhookNetwork(t)
+	guestRepo := NewMockGuestRepository()
+	analyticsRepo := &MockAnalyticsRepository{}
+
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		UserID: models.NewID(),
+		Slug:   "alice-and-bob",
+		Title:  "Alice & Bob",
+	}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+	analyticsRepo.On("GetWeddingAnalytics", mock.Anything, wedding.ID).
+		Return(&models.WeddingAnalytics{WeddingID: wedding.ID, PageViews: 42}, nil)
+
+	guestRepo.guests[models.NewID()] = &models.Guest{WeddingID: wedding.ID, RSVPStatus: "pending"}
+	guestRepo.guests[models.NewID()] = &models.Guest{WeddingID: wedding.ID, RSVPStatus: "attending"}
+	guestRepo.guests[models.NewID()] = &models.Guest{WeddingID: wedding.ID}
+
+	service := NewMetricsWebhookService(webhookRepo, weddingRepo, rsvpRepo, guestRepo, analyticsRepo, zaptest.NewLogger(t))
+	return service, webhookRepo, weddingRepo, wedding
+}
+
+func TestMetricsWebhookService_CreateWebhook(t *testing.T) {
+	service, webhookRepo, _, wedding := setupMetricsWebhookService(t)
+	ctx := context.Background()
+
+	t.Run("Success - generates secret", func(t *testing.T) {
+		webhook, secret, err := service.CreateWebhook(ctx, wedding.UserID, CreateMetricsWebhookRequest{
+			Name:       "Planner CRM",
+			URL:        "https://crm.example.com/hooks/metrics",
+			WeddingIDs: []string{wedding.ID.String()},
+		})
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, secret)
+		assert.Equal(t, secret, webhook.Secret)
+		assert.True(t, webhook.Active)
+		assert.Equal(t, []models.ID{wedding.ID}, webhook.WeddingIDs)
+		assert.True(t, webhook.NextDeliveryAt.After(time.Now()))
+		assert.Contains(t, webhookRepo.webhooks, webhook.ID)
+	})
+
+	t.Run("Error - invalid URL", func(t *testing.T) {
+		_, _, err := service.CreateWebhook(ctx, wedding.UserID, CreateMetricsWebhookRequest{
+			Name:       "Planner CRM",
+			URL:        "ftp://crm.example.com",
+			WeddingIDs: []string{wedding.ID.String()},
+		})
+		assert.ErrorIs(t, err, ErrInvalidWebhookURL)
+	})
+
+	t.Run("Error - internal URL", func(t *testing.T) {
+		_, _, err := service.CreateWebhook(ctx, wedding.UserID, CreateMetricsWebhookRequest{
+			Name:       "Planner CRM",
+			URL:        "https://intranet.example.com/hooks/metrics",
+			WeddingIDs: []string{wedding.ID.String()},
+		})
+		assert.ErrorIs(t, err, ErrWebhookURLNotPublic)
+	})
+
+	t.Run("Error - wedding owned by another user", func(t *testing.T) {
+		_, _, err := service.CreateWebhook(ctx, models.NewID(), CreateMetricsWebhookRequest{
+			Name:       "Planner CRM",
+			URL:        "https://crm.example.com/hooks/metrics",
+			WeddingIDs: []string{wedding.ID.String()},
+		})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+}
+
+func TestMetricsWebhookService_DeliverDue(t *testing.T) {
+	service, webhookRepo, _, wedding := setupMetricsWebhookService(t)
+	ctx := context.Background()
+	secret := "test-secret-value-1234"
+
+	var received models.MetricsWebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		timestamp, err := strconv.ParseInt(r.Header.Get(utils.SignatureTimestampHeader), 10, 64)
+		require.NoError(t, err)
+		if !utils.VerifyPayloadSignature(secret, timestamp, body, r.Header.Get(utils.SignatureHeader)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	webhook := &models.MetricsWebhook{
+		ID:             models.NewID(),
+		UserID:         wedding.UserID,
+		URL:            server.URL,
+		Secret:         secret,
+		WeddingIDs:     []models.ID{wedding.ID},
+		Schedule:       string(models.MetricsWebhookScheduleDaily),
+		Active:         true,
+		NextDeliveryAt: now.Add(-time.Minute),
+	}
+	webhookRepo.webhooks[webhook.ID] = webhook
+
+	delivered, err := service.DeliverDue(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+
+	require.Len(t, received.Weddings, 1)
+	metrics := received.Weddings[0]
+	assert.Equal(t, "metrics.daily", received.Event)
+	assert.Equal(t, wedding.ID, metrics.WeddingID)
+	assert.Equal(t, int64(3), metrics.TotalGuests)
+	assert.Equal(t, int64(2), metrics.PendingGuests, "guests without an RSVP status are pending")
+	assert.Equal(t, int64(42), metrics.PageViews)
+
+	require.Len(t, webhookRepo.deliveries, 1)
+	assert.True(t, webhookRepo.deliveries[0].Success)
+	assert.Equal(t, http.StatusOK, webhookRepo.deliveries[0].StatusCode)
+	assert.NotNil(t, webhook.LastDeliveredAt)
+	assert.True(t, webhook.NextDeliveryAt.After(now.Add(23*time.Hour)))
+}
+
+func TestMetricsWebhookService_DeliverNow_Failure(t *testing.T) {
+	service, webhookRepo, _, wedding := setupMetricsWebhookService(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	webhook := &models.MetricsWebhook{
+		ID:         models.NewID(),
+		UserID:     wedding.UserID,
+		URL:        server.URL,
+		Secret:     "test-secret-value-1234",
+		WeddingIDs: []models.ID{wedding.ID},
+		Active:     true,
+	}
+	webhookRepo.webhooks[webhook.ID] = webhook
+
+	delivery, err := service.DeliverNow(ctx, webhook.ID, wedding.UserID)
+	require.NoError(t, err)
+	assert.False(t, delivery.Success)
+	assert.Equal(t, http.StatusInternalServerError, delivery.StatusCode)
+	assert.NotEmpty(t, delivery.Error)
+
+	// Failed deliveries are retried sooner than the regular schedule
+	assert.True(t, webhook.NextDeliveryAt.Before(time.Now().Add(2*time.Hour)))
+
+	_, err = service.DeliverNow(ctx, webhook.ID, models.NewID())
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	_, err = service.DeliverNow(ctx, models.NewID(), wedding.UserID)
+	assert.ErrorIs(t, err, ErrMetricsWebhookNotFound)
+}
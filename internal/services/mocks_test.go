@@ -7,7 +7,6 @@ import (
 	"wedding-invitation-backend/internal/domain/repository"
 
 	"github.com/stretchr/testify/mock"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // MockWeddingRepository is a mock implementation of WeddingRepository
@@ -20,7 +19,7 @@ func (m *MockWeddingRepository) Create(ctx context.Context, wedding *models.Wedd
 	return args.Error(0)
 }
 
-func (m *MockWeddingRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Wedding, error) {
+func (m *MockWeddingRepository) GetByID(ctx context.Context, id models.ID) (*models.Wedding, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -28,6 +27,14 @@ func (m *MockWeddingRepository) GetByID(ctx context.Context, id primitive.Object
 	return args.Get(0).(*models.Wedding), args.Error(1)
 }
 
+func (m *MockWeddingRepository) GetByIDs(ctx context.Context, ids []models.ID) ([]*models.Wedding, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Wedding), args.Error(1)
+}
+
 func (m *MockWeddingRepository) GetBySlug(ctx context.Context, slug string) (*models.Wedding, error) {
 	args := m.Called(ctx, slug)
 	if args.Get(0) == nil {
@@ -36,7 +43,7 @@ func (m *MockWeddingRepository) GetBySlug(ctx context.Context, slug string) (*mo
 	return args.Get(0).(*models.Wedding), args.Error(1)
 }
 
-func (m *MockWeddingRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error) {
+func (m *MockWeddingRepository) GetByUserID(ctx context.Context, userID models.ID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error) {
 	args := m.Called(ctx, userID, page, pageSize, filters)
 	return args.Get(0).([]*models.Wedding), args.Get(1).(int64), args.Error(2)
 }
@@ -46,7 +53,7 @@ func (m *MockWeddingRepository) Update(ctx context.Context, wedding *models.Wedd
 	return args.Error(0)
 }
 
-func (m *MockWeddingRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockWeddingRepository) Delete(ctx context.Context, id models.ID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
@@ -61,16 +68,31 @@ func (m *MockWeddingRepository) ListPublic(ctx context.Context, page, pageSize i
 	return args.Get(0).([]*models.Wedding), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockWeddingRepository) IncrementViewCount(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockWeddingRepository) List(ctx context.Context, page, pageSize int, filters repository.AdminWeddingFilters) ([]*models.Wedding, int64, error) {
+	args := m.Called(ctx, page, pageSize, filters)
+	return args.Get(0).([]*models.Wedding), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWeddingRepository) IncrementViewCount(ctx context.Context, id models.ID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockWeddingRepository) UpdateRSVPCount(ctx context.Context, weddingID primitive.ObjectID) error {
+func (m *MockWeddingRepository) UpdateRSVPCount(ctx context.Context, weddingID models.ID) error {
 	args := m.Called(ctx, weddingID)
 	return args.Error(0)
 }
 
+func (m *MockWeddingRepository) CountByOwner(ctx context.Context, userID models.ID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWeddingRepository) CountByTheme(ctx context.Context, themeID string) (int64, error) {
+	args := m.Called(ctx, themeID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // MockAnalyticsRepository is a mock implementation of AnalyticsRepository
 type MockAnalyticsRepository struct {
 	mock.Mock
@@ -81,7 +103,17 @@ func (m *MockAnalyticsRepository) TrackPageView(ctx context.Context, pageView *m
 	return args.Error(0)
 }
 
-func (m *MockAnalyticsRepository) GetPageViews(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error) {
+func (m *MockAnalyticsRepository) TrackPageViews(ctx context.Context, pageViews []*models.PageView) error {
+	args := m.Called(ctx, pageViews)
+	return args.Error(0)
+}
+
+func (m *MockAnalyticsRepository) TrackPageDuration(ctx context.Context, weddingID models.ID, sessionID, page string, duration int64) error {
+	args := m.Called(ctx, weddingID, sessionID, page, duration)
+	return args.Error(0)
+}
+
+func (m *MockAnalyticsRepository) GetPageViews(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error) {
 	args := m.Called(ctx, weddingID, filter)
 	return args.Get(0).([]*models.PageView), args.Get(1).(int64), args.Error(2)
 }
@@ -91,7 +123,7 @@ func (m *MockAnalyticsRepository) TrackRSVPEvent(ctx context.Context, event *mod
 	return args.Error(0)
 }
 
-func (m *MockAnalyticsRepository) GetRSVPAnalytics(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error) {
+func (m *MockAnalyticsRepository) GetRSVPAnalytics(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error) {
 	args := m.Called(ctx, weddingID, filter)
 	return args.Get(0).([]*models.RSVPAnalytics), args.Get(1).(int64), args.Error(2)
 }
@@ -101,12 +133,43 @@ func (m *MockAnalyticsRepository) TrackConversion(ctx context.Context, event *mo
 	return args.Error(0)
 }
 
-func (m *MockAnalyticsRepository) GetConversions(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error) {
+func (m *MockAnalyticsRepository) CountHourlyActivity(ctx context.Context, hours []time.Time) ([]models.HourlyActivity, error) {
+	args := m.Called(ctx, hours)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.HourlyActivity), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) GetRSVPFunnelSessions(ctx context.Context, weddingID models.ID, from, to time.Time) ([]models.RSVPFunnelSession, error) {
+	args := m.Called(ctx, weddingID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.RSVPFunnelSession), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) TrackConversions(ctx context.Context, events []*models.ConversionEvent) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (m *MockAnalyticsRepository) GetConversions(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error) {
 	args := m.Called(ctx, weddingID, filter)
 	return args.Get(0).([]*models.ConversionEvent), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockAnalyticsRepository) GetWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) (*models.WeddingAnalytics, error) {
+func (m *MockAnalyticsRepository) GetPageViewsByRequestID(ctx context.Context, requestID string) ([]*models.PageView, error) {
+	args := m.Called(ctx, requestID)
+	return args.Get(0).([]*models.PageView), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) GetConversionsByRequestID(ctx context.Context, requestID string) ([]*models.ConversionEvent, error) {
+	args := m.Called(ctx, requestID)
+	return args.Get(0).([]*models.ConversionEvent), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) GetWeddingAnalytics(ctx context.Context, weddingID models.ID) (*models.WeddingAnalytics, error) {
 	args := m.Called(ctx, weddingID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -114,16 +177,32 @@ func (m *MockAnalyticsRepository) GetWeddingAnalytics(ctx context.Context, weddi
 	return args.Get(0).(*models.WeddingAnalytics), args.Error(1)
 }
 
-func (m *MockAnalyticsRepository) UpdateWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) error {
+func (m *MockAnalyticsRepository) UpdateWeddingAnalytics(ctx context.Context, weddingID models.ID) error {
 	args := m.Called(ctx, weddingID)
 	return args.Error(0)
 }
 
-func (m *MockAnalyticsRepository) RefreshWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) error {
+func (m *MockAnalyticsRepository) RefreshWeddingAnalytics(ctx context.Context, weddingID models.ID) error {
 	args := m.Called(ctx, weddingID)
 	return args.Error(0)
 }
 
+func (m *MockAnalyticsRepository) ListWeddingsToReconcile(ctx context.Context, after models.ID, limit int) ([]models.ID, error) {
+	args := m.Called(ctx, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ID), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) ListWeddingAnalytics(ctx context.Context, weddingIDs []models.ID) ([]*models.WeddingAnalytics, error) {
+	args := m.Called(ctx, weddingIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WeddingAnalytics), args.Error(1)
+}
+
 func (m *MockAnalyticsRepository) GetSystemAnalytics(ctx context.Context) (*models.SystemAnalytics, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -132,6 +211,22 @@ func (m *MockAnalyticsRepository) GetSystemAnalytics(ctx context.Context) (*mode
 	return args.Get(0).(*models.SystemAnalytics), args.Error(1)
 }
 
+func (m *MockAnalyticsRepository) GetAnalyticsBenchmarks(ctx context.Context) (*models.AnalyticsBenchmarks, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AnalyticsBenchmarks), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) UpdateAnalyticsBenchmarks(ctx context.Context, minPageViews int64) (*models.AnalyticsBenchmarks, error) {
+	args := m.Called(ctx, minPageViews)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AnalyticsBenchmarks), args.Error(1)
+}
+
 func (m *MockAnalyticsRepository) UpdateSystemAnalytics(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
@@ -142,7 +237,7 @@ func (m *MockAnalyticsRepository) RefreshSystemAnalytics(ctx context.Context) er
 	return args.Error(0)
 }
 
-func (m *MockAnalyticsRepository) GetAnalyticsSummary(ctx context.Context, weddingID primitive.ObjectID, period string) (*models.AnalyticsSummary, error) {
+func (m *MockAnalyticsRepository) GetAnalyticsSummary(ctx context.Context, weddingID models.ID, period string) (*models.AnalyticsSummary, error) {
 	args := m.Called(ctx, weddingID, period)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -150,21 +245,62 @@ func (m *MockAnalyticsRepository) GetAnalyticsSummary(ctx context.Context, weddi
 	return args.Get(0).(*models.AnalyticsSummary), args.Error(1)
 }
 
-func (m *MockAnalyticsRepository) GetPopularPages(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.PageStats, error) {
+func (m *MockAnalyticsRepository) GetPopularPages(ctx context.Context, weddingID models.ID, limit int) ([]models.PageStats, error) {
 	args := m.Called(ctx, weddingID, limit)
 	return args.Get(0).([]models.PageStats), args.Error(1)
 }
 
-func (m *MockAnalyticsRepository) GetTrafficSources(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.TrafficSourceStats, error) {
+func (m *MockAnalyticsRepository) GetTrafficSources(ctx context.Context, weddingID models.ID, limit int) ([]models.TrafficSourceStats, error) {
 	args := m.Called(ctx, weddingID, limit)
 	return args.Get(0).([]models.TrafficSourceStats), args.Error(1)
 }
 
-func (m *MockAnalyticsRepository) GetDailyMetrics(ctx context.Context, weddingID primitive.ObjectID, startDate, endDate time.Time) ([]models.DailyMetrics, error) {
+func (m *MockAnalyticsRepository) GetGeoBreakdown(ctx context.Context, weddingID models.ID, limit int) ([]models.CountryStats, error) {
+	args := m.Called(ctx, weddingID, limit)
+	return args.Get(0).([]models.CountryStats), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) GetDailyMetrics(ctx context.Context, weddingID models.ID, startDate, endDate time.Time) ([]models.DailyMetrics, error) {
 	args := m.Called(ctx, weddingID, startDate, endDate)
 	return args.Get(0).([]models.DailyMetrics), args.Error(1)
 }
 
+func (m *MockAnalyticsRepository) StreamPageViews(ctx context.Context, weddingID models.ID, from, to time.Time, fn func(*models.PageView) error) error {
+	args := m.Called(ctx, weddingID, from, to, fn)
+	if pageViews, ok := args.Get(0).([]*models.PageView); ok {
+		for _, pageView := range pageViews {
+			if err := fn(pageView); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) StreamRSVPEvents(ctx context.Context, weddingID models.ID, from, to time.Time, fn func(*models.RSVPAnalytics) error) error {
+	args := m.Called(ctx, weddingID, from, to, fn)
+	if events, ok := args.Get(0).([]*models.RSVPAnalytics); ok {
+		for _, event := range events {
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) StreamConversions(ctx context.Context, weddingID models.ID, from, to time.Time, fn func(*models.ConversionEvent) error) error {
+	args := m.Called(ctx, weddingID, from, to, fn)
+	if events, ok := args.Get(0).([]*models.ConversionEvent); ok {
+		for _, event := range events {
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
 func (m *MockAnalyticsRepository) CleanupOldAnalytics(ctx context.Context, olderThan time.Time) error {
 	args := m.Called(ctx, olderThan)
 	return args.Error(0)
@@ -180,7 +316,7 @@ func (m *MockUserRepository) Create(ctx context.Context, user *models.User) erro
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+func (m *MockUserRepository) GetByID(ctx context.Context, id models.ID) (*models.User, error) {
 	args := m.Called(ctx, id)
 	user := args.Get(0)
 	if user == nil {
@@ -221,7 +357,7 @@ func (m *MockUserRepository) Update(ctx context.Context, user *models.User) erro
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockUserRepository) Delete(ctx context.Context, id models.ID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
@@ -231,26 +367,73 @@ func (m *MockUserRepository) List(ctx context.Context, page, pageSize int, filte
 	return args.Get(0).([]*models.User), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockUserRepository) AddWeddingID(ctx context.Context, userID, weddingID primitive.ObjectID) error {
+func (m *MockUserRepository) AddWeddingID(ctx context.Context, userID, weddingID models.ID) error {
 	args := m.Called(ctx, userID, weddingID)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) RemoveWeddingID(ctx context.Context, userID, weddingID primitive.ObjectID) error {
+func (m *MockUserRepository) RemoveWeddingID(ctx context.Context, userID, weddingID models.ID) error {
 	args := m.Called(ctx, userID, weddingID)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) UpdateLastLogin(ctx context.Context, userID primitive.ObjectID) error {
+func (m *MockUserRepository) UpdateLastLogin(ctx context.Context, userID models.ID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetEmailVerified(ctx context.Context, userID models.ID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetByBillingCustomerID(ctx context.Context, customerID string) (*models.User, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByOAuthAccount(ctx context.Context, provider models.AuthProvider, subject string) (*models.User, error) {
+	args := m.Called(ctx, provider, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateSubscription(ctx context.Context, userID models.ID, subscription *models.Subscription) error {
+	args := m.Called(ctx, userID, subscription)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RecordFailedLogin(ctx context.Context, userID models.ID) (*models.User, error) {
 	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) LockLogin(ctx context.Context, userID models.ID, until time.Time, unlockTokenHash string) error {
+	args := m.Called(ctx, userID, until, unlockTokenHash)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) SetEmailVerified(ctx context.Context, userID primitive.ObjectID) error {
+func (m *MockUserRepository) UnlockLogin(ctx context.Context, userID models.ID) error {
 	args := m.Called(ctx, userID)
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) GetByUnlockToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
 // Helper functions for tests
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,403 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+const (
+	// maxSongTitleLength and maxSongArtistLength bound the song of a request
+	maxSongTitleLength  = 200
+	maxSongArtistLength = 200
+	// maxSongRequesterLength bounds the name a guest requests a song with
+	maxSongRequesterLength = 100
+	// maxSongRequesters bounds the names kept with a request; more guests are only counted
+	maxSongRequesters = 20
+	// songRequestExportPageSize is how many requests the DJ's list is read in at a time
+	songRequestExportPageSize = 100
+)
+
+var (
+	ErrSongRequestsDisabled     = errors.New("song requests are disabled for this wedding")
+	ErrInvalidSongRequest       = errors.New("a song request needs a title of at most 200 characters, and an artist of at most 200 and a name of at most 100 characters if given")
+	ErrSongRequestNotFound      = errs.NotFound("song request not found")
+	ErrInvalidSongRequestStatus = errors.New("invalid song request status")
+	// ErrInvalidMusicURL is returned for background music that is not a Spotify or YouTube link
+	ErrInvalidMusicURL = errors.New("music url must be a Spotify or YouTube link to a song, album, playlist or video")
+)
+
+// SongRequestInput describes a song a guest asks the DJ to play
+type SongRequestInput struct {
+	Title  string `json:"title" validate:"required,max=200"`
+	Artist string `json:"artist,omitempty" validate:"omitempty,max=200"`
+	// GuestName is listed with the request when given
+	GuestName string `json:"guest_name,omitempty" validate:"omitempty,max=100"`
+}
+
+// MusicService collects the songs the guests of a published wedding request
+// for its DJ and lets the wedding's editors moderate them. Requests of a song
+// already requested add to its count; the approved ones make the DJ's list,
+// most requested first.
+type MusicService struct {
+	songRepo    repository.SongRequestRepository
+	weddingRepo repository.WeddingRepository
+	filter      *ProfanityFilter
+	features    FeatureChecker
+	logger      *zap.Logger
+}
+
+// NewMusicService creates a new music service
+func NewMusicService(songRepo repository.SongRequestRepository, weddingRepo repository.WeddingRepository, logger *zap.Logger) *MusicService {
+	return &MusicService{
+		songRepo:    songRepo,
+		weddingRepo: weddingRepo,
+		filter:      NewProfanityFilter(defaultProfaneWords...),
+		logger:      logger,
+	}
+}
+
+// SetFeatureFlags turns song requests away from weddings the song requests
+// feature is off for, whatever the wedding's own setting
+func (s *MusicService) SetFeatureFlags(features FeatureChecker) {
+	s.features = features
+}
+
+// RequestSong stores a guest's song request, or counts it on the request of
+// the same song when there is one. A new request waits for approval when the
+// wedding requires it; one collapsed into an earlier request keeps that
+// request's status.
+func (s *MusicService) RequestSong(ctx context.Context, slug string, req SongRequestInput) (*models.SongRequest, error) {
+	wedding, err := s.getPublishedWedding(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !wedding.Music.SongRequests.Enabled || !featureEnabled(ctx, s.features, wedding.ID, FeatureSongRequests) {
+		return nil, ErrSongRequestsDisabled
+	}
+
+	title := strings.Join(strings.Fields(req.Title), " ")
+	artist := strings.Join(strings.Fields(req.Artist), " ")
+	name := strings.TrimSpace(req.GuestName)
+	if title == "" || utf8.RuneCountInString(title) > maxSongTitleLength ||
+		utf8.RuneCountInString(artist) > maxSongArtistLength || utf8.RuneCountInString(name) > maxSongRequesterLength {
+		return nil, ErrInvalidSongRequest
+	}
+	key := songRequestKey(title, artist)
+	if key == "" {
+		return nil, ErrInvalidSongRequest
+	}
+
+	title, titleFiltered := s.filter.Clean(title)
+	artist, artistFiltered := s.filter.Clean(artist)
+	name, nameFiltered := s.filter.Clean(name)
+
+	status := models.SongRequestApproved
+	if wedding.Music.SongRequests.RequireApproval {
+		status = models.SongRequestPending
+	}
+	request := &models.SongRequest{
+		WeddingID: wedding.ID,
+		Title:     title,
+		Artist:    artist,
+		Key:       key,
+		Filtered:  titleFiltered || artistFiltered || nameFiltered,
+		Status:    status,
+	}
+	stored, err := s.songRepo.Request(ctx, request, name, maxSongRequesters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save song request: %w", err)
+	}
+	return stored, nil
+}
+
+// ListPublicSongRequests lists the approved song requests of a published
+// wedding, most requested first
+func (s *MusicService) ListPublicSongRequests(ctx context.Context, slug string, page, pageSize int) ([]*models.SongRequest, int64, error) {
+	wedding, err := s.getPublishedWedding(ctx, slug)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.songRepo.ListByWedding(ctx, wedding.ID, models.SongRequestApproved, page, pageSize)
+}
+
+// ListSongRequests lists the song requests of a wedding for its editors, most
+// requested first, optionally in one status
+func (s *MusicService) ListSongRequests(ctx context.Context, weddingID, userID models.ID, status models.SongRequestStatus, page, pageSize int) ([]*models.SongRequest, int64, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, 0, err
+	}
+	if status != "" && !isSongRequestStatus(status) {
+		return nil, 0, fmt.Errorf("%w: %q", ErrInvalidSongRequestStatus, status)
+	}
+	return s.songRepo.ListByWedding(ctx, weddingID, status, page, pageSize)
+}
+
+// ModerateSongRequest puts a song on the DJ's list or rejects it. Rejected
+// songs can be approved again later, and guests requesting them again do not
+// bring them back.
+func (s *MusicService) ModerateSongRequest(ctx context.Context, weddingID, requestID, userID models.ID, approve bool) (*models.SongRequest, error) {
+	request, err := s.getSongRequest(ctx, weddingID, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := models.SongRequestRejected
+	if approve {
+		status = models.SongRequestApproved
+	}
+	now := time.Now()
+	if err := s.songRepo.SetStatus(ctx, request.ID, status, userID, now); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSongRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to moderate song request: %w", err)
+	}
+	request.Status = status
+	request.ModeratedBy = &userID
+	request.ModeratedAt = &now
+	return request, nil
+}
+
+// DeleteSongRequest removes a song request. Guests requesting the song again
+// start a new request.
+func (s *MusicService) DeleteSongRequest(ctx context.Context, weddingID, requestID, userID models.ID) error {
+	request, err := s.getSongRequest(ctx, weddingID, requestID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.songRepo.Delete(ctx, request.ID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSongRequestNotFound
+		}
+		return fmt.Errorf("failed to delete song request: %w", err)
+	}
+	return nil
+}
+
+// StreamSongRequests passes the DJ's list of a wedding to fn: its approved song
+// requests, most requested first. Nothing is passed when the user may not see it.
+func (s *MusicService) StreamSongRequests(ctx context.Context, weddingID, userID models.ID, fn func(*models.SongRequest) error) error {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return err
+	}
+
+	for page := 1; ; page++ {
+		requests, total, err := s.songRepo.ListByWedding(ctx, weddingID, models.SongRequestApproved, page, songRequestExportPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to list song requests: %w", err)
+		}
+		for _, request := range requests {
+			if err := fn(request); err != nil {
+				return err
+			}
+		}
+		if len(requests) < songRequestExportPageSize || int64(page*songRequestExportPageSize) >= total {
+			return nil
+		}
+	}
+}
+
+// getSongRequest returns a song request of a wedding the user may moderate
+func (s *MusicService) getSongRequest(ctx context.Context, weddingID, requestID, userID models.ID) (*models.SongRequest, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	request, err := s.songRepo.GetByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSongRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to get song request: %w", err)
+	}
+	if request.WeddingID != weddingID {
+		return nil, ErrSongRequestNotFound
+	}
+	return request, nil
+}
+
+// getPublishedWedding returns a wedding guests can see, hiding unpublished ones
+func (s *MusicService) getPublishedWedding(ctx context.Context, slug string) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	// The wedding repository reports a missing wedding as nil
+	if wedding == nil || !wedding.IsAccessible() {
+		return nil, ErrWeddingNotFound
+	}
+	return wedding, nil
+}
+
+// getEditableWedding returns a wedding the user may moderate the song requests of
+func (s *MusicService) getEditableWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	if !wedding.Can(userID, models.PermissionEditWedding) {
+		return nil, ErrUnauthorized
+	}
+	return wedding, nil
+}
+
+func isSongRequestStatus(status models.SongRequestStatus) bool {
+	switch status {
+	case models.SongRequestPending, models.SongRequestApproved, models.SongRequestRejected:
+		return true
+	}
+	return false
+}
+
+// songVersionPattern matches the parts of a song title naming its version or
+// guests, e.g. "(feat. Kygo)" or "[Remastered 2011]"
+var songVersionPattern = regexp.MustCompile(`\([^)]*\)|\[[^\]]*\]`)
+
+// songRequestKey identifies a song however its title and artist were written:
+// case, diacritics, punctuation, a version in brackets and a leading "The" of
+// the artist are left out. It is empty for a title without words.
+func songRequestKey(title, artist string) string {
+	titleWords := models.SearchTokens(songVersionPattern.ReplaceAllString(title, " "))
+	if len(titleWords) == 0 {
+		// A title that is only a version is still a title
+		titleWords = models.SearchTokens(title)
+	}
+	if len(titleWords) == 0 {
+		return ""
+	}
+	artistWords := models.SearchTokens(artist)
+	if len(artistWords) > 1 && artistWords[0] == "the" {
+		artistWords = artistWords[1:]
+	}
+	return strings.Join(titleWords, " ") + "|" + strings.Join(artistWords, " ")
+}
+
+var (
+	// spotifyIDPattern matches the base-62 IDs of Spotify items
+	spotifyIDPattern = regexp.MustCompile(`^[0-9A-Za-z]{22}$`)
+	// youTubeIDPattern matches YouTube video IDs, youTubeListPattern playlist IDs
+	youTubeIDPattern   = regexp.MustCompile(`^[0-9A-Za-z_-]{11}$`)
+	youTubeListPattern = regexp.MustCompile(`^[0-9A-Za-z_-]{2,64}$`)
+)
+
+// spotifyEmbedTypes are the Spotify items that have an embeddable player
+var spotifyEmbedTypes = map[string]bool{
+	"track": true, "album": true, "playlist": true, "artist": true, "episode": true, "show": true,
+}
+
+// normalizeMusicSettings derives the player of a wedding's background music
+// from the link its owner shared, clearing it when there is no link
+func normalizeMusicSettings(music *models.MusicSettings) error {
+	music.URL = strings.TrimSpace(music.URL)
+	if music.URL == "" {
+		music.Provider = ""
+		music.EmbedURL = ""
+		return nil
+	}
+
+	provider, embedURL, err := musicEmbed(music.URL)
+	if err != nil {
+		return err
+	}
+	music.Provider = provider
+	music.EmbedURL = embedURL
+	return nil
+}
+
+// musicEmbed returns the provider and the embeddable player URL of a Spotify
+// or YouTube link or Spotify URI
+func musicEmbed(raw string) (models.MusicProvider, string, error) {
+	if strings.HasPrefix(raw, "spotify:") {
+		parts := strings.Split(raw, ":")
+		if len(parts) != 3 {
+			return "", "", ErrInvalidMusicURL
+		}
+		return spotifyEmbed(parts[1], parts[2])
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", "", ErrInvalidMusicURL
+	}
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+
+	switch strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.") {
+	case "open.spotify.com", "play.spotify.com":
+		// Localized links start with e.g. /intl-id/, and players with /embed/
+		if len(segments) > 0 && strings.HasPrefix(segments[0], "intl-") {
+			segments = segments[1:]
+		}
+		if len(segments) > 0 && segments[0] == "embed" {
+			segments = segments[1:]
+		}
+		if len(segments) != 2 {
+			return "", "", ErrInvalidMusicURL
+		}
+		return spotifyEmbed(segments[0], segments[1])
+
+	case "youtu.be":
+		if len(segments) != 1 {
+			return "", "", ErrInvalidMusicURL
+		}
+		return youTubeEmbed(segments[0], u.Query().Get("list"))
+
+	case "youtube.com", "m.youtube.com", "music.youtube.com", "youtube-nocookie.com":
+		query := u.Query()
+		switch {
+		case len(segments) == 1 && segments[0] == "watch":
+			return youTubeEmbed(query.Get("v"), query.Get("list"))
+		case len(segments) == 1 && segments[0] == "playlist":
+			return youTubeEmbed("", query.Get("list"))
+		case len(segments) == 2 && segments[0] == "embed" && segments[1] == "videoseries":
+			return youTubeEmbed("", query.Get("list"))
+		case len(segments) == 2 && (segments[0] == "embed" || segments[0] == "shorts" || segments[0] == "live"):
+			return youTubeEmbed(segments[1], query.Get("list"))
+		}
+	}
+	return "", "", ErrInvalidMusicURL
+}
+
+func spotifyEmbed(kind, id string) (models.MusicProvider, string, error) {
+	if !spotifyEmbedTypes[kind] || !spotifyIDPattern.MatchString(id) {
+		return "", "", ErrInvalidMusicURL
+	}
+	return models.MusicSpotify, "https://open.spotify.com/embed/" + kind + "/" + id, nil
+}
+
+// youTubeEmbed returns the privacy-enhanced player of a video, a playlist, or
+// a video played as part of a playlist
+func youTubeEmbed(videoID, listID string) (models.MusicProvider, string, error) {
+	if listID != "" && !youTubeListPattern.MatchString(listID) {
+		return "", "", ErrInvalidMusicURL
+	}
+	switch {
+	case videoID == "" && listID != "":
+		return models.MusicYouTube, "https://www.youtube-nocookie.com/embed/videoseries?list=" + listID, nil
+	case !youTubeIDPattern.MatchString(videoID):
+		return "", "", ErrInvalidMusicURL
+	case listID != "":
+		return models.MusicYouTube, "https://www.youtube-nocookie.com/embed/" + videoID + "?list=" + listID, nil
+	}
+	return models.MusicYouTube, "https://www.youtube-nocookie.com/embed/" + videoID, nil
+}
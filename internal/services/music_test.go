@@ -0,0 +1,344 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockSongRequestRepository is an in-memory song request repository
+type MockSongRequestRepository struct {
+	requests map[models.ID]*models.SongRequest
+}
+
+func NewMockSongRequestRepository() *MockSongRequestRepository {
+	return &MockSongRequestRepository{
+		requests: make(map[models.ID]*models.SongRequest),
+	}
+}
+
+func (m *MockSongRequestRepository) Request(ctx context.Context, request *models.SongRequest, requester string, maxRequesters int) (*models.SongRequest, error) {
+	var stored *models.SongRequest
+	for _, existing := range m.requests {
+		if existing.WeddingID == request.WeddingID && existing.Key == request.Key {
+			stored = existing
+		}
+	}
+	if stored == nil {
+		copied := *request
+		copied.ID = models.NewID()
+		copied.CreatedAt = time.Now()
+		stored = &copied
+		m.requests[stored.ID] = stored
+	}
+	stored.Requests++
+	stored.UpdatedAt = time.Now()
+	if requester != "" && len(stored.RequestedBy) < maxRequesters {
+		listed := false
+		for _, name := range stored.RequestedBy {
+			listed = listed || name == requester
+		}
+		if !listed {
+			stored.RequestedBy = append(stored.RequestedBy, requester)
+		}
+	}
+	copied := *stored
+	return &copied, nil
+}
+
+func (m *MockSongRequestRepository) GetByID(ctx context.Context, id models.ID) (*models.SongRequest, error) {
+	request, ok := m.requests[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	copied := *request
+	return &copied, nil
+}
+
+func (m *MockSongRequestRepository) SetStatus(ctx context.Context, id models.ID, status models.SongRequestStatus, moderatedBy models.ID, moderatedAt time.Time) error {
+	request, ok := m.requests[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	request.Status = status
+	request.ModeratedBy = &moderatedBy
+	request.ModeratedAt = &moderatedAt
+	return nil
+}
+
+func (m *MockSongRequestRepository) Delete(ctx context.Context, id models.ID) error {
+	if _, ok := m.requests[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.requests, id)
+	return nil
+}
+
+func (m *MockSongRequestRepository) ListByWedding(ctx context.Context, weddingID models.ID, status models.SongRequestStatus, page, pageSize int) ([]*models.SongRequest, int64, error) {
+	requests := []*models.SongRequest{}
+	for _, request := range m.requests {
+		if request.WeddingID == weddingID && (status == "" || request.Status == status) {
+			copied := *request
+			requests = append(requests, &copied)
+		}
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		if requests[i].Requests != requests[j].Requests {
+			return requests[i].Requests > requests[j].Requests
+		}
+		return requests[i].Title < requests[j].Title
+	})
+
+	total := int64(len(requests))
+	start := (page - 1) * pageSize
+	if start > len(requests) {
+		start = len(requests)
+	}
+	end := start + pageSize
+	if end > len(requests) {
+		end = len(requests)
+	}
+	return requests[start:end], total, nil
+}
+
+func musicTestWedding(ownerID models.ID) *models.Wedding {
+	return &models.Wedding{
+		ID:       models.NewID(),
+		UserID:   ownerID,
+		Slug:     "jane-john",
+		Status:   string(models.WeddingStatusPublished),
+		IsPublic: true,
+		Music:    models.MusicSettings{SongRequests: models.SongRequestSettings{Enabled: true}},
+	}
+}
+
+func TestMusicService_RequestSong(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+
+	setup := func(wedding *models.Wedding) (*MockSongRequestRepository, *MusicService) {
+		songs := NewMockSongRequestRepository()
+		weddings := new(MockWeddingRepository)
+		weddings.On("GetBySlug", ctx, wedding.Slug).Return(wedding, nil)
+		return songs, NewMusicService(songs, weddings, zaptest.NewLogger(t))
+	}
+
+	t.Run("Success - duplicates collapsed", func(t *testing.T) {
+		wedding := musicTestWedding(ownerID)
+		songs, service := setup(wedding)
+
+		first, err := service.RequestSong(ctx, wedding.Slug, SongRequestInput{Title: " Dancing  Queen ", Artist: "ABBA", GuestName: "Alice"})
+		require.NoError(t, err)
+		assert.Equal(t, "Dancing Queen", first.Title)
+		assert.Equal(t, models.SongRequestApproved, first.Status)
+		assert.Equal(t, 1, first.Requests)
+
+		second, err := service.RequestSong(ctx, wedding.Slug, SongRequestInput{Title: "dancing queen (Remastered 2001)", Artist: "Abba", GuestName: "Bob"})
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, second.ID)
+		assert.Equal(t, "Dancing Queen", second.Title, "the first request's spelling is kept")
+		assert.Equal(t, 2, second.Requests)
+		assert.Equal(t, []string{"Alice", "Bob"}, second.RequestedBy)
+
+		third, err := service.RequestSong(ctx, wedding.Slug, SongRequestInput{Title: "Dancing Queen!", Artist: "ABBA"})
+		require.NoError(t, err)
+		assert.Equal(t, 3, third.Requests)
+		assert.Len(t, third.RequestedBy, 2)
+
+		other, err := service.RequestSong(ctx, wedding.Slug, SongRequestInput{Title: "Killing Me Softly", Artist: "The Fugees"})
+		require.NoError(t, err)
+		assert.NotEqual(t, first.ID, other.ID)
+		again, err := service.RequestSong(ctx, wedding.Slug, SongRequestInput{Title: "Killing me softly", Artist: "Fugees"})
+		require.NoError(t, err)
+		assert.Equal(t, other.ID, again.ID)
+		assert.Len(t, songs.requests, 2)
+	})
+
+	t.Run("Success - held for approval with profanity masked", func(t *testing.T) {
+		wedding := musicTestWedding(ownerID)
+		wedding.Music.SongRequests.RequireApproval = true
+		_, service := setup(wedding)
+
+		request, err := service.RequestSong(ctx, wedding.Slug, SongRequestInput{Title: "Bangsat Blues", GuestName: "Alice"})
+		require.NoError(t, err)
+		assert.Equal(t, models.SongRequestPending, request.Status)
+		assert.Equal(t, "******* Blues", request.Title)
+		assert.True(t, request.Filtered)
+	})
+
+	t.Run("Error - song requests disabled", func(t *testing.T) {
+		wedding := musicTestWedding(ownerID)
+		wedding.Music.SongRequests.Enabled = false
+		_, service := setup(wedding)
+
+		_, err := service.RequestSong(ctx, wedding.Slug, SongRequestInput{Title: "Dancing Queen"})
+		assert.ErrorIs(t, err, ErrSongRequestsDisabled)
+	})
+
+	t.Run("Error - song requests feature off", func(t *testing.T) {
+		wedding := musicTestWedding(ownerID)
+		_, service := setup(wedding)
+		service.SetFeatureFlags(staticFeatures{FeatureSongRequests: false})
+
+		_, err := service.RequestSong(ctx, wedding.Slug, SongRequestInput{Title: "Dancing Queen"})
+		assert.ErrorIs(t, err, ErrSongRequestsDisabled)
+	})
+
+	t.Run("Error - blank title", func(t *testing.T) {
+		wedding := musicTestWedding(ownerID)
+		_, service := setup(wedding)
+
+		for _, title := range []string{"   ", "?!"} {
+			_, err := service.RequestSong(ctx, wedding.Slug, SongRequestInput{Title: title, Artist: "ABBA"})
+			assert.ErrorIs(t, err, ErrInvalidSongRequest, title)
+		}
+	})
+
+	t.Run("Error - unpublished wedding", func(t *testing.T) {
+		wedding := musicTestWedding(ownerID)
+		wedding.Status = string(models.WeddingStatusDraft)
+		_, service := setup(wedding)
+
+		_, err := service.RequestSong(ctx, wedding.Slug, SongRequestInput{Title: "Dancing Queen"})
+		assert.ErrorIs(t, err, ErrWeddingNotFound)
+	})
+}
+
+func TestMusicService_Moderation(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+	wedding := musicTestWedding(ownerID)
+	wedding.Music.SongRequests.RequireApproval = true
+	songs := NewMockSongRequestRepository()
+	weddings := new(MockWeddingRepository)
+	weddings.On("GetByID", ctx, wedding.ID).Return(wedding, nil)
+	weddings.On("GetBySlug", ctx, wedding.Slug).Return(wedding, nil)
+	service := NewMusicService(songs, weddings, zaptest.NewLogger(t))
+
+	request, err := service.RequestSong(ctx, wedding.Slug, SongRequestInput{Title: "Dancing Queen", Artist: "ABBA"})
+	require.NoError(t, err)
+
+	public, total, err := service.ListPublicSongRequests(ctx, wedding.Slug, 1, 20)
+	require.NoError(t, err)
+	assert.Empty(t, public)
+	assert.Zero(t, total)
+
+	approved, err := service.ModerateSongRequest(ctx, wedding.ID, request.ID, ownerID, true)
+	require.NoError(t, err)
+	assert.Equal(t, models.SongRequestApproved, approved.Status)
+	assert.Equal(t, ownerID, *approved.ModeratedBy)
+
+	public, _, err = service.ListPublicSongRequests(ctx, wedding.Slug, 1, 20)
+	require.NoError(t, err)
+	assert.Len(t, public, 1)
+
+	rejected, err := service.ModerateSongRequest(ctx, wedding.ID, request.ID, ownerID, false)
+	require.NoError(t, err)
+	assert.Equal(t, models.SongRequestRejected, rejected.Status)
+
+	again, err := service.RequestSong(ctx, wedding.Slug, SongRequestInput{Title: "Dancing Queen", Artist: "ABBA"})
+	require.NoError(t, err)
+	assert.Equal(t, models.SongRequestRejected, again.Status, "requesting a rejected song again does not bring it back")
+
+	listed, _, err := service.ListSongRequests(ctx, wedding.ID, ownerID, models.SongRequestRejected, 1, 20)
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+
+	_, _, err = service.ListSongRequests(ctx, wedding.ID, ownerID, "maybe", 1, 20)
+	assert.ErrorIs(t, err, ErrInvalidSongRequestStatus)
+
+	_, err = service.ModerateSongRequest(ctx, wedding.ID, request.ID, models.NewID(), true)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	other := &models.SongRequest{WeddingID: models.NewID(), Key: "other|", Status: models.SongRequestApproved}
+	other, err = songs.Request(ctx, other, "", maxSongRequesters)
+	require.NoError(t, err)
+	assert.ErrorIs(t, service.DeleteSongRequest(ctx, wedding.ID, other.ID, ownerID), ErrSongRequestNotFound)
+
+	require.NoError(t, service.DeleteSongRequest(ctx, wedding.ID, request.ID, ownerID))
+	assert.NotContains(t, songs.requests, request.ID)
+}
+
+func TestMusicService_StreamSongRequests(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+	wedding := musicTestWedding(ownerID)
+	songs := NewMockSongRequestRepository()
+	weddings := new(MockWeddingRepository)
+	weddings.On("GetByID", ctx, wedding.ID).Return(wedding, nil)
+	service := NewMusicService(songs, weddings, zaptest.NewLogger(t))
+
+	for i := 0; i < songRequestExportPageSize+20; i++ {
+		_, err := songs.Request(ctx, &models.SongRequest{WeddingID: wedding.ID, Title: fmt.Sprintf("Song %03d", i), Key: fmt.Sprintf("song %03d|", i), Status: models.SongRequestApproved}, "", maxSongRequesters)
+		require.NoError(t, err)
+	}
+	popular, err := songs.Request(ctx, &models.SongRequest{WeddingID: wedding.ID, Key: "song 050|"}, "", maxSongRequesters)
+	require.NoError(t, err)
+	_, err = songs.Request(ctx, &models.SongRequest{WeddingID: wedding.ID, Title: "Pending", Key: "pending|", Status: models.SongRequestPending}, "", maxSongRequesters)
+	require.NoError(t, err)
+
+	var streamed []*models.SongRequest
+	require.NoError(t, service.StreamSongRequests(ctx, wedding.ID, ownerID, func(request *models.SongRequest) error {
+		streamed = append(streamed, request)
+		return nil
+	}))
+	require.Len(t, streamed, songRequestExportPageSize+20, "every approved request, over several pages")
+	assert.Equal(t, popular.ID, streamed[0].ID, "most requested first")
+
+	err = service.StreamSongRequests(ctx, wedding.ID, models.NewID(), func(*models.SongRequest) error {
+		t.Fatal("nothing is streamed to other users")
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestNormalizeMusicSettings(t *testing.T) {
+	tests := []struct {
+		url      string
+		provider models.MusicProvider
+		embedURL string
+	}{
+		{"https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC?si=abc", models.MusicSpotify, "https://open.spotify.com/embed/track/4uLU6hMCjMI75M1A2tKUQC"},
+		{"https://open.spotify.com/intl-id/playlist/37i9dQZF1DXcBWIGoYBM5M", models.MusicSpotify, "https://open.spotify.com/embed/playlist/37i9dQZF1DXcBWIGoYBM5M"},
+		{"https://open.spotify.com/embed/album/1DFixLWuPkv3KT3TnV35m3", models.MusicSpotify, "https://open.spotify.com/embed/album/1DFixLWuPkv3KT3TnV35m3"},
+		{"spotify:track:4uLU6hMCjMI75M1A2tKUQC", models.MusicSpotify, "https://open.spotify.com/embed/track/4uLU6hMCjMI75M1A2tKUQC"},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42", models.MusicYouTube, "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"},
+		{"https://youtu.be/dQw4w9WgXcQ?si=xyz", models.MusicYouTube, "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"},
+		{"https://music.youtube.com/watch?v=dQw4w9WgXcQ&list=PLFgquLnL59alCl_2TQvOiD5Vgm1hCaGSI", models.MusicYouTube, "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ?list=PLFgquLnL59alCl_2TQvOiD5Vgm1hCaGSI"},
+		{"https://www.youtube.com/playlist?list=PLFgquLnL59alCl_2TQvOiD5Vgm1hCaGSI", models.MusicYouTube, "https://www.youtube-nocookie.com/embed/videoseries?list=PLFgquLnL59alCl_2TQvOiD5Vgm1hCaGSI"},
+		{"https://youtube.com/shorts/dQw4w9WgXcQ", models.MusicYouTube, "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"},
+	}
+	for _, tt := range tests {
+		music := models.MusicSettings{URL: tt.url}
+		require.NoError(t, normalizeMusicSettings(&music), tt.url)
+		assert.Equal(t, tt.provider, music.Provider, tt.url)
+		assert.Equal(t, tt.embedURL, music.EmbedURL, tt.url)
+	}
+
+	for _, url := range []string{
+		"https://soundcloud.com/artist/song",
+		"https://open.spotify.com/user/someone",
+		"https://open.spotify.com/track/short",
+		"https://www.youtube.com/watch?v=nope",
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ&list=<script>",
+		"javascript:alert(1)",
+		"spotify:track",
+	} {
+		music := models.MusicSettings{URL: url}
+		assert.ErrorIs(t, normalizeMusicSettings(&music), ErrInvalidMusicURL, url)
+	}
+
+	music := models.MusicSettings{Provider: models.MusicSpotify, EmbedURL: "https://open.spotify.com/embed/track/x"}
+	require.NoError(t, normalizeMusicSettings(&music))
+	assert.Empty(t, music.Provider, "removing the link removes the player")
+	assert.Empty(t, music.EmbedURL)
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// Endpoints of the social login providers
+const (
+	googleTokenEndpoint    = "https://oauth2.googleapis.com/token"
+	googleUserInfoEndpoint = "https://openidconnect.googleapis.com/v1/userinfo"
+	appleTokenEndpoint     = "https://appleid.apple.com/auth/token"
+	// appleIssuer is the issuer of Apple ID tokens and the audience of client secrets
+	appleIssuer = "https://appleid.apple.com"
+	// appleClientSecretTTL is how long a signed Apple client secret is valid; Apple allows up to six months
+	appleClientSecretTTL = 5 * time.Minute
+)
+
+// ErrOAuthExchangeFailed is returned when a provider rejects an authorization code
+var ErrOAuthExchangeFailed = errors.New("failed to sign in with the provider")
+
+// OAuthIdentity is the account a social login provider vouches for
+type OAuthIdentity struct {
+	Provider      models.AuthProvider
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+	PictureURL    string
+}
+
+// OAuthProvider exchanges an authorization code from the provider's consent
+// screen for the identity of the signed-in account
+type OAuthProvider interface {
+	Name() models.AuthProvider
+	// Exchange redeems the code; redirectURI must be the one the code was requested with
+	Exchange(ctx context.Context, code, redirectURI string) (*OAuthIdentity, error)
+}
+
+var oauthHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// postOAuthForm posts a token request and decodes the JSON answer into out
+func postOAuthForm(ctx context.Context, client *http.Client, endpoint string, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	return doOAuthRequest(client, req, out)
+}
+
+func doOAuthRequest(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Rejected codes are the caller's fault, not an outage
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%w: %s returned status %d: %s", ErrOAuthExchangeFailed, req.URL.Host, resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", req.URL.Host, err)
+	}
+	return nil
+}
+
+// GoogleOAuthProvider signs users in with their Google account
+type GoogleOAuthProvider struct {
+	clientID         string
+	clientSecret     string
+	tokenEndpoint    string
+	userInfoEndpoint string
+	httpClient       *http.Client
+}
+
+// NewGoogleOAuthProvider creates a Google provider for the OAuth client
+func NewGoogleOAuthProvider(clientID, clientSecret string) *GoogleOAuthProvider {
+	return &GoogleOAuthProvider{
+		clientID:         clientID,
+		clientSecret:     clientSecret,
+		tokenEndpoint:    googleTokenEndpoint,
+		userInfoEndpoint: googleUserInfoEndpoint,
+		httpClient:       oauthHTTPClient,
+	}
+}
+
+// Name returns google
+func (p *GoogleOAuthProvider) Name() models.AuthProvider {
+	return models.AuthProviderGoogle
+}
+
+type googleUserInfo struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+	Picture       string `json:"picture"`
+}
+
+// Exchange redeems the code for an access token and reads the account's profile with it
+func (p *GoogleOAuthProvider) Exchange(ctx context.Context, code, redirectURI string) (*OAuthIdentity, error) {
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := postOAuthForm(ctx, p.httpClient, p.tokenEndpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {p.clientID},
+		"client_secret": {p.clientSecret},
+	}, &token); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.userInfoEndpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build userinfo request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	var info googleUserInfo
+	if err := doOAuthRequest(p.httpClient, req, &info); err != nil {
+		return nil, err
+	}
+	if info.Subject == "" {
+		return nil, fmt.Errorf("%w: Google returned no account ID", ErrOAuthExchangeFailed)
+	}
+
+	return &OAuthIdentity{
+		Provider:      models.AuthProviderGoogle,
+		Subject:       info.Subject,
+		Email:         info.Email,
+		EmailVerified: info.EmailVerified,
+		FirstName:     info.GivenName,
+		LastName:      info.FamilyName,
+		PictureURL:    info.Picture,
+	}, nil
+}
+
+// AppleOAuthProvider signs users in with their Apple ID. Apple authenticates
+// clients with a short-lived JWT signed by a Sign in with Apple key.
+type AppleOAuthProvider struct {
+	clientID      string
+	teamID        string
+	keyID         string
+	key           *ecdsa.PrivateKey
+	tokenEndpoint string
+	httpClient    *http.Client
+	now           func() time.Time
+}
+
+// NewAppleOAuthProvider creates an Apple provider for the Services ID clientID,
+// signing client secrets with the PEM encoded key of the team
+func NewAppleOAuthProvider(clientID, teamID, keyID, privateKeyPEM string) (*AppleOAuthProvider, error) {
+	key, err := jwt.ParseECPrivateKeyFromPEM([]byte(privateKeyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Apple private key: %w", err)
+	}
+	return &AppleOAuthProvider{
+		clientID:      clientID,
+		teamID:        teamID,
+		keyID:         keyID,
+		key:           key,
+		tokenEndpoint: appleTokenEndpoint,
+		httpClient:    oauthHTTPClient,
+		now:           time.Now,
+	}, nil
+}
+
+// Name returns apple
+func (p *AppleOAuthProvider) Name() models.AuthProvider {
+	return models.AuthProviderApple
+}
+
+// appleIDTokenClaims are the claims of an Apple ID token. Apple sends
+// email_verified as a string in some tokens and as a boolean in others.
+type appleIDTokenClaims struct {
+	Email         string      `json:"email"`
+	EmailVerified interface{} `json:"email_verified"`
+	jwt.RegisteredClaims
+}
+
+// Exchange redeems the code for an ID token. Apple only shares the user's
+// name with the app once, outside of the token, so it is left empty.
+func (p *AppleOAuthProvider) Exchange(ctx context.Context, code, redirectURI string) (*OAuthIdentity, error) {
+	secret, err := p.clientSecret()
+	if err != nil {
+		return nil, err
+	}
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := postOAuthForm(ctx, p.httpClient, p.tokenEndpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {p.clientID},
+		"client_secret": {secret},
+	}, &token); err != nil {
+		return nil, err
+	}
+
+	// The token came straight from Apple over TLS, so its signature need not be
+	// checked (OpenID Connect Core 3.1.3.7); who it was issued to still is
+	var claims appleIDTokenClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token.IDToken, &claims); err != nil {
+		return nil, fmt.Errorf("%w: invalid Apple ID token: %v", ErrOAuthExchangeFailed, err)
+	}
+	if claims.Issuer != appleIssuer || !audienceContains(claims.Audience, p.clientID) || claims.Subject == "" {
+		return nil, fmt.Errorf("%w: Apple ID token was not issued to this app", ErrOAuthExchangeFailed)
+	}
+
+	verified := false
+	switch v := claims.EmailVerified.(type) {
+	case bool:
+		verified = v
+	case string:
+		verified = v == "true"
+	}
+	return &OAuthIdentity{
+		Provider:      models.AuthProviderApple,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: verified,
+	}, nil
+}
+
+// clientSecret signs the JWT Apple accepts as the client secret
+func (p *AppleOAuthProvider) clientSecret() (string, error) {
+	now := p.now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.RegisteredClaims{
+		Issuer:    p.teamID,
+		Subject:   p.clientID,
+		Audience:  jwt.ClaimStrings{appleIssuer},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(appleClientSecretTTL)),
+	})
+	token.Header["kid"] = p.keyID
+	secret, err := token.SignedString(p.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign Apple client secret: %w", err)
+	}
+	return secret, nil
+}
+
+func audienceContains(audience jwt.ClaimStrings, clientID string) bool {
+	for _, aud := range audience {
+		if aud == clientID {
+			return true
+		}
+	}
+	return false
+}
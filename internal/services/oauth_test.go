@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/utils"
+)
+
+func TestGoogleOAuthProvider_Exchange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			require.NoError(t, r.ParseForm())
+			if r.PostForm.Get("code") != "good-code" || r.PostForm.Get("client_secret") != "secret" ||
+				r.PostForm.Get("redirect_uri") != "https://app.example.com/callback" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"access_token": "google-access"})
+		case "/userinfo":
+			assert.Equal(t, "Bearer google-access", r.Header.Get("Authorization"))
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"sub":            "1234567890",
+				"email":          "dana@example.com",
+				"email_verified": true,
+				"given_name":     "Dana",
+				"family_name":    "Lee",
+			})
+		}
+	}))
+	defer server.Close()
+
+	provider := NewGoogleOAuthProvider("client", "secret")
+	provider.tokenEndpoint = server.URL + "/token"
+	provider.userInfoEndpoint = server.URL + "/userinfo"
+
+	identity, err := provider.Exchange(context.Background(), "good-code", "https://app.example.com/callback")
+	require.NoError(t, err)
+	assert.Equal(t, &OAuthIdentity{
+		Provider:      models.AuthProviderGoogle,
+		Subject:       "1234567890",
+		Email:         "dana@example.com",
+		EmailVerified: true,
+		FirstName:     "Dana",
+		LastName:      "Lee",
+	}, identity)
+
+	_, err = provider.Exchange(context.Background(), "bad-code", "https://app.example.com/callback")
+	assert.ErrorIs(t, err, ErrOAuthExchangeFailed)
+}
+
+func TestAppleOAuthProvider_Exchange(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	// Apple signs ID tokens with its own keys, which are not checked
+	appleKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	idToken := func(audience string) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+			"iss":            appleIssuer,
+			"aud":            audience,
+			"sub":            "001234.abcdef",
+			"email":          "dana@privaterelay.appleid.com",
+			"email_verified": "true",
+		}).SignedString(appleKey)
+		require.NoError(t, err)
+		return token
+	}
+
+	audience := "com.example.wedding"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		// The client secret is a JWT signed with the team's key
+		secret, err := jwt.ParseWithClaims(r.PostForm.Get("client_secret"), &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
+			assert.Equal(t, "KEY123", token.Header["kid"])
+			return &key.PublicKey, nil
+		}, jwt.WithAudience(appleIssuer), jwt.WithIssuer("TEAM123"))
+		require.NoError(t, err)
+		sub, _ := secret.Claims.GetSubject()
+		assert.Equal(t, "com.example.wedding", sub)
+
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idToken(audience)})
+	}))
+	defer server.Close()
+
+	provider, err := NewAppleOAuthProvider("com.example.wedding", "TEAM123", "KEY123", keyPEM)
+	require.NoError(t, err)
+	provider.tokenEndpoint = server.URL
+
+	identity, err := provider.Exchange(context.Background(), "code", "https://app.example.com/callback")
+	require.NoError(t, err)
+	assert.Equal(t, models.AuthProviderApple, identity.Provider)
+	assert.Equal(t, "001234.abcdef", identity.Subject)
+	assert.Equal(t, "dana@privaterelay.appleid.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+
+	// Tokens issued to another app are refused
+	audience = "com.example.other"
+	_, err = provider.Exchange(context.Background(), "code", "https://app.example.com/callback")
+	assert.ErrorIs(t, err, ErrOAuthExchangeFailed)
+
+	_, err = NewAppleOAuthProvider("com.example.wedding", "TEAM123", "KEY123", "not a key")
+	assert.Error(t, err)
+}
+
+// stubOAuthProvider vouches for a fixed identity
+type stubOAuthProvider struct {
+	identity *OAuthIdentity
+}
+
+func (p *stubOAuthProvider) Name() models.AuthProvider {
+	return models.AuthProviderGoogle
+}
+
+func (p *stubOAuthProvider) Exchange(ctx context.Context, code, redirectURI string) (*OAuthIdentity, error) {
+	if code != "good-code" {
+		return nil, ErrOAuthExchangeFailed
+	}
+	return p.identity, nil
+}
+
+func TestAuthService_OAuthLogin(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := utils.NewJWTManager("access-secret", "refresh-secret", time.Minute, time.Hour, "test")
+	req := OAuthLoginRequest{Code: "good-code", RedirectURI: "https://app.example.com/callback"}
+	identity := func() *OAuthIdentity {
+		return &OAuthIdentity{
+			Provider:      models.AuthProviderGoogle,
+			Subject:       "1234567890",
+			Email:         "dana@example.com",
+			EmailVerified: true,
+			FirstName:     "Dana",
+			LastName:      "Lee",
+		}
+	}
+
+	t.Run("Success - first sign-in creates the account", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		userRepo.On("GetByOAuthAccount", mock.Anything, models.AuthProviderGoogle, "1234567890").Return(nil, nil)
+		userRepo.On("GetByEmail", mock.Anything, "dana@example.com").Return(nil, nil)
+		userRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *models.User) bool {
+			return u.Provider == models.AuthProviderGoogle && u.EmailVerified &&
+				u.Status == models.UserStatusActive && u.PasswordHash == "" &&
+				u.FirstName == "Dana" && u.OAuthAccountFor(models.AuthProviderGoogle).Subject == "1234567890"
+		})).Return(nil)
+		userRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		auth := NewAuthService(userRepo, jwtManager, AuthDependencies{OAuthProviders: []OAuthProvider{&stubOAuthProvider{identity: identity()}}})
+		resp, err := auth.OAuthLogin(ctx, models.AuthProviderGoogle, req)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.AccessToken)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - links the account with the same email", func(t *testing.T) {
+		existing := &models.User{ID: models.NewID(), Email: "dana@example.com", Status: models.UserStatusUnverified, Role: "user"}
+		userRepo := &MockUserRepository{}
+		userRepo.On("GetByOAuthAccount", mock.Anything, models.AuthProviderGoogle, "1234567890").Return(nil, nil)
+		userRepo.On("GetByEmail", mock.Anything, "dana@example.com").Return(existing, nil)
+		userRepo.On("Update", mock.Anything, existing).Return(nil)
+
+		auth := NewAuthService(userRepo, jwtManager, AuthDependencies{OAuthProviders: []OAuthProvider{&stubOAuthProvider{identity: identity()}}})
+		resp, err := auth.OAuthLogin(ctx, models.AuthProviderGoogle, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, existing.ID, resp.User.ID)
+		assert.NotNil(t, existing.OAuthAccountFor(models.AuthProviderGoogle))
+		assert.True(t, existing.EmailVerified)
+		assert.Equal(t, models.UserStatusActive, existing.Status)
+	})
+
+	t.Run("Success - linked accounts are asked for their second factor", func(t *testing.T) {
+		linked := &models.User{
+			ID:            models.NewID(),
+			Status:        models.UserStatusActive,
+			TwoFactor:     &models.TwoFactorAuth{Enabled: true},
+			OAuthAccounts: []models.OAuthAccount{{Provider: models.AuthProviderGoogle, Subject: "1234567890"}},
+		}
+		userRepo := &MockUserRepository{}
+		userRepo.On("GetByOAuthAccount", mock.Anything, models.AuthProviderGoogle, "1234567890").Return(linked, nil)
+
+		twoFactor := NewTwoFactorService(userRepo, NewMemoryPendingLoginStore(), "", nil)
+		auth := NewAuthService(userRepo, jwtManager, AuthDependencies{TwoFactor: twoFactor, OAuthProviders: []OAuthProvider{&stubOAuthProvider{identity: identity()}}})
+		resp, err := auth.OAuthLogin(ctx, models.AuthProviderGoogle, req)
+
+		require.NoError(t, err)
+		assert.True(t, resp.TwoFactorRequired)
+		assert.Empty(t, resp.AccessToken)
+	})
+
+	t.Run("Error - unverified emails can't claim an account", func(t *testing.T) {
+		unverified := identity()
+		unverified.EmailVerified = false
+		userRepo := &MockUserRepository{}
+		userRepo.On("GetByOAuthAccount", mock.Anything, models.AuthProviderGoogle, "1234567890").Return(nil, nil)
+
+		auth := NewAuthService(userRepo, jwtManager, AuthDependencies{OAuthProviders: []OAuthProvider{&stubOAuthProvider{identity: unverified}}})
+		_, err := auth.OAuthLogin(ctx, models.AuthProviderGoogle, req)
+
+		assert.ErrorIs(t, err, ErrOAuthEmailNotVerified)
+		userRepo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - email linked to another account at the provider", func(t *testing.T) {
+		existing := &models.User{
+			ID:            models.NewID(),
+			Email:         "dana@example.com",
+			OAuthAccounts: []models.OAuthAccount{{Provider: models.AuthProviderGoogle, Subject: "other"}},
+		}
+		userRepo := &MockUserRepository{}
+		userRepo.On("GetByOAuthAccount", mock.Anything, models.AuthProviderGoogle, "1234567890").Return(nil, nil)
+		userRepo.On("GetByEmail", mock.Anything, "dana@example.com").Return(existing, nil)
+
+		auth := NewAuthService(userRepo, jwtManager, AuthDependencies{OAuthProviders: []OAuthProvider{&stubOAuthProvider{identity: identity()}}})
+		_, err := auth.OAuthLogin(ctx, models.AuthProviderGoogle, req)
+
+		assert.ErrorIs(t, err, ErrEmailAlreadyExists)
+	})
+
+	t.Run("Error - suspended account", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		userRepo.On("GetByOAuthAccount", mock.Anything, models.AuthProviderGoogle, "1234567890").
+			Return(&models.User{ID: models.NewID(), Status: models.UserStatusSuspended}, nil)
+
+		auth := NewAuthService(userRepo, jwtManager, AuthDependencies{OAuthProviders: []OAuthProvider{&stubOAuthProvider{identity: identity()}}})
+		_, err := auth.OAuthLogin(ctx, models.AuthProviderGoogle, req)
+
+		assert.ErrorIs(t, err, ErrAccountDisabled)
+	})
+
+	t.Run("Error - provider not configured", func(t *testing.T) {
+		auth := NewAuthService(&MockUserRepository{}, jwtManager, AuthDependencies{OAuthProviders: []OAuthProvider{&stubOAuthProvider{identity: identity()}}})
+		_, err := auth.OAuthLogin(ctx, models.AuthProviderApple, req)
+		assert.ErrorIs(t, err, ErrOAuthProviderNotSupported)
+
+		_, err = auth.OAuthLogin(ctx, models.AuthProviderGoogle, OAuthLoginRequest{Code: "bad-code"})
+		assert.ErrorIs(t, err, ErrOAuthExchangeFailed)
+	})
+}
@@ -0,0 +1,341 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// Size of Open Graph images, the 1.91:1 ratio link previews are shown in
+const (
+	OpenGraphImageWidth  = 1200
+	OpenGraphImageHeight = 630
+)
+
+const (
+	// openGraphLayoutVersion changes the cache keys of all images when their layout changes
+	openGraphLayoutVersion = 1
+	// defaultOpenGraphCacheTTL is how long images are cached unless configured
+	defaultOpenGraphCacheTTL = 30 * 24 * time.Hour
+)
+
+// OpenGraphOptions configures the link previews of public weddings
+type OpenGraphOptions struct {
+	// SiteURL is the frontend the shared links point to
+	SiteURL string
+	// APIURL is the public base URL of the API routes, which serve the images
+	APIURL string
+	// CacheTTL is how long rendered images are cached
+	CacheTTL time.Duration
+}
+
+// OpenGraphMeta is what link previews of a wedding show
+type OpenGraphMeta struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	Image       string `json:"image"`
+	ImageWidth  int    `json:"image_width"`
+	ImageHeight int    `json:"image_height"`
+	Language    string `json:"language"`
+}
+
+// OpenGraphImage is a rendered link preview image
+type OpenGraphImage struct {
+	Data []byte
+	// ETag identifies the image, which changes with the content it shows
+	ETag string
+}
+
+// openGraphCard is the content of a wedding's preview image
+type openGraphCard struct {
+	heading string
+	names   string
+	date    string
+	venue   string
+	style   themeStyle
+}
+
+// OpenGraphService generates the link previews of public weddings: the meta
+// tags and an image of the couple's names, date and venue in the wedding's
+// theme colors. Images are cached by their content, so a wedding's image is
+// rendered again once its content changes.
+type OpenGraphService struct {
+	cache  ImageCache
+	opts   OpenGraphOptions
+	logger *zap.Logger
+}
+
+// NewOpenGraphService creates a new Open Graph service
+func NewOpenGraphService(cache ImageCache, opts OpenGraphOptions, logger *zap.Logger) *OpenGraphService {
+	if opts.SiteURL == "" {
+		opts.SiteURL = DefaultInvitationOptions().SiteURL
+	}
+	if opts.CacheTTL <= 0 {
+		opts.CacheTTL = defaultOpenGraphCacheTTL
+	}
+	opts.SiteURL = strings.TrimRight(opts.SiteURL, "/")
+	opts.APIURL = strings.TrimRight(opts.APIURL, "/")
+	return &OpenGraphService{cache: cache, opts: opts, logger: logger}
+}
+
+// Meta returns the link preview of a wedding. The image URL carries the
+// image's version, so that sites which cache previews fetch it again after
+// the content changes.
+func (s *OpenGraphService) Meta(wedding *models.Wedding) *OpenGraphMeta {
+	card := newOpenGraphCard(wedding)
+
+	title := wedding.Title
+	if title == "" {
+		title = card.names
+	}
+	description := wedding.ShareMessage
+	if description == "" {
+		description = card.names + " invite you to celebrate their wedding"
+		if card.date != "" {
+			description += " on " + card.date
+		}
+		description += "."
+	}
+
+	return &OpenGraphMeta{
+		Title:       title,
+		Description: description,
+		URL:         fmt.Sprintf("%s/w/%s", s.opts.SiteURL, url.PathEscape(wedding.Slug)),
+		Image: fmt.Sprintf("%s/public/weddings/slug/%s/og-image.png?v=%s",
+			s.opts.APIURL, url.PathEscape(wedding.Slug), card.key(wedding.ID)[:16]),
+		ImageWidth:  OpenGraphImageWidth,
+		ImageHeight: OpenGraphImageHeight,
+		Language:    wedding.ContentLanguage(),
+	}
+}
+
+// Image returns the link preview image of a wedding as a PNG, rendering it
+// unless it is cached
+func (s *OpenGraphService) Image(ctx context.Context, wedding *models.Wedding) (*OpenGraphImage, error) {
+	card := newOpenGraphCard(wedding)
+	key := card.key(wedding.ID)
+	etag := `"` + key[:32] + `"`
+
+	data, err := s.cache.Get(ctx, key)
+	if err != nil {
+		// A broken cache only costs rendering the image again
+		s.logger.Warn("Failed to read cached Open Graph image", zap.String("wedding_id", wedding.ID.String()), zap.Error(err))
+	}
+	if data != nil {
+		return &OpenGraphImage{Data: data, ETag: etag}, nil
+	}
+
+	data, err = card.render()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Set(ctx, key, data, s.opts.CacheTTL); err != nil {
+		s.logger.Warn("Failed to cache Open Graph image", zap.String("wedding_id", wedding.ID.String()), zap.Error(err))
+	}
+	return &OpenGraphImage{Data: data, ETag: etag}, nil
+}
+
+var openGraphPage = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html lang="{{.Language}}">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<meta name="description" content="{{.Description}}">
+<meta property="og:type" content="website">
+<meta property="og:title" content="{{.Title}}">
+<meta property="og:description" content="{{.Description}}">
+<meta property="og:url" content="{{.URL}}">
+<meta property="og:image" content="{{.Image}}">
+<meta property="og:image:type" content="image/png">
+<meta property="og:image:width" content="{{.ImageWidth}}">
+<meta property="og:image:height" content="{{.ImageHeight}}">
+<meta name="twitter:card" content="summary_large_image">
+<meta name="twitter:title" content="{{.Title}}">
+<meta name="twitter:description" content="{{.Description}}">
+<meta name="twitter:image" content="{{.Image}}">
+<link rel="canonical" href="{{.URL}}">
+<meta http-equiv="refresh" content="0; url={{.URL}}">
+</head>
+<body><a href="{{.URL}}">{{.Title}}</a></body>
+</html>
+`))
+
+// RenderSharePage renders a page with the link preview's meta tags that sends
+// browsers on to the invitation, for crawlers that do not run the frontend
+func RenderSharePage(meta *OpenGraphMeta) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := openGraphPage.Execute(&buf, meta); err != nil {
+		return nil, fmt.Errorf("failed to render share page: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func newOpenGraphCard(wedding *models.Wedding) openGraphCard {
+	partner1 := wedding.Couple.Partner1.FirstName
+	if partner1 == "" {
+		partner1 = wedding.Couple.Partner1.FullName
+	}
+	partner2 := wedding.Couple.Partner2.FirstName
+	if partner2 == "" {
+		partner2 = wedding.Couple.Partner2.FullName
+	}
+	var partners []string
+	for _, name := range []string{partner1, partner2} {
+		if name != "" {
+			partners = append(partners, name)
+		}
+	}
+	names := strings.Join(partners, " & ")
+	if names == "" {
+		names = wedding.Title
+	}
+
+	card := openGraphCard{
+		heading: "The Wedding of",
+		names:   names,
+		style:   themeStyleOf(wedding.Theme),
+	}
+	event := wedding.EventSessions()[0].EventDetails
+	if !event.Date.IsZero() {
+		// Only the day: the time is on the invitation
+		event.Time = ""
+		card.date = formatInvitationDate(event)
+	}
+	card.venue = event.VenueName
+	return card
+}
+
+// key identifies the image of the card's content
+func (c openGraphCard) key(weddingID models.ID) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("og|%d|%s|%s|%s|%s|%s|%v|%v|%v",
+		openGraphLayoutVersion, weddingID.String(), c.heading, c.names, c.date, c.venue,
+		c.style.background, c.style.primary, c.style.secondary)))
+	return hex.EncodeToString(sum[:])
+}
+
+var (
+	openGraphFontsOnce sync.Once
+	openGraphRegular   *opentype.Font
+	openGraphBold      *opentype.Font
+	openGraphFontsErr  error
+)
+
+// openGraphFonts parses the Go fonts the images are set in
+func openGraphFonts() (*opentype.Font, *opentype.Font, error) {
+	openGraphFontsOnce.Do(func() {
+		openGraphRegular, openGraphFontsErr = opentype.Parse(goregular.TTF)
+		if openGraphFontsErr == nil {
+			openGraphBold, openGraphFontsErr = opentype.Parse(gobold.TTF)
+		}
+	})
+	return openGraphRegular, openGraphBold, openGraphFontsErr
+}
+
+// render draws the card as a PNG
+func (c openGraphCard) render() ([]byte, error) {
+	regular, bold, err := openGraphFonts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fonts: %w", err)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, OpenGraphImageWidth, OpenGraphImageHeight))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c.style.background), image.Point{}, draw.Src)
+	strokeRect(img, image.Rect(32, 32, OpenGraphImageWidth-32, OpenGraphImageHeight-32), 4, c.style.primary)
+	strokeRect(img, image.Rect(46, 46, OpenGraphImageWidth-46, OpenGraphImageHeight-46), 1, c.style.primary)
+
+	const maxWidth = OpenGraphImageWidth - 160
+	lines := []struct {
+		text    string
+		font    *opentype.Font
+		size    float64
+		minSize float64
+		color   color.Color
+		y       int
+	}{
+		{c.heading, regular, 32, 32, c.style.secondary, 200},
+		{c.names, bold, 96, 48, c.style.primary, 320},
+		{c.date, regular, 40, 28, c.style.secondary, 450},
+		{c.venue, regular, 32, 24, c.style.secondary, 505},
+	}
+	for _, line := range lines {
+		if line.text == "" {
+			continue
+		}
+		if err := drawCenteredText(img, line.font, line.size, line.minSize, maxWidth, line.color, line.y, line.text); err != nil {
+			return nil, err
+		}
+	}
+	// A rule between the names and the date
+	ruleWidth := 160
+	draw.Draw(img, image.Rect((OpenGraphImageWidth-ruleWidth)/2, 372, (OpenGraphImageWidth+ruleWidth)/2, 374),
+		image.NewUniform(c.style.primary), image.Point{}, draw.Src)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode Open Graph image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// drawCenteredText draws a line of text centered on the image with its
+// baseline at y, shrinking it from size down to minSize to fit maxWidth
+func drawCenteredText(img draw.Image, f *opentype.Font, size, minSize float64, maxWidth int, c color.Color, y int, text string) error {
+	for {
+		face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return fmt.Errorf("failed to load font face: %w", err)
+		}
+		width := font.MeasureString(face, text).Ceil()
+		if width > maxWidth && size > minSize {
+			face.Close()
+			size = size * 0.9
+			if size < minSize {
+				size = minSize
+			}
+			continue
+		}
+
+		drawer := font.Drawer{
+			Dst:  img,
+			Src:  image.NewUniform(c),
+			Face: face,
+			Dot:  fixed.P((img.Bounds().Dx()-width)/2, y),
+		}
+		drawer.DrawString(text)
+		return face.Close()
+	}
+}
+
+// strokeRect draws the outline of a rectangle inside its bounds
+func strokeRect(img draw.Image, r image.Rectangle, width int, c color.Color) {
+	src := image.NewUniform(c)
+	for _, edge := range []image.Rectangle{
+		image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+width),
+		image.Rect(r.Min.X, r.Max.Y-width, r.Max.X, r.Max.Y),
+		image.Rect(r.Min.X, r.Min.Y, r.Min.X+width, r.Max.Y),
+		image.Rect(r.Max.X-width, r.Min.Y, r.Max.X, r.Max.Y),
+	} {
+		draw.Draw(img, edge, src, image.Point{}, draw.Src)
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func newOpenGraphWedding() *models.Wedding {
+	wedding := &models.Wedding{ID: models.NewID(), Slug: "ana-budi"}
+	wedding.Couple.Partner1.FirstName = "Ana"
+	wedding.Couple.Partner1.FullName = "Ana Putri"
+	wedding.Couple.Partner2.FirstName = "Budi"
+	wedding.Couple.Partner2.FullName = "Budi Santoso"
+	wedding.Event = models.EventDetails{
+		Date:      time.Date(2026, 6, 20, 11, 0, 0, 0, time.UTC),
+		Time:      "18:00",
+		VenueName: "Gedung Serbaguna",
+		Timezone:  "Asia/Jakarta",
+	}
+	wedding.Theme.PrimaryColor = "#8a5a44"
+	return wedding
+}
+
+func TestOpenGraphService_Meta(t *testing.T) {
+	service := NewOpenGraphService(NewDiskImageCache(t.TempDir()), OpenGraphOptions{
+		SiteURL: "https://invite.example.com/",
+		APIURL:  "https://api.example.com/api/v1",
+	}, zaptest.NewLogger(t))
+	wedding := newOpenGraphWedding()
+
+	meta := service.Meta(wedding)
+	assert.Equal(t, "Ana & Budi", meta.Title)
+	assert.Equal(t, "Ana & Budi invite you to celebrate their wedding on Saturday, June 20, 2026.", meta.Description)
+	assert.Equal(t, "https://invite.example.com/w/ana-budi", meta.URL)
+	assert.Regexp(t, `^https://api\.example\.com/api/v1/public/weddings/slug/ana-budi/og-image\.png\?v=[0-9a-f]{16}$`, meta.Image)
+	assert.Equal(t, OpenGraphImageWidth, meta.ImageWidth)
+	assert.Equal(t, OpenGraphImageHeight, meta.ImageHeight)
+
+	wedding.Title = "The Wedding of Ana & Budi"
+	wedding.ShareMessage = "Save the date!"
+	meta2 := service.Meta(wedding)
+	assert.Equal(t, "The Wedding of Ana & Budi", meta2.Title)
+	assert.Equal(t, "Save the date!", meta2.Description)
+	assert.Equal(t, meta.Image, meta2.Image, "the image does not show the title or message")
+
+	wedding.Event.VenueName = "Balai Kartini"
+	assert.NotEqual(t, meta.Image, service.Meta(wedding).Image, "a new image once the venue changes")
+}
+
+func TestOpenGraphService_Image(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	service := NewOpenGraphService(NewDiskImageCache(dir), OpenGraphOptions{}, zaptest.NewLogger(t))
+	wedding := newOpenGraphWedding()
+
+	img, err := service.Image(ctx, wedding)
+	require.NoError(t, err)
+	decoded, err := png.Decode(bytes.NewReader(img.Data))
+	require.NoError(t, err)
+	assert.Equal(t, OpenGraphImageWidth, decoded.Bounds().Dx())
+	assert.Equal(t, OpenGraphImageHeight, decoded.Bounds().Dy())
+	r, g, b, _ := decoded.At(34, 34).RGBA()
+	assert.Equal(t, []uint32{0x8a, 0x5a, 0x44}, []uint32{r >> 8, g >> 8, b >> 8}, "framed in the theme's primary color")
+
+	// Served from the cache while the content stays the same
+	cached, err := service.Image(ctx, wedding)
+	require.NoError(t, err)
+	assert.Equal(t, img.ETag, cached.ETag)
+	assert.Equal(t, img.Data, cached.Data)
+
+	wedding.Couple.Partner2.FirstName = "Bayu"
+	changed, err := service.Image(ctx, wedding)
+	require.NoError(t, err)
+	assert.NotEqual(t, img.ETag, changed.ETag)
+	assert.NotEqual(t, img.Data, changed.Data)
+}
+
+func TestRenderSharePage(t *testing.T) {
+	page, err := RenderSharePage(&OpenGraphMeta{
+		Title:       `Ana & Budi "2026"`,
+		Description: "<script>alert(1)</script>",
+		URL:         "https://invite.example.com/w/ana-budi",
+		Image:       "https://api.example.com/api/v1/public/weddings/slug/ana-budi/og-image.png?v=abc",
+		ImageWidth:  OpenGraphImageWidth,
+		ImageHeight: OpenGraphImageHeight,
+		Language:    "id",
+	})
+	require.NoError(t, err)
+
+	html := string(page)
+	assert.Contains(t, html, `<html lang="id">`)
+	assert.Contains(t, html, `<meta property="og:title" content="Ana &amp; Budi &#34;2026&#34;">`)
+	assert.Contains(t, html, `<meta property="og:image" content="https://api.example.com/api/v1/public/weddings/slug/ana-budi/og-image.png?v=abc">`)
+	assert.Contains(t, html, `<meta property="og:image:width" content="1200">`)
+	assert.Contains(t, html, `<link rel="canonical" href="https://invite.example.com/w/ana-budi">`)
+	assert.NotContains(t, html, "<script>")
+}
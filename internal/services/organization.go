@@ -0,0 +1,376 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+var (
+	ErrOrganizationNotFound   = errs.NotFound("organization not found")
+	ErrOrganizationExists     = errs.Conflict("organization already exists")
+	ErrOrganizationHasMembers = errs.Conflict("organization still has members")
+	ErrInvalidOrganizationID  = errs.InvalidField("id", "organization ID must be 3 to 64 lowercase letters, digits or dashes")
+	ErrPlatformAdminRequired  = errs.Forbidden("platform admin access required")
+)
+
+var organizationIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,62}[a-z0-9]$`)
+
+// OrganizationService manages white-label organizations. Platform admins create
+// organizations and assign their members; organization admins, the tenant
+// admins of an organization, manage its branding and see its users and weddings.
+type OrganizationService struct {
+	repo        repository.OrganizationRepository
+	userRepo    repository.UserRepository
+	weddingRepo repository.WeddingRepository
+	events      LifecycleEventPublisher
+	logger      *zap.Logger
+}
+
+// NewOrganizationService creates a new organization service
+func NewOrganizationService(repo repository.OrganizationRepository, userRepo repository.UserRepository, weddingRepo repository.WeddingRepository, logger *zap.Logger) *OrganizationService {
+	return &OrganizationService{
+		repo:        repo,
+		userRepo:    userRepo,
+		weddingRepo: weddingRepo,
+		logger:      logger,
+	}
+}
+
+// SetEventPublisher announces users joining an organization to its tenant
+// webhooks as user.registered lifecycle events
+func (s *OrganizationService) SetEventPublisher(events LifecycleEventPublisher) {
+	s.events = events
+}
+
+// CreateOrganizationRequest represents a new organization. The ID becomes the
+// tenant ID of its users and weddings and cannot be changed later.
+type CreateOrganizationRequest struct {
+	ID       string                      `json:"id" validate:"required,min=3,max=64"`
+	Name     string                      `json:"name" validate:"required,max=100"`
+	Branding models.OrganizationBranding `json:"branding"`
+}
+
+// UpdateOrganizationRequest represents changes to an organization
+type UpdateOrganizationRequest struct {
+	Name     *string                      `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Branding *models.OrganizationBranding `json:"branding,omitempty"`
+}
+
+// AddOrganizationMemberRequest moves a user into an organization
+type AddOrganizationMemberRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+	// Admin makes the user an organization admin
+	Admin bool `json:"admin"`
+}
+
+// CreateOrganization creates an organization (platform admin only)
+func (s *OrganizationService) CreateOrganization(ctx context.Context, actorID models.ID, req CreateOrganizationRequest) (*models.Organization, error) {
+	if err := s.requirePlatformAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	id := strings.ToLower(strings.TrimSpace(req.ID))
+	if !organizationIDPattern.MatchString(id) {
+		return nil, ErrInvalidOrganizationID
+	}
+	if _, err := s.repo.GetByID(ctx, id); err == nil {
+		return nil, ErrOrganizationExists
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	now := time.Now()
+	org := &models.Organization{
+		ID:        id,
+		Name:      strings.TrimSpace(req.Name),
+		Branding:  req.Branding,
+		CreatedBy: actorID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.Create(ctx, org); err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	s.logger.Info("Organization created", zap.String("organization_id", id), zap.String("created_by", actorID.String()))
+	return org, nil
+}
+
+// ListOrganizations lists every organization (platform admin only)
+func (s *OrganizationService) ListOrganizations(ctx context.Context, actorID models.ID, page, pageSize int) ([]*models.Organization, int64, error) {
+	if err := s.requirePlatformAdmin(ctx, actorID); err != nil {
+		return nil, 0, err
+	}
+	orgs, total, err := s.repo.List(ctx, page, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	return orgs, total, nil
+}
+
+// GetOrganization returns an organization (platform admin only)
+func (s *OrganizationService) GetOrganization(ctx context.Context, actorID models.ID, id string) (*models.Organization, error) {
+	if err := s.requirePlatformAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+	return s.getOrganization(ctx, id)
+}
+
+// UpdateOrganization changes the name or branding of an organization (platform admin only)
+func (s *OrganizationService) UpdateOrganization(ctx context.Context, actorID models.ID, id string, req UpdateOrganizationRequest) (*models.Organization, error) {
+	if err := s.requirePlatformAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+	org, err := s.getOrganization(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		org.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Branding != nil {
+		org.Branding = *req.Branding
+	}
+	return org, s.save(ctx, org)
+}
+
+// DeleteOrganization deletes an organization that no longer has members (platform admin only)
+func (s *OrganizationService) DeleteOrganization(ctx context.Context, actorID models.ID, id string) error {
+	if err := s.requirePlatformAdmin(ctx, actorID); err != nil {
+		return err
+	}
+	if _, err := s.getOrganization(ctx, id); err != nil {
+		return err
+	}
+
+	_, members, err := s.userRepo.List(repository.WithTenant(ctx, id), 1, 1, repository.UserFilters{})
+	if err != nil {
+		return fmt.Errorf("failed to count organization members: %w", err)
+	}
+	if members > 0 {
+		return ErrOrganizationHasMembers
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrOrganizationNotFound
+		}
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	return nil
+}
+
+// AddMember moves a user into an organization, optionally as an organization
+// admin (platform admin only). The weddings the user owns move with them.
+func (s *OrganizationService) AddMember(ctx context.Context, actorID models.ID, id string, req AddOrganizationMemberRequest) (*models.User, error) {
+	if err := s.requirePlatformAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getOrganization(ctx, id); err != nil {
+		return nil, err
+	}
+	userID, err := models.ParseID(req.UserID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	joined := user.TenantID != id
+	user.TenantID = id
+	switch {
+	case req.Admin:
+		user.Role = models.RoleOrgAdmin
+	case user.Role == models.RoleAdmin || user.Role == models.RoleOrgAdmin:
+		// Admin rights do not carry over into the organization
+		user.Role = models.RoleUser
+	}
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	if err := s.moveOwnedWeddings(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	if joined && s.events != nil {
+		// Event publishing must not fail adding the member
+		_ = s.events.Publish(ctx, &models.LifecycleEvent{
+			TenantID:   id,
+			Type:       models.LifecycleUserRegistered,
+			SubjectID:  user.ID,
+			Data:       map[string]interface{}{"email": user.Email},
+			OccurredAt: time.Now(),
+		})
+	}
+	return user, nil
+}
+
+// GetOwnOrganization returns the organization the organization admin belongs to
+func (s *OrganizationService) GetOwnOrganization(ctx context.Context, userID models.ID) (*models.Organization, error) {
+	tenantID, err := s.tenantOf(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.getOrganization(ctx, tenantID)
+}
+
+// UpdateBranding replaces the branding of the organization admin's organization
+func (s *OrganizationService) UpdateBranding(ctx context.Context, userID models.ID, branding models.OrganizationBranding) (*models.Organization, error) {
+	org, err := s.GetOwnOrganization(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	org.Branding = branding
+	return org, s.save(ctx, org)
+}
+
+// ListMembers lists the users of the organization admin's organization
+func (s *OrganizationService) ListMembers(ctx context.Context, userID models.ID, page, pageSize int, filters repository.UserFilters) ([]*models.User, int64, error) {
+	tenantID, err := s.tenantOf(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	users, total, err := s.userRepo.List(repository.WithTenant(ctx, tenantID), page, pageSize, filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	return users, total, nil
+}
+
+// ListWeddings lists the weddings of the organization admin's organization
+func (s *OrganizationService) ListWeddings(ctx context.Context, userID models.ID, page, pageSize int, filters repository.AdminWeddingFilters) ([]*models.Wedding, int64, error) {
+	tenantID, err := s.tenantOf(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	weddings, total, err := s.weddingRepo.List(repository.WithTenant(ctx, tenantID), page, pageSize, filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list organization weddings: %w", err)
+	}
+	return weddings, total, nil
+}
+
+// Branding returns the branding shown on the public pages of a tenant's
+// weddings, or nil when the tenant has no organization
+func (s *OrganizationService) Branding(ctx context.Context, tenantID string) (*models.OrganizationBranding, error) {
+	if tenantID == "" {
+		return nil, nil
+	}
+	org, err := s.repo.GetByID(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	branding := org.Branding
+	if branding.DisplayName == "" {
+		branding.DisplayName = org.Name
+	}
+	return &branding, nil
+}
+
+// Exists reports whether an organization with the ID exists
+func (s *OrganizationService) Exists(ctx context.Context, id string) (bool, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return true, nil
+}
+
+func (s *OrganizationService) getOrganization(ctx context.Context, id string) (*models.Organization, error) {
+	org, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return org, nil
+}
+
+func (s *OrganizationService) save(ctx context.Context, org *models.Organization) error {
+	org.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, org); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrOrganizationNotFound
+		}
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+	return nil
+}
+
+// moveOwnedWeddings sets the tenant of the weddings the user owns, leaving out
+// those they only collaborate on
+func (s *OrganizationService) moveOwnedWeddings(ctx context.Context, userID models.ID, tenantID string) error {
+	const pageSize = 100
+	for page := 1; ; page++ {
+		weddings, total, err := s.weddingRepo.GetByUserID(ctx, userID, page, pageSize, repository.WeddingFilters{})
+		if err != nil {
+			return fmt.Errorf("failed to list user weddings: %w", err)
+		}
+		for _, wedding := range weddings {
+			if wedding.UserID != userID || wedding.TenantID == tenantID {
+				continue
+			}
+			wedding.TenantID = tenantID
+			if err := s.weddingRepo.Update(ctx, wedding); err != nil {
+				return fmt.Errorf("failed to move wedding %s: %w", wedding.ID.String(), err)
+			}
+		}
+		if int64(page*pageSize) >= total || len(weddings) == 0 {
+			return nil
+		}
+	}
+}
+
+func (s *OrganizationService) getUser(ctx context.Context, userID models.ID) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+// requirePlatformAdmin rejects anyone but platform admins, whom the admin routes
+// are also limited to by middleware.RequirePlatformAdmin
+func (s *OrganizationService) requirePlatformAdmin(ctx context.Context, userID models.ID) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsPlatformAdmin() {
+		return ErrPlatformAdminRequired
+	}
+	return nil
+}
+
+func (s *OrganizationService) tenantOf(ctx context.Context, userID models.ID) (string, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if !user.IsTenantAdmin() {
+		return "", ErrNotTenantAdmin
+	}
+	return user.TenantID, nil
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockOrganizationRepository is an in-memory organization repository
+type MockOrganizationRepository struct {
+	orgs map[string]*models.Organization
+}
+
+func NewMockOrganizationRepository() *MockOrganizationRepository {
+	return &MockOrganizationRepository{orgs: make(map[string]*models.Organization)}
+}
+
+func (m *MockOrganizationRepository) Create(ctx context.Context, org *models.Organization) error {
+	m.orgs[org.ID] = org
+	return nil
+}
+
+func (m *MockOrganizationRepository) GetByID(ctx context.Context, id string) (*models.Organization, error) {
+	org, exists := m.orgs[id]
+	if !exists {
+		return nil, repository.ErrNotFound
+	}
+	return org, nil
+}
+
+func (m *MockOrganizationRepository) List(ctx context.Context, page, pageSize int) ([]*models.Organization, int64, error) {
+	var orgs []*models.Organization
+	for _, org := range m.orgs {
+		orgs = append(orgs, org)
+	}
+	return orgs, int64(len(orgs)), nil
+}
+
+func (m *MockOrganizationRepository) Update(ctx context.Context, org *models.Organization) error {
+	if _, exists := m.orgs[org.ID]; !exists {
+		return repository.ErrNotFound
+	}
+	m.orgs[org.ID] = org
+	return nil
+}
+
+func (m *MockOrganizationRepository) Delete(ctx context.Context, id string) error {
+	if _, exists := m.orgs[id]; !exists {
+		return repository.ErrNotFound
+	}
+	delete(m.orgs, id)
+	return nil
+}
+
+// inTenant matches contexts scoped to the tenant
+func inTenant(tenantID string) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		scoped, ok := repository.TenantFromContext(ctx)
+		return ok && scoped == tenantID
+	})
+}
+
+func newTestOrganizationService(t *testing.T) (*OrganizationService, *MockOrganizationRepository, *MockUserRepository, *MockWeddingRepository) {
+	orgs := NewMockOrganizationRepository()
+	users := new(MockUserRepository)
+	weddings := new(MockWeddingRepository)
+	return NewOrganizationService(orgs, users, weddings, zaptest.NewLogger(t)), orgs, users, weddings
+}
+
+func TestOrganizationService_CreateOrganization(t *testing.T) {
+	service, orgs, users, _ := newTestOrganizationService(t)
+	ctx := context.Background()
+	platformAdmin := &models.User{ID: models.NewID(), Role: models.RoleAdmin}
+	orgAdmin := &models.User{ID: models.NewID(), Role: models.RoleOrgAdmin, TenantID: "acme"}
+	tenantAdmin := &models.User{ID: models.NewID(), Role: models.RoleAdmin, TenantID: "acme"}
+	users.On("GetByID", mock.Anything, platformAdmin.ID).Return(platformAdmin, nil)
+	users.On("GetByID", mock.Anything, orgAdmin.ID).Return(orgAdmin, nil)
+	users.On("GetByID", mock.Anything, tenantAdmin.ID).Return(tenantAdmin, nil)
+
+	org, err := service.CreateOrganization(ctx, platformAdmin.ID, CreateOrganizationRequest{ID: " Acme-Weddings ", Name: "Acme Weddings"})
+	require.NoError(t, err)
+	assert.Equal(t, "acme-weddings", org.ID)
+	assert.Equal(t, platformAdmin.ID, org.CreatedBy)
+	assert.Contains(t, orgs.orgs, "acme-weddings")
+
+	_, err = service.CreateOrganization(ctx, platformAdmin.ID, CreateOrganizationRequest{ID: "acme-weddings", Name: "Again"})
+	assert.ErrorIs(t, err, ErrOrganizationExists)
+
+	_, err = service.CreateOrganization(ctx, platformAdmin.ID, CreateOrganizationRequest{ID: "acme_weddings!", Name: "Bad"})
+	assert.ErrorIs(t, err, ErrInvalidOrganizationID)
+	assert.ErrorIs(t, err, errs.ErrValidation)
+
+	// Organization admins do not administer the platform, nor do admins moved into an organization
+	_, err = service.CreateOrganization(ctx, orgAdmin.ID, CreateOrganizationRequest{ID: "other", Name: "Other"})
+	assert.ErrorIs(t, err, ErrPlatformAdminRequired)
+	_, err = service.CreateOrganization(ctx, tenantAdmin.ID, CreateOrganizationRequest{ID: "other", Name: "Other"})
+	assert.ErrorIs(t, err, ErrPlatformAdminRequired)
+}
+
+func TestOrganizationService_DeleteOrganization(t *testing.T) {
+	service, orgs, users, _ := newTestOrganizationService(t)
+	ctx := context.Background()
+	platformAdmin := &models.User{ID: models.NewID(), Role: models.RoleAdmin}
+	users.On("GetByID", mock.Anything, platformAdmin.ID).Return(platformAdmin, nil)
+	orgs.orgs["acme"] = &models.Organization{ID: "acme"}
+	orgs.orgs["empty"] = &models.Organization{ID: "empty"}
+	users.On("List", inTenant("acme"), 1, 1, repository.UserFilters{}).Return([]*models.User{{ID: models.NewID()}}, int64(1), nil)
+	users.On("List", inTenant("empty"), 1, 1, repository.UserFilters{}).Return([]*models.User{}, int64(0), nil)
+
+	assert.ErrorIs(t, service.DeleteOrganization(ctx, platformAdmin.ID, "acme"), ErrOrganizationHasMembers)
+	assert.NoError(t, service.DeleteOrganization(ctx, platformAdmin.ID, "empty"))
+	assert.NotContains(t, orgs.orgs, "empty")
+	assert.ErrorIs(t, service.DeleteOrganization(ctx, platformAdmin.ID, "missing"), ErrOrganizationNotFound)
+}
+
+func TestOrganizationService_AddMember(t *testing.T) {
+	service, orgs, users, weddings := newTestOrganizationService(t)
+	ctx := context.Background()
+	platformAdmin := &models.User{ID: models.NewID(), Role: models.RoleAdmin}
+	member := &models.User{ID: models.NewID(), Role: models.RoleUser}
+	orgs.orgs["acme"] = &models.Organization{ID: "acme"}
+	owned := &models.Wedding{ID: models.NewID(), UserID: member.ID}
+	shared := &models.Wedding{ID: models.NewID(), UserID: models.NewID()}
+
+	users.On("GetByID", mock.Anything, platformAdmin.ID).Return(platformAdmin, nil)
+	users.On("GetByID", mock.Anything, member.ID).Return(member, nil)
+	users.On("Update", mock.Anything, member).Return(nil)
+	weddings.On("GetByUserID", mock.Anything, member.ID, 1, 100, repository.WeddingFilters{}).Return([]*models.Wedding{owned, shared}, int64(2), nil)
+	weddings.On("Update", mock.Anything, owned).Return(nil)
+
+	user, err := service.AddMember(ctx, platformAdmin.ID, "acme", AddOrganizationMemberRequest{UserID: member.ID.String(), Admin: true})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", user.TenantID)
+	assert.True(t, user.IsTenantAdmin())
+	assert.Equal(t, models.RoleOrgAdmin, user.Role)
+	assert.False(t, user.IsPlatformAdmin())
+	assert.Equal(t, "acme", owned.TenantID)
+	assert.Empty(t, shared.TenantID, "weddings the member only collaborates on stay where they are")
+	weddings.AssertNotCalled(t, "Update", mock.Anything, shared)
+
+	_, err = service.AddMember(ctx, platformAdmin.ID, "missing", AddOrganizationMemberRequest{UserID: member.ID.String()})
+	assert.ErrorIs(t, err, ErrOrganizationNotFound)
+
+	// Added again as a plain member, they lose their admin rights
+	user, err = service.AddMember(ctx, platformAdmin.ID, "acme", AddOrganizationMemberRequest{UserID: member.ID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleUser, user.Role)
+}
+
+func TestOrganizationService_OrganizationAdmin(t *testing.T) {
+	service, orgs, users, weddings := newTestOrganizationService(t)
+	ctx := context.Background()
+	orgAdmin := &models.User{ID: models.NewID(), Role: models.RoleOrgAdmin, TenantID: "acme"}
+	member := &models.User{ID: models.NewID(), Role: models.RoleUser, TenantID: "acme"}
+	orgs.orgs["acme"] = &models.Organization{ID: "acme", Name: "Acme Weddings"}
+	users.On("GetByID", mock.Anything, orgAdmin.ID).Return(orgAdmin, nil)
+	users.On("GetByID", mock.Anything, member.ID).Return(member, nil)
+
+	org, err := service.UpdateBranding(ctx, orgAdmin.ID, models.OrganizationBranding{PrimaryColor: "#112233", HidePlatformBranding: true})
+	require.NoError(t, err)
+	assert.Equal(t, "#112233", org.Branding.PrimaryColor)
+	assert.False(t, org.UpdatedAt.IsZero())
+
+	users.On("List", inTenant("acme"), 1, 20, repository.UserFilters{}).Return([]*models.User{orgAdmin, member}, int64(2), nil)
+	members, total, err := service.ListMembers(ctx, orgAdmin.ID, 1, 20, repository.UserFilters{})
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+	assert.Equal(t, int64(2), total)
+
+	weddings.On("List", inTenant("acme"), 1, 20, repository.AdminWeddingFilters{}).Return([]*models.Wedding{{ID: models.NewID(), TenantID: "acme"}}, int64(1), nil)
+	list, _, err := service.ListWeddings(ctx, orgAdmin.ID, 1, 20, repository.AdminWeddingFilters{})
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	// Members who are not admins of the organization cannot manage it
+	_, err = service.UpdateBranding(ctx, member.ID, models.OrganizationBranding{})
+	assert.ErrorIs(t, err, ErrNotTenantAdmin)
+	_, _, err = service.ListMembers(ctx, member.ID, 1, 20, repository.UserFilters{})
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+}
+
+func TestOrganizationService_Branding(t *testing.T) {
+	service, orgs, _, _ := newTestOrganizationService(t)
+	ctx := context.Background()
+	orgs.orgs["acme"] = &models.Organization{ID: "acme", Name: "Acme Weddings", Branding: models.OrganizationBranding{LogoURL: "https://acme.example/logo.png"}}
+
+	branding, err := service.Branding(ctx, "acme")
+	require.NoError(t, err)
+	require.NotNil(t, branding)
+	assert.Equal(t, "Acme Weddings", branding.DisplayName, "the organization name is shown without a display name")
+	assert.Equal(t, "https://acme.example/logo.png", branding.LogoURL)
+	assert.Empty(t, orgs.orgs["acme"].Branding.DisplayName, "the stored branding is unchanged")
+
+	branding, err = service.Branding(ctx, "unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, branding)
+
+	branding, err = service.Branding(ctx, "")
+	assert.NoError(t, err)
+	assert.Nil(t, branding)
+}
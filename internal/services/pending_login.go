@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// pendingLoginTokenLength is the length of the token that carries a login to its second step
+const pendingLoginTokenLength = 43
+
+// ErrPendingLoginNotFound is returned for an unknown or expired second-step login token
+var ErrPendingLoginNotFound = errs.NotFound("login session not found or expired")
+
+// PendingLogin is a login whose password was checked and that waits for its second factor
+type PendingLogin struct {
+	UserID models.ID
+	// Attempts counts the wrong codes entered so far
+	Attempts int
+}
+
+// PendingLoginStore keeps logins between the password and the second factor.
+// Tokens are only stored hashed.
+type PendingLoginStore interface {
+	// Create starts a pending login for the user and returns its token
+	Create(ctx context.Context, userID models.ID, ttl time.Duration) (string, error)
+	Get(ctx context.Context, token string) (*PendingLogin, error)
+	// RecordFailure counts a wrong code and returns the attempts so far
+	RecordFailure(ctx context.Context, token string) (int, error)
+	Delete(ctx context.Context, token string) error
+}
+
+func newPendingLoginToken() (string, string, error) {
+	token, err := utils.GenerateSecureToken(pendingLoginTokenLength)
+	if err != nil {
+		return "", "", err
+	}
+	return token, hashPendingLoginToken(token), nil
+}
+
+func hashPendingLoginToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// redisPendingLoginStore keeps pending logins in Redis, so that the second
+// step may be served by any API instance
+type redisPendingLoginStore struct {
+	client *redis.Client
+}
+
+// NewRedisPendingLoginStore keeps pending logins in Redis
+func NewRedisPendingLoginStore(client *redis.Client) PendingLoginStore {
+	return &redisPendingLoginStore{client: client}
+}
+
+func (s *redisPendingLoginStore) key(hash string) string {
+	return "2fa:login:" + hash
+}
+
+func (s *redisPendingLoginStore) Create(ctx context.Context, userID models.ID, ttl time.Duration) (string, error) {
+	token, hash, err := newPendingLoginToken()
+	if err != nil {
+		return "", err
+	}
+	pipe := s.client.TxPipeline()
+	pipe.HSet(ctx, s.key(hash), "user_id", userID.String(), "attempts", 0)
+	pipe.Expire(ctx, s.key(hash), ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", fmt.Errorf("failed to store pending login: %w", err)
+	}
+	return token, nil
+}
+
+func (s *redisPendingLoginStore) Get(ctx context.Context, token string) (*PendingLogin, error) {
+	values, err := s.client.HGetAll(ctx, s.key(hashPendingLoginToken(token))).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending login: %w", err)
+	}
+	userID, err := models.ParseID(values["user_id"])
+	if err != nil {
+		return nil, ErrPendingLoginNotFound
+	}
+	attempts, _ := strconv.Atoi(values["attempts"])
+	return &PendingLogin{UserID: userID, Attempts: attempts}, nil
+}
+
+func (s *redisPendingLoginStore) RecordFailure(ctx context.Context, token string) (int, error) {
+	key := s.key(hashPendingLoginToken(token))
+	// HINCRBY would recreate an expired login without a TTL
+	if exists, err := s.client.Exists(ctx, key).Result(); err != nil {
+		return 0, fmt.Errorf("failed to record failed login: %w", err)
+	} else if exists == 0 {
+		return 0, ErrPendingLoginNotFound
+	}
+	attempts, err := s.client.HIncrBy(ctx, key, "attempts", 1).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to record failed login: %w", err)
+	}
+	return int(attempts), nil
+}
+
+func (s *redisPendingLoginStore) Delete(ctx context.Context, token string) error {
+	if err := s.client.Del(ctx, s.key(hashPendingLoginToken(token))).Err(); err != nil {
+		return fmt.Errorf("failed to delete pending login: %w", err)
+	}
+	return nil
+}
+
+// memoryPendingLoginStore keeps pending logins in process memory, for a single
+// API instance without Redis
+type memoryPendingLoginStore struct {
+	mu     sync.Mutex
+	logins map[string]*memoryPendingLogin
+	now    func() time.Time
+}
+
+type memoryPendingLogin struct {
+	PendingLogin
+	expiresAt time.Time
+}
+
+// NewMemoryPendingLoginStore keeps pending logins in process memory
+func NewMemoryPendingLoginStore() PendingLoginStore {
+	return &memoryPendingLoginStore{logins: make(map[string]*memoryPendingLogin), now: time.Now}
+}
+
+func (s *memoryPendingLoginStore) Create(ctx context.Context, userID models.ID, ttl time.Duration) (string, error) {
+	token, hash, err := newPendingLoginToken()
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	// Expired logins are dropped as new ones start
+	for h, login := range s.logins {
+		if !now.Before(login.expiresAt) {
+			delete(s.logins, h)
+		}
+	}
+	s.logins[hash] = &memoryPendingLogin{PendingLogin: PendingLogin{UserID: userID}, expiresAt: now.Add(ttl)}
+	return token, nil
+}
+
+func (s *memoryPendingLoginStore) Get(ctx context.Context, token string) (*PendingLogin, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	login, err := s.get(token)
+	if err != nil {
+		return nil, err
+	}
+	copied := login.PendingLogin
+	return &copied, nil
+}
+
+func (s *memoryPendingLoginStore) RecordFailure(ctx context.Context, token string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	login, err := s.get(token)
+	if err != nil {
+		return 0, err
+	}
+	login.Attempts++
+	return login.Attempts, nil
+}
+
+func (s *memoryPendingLoginStore) Delete(ctx context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.logins, hashPendingLoginToken(token))
+	return nil
+}
+
+// get returns an unexpired login; the caller holds the lock
+func (s *memoryPendingLoginStore) get(token string) (*memoryPendingLogin, error) {
+	hash := hashPendingLoginToken(token)
+	login, ok := s.logins[hash]
+	if !ok {
+		return nil, ErrPendingLoginNotFound
+	}
+	if !s.now().Before(login.expiresAt) {
+		delete(s.logins, hash)
+		return nil, ErrPendingLoginNotFound
+	}
+	return login, nil
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+const (
+	// defaultPreviewLinkTTL is how long a preview link created without an expiry works
+	defaultPreviewLinkTTL = 7 * 24 * time.Hour
+	// maxActivePreviewLinks bounds the preview links of a wedding that work at once
+	maxActivePreviewLinks = 20
+)
+
+var (
+	ErrPreviewLinkNotFound = errs.NotFound("preview link not found")
+	// ErrInvalidPreviewLink is returned for preview tokens that are malformed or belong to no link
+	ErrInvalidPreviewLink = errors.New("invalid preview link")
+	// ErrExpiredPreviewLink is returned for preview links that expired or were revoked
+	ErrExpiredPreviewLink  = errors.New("preview link has expired or was revoked")
+	ErrTooManyPreviewLinks = errors.New("a wedding can have at most 20 active preview links")
+)
+
+// CreatePreviewLinkRequest represents a new preview link
+type CreatePreviewLinkRequest struct {
+	Label string `json:"label" validate:"max=100"`
+	// ExpiresInHours is how long the link works, 7 days when left out
+	ExpiresInHours int `json:"expires_in_hours,omitempty" validate:"omitempty,min=1,max=720"`
+}
+
+// PreviewLinkDetail is a preview link with the token and frontend URL it is
+// shared through. Both are left out once the link stops working.
+type PreviewLinkDetail struct {
+	*models.PreviewLink
+	Token string `json:"token,omitempty"`
+	URL   string `json:"url,omitempty"`
+}
+
+// PreviewLinkService shares weddings that are not published yet through
+// expiring, revocable links that give read-only access to the draft
+type PreviewLinkService struct {
+	repo        repository.PreviewLinkRepository
+	weddingRepo repository.WeddingRepository
+	secret      string
+	siteURL     string
+	audit       AuditRecorder
+}
+
+// NewPreviewLinkService creates a new preview link service. Tokens are signed
+// with secret; siteURL is the frontend the links point to.
+func NewPreviewLinkService(repo repository.PreviewLinkRepository, weddingRepo repository.WeddingRepository, secret, siteURL string) *PreviewLinkService {
+	if siteURL == "" {
+		siteURL = DefaultInvitationOptions().SiteURL
+	}
+	return &PreviewLinkService{
+		repo:        repo,
+		weddingRepo: weddingRepo,
+		secret:      secret,
+		siteURL:     strings.TrimRight(siteURL, "/"),
+	}
+}
+
+// SetAuditLog records created and revoked preview links in the audit log
+func (s *PreviewLinkService) SetAuditLog(audit AuditRecorder) {
+	s.audit = audit
+}
+
+// CreateLink issues a preview link for a wedding the user may edit
+func (s *PreviewLinkService) CreateLink(ctx context.Context, weddingID, userID models.ID, req CreatePreviewLinkRequest) (*PreviewLinkDetail, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.ListByWedding(ctx, weddingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list preview links: %w", err)
+	}
+	now := time.Now()
+	active := 0
+	for _, link := range existing {
+		if link.IsActive(now) {
+			active++
+		}
+	}
+	if active >= maxActivePreviewLinks {
+		return nil, ErrTooManyPreviewLinks
+	}
+
+	ttl := defaultPreviewLinkTTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	link := &models.PreviewLink{
+		WeddingID: weddingID,
+		Label:     strings.TrimSpace(req.Label),
+		// Tokens carry the expiry in whole seconds
+		ExpiresAt: now.Add(ttl).Truncate(time.Second),
+		CreatedBy: userID,
+	}
+	if err := s.repo.Create(ctx, link); err != nil {
+		return nil, fmt.Errorf("failed to create preview link: %w", err)
+	}
+
+	s.recordAudit(ctx, models.AuditWeddingPreviewLink, link)
+	return s.detail(link, now), nil
+}
+
+// ListLinks lists the preview links of a wedding the user may edit, newest
+// first, including expired and revoked ones with their usage
+func (s *PreviewLinkService) ListLinks(ctx context.Context, weddingID, userID models.ID) ([]*PreviewLinkDetail, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.repo.ListByWedding(ctx, weddingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list preview links: %w", err)
+	}
+	now := time.Now()
+	details := make([]*PreviewLinkDetail, len(links))
+	for i, link := range links {
+		details[i] = s.detail(link, now)
+	}
+	return details, nil
+}
+
+// RevokeLink stops a preview link from working; its usage is kept
+func (s *PreviewLinkService) RevokeLink(ctx context.Context, weddingID, userID, linkID models.ID) error {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return err
+	}
+
+	link, err := s.repo.GetByID(ctx, linkID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrPreviewLinkNotFound
+		}
+		return fmt.Errorf("failed to get preview link: %w", err)
+	}
+	if link.WeddingID != weddingID {
+		return ErrPreviewLinkNotFound
+	}
+	if link.RevokedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	link.RevokedAt = &now
+	if err := s.repo.Update(ctx, link); err != nil {
+		return fmt.Errorf("failed to revoke preview link: %w", err)
+	}
+
+	s.recordAudit(ctx, models.AuditWeddingPreviewRevoke, link)
+	return nil
+}
+
+// OpenPreview returns the wedding a preview token gives access to, whether
+// published or not, and counts the visit on its link
+func (s *PreviewLinkService) OpenPreview(ctx context.Context, token string) (*models.Wedding, error) {
+	linkID, err := utils.VerifyPreviewToken(s.secret, token)
+	if err != nil {
+		if errors.Is(err, utils.ErrExpiredPreviewToken) {
+			return nil, ErrExpiredPreviewLink
+		}
+		return nil, ErrInvalidPreviewLink
+	}
+
+	link, err := s.repo.GetByID(ctx, linkID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidPreviewLink
+		}
+		return nil, fmt.Errorf("failed to get preview link: %w", err)
+	}
+	now := time.Now()
+	if !link.IsActive(now) {
+		return nil, ErrExpiredPreviewLink
+	}
+
+	wedding, err := s.weddingRepo.GetByID(ctx, link.WeddingID)
+	if err != nil {
+		// The wedding was deleted since the link was shared
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidPreviewLink
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrInvalidPreviewLink
+	}
+	if wedding.IsTakenDown() {
+		return nil, ErrWeddingTakenDown
+	}
+
+	if err := s.repo.RecordUse(ctx, link.ID, now); err != nil {
+		return nil, fmt.Errorf("failed to record preview link use: %w", err)
+	}
+	return wedding, nil
+}
+
+// detail adds the token and URL of a link that still works
+func (s *PreviewLinkService) detail(link *models.PreviewLink, now time.Time) *PreviewLinkDetail {
+	detail := &PreviewLinkDetail{PreviewLink: link}
+	if link.IsActive(now) {
+		detail.Token = utils.SignPreviewToken(s.secret, link.ID, link.ExpiresAt)
+		query := url.Values{}
+		query.Set("token", detail.Token)
+		detail.URL = fmt.Sprintf("%s/preview?%s", s.siteURL, query.Encode())
+	}
+	return detail
+}
+
+func (s *PreviewLinkService) getEditableWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	if !wedding.Can(userID, models.PermissionEditWedding) {
+		return nil, ErrUnauthorized
+	}
+	return wedding, nil
+}
+
+func (s *PreviewLinkService) recordAudit(ctx context.Context, action string, link *models.PreviewLink) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, &models.AuditLog{
+		Action:     action,
+		TargetType: models.AuditTargetWedding,
+		TargetID:   link.WeddingID.String(),
+		WeddingID:  &link.WeddingID,
+		Metadata: map[string]interface{}{
+			"preview_link_id": link.ID.String(),
+			"label":           link.Label,
+			"expires_at":      link.ExpiresAt,
+		},
+	})
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// MockPreviewLinkRepository is an in-memory preview link repository
+type MockPreviewLinkRepository struct {
+	links map[models.ID]*models.PreviewLink
+}
+
+func NewMockPreviewLinkRepository() *MockPreviewLinkRepository {
+	return &MockPreviewLinkRepository{links: make(map[models.ID]*models.PreviewLink)}
+}
+
+func (m *MockPreviewLinkRepository) Create(ctx context.Context, link *models.PreviewLink) error {
+	link.ID = models.NewID()
+	link.CreatedAt = time.Now()
+	copied := *link
+	m.links[link.ID] = &copied
+	return nil
+}
+
+func (m *MockPreviewLinkRepository) GetByID(ctx context.Context, id models.ID) (*models.PreviewLink, error) {
+	link, ok := m.links[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	copied := *link
+	return &copied, nil
+}
+
+func (m *MockPreviewLinkRepository) ListByWedding(ctx context.Context, weddingID models.ID) ([]*models.PreviewLink, error) {
+	links := []*models.PreviewLink{}
+	for _, link := range m.links {
+		if link.WeddingID == weddingID {
+			copied := *link
+			links = append(links, &copied)
+		}
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].CreatedAt.After(links[j].CreatedAt) })
+	return links, nil
+}
+
+func (m *MockPreviewLinkRepository) Update(ctx context.Context, link *models.PreviewLink) error {
+	if _, ok := m.links[link.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	copied := *link
+	m.links[link.ID] = &copied
+	return nil
+}
+
+func (m *MockPreviewLinkRepository) RecordUse(ctx context.Context, id models.ID, at time.Time) error {
+	link, ok := m.links[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	link.UseCount++
+	link.LastUsedAt = &at
+	return nil
+}
+
+func TestPreviewLinkService(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+	draft := &models.Wedding{ID: models.NewID(), UserID: ownerID, Status: string(models.WeddingStatusDraft)}
+
+	setup := func() (*PreviewLinkService, *MockPreviewLinkRepository) {
+		links := NewMockPreviewLinkRepository()
+		weddings := new(MockWeddingRepository)
+		weddings.On("GetByID", mock.Anything, draft.ID).Return(draft, nil)
+		return NewPreviewLinkService(links, weddings, "secret", "https://app.example.com/"), links
+	}
+
+	t.Run("Success - a draft opens through its link until revoked", func(t *testing.T) {
+		service, links := setup()
+
+		link, err := service.CreateLink(ctx, draft.ID, ownerID, CreatePreviewLinkRequest{Label: " Planner ", ExpiresInHours: 48})
+		require.NoError(t, err)
+		assert.Equal(t, "Planner", link.Label)
+		assert.WithinDuration(t, time.Now().Add(48*time.Hour), link.ExpiresAt, 2*time.Second)
+		assert.Equal(t, "https://app.example.com/preview?token="+link.Token, link.URL)
+
+		for i := 0; i < 2; i++ {
+			wedding, err := service.OpenPreview(ctx, link.Token)
+			require.NoError(t, err)
+			assert.Equal(t, draft.ID, wedding.ID)
+		}
+		assert.Equal(t, int64(2), links.links[link.ID].UseCount)
+		assert.NotNil(t, links.links[link.ID].LastUsedAt)
+
+		require.NoError(t, service.RevokeLink(ctx, draft.ID, ownerID, link.ID))
+		_, err = service.OpenPreview(ctx, link.Token)
+		assert.ErrorIs(t, err, ErrExpiredPreviewLink)
+
+		listed, err := service.ListLinks(ctx, draft.ID, ownerID)
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, int64(2), listed[0].UseCount)
+		assert.Empty(t, listed[0].URL)
+	})
+
+	t.Run("Error - expired and forged tokens", func(t *testing.T) {
+		service, links := setup()
+
+		link, err := service.CreateLink(ctx, draft.ID, ownerID, CreatePreviewLinkRequest{})
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(defaultPreviewLinkTTL), link.ExpiresAt, 2*time.Second)
+
+		expired := utils.SignPreviewToken("secret", link.ID, time.Now().Add(-time.Minute))
+		_, err = service.OpenPreview(ctx, expired)
+		assert.ErrorIs(t, err, ErrExpiredPreviewLink)
+
+		_, err = service.OpenPreview(ctx, utils.SignPreviewToken("other", link.ID, link.ExpiresAt))
+		assert.ErrorIs(t, err, ErrInvalidPreviewLink)
+		_, err = service.OpenPreview(ctx, utils.SignPreviewToken("secret", models.NewID(), link.ExpiresAt))
+		assert.ErrorIs(t, err, ErrInvalidPreviewLink)
+		assert.Zero(t, links.links[link.ID].UseCount)
+	})
+
+	t.Run("Error - only editors share and revoke links", func(t *testing.T) {
+		service, _ := setup()
+
+		_, err := service.CreateLink(ctx, draft.ID, models.NewID(), CreatePreviewLinkRequest{})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+
+		link, err := service.CreateLink(ctx, draft.ID, ownerID, CreatePreviewLinkRequest{})
+		require.NoError(t, err)
+		assert.ErrorIs(t, service.RevokeLink(ctx, draft.ID, models.NewID(), link.ID), ErrUnauthorized)
+		assert.ErrorIs(t, service.RevokeLink(ctx, draft.ID, ownerID, models.NewID()), ErrPreviewLinkNotFound)
+	})
+
+	t.Run("Error - too many active links", func(t *testing.T) {
+		service, _ := setup()
+
+		for i := 0; i < maxActivePreviewLinks; i++ {
+			_, err := service.CreateLink(ctx, draft.ID, ownerID, CreatePreviewLinkRequest{})
+			require.NoError(t, err)
+		}
+		_, err := service.CreateLink(ctx, draft.ID, ownerID, CreatePreviewLinkRequest{})
+		assert.ErrorIs(t, err, ErrTooManyPreviewLinks)
+	})
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultProfaneWords are masked out of guest-written text. The list covers
+// common English and Indonesian swear words; matching is on whole words.
+var defaultProfaneWords = []string{
+	"asshole", "bastard", "bitch", "bullshit", "cunt", "dick", "fuck", "fucked",
+	"fucker", "fucking", "motherfucker", "shit", "shitty",
+	"anjing", "anjir", "bajingan", "bangsat", "goblok", "jancok", "kampret",
+	"keparat", "kontol", "memek", "ngentot", "tolol",
+}
+
+// profanityWordPattern matches the words of a text, in any script
+var profanityWordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// ProfanityFilter masks profane words in text guests write
+type ProfanityFilter struct {
+	words map[string]bool
+}
+
+// NewProfanityFilter creates a filter for the given words, matched case-insensitively
+func NewProfanityFilter(words ...string) *ProfanityFilter {
+	f := &ProfanityFilter{words: make(map[string]bool, len(words))}
+	for _, word := range words {
+		f.words[strings.ToLower(word)] = true
+	}
+	return f
+}
+
+// Clean replaces every profane word of the text with asterisks and reports
+// whether it replaced any
+func (f *ProfanityFilter) Clean(text string) (string, bool) {
+	filtered := false
+	cleaned := profanityWordPattern.ReplaceAllStringFunc(text, func(word string) string {
+		if !f.words[strings.ToLower(word)] {
+			return word
+		}
+		filtered = true
+		return strings.Repeat("*", utf8.RuneCountInString(word))
+	})
+	return cleaned, filtered
+}
+
+// Contains reports whether the text has a profane word
+func (f *ProfanityFilter) Contains(text string) bool {
+	for _, word := range profanityWordPattern.FindAllString(text, -1) {
+		if f.words[strings.ToLower(word)] {
+			return true
+		}
+	}
+	return false
+}
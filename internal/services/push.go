@@ -0,0 +1,373 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdh"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// maxPushSubscriptions bounds the browsers of one user; subscribing another
+// replaces the oldest, which is likely no longer in use
+const maxPushSubscriptions = 10
+
+var (
+	ErrWebPushUnavailable       = errors.New("web push notifications are not configured")
+	ErrPushSubscriptionNotFound = errs.NotFound("push subscription not found")
+	ErrInvalidPushSubscription  = errors.New("invalid push subscription")
+	ErrInvalidPushEvent         = errors.New("unsupported push notification event")
+)
+
+// PushSubscriptionKeys are the keys of a browser's PushSubscription
+type PushSubscriptionKeys struct {
+	P256dh string `json:"p256dh" validate:"required,max=200"`
+	Auth   string `json:"auth" validate:"required,max=100"`
+}
+
+// PushSubscriptionRequest is the JSON of a browser's PushSubscription, as
+// returned by its toJSON method
+type PushSubscriptionRequest struct {
+	Endpoint string               `json:"endpoint" validate:"required,url,max=2048"`
+	Keys     PushSubscriptionKeys `json:"keys" validate:"required"`
+}
+
+// NotificationPreferencesRequest sets which events a user gets push notifications for
+type NotificationPreferencesRequest struct {
+	PushMuted []string `json:"push_muted" validate:"max=20"`
+}
+
+// PushJob delivers one notification to one subscribed browser
+type PushJob struct {
+	SubscriptionID models.ID               `bson:"subscription_id"`
+	Notification   models.PushNotification `bson:"notification"`
+}
+
+// RSVPPushNotifier is told about every stored RSVP, e.g. to push it to the
+// browsers of the wedding's owner. Notifying never fails the RSVP.
+type RSVPPushNotifier interface {
+	NotifyRSVP(ctx context.Context, rsvp *models.RSVP)
+}
+
+// PushService lets users subscribe their browsers to Web Push notifications
+// about their weddings, such as new RSVPs. Notifications are delivered by
+// background jobs; subscriptions the push service reports gone are removed.
+type PushService struct {
+	subscriptions repository.PushSubscriptionRepository
+	userRepo      repository.UserRepository
+	weddingRepo   repository.WeddingRepository
+	jobs          JobEnqueuer
+	sender        WebPushSender
+	logger        *zap.Logger
+}
+
+// NewPushService creates a new Web Push service. Without a sender subscribing
+// is unavailable and nothing is pushed.
+func NewPushService(
+	subscriptions repository.PushSubscriptionRepository,
+	userRepo repository.UserRepository,
+	weddingRepo repository.WeddingRepository,
+	jobs JobEnqueuer,
+	sender WebPushSender,
+	logger *zap.Logger,
+) *PushService {
+	return &PushService{
+		subscriptions: subscriptions,
+		userRepo:      userRepo,
+		weddingRepo:   weddingRepo,
+		jobs:          jobs,
+		sender:        sender,
+		logger:        logger,
+	}
+}
+
+// VAPIDPublicKey returns the application server key browsers subscribe with
+func (s *PushService) VAPIDPublicKey() (string, error) {
+	if s.sender == nil {
+		return "", ErrWebPushUnavailable
+	}
+	return s.sender.PublicKey(), nil
+}
+
+// Subscribe stores a browser's subscription for the user. A browser subscribing
+// again replaces its earlier subscription, even one of another user.
+func (s *PushService) Subscribe(ctx context.Context, userID models.ID, req PushSubscriptionRequest, userAgent string) (*models.PushSubscription, error) {
+	if s.sender == nil {
+		return nil, ErrWebPushUnavailable
+	}
+	if err := validatePushSubscription(ctx, req); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.subscriptions.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxPushSubscriptions && !hasPushEndpoint(existing, req.Endpoint) {
+		oldest := existing[len(existing)-1]
+		if err := s.subscriptions.Delete(ctx, oldest.ID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	if len(userAgent) > 200 {
+		userAgent = userAgent[:200]
+	}
+	subscription := &models.PushSubscription{
+		UserID:    userID,
+		Endpoint:  req.Endpoint,
+		P256dh:    req.Keys.P256dh,
+		Auth:      req.Keys.Auth,
+		UserAgent: userAgent,
+		CreatedAt: time.Now(),
+	}
+	if err := s.subscriptions.Save(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// ListSubscriptions lists the subscribed browsers of a user, newest first
+func (s *PushService) ListSubscriptions(ctx context.Context, userID models.ID) ([]*models.PushSubscription, error) {
+	return s.subscriptions.ListByUser(ctx, userID)
+}
+
+// Unsubscribe removes a subscription of the user
+func (s *PushService) Unsubscribe(ctx context.Context, userID, subscriptionID models.ID) error {
+	subscription, err := s.subscriptions.GetByID(ctx, subscriptionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrPushSubscriptionNotFound
+		}
+		return err
+	}
+	if subscription.UserID != userID {
+		return ErrPushSubscriptionNotFound
+	}
+
+	if err := s.subscriptions.Delete(ctx, subscriptionID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrPushSubscriptionNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// GetPreferences returns the notification preferences of a user
+func (s *PushService) GetPreferences(ctx context.Context, userID models.ID) (*models.NotificationPreferences, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &user.Notifications, nil
+}
+
+// UpdatePreferences replaces the events a user gets no push notifications for
+func (s *PushService) UpdatePreferences(ctx context.Context, userID models.ID, req NotificationPreferencesRequest) (*models.NotificationPreferences, error) {
+	muted := make([]models.PushEvent, 0, len(req.PushMuted))
+	seen := make(map[string]bool, len(req.PushMuted))
+	for _, event := range req.PushMuted {
+		if !models.IsValidPushEvent(event) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPushEvent, event)
+		}
+		if !seen[event] {
+			seen[event] = true
+			muted = append(muted, models.PushEvent(event))
+		}
+	}
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.Notifications.PushMuted = muted
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+	return &user.Notifications, nil
+}
+
+// NotifyRSVP queues a push of a new RSVP to every browser of the wedding's
+// members who manage RSVPs and have not muted its event. Failures are logged,
+// not returned.
+func (s *PushService) NotifyRSVP(ctx context.Context, rsvp *models.RSVP) {
+	if s.sender == nil {
+		return
+	}
+
+	wedding, err := s.weddingRepo.GetByID(ctx, rsvp.WeddingID)
+	if err != nil || wedding == nil {
+		s.logger.Error("Failed to get wedding for RSVP push",
+			zap.Error(err),
+			zap.String("wedding_id", rsvp.WeddingID.String()))
+		return
+	}
+
+	notification := rsvpPushNotification(wedding, rsvp)
+	recipients := []models.ID{wedding.UserID}
+	for _, collaborator := range wedding.Collaborators {
+		if collaborator.Role.Has(models.PermissionManageRSVPs) {
+			recipients = append(recipients, collaborator.UserID)
+		}
+	}
+
+	for _, userID := range recipients {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil || user == nil {
+			continue
+		}
+		if !user.Notifications.Pushes(notification.Event) {
+			continue
+		}
+
+		subscriptions, err := s.subscriptions.ListByUser(ctx, userID)
+		if err != nil {
+			s.logger.Error("Failed to list push subscriptions",
+				zap.Error(err),
+				zap.String("user_id", userID.String()))
+			continue
+		}
+		for _, subscription := range subscriptions {
+			job := PushJob{SubscriptionID: subscription.ID, Notification: notification}
+			if err := s.jobs.Enqueue(ctx, JobTypeSendPush, job); err != nil {
+				s.logger.Error("Failed to queue push notification",
+					zap.Error(err),
+					zap.String("subscription_id", subscription.ID.String()))
+			}
+		}
+	}
+}
+
+// Deliver pushes a notification to a subscribed browser. Failures the push
+// service may recover from are returned for the job queue to retry; a
+// subscription the push service no longer knows is removed.
+func (s *PushService) Deliver(ctx context.Context, job PushJob) error {
+	if s.sender == nil {
+		return PermanentJobError(ErrWebPushUnavailable)
+	}
+
+	subscription, err := s.subscriptions.GetByID(ctx, job.SubscriptionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get push subscription: %w", err)
+	}
+
+	payload, err := json.Marshal(job.Notification)
+	if err != nil {
+		return PermanentJobError(fmt.Errorf("invalid push notification: %w", err))
+	}
+
+	err = s.sender.Send(ctx, subscription, payload)
+	var pushErr *WebPushError
+	switch {
+	case err == nil:
+		if err := s.subscriptions.MarkPushed(ctx, subscription.ID, time.Now()); err != nil {
+			s.logger.Warn("Failed to mark push subscription pushed", zap.Error(err))
+		}
+		return nil
+	case errors.As(err, &pushErr) && (pushErr.StatusCode == http.StatusNotFound || pushErr.StatusCode == http.StatusGone):
+		// The browser unsubscribed or the subscription expired
+		if err := s.subscriptions.Delete(ctx, subscription.ID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("failed to remove expired push subscription: %w", err)
+		}
+		return nil
+	case errors.As(err, &pushErr) && !isRetryableWebhookStatus(pushErr.StatusCode):
+		return PermanentJobError(err)
+	default:
+		return err
+	}
+}
+
+func (s *PushService) getUser(ctx context.Context, userID models.ID) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// rsvpPushNotification describes a new RSVP for the wedding's members
+func rsvpPushNotification(wedding *models.Wedding, rsvp *models.RSVP) models.PushNotification {
+	name := strings.TrimSpace(rsvp.GetFullName())
+	notification := models.PushNotification{
+		Title:     wedding.Title,
+		WeddingID: wedding.ID,
+		Data:      map[string]string{"rsvp_id": rsvp.ID.String()},
+		Tag:       "rsvp-" + rsvp.ID.String(),
+	}
+
+	switch {
+	case rsvp.NeedsReview():
+		notification.Event = models.PushEventRSVPReview
+		notification.Body = fmt.Sprintf("%s's RSVP looks suspicious and is waiting for your review", name)
+	case rsvp.Status == string(models.RSVPNotAttending):
+		notification.Event = models.PushEventRSVPDeclined
+		notification.Body = fmt.Sprintf("%s can't make it", name)
+	case rsvp.Status == string(models.RSVPMaybe):
+		notification.Event = models.PushEventRSVPMaybe
+		notification.Body = fmt.Sprintf("%s might attend", name)
+	default:
+		notification.Event = models.PushEventRSVPAttending
+		if guests := rsvp.GetTotalGuests(); guests > 1 {
+			notification.Body = fmt.Sprintf("%s is attending with %d guests in total", name, guests)
+		} else {
+			notification.Body = fmt.Sprintf("%s is attending", name)
+		}
+	}
+	return notification
+}
+
+// validatePushSubscription checks that a subscription can be pushed to: an
+// HTTPS endpoint of a host resolving only to public addresses, like webhook
+// URLs, a P-256 public key and a 16 byte auth secret
+func validatePushSubscription(ctx context.Context, req PushSubscriptionRequest) error {
+	endpoint, err := url.Parse(req.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return fmt.Errorf("%w: endpoint must be an https URL", ErrInvalidPushSubscription)
+	}
+	if err := checkWebhookHost(ctx, endpoint.Hostname()); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPushSubscription, err)
+	}
+
+	key, err := decodeWebPushKey(req.Keys.P256dh)
+	if err != nil {
+		return fmt.Errorf("%w: invalid p256dh key", ErrInvalidPushSubscription)
+	}
+	if _, err := ecdh.P256().NewPublicKey(key); err != nil {
+		return fmt.Errorf("%w: invalid p256dh key", ErrInvalidPushSubscription)
+	}
+
+	auth, err := decodeWebPushKey(req.Keys.Auth)
+	if err != nil || len(auth) != 16 {
+		return fmt.Errorf("%w: invalid auth secret", ErrInvalidPushSubscription)
+	}
+	return nil
+}
+
+func hasPushEndpoint(subscriptions []*models.PushSubscription, endpoint string) bool {
+	for _, subscription := range subscriptions {
+		if subscription.Endpoint == endpoint {
+			return true
+		}
+	}
+	return false
+}
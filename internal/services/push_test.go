@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockPushSubscriptionRepository is an in-memory push subscription repository
+type MockPushSubscriptionRepository struct {
+	subscriptions map[models.ID]*models.PushSubscription
+}
+
+func NewMockPushSubscriptionRepository() *MockPushSubscriptionRepository {
+	return &MockPushSubscriptionRepository{subscriptions: make(map[models.ID]*models.PushSubscription)}
+}
+
+func (m *MockPushSubscriptionRepository) Save(ctx context.Context, subscription *models.PushSubscription) error {
+	for _, existing := range m.subscriptions {
+		if existing.Endpoint == subscription.Endpoint {
+			subscription.ID = existing.ID
+			subscription.CreatedAt = existing.CreatedAt
+		}
+	}
+	if subscription.ID.IsZero() {
+		subscription.ID = models.NewID()
+	}
+	copied := *subscription
+	m.subscriptions[subscription.ID] = &copied
+	return nil
+}
+
+func (m *MockPushSubscriptionRepository) GetByID(ctx context.Context, id models.ID) (*models.PushSubscription, error) {
+	subscription, ok := m.subscriptions[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	copied := *subscription
+	return &copied, nil
+}
+
+func (m *MockPushSubscriptionRepository) ListByUser(ctx context.Context, userID models.ID) ([]*models.PushSubscription, error) {
+	subscriptions := []*models.PushSubscription{}
+	for _, subscription := range m.subscriptions {
+		if subscription.UserID == userID {
+			copied := *subscription
+			subscriptions = append(subscriptions, &copied)
+		}
+	}
+	sort.Slice(subscriptions, func(i, j int) bool { return subscriptions[i].CreatedAt.After(subscriptions[j].CreatedAt) })
+	return subscriptions, nil
+}
+
+func (m *MockPushSubscriptionRepository) MarkPushed(ctx context.Context, id models.ID, pushedAt time.Time) error {
+	if subscription, ok := m.subscriptions[id]; ok {
+		subscription.LastPushAt = &pushedAt
+	}
+	return nil
+}
+
+func (m *MockPushSubscriptionRepository) Delete(ctx context.Context, id models.ID) error {
+	if _, ok := m.subscriptions[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.subscriptions, id)
+	return nil
+}
+
+// recordingPushSender records pushes instead of sending them
+type recordingPushSender struct {
+	sent []models.ID
+	err  error
+}
+
+func (s *recordingPushSender) PublicKey() string { return "test-public-key" }
+
+func (s *recordingPushSender) Send(ctx context.Context, subscription *models.PushSubscription, payload []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, subscription.ID)
+	return nil
+}
+
+func TestPushService(t *testing.T) {
+	stubWebhookNetwork(t)
+	ctx := context.Background()
+	ownerID := models.NewID()
+	plannerID := models.NewID()
+	collaboratorID := models.NewID()
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		UserID: ownerID,
+		Title:  "Alice & Bob",
+		Collaborators: []models.WeddingCollaborator{
+			{UserID: plannerID, Role: models.WeddingRolePlanner},
+			{UserID: collaboratorID, Role: models.WeddingRoleCollaborator},
+		},
+	}
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+
+	owner := &models.User{ID: ownerID}
+	planner := &models.User{ID: plannerID, Notifications: models.NotificationPreferences{PushMuted: []models.PushEvent{models.PushEventRSVPDeclined}}}
+	collaborator := &models.User{ID: collaboratorID}
+	userRepo := &MockUserRepository{}
+	for _, user := range []*models.User{owner, planner, collaborator} {
+		userRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	}
+	userRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	subscriptions := NewMockPushSubscriptionRepository()
+	jobRepo := NewMockJobRepository()
+	queue := NewJobQueue(jobRepo, JobQueueOptions{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}, zap.NewNop())
+	sender := &recordingPushSender{}
+	service := NewPushService(subscriptions, userRepo, weddingRepo, queue, sender, zap.NewNop())
+	RegisterJobHandlers(queue, nil, nil, nil, nil, nil, nil, nil, service, nil)
+
+	subscribe := func(userID models.ID, endpoint string) *models.PushSubscription {
+		browser, _, _ := newTestBrowser(t, endpoint)
+		subscription, err := service.Subscribe(ctx, userID, PushSubscriptionRequest{
+			Endpoint: endpoint,
+			Keys:     PushSubscriptionKeys{P256dh: browser.P256dh, Auth: browser.Auth},
+		}, "Firefox")
+		require.NoError(t, err)
+		return subscription
+	}
+	ownerPhone := subscribe(ownerID, "https://push.example.com/owner-phone")
+	ownerLaptop := subscribe(ownerID, "https://push.example.com/owner-laptop")
+	plannerLaptop := subscribe(plannerID, "https://push.example.com/planner")
+	collaboratorPhone := subscribe(collaboratorID, "https://push.example.com/collaborator")
+
+	t.Run("Success - resubscribing replaces the keys of the browser", func(t *testing.T) {
+		again := subscribe(ownerID, "https://push.example.com/owner-phone")
+		assert.Equal(t, ownerPhone.ID, again.ID)
+
+		list, err := service.ListSubscriptions(ctx, ownerID)
+		require.NoError(t, err)
+		assert.Len(t, list, 2)
+	})
+
+	t.Run("Error - invalid subscriptions and internal endpoints", func(t *testing.T) {
+		browser, _, _ := newTestBrowser(t, "https://push.example.com/x")
+		short := base64.RawURLEncoding.EncodeToString([]byte("short"))
+		for _, req := range []PushSubscriptionRequest{
+			{Endpoint: "http://push.example.com/x", Keys: PushSubscriptionKeys{P256dh: browser.P256dh, Auth: browser.Auth}},
+			{Endpoint: "https://169.254.169.254/x", Keys: PushSubscriptionKeys{P256dh: browser.P256dh, Auth: browser.Auth}},
+			{Endpoint: "https://intranet.example.com/x", Keys: PushSubscriptionKeys{P256dh: browser.P256dh, Auth: browser.Auth}},
+			{Endpoint: "https://push.example.com/x", Keys: PushSubscriptionKeys{P256dh: short, Auth: browser.Auth}},
+			{Endpoint: "https://push.example.com/x", Keys: PushSubscriptionKeys{P256dh: browser.P256dh, Auth: short}},
+		} {
+			_, err := service.Subscribe(ctx, ownerID, req, "")
+			assert.ErrorIs(t, err, ErrInvalidPushSubscription)
+		}
+	})
+
+	t.Run("Success - pushes to members managing RSVPs who did not mute the event", func(t *testing.T) {
+		sender.sent = nil
+		service.NotifyRSVP(ctx, &models.RSVP{ID: models.NewID(), WeddingID: wedding.ID, FirstName: "Carol", LastName: "Jones", Status: string(models.RSVPAttending), AttendanceCount: 2})
+
+		jobs := jobRepo.byType(JobTypeSendPush)
+		require.Len(t, jobs, 4)
+		var payload PushJob
+		require.NoError(t, jobs[0].DecodePayload(&payload))
+		assert.Equal(t, models.PushEventRSVPAttending, payload.Notification.Event)
+		assert.Equal(t, "Alice & Bob", payload.Notification.Title)
+		assert.Equal(t, "Carol Jones is attending with 2 guests in total", payload.Notification.Body)
+
+		for range jobs {
+			_, err := queue.ProcessNext(ctx)
+			require.NoError(t, err)
+		}
+		assert.ElementsMatch(t, []models.ID{ownerPhone.ID, ownerLaptop.ID, plannerLaptop.ID, collaboratorPhone.ID}, sender.sent)
+	})
+
+	t.Run("Success - muted events skip the member", func(t *testing.T) {
+		sender.sent = nil
+		before := len(jobRepo.byType(JobTypeSendPush))
+		service.NotifyRSVP(ctx, &models.RSVP{ID: models.NewID(), WeddingID: wedding.ID, FirstName: "Dan", LastName: "Smith", Status: string(models.RSVPNotAttending), AttendanceCount: 1})
+		assert.Len(t, jobRepo.byType(JobTypeSendPush), before+3)
+	})
+
+	t.Run("Success - gone subscriptions are removed", func(t *testing.T) {
+		sender.err = &WebPushError{StatusCode: 410}
+		defer func() { sender.err = nil }()
+
+		require.NoError(t, service.Deliver(ctx, PushJob{SubscriptionID: ownerLaptop.ID}))
+		assert.NotContains(t, subscriptions.subscriptions, ownerLaptop.ID)
+	})
+
+	t.Run("Error - rejected pushes are not retried", func(t *testing.T) {
+		sender.err = &WebPushError{StatusCode: 400}
+		defer func() { sender.err = nil }()
+
+		err := service.Deliver(ctx, PushJob{SubscriptionID: ownerPhone.ID})
+		var permanent *permanentJobError
+		assert.ErrorAs(t, err, &permanent)
+	})
+
+	t.Run("Success - preferences", func(t *testing.T) {
+		preferences, err := service.UpdatePreferences(ctx, ownerID, NotificationPreferencesRequest{PushMuted: []string{"rsvp.maybe", "rsvp.maybe"}})
+		require.NoError(t, err)
+		assert.Equal(t, []models.PushEvent{models.PushEventRSVPMaybe}, preferences.PushMuted)
+
+		_, err = service.UpdatePreferences(ctx, ownerID, NotificationPreferencesRequest{PushMuted: []string{"wish.created"}})
+		assert.ErrorIs(t, err, ErrInvalidPushEvent)
+	})
+
+	t.Run("Error - unsubscribing another user's browser", func(t *testing.T) {
+		assert.ErrorIs(t, service.Unsubscribe(ctx, ownerID, plannerLaptop.ID), ErrPushSubscriptionNotFound)
+		assert.NoError(t, service.Unsubscribe(ctx, plannerID, plannerLaptop.ID))
+	})
+
+	t.Run("Error - unavailable without a VAPID key", func(t *testing.T) {
+		unconfigured := NewPushService(subscriptions, userRepo, weddingRepo, queue, nil, zap.NewNop())
+		_, err := unconfigured.VAPIDPublicKey()
+		assert.ErrorIs(t, err, ErrWebPushUnavailable)
+	})
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"wedding-invitation-backend/internal/utils"
+)
+
+// Named rate limit policies of abuse-prone endpoints
+const (
+	RateLimitLogin         = "login"
+	RateLimitPasswordReset = "password_reset"
+	RateLimitAccountEmail  = "account_email"
+	RateLimitPublicRSVP    = "public_rsvp"
+	RateLimitTracking      = "tracking"
+	RateLimitUploads       = "uploads"
+)
+
+// RateLimitPolicy allows Limit requests per client in any Window. A policy
+// without a limit allows everything.
+type RateLimitPolicy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+// Enabled reports whether the policy limits anything
+func (p RateLimitPolicy) Enabled() bool {
+	return p.Limit > 0 && p.Window > 0
+}
+
+// RateLimitResult is the outcome of counting a request against a policy
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long a throttled client waits until its next request is allowed
+	RetryAfter time.Duration
+}
+
+// RateLimiter counts the requests of clients against rate limit policies
+type RateLimiter interface {
+	// Allow counts a request of the client identified by key, unless the policy's
+	// limit is reached within its window
+	Allow(ctx context.Context, policy RateLimitPolicy, key string) (RateLimitResult, error)
+}
+
+// slidingWindowScript counts a request in a sorted set of the request times in
+// the window, atomically. It returns whether the request is allowed, the
+// requests in the window, and the milliseconds until the oldest leaves it.
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local count = redis.call('ZCARD', key)
+if count < limit then
+	redis.call('ZADD', key, now, ARGV[4])
+	redis.call('PEXPIRE', key, window)
+	return {1, count + 1, 0}
+end
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+return {0, count, tonumber(oldest[2]) + window - now}
+`)
+
+// redisRateLimiter keeps a sliding window log per client in Redis, so that every
+// API instance counts against the same limits
+type redisRateLimiter struct {
+	client *redis.Client
+	now    func() time.Time
+}
+
+// NewRedisRateLimiter counts requests in Redis sliding windows
+func NewRedisRateLimiter(client *redis.Client) RateLimiter {
+	return &redisRateLimiter{client: client, now: time.Now}
+}
+
+func (l *redisRateLimiter) Allow(ctx context.Context, policy RateLimitPolicy, key string) (RateLimitResult, error) {
+	if !policy.Enabled() {
+		return RateLimitResult{Allowed: true}, nil
+	}
+
+	// Requests in the same millisecond need distinct members to count separately
+	nonce, err := utils.GenerateSecureToken(8)
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+	now := l.now().UnixMilli()
+	values, err := slidingWindowScript.Run(ctx, l.client, []string{rateLimitKey(policy, key)},
+		now, policy.Window.Milliseconds(), policy.Limit, strconv.FormatInt(now, 10)+"-"+nonce).Int64Slice()
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("failed to count request against %s rate limit: %w", policy.Name, err)
+	}
+	if len(values) != 3 {
+		return RateLimitResult{}, fmt.Errorf("unexpected %s rate limit reply %v", policy.Name, values)
+	}
+
+	return RateLimitResult{
+		Allowed:    values[0] == 1,
+		Limit:      policy.Limit,
+		Remaining:  max(policy.Limit-int(values[1]), 0),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
+
+// memoryRateLimiter keeps sliding window logs in process memory, for a single
+// API instance without Redis
+type memoryRateLimiter struct {
+	mu        sync.Mutex
+	requests  map[string][]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryRateLimiter counts requests in process memory
+func NewMemoryRateLimiter() RateLimiter {
+	return &memoryRateLimiter{requests: make(map[string][]time.Time), now: time.Now}
+}
+
+// memoryRateLimitSweep is how often clients without recent requests are forgotten
+const memoryRateLimitSweep = time.Minute
+
+func (l *memoryRateLimiter) Allow(ctx context.Context, policy RateLimitPolicy, key string) (RateLimitResult, error) {
+	if !policy.Enabled() {
+		return RateLimitResult{Allowed: true}, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= memoryRateLimitSweep {
+		l.sweep(now)
+	}
+
+	id := rateLimitKey(policy, key)
+	windowStart := now.Add(-policy.Window)
+	requests := l.requests[id]
+	for len(requests) > 0 && !requests[0].After(windowStart) {
+		requests = requests[1:]
+	}
+
+	if len(requests) >= policy.Limit {
+		l.requests[id] = requests
+		return RateLimitResult{
+			Limit:      policy.Limit,
+			RetryAfter: requests[0].Add(policy.Window).Sub(now),
+		}, nil
+	}
+
+	requests = append(requests, now)
+	l.requests[id] = requests
+	return RateLimitResult{
+		Allowed:   true,
+		Limit:     policy.Limit,
+		Remaining: policy.Limit - len(requests),
+	}, nil
+}
+
+// sweep drops the logs without requests in the last day, longer than any window
+// in use; the caller holds the lock
+func (l *memoryRateLimiter) sweep(now time.Time) {
+	for id, requests := range l.requests {
+		if len(requests) == 0 || now.Sub(requests[len(requests)-1]) > 24*time.Hour {
+			delete(l.requests, id)
+		}
+	}
+	l.lastSweep = now
+}
+
+func rateLimitKey(policy RateLimitPolicy, key string) string {
+	return "ratelimit:" + policy.Name + ":" + key
+}
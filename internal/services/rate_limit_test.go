@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRateLimiter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewMemoryRateLimiter().(*memoryRateLimiter)
+	limiter.now = func() time.Time { return now }
+	login := RateLimitPolicy{Name: RateLimitLogin, Limit: 3, Window: time.Minute}
+
+	allow := func(policy RateLimitPolicy, key string) RateLimitResult {
+		result, err := limiter.Allow(ctx, policy, key)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("Success - allows the limit within the window", func(t *testing.T) {
+		for i := 2; i >= 0; i-- {
+			result := allow(login, "ip:203.0.113.1")
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 3, result.Limit)
+			assert.Equal(t, i, result.Remaining)
+			now = now.Add(10 * time.Second)
+		}
+	})
+
+	t.Run("Error - throttles until the oldest request leaves the window", func(t *testing.T) {
+		result := allow(login, "ip:203.0.113.1")
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+		assert.Equal(t, 30*time.Second, result.RetryAfter)
+
+		// Throttled requests are not counted
+		now = now.Add(30 * time.Second)
+		assert.True(t, allow(login, "ip:203.0.113.1").Allowed)
+		assert.False(t, allow(login, "ip:203.0.113.1").Allowed)
+	})
+
+	t.Run("Success - clients and policies are counted separately", func(t *testing.T) {
+		assert.True(t, allow(login, "ip:203.0.113.2").Allowed)
+		assert.True(t, allow(RateLimitPolicy{Name: RateLimitPasswordReset, Limit: 1, Window: time.Hour}, "ip:203.0.113.1").Allowed)
+	})
+
+	t.Run("Success - policies without a limit allow everything", func(t *testing.T) {
+		for range 10 {
+			assert.True(t, allow(RateLimitPolicy{Name: RateLimitTracking}, "ip:203.0.113.1").Allowed)
+		}
+	})
+}
@@ -0,0 +1,371 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"net/url"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// maxRegistryItems bounds the items of a wedding's registry
+const maxRegistryItems = 20
+
+var (
+	ErrRegistryItemNotFound = errs.NotFound("registry item not found")
+	ErrInvalidRegistryItem  = errors.New("invalid registry item")
+	ErrRegistryFull         = errors.New("a registry holds at most 20 items")
+	ErrGiftPledgeNotFound   = errs.NotFound("gift pledge not found")
+	ErrGiftPledgesDisabled  = errors.New("gift pledges are disabled for this wedding")
+	ErrInvalidGiftPledge    = errors.New("invalid gift pledge")
+)
+
+// RegistryItemRequest describes a registry item
+type RegistryItemRequest struct {
+	Type          models.RegistryItemType `json:"type" validate:"required,oneof=bank_account e_wallet external_link"`
+	Label         string                  `json:"label" validate:"required,max=100"`
+	Provider      string                  `json:"provider,omitempty" validate:"omitempty,max=100"`
+	AccountName   string                  `json:"account_name,omitempty" validate:"omitempty,max=100"`
+	AccountNumber string                  `json:"account_number,omitempty" validate:"omitempty,max=50"`
+	URL           string                  `json:"url,omitempty" validate:"omitempty,url,max=500"`
+	Note          string                  `json:"note,omitempty" validate:"omitempty,max=500"`
+	Order         int                     `json:"order"`
+}
+
+// GiftPledgeRequest describes a gift a guest reports having sent
+type GiftPledgeRequest struct {
+	RegistryItemID string `json:"registry_item_id,omitempty"`
+	GuestName      string `json:"guest_name" validate:"required,max=100"`
+	GuestEmail     string `json:"guest_email,omitempty" validate:"omitempty,email"`
+	Amount         int64  `json:"amount,omitempty" validate:"gte=0"`
+	Currency       string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+	Message        string `json:"message,omitempty" validate:"omitempty,max=500"`
+	// IP is the pledger's address, kept for abuse reports
+	IP string `json:"-"`
+}
+
+// GiftPledgeStatusRequest moves a pledged gift along as the couple receives it
+type GiftPledgeStatusRequest struct {
+	Status models.GiftPledgeStatus `json:"status" validate:"required,oneof=pledged received thanked"`
+}
+
+// PublicRegistry is the gift registry shown on a wedding's public page
+type PublicRegistry struct {
+	WeddingID    models.ID              `json:"wedding_id"`
+	Items        []*models.RegistryItem `json:"items"`
+	AllowPledges bool                   `json:"allow_pledges"`
+}
+
+// RegistryService lets wedding editors list where guests can send gifts, and
+// guests report the gifts they sent so the couple can thank them
+type RegistryService struct {
+	registryRepo repository.RegistryRepository
+	weddingRepo  repository.WeddingRepository
+	logger       *zap.Logger
+}
+
+// NewRegistryService creates a new gift registry service
+func NewRegistryService(registryRepo repository.RegistryRepository, weddingRepo repository.WeddingRepository, logger *zap.Logger) *RegistryService {
+	return &RegistryService{
+		registryRepo: registryRepo,
+		weddingRepo:  weddingRepo,
+		logger:       logger,
+	}
+}
+
+// ListItems lists the items of a wedding's registry
+func (s *RegistryService) ListItems(ctx context.Context, weddingID, userID models.ID) ([]*models.RegistryItem, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+	return s.registryRepo.ListItems(ctx, weddingID)
+}
+
+// CreateItem adds an item to a wedding's registry
+func (s *RegistryService) CreateItem(ctx context.Context, weddingID, userID models.ID, req RegistryItemRequest) (*models.RegistryItem, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+	if err := validateRegistryItem(req); err != nil {
+		return nil, err
+	}
+
+	items, err := s.registryRepo.ListItems(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) >= maxRegistryItems {
+		return nil, ErrRegistryFull
+	}
+
+	item := &models.RegistryItem{WeddingID: weddingID}
+	applyRegistryItem(item, req)
+	if err := s.registryRepo.CreateItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// UpdateItem replaces the details of a registry item
+func (s *RegistryService) UpdateItem(ctx context.Context, weddingID, itemID, userID models.ID, req RegistryItemRequest) (*models.RegistryItem, error) {
+	item, err := s.getItem(ctx, weddingID, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRegistryItem(req); err != nil {
+		return nil, err
+	}
+
+	applyRegistryItem(item, req)
+	if err := s.registryRepo.UpdateItem(ctx, item); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrRegistryItemNotFound
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// DeleteItem removes an item from a wedding's registry
+func (s *RegistryService) DeleteItem(ctx context.Context, weddingID, itemID, userID models.ID) error {
+	if _, err := s.getItem(ctx, weddingID, itemID, userID); err != nil {
+		return err
+	}
+	if err := s.registryRepo.DeleteItem(ctx, itemID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrRegistryItemNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ListPledges lists the gifts guests pledged, newest first, optionally in one status
+func (s *RegistryService) ListPledges(ctx context.Context, weddingID, userID models.ID, status models.GiftPledgeStatus, page, pageSize int) ([]*models.GiftPledge, int64, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, 0, err
+	}
+	if status != "" && !isGiftPledgeStatus(status) {
+		return nil, 0, fmt.Errorf("%w: unknown status %q", ErrInvalidGiftPledge, status)
+	}
+	return s.registryRepo.ListPledges(ctx, weddingID, status, page, pageSize)
+}
+
+// UpdatePledgeStatus records that a pledged gift arrived or that the guest was thanked
+func (s *RegistryService) UpdatePledgeStatus(ctx context.Context, weddingID, pledgeID, userID models.ID, status models.GiftPledgeStatus) (*models.GiftPledge, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+	if !isGiftPledgeStatus(status) {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidGiftPledge, status)
+	}
+
+	pledge, err := s.registryRepo.GetPledge(ctx, pledgeID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrGiftPledgeNotFound
+		}
+		return nil, err
+	}
+	if pledge.WeddingID != weddingID {
+		return nil, ErrGiftPledgeNotFound
+	}
+
+	var thankedAt *time.Time
+	if status == models.GiftThanked {
+		thankedAt = pledge.ThankedAt
+		if thankedAt == nil {
+			now := time.Now()
+			thankedAt = &now
+		}
+	}
+	if err := s.registryRepo.UpdatePledgeStatus(ctx, pledge.ID, status, thankedAt); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrGiftPledgeNotFound
+		}
+		return nil, err
+	}
+	pledge.Status = status
+	pledge.ThankedAt = thankedAt
+	return pledge, nil
+}
+
+// GetPublicRegistry returns the registry of a wedding guests can see
+func (s *RegistryService) GetPublicRegistry(ctx context.Context, slug string) (*PublicRegistry, error) {
+	wedding, err := s.getPublishedWedding(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.registryRepo.ListItems(ctx, wedding.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &PublicRegistry{
+		WeddingID:    wedding.ID,
+		Items:        items,
+		AllowPledges: wedding.Registry.AllowPledges,
+	}, nil
+}
+
+// PledgeGift records a gift a guest reports having sent to a published wedding
+func (s *RegistryService) PledgeGift(ctx context.Context, slug string, req GiftPledgeRequest) (*models.GiftPledge, error) {
+	wedding, err := s.getPublishedWedding(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !wedding.Registry.AllowPledges {
+		return nil, ErrGiftPledgesDisabled
+	}
+
+	name := strings.TrimSpace(req.GuestName)
+	if name == "" {
+		return nil, fmt.Errorf("%w: guest name is required", ErrInvalidGiftPledge)
+	}
+	currency := strings.ToUpper(strings.TrimSpace(req.Currency))
+	if req.Amount > 0 && currency == "" {
+		return nil, fmt.Errorf("%w: currency is required with an amount", ErrInvalidGiftPledge)
+	}
+
+	pledge := &models.GiftPledge{
+		WeddingID:  wedding.ID,
+		GuestName:  name,
+		GuestEmail: strings.ToLower(strings.TrimSpace(req.GuestEmail)),
+		Amount:     req.Amount,
+		Message:    strings.TrimSpace(req.Message),
+		Status:     models.GiftPledged,
+		PledgerIP:  req.IP,
+	}
+	if req.Amount > 0 {
+		pledge.Currency = currency
+	}
+
+	if req.RegistryItemID != "" {
+		itemID, err := models.ParseID(req.RegistryItemID)
+		if err != nil {
+			return nil, ErrRegistryItemNotFound
+		}
+		item, err := s.registryRepo.GetItem(ctx, itemID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, ErrRegistryItemNotFound
+			}
+			return nil, err
+		}
+		if item.WeddingID != wedding.ID {
+			return nil, ErrRegistryItemNotFound
+		}
+		pledge.RegistryItemID = &item.ID
+	}
+
+	if err := s.registryRepo.CreatePledge(ctx, pledge); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Gift pledged",
+		zap.String("wedding_id", wedding.ID.String()),
+		zap.String("pledge_id", pledge.ID.String()))
+	return pledge, nil
+}
+
+// getItem returns a registry item of a wedding the user may edit
+func (s *RegistryService) getItem(ctx context.Context, weddingID, itemID, userID models.ID) (*models.RegistryItem, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	item, err := s.registryRepo.GetItem(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrRegistryItemNotFound
+		}
+		return nil, err
+	}
+	if item.WeddingID != weddingID {
+		return nil, ErrRegistryItemNotFound
+	}
+	return item, nil
+}
+
+// getPublishedWedding returns a wedding guests can see, hiding unpublished ones
+func (s *RegistryService) getPublishedWedding(ctx context.Context, slug string) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	// The wedding repository reports a missing wedding as nil
+	if wedding == nil || !wedding.IsAccessible() {
+		return nil, ErrWeddingNotFound
+	}
+	return wedding, nil
+}
+
+// getEditableWedding returns a wedding the user may manage the registry of
+func (s *RegistryService) getEditableWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	if !wedding.Can(userID, models.PermissionEditWedding) {
+		return nil, ErrUnauthorized
+	}
+	return wedding, nil
+}
+
+// validateRegistryItem checks an item has the details guests need for its type
+func validateRegistryItem(req RegistryItemRequest) error {
+	switch req.Type {
+	case models.RegistryBankAccount, models.RegistryEWallet:
+		if req.Provider == "" || req.AccountName == "" || req.AccountNumber == "" {
+			return fmt.Errorf("%w: provider, account name and account number are required", ErrInvalidRegistryItem)
+		}
+	case models.RegistryExternalLink:
+		u, err := url.Parse(req.URL)
+		if req.URL == "" || err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("%w: external links need an http or https url", ErrInvalidRegistryItem)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidRegistryItem, req.Type)
+	}
+	return nil
+}
+
+// applyRegistryItem copies the details of a request onto an item, dropping
+// those its type does not use
+func applyRegistryItem(item *models.RegistryItem, req RegistryItemRequest) {
+	item.Type = req.Type
+	item.Label = strings.TrimSpace(req.Label)
+	item.Provider = strings.TrimSpace(req.Provider)
+	item.Note = strings.TrimSpace(req.Note)
+	item.Order = req.Order
+	item.AccountName, item.AccountNumber, item.URL = "", "", ""
+	if req.Type == models.RegistryExternalLink {
+		item.URL = req.URL
+	} else {
+		item.AccountName = strings.TrimSpace(req.AccountName)
+		item.AccountNumber = strings.TrimSpace(req.AccountNumber)
+	}
+}
+
+func isGiftPledgeStatus(status models.GiftPledgeStatus) bool {
+	switch status {
+	case models.GiftPledged, models.GiftReceived, models.GiftThanked:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockRegistryRepository is an in-memory gift registry repository
+type MockRegistryRepository struct {
+	items   map[models.ID]*models.RegistryItem
+	pledges map[models.ID]*models.GiftPledge
+}
+
+func NewMockRegistryRepository() *MockRegistryRepository {
+	return &MockRegistryRepository{
+		items:   make(map[models.ID]*models.RegistryItem),
+		pledges: make(map[models.ID]*models.GiftPledge),
+	}
+}
+
+func (m *MockRegistryRepository) CreateItem(ctx context.Context, item *models.RegistryItem) error {
+	item.ID = models.NewID()
+	copied := *item
+	m.items[item.ID] = &copied
+	return nil
+}
+
+func (m *MockRegistryRepository) GetItem(ctx context.Context, id models.ID) (*models.RegistryItem, error) {
+	item, ok := m.items[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	copied := *item
+	return &copied, nil
+}
+
+func (m *MockRegistryRepository) ListItems(ctx context.Context, weddingID models.ID) ([]*models.RegistryItem, error) {
+	items := []*models.RegistryItem{}
+	for _, item := range m.items {
+		if item.WeddingID == weddingID {
+			copied := *item
+			items = append(items, &copied)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Order < items[j].Order })
+	return items, nil
+}
+
+func (m *MockRegistryRepository) UpdateItem(ctx context.Context, item *models.RegistryItem) error {
+	if _, ok := m.items[item.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	copied := *item
+	m.items[item.ID] = &copied
+	return nil
+}
+
+func (m *MockRegistryRepository) DeleteItem(ctx context.Context, id models.ID) error {
+	if _, ok := m.items[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.items, id)
+	return nil
+}
+
+func (m *MockRegistryRepository) CreatePledge(ctx context.Context, pledge *models.GiftPledge) error {
+	pledge.ID = models.NewID()
+	copied := *pledge
+	m.pledges[pledge.ID] = &copied
+	return nil
+}
+
+func (m *MockRegistryRepository) GetPledge(ctx context.Context, id models.ID) (*models.GiftPledge, error) {
+	pledge, ok := m.pledges[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	copied := *pledge
+	return &copied, nil
+}
+
+func (m *MockRegistryRepository) ListPledges(ctx context.Context, weddingID models.ID, status models.GiftPledgeStatus, page, pageSize int) ([]*models.GiftPledge, int64, error) {
+	pledges := []*models.GiftPledge{}
+	for _, pledge := range m.pledges {
+		if pledge.WeddingID == weddingID && (status == "" || pledge.Status == status) {
+			copied := *pledge
+			pledges = append(pledges, &copied)
+		}
+	}
+	return pledges, int64(len(pledges)), nil
+}
+
+func (m *MockRegistryRepository) UpdatePledgeStatus(ctx context.Context, id models.ID, status models.GiftPledgeStatus, thankedAt *time.Time) error {
+	pledge, ok := m.pledges[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	pledge.Status = status
+	pledge.ThankedAt = thankedAt
+	return nil
+}
+
+func registryTestWedding(ownerID models.ID) *models.Wedding {
+	return &models.Wedding{
+		ID:       models.NewID(),
+		UserID:   ownerID,
+		Slug:     "jane-john",
+		Status:   string(models.WeddingStatusPublished),
+		IsPublic: true,
+		Registry: models.RegistrySettings{AllowPledges: true},
+	}
+}
+
+func bankAccountRequest() RegistryItemRequest {
+	return RegistryItemRequest{
+		Type:          models.RegistryBankAccount,
+		Label:         "Wedding fund",
+		Provider:      "BCA",
+		AccountName:   "Jane Doe",
+		AccountNumber: "1234567890",
+	}
+}
+
+func TestRegistryService_CreateItem(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+	wedding := registryTestWedding(ownerID)
+
+	setup := func() (*MockRegistryRepository, *RegistryService) {
+		registry := NewMockRegistryRepository()
+		weddings := new(MockWeddingRepository)
+		weddings.On("GetByID", ctx, wedding.ID).Return(wedding, nil)
+		return registry, NewRegistryService(registry, weddings, zaptest.NewLogger(t))
+	}
+
+	t.Run("Success - external link drops account details", func(t *testing.T) {
+		registry, service := setup()
+		req := RegistryItemRequest{
+			Type:          models.RegistryExternalLink,
+			Label:         "Our registry",
+			AccountNumber: "1234",
+			URL:           "https://store.example.com/registry/jane-john",
+		}
+
+		item, err := service.CreateItem(ctx, wedding.ID, ownerID, req)
+		require.NoError(t, err)
+		assert.Empty(t, item.AccountNumber)
+		assert.Contains(t, registry.items, item.ID)
+	})
+
+	t.Run("Error - not an editor", func(t *testing.T) {
+		_, service := setup()
+		_, err := service.CreateItem(ctx, wedding.ID, models.NewID(), bankAccountRequest())
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	invalid := map[string]func(*RegistryItemRequest){
+		"bank account without number": func(r *RegistryItemRequest) { r.AccountNumber = "" },
+		"e-wallet without provider":   func(r *RegistryItemRequest) { r.Type, r.Provider = models.RegistryEWallet, "" },
+		"link without url":            func(r *RegistryItemRequest) { r.Type = models.RegistryExternalLink },
+		"link to a non http url":      func(r *RegistryItemRequest) { r.Type, r.URL = models.RegistryExternalLink, "javascript:alert(1)" },
+	}
+	for name, change := range invalid {
+		t.Run("Error - "+name, func(t *testing.T) {
+			_, service := setup()
+			req := bankAccountRequest()
+			change(&req)
+
+			_, err := service.CreateItem(ctx, wedding.ID, ownerID, req)
+			assert.ErrorIs(t, err, ErrInvalidRegistryItem)
+		})
+	}
+
+	t.Run("Error - registry full", func(t *testing.T) {
+		_, service := setup()
+		for i := 0; i < maxRegistryItems; i++ {
+			_, err := service.CreateItem(ctx, wedding.ID, ownerID, bankAccountRequest())
+			require.NoError(t, err)
+		}
+
+		_, err := service.CreateItem(ctx, wedding.ID, ownerID, bankAccountRequest())
+		assert.ErrorIs(t, err, ErrRegistryFull)
+	})
+}
+
+func TestRegistryService_ItemOfAnotherWedding(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+	wedding := registryTestWedding(ownerID)
+	registry := NewMockRegistryRepository()
+	weddings := new(MockWeddingRepository)
+	weddings.On("GetByID", ctx, wedding.ID).Return(wedding, nil)
+	service := NewRegistryService(registry, weddings, zaptest.NewLogger(t))
+
+	other := &models.RegistryItem{WeddingID: models.NewID(), Type: models.RegistryBankAccount}
+	require.NoError(t, registry.CreateItem(ctx, other))
+
+	_, err := service.UpdateItem(ctx, wedding.ID, other.ID, ownerID, bankAccountRequest())
+	assert.ErrorIs(t, err, ErrRegistryItemNotFound)
+	assert.ErrorIs(t, service.DeleteItem(ctx, wedding.ID, other.ID, ownerID), ErrRegistryItemNotFound)
+	assert.Contains(t, registry.items, other.ID)
+}
+
+func TestRegistryService_PledgeGift(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+
+	setup := func(wedding *models.Wedding) (*MockRegistryRepository, *RegistryService) {
+		registry := NewMockRegistryRepository()
+		weddings := new(MockWeddingRepository)
+		weddings.On("GetBySlug", ctx, wedding.Slug).Return(wedding, nil)
+		return registry, NewRegistryService(registry, weddings, zaptest.NewLogger(t))
+	}
+
+	t.Run("Success - cash gift through a registry item", func(t *testing.T) {
+		wedding := registryTestWedding(ownerID)
+		registry, service := setup(wedding)
+		item := &models.RegistryItem{WeddingID: wedding.ID, Type: models.RegistryBankAccount}
+		require.NoError(t, registry.CreateItem(ctx, item))
+
+		pledge, err := service.PledgeGift(ctx, wedding.Slug, GiftPledgeRequest{
+			RegistryItemID: item.ID.String(),
+			GuestName:      " Alice ",
+			Amount:         50000000,
+			Currency:       "idr",
+			IP:             "203.0.113.7",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", pledge.GuestName)
+		assert.Equal(t, "IDR", pledge.Currency)
+		assert.Equal(t, models.GiftPledged, pledge.Status)
+		assert.Equal(t, item.ID, *pledge.RegistryItemID)
+	})
+
+	t.Run("Error - pledges disabled", func(t *testing.T) {
+		wedding := registryTestWedding(ownerID)
+		wedding.Registry.AllowPledges = false
+		_, service := setup(wedding)
+
+		_, err := service.PledgeGift(ctx, wedding.Slug, GiftPledgeRequest{GuestName: "Alice"})
+		assert.ErrorIs(t, err, ErrGiftPledgesDisabled)
+	})
+
+	t.Run("Error - unpublished wedding", func(t *testing.T) {
+		wedding := registryTestWedding(ownerID)
+		wedding.Status = string(models.WeddingStatusDraft)
+		_, service := setup(wedding)
+
+		_, err := service.PledgeGift(ctx, wedding.Slug, GiftPledgeRequest{GuestName: "Alice"})
+		assert.ErrorIs(t, err, ErrWeddingNotFound)
+	})
+
+	t.Run("Error - amount without currency", func(t *testing.T) {
+		wedding := registryTestWedding(ownerID)
+		_, service := setup(wedding)
+
+		_, err := service.PledgeGift(ctx, wedding.Slug, GiftPledgeRequest{GuestName: "Alice", Amount: 100})
+		assert.ErrorIs(t, err, ErrInvalidGiftPledge)
+	})
+
+	t.Run("Error - item of another wedding", func(t *testing.T) {
+		wedding := registryTestWedding(ownerID)
+		registry, service := setup(wedding)
+		item := &models.RegistryItem{WeddingID: models.NewID(), Type: models.RegistryEWallet}
+		require.NoError(t, registry.CreateItem(ctx, item))
+
+		_, err := service.PledgeGift(ctx, wedding.Slug, GiftPledgeRequest{GuestName: "Alice", RegistryItemID: item.ID.String()})
+		assert.ErrorIs(t, err, ErrRegistryItemNotFound)
+	})
+
+	t.Run("Error - unknown wedding", func(t *testing.T) {
+		weddings := new(MockWeddingRepository)
+		weddings.On("GetBySlug", ctx, "missing").Return(nil, nil)
+		service := NewRegistryService(NewMockRegistryRepository(), weddings, zaptest.NewLogger(t))
+
+		_, err := service.GetPublicRegistry(ctx, "missing")
+		assert.ErrorIs(t, err, ErrWeddingNotFound)
+	})
+}
+
+func TestRegistryService_UpdatePledgeStatus(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+	wedding := registryTestWedding(ownerID)
+	registry := NewMockRegistryRepository()
+	weddings := new(MockWeddingRepository)
+	weddings.On("GetByID", ctx, wedding.ID).Return(wedding, nil)
+	service := NewRegistryService(registry, weddings, zaptest.NewLogger(t))
+
+	pledge := &models.GiftPledge{WeddingID: wedding.ID, GuestName: "Alice", Status: models.GiftPledged}
+	require.NoError(t, registry.CreatePledge(ctx, pledge))
+
+	updated, err := service.UpdatePledgeStatus(ctx, wedding.ID, pledge.ID, ownerID, models.GiftThanked)
+	require.NoError(t, err)
+	require.NotNil(t, updated.ThankedAt)
+	thankedAt := *updated.ThankedAt
+
+	// Thanking again keeps the original time
+	updated, err = service.UpdatePledgeStatus(ctx, wedding.ID, pledge.ID, ownerID, models.GiftThanked)
+	require.NoError(t, err)
+	assert.Equal(t, thankedAt, *updated.ThankedAt)
+
+	updated, err = service.UpdatePledgeStatus(ctx, wedding.ID, pledge.ID, ownerID, models.GiftReceived)
+	require.NoError(t, err)
+	assert.Nil(t, updated.ThankedAt)
+	assert.Nil(t, registry.pledges[pledge.ID].ThankedAt)
+
+	_, err = service.UpdatePledgeStatus(ctx, wedding.ID, pledge.ID, ownerID, "lost")
+	assert.ErrorIs(t, err, ErrInvalidGiftPledge)
+
+	_, err = service.UpdatePledgeStatus(ctx, wedding.ID, models.NewID(), ownerID, models.GiftReceived)
+	assert.ErrorIs(t, err, ErrGiftPledgeNotFound)
+}
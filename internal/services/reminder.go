@@ -0,0 +1,510 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"html/template"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+const (
+	// reminderBatchSize bounds how many campaigns are dispatched per scheduler run
+	reminderBatchSize = 10
+	// reminderLease is how long a worker owns a campaign being dispatched before another may resume it
+	reminderLease = 15 * time.Minute
+	// reminderSendTimeout bounds a single email or WhatsApp send
+	reminderSendTimeout = 15 * time.Second
+	// maxReminderTemplateLength bounds the message template of a campaign
+	maxReminderTemplateLength = 2000
+	// reminderSchedulingSlack lets clients schedule "now" despite clock drift
+	reminderSchedulingSlack = time.Minute
+	// defaultReminderSubject is the subject of reminder emails without one
+	defaultReminderSubject = "Reminder: please RSVP to {wedding_title}"
+)
+
+var (
+	ErrInvalidReminderChannel   = errors.New("reminder channel must be email or whatsapp")
+	ErrInvalidReminderTemplate  = errors.New("reminder template is required and at most 2000 characters")
+	ErrReminderInPast           = errors.New("reminder send date is in the past")
+	ErrReminderCampaignNotFound = errs.NotFound("reminder campaign not found")
+	ErrReminderCampaignStarted  = errors.New("reminder campaign has already been sent")
+)
+
+// RSVPLinker builds a guest's personalized link to the RSVP form
+type RSVPLinker interface {
+	RSVPLink(wedding *models.Wedding, guest *models.Guest) string
+}
+
+// CreateReminderCampaignRequest schedules a reminder to the guests who have not RSVP'd yet
+type CreateReminderCampaignRequest struct {
+	Channel models.ReminderChannel
+	// Subject of reminder emails; defaults to a reminder to RSVP
+	Subject string
+	// Template is the message. {first_name}, {last_name}, {wedding_title}, {rsvp_link}
+	// and {rsvp_deadline} are replaced for each guest.
+	Template string
+	// SendAt defaults to now
+	SendAt *time.Time
+}
+
+// ReminderCampaignReport is a campaign with the opens and responses attributed to it
+type ReminderCampaignReport struct {
+	*models.ReminderCampaign
+	Stats models.ReminderDeliveryStats `json:"stats"`
+	// OpenRate is the percentage of sent reminders that were opened. Only emails
+	// report opens, and mail clients blocking images hide some of them.
+	OpenRate float64 `json:"open_rate"`
+	// ResponseRate is the percentage of sent reminders whose guest RSVP'd afterwards
+	ResponseRate float64 `json:"response_rate"`
+}
+
+// ReminderService sends scheduled reminders to the guests of a wedding whose RSVP is
+// still pending and reports how many of them opened the reminder and answered.
+// Answers are RSVPs submitted after the reminder with the guest's email or phone.
+type ReminderService struct {
+	reminderRepo repository.ReminderRepository
+	guestRepo    repository.GuestRepository
+	weddingRepo  repository.WeddingRepository
+	rsvpRepo     repository.RSVPRepository
+	email        EmailService
+	whatsapp     WhatsAppService
+	links        RSVPLinker
+	trackingURL  string
+	logger       *zap.Logger
+}
+
+// NewReminderService creates a new reminder service. trackingURL is the public API
+// base URL (e.g. https://api.example.com/api/v1) that email open pixels point to.
+func NewReminderService(
+	reminderRepo repository.ReminderRepository,
+	guestRepo repository.GuestRepository,
+	weddingRepo repository.WeddingRepository,
+	rsvpRepo repository.RSVPRepository,
+	email EmailService,
+	whatsapp WhatsAppService,
+	links RSVPLinker,
+	trackingURL string,
+	logger *zap.Logger,
+) *ReminderService {
+	return &ReminderService{
+		reminderRepo: reminderRepo,
+		guestRepo:    guestRepo,
+		weddingRepo:  weddingRepo,
+		rsvpRepo:     rsvpRepo,
+		email:        email,
+		whatsapp:     whatsapp,
+		links:        links,
+		trackingURL:  strings.TrimRight(trackingURL, "/"),
+		logger:       logger,
+	}
+}
+
+// CreateCampaign schedules a reminder campaign for a wedding the user manages guests of
+func (s *ReminderService) CreateCampaign(ctx context.Context, weddingID, userID models.ID, req CreateReminderCampaignRequest) (*models.ReminderCampaign, error) {
+	if _, err := s.getManagedWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	if !req.Channel.IsValid() {
+		return nil, ErrInvalidReminderChannel
+	}
+	tmpl := strings.TrimSpace(req.Template)
+	if tmpl == "" || utf8.RuneCountInString(tmpl) > maxReminderTemplateLength {
+		return nil, ErrInvalidReminderTemplate
+	}
+
+	now := time.Now()
+	sendAt := now
+	if req.SendAt != nil {
+		if req.SendAt.Before(now.Add(-reminderSchedulingSlack)) {
+			return nil, ErrReminderInPast
+		}
+		sendAt = req.SendAt.UTC()
+	}
+
+	campaign := &models.ReminderCampaign{
+		WeddingID: weddingID,
+		CreatedBy: userID,
+		Channel:   req.Channel,
+		Template:  tmpl,
+		SendAt:    sendAt,
+		Status:    models.ReminderCampaignScheduled,
+	}
+	if req.Channel == models.ReminderChannelEmail {
+		campaign.Subject = strings.TrimSpace(req.Subject)
+		if campaign.Subject == "" {
+			campaign.Subject = defaultReminderSubject
+		}
+	}
+
+	if err := s.reminderRepo.CreateCampaign(ctx, campaign); err != nil {
+		return nil, fmt.Errorf("failed to create reminder campaign: %w", err)
+	}
+	return campaign, nil
+}
+
+// ListCampaigns returns the campaigns of a wedding with their reports
+func (s *ReminderService) ListCampaigns(ctx context.Context, weddingID, userID models.ID) ([]*ReminderCampaignReport, error) {
+	if _, err := s.getManagedWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	campaigns, err := s.reminderRepo.ListCampaigns(ctx, weddingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminder campaigns: %w", err)
+	}
+
+	reports := make([]*ReminderCampaignReport, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		report, err := s.report(ctx, campaign)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// GetCampaignReport returns a campaign with the opens and responses attributed to it
+func (s *ReminderService) GetCampaignReport(ctx context.Context, weddingID, campaignID, userID models.ID) (*ReminderCampaignReport, error) {
+	campaign, err := s.getCampaign(ctx, weddingID, campaignID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.report(ctx, campaign)
+}
+
+// CancelCampaign cancels a campaign that has not been sent yet
+func (s *ReminderService) CancelCampaign(ctx context.Context, weddingID, campaignID, userID models.ID) error {
+	if _, err := s.getCampaign(ctx, weddingID, campaignID, userID); err != nil {
+		return err
+	}
+
+	if err := s.reminderRepo.CancelCampaign(ctx, campaignID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrReminderCampaignStarted
+		}
+		return fmt.Errorf("failed to cancel reminder campaign: %w", err)
+	}
+	return nil
+}
+
+// TrackOpen records that a reminder email was opened
+func (s *ReminderService) TrackOpen(ctx context.Context, deliveryID models.ID) error {
+	return s.reminderRepo.MarkDeliveryOpened(ctx, deliveryID, time.Now())
+}
+
+// TrackRSVP attributes a new RSVP to the reminders its guest received. It never fails
+// the RSVP; errors are only logged.
+func (s *ReminderService) TrackRSVP(ctx context.Context, rsvp *models.RSVP) {
+	if rsvp.Email == "" && rsvp.Phone == "" {
+		return
+	}
+	if _, err := s.reminderRepo.MarkResponded(ctx, rsvp.WeddingID, rsvp.Email, rsvp.Phone, rsvp.SubmittedAt); err != nil {
+		s.logger.Warn("Failed to attribute RSVP to reminders", zap.Error(err), zap.String("rsvp_id", rsvp.ID.String()))
+	}
+}
+
+// DispatchDue sends every campaign whose send date has passed and returns how many were dispatched
+func (s *ReminderService) DispatchDue(ctx context.Context, now time.Time) (int, error) {
+	dispatched := 0
+	for dispatched < reminderBatchSize {
+		if ctx.Err() != nil {
+			return dispatched, ctx.Err()
+		}
+
+		campaign, err := s.reminderRepo.ClaimDueCampaign(ctx, now, reminderLease)
+		if errors.Is(err, repository.ErrNotFound) {
+			return dispatched, nil
+		}
+		if err != nil {
+			return dispatched, fmt.Errorf("failed to claim reminder campaign: %w", err)
+		}
+
+		if err := s.dispatch(ctx, campaign); err != nil {
+			// The lease expires and a later run resumes where this one stopped
+			s.logger.Error("Failed to dispatch reminder campaign", zap.Error(err), zap.String("campaign_id", campaign.ID.String()))
+			continue
+		}
+		dispatched++
+	}
+	return dispatched, nil
+}
+
+// dispatch sends the campaign to every pending guest it has not reached yet and marks it sent
+func (s *ReminderService) dispatch(ctx context.Context, campaign *models.ReminderCampaign) error {
+	wedding, err := s.weddingRepo.GetByID(ctx, campaign.WeddingID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("failed to get wedding: %w", err)
+	}
+
+	skipped := 0
+	// A deleted wedding has no guests left to remind; the campaign completes empty
+	if wedding != nil {
+		filters := repository.GuestFilters{RSVPStatus: "pending"}
+		err = s.guestRepo.StreamByWedding(ctx, wedding.ID, filters, func(guest *models.Guest) error {
+			sent, err := s.remind(ctx, campaign, wedding, guest)
+			if err != nil {
+				return err
+			}
+			if !sent {
+				skipped++
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Count from the stored deliveries so a resumed dispatch includes the earlier run
+	stats, err := s.reminderRepo.DeliveryStats(ctx, campaign.ID)
+	if err != nil {
+		return err
+	}
+	completedAt := time.Now()
+	campaign.Status = models.ReminderCampaignSent
+	campaign.Sent = int(stats.Sent)
+	campaign.Failed = int(stats.Failed)
+	campaign.Recipients = campaign.Sent + campaign.Failed
+	campaign.Skipped = skipped
+	campaign.CompletedAt = &completedAt
+	return s.reminderRepo.FinishCampaign(ctx, campaign)
+}
+
+// remind sends the campaign to one guest and records the delivery. Guests the campaign
+// already reached, without an address for the channel or who already RSVP'd are
+// skipped, which is reported as false.
+func (s *ReminderService) remind(ctx context.Context, campaign *models.ReminderCampaign, wedding *models.Wedding, guest *models.Guest) (bool, error) {
+	delivered, err := s.reminderRepo.HasDelivery(ctx, campaign.ID, guest.ID)
+	if err != nil {
+		return false, err
+	}
+	if delivered {
+		return true, nil
+	}
+
+	if campaign.Channel == models.ReminderChannelEmail && guest.Email == "" ||
+		campaign.Channel == models.ReminderChannelWhatsApp && guest.Phone == "" {
+		return false, nil
+	}
+	// RSVPs are not linked to guests, so guests marked pending may have answered already
+	if guest.Email != "" {
+		if rsvp, _ := s.rsvpRepo.GetByEmail(ctx, wedding.ID, guest.Email); rsvp != nil {
+			return false, nil
+		}
+	}
+
+	delivery := &models.ReminderDelivery{
+		ID:         models.NewID(),
+		CampaignID: campaign.ID,
+		WeddingID:  wedding.ID,
+		GuestID:    guest.ID,
+		Channel:    campaign.Channel,
+		Email:      guest.Email,
+		Phone:      guest.Phone,
+		Status:     models.ReminderDeliverySent,
+		SentAt:     time.Now(),
+	}
+
+	if err := s.send(ctx, campaign, wedding, guest, delivery.ID); err != nil {
+		s.logger.Warn("Failed to send reminder", zap.Error(err),
+			zap.String("campaign_id", campaign.ID.String()),
+			zap.String("guest_id", guest.ID.String()))
+		delivery.Status = models.ReminderDeliveryFailed
+		delivery.Error = err.Error()
+	}
+
+	if err := s.reminderRepo.CreateDelivery(ctx, delivery); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *ReminderService) send(ctx context.Context, campaign *models.ReminderCampaign, wedding *models.Wedding, guest *models.Guest, deliveryID models.ID) error {
+	rsvpLink := s.links.RSVPLink(wedding, guest)
+	body := renderReminderTemplate(campaign.Template, wedding, guest, rsvpLink)
+
+	sendCtx, cancel := context.WithTimeout(ctx, reminderSendTimeout)
+	defer cancel()
+
+	switch campaign.Channel {
+	case models.ReminderChannelWhatsApp:
+		return s.whatsapp.Send(sendCtx, &WhatsAppMessage{To: guest.Phone, Text: body})
+	default:
+		msg, err := renderReminderEmail(body, rsvpLink, s.openPixelURL(deliveryID))
+		if err != nil {
+			return err
+		}
+		msg.To = guest.Email
+		msg.Subject = renderReminderTemplate(campaign.Subject, wedding, guest, rsvpLink)
+		return s.email.Send(sendCtx, msg)
+	}
+}
+
+// openPixelURL is the tracking image that records when a reminder email is opened
+func (s *ReminderService) openPixelURL(deliveryID models.ID) string {
+	return fmt.Sprintf("%s/public/reminders/%s/open", s.trackingURL, deliveryID.String())
+}
+
+func (s *ReminderService) report(ctx context.Context, campaign *models.ReminderCampaign) (*ReminderCampaignReport, error) {
+	stats, err := s.reminderRepo.DeliveryStats(ctx, campaign.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reminder delivery stats: %w", err)
+	}
+
+	report := &ReminderCampaignReport{ReminderCampaign: campaign, Stats: *stats}
+	if stats.Sent > 0 {
+		report.OpenRate = float64(stats.Opened) / float64(stats.Sent) * 100
+		report.ResponseRate = float64(stats.Responded) / float64(stats.Sent) * 100
+	}
+	return report, nil
+}
+
+func (s *ReminderService) getCampaign(ctx context.Context, weddingID, campaignID, userID models.ID) (*models.ReminderCampaign, error) {
+	if _, err := s.getManagedWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	campaign, err := s.reminderRepo.GetCampaign(ctx, campaignID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrReminderCampaignNotFound
+		}
+		return nil, fmt.Errorf("failed to get reminder campaign: %w", err)
+	}
+	if campaign.WeddingID != weddingID {
+		return nil, ErrReminderCampaignNotFound
+	}
+	return campaign, nil
+}
+
+func (s *ReminderService) getManagedWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	if !wedding.Can(userID, models.PermissionManageGuests) {
+		return nil, ErrUnauthorized
+	}
+	return wedding, nil
+}
+
+// renderReminderTemplate fills in the placeholders of a reminder template for a guest
+func renderReminderTemplate(tmpl string, wedding *models.Wedding, guest *models.Guest, rsvpLink string) string {
+	deadline := ""
+	if wedding.RSVP.Deadline != nil {
+		deadline = wedding.RSVP.Deadline.Format("January 2, 2006")
+	}
+	return strings.NewReplacer(
+		"{first_name}", guest.FirstName,
+		"{last_name}", guest.LastName,
+		"{wedding_title}", wedding.Title,
+		"{rsvp_link}", rsvpLink,
+		"{rsvp_deadline}", deadline,
+	).Replace(tmpl)
+}
+
+var reminderHTML = template.Must(template.New("reminder").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #333;">
+  {{range .Paragraphs}}<p>{{.}}</p>
+  {{end}}
+  <p><a href="{{.RSVPLink}}" style="display: inline-block; padding: 10px 20px; background: #333; color: #fff; text-decoration: none;">RSVP now</a></p>
+  <img src="{{.PixelURL}}" width="1" height="1" alt="" style="display: none;">
+</body>
+</html>`))
+
+// renderReminderEmail wraps a rendered reminder in an email with an RSVP button and an open tracking pixel
+func renderReminderEmail(body, rsvpLink, pixelURL string) (*EmailMessage, error) {
+	view := struct {
+		Paragraphs []string
+		RSVPLink   string
+		PixelURL   string
+	}{RSVPLink: rsvpLink, PixelURL: pixelURL}
+	for _, paragraph := range strings.Split(body, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			view.Paragraphs = append(view.Paragraphs, paragraph)
+		}
+	}
+
+	var html bytes.Buffer
+	if err := reminderHTML.Execute(&html, view); err != nil {
+		return nil, fmt.Errorf("failed to render reminder: %w", err)
+	}
+
+	text := body
+	if !strings.Contains(body, rsvpLink) {
+		text += "\n\nRSVP: " + rsvpLink
+	}
+	return &EmailMessage{HTML: html.String(), Text: text}, nil
+}
+
+// ReminderScheduler periodically dispatches due reminder campaigns
+type ReminderScheduler struct {
+	service  *ReminderService
+	interval time.Duration
+	logger   *zap.Logger
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewReminderScheduler creates a scheduler that checks for due campaigns every interval
+func NewReminderScheduler(service *ReminderService, interval time.Duration, logger *zap.Logger) *ReminderScheduler {
+	return &ReminderScheduler{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start runs the scheduler loop in the background
+func (sch *ReminderScheduler) Start(ctx context.Context) {
+	sch.wg.Add(1)
+	go func() {
+		defer sch.wg.Done()
+		ticker := time.NewTicker(sch.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sch.stop:
+				return
+			case now := <-ticker.C:
+				dispatched, err := sch.service.DispatchDue(ctx, now)
+				if err != nil {
+					sch.logger.Error("Reminder run failed", zap.Error(err))
+					continue
+				}
+				if dispatched > 0 {
+					sch.logger.Info("Reminder campaigns dispatched", zap.Int("count", dispatched))
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the scheduler loop to exit and waits for it
+func (sch *ReminderScheduler) Stop() {
+	close(sch.stop)
+	sch.wg.Wait()
+}
@@ -0,0 +1,450 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockReminderRepository is an in-memory reminder campaign repository
+type MockReminderRepository struct {
+	campaigns  map[models.ID]*models.ReminderCampaign
+	deliveries []*models.ReminderDelivery
+}
+
+func NewMockReminderRepository() *MockReminderRepository {
+	return &MockReminderRepository{campaigns: make(map[models.ID]*models.ReminderCampaign)}
+}
+
+func (m *MockReminderRepository) CreateCampaign(ctx context.Context, campaign *models.ReminderCampaign) error {
+	if campaign.ID.IsZero() {
+		campaign.ID = models.NewID()
+	}
+	m.campaigns[campaign.ID] = campaign
+	return nil
+}
+
+func (m *MockReminderRepository) GetCampaign(ctx context.Context, id models.ID) (*models.ReminderCampaign, error) {
+	campaign, exists := m.campaigns[id]
+	if !exists {
+		return nil, repository.ErrNotFound
+	}
+	return campaign, nil
+}
+
+func (m *MockReminderRepository) ListCampaigns(ctx context.Context, weddingID models.ID) ([]*models.ReminderCampaign, error) {
+	var campaigns []*models.ReminderCampaign
+	for _, campaign := range m.campaigns {
+		if campaign.WeddingID == weddingID {
+			campaigns = append(campaigns, campaign)
+		}
+	}
+	return campaigns, nil
+}
+
+func (m *MockReminderRepository) CancelCampaign(ctx context.Context, id models.ID) error {
+	campaign, exists := m.campaigns[id]
+	if !exists || campaign.Status != models.ReminderCampaignScheduled {
+		return repository.ErrNotFound
+	}
+	campaign.Status = models.ReminderCampaignCancelled
+	return nil
+}
+
+func (m *MockReminderRepository) ClaimDueCampaign(ctx context.Context, now time.Time, lease time.Duration) (*models.ReminderCampaign, error) {
+	for _, campaign := range m.campaigns {
+		if campaign.Status == models.ReminderCampaignScheduled && !campaign.SendAt.After(now) {
+			campaign.Status = models.ReminderCampaignSending
+			lockedUntil := now.Add(lease)
+			campaign.LockedUntil = &lockedUntil
+			return campaign, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *MockReminderRepository) FinishCampaign(ctx context.Context, campaign *models.ReminderCampaign) error {
+	m.campaigns[campaign.ID] = campaign
+	return nil
+}
+
+func (m *MockReminderRepository) CreateDelivery(ctx context.Context, delivery *models.ReminderDelivery) error {
+	m.deliveries = append(m.deliveries, delivery)
+	return nil
+}
+
+func (m *MockReminderRepository) HasDelivery(ctx context.Context, campaignID, guestID models.ID) (bool, error) {
+	for _, delivery := range m.deliveries {
+		if delivery.CampaignID == campaignID && delivery.GuestID == guestID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockReminderRepository) MarkDeliveryOpened(ctx context.Context, id models.ID, openedAt time.Time) error {
+	for _, delivery := range m.deliveries {
+		if delivery.ID == id && delivery.OpenedAt == nil {
+			delivery.OpenedAt = &openedAt
+		}
+	}
+	return nil
+}
+
+func (m *MockReminderRepository) MarkResponded(ctx context.Context, weddingID models.ID, email, phone string, respondedAt time.Time) (int64, error) {
+	var marked int64
+	for _, delivery := range m.deliveries {
+		matches := email != "" && strings.EqualFold(delivery.Email, email) || phone != "" && delivery.Phone == phone
+		if delivery.WeddingID == weddingID && matches && delivery.Status == models.ReminderDeliverySent &&
+			!delivery.SentAt.After(respondedAt) && delivery.RespondedAt == nil {
+			delivery.RespondedAt = &respondedAt
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+func (m *MockReminderRepository) DeliveryStats(ctx context.Context, campaignID models.ID) (*models.ReminderDeliveryStats, error) {
+	stats := &models.ReminderDeliveryStats{}
+	for _, delivery := range m.deliveries {
+		if delivery.CampaignID != campaignID {
+			continue
+		}
+		switch delivery.Status {
+		case models.ReminderDeliverySent:
+			stats.Sent++
+		case models.ReminderDeliveryFailed:
+			stats.Failed++
+		}
+		if delivery.OpenedAt != nil {
+			stats.Opened++
+		}
+		if delivery.RespondedAt != nil {
+			stats.Responded++
+		}
+	}
+	return stats, nil
+}
+
+// MockWhatsAppService records sent WhatsApp messages
+type MockWhatsAppService struct {
+	sent      []*WhatsAppMessage
+	templates []*TemplateMessage
+	err       error
+}
+
+func (m *MockWhatsAppService) Send(ctx context.Context, msg *WhatsAppMessage) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func (m *MockWhatsAppService) SendTemplate(ctx context.Context, msg *TemplateMessage) (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	m.templates = append(m.templates, msg)
+	return fmt.Sprintf("wamid.%d", len(m.templates)), nil
+}
+
+type reminderTestEnv struct {
+	service   *ReminderService
+	reminders *MockReminderRepository
+	guests    *MockGuestRepository
+	rsvps     *MockRSVPRepository
+	email     *MockEmailService
+	whatsapp  *MockWhatsAppService
+	wedding   *models.Wedding
+}
+
+func setupReminderService(t *testing.T) *reminderTestEnv {
+	env := &reminderTestEnv{
+		reminders: NewMockReminderRepository(),
+		guests:    NewMockGuestRepository(),
+		rsvps:     NewMockRSVPRepository(),
+		email:     &MockEmailService{},
+		whatsapp:  &MockWhatsAppService{},
+	}
+
+	deadline := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	env.wedding = &models.Wedding{
+		ID:     models.NewID(),
+		UserID: models.NewID(),
+		Title:  "Alice & Bob",
+		Slug:   "alice-and-bob",
+		RSVP:   models.RSVPSettings{Deadline: &deadline},
+	}
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, env.wedding.ID).Return(env.wedding, nil)
+
+	links := NewGuestQRService(env.guests, weddingRepo, "link-secret", "https://example.com")
+	env.service = NewReminderService(env.reminders, env.guests, weddingRepo, env.rsvps, env.email, env.whatsapp,
+		links, "https://api.example.com/api/v1/", zap.NewNop())
+	return env
+}
+
+func (env *reminderTestEnv) addGuest(t *testing.T, firstName, email, phone, status string) *models.Guest {
+	guest := &models.Guest{
+		WeddingID:  env.wedding.ID,
+		FirstName:  firstName,
+		LastName:   "Guest",
+		Email:      email,
+		Phone:      phone,
+		RSVPStatus: status,
+	}
+	require.NoError(t, env.guests.Create(context.Background(), guest))
+	return guest
+}
+
+func TestReminderService_CreateCampaign(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - defaults to now with a reminder subject", func(t *testing.T) {
+		env := setupReminderService(t)
+
+		campaign, err := env.service.CreateCampaign(ctx, env.wedding.ID, env.wedding.UserID, CreateReminderCampaignRequest{
+			Channel:  models.ReminderChannelEmail,
+			Template: " Hi {first_name}, please RSVP: {rsvp_link} ",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.ReminderCampaignScheduled, campaign.Status)
+		assert.Equal(t, defaultReminderSubject, campaign.Subject)
+		assert.Equal(t, "Hi {first_name}, please RSVP: {rsvp_link}", campaign.Template)
+		assert.WithinDuration(t, time.Now(), campaign.SendAt, time.Minute)
+	})
+
+	t.Run("Error - invalid requests", func(t *testing.T) {
+		env := setupReminderService(t)
+		past := time.Now().Add(-time.Hour)
+
+		_, err := env.service.CreateCampaign(ctx, env.wedding.ID, env.wedding.UserID, CreateReminderCampaignRequest{Channel: "sms", Template: "Hi"})
+		assert.ErrorIs(t, err, ErrInvalidReminderChannel)
+
+		_, err = env.service.CreateCampaign(ctx, env.wedding.ID, env.wedding.UserID, CreateReminderCampaignRequest{Channel: models.ReminderChannelWhatsApp, Template: "  "})
+		assert.ErrorIs(t, err, ErrInvalidReminderTemplate)
+
+		_, err = env.service.CreateCampaign(ctx, env.wedding.ID, env.wedding.UserID, CreateReminderCampaignRequest{Channel: models.ReminderChannelWhatsApp, Template: "Hi", SendAt: &past})
+		assert.ErrorIs(t, err, ErrReminderInPast)
+	})
+
+	t.Run("Error - not a manager of the wedding", func(t *testing.T) {
+		env := setupReminderService(t)
+
+		_, err := env.service.CreateCampaign(ctx, env.wedding.ID, models.NewID(), CreateReminderCampaignRequest{Channel: models.ReminderChannelEmail, Template: "Hi"})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+}
+
+func TestReminderService_DispatchDue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Email reminders reach pending guests only", func(t *testing.T) {
+		env := setupReminderService(t)
+		pending := env.addGuest(t, "Carol", "carol@example.com", "", "pending")
+		env.addGuest(t, "Dave", "dave@example.com", "", "attending")
+		env.addGuest(t, "Erin", "", "+62 812 0000", "pending")
+		answered := env.addGuest(t, "Frank", "frank@example.com", "", "pending")
+		require.NoError(t, env.rsvps.Create(ctx, &models.RSVP{ID: models.NewID(), WeddingID: env.wedding.ID, Email: answered.Email}))
+
+		campaign, err := env.service.CreateCampaign(ctx, env.wedding.ID, env.wedding.UserID, CreateReminderCampaignRequest{
+			Channel:  models.ReminderChannelEmail,
+			Subject:  "{wedding_title} needs your answer",
+			Template: "Hi {first_name} <3,\n\nplease RSVP by {rsvp_deadline}.",
+		})
+		require.NoError(t, err)
+
+		dispatched, err := env.service.DispatchDue(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, dispatched)
+
+		require.Len(t, env.email.sent, 1)
+		msg := env.email.sent[0]
+		assert.Equal(t, pending.Email, msg.To)
+		assert.Equal(t, "Alice & Bob needs your answer", msg.Subject)
+		assert.Contains(t, msg.Text, "please RSVP by June 1, 2026.")
+		assert.Contains(t, msg.Text, "RSVP: https://example.com/w/alice-and-bob?t=")
+		assert.Contains(t, msg.HTML, "Hi Carol &lt;3,")
+
+		require.Len(t, env.reminders.deliveries, 1)
+		delivery := env.reminders.deliveries[0]
+		assert.Contains(t, msg.HTML, "https://api.example.com/api/v1/public/reminders/"+delivery.ID.String()+"/open")
+
+		finished := env.reminders.campaigns[campaign.ID]
+		assert.Equal(t, models.ReminderCampaignSent, finished.Status)
+		assert.Equal(t, 1, finished.Sent)
+		assert.Equal(t, 2, finished.Skipped)
+		assert.NotNil(t, finished.CompletedAt)
+	})
+
+	t.Run("WhatsApp failures are recorded", func(t *testing.T) {
+		env := setupReminderService(t)
+		env.addGuest(t, "Erin", "", "+62 812 0000", "pending")
+		env.whatsapp.err = errors.New("provider down")
+
+		campaign, err := env.service.CreateCampaign(ctx, env.wedding.ID, env.wedding.UserID, CreateReminderCampaignRequest{
+			Channel:  models.ReminderChannelWhatsApp,
+			Template: "Hi {first_name}, RSVP at {rsvp_link}",
+		})
+		require.NoError(t, err)
+
+		_, err = env.service.DispatchDue(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+
+		require.Len(t, env.reminders.deliveries, 1)
+		assert.Equal(t, models.ReminderDeliveryFailed, env.reminders.deliveries[0].Status)
+		assert.Equal(t, "provider down", env.reminders.deliveries[0].Error)
+		assert.Equal(t, 1, env.reminders.campaigns[campaign.ID].Failed)
+	})
+
+	t.Run("Future campaigns wait", func(t *testing.T) {
+		env := setupReminderService(t)
+		env.addGuest(t, "Carol", "carol@example.com", "", "pending")
+		sendAt := time.Now().Add(24 * time.Hour)
+
+		_, err := env.service.CreateCampaign(ctx, env.wedding.ID, env.wedding.UserID, CreateReminderCampaignRequest{
+			Channel: models.ReminderChannelEmail, Template: "Hi", SendAt: &sendAt,
+		})
+		require.NoError(t, err)
+
+		dispatched, err := env.service.DispatchDue(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Zero(t, dispatched)
+		assert.Empty(t, env.email.sent)
+	})
+}
+
+func TestReminderService_Report(t *testing.T) {
+	ctx := context.Background()
+	env := setupReminderService(t)
+	carol := env.addGuest(t, "Carol", "carol@example.com", "", "pending")
+	env.addGuest(t, "Dave", "dave@example.com", "", "pending")
+
+	campaign, err := env.service.CreateCampaign(ctx, env.wedding.ID, env.wedding.UserID, CreateReminderCampaignRequest{
+		Channel: models.ReminderChannelEmail, Template: "Hi {first_name}",
+	})
+	require.NoError(t, err)
+	_, err = env.service.DispatchDue(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	for _, delivery := range env.reminders.deliveries {
+		if delivery.GuestID == carol.ID {
+			require.NoError(t, env.service.TrackOpen(ctx, delivery.ID))
+		}
+	}
+	env.service.TrackRSVP(ctx, &models.RSVP{WeddingID: env.wedding.ID, Email: "Carol@Example.com", SubmittedAt: time.Now()})
+
+	report, err := env.service.GetCampaignReport(ctx, env.wedding.ID, campaign.ID, env.wedding.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReminderDeliveryStats{Sent: 2, Opened: 1, Responded: 1}, report.Stats)
+	assert.Equal(t, 50.0, report.OpenRate)
+	assert.Equal(t, 50.0, report.ResponseRate)
+}
+
+func TestReminderService_CancelCampaign(t *testing.T) {
+	ctx := context.Background()
+	env := setupReminderService(t)
+	sendAt := time.Now().Add(time.Hour)
+
+	campaign, err := env.service.CreateCampaign(ctx, env.wedding.ID, env.wedding.UserID, CreateReminderCampaignRequest{
+		Channel: models.ReminderChannelEmail, Template: "Hi", SendAt: &sendAt,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, env.service.CancelCampaign(ctx, env.wedding.ID, campaign.ID, env.wedding.UserID))
+	assert.Equal(t, models.ReminderCampaignCancelled, campaign.Status)
+
+	err = env.service.CancelCampaign(ctx, env.wedding.ID, campaign.ID, env.wedding.UserID)
+	assert.ErrorIs(t, err, ErrReminderCampaignStarted)
+
+	err = env.service.CancelCampaign(ctx, env.wedding.ID, models.NewID(), env.wedding.UserID)
+	assert.ErrorIs(t, err, ErrReminderCampaignNotFound)
+}
+
+func TestCloudWhatsAppService_Send(t *testing.T) {
+	var received whatsAppRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	service := NewCloudWhatsAppService("token", "12345")
+	service.endpoint = server.URL
+
+	err := service.Send(context.Background(), &WhatsAppMessage{To: "+62 812-3456", Text: "Hi"})
+	require.NoError(t, err)
+	assert.Equal(t, "628123456", received.To)
+	assert.Equal(t, "whatsapp", received.MessagingProduct)
+	assert.Equal(t, "Hi", received.Text.Body)
+}
+
+func TestCloudWhatsAppService_SendTemplate(t *testing.T) {
+	var received whatsAppRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Write([]byte(`{"messaging_product":"whatsapp","messages":[{"id":"wamid.ABC"}]}`))
+	}))
+	defer server.Close()
+
+	service := NewCloudWhatsAppService("token", "12345")
+	service.endpoint = server.URL
+
+	id, err := service.SendTemplate(context.Background(), &TemplateMessage{
+		To:       "+62 812-3456",
+		Template: MessageTemplate{Name: "wedding_invitation", Language: "id"},
+		Params:   []string{"Carol", "https://example.com/w/a"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.ABC", id)
+	assert.Equal(t, "template", received.Type)
+	require.NotNil(t, received.Template)
+	assert.Equal(t, "id", received.Template.Language.Code)
+	require.Len(t, received.Template.Components, 1)
+	assert.Equal(t, "https://example.com/w/a", received.Template.Components[0].Parameters[1].Text)
+}
+
+func TestParseWhatsAppStatuses(t *testing.T) {
+	body := []byte(`{"object":"whatsapp_business_account","entry":[{"changes":[{"field":"messages","value":{
+		"statuses":[
+			{"id":"wamid.1","status":"delivered","timestamp":"1780000000","recipient_id":"628123456"},
+			{"id":"wamid.2","status":"failed","timestamp":"1780000001","errors":[{"code":131026,"title":"Message undeliverable"}]}
+		]}}]}]}`)
+
+	statuses, err := ParseWhatsAppStatuses(body)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, MessageStatus{MessageID: "wamid.1", Status: MessageStatusDelivered, Timestamp: time.Unix(1780000000, 0).UTC()}, statuses[0])
+	assert.Equal(t, "Message undeliverable", statuses[1].Reason)
+
+	assert.True(t, VerifyWhatsAppSignature("app-secret", body, "sha256="+hmacHex("app-secret", body)))
+	assert.False(t, VerifyWhatsAppSignature("other-secret", body, "sha256="+hmacHex("app-secret", body)))
+
+	_, err = ParseWhatsAppStatuses([]byte("nope"))
+	assert.Error(t, err)
+}
+
+func hmacHex(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+var (
+	ErrRetentionOverrideNotFound = errs.NotFound("retention override not found")
+	ErrUnknownRetentionDataType  = errs.InvalidField("days", "data types must be page_views, rsvp_events, conversions or audit_logs")
+	ErrInvalidRetentionDays      = errs.InvalidField("days", "retention periods must be at least 1 day")
+	// ErrRetentionOverrideTooLong is returned for an override keeping data longer
+	// than the platform does, which TTL indexes and the job would not honor
+	ErrRetentionOverrideTooLong = errs.InvalidField("days", "an override can only shorten the platform's retention period")
+)
+
+// RetentionService deletes analytics events and audit logs once they are older
+// than their retention period, and manages the overrides shortening the periods
+// of single weddings
+type RetentionService struct {
+	repo        repository.RetentionRepository
+	weddingRepo repository.WeddingRepository
+	// periods are the platform's periods by data type; 0 keeps the data forever
+	periods    map[string]time.Duration
+	ttlIndexes bool
+	logger     *zap.Logger
+}
+
+// NewRetentionService creates a retention service with the platform's periods
+// by data type. With ttlIndexes, MongoDB expires the raw analytics events past
+// their platform period and the job only applies the overrides to them.
+func NewRetentionService(repo repository.RetentionRepository, weddingRepo repository.WeddingRepository, periods map[string]time.Duration, ttlIndexes bool, logger *zap.Logger) *RetentionService {
+	return &RetentionService{
+		repo:        repo,
+		weddingRepo: weddingRepo,
+		periods:     periods,
+		ttlIndexes:  ttlIndexes,
+		logger:      logger,
+	}
+}
+
+// usesTTLIndex reports whether a TTL index enforces the platform period of a
+// data type. Audit logs are always deleted by the job.
+func (s *RetentionService) usesTTLIndex(dataType string) bool {
+	return s.ttlIndexes && dataType != models.RetentionAuditLogs && s.periods[dataType] > 0
+}
+
+// Policies lists the platform's retention period of every data type
+func (s *RetentionService) Policies() []models.RetentionPolicy {
+	policies := make([]models.RetentionPolicy, len(models.RetentionDataTypes))
+	for i, dataType := range models.RetentionDataTypes {
+		policies[i] = models.RetentionPolicy{
+			DataType: dataType,
+			Days:     int(s.periods[dataType] / (24 * time.Hour)),
+			TTLIndex: s.usesTTLIndex(dataType),
+		}
+	}
+	return policies
+}
+
+// ListOverrides lists the retention overrides of every wedding
+func (s *RetentionService) ListOverrides(ctx context.Context) ([]*models.RetentionOverride, error) {
+	return s.repo.ListOverrides(ctx)
+}
+
+// GetOverride returns the retention override of a wedding
+func (s *RetentionService) GetOverride(ctx context.Context, weddingID models.ID) (*models.RetentionOverride, error) {
+	override, err := s.repo.GetOverride(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrRetentionOverrideNotFound
+		}
+		return nil, err
+	}
+	return override, nil
+}
+
+// SetOverride replaces the retention override of a wedding with the given
+// periods in days by data type
+func (s *RetentionService) SetOverride(ctx context.Context, weddingID models.ID, days map[string]int) (*models.RetentionOverride, error) {
+	if len(days) == 0 {
+		return nil, ErrInvalidRetentionDays
+	}
+	for dataType, d := range days {
+		period, ok := s.periods[dataType]
+		switch {
+		case !ok:
+			return nil, ErrUnknownRetentionDataType
+		case d < 1:
+			return nil, ErrInvalidRetentionDays
+		case period > 0 && time.Duration(d)*24*time.Hour >= period:
+			return nil, ErrRetentionOverrideTooLong
+		}
+	}
+
+	if _, err := s.weddingRepo.GetByID(ctx, weddingID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+
+	override := &models.RetentionOverride{WeddingID: weddingID, Days: days}
+	if err := s.repo.SetOverride(ctx, override); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Retention override set", zap.String("wedding_id", weddingID.String()), zap.Any("days", days))
+	return override, nil
+}
+
+// ClearOverride returns a wedding to the platform's retention periods
+func (s *RetentionService) ClearOverride(ctx context.Context, weddingID models.ID) error {
+	if err := s.repo.DeleteOverride(ctx, weddingID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrRetentionOverrideNotFound
+		}
+		return err
+	}
+	s.logger.Info("Retention override removed", zap.String("wedding_id", weddingID.String()))
+	return nil
+}
+
+// Run deletes the data past its retention period: past the platform's period
+// unless a TTL index already expires it, and past the shorter period of each
+// wedding with an override. A dry run only counts what would be deleted,
+// including what the TTL indexes are due to expire.
+func (s *RetentionService) Run(ctx context.Context, dryRun bool) (*models.RetentionReport, error) {
+	overrides, err := s.repo.ListOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	report := &models.RetentionReport{DryRun: dryRun, RanAt: now, Results: []models.RetentionResult{}}
+	for _, dataType := range models.RetentionDataTypes {
+		period := s.periods[dataType]
+		if period > 0 && (dryRun || !s.usesTTLIndex(dataType)) {
+			if err := s.apply(ctx, report, dataType, nil, now.Add(-period)); err != nil {
+				return report, err
+			}
+		}
+
+		for _, override := range overrides {
+			weddingPeriod := override.Period(dataType)
+			if weddingPeriod == 0 || (period > 0 && weddingPeriod >= period) {
+				continue
+			}
+			weddingID := override.WeddingID
+			if err := s.apply(ctx, report, dataType, &weddingID, now.Add(-weddingPeriod)); err != nil {
+				return report, err
+			}
+		}
+	}
+	return report, nil
+}
+
+// apply deletes, or counts in a dry run, the data of a type created before the
+// cutoff and adds the result to the report
+func (s *RetentionService) apply(ctx context.Context, report *models.RetentionReport, dataType string, weddingID *models.ID, before time.Time) error {
+	var count int64
+	var err error
+	if report.DryRun {
+		count, err = s.repo.CountExpired(ctx, dataType, weddingID, before)
+	} else {
+		count, err = s.repo.DeleteExpired(ctx, dataType, weddingID, before)
+	}
+	if err != nil {
+		return err
+	}
+	report.Results = append(report.Results, models.RetentionResult{DataType: dataType, WeddingID: weddingID, Before: before, Count: count})
+	report.Total += count
+	return nil
+}
+
+// SyncIndexes turns the creation time indexes of the raw analytics events into
+// TTL indexes expiring them after the platform's period, or back into plain
+// indexes when TTL indexes are off or the data is kept forever
+func (s *RetentionService) SyncIndexes(ctx context.Context) error {
+	for _, dataType := range []string{models.RetentionPageViews, models.RetentionRSVPEvents, models.RetentionConversions} {
+		var period time.Duration
+		if s.usesTTLIndex(dataType) {
+			period = s.periods[dataType]
+		}
+		if err := s.repo.SyncTTLIndex(ctx, dataType, period); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RetentionScheduler applies the retention policy every interval. Outside of
+// dry runs it first brings the TTL indexes in line with the configured periods.
+type RetentionScheduler struct {
+	service  *RetentionService
+	interval time.Duration
+	dryRun   bool
+	logger   *zap.Logger
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewRetentionScheduler creates a scheduler that applies the retention policy
+// every interval, or only logs what it would delete when dryRun is set
+func NewRetentionScheduler(service *RetentionService, interval time.Duration, dryRun bool, logger *zap.Logger) *RetentionScheduler {
+	return &RetentionScheduler{
+		service:  service,
+		interval: interval,
+		dryRun:   dryRun,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start runs the scheduler loop in the background. The policy is applied
+// right away as well, after syncing the TTL indexes outside of dry runs.
+func (sch *RetentionScheduler) Start(ctx context.Context) {
+	sch.wg.Add(1)
+	go func() {
+		defer sch.wg.Done()
+		ticker := time.NewTicker(sch.interval)
+		defer ticker.Stop()
+
+		if !sch.dryRun {
+			if err := sch.service.SyncIndexes(ctx); err != nil {
+				sch.logger.Error("Failed to sync retention TTL indexes", zap.Error(err))
+			}
+		}
+		sch.run(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sch.stop:
+				return
+			case <-ticker.C:
+				sch.run(ctx)
+			}
+		}
+	}()
+}
+
+func (sch *RetentionScheduler) run(ctx context.Context) {
+	report, err := sch.service.Run(ctx, sch.dryRun)
+	if err != nil {
+		sch.logger.Error("Retention run failed", zap.Error(err))
+		return
+	}
+	if report.Total > 0 || report.DryRun {
+		sch.logger.Info("Retention run finished",
+			zap.Bool("dry_run", report.DryRun),
+			zap.Int64("total", report.Total),
+			zap.Any("results", report.Results),
+		)
+	}
+}
+
+// Stop signals the scheduler loop to exit and waits for it
+func (sch *RetentionScheduler) Stop() {
+	close(sch.stop)
+	sch.wg.Wait()
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// retentionCall records a CountExpired or DeleteExpired call
+type retentionCall struct {
+	dataType  string
+	weddingID *models.ID
+	age       time.Duration // How long before the call the cutoff is, rounded to the hour
+}
+
+// memoryRetentionRepository keeps overrides in memory and records the calls
+// counting or deleting expired data
+type memoryRetentionRepository struct {
+	overrides map[models.ID]*models.RetentionOverride
+	counted   []retentionCall
+	deleted   []retentionCall
+	ttl       map[string]time.Duration
+}
+
+func newMemoryRetentionRepository() *memoryRetentionRepository {
+	return &memoryRetentionRepository{
+		overrides: make(map[models.ID]*models.RetentionOverride),
+		ttl:       make(map[string]time.Duration),
+	}
+}
+
+func newRetentionCall(dataType string, weddingID *models.ID, before time.Time) retentionCall {
+	return retentionCall{dataType: dataType, weddingID: weddingID, age: time.Since(before).Round(time.Hour)}
+}
+
+func (r *memoryRetentionRepository) CountExpired(ctx context.Context, dataType string, weddingID *models.ID, before time.Time) (int64, error) {
+	r.counted = append(r.counted, newRetentionCall(dataType, weddingID, before))
+	return 2, nil
+}
+
+func (r *memoryRetentionRepository) DeleteExpired(ctx context.Context, dataType string, weddingID *models.ID, before time.Time) (int64, error) {
+	r.deleted = append(r.deleted, newRetentionCall(dataType, weddingID, before))
+	return 3, nil
+}
+
+func (r *memoryRetentionRepository) SyncTTLIndex(ctx context.Context, dataType string, period time.Duration) error {
+	r.ttl[dataType] = period
+	return nil
+}
+
+func (r *memoryRetentionRepository) GetOverride(ctx context.Context, weddingID models.ID) (*models.RetentionOverride, error) {
+	override, ok := r.overrides[weddingID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return override, nil
+}
+
+func (r *memoryRetentionRepository) ListOverrides(ctx context.Context) ([]*models.RetentionOverride, error) {
+	overrides := []*models.RetentionOverride{}
+	for _, override := range r.overrides {
+		overrides = append(overrides, override)
+	}
+	return overrides, nil
+}
+
+func (r *memoryRetentionRepository) SetOverride(ctx context.Context, override *models.RetentionOverride) error {
+	r.overrides[override.WeddingID] = override
+	return nil
+}
+
+func (r *memoryRetentionRepository) DeleteOverride(ctx context.Context, weddingID models.ID) error {
+	if _, ok := r.overrides[weddingID]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.overrides, weddingID)
+	return nil
+}
+
+const day = 24 * time.Hour
+
+func newTestRetentionService(repo *memoryRetentionRepository, weddingRepo *MockWeddingRepository, ttlIndexes bool) *RetentionService {
+	return NewRetentionService(repo, weddingRepo, map[string]time.Duration{
+		models.RetentionPageViews:   90 * day,
+		models.RetentionRSVPEvents:  180 * day,
+		models.RetentionConversions: 90 * day,
+		models.RetentionAuditLogs:   0,
+	}, ttlIndexes, zap.NewNop())
+}
+
+func TestRetentionService_Policies(t *testing.T) {
+	service := newTestRetentionService(newMemoryRetentionRepository(), new(MockWeddingRepository), true)
+
+	assert.Equal(t, []models.RetentionPolicy{
+		{DataType: models.RetentionPageViews, Days: 90, TTLIndex: true},
+		{DataType: models.RetentionRSVPEvents, Days: 180, TTLIndex: true},
+		{DataType: models.RetentionConversions, Days: 90, TTLIndex: true},
+		{DataType: models.RetentionAuditLogs, Days: 0, TTLIndex: false},
+	}, service.Policies())
+}
+
+func TestRetentionService_SetOverride(t *testing.T) {
+	ctx := context.Background()
+	weddingID := models.NewID()
+
+	t.Run("saves an override shortening the periods", func(t *testing.T) {
+		repo := newMemoryRetentionRepository()
+		weddingRepo := new(MockWeddingRepository)
+		weddingRepo.On("GetByID", ctx, weddingID).Return(&models.Wedding{ID: weddingID}, nil)
+		service := newTestRetentionService(repo, weddingRepo, true)
+
+		override, err := service.SetOverride(ctx, weddingID, map[string]int{models.RetentionPageViews: 30, models.RetentionAuditLogs: 365})
+		require.NoError(t, err)
+		assert.Equal(t, 30*day, override.Period(models.RetentionPageViews))
+		assert.Equal(t, override, repo.overrides[weddingID])
+
+		got, err := service.GetOverride(ctx, weddingID)
+		require.NoError(t, err)
+		assert.Equal(t, override, got)
+
+		require.NoError(t, service.ClearOverride(ctx, weddingID))
+		_, err = service.GetOverride(ctx, weddingID)
+		assert.ErrorIs(t, err, ErrRetentionOverrideNotFound)
+		assert.ErrorIs(t, service.ClearOverride(ctx, weddingID), ErrRetentionOverrideNotFound)
+	})
+
+	t.Run("rejects invalid periods", func(t *testing.T) {
+		service := newTestRetentionService(newMemoryRetentionRepository(), new(MockWeddingRepository), true)
+
+		tests := []struct {
+			days map[string]int
+			want error
+		}{
+			{days: nil, want: ErrInvalidRetentionDays},
+			{days: map[string]int{"sessions": 30}, want: ErrUnknownRetentionDataType},
+			{days: map[string]int{models.RetentionPageViews: 0}, want: ErrInvalidRetentionDays},
+			{days: map[string]int{models.RetentionPageViews: 90}, want: ErrRetentionOverrideTooLong},
+			{days: map[string]int{models.RetentionRSVPEvents: 200}, want: ErrRetentionOverrideTooLong},
+		}
+		for _, tt := range tests {
+			_, err := service.SetOverride(ctx, weddingID, tt.days)
+			assert.ErrorIs(t, err, tt.want, tt.days)
+		}
+	})
+
+	t.Run("requires the wedding to exist", func(t *testing.T) {
+		weddingRepo := new(MockWeddingRepository)
+		weddingRepo.On("GetByID", ctx, weddingID).Return(nil, repository.ErrNotFound)
+		service := newTestRetentionService(newMemoryRetentionRepository(), weddingRepo, true)
+
+		_, err := service.SetOverride(ctx, weddingID, map[string]int{models.RetentionPageViews: 30})
+		assert.ErrorIs(t, err, ErrWeddingNotFound)
+	})
+}
+
+func TestRetentionService_Run(t *testing.T) {
+	ctx := context.Background()
+	weddingID := models.NewID()
+	override := &models.RetentionOverride{WeddingID: weddingID, Days: map[string]int{
+		models.RetentionPageViews: 30,
+		models.RetentionAuditLogs: 365,
+	}}
+
+	t.Run("leaves the platform periods to the TTL indexes", func(t *testing.T) {
+		repo := newMemoryRetentionRepository()
+		repo.overrides[weddingID] = override
+		service := newTestRetentionService(repo, new(MockWeddingRepository), true)
+
+		report, err := service.Run(ctx, false)
+		require.NoError(t, err)
+		assert.False(t, report.DryRun)
+		assert.Equal(t, []retentionCall{
+			{dataType: models.RetentionPageViews, weddingID: &weddingID, age: 30 * day},
+			{dataType: models.RetentionAuditLogs, weddingID: &weddingID, age: 365 * day},
+		}, repo.deleted)
+		assert.Empty(t, repo.counted)
+		assert.Equal(t, int64(6), report.Total)
+		assert.Len(t, report.Results, 2)
+	})
+
+	t.Run("deletes past the platform periods without TTL indexes", func(t *testing.T) {
+		repo := newMemoryRetentionRepository()
+		service := newTestRetentionService(repo, new(MockWeddingRepository), false)
+
+		report, err := service.Run(ctx, false)
+		require.NoError(t, err)
+		assert.Equal(t, []retentionCall{
+			{dataType: models.RetentionPageViews, age: 90 * day},
+			{dataType: models.RetentionRSVPEvents, age: 180 * day},
+			{dataType: models.RetentionConversions, age: 90 * day},
+		}, repo.deleted)
+		assert.Equal(t, int64(9), report.Total)
+	})
+
+	t.Run("dry run only counts", func(t *testing.T) {
+		repo := newMemoryRetentionRepository()
+		repo.overrides[weddingID] = override
+		service := newTestRetentionService(repo, new(MockWeddingRepository), true)
+
+		report, err := service.Run(ctx, true)
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Empty(t, repo.deleted)
+		assert.Len(t, repo.counted, 5)
+		assert.Equal(t, int64(10), report.Total)
+	})
+}
+
+func TestRetentionService_SyncIndexes(t *testing.T) {
+	ctx := context.Background()
+
+	repo := newMemoryRetentionRepository()
+	require.NoError(t, newTestRetentionService(repo, new(MockWeddingRepository), true).SyncIndexes(ctx))
+	assert.Equal(t, map[string]time.Duration{
+		models.RetentionPageViews:   90 * day,
+		models.RetentionRSVPEvents:  180 * day,
+		models.RetentionConversions: 90 * day,
+	}, repo.ttl)
+
+	require.NoError(t, newTestRetentionService(repo, new(MockWeddingRepository), false).SyncIndexes(ctx))
+	assert.Equal(t, map[string]time.Duration{
+		models.RetentionPageViews:   0,
+		models.RetentionRSVPEvents:  0,
+		models.RetentionConversions: 0,
+	}, repo.ttl)
+}
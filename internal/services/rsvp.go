@@ -4,30 +4,57 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"go.opentelemetry.io/otel/attribute"
 	"time"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"wedding-invitation-backend/internal/domain/errs"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/tracing"
 )
 
 var (
-	ErrRSVPNotFound      = errors.New("rsvp not found")
+	ErrRSVPNotFound      = errs.NotFound("rsvp not found")
 	ErrRSVPClosed        = errors.New("rsvp is closed for this wedding")
-	ErrInvalidRSVPStatus = errors.New("invalid rsvp status")
-	ErrDuplicateRSVP     = errors.New("rsvp already submitted for this email")
-	ErrWeddingNotFound   = errors.New("wedding not found")
-	ErrUnauthorized      = errors.New("unauthorized")
-	ErrTooManyPlusOnes   = errors.New("too many plus ones")
+	ErrInvalidRSVPStatus = errs.InvalidField("status", "invalid rsvp status")
+	ErrDuplicateRSVP     = errs.Conflict("rsvp already submitted for this email")
+	ErrWeddingNotFound   = errs.NotFound("wedding not found")
+	ErrUnauthorized      = errs.Forbidden("unauthorized")
+	ErrTooManyPlusOnes   = errs.InvalidField("plus_ones", "too many plus ones")
 	ErrRSVPCannotModify  = errors.New("rsvp cannot be modified after 24 hours")
-	ErrGuestNotFound     = errors.New("guest not found")
-	ErrDuplicateGuest    = errors.New("guest with this email already exists")
+	ErrGuestNotFound     = errs.NotFound("guest not found")
+	ErrDuplicateGuest    = errs.Conflict("guest with this email already exists")
+	ErrRSVPNotInReview   = errors.New("rsvp is not awaiting review")
+	ErrInvalidReview     = errors.New("review decision must be accept or reject")
+	// ErrInvalidRSVPSessions is returned for answers to sessions the wedding does not have
+	ErrInvalidRSVPSessions = errors.New("rsvp sessions must be distinct sessions of the wedding")
+)
+
+// RSVP review decisions available to wedding owners
+const (
+	RSVPReviewAccept = "accept"
+	RSVPReviewReject = "reject"
 )
 
 // RSVPService provides business logic for RSVP management
 type RSVPService struct {
 	rsvpRepo    repository.RSVPRepository
 	weddingRepo repository.WeddingRepository
+	fraudScorer *RSVPFraudScorer
+	responses   RSVPResponseTracker
+	webhooks    WeddingEventNotifier
+	push        RSVPPushNotifier
+	guestRepo   repository.GuestRepository
+	guestSecret string
+	editLinks   *rsvpEditLinks
+	features    FeatureChecker
+	audit       AuditRecorder
+	// insightsCache keeps computed insights until the wedding's RSVPs change
+	insightsCache RSVPInsightsCache
+}
+
+// RSVPResponseTracker is notified of every stored RSVP, e.g. to attribute it to the reminders its guest received
+type RSVPResponseTracker interface {
+	TrackRSVP(ctx context.Context, rsvp *models.RSVP)
 }
 
 // NewRSVPService creates a new RSVP service
@@ -38,67 +65,126 @@ func NewRSVPService(rsvpRepo repository.RSVPRepository, weddingRepo repository.W
 	}
 }
 
+// EnableFraudScoring scores public submissions with scorer; suspicious RSVPs are
+// stored pending owner review and do not count towards statistics until accepted
+func (s *RSVPService) EnableFraudScoring(scorer *RSVPFraudScorer) {
+	s.fraudScorer = scorer
+}
+
+// EnableResponseTracking notifies tracker of every stored RSVP
+func (s *RSVPService) EnableResponseTracking(tracker RSVPResponseTracker) {
+	s.responses = tracker
+}
+
+// EnableWebhooks sends an rsvp.created event to the wedding's webhooks for every stored RSVP
+func (s *RSVPService) EnableWebhooks(notifier WeddingEventNotifier) {
+	s.webhooks = notifier
+}
+
+// EnablePushNotifications pushes every stored RSVP to the browsers of the wedding's members
+func (s *RSVPService) EnablePushNotifications(push RSVPPushNotifier) {
+	s.push = push
+}
+
+// EnableFeatureFlags stops edit links for weddings the rsvp_editing feature is off for
+func (s *RSVPService) EnableFeatureFlags(features FeatureChecker) {
+	s.features = features
+}
+
+// EnableAuditLog records the submission, changes, reviews and deletion of RSVPs in the audit log
+func (s *RSVPService) EnableAuditLog(audit AuditRecorder) {
+	s.audit = audit
+}
+
 // SubmitRSVPRequest represents a new RSVP submission
 type SubmitRSVPRequest struct {
-	FirstName           string                `json:"first_name" validate:"required,max=50"`
-	LastName            string                `json:"last_name" validate:"required,max=50"`
-	Email               string                `json:"email,omitempty" validate:"omitempty,email,max=100"`
-	Phone               string                `json:"phone,omitempty"`
-	Status              string                `json:"status" validate:"required,oneof=attending not-attending maybe"`
-	AttendanceCount     int                   `json:"attendance_count" validate:"required,min=1"`
-	PlusOnes            []models.PlusOneInfo  `json:"plus_ones,omitempty"`
-	DietaryRestrictions string                `json:"dietary_restrictions,omitempty"`
-	DietarySelected     []string              `json:"dietary_selected,omitempty"`
-	AdditionalNotes     string                `json:"additional_notes,omitempty" validate:"omitempty,max=500"`
-	CustomAnswers       []models.CustomAnswer `json:"custom_answers,omitempty"`
-	Source              string                `json:"source" validate:"oneof=web direct_link qr_code manual"`
-	IPAddress           string                `json:"ip_address,omitempty"`
-	UserAgent           string                `json:"user_agent,omitempty"`
+	FirstName       string `json:"first_name" validate:"required,max=50"`
+	LastName        string `json:"last_name" validate:"required,max=50"`
+	Email           string `json:"email,omitempty" validate:"omitempty,email,max=100"`
+	Phone           string `json:"phone,omitempty"`
+	Status          string `json:"status" validate:"required,oneof=attending not-attending maybe"`
+	AttendanceCount int    `json:"attendance_count" validate:"required,min=1"`
+	// Sessions answers each session of a multi-session wedding; without them
+	// Status applies to every session
+	Sessions            []models.RSVPSessionResponse `json:"sessions,omitempty" validate:"omitempty,dive"`
+	PlusOnes            []models.PlusOneInfo         `json:"plus_ones,omitempty"`
+	DietaryRestrictions string                       `json:"dietary_restrictions,omitempty"`
+	DietarySelected     []string                     `json:"dietary_selected,omitempty"`
+	AdditionalNotes     string                       `json:"additional_notes,omitempty" validate:"omitempty,max=500"`
+	MealChoice          string                       `json:"meal_choice,omitempty"`
+	Allergies           []string                     `json:"allergies,omitempty"`
+	CustomAnswers       []models.CustomAnswer        `json:"custom_answers,omitempty"`
+	GuestToken          string                       `json:"guest_token,omitempty"`
+	EditToken           string                       `json:"edit_token,omitempty"`                        // Token of the edit link of the RSVP this submission changes
+	Members             []models.RSVPMemberStatus    `json:"members,omitempty" validate:"omitempty,dive"` // Other household members; those left out take Status
+	Source              string                       `json:"source" validate:"oneof=web direct_link qr_code manual"`
+	IPAddress           string                       `json:"ip_address,omitempty"`
+	UserAgent           string                       `json:"user_agent,omitempty"`
 }
 
 // UpdateRSVPRequest represents an RSVP update
 type UpdateRSVPRequest struct {
-	Status              *string                `json:"status,omitempty" validate:"omitempty,oneof=attending not-attending maybe"`
-	AttendanceCount     *int                   `json:"attendance_count,omitempty" validate:"omitempty,min=1"`
-	PlusOnes            *[]models.PlusOneInfo  `json:"plus_ones,omitempty"`
-	DietaryRestrictions *string                `json:"dietary_restrictions,omitempty"`
-	DietarySelected     *[]string              `json:"dietary_selected,omitempty"`
-	AdditionalNotes     *string                `json:"additional_notes,omitempty" validate:"omitempty,max=500"`
-	CustomAnswers       *[]models.CustomAnswer `json:"custom_answers,omitempty"`
+	Status              *string                       `json:"status,omitempty" validate:"omitempty,oneof=attending not-attending maybe"`
+	AttendanceCount     *int                          `json:"attendance_count,omitempty" validate:"omitempty,min=1"`
+	Sessions            *[]models.RSVPSessionResponse `json:"sessions,omitempty" validate:"omitempty,dive"`
+	PlusOnes            *[]models.PlusOneInfo         `json:"plus_ones,omitempty"`
+	DietaryRestrictions *string                       `json:"dietary_restrictions,omitempty"`
+	DietarySelected     *[]string                     `json:"dietary_selected,omitempty"`
+	AdditionalNotes     *string                       `json:"additional_notes,omitempty" validate:"omitempty,max=500"`
+	MealChoice          *string                       `json:"meal_choice,omitempty"`
+	Allergies           *[]string                     `json:"allergies,omitempty"`
+	CustomAnswers       *[]models.CustomAnswer        `json:"custom_answers,omitempty"`
+}
+
+// SubmitRSVP handles an RSVP submission. A guest who answered before updates
+// their RSVP instead of submitting a second one when the submission comes with
+// their invitation link or the RSVP's edit link; the returned RSVP then has
+// UpdatedAt set. Without either it gets ErrDuplicateRSVP.
+func (s *RSVPService) SubmitRSVP(ctx context.Context, weddingID models.ID, req SubmitRSVPRequest) (_ *models.RSVP, err error) {
+	ctx, span := tracing.Start(ctx, "rsvp.submit", attribute.String("wedding.id", weddingID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	rsvp, proof, err := s.prepareRSVP(ctx, weddingID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saveRSVP(ctx, rsvp, proof)
 }
 
-// SubmitRSVP handles new RSVP submission
-func (s *RSVPService) SubmitRSVP(ctx context.Context, weddingID primitive.ObjectID, req SubmitRSVPRequest) (*models.RSVP, error) {
+// prepareRSVP validates a submission against the wedding and builds the RSVP
+// without saving it, along with what the submission proved about its sender
+func (s *RSVPService) prepareRSVP(ctx context.Context, weddingID models.ID, req SubmitRSVPRequest) (_ *models.RSVP, proof models.RSVPProof, err error) {
+	ctx, span := tracing.Start(ctx, "rsvp.prepare", attribute.String("wedding.id", weddingID.String()))
+	defer func() { tracing.End(span, err) }()
+
 	// Get wedding to validate RSVP is open
 	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			return nil, ErrWeddingNotFound
+			return nil, proof, ErrWeddingNotFound
 		}
-		return nil, fmt.Errorf("failed to get wedding: %w", err)
+		return nil, proof, fmt.Errorf("failed to get wedding: %w", err)
 	}
 
 	// Check if RSVP is open
 	if !s.isRSVPOpen(wedding) {
-		return nil, ErrRSVPClosed
+		return nil, proof, ErrRSVPClosed
 	}
 
 	// Validate request
 	if err := s.validateSubmitRequest(req, wedding); err != nil {
-		return nil, err
+		return nil, proof, err
 	}
 
-	// Check for duplicate RSVP by email
-	if req.Email != "" {
-		existing, _ := s.rsvpRepo.GetByEmail(ctx, weddingID, req.Email)
-		if existing != nil {
-			return nil, ErrDuplicateRSVP
-		}
+	customAnswers, err := validateCustomAnswers(wedding.RSVP.CustomQuestions, req.CustomAnswers)
+	if err != nil {
+		return nil, proof, err
 	}
 
 	// Create RSVP
 	rsvp := &models.RSVP{
-		ID:                  primitive.NewObjectID(),
+		ID:                  models.NewID(),
 		WeddingID:           weddingID,
 		FirstName:           req.FirstName,
 		LastName:            req.LastName,
@@ -106,12 +192,15 @@ func (s *RSVPService) SubmitRSVP(ctx context.Context, weddingID primitive.Object
 		Phone:               req.Phone,
 		Status:              req.Status,
 		AttendanceCount:     req.AttendanceCount,
+		Sessions:            req.Sessions,
 		PlusOnes:            req.PlusOnes,
 		PlusOneCount:        len(req.PlusOnes),
 		DietaryRestrictions: req.DietaryRestrictions,
 		DietarySelected:     req.DietarySelected,
 		AdditionalNotes:     req.AdditionalNotes,
-		CustomAnswers:       req.CustomAnswers,
+		MealChoice:          req.MealChoice,
+		Allergies:           req.Allergies,
+		CustomAnswers:       customAnswers,
 		SubmittedAt:         time.Now(),
 		IPAddress:           req.IPAddress,
 		UserAgent:           req.UserAgent,
@@ -119,21 +208,100 @@ func (s *RSVPService) SubmitRSVP(ctx context.Context, weddingID primitive.Object
 		ConfirmationSent:    false,
 	}
 
+	if err := applySessionResponses(rsvp, wedding); err != nil {
+		return nil, proof, err
+	}
+
+	if err := applyCompanions(rsvp); err != nil {
+		return nil, proof, err
+	}
+
+	if err := applyMealChoices(rsvp, wedding); err != nil {
+		return nil, proof, err
+	}
+
+	if req.EditToken != "" {
+		edited, err := s.rsvpByEditToken(ctx, weddingID, req.EditToken)
+		if err != nil {
+			return nil, proof, err
+		}
+		proof.EditRSVPID = &edited.ID
+	}
+
+	rsvp.Members = req.Members
+	var guest *models.Guest
+	if req.GuestToken != "" {
+		if guest, err = s.guestByToken(ctx, weddingID, req.GuestToken); err != nil {
+			return nil, proof, err
+		}
+		proof.GuestToken = true
+	} else if len(rsvp.Members) > 0 {
+		return nil, proof, ErrInvalidHouseholdMember
+	} else if guest, err = s.matchGuest(ctx, rsvp); err != nil {
+		return nil, proof, err
+	}
+	if guest != nil {
+		if err := s.linkGuest(ctx, rsvp, guest); err != nil {
+			return nil, proof, err
+		}
+	}
+
+	if s.fraudScorer != nil {
+		rsvp.Review = s.fraudScorer.Score(ctx, rsvp)
+	}
+	if guest == nil {
+		if err := s.flagWalkIn(ctx, rsvp); err != nil {
+			return nil, proof, err
+		}
+	}
+
+	return rsvp, proof, nil
+}
+
+// persistRSVP saves a prepared RSVP and refreshes the wedding RSVP count
+func (s *RSVPService) persistRSVP(ctx context.Context, rsvp *models.RSVP) (err error) {
+	ctx, span := tracing.Start(ctx, "rsvp.persist",
+		attribute.String("wedding.id", rsvp.WeddingID.String()),
+		attribute.String("rsvp.id", rsvp.ID.String()))
+	defer func() { tracing.End(span, err) }()
+
 	if err := s.rsvpRepo.Create(ctx, rsvp); err != nil {
-		return nil, fmt.Errorf("failed to create RSVP: %w", err)
+		return fmt.Errorf("failed to create RSVP: %w", err)
 	}
 
 	// Update wedding RSVP count
-	if err := s.weddingRepo.UpdateRSVPCount(ctx, weddingID); err != nil {
+	if err := s.weddingRepo.UpdateRSVPCount(ctx, rsvp.WeddingID); err != nil {
 		// Log error but don't fail the request
 		fmt.Printf("Failed to update RSVP count: %v\n", err)
 	}
 
-	return rsvp, nil
+	s.syncGuest(ctx, rsvp)
+	s.invalidateInsights(ctx, rsvp.WeddingID)
+	s.recordAudit(ctx, models.AuditRSVPCreate, rsvp, auditChanges(nil, auditFields(rsvp)))
+	s.sendEditLink(ctx, rsvp)
+	if s.responses != nil {
+		s.responses.TrackRSVP(ctx, rsvp)
+	}
+	if s.webhooks != nil {
+		s.webhooks.NotifyWeddingEvent(ctx, rsvp.WeddingID, models.WeddingEventRSVPCreated, map[string]interface{}{
+			"rsvp_id":          rsvp.ID.String(),
+			"name":             rsvp.GetFullName(),
+			"status":           rsvp.Status,
+			"attendance_count": rsvp.AttendanceCount,
+			"plus_one_count":   rsvp.PlusOneCount,
+			"source":           rsvp.Source,
+			"needs_review":     rsvp.NeedsReview(),
+		})
+	}
+	if s.push != nil {
+		s.push.NotifyRSVP(ctx, rsvp)
+	}
+
+	return nil
 }
 
 // GetRSVPByID retrieves an RSVP by ID
-func (s *RSVPService) GetRSVPByID(ctx context.Context, id primitive.ObjectID) (*models.RSVP, error) {
+func (s *RSVPService) GetRSVPByID(ctx context.Context, id models.ID) (*models.RSVP, error) {
 	rsvp, err := s.rsvpRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
@@ -145,7 +313,7 @@ func (s *RSVPService) GetRSVPByID(ctx context.Context, id primitive.ObjectID) (*
 }
 
 // UpdateRSVP updates an existing RSVP
-func (s *RSVPService) UpdateRSVP(ctx context.Context, id primitive.ObjectID, req UpdateRSVPRequest) (*models.RSVP, error) {
+func (s *RSVPService) UpdateRSVP(ctx context.Context, id models.ID, req UpdateRSVPRequest) (*models.RSVP, error) {
 	// Get existing RSVP
 	rsvp, err := s.rsvpRepo.GetByID(ctx, id)
 	if err != nil {
@@ -160,6 +328,23 @@ func (s *RSVPService) UpdateRSVP(ctx context.Context, id primitive.ObjectID, req
 		return nil, ErrRSVPCannotModify
 	}
 
+	wedding, err := s.weddingRepo.GetByID(ctx, rsvp.WeddingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wedding for validation: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+
+	return s.applyUpdate(ctx, rsvp, wedding, req, models.RSVPChangedByOwner)
+}
+
+// applyUpdate applies an update to an RSVP, validates it against the wedding,
+// records the changed fields in the RSVP's history and saves it
+func (s *RSVPService) applyUpdate(ctx context.Context, rsvp *models.RSVP, wedding *models.Wedding, req UpdateRSVPRequest, changedBy string) (*models.RSVP, error) {
+	before := auditedRSVPFields(rsvp)
+	snapshot := auditFields(rsvp)
+
 	// Update fields if provided
 	if req.Status != nil {
 		rsvp.Status = *req.Status
@@ -167,9 +352,20 @@ func (s *RSVPService) UpdateRSVP(ctx context.Context, id primitive.ObjectID, req
 	if req.AttendanceCount != nil {
 		rsvp.AttendanceCount = *req.AttendanceCount
 	}
+	if req.Sessions != nil {
+		rsvp.Sessions = *req.Sessions
+	} else if req.Status != nil {
+		// A new overall answer applies to every session
+		rsvp.Sessions = nil
+	}
 	if req.PlusOnes != nil {
 		rsvp.PlusOnes = *req.PlusOnes
-		rsvp.PlusOneCount = len(*req.PlusOnes)
+		if err := applyCompanions(rsvp); err != nil {
+			return nil, err
+		}
+		if err := s.checkGuestAllowance(ctx, rsvp); err != nil {
+			return nil, err
+		}
 	}
 	if req.DietaryRestrictions != nil {
 		rsvp.DietaryRestrictions = *req.DietaryRestrictions
@@ -180,24 +376,49 @@ func (s *RSVPService) UpdateRSVP(ctx context.Context, id primitive.ObjectID, req
 	if req.AdditionalNotes != nil {
 		rsvp.AdditionalNotes = *req.AdditionalNotes
 	}
-	if req.CustomAnswers != nil {
-		rsvp.CustomAnswers = *req.CustomAnswers
+	if req.MealChoice != nil {
+		rsvp.MealChoice = *req.MealChoice
+	}
+	if req.Allergies != nil {
+		rsvp.Allergies = *req.Allergies
 	}
-
 	// Update timestamp
 	now := time.Now()
 	rsvp.UpdatedAt = &now
 
 	// Validate updated RSVP
-	wedding, err := s.weddingRepo.GetByID(ctx, rsvp.WeddingID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get wedding for validation: %w", err)
+	if err := s.validateRSVP(rsvp, wedding); err != nil {
+		return nil, err
 	}
 
-	if err := s.validateRSVP(rsvp, wedding); err != nil {
+	// Choices are only checked when changed, so a later menu change does not
+	// block unrelated updates
+	if req.MealChoice != nil || req.Allergies != nil || req.PlusOnes != nil {
+		if err := applyMealChoices(rsvp, wedding); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.CustomAnswers != nil {
+		customAnswers, err := validateCustomAnswers(wedding.RSVP.CustomQuestions, *req.CustomAnswers)
+		if err != nil {
+			return nil, err
+		}
+		rsvp.CustomAnswers = customAnswers
+	}
+
+	if req.Sessions == nil {
+		// Answers to sessions removed from the wedding since are dropped
+		rsvp.Sessions = keepWeddingSessions(rsvp.Sessions, wedding)
+	}
+	if err := applySessionResponses(rsvp, wedding); err != nil {
 		return nil, err
 	}
 
+	if fields := changedRSVPFields(before, auditedRSVPFields(rsvp)); len(fields) > 0 {
+		rsvp.RecordChange(models.RSVPChange{ChangedAt: now, ChangedBy: changedBy, Fields: fields})
+	}
+
 	// Save updates
 	if err := s.rsvpRepo.Update(ctx, rsvp); err != nil {
 		return nil, fmt.Errorf("failed to update RSVP: %w", err)
@@ -207,12 +428,15 @@ func (s *RSVPService) UpdateRSVP(ctx context.Context, id primitive.ObjectID, req
 	if err := s.weddingRepo.UpdateRSVPCount(ctx, rsvp.WeddingID); err != nil {
 		fmt.Printf("Failed to update RSVP count: %v\n", err)
 	}
+	s.syncGuest(ctx, rsvp)
+	s.invalidateInsights(ctx, rsvp.WeddingID)
+	s.recordAudit(ctx, models.AuditRSVPUpdate, rsvp, auditChanges(snapshot, auditFields(rsvp)))
 
 	return rsvp, nil
 }
 
 // DeleteRSVP deletes an RSVP
-func (s *RSVPService) DeleteRSVP(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error {
+func (s *RSVPService) DeleteRSVP(ctx context.Context, id models.ID, userID models.ID) error {
 	// Get RSVP to verify ownership
 	rsvp, err := s.rsvpRepo.GetByID(ctx, id)
 	if err != nil {
@@ -229,7 +453,7 @@ func (s *RSVPService) DeleteRSVP(ctx context.Context, id primitive.ObjectID, use
 	}
 
 	// Check ownership
-	if wedding.UserID != userID {
+	if !wedding.Can(userID, models.PermissionManageRSVPs) {
 		return ErrUnauthorized
 	}
 
@@ -237,17 +461,34 @@ func (s *RSVPService) DeleteRSVP(ctx context.Context, id primitive.ObjectID, use
 	if err := s.rsvpRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete RSVP: %w", err)
 	}
+	s.recordAudit(ctx, models.AuditRSVPDelete, rsvp, auditChanges(auditFields(rsvp), nil))
 
 	// Update wedding RSVP count
 	if err := s.weddingRepo.UpdateRSVPCount(ctx, rsvp.WeddingID); err != nil {
 		fmt.Printf("Failed to update RSVP count: %v\n", err)
 	}
+	s.invalidateInsights(ctx, rsvp.WeddingID)
 
 	return nil
 }
 
+// recordAudit records a change of an RSVP when the audit log is enabled. Changes
+// made without a signed-in actor were made by the guest.
+func (s *RSVPService) recordAudit(ctx context.Context, action string, rsvp *models.RSVP, changes []models.AuditChange) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, &models.AuditLog{
+		Action:     action,
+		TargetType: models.AuditTargetRSVP,
+		TargetID:   rsvp.ID.String(),
+		WeddingID:  &rsvp.WeddingID,
+		Changes:    changes,
+	})
+}
+
 // ListRSVPs retrieves RSVPs for a wedding
-func (s *RSVPService) ListRSVPs(ctx context.Context, weddingID primitive.ObjectID, userID primitive.ObjectID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error) {
+func (s *RSVPService) ListRSVPs(ctx context.Context, weddingID models.ID, userID models.ID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error) {
 	// Verify wedding ownership
 	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
 	if err != nil {
@@ -257,7 +498,7 @@ func (s *RSVPService) ListRSVPs(ctx context.Context, weddingID primitive.ObjectI
 		return nil, 0, fmt.Errorf("failed to get wedding: %w", err)
 	}
 
-	if wedding.UserID != userID {
+	if !wedding.Can(userID, models.PermissionManageRSVPs) {
 		return nil, 0, ErrUnauthorized
 	}
 
@@ -269,8 +510,62 @@ func (s *RSVPService) ListRSVPs(ctx context.Context, weddingID primitive.ObjectI
 	return rsvps, total, nil
 }
 
+// ReviewRSVP records the owner's accept or reject decision on a flagged RSVP
+func (s *RSVPService) ReviewRSVP(ctx context.Context, id models.ID, userID models.ID, decision string) (*models.RSVP, error) {
+	var status models.RSVPReviewStatus
+	switch decision {
+	case RSVPReviewAccept:
+		status = models.RSVPReviewAccepted
+	case RSVPReviewReject:
+		status = models.RSVPReviewRejected
+	default:
+		return nil, ErrInvalidReview
+	}
+
+	rsvp, err := s.rsvpRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrRSVPNotFound
+		}
+		return nil, fmt.Errorf("failed to get RSVP: %w", err)
+	}
+
+	wedding, err := s.weddingRepo.GetByID(ctx, rsvp.WeddingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if !wedding.Can(userID, models.PermissionManageRSVPs) {
+		return nil, ErrUnauthorized
+	}
+
+	if !rsvp.NeedsReview() {
+		return nil, ErrRSVPNotInReview
+	}
+
+	before := auditFields(rsvp)
+	now := time.Now()
+	rsvp.Review.Status = status
+	rsvp.Review.ReviewedBy = &userID
+	rsvp.Review.ReviewedAt = &now
+	rsvp.UpdatedAt = &now
+
+	if err := s.rsvpRepo.Update(ctx, rsvp); err != nil {
+		return nil, fmt.Errorf("failed to update RSVP: %w", err)
+	}
+
+	// Accepted RSVPs now count towards the wedding's responses
+	if err := s.weddingRepo.UpdateRSVPCount(ctx, rsvp.WeddingID); err != nil {
+		fmt.Printf("Failed to update RSVP count: %v\n", err)
+	}
+	s.syncGuest(ctx, rsvp)
+	s.invalidateInsights(ctx, rsvp.WeddingID)
+	s.recordAudit(ctx, models.AuditRSVPReview, rsvp, auditChanges(before, auditFields(rsvp)))
+
+	return rsvp, nil
+}
+
 // GetRSVPStatistics retrieves RSVP statistics for a wedding
-func (s *RSVPService) GetRSVPStatistics(ctx context.Context, weddingID primitive.ObjectID, userID primitive.ObjectID) (*models.RSVPStatistics, error) {
+func (s *RSVPService) GetRSVPStatistics(ctx context.Context, weddingID models.ID, userID models.ID) (*models.RSVPStatistics, error) {
 	// Verify wedding ownership
 	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
 	if err != nil {
@@ -280,7 +575,7 @@ func (s *RSVPService) GetRSVPStatistics(ctx context.Context, weddingID primitive
 		return nil, fmt.Errorf("failed to get wedding: %w", err)
 	}
 
-	if wedding.UserID != userID {
+	if !wedding.Can(userID, models.PermissionManageRSVPs) {
 		return nil, ErrUnauthorized
 	}
 
@@ -288,12 +583,18 @@ func (s *RSVPService) GetRSVPStatistics(ctx context.Context, weddingID primitive
 	if err != nil {
 		return nil, fmt.Errorf("failed to get RSVP statistics: %w", err)
 	}
+	stats.Sessions = sessionStatistics(stats.Sessions, wedding)
+
+	stats.CustomQuestions, err = s.customQuestionStatistics(ctx, wedding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom question statistics: %w", err)
+	}
 
 	return stats, nil
 }
 
 // ExportRSVPs exports RSVPs data (for CSV export)
-func (s *RSVPService) ExportRSVPs(ctx context.Context, weddingID primitive.ObjectID, userID primitive.ObjectID) ([]*models.RSVP, error) {
+func (s *RSVPService) ExportRSVPs(ctx context.Context, weddingID models.ID, userID models.ID) ([]*models.RSVP, error) {
 	// Get all RSVPs for the wedding
 	rsvps, _, err := s.ListRSVPs(ctx, weddingID, userID, 1, 10000, repository.RSVPFilters{})
 	if err != nil {
@@ -303,6 +604,24 @@ func (s *RSVPService) ExportRSVPs(ctx context.Context, weddingID primitive.Objec
 	return rsvps, nil
 }
 
+// StreamRSVPs verifies ownership and then calls fn for every RSVP of the wedding
+// without loading them all into memory, for large exports
+func (s *RSVPService) StreamRSVPs(ctx context.Context, weddingID models.ID, userID models.ID, fn func(*models.RSVP) error) error {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWeddingNotFound
+		}
+		return fmt.Errorf("failed to get wedding: %w", err)
+	}
+
+	if !wedding.Can(userID, models.PermissionManageRSVPs) {
+		return ErrUnauthorized
+	}
+
+	return s.rsvpRepo.StreamByWedding(ctx, weddingID, fn)
+}
+
 // Helper methods
 
 func (s *RSVPService) isRSVPOpen(wedding *models.Wedding) bool {
@@ -365,6 +684,100 @@ func (s *RSVPService) validateRSVP(rsvp *models.RSVP, wedding *models.Wedding) e
 	return nil
 }
 
+// applySessionResponses checks an RSVP's session answers against the wedding's
+// sessions and fills in the sessions left unanswered. Guests who only answer
+// for the wedding as a whole give that answer for every session; otherwise
+// unanswered sessions are not attended and the overall status follows the
+// sessions, attending when any session is attended.
+func applySessionResponses(rsvp *models.RSVP, wedding *models.Wedding) error {
+	if len(wedding.Sessions) == 0 {
+		if len(rsvp.Sessions) > 0 {
+			return ErrInvalidRSVPSessions
+		}
+		return nil
+	}
+
+	answers := make(map[string]string, len(rsvp.Sessions))
+	for _, response := range rsvp.Sessions {
+		if _, ok := wedding.Session(response.SessionID); !ok {
+			return ErrInvalidRSVPSessions
+		}
+		if _, seen := answers[response.SessionID]; seen {
+			return ErrInvalidRSVPSessions
+		}
+		if !contains([]string{"attending", "not-attending", "maybe"}, response.Status) {
+			return ErrInvalidRSVPStatus
+		}
+		answers[response.SessionID] = response.Status
+	}
+
+	responses := make([]models.RSVPSessionResponse, 0, len(wedding.Sessions))
+	for _, session := range wedding.Sessions {
+		status, ok := answers[session.ID]
+		switch {
+		case ok:
+		case len(answers) == 0:
+			status = rsvp.Status
+		default:
+			status = string(models.RSVPNotAttending)
+		}
+		responses = append(responses, models.RSVPSessionResponse{SessionID: session.ID, Status: status})
+	}
+	rsvp.Sessions = responses
+
+	if len(answers) > 0 {
+		rsvp.Status = overallSessionStatus(responses)
+	}
+	return nil
+}
+
+// overallSessionStatus sums session answers up into an RSVP status
+func overallSessionStatus(responses []models.RSVPSessionResponse) string {
+	status := string(models.RSVPNotAttending)
+	for _, response := range responses {
+		switch response.Status {
+		case string(models.RSVPAttending):
+			return response.Status
+		case string(models.RSVPMaybe):
+			status = response.Status
+		}
+	}
+	return status
+}
+
+// keepWeddingSessions drops the answers to sessions the wedding no longer has
+func keepWeddingSessions(responses []models.RSVPSessionResponse, wedding *models.Wedding) []models.RSVPSessionResponse {
+	var kept []models.RSVPSessionResponse
+	for _, response := range responses {
+		if _, ok := wedding.Session(response.SessionID); ok {
+			kept = append(kept, response)
+		}
+	}
+	return kept
+}
+
+// sessionStatistics lists the statistics of every session of the wedding in
+// its order, including sessions nobody answered yet
+func sessionStatistics(counted []models.SessionStatistics, wedding *models.Wedding) []models.SessionStatistics {
+	if len(wedding.Sessions) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]models.SessionStatistics, len(counted))
+	for _, stats := range counted {
+		byID[stats.SessionID] = stats
+	}
+
+	sessions := make([]models.SessionStatistics, 0, len(wedding.Sessions))
+	for _, session := range wedding.Sessions {
+		stats := byID[session.ID]
+		stats.SessionID = session.ID
+		stats.Title = session.Title
+		sessions = append(sessions, stats)
+	}
+	return sessions
+}
+
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
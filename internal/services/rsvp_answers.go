@@ -0,0 +1,333 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// ErrInvalidRSVPAnswers is returned for custom answers that do not fit the wedding's RSVP questions
+var ErrInvalidRSVPAnswers = errors.New("invalid answers to custom rsvp questions")
+
+// maxCustomTextAnswerLength caps answers to text questions
+const maxCustomTextAnswerLength = 1000
+
+// GetRSVPQuestions returns the custom RSVP questions of a wedding to the users managing its RSVPs
+func (s *RSVPService) GetRSVPQuestions(ctx context.Context, weddingID models.ID, userID models.ID) ([]models.CustomQuestion, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+
+	if !wedding.Can(userID, models.PermissionManageRSVPs) {
+		return nil, ErrUnauthorized
+	}
+
+	return wedding.RSVP.CustomQuestions, nil
+}
+
+// validateCustomAnswers checks answers against the wedding's questions and
+// returns them in question order with the question text filled in. Text answers
+// are trimmed, choices must be among the question's options and numbers within
+// its bounds; blank answers count as unanswered.
+func validateCustomAnswers(questions []models.CustomQuestion, answers []models.CustomAnswer) ([]models.CustomAnswer, error) {
+	byID := make(map[string]interface{}, len(answers))
+	for _, answer := range answers {
+		if _, ok := findCustomQuestion(questions, answer.QuestionID); !ok {
+			return nil, fmt.Errorf("%w: unknown question %q", ErrInvalidRSVPAnswers, answer.QuestionID)
+		}
+		if _, seen := byID[answer.QuestionID]; seen {
+			return nil, fmt.Errorf("%w: question %q is answered twice", ErrInvalidRSVPAnswers, answer.QuestionID)
+		}
+		byID[answer.QuestionID] = answer.Answer
+	}
+
+	var normalized []models.CustomAnswer
+	for _, question := range questions {
+		value, err := normalizeCustomAnswer(question, byID[question.ID])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidRSVPAnswers, question.Question, err)
+		}
+		if value == nil {
+			if question.Required {
+				return nil, fmt.Errorf("%w: %s: an answer is required", ErrInvalidRSVPAnswers, question.Question)
+			}
+			continue
+		}
+		normalized = append(normalized, models.CustomAnswer{
+			QuestionID: question.ID,
+			Question:   question.Question,
+			Answer:     value,
+		})
+	}
+	return normalized, nil
+}
+
+// normalizeCustomAnswer converts an answer to a string, []string or float64
+// according to the question type, or nil when it is blank
+func normalizeCustomAnswer(question models.CustomQuestion, answer interface{}) (interface{}, error) {
+	switch question.Kind() {
+	case models.CustomQuestionText:
+		text, ok := answer.(string)
+		if !ok && answer != nil {
+			return nil, errors.New("answer must be text")
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return nil, nil
+		}
+		if len(text) > maxCustomTextAnswerLength {
+			return nil, fmt.Errorf("answer must be %d characters or less", maxCustomTextAnswerLength)
+		}
+		return text, nil
+
+	case models.CustomQuestionSingleChoice:
+		choice, ok := answer.(string)
+		if !ok && answer != nil {
+			return nil, errors.New("answer must be one of the options")
+		}
+		if choice == "" {
+			return nil, nil
+		}
+		if !contains(question.Options, choice) {
+			return nil, fmt.Errorf("%q is not one of the options", choice)
+		}
+		return choice, nil
+
+	case models.CustomQuestionMultiChoice:
+		var choices []string
+		switch value := answer.(type) {
+		case nil:
+		case string:
+			// Accept a single choice sent as plain text
+			if value != "" {
+				choices = []string{value}
+			}
+		case []string:
+			choices = value
+		case []interface{}:
+			for _, item := range value {
+				choice, ok := item.(string)
+				if !ok {
+					return nil, errors.New("answer must be a list of options")
+				}
+				choices = append(choices, choice)
+			}
+		default:
+			return nil, errors.New("answer must be a list of options")
+		}
+		if len(choices) == 0 {
+			return nil, nil
+		}
+		seen := make(map[string]bool, len(choices))
+		for _, choice := range choices {
+			if !contains(question.Options, choice) {
+				return nil, fmt.Errorf("%q is not one of the options", choice)
+			}
+			if seen[choice] {
+				return nil, fmt.Errorf("%q is chosen twice", choice)
+			}
+			seen[choice] = true
+		}
+		return choices, nil
+
+	case models.CustomQuestionNumber:
+		var number float64
+		switch value := answer.(type) {
+		case nil:
+			return nil, nil
+		case float64:
+			number = value
+		case int:
+			number = float64(value)
+		case json.Number:
+			parsed, err := value.Float64()
+			if err != nil {
+				return nil, errors.New("answer must be a number")
+			}
+			number = parsed
+		case string:
+			// Forms may send numbers as text
+			if strings.TrimSpace(value) == "" {
+				return nil, nil
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				return nil, errors.New("answer must be a number")
+			}
+			number = parsed
+		default:
+			return nil, errors.New("answer must be a number")
+		}
+		if question.Min != nil && number < *question.Min {
+			return nil, fmt.Errorf("answer must be at least %v", *question.Min)
+		}
+		if question.Max != nil && number > *question.Max {
+			return nil, fmt.Errorf("answer must be at most %v", *question.Max)
+		}
+		return number, nil
+	}
+
+	return nil, fmt.Errorf("unsupported question type %q", question.Type)
+}
+
+func findCustomQuestion(questions []models.CustomQuestion, id string) (models.CustomQuestion, bool) {
+	for _, question := range questions {
+		if question.ID == id {
+			return question, true
+		}
+	}
+	return models.CustomQuestion{}, false
+}
+
+// customQuestionStatistics sums up the answers of the counted RSVPs of a
+// wedding to each of its custom questions, in question order
+func (s *RSVPService) customQuestionStatistics(ctx context.Context, wedding *models.Wedding) ([]models.CustomQuestionStatistics, error) {
+	questions := wedding.RSVP.CustomQuestions
+	if len(questions) == 0 {
+		return nil, nil
+	}
+
+	tallies := make([]*customQuestionTally, len(questions))
+	index := make(map[string]int, len(questions))
+	for i, question := range questions {
+		tallies[i] = newCustomQuestionTally(question)
+		index[question.ID] = i
+	}
+
+	err := s.rsvpRepo.StreamByWedding(ctx, wedding.ID, func(rsvp *models.RSVP) error {
+		// Flagged RSVPs only count once accepted, as in the other statistics
+		if !rsvp.IsCounted() {
+			return nil
+		}
+		for _, answer := range rsvp.CustomAnswers {
+			if i, ok := index[answer.QuestionID]; ok {
+				tallies[i].add(answer.Answer)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]models.CustomQuestionStatistics, 0, len(tallies))
+	for _, tally := range tallies {
+		stats = append(stats, tally.statistics())
+	}
+	return stats, nil
+}
+
+// customQuestionTally accumulates the answers to one custom question
+type customQuestionTally struct {
+	question models.CustomQuestion
+	stats    models.CustomQuestionStatistics
+	options  map[string]int
+	numbers  int
+}
+
+func newCustomQuestionTally(question models.CustomQuestion) *customQuestionTally {
+	tally := &customQuestionTally{
+		question: question,
+		stats: models.CustomQuestionStatistics{
+			QuestionID: question.ID,
+			Question:   question.Question,
+			Type:       question.Kind(),
+		},
+	}
+	if question.IsChoice() {
+		tally.options = make(map[string]int, len(question.Options))
+	}
+	return tally
+}
+
+func (t *customQuestionTally) add(answer interface{}) {
+	if answer == nil {
+		return
+	}
+	t.stats.Responses++
+
+	switch t.question.Kind() {
+	case models.CustomQuestionSingleChoice, models.CustomQuestionMultiChoice:
+		switch value := answer.(type) {
+		case string:
+			t.options[value]++
+		case []string:
+			for _, choice := range value {
+				t.options[choice]++
+			}
+		case primitive.A:
+			t.addChoices(value)
+		case []interface{}:
+			t.addChoices(value)
+		}
+	case models.CustomQuestionNumber:
+		number, ok := answerNumber(answer)
+		if !ok {
+			return
+		}
+		if t.stats.Number == nil {
+			t.stats.Number = &models.NumberAnswerSummary{Min: number, Max: number}
+		}
+		if number < t.stats.Number.Min {
+			t.stats.Number.Min = number
+		}
+		if number > t.stats.Number.Max {
+			t.stats.Number.Max = number
+		}
+		t.stats.Number.Sum += number
+		t.numbers++
+	}
+}
+
+func (t *customQuestionTally) addChoices(choices []interface{}) {
+	for _, choice := range choices {
+		if value, ok := choice.(string); ok {
+			t.options[value]++
+		}
+	}
+}
+
+// statistics lists every option of choice questions, including those nobody picked
+func (t *customQuestionTally) statistics() models.CustomQuestionStatistics {
+	stats := t.stats
+	if t.options != nil {
+		stats.Options = make([]models.OptionCount, 0, len(t.question.Options))
+		for _, option := range t.question.Options {
+			stats.Options = append(stats.Options, models.OptionCount{Option: option, Count: t.options[option]})
+		}
+	}
+	if stats.Number != nil {
+		stats.Number.Average = stats.Number.Sum / float64(t.numbers)
+	}
+	return stats
+}
+
+// answerNumber reads a stored number answer, which MongoDB may decode as any numeric type
+func answerNumber(answer interface{}) (float64, bool) {
+	switch value := answer.(type) {
+	case float64:
+		return value, true
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	}
+	return 0, false
+}
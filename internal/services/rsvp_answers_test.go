@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// questionWedding is a published wedding asking one question of every type
+func questionWedding(weddingID, userID models.ID) *models.Wedding {
+	minGuests, maxGuests := 0.0, 10.0
+	return &models.Wedding{
+		ID:     weddingID,
+		UserID: userID,
+		Status: "published",
+		RSVP: models.RSVPSettings{
+			Enabled:     true,
+			MaxPlusOnes: 2,
+			CustomQuestions: []models.CustomQuestion{
+				{ID: "song", Question: "Song request", Type: models.CustomQuestionText},
+				{ID: "meal", Question: "Meal", Type: models.CustomQuestionSingleChoice, Required: true, Options: []string{"Chicken", "Fish", "Vegetarian"}},
+				{ID: "days", Question: "Days attending", Type: models.CustomQuestionMultiChoice, Options: []string{"Friday", "Saturday"}},
+				{ID: "kids", Question: "Children", Type: models.CustomQuestionNumber, Min: &minGuests, Max: &maxGuests},
+			},
+		},
+	}
+}
+
+func TestRSVPService_SubmitRSVP_CustomAnswers(t *testing.T) {
+	weddingID := models.NewID()
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(questionWedding(weddingID, models.NewID()), nil)
+	weddingRepo.On("UpdateRSVPCount", mock.Anything, weddingID).Return(nil)
+	service := NewRSVPService(NewMockRSVPRepository(), weddingRepo)
+
+	submit := func(answers ...models.CustomAnswer) (*models.RSVP, error) {
+		return service.SubmitRSVP(context.Background(), weddingID, SubmitRSVPRequest{
+			FirstName:       "John",
+			LastName:        "Doe",
+			Status:          "attending",
+			AttendanceCount: 1,
+			CustomAnswers:   answers,
+		})
+	}
+
+	t.Run("Success - answers are normalized in question order", func(t *testing.T) {
+		rsvp, err := submit(
+			models.CustomAnswer{QuestionID: "kids", Answer: "2"},
+			models.CustomAnswer{QuestionID: "days", Answer: []interface{}{"Friday", "Saturday"}},
+			models.CustomAnswer{QuestionID: "meal", Answer: "Fish"},
+			models.CustomAnswer{QuestionID: "song", Answer: "  "},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []models.CustomAnswer{
+			{QuestionID: "meal", Question: "Meal", Answer: "Fish"},
+			{QuestionID: "days", Question: "Days attending", Answer: []string{"Friday", "Saturday"}},
+			{QuestionID: "kids", Question: "Children", Answer: 2.0},
+		}, rsvp.CustomAnswers)
+	})
+
+	tests := []struct {
+		name    string
+		answers []models.CustomAnswer
+	}{
+		{"missing required answer", nil},
+		{"unknown question", []models.CustomAnswer{{QuestionID: "meal", Answer: "Fish"}, {QuestionID: "shoe-size", Answer: "42"}}},
+		{"option not offered", []models.CustomAnswer{{QuestionID: "meal", Answer: "Beef"}}},
+		{"choice picked twice", []models.CustomAnswer{{QuestionID: "meal", Answer: "Fish"}, {QuestionID: "days", Answer: []interface{}{"Friday", "Friday"}}}},
+		{"number out of range", []models.CustomAnswer{{QuestionID: "meal", Answer: "Fish"}, {QuestionID: "kids", Answer: 11.0}}},
+		{"not a number", []models.CustomAnswer{{QuestionID: "meal", Answer: "Fish"}, {QuestionID: "kids", Answer: "a few"}}},
+		{"text given a list", []models.CustomAnswer{{QuestionID: "meal", Answer: "Fish"}, {QuestionID: "song", Answer: []interface{}{"a"}}}},
+	}
+	for _, tt := range tests {
+		t.Run("Error - "+tt.name, func(t *testing.T) {
+			_, err := submit(tt.answers...)
+			assert.ErrorIs(t, err, ErrInvalidRSVPAnswers)
+		})
+	}
+}
+
+func TestRSVPService_UpdateRSVP_CustomAnswers(t *testing.T) {
+	weddingID := models.NewID()
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(questionWedding(weddingID, models.NewID()), nil)
+	weddingRepo.On("UpdateRSVPCount", mock.Anything, weddingID).Return(nil)
+	rsvpRepo := NewMockRSVPRepository()
+	service := NewRSVPService(rsvpRepo, weddingRepo)
+
+	rsvp, err := service.SubmitRSVP(context.Background(), weddingID, SubmitRSVPRequest{
+		FirstName:       "John",
+		LastName:        "Doe",
+		Status:          "attending",
+		AttendanceCount: 1,
+		CustomAnswers:   []models.CustomAnswer{{QuestionID: "meal", Answer: "Fish"}},
+	})
+	require.NoError(t, err)
+
+	invalid := []models.CustomAnswer{{QuestionID: "meal", Answer: "Beef"}}
+	_, err = service.UpdateRSVP(context.Background(), rsvp.ID, UpdateRSVPRequest{CustomAnswers: &invalid})
+	assert.ErrorIs(t, err, ErrInvalidRSVPAnswers)
+
+	answers := []models.CustomAnswer{{QuestionID: "meal", Answer: "Vegetarian"}}
+	updated, err := service.UpdateRSVP(context.Background(), rsvp.ID, UpdateRSVPRequest{CustomAnswers: &answers})
+	require.NoError(t, err)
+	assert.Equal(t, []models.CustomAnswer{{QuestionID: "meal", Question: "Meal", Answer: "Vegetarian"}}, updated.CustomAnswers)
+}
+
+func TestRSVPService_GetRSVPStatistics_CustomQuestions(t *testing.T) {
+	weddingID := models.NewID()
+	userID := models.NewID()
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(questionWedding(weddingID, userID), nil)
+	rsvpRepo := NewMockRSVPRepository()
+	service := NewRSVPService(rsvpRepo, weddingRepo)
+
+	add := func(review *models.RSVPReview, answers ...models.CustomAnswer) {
+		rsvp := &models.RSVP{ID: models.NewID(), WeddingID: weddingID, CustomAnswers: answers, Review: review}
+		rsvpRepo.rsvps[rsvp.ID] = rsvp
+	}
+	// Answers as MongoDB decodes them
+	add(nil,
+		models.CustomAnswer{QuestionID: "meal", Answer: "Fish"},
+		models.CustomAnswer{QuestionID: "days", Answer: primitive.A{"Friday", "Saturday"}},
+		models.CustomAnswer{QuestionID: "kids", Answer: 2.0},
+		models.CustomAnswer{QuestionID: "song", Answer: "Shout"},
+	)
+	add(nil,
+		models.CustomAnswer{QuestionID: "meal", Answer: "Fish"},
+		models.CustomAnswer{QuestionID: "days", Answer: primitive.A{"Saturday"}},
+		models.CustomAnswer{QuestionID: "kids", Answer: int32(1)},
+	)
+	// Pending review, not counted
+	add(&models.RSVPReview{Status: models.RSVPReviewPending}, models.CustomAnswer{QuestionID: "meal", Answer: "Chicken"})
+
+	stats, err := service.GetRSVPStatistics(context.Background(), weddingID, userID)
+	require.NoError(t, err)
+	require.Len(t, stats.CustomQuestions, 4)
+
+	song, meal, days, kids := stats.CustomQuestions[0], stats.CustomQuestions[1], stats.CustomQuestions[2], stats.CustomQuestions[3]
+	assert.Equal(t, 1, song.Responses)
+	assert.Nil(t, song.Options)
+
+	assert.Equal(t, models.CustomQuestionSingleChoice, meal.Type)
+	assert.Equal(t, 2, meal.Responses)
+	assert.Equal(t, []models.OptionCount{{Option: "Chicken", Count: 0}, {Option: "Fish", Count: 2}, {Option: "Vegetarian", Count: 0}}, meal.Options)
+
+	assert.Equal(t, []models.OptionCount{{Option: "Friday", Count: 1}, {Option: "Saturday", Count: 2}}, days.Options)
+
+	require.NotNil(t, kids.Number)
+	assert.Equal(t, models.NumberAnswerSummary{Min: 1, Max: 2, Sum: 3, Average: 1.5}, *kids.Number)
+}
+
+func TestRSVPService_GetRSVPQuestions(t *testing.T) {
+	weddingID := models.NewID()
+	userID := models.NewID()
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(questionWedding(weddingID, userID), nil)
+	service := NewRSVPService(NewMockRSVPRepository(), weddingRepo)
+
+	questions, err := service.GetRSVPQuestions(context.Background(), weddingID, userID)
+	require.NoError(t, err)
+	assert.Len(t, questions, 4)
+
+	_, err = service.GetRSVPQuestions(context.Background(), weddingID, models.NewID())
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// ErrInvalidCompanion is returned for plus ones without a first name, with an impossible age or a repeated ID
+var ErrInvalidCompanion = errors.New("companions need a distinct ID, a first name and an age between 0 and 120")
+
+// ErrInvalidHouseholdMember is returned for answers given for guests outside the linked guest's household
+var ErrInvalidHouseholdMember = errors.New("members must be distinct other members of the guest's household")
+
+// maxCompanionAge bounds the age given for a companion
+const maxCompanionAge = 120
+
+// EnableGuestLinking links RSVPs submitted with a guest's signed link token to
+// that guest. secret must be the one GuestQRService signs the tokens with.
+// Linked guests record their RSVP status and named companions, and may bring
+// no more companions than their own plus-one allowance. A grouped guest answers
+// for their whole household, whose allowances add up.
+func (s *RSVPService) EnableGuestLinking(guests repository.GuestRepository, secret string) {
+	s.guestRepo = guests
+	s.guestSecret = secret
+}
+
+// applyCompanions trims the names of an RSVP's companions, checks their ages and
+// gives new companions an ID to be checked in by
+func applyCompanions(rsvp *models.RSVP) error {
+	ids := make(map[string]bool, len(rsvp.PlusOnes))
+	for i := range rsvp.PlusOnes {
+		companion := &rsvp.PlusOnes[i]
+		companion.FirstName = strings.TrimSpace(companion.FirstName)
+		companion.LastName = strings.TrimSpace(companion.LastName)
+		if companion.FirstName == "" {
+			return ErrInvalidCompanion
+		}
+		if companion.Age != nil && (*companion.Age < 0 || *companion.Age > maxCompanionAge) {
+			return ErrInvalidCompanion
+		}
+		if companion.ID == "" {
+			companion.ID = models.NewID().String()
+		}
+		if ids[companion.ID] {
+			return ErrInvalidCompanion
+		}
+		ids[companion.ID] = true
+	}
+	rsvp.PlusOneCount = len(rsvp.PlusOnes)
+	return nil
+}
+
+// guestByToken resolves the guest of the wedding a signed link token belongs to
+func (s *RSVPService) guestByToken(ctx context.Context, weddingID models.ID, token string) (*models.Guest, error) {
+	if s.guestRepo == nil {
+		return nil, ErrInvalidGuestLink
+	}
+
+	guestID, err := utils.VerifyGuestToken(s.guestSecret, token)
+	if err != nil {
+		return nil, ErrInvalidGuestLink
+	}
+
+	guest, err := s.guestRepo.GetByID(ctx, guestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidGuestLink
+		}
+		return nil, fmt.Errorf("failed to get guest: %w", err)
+	}
+	if guest.WeddingID != weddingID {
+		return nil, ErrInvalidGuestLink
+	}
+	return guest, nil
+}
+
+// linkGuest links the RSVP to its guest, holding the companions to the guest's
+// plus-one allowance. The RSVP of a grouped guest answers for each member of the
+// household, none of whom may have answered already. A guest who answered before
+// is only linked, as submitting again through their link updates their RSVP,
+// see previousRSVP; the answers it holds for the household are kept.
+func (s *RSVPService) linkGuest(ctx context.Context, rsvp *models.RSVP, guest *models.Guest) error {
+	if guest.RSVPID != nil {
+		rsvp.GuestID = &guest.ID
+		rsvp.Members = nil
+		return nil
+	}
+
+	limit := guestPlusOneLimit(guest)
+	if guest.GroupID != nil {
+		members, err := s.householdMembers(ctx, guest)
+		if err != nil {
+			return err
+		}
+		if limit, err = linkHousehold(rsvp, guest, members); err != nil {
+			return err
+		}
+	} else if len(rsvp.Members) > 0 {
+		return ErrInvalidHouseholdMember
+	}
+
+	if len(rsvp.PlusOnes) > limit {
+		return ErrTooManyPlusOnes
+	}
+
+	rsvp.GuestID = &guest.ID
+	return nil
+}
+
+// householdMembers returns the other members of a grouped guest's household
+func (s *RSVPService) householdMembers(ctx context.Context, guest *models.Guest) ([]*models.Guest, error) {
+	guests, _, err := s.guestRepo.ListByWedding(ctx, guest.WeddingID, 0, 0, repository.GuestFilters{GroupID: guest.GroupID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get household members: %w", err)
+	}
+
+	members := make([]*models.Guest, 0, len(guests))
+	for _, member := range guests {
+		if member.ID != guest.ID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+// linkHousehold records the answer of the RSVP for each other member of the
+// guest's household and returns the plus-one allowance of the household
+func linkHousehold(rsvp *models.RSVP, guest *models.Guest, members []*models.Guest) (int, error) {
+	answers := make(map[models.ID]string, len(rsvp.Members))
+	for _, answer := range rsvp.Members {
+		if _, repeated := answers[answer.GuestID]; repeated {
+			return 0, ErrInvalidHouseholdMember
+		}
+		answers[answer.GuestID] = answer.Status
+	}
+
+	limit := guestPlusOneLimit(guest)
+	statuses := make([]models.RSVPMemberStatus, 0, len(members))
+	for _, member := range members {
+		if member.RSVPID != nil {
+			return 0, ErrDuplicateRSVP
+		}
+		limit += guestPlusOneLimit(member)
+
+		status, answered := answers[member.ID]
+		if !answered {
+			status = rsvp.Status
+		}
+		delete(answers, member.ID)
+		statuses = append(statuses, models.RSVPMemberStatus{GuestID: member.ID, Status: status})
+	}
+	if len(answers) > 0 {
+		return 0, ErrInvalidHouseholdMember
+	}
+
+	rsvp.GroupID = guest.GroupID
+	rsvp.Members = statuses
+	return limit, nil
+}
+
+// checkGuestAllowance holds the changed companions of a linked RSVP to its guest's plus-one allowance
+func (s *RSVPService) checkGuestAllowance(ctx context.Context, rsvp *models.RSVP) error {
+	if s.guestRepo == nil || rsvp.GuestID == nil {
+		return nil
+	}
+
+	guest, err := s.guestRepo.GetByID(ctx, *rsvp.GuestID)
+	if err != nil {
+		// The guest was removed from the list since
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get guest: %w", err)
+	}
+	limit := guestPlusOneLimit(guest)
+	if rsvp.GroupID != nil {
+		for _, answer := range rsvp.Members {
+			member, err := s.guestRepo.GetByID(ctx, answer.GuestID)
+			if err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					continue
+				}
+				return fmt.Errorf("failed to get guest: %w", err)
+			}
+			limit += guestPlusOneLimit(member)
+		}
+	}
+	if len(rsvp.PlusOnes) > limit {
+		return ErrTooManyPlusOnes
+	}
+	return nil
+}
+
+func guestPlusOneLimit(guest *models.Guest) int {
+	if !guest.AllowPlusOne {
+		return 0
+	}
+	return guest.MaxPlusOnes
+}
+
+// syncGuest copies the status and companions of a linked RSVP onto its guest, and
+// the answers for the rest of the household onto each member. RSVPs held for
+// review are only copied once accepted.
+func (s *RSVPService) syncGuest(ctx context.Context, rsvp *models.RSVP) {
+	if s.guestRepo == nil || rsvp.GuestID == nil || !rsvp.IsCounted() {
+		return
+	}
+
+	guest, err := s.guestRepo.GetByID(ctx, *rsvp.GuestID)
+	if err != nil {
+		fmt.Printf("Failed to get guest of RSVP %s: %v\n", rsvp.ID.String(), err)
+		return
+	}
+
+	guest.RSVPStatus = rsvp.Status
+	guest.RSVPID = &rsvp.ID
+	guest.Companions = rsvp.PlusOnes
+	if err := s.guestRepo.Update(ctx, guest); err != nil {
+		fmt.Printf("Failed to update guest of RSVP %s: %v\n", rsvp.ID.String(), err)
+	}
+
+	for _, answer := range rsvp.Members {
+		member, err := s.guestRepo.GetByID(ctx, answer.GuestID)
+		if err != nil {
+			fmt.Printf("Failed to get household member of RSVP %s: %v\n", rsvp.ID.String(), err)
+			continue
+		}
+		member.RSVPStatus = answer.Status
+		member.RSVPID = &rsvp.ID
+		if err := s.guestRepo.Update(ctx, member); err != nil {
+			fmt.Printf("Failed to update household member of RSVP %s: %v\n", rsvp.ID.String(), err)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/utils"
+)
+
+func TestRSVPService_SubmitRSVP_Companions(t *testing.T) {
+	weddingID := models.NewID()
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(mealWedding(weddingID, models.NewID()), nil)
+	weddingRepo.On("UpdateRSVPCount", mock.Anything, weddingID).Return(nil)
+
+	age := func(years int) *int { return &years }
+	request := func() SubmitRSVPRequest {
+		return SubmitRSVPRequest{
+			FirstName:       "John",
+			LastName:        "Doe",
+			Status:          "attending",
+			AttendanceCount: 1,
+			PlusOnes: []models.PlusOneInfo{
+				{FirstName: " Jane ", LastName: "Doe"},
+				{FirstName: "Tim", LastName: "Doe", Age: age(7)},
+			},
+		}
+	}
+
+	t.Run("Success - companions are named and counted", func(t *testing.T) {
+		service := NewRSVPService(NewMockRSVPRepository(), weddingRepo)
+
+		rsvp, err := service.SubmitRSVP(context.Background(), weddingID, request())
+		require.NoError(t, err)
+		require.Len(t, rsvp.PlusOnes, 2)
+		assert.Equal(t, 2, rsvp.PlusOneCount)
+		assert.Equal(t, 3, rsvp.GetTotalGuests())
+		assert.Equal(t, "Jane", rsvp.PlusOnes[0].FirstName)
+		assert.Equal(t, "Tim Doe (7)", rsvp.PlusOnes[1].Label())
+		assert.NotEmpty(t, rsvp.PlusOnes[0].ID)
+		assert.NotEqual(t, rsvp.PlusOnes[0].ID, rsvp.PlusOnes[1].ID)
+	})
+
+	t.Run("Error - invalid companions", func(t *testing.T) {
+		service := NewRSVPService(NewMockRSVPRepository(), weddingRepo)
+
+		req := request()
+		req.PlusOnes[0].FirstName = " "
+		_, err := service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrInvalidCompanion)
+
+		req = request()
+		req.PlusOnes[1].Age = age(-1)
+		_, err = service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrInvalidCompanion)
+	})
+
+	t.Run("Guest link - companions are recorded on the guest", func(t *testing.T) {
+		guestRepo := NewMockGuestRepository()
+		guest := &models.Guest{WeddingID: weddingID, FirstName: "John", LastName: "Doe", AllowPlusOne: true, MaxPlusOnes: 2}
+		require.NoError(t, guestRepo.Create(context.Background(), guest))
+		service := NewRSVPService(NewMockRSVPRepository(), weddingRepo)
+		service.EnableGuestLinking(guestRepo, "link-secret")
+
+		req := request()
+		req.GuestToken = utils.SignGuestToken("link-secret", guest.ID)
+		rsvp, err := service.SubmitRSVP(context.Background(), weddingID, req)
+		require.NoError(t, err)
+		require.NotNil(t, rsvp.GuestID)
+		assert.Equal(t, guest.ID, *rsvp.GuestID)
+
+		assert.Equal(t, "attending", guest.RSVPStatus)
+		require.NotNil(t, guest.RSVPID)
+		assert.Equal(t, rsvp.ID, *guest.RSVPID)
+		assert.Equal(t, rsvp.PlusOnes, guest.Companions)
+
+		// Answering again updates the guest's RSVP
+		req = request()
+		req.Email = "other@example.com"
+		req.Status = "not-attending"
+		req.PlusOnes = nil
+		req.GuestToken = utils.SignGuestToken("link-secret", guest.ID)
+		updated, err := service.SubmitRSVP(context.Background(), weddingID, req)
+		require.NoError(t, err)
+		assert.Equal(t, rsvp.ID, updated.ID)
+		assert.Equal(t, "not-attending", guest.RSVPStatus)
+		assert.Empty(t, guest.Companions)
+	})
+
+	t.Run("Household - one RSVP answers for every member", func(t *testing.T) {
+		guestRepo := NewMockGuestRepository()
+		groupID := models.NewID()
+		john := &models.Guest{WeddingID: weddingID, FirstName: "John", GroupID: &groupID, AllowPlusOne: true, MaxPlusOnes: 1}
+		mary := &models.Guest{WeddingID: weddingID, FirstName: "Mary", GroupID: &groupID, AllowPlusOne: true, MaxPlusOnes: 1}
+		tom := &models.Guest{WeddingID: weddingID, FirstName: "Tom", GroupID: &groupID}
+		for _, guest := range []*models.Guest{john, mary, tom} {
+			require.NoError(t, guestRepo.Create(context.Background(), guest))
+		}
+		service := NewRSVPService(NewMockRSVPRepository(), weddingRepo)
+		service.EnableGuestLinking(guestRepo, "link-secret")
+
+		// Two companions fit the allowances of John and Mary together
+		req := request()
+		req.GuestToken = utils.SignGuestToken("link-secret", john.ID)
+		req.Members = []models.RSVPMemberStatus{{GuestID: tom.ID, Status: "not-attending"}}
+		rsvp, err := service.SubmitRSVP(context.Background(), weddingID, req)
+		require.NoError(t, err)
+		require.NotNil(t, rsvp.GroupID)
+		assert.Equal(t, groupID, *rsvp.GroupID)
+		assert.ElementsMatch(t, []models.RSVPMemberStatus{
+			{GuestID: mary.ID, Status: "attending"},
+			{GuestID: tom.ID, Status: "not-attending"},
+		}, rsvp.Members)
+
+		assert.Equal(t, "attending", john.RSVPStatus)
+		assert.Equal(t, "attending", mary.RSVPStatus)
+		assert.Equal(t, "not-attending", tom.RSVPStatus)
+		for _, guest := range []*models.Guest{john, mary, tom} {
+			require.NotNil(t, guest.RSVPID)
+			assert.Equal(t, rsvp.ID, *guest.RSVPID)
+		}
+		assert.Len(t, john.Companions, 2)
+		assert.Empty(t, mary.Companions)
+
+		// The household already answered through John
+		req = request()
+		req.Email = "mary@example.com"
+		req.PlusOnes = nil
+		req.GuestToken = utils.SignGuestToken("link-secret", mary.ID)
+		_, err = service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrDuplicateRSVP)
+	})
+
+	t.Run("Household - errors", func(t *testing.T) {
+		guestRepo := NewMockGuestRepository()
+		groupID := models.NewID()
+		john := &models.Guest{WeddingID: weddingID, FirstName: "John", GroupID: &groupID, AllowPlusOne: true, MaxPlusOnes: 1}
+		mary := &models.Guest{WeddingID: weddingID, FirstName: "Mary", GroupID: &groupID}
+		single := &models.Guest{WeddingID: weddingID, FirstName: "Sam", AllowPlusOne: true, MaxPlusOnes: 2}
+		for _, guest := range []*models.Guest{john, mary, single} {
+			require.NoError(t, guestRepo.Create(context.Background(), guest))
+		}
+		service := NewRSVPService(NewMockRSVPRepository(), weddingRepo)
+		service.EnableGuestLinking(guestRepo, "link-secret")
+
+		req := request()
+		req.GuestToken = utils.SignGuestToken("link-secret", john.ID)
+		_, err := service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrTooManyPlusOnes)
+
+		req.PlusOnes = nil
+		req.Members = []models.RSVPMemberStatus{{GuestID: single.ID, Status: "attending"}}
+		_, err = service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrInvalidHouseholdMember)
+
+		req.Members = []models.RSVPMemberStatus{{GuestID: mary.ID, Status: "attending"}, {GuestID: mary.ID, Status: "maybe"}}
+		_, err = service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrInvalidHouseholdMember)
+
+		// Guests without a household cannot answer for others
+		req.GuestToken = utils.SignGuestToken("link-secret", single.ID)
+		req.Members = []models.RSVPMemberStatus{{GuestID: mary.ID, Status: "attending"}}
+		_, err = service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrInvalidHouseholdMember)
+
+		req.GuestToken = ""
+		_, err = service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrInvalidHouseholdMember)
+		assert.Nil(t, john.RSVPID)
+		assert.Nil(t, mary.RSVPID)
+	})
+
+	t.Run("Guest link - errors", func(t *testing.T) {
+		guestRepo := NewMockGuestRepository()
+		guest := &models.Guest{WeddingID: weddingID, FirstName: "John", AllowPlusOne: true, MaxPlusOnes: 1}
+		stranger := &models.Guest{WeddingID: models.NewID(), FirstName: "Eve"}
+		require.NoError(t, guestRepo.Create(context.Background(), guest))
+		require.NoError(t, guestRepo.Create(context.Background(), stranger))
+		service := NewRSVPService(NewMockRSVPRepository(), weddingRepo)
+		service.EnableGuestLinking(guestRepo, "link-secret")
+
+		req := request()
+		req.GuestToken = utils.SignGuestToken("link-secret", guest.ID)
+		_, err := service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrTooManyPlusOnes)
+
+		req.GuestToken = utils.SignGuestToken("link-secret", stranger.ID)
+		_, err = service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrInvalidGuestLink)
+
+		req.GuestToken = "forged"
+		_, err = service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrInvalidGuestLink)
+		assert.Nil(t, guest.RSVPID)
+	})
+}
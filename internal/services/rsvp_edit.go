@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// rsvpEditLinkTTL is how long the edit link sent after an RSVP stays valid.
+// Edits are also refused once the wedding's RSVP period closes.
+const rsvpEditLinkTTL = 90 * 24 * time.Hour
+
+var (
+	ErrInvalidRSVPEditLink = errors.New("invalid RSVP edit link")
+	ErrExpiredRSVPEditLink = errors.New("RSVP edit link has expired")
+	ErrRSVPEditingDisabled = errs.Forbidden("RSVP editing is disabled for this wedding")
+)
+
+// rsvpEditLinks signs the edit links guests receive after submitting an RSVP
+type rsvpEditLinks struct {
+	email   EmailService
+	secret  string
+	siteURL string
+}
+
+// EnableEditLinks emails guests who leave an address a signed link, valid for 90
+// days, to change their RSVP themselves. siteURL is the frontend the links point to.
+func (s *RSVPService) EnableEditLinks(email EmailService, secret, siteURL string) {
+	if siteURL == "" {
+		siteURL = DefaultInvitationOptions().SiteURL
+	}
+	s.editLinks = &rsvpEditLinks{email: email, secret: secret, siteURL: strings.TrimRight(siteURL, "/")}
+}
+
+// EditLink returns a new frontend link letting the guest edit the RSVP, or an
+// empty string when edit links are not enabled or the wedding's rsvp_editing
+// feature is off
+func (s *RSVPService) EditLink(ctx context.Context, rsvp *models.RSVP) string {
+	if s.editLinks == nil || !featureEnabled(ctx, s.features, rsvp.WeddingID, FeatureRSVPEditing) {
+		return ""
+	}
+	token := utils.SignRSVPEditToken(s.editLinks.secret, rsvp.ID, time.Now().Add(rsvpEditLinkTTL))
+	query := url.Values{}
+	query.Set("token", token)
+	return fmt.Sprintf("%s/rsvp/edit?%s", s.editLinks.siteURL, query.Encode())
+}
+
+// GetRSVPByEditToken returns the RSVP an edit link belongs to
+func (s *RSVPService) GetRSVPByEditToken(ctx context.Context, token string) (*models.RSVP, error) {
+	if s.editLinks == nil {
+		return nil, ErrInvalidRSVPEditLink
+	}
+
+	rsvpID, err := utils.VerifyRSVPEditToken(s.editLinks.secret, token)
+	if err != nil {
+		if errors.Is(err, utils.ErrExpiredRSVPEditToken) {
+			return nil, ErrExpiredRSVPEditLink
+		}
+		return nil, ErrInvalidRSVPEditLink
+	}
+
+	rsvp, err := s.rsvpRepo.GetByID(ctx, rsvpID)
+	if err != nil {
+		// The RSVP was deleted since the link was sent
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidRSVPEditLink
+		}
+		return nil, fmt.Errorf("failed to get RSVP: %w", err)
+	}
+	if !featureEnabled(ctx, s.features, rsvp.WeddingID, FeatureRSVPEditing) {
+		return nil, ErrRSVPEditingDisabled
+	}
+	return rsvp, nil
+}
+
+// rsvpByEditToken returns the RSVP of the wedding an edit link sent with a new
+// submission belongs to
+func (s *RSVPService) rsvpByEditToken(ctx context.Context, weddingID models.ID, token string) (*models.RSVP, error) {
+	rsvp, err := s.GetRSVPByEditToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if rsvp.WeddingID != weddingID {
+		return nil, ErrInvalidRSVPEditLink
+	}
+	return rsvp, nil
+}
+
+// UpdateRSVPByEditToken applies a guest's own changes to their RSVP while the
+// wedding's RSVP period is open. The changes are recorded in the RSVP's history.
+func (s *RSVPService) UpdateRSVPByEditToken(ctx context.Context, token string, req UpdateRSVPRequest) (*models.RSVP, error) {
+	rsvp, err := s.GetRSVPByEditToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	wedding, err := s.weddingRepo.GetByID(ctx, rsvp.WeddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	if !s.isRSVPOpen(wedding) {
+		return nil, ErrRSVPClosed
+	}
+
+	return s.applyUpdate(ctx, rsvp, wedding, req, models.RSVPChangedByGuest)
+}
+
+// sendEditLink emails the guest a confirmation of their RSVP with the link to edit it
+func (s *RSVPService) sendEditLink(ctx context.Context, rsvp *models.RSVP) {
+	if rsvp.Email == "" {
+		return
+	}
+	editLink := s.EditLink(ctx, rsvp)
+	if editLink == "" {
+		return
+	}
+
+	wedding, err := s.weddingRepo.GetByID(ctx, rsvp.WeddingID)
+	if err != nil || wedding == nil {
+		fmt.Printf("Failed to get wedding of RSVP %s: %v\n", rsvp.ID.String(), err)
+		return
+	}
+
+	msg, err := DefaultEmailTemplates().Render(EmailTemplateRSVPConfirmation, struct {
+		FirstName, Title, EditLink string
+	}{rsvp.FirstName, wedding.Title, editLink})
+	if err != nil {
+		fmt.Printf("Failed to render confirmation of RSVP %s: %v\n", rsvp.ID.String(), err)
+		return
+	}
+	msg.To = rsvp.Email
+	if err := s.editLinks.email.Send(ctx, msg); err != nil {
+		fmt.Printf("Failed to send edit link of RSVP %s: %v\n", rsvp.ID.String(), err)
+		return
+	}
+	if err := s.rsvpRepo.MarkConfirmationSent(ctx, rsvp.ID); err != nil {
+		fmt.Printf("Failed to mark confirmation of RSVP %s sent: %v\n", rsvp.ID.String(), err)
+	}
+}
+
+// rsvpField is an RSVP field as recorded in the RSVP's change history
+type rsvpField struct {
+	name  string
+	value string
+}
+
+// auditedRSVPFields describes the fields of an RSVP a guest or owner may change
+func auditedRSVPFields(rsvp *models.RSVP) []rsvpField {
+	sessions := make([]string, len(rsvp.Sessions))
+	for i, session := range rsvp.Sessions {
+		sessions[i] = session.SessionID + ": " + session.Status
+	}
+	companions := make([]string, len(rsvp.PlusOnes))
+	for i, companion := range rsvp.PlusOnes {
+		companions[i] = companion.Label()
+		if companion.MealChoice != "" {
+			companions[i] += ", meal: " + companion.MealChoice
+		}
+		if len(companion.Allergies) > 0 {
+			companions[i] += ", allergies: " + strings.Join(companion.Allergies, ", ")
+		}
+	}
+	answers := make([]string, len(rsvp.CustomAnswers))
+	for i, answer := range rsvp.CustomAnswers {
+		answers[i] = answer.Question + ": " + answer.Text()
+	}
+
+	return []rsvpField{
+		{"status", rsvp.Status},
+		{"attendance_count", strconv.Itoa(rsvp.AttendanceCount)},
+		{"sessions", strings.Join(sessions, "; ")},
+		{"companions", strings.Join(companions, "; ")},
+		{"dietary_restrictions", rsvp.DietaryRestrictions},
+		{"dietary_selected", strings.Join(rsvp.DietarySelected, ", ")},
+		{"additional_notes", rsvp.AdditionalNotes},
+		{"meal_choice", rsvp.MealChoice},
+		{"allergies", strings.Join(rsvp.Allergies, ", ")},
+		{"custom_answers", strings.Join(answers, "; ")},
+	}
+}
+
+// changedRSVPFields compares two descriptions made by auditedRSVPFields
+func changedRSVPFields(before, after []rsvpField) []models.RSVPFieldChange {
+	var changes []models.RSVPFieldChange
+	for i := range before {
+		if before[i].value != after[i].value {
+			changes = append(changes, models.RSVPFieldChange{Field: before[i].name, From: before[i].value, To: after[i].value})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// editToken extracts the token from an edit link
+func editToken(t *testing.T, link string) string {
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "/rsvp/edit", parsed.Path)
+	return parsed.Query().Get("token")
+}
+
+func TestRSVPService_EditLinks(t *testing.T) {
+	ctx := context.Background()
+	weddingID := models.NewID()
+	wedding := mealWedding(weddingID, models.NewID())
+	wedding.Title = "John & Jane"
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(wedding, nil)
+	weddingRepo.On("UpdateRSVPCount", mock.Anything, weddingID).Return(nil)
+	rsvpRepo := NewMockRSVPRepository()
+	email := &MockEmailService{}
+	service := NewRSVPService(rsvpRepo, weddingRepo)
+	service.EnableEditLinks(email, "link-secret", "https://example.com/")
+
+	rsvp, err := service.SubmitRSVP(ctx, weddingID, SubmitRSVPRequest{
+		FirstName: "John", LastName: "Doe", Email: "john@example.com",
+		Status: "attending", AttendanceCount: 1, MealChoice: "Beef",
+	})
+	require.NoError(t, err)
+
+	// The guest is emailed the link
+	require.Len(t, email.sent, 1)
+	assert.Equal(t, "john@example.com", email.sent[0].To)
+	assert.Contains(t, email.sent[0].Text, "https://example.com/rsvp/edit?token=")
+	assert.True(t, rsvp.ConfirmationSent)
+	token := editToken(t, service.EditLink(context.Background(), rsvp))
+
+	t.Run("Get by token", func(t *testing.T) {
+		found, err := service.GetRSVPByEditToken(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, rsvp.ID, found.ID)
+
+		_, err = service.GetRSVPByEditToken(ctx, utils.SignRSVPEditToken("other-secret", rsvp.ID, time.Now().Add(time.Hour)))
+		assert.ErrorIs(t, err, ErrInvalidRSVPEditLink)
+		_, err = service.GetRSVPByEditToken(ctx, utils.SignRSVPEditToken("link-secret", rsvp.ID, time.Now().Add(-time.Hour)))
+		assert.ErrorIs(t, err, ErrExpiredRSVPEditLink)
+		_, err = service.GetRSVPByEditToken(ctx, utils.SignRSVPEditToken("link-secret", models.NewID(), time.Now().Add(time.Hour)))
+		assert.ErrorIs(t, err, ErrInvalidRSVPEditLink)
+	})
+
+	t.Run("Guest changes are recorded", func(t *testing.T) {
+		status := "maybe"
+		meal := "Fish"
+		companions := []models.PlusOneInfo{{FirstName: "Jane", LastName: "Doe"}}
+		updated, err := service.UpdateRSVPByEditToken(ctx, token, UpdateRSVPRequest{
+			Status: &status, MealChoice: &meal, PlusOnes: &companions,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Fish", updated.MealChoice)
+
+		require.Len(t, updated.Changes, 1)
+		change := updated.Changes[0]
+		assert.Equal(t, models.RSVPChangedByGuest, change.ChangedBy)
+		assert.Equal(t, []models.RSVPFieldChange{
+			{Field: "status", From: "attending", To: "maybe"},
+			{Field: "companions", From: "", To: "Jane Doe"},
+			{Field: "meal_choice", From: "Beef", To: "Fish"},
+		}, change.Fields)
+
+		// Nothing changed: nothing recorded
+		_, err = service.UpdateRSVPByEditToken(ctx, token, UpdateRSVPRequest{MealChoice: &meal})
+		require.NoError(t, err)
+		assert.Len(t, updated.Changes, 1)
+	})
+
+	t.Run("Owner changes are recorded", func(t *testing.T) {
+		notes := "Arriving late"
+		updated, err := service.UpdateRSVP(ctx, rsvp.ID, UpdateRSVPRequest{AdditionalNotes: &notes})
+		require.NoError(t, err)
+		last := updated.Changes[len(updated.Changes)-1]
+		assert.Equal(t, models.RSVPChangedByOwner, last.ChangedBy)
+		assert.Equal(t, []models.RSVPFieldChange{{Field: "additional_notes", From: "", To: "Arriving late"}}, last.Fields)
+	})
+
+	t.Run("Error - RSVP period closed", func(t *testing.T) {
+		deadline := time.Now().Add(-time.Hour)
+		wedding.RSVP.Deadline = &deadline
+		defer func() { wedding.RSVP.Deadline = nil }()
+
+		status := "not-attending"
+		_, err := service.UpdateRSVPByEditToken(ctx, token, UpdateRSVPRequest{Status: &status})
+		assert.ErrorIs(t, err, ErrRSVPClosed)
+	})
+}
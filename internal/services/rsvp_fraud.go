@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// Score contributed by each fraud signal
+const (
+	rsvpIPVelocityScore        = 50
+	rsvpGuestNameMismatchScore = 50
+	rsvpDisposableEmailScore   = 30
+)
+
+// RSVPFraudConfig tunes when public RSVP submissions are flagged for owner review
+type RSVPFraudConfig struct {
+	// VelocityWindow and VelocityLimit flag an IP that already submitted
+	// VelocityLimit RSVPs to the same wedding within the window
+	VelocityWindow time.Duration
+	VelocityLimit  int
+	// FlagThreshold is the total score at which a submission is held for review
+	FlagThreshold int
+}
+
+// DefaultRSVPFraudConfig returns the default fraud scoring configuration
+func DefaultRSVPFraudConfig() RSVPFraudConfig {
+	return RSVPFraudConfig{
+		VelocityWindow: time.Hour,
+		VelocityLimit:  3,
+		FlagThreshold:  50,
+	}
+}
+
+// disposableEmailDomains lists throwaway mailbox providers commonly used for fake submissions
+var disposableEmailDomains = map[string]struct{}{
+	"10minutemail.com":       {},
+	"dispostable.com":        {},
+	"fakeinbox.com":          {},
+	"getnada.com":            {},
+	"guerrillamail.com":      {},
+	"guerrillamail.net":      {},
+	"mailinator.com":         {},
+	"maildrop.cc":            {},
+	"mintemail.com":          {},
+	"mohmal.com":             {},
+	"sharklasers.com":        {},
+	"spamgourmet.com":        {},
+	"temp-mail.org":          {},
+	"tempmail.com":           {},
+	"tempmailo.com":          {},
+	"throwawaymail.com":      {},
+	"trashmail.com":          {},
+	"yopmail.com":            {},
+	"emailondeck.com":        {},
+	"mailnesia.com":          {},
+	"burnermail.io":          {},
+	"discard.email":          {},
+	"tempr.email":            {},
+	"guerrillamailblock.com": {},
+}
+
+// RSVPFraudScorer scores public RSVP submissions and flags suspicious ones for owner
+// review instead of letting them count towards the wedding's responses
+type RSVPFraudScorer struct {
+	rsvpRepo  repository.RSVPRepository
+	guestRepo repository.GuestRepository
+	config    RSVPFraudConfig
+	logger    *zap.Logger
+}
+
+// NewRSVPFraudScorer creates a new RSVP fraud scorer
+func NewRSVPFraudScorer(rsvpRepo repository.RSVPRepository, guestRepo repository.GuestRepository, config RSVPFraudConfig, logger *zap.Logger) *RSVPFraudScorer {
+	return &RSVPFraudScorer{
+		rsvpRepo:  rsvpRepo,
+		guestRepo: guestRepo,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+// Score evaluates a prepared RSVP and returns a pending review when the submission
+// reaches the flag threshold, or nil when it can be accepted. Lookups that fail are
+// logged and skipped so scoring never blocks a genuine guest.
+func (s *RSVPFraudScorer) Score(ctx context.Context, rsvp *models.RSVP) *models.RSVPReview {
+	score := 0
+	var signals []string
+
+	if rsvp.IPAddress != "" && s.config.VelocityLimit > 0 {
+		since := time.Now().Add(-s.config.VelocityWindow)
+		count, err := s.rsvpRepo.CountByIPSince(ctx, rsvp.WeddingID, rsvp.IPAddress, since)
+		if err != nil {
+			s.logger.Warn("Failed to check RSVP submission velocity", zap.Error(err))
+		} else if count >= int64(s.config.VelocityLimit) {
+			score += rsvpIPVelocityScore
+			signals = append(signals, models.RSVPSignalIPVelocity)
+		}
+	}
+
+	if rsvp.Email != "" {
+		if isDisposableEmail(rsvp.Email) {
+			score += rsvpDisposableEmailScore
+			signals = append(signals, models.RSVPSignalDisposableEmail)
+		}
+
+		guest, err := s.guestRepo.GetByEmail(ctx, rsvp.WeddingID, rsvp.Email)
+		switch {
+		case err == nil:
+			if !sameGuestName(guest.FirstName, guest.LastName, rsvp.FirstName, rsvp.LastName) {
+				score += rsvpGuestNameMismatchScore
+				signals = append(signals, models.RSVPSignalGuestNameMismatch)
+			}
+		case !errors.Is(err, repository.ErrNotFound):
+			s.logger.Warn("Failed to look up invited guest for RSVP", zap.Error(err))
+		}
+	}
+
+	if score < s.config.FlagThreshold {
+		return nil
+	}
+
+	return &models.RSVPReview{
+		Status:    models.RSVPReviewPending,
+		Score:     score,
+		Signals:   signals,
+		FlaggedAt: time.Now(),
+	}
+}
+
+// isDisposableEmail checks whether the email belongs to a throwaway mailbox provider
+func isDisposableEmail(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	_, ok := disposableEmailDomains[strings.ToLower(strings.TrimSpace(email[at+1:]))]
+	return ok
+}
+
+// sameGuestName compares an invited guest's name with the submitted one, ignoring case and spacing
+func sameGuestName(guestFirst, guestLast, first, last string) bool {
+	normalize := func(name string) string {
+		return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	}
+	return normalize(guestFirst) == normalize(first) && normalize(guestLast) == normalize(last)
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func setupFraudScoredRSVPService(t *testing.T) (*RSVPService, *MockRSVPRepository, *MockGuestRepository, *models.Wedding) {
+	rsvpRepo := NewMockRSVPRepository()
+	guestRepo := NewMockGuestRepository()
+	weddingRepo := &MockWeddingRepository{}
+
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		UserID: models.NewID(),
+		Status: "published",
+		RSVP: models.RSVPSettings{
+			Enabled:     true,
+			MaxPlusOnes: 2,
+		},
+	}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+	weddingRepo.On("UpdateRSVPCount", mock.Anything, wedding.ID).Return(nil)
+
+	service := NewRSVPService(rsvpRepo, weddingRepo)
+	service.EnableFraudScoring(NewRSVPFraudScorer(rsvpRepo, guestRepo, DefaultRSVPFraudConfig(), zaptest.NewLogger(t)))
+	return service, rsvpRepo, guestRepo, wedding
+}
+
+func TestRSVPFraudScorer_FlagsSuspiciousSubmissions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Clean submission is accepted", func(t *testing.T) {
+		service, _, _, wedding := setupFraudScoredRSVPService(t)
+
+		rsvp, err := service.SubmitRSVP(ctx, wedding.ID, SubmitRSVPRequest{
+			FirstName: "John", LastName: "Doe", Email: "john@example.com",
+			Status: "attending", AttendanceCount: 1, IPAddress: "10.0.0.1",
+		})
+		require.NoError(t, err)
+		assert.Nil(t, rsvp.Review)
+		assert.True(t, rsvp.IsCounted())
+	})
+
+	t.Run("Disposable email alone stays below the threshold", func(t *testing.T) {
+		service, _, _, wedding := setupFraudScoredRSVPService(t)
+
+		rsvp, err := service.SubmitRSVP(ctx, wedding.ID, SubmitRSVPRequest{
+			FirstName: "John", LastName: "Doe", Email: "john@Mailinator.com",
+			Status: "attending", AttendanceCount: 1,
+		})
+		require.NoError(t, err)
+		assert.Nil(t, rsvp.Review)
+	})
+
+	t.Run("Invited guest email with another name is flagged", func(t *testing.T) {
+		service, _, guestRepo, wedding := setupFraudScoredRSVPService(t)
+		guest := &models.Guest{ID: models.NewID(), WeddingID: wedding.ID, FirstName: "Jane", LastName: "Smith", Email: "jane@yopmail.com"}
+		guestRepo.guests[guest.ID] = guest
+
+		rsvp, err := service.SubmitRSVP(ctx, wedding.ID, SubmitRSVPRequest{
+			FirstName: "Mallory", LastName: "Smith", Email: "jane@yopmail.com",
+			Status: "attending", AttendanceCount: 1,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, rsvp.Review)
+		assert.True(t, rsvp.NeedsReview())
+		assert.False(t, rsvp.IsCounted())
+		assert.Equal(t, 80, rsvp.Review.Score)
+		assert.ElementsMatch(t, []string{models.RSVPSignalGuestNameMismatch, models.RSVPSignalDisposableEmail}, rsvp.Review.Signals)
+	})
+
+	t.Run("Invited guest name matches ignoring case and spacing", func(t *testing.T) {
+		service, _, guestRepo, wedding := setupFraudScoredRSVPService(t)
+		guest := &models.Guest{ID: models.NewID(), WeddingID: wedding.ID, FirstName: "Mary Ann", LastName: "Smith", Email: "mary@example.com"}
+		guestRepo.guests[guest.ID] = guest
+
+		rsvp, err := service.SubmitRSVP(ctx, wedding.ID, SubmitRSVPRequest{
+			FirstName: " mary  ann", LastName: "SMITH", Email: "mary@example.com",
+			Status: "attending", AttendanceCount: 1,
+		})
+		require.NoError(t, err)
+		assert.Nil(t, rsvp.Review)
+	})
+
+	t.Run("Repeated submissions from one IP are flagged", func(t *testing.T) {
+		service, rsvpRepo, _, wedding := setupFraudScoredRSVPService(t)
+		for i := 0; i < 3; i++ {
+			existing := &models.RSVP{ID: models.NewID(), WeddingID: wedding.ID, IPAddress: "203.0.113.7", SubmittedAt: time.Now()}
+			rsvpRepo.rsvps[existing.ID] = existing
+		}
+
+		rsvp, err := service.SubmitRSVP(ctx, wedding.ID, SubmitRSVPRequest{
+			FirstName: "Bot", LastName: "Four", Status: "attending", AttendanceCount: 1, IPAddress: "203.0.113.7",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, rsvp.Review)
+		assert.Equal(t, []string{models.RSVPSignalIPVelocity}, rsvp.Review.Signals)
+	})
+}
+
+func TestRSVPService_ReviewRSVP(t *testing.T) {
+	ctx := context.Background()
+	service, rsvpRepo, _, wedding := setupFraudScoredRSVPService(t)
+
+	flagged := &models.RSVP{
+		ID:        models.NewID(),
+		WeddingID: wedding.ID,
+		Review:    &models.RSVPReview{Status: models.RSVPReviewPending, Score: 50, Signals: []string{models.RSVPSignalIPVelocity}},
+	}
+	rsvpRepo.rsvps[flagged.ID] = flagged
+
+	_, err := service.ReviewRSVP(ctx, flagged.ID, wedding.UserID, "maybe")
+	assert.ErrorIs(t, err, ErrInvalidReview)
+
+	_, err = service.ReviewRSVP(ctx, flagged.ID, models.NewID(), RSVPReviewAccept)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	reviewed, err := service.ReviewRSVP(ctx, flagged.ID, wedding.UserID, RSVPReviewAccept)
+	require.NoError(t, err)
+	assert.Equal(t, models.RSVPReviewAccepted, reviewed.Review.Status)
+	assert.Equal(t, wedding.UserID, *reviewed.Review.ReviewedBy)
+	assert.True(t, reviewed.IsCounted())
+
+	_, err = service.ReviewRSVP(ctx, flagged.ID, wedding.UserID, RSVPReviewReject)
+	assert.ErrorIs(t, err, ErrRSVPNotInReview)
+
+	_, err = service.ReviewRSVP(ctx, models.NewID(), wedding.UserID, RSVPReviewReject)
+	assert.ErrorIs(t, err, ErrRSVPNotFound)
+}
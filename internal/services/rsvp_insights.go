@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// rsvpInsightsTTL bounds how long cached insights are served. New, changed and
+// reviewed RSVPs invalidate them at once; guest list edits show after the TTL.
+const rsvpInsightsTTL = 5 * time.Minute
+
+// Assumptions of the headcount projection while few RSVPs have decided
+const (
+	defaultAcceptanceRate = 0.5
+	maybeAttendanceRate   = 0.5
+)
+
+// EnableInsightsCache caches computed RSVP insights until the wedding's RSVPs change
+func (s *RSVPService) EnableInsightsCache(cache RSVPInsightsCache) {
+	s.insightsCache = cache
+}
+
+// GetRSVPInsights returns the in-depth statistics of a wedding's RSVPs: how the
+// answers came in over time, the response rate of the guest list, a projected
+// headcount, the answers by side, relationship and VIP status, and the meals to
+// cook. The breakdowns and the response rate need guest linking to be enabled.
+func (s *RSVPService) GetRSVPInsights(ctx context.Context, weddingID models.ID, userID models.ID) (*models.RSVPInsights, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+
+	if !wedding.Can(userID, models.PermissionManageRSVPs) {
+		return nil, ErrUnauthorized
+	}
+
+	if s.insightsCache != nil {
+		if insights, err := s.insightsCache.Get(ctx, weddingID); err == nil && insights != nil {
+			return insights, nil
+		}
+	}
+
+	insights, err := s.computeInsights(ctx, wedding)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.insightsCache != nil {
+		if err := s.insightsCache.Set(ctx, insights, rsvpInsightsTTL); err != nil {
+			// Log error but don't fail the operation
+			fmt.Printf("Failed to cache RSVP insights: %v\n", err)
+		}
+	}
+	return insights, nil
+}
+
+func (s *RSVPService) computeInsights(ctx context.Context, wedding *models.Wedding) (*models.RSVPInsights, error) {
+	insights := &models.RSVPInsights{
+		WeddingID:      wedding.ID,
+		BySide:         []models.AttendanceBreakdown{},
+		ByRelationship: []models.AttendanceBreakdown{},
+		ByVIP:          []models.AttendanceBreakdown{},
+		GeneratedAt:    time.Now(),
+	}
+
+	timeline, err := s.rsvpRepo.GetAttendanceTimeline(ctx, wedding.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance timeline: %w", err)
+	}
+	headcount := &insights.Headcount
+	for i := range timeline {
+		day := &timeline[i]
+		insights.Attending += day.Attending
+		insights.NotAttending += day.NotAttending
+		insights.Maybe += day.Maybe
+		headcount.Confirmed += day.Headcount
+		headcount.Maybe += day.MaybeHeadcount
+		day.TotalHeadcount = headcount.Confirmed
+	}
+	insights.Timeline = timeline
+	insights.Responses = insights.Attending + insights.NotAttending + insights.Maybe
+	// Each attending RSVP counts its guest; everyone else it brings is a plus one
+	headcount.ConfirmedPlusOnes = headcount.Confirmed - insights.Attending
+
+	if s.guestRepo != nil {
+		breakdowns := map[string]*[]models.AttendanceBreakdown{
+			models.GuestBreakdownSide:         &insights.BySide,
+			models.GuestBreakdownRelationship: &insights.ByRelationship,
+			models.GuestBreakdownVIP:          &insights.ByVIP,
+		}
+		for field, breakdown := range breakdowns {
+			if *breakdown, err = s.guestRepo.AttendanceBreakdown(ctx, wedding.ID, field); err != nil {
+				return nil, fmt.Errorf("failed to get attendance by %s: %w", field, err)
+			}
+		}
+		// Every guest is in exactly one group of a breakdown
+		for _, group := range insights.ByVIP {
+			insights.InvitedGuests += group.Invited
+			headcount.PendingGuests += group.Pending
+		}
+		insights.RespondedGuests = insights.InvitedGuests - headcount.PendingGuests
+		if insights.InvitedGuests > 0 {
+			insights.ResponseRate = float64(insights.RespondedGuests) / float64(insights.InvitedGuests)
+		}
+	}
+
+	headcount.AcceptanceRate = defaultAcceptanceRate
+	if decided := insights.Attending + insights.NotAttending; decided > 0 {
+		headcount.AcceptanceRate = float64(insights.Attending) / float64(decided)
+	}
+	headcount.AveragePartySize = 1
+	if insights.Attending > 0 {
+		headcount.AveragePartySize = float64(headcount.Confirmed) / float64(insights.Attending)
+	}
+	headcount.Projected = headcount.Confirmed +
+		int(math.Round(float64(headcount.Maybe)*maybeAttendanceRate)) +
+		int(math.Round(float64(headcount.PendingGuests)*headcount.AcceptanceRate*headcount.AveragePartySize))
+
+	meals, err := s.rsvpRepo.GetMealCounts(ctx, wedding.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get meal counts: %w", err)
+	}
+	insights.NoMealChoice = meals[""]
+	delete(meals, "")
+	insights.Meals = mealCounts(wedding.RSVP.MealOptions, meals)
+
+	return insights, nil
+}
+
+// invalidateInsights drops the cached insights of a wedding whose RSVPs changed
+func (s *RSVPService) invalidateInsights(ctx context.Context, weddingID models.ID) {
+	if s.insightsCache == nil {
+		return
+	}
+	if err := s.insightsCache.Invalidate(ctx, weddingID); err != nil {
+		// Log error but don't fail the operation
+		fmt.Printf("Failed to invalidate RSVP insights: %v\n", err)
+	}
+}
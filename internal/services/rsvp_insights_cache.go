@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// RSVPInsightsCache keeps computed RSVP insights until the wedding's RSVPs change
+type RSVPInsightsCache interface {
+	// Get returns the cached insights of a wedding, nil when there are none
+	Get(ctx context.Context, weddingID models.ID) (*models.RSVPInsights, error)
+	Set(ctx context.Context, insights *models.RSVPInsights, ttl time.Duration) error
+	Invalidate(ctx context.Context, weddingID models.ID) error
+}
+
+// redisRSVPInsightsCache keeps insights in Redis, so that a new RSVP served by
+// one API instance invalidates them for all of them
+type redisRSVPInsightsCache struct {
+	client *redis.Client
+}
+
+// NewRedisRSVPInsightsCache keeps RSVP insights in Redis
+func NewRedisRSVPInsightsCache(client *redis.Client) RSVPInsightsCache {
+	return &redisRSVPInsightsCache{client: client}
+}
+
+func (c *redisRSVPInsightsCache) key(weddingID models.ID) string {
+	return "rsvp:insights:" + weddingID.String()
+}
+
+func (c *redisRSVPInsightsCache) Get(ctx context.Context, weddingID models.ID) (*models.RSVPInsights, error) {
+	data, err := c.client.Get(ctx, c.key(weddingID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached RSVP insights: %w", err)
+	}
+	var insights models.RSVPInsights
+	if err := json.Unmarshal(data, &insights); err != nil {
+		return nil, fmt.Errorf("failed to decode cached RSVP insights: %w", err)
+	}
+	return &insights, nil
+}
+
+func (c *redisRSVPInsightsCache) Set(ctx context.Context, insights *models.RSVPInsights, ttl time.Duration) error {
+	data, err := json.Marshal(insights)
+	if err != nil {
+		return fmt.Errorf("failed to encode RSVP insights: %w", err)
+	}
+	if err := c.client.Set(ctx, c.key(insights.WeddingID), data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to cache RSVP insights: %w", err)
+	}
+	return nil
+}
+
+func (c *redisRSVPInsightsCache) Invalidate(ctx context.Context, weddingID models.ID) error {
+	if err := c.client.Del(ctx, c.key(weddingID)).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate RSVP insights: %w", err)
+	}
+	return nil
+}
+
+// memoryRSVPInsightsCache keeps insights in process memory, for a single API
+// instance without Redis
+type memoryRSVPInsightsCache struct {
+	mu       sync.Mutex
+	insights map[models.ID]memoryRSVPInsights
+	now      func() time.Time
+}
+
+type memoryRSVPInsights struct {
+	insights  models.RSVPInsights
+	expiresAt time.Time
+}
+
+// NewMemoryRSVPInsightsCache keeps RSVP insights in process memory
+func NewMemoryRSVPInsightsCache() RSVPInsightsCache {
+	return &memoryRSVPInsightsCache{insights: make(map[models.ID]memoryRSVPInsights), now: time.Now}
+}
+
+func (c *memoryRSVPInsightsCache) Get(ctx context.Context, weddingID models.ID) (*models.RSVPInsights, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.insights[weddingID]
+	if !ok {
+		return nil, nil
+	}
+	if !c.now().Before(cached.expiresAt) {
+		delete(c.insights, weddingID)
+		return nil, nil
+	}
+	insights := cached.insights
+	return &insights, nil
+}
+
+func (c *memoryRSVPInsightsCache) Set(ctx context.Context, insights *models.RSVPInsights, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	// Expired insights are dropped as new ones are cached
+	for id, cached := range c.insights {
+		if !now.Before(cached.expiresAt) {
+			delete(c.insights, id)
+		}
+	}
+	c.insights[insights.WeddingID] = memoryRSVPInsights{insights: *insights, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (c *memoryRSVPInsightsCache) Invalidate(ctx context.Context, weddingID models.ID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.insights, weddingID)
+	return nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func TestRSVPService_GetRSVPInsights(t *testing.T) {
+	weddingID := models.NewID()
+	ownerID := models.NewID()
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(mealWedding(weddingID, ownerID), nil)
+	weddingRepo.On("UpdateRSVPCount", mock.Anything, weddingID).Return(nil)
+
+	day1 := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	rsvpRepo := NewMockRSVPRepository()
+	for _, rsvp := range []*models.RSVP{
+		{Status: "attending", AttendanceCount: 2, MealChoice: "Beef", PlusOnes: []models.PlusOneInfo{{FirstName: "Jane", MealChoice: "Fish"}}, SubmittedAt: day1},
+		{Status: "not-attending", SubmittedAt: day1.Add(time.Hour)},
+		{Status: "maybe", AttendanceCount: 3, SubmittedAt: day2},
+		{Status: "attending", AttendanceCount: 1, SubmittedAt: day2.Add(time.Hour)},
+		// Held for review, so not counted
+		{Status: "attending", AttendanceCount: 1, MealChoice: "Beef", SubmittedAt: day2, Review: &models.RSVPReview{Status: models.RSVPReviewPending}},
+	} {
+		rsvp.ID = models.NewID()
+		rsvp.WeddingID = weddingID
+		rsvpRepo.rsvps[rsvp.ID] = rsvp
+	}
+
+	guestRepo := NewMockGuestRepository()
+	for _, guest := range []*models.Guest{
+		{Side: "bride", Relationship: "family", VIP: true, RSVPStatus: "attending", Companions: []models.PlusOneInfo{{FirstName: "Jane"}}},
+		{Side: "groom", RSVPStatus: "not-attending"},
+		{Side: "bride", Email: "john@example.com"},
+		{Side: "groom", Relationship: "friend"},
+	} {
+		guest.ID = models.NewID()
+		guest.WeddingID = weddingID
+		guestRepo.guests[guest.ID] = guest
+	}
+
+	service := NewRSVPService(rsvpRepo, weddingRepo)
+	service.EnableGuestLinking(guestRepo, "secret")
+	service.EnableInsightsCache(NewMemoryRSVPInsightsCache())
+
+	t.Run("Success", func(t *testing.T) {
+		insights, err := service.GetRSVPInsights(context.Background(), weddingID, ownerID)
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, insights.Responses)
+		assert.Equal(t, 2, insights.Attending)
+		assert.Equal(t, 1, insights.NotAttending)
+		assert.Equal(t, 1, insights.Maybe)
+		assert.Equal(t, []models.AttendanceDay{
+			{Date: "2026-05-01", Attending: 1, NotAttending: 1, Headcount: 2, TotalHeadcount: 2},
+			{Date: "2026-05-02", Attending: 1, Maybe: 1, Headcount: 1, MaybeHeadcount: 3, TotalHeadcount: 3},
+		}, insights.Timeline)
+
+		assert.Equal(t, 4, insights.InvitedGuests)
+		assert.Equal(t, 2, insights.RespondedGuests)
+		assert.Equal(t, 0.5, insights.ResponseRate)
+
+		headcount := insights.Headcount
+		assert.Equal(t, 3, headcount.Confirmed)
+		assert.Equal(t, 1, headcount.ConfirmedPlusOnes)
+		assert.Equal(t, 3, headcount.Maybe)
+		assert.Equal(t, 2, headcount.PendingGuests)
+		assert.InDelta(t, 2.0/3, headcount.AcceptanceRate, 0.001)
+		assert.Equal(t, 1.5, headcount.AveragePartySize)
+		// 3 confirmed, half of 3 maybes and 2 pending guests at 2/3 with parties of 1.5
+		assert.Equal(t, 7, headcount.Projected)
+
+		assert.Equal(t, []models.AttendanceBreakdown{
+			{Key: "bride", Invited: 2, Attending: 1, Pending: 1, Headcount: 2},
+			{Key: "groom", Invited: 2, NotAttending: 1, Pending: 1},
+		}, insights.BySide)
+		assert.Equal(t, []string{models.BreakdownUnspecified, "family", "friend"}, breakdownKeys(insights.ByRelationship))
+		assert.Equal(t, []string{models.BreakdownRegular, models.BreakdownVIP}, breakdownKeys(insights.ByVIP))
+
+		assert.Equal(t, []models.OptionCount{
+			{Option: "Beef", Count: 1},
+			{Option: "Fish", Count: 1},
+			{Option: "Vegetarian", Count: 0},
+		}, insights.Meals)
+		assert.Equal(t, 1, insights.NoMealChoice)
+	})
+
+	t.Run("Success - cached until a new RSVP", func(t *testing.T) {
+		before, err := service.GetRSVPInsights(context.Background(), weddingID, ownerID)
+		require.NoError(t, err)
+
+		// Changes behind the service's back are not seen while cached
+		untracked := &models.RSVP{ID: models.NewID(), WeddingID: weddingID, Status: "not-attending", SubmittedAt: day2}
+		rsvpRepo.rsvps[untracked.ID] = untracked
+		cached, err := service.GetRSVPInsights(context.Background(), weddingID, ownerID)
+		require.NoError(t, err)
+		assert.Equal(t, before.Responses, cached.Responses)
+
+		_, err = service.SubmitRSVP(context.Background(), weddingID, SubmitRSVPRequest{
+			FirstName:       "John",
+			LastName:        "Doe",
+			Email:           "john@example.com",
+			Status:          "attending",
+			AttendanceCount: 1,
+			MealChoice:      "Vegetarian",
+		})
+		require.NoError(t, err)
+
+		insights, err := service.GetRSVPInsights(context.Background(), weddingID, ownerID)
+		require.NoError(t, err)
+		assert.Equal(t, before.Responses+2, insights.Responses)
+		assert.Equal(t, 1, insights.Meals[2].Count)
+	})
+
+	t.Run("Error - not the owner", func(t *testing.T) {
+		_, err := service.GetRSVPInsights(context.Background(), weddingID, models.NewID())
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+}
+
+func breakdownKeys(breakdowns []models.AttendanceBreakdown) []string {
+	keys := make([]string, len(breakdowns))
+	for i, breakdown := range breakdowns {
+		keys[i] = breakdown.Key
+	}
+	return keys
+}
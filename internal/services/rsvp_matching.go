@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// matchGuest finds the guest an RSVP submitted without a link token comes from
+// on the wedding's guest list, by email and then by the key of the phone number,
+// see models.GuestPhoneKey. It returns nil when nobody matches or guest linking
+// is off.
+func (s *RSVPService) matchGuest(ctx context.Context, rsvp *models.RSVP) (*models.Guest, error) {
+	if s.guestRepo == nil {
+		return nil, nil
+	}
+
+	if rsvp.Email != "" {
+		guest, err := s.guestRepo.GetByEmail(ctx, rsvp.WeddingID, rsvp.Email)
+		if err == nil {
+			return guest, nil
+		}
+		if !errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get guest: %w", err)
+		}
+	}
+
+	phoneKey := models.GuestPhoneKey(rsvp.Phone)
+	if phoneKey == "" {
+		return nil, nil
+	}
+	guest, err := s.guestRepo.GetByPhone(ctx, rsvp.WeddingID, phoneKey)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get guest by phone: %w", err)
+	}
+	return guest, nil
+}
+
+// flagWalkIn holds an RSVP that matches no guest for the owner's review, as a
+// walk-in, when the wedding keeps a guest list. Without one every RSVP would be.
+func (s *RSVPService) flagWalkIn(ctx context.Context, rsvp *models.RSVP) error {
+	if s.guestRepo == nil {
+		return nil
+	}
+
+	_, total, err := s.guestRepo.ListByWedding(ctx, rsvp.WeddingID, 1, 1, repository.GuestFilters{})
+	if err != nil {
+		return fmt.Errorf("failed to count guests: %w", err)
+	}
+	if total == 0 {
+		return nil
+	}
+
+	if rsvp.Review == nil {
+		rsvp.Review = &models.RSVPReview{Status: models.RSVPReviewPending, FlaggedAt: time.Now()}
+	}
+	rsvp.Review.Signals = append(rsvp.Review.Signals, models.RSVPSignalWalkIn)
+	return nil
+}
+
+// previousRSVP returns the RSVP the guest of a prepared submission answered with
+// before, the one whose edit link came with it or else the one found through the
+// linked guest or by email, or nil for a first answer. Only a submission proven
+// to come from the guest, see models.RSVPProof, may change an earlier answer;
+// anyone else gets ErrDuplicateRSVP, as do a guest answered for by another
+// member of their household, an email used by another guest's RSVP and any
+// answer given again while RSVP editing is off.
+func (s *RSVPService) previousRSVP(ctx context.Context, rsvp *models.RSVP, proof models.RSVPProof) (*models.RSVP, error) {
+	var previous *models.RSVP
+	if proof.EditRSVPID != nil {
+		var err error
+		previous, err = s.rsvpRepo.GetByID(ctx, *proof.EditRSVPID)
+		if err != nil {
+			// The RSVP was deleted since the submission was queued
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, ErrInvalidRSVPEditLink
+			}
+			return nil, fmt.Errorf("failed to get RSVP: %w", err)
+		}
+	}
+
+	if previous == nil && rsvp.GuestID != nil && s.guestRepo != nil {
+		// A guest removed from the list since, or whose RSVP was deleted, is
+		// only matched by email
+		guest, err := s.guestRepo.GetByID(ctx, *rsvp.GuestID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get guest: %w", err)
+		}
+		if guest != nil && guest.RSVPID != nil {
+			previous, err = s.rsvpRepo.GetByID(ctx, *guest.RSVPID)
+			if err != nil && !errors.Is(err, repository.ErrNotFound) {
+				return nil, fmt.Errorf("failed to get RSVP: %w", err)
+			}
+		}
+	}
+
+	if previous == nil && rsvp.Email != "" {
+		previous, _ = s.rsvpRepo.GetByEmail(ctx, rsvp.WeddingID, rsvp.Email)
+	}
+	if previous == nil {
+		return nil, nil
+	}
+
+	if proof.EditRSVPID == nil && !proof.GuestToken {
+		return nil, ErrDuplicateRSVP
+	}
+	if rsvp.GuestID != nil && previous.GuestID != nil && *previous.GuestID != *rsvp.GuestID {
+		return nil, ErrDuplicateRSVP
+	}
+	if !featureEnabled(ctx, s.features, rsvp.WeddingID, FeatureRSVPEditing) {
+		return nil, ErrDuplicateRSVP
+	}
+	return previous, nil
+}
+
+// resubmitRSVP applies the answers of a guest's new submission to the RSVP they
+// answered with before, recording the changes in its history. A walk-in RSVP
+// answered again through a guest's link is linked to the guest.
+func (s *RSVPService) resubmitRSVP(ctx context.Context, previous, rsvp *models.RSVP) (*models.RSVP, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, previous.WeddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+
+	if previous.GuestID == nil && rsvp.GuestID != nil {
+		previous.GuestID, previous.GroupID, previous.Members = rsvp.GuestID, rsvp.GroupID, rsvp.Members
+	}
+
+	return s.applyUpdate(ctx, previous, wedding, UpdateRSVPRequest{
+		Status:              &rsvp.Status,
+		AttendanceCount:     &rsvp.AttendanceCount,
+		Sessions:            &rsvp.Sessions,
+		PlusOnes:            &rsvp.PlusOnes,
+		DietaryRestrictions: &rsvp.DietaryRestrictions,
+		DietarySelected:     &rsvp.DietarySelected,
+		AdditionalNotes:     &rsvp.AdditionalNotes,
+		MealChoice:          &rsvp.MealChoice,
+		Allergies:           &rsvp.Allergies,
+		CustomAnswers:       &rsvp.CustomAnswers,
+	}, models.RSVPChangedByResubmission)
+}
+
+// saveRSVP saves a prepared RSVP, or applies it to the RSVP its guest answered
+// with before when the submission proved to come from them
+func (s *RSVPService) saveRSVP(ctx context.Context, rsvp *models.RSVP, proof models.RSVPProof) (*models.RSVP, error) {
+	previous, err := s.previousRSVP(ctx, rsvp, proof)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil {
+		return s.resubmitRSVP(ctx, previous, rsvp)
+	}
+
+	if err := s.persistRSVP(ctx, rsvp); err != nil {
+		return nil, err
+	}
+	return rsvp, nil
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/utils"
+)
+
+func TestRSVPService_SubmitRSVP_MatchesGuests(t *testing.T) {
+	weddingID := models.NewID()
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(mealWedding(weddingID, models.NewID()), nil)
+	weddingRepo.On("UpdateRSVPCount", mock.Anything, weddingID).Return(nil)
+
+	setup := func(t *testing.T, guests ...*models.Guest) (*RSVPService, *MockRSVPRepository) {
+		guestRepo := NewMockGuestRepository()
+		for _, guest := range guests {
+			guest.WeddingID = weddingID
+			require.NoError(t, guestRepo.Create(context.Background(), guest))
+		}
+		rsvpRepo := NewMockRSVPRepository()
+		service := NewRSVPService(rsvpRepo, weddingRepo)
+		service.EnableGuestLinking(guestRepo, "link-secret")
+		return service, rsvpRepo
+	}
+	request := func() SubmitRSVPRequest {
+		return SubmitRSVPRequest{FirstName: "John", LastName: "Doe", Status: "attending", AttendanceCount: 1}
+	}
+
+	t.Run("Success - matched by email", func(t *testing.T) {
+		guest := &models.Guest{FirstName: "John", LastName: "Doe", Email: "john@example.com"}
+		service, _ := setup(t, guest)
+
+		req := request()
+		req.Email = "john@example.com"
+		rsvp, err := service.SubmitRSVP(context.Background(), weddingID, req)
+		require.NoError(t, err)
+		require.NotNil(t, rsvp.GuestID)
+		assert.Equal(t, guest.ID, *rsvp.GuestID)
+		assert.Nil(t, rsvp.Review)
+		assert.Equal(t, "attending", guest.RSVPStatus)
+	})
+
+	t.Run("Success - matched by phone and updated when answering again through the guest's link", func(t *testing.T) {
+		guest := &models.Guest{FirstName: "John", LastName: "Doe", Phone: "+62 812-3456-7890"}
+		service, rsvpRepo := setup(t, guest)
+
+		req := request()
+		req.Phone = "0812 3456 7890"
+		rsvp, err := service.SubmitRSVP(context.Background(), weddingID, req)
+		require.NoError(t, err)
+		require.NotNil(t, rsvp.GuestID)
+		assert.Equal(t, guest.ID, *rsvp.GuestID)
+		assert.Nil(t, rsvp.UpdatedAt)
+
+		// Knowing the guest's phone number is not enough to change their answer
+		req.Status = "not-attending"
+		_, err = service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrDuplicateRSVP)
+		assert.Equal(t, "attending", guest.RSVPStatus)
+
+		req.GuestToken = utils.SignGuestToken("link-secret", guest.ID)
+		updated, err := service.SubmitRSVP(context.Background(), weddingID, req)
+		require.NoError(t, err)
+		assert.Equal(t, rsvp.ID, updated.ID)
+		assert.NotNil(t, updated.UpdatedAt)
+		assert.Len(t, rsvpRepo.rsvps, 1)
+		assert.Equal(t, "not-attending", guest.RSVPStatus)
+	})
+
+	t.Run("Walk-in - phone number too short to match", func(t *testing.T) {
+		service, _ := setup(t, &models.Guest{FirstName: "John", LastName: "Doe", Phone: "12345"})
+
+		req := request()
+		req.Phone = "12345"
+		rsvp, err := service.SubmitRSVP(context.Background(), weddingID, req)
+		require.NoError(t, err)
+		assert.Nil(t, rsvp.GuestID)
+	})
+
+	t.Run("Walk-in - flagged for review", func(t *testing.T) {
+		service, _ := setup(t, &models.Guest{FirstName: "Mary", Email: "mary@example.com"})
+
+		req := request()
+		req.Email = "john@example.com"
+		rsvp, err := service.SubmitRSVP(context.Background(), weddingID, req)
+		require.NoError(t, err)
+		assert.Nil(t, rsvp.GuestID)
+		require.True(t, rsvp.NeedsReview())
+		assert.Equal(t, []string{models.RSVPSignalWalkIn}, rsvp.Review.Signals)
+	})
+
+	t.Run("Walk-in - not flagged without a guest list", func(t *testing.T) {
+		service, _ := setup(t)
+
+		rsvp, err := service.SubmitRSVP(context.Background(), weddingID, request())
+		require.NoError(t, err)
+		assert.Nil(t, rsvp.Review)
+	})
+}
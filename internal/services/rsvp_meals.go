@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+var (
+	// ErrInvalidMealChoice is returned for meal choices that are not on the wedding's menu
+	ErrInvalidMealChoice = errors.New("meal choice must be one of the wedding's meal options")
+	ErrInvalidAllergies  = errors.New("allergies must be distinct, at most 10 of up to 50 characters each")
+)
+
+// Limits on the allergies listed per attendee
+const (
+	maxAllergiesPerAttendee = 10
+	maxAllergyLength        = 50
+)
+
+// applyMealChoices checks the meal choices of an RSVP and its plus ones against
+// the wedding's meal options and normalizes their allergies to distinct, trimmed,
+// lower-case names so that the meal report can count them
+func applyMealChoices(rsvp *models.RSVP, wedding *models.Wedding) error {
+	if err := checkMealChoice(rsvp.MealChoice, wedding); err != nil {
+		return err
+	}
+	allergies, err := normalizeAllergies(rsvp.Allergies)
+	if err != nil {
+		return err
+	}
+	rsvp.Allergies = allergies
+
+	for i := range rsvp.PlusOnes {
+		plusOne := &rsvp.PlusOnes[i]
+		if err := checkMealChoice(plusOne.MealChoice, wedding); err != nil {
+			return err
+		}
+		allergies, err := normalizeAllergies(plusOne.Allergies)
+		if err != nil {
+			return err
+		}
+		plusOne.Allergies = allergies
+	}
+	return nil
+}
+
+func checkMealChoice(choice string, wedding *models.Wedding) error {
+	if choice == "" || contains(wedding.RSVP.MealOptions, choice) {
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrInvalidMealChoice, choice)
+}
+
+func normalizeAllergies(allergies []string) ([]string, error) {
+	var normalized []string
+	seen := make(map[string]bool, len(allergies))
+	for _, allergy := range allergies {
+		allergy = strings.ToLower(strings.TrimSpace(allergy))
+		if allergy == "" {
+			continue
+		}
+		if len(allergy) > maxAllergyLength || seen[allergy] {
+			return nil, ErrInvalidAllergies
+		}
+		seen[allergy] = true
+		normalized = append(normalized, allergy)
+	}
+	if len(normalized) > maxAllergiesPerAttendee {
+		return nil, ErrInvalidAllergies
+	}
+	return normalized, nil
+}
+
+// GetMealReport counts the meal choices and allergies of everyone attending a
+// wedding, plus ones included, and lists them for the caterer. Only attending
+// RSVPs that count towards the statistics are included.
+func (s *RSVPService) GetMealReport(ctx context.Context, weddingID models.ID, userID models.ID) (*models.MealReport, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+
+	if !wedding.Can(userID, models.PermissionManageRSVPs) {
+		return nil, ErrUnauthorized
+	}
+
+	report := &models.MealReport{
+		WeddingID:     weddingID,
+		AllergyCounts: make(map[string]int),
+		Attendees:     []models.MealAttendee{},
+		GeneratedAt:   time.Now(),
+	}
+	meals := make(map[string]int, len(wedding.RSVP.MealOptions))
+
+	add := func(attendee models.MealAttendee) {
+		report.Attendees = append(report.Attendees, attendee)
+		report.TotalAttendees++
+		if attendee.MealChoice == "" {
+			report.NoMealChoice++
+		} else {
+			meals[attendee.MealChoice]++
+		}
+		for _, allergy := range attendee.Allergies {
+			report.AllergyCounts[allergy]++
+		}
+	}
+
+	err = s.rsvpRepo.StreamByWedding(ctx, weddingID, func(rsvp *models.RSVP) error {
+		if !rsvp.IsCounted() || rsvp.Status != string(models.RSVPAttending) {
+			return nil
+		}
+		add(models.MealAttendee{
+			RSVPID:       rsvp.ID,
+			Name:         rsvp.GetFullName(),
+			MealChoice:   rsvp.MealChoice,
+			Allergies:    rsvp.Allergies,
+			DietaryNotes: rsvp.DietaryRestrictions,
+		})
+		for _, plusOne := range rsvp.PlusOnes {
+			add(models.MealAttendee{
+				RSVPID:       rsvp.ID,
+				Name:         strings.TrimSpace(plusOne.FirstName + " " + plusOne.LastName),
+				PlusOne:      true,
+				MealChoice:   plusOne.MealChoice,
+				Allergies:    plusOne.Allergies,
+				DietaryNotes: plusOne.Dietary,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get meal report: %w", err)
+	}
+
+	report.Meals = mealCounts(wedding.RSVP.MealOptions, meals)
+	sort.SliceStable(report.Attendees, func(i, j int) bool {
+		return report.Attendees[i].Name < report.Attendees[j].Name
+	})
+
+	return report, nil
+}
+
+// mealCounts lists the counts of every meal option in the menu's order, then
+// those of meals chosen before they were taken off the menu. It consumes counts.
+func mealCounts(options []string, counts map[string]int) []models.OptionCount {
+	meals := make([]models.OptionCount, 0, len(options)+len(counts))
+	for _, option := range options {
+		meals = append(meals, models.OptionCount{Option: option, Count: counts[option]})
+		delete(counts, option)
+	}
+	// Meals taken off the menu after guests chose them still need cooking
+	var removed []string
+	for option := range counts {
+		removed = append(removed, option)
+	}
+	sort.Strings(removed)
+	for _, option := range removed {
+		meals = append(meals, models.OptionCount{Option: option, Count: counts[option]})
+	}
+	return meals
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// mealWedding is a published wedding with a menu of three meals
+func mealWedding(weddingID, userID models.ID) *models.Wedding {
+	return &models.Wedding{
+		ID:     weddingID,
+		UserID: userID,
+		Status: "published",
+		RSVP: models.RSVPSettings{
+			Enabled:     true,
+			MaxPlusOnes: 2,
+			MealOptions: []string{"Beef", "Fish", "Vegetarian"},
+		},
+	}
+}
+
+func TestRSVPService_SubmitRSVP_MealChoices(t *testing.T) {
+	weddingID := models.NewID()
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(mealWedding(weddingID, models.NewID()), nil)
+	weddingRepo.On("UpdateRSVPCount", mock.Anything, weddingID).Return(nil)
+	service := NewRSVPService(NewMockRSVPRepository(), weddingRepo)
+
+	request := func() SubmitRSVPRequest {
+		return SubmitRSVPRequest{
+			FirstName:       "John",
+			LastName:        "Doe",
+			Status:          "attending",
+			AttendanceCount: 1,
+			MealChoice:      "Fish",
+			Allergies:       []string{" Peanuts ", "", "Shellfish"},
+			PlusOnes:        []models.PlusOneInfo{{FirstName: "Jane", LastName: "Doe", MealChoice: "Vegetarian", Allergies: []string{"GLUTEN"}}},
+		}
+	}
+
+	t.Run("Success - allergies are normalized", func(t *testing.T) {
+		rsvp, err := service.SubmitRSVP(context.Background(), weddingID, request())
+		require.NoError(t, err)
+		assert.Equal(t, "Fish", rsvp.MealChoice)
+		assert.Equal(t, []string{"peanuts", "shellfish"}, rsvp.Allergies)
+		assert.Equal(t, []string{"gluten"}, rsvp.PlusOnes[0].Allergies)
+	})
+
+	t.Run("Error - meal not on the menu", func(t *testing.T) {
+		req := request()
+		req.MealChoice = "Lobster"
+		_, err := service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrInvalidMealChoice)
+	})
+
+	t.Run("Error - plus one meal not on the menu", func(t *testing.T) {
+		req := request()
+		req.PlusOnes[0].MealChoice = "Lobster"
+		_, err := service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrInvalidMealChoice)
+	})
+
+	t.Run("Error - duplicate allergy", func(t *testing.T) {
+		req := request()
+		req.Allergies = []string{"peanuts", "Peanuts"}
+		_, err := service.SubmitRSVP(context.Background(), weddingID, req)
+		assert.ErrorIs(t, err, ErrInvalidAllergies)
+	})
+}
+
+func TestRSVPService_UpdateRSVP_MealChoices(t *testing.T) {
+	weddingID := models.NewID()
+	wedding := mealWedding(weddingID, models.NewID())
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(wedding, nil)
+	weddingRepo.On("UpdateRSVPCount", mock.Anything, weddingID).Return(nil)
+	service := NewRSVPService(NewMockRSVPRepository(), weddingRepo)
+
+	rsvp, err := service.SubmitRSVP(context.Background(), weddingID, SubmitRSVPRequest{
+		FirstName: "John", LastName: "Doe", Status: "attending", AttendanceCount: 1, MealChoice: "Beef",
+	})
+	require.NoError(t, err)
+
+	// Taking a meal off the menu does not block unrelated updates
+	wedding.RSVP.MealOptions = []string{"Fish", "Vegetarian"}
+	status := "maybe"
+	_, err = service.UpdateRSVP(context.Background(), rsvp.ID, UpdateRSVPRequest{Status: &status})
+	require.NoError(t, err)
+
+	meal := "Beef"
+	_, err = service.UpdateRSVP(context.Background(), rsvp.ID, UpdateRSVPRequest{MealChoice: &meal})
+	assert.ErrorIs(t, err, ErrInvalidMealChoice)
+
+	meal = "Fish"
+	updated, err := service.UpdateRSVP(context.Background(), rsvp.ID, UpdateRSVPRequest{MealChoice: &meal})
+	require.NoError(t, err)
+	assert.Equal(t, "Fish", updated.MealChoice)
+}
+
+func TestRSVPService_GetMealReport(t *testing.T) {
+	weddingID := models.NewID()
+	userID := models.NewID()
+	wedding := mealWedding(weddingID, userID)
+	wedding.RSVP.MealOptions = []string{"Fish", "Vegetarian"}
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(wedding, nil)
+	rsvpRepo := NewMockRSVPRepository()
+	service := NewRSVPService(rsvpRepo, weddingRepo)
+
+	add := func(rsvp *models.RSVP) {
+		rsvp.ID = models.NewID()
+		rsvp.WeddingID = weddingID
+		rsvpRepo.rsvps[rsvp.ID] = rsvp
+	}
+	add(&models.RSVP{
+		FirstName: "John", LastName: "Doe", Status: "attending", MealChoice: "Fish", Allergies: []string{"peanuts"},
+		PlusOnes: []models.PlusOneInfo{{FirstName: "Jane", LastName: "Doe", Allergies: []string{"peanuts", "gluten"}}},
+	})
+	// Chose a meal since taken off the menu
+	add(&models.RSVP{FirstName: "Alice", LastName: "Smith", Status: "attending", MealChoice: "Beef"})
+	// Not attending, or waiting for review: not catered for
+	add(&models.RSVP{FirstName: "Bob", LastName: "Brown", Status: "not-attending", MealChoice: "Fish"})
+	add(&models.RSVP{FirstName: "Eve", LastName: "Black", Status: "attending", MealChoice: "Fish",
+		Review: &models.RSVPReview{Status: models.RSVPReviewPending}})
+
+	report, err := service.GetMealReport(context.Background(), weddingID, userID)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, report.TotalAttendees)
+	assert.Equal(t, []models.OptionCount{{Option: "Fish", Count: 1}, {Option: "Vegetarian", Count: 0}, {Option: "Beef", Count: 1}}, report.Meals)
+	assert.Equal(t, 1, report.NoMealChoice)
+	assert.Equal(t, map[string]int{"peanuts": 2, "gluten": 1}, report.AllergyCounts)
+
+	require.Len(t, report.Attendees, 3)
+	assert.Equal(t, "Alice Smith", report.Attendees[0].Name)
+	assert.Equal(t, "Jane Doe", report.Attendees[1].Name)
+	assert.True(t, report.Attendees[1].PlusOne)
+
+	_, err = service.GetMealReport(context.Background(), weddingID, models.NewID())
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
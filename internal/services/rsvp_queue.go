@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+var (
+	ErrRSVPSubmissionNotFound = errs.NotFound("rsvp submission not found")
+)
+
+// RSVPQueueOptions configures the write-behind RSVP worker
+type RSVPQueueOptions struct {
+	Partitions   int           // Number of workers; each guest always maps to the same one
+	PollInterval time.Duration // Idle wait between empty polls
+	Lease        time.Duration // How long a claimed submission is hidden from other workers
+	MaxAttempts  int           // Transient failures before a submission is marked failed
+	Backoff      time.Duration // Delay before the first retry; doubles with every attempt
+	MaxBackoff   time.Duration // Upper bound of the retry delay
+}
+
+// DefaultRSVPQueueOptions returns sensible defaults for the write-behind worker
+func DefaultRSVPQueueOptions() RSVPQueueOptions {
+	return RSVPQueueOptions{
+		Partitions:   4,
+		PollInterval: 250 * time.Millisecond,
+		Lease:        30 * time.Second,
+		MaxAttempts:  5,
+		Backoff:      time.Second,
+		MaxBackoff:   30 * time.Second,
+	}
+}
+
+// RSVPQueueService validates RSVP submissions synchronously and persists them
+// asynchronously through a durable queue, keeping submission latency flat during spikes.
+// Submissions for the same guest are hashed to one partition and applied in order,
+// one at a time even across replicas.
+type RSVPQueueService struct {
+	rsvpService    *RSVPService
+	submissionRepo repository.RSVPSubmissionRepository
+	opts           RSVPQueueOptions
+	logger         *zap.Logger
+	stop           chan struct{}
+	wg             sync.WaitGroup
+}
+
+// NewRSVPQueueService creates a new write-behind RSVP queue
+func NewRSVPQueueService(rsvpService *RSVPService, submissionRepo repository.RSVPSubmissionRepository, opts RSVPQueueOptions, logger *zap.Logger) *RSVPQueueService {
+	defaults := DefaultRSVPQueueOptions()
+	if opts.Partitions <= 0 {
+		opts.Partitions = defaults.Partitions
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaults.PollInterval
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = defaults.Lease
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaults.MaxAttempts
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = defaults.Backoff
+	}
+	if opts.MaxBackoff < opts.Backoff {
+		opts.MaxBackoff = defaults.MaxBackoff
+	}
+
+	return &RSVPQueueService{
+		rsvpService:    rsvpService,
+		submissionRepo: submissionRepo,
+		opts:           opts,
+		logger:         logger,
+		stop:           make(chan struct{}),
+	}
+}
+
+// Enqueue validates an RSVP and queues it for persistence
+func (s *RSVPQueueService) Enqueue(ctx context.Context, weddingID models.ID, req SubmitRSVPRequest) (*models.RSVPSubmission, error) {
+	rsvp, proof, err := s.rsvpService.prepareRSVP(ctx, weddingID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	guestKey := models.RSVPGuestKey(rsvp)
+	submission := &models.RSVPSubmission{
+		ID:        models.NewID(),
+		WeddingID: weddingID,
+		GuestKey:  guestKey,
+		Partition: models.RSVPPartition(guestKey, s.opts.Partitions),
+		RSVP:      *rsvp,
+		Proof:     proof,
+	}
+
+	if err := s.submissionRepo.Enqueue(ctx, submission); err != nil {
+		return nil, fmt.Errorf("failed to enqueue RSVP: %w", err)
+	}
+
+	return submission, nil
+}
+
+// GetSubmission returns the processing status of a queued submission
+func (s *RSVPQueueService) GetSubmission(ctx context.Context, weddingID, id models.ID) (*models.RSVPSubmission, error) {
+	submission, err := s.submissionRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrRSVPSubmissionNotFound
+		}
+		return nil, fmt.Errorf("failed to get RSVP submission: %w", err)
+	}
+
+	if submission.WeddingID != weddingID {
+		return nil, ErrRSVPSubmissionNotFound
+	}
+
+	return submission, nil
+}
+
+// ProcessNext persists the oldest pending submission in a partition.
+// It reports whether a submission was claimed.
+func (s *RSVPQueueService) ProcessNext(ctx context.Context, partition int) (bool, error) {
+	submission, err := s.submissionRepo.ClaimNext(ctx, partition, time.Now(), s.opts.Lease)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	s.process(ctx, submission)
+	return true, nil
+}
+
+func (s *RSVPQueueService) process(ctx context.Context, submission *models.RSVPSubmission) {
+	rsvp := &submission.RSVP
+
+	// A previous attempt may have saved the RSVP before crashing; don't insert twice
+	if existing, err := s.rsvpService.rsvpRepo.GetByID(ctx, rsvp.ID); err == nil && existing != nil {
+		s.complete(ctx, submission, rsvp.ID)
+		return
+	}
+
+	// Earlier submissions for the same guest have been applied by now, so a
+	// later one updates the RSVP they saved
+	saved, err := s.rsvpService.saveRSVP(ctx, rsvp, submission.Proof)
+	if err != nil {
+		if errors.Is(err, ErrDuplicateRSVP) || errors.Is(err, ErrInvalidRSVPEditLink) || submission.Attempts >= s.opts.MaxAttempts {
+			s.fail(ctx, submission, err.Error())
+			return
+		}
+		// Back off, so that a short outage does not use up every attempt at once
+		delay := s.backoff(submission.Attempts)
+		s.logger.Warn("Retrying RSVP submission",
+			zap.Error(err),
+			zap.String("submission_id", submission.ID.String()),
+			zap.Int("attempts", submission.Attempts),
+			zap.Duration("delay", delay))
+		if err := s.submissionRepo.Release(ctx, submission.ID, time.Now().Add(delay), err.Error()); err != nil {
+			s.logger.Error("Failed to release RSVP submission", zap.Error(err), zap.String("submission_id", submission.ID.String()))
+		}
+		return
+	}
+
+	s.complete(ctx, submission, saved.ID)
+}
+
+// backoff returns the delay before retrying a submission that failed its attempts-th attempt
+func (s *RSVPQueueService) backoff(attempts int) time.Duration {
+	delay := s.opts.Backoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= s.opts.MaxBackoff {
+			return s.opts.MaxBackoff
+		}
+	}
+	return delay
+}
+
+func (s *RSVPQueueService) complete(ctx context.Context, submission *models.RSVPSubmission, rsvpID models.ID) {
+	if err := s.submissionRepo.MarkCompleted(ctx, submission.ID, rsvpID); err != nil {
+		s.logger.Error("Failed to mark RSVP submission completed", zap.Error(err), zap.String("submission_id", submission.ID.String()))
+	}
+}
+
+func (s *RSVPQueueService) fail(ctx context.Context, submission *models.RSVPSubmission, reason string) {
+	if err := s.submissionRepo.MarkFailed(ctx, submission.ID, reason); err != nil {
+		s.logger.Error("Failed to mark RSVP submission failed", zap.Error(err), zap.String("submission_id", submission.ID.String()))
+	}
+}
+
+// Start launches one worker per partition
+func (s *RSVPQueueService) Start(ctx context.Context) {
+	for partition := 0; partition < s.opts.Partitions; partition++ {
+		s.wg.Add(1)
+		go s.run(ctx, partition)
+	}
+}
+
+// Stop signals the workers to persist the pending submissions and exit, and
+// waits for them
+func (s *RSVPQueueService) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+func (s *RSVPQueueService) run(ctx context.Context, partition int) {
+	defer s.wg.Done()
+
+	// Once stopped, the worker keeps going until nothing is due, draining
+	// the queue; cancelling ctx aborts it
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		claimed, err := s.ProcessNext(ctx, partition)
+		if err != nil {
+			s.logger.Error("RSVP queue worker error", zap.Error(err), zap.Int("partition", partition))
+		}
+		if claimed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stop:
+			return
+		case <-time.After(s.opts.PollInterval):
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// MockRSVPSubmissionRepository is an in-memory RSVP submission queue
+type MockRSVPSubmissionRepository struct {
+	submissions map[models.ID]*models.RSVPSubmission
+	sequence    int
+}
+
+func NewMockRSVPSubmissionRepository() *MockRSVPSubmissionRepository {
+	return &MockRSVPSubmissionRepository{
+		submissions: make(map[models.ID]*models.RSVPSubmission),
+	}
+}
+
+func (m *MockRSVPSubmissionRepository) Enqueue(ctx context.Context, submission *models.RSVPSubmission) error {
+	// Strictly increasing timestamps keep ordering deterministic
+	m.sequence++
+	submission.Status = models.RSVPSubmissionQueued
+	submission.CreatedAt = time.Unix(int64(m.sequence), 0)
+	m.submissions[submission.ID] = submission
+	return nil
+}
+
+func (m *MockRSVPSubmissionRepository) GetByID(ctx context.Context, id models.ID) (*models.RSVPSubmission, error) {
+	submission, exists := m.submissions[id]
+	if !exists {
+		return nil, repository.ErrNotFound
+	}
+	return submission, nil
+}
+
+func (m *MockRSVPSubmissionRepository) ClaimNext(ctx context.Context, partition int, now time.Time, lease time.Duration) (*models.RSVPSubmission, error) {
+	var pending []*models.RSVPSubmission
+	for _, submission := range m.submissions {
+		if submission.Partition == partition && !submission.IsFinal() {
+			pending = append(pending, submission)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].CreatedAt.Before(pending[j].CreatedAt) })
+
+	// Only the oldest unfinished submission of a guest is claimed, once its lease
+	// is free or, released, once it is due for a retry
+	var next *models.RSVPSubmission
+	seen := make(map[string]bool)
+	for _, submission := range pending {
+		if seen[submission.GuestKey] {
+			continue
+		}
+		seen[submission.GuestKey] = true
+		if (submission.Status == models.RSVPSubmissionQueued && (submission.LockedUntil == nil || !submission.LockedUntil.After(now))) ||
+			(submission.Status == models.RSVPSubmissionProcessing && submission.LockedUntil.Before(now)) {
+			next = submission
+			break
+		}
+	}
+	if next == nil {
+		return nil, repository.ErrNotFound
+	}
+
+	lockedUntil := now.Add(lease)
+	next.Status = models.RSVPSubmissionProcessing
+	next.LockedUntil = &lockedUntil
+	next.Attempts++
+	return next, nil
+}
+
+func (m *MockRSVPSubmissionRepository) MarkCompleted(ctx context.Context, id, rsvpID models.ID) error {
+	submission := m.submissions[id]
+	submission.Status = models.RSVPSubmissionCompleted
+	submission.RSVPID = &rsvpID
+	return nil
+}
+
+func (m *MockRSVPSubmissionRepository) MarkFailed(ctx context.Context, id models.ID, reason string) error {
+	submission := m.submissions[id]
+	submission.Status = models.RSVPSubmissionFailed
+	submission.Error = reason
+	submission.RSVP = models.RSVP{}
+	return nil
+}
+
+func (m *MockRSVPSubmissionRepository) Release(ctx context.Context, id models.ID, retryAt time.Time, reason string) error {
+	submission := m.submissions[id]
+	submission.Status = models.RSVPSubmissionQueued
+	submission.LockedUntil = &retryAt
+	submission.Error = reason
+	return nil
+}
+
+func setupRSVPQueue(t *testing.T, opts RSVPQueueOptions) (*RSVPQueueService, *MockRSVPRepository, *MockRSVPSubmissionRepository, models.ID) {
+	rsvpRepo := NewMockRSVPRepository()
+	weddingRepo := &MockWeddingRepository{}
+	submissionRepo := NewMockRSVPSubmissionRepository()
+
+	weddingID := models.NewID()
+	wedding := &models.Wedding{
+		ID:     weddingID,
+		UserID: models.NewID(),
+		Status: "published",
+		RSVP: models.RSVPSettings{
+			Enabled:     true,
+			MaxPlusOnes: 2,
+		},
+	}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(wedding, nil)
+	weddingRepo.On("UpdateRSVPCount", mock.Anything, weddingID).Return(nil)
+
+	queue := NewRSVPQueueService(NewRSVPService(rsvpRepo, weddingRepo), submissionRepo, opts, zaptest.NewLogger(t))
+	return queue, rsvpRepo, submissionRepo, weddingID
+}
+
+func TestRSVPQueueService_Enqueue(t *testing.T) {
+	queue, rsvpRepo, _, weddingID := setupRSVPQueue(t, RSVPQueueOptions{Partitions: 1})
+	ctx := context.Background()
+
+	t.Run("Success - validated and queued without persisting", func(t *testing.T) {
+		submission, err := queue.Enqueue(ctx, weddingID, SubmitRSVPRequest{
+			FirstName:       "Jane",
+			LastName:        "Doe",
+			Email:           "jane@example.com",
+			Status:          "attending",
+			AttendanceCount: 1,
+			Source:          "web",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, models.RSVPSubmissionQueued, submission.Status)
+		assert.Empty(t, rsvpRepo.rsvps)
+
+		status, err := queue.GetSubmission(ctx, weddingID, submission.ID)
+		require.NoError(t, err)
+		assert.Equal(t, submission.ID, status.ID)
+
+		_, err = queue.GetSubmission(ctx, models.NewID(), submission.ID)
+		assert.ErrorIs(t, err, ErrRSVPSubmissionNotFound)
+	})
+
+	t.Run("Error - validation happens synchronously", func(t *testing.T) {
+		_, err := queue.Enqueue(ctx, weddingID, SubmitRSVPRequest{
+			FirstName:       "Jane",
+			LastName:        "Doe",
+			Status:          "attending",
+			AttendanceCount: 1,
+			PlusOnes:        make([]models.PlusOneInfo, 3),
+		})
+		assert.ErrorIs(t, err, ErrTooManyPlusOnes)
+	})
+}
+
+func TestRSVPQueueService_ProcessNext_OrdersPerGuest(t *testing.T) {
+	queue, rsvpRepo, submissionRepo, weddingID := setupRSVPQueue(t, RSVPQueueOptions{Partitions: 4})
+	ctx := context.Background()
+	guestRepo := NewMockGuestRepository()
+	guest := &models.Guest{WeddingID: weddingID, FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"}
+	require.NoError(t, guestRepo.Create(ctx, guest))
+	queue.rsvpService.EnableGuestLinking(guestRepo, "link-secret")
+
+	req := SubmitRSVPRequest{
+		FirstName:       "Jane",
+		LastName:        "Doe",
+		Email:           "jane@example.com",
+		Status:          "attending",
+		AttendanceCount: 1,
+		GuestToken:      utils.SignGuestToken("link-secret", guest.ID),
+		Source:          "web",
+	}
+	first, err := queue.Enqueue(ctx, weddingID, req)
+	require.NoError(t, err)
+
+	req.Status = "not-attending"
+	second, err := queue.Enqueue(ctx, weddingID, req)
+	require.NoError(t, err)
+
+	// Without the guest's link a later submission cannot change the answer
+	req.Status = "maybe"
+	req.GuestToken = ""
+	third, err := queue.Enqueue(ctx, weddingID, req)
+	require.NoError(t, err)
+
+	// The same guest always lands in the same partition, however they were matched
+	require.Equal(t, first.Partition, second.Partition)
+	require.Equal(t, first.Partition, third.Partition)
+	assert.Equal(t, models.RSVPGuestKey(&models.RSVP{WeddingID: weddingID, GuestID: &guest.ID}), first.GuestKey)
+
+	// A guest's next submission waits while an earlier one is leased
+	claimed, err := submissionRepo.ClaimNext(ctx, first.Partition, time.Now(), time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, claimed.ID)
+	_, err = submissionRepo.ClaimNext(ctx, first.Partition, time.Now(), time.Minute)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	require.NoError(t, submissionRepo.Release(ctx, first.ID, time.Now(), "released"))
+
+	for {
+		claimed, err := queue.ProcessNext(ctx, first.Partition)
+		require.NoError(t, err)
+		if !claimed {
+			break
+		}
+	}
+
+	// The later submission updates the RSVP the first one saved
+	assert.Equal(t, models.RSVPSubmissionCompleted, submissionRepo.submissions[first.ID].Status)
+	assert.Equal(t, models.RSVPSubmissionCompleted, submissionRepo.submissions[second.ID].Status)
+	require.NotNil(t, submissionRepo.submissions[second.ID].RSVPID)
+	assert.Equal(t, first.RSVP.ID, *submissionRepo.submissions[second.ID].RSVPID)
+	assert.Equal(t, models.RSVPSubmissionFailed, submissionRepo.submissions[third.ID].Status)
+
+	require.Len(t, rsvpRepo.rsvps, 1)
+	assert.Equal(t, "not-attending", rsvpRepo.rsvps[first.RSVP.ID].Status)
+}
+
+func TestRSVPQueueService_ProcessNext_RetriesTransientErrors(t *testing.T) {
+	queue, rsvpRepo, submissionRepo, weddingID := setupRSVPQueue(t, RSVPQueueOptions{Partitions: 1, MaxAttempts: 2, Backoff: time.Minute})
+	ctx := context.Background()
+
+	submission, err := queue.Enqueue(ctx, weddingID, SubmitRSVPRequest{
+		FirstName:       "John",
+		LastName:        "Smith",
+		Status:          "maybe",
+		AttendanceCount: 1,
+		Source:          "web",
+	})
+	require.NoError(t, err)
+
+	rsvpRepo.createError = errors.New("connection reset")
+
+	before := time.Now()
+	claimed, err := queue.ProcessNext(ctx, 0)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	released := submissionRepo.submissions[submission.ID]
+	assert.Equal(t, models.RSVPSubmissionQueued, released.Status)
+	require.NotNil(t, released.LockedUntil)
+	assert.WithinDuration(t, before.Add(time.Minute), *released.LockedUntil, 5*time.Second)
+
+	// The submission is not retried before its backoff is over
+	claimed, err = queue.ProcessNext(ctx, 0)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	past := time.Now().Add(-time.Second)
+	released.LockedUntil = &past
+	claimed, err = queue.ProcessNext(ctx, 0)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, models.RSVPSubmissionFailed, released.Status)
+	assert.Equal(t, 2, released.Attempts)
+	assert.Empty(t, released.RSVP.FirstName, "failed submissions drop the guest's details")
+}
+
+func TestRSVPQueueService_Backoff(t *testing.T) {
+	queue, _, _, _ := setupRSVPQueue(t, RSVPQueueOptions{Backoff: time.Second, MaxBackoff: 10 * time.Second})
+
+	assert.Equal(t, time.Second, queue.backoff(1))
+	assert.Equal(t, 2*time.Second, queue.backoff(2))
+	assert.Equal(t, 8*time.Second, queue.backoff(4))
+	assert.Equal(t, 10*time.Second, queue.backoff(5))
+}
+
+func TestRSVPQueueService_StartStop(t *testing.T) {
+	queue, rsvpRepo, submissionRepo, weddingID := setupRSVPQueue(t, RSVPQueueOptions{Partitions: 1, PollInterval: 5 * time.Millisecond})
+	ctx := context.Background()
+
+	submission, err := queue.Enqueue(ctx, weddingID, SubmitRSVPRequest{
+		FirstName:       "Ann",
+		LastName:        "Lee",
+		Status:          "attending",
+		AttendanceCount: 2,
+		Source:          "web",
+	})
+	require.NoError(t, err)
+
+	queue.Start(ctx)
+	time.Sleep(50 * time.Millisecond)
+	queue.Stop()
+
+	assert.Equal(t, models.RSVPSubmissionCompleted, submissionRepo.submissions[submission.ID].Status)
+	assert.Len(t, rsvpRepo.rsvps, 1)
+}
@@ -3,28 +3,29 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
 )
 
 // MockRSVPRepository for testing
 type MockRSVPRepository struct {
-	rsvps       map[primitive.ObjectID]*models.RSVP
+	rsvps       map[models.ID]*models.RSVP
 	createError error
 	getError    error
 }
 
 func NewMockRSVPRepository() *MockRSVPRepository {
 	return &MockRSVPRepository{
-		rsvps: make(map[primitive.ObjectID]*models.RSVP),
+		rsvps: make(map[models.ID]*models.RSVP),
 	}
 }
 
@@ -36,7 +37,7 @@ func (m *MockRSVPRepository) Create(ctx context.Context, rsvp *models.RSVP) erro
 	return nil
 }
 
-func (m *MockRSVPRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.RSVP, error) {
+func (m *MockRSVPRepository) GetByID(ctx context.Context, id models.ID) (*models.RSVP, error) {
 	if m.getError != nil {
 		return nil, m.getError
 	}
@@ -46,7 +47,7 @@ func (m *MockRSVPRepository) GetByID(ctx context.Context, id primitive.ObjectID)
 	return nil, repository.ErrNotFound
 }
 
-func (m *MockRSVPRepository) GetByEmail(ctx context.Context, weddingID primitive.ObjectID, email string) (*models.RSVP, error) {
+func (m *MockRSVPRepository) GetByEmail(ctx context.Context, weddingID models.ID, email string) (*models.RSVP, error) {
 	for _, rsvp := range m.rsvps {
 		if rsvp.WeddingID == weddingID && rsvp.Email == email {
 			return rsvp, nil
@@ -55,7 +56,7 @@ func (m *MockRSVPRepository) GetByEmail(ctx context.Context, weddingID primitive
 	return nil, repository.ErrNotFound
 }
 
-func (m *MockRSVPRepository) ListByWedding(ctx context.Context, weddingID primitive.ObjectID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error) {
+func (m *MockRSVPRepository) ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error) {
 	var results []*models.RSVP
 	for _, rsvp := range m.rsvps {
 		if rsvp.WeddingID == weddingID {
@@ -65,17 +66,32 @@ func (m *MockRSVPRepository) ListByWedding(ctx context.Context, weddingID primit
 	return results, int64(len(results)), nil
 }
 
+func (m *MockRSVPRepository) StreamByWedding(ctx context.Context, weddingID models.ID, fn func(*models.RSVP) error) error {
+	for _, rsvp := range m.rsvps {
+		if rsvp.WeddingID != weddingID {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(rsvp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *MockRSVPRepository) Update(ctx context.Context, rsvp *models.RSVP) error {
 	m.rsvps[rsvp.ID] = rsvp
 	return nil
 }
 
-func (m *MockRSVPRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockRSVPRepository) Delete(ctx context.Context, id models.ID) error {
 	delete(m.rsvps, id)
 	return nil
 }
 
-func (m *MockRSVPRepository) GetStatistics(ctx context.Context, weddingID primitive.ObjectID) (*models.RSVPStatistics, error) {
+func (m *MockRSVPRepository) GetStatistics(ctx context.Context, weddingID models.ID) (*models.RSVPStatistics, error) {
 	stats := &models.RSVPStatistics{
 		TotalResponses:  len(m.rsvps),
 		DietaryCounts:   make(map[string]int),
@@ -84,7 +100,7 @@ func (m *MockRSVPRepository) GetStatistics(ctx context.Context, weddingID primit
 	return stats, nil
 }
 
-func (m *MockRSVPRepository) MarkConfirmationSent(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockRSVPRepository) MarkConfirmationSent(ctx context.Context, id models.ID) error {
 	if rsvp, exists := m.rsvps[id]; exists {
 		now := time.Now()
 		rsvp.ConfirmationSent = true
@@ -93,18 +109,72 @@ func (m *MockRSVPRepository) MarkConfirmationSent(ctx context.Context, id primit
 	return nil
 }
 
-func (m *MockRSVPRepository) GetSubmissionTrend(ctx context.Context, weddingID primitive.ObjectID, days int) ([]models.DailyCount, error) {
+func (m *MockRSVPRepository) GetSubmissionTrend(ctx context.Context, weddingID models.ID, days int) ([]models.DailyCount, error) {
 	return []models.DailyCount{}, nil
 }
 
+func (m *MockRSVPRepository) CountByIPSince(ctx context.Context, weddingID models.ID, ipAddress string, since time.Time) (int64, error) {
+	var count int64
+	for _, rsvp := range m.rsvps {
+		if rsvp.WeddingID == weddingID && rsvp.IPAddress == ipAddress && !rsvp.SubmittedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockRSVPRepository) GetAttendanceTimeline(ctx context.Context, weddingID models.ID) ([]models.AttendanceDay, error) {
+	var rsvps []*models.RSVP
+	for _, rsvp := range m.rsvps {
+		if rsvp.WeddingID == weddingID && rsvp.IsCounted() {
+			rsvps = append(rsvps, rsvp)
+		}
+	}
+	sort.Slice(rsvps, func(i, j int) bool { return rsvps[i].SubmittedAt.Before(rsvps[j].SubmittedAt) })
+
+	timeline := []models.AttendanceDay{}
+	for _, rsvp := range rsvps {
+		date := rsvp.SubmittedAt.UTC().Format("2006-01-02")
+		if len(timeline) == 0 || timeline[len(timeline)-1].Date != date {
+			timeline = append(timeline, models.AttendanceDay{Date: date})
+		}
+		day := &timeline[len(timeline)-1]
+		switch rsvp.Status {
+		case string(models.RSVPAttending):
+			day.Attending++
+			day.Headcount += rsvp.AttendanceCount
+		case string(models.RSVPNotAttending):
+			day.NotAttending++
+		case string(models.RSVPMaybe):
+			day.Maybe++
+			day.MaybeHeadcount += rsvp.AttendanceCount
+		}
+	}
+	return timeline, nil
+}
+
+func (m *MockRSVPRepository) GetMealCounts(ctx context.Context, weddingID models.ID) (map[string]int, error) {
+	counts := make(map[string]int)
+	for _, rsvp := range m.rsvps {
+		if rsvp.WeddingID != weddingID || !rsvp.IsCounted() || rsvp.Status != string(models.RSVPAttending) {
+			continue
+		}
+		counts[rsvp.MealChoice]++
+		for _, plusOne := range rsvp.PlusOnes {
+			counts[plusOne.MealChoice]++
+		}
+	}
+	return counts, nil
+}
+
 func TestRSVPService_SubmitRSVP(t *testing.T) {
 	// Setup
 	rsvpRepo := NewMockRSVPRepository()
 	weddingRepo := &MockWeddingRepository{}
 	service := NewRSVPService(rsvpRepo, weddingRepo)
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Create a published wedding
 	wedding := &models.Wedding{
@@ -121,7 +191,7 @@ func TestRSVPService_SubmitRSVP(t *testing.T) {
 
 	// Create existing RSVP
 	existingRSVP := &models.RSVP{
-		ID:        primitive.NewObjectID(),
+		ID:        models.NewID(),
 		WeddingID: weddingID,
 		FirstName: "Existing",
 		LastName:  "User",
@@ -130,18 +200,43 @@ func TestRSVPService_SubmitRSVP(t *testing.T) {
 	}
 	rsvpRepo.rsvps[existingRSVP.ID] = existingRSVP
 
-	// Try to submit with same email
+	// Submitting with the same email does not change the existing RSVP
 	req := SubmitRSVPRequest{
 		FirstName:       "John",
 		LastName:        "Doe",
 		Email:           "duplicate@example.com", // Same email
-		Status:          "attending",
+		Status:          "not-attending",
 		AttendanceCount: 1,
 	}
 
 	_, err := service.SubmitRSVP(context.Background(), weddingID, req)
-	assert.Error(t, err)
 	assert.Equal(t, ErrDuplicateRSVP, err)
+	assert.Equal(t, "attending", existingRSVP.Status)
+
+	// Not with the edit link of another wedding's RSVP either
+	service.EnableEditLinks(&MockEmailService{}, "link-secret", "")
+	otherRSVP := &models.RSVP{ID: models.NewID(), WeddingID: models.NewID(), Email: "other@example.com"}
+	rsvpRepo.rsvps[otherRSVP.ID] = otherRSVP
+	req.EditToken = utils.SignRSVPEditToken("link-secret", otherRSVP.ID, time.Now().Add(time.Hour))
+	_, err = service.SubmitRSVP(context.Background(), weddingID, req)
+	assert.ErrorIs(t, err, ErrInvalidRSVPEditLink)
+
+	// With the RSVP's edit link it updates the existing RSVP
+	req.EditToken = utils.SignRSVPEditToken("link-secret", existingRSVP.ID, time.Now().Add(time.Hour))
+	rsvp, err := service.SubmitRSVP(context.Background(), weddingID, req)
+	assert.NoError(t, err)
+	assert.Equal(t, existingRSVP.ID, rsvp.ID)
+	assert.Equal(t, "not-attending", rsvp.Status)
+	assert.NotNil(t, rsvp.UpdatedAt)
+	assert.Len(t, rsvpRepo.rsvps, 2)
+	if assert.Len(t, rsvp.Changes, 1) {
+		assert.Equal(t, models.RSVPChangedByResubmission, rsvp.Changes[0].ChangedBy)
+	}
+
+	// Not while RSVP editing is off
+	service.EnableFeatureFlags(staticFeatures{FeatureRSVPEditing: false})
+	_, err = service.SubmitRSVP(context.Background(), weddingID, req)
+	assert.Equal(t, ErrRSVPEditingDisabled, err)
 }
 
 func TestRSVPService_SubmitRSVP_TooManyPlusOnes(t *testing.T) {
@@ -149,8 +244,8 @@ func TestRSVPService_SubmitRSVP_TooManyPlusOnes(t *testing.T) {
 	weddingRepo := &MockWeddingRepository{}
 	service := NewRSVPService(rsvpRepo, weddingRepo)
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Create wedding with max plus ones = 1
 	wedding := &models.Wedding{
@@ -186,8 +281,8 @@ func TestRSVPService_UpdateRSVP(t *testing.T) {
 	weddingRepo := &MockWeddingRepository{}
 	service := NewRSVPService(rsvpRepo, weddingRepo)
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Create wedding
 	wedding := &models.Wedding{
@@ -204,7 +299,7 @@ func TestRSVPService_UpdateRSVP(t *testing.T) {
 
 	// Create existing RSVP
 	rsvp := &models.RSVP{
-		ID:              primitive.NewObjectID(),
+		ID:              models.NewID(),
 		WeddingID:       weddingID,
 		FirstName:       "John",
 		LastName:        "Doe",
@@ -234,7 +329,7 @@ func TestRSVPService_UpdateRSVP_NotFound(t *testing.T) {
 	weddingRepo := &MockWeddingRepository{}
 	service := NewRSVPService(rsvpRepo, weddingRepo)
 
-	nonExistentID := primitive.NewObjectID()
+	nonExistentID := models.NewID()
 	req := UpdateRSVPRequest{
 		Status: stringPtr("not-attending"),
 	}
@@ -249,8 +344,8 @@ func TestRSVPService_UpdateRSVP_CannotModify(t *testing.T) {
 	weddingRepo := &MockWeddingRepository{}
 	service := NewRSVPService(rsvpRepo, weddingRepo)
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Create wedding
 	wedding := &models.Wedding{
@@ -262,7 +357,7 @@ func TestRSVPService_UpdateRSVP_CannotModify(t *testing.T) {
 
 	// Create old RSVP (more than 24 hours ago)
 	rsvp := &models.RSVP{
-		ID:              primitive.NewObjectID(),
+		ID:              models.NewID(),
 		WeddingID:       weddingID,
 		FirstName:       "John",
 		LastName:        "Doe",
@@ -286,8 +381,8 @@ func TestRSVPService_DeleteRSVP(t *testing.T) {
 	weddingRepo := &MockWeddingRepository{}
 	service := NewRSVPService(rsvpRepo, weddingRepo)
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Create wedding
 	wedding := &models.Wedding{
@@ -300,7 +395,7 @@ func TestRSVPService_DeleteRSVP(t *testing.T) {
 
 	// Create RSVP
 	rsvp := &models.RSVP{
-		ID:        primitive.NewObjectID(),
+		ID:        models.NewID(),
 		WeddingID: weddingID,
 		FirstName: "John",
 		LastName:  "Doe",
@@ -323,9 +418,9 @@ func TestRSVPService_DeleteRSVP_Unauthorized(t *testing.T) {
 	weddingRepo := &MockWeddingRepository{}
 	service := NewRSVPService(rsvpRepo, weddingRepo)
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
-	otherUserID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
+	otherUserID := models.NewID()
 
 	// Create wedding owned by different user
 	wedding := &models.Wedding{
@@ -337,7 +432,7 @@ func TestRSVPService_DeleteRSVP_Unauthorized(t *testing.T) {
 
 	// Create RSVP
 	rsvp := &models.RSVP{
-		ID:        primitive.NewObjectID(),
+		ID:        models.NewID(),
 		WeddingID: weddingID,
 		FirstName: "John",
 		LastName:  "Doe",
@@ -356,8 +451,8 @@ func TestRSVPService_ListRSVPs(t *testing.T) {
 	weddingRepo := &MockWeddingRepository{}
 	service := NewRSVPService(rsvpRepo, weddingRepo)
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Create wedding
 	wedding := &models.Wedding{
@@ -370,7 +465,7 @@ func TestRSVPService_ListRSVPs(t *testing.T) {
 	// Create RSVPs
 	for i := 0; i < 3; i++ {
 		rsvp := &models.RSVP{
-			ID:        primitive.NewObjectID(),
+			ID:        models.NewID(),
 			WeddingID: weddingID,
 			FirstName: fmt.Sprintf("User%d", i),
 			LastName:  "Test",
@@ -385,13 +480,36 @@ func TestRSVPService_ListRSVPs(t *testing.T) {
 	assert.Equal(t, int64(3), total)
 }
 
+func TestRSVPService_ListRSVPs_Collaborators(t *testing.T) {
+	rsvpRepo := NewMockRSVPRepository()
+	weddingRepo := &MockWeddingRepository{}
+	service := NewRSVPService(rsvpRepo, weddingRepo)
+
+	weddingID := models.NewID()
+	plannerID := models.NewID()
+	wedding := &models.Wedding{
+		ID:     weddingID,
+		UserID: models.NewID(),
+		Collaborators: []models.WeddingCollaborator{
+			{UserID: plannerID, Role: models.WeddingRolePlanner},
+		},
+	}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(wedding, nil)
+
+	_, _, err := service.ListRSVPs(context.Background(), weddingID, plannerID, 1, 10, repository.RSVPFilters{})
+	assert.NoError(t, err)
+
+	_, _, err = service.ListRSVPs(context.Background(), weddingID, models.NewID(), 1, 10, repository.RSVPFilters{})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
 func TestRSVPService_GetRSVPStatistics(t *testing.T) {
 	rsvpRepo := NewMockRSVPRepository()
 	weddingRepo := &MockWeddingRepository{}
 	service := NewRSVPService(rsvpRepo, weddingRepo)
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Create wedding
 	wedding := &models.Wedding{
@@ -411,8 +529,8 @@ func TestRSVPService_ExportRSVPs(t *testing.T) {
 	weddingRepo := &MockWeddingRepository{}
 	service := NewRSVPService(rsvpRepo, weddingRepo)
 
-	weddingID := primitive.NewObjectID()
-	userID := primitive.NewObjectID()
+	weddingID := models.NewID()
+	userID := models.NewID()
 
 	// Create wedding
 	wedding := &models.Wedding{
@@ -424,7 +542,7 @@ func TestRSVPService_ExportRSVPs(t *testing.T) {
 
 	// Create RSVP
 	rsvp := &models.RSVP{
-		ID:        primitive.NewObjectID(),
+		ID:        models.NewID(),
 		WeddingID: weddingID,
 		FirstName: "John",
 		LastName:  "Doe",
@@ -437,3 +555,246 @@ func TestRSVPService_ExportRSVPs(t *testing.T) {
 	assert.Equal(t, 1, len(rsvps))
 	assert.Equal(t, "John", rsvps[0].FirstName)
 }
+
+func TestRSVPService_StreamRSVPs(t *testing.T) {
+	rsvpRepo := NewMockRSVPRepository()
+	weddingRepo := &MockWeddingRepository{}
+	service := NewRSVPService(rsvpRepo, weddingRepo)
+
+	weddingID := models.NewID()
+	userID := models.NewID()
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(&models.Wedding{ID: weddingID, UserID: userID}, nil)
+
+	for i := 0; i < 5; i++ {
+		id := models.NewID()
+		rsvpRepo.rsvps[id] = &models.RSVP{ID: id, WeddingID: weddingID, FirstName: "Guest", Status: "attending"}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		count := 0
+		err := service.StreamRSVPs(context.Background(), weddingID, userID, func(rsvp *models.RSVP) error {
+			count++
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 5, count)
+	})
+
+	t.Run("Stops when context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		count := 0
+		err := service.StreamRSVPs(ctx, weddingID, userID, func(rsvp *models.RSVP) error {
+			count++
+			cancel()
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Error - not owner", func(t *testing.T) {
+		err := service.StreamRSVPs(context.Background(), weddingID, models.NewID(), func(rsvp *models.RSVP) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+}
+
+func sessionWedding(weddingID, userID models.ID) *models.Wedding {
+	return &models.Wedding{
+		ID:     weddingID,
+		UserID: userID,
+		Status: "published",
+		RSVP:   models.RSVPSettings{Enabled: true, MaxPlusOnes: 2},
+		Sessions: []models.EventSession{
+			{ID: "akad", EventDetails: models.EventDetails{Title: "Akad Nikah"}},
+			{ID: "reception", EventDetails: models.EventDetails{Title: "Reception"}},
+			{ID: "after-party", EventDetails: models.EventDetails{Title: "After-party"}},
+		},
+	}
+}
+
+func TestRSVPService_SubmitRSVP_Sessions(t *testing.T) {
+	weddingID := models.NewID()
+	wedding := sessionWedding(weddingID, models.NewID())
+
+	tests := []struct {
+		name           string
+		status         string
+		sessions       []models.RSVPSessionResponse
+		expectedErr    error
+		expectedStatus string
+		expected       []models.RSVPSessionResponse
+	}{
+		{
+			name:           "overall answer applies to every session",
+			status:         "maybe",
+			expectedStatus: "maybe",
+			expected: []models.RSVPSessionResponse{
+				{SessionID: "akad", Status: "maybe"},
+				{SessionID: "reception", Status: "maybe"},
+				{SessionID: "after-party", Status: "maybe"},
+			},
+		},
+		{
+			name:           "unanswered sessions are declined",
+			status:         "not-attending",
+			sessions:       []models.RSVPSessionResponse{{SessionID: "reception", Status: "attending"}},
+			expectedStatus: "attending",
+			expected: []models.RSVPSessionResponse{
+				{SessionID: "akad", Status: "not-attending"},
+				{SessionID: "reception", Status: "attending"},
+				{SessionID: "after-party", Status: "not-attending"},
+			},
+		},
+		{
+			name:        "unknown session",
+			status:      "attending",
+			sessions:    []models.RSVPSessionResponse{{SessionID: "brunch", Status: "attending"}},
+			expectedErr: ErrInvalidRSVPSessions,
+		},
+		{
+			name:   "duplicate session",
+			status: "attending",
+			sessions: []models.RSVPSessionResponse{
+				{SessionID: "akad", Status: "attending"},
+				{SessionID: "akad", Status: "not-attending"},
+			},
+			expectedErr: ErrInvalidRSVPSessions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weddingRepo := &MockWeddingRepository{}
+			weddingRepo.On("GetByID", mock.Anything, weddingID).Return(wedding, nil)
+			weddingRepo.On("UpdateRSVPCount", mock.Anything, weddingID).Return(nil)
+			service := NewRSVPService(NewMockRSVPRepository(), weddingRepo)
+
+			rsvp, err := service.SubmitRSVP(context.Background(), weddingID, SubmitRSVPRequest{
+				FirstName:       "John",
+				LastName:        "Doe",
+				Status:          tt.status,
+				AttendanceCount: 1,
+				Sessions:        tt.sessions,
+			})
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rsvp.Status)
+			assert.Equal(t, tt.expected, rsvp.Sessions)
+		})
+	}
+}
+
+func TestRSVPService_SubmitRSVP_SessionsWithoutSessionWedding(t *testing.T) {
+	weddingID := models.NewID()
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(&models.Wedding{
+		ID:     weddingID,
+		Status: "published",
+		RSVP:   models.RSVPSettings{Enabled: true},
+	}, nil)
+	service := NewRSVPService(NewMockRSVPRepository(), weddingRepo)
+
+	_, err := service.SubmitRSVP(context.Background(), weddingID, SubmitRSVPRequest{
+		FirstName:       "John",
+		LastName:        "Doe",
+		Status:          "attending",
+		AttendanceCount: 1,
+		Sessions:        []models.RSVPSessionResponse{{SessionID: "akad", Status: "attending"}},
+	})
+	assert.ErrorIs(t, err, ErrInvalidRSVPSessions)
+}
+
+func TestRSVPService_UpdateRSVP_Sessions(t *testing.T) {
+	weddingID := models.NewID()
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(sessionWedding(weddingID, models.NewID()), nil)
+	weddingRepo.On("UpdateRSVPCount", mock.Anything, weddingID).Return(nil)
+	rsvpRepo := NewMockRSVPRepository()
+	service := NewRSVPService(rsvpRepo, weddingRepo)
+
+	rsvp := &models.RSVP{
+		ID:              models.NewID(),
+		WeddingID:       weddingID,
+		FirstName:       "John",
+		LastName:        "Doe",
+		Status:          "attending",
+		AttendanceCount: 1,
+		Sessions: []models.RSVPSessionResponse{
+			{SessionID: "akad", Status: "attending"},
+			{SessionID: "reception", Status: "not-attending"},
+			{SessionID: "dropped", Status: "attending"},
+		},
+		SubmittedAt: time.Now().Add(-time.Hour),
+	}
+	rsvpRepo.rsvps[rsvp.ID] = rsvp
+
+	// Other changes keep the answers, minus sessions the wedding no longer has
+	updated, err := service.UpdateRSVP(context.Background(), rsvp.ID, UpdateRSVPRequest{AttendanceCount: intPtr(2)})
+	require.NoError(t, err)
+	assert.Equal(t, "attending", updated.Status)
+	assert.Equal(t, []models.RSVPSessionResponse{
+		{SessionID: "akad", Status: "attending"},
+		{SessionID: "reception", Status: "not-attending"},
+		{SessionID: "after-party", Status: "not-attending"},
+	}, updated.Sessions)
+
+	// A new overall answer replaces them
+	maybe := "maybe"
+	updated, err = service.UpdateRSVP(context.Background(), rsvp.ID, UpdateRSVPRequest{Status: &maybe})
+	require.NoError(t, err)
+	for _, response := range updated.Sessions {
+		assert.Equal(t, "maybe", response.Status)
+	}
+}
+
+func TestRSVPService_GetRSVPStatistics_Sessions(t *testing.T) {
+	weddingID := models.NewID()
+	userID := models.NewID()
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(sessionWedding(weddingID, userID), nil)
+	service := NewRSVPService(NewMockRSVPRepository(), weddingRepo)
+
+	stats, err := service.GetRSVPStatistics(context.Background(), weddingID, userID)
+	require.NoError(t, err)
+	require.Len(t, stats.Sessions, 3)
+	assert.Equal(t, models.SessionStatistics{SessionID: "akad", Title: "Akad Nikah"}, stats.Sessions[0])
+	assert.Equal(t, "after-party", stats.Sessions[2].SessionID)
+}
+
+func TestRSVPService_SubmitRSVP_NotifiesWebhooks(t *testing.T) {
+	weddingID := models.NewID()
+	wedding := &models.Wedding{
+		ID:     weddingID,
+		UserID: models.NewID(),
+		Status: "published",
+		RSVP:   models.RSVPSettings{Enabled: true, MaxPlusOnes: 2},
+	}
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, weddingID).Return(wedding, nil)
+	weddingRepo.On("UpdateRSVPCount", mock.Anything, weddingID).Return(nil)
+
+	notifier := &recordingEventNotifier{}
+	service := NewRSVPService(NewMockRSVPRepository(), weddingRepo)
+	service.EnableWebhooks(notifier)
+
+	rsvp, err := service.SubmitRSVP(context.Background(), weddingID, SubmitRSVPRequest{
+		FirstName:       "John",
+		LastName:        "Doe",
+		Status:          "attending",
+		AttendanceCount: 1,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, notifier.events, 1)
+	event := notifier.events[0]
+	assert.Equal(t, models.WeddingEventRSVPCreated, event.Type)
+	assert.Equal(t, weddingID, event.WeddingID)
+	assert.Equal(t, rsvp.ID.String(), event.Data["rsvp_id"])
+	assert.Equal(t, "John Doe", event.Data["name"])
+	assert.Equal(t, "attending", event.Data["status"])
+}
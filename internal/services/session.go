@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// maxSessionUserAgentLength caps the user agent kept on a session
+const maxSessionUserAgentLength = 512
+
+// ErrSessionNotFound is returned for sessions that ended, expired or belong to another user
+var ErrSessionNotFound = errs.NotFound("session not found")
+
+// SessionService tracks the devices signed in to each account. Every login
+// starts a session, which its tokens are bound to; ending the session stops
+// them from being refreshed or used.
+type SessionService struct {
+	repo   repository.SessionRepository
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+// NewSessionService creates a new session service
+func NewSessionService(repo repository.SessionRepository, logger *zap.Logger) *SessionService {
+	return &SessionService{repo: repo, logger: logger, now: time.Now}
+}
+
+// Start records a session for the device the request came from, bound to the
+// refresh token issued for it
+func (s *SessionService) Start(ctx context.Context, id, userID models.ID, refreshTokenID string, expiresAt time.Time) error {
+	now := s.now()
+	session := &models.Session{
+		ID:             id,
+		UserID:         userID,
+		RefreshTokenID: refreshTokenID,
+		CreatedAt:      now,
+		LastSeenAt:     now,
+		ExpiresAt:      expiresAt,
+	}
+	s.setClient(ctx, session)
+	return s.repo.Create(ctx, session)
+}
+
+// Rotate binds the session to a new refresh token. A refresh token that was
+// already replaced must have been copied, so presenting it ends the session.
+func (s *SessionService) Rotate(ctx context.Context, id, userID models.ID, previousTokenID, refreshTokenID string, expiresAt time.Time) error {
+	session, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+	if session.UserID != userID || !s.now().Before(session.ExpiresAt) {
+		return ErrSessionNotFound
+	}
+
+	session.RefreshTokenID = refreshTokenID
+	session.LastSeenAt = s.now()
+	session.ExpiresAt = expiresAt
+	s.setClient(ctx, session)
+	if err := s.repo.Rotate(ctx, session, previousTokenID); err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		s.logger.Warn("Refresh token reused, ending its session",
+			zap.String("user_id", userID.String()),
+			zap.String("session_id", id.String()))
+		if err := s.repo.Delete(ctx, userID, id); err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// IsActive reports whether the user's session has neither ended nor expired
+func (s *SessionService) IsActive(ctx context.Context, userID, id models.ID) (bool, error) {
+	session, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return session.UserID == userID && s.now().Before(session.ExpiresAt), nil
+}
+
+// ListSessions lists the user's active sessions, most recently seen first,
+// marking the current one
+func (s *SessionService) ListSessions(ctx context.Context, userID, currentID models.ID) ([]*models.Session, error) {
+	sessions, err := s.repo.ListActiveByUser(ctx, userID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		session.Current = !currentID.IsZero() && session.ID == currentID
+	}
+	return sessions, nil
+}
+
+// RevokeSession ends one of the user's sessions
+func (s *SessionService) RevokeSession(ctx context.Context, userID, id models.ID) error {
+	if err := s.repo.Delete(ctx, userID, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// RevokeOtherSessions ends all of the user's sessions but the current one and
+// returns how many ended. Without a current session, all of them end.
+func (s *SessionService) RevokeOtherSessions(ctx context.Context, userID, currentID models.ID) (int64, error) {
+	var except *models.ID
+	if !currentID.IsZero() {
+		except = &currentID
+	}
+	return s.repo.DeleteByUser(ctx, userID, except)
+}
+
+// RevokeAllSessions ends all of the user's sessions
+func (s *SessionService) RevokeAllSessions(ctx context.Context, userID models.ID) (int64, error) {
+	return s.repo.DeleteByUser(ctx, userID, nil)
+}
+
+// setClient records the device the request came from on the session
+func (s *SessionService) setClient(ctx context.Context, session *models.Session) {
+	client := utils.ClientFromContext(ctx)
+	session.IP = client.IP
+	session.UserAgent = client.UserAgent
+	if len(session.UserAgent) > maxSessionUserAgentLength {
+		session.UserAgent = session.UserAgent[:maxSessionUserAgentLength]
+	}
+	session.Device, session.Browser, session.OS = parseUserAgent(client.UserAgent)
+}
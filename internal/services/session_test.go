@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// memorySessionRepository is an in-memory SessionRepository
+type memorySessionRepository struct {
+	mu       sync.Mutex
+	sessions map[models.ID]models.Session
+}
+
+func newMemorySessionRepository() *memorySessionRepository {
+	return &memorySessionRepository{sessions: make(map[models.ID]models.Session)}
+}
+
+func (r *memorySessionRepository) Create(ctx context.Context, session *models.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.ID] = *session
+	return nil
+}
+
+func (r *memorySessionRepository) GetByID(ctx context.Context, id models.ID) (*models.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &session, nil
+}
+
+func (r *memorySessionRepository) ListActiveByUser(ctx context.Context, userID models.ID, now time.Time) ([]*models.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sessions := []*models.Session{}
+	for _, session := range r.sessions {
+		if session.UserID == userID && now.Before(session.ExpiresAt) {
+			session := session
+			sessions = append(sessions, &session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt) })
+	return sessions, nil
+}
+
+func (r *memorySessionRepository) Rotate(ctx context.Context, session *models.Session, previousTokenID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.sessions[session.ID]
+	if !ok || stored.RefreshTokenID != previousTokenID {
+		return repository.ErrNotFound
+	}
+	r.sessions[session.ID] = *session
+	return nil
+}
+
+func (r *memorySessionRepository) Delete(ctx context.Context, userID, id models.ID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, ok := r.sessions[id]
+	if !ok || session.UserID != userID {
+		return repository.ErrNotFound
+	}
+	delete(r.sessions, id)
+	return nil
+}
+
+func (r *memorySessionRepository) DeleteByUser(ctx context.Context, userID models.ID, except *models.ID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for id, session := range r.sessions {
+		if session.UserID == userID && (except == nil || id != *except) {
+			delete(r.sessions, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// newSessionTestAuthService returns an auth service tracking sessions for an
+// active user with the password "correct-horse"
+func newSessionTestAuthService(t *testing.T) (AuthService, *SessionService, *memorySessionRepository, *utils.JWTManager, *models.User) {
+	t.Helper()
+	hash, err := utils.HashPasswordWithCost("correct-horse", 4)
+	require.NoError(t, err)
+	user := &models.User{
+		ID:           models.NewID(),
+		Email:        "dana@example.com",
+		PasswordHash: hash,
+		Role:         "user",
+		Status:       models.UserStatusActive,
+	}
+	userRepo := &MockUserRepository{}
+	userRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+	userRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	userRepo.On("Update", mock.Anything, user).Return(nil)
+
+	repo := newMemorySessionRepository()
+	sessions := NewSessionService(repo, zap.NewNop())
+	jwtManager := utils.NewJWTManager("access-secret", "refresh-secret", time.Minute, time.Hour, "test")
+	return NewAuthService(userRepo, jwtManager, AuthDependencies{Sessions: sessions}), sessions, repo, jwtManager, user
+}
+
+func TestAuthService_Sessions(t *testing.T) {
+	login := LoginRequest{Email: "dana@example.com", Password: "correct-horse"}
+	ctx := utils.WithClient(context.Background(), utils.RequestClient{
+		IP:        "203.0.113.7",
+		UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148 Safari/604.1",
+	})
+
+	t.Run("Success - login starts a session its tokens are bound to", func(t *testing.T) {
+		auth, sessions, _, jwtManager, user := newSessionTestAuthService(t)
+
+		resp, err := auth.Login(ctx, login)
+		require.NoError(t, err)
+
+		claims, err := jwtManager.ValidateToken(resp.AccessToken, utils.AccessToken)
+		require.NoError(t, err)
+		list, err := sessions.ListSessions(ctx, user.ID, models.NilID)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, list[0].ID.String(), claims.SessionID)
+		assert.Equal(t, "203.0.113.7", list[0].IP)
+		assert.Equal(t, "mobile", list[0].Device)
+		assert.Equal(t, "ios", list[0].OS)
+	})
+
+	t.Run("Success - refreshing rotates the refresh token; replaying the old one ends the session", func(t *testing.T) {
+		auth, _, repo, _, _ := newSessionTestAuthService(t)
+		first, err := auth.Login(ctx, login)
+		require.NoError(t, err)
+
+		second, err := auth.RefreshToken(ctx, first.RefreshToken)
+		require.NoError(t, err)
+		assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+
+		_, err = auth.RefreshToken(ctx, first.RefreshToken)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+		assert.Empty(t, repo.sessions)
+		_, err = auth.RefreshToken(ctx, second.RefreshToken)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	})
+
+	t.Run("Error - refresh tokens bound to no session are rejected", func(t *testing.T) {
+		auth, _, repo, jwtManager, user := newSessionTestAuthService(t)
+		legacy, err := jwtManager.GenerateTokenPair(user.ID, user.Email, []string{user.Role})
+		require.NoError(t, err)
+
+		_, err = auth.RefreshToken(ctx, legacy.RefreshToken)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+		assert.Empty(t, repo.sessions)
+	})
+
+	t.Run("Success - logging out ends the session", func(t *testing.T) {
+		auth, sessions, repo, jwtManager, user := newSessionTestAuthService(t)
+		resp, err := auth.Login(ctx, login)
+		require.NoError(t, err)
+		claims, err := jwtManager.ValidateToken(resp.AccessToken, utils.AccessToken)
+		require.NoError(t, err)
+
+		sessionID, err := models.ParseID(claims.SessionID)
+		require.NoError(t, err)
+		active, err := sessions.IsActive(ctx, user.ID, sessionID)
+		require.NoError(t, err)
+		assert.True(t, active)
+
+		require.NoError(t, auth.Logout(ctx, user.ID.String(), claims.SessionID))
+		assert.Empty(t, repo.sessions)
+		active, err = sessions.IsActive(ctx, user.ID, sessionID)
+		require.NoError(t, err)
+		assert.False(t, active, "the access token of the session is turned away")
+		_, err = auth.RefreshToken(ctx, resp.RefreshToken)
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	})
+}
+
+func TestSessionService_Revoke(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemorySessionRepository()
+	sessions := NewSessionService(repo, zap.NewNop())
+	userID := models.NewID()
+	otherUserID := models.NewID()
+	expires := time.Now().Add(time.Hour)
+
+	current, laptop, phone, others := models.NewID(), models.NewID(), models.NewID(), models.NewID()
+	require.NoError(t, sessions.Start(ctx, current, userID, "a", expires))
+	require.NoError(t, sessions.Start(ctx, laptop, userID, "b", expires))
+	require.NoError(t, sessions.Start(ctx, phone, userID, "c", expires))
+	require.NoError(t, sessions.Start(ctx, others, otherUserID, "d", expires))
+	require.NoError(t, sessions.Start(ctx, models.NewID(), userID, "e", time.Now().Add(-time.Minute)))
+
+	list, err := sessions.ListSessions(ctx, userID, current)
+	require.NoError(t, err)
+	require.Len(t, list, 3, "expired sessions are not listed")
+	for _, session := range list {
+		assert.Equal(t, session.ID == current, session.Current)
+	}
+
+	active, err := sessions.IsActive(ctx, userID, others)
+	require.NoError(t, err)
+	assert.False(t, active, "other users' sessions are not active for the user")
+
+	assert.ErrorIs(t, sessions.RevokeSession(ctx, userID, others), ErrSessionNotFound)
+	require.NoError(t, sessions.RevokeSession(ctx, userID, laptop))
+	assert.ErrorIs(t, sessions.RevokeSession(ctx, userID, laptop), ErrSessionNotFound)
+
+	revoked, err := sessions.RevokeOtherSessions(ctx, userID, current)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), revoked)
+	list, err = sessions.ListSessions(ctx, userID, current)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, current, list[0].ID)
+
+	_, err = repo.GetByID(ctx, others)
+	assert.NoError(t, err, "other users' sessions are kept")
+}
@@ -1,10 +1,22 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
 	"time"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/utils"
 )
 
 // StorageService handles file storage operations
@@ -17,11 +29,62 @@ type StorageService interface {
 	Exists(ctx context.Context, key string) (bool, error)
 }
 
-// PresignedUploadInfo contains information for pre-signed uploads
+// MultipartStorage is implemented by storage backends that let clients upload
+// large files directly in parts
+type MultipartStorage interface {
+	CreateMultipartUpload(ctx context.Context, key string, contentType string) (string, error)
+	PresignUploadPart(ctx context.Context, key, uploadID string, partNumber int, expiry time.Duration) (string, error)
+	CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []CompletedPart) error
+	AbortMultipartUpload(ctx context.Context, key, uploadID string) error
+}
+
+// ObjectReader is implemented by storage backends that can read stored files
+// back, which background thumbnail generation needs
+type ObjectReader interface {
+	Download(ctx context.Context, key string) ([]byte, error)
+}
+
+// ObjectOpener is implemented by storage backends whose files the API serves
+// itself, with range requests and conditional caching
+type ObjectOpener interface {
+	Open(ctx context.Context, key string) (*StoredObject, error)
+}
+
+// StoredObject is an opened stored file. The caller closes Content.
+type StoredObject struct {
+	Content     io.ReadSeekCloser
+	Size        int64
+	ContentType string
+	// ETag identifies the stored version of the file, quoted as in the header
+	ETag    string
+	ModTime time.Time
+}
+
+// ErrStoredFileNotFound is returned when opening a file that is not stored
+var ErrStoredFileNotFound = errs.NotFound("file not found")
+
+// StoragePinger is implemented by remote storage backends, whose reachability
+// readiness checks probe
+type StoragePinger interface {
+	Ping(ctx context.Context) error
+}
+
+// PresignedUploadInfo contains information for pre-signed uploads. Method is
+// POST when Fields must be sent as a multipart form along with the file, and
+// PUT when the file is the raw request body.
 type PresignedUploadInfo struct {
-	URL    string
-	Fields map[string]string
-	Key    string
+	URL       string
+	Method    string
+	Fields    map[string]string
+	Key       string
+	ExpiresAt time.Time
+}
+
+// CompletedPart identifies an uploaded part of a multipart upload by the ETag
+// the storage backend returned for it
+type CompletedPart struct {
+	PartNumber int    `json:"partNumber" binding:"required,min=1"`
+	ETag       string `json:"etag" binding:"required"`
 }
 
 // StorageConfig contains storage configuration
@@ -34,60 +97,161 @@ type StorageConfig struct {
 	Endpoint    string `json:"endpoint"`
 	CDNURL      string `json:"cdnUrl"`
 	Environment string `json:"environment"`
+	// UseSSL selects https for the endpoint
+	UseSSL bool `json:"useSsl"`
+	// ForcePathStyle addresses objects as endpoint/bucket/key, which MinIO needs
+	ForcePathStyle bool `json:"forcePathStyle"`
 }
 
-// LocalStorageService is a simple file system storage for development
+// LocalStorageService stores files on the local file system, for development
+// and single-instance deployments. The API serves them under baseURL.
 type LocalStorageService struct {
 	basePath string
 	baseURL  string
+	// signingSecret signs the expiring download links of private files
+	signingSecret string
 }
 
+// Ensure LocalStorageService can read files back for background processing
+var _ ObjectReader = (*LocalStorageService)(nil)
+
+// Ensure LocalStorageService files can be served by the API
+var _ ObjectOpener = (*LocalStorageService)(nil)
+
 // NewLocalStorageService creates a new local storage service
-func NewLocalStorageService(basePath, baseURL string) StorageService {
+func NewLocalStorageService(basePath, baseURL string) *LocalStorageService {
 	return &LocalStorageService{
 		basePath: basePath,
-		baseURL:  baseURL,
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
 	}
 }
 
+// EnableSignedURLs makes presigned URLs expiring links signed with secret, which
+// the file handler checks before serving private files
+func (s *LocalStorageService) EnableSignedURLs(secret string) {
+	s.signingSecret = secret
+}
+
 // Upload saves a file to local storage
 func (s *LocalStorageService) Upload(ctx context.Context, key string, data []byte, contentType string, metadata map[string]string) (string, error) {
-	// In a real implementation, this would save the file to the filesystem
-	// For now, return a mock URL
-	url := fmt.Sprintf("%s/%s", s.baseURL, key)
-	return url, nil
+	return s.UploadStream(ctx, key, bytes.NewReader(data), contentType, int64(len(data)), metadata)
 }
 
-// UploadStream saves a file stream to local storage
+// UploadStream saves a file stream to local storage. The file is written under
+// a temporary name first, so that readers never see a partial file.
 func (s *LocalStorageService) UploadStream(ctx context.Context, key string, reader io.Reader, contentType string, size int64, metadata map[string]string) (string, error) {
-	// In a real implementation, this would save the file stream to the filesystem
-	url := fmt.Sprintf("%s/%s", s.baseURL, key)
-	return url, nil
+	filePath := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, reader); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return "", fmt.Errorf("failed to store file: %w", err)
+	}
+	return s.fileURL(key), nil
 }
 
 // Delete removes a file from local storage
 func (s *LocalStorageService) Delete(ctx context.Context, key string) error {
-	// In a real implementation, this would delete the file from the filesystem
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
 	return nil
 }
 
-// GetPresignedURL generates a pre-signed URL for local storage (not typically used)
+// GetPresignedURL returns a download link that expires after expiry when signed
+// URLs are enabled, and the plain file URL otherwise
 func (s *LocalStorageService) GetPresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
-	url := fmt.Sprintf("%s/%s", s.baseURL, key)
-	return url, nil
+	if s.signingSecret == "" {
+		return s.fileURL(key), nil
+	}
+	return utils.SignFileURL(s.signingSecret, s.baseURL, key, time.Now().Add(expiry)), nil
 }
 
 // GeneratePresignedUploadURL generates a pre-signed upload URL (not typically used for local storage)
 func (s *LocalStorageService) GeneratePresignedUploadURL(ctx context.Context, key string, contentType string, size int64, expiry time.Duration) (*PresignedUploadInfo, error) {
 	return &PresignedUploadInfo{
-		URL:    fmt.Sprintf("%s/%s", s.baseURL, key),
-		Fields: make(map[string]string),
-		Key:    key,
+		URL:       s.fileURL(key),
+		Method:    http.MethodPut,
+		Fields:    make(map[string]string),
+		Key:       key,
+		ExpiresAt: time.Now().Add(expiry),
 	}, nil
 }
 
 // Exists checks if a file exists in local storage
 func (s *LocalStorageService) Exists(ctx context.Context, key string) (bool, error) {
-	// In a real implementation, this would check if the file exists on the filesystem
-	return true, nil
-}
\ No newline at end of file
+	info, err := os.Stat(s.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to stat file: %w", err)
+	}
+	return !info.IsDir(), nil
+}
+
+// Download reads a file from local storage
+func (s *LocalStorageService) Download(ctx context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrStoredFileNotFound
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data, nil
+}
+
+// Open opens a file in local storage. Its ETag derives from the size and
+// modification time, as files are only ever replaced whole.
+func (s *LocalStorageService) Open(ctx context.Context, key string) (*StoredObject, error) {
+	file, err := os.Open(s.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrStoredFileNotFound
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, ErrStoredFileNotFound
+	}
+
+	return &StoredObject{
+		Content:     file,
+		Size:        info.Size(),
+		ContentType: mime.TypeByExtension(filepath.Ext(key)),
+		ETag:        fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()),
+		ModTime:     info.ModTime(),
+	}, nil
+}
+
+// path returns the file system path of a key. Keys are cleaned as rooted paths
+// first, so that ".." segments cannot escape the base path.
+func (s *LocalStorageService) path(key string) string {
+	return filepath.Join(s.basePath, filepath.FromSlash(path.Clean("/"+key)))
+}
+
+func (s *LocalStorageService) fileURL(key string) string {
+	return s.baseURL + "/" + key
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// storageMigrationPageSize is how many media records are read per query
+const storageMigrationPageSize = 100
+
+// StorageMigrationResult reports what a storage migration did
+type StorageMigrationResult struct {
+	Media  int         `json:"media"`
+	Files  int         `json:"files"`
+	Failed []models.ID `json:"failed,omitempty"`
+}
+
+// StorageMigrationService copies stored media files from one storage backend to
+// another and points their media records at the copies. Files are copied under
+// the same keys, so a migration interrupted half way can simply be run again;
+// the source files are left in place.
+type StorageMigrationService struct {
+	mediaRepo repository.MediaRepository
+	logger    *zap.Logger
+}
+
+// NewStorageMigrationService creates a new storage migration service
+func NewStorageMigrationService(mediaRepo repository.MediaRepository, logger *zap.Logger) *StorageMigrationService {
+	return &StorageMigrationService{mediaRepo: mediaRepo, logger: logger}
+}
+
+// Migrate copies the files of every media record that is not deleted, its
+// original, thumbnails and video rendition, from one backend to the other. A
+// media record failing to copy is logged and reported, and the others are still
+// migrated. A dry run only counts what would be copied.
+func (s *StorageMigrationService) Migrate(ctx context.Context, from ObjectReader, to StorageService, dryRun bool) (*StorageMigrationResult, error) {
+	result := &StorageMigrationResult{}
+	opts := repository.ListOptions{
+		Limit: storageMigrationPageSize,
+		Sort:  []repository.SortField{{Field: "createdAt"}, {Field: "_id"}},
+	}
+
+	for {
+		page, _, err := s.mediaRepo.List(ctx, repository.MediaFilter{}, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list media: %w", err)
+		}
+
+		for _, media := range page {
+			if media.StorageKey == "" {
+				continue
+			}
+			files := mediaFiles(media)
+			if !dryRun {
+				if err := s.migrateMedia(ctx, from, to, media, files); err != nil {
+					s.logger.Error("Failed to migrate media",
+						zap.String("media_id", media.ID.String()),
+						zap.Error(err))
+					result.Failed = append(result.Failed, media.ID)
+					continue
+				}
+			}
+			result.Media++
+			result.Files += len(files)
+		}
+
+		if len(page) < storageMigrationPageSize {
+			return result, nil
+		}
+		opts.Offset += storageMigrationPageSize
+	}
+}
+
+// migrateMedia copies the files of a media record and records their new URLs
+// once all of them were copied
+func (s *StorageMigrationService) migrateMedia(ctx context.Context, from ObjectReader, to StorageService, media *models.Media, files []mediaFile) error {
+	urls := make(map[string]string, len(files))
+	for _, file := range files {
+		data, err := from.Download(ctx, file.key)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file.key, err)
+		}
+		copied, err := to.Upload(ctx, file.key, data, file.contentType, nil)
+		if err != nil {
+			return fmt.Errorf("failed to copy %s: %w", file.key, err)
+		}
+		urls[file.url] = copied
+	}
+
+	media.OriginalURL = urls[media.OriginalURL]
+	for name, thumbURL := range media.Thumbnails {
+		media.Thumbnails[name] = urls[thumbURL]
+	}
+	if media.PlaybackURL != "" {
+		media.PlaybackURL = urls[media.PlaybackURL]
+	}
+	return s.mediaRepo.Update(ctx, media)
+}
+
+// mediaFile is one stored file of a media record
+type mediaFile struct {
+	key         string
+	url         string
+	contentType string
+}
+
+// mediaFiles lists the stored files of a media record. Thumbnails and video
+// renditions are stored next to the original, under the name their URL ends in.
+func mediaFiles(media *models.Media) []mediaFile {
+	files := []mediaFile{{key: media.StorageKey, url: media.OriginalURL, contentType: media.MimeType}}
+
+	derived := make([]string, 0, len(media.Thumbnails)+1)
+	for _, thumbURL := range media.Thumbnails {
+		derived = append(derived, thumbURL)
+	}
+	if media.PlaybackURL != "" {
+		derived = append(derived, media.PlaybackURL)
+	}
+	for _, fileURL := range derived {
+		name := fileURL
+		if parsed, err := url.Parse(fileURL); err == nil {
+			name = parsed.Path
+		}
+		key := path.Dir(media.StorageKey) + "/" + path.Base(name)
+		contentType := extensionToMimeType(strings.TrimPrefix(path.Ext(key), "."))
+		if contentType == "" {
+			contentType = media.MimeType
+		}
+		files = append(files, mediaFile{key: key, url: fileURL, contentType: contentType})
+	}
+	return files
+}
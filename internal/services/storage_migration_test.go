@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func TestStorageMigrationService_Migrate(t *testing.T) {
+	ctx := context.Background()
+
+	newImage := func() *models.Media {
+		return &models.Media{
+			ID:          models.NewID(),
+			MimeType:    "image/jpeg",
+			StorageKey:  "uploads/2026/01/02/abc/original.jpg",
+			OriginalURL: "http://localhost:8080/uploads/uploads/2026/01/02/abc/original.jpg",
+			Thumbnails: map[string]string{
+				"small":      "http://localhost:8080/uploads/uploads/2026/01/02/abc/small.jpg",
+				"small_webp": "http://localhost:8080/uploads/uploads/2026/01/02/abc/small.webp",
+			},
+		}
+	}
+
+	t.Run("Success - copies every file and repoints the record", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		from := new(MockReadableStorageService)
+		to := new(MockStorageService)
+		service := NewStorageMigrationService(repo, zaptest.NewLogger(t))
+
+		media := newImage()
+		repo.On("List", ctx, mock.Anything, mock.Anything).Return([]*models.Media{media, {ID: models.NewID()}}, int64(2), nil).Once()
+		from.On("Download", ctx, mock.AnythingOfType("string")).Return([]byte("data"), nil)
+		to.On("Upload", ctx, "uploads/2026/01/02/abc/original.jpg", []byte("data"), "image/jpeg", mock.Anything).
+			Return("https://cdn.example.com/uploads/2026/01/02/abc/original.jpg", nil)
+		to.On("Upload", ctx, "uploads/2026/01/02/abc/small.jpg", []byte("data"), "image/jpeg", mock.Anything).
+			Return("https://cdn.example.com/uploads/2026/01/02/abc/small.jpg", nil)
+		to.On("Upload", ctx, "uploads/2026/01/02/abc/small.webp", []byte("data"), "image/webp", mock.Anything).
+			Return("https://cdn.example.com/uploads/2026/01/02/abc/small.webp", nil)
+		repo.On("Update", ctx, media).Return(nil).Once()
+
+		result, err := service.Migrate(ctx, from, to, false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Media, "records without a stored file are skipped")
+		assert.Equal(t, 3, result.Files)
+		assert.Empty(t, result.Failed)
+		assert.Equal(t, "https://cdn.example.com/uploads/2026/01/02/abc/original.jpg", media.OriginalURL)
+		assert.Equal(t, "https://cdn.example.com/uploads/2026/01/02/abc/small.webp", media.Thumbnails["small_webp"])
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Dry run copies nothing", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		from := new(MockReadableStorageService)
+		to := new(MockStorageService)
+		service := NewStorageMigrationService(repo, zaptest.NewLogger(t))
+
+		repo.On("List", ctx, mock.Anything, mock.Anything).Return([]*models.Media{newImage()}, int64(1), nil).Once()
+
+		result, err := service.Migrate(ctx, from, to, true)
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Files)
+		from.AssertNotCalled(t, "Download", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed copies are reported and leave the record alone", func(t *testing.T) {
+		repo := new(MockMediaRepository)
+		from := new(MockReadableStorageService)
+		to := new(MockStorageService)
+		service := NewStorageMigrationService(repo, zaptest.NewLogger(t))
+
+		media := newImage()
+		repo.On("List", ctx, mock.Anything, mock.Anything).Return([]*models.Media{media}, int64(1), nil).Once()
+		from.On("Download", ctx, mock.AnythingOfType("string")).Return(nil, errors.New("no such file"))
+
+		result, err := service.Migrate(ctx, from, to, false)
+		require.NoError(t, err)
+		assert.Equal(t, []models.ID{media.ID}, result.Failed)
+		assert.Equal(t, 0, result.Media)
+		assert.Contains(t, media.OriginalURL, "localhost")
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
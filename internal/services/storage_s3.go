@@ -0,0 +1,293 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const (
+	// s3DefaultEndpoint is used when no endpoint is configured, i.e. for AWS S3
+	s3DefaultEndpoint = "s3.amazonaws.com"
+	// s3StreamPartSize is the part size of server-side streamed uploads; larger
+	// or unknown-size streams are sent as multipart uploads
+	s3StreamPartSize = 16 * 1024 * 1024
+)
+
+// S3StorageService stores files in an S3-compatible bucket such as AWS S3,
+// MinIO or DigitalOcean Spaces
+type S3StorageService struct {
+	client  *minio.Client
+	bucket  string
+	baseURL string
+}
+
+// Ensure S3StorageService supports direct multipart uploads
+var _ MultipartStorage = (*S3StorageService)(nil)
+
+// Ensure S3StorageService can read files back for background processing
+var _ ObjectReader = (*S3StorageService)(nil)
+
+// Ensure S3StorageService files can be served by the API, e.g. from a private bucket
+var _ ObjectOpener = (*S3StorageService)(nil)
+
+// Ensure S3StorageService is probed by readiness checks
+var _ StoragePinger = (*S3StorageService)(nil)
+
+// NewS3StorageService creates a storage service for the configured bucket
+func NewS3StorageService(cfg *StorageConfig) (*S3StorageService, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("storage bucket is required")
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = s3DefaultEndpoint
+	}
+	// Accept endpoints written as URLs; the scheme comes from UseSSL
+	endpoint = strings.TrimPrefix(strings.TrimPrefix(endpoint, "https://"), "http://")
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	lookup := minio.BucketLookupAuto
+	if cfg.ForcePathStyle {
+		lookup = minio.BucketLookupPath
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure:       cfg.UseSSL,
+		Region:       cfg.Region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage client: %w", err)
+	}
+
+	return &S3StorageService{
+		client:  client,
+		bucket:  cfg.Bucket,
+		baseURL: s3BaseURL(cfg, endpoint),
+	}, nil
+}
+
+// s3BaseURL returns the public URL prefix of objects in the bucket, preferring
+// the CDN in front of it
+func s3BaseURL(cfg *StorageConfig, endpoint string) string {
+	if cfg.CDNURL != "" {
+		return strings.TrimSuffix(cfg.CDNURL, "/")
+	}
+
+	scheme := "http"
+	if cfg.UseSSL {
+		scheme = "https"
+	}
+	if endpoint == s3DefaultEndpoint && cfg.Region != "" {
+		endpoint = fmt.Sprintf("s3.%s.amazonaws.com", cfg.Region)
+	}
+	if cfg.ForcePathStyle {
+		return fmt.Sprintf("%s://%s/%s", scheme, endpoint, cfg.Bucket)
+	}
+	return fmt.Sprintf("%s://%s.%s", scheme, cfg.Bucket, endpoint)
+}
+
+// BaseURL returns the public URL prefix of stored objects
+func (s *S3StorageService) BaseURL() string {
+	return s.baseURL
+}
+
+// Upload stores a file in the bucket
+func (s *S3StorageService) Upload(ctx context.Context, key string, data []byte, contentType string, metadata map[string]string) (string, error) {
+	return s.UploadStream(ctx, key, bytes.NewReader(data), contentType, int64(len(data)), metadata)
+}
+
+// UploadStream stores a file stream in the bucket. A negative size means the
+// size is unknown.
+func (s *S3StorageService) UploadStream(ctx context.Context, key string, reader io.Reader, contentType string, size int64, metadata map[string]string) (string, error) {
+	_, err := s.client.PutObject(ctx, s.bucket, key, reader, size, minio.PutObjectOptions{
+		ContentType:  contentType,
+		UserMetadata: metadata,
+		PartSize:     s3StreamPartSize,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload object: %w", err)
+	}
+	return s.objectURL(key), nil
+}
+
+// Delete removes a file from the bucket
+func (s *S3StorageService) Delete(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+// GetPresignedURL generates a time-limited download URL
+func (s *S3StorageService) GetPresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	u, err := s.client.PresignedGetObject(ctx, s.bucket, key, expiry, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign download: %w", err)
+	}
+	return u.String(), nil
+}
+
+// GeneratePresignedUploadURL generates a browser form upload policy that only
+// accepts the given key, content type and at most size bytes
+func (s *S3StorageService) GeneratePresignedUploadURL(ctx context.Context, key string, contentType string, size int64, expiry time.Duration) (*PresignedUploadInfo, error) {
+	expiresAt := time.Now().Add(expiry)
+
+	policy := minio.NewPostPolicy()
+	if err := policy.SetBucket(s.bucket); err != nil {
+		return nil, err
+	}
+	if err := policy.SetKey(key); err != nil {
+		return nil, err
+	}
+	if err := policy.SetExpires(expiresAt); err != nil {
+		return nil, err
+	}
+	if err := policy.SetContentType(contentType); err != nil {
+		return nil, err
+	}
+	if err := policy.SetContentLengthRange(1, size); err != nil {
+		return nil, err
+	}
+
+	u, fields, err := s.client.PresignedPostPolicy(ctx, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign upload: %w", err)
+	}
+
+	return &PresignedUploadInfo{
+		URL:       u.String(),
+		Method:    http.MethodPost,
+		Fields:    fields,
+		Key:       key,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Exists checks if a file exists in the bucket
+func (s *S3StorageService) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := s.client.StatObject(ctx, s.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to stat object: %w", err)
+	}
+	return true, nil
+}
+
+// Ping checks that the bucket is reachable and exists
+func (s *S3StorageService) Ping(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil {
+		return fmt.Errorf("failed to reach storage bucket: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("storage bucket %s does not exist", s.bucket)
+	}
+	return nil
+}
+
+// Download reads a file from the bucket
+func (s *S3StorageService) Download(ctx context.Context, key string) ([]byte, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download object: %w", err)
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download object: %w", err)
+	}
+	return data, nil
+}
+
+// Open opens a file in the bucket. Reads are lazy: seeking before reading makes
+// the first read a ranged GET, so range requests only transfer their range.
+func (s *S3StorageService) Open(ctx context.Context, key string) (*StoredObject, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open object: %w", err)
+	}
+
+	info, err := object.Stat()
+	if err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).StatusCode == http.StatusNotFound {
+			return nil, ErrStoredFileNotFound
+		}
+		return nil, fmt.Errorf("failed to stat object: %w", err)
+	}
+
+	return &StoredObject{
+		Content:     object,
+		Size:        info.Size,
+		ContentType: info.ContentType,
+		ETag:        `"` + strings.Trim(info.ETag, `"`) + `"`,
+		ModTime:     info.LastModified,
+	}, nil
+}
+
+// CreateMultipartUpload starts a multipart upload and returns its upload ID
+func (s *S3StorageService) CreateMultipartUpload(ctx context.Context, key string, contentType string) (string, error) {
+	core := minio.Core{Client: s.client}
+	uploadID, err := core.NewMultipartUpload(ctx, s.bucket, key, minio.PutObjectOptions{ContentType: contentType})
+	if err != nil {
+		return "", fmt.Errorf("failed to create multipart upload: %w", err)
+	}
+	return uploadID, nil
+}
+
+// PresignUploadPart generates a URL the client PUTs one part to. The bucket's
+// CORS rules must expose the ETag header so browsers can complete the upload.
+func (s *S3StorageService) PresignUploadPart(ctx context.Context, key, uploadID string, partNumber int, expiry time.Duration) (string, error) {
+	params := url.Values{}
+	params.Set("partNumber", strconv.Itoa(partNumber))
+	params.Set("uploadId", uploadID)
+
+	u, err := s.client.Presign(ctx, http.MethodPut, s.bucket, key, expiry, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign upload part: %w", err)
+	}
+	return u.String(), nil
+}
+
+// CompleteMultipartUpload assembles the uploaded parts into the final object
+func (s *S3StorageService) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []CompletedPart) error {
+	completed := make([]minio.CompletePart, len(parts))
+	for i, part := range parts {
+		completed[i] = minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag}
+	}
+
+	core := minio.Core{Client: s.client}
+	if _, err := core.CompleteMultipartUpload(ctx, s.bucket, key, uploadID, completed, minio.PutObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	return nil
+}
+
+// AbortMultipartUpload discards a multipart upload and its uploaded parts
+func (s *S3StorageService) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	core := minio.Core{Client: s.client}
+	if err := core.AbortMultipartUpload(ctx, s.bucket, key, uploadID); err != nil {
+		return fmt.Errorf("failed to abort multipart upload: %w", err)
+	}
+	return nil
+}
+
+func (s *S3StorageService) objectURL(key string) string {
+	return s.baseURL + "/" + key
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewS3StorageService(t *testing.T) {
+	_, err := NewS3StorageService(&StorageConfig{Region: "us-east-1"})
+	assert.Error(t, err)
+
+	tests := []struct {
+		name    string
+		config  StorageConfig
+		baseURL string
+	}{
+		{
+			name:    "AWS S3",
+			config:  StorageConfig{Bucket: "photos", Region: "eu-west-1", UseSSL: true},
+			baseURL: "https://photos.s3.eu-west-1.amazonaws.com",
+		},
+		{
+			name:    "MinIO",
+			config:  StorageConfig{Bucket: "photos", Region: "us-east-1", Endpoint: "http://localhost:9000/", ForcePathStyle: true},
+			baseURL: "http://localhost:9000/photos",
+		},
+		{
+			name:    "DigitalOcean Spaces",
+			config:  StorageConfig{Bucket: "photos", Region: "nyc3", Endpoint: "nyc3.digitaloceanspaces.com", UseSSL: true},
+			baseURL: "https://photos.nyc3.digitaloceanspaces.com",
+		},
+		{
+			name:    "CDN",
+			config:  StorageConfig{Bucket: "photos", Region: "us-east-1", UseSSL: true, CDNURL: "https://cdn.example.com/"},
+			baseURL: "https://cdn.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, err := NewS3StorageService(&tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.baseURL, storage.BaseURL())
+		})
+	}
+}
+
+func TestS3StorageService_Presign(t *testing.T) {
+	ctx := context.Background()
+	storage, err := NewS3StorageService(&StorageConfig{
+		Bucket:         "photos",
+		Region:         "us-east-1",
+		Endpoint:       "localhost:9000",
+		AccessKey:      "access",
+		SecretKey:      "secret",
+		ForcePathStyle: true,
+	})
+	require.NoError(t, err)
+
+	t.Run("upload form policy", func(t *testing.T) {
+		info, err := storage.GeneratePresignedUploadURL(ctx, "uploads/a.jpg", "image/jpeg", 1024, 15*time.Minute)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.MethodPost, info.Method)
+		assert.Equal(t, "http://localhost:9000/photos/", info.URL)
+		assert.Equal(t, "uploads/a.jpg", info.Fields["key"])
+		assert.Equal(t, "image/jpeg", info.Fields["Content-Type"])
+		assert.NotEmpty(t, info.Fields["policy"])
+		assert.NotEmpty(t, info.Fields["x-amz-signature"])
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), info.ExpiresAt, time.Minute)
+	})
+
+	t.Run("upload part URL", func(t *testing.T) {
+		partURL, err := storage.PresignUploadPart(ctx, "uploads/a.jpg", "upload-1", 3, time.Hour)
+		require.NoError(t, err)
+
+		u, err := url.Parse(partURL)
+		require.NoError(t, err)
+		assert.Equal(t, "/photos/uploads/a.jpg", u.Path)
+		assert.Equal(t, "3", u.Query().Get("partNumber"))
+		assert.Equal(t, "upload-1", u.Query().Get("uploadId"))
+		assert.NotEmpty(t, u.Query().Get("X-Amz-Signature"))
+	})
+}
+
+func TestS3StorageService_Exists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/present.jpg") {
+			w.Header().Set("Content-Length", "3")
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+			w.Header().Set("ETag", `"abc"`)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	storage, err := NewS3StorageService(&StorageConfig{
+		Bucket:         "photos",
+		Region:         "us-east-1",
+		Endpoint:       server.URL,
+		AccessKey:      "access",
+		SecretKey:      "secret",
+		ForcePathStyle: true,
+	})
+	require.NoError(t, err)
+
+	exists, err := storage.Exists(context.Background(), "uploads/present.jpg")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = storage.Exists(context.Background(), "uploads/missing.jpg")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestS3StorageService_Ping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/photos") {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	newStorage := func(bucket string) *S3StorageService {
+		storage, err := NewS3StorageService(&StorageConfig{
+			Bucket:         bucket,
+			Region:         "us-east-1",
+			Endpoint:       server.URL,
+			AccessKey:      "access",
+			SecretKey:      "secret",
+			ForcePathStyle: true,
+		})
+		require.NoError(t, err)
+		return storage
+	}
+
+	assert.NoError(t, newStorage("photos").Ping(context.Background()))
+	assert.ErrorContains(t, newStorage("missing").Ping(context.Background()), "does not exist")
+}
+
+func TestS3StorageService_Open(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/present.mp4") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "video/mp4")
+		w.Header().Set("Last-Modified", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+		w.Header().Set("ETag", `"abc"`)
+		http.ServeContent(w, r, "present.mp4", time.Time{}, strings.NewReader("0123456789"))
+	}))
+	defer server.Close()
+
+	storage, err := NewS3StorageService(&StorageConfig{
+		Bucket:         "photos",
+		Region:         "us-east-1",
+		Endpoint:       server.URL,
+		AccessKey:      "access",
+		SecretKey:      "secret",
+		ForcePathStyle: true,
+	})
+	require.NoError(t, err)
+
+	object, err := storage.Open(context.Background(), "uploads/present.mp4")
+	require.NoError(t, err)
+	defer object.Content.Close()
+	assert.Equal(t, int64(10), object.Size)
+	assert.Equal(t, "video/mp4", object.ContentType)
+	assert.Equal(t, `"abc"`, object.ETag)
+
+	_, err = object.Content.Seek(4, io.SeekStart)
+	require.NoError(t, err)
+	data, err := io.ReadAll(object.Content)
+	require.NoError(t, err)
+	assert.Equal(t, "456789", string(data))
+
+	_, err = storage.Open(context.Background(), "uploads/missing.mp4")
+	assert.ErrorIs(t, err, ErrStoredFileNotFound)
+}
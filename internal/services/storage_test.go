@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/utils"
+)
+
+func TestLocalStorageService(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storage := NewLocalStorageService(dir, "http://localhost:8080/uploads/")
+
+	fileURL, err := storage.Upload(ctx, "uploads/2024/06/a/original.jpg", []byte("jpeg"), "image/jpeg", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/uploads/uploads/2024/06/a/original.jpg", fileURL)
+
+	exists, err := storage.Exists(ctx, "uploads/2024/06/a/original.jpg")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	data, err := storage.Download(ctx, "uploads/2024/06/a/original.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", string(data))
+
+	object, err := storage.Open(ctx, "uploads/2024/06/a/original.jpg")
+	require.NoError(t, err)
+	content, err := io.ReadAll(object.Content)
+	require.NoError(t, err)
+	require.NoError(t, object.Content.Close())
+	assert.Equal(t, "jpeg", string(content))
+	assert.Equal(t, int64(4), object.Size)
+	assert.Equal(t, "image/jpeg", object.ContentType)
+	assert.NotEmpty(t, object.ETag)
+
+	require.NoError(t, storage.Delete(ctx, "uploads/2024/06/a/original.jpg"))
+	exists, err = storage.Exists(ctx, "uploads/2024/06/a/original.jpg")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.NoError(t, storage.Delete(ctx, "uploads/2024/06/a/original.jpg"), "deleting a missing file is not an error")
+
+	_, err = storage.Open(ctx, "uploads/2024/06/a/original.jpg")
+	assert.ErrorIs(t, err, ErrStoredFileNotFound)
+	_, err = storage.Open(ctx, "uploads/2024")
+	assert.ErrorIs(t, err, ErrStoredFileNotFound, "directories are not files")
+}
+
+func TestLocalStorageService_KeysStayInBasePath(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secret.txt"), []byte("secret"), 0o600))
+
+	storage := NewLocalStorageService(filepath.Join(root, "uploads"), "http://localhost:8080/uploads")
+	_, err := storage.Open(ctx, "../secret.txt")
+	assert.ErrorIs(t, err, ErrStoredFileNotFound)
+
+	_, err = storage.Upload(ctx, "../../escaped.txt", []byte("x"), "text/plain", nil)
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(root, "uploads", "escaped.txt"))
+	assert.NoError(t, err)
+}
+
+func TestLocalStorageService_GetPresignedURL(t *testing.T) {
+	ctx := context.Background()
+	storage := NewLocalStorageService(t.TempDir(), "http://localhost:8080/uploads")
+
+	plain, err := storage.GetPresignedURL(ctx, "exports/a.zip", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/uploads/exports/a.zip", plain)
+
+	storage.EnableSignedURLs("secret")
+	signed, err := storage.GetPresignedURL(ctx, "exports/a.zip", time.Hour)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(signed, plain+"?"))
+
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.NoError(t, utils.VerifyFileSignature("secret", "exports/a.zip",
+		u.Query().Get(utils.FileURLExpiresParam), u.Query().Get(utils.FileURLSignatureParam)))
+}
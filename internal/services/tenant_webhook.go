@@ -0,0 +1,420 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"net/http"
+	"sync"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+var (
+	ErrTenantWebhookNotFound = errs.NotFound("tenant webhook not found")
+	ErrInvalidLifecycleEvent = errors.New("unsupported lifecycle event type")
+	ErrNotTenantAdmin        = errs.Forbidden("tenant admin access required")
+)
+
+const (
+	tenantWebhookBatchSize = 100
+	tenantWebhookReplayMax = 500
+)
+
+// LifecycleEventPublisher records platform lifecycle events for delivery to tenants
+type LifecycleEventPublisher interface {
+	Publish(ctx context.Context, event *models.LifecycleEvent) error
+}
+
+// TenantWebhookService manages tenant lifecycle webhooks and delivers events from the outbox
+type TenantWebhookService struct {
+	repo       repository.TenantWebhookRepository
+	userRepo   repository.UserRepository
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewTenantWebhookService creates a new tenant webhook service
+func NewTenantWebhookService(repo repository.TenantWebhookRepository, userRepo repository.UserRepository, logger *zap.Logger) *TenantWebhookService {
+	return &TenantWebhookService{
+		repo:       repo,
+		userRepo:   userRepo,
+		httpClient: newWebhookClient(),
+		logger:     logger,
+	}
+}
+
+// CreateTenantWebhookRequest represents a new tenant webhook subscription
+type CreateTenantWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url"`
+	Events []string `json:"events,omitempty"`
+}
+
+// UpdateTenantWebhookRequest represents changes to a tenant webhook
+type UpdateTenantWebhookRequest struct {
+	URL    *string   `json:"url,omitempty" validate:"omitempty,url"`
+	Events *[]string `json:"events,omitempty"`
+	Active *bool     `json:"active,omitempty"`
+}
+
+// TenantWebhookReplayResult summarizes a replay of outbox events to a webhook
+type TenantWebhookReplayResult struct {
+	Events    int `json:"events"`
+	Delivered int `json:"delivered"`
+	Failed    int `json:"failed"`
+}
+
+// tenantWebhookPayload is the body posted to tenant endpoints
+type tenantWebhookPayload struct {
+	*models.LifecycleEvent
+	Replay bool `json:"replay"`
+}
+
+// Publish appends an event to the outbox. Events without a tenant are ignored.
+func (s *TenantWebhookService) Publish(ctx context.Context, event *models.LifecycleEvent) error {
+	if event.TenantID == "" {
+		return nil
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	if err := s.repo.AppendEvent(ctx, event); err != nil {
+		return fmt.Errorf("failed to publish lifecycle event: %w", err)
+	}
+	return nil
+}
+
+// CreateWebhook registers a tenant webhook. The tenant signing secret is returned
+// only when this call created it; otherwise it is empty.
+func (s *TenantWebhookService) CreateWebhook(ctx context.Context, userID models.ID, req CreateTenantWebhookRequest) (*models.TenantWebhook, string, error) {
+	tenantID, err := s.tenantOf(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := validateWebhookURL(ctx, req.URL); err != nil {
+		return nil, "", err
+	}
+	if err := validateLifecycleEvents(req.Events); err != nil {
+		return nil, "", err
+	}
+
+	secret := ""
+	if _, err := s.repo.GetSigningKey(ctx, tenantID); err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			return nil, "", fmt.Errorf("failed to get signing key: %w", err)
+		}
+		if secret, err = s.saveNewSigningKey(ctx, tenantID); err != nil {
+			return nil, "", err
+		}
+	}
+
+	webhook := &models.TenantWebhook{
+		ID:        models.NewID(),
+		TenantID:  tenantID,
+		URL:       req.URL,
+		Events:    req.Events,
+		Active:    true,
+		CreatedBy: userID,
+	}
+	if err := s.repo.CreateWebhook(ctx, webhook); err != nil {
+		return nil, "", fmt.Errorf("failed to create tenant webhook: %w", err)
+	}
+
+	return webhook, secret, nil
+}
+
+// ListWebhooks lists the webhooks of the user's tenant
+func (s *TenantWebhookService) ListWebhooks(ctx context.Context, userID models.ID) ([]*models.TenantWebhook, error) {
+	tenantID, err := s.tenantOf(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	webhooks, err := s.repo.ListWebhooks(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenant webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// UpdateWebhook applies changes to a tenant webhook
+func (s *TenantWebhookService) UpdateWebhook(ctx context.Context, id, userID models.ID, req UpdateTenantWebhookRequest) (*models.TenantWebhook, error) {
+	webhook, err := s.getTenantWebhook(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := validateWebhookURL(ctx, *req.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = *req.URL
+	}
+	if req.Events != nil {
+		if err := validateLifecycleEvents(*req.Events); err != nil {
+			return nil, err
+		}
+		webhook.Events = *req.Events
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+
+	if err := s.repo.UpdateWebhook(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to update tenant webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook removes a tenant webhook
+func (s *TenantWebhookService) DeleteWebhook(ctx context.Context, id, userID models.ID) error {
+	if _, err := s.getTenantWebhook(ctx, id, userID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteWebhook(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete tenant webhook: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries lists delivery attempts for a tenant webhook
+func (s *TenantWebhookService) ListDeliveries(ctx context.Context, id, userID models.ID, page, pageSize int) ([]*models.TenantWebhookDelivery, int64, error) {
+	if _, err := s.getTenantWebhook(ctx, id, userID); err != nil {
+		return nil, 0, err
+	}
+	deliveries, total, err := s.repo.ListDeliveries(ctx, id, page, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list tenant webhook deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
+
+// RotateSecret replaces the tenant signing secret and returns the new one
+func (s *TenantWebhookService) RotateSecret(ctx context.Context, userID models.ID) (string, error) {
+	tenantID, err := s.tenantOf(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return s.saveNewSigningKey(ctx, tenantID)
+}
+
+// Replay redelivers the tenant's outbox events since the given time to one webhook
+func (s *TenantWebhookService) Replay(ctx context.Context, id, userID models.ID, since time.Time) (*TenantWebhookReplayResult, error) {
+	webhook, err := s.getTenantWebhook(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := s.signingSecret(ctx, webhook.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.repo.ListEventsSince(ctx, webhook.TenantID, since, tenantWebhookReplayMax)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lifecycle events: %w", err)
+	}
+
+	result := &TenantWebhookReplayResult{}
+	for _, event := range events {
+		if !webhook.Subscribes(event.Type) {
+			continue
+		}
+		result.Events++
+		if s.deliver(ctx, webhook, secret, event, true).Success {
+			result.Delivered++
+		} else {
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// DispatchPending delivers undispatched outbox events to subscribed webhooks and returns
+// the number of events processed. Failed deliveries are logged and can be replayed.
+func (s *TenantWebhookService) DispatchPending(ctx context.Context) (int, error) {
+	events, err := s.repo.ListPendingEvents(ctx, tenantWebhookBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pending lifecycle events: %w", err)
+	}
+
+	for _, event := range events {
+		webhooks, err := s.repo.ListWebhooks(ctx, event.TenantID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list tenant webhooks: %w", err)
+		}
+
+		var secret string
+		for _, webhook := range webhooks {
+			if !webhook.Active || !webhook.Subscribes(event.Type) {
+				continue
+			}
+			if secret == "" {
+				if secret, err = s.signingSecret(ctx, event.TenantID); err != nil {
+					return 0, err
+				}
+			}
+			s.deliver(ctx, webhook, secret, event, false)
+		}
+
+		if err := s.repo.MarkEventDispatched(ctx, event.ID, time.Now()); err != nil {
+			return 0, fmt.Errorf("failed to mark lifecycle event dispatched: %w", err)
+		}
+	}
+	return len(events), nil
+}
+
+func (s *TenantWebhookService) deliver(ctx context.Context, webhook *models.TenantWebhook, secret string, event *models.LifecycleEvent, replay bool) *models.TenantWebhookDelivery {
+	delivery := &models.TenantWebhookDelivery{
+		ID:          models.NewID(),
+		WebhookID:   webhook.ID,
+		EventID:     event.ID,
+		EventType:   event.Type,
+		Replay:      replay,
+		AttemptedAt: time.Now(),
+	}
+
+	payload := tenantWebhookPayload{LifecycleEvent: event, Replay: replay}
+	status, err := postSignedJSON(ctx, s.httpClient, webhook.URL, secret, string(event.Type), payload)
+	delivery.StatusCode = status
+	if err != nil {
+		delivery.Error = err.Error()
+		s.logger.Warn("Tenant webhook delivery failed",
+			zap.String("webhook_id", webhook.ID.String()),
+			zap.String("event_id", event.ID.String()),
+			zap.Error(err))
+	} else {
+		delivery.Success = true
+	}
+
+	if err := s.repo.CreateDelivery(ctx, delivery); err != nil {
+		s.logger.Error("Failed to record tenant webhook delivery", zap.Error(err))
+	}
+	return delivery
+}
+
+func (s *TenantWebhookService) signingSecret(ctx context.Context, tenantID string) (string, error) {
+	key, err := s.repo.GetSigningKey(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return s.saveNewSigningKey(ctx, tenantID)
+		}
+		return "", fmt.Errorf("failed to get signing key: %w", err)
+	}
+	return key.Secret, nil
+}
+
+func (s *TenantWebhookService) saveNewSigningKey(ctx context.Context, tenantID string) (string, error) {
+	secret, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", err
+	}
+	key := &models.TenantSigningKey{
+		TenantID:  tenantID,
+		Secret:    secret,
+		RotatedAt: time.Now(),
+	}
+	if err := s.repo.SaveSigningKey(ctx, key); err != nil {
+		return "", fmt.Errorf("failed to save signing key: %w", err)
+	}
+	return secret, nil
+}
+
+// tenantOf returns the tenant the user administers
+func (s *TenantWebhookService) tenantOf(ctx context.Context, userID models.ID) (string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", ErrUserNotFound
+		}
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsTenantAdmin() {
+		return "", ErrNotTenantAdmin
+	}
+	return user.TenantID, nil
+}
+
+func (s *TenantWebhookService) getTenantWebhook(ctx context.Context, id, userID models.ID) (*models.TenantWebhook, error) {
+	tenantID, err := s.tenantOf(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	webhook, err := s.repo.GetWebhook(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTenantWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get tenant webhook: %w", err)
+	}
+	if webhook.TenantID != tenantID {
+		return nil, ErrTenantWebhookNotFound
+	}
+	return webhook, nil
+}
+
+func validateLifecycleEvents(events []string) error {
+	for _, event := range events {
+		if !models.IsValidLifecycleEventType(event) {
+			return ErrInvalidLifecycleEvent
+		}
+	}
+	return nil
+}
+
+// TenantWebhookDispatcher periodically drains the lifecycle event outbox
+type TenantWebhookDispatcher struct {
+	service  *TenantWebhookService
+	interval time.Duration
+	logger   *zap.Logger
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewTenantWebhookDispatcher creates a dispatcher that drains the outbox every interval
+func NewTenantWebhookDispatcher(service *TenantWebhookService, interval time.Duration, logger *zap.Logger) *TenantWebhookDispatcher {
+	return &TenantWebhookDispatcher{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start runs the dispatcher loop in the background
+func (d *TenantWebhookDispatcher) Start(ctx context.Context) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-d.stop:
+				return
+			case <-ticker.C:
+				processed, err := d.service.DispatchPending(ctx)
+				if err != nil {
+					d.logger.Error("Tenant webhook dispatch failed", zap.Error(err))
+					continue
+				}
+				if processed > 0 {
+					d.logger.Info("Lifecycle events dispatched", zap.Int("count", processed))
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the dispatcher loop to exit and waits for it
+func (d *TenantWebhookDispatcher) Stop() {
+	close(d.stop)
+	d.wg.Wait()
+}
@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// MockTenantWebhookRepository is an in-memory tenant webhook repository
+type MockTenantWebhookRepository struct {
+	webhooks   map[models.ID]*models.TenantWebhook
+	keys       map[string]*models.TenantSigningKey
+	events     []*models.LifecycleEvent
+	deliveries []*models.TenantWebhookDelivery
+}
+
+func NewMockTenantWebhookRepository() *MockTenantWebhookRepository {
+	return &MockTenantWebhookRepository{
+		webhooks: make(map[models.ID]*models.TenantWebhook),
+		keys:     make(map[string]*models.TenantSigningKey),
+	}
+}
+
+func (m *MockTenantWebhookRepository) CreateWebhook(ctx context.Context, webhook *models.TenantWebhook) error {
+	m.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (m *MockTenantWebhookRepository) GetWebhook(ctx context.Context, id models.ID) (*models.TenantWebhook, error) {
+	webhook, exists := m.webhooks[id]
+	if !exists {
+		return nil, repository.ErrNotFound
+	}
+	return webhook, nil
+}
+
+func (m *MockTenantWebhookRepository) ListWebhooks(ctx context.Context, tenantID string) ([]*models.TenantWebhook, error) {
+	var webhooks []*models.TenantWebhook
+	for _, webhook := range m.webhooks {
+		if webhook.TenantID == tenantID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (m *MockTenantWebhookRepository) UpdateWebhook(ctx context.Context, webhook *models.TenantWebhook) error {
+	if _, exists := m.webhooks[webhook.ID]; !exists {
+		return repository.ErrNotFound
+	}
+	m.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (m *MockTenantWebhookRepository) DeleteWebhook(ctx context.Context, id models.ID) error {
+	if _, exists := m.webhooks[id]; !exists {
+		return repository.ErrNotFound
+	}
+	delete(m.webhooks, id)
+	return nil
+}
+
+func (m *MockTenantWebhookRepository) GetSigningKey(ctx context.Context, tenantID string) (*models.TenantSigningKey, error) {
+	key, exists := m.keys[tenantID]
+	if !exists {
+		return nil, repository.ErrNotFound
+	}
+	return key, nil
+}
+
+func (m *MockTenantWebhookRepository) SaveSigningKey(ctx context.Context, key *models.TenantSigningKey) error {
+	m.keys[key.TenantID] = key
+	return nil
+}
+
+func (m *MockTenantWebhookRepository) AppendEvent(ctx context.Context, event *models.LifecycleEvent) error {
+	if event.ID.IsZero() {
+		event.ID = models.NewID()
+	}
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *MockTenantWebhookRepository) ListPendingEvents(ctx context.Context, limit int) ([]*models.LifecycleEvent, error) {
+	var events []*models.LifecycleEvent
+	for _, event := range m.events {
+		if !event.Dispatched && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (m *MockTenantWebhookRepository) MarkEventDispatched(ctx context.Context, id models.ID, dispatchedAt time.Time) error {
+	for _, event := range m.events {
+		if event.ID == id {
+			event.Dispatched = true
+			event.DispatchedAt = &dispatchedAt
+		}
+	}
+	return nil
+}
+
+func (m *MockTenantWebhookRepository) ListEventsSince(ctx context.Context, tenantID string, since time.Time, limit int) ([]*models.LifecycleEvent, error) {
+	var events []*models.LifecycleEvent
+	for _, event := range m.events {
+		if event.TenantID == tenantID && !event.OccurredAt.Before(since) && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (m *MockTenantWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.TenantWebhookDelivery) error {
+	m.deliveries = append(m.deliveries, delivery)
+	return nil
+}
+
+func (m *MockTenantWebhookRepository) ListDeliveries(ctx context.Context, webhookID models.ID, page, pageSize int) ([]*models.TenantWebhookDelivery, int64, error) {
+	var deliveries []*models.TenantWebhookDelivery
+	for _, delivery := range m.deliveries {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, int64(len(deliveries)), nil
+}
+
+// tenantWebhookReceiver records signed lifecycle events posted to it
+type tenantWebhookReceiver struct {
+	mu     sync.Mutex
+	secret func() string
+	events []map[string]interface{}
+}
+
+func (r *tenantWebhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	timestamp, _ := strconv.ParseInt(req.Header.Get(utils.SignatureTimestampHeader), 10, 64)
+	if !utils.VerifyPayloadSignature(r.secret(), timestamp, body, req.Header.Get(utils.SignatureHeader)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var event map[string]interface{}
+	if err := json.Unmarshal(body, &event); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.mu.Lock()
+	r.events = append(r.events, event)
+	r.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func setupTenantWebhookService(t *testing.T) (*TenantWebhookService, *MockTenantWebhookRepository, *models.User) {
+	repo := NewMockTenantWebhookRepository()
+	userRepo := &MockUserRepository{}
+	stubWebhookNetwork(t)
+
+	admin := &models.User{ID: models.NewID(), Role: models.RoleOrgAdmin, TenantID: "acme"}
+	userRepo.On("GetByID", mock.Anything, admin.ID).Return(admin, nil)
+
+	return NewTenantWebhookService(repo, userRepo, zaptest.NewLogger(t)), repo, admin
+}
+
+func TestTenantWebhookService_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - creates signing key once", func(t *testing.T) {
+		service, repo, admin := setupTenantWebhookService(t)
+
+		webhook, secret, err := service.CreateWebhook(ctx, admin.ID, CreateTenantWebhookRequest{
+			URL:    "https://tenant.example.com/hooks",
+			Events: []string{"wedding.published"},
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, secret)
+		assert.Equal(t, "acme", webhook.TenantID)
+		assert.True(t, webhook.Active)
+		assert.Equal(t, secret, repo.keys["acme"].Secret)
+
+		_, again, err := service.CreateWebhook(ctx, admin.ID, CreateTenantWebhookRequest{URL: "https://tenant.example.com/other"})
+		require.NoError(t, err)
+		assert.Empty(t, again)
+	})
+
+	t.Run("Error - unknown event", func(t *testing.T) {
+		service, _, admin := setupTenantWebhookService(t)
+
+		_, _, err := service.CreateWebhook(ctx, admin.ID, CreateTenantWebhookRequest{
+			URL:    "https://tenant.example.com/hooks",
+			Events: []string{"wedding.exploded"},
+		})
+		assert.ErrorIs(t, err, ErrInvalidLifecycleEvent)
+	})
+
+	t.Run("Error - not a tenant admin", func(t *testing.T) {
+		service, _, _ := setupTenantWebhookService(t)
+		userRepo := service.userRepo.(*MockUserRepository)
+		owner := &models.User{ID: models.NewID(), Role: "user", TenantID: "acme"}
+		userRepo.On("GetByID", mock.Anything, owner.ID).Return(owner, nil)
+
+		_, _, err := service.CreateWebhook(ctx, owner.ID, CreateTenantWebhookRequest{URL: "https://tenant.example.com/hooks"})
+		assert.ErrorIs(t, err, ErrNotTenantAdmin)
+	})
+}
+
+func TestTenantWebhookService_DispatchAndReplay(t *testing.T) {
+	ctx := context.Background()
+	service, repo, admin := setupTenantWebhookService(t)
+
+	receiver := &tenantWebhookReceiver{secret: func() string { return repo.keys["acme"].Secret }}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	webhook, _, err := service.CreateWebhook(ctx, admin.ID, CreateTenantWebhookRequest{
+		URL:    server.URL,
+		Events: []string{"wedding.created", "wedding.published"},
+	})
+	require.NoError(t, err)
+
+	since := time.Now().Add(-time.Minute)
+	require.NoError(t, service.Publish(ctx, &models.LifecycleEvent{TenantID: "acme", Type: models.LifecycleWeddingCreated, SubjectID: models.NewID()}))
+	require.NoError(t, service.Publish(ctx, &models.LifecycleEvent{TenantID: "acme", Type: models.LifecycleUserRegistered, SubjectID: models.NewID()}))
+	require.NoError(t, service.Publish(ctx, &models.LifecycleEvent{TenantID: "other", Type: models.LifecycleWeddingCreated, SubjectID: models.NewID()}))
+	require.NoError(t, service.Publish(ctx, &models.LifecycleEvent{Type: models.LifecycleWeddingCreated}))
+	assert.Len(t, repo.events, 3, "events without a tenant are not stored")
+
+	processed, err := service.DispatchPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, processed)
+
+	// Only the subscribed event for this tenant is delivered
+	require.Len(t, receiver.events, 1)
+	assert.Equal(t, "wedding.created", receiver.events[0]["type"])
+	assert.Equal(t, false, receiver.events[0]["replay"])
+
+	pending, err := repo.ListPendingEvents(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// Replaying after a secret rotation re-signs with the new secret
+	_, err = service.RotateSecret(ctx, admin.ID)
+	require.NoError(t, err)
+
+	result, err := service.Replay(ctx, webhook.ID, admin.ID, since)
+	require.NoError(t, err)
+	assert.Equal(t, &TenantWebhookReplayResult{Events: 1, Delivered: 1}, result)
+	require.Len(t, receiver.events, 2)
+	assert.Equal(t, true, receiver.events[1]["replay"])
+
+	deliveries, total, err := service.ListDeliveries(ctx, webhook.ID, admin.ID, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.True(t, deliveries[1].Replay)
+}
+
+func TestTenantWebhookService_ForeignTenantWebhook(t *testing.T) {
+	ctx := context.Background()
+	service, repo, admin := setupTenantWebhookService(t)
+
+	foreign := &models.TenantWebhook{ID: models.NewID(), TenantID: "globex", URL: "https://globex.example.com", Active: true}
+	repo.webhooks[foreign.ID] = foreign
+
+	err := service.DeleteWebhook(ctx, foreign.ID, admin.ID)
+	assert.ErrorIs(t, err, ErrTenantWebhookNotFound)
+	assert.Contains(t, repo.webhooks, foreign.ID)
+}
+
+func TestWeddingService_PublishesLifecycleEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockTenantWebhookRepository()
+	events := NewTenantWebhookService(repo, &MockUserRepository{}, zaptest.NewLogger(t))
+
+	weddingRepo := &MockWeddingRepository{}
+	userRepo := &MockUserRepository{}
+	service := NewWeddingService(weddingRepo, userRepo)
+	service.SetEventPublisher(events)
+
+	owner := &models.User{ID: models.NewID(), Role: "user", TenantID: "acme"}
+	wedding := createTestWedding()
+
+	userRepo.On("GetByID", ctx, owner.ID).Return(owner, nil)
+	userRepo.On("AddWeddingID", ctx, owner.ID, mock.Anything).Return(nil)
+	weddingRepo.On("ExistsBySlug", ctx, wedding.Slug).Return(false, nil)
+	weddingRepo.On("Create", ctx, wedding).Return(nil)
+
+	require.NoError(t, service.CreateWedding(ctx, wedding, owner.ID))
+	assert.Equal(t, "acme", wedding.TenantID)
+	require.Len(t, repo.events, 1)
+	assert.Equal(t, models.LifecycleWeddingCreated, repo.events[0].Type)
+	assert.Equal(t, "acme", repo.events[0].TenantID)
+}
+
+func TestOrganizationService_PublishesUserRegistered(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockTenantWebhookRepository()
+	service, orgs, users, weddings := newTestOrganizationService(t)
+	service.SetEventPublisher(NewTenantWebhookService(repo, &MockUserRepository{}, zaptest.NewLogger(t)))
+
+	platformAdmin := &models.User{ID: models.NewID(), Role: models.RoleAdmin}
+	member := &models.User{ID: models.NewID(), Role: models.RoleUser, Email: "jane@example.com"}
+	orgs.orgs["acme"] = &models.Organization{ID: "acme"}
+	users.On("GetByID", mock.Anything, platformAdmin.ID).Return(platformAdmin, nil)
+	users.On("GetByID", mock.Anything, member.ID).Return(member, nil)
+	users.On("Update", mock.Anything, member).Return(nil)
+	weddings.On("GetByUserID", mock.Anything, member.ID, 1, 100, repository.WeddingFilters{}).Return([]*models.Wedding{}, int64(0), nil)
+
+	_, err := service.AddMember(ctx, platformAdmin.ID, "acme", AddOrganizationMemberRequest{UserID: member.ID.String()})
+	require.NoError(t, err)
+	require.Len(t, repo.events, 1)
+	assert.Equal(t, models.LifecycleUserRegistered, repo.events[0].Type)
+	assert.Equal(t, "acme", repo.events[0].TenantID)
+	assert.Equal(t, member.ID, repo.events[0].SubjectID)
+
+	// Changing a member's admin rights is no new registration
+	_, err = service.AddMember(ctx, platformAdmin.ID, "acme", AddOrganizationMemberRequest{UserID: member.ID.String(), Admin: true})
+	require.NoError(t, err)
+	assert.Len(t, repo.events, 1)
+}
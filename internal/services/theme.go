@@ -0,0 +1,311 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+var (
+	ErrThemeNotFound = errs.NotFound("theme not found")
+	ErrThemeExists   = errs.Conflict("theme already exists")
+	ErrThemeInUse    = errors.New("theme is in use")
+	ErrInvalidTheme  = errors.New("invalid theme")
+)
+
+// themeIDPattern keeps theme IDs usable in URLs and asset paths
+var themeIDPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// CreateThemeRequest represents a new theme in the catalog
+type CreateThemeRequest struct {
+	ID             string                    `json:"id" validate:"required,min=2,max=50"`
+	Name           string                    `json:"name" validate:"required,max=100"`
+	Description    string                    `json:"description,omitempty" validate:"omitempty,max=500"`
+	PrimaryColor   string                    `json:"primary_color" validate:"required,hexcolor"`
+	SecondaryColor string                    `json:"secondary_color" validate:"required,hexcolor"`
+	FontFamily     string                    `json:"font_family" validate:"required,max=100"`
+	PreviewImages  []string                  `json:"preview_images,omitempty" validate:"omitempty,max=10,dive,url"`
+	Sections       []string                  `json:"sections,omitempty"`
+	ColorScheme    []models.ThemeColorOption `json:"color_scheme,omitempty" validate:"omitempty,dive"`
+	IsDefault      bool                      `json:"is_default"`
+	Premium        bool                      `json:"premium"`
+	Active         *bool                     `json:"active,omitempty"` // Defaults to true
+}
+
+// UpdateThemeRequest represents changes to a theme
+type UpdateThemeRequest struct {
+	Name           *string                    `json:"name,omitempty" validate:"omitempty,max=100"`
+	Description    *string                    `json:"description,omitempty" validate:"omitempty,max=500"`
+	PrimaryColor   *string                    `json:"primary_color,omitempty" validate:"omitempty,hexcolor"`
+	SecondaryColor *string                    `json:"secondary_color,omitempty" validate:"omitempty,hexcolor"`
+	FontFamily     *string                    `json:"font_family,omitempty" validate:"omitempty,max=100"`
+	PreviewImages  *[]string                  `json:"preview_images,omitempty" validate:"omitempty,max=10,dive,url"`
+	Sections       *[]string                  `json:"sections,omitempty"`
+	ColorScheme    *[]models.ThemeColorOption `json:"color_scheme,omitempty" validate:"omitempty,dive"`
+	IsDefault      *bool                      `json:"is_default,omitempty"`
+	Premium        *bool                      `json:"premium,omitempty"`
+	Active         *bool                      `json:"active,omitempty"`
+}
+
+// ThemeService manages the catalog of wedding page themes
+type ThemeService struct {
+	themeRepo   repository.ThemeRepository
+	weddingRepo repository.WeddingRepository
+	logger      *zap.Logger
+	audit       AuditRecorder
+}
+
+// NewThemeService creates a new theme service
+func NewThemeService(themeRepo repository.ThemeRepository, weddingRepo repository.WeddingRepository, logger *zap.Logger) *ThemeService {
+	return &ThemeService{
+		themeRepo:   themeRepo,
+		weddingRepo: weddingRepo,
+		logger:      logger,
+	}
+}
+
+// ListAvailableThemes lists the themes couples may choose from
+func (s *ThemeService) ListAvailableThemes(ctx context.Context) ([]*models.Theme, error) {
+	themes, err := s.themeRepo.List(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list themes: %w", err)
+	}
+	return themes, nil
+}
+
+// ListThemes lists the whole catalog, inactive themes included
+func (s *ThemeService) ListThemes(ctx context.Context) ([]*models.Theme, error) {
+	themes, err := s.themeRepo.List(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list themes: %w", err)
+	}
+	return themes, nil
+}
+
+// GetTheme retrieves a theme of the catalog
+func (s *ThemeService) GetTheme(ctx context.Context, id string) (*models.Theme, error) {
+	theme, err := s.themeRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrThemeNotFound
+		}
+		return nil, fmt.Errorf("failed to get theme: %w", err)
+	}
+	return theme, nil
+}
+
+// SetAuditLog records the changes admins make to the catalog in the audit log
+func (s *ThemeService) SetAuditLog(audit AuditRecorder) {
+	s.audit = audit
+}
+
+// CreateTheme adds a theme to the catalog
+func (s *ThemeService) CreateTheme(ctx context.Context, req CreateThemeRequest) (*models.Theme, error) {
+	if !themeIDPattern.MatchString(req.ID) {
+		return nil, fmt.Errorf("%w: id must be lowercase letters, digits and dashes", ErrInvalidTheme)
+	}
+
+	if _, err := s.themeRepo.GetByID(ctx, req.ID); err == nil {
+		return nil, ErrThemeExists
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get theme: %w", err)
+	}
+
+	theme := &models.Theme{
+		ID:             req.ID,
+		Name:           req.Name,
+		Description:    req.Description,
+		PrimaryColor:   req.PrimaryColor,
+		SecondaryColor: req.SecondaryColor,
+		FontFamily:     req.FontFamily,
+		PreviewImages:  req.PreviewImages,
+		Sections:       req.Sections,
+		ColorScheme:    req.ColorScheme,
+		IsDefault:      req.IsDefault,
+		Premium:        req.Premium,
+		Active:         req.Active == nil || *req.Active,
+	}
+	if err := validateTheme(theme); err != nil {
+		return nil, err
+	}
+
+	if err := s.themeRepo.Create(ctx, theme); err != nil {
+		return nil, fmt.Errorf("failed to create theme: %w", err)
+	}
+	if err := s.keepSingleDefault(ctx, theme); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Theme created", zap.String("theme_id", theme.ID))
+	s.recordAudit(ctx, models.AuditThemeCreate, theme.ID, auditChanges(nil, auditFields(theme)))
+	return theme, nil
+}
+
+// UpdateTheme changes a theme of the catalog
+func (s *ThemeService) UpdateTheme(ctx context.Context, id string, req UpdateThemeRequest) (*models.Theme, error) {
+	theme, err := s.GetTheme(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := auditFields(theme)
+
+	if req.Name != nil {
+		theme.Name = *req.Name
+	}
+	if req.Description != nil {
+		theme.Description = *req.Description
+	}
+	if req.PrimaryColor != nil {
+		theme.PrimaryColor = *req.PrimaryColor
+	}
+	if req.SecondaryColor != nil {
+		theme.SecondaryColor = *req.SecondaryColor
+	}
+	if req.FontFamily != nil {
+		theme.FontFamily = *req.FontFamily
+	}
+	if req.PreviewImages != nil {
+		theme.PreviewImages = *req.PreviewImages
+	}
+	if req.Sections != nil {
+		theme.Sections = *req.Sections
+	}
+	if req.ColorScheme != nil {
+		theme.ColorScheme = *req.ColorScheme
+	}
+	if req.IsDefault != nil {
+		theme.IsDefault = *req.IsDefault
+	}
+	if req.Premium != nil {
+		theme.Premium = *req.Premium
+	}
+	if req.Active != nil {
+		theme.Active = *req.Active
+	}
+	if err := validateTheme(theme); err != nil {
+		return nil, err
+	}
+
+	if err := s.themeRepo.Update(ctx, theme); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrThemeNotFound
+		}
+		return nil, fmt.Errorf("failed to update theme: %w", err)
+	}
+	if err := s.keepSingleDefault(ctx, theme); err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, models.AuditThemeUpdate, theme.ID, auditChanges(before, auditFields(theme)))
+	return theme, nil
+}
+
+// DeleteTheme removes a theme no wedding uses from the catalog. Themes in use
+// can be withdrawn by deactivating them instead.
+func (s *ThemeService) DeleteTheme(ctx context.Context, id string) error {
+	theme, err := s.GetTheme(ctx, id)
+	if err != nil {
+		return err
+	}
+	if theme.IsDefault {
+		return fmt.Errorf("%w: it is the default theme", ErrThemeInUse)
+	}
+
+	count, err := s.weddingRepo.CountByTheme(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to count weddings: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d weddings use it", ErrThemeInUse, count)
+	}
+
+	if err := s.themeRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrThemeNotFound
+		}
+		return fmt.Errorf("failed to delete theme: %w", err)
+	}
+
+	s.logger.Info("Theme deleted", zap.String("theme_id", id))
+	s.recordAudit(ctx, models.AuditThemeDelete, id, auditChanges(auditFields(theme), nil))
+	return nil
+}
+
+// recordAudit records a change of the catalog when the audit log is enabled
+func (s *ThemeService) recordAudit(ctx context.Context, action, themeID string, changes []models.AuditChange) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, &models.AuditLog{
+		Action:     action,
+		TargetType: models.AuditTargetTheme,
+		TargetID:   themeID,
+		Changes:    changes,
+	})
+}
+
+// keepSingleDefault clears the default flag of the other themes once a theme becomes the default
+func (s *ThemeService) keepSingleDefault(ctx context.Context, theme *models.Theme) error {
+	if !theme.IsDefault {
+		return nil
+	}
+
+	themes, err := s.themeRepo.List(ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to list themes: %w", err)
+	}
+	for _, other := range themes {
+		if other.ID == theme.ID || !other.IsDefault {
+			continue
+		}
+		other.IsDefault = false
+		if err := s.themeRepo.Update(ctx, other); err != nil {
+			return fmt.Errorf("failed to update theme %s: %w", other.ID, err)
+		}
+	}
+	return nil
+}
+
+// validateTheme checks what the request tags cannot: known sections and color
+// keys, and a default theme every couple can use
+func validateTheme(theme *models.Theme) error {
+	for _, section := range theme.Sections {
+		if !utils.Contains(models.ThemeSections, section) {
+			return fmt.Errorf("%w: unknown section %q", ErrInvalidTheme, section)
+		}
+	}
+
+	seen := make(map[string]bool, len(theme.ColorScheme))
+	for _, option := range theme.ColorScheme {
+		if !utils.Contains(models.ThemeColorKeys, option.Key) {
+			return fmt.Errorf("%w: unknown color %q", ErrInvalidTheme, option.Key)
+		}
+		if seen[option.Key] {
+			return fmt.Errorf("%w: color %q is listed twice", ErrInvalidTheme, option.Key)
+		}
+		seen[option.Key] = true
+		if err := utils.ValidateHexColor(option.Default); err != nil {
+			return fmt.Errorf("%w: default of %s: %v", ErrInvalidTheme, option.Key, err)
+		}
+	}
+
+	for _, image := range theme.PreviewImages {
+		if u, err := url.Parse(image); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("%w: preview images must be http or https urls", ErrInvalidTheme)
+		}
+	}
+
+	if theme.IsDefault && (theme.Premium || !theme.Active) {
+		return fmt.Errorf("%w: the default theme must be active and free", ErrInvalidTheme)
+	}
+	return nil
+}
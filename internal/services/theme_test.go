@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockThemeRepository is an in-memory theme repository
+type MockThemeRepository struct {
+	themes map[string]*models.Theme
+}
+
+func NewMockThemeRepository() *MockThemeRepository {
+	return &MockThemeRepository{
+		themes: make(map[string]*models.Theme),
+	}
+}
+
+// seedDefaultThemes fills the catalog with the built-in themes
+func (m *MockThemeRepository) seedDefaultThemes() {
+	for _, theme := range models.DefaultThemes() {
+		theme := theme
+		m.themes[theme.ID] = &theme
+	}
+}
+
+func (m *MockThemeRepository) Create(ctx context.Context, theme *models.Theme) error {
+	m.themes[theme.ID] = theme
+	return nil
+}
+
+func (m *MockThemeRepository) GetByID(ctx context.Context, id string) (*models.Theme, error) {
+	theme, ok := m.themes[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	copied := *theme
+	return &copied, nil
+}
+
+func (m *MockThemeRepository) List(ctx context.Context, activeOnly bool) ([]*models.Theme, error) {
+	var themes []*models.Theme
+	for _, theme := range m.themes {
+		if activeOnly && !theme.Active {
+			continue
+		}
+		copied := *theme
+		themes = append(themes, &copied)
+	}
+	return themes, nil
+}
+
+func (m *MockThemeRepository) Update(ctx context.Context, theme *models.Theme) error {
+	if _, ok := m.themes[theme.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	copied := *theme
+	m.themes[theme.ID] = &copied
+	return nil
+}
+
+func (m *MockThemeRepository) Delete(ctx context.Context, id string) error {
+	if _, ok := m.themes[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.themes, id)
+	return nil
+}
+
+func (m *MockThemeRepository) Upsert(ctx context.Context, theme *models.Theme) error {
+	m.themes[theme.ID] = theme
+	return nil
+}
+
+func validCreateThemeRequest() CreateThemeRequest {
+	return CreateThemeRequest{
+		ID:             "sunset",
+		Name:           "Sunset",
+		PrimaryColor:   "#E07A5F",
+		SecondaryColor: "#F4F1DE",
+		FontFamily:     "Lora",
+		Sections:       []string{models.ThemeSectionHero, models.ThemeSectionRSVP},
+		ColorScheme: []models.ThemeColorOption{
+			{Key: "primary_color", Label: "Primary", Default: "#E07A5F"},
+		},
+	}
+}
+
+func TestThemeService_CreateTheme(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - active by default", func(t *testing.T) {
+		themes := NewMockThemeRepository()
+		service := NewThemeService(themes, &MockWeddingRepository{}, zaptest.NewLogger(t))
+
+		theme, err := service.CreateTheme(ctx, validCreateThemeRequest())
+		require.NoError(t, err)
+		assert.True(t, theme.Active)
+		assert.Contains(t, themes.themes, "sunset")
+
+		_, err = service.CreateTheme(ctx, validCreateThemeRequest())
+		assert.ErrorIs(t, err, ErrThemeExists)
+	})
+
+	t.Run("Success - new default replaces the previous one", func(t *testing.T) {
+		themes := NewMockThemeRepository()
+		themes.seedDefaultThemes()
+		service := NewThemeService(themes, &MockWeddingRepository{}, zaptest.NewLogger(t))
+
+		req := validCreateThemeRequest()
+		req.IsDefault = true
+		_, err := service.CreateTheme(ctx, req)
+		require.NoError(t, err)
+
+		assert.True(t, themes.themes["sunset"].IsDefault)
+		assert.False(t, themes.themes["classic"].IsDefault)
+	})
+
+	invalid := map[string]func(*CreateThemeRequest){
+		"id with spaces":      func(r *CreateThemeRequest) { r.ID = "Sun Set" },
+		"unknown section":     func(r *CreateThemeRequest) { r.Sections = []string{"footer"} },
+		"unknown color":       func(r *CreateThemeRequest) { r.ColorScheme[0].Key = "text_color" },
+		"repeated color":      func(r *CreateThemeRequest) { r.ColorScheme = append(r.ColorScheme, r.ColorScheme[0]) },
+		"bad color default":   func(r *CreateThemeRequest) { r.ColorScheme[0].Default = "orange" },
+		"non http preview":    func(r *CreateThemeRequest) { r.PreviewImages = []string{"ftp://cdn/preview.jpg"} },
+		"premium default":     func(r *CreateThemeRequest) { r.IsDefault, r.Premium = true, true },
+		"inactive as default": func(r *CreateThemeRequest) { r.IsDefault, r.Active = true, new(bool) },
+	}
+	for name, change := range invalid {
+		t.Run("Error - "+name, func(t *testing.T) {
+			service := NewThemeService(NewMockThemeRepository(), &MockWeddingRepository{}, zaptest.NewLogger(t))
+			req := validCreateThemeRequest()
+			change(&req)
+
+			_, err := service.CreateTheme(ctx, req)
+			assert.ErrorIs(t, err, ErrInvalidTheme)
+		})
+	}
+}
+
+func TestThemeService_UpdateTheme(t *testing.T) {
+	ctx := context.Background()
+	themes := NewMockThemeRepository()
+	themes.seedDefaultThemes()
+	service := NewThemeService(themes, &MockWeddingRepository{}, zaptest.NewLogger(t))
+
+	inactive := false
+	theme, err := service.UpdateTheme(ctx, "garden", UpdateThemeRequest{Active: &inactive})
+	require.NoError(t, err)
+	assert.False(t, theme.Active)
+
+	available, err := service.ListAvailableThemes(ctx)
+	require.NoError(t, err)
+	assert.Len(t, available, len(models.DefaultThemes())-1)
+
+	_, err = service.UpdateTheme(ctx, "missing", UpdateThemeRequest{})
+	assert.ErrorIs(t, err, ErrThemeNotFound)
+}
+
+func TestThemeService_DeleteTheme(t *testing.T) {
+	ctx := context.Background()
+	themes := NewMockThemeRepository()
+	themes.seedDefaultThemes()
+	weddings := &MockWeddingRepository{}
+	service := NewThemeService(themes, weddings, zaptest.NewLogger(t))
+
+	weddings.On("CountByTheme", ctx, "garden").Return(int64(2), nil).Once()
+	assert.ErrorIs(t, service.DeleteTheme(ctx, "garden"), ErrThemeInUse)
+
+	assert.ErrorIs(t, service.DeleteTheme(ctx, "classic"), ErrThemeInUse)
+
+	weddings.On("CountByTheme", ctx, "garden").Return(int64(0), nil).Once()
+	require.NoError(t, service.DeleteTheme(ctx, "garden"))
+	assert.NotContains(t, themes.themes, "garden")
+
+	assert.ErrorIs(t, service.DeleteTheme(ctx, "garden"), ErrThemeNotFound)
+	weddings.AssertExpectations(t)
+}
+
+func TestWeddingService_CreateWedding_ThemeCatalog(t *testing.T) {
+	ctx := context.Background()
+	userID := models.NewID()
+
+	setup := func() (*MockWeddingRepository, *MockThemeRepository, *WeddingService) {
+		weddingRepo := new(MockWeddingRepository)
+		userRepo := new(MockUserRepository)
+		themes := NewMockThemeRepository()
+		themes.seedDefaultThemes()
+		service := NewWeddingService(weddingRepo, userRepo)
+		service.SetThemeCatalog(themes)
+
+		weddingRepo.On("ExistsBySlug", ctx, mock.Anything).Return(false, nil)
+		weddingRepo.On("Create", ctx, mock.AnythingOfType("*models.Wedding")).Return(nil)
+		userRepo.On("AddWeddingID", ctx, userID, mock.Anything).Return(nil)
+		return weddingRepo, themes, service
+	}
+
+	t.Run("Success - theme from the catalog", func(t *testing.T) {
+		_, _, service := setup()
+		wedding := createTestWedding()
+		wedding.Theme.ThemeID = "garden"
+
+		require.NoError(t, service.CreateWedding(ctx, wedding, userID))
+	})
+
+	t.Run("Success - default theme when none is chosen", func(t *testing.T) {
+		_, _, service := setup()
+		wedding := createTestWedding()
+		wedding.Theme.ThemeID = ""
+
+		require.NoError(t, service.CreateWedding(ctx, wedding, userID))
+		assert.Equal(t, "classic", wedding.Theme.ThemeID)
+	})
+
+	t.Run("Error - unknown theme", func(t *testing.T) {
+		weddingRepo, _, service := setup()
+		wedding := createTestWedding()
+		wedding.Theme.ThemeID = "neon"
+
+		assert.ErrorIs(t, service.CreateWedding(ctx, wedding, userID), ErrThemeNotFound)
+		weddingRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - inactive theme", func(t *testing.T) {
+		_, themes, service := setup()
+		themes.themes["garden"].Active = false
+		wedding := createTestWedding()
+		wedding.Theme.ThemeID = "garden"
+
+		assert.ErrorIs(t, service.CreateWedding(ctx, wedding, userID), ErrThemeNotFound)
+	})
+
+	t.Run("Error - premium theme on the free plan", func(t *testing.T) {
+		_, _, service := setup()
+		users := new(MockUserRepository)
+		weddings := new(MockWeddingRepository)
+		users.On("GetByID", ctx, userID).Return(&models.User{ID: userID}, nil)
+		weddings.On("CountByOwner", ctx, userID).Return(int64(0), nil)
+		service.SetPlanLimiter(NewBillingService(users, weddings, nil, nil, "", "", zaptest.NewLogger(t)))
+		wedding := createTestWedding()
+		wedding.Theme.ThemeID = "royal"
+
+		assert.ErrorIs(t, service.CreateWedding(ctx, wedding, userID), ErrPlanLimitExceeded)
+	})
+}
@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	qrcode "github.com/skip2/go-qrcode"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+const (
+	// twoFactorLoginTTL is how long a login may wait for its second factor
+	twoFactorLoginTTL = 5 * time.Minute
+	// maxTwoFactorAttempts is how many wrong codes end a pending login
+	maxTwoFactorAttempts = 5
+	// backupCodeCount is how many backup codes are issued at a time
+	backupCodeCount = 10
+	// twoFactorQRSize is the width of the provisioning QR code in pixels
+	twoFactorQRSize = 256
+	// DefaultTwoFactorIssuer names the account in authenticator apps
+	DefaultTwoFactorIssuer = "Wedding Invitation"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotSetUp       = errors.New("two-factor authentication has not been set up")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	// ErrTwoFactorLoginExpired is returned when a second-step login token is unknown,
+	// expired or used up by too many wrong codes
+	ErrTwoFactorLoginExpired = errors.New("login session expired, please log in again")
+)
+
+// TwoFactorSetup is what an authenticator app needs to add the account
+type TwoFactorSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+	// QRCode is a PNG data URI of the provisioning URI
+	QRCode string `json:"qr_code"`
+}
+
+// TwoFactorBackupCodes are single-use codes for logging in without the authenticator
+// app. They are only shown once.
+type TwoFactorBackupCodes struct {
+	BackupCodes []string `json:"backup_codes"`
+}
+
+// TwoFactorService manages authenticator app (TOTP) second factors and the
+// logins waiting for them. Users set up a secret, confirm it with a code and
+// receive backup codes; from then on a password alone only starts a pending
+// login, which a code from the app or a backup code completes.
+type TwoFactorService struct {
+	userRepo repository.UserRepository
+	pending  PendingLoginStore
+	issuer   string
+	audit    AuditRecorder
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+// NewTwoFactorService creates a new two-factor service. issuer names the
+// account in authenticator apps.
+func NewTwoFactorService(userRepo repository.UserRepository, pending PendingLoginStore, issuer string, logger *zap.Logger) *TwoFactorService {
+	if issuer == "" {
+		issuer = DefaultTwoFactorIssuer
+	}
+	return &TwoFactorService{
+		userRepo: userRepo,
+		pending:  pending,
+		issuer:   issuer,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// SetAuditLog records enabling and disabling two-factor authentication in the audit log
+func (s *TwoFactorService) SetAuditLog(audit AuditRecorder) {
+	s.audit = audit
+}
+
+// Setup hands out a new secret for the user's authenticator app. It takes
+// effect once Enable confirms a code generated from it.
+func (s *TwoFactorService) Setup(ctx context.Context, userID models.ID) (*TwoFactorSetup, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	uri := utils.TOTPProvisioningURI(s.issuer, user.Email, secret)
+	png, err := qrcode.Encode(uri, qrcode.Medium, twoFactorQRSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render QR code: %w", err)
+	}
+
+	if user.TwoFactor == nil {
+		user.TwoFactor = &models.TwoFactorAuth{}
+	}
+	user.TwoFactor.PendingSecret = secret
+	user.UpdatedAt = s.now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save two-factor setup: %w", err)
+	}
+
+	return &TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningURI: uri,
+		QRCode:          "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+// Enable confirms the secret handed out by Setup with a code from the
+// authenticator app, turns two-factor authentication on and returns the backup codes
+func (s *TwoFactorService) Enable(ctx context.Context, userID models.ID, code string) (*TwoFactorBackupCodes, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if user.TwoFactor == nil || user.TwoFactor.PendingSecret == "" {
+		return nil, ErrTwoFactorNotSetUp
+	}
+
+	step, ok := utils.ValidateTOTP(user.TwoFactor.PendingSecret, code, s.now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := generateBackupCodes()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	user.TwoFactor = &models.TwoFactorAuth{
+		Enabled:      true,
+		EnabledAt:    &now,
+		Secret:       user.TwoFactor.PendingSecret,
+		BackupCodes:  hashes,
+		LastUsedStep: step,
+	}
+	user.UpdatedAt = now
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+	s.recordAudit(ctx, models.AuditUser2FAEnable, user.ID, false, true)
+
+	return &TwoFactorBackupCodes{BackupCodes: codes}, nil
+}
+
+// Disable turns two-factor authentication off. Both the password and a code
+// from the app or a backup code are required.
+func (s *TwoFactorService) Disable(ctx context.Context, userID models.ID, password, code string) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactorEnabled() {
+		return ErrTwoFactorNotEnabled
+	}
+	if !utils.CheckPassword(user.PasswordHash, password) {
+		return ErrInvalidCredentials
+	}
+	if !s.verifyCode(user, code) {
+		return ErrInvalidTwoFactorCode
+	}
+
+	// Kept as an empty document, since updates don't unset missing fields
+	user.TwoFactor = &models.TwoFactorAuth{}
+	user.UpdatedAt = s.now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+	s.recordAudit(ctx, models.AuditUser2FADisable, user.ID, true, false)
+	return nil
+}
+
+// RegenerateBackupCodes replaces the user's backup codes after checking a code from the app
+func (s *TwoFactorService) RegenerateBackupCodes(ctx context.Context, userID models.ID, code string) (*TwoFactorBackupCodes, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TwoFactorEnabled() {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if !s.verifyTOTP(user, code) {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := generateBackupCodes()
+	if err != nil {
+		return nil, err
+	}
+	user.TwoFactor.BackupCodes = hashes
+	user.UpdatedAt = s.now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save backup codes: %w", err)
+	}
+	return &TwoFactorBackupCodes{BackupCodes: codes}, nil
+}
+
+// StartLogin starts a pending login for a user whose password was checked and
+// returns the token that carries it to the second step
+func (s *TwoFactorService) StartLogin(ctx context.Context, user *models.User) (string, time.Time, error) {
+	token, err := s.pending.Create(ctx, user.ID, twoFactorLoginTTL)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, s.now().Add(twoFactorLoginTTL), nil
+}
+
+// CompleteLogin checks the second factor of a pending login and returns its
+// user. Too many wrong codes end the pending login.
+func (s *TwoFactorService) CompleteLogin(ctx context.Context, token, code string) (*models.User, error) {
+	login, err := s.pending.Get(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrPendingLoginNotFound) {
+			return nil, ErrTwoFactorLoginExpired
+		}
+		return nil, err
+	}
+
+	user, err := s.getUser(ctx, login.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TwoFactorEnabled() {
+		_ = s.pending.Delete(ctx, token)
+		return nil, ErrTwoFactorLoginExpired
+	}
+
+	if !s.verifyCode(user, code) {
+		attempts, err := s.pending.RecordFailure(ctx, token)
+		if err != nil && !errors.Is(err, ErrPendingLoginNotFound) {
+			return nil, err
+		}
+		if errors.Is(err, ErrPendingLoginNotFound) || attempts >= maxTwoFactorAttempts {
+			_ = s.pending.Delete(ctx, token)
+			return nil, ErrTwoFactorLoginExpired
+		}
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	if err := s.pending.Delete(ctx, token); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save two-factor state: %w", err)
+	}
+	return user, nil
+}
+
+// verifyCode accepts a code from the app or an unused backup code, consuming it.
+// The caller saves the user.
+func (s *TwoFactorService) verifyCode(user *models.User, code string) bool {
+	if s.verifyTOTP(user, code) {
+		return true
+	}
+	return consumeBackupCode(user.TwoFactor, code)
+}
+
+// verifyTOTP accepts a code from the app that was not used before
+func (s *TwoFactorService) verifyTOTP(user *models.User, code string) bool {
+	step, ok := utils.ValidateTOTP(user.TwoFactor.Secret, code, s.now())
+	if !ok || step <= user.TwoFactor.LastUsedStep {
+		return false
+	}
+	user.TwoFactor.LastUsedStep = step
+	return true
+}
+
+func (s *TwoFactorService) getUser(ctx context.Context, userID models.ID) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	// The user repository reports a missing user as nil
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *TwoFactorService) recordAudit(ctx context.Context, action string, userID models.ID, before, after bool) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, &models.AuditLog{
+		Action:     action,
+		TargetType: models.AuditTargetUser,
+		TargetID:   userID.String(),
+		Changes:    []models.AuditChange{{Field: "two_factor.enabled", Before: before, After: after}},
+	})
+}
+
+// generateBackupCodes returns new backup codes, formatted xxxxx-xxxxx, and their hashes
+func generateBackupCodes() ([]string, []string, error) {
+	codes := make([]string, backupCodeCount)
+	hashes := make([]string, backupCodeCount)
+	for i := range codes {
+		raw := make([]byte, 5)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate backup codes: %w", err)
+		}
+		code := hex.EncodeToString(raw)
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = hashBackupCode(code)
+	}
+	return codes, hashes, nil
+}
+
+// consumeBackupCode removes a matching backup code, ignoring case, spaces and dashes
+func consumeBackupCode(tfa *models.TwoFactorAuth, code string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	if normalized == "" {
+		return false
+	}
+	hash := hashBackupCode(normalized)
+	for i, stored := range tfa.BackupCodes {
+		if subtle.ConstantTimeCompare([]byte(stored), []byte(hash)) == 1 {
+			tfa.BackupCodes = append(tfa.BackupCodes[:i:i], tfa.BackupCodes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func hashBackupCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
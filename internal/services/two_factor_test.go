@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// newTwoFactorTestService returns a service for a user with the password
+// "correct-horse", at a fixed clock that tests may advance
+func newTwoFactorTestService(t *testing.T) (*TwoFactorService, *models.User, *time.Time) {
+	t.Helper()
+	hash, err := utils.HashPasswordWithCost("correct-horse", 4)
+	require.NoError(t, err)
+	user := &models.User{
+		ID:           models.NewID(),
+		Email:        "dana@example.com",
+		PasswordHash: hash,
+		Role:         "user",
+		Status:       models.UserStatusActive,
+	}
+
+	userRepo := &MockUserRepository{}
+	userRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	userRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+	userRepo.On("Update", mock.Anything, user).Return(nil)
+
+	now := time.Unix(1700000000, 0)
+	svc := NewTwoFactorService(userRepo, NewMemoryPendingLoginStore(), "", zap.NewNop())
+	svc.now = func() time.Time { return now }
+	return svc, user, &now
+}
+
+// enableTwoFactor runs setup and verification and returns the secret and backup codes
+func enableTwoFactor(t *testing.T, svc *TwoFactorService, user *models.User) (string, []string) {
+	t.Helper()
+	ctx := context.Background()
+	setup, err := svc.Setup(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(setup.ProvisioningURI, "otpauth://totp/Wedding%20Invitation:dana@example.com?"))
+	assert.True(t, strings.HasPrefix(setup.QRCode, "data:image/png;base64,"))
+	assert.False(t, user.TwoFactorEnabled(), "setup alone must not enable two-factor authentication")
+
+	code, err := utils.TOTPCode(setup.Secret, svc.now())
+	require.NoError(t, err)
+	codes, err := svc.Enable(ctx, user.ID, code)
+	require.NoError(t, err)
+	require.Len(t, codes.BackupCodes, backupCodeCount)
+	return setup.Secret, codes.BackupCodes
+}
+
+func TestTwoFactorService_Enable(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svc, user, _ := newTwoFactorTestService(t)
+		_, codes := enableTwoFactor(t, svc, user)
+
+		assert.True(t, user.TwoFactorEnabled())
+		assert.Empty(t, user.TwoFactor.PendingSecret)
+		// Only hashes of the backup codes are stored
+		assert.NotContains(t, user.TwoFactor.BackupCodes, codes[0])
+
+		_, err := svc.Setup(ctx, user.ID)
+		assert.ErrorIs(t, err, ErrTwoFactorAlreadyEnabled)
+	})
+
+	t.Run("Error - not set up", func(t *testing.T) {
+		svc, user, _ := newTwoFactorTestService(t)
+		_, err := svc.Enable(ctx, user.ID, "123456")
+		assert.ErrorIs(t, err, ErrTwoFactorNotSetUp)
+	})
+
+	t.Run("Error - wrong code", func(t *testing.T) {
+		svc, user, _ := newTwoFactorTestService(t)
+		_, err := svc.Setup(ctx, user.ID)
+		require.NoError(t, err)
+
+		_, err = svc.Enable(ctx, user.ID, "000000")
+		assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+		assert.False(t, user.TwoFactorEnabled())
+	})
+}
+
+func TestAuthService_TwoFactorLogin(t *testing.T) {
+	ctx := context.Background()
+	jwtManager := utils.NewJWTManager("access-secret", "refresh-secret", time.Minute, time.Hour, "test")
+	login := LoginRequest{Email: "dana@example.com", Password: "correct-horse"}
+
+	t.Run("Success - code from the app, which cannot be replayed", func(t *testing.T) {
+		svc, user, now := newTwoFactorTestService(t)
+		secret, _ := enableTwoFactor(t, svc, user)
+		auth := NewAuthService(svc.userRepo, jwtManager, AuthDependencies{TwoFactor: svc})
+
+		resp, err := auth.Login(ctx, login)
+		require.NoError(t, err)
+		assert.True(t, resp.TwoFactorRequired)
+		assert.NotEmpty(t, resp.TwoFactorToken)
+		assert.Empty(t, resp.AccessToken)
+
+		*now = now.Add(time.Minute)
+		code, _ := utils.TOTPCode(secret, *now)
+		resp, err = auth.CompleteTwoFactorLogin(ctx, TwoFactorLoginRequest{TwoFactorToken: resp.TwoFactorToken, Code: code})
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.AccessToken)
+
+		// The second-step token is single use
+		_, err = auth.CompleteTwoFactorLogin(ctx, TwoFactorLoginRequest{TwoFactorToken: resp.TwoFactorToken, Code: code})
+		assert.ErrorIs(t, err, ErrTwoFactorLoginExpired)
+
+		resp, err = auth.Login(ctx, login)
+		require.NoError(t, err)
+		_, err = auth.CompleteTwoFactorLogin(ctx, TwoFactorLoginRequest{TwoFactorToken: resp.TwoFactorToken, Code: code})
+		assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+	})
+
+	t.Run("Success - backup codes work once", func(t *testing.T) {
+		svc, user, _ := newTwoFactorTestService(t)
+		_, codes := enableTwoFactor(t, svc, user)
+		auth := NewAuthService(svc.userRepo, jwtManager, AuthDependencies{TwoFactor: svc})
+
+		resp, err := auth.Login(ctx, login)
+		require.NoError(t, err)
+		_, err = auth.CompleteTwoFactorLogin(ctx, TwoFactorLoginRequest{TwoFactorToken: resp.TwoFactorToken, Code: strings.ToUpper(codes[0])})
+		require.NoError(t, err)
+		assert.Len(t, user.TwoFactor.BackupCodes, backupCodeCount-1)
+
+		resp, err = auth.Login(ctx, login)
+		require.NoError(t, err)
+		_, err = auth.CompleteTwoFactorLogin(ctx, TwoFactorLoginRequest{TwoFactorToken: resp.TwoFactorToken, Code: codes[0]})
+		assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+	})
+
+	t.Run("Error - too many wrong codes end the login", func(t *testing.T) {
+		svc, user, _ := newTwoFactorTestService(t)
+		secret, _ := enableTwoFactor(t, svc, user)
+		auth := NewAuthService(svc.userRepo, jwtManager, AuthDependencies{TwoFactor: svc})
+
+		resp, err := auth.Login(ctx, login)
+		require.NoError(t, err)
+		req := TwoFactorLoginRequest{TwoFactorToken: resp.TwoFactorToken, Code: "000000"}
+		for i := 1; i < maxTwoFactorAttempts; i++ {
+			_, err = auth.CompleteTwoFactorLogin(ctx, req)
+			assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+		}
+		_, err = auth.CompleteTwoFactorLogin(ctx, req)
+		assert.ErrorIs(t, err, ErrTwoFactorLoginExpired)
+
+		req.Code, _ = utils.TOTPCode(secret, svc.now().Add(30*time.Second))
+		_, err = auth.CompleteTwoFactorLogin(ctx, req)
+		assert.ErrorIs(t, err, ErrTwoFactorLoginExpired)
+	})
+
+	t.Run("Error - no two-factor service", func(t *testing.T) {
+		svc, user, _ := newTwoFactorTestService(t)
+		enableTwoFactor(t, svc, user)
+
+		_, err := NewAuthService(svc.userRepo, jwtManager, AuthDependencies{}).Login(ctx, login)
+		assert.ErrorIs(t, err, ErrTwoFactorUnavailable)
+	})
+}
+
+func TestTwoFactorService_Disable(t *testing.T) {
+	ctx := context.Background()
+	svc, user, now := newTwoFactorTestService(t)
+	secret, _ := enableTwoFactor(t, svc, user)
+
+	*now = now.Add(time.Minute)
+	code, _ := utils.TOTPCode(secret, *now)
+	assert.ErrorIs(t, svc.Disable(ctx, user.ID, "wrong", code), ErrInvalidCredentials)
+	assert.ErrorIs(t, svc.Disable(ctx, user.ID, "correct-horse", "000000"), ErrInvalidTwoFactorCode)
+
+	require.NoError(t, svc.Disable(ctx, user.ID, "correct-horse", code))
+	assert.False(t, user.TwoFactorEnabled())
+	assert.Empty(t, user.TwoFactor.Secret)
+	assert.ErrorIs(t, svc.Disable(ctx, user.ID, "correct-horse", code), ErrTwoFactorNotEnabled)
+}
+
+func TestTwoFactorService_RegenerateBackupCodes(t *testing.T) {
+	ctx := context.Background()
+	svc, user, now := newTwoFactorTestService(t)
+	secret, old := enableTwoFactor(t, svc, user)
+
+	// Backup codes can't be used to replace themselves
+	_, err := svc.RegenerateBackupCodes(ctx, user.ID, old[0])
+	assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+
+	*now = now.Add(time.Minute)
+	code, _ := utils.TOTPCode(secret, *now)
+	codes, err := svc.RegenerateBackupCodes(ctx, user.ID, code)
+	require.NoError(t, err)
+	assert.Len(t, codes.BackupCodes, backupCodeCount)
+	assert.False(t, consumeBackupCode(user.TwoFactor, old[1]))
+	assert.True(t, consumeBackupCode(user.TwoFactor, codes.BackupCodes[1]))
+}
+
+func TestMemoryPendingLoginStore_Expiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryPendingLoginStore().(*memoryPendingLoginStore)
+	store.now = func() time.Time { return now }
+
+	userID := models.NewID()
+	token, err := store.Create(ctx, userID, time.Minute)
+	require.NoError(t, err)
+
+	login, err := store.Get(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, userID, login.UserID)
+
+	attempts, err := store.RecordFailure(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempts)
+
+	_, err = store.Get(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrPendingLoginNotFound)
+
+	now = now.Add(time.Minute)
+	_, err = store.Get(ctx, token)
+	assert.ErrorIs(t, err, ErrPendingLoginNotFound)
+	_, err = store.RecordFailure(ctx, token)
+	assert.ErrorIs(t, err, ErrPendingLoginNotFound)
+}
@@ -0,0 +1,309 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+const (
+	// DefaultUploadSessionExpiry is how long an upload session survives without receiving a chunk
+	DefaultUploadSessionExpiry = 24 * time.Hour
+	// uploadSessionPurgeBatch bounds how many expired sessions are purged per janitor run
+	uploadSessionPurgeBatch = 100
+)
+
+var (
+	ErrUploadSessionNotFound = errs.NotFound("upload session not found")
+	ErrUploadOffsetMismatch  = errors.New("upload offset does not match the bytes received")
+	ErrUploadChunkTooLarge   = errors.New("chunk extends past the declared upload size")
+	ErrUploadIncomplete      = errors.New("upload has not received every byte yet")
+)
+
+// ChunkStore stages the bytes of resumable uploads until they are complete.
+// API instances taking chunks of the same upload must share it.
+type ChunkStore interface {
+	// Write stores the data at offset of the upload's file, returning how many
+	// bytes were stored even when reading the data failed part way
+	Write(ctx context.Context, id string, offset int64, data io.Reader) (int64, error)
+	Open(ctx context.Context, id string) (io.ReadSeekCloser, error)
+	Remove(ctx context.Context, id string) error
+}
+
+type fileChunkStore struct {
+	dir string
+}
+
+// NewFileChunkStore creates a chunk store keeping each upload in a file under dir
+func NewFileChunkStore(dir string) ChunkStore {
+	return &fileChunkStore{dir: dir}
+}
+
+func (s *fileChunkStore) Write(ctx context.Context, id string, offset int64, data io.Reader) (int64, error) {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return 0, err
+	}
+	file, err := os.OpenFile(filepath.Join(s.dir, id), os.O_WRONLY|os.O_CREATE, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return io.Copy(file, data)
+}
+
+func (s *fileChunkStore) Open(ctx context.Context, id string) (io.ReadSeekCloser, error) {
+	return os.Open(filepath.Join(s.dir, id))
+}
+
+func (s *fileChunkStore) Remove(ctx context.Context, id string) error {
+	if err := os.Remove(filepath.Join(s.dir, id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// UploadSessionService takes large uploads through the API in chunks, so that
+// uploads interrupted by flaky connections resume where they stopped. Once
+// every byte arrived, the file goes through the regular upload pipeline.
+type UploadSessionService struct {
+	sessions repository.UploadSessionRepository
+	chunks   ChunkStore
+	media    MediaService
+	config   *MediaServiceConfig
+	expiry   time.Duration
+	logger   *zap.Logger
+}
+
+// NewUploadSessionService creates a new resumable upload service. Sessions
+// expire after expiry without a chunk, or DefaultUploadSessionExpiry when zero.
+func NewUploadSessionService(
+	sessions repository.UploadSessionRepository,
+	chunks ChunkStore,
+	media MediaService,
+	config *MediaServiceConfig,
+	expiry time.Duration,
+	logger *zap.Logger,
+) *UploadSessionService {
+	if expiry <= 0 {
+		expiry = DefaultUploadSessionExpiry
+	}
+	return &UploadSessionService{
+		sessions: sessions,
+		chunks:   chunks,
+		media:    media,
+		config:   config,
+		expiry:   expiry,
+		logger:   logger,
+	}
+}
+
+// CreateSession starts a resumable upload of a file, checking its type and
+// size up front like direct uploads
+func (s *UploadSessionService) CreateSession(ctx context.Context, filename string, size int64, userID models.ID) (*models.UploadSession, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("file size must be positive")
+	}
+	if _, err := s.config.validateDirectUpload(filename, size); err != nil {
+		return nil, err
+	}
+
+	session := &models.UploadSession{
+		UserID:    userID,
+		Filename:  filepath.Base(filename),
+		Size:      size,
+		ExpiresAt: time.Now().Add(s.expiry),
+	}
+	if err := s.sessions.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetSession returns an upload session of the user
+func (s *UploadSessionService) GetSession(ctx context.Context, id, userID models.ID) (*models.UploadSession, error) {
+	session, err := s.sessions.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUploadSessionNotFound
+		}
+		return nil, err
+	}
+	if session.UserID != userID || time.Now().After(session.ExpiresAt) {
+		return nil, ErrUploadSessionNotFound
+	}
+	return session, nil
+}
+
+// AppendChunk stores a chunk sent for the given offset, which must be the
+// number of bytes received so far. Bytes stored before the chunk failed (e.g.
+// the connection dropped) are kept, so the returned session carries the offset
+// to resume from whenever it is known, errors included.
+func (s *UploadSessionService) AppendChunk(ctx context.Context, id, userID models.ID, offset int64, data io.Reader) (*models.UploadSession, error) {
+	session, err := s.GetSession(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if offset != session.Offset {
+		return session, ErrUploadOffsetMismatch
+	}
+
+	remaining := session.Size - session.Offset
+	written, writeErr := s.chunks.Write(ctx, session.ID.String(), offset, io.LimitReader(data, remaining))
+	if writeErr == nil && written == remaining {
+		// Anything beyond the declared size is refused
+		if n, _ := data.Read(make([]byte, 1)); n > 0 {
+			writeErr = ErrUploadChunkTooLarge
+		}
+	}
+
+	if written > 0 {
+		expiresAt := time.Now().Add(s.expiry)
+		if err := s.sessions.Advance(ctx, session.ID, offset, offset+written, expiresAt); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				// A concurrent request for the same offset won
+				return nil, ErrUploadOffsetMismatch
+			}
+			return nil, err
+		}
+		session.Offset += written
+		session.ExpiresAt = expiresAt
+	}
+
+	if writeErr != nil {
+		if errors.Is(writeErr, ErrUploadChunkTooLarge) {
+			return session, writeErr
+		}
+		return session, fmt.Errorf("failed to store chunk: %w", writeErr)
+	}
+	return session, nil
+}
+
+// CompleteSession runs a fully received upload through the upload pipeline
+// (validation, processing and storage) and discards the session
+func (s *UploadSessionService) CompleteSession(ctx context.Context, id, userID models.ID) (*models.Media, error) {
+	session, err := s.GetSession(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsComplete() {
+		return nil, ErrUploadIncomplete
+	}
+
+	file, err := s.chunks.Open(ctx, session.ID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	header := &multipart.FileHeader{Filename: session.Filename, Size: session.Size}
+	media, err := s.media.UploadFile(ctx, file, header, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.discard(ctx, session)
+	return media, nil
+}
+
+// CancelSession discards an upload session and the bytes it received
+func (s *UploadSessionService) CancelSession(ctx context.Context, id, userID models.ID) error {
+	session, err := s.GetSession(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	s.discard(ctx, session)
+	return nil
+}
+
+// PurgeExpired discards a batch of the sessions that expired before now,
+// returning how many
+func (s *UploadSessionService) PurgeExpired(ctx context.Context, now time.Time) (int, error) {
+	sessions, err := s.sessions.ListExpired(ctx, now, uploadSessionPurgeBatch)
+	if err != nil {
+		return 0, err
+	}
+	for _, session := range sessions {
+		s.discard(ctx, session)
+	}
+	return len(sessions), nil
+}
+
+// discard removes the staged bytes of a session before the session, so that
+// a failure leaves the session for the next purge rather than orphaned bytes
+func (s *UploadSessionService) discard(ctx context.Context, session *models.UploadSession) {
+	if err := s.chunks.Remove(ctx, session.ID.String()); err != nil {
+		s.logger.Error("Failed to remove upload chunks", zap.String("session_id", session.ID.String()), zap.Error(err))
+		return
+	}
+	if err := s.sessions.Delete(ctx, session.ID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		s.logger.Error("Failed to delete upload session", zap.String("session_id", session.ID.String()), zap.Error(err))
+	}
+}
+
+// UploadSessionJanitor periodically discards expired upload sessions
+type UploadSessionJanitor struct {
+	service  *UploadSessionService
+	interval time.Duration
+	logger   *zap.Logger
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewUploadSessionJanitor creates a janitor that purges expired sessions every interval
+func NewUploadSessionJanitor(service *UploadSessionService, interval time.Duration, logger *zap.Logger) *UploadSessionJanitor {
+	return &UploadSessionJanitor{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start runs the janitor loop in the background
+func (j *UploadSessionJanitor) Start(ctx context.Context) {
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-j.stop:
+				return
+			case now := <-ticker.C:
+				purged, err := j.service.PurgeExpired(ctx, now)
+				if err != nil {
+					j.logger.Error("Upload session purge failed", zap.Error(err))
+					continue
+				}
+				if purged > 0 {
+					j.logger.Info("Expired upload sessions purged", zap.Int("count", purged))
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the janitor loop to exit and waits for it
+func (j *UploadSessionJanitor) Stop() {
+	close(j.stop)
+	j.wg.Wait()
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockUploadSessionRepository is an in-memory upload session repository
+type MockUploadSessionRepository struct {
+	sessions map[models.ID]*models.UploadSession
+}
+
+func NewMockUploadSessionRepository() *MockUploadSessionRepository {
+	return &MockUploadSessionRepository{sessions: make(map[models.ID]*models.UploadSession)}
+}
+
+func (m *MockUploadSessionRepository) Create(ctx context.Context, session *models.UploadSession) error {
+	if session.ID.IsZero() {
+		session.ID = models.NewID()
+	}
+	stored := *session
+	m.sessions[session.ID] = &stored
+	return nil
+}
+
+func (m *MockUploadSessionRepository) GetByID(ctx context.Context, id models.ID) (*models.UploadSession, error) {
+	session, ok := m.sessions[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	stored := *session
+	return &stored, nil
+}
+
+func (m *MockUploadSessionRepository) Advance(ctx context.Context, id models.ID, from, to int64, expiresAt time.Time) error {
+	session, ok := m.sessions[id]
+	if !ok || session.Offset != from {
+		return repository.ErrNotFound
+	}
+	session.Offset = to
+	session.ExpiresAt = expiresAt
+	return nil
+}
+
+func (m *MockUploadSessionRepository) Delete(ctx context.Context, id models.ID) error {
+	if _, ok := m.sessions[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.sessions, id)
+	return nil
+}
+
+func (m *MockUploadSessionRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*models.UploadSession, error) {
+	sessions := []*models.UploadSession{}
+	for _, session := range m.sessions {
+		if session.ExpiresAt.Before(now) && len(sessions) < limit {
+			stored := *session
+			sessions = append(sessions, &stored)
+		}
+	}
+	return sessions, nil
+}
+
+// failingReader returns its data then fails, like a dropped connection
+type failingReader struct {
+	data io.Reader
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	n, err := r.data.Read(p)
+	if err == io.EOF {
+		return n, errors.New("connection reset")
+	}
+	return n, err
+}
+
+// uploadedMediaService records the file completed uploads hand to the upload pipeline
+type uploadedMediaService struct {
+	galleryMediaService
+	content string
+}
+
+func (m *uploadedMediaService) UploadFile(ctx context.Context, file io.Reader, header *multipart.FileHeader, userID models.ID) (*models.Media, error) {
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	m.content = string(content)
+	return m.galleryMediaService.UploadFile(ctx, file, header, userID)
+}
+
+func setupUploadSessionService(t *testing.T) (*UploadSessionService, *MockUploadSessionRepository, *uploadedMediaService) {
+	repo := NewMockUploadSessionRepository()
+	media := &uploadedMediaService{galleryMediaService: galleryMediaService{media: make(map[models.ID]*models.Media)}}
+	chunks := NewFileChunkStore(t.TempDir())
+
+	return NewUploadSessionService(repo, chunks, media, DefaultMediaServiceConfig(), time.Hour, zaptest.NewLogger(t)), repo, media
+}
+
+func TestUploadSessionService_CreateSession(t *testing.T) {
+	ctx := context.Background()
+	userID := models.NewID()
+
+	t.Run("Success", func(t *testing.T) {
+		service, _, _ := setupUploadSessionService(t)
+
+		session, err := service.CreateSession(ctx, "../photos/dance.jpg", 1024, userID)
+		require.NoError(t, err)
+		assert.Equal(t, "dance.jpg", session.Filename)
+		assert.Zero(t, session.Offset)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), session.ExpiresAt, time.Minute)
+	})
+
+	t.Run("Error - file type not allowed", func(t *testing.T) {
+		service, _, _ := setupUploadSessionService(t)
+
+		_, err := service.CreateSession(ctx, "notes.txt", 1024, userID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Error - file too large", func(t *testing.T) {
+		service, _, _ := setupUploadSessionService(t)
+
+		_, err := service.CreateSession(ctx, "dance.jpg", 100*1024*1024, userID)
+		assert.Error(t, err)
+	})
+}
+
+func TestUploadSessionService_AppendChunk(t *testing.T) {
+	ctx := context.Background()
+	userID := models.NewID()
+
+	t.Run("Success - resumes after a dropped connection", func(t *testing.T) {
+		service, _, media := setupUploadSessionService(t)
+		session, err := service.CreateSession(ctx, "dance.jpg", 10, userID)
+		require.NoError(t, err)
+
+		session, err = service.AppendChunk(ctx, session.ID, userID, 0, &failingReader{data: strings.NewReader("0123")})
+		assert.Error(t, err)
+		require.NotNil(t, session)
+		assert.Equal(t, int64(4), session.Offset)
+
+		_, err = service.CompleteSession(ctx, session.ID, userID)
+		assert.ErrorIs(t, err, ErrUploadIncomplete)
+
+		session, err = service.AppendChunk(ctx, session.ID, userID, 4, strings.NewReader("456789"))
+		require.NoError(t, err)
+		assert.True(t, session.IsComplete())
+
+		uploaded, err := service.CompleteSession(ctx, session.ID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, "dance.jpg", uploaded.Filename)
+		assert.Equal(t, "0123456789", media.content)
+
+		_, err = service.GetSession(ctx, session.ID, userID)
+		assert.ErrorIs(t, err, ErrUploadSessionNotFound)
+	})
+
+	t.Run("Error - offset mismatch", func(t *testing.T) {
+		service, _, _ := setupUploadSessionService(t)
+		session, err := service.CreateSession(ctx, "dance.jpg", 10, userID)
+		require.NoError(t, err)
+
+		session, err = service.AppendChunk(ctx, session.ID, userID, 5, strings.NewReader("56789"))
+		assert.ErrorIs(t, err, ErrUploadOffsetMismatch)
+		assert.Zero(t, session.Offset)
+	})
+
+	t.Run("Error - chunk past the declared size", func(t *testing.T) {
+		service, _, _ := setupUploadSessionService(t)
+		session, err := service.CreateSession(ctx, "dance.jpg", 4, userID)
+		require.NoError(t, err)
+
+		session, err = service.AppendChunk(ctx, session.ID, userID, 0, strings.NewReader("012345"))
+		assert.ErrorIs(t, err, ErrUploadChunkTooLarge)
+		assert.Equal(t, int64(4), session.Offset)
+	})
+
+	t.Run("Error - session of another user", func(t *testing.T) {
+		service, _, _ := setupUploadSessionService(t)
+		session, err := service.CreateSession(ctx, "dance.jpg", 10, userID)
+		require.NoError(t, err)
+
+		_, err = service.AppendChunk(ctx, session.ID, models.NewID(), 0, strings.NewReader("0123"))
+		assert.ErrorIs(t, err, ErrUploadSessionNotFound)
+	})
+}
+
+func TestUploadSessionService_PurgeExpired(t *testing.T) {
+	ctx := context.Background()
+	userID := models.NewID()
+	service, repo, _ := setupUploadSessionService(t)
+
+	expired, err := service.CreateSession(ctx, "dance.jpg", 10, userID)
+	require.NoError(t, err)
+	_, err = service.AppendChunk(ctx, expired.ID, userID, 0, strings.NewReader("0123"))
+	require.NoError(t, err)
+	repo.sessions[expired.ID].ExpiresAt = time.Now().Add(-time.Minute)
+
+	active, err := service.CreateSession(ctx, "toast.jpg", 10, userID)
+	require.NoError(t, err)
+
+	_, err = service.GetSession(ctx, expired.ID, userID)
+	assert.ErrorIs(t, err, ErrUploadSessionNotFound)
+
+	purged, err := service.PurgeExpired(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.NotContains(t, repo.sessions, expired.ID)
+	assert.Contains(t, repo.sessions, active.ID)
+
+	_, err = service.chunks.Open(ctx, expired.ID.String())
+	assert.Error(t, err)
+}
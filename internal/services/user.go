@@ -6,15 +6,22 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"wedding-invitation-backend/internal/domain/errs"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
+)
 
-	"go.mongodb.org/mongo-driver/bson/primitive"
+var (
+	// ErrInvalidUserStatus is returned for a status other than active, inactive, unverified or suspended
+	ErrInvalidUserStatus = errs.InvalidField("status", "invalid user status")
+	// ErrInvalidUserRole is returned for an unknown account role
+	ErrInvalidUserRole = errs.InvalidField("role", "invalid user role")
 )
 
 // UserService provides business logic for user management
 type UserService struct {
 	userRepo repository.UserRepository
+	audit    AuditRecorder
 }
 
 // NewUserService creates a new user service
@@ -24,6 +31,11 @@ func NewUserService(userRepo repository.UserRepository) *UserService {
 	}
 }
 
+// SetAuditLog records the status and role changes admins make to accounts in the audit log
+func (s *UserService) SetAuditLog(audit AuditRecorder) {
+	s.audit = audit
+}
+
 // UserProfile represents user profile data for updates
 type UserProfile struct {
 	FirstName *string `json:"first_name" validate:"omitempty,min=1,max=50"`
@@ -57,13 +69,13 @@ func (s *UserService) isValidUserStatus(status models.UserStatus) bool {
 }
 
 // GetUserProfile retrieves a user's profile by ID
-func (s *UserService) GetUserProfile(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+func (s *UserService) GetUserProfile(ctx context.Context, userID models.ID) (*models.User, error) {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
 	}
 
 	// Clear sensitive data
@@ -75,14 +87,14 @@ func (s *UserService) GetUserProfile(ctx context.Context, userID primitive.Objec
 }
 
 // UpdateUserProfile updates a user's profile information
-func (s *UserService) UpdateUserProfile(ctx context.Context, userID primitive.ObjectID, profile *UserProfile) (*models.User, error) {
+func (s *UserService) UpdateUserProfile(ctx context.Context, userID models.ID, profile *UserProfile) (*models.User, error) {
 	// Get existing user
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
 	}
 
 	// Update fields if provided
@@ -112,10 +124,15 @@ func (s *UserService) UpdateUserProfile(ctx context.Context, userID primitive.Ob
 }
 
 // UpdateUserStatus updates a user's status (admin only)
-func (s *UserService) UpdateUserStatus(ctx context.Context, userID primitive.ObjectID, status models.UserStatus) error {
+func (s *UserService) UpdateUserStatus(ctx context.Context, userID models.ID, status models.UserStatus) error {
+	return s.setUserStatus(ctx, userID, status, models.AuditUserStatus)
+}
+
+// setUserStatus updates a user's status, recording the change as action
+func (s *UserService) setUserStatus(ctx context.Context, userID models.ID, status models.UserStatus, action string) error {
 	// Validate status
 	if !s.isValidUserStatus(status) {
-		return errors.New("invalid user status")
+		return ErrInvalidUserStatus
 	}
 
 	// Get existing user
@@ -124,10 +141,11 @@ func (s *UserService) UpdateUserStatus(ctx context.Context, userID primitive.Obj
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return errors.New("user not found")
+		return ErrUserNotFound
 	}
 
 	// Update status
+	previous := user.Status
 	user.Status = status
 	user.UpdatedAt = time.Now()
 
@@ -136,12 +154,56 @@ func (s *UserService) UpdateUserStatus(ctx context.Context, userID primitive.Obj
 		return fmt.Errorf("failed to update user status: %w", err)
 	}
 
+	s.recordAudit(ctx, action, userID, "status", previous, status)
+	return nil
+}
+
+// UpdateUserRole updates user account role (admin only)
+func (s *UserService) UpdateUserRole(ctx context.Context, userID models.ID, role string) error {
+	// Validate role
+	if !models.IsValidRole(role) {
+		return ErrInvalidUserRole
+	}
+
+	// Get existing user
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	// Update role; it takes effect on the user's next sign-in or token refresh
+	previous := user.Role
+	user.Role = role
+	user.UpdatedAt = time.Now()
+
+	// Save changes
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	s.recordAudit(ctx, models.AuditUserRole, userID, "role", previous, role)
 	return nil
 }
 
 // DeleteUser deletes a user (soft delete by setting status to inactive)
-func (s *UserService) DeleteUser(ctx context.Context, userID primitive.ObjectID) error {
-	return s.UpdateUserStatus(ctx, userID, models.UserStatusInactive)
+func (s *UserService) DeleteUser(ctx context.Context, userID models.ID) error {
+	return s.setUserStatus(ctx, userID, models.UserStatusInactive, models.AuditUserDelete)
+}
+
+// recordAudit records an admin's change of an account field when the audit log is enabled
+func (s *UserService) recordAudit(ctx context.Context, action string, userID models.ID, field string, before, after interface{}) {
+	if s.audit == nil || before == after {
+		return
+	}
+	s.audit.Record(ctx, &models.AuditLog{
+		Action:     action,
+		TargetType: models.AuditTargetUser,
+		TargetID:   userID.String(),
+		Changes:    []models.AuditChange{{Field: field, Before: before, After: after}},
+	})
 }
 
 // GetUsersList retrieves a paginated list of users (admin only)
@@ -232,14 +294,14 @@ func (s *UserService) GetUserStats(ctx context.Context) (map[string]int64, error
 }
 
 // AddWeddingToUser adds a wedding ID to a user's wedding list
-func (s *UserService) AddWeddingToUser(ctx context.Context, userID, weddingID primitive.ObjectID) error {
+func (s *UserService) AddWeddingToUser(ctx context.Context, userID, weddingID models.ID) error {
 	// Verify user exists
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return errors.New("user not found")
+		return ErrUserNotFound
 	}
 
 	// Add wedding ID
@@ -251,14 +313,14 @@ func (s *UserService) AddWeddingToUser(ctx context.Context, userID, weddingID pr
 }
 
 // RemoveWeddingFromUser removes a wedding ID from a user's wedding list
-func (s *UserService) RemoveWeddingFromUser(ctx context.Context, userID, weddingID primitive.ObjectID) error {
+func (s *UserService) RemoveWeddingFromUser(ctx context.Context, userID, weddingID models.ID) error {
 	// Verify user exists
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return errors.New("user not found")
+		return ErrUserNotFound
 	}
 
 	// Remove wedding ID
@@ -270,13 +332,13 @@ func (s *UserService) RemoveWeddingFromUser(ctx context.Context, userID, wedding
 }
 
 // GetUserWeddings retrieves all wedding IDs for a user
-func (s *UserService) GetUserWeddings(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+func (s *UserService) GetUserWeddings(ctx context.Context, userID models.ID) ([]models.ID, error) {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
 	}
 
 	return user.WeddingIDs, nil
@@ -306,7 +368,7 @@ func (s *UserService) ValidateUser(user *models.User) error {
 
 	// Validate status
 	if !s.isValidUserStatus(user.Status) {
-		return errors.New("invalid user status")
+		return ErrInvalidUserStatus
 	}
 
 	// Validate phone if provided
@@ -318,7 +380,7 @@ func (s *UserService) ValidateUser(user *models.User) error {
 }
 
 // IsEmailAvailable checks if an email is available for registration
-func (s *UserService) IsEmailAvailable(ctx context.Context, email string, excludeUserID *primitive.ObjectID) (bool, error) {
+func (s *UserService) IsEmailAvailable(ctx context.Context, email string, excludeUserID *models.ID) (bool, error) {
 	existingUser, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		return false, fmt.Errorf("failed to check email availability: %w", err)
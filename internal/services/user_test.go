@@ -9,7 +9,6 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func setupUserService() (*UserService, *MockUserRepository) {
@@ -19,7 +18,7 @@ func setupUserService() (*UserService, *MockUserRepository) {
 }
 
 func createTestUser() *models.User {
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	return &models.User{
 		ID:            userID,
 		Email:         "test@example.com",
@@ -29,7 +28,7 @@ func createTestUser() *models.User {
 		EmailVerified: true,
 		Status:        models.UserStatusActive,
 		Role:          "user",
-		WeddingIDs:    []primitive.ObjectID{},
+		WeddingIDs:    []models.ID{},
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
@@ -38,7 +37,7 @@ func createTestUser() *models.User {
 func TestUserService_GetUserProfile(t *testing.T) {
 	service, mockRepo := setupUserService()
 	ctx := context.Background()
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 
 	t.Run("success", func(t *testing.T) {
 		user := createTestUser()
@@ -94,7 +93,7 @@ func TestUserService_GetUserProfile(t *testing.T) {
 func TestUserService_UpdateUserProfile(t *testing.T) {
 	service, mockRepo := setupUserService()
 	ctx := context.Background()
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 
 	t.Run("success", func(t *testing.T) {
 		user := createTestUser()
@@ -141,7 +140,7 @@ func TestUserService_UpdateUserProfile(t *testing.T) {
 func TestUserService_UpdateUserStatus(t *testing.T) {
 	service, mockRepo := setupUserService()
 	ctx := context.Background()
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 
 	t.Run("success", func(t *testing.T) {
 		user := createTestUser()
@@ -180,6 +179,47 @@ func TestUserService_UpdateUserStatus(t *testing.T) {
 	})
 }
 
+func TestUserService_UpdateUserRole(t *testing.T) {
+	ctx := context.Background()
+	userID := models.NewID()
+
+	t.Run("success", func(t *testing.T) {
+		service, mockRepo := setupUserService()
+		user := createTestUser()
+		user.ID = userID
+
+		mockRepo.On("GetByID", ctx, userID).Return(user, nil)
+		mockRepo.On("Update", ctx, mock.AnythingOfType("*models.User")).Return(nil)
+
+		err := service.UpdateUserRole(ctx, userID, models.RolePlanner)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.RolePlanner, user.Role)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		service, _ := setupUserService()
+
+		err := service.UpdateUserRole(ctx, userID, "superuser")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid user role")
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		service, mockRepo := setupUserService()
+
+		mockRepo.On("GetByID", ctx, userID).Return((*models.User)(nil), nil)
+
+		err := service.UpdateUserRole(ctx, userID, models.RoleAdmin)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "user not found")
+		mockRepo.AssertExpectations(t)
+	})
+}
+
 func TestUserService_GetUsersList(t *testing.T) {
 	service, mockRepo := setupUserService()
 	ctx := context.Background()
@@ -265,8 +305,8 @@ func TestUserService_SearchUsers(t *testing.T) {
 func TestUserService_AddWeddingToUser(t *testing.T) {
 	service, mockRepo := setupUserService()
 	ctx := context.Background()
-	userID := primitive.NewObjectID()
-	weddingID := primitive.NewObjectID()
+	userID := models.NewID()
+	weddingID := models.NewID()
 
 	t.Run("success", func(t *testing.T) {
 		user := createTestUser()
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"strings"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+const (
+	// maxVendors bounds the vendors of a wedding
+	maxVendors = 200
+	// maxVendorAttachments bounds the files attached to a vendor
+	maxVendorAttachments = 20
+)
+
+var (
+	ErrVendorNotFound = errs.NotFound("vendor not found")
+	ErrInvalidVendor  = errors.New("invalid vendor")
+	ErrVendorsFull    = errors.New("a wedding holds at most 200 vendors")
+)
+
+// VendorRequest describes a vendor of a wedding
+type VendorRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Category    string `json:"category" validate:"required,max=50"`
+	ContactName string `json:"contact_name,omitempty" validate:"omitempty,max=100"`
+	Email       string `json:"email,omitempty" validate:"omitempty,email"`
+	Phone       string `json:"phone,omitempty" validate:"omitempty,max=30"`
+	Website     string `json:"website,omitempty" validate:"omitempty,url,max=500"`
+	Address     string `json:"address,omitempty" validate:"omitempty,max=300"`
+	// ContractStatus defaults to none
+	ContractStatus models.VendorContractStatus `json:"contract_status,omitempty" validate:"omitempty,oneof=none negotiating sent signed cancelled"`
+	// AttachmentIDs are media the editing user or the wedding owner uploaded
+	AttachmentIDs []string `json:"attachment_ids,omitempty" validate:"omitempty,max=20"`
+	Notes         string   `json:"notes,omitempty" validate:"omitempty,max=5000"`
+	// Sharing defaults to private; only the wedding owner may change it
+	Sharing models.VendorSharing `json:"sharing,omitempty" validate:"omitempty,oneof=private view edit"`
+}
+
+// VendorService lets wedding owners keep track of the vendors they work with,
+// optionally sharing them with the wedding's collaborators
+type VendorService struct {
+	vendorRepo  repository.VendorRepository
+	weddingRepo repository.WeddingRepository
+	mediaRepo   repository.MediaRepository
+	logger      *zap.Logger
+}
+
+// NewVendorService creates a new wedding vendor service
+func NewVendorService(
+	vendorRepo repository.VendorRepository,
+	weddingRepo repository.WeddingRepository,
+	mediaRepo repository.MediaRepository,
+	logger *zap.Logger,
+) *VendorService {
+	return &VendorService{
+		vendorRepo:  vendorRepo,
+		weddingRepo: weddingRepo,
+		mediaRepo:   mediaRepo,
+		logger:      logger,
+	}
+}
+
+// ListVendors lists the vendors of a wedding by name. Collaborators see the vendors shared with them.
+func (s *VendorService) ListVendors(ctx context.Context, weddingID, userID models.ID, filters repository.VendorFilters) ([]*models.Vendor, error) {
+	_, role, err := s.getWeddingRole(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if filters.ContractStatus != "" && !filters.ContractStatus.IsValid() {
+		return nil, fmt.Errorf("%w: unknown contract status %q", ErrInvalidVendor, filters.ContractStatus)
+	}
+
+	filters.Category = normalizeVendorCategory(filters.Category)
+	filters.SharedOnly = role != models.WeddingRoleOwner
+	return s.vendorRepo.ListByWedding(ctx, weddingID, filters)
+}
+
+// GetVendor returns a vendor the user sees
+func (s *VendorService) GetVendor(ctx context.Context, weddingID, vendorID, userID models.ID) (*models.Vendor, error) {
+	_, role, err := s.getWeddingRole(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.getVendor(ctx, weddingID, vendorID, role)
+}
+
+// CreateVendor adds a vendor to a wedding (owner only)
+func (s *VendorService) CreateVendor(ctx context.Context, weddingID, userID models.ID, req VendorRequest) (*models.Vendor, error) {
+	wedding, role, err := s.getWeddingRole(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role != models.WeddingRoleOwner {
+		return nil, ErrUnauthorized
+	}
+
+	count, err := s.vendorRepo.CountByWedding(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxVendors {
+		return nil, ErrVendorsFull
+	}
+
+	vendor := &models.Vendor{
+		WeddingID: weddingID,
+		Sharing:   models.VendorPrivate,
+		CreatedBy: userID,
+	}
+	if err := s.applyVendor(ctx, wedding, vendor, role, userID, req); err != nil {
+		return nil, err
+	}
+	if err := s.vendorRepo.Create(ctx, vendor); err != nil {
+		return nil, err
+	}
+	return vendor, nil
+}
+
+// UpdateVendor replaces the details of a vendor. Collaborators may update the
+// vendors shared with them for editing, without changing how they are shared.
+func (s *VendorService) UpdateVendor(ctx context.Context, weddingID, vendorID, userID models.ID, req VendorRequest) (*models.Vendor, error) {
+	wedding, role, err := s.getWeddingRole(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	vendor, err := s.getVendor(ctx, weddingID, vendorID, role)
+	if err != nil {
+		return nil, err
+	}
+	if !vendor.IsEditableBy(role) {
+		return nil, ErrUnauthorized
+	}
+
+	if err := s.applyVendor(ctx, wedding, vendor, role, userID, req); err != nil {
+		return nil, err
+	}
+	if err := s.vendorRepo.Update(ctx, vendor); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrVendorNotFound
+		}
+		return nil, err
+	}
+	return vendor, nil
+}
+
+// DeleteVendor removes a vendor from a wedding (owner only). Attached files are kept in the owner's media.
+func (s *VendorService) DeleteVendor(ctx context.Context, weddingID, vendorID, userID models.ID) error {
+	_, role, err := s.getWeddingRole(ctx, weddingID, userID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getVendor(ctx, weddingID, vendorID, role); err != nil {
+		return err
+	}
+	if role != models.WeddingRoleOwner {
+		return ErrUnauthorized
+	}
+
+	if err := s.vendorRepo.Delete(ctx, vendorID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrVendorNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// applyVendor copies the details of a request onto a vendor
+func (s *VendorService) applyVendor(ctx context.Context, wedding *models.Wedding, vendor *models.Vendor, role models.WeddingRole, userID models.ID, req VendorRequest) error {
+	name := strings.TrimSpace(req.Name)
+	category := normalizeVendorCategory(req.Category)
+	if name == "" || category == "" {
+		return fmt.Errorf("%w: name and category are required", ErrInvalidVendor)
+	}
+
+	contractStatus := req.ContractStatus
+	if contractStatus == "" {
+		contractStatus = models.VendorContractNone
+	}
+	if !contractStatus.IsValid() {
+		return fmt.Errorf("%w: unknown contract status %q", ErrInvalidVendor, contractStatus)
+	}
+
+	if req.Sharing != "" && req.Sharing != vendor.Sharing {
+		if role != models.WeddingRoleOwner {
+			return ErrUnauthorized
+		}
+		switch req.Sharing {
+		case models.VendorPrivate, models.VendorSharedView, models.VendorSharedEdit:
+		default:
+			return fmt.Errorf("%w: unknown sharing %q", ErrInvalidVendor, req.Sharing)
+		}
+		vendor.Sharing = req.Sharing
+	}
+
+	attachments, err := s.resolveAttachments(ctx, wedding, vendor, userID, req.AttachmentIDs)
+	if err != nil {
+		return err
+	}
+
+	vendor.Name = name
+	vendor.Category = category
+	vendor.ContactName = strings.TrimSpace(req.ContactName)
+	vendor.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	vendor.Phone = strings.TrimSpace(req.Phone)
+	vendor.Website = strings.TrimSpace(req.Website)
+	vendor.Address = strings.TrimSpace(req.Address)
+	vendor.ContractStatus = contractStatus
+	vendor.Attachments = attachments
+	vendor.Notes = strings.TrimSpace(req.Notes)
+	return nil
+}
+
+// resolveAttachments links the requested media to a vendor. Files already attached
+// stay as they are; new ones must be uploaded by the user or the wedding owner.
+func (s *VendorService) resolveAttachments(ctx context.Context, wedding *models.Wedding, vendor *models.Vendor, userID models.ID, ids []string) ([]models.VendorAttachment, error) {
+	if len(ids) > maxVendorAttachments {
+		return nil, fmt.Errorf("%w: at most %d attachments", ErrInvalidVendor, maxVendorAttachments)
+	}
+
+	attached := make(map[models.ID]models.VendorAttachment, len(vendor.Attachments))
+	for _, attachment := range vendor.Attachments {
+		attached[attachment.MediaID] = attachment
+	}
+
+	attachments := make([]models.VendorAttachment, 0, len(ids))
+	seen := make(map[models.ID]bool, len(ids))
+	for _, raw := range ids {
+		id, err := models.ParseID(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid attachment ID %q", ErrInvalidVendor, raw)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if attachment, ok := attached[id]; ok {
+			attachments = append(attachments, attachment)
+			continue
+		}
+
+		media, err := s.mediaRepo.GetByID(ctx, id)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get attachment: %w", err)
+		}
+		if media == nil || media.DeletedAt != nil || (media.CreatedBy != userID && media.CreatedBy != wedding.UserID) {
+			return nil, fmt.Errorf("%w: attachment %s not found", ErrInvalidVendor, raw)
+		}
+		attachments = append(attachments, models.VendorAttachment{
+			MediaID:  media.ID,
+			Filename: media.Filename,
+			URL:      media.OriginalURL,
+			MimeType: media.MimeType,
+			Size:     media.Size,
+		})
+	}
+	return attachments, nil
+}
+
+// getVendor returns a vendor of a wedding that a member with the role sees
+func (s *VendorService) getVendor(ctx context.Context, weddingID, vendorID models.ID, role models.WeddingRole) (*models.Vendor, error) {
+	vendor, err := s.vendorRepo.GetByID(ctx, vendorID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrVendorNotFound
+		}
+		return nil, err
+	}
+	// Vendors private to the owner don't exist as far as collaborators know
+	if vendor.WeddingID != weddingID || !vendor.IsVisibleTo(role) {
+		return nil, ErrVendorNotFound
+	}
+	return vendor, nil
+}
+
+// getWeddingRole returns a wedding and the role of the user in it
+func (s *VendorService) getWeddingRole(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, models.WeddingRole, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, "", ErrWeddingNotFound
+		}
+		return nil, "", fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, "", ErrWeddingNotFound
+	}
+
+	role, ok := wedding.RoleOf(userID)
+	if !ok {
+		return nil, "", ErrUnauthorized
+	}
+	return wedding, role, nil
+}
+
+// normalizeVendorCategory lowercases a category so that filters match however it was typed
+func normalizeVendorCategory(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
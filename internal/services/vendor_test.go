@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockVendorRepository is an in-memory vendor repository
+type MockVendorRepository struct {
+	vendors map[models.ID]*models.Vendor
+}
+
+func NewMockVendorRepository() *MockVendorRepository {
+	return &MockVendorRepository{vendors: make(map[models.ID]*models.Vendor)}
+}
+
+func (m *MockVendorRepository) Create(ctx context.Context, vendor *models.Vendor) error {
+	vendor.ID = models.NewID()
+	copied := *vendor
+	m.vendors[vendor.ID] = &copied
+	return nil
+}
+
+func (m *MockVendorRepository) GetByID(ctx context.Context, id models.ID) (*models.Vendor, error) {
+	vendor, ok := m.vendors[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	copied := *vendor
+	return &copied, nil
+}
+
+func (m *MockVendorRepository) ListByWedding(ctx context.Context, weddingID models.ID, filters repository.VendorFilters) ([]*models.Vendor, error) {
+	vendors := []*models.Vendor{}
+	for _, vendor := range m.vendors {
+		if vendor.WeddingID != weddingID ||
+			(filters.Category != "" && vendor.Category != filters.Category) ||
+			(filters.ContractStatus != "" && vendor.ContractStatus != filters.ContractStatus) ||
+			(filters.SharedOnly && vendor.Sharing == models.VendorPrivate) {
+			continue
+		}
+		copied := *vendor
+		vendors = append(vendors, &copied)
+	}
+	sort.Slice(vendors, func(i, j int) bool { return vendors[i].Name < vendors[j].Name })
+	return vendors, nil
+}
+
+func (m *MockVendorRepository) CountByWedding(ctx context.Context, weddingID models.ID) (int64, error) {
+	var count int64
+	for _, vendor := range m.vendors {
+		if vendor.WeddingID == weddingID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockVendorRepository) Update(ctx context.Context, vendor *models.Vendor) error {
+	if _, ok := m.vendors[vendor.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	copied := *vendor
+	m.vendors[vendor.ID] = &copied
+	return nil
+}
+
+func (m *MockVendorRepository) Delete(ctx context.Context, id models.ID) error {
+	if _, ok := m.vendors[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.vendors, id)
+	return nil
+}
+
+func TestVendorService(t *testing.T) {
+	ownerID := models.NewID()
+	collaboratorID := models.NewID()
+	plannerID := models.NewID()
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		UserID: ownerID,
+		Collaborators: []models.WeddingCollaborator{
+			{UserID: collaboratorID, Role: models.WeddingRoleCollaborator},
+			{UserID: plannerID, Role: models.WeddingRolePlanner},
+		},
+	}
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+
+	contract := &models.Media{ID: models.NewID(), Filename: "contract.pdf", OriginalURL: "https://cdn.example.com/contract.pdf", MimeType: "application/pdf", Size: 2048, CreatedBy: ownerID}
+	deleted := time.Now()
+	strangers := &models.Media{ID: models.NewID(), Filename: "quote.pdf", CreatedBy: models.NewID()}
+	trashed := &models.Media{ID: models.NewID(), Filename: "old.pdf", CreatedBy: ownerID, DeletedAt: &deleted}
+	mediaRepo := &MockMediaRepository{}
+	for _, media := range []*models.Media{contract, strangers, trashed} {
+		mediaRepo.On("GetByID", mock.Anything, media.ID).Return(media, nil)
+	}
+
+	vendorRepo := NewMockVendorRepository()
+	service := NewVendorService(vendorRepo, weddingRepo, mediaRepo, zap.NewNop())
+	ctx := context.Background()
+
+	photographer, err := service.CreateVendor(ctx, wedding.ID, ownerID, VendorRequest{
+		Name:          "Lens & Light",
+		Category:      " Photographer ",
+		Email:         "Hello@LensLight.example",
+		AttachmentIDs: []string{contract.ID.String(), contract.ID.String()},
+		Sharing:       models.VendorSharedEdit,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "photographer", photographer.Category)
+	assert.Equal(t, "hello@lenslight.example", photographer.Email)
+	assert.Equal(t, models.VendorContractNone, photographer.ContractStatus)
+	require.Len(t, photographer.Attachments, 1)
+	assert.Equal(t, "https://cdn.example.com/contract.pdf", photographer.Attachments[0].URL)
+
+	caterer, err := service.CreateVendor(ctx, wedding.ID, ownerID, VendorRequest{Name: "Feast Co", Category: "caterer", Notes: "Still comparing quotes"})
+	require.NoError(t, err)
+	assert.Equal(t, models.VendorPrivate, caterer.Sharing)
+
+	t.Run("Success - collaborators see shared vendors only", func(t *testing.T) {
+		vendors, err := service.ListVendors(ctx, wedding.ID, ownerID, repository.VendorFilters{})
+		require.NoError(t, err)
+		assert.Len(t, vendors, 2)
+
+		vendors, err = service.ListVendors(ctx, wedding.ID, plannerID, repository.VendorFilters{})
+		require.NoError(t, err)
+		require.Len(t, vendors, 1)
+		assert.Equal(t, photographer.ID, vendors[0].ID)
+
+		_, err = service.GetVendor(ctx, wedding.ID, caterer.ID, collaboratorID)
+		assert.ErrorIs(t, err, ErrVendorNotFound)
+	})
+
+	t.Run("Success - filtered by category", func(t *testing.T) {
+		vendors, err := service.ListVendors(ctx, wedding.ID, ownerID, repository.VendorFilters{Category: "Caterer"})
+		require.NoError(t, err)
+		require.Len(t, vendors, 1)
+		assert.Equal(t, caterer.ID, vendors[0].ID)
+	})
+
+	t.Run("Success - collaborator updates a vendor shared for editing", func(t *testing.T) {
+		updated, err := service.UpdateVendor(ctx, wedding.ID, photographer.ID, collaboratorID, VendorRequest{
+			Name:           "Lens & Light",
+			Category:       "photographer",
+			ContractStatus: models.VendorContractSigned,
+			AttachmentIDs:  []string{contract.ID.String()},
+			Sharing:        models.VendorSharedEdit,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.VendorContractSigned, updated.ContractStatus)
+		assert.Len(t, updated.Attachments, 1)
+	})
+
+	t.Run("Error - collaborator changes sharing", func(t *testing.T) {
+		_, err := service.UpdateVendor(ctx, wedding.ID, photographer.ID, collaboratorID, VendorRequest{
+			Name: "Lens & Light", Category: "photographer", Sharing: models.VendorPrivate,
+		})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Error - attachments of someone else or deleted", func(t *testing.T) {
+		for _, media := range []*models.Media{strangers, trashed} {
+			_, err := service.UpdateVendor(ctx, wedding.ID, caterer.ID, ownerID, VendorRequest{
+				Name: "Feast Co", Category: "caterer", AttachmentIDs: []string{media.ID.String()},
+			})
+			assert.ErrorIs(t, err, ErrInvalidVendor)
+		}
+	})
+
+	t.Run("Error - only the owner adds and deletes vendors", func(t *testing.T) {
+		_, err := service.CreateVendor(ctx, wedding.ID, collaboratorID, VendorRequest{Name: "Bloom", Category: "florist"})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		assert.ErrorIs(t, service.DeleteVendor(ctx, wedding.ID, photographer.ID, collaboratorID), ErrUnauthorized)
+		assert.NoError(t, service.DeleteVendor(ctx, wedding.ID, photographer.ID, ownerID))
+	})
+
+	t.Run("Error - not a member", func(t *testing.T) {
+		_, err := service.ListVendors(ctx, wedding.ID, models.NewID(), repository.VendorFilters{})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+}
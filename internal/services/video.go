@@ -0,0 +1,127 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VideoInfo describes an uploaded video
+type VideoInfo struct {
+	Duration time.Duration
+	Width    int
+	Height   int
+}
+
+// VideoTranscoder inspects uploaded videos and renders their web-friendly
+// rendition and poster frame. It works on local files.
+type VideoTranscoder interface {
+	Probe(ctx context.Context, path string) (*VideoInfo, error)
+	// Transcode writes an H.264/AAC MP4 that starts playing before it is fully downloaded
+	Transcode(ctx context.Context, inputPath, outputPath string) error
+	// Poster writes the frame at the given offset as a JPEG
+	Poster(ctx context.Context, inputPath, outputPath string, at time.Duration) error
+}
+
+// renditionScale caps renditions and posters at 720p, keeping the aspect ratio
+const renditionScale = "scale=-2:'min(720,ih)'"
+
+type ffmpegTranscoder struct {
+	ffmpegPath  string
+	ffprobePath string
+}
+
+// NewFFmpegTranscoder creates a video transcoder running the ffmpeg and ffprobe binaries
+func NewFFmpegTranscoder(ffmpegPath, ffprobePath string) VideoTranscoder {
+	return &ffmpegTranscoder{ffmpegPath: ffmpegPath, ffprobePath: ffprobePath}
+}
+
+// Probe reads the duration and dimensions of a video
+func (t *ffmpegTranscoder) Probe(ctx context.Context, path string) (*VideoInfo, error) {
+	output, err := t.run(ctx, t.ffprobePath,
+		"-v", "error",
+		"-select_streams", "v:0",
+		"-show_entries", "stream=width,height:format=duration",
+		"-of", "json",
+		path)
+	if err != nil {
+		return nil, err
+	}
+	return parseProbeOutput(output)
+}
+
+// Transcode renders the web-friendly rendition of a video
+func (t *ffmpegTranscoder) Transcode(ctx context.Context, inputPath, outputPath string) error {
+	_, err := t.run(ctx, t.ffmpegPath,
+		"-y", "-i", inputPath,
+		"-vf", renditionScale,
+		"-c:v", "libx264", "-preset", "veryfast", "-crf", "23", "-pix_fmt", "yuv420p",
+		"-c:a", "aac", "-b:a", "128k",
+		"-movflags", "+faststart",
+		outputPath)
+	return err
+}
+
+// Poster extracts one frame of a video
+func (t *ffmpegTranscoder) Poster(ctx context.Context, inputPath, outputPath string, at time.Duration) error {
+	_, err := t.run(ctx, t.ffmpegPath,
+		"-y", "-ss", strconv.FormatFloat(at.Seconds(), 'f', 3, 64), "-i", inputPath,
+		"-frames:v", "1",
+		"-vf", renditionScale,
+		"-q:v", "3",
+		outputPath)
+	return err
+}
+
+func (t *ffmpegTranscoder) run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %w: %s", name, err, lastLine(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// lastLine returns the last non-empty line of tool output, which holds the error
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return lines[len(lines)-1]
+}
+
+// probeOutput is the JSON written by ffprobe -of json
+type probeOutput struct {
+	Streams []struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	} `json:"streams"`
+	Format struct {
+		Duration string `json:"duration"`
+	} `json:"format"`
+}
+
+func parseProbeOutput(output []byte) (*VideoInfo, error) {
+	var probe probeOutput
+	if err := json.Unmarshal(output, &probe); err != nil {
+		return nil, fmt.Errorf("invalid ffprobe output: %w", err)
+	}
+	if len(probe.Streams) == 0 {
+		return nil, fmt.Errorf("no video stream found")
+	}
+
+	seconds, err := strconv.ParseFloat(probe.Format.Duration, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unknown video duration: %w", err)
+	}
+	return &VideoInfo{
+		Duration: time.Duration(seconds * float64(time.Second)),
+		Width:    probe.Streams[0].Width,
+		Height:   probe.Streams[0].Height,
+	}, nil
+}
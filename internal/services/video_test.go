@@ -0,0 +1,25 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProbeOutput(t *testing.T) {
+	info, err := parseProbeOutput([]byte(`{
+		"programs": [],
+		"streams": [{"width": 1280, "height": 720}],
+		"format": {"duration": "42.500000"}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, &VideoInfo{Duration: 42500 * time.Millisecond, Width: 1280, Height: 720}, info)
+
+	_, err = parseProbeOutput([]byte(`{"streams": [], "format": {"duration": "3.0"}}`))
+	assert.EqualError(t, err, "no video stream found")
+
+	_, err = parseProbeOutput([]byte(`{"streams": [{"width": 640, "height": 480}], "format": {}}`))
+	assert.ErrorContains(t, err, "unknown video duration")
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"wedding-invitation-backend/internal/utils"
+)
+
+// internalNetworks are the ranges webhooks may not reach besides those the net
+// package classifies: "this network", shared (carrier-grade NAT) address space,
+// IETF protocol assignments and benchmarking networks
+var internalNetworks = mustParseCIDRs("0.0.0.0/8", "100.64.0.0/10", "192.0.0.0/24", "198.18.0.0/15")
+
+// isInternalAddress reports whether an address belongs to the server's own
+// network rather than the internet: loopback, private, link-local (which
+// includes cloud metadata endpoints), multicast and unspecified addresses.
+// Tests replace it to deliver to local servers.
+var isInternalAddress = func(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+	for _, network := range internalNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookResolver resolves the hosts of webhook URLs
+var webhookResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+} = net.DefaultResolver
+
+// checkWebhookHost rejects a webhook host that does not resolve, or resolves
+// to an internal address
+func checkWebhookHost(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if isInternalAddress(ip) {
+			return ErrWebhookURLNotPublic
+		}
+		return nil
+	}
+
+	addrs, err := webhookResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return ErrWebhookURLNotPublic
+	}
+	for _, addr := range addrs {
+		if isInternalAddress(addr.IP) {
+			return ErrWebhookURLNotPublic
+		}
+	}
+	return nil
+}
+
+// newWebhookClient creates the client webhooks are delivered with. It checks
+// every address it connects to, so a host resolving to an internal address
+// after its URL was validated, or a redirect to one, is refused too.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isInternalAddress(ip) {
+				return fmt.Errorf("%w: %s", ErrWebhookURLNotPublic, host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			// Connect directly: through a proxy only the proxy's address would be checked
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConnsPerHost: 2,
+		},
+	}
+}
+
+// postSignedJSON posts payload as JSON to url, signed with secret, and returns the response status.
+// Non-2xx responses are reported as errors alongside their status code.
+func postSignedJSON(ctx context.Context, client *http.Client, url, secret, event string, payload interface{}) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "wedding-invitation-webhooks/1.0")
+	req.Header.Set("X-Webhook-Event", event)
+	req.Header.Set(utils.SignatureTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(utils.SignatureHeader, utils.SignPayload(secret, timestamp, body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWebhookResolver resolves webhook hosts from a fixed table instead of DNS
+type fakeWebhookResolver map[string]string
+
+func (r fakeWebhookResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ip, ok := r[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return []net.IPAddr{{IP: net.ParseIP(ip)}}, nil
+}
+
+// stubWebhookNetwork resolves the example hosts of webhook tests without DNS,
+// intranet.example.com to a private address, and lets webhooks reach test
+// servers on loopback
+func stubWebhookNetwork(t *testing.T) {
+	resolver, internal := webhookResolver, isInternalAddress
+	t.Cleanup(func() { webhookResolver, isInternalAddress = resolver, internal })
+
+	webhookResolver = fakeWebhookResolver{
+		"hooks.example.com":    "93.184.216.34",
+		"crm.example.com":      "93.184.216.34",
+		"tenant.example.com":   "93.184.216.34",
+		"globex.example.com":   "93.184.216.34",
+		"push.example.com":     "93.184.216.34",
+		"intranet.example.com": "10.1.2.3",
+	}
+	isInternalAddress = func(ip net.IP) bool { return !ip.IsLoopback() && internal(ip) }
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	resolver := webhookResolver
+	t.Cleanup(func() { webhookResolver = resolver })
+	webhookResolver = fakeWebhookResolver{
+		"hooks.example.com":    "93.184.216.34",
+		"rebound.example.com":  "127.0.0.1",
+		"metadata.example.com": "169.254.169.254",
+	}
+	ctx := context.Background()
+
+	assert.NoError(t, validateWebhookURL(ctx, "https://hooks.example.com/wedding"))
+	assert.NoError(t, validateWebhookURL(ctx, "http://93.184.216.34:8080/hooks"))
+	assert.ErrorIs(t, validateWebhookURL(ctx, "ftp://hooks.example.com"), ErrInvalidWebhookURL)
+
+	for _, rawURL := range []string{
+		"http://127.0.0.1:8080/hooks",
+		"http://[::1]/hooks",
+		"http://10.0.0.7/hooks",
+		"http://192.168.1.1/hooks",
+		"http://172.16.0.1/hooks",
+		"http://100.64.0.1/hooks",
+		"http://0.0.0.0/hooks",
+		"http://[fd00::1]/hooks",
+		"http://[::ffff:127.0.0.1]/hooks",
+		"http://169.254.169.254/latest/meta-data",
+		"https://rebound.example.com/hooks",
+		"https://metadata.example.com/hooks",
+		"https://unknown.example.com/hooks",
+	} {
+		assert.ErrorIs(t, validateWebhookURL(ctx, rawURL), ErrWebhookURLNotPublic, rawURL)
+	}
+}
+
+func TestWebhookClient_RefusesInternalAddresses(t *testing.T) {
+	var reached bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	}))
+	defer server.Close()
+
+	// A host that passed validation but resolves to the server's network when delivered to
+	status, err := postSignedJSON(context.Background(), newWebhookClient(), server.URL, "secret", "test", map[string]string{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrWebhookURLNotPublic)
+	assert.Zero(t, status)
+	assert.False(t, reached)
+}
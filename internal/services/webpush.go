@@ -0,0 +1,246 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+const (
+	// webPushRecordSize is the aes128gcm record size; a notification fits one record
+	webPushRecordSize = 4096
+	// webPushMaxPayload is the largest payload push services must accept (RFC 8291)
+	webPushMaxPayload = 3993
+	// webPushTTL is how long a push service keeps a notification for an offline browser
+	webPushTTL = 24 * time.Hour
+	// vapidTokenLifetime is how long the VAPID token of a push is valid, at most 24 hours
+	vapidTokenLifetime = 12 * time.Hour
+)
+
+// WebPushError is a push service refusing a notification
+type WebPushError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *WebPushError) Error() string {
+	return fmt.Sprintf("push service returned %d: %s", e.StatusCode, e.Body)
+}
+
+// WebPushSender delivers encrypted notifications to the push service of a browser
+type WebPushSender interface {
+	// PublicKey is the VAPID application server key browsers subscribe with
+	PublicKey() string
+	Send(ctx context.Context, subscription *models.PushSubscription, payload []byte) error
+}
+
+// VAPIDSender sends Web Push notifications encrypted with aes128gcm (RFC 8291)
+// and identified to push services with VAPID (RFC 8292)
+type VAPIDSender struct {
+	privateKey *ecdsa.PrivateKey
+	publicKey  []byte
+	subject    string
+	httpClient *http.Client
+}
+
+// NewVAPIDSender creates a sender from a base64url encoded P-256 private key.
+// The subject is a mailto: or https: contact push services may reach out to.
+func NewVAPIDSender(privateKey, subject string) (*VAPIDSender, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	key, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+
+	// The uncompressed public key is 0x04 || X || Y
+	public := key.PublicKey().Bytes()
+	signingKey := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(public[1:33]),
+			Y:     new(big.Int).SetBytes(public[33:]),
+		},
+		D: new(big.Int).SetBytes(raw),
+	}
+
+	return &VAPIDSender{
+		privateKey: signingKey,
+		publicKey:  public,
+		subject:    subject,
+		// Endpoints come from browsers, so like webhooks they may not reach internal networks
+		httpClient: newWebhookClient(),
+	}, nil
+}
+
+// PublicKey returns the base64url encoded application server key
+func (s *VAPIDSender) PublicKey() string {
+	return base64.RawURLEncoding.EncodeToString(s.publicKey)
+}
+
+// Send encrypts the payload for the subscription and posts it to its push service
+func (s *VAPIDSender) Send(ctx context.Context, subscription *models.PushSubscription, payload []byte) error {
+	body, err := encryptWebPush(subscription, payload)
+	if err != nil {
+		return err
+	}
+	authorization, err := s.authorization(subscription.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create push request: %w", err)
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(webPushTTL.Seconds())))
+	req.Header.Set("Urgency", "normal")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach push service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return &WebPushError{StatusCode: resp.StatusCode, Body: string(message)}
+}
+
+// authorization signs a VAPID token for the push service of an endpoint
+func (s *VAPIDSender) authorization(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid push endpoint %q", endpoint)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.RegisteredClaims{
+		Audience:  jwt.ClaimStrings{u.Scheme + "://" + u.Host},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(vapidTokenLifetime)),
+		Subject:   s.subject,
+	})
+	signed, err := token.SignedString(s.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+	return "vapid t=" + signed + ", k=" + s.PublicKey(), nil
+}
+
+// encryptWebPush encrypts a payload for a subscription as a single aes128gcm record
+func encryptWebPush(subscription *models.PushSubscription, payload []byte) ([]byte, error) {
+	if len(payload) > webPushMaxPayload {
+		return nil, fmt.Errorf("push payload of %d bytes exceeds %d", len(payload), webPushMaxPayload)
+	}
+
+	userAgentKey, err := decodeWebPushKey(subscription.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key: %w", err)
+	}
+	userAgentPublic, err := ecdh.P256().NewPublicKey(userAgentKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key: %w", err)
+	}
+	authSecret, err := decodeWebPushKey(subscription.Auth)
+	if err != nil || len(authSecret) != 16 {
+		return nil, errors.New("invalid subscription auth secret")
+	}
+
+	// Every message is encrypted with a fresh key pair and salt
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate push key: %w", err)
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate push salt: %w", err)
+	}
+
+	sharedSecret, err := serverKey.ECDH(userAgentPublic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive push secret: %w", err)
+	}
+	serverPublic := serverKey.PublicKey().Bytes()
+
+	cek, nonce, err := deriveWebPushKeys(sharedSecret, authSecret, salt, userAgentKey, serverPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	// The 0x02 delimiter marks the last record, without further padding
+	plaintext := append(append(make([]byte, 0, len(payload)+1), payload...), 0x02)
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+
+	// Header: salt || record size || key id length || key id (the server public key)
+	body := make([]byte, 0, 16+4+1+len(serverPublic)+len(ciphertext))
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, webPushRecordSize)
+	body = append(body, byte(len(serverPublic)))
+	body = append(body, serverPublic...)
+	return append(body, ciphertext...), nil
+}
+
+// deriveWebPushKeys derives the content encryption key and nonce of a message
+// from the ECDH secret and the subscription's auth secret (RFC 8291 section 3.4)
+func deriveWebPushKeys(sharedSecret, authSecret, salt, userAgentPublic, serverPublic []byte) ([]byte, []byte, error) {
+	keyInfo := "WebPush: info\x00" + string(userAgentPublic) + string(serverPublic)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive push key: %w", err)
+	}
+	cek, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive push key: %w", err)
+	}
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive push nonce: %w", err)
+	}
+	return cek, nonce, nil
+}
+
+// decodeWebPushKey decodes a subscription key, which browsers encode as base64url
+// without padding but some clients pad or send as standard base64
+func decodeWebPushKey(key string) ([]byte, error) {
+	for _, encoding := range []*base64.Encoding{base64.RawURLEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.StdEncoding} {
+		if decoded, err := encoding.DecodeString(key); err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, errors.New("key is not base64")
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// newTestVAPIDKey generates a base64url VAPID private key
+func newTestVAPIDKey(t *testing.T) string {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(key.Bytes())
+}
+
+// newTestBrowser generates the keys of a subscribed browser, returning its
+// private key and auth secret to decrypt what is pushed to it
+func newTestBrowser(t *testing.T, endpoint string) (*models.PushSubscription, *ecdh.PrivateKey, []byte) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	auth := make([]byte, 16)
+	_, err = rand.Read(auth)
+	require.NoError(t, err)
+
+	subscription := &models.PushSubscription{
+		ID:       models.NewID(),
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(auth),
+	}
+	return subscription, key, auth
+}
+
+// decryptWebPush decrypts an aes128gcm body the way a browser does
+func decryptWebPush(t *testing.T, body []byte, key *ecdh.PrivateKey, auth []byte) []byte {
+	require.Greater(t, len(body), 21)
+	salt := body[:16]
+	assert.Equal(t, uint32(webPushRecordSize), binary.BigEndian.Uint32(body[16:20]))
+	idLen := int(body[20])
+	serverPublic := body[21 : 21+idLen]
+
+	serverKey, err := ecdh.P256().NewPublicKey(serverPublic)
+	require.NoError(t, err)
+	sharedSecret, err := key.ECDH(serverKey)
+	require.NoError(t, err)
+
+	cek, nonce, err := deriveWebPushKeys(sharedSecret, auth, salt, key.PublicKey().Bytes(), serverPublic)
+	require.NoError(t, err)
+	block, err := aes.NewCipher(cek)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	plaintext, err := gcm.Open(nil, nonce, body[21+idLen:], nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, plaintext)
+	assert.Equal(t, byte(0x02), plaintext[len(plaintext)-1])
+	return plaintext[:len(plaintext)-1]
+}
+
+func TestVAPIDSender_Send(t *testing.T) {
+	stubWebhookNetwork(t)
+	sender, err := NewVAPIDSender(newTestVAPIDKey(t), "mailto:ops@example.com")
+	require.NoError(t, err)
+
+	var received []byte
+	var authorization, encoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		authorization = r.Header.Get("Authorization")
+		encoding = r.Header.Get("Content-Encoding")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	subscription, browserKey, auth := newTestBrowser(t, server.URL+"/push/abc123")
+	payload := []byte(`{"title":"Alice & Bob","body":"Carol is attending"}`)
+	require.NoError(t, sender.Send(context.Background(), subscription, payload))
+
+	t.Run("Only the browser decrypts the payload", func(t *testing.T) {
+		assert.Equal(t, "aes128gcm", encoding)
+		assert.Equal(t, payload, decryptWebPush(t, received, browserKey, auth))
+	})
+
+	t.Run("Signs a VAPID token for the push service", func(t *testing.T) {
+		require.True(t, strings.HasPrefix(authorization, "vapid t="))
+		parts := strings.SplitN(strings.TrimPrefix(authorization, "vapid t="), ", k=", 2)
+		require.Len(t, parts, 2)
+		assert.Equal(t, sender.PublicKey(), parts[1])
+
+		public, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		verifyKey := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(public[1:33]),
+			Y:     new(big.Int).SetBytes(public[33:]),
+		}
+
+		claims := jwt.RegisteredClaims{}
+		_, err = jwt.ParseWithClaims(parts[0], &claims, func(token *jwt.Token) (interface{}, error) {
+			return verifyKey, nil
+		}, jwt.WithValidMethods([]string{"ES256"}), jwt.WithAudience(server.URL))
+		require.NoError(t, err)
+		assert.Equal(t, "mailto:ops@example.com", claims.Subject)
+	})
+
+	t.Run("Returns the refusal of the push service", func(t *testing.T) {
+		gone := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer gone.Close()
+
+		subscription, _, _ := newTestBrowser(t, gone.URL+"/push/expired")
+		err := sender.Send(context.Background(), subscription, payload)
+		var pushErr *WebPushError
+		require.ErrorAs(t, err, &pushErr)
+		assert.Equal(t, http.StatusGone, pushErr.StatusCode)
+	})
+
+	t.Run("Refuses endpoints in internal networks", func(t *testing.T) {
+		subscription, _, _ := newTestBrowser(t, "https://10.0.0.5/push/abc123")
+		err := sender.Send(context.Background(), subscription, payload)
+		assert.ErrorIs(t, err, ErrWebhookURLNotPublic)
+	})
+}
+
+func TestNewVAPIDSender_InvalidKey(t *testing.T) {
+	_, err := NewVAPIDSender("not-a-key", "mailto:ops@example.com")
+	assert.Error(t, err)
+}
+
+func TestDeriveWebPushKeys_RFC8291Example(t *testing.T) {
+	// The example message of RFC 8291 appendix A, decrypted by its user agent
+	decode := func(s string) []byte {
+		decoded, err := base64.RawURLEncoding.DecodeString(s)
+		require.NoError(t, err)
+		return decoded
+	}
+	key, err := ecdh.P256().NewPrivateKey(decode("q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"))
+	require.NoError(t, err)
+	body := decode("DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN")
+
+	plaintext := decryptWebPush(t, body, key, decode("BTBZMqHH6r4Tts7J_aSIgg"))
+	assert.Equal(t, "When I grow up, I want to be a watermelon", string(plaintext))
+}
@@ -4,18 +4,46 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"strings"
 	"time"
+	"wedding-invitation-backend/internal/domain/errs"
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
 	"wedding-invitation-backend/internal/utils"
 )
 
+var (
+	// ErrInvalidPublicWeddingFilter is returned for unsupported showcase search options
+	ErrInvalidPublicWeddingFilter = errors.New("invalid public wedding filter")
+	// ErrDuplicateEventSession is returned when two sessions of a wedding share an ID
+	ErrDuplicateEventSession = errors.New("event sessions must have distinct IDs")
+	// ErrInvalidEventTimezone is returned for an event time zone that is not a known IANA zone
+	ErrInvalidEventTimezone = errors.New("event timezone must be an IANA time zone, e.g. Asia/Jakarta")
+	// ErrInvalidEventEnd is returned for an event that ends before it starts
+	ErrInvalidEventEnd = errors.New("event end date must be after its start date")
+	// ErrWeddingTakenDown is returned when publishing a wedding an admin took down
+	ErrWeddingTakenDown = errors.New("wedding was taken down by an administrator")
+	// ErrInvalidWeddingLanguage is returned for a wedding language or translation that is not a BCP 47 tag
+	ErrInvalidWeddingLanguage = errors.New("wedding languages must be BCP 47 tags, e.g. en or id")
+	// ErrOwnLanguageTranslation is returned for a translation into the language the wedding is written in
+	ErrOwnLanguageTranslation = errors.New("translations must be in another language than the wedding's own")
+	// ErrWeddingAccessDenied is returned to users who may not see or change a wedding
+	ErrWeddingAccessDenied = errs.Forbidden("access denied")
+	// ErrWeddingNotPublished is returned for public requests of a wedding that is not published
+	ErrWeddingNotPublished = errs.NotFound("wedding not published")
+)
+
 // WeddingService provides business logic for wedding management
 type WeddingService struct {
 	weddingRepo repository.WeddingRepository
 	userRepo    repository.UserRepository
+	events      LifecycleEventPublisher
+	limits      PlanLimiter
+	themes      repository.ThemeRepository
+	webhooks    WeddingEventNotifier
+	audit       AuditRecorder
+	revisions   repository.WeddingRevisionRepository
+	redirects   repository.SlugRedirectRepository
 }
 
 // NewWeddingService creates a new wedding service
@@ -26,15 +54,51 @@ func NewWeddingService(weddingRepo repository.WeddingRepository, userRepo reposi
 	}
 }
 
+// SetEventPublisher enables lifecycle events for tenant webhooks. When set, new
+// weddings also inherit their owner's tenant.
+func (s *WeddingService) SetEventPublisher(events LifecycleEventPublisher) {
+	s.events = events
+}
+
+// SetWebhookNotifier sends a wedding.published event to the wedding's own webhooks
+func (s *WeddingService) SetWebhookNotifier(notifier WeddingEventNotifier) {
+	s.webhooks = notifier
+}
+
+// SetAuditLog records the creation, changes and deletion of weddings in the audit log
+func (s *WeddingService) SetAuditLog(audit AuditRecorder) {
+	s.audit = audit
+}
+
+// SetPlanLimiter enforces the limits of the owner's plan on the number of weddings
+// and their themes
+func (s *WeddingService) SetPlanLimiter(limits PlanLimiter) {
+	s.limits = limits
+}
+
+// SetThemeCatalog checks the themes of weddings against the theme catalog. When
+// set, new weddings without a theme get the catalog's default theme.
+func (s *WeddingService) SetThemeCatalog(themes repository.ThemeRepository) {
+	s.themes = themes
+}
+
 // CreateWedding creates a new wedding
-func (s *WeddingService) CreateWedding(ctx context.Context, wedding *models.Wedding, userID primitive.ObjectID) error {
+func (s *WeddingService) CreateWedding(ctx context.Context, wedding *models.Wedding, userID models.ID) error {
 	// Validate wedding data
 	if err := s.validateWedding(wedding, true); err != nil {
 		return err
 	}
 
+	if s.limits != nil {
+		if err := s.limits.CheckWeddingLimit(ctx, userID); err != nil {
+			return err
+		}
+	}
+
 	// Set user ID
 	wedding.UserID = userID
+	// Only admins moderate weddings
+	wedding.Moderation = nil
 
 	// Generate unique slug if not provided
 	if wedding.Slug == "" {
@@ -43,15 +107,8 @@ func (s *WeddingService) CreateWedding(ctx context.Context, wedding *models.Wedd
 			return fmt.Errorf("failed to generate slug: %w", err)
 		}
 		wedding.Slug = slug
-	} else {
-		// Check if slug is available
-		exists, err := s.weddingRepo.ExistsBySlug(ctx, wedding.Slug)
-		if err != nil {
-			return fmt.Errorf("failed to check slug availability: %w", err)
-		}
-		if exists {
-			return errors.New("slug already exists")
-		}
+	} else if err := s.checkSlug(ctx, wedding.Slug, models.NilID); err != nil {
+		return err
 	}
 
 	// Set default values
@@ -63,14 +120,21 @@ func (s *WeddingService) CreateWedding(ctx context.Context, wedding *models.Wedd
 	wedding.GalleryEnabled = false
 	wedding.IsPublic = false
 
+	// Set default theme if not provided
+	if wedding.Theme.ThemeID == "" && s.themes != nil {
+		themeID, err := s.defaultThemeID(ctx)
+		if err != nil {
+			return err
+		}
+		wedding.Theme.ThemeID = themeID
+	}
+
 	// Validate theme settings
 	if err := s.validateThemeSettings(&wedding.Theme); err != nil {
 		return err
 	}
-
-	// Set default theme if not provided
-	if wedding.Theme.ThemeID == "" {
-		wedding.Theme.ThemeID = "default"
+	if err := s.checkTheme(ctx, userID, wedding.Theme.ThemeID); err != nil {
+		return err
 	}
 
 	// Validate RSVP settings
@@ -78,11 +142,24 @@ func (s *WeddingService) CreateWedding(ctx context.Context, wedding *models.Wedd
 		return err
 	}
 
+	// Inherit the owner's tenant, which scoped requests carry already, for
+	// tenant isolation and lifecycle events
+	if tenantID, ok := repository.TenantFromContext(ctx); ok {
+		wedding.TenantID = tenantID
+	} else if s.events != nil {
+		if owner, err := s.userRepo.GetByID(ctx, userID); err == nil && owner != nil {
+			wedding.TenantID = owner.TenantID
+		}
+	}
+
 	// Create wedding
 	if err := s.weddingRepo.Create(ctx, wedding); err != nil {
 		return fmt.Errorf("failed to create wedding: %w", err)
 	}
 
+	s.publish(ctx, models.LifecycleWeddingCreated, wedding)
+	s.recordAudit(ctx, models.AuditWeddingCreate, wedding.ID, auditChanges(nil, auditFields(wedding)))
+
 	// Add wedding ID to user's weddings list
 	if err := s.userRepo.AddWeddingID(ctx, userID, wedding.ID); err != nil {
 		// Log error but don't fail the operation
@@ -93,23 +170,23 @@ func (s *WeddingService) CreateWedding(ctx context.Context, wedding *models.Wedd
 }
 
 // GetWeddingByID retrieves a wedding by ID
-func (s *WeddingService) GetWeddingByID(ctx context.Context, id primitive.ObjectID, requestingUserID primitive.ObjectID) (*models.Wedding, error) {
+func (s *WeddingService) GetWeddingByID(ctx context.Context, id models.ID, requestingUserID models.ID) (*models.Wedding, error) {
 	wedding, err := s.weddingRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get wedding: %w", err)
 	}
 
 	if wedding == nil {
-		return nil, errors.New("wedding not found")
+		return nil, ErrWeddingNotFound
 	}
 
 	// Check access permissions
 	if !s.canAccessWedding(wedding, requestingUserID) {
-		return nil, errors.New("access denied")
+		return nil, ErrWeddingAccessDenied
 	}
 
-	// Increment view count if not the owner
-	if wedding.UserID != requestingUserID {
+	// Increment view count if not the owner or a collaborator
+	if _, member := wedding.RoleOf(requestingUserID); !member {
 		if err := s.weddingRepo.IncrementViewCount(ctx, id); err != nil {
 			// Log error but don't fail the request
 		}
@@ -118,24 +195,42 @@ func (s *WeddingService) GetWeddingByID(ctx context.Context, id primitive.Object
 	return wedding, nil
 }
 
+// GetWeddingsByIDs retrieves the weddings with the given IDs in one query, by
+// ID, leaving out those that do not exist or the user cannot access. Unlike
+// GetWeddingByID it does not count views, since it serves the dashboard.
+func (s *WeddingService) GetWeddingsByIDs(ctx context.Context, ids []models.ID, requestingUserID models.ID) (map[models.ID]*models.Wedding, error) {
+	weddings, err := s.weddingRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weddings: %w", err)
+	}
+
+	byID := make(map[models.ID]*models.Wedding, len(weddings))
+	for _, wedding := range weddings {
+		if s.canAccessWedding(wedding, requestingUserID) {
+			byID[wedding.ID] = wedding
+		}
+	}
+	return byID, nil
+}
+
 // GetWeddingBySlug retrieves a wedding by slug
-func (s *WeddingService) GetWeddingBySlug(ctx context.Context, slug string, requestingUserID primitive.ObjectID) (*models.Wedding, error) {
+func (s *WeddingService) GetWeddingBySlug(ctx context.Context, slug string, requestingUserID models.ID) (*models.Wedding, error) {
 	wedding, err := s.weddingRepo.GetBySlug(ctx, slug)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get wedding: %w", err)
 	}
 
 	if wedding == nil {
-		return nil, errors.New("wedding not found")
+		return nil, ErrWeddingNotFound
 	}
 
 	// Check access permissions
 	if !s.canAccessWedding(wedding, requestingUserID) {
-		return nil, errors.New("access denied")
+		return nil, ErrWeddingAccessDenied
 	}
 
-	// Increment view count if not the owner
-	if wedding.UserID != requestingUserID {
+	// Increment view count if not the owner or a collaborator
+	if _, member := wedding.RoleOf(requestingUserID); !member {
 		if err := s.weddingRepo.IncrementViewCount(ctx, wedding.ID); err != nil {
 			// Log error but don't fail the request
 		}
@@ -145,7 +240,7 @@ func (s *WeddingService) GetWeddingBySlug(ctx context.Context, slug string, requ
 }
 
 // GetUserWeddings retrieves all weddings for a user with pagination
-func (s *WeddingService) GetUserWeddings(ctx context.Context, userID primitive.ObjectID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error) {
+func (s *WeddingService) GetUserWeddings(ctx context.Context, userID models.ID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error) {
 	// Validate pagination parameters
 	if page < 1 {
 		page = 1
@@ -163,7 +258,13 @@ func (s *WeddingService) GetUserWeddings(ctx context.Context, userID primitive.O
 }
 
 // UpdateWedding updates an existing wedding
-func (s *WeddingService) UpdateWedding(ctx context.Context, wedding *models.Wedding, requestingUserID primitive.ObjectID) error {
+func (s *WeddingService) UpdateWedding(ctx context.Context, wedding *models.Wedding, requestingUserID models.ID) error {
+	return s.updateWedding(ctx, wedding, requestingUserID, nil)
+}
+
+// updateWedding updates an existing wedding, keeping its previous content as a
+// revision. restoredFrom is the revision a rollback restores.
+func (s *WeddingService) updateWedding(ctx context.Context, wedding *models.Wedding, requestingUserID models.ID, restoredFrom *int) error {
 	// Get existing wedding
 	existingWedding, err := s.weddingRepo.GetByID(ctx, wedding.ID)
 	if err != nil {
@@ -171,12 +272,12 @@ func (s *WeddingService) UpdateWedding(ctx context.Context, wedding *models.Wedd
 	}
 
 	if existingWedding == nil {
-		return errors.New("wedding not found")
+		return ErrWeddingNotFound
 	}
 
-	// Check ownership
-	if existingWedding.UserID != requestingUserID {
-		return errors.New("access denied")
+	// Check the user may edit the wedding
+	if !existingWedding.Can(requestingUserID, models.PermissionEditWedding) {
+		return ErrWeddingAccessDenied
 	}
 
 	// Validate wedding data
@@ -186,12 +287,8 @@ func (s *WeddingService) UpdateWedding(ctx context.Context, wedding *models.Wedd
 
 	// Check if slug changed and is available
 	if wedding.Slug != existingWedding.Slug {
-		exists, err := s.weddingRepo.ExistsBySlug(ctx, wedding.Slug)
-		if err != nil {
-			return fmt.Errorf("failed to check slug availability: %w", err)
-		}
-		if exists {
-			return errors.New("slug already exists")
+		if err := s.checkSlug(ctx, wedding.Slug, wedding.ID); err != nil {
+			return err
 		}
 	}
 
@@ -199,14 +296,28 @@ func (s *WeddingService) UpdateWedding(ctx context.Context, wedding *models.Wedd
 	if err := s.validateThemeSettings(&wedding.Theme); err != nil {
 		return err
 	}
+	// Weddings keep a theme withdrawn from the catalog until they change it
+	if wedding.Theme.ThemeID != existingWedding.Theme.ThemeID {
+		if err := s.checkTheme(ctx, existingWedding.UserID, wedding.Theme.ThemeID); err != nil {
+			return err
+		}
+	}
 
 	// Validate RSVP settings
 	if err := s.validateRSVPSettings(&wedding.RSVP); err != nil {
 		return err
 	}
 
+	if err := validateEventSchedule(wedding); err != nil {
+		return err
+	}
+
 	// Preserve certain fields that shouldn't be changed via update
 	wedding.UserID = existingWedding.UserID
+	wedding.TenantID = existingWedding.TenantID
+	wedding.Collaborators = existingWedding.Collaborators
+	wedding.CollaboratorInvites = existingWedding.CollaboratorInvites
+	wedding.Moderation = existingWedding.Moderation
 	wedding.CreatedAt = existingWedding.CreatedAt
 	wedding.ViewCount = existingWedding.ViewCount
 	wedding.RSVPCount = existingWedding.RSVPCount
@@ -220,16 +331,38 @@ func (s *WeddingService) UpdateWedding(ctx context.Context, wedding *models.Wedd
 		}
 	}
 
+	if err := s.saveRevision(ctx, existingWedding, wedding, requestingUserID, restoredFrom); err != nil {
+		return err
+	}
+	// Links shared with the old slug keep working after a rename
+	renamed := wedding.Slug != existingWedding.Slug
+	if err := s.keepOldSlug(ctx, wedding, existingWedding.Slug); err != nil {
+		return err
+	}
+
 	// Update wedding
 	if err := s.weddingRepo.Update(ctx, wedding); err != nil {
 		return fmt.Errorf("failed to update wedding: %w", err)
 	}
+	s.recordAudit(ctx, models.AuditWeddingUpdate, wedding.ID, auditChanges(auditFields(existingWedding), auditFields(wedding)))
+
+	// A wedding renamed back to an old slug no longer needs its redirect
+	if s.redirects != nil && renamed {
+		if err := s.redirects.Delete(ctx, wedding.Slug); err != nil {
+			// Log error but don't fail the operation
+		}
+	}
+
+	if wedding.Status == string(models.WeddingStatusPublished) && existingWedding.Status != string(models.WeddingStatusPublished) {
+		s.publish(ctx, models.LifecycleWeddingPublished, wedding)
+		s.notifyWebhooks(ctx, models.WeddingEventWeddingPublished, wedding)
+	}
 
 	return nil
 }
 
 // DeleteWedding deletes a wedding
-func (s *WeddingService) DeleteWedding(ctx context.Context, weddingID primitive.ObjectID, requestingUserID primitive.ObjectID) error {
+func (s *WeddingService) DeleteWedding(ctx context.Context, weddingID models.ID, requestingUserID models.ID) error {
 	// Get wedding to check ownership
 	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
 	if err != nil {
@@ -237,41 +370,55 @@ func (s *WeddingService) DeleteWedding(ctx context.Context, weddingID primitive.
 	}
 
 	if wedding == nil {
-		return errors.New("wedding not found")
+		return ErrWeddingNotFound
 	}
 
-	// Check ownership
-	if wedding.UserID != requestingUserID {
-		return errors.New("access denied")
+	// Only the owner may delete the wedding
+	if !wedding.Can(requestingUserID, models.PermissionDeleteWedding) {
+		return ErrWeddingAccessDenied
 	}
 
 	// Delete wedding
 	if err := s.weddingRepo.Delete(ctx, weddingID); err != nil {
 		return fmt.Errorf("failed to delete wedding: %w", err)
 	}
+	s.recordAudit(ctx, models.AuditWeddingDelete, weddingID, auditChanges(auditFields(wedding), nil))
+
+	// Release the old slugs of the wedding
+	if s.redirects != nil {
+		if err := s.redirects.DeleteByWedding(ctx, weddingID); err != nil {
+			// Log error but don't fail the operation
+		}
+	}
 
 	// Remove wedding ID from user's weddings list
 	if err := s.userRepo.RemoveWeddingID(ctx, requestingUserID, weddingID); err != nil {
 		// Log error but don't fail the operation
 	}
 
+	s.publish(ctx, models.LifecycleWeddingDeleted, wedding)
+
 	return nil
 }
 
 // PublishWedding publishes a wedding
-func (s *WeddingService) PublishWedding(ctx context.Context, weddingID primitive.ObjectID, requestingUserID primitive.ObjectID) error {
+func (s *WeddingService) PublishWedding(ctx context.Context, weddingID models.ID, requestingUserID models.ID) error {
 	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
 	if err != nil {
 		return fmt.Errorf("failed to get wedding: %w", err)
 	}
 
 	if wedding == nil {
-		return errors.New("wedding not found")
+		return ErrWeddingNotFound
 	}
 
-	// Check ownership
-	if wedding.UserID != requestingUserID {
-		return errors.New("access denied")
+	// Check the user may edit the wedding
+	if !wedding.Can(requestingUserID, models.PermissionEditWedding) {
+		return ErrWeddingAccessDenied
+	}
+
+	if wedding.IsTakenDown() {
+		return ErrWeddingTakenDown
 	}
 
 	// Validate wedding is ready for publishing
@@ -280,6 +427,7 @@ func (s *WeddingService) PublishWedding(ctx context.Context, weddingID primitive
 	}
 
 	// Update status and publish date
+	before := auditFields(wedding)
 	now := time.Now()
 	wedding.Status = string(models.WeddingStatusPublished)
 	wedding.PublishedAt = &now
@@ -289,6 +437,10 @@ func (s *WeddingService) PublishWedding(ctx context.Context, weddingID primitive
 	if err := s.weddingRepo.Update(ctx, wedding); err != nil {
 		return fmt.Errorf("failed to publish wedding: %w", err)
 	}
+	s.recordAudit(ctx, models.AuditWeddingPublish, weddingID, auditChanges(before, auditFields(wedding)))
+
+	s.publish(ctx, models.LifecycleWeddingPublished, wedding)
+	s.notifyWebhooks(ctx, models.WeddingEventWeddingPublished, wedding)
 
 	return nil
 }
@@ -303,6 +455,10 @@ func (s *WeddingService) ListPublicWeddings(ctx context.Context, page, pageSize
 		pageSize = 20
 	}
 
+	if err := validatePublicWeddingFilters(filters); err != nil {
+		return nil, 0, err
+	}
+
 	weddings, total, err := s.weddingRepo.ListPublic(ctx, page, pageSize, filters)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get public weddings: %w", err)
@@ -313,6 +469,29 @@ func (s *WeddingService) ListPublicWeddings(ctx context.Context, page, pageSize
 
 // Helper functions
 
+func validatePublicWeddingFilters(filters repository.PublicWeddingFilters) error {
+	switch filters.Sort {
+	case "", repository.PublicWeddingSortDate, repository.PublicWeddingSortRecent, repository.PublicWeddingSortMostViewed:
+	case repository.PublicWeddingSortRelevance:
+		if strings.TrimSpace(filters.Search) == "" {
+			return fmt.Errorf("%w: relevance sort requires a search term", ErrInvalidPublicWeddingFilter)
+		}
+	default:
+		return fmt.Errorf("%w: unknown sort %q", ErrInvalidPublicWeddingFilter, filters.Sort)
+	}
+
+	switch filters.When {
+	case "", repository.PublicWeddingsUpcoming, repository.PublicWeddingsPast:
+	default:
+		return fmt.Errorf("%w: unknown date range %q", ErrInvalidPublicWeddingFilter, filters.When)
+	}
+
+	if filters.DateFrom != nil && filters.DateTo != nil && filters.DateFrom.After(*filters.DateTo) {
+		return fmt.Errorf("%w: date_from must not be after date_to", ErrInvalidPublicWeddingFilter)
+	}
+	return nil
+}
+
 func (s *WeddingService) validateWedding(wedding *models.Wedding, isNew bool) error {
 	// Validate basic required fields
 	if wedding.Title == "" {
@@ -351,6 +530,18 @@ func (s *WeddingService) validateWedding(wedding *models.Wedding, isNew bool) er
 		return errors.New("event date is required")
 	}
 
+	if err := validateEventSchedule(wedding); err != nil {
+		return err
+	}
+
+	if err := normalizeLanguages(wedding); err != nil {
+		return err
+	}
+
+	if err := normalizeMusicSettings(&wedding.Music); err != nil {
+		return err
+	}
+
 	// Validate status
 	validStatuses := []string{
 		string(models.WeddingStatusDraft),
@@ -366,6 +557,63 @@ func (s *WeddingService) validateWedding(wedding *models.Wedding, isNew bool) er
 	return nil
 }
 
+// normalizeLanguages puts the language of a wedding and of its translations in
+// their canonical form, so that "ID" and "id" are one language
+func normalizeLanguages(wedding *models.Wedding) error {
+	if wedding.Language != "" {
+		lang, err := utils.NormalizeLanguage(wedding.Language)
+		if err != nil {
+			return ErrInvalidWeddingLanguage
+		}
+		wedding.Language = lang
+	}
+
+	if len(wedding.Translations) == 0 {
+		return nil
+	}
+	translations := make(map[string]models.WeddingTranslation, len(wedding.Translations))
+	for tag, translation := range wedding.Translations {
+		lang, err := utils.NormalizeLanguage(tag)
+		if err != nil {
+			return ErrInvalidWeddingLanguage
+		}
+		if lang == wedding.ContentLanguage() {
+			return ErrOwnLanguageTranslation
+		}
+		translations[lang] = translation
+	}
+	wedding.Translations = translations
+	return nil
+}
+
+// validateEventSchedule checks that the sessions of a wedding can be told
+// apart and that its events can be put on a calendar
+func validateEventSchedule(wedding *models.Wedding) error {
+	seen := make(map[string]bool, len(wedding.Sessions))
+	for _, session := range wedding.Sessions {
+		if seen[session.ID] {
+			return ErrDuplicateEventSession
+		}
+		seen[session.ID] = true
+	}
+
+	events := []models.EventDetails{wedding.Event}
+	for _, session := range wedding.Sessions {
+		events = append(events, session.EventDetails)
+	}
+	for _, event := range events {
+		if event.Timezone != "" {
+			if _, err := time.LoadLocation(event.Timezone); err != nil || event.Timezone == "Local" {
+				return ErrInvalidEventTimezone
+			}
+		}
+		if event.EndDate != nil && !event.EndDate.After(event.Date) {
+			return ErrInvalidEventEnd
+		}
+	}
+	return nil
+}
+
 func (s *WeddingService) validateThemeSettings(theme *models.ThemeSettings) error {
 	if theme.ThemeID == "" {
 		return errors.New("theme ID is required")
@@ -393,6 +641,43 @@ func (s *WeddingService) validateThemeSettings(theme *models.ThemeSettings) erro
 	return nil
 }
 
+// defaultThemeID returns the catalog's default theme
+func (s *WeddingService) defaultThemeID(ctx context.Context) (string, error) {
+	themes, err := s.themes.List(ctx, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to list themes: %w", err)
+	}
+	for _, theme := range themes {
+		if theme.IsDefault {
+			return theme.ID, nil
+		}
+	}
+	return "", errors.New("theme ID is required")
+}
+
+// checkTheme checks the theme is available in the catalog and to the owner's plan
+func (s *WeddingService) checkTheme(ctx context.Context, userID models.ID, themeID string) error {
+	if s.themes == nil {
+		return nil
+	}
+
+	theme, err := s.themes.GetByID(ctx, themeID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("%w: %s", ErrThemeNotFound, themeID)
+		}
+		return fmt.Errorf("failed to get theme: %w", err)
+	}
+	if !theme.Active {
+		return fmt.Errorf("%w: %s", ErrThemeNotFound, themeID)
+	}
+
+	if s.limits != nil {
+		return s.limits.CheckTheme(ctx, userID, theme)
+	}
+	return nil
+}
+
 func (s *WeddingService) validateRSVPSettings(rsvp *models.RSVPSettings) error {
 	if rsvp.Enabled {
 		// Validate max plus ones
@@ -400,21 +685,52 @@ func (s *WeddingService) validateRSVPSettings(rsvp *models.RSVPSettings) error {
 			return errors.New("max plus ones must be between 0 and 5")
 		}
 
-		// Validate custom questions
-		for i, q := range rsvp.CustomQuestions {
+		// Meal options are what guests choose from, so they must be told apart
+		meals := make(map[string]bool, len(rsvp.MealOptions))
+		for _, meal := range rsvp.MealOptions {
+			if meal == "" || meals[meal] {
+				return errors.New("meal options must be distinct and not empty")
+			}
+			meals[meal] = true
+		}
+
+		// Validate custom questions, giving new ones an ID answers can refer to
+		ids := make(map[string]bool, len(rsvp.CustomQuestions))
+		for i := range rsvp.CustomQuestions {
+			q := &rsvp.CustomQuestions[i]
 			if q.Question == "" {
 				return fmt.Errorf("custom question %d: question is required", i+1)
 			}
 
-			validTypes := []string{"text", "textarea", "select", "checkbox", "radio"}
-			if !utils.Contains(validTypes, q.Type) {
+			if q.Kind() == "" {
 				return fmt.Errorf("custom question %d: invalid question type", i+1)
 			}
 
-			// For select/radio types, options are required
-			if (q.Type == "select" || q.Type == "radio") && len(q.Options) == 0 {
-				return fmt.Errorf("custom question %d: options are required for %s type", i+1, q.Type)
+			// Choice questions need distinct options to choose from
+			if q.IsChoice() {
+				if len(q.Options) == 0 {
+					return fmt.Errorf("custom question %d: options are required for %s type", i+1, q.Type)
+				}
+				options := make(map[string]bool, len(q.Options))
+				for _, option := range q.Options {
+					if option == "" || options[option] {
+						return fmt.Errorf("custom question %d: options must be distinct and not empty", i+1)
+					}
+					options[option] = true
+				}
+			}
+
+			if q.Min != nil && q.Max != nil && *q.Min > *q.Max {
+				return fmt.Errorf("custom question %d: min must not be greater than max", i+1)
+			}
+
+			if q.ID == "" {
+				q.ID = models.NewID().String()
+			}
+			if ids[q.ID] {
+				return fmt.Errorf("custom question %d: duplicate question ID %s", i+1, q.ID)
 			}
+			ids[q.ID] = true
 		}
 	}
 
@@ -447,33 +763,12 @@ func (s *WeddingService) generateUniqueSlug(ctx context.Context, title string) (
 	baseSlug := strings.ToLower(strings.ReplaceAll(title, " ", "-"))
 	baseSlug = utils.SanitizeSlug(baseSlug)
 
-	// If base slug is available, use it
-	exists, err := s.weddingRepo.ExistsBySlug(ctx, baseSlug)
-	if err != nil {
-		return "", err
-	}
-	if !exists {
-		return baseSlug, nil
-	}
-
-	// Try with random suffix
-	for i := 1; i <= 100; i++ {
-		candidateSlug := fmt.Sprintf("%s-%d", baseSlug, i)
-		exists, err := s.weddingRepo.ExistsBySlug(ctx, candidateSlug)
-		if err != nil {
-			return "", err
-		}
-		if !exists {
-			return candidateSlug, nil
-		}
-	}
-
-	return "", errors.New("failed to generate unique slug")
+	return s.nextFreeSlug(ctx, baseSlug, models.NilID)
 }
 
-func (s *WeddingService) canAccessWedding(wedding *models.Wedding, requestingUserID primitive.ObjectID) bool {
-	// Owner can always access
-	if wedding.UserID == requestingUserID {
+func (s *WeddingService) canAccessWedding(wedding *models.Wedding, requestingUserID models.ID) bool {
+	// Owner and collaborators can always access
+	if _, member := wedding.RoleOf(requestingUserID); member {
 		return true
 	}
 
@@ -493,6 +788,9 @@ func (s *WeddingService) canAccessWedding(wedding *models.Wedding, requestingUse
 func (s *WeddingService) handleStatusChange(ctx context.Context, newWedding *models.Wedding, oldWedding *models.Wedding) error {
 	// Handle transition to published
 	if newWedding.Status == string(models.WeddingStatusPublished) && oldWedding.Status != string(models.WeddingStatusPublished) {
+		if oldWedding.IsTakenDown() {
+			return ErrWeddingTakenDown
+		}
 		now := time.Now()
 		newWedding.PublishedAt = &now
 
@@ -513,12 +811,12 @@ func (s *WeddingService) GetWeddingBySlugForPublic(ctx context.Context, slug str
 	}
 
 	if wedding == nil {
-		return nil, errors.New("wedding not found")
+		return nil, ErrWeddingNotFound
 	}
 
 	// Check if wedding is published
 	if wedding.Status != string(models.WeddingStatusPublished) {
-		return nil, errors.New("wedding not published")
+		return nil, ErrWeddingNotPublished
 	}
 
 	// Increment view count for public access
@@ -528,3 +826,47 @@ func (s *WeddingService) GetWeddingBySlugForPublic(ctx context.Context, slug str
 
 	return wedding, nil
 }
+
+// publish records a lifecycle event for the wedding's tenant, if any
+func (s *WeddingService) publish(ctx context.Context, eventType models.LifecycleEventType, wedding *models.Wedding) {
+	if s.events == nil || wedding.TenantID == "" {
+		return
+	}
+	// Event publishing must not fail the wedding operation
+	_ = s.events.Publish(ctx, &models.LifecycleEvent{
+		TenantID:  wedding.TenantID,
+		Type:      eventType,
+		SubjectID: wedding.ID,
+		Data: map[string]interface{}{
+			"slug":    wedding.Slug,
+			"title":   wedding.Title,
+			"user_id": wedding.UserID.String(),
+		},
+		OccurredAt: time.Now(),
+	})
+}
+
+// notifyWebhooks sends an event to the wedding's own webhooks, if enabled
+func (s *WeddingService) notifyWebhooks(ctx context.Context, eventType models.WeddingEventType, wedding *models.Wedding) {
+	if s.webhooks == nil {
+		return
+	}
+	s.webhooks.NotifyWeddingEvent(ctx, wedding.ID, eventType, map[string]interface{}{
+		"slug":  wedding.Slug,
+		"title": wedding.Title,
+	})
+}
+
+// recordAudit records a change of a wedding when the audit log is enabled
+func (s *WeddingService) recordAudit(ctx context.Context, action string, weddingID models.ID, changes []models.AuditChange) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, &models.AuditLog{
+		Action:     action,
+		TargetType: models.AuditTargetWedding,
+		TargetID:   weddingID.String(),
+		WeddingID:  &weddingID,
+		Changes:    changes,
+	})
+}
@@ -0,0 +1,376 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"html/template"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+const (
+	// maxModerationReasonLength bounds the reason admins give for a moderation action
+	maxModerationReasonLength = 1000
+	// maxAbuseReportDetailsLength bounds the details of an abuse report
+	maxAbuseReportDetailsLength = 2000
+)
+
+var (
+	// ErrModerationReasonRequired is returned for a takedown or unpublish without a reason
+	ErrModerationReasonRequired = errors.New("a reason of at most 1000 characters is required")
+	// ErrWeddingNotTakenDown is returned when restoring a wedding that was not taken down
+	ErrWeddingNotTakenDown = errors.New("wedding is not taken down")
+	// ErrInvalidAbuseReport is returned for an abuse report with an unknown category or overlong details
+	ErrInvalidAbuseReport = errors.New("invalid abuse report")
+	// ErrAbuseReportNotFound is returned for an unknown abuse report
+	ErrAbuseReportNotFound = errs.NotFound("abuse report not found")
+	// ErrInvalidAbuseReportStatus is returned when closing a report with a status other than resolved or dismissed
+	ErrInvalidAbuseReportStatus = errors.New("abuse reports can only be resolved or dismissed")
+)
+
+// AbuseReportRequest is a visitor's report of a published wedding
+type AbuseReportRequest struct {
+	Category      models.AbuseReportCategory `json:"category" validate:"required"`
+	Details       string                     `json:"details" validate:"max=2000"`
+	ReporterEmail string                     `json:"reporter_email" validate:"omitempty,email"`
+	IP            string                     `json:"-"` // Set by the handler
+}
+
+// AdminWeddingDetail is a wedding with its owner and open abuse reports, for admins
+type AdminWeddingDetail struct {
+	Wedding     *models.Wedding `json:"wedding"`
+	Owner       *models.User    `json:"owner,omitempty"`
+	OpenReports int64           `json:"open_reports"`
+}
+
+// WeddingModerationService lets admins review every wedding, unpublish, flag
+// and take down weddings that break the rules, and handle the abuse reports
+// visitors file against published weddings. Owners are emailed when their
+// wedding is taken down.
+type WeddingModerationService struct {
+	weddingRepo repository.WeddingRepository
+	userRepo    repository.UserRepository
+	reportRepo  repository.AbuseReportRepository
+	email       EmailService
+	audit       AuditRecorder
+	logger      *zap.Logger
+}
+
+// NewWeddingModerationService creates a new wedding moderation service
+func NewWeddingModerationService(weddingRepo repository.WeddingRepository, userRepo repository.UserRepository, reportRepo repository.AbuseReportRepository, email EmailService, logger *zap.Logger) *WeddingModerationService {
+	return &WeddingModerationService{
+		weddingRepo: weddingRepo,
+		userRepo:    userRepo,
+		reportRepo:  reportRepo,
+		email:       email,
+		logger:      logger,
+	}
+}
+
+// SetAuditLog records moderation actions in the audit log
+func (s *WeddingModerationService) SetAuditLog(audit AuditRecorder) {
+	s.audit = audit
+}
+
+// ListWeddings lists all weddings matching the filters, newest first
+func (s *WeddingModerationService) ListWeddings(ctx context.Context, page, pageSize int, filters repository.AdminWeddingFilters) ([]*models.Wedding, int64, error) {
+	weddings, total, err := s.weddingRepo.List(ctx, page, pageSize, filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list weddings: %w", err)
+	}
+	return weddings, total, nil
+}
+
+// GetWedding returns a wedding with its owner's account and its open abuse reports
+func (s *WeddingModerationService) GetWedding(ctx context.Context, weddingID models.ID) (*AdminWeddingDetail, error) {
+	wedding, err := s.getWedding(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &AdminWeddingDetail{Wedding: wedding}
+	// The owner may have deleted their account
+	if owner, err := s.userRepo.GetByID(ctx, wedding.UserID); err == nil {
+		detail.Owner = owner
+	}
+	if detail.OpenReports, err = s.reportRepo.CountOpenByWedding(ctx, weddingID); err != nil {
+		return nil, err
+	}
+	return detail, nil
+}
+
+// Unpublish returns a published wedding to draft. The owner may publish it again.
+func (s *WeddingModerationService) Unpublish(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error) {
+	reason, err := moderationReason(reason, true)
+	if err != nil {
+		return nil, err
+	}
+	return s.moderate(ctx, weddingID, adminID, models.ModerationUnpublish, reason, func(w *models.Wedding) {
+		w.Status = string(models.WeddingStatusDraft)
+	})
+}
+
+// Flag marks a wedding for review without changing what visitors see
+func (s *WeddingModerationService) Flag(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error) {
+	reason, err := moderationReason(reason, false)
+	if err != nil {
+		return nil, err
+	}
+	return s.moderate(ctx, weddingID, adminID, models.ModerationFlag, reason, func(w *models.Wedding) {
+		now := time.Now()
+		w.Moderation.Flagged = true
+		w.Moderation.FlagReason = reason
+		w.Moderation.FlaggedAt = &now
+	})
+}
+
+// Unflag clears a wedding's review flag
+func (s *WeddingModerationService) Unflag(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error) {
+	reason, err := moderationReason(reason, false)
+	if err != nil {
+		return nil, err
+	}
+	return s.moderate(ctx, weddingID, adminID, models.ModerationUnflag, reason, func(w *models.Wedding) {
+		w.Moderation.Flagged = false
+		w.Moderation.FlagReason = ""
+		w.Moderation.FlaggedAt = nil
+	})
+}
+
+// TakeDown unpublishes a wedding and keeps its owner from publishing it again,
+// then emails the owner the reason
+func (s *WeddingModerationService) TakeDown(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error) {
+	reason, err := moderationReason(reason, true)
+	if err != nil {
+		return nil, err
+	}
+	wedding, err := s.moderate(ctx, weddingID, adminID, models.ModerationTakedown, reason, func(w *models.Wedding) {
+		now := time.Now()
+		w.Status = string(models.WeddingStatusDraft)
+		w.Moderation.TakenDown = true
+		w.Moderation.TakedownReason = reason
+		w.Moderation.TakenDownAt = &now
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyOwner(ctx, wedding, reason)
+	return wedding, nil
+}
+
+// Restore lifts a takedown so that the owner may publish the wedding again.
+// The wedding stays in draft.
+func (s *WeddingModerationService) Restore(ctx context.Context, weddingID, adminID models.ID, reason string) (*models.Wedding, error) {
+	reason, err := moderationReason(reason, false)
+	if err != nil {
+		return nil, err
+	}
+	wedding, err := s.getWedding(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+	if !wedding.IsTakenDown() {
+		return nil, ErrWeddingNotTakenDown
+	}
+	return s.apply(ctx, wedding, &adminID, models.ModerationRestore, reason, func(w *models.Wedding) {
+		w.Moderation.TakenDown = false
+		w.Moderation.TakedownReason = ""
+		w.Moderation.TakenDownAt = nil
+	})
+}
+
+// ReportAbuse files a visitor's abuse report against a published wedding and
+// flags the wedding for review
+func (s *WeddingModerationService) ReportAbuse(ctx context.Context, slug string, req AbuseReportRequest) (*models.AbuseReport, error) {
+	details := strings.TrimSpace(req.Details)
+	if !req.Category.IsValid() || utf8.RuneCountInString(details) > maxAbuseReportDetailsLength {
+		return nil, ErrInvalidAbuseReport
+	}
+
+	wedding, err := s.weddingRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	// The wedding repository reports a missing wedding as nil
+	if wedding == nil || !wedding.IsAccessible() {
+		return nil, ErrWeddingNotFound
+	}
+
+	report := &models.AbuseReport{
+		WeddingID:     wedding.ID,
+		WeddingSlug:   wedding.Slug,
+		Category:      req.Category,
+		Details:       details,
+		ReporterEmail: strings.ToLower(strings.TrimSpace(req.ReporterEmail)),
+		ReporterIP:    req.IP,
+		Status:        models.AbuseReportOpen,
+	}
+	if err := s.reportRepo.Create(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to save abuse report: %w", err)
+	}
+
+	// Reported weddings are flagged once; the report is kept even if flagging fails
+	if wedding.Moderation == nil || !wedding.Moderation.Flagged {
+		reason := "abuse report: " + string(req.Category)
+		_, err := s.apply(ctx, wedding, nil, models.ModerationFlag, reason, func(w *models.Wedding) {
+			now := time.Now()
+			w.Moderation.Flagged = true
+			w.Moderation.FlagReason = reason
+			w.Moderation.FlaggedAt = &now
+		})
+		if err != nil {
+			s.logger.Warn("Failed to flag reported wedding",
+				zap.String("wedding_id", wedding.ID.String()),
+				zap.Error(err))
+		}
+	}
+	return report, nil
+}
+
+// ListAbuseReports lists the abuse reports matching the filters, newest first
+func (s *WeddingModerationService) ListAbuseReports(ctx context.Context, filters repository.AbuseReportFilters, page, pageSize int) ([]*models.AbuseReport, int64, error) {
+	return s.reportRepo.List(ctx, filters, page, pageSize)
+}
+
+// CloseAbuseReport resolves or dismisses an abuse report with the admin's note
+func (s *WeddingModerationService) CloseAbuseReport(ctx context.Context, reportID, adminID models.ID, status models.AbuseReportStatus, note string) (*models.AbuseReport, error) {
+	if status != models.AbuseReportResolved && status != models.AbuseReportDismissed {
+		return nil, ErrInvalidAbuseReportStatus
+	}
+	note, err := moderationReason(note, false)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.reportRepo.GetByID(ctx, reportID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrAbuseReportNotFound
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	report.Status = status
+	report.ResolutionNote = note
+	report.ResolvedBy = &adminID
+	report.ResolvedAt = &now
+	if err := s.reportRepo.Update(ctx, report); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrAbuseReportNotFound
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+// moderate loads a wedding and applies a moderation action to it
+func (s *WeddingModerationService) moderate(ctx context.Context, weddingID, adminID models.ID, action models.ModerationActionType, reason string, change func(*models.Wedding)) (*models.Wedding, error) {
+	wedding, err := s.getWedding(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+	return s.apply(ctx, wedding, &adminID, action, reason, change)
+}
+
+// apply changes a wedding, records the action in its moderation history and
+// saves it. adminID is nil for actions taken on behalf of visitors.
+func (s *WeddingModerationService) apply(ctx context.Context, wedding *models.Wedding, adminID *models.ID, action models.ModerationActionType, reason string, change func(*models.Wedding)) (*models.Wedding, error) {
+	before := auditFields(wedding)
+	if wedding.Moderation == nil {
+		wedding.Moderation = &models.WeddingModeration{}
+	}
+	change(wedding)
+	wedding.Moderation.History = append(wedding.Moderation.History, models.ModerationAction{
+		Action:    action,
+		Reason:    reason,
+		AdminID:   adminID,
+		CreatedAt: time.Now(),
+	})
+
+	if err := s.weddingRepo.Update(ctx, wedding); err != nil {
+		return nil, fmt.Errorf("failed to update wedding: %w", err)
+	}
+
+	if s.audit != nil {
+		s.audit.Record(ctx, &models.AuditLog{
+			Action:     models.AuditWeddingModerate,
+			TargetType: models.AuditTargetWedding,
+			TargetID:   wedding.ID.String(),
+			WeddingID:  &wedding.ID,
+			Changes:    auditChanges(before, auditFields(wedding)),
+			Metadata:   map[string]interface{}{"action": string(action), "reason": reason},
+		})
+	}
+	return wedding, nil
+}
+
+// notifyOwner emails the owner that their wedding was taken down. Failures are
+// logged, since the takedown itself succeeded.
+func (s *WeddingModerationService) notifyOwner(ctx context.Context, wedding *models.Wedding, reason string) {
+	if s.email == nil {
+		return
+	}
+	owner, err := s.userRepo.GetByID(ctx, wedding.UserID)
+	if err != nil || owner == nil || owner.Email == "" {
+		s.logger.Warn("Failed to find owner of taken down wedding",
+			zap.String("wedding_id", wedding.ID.String()),
+			zap.Error(err))
+		return
+	}
+	if err := s.email.Send(ctx, takedownEmail(owner, wedding, reason)); err != nil {
+		s.logger.Warn("Failed to email takedown notice",
+			zap.String("wedding_id", wedding.ID.String()),
+			zap.Error(err))
+	}
+}
+
+func takedownEmail(owner *models.User, wedding *models.Wedding, reason string) *EmailMessage {
+	return &EmailMessage{
+		To:      owner.Email,
+		Subject: fmt.Sprintf("Your wedding page %s was taken down", wedding.Title),
+		HTML: fmt.Sprintf(`<p>Hi %s,</p>
+<p>Your wedding page <strong>%s</strong> (/%s) was taken down by our moderators and is no longer visible to guests.</p>
+<p>Reason: %s</p>
+<p style="font-size: 12px; color: #888;">Reply to this email if you believe this was a mistake.</p>`,
+			template.HTMLEscapeString(owner.FirstName), template.HTMLEscapeString(wedding.Title),
+			template.HTMLEscapeString(wedding.Slug), template.HTMLEscapeString(reason)),
+		Text: fmt.Sprintf("Hi %s,\n\nYour wedding page %s (/%s) was taken down by our moderators and is no longer visible to guests.\n\nReason: %s\n\nReply to this email if you believe this was a mistake.\n",
+			owner.FirstName, wedding.Title, wedding.Slug, reason),
+	}
+}
+
+func (s *WeddingModerationService) getWedding(ctx context.Context, weddingID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	// The wedding repository reports a missing wedding as nil
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	return wedding, nil
+}
+
+// moderationReason trims a moderation reason and checks its length
+func moderationReason(reason string, required bool) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if (required && reason == "") || utf8.RuneCountInString(reason) > maxModerationReasonLength {
+		return "", ErrModerationReasonRequired
+	}
+	return reason, nil
+}
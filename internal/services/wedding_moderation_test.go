@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockAbuseReportRepository is an in-memory abuse report repository
+type MockAbuseReportRepository struct {
+	reports []*models.AbuseReport
+}
+
+func (m *MockAbuseReportRepository) Create(ctx context.Context, report *models.AbuseReport) error {
+	report.ID = models.NewID()
+	m.reports = append(m.reports, report)
+	return nil
+}
+
+func (m *MockAbuseReportRepository) GetByID(ctx context.Context, id models.ID) (*models.AbuseReport, error) {
+	for _, report := range m.reports {
+		if report.ID == id {
+			copied := *report
+			return &copied, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *MockAbuseReportRepository) List(ctx context.Context, filters repository.AbuseReportFilters, page, pageSize int) ([]*models.AbuseReport, int64, error) {
+	reports := []*models.AbuseReport{}
+	for i := len(m.reports) - 1; i >= 0; i-- {
+		if filters.Status == "" || m.reports[i].Status == filters.Status {
+			reports = append(reports, m.reports[i])
+		}
+	}
+	return reports, int64(len(reports)), nil
+}
+
+func (m *MockAbuseReportRepository) Update(ctx context.Context, report *models.AbuseReport) error {
+	for i := range m.reports {
+		if m.reports[i].ID == report.ID {
+			m.reports[i] = report
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func (m *MockAbuseReportRepository) CountOpenByWedding(ctx context.Context, weddingID models.ID) (int64, error) {
+	var count int64
+	for _, report := range m.reports {
+		if report.WeddingID == weddingID && report.Status == models.AbuseReportOpen {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func setupWeddingModerationService(t *testing.T) (*WeddingModerationService, *MockWeddingRepository, *MockAbuseReportRepository, *MockEmailService, *MockAuditLogRepository, *models.Wedding) {
+	owner := &models.User{ID: models.NewID(), Email: "owner@example.com", FirstName: "Dana"}
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		UserID: owner.ID,
+		Slug:   "dana-and-sam",
+		Title:  "Dana & Sam",
+		Status: string(models.WeddingStatusPublished),
+	}
+
+	weddingRepo := &MockWeddingRepository{}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+	weddingRepo.On("GetBySlug", mock.Anything, wedding.Slug).Return(wedding, nil)
+	weddingRepo.On("Update", mock.Anything, wedding).Return(nil)
+	userRepo := &MockUserRepository{}
+	userRepo.On("GetByID", mock.Anything, owner.ID).Return(owner, nil)
+
+	reports := &MockAbuseReportRepository{}
+	email := &MockEmailService{}
+	auditRepo := &MockAuditLogRepository{}
+	service := NewWeddingModerationService(weddingRepo, userRepo, reports, email, zaptest.NewLogger(t))
+	service.SetAuditLog(NewAuditLogService(auditRepo, weddingRepo, zaptest.NewLogger(t)))
+	return service, weddingRepo, reports, email, auditRepo, wedding
+}
+
+func TestWeddingModerationService_TakeDown(t *testing.T) {
+	ctx := context.Background()
+	adminID := models.NewID()
+	service, weddingRepo, _, email, auditRepo, wedding := setupWeddingModerationService(t)
+
+	_, err := service.TakeDown(ctx, wedding.ID, adminID, "  ")
+	assert.ErrorIs(t, err, ErrModerationReasonRequired)
+
+	taken, err := service.TakeDown(ctx, wedding.ID, adminID, "Selling counterfeit goods")
+	require.NoError(t, err)
+	assert.Equal(t, string(models.WeddingStatusDraft), taken.Status)
+	assert.True(t, taken.IsTakenDown())
+	assert.False(t, taken.IsAccessible())
+	assert.Equal(t, "Selling counterfeit goods", taken.Moderation.TakedownReason)
+	require.Len(t, taken.Moderation.History, 1)
+	assert.Equal(t, models.ModerationTakedown, taken.Moderation.History[0].Action)
+	assert.Equal(t, &adminID, taken.Moderation.History[0].AdminID)
+
+	// The owner is told why
+	require.Len(t, email.sent, 1)
+	assert.Equal(t, "owner@example.com", email.sent[0].To)
+	assert.Contains(t, email.sent[0].Text, "Selling counterfeit goods")
+
+	require.Len(t, auditRepo.entries, 1)
+	assert.Equal(t, models.AuditWeddingModerate, auditRepo.entries[0].Action)
+	assert.Equal(t, "takedown", auditRepo.entries[0].Metadata["action"])
+
+	// The owner can't publish it again until it is restored
+	weddings := NewWeddingService(weddingRepo, &MockUserRepository{})
+	assert.ErrorIs(t, weddings.PublishWedding(ctx, wedding.ID, wedding.UserID), ErrWeddingTakenDown)
+
+	restored, err := service.Restore(ctx, wedding.ID, adminID, "")
+	require.NoError(t, err)
+	assert.False(t, restored.IsTakenDown())
+	assert.Equal(t, string(models.WeddingStatusDraft), restored.Status)
+
+	_, err = service.Restore(ctx, wedding.ID, adminID, "")
+	assert.ErrorIs(t, err, ErrWeddingNotTakenDown)
+}
+
+func TestWeddingModerationService_UnpublishAndFlag(t *testing.T) {
+	ctx := context.Background()
+	adminID := models.NewID()
+	service, weddingRepo, _, email, _, wedding := setupWeddingModerationService(t)
+	missingID := models.NewID()
+	weddingRepo.On("GetByID", mock.Anything, missingID).Return(nil, repository.ErrNotFound)
+
+	flagged, err := service.Flag(ctx, wedding.ID, adminID, "Looks like spam")
+	require.NoError(t, err)
+	assert.True(t, flagged.Moderation.Flagged)
+	assert.Equal(t, string(models.WeddingStatusPublished), flagged.Status)
+
+	unpublished, err := service.Unpublish(ctx, wedding.ID, adminID, "Placeholder content")
+	require.NoError(t, err)
+	assert.Equal(t, string(models.WeddingStatusDraft), unpublished.Status)
+	assert.False(t, unpublished.IsTakenDown())
+
+	unflagged, err := service.Unflag(ctx, wedding.ID, adminID, "")
+	require.NoError(t, err)
+	assert.False(t, unflagged.Moderation.Flagged)
+	assert.Len(t, unflagged.Moderation.History, 3)
+	assert.Empty(t, email.sent)
+
+	_, err = service.Flag(ctx, missingID, adminID, "")
+	assert.ErrorIs(t, err, ErrWeddingNotFound)
+}
+
+func TestWeddingModerationService_AbuseReports(t *testing.T) {
+	ctx := context.Background()
+	adminID := models.NewID()
+	service, weddingRepo, reports, _, _, wedding := setupWeddingModerationService(t)
+	weddingRepo.On("GetBySlug", mock.Anything, "missing").Return(nil, repository.ErrNotFound)
+
+	_, err := service.ReportAbuse(ctx, wedding.Slug, AbuseReportRequest{Category: "rude"})
+	assert.ErrorIs(t, err, ErrInvalidAbuseReport)
+	_, err = service.ReportAbuse(ctx, "missing", AbuseReportRequest{Category: models.AbuseCategorySpam})
+	assert.ErrorIs(t, err, ErrWeddingNotFound)
+
+	report, err := service.ReportAbuse(ctx, wedding.Slug, AbuseReportRequest{
+		Category:      models.AbuseCategoryScam,
+		Details:       " Asks guests to wire money ",
+		ReporterEmail: "Guest@Example.com",
+		IP:            "203.0.113.7",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.AbuseReportOpen, report.Status)
+	assert.Equal(t, "Asks guests to wire money", report.Details)
+	assert.Equal(t, "guest@example.com", report.ReporterEmail)
+
+	// Reported weddings are flagged for review once
+	require.NotNil(t, wedding.Moderation)
+	assert.True(t, wedding.Moderation.Flagged)
+	assert.Nil(t, wedding.Moderation.History[0].AdminID)
+	_, err = service.ReportAbuse(ctx, wedding.Slug, AbuseReportRequest{Category: models.AbuseCategorySpam})
+	require.NoError(t, err)
+	assert.Len(t, wedding.Moderation.History, 1)
+
+	detail, err := service.GetWedding(ctx, wedding.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, detail.OpenReports)
+	assert.Equal(t, "owner@example.com", detail.Owner.Email)
+
+	_, err = service.CloseAbuseReport(ctx, report.ID, adminID, models.AbuseReportOpen, "")
+	assert.ErrorIs(t, err, ErrInvalidAbuseReportStatus)
+	closed, err := service.CloseAbuseReport(ctx, report.ID, adminID, models.AbuseReportResolved, "Taken down")
+	require.NoError(t, err)
+	assert.Equal(t, models.AbuseReportResolved, closed.Status)
+	assert.Equal(t, &adminID, closed.ResolvedBy)
+	_, err = service.CloseAbuseReport(ctx, models.NewID(), adminID, models.AbuseReportDismissed, "")
+	assert.ErrorIs(t, err, ErrAbuseReportNotFound)
+
+	open, total, err := service.ListAbuseReports(ctx, repository.AbuseReportFilters{Status: models.AbuseReportOpen}, 1, 20)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+	assert.Equal(t, models.AbuseCategorySpam, open[0].Category)
+	assert.Len(t, reports.reports, 2)
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+const (
+	// maxWeddingRevisions is how many revisions of a wedding are kept; older ones are pruned
+	maxWeddingRevisions = 50
+	// maxRevisionFieldDepth bounds how deep changed fields are reported, e.g. rsvp.meal_options
+	maxRevisionFieldDepth = 2
+)
+
+var (
+	ErrRevisionNotFound = errs.NotFound("wedding revision not found")
+	// ErrNothingToRestore is returned for a revision whose content the wedding already has
+	ErrNothingToRestore = errors.New("the wedding already has the content of this revision")
+)
+
+// EnableRevisions keeps the content of a wedding before each update, so that
+// an update that broke the invitation can be rolled back
+func (s *WeddingService) EnableRevisions(revisions repository.WeddingRevisionRepository) {
+	s.revisions = revisions
+}
+
+// ListRevisions lists the revisions of a wedding the user may edit, newest
+// first, with the fields each update changed
+func (s *WeddingService) ListRevisions(ctx context.Context, weddingID, userID models.ID) ([]*models.WeddingRevision, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+	if s.revisions == nil {
+		return []*models.WeddingRevision{}, nil
+	}
+
+	revisions, err := s.revisions.ListByWedding(ctx, weddingID, maxWeddingRevisions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wedding revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// GetRevision returns a revision of a wedding the user may edit, with the
+// content the wedding had before the revision's update
+func (s *WeddingService) GetRevision(ctx context.Context, weddingID, userID models.ID, number int) (*models.WeddingRevision, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+	return s.getRevision(ctx, weddingID, number)
+}
+
+// RestoreRevision rolls the content of a wedding back to what it was before
+// the revision's update. The wedding keeps its status, slug and visibility, and
+// the rollback is itself recorded as a revision, so it can be undone.
+func (s *WeddingService) RestoreRevision(ctx context.Context, weddingID, userID models.ID, number int) (*models.Wedding, error) {
+	current, err := s.getEditableWedding(ctx, weddingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.getRevision(ctx, weddingID, number)
+	if err != nil {
+		return nil, err
+	}
+
+	restored := *revision.Snapshot
+	restored.ID = current.ID
+	restored.Status = current.Status
+	restored.PublishedAt = current.PublishedAt
+	restored.Slug = current.Slug
+	restored.IsPublic = current.IsPublic
+	restored.PasswordHash = current.PasswordHash
+	if len(revisionChangedFields(auditFields(current), auditFields(&restored))) == 0 {
+		return nil, ErrNothingToRestore
+	}
+
+	if err := s.updateWedding(ctx, &restored, userID, &revision.Number); err != nil {
+		return nil, err
+	}
+	return &restored, nil
+}
+
+// saveRevision keeps the wedding as it was before an update that changes it,
+// and prunes the revisions beyond maxWeddingRevisions
+func (s *WeddingService) saveRevision(ctx context.Context, before, after *models.Wedding, userID models.ID, restoredFrom *int) error {
+	if s.revisions == nil {
+		return nil
+	}
+	changed := revisionChangedFields(auditFields(before), auditFields(after))
+	if len(changed) == 0 {
+		return nil
+	}
+
+	revision := &models.WeddingRevision{
+		WeddingID:     before.ID,
+		ChangedFields: changed,
+		RestoredFrom:  restoredFrom,
+		Snapshot:      before,
+		CreatedBy:     userID,
+	}
+	if err := s.revisions.Create(ctx, revision); err != nil {
+		return fmt.Errorf("failed to save wedding revision: %w", err)
+	}
+	if revision.Number > maxWeddingRevisions {
+		// Pruning is best effort; the oldest revisions go with the next update
+		_ = s.revisions.DeleteBefore(ctx, before.ID, revision.Number-maxWeddingRevisions+1)
+	}
+	return nil
+}
+
+func (s *WeddingService) getRevision(ctx context.Context, weddingID models.ID, number int) (*models.WeddingRevision, error) {
+	if s.revisions == nil {
+		return nil, ErrRevisionNotFound
+	}
+	revision, err := s.revisions.GetByNumber(ctx, weddingID, number)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding revision: %w", err)
+	}
+	if revision.Snapshot == nil {
+		return nil, ErrRevisionNotFound
+	}
+	return revision, nil
+}
+
+func (s *WeddingService) getEditableWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	if !wedding.Can(userID, models.PermissionEditWedding) {
+		return nil, ErrUnauthorized
+	}
+	return wedding, nil
+}
+
+// revisionChangedFields lists the JSON paths that differ between two snapshots
+// taken by auditFields, descending into objects up to maxRevisionFieldDepth
+func revisionChangedFields(before, after map[string]interface{}) []string {
+	var changed []string
+	collectChangedFields("", before, after, 1, &changed)
+	sort.Strings(changed)
+	return changed
+}
+
+func collectChangedFields(prefix string, before, after map[string]interface{}, depth int, changed *[]string) {
+	for name := range unionKeys(before, after) {
+		if depth == 1 && auditUnchangedFields[name] {
+			continue
+		}
+		from, to := before[name], after[name]
+		if reflect.DeepEqual(from, to) {
+			continue
+		}
+
+		fromFields, fromIsObject := from.(map[string]interface{})
+		toFields, toIsObject := to.(map[string]interface{})
+		if depth < maxRevisionFieldDepth && fromIsObject && toIsObject {
+			collectChangedFields(prefix+name+".", fromFields, toFields, depth+1, changed)
+			continue
+		}
+		*changed = append(*changed, prefix+name)
+	}
+}
+
+func unionKeys(a, b map[string]interface{}) map[string]struct{} {
+	keys := make(map[string]struct{}, len(a)+len(b))
+	for key := range a {
+		keys[key] = struct{}{}
+	}
+	for key := range b {
+		keys[key] = struct{}{}
+	}
+	return keys
+}
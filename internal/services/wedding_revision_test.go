@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockWeddingRevisionRepository is an in-memory wedding revision repository
+type MockWeddingRevisionRepository struct {
+	revisions []*models.WeddingRevision
+}
+
+func (m *MockWeddingRevisionRepository) Create(ctx context.Context, revision *models.WeddingRevision) error {
+	revision.ID = models.NewID()
+	revision.Number = 1
+	for _, existing := range m.revisions {
+		if existing.WeddingID == revision.WeddingID && existing.Number >= revision.Number {
+			revision.Number = existing.Number + 1
+		}
+	}
+	revision.CreatedAt = time.Now()
+	copied := *revision
+	snapshot := *revision.Snapshot
+	copied.Snapshot = &snapshot
+	m.revisions = append(m.revisions, &copied)
+	return nil
+}
+
+func (m *MockWeddingRevisionRepository) GetByNumber(ctx context.Context, weddingID models.ID, number int) (*models.WeddingRevision, error) {
+	for _, revision := range m.revisions {
+		if revision.WeddingID == weddingID && revision.Number == number {
+			copied := *revision
+			return &copied, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *MockWeddingRevisionRepository) ListByWedding(ctx context.Context, weddingID models.ID, limit int) ([]*models.WeddingRevision, error) {
+	revisions := []*models.WeddingRevision{}
+	for _, revision := range m.revisions {
+		if revision.WeddingID == weddingID {
+			copied := *revision
+			copied.Snapshot = nil
+			revisions = append(revisions, &copied)
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Number > revisions[j].Number })
+	if len(revisions) > limit {
+		revisions = revisions[:limit]
+	}
+	return revisions, nil
+}
+
+func (m *MockWeddingRevisionRepository) DeleteBefore(ctx context.Context, weddingID models.ID, number int) error {
+	kept := m.revisions[:0]
+	for _, revision := range m.revisions {
+		if revision.WeddingID != weddingID || revision.Number >= number {
+			kept = append(kept, revision)
+		}
+	}
+	m.revisions = kept
+	return nil
+}
+
+func TestWeddingService_Revisions(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+
+	setup := func() (*WeddingService, *MockWeddingRevisionRepository, *models.Wedding) {
+		stored := createTestWedding()
+		stored.ID = models.NewID()
+		stored.UserID = ownerID
+		stored.Couple.Story = "We met at university"
+
+		weddings := new(MockWeddingRepository)
+		weddings.On("GetByID", mock.Anything, stored.ID).Return(stored, nil)
+		weddings.On("Update", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			*stored = *args.Get(1).(*models.Wedding)
+		})
+
+		revisions := &MockWeddingRevisionRepository{}
+		service := NewWeddingService(weddings, new(MockUserRepository))
+		service.EnableRevisions(revisions)
+		return service, revisions, stored
+	}
+	update := func(t *testing.T, service *WeddingService, stored *models.Wedding, change func(*models.Wedding)) {
+		edited := *stored
+		change(&edited)
+		require.NoError(t, service.UpdateWedding(ctx, &edited, ownerID))
+	}
+
+	t.Run("Success - updates are kept and rolled back", func(t *testing.T) {
+		service, revisions, stored := setup()
+
+		update(t, service, stored, func(w *models.Wedding) { w.Title = "Broken Title" })
+		update(t, service, stored, func(w *models.Wedding) { w.Couple.Story = "" })
+		// Saving unchanged content adds no revision
+		update(t, service, stored, func(*models.Wedding) {})
+
+		listed, err := service.ListRevisions(ctx, stored.ID, ownerID)
+		require.NoError(t, err)
+		require.Len(t, listed, 2)
+		assert.Equal(t, 2, listed[0].Number)
+		assert.Equal(t, []string{"couple.story"}, listed[0].ChangedFields)
+		assert.Nil(t, listed[0].Snapshot)
+		assert.Equal(t, []string{"title"}, listed[1].ChangedFields)
+
+		revision, err := service.GetRevision(ctx, stored.ID, ownerID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "Test Wedding", revision.Snapshot.Title)
+
+		stored.Status = string(models.WeddingStatusPublished)
+		restored, err := service.RestoreRevision(ctx, stored.ID, ownerID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "Test Wedding", restored.Title)
+		assert.Equal(t, "We met at university", stored.Couple.Story)
+		assert.Equal(t, string(models.WeddingStatusPublished), stored.Status)
+
+		require.Len(t, revisions.revisions, 3)
+		rollback := revisions.revisions[2]
+		require.NotNil(t, rollback.RestoredFrom)
+		assert.Equal(t, 1, *rollback.RestoredFrom)
+		assert.Equal(t, "Broken Title", rollback.Snapshot.Title)
+
+		_, err = service.RestoreRevision(ctx, stored.ID, ownerID, 1)
+		assert.ErrorIs(t, err, ErrNothingToRestore)
+	})
+
+	t.Run("Success - old revisions are pruned", func(t *testing.T) {
+		service, revisions, stored := setup()
+
+		for i := 0; i <= maxWeddingRevisions; i++ {
+			update(t, service, stored, func(w *models.Wedding) { w.Title = time.Duration(i).String() })
+		}
+		assert.Len(t, revisions.revisions, maxWeddingRevisions)
+		_, err := service.GetRevision(ctx, stored.ID, ownerID, 1)
+		assert.ErrorIs(t, err, ErrRevisionNotFound)
+	})
+
+	t.Run("Error - only editors see and restore revisions", func(t *testing.T) {
+		service, _, stored := setup()
+		update(t, service, stored, func(w *models.Wedding) { w.Title = "Broken Title" })
+
+		_, err := service.ListRevisions(ctx, stored.ID, models.NewID())
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		_, err = service.RestoreRevision(ctx, stored.ID, models.NewID(), 1)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		_, err = service.RestoreRevision(ctx, stored.ID, ownerID, 7)
+		assert.ErrorIs(t, err, ErrRevisionNotFound)
+	})
+}
+
+func TestRevisionChangedFields(t *testing.T) {
+	before := map[string]interface{}{
+		"id":         "1",
+		"title":      "Ours",
+		"updated_at": "yesterday",
+		"couple":     map[string]interface{}{"story": "old", "partner1": map[string]interface{}{"first_name": "John"}},
+		"rsvp":       map[string]interface{}{"meal_options": []interface{}{"fish"}},
+	}
+	after := map[string]interface{}{
+		"id":         "1",
+		"title":      "Ours",
+		"updated_at": "today",
+		"couple":     map[string]interface{}{"story": "new", "partner1": map[string]interface{}{"first_name": "Jon"}},
+		"rsvp":       map[string]interface{}{"meal_options": []interface{}{"fish", "beef"}},
+		"theme":      map[string]interface{}{"theme_id": "classic"},
+	}
+
+	assert.Equal(t, []string{"couple.partner1", "couple.story", "rsvp.meal_options", "theme"}, revisionChangedFields(before, after))
+	assert.Empty(t, revisionChangedFields(before, before))
+}
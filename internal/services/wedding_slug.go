@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+var (
+	// ErrSlugTaken is returned for a slug used by another wedding, now or before a rename
+	ErrSlugTaken = errs.Conflict("slug already exists")
+	// ErrReservedSlug is returned for a slug that would clash with the site's own pages
+	ErrReservedSlug = errors.New("slug is reserved")
+	// ErrInappropriateSlug is returned for a slug with a profane word
+	ErrInappropriateSlug = errors.New("slug contains inappropriate language")
+)
+
+// reservedSlugs cannot be chosen by weddings: they name, or may one day name,
+// pages and hosts of the site itself.
+var reservedSlugs = map[string]bool{
+	"about": true, "account": true, "admin": true, "api": true, "app": true,
+	"assets": true, "auth": true, "blog": true, "cdn": true, "contact": true,
+	"dashboard": true, "docs": true, "health": true, "help": true, "invite": true,
+	"login": true, "logout": true, "mail": true, "metrics": true, "new": true,
+	"preview": true, "pricing": true, "privacy": true, "public": true, "register": true,
+	"root": true, "rsvp": true, "settings": true, "signup": true, "static": true,
+	"status": true, "support": true, "swagger": true, "terms": true, "uploads": true,
+	"wedding": true, "weddings": true, "www": true,
+}
+
+// Reasons a slug is not available
+const (
+	SlugUnavailableInvalid       = "invalid"
+	SlugUnavailableTaken         = "taken"
+	SlugUnavailableReserved      = "reserved"
+	SlugUnavailableInappropriate = "inappropriate"
+)
+
+// SlugAvailability tells whether a slug can be given to a wedding
+type SlugAvailability struct {
+	Slug      string `json:"slug"`
+	Available bool   `json:"available"`
+	// Reason is set when the slug is not available
+	Reason string `json:"reason,omitempty"`
+	// Suggestion is a similar slug that is available, when there is one
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// EnableSlugHistory keeps the old slugs of renamed weddings, so links shared
+// before a rename redirect to the new slug and no other wedding can take them.
+func (s *WeddingService) EnableSlugHistory(redirects repository.SlugRedirectRepository) {
+	s.redirects = redirects
+}
+
+// CheckSlugAvailability tells whether a wedding could use the slug. A wedding
+// ID, when given, lets the wedding's own current and old slugs count as
+// available.
+func (s *WeddingService) CheckSlugAvailability(ctx context.Context, slug string, weddingID, userID models.ID) (*SlugAvailability, error) {
+	result := &SlugAvailability{Slug: slug}
+
+	if !weddingID.IsZero() {
+		wedding, err := s.getEditableWedding(ctx, weddingID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if wedding.Slug == slug {
+			result.Available = true
+			return result, nil
+		}
+	}
+
+	if err := utils.ValidateSlug(slug); err != nil {
+		result.Reason = SlugUnavailableInvalid
+		if candidate := utils.SanitizeSlug(slug); utils.ValidateSlug(candidate) == nil {
+			suggestion, err := s.nextFreeSlug(ctx, candidate, weddingID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to suggest slug: %w", err)
+			}
+			result.Suggestion = suggestion
+		}
+		return result, nil
+	}
+
+	err := s.checkSlug(ctx, slug, weddingID)
+	switch {
+	case err == nil:
+		result.Available = true
+		return result, nil
+	case errors.Is(err, ErrInappropriateSlug):
+		result.Reason = SlugUnavailableInappropriate
+		return result, nil
+	case errors.Is(err, ErrReservedSlug):
+		result.Reason = SlugUnavailableReserved
+	case errors.Is(err, ErrSlugTaken):
+		result.Reason = SlugUnavailableTaken
+	default:
+		return nil, err
+	}
+
+	suggestion, err := s.nextFreeSlug(ctx, slug, weddingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest slug: %w", err)
+	}
+	result.Suggestion = suggestion
+	return result, nil
+}
+
+// ResolveSlug returns the current slug of the wedding that used to have the
+// given slug before a rename
+func (s *WeddingService) ResolveSlug(ctx context.Context, slug string) (string, error) {
+	if s.redirects == nil {
+		return "", ErrWeddingNotFound
+	}
+
+	redirect, err := s.redirects.GetBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", ErrWeddingNotFound
+		}
+		return "", fmt.Errorf("failed to get slug redirect: %w", err)
+	}
+
+	wedding, err := s.weddingRepo.GetByID(ctx, redirect.WeddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", ErrWeddingNotFound
+		}
+		return "", fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil || wedding.Slug == slug {
+		return "", ErrWeddingNotFound
+	}
+	return wedding.Slug, nil
+}
+
+// checkSlug reports why a slug a wedding chose cannot be used. weddingID is
+// zero for a new wedding.
+func (s *WeddingService) checkSlug(ctx context.Context, slug string, weddingID models.ID) error {
+	if reservedSlugs[slug] {
+		return ErrReservedSlug
+	}
+	if NewProfanityFilter(defaultProfaneWords...).Contains(slug) {
+		return ErrInappropriateSlug
+	}
+
+	taken, err := s.slugTaken(ctx, slug, weddingID)
+	if err != nil {
+		return fmt.Errorf("failed to check slug availability: %w", err)
+	}
+	if taken {
+		return ErrSlugTaken
+	}
+	return nil
+}
+
+// slugTaken reports whether a slug belongs to another wedding, as its current
+// slug or one it had before a rename
+func (s *WeddingService) slugTaken(ctx context.Context, slug string, weddingID models.ID) (bool, error) {
+	exists, err := s.weddingRepo.ExistsBySlug(ctx, slug)
+	if err != nil || exists {
+		return exists, err
+	}
+	if s.redirects == nil {
+		return false, nil
+	}
+
+	redirect, err := s.redirects.GetBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return redirect.WeddingID != weddingID, nil
+}
+
+// nextFreeSlug returns the base slug, or the base slug with the first number
+// suffix, that a wedding could use
+func (s *WeddingService) nextFreeSlug(ctx context.Context, baseSlug string, weddingID models.ID) (string, error) {
+	if !reservedSlugs[baseSlug] {
+		taken, err := s.slugTaken(ctx, baseSlug, weddingID)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return baseSlug, nil
+		}
+	}
+
+	// Try with a number suffix
+	for i := 1; i <= 100; i++ {
+		candidateSlug := fmt.Sprintf("%s-%d", baseSlug, i)
+		taken, err := s.slugTaken(ctx, candidateSlug, weddingID)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidateSlug, nil
+		}
+	}
+
+	return "", errors.New("failed to generate unique slug")
+}
+
+// keepOldSlug lets the old slug of a renamed wedding redirect to it
+func (s *WeddingService) keepOldSlug(ctx context.Context, wedding *models.Wedding, oldSlug string) error {
+	if s.redirects == nil || oldSlug == "" || oldSlug == wedding.Slug {
+		return nil
+	}
+
+	if err := s.redirects.Save(ctx, &models.SlugRedirect{Slug: oldSlug, WeddingID: wedding.ID}); err != nil {
+		return fmt.Errorf("failed to keep old slug: %w", err)
+	}
+	return nil
+}
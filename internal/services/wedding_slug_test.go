@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockSlugRedirectRepository is an in-memory slug redirect repository
+type MockSlugRedirectRepository struct {
+	redirects map[string]models.ID
+}
+
+func NewMockSlugRedirectRepository() *MockSlugRedirectRepository {
+	return &MockSlugRedirectRepository{redirects: make(map[string]models.ID)}
+}
+
+func (m *MockSlugRedirectRepository) Save(ctx context.Context, redirect *models.SlugRedirect) error {
+	m.redirects[redirect.Slug] = redirect.WeddingID
+	return nil
+}
+
+func (m *MockSlugRedirectRepository) GetBySlug(ctx context.Context, slug string) (*models.SlugRedirect, error) {
+	weddingID, ok := m.redirects[slug]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &models.SlugRedirect{Slug: slug, WeddingID: weddingID}, nil
+}
+
+func (m *MockSlugRedirectRepository) Delete(ctx context.Context, slug string) error {
+	delete(m.redirects, slug)
+	return nil
+}
+
+func (m *MockSlugRedirectRepository) DeleteByWedding(ctx context.Context, weddingID models.ID) error {
+	for slug, id := range m.redirects {
+		if id == weddingID {
+			delete(m.redirects, slug)
+		}
+	}
+	return nil
+}
+
+func TestWeddingService_RenameKeepsOldSlug(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+
+	stored := createTestWedding()
+	stored.ID = models.NewID()
+	stored.UserID = ownerID
+
+	weddings := new(MockWeddingRepository)
+	weddings.On("GetByID", mock.Anything, stored.ID).Return(stored, nil)
+	weddings.On("ExistsBySlug", mock.Anything, mock.Anything).Return(false, nil)
+	weddings.On("Update", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*stored = *args.Get(1).(*models.Wedding)
+	})
+
+	redirects := NewMockSlugRedirectRepository()
+	service := NewWeddingService(weddings, new(MockUserRepository))
+	service.EnableSlugHistory(redirects)
+
+	rename := func(slug string) error {
+		edited := *stored
+		edited.Slug = slug
+		return service.UpdateWedding(ctx, &edited, ownerID)
+	}
+
+	require.NoError(t, rename("john-and-jane"))
+	current, err := service.ResolveSlug(ctx, "test-wedding")
+	require.NoError(t, err)
+	assert.Equal(t, "john-and-jane", current)
+
+	// Every old slug leads to the current one
+	require.NoError(t, rename("john-jane-2027"))
+	current, err = service.ResolveSlug(ctx, "test-wedding")
+	require.NoError(t, err)
+	assert.Equal(t, "john-jane-2027", current)
+
+	// Another wedding cannot take an old slug, the wedding itself can
+	taken, err := service.slugTaken(ctx, "john-and-jane", models.NewID())
+	require.NoError(t, err)
+	assert.True(t, taken)
+	require.NoError(t, rename("test-wedding"))
+	assert.NotContains(t, redirects.redirects, "test-wedding")
+
+	_, err = service.ResolveSlug(ctx, "never-used")
+	assert.ErrorIs(t, err, ErrWeddingNotFound)
+}
+
+func TestWeddingService_CheckSlug(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+	otherWeddingID := models.NewID()
+
+	stored := createTestWedding()
+	stored.ID = models.NewID()
+	stored.UserID = ownerID
+
+	weddings := new(MockWeddingRepository)
+	weddings.On("GetByID", mock.Anything, stored.ID).Return(stored, nil)
+	weddings.On("ExistsBySlug", mock.Anything, "anna-ben").Return(true, nil)
+	weddings.On("ExistsBySlug", mock.Anything, mock.Anything).Return(false, nil)
+
+	redirects := NewMockSlugRedirectRepository()
+	redirects.redirects["anna-ben-1"] = otherWeddingID
+	redirects.redirects["john-jane"] = stored.ID
+
+	service := NewWeddingService(weddings, new(MockUserRepository))
+	service.EnableSlugHistory(redirects)
+
+	tests := []struct {
+		name       string
+		slug       string
+		weddingID  models.ID
+		available  bool
+		reason     string
+		suggestion string
+	}{
+		{name: "free", slug: "john-and-jane", available: true},
+		{name: "taken", slug: "anna-ben", reason: SlugUnavailableTaken, suggestion: "anna-ben-2"},
+		{name: "old slug of another wedding", slug: "anna-ben-1", reason: SlugUnavailableTaken, suggestion: "anna-ben-1-1"},
+		{name: "old slug of the wedding", slug: "john-jane", weddingID: stored.ID, available: true},
+		{name: "current slug of the wedding", slug: "test-wedding", weddingID: stored.ID, available: true},
+		{name: "reserved", slug: "admin", reason: SlugUnavailableReserved, suggestion: "admin-1"},
+		{name: "inappropriate", slug: "fuck-weddings", reason: SlugUnavailableInappropriate},
+		{name: "invalid", slug: "John & Jane", reason: SlugUnavailableInvalid, suggestion: "john-jane-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.CheckSlugAvailability(ctx, tt.slug, tt.weddingID, ownerID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.available, result.Available)
+			assert.Equal(t, tt.reason, result.Reason)
+			assert.Equal(t, tt.suggestion, result.Suggestion)
+		})
+	}
+
+	t.Run("Error - another user's wedding", func(t *testing.T) {
+		_, err := service.CheckSlugAvailability(ctx, "john-jane", stored.ID, models.NewID())
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Error - creating with a reserved slug", func(t *testing.T) {
+		wedding := createTestWedding()
+		wedding.Slug = "www"
+		err := service.CreateWedding(ctx, wedding, ownerID)
+		assert.ErrorIs(t, err, ErrReservedSlug)
+	})
+
+	t.Run("Error - renaming to a taken slug", func(t *testing.T) {
+		edited := *stored
+		edited.Slug = "anna-ben-1"
+		err := service.UpdateWedding(ctx, &edited, ownerID)
+		assert.ErrorIs(t, err, ErrSlugTaken)
+	})
+}
@@ -7,7 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"github.com/stretchr/testify/require"
 
 	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
@@ -62,13 +62,13 @@ func TestWeddingService_CreateWedding(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	wedding := createTestWedding()
 
 	// Test successful creation
 	mockWeddingRepo.On("ExistsBySlug", ctx, wedding.Slug).Return(false, nil)
 	mockWeddingRepo.On("Create", ctx, mock.AnythingOfType("*models.Wedding")).Return(nil)
-	mockUserRepo.On("AddWeddingID", ctx, userID, mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	mockUserRepo.On("AddWeddingID", ctx, userID, mock.AnythingOfType("models.ID")).Return(nil)
 
 	err := service.CreateWedding(ctx, wedding, userID)
 	assert.NoError(t, err)
@@ -91,7 +91,7 @@ func TestWeddingService_CreateWedding_WithAutoSlug(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	wedding := createTestWedding()
 	wedding.Slug = "" // Empty slug to test auto-generation
 
@@ -99,7 +99,7 @@ func TestWeddingService_CreateWedding_WithAutoSlug(t *testing.T) {
 	mockWeddingRepo.On("ExistsBySlug", ctx, "test-wedding").Return(true, nil)
 	mockWeddingRepo.On("ExistsBySlug", ctx, "test-wedding-1").Return(false, nil)
 	mockWeddingRepo.On("Create", ctx, mock.AnythingOfType("*models.Wedding")).Return(nil)
-	mockUserRepo.On("AddWeddingID", ctx, userID, mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	mockUserRepo.On("AddWeddingID", ctx, userID, mock.AnythingOfType("models.ID")).Return(nil)
 
 	err := service.CreateWedding(ctx, wedding, userID)
 	assert.NoError(t, err)
@@ -115,7 +115,7 @@ func TestWeddingService_CreateWedding_SlugExists(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	wedding := createTestWedding()
 
 	// Test slug already exists
@@ -134,7 +134,7 @@ func TestWeddingService_CreateWedding_InvalidData(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 
 	// Test missing title
 	wedding := createTestWedding()
@@ -159,8 +159,8 @@ func TestWeddingService_GetWeddingByID(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
 
-	userID := primitive.NewObjectID()
-	weddingID := primitive.NewObjectID()
+	userID := models.NewID()
+	weddingID := models.NewID()
 	wedding := createTestWedding()
 	wedding.ID = weddingID
 	wedding.UserID = userID
@@ -180,7 +180,7 @@ func TestWeddingService_GetWeddingByID(t *testing.T) {
 	mockWeddingRepo.ExpectedCalls = nil
 
 	// Test successful retrieval (public access)
-	otherUserID := primitive.NewObjectID()
+	otherUserID := models.NewID()
 	mockWeddingRepo.On("GetByID", ctx, weddingID).Return(wedding, nil)
 	mockWeddingRepo.On("IncrementViewCount", ctx, weddingID).Return(nil)
 
@@ -197,12 +197,12 @@ func TestWeddingService_GetWeddingByID_NotFound(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
 
-	weddingID := primitive.NewObjectID()
+	weddingID := models.NewID()
 
 	// Test not found
 	mockWeddingRepo.On("GetByID", ctx, weddingID).Return(nil, nil)
 
-	result, err := service.GetWeddingByID(ctx, weddingID, primitive.NewObjectID())
+	result, err := service.GetWeddingByID(ctx, weddingID, models.NewID())
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "wedding not found")
 	assert.Nil(t, result)
@@ -216,11 +216,11 @@ func TestWeddingService_GetWeddingByID_AccessDenied(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
 
-	userID := primitive.NewObjectID()
-	weddingID := primitive.NewObjectID()
+	userID := models.NewID()
+	weddingID := models.NewID()
 	wedding := createTestWedding()
 	wedding.ID = weddingID
-	wedding.UserID = primitive.NewObjectID()
+	wedding.UserID = models.NewID()
 	wedding.IsPublic = false
 	wedding.Status = string(models.WeddingStatusDraft)
 
@@ -235,13 +235,41 @@ func TestWeddingService_GetWeddingByID_AccessDenied(t *testing.T) {
 	mockWeddingRepo.AssertExpectations(t)
 }
 
+func TestWeddingService_GetWeddingsByIDs(t *testing.T) {
+	ctx := context.Background()
+	mockWeddingRepo := new(MockWeddingRepository)
+	mockUserRepo := new(MockUserRepository)
+	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
+
+	userID := models.NewID()
+	owned := createTestWedding()
+	owned.ID = models.NewID()
+	owned.UserID = userID
+	draft := createTestWedding()
+	draft.ID = models.NewID()
+	draft.UserID = models.NewID()
+	draft.Status = string(models.WeddingStatusDraft)
+	missingID := models.NewID()
+
+	ids := []models.ID{owned.ID, draft.ID, missingID}
+	mockWeddingRepo.On("GetByIDs", ctx, ids).Return([]*models.Wedding{draft, owned}, nil)
+
+	result, err := service.GetWeddingsByIDs(ctx, ids, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, map[models.ID]*models.Wedding{owned.ID: owned}, result, "other users' drafts are left out")
+
+	// Views are not counted
+	mockWeddingRepo.AssertNotCalled(t, "IncrementViewCount", mock.Anything, mock.Anything)
+	mockWeddingRepo.AssertExpectations(t)
+}
+
 func TestWeddingService_GetUserWeddings(t *testing.T) {
 	ctx := context.Background()
 	mockWeddingRepo := new(MockWeddingRepository)
 	mockUserRepo := new(MockUserRepository)
 	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	weddings := []*models.Wedding{createTestWedding()}
 	filters := repository.WeddingFilters{}
 
@@ -261,8 +289,8 @@ func TestWeddingService_UpdateWedding(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
 
-	userID := primitive.NewObjectID()
-	weddingID := primitive.NewObjectID()
+	userID := models.NewID()
+	weddingID := models.NewID()
 	existingWedding := createTestWedding()
 	existingWedding.ID = weddingID
 	existingWedding.UserID = userID
@@ -286,11 +314,11 @@ func TestWeddingService_UpdateWedding_NotOwner(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
 
-	userID := primitive.NewObjectID()
-	weddingID := primitive.NewObjectID()
+	userID := models.NewID()
+	weddingID := models.NewID()
 	existingWedding := createTestWedding()
 	existingWedding.ID = weddingID
-	existingWedding.UserID = primitive.NewObjectID() // Different user
+	existingWedding.UserID = models.NewID() // Different user
 	updatedWedding := createTestWedding()
 	updatedWedding.ID = weddingID
 
@@ -304,14 +332,52 @@ func TestWeddingService_UpdateWedding_NotOwner(t *testing.T) {
 	mockWeddingRepo.AssertExpectations(t)
 }
 
+func TestWeddingService_UpdateWedding_Collaborators(t *testing.T) {
+	ctx := context.Background()
+	mockWeddingRepo := new(MockWeddingRepository)
+	mockUserRepo := new(MockUserRepository)
+	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
+
+	partnerID := models.NewID()
+	plannerID := models.NewID()
+	weddingID := models.NewID()
+	existingWedding := createTestWedding()
+	existingWedding.ID = weddingID
+	existingWedding.UserID = models.NewID()
+	existingWedding.Collaborators = []models.WeddingCollaborator{
+		{UserID: partnerID, Role: models.WeddingRoleCollaborator},
+		{UserID: plannerID, Role: models.WeddingRolePlanner},
+	}
+	mockWeddingRepo.On("GetByID", ctx, weddingID).Return(existingWedding, nil)
+	mockWeddingRepo.On("Update", ctx, mock.AnythingOfType("*models.Wedding")).Return(nil)
+
+	// A collaborator edits the wedding but can't change who collaborates on it
+	updatedWedding := createTestWedding()
+	updatedWedding.ID = weddingID
+	updatedWedding.Title = "Updated Wedding"
+	err := service.UpdateWedding(ctx, updatedWedding, partnerID)
+	assert.NoError(t, err)
+	assert.Equal(t, existingWedding.Collaborators, updatedWedding.Collaborators)
+
+	// A planner only manages guests and RSVPs
+	err = service.UpdateWedding(ctx, updatedWedding, plannerID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied")
+
+	// Only the owner deletes the wedding
+	err = service.DeleteWedding(ctx, weddingID, partnerID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied")
+}
+
 func TestWeddingService_DeleteWedding(t *testing.T) {
 	ctx := context.Background()
 	mockWeddingRepo := new(MockWeddingRepository)
 	mockUserRepo := new(MockUserRepository)
 	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
 
-	userID := primitive.NewObjectID()
-	weddingID := primitive.NewObjectID()
+	userID := models.NewID()
+	weddingID := models.NewID()
 	wedding := createTestWedding()
 	wedding.ID = weddingID
 	wedding.UserID = userID
@@ -334,8 +400,8 @@ func TestWeddingService_PublishWedding(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
 
-	userID := primitive.NewObjectID()
-	weddingID := primitive.NewObjectID()
+	userID := models.NewID()
+	weddingID := models.NewID()
 	wedding := createTestWedding()
 	wedding.ID = weddingID
 	wedding.UserID = userID
@@ -369,13 +435,61 @@ func TestWeddingService_ListPublicWeddings(t *testing.T) {
 	mockWeddingRepo.AssertExpectations(t)
 }
 
+func TestWeddingService_ListPublicWeddings_InvalidFilters(t *testing.T) {
+	ctx := context.Background()
+	mockWeddingRepo := new(MockWeddingRepository)
+	mockUserRepo := new(MockUserRepository)
+	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
+
+	later := time.Now().AddDate(0, 1, 0)
+	earlier := time.Now()
+
+	tests := []struct {
+		name    string
+		filters repository.PublicWeddingFilters
+	}{
+		{"unknown sort", repository.PublicWeddingFilters{Sort: "alphabetical"}},
+		{"relevance without search", repository.PublicWeddingFilters{Sort: repository.PublicWeddingSortRelevance}},
+		{"unknown date range", repository.PublicWeddingFilters{When: "someday"}},
+		{"inverted date range", repository.PublicWeddingFilters{DateFrom: &later, DateTo: &earlier}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := service.ListPublicWeddings(ctx, 1, 20, tt.filters)
+			assert.ErrorIs(t, err, ErrInvalidPublicWeddingFilter)
+		})
+	}
+
+	mockWeddingRepo.AssertNotCalled(t, "ListPublic", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWeddingService_ListPublicWeddings_SearchByRelevance(t *testing.T) {
+	ctx := context.Background()
+	mockWeddingRepo := new(MockWeddingRepository)
+	mockUserRepo := new(MockUserRepository)
+	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
+
+	filters := repository.PublicWeddingFilters{
+		Search: "john jane",
+		When:   repository.PublicWeddingsUpcoming,
+		Sort:   repository.PublicWeddingSortRelevance,
+	}
+	mockWeddingRepo.On("ListPublic", ctx, 1, 20, filters).Return([]*models.Wedding{}, int64(0), nil)
+
+	_, _, err := service.ListPublicWeddings(ctx, 1, 20, filters)
+	assert.NoError(t, err)
+
+	mockWeddingRepo.AssertExpectations(t)
+}
+
 func TestWeddingService_ValidateWedding_InvalidTheme(t *testing.T) {
 	ctx := context.Background()
 	mockWeddingRepo := new(MockWeddingRepository)
 	mockUserRepo := new(MockUserRepository)
 	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	wedding := createTestWedding()
 	wedding.Theme.ThemeID = "" // Invalid theme
 
@@ -393,7 +507,7 @@ func TestWeddingService_ValidateWedding_InvalidRSVP(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	wedding := createTestWedding()
 	wedding.RSVP.MaxPlusOnes = 10 // Invalid max plus ones
 
@@ -404,3 +518,127 @@ func TestWeddingService_ValidateWedding_InvalidRSVP(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "max plus ones must be between 0 and 5")
 }
+
+func TestWeddingService_ValidateWedding_CustomQuestions(t *testing.T) {
+	ctx := context.Background()
+	min, max := 5.0, 1.0
+
+	tests := []struct {
+		name      string
+		questions []models.CustomQuestion
+		expected  string
+	}{
+		{"unknown type", []models.CustomQuestion{{Question: "Age", Type: "date"}}, "invalid question type"},
+		{"choice without options", []models.CustomQuestion{{Question: "Meal", Type: models.CustomQuestionMultiChoice}}, "options are required"},
+		{"duplicate options", []models.CustomQuestion{{Question: "Meal", Type: models.CustomQuestionSingleChoice, Options: []string{"Fish", "Fish"}}}, "options must be distinct"},
+		{"min above max", []models.CustomQuestion{{Question: "Children", Type: models.CustomQuestionNumber, Min: &min, Max: &max}}, "min must not be greater than max"},
+		{"duplicate IDs", []models.CustomQuestion{{ID: "q", Question: "A", Type: "text"}, {ID: "q", Question: "B", Type: "text"}}, "duplicate question ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWeddingRepo := new(MockWeddingRepository)
+			service := NewWeddingService(mockWeddingRepo, new(MockUserRepository))
+
+			wedding := createTestWedding()
+			wedding.RSVP.CustomQuestions = tt.questions
+			mockWeddingRepo.On("ExistsBySlug", ctx, wedding.Slug).Return(false, nil)
+
+			err := service.CreateWedding(ctx, wedding, models.NewID())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+
+	t.Run("questions get IDs", func(t *testing.T) {
+		mockWeddingRepo := new(MockWeddingRepository)
+		mockUserRepo := new(MockUserRepository)
+		service := NewWeddingService(mockWeddingRepo, mockUserRepo)
+
+		userID := models.NewID()
+		wedding := createTestWedding()
+		wedding.RSVP.CustomQuestions = []models.CustomQuestion{
+			{Question: "Meal", Type: models.CustomQuestionSingleChoice, Options: []string{"Fish", "Chicken"}},
+			{Question: "Song request", Type: "textarea"},
+		}
+		mockWeddingRepo.On("ExistsBySlug", ctx, wedding.Slug).Return(false, nil)
+		mockWeddingRepo.On("Create", ctx, mock.AnythingOfType("*models.Wedding")).Return(nil)
+		mockUserRepo.On("AddWeddingID", ctx, userID, mock.AnythingOfType("models.ID")).Return(nil)
+
+		require.NoError(t, service.CreateWedding(ctx, wedding, userID))
+		assert.NotEmpty(t, wedding.RSVP.CustomQuestions[0].ID)
+		assert.NotEqual(t, wedding.RSVP.CustomQuestions[0].ID, wedding.RSVP.CustomQuestions[1].ID)
+	})
+}
+
+func TestWeddingService_ValidateWedding_DuplicateSession(t *testing.T) {
+	ctx := context.Background()
+	mockWeddingRepo := new(MockWeddingRepository)
+	mockUserRepo := new(MockUserRepository)
+	service := NewWeddingService(mockWeddingRepo, mockUserRepo)
+
+	userID := models.NewID()
+	wedding := createTestWedding()
+	wedding.Sessions = []models.EventSession{
+		{ID: "reception", EventDetails: wedding.Event},
+		{ID: "reception", EventDetails: wedding.Event},
+	}
+
+	// Mock slug existence check
+	mockWeddingRepo.On("ExistsBySlug", ctx, wedding.Slug).Return(false, nil)
+
+	err := service.CreateWedding(ctx, wedding, userID)
+	assert.ErrorIs(t, err, ErrDuplicateEventSession)
+}
+
+func TestWeddingService_ValidateWedding_EventSchedule(t *testing.T) {
+	ctx := context.Background()
+	userID := models.NewID()
+
+	tests := []struct {
+		name     string
+		modify   func(wedding *models.Wedding)
+		expected error
+	}{
+		{"unknown timezone", func(w *models.Wedding) { w.Event.Timezone = "Mars/Olympus" }, ErrInvalidEventTimezone},
+		{"server timezone", func(w *models.Wedding) { w.Event.Timezone = "Local" }, ErrInvalidEventTimezone},
+		{"session ends before it starts", func(w *models.Wedding) {
+			end := w.Event.Date.Add(-time.Hour)
+			session := models.EventSession{ID: "reception", EventDetails: w.Event}
+			session.EndDate = &end
+			w.Sessions = []models.EventSession{session}
+		}, ErrInvalidEventEnd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWeddingRepo := new(MockWeddingRepository)
+			service := NewWeddingService(mockWeddingRepo, new(MockUserRepository))
+			wedding := createTestWedding()
+			tt.modify(wedding)
+			mockWeddingRepo.On("ExistsBySlug", ctx, wedding.Slug).Return(false, nil)
+
+			err := service.CreateWedding(ctx, wedding, userID)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestNormalizeLanguages(t *testing.T) {
+	wedding := createTestWedding()
+	wedding.Language = "ID"
+	wedding.Translations = map[string]models.WeddingTranslation{"en-us": {Title: "Our Wedding"}}
+	require.NoError(t, normalizeLanguages(wedding))
+	assert.Equal(t, "id", wedding.Language)
+	assert.Equal(t, "Our Wedding", wedding.Translations["en-US"].Title)
+
+	wedding.Translations = map[string]models.WeddingTranslation{"id": {}}
+	assert.ErrorIs(t, normalizeLanguages(wedding), ErrOwnLanguageTranslation)
+
+	wedding.Translations = map[string]models.WeddingTranslation{"not a language": {}}
+	assert.ErrorIs(t, normalizeLanguages(wedding), ErrInvalidWeddingLanguage)
+
+	// Weddings without a language are written in English
+	wedding.Language = ""
+	wedding.Translations = map[string]models.WeddingTranslation{"en": {}}
+	assert.ErrorIs(t, normalizeLanguages(wedding), ErrOwnLanguageTranslation)
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// WeddingBundleVersion is the version of the wedding bundle format
+const WeddingBundleVersion = 1
+
+// WeddingBundle is a wedding with its guests, households and RSVPs, as moved
+// between environments. The private fields the API never shows, the wedding's
+// custom CSS and password, travel with it explicitly.
+type WeddingBundle struct {
+	Version      int                  `json:"version"`
+	ExportedAt   time.Time            `json:"exported_at"`
+	Wedding      *models.Wedding      `json:"wedding"`
+	CustomCSS    string               `json:"custom_css,omitempty"`
+	PasswordHash string               `json:"password_hash,omitempty"`
+	Groups       []*models.GuestGroup `json:"groups"`
+	Guests       []*models.Guest      `json:"guests"`
+	RSVPs        []*models.RSVP       `json:"rsvps"`
+}
+
+// WeddingTransferService exports a wedding with its guest list and answers, and
+// imports such an export as a new wedding of another owner
+type WeddingTransferService struct {
+	userRepo    repository.UserRepository
+	weddingRepo repository.WeddingRepository
+	groupRepo   repository.GuestGroupRepository
+	guestRepo   repository.GuestRepository
+	rsvpRepo    repository.RSVPRepository
+	logger      *zap.Logger
+}
+
+// NewWeddingTransferService creates a new wedding transfer service
+func NewWeddingTransferService(userRepo repository.UserRepository, weddingRepo repository.WeddingRepository, groupRepo repository.GuestGroupRepository, guestRepo repository.GuestRepository, rsvpRepo repository.RSVPRepository, logger *zap.Logger) *WeddingTransferService {
+	return &WeddingTransferService{
+		userRepo:    userRepo,
+		weddingRepo: weddingRepo,
+		groupRepo:   groupRepo,
+		guestRepo:   guestRepo,
+		rsvpRepo:    rsvpRepo,
+		logger:      logger,
+	}
+}
+
+// Export bundles a wedding with its households, guests and RSVPs
+func (s *WeddingTransferService) Export(ctx context.Context, weddingID models.ID) (*WeddingBundle, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &WeddingBundle{
+		Version:      WeddingBundleVersion,
+		ExportedAt:   time.Now().UTC(),
+		Wedding:      wedding,
+		CustomCSS:    wedding.Theme.CustomCSS,
+		PasswordHash: wedding.PasswordHash,
+		Guests:       []*models.Guest{},
+		RSVPs:        []*models.RSVP{},
+	}
+
+	bundle.Groups, err = s.groupRepo.ListByWedding(ctx, weddingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list guest groups: %w", err)
+	}
+	err = s.guestRepo.StreamByWedding(ctx, weddingID, repository.GuestFilters{}, func(guest *models.Guest) error {
+		bundle.Guests = append(bundle.Guests, guest)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read guests: %w", err)
+	}
+	err = s.rsvpRepo.StreamByWedding(ctx, weddingID, func(rsvp *models.RSVP) error {
+		bundle.RSVPs = append(bundle.RSVPs, rsvp)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read RSVPs: %w", err)
+	}
+	return bundle, nil
+}
+
+// Import creates the wedding of a bundle for a new owner, under the bundle's
+// slug unless another is given. Everything is stored under new IDs, with the
+// links between guests, households and RSVPs kept. Collaborators, moderation
+// and view counts stay behind with the original wedding.
+func (s *WeddingTransferService) Import(ctx context.Context, bundle *WeddingBundle, ownerID models.ID, slug string) (*models.Wedding, error) {
+	if bundle.Version != WeddingBundleVersion || bundle.Wedding == nil {
+		return nil, fmt.Errorf("unsupported wedding bundle version %d", bundle.Version)
+	}
+	owner, err := s.userRepo.GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner: %w", err)
+	}
+
+	wedding := *bundle.Wedding
+	if slug != "" {
+		wedding.Slug = slug
+	}
+	exists, err := s.weddingRepo.ExistsBySlug(ctx, wedding.Slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check slug availability: %w", err)
+	}
+	if exists {
+		return nil, ErrSlugTaken
+	}
+
+	wedding.ID = models.NewID()
+	wedding.UserID = owner.ID
+	wedding.TenantID = owner.TenantID
+	wedding.Theme.CustomCSS = bundle.CustomCSS
+	wedding.PasswordHash = bundle.PasswordHash
+	wedding.Collaborators = nil
+	wedding.CollaboratorInvites = nil
+	wedding.Moderation = nil
+	wedding.ViewCount = 0
+	wedding.LastViewedAt = nil
+	wedding.UpdatedAt = time.Now()
+	if err := s.weddingRepo.Create(ctx, &wedding); err != nil {
+		return nil, fmt.Errorf("failed to create wedding: %w", err)
+	}
+	if err := s.userRepo.AddWeddingID(ctx, owner.ID, wedding.ID); err != nil {
+		return nil, fmt.Errorf("failed to add wedding to owner: %w", err)
+	}
+
+	if err := s.importGuests(ctx, bundle, &wedding); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Wedding imported",
+		zap.String("wedding_id", wedding.ID.String()),
+		zap.String("source_wedding_id", bundle.Wedding.ID.String()),
+		zap.String("owner_id", owner.ID.String()),
+		zap.Int("guests", len(bundle.Guests)),
+		zap.Int("rsvps", len(bundle.RSVPs)))
+	return &wedding, nil
+}
+
+// importGuests stores the households, guests and RSVPs of a bundle for the
+// imported wedding. Records are created in the order they point to each other,
+// remapping the IDs they were exported with to the ones they are stored under.
+func (s *WeddingTransferService) importGuests(ctx context.Context, bundle *WeddingBundle, wedding *models.Wedding) error {
+	groupIDs := make(map[models.ID]models.ID, len(bundle.Groups))
+	groups := make([]*models.GuestGroup, 0, len(bundle.Groups))
+	for _, exported := range bundle.Groups {
+		group := *exported
+		group.ID = models.NilID
+		group.WeddingID = wedding.ID
+		group.CreatedBy = wedding.UserID
+		if err := s.groupRepo.Create(ctx, &group); err != nil {
+			return fmt.Errorf("failed to create guest group: %w", err)
+		}
+		groupIDs[exported.ID] = group.ID
+		groups = append(groups, &group)
+	}
+
+	guestIDs := make(map[models.ID]models.ID, len(bundle.Guests))
+	guests := make([]*models.Guest, 0, len(bundle.Guests))
+	for _, exported := range bundle.Guests {
+		guest := *exported
+		guest.ID = models.NilID
+		guest.WeddingID = wedding.ID
+		guest.GroupID = remapID(groupIDs, exported.GroupID)
+		guest.RSVPID = nil
+		guests = append(guests, &guest)
+	}
+	if len(guests) > 0 {
+		if err := s.guestRepo.CreateMany(ctx, guests); err != nil {
+			return fmt.Errorf("failed to create guests: %w", err)
+		}
+		for i, exported := range bundle.Guests {
+			guestIDs[exported.ID] = guests[i].ID
+		}
+	}
+
+	for _, group := range groups {
+		group.PrimaryGuestID = guestIDs[group.PrimaryGuestID]
+		if err := s.groupRepo.Update(ctx, group); err != nil {
+			return fmt.Errorf("failed to update guest group: %w", err)
+		}
+	}
+
+	rsvpIDs := make(map[models.ID]models.ID, len(bundle.RSVPs))
+	for _, exported := range bundle.RSVPs {
+		rsvp := *exported
+		rsvp.ID = models.NewID()
+		rsvp.WeddingID = wedding.ID
+		rsvp.GuestID = remapID(guestIDs, exported.GuestID)
+		rsvp.GroupID = remapID(groupIDs, exported.GroupID)
+		rsvp.Members = make([]models.RSVPMemberStatus, 0, len(exported.Members))
+		for _, member := range exported.Members {
+			if guestID, ok := guestIDs[member.GuestID]; ok {
+				member.GuestID = guestID
+				rsvp.Members = append(rsvp.Members, member)
+			}
+		}
+		if err := s.rsvpRepo.Create(ctx, &rsvp); err != nil {
+			return fmt.Errorf("failed to create RSVP: %w", err)
+		}
+		rsvpIDs[exported.ID] = rsvp.ID
+	}
+
+	for i, exported := range bundle.Guests {
+		if exported.RSVPID == nil {
+			continue
+		}
+		guests[i].RSVPID = remapID(rsvpIDs, exported.RSVPID)
+		if err := s.guestRepo.Update(ctx, guests[i]); err != nil {
+			return fmt.Errorf("failed to link guest to RSVP: %w", err)
+		}
+	}
+	return nil
+}
+
+// remapID returns the new ID of an optional reference, nil when there is none
+// or it points to a record that was not exported
+func remapID(ids map[models.ID]models.ID, id *models.ID) *models.ID {
+	if id == nil {
+		return nil
+	}
+	mapped, ok := ids[*id]
+	if !ok {
+		return nil
+	}
+	return &mapped
+}
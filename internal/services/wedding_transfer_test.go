@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+func TestWeddingTransferService_ExportImport(t *testing.T) {
+	ctx := context.Background()
+	userRepo := &MockUserRepository{}
+	weddingRepo := &MockWeddingRepository{}
+	groupRepo := NewMockGuestGroupRepository()
+	guestRepo := NewMockGuestRepository()
+	rsvpRepo := NewMockRSVPRepository()
+	service := NewWeddingTransferService(userRepo, weddingRepo, groupRepo, guestRepo, rsvpRepo, zaptest.NewLogger(t))
+
+	wedding := &models.Wedding{
+		ID:            models.NewID(),
+		UserID:        models.NewID(),
+		Slug:          "ann-and-bo",
+		Title:         "Ann & Bo",
+		PasswordHash:  "hash",
+		Collaborators: []models.WeddingCollaborator{{UserID: models.NewID()}},
+		ViewCount:     42,
+	}
+	wedding.Theme.CustomCSS = "body { color: red; }"
+
+	group := &models.GuestGroup{WeddingID: wedding.ID, Name: "The Lees"}
+	require.NoError(t, groupRepo.Create(ctx, group))
+	ann := &models.Guest{WeddingID: wedding.ID, FirstName: "Ann", GroupID: &group.ID}
+	bo := &models.Guest{WeddingID: wedding.ID, FirstName: "Bo", GroupID: &group.ID}
+	require.NoError(t, guestRepo.CreateMany(ctx, []*models.Guest{ann, bo}))
+	group.PrimaryGuestID = ann.ID
+	require.NoError(t, groupRepo.Update(ctx, group))
+	rsvp := &models.RSVP{
+		ID:        models.NewID(),
+		WeddingID: wedding.ID,
+		GuestID:   &ann.ID,
+		GroupID:   &group.ID,
+		Members:   []models.RSVPMemberStatus{{GuestID: bo.ID, Status: "not-attending"}},
+		Status:    "attending",
+	}
+	require.NoError(t, rsvpRepo.Create(ctx, rsvp))
+	ann.RSVPID = &rsvp.ID
+
+	weddingRepo.On("GetByID", ctx, wedding.ID).Return(wedding, nil).Once()
+	bundle, err := service.Export(ctx, wedding.ID)
+	require.NoError(t, err)
+	assert.Len(t, bundle.Groups, 1)
+	assert.Len(t, bundle.Guests, 2)
+	assert.Len(t, bundle.RSVPs, 1)
+
+	// Bundles travel as JSON files
+	data, err := json.Marshal(bundle)
+	require.NoError(t, err)
+	var imported WeddingBundle
+	require.NoError(t, json.Unmarshal(data, &imported))
+
+	owner := &models.User{ID: models.NewID(), TenantID: "org-2"}
+	userRepo.On("GetByID", ctx, owner.ID).Return(owner, nil)
+
+	t.Run("Error - slug taken", func(t *testing.T) {
+		weddingRepo.On("ExistsBySlug", ctx, "ann-and-bo").Return(true, nil).Once()
+
+		_, err := service.Import(ctx, &imported, owner.ID, "")
+		assert.ErrorIs(t, err, ErrSlugTaken)
+	})
+
+	t.Run("Success - stores a copy under new IDs", func(t *testing.T) {
+		weddingRepo.On("ExistsBySlug", ctx, "ann-and-bo-2").Return(false, nil).Once()
+		weddingRepo.On("Create", ctx, mock.AnythingOfType("*models.Wedding")).Return(nil).Once()
+		userRepo.On("AddWeddingID", ctx, owner.ID, mock.AnythingOfType("models.ID")).Return(nil).Once()
+
+		copied, err := service.Import(ctx, &imported, owner.ID, "ann-and-bo-2")
+		require.NoError(t, err)
+		assert.NotEqual(t, wedding.ID, copied.ID)
+		assert.Equal(t, "ann-and-bo-2", copied.Slug)
+		assert.Equal(t, owner.ID, copied.UserID)
+		assert.Equal(t, "org-2", copied.TenantID)
+		assert.Equal(t, "hash", copied.PasswordHash)
+		assert.Equal(t, "body { color: red; }", copied.Theme.CustomCSS)
+		assert.Empty(t, copied.Collaborators)
+		assert.Zero(t, copied.ViewCount)
+
+		groups, err := groupRepo.ListByWedding(ctx, copied.ID)
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		var copiedAnn *models.Guest
+		members := 0
+		require.NoError(t, guestRepo.StreamByWedding(ctx, copied.ID, repository.GuestFilters{}, func(guest *models.Guest) error {
+			assert.Equal(t, groups[0].ID, *guest.GroupID)
+			if guest.FirstName == "Ann" {
+				copiedAnn = guest
+			}
+			members++
+			return nil
+		}))
+		assert.Equal(t, 2, members)
+		require.NotNil(t, copiedAnn)
+		assert.Equal(t, copiedAnn.ID, groups[0].PrimaryGuestID)
+		require.NotNil(t, copiedAnn.RSVPID)
+
+		copiedRSVP, err := rsvpRepo.GetByID(ctx, *copiedAnn.RSVPID)
+		require.NoError(t, err)
+		assert.Equal(t, copied.ID, copiedRSVP.WeddingID)
+		assert.Equal(t, copiedAnn.ID, *copiedRSVP.GuestID)
+		assert.Equal(t, groups[0].ID, *copiedRSVP.GroupID)
+		require.Len(t, copiedRSVP.Members, 1)
+		assert.NotEqual(t, bo.ID, copiedRSVP.Members[0].GuestID)
+	})
+}
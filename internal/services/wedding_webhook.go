@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+var (
+	ErrWeddingWebhookNotFound = errs.NotFound("wedding webhook not found")
+	ErrInvalidWeddingEvent    = errors.New("unsupported wedding event type")
+	ErrTooManyWeddingWebhooks = errors.New("a wedding can have at most 10 webhooks")
+)
+
+// maxWeddingWebhooks bounds the webhooks of one wedding, each of which gets every event
+const maxWeddingWebhooks = 10
+
+// WeddingEventNotifier is told about the events of a wedding, e.g. to deliver
+// them to the owner's webhooks. Notifying never fails the operation that
+// raised the event.
+type WeddingEventNotifier interface {
+	NotifyWeddingEvent(ctx context.Context, weddingID models.ID, eventType models.WeddingEventType, data map[string]interface{})
+}
+
+// WeddingWebhookService lets wedding owners subscribe webhooks to the events of
+// their weddings, such as new RSVPs and guests checking in on the wedding day.
+// Events are delivered by background jobs, so a failing endpoint is retried
+// with backoff; every attempt is kept in the webhook's delivery log.
+type WeddingWebhookService struct {
+	repo        repository.WeddingWebhookRepository
+	weddingRepo repository.WeddingRepository
+	jobs        JobEnqueuer
+	httpClient  *http.Client
+	logger      *zap.Logger
+}
+
+// NewWeddingWebhookService creates a new wedding webhook service
+func NewWeddingWebhookService(repo repository.WeddingWebhookRepository, weddingRepo repository.WeddingRepository, jobs JobEnqueuer, logger *zap.Logger) *WeddingWebhookService {
+	return &WeddingWebhookService{
+		repo:        repo,
+		weddingRepo: weddingRepo,
+		jobs:        jobs,
+		httpClient:  newWebhookClient(),
+		logger:      logger,
+	}
+}
+
+// CreateWeddingWebhookRequest represents a new wedding webhook subscription
+type CreateWeddingWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url"`
+	Secret string   `json:"secret,omitempty" validate:"omitempty,min=16,max=128"`
+	Events []string `json:"events,omitempty"`
+}
+
+// UpdateWeddingWebhookRequest represents changes to a wedding webhook
+type UpdateWeddingWebhookRequest struct {
+	URL    *string   `json:"url,omitempty" validate:"omitempty,url"`
+	Events *[]string `json:"events,omitempty"`
+	Active *bool     `json:"active,omitempty"`
+}
+
+// CreateWebhook registers a webhook for a wedding and returns its signing secret,
+// which is not shown again
+func (s *WeddingWebhookService) CreateWebhook(ctx context.Context, weddingID, userID models.ID, req CreateWeddingWebhookRequest) (*models.WeddingWebhook, string, error) {
+	if err := s.verifyOwner(ctx, weddingID, userID); err != nil {
+		return nil, "", err
+	}
+	if err := validateWebhookURL(ctx, req.URL); err != nil {
+		return nil, "", err
+	}
+	if err := validateWeddingEvents(req.Events); err != nil {
+		return nil, "", err
+	}
+
+	existing, err := s.repo.ListByWedding(ctx, weddingID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list wedding webhooks: %w", err)
+	}
+	if len(existing) >= maxWeddingWebhooks {
+		return nil, "", ErrTooManyWeddingWebhooks
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = utils.GenerateSecureToken(32); err != nil {
+			return nil, "", err
+		}
+	}
+
+	webhook := &models.WeddingWebhook{
+		ID:        models.NewID(),
+		WeddingID: weddingID,
+		URL:       req.URL,
+		Secret:    secret,
+		Events:    req.Events,
+		Active:    true,
+		CreatedBy: userID,
+	}
+	if err := s.repo.Create(ctx, webhook); err != nil {
+		return nil, "", fmt.Errorf("failed to create wedding webhook: %w", err)
+	}
+	return webhook, secret, nil
+}
+
+// ListWebhooks lists the webhooks of a wedding
+func (s *WeddingWebhookService) ListWebhooks(ctx context.Context, weddingID, userID models.ID) ([]*models.WeddingWebhook, error) {
+	if err := s.verifyOwner(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+	webhooks, err := s.repo.ListByWedding(ctx, weddingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wedding webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// UpdateWebhook applies changes to a webhook of a wedding
+func (s *WeddingWebhookService) UpdateWebhook(ctx context.Context, weddingID, id, userID models.ID, req UpdateWeddingWebhookRequest) (*models.WeddingWebhook, error) {
+	webhook, err := s.getWebhook(ctx, weddingID, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := validateWebhookURL(ctx, *req.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = *req.URL
+	}
+	if req.Events != nil {
+		if err := validateWeddingEvents(*req.Events); err != nil {
+			return nil, err
+		}
+		webhook.Events = *req.Events
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+
+	if err := s.repo.Update(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to update wedding webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook removes a webhook of a wedding and its delivery log
+func (s *WeddingWebhookService) DeleteWebhook(ctx context.Context, weddingID, id, userID models.ID) error {
+	if _, err := s.getWebhook(ctx, weddingID, id, userID); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWeddingWebhookNotFound
+		}
+		return fmt.Errorf("failed to delete wedding webhook: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries returns the delivery log of a webhook of a wedding, newest first
+func (s *WeddingWebhookService) ListDeliveries(ctx context.Context, weddingID, id, userID models.ID, page, pageSize int) ([]*models.WeddingWebhookDelivery, int64, error) {
+	if _, err := s.getWebhook(ctx, weddingID, id, userID); err != nil {
+		return nil, 0, err
+	}
+	deliveries, total, err := s.repo.ListDeliveries(ctx, id, page, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list wedding webhook deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
+
+// NotifyWeddingEvent queues a delivery of the event to every active webhook of
+// the wedding that subscribes to it. Failures are logged, not returned.
+func (s *WeddingWebhookService) NotifyWeddingEvent(ctx context.Context, weddingID models.ID, eventType models.WeddingEventType, data map[string]interface{}) {
+	webhooks, err := s.repo.ListByWedding(ctx, weddingID)
+	if err != nil {
+		s.logger.Error("Failed to list wedding webhooks",
+			zap.Error(err),
+			zap.String("wedding_id", weddingID.String()),
+			zap.String("event", string(eventType)))
+		return
+	}
+
+	event := models.WeddingEvent{
+		ID:         models.NewID(),
+		Type:       eventType,
+		WeddingID:  weddingID,
+		Data:       data,
+		OccurredAt: time.Now(),
+	}
+	for _, webhook := range webhooks {
+		if !webhook.Active || !webhook.Subscribes(eventType) {
+			continue
+		}
+		job := WeddingWebhookJob{WebhookID: webhook.ID, Event: event}
+		if err := s.jobs.Enqueue(ctx, JobTypeDeliverWeddingWebhook, job); err != nil {
+			s.logger.Error("Failed to queue wedding webhook delivery",
+				zap.Error(err),
+				zap.String("webhook_id", webhook.ID.String()),
+				zap.String("event_id", event.ID.String()))
+		}
+	}
+}
+
+// Deliver posts an event to a webhook and records the attempt. Failures the
+// endpoint may recover from are returned for the job queue to retry; a webhook
+// deleted or paused since the event is skipped.
+func (s *WeddingWebhookService) Deliver(ctx context.Context, job WeddingWebhookJob, attempt int) error {
+	webhook, err := s.repo.GetByID(ctx, job.WebhookID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get wedding webhook: %w", err)
+	}
+	if !webhook.Active {
+		return nil
+	}
+
+	start := time.Now()
+	status, err := postSignedJSON(ctx, s.httpClient, webhook.URL, webhook.Secret, string(job.Event.Type), job.Event)
+	delivery := &models.WeddingWebhookDelivery{
+		ID:          models.NewID(),
+		WebhookID:   webhook.ID,
+		EventID:     job.Event.ID,
+		EventType:   job.Event.Type,
+		Attempt:     attempt,
+		StatusCode:  status,
+		Success:     err == nil,
+		DurationMs:  time.Since(start).Milliseconds(),
+		AttemptedAt: start,
+	}
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+	if recordErr := s.repo.CreateDelivery(ctx, delivery); recordErr != nil {
+		s.logger.Error("Failed to record wedding webhook delivery",
+			zap.Error(recordErr),
+			zap.String("webhook_id", webhook.ID.String()))
+	}
+
+	if err != nil && !isRetryableWebhookStatus(status) {
+		return PermanentJobError(err)
+	}
+	return err
+}
+
+// isRetryableWebhookStatus tells whether a failed delivery may succeed later.
+// Client errors other than timeouts and rate limits will not.
+func isRetryableWebhookStatus(status int) bool {
+	if status >= 400 && status < 500 {
+		return status == http.StatusRequestTimeout || status == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// verifyOwner checks that the user owns the wedding; webhooks send guest
+// details to outside systems, so collaborators cannot manage them
+func (s *WeddingWebhookService) verifyOwner(ctx context.Context, weddingID, userID models.ID) error {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWeddingNotFound
+		}
+		return fmt.Errorf("failed to get wedding: %w", err)
+	}
+	// The wedding repository reports a missing wedding as nil
+	if wedding == nil {
+		return ErrWeddingNotFound
+	}
+	if wedding.UserID != userID {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// getWebhook returns a webhook of a wedding the user owns
+func (s *WeddingWebhookService) getWebhook(ctx context.Context, weddingID, id, userID models.ID) (*models.WeddingWebhook, error) {
+	if err := s.verifyOwner(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+	webhook, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding webhook: %w", err)
+	}
+	if webhook.WeddingID != weddingID {
+		return nil, ErrWeddingWebhookNotFound
+	}
+	return webhook, nil
+}
+
+func validateWeddingEvents(events []string) error {
+	for _, event := range events {
+		if !models.IsValidWeddingEventType(event) {
+			return ErrInvalidWeddingEvent
+		}
+	}
+	return nil
+}
@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+	"wedding-invitation-backend/internal/utils"
+)
+
+// MockWeddingWebhookRepository is an in-memory wedding webhook repository
+type MockWeddingWebhookRepository struct {
+	webhooks   map[models.ID]*models.WeddingWebhook
+	deliveries []*models.WeddingWebhookDelivery
+}
+
+func NewMockWeddingWebhookRepository() *MockWeddingWebhookRepository {
+	return &MockWeddingWebhookRepository{
+		webhooks: make(map[models.ID]*models.WeddingWebhook),
+	}
+}
+
+func (m *MockWeddingWebhookRepository) Create(ctx context.Context, webhook *models.WeddingWebhook) error {
+	m.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (m *MockWeddingWebhookRepository) GetByID(ctx context.Context, id models.ID) (*models.WeddingWebhook, error) {
+	webhook, exists := m.webhooks[id]
+	if !exists {
+		return nil, repository.ErrNotFound
+	}
+	return webhook, nil
+}
+
+func (m *MockWeddingWebhookRepository) ListByWedding(ctx context.Context, weddingID models.ID) ([]*models.WeddingWebhook, error) {
+	var webhooks []*models.WeddingWebhook
+	for _, webhook := range m.webhooks {
+		if webhook.WeddingID == weddingID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (m *MockWeddingWebhookRepository) Update(ctx context.Context, webhook *models.WeddingWebhook) error {
+	if _, exists := m.webhooks[webhook.ID]; !exists {
+		return repository.ErrNotFound
+	}
+	m.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (m *MockWeddingWebhookRepository) Delete(ctx context.Context, id models.ID) error {
+	if _, exists := m.webhooks[id]; !exists {
+		return repository.ErrNotFound
+	}
+	delete(m.webhooks, id)
+	return nil
+}
+
+func (m *MockWeddingWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WeddingWebhookDelivery) error {
+	m.deliveries = append(m.deliveries, delivery)
+	return nil
+}
+
+func (m *MockWeddingWebhookRepository) ListDeliveries(ctx context.Context, webhookID models.ID, page, pageSize int) ([]*models.WeddingWebhookDelivery, int64, error) {
+	var deliveries []*models.WeddingWebhookDelivery
+	for _, delivery := range m.deliveries {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, int64(len(deliveries)), nil
+}
+
+// recordingEventNotifier records the wedding events it is told about
+type recordingEventNotifier struct {
+	events []models.WeddingEvent
+}
+
+func (n *recordingEventNotifier) NotifyWeddingEvent(ctx context.Context, weddingID models.ID, eventType models.WeddingEventType, data map[string]interface{}) {
+	n.events = append(n.events, models.WeddingEvent{Type: eventType, WeddingID: weddingID, Data: data})
+}
+
+func setupWeddingWebhookService(t *testing.T) (*WeddingWebhookService, *MockWeddingWebhookRepository, *JobQueue, *MockJobRepository, *models.Wedding) {
+	webhookRepo := NewMockWeddingWebhookRepository()
+	weddingRepo := &MockWeddingRepository{}
+	jobRepo := NewMockJobRepository()
+	logger := zaptest.NewLogger(t)
+	stubWebhookNetwork(t)
+
+	wedding := &models.Wedding{
+		ID:     models.NewID(),
+		UserID: models.NewID(),
+		Slug:   "alice-and-bob",
+		Title:  "Alice & Bob",
+	}
+	weddingRepo.On("GetByID", mock.Anything, wedding.ID).Return(wedding, nil)
+	weddingRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, nil)
+
+	queue := NewJobQueue(jobRepo, JobQueueOptions{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}, logger)
+	service := NewWeddingWebhookService(webhookRepo, weddingRepo, queue, logger)
+	RegisterJobHandlers(queue, nil, nil, nil, nil, nil, nil, service, nil, nil)
+	return service, webhookRepo, queue, jobRepo, wedding
+}
+
+func TestWeddingWebhookService_CreateWebhook(t *testing.T) {
+	service, webhookRepo, _, _, wedding := setupWeddingWebhookService(t)
+	ctx := context.Background()
+
+	t.Run("Success - generates secret", func(t *testing.T) {
+		webhook, secret, err := service.CreateWebhook(ctx, wedding.ID, wedding.UserID, CreateWeddingWebhookRequest{
+			URL:    "https://hooks.example.com/wedding",
+			Events: []string{string(models.WeddingEventRSVPCreated)},
+		})
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, secret)
+		assert.Equal(t, secret, webhook.Secret)
+		assert.True(t, webhook.Active)
+		assert.Contains(t, webhookRepo.webhooks, webhook.ID)
+	})
+
+	t.Run("Error - unknown event", func(t *testing.T) {
+		_, _, err := service.CreateWebhook(ctx, wedding.ID, wedding.UserID, CreateWeddingWebhookRequest{
+			URL:    "https://hooks.example.com/wedding",
+			Events: []string{"party.started"},
+		})
+		assert.ErrorIs(t, err, ErrInvalidWeddingEvent)
+	})
+
+	t.Run("Error - invalid URL", func(t *testing.T) {
+		_, _, err := service.CreateWebhook(ctx, wedding.ID, wedding.UserID, CreateWeddingWebhookRequest{URL: "ftp://hooks.example.com"})
+		assert.ErrorIs(t, err, ErrInvalidWebhookURL)
+	})
+
+	t.Run("Error - not the owner", func(t *testing.T) {
+		_, _, err := service.CreateWebhook(ctx, wedding.ID, models.NewID(), CreateWeddingWebhookRequest{URL: "https://hooks.example.com/wedding"})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Error - unknown wedding", func(t *testing.T) {
+		_, _, err := service.CreateWebhook(ctx, models.NewID(), wedding.UserID, CreateWeddingWebhookRequest{URL: "https://hooks.example.com/wedding"})
+		assert.ErrorIs(t, err, ErrWeddingNotFound)
+	})
+
+	t.Run("Error - too many webhooks", func(t *testing.T) {
+		for len(webhookRepo.webhooks) < maxWeddingWebhooks {
+			id := models.NewID()
+			webhookRepo.webhooks[id] = &models.WeddingWebhook{ID: id, WeddingID: wedding.ID}
+		}
+		_, _, err := service.CreateWebhook(ctx, wedding.ID, wedding.UserID, CreateWeddingWebhookRequest{URL: "https://hooks.example.com/wedding"})
+		assert.ErrorIs(t, err, ErrTooManyWeddingWebhooks)
+	})
+}
+
+func TestWeddingWebhookService_ManageWebhook(t *testing.T) {
+	service, webhookRepo, _, _, wedding := setupWeddingWebhookService(t)
+	ctx := context.Background()
+
+	webhook := &models.WeddingWebhook{ID: models.NewID(), WeddingID: wedding.ID, URL: "https://hooks.example.com/wedding", Active: true}
+	webhookRepo.webhooks[webhook.ID] = webhook
+	other := &models.WeddingWebhook{ID: models.NewID(), WeddingID: models.NewID(), Active: true}
+	webhookRepo.webhooks[other.ID] = other
+
+	paused := false
+	updated, err := service.UpdateWebhook(ctx, wedding.ID, webhook.ID, wedding.UserID, UpdateWeddingWebhookRequest{Active: &paused})
+	require.NoError(t, err)
+	assert.False(t, updated.Active)
+
+	// A webhook of another wedding is not found through this one
+	_, err = service.UpdateWebhook(ctx, wedding.ID, other.ID, wedding.UserID, UpdateWeddingWebhookRequest{Active: &paused})
+	assert.ErrorIs(t, err, ErrWeddingWebhookNotFound)
+
+	_, _, err = service.ListDeliveries(ctx, wedding.ID, webhook.ID, models.NewID(), 1, 20)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	require.NoError(t, service.DeleteWebhook(ctx, wedding.ID, webhook.ID, wedding.UserID))
+	assert.NotContains(t, webhookRepo.webhooks, webhook.ID)
+	assert.ErrorIs(t, service.DeleteWebhook(ctx, wedding.ID, webhook.ID, wedding.UserID), ErrWeddingWebhookNotFound)
+}
+
+func TestWeddingWebhookService_NotifyWeddingEvent(t *testing.T) {
+	service, webhookRepo, _, jobRepo, wedding := setupWeddingWebhookService(t)
+	ctx := context.Background()
+
+	all := &models.WeddingWebhook{ID: models.NewID(), WeddingID: wedding.ID, Active: true}
+	rsvps := &models.WeddingWebhook{ID: models.NewID(), WeddingID: wedding.ID, Active: true, Events: []string{string(models.WeddingEventRSVPCreated)}}
+	checkIns := &models.WeddingWebhook{ID: models.NewID(), WeddingID: wedding.ID, Active: true, Events: []string{string(models.WeddingEventGuestCheckedIn)}}
+	paused := &models.WeddingWebhook{ID: models.NewID(), WeddingID: wedding.ID}
+	for _, webhook := range []*models.WeddingWebhook{all, rsvps, checkIns, paused} {
+		webhookRepo.webhooks[webhook.ID] = webhook
+	}
+
+	service.NotifyWeddingEvent(ctx, wedding.ID, models.WeddingEventRSVPCreated, map[string]interface{}{"name": "Carol"})
+
+	jobs := jobRepo.byType(JobTypeDeliverWeddingWebhook)
+	require.Len(t, jobs, 2)
+
+	targets := make(map[models.ID]bool)
+	var eventIDs []models.ID
+	for _, job := range jobs {
+		var payload WeddingWebhookJob
+		require.NoError(t, job.DecodePayload(&payload))
+		targets[payload.WebhookID] = true
+		eventIDs = append(eventIDs, payload.Event.ID)
+	}
+	assert.Equal(t, map[models.ID]bool{all.ID: true, rsvps.ID: true}, targets)
+	// Every webhook gets the same event, so receivers can deduplicate
+	assert.Equal(t, eventIDs[0], eventIDs[1])
+}
+
+func TestWeddingWebhookService_Deliver(t *testing.T) {
+	ctx := context.Background()
+	secret := "test-secret-value-1234"
+
+	newWebhook := func(repo *MockWeddingWebhookRepository, weddingID models.ID, url string) *models.WeddingWebhook {
+		webhook := &models.WeddingWebhook{ID: models.NewID(), WeddingID: weddingID, URL: url, Secret: secret, Active: true}
+		repo.webhooks[webhook.ID] = webhook
+		return webhook
+	}
+
+	t.Run("Posts the signed event", func(t *testing.T) {
+		service, webhookRepo, queue, jobRepo, wedding := setupWeddingWebhookService(t)
+
+		var received models.WeddingEvent
+		var eventHeader string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			timestamp, err := strconv.ParseInt(r.Header.Get(utils.SignatureTimestampHeader), 10, 64)
+			require.NoError(t, err)
+			if !utils.VerifyPayloadSignature(secret, timestamp, body, r.Header.Get(utils.SignatureHeader)) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			eventHeader = r.Header.Get("X-Webhook-Event")
+			require.NoError(t, json.Unmarshal(body, &received))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		webhook := newWebhook(webhookRepo, wedding.ID, server.URL)
+		service.NotifyWeddingEvent(ctx, wedding.ID, models.WeddingEventGuestCheckedIn, map[string]interface{}{"name": "Carol", "plus_ones_brought": 1})
+
+		claimed, err := queue.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+
+		assert.Equal(t, "guest.checked_in", eventHeader)
+		assert.Equal(t, models.WeddingEventGuestCheckedIn, received.Type)
+		assert.Equal(t, wedding.ID, received.WeddingID)
+		assert.Equal(t, "Carol", received.Data["name"])
+
+		require.Len(t, webhookRepo.deliveries, 1)
+		delivery := webhookRepo.deliveries[0]
+		assert.True(t, delivery.Success)
+		assert.Equal(t, webhook.ID, delivery.WebhookID)
+		assert.Equal(t, received.ID, delivery.EventID)
+		assert.Equal(t, 1, delivery.Attempt)
+		assert.Equal(t, models.JobCompleted, jobRepo.byType(JobTypeDeliverWeddingWebhook)[0].Status)
+	})
+
+	t.Run("Retries server errors", func(t *testing.T) {
+		service, webhookRepo, queue, jobRepo, wedding := setupWeddingWebhookService(t)
+
+		status := http.StatusServiceUnavailable
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		defer server.Close()
+
+		newWebhook(webhookRepo, wedding.ID, server.URL)
+		service.NotifyWeddingEvent(ctx, wedding.ID, models.WeddingEventRSVPCreated, nil)
+
+		_, err := queue.ProcessNext(ctx)
+		require.NoError(t, err)
+		job := jobRepo.byType(JobTypeDeliverWeddingWebhook)[0]
+		assert.Equal(t, models.JobQueued, job.Status)
+
+		// The endpoint recovers by the next attempt
+		status = http.StatusOK
+		job.RunAt = time.Time{}
+		_, err = queue.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, models.JobCompleted, job.Status)
+
+		require.Len(t, webhookRepo.deliveries, 2)
+		assert.False(t, webhookRepo.deliveries[0].Success)
+		assert.Equal(t, http.StatusServiceUnavailable, webhookRepo.deliveries[0].StatusCode)
+		assert.NotEmpty(t, webhookRepo.deliveries[0].Error)
+		assert.True(t, webhookRepo.deliveries[1].Success)
+		assert.Equal(t, 2, webhookRepo.deliveries[1].Attempt)
+		assert.Equal(t, webhookRepo.deliveries[0].EventID, webhookRepo.deliveries[1].EventID)
+	})
+
+	t.Run("Does not retry client errors", func(t *testing.T) {
+		service, webhookRepo, queue, jobRepo, wedding := setupWeddingWebhookService(t)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer server.Close()
+
+		newWebhook(webhookRepo, wedding.ID, server.URL)
+		service.NotifyWeddingEvent(ctx, wedding.ID, models.WeddingEventRSVPCreated, nil)
+
+		_, err := queue.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, models.JobFailed, jobRepo.byType(JobTypeDeliverWeddingWebhook)[0].Status)
+		require.Len(t, webhookRepo.deliveries, 1)
+		assert.Equal(t, http.StatusGone, webhookRepo.deliveries[0].StatusCode)
+	})
+
+	t.Run("Skips a webhook paused since the event", func(t *testing.T) {
+		service, webhookRepo, queue, jobRepo, wedding := setupWeddingWebhookService(t)
+
+		webhook := newWebhook(webhookRepo, wedding.ID, "https://hooks.example.com/wedding")
+		service.NotifyWeddingEvent(ctx, wedding.ID, models.WeddingEventRSVPCreated, nil)
+		webhook.Active = false
+
+		_, err := queue.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, models.JobCompleted, jobRepo.byType(JobTypeDeliverWeddingWebhook)[0].Status)
+		assert.Empty(t, webhookRepo.deliveries)
+	})
+}
+
+func TestIsRetryableWebhookStatus(t *testing.T) {
+	assert.True(t, isRetryableWebhookStatus(0))
+	assert.True(t, isRetryableWebhookStatus(http.StatusBadGateway))
+	assert.True(t, isRetryableWebhookStatus(http.StatusTooManyRequests))
+	assert.True(t, isRetryableWebhookStatus(http.StatusRequestTimeout))
+	assert.False(t, isRetryableWebhookStatus(http.StatusNotFound))
+	assert.False(t, isRetryableWebhookStatus(http.StatusUnauthorized))
+}
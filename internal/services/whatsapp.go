@@ -0,0 +1,251 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// whatsAppCloudEndpoint is the WhatsApp Cloud API messages endpoint of a business phone number
+const whatsAppCloudEndpoint = "https://graph.facebook.com/v19.0/%s/messages"
+
+// WhatsAppMessage is a single outgoing WhatsApp text message
+type WhatsAppMessage struct {
+	To   string // Recipient phone number in international format
+	Text string
+}
+
+// WhatsAppService sends WhatsApp messages. Text messages only reach guests who chatted
+// with the business recently; templates reach anyone.
+type WhatsAppService interface {
+	MessagingService
+	Send(ctx context.Context, msg *WhatsAppMessage) error
+}
+
+// CloudWhatsAppService sends messages through the WhatsApp Cloud API
+type CloudWhatsAppService struct {
+	accessToken string
+	endpoint    string
+	httpClient  *http.Client
+}
+
+// NewCloudWhatsAppService creates a WhatsApp service sending from the business phone number
+func NewCloudWhatsAppService(accessToken, phoneNumberID string) *CloudWhatsAppService {
+	return &CloudWhatsAppService{
+		accessToken: accessToken,
+		endpoint:    fmt.Sprintf(whatsAppCloudEndpoint, phoneNumberID),
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type whatsAppText struct {
+	Body       string `json:"body"`
+	PreviewURL bool   `json:"preview_url"`
+}
+
+type whatsAppLanguage struct {
+	Code string `json:"code"`
+}
+
+type whatsAppParameter struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type whatsAppComponent struct {
+	Type       string              `json:"type"`
+	Parameters []whatsAppParameter `json:"parameters"`
+}
+
+type whatsAppTemplate struct {
+	Name       string              `json:"name"`
+	Language   whatsAppLanguage    `json:"language"`
+	Components []whatsAppComponent `json:"components,omitempty"`
+}
+
+type whatsAppRequest struct {
+	MessagingProduct string            `json:"messaging_product"`
+	To               string            `json:"to"`
+	Type             string            `json:"type"`
+	Text             *whatsAppText     `json:"text,omitempty"`
+	Template         *whatsAppTemplate `json:"template,omitempty"`
+}
+
+type whatsAppResponse struct {
+	Messages []struct {
+		ID string `json:"id"`
+	} `json:"messages"`
+}
+
+// Send posts a text message to the Cloud API, which answers 200 once it is accepted
+func (s *CloudWhatsAppService) Send(ctx context.Context, msg *WhatsAppMessage) error {
+	_, err := s.post(ctx, whatsAppRequest{
+		MessagingProduct: "whatsapp",
+		// The API expects digits only, without the leading + or separators
+		To:   normalizeWhatsAppNumber(msg.To),
+		Type: "text",
+		Text: &whatsAppText{Body: msg.Text, PreviewURL: true},
+	})
+	return err
+}
+
+// SendTemplate posts a template message to the Cloud API and returns its message ID
+func (s *CloudWhatsAppService) SendTemplate(ctx context.Context, msg *TemplateMessage) (string, error) {
+	tmpl := &whatsAppTemplate{
+		Name:     msg.Template.Name,
+		Language: whatsAppLanguage{Code: msg.Template.Language},
+	}
+	if len(msg.Params) > 0 {
+		body := whatsAppComponent{Type: "body"}
+		for _, param := range msg.Params {
+			body.Parameters = append(body.Parameters, whatsAppParameter{Type: "text", Text: param})
+		}
+		tmpl.Components = []whatsAppComponent{body}
+	}
+
+	return s.post(ctx, whatsAppRequest{
+		MessagingProduct: "whatsapp",
+		To:               normalizeWhatsAppNumber(msg.To),
+		Type:             "template",
+		Template:         tmpl,
+	})
+}
+
+// post sends a message request and returns the ID of the accepted message
+func (s *CloudWhatsAppService) post(ctx context.Context, payload whatsAppRequest) (string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode WhatsApp message: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to build WhatsApp request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+s.accessToken)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("failed to send WhatsApp message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("WhatsApp provider returned status %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+
+	var accepted whatsAppResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&accepted); err != nil || len(accepted.Messages) == 0 {
+		// The message was accepted; only its delivery cannot be followed
+		return "", nil
+	}
+	return accepted.Messages[0].ID, nil
+}
+
+// normalizeWhatsAppNumber strips everything but digits from a phone number
+func normalizeWhatsAppNumber(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
+
+// LogWhatsAppService writes WhatsApp messages to the log instead of sending them, for development
+type LogWhatsAppService struct {
+	logger *zap.Logger
+}
+
+// NewLogWhatsAppService creates a WhatsApp service that only logs messages
+func NewLogWhatsAppService(logger *zap.Logger) *LogWhatsAppService {
+	return &LogWhatsAppService{logger: logger}
+}
+
+// Send logs the recipient of the message
+func (s *LogWhatsAppService) Send(ctx context.Context, msg *WhatsAppMessage) error {
+	s.logger.Info("WhatsApp message not sent, no WhatsApp provider configured", zap.String("to", msg.To))
+	return nil
+}
+
+// SendTemplate logs the recipient and template of the message; it has no message ID
+func (s *LogWhatsAppService) SendTemplate(ctx context.Context, msg *TemplateMessage) (string, error) {
+	s.logger.Info("WhatsApp template not sent, no WhatsApp provider configured",
+		zap.String("to", msg.To),
+		zap.String("template", msg.Template.Name))
+	return "", nil
+}
+
+// WhatsAppSignatureHeader carries the signature of WhatsApp webhook requests
+const WhatsAppSignatureHeader = "X-Hub-Signature-256"
+
+// VerifyWhatsAppSignature checks the sha256=<hex HMAC> signature the Cloud API computes
+// over a webhook body with the Meta app secret
+func VerifyWhatsAppSignature(appSecret string, body []byte, signature string) bool {
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write(body)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// whatsAppWebhook is the part of a Cloud API webhook notification carrying delivery reports
+type whatsAppWebhook struct {
+	Entry []struct {
+		Changes []struct {
+			Value struct {
+				Statuses []struct {
+					ID        string `json:"id"`
+					Status    string `json:"status"`
+					Timestamp string `json:"timestamp"`
+					Errors    []struct {
+						Code    int    `json:"code"`
+						Title   string `json:"title"`
+						Message string `json:"message"`
+					} `json:"errors"`
+				} `json:"statuses"`
+			} `json:"value"`
+		} `json:"changes"`
+	} `json:"entry"`
+}
+
+// ParseWhatsAppStatuses extracts the delivery reports of a Cloud API webhook notification.
+// Notifications of incoming messages carry none.
+func ParseWhatsAppStatuses(body []byte) ([]MessageStatus, error) {
+	var webhook whatsAppWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		return nil, fmt.Errorf("invalid WhatsApp webhook: %w", err)
+	}
+
+	var statuses []MessageStatus
+	for _, entry := range webhook.Entry {
+		for _, change := range entry.Changes {
+			for _, report := range change.Value.Statuses {
+				status := MessageStatus{MessageID: report.ID, Status: report.Status}
+				if seconds, err := strconv.ParseInt(report.Timestamp, 10, 64); err == nil {
+					status.Timestamp = time.Unix(seconds, 0).UTC()
+				}
+				if len(report.Errors) > 0 {
+					status.Reason = report.Errors[0].Title
+					if report.Errors[0].Message != "" {
+						status.Reason = report.Errors[0].Message
+					}
+				}
+				statuses = append(statuses, status)
+			}
+		}
+	}
+	return statuses, nil
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"wedding-invitation-backend/internal/domain/errs"
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+const (
+	// maxWishNameLength bounds the guest name shown with a wish
+	maxWishNameLength = 100
+	// maxWishMessageLength bounds the message of a wish
+	maxWishMessageLength = 1000
+)
+
+var (
+	ErrWishesDisabled    = errors.New("wishes are disabled for this wedding")
+	ErrInvalidWish       = errors.New("a wish needs a name of at most 100 and a message of at most 1000 characters")
+	ErrWishNotFound      = errs.NotFound("wish not found")
+	ErrInvalidWishStatus = errors.New("invalid wish status")
+)
+
+// WishRequest describes a wish a guest leaves on a wedding's guestbook
+type WishRequest struct {
+	GuestName string `json:"guest_name" validate:"required,max=100"`
+	Message   string `json:"message" validate:"required,max=1000"`
+	// IP is the guest's address, kept for abuse reports
+	IP string `json:"-"`
+}
+
+// WishService lets the guests of a published wedding leave wishes on its
+// guestbook and the wedding's editors moderate them. Profanity is masked
+// before a wish is stored; only approved wishes are shown publicly.
+type WishService struct {
+	wishRepo    repository.WishRepository
+	weddingRepo repository.WeddingRepository
+	filter      *ProfanityFilter
+	features    FeatureChecker
+	logger      *zap.Logger
+}
+
+// NewWishService creates a new guestbook wish service
+func NewWishService(wishRepo repository.WishRepository, weddingRepo repository.WeddingRepository, logger *zap.Logger) *WishService {
+	return &WishService{
+		wishRepo:    wishRepo,
+		weddingRepo: weddingRepo,
+		filter:      NewProfanityFilter(defaultProfaneWords...),
+		logger:      logger,
+	}
+}
+
+// SetFeatureFlags turns wishes away from weddings the wishes feature is off
+// for, whatever the wedding's own setting
+func (s *WishService) SetFeatureFlags(features FeatureChecker) {
+	s.features = features
+}
+
+// CreateWish stores a guest's wish. It waits for approval when the wedding
+// requires it and is shown on the wall right away otherwise.
+func (s *WishService) CreateWish(ctx context.Context, slug string, req WishRequest) (*models.Wish, error) {
+	wedding, err := s.getPublishedWedding(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !wedding.Wishes.Enabled || !featureEnabled(ctx, s.features, wedding.ID, FeatureWishes) {
+		return nil, ErrWishesDisabled
+	}
+
+	name := strings.TrimSpace(req.GuestName)
+	message := strings.TrimSpace(req.Message)
+	if name == "" || message == "" ||
+		utf8.RuneCountInString(name) > maxWishNameLength || utf8.RuneCountInString(message) > maxWishMessageLength {
+		return nil, ErrInvalidWish
+	}
+
+	name, nameFiltered := s.filter.Clean(name)
+	message, messageFiltered := s.filter.Clean(message)
+
+	status := models.WishApproved
+	if wedding.Wishes.RequireApproval {
+		status = models.WishPending
+	}
+	wish := &models.Wish{
+		WeddingID: wedding.ID,
+		GuestName: name,
+		Message:   message,
+		Filtered:  nameFiltered || messageFiltered,
+		Status:    status,
+		GuestIP:   req.IP,
+	}
+	if err := s.wishRepo.Create(ctx, wish); err != nil {
+		return nil, fmt.Errorf("failed to save wish: %w", err)
+	}
+	return wish, nil
+}
+
+// ListPublicWishes lists the approved wishes of a published wedding, newest first
+func (s *WishService) ListPublicWishes(ctx context.Context, slug string, page, pageSize int) ([]*models.Wish, int64, error) {
+	wedding, err := s.getPublishedWedding(ctx, slug)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.wishRepo.ListByWedding(ctx, wedding.ID, models.WishApproved, page, pageSize)
+}
+
+// ListWishes lists the wishes of a wedding for its editors, newest first,
+// optionally in one status
+func (s *WishService) ListWishes(ctx context.Context, weddingID, userID models.ID, status models.WishStatus, page, pageSize int) ([]*models.Wish, int64, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, 0, err
+	}
+	if status != "" && !isWishStatus(status) {
+		return nil, 0, fmt.Errorf("%w: %q", ErrInvalidWishStatus, status)
+	}
+	return s.wishRepo.ListByWedding(ctx, weddingID, status, page, pageSize)
+}
+
+// ModerateWish shows a wish on the public wall or hides it. Hidden wishes
+// can be approved again later.
+func (s *WishService) ModerateWish(ctx context.Context, weddingID, wishID, userID models.ID, approve bool) (*models.Wish, error) {
+	wish, err := s.getWish(ctx, weddingID, wishID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := models.WishHidden
+	if approve {
+		status = models.WishApproved
+	}
+	now := time.Now()
+	if err := s.wishRepo.SetStatus(ctx, wish.ID, status, userID, now); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWishNotFound
+		}
+		return nil, fmt.Errorf("failed to moderate wish: %w", err)
+	}
+	wish.Status = status
+	wish.ModeratedBy = &userID
+	wish.ModeratedAt = &now
+	return wish, nil
+}
+
+// DeleteWish removes a wish from a wedding's guestbook
+func (s *WishService) DeleteWish(ctx context.Context, weddingID, wishID, userID models.ID) error {
+	wish, err := s.getWish(ctx, weddingID, wishID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.wishRepo.Delete(ctx, wish.ID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWishNotFound
+		}
+		return fmt.Errorf("failed to delete wish: %w", err)
+	}
+	return nil
+}
+
+// getWish returns a wish of a wedding the user may moderate
+func (s *WishService) getWish(ctx context.Context, weddingID, wishID, userID models.ID) (*models.Wish, error) {
+	if _, err := s.getEditableWedding(ctx, weddingID, userID); err != nil {
+		return nil, err
+	}
+
+	wish, err := s.wishRepo.GetByID(ctx, wishID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWishNotFound
+		}
+		return nil, fmt.Errorf("failed to get wish: %w", err)
+	}
+	if wish.WeddingID != weddingID {
+		return nil, ErrWishNotFound
+	}
+	return wish, nil
+}
+
+// getPublishedWedding returns a wedding guests can see, hiding unpublished ones
+func (s *WishService) getPublishedWedding(ctx context.Context, slug string) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	// The wedding repository reports a missing wedding as nil
+	if wedding == nil || !wedding.IsAccessible() {
+		return nil, ErrWeddingNotFound
+	}
+	return wedding, nil
+}
+
+// getEditableWedding returns a wedding the user may moderate the guestbook of
+func (s *WishService) getEditableWedding(ctx context.Context, weddingID, userID models.ID) (*models.Wedding, error) {
+	wedding, err := s.weddingRepo.GetByID(ctx, weddingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, fmt.Errorf("failed to get wedding: %w", err)
+	}
+	if wedding == nil {
+		return nil, ErrWeddingNotFound
+	}
+	if !wedding.Can(userID, models.PermissionEditWedding) {
+		return nil, ErrUnauthorized
+	}
+	return wedding, nil
+}
+
+func isWishStatus(status models.WishStatus) bool {
+	switch status {
+	case models.WishPending, models.WishApproved, models.WishHidden:
+		return true
+	}
+	return false
+}
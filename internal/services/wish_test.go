@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"wedding-invitation-backend/internal/domain/models"
+	"wedding-invitation-backend/internal/domain/repository"
+)
+
+// MockWishRepository is an in-memory guestbook wish repository
+type MockWishRepository struct {
+	wishes map[models.ID]*models.Wish
+}
+
+func NewMockWishRepository() *MockWishRepository {
+	return &MockWishRepository{
+		wishes: make(map[models.ID]*models.Wish),
+	}
+}
+
+func (m *MockWishRepository) Create(ctx context.Context, wish *models.Wish) error {
+	wish.ID = models.NewID()
+	copied := *wish
+	m.wishes[wish.ID] = &copied
+	return nil
+}
+
+func (m *MockWishRepository) GetByID(ctx context.Context, id models.ID) (*models.Wish, error) {
+	wish, ok := m.wishes[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	copied := *wish
+	return &copied, nil
+}
+
+func (m *MockWishRepository) SetStatus(ctx context.Context, id models.ID, status models.WishStatus, moderatedBy models.ID, moderatedAt time.Time) error {
+	wish, ok := m.wishes[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	wish.Status = status
+	wish.ModeratedBy = &moderatedBy
+	wish.ModeratedAt = &moderatedAt
+	return nil
+}
+
+func (m *MockWishRepository) Delete(ctx context.Context, id models.ID) error {
+	if _, ok := m.wishes[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.wishes, id)
+	return nil
+}
+
+func (m *MockWishRepository) ListByWedding(ctx context.Context, weddingID models.ID, status models.WishStatus, page, pageSize int) ([]*models.Wish, int64, error) {
+	wishes := []*models.Wish{}
+	for _, wish := range m.wishes {
+		if wish.WeddingID == weddingID && (status == "" || wish.Status == status) {
+			copied := *wish
+			wishes = append(wishes, &copied)
+		}
+	}
+	return wishes, int64(len(wishes)), nil
+}
+
+func wishTestWedding(ownerID models.ID) *models.Wedding {
+	return &models.Wedding{
+		ID:       models.NewID(),
+		UserID:   ownerID,
+		Slug:     "jane-john",
+		Status:   string(models.WeddingStatusPublished),
+		IsPublic: true,
+		Wishes:   models.WishSettings{Enabled: true, RequireApproval: true},
+	}
+}
+
+func TestProfanityFilter_Clean(t *testing.T) {
+	filter := NewProfanityFilter("shit", "bangsat")
+
+	cleaned, filtered := filter.Clean("Holy SHIT, congrats! Bangsat... shitake anyone?")
+	assert.True(t, filtered)
+	assert.Equal(t, "Holy ****, congrats! *******... shitake anyone?", cleaned)
+
+	cleaned, filtered = filter.Clean("Selamat menempuh hidup baru")
+	assert.False(t, filtered)
+	assert.Equal(t, "Selamat menempuh hidup baru", cleaned)
+}
+
+func TestWishService_CreateWish(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+
+	setup := func(wedding *models.Wedding) (*MockWishRepository, *WishService) {
+		wishes := NewMockWishRepository()
+		weddings := new(MockWeddingRepository)
+		weddings.On("GetBySlug", ctx, wedding.Slug).Return(wedding, nil)
+		return wishes, NewWishService(wishes, weddings, zaptest.NewLogger(t))
+	}
+
+	t.Run("Success - held for approval with profanity masked", func(t *testing.T) {
+		wedding := wishTestWedding(ownerID)
+		wishes, service := setup(wedding)
+
+		wish, err := service.CreateWish(ctx, wedding.Slug, WishRequest{
+			GuestName: " Alice ",
+			Message:   "Congrats, you lucky bastard!",
+			IP:        "203.0.113.7",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", wish.GuestName)
+		assert.Equal(t, "Congrats, you lucky *******!", wish.Message)
+		assert.True(t, wish.Filtered)
+		assert.Equal(t, models.WishPending, wish.Status)
+		assert.Contains(t, wishes.wishes, wish.ID)
+	})
+
+	t.Run("Success - shown right away without approval", func(t *testing.T) {
+		wedding := wishTestWedding(ownerID)
+		wedding.Wishes.RequireApproval = false
+		_, service := setup(wedding)
+
+		wish, err := service.CreateWish(ctx, wedding.Slug, WishRequest{GuestName: "Alice", Message: "Congratulations!"})
+		require.NoError(t, err)
+		assert.Equal(t, models.WishApproved, wish.Status)
+		assert.False(t, wish.Filtered)
+	})
+
+	t.Run("Error - wishes disabled", func(t *testing.T) {
+		wedding := wishTestWedding(ownerID)
+		wedding.Wishes.Enabled = false
+		_, service := setup(wedding)
+
+		_, err := service.CreateWish(ctx, wedding.Slug, WishRequest{GuestName: "Alice", Message: "Congratulations!"})
+		assert.ErrorIs(t, err, ErrWishesDisabled)
+	})
+
+	t.Run("Error - wishes feature off", func(t *testing.T) {
+		wedding := wishTestWedding(ownerID)
+		_, service := setup(wedding)
+		service.SetFeatureFlags(staticFeatures{FeatureWishes: false})
+
+		_, err := service.CreateWish(ctx, wedding.Slug, WishRequest{GuestName: "Alice", Message: "Congratulations!"})
+		assert.ErrorIs(t, err, ErrWishesDisabled)
+	})
+
+	t.Run("Error - blank message", func(t *testing.T) {
+		wedding := wishTestWedding(ownerID)
+		_, service := setup(wedding)
+
+		_, err := service.CreateWish(ctx, wedding.Slug, WishRequest{GuestName: "Alice", Message: "   "})
+		assert.ErrorIs(t, err, ErrInvalidWish)
+	})
+
+	t.Run("Error - unpublished wedding", func(t *testing.T) {
+		wedding := wishTestWedding(ownerID)
+		wedding.Status = string(models.WeddingStatusDraft)
+		_, service := setup(wedding)
+
+		_, err := service.CreateWish(ctx, wedding.Slug, WishRequest{GuestName: "Alice", Message: "Congratulations!"})
+		assert.ErrorIs(t, err, ErrWeddingNotFound)
+	})
+}
+
+func TestWishService_Moderation(t *testing.T) {
+	ctx := context.Background()
+	ownerID := models.NewID()
+	wedding := wishTestWedding(ownerID)
+	wishes := NewMockWishRepository()
+	weddings := new(MockWeddingRepository)
+	weddings.On("GetByID", ctx, wedding.ID).Return(wedding, nil)
+	weddings.On("GetBySlug", ctx, wedding.Slug).Return(wedding, nil)
+	service := NewWishService(wishes, weddings, zaptest.NewLogger(t))
+
+	wish := &models.Wish{WeddingID: wedding.ID, GuestName: "Alice", Message: "Congratulations!", Status: models.WishPending}
+	require.NoError(t, wishes.Create(ctx, wish))
+
+	public, total, err := service.ListPublicWishes(ctx, wedding.Slug, 1, 20)
+	require.NoError(t, err)
+	assert.Empty(t, public)
+	assert.Zero(t, total)
+
+	approved, err := service.ModerateWish(ctx, wedding.ID, wish.ID, ownerID, true)
+	require.NoError(t, err)
+	assert.Equal(t, models.WishApproved, approved.Status)
+	assert.Equal(t, ownerID, *approved.ModeratedBy)
+
+	public, _, err = service.ListPublicWishes(ctx, wedding.Slug, 1, 20)
+	require.NoError(t, err)
+	assert.Len(t, public, 1)
+
+	hidden, err := service.ModerateWish(ctx, wedding.ID, wish.ID, ownerID, false)
+	require.NoError(t, err)
+	assert.Equal(t, models.WishHidden, hidden.Status)
+
+	listed, _, err := service.ListWishes(ctx, wedding.ID, ownerID, models.WishHidden, 1, 20)
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+
+	_, _, err = service.ListWishes(ctx, wedding.ID, ownerID, "spam", 1, 20)
+	assert.ErrorIs(t, err, ErrInvalidWishStatus)
+
+	_, err = service.ModerateWish(ctx, wedding.ID, wish.ID, models.NewID(), true)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	other := &models.Wish{WeddingID: models.NewID(), Status: models.WishApproved}
+	require.NoError(t, wishes.Create(ctx, other))
+	assert.ErrorIs(t, service.DeleteWish(ctx, wedding.ID, other.ID, ownerID), ErrWishNotFound)
+
+	require.NoError(t, service.DeleteWish(ctx, wedding.ID, wish.ID, ownerID))
+	assert.NotContains(t, wishes.wishes, wish.ID)
+}
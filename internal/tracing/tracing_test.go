@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"wedding-invitation-backend/internal/config"
+)
+
+// recordSpans routes spans to an in-memory recorder for the rest of the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestSetup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Disabled without an exporter", func(t *testing.T) {
+		shutdown, err := Setup(ctx, config.TracingConfig{}, "api", "test")
+		require.NoError(t, err)
+		assert.NoError(t, shutdown(ctx))
+	})
+
+	t.Run("Unsupported exporter", func(t *testing.T) {
+		_, err := Setup(ctx, config.TracingConfig{Exporter: "zipkin"}, "api", "test")
+		assert.Error(t, err)
+	})
+
+	t.Run("Jaeger exporter", func(t *testing.T) {
+		previous := otel.GetTracerProvider()
+		defer otel.SetTracerProvider(previous)
+
+		shutdown, err := Setup(ctx, config.TracingConfig{Exporter: ExporterJaeger, Insecure: true, SampleRatio: 1}, "api", "test")
+		require.NoError(t, err)
+		assert.NoError(t, shutdown(ctx))
+	})
+}
+
+func TestEnd(t *testing.T) {
+	recorder := recordSpans(t)
+
+	_, span := Start(context.Background(), "ok")
+	End(span, nil)
+	_, span = Start(context.Background(), "failed")
+	End(span, errors.New("boom"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "boom", spans[1].Status().Description)
+}
+
+func TestInjectExtract(t *testing.T) {
+	recordSpans(t)
+	_, err := Setup(context.Background(), config.TracingConfig{}, "api", "test")
+	require.NoError(t, err)
+
+	assert.Nil(t, Inject(context.Background()))
+	assert.Empty(t, TraceID(context.Background()))
+
+	ctx, span := Start(context.Background(), "request")
+	defer span.End()
+	carrier := Inject(ctx)
+	require.NotEmpty(t, carrier)
+
+	// A context restored from the carrier continues the same trace
+	restored := Extract(context.Background(), carrier)
+	assert.Equal(t, TraceID(ctx), TraceID(restored))
+	assert.NotEmpty(t, TraceID(restored))
+}
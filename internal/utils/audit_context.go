@@ -0,0 +1,49 @@
+package utils
+
+import "context"
+
+// RequestClient is the network client a request came from
+type RequestClient struct {
+	IP        string
+	UserAgent string
+}
+
+// RequestActor is the authenticated account a request acts for. APIKeyID is set
+// when the request authenticated with an API key rather than a session.
+type RequestActor struct {
+	UserID   string
+	APIKeyID string
+}
+
+type requestClientContextKey struct{}
+
+type requestActorContextKey struct{}
+
+// WithClient returns a copy of ctx carrying the request's client
+func WithClient(ctx context.Context, client RequestClient) context.Context {
+	return context.WithValue(ctx, requestClientContextKey{}, client)
+}
+
+// ClientFromContext returns the client carried by ctx, or a zero client
+func ClientFromContext(ctx context.Context) RequestClient {
+	if ctx == nil {
+		return RequestClient{}
+	}
+	client, _ := ctx.Value(requestClientContextKey{}).(RequestClient)
+	return client
+}
+
+// WithActor returns a copy of ctx carrying the request's actor
+func WithActor(ctx context.Context, actor RequestActor) context.Context {
+	return context.WithValue(ctx, requestActorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor carried by ctx, or a zero actor for
+// unauthenticated requests
+func ActorFromContext(ctx context.Context) RequestActor {
+	if ctx == nil {
+		return RequestActor{}
+	}
+	actor, _ := ctx.Value(requestActorContextKey{}).(RequestActor)
+	return actor
+}
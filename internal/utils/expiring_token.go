@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// expiringTokenSize is the decoded size of an expiring token: 12 ID bytes, a
+// 4-byte expiry in Unix seconds and 16 MAC bytes, 43 characters once encoded
+const expiringTokenSize = 12 + 4 + guestTokenMACSize
+
+// signExpiringToken returns a URL-safe token carrying id and expiresAt, signed
+// with secret for one purpose so that tokens of other purposes do not verify
+func signExpiringToken(secret, purpose string, id models.ID, expiresAt time.Time) string {
+	payload := make([]byte, 0, expiringTokenSize)
+	payload = append(payload, id.Bytes()...)
+	payload = binary.BigEndian.AppendUint32(payload, uint32(expiresAt.Unix()))
+	payload = append(payload, expiringTokenMAC(secret, purpose, payload)...)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// verifyExpiringToken checks a token produced by signExpiringToken for the same
+// purpose in constant time and returns the ID and expiry it carries. ok is false
+// for tokens that are malformed or not signed with the secret.
+func verifyExpiringToken(secret, purpose, token string) (id models.ID, expiresAt time.Time, ok bool) {
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(payload) != expiringTokenSize {
+		return models.NilID, time.Time{}, false
+	}
+
+	signed := payload[:expiringTokenSize-guestTokenMACSize]
+	if !hmac.Equal(payload[len(signed):], expiringTokenMAC(secret, purpose, signed)) {
+		return models.NilID, time.Time{}, false
+	}
+
+	id, err = models.IDFromBytes(signed[:12])
+	if err != nil {
+		return models.NilID, time.Time{}, false
+	}
+	return id, time.Unix(int64(binary.BigEndian.Uint32(signed[12:])), 0), true
+}
+
+func expiringTokenMAC(secret, purpose string, signed []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose + ":"))
+	mac.Write(signed)
+	return mac.Sum(nil)[:guestTokenMACSize]
+}
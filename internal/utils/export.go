@@ -0,0 +1,291 @@
+package utils
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ExportFlushInterval is the number of records written between flushes of a streamed export.
+// Flushing hands data to the client in bounded chunks, so a slow reader blocks the writer
+// instead of the export piling up in memory.
+const ExportFlushInterval = 200
+
+// RecordWriter streams export records to an output one at a time
+type RecordWriter interface {
+	WriteRecord(record interface{}) error
+	// Close terminates the output and flushes anything still buffered
+	Close() error
+}
+
+// jsonRecordWriter writes records as {"data":[...]} with a json.Encoder
+type jsonRecordWriter struct {
+	w       io.Writer
+	enc     *json.Encoder
+	flusher http.Flusher
+	count   int
+}
+
+// NewJSONRecordWriter creates a writer that streams records as a {"data": [...]} document.
+// If w is an http.Flusher it is flushed every ExportFlushInterval records.
+func NewJSONRecordWriter(w io.Writer) RecordWriter {
+	flusher, _ := w.(http.Flusher)
+	return &jsonRecordWriter{
+		w:       w,
+		enc:     json.NewEncoder(w),
+		flusher: flusher,
+	}
+}
+
+func (j *jsonRecordWriter) WriteRecord(record interface{}) error {
+	prefix := ","
+	if j.count == 0 {
+		prefix = `{"data":[`
+	}
+	if _, err := io.WriteString(j.w, prefix); err != nil {
+		return err
+	}
+	if err := j.enc.Encode(record); err != nil {
+		return err
+	}
+
+	j.count++
+	if j.flusher != nil && j.count%ExportFlushInterval == 0 {
+		j.flusher.Flush()
+	}
+	return nil
+}
+
+func (j *jsonRecordWriter) Close() error {
+	suffix := "]}\n"
+	if j.count == 0 {
+		suffix = `{"data":[]}` + "\n"
+	}
+	if _, err := io.WriteString(j.w, suffix); err != nil {
+		return err
+	}
+	if j.flusher != nil {
+		j.flusher.Flush()
+	}
+	return nil
+}
+
+// csvRecordWriter writes records as CSV rows with a csv.Writer
+type csvRecordWriter struct {
+	w       *csv.Writer
+	header  []string
+	row     func(record interface{}) []string
+	flusher http.Flusher
+	count   int
+}
+
+// NewCSVRecordWriter creates a writer that streams records as CSV. The header is
+// written before the first row; row converts each record into its columns.
+func NewCSVRecordWriter(w io.Writer, header []string, row func(record interface{}) []string) RecordWriter {
+	flusher, _ := w.(http.Flusher)
+	return &csvRecordWriter{
+		w:       csv.NewWriter(w),
+		header:  header,
+		row:     row,
+		flusher: flusher,
+	}
+}
+
+func (c *csvRecordWriter) WriteRecord(record interface{}) error {
+	if c.count == 0 {
+		if err := c.w.Write(c.header); err != nil {
+			return err
+		}
+	}
+	if err := c.w.Write(c.row(record)); err != nil {
+		return err
+	}
+
+	c.count++
+	if c.count%ExportFlushInterval == 0 {
+		return c.flush()
+	}
+	return nil
+}
+
+func (c *csvRecordWriter) Close() error {
+	if c.count == 0 {
+		if err := c.w.Write(c.header); err != nil {
+			return err
+		}
+	}
+	return c.flush()
+}
+
+func (c *csvRecordWriter) flush() error {
+	c.w.Flush()
+	if err := c.w.Error(); err != nil {
+		return err
+	}
+	if c.flusher != nil {
+		c.flusher.Flush()
+	}
+	return nil
+}
+
+// xlsxStaticParts are the workbook parts written before the streamed worksheet. The
+// worksheet must be the last zip entry since entries can't be interleaved.
+var xlsxStaticParts = []struct{ name, body string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// xlsxRecordWriter writes records as rows of a single-sheet XLSX workbook. Cells are
+// inline strings, so nothing but the zip compressor's window is held in memory.
+type xlsxRecordWriter struct {
+	zip     *zip.Writer
+	sheet   io.Writer
+	name    string
+	header  []string
+	row     func(record interface{}) []string
+	flusher http.Flusher
+	count   int
+}
+
+// NewXLSXRecordWriter creates a writer that streams records as an XLSX workbook with
+// one sheet called sheetName. The header is the first row; row converts each record
+// into its columns.
+func NewXLSXRecordWriter(w io.Writer, sheetName string, header []string, row func(record interface{}) []string) RecordWriter {
+	flusher, _ := w.(http.Flusher)
+	return &xlsxRecordWriter{
+		zip:     zip.NewWriter(w),
+		name:    sheetName,
+		header:  header,
+		row:     row,
+		flusher: flusher,
+	}
+}
+
+func (x *xlsxRecordWriter) WriteRecord(record interface{}) error {
+	if x.sheet == nil {
+		if err := x.start(); err != nil {
+			return err
+		}
+	}
+	if err := x.writeRow(x.count+2, x.row(record)); err != nil {
+		return err
+	}
+
+	x.count++
+	if x.count%ExportFlushInterval == 0 {
+		return x.flush()
+	}
+	return nil
+}
+
+func (x *xlsxRecordWriter) Close() error {
+	if x.sheet == nil {
+		if err := x.start(); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(x.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	if err := x.zip.Close(); err != nil {
+		return err
+	}
+	if x.flusher != nil {
+		x.flusher.Flush()
+	}
+	return nil
+}
+
+// start writes the workbook parts and opens the worksheet with its header row
+func (x *xlsxRecordWriter) start() error {
+	for _, part := range xlsxStaticParts {
+		if err := x.writePart(part.name, part.body); err != nil {
+			return err
+		}
+	}
+
+	var name strings.Builder
+	if err := xml.EscapeText(&name, []byte(x.name)); err != nil {
+		return err
+	}
+	workbook := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="` + name.String() + `" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	if err := x.writePart("xl/workbook.xml", workbook); err != nil {
+		return err
+	}
+
+	sheet, err := x.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	x.sheet = sheet
+	if _, err := io.WriteString(sheet, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return err
+	}
+	return x.writeRow(1, x.header)
+}
+
+func (x *xlsxRecordWriter) writePart(name, body string) error {
+	part, err := x.zip.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(part, body)
+	return err
+}
+
+func (x *xlsxRecordWriter) writeRow(number int, cells []string) error {
+	var b strings.Builder
+	rowRef := strconv.Itoa(number)
+	b.WriteString(`<row r="` + rowRef + `">`)
+	for i, cell := range cells {
+		b.WriteString(`<c r="` + xlsxColumn(i) + rowRef + `" t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(&b, []byte(cell)); err != nil {
+			return err
+		}
+		b.WriteString(`</t></is></c>`)
+	}
+	b.WriteString(`</row>`)
+	_, err := io.WriteString(x.sheet, b.String())
+	return err
+}
+
+func (x *xlsxRecordWriter) flush() error {
+	if err := x.zip.Flush(); err != nil {
+		return err
+	}
+	if x.flusher != nil {
+		x.flusher.Flush()
+	}
+	return nil
+}
+
+// xlsxColumn returns the letters of the zero-based column index, e.g. 0 => A, 27 => AB
+func xlsxColumn(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
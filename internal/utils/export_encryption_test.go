@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encryptExport(t *testing.T, recipient ExportRecipient, plaintext string) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	w, err := recipient.Encrypt(&out)
+	require.NoError(t, err)
+	_, err = io.WriteString(w, plaintext)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return out.Bytes()
+}
+
+func TestParseExportRecipient_Age(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	recipient, err := ParseExportRecipient("  " + identity.Recipient().String() + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, ExportKeyAge, recipient.Type())
+	assert.True(t, strings.HasPrefix(recipient.Fingerprint(), "SHA256:"))
+	assert.Equal(t, ".age", recipient.FileExtension())
+
+	plaintext := strings.Repeat(`{"first_name":"John"},`, 10000)
+	ciphertext := encryptExport(t, recipient, plaintext)
+	assert.NotContains(t, string(ciphertext), "John")
+
+	r, err := age.Decrypt(bytes.NewReader(ciphertext), identity)
+	require.NoError(t, err)
+	decrypted, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, string(decrypted))
+}
+
+func TestParseExportRecipient_PGP(t *testing.T) {
+	entity, err := openpgp.NewEntity("Wedding Owner", "", "owner@example.com", nil)
+	require.NoError(t, err)
+
+	var armored bytes.Buffer
+	w, err := armor.Encode(&armored, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	recipient, err := ParseExportRecipient(armored.String())
+	require.NoError(t, err)
+	assert.Equal(t, ExportKeyPGP, recipient.Type())
+	assert.Len(t, recipient.Fingerprint(), len(entity.PrimaryKey.Fingerprint)*2)
+	assert.Equal(t, strings.ToUpper(recipient.Fingerprint()), recipient.Fingerprint())
+
+	ciphertext := encryptExport(t, recipient, "id,first_name\n1,John\n")
+
+	md, err := openpgp.ReadMessage(bytes.NewReader(ciphertext), openpgp.EntityList{entity}, nil, nil)
+	require.NoError(t, err)
+	decrypted, err := io.ReadAll(md.UnverifiedBody)
+	require.NoError(t, err)
+	assert.Equal(t, "id,first_name\n1,John\n", string(decrypted))
+}
+
+func TestParseExportRecipient_Invalid(t *testing.T) {
+	for _, key := range []string{
+		"",
+		"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI",
+		"age1notavalidrecipient",
+		"-----BEGIN PGP PUBLIC KEY BLOCK-----\n\ngarbage\n-----END PGP PUBLIC KEY BLOCK-----",
+	} {
+		_, err := ParseExportRecipient(key)
+		assert.ErrorIs(t, err, ErrInvalidExportKey, key)
+	}
+}
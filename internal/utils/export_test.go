@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// readXLSXPart returns the content of a part of an XLSX workbook
+func readXLSXPart(t *testing.T, workbook []byte, name string) string {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(workbook), int64(len(workbook)))
+	if err != nil {
+		t.Fatalf("workbook is not a zip archive: %v", err)
+	}
+	for _, file := range archive.File {
+		if file.Name != name {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", name, err)
+		}
+		defer rc.Close()
+		content, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		return string(content)
+	}
+	t.Fatalf("workbook has no %s", name)
+	return ""
+}
+
+func TestXLSXRecordWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewXLSXRecordWriter(&buf, "guests", []string{"name", "notes"}, func(record interface{}) []string {
+		return record.([]string)
+	})
+	for _, record := range [][]string{{"Ann", "Tom & Jerry <3"}, {"Bob", ""}} {
+		if err := writer.WriteRecord(record); err != nil {
+			t.Fatalf("WriteRecord() error = %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if workbook := readXLSXPart(t, buf.Bytes(), "xl/workbook.xml"); !strings.Contains(workbook, `name="guests"`) {
+		t.Errorf("workbook.xml does not name the sheet: %s", workbook)
+	}
+
+	sheet := readXLSXPart(t, buf.Bytes(), "xl/worksheets/sheet1.xml")
+	for _, want := range []string{
+		`<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">name</t></is></c>`,
+		`<c r="B2" t="inlineStr"><is><t xml:space="preserve">Tom &amp; Jerry &lt;3</t></is></c>`,
+		`<row r="3">`,
+		`</sheetData></worksheet>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet1.xml does not contain %q", want)
+		}
+	}
+}
+
+func TestXLSXRecordWriter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewXLSXRecordWriter(&buf, "rsvps", []string{"id"}, func(record interface{}) []string { return nil })
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	sheet := readXLSXPart(t, buf.Bytes(), "xl/worksheets/sheet1.xml")
+	if got := strings.Count(sheet, "<row "); got != 1 {
+		t.Errorf("empty export has %d rows, want only the header", got)
+	}
+}
+
+func TestXLSXColumn(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for index, want := range tests {
+		if got := xlsxColumn(index); got != want {
+			t.Errorf("xlsxColumn(%d) = %q, want %q", index, got, want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Query parameters of signed file URLs
+const (
+	FileURLExpiresParam   = "expires"
+	FileURLSignatureParam = "signature"
+)
+
+var (
+	// ErrInvalidFileSignature is returned for file URLs that are unsigned or not signed with the secret
+	ErrInvalidFileSignature = errors.New("invalid file signature")
+	ErrExpiredFileSignature = errors.New("file link has expired")
+)
+
+// SignFileURL returns the URL of a stored file under baseURL that downloads it
+// until expiresAt, signed with secret
+func SignFileURL(secret, baseURL, key string, expiresAt time.Time) string {
+	expires := expiresAt.Unix()
+	query := url.Values{}
+	query.Set(FileURLExpiresParam, strconv.FormatInt(expires, 10))
+	query.Set(FileURLSignatureParam, fileSignature(secret, key, expires))
+	return baseURL + "/" + key + "?" + query.Encode()
+}
+
+// VerifyFileSignature checks the expiry and signature query parameters of a
+// URL produced by SignFileURL for the file in constant time
+func VerifyFileSignature(secret, key, expires, signature string) error {
+	if secret == "" || signature == "" {
+		return ErrInvalidFileSignature
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidFileSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(fileSignature(secret, key, unix))) {
+		return ErrInvalidFileSignature
+	}
+	if time.Now().Unix() > unix {
+		return ErrExpiredFileSignature
+	}
+	return nil
+}
+
+func fileSignature(secret, key string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("file:" + strconv.FormatInt(expires, 10) + ":"))
+	mac.Write([]byte(key))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
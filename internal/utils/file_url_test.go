@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignFileURL(t *testing.T) {
+	key := "exports/account/user/job.zip"
+	signed := SignFileURL("secret", "http://localhost:8080/uploads", key, time.Now().Add(time.Hour))
+	if !strings.HasPrefix(signed, "http://localhost:8080/uploads/"+key+"?") {
+		t.Fatalf("SignFileURL() = %s", signed)
+	}
+
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+	expires := u.Query().Get(FileURLExpiresParam)
+	signature := u.Query().Get(FileURLSignatureParam)
+	if err := VerifyFileSignature("secret", key, expires, signature); err != nil {
+		t.Errorf("VerifyFileSignature() error = %v", err)
+	}
+
+	for name, args := range map[string][3]string{
+		"other key":     {"exports/account/user/other.zip", expires, signature},
+		"extended":      {key, "9999999999", signature},
+		"bad expiry":    {key, "soon", signature},
+		"missing":       {key, "", ""},
+		"other secret":  {key, expires, fileSignature("other", key, 0)},
+		"truncated sig": {key, expires, signature[:10]},
+	} {
+		if err := VerifyFileSignature("secret", args[0], args[1], args[2]); err != ErrInvalidFileSignature {
+			t.Errorf("%s: VerifyFileSignature() error = %v, want ErrInvalidFileSignature", name, err)
+		}
+	}
+
+	if err := VerifyFileSignature("", key, expires, signature); err != ErrInvalidFileSignature {
+		t.Errorf("no secret: VerifyFileSignature() error = %v, want ErrInvalidFileSignature", err)
+	}
+
+	expired := time.Now().Add(-time.Minute).Unix()
+	if err := VerifyFileSignature("secret", key, strconv.FormatInt(expired, 10), fileSignature("secret", key, expired)); err != ErrExpiredFileSignature {
+		t.Errorf("expired: VerifyFileSignature() error = %v, want ErrExpiredFileSignature", err)
+	}
+}
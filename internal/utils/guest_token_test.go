@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"testing"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func TestGuestToken(t *testing.T) {
+	guestID := models.NewID()
+	token := SignGuestToken("secret", guestID)
+
+	if len(token) != 38 {
+		t.Errorf("token length = %d, want 38", len(token))
+	}
+
+	got, err := VerifyGuestToken("secret", token)
+	if err != nil {
+		t.Fatalf("VerifyGuestToken() error = %v", err)
+	}
+	if got != guestID {
+		t.Errorf("VerifyGuestToken() = %s, want %s", got.String(), guestID.String())
+	}
+
+	// Another guest's ID with this guest's signature
+	other := models.NewID()
+	forged := SignGuestToken("secret", other)[:16] + token[16:]
+
+	for name, bad := range map[string]string{
+		"other secret": SignGuestToken("other", guestID),
+		"forged ID":    forged,
+		"truncated":    token[:20],
+		"not base64":   "not a token!",
+		"empty":        "",
+	} {
+		if _, err := VerifyGuestToken("secret", bad); err != ErrInvalidGuestToken {
+			t.Errorf("%s: VerifyGuestToken() error = %v, want ErrInvalidGuestToken", name, err)
+		}
+	}
+}
@@ -5,7 +5,8 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"wedding-invitation-backend/internal/domain/models"
 )
 
 var (
@@ -26,6 +27,10 @@ type Claims struct {
 	Email       string    `json:"email"`
 	TokenType   TokenType `json:"token_type"`
 	Permissions []string  `json:"permissions,omitempty"`
+	// SessionID is the signed-in session the token belongs to, if sessions are tracked
+	SessionID string `json:"sid,omitempty"`
+	// TenantID is the organization the user belongs to, if any
+	TenantID string `json:"tid,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -33,6 +38,9 @@ type TokenPair struct {
 	AccessToken  string    `json:"access_token"`
 	RefreshToken string    `json:"refresh_token"`
 	ExpiresAt    time.Time `json:"expires_at"`
+	// RefreshTokenID and RefreshExpiresAt identify the refresh token to its session
+	RefreshTokenID   string    `json:"-"`
+	RefreshExpiresAt time.Time `json:"-"`
 }
 
 type JWTManager struct {
@@ -53,19 +61,27 @@ func NewJWTManager(secretKey, refreshSecretKey string, accessTokenTTL, refreshTo
 	}
 }
 
-func (j *JWTManager) GenerateTokenPair(userID primitive.ObjectID, email string, permissions []string) (*TokenPair, error) {
+func (j *JWTManager) GenerateTokenPair(userID models.ID, email string, permissions []string) (*TokenPair, error) {
+	return j.GenerateSessionTokenPair(userID, email, permissions, "", "")
+}
+
+// GenerateSessionTokenPair generates tokens carrying the user's organization and
+// the ID of the session they belong to
+func (j *JWTManager) GenerateSessionTokenPair(userID models.ID, email string, permissions []string, tenantID, sessionID string) (*TokenPair, error) {
 	now := time.Now()
 
 	// Generate access token
 	accessClaims := &Claims{
-		UserID:      userID.Hex(),
+		UserID:      userID.String(),
 		Email:       email,
 		TokenType:   AccessToken,
 		Permissions: permissions,
+		SessionID:   sessionID,
+		TenantID:    tenantID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ID:        primitive.NewObjectID().Hex(),
+			ID:        models.NewID().String(),
 			Issuer:    j.issuer,
-			Subject:   userID.Hex(),
+			Subject:   userID.String(),
 			Audience:  []string{"wedding-invitation-api"},
 			ExpiresAt: jwt.NewNumericDate(now.Add(j.accessTokenTTL)),
 			NotBefore: jwt.NewNumericDate(now),
@@ -80,14 +96,16 @@ func (j *JWTManager) GenerateTokenPair(userID primitive.ObjectID, email string,
 
 	// Generate refresh token
 	refreshClaims := &Claims{
-		UserID:      userID.Hex(),
+		UserID:      userID.String(),
 		Email:       email,
 		TokenType:   RefreshToken,
 		Permissions: permissions,
+		SessionID:   sessionID,
+		TenantID:    tenantID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ID:        primitive.NewObjectID().Hex(),
+			ID:        models.NewID().String(),
 			Issuer:    j.issuer,
-			Subject:   userID.Hex(),
+			Subject:   userID.String(),
 			Audience:  []string{"wedding-invitation-api"},
 			ExpiresAt: jwt.NewNumericDate(now.Add(j.refreshTokenTTL)),
 			NotBefore: jwt.NewNumericDate(now),
@@ -101,9 +119,11 @@ func (j *JWTManager) GenerateTokenPair(userID primitive.ObjectID, email string,
 	}
 
 	return &TokenPair{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresAt:    now.Add(j.accessTokenTTL),
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		ExpiresAt:        now.Add(j.accessTokenTTL),
+		RefreshTokenID:   refreshClaims.ID,
+		RefreshExpiresAt: refreshClaims.ExpiresAt.Time,
 	}, nil
 }
 
@@ -168,21 +188,21 @@ func (j *JWTManager) RefreshAccessToken(refreshTokenString string) (*TokenPair,
 		return nil, err
 	}
 
-	userID, err := primitive.ObjectIDFromHex(claims.UserID)
+	userID, err := models.ParseID(claims.UserID)
 	if err != nil {
 		return nil, ErrInvalidToken
 	}
 
-	return j.GenerateTokenPair(userID, claims.Email, claims.Permissions)
+	return j.GenerateSessionTokenPair(userID, claims.Email, claims.Permissions, claims.TenantID, "")
 }
 
-func (j *JWTManager) ExtractUserIDFromToken(tokenString string) (primitive.ObjectID, error) {
+func (j *JWTManager) ExtractUserIDFromToken(tokenString string) (models.ID, error) {
 	claims, err := j.ValidateToken(tokenString, AccessToken)
 	if err != nil {
-		return primitive.NilObjectID, err
+		return models.NilID, err
 	}
 
-	return primitive.ObjectIDFromHex(claims.UserID)
+	return models.ParseID(claims.UserID)
 }
 
 func (j *JWTManager) ExtractEmailFromToken(tokenString string) (string, error) {
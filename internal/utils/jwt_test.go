@@ -4,7 +4,7 @@ import (
 	"testing"
 	"time"
 
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"wedding-invitation-backend/internal/domain/models"
 )
 
 func TestJWTManager(t *testing.T) {
@@ -17,7 +17,7 @@ func TestJWTManager(t *testing.T) {
 	)
 
 	// Test token generation
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	email := "test@example.com"
 	permissions := []string{"user"}
 
@@ -44,8 +44,8 @@ func TestJWTManager(t *testing.T) {
 		t.Fatalf("Failed to validate access token: %v", err)
 	}
 
-	if claims.UserID != userID.Hex() {
-		t.Errorf("Expected user ID %s, got %s", userID.Hex(), claims.UserID)
+	if claims.UserID != userID.String() {
+		t.Errorf("Expected user ID %s, got %s", userID.String(), claims.UserID)
 	}
 
 	if claims.Email != email {
@@ -120,6 +120,35 @@ func TestJWTManager(t *testing.T) {
 	}
 }
 
+func TestJWTManager_TenantClaim(t *testing.T) {
+	jwtManager := NewJWTManager("test-secret-key", "test-refresh-secret-key", 15*time.Minute, time.Hour, "test-issuer")
+
+	tokenPair, err := jwtManager.GenerateSessionTokenPair(models.NewID(), "test@example.com", []string{"admin"}, "acme", "")
+	if err != nil {
+		t.Fatalf("Failed to generate token pair: %v", err)
+	}
+	claims, err := jwtManager.ValidateToken(tokenPair.AccessToken, AccessToken)
+	if err != nil {
+		t.Fatalf("Failed to validate access token: %v", err)
+	}
+	if claims.TenantID != "acme" {
+		t.Errorf("Expected tenant acme, got %q", claims.TenantID)
+	}
+
+	// A refreshed token keeps the organization
+	refreshed, err := jwtManager.RefreshAccessToken(tokenPair.RefreshToken)
+	if err != nil {
+		t.Fatalf("Failed to refresh token: %v", err)
+	}
+	claims, err = jwtManager.ValidateToken(refreshed.AccessToken, AccessToken)
+	if err != nil {
+		t.Fatalf("Failed to validate refreshed token: %v", err)
+	}
+	if claims.TenantID != "acme" {
+		t.Errorf("Expected refreshed tenant acme, got %q", claims.TenantID)
+	}
+}
+
 func TestJWTManager_ExpiredToken(t *testing.T) {
 	jwtManager := NewJWTManager(
 		"test-secret-key",
@@ -129,7 +158,7 @@ func TestJWTManager_ExpiredToken(t *testing.T) {
 		"test-issuer",
 	)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	tokenPair, err := jwtManager.GenerateTokenPair(userID, "test@example.com", []string{"user"})
 	if err != nil {
 		t.Fatalf("Failed to generate token pair: %v", err)
@@ -158,7 +187,7 @@ func TestJWTManager_WrongTokenType(t *testing.T) {
 		"test-issuer",
 	)
 
-	userID := primitive.NewObjectID()
+	userID := models.NewID()
 	tokenPair, err := jwtManager.GenerateTokenPair(userID, "test@example.com", []string{"user"})
 	if err != nil {
 		t.Fatalf("Failed to generate token pair: %v", err)
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"errors"
+
+	"golang.org/x/text/language"
+)
+
+// ErrInvalidLanguage is returned for a language that is not a BCP 47 tag
+var ErrInvalidLanguage = errors.New("language must be a BCP 47 tag, e.g. en or id")
+
+// NormalizeLanguage returns the canonical form of a BCP 47 language tag, e.g.
+// "en-US" for "EN_us"
+func NormalizeLanguage(tag string) (string, error) {
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return "", ErrInvalidLanguage
+	}
+	return parsed.String(), nil
+}
+
+// NegotiateLanguage picks the supported language that best matches what the
+// client asked for. An explicitly requested language, such as a lang query
+// parameter, goes before the Accept-Language header; the first supported
+// language is the fallback when neither matches.
+func NegotiateLanguage(requested, acceptLanguage string, supported []string) string {
+	if len(supported) == 0 {
+		return ""
+	}
+
+	tags := make([]language.Tag, 0, len(supported))
+	indexes := make([]int, 0, len(supported))
+	for i, lang := range supported {
+		if tag, err := language.Parse(lang); err == nil {
+			tags = append(tags, tag)
+			indexes = append(indexes, i)
+		}
+	}
+	if len(tags) == 0 {
+		return supported[0]
+	}
+
+	var preferred []language.Tag
+	if tag, err := language.Parse(requested); requested != "" && err == nil {
+		preferred = append(preferred, tag)
+	}
+	if accepted, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil {
+		preferred = append(preferred, accepted...)
+	}
+
+	_, index, confidence := language.NewMatcher(tags).Match(preferred...)
+	if confidence == language.No {
+		return supported[0]
+	}
+	return supported[indexes[index]]
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateLanguage(t *testing.T) {
+	supported := []string{"en", "id"}
+
+	tests := []struct {
+		name      string
+		requested string
+		accept    string
+		expected  string
+	}{
+		{name: "nothing asked", expected: "en"},
+		{name: "requested", requested: "id", accept: "en", expected: "id"},
+		{name: "requested region", requested: "id-ID", expected: "id"},
+		{name: "accept language", accept: "id-ID,id;q=0.9,en;q=0.8", expected: "id"},
+		{name: "accept language by weight", accept: "fr;q=0.9,id;q=0.5,en;q=0.7", expected: "en"},
+		{name: "unsupported", requested: "ja", accept: "fr", expected: "en"},
+		{name: "malformed", requested: "not a tag!", accept: ";;;", expected: "en"},
+		{name: "unsupported request falls back to accept language", requested: "ja", accept: "id", expected: "id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NegotiateLanguage(tt.requested, tt.accept, supported))
+		})
+	}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	lang, err := NormalizeLanguage("EN-us")
+	assert.NoError(t, err)
+	assert.Equal(t, "en-US", lang)
+
+	_, err = NormalizeLanguage("english please")
+	assert.ErrorIs(t, err, ErrInvalidLanguage)
+}
@@ -0,0 +1,32 @@
+package utils
+
+import "strings"
+
+// maskFiller replaces the hidden part of a masked value
+const maskFiller = "***"
+
+// MaskEmail hides the local part of an email except its first character,
+// e.g. john.doe@gmail.com becomes j***@gmail.com
+func MaskEmail(email string) string {
+	if email == "" {
+		return ""
+	}
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return maskFiller
+	}
+	return email[:1] + maskFiller + email[at:]
+}
+
+// MaskPhone keeps the first three and last four characters of a phone number,
+// e.g. +6281234561234 becomes +62***1234. Short numbers are fully masked.
+func MaskPhone(phone string) string {
+	if phone == "" {
+		return ""
+	}
+	phone = strings.TrimSpace(phone)
+	if len(phone) < 8 {
+		return maskFiller
+	}
+	return phone[:3] + maskFiller + phone[len(phone)-4:]
+}
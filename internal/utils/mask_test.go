@@ -0,0 +1,31 @@
+package utils
+
+import "testing"
+
+func TestMaskEmail(t *testing.T) {
+	tests := map[string]string{
+		"john.doe@gmail.com": "j***@gmail.com",
+		"a@example.com":      "a***@example.com",
+		"not-an-email":       "***",
+		"":                   "",
+	}
+	for input, expected := range tests {
+		if got := MaskEmail(input); got != expected {
+			t.Errorf("MaskEmail(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestMaskPhone(t *testing.T) {
+	tests := map[string]string{
+		"+6281234561234": "+62***1234",
+		"555-1234":       "555***1234",
+		"12345":          "***",
+		"":               "",
+	}
+	for input, expected := range tests {
+		if got := MaskPhone(input); got != expected {
+			t.Errorf("MaskPhone(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package utils
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Page sizes in points
+const (
+	PDFA5Width  = 419.53
+	PDFA5Height = 595.28
+	PDFA4Width  = 595.28
+	PDFA4Height = 841.89
+)
+
+// Standard PDF fonts, which every reader has, so that documents need not embed fonts
+const (
+	PDFHelvetica     = "Helvetica"
+	PDFHelveticaBold = "Helvetica-Bold"
+	PDFTimes         = "Times-Roman"
+	PDFTimesBold     = "Times-Bold"
+)
+
+var pdfFonts = []string{PDFHelvetica, PDFHelveticaBold, PDFTimes, PDFTimesBold}
+
+// PDFDocument builds a PDF of pages with text and rectangles. Text is
+// set in the standard fonts with the Windows-1252 encoding; characters outside
+// of it are printed as "?".
+type PDFDocument struct {
+	width  float64
+	height float64
+	pages  []*PDFPage
+}
+
+// NewPDFDocument creates a document of pages of the given size in points
+func NewPDFDocument(width, height float64) *PDFDocument {
+	return &PDFDocument{width: width, height: height}
+}
+
+// PDFPage is a page of a PDFDocument. Positions are in points from the top left
+// corner of the page.
+type PDFPage struct {
+	doc     *PDFDocument
+	content bytes.Buffer
+}
+
+// AddPage appends a blank page to the document
+func (d *PDFDocument) AddPage() *PDFPage {
+	page := &PDFPage{doc: d}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// FillRect fills a rectangle
+func (p *PDFPage) FillRect(x, y, width, height float64, c color.Color) {
+	fmt.Fprintf(&p.content, "%s rg %s %s %s %s re f\n",
+		pdfColor(c), pdfNumber(x), pdfNumber(p.doc.height-y-height), pdfNumber(width), pdfNumber(height))
+}
+
+// StrokeRect draws the outline of a rectangle
+func (p *PDFPage) StrokeRect(x, y, width, height, lineWidth float64, c color.Color) {
+	fmt.Fprintf(&p.content, "%s RG %s w %s %s %s %s re S\n",
+		pdfColor(c), pdfNumber(lineWidth), pdfNumber(x), pdfNumber(p.doc.height-y-height), pdfNumber(width), pdfNumber(height))
+}
+
+// Text writes a line of text whose baseline is at y
+func (p *PDFPage) Text(x, y float64, font string, size float64, c color.Color, text string) {
+	fmt.Fprintf(&p.content, "BT %s rg /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		pdfColor(c), pdfFontIndex(font)+1, pdfNumber(size), pdfNumber(x), pdfNumber(p.doc.height-y), pdfString(text))
+}
+
+// CenteredText writes a line of text centered on the page
+func (p *PDFPage) CenteredText(y float64, font string, size float64, c color.Color, text string) {
+	p.Text((p.doc.width-PDFTextWidth(font, size, text))/2, y, font, size, c, text)
+}
+
+// WriteTo writes the document
+func (d *PDFDocument) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body []byte) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n", len(offsets))
+		buf.Write(body)
+		buf.WriteString("\nendobj\n")
+	}
+
+	// Objects are numbered: the catalog, the page tree, the fonts, then each
+	// page followed by its content stream
+	fontBase := 3
+	pageBase := fontBase + len(pdfFonts)
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object([]byte("<< /Type /Catalog /Pages 2 0 R >>"))
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", pageBase+2*i)
+	}
+	object([]byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages))))
+
+	var resources strings.Builder
+	resources.WriteString("<< /Font <<")
+	for i, font := range pdfFonts {
+		object([]byte(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font)))
+		fmt.Fprintf(&resources, " /F%d %d 0 R", i+1, fontBase+i)
+	}
+	resources.WriteString(" >> >>")
+
+	for i, page := range d.pages {
+		object([]byte(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources %s /Contents %d 0 R >>",
+			pdfNumber(d.width), pdfNumber(d.height), resources.String(), pageBase+2*i+1)))
+		stream, err := pdfStream(page.content.Bytes())
+		if err != nil {
+			return 0, err
+		}
+		object(stream)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.WriteTo(w)
+}
+
+// PDFTextWidth measures a line of text in points
+func PDFTextWidth(font string, size float64, text string) float64 {
+	widths := pdfFontWidths[pdfFontIndex(font)]
+	total := 0
+	for _, b := range pdfEncode(text) {
+		if b >= 32 && b <= 126 {
+			total += widths[b-32]
+		} else {
+			// Accented letters are about as wide as the average lowercase letter
+			total += widths['n'-32]
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// PDFWrapText breaks text into lines no wider than maxWidth, at spaces
+func PDFWrapText(font string, size, maxWidth float64, text string) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if line != "" && PDFTextWidth(font, size, candidate) > maxWidth {
+				lines = append(lines, line)
+				candidate = word
+			}
+			line = candidate
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func pdfFontIndex(font string) int {
+	for i, name := range pdfFonts {
+		if name == font {
+			return i
+		}
+	}
+	return 0
+}
+
+// pdfEncode encodes text in Windows-1252, the encoding of the standard fonts
+func pdfEncode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		b, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok || b < 32 {
+			b = '?'
+		}
+		encoded = append(encoded, b)
+	}
+	return encoded
+}
+
+// pdfString escapes encoded text as the body of a PDF string literal
+func pdfString(text string) string {
+	var b strings.Builder
+	for _, c := range pdfEncode(text) {
+		switch c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func pdfNumber(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+func pdfColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("%s %s %s", pdfNumber(float64(r)/0xffff), pdfNumber(float64(g)/0xffff), pdfNumber(float64(b)/0xffff))
+}
+
+// pdfStream compresses data into a stream object
+func pdfStream(data []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	if _, err := writer.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress PDF stream: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress PDF stream: %w", err)
+	}
+
+	var stream bytes.Buffer
+	fmt.Fprintf(&stream, "<< /Filter /FlateDecode /Length %d >>\nstream\n", compressed.Len())
+	stream.Write(compressed.Bytes())
+	stream.WriteString("\nendstream")
+	return stream.Bytes(), nil
+}
+
+// pdfFontWidths are the widths of the printable ASCII characters of the
+// standard fonts, in thousandths of the font size, in the order of pdfFonts
+var pdfFontWidths = [][95]int{
+	// Helvetica
+	{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	},
+	// Helvetica-Bold
+	{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	},
+	// Times-Roman
+	{
+		250, 333, 408, 500, 500, 833, 778, 180, 333, 333, 500, 564, 250, 333, 250, 278,
+		500, 500, 500, 500, 500, 500, 500, 500, 500, 500, 278, 278, 564, 564, 564, 444,
+		921, 722, 667, 667, 722, 611, 556, 722, 722, 333, 389, 722, 611, 889, 722, 722,
+		556, 722, 667, 556, 611, 722, 722, 944, 722, 722, 611, 333, 278, 333, 469, 500,
+		333, 444, 500, 444, 500, 444, 333, 500, 500, 278, 278, 500, 278, 778, 500, 500,
+		500, 500, 333, 389, 278, 500, 500, 722, 500, 500, 444, 480, 200, 480, 541,
+	},
+	// Times-Bold
+	{
+		250, 333, 555, 500, 500, 1000, 833, 278, 333, 333, 500, 570, 250, 333, 250, 278,
+		500, 500, 500, 500, 500, 500, 500, 500, 500, 500, 333, 333, 570, 570, 570, 500,
+		930, 722, 667, 722, 722, 667, 611, 778, 778, 389, 500, 778, 667, 944, 722, 778,
+		611, 778, 722, 556, 667, 722, 722, 1000, 722, 722, 667, 333, 278, 333, 581, 500,
+		333, 500, 556, 444, 556, 444, 333, 500, 556, 278, 333, 556, 278, 833, 556, 500,
+		556, 556, 444, 389, 333, 556, 500, 722, 500, 500, 444, 394, 220, 394, 520,
+	},
+}
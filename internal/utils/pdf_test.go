@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"bytes"
+	"image/color"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPDFDocument_WriteTo(t *testing.T) {
+	doc := NewPDFDocument(PDFA5Width, PDFA5Height)
+	for i := 0; i < 2; i++ {
+		page := doc.AddPage()
+		page.FillRect(0, 0, PDFA5Width, PDFA5Height, color.White)
+		page.StrokeRect(20, 20, PDFA5Width-40, PDFA5Height-40, 1, color.Black)
+		page.CenteredText(100, PDFTimesBold, 24, color.Black, "Ana & Budi (2026)")
+	}
+
+	var buf bytes.Buffer
+	_, err := doc.WriteTo(&buf)
+	require.NoError(t, err)
+	data := buf.Bytes()
+
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+	assert.Contains(t, string(data), "/Count 2")
+
+	// Every cross-reference entry points at its object
+	xref := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(data)
+	require.NotNil(t, xref)
+	start, err := strconv.Atoi(string(xref[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(data[start:], []byte("xref\n")))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[start:], -1)
+	require.Len(t, entries, 2+len(pdfFonts)+2*2) // Catalog, page tree, fonts, pages and contents
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data[offset:], []byte(strconv.Itoa(i+1)+" 0 obj\n")), "object %d", i+1)
+	}
+}
+
+func TestPDFText(t *testing.T) {
+	assert.Equal(t, `Ana \(and\) Budi \\ Caf`+"\xe9 ?", pdfString("Ana (and) Budi \\ Café 愛"))
+
+	for i, widths := range pdfFontWidths {
+		for c, width := range widths {
+			assert.NotZero(t, width, "%s %q", pdfFonts[i], rune(c+32))
+		}
+	}
+	assert.InDelta(t, 22.78, PDFTextWidth(PDFHelvetica, 10, "Hello"), 0.01)
+	assert.Greater(t, PDFTextWidth(PDFTimesBold, 10, "Hello"), PDFTextWidth(PDFTimes, 10, "Hello"))
+
+	lines := PDFWrapText(PDFHelvetica, 10, 80, "Gedung Serbaguna Jalan Merdeka\nNo. 17")
+	assert.Equal(t, []string{"Gedung", "Serbaguna Jalan", "Merdeka", "No. 17"}, lines)
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+var (
+	// ErrInvalidPreviewToken is returned for preview tokens that are malformed or not signed with the secret
+	ErrInvalidPreviewToken = errors.New("invalid preview token")
+	ErrExpiredPreviewToken = errors.New("preview token has expired")
+)
+
+// SignPreviewToken returns a URL-safe token for the preview link until
+// expiresAt, signed with secret
+func SignPreviewToken(secret string, linkID models.ID, expiresAt time.Time) string {
+	return signExpiringToken(secret, "wedding-preview", linkID, expiresAt)
+}
+
+// VerifyPreviewToken checks a token produced by SignPreviewToken in constant
+// time and returns the preview link it identifies, unless the token has expired
+func VerifyPreviewToken(secret, token string) (models.ID, error) {
+	linkID, expiresAt, ok := verifyExpiringToken(secret, "wedding-preview", token)
+	if !ok {
+		return models.NilID, ErrInvalidPreviewToken
+	}
+	if time.Now().After(expiresAt) {
+		return models.NilID, ErrExpiredPreviewToken
+	}
+	return linkID, nil
+}
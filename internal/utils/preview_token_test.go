@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func TestPreviewToken(t *testing.T) {
+	linkID := models.NewID()
+	token := SignPreviewToken("secret", linkID, time.Now().Add(time.Hour))
+
+	got, err := VerifyPreviewToken("secret", token)
+	if err != nil {
+		t.Fatalf("VerifyPreviewToken() error = %v", err)
+	}
+	if got != linkID {
+		t.Errorf("VerifyPreviewToken() = %s, want %s", got.String(), linkID.String())
+	}
+
+	expired := SignPreviewToken("secret", linkID, time.Now().Add(-time.Minute))
+	if _, err := VerifyPreviewToken("secret", expired); err != ErrExpiredPreviewToken {
+		t.Errorf("expired: VerifyPreviewToken() error = %v, want ErrExpiredPreviewToken", err)
+	}
+
+	for name, bad := range map[string]string{
+		"other secret":    SignPreviewToken("other", linkID, time.Now().Add(time.Hour)),
+		"RSVP edit token": SignRSVPEditToken("secret", linkID, time.Now().Add(time.Hour)),
+		"truncated":       token[:30],
+		"empty":           "",
+	} {
+		if _, err := VerifyPreviewToken("secret", bad); err != ErrInvalidPreviewToken {
+			t.Errorf("%s: VerifyPreviewToken() error = %v, want ErrInvalidPreviewToken", name, err)
+		}
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"wedding-invitation-backend/internal/domain/models"
 	"wedding-invitation-backend/internal/domain/repository"
 )
 
@@ -87,15 +87,15 @@ func PaginatedResponse(c *gin.Context, statusCode int, data interface{}, count,
 }
 
 // GetUserIDFromContext extracts user ID from Gin context
-func GetUserIDFromContext(c *gin.Context) (primitive.ObjectID, error) {
+func GetUserIDFromContext(c *gin.Context) (models.ID, error) {
 	userIDStr, exists := c.Get("user_id")
 	if !exists {
-		return primitive.NilObjectID, errors.New("user ID not found in context")
+		return models.NilID, errors.New("user ID not found in context")
 	}
 
-	userID, err := primitive.ObjectIDFromHex(userIDStr.(string))
+	userID, err := models.ParseID(userIDStr.(string))
 	if err != nil {
-		return primitive.NilObjectID, errors.New("invalid user ID format")
+		return models.NilID, errors.New("invalid user ID format")
 	}
 
 	return userID, nil
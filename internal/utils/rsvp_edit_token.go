@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+var (
+	// ErrInvalidRSVPEditToken is returned for edit tokens that are malformed or not signed with the secret
+	ErrInvalidRSVPEditToken = errors.New("invalid RSVP edit token")
+	ErrExpiredRSVPEditToken = errors.New("RSVP edit token has expired")
+)
+
+// SignRSVPEditToken returns a URL-safe token that lets a guest edit their RSVP
+// until expiresAt, signed with secret
+func SignRSVPEditToken(secret string, rsvpID models.ID, expiresAt time.Time) string {
+	return signExpiringToken(secret, "rsvp-edit", rsvpID, expiresAt)
+}
+
+// VerifyRSVPEditToken checks a token produced by SignRSVPEditToken in constant
+// time and returns the RSVP it identifies, unless the token has expired
+func VerifyRSVPEditToken(secret, token string) (models.ID, error) {
+	rsvpID, expiresAt, ok := verifyExpiringToken(secret, "rsvp-edit", token)
+	if !ok {
+		return models.NilID, ErrInvalidRSVPEditToken
+	}
+	if time.Now().After(expiresAt) {
+		return models.NilID, ErrExpiredRSVPEditToken
+	}
+	return rsvpID, nil
+}
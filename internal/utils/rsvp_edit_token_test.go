@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func TestRSVPEditToken(t *testing.T) {
+	rsvpID := models.NewID()
+	token := SignRSVPEditToken("secret", rsvpID, time.Now().Add(time.Hour))
+
+	if len(token) != 43 {
+		t.Errorf("token length = %d, want 43", len(token))
+	}
+
+	got, err := VerifyRSVPEditToken("secret", token)
+	if err != nil {
+		t.Fatalf("VerifyRSVPEditToken() error = %v", err)
+	}
+	if got != rsvpID {
+		t.Errorf("VerifyRSVPEditToken() = %s, want %s", got.String(), rsvpID.String())
+	}
+
+	expired := SignRSVPEditToken("secret", rsvpID, time.Now().Add(-time.Minute))
+	if _, err := VerifyRSVPEditToken("secret", expired); err != ErrExpiredRSVPEditToken {
+		t.Errorf("expired: VerifyRSVPEditToken() error = %v, want ErrExpiredRSVPEditToken", err)
+	}
+
+	for name, bad := range map[string]string{
+		"other secret": SignRSVPEditToken("other", rsvpID, time.Now().Add(time.Hour)),
+		"guest token":  SignGuestToken("secret", rsvpID),
+		"extended":     SignRSVPEditToken("secret", rsvpID, time.Now().Add(48*time.Hour))[:22] + token[22:],
+		"truncated":    token[:30],
+		"empty":        "",
+	} {
+		if _, err := VerifyRSVPEditToken("secret", bad); err != ErrInvalidRSVPEditToken {
+			t.Errorf("%s: VerifyRSVPEditToken() error = %v, want ErrInvalidRSVPEditToken", name, err)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// SignatureHeader is the header carrying the HMAC signature of an outgoing webhook body
+const SignatureHeader = "X-Webhook-Signature"
+
+// SignatureTimestampHeader is the header carrying the unix timestamp included in the signature
+const SignatureTimestampHeader = "X-Webhook-Timestamp"
+
+// SignPayload computes a hex-encoded HMAC-SHA256 over "<timestamp>.<body>"
+func SignPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyPayloadSignature checks a signature produced by SignPayload in constant time
+func VerifyPayloadSignature(secret string, timestamp int64, body []byte, signature string) bool {
+	expected := SignPayload(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
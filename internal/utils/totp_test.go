@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 test vectors
+var rfc6238Secret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	// The RFC lists 8 digit codes; authenticator apps use their last 6
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, want := range vectors {
+		code, err := TOTPCode(rfc6238Secret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, want, code, unix)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	now := time.Unix(1700000000, 0)
+	code, err := TOTPCode(secret, now)
+	require.NoError(t, err)
+
+	step, ok := ValidateTOTP(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/30, step)
+
+	// One period of clock drift is allowed
+	previous, _ := TOTPCode(secret, now.Add(-30*time.Second))
+	step, ok = ValidateTOTP(secret, previous, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/30-1, step)
+
+	stale, _ := TOTPCode(secret, now.Add(-90*time.Second))
+	_, ok = ValidateTOTP(secret, stale, now)
+	assert.False(t, ok)
+
+	_, ok = ValidateTOTP(secret, code[:3]+" "+code[3:], now)
+	assert.True(t, ok)
+	_, ok = ValidateTOTP(secret, "12345", now)
+	assert.False(t, ok)
+	_, ok = ValidateTOTP("not base32!", code, now)
+	assert.False(t, ok)
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := TOTPProvisioningURI("Wedding Invitation", "dana@example.com", "JBSWY3DPEHPK3PXP")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Wedding%20Invitation:dana@example.com?"), uri)
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=Wedding+Invitation")
+}
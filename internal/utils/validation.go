@@ -1,8 +1,10 @@
 package utils
 
 import (
+	"image/color"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -261,6 +263,20 @@ func ValidateHexColor(color string) error {
 	return nil
 }
 
+// ParseHexColor parses a #RGB or #RRGGBB color
+func ParseHexColor(hex string) (color.RGBA, bool) {
+	if ValidateHexColor(hex) != nil || hex == "" {
+		return color.RGBA{}, false
+	}
+
+	digits := hex[1:]
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	value, _ := strconv.ParseUint(digits, 16, 32)
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 0xff}, true
+}
+
 // SanitizeSlug sanitizes a string to be used as a slug
 func SanitizeSlug(input string) string {
 	// Convert to lowercase
@@ -0,0 +1,26 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// analyticsDailyKeys keys the daily analytics counters, one bucket per wedding and day
+var analyticsDailyKeys = bson.D{{Key: "wedding_id", Value: 1}, {Key: "date", Value: 1}}
+
+var analyticsDailyMigration = Migration{
+	Version:     1,
+	Description: "index daily analytics buckets by wedding and day",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		return createIndexes(ctx, db, "analytics_daily", mongo.IndexModel{
+			Keys:    analyticsDailyKeys,
+			Options: options.Index().SetUnique(true),
+		})
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		return dropIndexes(ctx, db, "analytics_daily", analyticsDailyKeys)
+	},
+}
@@ -0,0 +1,22 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// wishesKeys serves the guestbook of a wedding, newest wishes of a status first
+var wishesKeys = bson.D{{Key: "wedding_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}}
+
+var wishesMigration = Migration{
+	Version:     2,
+	Description: "index guestbook wishes by wedding and status",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		return createIndexes(ctx, db, "wishes", mongo.IndexModel{Keys: wishesKeys})
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		return dropIndexes(ctx, db, "wishes", wishesKeys)
+	},
+}
@@ -0,0 +1,32 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// weddingWebhooksKeys lists the webhooks of a wedding
+	weddingWebhooksKeys = bson.D{{Key: "wedding_id", Value: 1}, {Key: "created_at", Value: -1}}
+	// weddingWebhookDeliveriesKeys lists the latest deliveries of a webhook
+	weddingWebhookDeliveriesKeys = bson.D{{Key: "webhook_id", Value: 1}, {Key: "attempted_at", Value: -1}}
+)
+
+var weddingWebhooksMigration = Migration{
+	Version:     3,
+	Description: "index wedding webhooks and their deliveries",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		if err := createIndexes(ctx, db, "wedding_webhooks", mongo.IndexModel{Keys: weddingWebhooksKeys}); err != nil {
+			return err
+		}
+		return createIndexes(ctx, db, "wedding_webhook_deliveries", mongo.IndexModel{Keys: weddingWebhookDeliveriesKeys})
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := dropIndexes(ctx, db, "wedding_webhook_deliveries", weddingWebhookDeliveriesKeys); err != nil {
+			return err
+		}
+		return dropIndexes(ctx, db, "wedding_webhooks", weddingWebhooksKeys)
+	},
+}
@@ -0,0 +1,78 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// guestSearchBatchSize is how many guests are given search or phone keys per write
+const guestSearchBatchSize = 500
+
+// guestSearchIndexKeys serves guest search, which matches the prefixes of the
+// words a guest is found by
+var guestSearchIndexKeys = bson.D{{Key: "wedding_id", Value: 1}, {Key: "search_keys", Value: 1}}
+
+// guestSearchKeysMigration gives the guests saved before guest search their
+// search keys, which search otherwise has to check one by one
+var guestSearchKeysMigration = Migration{
+	Version:     4,
+	Description: "index guest search keys and fill them in for existing guests",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		if err := createIndexes(ctx, db, "guests", mongo.IndexModel{Keys: guestSearchIndexKeys}); err != nil {
+			return err
+		}
+
+		guests := db.Collection("guests")
+		cursor, err := guests.Find(ctx, bson.M{"search_keys": bson.M{"$exists": false}},
+			options.Find().SetProjection(bson.M{"first_name": 1, "last_name": 1, "email": 1, "phone": 1, "notes": 1}))
+		if err != nil {
+			return fmt.Errorf("failed to find guests: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		writes := make([]mongo.WriteModel, 0, guestSearchBatchSize)
+		flush := func() error {
+			if len(writes) == 0 {
+				return nil
+			}
+			if _, err := guests.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+				return fmt.Errorf("failed to save guest search keys: %w", err)
+			}
+			writes = writes[:0]
+			return nil
+		}
+		for cursor.Next(ctx) {
+			var guest models.Guest
+			if err := cursor.Decode(&guest); err != nil {
+				return fmt.Errorf("failed to decode guest: %w", err)
+			}
+			writes = append(writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": guest.ID}).
+				SetUpdate(bson.M{"$set": bson.M{"search_keys": models.GuestSearchKeys(&guest)}}))
+			if len(writes) == guestSearchBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := cursor.Err(); err != nil {
+			return fmt.Errorf("failed to read guests: %w", err)
+		}
+		return flush()
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := dropIndexes(ctx, db, "guests", guestSearchIndexKeys); err != nil {
+			return err
+		}
+		if _, err := db.Collection("guests").UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"search_keys": ""}}); err != nil {
+			return fmt.Errorf("failed to remove guest search keys: %w", err)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,29 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// analyticsAnomaliesKeys lists the anomalies of a wedding and finds the latest
+// of a metric and kind
+var analyticsAnomaliesKeys = bson.D{{Key: "wedding_id", Value: 1}, {Key: "metric", Value: 1}, {Key: "kind", Value: 1}, {Key: "hour", Value: -1}}
+
+// analyticsAnomaliesListKeys lists the anomalies of a wedding, latest first
+var analyticsAnomaliesListKeys = bson.D{{Key: "wedding_id", Value: 1}, {Key: "hour", Value: -1}}
+
+var analyticsAnomaliesMigration = Migration{
+	Version:     5,
+	Description: "index analytics anomalies",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		return createIndexes(ctx, db, "analytics_anomalies",
+			mongo.IndexModel{Keys: analyticsAnomaliesKeys},
+			mongo.IndexModel{Keys: analyticsAnomaliesListKeys},
+		)
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		return dropIndexes(ctx, db, "analytics_anomalies", analyticsAnomaliesKeys, analyticsAnomaliesListKeys)
+	},
+}
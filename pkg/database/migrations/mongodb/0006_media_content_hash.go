@@ -0,0 +1,28 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// mediaContentHashKeys finds an earlier upload of the same file by the same user
+var mediaContentHashKeys = bson.D{{Key: "createdBy", Value: 1}, {Key: "contentHash", Value: 1}}
+
+// mediaStorageKeyKeys counts the media sharing a stored object
+var mediaStorageKeyKeys = bson.D{{Key: "storageKey", Value: 1}}
+
+var mediaContentHashMigration = Migration{
+	Version:     6,
+	Description: "index media by content hash and storage key",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		return createIndexes(ctx, db, "media",
+			mongo.IndexModel{Keys: mediaContentHashKeys},
+			mongo.IndexModel{Keys: mediaStorageKeyKeys},
+		)
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		return dropIndexes(ctx, db, "media", mediaContentHashKeys, mediaStorageKeyKeys)
+	},
+}
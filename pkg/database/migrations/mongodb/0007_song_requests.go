@@ -0,0 +1,29 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// songRequestKeys collapses the requests of one song for a wedding into one
+var songRequestKeys = bson.D{{Key: "wedding_id", Value: 1}, {Key: "key", Value: 1}}
+
+// songRequestListKeys serves the most requested songs of a wedding in a status
+var songRequestListKeys = bson.D{{Key: "wedding_id", Value: 1}, {Key: "status", Value: 1}, {Key: "requests", Value: -1}}
+
+var songRequestsMigration = Migration{
+	Version:     7,
+	Description: "index song requests by wedding, song and status",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		return createIndexes(ctx, db, "song_requests",
+			mongo.IndexModel{Keys: songRequestKeys, Options: options.Index().SetUnique(true)},
+			mongo.IndexModel{Keys: songRequestListKeys},
+		)
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		return dropIndexes(ctx, db, "song_requests", songRequestKeys, songRequestListKeys)
+	},
+}
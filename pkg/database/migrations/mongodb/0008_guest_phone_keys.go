@@ -0,0 +1,79 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// guestPhoneIndexKeys serves matching an RSVP to the guest with its phone number
+var guestPhoneIndexKeys = bson.D{{Key: "wedding_id", Value: 1}, {Key: "phone_key", Value: 1}}
+
+// guestPhoneKeysMigration gives the guests saved before RSVPs were matched by
+// phone key the key of their phone number, without which they are not matched
+var guestPhoneKeysMigration = Migration{
+	Version:     8,
+	Description: "index guest phone keys and fill them in for existing guests",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		if err := createIndexes(ctx, db, "guests", mongo.IndexModel{Keys: guestPhoneIndexKeys}); err != nil {
+			return err
+		}
+
+		guests := db.Collection("guests")
+		cursor, err := guests.Find(ctx, bson.M{"phone": bson.M{"$nin": bson.A{"", nil}}, "phone_key": bson.M{"$exists": false}},
+			options.Find().SetProjection(bson.M{"phone": 1}))
+		if err != nil {
+			return fmt.Errorf("failed to find guests: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		writes := make([]mongo.WriteModel, 0, guestSearchBatchSize)
+		flush := func() error {
+			if len(writes) == 0 {
+				return nil
+			}
+			if _, err := guests.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+				return fmt.Errorf("failed to save guest phone keys: %w", err)
+			}
+			writes = writes[:0]
+			return nil
+		}
+		for cursor.Next(ctx) {
+			var guest models.Guest
+			if err := cursor.Decode(&guest); err != nil {
+				return fmt.Errorf("failed to decode guest: %w", err)
+			}
+			// Numbers too short to match anyone have no key
+			key := models.GuestPhoneKey(guest.Phone)
+			if key == "" {
+				continue
+			}
+			writes = append(writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": guest.ID}).
+				SetUpdate(bson.M{"$set": bson.M{"phone_key": key}}))
+			if len(writes) == guestSearchBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := cursor.Err(); err != nil {
+			return fmt.Errorf("failed to read guests: %w", err)
+		}
+		return flush()
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := dropIndexes(ctx, db, "guests", guestPhoneIndexKeys); err != nil {
+			return err
+		}
+		if _, err := db.Collection("guests").UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"phone_key": ""}}); err != nil {
+			return fmt.Errorf("failed to remove guest phone keys: %w", err)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,23 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// rsvpSubmissionGuestKeys serves checking for a guest's earlier unfinished
+// submissions before one of theirs is claimed
+var rsvpSubmissionGuestKeys = bson.D{{Key: "guest_key", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: 1}}
+
+var rsvpSubmissionGuestKeysMigration = Migration{
+	Version:     9,
+	Description: "index queued RSVP submissions by guest",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		return createIndexes(ctx, db, "rsvp_submissions", mongo.IndexModel{Keys: rsvpSubmissionGuestKeys})
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		return dropIndexes(ctx, db, "rsvp_submissions", rsvpSubmissionGuestKeys)
+	},
+}
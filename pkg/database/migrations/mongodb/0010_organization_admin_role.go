@@ -0,0 +1,32 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+// organizationAdminRoleMigration gives the admins of an organization, who used
+// to share the admin role of the platform, the organization admin role
+var organizationAdminRoleMigration = Migration{
+	Version:     10,
+	Description: "give organization admins their own role",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		return setOrganizationAdminRole(ctx, db, models.RoleAdmin, models.RoleOrgAdmin)
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		return setOrganizationAdminRole(ctx, db, models.RoleOrgAdmin, models.RoleAdmin)
+	},
+}
+
+func setOrganizationAdminRole(ctx context.Context, db *mongo.Database, from, to string) error {
+	filter := bson.M{"tenant_id": bson.M{"$nin": bson.A{"", nil}}, "role": from}
+	if _, err := db.Collection("users").UpdateMany(ctx, filter, bson.M{"$set": bson.M{"role": to}}); err != nil {
+		return fmt.Errorf("failed to update organization admin roles: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+// Package mongodb holds the versioned migrations of the MongoDB database, one
+// file per version, and applies and reverts them. Applied versions are recorded
+// in the schema_migrations collection.
+//
+// Indexes that have existed since before migrations are created at startup by
+// database.MongoDB.EnsureIndexes; indexes of new collections and changes to the
+// shape of stored data are added here as the next version.
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Collection records the applied migrations, and the lock taken while migrating
+const Collection = "schema_migrations"
+
+const (
+	// lockID is the ID of the lock document in Collection
+	lockID = "lock"
+	// lockLease is how long the lock is held by a migration run that neither
+	// finishes nor fails, e.g. because the process died
+	lockLease = 15 * time.Minute
+	// lockRetry is how often a run waiting for the lock tries to take it
+	lockRetry = time.Second
+)
+
+// Migration changes the indexes or the data of the database from one version
+// to the next. A run that fails halfway is run again, so Up and Down have to
+// be idempotent.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+	Down        func(ctx context.Context, db *mongo.Database) error
+}
+
+// migrations lists every migration, in order of version
+var migrations = []Migration{
+	analyticsDailyMigration,
+	wishesMigration,
+	weddingWebhooksMigration,
+	guestSearchKeysMigration,
+	analyticsAnomaliesMigration,
+	mediaContentHashMigration,
+	songRequestsMigration,
+	guestPhoneKeysMigration,
+	rsvpSubmissionGuestKeysMigration,
+	organizationAdminRoleMigration,
+}
+
+// Status is a migration and when it was applied; AppliedAt is nil for a
+// pending migration
+type Status struct {
+	Version     int        `json:"version" bson:"_id"`
+	Description string     `json:"description" bson:"description"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty" bson:"applied_at"`
+}
+
+// Latest returns the version the migrations bring the database to
+func Latest() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// Statuses lists the migrations with when they were applied, by version. A
+// version applied by a newer build is listed with the description it recorded.
+func Statuses(ctx context.Context, db *mongo.Database) ([]Status, error) {
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	for _, migration := range migrations {
+		status := Status{Version: migration.Version, Description: migration.Description}
+		if record, ok := applied[migration.Version]; ok {
+			status.AppliedAt = record.AppliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range applied {
+		statuses = append(statuses, record)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Up applies the pending migrations up to and including version target, or
+// all of them for target 0, and returns the ones it applied. Concurrent runs
+// wait for each other.
+func Up(ctx context.Context, db *mongo.Database, target int) ([]Status, error) {
+	if target == 0 {
+		target = Latest()
+	}
+
+	unlock, err := lock(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Status
+	for _, migration := range migrations {
+		if migration.Version > target {
+			break
+		}
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := migration.Up(ctx, db); err != nil {
+			return done, fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+		}
+
+		now := time.Now().UTC()
+		status := Status{Version: migration.Version, Description: migration.Description, AppliedAt: &now}
+		if _, err := db.Collection(Collection).InsertOne(ctx, status); err != nil {
+			return done, fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+		}
+		done = append(done, status)
+	}
+	return done, nil
+}
+
+// Down reverts the last steps applied migrations, latest first, and returns
+// the ones it reverted
+func Down(ctx context.Context, db *mongo.Database, steps int) ([]Status, error) {
+	if steps < 1 {
+		return nil, errors.New("steps must be at least 1")
+	}
+
+	unlock, err := lock(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	statuses, err := Statuses(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Status
+	for i := len(statuses) - 1; i >= 0 && len(done) < steps; i-- {
+		status := statuses[i]
+		if status.AppliedAt == nil {
+			continue
+		}
+		migration, ok := find(status.Version)
+		if !ok {
+			return done, fmt.Errorf("migration %d was applied by a newer version and cannot be reverted by this one", status.Version)
+		}
+		if err := migration.Down(ctx, db); err != nil {
+			return done, fmt.Errorf("reverting migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+		}
+		if _, err := db.Collection(Collection).DeleteOne(ctx, bson.M{"_id": migration.Version}); err != nil {
+			return done, fmt.Errorf("failed to record reverting migration %d: %w", migration.Version, err)
+		}
+		status.AppliedAt = nil
+		done = append(done, status)
+	}
+	return done, nil
+}
+
+// appliedVersions returns the recorded migrations by version
+func appliedVersions(ctx context.Context, db *mongo.Database) (map[int]Status, error) {
+	cursor, err := db.Collection(Collection).Find(ctx, bson.M{"_id": bson.M{"$type": "number"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	var records []Status
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	applied := make(map[int]Status, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// lock takes the migration lock, waiting while another run holds it, and
+// returns the function releasing it
+func lock(ctx context.Context, db *mongo.Database) (func(), error) {
+	collection := db.Collection(Collection)
+	for {
+		_, err := collection.InsertOne(ctx, bson.M{"_id": lockID, "locked_until": time.Now().Add(lockLease)})
+		if err == nil {
+			return func() {
+				// Release the lock even when the run was cancelled
+				collection.DeleteOne(context.WithoutCancel(ctx), bson.M{"_id": lockID})
+			}, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, fmt.Errorf("failed to lock migrations: %w", err)
+		}
+
+		// Take over a lock left behind by a run that died
+		if _, err := collection.DeleteOne(ctx, bson.M{"_id": lockID, "locked_until": bson.M{"$lt": time.Now()}}); err != nil {
+			return nil, fmt.Errorf("failed to lock migrations: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for another migration run: %w", ctx.Err())
+		case <-time.After(lockRetry):
+		}
+	}
+}
+
+// find returns the migration to a version
+func find(version int) (Migration, bool) {
+	for _, migration := range migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
+
+// createIndexes creates indexes of a collection. They get the names MongoDB
+// gives by default, so that an index created before it moved to a migration is
+// recognized rather than created twice.
+func createIndexes(ctx context.Context, db *mongo.Database, collection string, indexes ...mongo.IndexModel) error {
+	if _, err := db.Collection(collection).Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("failed to create %s indexes: %w", collection, err)
+	}
+	return nil
+}
+
+// dropIndexes drops the indexes of a collection with the given keys, if they exist
+func dropIndexes(ctx context.Context, db *mongo.Database, collection string, keys ...bson.D) error {
+	for _, key := range keys {
+		_, err := db.Collection(collection).Indexes().DropOne(ctx, indexName(key))
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) && (cmdErr.Name == "IndexNotFound" || cmdErr.Name == "NamespaceNotFound") {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to drop %s index %s: %w", collection, indexName(key), err)
+		}
+	}
+	return nil
+}
+
+// indexName returns the name MongoDB gives an index with the given keys by default
+func indexName(keys bson.D) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("%s_%v", key.Key, key.Value)
+	}
+	return strings.Join(parts, "_")
+}
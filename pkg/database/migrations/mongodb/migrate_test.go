@@ -0,0 +1,30 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigrations_Versions(t *testing.T) {
+	for i, migration := range migrations {
+		assert.Equal(t, i+1, migration.Version, "migrations are numbered in order from 1")
+		assert.NotEmpty(t, migration.Description)
+		assert.NotNil(t, migration.Up, "migration %d has no Up", migration.Version)
+		assert.NotNil(t, migration.Down, "migration %d has no Down", migration.Version)
+	}
+	assert.Equal(t, len(migrations), Latest())
+
+	_, ok := find(Latest())
+	assert.True(t, ok)
+	_, ok = find(Latest() + 1)
+	assert.False(t, ok)
+}
+
+func TestIndexName(t *testing.T) {
+	// The names MongoDB gives the indexes created before migrations existed
+	assert.Equal(t, "wedding_id_1_date_1", indexName(analyticsDailyKeys))
+	assert.Equal(t, "wedding_id_1_status_1_created_at_-1", indexName(wishesKeys))
+	assert.Equal(t, "title_text", indexName(bson.D{{Key: "title", Value: "text"}}))
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS rsvps;
+DROP TABLE IF EXISTS guests;
+DROP TABLE IF EXISTS weddings;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,117 @@
+-- Users, weddings, guests and RSVPs. Each row keeps the full model in document
+-- (MongoDB canonical extended JSON) next to the columns queries filter and sort on.
+-- IDs are ObjectIDs in hex so that records can reference those kept in MongoDB.
+
+CREATE TABLE users (
+    id                       CHAR(24) PRIMARY KEY,
+    email                    TEXT NOT NULL,
+    first_name               TEXT NOT NULL DEFAULT '',
+    last_name                TEXT NOT NULL DEFAULT '',
+    status                   TEXT NOT NULL DEFAULT '',
+    email_verification_token TEXT,
+    password_reset_token     TEXT,
+    billing_customer_id      TEXT,
+    unlock_token_hash        TEXT,
+    deletion_token_hash      TEXT,
+    deletion_scheduled_for   TIMESTAMPTZ,
+    -- provider:subject of each linked social login account
+    oauth_accounts           TEXT[] NOT NULL DEFAULT '{}',
+    created_at               TIMESTAMPTZ NOT NULL,
+    document                 JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX users_email_key ON users (email);
+CREATE INDEX users_created_at_idx ON users (created_at DESC);
+CREATE INDEX users_email_verification_token_idx ON users (email_verification_token) WHERE email_verification_token IS NOT NULL;
+CREATE INDEX users_password_reset_token_idx ON users (password_reset_token) WHERE password_reset_token IS NOT NULL;
+CREATE INDEX users_billing_customer_id_idx ON users (billing_customer_id) WHERE billing_customer_id IS NOT NULL;
+CREATE INDEX users_unlock_token_hash_idx ON users (unlock_token_hash) WHERE unlock_token_hash IS NOT NULL;
+CREATE INDEX users_deletion_token_hash_idx ON users (deletion_token_hash) WHERE deletion_token_hash IS NOT NULL;
+CREATE INDEX users_deletion_scheduled_for_idx ON users (deletion_scheduled_for) WHERE deletion_scheduled_for IS NOT NULL;
+CREATE INDEX users_oauth_accounts_idx ON users USING GIN (oauth_accounts);
+
+CREATE TABLE weddings (
+    id                  CHAR(24) PRIMARY KEY,
+    user_id             CHAR(24) NOT NULL,
+    collaborator_ids    TEXT[] NOT NULL DEFAULT '{}',
+    slug                TEXT NOT NULL,
+    status              TEXT NOT NULL DEFAULT '',
+    is_public           BOOLEAN NOT NULL DEFAULT FALSE,
+    title               TEXT NOT NULL DEFAULT '',
+    partner1_first_name TEXT NOT NULL DEFAULT '',
+    partner1_last_name  TEXT NOT NULL DEFAULT '',
+    partner2_first_name TEXT NOT NULL DEFAULT '',
+    partner2_last_name  TEXT NOT NULL DEFAULT '',
+    theme_id            TEXT NOT NULL DEFAULT '',
+    event_date          TIMESTAMPTZ,
+    published_at        TIMESTAMPTZ,
+    view_count          BIGINT NOT NULL DEFAULT 0,
+    flagged             BOOLEAN NOT NULL DEFAULT FALSE,
+    taken_down          BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at          TIMESTAMPTZ NOT NULL,
+    -- Full-text search of the public showcase; couple names weigh more than titles
+    search              TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', partner1_first_name || ' ' || partner1_last_name || ' ' ||
+                                         partner2_first_name || ' ' || partner2_last_name), 'A') ||
+        setweight(to_tsvector('english', title), 'B')
+    ) STORED,
+    document            JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX weddings_slug_key ON weddings (slug);
+CREATE INDEX weddings_user_id_idx ON weddings (user_id, created_at DESC);
+CREATE INDEX weddings_collaborator_ids_idx ON weddings USING GIN (collaborator_ids);
+CREATE INDEX weddings_showcase_date_idx ON weddings (is_public, status, event_date);
+CREATE INDEX weddings_showcase_published_idx ON weddings (is_public, status, published_at DESC);
+CREATE INDEX weddings_showcase_views_idx ON weddings (is_public, status, view_count DESC);
+CREATE INDEX weddings_search_idx ON weddings USING GIN (search);
+CREATE INDEX weddings_moderation_idx ON weddings (flagged, taken_down);
+CREATE INDEX weddings_theme_id_idx ON weddings (theme_id);
+
+CREATE TABLE guests (
+    id                    CHAR(24) PRIMARY KEY,
+    wedding_id            CHAR(24) NOT NULL,
+    first_name            TEXT NOT NULL DEFAULT '',
+    last_name             TEXT NOT NULL DEFAULT '',
+    email                 TEXT,
+    relationship          TEXT NOT NULL DEFAULT '',
+    side                  TEXT NOT NULL DEFAULT '',
+    rsvp_status           TEXT NOT NULL DEFAULT '',
+    vip                   BOOLEAN NOT NULL DEFAULT FALSE,
+    invitation_status     TEXT NOT NULL DEFAULT '',
+    invited_via           TEXT NOT NULL DEFAULT '',
+    invitation_message_id TEXT,
+    allow_plus_one        BOOLEAN NOT NULL DEFAULT FALSE,
+    import_batch_id       TEXT,
+    -- NULL until the guest checks in
+    checked_in_at         TIMESTAMPTZ,
+    plus_ones_brought     INT NOT NULL DEFAULT 0,
+    created_at            TIMESTAMPTZ NOT NULL,
+    document              JSONB NOT NULL
+);
+
+CREATE INDEX guests_wedding_id_idx ON guests (wedding_id, created_at DESC);
+CREATE INDEX guests_wedding_email_idx ON guests (wedding_id, email);
+CREATE INDEX guests_wedding_name_idx ON guests (wedding_id, last_name, first_name);
+CREATE INDEX guests_invitation_message_id_idx ON guests (invitation_message_id) WHERE invitation_message_id IS NOT NULL;
+CREATE INDEX guests_import_batch_id_idx ON guests (import_batch_id) WHERE import_batch_id IS NOT NULL;
+
+CREATE TABLE rsvps (
+    id               CHAR(24) PRIMARY KEY,
+    wedding_id       CHAR(24) NOT NULL,
+    first_name       TEXT NOT NULL DEFAULT '',
+    last_name        TEXT NOT NULL DEFAULT '',
+    email            TEXT,
+    status           TEXT NOT NULL DEFAULT '',
+    source           TEXT NOT NULL DEFAULT '',
+    -- NULL unless the RSVP was held for review
+    review_status    TEXT,
+    attendance_count INT NOT NULL DEFAULT 0,
+    plus_one_count   INT NOT NULL DEFAULT 0,
+    ip_address       TEXT,
+    submitted_at     TIMESTAMPTZ NOT NULL,
+    document         JSONB NOT NULL
+);
+
+CREATE INDEX rsvps_wedding_id_idx ON rsvps (wedding_id, submitted_at DESC);
+CREATE INDEX rsvps_wedding_email_idx ON rsvps (wedding_id, email);
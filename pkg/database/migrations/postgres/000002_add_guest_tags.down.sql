@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS guests_tags_idx;
+ALTER TABLE guests DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,4 @@
+-- Tags owners group guests by, filtered on by the guest list and bulk operations
+ALTER TABLE guests ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX guests_tags_idx ON guests USING GIN (tags);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS guests_group_id_idx;
+ALTER TABLE guests DROP COLUMN IF EXISTS group_id;
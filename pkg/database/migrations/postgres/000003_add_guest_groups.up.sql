@@ -0,0 +1,4 @@
+-- Household the guest is invited with; the groups themselves are stored in MongoDB
+ALTER TABLE guests ADD COLUMN group_id CHAR(24);
+
+CREATE INDEX guests_group_id_idx ON guests (group_id) WHERE group_id IS NOT NULL;
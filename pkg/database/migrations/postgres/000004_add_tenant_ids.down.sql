@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS weddings_tenant_id_idx;
+DROP INDEX IF EXISTS users_tenant_id_idx;
+ALTER TABLE weddings DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
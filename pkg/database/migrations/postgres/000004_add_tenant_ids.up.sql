@@ -0,0 +1,10 @@
+-- White-label tenant (organization) of users and weddings, which queries of
+-- tenant members are scoped to. Empty for users and weddings of no tenant.
+ALTER TABLE users ADD COLUMN tenant_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE weddings ADD COLUMN tenant_id TEXT NOT NULL DEFAULT '';
+
+UPDATE users SET tenant_id = document->>'tenant_id' WHERE document->>'tenant_id' IS NOT NULL;
+UPDATE weddings SET tenant_id = document->>'tenant_id' WHERE document->>'tenant_id' IS NOT NULL;
+
+CREATE INDEX users_tenant_id_idx ON users (tenant_id, created_at DESC) WHERE tenant_id <> '';
+CREATE INDEX weddings_tenant_id_idx ON weddings (tenant_id, created_at DESC) WHERE tenant_id <> '';
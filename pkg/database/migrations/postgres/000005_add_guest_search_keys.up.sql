@@ -0,0 +1,3 @@
+-- Words a guest is found by, matched by prefix by the guest search. NULL for
+-- guests saved before, which the search checks one by one until they are saved again.
+ALTER TABLE guests ADD COLUMN search_keys TEXT[];
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS guests_phone_key_idx;
+ALTER TABLE guests DROP COLUMN IF EXISTS phone_key;
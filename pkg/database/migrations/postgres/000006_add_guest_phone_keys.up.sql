@@ -0,0 +1,8 @@
+-- Last digits of a guest's phone number, which RSVPs are matched to guests by,
+-- see models.GuestPhoneKey. NULL for numbers too short to match anyone.
+ALTER TABLE guests ADD COLUMN phone_key TEXT;
+
+UPDATE guests SET phone_key = right(regexp_replace(document->>'phone', '[^0-9]', '', 'g'), 10)
+WHERE length(regexp_replace(document->>'phone', '[^0-9]', '', 'g')) >= 6;
+
+CREATE INDEX guests_phone_key_idx ON guests (wedding_id, phone_key) WHERE phone_key IS NOT NULL;
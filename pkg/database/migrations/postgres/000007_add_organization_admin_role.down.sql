@@ -0,0 +1,2 @@
+UPDATE users SET document = jsonb_set(document, '{role}', '"admin"')
+WHERE tenant_id <> '' AND document->>'role' = 'org_admin';
@@ -0,0 +1,4 @@
+-- Admins of an organization used to share the admin role of the platform and
+-- now have a role of their own, which grants nothing outside the organization.
+UPDATE users SET document = jsonb_set(document, '{role}', '"org_admin"')
+WHERE tenant_id <> '' AND document->>'role' = 'admin';
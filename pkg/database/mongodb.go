@@ -6,13 +6,19 @@ import (
 	"time"
 
 	"wedding-invitation-backend/internal/config"
+	migrations "wedding-invitation-backend/pkg/database/migrations/mongodb"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 )
 
+// MigrationTimeout bounds applying the pending migrations at startup, which may
+// have to wait for another instance applying them
+const MigrationTimeout = 10 * time.Minute
+
 type MongoDB struct {
 	Client   *mongo.Client
 	Database *mongo.Database
@@ -22,7 +28,15 @@ func NewMongoDB(cfg *config.DatabaseConfig) (*MongoDB, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Timeout)*time.Second)
 	defer cancel()
 
-	clientOptions := options.Client().ApplyURI(cfg.URI)
+	// Commands are traced as children of the caller's span; their contents are left out
+	clientOptions := options.Client().
+		ApplyURI(cfg.URI).
+		SetRegistry(NewRegistry()).
+		SetMonitor(otelmongo.NewMonitor()).
+		SetPoolMonitor(newPoolMonitor())
+	if err := applyClientOptions(clientOptions, cfg); err != nil {
+		return nil, err
+	}
 
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
@@ -42,6 +56,43 @@ func NewMongoDB(cfg *config.DatabaseConfig) (*MongoDB, error) {
 	}, nil
 }
 
+// applyClientOptions sets the pool, timeout and read preference options of the
+// configuration on top of those of the URI; options left zero keep the URI's or
+// the driver's defaults
+func applyClientOptions(clientOptions *options.ClientOptions, cfg *config.DatabaseConfig) error {
+	if cfg.MaxPoolSize > 0 {
+		clientOptions.SetMaxPoolSize(cfg.MaxPoolSize)
+	}
+	if cfg.MinPoolSize > 0 {
+		clientOptions.SetMinPoolSize(cfg.MinPoolSize)
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		clientOptions.SetMaxConnIdleTime(cfg.MaxConnIdleTime)
+	}
+	if cfg.ServerSelectionTimeout > 0 {
+		clientOptions.SetServerSelectionTimeout(cfg.ServerSelectionTimeout)
+	}
+	if cfg.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(cfg.ReadPreference)
+		if err != nil {
+			return fmt.Errorf("invalid MONGODB_READ_PREFERENCE: %w", err)
+		}
+		readPref, err := readpref.New(mode)
+		if err != nil {
+			return fmt.Errorf("invalid MONGODB_READ_PREFERENCE: %w", err)
+		}
+		clientOptions.SetReadPreference(readPref)
+	}
+	// Operations get the deadline of their context, e.g. the HTTP request's, or
+	// OperationTimeout when it has none, so that a slow aggregation cannot hang a
+	// request indefinitely. Cursors are bounded per batch rather than as a whole,
+	// so long exports keep streaming.
+	if cfg.OperationTimeout > 0 {
+		clientOptions.SetTimeout(cfg.OperationTimeout)
+	}
+	return nil
+}
+
 func (m *MongoDB) Close(ctx context.Context) error {
 	return m.Client.Disconnect(ctx)
 }
@@ -50,6 +101,17 @@ func (m *MongoDB) Collection(name string) *mongo.Collection {
 	return m.Database.Collection(name)
 }
 
+// Migrate applies the pending MongoDB migrations. Concurrent callers wait for
+// each other on a lock.
+func (m *MongoDB) Migrate(ctx context.Context) error {
+	if _, err := migrations.Up(ctx, m.Database, 0); err != nil {
+		return fmt.Errorf("failed to migrate MongoDB: %w", err)
+	}
+	return nil
+}
+
+// EnsureIndexes creates the indexes that predate the migrations in
+// pkg/database/migrations/mongodb; new indexes are added as migrations
 func (m *MongoDB) EnsureIndexes(ctx context.Context) error {
 	users := m.Collection("users")
 	if _, err := users.Indexes().CreateOne(ctx, mongo.IndexModel{
@@ -73,6 +135,55 @@ func (m *MongoDB) EnsureIndexes(ctx context.Context) error {
 		return fmt.Errorf("failed to create weddings user_id index: %w", err)
 	}
 
+	if _, err := weddings.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "collaborators.user_id", Value: 1}, {Key: "created_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create weddings collaborators index: %w", err)
+	}
+
+	// Organization members only see the users and weddings of their tenant
+	for name, collection := range map[string]*mongo.Collection{"users": users, "weddings": weddings} {
+		if _, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetSparse(true),
+		}); err != nil {
+			return fmt.Errorf("failed to create %s tenant_id index: %w", name, err)
+		}
+	}
+
+	// Public showcase indexes: one per sort order, plus full-text search over couple names and titles
+	showcaseSorts := []bson.D{
+		{{Key: "is_public", Value: 1}, {Key: "status", Value: 1}, {Key: "event.date", Value: 1}},
+		{{Key: "is_public", Value: 1}, {Key: "status", Value: 1}, {Key: "published_at", Value: -1}},
+		{{Key: "is_public", Value: 1}, {Key: "status", Value: 1}, {Key: "view_count", Value: -1}},
+	}
+	for _, keys := range showcaseSorts {
+		if _, err := weddings.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: keys}); err != nil {
+			return fmt.Errorf("failed to create weddings showcase index: %w", err)
+		}
+	}
+
+	if _, err := weddings.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "couple.partner1.first_name", Value: "text"},
+			{Key: "couple.partner1.last_name", Value: "text"},
+			{Key: "couple.partner2.first_name", Value: "text"},
+			{Key: "couple.partner2.last_name", Value: "text"},
+			{Key: "title", Value: "text"},
+		},
+		Options: options.Index().
+			SetName("weddings_showcase_text").
+			SetWeights(bson.D{
+				{Key: "couple.partner1.first_name", Value: 10},
+				{Key: "couple.partner1.last_name", Value: 10},
+				{Key: "couple.partner2.first_name", Value: 10},
+				{Key: "couple.partner2.last_name", Value: 10},
+				{Key: "title", Value: 5},
+			}),
+	}); err != nil {
+		return fmt.Errorf("failed to create weddings text index: %w", err)
+	}
+
 	// RSVP indexes
 	rsvps := m.Collection("rsvps")
 	if _, err := rsvps.Indexes().CreateOne(ctx, mongo.IndexModel{
@@ -101,6 +212,14 @@ func (m *MongoDB) EnsureIndexes(ctx context.Context) error {
 		return fmt.Errorf("failed to create guests email index: %w", err)
 	}
 
+	// Messaging delivery reports find the guest by the provider's message ID
+	if _, err := guests.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "invitation_message_id", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}); err != nil {
+		return fmt.Errorf("failed to create guests invitation_message_id index: %w", err)
+	}
+
 	// Analytics indexes
 	pageViews := m.Collection("page_views")
 	if _, err := pageViews.Indexes().CreateOne(ctx, mongo.IndexModel{
@@ -115,19 +234,22 @@ func (m *MongoDB) EnsureIndexes(ctx context.Context) error {
 		return fmt.Errorf("failed to create page_views session_id index: %w", err)
 	}
 
+	// Finds a session's latest view when counting new sessions
 	if _, err := pageViews.Indexes().CreateOne(ctx, mongo.IndexModel{
-		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "page", Value: 1}},
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "session_id", Value: 1}, {Key: "timestamp", Value: -1}},
 	}); err != nil {
-		return fmt.Errorf("failed to create page_views page index: %w", err)
+		return fmt.Errorf("failed to create page_views session timestamp index: %w", err)
 	}
 
 	if _, err := pageViews.Indexes().CreateOne(ctx, mongo.IndexModel{
-		Keys:    bson.D{{Key: "timestamp", Value: 1}},
-		Options: options.Index().SetExpireAfterSeconds(7776000), // 90 days TTL
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "page", Value: 1}},
 	}); err != nil {
-		return fmt.Errorf("failed to create page_views TTL index: %w", err)
+		return fmt.Errorf("failed to create page_views page index: %w", err)
 	}
 
+	// The timestamp indexes of page_views, rsvp_analytics and conversion_events
+	// are TTL indexes the retention scheduler keeps in sync with RETENTION_*
+
 	// RSVP analytics indexes
 	rsvpAnalytics := m.Collection("rsvp_analytics")
 	if _, err := rsvpAnalytics.Indexes().CreateOne(ctx, mongo.IndexModel{
@@ -142,13 +264,6 @@ func (m *MongoDB) EnsureIndexes(ctx context.Context) error {
 		return fmt.Errorf("failed to create rsvp_analytics session_id index: %w", err)
 	}
 
-	if _, err := rsvpAnalytics.Indexes().CreateOne(ctx, mongo.IndexModel{
-		Keys:    bson.D{{Key: "timestamp", Value: 1}},
-		Options: options.Index().SetExpireAfterSeconds(7776000), // 90 days TTL
-	}); err != nil {
-		return fmt.Errorf("failed to create rsvp_analytics TTL index: %w", err)
-	}
-
 	// Conversion events indexes
 	conversions := m.Collection("conversion_events")
 	if _, err := conversions.Indexes().CreateOne(ctx, mongo.IndexModel{
@@ -169,11 +284,19 @@ func (m *MongoDB) EnsureIndexes(ctx context.Context) error {
 		return fmt.Errorf("failed to create conversion_events event index: %w", err)
 	}
 
+	// Request tracing lookups; sparse since only API-ingested events carry a request ID
+	if _, err := pageViews.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "metadata.request_id", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}); err != nil {
+		return fmt.Errorf("failed to create page_views request_id index: %w", err)
+	}
+
 	if _, err := conversions.Indexes().CreateOne(ctx, mongo.IndexModel{
-		Keys:    bson.D{{Key: "timestamp", Value: 1}},
-		Options: options.Index().SetExpireAfterSeconds(7776000), // 90 days TTL
+		Keys:    bson.D{{Key: "properties.request_id", Value: 1}},
+		Options: options.Index().SetSparse(true),
 	}); err != nil {
-		return fmt.Errorf("failed to create conversion_events TTL index: %w", err)
+		return fmt.Errorf("failed to create conversion_events request_id index: %w", err)
 	}
 
 	// Wedding analytics indexes
@@ -189,5 +312,387 @@ func (m *MongoDB) EnsureIndexes(ctx context.Context) error {
 	// Note: _id index is automatically created by MongoDB and is always unique
 	_ = m.Collection("system_analytics") // Initialize collection to ensure it exists
 
+	// RSVP submission queue indexes
+	rsvpSubmissions := m.Collection("rsvp_submissions")
+	if _, err := rsvpSubmissions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "partition", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create rsvp_submissions queue index: %w", err)
+	}
+
+	if _, err := rsvpSubmissions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "processed_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(604800), // 7 days TTL once processed
+	}); err != nil {
+		return fmt.Errorf("failed to create rsvp_submissions TTL index: %w", err)
+	}
+
+	// Background job queue indexes
+	jobs := m.Collection("jobs")
+	if _, err := jobs.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "type", Value: 1}, {Key: "run_at", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create jobs queue index: %w", err)
+	}
+
+	if _, err := jobs.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "type", Value: 1}, {Key: "unique_key", Value: 1}, {Key: "status", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create jobs unique_key index: %w", err)
+	}
+
+	if _, err := jobs.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "processed_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(604800), // 7 days TTL once processed
+	}); err != nil {
+		return fmt.Errorf("failed to create jobs TTL index: %w", err)
+	}
+
+	// Metrics webhook indexes
+	metricsWebhooks := m.Collection("metrics_webhooks")
+	if _, err := metricsWebhooks.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create metrics_webhooks user_id index: %w", err)
+	}
+
+	if _, err := metricsWebhooks.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "active", Value: 1}, {Key: "next_delivery_at", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create metrics_webhooks schedule index: %w", err)
+	}
+
+	metricsWebhookDeliveries := m.Collection("metrics_webhook_deliveries")
+	if _, err := metricsWebhookDeliveries.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "webhook_id", Value: 1}, {Key: "attempted_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create metrics_webhook_deliveries webhook_id index: %w", err)
+	}
+
+	// Tenant lifecycle webhook indexes
+	tenantWebhooks := m.Collection("tenant_webhooks")
+	if _, err := tenantWebhooks.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create tenant_webhooks tenant_id index: %w", err)
+	}
+
+	lifecycleOutbox := m.Collection("lifecycle_outbox")
+	if _, err := lifecycleOutbox.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "dispatched", Value: 1}, {Key: "occurred_at", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create lifecycle_outbox dispatched index: %w", err)
+	}
+
+	if _, err := lifecycleOutbox.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "occurred_at", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create lifecycle_outbox tenant_id index: %w", err)
+	}
+
+	tenantWebhookDeliveries := m.Collection("tenant_webhook_deliveries")
+	if _, err := tenantWebhookDeliveries.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "webhook_id", Value: 1}, {Key: "attempted_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create tenant_webhook_deliveries webhook_id index: %w", err)
+	}
+
+	// RSVP fraud scoring indexes: per-IP submission velocity and the owner review queue
+	if _, err := rsvps.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "ip_address", Value: 1}, {Key: "submitted_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create rsvps ip_address index: %w", err)
+	}
+
+	if _, err := rsvps.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "wedding_id", Value: 1}, {Key: "review.status", Value: 1}, {Key: "submitted_at", Value: -1}},
+		Options: options.Index().SetPartialFilterExpression(bson.M{"review.status": bson.M{"$exists": true}}),
+	}); err != nil {
+		return fmt.Errorf("failed to create rsvps review index: %w", err)
+	}
+
+	// Export job history indexes
+	exportJobs := m.Collection("export_jobs")
+	if _, err := exportJobs.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "created_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create export_jobs wedding_id index: %w", err)
+	}
+
+	// Analytics digest settings indexes
+	analyticsReports := m.Collection("analytics_report_settings")
+	if _, err := analyticsReports.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "wedding_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return fmt.Errorf("failed to create analytics_report_settings wedding_id index: %w", err)
+	}
+
+	if _, err := analyticsReports.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "enabled", Value: 1}, {Key: "next_send_at", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create analytics_report_settings schedule index: %w", err)
+	}
+
+	// Reminder campaign indexes
+	reminderCampaigns := m.Collection("reminder_campaigns")
+	if _, err := reminderCampaigns.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "send_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create reminder_campaigns wedding_id index: %w", err)
+	}
+
+	if _, err := reminderCampaigns.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "send_at", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create reminder_campaigns schedule index: %w", err)
+	}
+
+	reminderDeliveries := m.Collection("reminder_deliveries")
+	if _, err := reminderDeliveries.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "campaign_id", Value: 1}, {Key: "guest_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return fmt.Errorf("failed to create reminder_deliveries campaign_id index: %w", err)
+	}
+
+	if _, err := reminderDeliveries.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "email", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create reminder_deliveries email index: %w", err)
+	}
+
+	if _, err := reminderDeliveries.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "phone", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create reminder_deliveries phone index: %w", err)
+	}
+
+	// Guest photo gallery indexes
+	guestPhotos := m.Collection("guest_photos")
+	if _, err := guestPhotos.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create guest_photos wedding_id index: %w", err)
+	}
+
+	// Resumable upload session indexes. Expired sessions are not removed by a TTL
+	// index, since their staged chunks have to be deleted along with them.
+	uploadSessions := m.Collection("upload_sessions")
+	if _, err := uploadSessions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "expires_at", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create upload_sessions expires_at index: %w", err)
+	}
+
+	// Billing indexes; payment provider events name the customer, not the user
+	if _, err := users.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "subscription.customer_id", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}); err != nil {
+		return fmt.Errorf("failed to create users subscription.customer_id index: %w", err)
+	}
+
+	// Social logins find their user by the provider's account ID
+	if _, err := users.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "oauth_accounts.provider", Value: 1}, {Key: "oauth_accounts.subject", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}); err != nil {
+		return fmt.Errorf("failed to create users oauth_accounts index: %w", err)
+	}
+
+	// Unlock links find the locked account by their token
+	if _, err := users.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "login_security.unlock_token_hash", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}); err != nil {
+		return fmt.Errorf("failed to create users unlock token index: %w", err)
+	}
+
+	// Account deletion links find the account by their token; the erasure
+	// worker looks for deletions that are due
+	if _, err := users.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "deletion.token_hash", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "deletion.scheduled_for", Value: 1}}, Options: options.Index().SetSparse(true)},
+	}); err != nil {
+		return fmt.Errorf("failed to create users account deletion indexes: %w", err)
+	}
+
+	// Sign-in session indexes; expired sessions can no longer be refreshed and are removed
+	sessions := m.Collection("sessions")
+	if _, err := sessions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "last_seen_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create sessions user_id index: %w", err)
+	}
+
+	if _, err := sessions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}); err != nil {
+		return fmt.Errorf("failed to create sessions TTL index: %w", err)
+	}
+
+	// Each address is suppressed once; the reason is replaced when it bounces again
+	if _, err := m.Collection("email_suppressions").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return fmt.Errorf("failed to create email_suppressions email index: %w", err)
+	}
+
+	if _, err := m.Collection("media").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "createdBy", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create media createdBy index: %w", err)
+	}
+
+	// Gift registry indexes
+	if _, err := m.Collection("registry_items").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "order", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create registry_items wedding_id index: %w", err)
+	}
+	if _, err := m.Collection("gift_pledges").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create gift_pledges wedding_id index: %w", err)
+	}
+
+	// Budget indexes; the sparse remind_at index serves the payment reminder scheduler
+	if _, err := m.Collection("budget_categories").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "order", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create budget_categories wedding_id index: %w", err)
+	}
+	if _, err := m.Collection("budget_payments").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "due_date", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create budget_payments wedding_id index: %w", err)
+	}
+	if _, err := m.Collection("budget_payments").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "remind_at", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}); err != nil {
+		return fmt.Errorf("failed to create budget_payments remind_at index: %w", err)
+	}
+
+	// Vendor indexes
+	if _, err := m.Collection("vendors").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "name", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create vendors wedding_id index: %w", err)
+	}
+
+	// Push subscription indexes; a browser subscribes once per endpoint
+	if _, err := m.Collection("push_subscriptions").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "endpoint", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return fmt.Errorf("failed to create push_subscriptions endpoint index: %w", err)
+	}
+	if _, err := m.Collection("push_subscriptions").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create push_subscriptions user_id index: %w", err)
+	}
+
+	// Guest group indexes
+	if _, err := m.Collection("guest_groups").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "name", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create guest_groups wedding_id index: %w", err)
+	}
+
+	// Slug redirects are keyed by the old slug
+	if _, err := m.Collection("slug_redirects").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create slug_redirects wedding_id index: %w", err)
+	}
+
+	// Wedding revision indexes; numbers are unique per wedding
+	if _, err := m.Collection("wedding_revisions").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "wedding_id", Value: 1}, {Key: "number", Value: -1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return fmt.Errorf("failed to create wedding_revisions wedding_id index: %w", err)
+	}
+
+	// Preview link indexes
+	if _, err := m.Collection("preview_links").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "created_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create preview_links wedding_id index: %w", err)
+	}
+
+	// API key indexes
+	if _, err := m.Collection("api_keys").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "key_hash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return fmt.Errorf("failed to create api_keys key_hash index: %w", err)
+	}
+
+	if _, err := m.Collection("api_keys").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create api_keys user_id index: %w", err)
+	}
+
+	if _, err := m.Collection("api_key_usage").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "key_id", Value: 1}, {Key: "date", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return fmt.Errorf("failed to create api_key_usage key_id index: %w", err)
+	}
+
+	// Audit log indexes
+	auditLogs := m.Collection("audit_logs")
+	if _, err := auditLogs.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "created_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create audit_logs wedding_id index: %w", err)
+	}
+
+	if _, err := auditLogs.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "created_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create audit_logs actor_id index: %w", err)
+	}
+
+	if _, err := auditLogs.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}, {Key: "created_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create audit_logs target index: %w", err)
+	}
+
+	if _, err := auditLogs.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "created_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create audit_logs created_at index: %w", err)
+	}
+
+	// Abuse report indexes
+	abuseReports := m.Collection("abuse_reports")
+	if _, err := abuseReports.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create abuse_reports status index: %w", err)
+	}
+
+	if _, err := abuseReports.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "wedding_id", Value: 1}, {Key: "status", Value: 1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create abuse_reports wedding_id index: %w", err)
+	}
+
+	// Admins filter weddings by moderation state
+	if _, err := weddings.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "moderation.flagged", Value: 1}, {Key: "created_at", Value: -1}},
+	}); err != nil {
+		return fmt.Errorf("failed to create weddings moderation index: %w", err)
+	}
+
 	return nil
 }
@@ -2,8 +2,12 @@ package database
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"wedding-invitation-backend/internal/config"
 )
 
@@ -47,3 +51,31 @@ func TestDatabaseConfig_Defaults(t *testing.T) {
 	assert.Equal(t, "test_db", cfg.Database)
 	assert.Equal(t, 10, cfg.Timeout)
 }
+
+func TestApplyClientOptions(t *testing.T) {
+	cfg := &config.DatabaseConfig{
+		MaxPoolSize:            50,
+		MinPoolSize:            5,
+		MaxConnIdleTime:        5 * time.Minute,
+		ServerSelectionTimeout: 10 * time.Second,
+		ReadPreference:         "secondaryPreferred",
+		OperationTimeout:       30 * time.Second,
+	}
+	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017/?maxPoolSize=10")
+	require.NoError(t, applyClientOptions(clientOptions, cfg))
+
+	assert.Equal(t, uint64(50), *clientOptions.MaxPoolSize)
+	assert.Equal(t, uint64(5), *clientOptions.MinPoolSize)
+	assert.Equal(t, 5*time.Minute, *clientOptions.MaxConnIdleTime)
+	assert.Equal(t, 10*time.Second, *clientOptions.ServerSelectionTimeout)
+	assert.Equal(t, readpref.SecondaryPreferredMode, clientOptions.ReadPreference.Mode())
+	assert.Equal(t, 30*time.Second, *clientOptions.Timeout)
+
+	// Options left zero keep those of the URI
+	clientOptions = options.Client().ApplyURI("mongodb://localhost:27017/?maxPoolSize=10")
+	require.NoError(t, applyClientOptions(clientOptions, &config.DatabaseConfig{}))
+	assert.Equal(t, uint64(10), *clientOptions.MaxPoolSize)
+	assert.Nil(t, clientOptions.Timeout)
+
+	assert.Error(t, applyClientOptions(options.Client(), &config.DatabaseConfig{ReadPreference: "secondaryOnly"}))
+}
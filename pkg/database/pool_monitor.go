@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// MongoDB connection pool metrics, exported by the globally installed
+// OpenTelemetry meter provider and labelled with the server's address
+var (
+	poolMeter = otel.Meter("wedding-invitation-backend")
+
+	poolConnections, _ = poolMeter.Int64UpDownCounter(
+		"db.client.connection.count",
+		metric.WithDescription("Connections open to MongoDB"),
+		metric.WithUnit("{connection}"),
+	)
+	poolCheckedOut, _ = poolMeter.Int64UpDownCounter(
+		"db.client.connection.checked_out",
+		metric.WithDescription("Connections to MongoDB in use by an operation"),
+		metric.WithUnit("{connection}"),
+	)
+	poolWaitTime, _ = poolMeter.Float64Histogram(
+		"db.client.connection.wait_time",
+		metric.WithDescription("Time operations waited to check out a MongoDB connection"),
+		metric.WithUnit("s"),
+	)
+	poolCheckOutFailures, _ = poolMeter.Int64Counter(
+		"db.client.connection.checkout_failures",
+		metric.WithDescription("Operations that failed to check out a MongoDB connection, by reason"),
+		metric.WithUnit("{operation}"),
+	)
+	poolClears, _ = poolMeter.Int64Counter(
+		"db.client.connection.pool_clears",
+		metric.WithDescription("Times a MongoDB connection pool was cleared after a server error"),
+		metric.WithUnit("{clear}"),
+	)
+)
+
+// newPoolMonitor records the connection pool metrics. A pool running out of
+// connections shows as growing wait times and checkout timeouts.
+func newPoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			// Pool events come without the context of the operation
+			ctx := context.Background()
+			server := metric.WithAttributes(attribute.String("server.address", e.Address))
+			switch e.Type {
+			case event.ConnectionCreated:
+				poolConnections.Add(ctx, 1, server)
+			case event.ConnectionClosed:
+				poolConnections.Add(ctx, -1, server)
+			case event.GetSucceeded:
+				poolCheckedOut.Add(ctx, 1, server)
+				poolWaitTime.Record(ctx, e.Duration.Seconds(), server)
+			case event.ConnectionReturned:
+				poolCheckedOut.Add(ctx, -1, server)
+			case event.GetFailed:
+				poolWaitTime.Record(ctx, e.Duration.Seconds(), server)
+				poolCheckOutFailures.Add(ctx, 1, metric.WithAttributes(
+					attribute.String("server.address", e.Address),
+					attribute.String("reason", e.Reason),
+				))
+			case event.PoolCleared:
+				poolClears.Add(ctx, 1, server)
+			}
+		},
+	}
+}
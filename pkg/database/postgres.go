@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratepgx "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/jackc/pgx/v5/stdlib" // Registers the "pgx" database/sql driver
+
+	"wedding-invitation-backend/internal/config"
+)
+
+// Database drivers for users, weddings, guests and RSVPs; everything else is
+// always stored in MongoDB
+const (
+	DriverMongoDB  = "mongodb"
+	DriverPostgres = "postgres"
+)
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+// OpenPostgres returns a handle to the PostgreSQL database at POSTGRES_URL. It
+// does not connect; connections are opened when first needed.
+func OpenPostgres(cfg *config.DatabaseConfig) (*sql.DB, error) {
+	if cfg.PostgresURL == "" {
+		return nil, errors.New("POSTGRES_URL is required when DATABASE_DRIVER is postgres")
+	}
+	db, err := sql.Open("pgx", cfg.PostgresURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid POSTGRES_URL: %w", err)
+	}
+	return db, nil
+}
+
+// MigratePostgres applies the pending schema migrations to the PostgreSQL
+// database. Concurrent callers wait for each other on a database lock.
+func MigratePostgres(cfg *config.DatabaseConfig) error {
+	// The migration driver closes the connection it is given, so it gets its own
+	db, err := OpenPostgres(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Timeout)*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return fmt.Errorf("failed to ping PostgreSQL: %w", err)
+	}
+
+	source, err := iofs.New(postgresMigrations, "migrations/postgres")
+	if err != nil {
+		db.Close()
+		return fmt.Errorf("failed to read PostgreSQL migrations: %w", err)
+	}
+	driver, err := migratepgx.WithInstance(db, &migratepgx.Config{})
+	if err != nil {
+		db.Close()
+		return fmt.Errorf("failed to prepare PostgreSQL migrations: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", source, "pgx", driver)
+	if err != nil {
+		driver.Close()
+		return fmt.Errorf("failed to prepare PostgreSQL migrations: %w", err)
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to migrate PostgreSQL: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+var idType = reflect.TypeOf(models.NilID)
+
+// NewRegistry returns the BSON registry of the MongoDB client. It stores
+// models.ID values as ObjectIDs, as records have always been identified, in
+// documents as well as in query filters.
+func NewRegistry() *bsoncodec.Registry {
+	registry := bson.NewRegistry()
+	registry.RegisterTypeEncoder(idType, bsoncodec.ValueEncoderFunc(encodeID))
+	registry.RegisterTypeDecoder(idType, bsoncodec.ValueDecoderFunc(decodeID))
+	return registry
+}
+
+// encodeID writes an ID as an ObjectID. IDs that are not ObjectID hex strings
+// are written as strings, so they still match only themselves.
+func encodeID(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != idType {
+		return bsoncodec.ValueEncoderError{Name: "encodeID", Types: []reflect.Type{idType}, Received: val}
+	}
+
+	id := models.ID(val.String())
+	if id.IsZero() {
+		return vw.WriteObjectID(primitive.NilObjectID)
+	}
+	oid, err := primitive.ObjectIDFromHex(id.String())
+	if err != nil {
+		return vw.WriteString(id.String())
+	}
+	return vw.WriteObjectID(oid)
+}
+
+// decodeID reads an ID written by encodeID; the nil ObjectID reads as models.NilID
+func decodeID(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != idType {
+		return bsoncodec.ValueDecoderError{Name: "decodeID", Types: []reflect.Type{idType}, Received: val}
+	}
+
+	var id models.ID
+	switch vr.Type() {
+	case bsontype.ObjectID:
+		oid, err := vr.ReadObjectID()
+		if err != nil {
+			return err
+		}
+		if !oid.IsZero() {
+			id = models.ID(oid.Hex())
+		}
+	case bsontype.String:
+		s, err := vr.ReadString()
+		if err != nil {
+			return err
+		}
+		id = models.ID(s)
+	case bsontype.Null:
+		if err := vr.ReadNull(); err != nil {
+			return err
+		}
+	case bsontype.Undefined:
+		if err := vr.ReadUndefined(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("cannot decode %v into an ID", vr.Type())
+	}
+
+	val.SetString(id.String())
+	return nil
+}
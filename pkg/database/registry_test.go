@@ -0,0 +1,62 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"wedding-invitation-backend/internal/domain/models"
+)
+
+func TestRegistry_StoresIDsAsObjectIDs(t *testing.T) {
+	registry := NewRegistry()
+	id := models.NewID()
+
+	type record struct {
+		ID       models.ID   `bson:"_id"`
+		OwnerID  models.ID   `bson:"owner_id"`
+		Members  []models.ID `bson:"members"`
+		Optional models.ID   `bson:"optional,omitempty"`
+	}
+
+	data, err := bson.MarshalWithRegistry(registry, record{ID: id, Members: []models.ID{id}})
+	require.NoError(t, err)
+
+	var raw bson.M
+	require.NoError(t, bson.Unmarshal(data, &raw))
+	oid, err := primitive.ObjectIDFromHex(id.String())
+	require.NoError(t, err)
+	assert.Equal(t, oid, raw["_id"])
+	assert.Equal(t, primitive.NilObjectID, raw["owner_id"], "the nil ID is stored as the nil ObjectID")
+	assert.Equal(t, primitive.A{oid}, raw["members"])
+	assert.NotContains(t, raw, "optional")
+
+	var decoded record
+	require.NoError(t, bson.UnmarshalWithRegistry(registry, data, &decoded))
+	assert.Equal(t, record{ID: id, Members: []models.ID{id}}, decoded)
+}
+
+func TestRegistry_FiltersMatchStoredIDs(t *testing.T) {
+	registry := NewRegistry()
+	id := models.NewID()
+	oid, err := primitive.ObjectIDFromHex(id.String())
+	require.NoError(t, err)
+
+	filter, err := bson.MarshalWithRegistry(registry, bson.M{"_id": bson.M{"$in": []models.ID{id}}})
+	require.NoError(t, err)
+	expected, err := bson.Marshal(bson.M{"_id": bson.M{"$in": []primitive.ObjectID{oid}}})
+	require.NoError(t, err)
+	assert.Equal(t, bson.Raw(expected), bson.Raw(filter))
+
+	// IDs that are not ObjectIDs are kept as strings
+	data, err := bson.MarshalWithRegistry(registry, bson.M{"_id": models.ID("legacy")})
+	require.NoError(t, err)
+	var decoded struct {
+		ID models.ID `bson:"_id"`
+	}
+	require.NoError(t, bson.UnmarshalWithRegistry(registry, data, &decoded))
+	assert.Equal(t, models.ID("legacy"), decoded.ID)
+}
@@ -12,7 +12,6 @@ import (
 	repository "wedding-invitation-backend/internal/domain/repository"
 
 	gomock "github.com/golang/mock/gomock"
-	primitive "go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // MockUserRepository is a mock of UserRepository interface.
@@ -39,7 +38,7 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 }
 
 // AddWeddingID mocks base method.
-func (m *MockUserRepository) AddWeddingID(ctx context.Context, userID, weddingID primitive.ObjectID) error {
+func (m *MockUserRepository) AddWeddingID(ctx context.Context, userID, weddingID models.ID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddWeddingID", ctx, userID, weddingID)
 	ret0, _ := ret[0].(error)
@@ -67,7 +66,7 @@ func (mr *MockUserRepositoryMockRecorder) Create(ctx, user interface{}) *gomock.
 }
 
 // Delete mocks base method.
-func (m *MockUserRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockUserRepository) Delete(ctx context.Context, id models.ID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
@@ -80,6 +79,21 @@ func (mr *MockUserRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), ctx, id)
 }
 
+// GetByBillingCustomerID mocks base method.
+func (m *MockUserRepository) GetByBillingCustomerID(ctx context.Context, customerID string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByBillingCustomerID", ctx, customerID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByBillingCustomerID indicates an expected call of GetByBillingCustomerID.
+func (mr *MockUserRepositoryMockRecorder) GetByBillingCustomerID(ctx, customerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBillingCustomerID", reflect.TypeOf((*MockUserRepository)(nil).GetByBillingCustomerID), ctx, customerID)
+}
+
 // GetByEmail mocks base method.
 func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -96,7 +110,7 @@ func (mr *MockUserRepositoryMockRecorder) GetByEmail(ctx, email interface{}) *go
 }
 
 // GetByID mocks base method.
-func (m *MockUserRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+func (m *MockUserRepository) GetByID(ctx context.Context, id models.ID) (*models.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByID", ctx, id)
 	ret0, _ := ret[0].(*models.User)
@@ -110,6 +124,21 @@ func (mr *MockUserRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByOAuthAccount mocks base method.
+func (m *MockUserRepository) GetByOAuthAccount(ctx context.Context, provider models.AuthProvider, subject string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOAuthAccount", ctx, provider, subject)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOAuthAccount indicates an expected call of GetByOAuthAccount.
+func (mr *MockUserRepositoryMockRecorder) GetByOAuthAccount(ctx, provider, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOAuthAccount", reflect.TypeOf((*MockUserRepository)(nil).GetByOAuthAccount), ctx, provider, subject)
+}
+
 // GetByResetToken mocks base method.
 func (m *MockUserRepository) GetByResetToken(ctx context.Context, token string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +154,21 @@ func (mr *MockUserRepositoryMockRecorder) GetByResetToken(ctx, token interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByResetToken", reflect.TypeOf((*MockUserRepository)(nil).GetByResetToken), ctx, token)
 }
 
+// GetByUnlockToken mocks base method.
+func (m *MockUserRepository) GetByUnlockToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUnlockToken", ctx, tokenHash)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUnlockToken indicates an expected call of GetByUnlockToken.
+func (mr *MockUserRepositoryMockRecorder) GetByUnlockToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUnlockToken", reflect.TypeOf((*MockUserRepository)(nil).GetByUnlockToken), ctx, tokenHash)
+}
+
 // GetByVerificationToken mocks base method.
 func (m *MockUserRepository) GetByVerificationToken(ctx context.Context, token string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -156,8 +200,37 @@ func (mr *MockUserRepositoryMockRecorder) List(ctx, page, pageSize, filters inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), ctx, page, pageSize, filters)
 }
 
+// LockLogin mocks base method.
+func (m *MockUserRepository) LockLogin(ctx context.Context, userID models.ID, until time.Time, unlockTokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLogin", ctx, userID, until, unlockTokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockLogin indicates an expected call of LockLogin.
+func (mr *MockUserRepositoryMockRecorder) LockLogin(ctx, userID, until, unlockTokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLogin", reflect.TypeOf((*MockUserRepository)(nil).LockLogin), ctx, userID, until, unlockTokenHash)
+}
+
+// RecordFailedLogin mocks base method.
+func (m *MockUserRepository) RecordFailedLogin(ctx context.Context, userID models.ID) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailedLogin", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailedLogin indicates an expected call of RecordFailedLogin.
+func (mr *MockUserRepositoryMockRecorder) RecordFailedLogin(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailedLogin", reflect.TypeOf((*MockUserRepository)(nil).RecordFailedLogin), ctx, userID)
+}
+
 // RemoveWeddingID mocks base method.
-func (m *MockUserRepository) RemoveWeddingID(ctx context.Context, userID, weddingID primitive.ObjectID) error {
+func (m *MockUserRepository) RemoveWeddingID(ctx context.Context, userID, weddingID models.ID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RemoveWeddingID", ctx, userID, weddingID)
 	ret0, _ := ret[0].(error)
@@ -171,7 +244,7 @@ func (mr *MockUserRepositoryMockRecorder) RemoveWeddingID(ctx, userID, weddingID
 }
 
 // SetEmailVerified mocks base method.
-func (m *MockUserRepository) SetEmailVerified(ctx context.Context, userID primitive.ObjectID) error {
+func (m *MockUserRepository) SetEmailVerified(ctx context.Context, userID models.ID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetEmailVerified", ctx, userID)
 	ret0, _ := ret[0].(error)
@@ -184,6 +257,20 @@ func (mr *MockUserRepositoryMockRecorder) SetEmailVerified(ctx, userID interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailVerified", reflect.TypeOf((*MockUserRepository)(nil).SetEmailVerified), ctx, userID)
 }
 
+// UnlockLogin mocks base method.
+func (m *MockUserRepository) UnlockLogin(ctx context.Context, userID models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockLogin", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockLogin indicates an expected call of UnlockLogin.
+func (mr *MockUserRepositoryMockRecorder) UnlockLogin(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockLogin", reflect.TypeOf((*MockUserRepository)(nil).UnlockLogin), ctx, userID)
+}
+
 // Update mocks base method.
 func (m *MockUserRepository) Update(ctx context.Context, user *models.User) error {
 	m.ctrl.T.Helper()
@@ -199,7 +286,7 @@ func (mr *MockUserRepositoryMockRecorder) Update(ctx, user interface{}) *gomock.
 }
 
 // UpdateLastLogin mocks base method.
-func (m *MockUserRepository) UpdateLastLogin(ctx context.Context, userID primitive.ObjectID) error {
+func (m *MockUserRepository) UpdateLastLogin(ctx context.Context, userID models.ID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateLastLogin", ctx, userID)
 	ret0, _ := ret[0].(error)
@@ -212,6 +299,20 @@ func (mr *MockUserRepositoryMockRecorder) UpdateLastLogin(ctx, userID interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastLogin", reflect.TypeOf((*MockUserRepository)(nil).UpdateLastLogin), ctx, userID)
 }
 
+// UpdateSubscription mocks base method.
+func (m *MockUserRepository) UpdateSubscription(ctx context.Context, userID models.ID, subscription *models.Subscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", ctx, userID, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockUserRepositoryMockRecorder) UpdateSubscription(ctx, userID, subscription interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockUserRepository)(nil).UpdateSubscription), ctx, userID, subscription)
+}
+
 // MockWeddingRepository is a mock of WeddingRepository interface.
 type MockWeddingRepository struct {
 	ctrl     *gomock.Controller
@@ -235,6 +336,36 @@ func (m *MockWeddingRepository) EXPECT() *MockWeddingRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountByOwner mocks base method.
+func (m *MockWeddingRepository) CountByOwner(ctx context.Context, userID models.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByOwner", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByOwner indicates an expected call of CountByOwner.
+func (mr *MockWeddingRepositoryMockRecorder) CountByOwner(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByOwner", reflect.TypeOf((*MockWeddingRepository)(nil).CountByOwner), ctx, userID)
+}
+
+// CountByTheme mocks base method.
+func (m *MockWeddingRepository) CountByTheme(ctx context.Context, themeID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByTheme", ctx, themeID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByTheme indicates an expected call of CountByTheme.
+func (mr *MockWeddingRepositoryMockRecorder) CountByTheme(ctx, themeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByTheme", reflect.TypeOf((*MockWeddingRepository)(nil).CountByTheme), ctx, themeID)
+}
+
 // Create mocks base method.
 func (m *MockWeddingRepository) Create(ctx context.Context, wedding *models.Wedding) error {
 	m.ctrl.T.Helper()
@@ -250,7 +381,7 @@ func (mr *MockWeddingRepositoryMockRecorder) Create(ctx, wedding interface{}) *g
 }
 
 // Delete mocks base method.
-func (m *MockWeddingRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockWeddingRepository) Delete(ctx context.Context, id models.ID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
@@ -279,7 +410,7 @@ func (mr *MockWeddingRepositoryMockRecorder) ExistsBySlug(ctx, slug interface{})
 }
 
 // GetByID mocks base method.
-func (m *MockWeddingRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Wedding, error) {
+func (m *MockWeddingRepository) GetByID(ctx context.Context, id models.ID) (*models.Wedding, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByID", ctx, id)
 	ret0, _ := ret[0].(*models.Wedding)
@@ -293,6 +424,21 @@ func (mr *MockWeddingRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWeddingRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByIDs mocks base method.
+func (m *MockWeddingRepository) GetByIDs(ctx context.Context, ids []models.ID) ([]*models.Wedding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*models.Wedding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockWeddingRepositoryMockRecorder) GetByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockWeddingRepository)(nil).GetByIDs), ctx, ids)
+}
+
 // GetBySlug mocks base method.
 func (m *MockWeddingRepository) GetBySlug(ctx context.Context, slug string) (*models.Wedding, error) {
 	m.ctrl.T.Helper()
@@ -309,7 +455,7 @@ func (mr *MockWeddingRepositoryMockRecorder) GetBySlug(ctx, slug interface{}) *g
 }
 
 // GetByUserID mocks base method.
-func (m *MockWeddingRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error) {
+func (m *MockWeddingRepository) GetByUserID(ctx context.Context, userID models.ID, page, pageSize int, filters repository.WeddingFilters) ([]*models.Wedding, int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID, page, pageSize, filters)
 	ret0, _ := ret[0].([]*models.Wedding)
@@ -325,7 +471,7 @@ func (mr *MockWeddingRepositoryMockRecorder) GetByUserID(ctx, userID, page, page
 }
 
 // IncrementViewCount mocks base method.
-func (m *MockWeddingRepository) IncrementViewCount(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockWeddingRepository) IncrementViewCount(ctx context.Context, id models.ID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "IncrementViewCount", ctx, id)
 	ret0, _ := ret[0].(error)
@@ -338,6 +484,22 @@ func (mr *MockWeddingRepositoryMockRecorder) IncrementViewCount(ctx, id interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementViewCount", reflect.TypeOf((*MockWeddingRepository)(nil).IncrementViewCount), ctx, id)
 }
 
+// List mocks base method.
+func (m *MockWeddingRepository) List(ctx context.Context, page, pageSize int, filters repository.AdminWeddingFilters) ([]*models.Wedding, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, page, pageSize, filters)
+	ret0, _ := ret[0].([]*models.Wedding)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockWeddingRepositoryMockRecorder) List(ctx, page, pageSize, filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWeddingRepository)(nil).List), ctx, page, pageSize, filters)
+}
+
 // ListPublic mocks base method.
 func (m *MockWeddingRepository) ListPublic(ctx context.Context, page, pageSize int, filters repository.PublicWeddingFilters) ([]*models.Wedding, int64, error) {
 	m.ctrl.T.Helper()
@@ -369,7 +531,7 @@ func (mr *MockWeddingRepositoryMockRecorder) Update(ctx, wedding interface{}) *g
 }
 
 // UpdateRSVPCount mocks base method.
-func (m *MockWeddingRepository) UpdateRSVPCount(ctx context.Context, weddingID primitive.ObjectID) error {
+func (m *MockWeddingRepository) UpdateRSVPCount(ctx context.Context, weddingID models.ID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateRSVPCount", ctx, weddingID)
 	ret0, _ := ret[0].(error)
@@ -405,6 +567,51 @@ func (m *MockRSVPRepository) EXPECT() *MockRSVPRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountByIPSince mocks base method.
+func (m *MockRSVPRepository) CountByIPSince(ctx context.Context, weddingID models.ID, ipAddress string, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByIPSince", ctx, weddingID, ipAddress, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByIPSince indicates an expected call of CountByIPSince.
+func (mr *MockRSVPRepositoryMockRecorder) CountByIPSince(ctx, weddingID, ipAddress, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByIPSince", reflect.TypeOf((*MockRSVPRepository)(nil).CountByIPSince), ctx, weddingID, ipAddress, since)
+}
+
+// GetAttendanceTimeline mocks base method.
+func (m *MockRSVPRepository) GetAttendanceTimeline(ctx context.Context, weddingID models.ID) ([]models.AttendanceDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttendanceTimeline", ctx, weddingID)
+	ret0, _ := ret[0].([]models.AttendanceDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttendanceTimeline indicates an expected call of GetAttendanceTimeline.
+func (mr *MockRSVPRepositoryMockRecorder) GetAttendanceTimeline(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttendanceTimeline", reflect.TypeOf((*MockRSVPRepository)(nil).GetAttendanceTimeline), ctx, weddingID)
+}
+
+// GetMealCounts mocks base method.
+func (m *MockRSVPRepository) GetMealCounts(ctx context.Context, weddingID models.ID) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMealCounts", ctx, weddingID)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMealCounts indicates an expected call of GetMealCounts.
+func (mr *MockRSVPRepositoryMockRecorder) GetMealCounts(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMealCounts", reflect.TypeOf((*MockRSVPRepository)(nil).GetMealCounts), ctx, weddingID)
+}
+
 // Create mocks base method.
 func (m *MockRSVPRepository) Create(ctx context.Context, rsvp *models.RSVP) error {
 	m.ctrl.T.Helper()
@@ -420,7 +627,7 @@ func (mr *MockRSVPRepositoryMockRecorder) Create(ctx, rsvp interface{}) *gomock.
 }
 
 // Delete mocks base method.
-func (m *MockRSVPRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockRSVPRepository) Delete(ctx context.Context, id models.ID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
@@ -434,7 +641,7 @@ func (mr *MockRSVPRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Ca
 }
 
 // GetByEmail mocks base method.
-func (m *MockRSVPRepository) GetByEmail(ctx context.Context, weddingID primitive.ObjectID, email string) (*models.RSVP, error) {
+func (m *MockRSVPRepository) GetByEmail(ctx context.Context, weddingID models.ID, email string) (*models.RSVP, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByEmail", ctx, weddingID, email)
 	ret0, _ := ret[0].(*models.RSVP)
@@ -449,7 +656,7 @@ func (mr *MockRSVPRepositoryMockRecorder) GetByEmail(ctx, weddingID, email inter
 }
 
 // GetByID mocks base method.
-func (m *MockRSVPRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.RSVP, error) {
+func (m *MockRSVPRepository) GetByID(ctx context.Context, id models.ID) (*models.RSVP, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByID", ctx, id)
 	ret0, _ := ret[0].(*models.RSVP)
@@ -464,7 +671,7 @@ func (mr *MockRSVPRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.C
 }
 
 // GetStatistics mocks base method.
-func (m *MockRSVPRepository) GetStatistics(ctx context.Context, weddingID primitive.ObjectID) (*models.RSVPStatistics, error) {
+func (m *MockRSVPRepository) GetStatistics(ctx context.Context, weddingID models.ID) (*models.RSVPStatistics, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStatistics", ctx, weddingID)
 	ret0, _ := ret[0].(*models.RSVPStatistics)
@@ -479,7 +686,7 @@ func (mr *MockRSVPRepositoryMockRecorder) GetStatistics(ctx, weddingID interface
 }
 
 // GetSubmissionTrend mocks base method.
-func (m *MockRSVPRepository) GetSubmissionTrend(ctx context.Context, weddingID primitive.ObjectID, days int) ([]models.DailyCount, error) {
+func (m *MockRSVPRepository) GetSubmissionTrend(ctx context.Context, weddingID models.ID, days int) ([]models.DailyCount, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSubmissionTrend", ctx, weddingID, days)
 	ret0, _ := ret[0].([]models.DailyCount)
@@ -494,7 +701,7 @@ func (mr *MockRSVPRepositoryMockRecorder) GetSubmissionTrend(ctx, weddingID, day
 }
 
 // ListByWedding mocks base method.
-func (m *MockRSVPRepository) ListByWedding(ctx context.Context, weddingID primitive.ObjectID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error) {
+func (m *MockRSVPRepository) ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int, filters repository.RSVPFilters) ([]*models.RSVP, int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListByWedding", ctx, weddingID, page, pageSize, filters)
 	ret0, _ := ret[0].([]*models.RSVP)
@@ -510,7 +717,7 @@ func (mr *MockRSVPRepositoryMockRecorder) ListByWedding(ctx, weddingID, page, pa
 }
 
 // MarkConfirmationSent mocks base method.
-func (m *MockRSVPRepository) MarkConfirmationSent(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockRSVPRepository) MarkConfirmationSent(ctx context.Context, id models.ID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "MarkConfirmationSent", ctx, id)
 	ret0, _ := ret[0].(error)
@@ -523,6 +730,20 @@ func (mr *MockRSVPRepositoryMockRecorder) MarkConfirmationSent(ctx, id interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkConfirmationSent", reflect.TypeOf((*MockRSVPRepository)(nil).MarkConfirmationSent), ctx, id)
 }
 
+// StreamByWedding mocks base method.
+func (m *MockRSVPRepository) StreamByWedding(ctx context.Context, weddingID models.ID, fn func(*models.RSVP) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamByWedding", ctx, weddingID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamByWedding indicates an expected call of StreamByWedding.
+func (mr *MockRSVPRepositoryMockRecorder) StreamByWedding(ctx, weddingID, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamByWedding", reflect.TypeOf((*MockRSVPRepository)(nil).StreamByWedding), ctx, weddingID, fn)
+}
+
 // Update mocks base method.
 func (m *MockRSVPRepository) Update(ctx context.Context, rsvp *models.RSVP) error {
 	m.ctrl.T.Helper()
@@ -560,6 +781,35 @@ func (m *MockGuestRepository) EXPECT() *MockGuestRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CheckIn mocks base method.
+func (m *MockGuestRepository) CheckIn(ctx context.Context, id models.ID, checkIn models.GuestCheckIn) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIn", ctx, id, checkIn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckIn indicates an expected call of CheckIn.
+func (mr *MockGuestRepositoryMockRecorder) CheckIn(ctx, id, checkIn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIn", reflect.TypeOf((*MockGuestRepository)(nil).CheckIn), ctx, id, checkIn)
+}
+
+// CheckInStats mocks base method.
+func (m *MockGuestRepository) CheckInStats(ctx context.Context, weddingID models.ID) (*models.CheckInStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckInStats", ctx, weddingID)
+	ret0, _ := ret[0].(*models.CheckInStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckInStats indicates an expected call of CheckInStats.
+func (mr *MockGuestRepositoryMockRecorder) CheckInStats(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckInStats", reflect.TypeOf((*MockGuestRepository)(nil).CheckInStats), ctx, weddingID)
+}
+
 // Create mocks base method.
 func (m *MockGuestRepository) Create(ctx context.Context, guest *models.Guest) error {
 	m.ctrl.T.Helper()
@@ -589,7 +839,7 @@ func (mr *MockGuestRepositoryMockRecorder) CreateMany(ctx, guests interface{}) *
 }
 
 // Delete mocks base method.
-func (m *MockGuestRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+func (m *MockGuestRepository) Delete(ctx context.Context, id models.ID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
@@ -602,8 +852,23 @@ func (mr *MockGuestRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGuestRepository)(nil).Delete), ctx, id)
 }
 
+// DeleteMany mocks base method.
+func (m *MockGuestRepository) DeleteMany(ctx context.Context, weddingID models.ID, ids []models.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", ctx, weddingID, ids)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockGuestRepositoryMockRecorder) DeleteMany(ctx, weddingID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockGuestRepository)(nil).DeleteMany), ctx, weddingID, ids)
+}
+
 // GetByEmail mocks base method.
-func (m *MockGuestRepository) GetByEmail(ctx context.Context, weddingID primitive.ObjectID, email string) (*models.Guest, error) {
+func (m *MockGuestRepository) GetByEmail(ctx context.Context, weddingID models.ID, email string) (*models.Guest, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByEmail", ctx, weddingID, email)
 	ret0, _ := ret[0].(*models.Guest)
@@ -618,7 +883,7 @@ func (mr *MockGuestRepositoryMockRecorder) GetByEmail(ctx, weddingID, email inte
 }
 
 // GetByID mocks base method.
-func (m *MockGuestRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Guest, error) {
+func (m *MockGuestRepository) GetByID(ctx context.Context, id models.ID) (*models.Guest, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByID", ctx, id)
 	ret0, _ := ret[0].(*models.Guest)
@@ -633,7 +898,7 @@ func (mr *MockGuestRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.
 }
 
 // GetByImportBatch mocks base method.
-func (m *MockGuestRepository) GetByImportBatch(ctx context.Context, weddingID primitive.ObjectID, batchID string) ([]*models.Guest, error) {
+func (m *MockGuestRepository) GetByImportBatch(ctx context.Context, weddingID models.ID, batchID string) ([]*models.Guest, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByImportBatch", ctx, weddingID, batchID)
 	ret0, _ := ret[0].([]*models.Guest)
@@ -647,6 +912,21 @@ func (mr *MockGuestRepositoryMockRecorder) GetByImportBatch(ctx, weddingID, batc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByImportBatch", reflect.TypeOf((*MockGuestRepository)(nil).GetByImportBatch), ctx, weddingID, batchID)
 }
 
+// GetByPhone mocks base method.
+func (m *MockGuestRepository) GetByPhone(ctx context.Context, weddingID models.ID, phoneKey string) (*models.Guest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPhone", ctx, weddingID, phoneKey)
+	ret0, _ := ret[0].(*models.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPhone indicates an expected call of GetByPhone.
+func (mr *MockGuestRepositoryMockRecorder) GetByPhone(ctx, weddingID, phoneKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPhone", reflect.TypeOf((*MockGuestRepository)(nil).GetByPhone), ctx, weddingID, phoneKey)
+}
+
 // ImportBatch mocks base method.
 func (m *MockGuestRepository) ImportBatch(ctx context.Context, guests []*models.Guest, batchID string) error {
 	m.ctrl.T.Helper()
@@ -662,7 +942,7 @@ func (mr *MockGuestRepositoryMockRecorder) ImportBatch(ctx, guests, batchID inte
 }
 
 // ListByWedding mocks base method.
-func (m *MockGuestRepository) ListByWedding(ctx context.Context, weddingID primitive.ObjectID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error) {
+func (m *MockGuestRepository) ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int, filters repository.GuestFilters) ([]*models.Guest, int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListByWedding", ctx, weddingID, page, pageSize, filters)
 	ret0, _ := ret[0].([]*models.Guest)
@@ -677,135 +957,632 @@ func (mr *MockGuestRepositoryMockRecorder) ListByWedding(ctx, weddingID, page, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWedding", reflect.TypeOf((*MockGuestRepository)(nil).ListByWedding), ctx, weddingID, page, pageSize, filters)
 }
 
-// Update mocks base method.
-func (m *MockGuestRepository) Update(ctx context.Context, guest *models.Guest) error {
+// MarkInvitationSent mocks base method.
+func (m *MockGuestRepository) MarkInvitationSent(ctx context.Context, id models.ID, channel, messageID string, sentAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, guest)
+	ret := m.ctrl.Call(m, "MarkInvitationSent", ctx, id, channel, messageID, sentAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockGuestRepositoryMockRecorder) Update(ctx, guest interface{}) *gomock.Call {
+// MarkInvitationSent indicates an expected call of MarkInvitationSent.
+func (mr *MockGuestRepositoryMockRecorder) MarkInvitationSent(ctx, id, channel, messageID, sentAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGuestRepository)(nil).Update), ctx, guest)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkInvitationSent", reflect.TypeOf((*MockGuestRepository)(nil).MarkInvitationSent), ctx, id, channel, messageID, sentAt)
 }
 
-// MockMediaRepository is a mock of MediaRepository interface.
-type MockMediaRepository struct {
-	ctrl     *gomock.Controller
-	recorder *MockMediaRepositoryMockRecorder
+// SetGroup mocks base method.
+func (m *MockGuestRepository) SetGroup(ctx context.Context, weddingID models.ID, ids []models.ID, groupID *models.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGroup", ctx, weddingID, ids, groupID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockMediaRepositoryMockRecorder is the mock recorder for MockMediaRepository.
-type MockMediaRepositoryMockRecorder struct {
-	mock *MockMediaRepository
+// SetGroup indicates an expected call of SetGroup.
+func (mr *MockGuestRepositoryMockRecorder) SetGroup(ctx, weddingID, ids, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGroup", reflect.TypeOf((*MockGuestRepository)(nil).SetGroup), ctx, weddingID, ids, groupID)
 }
 
-// NewMockMediaRepository creates a new mock instance.
-func NewMockMediaRepository(ctrl *gomock.Controller) *MockMediaRepository {
-	mock := &MockMediaRepository{ctrl: ctrl}
-	mock.recorder = &MockMediaRepositoryMockRecorder{mock}
-	return mock
+// AttendanceBreakdown mocks base method.
+func (m *MockGuestRepository) AttendanceBreakdown(ctx context.Context, weddingID models.ID, field string) ([]models.AttendanceBreakdown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttendanceBreakdown", ctx, weddingID, field)
+	ret0, _ := ret[0].([]models.AttendanceBreakdown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockMediaRepository) EXPECT() *MockMediaRepositoryMockRecorder {
-	return m.recorder
+// AttendanceBreakdown indicates an expected call of AttendanceBreakdown.
+func (mr *MockGuestRepositoryMockRecorder) AttendanceBreakdown(ctx, weddingID, field interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttendanceBreakdown", reflect.TypeOf((*MockGuestRepository)(nil).AttendanceBreakdown), ctx, weddingID, field)
 }
 
-// Create mocks base method.
-func (m *MockMediaRepository) Create(ctx context.Context, media *models.Media) error {
+// Search mocks base method.
+func (m *MockGuestRepository) Search(ctx context.Context, weddingID models.ID, prefixes []string) ([]*models.Guest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, media)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Search", ctx, weddingID, prefixes)
+	ret0, _ := ret[0].([]*models.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockMediaRepositoryMockRecorder) Create(ctx, media interface{}) *gomock.Call {
+// Search indicates an expected call of Search.
+func (mr *MockGuestRepositoryMockRecorder) Search(ctx, weddingID, prefixes interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMediaRepository)(nil).Create), ctx, media)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockGuestRepository)(nil).Search), ctx, weddingID, prefixes)
 }
 
-// Delete mocks base method.
-func (m *MockMediaRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+// StreamByWedding mocks base method.
+func (m *MockGuestRepository) StreamByWedding(ctx context.Context, weddingID models.ID, filters repository.GuestFilters, fn func(*models.Guest) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "StreamByWedding", ctx, weddingID, filters, fn)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockMediaRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+// StreamByWedding indicates an expected call of StreamByWedding.
+func (mr *MockGuestRepositoryMockRecorder) StreamByWedding(ctx, weddingID, filters, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMediaRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamByWedding", reflect.TypeOf((*MockGuestRepository)(nil).StreamByWedding), ctx, weddingID, filters, fn)
 }
 
-// GetByCreatedBy mocks base method.
-func (m *MockMediaRepository) GetByCreatedBy(ctx context.Context, userID primitive.ObjectID, opts repository.ListOptions) ([]*models.Media, int64, error) {
+// Update mocks base method.
+func (m *MockGuestRepository) Update(ctx context.Context, guest *models.Guest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCreatedBy", ctx, userID, opts)
-	ret0, _ := ret[0].([]*models.Media)
-	ret1, _ := ret[1].(int64)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "Update", ctx, guest)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetByCreatedBy indicates an expected call of GetByCreatedBy.
-func (mr *MockMediaRepositoryMockRecorder) GetByCreatedBy(ctx, userID, opts interface{}) *gomock.Call {
+// Update indicates an expected call of Update.
+func (mr *MockGuestRepositoryMockRecorder) Update(ctx, guest interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCreatedBy", reflect.TypeOf((*MockMediaRepository)(nil).GetByCreatedBy), ctx, userID, opts)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGuestRepository)(nil).Update), ctx, guest)
 }
 
-// GetByID mocks base method.
-func (m *MockMediaRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Media, error) {
+// UpdateInvitationStatus mocks base method.
+func (m *MockGuestRepository) UpdateInvitationStatus(ctx context.Context, id models.ID, status string, sentAt *time.Time, reason string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
-	ret0, _ := ret[0].(*models.Media)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateInvitationStatus", ctx, id, status, sentAt, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetByID indicates an expected call of GetByID.
-func (mr *MockMediaRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+// UpdateInvitationStatus indicates an expected call of UpdateInvitationStatus.
+func (mr *MockGuestRepositoryMockRecorder) UpdateInvitationStatus(ctx, id, status, sentAt, reason interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMediaRepository)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvitationStatus", reflect.TypeOf((*MockGuestRepository)(nil).UpdateInvitationStatus), ctx, id, status, sentAt, reason)
 }
 
-// GetByStorageKey mocks base method.
-func (m *MockMediaRepository) GetByStorageKey(ctx context.Context, key string) (*models.Media, error) {
+// UpdateInvitationStatusByMessage mocks base method.
+func (m *MockGuestRepository) UpdateInvitationStatusByMessage(ctx context.Context, messageID, status string, from []string, reason string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByStorageKey", ctx, key)
-	ret0, _ := ret[0].(*models.Media)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateInvitationStatusByMessage", ctx, messageID, status, from, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetByStorageKey indicates an expected call of GetByStorageKey.
-func (mr *MockMediaRepositoryMockRecorder) GetByStorageKey(ctx, key interface{}) *gomock.Call {
+// UpdateInvitationStatusByMessage indicates an expected call of UpdateInvitationStatusByMessage.
+func (mr *MockGuestRepositoryMockRecorder) UpdateInvitationStatusByMessage(ctx, messageID, status, from, reason interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStorageKey", reflect.TypeOf((*MockMediaRepository)(nil).GetByStorageKey), ctx, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvitationStatusByMessage", reflect.TypeOf((*MockGuestRepository)(nil).UpdateInvitationStatusByMessage), ctx, messageID, status, from, reason)
 }
 
-// GetOrphaned mocks base method.
-func (m *MockMediaRepository) GetOrphaned(ctx context.Context, before time.Time) ([]*models.Media, error) {
+// UpdateMany mocks base method.
+func (m *MockGuestRepository) UpdateMany(ctx context.Context, weddingID models.ID, ids []models.ID, patch models.GuestPatch) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrphaned", ctx, before)
-	ret0, _ := ret[0].([]*models.Media)
+	ret := m.ctrl.Call(m, "UpdateMany", ctx, weddingID, ids, patch)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrphaned indicates an expected call of GetOrphaned.
-func (mr *MockMediaRepositoryMockRecorder) GetOrphaned(ctx, before interface{}) *gomock.Call {
+// UpdateMany indicates an expected call of UpdateMany.
+func (mr *MockGuestRepositoryMockRecorder) UpdateMany(ctx, weddingID, ids, patch interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrphaned", reflect.TypeOf((*MockMediaRepository)(nil).GetOrphaned), ctx, before)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMany", reflect.TypeOf((*MockGuestRepository)(nil).UpdateMany), ctx, weddingID, ids, patch)
 }
 
-// List mocks base method.
-func (m *MockMediaRepository) List(ctx context.Context, filter repository.MediaFilter, opts repository.ListOptions) ([]*models.Media, int64, error) {
-	m.ctrl.T.Helper()
+// MockGuestGroupRepository is a mock of GuestGroupRepository interface.
+type MockGuestGroupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGuestGroupRepositoryMockRecorder
+}
+
+// MockGuestGroupRepositoryMockRecorder is the mock recorder for MockGuestGroupRepository.
+type MockGuestGroupRepositoryMockRecorder struct {
+	mock *MockGuestGroupRepository
+}
+
+// NewMockGuestGroupRepository creates a new mock instance.
+func NewMockGuestGroupRepository(ctrl *gomock.Controller) *MockGuestGroupRepository {
+	mock := &MockGuestGroupRepository{ctrl: ctrl}
+	mock.recorder = &MockGuestGroupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGuestGroupRepository) EXPECT() *MockGuestGroupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGuestGroupRepository) Create(ctx context.Context, group *models.GuestGroup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, group)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGuestGroupRepositoryMockRecorder) Create(ctx, group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGuestGroupRepository)(nil).Create), ctx, group)
+}
+
+// Delete mocks base method.
+func (m *MockGuestGroupRepository) Delete(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGuestGroupRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGuestGroupRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockGuestGroupRepository) GetByID(ctx context.Context, id models.ID) (*models.GuestGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.GuestGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockGuestGroupRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGuestGroupRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByWedding mocks base method.
+func (m *MockGuestGroupRepository) ListByWedding(ctx context.Context, weddingID models.ID) ([]*models.GuestGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByWedding", ctx, weddingID)
+	ret0, _ := ret[0].([]*models.GuestGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByWedding indicates an expected call of ListByWedding.
+func (mr *MockGuestGroupRepositoryMockRecorder) ListByWedding(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWedding", reflect.TypeOf((*MockGuestGroupRepository)(nil).ListByWedding), ctx, weddingID)
+}
+
+// Update mocks base method.
+func (m *MockGuestGroupRepository) Update(ctx context.Context, group *models.GuestGroup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, group)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGuestGroupRepositoryMockRecorder) Update(ctx, group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGuestGroupRepository)(nil).Update), ctx, group)
+}
+
+// MockSlugRedirectRepository is a mock of SlugRedirectRepository interface.
+type MockSlugRedirectRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSlugRedirectRepositoryMockRecorder
+}
+
+// MockSlugRedirectRepositoryMockRecorder is the mock recorder for MockSlugRedirectRepository.
+type MockSlugRedirectRepositoryMockRecorder struct {
+	mock *MockSlugRedirectRepository
+}
+
+// NewMockSlugRedirectRepository creates a new mock instance.
+func NewMockSlugRedirectRepository(ctrl *gomock.Controller) *MockSlugRedirectRepository {
+	mock := &MockSlugRedirectRepository{ctrl: ctrl}
+	mock.recorder = &MockSlugRedirectRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSlugRedirectRepository) EXPECT() *MockSlugRedirectRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSlugRedirectRepository) Delete(ctx context.Context, slug string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, slug)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSlugRedirectRepositoryMockRecorder) Delete(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSlugRedirectRepository)(nil).Delete), ctx, slug)
+}
+
+// DeleteByWedding mocks base method.
+func (m *MockSlugRedirectRepository) DeleteByWedding(ctx context.Context, weddingID models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByWedding", ctx, weddingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByWedding indicates an expected call of DeleteByWedding.
+func (mr *MockSlugRedirectRepositoryMockRecorder) DeleteByWedding(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByWedding", reflect.TypeOf((*MockSlugRedirectRepository)(nil).DeleteByWedding), ctx, weddingID)
+}
+
+// GetBySlug mocks base method.
+func (m *MockSlugRedirectRepository) GetBySlug(ctx context.Context, slug string) (*models.SlugRedirect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlug", ctx, slug)
+	ret0, _ := ret[0].(*models.SlugRedirect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockSlugRedirectRepositoryMockRecorder) GetBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockSlugRedirectRepository)(nil).GetBySlug), ctx, slug)
+}
+
+// Save mocks base method.
+func (m *MockSlugRedirectRepository) Save(ctx context.Context, redirect *models.SlugRedirect) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, redirect)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSlugRedirectRepositoryMockRecorder) Save(ctx, redirect interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSlugRedirectRepository)(nil).Save), ctx, redirect)
+}
+
+// MockWeddingRevisionRepository is a mock of WeddingRevisionRepository interface.
+type MockWeddingRevisionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWeddingRevisionRepositoryMockRecorder
+}
+
+// MockWeddingRevisionRepositoryMockRecorder is the mock recorder for MockWeddingRevisionRepository.
+type MockWeddingRevisionRepositoryMockRecorder struct {
+	mock *MockWeddingRevisionRepository
+}
+
+// NewMockWeddingRevisionRepository creates a new mock instance.
+func NewMockWeddingRevisionRepository(ctrl *gomock.Controller) *MockWeddingRevisionRepository {
+	mock := &MockWeddingRevisionRepository{ctrl: ctrl}
+	mock.recorder = &MockWeddingRevisionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWeddingRevisionRepository) EXPECT() *MockWeddingRevisionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWeddingRevisionRepository) Create(ctx context.Context, revision *models.WeddingRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWeddingRevisionRepositoryMockRecorder) Create(ctx, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWeddingRevisionRepository)(nil).Create), ctx, revision)
+}
+
+// DeleteBefore mocks base method.
+func (m *MockWeddingRevisionRepository) DeleteBefore(ctx context.Context, weddingID models.ID, number int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", ctx, weddingID, number)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockWeddingRevisionRepositoryMockRecorder) DeleteBefore(ctx, weddingID, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockWeddingRevisionRepository)(nil).DeleteBefore), ctx, weddingID, number)
+}
+
+// GetByNumber mocks base method.
+func (m *MockWeddingRevisionRepository) GetByNumber(ctx context.Context, weddingID models.ID, number int) (*models.WeddingRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByNumber", ctx, weddingID, number)
+	ret0, _ := ret[0].(*models.WeddingRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByNumber indicates an expected call of GetByNumber.
+func (mr *MockWeddingRevisionRepositoryMockRecorder) GetByNumber(ctx, weddingID, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByNumber", reflect.TypeOf((*MockWeddingRevisionRepository)(nil).GetByNumber), ctx, weddingID, number)
+}
+
+// ListByWedding mocks base method.
+func (m *MockWeddingRevisionRepository) ListByWedding(ctx context.Context, weddingID models.ID, limit int) ([]*models.WeddingRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByWedding", ctx, weddingID, limit)
+	ret0, _ := ret[0].([]*models.WeddingRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByWedding indicates an expected call of ListByWedding.
+func (mr *MockWeddingRevisionRepositoryMockRecorder) ListByWedding(ctx, weddingID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWedding", reflect.TypeOf((*MockWeddingRevisionRepository)(nil).ListByWedding), ctx, weddingID, limit)
+}
+
+// MockPreviewLinkRepository is a mock of PreviewLinkRepository interface.
+type MockPreviewLinkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreviewLinkRepositoryMockRecorder
+}
+
+// MockPreviewLinkRepositoryMockRecorder is the mock recorder for MockPreviewLinkRepository.
+type MockPreviewLinkRepositoryMockRecorder struct {
+	mock *MockPreviewLinkRepository
+}
+
+// NewMockPreviewLinkRepository creates a new mock instance.
+func NewMockPreviewLinkRepository(ctrl *gomock.Controller) *MockPreviewLinkRepository {
+	mock := &MockPreviewLinkRepository{ctrl: ctrl}
+	mock.recorder = &MockPreviewLinkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreviewLinkRepository) EXPECT() *MockPreviewLinkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPreviewLinkRepository) Create(ctx context.Context, link *models.PreviewLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPreviewLinkRepositoryMockRecorder) Create(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPreviewLinkRepository)(nil).Create), ctx, link)
+}
+
+// GetByID mocks base method.
+func (m *MockPreviewLinkRepository) GetByID(ctx context.Context, id models.ID) (*models.PreviewLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.PreviewLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPreviewLinkRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPreviewLinkRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByWedding mocks base method.
+func (m *MockPreviewLinkRepository) ListByWedding(ctx context.Context, weddingID models.ID) ([]*models.PreviewLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByWedding", ctx, weddingID)
+	ret0, _ := ret[0].([]*models.PreviewLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByWedding indicates an expected call of ListByWedding.
+func (mr *MockPreviewLinkRepositoryMockRecorder) ListByWedding(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWedding", reflect.TypeOf((*MockPreviewLinkRepository)(nil).ListByWedding), ctx, weddingID)
+}
+
+// RecordUse mocks base method.
+func (m *MockPreviewLinkRepository) RecordUse(ctx context.Context, id models.ID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordUse", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordUse indicates an expected call of RecordUse.
+func (mr *MockPreviewLinkRepositoryMockRecorder) RecordUse(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordUse", reflect.TypeOf((*MockPreviewLinkRepository)(nil).RecordUse), ctx, id, at)
+}
+
+// Update mocks base method.
+func (m *MockPreviewLinkRepository) Update(ctx context.Context, link *models.PreviewLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPreviewLinkRepositoryMockRecorder) Update(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPreviewLinkRepository)(nil).Update), ctx, link)
+}
+
+// MockMediaRepository is a mock of MediaRepository interface.
+type MockMediaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaRepositoryMockRecorder
+}
+
+// MockMediaRepositoryMockRecorder is the mock recorder for MockMediaRepository.
+type MockMediaRepositoryMockRecorder struct {
+	mock *MockMediaRepository
+}
+
+// NewMockMediaRepository creates a new mock instance.
+func NewMockMediaRepository(ctrl *gomock.Controller) *MockMediaRepository {
+	mock := &MockMediaRepository{ctrl: ctrl}
+	mock.recorder = &MockMediaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaRepository) EXPECT() *MockMediaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByStorageKey mocks base method.
+func (m *MockMediaRepository) CountByStorageKey(ctx context.Context, key string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByStorageKey", ctx, key)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByStorageKey indicates an expected call of CountByStorageKey.
+func (mr *MockMediaRepositoryMockRecorder) CountByStorageKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByStorageKey", reflect.TypeOf((*MockMediaRepository)(nil).CountByStorageKey), ctx, key)
+}
+
+// Create mocks base method.
+func (m *MockMediaRepository) Create(ctx context.Context, media *models.Media) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, media)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMediaRepositoryMockRecorder) Create(ctx, media interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMediaRepository)(nil).Create), ctx, media)
+}
+
+// Delete mocks base method.
+func (m *MockMediaRepository) Delete(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMediaRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMediaRepository)(nil).Delete), ctx, id)
+}
+
+// GetByContentHash mocks base method.
+func (m *MockMediaRepository) GetByContentHash(ctx context.Context, userID models.ID, hash string) (*models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByContentHash", ctx, userID, hash)
+	ret0, _ := ret[0].(*models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByContentHash indicates an expected call of GetByContentHash.
+func (mr *MockMediaRepositoryMockRecorder) GetByContentHash(ctx, userID, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByContentHash", reflect.TypeOf((*MockMediaRepository)(nil).GetByContentHash), ctx, userID, hash)
+}
+
+// GetByCreatedBy mocks base method.
+func (m *MockMediaRepository) GetByCreatedBy(ctx context.Context, userID models.ID, opts repository.ListOptions) ([]*models.Media, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCreatedBy", ctx, userID, opts)
+	ret0, _ := ret[0].([]*models.Media)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByCreatedBy indicates an expected call of GetByCreatedBy.
+func (mr *MockMediaRepositoryMockRecorder) GetByCreatedBy(ctx, userID, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCreatedBy", reflect.TypeOf((*MockMediaRepository)(nil).GetByCreatedBy), ctx, userID, opts)
+}
+
+// GetByID mocks base method.
+func (m *MockMediaRepository) GetByID(ctx context.Context, id models.ID) (*models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockMediaRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMediaRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByStorageKey mocks base method.
+func (m *MockMediaRepository) GetByStorageKey(ctx context.Context, key string) (*models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStorageKey", ctx, key)
+	ret0, _ := ret[0].(*models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStorageKey indicates an expected call of GetByStorageKey.
+func (mr *MockMediaRepositoryMockRecorder) GetByStorageKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStorageKey", reflect.TypeOf((*MockMediaRepository)(nil).GetByStorageKey), ctx, key)
+}
+
+// GetOrphaned mocks base method.
+func (m *MockMediaRepository) GetOrphaned(ctx context.Context, before time.Time) ([]*models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrphaned", ctx, before)
+	ret0, _ := ret[0].([]*models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrphaned indicates an expected call of GetOrphaned.
+func (mr *MockMediaRepositoryMockRecorder) GetOrphaned(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrphaned", reflect.TypeOf((*MockMediaRepository)(nil).GetOrphaned), ctx, before)
+}
+
+// List mocks base method.
+func (m *MockMediaRepository) List(ctx context.Context, filter repository.MediaFilter, opts repository.ListOptions) ([]*models.Media, int64, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List", ctx, filter, opts)
 	ret0, _ := ret[0].([]*models.Media)
 	ret1, _ := ret[1].(int64)
@@ -813,309 +1590,3638 @@ func (m *MockMediaRepository) List(ctx context.Context, filter repository.MediaF
 	return ret0, ret1, ret2
 }
 
-// List indicates an expected call of List.
-func (mr *MockMediaRepositoryMockRecorder) List(ctx, filter, opts interface{}) *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockMediaRepositoryMockRecorder) List(ctx, filter, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMediaRepository)(nil).List), ctx, filter, opts)
+}
+
+// ListAllByCreatedBy mocks base method.
+func (m *MockMediaRepository) ListAllByCreatedBy(ctx context.Context, userID models.ID) ([]*models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllByCreatedBy", ctx, userID)
+	ret0, _ := ret[0].([]*models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllByCreatedBy indicates an expected call of ListAllByCreatedBy.
+func (mr *MockMediaRepositoryMockRecorder) ListAllByCreatedBy(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllByCreatedBy", reflect.TypeOf((*MockMediaRepository)(nil).ListAllByCreatedBy), ctx, userID)
+}
+
+// SoftDelete mocks base method.
+func (m *MockMediaRepository) SoftDelete(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDelete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDelete indicates an expected call of SoftDelete.
+func (mr *MockMediaRepositoryMockRecorder) SoftDelete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockMediaRepository)(nil).SoftDelete), ctx, id)
+}
+
+// TotalSizeByCreatedBy mocks base method.
+func (m *MockMediaRepository) TotalSizeByCreatedBy(ctx context.Context, userID models.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TotalSizeByCreatedBy", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TotalSizeByCreatedBy indicates an expected call of TotalSizeByCreatedBy.
+func (mr *MockMediaRepositoryMockRecorder) TotalSizeByCreatedBy(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalSizeByCreatedBy", reflect.TypeOf((*MockMediaRepository)(nil).TotalSizeByCreatedBy), ctx, userID)
+}
+
+// Update mocks base method.
+func (m *MockMediaRepository) Update(ctx context.Context, media *models.Media) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, media)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMediaRepositoryMockRecorder) Update(ctx, media interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMediaRepository)(nil).Update), ctx, media)
+}
+
+// MockAnalyticsRepository is a mock of AnalyticsRepository interface.
+type MockAnalyticsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsRepositoryMockRecorder
+}
+
+// MockAnalyticsRepositoryMockRecorder is the mock recorder for MockAnalyticsRepository.
+type MockAnalyticsRepositoryMockRecorder struct {
+	mock *MockAnalyticsRepository
+}
+
+// NewMockAnalyticsRepository creates a new mock instance.
+func NewMockAnalyticsRepository(ctrl *gomock.Controller) *MockAnalyticsRepository {
+	mock := &MockAnalyticsRepository{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsRepository) EXPECT() *MockAnalyticsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CleanupOldAnalytics mocks base method.
+func (m *MockAnalyticsRepository) CleanupOldAnalytics(ctx context.Context, olderThan time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupOldAnalytics", ctx, olderThan)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanupOldAnalytics indicates an expected call of CleanupOldAnalytics.
+func (mr *MockAnalyticsRepositoryMockRecorder) CleanupOldAnalytics(ctx, olderThan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupOldAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).CleanupOldAnalytics), ctx, olderThan)
+}
+
+// CountHourlyActivity mocks base method.
+func (m *MockAnalyticsRepository) CountHourlyActivity(ctx context.Context, hours []time.Time) ([]models.HourlyActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountHourlyActivity", ctx, hours)
+	ret0, _ := ret[0].([]models.HourlyActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountHourlyActivity indicates an expected call of CountHourlyActivity.
+func (mr *MockAnalyticsRepositoryMockRecorder) CountHourlyActivity(ctx, hours interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountHourlyActivity", reflect.TypeOf((*MockAnalyticsRepository)(nil).CountHourlyActivity), ctx, hours)
+}
+
+// GetAnalyticsBenchmarks mocks base method.
+func (m *MockAnalyticsRepository) GetAnalyticsBenchmarks(ctx context.Context) (*models.AnalyticsBenchmarks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnalyticsBenchmarks", ctx)
+	ret0, _ := ret[0].(*models.AnalyticsBenchmarks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnalyticsBenchmarks indicates an expected call of GetAnalyticsBenchmarks.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetAnalyticsBenchmarks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnalyticsBenchmarks", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetAnalyticsBenchmarks), ctx)
+}
+
+// GetAnalyticsSummary mocks base method.
+func (m *MockAnalyticsRepository) GetAnalyticsSummary(ctx context.Context, weddingID models.ID, period string) (*models.AnalyticsSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnalyticsSummary", ctx, weddingID, period)
+	ret0, _ := ret[0].(*models.AnalyticsSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnalyticsSummary indicates an expected call of GetAnalyticsSummary.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetAnalyticsSummary(ctx, weddingID, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnalyticsSummary", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetAnalyticsSummary), ctx, weddingID, period)
+}
+
+// GetConversions mocks base method.
+func (m *MockAnalyticsRepository) GetConversions(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversions", ctx, weddingID, filter)
+	ret0, _ := ret[0].([]*models.ConversionEvent)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetConversions indicates an expected call of GetConversions.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetConversions(ctx, weddingID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversions", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetConversions), ctx, weddingID, filter)
+}
+
+// GetConversionsByRequestID mocks base method.
+func (m *MockAnalyticsRepository) GetConversionsByRequestID(ctx context.Context, requestID string) ([]*models.ConversionEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversionsByRequestID", ctx, requestID)
+	ret0, _ := ret[0].([]*models.ConversionEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversionsByRequestID indicates an expected call of GetConversionsByRequestID.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetConversionsByRequestID(ctx, requestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversionsByRequestID", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetConversionsByRequestID), ctx, requestID)
+}
+
+// GetDailyMetrics mocks base method.
+func (m *MockAnalyticsRepository) GetDailyMetrics(ctx context.Context, weddingID models.ID, startDate, endDate time.Time) ([]models.DailyMetrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyMetrics", ctx, weddingID, startDate, endDate)
+	ret0, _ := ret[0].([]models.DailyMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyMetrics indicates an expected call of GetDailyMetrics.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetDailyMetrics(ctx, weddingID, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyMetrics", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetDailyMetrics), ctx, weddingID, startDate, endDate)
+}
+
+// GetGeoBreakdown mocks base method.
+func (m *MockAnalyticsRepository) GetGeoBreakdown(ctx context.Context, weddingID models.ID, limit int) ([]models.CountryStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeoBreakdown", ctx, weddingID, limit)
+	ret0, _ := ret[0].([]models.CountryStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeoBreakdown indicates an expected call of GetGeoBreakdown.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetGeoBreakdown(ctx, weddingID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeoBreakdown", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetGeoBreakdown), ctx, weddingID, limit)
+}
+
+// GetPageViews mocks base method.
+func (m *MockAnalyticsRepository) GetPageViews(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPageViews", ctx, weddingID, filter)
+	ret0, _ := ret[0].([]*models.PageView)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPageViews indicates an expected call of GetPageViews.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetPageViews(ctx, weddingID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPageViews", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetPageViews), ctx, weddingID, filter)
+}
+
+// GetPageViewsByRequestID mocks base method.
+func (m *MockAnalyticsRepository) GetPageViewsByRequestID(ctx context.Context, requestID string) ([]*models.PageView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPageViewsByRequestID", ctx, requestID)
+	ret0, _ := ret[0].([]*models.PageView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPageViewsByRequestID indicates an expected call of GetPageViewsByRequestID.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetPageViewsByRequestID(ctx, requestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPageViewsByRequestID", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetPageViewsByRequestID), ctx, requestID)
+}
+
+// GetPopularPages mocks base method.
+func (m *MockAnalyticsRepository) GetPopularPages(ctx context.Context, weddingID models.ID, limit int) ([]models.PageStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPopularPages", ctx, weddingID, limit)
+	ret0, _ := ret[0].([]models.PageStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPopularPages indicates an expected call of GetPopularPages.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetPopularPages(ctx, weddingID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopularPages", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetPopularPages), ctx, weddingID, limit)
+}
+
+// GetRSVPAnalytics mocks base method.
+func (m *MockAnalyticsRepository) GetRSVPAnalytics(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRSVPAnalytics", ctx, weddingID, filter)
+	ret0, _ := ret[0].([]*models.RSVPAnalytics)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRSVPAnalytics indicates an expected call of GetRSVPAnalytics.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetRSVPAnalytics(ctx, weddingID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRSVPAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetRSVPAnalytics), ctx, weddingID, filter)
+}
+
+// GetRSVPFunnelSessions mocks base method.
+func (m *MockAnalyticsRepository) GetRSVPFunnelSessions(ctx context.Context, weddingID models.ID, from, to time.Time) ([]models.RSVPFunnelSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRSVPFunnelSessions", ctx, weddingID, from, to)
+	ret0, _ := ret[0].([]models.RSVPFunnelSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRSVPFunnelSessions indicates an expected call of GetRSVPFunnelSessions.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetRSVPFunnelSessions(ctx, weddingID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRSVPFunnelSessions", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetRSVPFunnelSessions), ctx, weddingID, from, to)
+}
+
+// GetSystemAnalytics mocks base method.
+func (m *MockAnalyticsRepository) GetSystemAnalytics(ctx context.Context) (*models.SystemAnalytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSystemAnalytics", ctx)
+	ret0, _ := ret[0].(*models.SystemAnalytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSystemAnalytics indicates an expected call of GetSystemAnalytics.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetSystemAnalytics(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetSystemAnalytics), ctx)
+}
+
+// GetTrafficSources mocks base method.
+func (m *MockAnalyticsRepository) GetTrafficSources(ctx context.Context, weddingID models.ID, limit int) ([]models.TrafficSourceStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrafficSources", ctx, weddingID, limit)
+	ret0, _ := ret[0].([]models.TrafficSourceStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrafficSources indicates an expected call of GetTrafficSources.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetTrafficSources(ctx, weddingID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrafficSources", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetTrafficSources), ctx, weddingID, limit)
+}
+
+// GetWeddingAnalytics mocks base method.
+func (m *MockAnalyticsRepository) GetWeddingAnalytics(ctx context.Context, weddingID models.ID) (*models.WeddingAnalytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWeddingAnalytics", ctx, weddingID)
+	ret0, _ := ret[0].(*models.WeddingAnalytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWeddingAnalytics indicates an expected call of GetWeddingAnalytics.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetWeddingAnalytics(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeddingAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetWeddingAnalytics), ctx, weddingID)
+}
+
+// ListWeddingAnalytics mocks base method.
+func (m *MockAnalyticsRepository) ListWeddingAnalytics(ctx context.Context, weddingIDs []models.ID) ([]*models.WeddingAnalytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWeddingAnalytics", ctx, weddingIDs)
+	ret0, _ := ret[0].([]*models.WeddingAnalytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWeddingAnalytics indicates an expected call of ListWeddingAnalytics.
+func (mr *MockAnalyticsRepositoryMockRecorder) ListWeddingAnalytics(ctx, weddingIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWeddingAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).ListWeddingAnalytics), ctx, weddingIDs)
+}
+
+// ListWeddingsToReconcile mocks base method.
+func (m *MockAnalyticsRepository) ListWeddingsToReconcile(ctx context.Context, after models.ID, limit int) ([]models.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWeddingsToReconcile", ctx, after, limit)
+	ret0, _ := ret[0].([]models.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWeddingsToReconcile indicates an expected call of ListWeddingsToReconcile.
+func (mr *MockAnalyticsRepositoryMockRecorder) ListWeddingsToReconcile(ctx, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWeddingsToReconcile", reflect.TypeOf((*MockAnalyticsRepository)(nil).ListWeddingsToReconcile), ctx, after, limit)
+}
+
+// RefreshSystemAnalytics mocks base method.
+func (m *MockAnalyticsRepository) RefreshSystemAnalytics(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSystemAnalytics", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshSystemAnalytics indicates an expected call of RefreshSystemAnalytics.
+func (mr *MockAnalyticsRepositoryMockRecorder) RefreshSystemAnalytics(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSystemAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).RefreshSystemAnalytics), ctx)
+}
+
+// RefreshWeddingAnalytics mocks base method.
+func (m *MockAnalyticsRepository) RefreshWeddingAnalytics(ctx context.Context, weddingID models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshWeddingAnalytics", ctx, weddingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshWeddingAnalytics indicates an expected call of RefreshWeddingAnalytics.
+func (mr *MockAnalyticsRepositoryMockRecorder) RefreshWeddingAnalytics(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshWeddingAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).RefreshWeddingAnalytics), ctx, weddingID)
+}
+
+// StreamConversions mocks base method.
+func (m *MockAnalyticsRepository) StreamConversions(ctx context.Context, weddingID models.ID, from, to time.Time, fn func(*models.ConversionEvent) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamConversions", ctx, weddingID, from, to, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamConversions indicates an expected call of StreamConversions.
+func (mr *MockAnalyticsRepositoryMockRecorder) StreamConversions(ctx, weddingID, from, to, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamConversions", reflect.TypeOf((*MockAnalyticsRepository)(nil).StreamConversions), ctx, weddingID, from, to, fn)
+}
+
+// StreamPageViews mocks base method.
+func (m *MockAnalyticsRepository) StreamPageViews(ctx context.Context, weddingID models.ID, from, to time.Time, fn func(*models.PageView) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamPageViews", ctx, weddingID, from, to, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamPageViews indicates an expected call of StreamPageViews.
+func (mr *MockAnalyticsRepositoryMockRecorder) StreamPageViews(ctx, weddingID, from, to, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamPageViews", reflect.TypeOf((*MockAnalyticsRepository)(nil).StreamPageViews), ctx, weddingID, from, to, fn)
+}
+
+// StreamRSVPEvents mocks base method.
+func (m *MockAnalyticsRepository) StreamRSVPEvents(ctx context.Context, weddingID models.ID, from, to time.Time, fn func(*models.RSVPAnalytics) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamRSVPEvents", ctx, weddingID, from, to, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamRSVPEvents indicates an expected call of StreamRSVPEvents.
+func (mr *MockAnalyticsRepositoryMockRecorder) StreamRSVPEvents(ctx, weddingID, from, to, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamRSVPEvents", reflect.TypeOf((*MockAnalyticsRepository)(nil).StreamRSVPEvents), ctx, weddingID, from, to, fn)
+}
+
+// TrackConversion mocks base method.
+func (m *MockAnalyticsRepository) TrackConversion(ctx context.Context, event *models.ConversionEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackConversion", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackConversion indicates an expected call of TrackConversion.
+func (mr *MockAnalyticsRepositoryMockRecorder) TrackConversion(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackConversion", reflect.TypeOf((*MockAnalyticsRepository)(nil).TrackConversion), ctx, event)
+}
+
+// TrackConversions mocks base method.
+func (m *MockAnalyticsRepository) TrackConversions(ctx context.Context, events []*models.ConversionEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackConversions", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackConversions indicates an expected call of TrackConversions.
+func (mr *MockAnalyticsRepositoryMockRecorder) TrackConversions(ctx, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackConversions", reflect.TypeOf((*MockAnalyticsRepository)(nil).TrackConversions), ctx, events)
+}
+
+// TrackPageDuration mocks base method.
+func (m *MockAnalyticsRepository) TrackPageDuration(ctx context.Context, weddingID models.ID, sessionID, page string, duration int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackPageDuration", ctx, weddingID, sessionID, page, duration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackPageDuration indicates an expected call of TrackPageDuration.
+func (mr *MockAnalyticsRepositoryMockRecorder) TrackPageDuration(ctx, weddingID, sessionID, page, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackPageDuration", reflect.TypeOf((*MockAnalyticsRepository)(nil).TrackPageDuration), ctx, weddingID, sessionID, page, duration)
+}
+
+// TrackPageView mocks base method.
+func (m *MockAnalyticsRepository) TrackPageView(ctx context.Context, pageView *models.PageView) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackPageView", ctx, pageView)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackPageView indicates an expected call of TrackPageView.
+func (mr *MockAnalyticsRepositoryMockRecorder) TrackPageView(ctx, pageView interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackPageView", reflect.TypeOf((*MockAnalyticsRepository)(nil).TrackPageView), ctx, pageView)
+}
+
+// TrackPageViews mocks base method.
+func (m *MockAnalyticsRepository) TrackPageViews(ctx context.Context, pageViews []*models.PageView) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackPageViews", ctx, pageViews)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackPageViews indicates an expected call of TrackPageViews.
+func (mr *MockAnalyticsRepositoryMockRecorder) TrackPageViews(ctx, pageViews interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackPageViews", reflect.TypeOf((*MockAnalyticsRepository)(nil).TrackPageViews), ctx, pageViews)
+}
+
+// TrackRSVPEvent mocks base method.
+func (m *MockAnalyticsRepository) TrackRSVPEvent(ctx context.Context, event *models.RSVPAnalytics) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackRSVPEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackRSVPEvent indicates an expected call of TrackRSVPEvent.
+func (mr *MockAnalyticsRepositoryMockRecorder) TrackRSVPEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackRSVPEvent", reflect.TypeOf((*MockAnalyticsRepository)(nil).TrackRSVPEvent), ctx, event)
+}
+
+// UpdateAnalyticsBenchmarks mocks base method.
+func (m *MockAnalyticsRepository) UpdateAnalyticsBenchmarks(ctx context.Context, minPageViews int64) (*models.AnalyticsBenchmarks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnalyticsBenchmarks", ctx, minPageViews)
+	ret0, _ := ret[0].(*models.AnalyticsBenchmarks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAnalyticsBenchmarks indicates an expected call of UpdateAnalyticsBenchmarks.
+func (mr *MockAnalyticsRepositoryMockRecorder) UpdateAnalyticsBenchmarks(ctx, minPageViews interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnalyticsBenchmarks", reflect.TypeOf((*MockAnalyticsRepository)(nil).UpdateAnalyticsBenchmarks), ctx, minPageViews)
+}
+
+// UpdateSystemAnalytics mocks base method.
+func (m *MockAnalyticsRepository) UpdateSystemAnalytics(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSystemAnalytics", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSystemAnalytics indicates an expected call of UpdateSystemAnalytics.
+func (mr *MockAnalyticsRepositoryMockRecorder) UpdateSystemAnalytics(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSystemAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).UpdateSystemAnalytics), ctx)
+}
+
+// UpdateWeddingAnalytics mocks base method.
+func (m *MockAnalyticsRepository) UpdateWeddingAnalytics(ctx context.Context, weddingID models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWeddingAnalytics", ctx, weddingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWeddingAnalytics indicates an expected call of UpdateWeddingAnalytics.
+func (mr *MockAnalyticsRepositoryMockRecorder) UpdateWeddingAnalytics(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWeddingAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).UpdateWeddingAnalytics), ctx, weddingID)
+}
+
+// MockSystemRepository is a mock of SystemRepository interface.
+type MockSystemRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSystemRepositoryMockRecorder
+}
+
+// MockSystemRepositoryMockRecorder is the mock recorder for MockSystemRepository.
+type MockSystemRepositoryMockRecorder struct {
+	mock *MockSystemRepository
+}
+
+// NewMockSystemRepository creates a new mock instance.
+func NewMockSystemRepository(ctrl *gomock.Controller) *MockSystemRepository {
+	mock := &MockSystemRepository{ctrl: ctrl}
+	mock.recorder = &MockSystemRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSystemRepository) EXPECT() *MockSystemRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetConfig mocks base method.
+func (m *MockSystemRepository) GetConfig(ctx context.Context) (*models.SystemConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfig", ctx)
+	ret0, _ := ret[0].(*models.SystemConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfig indicates an expected call of GetConfig.
+func (mr *MockSystemRepositoryMockRecorder) GetConfig(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfig", reflect.TypeOf((*MockSystemRepository)(nil).GetConfig), ctx)
+}
+
+// SaveConfig mocks base method.
+func (m *MockSystemRepository) SaveConfig(ctx context.Context, config *models.SystemConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveConfig", ctx, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveConfig indicates an expected call of SaveConfig.
+func (mr *MockSystemRepositoryMockRecorder) SaveConfig(ctx, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveConfig", reflect.TypeOf((*MockSystemRepository)(nil).SaveConfig), ctx, config)
+}
+
+// MockThemeRepository is a mock of ThemeRepository interface.
+type MockThemeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockThemeRepositoryMockRecorder
+}
+
+// MockThemeRepositoryMockRecorder is the mock recorder for MockThemeRepository.
+type MockThemeRepositoryMockRecorder struct {
+	mock *MockThemeRepository
+}
+
+// NewMockThemeRepository creates a new mock instance.
+func NewMockThemeRepository(ctrl *gomock.Controller) *MockThemeRepository {
+	mock := &MockThemeRepository{ctrl: ctrl}
+	mock.recorder = &MockThemeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockThemeRepository) EXPECT() *MockThemeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockThemeRepository) Create(ctx context.Context, theme *models.Theme) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, theme)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockThemeRepositoryMockRecorder) Create(ctx, theme interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockThemeRepository)(nil).Create), ctx, theme)
+}
+
+// Delete mocks base method.
+func (m *MockThemeRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockThemeRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockThemeRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockThemeRepository) GetByID(ctx context.Context, id string) (*models.Theme, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Theme)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockThemeRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockThemeRepository)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockThemeRepository) List(ctx context.Context, activeOnly bool) ([]*models.Theme, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, activeOnly)
+	ret0, _ := ret[0].([]*models.Theme)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockThemeRepositoryMockRecorder) List(ctx, activeOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockThemeRepository)(nil).List), ctx, activeOnly)
+}
+
+// Update mocks base method.
+func (m *MockThemeRepository) Update(ctx context.Context, theme *models.Theme) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, theme)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockThemeRepositoryMockRecorder) Update(ctx, theme interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockThemeRepository)(nil).Update), ctx, theme)
+}
+
+// Upsert mocks base method.
+func (m *MockThemeRepository) Upsert(ctx context.Context, theme *models.Theme) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, theme)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockThemeRepositoryMockRecorder) Upsert(ctx, theme interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockThemeRepository)(nil).Upsert), ctx, theme)
+}
+
+// MockRSVPSubmissionRepository is a mock of RSVPSubmissionRepository interface.
+type MockRSVPSubmissionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRSVPSubmissionRepositoryMockRecorder
+}
+
+// MockRSVPSubmissionRepositoryMockRecorder is the mock recorder for MockRSVPSubmissionRepository.
+type MockRSVPSubmissionRepositoryMockRecorder struct {
+	mock *MockRSVPSubmissionRepository
+}
+
+// NewMockRSVPSubmissionRepository creates a new mock instance.
+func NewMockRSVPSubmissionRepository(ctrl *gomock.Controller) *MockRSVPSubmissionRepository {
+	mock := &MockRSVPSubmissionRepository{ctrl: ctrl}
+	mock.recorder = &MockRSVPSubmissionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRSVPSubmissionRepository) EXPECT() *MockRSVPSubmissionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimNext mocks base method.
+func (m *MockRSVPSubmissionRepository) ClaimNext(ctx context.Context, partition int, now time.Time, lease time.Duration) (*models.RSVPSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNext", ctx, partition, now, lease)
+	ret0, _ := ret[0].(*models.RSVPSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNext indicates an expected call of ClaimNext.
+func (mr *MockRSVPSubmissionRepositoryMockRecorder) ClaimNext(ctx, partition, now, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNext", reflect.TypeOf((*MockRSVPSubmissionRepository)(nil).ClaimNext), ctx, partition, now, lease)
+}
+
+// Enqueue mocks base method.
+func (m *MockRSVPSubmissionRepository) Enqueue(ctx context.Context, submission *models.RSVPSubmission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, submission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockRSVPSubmissionRepositoryMockRecorder) Enqueue(ctx, submission interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockRSVPSubmissionRepository)(nil).Enqueue), ctx, submission)
+}
+
+// GetByID mocks base method.
+func (m *MockRSVPSubmissionRepository) GetByID(ctx context.Context, id models.ID) (*models.RSVPSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.RSVPSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRSVPSubmissionRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRSVPSubmissionRepository)(nil).GetByID), ctx, id)
+}
+
+// MarkCompleted mocks base method.
+func (m *MockRSVPSubmissionRepository) MarkCompleted(ctx context.Context, id, rsvpID models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkCompleted", ctx, id, rsvpID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkCompleted indicates an expected call of MarkCompleted.
+func (mr *MockRSVPSubmissionRepositoryMockRecorder) MarkCompleted(ctx, id, rsvpID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCompleted", reflect.TypeOf((*MockRSVPSubmissionRepository)(nil).MarkCompleted), ctx, id, rsvpID)
+}
+
+// MarkFailed mocks base method.
+func (m *MockRSVPSubmissionRepository) MarkFailed(ctx context.Context, id models.ID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockRSVPSubmissionRepositoryMockRecorder) MarkFailed(ctx, id, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockRSVPSubmissionRepository)(nil).MarkFailed), ctx, id, reason)
+}
+
+// Release mocks base method.
+func (m *MockRSVPSubmissionRepository) Release(ctx context.Context, id models.ID, retryAt time.Time, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, id, retryAt, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockRSVPSubmissionRepositoryMockRecorder) Release(ctx, id, retryAt, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockRSVPSubmissionRepository)(nil).Release), ctx, id, retryAt, reason)
+}
+
+// MockJobRepository is a mock of JobRepository interface.
+type MockJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRepositoryMockRecorder
+}
+
+// MockJobRepositoryMockRecorder is the mock recorder for MockJobRepository.
+type MockJobRepositoryMockRecorder struct {
+	mock *MockJobRepository
+}
+
+// NewMockJobRepository creates a new mock instance.
+func NewMockJobRepository(ctrl *gomock.Controller) *MockJobRepository {
+	mock := &MockJobRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimNext mocks base method.
+func (m *MockJobRepository) ClaimNext(ctx context.Context, types []string, now time.Time, lease time.Duration) (*models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNext", ctx, types, now, lease)
+	ret0, _ := ret[0].(*models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNext indicates an expected call of ClaimNext.
+func (mr *MockJobRepositoryMockRecorder) ClaimNext(ctx, types, now, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNext", reflect.TypeOf((*MockJobRepository)(nil).ClaimNext), ctx, types, now, lease)
+}
+
+// Enqueue mocks base method.
+func (m *MockJobRepository) Enqueue(ctx context.Context, job *models.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockJobRepositoryMockRecorder) Enqueue(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockJobRepository)(nil).Enqueue), ctx, job)
+}
+
+// GetByID mocks base method.
+func (m *MockJobRepository) GetByID(ctx context.Context, id models.ID) (*models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockJobRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockJobRepository)(nil).GetByID), ctx, id)
+}
+
+// MarkCompleted mocks base method.
+func (m *MockJobRepository) MarkCompleted(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkCompleted", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkCompleted indicates an expected call of MarkCompleted.
+func (mr *MockJobRepositoryMockRecorder) MarkCompleted(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCompleted", reflect.TypeOf((*MockJobRepository)(nil).MarkCompleted), ctx, id)
+}
+
+// MarkFailed mocks base method.
+func (m *MockJobRepository) MarkFailed(ctx context.Context, id models.ID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockJobRepositoryMockRecorder) MarkFailed(ctx, id, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockJobRepository)(nil).MarkFailed), ctx, id, reason)
+}
+
+// Retry mocks base method.
+func (m *MockJobRepository) Retry(ctx context.Context, id models.ID, runAt time.Time, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, id, runAt, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockJobRepositoryMockRecorder) Retry(ctx, id, runAt, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockJobRepository)(nil).Retry), ctx, id, runAt, reason)
+}
+
+// MockExportJobRepository is a mock of ExportJobRepository interface.
+type MockExportJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportJobRepositoryMockRecorder
+}
+
+// MockExportJobRepositoryMockRecorder is the mock recorder for MockExportJobRepository.
+type MockExportJobRepositoryMockRecorder struct {
+	mock *MockExportJobRepository
+}
+
+// NewMockExportJobRepository creates a new mock instance.
+func NewMockExportJobRepository(ctrl *gomock.Controller) *MockExportJobRepository {
+	mock := &MockExportJobRepository{ctrl: ctrl}
+	mock.recorder = &MockExportJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportJobRepository) EXPECT() *MockExportJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockExportJobRepository) Create(ctx context.Context, job *models.ExportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockExportJobRepositoryMockRecorder) Create(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockExportJobRepository)(nil).Create), ctx, job)
+}
+
+// Finish mocks base method.
+func (m *MockExportJobRepository) Finish(ctx context.Context, id models.ID, status models.ExportJobStatus, records int, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, id, status, records, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockExportJobRepositoryMockRecorder) Finish(ctx, id, status, records, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockExportJobRepository)(nil).Finish), ctx, id, status, records, reason)
+}
+
+// GetByID mocks base method.
+func (m *MockExportJobRepository) GetByID(ctx context.Context, id models.ID) (*models.ExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockExportJobRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockExportJobRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByWedding mocks base method.
+func (m *MockExportJobRepository) ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int) ([]*models.ExportJob, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByWedding", ctx, weddingID, page, pageSize)
+	ret0, _ := ret[0].([]*models.ExportJob)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByWedding indicates an expected call of ListByWedding.
+func (mr *MockExportJobRepositoryMockRecorder) ListByWedding(ctx, weddingID, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWedding", reflect.TypeOf((*MockExportJobRepository)(nil).ListByWedding), ctx, weddingID, page, pageSize)
+}
+
+// SetFile mocks base method.
+func (m *MockExportJobRepository) SetFile(ctx context.Context, id models.ID, fileKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFile", ctx, id, fileKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFile indicates an expected call of SetFile.
+func (mr *MockExportJobRepositoryMockRecorder) SetFile(ctx, id, fileKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFile", reflect.TypeOf((*MockExportJobRepository)(nil).SetFile), ctx, id, fileKey)
+}
+
+// MockMetricsWebhookRepository is a mock of MetricsWebhookRepository interface.
+type MockMetricsWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsWebhookRepositoryMockRecorder
+}
+
+// MockMetricsWebhookRepositoryMockRecorder is the mock recorder for MockMetricsWebhookRepository.
+type MockMetricsWebhookRepositoryMockRecorder struct {
+	mock *MockMetricsWebhookRepository
+}
+
+// NewMockMetricsWebhookRepository creates a new mock instance.
+func NewMockMetricsWebhookRepository(ctrl *gomock.Controller) *MockMetricsWebhookRepository {
+	mock := &MockMetricsWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockMetricsWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricsWebhookRepository) EXPECT() *MockMetricsWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMetricsWebhookRepository) Create(ctx context.Context, webhook *models.MetricsWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMetricsWebhookRepositoryMockRecorder) Create(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMetricsWebhookRepository)(nil).Create), ctx, webhook)
+}
+
+// CreateDelivery mocks base method.
+func (m *MockMetricsWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.MetricsWebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDelivery indicates an expected call of CreateDelivery.
+func (mr *MockMetricsWebhookRepositoryMockRecorder) CreateDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelivery", reflect.TypeOf((*MockMetricsWebhookRepository)(nil).CreateDelivery), ctx, delivery)
+}
+
+// Delete mocks base method.
+func (m *MockMetricsWebhookRepository) Delete(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMetricsWebhookRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMetricsWebhookRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockMetricsWebhookRepository) GetByID(ctx context.Context, id models.ID) (*models.MetricsWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.MetricsWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockMetricsWebhookRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMetricsWebhookRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByUser mocks base method.
+func (m *MockMetricsWebhookRepository) ListByUser(ctx context.Context, userID models.ID) ([]*models.MetricsWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, userID)
+	ret0, _ := ret[0].([]*models.MetricsWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockMetricsWebhookRepositoryMockRecorder) ListByUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockMetricsWebhookRepository)(nil).ListByUser), ctx, userID)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockMetricsWebhookRepository) ListDeliveries(ctx context.Context, webhookID models.ID, page, pageSize int) ([]*models.MetricsWebhookDelivery, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, webhookID, page, pageSize)
+	ret0, _ := ret[0].([]*models.MetricsWebhookDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockMetricsWebhookRepositoryMockRecorder) ListDeliveries(ctx, webhookID, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockMetricsWebhookRepository)(nil).ListDeliveries), ctx, webhookID, page, pageSize)
+}
+
+// ListDue mocks base method.
+func (m *MockMetricsWebhookRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.MetricsWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDue", ctx, now, limit)
+	ret0, _ := ret[0].([]*models.MetricsWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDue indicates an expected call of ListDue.
+func (mr *MockMetricsWebhookRepositoryMockRecorder) ListDue(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDue", reflect.TypeOf((*MockMetricsWebhookRepository)(nil).ListDue), ctx, now, limit)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockMetricsWebhookRepository) MarkDelivered(ctx context.Context, id models.ID, deliveredAt, nextDeliveryAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, id, deliveredAt, nextDeliveryAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockMetricsWebhookRepositoryMockRecorder) MarkDelivered(ctx, id, deliveredAt, nextDeliveryAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockMetricsWebhookRepository)(nil).MarkDelivered), ctx, id, deliveredAt, nextDeliveryAt)
+}
+
+// Update mocks base method.
+func (m *MockMetricsWebhookRepository) Update(ctx context.Context, webhook *models.MetricsWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMetricsWebhookRepositoryMockRecorder) Update(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMetricsWebhookRepository)(nil).Update), ctx, webhook)
+}
+
+// MockTenantWebhookRepository is a mock of TenantWebhookRepository interface.
+type MockTenantWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantWebhookRepositoryMockRecorder
+}
+
+// MockTenantWebhookRepositoryMockRecorder is the mock recorder for MockTenantWebhookRepository.
+type MockTenantWebhookRepositoryMockRecorder struct {
+	mock *MockTenantWebhookRepository
+}
+
+// NewMockTenantWebhookRepository creates a new mock instance.
+func NewMockTenantWebhookRepository(ctrl *gomock.Controller) *MockTenantWebhookRepository {
+	mock := &MockTenantWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockTenantWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantWebhookRepository) EXPECT() *MockTenantWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AppendEvent mocks base method.
+func (m *MockTenantWebhookRepository) AppendEvent(ctx context.Context, event *models.LifecycleEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendEvent indicates an expected call of AppendEvent.
+func (mr *MockTenantWebhookRepositoryMockRecorder) AppendEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendEvent", reflect.TypeOf((*MockTenantWebhookRepository)(nil).AppendEvent), ctx, event)
+}
+
+// CreateDelivery mocks base method.
+func (m *MockTenantWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.TenantWebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDelivery indicates an expected call of CreateDelivery.
+func (mr *MockTenantWebhookRepositoryMockRecorder) CreateDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelivery", reflect.TypeOf((*MockTenantWebhookRepository)(nil).CreateDelivery), ctx, delivery)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockTenantWebhookRepository) CreateWebhook(ctx context.Context, webhook *models.TenantWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockTenantWebhookRepositoryMockRecorder) CreateWebhook(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockTenantWebhookRepository)(nil).CreateWebhook), ctx, webhook)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockTenantWebhookRepository) DeleteWebhook(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockTenantWebhookRepositoryMockRecorder) DeleteWebhook(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockTenantWebhookRepository)(nil).DeleteWebhook), ctx, id)
+}
+
+// GetSigningKey mocks base method.
+func (m *MockTenantWebhookRepository) GetSigningKey(ctx context.Context, tenantID string) (*models.TenantSigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSigningKey", ctx, tenantID)
+	ret0, _ := ret[0].(*models.TenantSigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSigningKey indicates an expected call of GetSigningKey.
+func (mr *MockTenantWebhookRepositoryMockRecorder) GetSigningKey(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSigningKey", reflect.TypeOf((*MockTenantWebhookRepository)(nil).GetSigningKey), ctx, tenantID)
+}
+
+// GetWebhook mocks base method.
+func (m *MockTenantWebhookRepository) GetWebhook(ctx context.Context, id models.ID) (*models.TenantWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", ctx, id)
+	ret0, _ := ret[0].(*models.TenantWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockTenantWebhookRepositoryMockRecorder) GetWebhook(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockTenantWebhookRepository)(nil).GetWebhook), ctx, id)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockTenantWebhookRepository) ListDeliveries(ctx context.Context, webhookID models.ID, page, pageSize int) ([]*models.TenantWebhookDelivery, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, webhookID, page, pageSize)
+	ret0, _ := ret[0].([]*models.TenantWebhookDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockTenantWebhookRepositoryMockRecorder) ListDeliveries(ctx, webhookID, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockTenantWebhookRepository)(nil).ListDeliveries), ctx, webhookID, page, pageSize)
+}
+
+// ListEventsSince mocks base method.
+func (m *MockTenantWebhookRepository) ListEventsSince(ctx context.Context, tenantID string, since time.Time, limit int) ([]*models.LifecycleEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventsSince", ctx, tenantID, since, limit)
+	ret0, _ := ret[0].([]*models.LifecycleEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventsSince indicates an expected call of ListEventsSince.
+func (mr *MockTenantWebhookRepositoryMockRecorder) ListEventsSince(ctx, tenantID, since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventsSince", reflect.TypeOf((*MockTenantWebhookRepository)(nil).ListEventsSince), ctx, tenantID, since, limit)
+}
+
+// ListPendingEvents mocks base method.
+func (m *MockTenantWebhookRepository) ListPendingEvents(ctx context.Context, limit int) ([]*models.LifecycleEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingEvents", ctx, limit)
+	ret0, _ := ret[0].([]*models.LifecycleEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingEvents indicates an expected call of ListPendingEvents.
+func (mr *MockTenantWebhookRepositoryMockRecorder) ListPendingEvents(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingEvents", reflect.TypeOf((*MockTenantWebhookRepository)(nil).ListPendingEvents), ctx, limit)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockTenantWebhookRepository) ListWebhooks(ctx context.Context, tenantID string) ([]*models.TenantWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", ctx, tenantID)
+	ret0, _ := ret[0].([]*models.TenantWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockTenantWebhookRepositoryMockRecorder) ListWebhooks(ctx, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockTenantWebhookRepository)(nil).ListWebhooks), ctx, tenantID)
+}
+
+// MarkEventDispatched mocks base method.
+func (m *MockTenantWebhookRepository) MarkEventDispatched(ctx context.Context, id models.ID, dispatchedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventDispatched", ctx, id, dispatchedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventDispatched indicates an expected call of MarkEventDispatched.
+func (mr *MockTenantWebhookRepositoryMockRecorder) MarkEventDispatched(ctx, id, dispatchedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventDispatched", reflect.TypeOf((*MockTenantWebhookRepository)(nil).MarkEventDispatched), ctx, id, dispatchedAt)
+}
+
+// SaveSigningKey mocks base method.
+func (m *MockTenantWebhookRepository) SaveSigningKey(ctx context.Context, key *models.TenantSigningKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSigningKey", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSigningKey indicates an expected call of SaveSigningKey.
+func (mr *MockTenantWebhookRepositoryMockRecorder) SaveSigningKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSigningKey", reflect.TypeOf((*MockTenantWebhookRepository)(nil).SaveSigningKey), ctx, key)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockTenantWebhookRepository) UpdateWebhook(ctx context.Context, webhook *models.TenantWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockTenantWebhookRepositoryMockRecorder) UpdateWebhook(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockTenantWebhookRepository)(nil).UpdateWebhook), ctx, webhook)
+}
+
+// MockAnalyticsReportRepository is a mock of AnalyticsReportRepository interface.
+type MockAnalyticsReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsReportRepositoryMockRecorder
+}
+
+// MockAnalyticsReportRepositoryMockRecorder is the mock recorder for MockAnalyticsReportRepository.
+type MockAnalyticsReportRepositoryMockRecorder struct {
+	mock *MockAnalyticsReportRepository
+}
+
+// NewMockAnalyticsReportRepository creates a new mock instance.
+func NewMockAnalyticsReportRepository(ctrl *gomock.Controller) *MockAnalyticsReportRepository {
+	mock := &MockAnalyticsReportRepository{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsReportRepository) EXPECT() *MockAnalyticsReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByWedding mocks base method.
+func (m *MockAnalyticsReportRepository) GetByWedding(ctx context.Context, weddingID models.ID) (*models.AnalyticsReportSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByWedding", ctx, weddingID)
+	ret0, _ := ret[0].(*models.AnalyticsReportSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByWedding indicates an expected call of GetByWedding.
+func (mr *MockAnalyticsReportRepositoryMockRecorder) GetByWedding(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByWedding", reflect.TypeOf((*MockAnalyticsReportRepository)(nil).GetByWedding), ctx, weddingID)
+}
+
+// ListDue mocks base method.
+func (m *MockAnalyticsReportRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.AnalyticsReportSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDue", ctx, now, limit)
+	ret0, _ := ret[0].([]*models.AnalyticsReportSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDue indicates an expected call of ListDue.
+func (mr *MockAnalyticsReportRepositoryMockRecorder) ListDue(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDue", reflect.TypeOf((*MockAnalyticsReportRepository)(nil).ListDue), ctx, now, limit)
+}
+
+// MarkSent mocks base method.
+func (m *MockAnalyticsReportRepository) MarkSent(ctx context.Context, id models.ID, sentAt, nextSendAt time.Time, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, id, sentAt, nextSendAt, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockAnalyticsReportRepositoryMockRecorder) MarkSent(ctx, id, sentAt, nextSendAt, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockAnalyticsReportRepository)(nil).MarkSent), ctx, id, sentAt, nextSendAt, reason)
+}
+
+// Upsert mocks base method.
+func (m *MockAnalyticsReportRepository) Upsert(ctx context.Context, settings *models.AnalyticsReportSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockAnalyticsReportRepositoryMockRecorder) Upsert(ctx, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockAnalyticsReportRepository)(nil).Upsert), ctx, settings)
+}
+
+// MockAnalyticsAnomalyRepository is a mock of AnalyticsAnomalyRepository interface.
+type MockAnalyticsAnomalyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsAnomalyRepositoryMockRecorder
+}
+
+// MockAnalyticsAnomalyRepositoryMockRecorder is the mock recorder for MockAnalyticsAnomalyRepository.
+type MockAnalyticsAnomalyRepositoryMockRecorder struct {
+	mock *MockAnalyticsAnomalyRepository
+}
+
+// NewMockAnalyticsAnomalyRepository creates a new mock instance.
+func NewMockAnalyticsAnomalyRepository(ctrl *gomock.Controller) *MockAnalyticsAnomalyRepository {
+	mock := &MockAnalyticsAnomalyRepository{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsAnomalyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsAnomalyRepository) EXPECT() *MockAnalyticsAnomalyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAnalyticsAnomalyRepository) Create(ctx context.Context, anomaly *models.AnalyticsAnomaly) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, anomaly)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAnalyticsAnomalyRepositoryMockRecorder) Create(ctx, anomaly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAnalyticsAnomalyRepository)(nil).Create), ctx, anomaly)
+}
+
+// GetLatest mocks base method.
+func (m *MockAnalyticsAnomalyRepository) GetLatest(ctx context.Context, weddingID models.ID, metric models.AnomalyMetric, kind models.AnomalyKind) (*models.AnalyticsAnomaly, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatest", ctx, weddingID, metric, kind)
+	ret0, _ := ret[0].(*models.AnalyticsAnomaly)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatest indicates an expected call of GetLatest.
+func (mr *MockAnalyticsAnomalyRepositoryMockRecorder) GetLatest(ctx, weddingID, metric, kind interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatest", reflect.TypeOf((*MockAnalyticsAnomalyRepository)(nil).GetLatest), ctx, weddingID, metric, kind)
+}
+
+// ListByWedding mocks base method.
+func (m *MockAnalyticsAnomalyRepository) ListByWedding(ctx context.Context, weddingID models.ID, page, pageSize int) ([]*models.AnalyticsAnomaly, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByWedding", ctx, weddingID, page, pageSize)
+	ret0, _ := ret[0].([]*models.AnalyticsAnomaly)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByWedding indicates an expected call of ListByWedding.
+func (mr *MockAnalyticsAnomalyRepositoryMockRecorder) ListByWedding(ctx, weddingID, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWedding", reflect.TypeOf((*MockAnalyticsAnomalyRepository)(nil).ListByWedding), ctx, weddingID, page, pageSize)
+}
+
+// MockReminderRepository is a mock of ReminderRepository interface.
+type MockReminderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderRepositoryMockRecorder
+}
+
+// MockReminderRepositoryMockRecorder is the mock recorder for MockReminderRepository.
+type MockReminderRepositoryMockRecorder struct {
+	mock *MockReminderRepository
+}
+
+// NewMockReminderRepository creates a new mock instance.
+func NewMockReminderRepository(ctrl *gomock.Controller) *MockReminderRepository {
+	mock := &MockReminderRepository{ctrl: ctrl}
+	mock.recorder = &MockReminderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderRepository) EXPECT() *MockReminderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CancelCampaign mocks base method.
+func (m *MockReminderRepository) CancelCampaign(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelCampaign", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelCampaign indicates an expected call of CancelCampaign.
+func (mr *MockReminderRepositoryMockRecorder) CancelCampaign(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelCampaign", reflect.TypeOf((*MockReminderRepository)(nil).CancelCampaign), ctx, id)
+}
+
+// ClaimDueCampaign mocks base method.
+func (m *MockReminderRepository) ClaimDueCampaign(ctx context.Context, now time.Time, lease time.Duration) (*models.ReminderCampaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueCampaign", ctx, now, lease)
+	ret0, _ := ret[0].(*models.ReminderCampaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueCampaign indicates an expected call of ClaimDueCampaign.
+func (mr *MockReminderRepositoryMockRecorder) ClaimDueCampaign(ctx, now, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueCampaign", reflect.TypeOf((*MockReminderRepository)(nil).ClaimDueCampaign), ctx, now, lease)
+}
+
+// CreateCampaign mocks base method.
+func (m *MockReminderRepository) CreateCampaign(ctx context.Context, campaign *models.ReminderCampaign) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCampaign", ctx, campaign)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCampaign indicates an expected call of CreateCampaign.
+func (mr *MockReminderRepositoryMockRecorder) CreateCampaign(ctx, campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCampaign", reflect.TypeOf((*MockReminderRepository)(nil).CreateCampaign), ctx, campaign)
+}
+
+// CreateDelivery mocks base method.
+func (m *MockReminderRepository) CreateDelivery(ctx context.Context, delivery *models.ReminderDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDelivery indicates an expected call of CreateDelivery.
+func (mr *MockReminderRepositoryMockRecorder) CreateDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelivery", reflect.TypeOf((*MockReminderRepository)(nil).CreateDelivery), ctx, delivery)
+}
+
+// DeliveryStats mocks base method.
+func (m *MockReminderRepository) DeliveryStats(ctx context.Context, campaignID models.ID) (*models.ReminderDeliveryStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveryStats", ctx, campaignID)
+	ret0, _ := ret[0].(*models.ReminderDeliveryStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliveryStats indicates an expected call of DeliveryStats.
+func (mr *MockReminderRepositoryMockRecorder) DeliveryStats(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryStats", reflect.TypeOf((*MockReminderRepository)(nil).DeliveryStats), ctx, campaignID)
+}
+
+// FinishCampaign mocks base method.
+func (m *MockReminderRepository) FinishCampaign(ctx context.Context, campaign *models.ReminderCampaign) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishCampaign", ctx, campaign)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishCampaign indicates an expected call of FinishCampaign.
+func (mr *MockReminderRepositoryMockRecorder) FinishCampaign(ctx, campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishCampaign", reflect.TypeOf((*MockReminderRepository)(nil).FinishCampaign), ctx, campaign)
+}
+
+// GetCampaign mocks base method.
+func (m *MockReminderRepository) GetCampaign(ctx context.Context, id models.ID) (*models.ReminderCampaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaign", ctx, id)
+	ret0, _ := ret[0].(*models.ReminderCampaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaign indicates an expected call of GetCampaign.
+func (mr *MockReminderRepositoryMockRecorder) GetCampaign(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaign", reflect.TypeOf((*MockReminderRepository)(nil).GetCampaign), ctx, id)
+}
+
+// HasDelivery mocks base method.
+func (m *MockReminderRepository) HasDelivery(ctx context.Context, campaignID, guestID models.ID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasDelivery", ctx, campaignID, guestID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasDelivery indicates an expected call of HasDelivery.
+func (mr *MockReminderRepositoryMockRecorder) HasDelivery(ctx, campaignID, guestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasDelivery", reflect.TypeOf((*MockReminderRepository)(nil).HasDelivery), ctx, campaignID, guestID)
+}
+
+// ListCampaigns mocks base method.
+func (m *MockReminderRepository) ListCampaigns(ctx context.Context, weddingID models.ID) ([]*models.ReminderCampaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCampaigns", ctx, weddingID)
+	ret0, _ := ret[0].([]*models.ReminderCampaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCampaigns indicates an expected call of ListCampaigns.
+func (mr *MockReminderRepositoryMockRecorder) ListCampaigns(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCampaigns", reflect.TypeOf((*MockReminderRepository)(nil).ListCampaigns), ctx, weddingID)
+}
+
+// MarkDeliveryOpened mocks base method.
+func (m *MockReminderRepository) MarkDeliveryOpened(ctx context.Context, id models.ID, openedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDeliveryOpened", ctx, id, openedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDeliveryOpened indicates an expected call of MarkDeliveryOpened.
+func (mr *MockReminderRepositoryMockRecorder) MarkDeliveryOpened(ctx, id, openedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDeliveryOpened", reflect.TypeOf((*MockReminderRepository)(nil).MarkDeliveryOpened), ctx, id, openedAt)
+}
+
+// MarkResponded mocks base method.
+func (m *MockReminderRepository) MarkResponded(ctx context.Context, weddingID models.ID, email, phone string, respondedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkResponded", ctx, weddingID, email, phone, respondedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkResponded indicates an expected call of MarkResponded.
+func (mr *MockReminderRepositoryMockRecorder) MarkResponded(ctx, weddingID, email, phone, respondedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkResponded", reflect.TypeOf((*MockReminderRepository)(nil).MarkResponded), ctx, weddingID, email, phone, respondedAt)
+}
+
+// MockGuestPhotoRepository is a mock of GuestPhotoRepository interface.
+type MockGuestPhotoRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGuestPhotoRepositoryMockRecorder
+}
+
+// MockGuestPhotoRepositoryMockRecorder is the mock recorder for MockGuestPhotoRepository.
+type MockGuestPhotoRepositoryMockRecorder struct {
+	mock *MockGuestPhotoRepository
+}
+
+// NewMockGuestPhotoRepository creates a new mock instance.
+func NewMockGuestPhotoRepository(ctrl *gomock.Controller) *MockGuestPhotoRepository {
+	mock := &MockGuestPhotoRepository{ctrl: ctrl}
+	mock.recorder = &MockGuestPhotoRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGuestPhotoRepository) EXPECT() *MockGuestPhotoRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGuestPhotoRepository) Create(ctx context.Context, photo *models.GuestPhoto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, photo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGuestPhotoRepositoryMockRecorder) Create(ctx, photo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGuestPhotoRepository)(nil).Create), ctx, photo)
+}
+
+// GetByID mocks base method.
+func (m *MockGuestPhotoRepository) GetByID(ctx context.Context, id models.ID) (*models.GuestPhoto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.GuestPhoto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockGuestPhotoRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGuestPhotoRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByWedding mocks base method.
+func (m *MockGuestPhotoRepository) ListByWedding(ctx context.Context, weddingID models.ID, status models.GuestPhotoStatus, page, pageSize int) ([]*models.GuestPhoto, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByWedding", ctx, weddingID, status, page, pageSize)
+	ret0, _ := ret[0].([]*models.GuestPhoto)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByWedding indicates an expected call of ListByWedding.
+func (mr *MockGuestPhotoRepositoryMockRecorder) ListByWedding(ctx, weddingID, status, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWedding", reflect.TypeOf((*MockGuestPhotoRepository)(nil).ListByWedding), ctx, weddingID, status, page, pageSize)
+}
+
+// Moderate mocks base method.
+func (m *MockGuestPhotoRepository) Moderate(ctx context.Context, id models.ID, status models.GuestPhotoStatus, moderatedBy models.ID, moderatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Moderate", ctx, id, status, moderatedBy, moderatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Moderate indicates an expected call of Moderate.
+func (mr *MockGuestPhotoRepositoryMockRecorder) Moderate(ctx, id, status, moderatedBy, moderatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Moderate", reflect.TypeOf((*MockGuestPhotoRepository)(nil).Moderate), ctx, id, status, moderatedBy, moderatedAt)
+}
+
+// SetThumbnails mocks base method.
+func (m *MockGuestPhotoRepository) SetThumbnails(ctx context.Context, id models.ID, thumbnails map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetThumbnails", ctx, id, thumbnails)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetThumbnails indicates an expected call of SetThumbnails.
+func (mr *MockGuestPhotoRepositoryMockRecorder) SetThumbnails(ctx, id, thumbnails interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetThumbnails", reflect.TypeOf((*MockGuestPhotoRepository)(nil).SetThumbnails), ctx, id, thumbnails)
+}
+
+// MockRegistryRepository is a mock of RegistryRepository interface.
+type MockRegistryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistryRepositoryMockRecorder
+}
+
+// MockRegistryRepositoryMockRecorder is the mock recorder for MockRegistryRepository.
+type MockRegistryRepositoryMockRecorder struct {
+	mock *MockRegistryRepository
+}
+
+// NewMockRegistryRepository creates a new mock instance.
+func NewMockRegistryRepository(ctrl *gomock.Controller) *MockRegistryRepository {
+	mock := &MockRegistryRepository{ctrl: ctrl}
+	mock.recorder = &MockRegistryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistryRepository) EXPECT() *MockRegistryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateItem mocks base method.
+func (m *MockRegistryRepository) CreateItem(ctx context.Context, item *models.RegistryItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItem", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateItem indicates an expected call of CreateItem.
+func (mr *MockRegistryRepositoryMockRecorder) CreateItem(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockRegistryRepository)(nil).CreateItem), ctx, item)
+}
+
+// CreatePledge mocks base method.
+func (m *MockRegistryRepository) CreatePledge(ctx context.Context, pledge *models.GiftPledge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePledge", ctx, pledge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePledge indicates an expected call of CreatePledge.
+func (mr *MockRegistryRepositoryMockRecorder) CreatePledge(ctx, pledge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePledge", reflect.TypeOf((*MockRegistryRepository)(nil).CreatePledge), ctx, pledge)
+}
+
+// DeleteItem mocks base method.
+func (m *MockRegistryRepository) DeleteItem(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockRegistryRepositoryMockRecorder) DeleteItem(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockRegistryRepository)(nil).DeleteItem), ctx, id)
+}
+
+// GetItem mocks base method.
+func (m *MockRegistryRepository) GetItem(ctx context.Context, id models.ID) (*models.RegistryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItem", ctx, id)
+	ret0, _ := ret[0].(*models.RegistryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItem indicates an expected call of GetItem.
+func (mr *MockRegistryRepositoryMockRecorder) GetItem(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockRegistryRepository)(nil).GetItem), ctx, id)
+}
+
+// GetPledge mocks base method.
+func (m *MockRegistryRepository) GetPledge(ctx context.Context, id models.ID) (*models.GiftPledge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPledge", ctx, id)
+	ret0, _ := ret[0].(*models.GiftPledge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPledge indicates an expected call of GetPledge.
+func (mr *MockRegistryRepositoryMockRecorder) GetPledge(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPledge", reflect.TypeOf((*MockRegistryRepository)(nil).GetPledge), ctx, id)
+}
+
+// ListItems mocks base method.
+func (m *MockRegistryRepository) ListItems(ctx context.Context, weddingID models.ID) ([]*models.RegistryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItems", ctx, weddingID)
+	ret0, _ := ret[0].([]*models.RegistryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItems indicates an expected call of ListItems.
+func (mr *MockRegistryRepositoryMockRecorder) ListItems(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItems", reflect.TypeOf((*MockRegistryRepository)(nil).ListItems), ctx, weddingID)
+}
+
+// ListPledges mocks base method.
+func (m *MockRegistryRepository) ListPledges(ctx context.Context, weddingID models.ID, status models.GiftPledgeStatus, page, pageSize int) ([]*models.GiftPledge, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPledges", ctx, weddingID, status, page, pageSize)
+	ret0, _ := ret[0].([]*models.GiftPledge)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPledges indicates an expected call of ListPledges.
+func (mr *MockRegistryRepositoryMockRecorder) ListPledges(ctx, weddingID, status, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPledges", reflect.TypeOf((*MockRegistryRepository)(nil).ListPledges), ctx, weddingID, status, page, pageSize)
+}
+
+// UpdateItem mocks base method.
+func (m *MockRegistryRepository) UpdateItem(ctx context.Context, item *models.RegistryItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockRegistryRepositoryMockRecorder) UpdateItem(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockRegistryRepository)(nil).UpdateItem), ctx, item)
+}
+
+// UpdatePledgeStatus mocks base method.
+func (m *MockRegistryRepository) UpdatePledgeStatus(ctx context.Context, id models.ID, status models.GiftPledgeStatus, thankedAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePledgeStatus", ctx, id, status, thankedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePledgeStatus indicates an expected call of UpdatePledgeStatus.
+func (mr *MockRegistryRepositoryMockRecorder) UpdatePledgeStatus(ctx, id, status, thankedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePledgeStatus", reflect.TypeOf((*MockRegistryRepository)(nil).UpdatePledgeStatus), ctx, id, status, thankedAt)
+}
+
+// MockVendorRepository is a mock of VendorRepository interface.
+type MockVendorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVendorRepositoryMockRecorder
+}
+
+// MockVendorRepositoryMockRecorder is the mock recorder for MockVendorRepository.
+type MockVendorRepositoryMockRecorder struct {
+	mock *MockVendorRepository
+}
+
+// NewMockVendorRepository creates a new mock instance.
+func NewMockVendorRepository(ctrl *gomock.Controller) *MockVendorRepository {
+	mock := &MockVendorRepository{ctrl: ctrl}
+	mock.recorder = &MockVendorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVendorRepository) EXPECT() *MockVendorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByWedding mocks base method.
+func (m *MockVendorRepository) CountByWedding(ctx context.Context, weddingID models.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByWedding", ctx, weddingID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByWedding indicates an expected call of CountByWedding.
+func (mr *MockVendorRepositoryMockRecorder) CountByWedding(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByWedding", reflect.TypeOf((*MockVendorRepository)(nil).CountByWedding), ctx, weddingID)
+}
+
+// Create mocks base method.
+func (m *MockVendorRepository) Create(ctx context.Context, vendor *models.Vendor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, vendor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVendorRepositoryMockRecorder) Create(ctx, vendor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVendorRepository)(nil).Create), ctx, vendor)
+}
+
+// Delete mocks base method.
+func (m *MockVendorRepository) Delete(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVendorRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVendorRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockVendorRepository) GetByID(ctx context.Context, id models.ID) (*models.Vendor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Vendor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockVendorRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockVendorRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByWedding mocks base method.
+func (m *MockVendorRepository) ListByWedding(ctx context.Context, weddingID models.ID, filters repository.VendorFilters) ([]*models.Vendor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByWedding", ctx, weddingID, filters)
+	ret0, _ := ret[0].([]*models.Vendor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByWedding indicates an expected call of ListByWedding.
+func (mr *MockVendorRepositoryMockRecorder) ListByWedding(ctx, weddingID, filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWedding", reflect.TypeOf((*MockVendorRepository)(nil).ListByWedding), ctx, weddingID, filters)
+}
+
+// Update mocks base method.
+func (m *MockVendorRepository) Update(ctx context.Context, vendor *models.Vendor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, vendor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVendorRepositoryMockRecorder) Update(ctx, vendor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVendorRepository)(nil).Update), ctx, vendor)
+}
+
+// MockPushSubscriptionRepository is a mock of PushSubscriptionRepository interface.
+type MockPushSubscriptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPushSubscriptionRepositoryMockRecorder
+}
+
+// MockPushSubscriptionRepositoryMockRecorder is the mock recorder for MockPushSubscriptionRepository.
+type MockPushSubscriptionRepositoryMockRecorder struct {
+	mock *MockPushSubscriptionRepository
+}
+
+// NewMockPushSubscriptionRepository creates a new mock instance.
+func NewMockPushSubscriptionRepository(ctrl *gomock.Controller) *MockPushSubscriptionRepository {
+	mock := &MockPushSubscriptionRepository{ctrl: ctrl}
+	mock.recorder = &MockPushSubscriptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPushSubscriptionRepository) EXPECT() *MockPushSubscriptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockPushSubscriptionRepository) Delete(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPushSubscriptionRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPushSubscriptionRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockPushSubscriptionRepository) GetByID(ctx context.Context, id models.ID) (*models.PushSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.PushSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPushSubscriptionRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPushSubscriptionRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByUser mocks base method.
+func (m *MockPushSubscriptionRepository) ListByUser(ctx context.Context, userID models.ID) ([]*models.PushSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, userID)
+	ret0, _ := ret[0].([]*models.PushSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockPushSubscriptionRepositoryMockRecorder) ListByUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockPushSubscriptionRepository)(nil).ListByUser), ctx, userID)
+}
+
+// MarkPushed mocks base method.
+func (m *MockPushSubscriptionRepository) MarkPushed(ctx context.Context, id models.ID, pushedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPushed", ctx, id, pushedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPushed indicates an expected call of MarkPushed.
+func (mr *MockPushSubscriptionRepositoryMockRecorder) MarkPushed(ctx, id, pushedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPushed", reflect.TypeOf((*MockPushSubscriptionRepository)(nil).MarkPushed), ctx, id, pushedAt)
+}
+
+// Save mocks base method.
+func (m *MockPushSubscriptionRepository) Save(ctx context.Context, subscription *models.PushSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPushSubscriptionRepositoryMockRecorder) Save(ctx, subscription interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPushSubscriptionRepository)(nil).Save), ctx, subscription)
+}
+
+// MockBudgetRepository is a mock of BudgetRepository interface.
+type MockBudgetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBudgetRepositoryMockRecorder
+}
+
+// MockBudgetRepositoryMockRecorder is the mock recorder for MockBudgetRepository.
+type MockBudgetRepositoryMockRecorder struct {
+	mock *MockBudgetRepository
+}
+
+// NewMockBudgetRepository creates a new mock instance.
+func NewMockBudgetRepository(ctrl *gomock.Controller) *MockBudgetRepository {
+	mock := &MockBudgetRepository{ctrl: ctrl}
+	mock.recorder = &MockBudgetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBudgetRepository) EXPECT() *MockBudgetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDueReminder mocks base method.
+func (m *MockBudgetRepository) ClaimDueReminder(ctx context.Context, now time.Time) (*models.BudgetPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueReminder", ctx, now)
+	ret0, _ := ret[0].(*models.BudgetPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueReminder indicates an expected call of ClaimDueReminder.
+func (mr *MockBudgetRepositoryMockRecorder) ClaimDueReminder(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueReminder", reflect.TypeOf((*MockBudgetRepository)(nil).ClaimDueReminder), ctx, now)
+}
+
+// CreateCategory mocks base method.
+func (m *MockBudgetRepository) CreateCategory(ctx context.Context, category *models.BudgetCategory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockBudgetRepositoryMockRecorder) CreateCategory(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockBudgetRepository)(nil).CreateCategory), ctx, category)
+}
+
+// CreatePayment mocks base method.
+func (m *MockBudgetRepository) CreatePayment(ctx context.Context, payment *models.BudgetPayment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", ctx, payment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockBudgetRepositoryMockRecorder) CreatePayment(ctx, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockBudgetRepository)(nil).CreatePayment), ctx, payment)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockBudgetRepository) DeleteCategory(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockBudgetRepositoryMockRecorder) DeleteCategory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockBudgetRepository)(nil).DeleteCategory), ctx, id)
+}
+
+// DeletePayment mocks base method.
+func (m *MockBudgetRepository) DeletePayment(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePayment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePayment indicates an expected call of DeletePayment.
+func (mr *MockBudgetRepositoryMockRecorder) DeletePayment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayment", reflect.TypeOf((*MockBudgetRepository)(nil).DeletePayment), ctx, id)
+}
+
+// GetCategory mocks base method.
+func (m *MockBudgetRepository) GetCategory(ctx context.Context, id models.ID) (*models.BudgetCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", ctx, id)
+	ret0, _ := ret[0].(*models.BudgetCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockBudgetRepositoryMockRecorder) GetCategory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockBudgetRepository)(nil).GetCategory), ctx, id)
+}
+
+// GetPayment mocks base method.
+func (m *MockBudgetRepository) GetPayment(ctx context.Context, id models.ID) (*models.BudgetPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayment", ctx, id)
+	ret0, _ := ret[0].(*models.BudgetPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayment indicates an expected call of GetPayment.
+func (mr *MockBudgetRepositoryMockRecorder) GetPayment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayment", reflect.TypeOf((*MockBudgetRepository)(nil).GetPayment), ctx, id)
+}
+
+// ListCategories mocks base method.
+func (m *MockBudgetRepository) ListCategories(ctx context.Context, weddingID models.ID) ([]*models.BudgetCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", ctx, weddingID)
+	ret0, _ := ret[0].([]*models.BudgetCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockBudgetRepositoryMockRecorder) ListCategories(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockBudgetRepository)(nil).ListCategories), ctx, weddingID)
+}
+
+// ListPayments mocks base method.
+func (m *MockBudgetRepository) ListPayments(ctx context.Context, weddingID models.ID, filters repository.BudgetPaymentFilters) ([]*models.BudgetPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayments", ctx, weddingID, filters)
+	ret0, _ := ret[0].([]*models.BudgetPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayments indicates an expected call of ListPayments.
+func (mr *MockBudgetRepositoryMockRecorder) ListPayments(ctx, weddingID, filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayments", reflect.TypeOf((*MockBudgetRepository)(nil).ListPayments), ctx, weddingID, filters)
+}
+
+// RescheduleReminder mocks base method.
+func (m *MockBudgetRepository) RescheduleReminder(ctx context.Context, id models.ID, remindAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleReminder", ctx, id, remindAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RescheduleReminder indicates an expected call of RescheduleReminder.
+func (mr *MockBudgetRepositoryMockRecorder) RescheduleReminder(ctx, id, remindAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleReminder", reflect.TypeOf((*MockBudgetRepository)(nil).RescheduleReminder), ctx, id, remindAt)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockBudgetRepository) UpdateCategory(ctx context.Context, category *models.BudgetCategory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockBudgetRepositoryMockRecorder) UpdateCategory(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockBudgetRepository)(nil).UpdateCategory), ctx, category)
+}
+
+// UpdatePayment mocks base method.
+func (m *MockBudgetRepository) UpdatePayment(ctx context.Context, payment *models.BudgetPayment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePayment", ctx, payment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePayment indicates an expected call of UpdatePayment.
+func (mr *MockBudgetRepositoryMockRecorder) UpdatePayment(ctx, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePayment", reflect.TypeOf((*MockBudgetRepository)(nil).UpdatePayment), ctx, payment)
+}
+
+// MockWishRepository is a mock of WishRepository interface.
+type MockWishRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWishRepositoryMockRecorder
+}
+
+// MockWishRepositoryMockRecorder is the mock recorder for MockWishRepository.
+type MockWishRepositoryMockRecorder struct {
+	mock *MockWishRepository
+}
+
+// NewMockWishRepository creates a new mock instance.
+func NewMockWishRepository(ctrl *gomock.Controller) *MockWishRepository {
+	mock := &MockWishRepository{ctrl: ctrl}
+	mock.recorder = &MockWishRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWishRepository) EXPECT() *MockWishRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWishRepository) Create(ctx context.Context, wish *models.Wish) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, wish)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWishRepositoryMockRecorder) Create(ctx, wish interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWishRepository)(nil).Create), ctx, wish)
+}
+
+// Delete mocks base method.
+func (m *MockWishRepository) Delete(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWishRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWishRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockWishRepository) GetByID(ctx context.Context, id models.ID) (*models.Wish, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Wish)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWishRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWishRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByWedding mocks base method.
+func (m *MockWishRepository) ListByWedding(ctx context.Context, weddingID models.ID, status models.WishStatus, page, pageSize int) ([]*models.Wish, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByWedding", ctx, weddingID, status, page, pageSize)
+	ret0, _ := ret[0].([]*models.Wish)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByWedding indicates an expected call of ListByWedding.
+func (mr *MockWishRepositoryMockRecorder) ListByWedding(ctx, weddingID, status, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWedding", reflect.TypeOf((*MockWishRepository)(nil).ListByWedding), ctx, weddingID, status, page, pageSize)
+}
+
+// SetStatus mocks base method.
+func (m *MockWishRepository) SetStatus(ctx context.Context, id models.ID, status models.WishStatus, moderatedBy models.ID, moderatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, status, moderatedBy, moderatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockWishRepositoryMockRecorder) SetStatus(ctx, id, status, moderatedBy, moderatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockWishRepository)(nil).SetStatus), ctx, id, status, moderatedBy, moderatedAt)
+}
+
+// MockSongRequestRepository is a mock of SongRequestRepository interface.
+type MockSongRequestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSongRequestRepositoryMockRecorder
+}
+
+// MockSongRequestRepositoryMockRecorder is the mock recorder for MockSongRequestRepository.
+type MockSongRequestRepositoryMockRecorder struct {
+	mock *MockSongRequestRepository
+}
+
+// NewMockSongRequestRepository creates a new mock instance.
+func NewMockSongRequestRepository(ctrl *gomock.Controller) *MockSongRequestRepository {
+	mock := &MockSongRequestRepository{ctrl: ctrl}
+	mock.recorder = &MockSongRequestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSongRequestRepository) EXPECT() *MockSongRequestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSongRequestRepository) Delete(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSongRequestRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSongRequestRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockSongRequestRepository) GetByID(ctx context.Context, id models.ID) (*models.SongRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.SongRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSongRequestRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSongRequestRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByWedding mocks base method.
+func (m *MockSongRequestRepository) ListByWedding(ctx context.Context, weddingID models.ID, status models.SongRequestStatus, page, pageSize int) ([]*models.SongRequest, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByWedding", ctx, weddingID, status, page, pageSize)
+	ret0, _ := ret[0].([]*models.SongRequest)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByWedding indicates an expected call of ListByWedding.
+func (mr *MockSongRequestRepositoryMockRecorder) ListByWedding(ctx, weddingID, status, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWedding", reflect.TypeOf((*MockSongRequestRepository)(nil).ListByWedding), ctx, weddingID, status, page, pageSize)
+}
+
+// Request mocks base method.
+func (m *MockSongRequestRepository) Request(ctx context.Context, request *models.SongRequest, requester string, maxRequesters int) (*models.SongRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Request", ctx, request, requester, maxRequesters)
+	ret0, _ := ret[0].(*models.SongRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Request indicates an expected call of Request.
+func (mr *MockSongRequestRepositoryMockRecorder) Request(ctx, request, requester, maxRequesters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockSongRequestRepository)(nil).Request), ctx, request, requester, maxRequesters)
+}
+
+// SetStatus mocks base method.
+func (m *MockSongRequestRepository) SetStatus(ctx context.Context, id models.ID, status models.SongRequestStatus, moderatedBy models.ID, moderatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, status, moderatedBy, moderatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockSongRequestRepositoryMockRecorder) SetStatus(ctx, id, status, moderatedBy, moderatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockSongRequestRepository)(nil).SetStatus), ctx, id, status, moderatedBy, moderatedAt)
+}
+
+// MockWeddingWebhookRepository is a mock of WeddingWebhookRepository interface.
+type MockWeddingWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWeddingWebhookRepositoryMockRecorder
+}
+
+// MockWeddingWebhookRepositoryMockRecorder is the mock recorder for MockWeddingWebhookRepository.
+type MockWeddingWebhookRepositoryMockRecorder struct {
+	mock *MockWeddingWebhookRepository
+}
+
+// NewMockWeddingWebhookRepository creates a new mock instance.
+func NewMockWeddingWebhookRepository(ctrl *gomock.Controller) *MockWeddingWebhookRepository {
+	mock := &MockWeddingWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWeddingWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWeddingWebhookRepository) EXPECT() *MockWeddingWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWeddingWebhookRepository) Create(ctx context.Context, webhook *models.WeddingWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWeddingWebhookRepositoryMockRecorder) Create(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWeddingWebhookRepository)(nil).Create), ctx, webhook)
+}
+
+// CreateDelivery mocks base method.
+func (m *MockWeddingWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WeddingWebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDelivery indicates an expected call of CreateDelivery.
+func (mr *MockWeddingWebhookRepositoryMockRecorder) CreateDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelivery", reflect.TypeOf((*MockWeddingWebhookRepository)(nil).CreateDelivery), ctx, delivery)
+}
+
+// Delete mocks base method.
+func (m *MockWeddingWebhookRepository) Delete(ctx context.Context, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWeddingWebhookRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWeddingWebhookRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockWeddingWebhookRepository) GetByID(ctx context.Context, id models.ID) (*models.WeddingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.WeddingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWeddingWebhookRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWeddingWebhookRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByWedding mocks base method.
+func (m *MockWeddingWebhookRepository) ListByWedding(ctx context.Context, weddingID models.ID) ([]*models.WeddingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByWedding", ctx, weddingID)
+	ret0, _ := ret[0].([]*models.WeddingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByWedding indicates an expected call of ListByWedding.
+func (mr *MockWeddingWebhookRepositoryMockRecorder) ListByWedding(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWedding", reflect.TypeOf((*MockWeddingWebhookRepository)(nil).ListByWedding), ctx, weddingID)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWeddingWebhookRepository) ListDeliveries(ctx context.Context, webhookID models.ID, page, pageSize int) ([]*models.WeddingWebhookDelivery, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, webhookID, page, pageSize)
+	ret0, _ := ret[0].([]*models.WeddingWebhookDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWeddingWebhookRepositoryMockRecorder) ListDeliveries(ctx, webhookID, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWeddingWebhookRepository)(nil).ListDeliveries), ctx, webhookID, page, pageSize)
+}
+
+// Update mocks base method.
+func (m *MockWeddingWebhookRepository) Update(ctx context.Context, webhook *models.WeddingWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWeddingWebhookRepositoryMockRecorder) Update(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWeddingWebhookRepository)(nil).Update), ctx, webhook)
+}
+
+// MockAPIKeyRepository is a mock of APIKeyRepository interface.
+type MockAPIKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepositoryMockRecorder
+}
+
+// MockAPIKeyRepositoryMockRecorder is the mock recorder for MockAPIKeyRepository.
+type MockAPIKeyRepositoryMockRecorder struct {
+	mock *MockAPIKeyRepository
+}
+
+// NewMockAPIKeyRepository creates a new mock instance.
+func NewMockAPIKeyRepository(ctrl *gomock.Controller) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyRepositoryMockRecorder) Create(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeyRepository)(nil).Create), ctx, key)
+}
+
+// GetByHash mocks base method.
+func (m *MockAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, keyHash)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetByHash(ctx, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetByHash), ctx, keyHash)
+}
+
+// GetByID mocks base method.
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, id models.ID) (*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByUser mocks base method.
+func (m *MockAPIKeyRepository) ListByUser(ctx context.Context, userID models.ID) ([]*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, userID)
+	ret0, _ := ret[0].([]*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockAPIKeyRepositoryMockRecorder) ListByUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockAPIKeyRepository)(nil).ListByUser), ctx, userID)
+}
+
+// ListUsage mocks base method.
+func (m *MockAPIKeyRepository) ListUsage(ctx context.Context, keyID models.ID, from string) ([]*models.APIKeyUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsage", ctx, keyID, from)
+	ret0, _ := ret[0].([]*models.APIKeyUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsage indicates an expected call of ListUsage.
+func (mr *MockAPIKeyRepositoryMockRecorder) ListUsage(ctx, keyID, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsage", reflect.TypeOf((*MockAPIKeyRepository)(nil).ListUsage), ctx, keyID, from)
+}
+
+// RecordUsage mocks base method.
+func (m *MockAPIKeyRepository) RecordUsage(ctx context.Context, keyID models.ID, at time.Time, failed, rateLimited bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordUsage", ctx, keyID, at, failed, rateLimited)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordUsage indicates an expected call of RecordUsage.
+func (mr *MockAPIKeyRepositoryMockRecorder) RecordUsage(ctx, keyID, at, failed, rateLimited interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordUsage", reflect.TypeOf((*MockAPIKeyRepository)(nil).RecordUsage), ctx, keyID, at, failed, rateLimited)
+}
+
+// Update mocks base method.
+func (m *MockAPIKeyRepository) Update(ctx context.Context, key *models.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAPIKeyRepositoryMockRecorder) Update(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAPIKeyRepository)(nil).Update), ctx, key)
+}
+
+// MockAuditLogRepository is a mock of AuditLogRepository interface.
+type MockAuditLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogRepositoryMockRecorder
+}
+
+// MockAuditLogRepositoryMockRecorder is the mock recorder for MockAuditLogRepository.
+type MockAuditLogRepositoryMockRecorder struct {
+	mock *MockAuditLogRepository
+}
+
+// NewMockAuditLogRepository creates a new mock instance.
+func NewMockAuditLogRepository(ctrl *gomock.Controller) *MockAuditLogRepository {
+	mock := &MockAuditLogRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogRepository) EXPECT() *MockAuditLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAuditLogRepository) Create(ctx context.Context, entry *models.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuditLogRepositoryMockRecorder) Create(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuditLogRepository)(nil).Create), ctx, entry)
+}
+
+// List mocks base method.
+func (m *MockAuditLogRepository) List(ctx context.Context, filters repository.AuditLogFilters, page, pageSize int) ([]*models.AuditLog, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filters, page, pageSize)
+	ret0, _ := ret[0].([]*models.AuditLog)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockAuditLogRepositoryMockRecorder) List(ctx, filters, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuditLogRepository)(nil).List), ctx, filters, page, pageSize)
+}
+
+// MockRetentionRepository is a mock of RetentionRepository interface.
+type MockRetentionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRetentionRepositoryMockRecorder
+}
+
+// MockRetentionRepositoryMockRecorder is the mock recorder for MockRetentionRepository.
+type MockRetentionRepositoryMockRecorder struct {
+	mock *MockRetentionRepository
+}
+
+// NewMockRetentionRepository creates a new mock instance.
+func NewMockRetentionRepository(ctrl *gomock.Controller) *MockRetentionRepository {
+	mock := &MockRetentionRepository{ctrl: ctrl}
+	mock.recorder = &MockRetentionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRetentionRepository) EXPECT() *MockRetentionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountExpired mocks base method.
+func (m *MockRetentionRepository) CountExpired(ctx context.Context, dataType string, weddingID *models.ID, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountExpired", ctx, dataType, weddingID, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountExpired indicates an expected call of CountExpired.
+func (mr *MockRetentionRepositoryMockRecorder) CountExpired(ctx, dataType, weddingID, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountExpired", reflect.TypeOf((*MockRetentionRepository)(nil).CountExpired), ctx, dataType, weddingID, before)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockRetentionRepository) DeleteExpired(ctx context.Context, dataType string, weddingID *models.ID, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, dataType, weddingID, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockRetentionRepositoryMockRecorder) DeleteExpired(ctx, dataType, weddingID, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockRetentionRepository)(nil).DeleteExpired), ctx, dataType, weddingID, before)
+}
+
+// DeleteOverride mocks base method.
+func (m *MockRetentionRepository) DeleteOverride(ctx context.Context, weddingID models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOverride", ctx, weddingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOverride indicates an expected call of DeleteOverride.
+func (mr *MockRetentionRepositoryMockRecorder) DeleteOverride(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOverride", reflect.TypeOf((*MockRetentionRepository)(nil).DeleteOverride), ctx, weddingID)
+}
+
+// GetOverride mocks base method.
+func (m *MockRetentionRepository) GetOverride(ctx context.Context, weddingID models.ID) (*models.RetentionOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverride", ctx, weddingID)
+	ret0, _ := ret[0].(*models.RetentionOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverride indicates an expected call of GetOverride.
+func (mr *MockRetentionRepositoryMockRecorder) GetOverride(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverride", reflect.TypeOf((*MockRetentionRepository)(nil).GetOverride), ctx, weddingID)
+}
+
+// ListOverrides mocks base method.
+func (m *MockRetentionRepository) ListOverrides(ctx context.Context) ([]*models.RetentionOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOverrides", ctx)
+	ret0, _ := ret[0].([]*models.RetentionOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOverrides indicates an expected call of ListOverrides.
+func (mr *MockRetentionRepositoryMockRecorder) ListOverrides(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverrides", reflect.TypeOf((*MockRetentionRepository)(nil).ListOverrides), ctx)
+}
+
+// SetOverride mocks base method.
+func (m *MockRetentionRepository) SetOverride(ctx context.Context, override *models.RetentionOverride) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOverride", ctx, override)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOverride indicates an expected call of SetOverride.
+func (mr *MockRetentionRepositoryMockRecorder) SetOverride(ctx, override interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOverride", reflect.TypeOf((*MockRetentionRepository)(nil).SetOverride), ctx, override)
+}
+
+// SyncTTLIndex mocks base method.
+func (m *MockRetentionRepository) SyncTTLIndex(ctx context.Context, dataType string, period time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncTTLIndex", ctx, dataType, period)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncTTLIndex indicates an expected call of SyncTTLIndex.
+func (mr *MockRetentionRepositoryMockRecorder) SyncTTLIndex(ctx, dataType, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncTTLIndex", reflect.TypeOf((*MockRetentionRepository)(nil).SyncTTLIndex), ctx, dataType, period)
+}
+
+// MockAbuseReportRepository is a mock of AbuseReportRepository interface.
+type MockAbuseReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbuseReportRepositoryMockRecorder
+}
+
+// MockAbuseReportRepositoryMockRecorder is the mock recorder for MockAbuseReportRepository.
+type MockAbuseReportRepositoryMockRecorder struct {
+	mock *MockAbuseReportRepository
+}
+
+// NewMockAbuseReportRepository creates a new mock instance.
+func NewMockAbuseReportRepository(ctrl *gomock.Controller) *MockAbuseReportRepository {
+	mock := &MockAbuseReportRepository{ctrl: ctrl}
+	mock.recorder = &MockAbuseReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbuseReportRepository) EXPECT() *MockAbuseReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountOpenByWedding mocks base method.
+func (m *MockAbuseReportRepository) CountOpenByWedding(ctx context.Context, weddingID models.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenByWedding", ctx, weddingID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenByWedding indicates an expected call of CountOpenByWedding.
+func (mr *MockAbuseReportRepositoryMockRecorder) CountOpenByWedding(ctx, weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenByWedding", reflect.TypeOf((*MockAbuseReportRepository)(nil).CountOpenByWedding), ctx, weddingID)
+}
+
+// Create mocks base method.
+func (m *MockAbuseReportRepository) Create(ctx context.Context, report *models.AbuseReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAbuseReportRepositoryMockRecorder) Create(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAbuseReportRepository)(nil).Create), ctx, report)
+}
+
+// GetByID mocks base method.
+func (m *MockAbuseReportRepository) GetByID(ctx context.Context, id models.ID) (*models.AbuseReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.AbuseReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAbuseReportRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAbuseReportRepository)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockAbuseReportRepository) List(ctx context.Context, filters repository.AbuseReportFilters, page, pageSize int) ([]*models.AbuseReport, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filters, page, pageSize)
+	ret0, _ := ret[0].([]*models.AbuseReport)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockAbuseReportRepositoryMockRecorder) List(ctx, filters, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAbuseReportRepository)(nil).List), ctx, filters, page, pageSize)
+}
+
+// Update mocks base method.
+func (m *MockAbuseReportRepository) Update(ctx context.Context, report *models.AbuseReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAbuseReportRepositoryMockRecorder) Update(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAbuseReportRepository)(nil).Update), ctx, report)
+}
+
+// MockSessionRepository is a mock of SessionRepository interface.
+type MockSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRepositoryMockRecorder
+}
+
+// MockSessionRepositoryMockRecorder is the mock recorder for MockSessionRepository.
+type MockSessionRepositoryMockRecorder struct {
+	mock *MockSessionRepository
+}
+
+// NewMockSessionRepository creates a new mock instance.
+func NewMockSessionRepository(ctrl *gomock.Controller) *MockSessionRepository {
+	mock := &MockSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRepository) EXPECT() *MockSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSessionRepository) Create(ctx context.Context, session *models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSessionRepositoryMockRecorder) Create(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSessionRepository)(nil).Create), ctx, session)
+}
+
+// Delete mocks base method.
+func (m *MockSessionRepository) Delete(ctx context.Context, userID, id models.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSessionRepositoryMockRecorder) Delete(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSessionRepository)(nil).Delete), ctx, userID, id)
+}
+
+// DeleteByUser mocks base method.
+func (m *MockSessionRepository) DeleteByUser(ctx context.Context, userID models.ID, except *models.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUser", ctx, userID, except)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUser indicates an expected call of DeleteByUser.
+func (mr *MockSessionRepositoryMockRecorder) DeleteByUser(ctx, userID, except interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUser", reflect.TypeOf((*MockSessionRepository)(nil).DeleteByUser), ctx, userID, except)
+}
+
+// GetByID mocks base method.
+func (m *MockSessionRepository) GetByID(ctx context.Context, id models.ID) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSessionRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMediaRepository)(nil).List), ctx, filter, opts)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSessionRepository)(nil).GetByID), ctx, id)
 }
 
-// SoftDelete mocks base method.
-func (m *MockMediaRepository) SoftDelete(ctx context.Context, id primitive.ObjectID) error {
+// ListActiveByUser mocks base method.
+func (m *MockSessionRepository) ListActiveByUser(ctx context.Context, userID models.ID, now time.Time) ([]*models.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SoftDelete", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListActiveByUser", ctx, userID, now)
+	ret0, _ := ret[0].([]*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SoftDelete indicates an expected call of SoftDelete.
-func (mr *MockMediaRepositoryMockRecorder) SoftDelete(ctx, id interface{}) *gomock.Call {
+// ListActiveByUser indicates an expected call of ListActiveByUser.
+func (mr *MockSessionRepositoryMockRecorder) ListActiveByUser(ctx, userID, now interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockMediaRepository)(nil).SoftDelete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveByUser", reflect.TypeOf((*MockSessionRepository)(nil).ListActiveByUser), ctx, userID, now)
 }
 
-// Update mocks base method.
-func (m *MockMediaRepository) Update(ctx context.Context, media *models.Media) error {
+// Rotate mocks base method.
+func (m *MockSessionRepository) Rotate(ctx context.Context, session *models.Session, previousTokenID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, media)
+	ret := m.ctrl.Call(m, "Rotate", ctx, session, previousTokenID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockMediaRepositoryMockRecorder) Update(ctx, media interface{}) *gomock.Call {
+// Rotate indicates an expected call of Rotate.
+func (mr *MockSessionRepositoryMockRecorder) Rotate(ctx, session, previousTokenID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMediaRepository)(nil).Update), ctx, media)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockSessionRepository)(nil).Rotate), ctx, session, previousTokenID)
 }
 
-// MockAnalyticsRepository is a mock of AnalyticsRepository interface.
-type MockAnalyticsRepository struct {
+// MockEmailSuppressionRepository is a mock of EmailSuppressionRepository interface.
+type MockEmailSuppressionRepository struct {
 	ctrl     *gomock.Controller
-	recorder *MockAnalyticsRepositoryMockRecorder
+	recorder *MockEmailSuppressionRepositoryMockRecorder
 }
 
-// MockAnalyticsRepositoryMockRecorder is the mock recorder for MockAnalyticsRepository.
-type MockAnalyticsRepositoryMockRecorder struct {
-	mock *MockAnalyticsRepository
+// MockEmailSuppressionRepositoryMockRecorder is the mock recorder for MockEmailSuppressionRepository.
+type MockEmailSuppressionRepositoryMockRecorder struct {
+	mock *MockEmailSuppressionRepository
 }
 
-// NewMockAnalyticsRepository creates a new mock instance.
-func NewMockAnalyticsRepository(ctrl *gomock.Controller) *MockAnalyticsRepository {
-	mock := &MockAnalyticsRepository{ctrl: ctrl}
-	mock.recorder = &MockAnalyticsRepositoryMockRecorder{mock}
+// NewMockEmailSuppressionRepository creates a new mock instance.
+func NewMockEmailSuppressionRepository(ctrl *gomock.Controller) *MockEmailSuppressionRepository {
+	mock := &MockEmailSuppressionRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailSuppressionRepositoryMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockAnalyticsRepository) EXPECT() *MockAnalyticsRepositoryMockRecorder {
+func (m *MockEmailSuppressionRepository) EXPECT() *MockEmailSuppressionRepositoryMockRecorder {
 	return m.recorder
 }
 
-// CleanupOldAnalytics mocks base method.
-func (m *MockAnalyticsRepository) CleanupOldAnalytics(ctx context.Context, olderThan time.Time) error {
+// Delete mocks base method.
+func (m *MockEmailSuppressionRepository) Delete(ctx context.Context, email string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CleanupOldAnalytics", ctx, olderThan)
+	ret := m.ctrl.Call(m, "Delete", ctx, email)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CleanupOldAnalytics indicates an expected call of CleanupOldAnalytics.
-func (mr *MockAnalyticsRepositoryMockRecorder) CleanupOldAnalytics(ctx, olderThan interface{}) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockEmailSuppressionRepositoryMockRecorder) Delete(ctx, email interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupOldAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).CleanupOldAnalytics), ctx, olderThan)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEmailSuppressionRepository)(nil).Delete), ctx, email)
 }
 
-// GetAnalyticsSummary mocks base method.
-func (m *MockAnalyticsRepository) GetAnalyticsSummary(ctx context.Context, weddingID primitive.ObjectID, period string) (*models.AnalyticsSummary, error) {
+// Exists mocks base method.
+func (m *MockEmailSuppressionRepository) Exists(ctx context.Context, email string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAnalyticsSummary", ctx, weddingID, period)
-	ret0, _ := ret[0].(*models.AnalyticsSummary)
+	ret := m.ctrl.Call(m, "Exists", ctx, email)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAnalyticsSummary indicates an expected call of GetAnalyticsSummary.
-func (mr *MockAnalyticsRepositoryMockRecorder) GetAnalyticsSummary(ctx, weddingID, period interface{}) *gomock.Call {
+// Exists indicates an expected call of Exists.
+func (mr *MockEmailSuppressionRepositoryMockRecorder) Exists(ctx, email interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnalyticsSummary", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetAnalyticsSummary), ctx, weddingID, period)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockEmailSuppressionRepository)(nil).Exists), ctx, email)
 }
 
-// GetConversions mocks base method.
-func (m *MockAnalyticsRepository) GetConversions(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error) {
+// List mocks base method.
+func (m *MockEmailSuppressionRepository) List(ctx context.Context, search string, page, pageSize int) ([]*models.EmailSuppression, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetConversions", ctx, weddingID, filter)
-	ret0, _ := ret[0].([]*models.ConversionEvent)
+	ret := m.ctrl.Call(m, "List", ctx, search, page, pageSize)
+	ret0, _ := ret[0].([]*models.EmailSuppression)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// GetConversions indicates an expected call of GetConversions.
-func (mr *MockAnalyticsRepositoryMockRecorder) GetConversions(ctx, weddingID, filter interface{}) *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockEmailSuppressionRepositoryMockRecorder) List(ctx, search, page, pageSize interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversions", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetConversions), ctx, weddingID, filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockEmailSuppressionRepository)(nil).List), ctx, search, page, pageSize)
 }
 
-// GetDailyMetrics mocks base method.
-func (m *MockAnalyticsRepository) GetDailyMetrics(ctx context.Context, weddingID primitive.ObjectID, startDate, endDate time.Time) ([]models.DailyMetrics, error) {
+// Upsert mocks base method.
+func (m *MockEmailSuppressionRepository) Upsert(ctx context.Context, suppression *models.EmailSuppression) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDailyMetrics", ctx, weddingID, startDate, endDate)
-	ret0, _ := ret[0].([]models.DailyMetrics)
+	ret := m.ctrl.Call(m, "Upsert", ctx, suppression)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockEmailSuppressionRepositoryMockRecorder) Upsert(ctx, suppression interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockEmailSuppressionRepository)(nil).Upsert), ctx, suppression)
+}
+
+// MockAccountDeletionRepository is a mock of AccountDeletionRepository interface.
+type MockAccountDeletionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountDeletionRepositoryMockRecorder
+}
+
+// MockAccountDeletionRepositoryMockRecorder is the mock recorder for MockAccountDeletionRepository.
+type MockAccountDeletionRepositoryMockRecorder struct {
+	mock *MockAccountDeletionRepository
+}
+
+// NewMockAccountDeletionRepository creates a new mock instance.
+func NewMockAccountDeletionRepository(ctrl *gomock.Controller) *MockAccountDeletionRepository {
+	mock := &MockAccountDeletionRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountDeletionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountDeletionRepository) EXPECT() *MockAccountDeletionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AnonymizeContact mocks base method.
+func (m *MockAccountDeletionRepository) AnonymizeContact(ctx context.Context, email string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeContact", ctx, email)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDailyMetrics indicates an expected call of GetDailyMetrics.
-func (mr *MockAnalyticsRepositoryMockRecorder) GetDailyMetrics(ctx, weddingID, startDate, endDate interface{}) *gomock.Call {
+// AnonymizeContact indicates an expected call of AnonymizeContact.
+func (mr *MockAccountDeletionRepositoryMockRecorder) AnonymizeContact(ctx, email interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyMetrics", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetDailyMetrics), ctx, weddingID, startDate, endDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeContact", reflect.TypeOf((*MockAccountDeletionRepository)(nil).AnonymizeContact), ctx, email)
 }
 
-// GetPageViews mocks base method.
-func (m *MockAnalyticsRepository) GetPageViews(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error) {
+// ClearDeletion mocks base method.
+func (m *MockAccountDeletionRepository) ClearDeletion(ctx context.Context, userID models.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPageViews", ctx, weddingID, filter)
-	ret0, _ := ret[0].([]*models.PageView)
-	ret1, _ := ret[1].(int64)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "ClearDeletion", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetPageViews indicates an expected call of GetPageViews.
-func (mr *MockAnalyticsRepositoryMockRecorder) GetPageViews(ctx, weddingID, filter interface{}) *gomock.Call {
+// ClearDeletion indicates an expected call of ClearDeletion.
+func (mr *MockAccountDeletionRepositoryMockRecorder) ClearDeletion(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPageViews", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetPageViews), ctx, weddingID, filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearDeletion", reflect.TypeOf((*MockAccountDeletionRepository)(nil).ClearDeletion), ctx, userID)
 }
 
-// GetPopularPages mocks base method.
-func (m *MockAnalyticsRepository) GetPopularPages(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.PageStats, error) {
+// DeleteAccount mocks base method.
+func (m *MockAccountDeletionRepository) DeleteAccount(ctx context.Context, userID models.ID) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPopularPages", ctx, weddingID, limit)
-	ret0, _ := ret[0].([]models.PageStats)
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, userID)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPopularPages indicates an expected call of GetPopularPages.
-func (mr *MockAnalyticsRepositoryMockRecorder) GetPopularPages(ctx, weddingID, limit interface{}) *gomock.Call {
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccountDeletionRepositoryMockRecorder) DeleteAccount(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopularPages", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetPopularPages), ctx, weddingID, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccountDeletionRepository)(nil).DeleteAccount), ctx, userID)
 }
 
-// GetRSVPAnalytics mocks base method.
-func (m *MockAnalyticsRepository) GetRSVPAnalytics(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error) {
+// DeleteWeddingData mocks base method.
+func (m *MockAccountDeletionRepository) DeleteWeddingData(ctx context.Context, weddingID models.ID) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRSVPAnalytics", ctx, weddingID, filter)
-	ret0, _ := ret[0].([]*models.RSVPAnalytics)
-	ret1, _ := ret[1].(int64)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "DeleteWeddingData", ctx, weddingID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetRSVPAnalytics indicates an expected call of GetRSVPAnalytics.
-func (mr *MockAnalyticsRepositoryMockRecorder) GetRSVPAnalytics(ctx, weddingID, filter interface{}) *gomock.Call {
+// DeleteWeddingData indicates an expected call of DeleteWeddingData.
+func (mr *MockAccountDeletionRepositoryMockRecorder) DeleteWeddingData(ctx, weddingID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRSVPAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetRSVPAnalytics), ctx, weddingID, filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWeddingData", reflect.TypeOf((*MockAccountDeletionRepository)(nil).DeleteWeddingData), ctx, weddingID)
 }
 
-// GetSystemAnalytics mocks base method.
-func (m *MockAnalyticsRepository) GetSystemAnalytics(ctx context.Context) (*models.SystemAnalytics, error) {
+// GetByDeletionToken mocks base method.
+func (m *MockAccountDeletionRepository) GetByDeletionToken(ctx context.Context, tokenHash string) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSystemAnalytics", ctx)
-	ret0, _ := ret[0].(*models.SystemAnalytics)
+	ret := m.ctrl.Call(m, "GetByDeletionToken", ctx, tokenHash)
+	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSystemAnalytics indicates an expected call of GetSystemAnalytics.
-func (mr *MockAnalyticsRepositoryMockRecorder) GetSystemAnalytics(ctx interface{}) *gomock.Call {
+// GetByDeletionToken indicates an expected call of GetByDeletionToken.
+func (mr *MockAccountDeletionRepositoryMockRecorder) GetByDeletionToken(ctx, tokenHash interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetSystemAnalytics), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDeletionToken", reflect.TypeOf((*MockAccountDeletionRepository)(nil).GetByDeletionToken), ctx, tokenHash)
 }
 
-// GetTrafficSources mocks base method.
-func (m *MockAnalyticsRepository) GetTrafficSources(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.TrafficSourceStats, error) {
+// ListDue mocks base method.
+func (m *MockAccountDeletionRepository) ListDue(ctx context.Context, before time.Time, limit int) ([]*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTrafficSources", ctx, weddingID, limit)
-	ret0, _ := ret[0].([]models.TrafficSourceStats)
+	ret := m.ctrl.Call(m, "ListDue", ctx, before, limit)
+	ret0, _ := ret[0].([]*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTrafficSources indicates an expected call of GetTrafficSources.
-func (mr *MockAnalyticsRepositoryMockRecorder) GetTrafficSources(ctx, weddingID, limit interface{}) *gomock.Call {
+// ListDue indicates an expected call of ListDue.
+func (mr *MockAccountDeletionRepositoryMockRecorder) ListDue(ctx, before, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrafficSources", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetTrafficSources), ctx, weddingID, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDue", reflect.TypeOf((*MockAccountDeletionRepository)(nil).ListDue), ctx, before, limit)
 }
 
-// GetWeddingAnalytics mocks base method.
-func (m *MockAnalyticsRepository) GetWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) (*models.WeddingAnalytics, error) {
+// OwnedWeddingIDs mocks base method.
+func (m *MockAccountDeletionRepository) OwnedWeddingIDs(ctx context.Context, userID models.ID) ([]models.ID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWeddingAnalytics", ctx, weddingID)
-	ret0, _ := ret[0].(*models.WeddingAnalytics)
+	ret := m.ctrl.Call(m, "OwnedWeddingIDs", ctx, userID)
+	ret0, _ := ret[0].([]models.ID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetWeddingAnalytics indicates an expected call of GetWeddingAnalytics.
-func (mr *MockAnalyticsRepositoryMockRecorder) GetWeddingAnalytics(ctx, weddingID interface{}) *gomock.Call {
+// OwnedWeddingIDs indicates an expected call of OwnedWeddingIDs.
+func (mr *MockAccountDeletionRepositoryMockRecorder) OwnedWeddingIDs(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeddingAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetWeddingAnalytics), ctx, weddingID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnedWeddingIDs", reflect.TypeOf((*MockAccountDeletionRepository)(nil).OwnedWeddingIDs), ctx, userID)
 }
 
-// RefreshSystemAnalytics mocks base method.
-func (m *MockAnalyticsRepository) RefreshSystemAnalytics(ctx context.Context) error {
+// RemoveCollaborator mocks base method.
+func (m *MockAccountDeletionRepository) RemoveCollaborator(ctx context.Context, userID models.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RefreshSystemAnalytics", ctx)
+	ret := m.ctrl.Call(m, "RemoveCollaborator", ctx, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RefreshSystemAnalytics indicates an expected call of RefreshSystemAnalytics.
-func (mr *MockAnalyticsRepositoryMockRecorder) RefreshSystemAnalytics(ctx interface{}) *gomock.Call {
+// RemoveCollaborator indicates an expected call of RemoveCollaborator.
+func (mr *MockAccountDeletionRepositoryMockRecorder) RemoveCollaborator(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSystemAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).RefreshSystemAnalytics), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCollaborator", reflect.TypeOf((*MockAccountDeletionRepository)(nil).RemoveCollaborator), ctx, userID)
 }
 
-// RefreshWeddingAnalytics mocks base method.
-func (m *MockAnalyticsRepository) RefreshWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) error {
+// SetDeletion mocks base method.
+func (m *MockAccountDeletionRepository) SetDeletion(ctx context.Context, userID models.ID, deletion *models.AccountDeletion) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RefreshWeddingAnalytics", ctx, weddingID)
+	ret := m.ctrl.Call(m, "SetDeletion", ctx, userID, deletion)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RefreshWeddingAnalytics indicates an expected call of RefreshWeddingAnalytics.
-func (mr *MockAnalyticsRepositoryMockRecorder) RefreshWeddingAnalytics(ctx, weddingID interface{}) *gomock.Call {
+// SetDeletion indicates an expected call of SetDeletion.
+func (mr *MockAccountDeletionRepositoryMockRecorder) SetDeletion(ctx, userID, deletion interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshWeddingAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).RefreshWeddingAnalytics), ctx, weddingID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeletion", reflect.TypeOf((*MockAccountDeletionRepository)(nil).SetDeletion), ctx, userID, deletion)
 }
 
-// TrackConversion mocks base method.
-func (m *MockAnalyticsRepository) TrackConversion(ctx context.Context, event *models.ConversionEvent) error {
+// MockUploadSessionRepository is a mock of UploadSessionRepository interface.
+type MockUploadSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadSessionRepositoryMockRecorder
+}
+
+// MockUploadSessionRepositoryMockRecorder is the mock recorder for MockUploadSessionRepository.
+type MockUploadSessionRepositoryMockRecorder struct {
+	mock *MockUploadSessionRepository
+}
+
+// NewMockUploadSessionRepository creates a new mock instance.
+func NewMockUploadSessionRepository(ctrl *gomock.Controller) *MockUploadSessionRepository {
+	mock := &MockUploadSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockUploadSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadSessionRepository) EXPECT() *MockUploadSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Advance mocks base method.
+func (m *MockUploadSessionRepository) Advance(ctx context.Context, id models.ID, from, to int64, expiresAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TrackConversion", ctx, event)
+	ret := m.ctrl.Call(m, "Advance", ctx, id, from, to, expiresAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// TrackConversion indicates an expected call of TrackConversion.
-func (mr *MockAnalyticsRepositoryMockRecorder) TrackConversion(ctx, event interface{}) *gomock.Call {
+// Advance indicates an expected call of Advance.
+func (mr *MockUploadSessionRepositoryMockRecorder) Advance(ctx, id, from, to, expiresAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackConversion", reflect.TypeOf((*MockAnalyticsRepository)(nil).TrackConversion), ctx, event)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Advance", reflect.TypeOf((*MockUploadSessionRepository)(nil).Advance), ctx, id, from, to, expiresAt)
 }
 
-// TrackPageView mocks base method.
-func (m *MockAnalyticsRepository) TrackPageView(ctx context.Context, pageView *models.PageView) error {
+// Create mocks base method.
+func (m *MockUploadSessionRepository) Create(ctx context.Context, session *models.UploadSession) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TrackPageView", ctx, pageView)
+	ret := m.ctrl.Call(m, "Create", ctx, session)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// TrackPageView indicates an expected call of TrackPageView.
-func (mr *MockAnalyticsRepositoryMockRecorder) TrackPageView(ctx, pageView interface{}) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockUploadSessionRepositoryMockRecorder) Create(ctx, session interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackPageView", reflect.TypeOf((*MockAnalyticsRepository)(nil).TrackPageView), ctx, pageView)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUploadSessionRepository)(nil).Create), ctx, session)
 }
 
-// TrackRSVPEvent mocks base method.
-func (m *MockAnalyticsRepository) TrackRSVPEvent(ctx context.Context, event *models.RSVPAnalytics) error {
+// Delete mocks base method.
+func (m *MockUploadSessionRepository) Delete(ctx context.Context, id models.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TrackRSVPEvent", ctx, event)
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// TrackRSVPEvent indicates an expected call of TrackRSVPEvent.
-func (mr *MockAnalyticsRepositoryMockRecorder) TrackRSVPEvent(ctx, event interface{}) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockUploadSessionRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackRSVPEvent", reflect.TypeOf((*MockAnalyticsRepository)(nil).TrackRSVPEvent), ctx, event)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUploadSessionRepository)(nil).Delete), ctx, id)
 }
 
-// UpdateSystemAnalytics mocks base method.
-func (m *MockAnalyticsRepository) UpdateSystemAnalytics(ctx context.Context) error {
+// GetByID mocks base method.
+func (m *MockUploadSessionRepository) GetByID(ctx context.Context, id models.ID) (*models.UploadSession, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSystemAnalytics", ctx)
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.UploadSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUploadSessionRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUploadSessionRepository)(nil).GetByID), ctx, id)
+}
+
+// ListExpired mocks base method.
+func (m *MockUploadSessionRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*models.UploadSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpired", ctx, now, limit)
+	ret0, _ := ret[0].([]*models.UploadSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpired indicates an expected call of ListExpired.
+func (mr *MockUploadSessionRepositoryMockRecorder) ListExpired(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpired", reflect.TypeOf((*MockUploadSessionRepository)(nil).ListExpired), ctx, now, limit)
+}
+
+// MockOrganizationRepository is a mock of OrganizationRepository interface.
+type MockOrganizationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationRepositoryMockRecorder
+}
+
+// MockOrganizationRepositoryMockRecorder is the mock recorder for MockOrganizationRepository.
+type MockOrganizationRepositoryMockRecorder struct {
+	mock *MockOrganizationRepository
+}
+
+// NewMockOrganizationRepository creates a new mock instance.
+func NewMockOrganizationRepository(ctrl *gomock.Controller) *MockOrganizationRepository {
+	mock := &MockOrganizationRepository{ctrl: ctrl}
+	mock.recorder = &MockOrganizationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationRepository) EXPECT() *MockOrganizationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrganizationRepository) Create(ctx context.Context, org *models.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, org)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateSystemAnalytics indicates an expected call of UpdateSystemAnalytics.
-func (mr *MockAnalyticsRepositoryMockRecorder) UpdateSystemAnalytics(ctx interface{}) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationRepositoryMockRecorder) Create(ctx, org interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSystemAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).UpdateSystemAnalytics), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganizationRepository)(nil).Create), ctx, org)
 }
 
-// UpdateWeddingAnalytics mocks base method.
-func (m *MockAnalyticsRepository) UpdateWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) error {
+// Delete mocks base method.
+func (m *MockOrganizationRepository) Delete(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateWeddingAnalytics", ctx, weddingID)
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateWeddingAnalytics indicates an expected call of UpdateWeddingAnalytics.
-func (mr *MockAnalyticsRepositoryMockRecorder) UpdateWeddingAnalytics(ctx, weddingID interface{}) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockOrganizationRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWeddingAnalytics", reflect.TypeOf((*MockAnalyticsRepository)(nil).UpdateWeddingAnalytics), ctx, weddingID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrganizationRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockOrganizationRepository) GetByID(ctx context.Context, id string) (*models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockOrganizationRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockOrganizationRepository)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockOrganizationRepository) List(ctx context.Context, page, pageSize int) ([]*models.Organization, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, page, pageSize)
+	ret0, _ := ret[0].([]*models.Organization)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockOrganizationRepositoryMockRecorder) List(ctx, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOrganizationRepository)(nil).List), ctx, page, pageSize)
+}
+
+// Update mocks base method.
+func (m *MockOrganizationRepository) Update(ctx context.Context, org *models.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, org)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockOrganizationRepositoryMockRecorder) Update(ctx, org interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrganizationRepository)(nil).Update), ctx, org)
 }
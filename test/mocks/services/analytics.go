@@ -10,9 +10,9 @@ import (
 	reflect "reflect"
 	time "time"
 	models "wedding-invitation-backend/internal/domain/models"
+	services "wedding-invitation-backend/internal/services"
 
 	gomock "github.com/golang/mock/gomock"
-	primitive "go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // MockAnalyticsService is a mock of AnalyticsService interface.
@@ -53,7 +53,7 @@ func (mr *MockAnalyticsServiceMockRecorder) CleanupOldAnalytics(ctx, olderThan i
 }
 
 // GetAnalyticsSummary mocks base method.
-func (m *MockAnalyticsService) GetAnalyticsSummary(ctx context.Context, weddingID primitive.ObjectID, period string) (*models.AnalyticsSummary, error) {
+func (m *MockAnalyticsService) GetAnalyticsSummary(ctx context.Context, weddingID models.ID, period string) (*models.AnalyticsSummary, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAnalyticsSummary", ctx, weddingID, period)
 	ret0, _ := ret[0].(*models.AnalyticsSummary)
@@ -68,7 +68,7 @@ func (mr *MockAnalyticsServiceMockRecorder) GetAnalyticsSummary(ctx, weddingID,
 }
 
 // GetConversions mocks base method.
-func (m *MockAnalyticsService) GetConversions(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error) {
+func (m *MockAnalyticsService) GetConversions(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.ConversionEvent, int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetConversions", ctx, weddingID, filter)
 	ret0, _ := ret[0].([]*models.ConversionEvent)
@@ -84,7 +84,7 @@ func (mr *MockAnalyticsServiceMockRecorder) GetConversions(ctx, weddingID, filte
 }
 
 // GetDailyMetrics mocks base method.
-func (m *MockAnalyticsService) GetDailyMetrics(ctx context.Context, weddingID primitive.ObjectID, startDate, endDate time.Time) ([]models.DailyMetrics, error) {
+func (m *MockAnalyticsService) GetDailyMetrics(ctx context.Context, weddingID models.ID, startDate, endDate time.Time) ([]models.DailyMetrics, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetDailyMetrics", ctx, weddingID, startDate, endDate)
 	ret0, _ := ret[0].([]models.DailyMetrics)
@@ -98,8 +98,23 @@ func (mr *MockAnalyticsServiceMockRecorder) GetDailyMetrics(ctx, weddingID, star
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyMetrics", reflect.TypeOf((*MockAnalyticsService)(nil).GetDailyMetrics), ctx, weddingID, startDate, endDate)
 }
 
+// GetGeoBreakdown mocks base method.
+func (m *MockAnalyticsService) GetGeoBreakdown(ctx context.Context, weddingID models.ID, limit int) ([]models.CountryStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeoBreakdown", ctx, weddingID, limit)
+	ret0, _ := ret[0].([]models.CountryStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeoBreakdown indicates an expected call of GetGeoBreakdown.
+func (mr *MockAnalyticsServiceMockRecorder) GetGeoBreakdown(ctx, weddingID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeoBreakdown", reflect.TypeOf((*MockAnalyticsService)(nil).GetGeoBreakdown), ctx, weddingID, limit)
+}
+
 // GetPageViews mocks base method.
-func (m *MockAnalyticsService) GetPageViews(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error) {
+func (m *MockAnalyticsService) GetPageViews(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.PageView, int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPageViews", ctx, weddingID, filter)
 	ret0, _ := ret[0].([]*models.PageView)
@@ -115,7 +130,7 @@ func (mr *MockAnalyticsServiceMockRecorder) GetPageViews(ctx, weddingID, filter
 }
 
 // GetPopularPages mocks base method.
-func (m *MockAnalyticsService) GetPopularPages(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.PageStats, error) {
+func (m *MockAnalyticsService) GetPopularPages(ctx context.Context, weddingID models.ID, limit int) ([]models.PageStats, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPopularPages", ctx, weddingID, limit)
 	ret0, _ := ret[0].([]models.PageStats)
@@ -130,7 +145,7 @@ func (mr *MockAnalyticsServiceMockRecorder) GetPopularPages(ctx, weddingID, limi
 }
 
 // GetRSVPAnalytics mocks base method.
-func (m *MockAnalyticsService) GetRSVPAnalytics(ctx context.Context, weddingID primitive.ObjectID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error) {
+func (m *MockAnalyticsService) GetRSVPAnalytics(ctx context.Context, weddingID models.ID, filter *models.AnalyticsFilter) ([]*models.RSVPAnalytics, int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetRSVPAnalytics", ctx, weddingID, filter)
 	ret0, _ := ret[0].([]*models.RSVPAnalytics)
@@ -145,6 +160,36 @@ func (mr *MockAnalyticsServiceMockRecorder) GetRSVPAnalytics(ctx, weddingID, fil
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRSVPAnalytics", reflect.TypeOf((*MockAnalyticsService)(nil).GetRSVPAnalytics), ctx, weddingID, filter)
 }
 
+// GetRSVPFunnel mocks base method.
+func (m *MockAnalyticsService) GetRSVPFunnel(ctx context.Context, weddingID models.ID, startDate, endDate time.Time) (*models.RSVPFunnel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRSVPFunnel", ctx, weddingID, startDate, endDate)
+	ret0, _ := ret[0].(*models.RSVPFunnel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRSVPFunnel indicates an expected call of GetRSVPFunnel.
+func (mr *MockAnalyticsServiceMockRecorder) GetRSVPFunnel(ctx, weddingID, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRSVPFunnel", reflect.TypeOf((*MockAnalyticsService)(nil).GetRSVPFunnel), ctx, weddingID, startDate, endDate)
+}
+
+// GetRequestTrace mocks base method.
+func (m *MockAnalyticsService) GetRequestTrace(ctx context.Context, requestID string) (*models.RequestTrace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRequestTrace", ctx, requestID)
+	ret0, _ := ret[0].(*models.RequestTrace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRequestTrace indicates an expected call of GetRequestTrace.
+func (mr *MockAnalyticsServiceMockRecorder) GetRequestTrace(ctx, requestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRequestTrace", reflect.TypeOf((*MockAnalyticsService)(nil).GetRequestTrace), ctx, requestID)
+}
+
 // GetSystemAnalytics mocks base method.
 func (m *MockAnalyticsService) GetSystemAnalytics(ctx context.Context) (*models.SystemAnalytics, error) {
 	m.ctrl.T.Helper()
@@ -161,7 +206,7 @@ func (mr *MockAnalyticsServiceMockRecorder) GetSystemAnalytics(ctx interface{})
 }
 
 // GetTrafficSources mocks base method.
-func (m *MockAnalyticsService) GetTrafficSources(ctx context.Context, weddingID primitive.ObjectID, limit int) ([]models.TrafficSourceStats, error) {
+func (m *MockAnalyticsService) GetTrafficSources(ctx context.Context, weddingID models.ID, limit int) ([]models.TrafficSourceStats, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetTrafficSources", ctx, weddingID, limit)
 	ret0, _ := ret[0].([]models.TrafficSourceStats)
@@ -175,8 +220,23 @@ func (mr *MockAnalyticsServiceMockRecorder) GetTrafficSources(ctx, weddingID, li
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrafficSources", reflect.TypeOf((*MockAnalyticsService)(nil).GetTrafficSources), ctx, weddingID, limit)
 }
 
+// GetUserOverview mocks base method.
+func (m *MockAnalyticsService) GetUserOverview(ctx context.Context, userID models.ID) (*models.UserAnalyticsOverview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOverview", ctx, userID)
+	ret0, _ := ret[0].(*models.UserAnalyticsOverview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOverview indicates an expected call of GetUserOverview.
+func (mr *MockAnalyticsServiceMockRecorder) GetUserOverview(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOverview", reflect.TypeOf((*MockAnalyticsService)(nil).GetUserOverview), ctx, userID)
+}
+
 // GetWeddingAnalytics mocks base method.
-func (m *MockAnalyticsService) GetWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) (*models.WeddingAnalytics, error) {
+func (m *MockAnalyticsService) GetWeddingAnalytics(ctx context.Context, weddingID models.ID) (*models.WeddingAnalytics, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetWeddingAnalytics", ctx, weddingID)
 	ret0, _ := ret[0].(*models.WeddingAnalytics)
@@ -233,7 +293,7 @@ func (mr *MockAnalyticsServiceMockRecorder) RefreshSystemAnalytics(ctx interface
 }
 
 // RefreshWeddingAnalytics mocks base method.
-func (m *MockAnalyticsService) RefreshWeddingAnalytics(ctx context.Context, weddingID primitive.ObjectID) error {
+func (m *MockAnalyticsService) RefreshWeddingAnalytics(ctx context.Context, weddingID models.ID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RefreshWeddingAnalytics", ctx, weddingID)
 	ret0, _ := ret[0].(error)
@@ -260,8 +320,37 @@ func (mr *MockAnalyticsServiceMockRecorder) SanitizeCustomData(data interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SanitizeCustomData", reflect.TypeOf((*MockAnalyticsService)(nil).SanitizeCustomData), data)
 }
 
+// SubscribeLive mocks base method.
+func (m *MockAnalyticsService) SubscribeLive(weddingID models.ID) (<-chan *models.AnalyticsLiveEvent, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeLive", weddingID)
+	ret0, _ := ret[0].(<-chan *models.AnalyticsLiveEvent)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// SubscribeLive indicates an expected call of SubscribeLive.
+func (mr *MockAnalyticsServiceMockRecorder) SubscribeLive(weddingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeLive", reflect.TypeOf((*MockAnalyticsService)(nil).SubscribeLive), weddingID)
+}
+
+// TrackBatch mocks base method.
+func (m *MockAnalyticsService) TrackBatch(ctx context.Context, events []services.TrackBatchEvent, req *http.Request) []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackBatch", ctx, events, req)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// TrackBatch indicates an expected call of TrackBatch.
+func (mr *MockAnalyticsServiceMockRecorder) TrackBatch(ctx, events, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackBatch", reflect.TypeOf((*MockAnalyticsService)(nil).TrackBatch), ctx, events, req)
+}
+
 // TrackConversion mocks base method.
-func (m *MockAnalyticsService) TrackConversion(ctx context.Context, weddingID primitive.ObjectID, sessionID, event string, value float64, properties map[string]interface{}) error {
+func (m *MockAnalyticsService) TrackConversion(ctx context.Context, weddingID models.ID, sessionID, event string, value float64, properties map[string]interface{}) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "TrackConversion", ctx, weddingID, sessionID, event, value, properties)
 	ret0, _ := ret[0].(error)
@@ -274,8 +363,22 @@ func (mr *MockAnalyticsServiceMockRecorder) TrackConversion(ctx, weddingID, sess
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackConversion", reflect.TypeOf((*MockAnalyticsService)(nil).TrackConversion), ctx, weddingID, sessionID, event, value, properties)
 }
 
+// TrackPageDuration mocks base method.
+func (m *MockAnalyticsService) TrackPageDuration(ctx context.Context, weddingID models.ID, sessionID, page string, duration int64, req *http.Request) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackPageDuration", ctx, weddingID, sessionID, page, duration, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackPageDuration indicates an expected call of TrackPageDuration.
+func (mr *MockAnalyticsServiceMockRecorder) TrackPageDuration(ctx, weddingID, sessionID, page, duration, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackPageDuration", reflect.TypeOf((*MockAnalyticsService)(nil).TrackPageDuration), ctx, weddingID, sessionID, page, duration, req)
+}
+
 // TrackPageView mocks base method.
-func (m *MockAnalyticsService) TrackPageView(ctx context.Context, weddingID primitive.ObjectID, sessionID, page string, req *http.Request) error {
+func (m *MockAnalyticsService) TrackPageView(ctx context.Context, weddingID models.ID, sessionID, page string, req *http.Request) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "TrackPageView", ctx, weddingID, sessionID, page, req)
 	ret0, _ := ret[0].(error)
@@ -289,7 +392,7 @@ func (mr *MockAnalyticsServiceMockRecorder) TrackPageView(ctx, weddingID, sessio
 }
 
 // TrackRSVPAbandonment mocks base method.
-func (m *MockAnalyticsService) TrackRSVPAbandonment(ctx context.Context, weddingID primitive.ObjectID, sessionID, abandonedStep string, formErrors []string, req *http.Request) error {
+func (m *MockAnalyticsService) TrackRSVPAbandonment(ctx context.Context, weddingID models.ID, sessionID, abandonedStep string, formErrors []string, req *http.Request) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "TrackRSVPAbandonment", ctx, weddingID, sessionID, abandonedStep, formErrors, req)
 	ret0, _ := ret[0].(error)
@@ -303,7 +406,7 @@ func (mr *MockAnalyticsServiceMockRecorder) TrackRSVPAbandonment(ctx, weddingID,
 }
 
 // TrackRSVPSubmission mocks base method.
-func (m *MockAnalyticsService) TrackRSVPSubmission(ctx context.Context, weddingID, rsvpID primitive.ObjectID, sessionID, source string, timeToComplete int64, req *http.Request) error {
+func (m *MockAnalyticsService) TrackRSVPSubmission(ctx context.Context, weddingID, rsvpID models.ID, sessionID, source string, timeToComplete int64, req *http.Request) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "TrackRSVPSubmission", ctx, weddingID, rsvpID, sessionID, source, timeToComplete, req)
 	ret0, _ := ret[0].(error)